@@ -6,13 +6,16 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"auralogic/internal/config"
 	"auralogic/internal/database"
 	adminHandler "auralogic/internal/handler/admin"
 	"auralogic/internal/jsworker"
 	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/fieldcrypt"
 	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/tracing"
 	"auralogic/internal/repository"
 	"auralogic/internal/router"
 	"auralogic/internal/service"
@@ -24,15 +27,22 @@ import (
 var GitCommit = ""
 
 func main() {
-	// 同一后端二进制双模式运行：
+	// 同一后端二进制多模式运行：
 	// 1) 默认 API 服务模式
 	// 2) --js-worker 子进程模式（供插件管理器拉起）
+	// 3) --encrypt-virtual-stock 一次性加密历史明文卡密
 	if len(os.Args) > 1 && strings.EqualFold(strings.TrimSpace(os.Args[1]), "--js-worker") {
 		if err := jsworker.Run(os.Args[2:]); err != nil {
 			log.Fatalf("JS worker mode failed: %v", err)
 		}
 		return
 	}
+	if len(os.Args) > 1 && strings.EqualFold(strings.TrimSpace(os.Args[1]), encryptVirtualStockFlag) {
+		if err := runEncryptVirtualStock(); err != nil {
+			log.Fatalf("Virtual stock encryption failed: %v", err)
+		}
+		return
+	}
 
 	if GitCommit == "" {
 		GitCommit = "dev"
@@ -53,6 +63,29 @@ func main() {
 	defer config.CloseLogger()
 	log.Printf("Logger initialized (level=%s, format=%s, output=%s)", cfg.Log.Level, cfg.Log.Format, cfg.Log.Output)
 
+	// 初始化链路追踪（tracing.enabled 为 false 时不记录任何 span）
+	if err := tracing.Init(&cfg.Tracing); err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracing.Shutdown(ctx); err != nil {
+			log.Printf("Failed to flush traces: %v", err)
+		}
+	}()
+	if cfg.Tracing.Enabled {
+		log.Printf("Tracing enabled (endpoint=%s, sample_ratio=%g)", cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+	}
+
+	// 初始化敏感数据加密密钥（迁移中按密钥重算卡密内容哈希，需在数据库迁移之前）
+	if err := fieldcrypt.Init(&cfg.Security.DataEncryption); err != nil {
+		log.Fatalf("Failed to initialize data encryption: %v", err)
+	}
+	if !fieldcrypt.Enabled() {
+		log.Println("Warning: data encryption key is not configured, virtual stock content will be stored in plaintext")
+	}
+
 	// 初始化数据库
 	if err := database.InitDatabase(&cfg.Database); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -109,6 +142,12 @@ func main() {
 	serialService.SetPluginManager(pluginManagerService)
 	orderService.SetPluginManager(pluginManagerService)
 	orderService.SetSerialGenerationService(serialGenerationService)
+	flashSaleService := service.NewFlashSaleService(db, cfg)
+	orderService.SetFlashSaleService(flashSaleService)
+	giftCardService := service.NewGiftCardService(db, cfg)
+	orderService.SetGiftCardService(giftCardService)
+	carrierTrackingService := service.NewCarrierTrackingService(db, cfg)
+	orderService.SetCarrierTrackingService(carrierTrackingService)
 
 	// 启动邮件队列处理（如果启用）
 	emailService.Start()
@@ -117,10 +156,16 @@ func main() {
 		log.Println("Email service started")
 	}
 
-	smsDelayedCtx, smsDelayedCancel := context.WithCancel(context.Background())
-	defer smsDelayedCancel()
-	go smsService.ProcessDelayedSMS(smsDelayedCtx)
-	log.Println("SMS delayed worker started")
+	// 周期任务调度器：分布式锁 + 共享执行记录，多实例部署时每个周期只由一个实例执行
+	jobScheduler := service.NewJobScheduler(db, nil)
+	smsService.RegisterJobs(jobScheduler)
+
+	// 注册物流单号登记任务（配置启用时）
+	carrierTrackingService.RegisterJobs(jobScheduler)
+
+	// 注册操作日志外部归档任务（配置启用时）
+	auditExportService := service.NewAuditExportService(db, cfg)
+	auditExportService.RegisterJobs(jobScheduler)
 
 	marketingService.Start()
 	defer marketingService.Stop()
@@ -142,12 +187,25 @@ func main() {
 	paymentPollingService.Start()
 	defer paymentPollingService.Stop()
 
-	// 启动订单自动取消服务
+	// 注册秒杀库存写回任务；退出时在调度器停止后再写回一次剩余预留
+	flashSaleService.RegisterJobs(jobScheduler)
+	defer flashSaleService.Flush()
+
+	// 注册订单自动取消任务
 	orderCancelService := service.NewOrderCancelService(db, cfg, inventoryRepo, promoCodeRepo, virtualInventoryService, serialService)
 	orderCancelService.SetPluginManager(pluginManagerService)
-	orderCancelService.Start()
-	defer orderCancelService.Stop()
-	log.Println("Order auto-cancel service started")
+	orderCancelService.SetFlashSaleService(flashSaleService)
+	orderCancelService.SetGiftCardService(giftCardService)
+	orderCancelService.RegisterJobs(jobScheduler)
+
+	// 注册未完成订单挽回提醒任务（配置启用时）
+	service.NewOrderRecoveryService(db, cfg, emailService, smsService, service.NewPaymentLinkService(db, cfg)).RegisterJobs(jobScheduler)
+
+	// 注册订单自动完成任务
+	orderAutoCompleteService := service.NewOrderAutoCompleteService(db, cfg, promoCodeRepo, emailService)
+	orderAutoCompleteService.SetPluginManager(pluginManagerService)
+	orderAutoCompleteService.SetGiftCardService(giftCardService)
+	orderAutoCompleteService.RegisterJobs(jobScheduler)
 
 	// 启动工单附件自动清理服务
 	ticketAttachmentCleanupService := service.NewTicketAttachmentCleanupService(db, cfg)
@@ -155,15 +213,70 @@ func main() {
 	defer ticketAttachmentCleanupService.Stop()
 	log.Println("Ticket attachment cleanup service started")
 
-	// 启动工单超时自动关闭服务
+	// 注册未发送的工单消息附件清理任务
+	service.NewTicketAttachmentService(db, cfg).RegisterJobs(jobScheduler)
+
+	// 注册工单超时自动关闭任务
 	ticketAutoCloseService := service.NewTicketAutoCloseService(db, cfg)
 	ticketAutoCloseService.SetPluginManager(pluginManagerService)
-	ticketAutoCloseService.Start()
-	defer ticketAutoCloseService.Stop()
-	log.Println("Ticket auto-close service started")
+	ticketAutoCloseService.RegisterJobs(jobScheduler)
+
+	// 注册发货脚本存储过期清理任务
+	virtualInventoryService.RegisterJobs(jobScheduler)
+
+	// 注册回收站到期清理任务
+	trashService := service.NewTrashService(db, cfg, productService)
+	trashService.RegisterJobs(jobScheduler)
+
+	// 注册用量超额计费任务
+	usageMeterService := service.NewUsageMeterService(db, cfg, bindingService, emailService)
+	usageMeterService.SetOrderService(orderService)
+	usageMeterService.RegisterJobs(jobScheduler)
+
+	// 注册商品试用到期提醒与转正任务
+	productTrialService := service.NewProductTrialService(db, cfg, emailService)
+	productTrialService.SetOrderService(orderService)
+	productTrialService.RegisterJobs(jobScheduler)
+
+	// 注册虚拟库存自动补货任务
+	restockService := service.NewVirtualInventoryRestockService(db, cfg, virtualInventoryService, emailService)
+	restockService.RegisterJobs(jobScheduler)
+
+	// 注册每日库存快照任务
+	service.NewInventorySnapshotService(db).RegisterJobs(jobScheduler)
+
+	// 注册低库存告警任务
+	service.NewInventoryStockAlertService(db, cfg, emailService).RegisterJobs(jobScheduler)
+
+	// 注册客服绩效每日聚合任务
+	service.NewTicketAgentStatsService(db).RegisterJobs(jobScheduler)
+
+	// 注册邮箱验证提醒任务
+	service.NewEmailVerificationReminderService(db, cfg, emailService).RegisterJobs(jobScheduler)
+
+	// 注册账期发票催收任务
+	service.NewNetTermsDunningService(db, cfg, emailService).RegisterJobs(jobScheduler)
+
+	// 注册 SKU 销售每日聚合任务
+	service.NewSKUSalesStatsService(db).RegisterJobs(jobScheduler)
+
+	// 注册每日库存对账任务
+	service.NewStockReconciliationService(db, cfg, emailService).RegisterJobs(jobScheduler)
+
+	// 注册会计系统每日推送任务
+	service.NewAccountingExportService(db, cfg).RegisterJobs(jobScheduler)
+
+	// 注册限量发售等候室放行任务
+	waitingRoomService := service.NewWaitingRoomService(db, cfg)
+	waitingRoomService.RegisterJobs(jobScheduler)
+
+	// 所有任务注册完成后启动调度器，退出时最先停止
+	jobScheduler.Start()
+	defer jobScheduler.Stop()
+	log.Printf("Job scheduler started (instance %s)", jobScheduler.InstanceID())
 
 	// 设置路由
-	r := router.SetupRouter(cfg, authService, orderService, productService, emailService, userRepo, db, paymentPollingService, pluginManagerService, GitCommit)
+	r := router.SetupRouter(cfg, authService, orderService, productService, emailService, userRepo, db, paymentPollingService, pluginManagerService, waitingRoomService, jobScheduler, GitCommit)
 
 	// 启动服务器
 	addr := fmt.Sprintf(":%d", cfg.App.Port)
@@ -174,3 +287,32 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// encryptVirtualStockFlag 一次性加密历史明文卡密：./bin/api --encrypt-virtual-stock
+const encryptVirtualStockFlag = "--encrypt-virtual-stock"
+
+// runEncryptVirtualStock 使用与 API 服务相同的配置和密钥加密库存中的明文卡密
+// 可在服务运行期间执行，也可重复执行；未配置密钥时直接失败，避免误以为已加密
+func runEncryptVirtualStock() error {
+	cfg, err := config.LoadConfig(config.GetConfigPath())
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := fieldcrypt.Init(&cfg.Security.DataEncryption); err != nil {
+		return fmt.Errorf("initialize data encryption: %w", err)
+	}
+	if !fieldcrypt.Enabled() {
+		return fmt.Errorf("data encryption key is not configured, set %s or security.data_encryption", fieldcrypt.KeyEnv)
+	}
+	if err := database.InitDatabase(&cfg.Database); err != nil {
+		return fmt.Errorf("initialize database: %w", err)
+	}
+	defer database.Close()
+
+	encrypted, err := service.EncryptPlaintextVirtualStocks(database.GetDB(), 500)
+	if err != nil {
+		return fmt.Errorf("encrypt virtual stock after %d rows: %w", encrypted, err)
+	}
+	log.Printf("Encrypted %d plaintext virtual stock items", encrypted)
+	return nil
+}
@@ -30,18 +30,26 @@ type Config struct {
 	EmailRateLimit     MessageRateLimit         `json:"email_rate_limit"`
 	SMSRateLimit       MessageRateLimit         `json:"sms_rate_limit"`
 	Log                LogConfig                `json:"log"`
+	Tracing            TracingConfig            `json:"tracing"`
 	Order              OrderConfig              `json:"order"`
 	MagicLink          MagicLinkConfig          `json:"magic_link"`
 	Form               FormConfig               `json:"form"`
 	Upload             UploadConfig             `json:"upload"`
+	Storage            ObjectStorageConfig      `json:"storage"`
 	Ticket             TicketConfig             `json:"ticket"`
 	Serial             SerialConfig             `json:"serial"`
 	Customization      CustomizationConfig      `json:"customization"`
 	EmailNotifications EmailNotificationsConfig `json:"email_notifications"`
 	Analytics          AnalyticsConfig          `json:"analytics"`
+	Trash              TrashConfig              `json:"trash"`
 	Plugin             PluginPlatformConfig     `json:"plugin"`
 }
 
+// TrashConfig 回收站配置：商品、优惠码、虚拟库存删除后进入回收站，保留期内可恢复，到期后定时彻底删除
+type TrashConfig struct {
+	RetentionDays int `json:"retention_days"` // 保留天数，默认 30
+}
+
 // AppConfig 应用配置
 type AppConfig struct {
 	Name         string `json:"name"`
@@ -121,6 +129,7 @@ type SMSConfig struct {
 	TwilioAccountSID   string            `json:"twilio_account_sid"`
 	TwilioAuthToken    string            `json:"twilio_auth_token"`
 	TwilioFromNumber   string            `json:"twilio_from_number"`
+	TwilioWhatsAppFrom string            `json:"twilio_whatsapp_from"` // WhatsApp 发送号码（仅 phone_channel=whatsapp 时使用）
 	CustomURL          string            `json:"custom_url"`
 	CustomMethod       string            `json:"custom_method"`
 	CustomHeaders      map[string]string `json:"custom_headers"`
@@ -133,6 +142,12 @@ type SMSTemplates struct {
 	Register      string `json:"register"`
 	ResetPassword string `json:"reset_password"`
 	BindPhone     string `json:"bind_phone"`
+	// 短信正文模板（Twilio / 自定义 HTTP 使用）：语言 -> 事件类型（login/register/reset_password/bind_phone/default）-> 正文
+	// 支持 {{code}} {{app_name}} {{event}} 占位符
+	Bodies map[string]map[string]string `json:"bodies,omitempty"`
+	// 国家区号 -> 语言（如 "+86": "zh"），用户未设置语言时按手机号区号选择
+	CountryLocales map[string]string `json:"country_locales,omitempty"`
+	DefaultLocale  string            `json:"default_locale,omitempty"`
 }
 
 // CORSConfig CORS配置
@@ -154,6 +169,16 @@ type LoginConfig struct {
 	AllowPhoneLogin          bool `json:"allow_phone_login"`
 	AllowPhoneRegister       bool `json:"allow_phone_register"`
 	AllowPhonePasswordReset  bool `json:"allow_phone_password_reset"`
+	AllowPasskeyLogin        bool `json:"allow_passkey_login"` // 通行密钥登录，依赖 app.url 推导依赖方 ID
+	// 开启邮箱验证后对未验证用户的限制方式（由强到弱）：login（默认，禁止登录）| checkout（可登录，禁止下单与查看虚拟商品内容）| virtual_reveal（仅禁止查看虚拟商品内容）| warn（仅提示）
+	EmailVerificationMode     string                          `json:"email_verification_mode"`
+	EmailVerificationReminder EmailVerificationReminderConfig `json:"email_verification_reminder"`
+}
+
+// EmailVerificationReminderConfig 未验证邮箱的提醒邮件，按注册后的小时数依次发送
+type EmailVerificationReminderConfig struct {
+	Enabled       bool  `json:"enabled"`
+	ScheduleHours []int `json:"schedule_hours"` // 例如 [24, 72, 168]，为空时使用默认值
 }
 
 // PasswordPolicyConfig Password策略配置
@@ -178,12 +203,58 @@ type CaptchaConfig struct {
 
 // SecurityConfig 安全配置
 type SecurityConfig struct {
-	CORS           CORSConfig           `json:"cors"`
-	Login          LoginConfig          `json:"login"`
-	PasswordPolicy PasswordPolicyConfig `json:"password_policy"`
-	Captcha        CaptchaConfig        `json:"captcha"`
-	IPHeader       string               `json:"ip_header"`       // 获取真实IP的header名称，如 "CF-Connecting-IP", "X-Real-IP", "X-Forwarded-For"
-	TrustedProxies []string             `json:"trusted_proxies"` // Trusted reverse proxies CIDRs/IPs. Only trusted peers can supply IPHeader.
+	CORS            CORSConfig            `json:"cors"`
+	Login           LoginConfig           `json:"login"`
+	PasswordPolicy  PasswordPolicyConfig  `json:"password_policy"`
+	Captcha         CaptchaConfig         `json:"captcha"`
+	OTP             OTPConfig             `json:"otp"`
+	DataEncryption  DataEncryptionConfig  `json:"data_encryption"`
+	Moderation      ModerationConfig      `json:"moderation"`
+	EmailValidation EmailValidationConfig `json:"email_validation"`
+	IPHeader        string                `json:"ip_header"`       // 获取真实IP的header名称，如 "CF-Connecting-IP", "X-Real-IP", "X-Forwarded-For"
+	TrustedProxies  []string              `json:"trusted_proxies"` // Trusted reverse proxies CIDRs/IPs. Only trusted peers can supply IPHeader.
+}
+
+// OTPConfig 验证码签发与校验配置
+type OTPConfig struct {
+	CodeTTLSeconds  int    `json:"code_ttl_seconds"` // 验证码有效期，默认 600
+	MaxAttempts     int    `json:"max_attempts"`     // 单个验证码最多可尝试次数，默认 5
+	CooldownSeconds int    `json:"cooldown_seconds"` // 重新发送冷却时间，默认 60
+	PhoneChannel    string `json:"phone_channel"`    // 手机验证码渠道：sms（默认）/ whatsapp
+}
+
+// EmailValidationConfig 注册及发送绑定邮箱验证码前校验邮箱域名，减少一次性邮箱注册的小号
+type EmailValidationConfig struct {
+	// 命中后的处理方式：off（默认，不校验）| warn（仅记录日志）| flag（允许注册，标记账户供后台复核）| block（拒绝）
+	Mode                   string   `json:"mode"`
+	CheckDisposable        bool     `json:"check_disposable"`         // 按内置一次性邮箱域名列表检查
+	CheckMX                bool     `json:"check_mx"`                 // 查询域名 MX 记录，无法收信的域名视为无效
+	ExtraDisposableDomains []string `json:"extra_disposable_domains"` // 追加的一次性邮箱域名
+	AllowedDomains         []string `json:"allowed_domains"`          // 白名单域名，跳过所有检查
+	MXTimeoutMs            int      `json:"mx_timeout_ms"`            // DNS 查询超时，默认 2000；超时或 DNS 故障时放行
+}
+
+// ModerationConfig 用户提交文本（订单备注、工单内容）的内容审核，词库规则在管理后台维护
+type ModerationConfig struct {
+	Enabled  bool                     `json:"enabled"`
+	External ModerationExternalConfig `json:"external"` // 可选的外部审核接口，在词库规则之后调用
+}
+
+// ModerationExternalConfig 外部内容审核接口
+// 请求：POST {"text": "...", "source": "ticket_message"}，响应：{"action": "allow|mask|flag|reject", "labels": [], "masked_text": ""}
+type ModerationExternalConfig struct {
+	Enabled   bool   `json:"enabled"`
+	URL       string `json:"url"`
+	APIKey    string `json:"api_key"`    // 以 Authorization: Bearer 发送
+	TimeoutMs int    `json:"timeout_ms"` // 默认 3000
+	OnError   string `json:"on_error"`   // 接口不可用时的处理：allow（默认，放行）| flag（标记待审核）
+}
+
+// DataEncryptionConfig 敏感数据静态加密配置
+// 密钥优先从环境变量 AURALOGIC_DATA_ENCRYPTION_KEY（由 secrets manager 注入）读取，其次为密钥文件，最后为 key
+type DataEncryptionConfig struct {
+	KeyFile string `json:"key_file"` // 密钥文件路径，如 secrets manager 挂载的 /run/secrets/data_key
+	Key     string `json:"key"`      // base64 或 hex 编码的 32 字节密钥
 }
 
 // MessageRateLimit 邮件/短信发送频率限制
@@ -203,14 +274,92 @@ type RateLimitConfig struct {
 	OrderCreate   int  `json:"order_create"`
 	PaymentInfo   int  `json:"payment_info"`
 	PaymentSelect int  `json:"payment_select"`
+	// 路由组令牌桶策略，键为策略名（login / order_create / ticket_message / api_key），与全局 Enabled 开关联动
+	Policies map[string]RateLimitPolicy `json:"policies,omitempty"`
+}
+
+// 令牌桶策略名
+const (
+	RateLimitPolicyLogin         = "login"
+	RateLimitPolicyOrderCreate   = "order_create"
+	RateLimitPolicyTicketMessage = "ticket_message"
+	RateLimitPolicyAPIKey        = "api_key" // 所有 API Key 认证的请求，在认证通过后按 Key 计数
+)
+
+// 令牌桶计数维度
+const (
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyUser   = "user"
+	RateLimitKeyAPIKey = "api_key"
+)
+
+// RateLimitPolicy 令牌桶限流策略：桶容量为 Burst，按 Rate/PeriodSeconds 匀速补充令牌
+type RateLimitPolicy struct {
+	Rate          int      `json:"rate"`           // 每个周期补充的令牌数，0 表示关闭该策略
+	PeriodSeconds int      `json:"period_seconds"` // 补充周期（秒），默认 60
+	Burst         int      `json:"burst"`          // 桶容量，默认等于 Rate
+	Keys          []string `json:"keys"`           // 计数维度 ip/user/api_key，每个维度独立计桶，任一耗尽即拒绝
 }
 
 // LogConfig 日志配置
 type LogConfig struct {
-	Level    string `json:"level"`
-	Format   string `json:"format"`
-	Output   string `json:"output"`
-	FilePath string `json:"file_path"`
+	Level       string            `json:"level"`
+	Format      string            `json:"format"`
+	Output      string            `json:"output"`
+	FilePath    string            `json:"file_path"`
+	AdminAudit  AdminAuditConfig  `json:"admin_audit"`
+	AuditExport AuditExportConfig `json:"audit_export"`
+}
+
+// TracingConfig 链路追踪：请求、服务调用、数据库查询与外部 HTTP 调用记录为 span，通过 OTLP/HTTP（JSON 编码）导出
+type TracingConfig struct {
+	Enabled         bool              `json:"enabled"`
+	ServiceName     string            `json:"service_name"`      // 上报的 service.name，默认 auralogic
+	Endpoint        string            `json:"endpoint"`          // OTLP traces 地址，默认 http://localhost:4318/v1/traces
+	Headers         map[string]string `json:"headers"`           // 导出请求附加的请求头，如后端鉴权
+	SampleRatio     float64           `json:"sample_ratio"`      // 请求未携带上游采样决定时的采样比例（0~1），默认 1
+	BatchSize       int               `json:"batch_size"`        // 单次导出的最大 span 数，默认 512
+	FlushIntervalMs int               `json:"flush_interval_ms"` // 导出间隔，默认 5000
+	TimeoutMs       int               `json:"timeout_ms"`        // 单次导出超时，默认 10000
+}
+
+// AdminAuditConfig 管理端写操作审计（SOC2 留痕），脱敏后的请求体和响应码写入操作日志 details
+type AdminAuditConfig struct {
+	Enabled       bool     `json:"enabled"`
+	Groups        []string `json:"groups"`         // 启用审计的路由分组（/api/admin/ 后第一段，如 orders、settings），为空表示全部分组
+	ExcludeGroups []string `json:"exclude_groups"` // 排除的路由分组，优先于 groups
+	MaxBodyBytes  int      `json:"max_body_bytes"` // 脱敏后请求体记录上限，超出只记录字段名，默认 16384
+	RedactFields  []string `json:"redact_fields"`  // 额外脱敏的字段名（不区分大小写），password/secret/token 等已内置
+}
+
+// AuditExportConfig 操作日志外部归档：按哈希链串联后近实时推送到只可追加的外部存储，内部管理员无法事后篡改已归档的审计记录
+type AuditExportConfig struct {
+	Enabled         bool                  `json:"enabled"`
+	Sink            string                `json:"sink"`             // s3（对象锁定存储桶）/ http（外部日志服务）
+	IntervalSeconds int                   `json:"interval_seconds"` // 推送间隔，默认 15
+	BatchSize       int                   `json:"batch_size"`       // 单批最多推送条数，默认 500
+	S3              AuditExportS3Config   `json:"s3"`
+	HTTP            AuditExportHTTPConfig `json:"http"`
+}
+
+// AuditExportS3Config S3 兼容存储配置，存储桶需开启对象锁定（Object Lock）
+type AuditExportS3Config struct {
+	Endpoint        string `json:"endpoint"` // 为空时使用 https://s3.<region>.amazonaws.com
+	Region          string `json:"region"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	PathStyle       bool   `json:"path_style"`       // 使用路径风格地址（MinIO 等自建存储）
+	ObjectLockMode  string `json:"object_lock_mode"` // COMPLIANCE / GOVERNANCE，默认 COMPLIANCE
+	RetentionDays   int    `json:"retention_days"`   // 对象锁定保留天数，默认 2555（7 年）
+}
+
+// AuditExportHTTPConfig 外部日志服务配置，每批以 NDJSON POST 到 url
+type AuditExportHTTPConfig struct {
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`    // 附加请求头，如 Authorization
+	TimeoutMs int               `json:"timeout_ms"` // 默认 10000
 }
 
 // InitLogger 根据 LogConfig 初始化日志系统。
@@ -313,7 +462,13 @@ type OrderHighConcurrencyProtectionConfig struct {
 
 type OrderConfig struct {
 	NoPrefix                       string                               `json:"no_prefix"`
+	Number                         OrderNumberConfig                    `json:"number"`
 	AutoCancelHours                int                                  `json:"auto_cancel_hours"`
+	AutoCompleteDays               int                                  `json:"auto_complete_days"`          // 已发货订单超过N天无争议自动完成，0表示不自动完成
+	AutoCompleteReminderDays       int                                  `json:"auto_complete_reminder_days"` // 自动完成前N天发送提醒邮件，0表示不提醒
+	AutoCompleteDaysByType         map[string]int                       `json:"auto_complete_days_by_type"`  // 按商品类型（physical/virtual）覆盖自动完成天数，未设置的类型沿用 auto_complete_days，0 表示该类型不自动完成
+	AbandonReleaseMinutes          int                                  `json:"abandon_release_minutes"`     // 用户主动离开付款页后N分钟未返回则提前释放预留库存，0表示禁用
+	DraftCleanup                   DraftCleanupConfig                   `json:"draft_cleanup"`
 	MaxPendingPaymentOrdersPerUser int                                  `json:"max_pending_payment_orders_per_user"`
 	MaxPaymentPollingTasksPerUser  int                                  `json:"max_payment_polling_tasks_per_user"`
 	MaxPaymentPollingTasksGlobal   int                                  `json:"max_payment_polling_tasks_global"`
@@ -327,6 +482,251 @@ type OrderConfig struct {
 	VirtualScriptTimeoutMaxMs      int                                  `json:"virtual_script_timeout_max_ms"` // 虚拟脚本发货允许的最大执行时长
 	Invoice                        InvoiceConfig                        `json:"invoice"`
 	HighConcurrencyProtection      OrderHighConcurrencyProtectionConfig `json:"high_concurrency_protection"`
+	VirtualStockReveal             VirtualStockRevealConfig             `json:"virtual_stock_reveal"`
+	SupplierHealth                 SupplierHealthConfig                 `json:"supplier_health"`
+	RequireScriptApproval          bool                                 `json:"require_script_approval"` // 发货脚本修改需另一名管理员批准后才生效
+	StockReconciliation            StockReconciliationConfig            `json:"stock_reconciliation"`
+	LowStockAlert                  LowStockAlertConfig                  `json:"low_stock_alert"`
+	FlashSale                      FlashSaleConfig                      `json:"flash_sale"`
+	WaitingRoom                    WaitingRoomConfig                    `json:"waiting_room"`
+	OrderRateCap                   OrderRateCapConfig                   `json:"order_rate_cap"`
+	FXSettlement                   FXSettlementConfig                   `json:"fx_settlement"`
+	AccountingExport               AccountingExportConfig               `json:"accounting_export"`
+	PublicTracking                 PublicTrackingConfig                 `json:"public_tracking"`
+	CarrierTracking                CarrierTrackingConfig                `json:"carrier_tracking"`
+	PaymentLink                    PaymentLinkConfig                    `json:"payment_link"`
+	Recovery                       OrderRecoveryConfig                  `json:"recovery"`
+	PackingSlip                    PackingSlipConfig                    `json:"packing_slip"`
+	NetTerms                       NetTermsConfig                       `json:"net_terms"`
+	CashOnDelivery                 CashOnDeliveryConfig                 `json:"cash_on_delivery"`
+	GiftCard                       GiftCardConfig                       `json:"gift_card"`
+	PriceAdjustment                PriceAdjustmentConfig                `json:"price_adjustment"`
+	Quote                          QuoteConfig                          `json:"quote"`
+	Returns                        ReturnConfig                         `json:"returns"`
+	SpendingControls               SpendingControlConfig                `json:"spending_controls"`
+	PriceRounding                  map[string]PriceRoundingRule         `json:"price_rounding"` // 按币种的价格取整规则，键为币种代码
+}
+
+// OrderNumberConfig 订单号生成规则，便于与商家会计系统的单号格式对齐
+type OrderNumberConfig struct {
+	Format         string            `json:"format"`          // timestamp（默认，前缀+时间戳+序号）| date_sequence（前缀+日期+每日递增序号）| random_checksum（前缀+随机数字+校验位）
+	SequenceDigits int               `json:"sequence_digits"` // date_sequence 序号位数，默认 6
+	RandomDigits   int               `json:"random_digits"`   // random_checksum 随机数字位数（不含校验位），默认 10
+	StorePrefixes  map[string]string `json:"store_prefixes"`  // 按来源平台（API 草稿订单的 platform）使用独立前缀，替代 no_prefix
+}
+
+// DraftCleanupConfig 未提交的草稿/待重填订单保留策略，与待付款订单的自动取消相互独立
+type DraftCleanupConfig struct {
+	RetentionDays int    `json:"retention_days"` // 超过N天未提交收货信息则清理，0表示不清理
+	Action        string `json:"action"`         // delete（默认，物理删除订单）| anonymize（清除收货与联系信息并标记为已取消）
+}
+
+// FXSettlementConfig 结算报表汇率配置，付款时按此汇率将订单金额折算为记账本位币并记录在订单上
+type FXSettlementConfig struct {
+	BaseCurrency string             `json:"base_currency"` // 记账本位币，为空时使用 order.currency
+	Rates        map[string]float64 `json:"rates"`         // 1 单位订单币种折合多少本位币，如 {"USD": 7.1}
+}
+
+// AccountingExportConfig 会计系统对接配置：将已付款/已退款订单生成会计分录，定时推送到 QuickBooks Online 或 Xero，或导出为可导入的 CSV
+type AccountingExportConfig struct {
+	Enabled      bool                     `json:"enabled"`       // 开启每日定时推送
+	Provider     string                   `json:"provider"`      // quickbooks / xero，为空时仅支持导出 CSV
+	RunHour      int                      `json:"run_hour"`      // 每日推送时刻（UTC 小时，0-23）
+	LookbackDays int                      `json:"lookback_days"` // 每次推送检查最近多少天内付款/退款的订单，0表示使用默认值30
+	Accounts     AccountingAccountMapping `json:"accounts"`
+	TaxCodes     map[string]string        `json:"tax_codes"` // 按收货国家代码映射销售税码（QuickBooks TaxCode ID / Xero TaxType），"*" 为默认
+	QuickBooks   QuickBooksConfig         `json:"quickbooks"`
+	Xero         XeroConfig               `json:"xero"`
+}
+
+// AccountingAccountMapping 会计科目映射：QuickBooks 填写 Account ID，Xero 填写 Account Code
+type AccountingAccountMapping struct {
+	Sales          string            `json:"sales"`           // 销售收入
+	Surcharge      string            `json:"surcharge"`       // 付款方式附加费收入，为空时计入销售收入
+	Refunds        string            `json:"refunds"`         // 销售退回，为空时冲减销售收入
+	Receivable     string            `json:"receivable"`      // 应收账款
+	Clearing       string            `json:"clearing"`        // 默认收款账户
+	PaymentMethods map[string]string `json:"payment_methods"` // 付款方式名称 -> 收款账户，未配置时使用 clearing
+}
+
+// QuickBooksConfig QuickBooks Online OAuth2 应用配置，refresh_token 仅用于首次授权，轮换后的令牌保存在数据库
+type QuickBooksConfig struct {
+	RealmID      string `json:"realm_id"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+	Sandbox      bool   `json:"sandbox"`
+}
+
+// XeroConfig Xero OAuth2 应用配置，refresh_token 仅用于首次授权，轮换后的令牌保存在数据库
+type XeroConfig struct {
+	TenantID     string `json:"tenant_id"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PublicTrackingConfig 免登录订单查询页配置，供游客凭订单号+邮箱或发货邮件中的签名链接查看订单状态
+type PublicTrackingConfig struct {
+	Enabled               bool `json:"enabled"`                 // 开启后提供公开订单查询页与接口
+	EstimatedDeliveryDays int  `json:"estimated_delivery_days"` // 发货后预计送达天数，用于展示预计送达时间，0表示不展示
+	LinkTTLDays           int  `json:"link_ttl_days"`           // 发货邮件中签名链接的有效期，0表示使用默认值30
+}
+
+// CarrierTrackingConfig 物流轨迹对接配置：发货后将物流单号注册到 17TRACK 或 AfterShip，通过回调接收轨迹更新
+type CarrierTrackingConfig struct {
+	Enabled                   bool   `json:"enabled"`
+	Provider                  string `json:"provider"`                     // 17track / aftership
+	APIKey                    string `json:"api_key"`                      // 17TRACK 为 17token，AfterShip 为 as-api-key
+	WebhookSecret             string `json:"webhook_secret"`               // 回调签名密钥，17TRACK 留空时使用 api_key
+	DefaultCarrier            string `json:"default_carrier"`              // 默认承运商（17TRACK 为数字代码，AfterShip 为 slug），为空时由服务商自动识别
+	CompleteAfterDeliveryDays int    `json:"complete_after_delivery_days"` // 签收后N天无争议自动完成订单，0表示不按签收自动完成
+}
+
+// PaymentLinkConfig 付款链接配置：为待付款订单生成签名链接，买家无需登录即可付款（电话下单、企业采购代付）
+type PaymentLinkConfig struct {
+	Enabled      bool `json:"enabled"`        // 开启后管理员与下单用户可生成付款链接
+	LinkTTLHours int  `json:"link_ttl_hours"` // 付款链接有效期（小时），0表示使用默认值72
+}
+
+// OrderRecoveryConfig 未完成订单挽回提醒：待付款订单自动取消前、草稿订单过期前，按间隔发送继续付款或填写信息的提醒
+type OrderRecoveryConfig struct {
+	Enabled        bool   `json:"enabled"`
+	IntervalsHours []int  `json:"intervals_hours"` // 下单后第N小时发送提醒，未配置时为 1 和 24；晚于自动取消或草稿过期的提醒不发送
+	SendSMS        bool   `json:"send_sms"`        // 同时向收货手机号发送短信，仅支持可发送自定义正文的服务商
+	Campaign       string `json:"campaign"`        // 记录在邮件日志与链接 utm_campaign 中的活动标识，默认 order_recovery
+}
+
+// PackingSlipConfig 装箱单模板配置，自定义模板渲染整份文档（可包含多张装箱单）
+type PackingSlipConfig struct {
+	TemplateType   string `json:"template_type"`   // "builtin" or "custom"
+	CustomTemplate string `json:"custom_template"` // 自定义 HTML 模板
+}
+
+// NetTermsConfig 企业账户账期付款配置：审核通过的企业账户可在信用额度内先发货后付款，按账期开具应收发票并催收逾期款项
+type NetTermsConfig struct {
+	Enabled             bool  `json:"enabled"`                // 开启后用户可申请企业账户并使用账期付款
+	DefaultTermsDays    int   `json:"default_terms_days"`     // 审核通过时的默认账期天数，0表示使用默认值30
+	DunningDays         []int `json:"dunning_days"`           // 发票逾期第N天发送催款邮件，为空时使用默认值 [1, 7, 14]
+	CreditHoldAfterDays int   `json:"credit_hold_after_days"` // 发票逾期超过N天自动冻结账期额度，0表示不自动冻结
+}
+
+// CashOnDeliveryConfig 货到付款配置：符合条件的实物订单可选择货到付款，订单直接进入发货流程，签收时由管理员登记实收金额
+type CashOnDeliveryConfig struct {
+	Enabled        bool     `json:"enabled"`          // 开启后符合条件的订单可选择货到付款
+	Countries      []string `json:"countries"`        // 允许货到付款的收货国家代码，为空表示不限制
+	MinOrderAmount int64    `json:"min_order_amount"` // 订单金额下限（最小货币单位），0表示不限制
+	MaxOrderAmount int64    `json:"max_order_amount"` // 订单金额上限（最小货币单位），0表示不限制
+}
+
+// GiftCardConfig 礼品卡配置，金额均为最小货币单位
+type GiftCardConfig struct {
+	Enabled       bool    `json:"enabled"`       // 开启后结账时可使用礼品卡抵扣
+	Denominations []int64 `json:"denominations"` // 固定面值礼品卡可选的面值
+	MinValue      int64   `json:"min_value"`     // 自定义面值下限，0表示不限制
+	MaxValue      int64   `json:"max_value"`     // 自定义面值上限，0表示不限制
+	ValidityDays  int     `json:"validity_days"` // 发行时未指定过期时间时的默认有效天数，0表示永不过期
+}
+
+// PriceAdjustmentConfig 管理员手动调价（折扣或改价）配置
+type PriceAdjustmentConfig struct {
+	ApprovalThreshold int64 `json:"approval_threshold"` // 应付金额减少超过该金额（最小货币单位）时需另一名管理员批准，0表示无需审批
+}
+
+// QuoteConfig 报价单配置：管理员为客户编制报价，客户接受后按报价价格生成订单
+type QuoteConfig struct {
+	Enabled          bool   `json:"enabled"`            // 开启后管理员可创建报价单，用户可在个人中心查看与接受
+	DefaultValidDays int    `json:"default_valid_days"` // 未指定有效期时的默认天数，0表示使用默认值14
+	CustomTemplate   string `json:"custom_template"`    // 自定义报价单 HTML 模板，为空时使用内置模板；公司信息与 PDF 渲染沿用账单配置
+}
+
+// ReturnConfig 退货（RMA）配置：用户对已发货或已完成订单中的实物商品申请退货
+type ReturnConfig struct {
+	Enabled    bool `json:"enabled"`     // 开启后用户可在订单详情页申请退货
+	WindowDays int  `json:"window_days"` // 发货后可申请退货的天数，0表示不限制
+}
+
+// SpendingControlConfig 账户消费控制：用户可为自己的账户设置每月消费限额和禁止购买的商品分类，
+// 放宽限制需等待冷静期后才生效，每次变更都会通知账户所有人邮箱
+type SpendingControlConfig struct {
+	Enabled             bool `json:"enabled"`               // 开启后个人中心可设置消费控制，下单时校验
+	ChangeCooldownHours int  `json:"change_cooldown_hours"` // 放宽限制的冷静期（小时），<=0 时使用 24
+}
+
+// PriceRoundingRule 系统计算的应付金额（商品价格 × 数量 - 百分比优惠）的取整规则，金额均为最小货币单位；
+// JPY/KRW 等零小数币种未配置时也会取整到整数单位
+type PriceRoundingRule struct {
+	Mode      string `json:"mode"`      // none | nearest（四舍五入）| up | down | charm（尾数定价，如 x.99）
+	Increment int64  `json:"increment"` // 取整步长，如 5 表示尾数 0/5，100 表示整数单位
+	Ending    int64  `json:"ending"`    // charm 模式下从整步长扣减的尾数，如 increment=100、ending=1 得到 x.99
+}
+
+// OrderRateCapConfig 指定商品的下单频率限制（需要 Redis）
+type OrderRateCapConfig struct {
+	Enabled         bool `json:"enabled"`           // 开启后对标记为限购频率的商品生效
+	PerUserHourly   int  `json:"per_user_hourly"`   // 每个用户每小时最多下单次数，0表示不限制
+	PerIPHourly     int  `json:"per_ip_hourly"`     // 每个IP每小时最多下单次数，0表示不限制
+	PerDeviceHourly int  `json:"per_device_hourly"` // 每个设备指纹每小时最多下单次数，0表示不限制
+}
+
+// WaitingRoomConfig 限量发售等候室配置（需要 Redis）
+type WaitingRoomConfig struct {
+	Enabled                 bool `json:"enabled"`                   // 开启后标记为等候室的商品需排队获取准入令牌才能下单
+	AdmitBatchSize          int  `json:"admit_batch_size"`          // 每轮放行人数，0表示使用默认值50
+	AdmitIntervalSeconds    int  `json:"admit_interval_seconds"`    // 放行间隔，0表示使用默认值5
+	MaxActive               int  `json:"max_active"`                // 同时持有有效令牌的最大人数，0表示不限制
+	AccessTokenTTLSeconds   int  `json:"access_token_ttl_seconds"`  // 准入令牌有效期，0表示使用默认值300
+	HeartbeatTimeoutSeconds int  `json:"heartbeat_timeout_seconds"` // 排队者超过该时长未轮询即移出队列，0表示使用默认值60
+}
+
+// FlashSaleConfig 秒杀模式配置（需要 Redis）
+type FlashSaleConfig struct {
+	Enabled        bool `json:"enabled"`          // 开启后标记为秒杀的库存使用 Redis 计数预留
+	SyncIntervalMs int  `json:"sync_interval_ms"` // 预留变动写回数据库的间隔，0表示使用默认值1000
+	SyncBatchSize  int  `json:"sync_batch_size"`  // 每次写回的最大变动条数，0表示使用默认值1000
+}
+
+// StockReconciliationConfig 每日库存对账配置
+type StockReconciliationConfig struct {
+	Enabled      bool `json:"enabled"`
+	AutoHeal     bool `json:"auto_heal"`     // 自动修正实物库存预留数并释放泄漏的虚拟库存预留
+	RunHour      int  `json:"run_hour"`      // 每日执行时刻（UTC 小时，0-23）
+	NotifyAdmins bool `json:"notify_admins"` // 发现差异时邮件通知超级管理员
+}
+
+// LowStockAlertConfig 低库存告警配置：可用库存低于库存上设置的阈值（实物为 safety_stock，虚拟为 low_stock_threshold）时通知管理员
+type LowStockAlertConfig struct {
+	Enabled     bool                        `json:"enabled"`
+	RepeatHours int                         `json:"repeat_hours"` // 持续低库存时的重复提醒间隔，0表示使用默认值24小时
+	Email       bool                        `json:"email"`        // 邮件通知超级管理员，实物库存设置了 alert_email 时同时发送到该邮箱
+	Webhook     LowStockAlertWebhookConfig  `json:"webhook"`
+	Telegram    LowStockAlertTelegramConfig `json:"telegram"`
+}
+
+// LowStockAlertWebhookConfig 以 JSON POST 推送低库存告警，url 为空表示不推送
+type LowStockAlertWebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"` // 附加请求头，如鉴权 token
+}
+
+// LowStockAlertTelegramConfig 通过 Telegram Bot 发送低库存告警，bot_token 或 chat_id 为空表示不发送
+type LowStockAlertTelegramConfig struct {
+	BotToken string `json:"bot_token"`
+	ChatID   string `json:"chat_id"`
+}
+
+// SupplierHealthConfig 脚本发货上游接口健康监控配置
+type SupplierHealthConfig struct {
+	AutoPause          bool    `json:"auto_pause"`           // 错误率超过阈值时自动暂停对应脚本库存（视为缺货）
+	WindowMinutes      int     `json:"window_minutes"`       // 统计窗口，0表示使用默认值15分钟
+	MinRequests        int     `json:"min_requests"`         // 窗口内请求数达到该值才判定，0表示使用默认值20
+	ErrorRateThreshold float64 `json:"error_rate_threshold"` // 错误率阈值(0-1)，0表示使用默认值0.5
+}
+
+// VirtualStockRevealConfig 用户查看虚拟商品内容的限流与重新验证配置
+type VirtualStockRevealConfig struct {
+	MaxPerHour       int `json:"max_per_hour"`       // 每个用户每个订单每小时最多查看次数，0表示不限制
+	ReauthTTLMinutes int `json:"reauth_ttl_minutes"` // 重新验证身份后免验证查看的有效期，0表示使用默认值10分钟
 }
 
 // InvoiceConfig 账单/发票配置
@@ -338,9 +738,13 @@ type InvoiceConfig struct {
 	CompanyAddress string `json:"company_address"`
 	CompanyPhone   string `json:"company_phone"`
 	CompanyEmail   string `json:"company_email"`
-	CompanyLogo    string `json:"company_logo"` // Logo URL
+	CompanyLogo    string `json:"company_logo"` // Logo URL，或 asset:<name> 引用模板资源
 	TaxID          string `json:"tax_id"`
 	FooterText     string `json:"footer_text"`
+	// PDF 渲染：调用无头浏览器（chromium、wkhtmltopdf 等）将账单 HTML 转为 PDF，仅可在配置文件中设置。
+	// {input} / {output} 分别替换为 HTML 与 PDF 文件路径，为空时不提供 PDF 下载
+	PDFRendererCommand string `json:"pdf_renderer_command"`
+	PDFTimeoutSeconds  int    `json:"pdf_timeout_seconds"` // 单次渲染超时，0表示使用默认值30秒
 }
 
 // StockDisplayConfig 库存显示配置
@@ -368,6 +772,25 @@ type UploadConfig struct {
 	AllowedTypes []string `json:"allowed_types"` // 允许的文件类型
 }
 
+// ObjectStorageConfig 对象存储配置，用于工单消息附件等私有文件，不通过后台设置页修改
+type ObjectStorageConfig struct {
+	Driver              string          `json:"driver"`                 // local/s3，默认 local
+	LocalDir            string          `json:"local_dir"`              // 本地存储目录，默认 {upload.dir}/private
+	SignedURLTTLSeconds int             `json:"signed_url_ttl_seconds"` // 下载签名链接有效期（秒），默认 900
+	S3                  S3StorageConfig `json:"s3"`
+}
+
+// S3StorageConfig S3 兼容存储配置（AWS S3、MinIO、Cloudflare R2 等）
+type S3StorageConfig struct {
+	Endpoint        string `json:"endpoint"`          // 如 https://s3.amazonaws.com
+	Region          string `json:"region"`            // 默认 us-east-1
+	Bucket          string `json:"bucket"`            // 存储桶
+	AccessKeyID     string `json:"access_key_id"`     // 访问密钥 ID
+	SecretAccessKey string `json:"secret_access_key"` // 访问密钥
+	PathStyle       bool   `json:"path_style"`        // 使用路径风格地址（MinIO 等通常需要）
+	Prefix          string `json:"prefix"`            // 对象键前缀
+}
+
 // TicketAttachmentConfig 工单附件配置
 type TicketAttachmentConfig struct {
 	EnableImage       bool     `json:"enable_image"`        // 是否允许上传图片
@@ -376,17 +799,43 @@ type TicketAttachmentConfig struct {
 	MaxVoiceSize      int64    `json:"max_voice_size"`      // 最大语音大小（字节）
 	MaxVoiceDuration  int      `json:"max_voice_duration"`  // 最大语音时长（秒）
 	AllowedImageTypes []string `json:"allowed_image_types"` // 允许的图片类型
+	EnableFile        bool     `json:"enable_file"`         // 是否允许在消息中附加文档
+	MaxFileSize       int64    `json:"max_file_size"`       // 最大文档大小（字节）
+	AllowedFileTypes  []string `json:"allowed_file_types"`  // 允许的文档类型
 	RetentionDays     int      `json:"retention_days"`      // 附件保存天数，0表示永久保存
 }
 
 // TicketConfig 工单配置
 type TicketConfig struct {
-	Enabled          bool                    `json:"enabled"`              // 是否启用工单系统
-	Categories       []string                `json:"categories"`           // 工单分类列表
-	Template         string                  `json:"template"`             // 工单提交模板/格式说明
-	MaxContentLength int                     `json:"max_content_length"`   // 工单内容最大字符数，0表示不限制
-	AutoCloseHours   int                     `json:"auto_close_hours"`     // 超时无回复自动关闭（小时），0表示不自动关闭
-	Attachment       *TicketAttachmentConfig `json:"attachment,omitempty"` // 附件配置
+	Enabled               bool                    `json:"enabled"`                 // 是否启用工单系统
+	Categories            []string                `json:"categories"`              // 工单分类列表
+	Template              string                  `json:"template"`                // 工单提交模板/格式说明
+	MaxContentLength      int                     `json:"max_content_length"`      // 工单内容最大字符数，0表示不限制
+	AutoCloseHours        int                     `json:"auto_close_hours"`        // 超时无回复自动关闭（小时），0表示不自动关闭
+	DisputeEscalationDays int                     `json:"dispute_escalation_days"` // 工单创建超过该天数仍未解决时用户可升级为平台争议，0表示关闭
+	DisputeEvidenceDays   int                     `json:"dispute_evidence_days"`   // 争议商家举证期限（天），0表示默认7天
+	Attachment            *TicketAttachmentConfig `json:"attachment,omitempty"`    // 附件配置
+	Topics                []TicketTopicConfig     `json:"topics,omitempty"`        // 分类主题模板（结构化提交字段）
+}
+
+// TicketTopicConfig 工单主题模板，按分类定义创建时必须提供的结构化信息
+type TicketTopicConfig struct {
+	Category        string                    `json:"category"`                   // 对应的工单分类
+	Description     string                    `json:"description,omitempty"`      // 提交页说明
+	ContentTemplate string                    `json:"content_template,omitempty"` // 预填内容模板
+	RequireOrder    bool                      `json:"require_order"`              // 是否必须关联订单
+	MinAttachments  int                       `json:"min_attachments"`            // 至少上传的截图数量，0表示不要求
+	Fields          []TicketIntakeFieldConfig `json:"fields,omitempty"`           // 结构化字段
+}
+
+// TicketIntakeFieldConfig 工单结构化字段
+type TicketIntakeFieldConfig struct {
+	Key       string   `json:"key"`                  // 字段标识
+	Label     string   `json:"label"`                // 显示名称
+	Type      string   `json:"type"`                 // text/textarea/select/number
+	Required  bool     `json:"required"`             // 是否必填
+	Options   []string `json:"options,omitempty"`    // select 可选值
+	MaxLength int      `json:"max_length,omitempty"` // 最大字符数，0表示使用默认值
 }
 
 // SerialConfig 序列号查询配置
@@ -411,6 +860,7 @@ type EmailNotificationsConfig struct {
 	OrderPaid        bool `json:"order_paid"`         // 付款确认
 	OrderShipped     bool `json:"order_shipped"`      // 订单发货
 	OrderCompleted   bool `json:"order_completed"`    // 订单完成
+	OrderAutoRemind  bool `json:"order_auto_remind"`  // 订单即将自动完成提醒
 	OrderCancelled   bool `json:"order_cancelled"`    // 订单取消
 	OrderResubmit    bool `json:"order_resubmit"`     // 需要重填信息
 	TicketCreated    bool `json:"ticket_created"`     // 新工单（通知管理员）
@@ -672,6 +1122,15 @@ func (c *Config) Validate() error {
 	if c.Order.NoPrefix == "" {
 		c.Order.NoPrefix = "ORD"
 	}
+	if c.Order.Number.Format == "" {
+		c.Order.Number.Format = "timestamp"
+	}
+	if c.Order.Number.SequenceDigits <= 0 {
+		c.Order.Number.SequenceDigits = 6
+	}
+	if c.Order.Number.RandomDigits <= 0 {
+		c.Order.Number.RandomDigits = 10
+	}
 	if c.Order.StockDisplay.Mode == "" {
 		c.Order.StockDisplay.Mode = "exact"
 	}
@@ -705,6 +1164,57 @@ func (c *Config) Validate() error {
 	if c.Order.MaxPaymentPollingTasksGlobal == 0 {
 		c.Order.MaxPaymentPollingTasksGlobal = 2000
 	}
+	if c.Security.Login.EmailVerificationMode == "" {
+		c.Security.Login.EmailVerificationMode = "login"
+	}
+	switch c.Security.Login.EmailVerificationMode {
+	case "login", "checkout", "virtual_reveal", "warn":
+	default:
+		return fmt.Errorf("security.login.email_verification_mode must be one of login/checkout/virtual_reveal/warn")
+	}
+	if c.Log.AdminAudit.MaxBodyBytes <= 0 {
+		c.Log.AdminAudit.MaxBodyBytes = 16384
+	}
+	if c.Trash.RetentionDays <= 0 {
+		c.Trash.RetentionDays = 30
+	}
+	if err := normalizeAuditExportConfig(&c.Log.AuditExport); err != nil {
+		return err
+	}
+	if err := normalizeTracingConfig(&c.Tracing); err != nil {
+		return err
+	}
+	for productType, days := range c.Order.AutoCompleteDaysByType {
+		if productType != "physical" && productType != "virtual" {
+			return fmt.Errorf("order.auto_complete_days_by_type only supports physical/virtual, got %q", productType)
+		}
+		if days < 0 {
+			return fmt.Errorf("order.auto_complete_days_by_type.%s cannot be negative", productType)
+		}
+	}
+	switch c.Security.EmailValidation.Mode {
+	case "":
+		c.Security.EmailValidation.Mode = "off"
+	case "off", "warn", "flag", "block":
+	default:
+		return fmt.Errorf("security.email_validation.mode must be one of off/warn/flag/block")
+	}
+	if c.Security.EmailValidation.MXTimeoutMs <= 0 {
+		c.Security.EmailValidation.MXTimeoutMs = 2000
+	}
+	if c.Security.Moderation.External.TimeoutMs <= 0 {
+		c.Security.Moderation.External.TimeoutMs = 3000
+	}
+	switch c.Security.Moderation.External.OnError {
+	case "":
+		c.Security.Moderation.External.OnError = "allow"
+	case "allow", "flag":
+	default:
+		return fmt.Errorf("security.moderation.external.on_error must be one of allow/flag")
+	}
+	if c.Security.Moderation.External.Enabled && strings.TrimSpace(c.Security.Moderation.External.URL) == "" {
+		return fmt.Errorf("security.moderation.external.url is required when external moderation is enabled")
+	}
 	if c.Order.HighConcurrencyProtection.Mode == "" {
 		c.Order.HighConcurrencyProtection.Mode = "auto"
 	}
@@ -722,6 +1232,17 @@ func (c *Config) Validate() error {
 	if c.Order.HighConcurrencyProtection.RedisLeaseMs <= 0 {
 		c.Order.HighConcurrencyProtection.RedisLeaseMs = 30000
 	}
+	c.Order.CarrierTracking.Provider = strings.ToLower(strings.TrimSpace(c.Order.CarrierTracking.Provider))
+	if c.Order.CarrierTracking.Enabled {
+		switch c.Order.CarrierTracking.Provider {
+		case "17track", "aftership":
+		default:
+			return fmt.Errorf("order.carrier_tracking.provider must be one of 17track/aftership")
+		}
+		if strings.TrimSpace(c.Order.CarrierTracking.APIKey) == "" {
+			return fmt.Errorf("order.carrier_tracking.api_key is required when carrier tracking is enabled")
+		}
+	}
 	if c.RateLimit.OrderCreate == 0 {
 		c.RateLimit.OrderCreate = 30
 	}
@@ -731,6 +1252,9 @@ func (c *Config) Validate() error {
 	if c.RateLimit.PaymentSelect == 0 {
 		c.RateLimit.PaymentSelect = 60
 	}
+	if err := normalizeRateLimitPolicies(&c.RateLimit); err != nil {
+		return err
+	}
 	if c.MagicLink.ExpireMinutes == 0 {
 		c.MagicLink.ExpireMinutes = 15
 	}
@@ -756,9 +1280,23 @@ func (c *Config) Validate() error {
 			MaxVoiceSize:      10 * 1024 * 1024, // 10MB
 			MaxVoiceDuration:  60,               // 60秒
 			AllowedImageTypes: []string{".jpg", ".jpeg", ".png", ".gif", ".webp"},
+			EnableFile:        true,
+			MaxFileSize:       10 * 1024 * 1024, // 10MB
+			AllowedFileTypes:  []string{".pdf", ".txt", ".csv", ".zip", ".doc", ".docx", ".xls", ".xlsx"},
 		}
 	}
 
+	// 对象存储默认配置
+	if c.Storage.Driver == "" {
+		c.Storage.Driver = "local"
+	}
+	if c.Storage.LocalDir == "" {
+		c.Storage.LocalDir = filepath.Join(c.Upload.Dir, "private")
+	}
+	if c.Storage.SignedURLTTLSeconds <= 0 {
+		c.Storage.SignedURLTTLSeconds = 900
+	}
+
 	// 验证码默认配置
 	if c.Security.Captcha.Provider == "" {
 		c.Security.Captcha.Provider = "none"
@@ -1160,3 +1698,123 @@ func GetConfigPath() string {
 
 	return "config/config.json"
 }
+
+// normalizeAuditExportConfig 填充操作日志归档默认值，启用时校验推送目标
+func normalizeTracingConfig(cfg *TracingConfig) error {
+	cfg.ServiceName = strings.TrimSpace(cfg.ServiceName)
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "auralogic"
+	}
+	cfg.Endpoint = strings.TrimSpace(cfg.Endpoint)
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "http://localhost:4318/v1/traces"
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1")
+	}
+	if cfg.SampleRatio == 0 {
+		cfg.SampleRatio = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 512
+	}
+	if cfg.FlushIntervalMs <= 0 {
+		cfg.FlushIntervalMs = 5000
+	}
+	if cfg.TimeoutMs <= 0 {
+		cfg.TimeoutMs = 10000
+	}
+	return nil
+}
+
+// normalizeRateLimitPolicies 补齐内置路由组策略并校验计数维度；已显式配置的策略（包括 rate=0 关闭）保持不变
+func normalizeRateLimitPolicies(cfg *RateLimitConfig) error {
+	defaults := map[string]RateLimitPolicy{
+		RateLimitPolicyLogin:         {Rate: 10, Burst: 10, Keys: []string{RateLimitKeyIP}},
+		RateLimitPolicyOrderCreate:   {Rate: cfg.OrderCreate, Burst: cfg.OrderCreate, Keys: []string{RateLimitKeyUser, RateLimitKeyIP}},
+		RateLimitPolicyTicketMessage: {Rate: 20, Burst: 10, Keys: []string{RateLimitKeyUser}},
+		RateLimitPolicyAPIKey:        {Rate: 600, Burst: 600, Keys: []string{RateLimitKeyAPIKey}},
+	}
+	if cfg.Policies == nil {
+		cfg.Policies = make(map[string]RateLimitPolicy, len(defaults))
+	}
+	for name, policy := range defaults {
+		if _, ok := cfg.Policies[name]; !ok {
+			cfg.Policies[name] = policy
+		}
+	}
+	for name, policy := range cfg.Policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("rate_limit.policies.%s: %w", name, err)
+		}
+		if policy.PeriodSeconds == 0 {
+			policy.PeriodSeconds = 60
+		}
+		if policy.Burst == 0 {
+			policy.Burst = policy.Rate
+		}
+		policy.Keys = normalizeLowerStringList(policy.Keys)
+		if len(policy.Keys) == 0 {
+			policy.Keys = []string{RateLimitKeyIP}
+		}
+		cfg.Policies[name] = policy
+	}
+	return nil
+}
+
+// Validate 校验令牌桶策略取值与计数维度
+func (p RateLimitPolicy) Validate() error {
+	if p.Rate < 0 || p.Burst < 0 || p.PeriodSeconds < 0 {
+		return fmt.Errorf("rate, burst and period_seconds must not be negative")
+	}
+	for _, key := range normalizeLowerStringList(p.Keys) {
+		switch key {
+		case RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyAPIKey:
+		default:
+			return fmt.Errorf("keys must be one of ip/user/api_key")
+		}
+	}
+	return nil
+}
+
+func normalizeAuditExportConfig(cfg *AuditExportConfig) error {
+	cfg.Sink = strings.ToLower(strings.TrimSpace(cfg.Sink))
+	if cfg.IntervalSeconds <= 0 {
+		cfg.IntervalSeconds = 15
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	cfg.S3.ObjectLockMode = strings.ToUpper(strings.TrimSpace(cfg.S3.ObjectLockMode))
+	if cfg.S3.ObjectLockMode == "" {
+		cfg.S3.ObjectLockMode = "COMPLIANCE"
+	}
+	if cfg.S3.RetentionDays <= 0 {
+		cfg.S3.RetentionDays = 2555
+	}
+	if cfg.HTTP.TimeoutMs <= 0 {
+		cfg.HTTP.TimeoutMs = 10000
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Sink {
+	case "s3":
+		if cfg.S3.ObjectLockMode != "COMPLIANCE" && cfg.S3.ObjectLockMode != "GOVERNANCE" {
+			return fmt.Errorf("log.audit_export.s3.object_lock_mode must be one of COMPLIANCE/GOVERNANCE")
+		}
+		if strings.TrimSpace(cfg.S3.Bucket) == "" || strings.TrimSpace(cfg.S3.Region) == "" {
+			return fmt.Errorf("log.audit_export.s3.bucket and region are required when the s3 sink is enabled")
+		}
+		if strings.TrimSpace(cfg.S3.AccessKeyID) == "" || strings.TrimSpace(cfg.S3.SecretAccessKey) == "" {
+			return fmt.Errorf("log.audit_export.s3 credentials are required when the s3 sink is enabled")
+		}
+	case "http":
+		if strings.TrimSpace(cfg.HTTP.URL) == "" {
+			return fmt.Errorf("log.audit_export.http.url is required when the http sink is enabled")
+		}
+	default:
+		return fmt.Errorf("log.audit_export.sink must be one of s3/http")
+	}
+	return nil
+}
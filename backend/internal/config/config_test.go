@@ -109,6 +109,40 @@ func TestValidateNormalizesVirtualScriptTimeoutMaxFloor(t *testing.T) {
 	}
 }
 
+func TestValidateFillsDefaultRateLimitPolicies(t *testing.T) {
+	cfg := newValidTestConfig()
+	cfg.RateLimit.Policies = map[string]RateLimitPolicy{
+		RateLimitPolicyLogin: {Rate: 5, Keys: []string{" IP "}},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected config to be valid, got %v", err)
+	}
+	login := cfg.RateLimit.Policies[RateLimitPolicyLogin]
+	if login.Rate != 5 || login.Burst != 5 || login.PeriodSeconds != 60 || login.Keys[0] != RateLimitKeyIP {
+		t.Fatalf("expected explicit login policy to be normalized, got %+v", login)
+	}
+	orderCreate := cfg.RateLimit.Policies[RateLimitPolicyOrderCreate]
+	if orderCreate.Rate != cfg.RateLimit.OrderCreate || len(orderCreate.Keys) != 2 {
+		t.Fatalf("expected order_create policy to default from rate_limit.order_create, got %+v", orderCreate)
+	}
+	if _, ok := cfg.RateLimit.Policies[RateLimitPolicyTicketMessage]; !ok {
+		t.Fatalf("expected ticket_message policy to be filled in")
+	}
+}
+
+func TestValidateRejectsUnknownRateLimitPolicyKey(t *testing.T) {
+	cfg := newValidTestConfig()
+	cfg.RateLimit.Policies = map[string]RateLimitPolicy{
+		RateLimitPolicyLogin: {Rate: 5, Keys: []string{"device"}},
+	}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "rate_limit.policies.login") {
+		t.Fatalf("expected rate limit policy key error, got %v", err)
+	}
+}
+
 func newValidTestConfig() Config {
 	return Config{
 		App: AppConfig{
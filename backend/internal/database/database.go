@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -8,6 +9,8 @@ import (
 
 	"auralogic/internal/config"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/fieldcrypt"
+	"auralogic/internal/pkg/tracing"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -47,6 +50,11 @@ func InitDatabase(cfg *config.DatabaseConfig) error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// 带有请求 span 的查询（db.WithContext）记录为子 span，未启用链路追踪时为空操作
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	// get底层的sql.DB以配置连接池
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -126,11 +134,14 @@ func AutoMigrate() error {
 		&models.User{},
 		&models.AdminPermission{},
 		&models.Order{},
+		&models.OrderItemAllocation{},
 		&models.UserPurchaseStat{},
 		&models.Product{},
 		&models.Inventory{},
 		&models.InventoryLog{},
 		&models.ProductInventoryBinding{},
+		&models.InventoryBindingHistory{},
+		&models.StockReconciliationRun{},
 		&models.ProductSerial{},
 		&models.SerialGenerationTask{},
 		&models.MagicToken{},
@@ -142,6 +153,19 @@ func AutoMigrate() error {
 		&models.SmsLog{},
 		&models.VirtualInventory{},
 		&models.VirtualProductStock{},
+		&models.VirtualStockRevealLog{},
+		&models.LicenseActivation{},
+		&models.UsageMeter{},
+		&models.UsageRecord{},
+		&models.VirtualInventorySupplierStat{},
+		&models.VirtualInventoryScriptRevision{},
+		&models.ScriptExecution{},
+		&models.ProductTrial{},
+		&models.VirtualInventoryRestockPolicy{},
+		&models.VirtualInventoryRestockRun{},
+		&models.VirtualStockResaleReport{},
+		&models.InventorySnapshot{},
+		&models.InventoryStockAlert{},
 		&models.ProductVirtualInventoryBinding{},
 		&models.CartItem{},
 		&models.PaymentMethod{},
@@ -152,8 +176,53 @@ func AutoMigrate() error {
 		&models.PaymentPollingTask{},
 		&models.Ticket{},
 		&models.TicketMessage{},
+		&models.TicketAttachment{},
 		&models.TicketOrderAccess{},
+		&models.RefundRequest{},
+		&models.OrderRefund{},
+		&models.OrderPriceAdjustment{},
+		&models.Quote{},
+		&models.ReturnRequest{},
+		&models.OrderDispute{},
+		&models.SpendingControl{},
+		&models.PolicyVersion{},
+		&models.PolicyConsent{},
+		&models.UserTwoFactor{},
+		&models.UserPasskey{},
+		&models.AnalyticsSession{},
+		&models.AnalyticsEvent{},
+		&models.OrderEvent{},
+		&models.OrderShipment{},
+		&models.OrderShipmentCheckpoint{},
+		&models.Vendor{},
+		&models.VendorLedgerEntry{},
+		&models.VendorPayoutStatement{},
+		&models.BusinessAccount{},
+		&models.NetTermsInvoice{},
+		&models.CODCollection{},
+		&models.GiftCard{},
+		&models.GiftCardTransaction{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.OrganizationInvitation{},
+		&models.PersonalAccessToken{},
+		&models.SiteBanner{},
+		&models.ScheduledJob{},
+		&models.AuditExportState{},
+		&models.ModerationRule{},
+		&models.ModerationCase{},
+		&models.BlocklistEntry{},
+		&models.VelocityRule{},
+		&models.OrderRiskReview{},
+		&models.AccountingExportRun{},
+		&models.AccountingExportRecord{},
+		&models.AccountingCredential{},
+		&models.TicketAgentDailyStat{},
+		&models.SKUSalesDailyStat{},
 		&models.PromoCode{},
+		&models.PromoCodeCampaign{},
 		&models.KnowledgeCategory{},
 		&models.KnowledgeArticle{},
 		&models.Announcement{},
@@ -161,6 +230,11 @@ func AutoMigrate() error {
 		&models.EmailVerificationToken{},
 		&models.LandingPage{},
 		&models.TemplateVersion{},
+		&models.TemplateAsset{},
+		&models.DocumentType{},
+		&models.PricingRule{},
+		&models.InboundWebhook{},
+		&models.InboundWebhookEvent{},
 		&models.PageView{},
 		&models.Plugin{},
 		&models.PluginVersion{},
@@ -183,6 +257,11 @@ func AutoMigrate() error {
 		log.Printf("Warning: failed to migrate virtual inventory bindings hash: %v", err)
 	}
 
+	// 迁移：为历史卡密补充内容哈希，设备激活接口按哈希查找
+	if err := migrateVirtualStockContentHash(); err != nil {
+		log.Printf("Warning: failed to migrate virtual stock content hash: %v", err)
+	}
+
 	// Migration: allow re-registering with the same email/phone after soft-delete.
 	// This is done by "active-only" (deleted_at IS NULL) unique indexes, plus dropping old global unique indexes.
 	if err := migrateUserActiveUniqueIndexes(); err != nil {
@@ -212,6 +291,16 @@ func AutoMigrate() error {
 	if err := migrateOrderSerialGenerationStatus(); err != nil {
 		log.Printf("Warning: failed to migrate order serial generation status: %v", err)
 	}
+	// Migration: backfill orders.shared_to_support from existing ticket order access grants.
+	if err := migrateOrderSharedToSupport(); err != nil {
+		log.Printf("Warning: failed to migrate order shared_to_support flag: %v", err)
+	}
+	if err := migrateTicketOrderAccessReceiverInfo(); err != nil {
+		log.Printf("Warning: failed to migrate ticket order access receiver info: %v", err)
+	}
+	if err := migrateOrderItemAllocations(); err != nil {
+		log.Printf("Warning: failed to migrate order actual_attributes to item allocations: %v", err)
+	}
 
 	// Migration: backfill plugin runtime/runtime_params defaults for legacy rows.
 	if err := migratePluginRuntimeDefaults(); err != nil {
@@ -422,6 +511,64 @@ func migrateVirtualInventoryBindingsHash() error {
 	return nil
 }
 
+// migrateVirtualStockContentHash 按当前哈希密钥重算卡密内容哈希
+// 已加密的内容先解密再计算；迁移记录带哈希密钥标识，配置或更换数据加密密钥后按新密钥重新执行
+func migrateVirtualStockContentHash() error {
+	if err := DB.Exec(`
+CREATE TABLE IF NOT EXISTS system_migrations (
+	name VARCHAR(100) PRIMARY KEY,
+	executed_at TIMESTAMP
+)`).Error; err != nil {
+		return err
+	}
+
+	migrationName := "virtual_stock_content_hash_" + fieldcrypt.HashKeyID()
+	var count int64
+	if err := DB.Table("system_migrations").Where("name = ?", migrationName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var stocks []models.VirtualProductStock
+	migrated := 0
+	err := DB.Select("id", "content").
+		Where("content <> ''").
+		FindInBatches(&stocks, 500, func(tx *gorm.DB, batch int) error {
+			for _, stock := range stocks {
+				plain, err := fieldcrypt.Decrypt(stock.Content)
+				if err != nil {
+					log.Printf("Warning: failed to decrypt virtual stock %d for content hash: %v", stock.ID, err)
+					continue
+				}
+				if err := DB.Model(&models.VirtualProductStock{}).
+					Where("id = ?", stock.ID).
+					UpdateColumn("content_hash", models.LicenseKeyHash(plain)).Error; err != nil {
+					return err
+				}
+				migrated++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+	if migrated > 0 {
+		log.Printf("Migrated content hash for %d virtual stocks", migrated)
+	}
+	// 只保留当前密钥的记录，切换回之前的密钥时同样重新计算
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM system_migrations WHERE name LIKE ?", "virtual_stock_content_hash_%").Error; err != nil {
+			return err
+		}
+		return tx.Exec(
+			"INSERT INTO system_migrations(name, executed_at) VALUES(?, ?)",
+			migrationName, time.Now().UTC(),
+		).Error
+	})
+}
+
 func migrateMoneyToMinorUnits() error {
 	if DB == nil {
 		return nil
@@ -755,6 +902,181 @@ WHERE COALESCE(serial_generation_status, '') = ''
 	})
 }
 
+func migrateOrderSharedToSupport() error {
+	if DB == nil {
+		return nil
+	}
+
+	if err := DB.Exec(`
+CREATE TABLE IF NOT EXISTS system_migrations (
+	name VARCHAR(100) PRIMARY KEY,
+	executed_at TIMESTAMP
+)`).Error; err != nil {
+		return err
+	}
+
+	const migrationName = "order_shared_to_support_v1"
+	var count int64
+	if err := DB.Table("system_migrations").Where("name = ?", migrationName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+UPDATE orders
+SET shared_to_support = ?
+WHERE EXISTS (
+	SELECT 1
+	FROM ticket_order_access
+	WHERE ticket_order_access.order_id = orders.id
+		AND ticket_order_access.deleted_at IS NULL
+)`, true).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(
+			"INSERT INTO system_migrations(name, executed_at) VALUES(?, ?)",
+			migrationName, time.Now().UTC(),
+		).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// migrateTicketOrderAccessReceiverInfo 历史分享默认允许客服查看完整订单信息，
+// 收货信息改为按 can_view_privacy 控制后需为存量授权补齐该标记
+func migrateTicketOrderAccessReceiverInfo() error {
+	if DB == nil {
+		return nil
+	}
+
+	if err := DB.Exec(`
+CREATE TABLE IF NOT EXISTS system_migrations (
+	name VARCHAR(100) PRIMARY KEY,
+	executed_at TIMESTAMP
+)`).Error; err != nil {
+		return err
+	}
+
+	const migrationName = "ticket_order_access_receiver_info_v1"
+	var count int64
+	if err := DB.Table("system_migrations").Where("name = ?", migrationName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"UPDATE ticket_order_access SET can_view_privacy = ? WHERE deleted_at IS NULL",
+			true,
+		).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(
+			"INSERT INTO system_migrations(name, executed_at) VALUES(?, ?)",
+			migrationName, time.Now().UTC(),
+		).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// migrateOrderItemAllocations 将 orders.actual_attributes（按订单项下标索引的 JSON）
+// 迁移为 order_item_allocations 记录。旧列保留不删除，新代码不再读写
+func migrateOrderItemAllocations() error {
+	if DB == nil || !DB.Migrator().HasColumn("orders", "actual_attributes") {
+		return nil
+	}
+
+	if err := DB.Exec(`
+CREATE TABLE IF NOT EXISTS system_migrations (
+	name VARCHAR(100) PRIMARY KEY,
+	executed_at TIMESTAMP
+)`).Error; err != nil {
+		return err
+	}
+
+	const migrationName = "order_item_allocations_v1"
+	var count int64
+	if err := DB.Table("system_migrations").Where("name = ?", migrationName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	type legacyOrderRow struct {
+		ID               uint
+		Items            string
+		ActualAttributes string
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyOrderRow
+		result := tx.Table("orders").
+			Select("id, items, actual_attributes").
+			Where("actual_attributes IS NOT NULL").
+			FindInBatches(&rows, 200, func(batchTx *gorm.DB, batch int) error {
+				var allocations []models.OrderItemAllocation
+				for _, row := range rows {
+					allocations = append(allocations, legacyOrderItemAllocations(row.ID, row.Items, row.ActualAttributes)...)
+				}
+				if len(allocations) == 0 {
+					return nil
+				}
+				return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&allocations).Error
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := tx.Exec(
+			"INSERT INTO system_migrations(name, executed_at) VALUES(?, ?)",
+			migrationName, time.Now().UTC(),
+		).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// legacyOrderItemAllocations 解析旧格式 {"0": {"color": "red"}}，无法解析或下标越界的条目跳过
+func legacyOrderItemAllocations(orderID uint, itemsJSON string, actualAttributesJSON string) []models.OrderItemAllocation {
+	if strings.TrimSpace(actualAttributesJSON) == "" {
+		return nil
+	}
+	var actualMap map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(actualAttributesJSON), &actualMap); err != nil {
+		log.Printf("Warning: skip unparsable actual_attributes: order_id=%d err=%v", orderID, err)
+		return nil
+	}
+	var items []models.OrderItem
+	_ = json.Unmarshal([]byte(itemsJSON), &items)
+
+	allocations := make([]models.OrderItemAllocation, 0, len(actualMap))
+	for key, attributes := range actualMap {
+		var index int
+		if _, err := fmt.Sscanf(key, "%d", &index); err != nil || index < 0 || index >= len(items) || len(attributes) == 0 {
+			continue
+		}
+		allocations = append(allocations, models.OrderItemAllocation{
+			OrderID:    orderID,
+			ItemIndex:  index,
+			SKU:        items[index].SKU,
+			Attributes: attributes,
+		})
+	}
+	return allocations
+}
+
 func orderTotalAmountSumExprForDialect(dialect string) string {
 	switch dialect {
 	case "postgres":
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"testing"
+
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMigrateOrderItemAllocationsConvertsLegacyActualAttributes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:order-item-allocation-migration?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Order{}, &models.OrderItemAllocation{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	// 旧版本 orders 表上的列
+	if err := db.Exec("ALTER TABLE orders ADD COLUMN actual_attributes json").Error; err != nil {
+		t.Fatalf("add legacy column failed: %v", err)
+	}
+
+	previousDB := DB
+	DB = db
+	defer func() {
+		DB = previousDB
+	}()
+
+	order := models.Order{
+		OrderNo: "ORD-LEGACY-BB",
+		Status:  models.OrderStatusPending,
+		Items: []models.OrderItem{
+			{SKU: "BOX-1", Name: "Box", Quantity: 1},
+			{SKU: "TEE-1", Name: "Tee", Quantity: 1},
+		},
+	}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	if err := db.Exec(
+		"UPDATE orders SET actual_attributes = ? WHERE id = ?",
+		`{"0": {"color": "red"}, "5": {"size": "L"}, "x": {"size": "M"}}`, order.ID,
+	).Error; err != nil {
+		t.Fatalf("seed legacy actual_attributes failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := migrateOrderItemAllocations(); err != nil {
+			t.Fatalf("migrate order item allocations failed: %v", err)
+		}
+	}
+
+	var allocations []models.OrderItemAllocation
+	if err := db.Where("order_id = ?", order.ID).Find(&allocations).Error; err != nil {
+		t.Fatalf("query allocations failed: %v", err)
+	}
+	if len(allocations) != 1 {
+		t.Fatalf("expected only the valid item index to be migrated, got %+v", allocations)
+	}
+	if allocations[0].ItemIndex != 0 || allocations[0].SKU != "BOX-1" || allocations[0].Attributes["color"] != "red" {
+		t.Fatalf("unexpected allocation: %+v", allocations[0])
+	}
+
+	order.ItemAllocations = allocations
+	items := order.ItemsWithAllocations()
+	if items[0].Attributes["color"] != "red" || order.Items[0].Attributes != nil {
+		t.Fatalf("expected allocation merged into a copy of the items, got %+v / %+v", items, order.Items)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/fieldcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMigrateVirtualStockContentHashDecryptsEncryptedRows(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:virtual-stock-content-hash-migration?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.VirtualProductStock{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+
+	previousDB := DB
+	DB = db
+	defer func() {
+		DB = previousDB
+	}()
+
+	t.Setenv(fieldcrypt.KeyEnv, "")
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	if err := fieldcrypt.Init(&config.DataEncryptionConfig{Key: key}); err != nil {
+		t.Fatalf("init data encryption failed: %v", err)
+	}
+	defer func() { _ = fieldcrypt.Init(nil) }()
+
+	sealed, err := fieldcrypt.Encrypt("SEALED-0001")
+	if err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	// 历史数据：加密行的哈希按密文计算，明文行为不带密钥的 SHA-256
+	stocks := []models.VirtualProductStock{
+		{VirtualInventoryID: 1, Content: "PLAIN-0001"},
+		{VirtualInventoryID: 1, Content: sealed},
+	}
+	for i := range stocks {
+		if err := db.Create(&stocks[i]).Error; err != nil {
+			t.Fatalf("create stock failed: %v", err)
+		}
+	}
+	if err := db.Model(&models.VirtualProductStock{}).Where("id = ?", stocks[1].ID).
+		UpdateColumn("content_hash", "stale").Error; err != nil {
+		t.Fatalf("seed stale hash failed: %v", err)
+	}
+	if err := db.Model(&models.VirtualProductStock{}).Where("id = ?", stocks[0].ID).
+		UpdateColumn("content_hash", "").Error; err != nil {
+		t.Fatalf("seed empty hash failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := migrateVirtualStockContentHash(); err != nil {
+			t.Fatalf("migrate virtual stock content hash failed: %v", err)
+		}
+	}
+
+	var migrated []models.VirtualProductStock
+	if err := db.Order("id").Find(&migrated).Error; err != nil {
+		t.Fatalf("query stocks failed: %v", err)
+	}
+	if migrated[0].ContentHash != models.LicenseKeyHash("PLAIN-0001") {
+		t.Fatalf("expected plaintext row to use the keyed hash, got %q", migrated[0].ContentHash)
+	}
+	if migrated[1].ContentHash != models.LicenseKeyHash("SEALED-0001") {
+		t.Fatalf("expected encrypted row to be hashed from its plaintext, got %q", migrated[1].ContentHash)
+	}
+
+	// 更换密钥后重新计算
+	otherKey := base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+	if err := fieldcrypt.Init(&config.DataEncryptionConfig{Key: otherKey}); err != nil {
+		t.Fatalf("init data encryption failed: %v", err)
+	}
+	if err := migrateVirtualStockContentHash(); err != nil {
+		t.Fatalf("migrate virtual stock content hash failed: %v", err)
+	}
+	var plain models.VirtualProductStock
+	if err := db.First(&plain, stocks[0].ID).Error; err != nil {
+		t.Fatalf("query stock failed: %v", err)
+	}
+	if plain.ContentHash != models.LicenseKeyHash("PLAIN-0001") || plain.ContentHash == migrated[0].ContentHash {
+		t.Fatalf("expected content hash to be recomputed with the new key, got %q", plain.ContentHash)
+	}
+}
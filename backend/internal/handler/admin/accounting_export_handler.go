@@ -0,0 +1,243 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const accountingExportDateFormat = "2006-01-02"
+
+type AccountingExportHandler struct {
+	accountingService *service.AccountingExportService
+	db                *gorm.DB
+}
+
+func NewAccountingExportHandler(accountingService *service.AccountingExportService, db *gorm.DB) *AccountingExportHandler {
+	return &AccountingExportHandler{accountingService: accountingService, db: db}
+}
+
+// parseAccountingExportRange 解析 YYYY-MM-DD 格式的起止日期（均包含），默认本月初至今天
+func parseAccountingExportRange(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, err := time.Parse(accountingExportDateFormat, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid date range, expected YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, err := time.Parse(accountingExportDateFormat, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid date range, expected YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+	if from.After(to) {
+		response.BadRequest(c, "Start date is after end date")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+// ExportJournal 导出会计分录（CSV）
+// format=journal（默认，通用借贷格式）、quickbooks（QuickBooks Online 分录导入格式）、xero（Xero 手工分录导入格式，按付款时汇率折算为本位币）
+func (h *AccountingExportHandler) ExportJournal(c *gin.Context) {
+	from, to, ok := parseAccountingExportRange(c)
+	if !ok {
+		return
+	}
+	format := strings.TrimSpace(c.DefaultQuery("format", "journal"))
+	if format != "journal" && format != service.AccountingProviderQuickBooks && format != service.AccountingProviderXero {
+		response.BadRequest(c, "Invalid export format")
+		return
+	}
+
+	journals, err := h.accountingService.BuildJournals(from, to, adminCSVExportMaxRows)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+
+	var (
+		headers []string
+		rows    [][]string
+	)
+	switch format {
+	case service.AccountingProviderQuickBooks:
+		headers, rows = quickBooksJournalCSV(journals)
+	case service.AccountingProviderXero:
+		var skipped int
+		headers, rows, skipped = xeroJournalCSV(journals)
+		// Xero 手工分录仅支持本位币，付款时未记录汇率的外币分录无法导出
+		c.Header("X-Skipped-Journals", strconv.Itoa(skipped))
+	default:
+		headers, rows = genericJournalCSV(journals)
+	}
+
+	logger.LogOperation(h.db, c, "accounting_export_download", "accounting_export", nil, map[string]interface{}{
+		"format":   format,
+		"from":     from.Format(accountingExportDateFormat),
+		"to":       to.AddDate(0, 0, -1).Format(accountingExportDateFormat),
+		"journals": len(journals),
+	})
+	writeCSVAttachment(c, buildAdminCSVFileName("accounting_"+format), headers, rows)
+}
+
+func genericJournalCSV(journals []service.AccountingJournal) ([]string, [][]string) {
+	headers := []string{
+		"journal_no",
+		"date",
+		"type",
+		"order_no",
+		"currency",
+		"account",
+		"description",
+		"debit",
+		"credit",
+		"tax_code",
+		"base_currency",
+		"fx_rate",
+	}
+	rows := make([][]string, 0, len(journals)*3)
+	for _, journal := range journals {
+		for _, line := range journal.Lines {
+			rows = append(rows, []string{
+				journal.Reference(),
+				journal.Date.Format(accountingExportDateFormat),
+				journal.Type,
+				journal.OrderNo,
+				journal.Currency,
+				line.Account,
+				line.Description,
+				accountingCSVAmount(line.Debit),
+				accountingCSVAmount(line.Credit),
+				line.TaxCode,
+				journal.BaseCurrency,
+				strconv.FormatFloat(journal.FXRate, 'f', -1, 64),
+			})
+		}
+	}
+	return headers, rows
+}
+
+func quickBooksJournalCSV(journals []service.AccountingJournal) ([]string, [][]string) {
+	headers := []string{
+		"Journal No",
+		"Journal Date",
+		"Currency Code",
+		"Exchange Rate",
+		"Memo",
+		"Account",
+		"Debits",
+		"Credits",
+		"Description",
+		"Tax Code",
+	}
+	rows := make([][]string, 0, len(journals)*3)
+	for _, journal := range journals {
+		exchangeRate := ""
+		if journal.BaseCurrency != "" && journal.Currency != journal.BaseCurrency && journal.FXRate > 0 {
+			exchangeRate = strconv.FormatFloat(journal.FXRate, 'f', -1, 64)
+		}
+		for _, line := range journal.Lines {
+			rows = append(rows, []string{
+				journal.Reference(),
+				journal.Date.Format("01/02/2006"),
+				journal.Currency,
+				exchangeRate,
+				journal.Memo(),
+				line.Account,
+				accountingCSVAmount(line.Debit),
+				accountingCSVAmount(line.Credit),
+				line.Description,
+				line.TaxCode,
+			})
+		}
+	}
+	return headers, rows
+}
+
+func xeroJournalCSV(journals []service.AccountingJournal) ([]string, [][]string, int) {
+	headers := []string{
+		"*Narration",
+		"*Date",
+		"Description",
+		"*AccountCode",
+		"*TaxRate",
+		"*Amount",
+	}
+	rows := make([][]string, 0, len(journals)*3)
+	skipped := 0
+	for _, journal := range journals {
+		lines, ok := journal.BaseLines()
+		if !ok {
+			skipped++
+			continue
+		}
+		narration := journal.Reference() + " " + journal.Memo()
+		for _, line := range lines {
+			rows = append(rows, []string{
+				narration,
+				journal.Date.Format(accountingExportDateFormat),
+				line.Description,
+				line.Account,
+				line.TaxCode,
+				money.MinorToString(line.Debit - line.Credit),
+			})
+		}
+	}
+	return headers, rows, skipped
+}
+
+func accountingCSVAmount(amountMinor int64) string {
+	if amountMinor == 0 {
+		return ""
+	}
+	return money.MinorToString(amountMinor)
+}
+
+// ListRuns 会计系统推送记录
+func (h *AccountingExportHandler) ListRuns(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	runs, total, err := h.accountingService.ListRuns(page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get accounting export runs")
+		return
+	}
+	response.Paginated(c, runs, page, limit, total)
+}
+
+// TriggerRun 立即推送一次会计分录
+func (h *AccountingExportHandler) TriggerRun(c *gin.Context) {
+	run, err := h.accountingService.Push(models.AccountingExportTriggerManual, getOptionalUserID(c))
+	// 推送过程出错时仍会保存 failed 记录并返回，便于查看错误原因
+	if err != nil && run == nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to push accounting journals")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "accounting_export_trigger", "accounting_export", &run.ID, map[string]interface{}{
+		"provider":     run.Provider,
+		"status":       run.Status,
+		"pushed_count": run.PushedCount,
+		"failed_count": run.FailedCount,
+	})
+	response.Success(c, run)
+}
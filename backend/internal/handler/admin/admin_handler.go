@@ -169,7 +169,7 @@ func newAdminCannotDeleteSelfError() error {
 func (h *AdminHandler) CreateAdmin(c *gin.Context) {
 	var req CreateAdminRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -293,7 +293,7 @@ func (h *AdminHandler) UpdateAdmin(c *gin.Context) {
 
 	var req UpdateAdminRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -220,7 +220,7 @@ func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
 		RequireFullRead bool   `json:"require_full_read"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -354,7 +354,7 @@ func (h *AnnouncementHandler) UpdateAnnouncement(c *gin.Context) {
 		RequireFullRead *bool  `json:"require_full_read"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
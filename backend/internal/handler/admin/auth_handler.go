@@ -33,7 +33,7 @@ type LoginRequest struct {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
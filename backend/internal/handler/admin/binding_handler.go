@@ -99,7 +99,7 @@ func (h *BindingHandler) CreateBinding(c *gin.Context) {
 
 	var req CreateBindingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -168,6 +168,7 @@ func (h *BindingHandler) CreateBinding(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	h.recordBindingHistory(c, models.InventoryBindingActionCreate, binding, nil, &binding.InventoryID, 0, req.Notes)
 
 	if h.pluginManager != nil && binding != nil {
 		afterPayload := buildInventoryBindingHookPayload(binding)
@@ -232,7 +233,7 @@ func (h *BindingHandler) BatchCreateBindings(c *gin.Context) {
 
 	var req BatchCreateBindingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -304,6 +305,7 @@ func (h *BindingHandler) BatchCreateBindings(c *gin.Context) {
 		if err != nil {
 			batchErrors = append(batchErrors, buildBindingBatchError(i+1, bindingReq, err))
 		} else {
+			h.recordBindingHistory(c, models.InventoryBindingActionCreate, binding, nil, &binding.InventoryID, 0, bindingReq.Notes)
 			createdBindings = append(createdBindings, binding)
 			createdPayloads = append(createdPayloads, buildInventoryBindingHookPayload(binding))
 		}
@@ -380,7 +382,7 @@ func (h *BindingHandler) UpdateBinding(c *gin.Context) {
 
 	var req UpdateBindingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -444,6 +446,9 @@ func (h *BindingHandler) UpdateBinding(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	if beforeBinding != nil {
+		h.recordBindingHistory(c, models.InventoryBindingActionUpdate, beforeBinding, nil, nil, 0, req.Notes)
+	}
 
 	if h.pluginManager != nil {
 		updatedBinding := beforeBinding
@@ -522,6 +527,9 @@ func (h *BindingHandler) DeleteBinding(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	if beforeBinding != nil {
+		h.recordBindingHistory(c, models.InventoryBindingActionDelete, beforeBinding, &beforeBinding.InventoryID, nil, 0, "")
+	}
 
 	if h.pluginManager != nil && beforeBinding != nil {
 		afterPayload := buildInventoryBindingHookPayload(beforeBinding)
@@ -553,6 +561,7 @@ func (h *BindingHandler) DeleteAllProductBindings(c *gin.Context) {
 		return
 	}
 
+	currentBindings, _ := h.bindingService.GetProductBindings(uint(productID))
 	count, err := h.bindingService.DeleteAllProductBindings(uint(productID))
 	if err != nil {
 		if respondAdminBizError(c, err) {
@@ -561,6 +570,9 @@ func (h *BindingHandler) DeleteAllProductBindings(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	for i := range currentBindings {
+		h.recordBindingHistory(c, models.InventoryBindingActionDelete, &currentBindings[i], &currentBindings[i].InventoryID, nil, 0, "")
+	}
 
 	response.Success(c, gin.H{
 		"message": "Batch delete successful",
@@ -578,7 +590,7 @@ func (h *BindingHandler) ReplaceProductBindings(c *gin.Context) {
 
 	var req BatchCreateBindingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -635,6 +647,9 @@ func (h *BindingHandler) ReplaceProductBindings(c *gin.Context) {
 		response.BadRequest(c, "Failed to delete old bindings")
 		return
 	}
+	for i := range currentBindings {
+		h.recordBindingHistory(c, models.InventoryBindingActionDelete, &currentBindings[i], &currentBindings[i].InventoryID, nil, 0, "")
+	}
 
 	// 2. 批量Create新绑定（如果bindings为空，只Delete不Create）
 	var createdBindings []interface{}
@@ -659,6 +674,7 @@ func (h *BindingHandler) ReplaceProductBindings(c *gin.Context) {
 			if err != nil {
 				batchErrors = append(batchErrors, buildBindingBatchError(i+1, bindingReq, err))
 			} else {
+				h.recordBindingHistory(c, models.InventoryBindingActionCreate, binding, nil, &binding.InventoryID, 0, bindingReq.Notes)
 				createdBindings = append(createdBindings, binding)
 				createdPayloads = append(createdPayloads, buildInventoryBindingHookPayload(binding))
 			}
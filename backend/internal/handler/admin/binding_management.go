@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"log"
+	"strconv"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// RebindRequest 换绑请求
+type RebindRequest struct {
+	InventoryID uint   `json:"inventory_id" binding:"required"`
+	DryRun      bool   `json:"dry_run"`
+	Force       bool   `json:"force"` // 存在仍占用原库存的未完成订单时仍然换绑
+	Notes       string `json:"notes"`
+}
+
+// recordBindingHistory 记录绑定变更历史
+func (h *BindingHandler) recordBindingHistory(c *gin.Context, action string, binding *models.ProductInventoryBinding, from, to *uint, affectedOrders int, notes string) {
+	if binding == nil {
+		return
+	}
+	history := &models.InventoryBindingHistory{
+		BindingID:       binding.ID,
+		ProductID:       binding.ProductID,
+		Action:          action,
+		FromInventoryID: from,
+		ToInventoryID:   to,
+		Attributes:      binding.Attributes,
+		AffectedOrders:  affectedOrders,
+		OperatorID:      getOptionalUserID(c),
+		Notes:           notes,
+	}
+	if err := h.bindingService.RecordHistory(history); err != nil {
+		log.Printf("inventory binding history failed: binding=%d action=%s err=%v", binding.ID, action, err)
+	}
+}
+
+// ListAllBindings 跨商品列出当前的 SKU 与库存绑定关系
+func (h *BindingHandler) ListAllBindings(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	productID, _ := strconv.ParseUint(c.Query("product_id"), 10, 32)
+	inventoryID, _ := strconv.ParseUint(c.Query("inventory_id"), 10, 32)
+
+	bindings, total, err := h.bindingService.ListBindings(c.Query("search"), uint(productID), uint(inventoryID), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get inventory bindings")
+		return
+	}
+	response.Paginated(c, bindings, page, limit, total)
+}
+
+// RebindBinding 将绑定换到另一库存，dry_run（请求体或查询参数）时只返回受影响的未完成订单
+func (h *BindingHandler) RebindBinding(c *gin.Context) {
+	bindingID, err := strconv.ParseUint(c.Param("bindingId"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid binding ID format")
+		return
+	}
+	var req RebindRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	dryRun, ok := dryRunRequested(c, req.DryRun)
+	if !ok {
+		return
+	}
+	if dryRun {
+		impact, err := h.bindingService.PreviewRebind(uint(bindingID), req.InventoryID)
+		if err != nil {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.InternalError(c, "Failed to preview rebind")
+			return
+		}
+		response.Success(c, impact)
+		return
+	}
+
+	beforeBinding, _ := h.loadBinding(uint(bindingID))
+	impact, err := h.bindingService.Rebind(uint(bindingID), req.InventoryID, req.Force)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to rebind inventory")
+		return
+	}
+
+	from, to := impact.FromInventoryID, impact.ToInventoryID
+	h.recordBindingHistory(c, models.InventoryBindingActionRebind, beforeBinding, &from, &to, len(impact.AffectedOrders), req.Notes)
+	logger.LogOperation(h.db, c, "inventory_binding_rebind", "inventory_binding", &impact.BindingID, map[string]interface{}{
+		"product_id":        impact.ProductID,
+		"from_inventory_id": from,
+		"to_inventory_id":   to,
+		"affected_orders":   len(impact.AffectedOrders),
+		"forced":            req.Force,
+	})
+	response.Success(c, impact)
+}
+
+// GetBindingHistory 查询绑定变更记录，可按商品或绑定过滤
+func (h *BindingHandler) GetBindingHistory(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	productID, _ := strconv.ParseUint(c.Query("product_id"), 10, 32)
+	if raw := c.Param("id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid product ID format")
+			return
+		}
+		productID = id
+	}
+	bindingID, _ := strconv.ParseUint(c.Query("binding_id"), 10, 32)
+
+	histories, total, err := h.bindingService.ListHistory(uint(productID), uint(bindingID), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get binding history")
+		return
+	}
+	response.Paginated(c, histories, page, limit, total)
+}
@@ -2,12 +2,16 @@ package admin
 
 import (
 	"errors"
+	"net/http"
 
 	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
+// errAdminUserNotFound 管理端按 ID 查询用户时记录不存在
+var errAdminUserNotFound = bizerr.Register("admin.userNotFound", http.StatusNotFound, "User not found")
+
 func respondAdminBizError(c *gin.Context, err error) bool {
 	if err == nil {
 		return false
@@ -18,6 +22,6 @@ func respondAdminBizError(c *gin.Context, err error) bool {
 		return false
 	}
 
-	response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+	response.RespondBizError(c, bizErr)
 	return true
 }
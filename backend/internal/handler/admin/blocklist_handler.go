@@ -0,0 +1,249 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type BlocklistHandler struct {
+	blocklistService *service.BlocklistService
+	db               *gorm.DB
+}
+
+func NewBlocklistHandler(blocklistService *service.BlocklistService, db *gorm.DB) *BlocklistHandler {
+	return &BlocklistHandler{blocklistService: blocklistService, db: db}
+}
+
+// BlocklistEntryRequest 创建黑名单条目请求
+type BlocklistEntryRequest struct {
+	Type      string     `json:"type" binding:"required"`
+	Value     string     `json:"value" binding:"required"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// UpdateBlocklistEntryRequest 更新黑名单条目请求，类型与值不可修改
+type UpdateBlocklistEntryRequest struct {
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (h *BlocklistHandler) respondError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrBlocklistEntryNotFound) {
+		response.NotFound(c, "Blocklist entry not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListEntries 黑名单条目列表，支持按类型筛选与按值搜索
+func (h *BlocklistHandler) ListEntries(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	entries, total, err := h.blocklistService.List(
+		strings.TrimSpace(c.Query("type")),
+		c.Query("search"),
+		page, limit,
+	)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, entries, page, limit, total)
+}
+
+// CreateEntry 创建黑名单条目
+func (h *BlocklistHandler) CreateEntry(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req BlocklistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	entry, err := h.blocklistService.Create(adminID, service.BlocklistEntryInput{
+		Type:      models.BlocklistType(strings.TrimSpace(req.Type)),
+		Value:     req.Value,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to create blocklist entry")
+		return
+	}
+	logger.LogOperation(h.db, c, "create", "blocklist_entry", &entry.ID, map[string]interface{}{
+		"type":       entry.Type,
+		"value":      entry.Value,
+		"expires_at": entry.ExpiresAt,
+	})
+	response.Success(c, entry)
+}
+
+// UpdateEntry 更新黑名单条目的原因与有效期
+func (h *BlocklistHandler) UpdateEntry(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid entry ID")
+		return
+	}
+	var req UpdateBlocklistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	entry, err := h.blocklistService.Update(id, req.Reason, req.ExpiresAt)
+	if err != nil {
+		h.respondError(c, err, "Failed to update blocklist entry")
+		return
+	}
+	logger.LogOperation(h.db, c, "update", "blocklist_entry", &entry.ID, map[string]interface{}{
+		"type":       entry.Type,
+		"value":      entry.Value,
+		"expires_at": entry.ExpiresAt,
+	})
+	response.Success(c, entry)
+}
+
+// DeleteEntry 删除黑名单条目
+func (h *BlocklistHandler) DeleteEntry(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid entry ID")
+		return
+	}
+	if err := h.blocklistService.Delete(id); err != nil {
+		h.respondError(c, err, "Failed to delete blocklist entry")
+		return
+	}
+	logger.LogOperation(h.db, c, "delete", "blocklist_entry", &id, nil)
+	response.Success(c, nil)
+}
+
+func canonicalBlocklistImportHeader(value string) string {
+	switch normalizePromoCodeImportHeader(value) {
+	case "type", "类型":
+		return "type"
+	case "value", "值", "ip", "email", "邮箱", "phone", "手机号":
+		return "value"
+	case "reason", "原因", "备注":
+		return "reason"
+	case "expiresat", "expiry", "到期时间":
+		return "expires_at"
+	default:
+		return ""
+	}
+}
+
+// ImportEntries 从 .csv/.xlsx 文件批量导入黑名单条目。
+// 文件需包含 value 列；缺少 type 列时使用表单参数 type 作为所有行的类型。已存在的条目会更新原因与有效期
+func (h *BlocklistHandler) ImportEntries(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	defaultType := strings.TrimSpace(c.DefaultPostForm("type", c.Query("type")))
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "Please select an import file to upload")
+		return
+	}
+	fileFormat, tableRows, err := readAdminTabularRows(file)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "unsupported format") {
+			response.BadRequest(c, "Only .csv and .xlsx formats are supported")
+			return
+		}
+		response.BadRequest(c, "Failed to parse import file")
+		return
+	}
+	if len(tableRows) == 0 {
+		response.BadRequest(c, "Import file is empty")
+		return
+	}
+
+	headerMap := make(map[string]int)
+	for idx, value := range tableRows[0] {
+		if canonical := canonicalBlocklistImportHeader(value); canonical != "" {
+			if _, exists := headerMap[canonical]; !exists {
+				headerMap[canonical] = idx
+			}
+		}
+	}
+	if _, exists := headerMap["value"]; !exists {
+		response.BadRequest(c, "missing required columns: value")
+		return
+	}
+	if _, exists := headerMap["type"]; !exists && defaultType == "" {
+		response.BadRequest(c, "missing required columns: type")
+		return
+	}
+
+	rows := make([]service.BlocklistImportRow, 0, len(tableRows)-1)
+	var parseErrors []string
+	for idx, record := range tableRows[1:] {
+		rowIndex := idx + 2
+		value := promoCodeImportCell(record, headerMap, "value")
+		if value == "" {
+			continue
+		}
+		if len(rows)+len(parseErrors) >= service.MaxBlocklistImportRows {
+			response.BadRequest(c, fmt.Sprintf("Too many rows to import (max %d). Please split the file.", service.MaxBlocklistImportRows))
+			return
+		}
+		entryType := promoCodeImportCell(record, headerMap, "type")
+		if entryType == "" {
+			entryType = defaultType
+		}
+		rawExpiry := promoCodeImportCell(record, headerMap, "expires_at")
+		expiresAt, err := parsePromoCodeExpiryInput(&rawExpiry)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("Row %d: invalid expires_at: %s", rowIndex, rawExpiry))
+			continue
+		}
+		rows = append(rows, service.BlocklistImportRow{
+			Row: rowIndex,
+			Input: service.BlocklistEntryInput{
+				Type:      models.BlocklistType(strings.ToLower(entryType)),
+				Value:     value,
+				Reason:    promoCodeImportCell(record, headerMap, "reason"),
+				ExpiresAt: expiresAt,
+			},
+		})
+	}
+	if len(rows) == 0 && len(parseErrors) == 0 {
+		response.BadRequest(c, "No data rows found in import file")
+		return
+	}
+
+	result, err := h.blocklistService.Import(adminID, rows)
+	if err != nil {
+		response.InternalError(c, "Failed to import blocklist entries")
+		return
+	}
+	result.TotalRows += len(parseErrors)
+	result.ErrorCount += len(parseErrors)
+	result.Errors = append(parseErrors, result.Errors...)
+
+	logger.LogOperation(h.db, c, "import", "blocklist_entry", nil, map[string]interface{}{
+		"format":        fileFormat,
+		"total_rows":    result.TotalRows,
+		"created_count": result.CreatedCount,
+		"updated_count": result.UpdatedCount,
+		"error_count":   result.ErrorCount,
+	})
+	response.Success(c, result)
+}
@@ -0,0 +1,351 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const netTermsStatementDateFormat = "2006-01-02"
+
+type BusinessAccountHandler struct {
+	netTermsService *service.NetTermsService
+	db              *gorm.DB
+}
+
+func NewBusinessAccountHandler(netTermsService *service.NetTermsService, db *gorm.DB) *BusinessAccountHandler {
+	return &BusinessAccountHandler{netTermsService: netTermsService, db: db}
+}
+
+// BusinessAccountTermsRequest 账期条款，审核通过时 terms_days 为 0 表示使用默认账期
+type BusinessAccountTermsRequest struct {
+	Currency         string `json:"currency"`
+	CreditLimitMinor int64  `json:"credit_limit_minor"`
+	TermsDays        int    `json:"terms_days"`
+}
+
+func (r BusinessAccountTermsRequest) toTerms() service.BusinessAccountTerms {
+	return service.BusinessAccountTerms{
+		Currency:    r.Currency,
+		CreditLimit: r.CreditLimitMinor,
+		TermsDays:   r.TermsDays,
+	}
+}
+
+// ApproveBusinessAccountRequest 审核通过企业账户请求
+type ApproveBusinessAccountRequest struct {
+	BusinessAccountTermsRequest
+	Note string `json:"note"`
+}
+
+// RejectBusinessAccountRequest 拒绝企业账户申请请求
+type RejectBusinessAccountRequest struct {
+	Note string `json:"note"`
+}
+
+// UpdateBusinessAccountRequest 调整企业账户条款与状态请求
+type UpdateBusinessAccountRequest struct {
+	BusinessAccountTermsRequest
+	Suspended  bool `json:"suspended"`
+	CreditHold bool `json:"credit_hold"`
+}
+
+// MarkNetTermsInvoicePaidRequest 登记发票收款请求
+type MarkNetTermsInvoicePaidRequest struct {
+	Reference string `json:"reference"`
+}
+
+// VoidNetTermsInvoiceRequest 作废发票请求
+type VoidNetTermsInvoiceRequest struct {
+	Reason string `json:"reason"`
+}
+
+func respondNetTermsError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrBusinessAccountNotFound):
+		response.NotFound(c, "Business account not found")
+	case errors.Is(err, service.ErrNetTermsInvoiceNotFound):
+		response.NotFound(c, "Invoice not found")
+	default:
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// ListAccounts 企业账户列表
+func (h *BusinessAccountHandler) ListAccounts(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	accounts, total, err := h.netTermsService.ListAccounts(c.Query("status"), c.Query("search"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get business accounts")
+		return
+	}
+	response.Paginated(c, accounts, page, limit, total)
+}
+
+// GetAccount 企业账户详情
+func (h *BusinessAccountHandler) GetAccount(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	account, err := h.netTermsService.GetAccount(id)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to get business account")
+		return
+	}
+	response.Success(c, account)
+}
+
+// ApproveAccount 审核通过企业账户
+func (h *BusinessAccountHandler) ApproveAccount(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	var req ApproveBusinessAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	account, err := h.netTermsService.Approve(id, adminID, req.toTerms(), req.Note)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to approve business account")
+		return
+	}
+	logger.LogOperation(h.db, c, "approve_business_account", "business_account", &account.ID, map[string]interface{}{
+		"user_id":      account.UserID,
+		"currency":     account.Currency,
+		"credit_limit": account.CreditLimit,
+		"terms_days":   account.TermsDays,
+	})
+	response.Success(c, account)
+}
+
+// RejectAccount 拒绝企业账户申请
+func (h *BusinessAccountHandler) RejectAccount(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	var req RejectBusinessAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	account, err := h.netTermsService.Reject(id, adminID, req.Note)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to reject business account")
+		return
+	}
+	logger.LogOperation(h.db, c, "reject_business_account", "business_account", &account.ID, map[string]interface{}{
+		"user_id": account.UserID,
+		"note":    account.ReviewNote,
+	})
+	response.Success(c, account)
+}
+
+// UpdateAccount 调整企业账户额度、账期、停用与冻结状态
+func (h *BusinessAccountHandler) UpdateAccount(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	var req UpdateBusinessAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	account, err := h.netTermsService.UpdateTerms(id, req.toTerms(), req.Suspended, req.CreditHold)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to update business account")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_business_account", "business_account", &account.ID, map[string]interface{}{
+		"status":       account.Status,
+		"currency":     account.Currency,
+		"credit_limit": account.CreditLimit,
+		"terms_days":   account.TermsDays,
+		"credit_hold":  account.CreditHold,
+	})
+	response.Success(c, account)
+}
+
+// ListInvoices 账期发票列表
+func (h *BusinessAccountHandler) ListInvoices(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	var accountID uint
+	if raw := strings.TrimSpace(c.Query("business_account_id")); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid business account ID")
+			return
+		}
+		accountID = uint(parsed)
+	}
+	invoices, total, err := h.netTermsService.ListInvoices(accountID, c.Query("status"), c.Query("overdue") == "1", page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get invoices")
+		return
+	}
+	response.Paginated(c, invoices, page, limit, total)
+}
+
+// MarkInvoicePaid 登记发票收款
+func (h *BusinessAccountHandler) MarkInvoicePaid(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid invoice ID")
+		return
+	}
+	var req MarkNetTermsInvoicePaidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	invoice, err := h.netTermsService.MarkInvoicePaid(id, adminID, req.Reference)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to update invoice")
+		return
+	}
+	logger.LogOperation(h.db, c, "mark_net_terms_invoice_paid", "net_terms_invoice", &invoice.ID, map[string]interface{}{
+		"invoice_no": invoice.InvoiceNo,
+		"currency":   invoice.Currency,
+		"amount":     invoice.Amount,
+		"reference":  invoice.PaymentReference,
+	})
+	response.Success(c, invoice)
+}
+
+// VoidInvoice 作废发票
+func (h *BusinessAccountHandler) VoidInvoice(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid invoice ID")
+		return
+	}
+	var req VoidNetTermsInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	invoice, err := h.netTermsService.VoidInvoice(id, req.Reason)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to void invoice")
+		return
+	}
+	logger.LogOperation(h.db, c, "void_net_terms_invoice", "net_terms_invoice", &invoice.ID, map[string]interface{}{
+		"invoice_no": invoice.InvoiceNo,
+		"amount":     invoice.Amount,
+		"reason":     invoice.VoidReason,
+	})
+	response.Success(c, invoice)
+}
+
+// parseNetTermsStatementPeriod 解析对账单周期（YYYY-MM-DD），默认为当月，结束日期当天包含在内
+func parseNetTermsStatementPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	if raw := strings.TrimSpace(c.Query("start")); raw != "" {
+		parsed, err := time.Parse(netTermsStatementDateFormat, raw)
+		if err != nil {
+			return start, end, false
+		}
+		start = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("end")); raw != "" {
+		parsed, err := time.Parse(netTermsStatementDateFormat, raw)
+		if err != nil {
+			return start, end, false
+		}
+		end = parsed.AddDate(0, 0, 1)
+	}
+	return start, end, true
+}
+
+// GetStatement 企业账户对账单
+func (h *BusinessAccountHandler) GetStatement(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	start, end, ok := parseNetTermsStatementPeriod(c)
+	if !ok {
+		response.BadRequest(c, "Invalid period, expected YYYY-MM-DD")
+		return
+	}
+	statement, err := h.netTermsService.Statement(id, start, end)
+	if err != nil {
+		respondNetTermsError(c, err, "Failed to generate statement")
+		return
+	}
+	response.Success(c, statement)
+}
+
+// ExportStatement 导出企业账户对账单（CSV）
+func (h *BusinessAccountHandler) ExportStatement(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid business account ID")
+		return
+	}
+	start, end, ok := parseNetTermsStatementPeriod(c)
+	if !ok {
+		response.BadRequest(c, "Invalid period, expected YYYY-MM-DD")
+		return
+	}
+	statement, err := h.netTermsService.Statement(id, start, end)
+	if err != nil {
+		respondNetTermsError(c, err, "Export failed")
+		return
+	}
+
+	headers := []string{"date", "type", "invoice_no", "order_no", "due_at", "reference", "currency", "amount", "balance"}
+	rows := make([][]string, 0, len(statement.Lines)+2)
+	rows = append(rows, []string{csvTimeValue(statement.PeriodStart), "opening_balance", "", "", "", "", statement.Currency, "", money.MinorToString(statement.OpeningBalanceMinor)})
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			csvTimeValue(line.Date),
+			line.Type,
+			line.InvoiceNo,
+			line.OrderNo,
+			csvTimeValue(line.DueAt),
+			line.Reference,
+			statement.Currency,
+			money.MinorToString(line.AmountMinor),
+			money.MinorToString(line.BalanceMinor),
+		})
+	}
+	rows = append(rows, []string{csvTimeValue(statement.PeriodEnd), "closing_balance", "", "", "", "", statement.Currency, "", money.MinorToString(statement.ClosingBalanceMinor)})
+
+	writeCSVAttachment(c, buildAdminCSVFileName(fmt.Sprintf("business_account_statement_%d", id)), headers, rows)
+}
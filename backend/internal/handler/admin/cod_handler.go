@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"errors"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CODHandler struct {
+	codService *service.CODService
+	db         *gorm.DB
+}
+
+func NewCODHandler(codService *service.CODService, db *gorm.DB) *CODHandler {
+	return &CODHandler{codService: codService, db: db}
+}
+
+// ConfirmCODCollectionRequest 登记货到付款实收金额请求
+type ConfirmCODCollectionRequest struct {
+	CollectedAmountMinor *int64 `json:"collected_amount_minor" binding:"required"`
+	Reference            string `json:"reference"`
+}
+
+// VoidCODCollectionRequest 作废代收记录请求
+type VoidCODCollectionRequest struct {
+	Reason string `json:"reason"`
+}
+
+func respondCODError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrCODCollectionNotFound) {
+		response.NotFound(c, "Collection not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListCollections 货到付款代收记录列表
+func (h *CODHandler) ListCollections(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	collections, total, err := h.codService.ListCollections(c.Query("status"), c.Query("search"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get collections")
+		return
+	}
+	response.Paginated(c, collections, page, limit, total)
+}
+
+// GetReport 按币种汇总的待收款余额与账龄
+func (h *CODHandler) GetReport(c *gin.Context) {
+	report, err := h.codService.Report(time.Now())
+	if err != nil {
+		response.InternalError(c, "Failed to get cash on delivery report")
+		return
+	}
+	response.Success(c, gin.H{"balances": report})
+}
+
+// ConfirmCollection 发货后登记实收金额
+func (h *CODHandler) ConfirmCollection(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid collection ID")
+		return
+	}
+	var req ConfirmCODCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	collection, err := h.codService.ConfirmCollection(id, adminID, *req.CollectedAmountMinor, req.Reference)
+	if err != nil {
+		respondCODError(c, err, "Failed to confirm collection")
+		return
+	}
+	logger.LogOperation(h.db, c, "confirm_cod_collection", "cod_collection", &collection.ID, map[string]interface{}{
+		"order_no":         collection.OrderNo,
+		"currency":         collection.Currency,
+		"amount":           collection.Amount,
+		"collected_amount": *req.CollectedAmountMinor,
+		"reference":        collection.Reference,
+	})
+	response.Success(c, collection)
+}
+
+// VoidCollection 作废代收记录
+func (h *CODHandler) VoidCollection(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid collection ID")
+		return
+	}
+	var req VoidCODCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	collection, err := h.codService.VoidCollection(id, req.Reason)
+	if err != nil {
+		respondCODError(c, err, "Failed to void collection")
+		return
+	}
+	logger.LogOperation(h.db, c, "void_cod_collection", "cod_collection", &collection.ID, map[string]interface{}{
+		"order_no": collection.OrderNo,
+		"amount":   collection.Amount,
+		"reason":   collection.VoidReason,
+	})
+	response.Success(c, collection)
+}
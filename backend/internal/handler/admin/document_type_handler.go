@@ -0,0 +1,265 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DocumentTypeHandler 自定义文档类型（保修卡、送货单、正品证书等）的管理与后台生成
+type DocumentTypeHandler struct {
+	documentService *service.DocumentService
+	db              *gorm.DB
+	cfg             *config.Config
+}
+
+func NewDocumentTypeHandler(documentService *service.DocumentService, db *gorm.DB, cfg *config.Config) *DocumentTypeHandler {
+	return &DocumentTypeHandler{documentService: documentService, db: db, cfg: cfg}
+}
+
+// DocumentTypeRequest 创建或更新文档类型请求
+type DocumentTypeRequest struct {
+	Key           string            `json:"key" binding:"required"`
+	Name          string            `json:"name" binding:"required"`
+	Description   string            `json:"description"`
+	Binding       string            `json:"binding"`
+	Template      string            `json:"template"`
+	Fields        map[string]string `json:"fields"`
+	OrderStatuses []string          `json:"order_statuses"`
+	ItemSKUs      []string          `json:"item_skus"`
+	Enabled       *bool             `json:"enabled"`
+	VisibleToUser *bool             `json:"visible_to_user"`
+}
+
+func (req DocumentTypeRequest) toInput() service.DocumentTypeInput {
+	input := service.DocumentTypeInput{
+		Key:           req.Key,
+		Name:          req.Name,
+		Description:   req.Description,
+		Binding:       req.Binding,
+		Template:      req.Template,
+		Fields:        req.Fields,
+		OrderStatuses: req.OrderStatuses,
+		ItemSKUs:      req.ItemSKUs,
+		Enabled:       true,
+		VisibleToUser: true,
+	}
+	if req.Enabled != nil {
+		input.Enabled = *req.Enabled
+	}
+	if req.VisibleToUser != nil {
+		input.VisibleToUser = *req.VisibleToUser
+	}
+	return input
+}
+
+// PreviewDocumentTypeRequest 用未保存的设置渲染指定订单的预览
+type PreviewDocumentTypeRequest struct {
+	Key       string            `json:"key"`
+	Name      string            `json:"name"`
+	Binding   string            `json:"binding"`
+	Template  string            `json:"template"`
+	Fields    map[string]string `json:"fields"`
+	OrderNo   string            `json:"order_no" binding:"required"`
+	ItemIndex int               `json:"item_index"`
+}
+
+// ListDocumentTypes 文档类型列表
+func (h *DocumentTypeHandler) ListDocumentTypes(c *gin.Context) {
+	docTypes, err := h.documentService.List()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, docTypes)
+}
+
+// GetDocumentType 文档类型详情
+func (h *DocumentTypeHandler) GetDocumentType(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid document type ID")
+		return
+	}
+	docType, err := h.documentService.Get(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, docType)
+}
+
+// CreateDocumentType 新建文档类型
+func (h *DocumentTypeHandler) CreateDocumentType(c *gin.Context) {
+	var req DocumentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	docType, err := h.documentService.Create(req.toInput())
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to create document type")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "create", "document_type", &docType.ID, map[string]interface{}{
+		"key":     docType.Key,
+		"name":    docType.Name,
+		"binding": docType.Binding,
+	})
+	response.Success(c, docType)
+}
+
+// UpdateDocumentType 更新文档类型
+func (h *DocumentTypeHandler) UpdateDocumentType(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid document type ID")
+		return
+	}
+	var req DocumentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	docType, err := h.documentService.Update(id, req.toInput())
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update document type")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update", "document_type", &docType.ID, map[string]interface{}{
+		"key":     docType.Key,
+		"name":    docType.Name,
+		"enabled": docType.Enabled,
+	})
+	response.Success(c, docType)
+}
+
+// DeleteDocumentType 删除文档类型
+func (h *DocumentTypeHandler) DeleteDocumentType(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid document type ID")
+		return
+	}
+	docType, err := h.documentService.Delete(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to delete document type")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "delete", "document_type", &docType.ID, map[string]interface{}{
+		"key": docType.Key,
+	})
+	response.Success(c, gin.H{"id": docType.ID})
+}
+
+// PreviewDocumentType 用编辑中的模板渲染指定订单，返回 HTML
+func (h *DocumentTypeHandler) PreviewDocumentType(c *gin.Context) {
+	var req PreviewDocumentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	order, err := h.documentService.OrderByNo(req.OrderNo)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+	html, err := h.documentService.Preview(service.DocumentTypeInput{
+		Key:      req.Key,
+		Name:     req.Name,
+		Binding:  req.Binding,
+		Template: req.Template,
+		Fields:   req.Fields,
+	}, order, req.ItemIndex)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalServerError(c, "Failed to render document", err)
+		}
+		return
+	}
+	c.Data(200, "text/html; charset=utf-8", html)
+}
+
+// ListOrderDocuments 订单当前可生成的文档（含仅后台可见的文档）
+func (h *DocumentTypeHandler) ListOrderDocuments(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	order, err := h.documentService.OrderByID(orderID)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+	documents, err := h.documentService.AvailableForOrder(order, false)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, documents)
+}
+
+// RenderOrderDocument 生成订单文档，format=pdf 时输出 PDF
+func (h *DocumentTypeHandler) RenderOrderDocument(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	order, err := h.documentService.OrderByID(orderID)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+	docType, err := h.documentService.GetByKey(c.Param("key"))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	itemIndex, _ := strconv.Atoi(c.DefaultQuery("item", "0"))
+	if err := h.documentService.CheckAvailable(docType, order, itemIndex, false); err != nil {
+		respondAdminBizError(c, err)
+		return
+	}
+
+	asPDF := c.Query("format") == "pdf"
+	if asPDF && !service.InvoicePDFEnabled(&h.cfg.Order.Invoice) {
+		response.BadRequest(c, "PDF documents are not enabled")
+		return
+	}
+	html, err := h.documentService.Render(docType, order, itemIndex, asPDF)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render document", err)
+		return
+	}
+	if !asPDF {
+		c.Data(200, "text/html; charset=utf-8", html)
+		return
+	}
+	pdf, err := service.RenderInvoicePDF(c.Request.Context(), &h.cfg.Order.Invoice, html)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render document PDF", err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", service.DocumentNo(docType, order, itemIndex)+".pdf"))
+	c.Data(200, "application/pdf", pdf)
+}
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// dryRunRequested 批量与破坏性操作的试运行开关：查询参数 ?dry_run=true 或请求体中的 dry_run 任一开启即为试运行
+// 试运行只返回将受影响的记录，不执行插件钩子，也不写入任何数据；参数无法解析时返回 400，避免误执行
+func dryRunRequested(c *gin.Context, bodyDryRun bool) (bool, bool) {
+	raw := strings.TrimSpace(c.Query("dry_run"))
+	if raw == "" {
+		return bodyDryRun, true
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		response.BadRequest(c, "Invalid dry_run value")
+		return false, false
+	}
+	return enabled || bodyDryRun, true
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// parseFunnelRange 解析漏斗统计的日期范围（UTC 日期，含首尾两天），默认最近30天
+func parseFunnelRange(c *gin.Context) (time.Time, time.Time, bool) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	endDate := today
+	startDate := today.AddDate(0, 0, -(skuSalesDefaultDays - 1))
+
+	if raw := strings.TrimSpace(c.Query("end_date")); raw != "" {
+		parsed, err := time.Parse(skuSalesDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date, expected YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		endDate = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("start_date")); raw != "" {
+		parsed, err := time.Parse(skuSalesDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date, expected YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		startDate = parsed
+	}
+	if startDate.After(endDate) {
+		response.BadRequest(c, "start_date cannot be after end_date")
+		return time.Time{}, time.Time{}, false
+	}
+	if endDate.Sub(startDate) > skuSalesMaxRangeDays*24*time.Hour {
+		response.BadRequest(c, fmt.Sprintf("Date range cannot exceed %d days", skuSalesMaxRangeDays))
+		return time.Time{}, time.Time{}, false
+	}
+	return startDate, endDate.AddDate(0, 0, 1), true
+}
+
+// GetFunnelAnalytics 获取第一方事件统计的转化漏斗
+func (h *AnalyticsHandler) GetFunnelAnalytics(c *gin.Context) {
+	if h.checkDisabled(c) {
+		return
+	}
+	start, end, ok := parseFunnelRange(c)
+	if !ok {
+		return
+	}
+	funnel, err := service.NewAnalyticsEventService(h.db, h.cfg).Funnel(start, end)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, funnel)
+}
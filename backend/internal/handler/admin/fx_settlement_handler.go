@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type FXSettlementHandler struct {
+	fxSettlementService *service.FXSettlementService
+}
+
+func NewFXSettlementHandler(fxSettlementService *service.FXSettlementService) *FXSettlementHandler {
+	return &FXSettlementHandler{fxSettlementService: fxSettlementService}
+}
+
+func parseFXSettlementRange(c *gin.Context) (time.Time, time.Time, bool) {
+	from, to, err := service.ParseFXSettlementMonthRange(c.Query("from"), c.Query("to"), time.Now().UTC())
+	if err != nil {
+		response.BadRequest(c, "Invalid month range, expected YYYY-MM")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// GetReport 按月份、币种、付款方式汇总的本位币结算报表
+func (h *FXSettlementHandler) GetReport(c *gin.Context) {
+	from, to, ok := parseFXSettlementRange(c)
+	if !ok {
+		return
+	}
+	report, err := h.fxSettlementService.MonthlySummary(from, to)
+	if err != nil {
+		response.InternalError(c, "Failed to build settlement report")
+		return
+	}
+	response.Success(c, report)
+}
+
+// ExportReport 导出结算报表（CSV），type=orders 导出逐单折算明细
+func (h *FXSettlementHandler) ExportReport(c *gin.Context) {
+	from, to, ok := parseFXSettlementRange(c)
+	if !ok {
+		return
+	}
+
+	if strings.TrimSpace(c.Query("type")) == "orders" {
+		h.exportOrders(c, from, to)
+		return
+	}
+
+	report, err := h.fxSettlementService.MonthlySummary(from, to)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+
+	headers := []string{
+		"month",
+		"currency",
+		"gateway",
+		"order_count",
+		"gross_amount",
+		"refunded_count",
+		"refunded_amount",
+		"base_currency",
+		"base_amount",
+		"refunded_base_amount",
+		"net_base_amount",
+		"unconverted_count",
+	}
+	rows := make([][]string, 0, len(report.Rows))
+	for _, item := range report.Rows {
+		rows = append(rows, []string{
+			item.Month,
+			item.Currency,
+			item.Gateway,
+			strconv.FormatInt(item.OrderCount, 10),
+			money.MinorToString(item.GrossAmountMinor),
+			strconv.FormatInt(item.RefundedCount, 10),
+			money.MinorToString(item.RefundedAmountMinor),
+			item.BaseCurrency,
+			money.MinorToString(item.BaseAmountMinor),
+			money.MinorToString(item.RefundedBaseMinor),
+			money.MinorToString(item.NetBaseAmountMinor),
+			strconv.FormatInt(item.UnconvertedCount, 10),
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName("fx_settlement_"+report.From+"_"+report.To), headers, rows)
+}
+
+func (h *FXSettlementHandler) exportOrders(c *gin.Context, from, to time.Time) {
+	items, err := h.fxSettlementService.ListOrders(from, to, adminCSVExportMaxRows+1)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+	if len(items) > adminCSVExportMaxRows {
+		response.BadRequest(c, fmt.Sprintf("Too many records to export (max %d). Please narrow the filters.", adminCSVExportMaxRows))
+		return
+	}
+
+	headers := []string{
+		"order_no",
+		"status",
+		"paid_at",
+		"currency",
+		"gateway",
+		"amount",
+		"refunded_amount",
+		"fx_rate",
+		"base_currency",
+		"base_amount",
+		"refunded_base_amount",
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rate, baseAmount, refundedBase := "", "", ""
+		if item.Converted() {
+			rate = strconv.FormatFloat(item.FXRate, 'f', -1, 64)
+			baseAmount = money.MinorToString(item.BaseAmountMinor)
+			refundedBase = money.MinorToString(item.RefundedBaseMinor)
+		}
+		rows = append(rows, []string{
+			item.OrderNo,
+			item.Status,
+			csvTimeValue(item.PaidAt),
+			item.Currency,
+			item.Gateway,
+			money.MinorToString(item.AmountMinor),
+			money.MinorToString(item.RefundedAmountMinor),
+			rate,
+			item.BaseCurrency,
+			baseAmount,
+			refundedBase,
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName("fx_settlement_orders"), headers, rows)
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"errors"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type GiftCardHandler struct {
+	giftCardService *service.GiftCardService
+	db              *gorm.DB
+}
+
+func NewGiftCardHandler(giftCardService *service.GiftCardService, db *gorm.DB) *GiftCardHandler {
+	return &GiftCardHandler{giftCardService: giftCardService, db: db}
+}
+
+// IssueGiftCardRequest 发行礼品卡请求，expires_at 为空时按配置的默认有效期计算
+type IssueGiftCardRequest struct {
+	Count       int        `json:"count"`
+	ValueType   string     `json:"value_type" binding:"required"`
+	AmountMinor int64      `json:"amount_minor" binding:"required"`
+	Currency    string     `json:"currency" binding:"omitempty,max=10"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	Note        string     `json:"note"`
+}
+
+// VoidGiftCardRequest 作废礼品卡请求
+type VoidGiftCardRequest struct {
+	Reason string `json:"reason"`
+}
+
+func respondGiftCardError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrGiftCardNotFound) {
+		response.NotFound(c, "Gift card not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListGiftCards 礼品卡列表
+func (h *GiftCardHandler) ListGiftCards(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	cards, total, err := h.giftCardService.List(c.Query("status"), c.Query("search"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get gift cards")
+		return
+	}
+	response.Paginated(c, cards, page, limit, total)
+}
+
+// GetGiftCard 礼品卡详情与余额流水
+func (h *GiftCardHandler) GetGiftCard(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid gift card ID")
+		return
+	}
+	card, transactions, err := h.giftCardService.Get(id)
+	if err != nil {
+		respondGiftCardError(c, err, "Failed to get gift card")
+		return
+	}
+	response.Success(c, gin.H{"gift_card": card, "transactions": transactions})
+}
+
+// IssueGiftCards 批量发行礼品卡
+func (h *GiftCardHandler) IssueGiftCards(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req IssueGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	cards, err := h.giftCardService.Issue(service.GiftCardIssueRequest{
+		Count:     req.Count,
+		ValueType: models.GiftCardValueType(req.ValueType),
+		Amount:    req.AmountMinor,
+		Currency:  req.Currency,
+		ExpiresAt: req.ExpiresAt,
+		Note:      req.Note,
+		AdminID:   adminID,
+	})
+	if err != nil {
+		respondGiftCardError(c, err, "Failed to issue gift cards")
+		return
+	}
+	logger.LogOperation(h.db, c, "issue_gift_cards", "gift_card", nil, map[string]interface{}{
+		"count":        len(cards),
+		"value_type":   req.ValueType,
+		"amount_minor": req.AmountMinor,
+		"currency":     cards[0].Currency,
+	})
+	response.Success(c, gin.H{"items": cards})
+}
+
+// VoidGiftCard 作废礼品卡
+func (h *GiftCardHandler) VoidGiftCard(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid gift card ID")
+		return
+	}
+	var req VoidGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	card, err := h.giftCardService.Void(id, adminID, req.Reason)
+	if err != nil {
+		respondGiftCardError(c, err, "Failed to void gift card")
+		return
+	}
+	logger.LogOperation(h.db, c, "void_gift_card", "gift_card", &card.ID, map[string]interface{}{
+		"code":   card.Code,
+		"reason": card.VoidReason,
+	})
+	response.Success(c, card)
+}
@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InboundWebhookHandler 入站 Webhook 端点与事件收件箱管理
+type InboundWebhookHandler struct {
+	webhookService *service.InboundWebhookService
+	db             *gorm.DB
+}
+
+func NewInboundWebhookHandler(webhookService *service.InboundWebhookService, db *gorm.DB) *InboundWebhookHandler {
+	return &InboundWebhookHandler{webhookService: webhookService, db: db}
+}
+
+// InboundWebhookRequest 创建/更新入站 Webhook 请求，secret 为空时创建自动生成、更新保持不变
+type InboundWebhookRequest struct {
+	Name            string   `json:"name" binding:"required"`
+	Slug            string   `json:"slug" binding:"required"`
+	Description     string   `json:"description"`
+	AuthMode        string   `json:"auth_mode"`
+	SignatureHeader string   `json:"signature_header"`
+	EventIDHeader   string   `json:"event_id_header"`
+	Secret          string   `json:"secret"`
+	Script          string   `json:"script" binding:"required"`
+	AllowedActions  []string `json:"allowed_actions"`
+	Enabled         bool     `json:"enabled"`
+}
+
+// TestInboundWebhookRequest 试运行请求，script 为空时使用已保存的脚本
+type TestInboundWebhookRequest struct {
+	Script  string            `json:"script"`
+	Payload string            `json:"payload"`
+	Headers map[string]string `json:"headers"`
+}
+
+func (req InboundWebhookRequest) input() service.InboundWebhookInput {
+	return service.InboundWebhookInput{
+		Name:            req.Name,
+		Slug:            req.Slug,
+		Description:     req.Description,
+		AuthMode:        models.InboundWebhookAuthMode(strings.TrimSpace(req.AuthMode)),
+		SignatureHeader: req.SignatureHeader,
+		EventIDHeader:   req.EventIDHeader,
+		Secret:          req.Secret,
+		Script:          req.Script,
+		AllowedActions:  req.AllowedActions,
+		Enabled:         req.Enabled,
+	}
+}
+
+// ListWebhooks 入站 Webhook 列表
+func (h *InboundWebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.List()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, webhooks)
+}
+
+// GetWebhook 入站 Webhook 详情
+func (h *InboundWebhookHandler) GetWebhook(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	webhook, err := h.webhookService.Get(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, webhook)
+}
+
+// CreateWebhook 新建入站 Webhook，响应中的 secret 只返回这一次
+func (h *InboundWebhookHandler) CreateWebhook(c *gin.Context) {
+	var req InboundWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	webhook, secret, err := h.webhookService.Create(req.input())
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to create inbound webhook")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "create", "inbound_webhook", &webhook.ID, map[string]interface{}{
+		"name":            webhook.Name,
+		"slug":            webhook.Slug,
+		"allowed_actions": webhook.AllowedActions,
+		"enabled":         webhook.Enabled,
+	})
+	response.Success(c, gin.H{"webhook": webhook, "secret": secret})
+}
+
+// UpdateWebhook 更新入站 Webhook
+func (h *InboundWebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	var req InboundWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	webhook, err := h.webhookService.Update(id, req.input())
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update inbound webhook")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update", "inbound_webhook", &webhook.ID, map[string]interface{}{
+		"name":            webhook.Name,
+		"slug":            webhook.Slug,
+		"allowed_actions": webhook.AllowedActions,
+		"enabled":         webhook.Enabled,
+		"secret_changed":  strings.TrimSpace(req.Secret) != "",
+	})
+	response.Success(c, webhook)
+}
+
+// RotateSecret 重新生成密钥，旧密钥立即失效
+func (h *InboundWebhookHandler) RotateSecret(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	webhook, secret, err := h.webhookService.RotateSecret(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to rotate secret")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "rotate_secret", "inbound_webhook", &webhook.ID, map[string]interface{}{
+		"slug": webhook.Slug,
+	})
+	response.Success(c, gin.H{"webhook": webhook, "secret": secret})
+}
+
+// DeleteWebhook 删除入站 Webhook 及其事件记录
+func (h *InboundWebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	webhook, err := h.webhookService.Delete(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to delete inbound webhook")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "delete", "inbound_webhook", &webhook.ID, map[string]interface{}{
+		"name": webhook.Name,
+		"slug": webhook.Slug,
+	})
+	response.Success(c, gin.H{"id": webhook.ID})
+}
+
+// TestWebhook 用示例负载试运行转换脚本，不执行动作
+func (h *InboundWebhookHandler) TestWebhook(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	var req TestInboundWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	result, err := h.webhookService.Test(id, req.Script, req.Payload, req.Headers)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to run script")
+		}
+		return
+	}
+	response.Success(c, result)
+}
+
+// ListEvents 入站事件收件箱
+func (h *InboundWebhookHandler) ListEvents(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	page, limit := response.GetPagination(c)
+	events, total, err := h.webhookService.ListEvents(id, strings.TrimSpace(c.Query("status")), page, limit)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, events, page, limit, total)
+}
+
+// GetEvent 入站事件详情
+func (h *InboundWebhookHandler) GetEvent(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	eventID, err := middleware.GetUintParam(c, "event_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID")
+		return
+	}
+	event, err := h.webhookService.GetEvent(id, eventID)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, event)
+}
+
+// ReplayEvent 用当前脚本重新处理事件
+func (h *InboundWebhookHandler) ReplayEvent(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	eventID, err := middleware.GetUintParam(c, "event_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID")
+		return
+	}
+	event, err := h.webhookService.ReplayEvent(id, eventID)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to replay event")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "replay_event", "inbound_webhook", &id, map[string]interface{}{
+		"event_id": event.ID,
+		"status":   event.Status,
+		"attempts": event.Attempts,
+	})
+	response.Success(c, event)
+}
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"strconv"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// IntegrationHandler Zapier / Make 等无代码平台的轮询触发器与动作接口
+type IntegrationHandler struct {
+	integrationService *service.IntegrationService
+	db                 *gorm.DB
+}
+
+func NewIntegrationHandler(integrationService *service.IntegrationService, db *gorm.DB) *IntegrationHandler {
+	return &IntegrationHandler{integrationService: integrationService, db: db}
+}
+
+// IntegrationTicketReplyRequest 回复工单
+type IntegrationTicketReplyRequest struct {
+	TicketNo string `json:"ticket_no" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+}
+
+// IntegrationOrderTagRequest 为订单添加标签
+type IntegrationOrderTagRequest struct {
+	OrderNo string `json:"order_no" binding:"required"`
+	Tag     string `json:"tag" binding:"required"`
+}
+
+// Me 连接测试，平台用返回的 label 标识已连接的账户
+func (h *IntegrationHandler) Me(c *gin.Context) {
+	if middleware.IsAPIKeyAuth(c) {
+		keyID, _ := c.Get("api_key_id")
+		var key models.APIKey
+		if err := h.db.Select("id", "key_name", "platform", "scopes").First(&key, keyID).Error; err != nil {
+			response.InternalError(c, "Query failed")
+			return
+		}
+		response.Success(c, gin.H{
+			"auth_type": "api_key",
+			"label":     key.KeyName,
+			"platform":  key.Platform,
+			"scopes":    key.Scopes,
+		})
+		return
+	}
+	email, _ := c.Get("user_email")
+	response.Success(c, gin.H{"auth_type": "jwt", "label": email})
+}
+
+// PaidOrders 轮询触发器：新付款订单，返回扁平数组
+func (h *IntegrationHandler) PaidOrders(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	orders, err := h.integrationService.ListPaidOrders(c.Query("cursor"), limit)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, orders)
+}
+
+// ReplyTicket 动作：回复工单
+func (h *IntegrationHandler) ReplyTicket(c *gin.Context) {
+	adminID, adminIDOK := middleware.RequireUserID(c)
+	if !adminIDOK {
+		return
+	}
+	var req IntegrationTicketReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	message, err := h.integrationService.ReplyToTicket(adminID, req.TicketNo, req.Content)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Send failed")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "integration_reply", "ticket", &message.TicketID, map[string]interface{}{
+		"ticket_no":  req.TicketNo,
+		"message_id": message.ID,
+	})
+	response.Success(c, gin.H{
+		"id":         message.ID,
+		"ticket_no":  req.TicketNo,
+		"content":    message.Content,
+		"created_at": message.CreatedAt,
+	})
+}
+
+// AddOrderTag 动作：为订单添加标签，重复添加返回 added=false
+func (h *IntegrationHandler) AddOrderTag(c *gin.Context) {
+	var req IntegrationOrderTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	order, added, err := h.integrationService.AddOrderTag(req.OrderNo, req.Tag)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Update failed")
+		}
+		return
+	}
+
+	if added {
+		logger.LogOperation(h.db, c, "add_tag", "order", &order.ID, map[string]interface{}{
+			"order_no": order.OrderNo,
+			"tags":     order.Tags,
+		})
+	}
+	response.Success(c, gin.H{
+		"id":       order.ID,
+		"order_no": order.OrderNo,
+		"tags":     order.Tags,
+		"added":    added,
+	})
+}
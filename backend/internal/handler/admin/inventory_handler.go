@@ -17,6 +17,7 @@ type InventoryHandler struct {
 	inventoryService *service.InventoryService
 	db               *gorm.DB
 	pluginManager    *service.PluginManagerService
+	flashSale        *service.FlashSaleService
 }
 
 func NewInventoryHandler(inventoryService *service.InventoryService, db *gorm.DB, pluginManager *service.PluginManagerService) *InventoryHandler {
@@ -27,6 +28,10 @@ func NewInventoryHandler(inventoryService *service.InventoryService, db *gorm.DB
 	}
 }
 
+func (h *InventoryHandler) SetFlashSaleService(flashSale *service.FlashSaleService) {
+	h.flashSale = flashSale
+}
+
 // CreateInventoryRequest CreateInventory请求（独立Create，不need关联Product）
 type CreateInventoryRequest struct {
 	Name              string            `json:"name" binding:"required"` // Inventory配置名称
@@ -45,8 +50,10 @@ type UpdateInventoryRequest struct {
 	AvailableQuantity int    `json:"available_quantity" binding:"required,min=0"`
 	SafetyStock       int    `json:"safety_stock" binding:"min=0"`
 	IsActive          bool   `json:"is_active"`
+	FlashSale         *bool  `json:"flash_sale,omitempty"` // 为空时保持不变
 	AlertEmail        string `json:"alert_email,omitempty"`
 	Notes             string `json:"notes,omitempty"`
+	Version           uint   `json:"version,omitempty"` // 读取时的版本号，也可通过 If-Match 请求头提交
 }
 
 // AdjustStockRequest 调整库存请求
@@ -55,13 +62,14 @@ type AdjustStockRequest struct {
 	AvailableQuantityDelta int    `json:"available_quantity_delta" binding:"required"` // 可售数量增量
 	Reason                 string `json:"reason" binding:"required"`
 	Notes                  string `json:"notes,omitempty"`
+	DryRun                 bool   `json:"dry_run"` // 只返回调整前后的数量，不写入
 }
 
 // CreateInventory CreateInventory配置（独立Create，之后通过绑定API关联到Product）
 func (h *InventoryHandler) CreateInventory(c *gin.Context) {
 	var req CreateInventoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -191,7 +199,12 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 
 	var req UpdateInventoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
+		return
+	}
+	expectedVersion, ok := requestedRecordVersion(c, req.Version)
+	if !ok {
+		response.BadRequest(c, "Invalid version")
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -221,6 +234,7 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 					"available_quantity": beforeInventory.AvailableQuantity,
 					"safety_stock":       beforeInventory.SafetyStock,
 					"is_active":          beforeInventory.IsActive,
+					"flash_sale":         beforeInventory.FlashSale,
 					"alert_email":        beforeInventory.AlertEmail,
 					"notes":              beforeInventory.Notes,
 				}
@@ -258,6 +272,7 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 
 	err := h.inventoryService.UpdateInventory(
 		inventoryID,
+		expectedVersion,
 		req.Stock,
 		req.AvailableQuantity,
 		req.SafetyStock,
@@ -271,6 +286,15 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	if req.FlashSale != nil {
+		if err := h.inventoryService.SetFlashSale(inventoryID, *req.FlashSale); err != nil {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.InternalError(c, "Failed to update flash sale mode")
+			return
+		}
+	}
 
 	// getUpdate后的InventoryInfo
 	inventory, _ := h.inventoryService.GetInventory(inventoryID)
@@ -294,6 +318,8 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 		"after_available_quantity":  inventory.AvailableQuantity,
 		"before_is_active":          beforeInventory.IsActive,
 		"after_is_active":           inventory.IsActive,
+		"before_flash_sale":         beforeInventory.FlashSale,
+		"after_flash_sale":          inventory.FlashSale,
 	})
 	if h.pluginManager != nil && inventory != nil {
 		afterPayload := map[string]interface{}{
@@ -308,6 +334,7 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 			"alert_email":               inventory.AlertEmail,
 			"notes":                     inventory.Notes,
 			"is_active":                 inventory.IsActive,
+			"flash_sale":                inventory.FlashSale,
 			"before_stock":              beforeInventory.Stock,
 			"before_available_quantity": beforeInventory.AvailableQuantity,
 			"before_safety_stock":       beforeInventory.SafetyStock,
@@ -332,6 +359,7 @@ func (h *InventoryHandler) UpdateInventory(c *gin.Context) {
 		})), afterPayload)
 	}
 
+	setVersionETag(c, strconv.FormatUint(uint64(inventory.Version), 10))
 	response.Success(c, inventory)
 }
 
@@ -345,9 +373,23 @@ func (h *InventoryHandler) GetInventory(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, strconv.FormatUint(uint64(inventory.Version), 10))
 	response.Success(c, inventory)
 }
 
+// GetFlashSaleStatus 查看秒杀库存的 Redis 剩余计数与尚未写回数据库的预留
+func (h *InventoryHandler) GetFlashSaleStatus(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	inventory, err := h.inventoryService.GetInventory(uint(id))
+	if err != nil {
+		response.NotFound(c, "Inventory record does not exist")
+		return
+	}
+
+	response.Success(c, h.flashSale.Status(inventory))
+}
+
 // ListInventories Inventory列表
 func (h *InventoryHandler) ListInventories(c *gin.Context) {
 	page, limit := response.GetPagination(c)
@@ -489,7 +531,23 @@ func (h *InventoryHandler) AdjustStock(c *gin.Context) {
 
 	var req AdjustStockRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
+		return
+	}
+	dryRun, ok := dryRunRequested(c, req.DryRun)
+	if !ok {
+		return
+	}
+	if dryRun {
+		preview, err := h.inventoryService.PreviewAdjustStockByDelta(inventoryID, req.StockDelta, req.AvailableQuantityDelta)
+		if err != nil {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.Success(c, preview)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
 	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -64,6 +65,21 @@ func (h *InventoryLogHandler) ListInventoryLogs(c *gin.Context) {
 
 	query := h.buildInventoryLogQuery(c)
 
+	if cursor, ok := response.GetCursor(c); ok {
+		after, err := dbutil.DecodeCursor(cursor)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		if err := dbutil.ApplyCursor(query, after, limit).Find(&logs).Error; err != nil {
+			response.InternalError(c, "Query failed")
+			return
+		}
+		logs, nextCursor := dbutil.CursorPage(logs, limit, func(l models.InventoryLog) (time.Time, uint) { return l.CreatedAt, l.ID })
+		response.CursorPaginated(c, logs, limit, nextCursor)
+		return
+	}
+
 	// get总数
 	if err := query.Count(&total).Error; err != nil {
 		response.InternalError(c, "Query failed")
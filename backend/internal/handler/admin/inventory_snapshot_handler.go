@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InventorySnapshotHandler 每日库存快照与历史时点查询
+type InventorySnapshotHandler struct {
+	snapshotService *service.InventorySnapshotService
+	db              *gorm.DB
+}
+
+func NewInventorySnapshotHandler(snapshotService *service.InventorySnapshotService, db *gorm.DB) *InventorySnapshotHandler {
+	return &InventorySnapshotHandler{snapshotService: snapshotService, db: db}
+}
+
+// bindSnapshotFilter 解析 source、search 过滤参数
+func bindSnapshotFilter(c *gin.Context) (service.InventorySnapshotFilter, bool) {
+	filter := service.InventorySnapshotFilter{Source: c.Query("source"), Search: c.Query("search")}
+	switch filter.Source {
+	case "", models.InventorySnapshotSourcePhysical, models.InventorySnapshotSourceVirtual:
+		return filter, true
+	default:
+		response.BadRequest(c, "Invalid source filter")
+		return filter, false
+	}
+}
+
+// GetSnapshot 查询指定日期（date=YYYY-MM-DD，默认今天）的库存水平
+func (h *InventorySnapshotHandler) GetSnapshot(c *gin.Context) {
+	filter, ok := bindSnapshotFilter(c)
+	if !ok {
+		return
+	}
+	result, err := h.snapshotService.GetSnapshot(c.Query("date"), filter)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to get inventory snapshot")
+		}
+		return
+	}
+	response.Success(c, result)
+}
+
+// Compare 对比 from 与 to 两个日期的库存水平
+func (h *InventorySnapshotHandler) Compare(c *gin.Context) {
+	filter, ok := bindSnapshotFilter(c)
+	if !ok {
+		return
+	}
+	result, err := h.snapshotService.Compare(c.Query("from"), c.Query("to"), filter)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to compare inventory snapshots")
+		}
+		return
+	}
+	response.Success(c, result)
+}
+
+// TakeSnapshot 立即拍摄当天快照，覆盖当天已有数据
+func (h *InventorySnapshotHandler) TakeSnapshot(c *gin.Context) {
+	result, err := h.snapshotService.TakeSnapshot(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, "Failed to take inventory snapshot")
+		return
+	}
+	logger.LogOperation(h.db, c, "inventory_snapshot", "inventory_snapshot", nil, map[string]interface{}{
+		"snapshot_date": result.SnapshotDate,
+		"count":         len(result.Items),
+	})
+	response.Success(c, result)
+}
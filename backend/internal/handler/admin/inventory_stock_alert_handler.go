@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"strconv"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InventoryStockAlertHandler 低库存告警状态与静默
+type InventoryStockAlertHandler struct {
+	alertService *service.InventoryStockAlertService
+	db           *gorm.DB
+}
+
+func NewInventoryStockAlertHandler(alertService *service.InventoryStockAlertService, db *gorm.DB) *InventoryStockAlertHandler {
+	return &InventoryStockAlertHandler{alertService: alertService, db: db}
+}
+
+// SnoozeLowStockAlertRequest 静默请求
+type SnoozeLowStockAlertRequest struct {
+	Minutes int `json:"minutes" binding:"required,min=1"`
+}
+
+// ListAlerts 低库存告警状态列表，low=true 时只返回当前低库存的库存
+func (h *InventoryStockAlertHandler) ListAlerts(c *gin.Context) {
+	lowOnly := false
+	if raw := c.Query("low"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid low filter")
+			return
+		}
+		lowOnly = value
+	}
+	alerts, err := h.alertService.ListAlerts(lowOnly)
+	if err != nil {
+		response.InternalError(c, "Failed to get low-stock alerts")
+		return
+	}
+	response.Success(c, gin.H{"items": alerts})
+}
+
+// RunCheck 立即检查一次并发送需要提醒的告警
+func (h *InventoryStockAlertHandler) RunCheck(c *gin.Context) {
+	result, err := h.alertService.RunNow(c.Request.Context())
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to check low stock")
+		}
+		return
+	}
+	logger.LogOperation(h.db, c, "low_stock_check", "inventory", nil, map[string]interface{}{
+		"low":      result.Low,
+		"alerted":  len(result.Alerted),
+		"channels": result.Channels,
+	})
+	response.Success(c, result)
+}
+
+// Snooze 在指定分钟内不再发送该库存的低库存告警
+func (h *InventoryStockAlertHandler) Snooze(c *gin.Context) {
+	var req SnoozeLowStockAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	h.setSnooze(c, req.Minutes)
+}
+
+// Unsnooze 取消静默
+func (h *InventoryStockAlertHandler) Unsnooze(c *gin.Context) {
+	h.setSnooze(c, 0)
+}
+
+func (h *InventoryStockAlertHandler) setSnooze(c *gin.Context, minutes int) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return
+	}
+	source := c.Param("source")
+	alert, err := h.alertService.Snooze(source, inventoryID, minutes, getOptionalUserID(c))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update snooze")
+		}
+		return
+	}
+	action := "low_stock_snooze"
+	if minutes == 0 {
+		action = "low_stock_unsnooze"
+	}
+	logger.LogOperation(h.db, c, action, "inventory", &inventoryID, map[string]interface{}{
+		"source":        source,
+		"minutes":       minutes,
+		"snoozed_until": alert.SnoozedUntil,
+	})
+	response.Success(c, alert)
+}
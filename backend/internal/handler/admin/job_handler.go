@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	scheduler *service.JobScheduler
+}
+
+func NewJobHandler(scheduler *service.JobScheduler) *JobHandler {
+	return &JobHandler{scheduler: scheduler}
+}
+
+// ListJobs 周期任务列表：周期、最近执行时间/结果/实例，多实例部署时执行记录共享
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	if h.scheduler == nil {
+		response.Success(c, gin.H{"instance_id": "", "items": []service.ScheduledJobInfo{}})
+		return
+	}
+	jobs, err := h.scheduler.ListJobs()
+	if err != nil {
+		response.InternalServerError(c, "Failed to load scheduled jobs", err)
+		return
+	}
+	response.Success(c, gin.H{
+		"instance_id": h.scheduler.InstanceID(),
+		"items":       jobs,
+	})
+}
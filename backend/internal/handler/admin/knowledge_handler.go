@@ -651,7 +651,7 @@ func (h *KnowledgeHandler) CreateCategory(c *gin.Context) {
 		SortOrder int    `json:"sort_order"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -759,7 +759,7 @@ func (h *KnowledgeHandler) UpdateCategory(c *gin.Context) {
 		SortOrder *int   `json:"sort_order"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -995,7 +995,7 @@ func (h *KnowledgeHandler) CreateArticle(c *gin.Context) {
 		SortOrder  int    `json:"sort_order"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -1112,7 +1112,7 @@ func (h *KnowledgeHandler) UpdateArticle(c *gin.Context) {
 		SortOrder  *int   `json:"sort_order"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
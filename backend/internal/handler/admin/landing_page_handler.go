@@ -185,7 +185,7 @@ func (h *LandingPageHandler) UpdateLandingPage(c *gin.Context) {
 		HTMLContent string `json:"html_content" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "html_content is required")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, adminIDOK := middleware.RequireUserID(c)
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LicenseActivationHandler 订单卡密的设备激活历史与解绑
+type LicenseActivationHandler struct {
+	bindingService *service.BindingService
+	db             *gorm.DB
+}
+
+func NewLicenseActivationHandler(bindingService *service.BindingService, db *gorm.DB) *LicenseActivationHandler {
+	return &LicenseActivationHandler{bindingService: bindingService, db: db}
+}
+
+// ListActivations 订单内卡密的设备激活历史
+func (h *LicenseActivationHandler) ListActivations(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	activations, err := h.bindingService.ListOrderActivations(orderID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": activations})
+}
+
+// DeactivateActivation 管理员解绑设备（如买家更换电脑后无法自行解绑）
+func (h *LicenseActivationHandler) DeactivateActivation(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	activationID, err := middleware.GetUintParam(c, "activation_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid activation ID")
+		return
+	}
+
+	activation, err := h.bindingService.DeactivateOrderActivation(orderID, activationID, models.LicenseDeactivatedByAdmin, adminID)
+	if err != nil {
+		if errors.Is(err, service.ErrLicenseActivationNotFound) {
+			response.NotFound(c, "Activation not found")
+			return
+		}
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to deactivate device")
+		}
+		return
+	}
+	logger.LogOperation(h.db, c, "deactivate_license_device", "license_activation", &activation.ID, map[string]interface{}{
+		"order_id":  orderID,
+		"order_no":  activation.OrderNo,
+		"device_id": activation.DeviceID,
+	})
+	response.Success(c, activation)
+}
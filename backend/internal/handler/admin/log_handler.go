@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
 	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/service"
@@ -154,6 +155,7 @@ func (h *LogHandler) buildEmailLogQuery(c *gin.Context) *gorm.DB {
 	eventType := c.Query("event_type")
 	toEmail := c.Query("to_email")
 	batchID := c.Query("batch_id")
+	campaign := c.Query("campaign")
 	startDate := c.Query("start_date")
 	endDate := c.Query("end_date")
 
@@ -170,6 +172,9 @@ func (h *LogHandler) buildEmailLogQuery(c *gin.Context) *gorm.DB {
 	if batchID != "" {
 		query = query.Where("batch_id = ?", batchID)
 	}
+	if campaign != "" {
+		query = query.Where("campaign = ?", campaign)
+	}
 	if startDate != "" {
 		if t, err := time.Parse("2006-01-02", startDate); err == nil {
 			query = query.Where("created_at >= ?", t)
@@ -230,6 +235,21 @@ func (h *LogHandler) ListOperationLogs(c *gin.Context) {
 		return
 	}
 
+	if cursor, ok := response.GetCursor(c); ok {
+		after, err := dbutil.DecodeCursor(cursor)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		if err := dbutil.ApplyCursor(query, after, limit).Find(&logs).Error; err != nil {
+			response.InternalError(c, "Query failed")
+			return
+		}
+		logs, nextCursor := dbutil.CursorPage(logs, limit, func(l models.OperationLog) (time.Time, uint) { return l.CreatedAt, l.ID })
+		response.CursorPaginated(c, logs, limit, nextCursor)
+		return
+	}
+
 	// get总数
 	if err := query.Count(&total).Error; err != nil {
 		response.InternalError(c, "Query failed")
@@ -255,6 +275,21 @@ func (h *LogHandler) ListEmailLogs(c *gin.Context) {
 
 	query := h.buildEmailLogQuery(c)
 
+	if cursor, ok := response.GetCursor(c); ok {
+		after, err := dbutil.DecodeCursor(cursor)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		if err := dbutil.ApplyCursor(query, after, limit).Find(&logs).Error; err != nil {
+			response.InternalError(c, "Query failed")
+			return
+		}
+		logs, nextCursor := dbutil.CursorPage(logs, limit, func(l models.EmailLog) (time.Time, uint) { return l.CreatedAt, l.ID })
+		response.CursorPaginated(c, logs, limit, nextCursor)
+		return
+	}
+
 	// get总数
 	if err := query.Count(&total).Error; err != nil {
 		response.InternalError(c, "Query failed")
@@ -280,6 +315,34 @@ func (h *LogHandler) ListSmsLogs(c *gin.Context) {
 
 	query := h.buildSMSLogQuery(c)
 
+	// 返回脱敏内容
+	type smsLogView struct {
+		models.SmsLog
+		MaskedContent string `json:"content"`
+	}
+	maskLogs := func(logs []models.SmsLog) []smsLogView {
+		items := make([]smsLogView, len(logs))
+		for i, l := range logs {
+			items[i] = smsLogView{SmsLog: l, MaskedContent: models.MaskContent(l.Content)}
+		}
+		return items
+	}
+
+	if cursor, ok := response.GetCursor(c); ok {
+		after, err := dbutil.DecodeCursor(cursor)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		if err := dbutil.ApplyCursor(query, after, limit).Find(&logs).Error; err != nil {
+			response.InternalError(c, "Query failed")
+			return
+		}
+		logs, nextCursor := dbutil.CursorPage(logs, limit, func(l models.SmsLog) (time.Time, uint) { return l.CreatedAt, l.ID })
+		response.CursorPaginated(c, maskLogs(logs), limit, nextCursor)
+		return
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		response.InternalError(c, "Query failed")
 		return
@@ -291,17 +354,7 @@ func (h *LogHandler) ListSmsLogs(c *gin.Context) {
 		return
 	}
 
-	// 返回脱敏内容
-	type smsLogView struct {
-		models.SmsLog
-		MaskedContent string `json:"content"`
-	}
-	items := make([]smsLogView, len(logs))
-	for i, l := range logs {
-		items[i] = smsLogView{SmsLog: l, MaskedContent: models.MaskContent(l.Content)}
-	}
-
-	response.Paginated(c, items, page, limit, total)
+	response.Paginated(c, maskLogs(logs), page, limit, total)
 }
 
 func (h *LogHandler) ExportOperationLogs(c *gin.Context) {
@@ -427,6 +480,7 @@ func (h *LogHandler) ExportEmailLogs(c *gin.Context) {
 			orderNo,
 			batchID,
 			batchNo,
+			item.Campaign,
 			string(item.Status),
 			item.ErrorMessage,
 			strconv.Itoa(item.RetryCount),
@@ -443,6 +497,7 @@ func (h *LogHandler) ExportEmailLogs(c *gin.Context) {
 		"event_type": strings.TrimSpace(c.Query("event_type")),
 		"to_email":   strings.TrimSpace(c.Query("to_email")),
 		"batch_id":   strings.TrimSpace(c.Query("batch_id")),
+		"campaign":   strings.TrimSpace(c.Query("campaign")),
 		"start_date": strings.TrimSpace(c.Query("start_date")),
 		"end_date":   strings.TrimSpace(c.Query("end_date")),
 		"format":     "xlsx",
@@ -459,6 +514,7 @@ func (h *LogHandler) ExportEmailLogs(c *gin.Context) {
 		"Order No",
 		"Batch ID",
 		"Batch No",
+		"Campaign",
 		"Status",
 		"Error Message",
 		"Retry Count",
@@ -631,7 +687,7 @@ type RetryEmailRequest struct {
 func (h *LogHandler) RetryFailedEmails(c *gin.Context) {
 	var req RetryEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -344,7 +344,7 @@ func (h *MarketingHandler) PreviewMarketing(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -558,7 +558,7 @@ func (h *MarketingHandler) SendMarketing(c *gin.Context) {
 		AudienceQuery *service.MarketingAudienceNode `json:"audience_query"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -0,0 +1,202 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ModerationHandler struct {
+	moderationService *service.ContentModerationService
+	db                *gorm.DB
+}
+
+func NewModerationHandler(moderationService *service.ContentModerationService, db *gorm.DB) *ModerationHandler {
+	return &ModerationHandler{moderationService: moderationService, db: db}
+}
+
+// ModerationRuleRequest 创建/更新审核词库规则请求
+type ModerationRuleRequest struct {
+	Pattern   string   `json:"pattern" binding:"required"`
+	MatchType string   `json:"match_type"`
+	Action    string   `json:"action" binding:"required"`
+	Sources   []string `json:"sources"`
+	Note      string   `json:"note"`
+	IsActive  bool     `json:"is_active"`
+}
+
+func (r *ModerationRuleRequest) toInput() service.ModerationRuleInput {
+	return service.ModerationRuleInput{
+		Pattern:   r.Pattern,
+		MatchType: models.ModerationMatchType(strings.TrimSpace(r.MatchType)),
+		Action:    models.ModerationAction(strings.TrimSpace(r.Action)),
+		Sources:   r.Sources,
+		Note:      r.Note,
+		IsActive:  r.IsActive,
+	}
+}
+
+// ReviewModerationCaseRequest 人工审核请求，decision 为 approve（保留内容）或 remove（移除内容）
+type ReviewModerationCaseRequest struct {
+	Decision string `json:"decision" binding:"required"`
+	Note     string `json:"note"`
+}
+
+// ListCases 审核队列，支持按状态与来源筛选
+func (h *ModerationHandler) ListCases(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	cases, total, err := h.moderationService.ListCases(
+		strings.TrimSpace(c.Query("status")),
+		strings.TrimSpace(c.Query("source")),
+		page, limit,
+	)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, cases, page, limit, total)
+}
+
+// GetPendingCount 待审核记录数量
+func (h *ModerationHandler) GetPendingCount(c *gin.Context) {
+	count, err := h.moderationService.PendingCount()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"pending_count": count})
+}
+
+// ReviewCase 人工审核：保留内容或从来源处移除
+func (h *ModerationHandler) ReviewCase(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid case ID")
+		return
+	}
+	var req ReviewModerationCaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	decision := models.ModerationCaseStatus(strings.TrimSpace(req.Decision))
+	switch decision {
+	case "approve":
+		decision = models.ModerationCaseStatusApproved
+	case "remove":
+		decision = models.ModerationCaseStatusRemoved
+	}
+
+	record, err := h.moderationService.ReviewCase(id, adminID, decision, req.Note)
+	if err != nil {
+		h.respondError(c, err, "Failed to review case")
+		return
+	}
+	logger.LogOperation(h.db, c, "review", "moderation_case", &record.ID, map[string]interface{}{
+		"source":    record.Source,
+		"source_id": record.SourceID,
+		"decision":  record.Status,
+	})
+	response.Success(c, record)
+}
+
+// ListRules 审核词库规则列表
+func (h *ModerationHandler) ListRules(c *gin.Context) {
+	rules, err := h.moderationService.ListRules()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": rules})
+}
+
+// CreateRule 创建审核词库规则
+func (h *ModerationHandler) CreateRule(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req ModerationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	rule, err := h.moderationService.CreateRule(adminID, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to create rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "create", "moderation_rule", &rule.ID, map[string]interface{}{
+		"match_type": rule.MatchType,
+		"action":     rule.Action,
+		"sources":    rule.Sources,
+	})
+	response.Success(c, rule)
+}
+
+// UpdateRule 更新审核词库规则
+func (h *ModerationHandler) UpdateRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	var req ModerationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	rule, err := h.moderationService.UpdateRule(id, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to update rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "update", "moderation_rule", &rule.ID, map[string]interface{}{
+		"match_type": rule.MatchType,
+		"action":     rule.Action,
+		"sources":    rule.Sources,
+		"is_active":  rule.IsActive,
+	})
+	response.Success(c, rule)
+}
+
+// DeleteRule 删除审核词库规则
+func (h *ModerationHandler) DeleteRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	if err := h.moderationService.DeleteRule(id); err != nil {
+		h.respondError(c, err, "Failed to delete rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "delete", "moderation_rule", &id, nil)
+	response.Success(c, nil)
+}
+
+func (h *ModerationHandler) respondError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrModerationRuleNotFound) {
+		response.NotFound(c, "Rule not found")
+		return
+	}
+	if errors.Is(err, service.ErrModerationCaseNotFound) {
+		response.NotFound(c, "Case not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
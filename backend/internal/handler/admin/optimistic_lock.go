@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// requestedVersion 读取客户端提交的版本：优先 If-Match 请求头，其次请求体中的 version 字段；都为空表示不校验
+func requestedVersion(c *gin.Context, bodyVersion string) string {
+	if header := strings.TrimSpace(c.GetHeader("If-Match")); header != "" && header != "*" {
+		return strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	}
+	return strings.TrimSpace(bodyVersion)
+}
+
+// requestedRecordVersion 读取数据库记录的版本号，返回 0 表示客户端未提交版本
+func requestedRecordVersion(c *gin.Context, bodyVersion uint) (uint, bool) {
+	raw := requestedVersion(c, "")
+	if raw == "" {
+		return bodyVersion, true
+	}
+	version, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(version), true
+}
+
+// contentVersion 配置文件、邮件模板等文件资源以内容摘要作为版本
+func contentVersion(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:8])
+}
+
+// setVersionETag 在响应头返回当前版本，客户端保存时通过 If-Match 回传
+func setVersionETag(c *gin.Context, version string) {
+	if version != "" {
+		c.Header("ETag", strconv.Quote(version))
+	}
+}
+
+// respondVersionConflict 文件资源版本不一致时返回 409，附带服务端当前版本
+func respondVersionConflict(c *gin.Context, currentVersion string) {
+	response.RespondBizError(c, bizerr.CodeVersionConflict.New().WithParams(map[string]interface{}{
+		"current_version": currentVersion,
+	}))
+}
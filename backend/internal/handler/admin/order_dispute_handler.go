@@ -0,0 +1,233 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxDisputeEvidenceFiles = 10
+
+type OrderDisputeHandler struct {
+	disputeService *service.OrderDisputeService
+	orderService   *service.OrderService
+	pluginManager  *service.PluginManagerService
+	db             *gorm.DB
+}
+
+func NewOrderDisputeHandler(disputeService *service.OrderDisputeService, orderService *service.OrderService, pluginManager *service.PluginManagerService, db *gorm.DB) *OrderDisputeHandler {
+	return &OrderDisputeHandler{
+		disputeService: disputeService,
+		orderService:   orderService,
+		pluginManager:  pluginManager,
+		db:             db,
+	}
+}
+
+// DisputeEvidenceRequest 商家答辩请求
+type DisputeEvidenceRequest struct {
+	Statement string   `json:"statement" binding:"required"`
+	Evidence  []string `json:"evidence"` // 通过 /tickets/:id/upload 上传的文件URL
+}
+
+// DisputeDecisionRequest 平台裁决请求
+type DisputeDecisionRequest struct {
+	Decision string `json:"decision" binding:"required"` // refund_full, refund_partial, no_refund
+	Items    []struct {
+		ItemIndex int `json:"item_index"`
+		Quantity  int `json:"quantity"`
+	} `json:"items"`
+	AmountMinor int64  `json:"amount_minor"` // 部分退款金额，0表示按订单项单价计算
+	Note        string `json:"note" binding:"required"`
+}
+
+func respondOrderDisputeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrOrderDisputeNotFound):
+		response.NotFound(c, "Dispute not found")
+	case errors.Is(err, service.ErrDisputeOrderNotFound):
+		response.NotFound(c, "Order not found")
+	default:
+		var declined *service.RefundDeclinedError
+		if errors.As(err, &declined) || errors.Is(err, service.ErrRefundExecution) {
+			respondAdminRefundError(c, err)
+			return
+		}
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// normalizeDisputeEvidence 商家证据只能引用工单附件上传目录中的文件
+func normalizeDisputeEvidence(cfg *config.Config, raw []string) ([]string, bool) {
+	if len(raw) > maxDisputeEvidenceFiles {
+		return nil, false
+	}
+	prefix := cfg.App.URL + "/uploads/tickets/"
+	result := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		fileURL := strings.TrimSpace(item)
+		if fileURL == "" {
+			continue
+		}
+		if !strings.HasPrefix(fileURL, prefix) || strings.Contains(fileURL, "..") || strings.ContainsAny(fileURL, "?#()[] ") {
+			return nil, false
+		}
+		if _, exists := seen[fileURL]; exists {
+			continue
+		}
+		seen[fileURL] = struct{}{}
+		result = append(result, fileURL)
+	}
+	return result, true
+}
+
+// ListDisputes 争议队列
+func (h *OrderDisputeHandler) ListDisputes(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	disputes, total, err := h.disputeService.List(c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get disputes")
+		return
+	}
+	response.Paginated(c, disputes, page, limit, total)
+}
+
+// GetDispute 争议详情
+func (h *OrderDisputeHandler) GetDispute(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid dispute ID")
+		return
+	}
+	dispute, err := h.disputeService.Get(id)
+	if err != nil {
+		respondOrderDisputeError(c, err, "Failed to get dispute")
+		return
+	}
+	response.Success(c, dispute)
+}
+
+// SubmitEvidence 商家提交答辩与证据
+func (h *OrderDisputeHandler) SubmitEvidence(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid dispute ID")
+		return
+	}
+	var req DisputeEvidenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	evidence, valid := normalizeDisputeEvidence(config.GetConfig(), req.Evidence)
+	if !valid {
+		response.BadRequest(c, "Invalid evidence attachments")
+		return
+	}
+
+	dispute, err := h.disputeService.SubmitMerchantEvidence(id, adminID, service.DisputeEvidenceInput{
+		Statement: validator.SanitizeMarkdown(req.Statement),
+		Evidence:  evidence,
+	})
+	if err != nil {
+		respondOrderDisputeError(c, err, "Failed to submit evidence")
+		return
+	}
+	logger.LogOperation(h.db, c, "submit_dispute_evidence", "order_dispute", &dispute.ID, map[string]interface{}{
+		"dispute_no": dispute.DisputeNo,
+		"order_no":   dispute.OrderNo,
+		"evidence":   len(evidence),
+	})
+	response.Success(c, dispute)
+}
+
+// DecideDispute 记录平台裁决，退款类裁决立即执行退款
+func (h *OrderDisputeHandler) DecideDispute(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid dispute ID")
+		return
+	}
+	var req DisputeDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	items := make([]service.OrderRefundItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.OrderRefundItemInput{ItemIndex: item.ItemIndex, Quantity: item.Quantity})
+	}
+
+	dispute, refunded, err := h.disputeService.Decide(id, adminID, service.DisputeDecisionInput{
+		Decision: req.Decision,
+		Items:    items,
+		Amount:   req.AmountMinor,
+		Note:     validator.SanitizeMarkdown(req.Note),
+	})
+	if err != nil {
+		respondOrderDisputeError(c, err, "Failed to decide dispute")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "decide_dispute", "order_dispute", &dispute.ID, map[string]interface{}{
+		"dispute_no":      dispute.DisputeNo,
+		"order_no":        dispute.OrderNo,
+		"decision":        dispute.Decision,
+		"decision_amount": dispute.DecisionAmount,
+		"refund_status":   dispute.RefundStatus,
+	})
+	if refunded != nil {
+		order := refunded.Order
+		logger.LogOrderOperation(h.db, c, "refund", order.ID, map[string]interface{}{
+			"order_no":        order.OrderNo,
+			"dispute_id":      dispute.ID,
+			"order_refund_id": dispute.OrderRefundID,
+			"status_after":    refunded.StatusAfter,
+			"refund_amount":   dispute.DecisionAmount,
+			"refund_pending":  refunded.Result.Pending,
+			"refund_message":  refunded.Result.Message,
+			"transaction_id":  refunded.Result.TransactionID,
+		})
+		if refunded.StatusAfter != refunded.StatusBefore {
+			if order.UserID != nil {
+				if err := h.orderService.SyncUserConsumptionStats(*order.UserID); err != nil {
+					logger.LogOrderOperation(h.db, c, "sync_user_consumption_stats_failed", order.ID, map[string]interface{}{
+						"order_no": order.OrderNo,
+						"user_id":  *order.UserID,
+						"error":    err.Error(),
+					})
+				}
+			}
+			service.PublishOrderStatusChanged(h.orderService.OrderRepo, h.pluginManager, nil, order, refunded.StatusBefore, refunded.StatusAfter, map[string]interface{}{
+				"source":          "admin_api",
+				"trigger_action":  "order.dispute.decide",
+				"admin_id":        adminID,
+				"dispute_id":      dispute.ID,
+				"transaction_id":  refunded.Result.TransactionID,
+				"refund_pending":  refunded.Result.Pending,
+				"payment_message": refunded.Result.Message,
+			})
+		}
+	}
+
+	response.Success(c, dispute)
+}
@@ -8,9 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"auralogic/internal/database"
 	"auralogic/internal/models"
-	"auralogic/internal/pkg/constants"
 	"auralogic/internal/pkg/response"
+	"auralogic/internal/repository"
 	"auralogic/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/xuri/excelize/v2"
@@ -24,6 +25,10 @@ type ExportOrdersRequest struct {
 	ProductSearch string `form:"product_search" json:"product_search"` // ProductSKU/名称搜索
 	PromoCode     string `form:"promo_code" json:"promo_code"`         // Promo code
 	PromoCodeID   string `form:"promo_code_id" json:"promo_code_id"`   // Promo code id
+	SKU           string `form:"sku" json:"sku"`                       // 订单项 SKU 精确匹配
+	From          string `form:"from" json:"from"`                     // 创建日期起（YYYY-MM-DD，含）
+	To            string `form:"to" json:"to"`                         // 创建日期止（YYYY-MM-DD，含）
+	Format        string `form:"format" json:"format"`                 // xlsx（默认）或 csv
 }
 
 type orderImportEntry struct {
@@ -70,11 +75,11 @@ func buildOrderImportEntries(rows [][]string) []orderImportEntry {
 	return entries
 }
 
-// ExportOrders 导出Order到Excel
+// ExportOrders 导出Order到Excel或CSV
 func (h *OrderHandler) ExportOrders(c *gin.Context) {
 	var req ExportOrdersRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -119,8 +124,16 @@ func (h *OrderHandler) ExportOrders(c *gin.Context) {
 		}
 	}
 
-	// get所有匹配条件的Order（不分页）
-	promoCode := strings.ToUpper(strings.TrimSpace(req.PromoCode))
+	format, err := service.NormalizeOrderExportFormat(req.Format, service.OrderExportFormatXLSX)
+	if err != nil {
+		respondAdminBizError(c, err)
+		return
+	}
+	from, to, err := service.ParseOrderExportDateRange(req.From, req.To)
+	if err != nil {
+		respondAdminBizError(c, err)
+		return
+	}
 	var promoCodeID *uint
 	if req.PromoCodeID != "" {
 		if pid, err := strconv.ParseUint(req.PromoCodeID, 10, 32); err == nil {
@@ -128,180 +141,43 @@ func (h *OrderHandler) ExportOrders(c *gin.Context) {
 			promoCodeID = &pidUint
 		}
 	}
-	orders, _, err := h.orderService.ListOrders(1, 10000, req.Status, req.Search, req.Country, req.ProductSearch, promoCodeID, promoCode, nil)
-	if err != nil {
-		response.InternalError(c, "QueryOrderFailed")
-		return
+	filter := repository.OrderExportFilter{
+		Status:        req.Status,
+		Search:        req.Search,
+		Country:       req.Country,
+		ProductSearch: req.ProductSearch,
+		SKU:           strings.TrimSpace(req.SKU),
+		PromoCodeID:   promoCodeID,
+		PromoCode:     strings.ToUpper(strings.TrimSpace(req.PromoCode)),
+		From:          from,
+		To:            to,
 	}
 
 	// Check if admin has privacy view permission
 	hasPrivacyPerm := h.hasPrivacyPermission(c)
-	for i := range orders {
-		h.orderService.MaskOrderIfNeeded(&orders[i], hasPrivacyPerm)
-	}
-
-	// CreateExcel文件
-	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
-		}
-	}()
-
-	// Create工作表
-	sheetName := "Order List"
-	index, err := f.NewSheet(sheetName)
-	if err != nil {
-		response.InternalError(c, "CreateExcelFailed")
-		return
-	}
 
-	// Delete默认的Sheet1
-	f.DeleteSheet("Sheet1")
-
-	// 设置表头样式
-	headerStyle, err := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold: true,
-			Size: 12,
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"#4472C4"},
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "center",
-			Vertical:   "center",
+	// 流式写出：按批查询订单并直接写入响应，导出量不受内存限制
+	fileName := fmt.Sprintf("Order List_%s.%s", time.Now().Format("20060102_150405"), format)
+	c.Header("Content-Type", service.OrderExportContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	c.Header("Cache-Control", "no-store")
+	exportedCount, err := service.NewOrderExportService(database.GetDB()).Export(c.Request.Context(), c.Writer, service.OrderExportRequest{
+		Format:    format,
+		SheetName: "Order List",
+		Filter:    filter,
+		Columns:   service.AdminOrderExportColumns(),
+		Prepare: func(order *models.Order) {
+			h.orderService.MaskOrderIfNeeded(order, hasPrivacyPerm)
 		},
 	})
 	if err != nil {
-		response.InternalError(c, "Failed to set style")
-		return
-	}
-
-	// 设置表头
-	headers := []string{
-		"Order No.", "User Email", "Order Status", "Privacy Protected",
-		"Recipient", "Phone", "Country", "Province", "City", "District", "Address", "Postcode",
-		"Tracking No.",
-		"Created At", "Completed At",
-	}
-
-	for i, header := range headers {
-		cell := string(rune('A'+i)) + "1"
-		f.SetCellValue(sheetName, cell, header)
-		f.SetCellStyle(sheetName, cell, cell, headerStyle)
-	}
-
-	// 设置列宽
-	colWidths := map[string]float64{
-		"A": 18, // Order号
-		"B": 25, // UserEmail
-		"C": 10, // Order状态
-		"D": 10, // 隐私保护
-		"E": 12, // 收货人
-		"F": 15, // Phone
-		"G": 12, // 国家
-		"H": 10, // 省
-		"I": 10, // 市
-		"J": 10, // 区
-		"K": 30, // 详细Address
-		"L": 10, // 邮编
-		"M": 20, // 物流单号
-		"N": 20, // Create时间
-		"O": 20, // 完成时间
-	}
-
-	for col, width := range colWidths {
-		f.SetColWidth(sheetName, col, col, width)
-	}
-
-	// 填充数据
-	for i, order := range orders {
-		row := i + 2
-
-		// Order status translation
-		statusMap := map[string]string{
-			"draft":          "Draft",
-			"pending":        "Pending Shipment",
-			"need_resubmit":  "Needs Resubmit",
-			"shipped":        "Shipped",
-			"completed":      "Completed",
-			"cancelled":      "Cancelled",
-			"refund_pending": "Refund Pending",
-			"refunded":       "Refunded",
-		}
-		statusText := statusMap[string(order.Status)]
-		if statusText == "" {
-			statusText = string(order.Status)
-		}
-
-		// Privacy protected
-		privacyText := "No"
-		if order.PrivacyProtected {
-			privacyText = "Yes"
-		}
-
-		// 完成时间
-		completedAt := ""
-		if order.CompletedAt != nil {
-			completedAt = order.CompletedAt.Format("2006-01-02 15:04:05")
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			response.InternalError(c, "Export failed")
+			return
 		}
-
-		// 国家代码转换为中文名称
-		country := order.ReceiverCountry
-		if country == "" {
-			country = "CN"
-		}
-		// 转换国家代码为中文名称
-		countryName := constants.GetCountryNameZH(country)
-
-		// Phone（包含区号）
-		fullPhone := order.ReceiverPhone
-		if order.PhoneCode != "" {
-			fullPhone = order.PhoneCode + " " + order.ReceiverPhone
-		}
-
-		// 填充每一行
-		values := []interface{}{
-			order.OrderNo,
-			order.UserEmail,
-			statusText,
-			privacyText,
-			order.ReceiverName,
-			fullPhone,
-			countryName,
-			order.ReceiverProvince,
-			order.ReceiverCity,
-			order.ReceiverDistrict,
-			order.ReceiverAddress,
-			order.ReceiverPostcode,
-			order.TrackingNo,
-			order.CreatedAt.Format("2006-01-02 15:04:05"),
-			completedAt,
-		}
-
-		for j, value := range values {
-			cell := string(rune('A'+j)) + strconv.Itoa(row)
-			f.SetCellValue(sheetName, cell, value)
-		}
-	}
-
-	// 设置默认工作表
-	f.SetActiveSheet(index)
-
-	// generate文件名
-	fileName := fmt.Sprintf("Order List_%s.xlsx", time.Now().Format("20060102_150405"))
-
-	// 设置响应头
-	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
-	c.Header("Content-Transfer-Encoding", "binary")
-
-	// 写入响应
-	if err := f.Write(c.Writer); err != nil {
-		response.InternalError(c, "Export failed")
+		log.Printf("order export aborted: admin=%d exported=%d err=%v", adminIDValue, exportedCount, err)
 		return
 	}
 
@@ -313,8 +189,12 @@ func (h *OrderHandler) ExportOrders(c *gin.Context) {
 			"product_search":         req.ProductSearch,
 			"promo_code":             req.PromoCode,
 			"promo_code_id":          req.PromoCodeID,
-			"exported_count":         len(orders),
-			"matched_total":          len(orders),
+			"sku":                    req.SKU,
+			"from":                   req.From,
+			"to":                     req.To,
+			"format":                 format,
+			"exported_count":         exportedCount,
+			"matched_total":          exportedCount,
 			"file_name":              fileName,
 			"has_privacy_permission": hasPrivacyPerm,
 			"admin_id":               adminIDValue,
@@ -332,7 +212,7 @@ func (h *OrderHandler) ExportOrders(c *gin.Context) {
 			"hook_resource": "order",
 			"hook_source":   "admin_api",
 			"hook_action":   "export",
-		})), afterPayload, len(orders))
+		})), afterPayload, exportedCount)
 	}
 }
 
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetOrderFull 一次性返回订单详情及退款、发货、发货脚本执行、邮件/短信和关联工单记录
+// 路径参数可以是订单 ID 或订单号
+func (h *OrderHandler) GetOrderFull(c *gin.Context) {
+	order, ok := h.findOrderByRef(c)
+	if !ok {
+		return
+	}
+
+	payload := h.buildAdminOrderDetail(c, order)
+
+	activity, err := service.NewOrderActivityService(database.GetDB()).Load(order)
+	if err != nil {
+		log.Printf("admin.get_order_full failed to load order activity: order_id=%d err=%v", order.ID, err)
+		response.InternalError(c, "Failed to load order activity")
+		return
+	}
+	if order.PrivacyProtected && !h.hasPrivacyPermission(c) {
+		for i := range activity.SMS {
+			if phone := activity.SMS[i].Phone; len(phone) > 7 {
+				activity.SMS[i].Phone = phone[:3] + "****" + phone[len(phone)-4:]
+			}
+		}
+	}
+
+	payload["refund_requests"] = activity.RefundRequests
+	payload["refund_events"] = activity.RefundEvents
+	payload["shipments"] = activity.Shipments
+	payload["delivery_attempts"] = activity.DeliveryAttempts
+	payload["emails"] = activity.Emails
+	payload["sms"] = activity.SMS
+	payload["tickets"] = activity.Tickets
+	response.Success(c, payload)
+}
+
+// findOrderByRef 按路径参数 id 查找订单，参数可以是订单 ID 或订单号；未找到时直接返回 404
+func (h *OrderHandler) findOrderByRef(c *gin.Context) (*models.Order, bool) {
+	ref := strings.TrimSpace(c.Param("id"))
+
+	var (
+		order *models.Order
+		err   error
+	)
+	if orderID, parseErr := strconv.ParseUint(ref, 10, 32); parseErr == nil {
+		order, err = h.orderService.GetOrderByID(uint(orderID))
+		// 纯数字的订单号（如未配置前缀的 random_checksum 格式）按订单号再查一次
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			order, err = h.orderService.GetOrderByNo(ref)
+		}
+	} else {
+		order, err = h.orderService.GetOrderByNo(ref)
+	}
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return nil, false
+	}
+	return order, true
+}
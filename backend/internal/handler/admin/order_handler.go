@@ -27,22 +27,55 @@ type OrderHandler struct {
 	orderService            *service.OrderService
 	serialService           *service.SerialService
 	virtualInventoryService *service.VirtualInventoryService
+	revealService           *service.VirtualStockRevealService
 	jsRuntimeService        *service.JSRuntimeService
 	pluginManager           *service.PluginManagerService
+	orderCancelService      *service.OrderCancelService
+	paymentLinkHandler      *PaymentLinkHandler
 	cfg                     *config.Config
 }
 
-func NewOrderHandler(orderService *service.OrderService, serialService *service.SerialService, virtualInventoryService *service.VirtualInventoryService, jsRuntimeService *service.JSRuntimeService, pluginManager *service.PluginManagerService, cfg *config.Config) *OrderHandler {
+func NewOrderHandler(orderService *service.OrderService, serialService *service.SerialService, virtualInventoryService *service.VirtualInventoryService, revealService *service.VirtualStockRevealService, jsRuntimeService *service.JSRuntimeService, pluginManager *service.PluginManagerService, cfg *config.Config) *OrderHandler {
 	return &OrderHandler{
 		orderService:            orderService,
 		serialService:           serialService,
 		virtualInventoryService: virtualInventoryService,
+		revealService:           revealService,
 		jsRuntimeService:        jsRuntimeService,
 		pluginManager:           pluginManager,
 		cfg:                     cfg,
 	}
 }
 
+// SetOrderCancelService 注入订单清理服务，用于手动触发草稿清理
+func (h *OrderHandler) SetOrderCancelService(orderCancelService *service.OrderCancelService) {
+	h.orderCancelService = orderCancelService
+}
+
+// SetPaymentLinkHandler 注入付款链接处理器，用于手动建单后直接发送付款链接
+func (h *OrderHandler) SetPaymentLinkHandler(paymentLinkHandler *PaymentLinkHandler) {
+	h.paymentLinkHandler = paymentLinkHandler
+}
+
+// refundService 退款引擎，与审核通过的用户退款申请共用
+func (h *OrderHandler) refundService() *service.RefundService {
+	return service.NewRefundService(database.GetDB(), h.orderService, h.jsRuntimeService)
+}
+
+// respondAdminRefundError 输出退款引擎错误
+func respondAdminRefundError(c *gin.Context, err error) {
+	var declined *service.RefundDeclinedError
+	switch {
+	case errors.As(err, &declined):
+		response.BadRequest(c, declined.Error())
+	case errors.Is(err, service.ErrRefundExecution):
+		response.InternalError(c, "Refund execution failed")
+	case respondAdminBizError(c, err):
+	default:
+		response.InternalError(c, "Failed to update order status")
+	}
+}
+
 func respondAdminOrderServiceError(c *gin.Context, err error, fallback string) bool {
 	if err == nil {
 		return false
@@ -50,7 +83,7 @@ func respondAdminOrderServiceError(c *gin.Context, err error, fallback string) b
 
 	var bizErr *bizerr.Error
 	if errors.As(err, &bizErr) {
-		response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+		response.RespondBizError(c, bizErr)
 		return true
 	}
 
@@ -417,6 +450,22 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 		}
 	}
 
+	hasPrivacyPerm := h.hasPrivacyPermission(c)
+
+	// 带 cursor 参数时使用游标分页，大量订单翻页不再随偏移量变慢
+	if cursor, ok := response.GetCursor(c); ok {
+		orders, nextCursor, err := h.orderService.ListOrdersByCursor(cursor, limit, status, search, country, productSearch, promoCodeID, promoCode, userID)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		for i := range orders {
+			h.orderService.MaskOrderIfNeeded(&orders[i], hasPrivacyPerm)
+		}
+		response.CursorPaginated(c, orders, limit, nextCursor)
+		return
+	}
+
 	orders, total, err := h.orderService.ListOrders(page, limit, status, search, country, productSearch, promoCodeID, promoCode, userID)
 	if err != nil {
 		response.InternalError(c, "Query failed")
@@ -424,7 +473,6 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 	}
 
 	// Check if admin has privacy view permission, mask if not
-	hasPrivacyPerm := h.hasPrivacyPermission(c)
 	for i := range orders {
 		h.orderService.MaskOrderIfNeeded(&orders[i], hasPrivacyPerm)
 	}
@@ -458,6 +506,13 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		return
 	}
 
+	response.Success(c, h.buildAdminOrderDetail(c, order))
+}
+
+// buildAdminOrderDetail 组装订单详情：订单、序列号、虚拟库存和付款信息
+func (h *OrderHandler) buildAdminOrderDetail(c *gin.Context, order *models.Order) gin.H {
+	orderID := order.ID
+
 	// Check if admin has privacy view permission, mask if not
 	hasPrivacyPerm := h.hasPrivacyPermission(c)
 	h.orderService.MaskOrderIfNeeded(order, hasPrivacyPerm)
@@ -484,6 +539,10 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 			log.Printf("admin.get_order failed to load virtual stocks: order_no=%s err=%v", order.OrderNo, err)
 			warnings = append(warnings, "Failed to load order virtual stock")
 		} else if len(stockList) > 0 {
+			logger.LogOrderOperation(database.GetDB(), c, "view_virtual_stock", order.ID, map[string]interface{}{
+				"order_no": order.OrderNo,
+				"count":    len(stockList),
+			})
 			if !h.cfg.Order.EnableVirtualStockInlineIframe {
 				for i := range stockList {
 					stockList[i].Presentation = ""
@@ -528,6 +587,22 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		warnings = append(warnings, "Failed to load order payment information")
 	}
 
+	if err := h.orderService.LoadItemAllocations(order); err != nil {
+		log.Printf("admin.get_order failed to load item allocations: order_id=%d err=%v", orderID, err)
+		warnings = append(warnings, "Failed to load blind box allocations")
+	}
+
+	shipment, err := h.orderService.ShipmentTracking(orderID)
+	if err != nil {
+		log.Printf("admin.get_order failed to load shipment tracking: order_id=%d err=%v", orderID, err)
+		warnings = append(warnings, "Failed to load shipment tracking")
+	}
+	riskReview, err := h.orderService.RiskReview(orderID)
+	if err != nil {
+		log.Printf("admin.get_order failed to load risk review: order_id=%d err=%v", orderID, err)
+		warnings = append(warnings, "Failed to load risk review")
+	}
+
 	// 返回订单信息和序列号
 	payload := gin.H{
 		"order":                     order,
@@ -536,11 +611,34 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		"has_pending_virtual_stock": hasPendingVirtualStock,
 		"payment_info":              paymentInfo,
 		"form_url":                  h.buildShippingFormURL(order.FormToken),
+		"item_allocations":          order.ItemAllocations,
+		"shipment":                  shipment,
+		"risk_review":               riskReview,
 	}
 	if len(warnings) > 0 {
 		payload["warnings"] = warnings
 	}
-	response.Success(c, payload)
+	return payload
+}
+
+// GetVirtualRevealLogs 获取用户查看虚拟商品内容的审计记录
+func (h *OrderHandler) GetVirtualRevealLogs(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	if h.revealService == nil {
+		response.Paginated(c, []models.VirtualStockRevealLog{}, 1, 20, 0)
+		return
+	}
+
+	page, limit := response.GetPagination(c)
+	logs, total, err := h.revealService.ListByOrder(orderID, page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get virtual product view logs")
+		return
+	}
+	response.Paginated(c, logs, page, limit, total)
 }
 
 // AssignTrackingRequest 分配物流单号请求
@@ -557,7 +655,7 @@ func (h *OrderHandler) AssignTracking(c *gin.Context) {
 
 	var req AssignTrackingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -886,75 +984,14 @@ func (h *OrderHandler) RefundOrder(c *gin.Context) {
 		}
 	}
 
-	// 只允许已付款后的订单退款（包括草稿状态，草稿表示已付款但用户尚未填写收货信息）
-	allowedStatuses := map[models.OrderStatus]bool{
-		models.OrderStatusDraft:        true,
-		models.OrderStatusPending:      true,
-		models.OrderStatusNeedResubmit: true,
-		models.OrderStatusShipped:      true,
-		models.OrderStatusCompleted:    true,
-	}
-	if !allowedStatuses[order.Status] {
-		respondAdminOrderValidationError(c, orderbiz.RefundStatusInvalid(order.Status))
-		return
-	}
-
-	// 获取订单的付款方式
-	db := database.GetDB()
-	var opm models.OrderPaymentMethod
-	if err := db.Where("order_id = ?", orderID).First(&opm).Error; err != nil {
-		respondAdminOrderValidationError(c, orderbiz.OrderPaymentMethodNotFound())
-		return
-	}
-
-	var pm models.PaymentMethod
-	if err := db.First(&pm, opm.PaymentMethodID).Error; err != nil {
-		respondAdminOrderValidationError(c, orderbiz.PaymentMethodNotFound())
-		return
-	}
-
-	// 调用JS退款API
-	refundResult, err := h.jsRuntimeService.ExecuteRefund(&pm, order)
+	outcome, err := h.refundService().RefundOrder(order, req.Reason)
 	if err != nil {
-		response.InternalError(c, "Refund execution failed")
-		return
-	}
-
-	if !refundResult.Success {
-		msg := "Refund failed"
-		if refundResult.Message != "" {
-			msg = refundResult.Message
-		}
-		response.BadRequest(c, msg)
-		return
-	}
-
-	// 未发货的订单退款时释放预留库存（物理库存 + 虚拟库存 + 优惠码）
-	if order.Status == models.OrderStatusDraft || order.Status == models.OrderStatusPending || order.Status == models.OrderStatusNeedResubmit {
-		h.orderService.ReleaseOrderReserves(order)
-	}
-
-	nextStatus := models.OrderStatusRefunded
-	if refundResult.Pending {
-		nextStatus = models.OrderStatusRefundPending
-	}
-
-	// 更新订单状态
-	updates := map[string]interface{}{
-		"status": nextStatus,
-	}
-	if req.Reason != "" {
-		remark := order.AdminRemark
-		if remark != "" {
-			remark += "\n"
-		}
-		remark += "[Refund] " + req.Reason
-		updates["admin_remark"] = remark
-	}
-	if err := db.Model(order).Updates(updates).Error; err != nil {
-		response.InternalError(c, "Failed to update order status")
+		respondAdminRefundError(c, err)
 		return
 	}
+	refundResult := outcome.Result
+	nextStatus := outcome.StatusAfter
+	db := database.GetDB()
 	if order.UserID != nil {
 		if err := h.orderService.SyncUserConsumptionStats(*order.UserID); err != nil {
 			logger.LogOrderOperation(db, c, "sync_user_consumption_stats_failed", order.ID, map[string]interface{}{
@@ -970,6 +1007,7 @@ func (h *OrderHandler) RefundOrder(c *gin.Context) {
 		"order_no":       order.OrderNo,
 		"reason":         req.Reason,
 		"status_after":   nextStatus,
+		"refund_amount":  outcome.RefundAmount,
 		"refund_pending": refundResult.Pending,
 		"refund_message": refundResult.Message,
 		"transaction_id": refundResult.TransactionID,
@@ -989,7 +1027,7 @@ func (h *OrderHandler) RefundOrder(c *gin.Context) {
 			"source":         "admin_api",
 			"completed_at":   time.Now().Format(time.RFC3339),
 		}
-		service.EmitOrderStatusChangedAfterHookAsync(h.pluginManager, hookExecCtx, order, beforeStatus, nextStatus, map[string]interface{}{
+		service.PublishOrderStatusChanged(h.orderService.OrderRepo, h.pluginManager, hookExecCtx, order, beforeStatus, nextStatus, map[string]interface{}{
 			"source":          "admin_api",
 			"trigger_action":  "order.admin.refund",
 			"admin_id":        adminID,
@@ -1126,10 +1164,20 @@ func (h *OrderHandler) ConfirmRefund(c *gin.Context) {
 		updates := map[string]interface{}{
 			"status":       models.OrderStatusRefunded,
 			"admin_remark": nextAdminRemark,
+			"refunded_at":  models.NowFunc(),
 		}
 		if err := tx.Model(order).Updates(updates).Error; err != nil {
 			return err
 		}
+		if err := service.RecordVendorRefundTx(tx, order); err != nil {
+			return err
+		}
+		if err := service.RefundGiftCardTx(tx, order); err != nil {
+			return err
+		}
+		if err := service.CancelRevenueSchedulesTx(tx, order); err != nil {
+			return err
+		}
 
 		var opm models.OrderPaymentMethod
 		if err := tx.Where("order_id = ?", order.ID).First(&opm).Error; err != nil {
@@ -1195,7 +1243,7 @@ func (h *OrderHandler) ConfirmRefund(c *gin.Context) {
 			"source":         "admin_api",
 			"completed_at":   now.Format(time.RFC3339),
 		}
-		service.EmitOrderStatusChangedAfterHookAsync(h.pluginManager, hookExecCtx, order, beforeStatus, models.OrderStatusRefunded, map[string]interface{}{
+		service.PublishOrderStatusChanged(h.orderService.OrderRepo, h.pluginManager, hookExecCtx, order, beforeStatus, models.OrderStatusRefunded, map[string]interface{}{
 			"source":         "admin_api",
 			"trigger_action": "order.admin.refund_finalize",
 			"admin_id":       adminID,
@@ -1280,6 +1328,7 @@ func (h *OrderHandler) MarkAsPaid(c *gin.Context) {
 		respondAdminOrderValidationError(c, orderbiz.AdminRemarkTooLong(1000))
 		return
 	}
+	options.AdminID = adminID
 
 	if err := h.orderService.MarkAsPaidWithOptions(orderID, options); err != nil {
 		respondAdminOrderServiceError(c, err, "Failed to mark order as paid")
@@ -1548,7 +1597,7 @@ func (h *OrderHandler) UpdateShippingInfo(c *gin.Context) {
 
 	var req UpdateShippingInfoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1703,6 +1752,27 @@ func (h *OrderHandler) UpdateShippingInfo(c *gin.Context) {
 		return
 	}
 
+	// 修改地址相关字段时，按合并后的地址校验国家格式
+	if req.ReceiverCountry != "" || req.ReceiverProvince != "" || req.ReceiverCity != "" ||
+		req.ReceiverDistrict != "" || req.ReceiverPostcode != "" {
+		merged := func(value, current string) string {
+			if value != "" {
+				return value
+			}
+			return current
+		}
+		if bizErr := orderbiz.ValidateReceiverAddress(
+			merged(req.ReceiverCountry, order.ReceiverCountry),
+			merged(req.ReceiverProvince, order.ReceiverProvince),
+			merged(req.ReceiverCity, order.ReceiverCity),
+			merged(req.ReceiverDistrict, order.ReceiverDistrict),
+			merged(req.ReceiverPostcode, order.ReceiverPostcode),
+		); bizErr != nil {
+			respondAdminOrderValidationError(c, bizErr)
+			return
+		}
+	}
+
 	// Update收货Info
 	if req.ReceiverName != "" {
 		order.ReceiverName = req.ReceiverName
@@ -1791,7 +1861,7 @@ func (h *OrderHandler) RequestResubmit(c *gin.Context) {
 
 	var req RequestResubmitRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1862,6 +1932,19 @@ func (h *OrderHandler) CompleteAllShippedOrders(c *gin.Context) {
 		return
 	}
 
+	dryRun, ok := dryRunRequested(c, false)
+	if !ok {
+		return
+	}
+	if dryRun {
+		previews := make([]batchOrderPreview, 0, len(orders))
+		for i := range orders {
+			previews = append(previews, newBatchOrderPreview(orders[i].ID, &orders[i], nil))
+		}
+		response.Success(c, batchOrderPreviewResponse("complete", previews))
+		return
+	}
+
 	if len(orders) == 0 {
 		response.Success(c, gin.H{
 			"completed_count": 0,
@@ -1975,6 +2058,50 @@ func (h *OrderHandler) CompleteAllShippedOrders(c *gin.Context) {
 type BatchUpdateOrdersRequest struct {
 	OrderIDs []uint `json:"order_ids" binding:"required,min=1"`
 	Action   string `json:"action" binding:"required,oneof=complete cancel delete"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+// batchOrderPreview 批量订单操作试运行中单个订单的检查结果
+type batchOrderPreview struct {
+	OrderID    uint               `json:"order_id"`
+	OrderNo    string             `json:"order_no,omitempty"`
+	Status     models.OrderStatus `json:"status,omitempty"`
+	Applicable bool               `json:"applicable"`
+	Error      *bizerr.Error      `json:"error,omitempty"`
+}
+
+func newBatchOrderPreview(orderID uint, order *models.Order, err error) batchOrderPreview {
+	preview := batchOrderPreview{OrderID: orderID, Applicable: err == nil}
+	if order != nil {
+		preview.OrderNo = order.OrderNo
+		preview.Status = order.Status
+	}
+	if err != nil {
+		var bizErr *bizerr.Error
+		if !errors.As(err, &bizErr) {
+			bizErr = &bizerr.Error{Message: err.Error()}
+		}
+		preview.Error = bizErr
+	}
+	return preview
+}
+
+// batchOrderPreviewResponse 批量订单操作的试运行响应
+func batchOrderPreviewResponse(action string, previews []batchOrderPreview) gin.H {
+	affectedCount := 0
+	for _, preview := range previews {
+		if preview.Applicable {
+			affectedCount++
+		}
+	}
+	return gin.H{
+		"dry_run":        true,
+		"action":         action,
+		"total_count":    len(previews),
+		"affected_count": affectedCount,
+		"skipped_count":  len(previews) - affectedCount,
+		"orders":         previews,
+	}
 }
 
 // BatchUpdateOrders 批量操作订单（完成/取消/删除）
@@ -1986,7 +2113,7 @@ func (h *OrderHandler) BatchUpdateOrders(c *gin.Context) {
 
 	var req BatchUpdateOrdersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -2006,6 +2133,21 @@ func (h *OrderHandler) BatchUpdateOrders(c *gin.Context) {
 		}
 	}
 
+	dryRun, ok := dryRunRequested(c, req.DryRun)
+	if !ok {
+		return
+	}
+	if dryRun {
+		// 与实际执行使用相同的状态校验，插件 before 钩子不会被调用
+		previews := make([]batchOrderPreview, 0, len(req.OrderIDs))
+		for _, orderID := range req.OrderIDs {
+			order, err := h.orderService.PreviewOrderAction(orderID, req.Action)
+			previews = append(previews, newBatchOrderPreview(orderID, order, err))
+		}
+		response.Success(c, batchOrderPreviewResponse(req.Action, previews))
+		return
+	}
+
 	successCount := 0
 	failedCount := 0
 	var failedOrders []string
@@ -2233,6 +2375,35 @@ func (h *OrderHandler) BatchUpdateOrders(c *gin.Context) {
 	response.Success(c, result)
 }
 
+// CleanupStaleDrafts 立即清理一批过期草稿订单（按配置删除或清除个人信息），dry_run 时只返回将被清理的订单
+func (h *OrderHandler) CleanupStaleDrafts(c *gin.Context) {
+	dryRun, ok := dryRunRequested(c, false)
+	if !ok {
+		return
+	}
+	if h.orderCancelService == nil {
+		response.InternalError(c, "Draft cleanup is unavailable")
+		return
+	}
+
+	report, err := h.orderCancelService.CleanupStaleDrafts(dryRun)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to clean up draft orders")
+		return
+	}
+	if !dryRun {
+		logger.LogOperation(database.GetDB(), c, "order_draft_cleanup", "order", nil, map[string]interface{}{
+			"action":         report.Action,
+			"retention_days": report.RetentionDays,
+			"cleaned_count":  report.CleanedCount,
+		})
+	}
+	response.Success(c, report)
+}
+
 // UpdateOrderPriceRequest 修改订单价格请求
 type UpdateOrderPriceRequest struct {
 	TotalAmountMinor *int64 `json:"total_amount_minor" binding:"required,min=0"`
@@ -2256,7 +2427,7 @@ func (h *OrderHandler) UpdateOrderPrice(c *gin.Context) {
 
 	var req UpdateOrderPriceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 	// 获取订单
@@ -2315,11 +2486,21 @@ func (h *OrderHandler) UpdateOrderPrice(c *gin.Context) {
 		return
 	}
 
+	// 超过审批阈值的减免需通过调价申请由另一名管理员批准
+	if err := h.orderService.CheckDirectPriceUpdate(order.TotalAmount, *req.TotalAmountMinor); err != nil {
+		respondAdminOrderValidationError(c, err)
+		return
+	}
+
 	// 保存修改前的价格
 	oldAmount := order.TotalAmount
 
-	// 更新订单价格
+	// 更新订单价格，相对不含手续费金额的差额计入手动调价，账单明细据此列示
+	order.ManualAdjustment += *req.TotalAmountMinor - (oldAmount - order.PaymentFee)
 	order.TotalAmount = *req.TotalAmountMinor
+	// 改价后的金额即为应付金额，之前计入的付款方式手续费不再单独列示
+	order.PaymentFee = 0
+	order.PaymentFeeRetained = false
 
 	db := database.GetDB()
 	paymentArtifactsReset := false
@@ -2327,40 +2508,9 @@ func (h *OrderHandler) UpdateOrderPrice(c *gin.Context) {
 		if err := tx.Save(order).Error; err != nil {
 			return err
 		}
-
-		var opm models.OrderPaymentMethod
-		if err := tx.Where("order_id = ?", order.ID).First(&opm).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil
-			}
-			return err
-		}
-
-		cacheResult := tx.Model(&models.OrderPaymentMethod{}).
-			Where("order_id = ?", order.ID).
-			Updates(map[string]interface{}{
-				"payment_data":       "",
-				"payment_card_cache": "",
-				"cache_expires_at":   nil,
-			})
-		if cacheResult.Error != nil {
-			return cacheResult.Error
-		}
-
-		storageKeys := []string{
-			"order_" + strconv.FormatUint(uint64(order.ID), 10) + "_amount",
-			"order_" + strconv.FormatUint(uint64(order.ID), 10) + "_time",
-			"order_" + strconv.FormatUint(uint64(order.ID), 10) + "_address",
-		}
-		deleteResult := tx.
-			Where("payment_method_id = ? AND key IN ?", opm.PaymentMethodID, storageKeys).
-			Delete(&models.PaymentMethodStorageEntry{})
-		if deleteResult.Error != nil {
-			return deleteResult.Error
-		}
-
-		paymentArtifactsReset = cacheResult.RowsAffected > 0 || deleteResult.RowsAffected > 0
-		return nil
+		var err error
+		paymentArtifactsReset, err = service.ResetOrderPaymentArtifactsTx(tx, order.ID)
+		return err
 	}); err != nil {
 		response.InternalError(c, "Failed to update order price")
 		return
@@ -2419,7 +2569,7 @@ type CreateDraftRequest struct {
 func (h *OrderHandler) CreateDraft(c *gin.Context) {
 	var req CreateDraftRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -2471,7 +2621,7 @@ func (h *OrderHandler) CreateDraft(c *gin.Context) {
 	if err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		db := database.GetDB()
@@ -2523,14 +2673,24 @@ type CreateOrderForUserRequest struct {
 	AdminRemark      string                   `json:"admin_remark"`
 	Status           string                   `json:"status"`
 	TotalAmountMinor *int64                   `json:"total_amount_minor"`
+	DiscountMinor    int64                    `json:"discount_minor"`
 	UserEmail        string                   `json:"user_email"`
+	// PaymentAction 建单后的付款处理：none（默认）、mark_paid 标记已付款、payment_link 生成并发送付款链接
+	PaymentAction string                    `json:"payment_action"`
+	PaymentLink   *CreatePaymentLinkRequest `json:"payment_link"`
 }
 
+const (
+	adminOrderPaymentActionNone        = "none"
+	adminOrderPaymentActionMarkPaid    = "mark_paid"
+	adminOrderPaymentActionPaymentLink = "payment_link"
+)
+
 // CreateOrderForUser 管理员为用户创建订单
 func (h *OrderHandler) CreateOrderForUser(c *gin.Context) {
 	var req CreateOrderForUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		respondAdminOrderValidationError(c, orderbiz.InvalidRequestParameters())
+		response.BindingError(c, err)
 		return
 	}
 
@@ -2555,7 +2715,28 @@ func (h *OrderHandler) CreateOrderForUser(c *gin.Context) {
 		respondAdminOrderValidationError(c, orderbiz.TotalAmountNegative())
 		return
 	}
+	req.PaymentAction = strings.TrimSpace(req.PaymentAction)
+	switch req.PaymentAction {
+	case "", adminOrderPaymentActionNone:
+	case adminOrderPaymentActionMarkPaid, adminOrderPaymentActionPaymentLink:
+		// 标记已付款与付款链接都只适用于待付款订单
+		if req.Status != "" && req.Status != string(models.OrderStatusPendingPayment) {
+			respondAdminOrderValidationError(c, orderbiz.PaymentActionStatusInvalid(req.Status))
+			return
+		}
+	default:
+		respondAdminOrderValidationError(c, orderbiz.PaymentActionInvalid(req.PaymentAction))
+		return
+	}
+	if req.ReceiverCountry != "" {
+		req.ReceiverCountry = strings.ToUpper(req.ReceiverCountry)
+		if bizErr := orderbiz.ValidateReceiverAddress(req.ReceiverCountry, req.ReceiverProvince, req.ReceiverCity, req.ReceiverDistrict, req.ReceiverPostcode); bizErr != nil {
+			respondAdminOrderValidationError(c, bizErr)
+			return
+		}
+	}
 
+	adminID, _ := middleware.GetUserID(c)
 	order, err := h.orderService.CreateAdminOrder(service.AdminOrderRequest{
 		UserID:           req.UserID,
 		Items:            req.Items,
@@ -2573,12 +2754,14 @@ func (h *OrderHandler) CreateOrderForUser(c *gin.Context) {
 		AdminRemark:      req.AdminRemark,
 		Status:           req.Status,
 		TotalAmount:      req.TotalAmountMinor,
+		DiscountAmount:   req.DiscountMinor,
 		UserEmail:        req.UserEmail,
+		AdminID:          adminID,
 	})
 	if err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		response.InternalError(c, "Failed to create order")
@@ -2596,9 +2779,15 @@ func (h *OrderHandler) CreateOrderForUser(c *gin.Context) {
 		logDetails["amount_override"] = true
 		logDetails["override_amount_minor"] = *req.TotalAmountMinor
 	}
+	if req.DiscountMinor > 0 {
+		logDetails["discount_minor"] = req.DiscountMinor
+	}
+	if req.PaymentAction != "" {
+		logDetails["payment_action"] = req.PaymentAction
+	}
 	logger.LogOrderOperation(db, c, "admin_create_order", order.ID, logDetails)
 
-	response.Success(c, gin.H{
+	result := gin.H{
 		"order_id":        order.ID,
 		"order_no":        order.OrderNo,
 		"form_url":        h.buildShippingFormURL(order.FormToken),
@@ -2606,5 +2795,34 @@ func (h *OrderHandler) CreateOrderForUser(c *gin.Context) {
 		"form_expires_at": order.FormExpiresAt,
 		"status":          order.Status,
 		"created_at":      order.CreatedAt,
-	})
+	}
+
+	// 订单已创建，付款处理失败时不回滚订单，只在结果中返回失败原因供管理员在订单详情中重试
+	switch req.PaymentAction {
+	case adminOrderPaymentActionMarkPaid:
+		if err := h.orderService.MarkAsPaidWithOptions(order.ID, service.MarkAsPaidOptions{AdminID: adminID}); err != nil {
+			log.Printf("Failed to mark admin-created order as paid: order=%s err=%v", order.OrderNo, err)
+			result["payment_action_error"] = err.Error()
+		} else if paidOrder, err := h.orderService.GetOrderByID(order.ID); err == nil {
+			result["status"] = paidOrder.Status
+		}
+	case adminOrderPaymentActionPaymentLink:
+		if h.paymentLinkHandler == nil {
+			result["payment_action_error"] = "Payment link is not available"
+			break
+		}
+		linkReq := CreatePaymentLinkRequest{}
+		if req.PaymentLink != nil {
+			linkReq = *req.PaymentLink
+		}
+		link, err := h.paymentLinkHandler.issue(c, order, linkReq)
+		if err != nil {
+			log.Printf("Failed to create payment link for admin-created order: order=%s err=%v", order.OrderNo, err)
+			result["payment_action_error"] = err.Error()
+		} else {
+			result["payment_link"] = link
+		}
+	}
+
+	response.Success(c, result)
 }
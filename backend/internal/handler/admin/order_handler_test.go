@@ -34,6 +34,10 @@ func newOrderHandlerTestDeps(t *testing.T) (*OrderHandler, *gorm.DB) {
 		&models.OrderPaymentMethod{},
 		&models.PaymentMethodStorageEntry{},
 		&models.PaymentMethod{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
 	); err != nil {
 		t.Fatalf("auto migrate: %v", err)
 	}
@@ -61,7 +65,7 @@ func newOrderHandlerTestDeps(t *testing.T) (*OrderHandler, *gorm.DB) {
 	)
 
 	jsRuntimeService := service.NewJSRuntimeService(db, cfg)
-	handler := NewOrderHandler(orderService, nil, nil, jsRuntimeService, nil, cfg)
+	handler := NewOrderHandler(orderService, nil, nil, nil, jsRuntimeService, nil, cfg)
 	return handler, db
 }
 
@@ -104,7 +108,7 @@ func TestGetOrderInvalidOrderIDReturnsBizError(t *testing.T) {
 	}
 }
 
-func TestUpdateOrderPriceInvalidRequestReturnsBizError(t *testing.T) {
+func TestUpdateOrderPriceMissingAmountReturnsValidationError(t *testing.T) {
 	handler, db := newOrderHandlerTestDeps(t)
 	order := createOrderForHandlerTest(t, db, models.OrderStatusPendingPayment)
 
@@ -118,11 +122,18 @@ func TestUpdateOrderPriceInvalidRequestReturnsBizError(t *testing.T) {
 		1,
 	)
 
-	if resp.Code != response.CodeBusinessError {
-		t.Fatalf("expected business error code, got %d", resp.Code)
+	if resp.Code != response.CodeParamError {
+		t.Fatalf("expected param error code, got %d", resp.Code)
+	}
+	if key := adminErrorKey(t, resp.Data); key != "validation.required" {
+		t.Fatalf("expected validation.required, got %q", key)
+	}
+	fieldErrors, ok := resp.Errors.([]interface{})
+	if !ok || len(fieldErrors) != 1 {
+		t.Fatalf("expected one field error, got %#v", resp.Errors)
 	}
-	if key := adminErrorKey(t, resp.Data); key != "order.invalidRequestParameters" {
-		t.Fatalf("expected order.invalidRequestParameters, got %q", key)
+	if field, _ := fieldErrors[0].(map[string]interface{})["field"].(string); field != "total_amount_minor" {
+		t.Fatalf("expected field total_amount_minor, got %q", field)
 	}
 }
 
@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OrderPriceAdjustmentHandler struct {
+	orderService *service.OrderService
+	db           *gorm.DB
+}
+
+func NewOrderPriceAdjustmentHandler(orderService *service.OrderService, db *gorm.DB) *OrderPriceAdjustmentHandler {
+	return &OrderPriceAdjustmentHandler{orderService: orderService, db: db}
+}
+
+// CreatePriceAdjustmentRequest 管理员调价请求：discount 使用 amount_minor，override 使用 total_amount_minor
+type CreatePriceAdjustmentRequest struct {
+	Type             string `json:"type" binding:"required"`
+	AmountMinor      int64  `json:"amount_minor"`
+	TotalAmountMinor int64  `json:"total_amount_minor"`
+	Reason           string `json:"reason" binding:"required"`
+}
+
+// ReviewPriceAdjustmentRequest 审批调价请求
+type ReviewPriceAdjustmentRequest struct {
+	Note string `json:"note"`
+}
+
+func respondPriceAdjustmentError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrOrderPriceAdjustmentNotFound) {
+		response.NotFound(c, "Price adjustment not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+func logPriceAdjustment(db *gorm.DB, c *gin.Context, action string, adjustment *models.OrderPriceAdjustment) {
+	logger.LogOrderOperation(db, c, action, adjustment.OrderID, map[string]interface{}{
+		"order_no":           adjustment.OrderNo,
+		"adjustment_id":      adjustment.ID,
+		"type":               adjustment.Type,
+		"status":             adjustment.Status,
+		"total_before_minor": adjustment.TotalBefore,
+		"total_after_minor":  adjustment.TotalAfter,
+		"reason":             adjustment.Reason,
+		"review_note":        adjustment.ReviewNote,
+	})
+}
+
+// ListPriceAdjustments 调价审批队列，支持按状态筛选
+func (h *OrderPriceAdjustmentHandler) ListPriceAdjustments(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	adjustments, total, err := h.orderService.ListAllPriceAdjustments(strings.TrimSpace(c.Query("status")), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get price adjustments")
+		return
+	}
+	response.Paginated(c, adjustments, page, limit, total)
+}
+
+// ListPriceAdjustmentsForOrder 订单的调价记录
+func (h *OrderPriceAdjustmentHandler) ListPriceAdjustmentsForOrder(c *gin.Context) {
+	orderID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID")
+		return
+	}
+	adjustments, err := h.orderService.ListOrderPriceAdjustments(orderID)
+	if err != nil {
+		response.InternalError(c, "Failed to get price adjustments")
+		return
+	}
+	response.Success(c, gin.H{"items": adjustments})
+}
+
+// CreatePriceAdjustment 对待付款订单发起折扣或改价，超过审批阈值时等待另一名管理员批准
+func (h *OrderPriceAdjustmentHandler) CreatePriceAdjustment(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	orderID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID")
+		return
+	}
+	var req CreatePriceAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	adjustment, err := h.orderService.RequestPriceAdjustment(orderID, service.OrderPriceAdjustmentInput{
+		Type:        models.OrderPriceAdjustmentType(req.Type),
+		Amount:      req.AmountMinor,
+		TotalAmount: req.TotalAmountMinor,
+		Reason:      req.Reason,
+		AdminID:     adminID,
+	})
+	if err != nil {
+		respondPriceAdjustmentError(c, err, "Failed to adjust order price")
+		return
+	}
+	logPriceAdjustment(h.db, c, "request_price_adjustment", adjustment)
+	response.Success(c, adjustment)
+}
+
+// ApprovePriceAdjustment 批准调价并立即生效
+func (h *OrderPriceAdjustmentHandler) ApprovePriceAdjustment(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid price adjustment ID")
+		return
+	}
+	var req ReviewPriceAdjustmentRequest
+	_ = c.ShouldBindJSON(&req)
+
+	adjustment, err := h.orderService.ApprovePriceAdjustment(id, adminID, req.Note)
+	if err != nil {
+		respondPriceAdjustmentError(c, err, "Failed to approve price adjustment")
+		return
+	}
+	logPriceAdjustment(h.db, c, "approve_price_adjustment", adjustment)
+	response.Success(c, adjustment)
+}
+
+// RejectPriceAdjustment 拒绝调价
+func (h *OrderPriceAdjustmentHandler) RejectPriceAdjustment(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid price adjustment ID")
+		return
+	}
+	var req ReviewPriceAdjustmentRequest
+	_ = c.ShouldBindJSON(&req)
+
+	adjustment, err := h.orderService.RejectPriceAdjustment(id, adminID, req.Note)
+	if err != nil {
+		respondPriceAdjustmentError(c, err, "Failed to reject price adjustment")
+		return
+	}
+	logPriceAdjustment(h.db, c, "reject_price_adjustment", adjustment)
+	response.Success(c, adjustment)
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OrderRateCapHandler struct {
+	orderRateCapService *service.OrderRateCapService
+	db                  *gorm.DB
+}
+
+func NewOrderRateCapHandler(orderRateCapService *service.OrderRateCapService, db *gorm.DB) *OrderRateCapHandler {
+	return &OrderRateCapHandler{orderRateCapService: orderRateCapService, db: db}
+}
+
+// UpdateOrderRateCapRequest 开关商品下单频率限制请求
+type UpdateOrderRateCapRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetSettings 商品下单频率限制概况
+func (h *OrderRateCapHandler) GetSettings(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	settings, err := h.orderRateCapService.Settings(productID)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		response.InternalError(c, "Failed to get order rate cap settings")
+		return
+	}
+	response.Success(c, settings)
+}
+
+// Update 开关商品下单频率限制
+func (h *OrderRateCapHandler) Update(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	var req UpdateOrderRateCapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	product, err := h.orderRateCapService.SetProductOrderRateCap(productID, req.Enabled)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		response.InternalError(c, "Failed to update order rate cap")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update_order_rate_cap", "product", &product.ID, map[string]interface{}{
+		"sku":            product.SKU,
+		"order_rate_cap": req.Enabled,
+	})
+	settings, err := h.orderRateCapService.Settings(productID)
+	if err != nil {
+		response.InternalError(c, "Failed to get order rate cap settings")
+		return
+	}
+	response.Success(c, settings)
+}
@@ -0,0 +1,235 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OrderRefundHandler struct {
+	orderService  *service.OrderService
+	refundService *service.RefundService
+	pluginManager *service.PluginManagerService
+	db            *gorm.DB
+}
+
+func NewOrderRefundHandler(orderService *service.OrderService, refundService *service.RefundService, pluginManager *service.PluginManagerService, db *gorm.DB) *OrderRefundHandler {
+	return &OrderRefundHandler{
+		orderService:  orderService,
+		refundService: refundService,
+		pluginManager: pluginManager,
+		db:            db,
+	}
+}
+
+// OrderRefundItemRequest 部分退款的订单项
+type OrderRefundItemRequest struct {
+	ItemIndex int `json:"item_index"`
+	Quantity  int `json:"quantity"`
+}
+
+// CreateOrderRefundRequest 管理员发起部分退款，amount_minor 为 0 时按下单单价计算
+type CreateOrderRefundRequest struct {
+	Items       []OrderRefundItemRequest `json:"items" binding:"required"`
+	AmountMinor int64                    `json:"amount_minor"`
+	Reason      string                   `json:"reason" binding:"required"`
+}
+
+// ReviewOrderRefundRequest 审核部分退款请求
+type ReviewOrderRefundRequest struct {
+	Note string `json:"note"`
+}
+
+func respondOrderRefundError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrOrderRefundNotFound) {
+		response.NotFound(c, "Refund not found")
+		return
+	}
+	var declined *service.RefundDeclinedError
+	if errors.As(err, &declined) || errors.Is(err, service.ErrRefundExecution) {
+		respondAdminRefundError(c, err)
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListOrderRefunds 部分退款队列，支持按状态筛选
+func (h *OrderRefundHandler) ListOrderRefunds(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	refunds, total, err := h.orderService.ListAllOrderRefunds(strings.TrimSpace(c.Query("status")), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get refunds")
+		return
+	}
+	response.Paginated(c, refunds, page, limit, total)
+}
+
+// ListRefundsForOrder 订单的部分退款记录
+func (h *OrderRefundHandler) ListRefundsForOrder(c *gin.Context) {
+	orderID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID")
+		return
+	}
+	refunds, err := h.orderService.ListOrderRefunds(orderID)
+	if err != nil {
+		response.InternalError(c, "Failed to get refunds")
+		return
+	}
+	response.Success(c, gin.H{"items": refunds})
+}
+
+// CreateOrderRefund 管理员对订单的部分商品发起退款
+func (h *OrderRefundHandler) CreateOrderRefund(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	orderID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID")
+		return
+	}
+	var req CreateOrderRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	items := make([]service.OrderRefundItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.OrderRefundItemInput{ItemIndex: item.ItemIndex, Quantity: item.Quantity})
+	}
+
+	refund, err := h.orderService.RefundOrder(orderID, items, req.AmountMinor, req.Reason, service.OrderRefundRequester{
+		UserID: adminID,
+		Role:   "admin",
+	})
+	if err != nil {
+		respondOrderRefundError(c, err, "Failed to create refund")
+		return
+	}
+	logger.LogOrderOperation(h.db, c, "partial_refund_request", refund.OrderID, map[string]interface{}{
+		"order_no":        refund.OrderNo,
+		"order_refund_id": refund.ID,
+		"items":           refund.Items,
+		"amount":          refund.Amount,
+		"reason":          refund.Reason,
+	})
+	response.Success(c, refund)
+}
+
+// ApproveOrderRefund 批准部分退款
+func (h *OrderRefundHandler) ApproveOrderRefund(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund ID")
+		return
+	}
+	var req ReviewOrderRefundRequest
+	_ = c.ShouldBindJSON(&req)
+
+	refund, err := h.orderService.ApproveOrderRefund(id, adminID, req.Note)
+	if err != nil {
+		respondOrderRefundError(c, err, "Failed to approve refund")
+		return
+	}
+	logger.LogOperation(h.db, c, "approve_order_refund", "order_refund", &refund.ID, map[string]interface{}{
+		"order_no": refund.OrderNo,
+		"amount":   refund.Amount,
+		"note":     refund.ReviewNote,
+	})
+	response.Success(c, refund)
+}
+
+// RejectOrderRefund 拒绝部分退款
+func (h *OrderRefundHandler) RejectOrderRefund(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund ID")
+		return
+	}
+	var req ReviewOrderRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	refund, err := h.orderService.RejectOrderRefund(id, adminID, req.Note)
+	if err != nil {
+		respondOrderRefundError(c, err, "Failed to reject refund")
+		return
+	}
+	logger.LogOperation(h.db, c, "reject_order_refund", "order_refund", &refund.ID, map[string]interface{}{
+		"order_no": refund.OrderNo,
+		"note":     refund.ReviewNote,
+	})
+	response.Success(c, refund)
+}
+
+// IssueOrderRefund 通过付款方式退还已批准的部分退款
+func (h *OrderRefundHandler) IssueOrderRefund(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund ID")
+		return
+	}
+
+	refund, order, completion, err := h.refundService.IssueOrderRefund(id, adminID)
+	if err != nil {
+		respondOrderRefundError(c, err, "Failed to issue refund")
+		return
+	}
+
+	logger.LogOrderOperation(h.db, c, "partial_refund", order.ID, map[string]interface{}{
+		"order_no":        order.OrderNo,
+		"order_refund_id": refund.ID,
+		"items":           refund.Items,
+		"refund_amount":   refund.Amount,
+		"status_after":    completion.StatusAfter,
+		"refund_pending":  completion.Result.Pending,
+		"refund_message":  completion.Result.Message,
+		"transaction_id":  completion.Result.TransactionID,
+	})
+	if completion.StatusAfter != completion.StatusBefore {
+		if order.UserID != nil {
+			if err := h.orderService.SyncUserConsumptionStats(*order.UserID); err != nil {
+				logger.LogOrderOperation(h.db, c, "sync_user_consumption_stats_failed", order.ID, map[string]interface{}{
+					"order_no": order.OrderNo,
+					"user_id":  *order.UserID,
+					"error":    err.Error(),
+				})
+			}
+		}
+		service.PublishOrderStatusChanged(h.orderService.OrderRepo, h.pluginManager, nil, order, completion.StatusBefore, completion.StatusAfter, map[string]interface{}{
+			"source":          "admin_api",
+			"trigger_action":  "order.partial_refund.issue",
+			"admin_id":        adminID,
+			"order_refund_id": refund.ID,
+			"transaction_id":  completion.Result.TransactionID,
+			"refund_pending":  completion.Result.Pending,
+			"payment_message": completion.Result.Message,
+		})
+	}
+
+	response.Success(c, refund)
+}
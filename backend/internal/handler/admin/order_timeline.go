@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"errors"
+	"log"
+
+	"auralogic/internal/database"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetOrderTimeline 订单时间线，包含仅管理员可见的备注事件
+// 路径参数可以是订单 ID 或订单号
+func (h *OrderHandler) GetOrderTimeline(c *gin.Context) {
+	order, ok := h.findOrderByRef(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.orderService.GetOrderTimeline(order, true)
+	if err != nil {
+		log.Printf("admin.get_order_timeline failed: order_id=%d err=%v", order.ID, err)
+		response.InternalError(c, "Failed to load order timeline")
+		return
+	}
+	response.Success(c, gin.H{
+		"order_no": order.OrderNo,
+		"events":   events,
+	})
+}
+
+// AddOrderRemarkRequest 添加管理员备注请求
+type AddOrderRemarkRequest struct {
+	Remark string `json:"remark"`
+}
+
+// AddOrderRemark 追加管理员备注，写入订单备注并记录到时间线
+func (h *OrderHandler) AddOrderRemark(c *gin.Context) {
+	adminID, adminIDOK := middleware.RequireUserID(c)
+	if !adminIDOK {
+		return
+	}
+	order, ok := h.findOrderByRef(c)
+	if !ok {
+		return
+	}
+
+	var req AddOrderRemarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request parameters")
+		return
+	}
+	req.Remark = validator.SanitizeText(req.Remark)
+	if req.Remark == "" {
+		respondAdminOrderValidationError(c, orderbiz.AdminRemarkRequired())
+		return
+	}
+	if !validator.ValidateLength(req.Remark, 1, 1000) {
+		respondAdminOrderValidationError(c, orderbiz.AdminRemarkTooLong(1000))
+		return
+	}
+
+	event, err := h.orderService.AddAdminRemark(order.ID, adminID, req.Remark)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		respondAdminOrderServiceError(c, err, "Failed to add remark")
+		return
+	}
+
+	logger.LogOrderOperation(database.GetDB(), c, "add_remark", order.ID, map[string]interface{}{
+		"order_no": order.OrderNo,
+		"remark":   req.Remark,
+	})
+
+	response.Success(c, event)
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOverview 精简概览（今日订单/销售额、待处理退款、未结工单、失败的后台任务），
+// 供移动端与桌面小组件轮询；数据缓存 30 秒，并支持 ETag 条件请求
+func (h *DashboardHandler) GetOverview(c *gin.Context) {
+	overview, err := service.GetAdminOverview(h.db, h.cfg)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+
+	payload, err := json.Marshal(overview)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	sum := sha256.Sum256(payload)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(service.AdminOverviewCacheTTL.Seconds())))
+	c.Header("ETag", etag)
+	if match := c.GetHeader("If-None-Match"); match != "" && match == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	response.Success(c, overview)
+}
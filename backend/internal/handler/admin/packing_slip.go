@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *OrderHandler) packingSlipService() *service.PackingSlipService {
+	return service.NewPackingSlipService(database.GetDB(), h.cfg)
+}
+
+func (h *OrderHandler) writePackingSlips(c *gin.Context, title string, orders []models.Order) {
+	hasPrivacyPerm := h.hasPrivacyPermission(c)
+	for i := range orders {
+		h.orderService.MaskOrderIfNeeded(&orders[i], hasPrivacyPerm)
+	}
+
+	body, err := h.packingSlipService().Render(title, orders)
+	if err != nil {
+		response.InternalError(c, "Failed to render packing slip")
+		return
+	}
+	// 装箱单包含收货信息，不允许缓存
+	c.Header("Cache-Control", "no-store")
+	c.Data(200, "text/html; charset=utf-8", body)
+}
+
+// GetPackingSlip 生成单个订单的装箱单（HTML，可直接打印）
+func (h *OrderHandler) GetPackingSlip(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(orderID)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+
+	h.writePackingSlips(c, "Packing Slip "+order.OrderNo, []models.Order{*order})
+}
+
+// GetDailyPackingSlips 合并生成某日全部发货单的装箱单，默认当天（服务器时区）
+func (h *OrderHandler) GetDailyPackingSlips(c *gin.Context) {
+	day := time.Now()
+	if raw := strings.TrimSpace(c.Query("date")); raw != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			response.BadRequest(c, "Invalid date, expected YYYY-MM-DD")
+			return
+		}
+		day = parsed
+	}
+
+	orders, err := h.packingSlipService().ListShipmentsForDay(day)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	if len(orders) > service.PackingSlipBatchMaxOrders {
+		response.BadRequest(c, fmt.Sprintf("Too many shipments to print (max %d)", service.PackingSlipBatchMaxOrders))
+		return
+	}
+
+	h.writePackingSlips(c, "Packing Slips "+day.Format("2006-01-02"), orders)
+}
@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"log"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PaymentLinkHandler 管理员为待付款订单生成付款链接，并可通过邮件或短信发送给买家
+type PaymentLinkHandler struct {
+	db           *gorm.DB
+	linkService  *service.PaymentLinkService
+	emailService *service.EmailService
+	smsService   *service.SMSService
+}
+
+func NewPaymentLinkHandler(db *gorm.DB, linkService *service.PaymentLinkService, emailService *service.EmailService, smsService *service.SMSService) *PaymentLinkHandler {
+	return &PaymentLinkHandler{db: db, linkService: linkService, emailService: emailService, smsService: smsService}
+}
+
+// CreatePaymentLinkRequest 生成付款链接请求，收件人为空时使用订单上的邮箱与收货手机号
+type CreatePaymentLinkRequest struct {
+	SendEmail bool   `json:"send_email"`
+	SendSMS   bool   `json:"send_sms"`
+	Email     string `json:"email" binding:"omitempty,email,max=255"`
+	Phone     string `json:"phone" binding:"omitempty,max=50"`
+	PhoneCode string `json:"phone_code" binding:"omitempty,max=10"`
+}
+
+// Create 生成付款链接
+func (h *PaymentLinkHandler) Create(c *gin.Context) {
+	orderID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID format")
+		return
+	}
+	var req CreatePaymentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	var order models.Order
+	if err := h.db.First(&order, orderID).Error; err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+
+	result, err := h.issue(c, &order, req)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to generate payment link")
+		}
+		return
+	}
+	response.Success(c, result)
+}
+
+// issue 生成付款链接并按请求发送邮件或短信，发送失败只记录日志不影响链接返回
+func (h *PaymentLinkHandler) issue(c *gin.Context, order *models.Order, req CreatePaymentLinkRequest) (gin.H, error) {
+	link, err := h.linkService.Generate(order)
+	if err != nil {
+		return nil, err
+	}
+
+	emailSent := false
+	if req.SendEmail && h.emailService != nil {
+		to := firstNonEmpty(req.Email, order.UserEmail, order.ReceiverEmail)
+		if to != "" {
+			if err := h.emailService.SendOrderPaymentLinkEmail(order, to, link.URL, link.ExpiresAt); err != nil {
+				log.Printf("Failed to send payment link email: order=%s err=%v", order.OrderNo, err)
+			} else {
+				emailSent = true
+			}
+		}
+	}
+
+	smsSent := false
+	if req.SendSMS && h.smsService != nil {
+		phone := firstNonEmpty(req.Phone, order.ReceiverPhone)
+		phoneCode := firstNonEmpty(req.PhoneCode, order.PhoneCode)
+		if phone != "" {
+			if err := h.smsService.SendPaymentLinkSMS(phone, phoneCode, order.OrderNo, link.URL, order.UserID); err != nil {
+				log.Printf("Failed to send payment link SMS: order=%s err=%v", order.OrderNo, err)
+			} else {
+				smsSent = true
+			}
+		}
+	}
+
+	logger.LogOrderOperation(h.db, c, "payment_link_created", order.ID, map[string]interface{}{
+		"order_no":   order.OrderNo,
+		"expires_at": link.ExpiresAt,
+		"email_sent": emailSent,
+		"sms_sent":   smsSent,
+	})
+	return gin.H{
+		"order_no":   link.OrderNo,
+		"url":        link.URL,
+		"expires_at": link.ExpiresAt,
+		"email_sent": emailSent,
+		"sms_sent":   smsSent,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"log"
 	"strconv"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/service"
 	"github.com/gin-gonic/gin"
@@ -72,7 +74,7 @@ type CreatePaymentMethodRequest struct {
 func (h *PaymentMethodHandler) Create(c *gin.Context) {
 	var req CreatePaymentMethodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
@@ -205,7 +207,7 @@ func (h *PaymentMethodHandler) Update(c *gin.Context) {
 
 	var req UpdatePaymentMethodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
@@ -496,6 +498,48 @@ func (h *PaymentMethodHandler) ToggleEnabled(c *gin.Context) {
 	response.Success(c, pm)
 }
 
+// UpdatePaymentMethodFeeRequest 付款方式手续费规则请求
+type UpdatePaymentMethodFeeRequest struct {
+	FeeRate             int64 `json:"fee_rate"`        // 基点，正数为附加费，负数为优惠
+	FeeFixedMinor       int64 `json:"fee_fixed_minor"` // 固定金额（最小货币单位）
+	FeeRetainedOnRefund bool  `json:"fee_retained_on_refund"`
+}
+
+// UpdateFee 更新付款方式手续费规则，仅影响之后选择该付款方式的订单
+func (h *PaymentMethodHandler) UpdateFee(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ID")
+		return
+	}
+	var req UpdatePaymentMethodFeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	pm, err := h.service.UpdatePaymentMethodFee(uint(id), req.FeeRate, req.FeeFixedMinor, req.FeeRetainedOnRefund)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.NotFound(c, "Payment method not found")
+			return
+		}
+		response.InternalError(c, "Failed to update payment method fee")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update_payment_method_fee", "payment_method", &pm.ID, map[string]interface{}{
+		"name":                   pm.Name,
+		"fee_rate":               pm.FeeRate,
+		"fee_fixed":              pm.FeeFixed,
+		"fee_retained_on_refund": pm.FeeRetainedOnRefund,
+	})
+	response.Success(c, pm)
+}
+
 // ReorderRequest 重排序请求
 type ReorderPaymentMethodRequest struct {
 	IDs []uint `json:"ids" binding:"required"`
@@ -505,7 +549,7 @@ type ReorderPaymentMethodRequest struct {
 func (h *PaymentMethodHandler) Reorder(c *gin.Context) {
 	var req ReorderPaymentMethodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
@@ -546,7 +590,7 @@ type TestScriptRequest struct {
 func (h *PaymentMethodHandler) TestScript(c *gin.Context) {
 	var req TestScriptRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
@@ -102,7 +102,7 @@ func (h *PaymentMethodHandler) respondPaymentMethodMarketError(c *gin.Context, e
 func (h *PaymentMethodHandler) PreviewMarketPackage(c *gin.Context) {
 	var req adminPaymentMethodMarketPreviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid market preview request")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -145,7 +145,7 @@ func (h *PaymentMethodHandler) PreviewMarketPackage(c *gin.Context) {
 func (h *PaymentMethodHandler) ImportPackageFromMarket(c *gin.Context) {
 	var req adminPaymentMethodMarketImportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid market import request")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
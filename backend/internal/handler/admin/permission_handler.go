@@ -8,6 +8,7 @@ import (
 
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/service"
 	"github.com/gin-gonic/gin"
@@ -60,7 +61,7 @@ func (h *PermissionHandler) UpdateUserPermissions(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -110,7 +111,7 @@ func (h *PermissionHandler) UpdateUserPermissions(c *gin.Context) {
 	// 检查User是否存在
 	var user models.User
 	if err := h.db.First(&user, userID).Error; err != nil {
-		response.NotFound(c, "User not found")
+		response.HandleError(c, "Query failed", bizerr.FromStore(err, errAdminUserNotFound))
 		return
 	}
 
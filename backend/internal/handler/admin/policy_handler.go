@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PolicyHandler struct {
+	policyConsentService *service.PolicyConsentService
+	db                   *gorm.DB
+}
+
+func NewPolicyHandler(policyConsentService *service.PolicyConsentService, db *gorm.DB) *PolicyHandler {
+	return &PolicyHandler{policyConsentService: policyConsentService, db: db}
+}
+
+// PublishPolicyRequest 发布政策版本请求
+type PublishPolicyRequest struct {
+	Type    string `json:"type" binding:"required"`
+	Version string `json:"version" binding:"required"`
+	Title   string `json:"title" binding:"required"`
+	Content string `json:"content" binding:"required"`
+	Summary string `json:"summary"`
+}
+
+func respondPolicyError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrPolicyConsentUserNotFound) {
+		response.NotFound(c, "User not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListPolicyVersions 已发布的政策版本及各版本同意人数
+func (h *PolicyHandler) ListPolicyVersions(c *gin.Context) {
+	versions, err := h.policyConsentService.ListVersions(c.Query("type"))
+	if err != nil {
+		respondPolicyError(c, err, "Failed to get policy versions")
+		return
+	}
+	counts, err := h.policyConsentService.AcceptanceCounts(versions)
+	if err != nil {
+		respondPolicyError(c, err, "Failed to get policy versions")
+		return
+	}
+	current, err := h.policyConsentService.Current()
+	if err != nil {
+		respondPolicyError(c, err, "Failed to get policy versions")
+		return
+	}
+	currentIDs := make(map[uint]bool, len(current))
+	for _, version := range current {
+		currentIDs[version.ID] = true
+	}
+	items := make([]gin.H, 0, len(versions))
+	for _, version := range versions {
+		items = append(items, gin.H{
+			"id":             version.ID,
+			"type":           version.Type,
+			"version":        version.Version,
+			"title":          version.Title,
+			"content":        version.Content,
+			"summary":        version.Summary,
+			"published_by":   version.PublishedBy,
+			"created_at":     version.CreatedAt,
+			"is_current":     currentIDs[version.ID],
+			"accepted_count": counts[version.ID],
+		})
+	}
+	response.Success(c, items)
+}
+
+// PublishPolicyVersion 发布新的政策版本，所有用户需重新同意后才能下单
+func (h *PolicyHandler) PublishPolicyVersion(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req PublishPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	version, err := h.policyConsentService.Publish(adminID, service.PublishPolicyInput{
+		Type:    req.Type,
+		Version: req.Version,
+		Title:   req.Title,
+		Content: req.Content,
+		Summary: req.Summary,
+	})
+	if err != nil {
+		respondPolicyError(c, err, "Failed to publish policy")
+		return
+	}
+	logger.LogOperation(h.db, c, "publish_policy", "policy_version", &version.ID, map[string]interface{}{
+		"type":    version.Type,
+		"version": version.Version,
+	})
+	response.Success(c, version)
+}
+
+// GetUserConsents 用户的政策同意记录，用于处理合规请求
+func (h *PolicyHandler) GetUserConsents(c *gin.Context) {
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	history, err := h.policyConsentService.AdminHistory(userID)
+	if err != nil {
+		respondPolicyError(c, err, "Failed to get consent history")
+		return
+	}
+	pending, err := h.policyConsentService.Pending(userID)
+	if err != nil {
+		respondPolicyError(c, err, "Failed to get consent history")
+		return
+	}
+	response.Success(c, gin.H{"history": history, "pending": pending})
+}
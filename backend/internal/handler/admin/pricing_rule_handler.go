@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PricingRuleHandler 定价规则（折扣、立减、满减）的管理与模拟计算
+type PricingRuleHandler struct {
+	pricingService *service.PricingRuleService
+	db             *gorm.DB
+}
+
+func NewPricingRuleHandler(pricingService *service.PricingRuleService, db *gorm.DB) *PricingRuleHandler {
+	return &PricingRuleHandler{pricingService: pricingService, db: db}
+}
+
+// PricingRuleRequest 创建/更新定价规则请求，比例规则的 value_minor 为基点（100% = 10000）
+type PricingRuleRequest struct {
+	Name             string   `json:"name" binding:"required"`
+	Description      string   `json:"description"`
+	Type             string   `json:"type" binding:"required"`
+	ValueMinor       int64    `json:"value_minor"`
+	MaxDiscountMinor int64    `json:"max_discount_minor"`
+	MinAmountMinor   int64    `json:"min_amount_minor"`
+	Scope            string   `json:"scope"`
+	SKUs             []string `json:"skus"`
+	Categories       []string `json:"categories"`
+	Priority         int      `json:"priority"`
+	Exclusive        bool     `json:"exclusive"`
+	Enabled          bool     `json:"enabled"`
+	StartsAt         *string  `json:"starts_at"`
+	ExpiresAt        *string  `json:"expires_at"`
+}
+
+// SimulatePricingRequest 模拟下单请求
+type SimulatePricingRequest struct {
+	Items []struct {
+		SKU      string `json:"sku" binding:"required"`
+		Quantity int    `json:"quantity" binding:"required,gt=0"`
+	} `json:"items" binding:"required,dive"`
+	PromoCode string `json:"promo_code"`
+}
+
+// bindPricingRuleInput 解析请求并转换为服务层输入，失败时已写入响应
+func bindPricingRuleInput(c *gin.Context) (service.PricingRuleInput, bool) {
+	var req PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return service.PricingRuleInput{}, false
+	}
+	startsAt, err := parsePromoCodeStartInput(req.StartsAt)
+	if err != nil {
+		response.BadRequest(c, "Invalid start date format")
+		return service.PricingRuleInput{}, false
+	}
+	expiresAt, err := parsePromoCodeExpiryInput(req.ExpiresAt)
+	if err != nil {
+		response.BadRequest(c, "Invalid expiry date format")
+		return service.PricingRuleInput{}, false
+	}
+	return service.PricingRuleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        models.PricingRuleType(strings.TrimSpace(req.Type)),
+		Value:       req.ValueMinor,
+		MaxDiscount: req.MaxDiscountMinor,
+		MinAmount:   req.MinAmountMinor,
+		Scope:       models.PricingRuleScope(strings.TrimSpace(req.Scope)),
+		SKUs:        req.SKUs,
+		Categories:  req.Categories,
+		Priority:    req.Priority,
+		Exclusive:   req.Exclusive,
+		Enabled:     req.Enabled,
+		StartsAt:    startsAt,
+		ExpiresAt:   expiresAt,
+	}, true
+}
+
+// ListRules 定价规则列表，按计算顺序排列
+func (h *PricingRuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.pricingService.List()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, rules)
+}
+
+// GetRule 定价规则详情
+func (h *PricingRuleHandler) GetRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	rule, err := h.pricingService.Get(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, rule)
+}
+
+// CreateRule 新建定价规则
+func (h *PricingRuleHandler) CreateRule(c *gin.Context) {
+	input, ok := bindPricingRuleInput(c)
+	if !ok {
+		return
+	}
+	rule, err := h.pricingService.Create(input)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to create pricing rule")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "create", "pricing_rule", &rule.ID, map[string]interface{}{
+		"name":     rule.Name,
+		"type":     rule.Type,
+		"priority": rule.Priority,
+		"enabled":  rule.Enabled,
+	})
+	response.Success(c, rule)
+}
+
+// UpdateRule 更新定价规则
+func (h *PricingRuleHandler) UpdateRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	input, ok := bindPricingRuleInput(c)
+	if !ok {
+		return
+	}
+	rule, err := h.pricingService.Update(id, input)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update pricing rule")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update", "pricing_rule", &rule.ID, map[string]interface{}{
+		"name":     rule.Name,
+		"type":     rule.Type,
+		"priority": rule.Priority,
+		"enabled":  rule.Enabled,
+	})
+	response.Success(c, rule)
+}
+
+// DeleteRule 删除定价规则
+func (h *PricingRuleHandler) DeleteRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	rule, err := h.pricingService.Delete(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to delete pricing rule")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "delete", "pricing_rule", &rule.ID, map[string]interface{}{
+		"name": rule.Name,
+	})
+	response.Success(c, gin.H{"id": rule.ID})
+}
+
+// SimulateRules 按当前启用的规则模拟一次下单的优惠计算，返回评估过程
+func (h *PricingRuleHandler) SimulateRules(c *gin.Context) {
+	var req SimulatePricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quantities := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		if sku := strings.TrimSpace(item.SKU); sku != "" {
+			quantities[sku] += item.Quantity
+		}
+	}
+	evaluation, err := h.pricingService.Simulate(quantities, req.PromoCode)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to simulate pricing")
+		}
+		return
+	}
+	response.Success(c, evaluation)
+}
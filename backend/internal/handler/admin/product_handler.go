@@ -534,7 +534,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 	var req CreateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := h.buildProductHookExecutionContext(c, adminID, 0)
@@ -690,7 +690,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	var req UpdateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	currentProduct, err := h.productService.GetProductByID(uint(productID), false)
@@ -844,7 +844,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 		return
 	}
 	hookExecCtx := h.buildProductHookExecutionContext(c, adminID, product.ID)
-	deleteOptions := service.DeleteProductOptions{DeleteImages: true}
+	deleteOptions := service.DeleteProductOptions{DeletedBy: adminID}
 	if h.pluginManager != nil {
 		originalOptions := deleteOptions
 		hookPayload := map[string]interface{}{
@@ -1172,7 +1172,7 @@ func (h *ProductHandler) UpdateProductStatus(c *gin.Context) {
 
 	var req UpdateStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1272,7 +1272,7 @@ func (h *ProductHandler) UpdateStock(c *gin.Context) {
 
 	var req UpdateStockRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1342,7 +1342,7 @@ func (h *ProductHandler) UpdateInventoryMode(c *gin.Context) {
 
 	var req UpdateInventoryModeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"errors"
+	"strconv"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductTrialHandler 虚拟商品试用：设置试用天数、查看试用记录
+type ProductTrialHandler struct {
+	trialService *service.ProductTrialService
+	db           *gorm.DB
+}
+
+func NewProductTrialHandler(trialService *service.ProductTrialService, db *gorm.DB) *ProductTrialHandler {
+	return &ProductTrialHandler{trialService: trialService, db: db}
+}
+
+// UpdateProductTrialRequest 设置商品试用天数请求，0 表示关闭
+type UpdateProductTrialRequest struct {
+	TrialDays int `json:"trial_days"`
+}
+
+// GetStats 商品试用设置与各状态的试用数量
+func (h *ProductTrialHandler) GetStats(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	stats, err := h.trialService.Stats(productID)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		response.InternalError(c, "Failed to get trial stats")
+		return
+	}
+	response.Success(c, stats)
+}
+
+// Update 设置商品试用天数
+func (h *ProductTrialHandler) Update(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	var req UpdateProductTrialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	product, err := h.trialService.SetProductTrial(productID, req.TrialDays)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update trial")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update_product_trial", "product", &product.ID, map[string]interface{}{
+		"sku":        product.SKU,
+		"trial_days": req.TrialDays,
+	})
+	stats, err := h.trialService.Stats(productID)
+	if err != nil {
+		response.InternalError(c, "Failed to get trial stats")
+		return
+	}
+	response.Success(c, stats)
+}
+
+// List 分页查询试用记录，可按商品、用户与状态过滤
+func (h *ProductTrialHandler) List(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	productID, _ := strconv.ParseUint(c.Query("product_id"), 10, 32)
+	userID, _ := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	trials, total, err := h.trialService.ListTrials(uint(productID), uint(userID), c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, trials, page, limit, total)
+}
@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PromoCodeCampaignHandler 优惠码活动：批量生成、导出与统计
+type PromoCodeCampaignHandler struct {
+	campaignService *service.PromoCodeCampaignService
+	db              *gorm.DB
+}
+
+func NewPromoCodeCampaignHandler(campaignService *service.PromoCodeCampaignService, db *gorm.DB) *PromoCodeCampaignHandler {
+	return &PromoCodeCampaignHandler{campaignService: campaignService, db: db}
+}
+
+// CreatePromoCodeCampaignRequest 创建活动并批量生成优惠码请求
+type CreatePromoCodeCampaignRequest struct {
+	Name                string              `json:"name" binding:"required"`
+	Description         string              `json:"description"`
+	Prefix              string              `json:"prefix"`
+	Pattern             string              `json:"pattern"`
+	Count               int                 `json:"count" binding:"required,gt=0"`
+	DiscountType        models.DiscountType `json:"discount_type" binding:"required"`
+	DiscountValueMinor  int64               `json:"discount_value_minor" binding:"required,gt=0"`
+	MaxDiscountMinor    int64               `json:"max_discount_minor"`
+	MinOrderAmountMinor int64               `json:"min_order_amount_minor"`
+	UsesPerCode         *int                `json:"uses_per_code"`
+	PerUserLimit        int                 `json:"per_user_limit"`
+	ProductIDs          []uint              `json:"product_ids"`
+	ProductScope        string              `json:"product_scope"`
+	StartsAt            *string             `json:"starts_at"`
+	ExpiresAt           *string             `json:"expires_at"`
+}
+
+// parsePromoCodeStartInput 解析生效时间，仅有日期时从当天零点开始生效
+func parsePromoCodeStartInput(value *string) (*time.Time, error) {
+	if value != nil {
+		if t, err := time.Parse("2006-01-02", strings.TrimSpace(*value)); err == nil {
+			return &t, nil
+		}
+	}
+	return parsePromoCodeExpiryInput(value)
+}
+
+// CreateCampaign 创建活动并按前缀与模式生成优惠码
+func (h *PromoCodeCampaignHandler) CreateCampaign(c *gin.Context) {
+	var req CreatePromoCodeCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	startsAt, err := parsePromoCodeStartInput(req.StartsAt)
+	if err != nil {
+		response.BadRequest(c, "Invalid start date format")
+		return
+	}
+	expiresAt, err := parsePromoCodeExpiryInput(req.ExpiresAt)
+	if err != nil {
+		response.BadRequest(c, "Invalid expiry date format")
+		return
+	}
+	usesPerCode := 1
+	if req.UsesPerCode != nil {
+		usesPerCode = *req.UsesPerCode
+	}
+
+	campaign, err := h.campaignService.Create(service.PromoCodeCampaignInput{
+		Name:           req.Name,
+		Description:    req.Description,
+		Prefix:         req.Prefix,
+		Pattern:        req.Pattern,
+		Count:          req.Count,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValueMinor,
+		MaxDiscount:    req.MaxDiscountMinor,
+		MinOrderAmount: req.MinOrderAmountMinor,
+		UsesPerCode:    usesPerCode,
+		PerUserLimit:   req.PerUserLimit,
+		ProductIDs:     req.ProductIDs,
+		ProductScope:   req.ProductScope,
+		StartsAt:       startsAt,
+		ExpiresAt:      expiresAt,
+		CreatedBy:      getOptionalUserID(c),
+	})
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to create promo code campaign")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "create", "promo_code_campaign", &campaign.ID, map[string]interface{}{
+		"name":    campaign.Name,
+		"prefix":  campaign.Prefix,
+		"pattern": campaign.Pattern,
+		"count":   campaign.CodeCount,
+	})
+	response.Success(c, campaign)
+}
+
+// ListCampaigns 活动列表
+func (h *PromoCodeCampaignHandler) ListCampaigns(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	campaigns, total, err := h.campaignService.List(page, limit, c.Query("search"))
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, campaigns, page, limit, total)
+}
+
+// GetCampaign 活动详情
+func (h *PromoCodeCampaignHandler) GetCampaign(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid campaign ID")
+		return
+	}
+	campaign, err := h.campaignService.Get(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, campaign)
+}
+
+// GetCampaignStats 活动的发放、预留与使用统计
+func (h *PromoCodeCampaignHandler) GetCampaignStats(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid campaign ID")
+		return
+	}
+	stats, err := h.campaignService.Stats(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, stats)
+}
+
+// ExportCampaignCodes 导出活动生成的优惠码 CSV
+func (h *PromoCodeCampaignHandler) ExportCampaignCodes(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid campaign ID")
+		return
+	}
+	codes, err := h.campaignService.ListCodes(id)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	if len(codes) > adminCSVExportMaxRows {
+		response.BadRequest(c, fmt.Sprintf("Too many records to export (max %d).", adminCSVExportMaxRows))
+		return
+	}
+
+	rows := make([][]string, 0, len(codes))
+	for _, item := range codes {
+		rows = append(rows, []string{
+			item.Code,
+			string(item.Status),
+			strconv.Itoa(item.TotalQuantity),
+			strconv.Itoa(item.UsedQuantity),
+			strconv.Itoa(item.ReservedQuantity),
+			strconv.Itoa(item.PerUserLimit),
+			csvTimePtrValue(item.StartsAt),
+			csvTimePtrValue(item.ExpiresAt),
+			csvTimeValue(item.CreatedAt),
+		})
+	}
+
+	logger.LogOperation(h.db, c, "export", "promo_code_campaign", &id, map[string]interface{}{
+		"count":  len(rows),
+		"format": "csv",
+	})
+	writeCSVAttachment(c, buildAdminCSVFileName(fmt.Sprintf("promo_code_campaign_%d", id)), []string{
+		"Code",
+		"Status",
+		"Total Quantity",
+		"Used Quantity",
+		"Reserved Quantity",
+		"Per User Limit",
+		"Starts At",
+		"Expires At",
+		"Created At",
+	}, rows)
+}
@@ -454,7 +454,7 @@ func buildPromoCodeAdminHookPayload(promoCode *models.PromoCode) map[string]inte
 func (h *PromoCodeHandler) CreatePromoCode(c *gin.Context) {
 	var req CreatePromoCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -585,7 +585,7 @@ func (h *PromoCodeHandler) UpdatePromoCode(c *gin.Context) {
 
 	var req UpdatePromoCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -732,7 +732,7 @@ func (h *PromoCodeHandler) DeletePromoCode(c *gin.Context) {
 		}
 	}
 
-	if err := h.promoCodeService.Delete(uint(id)); err != nil {
+	if err := h.promoCodeService.Delete(uint(id), adminIDValue); err != nil {
 		if respondAdminBizError(c, err) {
 			return
 		}
@@ -0,0 +1,226 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type QuoteHandler struct {
+	quoteService *service.QuoteService
+	cfg          *config.Config
+	db           *gorm.DB
+}
+
+func NewQuoteHandler(quoteService *service.QuoteService, cfg *config.Config, db *gorm.DB) *QuoteHandler {
+	return &QuoteHandler{quoteService: quoteService, cfg: cfg, db: db}
+}
+
+// QuoteRequest 创建或修改报价单请求，金额均为最小货币单位
+type QuoteRequest struct {
+	UserID        uint               `json:"user_id" binding:"required"`
+	Title         string             `json:"title"`
+	Items         []models.QuoteItem `json:"items" binding:"required"`
+	DiscountMinor int64              `json:"discount_minor"`
+	ValidUntil    *time.Time         `json:"valid_until"`
+	Note          string             `json:"note"`
+	AdminNote     string             `json:"admin_note"`
+}
+
+// SendQuoteRequest 发送报价单请求
+type SendQuoteRequest struct {
+	SendEmail bool `json:"send_email"`
+}
+
+func (r *QuoteRequest) toInput() service.QuoteInput {
+	return service.QuoteInput{
+		UserID:         r.UserID,
+		Title:          validator.SanitizeInput(r.Title),
+		Items:          r.Items,
+		DiscountAmount: r.DiscountMinor,
+		ValidUntil:     r.ValidUntil,
+		Note:           validator.SanitizeText(r.Note),
+		AdminNote:      validator.SanitizeText(r.AdminNote),
+	}
+}
+
+func respondQuoteError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrQuoteNotFound) {
+		response.NotFound(c, "Quote not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListQuotes 报价单列表
+func (h *QuoteHandler) ListQuotes(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	var userID uint
+	if raw := c.Query("user_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid user ID")
+			return
+		}
+		userID = uint(parsed)
+	}
+	quotes, total, err := h.quoteService.List(c.Query("status"), c.Query("search"), userID, page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get quotes")
+		return
+	}
+	response.Paginated(c, quotes, page, limit, total)
+}
+
+// GetQuote 报价单详情
+func (h *QuoteHandler) GetQuote(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid quote ID")
+		return
+	}
+	quote, err := h.quoteService.Get(id)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to get quote")
+		return
+	}
+	response.Success(c, quote)
+}
+
+// CreateQuote 创建报价单草稿
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quote, err := h.quoteService.Create(req.toInput(), adminID)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to create quote")
+		return
+	}
+	logger.LogOperation(h.db, c, "create_quote", "quote", &quote.ID, map[string]interface{}{
+		"quote_no":           quote.QuoteNo,
+		"user_id":            quote.UserID,
+		"total_amount_minor": quote.TotalAmount,
+	})
+	response.Success(c, quote)
+}
+
+// UpdateQuote 修改报价单草稿
+func (h *QuoteHandler) UpdateQuote(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid quote ID")
+		return
+	}
+	var req QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quote, err := h.quoteService.Update(id, req.toInput())
+	if err != nil {
+		respondQuoteError(c, err, "Failed to update quote")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_quote", "quote", &quote.ID, map[string]interface{}{
+		"quote_no":           quote.QuoteNo,
+		"total_amount_minor": quote.TotalAmount,
+	})
+	response.Success(c, quote)
+}
+
+// SendQuote 将草稿发送给客户
+func (h *QuoteHandler) SendQuote(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid quote ID")
+		return
+	}
+	var req SendQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quote, err := h.quoteService.Send(id, req.SendEmail)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to send quote")
+		return
+	}
+	logger.LogOperation(h.db, c, "send_quote", "quote", &quote.ID, map[string]interface{}{
+		"quote_no":   quote.QuoteNo,
+		"send_email": req.SendEmail,
+	})
+	response.Success(c, quote)
+}
+
+// CancelQuote 撤回报价单
+func (h *QuoteHandler) CancelQuote(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid quote ID")
+		return
+	}
+	quote, err := h.quoteService.Cancel(id)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to cancel quote")
+		return
+	}
+	logger.LogOperation(h.db, c, "cancel_quote", "quote", &quote.ID, map[string]interface{}{
+		"quote_no": quote.QuoteNo,
+	})
+	response.Success(c, quote)
+}
+
+// PreviewQuote 按客户看到的版式预览报价单，format=pdf 时返回 PDF
+func (h *QuoteHandler) PreviewQuote(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid quote ID")
+		return
+	}
+	quote, err := h.quoteService.Get(id)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to get quote")
+		return
+	}
+	asPDF := c.Query("format") == "pdf"
+	if asPDF && !service.InvoicePDFEnabled(&h.cfg.Order.Invoice) {
+		response.BadRequest(c, "PDF documents are not enabled")
+		return
+	}
+	html, err := service.RenderQuoteHTML(h.cfg, quote, asPDF)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render quote", err)
+		return
+	}
+	if !asPDF {
+		c.Data(200, "text/html; charset=utf-8", html)
+		return
+	}
+	pdf, err := service.RenderInvoicePDF(c.Request.Context(), &h.cfg.Order.Invoice, html)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render quote PDF", err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", quote.QuoteNo+".pdf"))
+	c.Data(200, "application/pdf", pdf)
+}
@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RefundRequestHandler struct {
+	refundRequestService *service.RefundRequestService
+	orderService         *service.OrderService
+	pluginManager        *service.PluginManagerService
+	db                   *gorm.DB
+}
+
+func NewRefundRequestHandler(refundRequestService *service.RefundRequestService, orderService *service.OrderService, pluginManager *service.PluginManagerService, db *gorm.DB) *RefundRequestHandler {
+	return &RefundRequestHandler{
+		refundRequestService: refundRequestService,
+		orderService:         orderService,
+		pluginManager:        pluginManager,
+		db:                   db,
+	}
+}
+
+// ReviewRefundRequestRequest 审核退款申请请求
+type ReviewRefundRequestRequest struct {
+	Note string `json:"note"`
+}
+
+func respondRefundRequestError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrRefundRequestNotFound):
+		response.NotFound(c, "Refund request not found")
+	case errors.Is(err, service.ErrRefundOrderNotFound):
+		response.NotFound(c, "Order not found")
+	default:
+		var declined *service.RefundDeclinedError
+		if errors.As(err, &declined) || errors.Is(err, service.ErrRefundExecution) {
+			respondAdminRefundError(c, err)
+			return
+		}
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// ListRefundRequests 退款申请审核队列
+func (h *RefundRequestHandler) ListRefundRequests(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	requests, total, err := h.refundRequestService.List(c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get refund requests")
+		return
+	}
+	response.Paginated(c, requests, page, limit, total)
+}
+
+// GetRefundRequest 退款申请详情
+func (h *RefundRequestHandler) GetRefundRequest(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund request ID")
+		return
+	}
+	request, err := h.refundRequestService.Get(id)
+	if err != nil {
+		respondRefundRequestError(c, err, "Failed to get refund request")
+		return
+	}
+	response.Success(c, request)
+}
+
+// ApproveRefundRequest 批准退款申请并执行退款
+func (h *RefundRequestHandler) ApproveRefundRequest(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund request ID")
+		return
+	}
+	var req ReviewRefundRequestRequest
+	_ = c.ShouldBindJSON(&req)
+
+	request, order, outcome, err := h.refundRequestService.Approve(id, adminID, req.Note)
+	if err != nil {
+		respondRefundRequestError(c, err, "Failed to approve refund request")
+		return
+	}
+
+	if order.UserID != nil {
+		if err := h.orderService.SyncUserConsumptionStats(*order.UserID); err != nil {
+			logger.LogOrderOperation(h.db, c, "sync_user_consumption_stats_failed", order.ID, map[string]interface{}{
+				"order_no": order.OrderNo,
+				"user_id":  *order.UserID,
+				"error":    err.Error(),
+			})
+		}
+	}
+	logger.LogOrderOperation(h.db, c, "refund", order.ID, map[string]interface{}{
+		"order_no":          order.OrderNo,
+		"refund_request_id": request.ID,
+		"status_after":      outcome.StatusAfter,
+		"refund_amount":     outcome.RefundAmount,
+		"refund_pending":    outcome.Result.Pending,
+		"refund_message":    outcome.Result.Message,
+		"transaction_id":    outcome.Result.TransactionID,
+	})
+	logger.LogOperation(h.db, c, "approve_refund_request", "refund_request", &request.ID, map[string]interface{}{
+		"order_no": request.OrderNo,
+		"note":     request.ReviewNote,
+	})
+	service.PublishOrderStatusChanged(h.orderService.OrderRepo, h.pluginManager, nil, order, outcome.StatusBefore, outcome.StatusAfter, map[string]interface{}{
+		"source":            "admin_api",
+		"trigger_action":    "order.refund_request.approve",
+		"admin_id":          adminID,
+		"refund_request_id": request.ID,
+		"transaction_id":    outcome.Result.TransactionID,
+		"refund_pending":    outcome.Result.Pending,
+		"payment_message":   outcome.Result.Message,
+	})
+
+	response.Success(c, request)
+}
+
+// RejectRefundRequest 拒绝退款申请
+func (h *RefundRequestHandler) RejectRefundRequest(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid refund request ID")
+		return
+	}
+	var req ReviewRefundRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	request, err := h.refundRequestService.Reject(id, adminID, req.Note)
+	if err != nil {
+		respondRefundRequestError(c, err, "Failed to reject refund request")
+		return
+	}
+	logger.LogOperation(h.db, c, "reject_refund_request", "refund_request", &request.ID, map[string]interface{}{
+		"order_no": request.OrderNo,
+		"note":     request.ReviewNote,
+	})
+	response.Success(c, request)
+}
@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReturnHandler struct {
+	returnService *service.ReturnService
+	db            *gorm.DB
+}
+
+func NewReturnHandler(returnService *service.ReturnService, db *gorm.DB) *ReturnHandler {
+	return &ReturnHandler{returnService: returnService, db: db}
+}
+
+// ReviewReturnRequest 审核退货申请请求
+type ReviewReturnRequest struct {
+	Note string `json:"note"`
+}
+
+// ReceiveReturnRequest 确认收到退货请求
+type ReceiveReturnRequest struct {
+	Restock bool   `json:"restock"` // 将商品退回订单项绑定的库存
+	Note    string `json:"note"`
+}
+
+func respondReturnError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrReturnRequestNotFound) {
+		response.NotFound(c, "Return request not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListReturns 退货列表
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	requests, total, err := h.returnService.List(c.Query("status"), c.Query("search"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get return requests")
+		return
+	}
+	response.Paginated(c, requests, page, limit, total)
+}
+
+// GetReturn 退货详情
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid return request ID")
+		return
+	}
+	request, err := h.returnService.Get(id)
+	if err != nil {
+		respondReturnError(c, err, "Failed to get return request")
+		return
+	}
+	response.Success(c, request)
+}
+
+// ApproveReturn 批准退货申请
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid return request ID")
+		return
+	}
+	var req ReviewReturnRequest
+	_ = c.ShouldBindJSON(&req)
+
+	request, err := h.returnService.Approve(id, adminID, validator.SanitizeText(req.Note))
+	if err != nil {
+		respondReturnError(c, err, "Failed to approve return request")
+		return
+	}
+	logger.LogOperation(h.db, c, "approve_return", "return_request", &request.ID, map[string]interface{}{
+		"return_no": request.ReturnNo,
+		"order_no":  request.OrderNo,
+		"note":      request.ReviewNote,
+	})
+	response.Success(c, request)
+}
+
+// RejectReturn 拒绝退货申请
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid return request ID")
+		return
+	}
+	var req ReviewReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	request, err := h.returnService.Reject(id, adminID, validator.SanitizeText(req.Note))
+	if err != nil {
+		respondReturnError(c, err, "Failed to reject return request")
+		return
+	}
+	logger.LogOperation(h.db, c, "reject_return", "return_request", &request.ID, map[string]interface{}{
+		"return_no": request.ReturnNo,
+		"order_no":  request.OrderNo,
+		"note":      request.ReviewNote,
+	})
+	response.Success(c, request)
+}
+
+// ReceiveReturn 确认收到退货，可选择退回库存
+func (h *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid return request ID")
+		return
+	}
+	var req ReceiveReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	operator := "unknown"
+	if email, ok := c.Get("user_email"); ok {
+		if value, ok := email.(string); ok {
+			operator = value
+		}
+	}
+	request, err := h.returnService.Receive(id, adminID, req.Restock, validator.SanitizeText(req.Note), operator)
+	if err != nil {
+		respondReturnError(c, err, "Failed to receive return")
+		return
+	}
+	logger.LogOperation(h.db, c, "receive_return", "return_request", &request.ID, map[string]interface{}{
+		"return_no": request.ReturnNo,
+		"order_no":  request.OrderNo,
+		"restock":   req.Restock,
+		"restocked": request.Restocked,
+	})
+	response.Success(c, request)
+}
@@ -0,0 +1,153 @@
+package admin
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RevenueRecognitionHandler struct {
+	revenueService *service.RevenueRecognitionService
+	db             *gorm.DB
+}
+
+func NewRevenueRecognitionHandler(revenueService *service.RevenueRecognitionService, db *gorm.DB) *RevenueRecognitionHandler {
+	return &RevenueRecognitionHandler{revenueService: revenueService, db: db}
+}
+
+// UpdateProductRevenueRecognitionRequest 设置商品收入确认方式请求
+type UpdateProductRevenueRecognitionRequest struct {
+	RevenueRecognition  models.RevenueRecognitionType `json:"revenue_recognition"`
+	ServicePeriodMonths int                           `json:"service_period_months"`
+}
+
+// BackfillRevenueSchedulesRequest 补建收入确认计划请求
+type BackfillRevenueSchedulesRequest struct {
+	Since string `json:"since" binding:"required"` // YYYY-MM，从该月起付款的订单
+}
+
+// GetReport 递延收入月度变动表
+func (h *RevenueRecognitionHandler) GetReport(c *gin.Context) {
+	from, to, ok := parseFXSettlementRange(c)
+	if !ok {
+		return
+	}
+	report, err := h.revenueService.MonthlyReport(from, to)
+	if err != nil {
+		response.InternalError(c, "Failed to build revenue recognition report")
+		return
+	}
+	response.Success(c, report)
+}
+
+// ExportReport 导出递延收入月度变动表（CSV）
+func (h *RevenueRecognitionHandler) ExportReport(c *gin.Context) {
+	from, to, ok := parseFXSettlementRange(c)
+	if !ok {
+		return
+	}
+	report, err := h.revenueService.MonthlyReport(from, to)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+
+	headers := []string{
+		"period",
+		"currency",
+		"type",
+		"schedule_count",
+		"deferred_opening",
+		"billed_amount",
+		"recognized_amount",
+		"released_amount",
+		"deferred_closing",
+	}
+	rows := make([][]string, 0, len(report.Rows))
+	for _, item := range report.Rows {
+		rows = append(rows, []string{
+			item.Period,
+			item.Currency,
+			item.Type,
+			strconv.FormatInt(item.ScheduleCount, 10),
+			money.MinorToString(item.DeferredOpeningMinor),
+			money.MinorToString(item.BilledAmountMinor),
+			money.MinorToString(item.RecognizedAmountMinor),
+			money.MinorToString(item.ReleasedAmountMinor),
+			money.MinorToString(item.DeferredClosingMinor),
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName("revenue_recognition_"+report.From+"_"+report.To), headers, rows)
+}
+
+// Backfill 为功能启用前已付款的订单补建收入确认计划
+func (h *RevenueRecognitionHandler) Backfill(c *gin.Context) {
+	var req BackfillRevenueSchedulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	since, err := time.Parse("2006-01", strings.TrimSpace(req.Since))
+	if err != nil {
+		response.BadRequest(c, "Invalid month, expected YYYY-MM")
+		return
+	}
+
+	created, err := h.revenueService.Backfill(since)
+	if err != nil {
+		response.InternalError(c, "Failed to backfill revenue schedules")
+		return
+	}
+	logger.LogOperation(h.db, c, "revenue_schedule_backfill", "revenue_schedule", nil, map[string]interface{}{
+		"since":   req.Since,
+		"created": created,
+	})
+	response.Success(c, gin.H{"created": created})
+}
+
+// UpdateProduct 设置商品收入确认方式与服务期，只影响之后创建的订单
+func (h *RevenueRecognitionHandler) UpdateProduct(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	var req UpdateProductRevenueRecognitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	product, err := h.revenueService.SetProductRevenueRecognition(productID, req.RevenueRecognition, req.ServicePeriodMonths)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to update revenue recognition")
+		}
+		return
+	}
+	logger.LogOperation(h.db, c, "update_revenue_recognition", "product", &product.ID, map[string]interface{}{
+		"sku":                   product.SKU,
+		"revenue_recognition":   product.RevenueRecognition,
+		"service_period_months": product.ServicePeriodMonths,
+	})
+	response.Success(c, gin.H{
+		"product_id":            product.ID,
+		"revenue_recognition":   product.RevenueRecognition,
+		"service_period_months": product.ServicePeriodMonths,
+	})
+}
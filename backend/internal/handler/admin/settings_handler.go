@@ -15,6 +15,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"auralogic/internal/config"
@@ -30,6 +31,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// settingsFileMu 串行化配置文件、邮件模板的读取-校验-写入，保证乐观锁校验与写入之间不被其他请求插入
+var settingsFileMu sync.Mutex
+
 type SettingsHandler struct {
 	db            *gorm.DB
 	cfg           *config.Config
@@ -187,6 +191,8 @@ func (h *SettingsHandler) GetPublicConfig(c *gin.Context) {
 		"allow_phone_login":          h.cfg.Security.Login.AllowPhoneLogin,
 		"allow_phone_register":       h.cfg.Security.Login.AllowPhoneRegister,
 		"allow_phone_password_reset": h.cfg.Security.Login.AllowPhonePasswordReset,
+		"allow_passkey_login":        h.cfg.Security.Login.AllowPasskeyLogin && strings.TrimSpace(h.cfg.App.URL) != "",
+		"email_verification_mode":    service.ResolveEmailVerificationMode(h.cfg),
 		"stock_display": gin.H{
 			"mode":                 h.cfg.Order.StockDisplay.Mode,
 			"low_stock_threshold":  h.cfg.Order.StockDisplay.LowStockThreshold,
@@ -199,17 +205,27 @@ func (h *SettingsHandler) GetPublicConfig(c *gin.Context) {
 			"auth_branding": h.renderAuthBranding(),
 		},
 		"ticket": gin.H{
-			"enabled":            h.cfg.Ticket.Enabled,
-			"categories":         h.cfg.Ticket.Categories,
-			"attachment":         h.cfg.Ticket.Attachment,
-			"max_content_length": h.cfg.Ticket.MaxContentLength,
-			"auto_close_hours":   h.cfg.Ticket.AutoCloseHours,
+			"enabled":                 h.cfg.Ticket.Enabled,
+			"categories":              h.cfg.Ticket.Categories,
+			"topics":                  h.cfg.Ticket.Topics,
+			"attachment":              h.cfg.Ticket.Attachment,
+			"max_content_length":      h.cfg.Ticket.MaxContentLength,
+			"auto_close_hours":        h.cfg.Ticket.AutoCloseHours,
+			"dispute_escalation_days": h.cfg.Ticket.DisputeEscalationDays,
 		},
 		"serial": gin.H{
 			"enabled": h.cfg.Serial.Enabled,
 		},
 		"auto_cancel_hours":                  h.cfg.Order.AutoCancelHours,
 		"invoice_enabled":                    h.cfg.Order.Invoice.Enabled,
+		"invoice_pdf_enabled":                service.InvoicePDFEnabled(&h.cfg.Order.Invoice),
+		"net_terms_enabled":                  h.cfg.Order.NetTerms.Enabled,
+		"cash_on_delivery_enabled":           h.cfg.Order.CashOnDelivery.Enabled,
+		"gift_card_enabled":                  h.cfg.Order.GiftCard.Enabled,
+		"payment_link_enabled":               h.cfg.Order.PaymentLink.Enabled,
+		"quote_enabled":                      h.cfg.Order.Quote.Enabled,
+		"returns_enabled":                    h.cfg.Order.Returns.Enabled,
+		"spending_controls_enabled":          h.cfg.Order.SpendingControls.Enabled,
 		"show_virtual_stock_remark":          h.cfg.Order.ShowVirtualStockRemark,
 		"enable_virtual_stock_inline_iframe": h.cfg.Order.EnableVirtualStockInlineIframe,
 		"smtp_enabled":                       h.cfg.SMTP.Enabled,
@@ -261,10 +277,13 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 			"aliyun_sign_name":     h.cfg.SMS.AliyunSignName,
 			"aliyun_template_code": h.cfg.SMS.AliyunTemplateCode,
 			"templates": gin.H{
-				"login":          h.cfg.SMS.Templates.Login,
-				"register":       h.cfg.SMS.Templates.Register,
-				"reset_password": h.cfg.SMS.Templates.ResetPassword,
-				"bind_phone":     h.cfg.SMS.Templates.BindPhone,
+				"login":           h.cfg.SMS.Templates.Login,
+				"register":        h.cfg.SMS.Templates.Register,
+				"reset_password":  h.cfg.SMS.Templates.ResetPassword,
+				"bind_phone":      h.cfg.SMS.Templates.BindPhone,
+				"bodies":          h.cfg.SMS.Templates.Bodies,
+				"country_locales": h.cfg.SMS.Templates.CountryLocales,
+				"default_locale":  h.cfg.SMS.Templates.DefaultLocale,
 			},
 			"dypns_code_length":         h.cfg.SMS.DYPNSCodeLength,
 			"twilio_account_sid":        h.cfg.SMS.TwilioAccountSID,
@@ -289,6 +308,10 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 		"order": gin.H{
 			"no_prefix":                          h.cfg.Order.NoPrefix,
 			"auto_cancel_hours":                  h.cfg.Order.AutoCancelHours,
+			"auto_complete_days":                 h.cfg.Order.AutoCompleteDays,
+			"auto_complete_reminder_days":        h.cfg.Order.AutoCompleteReminderDays,
+			"auto_complete_days_by_type":         h.cfg.Order.AutoCompleteDaysByType,
+			"abandon_release_minutes":            h.cfg.Order.AbandonReleaseMinutes,
 			"currency":                           h.cfg.Order.Currency,
 			"max_order_items":                    h.cfg.Order.MaxOrderItems,
 			"max_item_quantity":                  h.cfg.Order.MaxItemQuantity,
@@ -296,6 +319,22 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 			"virtual_script_timeout_max_ms":      h.cfg.Order.VirtualScriptTimeoutMaxMs,
 			"show_virtual_stock_remark":          h.cfg.Order.ShowVirtualStockRemark,
 			"enable_virtual_stock_inline_iframe": h.cfg.Order.EnableVirtualStockInlineIframe,
+			"number": gin.H{
+				"format":          h.cfg.Order.Number.Format,
+				"sequence_digits": h.cfg.Order.Number.SequenceDigits,
+				"random_digits":   h.cfg.Order.Number.RandomDigits,
+				"store_prefixes":  h.cfg.Order.Number.StorePrefixes,
+			},
+			"draft_cleanup": gin.H{
+				"retention_days": h.cfg.Order.DraftCleanup.RetentionDays,
+				"action":         h.cfg.Order.DraftCleanup.Action,
+			},
+			"recovery": gin.H{
+				"enabled":         h.cfg.Order.Recovery.Enabled,
+				"intervals_hours": h.cfg.Order.Recovery.IntervalsHours,
+				"send_sms":        h.cfg.Order.Recovery.SendSMS,
+				"campaign":        h.cfg.Order.Recovery.Campaign,
+			},
 			"high_concurrency_protection": gin.H{
 				"enabled":         h.cfg.Order.HighConcurrencyProtection.Enabled,
 				"mode":            h.cfg.Order.HighConcurrencyProtection.Mode,
@@ -360,12 +399,15 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 			// password 不返回
 		},
 		"ticket": gin.H{
-			"enabled":            h.cfg.Ticket.Enabled,
-			"categories":         h.cfg.Ticket.Categories,
-			"template":           h.cfg.Ticket.Template,
-			"max_content_length": h.cfg.Ticket.MaxContentLength,
-			"auto_close_hours":   h.cfg.Ticket.AutoCloseHours,
-			"attachment":         h.cfg.Ticket.Attachment,
+			"enabled":                 h.cfg.Ticket.Enabled,
+			"categories":              h.cfg.Ticket.Categories,
+			"template":                h.cfg.Ticket.Template,
+			"max_content_length":      h.cfg.Ticket.MaxContentLength,
+			"auto_close_hours":        h.cfg.Ticket.AutoCloseHours,
+			"dispute_escalation_days": h.cfg.Ticket.DisputeEscalationDays,
+			"dispute_evidence_days":   h.cfg.Ticket.DisputeEvidenceDays,
+			"attachment":              h.cfg.Ticket.Attachment,
+			"topics":                  h.cfg.Ticket.Topics,
 		},
 		"serial": gin.H{
 			"enabled": h.cfg.Serial.Enabled,
@@ -429,6 +471,10 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 			"enabled": h.cfg.Analytics.Enabled,
 		},
 	}
+	if data, err := os.ReadFile(config.GetConfigPath()); err == nil {
+		settings["version"] = contentVersion(data)
+		setVersionETag(c, settings["version"].(string))
+	}
 
 	response.Success(c, settings)
 }
@@ -457,6 +503,44 @@ func sortedConfiguredHeaderKeys(headers map[string]string) []string {
 	return keys
 }
 
+// normalizeSMSTemplateBodies 去除空语言/空正文，语言统一为小写
+func normalizeSMSTemplateBodies(bodies map[string]map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(bodies))
+	for locale, events := range bodies {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale == "" {
+			continue
+		}
+		normalized := make(map[string]string, len(events))
+		for event, body := range events {
+			event = strings.TrimSpace(event)
+			body = strings.TrimSpace(body)
+			if event == "" || body == "" {
+				continue
+			}
+			normalized[event] = body
+		}
+		if len(normalized) > 0 {
+			result[locale] = normalized
+		}
+	}
+	return result
+}
+
+// normalizeSMSCountryLocales 区号统一为 "+86" 形式
+func normalizeSMSCountryLocales(mapping map[string]string) map[string]string {
+	result := make(map[string]string, len(mapping))
+	for phoneCode, locale := range mapping {
+		phoneCode = strings.TrimLeft(strings.TrimSpace(phoneCode), "+")
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if phoneCode == "" || locale == "" {
+			continue
+		}
+		result["+"+phoneCode] = locale
+	}
+	return result
+}
+
 func captchaProviderRequiresSecret(provider string) bool {
 	switch strings.ToLower(strings.TrimSpace(provider)) {
 	case "cloudflare", "google":
@@ -501,6 +585,8 @@ type settingsCaptchaUpdateRequest struct {
 
 // UpdateSettingsRequest Update设置请求
 type UpdateSettingsRequest struct {
+	Version string `json:"version,omitempty"` // 读取设置时返回的配置文件版本，也可通过 If-Match 请求头提交
+
 	App struct {
 		Name         string `json:"name"`
 		URL          string `json:"url"`
@@ -539,6 +625,11 @@ type UpdateSettingsRequest struct {
 		CustomHeaders          map[string]string `json:"custom_headers"`
 		CustomHeadersSubmitted bool              `json:"custom_headers_submitted,omitempty"`
 		CustomBodyTemplate     string            `json:"custom_body_template"`
+		// 本地化正文模板，仅在 message_templates_submitted 为 true 时更新，避免旧版前端覆盖
+		MessageTemplatesSubmitted bool                         `json:"message_templates_submitted,omitempty"`
+		TemplateBodies            map[string]map[string]string `json:"template_bodies"`
+		TemplateCountryLocales    map[string]string            `json:"template_country_locales"`
+		TemplateDefaultLocale     string                       `json:"template_default_locale"`
 	} `json:"sms,omitempty"`
 
 	Security struct {
@@ -560,7 +651,14 @@ type UpdateSettingsRequest struct {
 
 	Order struct {
 		NoPrefix                       string                                      `json:"no_prefix"`
+		Number                         *config.OrderNumberConfig                   `json:"number"`
 		AutoCancelHours                int                                         `json:"auto_cancel_hours"`
+		AutoCompleteDays               int                                         `json:"auto_complete_days"`
+		AutoCompleteReminderDays       int                                         `json:"auto_complete_reminder_days"`
+		AutoCompleteDaysByType         map[string]int                              `json:"auto_complete_days_by_type"`
+		AbandonReleaseMinutes          int                                         `json:"abandon_release_minutes"`
+		DraftCleanup                   *config.DraftCleanupConfig                  `json:"draft_cleanup"`
+		Recovery                       *config.OrderRecoveryConfig                 `json:"recovery"`
 		MaxPendingPaymentOrdersPerUser int                                         `json:"max_pending_payment_orders_per_user"`
 		MaxPaymentPollingTasksPerUser  int                                         `json:"max_payment_polling_tasks_per_user"`
 		MaxPaymentPollingTasksGlobal   int                                         `json:"max_payment_polling_tasks_global"`
@@ -604,12 +702,15 @@ type UpdateSettingsRequest struct {
 	} `json:"log,omitempty"`
 
 	Ticket struct {
-		Enabled          bool                           `json:"enabled"`
-		Categories       []string                       `json:"categories"`
-		Template         string                         `json:"template"`
-		MaxContentLength int                            `json:"max_content_length"`
-		AutoCloseHours   int                            `json:"auto_close_hours"`
-		Attachment       *config.TicketAttachmentConfig `json:"attachment,omitempty"`
+		Enabled               bool                           `json:"enabled"`
+		Categories            []string                       `json:"categories"`
+		Template              string                         `json:"template"`
+		MaxContentLength      int                            `json:"max_content_length"`
+		AutoCloseHours        int                            `json:"auto_close_hours"`
+		DisputeEscalationDays int                            `json:"dispute_escalation_days"`
+		DisputeEvidenceDays   int                            `json:"dispute_evidence_days"`
+		Attachment            *config.TicketAttachmentConfig `json:"attachment,omitempty"`
+		Topics                []config.TicketTopicConfig     `json:"topics,omitempty"`
 	} `json:"ticket,omitempty"`
 
 	Serial struct {
@@ -697,7 +798,7 @@ type UpdateSettingsRequest struct {
 func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 	var req UpdateSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, adminIDOK := middleware.RequireUserID(c)
@@ -756,12 +857,18 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 	pluginRuntimeAction := ""
 
 	// 读取current配置文件
+	settingsFileMu.Lock()
+	defer settingsFileMu.Unlock()
 	configPath := config.GetConfigPath()
-	currentConfig, err := readConfigFile(configPath)
+	currentConfig, currentVersion, err := readConfigFile(configPath)
 	if err != nil {
 		response.InternalError(c, "Failed to read config file")
 		return
 	}
+	if expected := requestedVersion(c, req.Version); expected != "" && expected != currentVersion {
+		respondVersionConflict(c, currentVersion)
+		return
+	}
 
 	// Update配置
 	if req.App.Name != "" {
@@ -814,12 +921,24 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			}
 		}
 		smsConfig["custom_body_template"] = req.SMS.CustomBodyTemplate
-		smsConfig["templates"] = map[string]interface{}{
+		templates := map[string]interface{}{
 			"login":          req.SMS.TemplateLogin,
 			"register":       req.SMS.TemplateRegister,
 			"reset_password": req.SMS.TemplateResetPassword,
 			"bind_phone":     req.SMS.TemplateBindPhone,
 		}
+		if req.SMS.MessageTemplatesSubmitted {
+			templates["bodies"] = normalizeSMSTemplateBodies(req.SMS.TemplateBodies)
+			templates["country_locales"] = normalizeSMSCountryLocales(req.SMS.TemplateCountryLocales)
+			templates["default_locale"] = strings.TrimSpace(req.SMS.TemplateDefaultLocale)
+		} else if existing, ok := smsConfig["templates"].(map[string]interface{}); ok {
+			for _, key := range []string{"bodies", "country_locales", "default_locale"} {
+				if value, exists := existing[key]; exists {
+					templates[key] = value
+				}
+			}
+		}
+		smsConfig["templates"] = templates
 		smsConfig["dypns_code_length"] = req.SMS.DYPNSCodeLength
 		if req.SMS.AliyunAccessSecret != "" {
 			smsConfig["aliyun_access_secret"] = req.SMS.AliyunAccessSecret
@@ -883,6 +1002,39 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			}
 		}
 
+		// 验证：通行密钥依赖站点地址推导依赖方 ID
+		passkeyAppURL := h.cfg.App.URL
+		if req.App.URL != "" {
+			passkeyAppURL = req.App.URL
+		}
+		if req.Security.Login.AllowPasskeyLogin && strings.TrimSpace(passkeyAppURL) == "" {
+			response.BadRequest(c, "Allow passkey login requires the site URL to be set")
+			return
+		}
+
+		// 未提交邮箱验证限制方式与提醒节奏时沿用当前配置
+		verificationMode := strings.TrimSpace(req.Security.Login.EmailVerificationMode)
+		if verificationMode == "" {
+			verificationMode = h.cfg.Security.Login.EmailVerificationMode
+		}
+		switch verificationMode {
+		case "", service.EmailVerificationModeLogin, service.EmailVerificationModeCheckout,
+			service.EmailVerificationModeVirtualReveal, service.EmailVerificationModeWarn:
+		default:
+			response.BadRequest(c, "Invalid email verification mode")
+			return
+		}
+		verificationReminder := req.Security.Login.EmailVerificationReminder
+		if len(verificationReminder.ScheduleHours) == 0 {
+			verificationReminder.ScheduleHours = h.cfg.Security.Login.EmailVerificationReminder.ScheduleHours
+		}
+		for _, hours := range verificationReminder.ScheduleHours {
+			if hours <= 0 {
+				response.BadRequest(c, "Email verification reminder schedule hours must be positive")
+				return
+			}
+		}
+
 		securityConfig := currentConfig["security"].(map[string]interface{})
 		securityConfig["login"] = map[string]interface{}{
 			"allow_password_login":       req.Security.Login.AllowPasswordLogin,
@@ -894,12 +1046,18 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			"allow_phone_login":          req.Security.Login.AllowPhoneLogin,
 			"allow_phone_register":       req.Security.Login.AllowPhoneRegister,
 			"allow_phone_password_reset": req.Security.Login.AllowPhonePasswordReset,
+			"allow_passkey_login":        req.Security.Login.AllowPasskeyLogin,
+			"email_verification_mode":    verificationMode,
+			"email_verification_reminder": map[string]interface{}{
+				"enabled":        verificationReminder.Enabled,
+				"schedule_hours": verificationReminder.ScheduleHours,
+			},
 		}
 	}
 
 	// Update限流配置
 	if req.RateLimit.API > 0 || req.RateLimit.OrderCreate > 0 || req.RateLimit.PaymentInfo > 0 || req.RateLimit.PaymentSelect > 0 {
-		currentConfig["rate_limit"] = map[string]interface{}{
+		rateLimitConfig := map[string]interface{}{
 			"enabled":        req.RateLimit.Enabled,
 			"api":            req.RateLimit.API,
 			"user_login":     req.RateLimit.UserLogin,
@@ -909,6 +1067,21 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			"payment_info":   req.RateLimit.PaymentInfo,
 			"payment_select": req.RateLimit.PaymentSelect,
 		}
+		// 路由组令牌桶策略：未提交时沿用现有配置
+		if req.RateLimit.Policies != nil {
+			for name, policy := range req.RateLimit.Policies {
+				if err := policy.Validate(); err != nil {
+					response.BadRequest(c, fmt.Sprintf("Invalid rate limit policy %s: %v", name, err))
+					return
+				}
+			}
+			rateLimitConfig["policies"] = req.RateLimit.Policies
+		} else if existing, ok := currentConfig["rate_limit"].(map[string]interface{}); ok {
+			if policies, exists := existing["policies"]; exists {
+				rateLimitConfig["policies"] = policies
+			}
+		}
+		currentConfig["rate_limit"] = rateLimitConfig
 	}
 
 	// Update邮件发送限流
@@ -939,9 +1112,89 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 		if req.Order.EnableVirtualStockInlineIframe != nil {
 			enableVirtualStockInlineIframe = *req.Order.EnableVirtualStockInlineIframe
 		}
-		currentConfig["order"] = map[string]interface{}{
+		// 在原有配置上合并，保留设置页未涉及的订单配置（如结算报表、会计对接）
+		orderConfig, _ := currentConfig["order"].(map[string]interface{})
+		if orderConfig == nil {
+			orderConfig = map[string]interface{}{}
+		}
+		if req.Order.Number != nil {
+			format := strings.TrimSpace(req.Order.Number.Format)
+			switch format {
+			case service.OrderNumberFormatTimestamp, service.OrderNumberFormatDateSequence, service.OrderNumberFormatRandomChecksum:
+			default:
+				response.BadRequest(c, "Invalid order number format")
+				return
+			}
+			storePrefixes := req.Order.Number.StorePrefixes
+			if storePrefixes == nil {
+				storePrefixes = h.cfg.Order.Number.StorePrefixes
+			}
+			orderConfig["number"] = map[string]interface{}{
+				"format":          format,
+				"sequence_digits": req.Order.Number.SequenceDigits,
+				"random_digits":   req.Order.Number.RandomDigits,
+				"store_prefixes":  storePrefixes,
+			}
+		}
+		// 未提交时保留当前的按商品类型覆盖，提交空对象表示全部沿用 auto_complete_days
+		if req.Order.AutoCompleteDaysByType != nil {
+			for productType, days := range req.Order.AutoCompleteDaysByType {
+				if productType != string(models.ProductTypePhysical) && productType != string(models.ProductTypeVirtual) {
+					response.BadRequest(c, "Invalid product type for auto-complete days")
+					return
+				}
+				if days < 0 {
+					response.BadRequest(c, "Auto-complete days cannot be negative")
+					return
+				}
+			}
+			orderConfig["auto_complete_days_by_type"] = req.Order.AutoCompleteDaysByType
+		}
+		if req.Order.DraftCleanup != nil {
+			action := strings.TrimSpace(req.Order.DraftCleanup.Action)
+			if action == "" {
+				action = service.DraftCleanupActionDelete
+			}
+			if action != service.DraftCleanupActionDelete && action != service.DraftCleanupActionAnonymize {
+				response.BadRequest(c, "Invalid draft cleanup action")
+				return
+			}
+			if req.Order.DraftCleanup.RetentionDays < 0 {
+				response.BadRequest(c, "Draft cleanup retention days cannot be negative")
+				return
+			}
+			orderConfig["draft_cleanup"] = map[string]interface{}{
+				"retention_days": req.Order.DraftCleanup.RetentionDays,
+				"action":         action,
+			}
+		}
+		if req.Order.Recovery != nil {
+			intervals := make([]int, 0, len(req.Order.Recovery.IntervalsHours))
+			for _, hours := range req.Order.Recovery.IntervalsHours {
+				if hours <= 0 {
+					response.BadRequest(c, "Recovery reminder intervals must be positive hours")
+					return
+				}
+				intervals = append(intervals, hours)
+			}
+			campaign := strings.TrimSpace(req.Order.Recovery.Campaign)
+			if len(campaign) > 100 {
+				response.BadRequest(c, "Recovery campaign cannot exceed 100 characters")
+				return
+			}
+			orderConfig["recovery"] = map[string]interface{}{
+				"enabled":         req.Order.Recovery.Enabled,
+				"intervals_hours": intervals,
+				"send_sms":        req.Order.Recovery.SendSMS,
+				"campaign":        campaign,
+			}
+		}
+		for key, value := range map[string]interface{}{
 			"no_prefix":                           req.Order.NoPrefix,
 			"auto_cancel_hours":                   req.Order.AutoCancelHours,
+			"auto_complete_days":                  req.Order.AutoCompleteDays,
+			"auto_complete_reminder_days":         req.Order.AutoCompleteReminderDays,
+			"abandon_release_minutes":             req.Order.AbandonReleaseMinutes,
 			"max_pending_payment_orders_per_user": req.Order.MaxPendingPaymentOrdersPerUser,
 			"max_payment_polling_tasks_per_user":  req.Order.MaxPaymentPollingTasksPerUser,
 			"max_payment_polling_tasks_global":    req.Order.MaxPaymentPollingTasksGlobal,
@@ -975,8 +1228,14 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 				"company_logo":    req.Order.Invoice.CompanyLogo,
 				"tax_id":          req.Order.Invoice.TaxID,
 				"footer_text":     req.Order.Invoice.FooterText,
+				// PDF 渲染命令仅可在配置文件中修改，保存设置时原样保留
+				"pdf_renderer_command": h.cfg.Order.Invoice.PDFRendererCommand,
+				"pdf_timeout_seconds":  h.cfg.Order.Invoice.PDFTimeoutSeconds,
 			},
+		} {
+			orderConfig[key] = value
 		}
+		currentConfig["order"] = orderConfig
 	}
 
 	// Update魔法链接配置
@@ -1030,12 +1289,21 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 
 	// Update日志配置
 	if req.Log.Level != "" {
-		currentConfig["log"] = map[string]interface{}{
+		logConfig := map[string]interface{}{
 			"level":     req.Log.Level,
 			"format":    req.Log.Format,
 			"output":    req.Log.Output,
 			"file_path": req.Log.FilePath,
 		}
+		// 管理端审计和日志归档只能在配置文件中修改，避免管理员在后台关闭对自身操作的审计
+		if existing, ok := currentConfig["log"].(map[string]interface{}); ok {
+			for _, key := range []string{"admin_audit", "audit_export"} {
+				if value, exists := existing[key]; exists {
+					logConfig[key] = value
+				}
+			}
+		}
+		currentConfig["log"] = logConfig
 	}
 
 	// UpdateCORS配置
@@ -1083,7 +1351,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 	}
 
 	// Update工单配置
-	if req.Ticket.Categories != nil || req.Ticket.Template != "" || req.Ticket.Attachment != nil {
+	if req.Ticket.Categories != nil || req.Ticket.Template != "" || req.Ticket.Attachment != nil || req.Ticket.Topics != nil {
 		ticketConfig, ok := currentConfig["ticket"].(map[string]interface{})
 		if !ok {
 			ticketConfig = make(map[string]interface{})
@@ -1095,10 +1363,15 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			ticketConfig["categories"] = req.Ticket.Categories
 			ticketConfig["max_content_length"] = req.Ticket.MaxContentLength
 			ticketConfig["auto_close_hours"] = req.Ticket.AutoCloseHours
+			ticketConfig["dispute_escalation_days"] = req.Ticket.DisputeEscalationDays
+			ticketConfig["dispute_evidence_days"] = req.Ticket.DisputeEvidenceDays
 		}
 		if req.Ticket.Template != "" {
 			ticketConfig["template"] = req.Ticket.Template
 		}
+		if req.Ticket.Topics != nil {
+			ticketConfig["topics"] = req.Ticket.Topics
+		}
 		if req.Ticket.Attachment != nil {
 			ticketConfig["attachment"] = map[string]interface{}{
 				"enable_image":        req.Ticket.Attachment.EnableImage,
@@ -1107,6 +1380,9 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 				"max_voice_size":      req.Ticket.Attachment.MaxVoiceSize,
 				"max_voice_duration":  req.Ticket.Attachment.MaxVoiceDuration,
 				"allowed_image_types": req.Ticket.Attachment.AllowedImageTypes,
+				"enable_file":         req.Ticket.Attachment.EnableFile,
+				"max_file_size":       req.Ticket.Attachment.MaxFileSize,
+				"allowed_file_types":  req.Ticket.Attachment.AllowedFileTypes,
 				"retention_days":      req.Ticket.Attachment.RetentionDays,
 			}
 		}
@@ -1148,6 +1424,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			"order_paid":         req.EmailNotifications.OrderPaid,
 			"order_shipped":      req.EmailNotifications.OrderShipped,
 			"order_completed":    req.EmailNotifications.OrderCompleted,
+			"order_auto_remind":  req.EmailNotifications.OrderAutoRemind,
 			"order_cancelled":    req.EmailNotifications.OrderCancelled,
 			"order_resubmit":     req.EmailNotifications.OrderResubmit,
 			"ticket_created":     req.EmailNotifications.TicketCreated,
@@ -1542,6 +1819,10 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 	resp := gin.H{
 		"message": "Settings saved and applied. Some configurations (Database, Redis, JWT) require service restart to take effect",
 	}
+	if data, err := os.ReadFile(configPath); err == nil {
+		resp["version"] = contentVersion(data)
+		setVersionETag(c, resp["version"].(string))
+	}
 	if pluginRuntimeAction != "" {
 		resp["plugin_runtime_action"] = pluginRuntimeAction
 	}
@@ -1618,7 +1899,7 @@ func (h *SettingsHandler) TestSMTP(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1670,11 +1951,12 @@ func (h *SettingsHandler) TestSMTP(c *gin.Context) {
 // TestSMS 测试SMS配置
 func (h *SettingsHandler) TestSMS(c *gin.Context) {
 	var req struct {
-		Phone string `json:"phone" binding:"required"`
+		Phone     string `json:"phone" binding:"required"`
+		PhoneCode string `json:"phone_code"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1683,16 +1965,53 @@ func (h *SettingsHandler) TestSMS(c *gin.Context) {
 		return
 	}
 
-	if err := h.smsService.TestSMS(req.Phone); err != nil {
+	providerResponse, err := h.smsService.TestSMS(req.Phone, req.PhoneCode)
+	if err != nil {
 		response.InternalError(c, fmt.Sprintf("Failed to send test SMS: %v", err))
 		return
 	}
 
 	response.Success(c, gin.H{
-		"message": "Test SMS sent, please check your phone",
+		"message":           "Test SMS sent, please check your phone",
+		"provider_response": providerResponse,
 	})
 }
 
+// SendTestMessage 使用当前线上配置向指定渠道发送测试消息，并返回服务商原始响应
+func (h *SettingsHandler) SendTestMessage(c *gin.Context) {
+	var req struct {
+		Channel   string `json:"channel" binding:"required"`
+		To        string `json:"to" binding:"required"`
+		PhoneCode string `json:"phone_code"`
+		Secret    string `json:"secret"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	diagnostics := service.NewMessageDiagnosticsService(h.emailService, h.smsService)
+	result, err := diagnostics.Send(service.MessageTestRequest{
+		Channel:   req.Channel,
+		To:        req.To,
+		PhoneCode: req.PhoneCode,
+		Secret:    req.Secret,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	logger.LogOperation(h.db, c, "test_message", "settings", nil, map[string]interface{}{
+		"channel":  result.Channel,
+		"provider": result.Provider,
+		"target":   result.Target,
+		"success":  result.Success,
+	})
+
+	response.Success(c, result)
+}
+
 // GetPageInject 根据页面路径返回匹配的注入脚本和样式
 // 前端通过 path 查询参数传递当前页面路径（穿透CDN），同时回退检查 Referer
 func (h *SettingsHandler) GetPageInject(c *gin.Context) {
@@ -1804,9 +2123,12 @@ func (h *SettingsHandler) GetEmailTemplate(c *gin.Context) {
 		return
 	}
 
+	version := contentVersion(content)
+	setVersionETag(c, version)
 	response.Success(c, gin.H{
 		"filename": filename,
 		"content":  string(content),
+		"version":  version,
 	})
 }
 
@@ -1822,9 +2144,10 @@ func (h *SettingsHandler) UpdateEmailTemplate(c *gin.Context) {
 
 	var req struct {
 		Content string `json:"content" binding:"required"`
+		Version string `json:"version"` // 读取模板时返回的版本，也可通过 If-Match 请求头提交
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, adminIDOK := middleware.RequireUserID(c)
@@ -1873,17 +2196,29 @@ func (h *SettingsHandler) UpdateEmailTemplate(c *gin.Context) {
 
 	tmplPath := filepath.Join(templateDir, filename)
 
-	// 确认文件存在
-	if _, err := os.Stat(tmplPath); os.IsNotExist(err) {
+	// 确认文件存在，并校验是否已被他人修改
+	settingsFileMu.Lock()
+	currentContent, err := os.ReadFile(tmplPath)
+	if err != nil {
+		settingsFileMu.Unlock()
 		response.NotFound(c, "Template not found")
 		return
 	}
+	if expected := requestedVersion(c, req.Version); expected != "" && expected != contentVersion(currentContent) {
+		settingsFileMu.Unlock()
+		respondVersionConflict(c, contentVersion(currentContent))
+		return
+	}
 
 	// 写入文件
-	if err := os.WriteFile(tmplPath, []byte(req.Content), 0644); err != nil {
+	err = os.WriteFile(tmplPath, []byte(req.Content), 0644)
+	settingsFileMu.Unlock()
+	if err != nil {
 		response.InternalError(c, "Failed to save template")
 		return
 	}
+	version := contentVersion([]byte(req.Content))
+	setVersionETag(c, version)
 
 	hotReloaded, reloadWarning := h.reloadEmailTemplates()
 
@@ -1927,6 +2262,7 @@ func (h *SettingsHandler) UpdateEmailTemplate(c *gin.Context) {
 		}(),
 		"hot_reloaded":   hotReloaded,
 		"reload_warning": reloadWarning,
+		"version":        version,
 	})
 }
 
@@ -2175,19 +2511,19 @@ func isPathEqualOrWithin(basePath string, targetPath string) bool {
 	return !strings.HasPrefix(rel, parentPrefix)
 }
 
-// readConfigFile 读取配置文件
-func readConfigFile(path string) (map[string]interface{}, error) {
+// readConfigFile 读取配置文件，同时返回内容摘要作为乐观锁版本
+func readConfigFile(path string) (map[string]interface{}, string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var config map[string]interface{}
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return config, nil
+	return config, contentVersion(data), nil
 }
 
 // writeConfigFile 写入配置文件
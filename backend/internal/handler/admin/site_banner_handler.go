@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SiteBannerHandler struct {
+	bannerService *service.SiteBannerService
+	db            *gorm.DB
+}
+
+func NewSiteBannerHandler(bannerService *service.SiteBannerService, db *gorm.DB) *SiteBannerHandler {
+	return &SiteBannerHandler{bannerService: bannerService, db: db}
+}
+
+// SiteBannerRequest 创建/更新站点横幅请求，时间均为 RFC3339 格式
+type SiteBannerRequest struct {
+	Type                string                              `json:"type" binding:"required"`
+	Audience            string                              `json:"audience"`
+	Translations        map[string]models.SiteBannerContent `json:"translations" binding:"required"`
+	DefaultLocale       string                              `json:"default_locale"`
+	StartsAt            *time.Time                          `json:"starts_at"`
+	EndsAt              *time.Time                          `json:"ends_at"`
+	MaintenanceStartsAt *time.Time                          `json:"maintenance_starts_at"`
+	MaintenanceEndsAt   *time.Time                          `json:"maintenance_ends_at"`
+	Dismissible         bool                                `json:"dismissible"`
+	Priority            int                                 `json:"priority"`
+	IsActive            bool                                `json:"is_active"`
+}
+
+func (r *SiteBannerRequest) toInput() service.SiteBannerInput {
+	return service.SiteBannerInput{
+		Type:                models.SiteBannerType(strings.TrimSpace(r.Type)),
+		Audience:            models.SiteBannerAudience(strings.TrimSpace(r.Audience)),
+		Translations:        r.Translations,
+		DefaultLocale:       r.DefaultLocale,
+		StartsAt:            r.StartsAt,
+		EndsAt:              r.EndsAt,
+		MaintenanceStartsAt: r.MaintenanceStartsAt,
+		MaintenanceEndsAt:   r.MaintenanceEndsAt,
+		Dismissible:         r.Dismissible,
+		Priority:            r.Priority,
+		IsActive:            r.IsActive,
+	}
+}
+
+// ListBanners 站点横幅列表，支持按状态（live/scheduled/ended）与类型筛选
+func (h *SiteBannerHandler) ListBanners(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	banners, total, err := h.bannerService.List(
+		strings.TrimSpace(c.Query("status")),
+		strings.TrimSpace(c.Query("type")),
+		page, limit,
+	)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, banners, page, limit, total)
+}
+
+// GetBanner 站点横幅详情
+func (h *SiteBannerHandler) GetBanner(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid banner ID")
+		return
+	}
+	banner, err := h.bannerService.Get(id)
+	if err != nil {
+		h.respondError(c, err, "Query failed")
+		return
+	}
+	response.Success(c, banner)
+}
+
+// CreateBanner 创建站点横幅
+func (h *SiteBannerHandler) CreateBanner(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req SiteBannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	banner, err := h.bannerService.Create(adminID, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to create banner")
+		return
+	}
+	logger.LogOperation(h.db, c, "create", "site_banner", &banner.ID, map[string]interface{}{
+		"type":      banner.Type,
+		"audience":  banner.Audience,
+		"starts_at": banner.StartsAt,
+		"ends_at":   banner.EndsAt,
+	})
+	response.Success(c, banner)
+}
+
+// UpdateBanner 更新站点横幅
+func (h *SiteBannerHandler) UpdateBanner(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid banner ID")
+		return
+	}
+	var req SiteBannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	banner, err := h.bannerService.Update(id, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to update banner")
+		return
+	}
+	logger.LogOperation(h.db, c, "update", "site_banner", &banner.ID, map[string]interface{}{
+		"type":      banner.Type,
+		"audience":  banner.Audience,
+		"is_active": banner.IsActive,
+		"starts_at": banner.StartsAt,
+		"ends_at":   banner.EndsAt,
+	})
+	response.Success(c, banner)
+}
+
+// DeleteBanner 删除站点横幅
+func (h *SiteBannerHandler) DeleteBanner(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid banner ID")
+		return
+	}
+	if err := h.bannerService.Delete(id); err != nil {
+		h.respondError(c, err, "Failed to delete banner")
+		return
+	}
+	logger.LogOperation(h.db, c, "delete", "site_banner", &id, nil)
+	response.Success(c, nil)
+}
+
+func (h *SiteBannerHandler) respondError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrSiteBannerNotFound) {
+		response.NotFound(c, "Banner not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
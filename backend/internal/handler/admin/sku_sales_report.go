@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	skuSalesDateLayout   = "2006-01-02"
+	skuSalesDefaultDays  = 30
+	skuSalesMaxRangeDays = 366
+)
+
+// parseSKUSalesQuery 解析 SKU 销售报表查询参数，默认最近30天（UTC 日期）
+func parseSKUSalesQuery(c *gin.Context) (service.SKUSalesReportQuery, bool) {
+	today := time.Now().UTC()
+	endDate := today
+	startDate := today.AddDate(0, 0, -(skuSalesDefaultDays - 1))
+
+	if raw := strings.TrimSpace(c.Query("end_date")); raw != "" {
+		parsed, err := time.Parse(skuSalesDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date, expected YYYY-MM-DD")
+			return service.SKUSalesReportQuery{}, false
+		}
+		endDate = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("start_date")); raw != "" {
+		parsed, err := time.Parse(skuSalesDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date, expected YYYY-MM-DD")
+			return service.SKUSalesReportQuery{}, false
+		}
+		startDate = parsed
+	}
+	if startDate.After(endDate) {
+		response.BadRequest(c, "start_date cannot be after end_date")
+		return service.SKUSalesReportQuery{}, false
+	}
+	if endDate.Sub(startDate) > skuSalesMaxRangeDays*24*time.Hour {
+		response.BadRequest(c, fmt.Sprintf("Date range cannot exceed %d days", skuSalesMaxRangeDays))
+		return service.SKUSalesReportQuery{}, false
+	}
+
+	groupBy := strings.ToLower(strings.TrimSpace(c.Query("group_by")))
+	switch groupBy {
+	case "":
+		groupBy = service.SKUSalesGroupBySKU
+	case service.SKUSalesGroupBySKU, service.SKUSalesGroupByDay, service.SKUSalesGroupByMonth:
+	default:
+		response.BadRequest(c, "Invalid group_by, expected sku, day or month")
+		return service.SKUSalesReportQuery{}, false
+	}
+
+	return service.SKUSalesReportQuery{
+		StartDate: startDate.Format(skuSalesDateLayout),
+		EndDate:   endDate.Format(skuSalesDateLayout),
+		SKU:       strings.TrimSpace(c.Query("sku")),
+		GroupBy:   groupBy,
+	}, true
+}
+
+// GetSKUSalesAnalytics 获取 SKU 销售速度与售罄率报表
+func (h *AnalyticsHandler) GetSKUSalesAnalytics(c *gin.Context) {
+	if h.checkDisabled(c) {
+		return
+	}
+	query, ok := parseSKUSalesQuery(c)
+	if !ok {
+		return
+	}
+
+	items, err := service.BuildSKUSalesReport(h.db, query)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+
+	currency := h.cfg.Order.Currency
+	if currency == "" {
+		currency = "CNY"
+	}
+	response.Success(c, gin.H{
+		"start_date": query.StartDate,
+		"end_date":   query.EndDate,
+		"group_by":   query.GroupBy,
+		"currency":   currency,
+		"items":      items,
+	})
+}
+
+// ExportSKUSalesAnalytics 导出 SKU 销售报表（CSV）
+func (h *AnalyticsHandler) ExportSKUSalesAnalytics(c *gin.Context) {
+	if h.checkDisabled(c) {
+		return
+	}
+	query, ok := parseSKUSalesQuery(c)
+	if !ok {
+		return
+	}
+
+	items, err := service.BuildSKUSalesReport(h.db, query)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+	if len(items) > adminCSVExportMaxRows {
+		response.BadRequest(c, fmt.Sprintf("Too many records to export (max %d). Please narrow the filters.", adminCSVExportMaxRows))
+		return
+	}
+
+	headers := []string{
+		"period",
+		"sku",
+		"product_id",
+		"name",
+		"order_count",
+		"units_sold",
+		"revenue",
+		"units_refunded",
+		"refunded_revenue",
+		"refund_rate",
+		"daily_velocity",
+		"ending_stock",
+		"avg_stock",
+		"stock_turn",
+		"sell_through_rate",
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		period := item.Period
+		if period == "" {
+			period = query.StartDate + "~" + query.EndDate
+		}
+		rows = append(rows, []string{
+			period,
+			item.SKU,
+			strconv.FormatUint(uint64(item.ProductID), 10),
+			item.Name,
+			strconv.FormatInt(item.OrderCount, 10),
+			strconv.FormatInt(item.UnitsSold, 10),
+			money.MinorToString(item.RevenueMinor),
+			strconv.FormatInt(item.UnitsRefunded, 10),
+			money.MinorToString(item.RefundedRevenueMinor),
+			strconv.FormatFloat(item.RefundRate, 'f', 2, 64),
+			strconv.FormatFloat(item.DailyVelocity, 'f', 2, 64),
+			strconv.FormatInt(item.EndingStock, 10),
+			strconv.FormatFloat(item.AvgStock, 'f', 2, 64),
+			strconv.FormatFloat(item.StockTurn, 'f', 2, 64),
+			strconv.FormatFloat(item.SellThroughRate, 'f', 2, 64),
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName("sku_sales"), headers, rows)
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SpendingControlHandler struct {
+	spendingControlService *service.SpendingControlService
+	db                     *gorm.DB
+}
+
+func NewSpendingControlHandler(spendingControlService *service.SpendingControlService, db *gorm.DB) *SpendingControlHandler {
+	return &SpendingControlHandler{spendingControlService: spendingControlService, db: db}
+}
+
+// UpdateSpendingControlRequest 管理员修改用户消费控制请求，立即生效
+type UpdateSpendingControlRequest struct {
+	MonthlyLimitMinor int64    `json:"monthly_limit_minor"`
+	BlockedCategories []string `json:"blocked_categories"`
+	OwnerEmail        string   `json:"owner_email"`
+}
+
+func respondSpendingControlError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrSpendingControlUserNotFound) {
+		response.NotFound(c, "User not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// GetUserSpendingControl 用户的消费控制
+func (h *SpendingControlHandler) GetUserSpendingControl(c *gin.Context) {
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	overview, err := h.spendingControlService.AdminGet(userID)
+	if err != nil {
+		respondSpendingControlError(c, err, "Failed to get spending controls")
+		return
+	}
+	response.Success(c, overview)
+}
+
+// UpdateUserSpendingControl 修改用户的消费控制，不受冷静期限制
+func (h *SpendingControlHandler) UpdateUserSpendingControl(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	var req UpdateSpendingControlRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, err := h.spendingControlService.AdminUpdate(userID, adminID, models.SpendingControlTerms{
+		MonthlyLimit:      req.MonthlyLimitMinor,
+		BlockedCategories: req.BlockedCategories,
+		OwnerEmail:        req.OwnerEmail,
+	})
+	if err != nil {
+		respondSpendingControlError(c, err, "Failed to update spending controls")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_spending_control", "user", &userID, map[string]interface{}{
+		"monthly_limit_minor": overview.Control.MonthlyLimit,
+		"blocked_categories":  overview.Control.BlockedCategories,
+		"owner_email":         overview.Control.OwnerEmail,
+	})
+	response.Success(c, overview)
+}
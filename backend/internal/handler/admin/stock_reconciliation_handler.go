@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StockReconciliationHandler struct {
+	reconciliationService *service.StockReconciliationService
+	db                    *gorm.DB
+}
+
+func NewStockReconciliationHandler(reconciliationService *service.StockReconciliationService, db *gorm.DB) *StockReconciliationHandler {
+	return &StockReconciliationHandler{reconciliationService: reconciliationService, db: db}
+}
+
+// RunReconciliationRequest 手动对账请求
+type RunReconciliationRequest struct {
+	AutoHeal bool `json:"auto_heal"`
+}
+
+// ListRuns 库存对账记录列表
+func (h *StockReconciliationHandler) ListRuns(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	runs, total, err := h.reconciliationService.ListRuns(page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get reconciliation runs")
+		return
+	}
+	response.Paginated(c, runs, page, limit, total)
+}
+
+// GetRun 库存对账详情（含差异明细）
+func (h *StockReconciliationHandler) GetRun(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid run ID")
+		return
+	}
+	run, err := h.reconciliationService.GetRun(id)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to get reconciliation run")
+		return
+	}
+	response.Success(c, run)
+}
+
+// TriggerRun 立即执行一次库存对账，auto_heal 为 true 时同时修正计数
+func (h *StockReconciliationHandler) TriggerRun(c *gin.Context) {
+	var req RunReconciliationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BindingError(c, err)
+			return
+		}
+	}
+
+	run, err := h.reconciliationService.Run(models.StockReconciliationTriggerManual, getOptionalUserID(c), req.AutoHeal)
+	// 对账过程出错时仍会保存 failed 记录并返回，便于查看错误原因
+	if err != nil && run == nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to run stock reconciliation")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "stock_reconciliation_trigger", "stock_reconciliation", &run.ID, map[string]interface{}{
+		"auto_heal":         req.AutoHeal,
+		"status":            run.Status,
+		"discrepancy_count": run.DiscrepancyCount,
+		"healed_count":      run.HealedCount,
+	})
+	response.Success(c, run)
+}
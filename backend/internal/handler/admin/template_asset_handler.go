@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TemplateAssetHandler 账单、报价单与邮件模板使用的 Logo、字体与样式资源
+type TemplateAssetHandler struct {
+	assetService *service.TemplateAssetService
+	db           *gorm.DB
+}
+
+func NewTemplateAssetHandler(assetService *service.TemplateAssetService, db *gorm.DB) *TemplateAssetHandler {
+	return &TemplateAssetHandler{assetService: assetService, db: db}
+}
+
+// ListAssets 资源列表
+func (h *TemplateAssetHandler) ListAssets(c *gin.Context) {
+	assets, err := h.assetService.List()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, assets)
+}
+
+// UploadAsset 上传资源，同名资源被替换且版本参数随之变化
+func (h *TemplateAssetHandler) UploadAsset(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "Please select a file to upload")
+		return
+	}
+	name := c.PostForm("name")
+	if strings.TrimSpace(name) == "" {
+		// 未指定名称时使用去掉扩展名的文件名
+		name = strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		response.InternalError(c, "Failed to read file")
+		return
+	}
+	defer src.Close()
+	content, err := io.ReadAll(io.LimitReader(src, service.TemplateAssetMaxSize+1))
+	if err != nil {
+		response.InternalError(c, "Failed to read file")
+		return
+	}
+
+	asset, err := h.assetService.Save(name, file.Filename, content, getOptionalUserID(c))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to save template asset")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "upload", "template_asset", &asset.ID, map[string]interface{}{
+		"name":     asset.Name,
+		"kind":     asset.Kind,
+		"size":     asset.Size,
+		"checksum": asset.Checksum,
+	})
+	response.Success(c, asset)
+}
+
+// DeleteAsset 删除资源
+func (h *TemplateAssetHandler) DeleteAsset(c *gin.Context) {
+	asset, err := h.assetService.Delete(c.Param("name"))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to delete template asset")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "delete", "template_asset", &asset.ID, map[string]interface{}{
+		"name": asset.Name,
+	})
+	response.Success(c, gin.H{"name": asset.Name})
+}
+
+// ServeAsset 公开访问 /uploads/templates/:name；带当前版本参数的请求可长期缓存，其余请求短期缓存以便替换后尽快生效
+func (h *TemplateAssetHandler) ServeAsset(c *gin.Context) {
+	asset, err := h.assetService.Get(c.Param("name"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	version := h.assetService.Version(asset)
+	if c.Query("v") == version {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "public, max-age=300")
+	}
+	c.Header("ETag", `"`+asset.Checksum+`"`)
+	c.Header("Content-Type", asset.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	switch {
+	case asset.Kind == models.TemplateAssetKindFont:
+		// 邮件客户端与 PDF 渲染器跨域加载字体
+		c.Header("Access-Control-Allow-Origin", "*")
+	case strings.HasPrefix(asset.ContentType, "image/svg"):
+		c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	}
+	c.File(h.assetService.FilePath(asset))
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	agentPerformanceDateLayout   = "2006-01-02"
+	agentPerformanceDefaultDays  = 30
+	agentPerformanceMaxRangeDays = 366
+)
+
+// parseAgentPerformanceQuery 解析报表查询参数，默认最近30天（UTC 日期）
+func parseAgentPerformanceQuery(c *gin.Context) (service.TicketAgentPerformanceQuery, bool) {
+	today := time.Now().UTC()
+	endDate := today
+	startDate := today.AddDate(0, 0, -(agentPerformanceDefaultDays - 1))
+
+	if raw := strings.TrimSpace(c.Query("end_date")); raw != "" {
+		parsed, err := time.Parse(agentPerformanceDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date, expected YYYY-MM-DD")
+			return service.TicketAgentPerformanceQuery{}, false
+		}
+		endDate = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("start_date")); raw != "" {
+		parsed, err := time.Parse(agentPerformanceDateLayout, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date, expected YYYY-MM-DD")
+			return service.TicketAgentPerformanceQuery{}, false
+		}
+		startDate = parsed
+	}
+	if startDate.After(endDate) {
+		response.BadRequest(c, "start_date cannot be after end_date")
+		return service.TicketAgentPerformanceQuery{}, false
+	}
+	if endDate.Sub(startDate) > agentPerformanceMaxRangeDays*24*time.Hour {
+		response.BadRequest(c, fmt.Sprintf("Date range cannot exceed %d days", agentPerformanceMaxRangeDays))
+		return service.TicketAgentPerformanceQuery{}, false
+	}
+
+	query := service.TicketAgentPerformanceQuery{
+		StartDate:  startDate.Format(agentPerformanceDateLayout),
+		EndDate:    endDate.Format(agentPerformanceDateLayout),
+		GroupByDay: strings.EqualFold(strings.TrimSpace(c.Query("group_by")), "day"),
+	}
+	if raw := strings.TrimSpace(c.Query("agent_id")); raw != "" {
+		agentID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid agent_id")
+			return service.TicketAgentPerformanceQuery{}, false
+		}
+		query.AgentID = uint(agentID)
+	}
+	return query, true
+}
+
+// GetAgentPerformance 获取客服绩效报表
+func (h *TicketHandler) GetAgentPerformance(c *gin.Context) {
+	query, ok := parseAgentPerformanceQuery(c)
+	if !ok {
+		return
+	}
+
+	items, err := service.BuildTicketAgentPerformance(h.db, query)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+
+	response.Success(c, gin.H{
+		"start_date": query.StartDate,
+		"end_date":   query.EndDate,
+		"group_by":   agentPerformanceGroupBy(query),
+		"items":      items,
+	})
+}
+
+// ExportAgentPerformance 导出客服绩效报表（CSV）
+func (h *TicketHandler) ExportAgentPerformance(c *gin.Context) {
+	query, ok := parseAgentPerformanceQuery(c)
+	if !ok {
+		return
+	}
+
+	items, err := service.BuildTicketAgentPerformance(h.db, query)
+	if err != nil {
+		response.InternalError(c, "Export failed")
+		return
+	}
+	if len(items) > adminCSVExportMaxRows {
+		response.BadRequest(c, fmt.Sprintf("Too many records to export (max %d). Please narrow the filters.", adminCSVExportMaxRows))
+		return
+	}
+
+	headers := []string{
+		"stat_date",
+		"agent_id",
+		"agent_name",
+		"agent_email",
+		"tickets_handled",
+		"first_response_count",
+		"avg_first_response_seconds",
+		"resolved_count",
+		"avg_resolution_seconds",
+		"rating_count",
+		"avg_rating",
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		statDate := item.StatDate
+		if statDate == "" {
+			statDate = query.StartDate + "~" + query.EndDate
+		}
+		rows = append(rows, []string{
+			statDate,
+			strconv.FormatUint(uint64(item.AgentID), 10),
+			item.AgentName,
+			item.AgentEmail,
+			strconv.FormatInt(item.TicketsHandled, 10),
+			strconv.FormatInt(item.FirstResponseCount, 10),
+			strconv.FormatInt(item.AvgFirstResponseSeconds, 10),
+			strconv.FormatInt(item.ResolvedCount, 10),
+			strconv.FormatInt(item.AvgResolutionSeconds, 10),
+			strconv.FormatInt(item.RatingCount, 10),
+			strconv.FormatFloat(item.AvgRating, 'f', 2, 64),
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName("agent_performance"), headers, rows)
+}
+
+func agentPerformanceGroupBy(query service.TicketAgentPerformanceQuery) string {
+	if query.GroupByDay {
+		return "day"
+	}
+	return "agent"
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"mime/multipart"
@@ -14,6 +15,8 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/pkg/ticketbiz"
 	"auralogic/internal/pkg/utils"
@@ -34,6 +37,11 @@ func NewTicketHandler(db *gorm.DB, emailService *service.EmailService, pluginMan
 	return &TicketHandler{db: db, emailService: emailService, pluginManager: pluginManager}
 }
 
+// attachmentService 工单消息附件（对象存储），按当前配置选择存储后端
+func (h *TicketHandler) attachmentService() *service.TicketAttachmentService {
+	return service.NewTicketAttachmentService(h.db, config.GetConfig())
+}
+
 // ListTickets 获取工单列表
 func (h *TicketHandler) ListTickets(c *gin.Context) {
 	page, limit := response.GetPagination(c)
@@ -160,6 +168,10 @@ func (h *TicketHandler) GetTicketMessages(c *gin.Context) {
 		response.InternalError(c, "Query failed")
 		return
 	}
+	if err := h.attachmentService().LoadForMessages(messages); err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
 
 	var ticket models.Ticket
 	_ = h.db.Select("id", "ticket_no", "status").First(&ticket, ticketID).Error
@@ -380,8 +392,9 @@ func applyTicketAttachmentUploadHookPayload(file *multipart.FileHeader, payload
 
 // SendMessageRequest 发送消息请求
 type AdminSendMessageRequest struct {
-	Content     string `json:"content" binding:"required"`
-	ContentType string `json:"content_type"`
+	Content       string `json:"content"`
+	ContentType   string `json:"content_type"`
+	AttachmentIDs []uint `json:"attachment_ids"` // 通过 /tickets/:id/attachments 预先上传的附件
 }
 
 // SendMessage 管理员发送消息
@@ -398,7 +411,7 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	var req AdminSendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -464,6 +477,18 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 		respondAdminBizError(c, ticketbiz.ContentTooLong(cfg.Ticket.MaxContentLength))
 		return
 	}
+	if strings.TrimSpace(sanitizedContent) == "" && len(req.AttachmentIDs) == 0 {
+		respondAdminBizError(c, ticketbiz.MessageEmpty())
+		return
+	}
+	attachmentService := h.attachmentService()
+	attachments, err := attachmentService.ResolvePending(h.db, ticket.ID, "admin", adminID, req.AttachmentIDs)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Send failed")
+		}
+		return
+	}
 
 	message := &models.TicketMessage{
 		TicketID:      uint(ticketID),
@@ -476,16 +501,24 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 		IsReadByAdmin: true,
 	}
 
-	if err := h.db.Create(message).Error; err != nil {
-		response.InternalError(c, "Send failed")
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return attachmentService.LinkToMessage(tx, message, attachments)
+	}); err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Send failed")
+		}
 		return
 	}
+	preview := service.TicketMessagePreview(sanitizedContent, attachments)
 
 	// 更新工单信息
 	now := time.Now()
 	updates := map[string]interface{}{
 		"last_message_at":      now,
-		"last_message_preview": truncateString(sanitizedContent, 200),
+		"last_message_preview": truncateString(preview, 200),
 		"last_message_by":      "admin",
 		"unread_count_user":    gorm.Expr("unread_count_user + 1"),
 	}
@@ -502,6 +535,16 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	h.db.Model(&ticket).Updates(updates)
 
+	// 记录首次响应（用于客服绩效统计），条件更新避免并发回复覆盖
+	if ticket.FirstResponseAt == nil {
+		h.db.Model(&models.Ticket{}).
+			Where("id = ? AND first_response_at IS NULL", ticket.ID).
+			Updates(map[string]interface{}{
+				"first_response_at": now,
+				"first_response_by": adminID,
+			})
+	}
+
 	if h.pluginManager != nil {
 		afterStatus := ticket.Status
 		if statusRaw, exists := updates["status"]; exists {
@@ -510,15 +553,16 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 			}
 		}
 		afterPayload := map[string]interface{}{
-			"ticket_id":    ticket.ID,
-			"ticket_no":    ticket.TicketNo,
-			"message_id":   message.ID,
-			"admin_id":     adminID,
-			"content":      sanitizedContent,
-			"content_type": contentType,
-			"status":       afterStatus,
-			"source":       "admin_api",
-			"created_at":   message.CreatedAt.Format(time.RFC3339),
+			"ticket_id":        ticket.ID,
+			"ticket_no":        ticket.TicketNo,
+			"message_id":       message.ID,
+			"admin_id":         adminID,
+			"content":          sanitizedContent,
+			"content_type":     contentType,
+			"attachment_count": len(attachments),
+			"status":           afterStatus,
+			"source":           "admin_api",
+			"created_at":       message.CreatedAt.Format(time.RFC3339),
 		}
 		go func(execCtx *service.ExecutionContext, payload map[string]interface{}, aid uint, tid uint) {
 			_, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
@@ -535,10 +579,69 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	// 发送管理员回复通知邮件（通知用户）
 	if h.emailService != nil {
-		go h.emailService.SendTicketAdminReplyEmail(&ticket, admin.Name, truncateString(sanitizedContent, 200))
+		go h.emailService.SendTicketAdminReplyEmail(&ticket, admin.Name, truncateString(preview, 200))
 	}
 }
 
+// UploadAttachment 管理员上传消息附件，随后在发送消息时通过 attachment_ids 关联
+func (h *TicketHandler) UploadAttachment(c *gin.Context) {
+	adminID, adminIDOK := middleware.RequireUserID(c)
+	if !adminIDOK {
+		return
+	}
+	ticketID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+
+	var ticket models.Ticket
+	if err := h.db.First(&ticket, ticketID).Error; err != nil {
+		response.NotFound(c, "Ticket not found")
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		respondAdminBizError(c, ticketbiz.FileRequired())
+		return
+	}
+	attachment, err := h.attachmentService().Upload(c.Request.Context(), &ticket, "admin", adminID, file)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			log.Printf("ticket attachment upload failed: admin=%d ticket=%d err=%v", adminID, ticket.ID, err)
+			response.InternalError(c, "Failed to save file")
+		}
+		return
+	}
+	response.Success(c, attachment)
+}
+
+// DeleteTicket 删除工单（移入回收站），保留期满后连同消息与附件文件彻底删除
+func (h *TicketHandler) DeleteTicket(c *gin.Context) {
+	adminID, adminIDOK := middleware.RequireUserID(c)
+	if !adminIDOK {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+
+	if err := dbutil.SoftDelete(h.db, &models.Ticket{}, id, adminID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.NotFound(c, "Ticket not found")
+			return
+		}
+		response.InternalError(c, "Delete failed")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "delete", "ticket", &id, nil)
+	response.Success(c, gin.H{"message": "Ticket moved to trash"})
+}
+
 // UpdateTicketRequest 更新工单请求
 type UpdateTicketRequest struct {
 	Status     string `json:"status"`
@@ -560,7 +663,7 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 
 	var req UpdateTicketRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -751,11 +854,19 @@ func (h *TicketHandler) GetSharedOrders(c *gin.Context) {
 		return
 	}
 
+	// 已过期的分享不再展示
 	var accesses []models.TicketOrderAccess
-	if err := h.db.Preload("Order").Where("ticket_id = ?", ticketID).Find(&accesses).Error; err != nil {
+	if err := h.db.Preload("Order").
+		Where("ticket_id = ? AND (expires_at IS NULL OR expires_at > ?)", ticketID, time.Now()).
+		Find(&accesses).Error; err != nil {
 		response.InternalError(c, "Query failed")
 		return
 	}
+	for i := range accesses {
+		if accesses[i].Order != nil && !accesses[i].CanViewPrivacy {
+			accesses[i].Order.MaskReceiverInfo()
+		}
+	}
 
 	response.Success(c, accesses)
 }
@@ -792,8 +903,10 @@ func (h *TicketHandler) GetSharedOrder(c *gin.Context) {
 		return
 	}
 
-	// 用户主动分享订单到工单即视为同意客服查看所有信息，不再隐藏隐私信息
-	// 客服可以查看完整的订单详情，包括地址等
+	// 用户分享订单时可选择是否允许客服查看收货信息，未授权时隐藏收货人姓名、联系方式和详细地址
+	if !access.CanViewPrivacy {
+		order.MaskReceiverInfo()
+	}
 
 	response.Success(c, gin.H{
 		"order":  order,
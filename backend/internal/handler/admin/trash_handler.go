@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	db           *gorm.DB
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(db *gorm.DB, trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{db: db, trashService: trashService}
+}
+
+// ListTrash 回收站列表，type 为 product / promo_code / virtual_inventory
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	entityType := strings.TrimSpace(c.DefaultQuery("type", service.TrashEntityProduct))
+
+	items, total, err := h.trashService.List(entityType, page, limit)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, items, page, limit, total)
+}
+
+// RestoreTrashItem 从回收站恢复
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	entityType := strings.TrimSpace(c.Param("type"))
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid ID")
+		return
+	}
+
+	if err := h.trashService.Restore(entityType, id); err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to restore item")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "restore", entityType, &id, nil)
+	response.Success(c, gin.H{"entity_type": entityType, "id": id})
+}
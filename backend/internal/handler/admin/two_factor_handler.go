@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *service.TwoFactorService
+	db               *gorm.DB
+}
+
+func NewTwoFactorHandler(twoFactorService *service.TwoFactorService, db *gorm.DB) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService, db: db}
+}
+
+// UpdateUserTwoFactorRequest 强制开启两步验证请求
+type UpdateUserTwoFactorRequest struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+func respondTwoFactorError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrTwoFactorUserNotFound) {
+		response.NotFound(c, "User not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// GetUserTwoFactor 用户的两步验证状态
+func (h *TwoFactorHandler) GetUserTwoFactor(c *gin.Context) {
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	status, err := h.twoFactorService.Status(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to get two-factor status")
+		return
+	}
+	response.Success(c, status)
+}
+
+// UpdateUserTwoFactor 设置是否强制用户开启两步验证
+func (h *TwoFactorHandler) UpdateUserTwoFactor(c *gin.Context) {
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	var req UpdateUserTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if err := h.twoFactorService.SetRequired(userID, *req.Required); err != nil {
+		respondTwoFactorError(c, err, "Failed to update two-factor requirement")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_user_two_factor", "user", &userID, map[string]interface{}{
+		"required": *req.Required,
+	})
+	status, err := h.twoFactorService.Status(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to get two-factor status")
+		return
+	}
+	response.Success(c, status)
+}
+
+// ResetUserTwoFactor 重置用户的两步验证，用户丢失验证器与备用码时使用
+func (h *TwoFactorHandler) ResetUserTwoFactor(c *gin.Context) {
+	userID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+	if err := h.twoFactorService.AdminReset(userID); err != nil {
+		respondTwoFactorError(c, err, "Failed to reset two-factor authentication")
+		return
+	}
+	logger.LogOperation(h.db, c, "reset_user_two_factor", "user", &userID, nil)
+	status, err := h.twoFactorService.Status(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to get two-factor status")
+		return
+	}
+	response.Success(c, status)
+}
@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UsageMeterHandler 卡密用量计量：商户软件凭 API Key 上报用量，管理端查看计量与上报记录
+type UsageMeterHandler struct {
+	usageService *service.UsageMeterService
+}
+
+func NewUsageMeterHandler(usageService *service.UsageMeterService) *UsageMeterHandler {
+	return &UsageMeterHandler{usageService: usageService}
+}
+
+// ReportUsageRequest 用量上报请求
+type ReportUsageRequest struct {
+	LicenseKey     string `json:"license_key" binding:"required"`
+	Quantity       int64  `json:"quantity" binding:"required"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Note           string `json:"note"`
+}
+
+// ReportUsage 上报一次用量变化，返回最新余额
+func (h *UsageMeterHandler) ReportUsage(c *gin.Context) {
+	var req ReportUsageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	report := service.UsageReport{
+		LicenseKey:     req.LicenseKey,
+		Quantity:       req.Quantity,
+		IdempotencyKey: req.IdempotencyKey,
+		Note:           req.Note,
+		IPAddress:      utils.GetRealIP(c),
+	}
+	if value, exists := c.Get("api_key_id"); exists {
+		if apiKeyID, ok := value.(uint); ok {
+			report.APIKeyID = &apiKeyID
+		}
+	}
+	balance, err := h.usageService.ReportUsage(report)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to report usage")
+		}
+		return
+	}
+	response.Success(c, balance)
+}
+
+// GetBalance 按卡密查询计量余额
+func (h *UsageMeterHandler) GetBalance(c *gin.Context) {
+	balance, err := h.usageService.GetBalance(c.Query("license_key"))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	response.Success(c, balance)
+}
+
+// ListMeterRecords 计量的上报记录
+func (h *UsageMeterHandler) ListMeterRecords(c *gin.Context) {
+	meterID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid meter ID")
+		return
+	}
+	page, limit := response.GetPagination(c)
+	records, total, err := h.usageService.ListMeterRecords(meterID, page, limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.NotFound(c, "Usage meter not found")
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, records, page, limit, total)
+}
+
+// ListOrderMeters 订单内卡密的用量计量
+func (h *UsageMeterHandler) ListOrderMeters(c *gin.Context) {
+	orderID, ok := parseAdminOrderID(c)
+	if !ok {
+		return
+	}
+	meters, err := h.usageService.ListOrderMeters(orderID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": meters})
+}
@@ -8,6 +8,7 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/password"
 	"auralogic/internal/pkg/response"
@@ -67,23 +68,25 @@ func (h *UserHandler) enrichUserConsumptionStats(user *models.User) {
 // userToResponse converts a User model to a safe response map with explicit fields
 func userToResponse(user *models.User) gin.H {
 	resp := gin.H{
-		"id":                user.ID,
-		"uuid":              user.UUID,
-		"email":             user.Email,
-		"name":              user.Name,
-		"avatar":            user.Avatar,
-		"role":              user.Role,
-		"is_active":         user.IsActive,
-		"email_verified":    user.EmailVerified,
-		"locale":            user.Locale,
-		"last_login_ip":     user.LastLoginIP,
-		"register_ip":       user.RegisterIP,
-		"country":           user.Country,
-		"last_login_at":     user.LastLoginAt,
-		"total_spent_minor": user.TotalSpentMinor,
-		"total_order_count": user.TotalOrderCount,
-		"created_at":        user.CreatedAt,
-		"updated_at":        user.UpdatedAt,
+		"id":                        user.ID,
+		"uuid":                      user.UUID,
+		"email":                     user.Email,
+		"name":                      user.Name,
+		"avatar":                    user.Avatar,
+		"role":                      user.Role,
+		"is_active":                 user.IsActive,
+		"email_verified":            user.EmailVerified,
+		"email_verification_exempt": user.EmailVerificationExempt,
+		"email_risk_flag":           user.EmailRiskFlag,
+		"locale":                    user.Locale,
+		"last_login_ip":             user.LastLoginIP,
+		"register_ip":               user.RegisterIP,
+		"country":                   user.Country,
+		"last_login_at":             user.LastLoginAt,
+		"total_spent_minor":         user.TotalSpentMinor,
+		"total_order_count":         user.TotalOrderCount,
+		"created_at":                user.CreatedAt,
+		"updated_at":                user.UpdatedAt,
 	}
 	if user.Phone != nil {
 		resp["phone"] = user.Phone
@@ -120,6 +123,10 @@ func parseUserListFilters(c *gin.Context) (repository.UserListFilters, string, b
 	if !ok {
 		return filters, "Invalid has_phone parameter", false
 	}
+	filters.EmailFlagged, ok = parseOptionalBoolQuery(c.Query("email_flagged"))
+	if !ok {
+		return filters, "Invalid email_flagged parameter", false
+	}
 
 	return filters, "", true
 }
@@ -150,7 +157,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
@@ -479,11 +486,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 	user, err := h.userRepo.FindByID(uint(userID))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			response.NotFound(c, "User not found")
-			return
-		}
-		response.InternalError(c, "Query failed")
+		response.HandleError(c, "Query failed", bizerr.FromStore(err, errAdminUserNotFound))
 		return
 	}
 	h.enrichUserConsumptionStats(user)
@@ -504,17 +507,19 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		Role     string  `json:"role"`
 		IsActive *bool   `json:"is_active"`
 		Password *string `json:"password" binding:"omitempty,min=8"`
+		// 豁免邮箱验证限制，仅管理员可设置
+		EmailVerificationExempt *bool `json:"email_verification_exempt"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	adminID, _ := middleware.GetUserID(c)
 
 	user, err := h.userRepo.FindByID(uint(userID))
 	if err != nil {
-		response.NotFound(c, "User not found")
+		response.HandleError(c, "Query failed", bizerr.FromStore(err, errAdminUserNotFound))
 		return
 	}
 
@@ -626,6 +631,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	if req.IsActive != nil {
 		user.IsActive = *req.IsActive
 	}
+	if req.EmailVerificationExempt != nil {
+		user.EmailVerificationExempt = *req.EmailVerificationExempt
+	}
 
 	if err := h.userRepo.Update(user); err != nil {
 		response.InternalError(c, "UpdateFailed")
@@ -643,6 +651,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		"role":      req.Role,
 		"is_active": req.IsActive,
 	}
+	if req.EmailVerificationExempt != nil {
+		details["email_verification_exempt"] = *req.EmailVerificationExempt
+	}
 	if passwordChanged {
 		// Never log plaintext password.
 		details["password_changed"] = true
@@ -695,11 +706,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	user, err := h.userRepo.FindByID(uint(userID))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			response.NotFound(c, "User not found")
-			return
-		}
-		response.InternalError(c, "Query failed")
+		response.HandleError(c, "Query failed", bizerr.FromStore(err, errAdminUserNotFound))
 		return
 	}
 
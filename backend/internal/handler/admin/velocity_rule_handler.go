@@ -0,0 +1,220 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type VelocityRuleHandler struct {
+	velocityService *service.VelocityRuleService
+	orderService    *service.OrderService
+	db              *gorm.DB
+}
+
+func NewVelocityRuleHandler(velocityService *service.VelocityRuleService, orderService *service.OrderService, db *gorm.DB) *VelocityRuleHandler {
+	return &VelocityRuleHandler{velocityService: velocityService, orderService: orderService, db: db}
+}
+
+// VelocityRuleRequest 创建/更新频率规则请求
+type VelocityRuleRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Metric        string `json:"metric" binding:"required"`
+	Threshold     int    `json:"threshold"`
+	WindowMinutes int    `json:"window_minutes"`
+	Action        string `json:"action" binding:"required"`
+	IsActive      bool   `json:"is_active"`
+}
+
+func (r *VelocityRuleRequest) toInput() service.VelocityRuleInput {
+	return service.VelocityRuleInput{
+		Name:          r.Name,
+		Metric:        models.VelocityMetric(strings.TrimSpace(r.Metric)),
+		Threshold:     r.Threshold,
+		WindowMinutes: r.WindowMinutes,
+		Action:        models.VelocityAction(strings.TrimSpace(r.Action)),
+		IsActive:      r.IsActive,
+	}
+}
+
+// ReviewOrderRiskRequest 风控审核请求，decision 为 approve（解除暂扣）或 reject（取消未付款订单）
+type ReviewOrderRiskRequest struct {
+	Decision string `json:"decision" binding:"required"`
+	Note     string `json:"note"`
+}
+
+func (h *VelocityRuleHandler) respondError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrVelocityRuleNotFound) {
+		response.NotFound(c, "Rule not found")
+		return
+	}
+	if errors.Is(err, service.ErrOrderRiskReviewNotFound) {
+		response.NotFound(c, "Review not found")
+		return
+	}
+	if !respondAdminBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// ListReviews 风控审核队列，支持按状态与处理方式筛选
+func (h *VelocityRuleHandler) ListReviews(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	reviews, total, err := h.velocityService.ListReviews(
+		strings.TrimSpace(c.Query("status")),
+		strings.TrimSpace(c.Query("action")),
+		page, limit,
+	)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, reviews, page, limit, total)
+}
+
+// GetPendingCount 待审核订单数量
+func (h *VelocityRuleHandler) GetPendingCount(c *gin.Context) {
+	count, err := h.velocityService.PendingCount()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"pending_count": count})
+}
+
+// ReviewOrder 人工审核：通过时解除暂扣；拒绝时取消仍未付款的订单，已付款订单保持暂扣，由管理员另行退款
+func (h *VelocityRuleHandler) ReviewOrder(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid review ID")
+		return
+	}
+	var req ReviewOrderRiskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	decision := models.OrderRiskReviewStatus(strings.TrimSpace(req.Decision))
+	switch decision {
+	case "approve":
+		decision = models.OrderRiskReviewStatusApproved
+	case "reject":
+		decision = models.OrderRiskReviewStatusRejected
+	}
+
+	review, err := h.velocityService.Review(id, adminID, decision, req.Note)
+	if err != nil {
+		h.respondError(c, err, "Failed to review order")
+		return
+	}
+
+	cancelled := false
+	if review.Status == models.OrderRiskReviewStatusRejected {
+		order, err := h.orderService.GetOrderByID(review.OrderID)
+		if err == nil && (order.Status == models.OrderStatusPendingPayment || order.Status == models.OrderStatusDraft) {
+			if err := h.orderService.CancelOrder(order.ID, "Risk review rejected"); err != nil {
+				log.Printf("velocity: failed to cancel rejected order %s: %v", order.OrderNo, err)
+			} else {
+				cancelled = true
+			}
+		}
+	}
+
+	logger.LogOperation(h.db, c, "review", "order_risk_review", &review.ID, map[string]interface{}{
+		"order_id":  review.OrderID,
+		"order_no":  review.OrderNo,
+		"decision":  review.Status,
+		"cancelled": cancelled,
+	})
+	response.Success(c, gin.H{"review": review, "order_cancelled": cancelled})
+}
+
+// ListRules 频率规则列表
+func (h *VelocityRuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.velocityService.ListRules()
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": rules})
+}
+
+// CreateRule 创建频率规则
+func (h *VelocityRuleHandler) CreateRule(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req VelocityRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	rule, err := h.velocityService.CreateRule(adminID, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to create rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "create", "velocity_rule", &rule.ID, map[string]interface{}{
+		"metric":         rule.Metric,
+		"threshold":      rule.Threshold,
+		"window_minutes": rule.WindowMinutes,
+		"action":         rule.Action,
+	})
+	response.Success(c, rule)
+}
+
+// UpdateRule 更新频率规则
+func (h *VelocityRuleHandler) UpdateRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	var req VelocityRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	rule, err := h.velocityService.UpdateRule(id, req.toInput())
+	if err != nil {
+		h.respondError(c, err, "Failed to update rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "update", "velocity_rule", &rule.ID, map[string]interface{}{
+		"metric":         rule.Metric,
+		"threshold":      rule.Threshold,
+		"window_minutes": rule.WindowMinutes,
+		"action":         rule.Action,
+		"is_active":      rule.IsActive,
+	})
+	response.Success(c, rule)
+}
+
+// DeleteRule 删除频率规则
+func (h *VelocityRuleHandler) DeleteRule(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid rule ID")
+		return
+	}
+	if err := h.velocityService.DeleteRule(id); err != nil {
+		h.respondError(c, err, "Failed to delete rule")
+		return
+	}
+	logger.LogOperation(h.db, c, "delete", "velocity_rule", &id, nil)
+	response.Success(c, nil)
+}
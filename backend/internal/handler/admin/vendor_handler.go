@@ -0,0 +1,354 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const vendorStatementDateFormat = "2006-01-02"
+
+type VendorHandler struct {
+	vendorService *service.VendorSettlementService
+	db            *gorm.DB
+}
+
+func NewVendorHandler(vendorService *service.VendorSettlementService, db *gorm.DB) *VendorHandler {
+	return &VendorHandler{vendorService: vendorService, db: db}
+}
+
+// VendorRequest 创建/更新商家请求
+type VendorRequest struct {
+	Name           string              `json:"name"`
+	ContactEmail   string              `json:"contact_email"`
+	CommissionRate int64               `json:"commission_rate"` // 基点，10000 = 100%
+	PayoutAccount  string              `json:"payout_account"`
+	Status         models.VendorStatus `json:"status"`
+	Remark         string              `json:"remark"`
+}
+
+func (r VendorRequest) toInput() service.VendorInput {
+	return service.VendorInput{
+		Name:           r.Name,
+		ContactEmail:   r.ContactEmail,
+		CommissionRate: r.CommissionRate,
+		PayoutAccount:  r.PayoutAccount,
+		Status:         r.Status,
+		Remark:         r.Remark,
+	}
+}
+
+// VendorAdjustmentRequest 商家台账手动调整请求
+type VendorAdjustmentRequest struct {
+	Currency    string `json:"currency"`
+	AmountMinor int64  `json:"amount_minor"`
+	Note        string `json:"note"`
+}
+
+// GenerateVendorStatementsRequest 生成结算单请求，日期格式 YYYY-MM-DD，period_end 当天不包含在内
+type GenerateVendorStatementsRequest struct {
+	PeriodStart string `json:"period_start" binding:"required"`
+	PeriodEnd   string `json:"period_end" binding:"required"`
+}
+
+// MarkVendorStatementPaidRequest 标记结算单已打款请求
+type MarkVendorStatementPaidRequest struct {
+	Reference string `json:"reference"`
+}
+
+// AssignProductVendorRequest 设置商品所属商家请求，vendor_id 为空表示平台自营
+type AssignProductVendorRequest struct {
+	VendorID *uint `json:"vendor_id"`
+}
+
+func respondVendorError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrVendorNotFound):
+		response.NotFound(c, "Vendor not found")
+	case errors.Is(err, service.ErrVendorStatementNotFound):
+		response.NotFound(c, "Statement not found")
+	case errors.Is(err, service.ErrProductNotFound):
+		response.NotFound(c, "Product not found")
+	default:
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// ListVendors 商家列表
+func (h *VendorHandler) ListVendors(c *gin.Context) {
+	vendors, err := h.vendorService.ListVendors()
+	if err != nil {
+		response.InternalError(c, "Failed to get vendors")
+		return
+	}
+	response.Success(c, gin.H{"items": vendors})
+}
+
+// CreateVendor 创建商家
+func (h *VendorHandler) CreateVendor(c *gin.Context) {
+	var req VendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	vendor, err := h.vendorService.CreateVendor(req.toInput())
+	if err != nil {
+		respondVendorError(c, err, "Failed to create vendor")
+		return
+	}
+	logger.LogOperation(h.db, c, "create_vendor", "vendor", &vendor.ID, map[string]interface{}{
+		"name":            vendor.Name,
+		"commission_rate": vendor.CommissionRate,
+	})
+	response.Success(c, vendor)
+}
+
+// UpdateVendor 更新商家
+func (h *VendorHandler) UpdateVendor(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid vendor ID")
+		return
+	}
+	var req VendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	vendor, err := h.vendorService.UpdateVendor(id, req.toInput())
+	if err != nil {
+		respondVendorError(c, err, "Failed to update vendor")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_vendor", "vendor", &vendor.ID, map[string]interface{}{
+		"name":            vendor.Name,
+		"commission_rate": vendor.CommissionRate,
+		"status":          vendor.Status,
+	})
+	response.Success(c, vendor)
+}
+
+// ListVendorLedger 商家台账
+func (h *VendorHandler) ListVendorLedger(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid vendor ID")
+		return
+	}
+	page, limit := response.GetPagination(c)
+	entries, total, err := h.vendorService.ListLedger(id, c.Query("unsettled") == "1", page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get vendor ledger")
+		return
+	}
+	response.Paginated(c, entries, page, limit, total)
+}
+
+// CreateVendorAdjustment 手动调整商家应付金额
+func (h *VendorHandler) CreateVendorAdjustment(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid vendor ID")
+		return
+	}
+	var req VendorAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	entry, err := h.vendorService.CreateAdjustment(id, adminID, req.Currency, req.AmountMinor, req.Note)
+	if err != nil {
+		respondVendorError(c, err, "Failed to create adjustment")
+		return
+	}
+	logger.LogOperation(h.db, c, "create_vendor_adjustment", "vendor", &id, map[string]interface{}{
+		"entry_id": entry.ID,
+		"currency": entry.Currency,
+		"amount":   entry.NetAmount,
+		"note":     entry.Note,
+	})
+	response.Success(c, entry)
+}
+
+// ListStatements 结算单列表
+func (h *VendorHandler) ListStatements(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	var vendorID uint
+	if raw := strings.TrimSpace(c.Query("vendor_id")); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid vendor ID")
+			return
+		}
+		vendorID = uint(parsed)
+	}
+	statements, total, err := h.vendorService.ListStatements(vendorID, c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get statements")
+		return
+	}
+	response.Paginated(c, statements, page, limit, total)
+}
+
+// GenerateStatements 按结算周期生成结算单
+func (h *VendorHandler) GenerateStatements(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req GenerateVendorStatementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	periodStart, startErr := time.Parse(vendorStatementDateFormat, strings.TrimSpace(req.PeriodStart))
+	periodEnd, endErr := time.Parse(vendorStatementDateFormat, strings.TrimSpace(req.PeriodEnd))
+	if startErr != nil || endErr != nil {
+		response.BadRequest(c, "Invalid period, expected YYYY-MM-DD")
+		return
+	}
+
+	statements, err := h.vendorService.GenerateStatements(periodStart, periodEnd, adminID)
+	if err != nil {
+		respondVendorError(c, err, "Failed to generate statements")
+		return
+	}
+	logger.LogOperation(h.db, c, "generate_vendor_statements", "vendor_statement", nil, map[string]interface{}{
+		"period_start": req.PeriodStart,
+		"period_end":   req.PeriodEnd,
+		"count":        len(statements),
+	})
+	response.Success(c, gin.H{"items": statements})
+}
+
+// GetStatement 结算单详情
+func (h *VendorHandler) GetStatement(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid statement ID")
+		return
+	}
+	statement, entries, err := h.vendorService.GetStatement(id)
+	if err != nil {
+		respondVendorError(c, err, "Failed to get statement")
+		return
+	}
+	response.Success(c, gin.H{"statement": statement, "entries": entries})
+}
+
+// ExportStatement 导出结算单明细（CSV）
+func (h *VendorHandler) ExportStatement(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid statement ID")
+		return
+	}
+	statement, entries, err := h.vendorService.GetStatement(id)
+	if err != nil {
+		respondVendorError(c, err, "Export failed")
+		return
+	}
+
+	headers := []string{
+		"entry_id",
+		"type",
+		"created_at",
+		"order_no",
+		"sku",
+		"quantity",
+		"currency",
+		"gross_amount",
+		"commission",
+		"net_amount",
+		"note",
+	}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(entry.ID), 10),
+			string(entry.Type),
+			csvTimeValue(entry.CreatedAt),
+			entry.OrderNo,
+			entry.SKU,
+			strconv.Itoa(entry.Quantity),
+			entry.Currency,
+			money.MinorToString(entry.GrossAmount),
+			money.MinorToString(entry.Commission),
+			money.MinorToString(entry.NetAmount),
+			entry.Note,
+		})
+	}
+
+	writeCSVAttachment(c, buildAdminCSVFileName(fmt.Sprintf("vendor_statement_%d", statement.ID)), headers, rows)
+}
+
+// MarkStatementPaid 标记结算单已打款
+func (h *VendorHandler) MarkStatementPaid(c *gin.Context) {
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid statement ID")
+		return
+	}
+	var req MarkVendorStatementPaidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	statement, err := h.vendorService.MarkStatementPaid(id, adminID, req.Reference)
+	if err != nil {
+		respondVendorError(c, err, "Failed to update statement")
+		return
+	}
+	logger.LogOperation(h.db, c, "mark_vendor_statement_paid", "vendor_statement", &statement.ID, map[string]interface{}{
+		"vendor_id": statement.VendorID,
+		"currency":  statement.Currency,
+		"payout":    statement.PayoutAmount,
+		"reference": statement.PaymentReference,
+	})
+	response.Success(c, statement)
+}
+
+// AssignProductVendor 设置商品所属商家
+func (h *VendorHandler) AssignProductVendor(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	var req AssignProductVendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	product, err := h.vendorService.AssignProductVendor(productID, req.VendorID)
+	if err != nil {
+		respondVendorError(c, err, "Failed to update product vendor")
+		return
+	}
+	logger.LogOperation(h.db, c, "assign_product_vendor", "product", &product.ID, map[string]interface{}{
+		"sku":       product.SKU,
+		"vendor_id": req.VendorID,
+	})
+	response.Success(c, gin.H{"product_id": product.ID, "vendor_id": product.VendorID})
+}
@@ -1,10 +1,12 @@
 package admin
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -13,6 +15,8 @@ import (
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/fieldcrypt"
+	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/service"
 	"github.com/gin-gonic/gin"
@@ -20,19 +24,29 @@ import (
 )
 
 type VirtualInventoryHandler struct {
-	service       *service.VirtualInventoryService
-	db            *gorm.DB
-	pluginManager *service.PluginManagerService
+	service        *service.VirtualInventoryService
+	supplierHealth *service.SupplierHealthService
+	scriptApproval *service.ScriptApprovalService
+	db             *gorm.DB
+	pluginManager  *service.PluginManagerService
+	orderService   *service.OrderService
 }
 
-func NewVirtualInventoryHandler(service *service.VirtualInventoryService, db *gorm.DB, pluginManager *service.PluginManagerService) *VirtualInventoryHandler {
+func NewVirtualInventoryHandler(service *service.VirtualInventoryService, supplierHealth *service.SupplierHealthService, scriptApproval *service.ScriptApprovalService, db *gorm.DB, pluginManager *service.PluginManagerService) *VirtualInventoryHandler {
 	return &VirtualInventoryHandler{
-		service:       service,
-		db:            db,
-		pluginManager: pluginManager,
+		service:        service,
+		supplierHealth: supplierHealth,
+		scriptApproval: scriptApproval,
+		db:             db,
+		pluginManager:  pluginManager,
 	}
 }
 
+// SetOrderService 注入订单服务，用于重放失败的脚本执行
+func (h *VirtualInventoryHandler) SetOrderService(orderService *service.OrderService) {
+	h.orderService = orderService
+}
+
 func (h *VirtualInventoryHandler) loadVirtualBinding(bindingID uint) (*models.ProductVirtualInventoryBinding, error) {
 	if h.db == nil {
 		return nil, gorm.ErrInvalidDB
@@ -54,6 +68,11 @@ func (h *VirtualInventoryHandler) loadVirtualStock(stockID uint) (*models.Virtua
 	if err := h.db.First(&stock, stockID).Error; err != nil {
 		return nil, err
 	}
+	plain, err := fieldcrypt.Decrypt(stock.Content)
+	if err != nil {
+		return nil, err
+	}
+	stock.Content = plain
 	return &stock, nil
 }
 
@@ -72,6 +91,12 @@ func buildVirtualInventoryHookPayload(inventory *models.VirtualInventory) map[st
 		"description":          inventory.Description,
 		"total_limit":          inventory.TotalLimit,
 		"allow_inline_iframe":  inventory.AllowInlineIframe,
+		"require_reauth":       inventory.RequireReauth,
+		"max_activations":      inventory.MaxActivations,
+		"usage_unit":           inventory.UsageUnit,
+		"usage_quota":          inventory.UsageQuota,
+		"overage_unit_price":   inventory.OverageUnitPrice,
+		"low_stock_threshold":  inventory.LowStockThreshold,
 		"is_active":            inventory.IsActive,
 		"notes":                inventory.Notes,
 		"created_at":           inventory.CreatedAt,
@@ -172,12 +197,18 @@ func (h *VirtualInventoryHandler) CreateVirtualInventory(c *gin.Context) {
 		Description       string `json:"description"`
 		TotalLimit        int64  `json:"total_limit"`
 		AllowInlineIframe bool   `json:"allow_inline_iframe"`
+		RequireReauth     bool   `json:"require_reauth"`
+		MaxActivations    int    `json:"max_activations"`
+		UsageUnit         string `json:"usage_unit"`
+		UsageQuota        int64  `json:"usage_quota"`
+		OverageUnitPrice  int64  `json:"overage_unit_price_minor"`
+		LowStockThreshold int    `json:"low_stock_threshold" binding:"min=0"`
 		IsActive          bool   `json:"is_active"`
 		Notes             string `json:"notes"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -229,6 +260,14 @@ func (h *VirtualInventoryHandler) CreateVirtualInventory(c *gin.Context) {
 			return
 		}
 	}
+	if err := service.ValidateMaxActivations(req.MaxActivations); err != nil {
+		respondAdminBizError(c, err)
+		return
+	}
+	if err := service.ValidateUsageSettings(req.UsageUnit, req.UsageQuota, req.OverageUnitPrice); err != nil {
+		respondAdminBizError(c, err)
+		return
+	}
 
 	inventory := &models.VirtualInventory{
 		Name:              req.Name,
@@ -239,10 +278,24 @@ func (h *VirtualInventoryHandler) CreateVirtualInventory(c *gin.Context) {
 		Description:       req.Description,
 		TotalLimit:        req.TotalLimit,
 		AllowInlineIframe: invType == models.VirtualInventoryTypeScript && req.AllowInlineIframe,
+		RequireReauth:     req.RequireReauth,
+		MaxActivations:    req.MaxActivations,
+		UsageUnit:         strings.TrimSpace(req.UsageUnit),
+		UsageQuota:        req.UsageQuota,
+		OverageUnitPrice:  req.OverageUnitPrice,
+		LowStockThreshold: req.LowStockThreshold,
 		IsActive:          req.IsActive,
 		Notes:             req.Notes,
 	}
 
+	// 开启四眼审批时，新脚本库存先以无库存的静态类型创建，脚本批准后才切换为脚本发货
+	pendingScript := h.scriptApproval.Required() && invType == models.VirtualInventoryTypeScript
+	if pendingScript {
+		inventory.Type = models.VirtualInventoryTypeStatic
+		inventory.Script = ""
+		inventory.ScriptConfig = ""
+	}
+
 	if err := h.service.CreateVirtualInventory(inventory); err != nil {
 		if respondAdminBizError(c, err) {
 			return
@@ -251,6 +304,22 @@ func (h *VirtualInventoryHandler) CreateVirtualInventory(c *gin.Context) {
 		return
 	}
 
+	var scriptRevision *models.VirtualInventoryScriptRevision
+	if pendingScript {
+		revision, err := h.scriptApproval.Submit(inventory, req.Script, req.ScriptConfig, adminIDValue, "")
+		if err != nil {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.InternalError(c, "Failed to submit script revision")
+			return
+		}
+		scriptRevision = revision
+		logger.LogOperation(h.db, c, "script_revision_submit", "virtual_inventory", &inventory.ID, map[string]interface{}{
+			"revision_id": revision.ID,
+		})
+	}
+
 	if h.pluginManager != nil {
 		afterPayload := buildVirtualInventoryHookPayload(inventory)
 		afterPayload["admin_id"] = adminIDValue
@@ -270,6 +339,14 @@ func (h *VirtualInventoryHandler) CreateVirtualInventory(c *gin.Context) {
 		})), afterPayload, inventory.ID)
 	}
 
+	if scriptRevision != nil {
+		response.Success(c, gin.H{
+			"message":         "Virtual inventory created, delivery script is pending approval",
+			"inventory":       inventory,
+			"script_revision": scriptRevision,
+		})
+		return
+	}
 	response.Success(c, gin.H{
 		"message":   "Virtual inventory created successfully",
 		"inventory": inventory,
@@ -290,6 +367,7 @@ func (h *VirtualInventoryHandler) GetVirtualInventory(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, strconv.FormatUint(uint64(inventory.Version), 10))
 	response.Success(c, inventory)
 }
 
@@ -310,12 +388,25 @@ func (h *VirtualInventoryHandler) UpdateVirtualInventory(c *gin.Context) {
 		Description       string  `json:"description"`
 		TotalLimit        *int64  `json:"total_limit"`
 		AllowInlineIframe *bool   `json:"allow_inline_iframe"`
+		RequireReauth     *bool   `json:"require_reauth"`
+		MaxActivations    *int    `json:"max_activations"`
+		UsageUnit         *string `json:"usage_unit"`
+		UsageQuota        *int64  `json:"usage_quota"`
+		OverageUnitPrice  *int64  `json:"overage_unit_price_minor"`
+		LowStockThreshold *int    `json:"low_stock_threshold" binding:"omitempty,min=0"`
 		IsActive          *bool   `json:"is_active"`
 		Notes             string  `json:"notes"`
+		ChangeNote        string  `json:"change_note"` // 脚本修改说明，开启审批时随修订提交
+		Version           uint    `json:"version"`     // 读取时的版本号，也可通过 If-Match 请求头提交
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
+		return
+	}
+	expectedVersion, ok := requestedRecordVersion(c, req.Version)
+	if !ok {
+		response.BadRequest(c, "Invalid version")
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -406,17 +497,90 @@ func (h *VirtualInventoryHandler) UpdateVirtualInventory(c *gin.Context) {
 	} else if req.AllowInlineIframe != nil {
 		updates["allow_inline_iframe"] = *req.AllowInlineIframe
 	}
+	if req.RequireReauth != nil {
+		updates["require_reauth"] = *req.RequireReauth
+	}
+	if req.LowStockThreshold != nil {
+		updates["low_stock_threshold"] = *req.LowStockThreshold
+	}
+	if req.MaxActivations != nil {
+		if err := service.ValidateMaxActivations(*req.MaxActivations); err != nil {
+			respondAdminBizError(c, err)
+			return
+		}
+		updates["max_activations"] = *req.MaxActivations
+	}
+	// 计量配置只影响之后首次上报用量的卡密，已有计量保留发货时的额度与单价
+	if req.UsageUnit != nil || req.UsageQuota != nil || req.OverageUnitPrice != nil {
+		var unit string
+		var quota, overagePrice int64
+		if req.UsageUnit != nil {
+			unit = strings.TrimSpace(*req.UsageUnit)
+			updates["usage_unit"] = unit
+		}
+		if req.UsageQuota != nil {
+			quota = *req.UsageQuota
+			updates["usage_quota"] = quota
+		}
+		if req.OverageUnitPrice != nil {
+			overagePrice = *req.OverageUnitPrice
+			updates["overage_unit_price"] = overagePrice
+		}
+		if err := service.ValidateUsageSettings(unit, quota, overagePrice); err != nil {
+			respondAdminBizError(c, err)
+			return
+		}
+	}
 	if req.Notes != "" {
 		updates["notes"] = req.Notes
 	}
 
-	if err := h.service.UpdateVirtualInventory(id, updates); err != nil {
+	// 开启四眼审批时，脚本及其配置的修改（含切换为脚本类型）转为待审批修订，其余字段照常更新
+	var scriptRevision *models.VirtualInventoryScriptRevision
+	pendingScript := false
+	nextScript, nextScriptConfig := "", ""
+	if h.scriptApproval.Required() && beforeInventory != nil && finalType == models.VirtualInventoryTypeScript {
+		nextScript, nextScriptConfig = beforeInventory.Script, beforeInventory.ScriptConfig
+		if req.Script != nil {
+			nextScript = *req.Script
+		}
+		if req.ScriptConfig != nil {
+			nextScriptConfig = *req.ScriptConfig
+		}
+		switching := beforeInventory.Type != models.VirtualInventoryTypeScript
+		if switching || nextScript != beforeInventory.Script || nextScriptConfig != beforeInventory.ScriptConfig {
+			pendingScript = true
+			delete(updates, "script")
+			delete(updates, "script_config")
+			if switching {
+				delete(updates, "type")
+				delete(updates, "allow_inline_iframe")
+			}
+		}
+	}
+
+	// 仅提交脚本修订时也递增版本，其他管理员基于旧版本的编辑会被拒绝
+	if err := h.service.UpdateVirtualInventory(id, expectedVersion, updates); err != nil {
 		if respondAdminBizError(c, err) {
 			return
 		}
 		response.InternalError(c, "Failed to update virtual inventory")
 		return
 	}
+	if pendingScript {
+		scriptRevision, err = h.scriptApproval.Submit(beforeInventory, nextScript, nextScriptConfig, adminIDValue, req.ChangeNote)
+		if err != nil {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.InternalError(c, "Failed to submit script revision")
+			return
+		}
+		logger.LogOperation(h.db, c, "script_revision_submit", "virtual_inventory", &id, map[string]interface{}{
+			"revision_id": scriptRevision.ID,
+			"note":        scriptRevision.SubmitNote,
+		})
+	}
 
 	if h.pluginManager != nil {
 		updatedInventory, loadErr := h.service.GetVirtualInventory(id)
@@ -431,6 +595,12 @@ func (h *VirtualInventoryHandler) UpdateVirtualInventory(c *gin.Context) {
 				afterPayload["before_description"] = beforeInventory.Description
 				afterPayload["before_total_limit"] = beforeInventory.TotalLimit
 				afterPayload["before_allow_inline_iframe"] = beforeInventory.AllowInlineIframe
+				afterPayload["before_require_reauth"] = beforeInventory.RequireReauth
+				afterPayload["before_max_activations"] = beforeInventory.MaxActivations
+				afterPayload["before_usage_unit"] = beforeInventory.UsageUnit
+				afterPayload["before_usage_quota"] = beforeInventory.UsageQuota
+				afterPayload["before_overage_unit_price"] = beforeInventory.OverageUnitPrice
+				afterPayload["before_low_stock_threshold"] = beforeInventory.LowStockThreshold
 				afterPayload["before_is_active"] = beforeInventory.IsActive
 				afterPayload["before_notes"] = beforeInventory.Notes
 			}
@@ -452,6 +622,13 @@ func (h *VirtualInventoryHandler) UpdateVirtualInventory(c *gin.Context) {
 		}
 	}
 
+	if scriptRevision != nil {
+		response.Success(c, gin.H{
+			"message":         "Virtual inventory updated, script change is pending approval",
+			"script_revision": scriptRevision,
+		})
+		return
+	}
 	response.Success(c, gin.H{"message": "Virtual inventory updated successfully"})
 }
 
@@ -492,7 +669,7 @@ func (h *VirtualInventoryHandler) DeleteVirtualInventory(c *gin.Context) {
 		}
 	}
 
-	if err := h.service.DeleteVirtualInventory(id); err != nil {
+	if err := h.service.DeleteVirtualInventory(id, adminIDValue); err != nil {
 		if respondAdminBizError(c, err) {
 			return
 		}
@@ -531,7 +708,7 @@ func (h *VirtualInventoryHandler) TestDeliveryScript(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -551,6 +728,134 @@ func (h *VirtualInventoryHandler) TestDeliveryScript(c *gin.Context) {
 	response.Success(c, result)
 }
 
+type dryRunDeliveryScriptRequest struct {
+	Script    *string                        `json:"script"`
+	Config    map[string]interface{}         `json:"config"`
+	Quantity  int                            `json:"quantity"`
+	Order     service.ScriptDryRunOrder      `json:"order"`
+	HTTPMode  string                         `json:"http_mode"` // live（默认）/ mock
+	HTTPMocks []service.ScriptDryRunHTTPMock `json:"http_mocks"`
+}
+
+// dryRunStreamEvent 流式试运行的一行输出：log/http 为过程事件，result 为最终结果
+type dryRunStreamEvent struct {
+	Type   string                      `json:"type"`
+	Event  *service.ScriptDryRunEvent  `json:"event,omitempty"`
+	Result *service.ScriptDryRunResult `json:"result,omitempty"`
+	Error  string                      `json:"error,omitempty"`
+}
+
+func (h *VirtualInventoryHandler) bindDryRunRequest(c *gin.Context) (uint, *dryRunDeliveryScriptRequest, bool) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return 0, nil, false
+	}
+	var req dryRunDeliveryScriptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return 0, nil, false
+	}
+	req.HTTPMode = strings.ToLower(strings.TrimSpace(req.HTTPMode))
+	if req.HTTPMode != "" && req.HTTPMode != "live" && req.HTTPMode != "mock" {
+		response.BadRequest(c, "http_mode must be live or mock")
+		return 0, nil, false
+	}
+	return id, &req, true
+}
+
+func (req *dryRunDeliveryScriptRequest) options() service.ScriptDryRunOptions {
+	return service.ScriptDryRunOptions{
+		Quantity:  req.Quantity,
+		Order:     req.Order,
+		MockHTTP:  req.HTTPMode == "mock",
+		HTTPMocks: req.HTTPMocks,
+	}
+}
+
+func (h *VirtualInventoryHandler) logDryRun(c *gin.Context, id uint, req *dryRunDeliveryScriptRequest, result *service.ScriptDryRunResult) {
+	logger.LogOperation(h.db, c, "script_dry_run", "virtual_inventory", &id, map[string]interface{}{
+		"quantity":       result.Quantity,
+		"http_mode":      req.HTTPMode,
+		"script_changed": req.Script != nil,
+		"success":        result.Success,
+	})
+}
+
+// DryRunDeliveryScript 使用模拟订单试运行库存的发货脚本，返回日志、HTTP 调用与解析出的发货项
+func (h *VirtualInventoryHandler) DryRunDeliveryScript(c *gin.Context) {
+	id, req, ok := h.bindDryRunRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.DryRunDeliveryScript(id, req.Script, req.Config, req.options())
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to dry-run delivery script")
+		return
+	}
+	h.logDryRun(c, id, req, result)
+	response.Success(c, result)
+}
+
+// DryRunDeliveryScriptStream 流式试运行：脚本每输出一条日志或发起一次 HTTP 请求即推送一行 NDJSON，最后推送结果
+func (h *VirtualInventoryHandler) DryRunDeliveryScriptStream(c *gin.Context) {
+	id, req, ok := h.bindDryRunRequest(c)
+	if !ok {
+		return
+	}
+	if _, ok := c.Writer.(http.Flusher); !ok {
+		response.InternalError(c, "Streaming is not supported")
+		return
+	}
+
+	// 首个事件产生时才写响应头，脚本运行前的校验错误仍以普通 JSON 返回
+	started := false
+	write := func(event dryRunStreamEvent) {
+		if !started {
+			started = true
+			c.Status(http.StatusOK)
+			c.Header("Content-Type", "application/x-ndjson; charset=utf-8")
+			c.Header("Cache-Control", "no-cache")
+			c.Header("X-Accel-Buffering", "no")
+		}
+		body, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if _, err := c.Writer.Write(append(body, '\n')); err != nil {
+			return
+		}
+		if flusher, ok := c.Writer.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	opts := req.options()
+	opts.OnEvent = func(event service.ScriptDryRunEvent) {
+		write(dryRunStreamEvent{Type: event.Type, Event: &event})
+	}
+	result, err := h.service.DryRunDeliveryScript(id, req.Script, req.Config, opts)
+	if err != nil {
+		if !started {
+			if respondAdminBizError(c, err) {
+				return
+			}
+			response.InternalError(c, "Failed to dry-run delivery script")
+			return
+		}
+		write(dryRunStreamEvent{Type: "error", Error: err.Error()})
+		return
+	}
+	h.logDryRun(c, id, req, result)
+	// 流式输出中已逐条推送过事件，结果中不再重复
+	result.Events = nil
+	write(dryRunStreamEvent{Type: "result", Result: result})
+}
+
 // ImportStock 导入库存项
 func (h *VirtualInventoryHandler) ImportStock(c *gin.Context) {
 	id, err := middleware.GetUintParam(c, "id")
@@ -563,14 +868,14 @@ func (h *VirtualInventoryHandler) ImportStock(c *gin.Context) {
 }
 
 // handleFileImport 处理文件导入
-func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file *multipart.FileHeader, importedBy string) (int, error) {
+func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file *multipart.FileHeader, importedBy string) (*service.VirtualStockImportResult, error) {
 	// 检查文件类型
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 
 	// 打开上传的文件
 	src, err := file.Open()
 	if err != nil {
-		return 0, fmt.Errorf("failed to open uploaded file: %w", err)
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
 	}
 	defer src.Close()
 
@@ -579,13 +884,13 @@ func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file
 		// 保存临时文件用于Excel处理
 		// 确保tmp目录存在
 		if err := os.MkdirAll("./tmp", 0755); err != nil {
-			return 0, fmt.Errorf("failed to create tmp dir: %w", err)
+			return nil, fmt.Errorf("failed to create tmp dir: %w", err)
 		}
 
 		// Use a server-generated temp filename to prevent path traversal via file.Filename.
 		dst, err := os.CreateTemp("./tmp", "virtual-inv-*"+ext)
 		if err != nil {
-			return 0, fmt.Errorf("failed to create temp file: %w", err)
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
 		}
 		tempPath := dst.Name()
 		defer dst.Close()
@@ -593,7 +898,7 @@ func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file
 
 		// 复制文件内容
 		if _, err := io.Copy(dst, src); err != nil {
-			return 0, fmt.Errorf("failed to save temp file: %w", err)
+			return nil, fmt.Errorf("failed to save temp file: %w", err)
 		}
 
 		return h.service.ImportFromExcel(virtualInventoryID, tempPath, importedBy)
@@ -602,7 +907,7 @@ func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file
 		// txt文件读取内容后调用ImportFromText
 		content, err := io.ReadAll(src)
 		if err != nil {
-			return 0, fmt.Errorf("failed to read txt file: %w", err)
+			return nil, fmt.Errorf("failed to read txt file: %w", err)
 		}
 		return h.service.ImportFromText(virtualInventoryID, string(content), importedBy)
 
@@ -611,7 +916,7 @@ func (h *VirtualInventoryHandler) handleFileImport(virtualInventoryID uint, file
 		return h.service.ImportFromCSV(virtualInventoryID, src, importedBy)
 
 	default:
-		return 0, bizerr.New("virtual_inventory.unsupportedFileType", "Unsupported file type").
+		return nil, bizerr.New("virtual_inventory.unsupportedFileType", "Unsupported file type").
 			WithParams(map[string]interface{}{"ext": ext})
 	}
 }
@@ -699,7 +1004,7 @@ func (h *VirtualInventoryHandler) handleImportStockRequest(c *gin.Context, virtu
 	}
 
 	importedBy := c.GetString("user_email")
-	var count int
+	var result *service.VirtualStockImportResult
 	var importErr error
 
 	switch importType {
@@ -709,13 +1014,13 @@ func (h *VirtualInventoryHandler) handleImportStockRequest(c *gin.Context, virtu
 			response.BadRequest(c, "File upload failed")
 			return
 		}
-		count, importErr = h.handleFileImport(virtualInventoryID, file, importedBy)
+		result, importErr = h.handleFileImport(virtualInventoryID, file, importedBy)
 	case "text":
 		if content == "" {
 			response.BizError(c, "Content cannot be empty", "virtual_inventory.contentRequired", nil)
 			return
 		}
-		count, importErr = h.service.ImportFromText(virtualInventoryID, content, importedBy)
+		result, importErr = h.service.ImportFromText(virtualInventoryID, content, importedBy)
 	default:
 		response.BizError(c, "Invalid import type", "virtual_inventory.importTypeInvalid", nil)
 		return
@@ -735,7 +1040,10 @@ func (h *VirtualInventoryHandler) handleImportStockRequest(c *gin.Context, virtu
 			"name":                 inv.Name,
 			"type":                 inv.Type,
 			"import_type":          importType,
-			"count":                count,
+			"count":                result.Added,
+			"duplicates":           result.Duplicates,
+			"invalid":              result.Invalid,
+			"batch_no":             result.BatchNo,
 			"admin_id":             adminIDValue,
 			"source":               "admin_api",
 		}
@@ -761,8 +1069,14 @@ func (h *VirtualInventoryHandler) handleImportStockRequest(c *gin.Context, virtu
 	}
 
 	response.Success(c, gin.H{
-		"message": fmt.Sprintf("Successfully imported %d items", count),
-		"count":   count,
+		"message":    fmt.Sprintf("Imported %d items, skipped %d duplicates and %d invalid rows", result.Added, result.Duplicates, result.Invalid),
+		"count":      result.Added,
+		"batch_no":   result.BatchNo,
+		"total":      result.Total,
+		"added":      result.Added,
+		"duplicates": result.Duplicates,
+		"invalid":    result.Invalid,
+		"issues":     result.Issues,
 	})
 }
 
@@ -859,7 +1173,7 @@ func (h *VirtualInventoryHandler) CreateStockManually(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -974,6 +1288,7 @@ func (h *VirtualInventoryHandler) GetStockList(c *gin.Context) {
 		response.InternalError(c, "Failed to get stock list")
 		return
 	}
+	service.MaskVirtualStockContents(stocks)
 
 	response.Paginated(c, stocks, pageInt, limitInt, total)
 }
@@ -1013,7 +1328,7 @@ func (h *VirtualInventoryHandler) DeleteBatch(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -1144,7 +1459,7 @@ func (h *VirtualInventoryHandler) CreateBinding(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -1247,7 +1562,7 @@ func (h *VirtualInventoryHandler) UpdateBinding(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -1420,7 +1735,7 @@ func (h *VirtualInventoryHandler) SaveVariantBindings(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request data")
+		response.BindingError(c, err)
 		return
 	}
 	adminID := getOptionalUserID(c)
@@ -1572,6 +1887,7 @@ func (h *VirtualInventoryHandler) GetStockListForProduct(c *gin.Context) {
 		response.InternalError(c, "Failed to get stock list")
 		return
 	}
+	service.MaskVirtualStockContents(stocks)
 
 	response.Paginated(c, stocks, pageInt, limitInt, total)
 }
@@ -1630,6 +1946,48 @@ func (h *VirtualInventoryHandler) ImportStockForProduct(c *gin.Context) {
 	h.handleImportStockRequest(c, binding.VirtualInventoryID, &productID)
 }
 
+// RevealStock 查看库存项的卡密明文，每次查看都记录审计日志
+func (h *VirtualInventoryHandler) RevealStock(c *gin.Context) {
+	stockID, err := middleware.GetUintParam(c, "stock_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid stock ID")
+		return
+	}
+
+	h.handleRevealStockRequest(c, stockID)
+}
+
+// RevealStockByID 查看单个库存项的卡密明文（通用API）
+func (h *VirtualInventoryHandler) RevealStockByID(c *gin.Context) {
+	stockID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid stock ID")
+		return
+	}
+
+	h.handleRevealStockRequest(c, stockID)
+}
+
+func (h *VirtualInventoryHandler) handleRevealStockRequest(c *gin.Context, stockID uint) {
+	stock, err := h.service.RevealStock(stockID)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to reveal stock content")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "reveal_content", "virtual_stock", &stock.ID, map[string]interface{}{
+		"virtual_inventory_id": stock.VirtualInventoryID,
+		"status":               stock.Status,
+		"order_no":             stock.OrderNo,
+	})
+	response.Success(c, gin.H{
+		"id":      stock.ID,
+		"content": stock.Content,
+	})
+}
+
 // DeleteStockByID 删除单个库存项（通用API）
 func (h *VirtualInventoryHandler) DeleteStockByID(c *gin.Context) {
 	stockID, err := middleware.GetUintParam(c, "id")
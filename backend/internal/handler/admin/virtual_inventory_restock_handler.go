@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VirtualInventoryRestockHandler 静态虚拟库存的自动补货策略与采购记录
+type VirtualInventoryRestockHandler struct {
+	restockService *service.VirtualInventoryRestockService
+	db             *gorm.DB
+}
+
+func NewVirtualInventoryRestockHandler(restockService *service.VirtualInventoryRestockService, db *gorm.DB) *VirtualInventoryRestockHandler {
+	return &VirtualInventoryRestockHandler{restockService: restockService, db: db}
+}
+
+// Get 补货策略与本日/本月采购花费
+func (h *VirtualInventoryRestockHandler) Get(c *gin.Context) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid virtual inventory ID")
+		return
+	}
+	overview, err := h.restockService.Overview(inventoryID)
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to get restock policy")
+		}
+		return
+	}
+	response.Success(c, overview)
+}
+
+// Update 保存补货策略
+func (h *VirtualInventoryRestockHandler) Update(c *gin.Context) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid virtual inventory ID")
+		return
+	}
+	var req service.VirtualInventoryRestockInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	policy, err := h.restockService.SavePolicy(inventoryID, req, getOptionalUserID(c))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to save restock policy")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update_restock_policy", "virtual_inventory", &inventoryID, map[string]interface{}{
+		"enabled":                 policy.Enabled,
+		"threshold":               policy.Threshold,
+		"quantity":                policy.Quantity,
+		"daily_spend_cap_minor":   policy.DailySpendCapMinor,
+		"monthly_spend_cap_minor": policy.MonthlySpendCapMinor,
+	})
+	overview, err := h.restockService.Overview(inventoryID)
+	if err != nil {
+		response.InternalError(c, "Failed to get restock policy")
+		return
+	}
+	response.Success(c, overview)
+}
+
+// Run 立即执行一次补货，不受阈值与冷却时间限制
+func (h *VirtualInventoryRestockHandler) Run(c *gin.Context) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid virtual inventory ID")
+		return
+	}
+	run, err := h.restockService.RunNow(inventoryID, getOptionalUserID(c))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to run restock")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "run_restock", "virtual_inventory", &inventoryID, map[string]interface{}{
+		"run_id":     run.ID,
+		"status":     run.Status,
+		"added":      run.Added,
+		"cost_minor": run.CostMinor,
+	})
+	response.Success(c, run)
+}
+
+// ListRuns 分页查询补货采购记录，可按状态过滤
+func (h *VirtualInventoryRestockHandler) ListRuns(c *gin.Context) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid virtual inventory ID")
+		return
+	}
+	page, limit := response.GetPagination(c)
+	runs, total, err := h.restockService.ListRuns(inventoryID, c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, runs, page, limit, total)
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ListScriptRevisions 脚本修订列表，带 :id 时仅返回该库存的修订
+func (h *VirtualInventoryHandler) ListScriptRevisions(c *gin.Context) {
+	var inventoryID uint
+	if c.Param("id") != "" {
+		id, err := middleware.GetUintParam(c, "id")
+		if err != nil {
+			response.BadRequest(c, "Invalid inventory ID")
+			return
+		}
+		inventoryID = id
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.ScriptRevisionStatusPending, models.ScriptRevisionStatusApproved,
+		models.ScriptRevisionStatusRejected, models.ScriptRevisionStatusSuperseded:
+	default:
+		response.BadRequest(c, "Invalid status")
+		return
+	}
+
+	page, limit := response.GetPagination(c)
+	revisions, total, err := h.scriptApproval.List(inventoryID, status, page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get script revisions")
+		return
+	}
+	response.Paginated(c, revisions, page, limit, total)
+}
+
+// GetScriptRevisionDiff 查看修订相对提交时生效脚本的差异
+func (h *VirtualInventoryHandler) GetScriptRevisionDiff(c *gin.Context) {
+	inventoryID, revisionID, ok := h.scriptRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.scriptApproval.Diff(inventoryID, revisionID)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to get script revision diff")
+		return
+	}
+	response.Success(c, diff)
+}
+
+// ApproveScriptRevision 批准脚本修订并使其生效，提交人不能批准自己的修订
+func (h *VirtualInventoryHandler) ApproveScriptRevision(c *gin.Context) {
+	h.reviewScriptRevision(c, true)
+}
+
+// RejectScriptRevision 驳回脚本修订
+func (h *VirtualInventoryHandler) RejectScriptRevision(c *gin.Context) {
+	h.reviewScriptRevision(c, false)
+}
+
+func (h *VirtualInventoryHandler) reviewScriptRevision(c *gin.Context, approve bool) {
+	inventoryID, revisionID, ok := h.scriptRevisionParams(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		Note string `json:"note"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	reviewerID, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "Unauthorized")
+		return
+	}
+
+	review := h.scriptApproval.Reject
+	action := "script_revision_reject"
+	if approve {
+		review = h.scriptApproval.Approve
+		action = "script_revision_approve"
+	}
+	revision, err := review(inventoryID, revisionID, reviewerID, req.Note)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to review script revision")
+		return
+	}
+
+	logger.LogOperation(h.db, c, action, "virtual_inventory", &inventoryID, map[string]interface{}{
+		"revision_id":  revision.ID,
+		"submitted_by": revision.SubmittedBy,
+		"note":         revision.ReviewNote,
+	})
+	response.Success(c, revision)
+}
+
+func (h *VirtualInventoryHandler) scriptRevisionParams(c *gin.Context) (uint, uint, bool) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return 0, 0, false
+	}
+	revisionID, err := middleware.GetUintParam(c, "revision_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid revision ID")
+		return 0, 0, false
+	}
+	return inventoryID, revisionID, true
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"strconv"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ListScriptExecutions 库存的脚本执行审计记录，?success=false 只看失败的执行
+func (h *VirtualInventoryHandler) ListScriptExecutions(c *gin.Context) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return
+	}
+	var success *bool
+	if raw := c.Query("success"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid success filter")
+			return
+		}
+		success = &parsed
+	}
+
+	page, limit := response.GetPagination(c)
+	executions, total, err := h.service.ListScriptExecutions(inventoryID, success, page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get script executions")
+		return
+	}
+	response.Paginated(c, executions, page, limit, total)
+}
+
+// GetScriptExecution 查看一次脚本执行的控制台输出与 HTTP 调用
+func (h *VirtualInventoryHandler) GetScriptExecution(c *gin.Context) {
+	inventoryID, executionID, ok := h.scriptExecutionParams(c)
+	if !ok {
+		return
+	}
+	execution, err := h.service.GetScriptExecution(inventoryID, executionID)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to get script execution")
+		return
+	}
+	response.Success(c, execution)
+}
+
+// ReplayScriptExecution 修复脚本后对失败执行所属的订单重新发货
+// 脚本再次失败时仍返回 200，结果中 success 为 false 并附带新的执行记录
+func (h *VirtualInventoryHandler) ReplayScriptExecution(c *gin.Context) {
+	inventoryID, executionID, ok := h.scriptExecutionParams(c)
+	if !ok {
+		return
+	}
+	adminID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	if h.orderService == nil {
+		response.InternalError(c, "Order service unavailable")
+		return
+	}
+
+	result, err := h.orderService.ReplayScriptExecution(inventoryID, executionID, adminID)
+	if err != nil {
+		if respondAdminBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to replay script execution")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "script_execution_replay", "virtual_inventory", &inventoryID, map[string]interface{}{
+		"execution_id": executionID,
+		"success":      result.Success,
+		"executions":   len(result.Executions),
+	})
+	response.Success(c, result)
+}
+
+func (h *VirtualInventoryHandler) scriptExecutionParams(c *gin.Context) (uint, uint, bool) {
+	inventoryID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return 0, 0, false
+	}
+	executionID, err := middleware.GetUintParam(c, "execution_id")
+	if err != nil {
+		response.BadRequest(c, "Invalid execution ID")
+		return 0, 0, false
+	}
+	return inventoryID, executionID, true
+}
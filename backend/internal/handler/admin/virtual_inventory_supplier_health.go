@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"strconv"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSupplierHealth 脚本发货上游接口健康看板
+func (h *VirtualInventoryHandler) GetSupplierHealth(c *gin.Context) {
+	if h.supplierHealth == nil {
+		response.Success(c, gin.H{"items": []interface{}{}})
+		return
+	}
+
+	window := h.supplierHealth.Window()
+	if raw := c.Query("window_minutes"); raw != "" {
+		minutes, err := strconv.Atoi(raw)
+		if err != nil || minutes <= 0 || minutes > 7*24*60 {
+			response.BadRequest(c, "Invalid window_minutes")
+			return
+		}
+		window = time.Duration(minutes) * time.Minute
+	}
+
+	items, err := h.supplierHealth.Dashboard(window)
+	if err != nil {
+		response.InternalError(c, "Failed to get supplier health")
+		return
+	}
+	response.Success(c, gin.H{
+		"window_minutes": int(window / time.Minute),
+		"items":          items,
+	})
+}
+
+// ResumeSupplier 解除因上游错误率过高而被自动暂停的脚本库存
+func (h *VirtualInventoryHandler) ResumeSupplier(c *gin.Context) {
+	id, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid inventory ID")
+		return
+	}
+	if h.supplierHealth == nil {
+		response.NotFound(c, "Virtual inventory is not paused")
+		return
+	}
+
+	resumed, err := h.supplierHealth.Resume(id)
+	if err != nil {
+		response.InternalError(c, "Failed to resume virtual inventory")
+		return
+	}
+	if !resumed {
+		response.NotFound(c, "Virtual inventory is not paused")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "supplier_resume", "virtual_inventory", &id, nil)
+	response.Success(c, gin.H{"message": "Virtual inventory resumed"})
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"strconv"
+
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VirtualStockResaleHandler 站外转售卡密核查
+type VirtualStockResaleHandler struct {
+	resaleService *service.VirtualStockResaleService
+	db            *gorm.DB
+}
+
+func NewVirtualStockResaleHandler(resaleService *service.VirtualStockResaleService, db *gorm.DB) *VirtualStockResaleHandler {
+	return &VirtualStockResaleHandler{resaleService: resaleService, db: db}
+}
+
+// Check 核查卡密是否由本店售出以及售给了谁，每次核查都会登记
+func (h *VirtualStockResaleHandler) Check(c *gin.Context) {
+	var req service.VirtualStockResaleCheckInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	result, err := h.resaleService.Check(req, getOptionalUserID(c))
+	if err != nil {
+		if !respondAdminBizError(c, err) {
+			response.InternalError(c, "Failed to check code")
+		}
+		return
+	}
+
+	logger.LogOperation(h.db, c, "resale_check", "virtual_stock", &result.ReportID, map[string]interface{}{
+		"matched":     result.Matched,
+		"deliveries":  len(result.Deliveries),
+		"source":      req.Source,
+		"prior_count": result.PreviousReports,
+	})
+	response.Success(c, result)
+}
+
+// ListReports 分页查询核查记录，可按 matched、order_id 过滤
+func (h *VirtualStockResaleHandler) ListReports(c *gin.Context) {
+	page, limit := response.GetPagination(c)
+	var matched *bool
+	if raw := c.Query("matched"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid matched filter")
+			return
+		}
+		matched = &value
+	}
+	var orderID uint
+	if raw := c.Query("order_id"); raw != "" {
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid order ID")
+			return
+		}
+		orderID = uint(value)
+	}
+
+	reports, total, err := h.resaleService.ListReports(matched, orderID, page, limit)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Paginated(c, reports, page, limit, total)
+}
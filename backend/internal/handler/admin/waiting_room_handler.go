@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WaitingRoomHandler struct {
+	waitingRoomService *service.WaitingRoomService
+	db                 *gorm.DB
+}
+
+func NewWaitingRoomHandler(waitingRoomService *service.WaitingRoomService, db *gorm.DB) *WaitingRoomHandler {
+	return &WaitingRoomHandler{waitingRoomService: waitingRoomService, db: db}
+}
+
+// UpdateWaitingRoomRequest 开关商品等候室请求
+type UpdateWaitingRoomRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetStats 商品等候室概况（排队人数、持有令牌人数）
+func (h *WaitingRoomHandler) GetStats(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	stats, err := h.waitingRoomService.Stats(productID)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		response.InternalError(c, "Failed to get waiting room stats")
+		return
+	}
+	response.Success(c, stats)
+}
+
+// Update 开关商品等候室
+func (h *WaitingRoomHandler) Update(c *gin.Context) {
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	var req UpdateWaitingRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	product, err := h.waitingRoomService.SetProductWaitingRoom(productID, req.Enabled)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		response.InternalError(c, "Failed to update waiting room")
+		return
+	}
+
+	logger.LogOperation(h.db, c, "update_waiting_room", "product", &product.ID, map[string]interface{}{
+		"sku":          product.SKU,
+		"waiting_room": req.Enabled,
+	})
+	stats, err := h.waitingRoomService.Stats(productID)
+	if err != nil {
+		response.InternalError(c, "Failed to get waiting room stats")
+		return
+	}
+	response.Success(c, stats)
+}
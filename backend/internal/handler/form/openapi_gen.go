@@ -0,0 +1,26 @@
+// Code generated by internal/openapi/gen; DO NOT EDIT.
+
+package form
+
+import (
+	"auralogic/internal/openapi"
+	"reflect"
+)
+
+// OpenAPIBindings 本包 handler 方法的请求描述，由 /openapi.json 使用
+var OpenAPIBindings = map[string]openapi.Binding{
+	"auralogic/internal/handler/form.(*ShippingHandler).GetAddressSchemas": {
+		Summary: "Get phone codes, required address fields and postcode formats per country",
+	},
+	"auralogic/internal/handler/form.(*ShippingHandler).GetCountries": {
+		Summary: "Get list of supported countries",
+	},
+	"auralogic/internal/handler/form.(*ShippingHandler).GetForm": {
+		Summary:     "Get form information",
+		QueryParams: []string{"token"},
+	},
+	"auralogic/internal/handler/form.(*ShippingHandler).SubmitForm": {
+		Summary: "Submit shipping information form",
+		Body:    reflect.TypeOf((*SubmitFormRequest)(nil)).Elem(),
+	},
+}
@@ -10,7 +10,9 @@ import (
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/constants"
 	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/orderbiz"
 	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
 	"auralogic/internal/pkg/validator"
 	"auralogic/internal/service"
 	"github.com/gin-gonic/gin"
@@ -86,7 +88,7 @@ type SubmitFormRequest struct {
 func (h *ShippingHandler) SubmitForm(c *gin.Context) {
 	var req SubmitFormRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -209,6 +211,12 @@ func (h *ShippingHandler) SubmitForm(c *gin.Context) {
 		receiverCountry = "CN"
 	}
 
+	// Country-specific required fields and postcode format
+	if bizErr := orderbiz.ValidateReceiverAddress(receiverCountry, req.ReceiverProvince, req.ReceiverCity, req.ReceiverDistrict, req.ReceiverPostcode); bizErr != nil {
+		response.HandleError(c, "Invalid shipping address", bizErr)
+		return
+	}
+
 	// Default phone code is +86
 	phoneCode := req.PhoneCode
 	if phoneCode == "" {
@@ -249,6 +257,9 @@ func (h *ShippingHandler) SubmitForm(c *gin.Context) {
 		return
 	}
 
+	// 收货地址确定后重新按频率规则检查订单
+	h.orderService.EvaluateOrderRisk(submittedOrder, utils.GetRealIP(c))
+
 	// Log success
 	db := database.GetDB()
 	logger.LogOrderOperation(db, c, "form_submit", submittedOrder.ID, map[string]interface{}{
@@ -317,3 +328,8 @@ func (h *ShippingHandler) respondFormOrderLoadError(c *gin.Context, err error) {
 func (h *ShippingHandler) GetCountries(c *gin.Context) {
 	response.Success(c, constants.Countries)
 }
+
+// GetAddressSchemas Get phone codes, required address fields and postcode formats per country
+func (h *ShippingHandler) GetAddressSchemas(c *gin.Context) {
+	response.Success(c, constants.AddressSchemas())
+}
@@ -11,6 +11,7 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/database"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/constants"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/repository"
 	"auralogic/internal/service"
@@ -218,3 +219,97 @@ func TestSubmitFormRejectsMismatchedAuthenticatedUser(t *testing.T) {
 		t.Fatalf("expected response code %d, got %d", response.CodeForbidden, resp.Code)
 	}
 }
+
+func TestSubmitFormValidatesAddressAgainstCountrySchema(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, db := newShippingHandlerTestEnv(t)
+
+	token := "schema-token"
+	order := &models.Order{
+		OrderNo:   "ORD-SCHEMA",
+		Status:    models.OrderStatusDraft,
+		Items:     []models.OrderItem{},
+		FormToken: &token,
+		UserEmail: "buyer@example.com",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	cases := []struct {
+		postcode string
+		wantKey  string
+	}{
+		{postcode: "", wantKey: "order.postcodeRequired"},
+		{postcode: "9410", wantKey: "order.postcodeFormatInvalid"},
+	}
+	for _, tc := range cases {
+		payload, err := json.Marshal(map[string]interface{}{
+			"form_token":        token,
+			"receiver_name":     "Receiver",
+			"receiver_phone":    "4155550100",
+			"receiver_email":    "buyer@example.com",
+			"receiver_country":  "us",
+			"receiver_province": "CA",
+			"receiver_city":     "San Francisco",
+			"receiver_address":  "1 Market St",
+			"receiver_postcode": tc.postcode,
+		})
+		if err != nil {
+			t.Fatalf("marshal payload: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/api/form/shipping", bytes.NewReader(payload))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+
+		handler.SubmitForm(ctx)
+
+		resp := decodeShippingResponse(t, recorder)
+		if recorder.Code != http.StatusBadRequest || resp.Code != response.CodeBusinessError {
+			t.Fatalf("postcode %q: expected business error, got status %d code %d", tc.postcode, recorder.Code, resp.Code)
+		}
+		data, _ := resp.Data.(map[string]interface{})
+		if data["error_key"] != tc.wantKey {
+			t.Fatalf("postcode %q: expected error key %s, got %v", tc.postcode, tc.wantKey, data["error_key"])
+		}
+	}
+
+	var stored models.Order
+	if err := db.First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("reload order: %v", err)
+	}
+	if stored.FormSubmittedAt != nil {
+		t.Fatalf("expected invalid address to leave the form unsubmitted")
+	}
+}
+
+func TestGetAddressSchemasCoversCountryList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, _ := newShippingHandlerTestEnv(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/form/address-schemas", nil)
+
+	handler.GetAddressSchemas(ctx)
+
+	var resp struct {
+		Data []constants.AddressSchema `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Data) != len(constants.Countries) {
+		t.Fatalf("expected a schema for each of %d countries, got %d", len(constants.Countries), len(resp.Data))
+	}
+	for _, schema := range resp.Data {
+		if schema.PhoneCode == "" {
+			t.Fatalf("country %s has no phone code", schema.Code)
+		}
+		if schema.PostcodeExample != "" && !constants.MatchPostcode(schema.Code, schema.PostcodeExample) {
+			t.Fatalf("country %s postcode example %q does not match its own pattern", schema.Code, schema.PostcodeExample)
+		}
+	}
+}
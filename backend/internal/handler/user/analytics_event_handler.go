@@ -0,0 +1,66 @@
+package user
+
+import (
+	"log"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsEventHandler 第一方行为事件上报（无需登录，不使用 Cookie）
+type AnalyticsEventHandler struct {
+	eventService *service.AnalyticsEventService
+	cfg          *config.Config
+}
+
+func NewAnalyticsEventHandler(eventService *service.AnalyticsEventService, cfg *config.Config) *AnalyticsEventHandler {
+	return &AnalyticsEventHandler{eventService: eventService, cfg: cfg}
+}
+
+// AnalyticsEventRequest 上报的单个事件
+type AnalyticsEventRequest struct {
+	Type      string `json:"type" binding:"required,oneof=page_view add_to_cart checkout_step"`
+	Path      string `json:"path" binding:"max=2048"`
+	Referer   string `json:"referer" binding:"max=2048"`
+	ProductID *uint  `json:"product_id"`
+	Quantity  int    `json:"quantity" binding:"min=0,max=10000"`
+	Step      string `json:"step" binding:"omitempty,oneof=cart submit placed payment"`
+}
+
+// Record 记录事件。未启用数据分析或识别为爬虫时静默丢弃，上报方无需区分
+func (h *AnalyticsEventHandler) Record(c *gin.Context) {
+	var req AnalyticsEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if req.Type == models.AnalyticsEventCheckoutStep && req.Step == "" {
+		response.BadRequest(c, "Checkout step is required")
+		return
+	}
+	if h.cfg == nil || !h.cfg.Analytics.Enabled {
+		response.Success(c, gin.H{"recorded": false})
+		return
+	}
+
+	recorded, err := h.eventService.Record(service.AnalyticsEventInput{
+		Type:      req.Type,
+		Path:      req.Path,
+		Referer:   req.Referer,
+		ProductID: req.ProductID,
+		Quantity:  req.Quantity,
+		Step:      req.Step,
+		IP:        utils.GetRealIP(c),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to record analytics event: %v", err)
+		response.InternalError(c, "Failed to record event")
+		return
+	}
+	response.Success(c, gin.H{"recorded": recorded})
+}
@@ -39,14 +39,17 @@ func respondAuthBizError(c *gin.Context, err error, extraData gin.H) bool {
 		"auth.passwordResetDisabled",
 		"auth.phoneLoginDisabled",
 		"auth.phoneRegistrationDisabled",
-		"auth.phonePasswordResetDisabled":
+		"auth.phonePasswordResetDisabled",
+		"blocklist.blocked":
 		response.ErrorWithData(c, http.StatusForbidden, response.CodeForbidden, bizErr.Message, data)
-	case "auth.emailNotVerified":
+	case "auth.emailNotVerified", "auth.emailNotVerifiedForCheckout", "auth.emailNotVerifiedForVirtualReveal":
 		response.ErrorWithData(c, http.StatusForbidden, response.CodeEmailNotVerified, bizErr.Message, data)
 	case "auth.emailAlreadyInUse", "auth.phoneAlreadyInUse":
 		response.ErrorWithData(c, http.StatusConflict, response.CodeConflict, bizErr.Message, data)
 	case "auth.emailLoginUnavailable", "auth.smsServiceUnavailable":
 		response.ErrorWithData(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable, bizErr.Message, data)
+	case "auth.tooManyCodeAttempts":
+		response.ErrorWithData(c, http.StatusTooManyRequests, response.CodeTooManyRequests, bizErr.Message, data)
 	case "auth.captchaRequired":
 		response.ErrorWithData(c, http.StatusBadRequest, response.CodeParamMissing, bizErr.Message, data)
 	case "auth.captchaFailed", "auth.invalidPhoneFormat":
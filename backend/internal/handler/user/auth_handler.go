@@ -123,7 +123,7 @@ type LoginRequest struct {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -188,6 +188,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		response.InternalServerError(c, "Login failed", err)
 		return
 	}
+	if rejectBlocklistedLogin(c, h.authService, user) {
+		return
+	}
+	if respondTwoFactorChallenge(c, user) {
+		return
+	}
 
 	// 记录登录IP
 	user.LastLoginIP = utils.GetRealIP(c)
@@ -262,7 +268,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -324,6 +330,18 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		}
 	}
 
+	if err := h.authService.CheckBlocklist(models.BlocklistScopeRegister, utils.GetRealIP(c), req.Email, ""); err != nil {
+		if !respondAuthBizError(c, err, nil) {
+			response.InternalError(c, "Registration failed")
+		}
+		return
+	}
+	emailRiskFlag, err := h.authService.ScreenEmail(req.Email)
+	if err != nil {
+		respondAuthBizError(c, err, nil)
+		return
+	}
+
 	user, err := h.authService.Register(req.Email, "", req.Name, req.Password)
 	if err != nil {
 		switch {
@@ -338,6 +356,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// 记录注册IP
 	user.RegisterIP = utils.GetRealIP(c)
+	user.EmailRiskFlag = emailRiskFlag
 	db := database.GetDB()
 	if err := db.Save(user).Error; err != nil {
 		response.InternalError(c, "Registration failed")
@@ -345,11 +364,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// 记录注册日志
-	logger.LogOperation(db, c, "register", "user", &user.ID, map[string]interface{}{
+	registerLog := map[string]interface{}{
 		"email":       user.Email,
 		"name":        user.Name,
 		"register_ip": user.RegisterIP,
-	})
+	}
+	if user.EmailRiskFlag != "" {
+		registerLog["email_risk_flag"] = user.EmailRiskFlag
+	}
+	logger.LogOperation(db, c, "register", "user", &user.ID, registerLog)
 	emitRegisterAfter := func(requireVerification bool) {
 		if h.pluginManager == nil {
 			return
@@ -376,7 +399,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// 如果需要邮箱验证
-	if cfg.Security.Login.RequireEmailVerification && h.emailService != nil {
+	requireVerification := cfg.Security.Login.RequireEmailVerification && h.emailService != nil
+	if requireVerification {
 		// 生成验证 token
 		token, err := generateVerificationToken()
 		if err != nil {
@@ -399,19 +423,22 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		go h.emailService.SendVerificationEmail(user.Email, user.Name, token, user.Locale)
 		emitRegisterAfter(true)
 
-		response.Success(c, gin.H{
-			"require_verification": true,
-			"message":              "Registration successful. Please check your email to verify your account.",
-			"email":                user.Email,
-		})
-		return
-	}
-
-	// 不需要邮箱验证，直接标记已验证并登录
-	user.EmailVerified = true
-	if err := db.Save(user).Error; err != nil {
-		response.InternalError(c, "Registration failed")
-		return
+		// 仅 login 限制方式下需先验证再登录，其余方式注册后直接登录
+		if service.ResolveEmailVerificationMode(cfg) == service.EmailVerificationModeLogin {
+			response.Success(c, gin.H{
+				"require_verification": true,
+				"message":              "Registration successful. Please check your email to verify your account.",
+				"email":                user.Email,
+			})
+			return
+		}
+	} else {
+		// 不需要邮箱验证，直接标记已验证并登录
+		user.EmailVerified = true
+		if err := db.Save(user).Error; err != nil {
+			response.InternalError(c, "Registration failed")
+			return
+		}
 	}
 
 	// 生成JWT Token
@@ -426,14 +453,17 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	h.authService.UpdateLoginIP(user)
 
 	// 发送注册欢迎邮件
-	if h.emailService != nil {
-		go h.emailService.SendRegistrationWelcomeEmail(user.Email, user.Name, user.Locale)
+	if !requireVerification {
+		if h.emailService != nil {
+			go h.emailService.SendRegistrationWelcomeEmail(user.Email, user.Name, user.Locale)
+		}
+		emitRegisterAfter(false)
 	}
-	emitRegisterAfter(false)
 
 	response.Success(c, gin.H{
-		"token":      jwtToken,
-		"token_type": "Bearer",
+		"token":                      jwtToken,
+		"token_type":                 "Bearer",
+		"email_verification_pending": service.EmailVerificationPending(cfg, user),
 		"user": gin.H{
 			"id":                user.ID,
 			"user_id":           user.ID,
@@ -443,12 +473,30 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			"role":              user.Role,
 			"avatar":            user.Avatar,
 			"locale":            user.Locale,
+			"email_verified":    user.EmailVerified,
 			"total_spent_minor": user.TotalSpentMinor,
 			"total_order_count": user.TotalOrderCount,
 		},
 	})
 }
 
+// rejectBlocklistedLogin 凭据校验通过后检查黑名单，命中时拒绝登录并已写入响应
+func rejectBlocklistedLogin(c *gin.Context, authService *service.AuthService, user *models.User) bool {
+	phone := ""
+	if user.Phone != nil {
+		phone = *user.Phone
+	}
+	err := authService.CheckBlocklist(models.BlocklistScopeLogin, utils.GetRealIP(c), user.Email, phone)
+	if err == nil {
+		return false
+	}
+	logger.LogLoginAttempt(database.GetDB(), c, user.Email, false, &user.ID)
+	if !respondAuthBizError(c, err, nil) {
+		response.InternalError(c, "Login failed")
+	}
+	return true
+}
+
 // maskPhone masks a phone number, e.g. "13300003333" -> "13*******33"
 func maskPhone(phone string) string {
 	n := len(phone)
@@ -492,23 +540,25 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 
 	// 构建响应数据
 	result := gin.H{
-		"id":                     user.ID,
-		"user_id":                user.ID,
-		"uuid":                   user.UUID,
-		"email":                  user.Email,
-		"name":                   user.Name,
-		"role":                   user.Role,
-		"avatar":                 user.Avatar,
-		"is_active":              user.IsActive,
-		"locale":                 user.Locale,
-		"country":                user.Country,
-		"email_notify_order":     user.EmailNotifyOrder,
-		"email_notify_ticket":    user.EmailNotifyTicket,
-		"email_notify_marketing": user.EmailNotifyMarketing,
-		"sms_notify_marketing":   user.SMSNotifyMarketing,
-		"total_spent_minor":      user.TotalSpentMinor,
-		"total_order_count":      user.TotalOrderCount,
-		"created_at":             user.CreatedAt,
+		"id":                         user.ID,
+		"user_id":                    user.ID,
+		"uuid":                       user.UUID,
+		"email":                      user.Email,
+		"name":                       user.Name,
+		"role":                       user.Role,
+		"avatar":                     user.Avatar,
+		"is_active":                  user.IsActive,
+		"locale":                     user.Locale,
+		"country":                    user.Country,
+		"email_notify_order":         user.EmailNotifyOrder,
+		"email_notify_ticket":        user.EmailNotifyTicket,
+		"email_notify_marketing":     user.EmailNotifyMarketing,
+		"sms_notify_marketing":       user.SMSNotifyMarketing,
+		"total_spent_minor":          user.TotalSpentMinor,
+		"total_order_count":          user.TotalOrderCount,
+		"created_at":                 user.CreatedAt,
+		"email_verified":             user.EmailVerified,
+		"email_verification_pending": service.EmailVerificationPending(config.GetConfig(), user),
 	}
 	if user.Phone != nil && *user.Phone != "" {
 		result["phone"] = maskPhone(*user.Phone)
@@ -546,7 +596,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 
 	var req ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := h.buildAuthHookExecutionContext(c, &userID)
@@ -623,7 +673,7 @@ func (h *AuthHandler) UpdatePreferences(c *gin.Context) {
 
 	var req UpdatePreferencesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := h.buildAuthHookExecutionContext(c, &userID)
@@ -807,6 +857,14 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 		"email": user.Email,
 	})
 
+	if rejectBlocklistedLogin(c, h.authService, &user) {
+		return
+	}
+	// 开启两步验证的用户需先完成第二步
+	if respondTwoFactorChallenge(c, &user) {
+		return
+	}
+
 	// 生成 JWT Token 让用户直接登录
 	jwtToken, err := h.authService.GenerateToken(&user)
 	if err != nil {
@@ -843,7 +901,7 @@ func (h *AuthHandler) ResendVerification(c *gin.Context) {
 		Email string `json:"email" binding:"required,email"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -920,21 +978,16 @@ func (h *AuthHandler) SendLoginCode(c *gin.Context) {
 
 	var req SendLoginCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
 
 	// 先检查冷却，避免浪费验证码
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("email_login_cooldown:ip:%s", ip)
-	emailKey := fmt.Sprintf("email_login_cooldown:email:%s", req.Email)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(emailKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposeEmailLogin, ip, req.Email) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
 
@@ -951,8 +1004,7 @@ func (h *AuthHandler) SendLoginCode(c *gin.Context) {
 	}
 
 	// 设置冷却
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(emailKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposeEmailLogin, ip, req.Email)
 
 	code, err := h.authService.SendLoginCode(req.Email)
 	if err != nil {
@@ -996,7 +1048,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
 	var req ForgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -1039,14 +1091,9 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
 	// 冷却检查
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("password_reset_cooldown:ip:%s", ip)
-	emailKey := fmt.Sprintf("password_reset_cooldown:email:%s", req.Email)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(emailKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposePasswordReset, ip, req.Email) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
 
@@ -1063,8 +1110,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	}
 
 	// 设置冷却
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(emailKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposePasswordReset, ip, req.Email)
 
 	// 生成token并发送邮件（不暴露用户是否存在）
 	token, err := h.authService.GeneratePasswordResetToken(req.Email)
@@ -1110,7 +1156,7 @@ type ResetPasswordRequest struct {
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := h.buildAuthHookExecutionContext(c, nil)
@@ -1187,7 +1233,7 @@ func (h *AuthHandler) LoginWithCode(c *gin.Context) {
 
 	var req LoginWithCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -1239,6 +1285,12 @@ func (h *AuthHandler) LoginWithCode(c *gin.Context) {
 		response.InternalServerError(c, "Login failed", err)
 		return
 	}
+	if rejectBlocklistedLogin(c, h.authService, user) {
+		return
+	}
+	if respondTwoFactorChallenge(c, user) {
+		return
+	}
 
 	user.LastLoginIP = utils.GetRealIP(c)
 	h.authService.UpdateLoginIP(user)
@@ -1307,7 +1359,7 @@ func (h *AuthHandler) SendPhoneLoginCode(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -1318,14 +1370,9 @@ func (h *AuthHandler) SendPhoneLoginCode(c *gin.Context) {
 	}
 
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("phone_login_cooldown:ip:%s", ip)
-	phoneKey := fmt.Sprintf("phone_login_cooldown:phone:%s", req.Phone)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(phoneKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposePhoneLogin, ip, req.Phone) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
 	if h.captchaService.NeedCaptcha("login") {
@@ -1338,8 +1385,7 @@ func (h *AuthHandler) SendPhoneLoginCode(c *gin.Context) {
 			return
 		}
 	}
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(phoneKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposePhoneLogin, ip, req.Phone)
 
 	code, err := h.authService.SendPhoneLoginCode(req.Phone)
 	if err != nil {
@@ -1370,7 +1416,7 @@ func (h *AuthHandler) LoginWithPhoneCode(c *gin.Context) {
 		Code      string `json:"code" binding:"required,len=6"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	phone := strings.TrimSpace(req.Phone)
@@ -1413,6 +1459,12 @@ func (h *AuthHandler) LoginWithPhoneCode(c *gin.Context) {
 		response.InternalServerError(c, "Login failed", err)
 		return
 	}
+	if rejectBlocklistedLogin(c, h.authService, user) {
+		return
+	}
+	if respondTwoFactorChallenge(c, user) {
+		return
+	}
 	user.LastLoginIP = utils.GetRealIP(c)
 	h.authService.UpdateLoginIP(user)
 
@@ -1476,7 +1528,7 @@ func (h *AuthHandler) PhoneRegister(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -1536,15 +1588,20 @@ func (h *AuthHandler) PhoneRegister(c *gin.Context) {
 		respondAuthBizError(c, authbiz.InvalidPhoneFormat(), nil)
 		return
 	}
+	if err := h.authService.CheckBlocklist(models.BlocklistScopeRegister, utils.GetRealIP(c), "", req.Phone); err != nil {
+		if !respondAuthBizError(c, err, nil) {
+			response.InternalError(c, "Registration failed")
+		}
+		return
+	}
 
 	// Verify SMS code
-	key := "phone_register_code:" + req.Phone
-	storedCode, err := cache.Get(key)
-	if err != nil || storedCode != req.Code {
-		respondAuthBizError(c, authbiz.CodeExpired(), nil)
+	if err := h.authService.VerifyPhoneRegisterCode(req.Phone, req.Code); err != nil {
+		if !respondAuthBizError(c, err, nil) {
+			response.InternalServerError(c, "Verification failed", err)
+		}
 		return
 	}
-	_ = cache.Del(key)
 
 	user, err := h.authService.Register("", req.Phone, req.Name, req.Password)
 	if err != nil {
@@ -1628,7 +1685,7 @@ func (h *AuthHandler) PhoneForgotPassword(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -1678,14 +1735,9 @@ func (h *AuthHandler) PhoneForgotPassword(c *gin.Context) {
 		return
 	}
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("phone_reset_cooldown:ip:%s", ip)
-	phoneKey := fmt.Sprintf("phone_reset_cooldown:phone:%s", req.Phone)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(phoneKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposePhoneReset, ip, req.Phone) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
 	if h.captchaService.NeedCaptcha("login") {
@@ -1698,8 +1750,7 @@ func (h *AuthHandler) PhoneForgotPassword(c *gin.Context) {
 			return
 		}
 	}
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(phoneKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposePhoneReset, ip, req.Phone)
 	code, err := h.authService.GeneratePhoneResetCode(req.Phone)
 	if err == nil && h.smsService != nil {
 		go h.smsService.SendVerificationCode(req.Phone, req.PhoneCode, code, "reset_password")
@@ -1733,7 +1784,7 @@ func (h *AuthHandler) PhoneResetPassword(c *gin.Context) {
 		NewPassword string `json:"new_password" binding:"required,min=8"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	phone := strings.TrimSpace(req.Phone)
@@ -1803,7 +1854,7 @@ func (h *AuthHandler) SendBindEmailCode(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -1856,18 +1907,12 @@ func (h *AuthHandler) SendBindEmailCode(c *gin.Context) {
 	}
 
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("bind_email_cooldown:ip:%s", ip)
-	emailKey := fmt.Sprintf("bind_email_cooldown:email:%s", req.Email)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(emailKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposeBindEmail, ip, req.Email) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(emailKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposeBindEmail, ip, req.Email)
 
 	code, err := h.authService.SendBindEmailCode(userID, req.Email)
 	if err != nil {
@@ -1917,7 +1962,7 @@ func (h *AuthHandler) BindEmail(c *gin.Context) {
 		Code  string `json:"code" binding:"required,len=6"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -1997,7 +2042,7 @@ func (h *AuthHandler) SendBindPhoneCode(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -2065,18 +2110,12 @@ func (h *AuthHandler) SendBindPhoneCode(c *gin.Context) {
 	}
 
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("bind_phone_cooldown:ip:%s", ip)
-	phoneKey := fmt.Sprintf("bind_phone_cooldown:phone:%s", req.Phone)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposeBindPhone, ip, req.Phone) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
-	if n, _ := cache.Exists(phoneKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(phoneKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposeBindPhone, ip, req.Phone)
 
 	code, err := h.authService.SendBindPhoneCode(userID, req.Phone)
 	if err != nil {
@@ -2121,7 +2160,7 @@ func (h *AuthHandler) BindPhone(c *gin.Context) {
 		Code  string `json:"code" binding:"required,len=6"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -2207,7 +2246,7 @@ func (h *AuthHandler) SendPhoneRegisterCode(c *gin.Context) {
 		CaptchaToken string `json:"captcha_token"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	req.Phone = strings.TrimSpace(req.Phone)
@@ -2218,14 +2257,9 @@ func (h *AuthHandler) SendPhoneRegisterCode(c *gin.Context) {
 	}
 
 	ip := utils.GetRealIP(c)
-	ipKey := fmt.Sprintf("phone_register_cooldown:ip:%s", ip)
-	phoneKey := fmt.Sprintf("phone_register_cooldown:phone:%s", req.Phone)
-	if n, _ := cache.Exists(ipKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
-		return
-	}
-	if n, _ := cache.Exists(phoneKey); n > 0 {
-		response.Error(c, 429, response.CodeCooldown, "Please wait 60 seconds before requesting again")
+	otp := h.authService.OTP()
+	if otp.InCooldown(service.OTPPurposePhoneRegister, ip, req.Phone) {
+		response.Error(c, 429, response.CodeCooldown, otp.CooldownMessage())
 		return
 	}
 	if h.captchaService.NeedCaptcha("register") {
@@ -2238,8 +2272,7 @@ func (h *AuthHandler) SendPhoneRegisterCode(c *gin.Context) {
 			return
 		}
 	}
-	cache.Set(ipKey, "1", 60*time.Second)
-	cache.Set(phoneKey, "1", 60*time.Second)
+	otp.StartCooldown(service.OTPPurposePhoneRegister, ip, req.Phone)
 
 	code, err := h.authService.SendPhoneRegisterCode(req.Phone)
 	if err != nil {
@@ -12,7 +12,7 @@ func respondAuthValidationOrInternalError(c *gin.Context, err error, fallback st
 	}
 
 	if bizErr := password.ToBizError(err); bizErr != nil {
-		response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+		response.RespondBizError(c, bizErr)
 		return
 	}
 
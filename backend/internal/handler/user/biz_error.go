@@ -18,6 +18,6 @@ func respondUserBizError(c *gin.Context, err error) bool {
 		return false
 	}
 
-	response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+	response.RespondBizError(c, bizErr)
 	return true
 }
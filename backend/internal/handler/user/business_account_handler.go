@@ -0,0 +1,143 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type BusinessAccountHandler struct {
+	netTermsService *service.NetTermsService
+}
+
+func NewBusinessAccountHandler(netTermsService *service.NetTermsService) *BusinessAccountHandler {
+	return &BusinessAccountHandler{netTermsService: netTermsService}
+}
+
+// ApplyBusinessAccountRequest 申请企业账户请求
+type ApplyBusinessAccountRequest struct {
+	CompanyName    string `json:"company_name" binding:"required"`
+	TaxID          string `json:"tax_id"`
+	BillingEmail   string `json:"billing_email" binding:"omitempty,email"`
+	BillingAddress string `json:"billing_address"`
+}
+
+// GetAccount 当前用户的企业账户与额度，未申请时 account 为 null
+func (h *BusinessAccountHandler) GetAccount(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	account, err := h.netTermsService.GetAccountForUser(userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get business account")
+		return
+	}
+	response.Success(c, gin.H{"account": account})
+}
+
+// Apply 提交企业账户申请，管理员审核通过后才能使用账期付款
+func (h *BusinessAccountHandler) Apply(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req ApplyBusinessAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	account, err := h.netTermsService.Apply(userID, service.BusinessAccountApplication{
+		CompanyName:    req.CompanyName,
+		TaxID:          req.TaxID,
+		BillingEmail:   req.BillingEmail,
+		BillingAddress: req.BillingAddress,
+	})
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to submit business account application")
+		}
+		return
+	}
+	response.Success(c, gin.H{"account": account})
+}
+
+// ListInvoices 当前用户的账期发票
+func (h *BusinessAccountHandler) ListInvoices(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	page, limit := response.GetPagination(c)
+	invoices, total, err := h.netTermsService.ListInvoicesForUser(userID, c.Query("status"), page, limit)
+	if err != nil {
+		response.InternalError(c, "Failed to get invoices")
+		return
+	}
+	response.Paginated(c, invoices, page, limit, total)
+}
+
+// GetStatement 当前用户企业账户的对账单，日期格式 YYYY-MM-DD，默认为当月
+func (h *BusinessAccountHandler) GetStatement(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	if raw := strings.TrimSpace(c.Query("start")); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid period, expected YYYY-MM-DD")
+			return
+		}
+		start = parsed
+	}
+	if raw := strings.TrimSpace(c.Query("end")); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid period, expected YYYY-MM-DD")
+			return
+		}
+		end = parsed.AddDate(0, 0, 1)
+	}
+
+	statement, err := h.netTermsService.StatementForUser(userID, start, end)
+	if err != nil {
+		if errors.Is(err, service.ErrBusinessAccountNotFound) {
+			response.NotFound(c, "Business account not found")
+			return
+		}
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to generate statement")
+		}
+		return
+	}
+	response.Success(c, statement)
+}
+
+// PayOrderOnTerms 使用账期付款支付待付款订单，订单随即进入发货流程并开具发票
+func (h *BusinessAccountHandler) PayOrderOnTerms(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	invoice, err := h.netTermsService.PlaceOrderOnTerms(userID, c.Param("order_no"))
+	if err != nil {
+		if errors.Is(err, service.ErrNetTermsOrderNotFound) {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to pay order on net terms")
+		}
+		return
+	}
+	response.Success(c, gin.H{"invoice": invoice})
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CarrierTrackingHandler 接收物流服务商（17TRACK / AfterShip）的轨迹回调
+type CarrierTrackingHandler struct {
+	trackingService *service.CarrierTrackingService
+}
+
+func NewCarrierTrackingHandler(trackingService *service.CarrierTrackingService) *CarrierTrackingHandler {
+	return &CarrierTrackingHandler{trackingService: trackingService}
+}
+
+// HandleWebhook 校验签名后保存轨迹；未知单号同样返回成功，避免服务商反复重试
+func (h *CarrierTrackingHandler) HandleWebhook(c *gin.Context) {
+	rawBody, err := readPaymentWebhookBody(c.Request.Body, maxPaymentWebhookBodyBytes)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	updated, err := h.trackingService.HandleWebhook(c.Request.Header, rawBody)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCarrierTrackingDisabled):
+			response.NotFound(c, "Carrier tracking is not enabled")
+		case errors.Is(err, service.ErrCarrierTrackingSignatureInvalid):
+			response.Unauthorized(c, "Invalid webhook signature")
+		default:
+			response.InternalServerError(c, "Failed to process tracking webhook", err)
+		}
+		return
+	}
+	response.Success(c, gin.H{"updated": updated})
+}
@@ -72,7 +72,7 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 
 	var req AddToCartRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := buildUserHookExecutionContext(c, userID, map[string]string{
@@ -124,7 +124,7 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 	if err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		response.HandleError(c, "Failed to add to cart", err)
@@ -178,7 +178,7 @@ func (h *CartHandler) UpdateQuantity(c *gin.Context) {
 
 	var req UpdateQuantityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := buildUserHookExecutionContext(c, userID, map[string]string{
@@ -232,7 +232,7 @@ func (h *CartHandler) UpdateQuantity(c *gin.Context) {
 	if err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		response.HandleError(c, "Failed to update quantity", err)
@@ -298,7 +298,7 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 	if err := h.cartService.RemoveFromCart(userID, uint(itemID)); err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		response.HandleError(c, "Failed to remove from cart", err)
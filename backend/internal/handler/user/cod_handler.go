@@ -0,0 +1,56 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CODHandler struct {
+	codService *service.CODService
+}
+
+func NewCODHandler(codService *service.CODService) *CODHandler {
+	return &CODHandler{codService: codService}
+}
+
+// GetEligibility 订单能否使用货到付款，不符合条件时返回原因
+func (h *CODHandler) GetEligibility(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	eligibility, err := h.codService.Eligibility(userID, c.Param("order_no"))
+	if err != nil {
+		if errors.Is(err, service.ErrCODOrderNotFound) {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		response.InternalError(c, "Failed to check cash on delivery eligibility")
+		return
+	}
+	response.Success(c, eligibility)
+}
+
+// PayOrderCOD 选择货到付款，订单随即进入发货流程，签收时付款
+func (h *CODHandler) PayOrderCOD(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	collection, err := h.codService.PlaceOrderCOD(userID, c.Param("order_no"))
+	if err != nil {
+		if errors.Is(err, service.ErrCODOrderNotFound) {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to place order on cash on delivery")
+		}
+		return
+	}
+	response.Success(c, gin.H{"collection": collection})
+}
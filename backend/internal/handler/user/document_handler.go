@@ -0,0 +1,138 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentHandler 用户下载订单的自定义文档（保修卡、送货单、正品证书等）
+type DocumentHandler struct {
+	documentService *service.DocumentService
+	cfg             *config.Config
+}
+
+func NewDocumentHandler(documentService *service.DocumentService, cfg *config.Config) *DocumentHandler {
+	return &DocumentHandler{documentService: documentService, cfg: cfg}
+}
+
+// loadOwnOrder 读取当前用户的订单
+func (h *DocumentHandler) loadOwnOrder(c *gin.Context) (uint, *models.Order, bool) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return 0, nil, false
+	}
+	order, err := h.documentService.OrderByNo(c.Param("order_no"))
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return 0, nil, false
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		response.Forbidden(c, "No permission to access this order")
+		return 0, nil, false
+	}
+	return userID, order, true
+}
+
+// ListOrderDocuments 订单当前可下载的文档
+func (h *DocumentHandler) ListOrderDocuments(c *gin.Context) {
+	_, order, ok := h.loadOwnOrder(c)
+	if !ok {
+		return
+	}
+	documents, err := h.documentService.AvailableForOrder(order, true)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, documents)
+}
+
+// GetDocumentToken 生成一次性文档下载令牌（60秒有效，单次使用）
+func (h *DocumentHandler) GetDocumentToken(c *gin.Context) {
+	userID, order, ok := h.loadOwnOrder(c)
+	if !ok {
+		return
+	}
+	docType, err := h.documentService.GetByKey(c.Param("key"))
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Query failed")
+		}
+		return
+	}
+	itemIndex, _ := strconv.Atoi(c.DefaultQuery("item", "0"))
+	if err := h.documentService.CheckAvailable(docType, order, itemIndex, true); err != nil {
+		respondUserBizError(c, err)
+		return
+	}
+
+	token, err := h.documentService.IssueDownloadToken(userID, docType, order, itemIndex)
+	if err != nil {
+		response.InternalError(c, "Failed to generate download token")
+		return
+	}
+	response.Success(c, gin.H{
+		"token": token,
+	})
+}
+
+// ViewDocumentByToken 通过一次性令牌查看文档（无需JWT认证），format=pdf 时输出 PDF
+func (h *DocumentHandler) ViewDocumentByToken(c *gin.Context) {
+	download, err := h.documentService.ConsumeDownloadToken(c.Param("token"))
+	if err != nil {
+		c.String(401, "Invalid or expired download token")
+		return
+	}
+
+	order, err := h.documentService.OrderByNo(download.OrderNo)
+	if err != nil {
+		c.String(404, "Order not found")
+		return
+	}
+	if order.UserID == nil || *order.UserID != download.UserID {
+		c.String(403, "No permission")
+		return
+	}
+	docType, err := h.documentService.GetByKey(download.Key)
+	if err != nil {
+		c.String(404, "Document not found")
+		return
+	}
+	// 令牌签发后文档可能被停用或订单状态已变化，下载时重新校验
+	if err := h.documentService.CheckAvailable(docType, order, download.ItemIndex, true); err != nil {
+		c.String(400, "Document is not available for this order")
+		return
+	}
+
+	asPDF := c.Query("format") == "pdf"
+	if asPDF && !service.InvoicePDFEnabled(&h.cfg.Order.Invoice) {
+		c.String(400, "PDF documents are not enabled")
+		return
+	}
+	html, err := h.documentService.Render(docType, order, download.ItemIndex, asPDF)
+	if err != nil {
+		log.Printf("user.view_document_by_token failed to render document: order=%s key=%s err=%v", order.OrderNo, docType.Key, err)
+		c.String(500, "Failed to render document")
+		return
+	}
+	if !asPDF {
+		c.Data(200, "text/html; charset=utf-8", html)
+		return
+	}
+	pdf, err := service.RenderInvoicePDF(c.Request.Context(), &h.cfg.Order.Invoice, html)
+	if err != nil {
+		log.Printf("user.view_document_by_token failed to render PDF: order=%s key=%s err=%v", order.OrderNo, docType.Key, err)
+		c.String(500, "Failed to render document PDF")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", service.DocumentNo(docType, order, download.ItemIndex)+".pdf"))
+	c.Data(200, "application/pdf", pdf)
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type GiftCardHandler struct {
+	giftCardService *service.GiftCardService
+}
+
+func NewGiftCardHandler(giftCardService *service.GiftCardService) *GiftCardHandler {
+	return &GiftCardHandler{giftCardService: giftCardService}
+}
+
+// CheckGiftCardRequest 查询礼品卡余额请求
+type CheckGiftCardRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+}
+
+// Check 结账前查询礼品卡可用余额
+func (h *GiftCardHandler) Check(c *gin.Context) {
+	var req CheckGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	balance, err := h.giftCardService.Check(req.Code)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to check gift card")
+		}
+		return
+	}
+	response.Success(c, balance)
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// InboundWebhookHandler 接收第三方系统推送到入站 Webhook 的事件
+type InboundWebhookHandler struct {
+	webhookService *service.InboundWebhookService
+}
+
+func NewInboundWebhookHandler(webhookService *service.InboundWebhookService) *InboundWebhookHandler {
+	return &InboundWebhookHandler{webhookService: webhookService}
+}
+
+// HandleWebhook 校验来源后写入收件箱并执行动作；动作失败同样返回成功，由管理员在收件箱中重放，
+// 避免第三方反复重试
+func (h *InboundWebhookHandler) HandleWebhook(c *gin.Context) {
+	rawBody, err := readPaymentWebhookBody(c.Request.Body, maxPaymentWebhookBodyBytes)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	event, duplicate, err := h.webhookService.Receive(c.Param("slug"), c.Request.Header, c.Request.URL.Query(), rawBody)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInboundWebhookNotFound):
+			response.NotFound(c, "Webhook not found")
+		case errors.Is(err, service.ErrInboundWebhookSignatureInvalid):
+			response.Unauthorized(c, "Invalid webhook signature")
+		default:
+			response.InternalServerError(c, "Failed to process webhook", err)
+		}
+		return
+	}
+	response.Success(c, gin.H{"event_id": event.ID, "status": event.Status, "duplicate": duplicate})
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// LicenseHandler 商户软件调用的卡密设备激活接口，凭卡密本身鉴权
+type LicenseHandler struct {
+	bindingService *service.BindingService
+}
+
+func NewLicenseHandler(bindingService *service.BindingService) *LicenseHandler {
+	return &LicenseHandler{bindingService: bindingService}
+}
+
+// LicenseDeviceRequest 激活/校验/解绑请求
+type LicenseDeviceRequest struct {
+	LicenseKey string `json:"license_key" binding:"required"`
+	DeviceID   string `json:"device_id" binding:"required"`
+	DeviceName string `json:"device_name"`
+}
+
+// LicenseTransferRequest 设备迁移请求
+type LicenseTransferRequest struct {
+	LicenseKey   string `json:"license_key" binding:"required"`
+	FromDeviceID string `json:"from_device_id" binding:"required"`
+	DeviceID     string `json:"device_id" binding:"required"`
+	DeviceName   string `json:"device_name"`
+}
+
+func (r *LicenseDeviceRequest) device(c *gin.Context) service.LicenseDevice {
+	return service.LicenseDevice{DeviceID: r.DeviceID, DeviceName: r.DeviceName, IPAddress: utils.GetRealIP(c)}
+}
+
+func respondLicenseResult(c *gin.Context, status *service.LicenseStatus, err error, fallback string) {
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+		return
+	}
+	response.Success(c, status)
+}
+
+// Activate 在设备上激活卡密
+func (h *LicenseHandler) Activate(c *gin.Context) {
+	var req LicenseDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	status, err := h.bindingService.ActivateLicense(req.LicenseKey, req.device(c))
+	respondLicenseResult(c, status, err, "Failed to activate license")
+}
+
+// Validate 校验设备是否仍处于激活状态
+func (h *LicenseHandler) Validate(c *gin.Context) {
+	var req LicenseDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	status, err := h.bindingService.ValidateLicense(req.LicenseKey, req.device(c))
+	respondLicenseResult(c, status, err, "Failed to validate license")
+}
+
+// Deactivate 解绑当前设备
+func (h *LicenseHandler) Deactivate(c *gin.Context) {
+	var req LicenseDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	status, err := h.bindingService.DeactivateLicense(req.LicenseKey, req.device(c))
+	respondLicenseResult(c, status, err, "Failed to deactivate license")
+}
+
+// Transfer 将激活从旧设备迁移到当前设备
+func (h *LicenseHandler) Transfer(c *gin.Context) {
+	var req LicenseTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	device := service.LicenseDevice{DeviceID: req.DeviceID, DeviceName: req.DeviceName, IPAddress: utils.GetRealIP(c)}
+	status, err := h.bindingService.TransferLicense(req.LicenseKey, req.FromDeviceID, device)
+	respondLicenseResult(c, status, err, "Failed to transfer license")
+}
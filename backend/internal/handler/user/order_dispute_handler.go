@@ -0,0 +1,103 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OrderDisputeHandler struct {
+	disputeService *service.OrderDisputeService
+	db             *gorm.DB
+}
+
+func NewOrderDisputeHandler(disputeService *service.OrderDisputeService, db *gorm.DB) *OrderDisputeHandler {
+	return &OrderDisputeHandler{disputeService: disputeService, db: db}
+}
+
+// EscalateDisputeRequest 升级争议请求
+type EscalateDisputeRequest struct {
+	OrderNo   string   `json:"order_no"` // 为空时使用工单中最近分享的订单
+	Statement string   `json:"statement" binding:"required"`
+	Evidence  []string `json:"evidence"` // 通过 /tickets/attachments 预先上传的凭证截图URL
+}
+
+func respondOrderDisputeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrDisputeTicketNotFound):
+		response.NotFound(c, "Ticket not found")
+	case errors.Is(err, service.ErrDisputeOrderNotFound):
+		response.NotFound(c, "Order not found")
+	default:
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// GetDispute 工单的争议记录或升级资格
+func (h *OrderDisputeHandler) GetDispute(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	ticketID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+	result, err := h.disputeService.GetForUser(userID, ticketID)
+	if err != nil {
+		respondOrderDisputeError(c, err, "Failed to get dispute")
+		return
+	}
+	response.Success(c, result)
+}
+
+// EscalateDispute 将长时间未解决的工单升级为平台争议
+func (h *OrderDisputeHandler) EscalateDispute(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	ticketID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+	var req EscalateDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	evidence, err := normalizeTicketIntakeAttachments(config.GetConfig(), req.Evidence)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.BadRequest(c, "Invalid evidence attachments")
+		}
+		return
+	}
+
+	dispute, err := h.disputeService.Escalate(userID, ticketID, service.EscalateDisputeInput{
+		OrderNo:   req.OrderNo,
+		Statement: validator.SanitizeMarkdown(req.Statement),
+		Evidence:  evidence,
+	})
+	if err != nil {
+		respondOrderDisputeError(c, err, "Failed to escalate dispute")
+		return
+	}
+	logger.LogOperation(h.db, c, "escalate_dispute", "order_dispute", &dispute.ID, map[string]interface{}{
+		"dispute_no": dispute.DisputeNo,
+		"ticket_no":  dispute.TicketNo,
+		"order_no":   dispute.OrderNo,
+	})
+	response.Success(c, dispute)
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"auralogic/internal/database"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/repository"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportOrders 导出当前用户的订单（CSV 或 Excel），支持按创建日期、状态与 SKU 筛选，流式写出
+func (h *OrderHandler) ExportOrders(c *gin.Context) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return
+	}
+
+	format, err := service.NormalizeOrderExportFormat(c.Query("format"), service.OrderExportFormatCSV)
+	if err != nil {
+		response.HandleError(c, "Export failed", err)
+		return
+	}
+	from, to, err := service.ParseOrderExportDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		response.HandleError(c, "Export failed", err)
+		return
+	}
+
+	fileName := fmt.Sprintf("orders_%s.%s", time.Now().Format("20060102_150405"), format)
+	c.Header("Content-Type", service.OrderExportContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	c.Header("Cache-Control", "no-store")
+	exportedCount, err := service.NewOrderExportService(database.GetDB()).Export(c.Request.Context(), c.Writer, service.OrderExportRequest{
+		Format:    format,
+		SheetName: "Orders",
+		Filter: repository.OrderExportFilter{
+			UserID: &userID,
+			Status: strings.TrimSpace(c.Query("status")),
+			SKU:    strings.TrimSpace(c.Query("sku")),
+			From:   from,
+			To:     to,
+		},
+		Columns: service.UserOrderExportColumns(),
+	})
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			response.InternalError(c, "Export failed")
+			return
+		}
+		log.Printf("user order export aborted: user=%d exported=%d err=%v", userID, exportedCount, err)
+	}
+}
@@ -1,22 +1,22 @@
 package user
 
 import (
-	"bytes"
 	crand "crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"html/template"
 	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"auralogic/internal/config"
+	"auralogic/internal/database"
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/money"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/pkg/utils"
@@ -29,7 +29,10 @@ type OrderHandler struct {
 	orderService            *service.OrderService
 	bindingService          *service.BindingService
 	virtualInventoryService *service.VirtualInventoryService
+	revealService           *service.VirtualStockRevealService
 	pluginManager           *service.PluginManagerService
+	blocklist               *service.BlocklistService
+	usageMeters             *service.UsageMeterService
 	cfg                     *config.Config
 }
 
@@ -37,6 +40,7 @@ func NewOrderHandler(
 	orderService *service.OrderService,
 	bindingService *service.BindingService,
 	virtualInventoryService *service.VirtualInventoryService,
+	revealService *service.VirtualStockRevealService,
 	pluginManager *service.PluginManagerService,
 	cfg *config.Config,
 ) *OrderHandler {
@@ -44,6 +48,7 @@ func NewOrderHandler(
 		orderService:            orderService,
 		bindingService:          bindingService,
 		virtualInventoryService: virtualInventoryService,
+		revealService:           revealService,
 		pluginManager:           pluginManager,
 		cfg:                     cfg,
 	}
@@ -54,6 +59,35 @@ type CreateOrderRequest struct {
 	Items     []models.OrderItem `json:"items" binding:"required"`
 	Remark    string             `json:"remark"`
 	PromoCode string             `json:"promo_code"`
+	// GiftCardCode 礼品卡卡号，抵扣金额为卡内可用余额与应付金额的较小值
+	GiftCardCode string `json:"gift_card_code"`
+}
+
+// SetBlocklist 设置下单时检查的黑名单
+func (h *OrderHandler) SetBlocklist(blocklist *service.BlocklistService) {
+	h.blocklist = blocklist
+}
+
+// SetUsageMeterService 设置订单详情展示的卡密用量计量服务
+func (h *OrderHandler) SetUsageMeterService(usageMeters *service.UsageMeterService) {
+	h.usageMeters = usageMeters
+}
+
+// checkEmailVerification 按邮箱验证限制方式校验当前用户，失败时已写入响应
+func (h *OrderHandler) checkEmailVerification(c *gin.Context, userID uint, action string) bool {
+	if service.ResolveEmailVerificationMode(h.cfg) == "" {
+		return true
+	}
+	var user models.User
+	if err := database.GetDB().First(&user, userID).Error; err != nil {
+		response.Unauthorized(c, "User not found")
+		return false
+	}
+	if err := service.CheckEmailVerification(h.cfg, &user, action); err != nil {
+		respondAuthBizError(c, err, nil)
+		return false
+	}
+	return true
 }
 
 // CreateOrder CreateOrder
@@ -65,7 +99,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 
 	var req CreateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -74,6 +108,16 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	if !h.checkEmailVerification(c, userID, service.EmailVerificationModeCheckout) {
+		return
+	}
+	if err := h.blocklist.CheckUser(models.BlocklistScopeOrder, utils.GetRealIP(c), userID); err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to create order")
+		}
+		return
+	}
+
 	hookExecCtx := h.buildOrderHookExecutionContext(c, userID)
 	if h.pluginManager != nil {
 		originalReq := req
@@ -113,16 +157,17 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	}
 
 	// Create order draft (internal user)
-	order, err := h.orderService.CreateUserOrder(userID, req.Items, req.Remark, req.PromoCode)
+	order, err := h.orderService.CreateUserOrder(c.Request.Context(), userID, req.Items, req.Remark, req.PromoCode, req.GiftCardCode)
 	if err != nil {
 		var bizErr *bizerr.Error
 		if errors.As(err, &bizErr) {
-			response.BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+			response.RespondBizError(c, bizErr)
 			return
 		}
 		response.InternalError(c, "Failed to create order")
 		return
 	}
+	h.orderService.EvaluateOrderRisk(order, utils.GetRealIP(c))
 
 	if h.pluginManager != nil {
 		afterExecCtx := cloneExecutionContext(hookExecCtx)
@@ -134,6 +179,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 			"items":         order.Items,
 			"remark":        order.Remark,
 			"promo_code":    order.PromoCodeStr,
+			"gift_card":     order.GiftCardAmount > 0,
 			"total_amount":  order.TotalAmount,
 			"currency":      order.Currency,
 			"source":        "user_api",
@@ -152,10 +198,13 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	}
 
 	response.Success(c, gin.H{
-		"order_id":   order.ID,
-		"order_no":   order.OrderNo,
-		"status":     order.Status,
-		"created_at": order.CreatedAt,
+		"order_id":              order.ID,
+		"order_no":              order.OrderNo,
+		"status":                order.Status,
+		"created_at":            order.CreatedAt,
+		"total_amount_minor":    order.TotalAmount,
+		"discount_amount_minor": order.DiscountAmount,
+		"pricing_trace":         order.PricingTrace,
 	})
 }
 
@@ -181,6 +230,12 @@ func normalizeCreateOrderRequest(req *CreateOrderRequest) string {
 		return "Promo code length cannot exceed 50 characters"
 	}
 
+	// 清理礼品卡卡号
+	req.GiftCardCode = validator.SanitizeInput(req.GiftCardCode)
+	if !validator.ValidateLength(req.GiftCardCode, 0, 50) {
+		return "Gift card code length cannot exceed 50 characters"
+	}
+
 	return ""
 }
 
@@ -308,40 +363,27 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 		limit = 100
 	}
 
+	if cursor, ok := response.GetCursor(c); ok {
+		if limit < 1 {
+			limit = 10
+		}
+		orders, nextCursor, err := h.orderService.ListUserOrdersByCursor(userID, cursor, limit, status)
+		if err != nil {
+			response.HandleError(c, "Query failed", err)
+			return
+		}
+		response.CursorPaginated(c, orders, limit, nextCursor)
+		return
+	}
+
 	orders, total, err := h.orderService.ListUserOrders(userID, page, limit, status)
 	if err != nil {
 		response.InternalError(c, "Query failed")
 		return
 	}
 
-	// 获取所有订单的shared_to_support状态
-	orderIDs := make([]uint, len(orders))
-	for i, order := range orders {
-		orderIDs[i] = order.ID
-	}
-	sharedMap, _ := h.orderService.GetSharedOrderIDs(orderIDs)
-
-	// 构建带有shared_to_support标记的订单列表
-	type OrderWithShared struct {
-		models.Order
-		SharedToSupport bool `json:"shared_to_support"`
-	}
-	result := make([]OrderWithShared, len(orders))
-	for i, order := range orders {
-		// 未付款订单隐藏盲盒分配结果
-		if order.Status == models.OrderStatusPendingPayment ||
-			order.Status == models.OrderStatusDraft ||
-			order.Status == models.OrderStatusNeedResubmit ||
-			order.Status == models.OrderStatusCancelled {
-			order.ActualAttributes = ""
-		}
-		result[i] = OrderWithShared{
-			Order:           order,
-			SharedToSupport: sharedMap[order.ID],
-		}
-	}
-
-	response.Paginated(c, result, page, limit, total)
+	// 列表不加载盲盒分配结果（ItemAllocations），无需按付款状态隐藏
+	response.Paginated(c, orders, page, limit, total)
 }
 
 // GetOrder - Get order details
@@ -363,15 +405,13 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		return
 	}
 
-	// Check if order belongs to current user
-	if order.UserID == nil || *order.UserID != userID {
+	// Check if order belongs to current user; organization members can view shared orders
+	isOwner := order.UserID != nil && *order.UserID == userID
+	if !isOwner && !service.CanViewOrganizationOrder(database.GetDB(), userID, order) {
 		response.Forbidden(c, "No permission to access this order")
 		return
 	}
 
-	// 检查订单是否被分享到客服工单
-	sharedToSupport, _ := h.orderService.IsOrderSharedToSupport(order.ID)
-
 	// 处理盲盒属性：已付款订单将盲盒结果合并回items，未付款订单隐藏盲盒结果
 	isPaid := order.Status != models.OrderStatusPendingPayment &&
 		order.Status != models.OrderStatusDraft &&
@@ -379,28 +419,24 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		order.Status != models.OrderStatusCancelled
 
 	responseItems := order.Items
-	if isPaid && len(order.ActualAttributes) > 0 {
-		// 已付款：将 ActualAttributes 中的盲盒属性合并回 items
-		var actualMap map[string]map[string]interface{}
-		if err := json.Unmarshal([]byte(order.ActualAttributes), &actualMap); err == nil {
-			// 复制 items 以免修改原始数据
-			responseItems = make([]models.OrderItem, len(order.Items))
-			copy(responseItems, order.Items)
-			for idxStr, bbVals := range actualMap {
-				var idx int
-				if _, err := fmt.Sscanf(idxStr, "%d", &idx); err == nil && idx >= 0 && idx < len(responseItems) {
-					if responseItems[idx].Attributes == nil {
-						responseItems[idx].Attributes = make(map[string]interface{})
-					}
-					for k, v := range bbVals {
-						responseItems[idx].Attributes[k] = v
-					}
-				}
-			}
+	if isPaid {
+		// 已付款：将盲盒分配结果合并回 items
+		if err := h.orderService.LoadItemAllocations(order); err != nil {
+			log.Printf("user.get_order failed to load item allocations: order_id=%d err=%v", order.ID, err)
 		}
+		responseItems = order.ItemsWithAllocations()
 	}
 	// 未付款订单的 items 中不含盲盒属性（在 CreateUserOrder 中已剥离）
 
+	shipment, err := h.orderService.ShipmentTracking(order.ID)
+	if err != nil {
+		log.Printf("user.get_order failed to load shipment tracking: order_id=%d err=%v", order.ID, err)
+	} else if shipment != nil {
+		// 注册错误属于内部信息，不返回给用户
+		shipment.RegisterError = ""
+		shipment.RegisterAttempts = 0
+	}
+
 	response.Success(c, gin.H{
 		"id":                          order.ID,
 		"order_no":                    order.OrderNo,
@@ -420,6 +456,7 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		"privacy_protected":           order.PrivacyProtected,
 		"tracking_no":                 order.TrackingNo,
 		"shipped_at":                  order.ShippedAt,
+		"shipment":                    shipment,
 		"completed_at":                order.CompletedAt,
 		"form_submitted_at":           order.FormSubmittedAt,
 		"user_email":                  order.UserEmail,
@@ -427,9 +464,47 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		"total_amount_minor":          order.TotalAmount,
 		"currency":                    order.Currency,
 		"remark":                      order.Remark,
+		"organization_id":             order.OrganizationID,
 		"created_at":                  order.CreatedAt,
 		"updated_at":                  order.UpdatedAt,
-		"shared_to_support":           sharedToSupport,
+		"shared_to_support":           order.SharedToSupport,
+		"active_shares":               activeOrderShares(order.ID),
+	})
+}
+
+// GetOrderTimeline 订单时间线（状态变更、付款、发货记录），不含管理员内部备注
+func (h *OrderHandler) GetOrderTimeline(c *gin.Context) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return
+	}
+	orderNo := c.Param("order_no")
+	if orderNo == "" {
+		response.BadRequest(c, "Order number cannot be empty")
+		return
+	}
+
+	order, err := h.orderService.GetOrderByNo(orderNo)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+
+	isOwner := order.UserID != nil && *order.UserID == userID
+	if !isOwner && !service.CanViewOrganizationOrder(database.GetDB(), userID, order) {
+		response.Forbidden(c, "No permission to access this order")
+		return
+	}
+
+	events, err := h.orderService.GetOrderTimeline(order, false)
+	if err != nil {
+		log.Printf("user.get_order_timeline failed: order_id=%d err=%v", order.ID, err)
+		response.InternalError(c, "Failed to load order timeline")
+		return
+	}
+	response.Success(c, gin.H{
+		"order_no": order.OrderNo,
+		"events":   events,
 	})
 }
 
@@ -453,7 +528,7 @@ func (h *OrderHandler) CompleteOrder(c *gin.Context) {
 
 	var req CompleteOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -645,17 +720,54 @@ func (h *OrderHandler) GetVirtualProducts(c *gin.Context) {
 		return
 	}
 
+	if !h.checkEmailVerification(c, userID, service.EmailVerificationModeVirtualReveal) {
+		return
+	}
+
 	// Get virtual product stocks
 	if h.virtualInventoryService == nil {
 		response.Success(c, gin.H{"stocks": []interface{}{}})
 		return
 	}
 
+	// 高价值库存需要重新验证身份后才能查看
+	authMethod := ""
+	if h.revealService != nil {
+		requireReauth, reauthErr := h.revealService.RequiresReauth(order.ID)
+		if reauthErr != nil {
+			response.InternalError(c, "Failed to get virtual products")
+			return
+		}
+		if requireReauth {
+			authMethod = h.revealService.GrantedMethod(userID, order.ID)
+			if authMethod == "" {
+				response.Success(c, gin.H{
+					"stocks":          []interface{}{},
+					"reauth_required": true,
+				})
+				return
+			}
+		}
+	}
+
 	stocks, err := h.virtualInventoryService.GetStockByOrderNo(orderNo)
 	if err != nil {
 		response.InternalError(c, "Failed to get virtual products")
 		return
 	}
+	if len(stocks) > 0 {
+		if h.revealService != nil {
+			if throttleErr := h.revealService.Throttle(userID, order.ID); throttleErr != nil {
+				respondVirtualRevealError(c, throttleErr, "Failed to get virtual products")
+				return
+			}
+			h.recordVirtualStockReveal(c, order, userID, stocks, authMethod)
+		}
+		logger.LogOrderOperation(database.GetDB(), c, "view_virtual_stock", order.ID, map[string]interface{}{
+			"order_no": order.OrderNo,
+			"count":    len(stocks),
+		})
+	}
 
 	// 根据配置决定是否向用户展示虚拟产品备注
 	if !h.cfg.Order.ShowVirtualStockRemark {
@@ -709,13 +821,7 @@ type invoiceItem struct {
 // invoiceData 账单模板数据
 type invoiceData struct {
 	// 公司信息
-	CompanyName    string
-	CompanyAddress string
-	CompanyPhone   string
-	CompanyEmail   string
-	CompanyLogo    string
-	TaxID          string
-	FooterText     string
+	service.DocumentCompany
 	// 订单信息
 	InvoiceNo     string
 	OrderNo       string
@@ -732,6 +838,14 @@ type invoiceData struct {
 	Subtotal       string
 	DiscountAmount string
 	HasDiscount    bool
+	PaymentFee     string // 付款方式附加费（正数）或优惠（负数），带符号
+	HasPaymentFee  bool
+	Rounding       string // 价格取整差额，带符号
+	HasRounding    bool
+	Adjustment     string // 管理员手动调价差额，带符号
+	HasAdjustment  bool
+	GiftCard       string // 礼品卡抵扣金额（正数）
+	HasGiftCard    bool
 	TotalAmount    string
 	Currency       string
 	// 系统
@@ -740,72 +854,106 @@ type invoiceData struct {
 	CloseBtnText string
 }
 
-// DownloadInvoice 生成并返回订单账单 HTML
-func (h *OrderHandler) DownloadInvoice(c *gin.Context) {
+// loadInvoiceOrder 校验账单开关与订单归属，仅已完成的订单可生成账单
+func (h *OrderHandler) loadInvoiceOrder(c *gin.Context) (*models.Order, bool) {
 	userID, userIDOK := middleware.RequireUserID(c)
 	if !userIDOK {
-		return
+		return nil, false
 	}
 	orderNo := c.Param("order_no")
 
 	if orderNo == "" {
 		response.BadRequest(c, "Order number cannot be empty")
-		return
+		return nil, false
 	}
 
 	// 检查是否启用账单功能
-	invoiceCfg := h.cfg.Order.Invoice
-	if !invoiceCfg.Enabled {
+	if !h.cfg.Order.Invoice.Enabled {
 		response.BadRequest(c, "Invoice generation is not enabled")
-		return
+		return nil, false
 	}
 
 	order, err := h.orderService.GetOrderByNo(orderNo)
 	if err != nil {
 		response.NotFound(c, "Order not found")
-		return
+		return nil, false
 	}
 
 	// 检查订单归属
 	if order.UserID == nil || *order.UserID != userID {
 		response.Forbidden(c, "No permission to access this order")
-		return
+		return nil, false
 	}
 
 	// 只允许已完成的订单生成账单
 	if order.Status != models.OrderStatusCompleted {
 		response.BadRequest(c, "Invoice is only available for completed orders")
-		return
+		return nil, false
 	}
+	return order, true
+}
 
-	// 构建模板数据
-	data := h.buildInvoiceData(order, &invoiceCfg)
+// renderInvoiceHTML 按内置或自定义模板渲染账单 HTML；inlineAssets 为 true 时模板资源内联为 data URI，供 PDF 渲染使用
+func (h *OrderHandler) renderInvoiceHTML(order *models.Order, inlineAssets bool) ([]byte, *invoiceData, error) {
+	invoiceCfg := h.cfg.Order.Invoice
+	data := h.buildInvoiceData(order, &invoiceCfg, inlineAssets)
 
 	// 选择模板
-	var tmplStr string
+	tmplStr := builtinInvoiceTemplate
 	if invoiceCfg.TemplateType == "custom" && invoiceCfg.CustomTemplate != "" {
 		tmplStr = invoiceCfg.CustomTemplate
-	} else {
-		tmplStr = builtinInvoiceTemplate
 	}
 
-	tmpl, err := template.New("invoice").Parse(tmplStr)
+	html, err := service.RenderDocumentTemplate("invoice", tmplStr, data, inlineAssets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return html, &data, nil
+}
+
+// DownloadInvoice 生成并返回订单账单 HTML
+func (h *OrderHandler) DownloadInvoice(c *gin.Context) {
+	order, ok := h.loadInvoiceOrder(c)
+	if !ok {
+		return
+	}
+
+	html, _, err := h.renderInvoiceHTML(order, false)
 	if err != nil {
-		response.InternalError(c, "Failed to parse invoice template")
+		response.InternalServerError(c, "Failed to render invoice", err)
+		return
+	}
+
+	c.Data(200, "text/html; charset=utf-8", html)
+}
+
+// DownloadInvoicePDF 通过配置的无头浏览器将账单渲染为 PDF，便于作为邮件附件或归档
+func (h *OrderHandler) DownloadInvoicePDF(c *gin.Context) {
+	order, ok := h.loadInvoiceOrder(c)
+	if !ok {
+		return
+	}
+	if !service.InvoicePDFEnabled(&h.cfg.Order.Invoice) {
+		response.BadRequest(c, "PDF invoices are not enabled")
 		return
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		response.InternalError(c, "Failed to render invoice")
+	html, data, err := h.renderInvoiceHTML(order, true)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render invoice", err)
+		return
+	}
+	pdf, err := service.RenderInvoicePDF(c.Request.Context(), &h.cfg.Order.Invoice, html)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render invoice PDF", err)
 		return
 	}
 
-	c.Header("Content-Type", "text/html; charset=utf-8")
-	c.String(200, buf.String())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", data.InvoiceNo+".pdf"))
+	c.Data(200, "application/pdf", pdf)
 }
 
-func (h *OrderHandler) buildInvoiceData(order *models.Order, invoiceCfg *config.InvoiceConfig) invoiceData {
+func (h *OrderHandler) buildInvoiceData(order *models.Order, invoiceCfg *config.InvoiceConfig, inlineAssets bool) invoiceData {
 	currency := h.cfg.Order.Currency
 	if currency == "" {
 		currency = "CNY"
@@ -823,7 +971,19 @@ func (h *OrderHandler) buildInvoiceData(order *models.Order, invoiceCfg *config.
 
 	discount := order.DiscountAmount
 	total := order.TotalAmount
-	subtotal := total + discount
+	subtotal := total + discount + order.GiftCardAmount - order.PaymentFee - order.PriceRoundingAdjustment - order.ManualAdjustment
+	paymentFee := "+" + formatAmount(order.PaymentFee, currency)
+	if order.PaymentFee < 0 {
+		paymentFee = "-" + formatAmount(-order.PaymentFee, currency)
+	}
+	rounding := "+" + formatAmount(order.PriceRoundingAdjustment, currency)
+	if order.PriceRoundingAdjustment < 0 {
+		rounding = "-" + formatAmount(-order.PriceRoundingAdjustment, currency)
+	}
+	adjustment := "+" + formatAmount(order.ManualAdjustment, currency)
+	if order.ManualAdjustment < 0 {
+		adjustment = "-" + formatAmount(-order.ManualAdjustment, currency)
+	}
 
 	// 格式化日期
 	orderDate := order.CreatedAt.Format("2006-01-02")
@@ -865,13 +1025,7 @@ func (h *OrderHandler) buildInvoiceData(order *models.Order, invoiceCfg *config.
 	closeBtn := "Close"
 
 	return invoiceData{
-		CompanyName:     invoiceCfg.CompanyName,
-		CompanyAddress:  invoiceCfg.CompanyAddress,
-		CompanyPhone:    invoiceCfg.CompanyPhone,
-		CompanyEmail:    invoiceCfg.CompanyEmail,
-		CompanyLogo:     invoiceCfg.CompanyLogo,
-		TaxID:           invoiceCfg.TaxID,
-		FooterText:      invoiceCfg.FooterText,
+		DocumentCompany: service.NewDocumentCompany(invoiceCfg, inlineAssets),
 		InvoiceNo:       "INV-" + order.OrderNo,
 		OrderNo:         order.OrderNo,
 		OrderDate:       orderDate,
@@ -884,6 +1038,14 @@ func (h *OrderHandler) buildInvoiceData(order *models.Order, invoiceCfg *config.
 		Subtotal:        formatAmount(subtotal, currency),
 		DiscountAmount:  formatAmount(discount, currency),
 		HasDiscount:     discount > 0,
+		PaymentFee:      paymentFee,
+		HasPaymentFee:   order.PaymentFee != 0,
+		Rounding:        rounding,
+		HasRounding:     order.PriceRoundingAdjustment != 0,
+		Adjustment:      adjustment,
+		HasAdjustment:   order.ManualAdjustment != 0,
+		GiftCard:        formatAmount(order.GiftCardAmount, currency),
+		HasGiftCard:     order.GiftCardAmount > 0,
 		TotalAmount:     formatAmount(total, currency),
 		Currency:        currency,
 		AppName:         h.cfg.App.Name,
@@ -1011,29 +1173,14 @@ func (h *OrderHandler) ViewInvoiceByToken(c *gin.Context) {
 		return
 	}
 
-	data := h.buildInvoiceData(order, &invoiceCfg)
-
-	var tmplStr string
-	if invoiceCfg.TemplateType == "custom" && invoiceCfg.CustomTemplate != "" {
-		tmplStr = invoiceCfg.CustomTemplate
-	} else {
-		tmplStr = builtinInvoiceTemplate
-	}
-
-	tmpl, err := template.New("invoice").Parse(tmplStr)
+	html, _, err := h.renderInvoiceHTML(order, false)
 	if err != nil {
-		c.String(500, "Failed to parse invoice template")
-		return
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Printf("user.view_invoice_by_token failed to render invoice: order=%s err=%v", order.OrderNo, err)
 		c.String(500, "Failed to render invoice")
 		return
 	}
 
-	c.Header("Content-Type", "text/html; charset=utf-8")
-	c.String(200, buf.String())
+	c.Data(200, "text/html; charset=utf-8", html)
 }
 
 // builtinInvoiceTemplate 内置账单 HTML 模板
@@ -1142,6 +1289,10 @@ tbody td{padding:12px 16px;font-size:14px;border-bottom:1px solid #f0f0f0}
     <div class="totals">
       <div class="row"><span>Subtotal</span><span>{{.Subtotal}}</span></div>
       {{if .HasDiscount}}<div class="row discount"><span>Discount</span><span>-{{.DiscountAmount}}</span></div>{{end}}
+      {{if .HasPaymentFee}}<div class="row"><span>Payment Fee</span><span>{{.PaymentFee}}</span></div>{{end}}
+      {{if .HasRounding}}<div class="row"><span>Rounding</span><span>{{.Rounding}}</span></div>{{end}}
+      {{if .HasAdjustment}}<div class="row"><span>Adjustment</span><span>{{.Adjustment}}</span></div>{{end}}
+      {{if .HasGiftCard}}<div class="row discount"><span>Gift Card</span><span>-{{.GiftCard}}</span></div>{{end}}
       <div class="row total"><span>Total</span><span>{{.TotalAmount}}</span></div>
     </div>
   </div>
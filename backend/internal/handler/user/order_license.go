@@ -0,0 +1,57 @@
+package user
+
+import (
+	"errors"
+	"strconv"
+
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ListLicenseActivations 订单内卡密的设备激活历史
+func (h *OrderHandler) ListLicenseActivations(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+	activations, err := h.bindingService.ListOrderActivations(order.ID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": activations})
+}
+
+// DeactivateLicenseActivation 买家解绑不再使用的设备，释放名额后可在新设备上激活
+func (h *OrderHandler) DeactivateLicenseActivation(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+	activationID, err := strconv.ParseUint(c.Param("activation_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid activation ID")
+		return
+	}
+
+	activation, err := h.bindingService.DeactivateOrderActivation(order.ID, uint(activationID), models.LicenseDeactivatedByUser, *order.UserID)
+	if err != nil {
+		if errors.Is(err, service.ErrLicenseActivationNotFound) {
+			response.NotFound(c, "Activation not found")
+			return
+		}
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to deactivate device")
+		}
+		return
+	}
+	logger.LogOperation(database.GetDB(), c, "deactivate_license_device", "license_activation", &activation.ID, map[string]interface{}{
+		"order_no":  order.OrderNo,
+		"device_id": activation.DeviceID,
+	})
+	response.Success(c, activation)
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type OrderRefundHandler struct {
+	orderService *service.OrderService
+}
+
+func NewOrderRefundHandler(orderService *service.OrderService) *OrderRefundHandler {
+	return &OrderRefundHandler{orderService: orderService}
+}
+
+// OrderRefundItemRequest 部分退款的订单项
+type OrderRefundItemRequest struct {
+	ItemIndex int `json:"item_index"`
+	Quantity  int `json:"quantity"`
+}
+
+// CreateOrderRefundRequest 用户申请部分退款，金额按下单单价自动计算
+type CreateOrderRefundRequest struct {
+	Items  []OrderRefundItemRequest `json:"items" binding:"required"`
+	Reason string                   `json:"reason" binding:"required"`
+}
+
+// toOrderRefundItemInputs 转换为服务层参数
+func toOrderRefundItemInputs(items []OrderRefundItemRequest) []service.OrderRefundItemInput {
+	inputs := make([]service.OrderRefundItemInput, 0, len(items))
+	for _, item := range items {
+		inputs = append(inputs, service.OrderRefundItemInput{ItemIndex: item.ItemIndex, Quantity: item.Quantity})
+	}
+	return inputs
+}
+
+func (h *OrderRefundHandler) loadOwnedOrder(c *gin.Context, userID uint) (*models.Order, bool) {
+	order, err := h.orderService.GetOrderByNo(c.Param("order_no"))
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return nil, false
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		response.Forbidden(c, "No permission to operate this order")
+		return nil, false
+	}
+	return order, true
+}
+
+// CreateOrderRefund 对订单的部分商品申请退款，等待管理员审核
+func (h *OrderRefundHandler) CreateOrderRefund(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateOrderRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	order, ok := h.loadOwnedOrder(c, userID)
+	if !ok {
+		return
+	}
+
+	refund, err := h.orderService.RefundOrder(order.ID, toOrderRefundItemInputs(req.Items), 0, validator.SanitizeInput(req.Reason), service.OrderRefundRequester{
+		UserID: userID,
+		Role:   "user",
+	})
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to submit refund")
+		}
+		return
+	}
+	response.Success(c, refund)
+}
+
+// ListOrderRefunds 订单的部分退款记录
+func (h *OrderRefundHandler) ListOrderRefunds(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	order, ok := h.loadOwnedOrder(c, userID)
+	if !ok {
+		return
+	}
+	refunds, err := h.orderService.ListOrderRefunds(order.ID)
+	if err != nil {
+		response.InternalError(c, "Failed to get refunds")
+		return
+	}
+	response.Success(c, gin.H{"items": refunds})
+}
@@ -0,0 +1,121 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"auralogic/internal/database"
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// loadOwnedOrder 按订单号加载当前用户的订单，失败时已写入响应
+func (h *OrderHandler) loadOwnedOrder(c *gin.Context) (*models.Order, bool) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return nil, false
+	}
+	orderNo := c.Param("order_no")
+	if orderNo == "" {
+		response.BadRequest(c, "Order number cannot be empty")
+		return nil, false
+	}
+
+	order, err := h.orderService.GetOrderByNo(orderNo)
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return nil, false
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		response.Forbidden(c, "No permission to access this order")
+		return nil, false
+	}
+	return order, true
+}
+
+// activeOrderShares 订单详情中展示的生效分享，查询失败时返回空列表
+func activeOrderShares(orderID uint) []service.OrderShare {
+	shares, err := service.NewOrderShareService(database.GetDB()).ListActiveShares(orderID)
+	if err != nil {
+		log.Printf("user.order: failed to load active shares: order_id=%d err=%v", orderID, err)
+		return []service.OrderShare{}
+	}
+	return shares
+}
+
+// ListOrderShares 获取订单当前分享给客服的工单列表
+func (h *OrderHandler) ListOrderShares(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+
+	shares, err := service.NewOrderShareService(database.GetDB()).ListActiveShares(order.ID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, shares)
+}
+
+// UpdateOrderShare 修改订单分享的有效期和收货信息可见性
+func (h *OrderHandler) UpdateOrderShare(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+
+	var req UpdateOrderShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if req.ExpiresInHours != nil && (*req.ExpiresInHours < 0 || *req.ExpiresInHours > service.OrderShareMaxHours) {
+		response.BadRequest(c, fmt.Sprintf("Share duration must be between 0 and %d hours", service.OrderShareMaxHours))
+		return
+	}
+
+	access, err := service.NewOrderShareService(database.GetDB()).UpdateShare(uint(ticketID), order.ID, service.OrderShareUpdate{
+		ExpiresInHours:      req.ExpiresInHours,
+		IncludeReceiverInfo: req.IncludeReceiverInfo,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.NotFound(c, "Order share not found")
+			return
+		}
+		response.InternalError(c, "Failed to update order share")
+		return
+	}
+	response.Success(c, access)
+}
+
+// RevokeOrderShare 撤销订单在指定工单中的分享
+func (h *OrderHandler) RevokeOrderShare(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+
+	if err := service.NewOrderShareService(database.GetDB()).Revoke(uint(ticketID), order.ID); err != nil {
+		response.InternalError(c, "Failed to revoke")
+		return
+	}
+	response.Success(c, gin.H{"message": "Access revoked"})
+}
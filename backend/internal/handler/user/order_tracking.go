@@ -0,0 +1,58 @@
+package user
+
+import (
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OrderTrackingHandler 免登录订单查询（游客订单）
+type OrderTrackingHandler struct {
+	trackingService *service.OrderTrackingService
+}
+
+func NewOrderTrackingHandler(db *gorm.DB, cfg *config.Config) *OrderTrackingHandler {
+	return &OrderTrackingHandler{trackingService: service.NewOrderTrackingService(db, cfg)}
+}
+
+// LookupOrderTrackingRequest 凭订单号+邮箱查询订单
+type LookupOrderTrackingRequest struct {
+	OrderNo string `json:"order_no" binding:"required,max=50"`
+	Email   string `json:"email" binding:"required,max=255"`
+}
+
+// Lookup 凭订单号+下单邮箱查询订单状态（邮箱放在请求体中，避免出现在访问日志）
+func (h *OrderTrackingHandler) Lookup(c *gin.Context) {
+	var req LookupOrderTrackingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	view, err := h.trackingService.LookupByEmail(req.OrderNo, req.Email)
+	if err != nil {
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, view)
+}
+
+// GetBySignedLink 凭发货邮件中的签名链接查询订单状态
+func (h *OrderTrackingHandler) GetBySignedLink(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	view, err := h.trackingService.LookupBySignature(c.Query("order_no"), c.Query("expires"), c.Query("sig"))
+	if err != nil {
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, view)
+}
@@ -0,0 +1,25 @@
+package user
+
+import (
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ListUsageMeters 订单内卡密的用量与剩余额度
+func (h *OrderHandler) ListUsageMeters(c *gin.Context) {
+	order, ok := h.loadOwnedOrder(c)
+	if !ok {
+		return
+	}
+	if h.usageMeters == nil {
+		response.Success(c, gin.H{"items": []models.UsageMeter{}})
+		return
+	}
+	meters, err := h.usageMeters.ListOrderMeters(order.ID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, gin.H{"items": meters})
+}
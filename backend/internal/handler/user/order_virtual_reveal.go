@@ -0,0 +1,126 @@
+package user
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// respondVirtualRevealError 查看限流返回 429，验证码相关错误沿用认证错误映射
+func respondVirtualRevealError(c *gin.Context, err error, fallbackMsg string) {
+	var bizErr *bizerr.Error
+	if errors.As(err, &bizErr) && bizErr.Key == "order.virtualRevealRateLimited" {
+		response.ErrorWithData(c, http.StatusTooManyRequests, response.CodeTooManyRequests, bizErr.Message, gin.H{
+			"error_key": bizErr.Key,
+			"params":    bizErr.Params,
+		})
+		return
+	}
+	if respondAuthBizError(c, err, nil) {
+		return
+	}
+	response.InternalServerError(c, fallbackMsg, err)
+}
+
+// recordVirtualStockReveal 记录一次虚拟商品内容查看，写入失败不影响返回
+func (h *OrderHandler) recordVirtualStockReveal(c *gin.Context, order *models.Order, userID uint, stocks []models.VirtualProductStock, authMethod string) {
+	stockIDs := make([]uint, 0, len(stocks))
+	for _, stock := range stocks {
+		stockIDs = append(stockIDs, stock.ID)
+	}
+	if err := h.revealService.Record(service.VirtualStockRevealRecord{
+		OrderID:    order.ID,
+		OrderNo:    order.OrderNo,
+		UserID:     userID,
+		StockIDs:   stockIDs,
+		AuthMethod: authMethod,
+		IPAddress:  utils.GetRealIP(c),
+		UserAgent:  c.Request.UserAgent(),
+	}); err != nil {
+		log.Printf("record virtual stock reveal failed: order=%d user=%d err=%v", order.ID, userID, err)
+	}
+}
+
+// loadRevealOrder 校验订单归属与状态，失败时已写入响应
+func (h *OrderHandler) loadRevealOrder(c *gin.Context) (*models.Order, uint, bool) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return nil, 0, false
+	}
+	if h.revealService == nil {
+		response.NotFound(c, "Virtual products are not available")
+		return nil, 0, false
+	}
+
+	order, err := h.orderService.GetOrderByNo(c.Param("order_no"))
+	if err != nil {
+		response.NotFound(c, "Order not found")
+		return nil, 0, false
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		response.Forbidden(c, "No permission to access this order")
+		return nil, 0, false
+	}
+	if order.Status == models.OrderStatusPendingPayment || order.Status == models.OrderStatusDraft || order.Status == models.OrderStatusNeedResubmit {
+		response.BadRequest(c, "Virtual products are not available yet")
+		return nil, 0, false
+	}
+	return order, userID, true
+}
+
+// ReauthVirtualProducts 使用密码或邮箱验证码重新验证身份后查看虚拟商品
+func (h *OrderHandler) ReauthVirtualProducts(c *gin.Context) {
+	order, userID, ok := h.loadRevealOrder(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Password == "" && req.Code == "") {
+		response.BadRequest(c, "Password or verification code is required")
+		return
+	}
+
+	var err error
+	if req.Password != "" {
+		err = h.revealService.ReauthWithPassword(userID, order.ID, req.Password)
+	} else {
+		err = h.revealService.ReauthWithCode(userID, order.ID, req.Code)
+	}
+	if err != nil {
+		respondVirtualRevealError(c, err, "Verification failed")
+		return
+	}
+	response.Success(c, gin.H{"verified": true})
+}
+
+// SendVirtualProductsReauthCode 发送查看虚拟商品的邮箱验证码
+func (h *OrderHandler) SendVirtualProductsReauthCode(c *gin.Context) {
+	order, userID, ok := h.loadRevealOrder(c)
+	if !ok {
+		return
+	}
+
+	if cooling, message := h.revealService.CodeCooldown(utils.GetRealIP(c), userID, order.ID); cooling {
+		response.Error(c, http.StatusTooManyRequests, response.CodeCooldown, message)
+		return
+	}
+
+	maskedEmail, err := h.revealService.SendReauthCode(userID, order.ID)
+	if err != nil {
+		respondVirtualRevealError(c, err, "Failed to send verification code")
+		return
+	}
+	response.Success(c, gin.H{"email": maskedEmail})
+}
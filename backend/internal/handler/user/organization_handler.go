@@ -0,0 +1,282 @@
+package user
+
+import (
+	"errors"
+	"strconv"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	organizationService *service.OrganizationService
+}
+
+func NewOrganizationHandler(organizationService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{organizationService: organizationService}
+}
+
+// OrganizationNameRequest 创建或重命名组织请求
+type OrganizationNameRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// InviteOrganizationMemberRequest 邀请成员请求，spending_limit_minor 为每月消费限额（0 表示不限）
+type InviteOrganizationMemberRequest struct {
+	Email              string                  `json:"email" binding:"required"`
+	Role               models.OrganizationRole `json:"role" binding:"required"`
+	SpendingLimitMinor int64                   `json:"spending_limit_minor"`
+}
+
+// UpdateOrganizationMemberRequest 调整成员角色与限额，role 为 owner 时转让所有权
+type UpdateOrganizationMemberRequest struct {
+	Role               models.OrganizationRole `json:"role" binding:"required"`
+	SpendingLimitMinor int64                   `json:"spending_limit_minor"`
+}
+
+// AcceptOrganizationInvitationRequest 接受邀请请求
+type AcceptOrganizationInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func respondOrganizationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound):
+		response.NotFound(c, "You do not belong to an organization")
+	case errors.Is(err, service.ErrOrganizationMemberNotFound):
+		response.NotFound(c, "Member not found")
+	case errors.Is(err, service.ErrOrganizationInvitationNotFound):
+		response.NotFound(c, "Invitation not found")
+	default:
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+func parseOrganizationIDParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		response.BadRequest(c, "Invalid ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// GetOrganization 当前用户所在组织，未加入组织时 organization 为 null
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	overview, err := h.organizationService.GetForUser(userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get organization")
+		return
+	}
+	response.Success(c, gin.H{"organization": overview})
+}
+
+// CreateOrganization 创建组织，当前用户成为所有者
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req OrganizationNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, err := h.organizationService.Create(userID, req.Name)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to create organization")
+		return
+	}
+	response.Success(c, gin.H{"organization": overview})
+}
+
+// RenameOrganization 修改组织名称（仅所有者）
+func (h *OrganizationHandler) RenameOrganization(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req OrganizationNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, err := h.organizationService.Rename(userID, req.Name)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to update organization")
+		return
+	}
+	response.Success(c, gin.H{"organization": overview})
+}
+
+// ListInvitations 未处理的邀请（仅所有者）
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	invitations, err := h.organizationService.ListInvitations(userID)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to get invitations")
+		return
+	}
+	response.Success(c, gin.H{"items": invitations})
+}
+
+// InviteMember 通过邮件邀请成员（仅所有者）
+func (h *OrganizationHandler) InviteMember(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req InviteOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	invitation, err := h.organizationService.Invite(userID, req.Email, req.Role, req.SpendingLimitMinor)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to invite member")
+		return
+	}
+	response.Success(c, gin.H{"invitation": invitation})
+}
+
+// RevokeInvitation 撤销邀请（仅所有者）
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := parseOrganizationIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.organizationService.RevokeInvitation(userID, invitationID); err != nil {
+		respondOrganizationError(c, err, "Failed to revoke invitation")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// PreviewInvitation 查看邀请详情，仅被邀请邮箱对应的账户可查看
+func (h *OrganizationHandler) PreviewInvitation(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	invitation, err := h.organizationService.PreviewInvitation(userID, c.Query("token"))
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to get invitation")
+		return
+	}
+	response.Success(c, gin.H{"invitation": invitation})
+}
+
+// AcceptInvitation 接受邀请加入组织
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req AcceptOrganizationInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, err := h.organizationService.AcceptInvitation(userID, req.Token)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to accept invitation")
+		return
+	}
+	response.Success(c, gin.H{"organization": overview})
+}
+
+// UpdateMember 调整成员角色与每月消费限额（仅所有者）
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseOrganizationIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	var req UpdateOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, err := h.organizationService.UpdateMember(userID, memberUserID, service.OrganizationMemberUpdate{
+		Role:          req.Role,
+		SpendingLimit: req.SpendingLimitMinor,
+	})
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to update member")
+		return
+	}
+	response.Success(c, gin.H{"organization": overview})
+}
+
+// RemoveMember 移除成员（仅所有者）
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseOrganizationIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	if err := h.organizationService.RemoveMember(userID, memberUserID); err != nil {
+		respondOrganizationError(c, err, "Failed to remove member")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Leave 退出组织，所有者仅在没有其他成员时可退出（组织随之解散）
+func (h *OrganizationHandler) Leave(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.organizationService.RemoveMember(userID, userID); err != nil {
+		respondOrganizationError(c, err, "Failed to leave organization")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ListOrders 组织内所有成员的订单
+func (h *OrganizationHandler) ListOrders(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	page, limit := response.GetPagination(c)
+	var memberUserID uint
+	if raw := c.Query("user_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid user ID")
+			return
+		}
+		memberUserID = uint(parsed)
+	}
+	orders, total, err := h.organizationService.ListOrders(userID, c.Query("status"), memberUserID, page, limit)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to get organization orders")
+		return
+	}
+	response.Paginated(c, orders, page, limit, total)
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"errors"
+	"strconv"
+
+	"auralogic/internal/database"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PasskeyHandler struct {
+	passkeyService *service.PasskeyService
+	authService    *service.AuthService
+}
+
+func NewPasskeyHandler(passkeyService *service.PasskeyService, authService *service.AuthService) *PasskeyHandler {
+	return &PasskeyHandler{passkeyService: passkeyService, authService: authService}
+}
+
+// PasskeyRegisterFinishRequest 浏览器 navigator.credentials.create() 的结果，二进制字段为 base64url
+type PasskeyRegisterFinishRequest struct {
+	Name              string   `json:"name" binding:"max=100"`
+	ID                string   `json:"id" binding:"required,max=1400"`
+	ClientDataJSON    string   `json:"client_data_json" binding:"required,max=4096"`
+	AttestationObject string   `json:"attestation_object" binding:"required,max=16384"`
+	Transports        []string `json:"transports" binding:"max=8,dive,max=32"`
+}
+
+// PasskeyLoginFinishRequest 浏览器 navigator.credentials.get() 的结果，二进制字段为 base64url
+type PasskeyLoginFinishRequest struct {
+	SessionID         string `json:"session_id" binding:"required,max=64"`
+	ID                string `json:"id" binding:"required,max=1400"`
+	ClientDataJSON    string `json:"client_data_json" binding:"required,max=4096"`
+	AuthenticatorData string `json:"authenticator_data" binding:"required,max=4096"`
+	Signature         string `json:"signature" binding:"required,max=2048"`
+	UserHandle        string `json:"user_handle" binding:"max=128"`
+}
+
+func respondPasskeyError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrPasskeyUserNotFound) {
+		response.NotFound(c, "User not found")
+		return
+	}
+	if !respondUserBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// List 当前用户已注册的通行密钥
+func (h *PasskeyHandler) List(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	passkeys, err := h.passkeyService.List(userID)
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to get passkeys")
+		return
+	}
+	response.Success(c, gin.H{"items": passkeys})
+}
+
+// BeginRegistration 返回 navigator.credentials.create() 参数
+func (h *PasskeyHandler) BeginRegistration(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	options, err := h.passkeyService.BeginRegistration(userID)
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to start passkey registration")
+		return
+	}
+	response.Success(c, gin.H{"public_key": options})
+}
+
+// FinishRegistration 校验并保存新通行密钥
+func (h *PasskeyHandler) FinishRegistration(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req PasskeyRegisterFinishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	passkey, err := h.passkeyService.FinishRegistration(userID, req.Name, service.PasskeyRegistration{
+		ID:                req.ID,
+		ClientDataJSON:    req.ClientDataJSON,
+		AttestationObject: req.AttestationObject,
+		Transports:        req.Transports,
+	})
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to register passkey")
+		return
+	}
+	logger.LogOperation(database.GetDB(), c, "register_passkey", "user_passkey", &passkey.ID, map[string]interface{}{
+		"name": passkey.Name,
+	})
+	response.Success(c, passkey)
+}
+
+// Delete 删除通行密钥
+func (h *PasskeyHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		response.BadRequest(c, "Invalid passkey ID")
+		return
+	}
+	if err := h.passkeyService.Delete(userID, uint(id)); err != nil {
+		respondPasskeyError(c, err, "Failed to delete passkey")
+		return
+	}
+	passkeyID := uint(id)
+	logger.LogOperation(database.GetDB(), c, "delete_passkey", "user_passkey", &passkeyID, nil)
+	response.Success(c, nil)
+}
+
+// BeginLogin 返回登录仪式 ID 与 navigator.credentials.get() 参数
+func (h *PasskeyHandler) BeginLogin(c *gin.Context) {
+	sessionID, options, err := h.passkeyService.BeginLogin()
+	if err != nil {
+		respondPasskeyError(c, err, "Failed to start passkey login")
+		return
+	}
+	response.Success(c, gin.H{"session_id": sessionID, "public_key": options})
+}
+
+// FinishLogin 校验断言后签发完整会话，响应格式与密码登录一致；需绑定两步验证时返回受限令牌
+func (h *PasskeyHandler) FinishLogin(c *gin.Context) {
+	var req PasskeyLoginFinishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	user, err := h.passkeyService.FinishLogin(req.SessionID, service.PasskeyAssertion{
+		ID:                req.ID,
+		ClientDataJSON:    req.ClientDataJSON,
+		AuthenticatorData: req.AuthenticatorData,
+		Signature:         req.Signature,
+		UserHandle:        req.UserHandle,
+	})
+	if err != nil {
+		respondPasskeyError(c, err, "Passkey login failed")
+		return
+	}
+	if rejectBlocklistedLogin(c, h.authService, user) {
+		return
+	}
+	// 通行密钥已完成用户验证，无需再输入验证码；但被要求开启两步验证且尚未绑定的用户仍需先完成绑定
+	if service.TwoFactorChallengeScope(user) == jwt.ScopeTwoFactorSetup && respondTwoFactorChallenge(c, user) {
+		return
+	}
+	respondLoginSession(c, h.authService, user, nil)
+}
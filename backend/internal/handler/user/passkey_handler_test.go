@@ -0,0 +1,155 @@
+package user
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/webauthn"
+	"auralogic/internal/repository"
+	"auralogic/internal/service"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// passkeyCOSEKeyForTest 按 COSE_Key 编码 ES256 公钥
+func passkeyCOSEKeyForTest(key *ecdsa.PrivateKey) []byte {
+	point, _ := key.PublicKey.Bytes()
+	out := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	out = append(out, point[1:33]...)
+	out = append(out, 0x22, 0x58, 0x20)
+	return append(out, point[33:65]...)
+}
+
+func TestPasskeyFinishLoginRequiresTwoFactorSetup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.UserPasskey{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	oldDB := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = oldDB })
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	cfg := &config.Config{}
+	cfg.App.URL = "https://shop.example.com"
+	cfg.JWT = config.JWTConfig{Secret: "passkey-handler-test-secret", ExpireHours: 1}
+	cfg.Security.Login.AllowPasskeyLogin = true
+	jwt.InitJWT(&cfg.JWT)
+	passkeyService := service.NewPasskeyService(db, cfg)
+	handler := NewPasskeyHandler(passkeyService, service.NewAuthService(repository.NewUserRepository(db), cfg))
+	rp, err := webauthn.NewRelyingParty(cfg.App.URL, "Shop")
+	if err != nil {
+		t.Fatalf("relying party: %v", err)
+	}
+
+	login := func(user *models.User) map[string]interface{} {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("generate key: %v", err)
+		}
+		credentialID := webauthn.Encoding.EncodeToString([]byte(user.UUID))
+		passkey := models.UserPasskey{UserID: user.ID, Name: "Laptop", CredentialID: credentialID, PublicKey: passkeyCOSEKeyForTest(key), Algorithm: webauthn.AlgES256}
+		if err := db.Create(&passkey).Error; err != nil {
+			t.Fatalf("create passkey: %v", err)
+		}
+
+		sessionID, options, err := passkeyService.BeginLogin()
+		if err != nil {
+			t.Fatalf("begin login: %v", err)
+		}
+		clientDataJSON, _ := json.Marshal(map[string]string{"type": "webauthn.get", "challenge": options.Challenge, "origin": rp.Origin})
+		rpHash := sha256.Sum256([]byte(rp.ID))
+		authData := append(rpHash[:], webauthn.FlagUserPresent|webauthn.FlagUserVerified)
+		authData = binary.BigEndian.AppendUint32(authData, 1)
+		clientHash := sha256.Sum256(clientDataJSON)
+		digest := sha256.Sum256(append(append([]byte(nil), authData...), clientHash[:]...))
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+
+		body, _ := json.Marshal(PasskeyLoginFinishRequest{
+			SessionID:         sessionID,
+			ID:                credentialID,
+			ClientDataJSON:    webauthn.Encoding.EncodeToString(clientDataJSON),
+			AuthenticatorData: webauthn.Encoding.EncodeToString(authData),
+			Signature:         webauthn.Encoding.EncodeToString(signature),
+		})
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/api/user/auth/passkey/login/finish", bytes.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		handler.FinishLogin(ctx)
+
+		var resp struct {
+			Code int                    `json:"code"`
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if recorder.Code != http.StatusOK || resp.Code != 0 {
+			t.Fatalf("passkey login failed: status=%d body=%s", recorder.Code, recorder.Body.String())
+		}
+		return resp.Data
+	}
+
+	// 被要求开启两步验证但尚未绑定：只拿到绑定用的受限令牌
+	required := &models.User{UUID: "passkey-required", Email: "required@example.com", Role: "user", IsActive: true, TwoFactorRequired: true}
+	if err := db.Create(required).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	data := login(required)
+	if _, ok := data["token"]; ok || data["two_factor_required"] != true || data["two_factor_setup"] != true {
+		t.Fatalf("expected two-factor setup challenge, got %+v", data)
+	}
+	claims, err := jwt.ParseToken(data["two_factor_token"].(string))
+	if err != nil || claims.Scope != jwt.ScopeTwoFactorSetup {
+		t.Fatalf("expected setup-scoped token, got %+v err=%v", claims, err)
+	}
+
+	// 已绑定验证器：通行密钥的用户验证即视为第二因素，直接签发完整会话
+	enrolled := &models.User{UUID: "passkey-enrolled", Email: "enrolled@example.com", Role: "user", IsActive: true, TwoFactorRequired: true, TwoFactorEnabled: true}
+	if err := db.Create(enrolled).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	data = login(enrolled)
+	if token, _ := data["token"].(string); token == "" || data["two_factor_required"] != nil {
+		t.Fatalf("expected full session for enrolled user, got %+v", data)
+	}
+}
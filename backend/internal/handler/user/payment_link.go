@@ -0,0 +1,105 @@
+package user
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SelectPaymentLinkMethodRequest 凭付款链接选择付款方式
+type SelectPaymentLinkMethodRequest struct {
+	OrderNo         string `json:"order_no" binding:"required,max=50"`
+	Expires         string `json:"expires" binding:"required"`
+	Sig             string `json:"sig" binding:"required"`
+	PaymentMethodID uint   `json:"payment_method_id" binding:"required"`
+}
+
+func paymentLinkOrderUserID(order *models.Order) uint {
+	if order.UserID == nil {
+		return 0
+	}
+	return *order.UserID
+}
+
+// CreatePaymentLink 为自己的待付款订单生成付款链接，可转发给代付人
+func (h *PaymentMethodHandler) CreatePaymentLink(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+
+	var order models.Order
+	if err := h.db.Where("order_no = ? AND user_id = ?", c.Param("order_no"), userID).First(&order).Error; err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+
+	link, err := h.linkService.Generate(&order)
+	if err != nil {
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to generate payment link")
+		return
+	}
+	logger.LogOrderOperation(h.db, c, "payment_link_created", order.ID, map[string]interface{}{
+		"order_no":   order.OrderNo,
+		"expires_at": link.ExpiresAt,
+	})
+	response.Success(c, link)
+}
+
+// GetByPaymentLink 凭签名付款链接查看订单与付款信息（无需登录）
+func (h *PaymentMethodHandler) GetByPaymentLink(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	order, err := h.linkService.Resolve(c.Query("order_no"), c.Query("expires"), c.Query("sig"))
+	if err != nil {
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+
+	result := gin.H{"order": service.BuildPublicPaymentLinkOrder(order)}
+	// 仅待付款订单返回付款信息，已付款或已取消的订单只展示状态
+	if order.Status == models.OrderStatusPendingPayment {
+		info, err := h.orderPaymentInfo(order)
+		if err != nil {
+			response.HandleError(c, "Failed to get payment info", err)
+			return
+		}
+		methods, err := h.enabledMethodItems()
+		if err != nil {
+			response.InternalError(c, "Failed to get payment methods")
+			return
+		}
+		result["payment"] = info
+		result["methods"] = methods
+	}
+	response.Success(c, result)
+}
+
+// SelectByPaymentLink 凭签名付款链接选择付款方式并获取付款卡片（无需登录）
+func (h *PaymentMethodHandler) SelectByPaymentLink(c *gin.Context) {
+	var req SelectPaymentLinkMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	order, err := h.linkService.ResolvePayable(req.OrderNo, req.Expires, req.Sig)
+	if err != nil {
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Query failed")
+		return
+	}
+
+	h.selectOrderPaymentMethod(c, order, paymentLinkOrderUserID(order), req.PaymentMethodID, "payment_link")
+}
@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"auralogic/internal/config"
 	"auralogic/internal/middleware"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
 	"auralogic/internal/pkg/response"
 	"auralogic/internal/pkg/utils"
 	"auralogic/internal/service"
@@ -27,6 +29,8 @@ type PaymentMethodHandler struct {
 	db             *gorm.DB
 	pollingService *service.PaymentPollingService
 	pluginManager  *service.PluginManagerService
+	linkService    *service.PaymentLinkService
+	cfg            *config.Config
 }
 
 // NewPaymentMethodHandler 创建用户付款方式处理器
@@ -36,6 +40,8 @@ func NewPaymentMethodHandler(db *gorm.DB, pollingService *service.PaymentPolling
 		db:             db,
 		pollingService: pollingService,
 		pluginManager:  pluginManager,
+		linkService:    service.NewPaymentLinkService(db, cfg),
+		cfg:            cfg,
 	}
 }
 
@@ -318,13 +324,22 @@ func (h *PaymentMethodHandler) HandleWebhook(c *gin.Context) {
 
 // List 获取可用的付款方式列表
 func (h *PaymentMethodHandler) List(c *gin.Context) {
-	methods, err := h.service.GetEnabledMethods()
+	items, err := h.enabledMethodItems()
 	if err != nil {
 		response.InternalError(c, "Failed to get payment methods")
 		return
 	}
 
-	// 返回简化的付款方式信息（不包含脚本和配置详情）
+	response.Success(c, gin.H{"items": items})
+}
+
+// enabledMethodItems 返回简化的付款方式信息（不包含脚本和配置详情）
+func (h *PaymentMethodHandler) enabledMethodItems() ([]gin.H, error) {
+	methods, err := h.service.GetEnabledMethods()
+	if err != nil {
+		return nil, err
+	}
+
 	var items []gin.H
 	for _, pm := range methods {
 		items = append(items, gin.H{
@@ -333,10 +348,12 @@ func (h *PaymentMethodHandler) List(c *gin.Context) {
 			"description": pm.Description,
 			"icon":        pm.Icon,
 			"type":        pm.Type,
+			// 手续费规则，供前端展示附加费或优惠
+			"fee_rate":        pm.FeeRate,
+			"fee_fixed_minor": pm.FeeFixed,
 		})
 	}
-
-	response.Success(c, gin.H{"items": items})
+	return items, nil
 }
 
 // GetPaymentCard 获取订单的付款卡片
@@ -368,6 +385,13 @@ func (h *PaymentMethodHandler) GetPaymentCard(c *gin.Context) {
 		return
 	}
 
+	// 尚未选择该付款方式时，按其手续费规则预览应付金额
+	if selected, _, err := h.service.GetOrderPaymentMethod(order.ID); err == nil && (selected == nil || selected.ID != uint(paymentMethodID)) {
+		if pm, err := h.service.Get(uint(paymentMethodID)); err == nil {
+			service.ApplyPaymentMethodFee(&order, pm)
+		}
+	}
+
 	// 生成付款卡片
 	result, err := h.service.GeneratePaymentCard(uint(paymentMethodID), &order)
 	if err != nil {
@@ -393,7 +417,7 @@ func (h *PaymentMethodHandler) SelectPaymentMethod(c *gin.Context) {
 		PaymentMethodID uint `json:"payment_method_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -403,16 +427,25 @@ func (h *PaymentMethodHandler) SelectPaymentMethod(c *gin.Context) {
 		response.NotFound(c, "Order not found")
 		return
 	}
+
+	h.selectOrderPaymentMethod(c, &order, userID, req.PaymentMethodID, "user_api")
+}
+
+// selectOrderPaymentMethod 为订单选择付款方式并返回付款卡片（用户付款页与付款链接共用）
+func (h *PaymentMethodHandler) selectOrderPaymentMethod(c *gin.Context, order *models.Order, userID uint, paymentMethodID uint, source string) {
 	hookExecCtx := h.buildPaymentHookExecutionContext(c, userID, order.ID)
+	if source == "payment_link" && hookExecCtx != nil {
+		hookExecCtx.Metadata["auth_method"] = "payment_link"
+	}
 	if h.pluginManager != nil {
-		originalReq := req
+		requestedMethodID := paymentMethodID
 		hookPayload := map[string]interface{}{
 			"order_id":          order.ID,
 			"order_no":          order.OrderNo,
 			"user_id":           userID,
 			"status_before":     order.Status,
-			"payment_method_id": req.PaymentMethodID,
-			"source":            "user_api",
+			"payment_method_id": paymentMethodID,
+			"source":            source,
 		}
 		hookResult, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
 			Hook:    "payment.method.select.before",
@@ -434,9 +467,9 @@ func (h *PaymentMethodHandler) SelectPaymentMethod(c *gin.Context) {
 					methodID, convErr := paymentHookValueToUint(rawMethodID)
 					if convErr != nil || methodID == 0 {
 						log.Printf("payment.method.select.before payload apply failed, fallback to original request: user=%d order=%s err=%v", userID, order.OrderNo, convErr)
-						req = originalReq
+						paymentMethodID = requestedMethodID
 					} else {
-						req.PaymentMethodID = methodID
+						paymentMethodID = methodID
 					}
 				}
 			}
@@ -444,21 +477,27 @@ func (h *PaymentMethodHandler) SelectPaymentMethod(c *gin.Context) {
 	}
 
 	// 选择付款方式
-	if err := h.service.SelectPaymentMethod(order.ID, req.PaymentMethodID); err != nil {
+	if err := h.service.SelectPaymentMethod(order.ID, paymentMethodID); err != nil {
 		response.HandleError(c, "Failed to select payment method", err)
 		return
 	}
 
+	// 重新加载订单，付款卡片需使用计入手续费后的金额
+	if err := h.db.First(order, order.ID).Error; err != nil {
+		response.InternalError(c, "Failed to reload order")
+		return
+	}
+
 	// 将订单加入付款状态轮询队列
 	if h.pollingService != nil {
-		if err := h.pollingService.AddToQueue(order.ID, req.PaymentMethodID); err != nil {
+		if err := h.pollingService.AddToQueue(order.ID, paymentMethodID); err != nil {
 			response.HandleError(c, "Failed to queue payment polling task", err)
 			return
 		}
 	}
 
 	// 生成付款卡片并缓存
-	result, err := h.service.GeneratePaymentCard(req.PaymentMethodID, &order)
+	result, err := h.service.GeneratePaymentCard(paymentMethodID, order)
 	if err != nil {
 		response.InternalError(c, "Failed to generate payment info")
 		return
@@ -476,9 +515,9 @@ func (h *PaymentMethodHandler) SelectPaymentMethod(c *gin.Context) {
 			"order_no":          order.OrderNo,
 			"user_id":           userID,
 			"status_before":     order.Status,
-			"payment_method_id": req.PaymentMethodID,
+			"payment_method_id": paymentMethodID,
 			"queued_polling":    h.pollingService != nil,
-			"source":            "user_api",
+			"source":            source,
 		}
 		go func(execCtx *service.ExecutionContext, payload map[string]interface{}, uid uint, orderNumber string) {
 			_, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
@@ -512,11 +551,23 @@ func (h *PaymentMethodHandler) GetOrderPaymentInfo(c *gin.Context) {
 		return
 	}
 
+	// 用户回到付款页，撤销之前的离开标记
+	h.clearCheckoutAbandoned(&order)
+
+	info, err := h.orderPaymentInfo(&order)
+	if err != nil {
+		response.HandleError(c, "Failed to get payment info", err)
+		return
+	}
+	response.Success(c, info)
+}
+
+// orderPaymentInfo 订单当前的付款信息：未选择时返回可用付款方式，已选择时返回付款卡片
+func (h *PaymentMethodHandler) orderPaymentInfo(order *models.Order) (gin.H, error) {
 	// 获取订单选择的付款方式
 	pm, opm, err := h.service.GetOrderPaymentMethod(order.ID)
 	if err != nil {
-		response.InternalError(c, "Failed to get payment info")
-		return
+		return nil, err
 	}
 
 	if pm == nil {
@@ -531,29 +582,108 @@ func (h *PaymentMethodHandler) GetOrderPaymentInfo(c *gin.Context) {
 				"icon":        m.Icon,
 			})
 		}
-		response.Success(c, gin.H{
+		return gin.H{
 			"selected":          false,
 			"available_methods": items,
-		})
-		return
+		}, nil
 	}
 
 	// 已选择付款方式，优先使用缓存的付款卡片
 	result, err := h.service.GetCachedPaymentCard(order.ID)
 	if err != nil || result == nil {
 		// 缓存不存在或失败，重新生成并缓存
-		result, err = h.service.GeneratePaymentCard(pm.ID, &order)
+		result, err = h.service.GeneratePaymentCard(pm.ID, order)
 		if err != nil {
-			response.InternalError(c, "Failed to generate payment info")
-			return
+			return nil, err
 		}
 		_ = h.service.CachePaymentCard(order.ID, result)
 	}
 
-	response.Success(c, gin.H{
+	return gin.H{
 		"selected":       true,
 		"payment_method": gin.H{"id": pm.ID, "name": pm.Name, "icon": pm.Icon},
 		"payment_card":   result,
 		"order_payment":  opm,
+	}, nil
+}
+
+// checkoutAbandonReasons 前端上报的离开付款页原因
+var checkoutAbandonReasons = map[string]struct{}{
+	"page_hide":     {},
+	"navigate_away": {},
+	"user_cancel":   {},
+}
+
+// AbandonCheckout 用户主动离开付款页（前端 keepalive beacon 上报）
+// 仅做标记，由订单自动取消服务在宽限期后提前释放预留库存
+func (h *PaymentMethodHandler) AbandonCheckout(c *gin.Context) {
+	orderNo := c.Param("order_no")
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// beacon 请求体可能为空，解析失败按 unknown 处理
+	_ = c.ShouldBindJSON(&req)
+	reason := strings.ToLower(strings.TrimSpace(req.Reason))
+	if _, ok := checkoutAbandonReasons[reason]; !ok {
+		reason = "unknown"
+	}
+
+	var order models.Order
+	if err := h.db.Where("order_no = ? AND user_id = ?", orderNo, userID).First(&order).Error; err != nil {
+		response.NotFound(c, "Order not found")
+		return
+	}
+
+	abandonMinutes := 0
+	if h.cfg != nil {
+		abandonMinutes = h.cfg.Order.AbandonReleaseMinutes
+	}
+	if order.Status != models.OrderStatusPendingPayment || abandonMinutes <= 0 {
+		response.Success(c, gin.H{"tracked": false})
+		return
+	}
+
+	// 多次上报只记录第一次离开时间，避免宽限期被不断延长
+	now := models.NowFunc()
+	result := h.db.Model(&models.Order{}).
+		Where("id = ? AND status = ? AND checkout_abandoned_at IS NULL", order.ID, models.OrderStatusPendingPayment).
+		Update("checkout_abandoned_at", now)
+	if result.Error != nil {
+		response.InternalError(c, "Failed to record checkout abandonment")
+		return
+	}
+	abandonedAt := now
+	if result.RowsAffected == 0 && order.CheckoutAbandonedAt != nil {
+		abandonedAt = *order.CheckoutAbandonedAt
+	}
+	if result.RowsAffected > 0 {
+		logger.LogOrderOperation(h.db, c, "checkout_abandoned", order.ID, map[string]interface{}{
+			"order_no": order.OrderNo,
+			"reason":   reason,
+		})
+	}
+
+	response.Success(c, gin.H{
+		"tracked":    true,
+		"release_at": abandonedAt.Add(time.Duration(abandonMinutes) * time.Minute),
 	})
 }
+
+// clearCheckoutAbandoned 用户回到付款页时清除离开标记
+func (h *PaymentMethodHandler) clearCheckoutAbandoned(order *models.Order) {
+	if order == nil || order.CheckoutAbandonedAt == nil || order.Status != models.OrderStatusPendingPayment {
+		return
+	}
+	if err := h.db.Model(&models.Order{}).
+		Where("id = ? AND checkout_abandoned_at IS NOT NULL", order.ID).
+		Update("checkout_abandoned_at", nil).Error; err != nil {
+		log.Printf("Failed to clear checkout abandonment: order=%s err=%v", order.OrderNo, err)
+		return
+	}
+	order.CheckoutAbandonedAt = nil
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalTokenHandler struct {
+	tokenService *service.PersonalAccessTokenService
+}
+
+func NewPersonalTokenHandler(tokenService *service.PersonalAccessTokenService) *PersonalTokenHandler {
+	return &PersonalTokenHandler{tokenService: tokenService}
+}
+
+// CreatePersonalTokenRequest 创建个人访问令牌请求，expires_in_days 为 0 表示永不过期
+type CreatePersonalTokenRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// requireSessionAuth 令牌只能在登录会话中管理，合作方 API Key 不能代替用户签发令牌
+func requireSessionAuth(c *gin.Context) (uint, bool) {
+	if middleware.IsAPIKeyAuth(c) {
+		response.Forbidden(c, "Personal access tokens can only be managed from a signed-in session")
+		return 0, false
+	}
+	return middleware.RequireUserID(c)
+}
+
+// ListTokens 当前用户的个人访问令牌
+func (h *PersonalTokenHandler) ListTokens(c *gin.Context) {
+	userID, ok := requireSessionAuth(c)
+	if !ok {
+		return
+	}
+	tokens, err := h.tokenService.List(userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get tokens")
+		return
+	}
+	response.Success(c, gin.H{"items": tokens})
+}
+
+// CreateToken 创建个人访问令牌，明文令牌只在响应中返回一次
+func (h *PersonalTokenHandler) CreateToken(c *gin.Context) {
+	userID, ok := requireSessionAuth(c)
+	if !ok {
+		return
+	}
+	var req CreatePersonalTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	token, err := h.tokenService.Create(userID, service.PersonalAccessTokenInput{
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresInDays: req.ExpiresInDays,
+	})
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to create token")
+		}
+		return
+	}
+	response.Success(c, token)
+}
+
+// RevokeToken 撤销个人访问令牌
+func (h *PersonalTokenHandler) RevokeToken(c *gin.Context) {
+	userID, ok := requireSessionAuth(c)
+	if !ok {
+		return
+	}
+	tokenID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid token ID")
+		return
+	}
+	if err := h.tokenService.Revoke(userID, tokenID); err != nil {
+		if errors.Is(err, service.ErrPersonalAccessTokenNotFound) {
+			response.NotFound(c, "Token not found")
+			return
+		}
+		response.InternalError(c, "Failed to revoke token")
+		return
+	}
+	response.Success(c, nil)
+}
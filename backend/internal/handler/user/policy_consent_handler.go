@@ -0,0 +1,70 @@
+package user
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PolicyConsentHandler struct {
+	policyConsentService *service.PolicyConsentService
+}
+
+func NewPolicyConsentHandler(policyConsentService *service.PolicyConsentService) *PolicyConsentHandler {
+	return &PolicyConsentHandler{policyConsentService: policyConsentService}
+}
+
+// AcceptPoliciesRequest 同意政策请求，只能同意当前版本
+type AcceptPoliciesRequest struct {
+	PolicyVersionIDs []uint `json:"policy_version_ids" binding:"required"`
+}
+
+func respondPolicyConsentError(c *gin.Context, err error, fallback string) {
+	if !respondUserBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// GetConsents 需要（重新）同意的政策与同意记录
+func (h *PolicyConsentHandler) GetConsents(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	pending, err := h.policyConsentService.Pending(userID)
+	if err != nil {
+		respondPolicyConsentError(c, err, "Failed to get policies")
+		return
+	}
+	history, err := h.policyConsentService.History(userID)
+	if err != nil {
+		respondPolicyConsentError(c, err, "Failed to get consent history")
+		return
+	}
+	response.Success(c, gin.H{"pending": pending, "history": history})
+}
+
+// AcceptPolicies 同意当前版本的政策，同时记录 IP 与 User-Agent
+func (h *PolicyConsentHandler) AcceptPolicies(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req AcceptPoliciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	pending, err := h.policyConsentService.Accept(userID, service.AcceptPoliciesInput{
+		PolicyVersionIDs: req.PolicyVersionIDs,
+		IPAddress:        utils.GetRealIP(c),
+		UserAgent:        c.Request.UserAgent(),
+	})
+	if err != nil {
+		respondPolicyConsentError(c, err, "Failed to record consent")
+		return
+	}
+	response.Success(c, gin.H{"pending": pending})
+}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type ProductTrialHandler struct {
+	trialService *service.ProductTrialService
+}
+
+func NewProductTrialHandler(trialService *service.ProductTrialService) *ProductTrialHandler {
+	return &ProductTrialHandler{trialService: trialService}
+}
+
+// Start 领取商品试用，发放零金额试用订单
+func (h *ProductTrialHandler) Start(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	trial, err := h.trialService.StartTrial(userID, productID)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to start trial")
+		}
+		return
+	}
+	response.Success(c, trial)
+}
+
+// List 当前用户领取过的试用
+func (h *ProductTrialHandler) List(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	trials, err := h.trialService.ListUserTrials(userID)
+	if err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
+	response.Success(c, trials)
+}
@@ -113,7 +113,7 @@ func (h *PromoCodeHandler) ValidatePromoCode(c *gin.Context) {
 
 	var req ValidatePromoCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 	hookExecCtx := h.buildPromoHookExecutionContext(c, userID)
@@ -173,7 +173,7 @@ func (h *PromoCodeHandler) ValidatePromoCode(c *gin.Context) {
 		}
 	}
 
-	promoCode, discount, err := h.promoCodeService.ValidateCode(req.Code, req.ProductIDs, req.AmountMinor)
+	promoCode, discount, err := h.promoCodeService.ValidateCode(userID, req.Code, req.ProductIDs, req.AmountMinor)
 	if err != nil {
 		response.HandleError(c, "Invalid promo code", err)
 		return
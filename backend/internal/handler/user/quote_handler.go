@@ -0,0 +1,167 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type QuoteHandler struct {
+	quoteService *service.QuoteService
+	cfg          *config.Config
+	db           *gorm.DB
+}
+
+func NewQuoteHandler(quoteService *service.QuoteService, cfg *config.Config, db *gorm.DB) *QuoteHandler {
+	return &QuoteHandler{quoteService: quoteService, cfg: cfg, db: db}
+}
+
+// AcceptQuoteRequest 接受报价单请求，备注写入生成的订单
+type AcceptQuoteRequest struct {
+	Remark string `json:"remark" binding:"max=500"`
+}
+
+// DeclineQuoteRequest 拒绝报价单请求
+type DeclineQuoteRequest struct {
+	Reason string `json:"reason"`
+}
+
+func respondQuoteError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrQuoteNotFound) {
+		response.NotFound(c, "Quote not found")
+		return
+	}
+	if !respondUserBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// List 我的报价单
+func (h *QuoteHandler) List(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	page, limit := response.GetPagination(c)
+	quotes, total, err := h.quoteService.ListForUser(userID, page, limit)
+	if err != nil {
+		respondQuoteError(c, err, "Failed to get quotes")
+		return
+	}
+	response.Paginated(c, quotes, page, limit, total)
+}
+
+// Get 报价单详情
+func (h *QuoteHandler) Get(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	quote, err := h.quoteService.GetForUser(userID, c.Param("quote_no"))
+	if err != nil {
+		respondQuoteError(c, err, "Failed to get quote")
+		return
+	}
+	response.Success(c, quote)
+}
+
+// Accept 接受报价单并按报价价格生成订单
+func (h *QuoteHandler) Accept(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req AcceptQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quote, order, err := h.quoteService.Accept(userID, c.Param("quote_no"), validator.SanitizeText(req.Remark))
+	if err != nil {
+		respondQuoteError(c, err, "Failed to accept quote")
+		return
+	}
+	logger.LogOrderOperation(h.db, c, "quote_accepted", order.ID, map[string]interface{}{
+		"quote_no":           quote.QuoteNo,
+		"order_no":           order.OrderNo,
+		"total_amount_minor": order.TotalAmount,
+	})
+	response.Success(c, gin.H{"quote": quote, "order": order})
+}
+
+// Decline 拒绝报价单
+func (h *QuoteHandler) Decline(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req DeclineQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	quote, err := h.quoteService.Decline(userID, c.Param("quote_no"), validator.SanitizeText(req.Reason))
+	if err != nil {
+		respondQuoteError(c, err, "Failed to decline quote")
+		return
+	}
+	logger.LogOperation(h.db, c, "decline_quote", "quote", &quote.ID, map[string]interface{}{
+		"quote_no": quote.QuoteNo,
+	})
+	response.Success(c, quote)
+}
+
+func (h *QuoteHandler) loadDocument(c *gin.Context, inlineAssets bool) (*models.Quote, []byte, bool) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return nil, nil, false
+	}
+	quote, err := h.quoteService.GetForUser(userID, c.Param("quote_no"))
+	if err != nil {
+		respondQuoteError(c, err, "Failed to get quote")
+		return nil, nil, false
+	}
+	html, err := service.RenderQuoteHTML(h.cfg, quote, inlineAssets)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render quote", err)
+		return nil, nil, false
+	}
+	return quote, html, true
+}
+
+// Document 报价单 HTML，可直接打印
+func (h *QuoteHandler) Document(c *gin.Context) {
+	_, html, ok := h.loadDocument(c, false)
+	if !ok {
+		return
+	}
+	c.Data(200, "text/html; charset=utf-8", html)
+}
+
+// DocumentPDF 通过账单的 PDF 渲染命令输出报价单 PDF
+func (h *QuoteHandler) DocumentPDF(c *gin.Context) {
+	if !service.InvoicePDFEnabled(&h.cfg.Order.Invoice) {
+		response.BadRequest(c, "PDF documents are not enabled")
+		return
+	}
+	quote, html, ok := h.loadDocument(c, true)
+	if !ok {
+		return
+	}
+	pdf, err := service.RenderInvoicePDF(c.Request.Context(), &h.cfg.Order.Invoice, html)
+	if err != nil {
+		response.InternalServerError(c, "Failed to render quote PDF", err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", quote.QuoteNo+".pdf"))
+	c.Data(200, "application/pdf", pdf)
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type RefundRequestHandler struct {
+	refundRequestService *service.RefundRequestService
+}
+
+func NewRefundRequestHandler(refundRequestService *service.RefundRequestService) *RefundRequestHandler {
+	return &RefundRequestHandler{refundRequestService: refundRequestService}
+}
+
+// CreateRefundRequestRequest 提交退款申请请求
+type CreateRefundRequestRequest struct {
+	Reason     string   `json:"reason" binding:"required"`
+	Resolution string   `json:"resolution" binding:"required"` // full_refund, partial_refund, replacement, other
+	Evidence   []string `json:"evidence"`                      // 通过 /tickets/attachments 预先上传的凭证截图URL
+}
+
+// CreateRefundRequest 提交退款申请，自动创建关联工单
+func (h *RefundRequestHandler) CreateRefundRequest(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateRefundRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	evidence, err := normalizeTicketIntakeAttachments(config.GetConfig(), req.Evidence)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.BadRequest(c, "Invalid evidence attachments")
+		}
+		return
+	}
+
+	request, err := h.refundRequestService.Create(userID, c.Param("order_no"), service.CreateRefundRequestInput{
+		Reason:     validator.SanitizeMarkdown(req.Reason),
+		Resolution: req.Resolution,
+		Evidence:   evidence,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrRefundOrderNotFound) {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to submit refund request")
+		return
+	}
+	response.Success(c, request)
+}
+
+// ListRefundRequests 订单的退款申请记录
+func (h *RefundRequestHandler) ListRefundRequests(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	requests, err := h.refundRequestService.ListForUser(userID, c.Param("order_no"))
+	if err != nil {
+		response.InternalError(c, "Failed to get refund requests")
+		return
+	}
+	response.Success(c, gin.H{"items": requests})
+}
@@ -0,0 +1,138 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/config"
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/validator"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReturnHandler struct {
+	returnService *service.ReturnService
+	db            *gorm.DB
+}
+
+func NewReturnHandler(returnService *service.ReturnService, db *gorm.DB) *ReturnHandler {
+	return &ReturnHandler{returnService: returnService, db: db}
+}
+
+// ReturnItemRequest 申请退货的订单项
+type ReturnItemRequest struct {
+	ItemIndex int `json:"item_index"`
+	Quantity  int `json:"quantity"`
+}
+
+// CreateReturnRequest 提交退货申请请求
+type CreateReturnRequest struct {
+	Items       []ReturnItemRequest `json:"items" binding:"required"`
+	Reason      string              `json:"reason" binding:"required"` // defective, damaged, wrong_item, not_as_described, no_longer_needed, other
+	Description string              `json:"description"`
+	Photos      []string            `json:"photos"` // 通过 /tickets/attachments 预先上传的图片URL
+}
+
+// ReturnTrackingRequest 登记退货物流请求
+type ReturnTrackingRequest struct {
+	Carrier    string `json:"carrier"`
+	TrackingNo string `json:"tracking_no" binding:"required"`
+}
+
+func respondReturnError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrReturnOrderNotFound):
+		response.NotFound(c, "Order not found")
+	case errors.Is(err, service.ErrReturnRequestNotFound):
+		response.NotFound(c, "Return request not found")
+	default:
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, fallback)
+		}
+	}
+}
+
+// CreateReturn 对已发货的订单申请退货
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+
+	photos, err := normalizeTicketIntakeAttachments(config.GetConfig(), req.Photos)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.BadRequest(c, "Invalid photo attachments")
+		}
+		return
+	}
+
+	items := make([]service.ReturnItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.ReturnItemInput{ItemIndex: item.ItemIndex, Quantity: item.Quantity})
+	}
+	request, err := h.returnService.Create(userID, c.Param("order_no"), service.CreateReturnInput{
+		Items:       items,
+		Reason:      req.Reason,
+		Description: validator.SanitizeText(req.Description),
+		Photos:      photos,
+	})
+	if err != nil {
+		respondReturnError(c, err, "Failed to submit return request")
+		return
+	}
+	logger.LogOrderOperation(h.db, c, "return_requested", request.OrderID, map[string]interface{}{
+		"order_no":  request.OrderNo,
+		"return_no": request.ReturnNo,
+		"reason":    request.Reason,
+	})
+	response.Success(c, request)
+}
+
+// ListReturns 订单的退货申请
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	requests, err := h.returnService.ListForOrder(userID, c.Param("order_no"))
+	if err != nil {
+		response.InternalError(c, "Failed to get return requests")
+		return
+	}
+	response.Success(c, gin.H{"items": requests})
+}
+
+// SubmitTracking 寄回商品后登记退货物流单号
+func (h *ReturnHandler) SubmitTracking(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req ReturnTrackingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	request, err := h.returnService.SubmitTracking(userID, c.Param("return_no"),
+		validator.SanitizeInput(req.Carrier), validator.SanitizeInput(req.TrackingNo))
+	if err != nil {
+		respondReturnError(c, err, "Failed to save return tracking")
+		return
+	}
+	logger.LogOrderOperation(h.db, c, "return_shipped_back", request.OrderID, map[string]interface{}{
+		"order_no":    request.OrderNo,
+		"return_no":   request.ReturnNo,
+		"carrier":     request.ReturnCarrier,
+		"tracking_no": request.ReturnTrackingNo,
+	})
+	response.Success(c, request)
+}
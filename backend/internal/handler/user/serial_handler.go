@@ -54,7 +54,7 @@ func (h *SerialHandler) VerifySerial(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Please enter a serial number")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"strings"
+	"time"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type SiteBannerHandler struct {
+	bannerService *service.SiteBannerService
+}
+
+func NewSiteBannerHandler(bannerService *service.SiteBannerService) *SiteBannerHandler {
+	return &SiteBannerHandler{bannerService: bannerService}
+}
+
+// GetActiveBanners 当前展示中的站点横幅（公开），按登录身份筛选展示对象
+// 语言优先取 locale 参数，其次取 Accept-Language
+func (h *SiteBannerHandler) GetActiveBanners(c *gin.Context) {
+	viewer := service.SiteBannerViewer{}
+	if _, ok := middleware.GetUserID(c); ok {
+		viewer.LoggedIn = true
+		role, _ := middleware.GetUserRole(c)
+		viewer.IsAdmin = (&models.User{Role: role}).IsAdmin()
+	}
+	locale := strings.TrimSpace(c.Query("locale"))
+	if locale == "" {
+		locale = c.GetHeader("Accept-Language")
+	}
+
+	now := time.Now()
+	items, err := h.bannerService.Active(viewer, locale, now)
+	if err != nil {
+		response.InternalError(c, "Failed to get site banners")
+		return
+	}
+	response.Success(c, gin.H{
+		"version": h.bannerService.ActiveVersion(now),
+		"items":   items,
+	})
+}
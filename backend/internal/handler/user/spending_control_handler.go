@@ -0,0 +1,90 @@
+package user
+
+import (
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SpendingControlHandler struct {
+	spendingControlService *service.SpendingControlService
+	db                     *gorm.DB
+}
+
+func NewSpendingControlHandler(spendingControlService *service.SpendingControlService, db *gorm.DB) *SpendingControlHandler {
+	return &SpendingControlHandler{spendingControlService: spendingControlService, db: db}
+}
+
+// UpdateSpendingControlRequest 修改消费控制请求，monthly_limit_minor 为 0 表示不限
+type UpdateSpendingControlRequest struct {
+	MonthlyLimitMinor int64    `json:"monthly_limit_minor"`
+	BlockedCategories []string `json:"blocked_categories"`
+	OwnerEmail        string   `json:"owner_email"`
+}
+
+func respondSpendingControlError(c *gin.Context, err error, fallback string) {
+	if !respondUserBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// GetSpendingControl 当前账户的消费控制
+func (h *SpendingControlHandler) GetSpendingControl(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	overview, err := h.spendingControlService.Get(userID)
+	if err != nil {
+		respondSpendingControlError(c, err, "Failed to get spending controls")
+		return
+	}
+	response.Success(c, overview)
+}
+
+// UpdateSpendingControl 修改消费控制，放宽限制需等待冷静期
+func (h *SpendingControlHandler) UpdateSpendingControl(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req UpdateSpendingControlRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	overview, applied, err := h.spendingControlService.Update(userID, models.SpendingControlTerms{
+		MonthlyLimit:      req.MonthlyLimitMinor,
+		BlockedCategories: req.BlockedCategories,
+		OwnerEmail:        req.OwnerEmail,
+	})
+	if err != nil {
+		respondSpendingControlError(c, err, "Failed to update spending controls")
+		return
+	}
+	logger.LogOperation(h.db, c, "update_spending_control", "spending_control", &overview.Control.ID, map[string]interface{}{
+		"monthly_limit_minor": req.MonthlyLimitMinor,
+		"blocked_categories":  overview.Control.BlockedCategories,
+		"applied":             applied,
+	})
+	response.Success(c, gin.H{"overview": overview, "applied": applied})
+}
+
+// CancelPendingSpendingControl 撤销冷静期中的变更
+func (h *SpendingControlHandler) CancelPendingSpendingControl(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	overview, err := h.spendingControlService.CancelPending(userID)
+	if err != nil {
+		respondSpendingControlError(c, err, "Failed to cancel pending change")
+		return
+	}
+	logger.LogOperation(h.db, c, "cancel_spending_control_change", "spending_control", &overview.Control.ID, nil)
+	response.Success(c, overview)
+}
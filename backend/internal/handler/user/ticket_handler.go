@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"net/textproto"
 	"os"
 	"path/filepath"
@@ -29,12 +31,28 @@ type TicketHandler struct {
 	db            *gorm.DB
 	emailService  *service.EmailService
 	pluginManager *service.PluginManagerService
+	moderation    *service.ContentModerationService
 }
 
 func NewTicketHandler(db *gorm.DB, emailService *service.EmailService, pluginManager *service.PluginManagerService) *TicketHandler {
 	return &TicketHandler{db: db, emailService: emailService, pluginManager: pluginManager}
 }
 
+// SetContentModeration 设置工单标题、正文与用户消息的内容审核
+func (h *TicketHandler) SetContentModeration(moderation *service.ContentModerationService) {
+	h.moderation = moderation
+}
+
+// attachmentService 工单消息附件（对象存储），按当前配置选择存储后端
+func (h *TicketHandler) attachmentService() *service.TicketAttachmentService {
+	return service.NewTicketAttachmentService(h.db, config.GetConfig())
+}
+
+// orderShareService 工单订单授权管理（同步维护订单的 shared_to_support 标记）
+func (h *TicketHandler) orderShareService() *service.OrderShareService {
+	return service.NewOrderShareService(h.db)
+}
+
 // generateTicketNo 生成工单号
 func (h *TicketHandler) generateTicketNo() string {
 	return fmt.Sprintf("TK%s%04d", time.Now().Format("20060102150405"), time.Now().UnixNano()%10000)
@@ -42,13 +60,18 @@ func (h *TicketHandler) generateTicketNo() string {
 
 // CreateTicketRequest 创建工单请求
 type CreateTicketRequest struct {
-	Subject  string `json:"subject" binding:"required,max=255"`
-	Content  string `json:"content" binding:"required"`
-	Category string `json:"category"`
-	Priority string `json:"priority"`
-	OrderID  *uint  `json:"order_id"` // 可选绑定订单
+	Subject     string            `json:"subject" binding:"required,max=255"`
+	Content     string            `json:"content" binding:"required"`
+	Category    string            `json:"category"`
+	Priority    string            `json:"priority"`
+	OrderID     *uint             `json:"order_id"`    // 可选绑定订单
+	Fields      map[string]string `json:"fields"`      // 分类主题模板的结构化字段
+	Attachments []string          `json:"attachments"` // 通过 /tickets/attachments 预先上传的截图URL
 }
 
+// maxTicketIntakeAttachments 创建工单时最多附带的截图数量
+const maxTicketIntakeAttachments = 10
+
 type ticketAutoReplyPayload struct {
 	Enabled        *bool                  `json:"enabled"`
 	Content        string                 `json:"content"`
@@ -62,7 +85,7 @@ type ticketAutoReplyPayload struct {
 func (h *TicketHandler) CreateTicket(c *gin.Context) {
 	var req CreateTicketRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -79,6 +102,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 			"content":   req.Content,
 			"category":  req.Category,
 			"priority":  req.Priority,
+			"fields":    req.Fields,
 			"source":    "user_api",
 			"hook_time": time.Now().Format(time.RFC3339),
 		}
@@ -121,16 +145,65 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		req.Priority = string(parsedPriority)
 	}
 
+	cfg := config.GetConfig()
+
+	// 按分类主题模板校验结构化字段、关联订单与截图
+	topic := ticketbiz.FindTopic(cfg.Ticket.Topics, req.Category)
+	intakeData, intakeErr := ticketbiz.ValidateIntakeFields(topic, req.Fields)
+	if intakeErr != nil {
+		respondUserBizError(c, intakeErr)
+		return
+	}
+	if topic != nil && topic.RequireOrder {
+		if req.OrderID == nil || *req.OrderID == 0 {
+			respondUserBizError(c, ticketbiz.OrderRequired())
+			return
+		}
+		var ownedCount int64
+		if err := h.db.Model(&models.Order{}).Where("id = ? AND user_id = ?", *req.OrderID, userID).Count(&ownedCount).Error; err != nil || ownedCount == 0 {
+			respondUserBizError(c, ticketbiz.OrderRequired())
+			return
+		}
+	}
+	attachments, attachmentErr := normalizeTicketIntakeAttachments(cfg, req.Attachments)
+	if attachmentErr != nil {
+		respondUserBizError(c, attachmentErr)
+		return
+	}
+	if topic != nil && topic.MinAttachments > 0 && len(attachments) < topic.MinAttachments {
+		respondUserBizError(c, ticketbiz.AttachmentRequired(topic.MinAttachments))
+		return
+	}
+
 	// 清理内容，防止XSS
 	sanitizedSubject := validator.SanitizeInput(req.Subject)
 	sanitizedContent := validator.SanitizeMarkdown(req.Content)
 
 	// 检查内容长度限制
-	cfg := config.GetConfig()
 	if cfg.Ticket.MaxContentLength > 0 && len([]rune(sanitizedContent)) > cfg.Ticket.MaxContentLength {
 		respondUserBizError(c, ticketbiz.ContentTooLong(cfg.Ticket.MaxContentLength))
 		return
 	}
+	for key, value := range intakeData {
+		intakeData[key] = validator.SanitizeInput(value)
+	}
+
+	subjectVerdict, err := h.moderation.Screen(models.ModerationSourceTicketSubject, &userID, sanitizedSubject)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to create ticket")
+		}
+		return
+	}
+	contentVerdict, err := h.moderation.Screen(models.ModerationSourceTicketContent, &userID, sanitizedContent)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to create ticket")
+		}
+		return
+	}
+	sanitizedSubject = subjectVerdict.Text
+	sanitizedContent = contentVerdict.Text
 
 	now := time.Now()
 	ticket := &models.Ticket{
@@ -139,6 +212,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		Subject:            sanitizedSubject,
 		Content:            sanitizedContent,
 		Category:           req.Category,
+		IntakeData:         models.JSONMap(intakeData),
 		Priority:           priority,
 		Status:             models.TicketStatusOpen,
 		LastMessageAt:      &now,
@@ -151,6 +225,11 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		response.InternalError(c, "Failed to create ticket")
 		return
 	}
+	for _, verdict := range []*service.ModerationVerdict{subjectVerdict, contentVerdict} {
+		if err := service.RecordModerationVerdictTx(h.db, verdict, ticket.ID); err != nil {
+			log.Printf("ticket: failed to record moderation case: ticket_id=%d err=%v", ticket.ID, err)
+		}
+	}
 
 	// 创建初始消息
 	var user models.User
@@ -161,7 +240,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		SenderType:    "user",
 		SenderID:      userID,
 		SenderName:    user.Name,
-		Content:       sanitizedContent,
+		Content:       buildTicketIntakeMessage(topic, intakeData, sanitizedContent, attachments),
 		ContentType:   "text",
 		IsReadByUser:  true,
 		IsReadByAdmin: false,
@@ -175,16 +254,10 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		if err := h.db.First(&order, *req.OrderID).Error; err == nil {
 			// 安全检查：确保order.UserID不为nil且属于当前用户
 			if order.UserID != nil && *order.UserID == userID {
-				// 创建订单访问权限
-				access := &models.TicketOrderAccess{
-					TicketID:       ticket.ID,
-					OrderID:        *req.OrderID,
-					GrantedBy:      userID,
-					CanView:        true,
-					CanEdit:        false,
-					CanViewPrivacy: false,
+				// 创建订单访问权限（不过期，允许查看收货信息）
+				if _, _, err := h.orderShareService().Share(ticket.ID, order.ID, userID, service.OrderShareOptions{IncludeReceiverInfo: true}); err != nil {
+					log.Printf("ticket: failed to share order with new ticket: ticket_id=%d order_id=%d err=%v", ticket.ID, order.ID, err)
 				}
-				h.db.Create(access)
 
 				// 创建订单分享消息
 				orderMsg := &models.TicketMessage{
@@ -221,6 +294,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 			"subject":     ticket.Subject,
 			"content":     ticket.Content,
 			"category":    ticket.Category,
+			"intake_data": intakeData,
 			"priority":    string(ticket.Priority),
 			"status":      string(ticket.Status),
 			"created_at":  ticket.CreatedAt.Format(time.RFC3339),
@@ -626,6 +700,10 @@ func (h *TicketHandler) GetTicketMessages(c *gin.Context) {
 		response.InternalError(c, "Query failed")
 		return
 	}
+	if err := h.attachmentService().LoadForMessages(messages); err != nil {
+		response.InternalError(c, "Query failed")
+		return
+	}
 
 	if h.pluginManager != nil {
 		hookPayload := map[string]interface{}{
@@ -651,10 +729,11 @@ func (h *TicketHandler) GetTicketMessages(c *gin.Context) {
 	response.Success(c, messages)
 }
 
-// SendMessageRequest 发送消息请求
+// SendMessageRequest 发送消息请求，内容与附件至少提供一项
 type SendMessageRequest struct {
-	Content     string `json:"content" binding:"required"`
-	ContentType string `json:"content_type"`
+	Content       string `json:"content"`
+	ContentType   string `json:"content_type"`
+	AttachmentIDs []uint `json:"attachment_ids"` // 通过 /tickets/:id/attachments 预先上传的附件
 }
 
 // SendMessage 发送消息
@@ -671,7 +750,7 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	var req SendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -746,6 +825,32 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	if strings.TrimSpace(sanitizedContent) == "" && len(req.AttachmentIDs) == 0 {
+		respondUserBizError(c, ticketbiz.MessageEmpty())
+		return
+	}
+	attachmentService := h.attachmentService()
+	attachments, err := attachmentService.ResolvePending(h.db, ticket.ID, "user", userID, req.AttachmentIDs)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to send")
+		}
+		return
+	}
+
+	// 仅审核文本消息，图片、语音、订单分享等消息内容不是用户输入的文字
+	var messageVerdict *service.ModerationVerdict
+	if contentType == "text" && sanitizedContent != "" {
+		messageVerdict, err = h.moderation.Screen(models.ModerationSourceTicketMessage, &userID, sanitizedContent)
+		if err != nil {
+			if !respondUserBizError(c, err) {
+				response.InternalError(c, "Failed to send")
+			}
+			return
+		}
+		sanitizedContent = messageVerdict.Text
+	}
+
 	message := &models.TicketMessage{
 		TicketID:      uint(ticketID),
 		SenderType:    "user",
@@ -757,16 +862,27 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 		IsReadByAdmin: false,
 	}
 
-	if err := h.db.Create(message).Error; err != nil {
-		response.InternalError(c, "Failed to send")
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return attachmentService.LinkToMessage(tx, message, attachments)
+	}); err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to send")
+		}
 		return
 	}
+	if err := service.RecordModerationVerdictTx(h.db, messageVerdict, message.ID); err != nil {
+		log.Printf("ticket: failed to record moderation case: message_id=%d err=%v", message.ID, err)
+	}
+	preview := service.TicketMessagePreview(sanitizedContent, attachments)
 
 	// 更新工单信息
 	now := time.Now()
 	h.db.Model(&ticket).Updates(map[string]interface{}{
 		"last_message_at":      now,
-		"last_message_preview": truncateString(sanitizedContent, 200),
+		"last_message_preview": truncateString(preview, 200),
 		"last_message_by":      "user",
 		"unread_count_admin":   gorm.Expr("unread_count_admin + 1"),
 		"status":               models.TicketStatusOpen, // 用户回复后重新打开工单
@@ -774,15 +890,16 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	if h.pluginManager != nil {
 		afterPayload := map[string]interface{}{
-			"ticket_id":    ticket.ID,
-			"ticket_no":    ticket.TicketNo,
-			"message_id":   message.ID,
-			"user_id":      userID,
-			"content":      sanitizedContent,
-			"content_type": contentType,
-			"status":       models.TicketStatusOpen,
-			"source":       "user_api",
-			"created_at":   message.CreatedAt.Format(time.RFC3339),
+			"ticket_id":        ticket.ID,
+			"ticket_no":        ticket.TicketNo,
+			"message_id":       message.ID,
+			"user_id":          userID,
+			"content":          sanitizedContent,
+			"content_type":     contentType,
+			"attachment_count": len(attachments),
+			"status":           models.TicketStatusOpen,
+			"source":           "user_api",
+			"created_at":       message.CreatedAt.Format(time.RFC3339),
 		}
 		go func(execCtx *service.ExecutionContext, payload map[string]interface{}, uid uint, tid uint) {
 			_, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
@@ -799,7 +916,7 @@ func (h *TicketHandler) SendMessage(c *gin.Context) {
 
 	// 发送用户回复通知邮件（通知管理员）
 	if h.emailService != nil {
-		go h.emailService.SendTicketUserReplyEmail(&ticket, user.Name, truncateString(sanitizedContent, 200))
+		go h.emailService.SendTicketUserReplyEmail(&ticket, user.Name, truncateString(preview, 200))
 	}
 }
 
@@ -843,7 +960,7 @@ func (h *TicketHandler) UpdateTicketStatus(c *gin.Context) {
 
 	var req UpdateTicketStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
 		return
 	}
 
@@ -937,6 +1054,82 @@ func (h *TicketHandler) UpdateTicketStatus(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Status updated successfully"})
 }
 
+// RateTicketRequest 工单满意度评价请求
+type RateTicketRequest struct {
+	Rating  int    `json:"rating" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+const maxTicketRatingCommentLength = 500
+
+// RateTicket 用户对已解决/已关闭的工单进行满意度评价（每个工单仅可评价一次）
+func (h *TicketHandler) RateTicket(c *gin.Context) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return
+	}
+	ticketID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
+		return
+	}
+
+	var req RateTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		respondUserBizError(c, ticketbiz.RatingInvalid())
+		return
+	}
+	comment := strings.TrimSpace(validator.SanitizeText(req.Comment))
+	if len([]rune(comment)) > maxTicketRatingCommentLength {
+		comment = string([]rune(comment)[:maxTicketRatingCommentLength])
+	}
+
+	var ticket models.Ticket
+	if err := h.db.First(&ticket, ticketID).Error; err != nil {
+		response.NotFound(c, "Ticket not found")
+		return
+	}
+	if ticket.UserID != userID {
+		response.Forbidden(c, "No permission to operate this ticket")
+		return
+	}
+	if ticket.Status != models.TicketStatusResolved && ticket.Status != models.TicketStatusClosed {
+		respondUserBizError(c, ticketbiz.RatingNotAllowed())
+		return
+	}
+	if ticket.RatedAt != nil {
+		respondUserBizError(c, ticketbiz.AlreadyRated())
+		return
+	}
+
+	now := time.Now()
+	result := h.db.Model(&models.Ticket{}).
+		Where("id = ? AND rated_at IS NULL", ticket.ID).
+		Updates(map[string]interface{}{
+			"satisfaction_rating":  req.Rating,
+			"satisfaction_comment": comment,
+			"rated_at":             now,
+		})
+	if result.Error != nil {
+		response.InternalError(c, "Failed to update")
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondUserBizError(c, ticketbiz.AlreadyRated())
+		return
+	}
+
+	response.Success(c, gin.H{
+		"satisfaction_rating":  req.Rating,
+		"satisfaction_comment": comment,
+		"rated_at":             now,
+	})
+}
+
 func applyUserTicketStatusHookPayload(req *UpdateTicketStatusRequest, payload map[string]interface{}) error {
 	if req == nil || payload == nil {
 		return nil
@@ -954,6 +1147,28 @@ func applyUserTicketStatusHookPayload(req *UpdateTicketStatusRequest, payload ma
 // ShareOrderRequest 分享订单请求
 type ShareOrderRequest struct {
 	OrderID uint `json:"order_id" binding:"required"`
+	// 分享有效小时数，0 或不传表示不过期
+	ExpiresInHours int `json:"expires_in_hours"`
+	// 是否允许客服查看收货人姓名、联系方式和详细地址，不传默认允许
+	IncludeReceiverInfo *bool `json:"include_receiver_info"`
+}
+
+// UpdateOrderShareRequest 修改订单分享设置请求，未传的字段保持不变
+type UpdateOrderShareRequest struct {
+	ExpiresInHours      *int  `json:"expires_in_hours"`
+	IncludeReceiverInfo *bool `json:"include_receiver_info"`
+}
+
+// parseOrderShareOptions 校验分享有效期并填充默认值
+func parseOrderShareOptions(expiresInHours int, includeReceiverInfo *bool) (service.OrderShareOptions, bool) {
+	if expiresInHours < 0 || expiresInHours > service.OrderShareMaxHours {
+		return service.OrderShareOptions{}, false
+	}
+	opts := service.OrderShareOptions{ExpiresInHours: expiresInHours, IncludeReceiverInfo: true}
+	if includeReceiverInfo != nil {
+		opts.IncludeReceiverInfo = *includeReceiverInfo
+	}
+	return opts, true
 }
 
 // ShareOrder 分享订单给客服
@@ -970,7 +1185,12 @@ func (h *TicketHandler) ShareOrder(c *gin.Context) {
 
 	var req ShareOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request parameters")
+		response.BindingError(c, err)
+		return
+	}
+	opts, ok := parseOrderShareOptions(req.ExpiresInHours, req.IncludeReceiverInfo)
+	if !ok {
+		response.BadRequest(c, fmt.Sprintf("Share duration must be between 0 and %d hours", service.OrderShareMaxHours))
 		return
 	}
 
@@ -997,21 +1217,10 @@ func (h *TicketHandler) ShareOrder(c *gin.Context) {
 		return
 	}
 
-	// 检查是否已授权
-	var existingAccess models.TicketOrderAccess
-	if err := h.db.Where("ticket_id = ? AND order_id = ?", ticketID, req.OrderID).First(&existingAccess).Error; err == nil {
-		// 已经分享过，无需更新
-	} else {
-		// 创建新授权
-		access := &models.TicketOrderAccess{
-			TicketID:       uint(ticketID),
-			OrderID:        req.OrderID,
-			GrantedBy:      userID,
-			CanView:        true,
-			CanEdit:        false,
-			CanViewPrivacy: false,
-		}
-		h.db.Create(access)
+	// 创建授权，已分享过的按新的有效期和收货信息设置更新
+	if _, _, err := h.orderShareService().Share(uint(ticketID), req.OrderID, userID, opts); err != nil {
+		response.InternalError(c, "Failed to share order")
+		return
 	}
 
 	// 发送系统消息
@@ -1130,7 +1339,7 @@ func (h *TicketHandler) RevokeOrderAccess(c *gin.Context) {
 		return
 	}
 
-	if err := h.db.Where("ticket_id = ? AND order_id = ?", ticketID, orderID).Delete(&models.TicketOrderAccess{}).Error; err != nil {
+	if err := h.orderShareService().Revoke(uint(ticketID), uint(orderID)); err != nil {
 		response.InternalError(c, "Failed to revoke")
 		return
 	}
@@ -1167,7 +1376,6 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 	}
 
 	cfg := config.GetConfig()
-	attachment := cfg.Ticket.Attachment
 
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -1220,6 +1428,53 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 		}
 	}
 
+	fileURL, filename, saved := saveTicketUpload(c, cfg, file, "")
+	if !saved {
+		return
+	}
+
+	if h.pluginManager != nil {
+		afterPayload := map[string]interface{}{
+			"ticket_id":     ticket.ID,
+			"ticket_no":     ticket.TicketNo,
+			"user_id":       userID,
+			"status":        ticket.Status,
+			"filename":      filename,
+			"original_name": file.Filename,
+			"size":          file.Size,
+			"url":           fileURL,
+			"source":        "user_api",
+		}
+		go func(execCtx *service.ExecutionContext, payload map[string]interface{}, uid uint, tid uint) {
+			_, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
+				Hook:    "ticket.attachment.upload.after",
+				Payload: payload,
+			}, execCtx)
+			if hookErr != nil {
+				log.Printf("ticket.attachment.upload.after hook execution failed: user=%d ticket=%d err=%v", uid, tid, hookErr)
+			}
+		}(hookExecCtx, afterPayload, userID, ticket.ID)
+	}
+
+	response.Success(c, gin.H{
+		"url":      fileURL,
+		"filename": filename,
+		"size":     file.Size,
+	})
+}
+
+func truncateString(s string, maxLen int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
+
+// saveTicketUpload 校验附件类型与大小并保存到 uploads/tickets[/subDir]/YYYY/MM/DD，失败时已写入响应
+func saveTicketUpload(c *gin.Context, cfg *config.Config, file *multipart.FileHeader, subDir string) (string, string, bool) {
+	attachment := cfg.Ticket.Attachment
+
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 
 	// 判断文件类型并验证
@@ -1227,7 +1482,7 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 	if isAudio {
 		if attachment != nil && !attachment.EnableVoice {
 			respondUserBizError(c, ticketbiz.VoiceUploadDisabled())
-			return
+			return "", "", false
 		}
 		allowedAudioTypes := []string{".mp3", ".wav", ".m4a", ".ogg", ".aac", ".webm"}
 		audioAllowed := false
@@ -1239,7 +1494,7 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 		}
 		if !audioAllowed {
 			respondUserBizError(c, ticketbiz.AudioFormatInvalid())
-			return
+			return "", "", false
 		}
 		maxSize := int64(10 * 1024 * 1024)
 		if attachment != nil && attachment.MaxVoiceSize > 0 {
@@ -1247,12 +1502,12 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 		}
 		if file.Size > maxSize {
 			respondUserBizError(c, ticketbiz.VoiceFileTooLarge(ticketbiz.BytesToMegabytes(maxSize)))
-			return
+			return "", "", false
 		}
 	} else {
 		if attachment != nil && !attachment.EnableImage {
 			respondUserBizError(c, ticketbiz.ImageUploadDisabled())
-			return
+			return "", "", false
 		}
 		maxSize := int64(5 * 1024 * 1024)
 		if attachment != nil && attachment.MaxImageSize > 0 {
@@ -1260,7 +1515,7 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 		}
 		if file.Size > maxSize {
 			respondUserBizError(c, ticketbiz.ImageFileTooLarge(ticketbiz.BytesToMegabytes(maxSize)))
-			return
+			return "", "", false
 		}
 
 		// 验证图片类型
@@ -1277,49 +1532,119 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 		}
 		if !allowed {
 			respondUserBizError(c, ticketbiz.ImageFormatUnsupported())
-			return
+			return "", "", false
 		}
 	}
 
 	// 保存文件
 	filename := fmt.Sprintf("%s%s", uuid.New().String(), ext)
 	dateDir := time.Now().Format("2006/01/02")
-	targetDir := filepath.Join(cfg.Upload.Dir, "tickets", dateDir)
+	targetDir := filepath.Join(cfg.Upload.Dir, "tickets", subDir, dateDir)
 
 	if err := os.MkdirAll(targetDir, 0755); err != nil {
 		response.InternalError(c, "Failed to create directory")
-		return
+		return "", "", false
 	}
 
 	targetPath := filepath.Join(targetDir, filename)
 	if err := c.SaveUploadedFile(file, targetPath); err != nil {
 		response.InternalError(c, "Failed to save file")
+		return "", "", false
+	}
+
+	urlDir := dateDir
+	if subDir != "" {
+		urlDir = subDir + "/" + dateDir
+	}
+	return fmt.Sprintf("%s/uploads/tickets/%s/%s", cfg.App.URL, urlDir, filename), filename, true
+}
+
+// UploadAttachment 上传消息附件（图片或文档），保存到对象存储，随后在发送消息时通过 attachment_ids 关联
+func (h *TicketHandler) UploadAttachment(c *gin.Context) {
+	userID, userIDOK := middleware.RequireUserID(c)
+	if !userIDOK {
+		return
+	}
+	ticketID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID")
 		return
 	}
 
-	fileURL := fmt.Sprintf("%s/uploads/tickets/%s/%s", cfg.App.URL, dateDir, filename)
+	var ticket models.Ticket
+	if err := h.db.First(&ticket, ticketID).Error; err != nil {
+		response.NotFound(c, "Ticket not found")
+		return
+	}
+	if ticket.UserID != userID {
+		response.Forbidden(c, "No permission to operate this ticket")
+		return
+	}
 
-	if h.pluginManager != nil {
-		afterPayload := map[string]interface{}{
-			"ticket_id":     ticket.ID,
-			"ticket_no":     ticket.TicketNo,
-			"user_id":       userID,
-			"status":        ticket.Status,
-			"filename":      filename,
-			"original_name": file.Filename,
-			"size":          file.Size,
-			"url":           fileURL,
-			"source":        "user_api",
+	file, err := c.FormFile("file")
+	if err != nil {
+		respondUserBizError(c, ticketbiz.FileRequired())
+		return
+	}
+	attachment, err := h.attachmentService().Upload(c.Request.Context(), &ticket, "user", userID, file)
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			log.Printf("ticket attachment upload failed: user=%d ticket=%d err=%v", userID, ticket.ID, err)
+			response.InternalError(c, "Failed to save file")
 		}
-		go func(execCtx *service.ExecutionContext, payload map[string]interface{}, uid uint, tid uint) {
-			_, hookErr := h.pluginManager.ExecuteHook(service.HookExecutionRequest{
-				Hook:    "ticket.attachment.upload.after",
-				Payload: payload,
-			}, execCtx)
-			if hookErr != nil {
-				log.Printf("ticket.attachment.upload.after hook execution failed: user=%d ticket=%d err=%v", uid, tid, hookErr)
-			}
-		}(hookExecCtx, afterPayload, userID, ticket.ID)
+		return
+	}
+	response.Success(c, attachment)
+}
+
+// DownloadAttachment 凭签名链接下载消息附件（无需登录，链接有时效）
+func (h *TicketHandler) DownloadAttachment(c *gin.Context) {
+	attachmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid attachment ID")
+		return
+	}
+	attachment, reader, err := h.attachmentService().OpenSigned(c.Request.Context(), uint(attachmentID), c.Query("expires"), c.Query("sig"))
+	if err != nil {
+		if !respondUserBizError(c, err) {
+			response.InternalError(c, "Failed to read file")
+		}
+		return
+	}
+	defer reader.Close()
+
+	// 图片内联展示，其余文件一律下载，禁止浏览器按内容猜测类型
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		disposition = "inline"
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, reader, nil)
+}
+
+// UploadIntakeAttachment 创建工单前上传截图（用于分类主题模板要求的截图）
+func (h *TicketHandler) UploadIntakeAttachment(c *gin.Context) {
+	if _, userIDOK := middleware.RequireUserID(c); !userIDOK {
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		respondUserBizError(c, ticketbiz.FileRequired())
+		return
+	}
+	// 预上传仅接受图片截图
+	if strings.HasPrefix(file.Header.Get("Content-Type"), "audio/") {
+		respondUserBizError(c, ticketbiz.ImageFormatUnsupported())
+		return
+	}
+
+	cfg := config.GetConfig()
+	fileURL, filename, saved := saveTicketUpload(c, cfg, file, ticketIntakeUploadDir)
+	if !saved {
+		return
 	}
 
 	response.Success(c, gin.H{
@@ -1329,10 +1654,66 @@ func (h *TicketHandler) UploadFile(c *gin.Context) {
 	})
 }
 
-func truncateString(s string, maxLen int) string {
-	s = strings.TrimSpace(s)
-	if len(s) <= maxLen {
-		return s
+// ticketIntakeUploadDir 创建工单前预上传截图的子目录
+const ticketIntakeUploadDir = "intake"
+
+// normalizeTicketIntakeAttachments 校验预上传截图URL，仅允许本站 intake 目录下的图片
+func normalizeTicketIntakeAttachments(cfg *config.Config, raw []string) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
 	}
-	return s[:maxLen-3] + "..."
+	if len(raw) > maxTicketIntakeAttachments {
+		return nil, ticketbiz.AttachmentInvalid()
+	}
+
+	allowedTypes := []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+	if cfg.Ticket.Attachment != nil && len(cfg.Ticket.Attachment.AllowedImageTypes) > 0 {
+		allowedTypes = cfg.Ticket.Attachment.AllowedImageTypes
+	}
+	prefix := fmt.Sprintf("%s/uploads/tickets/%s/", cfg.App.URL, ticketIntakeUploadDir)
+
+	result := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		fileURL := strings.TrimSpace(item)
+		if fileURL == "" {
+			continue
+		}
+		if !strings.HasPrefix(fileURL, prefix) || strings.Contains(fileURL, "..") || strings.ContainsAny(fileURL, "?#()[] ") {
+			return nil, ticketbiz.AttachmentInvalid()
+		}
+		ext := strings.ToLower(filepath.Ext(fileURL))
+		allowed := false
+		for _, t := range allowedTypes {
+			if ext == strings.ToLower(t) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, ticketbiz.AttachmentInvalid()
+		}
+		if _, exists := seen[fileURL]; exists {
+			continue
+		}
+		seen[fileURL] = struct{}{}
+		result = append(result, fileURL)
+	}
+	return result, nil
+}
+
+// buildTicketIntakeMessage 组合首条消息：结构化字段摘要 + 用户描述 + 截图
+func buildTicketIntakeMessage(topic *config.TicketTopicConfig, intakeData map[string]string, content string, attachments []string) string {
+	var builder strings.Builder
+	if summary := ticketbiz.FormatIntakeSummary(topic, intakeData); summary != "" {
+		builder.WriteString(summary)
+		builder.WriteString("\n")
+	}
+	builder.WriteString(content)
+	for _, fileURL := range attachments {
+		builder.WriteString("\n\n![screenshot](")
+		builder.WriteString(fileURL)
+		builder.WriteString(")")
+	}
+	return builder.String()
 }
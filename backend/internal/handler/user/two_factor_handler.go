@@ -0,0 +1,266 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/database"
+	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *service.TwoFactorService
+	authService      *service.AuthService
+}
+
+func NewTwoFactorHandler(twoFactorService *service.TwoFactorService, authService *service.AuthService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService, authService: authService}
+}
+
+// TwoFactorCodeRequest 验证器验证码或备用码
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// respondTwoFactorChallenge 登录第一步通过后，如需两步验证则返回受限令牌并结束请求
+// 响应中不包含 token 字段，前端代理不会将其保存为会话
+func respondTwoFactorChallenge(c *gin.Context, user *models.User) bool {
+	scope := service.TwoFactorChallengeScope(user)
+	if scope == jwt.ScopeFull {
+		return false
+	}
+	token, err := service.TwoFactorChallengeToken(user, scope)
+	if err != nil {
+		response.InternalError(c, "Failed to generate token")
+		return true
+	}
+	response.Success(c, gin.H{
+		"two_factor_required": true,
+		"two_factor_setup":    scope == jwt.ScopeTwoFactorSetup,
+		"two_factor_token":    token,
+	})
+	return true
+}
+
+func respondTwoFactorError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, service.ErrTwoFactorUserNotFound) {
+		response.NotFound(c, "User not found")
+		return
+	}
+	if !respondUserBizError(c, err) {
+		response.InternalError(c, fallback)
+	}
+}
+
+// completeTwoFactorLogin 两步验证通过后签发完整会话，响应格式与密码登录一致
+func (h *TwoFactorHandler) completeTwoFactorLogin(c *gin.Context, userID uint, extra gin.H) {
+	var user models.User
+	if err := database.GetDB().First(&user, userID).Error; err != nil {
+		response.Unauthorized(c, "Invalid authentication token")
+		return
+	}
+	respondLoginSession(c, h.authService, &user, extra)
+}
+
+// respondLoginSession 为已通过全部登录校验的用户签发完整会话，供两步验证与通行密钥登录共用
+func respondLoginSession(c *gin.Context, authService *service.AuthService, user *models.User, extra gin.H) {
+	db := database.GetDB()
+	token, err := authService.GenerateToken(user)
+	if err != nil {
+		response.InternalError(c, "Failed to generate token")
+		return
+	}
+	user.LastLoginIP = utils.GetRealIP(c)
+	authService.UpdateLoginIP(user)
+	logger.LogLoginAttempt(db, c, user.Email, true, &user.ID)
+
+	result := gin.H{
+		"id":                user.ID,
+		"user_id":           user.ID,
+		"uuid":              user.UUID,
+		"email":             user.Email,
+		"name":              user.Name,
+		"role":              user.Role,
+		"avatar":            user.Avatar,
+		"locale":            user.Locale,
+		"total_spent_minor": user.TotalSpentMinor,
+		"total_order_count": user.TotalOrderCount,
+	}
+	if user.IsAdmin() {
+		result["permissions"] = loadEffectiveAdminPermissions(db, user.ID, user.Role)
+	}
+	data := gin.H{
+		"token":      token,
+		"token_type": "Bearer",
+		"user":       result,
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+	response.Success(c, data)
+}
+
+// GetStatus 两步验证状态
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	status, err := h.twoFactorService.Status(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to get two-factor status")
+		return
+	}
+	response.Success(c, status)
+}
+
+// Setup 生成验证器密钥与二维码（完整会话或管理员强制绑定的受限会话）
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	setup, err := h.twoFactorService.BeginSetup(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to start two-factor setup")
+		return
+	}
+	response.Success(c, setup)
+}
+
+// Enable 校验首个验证码完成绑定；受限会话绑定成功后直接完成登录
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	backupCodes, err := h.twoFactorService.Enable(userID, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+	logger.LogOperation(database.GetDB(), c, "enable_two_factor", "user", &userID, nil)
+	if middleware.GetAuthScope(c) == jwt.ScopeTwoFactorSetup {
+		h.completeTwoFactorLogin(c, userID, gin.H{"backup_codes": backupCodes})
+		return
+	}
+	response.Success(c, gin.H{"backup_codes": backupCodes})
+}
+
+// Disable 关闭两步验证
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if err := h.twoFactorService.Disable(userID, req.Code); err != nil {
+		respondTwoFactorError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+	logger.LogOperation(database.GetDB(), c, "disable_two_factor", "user", &userID, nil)
+	response.Success(c, gin.H{"enabled": false})
+}
+
+// RegenerateBackupCodes 重新生成备用码
+func (h *TwoFactorHandler) RegenerateBackupCodes(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	backupCodes, err := h.twoFactorService.RegenerateBackupCodes(userID, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to regenerate backup codes")
+		return
+	}
+	logger.LogOperation(database.GetDB(), c, "regenerate_two_factor_backup_codes", "user", &userID, nil)
+	response.Success(c, gin.H{"backup_codes": backupCodes})
+}
+
+// Verify 登录第二步：校验验证码或备用码后签发完整会话
+func (h *TwoFactorHandler) Verify(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if err := h.twoFactorService.Verify(userID, req.Code); err != nil {
+		email, _ := c.Get("user_email")
+		emailStr, _ := email.(string)
+		logger.LogLoginAttempt(database.GetDB(), c, emailStr, false, &userID)
+		respondTwoFactorError(c, err, "Two-factor verification failed")
+		return
+	}
+	h.completeTwoFactorLogin(c, userID, nil)
+}
+
+// SendRecoveryCode 丢失验证器时发送邮箱找回验证码
+func (h *TwoFactorHandler) SendRecoveryCode(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	if inCooldown, message := h.twoFactorService.RecoveryCooldown(utils.GetRealIP(c), userID); inCooldown {
+		response.Error(c, 429, response.CodeCooldown, message)
+		return
+	}
+	email, err := h.twoFactorService.SendRecoveryCode(userID)
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to send recovery code")
+		return
+	}
+	response.Success(c, gin.H{"email": email})
+}
+
+// Recover 使用邮箱验证码关闭两步验证并完成登录；管理员强制开启时需重新绑定
+func (h *TwoFactorHandler) Recover(c *gin.Context) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindingError(c, err)
+		return
+	}
+	if err := h.twoFactorService.Recover(userID, req.Code); err != nil {
+		respondTwoFactorError(c, err, "Two-factor recovery failed")
+		return
+	}
+	db := database.GetDB()
+	logger.LogOperation(db, c, "recover_two_factor", "user", &userID, nil)
+
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		response.Unauthorized(c, "Invalid authentication token")
+		return
+	}
+	if respondTwoFactorChallenge(c, &user) {
+		return
+	}
+	h.completeTwoFactorLogin(c, userID, nil)
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"errors"
+
+	"auralogic/internal/middleware"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type WaitingRoomHandler struct {
+	waitingRoomService *service.WaitingRoomService
+}
+
+func NewWaitingRoomHandler(waitingRoomService *service.WaitingRoomService) *WaitingRoomHandler {
+	return &WaitingRoomHandler{waitingRoomService: waitingRoomService}
+}
+
+// Join 加入商品等候室
+func (h *WaitingRoomHandler) Join(c *gin.Context) {
+	h.respondTicket(c, h.waitingRoomService.Join)
+}
+
+// Poll 查询排队位置，客户端按 poll_after_seconds 间隔轮询以保持排队资格
+func (h *WaitingRoomHandler) Poll(c *gin.Context) {
+	h.respondTicket(c, h.waitingRoomService.Poll)
+}
+
+func (h *WaitingRoomHandler) respondTicket(c *gin.Context, fn func(productID, userID uint) (*service.WaitingRoomTicket, error)) {
+	userID, ok := middleware.RequireUserID(c)
+	if !ok {
+		return
+	}
+	productID, err := middleware.GetUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, "Invalid product ID")
+		return
+	}
+
+	ticket, err := fn(productID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrProductNotFound) {
+			response.NotFound(c, "Product not found")
+			return
+		}
+		if respondUserBizError(c, err) {
+			return
+		}
+		response.InternalError(c, "Failed to get waiting room status")
+		return
+	}
+	response.Success(c, ticket)
+}
@@ -0,0 +1,295 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	adminAuditRoutePrefix     = "/api/admin/"
+	adminAuditResourceType    = "admin_audit"
+	adminAuditRedacted        = "[REDACTED]"
+	adminAuditResponseHeadMax = 256
+)
+
+// adminAuditSensitiveParts 字段名（去掉 _ 和 -、小写后）包含这些片段即脱敏
+var adminAuditSensitiveParts = []string{
+	"password", "passwd", "secret", "token", "apikey", "privatekey", "authorization", "credential", "cookie",
+}
+
+// adminAuditSensitiveNames 字段名完全匹配时脱敏（片段过短，按包含匹配误伤太多）
+var adminAuditSensitiveNames = map[string]struct{}{
+	"otp": {}, "cvv": {}, "cvc": {}, "pin": {}, "cardnumber": {}, "signature": {},
+}
+
+// 统一响应体的 code 字段位于首位，只需读取响应开头
+var adminAuditResponseCodePattern = regexp.MustCompile(`^\s*\{\s*"code"\s*:\s*(-?\d+)`)
+
+// AdminAuditConfigResolver 运行时读取审计配置，返回 nil 表示不审计
+type AdminAuditConfigResolver func() *config.AdminAuditConfig
+
+// AdminAudit 记录管理端写操作（POST/PUT/PATCH/DELETE）：脱敏后的请求体、查询参数、HTTP 状态码和业务码写入操作日志。
+// 按路由分组（/api/admin/ 后第一段）启用或排除，挂在 /api/admin 上，认证在分组内完成，处理结束后可读取操作者
+func AdminAudit(db *gorm.DB, resolve AdminAuditConfigResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isAdminAuditMethod(c.Request.Method) || resolve == nil {
+			c.Next()
+			return
+		}
+		cfg := resolve()
+		group := adminAuditGroup(c.FullPath())
+		if cfg == nil || !cfg.Enabled || group == "" || !adminAuditGroupEnabled(cfg, group) {
+			c.Next()
+			return
+		}
+
+		redact := newAdminAuditRedactor(cfg.RedactFields)
+		details := map[string]interface{}{
+			"method": c.Request.Method,
+			"path":   c.Request.URL.Path,
+			"route":  c.FullPath(),
+			"group":  group,
+		}
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			details["query"] = redact.values(query)
+		}
+		captureAdminAuditBody(c, cfg.MaxBodyBytes, redact, details)
+
+		writer := &adminAuditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		start := time.Now()
+
+		c.Next()
+
+		details["status"] = writer.Status()
+		details["duration_ms"] = time.Since(start).Milliseconds()
+		if match := adminAuditResponseCodePattern.FindSubmatch(writer.head.Bytes()); match != nil {
+			if code, err := strconv.Atoi(string(match[1])); err == nil {
+				details["response_code"] = code
+			}
+		}
+
+		var resourceID *uint
+		if id, err := strconv.ParseUint(c.Param("id"), 10, 64); err == nil && id > 0 {
+			value := uint(id)
+			resourceID = &value
+		}
+		logger.LogOperation(db, c, adminAuditAction(c.Request.Method), adminAuditResourceType, resourceID, details)
+	}
+}
+
+func isAdminAuditMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func adminAuditAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+// adminAuditGroup 从路由模板取分组名，未匹配路由（404）返回空
+func adminAuditGroup(route string) string {
+	if !strings.HasPrefix(route, adminAuditRoutePrefix) {
+		return ""
+	}
+	group := strings.TrimPrefix(route, adminAuditRoutePrefix)
+	if index := strings.Index(group, "/"); index >= 0 {
+		group = group[:index]
+	}
+	return group
+}
+
+func adminAuditGroupEnabled(cfg *config.AdminAuditConfig, group string) bool {
+	for _, excluded := range cfg.ExcludeGroups {
+		if strings.EqualFold(strings.TrimSpace(excluded), group) {
+			return false
+		}
+	}
+	if len(cfg.Groups) == 0 {
+		return true
+	}
+	for _, included := range cfg.Groups {
+		if strings.EqualFold(strings.TrimSpace(included), group) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureAdminAuditBody 读取请求体并还原供处理器绑定；文件上传只记录类型和大小
+func captureAdminAuditBody(c *gin.Context, maxBytes int, redact adminAuditRedactor, details map[string]interface{}) {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return
+	}
+	contentType := c.ContentType()
+	if strings.HasPrefix(contentType, "multipart/") || strings.HasPrefix(contentType, "application/octet-stream") {
+		details["request_body"] = map[string]interface{}{
+			"content_type": contentType,
+			"size":         c.Request.ContentLength,
+		}
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return
+	}
+
+	var payload interface{}
+	switch contentType {
+	case "application/x-www-form-urlencoded":
+		values, parseErr := url.ParseQuery(string(body))
+		if parseErr != nil {
+			details["request_body"] = map[string]interface{}{"content_type": contentType, "size": len(body)}
+			return
+		}
+		payload = redact.values(values)
+	default:
+		if err := json.Unmarshal(body, &payload); err != nil {
+			// 非 JSON 内容可能包含任意敏感信息，不记录原文
+			details["request_body"] = map[string]interface{}{"content_type": contentType, "size": len(body)}
+			return
+		}
+		payload = redact.value(payload)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err == nil && len(encoded) <= maxBytes {
+		details["request_body"] = payload
+		return
+	}
+	// 超出上限时只保留顶层字段名，证明提交了哪些字段
+	summary := map[string]interface{}{"truncated": true, "size": len(body)}
+	if object, ok := payload.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		summary["fields"] = keys
+	}
+	details["request_body"] = summary
+}
+
+type adminAuditRedactor struct {
+	extra map[string]struct{}
+}
+
+func newAdminAuditRedactor(fields []string) adminAuditRedactor {
+	extra := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if name := normalizeAdminAuditField(field); name != "" {
+			extra[name] = struct{}{}
+		}
+	}
+	return adminAuditRedactor{extra: extra}
+}
+
+func normalizeAdminAuditField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", "")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+func (r adminAuditRedactor) sensitive(name string) bool {
+	normalized := normalizeAdminAuditField(name)
+	if _, ok := adminAuditSensitiveNames[normalized]; ok {
+		return true
+	}
+	if _, ok := r.extra[normalized]; ok {
+		return true
+	}
+	for _, part := range adminAuditSensitiveParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r adminAuditRedactor) value(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			if r.sensitive(key) {
+				result[key] = adminAuditRedacted
+				continue
+			}
+			result[key] = r.value(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, item := range typed {
+			result[i] = r.value(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func (r adminAuditRedactor) values(values url.Values) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, items := range values {
+		switch {
+		case r.sensitive(key):
+			result[key] = adminAuditRedacted
+		case len(items) == 1:
+			result[key] = items[0]
+		default:
+			result[key] = items
+		}
+	}
+	return result
+}
+
+// adminAuditResponseWriter 保留响应开头用于解析业务码，不缓存完整响应
+type adminAuditResponseWriter struct {
+	gin.ResponseWriter
+	head bytes.Buffer
+}
+
+func (w *adminAuditResponseWriter) capture(data []byte) {
+	if remaining := adminAuditResponseHeadMax - w.head.Len(); remaining > 0 {
+		if len(data) > remaining {
+			data = data[:remaining]
+		}
+		w.head.Write(data)
+	}
+}
+
+func (w *adminAuditResponseWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *adminAuditResponseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAdminAuditRecordsRedactedMutations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	cfg := &config.AdminAuditConfig{
+		Enabled:       true,
+		ExcludeGroups: []string{"upload"},
+		MaxBodyBytes:  1024,
+		RedactFields:  []string{"webhook_url"},
+	}
+	router := gin.New()
+	admin := router.Group("/api/admin")
+	admin.Use(AdminAudit(db, func() *config.AdminAuditConfig { return cfg }))
+	admin.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(7))
+		c.Next()
+	})
+	var boundName string
+	admin.PUT("/users/:id", func(c *gin.Context) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		_ = c.ShouldBindJSON(&req)
+		boundName = req.Name
+		response.Success(c, nil)
+	})
+	admin.POST("/upload/image", func(c *gin.Context) { response.Success(c, nil) })
+	admin.GET("/users/:id", func(c *gin.Context) { response.Success(c, nil) })
+	admin.DELETE("/orders/:id", func(c *gin.Context) { response.BadRequest(c, "cannot delete") })
+
+	body := `{"name":"Alice","password":"hunter2","webhook_url":"https://hooks.example.com/x","nested":{"api_key":"k"}}`
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/api/admin/users/12?access_token=abc&page=2", strings.NewReader(body)),
+		httptest.NewRequest(http.MethodPost, "/api/admin/upload/image", strings.NewReader(`{}`)),
+		httptest.NewRequest(http.MethodGet, "/api/admin/users/12", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/admin/orders/3", nil),
+	}
+	for _, req := range requests {
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if boundName != "Alice" {
+		t.Fatalf("expected handler to bind the restored body, got %q", boundName)
+	}
+
+	var logs []models.OperationLog
+	if err := db.Order("id ASC").Find(&logs).Error; err != nil {
+		t.Fatalf("load logs: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 audit logs (excluded group and GET skipped), got %d", len(logs))
+	}
+
+	update := logs[0]
+	if update.Action != "update" || update.ResourceType != "admin_audit" || update.ResourceID == nil || *update.ResourceID != 12 {
+		t.Fatalf("unexpected audit log %+v", update)
+	}
+	if update.UserID == nil || *update.UserID != 7 {
+		t.Fatalf("expected operator user_id 7, got %v", update.UserID)
+	}
+	if update.Details["route"] != "/api/admin/users/:id" || update.Details["group"] != "users" {
+		t.Fatalf("unexpected route details %v", update.Details)
+	}
+	if update.Details["status"] != float64(http.StatusOK) || update.Details["response_code"] != float64(0) {
+		t.Fatalf("expected status 200 / code 0, got %v / %v", update.Details["status"], update.Details["response_code"])
+	}
+	requestBody, ok := update.Details["request_body"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected request body object, got %T", update.Details["request_body"])
+	}
+	if requestBody["name"] != "Alice" || requestBody["password"] != adminAuditRedacted || requestBody["webhook_url"] != adminAuditRedacted {
+		t.Fatalf("unexpected redaction %v", requestBody)
+	}
+	if nested, _ := requestBody["nested"].(map[string]interface{}); nested["api_key"] != adminAuditRedacted {
+		t.Fatalf("expected nested api_key to be redacted, got %v", requestBody["nested"])
+	}
+	query, _ := update.Details["query"].(map[string]interface{})
+	if query["access_token"] != adminAuditRedacted || query["page"] != "2" {
+		t.Fatalf("unexpected query redaction %v", query)
+	}
+
+	deletion := logs[1]
+	if deletion.Action != "delete" || deletion.Details["status"] != float64(http.StatusBadRequest) || deletion.Details["response_code"] != float64(response.CodeParamError) {
+		t.Fatalf("unexpected delete audit %+v", deletion.Details)
+	}
+}
+
+func TestAdminAuditGroupSelection(t *testing.T) {
+	cfg := &config.AdminAuditConfig{Groups: []string{"orders", "Settings"}, ExcludeGroups: []string{"settings"}}
+	if !adminAuditGroupEnabled(cfg, "orders") {
+		t.Fatal("expected listed group to be audited")
+	}
+	if adminAuditGroupEnabled(cfg, "settings") {
+		t.Fatal("expected exclusion to win over inclusion")
+	}
+	if adminAuditGroupEnabled(cfg, "products") {
+		t.Fatal("expected unlisted group to be skipped")
+	}
+	if got := adminAuditGroup("/api/admin/orders/:id/ship"); got != "orders" {
+		t.Fatalf("expected orders group, got %q", got)
+	}
+	if got := adminAuditGroup(""); got != "" {
+		t.Fatalf("expected unmatched route to have no group, got %q", got)
+	}
+}
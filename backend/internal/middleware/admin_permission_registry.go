@@ -19,6 +19,9 @@ var adminPermissionGroups = []AdminPermissionGroup{
 			"order.refund",
 			"order.assign_tracking",
 			"order.request_resubmit",
+			"order.price_adjust",
+			"order.price_approve",
+			"order.risk_review",
 		},
 	},
 	{
@@ -27,6 +30,21 @@ var adminPermissionGroups = []AdminPermissionGroup{
 			"product.view",
 			"product.edit",
 			"product.delete",
+			"product.script_approve",
+		},
+	},
+	{
+		Name: "VendorPermission",
+		Permissions: []string{
+			"vendor.view",
+			"vendor.manage",
+		},
+	},
+	{
+		Name: "BusinessAccountPermission",
+		Permissions: []string{
+			"business_account.view",
+			"business_account.manage",
 		},
 	},
 	{
@@ -36,12 +54,20 @@ var adminPermissionGroups = []AdminPermissionGroup{
 			"serial.manage",
 		},
 	},
+	{
+		Name: "UsagePermission",
+		Permissions: []string{
+			"usage.view",
+			"usage.report",
+		},
+	},
 	{
 		Name: "UserPermission",
 		Permissions: []string{
 			"user.view",
 			"user.edit",
 			"user.permission",
+			"user.blocklist",
 		},
 	},
 	{
@@ -103,6 +129,13 @@ var adminPermissionGroups = []AdminPermissionGroup{
 			"announcement.edit",
 		},
 	},
+	{
+		Name: "ModerationPermission",
+		Permissions: []string{
+			"moderation.view",
+			"moderation.manage",
+		},
+	},
 	{
 		Name: "MarketingPermission",
 		Permissions: []string{
@@ -116,6 +149,7 @@ var adminPermissionGroups = []AdminPermissionGroup{
 			"ticket.view",
 			"ticket.reply",
 			"ticket.status_update",
+			"ticket.dispute",
 		},
 	},
 	{
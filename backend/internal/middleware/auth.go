@@ -11,6 +11,7 @@ import (
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/jwt"
 	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,31 +22,9 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 优先尝试 JWT Bearer Token
 		if tokenString := extractBearerToken(c); tokenString != "" {
-			claims, err := jwt.ParseToken(tokenString)
-			if err != nil {
-				response.Error(c, 401, response.CodeTokenInvalid, "Invalid authentication token")
-				c.Abort()
-				return
-			}
-
-			db := database.GetDB()
-			var user models.User
-			if err := db.Select("id", "email", "role", "is_active").First(&user, claims.UserID).Error; err != nil {
-				response.Unauthorized(c, "Invalid authentication token")
-				c.Abort()
-				return
+			if authenticateJWT(c, tokenString, jwt.ScopeFull) {
+				c.Next()
 			}
-			if !user.IsActive {
-				response.Unauthorized(c, "User account has been disabled")
-				c.Abort()
-				return
-			}
-
-			c.Set("auth_type", "jwt")
-			c.Set("user_id", user.ID)
-			c.Set("user_email", user.Email)
-			c.Set("user_role", user.Role)
-			c.Next()
 			return
 		}
 
@@ -80,6 +59,11 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.Set("api_scopes", key.Scopes)
 			c.Set("api_platform", key.Platform)
 
+			// Key 在认证后才能确定，api_key 策略在这里执行，覆盖所有接受 API Key 的路由组
+			if !applyAPIKeyRateLimitPolicy(c) {
+				return
+			}
+
 			// 异步更新最后使用时间
 			go func() {
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -97,18 +81,139 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// TwoFactorSessionMiddleware 两步验证接口的认证中间件，只接受 JWT，且令牌作用域须在 scopes 中；
+// 包含 jwt.ScopeFull 时也接受完整会话
+func TwoFactorSessionMiddleware(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := extractBearerToken(c)
+		if tokenString == "" {
+			response.Unauthorized(c, "Missing authentication token")
+			c.Abort()
+			return
+		}
+		if authenticateJWT(c, tokenString, scopes...) {
+			c.Next()
+		}
+	}
+}
+
+// authenticateJWT 校验令牌、作用域与用户状态并写入上下文，失败时已中止请求
+func authenticateJWT(c *gin.Context, tokenString string, scopes ...string) bool {
+	claims, err := jwt.ParseToken(tokenString)
+	if err != nil {
+		response.Error(c, 401, response.CodeTokenInvalid, "Invalid authentication token")
+		c.Abort()
+		return false
+	}
+	allowed := false
+	for _, scope := range scopes {
+		if claims.Scope == scope {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		if claims.Scope == jwt.ScopeFull {
+			response.Forbidden(c, "This endpoint is only available during two-factor sign-in")
+		} else {
+			response.Error(c, 401, response.CodeTokenInvalid, "Two-factor verification is required")
+		}
+		c.Abort()
+		return false
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Select("id", "email", "role", "is_active").First(&user, claims.UserID).Error; err != nil {
+		response.Unauthorized(c, "Invalid authentication token")
+		c.Abort()
+		return false
+	}
+	if !user.IsActive {
+		response.Unauthorized(c, "User account has been disabled")
+		c.Abort()
+		return false
+	}
+
+	c.Set("auth_type", "jwt")
+	c.Set("auth_scope", claims.Scope)
+	c.Set("user_id", user.ID)
+	c.Set("user_email", user.Email)
+	c.Set("user_role", user.Role)
+	return true
+}
+
+// GetAuthScope 当前 JWT 会话的作用域，完整会话为 jwt.ScopeFull
+func GetAuthScope(c *gin.Context) string {
+	scope, _ := c.Get("auth_scope")
+	value, _ := scope.(string)
+	return value
+}
+
 // IsAPIKeyAuth 检查当前请求是否为 API Key 认证
 func IsAPIKeyAuth(c *gin.Context) bool {
 	authType, exists := c.Get("auth_type")
 	return exists && authType == "api_key"
 }
 
+// PersonalAccessTokenAuth 个人访问令牌认证，仅用于只读自动化接口，令牌必须拥有指定权限范围
+// 通用的 AuthMiddleware 不接受个人访问令牌，令牌无法用于下单或管理账户
+func PersonalAccessTokenAuth(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := extractBearerToken(c)
+		if !strings.HasPrefix(tokenString, models.PersonalAccessTokenPrefix) {
+			response.Unauthorized(c, "Missing personal access token")
+			c.Abort()
+			return
+		}
+
+		db := database.GetDB()
+		var token models.PersonalAccessToken
+		if err := db.Where("token_hash = ?", models.HashPersonalAccessToken(tokenString)).First(&token).Error; err != nil || !token.IsUsable() {
+			response.Error(c, 401, response.CodeTokenInvalid, "Invalid or expired personal access token")
+			c.Abort()
+			return
+		}
+		if !token.HasScope(scope) {
+			response.Forbidden(c, "Personal access token is missing scope "+scope)
+			c.Abort()
+			return
+		}
+
+		var user models.User
+		if err := db.Select("id", "email", "role", "is_active").First(&user, token.UserID).Error; err != nil || !user.IsActive {
+			response.Unauthorized(c, "User account has been disabled")
+			c.Abort()
+			return
+		}
+
+		c.Set("auth_type", "personal_token")
+		c.Set("user_id", user.ID)
+		c.Set("user_email", user.Email)
+		c.Set("user_role", user.Role)
+		c.Set("personal_token_id", token.ID)
+
+		// 异步记录最后使用时间与来源 IP
+		clientIP := utils.GetRealIP(c)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			db.WithContext(ctx).Model(&token).Updates(map[string]interface{}{
+				"last_used_at": models.NowFunc(),
+				"last_used_ip": clientIP,
+			})
+		}()
+
+		c.Next()
+	}
+}
+
 // OptionalAuthMiddleware 可选的认证中间件
 func OptionalAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if tokenString := extractBearerToken(c); tokenString != "" {
 			claims, err := jwt.ParseToken(tokenString)
-			if err == nil {
+			if err == nil && claims.Scope == jwt.ScopeFull {
 				db := database.GetDB()
 				if db != nil {
 					var user models.User
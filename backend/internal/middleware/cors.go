@@ -15,7 +15,7 @@ func CORS(cfg *config.CORSConfig) gin.HandlerFunc {
 			AllowOrigins:     append([]string(nil), cfg.AllowedOrigins...),
 			AllowMethods:     append([]string(nil), cfg.AllowedMethods...),
 			AllowHeaders:     append([]string(nil), cfg.AllowedHeaders...),
-			ExposeHeaders:    []string{"Content-Length"},
+			ExposeHeaders:    []string{"Content-Length", SiteBannerVersionHeader, TraceIDHeader},
 			AllowCredentials: true,
 			MaxAge:           time.Duration(cfg.MaxAge) * time.Second,
 		}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"auralogic/internal/pkg/tracing"
 	"auralogic/internal/pkg/utils"
 )
 
@@ -19,24 +20,31 @@ func Logger() gin.HandlerFunc {
 
 		duration := time.Since(start)
 		statusCode := c.Writer.Status()
+		// 启用链路追踪时附带 trace ID，便于从慢请求日志跳转到完整链路
+		traceSuffix := ""
+		if traceID := tracing.TraceIDFromContext(c.Request.Context()); traceID != "" {
+			traceSuffix = " - trace=" + traceID
+		}
 
 		// 如果有Error，记录ErrorInfo
 		if len(c.Errors) > 0 {
-			log.Printf("[%s] %s %s - %d - %v - ERROR: %v",
+			log.Printf("[%s] %s %s - %d - %v - ERROR: %v%s",
 				method,
 				path,
 				utils.GetRealIP(c),
 				statusCode,
 				duration,
 				c.Errors.String(),
+				traceSuffix,
 			)
 		} else {
-			log.Printf("[%s] %s %s - %d - %v",
+			log.Printf("[%s] %s %s - %d - %v%s",
 				method,
 				path,
 				utils.GetRealIP(c),
 				statusCode,
 				duration,
+				traceSuffix,
 			)
 		}
 	}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orderItemsBody struct {
+	Items []struct {
+		SKU string `json:"sku"`
+	} `json:"items"`
+}
+
+// readOrderItemSKUs 读取下单请求中的商品 SKU 并还原请求体供后续处理器绑定
+// 请求体无法读取时返回 error；格式错误时 ok 为 false，交给处理器返回参数错误
+func readOrderItemSKUs(c *gin.Context) (skus []string, ok bool, err error) {
+	if c.Request.Body == nil {
+		return nil, false, nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload orderItemsBody
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false, nil
+	}
+	skus = make([]string, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		if sku := strings.TrimSpace(item.SKU); sku != "" {
+			skus = append(skus, sku)
+		}
+	}
+	return skus, true, nil
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceFingerprintHeader 前端提交设备指纹的请求头
+const DeviceFingerprintHeader = "X-Device-Fingerprint"
+
+// OrderRateCapGate 指定商品的下单频率限制
+type OrderRateCapGate interface {
+	RequiresOrderRateCap() bool
+	AcquireOrderSlots(userID uint, ip, device string, skus []string) (release func(), err error)
+}
+
+// OrderRateCapMiddleware 在 CreateOrder 之前按用户/IP/设备指纹占用下单名额，下单失败时归还
+func OrderRateCapMiddleware(gate OrderRateCapGate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if gate == nil || !gate.RequiresOrderRateCap() {
+			c.Next()
+			return
+		}
+		userID, _ := GetUserID(c)
+
+		skus, parsed, err := readOrderItemSKUs(c)
+		if err != nil {
+			response.BadRequest(c, "Invalid request parameters")
+			c.Abort()
+			return
+		}
+		if !parsed || len(skus) == 0 {
+			c.Next()
+			return
+		}
+
+		release, err := gate.AcquireOrderSlots(userID, utils.GetRealIP(c), c.GetHeader(DeviceFingerprintHeader), skus)
+		if err != nil {
+			var bizErr *bizerr.Error
+			if errors.As(err, &bizErr) {
+				response.RespondBizError(c, bizErr)
+			} else {
+				response.InternalError(c, "Failed to check order rate limit")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if c.Writer.Status() != http.StatusOK && release != nil {
+			release()
+		}
+	}
+}
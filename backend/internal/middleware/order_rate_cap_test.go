@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeOrderRateCapGate struct {
+	gotDevice string
+	released  int
+}
+
+func (g *fakeOrderRateCapGate) RequiresOrderRateCap() bool { return true }
+
+func (g *fakeOrderRateCapGate) AcquireOrderSlots(userID uint, ip, device string, skus []string) (func(), error) {
+	g.gotDevice = device
+	return func() { g.released++ }, nil
+}
+
+func TestOrderRateCapMiddlewareReleasesSlotsOnFailedOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gate := &fakeOrderRateCapGate{}
+	status := http.StatusOK
+
+	router := gin.New()
+	router.POST("/orders", OrderRateCapMiddleware(gate), func(c *gin.Context) {
+		c.Status(status)
+	})
+
+	send := func() {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"items":[{"sku":"DROP-1"}]}`))
+		req.Header.Set(DeviceFingerprintHeader, "fp-1")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	send()
+	if gate.gotDevice != "fp-1" || gate.released != 0 {
+		t.Fatalf("successful order must keep its slot: device=%q released=%d", gate.gotDevice, gate.released)
+	}
+	status = http.StatusBadRequest
+	send()
+	if gate.released != 1 {
+		t.Fatalf("failed order must release its slot, released=%d", gate.released)
+	}
+}
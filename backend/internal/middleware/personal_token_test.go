@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPersonalAccessTokenAuthEnforcesScopeAndState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.PersonalAccessToken{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	oldDB := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = oldDB })
+
+	user := models.User{UUID: "pat-user", Email: "reseller@example.com", Role: "user", IsActive: true}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	past := time.Now().Add(-time.Hour)
+	tokens := map[string]*models.PersonalAccessToken{
+		"alpat_orders":  {Scopes: []string{models.PersonalTokenScopeOrdersRead}},
+		"alpat_expired": {Scopes: []string{models.PersonalTokenScopeOrdersRead}, ExpiresAt: &past},
+		"alpat_revoked": {Scopes: []string{models.PersonalTokenScopeOrdersRead}, RevokedAt: &past},
+	}
+	for raw, token := range tokens {
+		token.UserID = user.ID
+		token.Name = raw
+		token.TokenHash = models.HashPersonalAccessToken(raw)
+		if err := db.Create(token).Error; err != nil {
+			t.Fatalf("create token: %v", err)
+		}
+	}
+
+	router := gin.New()
+	router.GET("/orders", PersonalAccessTokenAuth(models.PersonalTokenScopeOrdersRead), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		c.String(http.StatusOK, "%d", userID)
+	})
+	router.GET("/virtual", PersonalAccessTokenAuth(models.PersonalTokenScopeVirtualProductsRead), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		path  string
+		token string
+		want  int
+	}{
+		{"/orders", "alpat_orders", http.StatusOK},
+		{"/virtual", "alpat_orders", http.StatusForbidden},
+		{"/orders", "alpat_expired", http.StatusUnauthorized},
+		{"/orders", "alpat_revoked", http.StatusUnauthorized},
+		{"/orders", "alpat_unknown", http.StatusUnauthorized},
+		{"/orders", "some.jwt.token", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != tc.want {
+			t.Fatalf("%s with %s: expected %d, got %d", tc.path, tc.token, tc.want, recorder.Code)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var used models.PersonalAccessToken
+		if err := db.First(&used, tokens["alpat_orders"].ID).Error; err != nil {
+			t.Fatalf("reload token: %v", err)
+		}
+		if used.LastUsedAt != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected last_used_at to be recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/response"
+	"auralogic/internal/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+// 按经过时间补充各维度的令牌，全部桶都有令牌时才各扣减一个，返回 {是否放行, 各桶剩余令牌...}
+// 在同一脚本内检查并扣减，被某个桶拒绝的请求不会消耗其它桶的令牌
+// 剩余令牌以字符串返回，避免 Lua 数字转换为 Redis 整数时丢失小数部分
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local allowed = 1
+local buckets = {}
+for i, key in ipairs(KEYS) do
+  local bucket = redis.call('HMGET', key, 'tokens', 'ts')
+  local tokens = tonumber(bucket[1])
+  local ts = tonumber(bucket[2])
+  if tokens == nil or ts == nil then
+    tokens = capacity
+    ts = now
+  end
+  if now > ts then
+    tokens = math.min(capacity, tokens + (now - ts) * rate)
+    ts = now
+  end
+  if tokens < 1 then
+    allowed = 0
+  end
+  buckets[i] = {tokens, ts}
+end
+local reply = {allowed}
+for i, key in ipairs(KEYS) do
+  local tokens = buckets[i][1]
+  if allowed == 1 then
+    tokens = tokens - 1
+  end
+  redis.call('HMSET', key, 'tokens', tostring(tokens), 'ts', tostring(buckets[i][2]))
+  redis.call('PEXPIRE', key, ARGV[4])
+  reply[i + 1] = tostring(tokens)
+end
+return reply
+`)
+
+// RateLimitPolicyResolver 按请求解析令牌桶策略，enabled=false 时直接放行
+type RateLimitPolicyResolver func(c *gin.Context) (policy config.RateLimitPolicy, enabled bool)
+
+type tokenBucketResult struct {
+	allowed   bool
+	remaining float64
+}
+
+// RateLimitPolicyMiddleware 令牌桶限流中间件
+// 按策略的计数维度（IP/用户/API Key）各自维护一个 Redis 令牌桶，任一耗尽即返回 429 且不扣减其它桶；
+// 响应携带 RateLimit-Limit/RateLimit-Remaining/RateLimit-Reset/RateLimit-Policy 标准头，Redis 不可用时放行
+func RateLimitPolicyMiddleware(name string, resolve RateLimitPolicyResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if resolve == nil {
+			c.Next()
+			return
+		}
+		policy, enabled := resolve(c)
+		if !enabled || applyRateLimitPolicy(c, name, policy) {
+			c.Next()
+		}
+	}
+}
+
+// apiKeyRateLimitResolver 由路由注册，AuthMiddleware 在 API Key 认证通过后据此按 Key 限流
+var apiKeyRateLimitResolver RateLimitPolicyResolver
+
+// SetAPIKeyRateLimitPolicy 设置 API Key 认证请求使用的限流策略，传入 nil 关闭
+func SetAPIKeyRateLimitPolicy(resolve RateLimitPolicyResolver) {
+	apiKeyRateLimitResolver = resolve
+}
+
+// applyAPIKeyRateLimitPolicy 对已通过 API Key 认证的请求执行 api_key 策略，被拒绝时返回 false
+func applyAPIKeyRateLimitPolicy(c *gin.Context) bool {
+	if apiKeyRateLimitResolver == nil {
+		return true
+	}
+	policy, enabled := apiKeyRateLimitResolver(c)
+	return !enabled || applyRateLimitPolicy(c, config.RateLimitPolicyAPIKey, policy)
+}
+
+// applyRateLimitPolicy 按策略扣减令牌并写入限流响应头，被拒绝时已中止请求并返回 false
+func applyRateLimitPolicy(c *gin.Context, name string, policy config.RateLimitPolicy) bool {
+	if cache.RedisClient == nil || policy.Rate <= 0 {
+		return true
+	}
+	period := time.Duration(policy.PeriodSeconds) * time.Second
+	if period <= 0 {
+		period = time.Minute
+	}
+	capacity := policy.Burst
+	if capacity <= 0 {
+		capacity = policy.Rate
+	}
+	ratePerMs := float64(policy.Rate) / float64(period.Milliseconds())
+	// 空桶补满所需时间，作为桶键的过期时间
+	ttl := time.Duration(math.Ceil(float64(capacity)/ratePerMs)) * time.Millisecond
+	now := time.Now().UnixMilli()
+
+	dimensionKeys := rateLimitPolicyKeys(c, policy.Keys)
+	bucketKeys := make([]string, 0, len(dimensionKeys))
+	for _, key := range dimensionKeys {
+		bucketKeys = append(bucketKeys, fmt.Sprintf("ratelimit:%s:%s", name, key))
+	}
+	worst, err := takeTokens(c, bucketKeys, capacity, ratePerMs, now, ttl)
+	if err != nil {
+		// 限流失败不影响业务
+		return true
+	}
+
+	remaining := int(math.Floor(worst.remaining))
+	c.Header("RateLimit-Limit", strconv.Itoa(capacity))
+	c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", policy.Rate, int(period.Seconds()), capacity))
+	if !worst.allowed {
+		retryAfter := secondsUntil(1-worst.remaining, ratePerMs)
+		c.Header("RateLimit-Reset", strconv.Itoa(retryAfter))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		response.Error(c, 429, response.CodeTooManyRequests, "Too many requests, please try again later")
+		c.Abort()
+		return false
+	}
+	c.Header("RateLimit-Reset", strconv.Itoa(secondsUntil(float64(capacity)-worst.remaining, ratePerMs)))
+	return true
+}
+
+// rateLimitPolicyKeys 按配置的维度生成桶标识；请求缺少全部维度（如匿名访问 user 策略）时退回 IP
+func rateLimitPolicyKeys(c *gin.Context, dimensions []string) []string {
+	keys := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		switch dimension {
+		case config.RateLimitKeyIP:
+			keys = append(keys, "ip:"+utils.GetRealIP(c))
+		case config.RateLimitKeyUser:
+			if userID, exists := GetUserID(c); exists {
+				keys = append(keys, fmt.Sprintf("user:%d", userID))
+			}
+		case config.RateLimitKeyAPIKey:
+			if apiKeyID, exists := c.Get("api_key_id"); exists {
+				keys = append(keys, fmt.Sprintf("api_key:%v", apiKeyID))
+			}
+		}
+	}
+	if len(keys) == 0 {
+		keys = append(keys, "ip:"+utils.GetRealIP(c))
+	}
+	return keys
+}
+
+// takeTokens 原子地检查并扣减全部桶，返回剩余令牌最少的桶作为限流结果
+func takeTokens(c *gin.Context, keys []string, capacity int, ratePerMs float64, now int64, ttl time.Duration) (tokenBucketResult, error) {
+	raw, err := tokenBucketScript.Run(c.Request.Context(), cache.RedisClient, keys,
+		capacity, strconv.FormatFloat(ratePerMs, 'f', -1, 64), now, ttl.Milliseconds()).Slice()
+	if err != nil {
+		return tokenBucketResult{}, err
+	}
+	if len(raw) != len(keys)+1 {
+		return tokenBucketResult{}, fmt.Errorf("unexpected token bucket reply: %v", raw)
+	}
+	allowed, _ := raw[0].(int64)
+	result := tokenBucketResult{allowed: allowed == 1, remaining: float64(capacity)}
+	for _, value := range raw[1:] {
+		remainingText, _ := value.(string)
+		remaining, err := strconv.ParseFloat(remainingText, 64)
+		if err != nil {
+			return tokenBucketResult{}, err
+		}
+		if remaining < result.remaining {
+			result.remaining = remaining
+		}
+	}
+	return result, nil
+}
+
+// secondsUntil 以 ratePerMs 速度补充 tokens 个令牌所需的整秒数
+func secondsUntil(tokens float64, ratePerMs float64) int {
+	if tokens <= 0 || ratePerMs <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / ratePerMs / 1000))
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRateLimitPolicyMiddlewareRejectsWhenBucketIsEmpty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	policy := config.RateLimitPolicy{Rate: 2, PeriodSeconds: 60, Burst: 2, Keys: []string{config.RateLimitKeyIP}}
+	router := gin.New()
+	router.POST("/login", RateLimitPolicyMiddleware("login", func(*gin.Context) (config.RateLimitPolicy, bool) {
+		return policy, true
+	}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send("10.0.0.1")
+	if first.Code != http.StatusOK || first.Header().Get("RateLimit-Remaining") != "1" {
+		t.Fatalf("first request: status=%d remaining=%q", first.Code, first.Header().Get("RateLimit-Remaining"))
+	}
+	if got := first.Header().Get("RateLimit-Policy"); got != "2;w=60;burst=2" {
+		t.Fatalf("unexpected RateLimit-Policy header %q", got)
+	}
+	send("10.0.0.1")
+	blocked := send("10.0.0.1")
+	if blocked.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the bucket is empty, got %d", blocked.Code)
+	}
+	if blocked.Header().Get("Retry-After") != "30" || blocked.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("unexpected throttle headers: retry=%q remaining=%q",
+			blocked.Header().Get("Retry-After"), blocked.Header().Get("RateLimit-Remaining"))
+	}
+	if other := send("10.0.0.2"); other.Code != http.StatusOK {
+		t.Fatalf("another IP must have its own bucket, got %d", other.Code)
+	}
+}
+
+func TestRateLimitPolicyMiddlewareRejectedRequestKeepsOtherBuckets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	policy := config.RateLimitPolicy{Rate: 2, PeriodSeconds: 60, Burst: 2, Keys: []string{config.RateLimitKeyIP, config.RateLimitKeyUser}}
+	router := gin.New()
+	router.POST("/orders", func(c *gin.Context) {
+		c.Set("user_id", uint(len(c.GetHeader("X-User"))))
+	}, RateLimitPolicyMiddleware("orders", func(*gin.Context) (config.RateLimitPolicy, bool) {
+		return policy, true
+	}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(ip, user string) int {
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("X-User", user)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// 用户 a 从两个 IP 各请求一次后用户桶耗尽，10.0.0.1 的 IP 桶还剩 1 个令牌
+	if send("10.0.0.1", "a") != http.StatusOK || send("10.0.0.2", "a") != http.StatusOK {
+		t.Fatalf("expected the first two requests to pass")
+	}
+	if code := send("10.0.0.1", "a"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the user bucket to reject, got %d", code)
+	}
+	// 被用户桶拒绝的请求没有消耗 IP 桶，同一 IP 上的其他用户仍可请求
+	if code := send("10.0.0.1", "bb"); code != http.StatusOK {
+		t.Fatalf("expected the rejected request to leave the IP bucket untouched, got %d", code)
+	}
+}
+
+func TestAuthMiddlewareAppliesAPIKeyPolicyPerKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.APIKey{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	oldDB := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = oldDB })
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	for _, name := range []string{"ak_first", "ak_second"} {
+		key := models.APIKey{KeyName: name, APIKey: name, IsActive: true, CreatedBy: 1}
+		if err := key.SetSecret("secret"); err != nil {
+			t.Fatalf("set secret: %v", err)
+		}
+		if err := db.Create(&key).Error; err != nil {
+			t.Fatalf("create api key: %v", err)
+		}
+	}
+
+	policy := config.RateLimitPolicy{Rate: 2, PeriodSeconds: 60, Burst: 2, Keys: []string{config.RateLimitKeyAPIKey}}
+	SetAPIKeyRateLimitPolicy(func(*gin.Context) (config.RateLimitPolicy, bool) {
+		return policy, true
+	})
+	t.Cleanup(func() { SetAPIKeyRateLimitPolicy(nil) })
+
+	router := gin.New()
+	router.GET("/orders", AuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", apiKey)
+		req.Header.Set("X-API-Secret", "secret")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := send("ak_first"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d within burst: expected 200, got %d", i+1, rec.Code)
+		}
+	}
+	blocked := send("ak_first")
+	if blocked.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the key's bucket is empty, got %d", blocked.Code)
+	}
+	if blocked.Header().Get("RateLimit-Policy") != "2;w=60;burst=2" {
+		t.Fatalf("unexpected RateLimit-Policy header %q", blocked.Header().Get("RateLimit-Policy"))
+	}
+	if other := send("ak_second"); other.Code != http.StatusOK {
+		t.Fatalf("another key from the same IP must have its own bucket, got %d", other.Code)
+	}
+}
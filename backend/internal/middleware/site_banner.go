@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SiteBannerVersionHeader 响应头：当前展示中站点横幅的版本号，前端发现变化后重新拉取横幅
+const SiteBannerVersionHeader = "X-Site-Banner-Version"
+
+// SiteBannerVersioner 站点横幅版本来源
+type SiteBannerVersioner interface {
+	ActiveVersion(now time.Time) string
+}
+
+// SiteBannerVersion 在所有 API 响应中附带横幅版本号，没有展示中的横幅时不写入
+func SiteBannerVersion(source SiteBannerVersioner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if source != nil {
+			if version := source.ActiveVersion(time.Now()); version != "" {
+				c.Header(SiteBannerVersionHeader, version)
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"strconv"
+
+	"auralogic/internal/pkg/tracing"
+	"auralogic/internal/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TraceIDHeader 响应头中返回的链路 ID，排查慢请求时可据此在追踪后端检索
+const TraceIDHeader = "X-Trace-Id"
+
+// Tracing 为每个请求创建服务端 span，沿用上游 traceparent，并把 span 放入 c.Request.Context() 供后续服务、数据库查询和外部调用使用
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !tracing.Enabled() {
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		name := c.Request.Method
+		if route != "" {
+			name += " " + route
+		}
+		ctx := tracing.Extract(c.Request.Context(), c.Request.Header)
+		ctx, span := tracing.Start(ctx, name, tracing.KindServer)
+		defer span.End()
+
+		span.SetAttribute("http.request.method", c.Request.Method)
+		span.SetAttribute("url.path", c.Request.URL.Path)
+		if route != "" {
+			span.SetAttribute("http.route", route)
+		}
+		span.SetAttribute("client.address", utils.GetRealIP(c))
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(TraceIDHeader, span.SpanContext().TraceID.String())
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttribute("http.response.status_code", status)
+		if status >= 500 {
+			if len(c.Errors) > 0 {
+				span.SetError(c.Errors.Last())
+			} else {
+				span.SetError(errServerStatus(status))
+			}
+		}
+	}
+}
+
+type errServerStatus int
+
+func (e errServerStatus) Error() string {
+	return "HTTP " + strconv.Itoa(int(e))
+}
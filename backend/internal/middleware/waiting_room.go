@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// WaitingRoomTokenHeader 下单时提交等候室准入令牌的请求头，多个商品的令牌以逗号分隔
+const WaitingRoomTokenHeader = "X-Waiting-Room-Token"
+
+// WaitingRoomGate 限量发售等候室准入校验
+type WaitingRoomGate interface {
+	RequiresWaitingRoom() bool
+	CheckOrderAccess(userID uint, skus []string, tokens []string) error
+	ConsumeOrderAccess(userID uint, skus []string)
+}
+
+// WaitingRoomMiddleware 在 CreateOrder 之前校验等候室准入令牌，下单成功后作废令牌
+func WaitingRoomMiddleware(gate WaitingRoomGate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if gate == nil || !gate.RequiresWaitingRoom() {
+			c.Next()
+			return
+		}
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		skus, parsed, err := readOrderItemSKUs(c)
+		if err != nil {
+			response.BadRequest(c, "Invalid request parameters")
+			c.Abort()
+			return
+		}
+		if !parsed {
+			// 请求体格式错误交给处理器返回参数错误
+			c.Next()
+			return
+		}
+
+		var tokens []string
+		if header := c.GetHeader(WaitingRoomTokenHeader); header != "" {
+			tokens = strings.Split(header, ",")
+		}
+		if err := gate.CheckOrderAccess(userID, skus, tokens); err != nil {
+			var bizErr *bizerr.Error
+			if errors.As(err, &bizErr) {
+				response.RespondBizError(c, bizErr)
+			} else {
+				response.InternalError(c, "Failed to verify waiting room access")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if c.Writer.Status() == http.StatusOK {
+			gate.ConsumeOrderAccess(userID, skus)
+		}
+	}
+}
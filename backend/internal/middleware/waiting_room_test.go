@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auralogic/internal/pkg/bizerr"
+	"github.com/gin-gonic/gin"
+)
+
+type fakeWaitingRoomGate struct {
+	gotSKUs   []string
+	gotTokens []string
+	consumed  bool
+	deny      bool
+}
+
+func (g *fakeWaitingRoomGate) RequiresWaitingRoom() bool { return true }
+
+func (g *fakeWaitingRoomGate) CheckOrderAccess(userID uint, skus []string, tokens []string) error {
+	g.gotSKUs = skus
+	g.gotTokens = tokens
+	if g.deny {
+		return bizerr.New("order.waitingRoomRequired", "waiting room required")
+	}
+	return nil
+}
+
+func (g *fakeWaitingRoomGate) ConsumeOrderAccess(userID uint, skus []string) { g.consumed = true }
+
+func TestWaitingRoomMiddlewarePreservesBodyAndConsumesOnSuccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gate := &fakeWaitingRoomGate{}
+	var handlerBody string
+
+	router := gin.New()
+	router.POST("/orders", func(c *gin.Context) {
+		c.Set("user_id", uint(7))
+	}, WaitingRoomMiddleware(gate), func(c *gin.Context) {
+		raw, _ := io.ReadAll(c.Request.Body)
+		handlerBody = string(raw)
+		c.Status(http.StatusOK)
+	})
+
+	body := `{"items":[{"sku":"DROP-1"},{"sku":"REG-1"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set(WaitingRoomTokenHeader, "tok-a,tok-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || handlerBody != body {
+		t.Fatalf("expected handler to receive original body, got code=%d body=%q", rec.Code, handlerBody)
+	}
+	if len(gate.gotSKUs) != 2 || len(gate.gotTokens) != 2 || !gate.consumed {
+		t.Fatalf("unexpected gate calls: skus=%v tokens=%v consumed=%v", gate.gotSKUs, gate.gotTokens, gate.consumed)
+	}
+
+	gate.deny = true
+	gate.consumed = false
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "order.waitingRoomRequired") || gate.consumed {
+		t.Fatalf("expected waiting room rejection, got code=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// 会计分录类型：付款时生成销售与收款分录，退款完成时生成退款分录
+const (
+	AccountingJournalInvoice = "invoice"
+	AccountingJournalPayment = "payment"
+	AccountingJournalRefund  = "refund"
+)
+
+// 会计系统推送触发方式
+const (
+	AccountingExportTriggerScheduled = "scheduled"
+	AccountingExportTriggerManual    = "manual"
+)
+
+// 会计系统推送执行状态
+const (
+	AccountingExportStatusCompleted = "completed"
+	AccountingExportStatusPartial   = "partial" // 部分分录推送失败，下次推送时重试
+	AccountingExportStatusFailed    = "failed"
+)
+
+// AccountingExportRun 会计系统推送执行记录
+type AccountingExportRun struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Provider     string     `gorm:"type:varchar(20);not null;index" json:"provider"`
+	TriggerType  string     `gorm:"type:varchar(20);not null" json:"trigger_type"`
+	TriggeredBy  *uint      `json:"triggered_by,omitempty"`
+	Status       string     `gorm:"type:varchar(20);not null" json:"status"`
+	PushedCount  int        `json:"pushed_count"`
+	FailedCount  int        `json:"failed_count"`
+	SkippedCount int        `json:"skipped_count"` // 此前已推送过的分录
+	Error        string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt    time.Time  `gorm:"index" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName 指定表名
+func (AccountingExportRun) TableName() string {
+	return "accounting_export_runs"
+}
+
+// AccountingExportRecord 已推送到会计系统的分录，同一订单的同类分录只推送一次
+type AccountingExportRecord struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Provider    string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_accounting_export_source" json:"provider"`
+	JournalType string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_accounting_export_source" json:"journal_type"`
+	OrderID     uint      `gorm:"not null;uniqueIndex:idx_accounting_export_source" json:"order_id"`
+	OrderNo     string    `gorm:"type:varchar(50)" json:"order_no"`
+	ExternalID  string    `gorm:"type:varchar(100)" json:"external_id"`
+	RunID       uint      `gorm:"index" json:"run_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (AccountingExportRecord) TableName() string {
+	return "accounting_export_records"
+}
+
+// AccountingCredential 会计系统 OAuth2 令牌（刷新令牌每次使用后轮换，需持久化最新值）
+type AccountingCredential struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Provider     string     `gorm:"type:varchar(20);not null;uniqueIndex" json:"provider"`
+	RefreshToken string     `gorm:"type:text" json:"-"` // fieldcrypt 加密
+	AccessToken  string     `gorm:"type:text" json:"-"` // fieldcrypt 加密
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (AccountingCredential) TableName() string {
+	return "accounting_credentials"
+}
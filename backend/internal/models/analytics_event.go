@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// 前端上报的行为事件类型
+const (
+	AnalyticsEventPageView     = "page_view"
+	AnalyticsEventAddToCart    = "add_to_cart"
+	AnalyticsEventCheckoutStep = "checkout_step"
+)
+
+// 结账步骤，按漏斗顺序排列
+const (
+	CheckoutStepCart    = "cart"    // 打开购物车
+	CheckoutStepSubmit  = "submit"  // 提交订单
+	CheckoutStepPlaced  = "placed"  // 订单创建成功
+	CheckoutStepPayment = "payment" // 选择付款方式
+)
+
+// CheckoutSteps 漏斗中结账步骤的顺序
+var CheckoutSteps = []string{CheckoutStepCart, CheckoutStepSubmit, CheckoutStepPlaced, CheckoutStepPayment}
+
+// AnalyticsSession 服务端划分的访问会话，不依赖 Cookie：
+// 访客以 IP + User-Agent 加每日轮换的盐做哈希标识，不保存原始 IP，跨天无法关联同一访客
+type AnalyticsSession struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	VisitorHash     string    `gorm:"type:varchar(64);not null;index:idx_analytics_session_visitor" json:"-"`
+	EntryPath       string    `gorm:"type:varchar(255)" json:"entry_path"`
+	Referer         string    `gorm:"type:varchar(500)" json:"referer"`
+	EventCount      int       `gorm:"not null;default:0" json:"event_count"`
+	StartedAt       time.Time `gorm:"index" json:"started_at"`
+	LastSeenAt      time.Time `gorm:"index:idx_analytics_session_visitor" json:"last_seen_at"`
+	AddedToCart     bool      `gorm:"not null;default:false" json:"added_to_cart"`
+	CheckoutStepMax int       `gorm:"not null;default:0" json:"checkout_step_max"` // 到达的最远结账步骤（CheckoutSteps 下标 + 1），0 表示未进入结账
+}
+
+func (AnalyticsSession) TableName() string {
+	return "analytics_sessions"
+}
+
+// AnalyticsEvent 行为事件记录（仅追加）
+type AnalyticsEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	SessionID uint      `gorm:"not null;index" json:"session_id"`
+	Type      string    `gorm:"type:varchar(32);not null;index" json:"type"`
+	Path      string    `gorm:"type:varchar(255)" json:"path"`
+	ProductID *uint     `gorm:"index" json:"product_id,omitempty"`
+	Quantity  int       `gorm:"not null;default:0" json:"quantity"`
+	Step      string    `gorm:"type:varchar(20)" json:"step,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+func (AnalyticsEvent) TableName() string {
+	return "analytics_events"
+}
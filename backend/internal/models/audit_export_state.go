@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AuditExportState 操作日志外部归档进度，记录已推送的最后一条日志和哈希链头，每个推送目标一条
+type AuditExportState struct {
+	Sink           string     `gorm:"type:varchar(20);primaryKey" json:"sink"`
+	LastLogID      uint       `gorm:"not null;default:0" json:"last_log_id"`
+	LastHash       string     `gorm:"type:varchar(64)" json:"last_hash"`
+	ExportedCount  int64      `gorm:"not null;default:0" json:"exported_count"`
+	LastExportedAt *time.Time `json:"last_exported_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (AuditExportState) TableName() string {
+	return "audit_export_states"
+}
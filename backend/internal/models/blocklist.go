@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// BlocklistType 黑名单条目类型
+type BlocklistType string
+
+const (
+	BlocklistTypeIP          BlocklistType = "ip"           // 单个IP或CIDR网段
+	BlocklistTypeEmail       BlocklistType = "email"        // 完整邮箱地址
+	BlocklistTypeEmailDomain BlocklistType = "email_domain" // 邮箱域名，同时匹配其子域名
+	BlocklistTypePhone       BlocklistType = "phone"        // 手机号，去除空格与分隔符后比较
+)
+
+// BlocklistTypes 所有黑名单条目类型
+var BlocklistTypes = []BlocklistType{
+	BlocklistTypeIP,
+	BlocklistTypeEmail,
+	BlocklistTypeEmailDomain,
+	BlocklistTypePhone,
+}
+
+// BlocklistScope 黑名单检查点
+type BlocklistScope string
+
+const (
+	BlocklistScopeRegister BlocklistScope = "register"
+	BlocklistScopeLogin    BlocklistScope = "login"
+	BlocklistScopeOrder    BlocklistScope = "order"
+)
+
+// BlocklistEntry 管理员维护的黑名单条目，在注册、登录与下单时检查
+type BlocklistEntry struct {
+	ID    uint          `gorm:"primaryKey" json:"id"`
+	Type  BlocklistType `gorm:"type:varchar(20);not null;uniqueIndex:idx_blocklist_type_value" json:"type"`
+	Value string        `gorm:"type:varchar(255);not null;uniqueIndex:idx_blocklist_type_value" json:"value"` // 规范化后的值
+	// 仅管理员可见，不会返回给被拦截的用户
+	Reason    string     `gorm:"type:varchar(500)" json:"reason,omitempty"`
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at,omitempty"` // 为空表示永久有效
+
+	// 命中统计
+	MatchCount       int64          `gorm:"not null;default:0" json:"match_count"`
+	LastMatchedAt    *time.Time     `json:"last_matched_at,omitempty"`
+	LastMatchedScope BlocklistScope `gorm:"type:varchar(20)" json:"last_matched_scope,omitempty"`
+
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (BlocklistEntry) TableName() string {
+	return "blocklist_entries"
+}
+
+// IsExpired 条目是否已过期
+func (e *BlocklistEntry) IsExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BusinessAccountStatus 企业账户状态
+type BusinessAccountStatus string
+
+const (
+	BusinessAccountStatusPending   BusinessAccountStatus = "pending"   // 待审核
+	BusinessAccountStatusApproved  BusinessAccountStatus = "approved"  // 已通过，可使用账期付款
+	BusinessAccountStatusRejected  BusinessAccountStatus = "rejected"  // 已拒绝，可修改资料后重新申请
+	BusinessAccountStatusSuspended BusinessAccountStatus = "suspended" // 已停用：不能新增账期订单，已开发票照常催收
+)
+
+// BusinessAccount 企业账户：管理员审核通过后，用户可在信用额度内先发货后付款
+type BusinessAccount struct {
+	ID             uint                  `gorm:"primaryKey" json:"id"`
+	UserID         uint                  `gorm:"uniqueIndex;not null" json:"user_id"`
+	User           *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CompanyName    string                `gorm:"type:varchar(200);not null" json:"company_name"`
+	TaxID          string                `gorm:"type:varchar(100)" json:"tax_id,omitempty"`
+	BillingEmail   string                `gorm:"type:varchar(255)" json:"billing_email,omitempty"` // 发票与催款邮件的收件地址，为空时使用用户邮箱
+	BillingAddress string                `gorm:"type:text" json:"billing_address,omitempty"`
+	Status         BusinessAccountStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+
+	// 账期条款：额度与未付发票均按 Currency 计算，仅该币种的订单可使用账期付款
+	Currency    string `gorm:"type:varchar(10)" json:"currency,omitempty"`
+	CreditLimit int64  `gorm:"type:bigint;default:0" json:"-"`
+	TermsDays   int    `gorm:"default:0" json:"terms_days"`
+
+	// 额度冻结：发票逾期超过 net_terms.credit_hold_after_days 时自动冻结，管理员也可手动冻结
+	CreditHold       bool       `gorm:"default:false;index" json:"credit_hold"`
+	CreditHoldReason string     `gorm:"type:varchar(20)" json:"credit_hold_reason,omitempty"` // overdue / manual
+	CreditHoldAt     *time.Time `json:"credit_hold_at,omitempty"`
+
+	// 审核信息
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 额度冻结原因
+const (
+	CreditHoldReasonOverdue = "overdue"
+	CreditHoldReasonManual  = "manual"
+)
+
+// TableName 指定表名
+func (BusinessAccount) TableName() string {
+	return "business_accounts"
+}
+
+func (a BusinessAccount) MarshalJSON() ([]byte, error) {
+	type Alias BusinessAccount
+	return json.Marshal(&struct {
+		Alias
+		CreditLimitMinor int64 `json:"credit_limit_minor"`
+	}{
+		Alias:            Alias(a),
+		CreditLimitMinor: a.CreditLimit,
+	})
+}
+
+// NetTermsInvoiceStatus 账期发票状态
+type NetTermsInvoiceStatus string
+
+const (
+	NetTermsInvoiceStatusOpen NetTermsInvoiceStatus = "open" // 未付款（含已逾期）
+	NetTermsInvoiceStatusPaid NetTermsInvoiceStatus = "paid" // 已收款
+	NetTermsInvoiceStatusVoid NetTermsInvoiceStatus = "void" // 已作废（订单取消或退款），不再占用额度
+)
+
+// NetTermsInvoice 账期发票：订单使用账期付款时开具，到期前需线下付款，由管理员登记收款
+type NetTermsInvoice struct {
+	ID                uint                  `gorm:"primaryKey" json:"id"`
+	InvoiceNo         string                `gorm:"type:varchar(50);uniqueIndex;not null" json:"invoice_no"`
+	BusinessAccountID uint                  `gorm:"index;not null" json:"business_account_id"`
+	BusinessAccount   *BusinessAccount      `gorm:"foreignKey:BusinessAccountID" json:"business_account,omitempty"`
+	UserID            uint                  `gorm:"index;not null" json:"user_id"`
+	OrderID           uint                  `gorm:"uniqueIndex;not null" json:"order_id"`
+	OrderNo           string                `gorm:"type:varchar(50);not null" json:"order_no"`
+	Currency          string                `gorm:"type:varchar(10);not null" json:"currency"`
+	Amount            int64                 `gorm:"type:bigint;default:0" json:"-"`
+	IssuedAt          time.Time             `gorm:"index" json:"issued_at"`
+	DueAt             time.Time             `gorm:"index" json:"due_at"`
+	Status            NetTermsInvoiceStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+
+	PaidAt           *time.Time `gorm:"index" json:"paid_at,omitempty"`
+	PaidBy           *uint      `json:"paid_by,omitempty"`
+	PaymentReference string     `gorm:"type:varchar(255)" json:"payment_reference,omitempty"`
+	VoidedAt         *time.Time `gorm:"index" json:"voided_at,omitempty"`
+	VoidReason       string     `gorm:"type:varchar(500)" json:"void_reason,omitempty"`
+
+	// 催款进度：已发送的催款邮件次数，对应 net_terms.dunning_days 的下标
+	DunningCount  int        `gorm:"default:0" json:"dunning_count"`
+	LastDunningAt *time.Time `json:"last_dunning_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (NetTermsInvoice) TableName() string {
+	return "net_terms_invoices"
+}
+
+func (i NetTermsInvoice) MarshalJSON() ([]byte, error) {
+	type Alias NetTermsInvoice
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor int64 `json:"amount_minor"`
+	}{
+		Alias:       Alias(i),
+		AmountMinor: i.Amount,
+	})
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CODCollectionStatus 货到付款代收状态
+type CODCollectionStatus string
+
+const (
+	CODCollectionStatusOutstanding CODCollectionStatus = "outstanding" // 待收款：已发货或待发货，尚未登记实收金额
+	CODCollectionStatusCollected   CODCollectionStatus = "collected"   // 已收款
+	CODCollectionStatusVoid        CODCollectionStatus = "void"        // 已作废（拒收、退回或订单取消）
+)
+
+// CODCollection 货到付款代收记录：订单选择货到付款时创建，签收后由管理员登记实收金额
+type CODCollection struct {
+	ID              uint                `gorm:"primaryKey" json:"id"`
+	OrderID         uint                `gorm:"uniqueIndex;not null" json:"order_id"`
+	OrderNo         string              `gorm:"type:varchar(50);not null" json:"order_no"`
+	UserID          uint                `gorm:"index;not null" json:"user_id"`
+	ReceiverCountry string              `gorm:"type:varchar(100)" json:"receiver_country,omitempty"`
+	Currency        string              `gorm:"type:varchar(10);not null" json:"currency"`
+	Amount          int64               `gorm:"type:bigint;default:0" json:"-"` // 应收金额
+	Status          CODCollectionStatus `gorm:"type:varchar(20);not null;default:'outstanding';index" json:"status"`
+
+	// 收款登记：实收金额可能少于应收（快递少收、找零差额），差额计入短收
+	CollectedAmount *int64     `gorm:"type:bigint" json:"-"`
+	CollectedAt     *time.Time `gorm:"index" json:"collected_at,omitempty"`
+	CollectedBy     *uint      `json:"collected_by,omitempty"`
+	Reference       string     `gorm:"type:varchar(255)" json:"reference,omitempty"` // 快递代收款回单号等
+	VoidedAt        *time.Time `gorm:"index" json:"voided_at,omitempty"`
+	VoidReason      string     `gorm:"type:varchar(500)" json:"void_reason,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (CODCollection) TableName() string {
+	return "cod_collections"
+}
+
+func (c CODCollection) MarshalJSON() ([]byte, error) {
+	type Alias CODCollection
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor          int64  `json:"amount_minor"`
+		CollectedAmountMinor *int64 `json:"collected_amount_minor,omitempty"`
+	}{
+		Alias:                Alias(c),
+		AmountMinor:          c.Amount,
+		CollectedAmountMinor: c.CollectedAmount,
+	})
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// 自定义文档的数据绑定
+const (
+	DocumentBindingOrder     = "order"      // 每个订单一份，如送货单
+	DocumentBindingOrderItem = "order_item" // 每个订单商品一份，如保修卡、正品证书
+)
+
+// DocumentType 管理员定义的自定义文档类型（保修卡、送货单、正品证书等），复用账单的模板渲染流程。
+// 模板中可使用订单数据、公司信息与 Fields 自定义字段；用户通过一次性令牌下载
+type DocumentType struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	Key           string            `gorm:"type:varchar(64);uniqueIndex;not null" json:"key"` // 稳定标识，用于下载地址
+	Name          string            `gorm:"type:varchar(128);not null" json:"name"`
+	Description   string            `gorm:"type:text" json:"description,omitempty"`
+	Binding       string            `gorm:"type:varchar(20);not null;default:'order'" json:"binding"`
+	Template      string            `gorm:"type:text;not null" json:"template"`
+	Fields        map[string]string `gorm:"type:text;serializer:json" json:"fields,omitempty"`         // 模板中以 {{.Fields.key}} 引用的自定义字段
+	OrderStatuses []string          `gorm:"type:text;serializer:json" json:"order_statuses,omitempty"` // 可下载的订单状态，为空时仅已完成订单
+	ItemSKUs      []string          `gorm:"type:text;serializer:json" json:"item_skus,omitempty"`      // order_item 绑定时适用的商品 SKU，为空表示全部
+	Enabled       bool              `gorm:"not null;default:false" json:"enabled"`
+	VisibleToUser bool              `gorm:"not null;default:false" json:"visible_to_user"` // 关闭后仅后台可生成
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+func (DocumentType) TableName() string {
+	return "document_types"
+}
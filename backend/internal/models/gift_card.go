@@ -0,0 +1,126 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GiftCardStatus 礼品卡状态
+type GiftCardStatus string
+
+const (
+	GiftCardStatusActive GiftCardStatus = "active" // 可用（余额为 0 时仍为 active，仅不可再抵扣）
+	GiftCardStatusVoid   GiftCardStatus = "void"   // 已作废
+)
+
+// GiftCardValueType 礼品卡面值类型
+type GiftCardValueType string
+
+const (
+	GiftCardValueFixed    GiftCardValueType = "fixed"    // 固定面值：只能按 order.gift_card.denominations 中的面值发行
+	GiftCardValueVariable GiftCardValueType = "variable" // 自定义面值：在 min_value ~ max_value 之间任意金额
+)
+
+// GiftCard 礼品卡：结账时抵扣订单金额，可分多次使用；
+// 下单时预留抵扣金额，订单完成时从余额中扣减，订单取消或删除时释放预留，与优惠码的预留流程一致
+type GiftCard struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Code      string            `gorm:"type:varchar(32);uniqueIndex;not null" json:"code"`
+	ValueType GiftCardValueType `gorm:"type:varchar(20);not null;default:'variable'" json:"value_type"`
+	Currency  string            `gorm:"type:varchar(10);not null" json:"currency"`
+
+	InitialValue   int64 `gorm:"type:bigint;not null;default:0" json:"-"` // 发行面值
+	Balance        int64 `gorm:"type:bigint;not null;default:0" json:"-"` // 余额（已扣减已完成订单的抵扣）
+	ReservedAmount int64 `gorm:"type:bigint;not null;default:0" json:"-"` // 未完成订单预留的抵扣金额
+
+	Status    GiftCardStatus `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	ExpiresAt *time.Time     `gorm:"index" json:"expires_at,omitempty"`
+	Note      string         `gorm:"type:varchar(500)" json:"note,omitempty"`
+
+	IssuedBy   *uint      `json:"issued_by,omitempty"`
+	VoidedAt   *time.Time `json:"voided_at,omitempty"`
+	VoidedBy   *uint      `json:"voided_by,omitempty"`
+	VoidReason string     `gorm:"type:varchar(500)" json:"void_reason,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (GiftCard) TableName() string {
+	return "gift_cards"
+}
+
+func (g GiftCard) MarshalJSON() ([]byte, error) {
+	type Alias GiftCard
+	return json.Marshal(&struct {
+		Alias
+		InitialValueMinor   int64 `json:"initial_value_minor"`
+		BalanceMinor        int64 `json:"balance_minor"`
+		ReservedAmountMinor int64 `json:"reserved_amount_minor"`
+		AvailableMinor      int64 `json:"available_minor"`
+	}{
+		Alias:               Alias(g),
+		InitialValueMinor:   g.InitialValue,
+		BalanceMinor:        g.Balance,
+		ReservedAmountMinor: g.ReservedAmount,
+		AvailableMinor:      g.Available(),
+	})
+}
+
+// Available 可用于新订单抵扣的金额
+func (g *GiftCard) Available() int64 {
+	available := g.Balance - g.ReservedAmount
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// IsExpired 是否已过期
+func (g *GiftCard) IsExpired() bool {
+	return g.ExpiresAt != nil && NowFunc().After(*g.ExpiresAt)
+}
+
+// GiftCardTransactionType 礼品卡余额流水类型
+type GiftCardTransactionType string
+
+const (
+	GiftCardTransactionIssue   GiftCardTransactionType = "issue"   // 发行
+	GiftCardTransactionReserve GiftCardTransactionType = "reserve" // 下单预留
+	GiftCardTransactionRelease GiftCardTransactionType = "release" // 订单取消或删除，释放预留
+	GiftCardTransactionRedeem  GiftCardTransactionType = "redeem"  // 订单完成，从余额扣减
+	GiftCardTransactionRefund  GiftCardTransactionType = "refund"  // 已完成订单退款，抵扣金额退回余额
+	GiftCardTransactionVoid    GiftCardTransactionType = "void"    // 作废，剩余余额清零
+)
+
+// GiftCardTransaction 礼品卡余额流水，同时用于保证同一订单的释放与扣减只执行一次
+type GiftCardTransaction struct {
+	ID           uint                    `gorm:"primaryKey" json:"id"`
+	GiftCardID   uint                    `gorm:"index;not null" json:"gift_card_id"`
+	Type         GiftCardTransactionType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount       int64                   `gorm:"type:bigint;not null;default:0" json:"-"`
+	BalanceAfter int64                   `gorm:"type:bigint;not null;default:0" json:"-"`
+	OrderID      *uint                   `gorm:"index" json:"order_id,omitempty"`
+	OrderNo      string                  `gorm:"type:varchar(50);index" json:"order_no,omitempty"`
+	OperatorID   *uint                   `json:"operator_id,omitempty"`
+	CreatedAt    time.Time               `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (GiftCardTransaction) TableName() string {
+	return "gift_card_transactions"
+}
+
+func (t GiftCardTransaction) MarshalJSON() ([]byte, error) {
+	type Alias GiftCardTransaction
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor       int64 `json:"amount_minor"`
+		BalanceAfterMinor int64 `json:"balance_after_minor"`
+	}{
+		Alias:             Alias(t),
+		AmountMinor:       t.Amount,
+		BalanceAfterMinor: t.BalanceAfter,
+	})
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// InboundWebhookAuthMode 入站 Webhook 的来源校验方式
+type InboundWebhookAuthMode string
+
+const (
+	InboundWebhookAuthHMAC   InboundWebhookAuthMode = "hmac_sha256" // 请求体的 HMAC-SHA256 签名，支持 "sha256=" 前缀
+	InboundWebhookAuthHeader InboundWebhookAuthMode = "header"      // 请求头中直接携带密钥
+)
+
+// 转换脚本可以返回的动作
+const (
+	InboundWebhookActionMarkPaid    = "mark_paid"    // 标记待付款订单为已付款
+	InboundWebhookActionAddTracking = "add_tracking" // 为待发货订单填写物流单号并发货
+	InboundWebhookActionTicketNote  = "ticket_note"  // 在工单中追加一条系统消息
+)
+
+// InboundWebhookEventStatus 入站事件的处理结果
+type InboundWebhookEventStatus string
+
+const (
+	InboundWebhookEventReceived  InboundWebhookEventStatus = "received"  // 已入库，等待处理
+	InboundWebhookEventProcessed InboundWebhookEventStatus = "processed" // 所有动作执行成功
+	InboundWebhookEventPartial   InboundWebhookEventStatus = "partial"   // 部分动作失败
+	InboundWebhookEventFailed    InboundWebhookEventStatus = "failed"    // 脚本出错或所有动作失败
+	InboundWebhookEventIgnored   InboundWebhookEventStatus = "ignored"   // 脚本没有返回动作
+)
+
+// InboundWebhook 第三方事件的入站端点。请求经 Secret 校验后交给 Script 中的 transform(event)
+// 转换为动作执行，只允许执行 AllowedActions 中的动作
+type InboundWebhook struct {
+	ID              uint                   `gorm:"primaryKey" json:"id"`
+	Name            string                 `gorm:"type:varchar(100);not null" json:"name"`
+	Slug            string                 `gorm:"type:varchar(64);uniqueIndex;not null" json:"slug"` // 入站地址 /api/webhooks/inbound/:slug
+	Description     string                 `gorm:"type:text" json:"description,omitempty"`
+	AuthMode        InboundWebhookAuthMode `gorm:"type:varchar(20);not null;default:'hmac_sha256'" json:"auth_mode"`
+	SignatureHeader string                 `gorm:"type:varchar(100)" json:"signature_header"`
+	EventIDHeader   string                 `gorm:"type:varchar(100)" json:"event_id_header,omitempty"` // 用于去重的事件 ID 请求头，未配置或缺失时按请求体摘要去重
+	Secret          string                 `gorm:"type:text;not null" json:"-"`                        // fieldcrypt 加密存储
+	Script          string                 `gorm:"type:text;not null" json:"script"`
+	AllowedActions  []string               `gorm:"type:text;serializer:json" json:"allowed_actions"`
+	Enabled         bool                   `gorm:"not null;default:false" json:"enabled"`
+	LastReceivedAt  *time.Time             `json:"last_received_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (InboundWebhook) TableName() string {
+	return "inbound_webhooks"
+}
+
+// InboundWebhookActionResult 单个动作的执行结果
+type InboundWebhookActionResult struct {
+	Action     string `json:"action"`
+	OrderNo    string `json:"order_no,omitempty"`
+	TicketNo   string `json:"ticket_no,omitempty"`
+	TrackingNo string `json:"tracking_no,omitempty"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+}
+
+// InboundWebhookEvent 入站事件收件箱，记录原始请求、脚本输出与动作结果，可在修正脚本后重放
+type InboundWebhookEvent struct {
+	ID          uint                         `gorm:"primaryKey" json:"id"`
+	WebhookID   uint                         `gorm:"not null;uniqueIndex:uidx_inbound_webhook_event_key,priority:1;index:idx_inbound_webhook_event_status,priority:1" json:"webhook_id"`
+	EventKey    string                       `gorm:"type:varchar(191);not null;uniqueIndex:uidx_inbound_webhook_event_key,priority:2" json:"event_key"`
+	Status      InboundWebhookEventStatus    `gorm:"type:varchar(20);not null;index:idx_inbound_webhook_event_status,priority:2" json:"status"`
+	Headers     JSONMap                      `gorm:"type:text" json:"headers,omitempty"` // 不含签名与密钥请求头
+	Query       JSONMap                      `gorm:"type:text" json:"query,omitempty"`
+	Payload     string                       `gorm:"type:text" json:"payload"`
+	Actions     []InboundWebhookActionResult `gorm:"type:text;serializer:json" json:"actions,omitempty"`
+	Console     []string                     `gorm:"type:text;serializer:json" json:"console,omitempty"`
+	Error       string                       `gorm:"type:text" json:"error,omitempty"`
+	DurationMs  int64                        `gorm:"not null;default:0" json:"duration_ms"`
+	Attempts    int                          `gorm:"not null;default:1" json:"attempts"` // 首次处理与每次重放各计一次
+	CreatedAt   time.Time                    `gorm:"index" json:"created_at"`
+	ProcessedAt *time.Time                   `json:"processed_at,omitempty"`
+}
+
+// TableName 指定表名
+func (InboundWebhookEvent) TableName() string {
+	return "inbound_webhook_events"
+}
@@ -23,7 +23,9 @@ type Inventory struct {
 	SafetyStock       int            `gorm:"default:0" json:"safety_stock"`                  // 安全Inventory（低于此值告警）
 	AlertEmail        string         `gorm:"type:varchar(255)" json:"alert_email,omitempty"` // Inventory告警Email
 	IsActive          bool           `gorm:"default:true" json:"is_active"`                  // 是否启用
+	FlashSale         bool           `gorm:"default:false" json:"flash_sale"`                // 秒杀模式：预留改走 Redis 原子计数，异步写回数据库
 	Notes             string         `gorm:"type:text" json:"notes,omitempty"`               // 备注
+	Version           uint           `gorm:"not null;default:1" json:"version"`              // 乐观锁版本号，管理员每次编辑后递增
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
@@ -119,7 +121,7 @@ type InventoryLog struct {
 	Operator    string         `gorm:"type:varchar(100)" json:"operator"`                // 操作人
 	Reason      string         `gorm:"type:varchar(255)" json:"reason"`                  // 变动原因
 	Notes       string         `gorm:"type:text" json:"notes,omitempty"`                 // 备注
-	CreatedAt   time.Time      `json:"created_at"`
+	CreatedAt   time.Time      `gorm:"index" json:"created_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
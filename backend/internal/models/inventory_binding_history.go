@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// 绑定变更类型
+const (
+	InventoryBindingActionCreate = "create"
+	InventoryBindingActionUpdate = "update"
+	InventoryBindingActionDelete = "delete"
+	InventoryBindingActionRebind = "rebind"
+)
+
+// InventoryBindingHistory 商品规格与实体库存绑定关系的变更记录
+// 绑定被删除后记录仍保留，便于追溯某个 SKU 历史上由哪些库存发货
+type InventoryBindingHistory struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	BindingID       uint      `gorm:"index" json:"binding_id"`
+	ProductID       uint      `gorm:"not null;index:idx_ibh_product_created" json:"product_id"`
+	Action          string    `gorm:"type:varchar(20);not null" json:"action"`
+	FromInventoryID *uint     `json:"from_inventory_id,omitempty"`
+	ToInventoryID   *uint     `json:"to_inventory_id,omitempty"`
+	Attributes      JSON      `gorm:"type:json" json:"attributes,omitempty"`
+	AffectedOrders  int       `gorm:"default:0" json:"affected_orders"` // 换绑时仍占用原库存的未完成订单数
+	OperatorID      *uint     `gorm:"index" json:"operator_id,omitempty"`
+	Notes           string    `gorm:"type:varchar(500)" json:"notes,omitempty"`
+	CreatedAt       time.Time `gorm:"index:idx_ibh_product_created" json:"created_at"`
+}
+
+// TableName 指定表名
+func (InventoryBindingHistory) TableName() string {
+	return "inventory_binding_histories"
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 库存快照来源
+const (
+	InventorySnapshotSourcePhysical = "physical"
+	InventorySnapshotSourceVirtual  = "virtual"
+)
+
+// InventorySnapshot 每日库存快照，每个库存每天一条，用于查询历史时点的库存水平（如会计期初/期末盘点）
+// 只做追加，不随库存变动更新；库存删除后快照仍保留
+type InventorySnapshot struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	SnapshotDate string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_inventory_snapshot_day,priority:1" json:"snapshot_date"` // UTC 日期 YYYY-MM-DD
+	Source       string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_inventory_snapshot_day,priority:2" json:"source"`        // physical, virtual
+	InventoryID  uint      `gorm:"not null;uniqueIndex:idx_inventory_snapshot_day,priority:3;index" json:"inventory_id"`
+	SKU          string    `gorm:"type:varchar(100);index" json:"sku"`
+	Name         string    `gorm:"type:varchar(255)" json:"name"`
+	OnHand       int64     `gorm:"not null;default:0" json:"on_hand"`   // 在库数量：实物为库存数减已售，虚拟为可用加预留/暂扣的卡密数
+	Available    int64     `gorm:"not null;default:0" json:"available"` // 可售数量
+	Reserved     int64     `gorm:"not null;default:0" json:"reserved"`  // 预留/暂扣数量
+	Sold         int64     `gorm:"not null;default:0" json:"sold"`      // 累计已售数量
+	CapturedAt   time.Time `gorm:"not null" json:"captured_at"`
+}
+
+// TableName 指定表名
+func (InventorySnapshot) TableName() string {
+	return "inventory_snapshots"
+}
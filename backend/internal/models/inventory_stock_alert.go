@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// InventoryStockAlert 低库存告警状态，每个库存一条；低库存时首次告警，持续低库存按间隔重复提醒，恢复后重置
+// Source 与 InventorySnapshot 一致：physical 为实物库存，virtual 为静态卡密库存
+type InventoryStockAlert struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Source        string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_inventory_stock_alert,priority:1" json:"source"`
+	InventoryID   uint       `gorm:"not null;uniqueIndex:idx_inventory_stock_alert,priority:2" json:"inventory_id"`
+	Name          string     `gorm:"type:varchar(255)" json:"name"`
+	SKU           string     `gorm:"type:varchar(100)" json:"sku"`
+	Low           bool       `gorm:"not null;default:false;index" json:"low"` // 最近一次检查时是否低于阈值
+	Available     int64      `gorm:"not null;default:0" json:"available"`
+	Threshold     int        `gorm:"not null;default:0" json:"threshold"`
+	LowSince      *time.Time `json:"low_since,omitempty"`
+	LastAlertedAt *time.Time `json:"last_alerted_at,omitempty"`
+	AlertCount    int        `gorm:"not null;default:0" json:"alert_count"` // 本次低库存期间已发送的告警次数
+	SnoozedUntil  *time.Time `json:"snoozed_until,omitempty"`               // 静默截止时间，期间不发送告警
+	SnoozedBy     *uint      `json:"snoozed_by,omitempty"`
+	CheckedAt     time.Time  `json:"checked_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (InventoryStockAlert) TableName() string {
+	return "inventory_stock_alerts"
+}
+
+// IsSnoozed 当前是否处于静默期
+func (a *InventoryStockAlert) IsSnoozed(now time.Time) bool {
+	return a.SnoozedUntil != nil && now.Before(*a.SnoozedUntil)
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// LicenseActivationStatus 设备激活状态
+type LicenseActivationStatus string
+
+const (
+	LicenseActivationStatusActive      LicenseActivationStatus = "active"      // 激活中，占用一个设备名额
+	LicenseActivationStatusDeactivated LicenseActivationStatus = "deactivated" // 已解绑
+)
+
+// 解绑来源
+const (
+	LicenseDeactivatedBySoftware = "software" // 商户软件凭卡密调用解绑
+	LicenseDeactivatedByUser     = "user"     // 买家在订单页解绑
+	LicenseDeactivatedByAdmin    = "admin"    // 管理员解绑
+	LicenseDeactivatedByTransfer = "transfer" // 迁移到新设备
+)
+
+// LicenseActivation 已发货卡密的设备绑定记录
+// 每次激活一条记录，解绑后保留作为激活历史；同一卡密的激活中记录数受虚拟库存的 MaxActivations 限制
+type LicenseActivation struct {
+	ID                 uint                    `gorm:"primaryKey" json:"id"`
+	StockID            uint                    `gorm:"not null;index:idx_la_stock_status" json:"stock_id"`
+	VirtualInventoryID uint                    `gorm:"not null;index" json:"virtual_inventory_id"`
+	OrderID            uint                    `gorm:"not null;index" json:"order_id"`
+	OrderNo            string                  `gorm:"type:varchar(50);index" json:"order_no"`
+	LicenseHint        string                  `gorm:"type:varchar(20)" json:"license_hint"` // 卡密末尾几位，用于在订单页区分多个卡密
+	DeviceID           string                  `gorm:"type:varchar(128);not null;index" json:"device_id"`
+	DeviceName         string                  `gorm:"type:varchar(100)" json:"device_name,omitempty"`
+	Status             LicenseActivationStatus `gorm:"type:varchar(20);not null;default:'active';index:idx_la_stock_status" json:"status"`
+	IPAddress          string                  `gorm:"type:varchar(50)" json:"ip_address,omitempty"`
+	ActivatedAt        time.Time               `json:"activated_at"`
+	LastSeenAt         *time.Time              `json:"last_seen_at,omitempty"` // 最近一次激活或校验时间
+	DeactivatedAt      *time.Time              `json:"deactivated_at,omitempty"`
+	DeactivatedBy      string                  `gorm:"type:varchar(20)" json:"deactivated_by,omitempty"` // software / user / admin / transfer
+	DeactivatedByID    *uint                   `json:"deactivated_by_id,omitempty"`                      // 解绑操作人（买家或管理员）
+	TransferredToID    *uint                   `json:"transferred_to_id,omitempty"`                      // 迁移后新设备的激活记录
+	CreatedAt          time.Time               `json:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (LicenseActivation) TableName() string {
+	return "license_activations"
+}
+
+// IsActive 是否仍占用设备名额
+func (a *LicenseActivation) IsActive() bool {
+	return a.Status == LicenseActivationStatusActive
+}
@@ -47,6 +47,7 @@ type EmailLog struct {
 	User         *User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	BatchID      *uint           `gorm:"index" json:"batch_id,omitempty"`
 	Batch        *MarketingBatch `gorm:"foreignKey:BatchID" json:"batch,omitempty"`
+	Campaign     string          `gorm:"type:varchar(100);index" json:"campaign,omitempty"` // 自动触达活动标识，如订单挽回提醒
 	Status       EmailLogStatus  `gorm:"type:varchar(20);default:'pending';index" json:"status"`
 	ErrorMessage string          `gorm:"type:text" json:"error_message,omitempty"`
 	RetryCount   int             `gorm:"default:0" json:"retry_count"`
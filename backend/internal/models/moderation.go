@@ -0,0 +1,126 @@
+package models
+
+import (
+	"time"
+)
+
+// ModerationAction 内容审核处理方式，按严重程度递增
+type ModerationAction string
+
+const (
+	ModerationActionAllow  ModerationAction = "allow"
+	ModerationActionMask   ModerationAction = "mask"   // 打码命中内容后保存，并进入审核队列
+	ModerationActionFlag   ModerationAction = "flag"   // 原样保存，进入审核队列
+	ModerationActionReject ModerationAction = "reject" // 拒绝提交
+)
+
+// Severity 处理方式的严重程度，用于合并多个命中结果
+func (a ModerationAction) Severity() int {
+	switch a {
+	case ModerationActionMask:
+		return 1
+	case ModerationActionFlag:
+		return 2
+	case ModerationActionReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ModerationSource 被审核文本的来源
+type ModerationSource string
+
+const (
+	ModerationSourceOrderRemark   ModerationSource = "order_remark"
+	ModerationSourceTicketSubject ModerationSource = "ticket_subject"
+	ModerationSourceTicketContent ModerationSource = "ticket_content"
+	ModerationSourceTicketMessage ModerationSource = "ticket_message"
+)
+
+// ModerationSources 所有受审核的文本来源
+var ModerationSources = []ModerationSource{
+	ModerationSourceOrderRemark,
+	ModerationSourceTicketSubject,
+	ModerationSourceTicketContent,
+	ModerationSourceTicketMessage,
+}
+
+// ModerationMatchType 词库规则匹配方式
+type ModerationMatchType string
+
+const (
+	ModerationMatchKeyword ModerationMatchType = "keyword" // 不区分大小写的子串匹配
+	ModerationMatchRegex   ModerationMatchType = "regex"
+)
+
+// ModerationRule 内容审核词库规则
+type ModerationRule struct {
+	ID        uint                `gorm:"primaryKey" json:"id"`
+	Pattern   string              `gorm:"type:varchar(255);not null" json:"pattern"`
+	MatchType ModerationMatchType `gorm:"type:varchar(20);not null;default:'keyword'" json:"match_type"`
+	Action    ModerationAction    `gorm:"type:varchar(20);not null" json:"action"`
+	// 生效的文本来源，为空表示全部来源
+	Sources   []string  `gorm:"type:text;serializer:json" json:"sources"`
+	Note      string    `gorm:"type:varchar(255)" json:"note,omitempty"`
+	IsActive  bool      `gorm:"index" json:"is_active"`
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ModerationRule) TableName() string {
+	return "moderation_rules"
+}
+
+// AppliesTo 规则是否对指定来源生效
+func (r *ModerationRule) AppliesTo(source ModerationSource) bool {
+	if len(r.Sources) == 0 {
+		return true
+	}
+	for _, item := range r.Sources {
+		if item == string(source) {
+			return true
+		}
+	}
+	return false
+}
+
+// ModerationCaseStatus 审核记录状态
+type ModerationCaseStatus string
+
+const (
+	ModerationCaseStatusPending  ModerationCaseStatus = "pending"  // 待人工审核
+	ModerationCaseStatusApproved ModerationCaseStatus = "approved" // 审核通过，内容保留
+	ModerationCaseStatusRemoved  ModerationCaseStatus = "removed"  // 审核不通过，内容已从来源处移除
+	ModerationCaseStatusRejected ModerationCaseStatus = "rejected" // 提交时已被自动拒绝，仅留档
+)
+
+// ModerationCase 内容审核记录：被标记、打码或拒绝的用户文本
+type ModerationCase struct {
+	ID       uint             `gorm:"primaryKey" json:"id"`
+	Source   ModerationSource `gorm:"type:varchar(30);not null;index:idx_moderation_case_source" json:"source"`
+	SourceID uint             `gorm:"index:idx_moderation_case_source" json:"source_id"` // 订单ID / 工单ID / 工单消息ID，被拒绝的提交为0
+	UserID   *uint            `gorm:"index" json:"user_id,omitempty"`
+	Action   ModerationAction `gorm:"type:varchar(20);not null" json:"action"`
+	Provider string           `gorm:"type:varchar(20)" json:"provider"` // rules / external
+	// 用户提交的原文与实际保存的文本（打码后）
+	Content          string               `gorm:"type:text" json:"content"`
+	ProcessedContent string               `gorm:"type:text" json:"processed_content"`
+	Matches          []string             `gorm:"type:text;serializer:json" json:"matches"`
+	Labels           []string             `gorm:"type:text;serializer:json" json:"labels,omitempty"`
+	Status           ModerationCaseStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	ReviewedBy       *uint                `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time           `json:"reviewed_at,omitempty"`
+	ReviewNote       string               `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	CreatedAt        time.Time            `gorm:"index" json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 指定表名
+func (ModerationCase) TableName() string {
+	return "moderation_cases"
+}
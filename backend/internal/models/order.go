@@ -40,7 +40,13 @@ type OrderItem struct {
 	Quantity    int                    `json:"quantity"`
 	ImageURL    string                 `json:"image_url,omitempty"`
 	Attributes  map[string]interface{} `json:"attributes,omitempty"`
-	ProductType ProductType            `json:"product_type,omitempty"` // physical(实物), virtual(虚拟)
+	ProductType ProductType            `json:"product_type,omitempty"`     // physical(实物), virtual(虚拟)
+	UnitPrice   int64                  `json:"unit_price_minor,omitempty"` // 下单时的商品单价，用于商家分账
+	VendorID    *uint                  `json:"vendor_id,omitempty"`        // 下单时商品所属商家
+
+	// 下单时商品的收入确认方式与服务期（月），付款后据此生成收入确认计划
+	RevenueRecognition  RevenueRecognitionType `json:"revenue_recognition,omitempty"`
+	ServicePeriodMonths int                    `json:"service_period_months,omitempty"`
 }
 
 // Order Order模型
@@ -53,8 +59,8 @@ type Order struct {
 	// OrderInfo
 	Items []OrderItem `gorm:"type:text;serializer:json;not null" json:"items"`
 
-	// 实际分配的属性（盲盒模式）
-	ActualAttributes JSON `gorm:"type:json" json:"actual_attributes,omitempty"` // 盲盒Product实际分配的属性
+	// 实际分配的属性（盲盒模式），仅在查询单个订单时加载；付款前不能暴露给用户，不直接序列化
+	ItemAllocations []OrderItemAllocation `gorm:"foreignKey:OrderID" json:"-"`
 
 	// Inventory绑定关系（内部使用，不对外暴露）
 	// Key: Order项索引(0,1,2...), Value: InventoryID
@@ -82,6 +88,10 @@ type Order struct {
 	// 隐私保护
 	PrivacyProtected bool `gorm:"default:false" json:"privacy_protected"`
 
+	// 是否已分享到客服工单，由工单授权流程维护，读取订单时无需再查询 ticket_order_access
+	// 只允许通过 RefreshSharedToSupport 写入，避免保存订单时用旧值覆盖
+	SharedToSupport bool `gorm:"<-:create;default:false" json:"shared_to_support"`
+
 	// 物流Info
 	TrackingNo   string     `gorm:"type:varchar(100);index" json:"tracking_no,omitempty"`
 	ShippedAt    *time.Time `json:"shipped_at,omitempty"`
@@ -89,11 +99,21 @@ type Order struct {
 	CompletedBy  *uint      `json:"completed_by,omitempty"`
 	UserFeedback string     `gorm:"type:text" json:"user_feedback,omitempty"`
 
+	// 自动完成提醒发送时间（避免重复提醒）
+	AutoCompleteRemindedAt *time.Time `json:"auto_complete_reminded_at,omitempty"`
+
 	// 序列号异步生成状态
 	SerialGenerationStatus SerialGenerationStatus `gorm:"type:varchar(20);index" json:"serial_generation_status,omitempty"`
 	SerialGenerationError  string                 `gorm:"type:text" json:"serial_generation_error,omitempty"`
 	SerialGeneratedAt      *time.Time             `json:"serial_generated_at,omitempty"`
 
+	// 用户主动离开付款页的时间（用于提前释放预留库存，返回付款页时清空）
+	CheckoutAbandonedAt *time.Time `gorm:"index" json:"checkout_abandoned_at,omitempty"`
+
+	// 未完成订单挽回提醒的发送进度
+	RecoveryRemindersSent  int        `gorm:"not null;default:0" json:"recovery_reminders_sent,omitempty"`
+	LastRecoveryReminderAt *time.Time `json:"last_recovery_reminder_at,omitempty"`
+
 	// 表单访问Token
 	FormToken       *string    `gorm:"type:varchar(255);uniqueIndex" json:"form_token,omitempty"`
 	FormSubmittedAt *time.Time `json:"form_submitted_at,omitempty"`
@@ -103,19 +123,54 @@ type Order struct {
 	UserEmail                 string `gorm:"type:varchar(255)" json:"user_email,omitempty"`
 	EmailNotificationsEnabled bool   `gorm:"default:true" json:"email_notifications_enabled"`
 
-	// 优惠码
-	PromoCodeID    *uint  `gorm:"index" json:"promo_code_id,omitempty"`
-	PromoCodeStr   string `gorm:"type:varchar(50)" json:"promo_code,omitempty"`
-	DiscountAmount int64  `gorm:"type:bigint;default:0" json:"-"`
+	// 优惠码与定价规则：DiscountAmount 为优惠总额，其中 RuleDiscountAmount 来自自动定价规则
+	PromoCodeID        *uint               `gorm:"index" json:"promo_code_id,omitempty"`
+	PromoCodeStr       string              `gorm:"type:varchar(50)" json:"promo_code,omitempty"`
+	DiscountAmount     int64               `gorm:"type:bigint;default:0" json:"-"`
+	RuleDiscountAmount int64               `gorm:"type:bigint;default:0" json:"-"`
+	PricingTrace       []PricingTraceEntry `gorm:"type:text;serializer:json" json:"pricing_trace,omitempty"` // 下单时定价规则与优惠码的评估过程
+
+	// 礼品卡抵扣金额，已从 TotalAmount 中扣除
+	GiftCardID     *uint  `gorm:"index" json:"gift_card_id,omitempty"`
+	GiftCardCode   string `gorm:"type:varchar(32)" json:"gift_card_code,omitempty"`
+	GiftCardAmount int64  `gorm:"type:bigint;default:0" json:"-"`
+
+	// 付款方式手续费（正数为附加费，负数为优惠），已计入 TotalAmount
+	PaymentFee         int64 `gorm:"type:bigint;default:0" json:"-"`
+	PaymentFeeRetained bool  `gorm:"default:false" json:"payment_fee_retained,omitempty"` // 附加费退款时不退还
+
+	// 价格取整：按 order.price_rounding 对应付金额取整的规则与差额（正数为上调），差额已计入 TotalAmount
+	PriceRoundingRule       string `gorm:"type:varchar(50)" json:"price_rounding_rule,omitempty"`
+	PriceRoundingAdjustment int64  `gorm:"type:bigint;default:0" json:"-"`
+
+	// 管理员手动调价（折扣或改价）累计的应付金额变化（负数为减免），已计入 TotalAmount，明细见 OrderPriceAdjustment
+	ManualAdjustment int64 `gorm:"type:bigint;default:0" json:"-"`
 
 	// 金额
 	TotalAmount int64  `gorm:"type:bigint;default:0" json:"-"`
 	Currency    string `gorm:"type:varchar(10);default:'CNY'" json:"currency"`
 
+	// 付款时间与结算汇率快照：付款时按 order.fx_settlement 配置折算为记账本位币，之后汇率调整不影响已付款订单
+	PaidAt         *time.Time `gorm:"index" json:"paid_at,omitempty"`
+	FXBaseCurrency string     `gorm:"type:varchar(10)" json:"fx_base_currency,omitempty"`
+	FXRate         float64    `gorm:"type:decimal(20,8);default:0" json:"fx_rate,omitempty"` // 0 表示付款时未配置该币种汇率
+	FXBaseAmount   int64      `gorm:"type:bigint;default:0" json:"-"`
+	RefundedAt     *time.Time `gorm:"index" json:"refunded_at,omitempty"` // 退款完成时间，用于会计分录记账日期
+
+	// 退款序号：付款脚本据此生成网关退款单号，分配后不再变化
+	LastRefundSequence int `gorm:"default:0" json:"-"` // 订单内已分配的最大序号
+	FullRefundSequence int `gorm:"default:0" json:"-"` // 整单退款首次执行时分配，失败重试沿用
+
 	// 备注
 	Remark      string `gorm:"type:text" json:"remark,omitempty"`
 	AdminRemark string `gorm:"type:text" json:"admin_remark,omitempty"`
 
+	// 标签：由管理员或自动化集成（Zapier / Make）添加，用于后续流程筛选
+	Tags []string `gorm:"type:text;serializer:json" json:"tags,omitempty"`
+
+	// 下单时所属组织，组织成员均可查看（见 OrganizationMember）
+	OrganizationID *uint `gorm:"index" json:"organization_id,omitempty"`
+
 	// 来源
 	Source           string `gorm:"type:varchar(50);default:'api'" json:"source"`
 	SourcePlatform   string `gorm:"type:varchar(100)" json:"source_platform,omitempty"`
@@ -127,7 +182,13 @@ type Order struct {
 	AssignedTo *uint      `json:"assigned_to,omitempty"`
 	AssignedAt *time.Time `json:"assigned_at,omitempty"`
 
-	CreatedAt time.Time      `json:"created_at"`
+	// 风控：下单IP与收货地址指纹供频率规则统计，只允许由风控服务写入（见 VelocityRuleService）
+	CreateIP           string `gorm:"<-:create;type:varchar(45);index" json:"-"`
+	AddressFingerprint string `gorm:"<-:create;type:varchar(64);index" json:"-"`
+	// 命中暂扣规则且尚未审核通过，暂扣期间不能发货
+	RiskHold bool `gorm:"<-:create;default:false;index" json:"risk_hold,omitempty"`
+
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -141,15 +202,59 @@ func (o Order) MarshalJSON() ([]byte, error) {
 	type Alias Order
 	return json.Marshal(&struct {
 		Alias
-		TotalAmountMinor    int64 `json:"total_amount_minor"`
-		DiscountAmountMinor int64 `json:"discount_amount_minor"`
+		TotalAmountMinor             int64 `json:"total_amount_minor"`
+		DiscountAmountMinor          int64 `json:"discount_amount_minor"`
+		RuleDiscountAmountMinor      int64 `json:"rule_discount_amount_minor"`
+		GiftCardAmountMinor          int64 `json:"gift_card_amount_minor"`
+		PaymentFeeMinor              int64 `json:"payment_fee_minor"`
+		PriceRoundingAdjustmentMinor int64 `json:"price_rounding_adjustment_minor"`
+		ManualAdjustmentMinor        int64 `json:"manual_adjustment_minor"`
+		FXBaseAmountMinor            int64 `json:"fx_base_amount_minor"`
 	}{
-		Alias:               Alias(o),
-		TotalAmountMinor:    o.TotalAmount,
-		DiscountAmountMinor: o.DiscountAmount,
+		Alias:                        Alias(o),
+		TotalAmountMinor:             o.TotalAmount,
+		DiscountAmountMinor:          o.DiscountAmount,
+		RuleDiscountAmountMinor:      o.RuleDiscountAmount,
+		GiftCardAmountMinor:          o.GiftCardAmount,
+		PaymentFeeMinor:              o.PaymentFee,
+		PriceRoundingAdjustmentMinor: o.PriceRoundingAdjustment,
+		ManualAdjustmentMinor:        o.ManualAdjustment,
+		FXBaseAmountMinor:            o.FXBaseAmount,
 	})
 }
 
+// RefundableAmount 退款金额：不退还的附加费从订单金额中扣除
+func (o *Order) RefundableAmount() int64 {
+	if o.PaymentFeeRetained && o.PaymentFee > 0 {
+		return o.TotalAmount - o.PaymentFee
+	}
+	return o.TotalAmount
+}
+
+// ItemsWithAllocations 返回合并了盲盒分配结果的订单项副本，不修改 o.Items
+func (o *Order) ItemsWithAllocations() []OrderItem {
+	if len(o.ItemAllocations) == 0 {
+		return o.Items
+	}
+	items := make([]OrderItem, len(o.Items))
+	copy(items, o.Items)
+	for _, allocation := range o.ItemAllocations {
+		if allocation.ItemIndex < 0 || allocation.ItemIndex >= len(items) {
+			continue
+		}
+		item := &items[allocation.ItemIndex]
+		attributes := make(map[string]interface{}, len(item.Attributes)+len(allocation.Attributes))
+		for k, v := range item.Attributes {
+			attributes[k] = v
+		}
+		for k, v := range allocation.Attributes {
+			attributes[k] = v
+		}
+		item.Attributes = attributes
+	}
+	return items
+}
+
 // MaskSensitiveInfo 打码敏感Info
 func (o *Order) MaskSensitiveInfo() {
 	if !o.PrivacyProtected {
@@ -164,3 +269,19 @@ func (o *Order) MaskSensitiveInfo() {
 	// 保留省市区，详细Address打码
 	o.ReceiverAddress = "***"
 }
+
+// MaskReceiverInfo 隐藏收货人姓名、联系方式和详细地址（不论是否开启隐私保护）
+// 用于用户分享订单给客服但未授权查看收货信息的场景，保留国家和省市区
+func (o *Order) MaskReceiverInfo() {
+	o.ReceiverName = "***"
+	if len(o.ReceiverPhone) > 7 {
+		o.ReceiverPhone = o.ReceiverPhone[:3] + "****" + o.ReceiverPhone[len(o.ReceiverPhone)-4:]
+	} else if o.ReceiverPhone != "" {
+		o.ReceiverPhone = "***"
+	}
+	if o.ReceiverEmail != "" {
+		o.ReceiverEmail = "***"
+	}
+	o.ReceiverAddress = "***"
+	o.ReceiverPostcode = ""
+}
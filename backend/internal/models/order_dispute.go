@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// OrderDisputeStatus 争议状态
+type OrderDisputeStatus string
+
+const (
+	OrderDisputeStatusAwaitingEvidence OrderDisputeStatus = "awaiting_evidence" // 等待商家举证
+	OrderDisputeStatusUnderReview      OrderDisputeStatus = "under_review"      // 双方证据已提交，等待平台裁决
+	OrderDisputeStatusResolved         OrderDisputeStatus = "resolved"          // 已裁决
+)
+
+// 裁决结果
+const (
+	OrderDisputeDecisionRefundFull    = "refund_full"
+	OrderDisputeDecisionRefundPartial = "refund_partial"
+	OrderDisputeDecisionNoRefund      = "no_refund"
+)
+
+// OrderDispute 工单长时间未解决时用户升级的平台争议。
+// 用户升级时提交陈述与证据，商家（客服）在举证期限内答辩，平台审核人记录裁决，退款类裁决直接调用退款引擎
+type OrderDispute struct {
+	ID        uint               `gorm:"primaryKey" json:"id"`
+	DisputeNo string             `gorm:"type:varchar(30);uniqueIndex;not null" json:"dispute_no"`
+	TicketID  uint               `gorm:"uniqueIndex;not null" json:"ticket_id"` // 每个工单只能升级一次
+	TicketNo  string             `gorm:"type:varchar(50)" json:"ticket_no"`
+	OrderID   uint               `gorm:"index;not null" json:"order_id"`
+	OrderNo   string             `gorm:"type:varchar(50);index;not null" json:"order_no"`
+	Currency  string             `gorm:"type:varchar(10)" json:"currency"`
+	UserID    uint               `gorm:"index;not null" json:"user_id"`
+	User      *User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Status    OrderDisputeStatus `gorm:"type:varchar(20);not null;default:'awaiting_evidence';index" json:"status"`
+
+	// 买家陈述与证据
+	BuyerStatement   string    `gorm:"type:text;not null" json:"buyer_statement"`
+	BuyerEvidence    []string  `gorm:"type:text;serializer:json" json:"buyer_evidence,omitempty"`
+	BuyerSubmittedAt time.Time `json:"buyer_submitted_at"`
+
+	// 商家答辩与证据
+	MerchantStatement   string     `gorm:"type:text" json:"merchant_statement,omitempty"`
+	MerchantEvidence    []string   `gorm:"type:text;serializer:json" json:"merchant_evidence,omitempty"`
+	MerchantSubmittedBy *uint      `json:"merchant_submitted_by,omitempty"`
+	MerchantSubmittedAt *time.Time `json:"merchant_submitted_at,omitempty"`
+	EvidenceDeadline    time.Time  `gorm:"index" json:"evidence_deadline"` // 逾期未答辩时平台可直接裁决
+
+	// 裁决记录
+	Decision       string     `gorm:"type:varchar(20)" json:"decision,omitempty"`
+	DecisionAmount int64      `json:"decision_amount_minor,omitempty"` // 实际退款金额（最小货币单位）
+	DecisionNote   string     `gorm:"type:varchar(2000)" json:"decision_note,omitempty"`
+	DecidedBy      *uint      `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+
+	// 裁决触发的退款
+	OrderRefundID *uint  `json:"order_refund_id,omitempty"`                       // 部分退款裁决对应的退款记录
+	RefundStatus  string `gorm:"type:varchar(20)" json:"refund_status,omitempty"` // 订单或部分退款记录的退款状态
+	TransactionID string `gorm:"type:varchar(255)" json:"transaction_id,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrderDispute) TableName() string {
+	return "order_disputes"
+}
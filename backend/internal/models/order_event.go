@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// OrderEventType 订单时间线事件类型
+type OrderEventType string
+
+const (
+	OrderEventTypeCreated       OrderEventType = "created"        // 下单
+	OrderEventTypeStatusChanged OrderEventType = "status_changed" // 状态变更
+	OrderEventTypePayment       OrderEventType = "payment"        // 付款尝试（选择付款方式、确认超时等）
+	OrderEventTypeShipment      OrderEventType = "shipment"       // 物流更新
+	OrderEventTypeRemark        OrderEventType = "remark"         // 管理员备注
+)
+
+// 事件操作方
+const (
+	OrderEventOperatorUser   = "user"
+	OrderEventOperatorAdmin  = "admin"
+	OrderEventOperatorSystem = "system"
+)
+
+// OrderEvent 订单时间线事件，按时间顺序记录订单经历的状态变更、付款、物流和备注
+// Internal 事件与 Remark 仅管理员可见
+type OrderEvent struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	OrderID      uint           `gorm:"not null;index:idx_order_events_order_created" json:"order_id"`
+	OrderNo      string         `gorm:"type:varchar(50);index" json:"order_no"`
+	Type         OrderEventType `gorm:"type:varchar(30);not null" json:"type"`
+	FromStatus   OrderStatus    `gorm:"type:varchar(30)" json:"from_status,omitempty"`
+	ToStatus     OrderStatus    `gorm:"type:varchar(30)" json:"to_status,omitempty"`
+	Source       string         `gorm:"type:varchar(100)" json:"source,omitempty"` // 触发动作，如 payment.confirm、order.cancel
+	OperatorType string         `gorm:"type:varchar(20)" json:"operator_type"`
+	OperatorID   *uint          `json:"operator_id,omitempty"`
+	Message      string         `gorm:"type:varchar(1000)" json:"message,omitempty"`
+	Remark       string         `gorm:"type:text" json:"remark,omitempty"`
+	Data         JSON           `gorm:"type:json" json:"data,omitempty"`
+	Internal     bool           `gorm:"default:false" json:"internal"`
+	CreatedAt    time.Time      `gorm:"index:idx_order_events_order_created" json:"created_at"`
+}
+
+// TableName 指定表名
+func (OrderEvent) TableName() string {
+	return "order_events"
+}
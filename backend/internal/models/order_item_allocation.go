@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// OrderItemAllocation 订单项实际分配的属性（盲盒模式）
+// 订单项以 JSON 保存在订单上没有独立 ID，按订单 ID + 订单项下标定位，并记录 SKU 便于核对
+type OrderItemAllocation struct {
+	ID         uint                   `gorm:"primaryKey" json:"id"`
+	OrderID    uint                   `gorm:"not null;uniqueIndex:idx_order_item_allocation,priority:1" json:"order_id"`
+	ItemIndex  int                    `gorm:"not null;uniqueIndex:idx_order_item_allocation,priority:2" json:"item_index"`
+	SKU        string                 `gorm:"type:varchar(255)" json:"sku"`
+	Attributes map[string]interface{} `gorm:"type:text;serializer:json;not null" json:"attributes"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (OrderItemAllocation) TableName() string {
+	return "order_item_allocations"
+}
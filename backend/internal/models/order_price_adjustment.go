@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OrderPriceAdjustmentType 管理员调价方式
+type OrderPriceAdjustmentType string
+
+const (
+	OrderPriceAdjustmentDiscount OrderPriceAdjustmentType = "discount" // 在当前应付金额上减免
+	OrderPriceAdjustmentOverride OrderPriceAdjustmentType = "override" // 直接指定新的应付金额
+)
+
+// OrderPriceAdjustmentStatus 调价状态：未超过审批阈值时直接 applied，否则 pending 等待另一名管理员审批
+type OrderPriceAdjustmentStatus string
+
+const (
+	OrderPriceAdjustmentPending  OrderPriceAdjustmentStatus = "pending"  // 待审批
+	OrderPriceAdjustmentApplied  OrderPriceAdjustmentStatus = "applied"  // 已生效
+	OrderPriceAdjustmentRejected OrderPriceAdjustmentStatus = "rejected" // 已拒绝
+)
+
+// OrderPriceAdjustment 管理员对待付款订单的手动折扣或改价记录，生效后差额累计到 Order.ManualAdjustment
+type OrderPriceAdjustment struct {
+	ID       uint                     `gorm:"primaryKey" json:"id"`
+	OrderID  uint                     `gorm:"index;not null" json:"order_id"`
+	OrderNo  string                   `gorm:"type:varchar(50);index;not null" json:"order_no"`
+	Type     OrderPriceAdjustmentType `gorm:"type:varchar(20);not null" json:"type"`
+	Currency string                   `gorm:"type:varchar(10)" json:"currency"`
+
+	TotalBefore int64 `gorm:"type:bigint;not null" json:"-"` // 发起时的应付金额，审批时订单金额已变化则不能生效
+	TotalAfter  int64 `gorm:"type:bigint;not null" json:"-"`
+
+	Reason           string                     `gorm:"type:text;not null" json:"reason"`
+	Status           OrderPriceAdjustmentStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	RequiresApproval bool                       `gorm:"default:false" json:"requires_approval"`
+	RequestedBy      uint                       `gorm:"not null" json:"requested_by"`
+
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrderPriceAdjustment) TableName() string {
+	return "order_price_adjustments"
+}
+
+func (a OrderPriceAdjustment) MarshalJSON() ([]byte, error) {
+	type Alias OrderPriceAdjustment
+	return json.Marshal(&struct {
+		Alias
+		TotalBeforeMinor int64 `json:"total_before_minor"`
+		TotalAfterMinor  int64 `json:"total_after_minor"`
+		AmountMinor      int64 `json:"amount_minor"`
+	}{
+		Alias:            Alias(a),
+		TotalBeforeMinor: a.TotalBefore,
+		TotalAfterMinor:  a.TotalAfter,
+		AmountMinor:      a.Amount(),
+	})
+}
+
+// Amount 应付金额的变化，负数为减免
+func (a *OrderPriceAdjustment) Amount() int64 {
+	return a.TotalAfter - a.TotalBefore
+}
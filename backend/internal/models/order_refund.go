@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OrderRefundStatus 部分退款状态：requested → approved → refunded，待审核时可被拒绝
+type OrderRefundStatus string
+
+const (
+	OrderRefundStatusRequested OrderRefundStatus = "requested" // 待审核
+	OrderRefundStatusApproved  OrderRefundStatus = "approved"  // 已批准，等待通过付款方式退款
+	OrderRefundStatusRefunded  OrderRefundStatus = "refunded"  // 已退款
+	OrderRefundStatusRejected  OrderRefundStatus = "rejected"  // 已拒绝
+)
+
+// OrderRefundItem 部分退款的订单项
+type OrderRefundItem struct {
+	ItemIndex int    `json:"item_index"` // 对应 Order.Items 的下标
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+}
+
+// OrderRefund 订单部分退款记录，按订单项和金额退款，与整单退款（订单状态 refunded）相互独立
+type OrderRefund struct {
+	ID       uint              `gorm:"primaryKey" json:"id"`
+	OrderID  uint              `gorm:"index;not null" json:"order_id"`
+	OrderNo  string            `gorm:"type:varchar(50);index;not null" json:"order_no"`
+	UserID   *uint             `gorm:"index" json:"user_id,omitempty"`
+	Items    []OrderRefundItem `gorm:"type:text;serializer:json;not null" json:"items"`
+	Amount   int64             `gorm:"type:bigint;not null" json:"-"`
+	Currency string            `gorm:"type:varchar(10)" json:"currency"`
+	Reason   string            `gorm:"type:text;not null" json:"reason"`
+	Status   OrderRefundStatus `gorm:"type:varchar(20);not null;default:'requested';index" json:"status"`
+
+	// 发起人：用户申请或管理员直接发起
+	RequestedBy     uint   `json:"requested_by"`
+	RequestedByRole string `gorm:"type:varchar(20)" json:"requested_by_role"` // user / admin
+
+	// 审核信息
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+
+	// 付款方式退款结果
+	RefundedBy        *uint      `json:"refunded_by,omitempty"`
+	RefundedAt        *time.Time `gorm:"index" json:"refunded_at,omitempty"`
+	TransactionID     string     `gorm:"type:varchar(255)" json:"transaction_id,omitempty"`
+	PaymentPending    bool       `gorm:"default:false" json:"payment_pending"` // 付款方式已受理但尚未到账
+	InventoryReleased bool       `gorm:"default:false" json:"inventory_released"`
+	RefundSequence    int        `gorm:"default:0" json:"-"` // 首次执行退款时分配的订单内序号，失败重试沿用
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrderRefund) TableName() string {
+	return "order_refunds"
+}
+
+func (r OrderRefund) MarshalJSON() ([]byte, error) {
+	type Alias OrderRefund
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor int64 `json:"amount_minor"`
+	}{
+		Alias:       Alias(r),
+		AmountMinor: r.Amount,
+	})
+}
+
+// Active 未被拒绝的记录会占用订单的可退数量和可退金额
+func (r *OrderRefund) Active() bool {
+	return r.Status != OrderRefundStatusRejected
+}
@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// ShipmentStatus 物流状态，由各服务商的状态归一化而来
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending            ShipmentStatus = "pending"              // 已发货，尚无物流信息
+	ShipmentStatusInfoReceived       ShipmentStatus = "info_received"        // 承运商已收到电子面单
+	ShipmentStatusInTransit          ShipmentStatus = "in_transit"           // 运输中
+	ShipmentStatusOutForDelivery     ShipmentStatus = "out_for_delivery"     // 派送中
+	ShipmentStatusAvailableForPickup ShipmentStatus = "available_for_pickup" // 待自提
+	ShipmentStatusFailedAttempt      ShipmentStatus = "failed_attempt"       // 投递失败
+	ShipmentStatusDelivered          ShipmentStatus = "delivered"            // 已签收
+	ShipmentStatusException          ShipmentStatus = "exception"            // 异常（退回、丢失等）
+	ShipmentStatusExpired            ShipmentStatus = "expired"              // 长时间无更新，服务商停止跟踪
+)
+
+// OrderShipment 订单物流跟踪记录，发货时创建并注册到物流服务商，轨迹由服务商回调更新
+type OrderShipment struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	OrderID    uint           `gorm:"not null;uniqueIndex" json:"order_id"`
+	OrderNo    string         `gorm:"type:varchar(50);index" json:"order_no"`
+	TrackingNo string         `gorm:"type:varchar(100);not null;index" json:"tracking_no"`
+	Carrier    string         `gorm:"type:varchar(50)" json:"carrier,omitempty"` // 服务商返回或配置的承运商代码
+	Provider   string         `gorm:"type:varchar(20);not null" json:"provider"`
+	Status     ShipmentStatus `gorm:"type:varchar(30);not null;default:'pending'" json:"status"`
+
+	// 注册到服务商的结果，失败时由定时任务重试
+	RegisteredAt     *time.Time `json:"registered_at,omitempty"`
+	RegisterAttempts int        `gorm:"not null;default:0" json:"register_attempts"`
+	RegisterError    string     `gorm:"type:varchar(500)" json:"register_error,omitempty"`
+
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+	DeliveredAt *time.Time `gorm:"index" json:"delivered_at,omitempty"`
+
+	Checkpoints []OrderShipmentCheckpoint `gorm:"foreignKey:ShipmentID" json:"checkpoints"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrderShipment) TableName() string {
+	return "order_shipments"
+}
+
+// OrderShipmentCheckpoint 物流轨迹节点；服务商每次回调都会带上完整轨迹，按指纹去重
+type OrderShipmentCheckpoint struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	ShipmentID  uint           `gorm:"not null;uniqueIndex:idx_shipment_checkpoint_fingerprint" json:"shipment_id"`
+	Fingerprint string         `gorm:"type:varchar(64);not null;uniqueIndex:idx_shipment_checkpoint_fingerprint" json:"-"`
+	Status      ShipmentStatus `gorm:"type:varchar(30)" json:"status"`
+	Message     string         `gorm:"type:varchar(500)" json:"message"`
+	Location    string         `gorm:"type:varchar(255)" json:"location,omitempty"`
+	OccurredAt  time.Time      `gorm:"index" json:"occurred_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// TableName 指定表名
+func (OrderShipmentCheckpoint) TableName() string {
+	return "order_shipment_checkpoints"
+}
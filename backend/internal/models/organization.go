@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OrganizationRole 组织成员角色
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner     OrganizationRole = "owner"     // 所有者：管理成员与邀请，可下单
+	OrganizationRolePurchaser OrganizationRole = "purchaser" // 采购：可下单，受消费限额约束
+	OrganizationRoleViewer    OrganizationRole = "viewer"    // 只读：仅可查看组织订单
+)
+
+// CanCheckout 该角色是否可以下单
+func (r OrganizationRole) CanCheckout() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRolePurchaser
+}
+
+// Organization 组织：多个用户共享订单可见性，按角色控制下单权限
+type Organization struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(200);not null" json:"name"`
+	OwnerUserID uint      `gorm:"index;not null" json:"owner_user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// OrganizationMember 组织成员，每个用户最多属于一个组织
+type OrganizationMember struct {
+	ID             uint             `gorm:"primaryKey" json:"id"`
+	OrganizationID uint             `gorm:"index;not null" json:"organization_id"`
+	UserID         uint             `gorm:"uniqueIndex;not null" json:"user_id"`
+	User           *User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null" json:"role"`
+	// 每月消费限额（order.currency 最小货币单位），0 表示不限
+	SpendingLimit int64     `gorm:"type:bigint;default:0" json:"-"`
+	InvitedBy     *uint     `json:"invited_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+func (m OrganizationMember) MarshalJSON() ([]byte, error) {
+	type Alias OrganizationMember
+	return json.Marshal(&struct {
+		Alias
+		SpendingLimitMinor int64 `json:"spending_limit_minor"`
+	}{
+		Alias:              Alias(m),
+		SpendingLimitMinor: m.SpendingLimit,
+	})
+}
+
+// OrganizationInvitationStatus 邀请状态
+type OrganizationInvitationStatus string
+
+const (
+	OrganizationInvitationStatusPending  OrganizationInvitationStatus = "pending"
+	OrganizationInvitationStatusAccepted OrganizationInvitationStatus = "accepted"
+	OrganizationInvitationStatusRevoked  OrganizationInvitationStatus = "revoked"
+)
+
+// OrganizationInvitation 组织邀请：被邀请人使用相同邮箱登录后凭 Token 加入
+type OrganizationInvitation struct {
+	ID             uint                         `gorm:"primaryKey" json:"id"`
+	OrganizationID uint                         `gorm:"index;not null" json:"organization_id"`
+	Organization   *Organization                `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	Email          string                       `gorm:"type:varchar(255);index;not null" json:"email"`
+	Role           OrganizationRole             `gorm:"type:varchar(20);not null" json:"role"`
+	SpendingLimit  int64                        `gorm:"type:bigint;default:0" json:"-"`
+	Token          string                       `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Status         OrganizationInvitationStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	InvitedBy      uint                         `json:"invited_by"`
+	ExpiresAt      time.Time                    `json:"expires_at"`
+	AcceptedAt     *time.Time                   `json:"accepted_at,omitempty"`
+	AcceptedBy     *uint                        `json:"accepted_by,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+func (i OrganizationInvitation) MarshalJSON() ([]byte, error) {
+	type Alias OrganizationInvitation
+	return json.Marshal(&struct {
+		Alias
+		SpendingLimitMinor int64 `json:"spending_limit_minor"`
+	}{
+		Alias:              Alias(i),
+		SpendingLimitMinor: i.SpendingLimit,
+	})
+}
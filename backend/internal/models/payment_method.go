@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"auralogic/internal/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -31,8 +32,14 @@ type PaymentMethod struct {
 	Manifest        string            `gorm:"type:text" json:"manifest"`                    // 导入包 manifest.json 原文
 	SortOrder       int               `gorm:"default:0" json:"sort_order"`                  // 排序顺序
 	PollInterval    int               `gorm:"default:30" json:"poll_interval"`              // 轮询检查间隔(秒)，默认30秒
-	CreatedAt       time.Time         `json:"created_at"`
-	UpdatedAt       time.Time         `json:"updated_at"`
+
+	// 手续费规则：正数为附加费，负数为优惠，选择付款方式时计入订单金额
+	FeeRate             int64 `gorm:"type:bigint;default:0" json:"fee_rate"`        // 比例部分（基点，300 = +3%，-100 = -1%）
+	FeeFixed            int64 `gorm:"type:bigint;default:0" json:"fee_fixed_minor"` // 固定部分（最小货币单位）
+	FeeRetainedOnRefund bool  `gorm:"default:false" json:"fee_retained_on_refund"`  // 退款时不退还附加费
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // TableName 指定表名
@@ -40,6 +47,20 @@ func (PaymentMethod) TableName() string {
 	return "payment_methods"
 }
 
+// HasFee 是否配置了手续费规则
+func (pm *PaymentMethod) HasFee() bool {
+	return pm.FeeRate != 0 || pm.FeeFixed != 0
+}
+
+// CalculateFee 按订单金额计算手续费，优惠最多抵扣到零
+func (pm *PaymentMethod) CalculateFee(amount int64) int64 {
+	fee := money.ApplyPercentage(amount, pm.FeeRate) + pm.FeeFixed
+	if fee < -amount {
+		fee = -amount
+	}
+	return fee
+}
+
 // BeforeCreate 创建前钩子
 func (pm *PaymentMethod) BeforeCreate(tx *gorm.DB) error {
 	pm.CreatedAt = time.Now()
@@ -735,4 +756,44 @@ function onRefund(order, config) {
 }
 `,
 	},
+	{
+		Name:        "WeChat Pay",
+		Description: "WeChat Pay APIv3 with Native QR and JSAPI checkout, signed callbacks and refunds",
+		Type:        PaymentMethodTypeCustom,
+		Icon:        "MessageCircle",
+		SortOrder:   3,
+		Enabled:     false,
+		Config:      builtinWeChatPayConfig,
+		Script:      builtinWeChatPayScript,
+	},
+	{
+		Name:        "Alipay",
+		Description: "Alipay QR (face-to-face) and mobile web checkout with signed notifications and refunds",
+		Type:        PaymentMethodTypeCustom,
+		Icon:        "Wallet",
+		SortOrder:   4,
+		Enabled:     false,
+		Config:      builtinAlipayConfig,
+		Script:      builtinAlipayScript,
+	},
+	{
+		Name:        "Paddle",
+		Description: "Paddle Billing hosted checkout as merchant of record, with tax-inclusive pricing, signed webhooks and refunds",
+		Type:        PaymentMethodTypeCustom,
+		Icon:        "CreditCard",
+		SortOrder:   5,
+		Enabled:     false,
+		Config:      builtinPaddleConfig,
+		Script:      builtinPaddleScript,
+	},
+	{
+		Name:        "Lemon Squeezy",
+		Description: "Lemon Squeezy hosted checkout as merchant of record, with tax-inclusive pricing, signed webhooks and refunds",
+		Type:        PaymentMethodTypeCustom,
+		Icon:        "CreditCard",
+		SortOrder:   6,
+		Enabled:     false,
+		Config:      builtinLemonSqueezyConfig,
+		Script:      builtinLemonSqueezyScript,
+	},
 }
@@ -0,0 +1,845 @@
+package models
+
+// 微信支付 / 支付宝内置付款方式脚本
+// 两者均只支持人民币订单；回调签名由脚本按网关规则校验，因此声明的 webhook 不使用宿主鉴权
+
+// builtinWeChatPayConfig 微信支付默认配置
+const builtinWeChatPayConfig = `{"app_id":"","mch_id":"","mch_serial_no":"","mch_private_key":"","api_v3_key":"","wechatpay_public_key":"","wechatpay_public_key_id":"","app_secret":"","description":"","return_url":"","api_base":"https://api.mch.weixin.qq.com"}`
+
+// builtinWeChatPayScript 微信支付 APIv3：Native 扫码（桌面端）与 JSAPI（微信内浏览器），支持回调、查单与退款
+const builtinWeChatPayScript = `
+/**
+ * 微信支付 (APIv3) 付款方式脚本
+ * - 桌面端：Native 下单生成 code_url 二维码
+ * - 微信内浏览器：通过 wechat.jsapi 回调完成网页授权后发起 JSAPI 支付
+ * - wechat.notify 回调：验签 + AEAD_AES_256_GCM 解密后确认付款
+ *
+ * 配置说明:
+ * - app_id: 公众号/服务号 AppID (必填)
+ * - mch_id: 商户号 (必填)
+ * - mch_serial_no: 商户 API 证书序列号 (必填)
+ * - mch_private_key: 商户 API 私钥 apiclient_key.pem 内容 (必填)
+ * - api_v3_key: APIv3 密钥，32 字节 (必填，用于解密回调)
+ * - wechatpay_public_key: 微信支付公钥或平台证书 PEM (必填，用于验证回调签名)
+ * - wechatpay_public_key_id: 微信支付公钥 ID (可选，填写后校验回调的 Wechatpay-Serial)
+ * - app_secret: 公众号 AppSecret (可选，填写后启用 JSAPI 支付)
+ * - description: 商品描述 (可选，默认 "Order <订单号>")
+ * - return_url: JSAPI 支付完成后跳转的地址 (可选)
+ * - api_base: API 地址 (默认 https://api.mch.weixin.qq.com)
+ */
+
+var WX_REQUIRED_FIELDS = ['app_id', 'mch_id', 'mch_serial_no', 'mch_private_key', 'api_v3_key', 'wechatpay_public_key'];
+
+function wxEscape(value) {
+    return String(value === undefined || value === null ? '' : value)
+        .replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;').replace(/'/g, '&#39;');
+}
+
+function wxMissingConfig(config) {
+    var missing = [];
+    for (var i = 0; i < WX_REQUIRED_FIELDS.length; i++) {
+        if (!String(config[WX_REQUIRED_FIELDS[i]] || '').trim()) {
+            missing.push(WX_REQUIRED_FIELDS[i]);
+        }
+    }
+    return missing;
+}
+
+function wxNonce() {
+    return AuraLogic.utils.generateId().replace(/-/g, '');
+}
+
+// wxRequest 按 APIv3 规则签名并发送请求
+function wxRequest(config, method, path, body) {
+    var base = String(config.api_base || 'https://api.mch.weixin.qq.com').replace(/\/+$/, '');
+    var bodyText = body ? AuraLogic.utils.jsonEncode(body) : '';
+    var timestamp = String(AuraLogic.system.getTimestamp());
+    var nonce = wxNonce();
+    var message = method + '\n' + path + '\n' + timestamp + '\n' + nonce + '\n' + bodyText + '\n';
+    var signature = AuraLogic.utils.rsaSignSHA256(message, config.mch_private_key);
+    if (!signature) {
+        return { status: 0, error: 'Invalid merchant private key' };
+    }
+    var headers = {
+        'Authorization': 'WECHATPAY2-SHA256-RSA2048 mchid="' + config.mch_id + '",nonce_str="' + nonce +
+            '",signature="' + signature + '",timestamp="' + timestamp + '",serial_no="' + config.mch_serial_no + '"',
+        'Accept': 'application/json',
+        'Content-Type': 'application/json'
+    };
+    if (method === 'GET') {
+        return AuraLogic.http.get(base + path, headers);
+    }
+    return AuraLogic.http.post(base + path, bodyText, headers);
+}
+
+function wxErrorMessage(resp) {
+    if (!resp) {
+        return 'No response';
+    }
+    if (resp.error) {
+        return resp.error;
+    }
+    if (resp.data && resp.data.message) {
+        return (resp.data.code ? resp.data.code + ': ' : '') + resp.data.message;
+    }
+    return 'HTTP ' + resp.status;
+}
+
+function wxOK(resp) {
+    return resp && !resp.error && resp.status >= 200 && resp.status < 300;
+}
+
+// 交易记录：trade_<订单号> 保存当前商户单号、金额与二维码；out_<商户单号> 反查订单号
+function wxLoadTrade(orderNo) {
+    var raw = AuraLogic.storage.get('trade_' + orderNo);
+    return raw ? AuraLogic.utils.jsonDecode(raw) : null;
+}
+
+function wxSaveTrade(orderNo, trade) {
+    AuraLogic.storage.set('trade_' + orderNo, AuraLogic.utils.jsonEncode(trade));
+}
+
+function wxOrderNoFor(outTradeNo) {
+    return AuraLogic.storage.get('out_' + outTradeNo) || String(outTradeNo || '').replace(/(_\d+)?J?$/, '');
+}
+
+// wxEnsureTrade 金额变化（如切换付款方式导致手续费不同）时换用新的商户单号，避免与已下单的金额冲突
+function wxEnsureTrade(order) {
+    var amount = order.total_amount_minor || 0;
+    var trade = wxLoadTrade(order.order_no);
+    if (trade && trade.amount === amount) {
+        return trade;
+    }
+    var seq = trade ? (trade.seq || 0) + 1 : 0;
+    var outTradeNo = seq ? order.order_no + '_' + seq : order.order_no;
+    trade = {
+        seq: seq,
+        amount: amount,
+        out_trade_no: outTradeNo,
+        jsapi_out_trade_no: outTradeNo + 'J',
+        description: '',
+        code_url: ''
+    };
+    AuraLogic.storage.set('out_' + trade.out_trade_no, order.order_no);
+    AuraLogic.storage.set('out_' + trade.jsapi_out_trade_no, order.order_no);
+    wxSaveTrade(order.order_no, trade);
+    return trade;
+}
+
+function wxDescription(config, orderNo) {
+    var description = String(config.description || '').trim() || ('Order ' + orderNo);
+    return description.substring(0, 120);
+}
+
+function wxCard(body, title) {
+    return {
+        html: '<div class="space-y-4">' + body + '</div>',
+        title: title || '微信支付'
+    };
+}
+
+function wxNotice(zh, en, detail) {
+    return wxCard(
+        '<div class="p-6 text-center space-y-2">' +
+            '<p class="text-sm text-destructive font-medium"><span class="lang-zh">' + zh + '</span><span class="lang-en">' + en + '</span></p>' +
+            (detail ? '<p class="text-xs text-muted-foreground break-all">' + wxEscape(detail) + '</p>' : '') +
+        '</div>',
+        'Error'
+    );
+}
+
+function onGeneratePaymentCard(order, config) {
+    if (wxMissingConfig(config).length > 0) {
+        return wxNotice('微信支付商户信息未配置', 'WeChat Pay merchant settings are incomplete', '');
+    }
+    if (order.currency !== 'CNY') {
+        return wxNotice('微信支付仅支持人民币 (CNY) 订单', 'WeChat Pay only supports CNY orders', order.currency);
+    }
+
+    var trade = wxEnsureTrade(order);
+    trade.description = wxDescription(config, order.order_no);
+    if (!trade.code_url) {
+        var resp = wxRequest(config, 'POST', '/v3/pay/transactions/native', {
+            appid: config.app_id,
+            mchid: config.mch_id,
+            description: trade.description,
+            out_trade_no: trade.out_trade_no,
+            notify_url: AuraLogic.system.getWebhookUrl('wechat.notify'),
+            amount: { total: trade.amount, currency: 'CNY' }
+        });
+        if (!wxOK(resp) || !resp.data || !resp.data.code_url) {
+            return wxNotice('微信支付下单失败，请稍后重试', 'Failed to create WeChat Pay order, please retry later', wxErrorMessage(resp));
+        }
+        trade.code_url = resp.data.code_url;
+    }
+    wxSaveTrade(order.order_no, trade);
+
+    AuraLogic.order.updatePaymentData({
+        gateway: 'wechat_pay',
+        out_trade_no: trade.out_trade_no
+    });
+
+    var qr = AuraLogic.utils.qrcode(trade.code_url, { size: 240, level: 'M' });
+    var jsapiLink = String(config.app_secret || '').trim()
+        ? '<a class="inline-flex w-full items-center justify-center rounded-md bg-green-600 px-4 py-2 text-sm font-medium text-white hover:bg-green-700" href="' +
+              wxEscape(AuraLogic.system.getWebhookUrl('wechat.jsapi') + '?order_no=' + encodeURIComponent(order.order_no)) + '">' +
+              '<span class="lang-zh">已在微信中打开？点此支付</span><span class="lang-en">Inside WeChat? Tap to pay</span>' +
+          '</a>'
+        : '';
+
+    var html =
+        '<div class="border border-green-200 dark:border-green-800 rounded-lg p-4 text-center space-y-3">' +
+            '<div class="text-3xl font-bold text-green-600 dark:text-green-400">' +
+                AuraLogic.utils.formatPrice(trade.amount, 'CNY') +
+            '</div>' +
+            '<div class="flex justify-center">' +
+                '<img src="' + qr + '" alt="WeChat Pay QR" class="w-48 h-48 rounded-lg border border-border bg-white p-1" />' +
+            '</div>' +
+            '<p class="text-xs text-muted-foreground">' +
+                '<span class="lang-zh">请使用微信“扫一扫”完成支付，付款后将自动确认</span>' +
+                '<span class="lang-en">Scan with WeChat to pay. Payment is confirmed automatically.</span>' +
+            '</p>' +
+        '</div>' +
+        jsapiLink +
+        '<div class="text-xs text-muted-foreground border-t pt-3">' +
+            '<p><span class="lang-zh">订单号</span><span class="lang-en">Order</span>: <code class="bg-muted px-1 rounded">' + wxEscape(order.order_no) + '</code></p>' +
+        '</div>';
+
+    return {
+        html: '<div class="space-y-4">' + html + '</div>',
+        title: '微信支付',
+        description: 'WeChat Pay',
+        data: { out_trade_no: trade.out_trade_no }
+    };
+}
+
+// wxQueryTrade 按商户单号查单，未下单时返回 null
+function wxQueryTrade(config, outTradeNo) {
+    var resp = wxRequest(config, 'GET',
+        '/v3/pay/transactions/out-trade-no/' + encodeURIComponent(outTradeNo) + '?mchid=' + encodeURIComponent(config.mch_id), null);
+    if (!wxOK(resp) || !resp.data) {
+        return null;
+    }
+    return resp.data;
+}
+
+function onCheckPaymentStatus(order, config) {
+    var trade = wxLoadTrade(order.order_no);
+    if (!trade || wxMissingConfig(config).length > 0) {
+        return { paid: false };
+    }
+    var candidates = [trade.out_trade_no];
+    if (trade.jsapi_prepay_id) {
+        candidates.push(trade.jsapi_out_trade_no);
+    }
+    for (var i = 0; i < candidates.length; i++) {
+        var result = wxQueryTrade(config, candidates[i]);
+        if (!result || result.trade_state !== 'SUCCESS') {
+            continue;
+        }
+        var paidTotal = result.amount ? result.amount.total : 0;
+        if (paidTotal !== trade.amount) {
+            return { paid: false, message: 'Paid amount ' + paidTotal + ' does not match expected ' + trade.amount };
+        }
+        trade.paid_out_trade_no = candidates[i];
+        trade.transaction_id = result.transaction_id;
+        wxSaveTrade(order.order_no, trade);
+        return {
+            paid: true,
+            transaction_id: result.transaction_id,
+            message: 'WeChat Pay confirmed',
+            data: { out_trade_no: candidates[i], trade_type: result.trade_type }
+        };
+    }
+    return { paid: false };
+}
+
+function onRefund(order, config) {
+    if (wxMissingConfig(config).length > 0) {
+        return { success: false, message: 'WeChat Pay merchant settings are incomplete' };
+    }
+    var trade = wxLoadTrade(order.order_no) || {};
+    var outTradeNo = trade.paid_out_trade_no || trade.out_trade_no || order.order_no;
+    var total = trade.amount || order.total_amount_minor;
+    var refund = order.refund_amount_minor;
+    if (refund === undefined || refund === null) {
+        return { success: false, message: 'Refund amount is missing' };
+    }
+    if (!(refund > 0)) {
+        return { success: false, message: 'Refund amount must be greater than 0' };
+    }
+    // 退款单号由订单号与退款序号组成，重试同一笔退款时不变，微信支付据此去重
+    var outRefundNo = order.order_no + 'R' + (order.refund_sequence || 1);
+
+    var resp = wxRequest(config, 'POST', '/v3/refund/domestic/refunds', {
+        out_trade_no: outTradeNo,
+        out_refund_no: outRefundNo,
+        amount: { refund: refund, total: total, currency: 'CNY' }
+    });
+    if (!wxOK(resp) || !resp.data) {
+        return { success: false, message: 'WeChat Pay refund failed: ' + wxErrorMessage(resp) };
+    }
+    var status = resp.data.status;
+    if (status === 'SUCCESS' || status === 'PROCESSING') {
+        return {
+            success: true,
+            pending: status === 'PROCESSING',
+            transaction_id: resp.data.refund_id,
+            message: status === 'SUCCESS' ? 'WeChat Pay refund succeeded' : 'WeChat Pay refund is processing',
+            data: { out_refund_no: outRefundNo, status: status }
+        };
+    }
+    return { success: false, message: 'WeChat Pay refund status: ' + status, data: { out_refund_no: outRefundNo } };
+}
+
+function wxAck(status, code, message) {
+    return { ack_status: status, ack_body: { code: code, message: message } };
+}
+
+// wxVerifyNotification 校验回调签名（含 5 分钟时间窗口）
+function wxVerifyNotification(config) {
+    var timestamp = AuraLogic.webhook.header('wechatpay-timestamp') || '';
+    var nonce = AuraLogic.webhook.header('wechatpay-nonce') || '';
+    var signature = AuraLogic.webhook.header('wechatpay-signature') || '';
+    var serial = AuraLogic.webhook.header('wechatpay-serial') || '';
+    if (!timestamp || !nonce || !signature) {
+        return false;
+    }
+    if (Math.abs(AuraLogic.system.getTimestamp() - parseInt(timestamp, 10)) > 300) {
+        return false;
+    }
+    var keyID = String(config.wechatpay_public_key_id || '').trim();
+    if (keyID && serial !== keyID) {
+        return false;
+    }
+    var message = timestamp + '\n' + nonce + '\n' + AuraLogic.webhook.text() + '\n';
+    return AuraLogic.utils.rsaVerifySHA256(message, signature, config.wechatpay_public_key);
+}
+
+function wxHandleNotify(config) {
+    if (!wxVerifyNotification(config)) {
+        return wxAck(401, 'FAIL', 'signature verification failed');
+    }
+    var payload = AuraLogic.webhook.json();
+    var resource = payload && payload.resource;
+    if (!resource) {
+        return wxAck(400, 'FAIL', 'missing resource');
+    }
+    var plain = AuraLogic.utils.aesGCMDecrypt(config.api_v3_key, resource.nonce, resource.ciphertext, resource.associated_data || '');
+    if (!plain) {
+        return wxAck(400, 'FAIL', 'decrypt failed');
+    }
+    var result = AuraLogic.utils.jsonDecode(plain);
+    if (!result || result.mchid !== config.mch_id || result.trade_state !== 'SUCCESS') {
+        return wxAck(200, 'SUCCESS', 'ignored');
+    }
+
+    var orderNo = wxOrderNoFor(result.out_trade_no);
+    var trade = wxLoadTrade(orderNo);
+    if (!trade) {
+        // 没有下单记录无法核对金额，不自动确认，应答成功避免重复通知，由管理员人工核对
+        var missing = wxAck(200, 'SUCCESS', 'trade not found');
+        missing.message = 'WeChat Pay notification for ' + result.out_trade_no + ' has no matching trade record, manual review required';
+        return missing;
+    }
+    var paidTotal = result.amount ? result.amount.total : 0;
+    if (paidTotal !== trade.amount) {
+        // 金额不符不自动确认，应答成功避免重复通知，由管理员人工核对
+        var ack = wxAck(200, 'SUCCESS', 'amount mismatch');
+        ack.message = 'WeChat Pay amount mismatch for ' + result.out_trade_no + ': paid ' + paidTotal + ', expected ' + trade.amount;
+        return ack;
+    }
+    trade.paid_out_trade_no = result.out_trade_no;
+    trade.transaction_id = result.transaction_id;
+    wxSaveTrade(orderNo, trade);
+    return {
+        paid: true,
+        order_no: orderNo,
+        transaction_id: result.transaction_id,
+        message: 'WeChat Pay notification',
+        data: { out_trade_no: result.out_trade_no, trade_type: result.trade_type },
+        ack_status: 200,
+        ack_body: { code: 'SUCCESS', message: 'OK' }
+    };
+}
+
+function wxHTMLPage(status, body) {
+    return {
+        ack_status: status,
+        ack_headers: { 'Content-Type': 'text/html; charset=utf-8', 'Cache-Control': 'no-store' },
+        ack_body: '<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1">' +
+            '<title>WeChat Pay</title></head><body style="font-family:sans-serif;text-align:center;padding:48px 16px">' + body + '</body></html>'
+    };
+}
+
+// wxHandleJSAPI 微信内浏览器支付：先网页授权获取 openid，再 JSAPI 下单并调起支付
+function wxHandleJSAPI(config) {
+    var orderNo = String(AuraLogic.webhook.query('order_no') || '').trim();
+    var trade = orderNo ? wxLoadTrade(orderNo) : null;
+    if (!trade || !String(config.app_secret || '').trim()) {
+        return wxHTMLPage(404, '<p>支付信息不存在，请返回商城重新发起支付。</p><p>Payment not found, please restart checkout.</p>');
+    }
+    var selfURL = AuraLogic.system.getWebhookUrl('wechat.jsapi') + '?order_no=' + encodeURIComponent(orderNo);
+
+    var code = AuraLogic.webhook.query('code');
+    if (!code) {
+        var authorizeURL = 'https://open.weixin.qq.com/connect/oauth2/authorize?appid=' + encodeURIComponent(config.app_id) +
+            '&redirect_uri=' + encodeURIComponent(selfURL) + '&response_type=code&scope=snsapi_base&state=pay#wechat_redirect';
+        return { ack_status: 302, ack_headers: { 'Location': authorizeURL, 'Cache-Control': 'no-store' }, ack_body: 'redirect' };
+    }
+
+    var tokenResp = AuraLogic.http.get('https://api.weixin.qq.com/sns/oauth2/access_token?appid=' + encodeURIComponent(config.app_id) +
+        '&secret=' + encodeURIComponent(config.app_secret) + '&code=' + encodeURIComponent(code) + '&grant_type=authorization_code');
+    var openid = tokenResp && tokenResp.data ? tokenResp.data.openid : '';
+    if (!openid) {
+        return wxHTMLPage(400, '<p>微信授权失败，请重试。</p><p>WeChat authorization failed, please retry.</p>');
+    }
+
+    if (!trade.jsapi_prepay_id || trade.jsapi_openid !== openid) {
+        var resp = wxRequest(config, 'POST', '/v3/pay/transactions/jsapi', {
+            appid: config.app_id,
+            mchid: config.mch_id,
+            description: trade.description || wxDescription(config, orderNo),
+            out_trade_no: trade.jsapi_out_trade_no,
+            notify_url: AuraLogic.system.getWebhookUrl('wechat.notify'),
+            amount: { total: trade.amount, currency: 'CNY' },
+            payer: { openid: openid }
+        });
+        if (!wxOK(resp) || !resp.data || !resp.data.prepay_id) {
+            return wxHTMLPage(502, '<p>微信支付下单失败。</p><p>' + wxEscape(wxErrorMessage(resp)) + '</p>');
+        }
+        trade.jsapi_prepay_id = resp.data.prepay_id;
+        trade.jsapi_openid = openid;
+        wxSaveTrade(orderNo, trade);
+    }
+
+    var params = {
+        appId: config.app_id,
+        timeStamp: String(AuraLogic.system.getTimestamp()),
+        nonceStr: wxNonce(),
+        package: 'prepay_id=' + trade.jsapi_prepay_id,
+        signType: 'RSA'
+    };
+    params.paySign = AuraLogic.utils.rsaSignSHA256(
+        params.appId + '\n' + params.timeStamp + '\n' + params.nonceStr + '\n' + params.package + '\n',
+        config.mch_private_key
+    );
+    var returnURL = String(config.return_url || '').trim();
+
+    return wxHTMLPage(200,
+        '<p id="status">正在调起微信支付… / Opening WeChat Pay…</p>' +
+        '<script>(function(){' +
+            'var params=' + AuraLogic.utils.jsonEncode(params) + ';' +
+            'var returnURL=' + AuraLogic.utils.jsonEncode(returnURL) + ';' +
+            'function pay(){WeixinJSBridge.invoke("getBrandWCPayRequest",params,function(res){' +
+                'var ok=res.err_msg==="get_brand_wcpay_request:ok";' +
+                'document.getElementById("status").innerText=ok?"支付成功，订单将自动确认 / Paid, the order will be confirmed shortly":"支付未完成 / Payment not completed";' +
+                'if(ok&&returnURL){location.href=returnURL;}' +
+            '});}' +
+            'if(typeof WeixinJSBridge==="undefined"){document.addEventListener("WeixinJSBridgeReady",pay,false);}else{pay();}' +
+        '})();</script>'
+    );
+}
+
+function onWebhook(hookKey, config) {
+    if (wxMissingConfig(config).length > 0) {
+        return wxAck(503, 'FAIL', 'merchant settings are incomplete');
+    }
+    if (hookKey === 'wechat.notify') {
+        return wxHandleNotify(config);
+    }
+    if (hookKey === 'wechat.jsapi') {
+        return wxHandleJSAPI(config);
+    }
+    return wxAck(404, 'FAIL', 'unknown webhook');
+}
+`
+
+// builtinAlipayConfig 支付宝默认配置
+const builtinAlipayConfig = `{"app_id":"","app_private_key":"","alipay_public_key":"","subject":"","return_url":"","gateway":"https://openapi.alipay.com/gateway.do"}`
+
+// builtinAlipayScript 支付宝开放平台（RSA2 公钥模式）：当面付扫码（桌面端）与手机网站支付，支持异步通知、查单与退款
+const builtinAlipayScript = `
+/**
+ * 支付宝付款方式脚本 (RSA2 公钥模式)
+ * - 桌面端：alipay.trade.precreate 生成二维码
+ * - 手机端：alipay.trade.wap.pay 跳转支付宝
+ * - alipay.notify 回调：验签后确认付款
+ *
+ * 配置说明:
+ * - app_id: 开放平台应用 APPID (必填)
+ * - app_private_key: 应用私钥，PKCS#1/PKCS#8 PEM 或不带头尾的 Base64 (必填)
+ * - alipay_public_key: 支付宝公钥 (必填，用于验证通知与同步响应签名)
+ * - subject: 订单标题 (可选，默认 "Order <订单号>")
+ * - return_url: 手机网站支付完成后跳转的地址 (可选)
+ * - gateway: 网关地址 (默认 https://openapi.alipay.com/gateway.do，沙箱请改为沙箱网关)
+ */
+
+var ALI_REQUIRED_FIELDS = ['app_id', 'app_private_key', 'alipay_public_key'];
+
+function aliEscape(value) {
+    return String(value === undefined || value === null ? '' : value)
+        .replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;').replace(/'/g, '&#39;');
+}
+
+function aliMissingConfig(config) {
+    var missing = [];
+    for (var i = 0; i < ALI_REQUIRED_FIELDS.length; i++) {
+        if (!String(config[ALI_REQUIRED_FIELDS[i]] || '').trim()) {
+            missing.push(ALI_REQUIRED_FIELDS[i]);
+        }
+    }
+    return missing;
+}
+
+function aliPad(n) {
+    return n < 10 ? '0' + n : String(n);
+}
+
+// aliTimestamp 支付宝要求北京时间 yyyy-MM-dd HH:mm:ss
+function aliTimestamp() {
+    var d = new Date((AuraLogic.system.getTimestamp() + 8 * 3600) * 1000);
+    return d.getUTCFullYear() + '-' + aliPad(d.getUTCMonth() + 1) + '-' + aliPad(d.getUTCDate()) + ' ' +
+        aliPad(d.getUTCHours()) + ':' + aliPad(d.getUTCMinutes()) + ':' + aliPad(d.getUTCSeconds());
+}
+
+function aliYuan(minor) {
+    return (minor / 100).toFixed(2);
+}
+
+// aliSignContent 按参数名排序拼接待签名字符串，跳过 sign 与空值
+function aliSignContent(params, skipSignType) {
+    var keys = [];
+    for (var key in params) {
+        if (!params.hasOwnProperty(key) || key === 'sign' || (skipSignType && key === 'sign_type')) {
+            continue;
+        }
+        if (params[key] === undefined || params[key] === null || params[key] === '') {
+            continue;
+        }
+        keys.push(key);
+    }
+    keys.sort();
+    var parts = [];
+    for (var i = 0; i < keys.length; i++) {
+        parts.push(keys[i] + '=' + params[keys[i]]);
+    }
+    return parts.join('&');
+}
+
+function aliFormEncode(params) {
+    var parts = [];
+    for (var key in params) {
+        if (params.hasOwnProperty(key) && params[key] !== undefined && params[key] !== null && params[key] !== '') {
+            parts.push(encodeURIComponent(key) + '=' + encodeURIComponent(params[key]));
+        }
+    }
+    return parts.join('&');
+}
+
+function aliSignedParams(config, method, biz, extra) {
+    var params = {
+        app_id: config.app_id,
+        method: method,
+        format: 'JSON',
+        charset: 'utf-8',
+        sign_type: 'RSA2',
+        timestamp: aliTimestamp(),
+        version: '1.0',
+        biz_content: AuraLogic.utils.jsonEncode(biz)
+    };
+    for (var key in (extra || {})) {
+        params[key] = extra[key];
+    }
+    params.sign = AuraLogic.utils.rsaSignSHA256(aliSignContent(params, false), config.app_private_key);
+    return params;
+}
+
+function aliGateway(config) {
+    return String(config.gateway || 'https://openapi.alipay.com/gateway.do').trim();
+}
+
+// aliCall 调用网关接口，校验同步响应签名后返回响应节点
+function aliCall(config, method, biz, extra) {
+    var params = aliSignedParams(config, method, biz, extra);
+    if (!params.sign) {
+        return { error: 'Invalid app private key' };
+    }
+    var resp = AuraLogic.http.post(aliGateway(config), aliFormEncode(params), {
+        'Content-Type': 'application/x-www-form-urlencoded;charset=utf-8'
+    });
+    if (!resp || resp.error || !resp.data) {
+        return { error: resp && resp.error ? resp.error : 'HTTP ' + (resp ? resp.status : 0) };
+    }
+    var node = method.replace(/\./g, '_') + '_response';
+    var result = resp.data[node];
+    if (!result) {
+        return { error: 'Unexpected response' };
+    }
+    // 签名覆盖响应节点的原始 JSON 文本
+    var body = resp.body || '';
+    var marker = '"' + node + '":';
+    var start = body.indexOf(marker);
+    var end = body.lastIndexOf(',"sign":"');
+    if (resp.data.sign && start >= 0 && end > start) {
+        var content = body.substring(start + marker.length, end);
+        if (!AuraLogic.utils.rsaVerifySHA256(content, resp.data.sign, config.alipay_public_key)) {
+            return { error: 'Response signature verification failed' };
+        }
+    } else if (result.code === '10000') {
+        return { error: 'Response is not signed' };
+    }
+    return { result: result };
+}
+
+function aliErrorMessage(call) {
+    if (call.error) {
+        return call.error;
+    }
+    var r = call.result || {};
+    return [r.code, r.msg, r.sub_code, r.sub_msg].filter(function (v) { return !!v; }).join(' ');
+}
+
+function aliLoadTrade(orderNo) {
+    var raw = AuraLogic.storage.get('trade_' + orderNo);
+    return raw ? AuraLogic.utils.jsonDecode(raw) : null;
+}
+
+function aliSaveTrade(orderNo, trade) {
+    AuraLogic.storage.set('trade_' + orderNo, AuraLogic.utils.jsonEncode(trade));
+}
+
+function aliOrderNoFor(outTradeNo) {
+    return AuraLogic.storage.get('out_' + outTradeNo) || String(outTradeNo || '').replace(/(_\d+)?W?$/, '');
+}
+
+// aliEnsureTrade 金额变化时换用新的商户单号，扫码与手机网站支付使用不同单号
+function aliEnsureTrade(order, config) {
+    var amount = order.total_amount_minor || 0;
+    var trade = aliLoadTrade(order.order_no);
+    if (trade && trade.amount === amount) {
+        return trade;
+    }
+    var seq = trade ? (trade.seq || 0) + 1 : 0;
+    var outTradeNo = seq ? order.order_no + '_' + seq : order.order_no;
+    trade = {
+        seq: seq,
+        amount: amount,
+        out_trade_no: outTradeNo,
+        wap_out_trade_no: outTradeNo + 'W',
+        subject: (String(config.subject || '').trim() || ('Order ' + order.order_no)).substring(0, 120),
+        qr_code: ''
+    };
+    AuraLogic.storage.set('out_' + trade.out_trade_no, order.order_no);
+    AuraLogic.storage.set('out_' + trade.wap_out_trade_no, order.order_no);
+    aliSaveTrade(order.order_no, trade);
+    return trade;
+}
+
+function aliNotice(zh, en, detail) {
+    return {
+        html: '<div class="p-6 text-center space-y-2">' +
+            '<p class="text-sm text-destructive font-medium"><span class="lang-zh">' + zh + '</span><span class="lang-en">' + en + '</span></p>' +
+            (detail ? '<p class="text-xs text-muted-foreground break-all">' + aliEscape(detail) + '</p>' : '') +
+        '</div>',
+        title: 'Error'
+    };
+}
+
+function onGeneratePaymentCard(order, config) {
+    if (aliMissingConfig(config).length > 0) {
+        return aliNotice('支付宝应用信息未配置', 'Alipay app settings are incomplete', '');
+    }
+    if (order.currency !== 'CNY') {
+        return aliNotice('支付宝仅支持人民币 (CNY) 订单', 'Alipay only supports CNY orders', order.currency);
+    }
+
+    var trade = aliEnsureTrade(order, config);
+    var notifyURL = AuraLogic.system.getWebhookUrl('alipay.notify');
+    if (!trade.qr_code) {
+        var call = aliCall(config, 'alipay.trade.precreate', {
+            out_trade_no: trade.out_trade_no,
+            total_amount: aliYuan(trade.amount),
+            subject: trade.subject
+        }, { notify_url: notifyURL });
+        if (call.error || !call.result || call.result.code !== '10000' || !call.result.qr_code) {
+            return aliNotice('支付宝下单失败，请稍后重试', 'Failed to create Alipay order, please retry later', aliErrorMessage(call));
+        }
+        trade.qr_code = call.result.qr_code;
+        aliSaveTrade(order.order_no, trade);
+    }
+
+    AuraLogic.order.updatePaymentData({
+        gateway: 'alipay',
+        out_trade_no: trade.out_trade_no
+    });
+
+    // 手机网站支付：签名后的网关 GET 链接，浏览器打开后跳转支付宝
+    var wapParams = aliSignedParams(config, 'alipay.trade.wap.pay', {
+        out_trade_no: trade.wap_out_trade_no,
+        total_amount: aliYuan(trade.amount),
+        subject: trade.subject,
+        product_code: 'QUICK_WAP_WAY'
+    }, { notify_url: notifyURL, return_url: String(config.return_url || '').trim() });
+    var wapURL = aliGateway(config) + '?' + aliFormEncode(wapParams);
+
+    var qr = AuraLogic.utils.qrcode(trade.qr_code, { size: 240, level: 'M' });
+    var html =
+        '<div class="space-y-4">' +
+            '<div class="border border-sky-200 dark:border-sky-800 rounded-lg p-4 text-center space-y-3">' +
+                '<div class="text-3xl font-bold text-sky-600 dark:text-sky-400">' + AuraLogic.utils.formatPrice(trade.amount, 'CNY') + '</div>' +
+                '<div class="flex justify-center">' +
+                    '<img src="' + qr + '" alt="Alipay QR" class="w-48 h-48 rounded-lg border border-border bg-white p-1" />' +
+                '</div>' +
+                '<p class="text-xs text-muted-foreground">' +
+                    '<span class="lang-zh">请使用支付宝扫码支付，付款后将自动确认</span>' +
+                    '<span class="lang-en">Scan with Alipay to pay. Payment is confirmed automatically.</span>' +
+                '</p>' +
+            '</div>' +
+            '<a class="inline-flex w-full items-center justify-center rounded-md bg-sky-600 px-4 py-2 text-sm font-medium text-white hover:bg-sky-700" href="' + aliEscape(wapURL) + '">' +
+                '<span class="lang-zh">在手机上？打开支付宝支付</span><span class="lang-en">On mobile? Open Alipay</span>' +
+            '</a>' +
+            '<div class="text-xs text-muted-foreground border-t pt-3">' +
+                '<p><span class="lang-zh">订单号</span><span class="lang-en">Order</span>: <code class="bg-muted px-1 rounded">' + aliEscape(order.order_no) + '</code></p>' +
+            '</div>' +
+        '</div>';
+
+    return {
+        html: html,
+        title: '支付宝',
+        description: 'Alipay',
+        data: { out_trade_no: trade.out_trade_no }
+    };
+}
+
+function aliIsPaid(status) {
+    return status === 'TRADE_SUCCESS' || status === 'TRADE_FINISHED';
+}
+
+function onCheckPaymentStatus(order, config) {
+    var trade = aliLoadTrade(order.order_no);
+    if (!trade || aliMissingConfig(config).length > 0) {
+        return { paid: false };
+    }
+    var candidates = [trade.out_trade_no, trade.wap_out_trade_no];
+    for (var i = 0; i < candidates.length; i++) {
+        var call = aliCall(config, 'alipay.trade.query', { out_trade_no: candidates[i] });
+        if (call.error || !call.result || call.result.code !== '10000' || !aliIsPaid(call.result.trade_status)) {
+            continue;
+        }
+        var paid = Math.round(parseFloat(call.result.total_amount) * 100);
+        if (paid !== trade.amount) {
+            return { paid: false, message: 'Paid amount ' + call.result.total_amount + ' does not match expected ' + aliYuan(trade.amount) };
+        }
+        trade.paid_out_trade_no = candidates[i];
+        trade.trade_no = call.result.trade_no;
+        aliSaveTrade(order.order_no, trade);
+        return {
+            paid: true,
+            transaction_id: call.result.trade_no,
+            message: 'Alipay confirmed',
+            data: { out_trade_no: candidates[i] }
+        };
+    }
+    return { paid: false };
+}
+
+function onRefund(order, config) {
+    if (aliMissingConfig(config).length > 0) {
+        return { success: false, message: 'Alipay app settings are incomplete' };
+    }
+    var trade = aliLoadTrade(order.order_no) || {};
+    var outTradeNo = trade.paid_out_trade_no || trade.out_trade_no || order.order_no;
+    var refund = order.refund_amount_minor;
+    if (refund === undefined || refund === null) {
+        return { success: false, message: 'Refund amount is missing' };
+    }
+    if (!(refund > 0)) {
+        return { success: false, message: 'Refund amount must be greater than 0' };
+    }
+    // 退款请求号由订单号与退款序号组成，重试同一笔退款时不变，支付宝据此去重
+    var outRequestNo = order.order_no + 'R' + (order.refund_sequence || 1);
+
+    var call = aliCall(config, 'alipay.trade.refund', {
+        out_trade_no: outTradeNo,
+        refund_amount: aliYuan(refund),
+        out_request_no: outRequestNo
+    });
+    if (call.error || !call.result || call.result.code !== '10000') {
+        return { success: false, message: 'Alipay refund failed: ' + aliErrorMessage(call) };
+    }
+    return {
+        success: true,
+        transaction_id: call.result.trade_no,
+        message: 'Alipay refund succeeded',
+        data: { out_request_no: outRequestNo, refund_fee: call.result.refund_fee, fund_change: call.result.fund_change }
+    };
+}
+
+function aliParseForm(text) {
+    var params = {};
+    var pairs = String(text || '').split('&');
+    for (var i = 0; i < pairs.length; i++) {
+        if (!pairs[i]) {
+            continue;
+        }
+        var idx = pairs[i].indexOf('=');
+        var key = idx >= 0 ? pairs[i].substring(0, idx) : pairs[i];
+        var value = idx >= 0 ? pairs[i].substring(idx + 1) : '';
+        params[decodeURIComponent(key.replace(/\+/g, ' '))] = decodeURIComponent(value.replace(/\+/g, ' '));
+    }
+    return params;
+}
+
+function aliPlain(status, body) {
+    return { ack_status: status, ack_headers: { 'Content-Type': 'text/plain; charset=utf-8' }, ack_body: body };
+}
+
+function onWebhook(hookKey, config) {
+    if (hookKey !== 'alipay.notify') {
+        return aliPlain(404, 'failure');
+    }
+    if (aliMissingConfig(config).length > 0) {
+        return aliPlain(503, 'failure');
+    }
+
+    var params = aliParseForm(AuraLogic.webhook.text());
+    if (!params.sign || !AuraLogic.utils.rsaVerifySHA256(aliSignContent(params, true), params.sign, config.alipay_public_key)) {
+        return aliPlain(400, 'failure');
+    }
+    if (params.app_id !== config.app_id) {
+        return aliPlain(400, 'failure');
+    }
+    if (!aliIsPaid(params.trade_status)) {
+        return aliPlain(200, 'success');
+    }
+
+    var orderNo = aliOrderNoFor(params.out_trade_no);
+    var trade = aliLoadTrade(orderNo);
+    if (!trade) {
+        // 没有下单记录无法核对金额，不自动确认，应答 success 避免重复通知，由管理员人工核对
+        var missing = aliPlain(200, 'success');
+        missing.message = 'Alipay notification for ' + params.out_trade_no + ' has no matching trade record, manual review required';
+        return missing;
+    }
+    var paid = Math.round(parseFloat(params.total_amount) * 100);
+    if (paid !== trade.amount) {
+        // 金额不符不自动确认，应答 success 避免重复通知，由管理员人工核对
+        var ack = aliPlain(200, 'success');
+        ack.message = 'Alipay amount mismatch for ' + params.out_trade_no + ': paid ' + params.total_amount + ', expected ' + aliYuan(trade.amount);
+        return ack;
+    }
+    trade.paid_out_trade_no = params.out_trade_no;
+    trade.trade_no = params.trade_no;
+    aliSaveTrade(orderNo, trade);
+    var result = aliPlain(200, 'success');
+    result.paid = true;
+    result.order_no = orderNo;
+    result.transaction_id = params.trade_no;
+    result.message = 'Alipay notification';
+    result.data = { out_trade_no: params.out_trade_no };
+    return result;
+}
+`
@@ -0,0 +1,724 @@
+package models
+
+// Paddle / Lemon Squeezy 内置付款方式脚本（代收商户 Merchant of Record）
+// 结账跳转到服务商托管页面，由服务商计算并代缴增值税/销售税；付款结果以签名回调为准
+// tax_inclusive 表示商城标价已含税：开启时服务商从订单金额中拆出税额，关闭时在结账页另行加税
+
+// builtinPaddleConfig Paddle 默认配置
+const builtinPaddleConfig = `{"api_key":"","client_token":"","webhook_secret":"","tax_inclusive":true,"tax_category":"standard","product_name":"","sandbox":false}`
+
+// builtinPaddleScript Paddle Billing：按订单金额创建交易并跳转 Paddle Checkout，transaction.completed 回调确认付款，支持退款
+const builtinPaddleScript = `
+/**
+ * Paddle Billing 付款方式脚本 (Merchant of Record)
+ * - 按订单金额创建非目录价格的交易 (transaction)，跳转 Paddle Checkout 完成付款
+ * - paddle.notify 回调：校验 Paddle-Signature 后确认付款
+ * - paddle.checkout：托管结账页，需在 Paddle 后台把默认付款链接 (Default payment link) 设置为该地址
+ *
+ * 配置说明:
+ * - api_key: Paddle API Key (必填)
+ * - client_token: 客户端令牌 Client-side token (必填，用于加载 Paddle.js)
+ * - webhook_secret: 通知目的地 (Notification destination) 的密钥 (必填)
+ * - tax_inclusive: 订单金额是否已含税 (默认 true；关闭后由 Paddle 在结账时另行加税)
+ * - tax_category: 税务类别 (默认 standard，数字商品可用 digital-goods 等 Paddle 已批准的类别)
+ * - product_name: 结账页显示的商品名称 (可选，默认 "Order <订单号>")
+ * - sandbox: 是否使用沙箱环境 (默认 false)
+ */
+
+var PADDLE_REQUIRED_FIELDS = ['api_key', 'client_token', 'webhook_secret'];
+// 无小数位的币种，Paddle 金额单位为整数元
+var PADDLE_ZERO_DECIMAL = { JPY: true, KRW: true };
+
+function paddleEscape(value) {
+    return String(value === undefined || value === null ? '' : value)
+        .replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;').replace(/'/g, '&#39;');
+}
+
+function paddleMissingConfig(config) {
+    var missing = [];
+    for (var i = 0; i < PADDLE_REQUIRED_FIELDS.length; i++) {
+        if (!String(config[PADDLE_REQUIRED_FIELDS[i]] || '').trim()) {
+            missing.push(PADDLE_REQUIRED_FIELDS[i]);
+        }
+    }
+    return missing;
+}
+
+function paddleTaxInclusive(config) {
+    return config.tax_inclusive !== false && config.tax_inclusive !== 'false';
+}
+
+function paddleSandbox(config) {
+    return config.sandbox === true || config.sandbox === 'true';
+}
+
+function paddleAPIBase(config) {
+    return paddleSandbox(config) ? 'https://sandbox-api.paddle.com' : 'https://api.paddle.com';
+}
+
+// 订单金额 (两位小数的最小单位) 与 Paddle 金额互转
+function paddleToGateway(minor, currency) {
+    return PADDLE_ZERO_DECIMAL[currency] ? Math.round(minor / 100) : minor;
+}
+
+function paddleFromGateway(amount, currency) {
+    var value = parseInt(amount, 10) || 0;
+    return PADDLE_ZERO_DECIMAL[currency] ? value * 100 : value;
+}
+
+function paddleRequest(config, method, path, body) {
+    var headers = {
+        'Authorization': 'Bearer ' + config.api_key,
+        'Accept': 'application/json',
+        'Content-Type': 'application/json'
+    };
+    var url = paddleAPIBase(config) + path;
+    if (method === 'GET') {
+        return AuraLogic.http.get(url, headers);
+    }
+    return AuraLogic.http.post(url, body ? AuraLogic.utils.jsonEncode(body) : '', headers);
+}
+
+function paddleOK(resp) {
+    return resp && !resp.error && resp.status >= 200 && resp.status < 300 && resp.data && resp.data.data;
+}
+
+function paddleErrorMessage(resp) {
+    if (!resp) {
+        return 'No response';
+    }
+    if (resp.error) {
+        return resp.error;
+    }
+    if (resp.data && resp.data.error) {
+        return (resp.data.error.code ? resp.data.error.code + ': ' : '') + (resp.data.error.detail || '');
+    }
+    return 'HTTP ' + resp.status;
+}
+
+// 交易记录：trade_<订单号> 保存 Paddle 交易 ID、金额、币种与结账链接
+function paddleLoadTrade(orderNo) {
+    var raw = AuraLogic.storage.get('trade_' + orderNo);
+    return raw ? AuraLogic.utils.jsonDecode(raw) : null;
+}
+
+function paddleSaveTrade(orderNo, trade) {
+    AuraLogic.storage.set('trade_' + orderNo, AuraLogic.utils.jsonEncode(trade));
+}
+
+// paddleAmountMatches 含税模式核对含税总额，不含税模式核对税前小计
+function paddleAmountMatches(trade, totals, currency) {
+    if (!totals || currency !== trade.currency) {
+        return false;
+    }
+    var paid = paddleFromGateway(trade.tax_inclusive ? totals.total : totals.subtotal, currency);
+    return paid === trade.amount;
+}
+
+function paddleNotice(zh, en, detail) {
+    return {
+        html: '<div class="p-6 text-center space-y-2">' +
+            '<p class="text-sm text-destructive font-medium"><span class="lang-zh">' + zh + '</span><span class="lang-en">' + en + '</span></p>' +
+            (detail ? '<p class="text-xs text-muted-foreground break-all">' + paddleEscape(detail) + '</p>' : '') +
+        '</div>',
+        title: 'Error'
+    };
+}
+
+// paddleEnsureTransaction 金额或币种变化（如切换付款方式导致手续费不同）时重新创建交易
+function paddleEnsureTransaction(order, config) {
+    var amount = order.total_amount_minor || 0;
+    var taxInclusive = paddleTaxInclusive(config);
+    var trade = paddleLoadTrade(order.order_no);
+    if (trade && trade.amount === amount && trade.currency === order.currency && trade.tax_inclusive === taxInclusive && trade.checkout_url) {
+        return { trade: trade };
+    }
+
+    var name = String(config.product_name || '').trim() || ('Order ' + order.order_no);
+    var user = AuraLogic.order.getUser() || {};
+    var body = {
+        items: [{
+            quantity: 1,
+            price: {
+                name: name.substring(0, 150),
+                description: 'Order ' + order.order_no,
+                unit_price: { amount: String(paddleToGateway(amount, order.currency)), currency_code: order.currency },
+                tax_mode: taxInclusive ? 'internal' : 'external',
+                product: { name: name.substring(0, 200), tax_category: String(config.tax_category || 'standard') }
+            }
+        }],
+        currency_code: order.currency,
+        collection_mode: 'automatic',
+        custom_data: { order_no: order.order_no, email: user.email || '' },
+        checkout: { url: AuraLogic.system.getWebhookUrl('paddle.checkout') }
+    };
+    var resp = paddleRequest(config, 'POST', '/transactions', body);
+    if (!paddleOK(resp) || !resp.data.data.checkout || !resp.data.data.checkout.url) {
+        return { error: paddleErrorMessage(resp) };
+    }
+    trade = {
+        transaction_id: resp.data.data.id,
+        amount: amount,
+        currency: order.currency,
+        tax_inclusive: taxInclusive,
+        checkout_url: resp.data.data.checkout.url
+    };
+    paddleSaveTrade(order.order_no, trade);
+    return { trade: trade };
+}
+
+function onGeneratePaymentCard(order, config) {
+    if (paddleMissingConfig(config).length > 0) {
+        return paddleNotice('Paddle 商户信息未配置', 'Paddle settings are incomplete', '');
+    }
+    var ensured = paddleEnsureTransaction(order, config);
+    if (ensured.error) {
+        return paddleNotice('创建 Paddle 结账失败，请稍后重试', 'Failed to start Paddle checkout, please retry later', ensured.error);
+    }
+    var trade = ensured.trade;
+
+    AuraLogic.order.updatePaymentData({
+        gateway: 'paddle',
+        transaction_id: trade.transaction_id,
+        tax_inclusive: trade.tax_inclusive
+    });
+
+    var taxNote = trade.tax_inclusive
+        ? '<span class="lang-zh">价格已含税，税费由 Paddle 作为代收商户代缴</span><span class="lang-en">Price includes tax. Paddle is the merchant of record and handles tax.</span>'
+        : '<span class="lang-zh">适用的增值税/销售税将在结账页另行计算</span><span class="lang-en">Applicable VAT / sales tax is added at checkout.</span>';
+
+    var html =
+        '<div class="border rounded-lg p-4 text-center space-y-3">' +
+            '<div class="text-3xl font-bold">' + AuraLogic.utils.formatPrice(trade.amount, trade.currency) + '</div>' +
+            '<p class="text-xs text-muted-foreground">' + taxNote + '</p>' +
+            '<a class="inline-flex w-full items-center justify-center rounded-md bg-primary px-4 py-2 text-sm font-medium text-primary-foreground hover:bg-primary/90" href="' +
+                paddleEscape(trade.checkout_url) + '" target="_blank" rel="noopener">' +
+                '<span class="lang-zh">前往 Paddle 安全结账</span><span class="lang-en">Continue to secure checkout</span>' +
+            '</a>' +
+            '<p class="text-xs text-muted-foreground">' +
+                '<span class="lang-zh">付款完成后订单将自动确认</span><span class="lang-en">Your order is confirmed automatically after payment.</span>' +
+            '</p>' +
+        '</div>';
+
+    return {
+        html: '<div class="space-y-4">' + html + '</div>',
+        title: 'Paddle',
+        description: 'Card, PayPal, Apple Pay and more',
+        data: { transaction_id: trade.transaction_id }
+    };
+}
+
+function paddleIsPaid(status) {
+    return status === 'paid' || status === 'completed';
+}
+
+function paddlePaidResult(trade, txn) {
+    var totals = txn.details ? txn.details.totals : null;
+    return {
+        transaction_id: txn.id,
+        data: {
+            transaction_id: txn.id,
+            currency: txn.currency_code,
+            tax_minor: totals ? paddleFromGateway(totals.tax, txn.currency_code) : 0,
+            gross_minor: totals ? paddleFromGateway(totals.total, txn.currency_code) : 0,
+            tax_inclusive: trade.tax_inclusive
+        }
+    };
+}
+
+function paddleRememberLineItem(trade, txn) {
+    var lineItems = txn.details && txn.details.line_items;
+    if (lineItems && lineItems.length > 0) {
+        trade.line_item_id = lineItems[0].id;
+    }
+    trade.gross_minor = txn.details && txn.details.totals ? paddleFromGateway(txn.details.totals.total, txn.currency_code) : trade.amount;
+    trade.paid = true;
+}
+
+function onCheckPaymentStatus(order, config) {
+    var trade = paddleLoadTrade(order.order_no);
+    if (!trade || !trade.transaction_id || paddleMissingConfig(config).length > 0) {
+        return { paid: false };
+    }
+    var resp = paddleRequest(config, 'GET', '/transactions/' + encodeURIComponent(trade.transaction_id), null);
+    if (!paddleOK(resp)) {
+        return { paid: false };
+    }
+    var txn = resp.data.data;
+    if (!paddleIsPaid(txn.status)) {
+        return { paid: false };
+    }
+    if (!paddleAmountMatches(trade, txn.details ? txn.details.totals : null, txn.currency_code)) {
+        return { paid: false, message: 'Paddle amount mismatch for ' + txn.id };
+    }
+    paddleRememberLineItem(trade, txn);
+    paddleSaveTrade(order.order_no, trade);
+    var result = paddlePaidResult(trade, txn);
+    result.paid = true;
+    result.message = 'Paddle payment confirmed';
+    return result;
+}
+
+// onRefund 通过调整单 (adjustment) 退款；Paddle 审核前为 pending_approval，返回 pending 由管理员稍后确认
+function onRefund(order, config) {
+    if (paddleMissingConfig(config).length > 0) {
+        return { success: false, message: 'Paddle settings are incomplete' };
+    }
+    var trade = paddleLoadTrade(order.order_no);
+    if (!trade || !trade.transaction_id) {
+        return { success: false, message: 'Paddle transaction not found for order ' + order.order_no };
+    }
+    var refund = order.refund_amount_minor;
+    if (refund === undefined || refund === null) {
+        return { success: false, message: 'Refund amount is missing' };
+    }
+    if (!(refund > 0)) {
+        return { success: false, message: 'Refund amount must be greater than 0' };
+    }
+    var body = {
+        action: 'refund',
+        transaction_id: trade.transaction_id,
+        reason: 'Refund for order ' + order.order_no
+    };
+    if (refund >= trade.amount) {
+        body.type = 'full';
+    } else {
+        if (!trade.line_item_id) {
+            return { success: false, message: 'Paddle line item not found, refund from the Paddle dashboard instead' };
+        }
+        // 不含税模式下顾客实付含税金额，按比例把税额一并退回
+        var gross = trade.tax_inclusive ? refund : Math.round(refund * (trade.gross_minor || trade.amount) / trade.amount);
+        body.type = 'partial';
+        body.items = [{ item_id: trade.line_item_id, type: 'partial', amount: String(paddleToGateway(gross, trade.currency)) }];
+    }
+    var resp = paddleRequest(config, 'POST', '/adjustments', body);
+    if (!paddleOK(resp)) {
+        return { success: false, message: 'Paddle refund failed: ' + paddleErrorMessage(resp) };
+    }
+    var adjustment = resp.data.data;
+    if (adjustment.status === 'rejected') {
+        return { success: false, message: 'Paddle rejected the refund', data: { adjustment_id: adjustment.id } };
+    }
+    return {
+        success: true,
+        pending: adjustment.status !== 'approved',
+        transaction_id: adjustment.id,
+        message: adjustment.status === 'approved' ? 'Paddle refund approved' : 'Paddle refund is awaiting approval',
+        data: { adjustment_id: adjustment.id, status: adjustment.status }
+    };
+}
+
+function paddleAck(status, message) {
+    return { ack_status: status, ack_body: { message: message } };
+}
+
+// paddleVerifySignature 校验 Paddle-Signature: ts=...;h1=...，签名内容为 "<ts>:<原始请求体>"，允许 5 分钟时间偏差
+function paddleVerifySignature(config) {
+    var header = AuraLogic.webhook.header('paddle-signature') || '';
+    var ts = '';
+    var signatures = [];
+    var parts = header.split(';');
+    for (var i = 0; i < parts.length; i++) {
+        var pair = parts[i].split('=');
+        if (pair[0] === 'ts') {
+            ts = pair[1] || '';
+        } else if (pair[0] === 'h1' && pair[1]) {
+            signatures.push(pair[1]);
+        }
+    }
+    if (!ts || signatures.length === 0) {
+        return false;
+    }
+    if (Math.abs(AuraLogic.system.getTimestamp() - parseInt(ts, 10)) > 300) {
+        return false;
+    }
+    var expected = AuraLogic.utils.hmacSHA256(ts + ':' + AuraLogic.webhook.text(), config.webhook_secret);
+    for (var j = 0; j < signatures.length; j++) {
+        if (signatures[j] === expected) {
+            return true;
+        }
+    }
+    return false;
+}
+
+function paddleHandleNotify(config) {
+    if (!paddleVerifySignature(config)) {
+        return paddleAck(401, 'invalid signature');
+    }
+    var payload = AuraLogic.webhook.json();
+    var txn = payload && payload.data;
+    if (!payload || (payload.event_type !== 'transaction.completed' && payload.event_type !== 'transaction.paid') || !txn) {
+        return paddleAck(200, 'ignored');
+    }
+    var orderNo = txn.custom_data ? String(txn.custom_data.order_no || '') : '';
+    var trade = orderNo ? paddleLoadTrade(orderNo) : null;
+    if (!trade || trade.transaction_id !== txn.id) {
+        // 非本商城创建的交易或已被新交易替换，应答成功避免重复通知
+        return paddleAck(200, 'unknown transaction');
+    }
+    if (!paddleAmountMatches(trade, txn.details ? txn.details.totals : null, txn.currency_code)) {
+        var ack = paddleAck(200, 'amount mismatch');
+        ack.message = 'Paddle amount mismatch for ' + txn.id + ' (order ' + orderNo + ')';
+        return ack;
+    }
+    paddleRememberLineItem(trade, txn);
+    paddleSaveTrade(orderNo, trade);
+
+    var result = paddlePaidResult(trade, txn);
+    result.paid = true;
+    result.order_no = orderNo;
+    result.message = 'Paddle ' + payload.event_type;
+    result.ack_status = 200;
+    result.ack_body = { message: 'ok' };
+    return result;
+}
+
+// paddleHandleCheckout 默认付款链接页面：加载 Paddle.js，Paddle.js 读取 URL 中的 _ptxn 自动打开结账
+function paddleHandleCheckout(config) {
+    var environment = paddleSandbox(config) ? 'Paddle.Environment.set("sandbox");' : '';
+    return {
+        ack_status: 200,
+        ack_headers: { 'Content-Type': 'text/html; charset=utf-8', 'Cache-Control': 'no-store' },
+        ack_body: '<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1">' +
+            '<title>Checkout</title><script src="https://cdn.paddle.com/paddle/v2/paddle.js"></script></head>' +
+            '<body style="font-family:sans-serif;text-align:center;padding:48px 16px">' +
+            '<p id="status">正在打开结账页面… / Opening checkout…</p>' +
+            '<script>' + environment + 'Paddle.Initialize({token:' + AuraLogic.utils.jsonEncode(String(config.client_token)) + ',eventCallback:function(e){' +
+                'if(e&&e.name==="checkout.completed"){document.getElementById("status").innerText="付款成功，订单将自动确认，可关闭本页 / Payment received, you can close this page";}' +
+            '}});</script></body></html>'
+    };
+}
+
+function onWebhook(hookKey, config) {
+    if (paddleMissingConfig(config).length > 0) {
+        return paddleAck(503, 'settings are incomplete');
+    }
+    if (hookKey === 'paddle.notify') {
+        return paddleHandleNotify(config);
+    }
+    if (hookKey === 'paddle.checkout') {
+        return paddleHandleCheckout(config);
+    }
+    return paddleAck(404, 'unknown webhook');
+}
+`
+
+// builtinLemonSqueezyConfig Lemon Squeezy 默认配置
+const builtinLemonSqueezyConfig = `{"api_key":"","store_id":"","variant_id":"","signing_secret":"","currency":"USD","tax_inclusive":true,"product_name":"","redirect_url":"","test_mode":false}`
+
+// builtinLemonSqueezyScript Lemon Squeezy：以自定义价格创建托管结账，order_created 回调确认付款，支持退款
+const builtinLemonSqueezyScript = `
+/**
+ * Lemon Squeezy 付款方式脚本 (Merchant of Record)
+ * - 以订单金额作为自定义价格 (custom_price) 创建结账链接，跳转 Lemon Squeezy 托管页面付款
+ * - lemonsqueezy.notify 回调：校验 X-Signature 后确认付款
+ *
+ * 配置说明:
+ * - api_key: API Key (必填)
+ * - store_id: 店铺 ID (必填)
+ * - variant_id: 用于结账的商品规格 ID (必填，价格会被订单金额覆盖)
+ * - signing_secret: Webhook 签名密钥 (必填，Webhook 需订阅 order_created 事件)
+ * - currency: 店铺币种 (默认 USD，只接受该币种的订单)
+ * - tax_inclusive: 与店铺 "Tax inclusive pricing" 设置保持一致 (默认 true)
+ * - product_name: 结账页显示的商品名称 (可选，默认 "Order <订单号>")
+ * - redirect_url: 付款完成后跳转的地址 (可选)
+ * - test_mode: 是否创建测试模式结账 (默认 false)
+ */
+
+var LS_REQUIRED_FIELDS = ['api_key', 'store_id', 'variant_id', 'signing_secret'];
+var LS_API_BASE = 'https://api.lemonsqueezy.com/v1';
+
+function lsEscape(value) {
+    return String(value === undefined || value === null ? '' : value)
+        .replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;').replace(/'/g, '&#39;');
+}
+
+function lsMissingConfig(config) {
+    var missing = [];
+    for (var i = 0; i < LS_REQUIRED_FIELDS.length; i++) {
+        if (!String(config[LS_REQUIRED_FIELDS[i]] || '').trim()) {
+            missing.push(LS_REQUIRED_FIELDS[i]);
+        }
+    }
+    return missing;
+}
+
+function lsCurrency(config) {
+    return String(config.currency || 'USD').trim().toUpperCase();
+}
+
+function lsTaxInclusive(config) {
+    return config.tax_inclusive !== false && config.tax_inclusive !== 'false';
+}
+
+function lsRequest(config, method, path, body) {
+    var headers = {
+        'Authorization': 'Bearer ' + config.api_key,
+        'Accept': 'application/vnd.api+json',
+        'Content-Type': 'application/vnd.api+json'
+    };
+    var resp = method === 'GET'
+        ? AuraLogic.http.get(LS_API_BASE + path, headers)
+        : AuraLogic.http.post(LS_API_BASE + path, body ? AuraLogic.utils.jsonEncode(body) : '', headers);
+    // JSON:API 响应的 Content-Type 为 application/vnd.api+json，运行时不会自动解析
+    if (resp && !resp.data && resp.body) {
+        resp.data = AuraLogic.utils.jsonDecode(resp.body);
+    }
+    return resp;
+}
+
+function lsOK(resp) {
+    return resp && !resp.error && resp.status >= 200 && resp.status < 300 && resp.data && resp.data.data;
+}
+
+function lsErrorMessage(resp) {
+    if (!resp) {
+        return 'No response';
+    }
+    if (resp.error) {
+        return resp.error;
+    }
+    if (resp.data && resp.data.errors && resp.data.errors.length > 0) {
+        return resp.data.errors[0].detail || resp.data.errors[0].title || ('HTTP ' + resp.status);
+    }
+    return 'HTTP ' + resp.status;
+}
+
+// 交易记录：trade_<订单号> 保存结账链接、金额以及回调确认后的 Lemon Squeezy 订单 ID
+function lsLoadTrade(orderNo) {
+    var raw = AuraLogic.storage.get('trade_' + orderNo);
+    return raw ? AuraLogic.utils.jsonDecode(raw) : null;
+}
+
+function lsSaveTrade(orderNo, trade) {
+    AuraLogic.storage.set('trade_' + orderNo, AuraLogic.utils.jsonEncode(trade));
+}
+
+// lsAmountMatches 含税模式核对含税总额，不含税模式核对税前小计（金额单位均为分）
+function lsAmountMatches(trade, attributes) {
+    if (!attributes || String(attributes.currency || '').toUpperCase() !== trade.currency) {
+        return false;
+    }
+    var paid = parseInt(trade.tax_inclusive ? attributes.total : attributes.subtotal, 10) || 0;
+    return paid === trade.amount;
+}
+
+function lsNotice(zh, en, detail) {
+    return {
+        html: '<div class="p-6 text-center space-y-2">' +
+            '<p class="text-sm text-destructive font-medium"><span class="lang-zh">' + zh + '</span><span class="lang-en">' + en + '</span></p>' +
+            (detail ? '<p class="text-xs text-muted-foreground break-all">' + lsEscape(detail) + '</p>' : '') +
+        '</div>',
+        title: 'Error'
+    };
+}
+
+// lsEnsureCheckout 金额变化（如切换付款方式导致手续费不同）时重新创建结账链接
+function lsEnsureCheckout(order, config) {
+    var amount = order.total_amount_minor || 0;
+    var taxInclusive = lsTaxInclusive(config);
+    var trade = lsLoadTrade(order.order_no);
+    if (trade && trade.amount === amount && trade.tax_inclusive === taxInclusive && trade.checkout_url) {
+        return { trade: trade };
+    }
+
+    var name = String(config.product_name || '').trim() || ('Order ' + order.order_no);
+    var user = AuraLogic.order.getUser() || {};
+    var productOptions = {
+        name: name,
+        description: 'Order ' + order.order_no,
+        enabled_variants: [parseInt(config.variant_id, 10)]
+    };
+    if (String(config.redirect_url || '').trim()) {
+        productOptions.redirect_url = String(config.redirect_url).trim();
+    }
+    var checkoutData = { custom: { order_no: order.order_no } };
+    if (user.email) {
+        checkoutData.email = user.email;
+    }
+    var resp = lsRequest(config, 'POST', '/checkouts', {
+        data: {
+            type: 'checkouts',
+            attributes: {
+                custom_price: amount,
+                product_options: productOptions,
+                checkout_data: checkoutData,
+                test_mode: config.test_mode === true || config.test_mode === 'true'
+            },
+            relationships: {
+                store: { data: { type: 'stores', id: String(config.store_id) } },
+                variant: { data: { type: 'variants', id: String(config.variant_id) } }
+            }
+        }
+    });
+    if (!lsOK(resp) || !resp.data.data.attributes || !resp.data.data.attributes.url) {
+        return { error: lsErrorMessage(resp) };
+    }
+    trade = {
+        checkout_id: resp.data.data.id,
+        amount: amount,
+        currency: lsCurrency(config),
+        tax_inclusive: taxInclusive,
+        checkout_url: resp.data.data.attributes.url
+    };
+    lsSaveTrade(order.order_no, trade);
+    return { trade: trade };
+}
+
+function onGeneratePaymentCard(order, config) {
+    if (lsMissingConfig(config).length > 0) {
+        return lsNotice('Lemon Squeezy 商户信息未配置', 'Lemon Squeezy settings are incomplete', '');
+    }
+    if (order.currency !== lsCurrency(config)) {
+        return lsNotice('该付款方式仅支持 ' + lsCurrency(config) + ' 订单', 'This payment method only supports ' + lsCurrency(config) + ' orders', order.currency);
+    }
+    var ensured = lsEnsureCheckout(order, config);
+    if (ensured.error) {
+        return lsNotice('创建 Lemon Squeezy 结账失败，请稍后重试', 'Failed to start Lemon Squeezy checkout, please retry later', ensured.error);
+    }
+    var trade = ensured.trade;
+
+    AuraLogic.order.updatePaymentData({
+        gateway: 'lemonsqueezy',
+        checkout_id: trade.checkout_id,
+        tax_inclusive: trade.tax_inclusive
+    });
+
+    var taxNote = trade.tax_inclusive
+        ? '<span class="lang-zh">价格已含税，税费由 Lemon Squeezy 作为代收商户代缴</span><span class="lang-en">Price includes tax. Lemon Squeezy is the merchant of record and handles tax.</span>'
+        : '<span class="lang-zh">适用的增值税/销售税将在结账页另行计算</span><span class="lang-en">Applicable VAT / sales tax is added at checkout.</span>';
+
+    var html =
+        '<div class="border rounded-lg p-4 text-center space-y-3">' +
+            '<div class="text-3xl font-bold">' + AuraLogic.utils.formatPrice(trade.amount, trade.currency) + '</div>' +
+            '<p class="text-xs text-muted-foreground">' + taxNote + '</p>' +
+            '<a class="inline-flex w-full items-center justify-center rounded-md bg-primary px-4 py-2 text-sm font-medium text-primary-foreground hover:bg-primary/90" href="' +
+                lsEscape(trade.checkout_url) + '" target="_blank" rel="noopener">' +
+                '<span class="lang-zh">前往 Lemon Squeezy 安全结账</span><span class="lang-en">Continue to secure checkout</span>' +
+            '</a>' +
+            '<p class="text-xs text-muted-foreground">' +
+                '<span class="lang-zh">付款完成后订单将自动确认</span><span class="lang-en">Your order is confirmed automatically after payment.</span>' +
+            '</p>' +
+        '</div>';
+
+    return {
+        html: '<div class="space-y-4">' + html + '</div>',
+        title: 'Lemon Squeezy',
+        description: 'Card, PayPal, Apple Pay and more',
+        data: { checkout_id: trade.checkout_id }
+    };
+}
+
+function lsPaidData(trade, orderID, attributes) {
+    return {
+        lemonsqueezy_order_id: orderID,
+        order_identifier: attributes.identifier,
+        currency: attributes.currency,
+        tax_minor: parseInt(attributes.tax, 10) || 0,
+        gross_minor: parseInt(attributes.total, 10) || 0,
+        tax_inclusive: trade.tax_inclusive
+    };
+}
+
+// 结账链接不关联订单，只能在回调确认后按记录的订单 ID 查询
+function onCheckPaymentStatus(order, config) {
+    var trade = lsLoadTrade(order.order_no);
+    if (!trade || !trade.ls_order_id || lsMissingConfig(config).length > 0) {
+        return { paid: false };
+    }
+    var resp = lsRequest(config, 'GET', '/orders/' + encodeURIComponent(trade.ls_order_id), null);
+    if (!lsOK(resp)) {
+        return { paid: false };
+    }
+    var attributes = resp.data.data.attributes || {};
+    if (attributes.status !== 'paid' || !lsAmountMatches(trade, attributes)) {
+        return { paid: false };
+    }
+    return {
+        paid: true,
+        transaction_id: String(trade.ls_order_id),
+        message: 'Lemon Squeezy payment confirmed',
+        data: lsPaidData(trade, trade.ls_order_id, attributes)
+    };
+}
+
+function onRefund(order, config) {
+    if (lsMissingConfig(config).length > 0) {
+        return { success: false, message: 'Lemon Squeezy settings are incomplete' };
+    }
+    var trade = lsLoadTrade(order.order_no);
+    if (!trade || !trade.ls_order_id) {
+        return { success: false, message: 'Lemon Squeezy order not found for ' + order.order_no };
+    }
+    var refund = order.refund_amount_minor;
+    if (refund === undefined || refund === null) {
+        return { success: false, message: 'Refund amount is missing' };
+    }
+    if (!(refund > 0)) {
+        return { success: false, message: 'Refund amount must be greater than 0' };
+    }
+    // 不含税模式下顾客实付含税金额，按比例把税额一并退回
+    var gross = trade.tax_inclusive || !trade.gross_minor ? refund : Math.round(refund * trade.gross_minor / trade.amount);
+    var resp = lsRequest(config, 'POST', '/orders/' + encodeURIComponent(trade.ls_order_id) + '/refund', {
+        data: { type: 'orders', id: String(trade.ls_order_id), attributes: { amount: gross } }
+    });
+    if (!lsOK(resp)) {
+        return { success: false, message: 'Lemon Squeezy refund failed: ' + lsErrorMessage(resp) };
+    }
+    return {
+        success: true,
+        transaction_id: String(trade.ls_order_id),
+        message: 'Lemon Squeezy refund succeeded',
+        data: { refunded_amount_minor: gross }
+    };
+}
+
+function lsAck(status, message) {
+    return { ack_status: status, ack_body: { message: message } };
+}
+
+function onWebhook(hookKey, config) {
+    if (hookKey !== 'lemonsqueezy.notify') {
+        return lsAck(404, 'unknown webhook');
+    }
+    if (lsMissingConfig(config).length > 0) {
+        return lsAck(503, 'settings are incomplete');
+    }
+    var signature = AuraLogic.webhook.header('x-signature') || '';
+    if (!signature || AuraLogic.utils.hmacSHA256(AuraLogic.webhook.text(), config.signing_secret) !== signature) {
+        return lsAck(401, 'invalid signature');
+    }
+
+    var payload = AuraLogic.webhook.json();
+    var meta = (payload && payload.meta) || {};
+    var order = payload && payload.data;
+    if (meta.event_name !== 'order_created' || !order || !order.attributes || order.attributes.status !== 'paid') {
+        return lsAck(200, 'ignored');
+    }
+    var orderNo = meta.custom_data ? String(meta.custom_data.order_no || '') : '';
+    var trade = orderNo ? lsLoadTrade(orderNo) : null;
+    if (!trade) {
+        return lsAck(200, 'unknown order');
+    }
+    if (!lsAmountMatches(trade, order.attributes)) {
+        var ack = lsAck(200, 'amount mismatch');
+        ack.message = 'Lemon Squeezy amount mismatch for order ' + order.id + ' (order ' + orderNo + ')';
+        return ack;
+    }
+    trade.ls_order_id = order.id;
+    trade.gross_minor = parseInt(order.attributes.total, 10) || trade.amount;
+    lsSaveTrade(orderNo, trade);
+
+    return {
+        paid: true,
+        order_no: orderNo,
+        transaction_id: String(order.id),
+        message: 'Lemon Squeezy order_created',
+        data: lsPaidData(trade, order.id, order.attributes),
+        ack_status: 200,
+        ack_body: { message: 'ok' }
+    };
+}
+`
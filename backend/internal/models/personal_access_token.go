@@ -0,0 +1,71 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// PersonalAccessTokenPrefix 个人访问令牌前缀，便于识别与密钥扫描
+const PersonalAccessTokenPrefix = "alpat_"
+
+// 个人访问令牌权限范围
+const (
+	PersonalTokenScopeOrdersRead          = "orders:read"           // 读取自己的订单
+	PersonalTokenScopeVirtualProductsRead = "virtual_products:read" // 读取已付款订单的虚拟商品
+)
+
+// PersonalTokenScopes 支持的全部权限范围
+var PersonalTokenScopes = []string{
+	PersonalTokenScopeOrdersRead,
+	PersonalTokenScopeVirtualProductsRead,
+}
+
+// PersonalAccessToken 用户个人访问令牌，用于脚本只读访问自己的订单与虚拟商品
+// 令牌只保存 SHA-256 哈希，明文仅在创建时返回一次
+type PersonalAccessToken struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"index;not null" json:"user_id"`
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`
+	TokenPrefix string     `gorm:"type:varchar(20)" json:"token_prefix"` // 明文前若干位，用于列表中辨认
+	TokenHash   string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Scopes      []string   `gorm:"type:text;serializer:json" json:"scopes"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP  string     `gorm:"type:varchar(64)" json:"last_used_ip,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	RevokedAt   *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// HashPersonalAccessToken 计算令牌哈希；令牌为高熵随机串，无需加盐
+func HashPersonalAccessToken(raw string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(raw)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired 检查是否已过期
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+// IsUsable 未撤销且未过期
+func (t *PersonalAccessToken) IsUsable() bool {
+	return t.RevokedAt == nil && !t.IsExpired()
+}
+
+// HasScope 检查是否拥有指定权限范围
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
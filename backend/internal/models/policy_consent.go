@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// 需要用户同意的政策类型
+const (
+	PolicyTypeTerms   = "terms"
+	PolicyTypePrivacy = "privacy"
+)
+
+// PolicyVersion 已发布的服务条款/隐私政策版本，每种类型以最新发布的版本为当前版本
+type PolicyVersion struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Type        string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_policy_type_version" json:"type"`
+	Version     string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_policy_type_version" json:"version"`
+	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
+	Content     string    `gorm:"type:text" json:"content"`            // Markdown 全文
+	Summary     string    `gorm:"type:varchar(1000)" json:"summary"`   // 本次更新说明
+	PublishedBy *uint     `gorm:"index" json:"published_by,omitempty"` // 发布的管理员
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+func (PolicyVersion) TableName() string {
+	return "policy_versions"
+}
+
+// PolicyConsent 用户同意政策的记录，只追加不修改，用于合规查询
+type PolicyConsent struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	UserID          uint      `gorm:"not null;uniqueIndex:idx_policy_consent_user_version" json:"user_id"`
+	PolicyVersionID uint      `gorm:"not null;uniqueIndex:idx_policy_consent_user_version" json:"policy_version_id"`
+	PolicyType      string    `gorm:"type:varchar(20);not null" json:"policy_type"`
+	Version         string    `gorm:"type:varchar(50);not null" json:"version"`
+	IPAddress       string    `gorm:"type:varchar(64)" json:"ip_address"`
+	UserAgent       string    `gorm:"type:varchar(500)" json:"user_agent"`
+	AcceptedAt      time.Time `gorm:"index" json:"accepted_at"`
+}
+
+func (PolicyConsent) TableName() string {
+	return "policy_consents"
+}
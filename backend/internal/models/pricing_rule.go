@@ -0,0 +1,134 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PricingRuleType 定价规则的优惠方式
+type PricingRuleType string
+
+const (
+	PricingRuleTypePercentage PricingRuleType = "percentage" // 按比例减免，Value 为基点（100% = 10000）
+	PricingRuleTypeFixed      PricingRuleType = "fixed"      // 立减固定金额
+	PricingRuleTypeThreshold  PricingRuleType = "threshold"  // 满减：适用商品满 MinAmount 减 Value
+)
+
+// PricingRuleScope 定价规则适用的商品范围
+type PricingRuleScope string
+
+const (
+	PricingRuleScopeAll      PricingRuleScope = "all"      // 全部商品
+	PricingRuleScopeSKU      PricingRuleScope = "sku"      // 指定 SKU
+	PricingRuleScopeCategory PricingRuleScope = "category" // 指定商品分类
+)
+
+// PricingRule 下单时自动应用的定价规则。规则按 Priority 从高到低依次计算，
+// 每条规则基于适用商品扣除前序优惠后的剩余金额；Exclusive 规则生效后不再应用优先级更低的规则与优惠码
+type PricingRule struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	Name        string          `gorm:"type:varchar(100);not null" json:"name"`
+	Description string          `gorm:"type:text" json:"description,omitempty"`
+	Type        PricingRuleType `gorm:"type:varchar(20);not null" json:"type"`
+	Value       int64           `gorm:"type:bigint;not null;default:0" json:"-"`
+	MaxDiscount int64           `gorm:"type:bigint;default:0" json:"-"` // 比例规则的优惠上限，0 表示不限
+	MinAmount   int64           `gorm:"type:bigint;default:0" json:"-"` // 适用商品原价小计门槛，满减规则必填
+
+	Scope      PricingRuleScope `gorm:"type:varchar(20);not null;default:'all'" json:"scope"`
+	SKUs       []string         `gorm:"type:text;serializer:json" json:"skus,omitempty"`
+	Categories []string         `gorm:"type:text;serializer:json" json:"categories,omitempty"`
+
+	Priority  int        `gorm:"not null;default:0;index" json:"priority"`
+	Exclusive bool       `gorm:"not null;default:false" json:"exclusive"`
+	Enabled   bool       `gorm:"not null;default:false;index" json:"enabled"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
+
+func (r PricingRule) MarshalJSON() ([]byte, error) {
+	type Alias PricingRule
+	return json.Marshal(&struct {
+		Alias
+		ValueMinor       int64 `json:"value_minor"`
+		MaxDiscountMinor int64 `json:"max_discount_minor"`
+		MinAmountMinor   int64 `json:"min_amount_minor"`
+	}{
+		Alias:            Alias(r),
+		ValueMinor:       r.Value,
+		MaxDiscountMinor: r.MaxDiscount,
+		MinAmountMinor:   r.MinAmount,
+	})
+}
+
+// ActiveAt 规则在指定时间是否处于生效期
+func (r *PricingRule) ActiveAt(now time.Time) bool {
+	if r.StartsAt != nil && now.Before(*r.StartsAt) {
+		return false
+	}
+	if r.ExpiresAt != nil && !now.Before(*r.ExpiresAt) {
+		return false
+	}
+	return true
+}
+
+// Matches 商品是否在规则的适用范围内
+func (r *PricingRule) Matches(sku, category string) bool {
+	switch r.Scope {
+	case PricingRuleScopeSKU:
+		for _, item := range r.SKUs {
+			if item == sku {
+				return true
+			}
+		}
+		return false
+	case PricingRuleScopeCategory:
+		for _, item := range r.Categories {
+			if category != "" && item == category {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// PricingTraceSource 定价评估记录的来源
+type PricingTraceSource string
+
+const (
+	PricingTraceSourceRule      PricingTraceSource = "rule"
+	PricingTraceSourcePromoCode PricingTraceSource = "promo_code"
+)
+
+// 规则未生效的原因
+const (
+	PricingSkipNoMatchingItems = "no_matching_items"  // 订单中没有适用商品
+	PricingSkipMinAmountNotMet = "min_amount_not_met" // 适用商品未达到金额门槛
+	PricingSkipNothingLeft     = "nothing_left"       // 适用商品已被前序优惠减至零
+	PricingSkipExclusive       = "blocked_by_exclusive"
+)
+
+// PricingTraceEntry 下单时一条定价规则或优惠码的评估结果，按计算顺序记录在订单上
+type PricingTraceEntry struct {
+	Source    PricingTraceSource `json:"source"`
+	RuleID    *uint              `json:"rule_id,omitempty"`
+	Name      string             `json:"name"`
+	Type      string             `json:"type"`
+	Priority  int                `json:"priority"`
+	Applied   bool               `json:"applied"`
+	Reason    string             `json:"reason,omitempty"`     // 未生效原因，见 PricingSkip*
+	BlockedBy string             `json:"blocked_by,omitempty"` // 阻止本条生效的独占规则名称
+	SKUs      []string           `json:"skus,omitempty"`       // 参与计算的商品
+	// 计算基数：适用商品扣除前序优惠后的剩余金额
+	BaseAmountMinor int64 `json:"base_amount_minor"`
+	DiscountMinor   int64 `json:"discount_minor"`
+}
@@ -119,9 +119,22 @@ type Product struct {
 	// 虚拟商品自动发货
 	AutoDelivery bool `gorm:"default:false" json:"auto_delivery"` // 虚拟商品是否自动发货
 
+	// 限量发售等候室：下单前需排队获取准入令牌
+	WaitingRoom bool `gorm:"default:false" json:"waiting_room"`
+	// 下单频率限制：按用户/IP/设备指纹限制每小时下单次数
+	OrderRateCap bool `gorm:"default:false" json:"order_rate_cap"`
+	// 免费试用天数：大于 0 时登录用户可领取一次限时试用卡密，仅自动发货的虚拟商品可用
+	TrialDays int `gorm:"default:0" json:"trial_days"`
+	// 所属商家：为空表示平台自营
+	VendorID *uint `gorm:"index" json:"vendor_id,omitempty"`
+	// 收入确认：订阅或预付额度在服务期内按月确认，为空表示付款当月一次性确认
+	RevenueRecognition  RevenueRecognitionType `gorm:"type:varchar(20)" json:"revenue_recognition,omitempty"`
+	ServicePeriodMonths int                    `gorm:"default:0" json:"service_period_months,omitempty"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedBy *uint          `json:"-"` // 删除操作人，移入回收站时记录
 
 	// 关联
 	InventoryBindings []ProductInventoryBinding `gorm:"foreignKey:ProductID" json:"inventory_bindings,omitempty"`
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// ProductTrialStatus 试用状态
+type ProductTrialStatus string
+
+const (
+	ProductTrialStatusActive    ProductTrialStatus = "active"    // 试用中
+	ProductTrialStatusConverted ProductTrialStatus = "converted" // 转正订单已付款，卡密继续有效
+	ProductTrialStatusExpired   ProductTrialStatus = "expired"   // 到期未付款，卡密失效
+)
+
+// ProductTrial 用户对虚拟商品的一次试用，每个用户每个商品只能试用一次
+// 试用通过零金额订单发放卡密；到期前生成转正订单并提醒付款，到期仍未付款则卡密失效
+type ProductTrial struct {
+	ID                uint               `gorm:"primaryKey" json:"id"`
+	UserID            uint               `gorm:"not null;uniqueIndex:idx_product_trial_user_product" json:"user_id"`
+	ProductID         uint               `gorm:"not null;uniqueIndex:idx_product_trial_user_product;index" json:"product_id"`
+	ProductName       string             `gorm:"type:varchar(255)" json:"product_name"`
+	Days              int                `gorm:"not null;default:0" json:"days"`
+	Status            ProductTrialStatus `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	OrderID           *uint              `gorm:"index" json:"order_id,omitempty"` // 发放试用卡密的零金额订单，发放完成前为空
+	OrderNo           string             `gorm:"type:varchar(50)" json:"order_no,omitempty"`
+	ExpiresAt         time.Time          `gorm:"index" json:"expires_at"`
+	RemindAt          time.Time          `gorm:"index" json:"remind_at"` // 到期提醒并生成转正订单的时间
+	ReminderSentAt    *time.Time         `json:"reminder_sent_at,omitempty"`
+	ConversionOrderID *uint              `gorm:"index" json:"conversion_order_id,omitempty"` // 转正待付款订单
+	ConversionOrderNo string             `gorm:"type:varchar(50)" json:"conversion_order_no,omitempty"`
+	ConvertedAt       *time.Time         `json:"converted_at,omitempty"`
+	ExpiredAt         *time.Time         `json:"expired_at,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ProductTrial) TableName() string {
+	return "product_trials"
+}
+
+// LicenseUsable 试用卡密当前是否可用；conversionPaid 表示转正订单已付款（定时任务尚未处理时也视为已转正）
+func (t *ProductTrial) LicenseUsable(now time.Time, conversionPaid bool) bool {
+	switch t.Status {
+	case ProductTrialStatusConverted:
+		return true
+	case ProductTrialStatusActive:
+		return now.Before(t.ExpiresAt) || conversionPaid
+	default:
+		return false
+	}
+}
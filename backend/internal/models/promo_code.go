@@ -43,11 +43,16 @@ type PromoCode struct {
 	ProductScope string `gorm:"type:varchar(20);default:'all'" json:"product_scope"`
 
 	Status    PromoCodeStatus `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	StartsAt  *time.Time      `json:"starts_at,omitempty"` // 生效时间，为空表示立即生效
 	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
 
+	PerUserLimit int   `gorm:"not null;default:0" json:"per_user_limit"` // 每个用户可使用次数，0 表示不限制
+	CampaignID   *uint `gorm:"index" json:"campaign_id,omitempty"`       // 批量生成时所属的活动
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedBy *uint          `json:"-"` // 删除操作人，移入回收站时记录
 }
 
 func (PromoCode) TableName() string {
@@ -76,6 +81,14 @@ func (p *PromoCode) IsExpired() bool {
 	return NowFunc().After(*p.ExpiresAt)
 }
 
+// IsNotStarted 是否尚未到生效时间
+func (p *PromoCode) IsNotStarted() bool {
+	if p.StartsAt == nil {
+		return false
+	}
+	return NowFunc().Before(*p.StartsAt)
+}
+
 func (p *PromoCode) GetAvailableQuantity() int {
 	if p.TotalQuantity == 0 {
 		return -1
@@ -91,7 +104,7 @@ func (p *PromoCode) IsAvailable() bool {
 	if p.Status != PromoCodeStatusActive {
 		return false
 	}
-	if p.IsExpired() {
+	if p.IsExpired() || p.IsNotStarted() {
 		return false
 	}
 	if p.TotalQuantity > 0 && p.GetAvailableQuantity() <= 0 {
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PromoCodeCampaign 优惠码批量生成活动，活动下的优惠码共享折扣、使用次数与有效期设置
+// 金额字段同 PromoCode，以最小货币单位存储；百分比折扣以基点表示
+type PromoCodeCampaign struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"type:varchar(255);not null" json:"name"`
+	Description string `gorm:"type:text" json:"description,omitempty"`
+	Prefix      string `gorm:"type:varchar(20)" json:"prefix,omitempty"`
+	Pattern     string `gorm:"type:varchar(40);not null" json:"pattern"` // X=字母或数字，9=数字，A=字母，其他字符原样保留
+
+	DiscountType   DiscountType `gorm:"type:varchar(20);not null" json:"discount_type"`
+	DiscountValue  int64        `gorm:"type:bigint;not null;default:0" json:"-"`
+	MaxDiscount    int64        `gorm:"type:bigint;default:0" json:"-"`
+	MinOrderAmount int64        `gorm:"type:bigint;default:0" json:"-"`
+
+	UsesPerCode  int    `gorm:"not null;default:1" json:"uses_per_code"` // 单个优惠码总可用次数，0 表示不限制
+	PerUserLimit int    `gorm:"not null;default:0" json:"per_user_limit"`
+	ProductIDs   []uint `gorm:"type:text;serializer:json" json:"product_ids,omitempty"`
+	ProductScope string `gorm:"type:varchar(20);default:'all'" json:"product_scope"`
+
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CodeCount int   `gorm:"not null;default:0" json:"code_count"`
+	CreatedBy *uint `json:"created_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (PromoCodeCampaign) TableName() string {
+	return "promo_code_campaigns"
+}
+
+func (p PromoCodeCampaign) MarshalJSON() ([]byte, error) {
+	type Alias PromoCodeCampaign
+	return json.Marshal(&struct {
+		Alias
+		DiscountValueMinor  int64 `json:"discount_value_minor"`
+		MaxDiscountMinor    int64 `json:"max_discount_minor"`
+		MinOrderAmountMinor int64 `json:"min_order_amount_minor"`
+	}{
+		Alias:               Alias(p),
+		DiscountValueMinor:  p.DiscountValue,
+		MaxDiscountMinor:    p.MaxDiscount,
+		MinOrderAmountMinor: p.MinOrderAmount,
+	})
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// QuoteStatus 报价单状态
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft     QuoteStatus = "draft"     // 草稿，客户不可见
+	QuoteStatusSent      QuoteStatus = "sent"      // 已发送，等待客户接受或拒绝
+	QuoteStatusAccepted  QuoteStatus = "accepted"  // 客户已接受并转为订单
+	QuoteStatusDeclined  QuoteStatus = "declined"  // 客户已拒绝
+	QuoteStatusExpired   QuoteStatus = "expired"   // 超过有效期未处理
+	QuoteStatusCancelled QuoteStatus = "cancelled" // 管理员撤回
+)
+
+// QuoteItem 报价单行项目，单价为报价时锁定的价格（最小货币单位），接受报价时原样写入订单
+type QuoteItem struct {
+	SKU                string                 `json:"sku"`
+	Name               string                 `json:"name"`
+	Quantity           int                    `json:"quantity"`
+	UnitPrice          int64                  `json:"-"`
+	Attributes         map[string]interface{} `json:"attributes,omitempty"`
+	ProductType        string                 `json:"product_type,omitempty"`
+	VirtualInventoryID *uint                  `json:"virtual_inventory_id,omitempty"`
+}
+
+func (i QuoteItem) MarshalJSON() ([]byte, error) {
+	type Alias QuoteItem
+	return json.Marshal(&struct {
+		Alias
+		UnitPriceMinor int64 `json:"unit_price_minor"`
+		LineTotalMinor int64 `json:"line_total_minor"`
+	}{
+		Alias:          Alias(i),
+		UnitPriceMinor: i.UnitPrice,
+		LineTotalMinor: i.UnitPrice * int64(i.Quantity),
+	})
+}
+
+func (i *QuoteItem) UnmarshalJSON(data []byte) error {
+	type Alias QuoteItem
+	aux := &struct {
+		*Alias
+		UnitPriceMinor int64 `json:"unit_price_minor"`
+	}{Alias: (*Alias)(i)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	i.UnitPrice = aux.UnitPriceMinor
+	return nil
+}
+
+// Quote 报价单：管理员为客户编制的商品与价格方案，客户在有效期内接受后按报价价格生成订单
+type Quote struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	QuoteNo   string      `gorm:"type:varchar(32);uniqueIndex;not null" json:"quote_no"`
+	UserID    uint        `gorm:"index;not null" json:"user_id"`
+	User      *User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Title     string      `gorm:"type:varchar(200)" json:"title"`
+	Status    QuoteStatus `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`
+	Currency  string      `gorm:"type:varchar(10);not null" json:"currency"`
+	Items     []QuoteItem `gorm:"type:text;serializer:json;not null" json:"items"`
+	Note      string      `gorm:"type:text" json:"note,omitempty"` // 展示给客户的说明与条款
+	AdminNote string      `gorm:"type:text" json:"admin_note,omitempty"`
+
+	Subtotal       int64 `gorm:"type:bigint;not null;default:0" json:"-"`
+	DiscountAmount int64 `gorm:"type:bigint;not null;default:0" json:"-"`
+	TotalAmount    int64 `gorm:"type:bigint;not null;default:0" json:"-"`
+
+	ValidUntil    time.Time  `gorm:"index" json:"valid_until"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	AcceptedAt    *time.Time `json:"accepted_at,omitempty"`
+	DeclinedAt    *time.Time `json:"declined_at,omitempty"`
+	DeclineReason string     `gorm:"type:varchar(500)" json:"decline_reason,omitempty"`
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
+
+	OrderID *uint  `gorm:"index" json:"order_id,omitempty"`
+	OrderNo string `gorm:"type:varchar(50)" json:"order_no,omitempty"`
+
+	CreatedBy uint      `gorm:"not null" json:"created_by"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Quote) TableName() string {
+	return "quotes"
+}
+
+func (q Quote) MarshalJSON() ([]byte, error) {
+	type Alias Quote
+	return json.Marshal(&struct {
+		Alias
+		SubtotalMinor       int64 `json:"subtotal_minor"`
+		DiscountAmountMinor int64 `json:"discount_amount_minor"`
+		TotalAmountMinor    int64 `json:"total_amount_minor"`
+	}{
+		Alias:               Alias(q),
+		SubtotalMinor:       q.Subtotal,
+		DiscountAmountMinor: q.DiscountAmount,
+		TotalAmountMinor:    q.TotalAmount,
+	})
+}
+
+// IsExpiredAt 已发送的报价单是否在 now 时已超过有效期
+func (q *Quote) IsExpiredAt(now time.Time) bool {
+	return q.Status == QuoteStatusSent && now.After(q.ValidUntil)
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// RefundRequestStatus 退款申请状态
+type RefundRequestStatus string
+
+const (
+	RefundRequestStatusPending  RefundRequestStatus = "pending"  // 待审核
+	RefundRequestStatusApproved RefundRequestStatus = "approved" // 已批准并已发起退款
+	RefundRequestStatusRejected RefundRequestStatus = "rejected" // 已拒绝
+)
+
+// 期望的处理方式
+const (
+	RefundResolutionFullRefund    = "full_refund"
+	RefundResolutionPartialRefund = "partial_refund"
+	RefundResolutionReplacement   = "replacement"
+	RefundResolutionOther         = "other"
+)
+
+// RefundRequest 用户发起的退款申请，管理员在审核队列中批准或拒绝
+type RefundRequest struct {
+	ID         uint                `gorm:"primaryKey" json:"id"`
+	OrderID    uint                `gorm:"index;not null" json:"order_id"`
+	OrderNo    string              `gorm:"type:varchar(50);index;not null" json:"order_no"`
+	UserID     uint                `gorm:"index;not null" json:"user_id"`
+	User       *User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reason     string              `gorm:"type:text;not null" json:"reason"`
+	Resolution string              `gorm:"type:varchar(30);not null" json:"resolution"`
+	Evidence   []string            `gorm:"type:text;serializer:json" json:"evidence,omitempty"` // 通过工单附件接口预先上传的凭证URL
+	Status     RefundRequestStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+
+	// 自动创建的关联工单，审核结果同步为工单消息
+	TicketID *uint  `gorm:"index" json:"ticket_id,omitempty"`
+	TicketNo string `gorm:"type:varchar(50)" json:"ticket_no,omitempty"`
+
+	// 审核信息
+	ReviewedBy    *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote    string     `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+	RefundStatus  string     `gorm:"type:varchar(20)" json:"refund_status,omitempty"` // 批准后订单的退款状态：refunded / refund_pending
+	TransactionID string     `gorm:"type:varchar(255)" json:"transaction_id,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (RefundRequest) TableName() string {
+	return "refund_requests"
+}
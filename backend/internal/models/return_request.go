@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// ReturnRequestStatus 退货状态：requested → approved → in_transit → received，待审核时可被拒绝
+type ReturnRequestStatus string
+
+const (
+	ReturnRequestStatusRequested ReturnRequestStatus = "requested"  // 待审核
+	ReturnRequestStatusApproved  ReturnRequestStatus = "approved"   // 已批准，等待用户寄回
+	ReturnRequestStatusRejected  ReturnRequestStatus = "rejected"   // 已拒绝
+	ReturnRequestStatusInTransit ReturnRequestStatus = "in_transit" // 用户已寄回并登记退货物流单号
+	ReturnRequestStatusReceived  ReturnRequestStatus = "received"   // 商家已收货
+)
+
+// 退货原因
+const (
+	ReturnReasonDefective      = "defective"
+	ReturnReasonDamaged        = "damaged"
+	ReturnReasonWrongItem      = "wrong_item"
+	ReturnReasonNotAsDescribed = "not_as_described"
+	ReturnReasonNoLongerNeeded = "no_longer_needed"
+	ReturnReasonOther          = "other"
+)
+
+// ReturnItem 退货的订单项
+type ReturnItem struct {
+	ItemIndex int    `json:"item_index"` // 对应 Order.Items 的下标
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	Restocked bool   `json:"restocked"` // 收货时是否已退回绑定的库存
+}
+
+// ReturnRequest 退货申请（RMA），用户对已发货或已完成的订单发起，管理员审核后用户寄回商品，
+// 收货时可选择将商品退回订单项绑定的库存；退款仍通过退款流程单独处理
+type ReturnRequest struct {
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	ReturnNo    string              `gorm:"type:varchar(32);uniqueIndex;not null" json:"return_no"`
+	OrderID     uint                `gorm:"index;not null" json:"order_id"`
+	OrderNo     string              `gorm:"type:varchar(50);index;not null" json:"order_no"`
+	UserID      uint                `gorm:"index;not null" json:"user_id"`
+	User        *User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Items       []ReturnItem        `gorm:"type:text;serializer:json;not null" json:"items"`
+	Reason      string              `gorm:"type:varchar(30);not null" json:"reason"`
+	Description string              `gorm:"type:text" json:"description,omitempty"`
+	Photos      []string            `gorm:"type:text;serializer:json" json:"photos,omitempty"` // 通过工单附件接口预先上传的图片URL
+	Status      ReturnRequestStatus `gorm:"type:varchar(20);not null;default:'requested';index" json:"status"`
+
+	// 审核信息
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+
+	// 退货物流
+	ReturnCarrier    string     `gorm:"type:varchar(50)" json:"return_carrier,omitempty"`
+	ReturnTrackingNo string     `gorm:"type:varchar(100);index" json:"return_tracking_no,omitempty"`
+	ShippedBackAt    *time.Time `json:"shipped_back_at,omitempty"`
+
+	// 收货信息
+	ReceivedBy  *uint      `json:"received_by,omitempty"`
+	ReceivedAt  *time.Time `json:"received_at,omitempty"`
+	ReceiveNote string     `gorm:"type:varchar(1000)" json:"receive_note,omitempty"`
+	Restocked   bool       `gorm:"default:false" json:"restocked"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ReturnRequest) TableName() string {
+	return "return_requests"
+}
+
+// Active 未被拒绝的退货申请占用订单项的可退货数量
+func (r *ReturnRequest) Active() bool {
+	return r.Status != ReturnRequestStatusRejected
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RevenueRecognitionType 收入确认方式：为空表示付款当月一次性确认
+type RevenueRecognitionType string
+
+const (
+	RevenueRecognitionSubscription  RevenueRecognitionType = "subscription"   // 订阅/授权：在服务期内按月平均确认
+	RevenueRecognitionPrepaidCredit RevenueRecognitionType = "prepaid_credit" // 预付额度：未跟踪消耗，在有效期内按月平均确认
+)
+
+// RevenueScheduleStatus 收入确认计划状态
+type RevenueScheduleStatus string
+
+const (
+	RevenueScheduleStatusActive    RevenueScheduleStatus = "active"    // 按计划确认中（含已全部确认完毕）
+	RevenueScheduleStatusCancelled RevenueScheduleStatus = "cancelled" // 订单退款，剩余未确认金额已释放
+)
+
+// RevenueSchedule 收入确认计划：订单付款时为按期确认的商品行创建，金额按月份拆分为计划明细
+type RevenueSchedule struct {
+	ID          uint                   `gorm:"primaryKey" json:"id"`
+	OrderID     uint                   `gorm:"uniqueIndex:idx_revenue_schedule_order_item;not null" json:"order_id"`
+	ItemIndex   int                    `gorm:"uniqueIndex:idx_revenue_schedule_order_item;not null" json:"item_index"`
+	OrderNo     string                 `gorm:"type:varchar(50);not null" json:"order_no"`
+	SKU         string                 `gorm:"type:varchar(100)" json:"sku,omitempty"`
+	Type        RevenueRecognitionType `gorm:"type:varchar(20);not null;index" json:"type"`
+	Currency    string                 `gorm:"type:varchar(10);not null" json:"currency"`
+	Amount      int64                  `gorm:"type:bigint;default:0" json:"-"` // 商品行实收金额（已按比例分摊优惠码折扣）
+	TermMonths  int                    `gorm:"not null" json:"term_months"`
+	StartPeriod string                 `gorm:"type:varchar(7);not null;index" json:"start_period"` // 付款月份 YYYY-MM（UTC）
+	Status      RevenueScheduleStatus  `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+
+	// 退款取消：取消当月及之后的计划明细不再确认，合计金额记为释放金额
+	CancelledPeriod string     `gorm:"type:varchar(7);index" json:"cancelled_period,omitempty"`
+	ReleasedAmount  int64      `gorm:"type:bigint;default:0" json:"-"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (RevenueSchedule) TableName() string {
+	return "revenue_schedules"
+}
+
+func (s RevenueSchedule) MarshalJSON() ([]byte, error) {
+	type Alias RevenueSchedule
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor         int64 `json:"amount_minor"`
+		ReleasedAmountMinor int64 `json:"released_amount_minor"`
+	}{
+		Alias:               Alias(s),
+		AmountMinor:         s.Amount,
+		ReleasedAmountMinor: s.ReleasedAmount,
+	})
+}
+
+// RevenueScheduleEntry 收入确认计划明细：某个月份应确认的金额
+type RevenueScheduleEntry struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	ScheduleID uint   `gorm:"uniqueIndex:idx_revenue_schedule_entry_period;not null" json:"schedule_id"`
+	Period     string `gorm:"type:varchar(7);uniqueIndex:idx_revenue_schedule_entry_period;index;not null" json:"period"` // YYYY-MM
+	Amount     int64  `gorm:"type:bigint;default:0" json:"-"`
+}
+
+// TableName 指定表名
+func (RevenueScheduleEntry) TableName() string {
+	return "revenue_schedule_entries"
+}
+
+func (e RevenueScheduleEntry) MarshalJSON() ([]byte, error) {
+	type Alias RevenueScheduleEntry
+	return json.Marshal(&struct {
+		Alias
+		AmountMinor int64 `json:"amount_minor"`
+	}{
+		Alias:       Alias(e),
+		AmountMinor: e.Amount,
+	})
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ScheduledJobStatus 周期任务最近一次执行状态
+type ScheduledJobStatus string
+
+const (
+	ScheduledJobStatusRunning ScheduledJobStatus = "running"
+	ScheduledJobStatusSuccess ScheduledJobStatus = "success"
+	ScheduledJobStatusFailed  ScheduledJobStatus = "failed"
+)
+
+// ScheduledJob 周期任务的执行记录，多个后端实例共享，用于避免同一周期内重复执行和展示运行状态
+type ScheduledJob struct {
+	Name            string             `gorm:"type:varchar(100);primaryKey" json:"name"`
+	IntervalSeconds int64              `gorm:"not null;default:0" json:"interval_seconds"`
+	LastStatus      ScheduledJobStatus `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	LastStartedAt   *time.Time         `json:"last_started_at,omitempty"`
+	LastFinishedAt  *time.Time         `json:"last_finished_at,omitempty"`
+	LastDurationMs  int64              `gorm:"not null;default:0" json:"last_duration_ms"`
+	LastError       string             `gorm:"type:text" json:"last_error,omitempty"`
+	LastInstance    string             `gorm:"type:varchar(150)" json:"last_instance,omitempty"`
+	RunCount        int64              `gorm:"not null;default:0" json:"run_count"`
+	FailCount       int64              `gorm:"not null;default:0" json:"fail_count"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ScheduledJob) TableName() string {
+	return "scheduled_jobs"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// ScriptExecution 一次真实订单发货脚本执行的审计记录（试运行与测试脚本不记录）
+// Console 与 HTTPCalls 为执行期间的日志与上游请求，URL 不含查询参数，避免把凭据落库
+type ScriptExecution struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	VirtualInventoryID uint       `gorm:"not null;index:idx_script_execution_inventory" json:"virtual_inventory_id"`
+	OrderID            uint       `gorm:"not null;index" json:"order_id"`
+	OrderNo            string     `gorm:"type:varchar(50);index" json:"order_no"`
+	Quantity           int        `gorm:"not null;default:0" json:"quantity"`
+	ScriptHash         string     `gorm:"type:varchar(16)" json:"script_hash"` // 执行时脚本内容的摘要，用于判断脚本是否已修改
+	Success            bool       `gorm:"not null;default:false;index:idx_script_execution_inventory" json:"success"`
+	ItemCount          int        `gorm:"not null;default:0" json:"item_count"` // 只记录数量，不保存发货内容
+	Message            string     `gorm:"type:varchar(500)" json:"message,omitempty"`
+	Error              string     `gorm:"type:text" json:"error,omitempty"`
+	DurationMs         int64      `gorm:"not null;default:0" json:"duration_ms"`
+	Console            JSON       `gorm:"type:text" json:"console,omitempty"`
+	HTTPCalls          JSON       `gorm:"type:text" json:"http_calls,omitempty"`
+	EventsTruncated    bool       `gorm:"default:false" json:"events_truncated,omitempty"`
+	ReplayOf           *uint      `gorm:"index" json:"replay_of,omitempty"` // 由重放产生时指向被重放的失败执行
+	ReplayedAt         *time.Time `json:"replayed_at,omitempty"`
+	ReplayedBy         *uint      `json:"replayed_by,omitempty"`
+	CreatedAt          time.Time  `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (ScriptExecution) TableName() string {
+	return "script_executions"
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SiteBannerType 横幅类型
+type SiteBannerType string
+
+const (
+	SiteBannerTypeMaintenance SiteBannerType = "maintenance" // 计划维护
+	SiteBannerTypePolicy      SiteBannerType = "policy"      // 政策/条款变更
+	SiteBannerTypeInfo        SiteBannerType = "info"        // 一般通知
+)
+
+// SiteBannerAudience 横幅展示对象
+type SiteBannerAudience string
+
+const (
+	SiteBannerAudienceAll    SiteBannerAudience = "all"
+	SiteBannerAudienceGuests SiteBannerAudience = "guests" // 未登录访客
+	SiteBannerAudienceUsers  SiteBannerAudience = "users"  // 已登录用户（含管理员）
+	SiteBannerAudienceAdmins SiteBannerAudience = "admins" // 仅管理员
+)
+
+// SiteBannerContent 某一语言下的横幅文案
+type SiteBannerContent struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	LinkURL  string `json:"link_url,omitempty"`
+	LinkText string `json:"link_text,omitempty"`
+}
+
+// SiteBanner 站点横幅：按时间窗口自动展示的维护、政策变更等通知，无需发布前端
+// 与 Announcement 不同，横幅对访客同样可见，且不记录已读状态
+type SiteBanner struct {
+	ID       uint               `gorm:"primaryKey" json:"id"`
+	Type     SiteBannerType     `gorm:"type:varchar(20);not null;index" json:"type"`
+	Audience SiteBannerAudience `gorm:"type:varchar(20);not null;default:'all'" json:"audience"`
+	// 多语言文案，键为语言代码（en、zh），缺少当前语言时回退到 DefaultLocale
+	Translations  map[string]SiteBannerContent `gorm:"type:text;serializer:json" json:"translations"`
+	DefaultLocale string                       `gorm:"type:varchar(10);not null;default:'en'" json:"default_locale"`
+	// 展示时间窗口，EndsAt 为空表示一直展示到手动停用
+	StartsAt time.Time  `gorm:"not null;index" json:"starts_at"`
+	EndsAt   *time.Time `gorm:"index" json:"ends_at,omitempty"`
+	// 维护时间段，仅维护类横幅使用，前端按用户本地时区展示
+	MaintenanceStartsAt *time.Time     `json:"maintenance_starts_at,omitempty"`
+	MaintenanceEndsAt   *time.Time     `json:"maintenance_ends_at,omitempty"`
+	Dismissible         bool           `json:"dismissible"`
+	Priority            int            `gorm:"default:0" json:"priority"`
+	IsActive            bool           `gorm:"index" json:"is_active"`
+	CreatedBy           uint           `json:"created_by"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName 指定表名
+func (SiteBanner) TableName() string {
+	return "site_banners"
+}
+
+// IsLiveAt 横幅在指定时间是否处于展示窗口内
+func (b *SiteBanner) IsLiveAt(now time.Time) bool {
+	if !b.IsActive || now.Before(b.StartsAt) {
+		return false
+	}
+	return b.EndsAt == nil || now.Before(*b.EndsAt)
+}
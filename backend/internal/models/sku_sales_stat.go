@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SKUSalesDailyStat SKU 每日销售汇总，由后台服务按自然日（UTC）聚合
+type SKUSalesDailyStat struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	StatDate  string `gorm:"type:varchar(10);not null;uniqueIndex:idx_sku_sales_daily_stat,priority:1" json:"stat_date"` // YYYY-MM-DD
+	SKU       string `gorm:"type:varchar(100);not null;index;uniqueIndex:idx_sku_sales_daily_stat,priority:2" json:"sku"`
+	ProductID uint   `gorm:"index" json:"product_id"`
+	Name      string `gorm:"type:varchar(255)" json:"name"`
+
+	// 销售（按付款时间归属当日，含之后退款的订单）
+	OrderCount   int64 `gorm:"default:0" json:"order_count"`
+	UnitsSold    int64 `gorm:"default:0" json:"units_sold"`
+	RevenueMinor int64 `gorm:"type:bigint;default:0" json:"revenue_minor"`
+
+	// 退款（按退款完成时间归属当日）
+	UnitsRefunded        int64 `gorm:"default:0" json:"units_refunded"`
+	RefundedRevenueMinor int64 `gorm:"type:bigint;default:0" json:"refunded_revenue_minor"`
+
+	// 当日结束时的商品库存快照，用于计算库存周转与售罄率
+	EndingStock int64 `gorm:"default:0" json:"ending_stock"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SKUSalesDailyStat) TableName() string {
+	return "sku_sales_daily_stats"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SpendingControlTerms 消费控制条款
+type SpendingControlTerms struct {
+	// 每月消费限额（order.currency 最小货币单位），0 表示不限
+	MonthlyLimit      int64    `json:"monthly_limit_minor"`
+	BlockedCategories []string `json:"blocked_categories"` // 禁止购买的商品分类
+	OwnerEmail        string   `json:"owner_email"`        // 接收变更通知的账户所有人邮箱，为空时通知账户本身的邮箱
+}
+
+// SpendingControl 账户消费控制（家长控制），每个用户最多一条。
+// 收紧限制立即生效；放宽限制记入 Pending，冷静期结束后在读取或下单时生效
+type SpendingControl struct {
+	ID     uint  `gorm:"primaryKey" json:"id"`
+	UserID uint  `gorm:"uniqueIndex;not null" json:"user_id"`
+	User   *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+
+	MonthlyLimit      int64    `gorm:"type:bigint;default:0" json:"-"`
+	BlockedCategories []string `gorm:"type:text;serializer:json" json:"blocked_categories"`
+	OwnerEmail        string   `gorm:"type:varchar(255)" json:"owner_email,omitempty"`
+
+	// 冷静期中的变更
+	Pending            *SpendingControlTerms `gorm:"type:text;serializer:json" json:"pending,omitempty"`
+	PendingEffectiveAt *time.Time            `json:"pending_effective_at,omitempty"`
+
+	UpdatedBy *uint     `json:"updated_by,omitempty"` // 最后修改人（用户本人或管理员）
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (SpendingControl) TableName() string {
+	return "spending_controls"
+}
+
+// Terms 当前生效的条款
+func (c *SpendingControl) Terms() SpendingControlTerms {
+	return SpendingControlTerms{
+		MonthlyLimit:      c.MonthlyLimit,
+		BlockedCategories: c.BlockedCategories,
+		OwnerEmail:        c.OwnerEmail,
+	}
+}
+
+// ApplyTerms 将条款写入当前生效的字段
+func (c *SpendingControl) ApplyTerms(terms SpendingControlTerms) {
+	c.MonthlyLimit = terms.MonthlyLimit
+	c.BlockedCategories = terms.BlockedCategories
+	c.OwnerEmail = terms.OwnerEmail
+}
+
+func (c SpendingControl) MarshalJSON() ([]byte, error) {
+	type Alias SpendingControl
+	return json.Marshal(&struct {
+		Alias
+		MonthlyLimitMinor int64 `json:"monthly_limit_minor"`
+	}{
+		Alias:             Alias(c),
+		MonthlyLimitMinor: c.MonthlyLimit,
+	})
+}
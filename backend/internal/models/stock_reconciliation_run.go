@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// 库存对账触发方式
+const (
+	StockReconciliationTriggerScheduled = "scheduled"
+	StockReconciliationTriggerManual    = "manual"
+)
+
+// 库存对账执行状态
+const (
+	StockReconciliationStatusCompleted = "completed"
+	StockReconciliationStatusFailed    = "failed"
+)
+
+// 库存对账差异类型
+const (
+	StockDiscrepancyReservedMismatch   = "reserved_mismatch"   // 实物库存预留数与未完成订单不一致
+	StockDiscrepancyOversold           = "oversold"            // 预留数超过现有库存或库存为负
+	StockDiscrepancyVirtualLeak        = "virtual_leak"        // 虚拟库存项仍为预留，但订单已取消/退款/不存在
+	StockDiscrepancyVirtualUndelivered = "virtual_undelivered" // 虚拟库存项仍为预留，但订单已发货/完成
+)
+
+// StockDiscrepancy 单条对账差异
+type StockDiscrepancy struct {
+	Kind          string   `json:"kind"`
+	Source        string   `json:"source"` // physical, virtual
+	InventoryID   uint     `json:"inventory_id"`
+	InventoryName string   `json:"inventory_name,omitempty"`
+	Expected      int      `json:"expected"`
+	Actual        int      `json:"actual"`
+	OrderNos      []string `json:"order_nos,omitempty"`
+	Healed        bool     `json:"healed"`
+}
+
+// StockReconciliationRun 库存对账执行记录
+type StockReconciliationRun struct {
+	ID                   uint               `gorm:"primaryKey" json:"id"`
+	TriggerType          string             `gorm:"type:varchar(20);not null;index" json:"trigger_type"`
+	TriggeredBy          *uint              `json:"triggered_by,omitempty"`
+	AutoHeal             bool               `json:"auto_heal"`
+	Status               string             `gorm:"type:varchar(20);not null" json:"status"`
+	InventoriesChecked   int                `json:"inventories_checked"`
+	VirtualStocksChecked int                `json:"virtual_stocks_checked"`
+	DiscrepancyCount     int                `json:"discrepancy_count"`
+	OversoldCount        int                `json:"oversold_count"`
+	HealedCount          int                `json:"healed_count"`
+	Discrepancies        []StockDiscrepancy `gorm:"type:text;serializer:json" json:"discrepancies,omitempty"`
+	Error                string             `gorm:"type:text" json:"error,omitempty"`
+	StartedAt            time.Time          `gorm:"index" json:"started_at"`
+	FinishedAt           *time.Time         `json:"finished_at,omitempty"`
+	CreatedAt            time.Time          `json:"created_at"`
+}
+
+// TableName 指定表名
+func (StockReconciliationRun) TableName() string {
+	return "stock_reconciliation_runs"
+}
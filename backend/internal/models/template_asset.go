@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 模板资源类型
+const (
+	TemplateAssetKindImage = "image"
+	TemplateAssetKindFont  = "font"
+	TemplateAssetKindCSS   = "css"
+)
+
+// TemplateAsset 账单、报价单与邮件模板使用的 Logo、字体、样式表等资源。
+// 以 Name 作为稳定标识，重新上传同名资源只替换文件，引用处无需修改；Checksum 用于生成缓存失效参数
+type TemplateAsset struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"name"`
+	Kind        string    `gorm:"type:varchar(20);not null" json:"kind"`
+	FileName    string    `gorm:"type:varchar(255)" json:"file_name"`  // 上传时的原始文件名
+	StoredPath  string    `gorm:"type:varchar(255);not null" json:"-"` // 相对上传目录 templates 子目录的路径
+	ContentType string    `gorm:"type:varchar(100)" json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `gorm:"type:varchar(64)" json:"checksum"` // 文件内容 SHA-256
+	UploadedBy  *uint     `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (TemplateAsset) TableName() string {
+	return "template_assets"
+}
@@ -37,6 +37,7 @@ type Ticket struct {
 	Subject     string         `gorm:"type:varchar(255);not null" json:"subject"`
 	Content     string         `gorm:"type:text;not null" json:"content"`
 	Category    string         `gorm:"type:varchar(50)" json:"category,omitempty"`
+	IntakeData  JSONMap        `gorm:"type:text" json:"intake_data,omitempty"` // 分类主题模板的结构化字段
 	Priority    TicketPriority `gorm:"type:varchar(20);default:'normal'" json:"priority"`
 	Status      TicketStatus   `gorm:"type:varchar(20);default:'open';index" json:"status"`
 
@@ -53,11 +54,19 @@ type Ticket struct {
 	UnreadCountUser  int `gorm:"default:0" json:"unread_count_user"`
 	UnreadCountAdmin int `gorm:"default:0" json:"unread_count_admin"`
 
+	// 服务指标：首次响应与满意度评价
+	FirstResponseAt     *time.Time `gorm:"index" json:"first_response_at,omitempty"`
+	FirstResponseBy     *uint      `gorm:"index" json:"first_response_by,omitempty"`
+	SatisfactionRating  int        `gorm:"default:0" json:"satisfaction_rating"` // 1-5，0 表示未评价
+	SatisfactionComment string     `gorm:"type:varchar(500)" json:"satisfaction_comment,omitempty"`
+	RatedAt             *time.Time `gorm:"index" json:"rated_at,omitempty"`
+
 	// 时间戳
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	ClosedAt  *time.Time     `json:"closed_at,omitempty"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedBy *uint          `json:"-"` // 删除操作人，移入回收站时记录
 }
 
 func (Ticket) TableName() string {
@@ -86,6 +95,9 @@ type TicketMessage struct {
 	IsReadByUser  bool `gorm:"default:false" json:"is_read_by_user"`
 	IsReadByAdmin bool `gorm:"default:false" json:"is_read_by_admin"`
 
+	// 消息附件
+	Attachments []TicketAttachment `gorm:"foreignKey:MessageID" json:"attachments,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -93,6 +105,36 @@ func (TicketMessage) TableName() string {
 	return "ticket_messages"
 }
 
+// TicketAttachment 工单消息附件
+// 文件保存在对象存储中（本地磁盘或 S3 兼容存储），不公开访问，通过有时效的签名链接下载
+type TicketAttachment struct {
+	ID        uint  `gorm:"primaryKey" json:"id"`
+	TicketID  uint  `gorm:"index;not null" json:"ticket_id"`
+	MessageID *uint `gorm:"index" json:"message_id,omitempty"` // 上传后、随消息发送前为空
+
+	// 上传者
+	UploaderType string `gorm:"type:varchar(20);not null" json:"uploader_type"` // user/admin
+	UploaderID   uint   `gorm:"index;not null" json:"uploader_id"`
+
+	// 存储位置：按上传时的存储后端读取，切换存储配置后历史附件仍可下载
+	Backend    string `gorm:"type:varchar(20);not null" json:"-"` // local/s3
+	StorageKey string `gorm:"type:varchar(255);not null" json:"-"`
+
+	Filename    string `gorm:"type:varchar(255);not null" json:"filename"`
+	ContentType string `gorm:"type:varchar(100)" json:"content_type"`
+	Size        int64  `gorm:"not null" json:"size"`
+
+	// 下载链接，查询时生成，不入库
+	DownloadURL  string     `gorm:"-" json:"download_url,omitempty"`
+	URLExpiresAt *time.Time `gorm:"-" json:"url_expires_at,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+func (TicketAttachment) TableName() string {
+	return "ticket_attachments"
+}
+
 // TicketOrderAccess 工单订单授权
 // 用户可以通过工单授权管理员查看/编辑特定订单
 type TicketOrderAccess struct {
@@ -109,7 +151,7 @@ type TicketOrderAccess struct {
 	// 授权权限
 	CanView         bool `gorm:"default:true" json:"can_view"`
 	CanEdit         bool `gorm:"default:false" json:"can_edit"`
-	CanViewPrivacy  bool `gorm:"default:false" json:"can_view_privacy"` // 是否可查看隐私保护订单的详细信息
+	CanViewPrivacy  bool `gorm:"default:false" json:"can_view_privacy"` // 是否可查看收货人姓名、联系方式和详细地址
 
 	// 有效期（可选）
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// TicketAgentDailyStat 客服每日绩效汇总，由后台服务按自然日聚合
+type TicketAgentDailyStat struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	StatDate string `gorm:"type:varchar(10);not null;uniqueIndex:idx_ticket_agent_daily_stat,priority:1" json:"stat_date"` // YYYY-MM-DD
+	AgentID  uint   `gorm:"not null;index;uniqueIndex:idx_ticket_agent_daily_stat,priority:2" json:"agent_id"`
+
+	// 当日回复过的工单数（去重）
+	TicketsHandled int64 `gorm:"default:0" json:"tickets_handled"`
+
+	// 首次响应（按首次响应时间归属当日）
+	FirstResponseCount   int64 `gorm:"default:0" json:"first_response_count"`
+	FirstResponseSeconds int64 `gorm:"default:0" json:"first_response_seconds"`
+
+	// 解决（按解决/关闭时间归属当日，处理人为当时的分配客服）
+	ResolvedCount     int64 `gorm:"default:0" json:"resolved_count"`
+	ResolutionSeconds int64 `gorm:"default:0" json:"resolution_seconds"`
+
+	// 满意度（按评价时间归属当日）
+	RatingCount int64 `gorm:"default:0" json:"rating_count"`
+	RatingTotal int64 `gorm:"default:0" json:"rating_total"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (TicketAgentDailyStat) TableName() string {
+	return "ticket_agent_daily_stats"
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// UsageMeter 已发货卡密的用量计量（API 调用次数、席位等），每个库存项一条
+// 额度、单位与超额单价在首次上报时从虚拟库存快照，之后修改库存配置不影响已发货的卡密
+type UsageMeter struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	StockID            uint       `gorm:"not null;uniqueIndex" json:"stock_id"`
+	VirtualInventoryID uint       `gorm:"not null;index" json:"virtual_inventory_id"`
+	OrderID            uint       `gorm:"not null;index" json:"order_id"`
+	OrderNo            string     `gorm:"type:varchar(50);index" json:"order_no"`
+	UserID             *uint      `gorm:"index" json:"user_id,omitempty"`
+	LicenseHint        string     `gorm:"type:varchar(20)" json:"license_hint"`
+	Unit               string     `gorm:"type:varchar(32)" json:"unit"`
+	Quota              int64      `gorm:"not null;default:0" json:"quota"`
+	Used               int64      `gorm:"not null;default:0" json:"used"`
+	OverageUnitPrice   int64      `gorm:"type:bigint;not null;default:0" json:"overage_unit_price_minor"` // 超出额度后每单位价格（最小货币单位），0 表示不允许超额
+	BilledOverage      int64      `gorm:"not null;default:0" json:"billed_overage"`                       // 已生成超额订单的用量（含待付款订单）
+	OverageOrderID     *uint      `gorm:"index" json:"overage_order_id,omitempty"`                        // 最近一张尚未结清的超额订单
+	OverageOrderNo     string     `gorm:"type:varchar(50)" json:"overage_order_no,omitempty"`
+	OverageOrderUnits  int64      `gorm:"not null;default:0" json:"overage_order_units,omitempty"` // 该超额订单计费的用量，订单取消后退回未计费
+	AlertedPercent     int        `gorm:"not null;default:0" json:"alerted_percent"`               // 已通知买家的最高用量阈值（百分比）
+	LastReportedAt     *time.Time `json:"last_reported_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (UsageMeter) TableName() string {
+	return "usage_meters"
+}
+
+// Overage 超出额度的用量
+func (m *UsageMeter) Overage() int64 {
+	if m.Used <= m.Quota {
+		return 0
+	}
+	return m.Used - m.Quota
+}
+
+// Remaining 剩余额度
+func (m *UsageMeter) Remaining() int64 {
+	if m.Used >= m.Quota {
+		return 0
+	}
+	return m.Quota - m.Used
+}
+
+// UsageRecord 商户软件上报的一次用量变化，Quantity 为增量（释放席位时为负数）
+type UsageRecord struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	MeterID        uint      `gorm:"not null;uniqueIndex:idx_usage_record_idempotency" json:"meter_id"`
+	IdempotencyKey *string   `gorm:"type:varchar(100);uniqueIndex:idx_usage_record_idempotency" json:"idempotency_key,omitempty"` // 同一计量下重复上报只记一次
+	Quantity       int64     `gorm:"not null" json:"quantity"`
+	UsedAfter      int64     `gorm:"not null" json:"used_after"`
+	Note           string    `gorm:"type:varchar(255)" json:"note,omitempty"`
+	APIKeyID       *uint     `json:"api_key_id,omitempty"`
+	IPAddress      string    `gorm:"type:varchar(50)" json:"ip_address,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (UsageRecord) TableName() string {
+	return "usage_records"
+}
@@ -23,6 +23,21 @@ type User struct {
 	Locale        string `gorm:"type:varchar(10)" json:"locale,omitempty"`
 	Country       string `gorm:"type:varchar(100)" json:"country,omitempty"`
 
+	// 邮箱验证：管理员可豁免单个用户的验证限制（不改变 EmailVerified）；提醒邮件发送进度
+	EmailVerificationExempt    bool       `gorm:"default:false" json:"email_verification_exempt"`
+	VerificationRemindersSent  int        `gorm:"default:0" json:"-"`
+	LastVerificationReminderAt *time.Time `json:"-"`
+
+	// 注册或绑定邮箱时邮箱校验命中的原因（disposable / no_mx），仅在 flag 模式下记录，供后台复核
+	EmailRiskFlag string `gorm:"type:varchar(20);index" json:"email_risk_flag,omitempty"`
+
+	// 两步验证：TwoFactorEnabled 为用户已绑定 TOTP；TwoFactorRequired 为管理员强制开启
+	TwoFactorEnabled  bool `gorm:"default:false" json:"two_factor_enabled"`
+	TwoFactorRequired bool `gorm:"default:false" json:"two_factor_required"`
+
+	// 通行密钥（WebAuthn）凭据，仅在需要时 Preload
+	Passkeys []UserPasskey `gorm:"foreignKey:UserID" json:"-"`
+
 	// 用户消费统计（金额单位：minor，例：分）
 	TotalSpentMinor int64 `gorm:"type:bigint;default:0" json:"total_spent_minor"`
 	TotalOrderCount int64 `gorm:"type:bigint;default:0" json:"total_order_count"`
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// UserPasskey 用户注册的通行密钥（WebAuthn 凭据）
+// 只保存凭据 ID 与 COSE 编码的公钥，私钥始终留在用户设备上
+type UserPasskey struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"index;not null" json:"user_id"`
+	Name         string     `gorm:"type:varchar(100)" json:"name"`
+	CredentialID string     `gorm:"type:varchar(512);uniqueIndex;not null" json:"-"` // base64url 编码，最长 512 字符
+	PublicKey    []byte     `gorm:"not null" json:"-"`
+	Algorithm    int64      `gorm:"not null" json:"algorithm"`
+	SignCount    uint32     `gorm:"default:0" json:"-"`
+	AAGUID       string     `gorm:"type:varchar(36)" json:"aaguid"`
+	Transports   []string   `gorm:"type:text;serializer:json" json:"transports"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TableName specifies table name
+func (UserPasskey) TableName() string {
+	return "user_passkeys"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// UserTwoFactor 用户的 TOTP 两步验证配置
+// 密钥经 fieldcrypt 加密保存，备用码只保存 SHA-256 哈希，明文仅在生成时返回一次
+type UserTwoFactor struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	UserID         uint       `gorm:"uniqueIndex;not null" json:"user_id"`
+	Secret         string     `gorm:"type:text;not null" json:"-"`
+	Enabled        bool       `gorm:"default:false" json:"enabled"`
+	EnabledAt      *time.Time `json:"enabled_at,omitempty"`
+	BackupCodes    []string   `gorm:"type:text;serializer:json" json:"-"`
+	LastUsedStep   int64      `gorm:"default:0" json:"-"` // 最近一次通过的时间步，防止验证码重放
+	FailedAttempts int        `gorm:"default:0" json:"-"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies table name
+func (UserTwoFactor) TableName() string {
+	return "user_two_factors"
+}
@@ -0,0 +1,113 @@
+package models
+
+import "time"
+
+// VelocityMetric 频率规则统计的指标
+type VelocityMetric string
+
+const (
+	VelocityMetricOrdersPerIP        VelocityMetric = "orders_per_ip"        // 同一下单IP在时间窗口内的订单数
+	VelocityMetricOrdersPerUser      VelocityMetric = "orders_per_user"      // 同一用户在时间窗口内的订单数
+	VelocityMetricAccountsPerIP      VelocityMetric = "accounts_per_ip"      // 同一下单IP在时间窗口内下单的不同用户数
+	VelocityMetricAccountsPerAddress VelocityMetric = "accounts_per_address" // 同一收货地址在时间窗口内关联的不同用户数
+)
+
+// VelocityMetrics 所有频率规则指标
+var VelocityMetrics = []VelocityMetric{
+	VelocityMetricOrdersPerIP,
+	VelocityMetricOrdersPerUser,
+	VelocityMetricAccountsPerIP,
+	VelocityMetricAccountsPerAddress,
+}
+
+// VelocityAction 频率规则命中后的处理方式，按严重程度递增
+type VelocityAction string
+
+const (
+	VelocityActionFlag VelocityAction = "flag" // 进入风控审核队列，不影响订单流转
+	VelocityActionHold VelocityAction = "hold" // 进入风控审核队列，审核通过前不能发货
+)
+
+// Severity 处理方式的严重程度，用于合并多条命中规则
+func (a VelocityAction) Severity() int {
+	switch a {
+	case VelocityActionFlag:
+		return 1
+	case VelocityActionHold:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// VelocityRule 管理员配置的频率规则：指标在时间窗口内超过阈值时按 Action 处理订单
+type VelocityRule struct {
+	ID     uint           `gorm:"primaryKey" json:"id"`
+	Name   string         `gorm:"type:varchar(100);not null" json:"name"`
+	Metric VelocityMetric `gorm:"type:varchar(30);not null" json:"metric"`
+	// 统计值大于阈值时命中，统计值包含当前订单
+	Threshold int `gorm:"not null" json:"threshold"`
+	// 时间窗口（分钟），0 表示不限时间
+	WindowMinutes int            `gorm:"not null;default:0" json:"window_minutes"`
+	Action        VelocityAction `gorm:"type:varchar(20);not null" json:"action"`
+	IsActive      bool           `gorm:"index" json:"is_active"`
+
+	// 命中统计
+	HitCount  int64      `gorm:"not null;default:0" json:"hit_count"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (VelocityRule) TableName() string {
+	return "velocity_rules"
+}
+
+// OrderRiskReviewStatus 风控审核状态
+type OrderRiskReviewStatus string
+
+const (
+	OrderRiskReviewStatusPending  OrderRiskReviewStatus = "pending"  // 待人工审核
+	OrderRiskReviewStatusApproved OrderRiskReviewStatus = "approved" // 审核通过，解除暂扣
+	OrderRiskReviewStatusRejected OrderRiskReviewStatus = "rejected" // 审核不通过，订单已取消或保持暂扣
+)
+
+// OrderRiskHit 一条命中的频率规则及命中时的统计值
+type OrderRiskHit struct {
+	RuleID        uint           `json:"rule_id"`
+	RuleName      string         `json:"rule_name"`
+	Metric        VelocityMetric `json:"metric"`
+	Value         int64          `json:"value"`
+	Threshold     int            `json:"threshold"`
+	WindowMinutes int            `json:"window_minutes"`
+	Action        VelocityAction `json:"action"`
+}
+
+// OrderRiskReview 风控审核队列中的订单，每个订单一条记录，多次命中时合并
+type OrderRiskReview struct {
+	ID      uint           `gorm:"primaryKey" json:"id"`
+	OrderID uint           `gorm:"not null;uniqueIndex" json:"order_id"`
+	OrderNo string         `gorm:"type:varchar(50);not null" json:"order_no"`
+	UserID  *uint          `gorm:"index" json:"user_id,omitempty"`
+	Action  VelocityAction `gorm:"type:varchar(20);not null" json:"action"` // 命中规则中最严重的处理方式
+	Hits    []OrderRiskHit `gorm:"type:text;serializer:json" json:"hits"`
+	// 下单IP，仅管理员可见
+	IP string `gorm:"type:varchar(45)" json:"ip,omitempty"`
+
+	Status     OrderRiskReviewStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	ReviewedBy *uint                 `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewNote string                `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	CreatedAt  time.Time             `gorm:"index" json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 指定表名
+func (OrderRiskReview) TableName() string {
+	return "order_risk_reviews"
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// VendorStatus 商家状态
+type VendorStatus string
+
+const (
+	VendorStatusActive   VendorStatus = "active"   // 正常
+	VendorStatusDisabled VendorStatus = "disabled" // 停用：已关联商品照常销售，新订单仍计入台账
+)
+
+// Vendor 平台入驻商家，商品可归属商家，销售额扣除平台佣金后定期结算给商家
+type Vendor struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	Name           string       `gorm:"type:varchar(100);not null" json:"name"`
+	ContactEmail   string       `gorm:"type:varchar(255)" json:"contact_email,omitempty"`
+	CommissionRate int64        `gorm:"not null;default:0" json:"commission_rate"` // 平台佣金比例（基点，10000 = 100%）
+	PayoutAccount  string       `gorm:"type:varchar(500)" json:"payout_account,omitempty"`
+	Status         VendorStatus `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	Remark         string       `gorm:"type:text" json:"remark,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Vendor) TableName() string {
+	return "vendors"
+}
+
+// VendorLedgerEntryType 商家台账条目类型
+type VendorLedgerEntryType string
+
+const (
+	VendorLedgerEntrySale       VendorLedgerEntryType = "sale"       // 订单付款时按商品行记账
+	VendorLedgerEntryRefund     VendorLedgerEntryType = "refund"     // 订单退款冲回对应的销售条目
+	VendorLedgerEntryAdjustment VendorLedgerEntryType = "adjustment" // 管理员手动调整
+)
+
+// VendorLedgerEntry 商家台账条目：金额均为订单币种的最小单位，退款冲回为负数
+type VendorLedgerEntry struct {
+	ID          uint                  `gorm:"primaryKey" json:"id"`
+	VendorID    uint                  `gorm:"index;not null" json:"vendor_id"`
+	Type        VendorLedgerEntryType `gorm:"type:varchar(20);not null;index" json:"type"`
+	OrderID     *uint                 `gorm:"index" json:"order_id,omitempty"`
+	OrderNo     string                `gorm:"type:varchar(50)" json:"order_no,omitempty"`
+	ItemIndex   int                   `gorm:"default:0" json:"item_index"`
+	SKU         string                `gorm:"type:varchar(100)" json:"sku,omitempty"`
+	Quantity    int                   `gorm:"default:0" json:"quantity"`
+	Currency    string                `gorm:"type:varchar(10);not null" json:"currency"`
+	GrossAmount int64                 `gorm:"type:bigint;default:0" json:"-"` // 商品行实收金额（已按比例分摊优惠码折扣）
+	Commission  int64                 `gorm:"type:bigint;default:0" json:"-"`
+	NetAmount   int64                 `gorm:"type:bigint;default:0" json:"-"` // 应付商家金额 = GrossAmount - Commission
+	Note        string                `gorm:"type:varchar(500)" json:"note,omitempty"`
+	CreatedBy   *uint                 `json:"created_by,omitempty"`
+	StatementID *uint                 `gorm:"index" json:"statement_id,omitempty"` // 为空表示尚未结算
+	CreatedAt   time.Time             `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (VendorLedgerEntry) TableName() string {
+	return "vendor_ledger_entries"
+}
+
+func (e VendorLedgerEntry) MarshalJSON() ([]byte, error) {
+	type Alias VendorLedgerEntry
+	return json.Marshal(&struct {
+		Alias
+		GrossAmountMinor int64 `json:"gross_amount_minor"`
+		CommissionMinor  int64 `json:"commission_minor"`
+		NetAmountMinor   int64 `json:"net_amount_minor"`
+	}{
+		Alias:            Alias(e),
+		GrossAmountMinor: e.GrossAmount,
+		CommissionMinor:  e.Commission,
+		NetAmountMinor:   e.NetAmount,
+	})
+}
+
+// VendorStatementStatus 结算单状态
+type VendorStatementStatus string
+
+const (
+	VendorStatementStatusPending VendorStatementStatus = "pending" // 待打款
+	VendorStatementStatusPaid    VendorStatementStatus = "paid"    // 已打款
+)
+
+// VendorPayoutStatement 商家结算单：汇总结算周期截止前所有未结算的台账条目
+type VendorPayoutStatement struct {
+	ID               uint                  `gorm:"primaryKey" json:"id"`
+	VendorID         uint                  `gorm:"index;not null" json:"vendor_id"`
+	Vendor           *Vendor               `gorm:"foreignKey:VendorID" json:"vendor,omitempty"`
+	Currency         string                `gorm:"type:varchar(10);not null" json:"currency"`
+	PeriodStart      time.Time             `json:"period_start"`
+	PeriodEnd        time.Time             `gorm:"index" json:"period_end"`
+	EntryCount       int                   `gorm:"default:0" json:"entry_count"`
+	SalesAmount      int64                 `gorm:"type:bigint;default:0" json:"-"`
+	CommissionAmount int64                 `gorm:"type:bigint;default:0" json:"-"`
+	RefundAmount     int64                 `gorm:"type:bigint;default:0" json:"-"` // 退款冲回的商品金额（负数）
+	AdjustmentAmount int64                 `gorm:"type:bigint;default:0" json:"-"`
+	PayoutAmount     int64                 `gorm:"type:bigint;default:0" json:"-"`
+	Status           VendorStatementStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	PaidAt           *time.Time            `json:"paid_at,omitempty"`
+	PaidBy           *uint                 `json:"paid_by,omitempty"`
+	PaymentReference string                `gorm:"type:varchar(255)" json:"payment_reference,omitempty"`
+	CreatedBy        *uint                 `json:"created_by,omitempty"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (VendorPayoutStatement) TableName() string {
+	return "vendor_payout_statements"
+}
+
+func (s VendorPayoutStatement) MarshalJSON() ([]byte, error) {
+	type Alias VendorPayoutStatement
+	return json.Marshal(&struct {
+		Alias
+		SalesAmountMinor      int64 `json:"sales_amount_minor"`
+		CommissionAmountMinor int64 `json:"commission_amount_minor"`
+		RefundAmountMinor     int64 `json:"refund_amount_minor"`
+		AdjustmentAmountMinor int64 `json:"adjustment_amount_minor"`
+		PayoutAmountMinor     int64 `json:"payout_amount_minor"`
+	}{
+		Alias:                 Alias(s),
+		SalesAmountMinor:      s.SalesAmount,
+		CommissionAmountMinor: s.CommissionAmount,
+		RefundAmountMinor:     s.RefundAmount,
+		AdjustmentAmountMinor: s.AdjustmentAmount,
+		PayoutAmountMinor:     s.PayoutAmount,
+	})
+}
@@ -17,20 +17,30 @@ const (
 // VirtualInventory 虚拟库存表（存储卡密/激活码等虚拟商品的库存池）
 // 类似于实体库存 Inventory，可以独立创建，然后绑定到商品
 type VirtualInventory struct {
-	ID                uint                 `gorm:"primaryKey" json:"id"`
-	Name              string               `gorm:"type:varchar(255);not null" json:"name"`                 // 库存名称
-	SKU               string               `gorm:"type:varchar(100);index" json:"sku"`                     // SKU（可选）
-	Type              VirtualInventoryType `gorm:"type:varchar(20);not null;default:'static'" json:"type"` // 库存类型：static(静态卡密) / script(JS脚本)
-	Script            string               `gorm:"type:text" json:"script,omitempty"`                      // JS脚本内容（type=script时使用）
-	ScriptConfig      string               `gorm:"type:text" json:"script_config,omitempty"`               // 脚本配置（JSON格式，传递给脚本的自定义参数）
-	Description       string               `gorm:"type:text" json:"description,omitempty"`                 // 描述
-	TotalLimit        int64                `gorm:"default:0" json:"total_limit"`                           // 脚本类型总发货次数限制（0=无限制）
-	AllowInlineIframe bool                 `gorm:"default:false" json:"allow_inline_iframe"`
-	IsActive          bool                 `gorm:"default:true" json:"is_active"`    // 是否启用
-	Notes             string               `gorm:"type:text" json:"notes,omitempty"` // 备注
-	CreatedAt         time.Time            `json:"created_at"`
-	UpdatedAt         time.Time            `json:"updated_at"`
-	DeletedAt         gorm.DeletedAt       `gorm:"index" json:"-"`
+	ID                  uint                 `gorm:"primaryKey" json:"id"`
+	Name                string               `gorm:"type:varchar(255);not null" json:"name"`                 // 库存名称
+	SKU                 string               `gorm:"type:varchar(100);index" json:"sku"`                     // SKU（可选）
+	Type                VirtualInventoryType `gorm:"type:varchar(20);not null;default:'static'" json:"type"` // 库存类型：static(静态卡密) / script(JS脚本)
+	Script              string               `gorm:"type:text" json:"script,omitempty"`                      // JS脚本内容（type=script时使用）
+	ScriptConfig        string               `gorm:"type:text" json:"script_config,omitempty"`               // 脚本配置（JSON格式，传递给脚本的自定义参数）
+	Description         string               `gorm:"type:text" json:"description,omitempty"`                 // 描述
+	TotalLimit          int64                `gorm:"default:0" json:"total_limit"`                           // 脚本类型总发货次数限制（0=无限制）
+	AllowInlineIframe   bool                 `gorm:"default:false" json:"allow_inline_iframe"`
+	RequireReauth       bool                 `gorm:"default:false" json:"require_reauth"`                   // 查看已发货内容前是否要求用户重新验证身份（高价值库存）
+	MaxActivations      int                  `gorm:"default:0" json:"max_activations"`                      // 每个卡密可同时激活的设备数（0=不限制）
+	UsageUnit           string               `gorm:"type:varchar(32)" json:"usage_unit"`                    // 用量单位，如 API calls、seats
+	UsageQuota          int64                `gorm:"default:0" json:"usage_quota"`                          // 每个卡密包含的用量额度（0=不计量）
+	OverageUnitPrice    int64                `gorm:"type:bigint;default:0" json:"overage_unit_price_minor"` // 超额每单位价格（最小货币单位，0=超出额度后拒绝上报）
+	SupplierPausedAt    *time.Time           `json:"supplier_paused_at,omitempty"`                          // 上游供应商错误率过高被自动暂停的时间，暂停期间视为缺货
+	SupplierPauseReason string               `gorm:"type:varchar(500)" json:"supplier_pause_reason,omitempty"`
+	LowStockThreshold   int                  `gorm:"default:0" json:"low_stock_threshold"` // 可用库存低于此值时告警（0=不告警，仅静态库存）
+	IsActive            bool                 `gorm:"default:true" json:"is_active"`        // 是否启用
+	Notes               string               `gorm:"type:text" json:"notes,omitempty"`     // 备注
+	Version             uint                 `gorm:"not null;default:1" json:"version"`    // 乐观锁版本号，编辑或脚本审批生效后递增
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt       `gorm:"index" json:"-"`
+	DeletedBy           *uint                `json:"-"` // 删除操作人，移入回收站时记录
 
 	// 关联
 	Stocks          []VirtualProductStock            `gorm:"foreignKey:VirtualInventoryID" json:"stocks,omitempty"`
@@ -42,6 +52,11 @@ func (VirtualInventory) TableName() string {
 	return "virtual_inventories"
 }
 
+// SupplierPaused 脚本库存是否因上游供应商异常被暂停发货
+func (v *VirtualInventory) SupplierPaused() bool {
+	return v.Type == VirtualInventoryTypeScript && v.SupplierPausedAt != nil
+}
+
 // ProductVirtualInventoryBinding 商品-虚拟库存绑定表（多对多关系）
 // 与实体库存 ProductInventoryBinding 采用相同的绑定制设计
 type ProductVirtualInventoryBinding struct {
@@ -77,8 +92,16 @@ type VirtualInventoryWithStats struct {
 	Description       string               `json:"description"`
 	TotalLimit        int64                `json:"total_limit"`
 	AllowInlineIframe bool                 `json:"allow_inline_iframe"`
+	RequireReauth     bool                 `json:"require_reauth"`
+	MaxActivations    int                  `json:"max_activations"`
+	UsageUnit         string               `json:"usage_unit"`
+	UsageQuota        int64                `json:"usage_quota"`
+	OverageUnitPrice  int64                `json:"overage_unit_price_minor"`
+	SupplierPausedAt  *time.Time           `json:"supplier_paused_at,omitempty"`
+	LowStockThreshold int                  `json:"low_stock_threshold"`
 	IsActive          bool                 `json:"is_active"`
 	Notes             string               `json:"notes"`
+	Version           uint                 `json:"version"`
 	Total             int64                `json:"total"`
 	Available         int64                `json:"available"`
 	Reserved          int64                `json:"reserved"`
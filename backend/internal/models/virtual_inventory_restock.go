@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// VirtualInventoryRestockStatus 自动补货最近一次执行的结果
+type VirtualInventoryRestockStatus string
+
+const (
+	VirtualInventoryRestockStatusSuccess    VirtualInventoryRestockStatus = "success"
+	VirtualInventoryRestockStatusFailed     VirtualInventoryRestockStatus = "failed"
+	VirtualInventoryRestockStatusCapReached VirtualInventoryRestockStatus = "cap_reached" // 已达到消费上限，本周期内不再采购
+)
+
+// 补货触发方式
+const (
+	VirtualInventoryRestockTriggerAuto   = "auto"
+	VirtualInventoryRestockTriggerManual = "manual"
+)
+
+// VirtualInventoryRestockPolicy 静态卡密库存的自动补货策略
+// 可用库存低于阈值时调用供应商脚本的 onRestock 采购并导入新卡密，消费金额受每日/每月上限约束
+type VirtualInventoryRestockPolicy struct {
+	ID                   uint   `gorm:"primaryKey" json:"id"`
+	VirtualInventoryID   uint   `gorm:"not null;uniqueIndex" json:"virtual_inventory_id"`
+	Enabled              bool   `gorm:"not null;default:false;index" json:"enabled"`
+	Threshold            int    `gorm:"not null;default:0" json:"threshold"`                  // 可用库存低于该值时补货
+	Quantity             int    `gorm:"not null;default:0" json:"quantity"`                   // 每次采购数量
+	Script               string `gorm:"type:text" json:"script"`                              // 供应商脚本，需定义 onRestock(request, config)
+	ScriptConfig         string `gorm:"type:text" json:"script_config,omitempty"`             // 脚本配置（JSON 对象）
+	CooldownMinutes      int    `gorm:"not null;default:0" json:"cooldown_minutes"`           // 两次自动补货的最小间隔，0表示使用默认值
+	DailySpendCapMinor   int64  `gorm:"type:bigint;default:0" json:"daily_spend_cap_minor"`   // 每日（UTC）采购金额上限，0表示不限制
+	MonthlySpendCapMinor int64  `gorm:"type:bigint;default:0" json:"monthly_spend_cap_minor"` // 每月（UTC）采购金额上限，0表示不限制
+
+	LastRunAt           *time.Time                    `json:"last_run_at,omitempty"`
+	LastStatus          VirtualInventoryRestockStatus `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	LastError           string                        `gorm:"type:varchar(500)" json:"last_error,omitempty"`
+	ConsecutiveFailures int                           `gorm:"not null;default:0" json:"consecutive_failures"`
+	LastAlertedAt       *time.Time                    `json:"last_alerted_at,omitempty"`
+	UpdatedBy           *uint                         `json:"updated_by,omitempty"`
+	CreatedAt           time.Time                     `json:"created_at"`
+	UpdatedAt           time.Time                     `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (VirtualInventoryRestockPolicy) TableName() string {
+	return "virtual_inventory_restock_policies"
+}
+
+// VirtualInventoryRestockRun 一次补货采购记录
+// 只记录数量与金额，不保存卡密内容；Console 与 HTTPCalls 与脚本执行审计格式一致
+type VirtualInventoryRestockRun struct {
+	ID                 uint                          `gorm:"primaryKey" json:"id"`
+	VirtualInventoryID uint                          `gorm:"not null;index:idx_restock_run_inventory" json:"virtual_inventory_id"`
+	TriggerType        string                        `gorm:"type:varchar(20);not null" json:"trigger_type"`
+	TriggeredBy        *uint                         `json:"triggered_by,omitempty"`
+	Status             VirtualInventoryRestockStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	AvailableBefore    int64                         `gorm:"not null;default:0" json:"available_before"`
+	Requested          int                           `gorm:"not null;default:0" json:"requested"`
+	Received           int                           `gorm:"not null;default:0" json:"received"`
+	Added              int                           `gorm:"not null;default:0" json:"added"`
+	Duplicates         int                           `gorm:"not null;default:0" json:"duplicates"`
+	Invalid            int                           `gorm:"not null;default:0" json:"invalid"`
+	CostMinor          int64                         `gorm:"type:bigint;default:0" json:"cost_minor"`
+	Currency           string                        `gorm:"type:varchar(10)" json:"currency"`
+	SupplierReference  string                        `gorm:"type:varchar(255)" json:"supplier_reference,omitempty"` // 供应商订单号等，便于对账
+	BatchNo            string                        `gorm:"type:varchar(100)" json:"batch_no,omitempty"`
+	Message            string                        `gorm:"type:varchar(500)" json:"message,omitempty"`
+	Error              string                        `gorm:"type:text" json:"error,omitempty"`
+	ScriptHash         string                        `gorm:"type:varchar(16)" json:"script_hash"`
+	DurationMs         int64                         `gorm:"not null;default:0" json:"duration_ms"`
+	Console            JSON                          `gorm:"type:text" json:"console,omitempty"`
+	HTTPCalls          JSON                          `gorm:"type:text" json:"http_calls,omitempty"`
+	EventsTruncated    bool                          `gorm:"default:false" json:"events_truncated,omitempty"`
+	CreatedAt          time.Time                     `gorm:"index:idx_restock_run_inventory" json:"created_at"`
+}
+
+// TableName 指定表名
+func (VirtualInventoryRestockRun) TableName() string {
+	return "virtual_inventory_restock_runs"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// 脚本修订审批状态
+const (
+	ScriptRevisionStatusPending    = "pending"
+	ScriptRevisionStatusApproved   = "approved"
+	ScriptRevisionStatusRejected   = "rejected"
+	ScriptRevisionStatusSuperseded = "superseded" // 审批前被同一库存的新修订取代
+)
+
+// VirtualInventoryScriptRevision 待审批的发货脚本修订
+// 发货脚本可以任意发起外部 HTTP 请求，开启四眼审批后脚本修改需由另一名管理员批准才会生效
+type VirtualInventoryScriptRevision struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	VirtualInventoryID uint       `gorm:"not null;index:idx_visr_inventory_status" json:"virtual_inventory_id"`
+	Status             string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_visr_inventory_status" json:"status"`
+	BaseScript         string     `gorm:"type:text" json:"base_script"`        // 提交时生效中的脚本，用于生成差异和检测冲突
+	BaseScriptConfig   string     `gorm:"type:text" json:"base_script_config"` // 提交时生效中的脚本配置
+	Script             string     `gorm:"type:text" json:"script"`
+	ScriptConfig       string     `gorm:"type:text" json:"script_config"`
+	SwitchToScript     bool       `gorm:"default:false" json:"switch_to_script"` // 批准后同时将库存类型切换为脚本发货
+	SubmittedBy        uint       `gorm:"not null;index" json:"submitted_by"`
+	SubmitNote         string     `gorm:"type:varchar(500)" json:"submit_note,omitempty"`
+	ReviewedBy         *uint      `json:"reviewed_by,omitempty"`
+	ReviewNote         string     `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	ReviewedAt         *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (VirtualInventoryScriptRevision) TableName() string {
+	return "virtual_inventory_script_revisions"
+}
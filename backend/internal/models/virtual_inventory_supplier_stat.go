@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// VirtualInventorySupplierStat 脚本发货上游接口调用统计（按时间桶聚合）
+// Endpoint 只保留 scheme://host，避免把查询参数中的凭据落库
+type VirtualInventorySupplierStat struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	VirtualInventoryID uint      `gorm:"not null;uniqueIndex:idx_vi_supplier_bucket" json:"virtual_inventory_id"`
+	Endpoint           string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_vi_supplier_bucket" json:"endpoint"`
+	BucketStart        time.Time `gorm:"not null;uniqueIndex:idx_vi_supplier_bucket;index" json:"bucket_start"`
+	Requests           int64     `gorm:"not null;default:0" json:"requests"`
+	Failures           int64     `gorm:"not null;default:0" json:"failures"`
+	TotalLatencyMs     int64     `gorm:"not null;default:0" json:"total_latency_ms"`
+	MaxLatencyMs       int64     `gorm:"not null;default:0" json:"max_latency_ms"`
+	LastStatus         int       `gorm:"default:0" json:"last_status"`
+	LastError          string    `gorm:"type:varchar(500)" json:"last_error,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (VirtualInventorySupplierStat) TableName() string {
+	return "virtual_inventory_supplier_stats"
+}
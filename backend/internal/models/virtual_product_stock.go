@@ -1,8 +1,11 @@
 package models
 
 import (
+	"strings"
 	"time"
 
+	"auralogic/internal/pkg/fieldcrypt"
+
 	"gorm.io/gorm"
 )
 
@@ -13,6 +16,7 @@ const (
 	VirtualStockStatusAvailable VirtualProductStockStatus = "available" // 可用
 	VirtualStockStatusSold      VirtualProductStockStatus = "sold"      // 已售出
 	VirtualStockStatusReserved  VirtualProductStockStatus = "reserved"  // 已预留
+	VirtualStockStatusHeld      VirtualProductStockStatus = "held"      // 付款中暂扣，确认付款后才绑定订单
 	VirtualStockStatusInvalid   VirtualProductStockStatus = "invalid"   // 已失效
 )
 
@@ -26,6 +30,7 @@ type VirtualProductStock struct {
 	Content      string `gorm:"type:text;not null" json:"content"`         // 卡密/激活码内容
 	Remark       string `gorm:"type:varchar(500)" json:"remark,omitempty"` // 备注信息
 	Presentation JSON   `gorm:"type:text" json:"presentation,omitempty"`
+	ContentHash  string `gorm:"type:varchar(64);index" json:"-"` // 内容哈希，设备激活时按卡密查找库存项
 
 	// 状态
 	Status VirtualProductStockStatus `gorm:"type:varchar(20);not null;default:'available';index:idx_virtual_inventory_status" json:"status"`
@@ -34,6 +39,10 @@ type VirtualProductStock struct {
 	OrderID *uint  `gorm:"index" json:"order_id,omitempty"`
 	OrderNo string `gorm:"type:varchar(50);index" json:"order_no,omitempty"`
 
+	// 暂扣凭证（仅 held 状态有效），到期未确认由后台任务释放
+	HoldToken string     `gorm:"type:varchar(64);index" json:"-"`
+	HeldUntil *time.Time `gorm:"index" json:"held_until,omitempty"`
+
 	// 发货信息
 	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
 	DeliveredBy *uint      `json:"delivered_by,omitempty"`
@@ -52,6 +61,20 @@ func (VirtualProductStock) TableName() string {
 	return "virtual_product_stocks"
 }
 
+// BeforeSave 写入内容时同步内容哈希
+// 已加密的内容由加密方在加密前按明文写入哈希，这里不再对密文重复计算
+func (v *VirtualProductStock) BeforeSave(tx *gorm.DB) error {
+	if v.Content != "" && !fieldcrypt.IsEncrypted(v.Content) {
+		v.ContentHash = LicenseKeyHash(v.Content)
+	}
+	return nil
+}
+
+// LicenseKeyHash 计算卡密内容哈希（忽略首尾空白），配置数据加密密钥时为带密钥的 HMAC
+func LicenseKeyHash(content string) string {
+	return fieldcrypt.Hash(strings.TrimSpace(content))
+}
+
 // IsAvailable 是否可用
 func (v *VirtualProductStock) IsAvailable() bool {
 	return v.Status == VirtualStockStatusAvailable
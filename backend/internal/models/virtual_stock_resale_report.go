@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// VirtualStockResaleReport 商户核查站外出现的卡密是否由本店售出的记录
+// 只保存卡密哈希，不保存卡密内容；命中时记录最近一次售出的库存项、订单与买家，便于追查退单后转售
+type VirtualStockResaleReport struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ContentHash string    `gorm:"type:varchar(64);not null;index" json:"content_hash"`
+	Matched     bool      `gorm:"not null;default:false;index" json:"matched"`
+	MatchCount  int       `gorm:"not null;default:0" json:"match_count"` // 同一卡密被发货的次数，大于 1 说明库存中存在重复卡密
+	StockID     *uint     `gorm:"index" json:"stock_id,omitempty"`
+	OrderID     *uint     `gorm:"index" json:"order_id,omitempty"`
+	OrderNo     string    `gorm:"type:varchar(50)" json:"order_no,omitempty"`
+	UserID      *uint     `gorm:"index" json:"user_id,omitempty"`
+	Refunded    bool      `gorm:"not null;default:false" json:"refunded"`    // 命中订单已退款/退款中，疑似退单后转售
+	Source      string    `gorm:"type:varchar(500)" json:"source,omitempty"` // 发现卡密的渠道，如转售平台链接
+	Note        string    `gorm:"type:varchar(1000)" json:"note,omitempty"`
+	ReportedBy  *uint     `json:"reported_by,omitempty"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (VirtualStockResaleReport) TableName() string {
+	return "virtual_stock_resale_reports"
+}
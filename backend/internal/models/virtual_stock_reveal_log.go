@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 查看虚拟商品内容前的身份验证方式
+const (
+	VirtualStockRevealAuthNone     = "none"
+	VirtualStockRevealAuthPassword = "password"
+	VirtualStockRevealAuthOTP      = "otp"
+)
+
+// VirtualStockRevealLog 用户查看虚拟商品内容（卡密/激活码）的审计记录
+// 用于买家声称卡密无效时向商户提供查看时间、来源 IP 等证据
+type VirtualStockRevealLog struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	OrderID    uint      `gorm:"not null;index:idx_vsrl_order_created" json:"order_id"`
+	OrderNo    string    `gorm:"type:varchar(50);index" json:"order_no"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	StockIDs   JSON      `gorm:"type:text" json:"stock_ids,omitempty"` // 本次返回的库存项 ID 列表
+	StockCount int       `gorm:"default:0" json:"stock_count"`
+	AuthMethod string    `gorm:"type:varchar(20);default:'none'" json:"auth_method"` // none / password / otp
+	IPAddress  string    `gorm:"type:varchar(50)" json:"ip_address,omitempty"`
+	UserAgent  string    `gorm:"type:text" json:"user_agent,omitempty"`
+	CreatedAt  time.Time `gorm:"index:idx_vsrl_order_created" json:"created_at"`
+}
+
+// TableName 指定表名
+func (VirtualStockRevealLog) TableName() string {
+	return "virtual_stock_reveal_logs"
+}
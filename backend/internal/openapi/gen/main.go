@@ -0,0 +1,599 @@
+// gen 静态分析 internal/handler 下各包的 handler 方法，提取请求体类型、查询参数与分页方式，
+// 为每个包生成 openapi_gen.go（OpenAPIBindings），供 /openapi.json 构建文档。
+//
+// 在 internal/openapi 目录执行 go generate 即可重新生成。
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath   = "auralogic"
+	handlerPkg   = modulePath + "/internal/handler"
+	openapiPkg   = modulePath + "/internal/openapi"
+	responsePkg  = modulePath + "/internal/pkg/response"
+	ginPkg       = "github.com/gin-gonic/gin"
+	generatedOut = "openapi_gen.go"
+)
+
+// builtinTypes 请求结构中可直接引用的预声明类型
+var builtinTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "any": true, "error": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+func main() {
+	dir := flag.String("dir", "../handler", "handler packages root")
+	flag.Parse()
+
+	outputs, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for file, content := range outputs {
+		if err := os.WriteFile(file, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate 返回 root 下每个 handler 包应生成的 openapi_gen.go 路径与内容
+func generate(root string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[string][]byte{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		content, err := generatePackage(dir, handlerPkg+"/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+		if content != nil {
+			outputs[filepath.Join(dir, generatedOut)] = content
+		}
+	}
+	return outputs, nil
+}
+
+// funcInfo 单个函数中直接提取到的信息，calls 为把 gin.Context 继续传递下去的调用
+type funcInfo struct {
+	key       string
+	summary   string
+	isHandler bool
+
+	body        string
+	query       string
+	queryParams []string
+	formFiles   []string
+	formFields  []string
+	paginated   bool
+	cursor      bool
+	imports     map[string]string
+
+	calls []string
+}
+
+type packageScope struct {
+	types   map[string]bool
+	funcs   map[string]*funcInfo
+	order   []string
+	imports map[string]string // 生成文件使用的 别名 -> 导入路径
+}
+
+func generatePackage(dir, importPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	pkgName := ""
+	for _, name := range matches {
+		base := filepath.Base(name)
+		if strings.HasSuffix(base, "_test.go") || base == generatedOut {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	scope := &packageScope{types: map[string]bool{}, funcs: map[string]*funcInfo{}, imports: map[string]string{}}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if typeSpec := spec.(*ast.TypeSpec); typeSpec.TypeParams == nil {
+					scope.types[typeSpec.Name.Name] = true
+				}
+			}
+		}
+	}
+	for _, file := range files {
+		fileImports := map[string]string{}
+		for _, spec := range file.Imports {
+			importPathValue, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(importPathValue)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			fileImports[name] = importPathValue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			info := scope.analyzeFunc(fn, fileImports, importPath)
+			scope.funcs[info.key] = info
+			scope.order = append(scope.order, info.key)
+		}
+	}
+
+	var entries []string
+	sort.Strings(scope.order)
+	for _, key := range scope.order {
+		info := scope.funcs[key]
+		if !info.isHandler {
+			continue
+		}
+		merged := scope.resolve(info)
+		if entry := scope.render(key, merged); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/openapi/gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkgName)
+	aliases := make([]string, 0, len(scope.imports))
+	for alias := range scope.imports {
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return scope.imports[aliases[i]] < scope.imports[aliases[j]] })
+	for _, alias := range aliases {
+		if path.Base(scope.imports[alias]) == alias {
+			fmt.Fprintf(&buf, "\t%q\n", scope.imports[alias])
+		} else {
+			fmt.Fprintf(&buf, "\t%s %q\n", alias, scope.imports[alias])
+		}
+	}
+	buf.WriteString(")\n\n")
+	buf.WriteString("// OpenAPIBindings 本包 handler 方法的请求描述，由 /openapi.json 使用\n")
+	buf.WriteString("var OpenAPIBindings = map[string]openapi.Binding{\n")
+	for _, entry := range entries {
+		buf.WriteString(entry)
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// analyzeFunc 提取函数体中与请求相关的调用。gin handler 指签名为 func(c *gin.Context) 的方法
+func (s *packageScope) analyzeFunc(fn *ast.FuncDecl, fileImports map[string]string, importPath string) *funcInfo {
+	info := &funcInfo{key: fn.Name.Name, imports: map[string]string{}}
+	receiver := ""
+	if fn.Recv != nil && len(fn.Recv.List) == 1 {
+		recvType := fn.Recv.List[0].Type
+		pointer := false
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType, pointer = star.X, true
+		}
+		if ident, ok := recvType.(*ast.Ident); ok {
+			if pointer {
+				info.key = importPath + ".(*" + ident.Name + ")." + fn.Name.Name
+			} else {
+				info.key = importPath + "." + ident.Name + "." + fn.Name.Name
+			}
+		}
+		if names := fn.Recv.List[0].Names; len(names) == 1 {
+			receiver = names[0].Name
+		}
+	}
+
+	ctxName := ""
+	params := fn.Type.Params.List
+	for _, field := range params {
+		if isGinContext(field.Type, fileImports) && len(field.Names) == 1 {
+			ctxName = field.Names[0].Name
+		}
+	}
+	info.isHandler = fn.Recv != nil && ctxName != "" && len(params) == 1 && fn.Type.Results == nil
+	if ctxName == "" || ctxName == "_" {
+		return info
+	}
+	if fn.Doc != nil {
+		summary := strings.TrimSpace(strings.SplitN(fn.Doc.Text(), "\n", 2)[0])
+		summary = strings.TrimSpace(strings.TrimPrefix(summary, fn.Name.Name))
+		info.summary = summary
+	}
+
+	// 函数内声明的变量类型与局部类型
+	varTypes := map[string]ast.Expr{}
+	localTypes := map[string]bool{}
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.TypeSpec:
+			localTypes[n.Name.Name] = true
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if n.Type != nil {
+					varTypes[name.Name] = n.Type
+				} else if len(n.Values) == len(n.Names) {
+					if typ := literalType(n.Values[i]); typ != nil {
+						varTypes[name.Name] = typ
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						if typ := literalType(n.Rhs[i]); typ != nil {
+							varTypes[ident.Name] = typ
+						}
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			x, ok := fun.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			switch {
+			case x.Name == ctxName:
+				s.contextCall(info, fun.Sel.Name, call, varTypes, localTypes, fileImports)
+			case fileImports[x.Name] == responsePkg:
+				switch fun.Sel.Name {
+				case "GetPagination":
+					info.queryParams = append(info.queryParams, "page", "limit")
+				case "GetCursor":
+					info.queryParams = append(info.queryParams, "cursor")
+				case "Paginated":
+					info.paginated = true
+				case "CursorPaginated":
+					info.cursor = true
+				}
+			case x.Name == receiver && receiver != "" && passesContext(call, ctxName):
+				info.calls = append(info.calls, methodKey(info.key, fun.Sel.Name))
+			}
+		case *ast.Ident:
+			if passesContext(call, ctxName) {
+				info.calls = append(info.calls, fun.Name)
+			}
+		}
+		return true
+	})
+	return info
+}
+
+func (s *packageScope) contextCall(info *funcInfo, method string, call *ast.CallExpr, varTypes map[string]ast.Expr, localTypes map[string]bool, fileImports map[string]string) {
+	switch method {
+	case "ShouldBindJSON", "ShouldBindQuery", "ShouldBind":
+		if len(call.Args) != 1 {
+			return
+		}
+		typ := boundType(call.Args[0], varTypes)
+		if typ == nil {
+			return
+		}
+		imports := map[string]string{}
+		if !s.resolvable(typ, localTypes, fileImports, imports) {
+			return
+		}
+		rendered := s.print(typ)
+		for alias, importPath := range imports {
+			info.imports[alias] = importPath
+		}
+		if method == "ShouldBindQuery" {
+			info.query = rendered
+		} else {
+			info.body = rendered
+		}
+	case "Query", "DefaultQuery", "GetQuery", "QueryArray", "GetQueryArray":
+		if name := stringArg(call); name != "" {
+			info.queryParams = append(info.queryParams, name)
+		}
+	case "PostForm", "DefaultPostForm", "GetPostForm":
+		if name := stringArg(call); name != "" {
+			info.formFields = append(info.formFields, name)
+		}
+	case "FormFile":
+		if name := stringArg(call); name != "" {
+			info.formFiles = append(info.formFiles, name)
+		}
+	}
+}
+
+// resolve 合并 handler 直接及间接调用（同包函数、同接收者方法）中提取的信息，请求体以 handler 自身为准
+func (s *packageScope) resolve(root *funcInfo) *funcInfo {
+	merged := &funcInfo{key: root.key, summary: root.summary, imports: map[string]string{}}
+	visited := map[string]bool{}
+	var walk func(info *funcInfo)
+	walk = func(info *funcInfo) {
+		if visited[info.key] {
+			return
+		}
+		visited[info.key] = true
+		if merged.body == "" && info.body != "" {
+			merged.body = info.body
+			for alias, importPath := range info.imports {
+				merged.imports[alias] = importPath
+			}
+		}
+		if merged.query == "" && info.query != "" {
+			merged.query = info.query
+			for alias, importPath := range info.imports {
+				merged.imports[alias] = importPath
+			}
+		}
+		merged.queryParams = append(merged.queryParams, info.queryParams...)
+		merged.formFiles = append(merged.formFiles, info.formFiles...)
+		merged.formFields = append(merged.formFields, info.formFields...)
+		merged.paginated = merged.paginated || info.paginated
+		merged.cursor = merged.cursor || info.cursor
+		for _, key := range info.calls {
+			if callee, ok := s.funcs[key]; ok {
+				walk(callee)
+			}
+		}
+	}
+	walk(root)
+	return merged
+}
+
+func (s *packageScope) render(key string, info *funcInfo) string {
+	if info.summary == "" && info.body == "" && info.query == "" && len(info.queryParams) == 0 &&
+		len(info.formFiles) == 0 && len(info.formFields) == 0 && !info.paginated && !info.cursor {
+		return ""
+	}
+	// 同一别名在不同文件中指向不同包时无法共用一个生成文件，放弃该类型
+	for alias, importPath := range info.imports {
+		if existing, ok := s.imports[alias]; ok && existing != importPath {
+			info.body, info.query = "", ""
+		}
+	}
+	if info.body != "" || info.query != "" {
+		for alias, importPath := range info.imports {
+			s.imports[alias] = importPath
+		}
+		s.imports["reflect"] = "reflect"
+	}
+	s.imports["openapi"] = openapiPkg
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%q: {\n", key)
+	if info.summary != "" {
+		fmt.Fprintf(&buf, "Summary: %q,\n", info.summary)
+	}
+	if info.body != "" {
+		fmt.Fprintf(&buf, "Body: reflect.TypeOf((*%s)(nil)).Elem(),\n", info.body)
+	}
+	if info.query != "" {
+		fmt.Fprintf(&buf, "Query: reflect.TypeOf((*%s)(nil)).Elem(),\n", info.query)
+	}
+	writeList(&buf, "QueryParams", info.queryParams)
+	writeList(&buf, "FormFiles", info.formFiles)
+	writeList(&buf, "FormFields", info.formFields)
+	if info.paginated {
+		buf.WriteString("Paginated: true,\n")
+	}
+	if info.cursor {
+		buf.WriteString("CursorPaginated: true,\n")
+	}
+	buf.WriteString("},\n")
+	return buf.String()
+}
+
+// resolvable 检查类型表达式能否在生成文件中原样引用，并收集其依赖的导入
+func (s *packageScope) resolvable(expr ast.Expr, localTypes map[string]bool, fileImports, imports map[string]string) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if localTypes[e.Name] {
+			return false
+		}
+		return builtinTypes[e.Name] || s.types[e.Name]
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok || fileImports[pkg.Name] == "" {
+			return false
+		}
+		imports[pkg.Name] = fileImports[pkg.Name]
+		return true
+	case *ast.StarExpr:
+		return s.resolvable(e.X, localTypes, fileImports, imports)
+	case *ast.ArrayType:
+		return s.resolvable(e.Elt, localTypes, fileImports, imports)
+	case *ast.MapType:
+		return s.resolvable(e.Key, localTypes, fileImports, imports) && s.resolvable(e.Value, localTypes, fileImports, imports)
+	case *ast.InterfaceType:
+		return len(e.Methods.List) == 0
+	case *ast.StructType:
+		for _, field := range e.Fields.List {
+			if !s.resolvable(field.Type, localTypes, fileImports, imports) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *packageScope) print(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), stripPositions(expr)); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// stripPositions 去掉位置信息与注释后再打印，避免生成文件沿用源文件的换行
+func stripPositions(expr ast.Expr) ast.Expr {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Field:
+			n.Doc, n.Comment = nil, nil
+		case *ast.Ident:
+			n.NamePos = token.NoPos
+		case *ast.FieldList:
+			n.Opening, n.Closing = token.NoPos, token.NoPos
+		case *ast.StructType:
+			n.Struct = token.NoPos
+		case *ast.BasicLit:
+			n.ValuePos = token.NoPos
+		case *ast.StarExpr:
+			n.Star = token.NoPos
+		case *ast.ArrayType:
+			n.Lbrack = token.NoPos
+		case *ast.MapType:
+			n.Map = token.NoPos
+		case *ast.InterfaceType:
+			n.Interface = token.NoPos
+		}
+		return true
+	})
+	return expr
+}
+
+// boundType 解析 ShouldBindJSON(&req) / ShouldBindJSON(req) 中 req 的声明类型
+func boundType(arg ast.Expr, varTypes map[string]ast.Expr) ast.Expr {
+	pointer := false
+	if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		arg = unary.X
+	} else {
+		pointer = true
+	}
+	ident, ok := arg.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	typ := varTypes[ident.Name]
+	if typ == nil {
+		return nil
+	}
+	if pointer {
+		star, ok := typ.(*ast.StarExpr)
+		if !ok {
+			return nil
+		}
+		typ = star.X
+	}
+	return typ
+}
+
+// literalType 返回 T{...} / &T{...} / new(T) 表达式的类型
+func literalType(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e.Type
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND && lit.Type != nil {
+			return &ast.StarExpr{X: lit.Type}
+		}
+	case *ast.CallExpr:
+		if fun, ok := e.Fun.(*ast.Ident); ok && fun.Name == "new" && len(e.Args) == 1 {
+			return &ast.StarExpr{X: e.Args[0]}
+		}
+	}
+	return nil
+}
+
+func isGinContext(expr ast.Expr, fileImports map[string]string) bool {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && fileImports[pkg.Name] == ginPkg
+}
+
+func passesContext(call *ast.CallExpr, ctxName string) bool {
+	for _, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok && ident.Name == ctxName {
+			return true
+		}
+	}
+	return false
+}
+
+// methodKey 把 "pkg.(*T).M" 中的方法名替换为 name
+func methodKey(key, name string) string {
+	return key[:strings.LastIndex(key, ".")+1] + name
+}
+
+func stringArg(call *ast.CallExpr) string {
+	if len(call.Args) == 0 {
+		return ""
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+func writeList(buf *strings.Builder, field string, values []string) {
+	seen := map[string]bool{}
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, strconv.Quote(value))
+		}
+	}
+	if len(unique) > 0 {
+		fmt.Fprintf(buf, "%s: []string{%s},\n", field, strings.Join(unique, ", "))
+	}
+}
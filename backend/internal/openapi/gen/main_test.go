@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 修改 handler 后未重新生成时失败，提示执行 go generate ./internal/openapi
+func TestGeneratedBindingsUpToDate(t *testing.T) {
+	outputs, err := generate("../../handler")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	existing, err := filepath.Glob(filepath.Join("../../handler", "*", generatedOut))
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(existing) != len(outputs) {
+		t.Fatalf("expected %d generated files, found %d; run go generate ./internal/openapi", len(outputs), len(existing))
+	}
+	for file, want := range outputs {
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s failed: %v", file, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale; run go generate ./internal/openapi", file)
+		}
+	}
+}
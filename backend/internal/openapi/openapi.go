@@ -0,0 +1,419 @@
+// Package openapi 根据已注册的 gin 路由与各 handler 包生成的绑定信息（openapi_gen.go）
+// 构建 OpenAPI 3 文档，供客户端 SDK 生成使用。
+//
+// handler 包中的绑定信息由 gen 工具静态分析源码得出，修改 handler 的请求结构或查询参数后需重新生成：
+//
+//	go generate ./internal/openapi
+package openapi
+
+//go:generate go run ./gen
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"auralogic/internal/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Binding 单个 handler 方法的请求描述，由 gen 工具从源码中提取
+type Binding struct {
+	// Summary 方法文档注释的首行
+	Summary string
+	// Body ShouldBindJSON 绑定的请求体类型
+	Body reflect.Type
+	// Query ShouldBindQuery 绑定的查询参数类型
+	Query reflect.Type
+	// QueryParams 通过 c.Query / c.DefaultQuery 等读取的查询参数
+	QueryParams []string
+	// FormFiles / FormFields multipart 表单中的文件与普通字段
+	FormFiles  []string
+	FormFields []string
+	// Paginated / CursorPaginated 响应是否为页码分页或游标分页结构
+	Paginated       bool
+	CursorPaginated bool
+}
+
+// Document OpenAPI 3.0 文档
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// SecurityRequirement 空对象表示允许匿名访问
+type SecurityRequirement map[string][]string
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+var (
+	pathParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+	handlerPattern   = regexp.MustCompile(`^(.*)\.\(\*(\w+)\)\.(\w+)-fm$`)
+)
+
+// Handler 返回 /openapi.json 的处理函数。文档在首次请求时根据 routes() 构建并缓存，
+// 因此可在路由全部注册前挂载
+func Handler(routes func() gin.RoutesInfo, version string, bindings ...map[string]Binding) gin.HandlerFunc {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			body, err = json.Marshal(Build(routes(), version, bindings...))
+		})
+		if err != nil {
+			response.InternalError(c, "Failed to build OpenAPI document")
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// Build 为 /api 下的路由生成 OpenAPI 文档。bindings 的键为 handler 方法的完整名称，
+// 如 "auralogic/internal/handler/admin.(*OrderHandler).GetOrder"
+func Build(routes gin.RoutesInfo, version string, bindings ...map[string]Binding) *Document {
+	merged := map[string]Binding{}
+	for _, set := range bindings {
+		for name, binding := range set {
+			merged[name] = binding
+		}
+	}
+
+	builder := newSchemaBuilder()
+	registerEnvelopes(builder)
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "AuraLogic API",
+			Description: "Generated from registered routes and handler request bindings.",
+			Version:     version,
+		},
+		Servers: []Server{{URL: "/"}},
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{
+			Schemas: builder.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				"apiKey":     {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				"apiSecret":  {Type: "apiKey", In: "header", Name: "X-API-Secret"},
+			},
+		},
+	}
+
+	sorted := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		if route.Method == http.MethodHead || !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		sorted = append(sorted, route)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	operationIDs := map[string]int{}
+	tags := map[string]bool{}
+	for _, route := range sorted {
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		handlerName := strings.TrimSuffix(route.Handler, "-fm")
+		binding := merged[handlerName]
+
+		operation := Operation{
+			OperationID: operationID(route, operationIDs),
+			Summary:     binding.Summary,
+			Parameters:  pathParameters(route.Path),
+			Responses:   responses(binding),
+			Security:    security(route.Path),
+		}
+		if tag := pathTag(route.Path); tag != "" {
+			operation.Tags = []string{tag}
+			tags[tag] = true
+		}
+		operation.Parameters = append(operation.Parameters, queryParameters(builder, binding)...)
+		operation.RequestBody = requestBody(builder, binding)
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return doc
+}
+
+// registerEnvelopes 注册 pkg/response 中的统一响应结构，items / data 在具体操作中再按类型细化
+func registerEnvelopes(builder *schemaBuilder) {
+	builder.components["Response"] = builder.structSchema(reflect.TypeOf(response.Response{}))
+	for name, t := range map[string]reflect.Type{
+		"Pagination":       reflect.TypeOf(response.Pagination{}),
+		"CursorPagination": reflect.TypeOf(response.CursorPagination{}),
+	} {
+		builder.names[t] = name
+		builder.components[name] = builder.structSchema(t)
+	}
+
+	paginated := builder.structSchema(reflect.TypeOf(response.PaginatedResponse{}))
+	paginated.Properties["items"] = &Schema{Type: "array", Items: &Schema{}}
+	builder.components["PaginatedResponse"] = paginated
+
+	cursorPaginated := builder.structSchema(reflect.TypeOf(response.CursorPaginatedResponse{}))
+	cursorPaginated.Properties["items"] = &Schema{Type: "array", Items: &Schema{}}
+	builder.components["CursorPaginatedResponse"] = cursorPaginated
+
+	builder.components["BizErrorData"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error_key": {Type: "string", Description: "i18n key of the business error, e.g. order.notFound"},
+			"params":    {Type: "object", AdditionalProperties: &Schema{}},
+		},
+	}
+	builder.components["ErrorResponse"] = &Schema{
+		AllOf: []*Schema{
+			{Ref: "#/components/schemas/Response"},
+			{Type: "object", Properties: map[string]*Schema{"data": {Ref: "#/components/schemas/BizErrorData"}}},
+		},
+	}
+}
+
+func responses(binding Binding) map[string]Response {
+	var data *Schema
+	switch {
+	case binding.CursorPaginated:
+		data = &Schema{Ref: "#/components/schemas/CursorPaginatedResponse"}
+	case binding.Paginated:
+		data = &Schema{Ref: "#/components/schemas/PaginatedResponse"}
+	}
+	success := &Schema{Ref: "#/components/schemas/Response"}
+	if data != nil {
+		success = &Schema{AllOf: []*Schema{success, {Type: "object", Properties: map[string]*Schema{"data": data}}}}
+	}
+	return map[string]Response{
+		"200": {
+			Description: "Success envelope, code is 0",
+			Content:     map[string]MediaType{"application/json": {Schema: success}},
+		},
+		"default": {
+			Description: "Error envelope with a non-zero code",
+			Content: map[string]MediaType{"application/json": {
+				Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"},
+			}},
+		},
+	}
+}
+
+func requestBody(builder *schemaBuilder, binding Binding) *RequestBody {
+	if binding.Body != nil {
+		return &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: builder.schemaFor(binding.Body)}},
+		}
+	}
+	if len(binding.FormFiles) == 0 && len(binding.FormFields) == 0 {
+		return nil
+	}
+	form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, name := range binding.FormFields {
+		form.Properties[name] = &Schema{Type: "string"}
+	}
+	for _, name := range binding.FormFiles {
+		form.Properties[name] = &Schema{Type: "string", Format: "binary"}
+		form.Required = append(form.Required, name)
+	}
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+	}
+}
+
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		schema := &Schema{Type: "string"}
+		if strings.HasPrefix(match[0], ":") && (name == "id" || strings.HasSuffix(name, "_id")) {
+			schema = &Schema{Type: "integer", Format: "int64"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return params
+}
+
+func queryParameters(builder *schemaBuilder, binding Binding) []Parameter {
+	var params []Parameter
+	seen := map[string]bool{}
+	add := func(name string, schema *Schema, required bool) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		params = append(params, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+
+	if binding.Query != nil {
+		queryType := binding.Query
+		for queryType.Kind() == reflect.Pointer {
+			queryType = queryType.Elem()
+		}
+		if queryType.Kind() == reflect.Struct {
+			// 查询参数按 form 标签展开
+			schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+			builder.addFields(schema, queryType, "form")
+			names := make([]string, 0, len(schema.Properties))
+			for name := range schema.Properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				add(name, schema.Properties[name], contains(schema.Required, name))
+			}
+		}
+	}
+	if binding.Paginated {
+		add("page", &Schema{Type: "integer", Format: "int32"}, false)
+		add("limit", &Schema{Type: "integer", Format: "int32"}, false)
+	}
+	if binding.CursorPaginated {
+		add("cursor", &Schema{Type: "string"}, false)
+		add("limit", &Schema{Type: "integer", Format: "int32"}, false)
+	}
+	for _, name := range binding.QueryParams {
+		schema := &Schema{Type: "string"}
+		if name == "page" || name == "limit" {
+			schema = &Schema{Type: "integer", Format: "int32"}
+		}
+		add(name, schema, false)
+	}
+	return params
+}
+
+// security 管理端接口支持 JWT 或 API Key，用户端接口使用 JWT，登录注册与商品浏览等允许匿名
+func security(path string) []SecurityRequirement {
+	switch {
+	case strings.HasPrefix(path, "/api/admin/"):
+		return []SecurityRequirement{{"bearerAuth": {}}, {"apiKey": {}, "apiSecret": {}}}
+	case strings.HasPrefix(path, "/api/user/auth/"), strings.HasPrefix(path, "/api/user/products"):
+		return []SecurityRequirement{{"bearerAuth": {}}, {}}
+	case strings.HasPrefix(path, "/api/user/"):
+		return []SecurityRequirement{{"bearerAuth": {}}}
+	default:
+		return []SecurityRequirement{{}}
+	}
+}
+
+// pathTag 以 /api/{scope}/{resource} 作为分组，如 admin/orders
+func pathTag(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/"), "/")
+	if len(parts) >= 2 && (parts[0] == "admin" || parts[0] == "user") && parts[1] != "" && !strings.ContainsAny(parts[1][:1], ":*") {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// operationID 由 handler 包名、类型名（去掉 Handler 后缀）与方法名组成，如 adminOrderGetOrder；
+// 匿名函数等无法解析的 handler 退化为方法与路径拼接
+func operationID(route gin.RouteInfo, used map[string]int) string {
+	id := ""
+	if match := handlerPattern.FindStringSubmatch(route.Handler); match != nil {
+		pkg := match[1][strings.LastIndex(match[1], "/")+1:]
+		id = pkg + strings.TrimSuffix(match[2], "Handler") + match[3]
+	} else {
+		id = strings.ToLower(route.Method)
+		for _, part := range strings.FieldsFunc(route.Path, func(r rune) bool { return !isAlnum(r) }) {
+			id += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	// 同一方法挂载到多条路由时追加序号区分
+	used[id]++
+	if n := used[id]; n > 1 {
+		id += strconv.Itoa(n)
+	}
+	return id
+}
+
+func isAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
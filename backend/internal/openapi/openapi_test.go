@@ -0,0 +1,135 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	adminHandler "auralogic/internal/handler/admin"
+	formHandler "auralogic/internal/handler/form"
+	userHandler "auralogic/internal/handler/user"
+	"auralogic/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+func newTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	admins := &adminHandler.AdminHandler{}
+	apiKeys := &adminHandler.APIKeyHandler{}
+	r.POST("/api/admin/admins", admins.CreateAdmin)
+	r.GET("/api/admin/admins/:id", admins.GetAdmin)
+	r.GET("/api/admin/api-keys", apiKeys.ListAPIKeys)
+	r.GET("/health", func(c *gin.Context) {})
+	r.GET("/openapi.json", openapi.Handler(r.Routes, "test", adminHandler.OpenAPIBindings))
+	return r
+}
+
+func TestBuildDescribesRequestBodiesFromBindings(t *testing.T) {
+	doc := openapi.Build(newTestEngine().Routes(), "test", adminHandler.OpenAPIBindings)
+
+	if _, ok := doc.Paths["/health"]; ok {
+		t.Fatalf("expected only /api routes to be documented")
+	}
+	create, ok := doc.Paths["/api/admin/admins"]["post"]
+	if !ok || create.RequestBody == nil {
+		t.Fatalf("expected a request body for CreateAdmin, got %+v", create)
+	}
+	if create.OperationID != "adminAdminCreateAdmin" {
+		t.Fatalf("unexpected operation id %q", create.OperationID)
+	}
+	ref := create.RequestBody.Content["application/json"].Schema.Ref
+	if ref != "#/components/schemas/admin.CreateAdminRequest" {
+		t.Fatalf("expected the request struct to be referenced, got %q", ref)
+	}
+
+	schema := doc.Components.Schemas["admin.CreateAdminRequest"]
+	if schema == nil || len(schema.Required) != 3 {
+		t.Fatalf("expected email, password and name to be required, got %+v", schema)
+	}
+	if schema.Properties["email"].Format != "email" || *schema.Properties["password"].MinLength != 8 {
+		t.Fatalf("expected binding rules to become constraints, got %+v", schema.Properties)
+	}
+	if role := schema.Properties["role"]; len(role.Enum) != 2 || role.Enum[1] != "super_admin" {
+		t.Fatalf("expected oneof to become an enum, got %+v", role)
+	}
+	if len(create.Security) != 2 {
+		t.Fatalf("expected admin routes to accept JWT or API key, got %+v", create.Security)
+	}
+}
+
+func TestBuildDescribesPathParamsAndPagination(t *testing.T) {
+	doc := openapi.Build(newTestEngine().Routes(), "test", adminHandler.OpenAPIBindings)
+
+	get := doc.Paths["/api/admin/admins/{id}"]["get"]
+	if len(get.Parameters) != 1 || get.Parameters[0].In != "path" || get.Parameters[0].Schema.Type != "integer" {
+		t.Fatalf("expected an integer id path parameter, got %+v", get.Parameters)
+	}
+
+	list := doc.Paths["/api/admin/api-keys"]["get"]
+	names := map[string]bool{}
+	for _, param := range list.Parameters {
+		names[param.Name] = param.In == "query"
+	}
+	if !names["page"] || !names["limit"] {
+		t.Fatalf("expected page and limit query parameters, got %+v", list.Parameters)
+	}
+	success := list.Responses["200"].Content["application/json"].Schema
+	if len(success.AllOf) != 2 || success.AllOf[1].Properties["data"].Ref != "#/components/schemas/PaginatedResponse" {
+		t.Fatalf("expected a paginated envelope, got %+v", success)
+	}
+	if doc.Components.Schemas["Pagination"] == nil || doc.Components.Schemas["ErrorResponse"] == nil {
+		t.Fatalf("expected response envelopes to be registered as components")
+	}
+}
+
+func TestHandlerServesDocument(t *testing.T) {
+	r := newTestEngine()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc.OpenAPI != "3.0.3" || doc.Info.Version != "test" || len(doc.Paths) != 3 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+}
+
+// 所有生成的绑定都能构建出 schema，且 $ref 均指向已注册的组件
+func TestBuildResolvesAllGeneratedBindings(t *testing.T) {
+	var routes gin.RoutesInfo
+	for _, bindings := range []map[string]openapi.Binding{adminHandler.OpenAPIBindings, userHandler.OpenAPIBindings, formHandler.OpenAPIBindings} {
+		for name := range bindings {
+			path := "/api/operations/" + strconv.Itoa(len(routes))
+			routes = append(routes, gin.RouteInfo{Method: http.MethodPost, Path: path, Handler: name + "-fm"})
+		}
+	}
+	doc := openapi.Build(routes, "test", adminHandler.OpenAPIBindings, userHandler.OpenAPIBindings, formHandler.OpenAPIBindings)
+	body, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	refs := regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllSubmatch(body, -1)
+	if len(refs) == 0 {
+		t.Fatalf("expected request structs to be referenced")
+	}
+	for _, ref := range refs {
+		if doc.Components.Schemas[string(ref[1])] == nil {
+			t.Fatalf("dangling reference %s", ref[1])
+		}
+	}
+}
@@ -18,10 +18,26 @@ func EmailNotVerified() *bizerr.Error {
 	return bizerr.New("auth.emailNotVerified", "Please verify your email before logging in")
 }
 
+func EmailNotVerifiedForCheckout() *bizerr.Error {
+	return bizerr.New("auth.emailNotVerifiedForCheckout", "Please verify your email before placing an order")
+}
+
+func EmailNotVerifiedForVirtualReveal() *bizerr.Error {
+	return bizerr.New("auth.emailNotVerifiedForVirtualReveal", "Please verify your email before viewing virtual product content")
+}
+
 func EmailAlreadyInUse() *bizerr.Error {
 	return bizerr.New("auth.emailAlreadyInUse", "Email already in use")
 }
 
+func EmailDisposable() *bizerr.Error {
+	return bizerr.New("auth.emailDisposable", "Disposable email addresses are not allowed")
+}
+
+func EmailDomainUnreachable() *bizerr.Error {
+	return bizerr.New("auth.emailDomainUnreachable", "This email domain cannot receive mail")
+}
+
 func PhoneAlreadyInUse() *bizerr.Error {
 	return bizerr.New("auth.phoneAlreadyInUse", "Phone number already in use")
 }
@@ -89,3 +105,7 @@ func CaptchaRequired() *bizerr.Error {
 func CaptchaFailed() *bizerr.Error {
 	return bizerr.New("auth.captchaFailed", "Captcha verification failed")
 }
+
+func TooManyCodeAttempts() *bizerr.Error {
+	return bizerr.New("auth.tooManyCodeAttempts", "Too many incorrect attempts, please request a new verification code")
+}
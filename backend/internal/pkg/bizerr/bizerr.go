@@ -1,18 +1,44 @@
 package bizerr
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 // Error 业务错误，携带 i18n key 和动态参数，可安全返回给前端
 type Error struct {
-	Key     string                 `json:"key"`               // 前端 i18n key，如 "order.purchaseLimitReached"
-	Message string                 `json:"message"`           // 英文 fallback
-	Params  map[string]interface{} `json:"params,omitempty"`  // 动态参数，如 {product: "xxx", limit: 5}
+	Key     string                 `json:"key"`              // 前端 i18n key，如 "order.purchaseLimitReached"
+	Message string                 `json:"message"`          // 英文 fallback
+	Params  map[string]interface{} `json:"params,omitempty"` // 动态参数，如 {product: "xxx", limit: 5}
+	Status  int                    `json:"-"`                // HTTP 状态码提示，0 时按登记的错误码或 400
+	cause   error                  // 原始错误，仅用于日志和 errors.Is/As，不返回给前端
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// Unwrap 返回原始错误，保留错误链
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Cause 原始错误（可能为 nil）
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// HTTPStatus 响应使用的 HTTP 状态码：显式设置优先，其次为登记的错误码，默认 400
+func (e *Error) HTTPStatus() int {
+	if e.Status > 0 {
+		return e.Status
+	}
+	if code, ok := Lookup(e.Key); ok {
+		return code.Status
+	}
+	return http.StatusBadRequest
+}
+
 // New 创建业务错误
 func New(key, message string) *Error {
 	return &Error{Key: key, Message: message}
@@ -23,8 +49,19 @@ func Newf(key, format string, args ...interface{}) *Error {
 	return &Error{Key: key, Message: fmt.Sprintf(format, args...)}
 }
 
+// Wrap 创建包装原始错误的业务错误，前端只看到 key 和 message，原始错误保留在错误链中
+func Wrap(err error, key, message string) *Error {
+	return &Error{Key: key, Message: message, cause: err}
+}
+
 // WithParams 附加动态参数
 func (e *Error) WithParams(params map[string]interface{}) *Error {
 	e.Params = params
 	return e
 }
+
+// WithStatus 覆盖 HTTP 状态码提示
+func (e *Error) WithStatus(status int) *Error {
+	e.Status = status
+	return e
+}
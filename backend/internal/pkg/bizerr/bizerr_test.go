@@ -0,0 +1,89 @@
+package bizerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+func TestWrapPreservesCauseChain(t *testing.T) {
+	cause := fmt.Errorf("load order: %w", gorm.ErrRecordNotFound)
+	err := Wrap(cause, "order.notFound", "Order not found")
+
+	if err.Error() != "Order not found" {
+		t.Fatalf("expected safe message, got %q", err.Error())
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatal("expected wrapped error to match the original cause")
+	}
+
+	outer := fmt.Errorf("handler: %w", err)
+	var bizErr *Error
+	if !errors.As(outer, &bizErr) || bizErr.Key != "order.notFound" {
+		t.Fatalf("expected bizerr to be found in chain, got %v", bizErr)
+	}
+}
+
+func TestHTTPStatusHints(t *testing.T) {
+	if status := New("order.test", "test").HTTPStatus(); status != http.StatusBadRequest {
+		t.Fatalf("expected unregistered key to default to 400, got %d", status)
+	}
+	if status := New("common.notFound", "missing").HTTPStatus(); status != http.StatusNotFound {
+		t.Fatalf("expected registered key to use its status, got %d", status)
+	}
+	if status := CodeNotFound.New().WithStatus(http.StatusGone).HTTPStatus(); status != http.StatusGone {
+		t.Fatalf("expected explicit status to win, got %d", status)
+	}
+}
+
+func TestRegisterRejectsDuplicateKeys(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected duplicate registration to panic")
+		}
+	}()
+	Register(CodeNotFound.Key, http.StatusNotFound, "duplicate")
+}
+
+func TestFromStore(t *testing.T) {
+	userNotFound := &Code{Key: "test.userNotFound", Status: http.StatusNotFound, Message: "User not found"}
+	existing := New("order.stockShort", "Insufficient stock")
+
+	cases := []struct {
+		name     string
+		err      error
+		notFound *Code
+		key      string
+		status   int
+	}{
+		{"gorm not found", gorm.ErrRecordNotFound, nil, "common.notFound", http.StatusNotFound},
+		{"custom not found", fmt.Errorf("find user: %w", gorm.ErrRecordNotFound), userNotFound, "test.userNotFound", http.StatusNotFound},
+		{"redis nil", redis.Nil, nil, "common.notFound", http.StatusNotFound},
+		{"duplicated key", gorm.ErrDuplicatedKey, nil, "common.conflict", http.StatusConflict},
+		{"unique constraint text", errors.New("UNIQUE constraint failed: users.email"), nil, "common.conflict", http.StatusConflict},
+		{"deadline", context.DeadlineExceeded, nil, "common.serviceUnavailable", http.StatusServiceUnavailable},
+		{"redis closed", redis.ErrClosed, nil, "common.serviceUnavailable", http.StatusServiceUnavailable},
+		{"unknown", errors.New("syntax error near SELECT"), nil, "common.internalError", http.StatusInternalServerError},
+		{"already bizerr", existing, nil, "order.stockShort", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FromStore(tc.err, tc.notFound)
+			if got.Key != tc.key || got.HTTPStatus() != tc.status {
+				t.Fatalf("expected %s/%d, got %s/%d", tc.key, tc.status, got.Key, got.HTTPStatus())
+			}
+			if !errors.Is(got, tc.err) {
+				t.Fatal("expected original error to stay in the chain")
+			}
+		})
+	}
+
+	if FromStore(nil, nil) != nil {
+		t.Fatal("expected nil for nil error")
+	}
+}
@@ -0,0 +1,95 @@
+package bizerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Code 登记的错误码：i18n key、HTTP 状态码提示和默认英文消息
+type Code struct {
+	Key     string
+	Status  int
+	Message string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*Code)
+)
+
+// 通用错误码，FromStore 转换存储层错误时使用
+var (
+	CodeNotFound    = Register("common.notFound", http.StatusNotFound, "Resource not found")
+	CodeConflict    = Register("common.conflict", http.StatusConflict, "Resource already exists")
+	CodeUnavailable = Register("common.serviceUnavailable", http.StatusServiceUnavailable, "Service temporarily unavailable, please try again later")
+	CodeInternal    = Register("common.internalError", http.StatusInternalServerError, "Internal server error")
+
+	// CodeVersionConflict 乐观锁校验失败：记录在读取后已被他人修改
+	CodeVersionConflict = Register("common.versionConflict", http.StatusConflict, "This record was changed by someone else, reload and try again")
+
+	// CodeInvalidCursor 游标分页的 cursor 无法解析
+	CodeInvalidCursor = Register("common.invalidCursor", http.StatusBadRequest, "Invalid page cursor, reload the list and try again")
+)
+
+// Register 登记错误码，通常在包级变量中调用；同一 key 重复登记视为编程错误直接 panic
+func Register(key string, status int, message string) *Code {
+	if key == "" {
+		panic("bizerr: register empty key")
+	}
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("bizerr: key %q registered twice", key))
+	}
+	code := &Code{Key: key, Status: status, Message: message}
+	registry[key] = code
+	return code
+}
+
+// Lookup 按 key 查找已登记的错误码
+func Lookup(key string) (*Code, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	code, ok := registry[key]
+	return code, ok
+}
+
+// Registered 按 key 排序返回全部已登记的错误码
+func Registered() []Code {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	codes := make([]Code, 0, len(registry))
+	for _, code := range registry {
+		codes = append(codes, *code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Key < codes[j].Key })
+	return codes
+}
+
+// New 使用默认消息创建业务错误
+func (c *Code) New() *Error {
+	return &Error{Key: c.Key, Message: c.Message, Status: c.Status}
+}
+
+// Newf 使用格式化消息创建业务错误
+func (c *Code) Newf(format string, args ...interface{}) *Error {
+	return &Error{Key: c.Key, Message: fmt.Sprintf(format, args...), Status: c.Status}
+}
+
+// Wrap 使用默认消息包装原始错误
+func (c *Code) Wrap(err error) *Error {
+	return &Error{Key: c.Key, Message: c.Message, Status: c.Status, cause: err}
+}
+
+// Is 判断错误链中是否包含该错误码的业务错误
+func (c *Code) Is(err error) bool {
+	var bizErr *Error
+	return errors.As(err, &bizErr) && bizErr.Key == c.Key
+}
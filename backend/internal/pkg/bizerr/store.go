@@ -0,0 +1,63 @@
+package bizerr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+// FromStore 将 GORM / Redis 错误转换为可安全返回给前端的业务错误，原始错误保留在错误链中。
+// notFound 不为 nil 时，记录不存在使用该错误码（如 "用户不存在"），否则使用通用的 common.notFound。
+// err 为 nil 返回 nil；已是业务错误的原样返回
+func FromStore(err error, notFound *Code) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var bizErr *Error
+	if errors.As(err, &bizErr) {
+		return bizErr
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, redis.Nil):
+		if notFound != nil {
+			return notFound.Wrap(err)
+		}
+		return CodeNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey), isUniqueViolation(err):
+		return CodeConflict.Wrap(err)
+	case isUnavailable(err):
+		return CodeUnavailable.Wrap(err)
+	default:
+		return CodeInternal.Wrap(err)
+	}
+}
+
+// isUniqueViolation 未开启 TranslateError 时按各数据库的错误文本识别唯一约束冲突
+func isUniqueViolation(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique constraint") ||
+		strings.Contains(msg, "duplicate key") ||
+		strings.Contains(msg, "duplicate entry") ||
+		strings.Contains(msg, "unique violation")
+}
+
+// isUnavailable 超时、连接断开等可重试的基础设施错误
+func isUnavailable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, redis.ErrClosed) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "bad connection") ||
+		strings.Contains(msg, "database is locked")
+}
@@ -0,0 +1,196 @@
+package constants
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 收货地址字段名（与下单/表单请求的 JSON 字段一致）
+const (
+	AddressFieldProvince = "receiver_province"
+	AddressFieldCity     = "receiver_city"
+	AddressFieldDistrict = "receiver_district"
+	AddressFieldPostcode = "receiver_postcode"
+)
+
+// AddressSchema 国家/地区的电话区号与收货地址格式，后端校验与前端表单共用
+type AddressSchema struct {
+	Code            string   `json:"code"`                       // 国家代码 (ISO 3166-1 alpha-2)
+	PhoneCode       string   `json:"phone_code"`                 // 国际电话区号，如 +86
+	RequiredFields  []string `json:"required_fields"`            // 除姓名、电话、详细地址外必填的地址字段
+	PostcodePattern string   `json:"postcode_pattern,omitempty"` // 邮编正则（大写后整体匹配，写法同时兼容 Go 与 JavaScript）
+	PostcodeExample string   `json:"postcode_example,omitempty"` // 邮编示例，用于提示
+}
+
+// phoneCodes 各国家/地区的国际电话区号
+var phoneCodes = map[string]string{
+	// 亚洲
+	"CN": "+86", "HK": "+852", "MO": "+853", "TW": "+886",
+	"JP": "+81", "KR": "+82", "SG": "+65", "MY": "+60",
+	"TH": "+66", "VN": "+84", "ID": "+62", "PH": "+63",
+	"IN": "+91", "PK": "+92", "BD": "+880", "LK": "+94",
+	"MM": "+95", "KH": "+855", "LA": "+856", "BN": "+673",
+	"MV": "+960", "NP": "+977", "BT": "+975", "MN": "+976",
+	"AF": "+93", "IQ": "+964", "IR": "+98", "IL": "+972",
+	"JO": "+962", "KW": "+965", "SA": "+966", "AE": "+971",
+	"QA": "+974", "OM": "+968", "YE": "+967", "SY": "+963",
+	"LB": "+961", "PS": "+970", "TR": "+90", "KZ": "+7",
+	"UZ": "+998", "TM": "+993", "KG": "+996", "TJ": "+992",
+	"AM": "+374", "AZ": "+994", "GE": "+995", "TL": "+670",
+
+	// 欧洲
+	"GB": "+44", "FR": "+33", "DE": "+49", "IT": "+39",
+	"ES": "+34", "PT": "+351", "NL": "+31", "BE": "+32",
+	"CH": "+41", "AT": "+43", "SE": "+46", "NO": "+47",
+	"DK": "+45", "FI": "+358", "IS": "+354", "IE": "+353",
+	"PL": "+48", "CZ": "+420", "SK": "+421", "HU": "+36",
+	"RO": "+40", "BG": "+359", "GR": "+30", "HR": "+385",
+	"SI": "+386", "RS": "+381", "BA": "+387", "ME": "+382",
+	"MK": "+389", "AL": "+355", "UA": "+380", "BY": "+375",
+	"MD": "+373", "RU": "+7", "EE": "+372", "LV": "+371",
+	"LT": "+370", "CY": "+357", "MT": "+356", "LU": "+352",
+	"MC": "+377", "AD": "+376", "SM": "+378", "VA": "+379",
+	"LI": "+423",
+
+	// 北美洲
+	"US": "+1", "CA": "+1", "MX": "+52",
+	"GT": "+502", "BZ": "+501", "SV": "+503", "HN": "+504",
+	"NI": "+505", "CR": "+506", "PA": "+507", "CU": "+53",
+	"JM": "+1", "HT": "+509", "DO": "+1", "BS": "+1",
+	"BB": "+1", "TT": "+1", "AG": "+1", "DM": "+1",
+	"GD": "+1", "KN": "+1", "LC": "+1", "VC": "+1",
+
+	// 南美洲
+	"BR": "+55", "AR": "+54", "CL": "+56", "CO": "+57",
+	"PE": "+51", "VE": "+58", "EC": "+593", "BO": "+591",
+	"PY": "+595", "UY": "+598", "GY": "+592", "SR": "+597",
+
+	// 大洋洲
+	"AU": "+61", "NZ": "+64", "FJ": "+679", "PG": "+675",
+	"SB": "+677", "VU": "+678",
+	"WS": "+685", "TO": "+676", "KI": "+686", "FM": "+691",
+	"MH": "+692", "PW": "+680", "NR": "+674", "TV": "+688",
+
+	// 非洲
+	"EG": "+20", "ZA": "+27", "NG": "+234", "KE": "+254",
+	"ET": "+251", "TZ": "+255", "UG": "+256", "DZ": "+213",
+	"MA": "+212", "TN": "+216", "LY": "+218", "SD": "+249",
+	"SS": "+211", "GH": "+233", "CI": "+225", "SN": "+221",
+	"CM": "+237", "AO": "+244", "MZ": "+258", "MG": "+261",
+	"ZW": "+263", "ZM": "+260", "MW": "+265", "BW": "+267",
+	"NA": "+264", "LS": "+266", "SZ": "+268", "MU": "+230",
+	"SC": "+248", "RW": "+250", "BI": "+257", "DJ": "+253",
+	"ER": "+291", "SO": "+252", "GA": "+241", "CG": "+242",
+	"CD": "+243", "CF": "+236", "TD": "+235", "NE": "+227",
+	"ML": "+223", "BF": "+226", "SL": "+232", "LR": "+231",
+	"GM": "+220", "GN": "+224", "GW": "+245", "MR": "+222",
+	"BJ": "+229", "TG": "+228", "GQ": "+240", "CV": "+238",
+	"ST": "+239", "KM": "+269",
+}
+
+type addressRule struct {
+	required        []string
+	postcodePattern string
+	postcodeExample string
+}
+
+// addressRules 有明确地址格式的国家/地区；未列出的国家只校验通用邮编字符
+// 邮编未列入 required 时为选填，但填写后仍需符合格式
+var addressRules = map[string]addressRule{
+	"CN": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldDistrict}, `^\d{6}$`, "100000"},
+	"TW": {[]string{AddressFieldCity}, `^\d{3}(\d{2,3})?$`, "100"},
+	"US": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}(-\d{4})?$`, "94105"},
+	"CA": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^[A-Z]\d[A-Z] ?\d[A-Z]\d$`, "K1A 0B1"},
+	"GB": {[]string{AddressFieldCity, AddressFieldPostcode}, `^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`, "SW1A 1AA"},
+	"JP": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{3}-?\d{4}$`, "100-0001"},
+	"KR": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "03187"},
+	"SG": {[]string{AddressFieldPostcode}, `^\d{6}$`, "018956"},
+	"AU": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "2000"},
+	"NZ": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "6011"},
+	"MY": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "50000"},
+	"TH": {[]string{AddressFieldProvince, AddressFieldPostcode}, `^\d{5}$`, "10200"},
+	"VN": {[]string{AddressFieldProvince}, `^\d{6}$`, "100000"},
+	"ID": {[]string{AddressFieldProvince, AddressFieldCity}, `^\d{5}$`, "10110"},
+	"PH": {[]string{AddressFieldProvince, AddressFieldCity}, `^\d{4}$`, "1000"},
+	"IN": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{6}$`, "110001"},
+	"IL": {[]string{AddressFieldCity}, `^\d{5}(\d{2})?$`, "9100001"},
+	"SA": {[]string{AddressFieldCity}, `^\d{5}(-\d{4})?$`, "11564"},
+	"TR": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "06100"},
+	"DE": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "10115"},
+	"FR": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "75001"},
+	"IT": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "00118"},
+	"ES": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "28001"},
+	"PT": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}-\d{3}$`, "1000-001"},
+	"NL": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4} ?[A-Z]{2}$`, "1011 AB"},
+	"BE": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "1000"},
+	"CH": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "8001"},
+	"AT": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "1010"},
+	"SE": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{3} ?\d{2}$`, "114 55"},
+	"NO": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "0150"},
+	"DK": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "1050"},
+	"FI": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "00100"},
+	"PL": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{2}-\d{3}$`, "00-001"},
+	"CZ": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{3} ?\d{2}$`, "110 00"},
+	"IE": {[]string{AddressFieldCity}, `^[A-Z]\d[\dW] ?[A-Z\d]{4}$`, "D02 X285"},
+	"RU": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{6}$`, "101000"},
+	"BR": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}-?\d{3}$`, "01310-100"},
+	"MX": {[]string{AddressFieldProvince, AddressFieldCity, AddressFieldPostcode}, `^\d{5}$`, "06000"},
+	"AR": {[]string{AddressFieldProvince, AddressFieldCity}, `^([A-Z]\d{4}[A-Z]{3}|\d{4})$`, "C1002AAA"},
+	"ZA": {[]string{AddressFieldCity, AddressFieldPostcode}, `^\d{4}$`, "8001"},
+}
+
+// postcodeRegexps 预编译的邮编正则
+var postcodeRegexps = func() map[string]*regexp.Regexp {
+	compiled := make(map[string]*regexp.Regexp, len(addressRules))
+	for code, rule := range addressRules {
+		if rule.postcodePattern != "" {
+			compiled[code] = regexp.MustCompile(rule.postcodePattern)
+		}
+	}
+	return compiled
+}()
+
+// GetAddressSchema 根据国家代码get地址格式，不在国家列表中时返回 false
+func GetAddressSchema(code string) (AddressSchema, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if GetCountryByCode(code) == nil {
+		return AddressSchema{}, false
+	}
+	rule := addressRules[code]
+	required := rule.required
+	if required == nil {
+		required = []string{}
+	}
+	return AddressSchema{
+		Code:            code,
+		PhoneCode:       phoneCodes[code],
+		RequiredFields:  required,
+		PostcodePattern: rule.postcodePattern,
+		PostcodeExample: rule.postcodeExample,
+	}, true
+}
+
+// AddressSchemas 按国家列表顺序返回全部地址格式
+func AddressSchemas() []AddressSchema {
+	schemas := make([]AddressSchema, 0, len(Countries))
+	for _, country := range Countries {
+		if schema, ok := GetAddressSchema(country.Code); ok {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas
+}
+
+// NormalizePostcode 邮编统一去除首尾空白并转为大写
+func NormalizePostcode(postcode string) string {
+	return strings.ToUpper(strings.TrimSpace(postcode))
+}
+
+// MatchPostcode 校验邮编是否符合国家格式，未定义格式的国家始终返回 true
+func MatchPostcode(code, postcode string) bool {
+	re, ok := postcodeRegexps[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return true
+	}
+	return re.MatchString(NormalizePostcode(postcode))
+}
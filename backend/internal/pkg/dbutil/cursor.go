@@ -0,0 +1,72 @@
+package dbutil
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/pkg/bizerr"
+
+	"gorm.io/gorm"
+)
+
+// Cursor is the position after the last row of a keyset page ordered by created_at DESC, id DESC.
+// A nil cursor means the first page.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// EncodeCursor builds the opaque cursor token handed to clients.
+func EncodeCursor(createdAt time.Time, id uint) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + strconv.FormatUint(uint64(id), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token produced by EncodeCursor. An empty token returns nil (first page);
+// malformed tokens return common.invalidCursor.
+func DecodeCursor(token string) (*Cursor, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, bizerr.CodeInvalidCursor.Wrap(err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, bizerr.CodeInvalidCursor.Wrap(fmt.Errorf("cursor %q has no id", raw))
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, bizerr.CodeInvalidCursor.Wrap(err)
+	}
+	rowID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || rowID == 0 {
+		return nil, bizerr.CodeInvalidCursor.Wrap(fmt.Errorf("cursor %q has invalid id", raw))
+	}
+	return &Cursor{CreatedAt: time.Unix(0, unixNano), ID: uint(rowID)}, nil
+}
+
+// ApplyCursor restricts query to rows after cursor and orders it by created_at DESC, id DESC.
+// It fetches limit+1 rows so CursorPage can tell whether another page exists without counting.
+func ApplyCursor(query *gorm.DB, cursor *Cursor, limit int) *gorm.DB {
+	if cursor != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+	return query.Order("created_at DESC").Order("id DESC").Limit(limit + 1)
+}
+
+// CursorPage trims the extra row fetched by ApplyCursor and returns the cursor for the next page,
+// or "" on the last page.
+func CursorPage[T any](items []T, limit int, key func(item T) (time.Time, uint)) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	createdAt, id := key(items[limit-1])
+	return items, EncodeCursor(createdAt, id)
+}
@@ -0,0 +1,72 @@
+package dbutil
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/pkg/bizerr"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type cursorTestRow struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func TestCursorPagesThroughRowsWithEqualTimestamps(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:dbutil_cursor?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&cursorTestRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	base := time.Date(2026, 10, 17, 12, 0, 0, 123456000, time.Local)
+	// 同一时间戳的多行需要靠 id 决定顺序，否则翻页会重复或遗漏
+	for i := 0; i < 7; i++ {
+		row := cursorTestRow{CreatedAt: base.Add(time.Duration(i/3) * time.Second)}
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatalf("seed row: %v", err)
+		}
+	}
+
+	key := func(row cursorTestRow) (time.Time, uint) { return row.CreatedAt, row.ID }
+	var seen []uint
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatalf("cursor pagination did not terminate, seen %v", seen)
+		}
+		after, err := DecodeCursor(cursor)
+		if err != nil {
+			t.Fatalf("decode cursor %q: %v", cursor, err)
+		}
+		var rows []cursorTestRow
+		if err := ApplyCursor(db.Model(&cursorTestRow{}), after, 3).Find(&rows).Error; err != nil {
+			t.Fatalf("query page: %v", err)
+		}
+		rows, cursor = CursorPage(rows, 3, key)
+		for _, row := range rows {
+			seen = append(seen, row.ID)
+		}
+		if cursor == "" {
+			break
+		}
+	}
+
+	expected := []uint{7, 6, 5, 4, 3, 2, 1}
+	if len(seen) != len(expected) {
+		t.Fatalf("expected ids %v, got %v", expected, seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("expected ids %v, got %v", expected, seen)
+		}
+	}
+
+	if _, err := DecodeCursor("not-a-cursor"); !bizerr.CodeInvalidCursor.Is(err) {
+		t.Fatalf("expected invalid cursor error, got %v", err)
+	}
+}
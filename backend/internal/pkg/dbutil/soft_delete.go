@@ -0,0 +1,43 @@
+package dbutil
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SoftDelete moves a row with deleted_at/deleted_by columns into the trash and records who deleted it.
+// deletedBy == 0 leaves deleted_by empty (system deletions). Returns gorm.ErrRecordNotFound when
+// the row does not exist or is already deleted.
+func SoftDelete(db *gorm.DB, model interface{}, id uint, deletedBy uint) error {
+	var actor interface{}
+	if deletedBy > 0 {
+		actor = deletedBy
+	}
+	result := db.Model(model).Where("id = ?", id).Updates(map[string]interface{}{
+		"deleted_at": time.Now(),
+		"deleted_by": actor,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Restore clears deleted_at/deleted_by on a soft-deleted row. Returns gorm.ErrRecordNotFound when
+// the row does not exist or is not deleted.
+func Restore(db *gorm.DB, model interface{}, id uint) error {
+	result := db.Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
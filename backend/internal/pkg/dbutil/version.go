@@ -0,0 +1,43 @@
+package dbutil
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned by UpdateWithVersion when the row was changed after the caller read it.
+var ErrVersionConflict = errors.New("dbutil: version conflict")
+
+// UpdateWithVersion applies updates to a row with a version column and increments the version in the
+// same statement, but only while the stored version still equals expectedVersion. expectedVersion == 0
+// skips the check for callers that did not send a version. Returns gorm.ErrRecordNotFound when the row
+// does not exist and ErrVersionConflict when its version has moved on.
+func UpdateWithVersion(db *gorm.DB, model interface{}, id uint, expectedVersion uint, updates map[string]interface{}) error {
+	values := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		values[column] = value
+	}
+	values["version"] = gorm.Expr("version + 1")
+
+	query := db.Model(model).Where("id = ?", id)
+	if expectedVersion > 0 {
+		query = query.Where("version = ?", expectedVersion)
+	}
+	result := query.Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return ErrVersionConflict
+}
@@ -0,0 +1,178 @@
+// Package fieldcrypt 提供敏感字段的静态加密（AES-256-GCM）。
+// 密文格式为 "enc:v1:" + base64(nonce|ciphertext)，未带前缀的值视为历史明文原样返回。
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"auralogic/internal/config"
+)
+
+const (
+	// KeyEnv 由 secrets manager 注入的密钥环境变量，优先级最高
+	KeyEnv = "AURALOGIC_DATA_ENCRYPTION_KEY"
+
+	encryptedPrefix = "enc:v1:"
+
+	// hashKeyLabel 从数据加密密钥派生哈希子密钥的标签，避免加密与哈希共用同一把密钥
+	hashKeyLabel = "auralogic/fieldcrypt/hash/v1"
+)
+
+var (
+	ErrKeyNotConfigured = errors.New("data encryption key is not configured")
+
+	mu      sync.RWMutex
+	aead    cipher.AEAD
+	hashKey []byte
+)
+
+// Init 按 环境变量 > 密钥文件 > 配置 的顺序加载密钥；均未配置时保持明文存储
+func Init(cfg *config.DataEncryptionConfig) error {
+	raw := strings.TrimSpace(os.Getenv(KeyEnv))
+	if raw == "" && cfg != nil && strings.TrimSpace(cfg.KeyFile) != "" {
+		content, err := os.ReadFile(strings.TrimSpace(cfg.KeyFile))
+		if err != nil {
+			return fmt.Errorf("read data encryption key file: %w", err)
+		}
+		raw = strings.TrimSpace(string(content))
+	}
+	if raw == "" && cfg != nil {
+		raw = strings.TrimSpace(cfg.Key)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if raw == "" {
+		aead = nil
+		hashKey = nil
+		return nil
+	}
+
+	key, err := decodeKey(raw)
+	if err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("init data encryption cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("init data encryption cipher: %w", err)
+	}
+	aead = gcm
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(hashKeyLabel))
+	hashKey = mac.Sum(nil)
+	return nil
+}
+
+// decodeKey 支持 base64 或 hex 编码的 32 字节密钥
+func decodeKey(raw string) ([]byte, error) {
+	if key, err := base64.StdEncoding.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, errors.New("data encryption key must be 32 bytes encoded as base64 or hex")
+}
+
+// Enabled 是否已配置密钥
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return aead != nil
+}
+
+// IsEncrypted 判断值是否为本包生成的密文
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt 加密明文；未配置密钥或值已加密时原样返回
+func Encrypt(plain string) (string, error) {
+	mu.RLock()
+	gcm := aead
+	mu.RUnlock()
+
+	if gcm == nil || plain == "" || IsEncrypted(plain) {
+		return plain, nil
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := crand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plain), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密密文；历史明文原样返回
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	mu.RLock()
+	gcm := aead
+	mu.RUnlock()
+	if gcm == nil {
+		return "", ErrKeyNotConfigured
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt ciphertext: %w", err)
+	}
+	return string(plain), nil
+}
+
+// Hash 计算用于等值查找的内容哈希：配置密钥后为 HMAC-SHA256（子密钥由数据加密密钥派生），
+// 拿到数据库备份也无法对短卡密暴力枚举；未配置密钥时内容本身即为明文存储，退化为 SHA-256
+func Hash(value string) string {
+	mu.RLock()
+	key := hashKey
+	mu.RUnlock()
+
+	if key == nil {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HashKeyID 当前哈希密钥的标识，不泄露密钥本身；哈希密钥变化后需按新密钥重算已存储的哈希
+func HashKeyID() string {
+	mu.RLock()
+	key := hashKey
+	mu.RUnlock()
+
+	if key == nil {
+		return "sha256"
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("key-id"))
+	return "hmac-" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
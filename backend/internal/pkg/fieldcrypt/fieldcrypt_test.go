@@ -0,0 +1,117 @@
+package fieldcrypt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+)
+
+func testKey() string {
+	return base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	t.Setenv(KeyEnv, "")
+	if err := Init(&config.DataEncryptionConfig{Key: testKey()}); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	t.Cleanup(func() { _ = Init(nil) })
+
+	sealed, err := Encrypt("CARD-0001")
+	if err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	if !IsEncrypted(sealed) || strings.Contains(sealed, "CARD-0001") {
+		t.Fatalf("expected ciphertext, got %q", sealed)
+	}
+	again, _ := Encrypt("CARD-0001")
+	if again == sealed {
+		t.Fatalf("expected random nonce to produce different ciphertexts")
+	}
+	if twice, _ := Encrypt(sealed); twice != sealed {
+		t.Fatalf("already encrypted values must not be re-encrypted")
+	}
+
+	plain, err := Decrypt(sealed)
+	if err != nil || plain != "CARD-0001" {
+		t.Fatalf("decrypt = %q, %v", plain, err)
+	}
+	if legacy, err := Decrypt("LEGACY-PLAIN"); err != nil || legacy != "LEGACY-PLAIN" {
+		t.Fatalf("legacy plaintext should pass through, got %q, %v", legacy, err)
+	}
+	if _, err := Decrypt(sealed[:len(sealed)-4] + "AAAA"); err == nil {
+		t.Fatalf("expected tampered ciphertext to fail")
+	}
+}
+
+func TestInitKeySources(t *testing.T) {
+	t.Cleanup(func() { _ = Init(nil) })
+
+	t.Setenv(KeyEnv, "")
+	if err := Init(nil); err != nil || Enabled() {
+		t.Fatalf("expected encryption disabled without key, err=%v", err)
+	}
+	if value, _ := Encrypt("plain"); value != "plain" {
+		t.Fatalf("expected passthrough without key, got %q", value)
+	}
+
+	if err := Init(&config.DataEncryptionConfig{Key: "too-short"}); err == nil {
+		t.Fatalf("expected invalid key error")
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "data_key")
+	if err := os.WriteFile(keyFile, []byte(testKey()+"\n"), 0o600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+	if err := Init(&config.DataEncryptionConfig{KeyFile: keyFile}); err != nil || !Enabled() {
+		t.Fatalf("expected key file to enable encryption, err=%v", err)
+	}
+	sealed, _ := Encrypt("CARD")
+
+	// 环境变量优先于配置，换成不同密钥后旧密文无法解密
+	t.Setenv(KeyEnv, strings.Repeat("ab", 32))
+	if err := Init(&config.DataEncryptionConfig{KeyFile: keyFile}); err != nil {
+		t.Fatalf("init from env failed: %v", err)
+	}
+	if _, err := Decrypt(sealed); err == nil {
+		t.Fatalf("expected env key to take precedence over key file")
+	}
+}
+
+func TestHashIsKeyedWhenKeyConfigured(t *testing.T) {
+	t.Setenv(KeyEnv, "")
+	if err := Init(nil); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	t.Cleanup(func() { _ = Init(nil) })
+
+	sum := sha256.Sum256([]byte("CARD-0001"))
+	plain := Hash("CARD-0001")
+	if plain != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected unkeyed hash %q", plain)
+	}
+	if HashKeyID() != "sha256" {
+		t.Fatalf("expected unkeyed hash id, got %q", HashKeyID())
+	}
+
+	if err := Init(&config.DataEncryptionConfig{Key: testKey()}); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	keyed := Hash("CARD-0001")
+	if len(keyed) != 64 || keyed == plain {
+		t.Fatalf("expected keyed hash to differ from plain SHA-256, got %q", keyed)
+	}
+	if Hash("CARD-0001") != keyed || Hash("CARD-0002") == keyed {
+		t.Fatalf("expected keyed hash to be deterministic per value")
+	}
+	id := HashKeyID()
+	if !strings.HasPrefix(id, "hmac-") || strings.Contains(id, testKey()) {
+		t.Fatalf("unexpected keyed hash id %q", id)
+	}
+}
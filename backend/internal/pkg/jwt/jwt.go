@@ -8,11 +8,20 @@ import (
 	"auralogic/internal/config"
 )
 
+// 令牌作用域：空值为完整会话；两步验证未完成时签发受限令牌，只能访问对应的两步验证接口
+const (
+	ScopeFull              = ""
+	ScopeTwoFactorPending  = "2fa_pending" // 已通过密码等第一步验证，等待输入两步验证码
+	ScopeTwoFactorSetup    = "2fa_setup"   // 管理员要求开启两步验证，需先完成绑定
+	TwoFactorScopeLifetime = 10 * time.Minute
+)
+
 // Claims JWT声明
 type Claims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	Scope  string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -25,13 +34,19 @@ func InitJWT(cfg *config.JWTConfig) {
 
 // GenerateToken generateJWTToken
 func GenerateToken(userID uint, email, role string, expireHours int) (string, error) {
+	return GenerateScopedToken(userID, email, role, ScopeFull, time.Duration(expireHours)*time.Hour)
+}
+
+// GenerateScopedToken 生成指定作用域与有效期的令牌
+func GenerateScopedToken(userID uint, email, role, scope string, lifetime time.Duration) (string, error) {
 	now := time.Now()
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
+		Scope:  scope,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expireHours) * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
@@ -0,0 +1,100 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RoundingNone    = "none"
+	RoundingNearest = "nearest"
+	RoundingUp      = "up"
+	RoundingDown    = "down"
+	// RoundingCharm picks the closest price of the form k*Increment - Ending, e.g. x.99.
+	RoundingCharm = "charm"
+)
+
+// zeroDecimalCurrencies have no minor unit. Amounts are still stored with CurrencyScale
+// (as MinorToString formats them), so they round to whole multiples of CurrencyScale.
+var zeroDecimalCurrencies = map[string]bool{
+	"JPY": true, "KRW": true, "VND": true, "CLP": true, "ISK": true,
+	"PYG": true, "UGX": true, "XAF": true, "XOF": true,
+}
+
+func IsZeroDecimalCurrency(currency string) bool {
+	return zeroDecimalCurrencies[strings.ToUpper(strings.TrimSpace(currency))]
+}
+
+// RoundingRule is a per-currency price rounding rule. Increment and Ending are in minor units.
+type RoundingRule struct {
+	Mode      string
+	Increment int64
+	Ending    int64
+}
+
+// Normalize fills defaults for currency. Zero-decimal currencies always round to at least
+// one whole unit, even when no rule is configured.
+func (r RoundingRule) Normalize(currency string) RoundingRule {
+	r.Mode = strings.ToLower(strings.TrimSpace(r.Mode))
+	switch r.Mode {
+	case RoundingNearest, RoundingUp, RoundingDown, RoundingCharm:
+	default:
+		r.Mode = RoundingNone
+	}
+	if r.Increment <= 0 {
+		r.Increment = 1
+	}
+	if IsZeroDecimalCurrency(currency) {
+		if r.Mode == RoundingNone {
+			r.Mode = RoundingNearest
+		}
+		if r.Increment%CurrencyScale != 0 {
+			r.Increment = (r.Increment/CurrencyScale + 1) * CurrencyScale
+		}
+	}
+	if r.Mode == RoundingNone {
+		r.Increment = 1
+	}
+	if r.Mode != RoundingCharm || r.Ending < 0 || r.Ending >= r.Increment {
+		r.Ending = 0
+	}
+	return r
+}
+
+// String describes the rule for audit records, e.g. "charm/100-1" or "nearest/500".
+func (r RoundingRule) String() string {
+	switch r.Mode {
+	case RoundingNone:
+		return r.Mode
+	case RoundingCharm:
+		return fmt.Sprintf("%s/%d-%d", r.Mode, r.Increment, r.Ending)
+	default:
+		return fmt.Sprintf("%s/%d", r.Mode, r.Increment)
+	}
+}
+
+// Round applies a normalized rule. Non-positive amounts are returned unchanged,
+// and charm rounding never goes below the first charm price.
+func (r RoundingRule) Round(amountMinor int64) int64 {
+	if amountMinor <= 0 || r.Mode == RoundingNone || r.Increment <= 1 {
+		return amountMinor
+	}
+	switch r.Mode {
+	case RoundingUp:
+		return (amountMinor + r.Increment - 1) / r.Increment * r.Increment
+	case RoundingDown:
+		return amountMinor / r.Increment * r.Increment
+	case RoundingCharm:
+		rounded := roundToNearest(amountMinor+r.Ending, r.Increment) - r.Ending
+		if rounded <= 0 {
+			return r.Increment - r.Ending
+		}
+		return rounded
+	default:
+		return roundToNearest(amountMinor, r.Increment)
+	}
+}
+
+func roundToNearest(amount, increment int64) int64 {
+	return (amount + increment/2) / increment * increment
+}
@@ -0,0 +1,48 @@
+// Package objectstore provides a small pluggable storage layer for user uploaded files,
+// backed by the local disk or any S3-compatible service.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ErrNotFound is returned by Open when the object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is the minimal set of operations the application needs from a storage backend.
+// Keys are slash separated relative paths such as "tickets/12/uuid.png".
+type Store interface {
+	Driver() string
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Presigner is implemented by backends that can hand out time limited download URLs
+// which clients fetch directly from the backend.
+type Presigner interface {
+	PresignGet(key string, expires time.Duration, downloadName string) (string, error)
+}
+
+// ValidateKey rejects empty, absolute and parent-relative keys.
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return errors.New("invalid object key")
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return errors.New("invalid object key")
+		}
+	}
+	return nil
+}
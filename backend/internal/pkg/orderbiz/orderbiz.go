@@ -1,8 +1,11 @@
 package orderbiz
 
 import (
+	"strings"
+
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/constants"
 )
 
 func InvalidOrderID() *bizerr.Error {
@@ -23,6 +26,10 @@ func AdminRemarkTooLong(max int) *bizerr.Error {
 		WithParams(map[string]interface{}{"max": max})
 }
 
+func AdminRemarkRequired() *bizerr.Error {
+	return bizerr.New("order.adminRemarkRequired", "Admin remark cannot be empty")
+}
+
 func CancellationReasonTooLong(max int) *bizerr.Error {
 	return bizerr.Newf("order.cancellationReasonTooLong", "Cancellation reason length cannot exceed %d characters", max).
 		WithParams(map[string]interface{}{"max": max})
@@ -38,6 +45,11 @@ func RefundStatusInvalid(status models.OrderStatus) *bizerr.Error {
 		WithParams(map[string]interface{}{"status": status})
 }
 
+func RefundedAmountExceeded(refunded, refundable int64) *bizerr.Error {
+	return bizerr.Newf("order.refundedAmountExceeded", "Refunded amount (%d) exceeds the refundable amount of this order (%d)", refunded, refundable).
+		WithParams(map[string]interface{}{"refunded": refunded, "refundable": refundable})
+}
+
 func RefundFinalizeStatusInvalid(status models.OrderStatus) *bizerr.Error {
 	return bizerr.Newf("order.refundFinalizeStatusInvalid", "Current order status does not support refund finalization (current status: %s)", status).
 		WithParams(map[string]interface{}{"status": status})
@@ -107,6 +119,48 @@ func PostcodeInvalid() *bizerr.Error {
 	return bizerr.New("order.postcodeInvalid", "Invalid postal code format or length")
 }
 
+func AddressFieldRequired(field, country string) *bizerr.Error {
+	key, label := "order.postcodeRequired", "Postal code"
+	switch field {
+	case constants.AddressFieldProvince:
+		key, label = "order.provinceRequired", "Province/state"
+	case constants.AddressFieldCity:
+		key, label = "order.cityRequired", "City"
+	case constants.AddressFieldDistrict:
+		key, label = "order.districtRequired", "District"
+	}
+	return bizerr.Newf(key, "%s is required for addresses in %s", label, country).
+		WithParams(map[string]interface{}{"country": country})
+}
+
+func PostcodeFormatInvalid(country, example string) *bizerr.Error {
+	return bizerr.Newf("order.postcodeFormatInvalid", "Invalid postal code for %s (e.g. %s)", country, example).
+		WithParams(map[string]interface{}{"country": country, "example": example})
+}
+
+// ValidateReceiverAddress 按国家地址格式校验必填字段与邮编，未收录的国家不做额外校验
+func ValidateReceiverAddress(country, province, city, district, postcode string) *bizerr.Error {
+	schema, ok := constants.GetAddressSchema(country)
+	if !ok {
+		return nil
+	}
+	values := map[string]string{
+		constants.AddressFieldProvince: province,
+		constants.AddressFieldCity:     city,
+		constants.AddressFieldDistrict: district,
+		constants.AddressFieldPostcode: postcode,
+	}
+	for _, field := range schema.RequiredFields {
+		if strings.TrimSpace(values[field]) == "" {
+			return AddressFieldRequired(field, schema.Code)
+		}
+	}
+	if strings.TrimSpace(postcode) != "" && !constants.MatchPostcode(schema.Code, postcode) {
+		return PostcodeFormatInvalid(schema.Code, schema.PostcodeExample)
+	}
+	return nil
+}
+
 func ResubmitReasonLengthInvalid(min, max int) *bizerr.Error {
 	return bizerr.Newf("order.resubmitReasonLengthInvalid", "Resubmit reason length must be between %d-%d characters", min, max).
 		WithParams(map[string]interface{}{"min": min, "max": max})
@@ -135,6 +189,16 @@ func TotalAmountNegative() *bizerr.Error {
 	return bizerr.New("order.totalAmountNegative", "Total amount cannot be negative")
 }
 
+func PaymentActionInvalid(action string) *bizerr.Error {
+	return bizerr.Newf("order.paymentActionInvalid", "Invalid payment action: %s", action).
+		WithParams(map[string]interface{}{"action": action})
+}
+
+func PaymentActionStatusInvalid(status string) *bizerr.Error {
+	return bizerr.Newf("order.paymentActionStatusInvalid", "Payment action requires a pending payment order, got status %s", status).
+		WithParams(map[string]interface{}{"status": status})
+}
+
 func ExternalUserIDLengthInvalid(min, max int) *bizerr.Error {
 	return bizerr.Newf("order.externalUserIDLengthInvalid", "External user ID length must be between %d-%d characters", min, max).
 		WithParams(map[string]interface{}{"min": min, "max": max})
@@ -159,3 +223,28 @@ func OrderRemarkTooLong(max int) *bizerr.Error {
 	return bizerr.Newf("order.orderRemarkTooLong", "Order remark length cannot exceed %d characters", max).
 		WithParams(map[string]interface{}{"max": max})
 }
+
+func VirtualRevealRateLimited(maxPerHour int) *bizerr.Error {
+	return bizerr.Newf("order.virtualRevealRateLimited", "Virtual products of this order can be viewed at most %d times per hour, please try again later", maxPerHour).
+		WithParams(map[string]interface{}{"max": maxPerHour})
+}
+
+func VirtualRevealPasswordInvalid() *bizerr.Error {
+	return bizerr.New("order.virtualRevealPasswordInvalid", "Incorrect password")
+}
+
+func VirtualRevealPasswordUnavailable() *bizerr.Error {
+	return bizerr.New("order.virtualRevealPasswordUnavailable", "This account has no password, please verify with an email code")
+}
+
+func VirtualRevealCodeUnavailable() *bizerr.Error {
+	return bizerr.New("order.virtualRevealCodeUnavailable", "This account has no email address, please verify with your password")
+}
+
+func ExportFormatInvalid() *bizerr.Error {
+	return bizerr.New("order.exportFormatInvalid", "Export format must be csv or xlsx")
+}
+
+func ExportDateRangeInvalid() *bizerr.Error {
+	return bizerr.New("order.exportDateRangeInvalid", "Invalid date range, expected YYYY-MM-DD with start not after end")
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,6 +36,19 @@ type PaginatedResponse struct {
 	Pagination Pagination  `json:"pagination"`
 }
 
+// CursorPagination 游标分页Info，不统计总数
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"`
+	HasNext    bool   `json:"has_next"`
+}
+
+// CursorPaginatedResponse 游标分页响应
+type CursorPaginatedResponse struct {
+	Items      interface{}      `json:"items"`
+	Pagination CursorPagination `json:"pagination"`
+}
+
 // Error码定义
 const (
 	CodeSuccess            = 0
@@ -96,6 +110,26 @@ func ValidationError(c *gin.Context, errors interface{}) {
 	})
 }
 
+// BindingError 请求绑定失败时返回字段级校验错误，
+// 首个错误同时以 error_key / params 返回，前端按 bizError 方式直接翻译
+func BindingError(c *gin.Context, err error) {
+	fieldErrors := validator.BindingErrors(err)
+	resp := Response{
+		Code:    CodeParamError,
+		Message: "Validation failed",
+		Errors:  fieldErrors,
+	}
+	if len(fieldErrors) > 0 {
+		first := fieldErrors[0]
+		resp.Message = first.Message
+		resp.Data = gin.H{
+			"error_key": first.Key,
+			"params":    first.Params(),
+		}
+	}
+	c.JSON(http.StatusBadRequest, resp)
+}
+
 // GetPagination 从请求中解析并校验分页参数
 func GetPagination(c *gin.Context) (page, limit int) {
 	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -131,6 +165,23 @@ func Paginated(c *gin.Context, items interface{}, page, limit int, total int64)
 	})
 }
 
+// GetCursor 读取游标分页参数：请求带 cursor 参数（首页传空值）时启用游标模式，否则沿用 page/limit
+func GetCursor(c *gin.Context) (cursor string, enabled bool) {
+	return c.GetQuery("cursor")
+}
+
+// CursorPaginated 游标分页响应，nextCursor 为空表示已到最后一页
+func CursorPaginated(c *gin.Context, items interface{}, limit int, nextCursor string) {
+	Success(c, CursorPaginatedResponse{
+		Items: items,
+		Pagination: CursorPagination{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			HasNext:    nextCursor != "",
+		},
+	})
+}
+
 // Unauthorized 未授权
 func Unauthorized(c *gin.Context, message string) {
 	if message == "" {
@@ -176,7 +227,12 @@ func InternalServerError(c *gin.Context, userMessage string, err error) {
 
 // BizError 业务逻辑错误响应（限购、库存不足等），携带 i18n key 和参数
 func BizError(c *gin.Context, message string, key string, params map[string]interface{}) {
-	c.JSON(http.StatusBadRequest, Response{
+	BizErrorWithStatus(c, http.StatusBadRequest, message, key, params)
+}
+
+// BizErrorWithStatus 使用指定 HTTP 状态码的业务错误响应（如 404 资源不存在、409 冲突）
+func BizErrorWithStatus(c *gin.Context, status int, message string, key string, params map[string]interface{}) {
+	c.JSON(status, Response{
 		Code:    CodeBusinessError,
 		Message: message,
 		Data: gin.H{
@@ -186,11 +242,25 @@ func BizError(c *gin.Context, message string, key string, params map[string]inte
 	})
 }
 
-// HandleError 统一错误处理：业务错误返回详细信息，其他错误仅记录日志并返回通用提示
+// RespondBizError 按错误码的 HTTP 状态码提示返回业务错误；5xx 记录原始错误，不向前端暴露
+func RespondBizError(c *gin.Context, bizErr *bizerr.Error) {
+	status := bizErr.HTTPStatus()
+	if status >= http.StatusInternalServerError && bizErr.Cause() != nil {
+		log.Printf("[ERROR] %s %s: %s - %v", c.Request.Method, c.Request.URL.Path, bizErr.Key, bizErr.Cause())
+	}
+	BizErrorWithStatus(c, status, bizErr.Message, bizErr.Key, bizErr.Params)
+}
+
+// HandleError 统一错误处理：业务错误返回详细信息；记录不存在、唯一约束冲突等存储层错误转换为对应业务错误；
+// 其他错误仅记录日志并返回通用提示
 func HandleError(c *gin.Context, fallbackMsg string, err error) {
 	var bizErr *bizerr.Error
 	if errors.As(err, &bizErr) {
-		BizError(c, bizErr.Message, bizErr.Key, bizErr.Params)
+		RespondBizError(c, bizErr)
+		return
+	}
+	if storeErr := bizerr.FromStore(err, nil); storeErr != nil && storeErr.HTTPStatus() < http.StatusInternalServerError {
+		RespondBizError(c, storeErr)
 		return
 	}
 	InternalServerError(c, fallbackMsg, err)
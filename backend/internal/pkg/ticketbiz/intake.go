@@ -0,0 +1,133 @@
+package ticketbiz
+
+import (
+	"strconv"
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/bizerr"
+)
+
+const defaultIntakeFieldMaxLength = 500
+
+func IntakeFieldRequired(label string) *bizerr.Error {
+	return bizerr.Newf("ticket.intakeFieldRequired", "%s is required", label).
+		WithParams(map[string]interface{}{"field": label})
+}
+
+func IntakeFieldInvalid(label string) *bizerr.Error {
+	return bizerr.Newf("ticket.intakeFieldInvalid", "Invalid value for %s", label).
+		WithParams(map[string]interface{}{"field": label})
+}
+
+func IntakeFieldTooLong(label string, max int) *bizerr.Error {
+	return bizerr.Newf("ticket.intakeFieldTooLong", "%s cannot exceed %d characters", label, max).
+		WithParams(map[string]interface{}{"field": label, "max": max})
+}
+
+func OrderRequired() *bizerr.Error {
+	return bizerr.New("ticket.orderRequired", "Please select the related order")
+}
+
+func AttachmentRequired(min int) *bizerr.Error {
+	return bizerr.Newf("ticket.attachmentRequired", "Please upload at least %d screenshot(s)", min).
+		WithParams(map[string]interface{}{"min": min})
+}
+
+func AttachmentInvalid() *bizerr.Error {
+	return bizerr.New("ticket.attachmentInvalid", "Invalid attachment")
+}
+
+// FindTopic 按分类查找主题模板，未配置时返回 nil
+func FindTopic(topics []config.TicketTopicConfig, category string) *config.TicketTopicConfig {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return nil
+	}
+	for i := range topics {
+		if strings.EqualFold(strings.TrimSpace(topics[i].Category), category) {
+			return &topics[i]
+		}
+	}
+	return nil
+}
+
+// ValidateIntakeFields 按主题模板校验并规范化结构化字段，未定义的字段会被丢弃
+func ValidateIntakeFields(topic *config.TicketTopicConfig, values map[string]string) (map[string]string, error) {
+	if topic == nil || len(topic.Fields) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(topic.Fields))
+	for _, field := range topic.Fields {
+		key := strings.TrimSpace(field.Key)
+		if key == "" {
+			continue
+		}
+		label := strings.TrimSpace(field.Label)
+		if label == "" {
+			label = key
+		}
+
+		value := strings.TrimSpace(values[key])
+		if value == "" {
+			if field.Required {
+				return nil, IntakeFieldRequired(label)
+			}
+			continue
+		}
+
+		maxLength := field.MaxLength
+		if maxLength <= 0 {
+			maxLength = defaultIntakeFieldMaxLength
+		}
+		if len([]rune(value)) > maxLength {
+			return nil, IntakeFieldTooLong(label, maxLength)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(field.Type)) {
+		case "select":
+			matched := false
+			for _, option := range field.Options {
+				if value == strings.TrimSpace(option) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, IntakeFieldInvalid(label)
+			}
+		case "number":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, IntakeFieldInvalid(label)
+			}
+		}
+
+		result[key] = value
+	}
+	return result, nil
+}
+
+// FormatIntakeSummary 将结构化字段渲染为 Markdown 摘要，便于客服在消息流中直接查看
+func FormatIntakeSummary(topic *config.TicketTopicConfig, values map[string]string) string {
+	if topic == nil || len(values) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	for _, field := range topic.Fields {
+		value, ok := values[strings.TrimSpace(field.Key)]
+		if !ok {
+			continue
+		}
+		label := strings.TrimSpace(field.Label)
+		if label == "" {
+			label = field.Key
+		}
+		builder.WriteString("- **")
+		builder.WriteString(label)
+		builder.WriteString("**: ")
+		builder.WriteString(value)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,88 @@
+package ticketbiz
+
+import (
+	"errors"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/bizerr"
+)
+
+func intakeErrorKey(t *testing.T, err error) string {
+	t.Helper()
+
+	var bizErr *bizerr.Error
+	if !errors.As(err, &bizErr) {
+		t.Fatalf("expected biz error, got %v", err)
+	}
+	return bizErr.Key
+}
+
+func TestValidateIntakeFields(t *testing.T) {
+	topics := []config.TicketTopicConfig{
+		{
+			Category: "Refund",
+			Fields: []config.TicketIntakeFieldConfig{
+				{Key: "issue_type", Label: "Issue type", Type: "select", Required: true, Options: []string{"damaged", "missing"}},
+				{Key: "amount", Label: "Amount", Type: "number"},
+				{Key: "note", Label: "Note", Type: "text", MaxLength: 5},
+			},
+		},
+	}
+
+	topic := FindTopic(topics, " refund ")
+	if topic == nil {
+		t.Fatalf("expected topic lookup to be case-insensitive")
+	}
+	if FindTopic(topics, "Other") != nil {
+		t.Fatalf("expected unknown category to have no topic")
+	}
+
+	values, err := ValidateIntakeFields(topic, map[string]string{
+		"issue_type": "damaged",
+		"amount":     "12.5",
+		"unexpected": "dropped",
+	})
+	if err != nil {
+		t.Fatalf("expected valid intake, got %v", err)
+	}
+	if len(values) != 2 || values["issue_type"] != "damaged" || values["amount"] != "12.5" {
+		t.Fatalf("unexpected normalized values: %#v", values)
+	}
+
+	cases := []struct {
+		name   string
+		values map[string]string
+		key    string
+	}{
+		{name: "missing required", values: map[string]string{}, key: "ticket.intakeFieldRequired"},
+		{name: "option not allowed", values: map[string]string{"issue_type": "other"}, key: "ticket.intakeFieldInvalid"},
+		{name: "not a number", values: map[string]string{"issue_type": "missing", "amount": "abc"}, key: "ticket.intakeFieldInvalid"},
+		{name: "too long", values: map[string]string{"issue_type": "missing", "note": "123456"}, key: "ticket.intakeFieldTooLong"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateIntakeFields(topic, tc.values)
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if key := intakeErrorKey(t, err); key != tc.key {
+				t.Fatalf("expected %s, got %s", tc.key, key)
+			}
+		})
+	}
+}
+
+func TestFormatIntakeSummaryFollowsFieldOrder(t *testing.T) {
+	topic := &config.TicketTopicConfig{
+		Fields: []config.TicketIntakeFieldConfig{
+			{Key: "b", Label: "Second"},
+			{Key: "a", Label: "First"},
+		},
+	}
+	got := FormatIntakeSummary(topic, map[string]string{"a": "1", "b": "2"})
+	want := "- **Second**: 2\n- **First**: 1\n"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
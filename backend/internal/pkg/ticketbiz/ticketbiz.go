@@ -58,6 +58,44 @@ func ImageFormatUnsupported() *bizerr.Error {
 	return bizerr.New("ticket.imageFormatUnsupported", "Unsupported image format")
 }
 
+func FileUploadDisabled() *bizerr.Error {
+	return bizerr.New("ticket.fileUploadDisabled", "File attachments not allowed")
+}
+
+func FileTooLarge(maxMB int64) *bizerr.Error {
+	return bizerr.Newf("ticket.fileTooLarge", "File size cannot exceed %dMB", maxMB).
+		WithParams(map[string]interface{}{"max": maxMB})
+}
+
+func FileTypeUnsupported() *bizerr.Error {
+	return bizerr.New("ticket.fileTypeUnsupported", "Unsupported file type")
+}
+
+func TooManyAttachments(max int) *bizerr.Error {
+	return bizerr.Newf("ticket.tooManyAttachments", "A message can have at most %d attachments", max).
+		WithParams(map[string]interface{}{"max": max})
+}
+
+func MessageEmpty() *bizerr.Error {
+	return bizerr.New("ticket.messageEmpty", "Message content or attachment is required")
+}
+
+func AttachmentLinkExpired() *bizerr.Error {
+	return bizerr.New("ticket.attachmentLinkExpired", "Download link is invalid or has expired")
+}
+
+func RatingInvalid() *bizerr.Error {
+	return bizerr.New("ticket.ratingInvalid", "Rating must be between 1 and 5")
+}
+
+func RatingNotAllowed() *bizerr.Error {
+	return bizerr.New("ticket.ratingNotAllowed", "Only resolved or closed tickets can be rated")
+}
+
+func AlreadyRated() *bizerr.Error {
+	return bizerr.New("ticket.alreadyRated", "This ticket has already been rated")
+}
+
 func ParseStatus(raw string) (models.TicketStatus, bool) {
 	status := models.TicketStatus(strings.ToLower(strings.TrimSpace(raw)))
 	switch status {
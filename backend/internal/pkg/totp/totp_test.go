@@ -0,0 +1,58 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 的 SHA1 测试向量（取 8 位结果的后 6 位）
+func TestCodeAtMatchesRFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		got, err := CodeAt(secret, Step(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatalf("code at %d: %v", unix, err)
+		}
+		if got != want {
+			t.Fatalf("code at %d = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestValidateAllowsSkewAndReportsStep(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	previous, _ := CodeAt(secret, Step(now)-1)
+	step, ok := Validate(secret, previous, now, 1)
+	if !ok || step != Step(now)-1 {
+		t.Fatalf("expected previous step to validate, ok=%v step=%d", ok, step)
+	}
+	old, _ := CodeAt(secret, Step(now)-3)
+	if _, ok := Validate(secret, old, now, 1); ok {
+		t.Fatalf("expected code outside skew to be rejected")
+	}
+	if _, ok := Validate(secret, "12345", now, 1); ok {
+		t.Fatalf("expected short code to be rejected")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Aura Logic", "user@example.com", "ABC")
+	if !strings.HasPrefix(uri, "otpauth://totp/Aura%20Logic:user@example.com?") ||
+		!strings.Contains(uri, "secret=ABC") || !strings.Contains(uri, "issuer=Aura+Logic") {
+		t.Fatalf("unexpected uri: %s", uri)
+	}
+}
@@ -0,0 +1,300 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+const exporterQueueSize = 8192
+
+// exporter batches finished spans and posts them to the collector from a single goroutine.
+// When the queue is full new spans are dropped instead of blocking requests.
+type exporter struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	batchSize   int
+	interval    time.Duration
+	client      *http.Client
+
+	queue    chan *Span
+	flushReq chan chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
+
+	dropMu  sync.Mutex
+	dropped int
+}
+
+func newExporter(cfg *config.TracingConfig) *exporter {
+	e := &exporter{
+		endpoint:    cfg.Endpoint,
+		headers:     cfg.Headers,
+		serviceName: cfg.ServiceName,
+		batchSize:   cfg.BatchSize,
+		interval:    time.Duration(cfg.FlushIntervalMs) * time.Millisecond,
+		// The exporter's own requests must not go through the tracing transport.
+		client:   &http.Client{Timeout: time.Duration(cfg.TimeoutMs) * time.Millisecond},
+		queue:    make(chan *Span, exporterQueueSize),
+		flushReq: make(chan chan struct{}),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case <-e.done:
+		return
+	default:
+	}
+	select {
+	case e.queue <- span:
+	default:
+		e.dropMu.Lock()
+		e.dropped++
+		e.dropMu.Unlock()
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, e.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			e.export(batch)
+			batch = make([]*Span, 0, e.batchSize)
+		}
+		e.dropMu.Lock()
+		dropped := e.dropped
+		e.dropped = 0
+		e.dropMu.Unlock()
+		if dropped > 0 {
+			log.Printf("[Tracing] export queue full, dropped %d spans", dropped)
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case span := <-e.queue:
+				batch = append(batch, span)
+				if len(batch) >= e.batchSize {
+					flush()
+				}
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= e.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case ack := <-e.flushReq:
+			drain()
+			flush()
+			close(ack)
+		case <-e.done:
+			drain()
+			flush()
+			return
+		}
+	}
+}
+
+// flush exports everything queued so far and waits for it, used by tests and shutdown.
+func (e *exporter) flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case e.flushReq <- ack:
+	case <-e.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *exporter) shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.done) })
+	select {
+	case <-e.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *exporter) export(spans []*Span) {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		log.Printf("[Tracing] encode spans failed: %v", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[Tracing] build export request failed: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		log.Printf("[Tracing] export %d spans failed: %v", len(spans), err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		log.Printf("[Tracing] export %d spans failed: HTTP %d %s", len(spans), resp.StatusCode, bytes.TrimSpace(detail))
+		return
+	}
+	io.Copy(io.Discard, resp.Body)
+}
+
+// OTLP/HTTP JSON payload, see opentelemetry-proto trace/v1. IDs are hex and 64-bit integers are strings.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+const (
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+func (e *exporter) encode(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		item := otlpSpan{
+			TraceID:           span.sc.TraceID.String(),
+			SpanID:            span.sc.SpanID.String(),
+			Name:              span.name,
+			Kind:              int(span.kind),
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        make([]otlpKeyValue, 0, len(span.attrs)),
+		}
+		if span.parentID.IsValid() {
+			item.ParentSpanID = span.parentID.String()
+		}
+		for _, attr := range span.attrs {
+			item.Attributes = append(item.Attributes, otlpKeyValue{Key: attr.key, Value: toOTLPValue(attr.value)})
+		}
+		if span.failed {
+			item.Status = otlpStatus{Code: otlpStatusError, Message: span.errStatus}
+		} else if span.kind == KindServer {
+			item.Status = otlpStatus{Code: otlpStatusOK}
+		}
+		span.mu.Unlock()
+		out = append(out, item)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{
+			{Key: "service.name", Value: toOTLPValue(e.serviceName)},
+		}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "auralogic"}, Spans: out}},
+	}}}
+}
+
+func toOTLPValue(value interface{}) otlpValue {
+	switch v := value.(type) {
+	case string:
+		return otlpValue{StringValue: &v}
+	case bool:
+		return otlpValue{BoolValue: &v}
+	case int:
+		s := strconv.FormatInt(int64(v), 10)
+		return otlpValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpValue{IntValue: &s}
+	case uint:
+		s := strconv.FormatUint(uint64(v), 10)
+		return otlpValue{IntValue: &s}
+	case uint64:
+		s := strconv.FormatUint(v, 10)
+		return otlpValue{IntValue: &s}
+	case float64:
+		return otlpValue{DoubleValue: &v}
+	default:
+		s := fmt.Sprintf("%v", v)
+		return otlpValue{StringValue: &s}
+	}
+}
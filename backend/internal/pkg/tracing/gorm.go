@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin records a client span for every query whose statement context
+// (set with db.WithContext) already carries a span. Queries without one are
+// not traced, so background jobs do not produce thousands of orphan traces.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "tracing" }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	register := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, item := range register {
+		if err := item.before("tracing:before_"+item.operation, beforeQuery(item.operation)); err != nil {
+			return err
+		}
+		if err := item.after("tracing:after_"+item.operation, afterQuery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func beforeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || !HasSpan(db.Statement.Context) {
+			return
+		}
+		name := "db." + operation
+		if table := db.Statement.Table; table != "" {
+			name += " " + table
+		}
+		_, span := Start(db.Statement.Context, name, KindClient)
+		if span == nil {
+			return
+		}
+		span.SetAttribute("db.system", db.Dialector.Name())
+		span.SetAttribute("db.operation", operation)
+		if db.Statement.Table != "" {
+			span.SetAttribute("db.sql.table", db.Statement.Table)
+		}
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func afterQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, _ := value.(*Span)
+	if span == nil {
+		return
+	}
+	// Only the SQL with placeholders is recorded; bound values may contain personal data.
+	if statement := strings.TrimSpace(db.Statement.SQL.String()); statement != "" {
+		span.SetAttribute("db.statement", statement)
+	}
+	span.SetAttribute("db.rows_affected", db.Statement.RowsAffected)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.SetError(db.Error)
+	}
+	span.End()
+}
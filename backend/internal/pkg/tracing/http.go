@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Transport wraps base so every outbound request gets a client span and a traceparent header.
+// A nil base uses http.DefaultTransport.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := base.(*transport); ok {
+		return base
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !Enabled() {
+		return t.base.RoundTrip(req)
+	}
+	ctx, span := Start(req.Context(), "HTTP "+req.Method, KindClient)
+	// The query string is left out because outbound URLs often carry credentials.
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("server.address", req.URL.Hostname())
+	span.SetAttribute("url.full", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+
+	// A RoundTripper must not modify the caller's request.
+	outReq := req.Clone(ctx)
+	Inject(ctx, outReq.Header)
+
+	resp, err := t.base.RoundTrip(outReq)
+	if err != nil {
+		span.EndWithError(err)
+		return resp, err
+	}
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 500 {
+		span.SetError(&statusError{code: resp.StatusCode})
+	}
+	span.End()
+	return resp, nil
+}
+
+// CloseIdleConnections lets http.Client.CloseIdleConnections reach the wrapped transport.
+func (t *transport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return "HTTP " + strconv.Itoa(e.code) + " " + http.StatusText(e.code)
+}
@@ -0,0 +1,290 @@
+// Package tracing records request traces as spans and exports them to an
+// OpenTelemetry collector over OTLP/HTTP with JSON encoding. Context is
+// propagated with the W3C traceparent header, so traces join up with any
+// OpenTelemetry instrumented caller or downstream service.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+// SpanKind follows the OTLP span kind values.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+type TraceID [16]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (t TraceID) IsValid() bool  { return t != TraceID{} }
+
+type SpanID [8]byte
+
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+func (s SpanID) IsValid() bool  { return s != SpanID{} }
+
+// SpanContext identifies a span and carries the sampling decision across process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool { return sc.TraceID.IsValid() && sc.SpanID.IsValid() }
+
+// Span is a single timed operation. A nil *Span is valid and ignores every call,
+// so callers never need to check whether tracing is enabled.
+type Span struct {
+	tracer   *tracer
+	sc       SpanContext
+	parentID SpanID
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu        sync.Mutex
+	end       time.Time
+	attrs     []attribute
+	errStatus string
+	failed    bool
+	ended     bool
+}
+
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+// SpanContext returns the identity of the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttribute records a string, bool, integer or float attribute; other values are formatted with %v.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil || !s.sc.Sampled {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	for i := range s.attrs {
+		if s.attrs[i].key == key {
+			s.attrs[i].value = value
+			return
+		}
+	}
+	s.attrs = append(s.attrs, attribute{key: key, value: value})
+}
+
+// SetError marks the span as failed. A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil || !s.sc.Sampled {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.failed = true
+	s.errStatus = err.Error()
+}
+
+// End finishes the span and queues it for export. Only the first call has any effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+	if s.sc.Sampled {
+		s.tracer.enqueue(s)
+	}
+}
+
+// EndWithError records err, if any, and ends the span.
+func (s *Span) EndWithError(err error) {
+	s.SetError(err)
+	s.End()
+}
+
+type spanContextKey struct{}
+type remoteContextKey struct{}
+
+// ContextWithSpan returns a context carrying span as the current span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the current span, or nil when there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext records a parent received from another process, see Extract.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteContextKey{}, sc)
+}
+
+// TraceIDFromContext returns the trace ID of the current span as hex, or "" when not traced.
+func TraceIDFromContext(ctx context.Context) string {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.sc.TraceID.String()
+	}
+	return ""
+}
+
+func parentFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	if span := SpanFromContext(ctx); span != nil {
+		return span.sc, true
+	}
+	if sc, ok := ctx.Value(remoteContextKey{}).(SpanContext); ok {
+		return sc, true
+	}
+	return SpanContext{}, false
+}
+
+// HasSpan reports whether ctx carries a span started in this process.
+func HasSpan(ctx context.Context) bool {
+	return SpanFromContext(ctx) != nil
+}
+
+// Start begins a span as a child of the span in ctx, or as a new trace when there is none.
+// It returns ctx unchanged and a nil span when tracing is disabled.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	t := current()
+	if t == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: t, name: name, kind: kind, start: time.Now()}
+	if parent, ok := parentFromContext(ctx); ok {
+		span.sc.TraceID = parent.TraceID
+		span.parentID = parent.SpanID
+		span.sc.Sampled = parent.Sampled
+	} else {
+		span.sc.TraceID = newTraceID()
+		span.sc.Sampled = t.sample(span.sc.TraceID)
+	}
+	span.sc.SpanID = newSpanID()
+	return ContextWithSpan(ctx, span), span
+}
+
+type tracer struct {
+	cfg         config.TracingConfig
+	sampleBound uint64
+	exporter    *exporter
+}
+
+// sample makes a deterministic decision from the trace ID, like the OpenTelemetry TraceIDRatioBased sampler.
+func (t *tracer) sample(id TraceID) bool {
+	if t.cfg.SampleRatio >= 1 {
+		return true
+	}
+	return binary.BigEndian.Uint64(id[8:16])>>1 < t.sampleBound
+}
+
+func (t *tracer) enqueue(span *Span) {
+	t.exporter.enqueue(span)
+}
+
+var active atomic.Pointer[tracer]
+
+func current() *tracer {
+	return active.Load()
+}
+
+// Enabled reports whether spans are being recorded.
+func Enabled() bool {
+	return current() != nil
+}
+
+// Init starts the exporter. It does nothing when tracing is disabled in config.
+func Init(cfg *config.TracingConfig) error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("tracing endpoint is required")
+	}
+	t := &tracer{
+		cfg:         *cfg,
+		sampleBound: uint64(cfg.SampleRatio * float64(uint64(math.MaxUint64>>1))),
+		exporter:    newExporter(cfg),
+	}
+	if prev := active.Swap(t); prev != nil {
+		prev.exporter.shutdown(context.Background())
+	}
+	return nil
+}
+
+// Shutdown flushes queued spans and stops recording new ones.
+func Shutdown(ctx context.Context) error {
+	t := active.Swap(nil)
+	if t == nil {
+		return nil
+	}
+	return t.exporter.shutdown(ctx)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		if _, err := rand.Read(id[:]); err != nil {
+			panic(fmt.Sprintf("tracing: read random trace id: %v", err))
+		}
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		if _, err := rand.Read(id[:]); err != nil {
+			panic(fmt.Sprintf("tracing: read random span id: %v", err))
+		}
+	}
+	return id
+}
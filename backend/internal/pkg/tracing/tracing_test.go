@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"auralogic/internal/config"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	sc, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || !sc.Sampled {
+		t.Fatalf("expected valid sampled span context, got %+v ok=%v", sc, ok)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Fatalf("unexpected ids: %s %s", sc.TraceID, sc.SpanID)
+	}
+	if got := FormatTraceparent(sc); got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("round trip mismatch: %s", got)
+	}
+
+	for _, value := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	} {
+		if _, ok := ParseTraceparent(value); ok {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestDisabledTracingIsNoop(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop", KindInternal)
+	if span != nil || HasSpan(ctx) {
+		t.Fatal("expected no span while tracing is disabled")
+	}
+	span.SetAttribute("key", "value")
+	span.EndWithError(io.EOF)
+}
+
+func TestSpansExportedWithParentsAndPropagation(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []otlpRequest
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test" {
+			t.Errorf("missing exporter header")
+		}
+		var payload otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	var downstreamTraceparent string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamTraceparent = r.Header.Get(TraceparentHeader)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer downstream.Close()
+
+	if err := Init(&config.TracingConfig{
+		Enabled:         true,
+		ServiceName:     "auralogic-test",
+		Endpoint:        collector.URL,
+		Headers:         map[string]string{"Authorization": "Bearer test"},
+		SampleRatio:     1,
+		BatchSize:       100,
+		FlushIntervalMs: 60000,
+		TimeoutMs:       5000,
+	}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	defer Shutdown(context.Background())
+
+	db, err := gorm.Open(sqlite.Open("file:tracing-test?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatalf("register plugin: %v", err)
+	}
+	type tracedRow struct {
+		ID   uint
+		Name string
+	}
+	if err := db.AutoMigrate(&tracedRow{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	// 上游携带的 traceparent 应成为服务端 span 的父级
+	incoming := http.Header{}
+	incoming.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, server := Start(Extract(context.Background(), incoming), "POST /api/user/orders", KindServer)
+	if err := db.WithContext(ctx).Create(&tracedRow{Name: "a"}).Error; err != nil {
+		t.Fatalf("create row: %v", err)
+	}
+	// 不带 span 的查询不记录
+	db.Find(&[]tracedRow{})
+
+	client := &http.Client{Transport: Transport(nil)}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, downstream.URL+"/deliver?token=secret", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("downstream request: %v", err)
+	}
+	resp.Body.Close()
+	if req.Header.Get(TraceparentHeader) != "" {
+		t.Fatal("transport must not modify the caller's request")
+	}
+	server.End()
+
+	if err := current().exporter.flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	spans := map[string]otlpSpan{}
+	for _, payload := range payloads {
+		if got := *payload.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; got != "auralogic-test" {
+			t.Fatalf("unexpected service.name %q", got)
+		}
+		for _, span := range payload.ResourceSpans[0].ScopeSpans[0].Spans {
+			spans[span.Name] = span
+		}
+	}
+	if len(spans) != 3 {
+		t.Fatalf("expected server, db and http spans, got %v", spans)
+	}
+	root := spans["POST /api/user/orders"]
+	if root.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || root.ParentSpanID != "00f067aa0ba902b7" {
+		t.Fatalf("server span did not continue the incoming trace: %+v", root)
+	}
+	dbSpan, ok := spans["db.create traced_rows"]
+	if !ok || dbSpan.ParentSpanID != root.SpanID || dbSpan.TraceID != root.TraceID {
+		t.Fatalf("db span not parented to server span: %+v", dbSpan)
+	}
+	httpSpan := spans["HTTP GET"]
+	if httpSpan.ParentSpanID != root.SpanID || httpSpan.Status.Code != otlpStatusError {
+		t.Fatalf("unexpected http span: %+v", httpSpan)
+	}
+	if downstreamTraceparent != "00-"+root.TraceID+"-"+httpSpan.SpanID+"-01" {
+		t.Fatalf("downstream got traceparent %q", downstreamTraceparent)
+	}
+	for _, attr := range httpSpan.Attributes {
+		if attr.Key == "url.full" && *attr.Value.StringValue != downstream.URL+"/deliver" {
+			t.Fatalf("url.full should not include the query string: %s", *attr.Value.StringValue)
+		}
+	}
+}
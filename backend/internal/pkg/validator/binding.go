@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	playground "github.com/go-playground/validator/v10"
+)
+
+// FieldError 请求绑定失败时的字段级错误，Key 为前端 i18n 键（validation.*）
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Key     string `json:"error_key"`
+	Message string `json:"message"`
+}
+
+// Params 前端翻译模板使用的参数
+func (e FieldError) Params() map[string]interface{} {
+	params := map[string]interface{}{"field": e.Field}
+	if e.Param != "" {
+		params["param"] = e.Param
+	}
+	return params
+}
+
+func init() {
+	// 校验错误使用 JSON / 表单字段名，与请求体保持一致
+	if engine, ok := binding.Validator.Engine().(*playground.Validate); ok {
+		engine.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// BindingErrors 将 ShouldBind* 返回的错误转换为字段级错误列表
+func BindingErrors(err error) []FieldError {
+	var validationErrors playground.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		result := make([]FieldError, 0, len(validationErrors))
+		for _, fe := range validationErrors {
+			result = append(result, translateFieldError(fe))
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		return []FieldError{{
+			Field:   field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Key:     "validation.type",
+			Message: fmt.Sprintf("%s must be of type %s", field, typeErr.Type.String()),
+		}}
+	}
+	if errors.Is(err, io.EOF) {
+		return []FieldError{{
+			Rule:    "body",
+			Key:     "validation.bodyRequired",
+			Message: "Request body is required",
+		}}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return []FieldError{{
+			Rule:    "body",
+			Key:     "validation.malformed",
+			Message: "Request body is not valid JSON",
+		}}
+	}
+	return []FieldError{{
+		Rule:    "invalid",
+		Key:     "validation.invalidRequest",
+		Message: "Invalid request parameters",
+	}}
+}
+
+// fieldPath 去掉顶层结构体名，保留嵌套路径，如 items[0].quantity
+func fieldPath(fe playground.FieldError) string {
+	namespace := fe.Namespace()
+	if index := strings.Index(namespace, "."); index >= 0 {
+		return namespace[index+1:]
+	}
+	return fe.Field()
+}
+
+func translateFieldError(fe playground.FieldError) FieldError {
+	field := fieldPath(fe)
+	result := FieldError{Field: field, Rule: fe.Tag(), Param: fe.Param()}
+
+	kind := fe.Kind()
+	isString := kind == reflect.String
+	isList := kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		result.Key = "validation.required"
+		result.Message = fmt.Sprintf("%s is required", field)
+	case "email":
+		result.Key = "validation.email"
+		result.Message = fmt.Sprintf("%s must be a valid email address", field)
+	case "url", "http_url":
+		result.Key = "validation.url"
+		result.Message = fmt.Sprintf("%s must be a valid URL", field)
+	case "oneof":
+		result.Param = strings.Join(strings.Fields(fe.Param()), ", ")
+		result.Key = "validation.oneof"
+		result.Message = fmt.Sprintf("%s must be one of: %s", field, result.Param)
+	case "min", "gte":
+		switch {
+		case isString:
+			result.Key = "validation.minLength"
+			result.Message = fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
+		case isList:
+			result.Key = "validation.minItems"
+			result.Message = fmt.Sprintf("%s must contain at least %s items", field, fe.Param())
+		default:
+			result.Key = "validation.min"
+			result.Message = fmt.Sprintf("%s must be at least %s", field, fe.Param())
+		}
+	case "max", "lte":
+		switch {
+		case isString:
+			result.Key = "validation.maxLength"
+			result.Message = fmt.Sprintf("%s cannot exceed %s characters", field, fe.Param())
+		case isList:
+			result.Key = "validation.maxItems"
+			result.Message = fmt.Sprintf("%s cannot contain more than %s items", field, fe.Param())
+		default:
+			result.Key = "validation.max"
+			result.Message = fmt.Sprintf("%s cannot exceed %s", field, fe.Param())
+		}
+	case "len":
+		switch {
+		case isString:
+			result.Key = "validation.length"
+			result.Message = fmt.Sprintf("%s must be exactly %s characters", field, fe.Param())
+		case isList:
+			result.Key = "validation.lengthItems"
+			result.Message = fmt.Sprintf("%s must contain exactly %s items", field, fe.Param())
+		default:
+			result.Key = "validation.equal"
+			result.Message = fmt.Sprintf("%s must equal %s", field, fe.Param())
+		}
+	case "gt":
+		result.Key = "validation.greaterThan"
+		result.Message = fmt.Sprintf("%s must be greater than %s", field, fe.Param())
+	case "lt":
+		result.Key = "validation.lessThan"
+		result.Message = fmt.Sprintf("%s must be less than %s", field, fe.Param())
+	default:
+		result.Key = "validation.invalid"
+		result.Message = fmt.Sprintf("%s is invalid", field)
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bindingTestItem struct {
+	SKU      string `json:"sku" binding:"required"`
+	Quantity int    `json:"quantity" binding:"gte=1"`
+}
+
+type bindingTestRequest struct {
+	Email  string            `json:"email" binding:"required,email"`
+	Name   string            `json:"name" binding:"min=2"`
+	Status string            `json:"status" binding:"oneof=open closed"`
+	Items  []bindingTestItem `json:"items" binding:"required,dive"`
+}
+
+func bindTestRequest(t *testing.T, body string) error {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	var req bindingTestRequest
+	return c.ShouldBindJSON(&req)
+}
+
+func TestBindingErrorsUseRequestFieldNames(t *testing.T) {
+	err := bindTestRequest(t, `{"email":"bad","name":"a","status":"pending","items":[{"sku":"","quantity":0}]}`)
+	if err == nil {
+		t.Fatal("expected binding error")
+	}
+
+	got := make(map[string]string)
+	for _, fieldErr := range BindingErrors(err) {
+		got[fieldErr.Field] = fieldErr.Key
+	}
+	want := map[string]string{
+		"email":             "validation.email",
+		"name":              "validation.minLength",
+		"status":            "validation.oneof",
+		"items[0].sku":      "validation.required",
+		"items[0].quantity": "validation.min",
+	}
+	for field, key := range want {
+		if got[field] != key {
+			t.Fatalf("expected %s for %s, got %q (all: %v)", key, field, got[field], got)
+		}
+	}
+}
+
+func TestBindingErrorsForMalformedBody(t *testing.T) {
+	cases := map[string]string{
+		``:                 "validation.bodyRequired",
+		`{"email":`:        "validation.malformed",
+		`{"email":123}`:    "validation.type",
+		`{"items":"none"}`: "validation.type",
+	}
+	for body, key := range cases {
+		fieldErrors := BindingErrors(bindTestRequest(t, body))
+		if len(fieldErrors) != 1 || fieldErrors[0].Key != key {
+			t.Fatalf("body %q: expected %s, got %+v", body, key, fieldErrors)
+		}
+	}
+}
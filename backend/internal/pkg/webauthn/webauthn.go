@@ -0,0 +1,237 @@
+// Package webauthn 实现通行密钥（Passkey）注册与登录仪式的服务端校验（W3C WebAuthn Level 2）。
+// 不校验证明声明（attestation statement）：商城场景不要求认证器型号可信，只需确认凭据公钥与签名
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// 认证器数据标志位
+const (
+	FlagUserPresent  byte = 0x01
+	FlagUserVerified byte = 0x04
+	FlagAttestedData byte = 0x40
+	FlagExtensions   byte = 0x80
+)
+
+// ChallengeSize 每次仪式随机挑战的字节数
+const ChallengeSize = 32
+
+var (
+	ErrInvalidClientData   = errors.New("webauthn: invalid client data")
+	ErrChallengeMismatch   = errors.New("webauthn: challenge mismatch")
+	ErrOriginMismatch      = errors.New("webauthn: origin mismatch")
+	ErrRPIDMismatch        = errors.New("webauthn: relying party id mismatch")
+	ErrUserNotVerified     = errors.New("webauthn: user presence or verification missing")
+	ErrInvalidAuthData     = errors.New("webauthn: invalid authenticator data")
+	ErrInvalidSignature    = errors.New("webauthn: invalid signature")
+	ErrSignCountRegression = errors.New("webauthn: signature counter did not increase, authenticator may be cloned")
+)
+
+// Encoding WebAuthn JSON 中二进制字段统一使用无填充的 base64url
+var Encoding = base64.RawURLEncoding
+
+// RelyingParty 依赖方（本站）信息
+type RelyingParty struct {
+	ID     string // 域名，如 shop.example.com
+	Name   string // 展示名称
+	Origin string // 浏览器发起仪式的完整源，如 https://shop.example.com
+}
+
+// NewRelyingParty 从站点 URL 推导依赖方 ID 与源
+func NewRelyingParty(siteURL, name string) (RelyingParty, error) {
+	parsed, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil || parsed.Hostname() == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return RelyingParty{}, fmt.Errorf("webauthn: invalid site url %q", siteURL)
+	}
+	return RelyingParty{
+		ID:     parsed.Hostname(),
+		Name:   name,
+		Origin: parsed.Scheme + "://" + parsed.Host,
+	}, nil
+}
+
+// NewChallenge 生成随机挑战
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, ChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("webauthn: generate challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// AuthenticatorData 解析后的认证器数据
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte // COSE 编码的凭据公钥原文，仅注册时存在
+}
+
+// ParseAuthenticatorData 解析认证器数据：rpIdHash(32) | flags(1) | signCount(4) | [attestedCredentialData] | [extensions]
+func ParseAuthenticatorData(raw []byte) (*AuthenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, ErrInvalidAuthData
+	}
+	data := &AuthenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[37:]
+	if data.Flags&FlagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, ErrInvalidAuthData
+		}
+		data.AAGUID = rest[:16]
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || idLen > 1023 || len(rest) < idLen {
+			return nil, ErrInvalidAuthData
+		}
+		data.CredentialID = rest[:idLen]
+		rest = rest[idLen:]
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, ErrInvalidAuthData
+		}
+		data.PublicKey = rest[:len(rest)-len(after)]
+		rest = after
+	}
+	if data.Flags&FlagExtensions != 0 {
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, ErrInvalidAuthData
+		}
+		rest = after
+	}
+	if len(rest) != 0 {
+		return nil, ErrInvalidAuthData
+	}
+	return data, nil
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// verifyClientData 校验 clientDataJSON 的类型、挑战与源
+func (rp RelyingParty) verifyClientData(raw []byte, ceremony string, challenge []byte) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil || data.Type != ceremony {
+		return ErrInvalidClientData
+	}
+	received, err := Encoding.DecodeString(strings.TrimRight(data.Challenge, "="))
+	if err != nil || subtle.ConstantTimeCompare(received, challenge) != 1 {
+		return ErrChallengeMismatch
+	}
+	if data.Origin != rp.Origin {
+		return ErrOriginMismatch
+	}
+	return nil
+}
+
+// verifyAuthenticatorFlags 校验 rpIdHash 与用户在场、用户验证标志（要求生物识别或 PIN）
+func (rp RelyingParty) verifyAuthenticatorFlags(data *AuthenticatorData) error {
+	expected := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(data.RPIDHash, expected[:]) {
+		return ErrRPIDMismatch
+	}
+	if data.Flags&FlagUserPresent == 0 || data.Flags&FlagUserVerified == 0 {
+		return ErrUserNotVerified
+	}
+	return nil
+}
+
+// Credential 注册成功的凭据
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // COSE 编码
+	Algorithm int64
+	SignCount uint32
+	AAGUID    []byte
+}
+
+// VerifyRegistration 校验 navigator.credentials.create() 的结果
+func (rp RelyingParty) VerifyRegistration(challenge, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+	decoded, rest, err := decodeCBOR(attestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrInvalidAuthData
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrInvalidAuthData
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidAuthData
+	}
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.verifyAuthenticatorFlags(authData); err != nil {
+		return nil, err
+	}
+	if authData.Flags&FlagAttestedData == 0 {
+		return nil, ErrInvalidAuthData
+	}
+	publicKey, err := ParsePublicKey(authData.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:        append([]byte(nil), authData.CredentialID...),
+		PublicKey: append([]byte(nil), authData.PublicKey...),
+		Algorithm: publicKey.Algorithm,
+		SignCount: authData.SignCount,
+		AAGUID:    append([]byte(nil), authData.AAGUID...),
+	}, nil
+}
+
+// VerifyAssertion 校验 navigator.credentials.get() 的结果，返回认证器的新签名计数。
+// 认证器支持计数（任一方非零）时新计数必须递增，否则视为凭据被克隆
+func (rp RelyingParty) VerifyAssertion(challenge, clientDataJSON, authenticatorData, signature, publicKey []byte, storedSignCount uint32) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+	authData, err := ParseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	if err := rp.verifyAuthenticatorFlags(authData); err != nil {
+		return 0, err
+	}
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+	if !key.Verify(message, signature) {
+		return 0, ErrInvalidSignature
+	}
+	if authData.SignCount != 0 || storedSignCount != 0 {
+		if authData.SignCount <= storedSignCount {
+			return 0, ErrSignCountRegression
+		}
+	}
+	return authData.SignCount, nil
+}
@@ -0,0 +1,175 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// encodeCBORForTest 按认证器的定长编码输出测试数据
+func encodeCBORForTest(value interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 256:
+			return []byte{major<<5 | 24, byte(n)}
+		default:
+			buf := []byte{major<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(buf[1:], uint16(n))
+			return buf
+		}
+	}
+	switch v := value.(type) {
+	case int:
+		if v >= 0 {
+			return head(0, uint64(v))
+		}
+		return head(1, uint64(-1-v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case [][2]interface{}:
+		out := head(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, encodeCBORForTest(pair[0])...)
+			out = append(out, encodeCBORForTest(pair[1])...)
+		}
+		return out
+	}
+	panic("unsupported test value")
+}
+
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	rp           RelyingParty
+}
+
+func newTestAuthenticator(t *testing.T, rp RelyingParty) *testAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return &testAuthenticator{key: key, credentialID: []byte("credential-1"), rp: rp}
+}
+
+func (a *testAuthenticator) coseKey() []byte {
+	point, _ := a.key.PublicKey.Bytes()
+	return encodeCBORForTest([][2]interface{}{
+		{1, 2}, {3, -7}, {-1, 1}, {-2, point[1:33]}, {-3, point[33:65]},
+	})
+}
+
+func (a *testAuthenticator) authData(flags byte, signCount uint32, attested bool) []byte {
+	rpHash := sha256.Sum256([]byte(a.rp.ID))
+	out := append([]byte(nil), rpHash[:]...)
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, signCount)
+	out = append(out, flags)
+	out = append(out, counter...)
+	if attested {
+		out = append(out, make([]byte, 16)...)
+		idLen := make([]byte, 2)
+		binary.BigEndian.PutUint16(idLen, uint16(len(a.credentialID)))
+		out = append(out, idLen...)
+		out = append(out, a.credentialID...)
+		out = append(out, a.coseKey()...)
+	}
+	return out
+}
+
+func clientDataJSONForTest(ceremony string, challenge []byte, origin string) []byte {
+	raw, _ := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": Encoding.EncodeToString(challenge),
+		"origin":    origin,
+	})
+	return raw
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	rp, err := NewRelyingParty("https://shop.example.com/", "Shop")
+	if err != nil || rp.ID != "shop.example.com" || rp.Origin != "https://shop.example.com" {
+		t.Fatalf("relying party: %+v err=%v", rp, err)
+	}
+	authenticator := newTestAuthenticator(t, rp)
+
+	challenge, _ := NewChallenge()
+	attestation := encodeCBORForTest([][2]interface{}{
+		{"fmt", "none"},
+		{"attStmt", [][2]interface{}{}},
+		{"authData", authenticator.authData(FlagUserPresent|FlagUserVerified|FlagAttestedData, 0, true)},
+	})
+	credential, err := rp.VerifyRegistration(challenge, clientDataJSONForTest("webauthn.create", challenge, rp.Origin), attestation)
+	if err != nil {
+		t.Fatalf("verify registration: %v", err)
+	}
+	if string(credential.ID) != "credential-1" || credential.Algorithm != AlgES256 {
+		t.Fatalf("unexpected credential: %+v", credential)
+	}
+
+	otherChallenge, _ := NewChallenge()
+	if _, err := rp.VerifyRegistration(otherChallenge, clientDataJSONForTest("webauthn.create", challenge, rp.Origin), attestation); !errors.Is(err, ErrChallengeMismatch) {
+		t.Fatalf("expected challenge mismatch, got %v", err)
+	}
+
+	sign := func(challenge []byte, origin string, flags byte, signCount uint32) ([]byte, []byte, []byte) {
+		clientDataJSON := clientDataJSONForTest("webauthn.get", challenge, origin)
+		authData := authenticator.authData(flags, signCount, false)
+		hash := sha256.Sum256(clientDataJSON)
+		digest := sha256.Sum256(append(append([]byte(nil), authData...), hash[:]...))
+		signature, err := ecdsa.SignASN1(rand.Reader, authenticator.key, digest[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return clientDataJSON, authData, signature
+	}
+
+	loginChallenge, _ := NewChallenge()
+	clientDataJSON, authData, signature := sign(loginChallenge, rp.Origin, FlagUserPresent|FlagUserVerified, 5)
+	signCount, err := rp.VerifyAssertion(loginChallenge, clientDataJSON, authData, signature, credential.PublicKey, 0)
+	if err != nil || signCount != 5 {
+		t.Fatalf("verify assertion: count=%d err=%v", signCount, err)
+	}
+	if _, err := rp.VerifyAssertion(loginChallenge, clientDataJSON, authData, signature, credential.PublicKey, 5); !errors.Is(err, ErrSignCountRegression) {
+		t.Fatalf("expected counter regression, got %v", err)
+	}
+
+	tampered := append([]byte(nil), signature...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := rp.VerifyAssertion(loginChallenge, clientDataJSON, authData, tampered, credential.PublicKey, 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+
+	clientDataJSON, authData, signature = sign(loginChallenge, "https://evil.example.com", FlagUserPresent|FlagUserVerified, 6)
+	if _, err := rp.VerifyAssertion(loginChallenge, clientDataJSON, authData, signature, credential.PublicKey, 5); !errors.Is(err, ErrOriginMismatch) {
+		t.Fatalf("expected origin mismatch, got %v", err)
+	}
+
+	clientDataJSON, authData, signature = sign(loginChallenge, rp.Origin, FlagUserPresent, 6)
+	if _, err := rp.VerifyAssertion(loginChallenge, clientDataJSON, authData, signature, credential.PublicKey, 5); !errors.Is(err, ErrUserNotVerified) {
+		t.Fatalf("expected user verification required, got %v", err)
+	}
+}
+
+func TestDecodeCBORRejectsMalformedInput(t *testing.T) {
+	cases := [][]byte{
+		{},
+		{0x5f},             // 不定长字节串
+		{0x59, 0xff, 0xff}, // 长度超出数据
+		{0x9a, 0xff, 0xff, 0xff, 0xff},
+	}
+	for _, input := range cases {
+		if _, _, err := decodeCBOR(input); err == nil {
+			t.Fatalf("expected error for % x", input)
+		}
+	}
+}
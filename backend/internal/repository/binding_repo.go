@@ -133,3 +133,85 @@ func (r *BindingRepository) GetBindingWithStockInfo(productID uint) ([]models.Bi
 
 	return results, err
 }
+
+// List 跨商品查询绑定关系，search 匹配商品 SKU 或名称
+func (r *BindingRepository) List(search string, productID, inventoryID uint, page, limit int) ([]models.ProductInventoryBinding, int64, error) {
+	query := r.db.Model(&models.ProductInventoryBinding{}).
+		Joins("JOIN products ON products.id = product_inventory_bindings.product_id AND products.deleted_at IS NULL")
+	if search != "" {
+		like := "%" + search + "%"
+		query = query.Where("products.sku LIKE ? OR products.name LIKE ?", like, like)
+	}
+	if productID > 0 {
+		query = query.Where("product_inventory_bindings.product_id = ?", productID)
+	}
+	if inventoryID > 0 {
+		query = query.Where("product_inventory_bindings.inventory_id = ?", inventoryID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var bindings []models.ProductInventoryBinding
+	err := query.Preload("Product").
+		Preload("Inventory").
+		Order("products.sku ASC, product_inventory_bindings.id ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&bindings).Error
+	return bindings, total, err
+}
+
+// UpdateInventoryID 将绑定指向新的库存
+func (r *BindingRepository) UpdateInventoryID(id, inventoryID uint) error {
+	return r.db.Model(&models.ProductInventoryBinding{}).
+		Where("id = ?", id).
+		Update("inventory_id", inventoryID).Error
+}
+
+// FindOpenOrdersBySKU 查找仍占用库存（未发货且未取消）且包含指定 SKU 的订单
+// items 以 JSON 文本存储，这里只做粗筛，调用方需再逐项核对
+func (r *BindingRepository) FindOpenOrdersBySKU(sku string) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Select("id, order_no, status, items, inventory_bindings, created_at").
+		Where("status IN ?", []models.OrderStatus{
+			models.OrderStatusPendingPayment,
+			models.OrderStatusDraft,
+			models.OrderStatusPending,
+			models.OrderStatusNeedResubmit,
+		}).
+		Where("items LIKE ?", "%"+sku+"%").
+		Order("id ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// CreateHistory 记录绑定变更
+func (r *BindingRepository) CreateHistory(history *models.InventoryBindingHistory) error {
+	return r.db.Create(history).Error
+}
+
+// ListHistory 查询绑定变更记录
+func (r *BindingRepository) ListHistory(productID, bindingID uint, page, limit int) ([]models.InventoryBindingHistory, int64, error) {
+	query := r.db.Model(&models.InventoryBindingHistory{})
+	if productID > 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if bindingID > 0 {
+		query = query.Where("binding_id = ?", bindingID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var histories []models.InventoryBindingHistory
+	err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&histories).Error
+	return histories, total, err
+}
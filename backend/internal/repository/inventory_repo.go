@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -17,6 +18,11 @@ func NewInventoryRepository(db *gorm.DB) *InventoryRepository {
 	return &InventoryRepository{db: db}
 }
 
+// WithContext 返回使用 ctx 执行查询的仓储，请求链路随之传递到数据库查询
+func (r *InventoryRepository) WithContext(ctx context.Context) *InventoryRepository {
+	return &InventoryRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create CreateInventory记录
 func (r *InventoryRepository) Create(inventory *models.Inventory) error {
 	return r.db.Create(inventory).Error
@@ -27,6 +33,16 @@ func (r *InventoryRepository) Update(inventory *models.Inventory) error {
 	return r.db.Save(inventory).Error
 }
 
+// UpdateWithVersion 按乐观锁版本更新管理员可编辑的字段，版本不一致时返回 dbutil.ErrVersionConflict
+func (r *InventoryRepository) UpdateWithVersion(id, expectedVersion uint, updates map[string]interface{}) error {
+	return dbutil.UpdateWithVersion(r.db, &models.Inventory{}, id, expectedVersion, updates)
+}
+
+// UpdateFlashSale 单独更新秒杀标记，不覆盖并发写入的库存计数
+func (r *InventoryRepository) UpdateFlashSale(id uint, enabled bool) error {
+	return r.db.Model(&models.Inventory{}).Where("id = ?", id).Update("flash_sale", enabled).Error
+}
+
 // FindByID 根据ID查找
 func (r *InventoryRepository) FindByID(id uint) (*models.Inventory, error) {
 	var inventory models.Inventory
@@ -247,6 +263,46 @@ func (r *InventoryRepository) Deduct(inventoryID uint, quantity int, orderNo str
 	})
 }
 
+// Restock 退货收货后将商品退回库存：增加总库存与可购买数，并冲减已售数量
+func (r *InventoryRepository) Restock(inventoryID uint, quantity int, orderNo, operator, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.Inventory{}, "id = ?", inventoryID); err != nil {
+			return err
+		}
+
+		var inventory models.Inventory
+		if err := tx.First(&inventory, inventoryID).Error; err != nil {
+			return err
+		}
+
+		beforeStock := inventory.Stock
+		inventory.Stock += quantity
+		inventory.AvailableQuantity += quantity
+		inventory.SoldQuantity -= quantity
+		if inventory.SoldQuantity < 0 {
+			inventory.SoldQuantity = 0
+		}
+
+		if err := tx.Save(&inventory).Error; err != nil {
+			return err
+		}
+
+		log := &models.InventoryLog{
+			InventoryID: inventoryID,
+			ProductID:   0,
+			Type:        models.InventoryLogTypeIn,
+			Quantity:    quantity,
+			BeforeStock: beforeStock,
+			AfterStock:  inventory.Stock,
+			OrderNo:     orderNo,
+			Operator:    operator,
+			Reason:      reason,
+		}
+
+		return tx.Create(log).Error
+	})
+}
+
 // Adjust 调整库存（入库、盘点等）- 旧方法保留用于兼容
 func (r *InventoryRepository) Adjust(inventoryID uint, newStock, newAvailable int, operator, reason string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
@@ -262,6 +318,8 @@ func (r *InventoryRepository) Adjust(inventoryID uint, newStock, newAvailable in
 		beforeStock := inventory.Stock
 		inventory.Stock = newStock
 		inventory.AvailableQuantity = newAvailable
+		// 手工调整同样递增版本，基于调整前数据的编辑保存时会被拒绝
+		inventory.Version++
 
 		if err := tx.Save(&inventory).Error; err != nil {
 			return err
@@ -283,6 +341,28 @@ func (r *InventoryRepository) Adjust(inventoryID uint, newStock, newAvailable in
 	})
 }
 
+// ApplyInventoryDelta 计算增量调整后的库存与可售数量并校验，不修改 inventory
+func ApplyInventoryDelta(inventory *models.Inventory, stockDelta, availableDelta int) (int, int, error) {
+	newStock := inventory.Stock + stockDelta
+	newAvailable := inventory.AvailableQuantity + availableDelta
+
+	// 验证：库存不能为负
+	if newStock < 0 {
+		return 0, 0, errors.New("Adjusted inventory cannot be negative")
+	}
+
+	// 验证：可售数量不能为负
+	if newAvailable < 0 {
+		return 0, 0, errors.New("Adjusted available quantity cannot be negative")
+	}
+
+	// 验证：可售数量不能超过库存
+	if newAvailable > newStock {
+		return 0, 0, errors.New("Available quantity cannot exceed total stock")
+	}
+	return newStock, newAvailable, nil
+}
+
 // AdjustByDelta 通过增量调整库存（推荐使用，避免并发问题）- 旧方法保留用于兼容
 func (r *InventoryRepository) AdjustByDelta(inventoryID uint, stockDelta, availableDelta int, operator, reason string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
@@ -297,28 +377,15 @@ func (r *InventoryRepository) AdjustByDelta(inventoryID uint, stockDelta, availa
 		}
 
 		beforeStock := inventory.Stock
-
-		// 应用增量
-		newStock := inventory.Stock + stockDelta
-		newAvailable := inventory.AvailableQuantity + availableDelta
-
-		// 验证：库存不能为负
-		if newStock < 0 {
-			return errors.New("Adjusted inventory cannot be negative")
-		}
-
-		// 验证：可售数量不能为负
-		if newAvailable < 0 {
-			return errors.New("Adjusted available quantity cannot be negative")
-		}
-
-		// 验证：可售数量不能超过库存
-		if newAvailable > newStock {
-			return errors.New("Available quantity cannot exceed total stock")
+		newStock, newAvailable, err := ApplyInventoryDelta(&inventory, stockDelta, availableDelta)
+		if err != nil {
+			return err
 		}
 
 		inventory.Stock = newStock
 		inventory.AvailableQuantity = newAvailable
+		// 与 Adjust 相同，递增版本
+		inventory.Version++
 
 		if err := tx.Save(&inventory).Error; err != nil {
 			return err
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
+	"gorm.io/gorm"
+)
+
+// WithTransaction 在事务中执行设备激活等需要加锁的操作
+func (r *BindingRepository) WithTransaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// FindSoldStockByKeyHash 按卡密哈希查找已发货的库存项（含所属虚拟库存）
+func (r *BindingRepository) FindSoldStockByKeyHash(hash string) (*models.VirtualProductStock, error) {
+	var stock models.VirtualProductStock
+	err := r.db.Preload("VirtualInventory").
+		Where("content_hash = ? AND status = ? AND order_id IS NOT NULL", hash, models.VirtualStockStatusSold).
+		Order("id DESC").
+		First(&stock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
+
+// FindLicenseOrder 查询卡密所属订单的状态
+func (r *BindingRepository) FindLicenseOrder(orderID uint) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Model(&models.Order{}).
+		Select("id", "order_no", "user_id", "status", "source", "paid_at").
+		Where("id = ?", orderID).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindTrialByOrder 查询试用订单对应的试用记录
+func (r *BindingRepository) FindTrialByOrder(orderID uint) (*models.ProductTrial, error) {
+	var trial models.ProductTrial
+	if err := r.db.Where("order_id = ?", orderID).First(&trial).Error; err != nil {
+		return nil, err
+	}
+	return &trial, nil
+}
+
+// LockStock 锁定库存项，串行化同一卡密的并发激活
+func (r *BindingRepository) LockStock(tx *gorm.DB, stockID uint) error {
+	return dbutil.LockForUpdate(tx, &models.VirtualProductStock{}, "id = ?", stockID)
+}
+
+// FindActiveActivation 查询设备当前的激活记录
+func (r *BindingRepository) FindActiveActivation(tx *gorm.DB, stockID uint, deviceID string) (*models.LicenseActivation, error) {
+	var activation models.LicenseActivation
+	err := tx.Where("stock_id = ? AND device_id = ? AND status = ?", stockID, deviceID, models.LicenseActivationStatusActive).
+		First(&activation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &activation, nil
+}
+
+// CountActiveActivations 统计卡密当前占用的设备数
+func (r *BindingRepository) CountActiveActivations(tx *gorm.DB, stockID uint) (int64, error) {
+	var count int64
+	err := tx.Model(&models.LicenseActivation{}).
+		Where("stock_id = ? AND status = ?", stockID, models.LicenseActivationStatusActive).
+		Count(&count).Error
+	return count, err
+}
+
+// FindOrderActivation 查询订单下的某条激活记录
+func (r *BindingRepository) FindOrderActivation(tx *gorm.DB, orderID, activationID uint) (*models.LicenseActivation, error) {
+	var activation models.LicenseActivation
+	err := tx.Where("id = ? AND order_id = ?", activationID, orderID).First(&activation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &activation, nil
+}
+
+// ListActivationsByOrder 订单的全部激活历史，激活中的排在前面
+func (r *BindingRepository) ListActivationsByOrder(orderID uint) ([]models.LicenseActivation, error) {
+	var activations []models.LicenseActivation
+	err := r.db.Where("order_id = ?", orderID).
+		Order("CASE WHEN status = 'active' THEN 0 ELSE 1 END").
+		Order("id DESC").
+		Find(&activations).Error
+	return activations, err
+}
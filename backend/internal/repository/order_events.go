@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"auralogic/internal/models"
+)
+
+// CreateOrderEvent 写入订单时间线事件
+func (r *OrderRepository) CreateOrderEvent(event *models.OrderEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindOrderEvents 订单时间线事件，按时间正序；includeInternal 为 false 时排除仅管理员可见的事件
+func (r *OrderRepository) FindOrderEvents(orderID uint, includeInternal bool, limit int) ([]models.OrderEvent, error) {
+	query := r.db.Where("order_id = ?", orderID)
+	if !includeInternal {
+		query = query.Where("internal = ?", false)
+	}
+	var events []models.OrderEvent
+	err := query.Order("created_at ASC").Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
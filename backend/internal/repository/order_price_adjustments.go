@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"auralogic/internal/models"
+)
+
+// FindOrderPriceAdjustmentByID 调价记录详情
+func (r *OrderRepository) FindOrderPriceAdjustmentByID(id uint) (*models.OrderPriceAdjustment, error) {
+	var adjustment models.OrderPriceAdjustment
+	err := r.db.First(&adjustment, id).Error
+	return &adjustment, err
+}
+
+// FindOrderPriceAdjustments 订单的调价记录，按时间倒序
+func (r *OrderRepository) FindOrderPriceAdjustments(orderID uint) ([]models.OrderPriceAdjustment, error) {
+	var adjustments []models.OrderPriceAdjustment
+	err := r.db.Where("order_id = ?", orderID).Order("id DESC").Find(&adjustments).Error
+	return adjustments, err
+}
+
+// ListOrderPriceAdjustments 管理端调价审批列表，status 为空时返回全部
+func (r *OrderRepository) ListOrderPriceAdjustments(status string, page, limit int) ([]models.OrderPriceAdjustment, int64, error) {
+	query := r.db.Model(&models.OrderPriceAdjustment{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var adjustments []models.OrderPriceAdjustment
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&adjustments).Error
+	return adjustments, total, err
+}
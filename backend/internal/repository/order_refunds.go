@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"auralogic/internal/models"
+)
+
+// FindOrderRefundByID 部分退款记录详情
+func (r *OrderRepository) FindOrderRefundByID(id uint) (*models.OrderRefund, error) {
+	var refund models.OrderRefund
+	err := r.db.First(&refund, id).Error
+	return &refund, err
+}
+
+// FindOrderRefunds 订单的部分退款记录，按时间倒序
+func (r *OrderRepository) FindOrderRefunds(orderID uint) ([]models.OrderRefund, error) {
+	var refunds []models.OrderRefund
+	err := r.db.Where("order_id = ?", orderID).Order("id DESC").Find(&refunds).Error
+	return refunds, err
+}
+
+// ListOrderRefunds 管理端部分退款列表，status 为空时返回全部
+func (r *OrderRepository) ListOrderRefunds(status string, page, limit int) ([]models.OrderRefund, int64, error) {
+	query := r.db.Model(&models.OrderRefund{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var refunds []models.OrderRefund
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&refunds).Error
+	return refunds, total, err
+}
+
+// RefundedItemQuantities 已退款并释放库存的订单项数量，整单释放库存时扣除
+func (r *OrderRepository) RefundedItemQuantities(orderID uint) (map[int]int, error) {
+	var refunds []models.OrderRefund
+	if err := r.db.Select("id", "items").
+		Where("order_id = ? AND status = ? AND inventory_released = ?", orderID, models.OrderRefundStatusRefunded, true).
+		Find(&refunds).Error; err != nil {
+		return nil, err
+	}
+	quantities := make(map[int]int)
+	for _, refund := range refunds {
+		for _, item := range refund.Items {
+			quantities[item.ItemIndex] += item.Quantity
+		}
+	}
+	return quantities, nil
+}
+
+// RefundedPartialAmount 已通过部分退款退还的金额，整单退款时从应退金额中扣除
+func (r *OrderRepository) RefundedPartialAmount(orderID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.OrderRefund{}).
+		Where("order_id = ? AND status = ?", orderID, models.OrderRefundStatusRefunded).
+		Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	return total, err
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/dbutil"
+	"context"
+	"encoding/json"
 	"fmt"
 	"gorm.io/gorm"
 	"strings"
+	"time"
 )
 
 type OrderRepository struct {
@@ -16,6 +19,11 @@ func NewOrderRepository(db *gorm.DB) *OrderRepository {
 	return &OrderRepository{db: db}
 }
 
+// WithContext 返回使用 ctx 执行查询的仓储，请求链路随之传递到数据库查询
+func (r *OrderRepository) WithContext(ctx context.Context) *OrderRepository {
+	return &OrderRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *OrderRepository) WithTransaction(fn func(tx *gorm.DB) error) error {
 	return r.db.Transaction(fn)
 }
@@ -41,6 +49,12 @@ func (r *OrderRepository) FindByIDForUpdate(tx *gorm.DB, id uint) (*models.Order
 	return &order, err
 }
 
+// LoadItemAllocations 加载订单的盲盒分配结果，仅订单详情等需要展示实际属性的场景调用
+func (r *OrderRepository) LoadItemAllocations(order *models.Order) error {
+	order.ItemAllocations = nil
+	return r.db.Where("order_id = ?", order.ID).Order("item_index ASC").Find(&order.ItemAllocations).Error
+}
+
 // FindByOrderNo 根据订单号查找订单
 func (r *OrderRepository) FindByOrderNo(orderNo string) (*models.Order, error) {
 	var order models.Order
@@ -48,6 +62,27 @@ func (r *OrderRepository) FindByOrderNo(orderNo string) (*models.Order, error) {
 	return &order, err
 }
 
+// ExistsByOrderNo 检查订单号是否已被占用（含已软删除的订单，唯一索引同样覆盖）
+func (r *OrderRepository) ExistsByOrderNo(orderNo string) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Order{}).Where("order_no = ?", orderNo).Count(&count).Error
+	return count > 0, err
+}
+
+// FindLastOrderNoWithPrefix 查找指定前缀与长度的最大订单号，用于恢复每日序号
+func (r *OrderRepository) FindLastOrderNoWithPrefix(prefix string, length int) (string, error) {
+	var orderNos []string
+	err := r.db.Unscoped().Model(&models.Order{}).
+		Where("SUBSTR(order_no, 1, ?) = ? AND LENGTH(order_no) = ?", len(prefix), prefix, length).
+		Order("order_no DESC").
+		Limit(1).
+		Pluck("order_no", &orderNos).Error
+	if err != nil || len(orderNos) == 0 {
+		return "", err
+	}
+	return orderNos[0], nil
+}
+
 // FindByFormToken 根据表单Token查找订单
 func (r *OrderRepository) FindByFormToken(token string) (*models.Order, error) {
 	var order models.Order
@@ -106,6 +141,24 @@ func (r *OrderRepository) FindByUserID(userID uint, page, limit int, status stri
 	return orders, total, err
 }
 
+// FindByUserIDByCursor 按游标获取用户订单列表，返回下一页游标
+func (r *OrderRepository) FindByUserIDByCursor(userID uint, cursor string, limit int, status string) ([]models.Order, string, error) {
+	after, err := dbutil.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var orders []models.Order
+	query := r.db.Model(&models.Order{}).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := dbutil.ApplyCursor(query, after, limit).Find(&orders).Error; err != nil {
+		return nil, "", err
+	}
+	orders, next := dbutil.CursorPage(orders, limit, orderCursorKey)
+	return orders, next, nil
+}
+
 // CountByUserAndStatus returns the number of orders for a user with the specified status.
 func (r *OrderRepository) CountByUserAndStatus(userID uint, status models.OrderStatus) (int64, error) {
 	var total int64
@@ -162,6 +215,41 @@ func (r *OrderRepository) List(page, limit int, status, search, country, product
 	var orders []models.Order
 	var total int64
 
+	query := r.listQuery(status, search, country, productSearch, promoCodeID, promoCode, userID)
+
+	// 获取总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 分页Query
+	offset := (page - 1) * limit
+	err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&orders).Error
+
+	return orders, total, err
+}
+
+// ListByCursor 按游标获取订单列表（筛选条件同 List），返回下一页游标，不统计总数
+func (r *OrderRepository) ListByCursor(cursor string, limit int, status, search, country, productSearch string, promoCodeID *uint, promoCode string, userID *uint) ([]models.Order, string, error) {
+	after, err := dbutil.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var orders []models.Order
+	query := r.listQuery(status, search, country, productSearch, promoCodeID, promoCode, userID)
+	if err := dbutil.ApplyCursor(query, after, limit).Find(&orders).Error; err != nil {
+		return nil, "", err
+	}
+	orders, next := dbutil.CursorPage(orders, limit, orderCursorKey)
+	return orders, next, nil
+}
+
+func orderCursorKey(order models.Order) (time.Time, uint) {
+	return order.CreatedAt, order.ID
+}
+
+// listQuery 构造订单列表的筛选条件
+func (r *OrderRepository) listQuery(status, search, country, productSearch string, promoCodeID *uint, promoCode string, userID *uint) *gorm.DB {
 	query := r.db.Model(&models.Order{}).Preload("User")
 
 	if status != "" {
@@ -200,16 +288,50 @@ func (r *OrderRepository) List(page, limit int, status, search, country, product
 		query = query.Where("promo_code_str = ?", promoCode)
 	}
 
-	// 获取总数
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+	return query
+}
 
-	// 分页Query
-	offset := (page - 1) * limit
-	err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&orders).Error
+// OrderExportFilter 订单导出筛选条件，Status/Search/Country/ProductSearch/PromoCode 含义同 List；
+// SKU 精确匹配订单项，From/To 为创建时间范围（左闭右开）
+type OrderExportFilter struct {
+	UserID        *uint
+	Status        string
+	Search        string
+	Country       string
+	ProductSearch string
+	SKU           string
+	PromoCodeID   *uint
+	PromoCode     string
+	From          *time.Time
+	To            *time.Time
+}
 
-	return orders, total, err
+// FindExportBatch 按 id 倒序分批读取导出订单，beforeID 为上一批最后一条的 id（首批传 0）
+func (r *OrderRepository) FindExportBatch(filter OrderExportFilter, beforeID uint, limit int) ([]models.Order, error) {
+	query := r.listQuery(filter.Status, filter.Search, filter.Country, filter.ProductSearch, filter.PromoCodeID, filter.PromoCode, filter.UserID)
+	if filter.SKU != "" {
+		query = query.Where("items LIKE ? ESCAPE '\\'", orderItemSKUPattern(filter.SKU))
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var orders []models.Order
+	err := query.Order("id DESC").Limit(limit).Find(&orders).Error
+	return orders, err
+}
+
+// orderItemSKUPattern 匹配订单项 JSON 中 "sku":"<sku>" 片段的 LIKE 模式
+func orderItemSKUPattern(sku string) string {
+	encoded, _ := json.Marshal(sku)
+	fragment := `"sku":` + string(encoded)
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(fragment)
+	return "%" + escaped + "%"
 }
 
 // Update 更新订单
@@ -253,36 +375,26 @@ func (r *OrderRepository) GetOrderCountries() ([]string, error) {
 	return countries, nil
 }
 
-// IsOrderSharedToSupport 检查订单是否被分享到客服工单
-func (r *OrderRepository) IsOrderSharedToSupport(orderID uint) (bool, error) {
+// RefreshSharedToSupport 根据工单授权记录重新计算订单的 shared_to_support 标记
+// 授权新增、修改或撤销后调用，已过期的授权不计入，不更新 updated_at
+func (r *OrderRepository) RefreshSharedToSupport(orderID uint) error {
 	var count int64
-	err := r.db.Model(&models.TicketOrderAccess{}).
-		Where("order_id = ?", orderID).
-		Count(&count).Error
-	if err != nil {
-		return false, err
+	if err := r.db.Model(&models.TicketOrderAccess{}).
+		Where("order_id = ? AND (expires_at IS NULL OR expires_at > ?)", orderID, time.Now()).
+		Count(&count).Error; err != nil {
+		return err
 	}
-	return count > 0, nil
+	// 字段在模型上为只读，需按表名更新
+	return r.db.Table(models.Order{}.TableName()).
+		Where("id = ?", orderID).
+		UpdateColumn("shared_to_support", count > 0).Error
 }
 
-// GetSharedOrderIDs 获取指定订单ID列表中被分享到客服的订单ID集合
-func (r *OrderRepository) GetSharedOrderIDs(orderIDs []uint) (map[uint]bool, error) {
-	if len(orderIDs) == 0 {
-		return make(map[uint]bool), nil
-	}
-
-	var sharedOrderIDs []uint
-	err := r.db.Model(&models.TicketOrderAccess{}).
-		Where("order_id IN ?", orderIDs).
-		Distinct("order_id").
-		Pluck("order_id", &sharedOrderIDs).Error
-	if err != nil {
-		return nil, err
-	}
-
-	result := make(map[uint]bool)
-	for _, id := range sharedOrderIDs {
-		result[id] = true
-	}
-	return result, nil
+// ClearExpiredSharedToSupport 清除所有授权均已过期订单的 shared_to_support 标记，返回更新的订单数
+func (r *OrderRepository) ClearExpiredSharedToSupport(now time.Time) (int64, error) {
+	result := r.db.Table(models.Order{}.TableName()).
+		Where("shared_to_support = ?", true).
+		Where("NOT EXISTS (SELECT 1 FROM ticket_order_access toa WHERE toa.order_id = orders.id AND toa.deleted_at IS NULL AND (toa.expires_at IS NULL OR toa.expires_at > ?))", now).
+		UpdateColumn("shared_to_support", false)
+	return result.RowsAffected, result.Error
 }
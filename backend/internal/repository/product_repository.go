@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +19,11 @@ func NewProductRepository(db *gorm.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
+// WithContext 返回使用 ctx 执行查询的仓储，请求链路随之传递到数据库查询
+func (r *ProductRepository) WithContext(ctx context.Context) *ProductRepository {
+	return &ProductRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create CreateProduct
 func (r *ProductRepository) Create(product *models.Product) error {
 	return r.db.Create(product).Error
@@ -85,9 +92,9 @@ func (r *ProductRepository) FindBySKUs(skus []string) (map[string]*models.Produc
 	return productBySKU, nil
 }
 
-// Delete 删除商品（软删除）
-func (r *ProductRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Product{}, id).Error
+// Delete 删除商品（软删除，移入回收站），deletedBy 为操作人
+func (r *ProductRepository) Delete(id uint, deletedBy uint) error {
+	return dbutil.SoftDelete(r.db, &models.Product{}, id, deletedBy)
 }
 
 // List 获取商品列表
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 
 	"auralogic/internal/models"
@@ -16,6 +17,11 @@ func NewPromoCodeRepository(db *gorm.DB) *PromoCodeRepository {
 	return &PromoCodeRepository{db: db}
 }
 
+// WithContext 返回使用 ctx 执行查询的仓储，请求链路随之传递到数据库查询
+func (r *PromoCodeRepository) WithContext(ctx context.Context) *PromoCodeRepository {
+	return &PromoCodeRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create 创建优惠码
 func (r *PromoCodeRepository) Create(promoCode *models.PromoCode) error {
 	return r.db.Create(promoCode).Error
@@ -68,9 +74,9 @@ func (r *PromoCodeRepository) List(page, limit int, status string, search string
 	return promoCodes, total, err
 }
 
-// Delete 删除优惠码（软删除）
-func (r *PromoCodeRepository) Delete(id uint) error {
-	return r.db.Delete(&models.PromoCode{}, id).Error
+// Delete 删除优惠码（软删除，移入回收站），deletedBy 为操作人
+func (r *PromoCodeRepository) Delete(id uint, deletedBy uint) error {
+	return dbutil.SoftDelete(r.db, &models.PromoCode{}, id, deletedBy)
 }
 
 // Reserve 预留优惠码（下单时）
@@ -137,3 +143,12 @@ func (r *PromoCodeRepository) Deduct(promoCodeID uint, orderNo string) error {
 		return tx.Save(&promoCode).Error
 	})
 }
+
+// CountUserUsage 统计用户使用该优惠码的订单数（已取消的订单已释放优惠码，不计入）
+func (r *PromoCodeRepository) CountUserUsage(promoCodeID, userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Order{}).
+		Where("promo_code_id = ? AND user_id = ? AND status <> ?", promoCodeID, userID, models.OrderStatusCancelled).
+		Count(&count).Error
+	return count, err
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"auralogic/internal/models"
+	"context"
 	"gorm.io/gorm"
 	"strings"
 )
@@ -18,6 +19,7 @@ type UserListFilters struct {
 	EmailNotifyMarketing *bool
 	SMSNotifyMarketing   *bool
 	HasPhone             *bool
+	EmailFlagged         *bool
 	Locale               string
 	Country              string
 }
@@ -55,6 +57,11 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// WithContext 返回使用 ctx 执行查询的仓储，请求链路随之传递到数据库查询
+func (r *UserRepository) WithContext(ctx context.Context) *UserRepository {
+	return &UserRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create 创建用户
 func (r *UserRepository) Create(user *models.User) error {
 	return r.db.Create(user).Error
@@ -186,6 +193,14 @@ func (r *UserRepository) List(page, limit int, filters UserListFilters) ([]model
 		}
 	}
 
+	if filters.EmailFlagged != nil {
+		if *filters.EmailFlagged {
+			query = query.Where("email_risk_flag IS NOT NULL AND email_risk_flag <> ''")
+		} else {
+			query = query.Where("email_risk_flag IS NULL OR email_risk_flag = ''")
+		}
+	}
+
 	locale := strings.TrimSpace(filters.Locale)
 	if locale != "" {
 		query = query.Where("LOWER(locale) = LOWER(?)", locale)
@@ -6,6 +6,9 @@ import (
 	formHandler "auralogic/internal/handler/form"
 	userHandler "auralogic/internal/handler/user"
 	"auralogic/internal/middleware"
+	"auralogic/internal/models"
+	"auralogic/internal/openapi"
+	"auralogic/internal/pkg/jwt"
 	"auralogic/internal/pluginobs"
 	"auralogic/internal/repository"
 	"auralogic/internal/service"
@@ -29,6 +32,8 @@ func SetupRouter(
 	db *gorm.DB,
 	paymentPollingService *service.PaymentPollingService,
 	pluginManagerService *service.PluginManagerService,
+	waitingRoomService *service.WaitingRoomService,
+	jobScheduler *service.JobScheduler,
 	version string,
 ) *gin.Engine {
 	// 设置Gin模式
@@ -40,10 +45,15 @@ func SetupRouter(
 
 	// 全局中间件
 	r.Use(gin.Recovery())
+	r.Use(middleware.Tracing())
 	r.Use(middleware.Logger())
 	r.Use(middleware.CORS(&cfg.Security.CORS))
 	r.Use(middleware.SecurityHeaders()) // 添加安全响应头
 
+	// 站点横幅版本号随每个响应下发，前端据此刷新维护公告
+	siteBannerService := service.NewSiteBannerService(db)
+	r.Use(middleware.SiteBannerVersion(siteBannerService))
+
 	// CreateRepository
 	inventoryRepo := repository.NewInventoryRepository(db)
 	productRepo := repository.NewProductRepository(db)
@@ -73,33 +83,137 @@ func SetupRouter(
 
 	// CreateHandler
 	userAuthHandler := userHandler.NewAuthHandler(authService, emailService, smsService, pluginManagerService)
-	userOrderHandler := userHandler.NewOrderHandler(orderService, bindingService, virtualInventoryService, pluginManagerService, cfg)
+	virtualStockRevealService := service.NewVirtualStockRevealService(db, cfg, authService.OTP(), emailService)
+	userOrderHandler := userHandler.NewOrderHandler(orderService, bindingService, virtualInventoryService, virtualStockRevealService, pluginManagerService, cfg)
 	userProductHandler := userHandler.NewProductHandler(productService, orderService, bindingService, virtualInventoryService, pluginManagerService)
 	formShippingHandler := formHandler.NewShippingHandler(orderService, cfg)
 	jsRuntimeService := service.NewJSRuntimeService(db, cfg)
-	adminOrderHandler := adminHandler.NewOrderHandler(orderService, serialService, virtualInventoryService, jsRuntimeService, pluginManagerService, cfg)
+	adminOrderHandler := adminHandler.NewOrderHandler(orderService, serialService, virtualInventoryService, virtualStockRevealService, jsRuntimeService, pluginManagerService, cfg)
+	refundService := service.NewRefundService(db, orderService, jsRuntimeService)
+	refundRequestService := service.NewRefundRequestService(db, refundService)
+	userOrderRefundHandler := userHandler.NewOrderRefundHandler(orderService)
+	adminOrderRefundHandler := adminHandler.NewOrderRefundHandler(orderService, refundService, pluginManagerService, db)
+	adminPriceAdjustmentHandler := adminHandler.NewOrderPriceAdjustmentHandler(orderService, db)
+	userRefundRequestHandler := userHandler.NewRefundRequestHandler(refundRequestService)
+	documentService := service.NewDocumentService(db, cfg)
+	userDocumentHandler := userHandler.NewDocumentHandler(documentService, cfg)
+	adminRefundRequestHandler := adminHandler.NewRefundRequestHandler(refundRequestService, orderService, pluginManagerService, db)
 	adminProductHandler := adminHandler.NewProductHandler(productService, virtualInventoryService, pluginManagerService)
 	adminUserHandler := adminHandler.NewUserHandler(userRepo, db, cfg, pluginManagerService)
 	adminPermissionHandler := adminHandler.NewPermissionHandler(db, pluginManagerService)
 	adminAPIKeyHandler := adminHandler.NewAPIKeyHandler(db, pluginManagerService)
 	adminAdminHandler := adminHandler.NewAdminHandler(userRepo, db, cfg)
 	adminLogHandler := adminHandler.NewLogHandler(db, pluginManagerService)
+	adminJobHandler := adminHandler.NewJobHandler(jobScheduler)
+	adminTrashHandler := adminHandler.NewTrashHandler(db, service.NewTrashService(db, cfg, productService))
 	adminDashboardHandler := adminHandler.NewDashboardHandler(db, cfg, version)
 	adminAnalyticsHandler := adminHandler.NewAnalyticsHandler(db, cfg)
 	adminSettingsHandler := adminHandler.NewSettingsHandler(db, cfg, smsService, emailService, pluginManagerService)
 	adminUploadHandler := adminHandler.NewUploadHandler(cfg.Upload.Dir, cfg.App.URL, pluginManagerService)
 	adminInventoryHandler := adminHandler.NewInventoryHandler(inventoryService, db, pluginManagerService)
+	flashSaleService := service.NewFlashSaleService(db, cfg)
+	adminInventoryHandler.SetFlashSaleService(flashSaleService)
+	// 手动触发草稿清理使用独立实例，定时任务仍由 main 中注册的实例执行
+	adminOrderCancelService := service.NewOrderCancelService(db, cfg, inventoryRepo, promoCodeRepo, virtualInventoryService, serialService)
+	adminOrderCancelService.SetPluginManager(pluginManagerService)
+	adminOrderCancelService.SetFlashSaleService(flashSaleService)
+	adminOrderHandler.SetOrderCancelService(adminOrderCancelService)
 	adminBindingHandler := adminHandler.NewBindingHandler(bindingService, db, pluginManagerService)
+	adminLicenseActivationHandler := adminHandler.NewLicenseActivationHandler(bindingService, db)
+	// 超额计费定时任务由 main 中注册的实例执行
+	usageMeterService := service.NewUsageMeterService(db, cfg, bindingService, emailService)
+	usageMeterService.SetOrderService(orderService)
+	userOrderHandler.SetUsageMeterService(usageMeterService)
+	adminUsageMeterHandler := adminHandler.NewUsageMeterHandler(usageMeterService)
 	adminInventoryLogHandler := adminHandler.NewInventoryLogHandler(db)
+	adminStockReconciliationHandler := adminHandler.NewStockReconciliationHandler(service.NewStockReconciliationService(db, cfg, emailService), db)
+	// 快照定时任务由 main 中注册的实例执行
+	adminInventorySnapshotHandler := adminHandler.NewInventorySnapshotHandler(service.NewInventorySnapshotService(db), db)
+	// 低库存检查定时任务由 main 中注册的实例执行
+	adminInventoryStockAlertHandler := adminHandler.NewInventoryStockAlertHandler(service.NewInventoryStockAlertService(db, cfg, emailService), db)
 	adminSerialHandler := adminHandler.NewSerialHandler(serialService, pluginManagerService)
 	userSerialHandler := userHandler.NewSerialHandler(serialService)
 	userCartHandler := userHandler.NewCartHandler(cartService, pluginManagerService)
-	adminVirtualInventoryHandler := adminHandler.NewVirtualInventoryHandler(virtualInventoryService, db, pluginManagerService)
+	userWaitingRoomHandler := userHandler.NewWaitingRoomHandler(waitingRoomService)
+	adminWaitingRoomHandler := adminHandler.NewWaitingRoomHandler(waitingRoomService, db)
+	// 试用到期处理定时任务由 main 中注册的实例执行
+	productTrialService := service.NewProductTrialService(db, cfg, emailService)
+	productTrialService.SetOrderService(orderService)
+	userProductTrialHandler := userHandler.NewProductTrialHandler(productTrialService)
+	adminProductTrialHandler := adminHandler.NewProductTrialHandler(productTrialService, db)
+	// 自动补货定时任务由 main 中注册的实例执行
+	adminVirtualInventoryRestockHandler := adminHandler.NewVirtualInventoryRestockHandler(
+		service.NewVirtualInventoryRestockService(db, cfg, virtualInventoryService, emailService), db)
+	adminVirtualStockResaleHandler := adminHandler.NewVirtualStockResaleHandler(service.NewVirtualStockResaleService(db), db)
+	orderRateCapService := service.NewOrderRateCapService(db, cfg)
+	adminOrderRateCapHandler := adminHandler.NewOrderRateCapHandler(orderRateCapService, db)
+	adminFXSettlementHandler := adminHandler.NewFXSettlementHandler(service.NewFXSettlementService(db, cfg))
+	adminVendorHandler := adminHandler.NewVendorHandler(service.NewVendorSettlementService(db), db)
+	adminRevenueRecognitionHandler := adminHandler.NewRevenueRecognitionHandler(service.NewRevenueRecognitionService(db), db)
+	netTermsService := service.NewNetTermsService(db, cfg, orderService)
+	userBusinessAccountHandler := userHandler.NewBusinessAccountHandler(netTermsService)
+	adminBusinessAccountHandler := adminHandler.NewBusinessAccountHandler(netTermsService, db)
+	codService := service.NewCODService(db, cfg, orderService)
+	userCODHandler := userHandler.NewCODHandler(codService)
+	adminCODHandler := adminHandler.NewCODHandler(codService, db)
+	userOrganizationHandler := userHandler.NewOrganizationHandler(service.NewOrganizationService(db, cfg, emailService))
+	userPersonalTokenHandler := userHandler.NewPersonalTokenHandler(service.NewPersonalAccessTokenService(db))
+	userSiteBannerHandler := userHandler.NewSiteBannerHandler(siteBannerService)
+	adminSiteBannerHandler := adminHandler.NewSiteBannerHandler(siteBannerService, db)
+	adminAccountingExportHandler := adminHandler.NewAccountingExportHandler(service.NewAccountingExportService(db, cfg), db)
+	adminVirtualInventoryHandler := adminHandler.NewVirtualInventoryHandler(virtualInventoryService, service.NewSupplierHealthService(db, cfg), service.NewScriptApprovalService(db, cfg), db, pluginManagerService)
+	adminVirtualInventoryHandler.SetOrderService(orderService)
 	adminPaymentMethodHandler := adminHandler.NewPaymentMethodHandler(db, cfg, pluginManagerService)
+	adminPaymentLinkHandler := adminHandler.NewPaymentLinkHandler(db, service.NewPaymentLinkService(db, cfg), emailService, smsService)
+	adminOrderHandler.SetPaymentLinkHandler(adminPaymentLinkHandler)
+	giftCardService := service.NewGiftCardService(db, cfg)
+	adminOrderCancelService.SetGiftCardService(giftCardService)
+	adminGiftCardHandler := adminHandler.NewGiftCardHandler(giftCardService, db)
+	userGiftCardHandler := userHandler.NewGiftCardHandler(giftCardService)
+	quoteService := service.NewQuoteService(db, cfg, orderService, emailService)
+	adminQuoteHandler := adminHandler.NewQuoteHandler(quoteService, cfg, db)
+	userQuoteHandler := userHandler.NewQuoteHandler(quoteService, cfg, db)
+	returnService := service.NewReturnService(db, cfg)
+	userReturnHandler := userHandler.NewReturnHandler(returnService, db)
+	adminReturnHandler := adminHandler.NewReturnHandler(returnService, db)
+	orderDisputeService := service.NewOrderDisputeService(db, cfg, refundService)
+	userOrderDisputeHandler := userHandler.NewOrderDisputeHandler(orderDisputeService, db)
+	adminOrderDisputeHandler := adminHandler.NewOrderDisputeHandler(orderDisputeService, orderService, pluginManagerService, db)
+	spendingControlService := service.NewSpendingControlService(db, cfg, emailService)
+	userSpendingControlHandler := userHandler.NewSpendingControlHandler(spendingControlService, db)
+	adminSpendingControlHandler := adminHandler.NewSpendingControlHandler(spendingControlService, db)
+	policyConsentService := service.NewPolicyConsentService(db)
+	userPolicyConsentHandler := userHandler.NewPolicyConsentHandler(policyConsentService)
+	adminPolicyHandler := adminHandler.NewPolicyHandler(policyConsentService, db)
+	twoFactorService := service.NewTwoFactorService(db, cfg, authService.OTP(), emailService)
+	userTwoFactorHandler := userHandler.NewTwoFactorHandler(twoFactorService, authService)
+	userPasskeyHandler := userHandler.NewPasskeyHandler(service.NewPasskeyService(db, cfg), authService)
+	adminTwoFactorHandler := adminHandler.NewTwoFactorHandler(twoFactorService, db)
 	userPaymentMethodHandler := userHandler.NewPaymentMethodHandler(db, paymentPollingService, pluginManagerService, cfg)
+	blocklistService := service.NewBlocklistService(db)
+	authService.SetBlocklist(blocklistService)
+	authService.SetEmailValidation(service.NewEmailValidationService(cfg))
+	userOrderHandler.SetBlocklist(blocklistService)
+	adminBlocklistHandler := adminHandler.NewBlocklistHandler(blocklistService, db)
+	velocityRuleService := service.NewVelocityRuleService(db)
+	orderService.SetVelocityRuleService(velocityRuleService)
+	adminVelocityRuleHandler := adminHandler.NewVelocityRuleHandler(velocityRuleService, orderService, db)
+	contentModerationService := service.NewContentModerationService(db, cfg)
+	orderService.SetContentModeration(contentModerationService)
+	adminModerationHandler := adminHandler.NewModerationHandler(contentModerationService, db)
 	userTicketHandler := userHandler.NewTicketHandler(db, emailService, pluginManagerService)
+	userTicketHandler.SetContentModeration(contentModerationService)
 	adminTicketHandler := adminHandler.NewTicketHandler(db, emailService, pluginManagerService)
 	adminPromoCodeHandler := adminHandler.NewPromoCodeHandler(promoCodeService, pluginManagerService, db)
+	adminPromoCodeCampaignHandler := adminHandler.NewPromoCodeCampaignHandler(service.NewPromoCodeCampaignService(db), db)
+	pricingRuleService := service.NewPricingRuleService(db)
+	orderService.SetPricingRuleService(pricingRuleService)
+	adminPricingRuleHandler := adminHandler.NewPricingRuleHandler(pricingRuleService, db)
+	inboundWebhookService := service.NewInboundWebhookService(db, orderService)
+	adminInboundWebhookHandler := adminHandler.NewInboundWebhookHandler(inboundWebhookService, db)
+	adminIntegrationHandler := adminHandler.NewIntegrationHandler(service.NewIntegrationService(db, emailService), db)
+	adminTemplateAssetHandler := adminHandler.NewTemplateAssetHandler(service.NewTemplateAssetService(db, cfg), db)
+	adminDocumentTypeHandler := adminHandler.NewDocumentTypeHandler(documentService, db, cfg)
 	userPromoCodeHandler := userHandler.NewPromoCodeHandler(promoCodeService, pluginManagerService)
 	adminKnowledgeHandler := adminHandler.NewKnowledgeHandler(db, pluginManagerService)
 	adminAnnouncementHandler := adminHandler.NewAnnouncementHandler(db, emailService, smsService, pluginManagerService)
@@ -115,7 +229,8 @@ func SetupRouter(
 	{
 		form.GET("/shipping", formShippingHandler.GetForm)
 		form.POST("/shipping", formShippingHandler.SubmitForm)
-		form.GET("/countries", formShippingHandler.GetCountries) // get国家列表
+		form.GET("/countries", formShippingHandler.GetCountries)            // get国家列表
+		form.GET("/address-schemas", formShippingHandler.GetAddressSchemas) // 各国电话区号与地址格式
 	}
 
 	// ========== 序列号查询API（公开，无需登录） ==========
@@ -127,6 +242,41 @@ func SetupRouter(
 		serialAPI.GET("/:serial_number", userSerialHandler.GetSerialByNumber)
 	}
 
+	// ========== 免登录订单查询API（游客订单，凭订单号+邮箱或签名链接） ==========
+	orderTrackingHandler := userHandler.NewOrderTrackingHandler(db, cfg)
+	orderTrackingAPI := r.Group("/api/order-tracking")
+	orderTrackingAPI.Use(middleware.RateLimitMiddleware(10, time.Minute)) // 每分钟最多10次，防止暴力枚举
+	{
+		orderTrackingAPI.POST("/lookup", orderTrackingHandler.Lookup)
+		orderTrackingAPI.GET("/link", orderTrackingHandler.GetBySignedLink)
+	}
+
+	// ========== 卡密设备激活API（商户软件调用，凭卡密鉴权） ==========
+	licenseHandler := userHandler.NewLicenseHandler(bindingService)
+	licenseAPI := r.Group("/api/licenses")
+	licenseAPI.Use(middleware.RateLimitMiddleware(60, time.Minute))
+	{
+		licenseAPI.POST("/activate", licenseHandler.Activate)
+		licenseAPI.POST("/validate", licenseHandler.Validate)
+		licenseAPI.POST("/deactivate", licenseHandler.Deactivate)
+		licenseAPI.POST("/transfer", licenseHandler.Transfer)
+	}
+
+	// ========== 免登录付款链接API（电话下单客户、代付人凭签名链接付款） ==========
+	paymentLinkAPI := r.Group("/api/payment-link")
+	paymentLinkAPI.Use(middleware.RateLimitMiddleware(30, time.Minute))
+	{
+		paymentLinkAPI.GET("", userPaymentMethodHandler.GetByPaymentLink)
+		paymentLinkAPI.POST("/select-payment", userPaymentMethodHandler.SelectByPaymentLink)
+	}
+
+	// ========== 第一方行为事件上报（无需登录，不使用 Cookie，服务端划分会话） ==========
+	analyticsEventHandler := userHandler.NewAnalyticsEventHandler(service.NewAnalyticsEventService(db, cfg), cfg)
+	r.POST("/api/events", middleware.RateLimitMiddleware(120, time.Minute), analyticsEventHandler.Record)
+
+	// ========== 工单附件签名下载（无需登录，凭有时效的签名链接） ==========
+	r.GET("/api/tickets/attachments/:id/download", userTicketHandler.DownloadAttachment)
+
 	// ========== 公开配置API（无需登录） ==========
 	configAPI := r.Group("/api/config")
 	publicPluginMiddlewares := []gin.HandlerFunc{
@@ -160,6 +310,7 @@ func SetupRouter(
 	{
 		configAPI.GET("/public", adminSettingsHandler.GetPublicConfig)
 		configAPI.GET("/page-inject", adminSettingsHandler.GetPageInject)
+		configAPI.GET("/banners", middleware.OptionalAuthMiddleware(), userSiteBannerHandler.GetActiveBanners)
 		configAPI.GET("/plugin-extensions", append(publicPluginMiddlewares, adminPluginHandler.GetPublicExtensions)...)
 		configAPI.POST("/plugin-extensions/batch", append(publicPluginMiddlewares, adminPluginHandler.GetPublicExtensionsBatch)...)
 		configAPI.GET("/plugin-bootstrap", append(publicPluginMiddlewares, adminPluginHandler.GetPublicFrontendBootstrap)...)
@@ -179,6 +330,15 @@ func SetupRouter(
 	{
 		paymentPublicAPI.Any("/:id/webhooks/:hook", append(paymentWebhookMiddlewares, userPaymentMethodHandler.HandleWebhook)...)
 	}
+	// 物流服务商轨迹回调，签名在服务内按配置的服务商校验
+	carrierTrackingHandler := userHandler.NewCarrierTrackingHandler(service.NewCarrierTrackingService(db, cfg))
+	r.POST("/api/carrier-tracking/webhook", append(paymentWebhookMiddlewares, carrierTrackingHandler.HandleWebhook)...)
+	// 第三方系统入站事件，按端点密钥校验后交给转换脚本处理
+	inboundWebhookHandler := userHandler.NewInboundWebhookHandler(inboundWebhookService)
+	r.POST("/api/webhooks/inbound/:slug", append(paymentWebhookMiddlewares, inboundWebhookHandler.HandleWebhook)...)
+
+	// API Key 认证的请求按 Key 计数，由 AuthMiddleware 在认证通过后执行，覆盖所有接受 API Key 的路由组
+	middleware.SetAPIKeyRateLimitPolicy(resolveRateLimitPolicy(config.RateLimitPolicyAPIKey))
 
 	// ========== User端API ==========
 	userAPI := r.Group("/api/user")
@@ -191,18 +351,19 @@ func SetupRouter(
 		auth.Use(middleware.DynamicRateLimitMiddleware(resolveRateLimit(func(runtimeCfg *config.Config) int {
 			return runtimeCfg.RateLimit.UserLogin
 		}, 0), time.Minute))
+		loginRateLimit := middleware.RateLimitPolicyMiddleware(config.RateLimitPolicyLogin, resolveRateLimitPolicy(config.RateLimitPolicyLogin))
 		{
-			auth.POST("/login", userAuthHandler.Login)
+			auth.POST("/login", loginRateLimit, userAuthHandler.Login)
 			auth.POST("/register", userAuthHandler.Register)
 			auth.GET("/captcha", userAuthHandler.GetCaptcha)
 			auth.GET("/verify-email", userAuthHandler.VerifyEmail)
 			auth.POST("/resend-verification", userAuthHandler.ResendVerification)
 			auth.POST("/send-login-code", userAuthHandler.SendLoginCode)
-			auth.POST("/login-with-code", userAuthHandler.LoginWithCode)
+			auth.POST("/login-with-code", loginRateLimit, userAuthHandler.LoginWithCode)
 			auth.POST("/forgot-password", userAuthHandler.ForgotPassword)
 			auth.POST("/reset-password", userAuthHandler.ResetPassword)
 			auth.POST("/send-phone-code", userAuthHandler.SendPhoneLoginCode)
-			auth.POST("/login-with-phone-code", userAuthHandler.LoginWithPhoneCode)
+			auth.POST("/login-with-phone-code", loginRateLimit, userAuthHandler.LoginWithPhoneCode)
 			auth.POST("/send-phone-register-code", userAuthHandler.SendPhoneRegisterCode)
 			auth.POST("/phone-register", userAuthHandler.PhoneRegister)
 			auth.POST("/phone-forgot-password", userAuthHandler.PhoneForgotPassword)
@@ -215,6 +376,26 @@ func SetupRouter(
 			auth.POST("/bind-email", middleware.AuthMiddleware(), userAuthHandler.BindEmail)
 			auth.POST("/send-bind-phone-code", middleware.AuthMiddleware(), userAuthHandler.SendBindPhoneCode)
 			auth.POST("/bind-phone", middleware.AuthMiddleware(), userAuthHandler.BindPhone)
+
+			// 两步验证：登录第二步与找回只接受 2fa_pending 受限令牌，绑定同时接受管理员强制绑定的受限令牌
+			twoFactorPending := middleware.TwoFactorSessionMiddleware(jwt.ScopeTwoFactorPending)
+			twoFactorSetup := middleware.TwoFactorSessionMiddleware(jwt.ScopeFull, jwt.ScopeTwoFactorSetup)
+			auth.GET("/2fa", middleware.AuthMiddleware(), userTwoFactorHandler.GetStatus)
+			auth.POST("/2fa/setup", twoFactorSetup, userTwoFactorHandler.Setup)
+			auth.POST("/2fa/enable", twoFactorSetup, userTwoFactorHandler.Enable)
+			auth.POST("/2fa/disable", middleware.AuthMiddleware(), userTwoFactorHandler.Disable)
+			auth.POST("/2fa/backup-codes", middleware.AuthMiddleware(), userTwoFactorHandler.RegenerateBackupCodes)
+			auth.POST("/2fa/verify", twoFactorPending, userTwoFactorHandler.Verify)
+			auth.POST("/2fa/recovery/send", twoFactorPending, userTwoFactorHandler.SendRecoveryCode)
+			auth.POST("/2fa/recovery", twoFactorPending, userTwoFactorHandler.Recover)
+
+			// 通行密钥：登录仪式公开（沿用登录限流），管理只接受完整会话
+			auth.POST("/passkey/login/begin", userPasskeyHandler.BeginLogin)
+			auth.POST("/passkey/login/finish", userPasskeyHandler.FinishLogin)
+			auth.GET("/passkeys", middleware.AuthMiddleware(), userPasskeyHandler.List)
+			auth.POST("/passkeys/register/begin", middleware.AuthMiddleware(), userPasskeyHandler.BeginRegistration)
+			auth.POST("/passkeys/register/finish", middleware.AuthMiddleware(), userPasskeyHandler.FinishRegistration)
+			auth.DELETE("/passkeys/:id", middleware.AuthMiddleware(), userPasskeyHandler.Delete)
 		}
 
 		// Order
@@ -223,18 +404,92 @@ func SetupRouter(
 		{
 			orders.POST("", middleware.DynamicRateLimitMiddleware(resolveRateLimit(func(runtimeCfg *config.Config) int {
 				return runtimeCfg.RateLimit.OrderCreate
-			}, 30), time.Minute), userOrderHandler.CreateOrder)
+			}, 30), time.Minute),
+				middleware.RateLimitPolicyMiddleware(config.RateLimitPolicyOrderCreate, resolveRateLimitPolicy(config.RateLimitPolicyOrderCreate)),
+				middleware.WaitingRoomMiddleware(waitingRoomService),
+				middleware.OrderRateCapMiddleware(orderRateCapService),
+				userOrderHandler.CreateOrder)
 			orders.GET("", userOrderHandler.ListOrders)
+			orders.GET("/export", middleware.RateLimitMiddleware(10, time.Minute), userOrderHandler.ExportOrders)
 			orders.GET("/:order_no", userOrderHandler.GetOrder)
+			orders.GET("/:order_no/timeline", userOrderHandler.GetOrderTimeline)
 			orders.GET("/:order_no/form-token", userOrderHandler.GetOrRefreshFormToken)
 			orders.GET("/:order_no/virtual-products", userOrderHandler.GetVirtualProducts)
+			orders.POST("/:order_no/virtual-products/reauth", userOrderHandler.ReauthVirtualProducts)
+			orders.POST("/:order_no/virtual-products/reauth/send-code", userOrderHandler.SendVirtualProductsReauthCode)
+			orders.GET("/:order_no/license-activations", userOrderHandler.ListLicenseActivations)
+			orders.POST("/:order_no/license-activations/:activation_id/deactivate", userOrderHandler.DeactivateLicenseActivation)
+			orders.GET("/:order_no/usage-meters", userOrderHandler.ListUsageMeters)
 			orders.POST("/:order_no/complete", userOrderHandler.CompleteOrder)
+			orders.POST("/:order_no/payment-link", userPaymentMethodHandler.CreatePaymentLink)
 			orders.GET("/:order_no/invoice", userOrderHandler.DownloadInvoice)
+			orders.GET("/:order_no/invoice.pdf", userOrderHandler.DownloadInvoicePDF)
 			orders.GET("/:order_no/invoice-token", userOrderHandler.GetInvoiceToken)
+			orders.GET("/:order_no/documents", userDocumentHandler.ListOrderDocuments)
+			orders.GET("/:order_no/documents/:key/token", userDocumentHandler.GetDocumentToken)
+			orders.POST("/:order_no/refund-request", userRefundRequestHandler.CreateRefundRequest)
+			orders.GET("/:order_no/refund-requests", userRefundRequestHandler.ListRefundRequests)
+			orders.POST("/:order_no/partial-refunds", userOrderRefundHandler.CreateOrderRefund)
+			orders.GET("/:order_no/partial-refunds", userOrderRefundHandler.ListOrderRefunds)
+			orders.POST("/:order_no/returns", userReturnHandler.CreateReturn)
+			orders.GET("/:order_no/returns", userReturnHandler.ListReturns)
+			orders.GET("/:order_no/shares", userOrderHandler.ListOrderShares)
+			orders.PUT("/:order_no/shares/:ticket_id", userOrderHandler.UpdateOrderShare)
+			orders.DELETE("/:order_no/shares/:ticket_id", userOrderHandler.RevokeOrderShare)
+			orders.POST("/:order_no/net-terms", userBusinessAccountHandler.PayOrderOnTerms)
+			orders.GET("/:order_no/cash-on-delivery", userCODHandler.GetEligibility)
+			orders.POST("/:order_no/cash-on-delivery", userCODHandler.PayOrderCOD)
+		}
+
+		// 企业账户与账期发票
+		businessAccount := userAPI.Group("/business-account")
+		businessAccount.Use(middleware.AuthMiddleware())
+		{
+			businessAccount.GET("", userBusinessAccountHandler.GetAccount)
+			businessAccount.POST("", userBusinessAccountHandler.Apply)
+			businessAccount.GET("/invoices", userBusinessAccountHandler.ListInvoices)
+			businessAccount.GET("/statement", userBusinessAccountHandler.GetStatement)
+		}
+
+		// 组织账户：成员共享订单可见性
+		organization := userAPI.Group("/organization")
+		organization.Use(middleware.AuthMiddleware())
+		{
+			organization.GET("", userOrganizationHandler.GetOrganization)
+			organization.POST("", userOrganizationHandler.CreateOrganization)
+			organization.PUT("", userOrganizationHandler.RenameOrganization)
+			organization.GET("/orders", userOrganizationHandler.ListOrders)
+			organization.GET("/invitations", userOrganizationHandler.ListInvitations)
+			organization.POST("/invitations", userOrganizationHandler.InviteMember)
+			organization.DELETE("/invitations/:id", userOrganizationHandler.RevokeInvitation)
+			organization.GET("/invitations/preview", userOrganizationHandler.PreviewInvitation)
+			organization.POST("/invitations/accept", userOrganizationHandler.AcceptInvitation)
+			organization.PUT("/members/:user_id", userOrganizationHandler.UpdateMember)
+			organization.DELETE("/members/:user_id", userOrganizationHandler.RemoveMember)
+			organization.POST("/leave", userOrganizationHandler.Leave)
+		}
+
+		// 个人访问令牌管理（仅限登录会话）
+		apiTokens := userAPI.Group("/api-tokens")
+		apiTokens.Use(middleware.AuthMiddleware())
+		{
+			apiTokens.GET("", userPersonalTokenHandler.ListTokens)
+			apiTokens.POST("", userPersonalTokenHandler.CreateToken)
+			apiTokens.DELETE("/:id", userPersonalTokenHandler.RevokeToken)
+		}
+
+		// 自动化只读接口：使用个人访问令牌认证，按权限范围放行
+		automation := userAPI.Group("/automation")
+		{
+			automation.GET("/orders", middleware.PersonalAccessTokenAuth(models.PersonalTokenScopeOrdersRead), userOrderHandler.ListOrders)
+			automation.GET("/orders/:order_no", middleware.PersonalAccessTokenAuth(models.PersonalTokenScopeOrdersRead), userOrderHandler.GetOrder)
+			automation.GET("/orders/:order_no/invoice.pdf", middleware.PersonalAccessTokenAuth(models.PersonalTokenScopeOrdersRead), userOrderHandler.DownloadInvoicePDF)
+			automation.GET("/orders/:order_no/virtual-products", middleware.PersonalAccessTokenAuth(models.PersonalTokenScopeVirtualProductsRead), userOrderHandler.GetVirtualProducts)
 		}
 
 		// 账单公开访问（通过一次性令牌认证）
 		userAPI.GET("/invoice/:token", userOrderHandler.ViewInvoiceByToken)
+		userAPI.GET("/documents/:token", userDocumentHandler.ViewDocumentByToken)
 
 		// Product（推荐商品公开访问；列表/详情按配置动态控制是否需要登录）
 		productsPublic := userAPI.Group("/products")
@@ -252,6 +507,22 @@ func SetupRouter(
 			products.GET("/:id/available-stock", userProductHandler.GetProductAvailableStock)
 		}
 
+		// 限量发售等候室
+		waitingRoom := userAPI.Group("/waiting-room")
+		waitingRoom.Use(middleware.AuthMiddleware())
+		{
+			waitingRoom.POST("/:id/join", userWaitingRoomHandler.Join)
+			waitingRoom.GET("/:id", userWaitingRoomHandler.Poll)
+		}
+
+		// 虚拟商品试用
+		trials := userAPI.Group("/trials")
+		trials.Use(middleware.AuthMiddleware())
+		{
+			trials.GET("", userProductTrialHandler.List)
+			trials.POST("/products/:id", userProductTrialHandler.Start)
+		}
+
 		// 购物车
 		cart := userAPI.Group("/cart")
 		cart.Use(middleware.AuthMiddleware())
@@ -271,6 +542,49 @@ func SetupRouter(
 			promoCodes.POST("/validate", userPromoCodeHandler.ValidatePromoCode)
 		}
 
+		// 礼品卡余额查询（限流防止枚举卡号）
+		giftCards := userAPI.Group("/gift-cards")
+		giftCards.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(10, time.Minute))
+		{
+			giftCards.POST("/check", userGiftCardHandler.Check)
+		}
+
+		// 报价单
+		quotes := userAPI.Group("/quotes")
+		quotes.Use(middleware.AuthMiddleware())
+		{
+			quotes.GET("", userQuoteHandler.List)
+			quotes.GET("/:quote_no", userQuoteHandler.Get)
+			quotes.GET("/:quote_no/document", userQuoteHandler.Document)
+			quotes.GET("/:quote_no/document.pdf", userQuoteHandler.DocumentPDF)
+			quotes.POST("/:quote_no/accept", userQuoteHandler.Accept)
+			quotes.POST("/:quote_no/decline", userQuoteHandler.Decline)
+		}
+
+		// 退货物流登记
+		returns := userAPI.Group("/returns")
+		returns.Use(middleware.AuthMiddleware())
+		{
+			returns.POST("/:return_no/tracking", userReturnHandler.SubmitTracking)
+		}
+
+		// 账户消费控制
+		spendingControls := userAPI.Group("/spending-controls")
+		spendingControls.Use(middleware.AuthMiddleware())
+		{
+			spendingControls.GET("", userSpendingControlHandler.GetSpendingControl)
+			spendingControls.PUT("", userSpendingControlHandler.UpdateSpendingControl)
+			spendingControls.DELETE("/pending", userSpendingControlHandler.CancelPendingSpendingControl)
+		}
+
+		// 服务条款/隐私政策同意记录
+		consents := userAPI.Group("/consents")
+		consents.Use(middleware.AuthMiddleware())
+		{
+			consents.GET("", userPolicyConsentHandler.GetConsents)
+			consents.POST("", userPolicyConsentHandler.AcceptPolicies)
+		}
+
 		// 付款方式（需要登录）
 		payment := userAPI.Group("/payment-methods")
 		payment.Use(middleware.AuthMiddleware())
@@ -289,6 +603,9 @@ func SetupRouter(
 			paymentAuth.POST("/:order_no/select-payment", middleware.DynamicRateLimitMiddleware(resolveRateLimit(func(runtimeCfg *config.Config) int {
 				return runtimeCfg.RateLimit.PaymentSelect
 			}, 60), time.Minute), userPaymentMethodHandler.SelectPaymentMethod)
+			paymentAuth.POST("/:order_no/checkout-abandon", middleware.DynamicRateLimitMiddleware(resolveRateLimit(func(runtimeCfg *config.Config) int {
+				return runtimeCfg.RateLimit.PaymentInfo
+			}, 120), time.Minute), userPaymentMethodHandler.AbandonCheckout)
 		}
 
 		// 工单/客服中心
@@ -296,15 +613,20 @@ func SetupRouter(
 		tickets.Use(middleware.AuthMiddleware(), middleware.RequireTicketEnabled())
 		{
 			tickets.POST("", userTicketHandler.CreateTicket)
+			tickets.POST("/attachments", userTicketHandler.UploadIntakeAttachment)
 			tickets.GET("", userTicketHandler.ListTickets)
 			tickets.GET("/:id", userTicketHandler.GetTicket)
 			tickets.GET("/:id/messages", userTicketHandler.GetTicketMessages)
-			tickets.POST("/:id/messages", userTicketHandler.SendMessage)
+			tickets.POST("/:id/messages", middleware.RateLimitPolicyMiddleware(config.RateLimitPolicyTicketMessage, resolveRateLimitPolicy(config.RateLimitPolicyTicketMessage)), userTicketHandler.SendMessage)
 			tickets.PUT("/:id/status", userTicketHandler.UpdateTicketStatus)
+			tickets.POST("/:id/rating", userTicketHandler.RateTicket)
 			tickets.POST("/:id/share-order", userTicketHandler.ShareOrder)
 			tickets.GET("/:id/shared-orders", userTicketHandler.GetSharedOrders)
 			tickets.DELETE("/:id/shared-orders/:orderId", userTicketHandler.RevokeOrderAccess)
 			tickets.POST("/:id/upload", userTicketHandler.UploadFile)
+			tickets.POST("/:id/attachments", userTicketHandler.UploadAttachment)
+			tickets.GET("/:id/dispute", userOrderDisputeHandler.GetDispute)
+			tickets.POST("/:id/dispute", userOrderDisputeHandler.EscalateDispute)
 		}
 
 		// 知识库
@@ -332,6 +654,13 @@ func SetupRouter(
 	adminAPI.Use(middleware.DynamicRateLimitMiddleware(resolveRateLimit(func(runtimeCfg *config.Config) int {
 		return runtimeCfg.RateLimit.AdminRequest
 	}, 0), time.Minute))
+	// 管理端写操作审计，按配置的路由分组启用
+	adminAPI.Use(middleware.AdminAudit(db, func() *config.AdminAuditConfig {
+		if runtimeCfg := config.GetConfig(); runtimeCfg != nil {
+			return &runtimeCfg.Log.AdminAudit
+		}
+		return nil
+	}))
 	{
 		adminAPI.GET("/plugin-bootstrap", middleware.AuthMiddleware(), middleware.RequireAdmin(), middleware.RequirePermission("plugin.view"), adminPluginHandler.GetAdminFrontendBootstrap)
 		adminAPI.GET("/plugin-extensions", middleware.AuthMiddleware(), middleware.RequireAdmin(), middleware.RequirePermission("plugin.view"), adminPluginHandler.GetAdminExtensions)
@@ -345,6 +674,13 @@ func SetupRouter(
 			dashboard.GET("/activities", adminDashboardHandler.GetRecentActivities)
 		}
 
+		// 精简概览（仅超级管理员，供移动端/小组件使用）
+		overview := adminAPI.Group("/overview")
+		overview.Use(middleware.AuthMiddleware(), middleware.RequireSuperAdmin())
+		{
+			overview.GET("", adminDashboardHandler.GetOverview)
+		}
+
 		// 数据分析（仅超级管理员）
 		analytics := adminAPI.Group("/analytics")
 		analytics.Use(middleware.AuthMiddleware(), middleware.RequireSuperAdmin())
@@ -354,6 +690,9 @@ func SetupRouter(
 			analytics.GET("/revenue", adminAnalyticsHandler.GetRevenueAnalytics)
 			analytics.GET("/devices", adminAnalyticsHandler.GetDeviceAnalytics)
 			analytics.GET("/pageviews", adminAnalyticsHandler.GetPageViewAnalytics)
+			analytics.GET("/funnel", adminAnalyticsHandler.GetFunnelAnalytics)
+			analytics.GET("/sku-sales", adminAnalyticsHandler.GetSKUSalesAnalytics)
+			analytics.GET("/sku-sales/export", adminAnalyticsHandler.ExportSKUSalesAnalytics)
 		}
 
 		// Order管理（needAdminPermission）
@@ -362,7 +701,18 @@ func SetupRouter(
 		{
 			orders.GET("", middleware.RequirePermission("order.view"), adminOrderHandler.ListOrders)
 			orders.GET("/countries", middleware.RequirePermission("order.view"), adminOrderHandler.GetOrderCountries)
+			orders.GET("/packing-slips", middleware.RequirePermission("order.view"), adminOrderHandler.GetDailyPackingSlips)
 			orders.GET("/:id", middleware.RequirePermission("order.view"), adminOrderHandler.GetOrder)
+			orders.GET("/:id/virtual-reveal-logs", middleware.RequirePermission("order.view"), adminOrderHandler.GetVirtualRevealLogs)
+			orders.GET("/:id/license-activations", middleware.RequirePermission("order.view"), adminLicenseActivationHandler.ListActivations)
+			orders.POST("/:id/license-activations/:activation_id/deactivate", middleware.RequirePermission("order.edit"), adminLicenseActivationHandler.DeactivateActivation)
+			orders.GET("/:id/usage-meters", middleware.RequirePermission("order.view"), adminUsageMeterHandler.ListOrderMeters)
+			orders.GET("/:id/full", middleware.RequirePermission("order.view"), adminOrderHandler.GetOrderFull)
+			orders.GET("/:id/timeline", middleware.RequirePermission("order.view"), adminOrderHandler.GetOrderTimeline)
+			orders.POST("/:id/remarks", middleware.RequirePermission("order.edit"), adminOrderHandler.AddOrderRemark)
+			orders.GET("/:id/packing-slip", middleware.RequirePermission("order.view"), adminOrderHandler.GetPackingSlip)
+			orders.GET("/:id/documents", middleware.RequirePermission("order.view"), adminDocumentTypeHandler.ListOrderDocuments)
+			orders.GET("/:id/documents/:key", middleware.RequirePermission("order.view"), adminDocumentTypeHandler.RenderOrderDocument)
 			orders.POST("/draft", middleware.RequirePermission("order.edit"), adminOrderHandler.CreateDraft)
 			orders.POST("", middleware.RequirePermission("order.edit"), adminOrderHandler.CreateOrderForUser)
 			orders.POST("/:id/assign-shipping", middleware.RequirePermission("order.assign_tracking"), adminOrderHandler.AssignTracking)
@@ -372,14 +722,20 @@ func SetupRouter(
 			orders.POST("/:id/cancel", middleware.RequirePermission("order.status_update"), adminOrderHandler.CancelOrder)
 			orders.POST("/:id/refund", middleware.RequirePermission("order.refund"), adminOrderHandler.RefundOrder)
 			orders.POST("/:id/confirm-refund", middleware.RequirePermission("order.refund"), adminOrderHandler.ConfirmRefund)
+			orders.GET("/:id/partial-refunds", middleware.RequirePermission("order.view"), adminOrderRefundHandler.ListRefundsForOrder)
+			orders.POST("/:id/partial-refunds", middleware.RequirePermission("order.refund"), adminOrderRefundHandler.CreateOrderRefund)
+			orders.GET("/:id/price-adjustments", middleware.RequirePermission("order.view"), adminPriceAdjustmentHandler.ListPriceAdjustmentsForOrder)
+			orders.POST("/:id/price-adjustments", middleware.RequirePermission("order.price_adjust"), adminPriceAdjustmentHandler.CreatePriceAdjustment)
 			orders.POST("/:id/mark-paid", middleware.RequirePermission("order.status_update"), adminOrderHandler.MarkAsPaid)
 			orders.POST("/:id/deliver-virtual", middleware.RequirePermission("order.status_update"), adminOrderHandler.DeliverVirtualStock)
 			orders.PUT("/:id/price", middleware.RequirePermission("order.edit"), adminOrderHandler.UpdateOrderPrice)
+			orders.POST("/:id/payment-link", middleware.RequirePermission("order.edit"), adminPaymentLinkHandler.Create)
 			orders.DELETE("/:id", middleware.RequirePermission("order.delete"), adminOrderHandler.DeleteOrder)
 
 			// 批量操作
 			orders.POST("/batch/complete-shipped", middleware.RequirePermission("order.status_update"), adminOrderHandler.CompleteAllShippedOrders)
 			orders.POST("/batch/update", middleware.RequirePermission("order.status_update"), adminOrderHandler.BatchUpdateOrders)
+			orders.POST("/batch/cleanup-drafts", middleware.RequirePermission("order.delete"), adminOrderHandler.CleanupStaleDrafts)
 
 			// Excel导出导入
 			orders.GET("/export", middleware.RequirePermission("order.view"), adminOrderHandler.ExportOrders)
@@ -387,6 +743,115 @@ func SetupRouter(
 			orders.GET("/import-template", middleware.RequirePermission("order.view"), adminOrderHandler.DownloadTemplate)
 		}
 
+		// 用户退款申请审核队列
+		refundRequests := adminAPI.Group("/refund-requests")
+		{
+			refundRequests.GET("", middleware.RequirePermission("order.view"), adminRefundRequestHandler.ListRefundRequests)
+			refundRequests.GET("/:id", middleware.RequirePermission("order.view"), adminRefundRequestHandler.GetRefundRequest)
+			refundRequests.POST("/:id/approve", middleware.RequirePermission("order.refund"), adminRefundRequestHandler.ApproveRefundRequest)
+			refundRequests.POST("/:id/reject", middleware.RequirePermission("order.refund"), adminRefundRequestHandler.RejectRefundRequest)
+		}
+
+		// 退货（RMA）：requested → approved → in_transit → received
+		returnRequests := adminAPI.Group("/returns")
+		{
+			returnRequests.GET("", middleware.RequirePermission("order.view"), adminReturnHandler.ListReturns)
+			returnRequests.GET("/:id", middleware.RequirePermission("order.view"), adminReturnHandler.GetReturn)
+			returnRequests.POST("/:id/approve", middleware.RequirePermission("order.edit"), adminReturnHandler.ApproveReturn)
+			returnRequests.POST("/:id/reject", middleware.RequirePermission("order.edit"), adminReturnHandler.RejectReturn)
+			returnRequests.POST("/:id/receive", middleware.RequirePermission("order.edit"), adminReturnHandler.ReceiveReturn)
+		}
+
+		// 订单争议：awaiting_evidence → under_review → resolved，裁决人不能是答辩人
+		disputes := adminAPI.Group("/disputes")
+		disputes.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			disputes.GET("", middleware.RequirePermission("ticket.view"), adminOrderDisputeHandler.ListDisputes)
+			disputes.GET("/:id", middleware.RequirePermission("ticket.view"), adminOrderDisputeHandler.GetDispute)
+			disputes.POST("/:id/evidence", middleware.RequirePermission("ticket.reply"), adminOrderDisputeHandler.SubmitEvidence)
+			disputes.POST("/:id/decide", middleware.RequirePermission("ticket.dispute"), adminOrderDisputeHandler.DecideDispute)
+		}
+
+		// 服务条款/隐私政策版本，发布新版本后用户需重新同意
+		policies := adminAPI.Group("/policies")
+		{
+			policies.GET("", middleware.RequirePermission("system.config"), adminPolicyHandler.ListPolicyVersions)
+			policies.POST("", middleware.RequirePermission("system.config"), adminPolicyHandler.PublishPolicyVersion)
+		}
+
+		// 订单部分退款：requested → approved → refunded
+		orderRefunds := adminAPI.Group("/order-refunds")
+		{
+			orderRefunds.GET("", middleware.RequirePermission("order.view"), adminOrderRefundHandler.ListOrderRefunds)
+			orderRefunds.POST("/:id/approve", middleware.RequirePermission("order.refund"), adminOrderRefundHandler.ApproveOrderRefund)
+			orderRefunds.POST("/:id/reject", middleware.RequirePermission("order.refund"), adminOrderRefundHandler.RejectOrderRefund)
+			orderRefunds.POST("/:id/issue", middleware.RequirePermission("order.refund"), adminOrderRefundHandler.IssueOrderRefund)
+		}
+
+		// 订单手动调价审批：超过 order.price_adjustment.approval_threshold 的减免需另一名管理员批准
+		priceAdjustments := adminAPI.Group("/price-adjustments")
+		{
+			priceAdjustments.GET("", middleware.RequirePermission("order.view"), adminPriceAdjustmentHandler.ListPriceAdjustments)
+			priceAdjustments.POST("/:id/approve", middleware.RequirePermission("order.price_approve"), adminPriceAdjustmentHandler.ApprovePriceAdjustment)
+			priceAdjustments.POST("/:id/reject", middleware.RequirePermission("order.price_approve"), adminPriceAdjustmentHandler.RejectPriceAdjustment)
+		}
+
+		// 本位币结算报表与会计系统对接
+		reports := adminAPI.Group("/reports")
+		{
+			reports.GET("/fx-settlement", middleware.RequirePermission("order.view"), adminFXSettlementHandler.GetReport)
+			reports.GET("/fx-settlement/export", middleware.RequirePermission("order.view"), adminFXSettlementHandler.ExportReport)
+			reports.GET("/revenue-recognition", middleware.RequirePermission("order.view"), adminRevenueRecognitionHandler.GetReport)
+			reports.GET("/revenue-recognition/export", middleware.RequirePermission("order.view"), adminRevenueRecognitionHandler.ExportReport)
+			reports.POST("/revenue-recognition/backfill", middleware.RequirePermission("system.config"), adminRevenueRecognitionHandler.Backfill)
+			reports.GET("/accounting/export", middleware.RequirePermission("order.view"), adminAccountingExportHandler.ExportJournal)
+			reports.GET("/accounting/runs", middleware.RequirePermission("order.view"), adminAccountingExportHandler.ListRuns)
+			reports.POST("/accounting/runs", middleware.RequirePermission("system.config"), adminAccountingExportHandler.TriggerRun)
+		}
+
+		// 商家分账与结算单
+		vendors := adminAPI.Group("/vendors")
+		{
+			vendors.GET("", middleware.RequirePermission("vendor.view"), adminVendorHandler.ListVendors)
+			vendors.POST("", middleware.RequirePermission("vendor.manage"), adminVendorHandler.CreateVendor)
+			vendors.PUT("/:id", middleware.RequirePermission("vendor.manage"), adminVendorHandler.UpdateVendor)
+			vendors.GET("/:id/ledger", middleware.RequirePermission("vendor.view"), adminVendorHandler.ListVendorLedger)
+			vendors.POST("/:id/adjustments", middleware.RequirePermission("vendor.manage"), adminVendorHandler.CreateVendorAdjustment)
+		}
+		vendorStatements := adminAPI.Group("/vendor-statements")
+		{
+			vendorStatements.GET("", middleware.RequirePermission("vendor.view"), adminVendorHandler.ListStatements)
+			vendorStatements.POST("/generate", middleware.RequirePermission("vendor.manage"), adminVendorHandler.GenerateStatements)
+			vendorStatements.GET("/:id", middleware.RequirePermission("vendor.view"), adminVendorHandler.GetStatement)
+			vendorStatements.GET("/:id/export", middleware.RequirePermission("vendor.view"), adminVendorHandler.ExportStatement)
+			vendorStatements.POST("/:id/mark-paid", middleware.RequirePermission("vendor.manage"), adminVendorHandler.MarkStatementPaid)
+		}
+
+		// 企业账户与账期发票
+		businessAccounts := adminAPI.Group("/business-accounts")
+		{
+			businessAccounts.GET("", middleware.RequirePermission("business_account.view"), adminBusinessAccountHandler.ListAccounts)
+			businessAccounts.GET("/:id", middleware.RequirePermission("business_account.view"), adminBusinessAccountHandler.GetAccount)
+			businessAccounts.POST("/:id/approve", middleware.RequirePermission("business_account.manage"), adminBusinessAccountHandler.ApproveAccount)
+			businessAccounts.POST("/:id/reject", middleware.RequirePermission("business_account.manage"), adminBusinessAccountHandler.RejectAccount)
+			businessAccounts.PUT("/:id", middleware.RequirePermission("business_account.manage"), adminBusinessAccountHandler.UpdateAccount)
+			businessAccounts.GET("/:id/statement", middleware.RequirePermission("business_account.view"), adminBusinessAccountHandler.GetStatement)
+			businessAccounts.GET("/:id/statement/export", middleware.RequirePermission("business_account.view"), adminBusinessAccountHandler.ExportStatement)
+		}
+		netTermsInvoices := adminAPI.Group("/net-terms-invoices")
+		{
+			netTermsInvoices.GET("", middleware.RequirePermission("business_account.view"), adminBusinessAccountHandler.ListInvoices)
+			netTermsInvoices.POST("/:id/mark-paid", middleware.RequirePermission("business_account.manage"), adminBusinessAccountHandler.MarkInvoicePaid)
+			netTermsInvoices.POST("/:id/void", middleware.RequirePermission("business_account.manage"), adminBusinessAccountHandler.VoidInvoice)
+		}
+		codCollections := adminAPI.Group("/cod-collections")
+		{
+			codCollections.GET("", middleware.RequirePermission("order.view"), adminCODHandler.ListCollections)
+			codCollections.GET("/report", middleware.RequirePermission("order.view"), adminCODHandler.GetReport)
+			codCollections.POST("/:id/confirm", middleware.RequirePermission("order.status_update"), adminCODHandler.ConfirmCollection)
+			codCollections.POST("/:id/void", middleware.RequirePermission("order.status_update"), adminCODHandler.VoidCollection)
+		}
+
 		// User管理
 		users := adminAPI.Group("/users")
 		users.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
@@ -399,6 +864,12 @@ func SetupRouter(
 			users.PUT("/:id", middleware.RequirePermission("user.edit"), adminUserHandler.UpdateUser)
 			users.DELETE("/:id", middleware.RequirePermission("user.edit"), adminUserHandler.DeleteUser)
 			users.GET("/:id/orders", middleware.RequirePermission("user.view"), adminUserHandler.GetUserOrders)
+			users.GET("/:id/spending-controls", middleware.RequirePermission("user.view"), adminSpendingControlHandler.GetUserSpendingControl)
+			users.PUT("/:id/spending-controls", middleware.RequirePermission("user.edit"), adminSpendingControlHandler.UpdateUserSpendingControl)
+			users.GET("/:id/consents", middleware.RequirePermission("user.view"), adminPolicyHandler.GetUserConsents)
+			users.GET("/:id/two-factor", middleware.RequirePermission("user.view"), adminTwoFactorHandler.GetUserTwoFactor)
+			users.PUT("/:id/two-factor", middleware.RequirePermission("user.edit"), adminTwoFactorHandler.UpdateUserTwoFactor)
+			users.POST("/:id/two-factor/reset", middleware.RequirePermission("user.edit"), adminTwoFactorHandler.ResetUserTwoFactor)
 		}
 
 		// Product管理
@@ -418,6 +889,14 @@ func SetupRouter(
 			products.PUT("/:id/stock", middleware.RequirePermission("product.edit"), adminProductHandler.UpdateStock)
 			products.POST("/:id/toggle-featured", middleware.RequirePermission("product.edit"), adminProductHandler.ToggleFeatured)
 			products.PUT("/:id/inventory-mode", middleware.RequirePermission("product.edit"), adminProductHandler.UpdateInventoryMode)
+			products.GET("/:id/waiting-room", middleware.RequirePermission("product.view"), adminWaitingRoomHandler.GetStats)
+			products.PUT("/:id/waiting-room", middleware.RequirePermission("product.edit"), adminWaitingRoomHandler.Update)
+			products.GET("/:id/trial", middleware.RequirePermission("product.view"), adminProductTrialHandler.GetStats)
+			products.PUT("/:id/trial", middleware.RequirePermission("product.edit"), adminProductTrialHandler.Update)
+			products.GET("/:id/order-rate-cap", middleware.RequirePermission("product.view"), adminOrderRateCapHandler.GetSettings)
+			products.PUT("/:id/order-rate-cap", middleware.RequirePermission("product.edit"), adminOrderRateCapHandler.Update)
+			products.PUT("/:id/vendor", middleware.RequirePermission("product.edit"), adminVendorHandler.AssignProductVendor)
+			products.PUT("/:id/revenue-recognition", middleware.RequirePermission("product.edit"), adminRevenueRecognitionHandler.UpdateProduct)
 
 			// Product-Inventory绑定管理
 			products.GET("/:id/inventory-bindings", middleware.RequirePermission("product.view"), adminBindingHandler.GetProductBindings)
@@ -427,6 +906,7 @@ func SetupRouter(
 			products.DELETE("/:id/inventory-bindings/:bindingId", middleware.RequirePermission("product.edit"), adminBindingHandler.DeleteBinding)
 			products.DELETE("/:id/inventory-bindings", middleware.RequirePermission("product.edit"), adminBindingHandler.DeleteAllProductBindings)
 			products.PUT("/:id/inventory-bindings/replace", middleware.RequirePermission("product.edit"), adminBindingHandler.ReplaceProductBindings)
+			products.GET("/:id/inventory-bindings/history", middleware.RequirePermission("product.view"), adminBindingHandler.GetBindingHistory)
 		}
 
 		// Inventory管理
@@ -436,15 +916,35 @@ func SetupRouter(
 			inventories.GET("", middleware.RequirePermission("product.view"), adminInventoryHandler.ListInventories)
 			inventories.POST("", middleware.RequirePermission("product.edit"), adminInventoryHandler.CreateInventory)
 			inventories.GET("/low-stock", middleware.RequirePermission("product.view"), adminInventoryHandler.GetLowStockList)
+			inventories.GET("/reconciliation/runs", middleware.RequirePermission("product.view"), adminStockReconciliationHandler.ListRuns)
+			inventories.GET("/reconciliation/runs/:id", middleware.RequirePermission("product.view"), adminStockReconciliationHandler.GetRun)
+			inventories.POST("/reconciliation/runs", middleware.RequirePermission("product.edit"), adminStockReconciliationHandler.TriggerRun)
+			inventories.GET("/snapshots", middleware.RequirePermission("product.view"), adminInventorySnapshotHandler.GetSnapshot)
+			inventories.GET("/snapshots/compare", middleware.RequirePermission("product.view"), adminInventorySnapshotHandler.Compare)
+			inventories.POST("/snapshots", middleware.RequirePermission("product.edit"), adminInventorySnapshotHandler.TakeSnapshot)
+			inventories.GET("/low-stock-alerts", middleware.RequirePermission("product.view"), adminInventoryStockAlertHandler.ListAlerts)
+			inventories.POST("/low-stock-alerts/check", middleware.RequirePermission("product.edit"), adminInventoryStockAlertHandler.RunCheck)
+			inventories.POST("/low-stock-alerts/:source/:id/snooze", middleware.RequirePermission("product.edit"), adminInventoryStockAlertHandler.Snooze)
+			inventories.DELETE("/low-stock-alerts/:source/:id/snooze", middleware.RequirePermission("product.edit"), adminInventoryStockAlertHandler.Unsnooze)
 			inventories.GET("/:id", middleware.RequirePermission("product.view"), adminInventoryHandler.GetInventory)
 			inventories.PUT("/:id", middleware.RequirePermission("product.edit"), adminInventoryHandler.UpdateInventory)
 			inventories.POST("/:id/adjust", middleware.RequirePermission("product.edit"), adminInventoryHandler.AdjustStock)
+			inventories.GET("/:id/flash-sale", middleware.RequirePermission("product.view"), adminInventoryHandler.GetFlashSaleStatus)
 			inventories.DELETE("/:id", middleware.RequirePermission("product.delete"), adminInventoryHandler.DeleteInventory)
 
 			// getInventory绑定的所有Product
 			inventories.GET("/:id/products", middleware.RequirePermission("product.view"), adminBindingHandler.GetInventoryProducts)
 		}
 
+		// 商品与实体库存绑定管理（跨商品）
+		inventoryBindings := adminAPI.Group("/inventory-bindings")
+		inventoryBindings.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			inventoryBindings.GET("", middleware.RequirePermission("product.view"), adminBindingHandler.ListAllBindings)
+			inventoryBindings.GET("/history", middleware.RequirePermission("product.view"), adminBindingHandler.GetBindingHistory)
+			inventoryBindings.POST("/:bindingId/rebind", middleware.RequirePermission("product.edit"), adminBindingHandler.RebindBinding)
+		}
+
 		// Permission管理（仅超级Admin）
 		permissions := adminAPI.Group("/permissions")
 		permissions.Use(middleware.AuthMiddleware(), middleware.RequireSuperAdmin())
@@ -492,6 +992,13 @@ func SetupRouter(
 			logs.GET("/inventories/statistics", middleware.RequirePermission("system.logs"), adminInventoryLogHandler.GetInventoryLogStatistics)
 		}
 
+		// 周期任务运行状态
+		jobs := adminAPI.Group("/jobs")
+		jobs.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			jobs.GET("", middleware.RequirePermission("system.logs"), adminJobHandler.ListJobs)
+		}
+
 		// 系统设置（仅超级Admin）
 		settings := adminAPI.Group("/settings")
 		settings.Use(middleware.AuthMiddleware(), middleware.RequireSuperAdmin())
@@ -500,10 +1007,20 @@ func SetupRouter(
 			settings.PUT("", middleware.RequirePermission("system.config"), adminSettingsHandler.UpdateSettings)
 			settings.POST("/smtp/test", middleware.RequirePermission("system.config"), adminSettingsHandler.TestSMTP)
 			settings.POST("/sms/test", middleware.RequirePermission("system.config"), adminSettingsHandler.TestSMS)
+			settings.POST("/test-message", middleware.RequirePermission("system.config"), adminSettingsHandler.SendTestMessage)
 			settings.GET("/email-templates", middleware.RequirePermission("system.config"), adminSettingsHandler.ListEmailTemplates)
 			settings.GET("/email-templates/:filename", middleware.RequirePermission("system.config"), adminSettingsHandler.GetEmailTemplate)
 			settings.PUT("/email-templates/:filename", middleware.RequirePermission("system.config"), adminSettingsHandler.UpdateEmailTemplate)
 			settings.POST("/template-packages/import", middleware.RequirePermission("system.config"), adminSettingsHandler.ImportTemplatePackage)
+			settings.GET("/template-assets", middleware.RequirePermission("system.config"), adminTemplateAssetHandler.ListAssets)
+			settings.POST("/template-assets", middleware.RequirePermission("system.config"), adminTemplateAssetHandler.UploadAsset)
+			settings.DELETE("/template-assets/:name", middleware.RequirePermission("system.config"), adminTemplateAssetHandler.DeleteAsset)
+			settings.GET("/document-types", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.ListDocumentTypes)
+			settings.POST("/document-types", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.CreateDocumentType)
+			settings.POST("/document-types/preview", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.PreviewDocumentType)
+			settings.GET("/document-types/:id", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.GetDocumentType)
+			settings.PUT("/document-types/:id", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.UpdateDocumentType)
+			settings.DELETE("/document-types/:id", middleware.RequirePermission("system.config"), adminDocumentTypeHandler.DeleteDocumentType)
 			settings.GET("/landing-page", middleware.RequirePermission("system.config"), adminLandingPageHandler.GetLandingPage)
 			settings.PUT("/landing-page", middleware.RequirePermission("system.config"), adminLandingPageHandler.UpdateLandingPage)
 			settings.POST("/landing-page/reset", middleware.RequirePermission("system.config"), adminLandingPageHandler.ResetLandingPage)
@@ -526,6 +1043,7 @@ func SetupRouter(
 			paymentMethods.PUT("/:id", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.Update)
 			paymentMethods.DELETE("/:id", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.Delete)
 			paymentMethods.POST("/:id/toggle", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.ToggleEnabled)
+			paymentMethods.PUT("/:id/fee", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.UpdateFee)
 			paymentMethods.POST("/reorder", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.Reorder)
 			paymentMethods.POST("/test-script", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.TestScript)
 			paymentMethods.POST("/init-builtin", middleware.RequirePermission("system.config"), adminPaymentMethodHandler.InitBuiltinMethods)
@@ -544,6 +1062,86 @@ func SetupRouter(
 			promoCodesAdmin.DELETE("/:id", middleware.RequirePermission("product.delete"), adminPromoCodeHandler.DeletePromoCode)
 		}
 
+		// 优惠码活动（批量生成）
+		promoCampaignsAdmin := adminAPI.Group("/promo-code-campaigns")
+		promoCampaignsAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			promoCampaignsAdmin.GET("", middleware.RequirePermission("product.view"), adminPromoCodeCampaignHandler.ListCampaigns)
+			promoCampaignsAdmin.POST("", middleware.RequirePermission("product.edit"), adminPromoCodeCampaignHandler.CreateCampaign)
+			promoCampaignsAdmin.GET("/:id", middleware.RequirePermission("product.view"), adminPromoCodeCampaignHandler.GetCampaign)
+			promoCampaignsAdmin.GET("/:id/stats", middleware.RequirePermission("product.view"), adminPromoCodeCampaignHandler.GetCampaignStats)
+			promoCampaignsAdmin.GET("/:id/export", middleware.RequirePermission("product.view"), adminPromoCodeCampaignHandler.ExportCampaignCodes)
+		}
+
+		// 定价规则（自动折扣、满减）
+		pricingRulesAdmin := adminAPI.Group("/pricing-rules")
+		pricingRulesAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			pricingRulesAdmin.GET("", middleware.RequirePermission("product.view"), adminPricingRuleHandler.ListRules)
+			pricingRulesAdmin.POST("", middleware.RequirePermission("product.edit"), adminPricingRuleHandler.CreateRule)
+			pricingRulesAdmin.POST("/simulate", middleware.RequirePermission("product.view"), adminPricingRuleHandler.SimulateRules)
+			pricingRulesAdmin.GET("/:id", middleware.RequirePermission("product.view"), adminPricingRuleHandler.GetRule)
+			pricingRulesAdmin.PUT("/:id", middleware.RequirePermission("product.edit"), adminPricingRuleHandler.UpdateRule)
+			pricingRulesAdmin.DELETE("/:id", middleware.RequirePermission("product.delete"), adminPricingRuleHandler.DeleteRule)
+		}
+
+		// 入站 Webhook（第三方事件收件箱）
+		inboundWebhooksAdmin := adminAPI.Group("/inbound-webhooks")
+		inboundWebhooksAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			inboundWebhooksAdmin.GET("", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.ListWebhooks)
+			inboundWebhooksAdmin.POST("", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.CreateWebhook)
+			inboundWebhooksAdmin.GET("/:id", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.GetWebhook)
+			inboundWebhooksAdmin.PUT("/:id", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.UpdateWebhook)
+			inboundWebhooksAdmin.DELETE("/:id", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.DeleteWebhook)
+			inboundWebhooksAdmin.POST("/:id/rotate-secret", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.RotateSecret)
+			inboundWebhooksAdmin.POST("/:id/test", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.TestWebhook)
+			inboundWebhooksAdmin.GET("/:id/events", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.ListEvents)
+			inboundWebhooksAdmin.GET("/:id/events/:event_id", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.GetEvent)
+			inboundWebhooksAdmin.POST("/:id/events/:event_id/replay", middleware.RequirePermission("system.config"), adminInboundWebhookHandler.ReplayEvent)
+		}
+
+		// Zapier / Make 集成：轮询触发器与动作，使用 API Key（X-API-Key / X-API-Secret）调用
+		integrations := adminAPI.Group("/integrations")
+		integrations.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			integrations.GET("/me", adminIntegrationHandler.Me)
+			integrations.GET("/triggers/paid-orders", middleware.RequirePermission("order.view"), adminIntegrationHandler.PaidOrders)
+			integrations.POST("/actions/ticket-reply", middleware.RequirePermission("ticket.reply"), adminIntegrationHandler.ReplyTicket)
+			integrations.POST("/actions/order-tag", middleware.RequirePermission("order.edit"), adminIntegrationHandler.AddOrderTag)
+		}
+
+		// 礼品卡管理
+		giftCardsAdmin := adminAPI.Group("/gift-cards")
+		giftCardsAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			giftCardsAdmin.GET("", middleware.RequirePermission("product.view"), adminGiftCardHandler.ListGiftCards)
+			giftCardsAdmin.POST("", middleware.RequirePermission("product.edit"), adminGiftCardHandler.IssueGiftCards)
+			giftCardsAdmin.GET("/:id", middleware.RequirePermission("product.view"), adminGiftCardHandler.GetGiftCard)
+			giftCardsAdmin.POST("/:id/void", middleware.RequirePermission("product.edit"), adminGiftCardHandler.VoidGiftCard)
+		}
+
+		// 报价单管理
+		quotesAdmin := adminAPI.Group("/quotes")
+		quotesAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			quotesAdmin.GET("", middleware.RequirePermission("order.view"), adminQuoteHandler.ListQuotes)
+			quotesAdmin.POST("", middleware.RequirePermission("order.edit"), adminQuoteHandler.CreateQuote)
+			quotesAdmin.GET("/:id", middleware.RequirePermission("order.view"), adminQuoteHandler.GetQuote)
+			quotesAdmin.PUT("/:id", middleware.RequirePermission("order.edit"), adminQuoteHandler.UpdateQuote)
+			quotesAdmin.GET("/:id/document", middleware.RequirePermission("order.view"), adminQuoteHandler.PreviewQuote)
+			quotesAdmin.POST("/:id/send", middleware.RequirePermission("order.edit"), adminQuoteHandler.SendQuote)
+			quotesAdmin.POST("/:id/cancel", middleware.RequirePermission("order.edit"), adminQuoteHandler.CancelQuote)
+		}
+
+		// 回收站（已删除的商品、优惠码、虚拟库存）
+		trash := adminAPI.Group("/trash")
+		trash.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			trash.GET("", middleware.RequirePermission("product.view"), adminTrashHandler.ListTrash)
+			trash.POST("/:type/:id/restore", middleware.RequirePermission("product.delete"), adminTrashHandler.RestoreTrashItem)
+		}
+
 		// 序列号管理
 		serials := adminAPI.Group("/serials")
 		serials.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
@@ -557,6 +1155,30 @@ func SetupRouter(
 			serials.POST("/batch-delete", middleware.RequirePermission("serial.manage"), adminSerialHandler.BatchDeleteSerials)
 		}
 
+		// 虚拟商品试用记录
+		trials := adminAPI.Group("/trials")
+		trials.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			trials.GET("", middleware.RequirePermission("order.view"), adminProductTrialHandler.List)
+		}
+
+		// 站外转售卡密核查（可凭 API Key 调用）
+		virtualStocks := adminAPI.Group("/virtual-stocks")
+		virtualStocks.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			virtualStocks.POST("/resale-check", middleware.RequirePermission("order.view"), adminVirtualStockResaleHandler.Check)
+			virtualStocks.GET("/resale-reports", middleware.RequirePermission("order.view"), adminVirtualStockResaleHandler.ListReports)
+		}
+
+		// 卡密用量计量（商户软件凭 API Key 上报）
+		usage := adminAPI.Group("/usage")
+		usage.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			usage.POST("/records", middleware.RequirePermission("usage.report"), adminUsageMeterHandler.ReportUsage)
+			usage.GET("/balance", middleware.RequirePermission("usage.report"), adminUsageMeterHandler.GetBalance)
+			usage.GET("/meters/:id/records", middleware.RequirePermission("usage.view"), adminUsageMeterHandler.ListMeterRecords)
+		}
+
 		// 虚拟库存管理（新版API，类似实体库存）
 		virtualInventories := adminAPI.Group("/virtual-inventories")
 		virtualInventories.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
@@ -570,12 +1192,35 @@ func SetupRouter(
 
 			// 脚本测试
 			virtualInventories.POST("/test-script", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.TestDeliveryScript)
+			virtualInventories.POST("/:id/dry-run", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.DryRunDeliveryScript)
+			virtualInventories.POST("/:id/dry-run/stream", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.DryRunDeliveryScriptStream)
+			virtualInventories.GET("/supplier-health", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetSupplierHealth)
+			virtualInventories.POST("/:id/supplier-resume", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.ResumeSupplier)
+
+			// 脚本执行审计与失败重放
+			virtualInventories.GET("/:id/script-executions", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.ListScriptExecutions)
+			virtualInventories.GET("/:id/script-executions/:execution_id", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetScriptExecution)
+			virtualInventories.POST("/:id/script-executions/:execution_id/replay", middleware.RequirePermission("order.status_update"), adminVirtualInventoryHandler.ReplayScriptExecution)
+
+			// 自动补货策略与采购记录
+			virtualInventories.GET("/:id/restock", middleware.RequirePermission("product.view"), adminVirtualInventoryRestockHandler.Get)
+			virtualInventories.PUT("/:id/restock", middleware.RequirePermission("product.edit"), adminVirtualInventoryRestockHandler.Update)
+			virtualInventories.POST("/:id/restock/run", middleware.RequirePermission("product.edit"), adminVirtualInventoryRestockHandler.Run)
+			virtualInventories.GET("/:id/restock/runs", middleware.RequirePermission("product.view"), adminVirtualInventoryRestockHandler.ListRuns)
+
+			// 脚本修改审批
+			virtualInventories.GET("/script-revisions", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.ListScriptRevisions)
+			virtualInventories.GET("/:id/script-revisions", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.ListScriptRevisions)
+			virtualInventories.GET("/:id/script-revisions/:revision_id/diff", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetScriptRevisionDiff)
+			virtualInventories.POST("/:id/script-revisions/:revision_id/approve", middleware.RequirePermission("product.script_approve"), adminVirtualInventoryHandler.ApproveScriptRevision)
+			virtualInventories.POST("/:id/script-revisions/:revision_id/reject", middleware.RequirePermission("product.script_approve"), adminVirtualInventoryHandler.RejectScriptRevision)
 
 			// 库存项管理
 			virtualInventories.POST("/:id/import", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.ImportStock)
 			virtualInventories.POST("/:id/stocks", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.CreateStockManually)
 			virtualInventories.GET("/:id/stocks", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetStockList)
 			virtualInventories.GET("/:id/stats", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetStockStats)
+			virtualInventories.POST("/:id/stocks/:stock_id/reveal", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.RevealStock)
 			virtualInventories.DELETE("/:id/stocks/:stock_id", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.DeleteStock)
 			virtualInventories.POST("/:id/stocks/:stock_id/reserve", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.ReserveStock)
 			virtualInventories.POST("/:id/stocks/:stock_id/release", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.ReleaseStockItem)
@@ -599,6 +1244,7 @@ func SetupRouter(
 			virtualProducts.GET("/:id/stocks", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetStockListForProduct)
 			virtualProducts.GET("/:id/stats", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.GetStockStatsForProduct)
 			virtualProducts.POST("/:id/import", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.ImportStockForProduct)
+			virtualProducts.POST("/stocks/:id/reveal", middleware.RequirePermission("product.view"), adminVirtualInventoryHandler.RevealStockByID)
 			virtualProducts.DELETE("/stocks/:id", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.DeleteStockByID)
 			virtualProducts.DELETE("/batch", middleware.RequirePermission("product.edit"), adminVirtualInventoryHandler.DeleteBatch)
 		}
@@ -617,6 +1263,8 @@ func SetupRouter(
 		{
 			tickets.GET("", middleware.RequirePermission("ticket.view"), adminTicketHandler.ListTickets)
 			tickets.GET("/stats", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetTicketStats)
+			tickets.GET("/agent-performance", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetAgentPerformance)
+			tickets.GET("/agent-performance/export", middleware.RequirePermission("ticket.view"), adminTicketHandler.ExportAgentPerformance)
 			tickets.GET("/:id", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetTicket)
 			tickets.GET("/:id/messages", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetTicketMessages)
 			tickets.POST("/:id/messages", middleware.RequirePermission("ticket.reply"), adminTicketHandler.SendMessage)
@@ -624,6 +1272,8 @@ func SetupRouter(
 			tickets.GET("/:id/shared-orders", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetSharedOrders)
 			tickets.GET("/:id/shared-orders/:orderId", middleware.RequirePermission("ticket.view"), adminTicketHandler.GetSharedOrder)
 			tickets.POST("/:id/upload", middleware.RequirePermission("ticket.reply"), adminTicketHandler.UploadFile)
+			tickets.POST("/:id/attachments", middleware.RequirePermission("ticket.reply"), adminTicketHandler.UploadAttachment)
+			tickets.DELETE("/:id", middleware.RequirePermission("ticket.status_update"), adminTicketHandler.DeleteTicket)
 		}
 
 		// 知识库管理
@@ -657,6 +1307,54 @@ func SetupRouter(
 			announcementsAdmin.DELETE("/:id", middleware.RequirePermission("announcement.edit"), adminAnnouncementHandler.DeleteAnnouncement)
 		}
 
+		// 站点横幅（维护公告、政策变更）
+		bannersAdmin := adminAPI.Group("/banners")
+		bannersAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			bannersAdmin.GET("", middleware.RequirePermission("announcement.view"), adminSiteBannerHandler.ListBanners)
+			bannersAdmin.POST("", middleware.RequirePermission("announcement.edit"), adminSiteBannerHandler.CreateBanner)
+			bannersAdmin.GET("/:id", middleware.RequirePermission("announcement.view"), adminSiteBannerHandler.GetBanner)
+			bannersAdmin.PUT("/:id", middleware.RequirePermission("announcement.edit"), adminSiteBannerHandler.UpdateBanner)
+			bannersAdmin.DELETE("/:id", middleware.RequirePermission("announcement.edit"), adminSiteBannerHandler.DeleteBanner)
+		}
+
+		// 内容审核（审核队列与词库规则）
+		moderationAdmin := adminAPI.Group("/moderation")
+		moderationAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			moderationAdmin.GET("/cases", middleware.RequirePermission("moderation.view"), adminModerationHandler.ListCases)
+			moderationAdmin.GET("/cases/pending-count", middleware.RequirePermission("moderation.view"), adminModerationHandler.GetPendingCount)
+			moderationAdmin.POST("/cases/:id/review", middleware.RequirePermission("moderation.manage"), adminModerationHandler.ReviewCase)
+			moderationAdmin.GET("/rules", middleware.RequirePermission("moderation.view"), adminModerationHandler.ListRules)
+			moderationAdmin.POST("/rules", middleware.RequirePermission("moderation.manage"), adminModerationHandler.CreateRule)
+			moderationAdmin.PUT("/rules/:id", middleware.RequirePermission("moderation.manage"), adminModerationHandler.UpdateRule)
+			moderationAdmin.DELETE("/rules/:id", middleware.RequirePermission("moderation.manage"), adminModerationHandler.DeleteRule)
+		}
+
+		// 黑名单（注册、登录与下单时检查）
+		blocklistAdmin := adminAPI.Group("/blocklist")
+		blocklistAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			blocklistAdmin.GET("", middleware.RequirePermission("user.view"), adminBlocklistHandler.ListEntries)
+			blocklistAdmin.POST("", middleware.RequirePermission("user.blocklist"), adminBlocklistHandler.CreateEntry)
+			blocklistAdmin.POST("/import", middleware.RequirePermission("user.blocklist"), adminBlocklistHandler.ImportEntries)
+			blocklistAdmin.PUT("/:id", middleware.RequirePermission("user.blocklist"), adminBlocklistHandler.UpdateEntry)
+			blocklistAdmin.DELETE("/:id", middleware.RequirePermission("user.blocklist"), adminBlocklistHandler.DeleteEntry)
+		}
+
+		// 频率规则与风控审核队列（下单与提交收货信息时检查）
+		riskAdmin := adminAPI.Group("/risk")
+		riskAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
+		{
+			riskAdmin.GET("/reviews", middleware.RequirePermission("order.view"), adminVelocityRuleHandler.ListReviews)
+			riskAdmin.GET("/reviews/pending-count", middleware.RequirePermission("order.view"), adminVelocityRuleHandler.GetPendingCount)
+			riskAdmin.POST("/reviews/:id/review", middleware.RequirePermission("order.risk_review"), adminVelocityRuleHandler.ReviewOrder)
+			riskAdmin.GET("/rules", middleware.RequirePermission("order.view"), adminVelocityRuleHandler.ListRules)
+			riskAdmin.POST("/rules", middleware.RequirePermission("order.risk_review"), adminVelocityRuleHandler.CreateRule)
+			riskAdmin.PUT("/rules/:id", middleware.RequirePermission("order.risk_review"), adminVelocityRuleHandler.UpdateRule)
+			riskAdmin.DELETE("/rules/:id", middleware.RequirePermission("order.risk_review"), adminVelocityRuleHandler.DeleteRule)
+		}
+
 		marketingAdmin := adminAPI.Group("/marketing")
 		marketingAdmin.Use(middleware.AuthMiddleware(), middleware.RequireAdmin())
 		{
@@ -724,6 +1422,9 @@ func SetupRouter(
 		uploadsGroup.HEAD("/products/*filepath", productUploadHandler)
 		uploadsGroup.GET("/tickets/*filepath", ticketUploadHandler)
 		uploadsGroup.HEAD("/tickets/*filepath", ticketUploadHandler)
+		// 模板资源按名称提供稳定地址，?v= 版本参数用于缓存失效
+		uploadsGroup.GET("/templates/:name", adminTemplateAssetHandler.ServeAsset)
+		uploadsGroup.HEAD("/templates/:name", adminTemplateAssetHandler.ServeAsset)
 	}
 
 	// 落地页（公开）
@@ -734,6 +1435,10 @@ func SetupRouter(
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// OpenAPI 文档（公开），供客户端 SDK 生成
+	r.GET("/openapi.json", openapi.Handler(r.Routes, version,
+		adminHandler.OpenAPIBindings, userHandler.OpenAPIBindings, formHandler.OpenAPIBindings))
+
 	return r
 }
 
@@ -755,6 +1460,18 @@ func resolveRateLimit(limitSelector func(*config.Config) int, fallback int) midd
 	}
 }
 
+// resolveRateLimitPolicy 读取运行时配置中的令牌桶策略，随全局限流开关启停
+func resolveRateLimitPolicy(name string) middleware.RateLimitPolicyResolver {
+	return func(c *gin.Context) (config.RateLimitPolicy, bool) {
+		runtimeCfg := config.GetConfig()
+		if runtimeCfg == nil || !runtimeCfg.RateLimit.Enabled {
+			return config.RateLimitPolicy{}, false
+		}
+		policy, ok := runtimeCfg.RateLimit.Policies[name]
+		return policy, ok && policy.Rate > 0
+	}
+}
+
 func buildDynamicUploadFileHandler(area string, fallbackUploadDir string) gin.HandlerFunc {
 	normalizedArea := strings.TrimSpace(area)
 	return func(c *gin.Context) {
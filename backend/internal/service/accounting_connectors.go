@@ -0,0 +1,413 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/fieldcrypt"
+	"auralogic/internal/pkg/money"
+	"gorm.io/gorm"
+)
+
+const (
+	quickBooksTokenURL       = "https://oauth.platform.intuit.com/oauth2/v1/tokens/bearer"
+	quickBooksAPIBaseURL     = "https://quickbooks.api.intuit.com"
+	quickBooksSandboxBaseURL = "https://sandbox-quickbooks.api.intuit.com"
+	quickBooksMinorVersion   = "65"
+	quickBooksDocNumberLimit = 21
+
+	xeroTokenURL   = "https://identity.xero.com/connect/token"
+	xeroAPIBaseURL = "https://api.xero.com/api.xro/2.0"
+
+	accountingResponseBodyLimit = 1 << 20
+	// 访问令牌提前刷新的时间，避免推送过程中过期
+	accountingTokenRefreshSkew = time.Minute
+)
+
+var errAccountingJournalUnconverted = errors.New("journal currency differs from the base currency and no exchange rate was recorded at payment time")
+
+func (s *AccountingExportService) defaultConnector(provider string) (accountingConnector, error) {
+	cfg := s.exportConfig()
+	switch provider {
+	case AccountingProviderQuickBooks:
+		qb := cfg.QuickBooks
+		if strings.TrimSpace(qb.RealmID) == "" || strings.TrimSpace(qb.ClientID) == "" || strings.TrimSpace(qb.ClientSecret) == "" {
+			return nil, accountingCredentialsMissing(provider)
+		}
+		baseURL := quickBooksAPIBaseURL
+		if qb.Sandbox {
+			baseURL = quickBooksSandboxBaseURL
+		}
+		return &quickBooksConnector{
+			baseURL: baseURL,
+			realmID: strings.TrimSpace(qb.RealmID),
+			client:  s.httpClient,
+			oauth:   s.newOAuthTokenSource(provider, quickBooksTokenURL, qb.ClientID, qb.ClientSecret, qb.RefreshToken),
+		}, nil
+	case AccountingProviderXero:
+		xero := cfg.Xero
+		if strings.TrimSpace(xero.TenantID) == "" || strings.TrimSpace(xero.ClientID) == "" || strings.TrimSpace(xero.ClientSecret) == "" {
+			return nil, accountingCredentialsMissing(provider)
+		}
+		return &xeroConnector{
+			baseURL:  xeroAPIBaseURL,
+			tenantID: strings.TrimSpace(xero.TenantID),
+			client:   s.httpClient,
+			oauth:    s.newOAuthTokenSource(provider, xeroTokenURL, xero.ClientID, xero.ClientSecret, xero.RefreshToken),
+		}, nil
+	default:
+		return nil, bizerr.Newf("accounting.providerInvalid", "Unsupported accounting system: %s", provider).
+			WithParams(map[string]interface{}{"provider": provider})
+	}
+}
+
+func accountingCredentialsMissing(provider string) error {
+	return bizerr.Newf("accounting.credentialsMissing", "OAuth credentials for %s are not configured", provider).
+		WithParams(map[string]interface{}{"provider": provider})
+}
+
+// accountingOAuthTokenSource 使用 refresh_token 换取访问令牌；刷新令牌每次使用后轮换，最新值加密保存在数据库，
+// 配置中的 refresh_token 仅在数据库无令牌或已保存令牌失效时使用
+type accountingOAuthTokenSource struct {
+	db           *gorm.DB
+	client       *http.Client
+	provider     string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	seedToken    string
+	mu           sync.Mutex
+}
+
+func (s *AccountingExportService) newOAuthTokenSource(provider, tokenURL, clientID, clientSecret, seedToken string) *accountingOAuthTokenSource {
+	return &accountingOAuthTokenSource{
+		db:           s.db,
+		client:       s.httpClient,
+		provider:     provider,
+		tokenURL:     tokenURL,
+		clientID:     strings.TrimSpace(clientID),
+		clientSecret: strings.TrimSpace(clientSecret),
+		seedToken:    strings.TrimSpace(seedToken),
+	}
+}
+
+// AccessToken 返回有效的访问令牌，过期时自动刷新
+func (t *accountingOAuthTokenSource) AccessToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var credential models.AccountingCredential
+	err := t.db.Where("provider = ?", t.provider).First(&credential).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	credential.Provider = t.provider
+
+	if credential.ExpiresAt != nil && time.Now().Add(accountingTokenRefreshSkew).Before(*credential.ExpiresAt) {
+		accessToken, err := fieldcrypt.Decrypt(credential.AccessToken)
+		if err != nil {
+			return "", fmt.Errorf("decrypt access token: %w", err)
+		}
+		if accessToken != "" {
+			return accessToken, nil
+		}
+	}
+
+	storedRefresh, err := fieldcrypt.Decrypt(credential.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("decrypt refresh token: %w", err)
+	}
+	candidates := make([]string, 0, 2)
+	if storedRefresh != "" {
+		candidates = append(candidates, storedRefresh)
+	}
+	if t.seedToken != "" && t.seedToken != storedRefresh {
+		candidates = append(candidates, t.seedToken)
+	}
+	if len(candidates) == 0 {
+		return "", accountingCredentialsMissing(t.provider)
+	}
+
+	var refreshErr error
+	for _, refreshToken := range candidates {
+		token, err := t.refresh(ctx, refreshToken)
+		if err != nil {
+			refreshErr = err
+			continue
+		}
+		if token.RefreshToken == "" {
+			token.RefreshToken = refreshToken
+		}
+		if err := t.save(&credential, token); err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}
+	return "", refreshErr
+}
+
+type accountingOAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (t *accountingOAuthTokenSource) refresh(ctx context.Context, refreshToken string) (*accountingOAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(t.clientID, t.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token accountingOAuthToken
+	if err := doAccountingRequest(t.client, req, &token); err != nil {
+		return nil, fmt.Errorf("refresh %s token: %w", t.provider, err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("refresh %s token: empty access token", t.provider)
+	}
+	return &token, nil
+}
+
+func (t *accountingOAuthTokenSource) save(credential *models.AccountingCredential, token *accountingOAuthToken) error {
+	sealedAccess, err := fieldcrypt.Encrypt(token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("encrypt access token: %w", err)
+	}
+	sealedRefresh, err := fieldcrypt.Encrypt(token.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("encrypt refresh token: %w", err)
+	}
+	expiresIn := token.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = 1800
+	}
+	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
+	credential.AccessToken = sealedAccess
+	credential.RefreshToken = sealedRefresh
+	credential.ExpiresAt = &expiresAt
+	return t.db.Save(credential).Error
+}
+
+// doAccountingRequest 发送请求并解析 JSON 响应，非 2xx 时返回包含响应内容的错误
+func doAccountingRequest(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, accountingResponseBodyLimit))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := strings.TrimSpace(string(body))
+		if len(message) > 500 {
+			message = message[:500]
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// accountingAmount 最小单位金额转为 JSON 数字（保留两位小数）
+func accountingAmount(amountMinor int64) json.Number {
+	return json.Number(money.MinorToString(amountMinor))
+}
+
+// quickBooksConnector 以 JournalEntry 形式推送到 QuickBooks Online，多币种分录附带付款时汇率
+type quickBooksConnector struct {
+	baseURL string
+	realmID string
+	client  *http.Client
+	oauth   *accountingOAuthTokenSource
+}
+
+type quickBooksRef struct {
+	Value string `json:"value"`
+}
+
+type quickBooksJournalLineDetail struct {
+	PostingType string         `json:"PostingType"`
+	AccountRef  quickBooksRef  `json:"AccountRef"`
+	TaxCodeRef  *quickBooksRef `json:"TaxCodeRef,omitempty"`
+}
+
+type quickBooksJournalLine struct {
+	Amount                 json.Number                 `json:"Amount"`
+	Description            string                      `json:"Description,omitempty"`
+	DetailType             string                      `json:"DetailType"`
+	JournalEntryLineDetail quickBooksJournalLineDetail `json:"JournalEntryLineDetail"`
+}
+
+type quickBooksJournalEntry struct {
+	DocNumber    string                  `json:"DocNumber"`
+	TxnDate      string                  `json:"TxnDate"`
+	PrivateNote  string                  `json:"PrivateNote,omitempty"`
+	CurrencyRef  *quickBooksRef          `json:"CurrencyRef,omitempty"`
+	ExchangeRate float64                 `json:"ExchangeRate,omitempty"`
+	Line         []quickBooksJournalLine `json:"Line"`
+}
+
+func buildQuickBooksJournalEntry(journal AccountingJournal) quickBooksJournalEntry {
+	docNumber := journal.Reference()
+	if len(docNumber) > quickBooksDocNumberLimit {
+		docNumber = docNumber[len(docNumber)-quickBooksDocNumberLimit:]
+	}
+	entry := quickBooksJournalEntry{
+		DocNumber:   docNumber,
+		TxnDate:     journal.Date.Format("2006-01-02"),
+		PrivateNote: journal.Memo(),
+		CurrencyRef: &quickBooksRef{Value: journal.Currency},
+	}
+	if journal.BaseCurrency != "" && journal.Currency != journal.BaseCurrency && journal.FXRate > 0 {
+		entry.ExchangeRate = journal.FXRate
+	}
+	for _, line := range journal.Lines {
+		postingType, amount := "Debit", line.Debit
+		if line.Credit > 0 {
+			postingType, amount = "Credit", line.Credit
+		}
+		detail := quickBooksJournalLineDetail{
+			PostingType: postingType,
+			AccountRef:  quickBooksRef{Value: line.Account},
+		}
+		if line.TaxCode != "" {
+			detail.TaxCodeRef = &quickBooksRef{Value: line.TaxCode}
+		}
+		entry.Line = append(entry.Line, quickBooksJournalLine{
+			Amount:                 accountingAmount(amount),
+			Description:            line.Description,
+			DetailType:             "JournalEntryLineDetail",
+			JournalEntryLineDetail: detail,
+		})
+	}
+	return entry
+}
+
+func (c *quickBooksConnector) PushJournal(ctx context.Context, journal AccountingJournal) (string, error) {
+	accessToken, err := c.oauth.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(buildQuickBooksJournalEntry(journal))
+	if err != nil {
+		return "", err
+	}
+	endpoint := fmt.Sprintf("%s/v3/company/%s/journalentry?minorversion=%s", c.baseURL, url.PathEscape(c.realmID), quickBooksMinorVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	var result struct {
+		JournalEntry struct {
+			ID string `json:"Id"`
+		} `json:"JournalEntry"`
+	}
+	if err := doAccountingRequest(c.client, req, &result); err != nil {
+		return "", err
+	}
+	return result.JournalEntry.ID, nil
+}
+
+// xeroConnector 以 Manual Journal 形式推送到 Xero；Xero 手工分录仅支持本位币，按付款时汇率折算
+type xeroConnector struct {
+	baseURL  string
+	tenantID string
+	client   *http.Client
+	oauth    *accountingOAuthTokenSource
+}
+
+type xeroJournalLine struct {
+	LineAmount  json.Number `json:"LineAmount"` // 借方为正，贷方为负
+	AccountCode string      `json:"AccountCode"`
+	Description string      `json:"Description,omitempty"`
+	TaxType     string      `json:"TaxType,omitempty"`
+}
+
+type xeroManualJournal struct {
+	Narration    string            `json:"Narration"`
+	Date         string            `json:"Date"`
+	Status       string            `json:"Status"`
+	JournalLines []xeroJournalLine `json:"JournalLines"`
+}
+
+func buildXeroManualJournal(journal AccountingJournal) (xeroManualJournal, error) {
+	lines, ok := journal.BaseLines()
+	if !ok {
+		return xeroManualJournal{}, errAccountingJournalUnconverted
+	}
+	manual := xeroManualJournal{
+		Narration: journal.Reference() + " " + journal.Memo(),
+		Date:      journal.Date.Format("2006-01-02"),
+		Status:    "POSTED",
+	}
+	for _, line := range lines {
+		manual.JournalLines = append(manual.JournalLines, xeroJournalLine{
+			LineAmount:  accountingAmount(line.Debit - line.Credit),
+			AccountCode: line.Account,
+			Description: line.Description,
+			TaxType:     line.TaxCode,
+		})
+	}
+	return manual, nil
+}
+
+func (c *xeroConnector) PushJournal(ctx context.Context, journal AccountingJournal) (string, error) {
+	manual, err := buildXeroManualJournal(journal)
+	if err != nil {
+		return "", err
+	}
+	accessToken, err := c.oauth.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(map[string]interface{}{"ManualJournals": []xeroManualJournal{manual}})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/ManualJournals", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Xero-tenant-id", c.tenantID)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	var result struct {
+		ManualJournals []struct {
+			ManualJournalID string `json:"ManualJournalID"`
+		} `json:"ManualJournals"`
+	}
+	if err := doAccountingRequest(c.client, req, &result); err != nil {
+		return "", err
+	}
+	if len(result.ManualJournals) == 0 {
+		return "", fmt.Errorf("xero returned no manual journal")
+	}
+	return result.ManualJournals[0].ManualJournalID, nil
+}
@@ -0,0 +1,521 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAccountingExportRunHour      = 2
+	defaultAccountingExportLookbackDays = 30
+	// 单次推送最多处理的分录数，剩余分录在下次推送时继续
+	accountingExportMaxPushPerRun = 200
+	accountingExportPushTimeout   = 30 * time.Second
+)
+
+// 支持推送的会计系统
+const (
+	AccountingProviderQuickBooks = "quickbooks"
+	AccountingProviderXero       = "xero"
+)
+
+// AccountingJournalLine 会计分录行，金额为订单币种最小单位，借贷二选一
+type AccountingJournalLine struct {
+	Account     string `json:"account"`
+	Description string `json:"description"`
+	Debit       int64  `json:"debit_minor"`
+	Credit      int64  `json:"credit_minor"`
+	TaxCode     string `json:"tax_code,omitempty"`
+}
+
+// AccountingJournal 单个订单的一笔会计分录（销售、收款或退款）
+type AccountingJournal struct {
+	Type         string                  `json:"type"`
+	OrderID      uint                    `json:"order_id"`
+	OrderNo      string                  `json:"order_no"`
+	Date         time.Time               `json:"date"`
+	Currency     string                  `json:"currency"`
+	BaseCurrency string                  `json:"base_currency"`
+	FXRate       float64                 `json:"fx_rate"` // 付款时记录的本位币汇率，0 表示未配置
+	Gateway      string                  `json:"gateway"`
+	Lines        []AccountingJournalLine `json:"lines"`
+}
+
+// Reference 分录编号，如 ORD123-INV
+func (j AccountingJournal) Reference() string {
+	suffix := map[string]string{
+		models.AccountingJournalInvoice: "INV",
+		models.AccountingJournalPayment: "PAY",
+		models.AccountingJournalRefund:  "REF",
+	}[j.Type]
+	return j.OrderNo + "-" + suffix
+}
+
+// Memo 分录摘要
+func (j AccountingJournal) Memo() string {
+	switch j.Type {
+	case models.AccountingJournalPayment:
+		if j.Gateway != "" {
+			return fmt.Sprintf("Payment for order %s via %s", j.OrderNo, j.Gateway)
+		}
+		return fmt.Sprintf("Payment for order %s", j.OrderNo)
+	case models.AccountingJournalRefund:
+		return fmt.Sprintf("Refund for order %s", j.OrderNo)
+	default:
+		return fmt.Sprintf("Sales for order %s", j.OrderNo)
+	}
+}
+
+// BaseLines 按付款时记录的汇率将分录行折算为记账本位币，折算尾差计入最后一条贷方行；
+// 订单币种与本位币不同且付款时未配置汇率时返回 false
+func (j AccountingJournal) BaseLines() ([]AccountingJournalLine, bool) {
+	if j.Currency == j.BaseCurrency || j.BaseCurrency == "" {
+		return j.Lines, true
+	}
+	if j.FXRate <= 0 {
+		return nil, false
+	}
+	lines := make([]AccountingJournalLine, len(j.Lines))
+	var diff int64
+	lastCredit := -1
+	for i, line := range j.Lines {
+		line.Debit = convertToBaseMinor(line.Debit, j.FXRate)
+		line.Credit = convertToBaseMinor(line.Credit, j.FXRate)
+		diff += line.Debit - line.Credit
+		if line.Credit > 0 {
+			lastCredit = i
+		}
+		lines[i] = line
+	}
+	if diff != 0 && lastCredit >= 0 {
+		lines[lastCredit].Credit += diff
+	}
+	return lines, true
+}
+
+// accountingExportJobName 定时推送的任务名；管理端手动推送持有同名任务锁，多实例间同时只有一个推送在执行，避免同一分录被重复推送
+const accountingExportJobName = "accounting_export"
+
+// accountingConnector 会计系统推送接口，返回会计系统中的分录 ID
+type accountingConnector interface {
+	PushJournal(ctx context.Context, journal AccountingJournal) (string, error)
+}
+
+// AccountingExportService 会计系统对接：将已付款/已退款订单生成会计分录，导出为 CSV 或定时推送到 QuickBooks Online / Xero
+type AccountingExportService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	httpClient    *http.Client
+	newConnector  func(provider string) (accountingConnector, error)
+	locker        JobLocker
+	checkInterval time.Duration
+}
+
+// NewAccountingExportService 创建会计系统对接服务
+func NewAccountingExportService(db *gorm.DB, cfg *config.Config) *AccountingExportService {
+	s := &AccountingExportService{
+		db:            db,
+		cfg:           cfg,
+		httpClient:    &http.Client{Timeout: accountingExportPushTimeout},
+		locker:        defaultJobLocker(),
+		checkInterval: 10 * time.Minute, // 定时检查是否到达每日推送时刻
+	}
+	s.newConnector = s.defaultConnector
+	return s
+}
+
+func (s *AccountingExportService) exportConfig() config.AccountingExportConfig {
+	if s.cfg == nil {
+		return config.AccountingExportConfig{}
+	}
+	return s.cfg.Order.AccountingExport
+}
+
+// Provider 当前配置的会计系统，未配置时为空
+func (s *AccountingExportService) Provider() string {
+	return strings.ToLower(strings.TrimSpace(s.exportConfig().Provider))
+}
+
+// RegisterJobs 注册定时推送任务
+func (s *AccountingExportService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        accountingExportJobName,
+		Description: "Push journals for paid and refunded orders to the accounting system once a day",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			return s.runScheduled(time.Now().UTC())
+		},
+	})
+}
+
+func (s *AccountingExportService) runHour() int {
+	hour := s.exportConfig().RunHour
+	if hour < 0 || hour > 23 {
+		return defaultAccountingExportRunHour
+	}
+	return hour
+}
+
+func (s *AccountingExportService) lookback() time.Duration {
+	days := s.exportConfig().LookbackDays
+	if days <= 0 {
+		days = defaultAccountingExportLookbackDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// runScheduled 到达每日推送时刻且当天尚未推送时执行一次；由调度器在持有任务锁时调用
+func (s *AccountingExportService) runScheduled(now time.Time) error {
+	if !s.exportConfig().Enabled || s.Provider() == "" || now.Hour() != s.runHour() {
+		return nil
+	}
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var count int64
+	if err := s.db.Model(&models.AccountingExportRun{}).
+		Where("trigger_type = ? AND started_at >= ?", models.AccountingExportTriggerScheduled, dayStart).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("count scheduled accounting exports: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err := s.validateAccountMapping(); err != nil {
+		return err
+	}
+	_, err := s.push(s.Provider(), models.AccountingExportTriggerScheduled, nil)
+	return err
+}
+
+// accountingTaxCode 按收货国家代码查找销售税码，未配置时使用 "*"
+func accountingTaxCode(taxCodes map[string]string, country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	for code, taxCode := range taxCodes {
+		if country != "" && strings.ToUpper(strings.TrimSpace(code)) == country {
+			return strings.TrimSpace(taxCode)
+		}
+	}
+	return strings.TrimSpace(taxCodes["*"])
+}
+
+// accountingClearingAccount 按付款方式名称查找收款账户，未配置时使用默认收款账户
+func accountingClearingAccount(accounts config.AccountingAccountMapping, gateway string) string {
+	if account := strings.TrimSpace(accounts.PaymentMethods[gateway]); gateway != "" && account != "" {
+		return account
+	}
+	return strings.TrimSpace(accounts.Clearing)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+// BuildJournals 生成区间 [from, to) 内付款和退款订单的会计分录，按记账日期排序；limit<=0 表示不限制订单数
+func (s *AccountingExportService) BuildJournals(from, to time.Time, limit int) ([]AccountingJournal, error) {
+	var records []struct {
+		ID              uint
+		OrderNo         string
+		Status          string
+		PaidAt          *time.Time
+		RefundedAt      *time.Time
+		Currency        string
+		Gateway         string
+		TotalAmount     int64
+		PaymentFee      int64
+		PaymentFeeKept  bool
+		FXRate          float64
+		FXBaseCurrency  string
+		ReceiverCountry string
+	}
+
+	query := s.db.Table("orders").
+		Select("orders.id, orders.order_no, orders.status, orders.paid_at, orders.refunded_at, orders.currency, "+
+			"COALESCE(payment_methods.name, '') AS gateway, orders.total_amount, orders.payment_fee, "+
+			"orders.payment_fee_retained AS payment_fee_kept, orders.fx_rate, orders.fx_base_currency, orders.receiver_country").
+		Joins("LEFT JOIN order_payment_methods ON order_payment_methods.order_id = orders.id").
+		Joins("LEFT JOIN payment_methods ON payment_methods.id = order_payment_methods.payment_method_id").
+		Where("orders.deleted_at IS NULL").
+		Where("(orders.paid_at >= ? AND orders.paid_at < ?) OR (orders.refunded_at >= ? AND orders.refunded_at < ?)", from, to, from, to).
+		Order("orders.id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	cfg := s.exportConfig()
+	accounts := cfg.Accounts
+	salesAccount := strings.TrimSpace(accounts.Sales)
+	surchargeAccount := firstNonEmpty(accounts.Surcharge, accounts.Sales)
+	refundAccount := firstNonEmpty(accounts.Refunds, accounts.Sales)
+	receivableAccount := strings.TrimSpace(accounts.Receivable)
+	inRange := func(at *time.Time) bool {
+		return at != nil && !at.Before(from) && at.Before(to)
+	}
+
+	journals := make([]AccountingJournal, 0, len(records)*2)
+	for _, record := range records {
+		if record.TotalAmount <= 0 {
+			continue
+		}
+		base := AccountingJournal{
+			OrderID:      record.ID,
+			OrderNo:      record.OrderNo,
+			Currency:     strings.ToUpper(record.Currency),
+			BaseCurrency: strings.ToUpper(record.FXBaseCurrency),
+			FXRate:       record.FXRate,
+			Gateway:      record.Gateway,
+		}
+		taxCode := accountingTaxCode(cfg.TaxCodes, record.ReceiverCountry)
+		clearing := accountingClearingAccount(accounts, record.Gateway)
+		goods := record.TotalAmount - record.PaymentFee
+
+		if inRange(record.PaidAt) {
+			invoice := base
+			invoice.Type = models.AccountingJournalInvoice
+			invoice.Date = record.PaidAt.UTC()
+			invoice.Lines = []AccountingJournalLine{
+				{Account: receivableAccount, Description: "Accounts receivable", Debit: record.TotalAmount},
+				{Account: salesAccount, Description: "Sales", Credit: goods, TaxCode: taxCode},
+			}
+			if record.PaymentFee > 0 {
+				invoice.Lines = append(invoice.Lines, AccountingJournalLine{
+					Account: surchargeAccount, Description: "Payment surcharge", Credit: record.PaymentFee, TaxCode: taxCode,
+				})
+			}
+
+			payment := base
+			payment.Type = models.AccountingJournalPayment
+			payment.Date = record.PaidAt.UTC()
+			payment.Lines = []AccountingJournalLine{
+				{Account: clearing, Description: "Payment received", Debit: record.TotalAmount},
+				{Account: receivableAccount, Description: "Accounts receivable", Credit: record.TotalAmount},
+			}
+			journals = append(journals, invoice, payment)
+		}
+
+		if models.OrderStatus(record.Status) == models.OrderStatusRefunded && inRange(record.RefundedAt) {
+			order := models.Order{
+				TotalAmount:        record.TotalAmount,
+				PaymentFee:         record.PaymentFee,
+				PaymentFeeRetained: record.PaymentFeeKept,
+			}
+			refunded := order.RefundableAmount()
+			refund := base
+			refund.Type = models.AccountingJournalRefund
+			refund.Date = record.RefundedAt.UTC()
+			refund.Lines = []AccountingJournalLine{
+				{Account: refundAccount, Description: "Sales refund", Debit: goods, TaxCode: taxCode},
+			}
+			if feeRefunded := refunded - goods; feeRefunded > 0 {
+				refund.Lines = append(refund.Lines, AccountingJournalLine{
+					Account: surchargeAccount, Description: "Payment surcharge refund", Debit: feeRefunded, TaxCode: taxCode,
+				})
+			}
+			refund.Lines = append(refund.Lines, AccountingJournalLine{
+				Account: clearing, Description: "Refund paid", Credit: refunded,
+			})
+			journals = append(journals, refund)
+		}
+	}
+
+	journalOrder := map[string]int{
+		models.AccountingJournalInvoice: 0,
+		models.AccountingJournalPayment: 1,
+		models.AccountingJournalRefund:  2,
+	}
+	sort.SliceStable(journals, func(i, j int) bool {
+		if !journals[i].Date.Equal(journals[j].Date) {
+			return journals[i].Date.Before(journals[j].Date)
+		}
+		if journals[i].OrderID != journals[j].OrderID {
+			return journals[i].OrderID < journals[j].OrderID
+		}
+		return journalOrder[journals[i].Type] < journalOrder[journals[j].Type]
+	})
+	return journals, nil
+}
+
+// validateAccountMapping 推送前检查必填的科目映射
+func (s *AccountingExportService) validateAccountMapping() error {
+	accounts := s.exportConfig().Accounts
+	for field, value := range map[string]string{
+		"sales":      accounts.Sales,
+		"receivable": accounts.Receivable,
+		"clearing":   accounts.Clearing,
+	} {
+		if strings.TrimSpace(value) == "" {
+			return bizerr.Newf("accounting.accountMappingMissing", "Account mapping %q is not configured", field).
+				WithParams(map[string]interface{}{"field": field})
+		}
+	}
+	return nil
+}
+
+// Push 将最近 lookback_days 天内尚未推送的分录推送到会计系统；与定时推送共用任务锁，同时只允许一个推送在执行
+func (s *AccountingExportService) Push(triggerType string, triggeredBy *uint) (*models.AccountingExportRun, error) {
+	provider := s.Provider()
+	if provider == "" {
+		return nil, bizerr.New("accounting.providerNotConfigured", "No accounting system is configured")
+	}
+	if err := s.validateAccountMapping(); err != nil {
+		return nil, err
+	}
+
+	var run *models.AccountingExportRun
+	locked, err := runWithJobLock(context.Background(), s.locker, accountingExportJobName, func(context.Context) error {
+		var pushErr error
+		run, pushErr = s.push(provider, triggerType, triggeredBy)
+		return pushErr
+	})
+	if !locked && err == nil {
+		return nil, bizerr.New("accounting.exportRunning", "An accounting export is already running")
+	}
+	return run, err
+}
+
+// push 执行一次推送并保存执行记录，调用方须持有任务锁
+func (s *AccountingExportService) push(provider, triggerType string, triggeredBy *uint) (*models.AccountingExportRun, error) {
+	connector, err := s.newConnector(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.AccountingExportRun{
+		Provider:    provider,
+		TriggerType: triggerType,
+		TriggeredBy: triggeredBy,
+		StartedAt:   models.NowFunc(),
+	}
+	pushErr := s.pushJournals(run, connector)
+	finishedAt := models.NowFunc()
+	run.FinishedAt = &finishedAt
+	switch {
+	case pushErr != nil:
+		run.Status = models.AccountingExportStatusFailed
+		run.Error = pushErr.Error()
+	case run.FailedCount > 0 && run.PushedCount == 0:
+		run.Status = models.AccountingExportStatusFailed
+	case run.FailedCount > 0:
+		run.Status = models.AccountingExportStatusPartial
+	default:
+		run.Status = models.AccountingExportStatusCompleted
+	}
+	if err := s.db.Save(run).Error; err != nil {
+		return nil, err
+	}
+
+	logger.LogSystemOperation(s.db, "accounting_export_run", "accounting_export", &run.ID, map[string]interface{}{
+		"provider":      run.Provider,
+		"trigger_type":  run.TriggerType,
+		"status":        run.Status,
+		"pushed_count":  run.PushedCount,
+		"failed_count":  run.FailedCount,
+		"skipped_count": run.SkippedCount,
+	})
+	if pushErr != nil {
+		return run, pushErr
+	}
+	return run, nil
+}
+
+func (s *AccountingExportService) pushJournals(run *models.AccountingExportRun, connector accountingConnector) error {
+	// 先保存执行记录以获得 ID，推送成功的分录关联到本次执行
+	if err := s.db.Create(run).Error; err != nil {
+		return err
+	}
+
+	now := models.NowFunc()
+	journals, err := s.BuildJournals(now.Add(-s.lookback()), now, 0)
+	if err != nil {
+		return fmt.Errorf("build journals: %w", err)
+	}
+	if len(journals) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]uint, 0, len(journals))
+	for _, journal := range journals {
+		orderIDs = append(orderIDs, journal.OrderID)
+	}
+	var existing []models.AccountingExportRecord
+	if err := s.db.Select("journal_type, order_id").
+		Where("provider = ? AND order_id IN ?", run.Provider, orderIDs).
+		Find(&existing).Error; err != nil {
+		return err
+	}
+	pushed := make(map[string]bool, len(existing))
+	for _, record := range existing {
+		pushed[fmt.Sprintf("%s:%d", record.JournalType, record.OrderID)] = true
+	}
+
+	var errorMessages []string
+	for _, journal := range journals {
+		if pushed[fmt.Sprintf("%s:%d", journal.Type, journal.OrderID)] {
+			run.SkippedCount++
+			continue
+		}
+		if run.PushedCount+run.FailedCount >= accountingExportMaxPushPerRun {
+			break
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), accountingExportPushTimeout)
+		externalID, pushErr := connector.PushJournal(ctx, journal)
+		cancel()
+		if pushErr != nil {
+			run.FailedCount++
+			if len(errorMessages) < 5 {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s: %v", journal.Reference(), pushErr))
+			}
+			continue
+		}
+
+		record := models.AccountingExportRecord{
+			Provider:    run.Provider,
+			JournalType: journal.Type,
+			OrderID:     journal.OrderID,
+			OrderNo:     journal.OrderNo,
+			ExternalID:  externalID,
+			RunID:       run.ID,
+		}
+		if err := s.db.Create(&record).Error; err != nil {
+			return fmt.Errorf("save export record for %s: %w", journal.Reference(), err)
+		}
+		run.PushedCount++
+	}
+	run.Error = strings.Join(errorMessages, "\n")
+	return nil
+}
+
+// ListRuns 查询推送记录
+func (s *AccountingExportService) ListRuns(page, limit int) ([]models.AccountingExportRun, int64, error) {
+	query := s.db.Model(&models.AccountingExportRun{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var runs []models.AccountingExportRun
+	if err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
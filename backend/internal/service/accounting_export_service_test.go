@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+func newAccountingExportTestService(t *testing.T) (*AccountingExportService, *gorm.DB) {
+	t.Helper()
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(
+		&models.Order{},
+		&models.PaymentMethod{},
+		&models.OrderPaymentMethod{},
+		&models.AccountingExportRun{},
+		&models.AccountingExportRecord{},
+		&models.AccountingCredential{},
+	); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Order.AccountingExport = config.AccountingExportConfig{
+		Provider: AccountingProviderQuickBooks,
+		Accounts: config.AccountingAccountMapping{
+			Sales:          "4000",
+			Surcharge:      "4100",
+			Receivable:     "1100",
+			Clearing:       "1010",
+			PaymentMethods: map[string]string{"Stripe": "1020"},
+		},
+		TaxCodes: map[string]string{"*": "EXEMPT", "us": "TAX"},
+	}
+	return NewAccountingExportService(db, cfg), db
+}
+
+func seedAccountingExportOrders(t *testing.T, db *gorm.DB) (paidAt, refundedAt time.Time) {
+	t.Helper()
+	stripe := models.PaymentMethod{Name: "Stripe", Type: models.PaymentMethodTypeCustom, Enabled: true}
+	if err := db.Create(&stripe).Error; err != nil {
+		t.Fatalf("create payment method failed: %v", err)
+	}
+
+	paidAt = time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+	refundedAt = paidAt.Add(24 * time.Hour)
+	orders := []models.Order{
+		{
+			OrderNo: "ACC-PAID", Status: models.OrderStatusShipped, Currency: "USD", ReceiverCountry: "US",
+			TotalAmount: 10200, PaymentFee: 200, PaidAt: &paidAt, FXBaseCurrency: "CNY", FXRate: 7.1,
+		},
+		{
+			OrderNo: "ACC-REFUNDED", Status: models.OrderStatusRefunded, Currency: "CNY", ReceiverCountry: "CN",
+			TotalAmount: 5300, PaymentFee: 300, PaymentFeeRetained: true, PaidAt: &paidAt, RefundedAt: &refundedAt,
+			FXBaseCurrency: "CNY", FXRate: 1,
+		},
+	}
+	for i := range orders {
+		orders[i].Items = []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}}
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: orders[0].ID, PaymentMethodID: stripe.ID}).Error; err != nil {
+		t.Fatalf("create order payment method failed: %v", err)
+	}
+	return paidAt, refundedAt
+}
+
+func requireBalancedJournal(t *testing.T, journal AccountingJournal) {
+	t.Helper()
+	var debit, credit int64
+	for _, line := range journal.Lines {
+		debit += line.Debit
+		credit += line.Credit
+	}
+	if debit != credit || debit == 0 {
+		t.Fatalf("journal %s is not balanced: debit=%d credit=%d", journal.Reference(), debit, credit)
+	}
+}
+
+func TestAccountingExportBuildJournalsMapsAccountsAndTaxCodes(t *testing.T) {
+	svc, db := newAccountingExportTestService(t)
+	paidAt, _ := seedAccountingExportOrders(t, db)
+
+	journals, err := svc.BuildJournals(paidAt.Add(-time.Hour), time.Now().UTC(), 0)
+	if err != nil {
+		t.Fatalf("build journals failed: %v", err)
+	}
+	if len(journals) != 5 {
+		t.Fatalf("expected invoice+payment for both orders and one refund, got %d journals", len(journals))
+	}
+	for _, journal := range journals {
+		requireBalancedJournal(t, journal)
+	}
+
+	byRef := make(map[string]AccountingJournal, len(journals))
+	for _, journal := range journals {
+		byRef[journal.Reference()] = journal
+	}
+
+	invoice := byRef["ACC-PAID-INV"]
+	if len(invoice.Lines) != 3 || invoice.Lines[1].Account != "4000" || invoice.Lines[1].Credit != 10000 || invoice.Lines[1].TaxCode != "TAX" {
+		t.Fatalf("unexpected invoice lines: %+v", invoice.Lines)
+	}
+	if invoice.Lines[2].Account != "4100" || invoice.Lines[2].Credit != 200 {
+		t.Fatalf("expected surcharge credited to surcharge account, got %+v", invoice.Lines[2])
+	}
+	if payment := byRef["ACC-PAID-PAY"]; payment.Lines[0].Account != "1020" {
+		t.Fatalf("expected Stripe clearing account, got %+v", payment.Lines)
+	}
+
+	// 附加费不退还时，仅冲回商品金额，付款方式未记录时使用默认收款账户
+	refund := byRef["ACC-REFUNDED-REF"]
+	if len(refund.Lines) != 2 || refund.Lines[0].Account != "4000" || refund.Lines[0].Debit != 5000 ||
+		refund.Lines[0].TaxCode != "EXEMPT" || refund.Lines[1].Account != "1010" || refund.Lines[1].Credit != 5000 {
+		t.Fatalf("unexpected refund lines: %+v", refund.Lines)
+	}
+	if journals[len(journals)-1].Type != models.AccountingJournalRefund {
+		t.Fatalf("expected journals to be ordered by date, got %+v", journals[len(journals)-1])
+	}
+
+	base, ok := invoice.BaseLines()
+	if !ok {
+		t.Fatalf("expected invoice to convert with recorded rate")
+	}
+	var debit, credit int64
+	for _, line := range base {
+		debit += line.Debit
+		credit += line.Credit
+	}
+	if debit != 72420 || credit != debit {
+		t.Fatalf("unexpected base lines: debit=%d credit=%d", debit, credit)
+	}
+}
+
+type fakeAccountingConnector struct {
+	pushed []string
+	fail   map[string]bool
+}
+
+func (f *fakeAccountingConnector) PushJournal(_ context.Context, journal AccountingJournal) (string, error) {
+	if f.fail[journal.Reference()] {
+		return "", errors.New("rejected")
+	}
+	f.pushed = append(f.pushed, journal.Reference())
+	return "ext-" + journal.Reference(), nil
+}
+
+func TestAccountingExportPushSkipsAlreadyPushedJournals(t *testing.T) {
+	svc, db := newAccountingExportTestService(t)
+	seedAccountingExportOrders(t, db)
+
+	connector := &fakeAccountingConnector{fail: map[string]bool{"ACC-REFUNDED-REF": true}}
+	svc.newConnector = func(string) (accountingConnector, error) { return connector, nil }
+
+	run, err := svc.Push(models.AccountingExportTriggerManual, nil)
+	if err != nil {
+		t.Fatalf("first push failed: %v", err)
+	}
+	if run.Status != models.AccountingExportStatusPartial || run.PushedCount != 4 || run.FailedCount != 1 || run.Error == "" {
+		t.Fatalf("unexpected first run: %+v", run)
+	}
+
+	connector.fail = nil
+	run, err = svc.Push(models.AccountingExportTriggerManual, nil)
+	if err != nil {
+		t.Fatalf("second push failed: %v", err)
+	}
+	if run.Status != models.AccountingExportStatusCompleted || run.PushedCount != 1 || run.SkippedCount != 4 {
+		t.Fatalf("unexpected retry run: %+v", run)
+	}
+	if len(connector.pushed) != 5 {
+		t.Fatalf("expected each journal to be pushed once, got %v", connector.pushed)
+	}
+
+	svc.cfg.Order.AccountingExport.Accounts.Receivable = ""
+	_, err = svc.Push(models.AccountingExportTriggerManual, nil)
+	requireBizErr(t, err, "accounting.accountMappingMissing")
+}
+
+func TestQuickBooksConnectorRefreshesTokenAndPostsJournalEntry(t *testing.T) {
+	svc, _ := newAccountingExportTestService(t)
+
+	var posted quickBooksJournalEntry
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			if r.FormValue("refresh_token") != "seed-refresh" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "access-1", "refresh_token": "rotated-refresh", "expires_in": 3600,
+			})
+		case "/v3/company/realm-1/journalentry":
+			if r.Header.Get("Authorization") != "Bearer access-1" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"JournalEntry": map[string]string{"Id": "145"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	connector := &quickBooksConnector{
+		baseURL: server.URL,
+		realmID: "realm-1",
+		client:  server.Client(),
+		oauth:   svc.newOAuthTokenSource(AccountingProviderQuickBooks, server.URL+"/token", "client", "secret", "seed-refresh"),
+	}
+	journal := AccountingJournal{
+		Type: models.AccountingJournalInvoice, OrderNo: "ORDER-1", Date: time.Date(2026, 9, 30, 8, 0, 0, 0, time.UTC),
+		Currency: "USD", BaseCurrency: "CNY", FXRate: 7.1,
+		Lines: []AccountingJournalLine{
+			{Account: "1100", Description: "Accounts receivable", Debit: 1999},
+			{Account: "4000", Description: "Sales", Credit: 1999, TaxCode: "TAX"},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		externalID, err := connector.PushJournal(context.Background(), journal)
+		if err != nil {
+			t.Fatalf("push journal failed: %v", err)
+		}
+		if externalID != "145" {
+			t.Fatalf("unexpected external id %q", externalID)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Fatalf("expected cached access token to be reused, got %d token requests", tokenRequests)
+	}
+	if posted.DocNumber != "ORDER-1-INV" || posted.TxnDate != "2026-09-30" || posted.ExchangeRate != 7.1 || len(posted.Line) != 2 {
+		t.Fatalf("unexpected journal entry payload: %+v", posted)
+	}
+	if posted.Line[0].Amount.String() != "19.99" || posted.Line[1].JournalEntryLineDetail.PostingType != "Credit" {
+		t.Fatalf("unexpected journal entry lines: %+v", posted.Line)
+	}
+
+	var credential models.AccountingCredential
+	if err := svc.db.Where("provider = ?", AccountingProviderQuickBooks).First(&credential).Error; err != nil {
+		t.Fatalf("load credential failed: %v", err)
+	}
+	if credential.RefreshToken != "rotated-refresh" || credential.ExpiresAt == nil {
+		t.Fatalf("expected rotated refresh token to be persisted, got %+v", credential)
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"gorm.io/gorm"
+)
+
+const (
+	adminOverviewCacheKey = "admin_overview"
+	// AdminOverviewCacheTTL 概览数据缓存时长，移动端/小组件频繁轮询时不必每次查库
+	AdminOverviewCacheTTL = 30 * time.Second
+	// 统计最近一段时间内失败的后台任务
+	adminOverviewJobFailureWindow = 24 * time.Hour
+)
+
+// AdminOverviewJobFailure 最近失败的后台任务
+type AdminOverviewJobFailure struct {
+	Name         string    `json:"name"`
+	Count        int64     `json:"count"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+}
+
+// AdminOverview 管理端精简概览，供移动端与桌面小组件使用
+type AdminOverview struct {
+	GeneratedAt        time.Time                 `json:"generated_at"`
+	Currency           string                    `json:"currency"`
+	OrdersToday        int64                     `json:"orders_today"`
+	OrdersToShip       int64                     `json:"orders_to_ship"`
+	RevenueTodayMinor  int64                     `json:"revenue_today_minor"`
+	PendingRefunds     int64                     `json:"pending_refunds"`
+	OpenTickets        int64                     `json:"open_tickets"`
+	FailingJobs        []AdminOverviewJobFailure `json:"failing_jobs"`
+	FailingJobsTotal   int64                     `json:"failing_jobs_total"`
+	FailureWindowHours int                       `json:"failure_window_hours"`
+	CacheTTLSeconds    int                       `json:"cache_ttl_seconds"`
+}
+
+// GetAdminOverview 读取管理端概览，Redis 可用时缓存 AdminOverviewCacheTTL
+func GetAdminOverview(db *gorm.DB, cfg *config.Config) (*AdminOverview, error) {
+	if cache.RedisClient != nil {
+		if raw, err := cache.Get(adminOverviewCacheKey); err == nil && raw != "" {
+			var cached AdminOverview
+			if json.Unmarshal([]byte(raw), &cached) == nil {
+				return &cached, nil
+			}
+		}
+	}
+
+	overview, err := BuildAdminOverview(db, cfg, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if cache.RedisClient != nil {
+		if payload, err := json.Marshal(overview); err == nil {
+			_ = cache.Set(adminOverviewCacheKey, string(payload), AdminOverviewCacheTTL)
+		}
+	}
+	return overview, nil
+}
+
+// BuildAdminOverview 直接查库生成概览；今日订单与销售额口径与仪表盘统计一致
+func BuildAdminOverview(db *gorm.DB, cfg *config.Config, now time.Time) (*AdminOverview, error) {
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	overview := &AdminOverview{
+		GeneratedAt:        now,
+		Currency:           "CNY",
+		FailingJobs:        []AdminOverviewJobFailure{},
+		FailureWindowHours: int(adminOverviewJobFailureWindow / time.Hour),
+		CacheTTLSeconds:    int(AdminOverviewCacheTTL / time.Second),
+	}
+	if cfg != nil && cfg.Order.Currency != "" {
+		overview.Currency = cfg.Order.Currency
+	}
+
+	paidStatuses := []models.OrderStatus{
+		models.OrderStatusPending,
+		models.OrderStatusShipped,
+		models.OrderStatusCompleted,
+	}
+	if err := db.Model(&models.Order{}).Where("created_at >= ?", todayStart).
+		Count(&overview.OrdersToday).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.Order{}).
+		Where("created_at >= ? AND status IN ?", todayStart, paidStatuses).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Scan(&overview.RevenueTodayMinor).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.Order{}).Where("status = ?", models.OrderStatusPending).
+		Count(&overview.OrdersToShip).Error; err != nil {
+		return nil, err
+	}
+
+	// 待处理退款：用户提交的退款申请 + 等待人工确认的退款订单
+	var refundRequests, refundPendingOrders int64
+	if err := db.Model(&models.RefundRequest{}).Where("status = ?", models.RefundRequestStatusPending).
+		Count(&refundRequests).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.Order{}).Where("status = ?", models.OrderStatusRefundPending).
+		Count(&refundPendingOrders).Error; err != nil {
+		return nil, err
+	}
+	overview.PendingRefunds = refundRequests + refundPendingOrders
+
+	if err := db.Model(&models.Ticket{}).
+		Where("status IN ?", []models.TicketStatus{models.TicketStatusOpen, models.TicketStatusProcessing}).
+		Count(&overview.OpenTickets).Error; err != nil {
+		return nil, err
+	}
+
+	since := now.Add(-adminOverviewJobFailureWindow)
+	for _, source := range []struct {
+		name       string
+		model      interface{}
+		timeColumn string
+		status     interface{}
+	}{
+		{"serial_generation", &models.SerialGenerationTask{}, "updated_at", models.SerialGenerationStatusFailed},
+		{"marketing_batch", &models.MarketingBatch{}, "updated_at", models.MarketingBatchStatusFailed},
+		{"stock_reconciliation", &models.StockReconciliationRun{}, "started_at", models.StockReconciliationStatusFailed},
+		{"accounting_export", &models.AccountingExportRun{}, "started_at", models.AccountingExportStatusFailed},
+	} {
+		var count int64
+		failed := func() *gorm.DB {
+			return db.Model(source.model).Where("status = ? AND "+source.timeColumn+" >= ?", source.status, since)
+		}
+		if err := failed().Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			continue
+		}
+		var lastFailedAt []time.Time
+		if err := failed().Order(source.timeColumn+" DESC").Limit(1).
+			Pluck(source.timeColumn, &lastFailedAt).Error; err != nil {
+			return nil, err
+		}
+		job := AdminOverviewJobFailure{Name: source.name, Count: count}
+		if len(lastFailedAt) > 0 {
+			job.LastFailedAt = lastFailedAt[0]
+		}
+		overview.FailingJobs = append(overview.FailingJobs, job)
+	}
+
+	// 进程内捕获到 panic 并自动重启的常驻任务
+	for _, failure := range RecentBackgroundServiceFailures(since) {
+		overview.FailingJobs = append(overview.FailingJobs, AdminOverviewJobFailure{
+			Name:         failure.Name,
+			Count:        int64(failure.Count),
+			LastFailedAt: failure.LastFailAt,
+		})
+	}
+	for _, job := range overview.FailingJobs {
+		overview.FailingJobsTotal += job.Count
+	}
+	return overview, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openAdminOverviewTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:admin-overview-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.Order{},
+		&models.RefundRequest{},
+		&models.Ticket{},
+		&models.SerialGenerationTask{},
+		&models.MarketingBatch{},
+		&models.StockReconciliationRun{},
+		&models.AccountingExportRun{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestBuildAdminOverview(t *testing.T) {
+	db := openAdminOverviewTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.Currency = "USD"
+
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	orders := []models.Order{
+		{OrderNo: "ORD-OV-1", Status: models.OrderStatusPending, TotalAmount: 1200, CreatedAt: now.Add(-time.Hour)},
+		{OrderNo: "ORD-OV-2", Status: models.OrderStatusPendingPayment, TotalAmount: 900, CreatedAt: now.Add(-2 * time.Hour)},
+		{OrderNo: "ORD-OV-3", Status: models.OrderStatusShipped, TotalAmount: 500, CreatedAt: yesterday},
+		{OrderNo: "ORD-OV-4", Status: models.OrderStatusRefundPending, TotalAmount: 300, CreatedAt: yesterday},
+	}
+	for i := range orders {
+		orders[i].Items = []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}}
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+	}
+	if err := db.Create(&models.RefundRequest{OrderID: orders[2].ID, Status: models.RefundRequestStatusPending}).Error; err != nil {
+		t.Fatalf("create refund request failed: %v", err)
+	}
+	for _, ticket := range []models.Ticket{
+		{TicketNo: "TK-OV-1", Status: models.TicketStatusOpen},
+		{TicketNo: "TK-OV-2", Status: models.TicketStatusProcessing},
+		{TicketNo: "TK-OV-3", Status: models.TicketStatusClosed},
+	} {
+		ticket := ticket
+		if err := db.Create(&ticket).Error; err != nil {
+			t.Fatalf("create ticket failed: %v", err)
+		}
+	}
+	failedAt := now.Add(-3 * time.Hour)
+	runs := []models.StockReconciliationRun{
+		{TriggerType: "schedule", Status: models.StockReconciliationStatusFailed, StartedAt: failedAt},
+		{TriggerType: "schedule", Status: models.StockReconciliationStatusFailed, StartedAt: now.Add(-48 * time.Hour)},
+		{TriggerType: "schedule", Status: models.StockReconciliationStatusCompleted, StartedAt: now.Add(-time.Hour)},
+	}
+	for i := range runs {
+		if err := db.Create(&runs[i]).Error; err != nil {
+			t.Fatalf("create reconciliation run failed: %v", err)
+		}
+	}
+	recordBackgroundServicePanic("admin_overview_test.loop", "boom")
+
+	overview, err := BuildAdminOverview(db, cfg, now)
+	if err != nil {
+		t.Fatalf("BuildAdminOverview failed: %v", err)
+	}
+	if overview.Currency != "USD" || overview.OrdersToday != 2 || overview.OrdersToShip != 1 {
+		t.Fatalf("unexpected order counters: %+v", overview)
+	}
+	if overview.RevenueTodayMinor != 1200 {
+		t.Fatalf("expected revenue today 1200, got %d", overview.RevenueTodayMinor)
+	}
+	if overview.PendingRefunds != 2 {
+		t.Fatalf("expected 2 pending refunds, got %d", overview.PendingRefunds)
+	}
+	if overview.OpenTickets != 2 {
+		t.Fatalf("expected 2 open tickets, got %d", overview.OpenTickets)
+	}
+
+	jobs := make(map[string]AdminOverviewJobFailure)
+	for _, job := range overview.FailingJobs {
+		jobs[job.Name] = job
+	}
+	reconciliation, ok := jobs["stock_reconciliation"]
+	if !ok || reconciliation.Count != 1 || !reconciliation.LastFailedAt.Equal(failedAt) {
+		t.Fatalf("unexpected stock reconciliation failure: %+v", overview.FailingJobs)
+	}
+	if job, ok := jobs["admin_overview_test.loop"]; !ok || job.Count != 1 {
+		t.Fatalf("expected recorded panic in failing jobs: %+v", overview.FailingJobs)
+	}
+	if overview.FailingJobsTotal < 2 {
+		t.Fatalf("expected failing jobs total >= 2, got %d", overview.FailingJobsTotal)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// AnalyticsSessionIdleTimeout 同一访客超过该时长无活动即开始新会话
+	AnalyticsSessionIdleTimeout = 30 * time.Minute
+	maxAnalyticsPathLength      = 255
+	maxAnalyticsRefererLength   = 500
+)
+
+// analyticsBotMarkers User-Agent 中包含这些片段的请求视为爬虫，不计入统计
+var analyticsBotMarkers = []string{"bot", "spider", "crawl", "slurp", "headless", "lighthouse"}
+
+// AnalyticsEventInput 前端上报的单个事件及请求来源
+type AnalyticsEventInput struct {
+	Type      string
+	Path      string
+	Referer   string
+	ProductID *uint
+	Quantity  int
+	Step      string
+	IP        string
+	UserAgent string
+}
+
+// AnalyticsFunnelStage 漏斗中的一个阶段
+type AnalyticsFunnelStage struct {
+	Stage            string  `json:"stage"`
+	Sessions         int64   `json:"sessions"`
+	RateFromStart    float64 `json:"rate_from_start"`    // 相对第一阶段的转化率（%）
+	RateFromPrevious float64 `json:"rate_from_previous"` // 相对上一阶段的转化率（%）
+}
+
+// AnalyticsFunnel 一段时间内的转化漏斗
+type AnalyticsFunnel struct {
+	Sessions    int64                  `json:"sessions"`
+	PageViews   int64                  `json:"page_views"`
+	Stages      []AnalyticsFunnelStage `json:"stages"`
+	EntryPages  []AnalyticsEntryPage   `json:"entry_pages"`
+	DailyTrend  []AnalyticsFunnelDay   `json:"daily_trend"`
+	PeriodStart time.Time              `json:"period_start"`
+	PeriodEnd   time.Time              `json:"period_end"`
+}
+
+// AnalyticsEntryPage 会话入口页面
+type AnalyticsEntryPage struct {
+	Path     string `json:"path"`
+	Sessions int64  `json:"sessions"`
+}
+
+// AnalyticsFunnelDay 每日会话与下单会话数
+type AnalyticsFunnelDay struct {
+	Date     string `json:"date"`
+	Sessions int64  `json:"sessions"`
+	Placed   int64  `json:"placed"`
+}
+
+// AnalyticsEventService 第一方行为事件采集：不写 Cookie，由服务端划分会话，
+// 广告拦截插件屏蔽第三方统计时仍能得到转化漏斗
+type AnalyticsEventService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewAnalyticsEventService(db *gorm.DB, cfg *config.Config) *AnalyticsEventService {
+	return &AnalyticsEventService{db: db, cfg: cfg}
+}
+
+// IsBotUserAgent 是否为爬虫或空 User-Agent
+func IsBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range analyticsBotMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// visitorHash 以每日轮换的盐对 IP + User-Agent 做哈希，盐由 JWT 密钥与日期派生，无需存储
+func (s *AnalyticsEventService) visitorHash(ip, userAgent string, now time.Time) string {
+	secret := ""
+	if s.cfg != nil {
+		secret = s.cfg.JWT.Secret
+	}
+	mac := hmac.New(sha256.New, []byte("analytics:"+secret+":"+now.UTC().Format("2006-01-02")))
+	mac.Write([]byte(ip))
+	mac.Write([]byte{0})
+	mac.Write([]byte(userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeAnalyticsPath 只保留路径部分，去掉查询参数与锚点，避免记录令牌等敏感信息
+func normalizeAnalyticsPath(raw string) string {
+	path := strings.TrimSpace(raw)
+	if idx := strings.IndexAny(path, "?#"); idx >= 0 {
+		path = path[:idx]
+	}
+	if path == "" || !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > maxAnalyticsPathLength {
+		path = path[:maxAnalyticsPathLength]
+	}
+	return path
+}
+
+// normalizeAnalyticsReferer 只保留来源站点的 scheme + host
+func normalizeAnalyticsReferer(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	referer := parsed.Scheme + "://" + parsed.Host
+	if len(referer) > maxAnalyticsRefererLength {
+		referer = referer[:maxAnalyticsRefererLength]
+	}
+	return referer
+}
+
+// checkoutStepRank 结账步骤在漏斗中的序号（从 1 开始），未知步骤返回 0
+func checkoutStepRank(step string) int {
+	for i, item := range models.CheckoutSteps {
+		if item == step {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Record 记录一个事件：同一访客在空闲超时内的事件归入同一会话，否则开启新会话。
+// 爬虫请求直接忽略，返回 false
+func (s *AnalyticsEventService) Record(input AnalyticsEventInput) (bool, error) {
+	if IsBotUserAgent(input.UserAgent) {
+		return false, nil
+	}
+	now := models.NowFunc()
+	hash := s.visitorHash(input.IP, input.UserAgent, now)
+	path := normalizeAnalyticsPath(input.Path)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var session models.AnalyticsSession
+		if err := tx.Where("visitor_hash = ? AND last_seen_at >= ?", hash, now.Add(-AnalyticsSessionIdleTimeout)).
+			Order("last_seen_at DESC").
+			Limit(1).
+			Find(&session).Error; err != nil {
+			return err
+		}
+		if session.ID == 0 {
+			session = models.AnalyticsSession{
+				VisitorHash: hash,
+				EntryPath:   path,
+				Referer:     normalizeAnalyticsReferer(input.Referer),
+				StartedAt:   now,
+				LastSeenAt:  now,
+			}
+			if err := tx.Create(&session).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"last_seen_at": now,
+			"event_count":  gorm.Expr("event_count + 1"),
+		}
+		switch input.Type {
+		case models.AnalyticsEventAddToCart:
+			updates["added_to_cart"] = true
+		case models.AnalyticsEventCheckoutStep:
+			if rank := checkoutStepRank(input.Step); rank > session.CheckoutStepMax {
+				updates["checkout_step_max"] = rank
+			}
+		}
+		if err := tx.Model(&models.AnalyticsSession{}).Where("id = ?", session.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		event := models.AnalyticsEvent{
+			SessionID: session.ID,
+			Type:      input.Type,
+			Path:      path,
+			CreatedAt: now,
+		}
+		switch input.Type {
+		case models.AnalyticsEventAddToCart:
+			event.ProductID = input.ProductID
+			event.Quantity = input.Quantity
+		case models.AnalyticsEventCheckoutStep:
+			event.Step = input.Step
+		}
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// funnelRate 转化率百分比，保留两位小数
+func funnelRate(count, base int64) float64 {
+	if base <= 0 {
+		return 0
+	}
+	return float64(count*10000/base) / 100
+}
+
+// Funnel 统计 [start, end) 内开始的会话的转化漏斗：
+// 访问 → 加入购物车 → 打开购物车 → 提交订单 → 下单成功 → 选择付款方式。
+// 每个阶段统计到达该阶段或更后阶段的会话数
+func (s *AnalyticsEventService) Funnel(start, end time.Time) (*AnalyticsFunnel, error) {
+	result := &AnalyticsFunnel{
+		Stages:      []AnalyticsFunnelStage{},
+		EntryPages:  []AnalyticsEntryPage{},
+		DailyTrend:  []AnalyticsFunnelDay{},
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+	sessions := func() *gorm.DB {
+		return s.db.Model(&models.AnalyticsSession{}).Where("started_at >= ? AND started_at < ?", start, end)
+	}
+
+	if err := sessions().Count(&result.Sessions).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.AnalyticsEvent{}).
+		Where("type = ? AND created_at >= ? AND created_at < ?", models.AnalyticsEventPageView, start, end).
+		Count(&result.PageViews).Error; err != nil {
+		return nil, err
+	}
+
+	type funnelQuery struct {
+		stage string
+		query *gorm.DB
+	}
+	// 已进入结账的会话也计入加购阶段（商品可能在之前的会话中加入购物车），保证漏斗单调
+	counts := []funnelQuery{
+		{"visit", sessions()},
+		{"add_to_cart", sessions().Where("added_to_cart = ? OR checkout_step_max > 0", true)},
+	}
+	for i, step := range models.CheckoutSteps {
+		counts = append(counts, funnelQuery{"checkout_" + step, sessions().Where("checkout_step_max >= ?", i+1)})
+	}
+	var first, previous int64
+	for i, item := range counts {
+		var count int64
+		if err := item.query.Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			first, previous = count, count
+		}
+		result.Stages = append(result.Stages, AnalyticsFunnelStage{
+			Stage:            item.stage,
+			Sessions:         count,
+			RateFromStart:    funnelRate(count, first),
+			RateFromPrevious: funnelRate(count, previous),
+		})
+		previous = count
+	}
+
+	if err := sessions().
+		Select("entry_path AS path, COUNT(*) AS sessions").
+		Group("entry_path").
+		Order("sessions DESC").
+		Limit(10).
+		Scan(&result.EntryPages).Error; err != nil {
+		return nil, err
+	}
+
+	var days []AnalyticsFunnelDay
+	if err := sessions().
+		Select("DATE(started_at) AS date, COUNT(*) AS sessions, "+
+			"SUM(CASE WHEN checkout_step_max >= ? THEN 1 ELSE 0 END) AS placed", checkoutStepRank(models.CheckoutStepPlaced)).
+		Group("DATE(started_at)").
+		Scan(&days).Error; err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]AnalyticsFunnelDay, len(days))
+	for _, day := range days {
+		if len(day.Date) > 10 {
+			day.Date = day.Date[:10]
+		}
+		byDate[day.Date] = day
+	}
+	// 补齐没有会话的日期，方便前端直接绘制趋势图
+	for cursor := start; cursor.Before(end); cursor = cursor.AddDate(0, 0, 1) {
+		date := cursor.Format("2006-01-02")
+		if day, ok := byDate[date]; ok {
+			result.DailyTrend = append(result.DailyTrend, day)
+			continue
+		}
+		result.DailyTrend = append(result.DailyTrend, AnalyticsFunnelDay{Date: date})
+	}
+	return result, nil
+}
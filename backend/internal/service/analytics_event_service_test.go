@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestAnalyticsEventSessionizationAndFunnel(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.AnalyticsSession{}, &models.AnalyticsEvent{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	svc := NewAnalyticsEventService(db, &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}})
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	originalNow := models.NowFunc
+	models.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	const browser = "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"
+	record := func(ip string, input AnalyticsEventInput) {
+		t.Helper()
+		input.IP = ip
+		if input.UserAgent == "" {
+			input.UserAgent = browser
+		}
+		if _, err := svc.Record(input); err != nil {
+			t.Fatalf("record %+v: %v", input, err)
+		}
+	}
+
+	// 访客 A：浏览 → 加购 → 打开购物车 → 下单
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventPageView, Path: "/products/1?token=secret", Referer: "https://search.example.com/q?x=1"})
+	now = now.Add(5 * time.Minute)
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventAddToCart, Path: "/products/1", Quantity: 2})
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventCheckoutStep, Path: "/cart", Step: models.CheckoutStepCart})
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventCheckoutStep, Path: "/cart", Step: models.CheckoutStepPlaced})
+	// 较早的步骤不会降低已到达的最远步骤
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventCheckoutStep, Path: "/cart", Step: models.CheckoutStepCart})
+
+	// 访客 B：只浏览
+	record("10.0.0.2", AnalyticsEventInput{Type: models.AnalyticsEventPageView, Path: "/"})
+
+	// 爬虫不计入
+	recorded, err := svc.Record(AnalyticsEventInput{Type: models.AnalyticsEventPageView, Path: "/", IP: "10.0.0.3", UserAgent: "Googlebot/2.1"})
+	if err != nil || recorded {
+		t.Fatalf("expected bot to be ignored, recorded=%v err=%v", recorded, err)
+	}
+
+	// 访客 A 空闲超过 30 分钟后开启新会话
+	now = now.Add(AnalyticsSessionIdleTimeout + time.Minute)
+	record("10.0.0.1", AnalyticsEventInput{Type: models.AnalyticsEventPageView, Path: "/orders"})
+
+	var sessions []models.AnalyticsSession
+	if err := db.Order("id").Find(&sessions).Error; err != nil {
+		t.Fatalf("load sessions: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(sessions))
+	}
+	first := sessions[0]
+	if first.EntryPath != "/products/1" || first.Referer != "https://search.example.com" {
+		t.Fatalf("expected stripped entry path and referer, got %q %q", first.EntryPath, first.Referer)
+	}
+	if first.EventCount != 5 || !first.AddedToCart || first.CheckoutStepMax != checkoutStepRank(models.CheckoutStepPlaced) {
+		t.Fatalf("unexpected first session: %+v", first)
+	}
+
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	funnel, err := svc.Funnel(start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("funnel: %v", err)
+	}
+	if funnel.Sessions != 3 || funnel.PageViews != 3 {
+		t.Fatalf("unexpected totals: sessions=%d page_views=%d", funnel.Sessions, funnel.PageViews)
+	}
+	want := map[string]int64{
+		"visit":            3,
+		"add_to_cart":      1,
+		"checkout_cart":    1,
+		"checkout_submit":  1,
+		"checkout_placed":  1,
+		"checkout_payment": 0,
+	}
+	if len(funnel.Stages) != len(want) {
+		t.Fatalf("expected %d stages, got %+v", len(want), funnel.Stages)
+	}
+	for _, stage := range funnel.Stages {
+		if stage.Sessions != want[stage.Stage] {
+			t.Fatalf("stage %s: expected %d sessions, got %d", stage.Stage, want[stage.Stage], stage.Sessions)
+		}
+	}
+	if funnel.Stages[1].RateFromStart != 33.33 {
+		t.Fatalf("expected add-to-cart rate 33.33, got %v", funnel.Stages[1].RateFromStart)
+	}
+	if len(funnel.DailyTrend) != 1 || funnel.DailyTrend[0].Sessions != 3 || funnel.DailyTrend[0].Placed != 1 {
+		t.Fatalf("unexpected daily trend: %+v", funnel.DailyTrend)
+	}
+}
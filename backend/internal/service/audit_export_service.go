@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// auditExportSettleDelay 只归档写入超过该时长的日志，避免并发事务中较小 ID 晚提交而被跳过
+	auditExportSettleDelay = 5 * time.Second
+	// auditExportMaxBatchesPerRun 单次任务最多推送的批次数，积压时分多个周期追平
+	auditExportMaxBatchesPerRun = 20
+)
+
+// AuditExportGenesisHash 哈希链起点，每个推送目标的第一条记录以此作为 prev_hash
+var AuditExportGenesisHash = strings.Repeat("0", 64)
+
+// AuditExportRecord 归档的操作日志
+type AuditExportRecord struct {
+	ID           uint                   `json:"id"`
+	UserID       *uint                  `json:"user_id"`
+	OperatorName string                 `json:"operator_name"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   *uint                  `json:"resource_id"`
+	Details      map[string]interface{} `json:"details"`
+	IPAddress    string                 `json:"ip_address"`
+	UserAgent    string                 `json:"user_agent"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// auditExportLine 归档文件中的一行（NDJSON），hash = sha256(prev_hash + "\n" + record 原始 JSON)
+type auditExportLine struct {
+	Seq      uint            `json:"seq"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+	Record   json.RawMessage `json:"record"`
+}
+
+// auditExportBatch 一次推送的日志批次
+type auditExportBatch struct {
+	FirstID  uint
+	LastID   uint
+	Count    int
+	PrevHash string
+	HeadHash string
+	Body     []byte
+}
+
+// auditExportSink 只可追加的外部存储
+type auditExportSink interface {
+	Put(ctx context.Context, batch auditExportBatch) error
+}
+
+// AuditExportService 将操作日志按哈希链串联后推送到外部只可追加存储（S3 对象锁定 / 外部日志服务）。
+// 归档内容在外部不可修改，事后篡改数据库中的日志可通过与归档记录比对发现，删除或插入则会使哈希链断裂
+type AuditExportService struct {
+	db      *gorm.DB
+	cfg     *config.Config
+	newSink func(cfg config.AuditExportConfig) (auditExportSink, error)
+}
+
+// NewAuditExportService 创建操作日志归档服务
+func NewAuditExportService(db *gorm.DB, cfg *config.Config) *AuditExportService {
+	s := &AuditExportService{db: db, cfg: cfg}
+	s.newSink = newAuditExportSink
+	return s
+}
+
+func (s *AuditExportService) exportConfig() config.AuditExportConfig {
+	if s.cfg == nil {
+		return config.AuditExportConfig{}
+	}
+	return s.cfg.Log.AuditExport
+}
+
+// RegisterJobs 启用归档时注册推送任务，开关和推送目标在配置文件中修改后需重启生效
+func (s *AuditExportService) RegisterJobs(scheduler *JobScheduler) {
+	cfg := s.exportConfig()
+	if !cfg.Enabled {
+		return
+	}
+	scheduler.Register(JobDefinition{
+		Name:        "operation_log_export",
+		Description: fmt.Sprintf("Ship operation logs to the %s append-only archive with hash chaining", cfg.Sink),
+		Interval:    time.Duration(cfg.IntervalSeconds) * time.Second,
+		Run:         s.ExportPending,
+	})
+}
+
+// ExportPending 推送尚未归档的操作日志，直到追平或达到单次批次上限
+func (s *AuditExportService) ExportPending(ctx context.Context) error {
+	cfg := s.exportConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+	sink, err := s.newSink(cfg)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < auditExportMaxBatchesPerRun; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		exported, err := s.exportBatch(ctx, sink, cfg)
+		if err != nil {
+			return err
+		}
+		if exported < cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *AuditExportService) exportBatch(ctx context.Context, sink auditExportSink, cfg config.AuditExportConfig) (int, error) {
+	state := models.AuditExportState{Sink: cfg.Sink}
+	if err := s.db.Where("sink = ?", cfg.Sink).FirstOrCreate(&state).Error; err != nil {
+		return 0, fmt.Errorf("load audit export state: %w", err)
+	}
+	prevHash := state.LastHash
+	if prevHash == "" {
+		prevHash = AuditExportGenesisHash
+	}
+
+	var logs []models.OperationLog
+	if err := s.db.Where("id > ? AND created_at <= ?", state.LastLogID, time.Now().Add(-auditExportSettleDelay)).
+		Order("id ASC").
+		Limit(cfg.BatchSize).
+		Find(&logs).Error; err != nil {
+		return 0, fmt.Errorf("load operation logs: %w", err)
+	}
+	if len(logs) == 0 {
+		return 0, nil
+	}
+
+	batch, err := buildAuditExportBatch(logs, prevHash)
+	if err != nil {
+		return 0, err
+	}
+	if err := sink.Put(ctx, batch); err != nil {
+		return 0, fmt.Errorf("ship operation logs %d-%d: %w", batch.FirstID, batch.LastID, err)
+	}
+
+	// 以上次进度为条件更新，防止并发推送时链头被覆盖
+	now := time.Now()
+	result := s.db.Model(&models.AuditExportState{}).
+		Where("sink = ? AND last_log_id = ?", cfg.Sink, state.LastLogID).
+		Updates(map[string]interface{}{
+			"last_log_id":      batch.LastID,
+			"last_hash":        batch.HeadHash,
+			"exported_count":   gorm.Expr("exported_count + ?", batch.Count),
+			"last_exported_at": now,
+			"updated_at":       now,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("save audit export state: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, errors.New("audit export state was advanced concurrently")
+	}
+	return batch.Count, nil
+}
+
+// buildAuditExportBatch 将日志序列化为 NDJSON 并串联哈希链
+func buildAuditExportBatch(logs []models.OperationLog, prevHash string) (auditExportBatch, error) {
+	batch := auditExportBatch{
+		FirstID:  logs[0].ID,
+		LastID:   logs[len(logs)-1].ID,
+		Count:    len(logs),
+		PrevHash: prevHash,
+	}
+	var body bytes.Buffer
+	hash := prevHash
+	for _, item := range logs {
+		record, err := json.Marshal(AuditExportRecord{
+			ID:           item.ID,
+			UserID:       item.UserID,
+			OperatorName: item.OperatorName,
+			Action:       item.Action,
+			ResourceType: item.ResourceType,
+			ResourceID:   item.ResourceID,
+			Details:      item.Details,
+			IPAddress:    item.IPAddress,
+			UserAgent:    item.UserAgent,
+			CreatedAt:    item.CreatedAt.UTC(),
+		})
+		if err != nil {
+			return auditExportBatch{}, fmt.Errorf("encode operation log %d: %w", item.ID, err)
+		}
+		line := auditExportLine{Seq: item.ID, PrevHash: hash, Record: record}
+		line.Hash = auditExportChainHash(hash, record)
+		encoded, err := json.Marshal(line)
+		if err != nil {
+			return auditExportBatch{}, fmt.Errorf("encode operation log %d: %w", item.ID, err)
+		}
+		body.Write(encoded)
+		body.WriteByte('\n')
+		hash = line.Hash
+	}
+	batch.HeadHash = hash
+	batch.Body = body.Bytes()
+	return batch, nil
+}
+
+func auditExportChainHash(prevHash string, record []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(prevHash))
+	sum.Write([]byte{'\n'})
+	sum.Write(record)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// VerifyAuditExportChain 校验一份归档内容的哈希链，prevHash 为上一份归档的链头（首份为 AuditExportGenesisHash），
+// 返回本份归档的链头和记录，用于按顺序逐份校验并与数据库中的日志比对
+func VerifyAuditExportChain(r io.Reader, prevHash string) (string, []AuditExportRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var records []AuditExportRecord
+	hash := prevHash
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var line auditExportLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if line.PrevHash != hash {
+			return "", nil, fmt.Errorf("line %d (seq %d): chain broken, prev_hash %s does not match %s", lineNo, line.Seq, line.PrevHash, hash)
+		}
+		if expected := auditExportChainHash(line.PrevHash, line.Record); line.Hash != expected {
+			return "", nil, fmt.Errorf("line %d (seq %d): record hash mismatch", lineNo, line.Seq)
+		}
+		var record AuditExportRecord
+		if err := json.Unmarshal(line.Record, &record); err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if record.ID != line.Seq {
+			return "", nil, fmt.Errorf("line %d: seq %d does not match record id %d", lineNo, line.Seq, record.ID)
+		}
+		records = append(records, record)
+		hash = line.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	return hash, records, nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+const (
+	auditExportS3Timeout         = 30 * time.Second
+	auditExportResponseBodyLimit = 4096
+	auditExportContentType       = "application/x-ndjson"
+)
+
+func newAuditExportSink(cfg config.AuditExportConfig) (auditExportSink, error) {
+	switch cfg.Sink {
+	case "s3":
+		return newAuditExportS3Sink(cfg.S3)
+	case "http":
+		return &auditExportHTTPSink{
+			url:     strings.TrimSpace(cfg.HTTP.URL),
+			headers: cfg.HTTP.Headers,
+			client:  &http.Client{Timeout: time.Duration(cfg.HTTP.TimeoutMs) * time.Millisecond},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported audit export sink: %q", cfg.Sink)
+	}
+}
+
+// auditExportHTTPSink 以 NDJSON POST 到外部日志服务，链信息放在请求头中供服务端校验连续性
+type auditExportHTTPSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (s *auditExportHTTPSink) Put(ctx context.Context, batch auditExportBatch) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(batch.Body))
+	if err != nil {
+		return err
+	}
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", auditExportContentType)
+	setAuditExportChainHeaders(req.Header, batch)
+	return doAuditExportRequest(s.client, req)
+}
+
+// auditExportS3Sink 每批写入一个对象，依赖存储桶对象锁定保证保留期内不可覆盖或删除
+type auditExportS3Sink struct {
+	endpoint       *url.URL
+	region         string
+	bucket         string
+	prefix         string
+	accessKeyID    string
+	secretKey      string
+	pathStyle      bool
+	objectLockMode string
+	retention      time.Duration
+	client         *http.Client
+}
+
+func newAuditExportS3Sink(cfg config.AuditExportS3Config) (*auditExportS3Sink, error) {
+	region := strings.TrimSpace(cfg.Region)
+	rawEndpoint := strings.TrimSpace(cfg.Endpoint)
+	if rawEndpoint == "" {
+		rawEndpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	endpoint, err := url.Parse(strings.TrimRight(rawEndpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid audit export s3 endpoint %q", rawEndpoint)
+	}
+	prefix := strings.Trim(strings.TrimSpace(cfg.Prefix), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &auditExportS3Sink{
+		endpoint:       endpoint,
+		region:         region,
+		bucket:         strings.TrimSpace(cfg.Bucket),
+		prefix:         prefix,
+		accessKeyID:    strings.TrimSpace(cfg.AccessKeyID),
+		secretKey:      strings.TrimSpace(cfg.SecretAccessKey),
+		pathStyle:      cfg.PathStyle,
+		objectLockMode: cfg.ObjectLockMode,
+		retention:      time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		client:         &http.Client{Timeout: auditExportS3Timeout},
+	}, nil
+}
+
+// objectKey 按日志 ID 区间命名，零填充保证按名称排序即为链顺序
+func (s *auditExportS3Sink) objectKey(batch auditExportBatch) string {
+	return fmt.Sprintf("%soperation-logs/%020d-%020d.ndjson", s.prefix, batch.FirstID, batch.LastID)
+}
+
+func (s *auditExportS3Sink) Put(ctx context.Context, batch auditExportBatch) error {
+	target := *s.endpoint
+	key := s.objectKey(batch)
+	if s.pathStyle {
+		target.Path = target.Path + "/" + s.bucket + "/" + key
+	} else {
+		target.Host = s.bucket + "." + target.Host
+		target.Path = target.Path + "/" + key
+	}
+	target.RawPath = awsURIEncodePath(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(batch.Body))
+	if err != nil {
+		return err
+	}
+	md5Sum := md5.Sum(batch.Body)
+	req.Header.Set("Content-Type", auditExportContentType)
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+	// 对象已存在时拒绝覆盖（上次推送成功但进度未保存）
+	req.Header.Set("If-None-Match", "*")
+	req.Header.Set("X-Amz-Object-Lock-Mode", s.objectLockMode)
+	req.Header.Set("X-Amz-Object-Lock-Retain-Until-Date", time.Now().UTC().Add(s.retention).Format(time.RFC3339))
+	setAuditExportChainMetadata(req.Header, batch)
+	s.sign(req, batch.Body, time.Now().UTC())
+
+	err = doAuditExportRequest(s.client, req)
+	var statusErr *auditExportStatusError
+	if errors.As(err, &statusErr) && statusErr.status == http.StatusPreconditionFailed {
+		// 同一区间的对象已写入，内容由同一批日志确定，视为成功以推进进度
+		return nil
+	}
+	return err
+}
+
+// sign AWS Signature Version 4
+func (s *auditExportS3Sink) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := auditExportHMAC([]byte("AWS4"+s.secretKey), date)
+	signingKey = auditExportHMAC(signingKey, s.region)
+	signingKey = auditExportHMAC(signingKey, "s3")
+	signingKey = auditExportHMAC(signingKey, "aws4_request")
+	signature := hex.EncodeToString(auditExportHMAC(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func auditExportHMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsURIEncodePath 按 SigV4 规则编码路径：除非保留字符和 / 外全部百分号编码
+func awsURIEncodePath(path string) string {
+	var builder strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			builder.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&builder, "%%%02X", c)
+	}
+	return builder.String()
+}
+
+func setAuditExportChainHeaders(header http.Header, batch auditExportBatch) {
+	header.Set("X-Audit-First-Id", fmt.Sprint(batch.FirstID))
+	header.Set("X-Audit-Last-Id", fmt.Sprint(batch.LastID))
+	header.Set("X-Audit-Prev-Hash", batch.PrevHash)
+	header.Set("X-Audit-Head-Hash", batch.HeadHash)
+}
+
+// setAuditExportChainMetadata 链信息同时写入对象元数据，便于不下载内容即可核对连续性
+func setAuditExportChainMetadata(header http.Header, batch auditExportBatch) {
+	header.Set("X-Amz-Meta-First-Id", fmt.Sprint(batch.FirstID))
+	header.Set("X-Amz-Meta-Last-Id", fmt.Sprint(batch.LastID))
+	header.Set("X-Amz-Meta-Prev-Hash", batch.PrevHash)
+	header.Set("X-Amz-Meta-Head-Hash", batch.HeadHash)
+}
+
+type auditExportStatusError struct {
+	status int
+	body   string
+}
+
+func (e *auditExportStatusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("archive responded with HTTP %d", e.status)
+	}
+	return fmt.Sprintf("archive responded with HTTP %d: %s", e.status, e.body)
+}
+
+func doAuditExportRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, auditExportResponseBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &auditExportStatusError{status: resp.StatusCode, body: strings.TrimSpace(string(body))}
+	}
+	return nil
+}
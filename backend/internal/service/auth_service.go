@@ -1,10 +1,11 @@
 package service
 
 import (
+	"context"
 	crand "crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
+	"log"
 	"strings"
 	"time"
 
@@ -20,8 +21,11 @@ import (
 )
 
 type AuthService struct {
-	userRepo *repository.UserRepository
-	cfg      *config.Config
+	userRepo  *repository.UserRepository
+	cfg       *config.Config
+	otp       *OTPService
+	blocklist *BlocklistService
+	emailRisk *EmailValidationService
 }
 
 var (
@@ -33,6 +37,49 @@ func NewAuthService(userRepo *repository.UserRepository, cfg *config.Config) *Au
 	return &AuthService{
 		userRepo: userRepo,
 		cfg:      cfg,
+		otp:      NewOTPService(cfg),
+	}
+}
+
+// OTP 返回验证码服务，供处理器做发送冷却控制
+func (s *AuthService) OTP() *OTPService {
+	return s.otp
+}
+
+// SetBlocklist 设置注册与登录时检查的黑名单
+func (s *AuthService) SetBlocklist(blocklist *BlocklistService) {
+	s.blocklist = blocklist
+}
+
+// CheckBlocklist 检查注册或登录请求的IP、邮箱与手机号是否命中黑名单
+func (s *AuthService) CheckBlocklist(scope models.BlocklistScope, ip, email, phone string) error {
+	return s.blocklist.Check(scope, BlocklistSubject{IP: ip, Email: email, Phone: phone})
+}
+
+// SetEmailValidation 设置注册与绑定邮箱时使用的邮箱域名校验
+func (s *AuthService) SetEmailValidation(emailRisk *EmailValidationService) {
+	s.emailRisk = emailRisk
+}
+
+// ScreenEmail 按邮箱校验配置处理注册或绑定邮箱请求：
+// block 模式返回错误；flag 模式返回应记录到账户上的风险原因；warn 模式只写日志
+func (s *AuthService) ScreenEmail(email string) (string, error) {
+	email = normalizeEmail(email)
+	reason := s.emailRisk.Check(context.Background(), email)
+	if reason == "" {
+		return "", nil
+	}
+	switch s.emailRisk.Mode() {
+	case EmailValidationModeBlock:
+		if reason == EmailRiskDisposable {
+			return "", authbiz.EmailDisposable()
+		}
+		return "", authbiz.EmailDomainUnreachable()
+	case EmailValidationModeFlag:
+		return reason, nil
+	default:
+		log.Printf("email validation warning: email=%s reason=%s", email, reason)
+		return "", nil
 	}
 }
 
@@ -63,9 +110,9 @@ func (s *AuthService) Login(email, pwd string) (string, *models.User, error) {
 		return "", nil, authbiz.AccountDisabled()
 	}
 
-	// 检查邮箱是否已验证（管理员跳过）
-	if !user.EmailVerified && !user.IsAdmin() && s.cfg.Security.Login.RequireEmailVerification {
-		return "", nil, authbiz.EmailNotVerified()
+	// 检查邮箱是否已验证（管理员与被豁免用户跳过；仅 login 限制方式下禁止登录）
+	if err := CheckEmailVerification(s.cfg, user, EmailVerificationModeLogin); err != nil {
+		return "", nil, err
 	}
 
 	// 生成JWT Token
@@ -309,16 +356,7 @@ func (s *AuthService) SendLoginCode(email string) (string, error) {
 		return "", authbiz.AccountDisabled()
 	}
 
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return "", fmt.Errorf("failed to generate code: %w", err)
-	}
-	code := fmt.Sprintf("%06d", n.Int64())
-
-	if err := cache.Set("email_login_code:"+email, code, 10*time.Minute); err != nil {
-		return "", fmt.Errorf("failed to store login code: %w", err)
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposeEmailLogin, email, OTPChannelEmail)
 }
 
 // GeneratePasswordResetToken 生成密码重置token并存入Redis
@@ -387,16 +425,9 @@ func (s *AuthService) LoginWithCode(email, code string) (string, *models.User, e
 	}
 
 	email = normalizeEmail(email)
-	key := "email_login_code:" + email
-
-	storedCode, err := cache.Get(key)
-	if err != nil {
-		return "", nil, authbiz.CodeExpired()
-	}
-	if storedCode != code {
-		return "", nil, authbiz.InvalidCode()
+	if err := s.otp.Verify(OTPPurposeEmailLogin, email, code); err != nil {
+		return "", nil, err
 	}
-	_ = cache.Del(key)
 
 	user, err := s.userRepo.FindByEmail(email)
 	if err != nil {
@@ -427,15 +458,7 @@ func (s *AuthService) SendPhoneLoginCode(phone string) (string, error) {
 	if !user.IsActive {
 		return "", authbiz.AccountDisabled()
 	}
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return "", fmt.Errorf("failed to generate code: %w", err)
-	}
-	code := fmt.Sprintf("%06d", n.Int64())
-	if err := cache.Set("phone_login_code:"+phone, code, 10*time.Minute); err != nil {
-		return "", fmt.Errorf("failed to store login code: %w", err)
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposePhoneLogin, phone, s.otp.PhoneChannel())
 }
 
 // LoginWithPhoneCode 使用手机验证码登录
@@ -447,15 +470,9 @@ func (s *AuthService) LoginWithPhoneCode(phone, code string) (string, *models.Us
 		return "", nil, authbiz.PhoneLoginDisabled()
 	}
 
-	key := "phone_login_code:" + phone
-	storedCode, err := cache.Get(key)
-	if err != nil {
-		return "", nil, authbiz.CodeExpired()
-	}
-	if storedCode != code {
-		return "", nil, authbiz.InvalidCode()
+	if err := s.otp.Verify(OTPPurposePhoneLogin, phone, code); err != nil {
+		return "", nil, err
 	}
-	_ = cache.Del(key)
 	user, err := s.userRepo.FindByPhone(phone)
 	if err != nil {
 		return "", nil, authbiz.UserNotFound()
@@ -482,28 +499,14 @@ func (s *AuthService) GeneratePhoneResetCode(phone string) (string, error) {
 	if !user.IsActive {
 		return "", authbiz.AccountDisabled()
 	}
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return "", fmt.Errorf("failed to generate code: %w", err)
-	}
-	code := fmt.Sprintf("%06d", n.Int64())
-	if err := cache.Set("phone_reset_code:"+phone, code, 10*time.Minute); err != nil {
-		return "", err
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposePhoneReset, phone, s.otp.PhoneChannel())
 }
 
 // ResetPasswordByPhone 使用手机验证码重置密码
 func (s *AuthService) ResetPasswordByPhone(phone, code, newPassword string) error {
-	key := "phone_reset_code:" + phone
-	storedCode, err := cache.Get(key)
-	if err != nil {
-		return authbiz.CodeExpired()
-	}
-	if storedCode != code {
-		return authbiz.InvalidCode()
+	if err := s.otp.Verify(OTPPurposePhoneReset, phone, code); err != nil {
+		return err
 	}
-	_ = cache.Del(key)
 	user, err := s.userRepo.FindByPhone(phone)
 	if err != nil {
 		return authbiz.UserNotFound()
@@ -529,15 +532,7 @@ func (s *AuthService) SendPhoneRegisterCode(phone string) (string, error) {
 	if _, err := s.userRepo.FindByPhone(phone); err == nil {
 		return "", authbiz.PhoneAlreadyInUse()
 	}
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return "", fmt.Errorf("failed to generate code: %w", err)
-	}
-	code := fmt.Sprintf("%06d", n.Int64())
-	if err := cache.Set("phone_register_code:"+phone, code, 10*time.Minute); err != nil {
-		return "", fmt.Errorf("failed to store register code: %w", err)
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposePhoneRegister, phone, s.otp.PhoneChannel())
 }
 
 // SendBindEmailCode generates a code for binding email to an existing account
@@ -546,27 +541,23 @@ func (s *AuthService) SendBindEmailCode(userID uint, email string) (string, erro
 	if _, err := s.userRepo.FindByEmail(email); err == nil {
 		return "", authbiz.EmailAlreadyInUse()
 	}
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
+	if _, err := s.ScreenEmail(email); err != nil {
 		return "", err
 	}
-	code := fmt.Sprintf("%06d", n.Int64())
-	key := fmt.Sprintf("bind_email_code:%d:%s", userID, email)
-	if err := cache.Set(key, code, 10*time.Minute); err != nil {
-		return "", err
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposeBindEmail, bindOTPSubject(userID, email), OTPChannelEmail)
+}
+
+// bindOTPSubject 绑定类验证码按 用户+目标 区分
+func bindOTPSubject(userID uint, target string) string {
+	return fmt.Sprintf("%d:%s", userID, target)
 }
 
 // BindEmail verifies code and binds email to user
 func (s *AuthService) BindEmail(userID uint, email string, code string) error {
 	email = normalizeEmail(email)
-	key := fmt.Sprintf("bind_email_code:%d:%s", userID, email)
-	stored, err := cache.Get(key)
-	if err != nil || stored != code {
-		return authbiz.CodeExpired()
+	if err := s.otp.Verify(OTPPurposeBindEmail, bindOTPSubject(userID, email), code); err != nil {
+		return err
 	}
-	_ = cache.Del(key)
 	if _, err := s.userRepo.FindByEmail(email); err == nil {
 		return authbiz.EmailAlreadyInUse()
 	}
@@ -576,6 +567,11 @@ func (s *AuthService) BindEmail(userID uint, email string, code string) error {
 	}
 	user.Email = email
 	user.EmailVerified = true
+	// 风险标记跟随当前邮箱，换绑后按新邮箱重新评估
+	user.EmailRiskFlag = ""
+	if s.emailRisk.Mode() == EmailValidationModeFlag {
+		user.EmailRiskFlag = s.emailRisk.Check(context.Background(), email)
+	}
 	return s.userRepo.Update(user)
 }
 
@@ -584,26 +580,14 @@ func (s *AuthService) SendBindPhoneCode(userID uint, phone string) (string, erro
 	if _, err := s.userRepo.FindByPhone(phone); err == nil {
 		return "", authbiz.PhoneAlreadyInUse()
 	}
-	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
-	if err != nil {
-		return "", err
-	}
-	code := fmt.Sprintf("%06d", n.Int64())
-	key := fmt.Sprintf("bind_phone_code:%d:%s", userID, phone)
-	if err := cache.Set(key, code, 10*time.Minute); err != nil {
-		return "", err
-	}
-	return code, nil
+	return s.otp.Issue(OTPPurposeBindPhone, bindOTPSubject(userID, phone), s.otp.PhoneChannel())
 }
 
 // BindPhone verifies code and binds phone to user
 func (s *AuthService) BindPhone(userID uint, phone string, code string) error {
-	key := fmt.Sprintf("bind_phone_code:%d:%s", userID, phone)
-	stored, err := cache.Get(key)
-	if err != nil || stored != code {
-		return authbiz.CodeExpired()
+	if err := s.otp.Verify(OTPPurposeBindPhone, bindOTPSubject(userID, phone), code); err != nil {
+		return err
 	}
-	_ = cache.Del(key)
 	if _, err := s.userRepo.FindByPhone(phone); err == nil {
 		return authbiz.PhoneAlreadyInUse()
 	}
@@ -617,14 +601,5 @@ func (s *AuthService) BindPhone(userID uint, phone string, code string) error {
 
 // VerifyPhoneRegisterCode 验证手机注册验证码
 func (s *AuthService) VerifyPhoneRegisterCode(phone, code string) error {
-	key := "phone_register_code:" + phone
-	storedCode, err := cache.Get(key)
-	if err != nil {
-		return authbiz.CodeExpired()
-	}
-	if storedCode != code {
-		return authbiz.InvalidCode()
-	}
-	_ = cache.Del(key)
-	return nil
+	return s.otp.Verify(OTPPurposePhoneRegister, phone, code)
 }
@@ -27,9 +27,15 @@ func openBackgroundServiceTestDB(t *testing.T) *gorm.DB {
 		&models.Order{},
 		&models.UserPurchaseStat{},
 		&models.Ticket{},
+		&models.TicketMessage{},
+		&models.TicketAgentDailyStat{},
+		&models.Product{},
+		&models.SKUSalesDailyStat{},
+		&models.User{},
 		&models.PaymentMethod{},
 		&models.OrderPaymentMethod{},
 		&models.PaymentPollingTask{},
+		&models.ScheduledJob{},
 	); err != nil {
 		t.Fatalf("auto migrate failed: %v", err)
 	}
@@ -41,7 +47,8 @@ func TestBackgroundServicesStopIsIdempotent(t *testing.T) {
 	db := openBackgroundServiceTestDB(t)
 	cfg := &config.Config{
 		Order: config.OrderConfig{
-			AutoCancelHours: 72,
+			AutoCancelHours:  72,
+			AutoCompleteDays: 10,
 		},
 		Ticket: config.TicketConfig{
 			AutoCloseHours: 48,
@@ -63,6 +70,14 @@ func TestBackgroundServicesStopIsIdempotent(t *testing.T) {
 		nil,
 	)
 	ticketAutoClose := NewTicketAutoCloseService(db, cfg)
+	jobScheduler := NewJobScheduler(db, NewLocalJobLocker())
+	orderCancel.RegisterJobs(jobScheduler)
+	ticketAutoClose.RegisterJobs(jobScheduler)
+	NewOrderAutoCompleteService(db, cfg, repository.NewPromoCodeRepository(db), nil).RegisterJobs(jobScheduler)
+	NewTicketAgentStatsService(db).RegisterJobs(jobScheduler)
+	NewSKUSalesStatsService(db).RegisterJobs(jobScheduler)
+	NewEmailVerificationReminderService(db, cfg, nil).RegisterJobs(jobScheduler)
+	NewNetTermsDunningService(db, cfg, nil).RegisterJobs(jobScheduler)
 	ticketAttachmentCleanup := NewTicketAttachmentCleanupService(db, cfg)
 	paymentPolling := NewPaymentPollingService(db, nil, nil, cfg)
 
@@ -73,8 +88,7 @@ func TestBackgroundServicesStopIsIdempotent(t *testing.T) {
 			Stop()
 		}
 	}{
-		{name: "order_cancel", service: orderCancel},
-		{name: "ticket_auto_close", service: ticketAutoClose},
+		{name: "job_scheduler", service: jobScheduler},
 		{name: "ticket_attachment_cleanup", service: ticketAttachmentCleanup},
 		{name: "payment_polling", service: paymentPolling},
 	}
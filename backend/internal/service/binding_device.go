@@ -0,0 +1,418 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	licenseDeviceIDMaxLength   = 128
+	licenseDeviceNameMaxLength = 100
+	licenseMaxActivationsLimit = 1000
+)
+
+// ErrLicenseActivationNotFound 订单下不存在该激活记录
+var ErrLicenseActivationNotFound = errors.New("license activation not found")
+
+// LicenseDevice 商户软件上报的设备信息
+type LicenseDevice struct {
+	DeviceID   string
+	DeviceName string
+	IPAddress  string
+}
+
+// LicenseStatus 返回给商户软件的授权状态
+type LicenseStatus struct {
+	Activated      bool       `json:"activated"`
+	DeviceID       string     `json:"device_id"`
+	ActivatedAt    *time.Time `json:"activated_at,omitempty"`
+	ActiveDevices  int64      `json:"active_devices"`
+	MaxActivations int        `json:"max_activations"` // 0 表示不限制
+}
+
+// ValidateMaxActivations 校验虚拟库存的设备数上限
+func ValidateMaxActivations(maxActivations int) error {
+	if maxActivations < 0 || maxActivations > licenseMaxActivationsLimit {
+		return bizerr.Newf("license.maxActivationsInvalid", "Max activations must be between 0 and %d", licenseMaxActivationsLimit).
+			WithParams(map[string]interface{}{"max": licenseMaxActivationsLimit})
+	}
+	return nil
+}
+
+func normalizeLicenseDevice(device LicenseDevice) (LicenseDevice, error) {
+	device.DeviceID = strings.TrimSpace(device.DeviceID)
+	if device.DeviceID == "" || len(device.DeviceID) > licenseDeviceIDMaxLength {
+		return device, bizerr.Newf("license.deviceIdInvalid", "Device ID must be 1-%d characters", licenseDeviceIDMaxLength).
+			WithParams(map[string]interface{}{"max": licenseDeviceIDMaxLength})
+	}
+	device.DeviceName = strings.TrimSpace(device.DeviceName)
+	if runes := []rune(device.DeviceName); len(runes) > licenseDeviceNameMaxLength {
+		device.DeviceName = string(runes[:licenseDeviceNameMaxLength])
+	}
+	return device, nil
+}
+
+// licenseHint 卡密末4位，其余以星号代替
+func licenseHint(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= 4 {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-4:])
+}
+
+// resolveLicense 按卡密查找已发货的库存项；订单取消或退款后卡密失效
+func (s *BindingService) resolveLicense(licenseKey string) (*models.VirtualProductStock, error) {
+	licenseKey = strings.TrimSpace(licenseKey)
+	notFound := bizerr.New("license.notFound", "License not found").WithStatus(http.StatusNotFound)
+	if licenseKey == "" {
+		return nil, notFound
+	}
+	stock, err := s.bindingRepo.FindSoldStockByKeyHash(models.LicenseKeyHash(licenseKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	if stock.OrderID == nil {
+		return nil, notFound
+	}
+	order, err := s.bindingRepo.FindLicenseOrder(*stock.OrderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	if order.Status == models.OrderStatusCancelled || order.Status == models.OrderStatusRefunded {
+		return nil, bizerr.New("license.revoked", "License has been revoked").WithStatus(http.StatusForbidden)
+	}
+	if order.Source == OrderSourceTrial {
+		if err := s.checkTrialLicense(order.ID); err != nil {
+			return nil, err
+		}
+	}
+	return stock, nil
+}
+
+// checkTrialLicense 试用卡密到期且转正订单未付款时失效
+func (s *BindingService) checkTrialLicense(orderID uint) error {
+	trial, err := s.bindingRepo.FindTrialByOrder(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	conversionPaid := false
+	if trial.Status == models.ProductTrialStatusActive && trial.ConversionOrderID != nil {
+		conversion, err := s.bindingRepo.FindLicenseOrder(*trial.ConversionOrderID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		conversionPaid = err == nil && conversion.PaidAt != nil
+	}
+	if !trial.LicenseUsable(models.NowFunc(), conversionPaid) {
+		return bizerr.New("license.trialExpired", "Trial license has expired").WithStatus(http.StatusForbidden)
+	}
+	return nil
+}
+
+func licenseMaxActivations(stock *models.VirtualProductStock) int {
+	if stock.VirtualInventory == nil {
+		return 0
+	}
+	return stock.VirtualInventory.MaxActivations
+}
+
+func (s *BindingService) licenseStatus(tx *gorm.DB, stock *models.VirtualProductStock, deviceID string, activation *models.LicenseActivation) (*LicenseStatus, error) {
+	count, err := s.bindingRepo.CountActiveActivations(tx, stock.ID)
+	if err != nil {
+		return nil, err
+	}
+	status := &LicenseStatus{
+		DeviceID:       deviceID,
+		ActiveDevices:  count,
+		MaxActivations: licenseMaxActivations(stock),
+	}
+	if activation != nil && activation.IsActive() {
+		status.Activated = true
+		activatedAt := activation.ActivatedAt
+		status.ActivatedAt = &activatedAt
+	}
+	return status, nil
+}
+
+func (s *BindingService) touchActivation(tx *gorm.DB, activation *models.LicenseActivation, ipAddress string) error {
+	now := models.NowFunc()
+	updates := map[string]interface{}{"last_seen_at": now}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+	if err := tx.Model(activation).Updates(updates).Error; err != nil {
+		return err
+	}
+	activation.LastSeenAt = &now
+	return nil
+}
+
+func (s *BindingService) createActivation(tx *gorm.DB, stock *models.VirtualProductStock, device LicenseDevice) (*models.LicenseActivation, error) {
+	now := models.NowFunc()
+	activation := &models.LicenseActivation{
+		StockID:            stock.ID,
+		VirtualInventoryID: stock.VirtualInventoryID,
+		OrderID:            *stock.OrderID,
+		OrderNo:            stock.OrderNo,
+		LicenseHint:        licenseHint(stock.Content),
+		DeviceID:           device.DeviceID,
+		DeviceName:         device.DeviceName,
+		Status:             models.LicenseActivationStatusActive,
+		IPAddress:          device.IPAddress,
+		ActivatedAt:        now,
+		LastSeenAt:         &now,
+	}
+	if err := tx.Create(activation).Error; err != nil {
+		return nil, err
+	}
+	return activation, nil
+}
+
+func (s *BindingService) deactivate(tx *gorm.DB, activation *models.LicenseActivation, by string, operatorID *uint, transferredToID *uint) error {
+	now := models.NowFunc()
+	updates := map[string]interface{}{
+		"status":            models.LicenseActivationStatusDeactivated,
+		"deactivated_at":    now,
+		"deactivated_by":    by,
+		"deactivated_by_id": operatorID,
+		"transferred_to_id": transferredToID,
+	}
+	if err := tx.Model(activation).Updates(updates).Error; err != nil {
+		return err
+	}
+	activation.Status = models.LicenseActivationStatusDeactivated
+	activation.DeactivatedAt = &now
+	activation.DeactivatedBy = by
+	activation.DeactivatedByID = operatorID
+	activation.TransferredToID = transferredToID
+	return nil
+}
+
+func newLicenseActivationLimitError(maxActivations int) error {
+	return bizerr.Newf("license.activationLimit", "License is already activated on %d device(s), deactivate one first", maxActivations).
+		WithParams(map[string]interface{}{"max": maxActivations}).
+		WithStatus(http.StatusConflict)
+}
+
+func newLicenseDeviceNotActivatedError() error {
+	return bizerr.New("license.deviceNotActivated", "License is not activated on this device").WithStatus(http.StatusNotFound)
+}
+
+// ActivateLicense 将卡密绑定到设备；设备已激活时仅刷新最近校验时间，超出设备数上限时拒绝
+func (s *BindingService) ActivateLicense(licenseKey string, device LicenseDevice) (*LicenseStatus, error) {
+	device, err := normalizeLicenseDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := s.resolveLicense(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *LicenseStatus
+	err = s.bindingRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bindingRepo.LockStock(tx, stock.ID); err != nil {
+			return err
+		}
+		activation, err := s.bindingRepo.FindActiveActivation(tx, stock.ID, device.DeviceID)
+		if err == nil {
+			if err := s.touchActivation(tx, activation, device.IPAddress); err != nil {
+				return err
+			}
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			maxActivations := licenseMaxActivations(stock)
+			if maxActivations > 0 {
+				count, err := s.bindingRepo.CountActiveActivations(tx, stock.ID)
+				if err != nil {
+					return err
+				}
+				if count >= int64(maxActivations) {
+					return newLicenseActivationLimitError(maxActivations)
+				}
+			}
+			if activation, err = s.createActivation(tx, stock, device); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+		status, err = s.licenseStatus(tx, stock, device.DeviceID, activation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ValidateLicense 商户软件定期校验设备是否仍处于激活状态
+func (s *BindingService) ValidateLicense(licenseKey string, device LicenseDevice) (*LicenseStatus, error) {
+	device, err := normalizeLicenseDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := s.resolveLicense(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *LicenseStatus
+	err = s.bindingRepo.WithTransaction(func(tx *gorm.DB) error {
+		activation, err := s.bindingRepo.FindActiveActivation(tx, stock.ID, device.DeviceID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			activation = nil
+		} else if err := s.touchActivation(tx, activation, device.IPAddress); err != nil {
+			return err
+		}
+		status, err = s.licenseStatus(tx, stock, device.DeviceID, activation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// DeactivateLicense 商户软件凭卡密解绑当前设备，释放一个设备名额
+func (s *BindingService) DeactivateLicense(licenseKey string, device LicenseDevice) (*LicenseStatus, error) {
+	device, err := normalizeLicenseDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := s.resolveLicense(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *LicenseStatus
+	err = s.bindingRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bindingRepo.LockStock(tx, stock.ID); err != nil {
+			return err
+		}
+		activation, err := s.bindingRepo.FindActiveActivation(tx, stock.ID, device.DeviceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return newLicenseDeviceNotActivatedError()
+			}
+			return err
+		}
+		if err := s.deactivate(tx, activation, models.LicenseDeactivatedBySoftware, nil, nil); err != nil {
+			return err
+		}
+		status, err = s.licenseStatus(tx, stock, device.DeviceID, activation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// TransferLicense 将激活从旧设备迁移到新设备，解绑与激活在同一事务中完成，不受设备数上限影响
+func (s *BindingService) TransferLicense(licenseKey, fromDeviceID string, device LicenseDevice) (*LicenseStatus, error) {
+	device, err := normalizeLicenseDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	from, err := normalizeLicenseDevice(LicenseDevice{DeviceID: fromDeviceID})
+	if err != nil {
+		return nil, err
+	}
+	if from.DeviceID == device.DeviceID {
+		return nil, bizerr.New("license.transferSameDevice", "Source and target devices must differ")
+	}
+	stock, err := s.resolveLicense(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *LicenseStatus
+	err = s.bindingRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bindingRepo.LockStock(tx, stock.ID); err != nil {
+			return err
+		}
+		previous, err := s.bindingRepo.FindActiveActivation(tx, stock.ID, from.DeviceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return newLicenseDeviceNotActivatedError()
+			}
+			return err
+		}
+		// 目标设备已激活时只解绑旧设备
+		activation, err := s.bindingRepo.FindActiveActivation(tx, stock.ID, device.DeviceID)
+		if err == nil {
+			if err := s.touchActivation(tx, activation, device.IPAddress); err != nil {
+				return err
+			}
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			if activation, err = s.createActivation(tx, stock, device); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+		if err := s.deactivate(tx, previous, models.LicenseDeactivatedByTransfer, nil, &activation.ID); err != nil {
+			return err
+		}
+		status, err = s.licenseStatus(tx, stock, device.DeviceID, activation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ListOrderActivations 订单下所有卡密的设备激活历史
+func (s *BindingService) ListOrderActivations(orderID uint) ([]models.LicenseActivation, error) {
+	return s.bindingRepo.ListActivationsByOrder(orderID)
+}
+
+// DeactivateOrderActivation 买家或管理员在订单页解绑设备，by 为 user 或 admin
+func (s *BindingService) DeactivateOrderActivation(orderID, activationID uint, by string, operatorID uint) (*models.LicenseActivation, error) {
+	var activation *models.LicenseActivation
+	err := s.bindingRepo.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		activation, err = s.bindingRepo.FindOrderActivation(tx, orderID, activationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrLicenseActivationNotFound
+			}
+			return err
+		}
+		if err := s.bindingRepo.LockStock(tx, activation.StockID); err != nil {
+			return err
+		}
+		// 加锁后重新读取，避免与软件端并发解绑重复处理
+		if err := tx.First(activation, activation.ID).Error; err != nil {
+			return err
+		}
+		if !activation.IsActive() {
+			return bizerr.New("license.alreadyDeactivated", "This device has already been deactivated")
+		}
+		return s.deactivate(tx, activation, by, &operatorID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activation, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestLicenseDeviceBindingLimitsAndTransfers(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.LicenseActivation{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	bindingService := NewBindingService(repository.NewBindingRepository(db), repository.NewInventoryRepository(db), repository.NewProductRepository(db))
+
+	order := &models.Order{OrderNo: "LIC-1", Status: models.OrderStatusCompleted}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "Pro license", Type: models.VirtualInventoryTypeStatic, IsActive: true, MaxActivations: 2}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "ABCD-EFGH-1234"}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create stock: %v", err)
+	}
+
+	// 未发货的卡密不能激活
+	_, err := bindingService.ActivateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-1"})
+	requireOrderBizErr(t, err, "license.notFound")
+
+	stock.MarkAsSold(order.ID, order.OrderNo)
+	if err := db.Save(stock).Error; err != nil {
+		t.Fatalf("mark stock sold: %v", err)
+	}
+
+	status, err := bindingService.ActivateLicense(" ABCD-EFGH-1234 ", LicenseDevice{DeviceID: "pc-1", DeviceName: "Office PC"})
+	if err != nil || !status.Activated || status.ActiveDevices != 1 || status.MaxActivations != 2 {
+		t.Fatalf("expected first activation, got %+v err=%v", status, err)
+	}
+	// 同一设备重复激活不占用新名额
+	if status, err = bindingService.ActivateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-1"}); err != nil || status.ActiveDevices != 1 {
+		t.Fatalf("expected idempotent activation, got %+v err=%v", status, err)
+	}
+	if _, err := bindingService.ActivateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-2"}); err != nil {
+		t.Fatalf("second activation failed: %v", err)
+	}
+	_, err = bindingService.ActivateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-3"})
+	requireOrderBizErr(t, err, "license.activationLimit")
+
+	// 满额时仍可迁移到新设备
+	status, err = bindingService.TransferLicense("ABCD-EFGH-1234", "pc-1", LicenseDevice{DeviceID: "pc-3"})
+	if err != nil || !status.Activated || status.ActiveDevices != 2 {
+		t.Fatalf("expected transfer to pc-3, got %+v err=%v", status, err)
+	}
+	if status, err = bindingService.ValidateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-1"}); err != nil || status.Activated {
+		t.Fatalf("expected pc-1 to be released, got %+v err=%v", status, err)
+	}
+
+	// 买家在订单页解绑后释放名额，历史记录保留
+	activations, err := bindingService.ListOrderActivations(order.ID)
+	if err != nil || len(activations) != 3 {
+		t.Fatalf("expected 3 activation records, got %d err=%v", len(activations), err)
+	}
+	var pc2 models.LicenseActivation
+	for _, activation := range activations {
+		if activation.DeviceID == "pc-1" && (activation.DeactivatedBy != models.LicenseDeactivatedByTransfer || activation.TransferredToID == nil) {
+			t.Fatalf("expected pc-1 to record the transfer, got %+v", activation)
+		}
+		if activation.DeviceID == "pc-2" {
+			pc2 = activation
+		}
+	}
+	if activation, err := bindingService.DeactivateOrderActivation(order.ID, pc2.ID, models.LicenseDeactivatedByUser, 7); err != nil || activation.IsActive() {
+		t.Fatalf("deactivate pc-2 failed: %+v err=%v", activation, err)
+	}
+	_, err = bindingService.DeactivateOrderActivation(order.ID, pc2.ID, models.LicenseDeactivatedByUser, 7)
+	requireOrderBizErr(t, err, "license.alreadyDeactivated")
+	if _, err := bindingService.DeactivateOrderActivation(order.ID+1, pc2.ID, models.LicenseDeactivatedByAdmin, 1); err != ErrLicenseActivationNotFound {
+		t.Fatalf("expected activation lookup scoped to order, got %v", err)
+	}
+	if _, err := bindingService.ActivateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-4"}); err != nil {
+		t.Fatalf("expected freed slot to be reusable: %v", err)
+	}
+
+	// 退款后卡密失效
+	if err := db.Model(order).Update("status", models.OrderStatusRefunded).Error; err != nil {
+		t.Fatalf("refund order: %v", err)
+	}
+	_, err = bindingService.ValidateLicense("ABCD-EFGH-1234", LicenseDevice{DeviceID: "pc-3"})
+	requireOrderBizErr(t, err, "license.revoked")
+}
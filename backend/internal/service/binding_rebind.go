@@ -0,0 +1,150 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+)
+
+// BindingAffectedOrder 换绑时仍占用原库存的订单项
+type BindingAffectedOrder struct {
+	OrderID   uint               `json:"order_id"`
+	OrderNo   string             `json:"order_no"`
+	Status    models.OrderStatus `json:"status"`
+	ItemIndex int                `json:"item_index"`
+	Quantity  int                `json:"quantity"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// BindingRebindImpact 换绑的影响评估（dry-run 与实际执行共用）
+type BindingRebindImpact struct {
+	BindingID        uint                   `json:"binding_id"`
+	ProductID        uint                   `json:"product_id"`
+	ProductSKU       string                 `json:"product_sku"`
+	FromInventoryID  uint                   `json:"from_inventory_id"`
+	ToInventoryID    uint                   `json:"to_inventory_id"`
+	ToInventory      *models.Inventory      `json:"to_inventory,omitempty"`
+	AffectedOrders   []BindingAffectedOrder `json:"affected_orders"`
+	AffectedQuantity int                    `json:"affected_quantity"`
+	Applied          bool                   `json:"applied"`
+}
+
+// ListBindings 跨商品查询当前的 SKU 与库存绑定关系
+func (s *BindingService) ListBindings(search string, productID, inventoryID uint, page, limit int) ([]models.ProductInventoryBinding, int64, error) {
+	return s.bindingRepo.List(search, productID, inventoryID, page, limit)
+}
+
+// PreviewRebind 评估将绑定换到另一库存会影响哪些未完成订单，不做任何修改
+func (s *BindingService) PreviewRebind(bindingID, inventoryID uint) (*BindingRebindImpact, error) {
+	binding, err := s.bindingRepo.FindByID(bindingID)
+	if err != nil {
+		return nil, translateBindingLookupError(err)
+	}
+	if binding.InventoryID == inventoryID {
+		return nil, bizerr.New("binding.rebindSameInventory", "The binding already points to this inventory")
+	}
+	target, err := s.inventoryRepo.FindByID(inventoryID)
+	if err != nil {
+		return nil, translateBindingInventoryLookupError(err)
+	}
+	if !target.IsActive {
+		return nil, bizerr.New("binding.rebindInventoryInactive", "The target inventory is disabled")
+	}
+
+	impact := &BindingRebindImpact{
+		BindingID:       binding.ID,
+		ProductID:       binding.ProductID,
+		FromInventoryID: binding.InventoryID,
+		ToInventoryID:   inventoryID,
+		ToInventory:     target,
+		AffectedOrders:  []BindingAffectedOrder{},
+	}
+	if binding.Product == nil {
+		return impact, nil
+	}
+	impact.ProductSKU = binding.Product.SKU
+
+	orders, err := s.bindingRepo.FindOpenOrdersBySKU(binding.Product.SKU)
+	if err != nil {
+		return nil, err
+	}
+	var bindingAttrs map[string]string
+	if len(binding.Attributes) > 0 {
+		_ = json.Unmarshal([]byte(binding.Attributes), &bindingAttrs)
+	}
+	for _, order := range orders {
+		for index, item := range order.Items {
+			if item.SKU != binding.Product.SKU || order.InventoryBindings[index] != binding.InventoryID {
+				continue
+			}
+			// 同一库存被多个规格绑定时，只统计规格与本绑定不冲突的订单项
+			if !orderItemMatchesBindingAttributes(item.Attributes, bindingAttrs) {
+				continue
+			}
+			impact.AffectedOrders = append(impact.AffectedOrders, BindingAffectedOrder{
+				OrderID:   order.ID,
+				OrderNo:   order.OrderNo,
+				Status:    order.Status,
+				ItemIndex: index,
+				Quantity:  item.Quantity,
+				CreatedAt: order.CreatedAt,
+			})
+			impact.AffectedQuantity += item.Quantity
+		}
+	}
+	return impact, nil
+}
+
+// Rebind 将绑定换到另一库存
+// 未完成订单的预留仍保留在原库存上并从原库存发货，因此存在受影响订单时需要显式 force 确认
+func (s *BindingService) Rebind(bindingID, inventoryID uint, force bool) (*BindingRebindImpact, error) {
+	impact, err := s.PreviewRebind(bindingID, inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(impact.AffectedOrders) > 0 && !force {
+		return nil, bizerr.Newf("binding.rebindOpenOrders",
+			"%d open orders still hold stock reserved from the current inventory", len(impact.AffectedOrders)).
+			WithParams(map[string]interface{}{
+				"count":    len(impact.AffectedOrders),
+				"quantity": impact.AffectedQuantity,
+			})
+	}
+	if err := s.bindingRepo.UpdateInventoryID(bindingID, inventoryID); err != nil {
+		return nil, err
+	}
+	impact.Applied = true
+	return impact, nil
+}
+
+// RecordHistory 记录绑定变更，失败不影响主流程
+func (s *BindingService) RecordHistory(history *models.InventoryBindingHistory) error {
+	if len(history.Notes) > 500 {
+		history.Notes = history.Notes[:500]
+	}
+	if err := s.bindingRepo.CreateHistory(history); err != nil {
+		return fmt.Errorf("record binding history: %w", err)
+	}
+	return nil
+}
+
+// ListHistory 查询绑定变更记录
+func (s *BindingService) ListHistory(productID, bindingID uint, page, limit int) ([]models.InventoryBindingHistory, int64, error) {
+	return s.bindingRepo.ListHistory(productID, bindingID, page, limit)
+}
+
+func orderItemMatchesBindingAttributes(itemAttrs map[string]interface{}, bindingAttrs map[string]string) bool {
+	for key, value := range bindingAttrs {
+		raw, ok := itemAttrs[key]
+		if !ok {
+			continue
+		}
+		if fmt.Sprint(raw) != value {
+			return false
+		}
+	}
+	return true
+}
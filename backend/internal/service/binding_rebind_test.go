@@ -0,0 +1,93 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestBindingRebindReportsOpenOrders(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}, &models.ProductInventoryBinding{}, &models.InventoryBindingHistory{}, &models.Order{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	product := &models.Product{SKU: "TEE-1", Name: "Tee", ProductType: models.ProductTypePhysical}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	oldInventory := &models.Inventory{Name: "old", Stock: 10, AvailableQuantity: 10, IsActive: true}
+	newInventory := &models.Inventory{Name: "new", Stock: 10, AvailableQuantity: 10, IsActive: true}
+	if err := db.Create(oldInventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	if err := db.Create(newInventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+
+	bindingService := NewBindingService(repository.NewBindingRepository(db), repository.NewInventoryRepository(db), repository.NewProductRepository(db))
+	binding, err := bindingService.CreateBinding(product.ID, oldInventory.ID, false, 1, `{"Size":"L"}`)
+	if err != nil {
+		t.Fatalf("create binding: %v", err)
+	}
+
+	orders := []models.Order{
+		{
+			OrderNo:           "OPEN-L",
+			Status:            models.OrderStatusPending,
+			Items:             []models.OrderItem{{SKU: "TEE-1", Quantity: 2, Attributes: map[string]interface{}{"Size": "L"}}},
+			InventoryBindings: map[int]uint{0: oldInventory.ID},
+		},
+		{
+			OrderNo:           "OPEN-M",
+			Status:            models.OrderStatusPending,
+			Items:             []models.OrderItem{{SKU: "TEE-1", Quantity: 1, Attributes: map[string]interface{}{"Size": "M"}}},
+			InventoryBindings: map[int]uint{0: oldInventory.ID},
+		},
+		{
+			OrderNo:           "SHIPPED-L",
+			Status:            models.OrderStatusShipped,
+			Items:             []models.OrderItem{{SKU: "TEE-1", Quantity: 5, Attributes: map[string]interface{}{"Size": "L"}}},
+			InventoryBindings: map[int]uint{0: oldInventory.ID},
+		},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+
+	impact, err := bindingService.PreviewRebind(binding.ID, newInventory.ID)
+	if err != nil {
+		t.Fatalf("preview rebind: %v", err)
+	}
+	if len(impact.AffectedOrders) != 1 || impact.AffectedOrders[0].OrderNo != "OPEN-L" || impact.AffectedQuantity != 2 {
+		t.Fatalf("unexpected impact: %+v", impact)
+	}
+	if impact.Applied {
+		t.Fatalf("dry-run must not apply the rebind")
+	}
+
+	_, err = bindingService.Rebind(binding.ID, newInventory.ID, false)
+	requireBizErr(t, err, "binding.rebindOpenOrders")
+
+	_, err = bindingService.Rebind(binding.ID, oldInventory.ID, true)
+	requireBizErr(t, err, "binding.rebindSameInventory")
+
+	impact, err = bindingService.Rebind(binding.ID, newInventory.ID, true)
+	if err != nil {
+		t.Fatalf("forced rebind: %v", err)
+	}
+	if !impact.Applied {
+		t.Fatalf("expected rebind to be applied")
+	}
+
+	var stored models.ProductInventoryBinding
+	if err := db.First(&stored, binding.ID).Error; err != nil {
+		t.Fatalf("reload binding: %v", err)
+	}
+	if stored.InventoryID != newInventory.ID {
+		t.Fatalf("expected binding to point to inventory %d, got %d", newInventory.ID, stored.InventoryID)
+	}
+}
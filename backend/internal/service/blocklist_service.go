@@ -0,0 +1,428 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	blocklistCacheTTL        = 30 * time.Second
+	maxBlocklistReasonLength = 500
+	// MaxBlocklistImportRows 单次导入的最大条目数
+	MaxBlocklistImportRows = 5000
+)
+
+var ErrBlocklistEntryNotFound = errors.New("blocklist entry not found")
+
+// BlocklistSubject 一次检查涉及的请求IP与账户标识，为空的字段不参与匹配
+type BlocklistSubject struct {
+	IP    string
+	Email string
+	Phone string
+}
+
+type blocklistIndex struct {
+	networks []blocklistNetwork
+	values   map[models.BlocklistType]map[string]uint
+}
+
+type blocklistNetwork struct {
+	id      uint
+	network *net.IPNet
+}
+
+// BlocklistService 管理员维护的 IP/邮箱/邮箱域名/手机号黑名单。
+// 有效条目缓存在内存中，注册、登录与下单时检查，命中后累加计数并拒绝请求
+type BlocklistService struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	index    *blocklistIndex
+	loadedAt time.Time
+}
+
+// NewBlocklistService 创建黑名单服务
+func NewBlocklistService(db *gorm.DB) *BlocklistService {
+	return &BlocklistService{db: db}
+}
+
+// NormalizeBlocklistValue 校验并规范化条目值：IP 转为标准形式或网段，邮箱与域名转为小写，手机号去除分隔符
+func NormalizeBlocklistValue(entryType models.BlocklistType, raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	invalid := bizerr.Newf("blocklist.valueInvalid", "Invalid %s value: %s", entryType, value).
+		WithParams(map[string]interface{}{"type": string(entryType), "value": value})
+	if value == "" || len(value) > 255 {
+		return "", invalid
+	}
+	switch entryType {
+	case models.BlocklistTypeIP:
+		if strings.Contains(value, "/") {
+			_, network, err := net.ParseCIDR(value)
+			if err != nil {
+				return "", invalid
+			}
+			return network.String(), nil
+		}
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", invalid
+		}
+		return ip.String(), nil
+	case models.BlocklistTypeEmail:
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return "", invalid
+		}
+		return strings.ToLower(value), nil
+	case models.BlocklistTypeEmailDomain:
+		domain := strings.ToLower(strings.TrimPrefix(value, "@"))
+		if !strings.Contains(domain, ".") || strings.ContainsAny(domain, "@ /") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+			return "", invalid
+		}
+		return domain, nil
+	case models.BlocklistTypePhone:
+		phone := normalizeBlocklistPhone(value)
+		digits := strings.TrimPrefix(phone, "+")
+		if len(digits) < 5 || len(digits) > 20 || strings.Trim(digits, "0123456789") != "" {
+			return "", invalid
+		}
+		return phone, nil
+	default:
+		return "", bizerr.Newf("blocklist.typeInvalid", "Invalid blocklist type: %s", entryType).
+			WithParams(map[string]interface{}{"type": string(entryType)})
+	}
+}
+
+func normalizeBlocklistPhone(value string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(value))
+}
+
+func (s *BlocklistService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *BlocklistService) activeIndex() (*blocklistIndex, error) {
+	now := time.Now()
+	s.mu.RLock()
+	if !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < blocklistCacheTTL {
+		index := s.index
+		s.mu.RUnlock()
+		return index, nil
+	}
+	s.mu.RUnlock()
+
+	var entries []models.BlocklistEntry
+	if err := s.db.Where("expires_at IS NULL OR expires_at > ?", models.NowFunc()).Order("id ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	index := &blocklistIndex{values: make(map[models.BlocklistType]map[string]uint)}
+	for _, entry := range entries {
+		if entry.Type == models.BlocklistTypeIP && strings.Contains(entry.Value, "/") {
+			_, network, err := net.ParseCIDR(entry.Value)
+			if err != nil {
+				// 保存时已校验，这里仅跳过被直接改库的坏条目
+				log.Printf("blocklist: skip invalid network id=%d: %v", entry.ID, err)
+				continue
+			}
+			index.networks = append(index.networks, blocklistNetwork{id: entry.ID, network: network})
+			continue
+		}
+		if index.values[entry.Type] == nil {
+			index.values[entry.Type] = make(map[string]uint)
+		}
+		index.values[entry.Type][entry.Value] = entry.ID
+	}
+
+	s.mu.Lock()
+	s.index = index
+	s.loadedAt = now
+	s.mu.Unlock()
+	return index, nil
+}
+
+// match 返回所有命中条目的ID；缓存中可能残留刚过期或已删除的条目，由 recordMatch 复核
+func (index *blocklistIndex) match(subject BlocklistSubject) []uint {
+	var ids []uint
+	if ip := net.ParseIP(strings.TrimSpace(subject.IP)); ip != nil {
+		if id, ok := index.values[models.BlocklistTypeIP][ip.String()]; ok {
+			ids = append(ids, id)
+		}
+		for _, item := range index.networks {
+			if item.network.Contains(ip) {
+				ids = append(ids, item.id)
+			}
+		}
+	}
+	if email := strings.ToLower(strings.TrimSpace(subject.Email)); email != "" {
+		if id, ok := index.values[models.BlocklistTypeEmail][email]; ok {
+			ids = append(ids, id)
+		}
+		if at := strings.LastIndex(email, "@"); at >= 0 {
+			// 依次检查完整域名与各级父域名
+			domain := email[at+1:]
+			for domain != "" {
+				if id, ok := index.values[models.BlocklistTypeEmailDomain][domain]; ok {
+					ids = append(ids, id)
+				}
+				dot := strings.Index(domain, ".")
+				if dot < 0 {
+					break
+				}
+				domain = domain[dot+1:]
+			}
+		}
+	}
+	if phone := normalizeBlocklistPhone(subject.Phone); phone != "" {
+		if id, ok := index.values[models.BlocklistTypePhone][phone]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Check 检查请求是否命中黑名单。命中时累加条目计数并返回 blocklist.blocked 业务错误，错误中不包含条目内容与原因
+// 服务为空时放行
+func (s *BlocklistService) Check(scope models.BlocklistScope, subject BlocklistSubject) error {
+	if s == nil {
+		return nil
+	}
+	index, err := s.activeIndex()
+	if err != nil {
+		return err
+	}
+	for _, id := range index.match(subject) {
+		matched, err := s.recordMatch(id, scope)
+		if err != nil {
+			return err
+		}
+		if matched {
+			return bizerr.New("blocklist.blocked", "This request has been blocked. Please contact support if you believe this is a mistake").
+				WithStatus(http.StatusForbidden)
+		}
+	}
+	return nil
+}
+
+// CheckUser 按用户的邮箱与手机号及请求IP检查
+func (s *BlocklistService) CheckUser(scope models.BlocklistScope, ip string, userID uint) error {
+	if s == nil {
+		return nil
+	}
+	var user models.User
+	if err := s.db.Select("id", "email", "phone").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.Check(scope, BlocklistSubject{IP: ip})
+		}
+		return err
+	}
+	subject := BlocklistSubject{IP: ip, Email: user.Email}
+	if user.Phone != nil {
+		subject.Phone = *user.Phone
+	}
+	return s.Check(scope, subject)
+}
+
+// recordMatch 累加命中计数；条目已删除或已过期时返回 false
+func (s *BlocklistService) recordMatch(id uint, scope models.BlocklistScope) (bool, error) {
+	now := models.NowFunc()
+	result := s.db.Model(&models.BlocklistEntry{}).
+		Where("id = ? AND (expires_at IS NULL OR expires_at > ?)", id, now).
+		Updates(map[string]interface{}{
+			"match_count":        gorm.Expr("match_count + 1"),
+			"last_matched_at":    now,
+			"last_matched_scope": scope,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// BlocklistEntryInput 管理端创建/更新条目参数
+type BlocklistEntryInput struct {
+	Type      models.BlocklistType
+	Value     string
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+func validateBlocklistEntryInput(input *BlocklistEntryInput) error {
+	value, err := NormalizeBlocklistValue(input.Type, input.Value)
+	if err != nil {
+		return err
+	}
+	input.Value = value
+	input.Reason = strings.TrimSpace(input.Reason)
+	if len([]rune(input.Reason)) > maxBlocklistReasonLength {
+		return bizerr.Newf("blocklist.reasonTooLong", "Reason cannot exceed %d characters", maxBlocklistReasonLength).
+			WithParams(map[string]interface{}{"max": maxBlocklistReasonLength})
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(models.NowFunc()) {
+		return bizerr.New("blocklist.expiresInPast", "Expiry time must be in the future")
+	}
+	return nil
+}
+
+// List 条目列表，支持按类型筛选与按值搜索
+func (s *BlocklistService) List(entryType, search string, page, limit int) ([]models.BlocklistEntry, int64, error) {
+	query := s.db.Model(&models.BlocklistEntry{})
+	if entryType != "" {
+		query = query.Where("type = ?", entryType)
+	}
+	if search = strings.ToLower(strings.TrimSpace(search)); search != "" {
+		query = query.Where("value LIKE ?", "%"+search+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var entries []models.BlocklistEntry
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// Create 创建条目，同类型同值的条目已存在时返回 blocklist.duplicate
+func (s *BlocklistService) Create(adminID uint, input BlocklistEntryInput) (*models.BlocklistEntry, error) {
+	if err := validateBlocklistEntryInput(&input); err != nil {
+		return nil, err
+	}
+	var count int64
+	if err := s.db.Model(&models.BlocklistEntry{}).Where("type = ? AND value = ?", input.Type, input.Value).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, bizerr.Newf("blocklist.duplicate", "%s is already on the blocklist", input.Value).
+			WithParams(map[string]interface{}{"value": input.Value})
+	}
+	entry := &models.BlocklistEntry{
+		Type:      input.Type,
+		Value:     input.Value,
+		Reason:    input.Reason,
+		ExpiresAt: input.ExpiresAt,
+		CreatedBy: adminID,
+	}
+	if err := s.db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return entry, nil
+}
+
+// Update 更新条目的原因与有效期，类型与值不可修改
+func (s *BlocklistService) Update(id uint, reason string, expiresAt *time.Time) (*models.BlocklistEntry, error) {
+	var entry models.BlocklistEntry
+	if err := s.db.First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBlocklistEntryNotFound
+		}
+		return nil, err
+	}
+	input := BlocklistEntryInput{Type: entry.Type, Value: entry.Value, Reason: reason, ExpiresAt: expiresAt}
+	if err := validateBlocklistEntryInput(&input); err != nil {
+		return nil, err
+	}
+	entry.Reason = input.Reason
+	entry.ExpiresAt = input.ExpiresAt
+	if err := s.db.Model(&entry).Select("reason", "expires_at").Updates(&entry).Error; err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return &entry, nil
+}
+
+// Delete 删除条目
+func (s *BlocklistService) Delete(id uint) error {
+	result := s.db.Delete(&models.BlocklistEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBlocklistEntryNotFound
+	}
+	s.invalidate()
+	return nil
+}
+
+// BlocklistImportRow 导入文件中的一行，Row 为文件中的行号
+type BlocklistImportRow struct {
+	Row   int
+	Input BlocklistEntryInput
+}
+
+// BlocklistImportResult 导入结果
+type BlocklistImportResult struct {
+	TotalRows    int      `json:"total_rows"`
+	CreatedCount int      `json:"created_count"`
+	UpdatedCount int      `json:"updated_count"`
+	ErrorCount   int      `json:"error_count"`
+	Errors       []string `json:"errors"`
+}
+
+const maxBlocklistImportErrors = 50
+
+// Import 批量导入条目；已存在的同类型同值条目会被更新原因与有效期，计数保留
+func (s *BlocklistService) Import(adminID uint, rows []BlocklistImportRow) (*BlocklistImportResult, error) {
+	result := &BlocklistImportResult{TotalRows: len(rows), Errors: make([]string, 0)}
+	addError := func(row int, err error) {
+		result.ErrorCount++
+		if len(result.Errors) < maxBlocklistImportErrors {
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", row, err))
+		}
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			input := row.Input
+			if err := validateBlocklistEntryInput(&input); err != nil {
+				addError(row.Row, err)
+				continue
+			}
+			var existing models.BlocklistEntry
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("type = ? AND value = ?", input.Type, input.Value).First(&existing).Error
+			switch {
+			case err == nil:
+				existing.Reason = input.Reason
+				existing.ExpiresAt = input.ExpiresAt
+				if err := tx.Model(&existing).Select("reason", "expires_at").Updates(&existing).Error; err != nil {
+					return err
+				}
+				result.UpdatedCount++
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&models.BlocklistEntry{
+					Type:      input.Type,
+					Value:     input.Value,
+					Reason:    input.Reason,
+					ExpiresAt: input.ExpiresAt,
+					CreatedBy: adminID,
+				}).Error; err != nil {
+					return err
+				}
+				result.CreatedCount++
+			default:
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestBlocklistMatchingCountersAndImport(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.BlocklistEntry{}); err != nil {
+		t.Fatalf("auto migrate blocklist failed: %v", err)
+	}
+	svc := NewBlocklistService(db)
+
+	_, err := svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypeIP, Value: "10.0.0.300"})
+	requireOrderBizErr(t, err, "blocklist.valueInvalid")
+	_, err = svc.Create(1, BlocklistEntryInput{Type: "asn", Value: "AS13335"})
+	requireOrderBizErr(t, err, "blocklist.typeInvalid")
+	past := time.Now().Add(-time.Hour)
+	_, err = svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypeEmail, Value: "a@b.com", ExpiresAt: &past})
+	requireOrderBizErr(t, err, "blocklist.expiresInPast")
+
+	network, err := svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypeIP, Value: "203.0.113.7/24", Reason: "Card testing"})
+	if err != nil || network.Value != "203.0.113.0/24" {
+		t.Fatalf("expected normalized network, got %+v err=%v", network, err)
+	}
+	domain, err := svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypeEmailDomain, Value: "@Spam.Example"})
+	if err != nil || domain.Value != "spam.example" {
+		t.Fatalf("expected normalized domain, got %+v err=%v", domain, err)
+	}
+	if _, err := svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypePhone, Value: "+1 (555) 010-9999"}); err != nil {
+		t.Fatalf("create phone entry failed: %v", err)
+	}
+	_, err = svc.Create(1, BlocklistEntryInput{Type: models.BlocklistTypeEmailDomain, Value: "spam.example"})
+	requireOrderBizErr(t, err, "blocklist.duplicate")
+
+	requireOrderBizErr(t, svc.Check(models.BlocklistScopeRegister, BlocklistSubject{IP: "203.0.113.99"}), "blocklist.blocked")
+	requireOrderBizErr(t, svc.Check(models.BlocklistScopeLogin, BlocklistSubject{Email: "Bot@Mail.Spam.Example"}), "blocklist.blocked")
+	requireOrderBizErr(t, svc.Check(models.BlocklistScopeOrder, BlocklistSubject{Phone: "+15550109999"}), "blocklist.blocked")
+	if err := svc.Check(models.BlocklistScopeLogin, BlocklistSubject{IP: "198.51.100.1", Email: "user@notspam.example", Phone: "+15550100000"}); err != nil {
+		t.Fatalf("expected clean subject to pass, got %v", err)
+	}
+
+	var stored models.BlocklistEntry
+	if err := db.First(&stored, network.ID).Error; err != nil {
+		t.Fatalf("reload entry: %v", err)
+	}
+	if stored.MatchCount != 1 || stored.LastMatchedAt == nil || stored.LastMatchedScope != models.BlocklistScopeRegister {
+		t.Fatalf("expected one register match, got %+v", stored)
+	}
+
+	// 导入时已存在的条目更新原因与有效期，计数保留；无效行单独报错
+	future := time.Now().Add(24 * time.Hour)
+	result, err := svc.Import(1, []BlocklistImportRow{
+		{Row: 2, Input: BlocklistEntryInput{Type: models.BlocklistTypeIP, Value: "203.0.113.0/24", Reason: "Updated", ExpiresAt: &future}},
+		{Row: 3, Input: BlocklistEntryInput{Type: models.BlocklistTypeEmail, Value: "fraud@example.com"}},
+		{Row: 4, Input: BlocklistEntryInput{Type: models.BlocklistTypeEmail, Value: "not-an-email"}},
+	})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if result.CreatedCount != 1 || result.UpdatedCount != 1 || result.ErrorCount != 1 || len(result.Errors) != 1 {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+	if err := db.First(&stored, network.ID).Error; err != nil {
+		t.Fatalf("reload entry: %v", err)
+	}
+	if stored.Reason != "Updated" || stored.ExpiresAt == nil || stored.MatchCount != 1 {
+		t.Fatalf("expected imported update to keep counters, got %+v", stored)
+	}
+	requireOrderBizErr(t, svc.Check(models.BlocklistScopeOrder, BlocklistSubject{Email: "FRAUD@example.com"}), "blocklist.blocked")
+
+	// 过期条目不再拦截，也不计数
+	originalNow := models.NowFunc
+	t.Cleanup(func() { models.NowFunc = originalNow })
+	models.NowFunc = func() time.Time { return future.Add(time.Minute) }
+	if err := svc.Check(models.BlocklistScopeLogin, BlocklistSubject{IP: "203.0.113.5"}); err != nil {
+		t.Fatalf("expected expired network to pass, got %v", err)
+	}
+	if err := db.First(&stored, network.ID).Error; err != nil {
+		t.Fatalf("reload entry: %v", err)
+	}
+	if stored.MatchCount != 1 {
+		t.Fatalf("expected expired entry not to count matches, got %d", stored.MatchCount)
+	}
+
+	if err := svc.Delete(domain.ID); err != nil {
+		t.Fatalf("delete entry failed: %v", err)
+	}
+	models.NowFunc = originalNow
+	if err := svc.Check(models.BlocklistScopeLogin, BlocklistSubject{Email: "bot@spam.example"}); err != nil {
+		t.Fatalf("expected deleted domain to pass, got %v", err)
+	}
+	if err := svc.Delete(domain.ID); err != ErrBlocklistEntryNotFound {
+		t.Fatalf("expected not found on second delete, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+const (
+	seventeenTrackRegisterURL = "https://api.17track.net/track/v2.2/register"
+	// 17TRACK 单号已注册的错误码，视为注册成功
+	seventeenTrackAlreadyRegistered = -18019901
+
+	afterShipTrackingsURL = "https://api.aftership.com/tracking/2024-04/trackings"
+	// AfterShip 单号已存在的错误码，视为注册成功
+	afterShipTrackingExists = 4003
+
+	carrierTrackingResponseBodyLimit = 1 << 20
+)
+
+func (s *CarrierTrackingService) defaultProvider(cfg config.CarrierTrackingConfig) (carrierTrackingProvider, error) {
+	apiKey := strings.TrimSpace(cfg.APIKey)
+	switch cfg.Provider {
+	case CarrierTrackingProvider17Track:
+		secret := strings.TrimSpace(cfg.WebhookSecret)
+		if secret == "" {
+			secret = apiKey
+		}
+		return &seventeenTrackProvider{client: s.httpClient, registerURL: seventeenTrackRegisterURL, apiKey: apiKey, webhookSecret: secret}, nil
+	case CarrierTrackingProviderAfterShip:
+		return &afterShipProvider{client: s.httpClient, trackingsURL: afterShipTrackingsURL, apiKey: apiKey, webhookSecret: strings.TrimSpace(cfg.WebhookSecret)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported carrier tracking provider: %s", cfg.Provider)
+	}
+}
+
+// doCarrierTrackingRequest 发送请求并返回状态码与响应体，由调用方按服务商的错误码判断结果
+func doCarrierTrackingRequest(client *http.Client, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, carrierTrackingResponseBodyLimit))
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+func carrierTrackingHTTPError(status int, body []byte) error {
+	return fmt.Errorf("HTTP %d: %s", status, truncateCarrierTrackingText(string(body), 300))
+}
+
+// parseCarrierTrackingTime 解析服务商时间；不带时区的本地时间按 UTC 处理
+func parseCarrierTrackingTime(values ...string) time.Time {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed.UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// seventeenTrackProvider 17TRACK v2.2：请求头 17token 鉴权，回调签名为 sha256(body + "/" + 密钥)
+type seventeenTrackProvider struct {
+	client        *http.Client
+	registerURL   string
+	apiKey        string
+	webhookSecret string
+}
+
+var seventeenTrackStatuses = map[string]models.ShipmentStatus{
+	"NotFound":           models.ShipmentStatusPending,
+	"InfoReceived":       models.ShipmentStatusInfoReceived,
+	"InTransit":          models.ShipmentStatusInTransit,
+	"PickedUp":           models.ShipmentStatusInTransit,
+	"Departure":          models.ShipmentStatusInTransit,
+	"Arrival":            models.ShipmentStatusInTransit,
+	"AvailableForPickup": models.ShipmentStatusAvailableForPickup,
+	"OutForDelivery":     models.ShipmentStatusOutForDelivery,
+	"DeliveryFailure":    models.ShipmentStatusFailedAttempt,
+	"Delivered":          models.ShipmentStatusDelivered,
+	"Exception":          models.ShipmentStatusException,
+	"Returning":          models.ShipmentStatusException,
+	"Returned":           models.ShipmentStatusException,
+	"Expired":            models.ShipmentStatusExpired,
+}
+
+func (p *seventeenTrackProvider) Register(ctx context.Context, shipment *models.OrderShipment) (string, error) {
+	item := map[string]interface{}{"number": shipment.TrackingNo, "tag": shipment.OrderNo}
+	if carrier, err := strconv.Atoi(strings.TrimSpace(shipment.Carrier)); err == nil && carrier > 0 {
+		item["carrier"] = carrier
+	}
+	payload, err := json.Marshal([]interface{}{item})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.registerURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("17token", p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	status, body, err := doCarrierTrackingRequest(p.client, req)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status >= 300 {
+		return "", carrierTrackingHTTPError(status, body)
+	}
+	var result struct {
+		Code int `json:"code"`
+		Data struct {
+			Accepted []struct {
+				Number  string `json:"number"`
+				Carrier int    `json:"carrier"`
+			} `json:"accepted"`
+			Rejected []struct {
+				Number string `json:"number"`
+				Error  struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			} `json:"rejected"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("decode 17TRACK response: %w", err)
+	}
+	if result.Code != 0 {
+		return "", carrierTrackingHTTPError(status, body)
+	}
+	if len(result.Data.Accepted) > 0 {
+		if carrier := result.Data.Accepted[0].Carrier; carrier > 0 {
+			return strconv.Itoa(carrier), nil
+		}
+		return "", nil
+	}
+	if len(result.Data.Rejected) > 0 {
+		rejected := result.Data.Rejected[0]
+		if rejected.Error.Code != seventeenTrackAlreadyRegistered {
+			return "", fmt.Errorf("17TRACK rejected %s: %s", rejected.Number, rejected.Error.Message)
+		}
+	}
+	return "", nil
+}
+
+func (p *seventeenTrackProvider) ParseWebhook(header http.Header, body []byte) ([]CarrierTrackingUpdate, error) {
+	sum := sha256.Sum256(append(append([]byte{}, body...), []byte("/"+p.webhookSecret)...))
+	expected := hex.EncodeToString(sum[:])
+	if p.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(strings.ToLower(strings.TrimSpace(header.Get("sign")))), []byte(expected)) != 1 {
+		return nil, ErrCarrierTrackingSignatureInvalid
+	}
+
+	var payload struct {
+		Event string `json:"event"`
+		Data  struct {
+			Number    string `json:"number"`
+			Carrier   int    `json:"carrier"`
+			TrackInfo struct {
+				LatestStatus struct {
+					Status string `json:"status"`
+				} `json:"latest_status"`
+				Tracking struct {
+					Providers []struct {
+						Events []struct {
+							TimeISO     string `json:"time_iso"`
+							TimeUTC     string `json:"time_utc"`
+							Description string `json:"description"`
+							Location    string `json:"location"`
+							Stage       string `json:"stage"`
+						} `json:"events"`
+					} `json:"providers"`
+				} `json:"tracking"`
+			} `json:"track_info"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode 17TRACK webhook: %w", err)
+	}
+	if payload.Event != "TRACKING_UPDATED" || payload.Data.Number == "" {
+		return nil, nil
+	}
+
+	update := CarrierTrackingUpdate{
+		TrackingNo: payload.Data.Number,
+		Status:     seventeenTrackStatuses[payload.Data.TrackInfo.LatestStatus.Status],
+	}
+	if payload.Data.Carrier > 0 {
+		update.Carrier = strconv.Itoa(payload.Data.Carrier)
+	}
+	for _, provider := range payload.Data.TrackInfo.Tracking.Providers {
+		for _, event := range provider.Events {
+			status, ok := seventeenTrackStatuses[event.Stage]
+			if !ok {
+				status = models.ShipmentStatusInTransit
+			}
+			update.Checkpoints = append(update.Checkpoints, CarrierTrackingCheckpoint{
+				Status:     status,
+				Message:    event.Description,
+				Location:   event.Location,
+				OccurredAt: parseCarrierTrackingTime(event.TimeUTC, event.TimeISO),
+			})
+		}
+	}
+	return []CarrierTrackingUpdate{update}, nil
+}
+
+// afterShipProvider AfterShip Tracking API：请求头 as-api-key 鉴权，回调签名为 base64(HMAC-SHA256(body, 回调密钥))
+type afterShipProvider struct {
+	client        *http.Client
+	trackingsURL  string
+	apiKey        string
+	webhookSecret string
+}
+
+var afterShipStatuses = map[string]models.ShipmentStatus{
+	"Pending":            models.ShipmentStatusPending,
+	"InfoReceived":       models.ShipmentStatusInfoReceived,
+	"InTransit":          models.ShipmentStatusInTransit,
+	"OutForDelivery":     models.ShipmentStatusOutForDelivery,
+	"AvailableForPickup": models.ShipmentStatusAvailableForPickup,
+	"AttemptFail":        models.ShipmentStatusFailedAttempt,
+	"Delivered":          models.ShipmentStatusDelivered,
+	"Exception":          models.ShipmentStatusException,
+	"Expired":            models.ShipmentStatusExpired,
+}
+
+func (p *afterShipProvider) Register(ctx context.Context, shipment *models.OrderShipment) (string, error) {
+	item := map[string]interface{}{
+		"tracking_number": shipment.TrackingNo,
+		"order_number":    shipment.OrderNo,
+	}
+	if slug := strings.TrimSpace(shipment.Carrier); slug != "" {
+		item["slug"] = slug
+	}
+	payload, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.trackingsURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("as-api-key", p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	status, body, err := doCarrierTrackingRequest(p.client, req)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Meta struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"meta"`
+		Data struct {
+			Slug     string `json:"slug"`
+			Tracking struct {
+				Slug string `json:"slug"`
+			} `json:"tracking"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		if status < 200 || status >= 300 {
+			return "", carrierTrackingHTTPError(status, body)
+		}
+		return "", fmt.Errorf("decode AfterShip response: %w", err)
+	}
+	if result.Meta.Code == afterShipTrackingExists {
+		return "", nil
+	}
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("AfterShip error %d: %s", result.Meta.Code, result.Meta.Message)
+	}
+	if result.Data.Slug != "" {
+		return result.Data.Slug, nil
+	}
+	return result.Data.Tracking.Slug, nil
+}
+
+func (p *afterShipProvider) ParseWebhook(header http.Header, body []byte) ([]CarrierTrackingUpdate, error) {
+	if p.webhookSecret == "" {
+		return nil, ErrCarrierTrackingSignatureInvalid
+	}
+	mac := hmac.New(sha256.New, []byte(p.webhookSecret))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(strings.TrimSpace(header.Get("aftership-hmac-sha256"))), []byte(expected)) {
+		return nil, ErrCarrierTrackingSignatureInvalid
+	}
+
+	var payload struct {
+		Event string `json:"event"`
+		Msg   struct {
+			TrackingNumber string `json:"tracking_number"`
+			Slug           string `json:"slug"`
+			Tag            string `json:"tag"`
+			Checkpoints    []struct {
+				CheckpointTime string `json:"checkpoint_time"`
+				Message        string `json:"message"`
+				Location       string `json:"location"`
+				Tag            string `json:"tag"`
+			} `json:"checkpoints"`
+		} `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode AfterShip webhook: %w", err)
+	}
+	if payload.Event != "tracking_update" || payload.Msg.TrackingNumber == "" {
+		return nil, nil
+	}
+
+	update := CarrierTrackingUpdate{
+		TrackingNo: payload.Msg.TrackingNumber,
+		Carrier:    payload.Msg.Slug,
+		Status:     afterShipStatuses[payload.Msg.Tag],
+	}
+	for _, checkpoint := range payload.Msg.Checkpoints {
+		status, ok := afterShipStatuses[checkpoint.Tag]
+		if !ok {
+			status = models.ShipmentStatusInTransit
+		}
+		update.Checkpoints = append(update.Checkpoints, CarrierTrackingCheckpoint{
+			Status:     status,
+			Message:    checkpoint.Message,
+			Location:   checkpoint.Location,
+			OccurredAt: parseCarrierTrackingTime(checkpoint.CheckpointTime),
+		})
+	}
+	return []CarrierTrackingUpdate{update}, nil
+}
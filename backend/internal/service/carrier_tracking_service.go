@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CarrierTrackingProvider17Track   = "17track"
+	CarrierTrackingProviderAfterShip = "aftership"
+
+	// 注册失败的物流单号最多重试次数
+	carrierTrackingMaxRegisterAttempts = 5
+	carrierTrackingRegisterBatchSize   = 50
+	carrierTrackingRequestTimeout      = 15 * time.Second
+)
+
+var (
+	ErrCarrierTrackingDisabled         = errors.New("carrier tracking is not enabled")
+	ErrCarrierTrackingSignatureInvalid = errors.New("invalid carrier tracking webhook signature")
+)
+
+// CarrierTrackingCheckpoint 服务商回调中的单个轨迹节点
+type CarrierTrackingCheckpoint struct {
+	Status     models.ShipmentStatus
+	Message    string
+	Location   string
+	OccurredAt time.Time
+}
+
+// CarrierTrackingUpdate 服务商回调解析结果，Checkpoints 为该单号的完整轨迹
+type CarrierTrackingUpdate struct {
+	TrackingNo  string
+	Carrier     string
+	Status      models.ShipmentStatus
+	Checkpoints []CarrierTrackingCheckpoint
+}
+
+// carrierTrackingProvider 物流服务商接口
+type carrierTrackingProvider interface {
+	// Register 注册物流单号，返回服务商识别出的承运商代码（可为空）
+	Register(ctx context.Context, shipment *models.OrderShipment) (string, error)
+	// ParseWebhook 校验签名并解析回调
+	ParseWebhook(header http.Header, body []byte) ([]CarrierTrackingUpdate, error)
+}
+
+// CarrierTrackingService 物流轨迹对接：发货后将物流单号注册到 17TRACK / AfterShip，接收回调保存轨迹
+type CarrierTrackingService struct {
+	db          *gorm.DB
+	cfg         *config.Config
+	httpClient  *http.Client
+	newProvider func(cfg config.CarrierTrackingConfig) (carrierTrackingProvider, error)
+}
+
+// NewCarrierTrackingService 创建物流轨迹服务
+func NewCarrierTrackingService(db *gorm.DB, cfg *config.Config) *CarrierTrackingService {
+	s := &CarrierTrackingService{
+		db:         db,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: carrierTrackingRequestTimeout},
+	}
+	s.newProvider = s.defaultProvider
+	return s
+}
+
+func (s *CarrierTrackingService) trackingConfig() config.CarrierTrackingConfig {
+	if s == nil || s.cfg == nil {
+		return config.CarrierTrackingConfig{}
+	}
+	return s.cfg.Order.CarrierTracking
+}
+
+// Enabled 是否开启物流轨迹对接
+func (s *CarrierTrackingService) Enabled() bool {
+	cfg := s.trackingConfig()
+	return cfg.Enabled && cfg.Provider != ""
+}
+
+// RegisterJobs 注册物流单号注册重试任务
+func (s *CarrierTrackingService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "carrier_tracking_register",
+		Description: "Register new tracking numbers with the carrier tracking provider",
+		Interval:    5 * time.Minute,
+		Run:         s.RegisterPending,
+	})
+}
+
+// TrackShipment 发货时创建物流跟踪记录，由定时任务注册到服务商；未开启时不做任何事
+func (s *CarrierTrackingService) TrackShipment(order *models.Order) error {
+	if !s.Enabled() || order == nil || strings.TrimSpace(order.TrackingNo) == "" {
+		return nil
+	}
+	cfg := s.trackingConfig()
+	trackingNo := strings.TrimSpace(order.TrackingNo)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.OrderShipment
+		err := tx.Where("order_id = ?", order.ID).First(&existing).Error
+		if err == nil {
+			if existing.TrackingNo == trackingNo && existing.Provider == cfg.Provider {
+				return nil
+			}
+			// 物流单号变更时重新跟踪，旧轨迹作废
+			if err := tx.Where("shipment_id = ?", existing.ID).Delete(&models.OrderShipmentCheckpoint{}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&existing).Updates(map[string]interface{}{
+				"tracking_no":       trackingNo,
+				"carrier":           strings.TrimSpace(cfg.DefaultCarrier),
+				"provider":          cfg.Provider,
+				"status":            models.ShipmentStatusPending,
+				"registered_at":     nil,
+				"register_attempts": 0,
+				"register_error":    "",
+				"last_event_at":     nil,
+				"delivered_at":      nil,
+			}).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return tx.Create(&models.OrderShipment{
+			OrderID:    order.ID,
+			OrderNo:    order.OrderNo,
+			TrackingNo: trackingNo,
+			Carrier:    strings.TrimSpace(cfg.DefaultCarrier),
+			Provider:   cfg.Provider,
+			Status:     models.ShipmentStatusPending,
+		}).Error
+	})
+}
+
+// RegisterPending 将尚未注册的物流单号注册到服务商，失败的单号在下次执行时重试
+func (s *CarrierTrackingService) RegisterPending(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+	cfg := s.trackingConfig()
+	provider, err := s.newProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	var shipments []models.OrderShipment
+	if err := s.db.Where("provider = ? AND registered_at IS NULL AND register_attempts < ?", cfg.Provider, carrierTrackingMaxRegisterAttempts).
+		Order("id ASC").Limit(carrierTrackingRegisterBatchSize).Find(&shipments).Error; err != nil {
+		return fmt.Errorf("query unregistered shipments: %w", err)
+	}
+
+	failed := 0
+	for i := range shipments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		shipment := &shipments[i]
+		carrier, registerErr := provider.Register(ctx, shipment)
+		updates := map[string]interface{}{"register_attempts": gorm.Expr("register_attempts + 1")}
+		if registerErr != nil {
+			failed++
+			updates["register_error"] = truncateCarrierTrackingText(registerErr.Error(), 500)
+			log.Printf("[CarrierTracking] Failed to register %s for order %s: %v", shipment.TrackingNo, shipment.OrderNo, registerErr)
+		} else {
+			updates["registered_at"] = models.NowFunc()
+			updates["register_error"] = ""
+			if carrier = strings.TrimSpace(carrier); carrier != "" {
+				updates["carrier"] = carrier
+			}
+		}
+		if err := s.db.Model(shipment).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update shipment %d: %w", shipment.ID, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tracking numbers failed to register", failed, len(shipments))
+	}
+	return nil
+}
+
+// HandleWebhook 校验并处理服务商回调，返回更新的物流记录数
+func (s *CarrierTrackingService) HandleWebhook(header http.Header, body []byte) (int, error) {
+	if !s.Enabled() {
+		return 0, ErrCarrierTrackingDisabled
+	}
+	cfg := s.trackingConfig()
+	provider, err := s.newProvider(cfg)
+	if err != nil {
+		return 0, err
+	}
+	updates, err := provider.ParseWebhook(header, body)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, update := range updates {
+		ok, err := s.ApplyUpdate(cfg.Provider, update)
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// ApplyUpdate 保存轨迹节点并更新物流状态；找不到对应物流记录时忽略（可能属于其他系统）
+func (s *CarrierTrackingService) ApplyUpdate(provider string, update CarrierTrackingUpdate) (bool, error) {
+	trackingNo := strings.TrimSpace(update.TrackingNo)
+	if trackingNo == "" {
+		return false, nil
+	}
+	var shipment models.OrderShipment
+	if err := s.db.Where("tracking_no = ? AND provider = ?", trackingNo, provider).Order("id DESC").First(&shipment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	checkpoints := make([]models.OrderShipmentCheckpoint, 0, len(update.Checkpoints))
+	for _, item := range update.Checkpoints {
+		if item.OccurredAt.IsZero() {
+			continue
+		}
+		checkpoint := models.OrderShipmentCheckpoint{
+			ShipmentID: shipment.ID,
+			Status:     item.Status,
+			Message:    truncateCarrierTrackingText(item.Message, 500),
+			Location:   truncateCarrierTrackingText(item.Location, 255),
+			OccurredAt: item.OccurredAt.UTC(),
+		}
+		checkpoint.Fingerprint = carrierTrackingCheckpointFingerprint(&checkpoint)
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].OccurredAt.Before(checkpoints[j].OccurredAt) })
+
+	status := update.Status
+	if status == "" && len(checkpoints) > 0 {
+		status = checkpoints[len(checkpoints)-1].Status
+	}
+	if status == "" {
+		status = shipment.Status
+	}
+
+	beforeStatus := shipment.Status
+	updates := map[string]interface{}{"status": status}
+	if carrier := strings.TrimSpace(update.Carrier); carrier != "" {
+		updates["carrier"] = truncateCarrierTrackingText(carrier, 50)
+	}
+	if len(checkpoints) > 0 {
+		latest := checkpoints[len(checkpoints)-1].OccurredAt
+		if shipment.LastEventAt == nil || latest.After(*shipment.LastEventAt) {
+			updates["last_event_at"] = latest
+		}
+	}
+	// 签收时间取签收节点时间；签收后又变为退回等状态时清除，避免按签收自动完成
+	if status == models.ShipmentStatusDelivered {
+		if shipment.DeliveredAt == nil {
+			deliveredAt := models.NowFunc()
+			for i := len(checkpoints) - 1; i >= 0; i-- {
+				if checkpoints[i].Status == models.ShipmentStatusDelivered {
+					deliveredAt = checkpoints[i].OccurredAt
+					break
+				}
+			}
+			updates["delivered_at"] = deliveredAt
+		}
+	} else if shipment.DeliveredAt != nil {
+		updates["delivered_at"] = nil
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if len(checkpoints) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&checkpoints).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&shipment).Updates(updates).Error
+	}); err != nil {
+		return false, err
+	}
+
+	if status != beforeStatus {
+		data := map[string]interface{}{
+			"tracking_no":     shipment.TrackingNo,
+			"shipment_status": status,
+		}
+		if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].Location != "" {
+			data["location"] = checkpoints[len(checkpoints)-1].Location
+		}
+		recordOrderEventDB(s.db, &models.OrderEvent{
+			OrderID: shipment.OrderID,
+			OrderNo: shipment.OrderNo,
+			Type:    models.OrderEventTypeShipment,
+			Source:  "carrier_tracking",
+			Message: string(status),
+			Data:    encodeOrderEventData(data),
+		})
+		if status == models.ShipmentStatusDelivered {
+			logger.LogSystemOperation(s.db, "shipment_delivered", "order", &shipment.OrderID, map[string]interface{}{
+				"order_no":    shipment.OrderNo,
+				"tracking_no": shipment.TrackingNo,
+				"provider":    provider,
+			})
+		}
+	}
+	return true, nil
+}
+
+// ShipmentForOrder 订单的物流跟踪记录与轨迹（按时间倒序），没有记录时返回 nil
+func (s *CarrierTrackingService) ShipmentForOrder(orderID uint) (*models.OrderShipment, error) {
+	if s == nil || s.db == nil {
+		return nil, nil
+	}
+	var shipment models.OrderShipment
+	err := s.db.Preload("Checkpoints", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurred_at DESC").Order("id DESC")
+	}).Where("order_id = ?", orderID).First(&shipment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func carrierTrackingCheckpointFingerprint(checkpoint *models.OrderShipmentCheckpoint) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		checkpoint.OccurredAt.UTC().Format(time.RFC3339),
+		string(checkpoint.Status),
+		checkpoint.Message,
+		checkpoint.Location,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncateCarrierTrackingText(s string, maxLen int) string {
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen])
+}
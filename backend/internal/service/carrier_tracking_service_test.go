@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+type fakeCarrierTrackingProvider struct {
+	registered []string
+	failFor    map[string]bool
+}
+
+func (p *fakeCarrierTrackingProvider) Register(ctx context.Context, shipment *models.OrderShipment) (string, error) {
+	if p.failFor[shipment.TrackingNo] {
+		return "", errors.New("carrier not detected")
+	}
+	p.registered = append(p.registered, shipment.TrackingNo)
+	return "3011", nil
+}
+
+func (p *fakeCarrierTrackingProvider) ParseWebhook(header http.Header, body []byte) ([]CarrierTrackingUpdate, error) {
+	return nil, nil
+}
+
+func newCarrierTrackingTestService(t *testing.T, provider string) (*CarrierTrackingService, *gorm.DB) {
+	t.Helper()
+	db := openOrderAutoCompleteTestDB(t)
+	if err := db.AutoMigrate(&models.OrderShipment{}, &models.OrderShipmentCheckpoint{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate shipments failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Order.CarrierTracking = config.CarrierTrackingConfig{
+		Enabled:       true,
+		Provider:      provider,
+		APIKey:        "api-key",
+		WebhookSecret: "hook-secret",
+	}
+	return NewCarrierTrackingService(db, cfg), db
+}
+
+func TestCarrierTrackingRegistersShipmentsAndRetriesFailures(t *testing.T) {
+	svc, db := newCarrierTrackingTestService(t, CarrierTrackingProvider17Track)
+	fake := &fakeCarrierTrackingProvider{failFor: map[string]bool{"BAD-1": true}}
+	svc.newProvider = func(config.CarrierTrackingConfig) (carrierTrackingProvider, error) { return fake, nil }
+
+	good := createShippedOrderForAutoComplete(t, db, "ORD-CT-GOOD", models.NowFunc())
+	good.TrackingNo = "RR123456789CN"
+	bad := createShippedOrderForAutoComplete(t, db, "ORD-CT-BAD", models.NowFunc())
+	bad.TrackingNo = "BAD-1"
+	for _, order := range []*models.Order{good, bad} {
+		if err := svc.TrackShipment(order); err != nil {
+			t.Fatalf("track shipment failed: %v", err)
+		}
+	}
+	// 重复登记同一单号不产生新记录
+	if err := svc.TrackShipment(good); err != nil {
+		t.Fatalf("track shipment again failed: %v", err)
+	}
+
+	if err := svc.RegisterPending(context.Background()); err == nil {
+		t.Fatalf("expected register run to report the failed tracking number")
+	}
+	var shipments []models.OrderShipment
+	if err := db.Order("id ASC").Find(&shipments).Error; err != nil {
+		t.Fatalf("load shipments: %v", err)
+	}
+	if len(shipments) != 2 {
+		t.Fatalf("expected 2 shipments, got %d", len(shipments))
+	}
+	if shipments[0].RegisteredAt == nil || shipments[0].Carrier != "3011" || shipments[0].RegisterAttempts != 1 {
+		t.Fatalf("expected good shipment registered with detected carrier, got %+v", shipments[0])
+	}
+	if shipments[1].RegisteredAt != nil || shipments[1].RegisterAttempts != 1 || shipments[1].RegisterError == "" {
+		t.Fatalf("expected bad shipment to record the failure, got %+v", shipments[1])
+	}
+
+	// 已注册的单号不再重复注册，失败的单号达到上限后停止重试
+	for i := 0; i < carrierTrackingMaxRegisterAttempts; i++ {
+		_ = svc.RegisterPending(context.Background())
+	}
+	if len(fake.registered) != 1 {
+		t.Fatalf("expected good shipment to register once, got %v", fake.registered)
+	}
+	var failed models.OrderShipment
+	if err := db.First(&failed, shipments[1].ID).Error; err != nil {
+		t.Fatalf("reload shipment: %v", err)
+	}
+	if failed.RegisterAttempts != carrierTrackingMaxRegisterAttempts {
+		t.Fatalf("expected retries to stop at %d, got %d", carrierTrackingMaxRegisterAttempts, failed.RegisterAttempts)
+	}
+}
+
+func TestCarrierTracking17TrackWebhookStoresCheckpointsOnce(t *testing.T) {
+	svc, db := newCarrierTrackingTestService(t, CarrierTrackingProvider17Track)
+	order := createShippedOrderForAutoComplete(t, db, "ORD-CT-17", models.NowFunc())
+	order.TrackingNo = "RR123456789CN"
+	if err := svc.TrackShipment(order); err != nil {
+		t.Fatalf("track shipment failed: %v", err)
+	}
+
+	body := []byte(`{"event":"TRACKING_UPDATED","data":{"number":"RR123456789CN","carrier":3011,"track_info":{
+		"latest_status":{"status":"Delivered"},
+		"tracking":{"providers":[{"events":[
+			{"time_utc":"2026-10-02T08:00:00Z","description":"Delivered, front door","location":"Berlin","stage":"Delivered"},
+			{"time_utc":"2026-10-01T06:30:00Z","description":"Out for delivery","location":"Berlin","stage":"OutForDelivery"},
+			{"time_utc":"2026-09-28T12:00:00Z","description":"Accepted by carrier","location":"Shenzhen","stage":"PickedUp"}
+		]}]}}}}`)
+	sum := sha256.Sum256(append(append([]byte{}, body...), []byte("/hook-secret")...))
+	header := http.Header{}
+	header.Set("sign", "bad")
+	if _, err := svc.HandleWebhook(header, body); !errors.Is(err, ErrCarrierTrackingSignatureInvalid) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+
+	header.Set("sign", hex.EncodeToString(sum[:]))
+	for i := 0; i < 2; i++ {
+		updated, err := svc.HandleWebhook(header, body)
+		if err != nil || updated != 1 {
+			t.Fatalf("webhook failed: updated=%d err=%v", updated, err)
+		}
+	}
+
+	shipment, err := svc.ShipmentForOrder(order.ID)
+	if err != nil || shipment == nil {
+		t.Fatalf("load shipment: %+v err=%v", shipment, err)
+	}
+	if shipment.Status != models.ShipmentStatusDelivered || shipment.DeliveredAt == nil ||
+		!shipment.DeliveredAt.Equal(time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected delivered at the delivered checkpoint, got %+v", shipment)
+	}
+	if len(shipment.Checkpoints) != 3 || shipment.Checkpoints[0].Message != "Delivered, front door" ||
+		shipment.Checkpoints[2].Status != models.ShipmentStatusInTransit {
+		t.Fatalf("expected 3 deduplicated checkpoints newest first, got %+v", shipment.Checkpoints)
+	}
+
+	var events int64
+	db.Model(&models.OrderEvent{}).Where("order_id = ? AND type = ?", order.ID, models.OrderEventTypeShipment).Count(&events)
+	if events != 1 {
+		t.Fatalf("expected one shipment timeline event for the status change, got %d", events)
+	}
+}
+
+func TestCarrierTrackingAfterShipWebhookClearsDeliveryOnReturn(t *testing.T) {
+	svc, db := newCarrierTrackingTestService(t, CarrierTrackingProviderAfterShip)
+	order := createShippedOrderForAutoComplete(t, db, "ORD-CT-AS", models.NowFunc())
+	order.TrackingNo = "1Z999AA10123456784"
+	if err := svc.TrackShipment(order); err != nil {
+		t.Fatalf("track shipment failed: %v", err)
+	}
+
+	send := func(body string) {
+		t.Helper()
+		mac := hmac.New(sha256.New, []byte("hook-secret"))
+		mac.Write([]byte(body))
+		header := http.Header{}
+		header.Set("aftership-hmac-sha256", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		if _, err := svc.HandleWebhook(header, []byte(body)); err != nil {
+			t.Fatalf("webhook failed: %v", err)
+		}
+	}
+
+	send(`{"event":"tracking_update","msg":{"tracking_number":"1Z999AA10123456784","slug":"ups","tag":"Delivered",
+		"checkpoints":[{"checkpoint_time":"2026-10-05T10:00:00+02:00","message":"Delivered","location":"Paris","tag":"Delivered"}]}}`)
+	shipment, _ := svc.ShipmentForOrder(order.ID)
+	if shipment.Carrier != "ups" || shipment.DeliveredAt == nil || !shipment.DeliveredAt.Equal(time.Date(2026, 10, 5, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected delivered ups shipment, got %+v", shipment)
+	}
+
+	send(`{"event":"tracking_update","msg":{"tracking_number":"1Z999AA10123456784","slug":"ups","tag":"Exception",
+		"checkpoints":[{"checkpoint_time":"2026-10-06T09:00:00","message":"Returned to sender","tag":"Exception"}]}}`)
+	shipment, _ = svc.ShipmentForOrder(order.ID)
+	if shipment.Status != models.ShipmentStatusException || shipment.DeliveredAt != nil || len(shipment.Checkpoints) != 2 {
+		t.Fatalf("expected return to clear the delivery time, got %+v", shipment)
+	}
+}
+
+func TestOrderAutoCompleteCompletesDeliveredOrders(t *testing.T) {
+	svc, db := newCarrierTrackingTestService(t, CarrierTrackingProvider17Track)
+	svc.cfg.Order.CarrierTracking.CompleteAfterDeliveryDays = 3
+
+	now := models.NowFunc()
+	daysAgo := func(days int) *time.Time {
+		at := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &at
+	}
+	delivered := createShippedOrderForAutoComplete(t, db, "ORD-CT-DELIVERED", now.Add(-5*24*time.Hour))
+	recent := createShippedOrderForAutoComplete(t, db, "ORD-CT-RECENT", now.Add(-5*24*time.Hour))
+	inTransit := createShippedOrderForAutoComplete(t, db, "ORD-CT-TRANSIT", now.Add(-5*24*time.Hour))
+	for _, item := range []struct {
+		order       *models.Order
+		status      models.ShipmentStatus
+		deliveredAt *time.Time
+	}{
+		{delivered, models.ShipmentStatusDelivered, daysAgo(4)},
+		{recent, models.ShipmentStatusDelivered, daysAgo(1)},
+		{inTransit, models.ShipmentStatusInTransit, nil},
+	} {
+		if err := db.Create(&models.OrderShipment{
+			OrderID: item.order.ID, OrderNo: item.order.OrderNo, TrackingNo: item.order.OrderNo,
+			Provider: CarrierTrackingProvider17Track, Status: item.status, DeliveredAt: item.deliveredAt,
+		}).Error; err != nil {
+			t.Fatalf("create shipment: %v", err)
+		}
+	}
+
+	// 未设置发货后自动完成天数时，签收后自动完成仍然生效
+	if err := NewOrderAutoCompleteService(db, svc.cfg, nil, nil).runOnce(); err != nil {
+		t.Fatalf("auto-complete run failed: %v", err)
+	}
+
+	for _, item := range []struct {
+		order *models.Order
+		want  models.OrderStatus
+	}{
+		{delivered, models.OrderStatusCompleted},
+		{recent, models.OrderStatusShipped},
+		{inTransit, models.OrderStatusShipped},
+	} {
+		var got models.Order
+		if err := db.First(&got, item.order.ID).Error; err != nil {
+			t.Fatalf("reload order: %v", err)
+		}
+		if got.Status != item.want {
+			t.Fatalf("order %s: expected %s, got %s", got.OrderNo, item.want, got.Status)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxCODReferenceLength     = 255
+	maxCODVoidReasonLength    = 500
+	codOrderAdminRemarkFormat = "[COD] Collect %s %s on delivery"
+)
+
+var (
+	ErrCODCollectionNotFound = errors.New("cod collection not found")
+	ErrCODOrderNotFound      = errors.New("order not found")
+)
+
+// CODEligibility 订单能否使用货到付款；不符合时 reason_key / params 为前端可翻译的原因
+type CODEligibility struct {
+	Eligible    bool                   `json:"eligible"`
+	AmountMinor int64                  `json:"amount_minor"`
+	Currency    string                 `json:"currency"`
+	ReasonKey   string                 `json:"reason_key,omitempty"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+}
+
+// CODAgingBucket 待收款账龄区间（按下单天数）
+type CODAgingBucket struct {
+	Label       string `json:"label"` // 0-7 / 8-30 / 31+
+	Count       int64  `json:"count"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// CODBalance 单一币种的货到付款余额
+type CODBalance struct {
+	Currency         string           `json:"currency"`
+	OutstandingCount int64            `json:"outstanding_count"`
+	OutstandingMinor int64            `json:"outstanding_minor"` // 待收款合计
+	CollectedCount   int64            `json:"collected_count"`
+	CollectedMinor   int64            `json:"collected_minor"` // 实收合计
+	ShortfallMinor   int64            `json:"shortfall_minor"` // 已收款记录中实收少于应收的差额合计
+	Aging            []CODAgingBucket `json:"aging"`
+}
+
+// CODService 货到付款：符合条件的实物订单跳过在线付款直接进入发货流程，
+// 每笔订单生成一条代收记录，签收后由管理员登记实收金额，未收款余额按币种汇总供对账
+type CODService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	orderService *OrderService
+}
+
+// NewCODService 创建货到付款服务
+func NewCODService(db *gorm.DB, cfg *config.Config, orderService *OrderService) *CODService {
+	return &CODService{db: db, cfg: cfg, orderService: orderService}
+}
+
+func (s *CODService) settings() config.CashOnDeliveryConfig {
+	if s.cfg == nil {
+		return config.CashOnDeliveryConfig{}
+	}
+	return s.cfg.Order.CashOnDelivery
+}
+
+func codDisabledError() error {
+	return bizerr.New("cod.disabled", "Cash on delivery is not available")
+}
+
+// checkEligibility 校验订单是否满足货到付款条件：待付款、包含实物商品、收货国家与金额在允许范围内
+func (s *CODService) checkEligibility(order *models.Order) error {
+	settings := s.settings()
+	if !settings.Enabled {
+		return codDisabledError()
+	}
+	if order.Status != models.OrderStatusPendingPayment {
+		return bizerr.Newf("cod.orderStatusInvalid", "Only orders awaiting payment can use cash on delivery (current status: %s)", order.Status).
+			WithParams(map[string]interface{}{"status": order.Status})
+	}
+	hasPhysical := false
+	for _, item := range order.Items {
+		if item.ProductType != models.ProductTypeVirtual {
+			hasPhysical = true
+			break
+		}
+	}
+	if !hasPhysical {
+		return bizerr.New("cod.virtualOnly", "Cash on delivery is only available for orders with physical items")
+	}
+	if len(settings.Countries) > 0 {
+		allowed := false
+		for _, country := range settings.Countries {
+			if strings.EqualFold(strings.TrimSpace(country), strings.TrimSpace(order.ReceiverCountry)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return bizerr.New("cod.countryNotEligible", "Cash on delivery is not available for this shipping country").
+				WithParams(map[string]interface{}{"country": order.ReceiverCountry})
+		}
+	}
+	if settings.MinOrderAmount > 0 && order.TotalAmount < settings.MinOrderAmount {
+		return bizerr.New("cod.amountBelowMinimum", "This order is below the minimum amount for cash on delivery").
+			WithParams(map[string]interface{}{"min": settings.MinOrderAmount, "currency": order.Currency})
+	}
+	if settings.MaxOrderAmount > 0 && order.TotalAmount > settings.MaxOrderAmount {
+		return bizerr.New("cod.amountAboveMaximum", "This order exceeds the maximum amount for cash on delivery").
+			WithParams(map[string]interface{}{"max": settings.MaxOrderAmount, "currency": order.Currency})
+	}
+	return nil
+}
+
+// Eligibility 用户订单的货到付款资格，用于展示付款选项
+func (s *CODService) Eligibility(userID uint, orderNo string) (*CODEligibility, error) {
+	var order models.Order
+	if err := s.db.Where("order_no = ? AND user_id = ?", orderNo, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCODOrderNotFound
+		}
+		return nil, err
+	}
+	result := &CODEligibility{Eligible: true, AmountMinor: order.TotalAmount, Currency: order.Currency}
+	if err := s.checkEligibility(&order); err != nil {
+		var bizErr *bizerr.Error
+		if !errors.As(err, &bizErr) {
+			return nil, err
+		}
+		result.Eligible = false
+		result.ReasonKey = bizErr.Key
+		result.Params = bizErr.Params
+	}
+	return result, nil
+}
+
+// PlaceOrderCOD 使用货到付款支付待付款订单：按已付款流程放行发货，并生成待收款记录
+func (s *CODService) PlaceOrderCOD(userID uint, orderNo string) (*models.CODCollection, error) {
+	if !s.settings().Enabled {
+		return nil, codDisabledError()
+	}
+
+	var (
+		order          *models.Order
+		collection     *models.CODCollection
+		finalizeResult *paidOrderFinalizeResult
+	)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var ref models.Order
+		if err := tx.Select("id").Where("order_no = ? AND user_id = ?", orderNo, userID).First(&ref).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCODOrderNotFound
+			}
+			return err
+		}
+		lockedOrder, err := repository.NewOrderRepository(tx).FindByIDForUpdate(tx, ref.ID)
+		if err != nil {
+			return err
+		}
+		order = lockedOrder
+		if err := s.checkEligibility(order); err != nil {
+			return err
+		}
+
+		collection = &models.CODCollection{
+			OrderID:         order.ID,
+			OrderNo:         order.OrderNo,
+			UserID:          userID,
+			ReceiverCountry: order.ReceiverCountry,
+			Currency:        order.Currency,
+			Amount:          order.TotalAmount,
+			Status:          models.CODCollectionStatusOutstanding,
+		}
+		if err := tx.Create(collection).Error; err != nil {
+			return err
+		}
+
+		finalizeResult, err = finalizePendingPaymentOrderTx(tx, order, s.orderService.virtualProductSvc, paidOrderFinalizeOptions{
+			AdminRemark:             fmt.Sprintf(codOrderAdminRemarkFormat, money.MinorToString(order.TotalAmount), order.Currency),
+			StrictAutoDeliveryCheck: true,
+			Config:                  s.cfg,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if finalizeResult.VirtualDeliveryErr != nil {
+		fmt.Printf("Warning: Failed to deliver virtual products for COD order %s: %v\n", order.OrderNo, finalizeResult.VirtualDeliveryErr)
+	}
+	s.orderService.syncUserConsumptionStatusTransitionBestEffort(
+		order.UserID,
+		models.OrderStatusPendingPayment,
+		finalizeResult.FinalStatus,
+		order.TotalAmount,
+		"cash_on_delivery",
+	)
+	PublishOrderStatusChanged(s.orderService.OrderRepo, s.orderService.pluginManager, nil, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, map[string]interface{}{
+		"source":         "cash_on_delivery",
+		"trigger_action": "order.cash_on_delivery",
+		"payment_method": "cash_on_delivery",
+	})
+	if s.orderService.emailService != nil {
+		go s.orderService.emailService.SendOrderPaidEmail(order, finalizeResult.IsVirtualOnly)
+	}
+	return collection, nil
+}
+
+// ListCollections 货到付款代收记录列表，status 为空表示全部
+func (s *CODService) ListCollections(status, search string, page, limit int) ([]models.CODCollection, int64, error) {
+	query := s.db.Model(&models.CODCollection{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search = strings.TrimSpace(search); search != "" {
+		query = query.Where("order_no LIKE ?", "%"+search+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var collections []models.CODCollection
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&collections).Error; err != nil {
+		return nil, 0, err
+	}
+	return collections, total, nil
+}
+
+// ConfirmCollection 发货后登记实收金额；实收少于应收时差额计入短收，多收视为录入错误
+func (s *CODService) ConfirmCollection(id, adminID uint, collectedAmount int64, reference string) (*models.CODCollection, error) {
+	reference = strings.TrimSpace(reference)
+	if len([]rune(reference)) > maxCODReferenceLength {
+		return nil, bizerr.Newf("cod.referenceTooLong", "Collection reference cannot exceed %d characters", maxCODReferenceLength).
+			WithParams(map[string]interface{}{"max": maxCODReferenceLength})
+	}
+
+	var order models.Order
+	collection, err := s.closeCollection(id, func(tx *gorm.DB, collection *models.CODCollection) (map[string]interface{}, error) {
+		if collectedAmount < 0 || collectedAmount > collection.Amount {
+			return nil, bizerr.New("cod.collectedAmountInvalid", "Collected amount must be between zero and the amount due").
+				WithParams(map[string]interface{}{"max": collection.Amount, "currency": collection.Currency})
+		}
+		if err := tx.First(&order, collection.OrderID).Error; err != nil {
+			return nil, err
+		}
+		if order.Status != models.OrderStatusShipped && order.Status != models.OrderStatusCompleted {
+			return nil, bizerr.Newf("cod.orderNotShipped", "Cash can only be collected after the order has shipped (current status: %s)", order.Status).
+				WithParams(map[string]interface{}{"status": order.Status})
+		}
+		return map[string]interface{}{
+			"status":           models.CODCollectionStatusCollected,
+			"collected_amount": collectedAmount,
+			"collected_at":     models.NowFunc(),
+			"collected_by":     adminID,
+			"reference":        reference,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := newOrderEvent(&order, models.OrderEventTypePayment, "payment.cod_collected", map[string]interface{}{
+		"payment_method":         "cash_on_delivery",
+		"collected_amount_minor": collectedAmount,
+		"shortfall_minor":        collection.Amount - collectedAmount,
+		"reference":              reference,
+	})
+	event.OperatorType = models.OrderEventOperatorAdmin
+	event.OperatorID = &adminID
+	recordOrderEventDB(s.db, event)
+	return collection, nil
+}
+
+// VoidCollection 作废代收记录（拒收、退回或订单取消），不再计入待收款余额
+func (s *CODService) VoidCollection(id uint, reason string) (*models.CODCollection, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxCODVoidReasonLength {
+		return nil, bizerr.Newf("cod.voidReasonInvalid", "A void reason is required and cannot exceed %d characters", maxCODVoidReasonLength).
+			WithParams(map[string]interface{}{"max": maxCODVoidReasonLength})
+	}
+	return s.closeCollection(id, func(*gorm.DB, *models.CODCollection) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"status":      models.CODCollectionStatusVoid,
+			"voided_at":   models.NowFunc(),
+			"void_reason": reason,
+		}, nil
+	})
+}
+
+func (s *CODService) closeCollection(id uint, buildUpdates func(tx *gorm.DB, collection *models.CODCollection) (map[string]interface{}, error)) (*models.CODCollection, error) {
+	var collection models.CODCollection
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.CODCollection{}, "id = ?", id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCODCollectionNotFound
+			}
+			return err
+		}
+		if err := tx.First(&collection, id).Error; err != nil {
+			return err
+		}
+		if collection.Status != models.CODCollectionStatusOutstanding {
+			return bizerr.Newf("cod.collectionNotOutstanding", "This collection is already %s", collection.Status).
+				WithParams(map[string]interface{}{"status": collection.Status})
+		}
+		updates, err := buildUpdates(tx, &collection)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&collection).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.First(&collection, id).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// Report 按币种汇总待收款余额、账龄与已收款短收
+func (s *CODService) Report(now time.Time) ([]CODBalance, error) {
+	var collections []models.CODCollection
+	if err := s.db.Where("status IN ?", []models.CODCollectionStatus{models.CODCollectionStatusOutstanding, models.CODCollectionStatusCollected}).
+		Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*CODBalance)
+	for _, collection := range collections {
+		balance, ok := balances[collection.Currency]
+		if !ok {
+			balance = &CODBalance{
+				Currency: collection.Currency,
+				Aging:    []CODAgingBucket{{Label: "0-7"}, {Label: "8-30"}, {Label: "31+"}},
+			}
+			balances[collection.Currency] = balance
+		}
+		if collection.Status == models.CODCollectionStatusCollected {
+			collected := int64(0)
+			if collection.CollectedAmount != nil {
+				collected = *collection.CollectedAmount
+			}
+			balance.CollectedCount++
+			balance.CollectedMinor += collected
+			balance.ShortfallMinor += collection.Amount - collected
+			continue
+		}
+		balance.OutstandingCount++
+		balance.OutstandingMinor += collection.Amount
+		bucket := 2
+		switch days := int(now.Sub(collection.CreatedAt).Hours() / 24); {
+		case days <= 7:
+			bucket = 0
+		case days <= 30:
+			bucket = 1
+		}
+		balance.Aging[bucket].Count++
+		balance.Aging[bucket].AmountMinor += collection.Amount
+	}
+
+	report := make([]CODBalance, 0, len(balances))
+	for _, balance := range balances {
+		report = append(report, *balance)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Currency < report[j].Currency })
+	return report, nil
+}
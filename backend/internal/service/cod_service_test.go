@@ -0,0 +1,149 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func newCODTestService(t *testing.T) (*CODService, *models.User) {
+	t.Helper()
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.CODCollection{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate cod tables failed: %v", err)
+	}
+	orderSvc.cfg.Order.CashOnDelivery.Enabled = true
+	orderSvc.cfg.Order.CashOnDelivery.Countries = []string{"CN", "HK"}
+	orderSvc.cfg.Order.CashOnDelivery.MinOrderAmount = 1000
+	orderSvc.cfg.Order.CashOnDelivery.MaxOrderAmount = 100000
+
+	user := &models.User{UUID: "cod-user", Email: "cod@example.com", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	return NewCODService(db, orderSvc.cfg, orderSvc), user
+}
+
+func createCODTestOrder(t *testing.T, svc *CODService, userID uint, orderNo, country string, amount int64, productType models.ProductType) *models.Order {
+	t.Helper()
+	order := &models.Order{
+		OrderNo:         orderNo,
+		UserID:          &userID,
+		Status:          models.OrderStatusPendingPayment,
+		Currency:        "CNY",
+		TotalAmount:     amount,
+		ReceiverName:    "Buyer",
+		ReceiverAddress: "1 Market St",
+		ReceiverCountry: country,
+		Items:           []models.OrderItem{{SKU: "COD-1", Name: "Kettle", Quantity: 1, ProductType: productType}},
+	}
+	if err := svc.db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	return order
+}
+
+func TestCODEligibilityChecksCountryAmountAndItems(t *testing.T) {
+	svc, user := newCODTestService(t)
+	createCODTestOrder(t, svc, user.ID, "COD-OK", "cn", 5000, models.ProductTypePhysical)
+	createCODTestOrder(t, svc, user.ID, "COD-US", "US", 5000, models.ProductTypePhysical)
+	createCODTestOrder(t, svc, user.ID, "COD-LOW", "CN", 500, models.ProductTypePhysical)
+	createCODTestOrder(t, svc, user.ID, "COD-HIGH", "CN", 200000, models.ProductTypePhysical)
+	createCODTestOrder(t, svc, user.ID, "COD-VIRTUAL", "CN", 5000, models.ProductTypeVirtual)
+
+	cases := map[string]string{
+		"COD-OK":      "",
+		"COD-US":      "cod.countryNotEligible",
+		"COD-LOW":     "cod.amountBelowMinimum",
+		"COD-HIGH":    "cod.amountAboveMaximum",
+		"COD-VIRTUAL": "cod.virtualOnly",
+	}
+	for orderNo, reason := range cases {
+		eligibility, err := svc.Eligibility(user.ID, orderNo)
+		if err != nil {
+			t.Fatalf("eligibility for %s failed: %v", orderNo, err)
+		}
+		if eligibility.Eligible != (reason == "") || eligibility.ReasonKey != reason {
+			t.Fatalf("expected %s reason %q, got %+v", orderNo, reason, eligibility)
+		}
+	}
+
+	_, err := svc.PlaceOrderCOD(user.ID, "COD-US")
+	requireOrderBizErr(t, err, "cod.countryNotEligible")
+
+	svc.cfg.Order.CashOnDelivery.Enabled = false
+	_, err = svc.PlaceOrderCOD(user.ID, "COD-OK")
+	requireOrderBizErr(t, err, "cod.disabled")
+}
+
+func TestCODCollectionConfirmAfterShipmentAndReport(t *testing.T) {
+	svc, user := newCODTestService(t)
+	order := createCODTestOrder(t, svc, user.ID, "COD-1", "CN", 8000, models.ProductTypePhysical)
+	createCODTestOrder(t, svc, user.ID, "COD-2", "HK", 3000, models.ProductTypePhysical)
+
+	collection, err := svc.PlaceOrderCOD(user.ID, "COD-1")
+	if err != nil {
+		t.Fatalf("place cod order failed: %v", err)
+	}
+	if collection.Amount != 8000 || collection.Status != models.CODCollectionStatusOutstanding {
+		t.Fatalf("unexpected collection: %+v", collection)
+	}
+	var placed models.Order
+	if err := svc.db.First(&placed, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if placed.Status == models.OrderStatusPendingPayment {
+		t.Fatalf("expected cod order to leave pending payment")
+	}
+	if _, err := svc.PlaceOrderCOD(user.ID, "COD-1"); err == nil {
+		t.Fatalf("expected second cod placement to be rejected")
+	} else {
+		requireOrderBizErr(t, err, "cod.orderStatusInvalid")
+	}
+	second, err := svc.PlaceOrderCOD(user.ID, "COD-2")
+	if err != nil {
+		t.Fatalf("place second cod order failed: %v", err)
+	}
+
+	_, err = svc.ConfirmCollection(collection.ID, 1, 8000, "")
+	requireOrderBizErr(t, err, "cod.orderNotShipped")
+
+	if err := svc.db.Model(&models.Order{}).Where("id = ?", order.ID).Update("status", models.OrderStatusShipped).Error; err != nil {
+		t.Fatalf("mark shipped failed: %v", err)
+	}
+	_, err = svc.ConfirmCollection(collection.ID, 1, 9000, "")
+	requireOrderBizErr(t, err, "cod.collectedAmountInvalid")
+
+	confirmed, err := svc.ConfirmCollection(collection.ID, 1, 7500, "SF-123")
+	if err != nil {
+		t.Fatalf("confirm collection failed: %v", err)
+	}
+	if confirmed.Status != models.CODCollectionStatusCollected || confirmed.CollectedAmount == nil || *confirmed.CollectedAmount != 7500 {
+		t.Fatalf("unexpected confirmed collection: %+v", confirmed)
+	}
+	_, err = svc.VoidCollection(collection.ID, "returned")
+	requireOrderBizErr(t, err, "cod.collectionNotOutstanding")
+
+	var events int64
+	svc.db.Model(&models.OrderEvent{}).Where("order_id = ? AND source = ?", order.ID, "payment.cod_collected").Count(&events)
+	if events != 1 {
+		t.Fatalf("expected one cod collected timeline event, got %d", events)
+	}
+
+	svc.db.Model(&models.CODCollection{}).Where("id = ?", second.ID).Update("created_at", time.Now().AddDate(0, 0, -10))
+	report, err := svc.Report(time.Now())
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	if len(report) != 1 {
+		t.Fatalf("expected one currency balance, got %+v", report)
+	}
+	balance := report[0]
+	if balance.OutstandingMinor != 3000 || balance.CollectedMinor != 7500 || balance.ShortfallMinor != 500 {
+		t.Fatalf("unexpected balance: %+v", balance)
+	}
+	if balance.Aging[1].Count != 1 || balance.Aging[1].AmountMinor != 3000 {
+		t.Fatalf("expected outstanding collection in 8-30 bucket, got %+v", balance.Aging)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -122,6 +123,9 @@ func openPerfServiceTestDB(t *testing.T, name string, migrations ...interface{})
 		&models.Inventory{},
 		&models.ProductInventoryBinding{},
 		&models.UserPurchaseStat{},
+		&models.OrganizationMember{},
+		&models.PolicyVersion{},
+		&models.PolicyConsent{},
 	}
 	allMigrations = append(allMigrations, migrations...)
 	if err := db.AutoMigrate(allMigrations...); err != nil {
@@ -272,7 +276,7 @@ func profileCreateUserOrder(t *testing.T, concurrency int, totalOps int) concurr
 	}}
 
 	result := runConcurrencyProfile(totalOps, concurrency, func(opIndex int) error {
-		_, err := svc.CreateUserOrder(users[opIndex].ID, items, "", "")
+		_, err := svc.CreateUserOrder(context.Background(), users[opIndex].ID, items, "", "", "")
 		return err
 	})
 	result.Workload = "create_user_order"
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -45,6 +46,9 @@ func openConcurrentServiceTestDB(t *testing.T, migrations ...interface{}) *gorm.
 		&models.Inventory{},
 		&models.ProductInventoryBinding{},
 		&models.UserPurchaseStat{},
+		&models.OrganizationMember{},
+		&models.PolicyVersion{},
+		&models.PolicyConsent{},
 	}
 	allMigrations = append(allMigrations, migrations...)
 
@@ -178,7 +182,7 @@ func TestCreateUserOrderPendingLimitEnforcedAcrossServiceInstances(t *testing.T)
 		go func(current *OrderService) {
 			defer wg.Done()
 			<-start
-			_, err := current.CreateUserOrder(user.ID, items, "", "")
+			_, err := current.CreateUserOrder(context.Background(), user.ID, items, "", "", "")
 			results <- result{err: err}
 		}(svc)
 	}
@@ -272,7 +276,7 @@ func TestCreateUserOrderPurchaseLimitEnforcedAcrossServiceInstances(t *testing.T
 		go func(current *OrderService) {
 			defer wg.Done()
 			<-start
-			_, err := current.CreateUserOrder(user.ID, items, "", "")
+			_, err := current.CreateUserOrder(context.Background(), user.ID, items, "", "", "")
 			results <- result{err: err}
 		}(svc)
 	}
@@ -0,0 +1,509 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	moderationRuleCacheTTL      = 30 * time.Second
+	maxModerationRulePatternLen = 255
+	// ModerationRemovedPlaceholder 审核不通过的内容在来源处替换为该文本
+	ModerationRemovedPlaceholder = "[Removed by moderator]"
+)
+
+var (
+	ErrModerationRuleNotFound = errors.New("moderation rule not found")
+	ErrModerationCaseNotFound = errors.New("moderation case not found")
+)
+
+// ModerationVerdict 单段文本的审核结果
+type ModerationVerdict struct {
+	Source   models.ModerationSource
+	UserID   *uint
+	Original string
+	Text     string // 实际保存的文本，打码时为打码后的内容
+	Action   models.ModerationAction
+	Provider string
+	Matches  []string
+	Labels   []string
+}
+
+// NeedsReview 是否需要进入人工审核队列
+func (v *ModerationVerdict) NeedsReview() bool {
+	return v != nil && (v.Action == models.ModerationActionFlag || v.Action == models.ModerationActionMask)
+}
+
+type compiledModerationRule struct {
+	rule    models.ModerationRule
+	pattern *regexp.Regexp
+}
+
+// ContentModerationService 用户提交文本的内容审核：先匹配词库规则，再调用可选的外部审核接口
+// 被拒绝的提交直接返回业务错误，被标记或打码的内容保存后进入管理后台审核队列
+type ContentModerationService struct {
+	db         *gorm.DB
+	cfg        *config.Config
+	httpClient *http.Client
+
+	mu       sync.RWMutex
+	rules    []compiledModerationRule
+	loadedAt time.Time
+}
+
+// NewContentModerationService 创建内容审核服务
+func NewContentModerationService(db *gorm.DB, cfg *config.Config) *ContentModerationService {
+	return &ContentModerationService{db: db, cfg: cfg, httpClient: &http.Client{}}
+}
+
+func (s *ContentModerationService) enabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Security.Moderation.Enabled
+}
+
+func compileModerationRule(rule models.ModerationRule) (*regexp.Regexp, error) {
+	if rule.MatchType == models.ModerationMatchRegex {
+		return regexp.Compile("(?i)" + rule.Pattern)
+	}
+	return regexp.Compile("(?i)" + regexp.QuoteMeta(rule.Pattern))
+}
+
+func (s *ContentModerationService) invalidateRules() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *ContentModerationService) activeRules() ([]compiledModerationRule, error) {
+	now := time.Now()
+	s.mu.RLock()
+	if !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < moderationRuleCacheTTL {
+		rules := s.rules
+		s.mu.RUnlock()
+		return rules, nil
+	}
+	s.mu.RUnlock()
+
+	var rules []models.ModerationRule
+	if err := s.db.Where("is_active = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	compiled := make([]compiledModerationRule, 0, len(rules))
+	for _, rule := range rules {
+		pattern, err := compileModerationRule(rule)
+		if err != nil {
+			// 保存时已校验，这里仅跳过被直接改库的坏规则
+			log.Printf("moderation: skip invalid rule id=%d: %v", rule.ID, err)
+			continue
+		}
+		compiled = append(compiled, compiledModerationRule{rule: rule, pattern: pattern})
+	}
+
+	s.mu.Lock()
+	s.rules = compiled
+	s.loadedAt = now
+	s.mu.Unlock()
+	return compiled, nil
+}
+
+func maskModerationMatch(match string) string {
+	return strings.Repeat("*", len([]rune(match)))
+}
+
+// applyModerationRules 按词库规则审核，多条规则命中时取最严重的处理方式；命中打码规则的片段总会被打码
+func applyModerationRules(rules []compiledModerationRule, verdict *ModerationVerdict) {
+	for _, item := range rules {
+		if !item.rule.AppliesTo(verdict.Source) {
+			continue
+		}
+		matches := item.pattern.FindAllString(verdict.Text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		verdict.Matches = appendUniqueStrings(verdict.Matches, matches...)
+		if item.rule.Action == models.ModerationActionMask {
+			verdict.Text = item.pattern.ReplaceAllStringFunc(verdict.Text, maskModerationMatch)
+		}
+		if item.rule.Action.Severity() > verdict.Action.Severity() {
+			verdict.Action = item.rule.Action
+			verdict.Provider = "rules"
+		}
+	}
+}
+
+func appendUniqueStrings(list []string, values ...string) []string {
+	for _, value := range values {
+		exists := false
+		for _, item := range list {
+			if strings.EqualFold(item, value) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+type externalModerationResponse struct {
+	Action     string   `json:"action"`
+	Labels     []string `json:"labels"`
+	MaskedText string   `json:"masked_text"`
+}
+
+func (s *ContentModerationService) callExternal(source models.ModerationSource, text string) (*externalModerationResponse, error) {
+	external := s.cfg.Security.Moderation.External
+	body, err := json.Marshal(map[string]string{"text": text, "source": string(source)})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, external.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if external.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+external.APIKey)
+	}
+	client := *s.httpClient
+	client.Timeout = time.Duration(external.TimeoutMs) * time.Millisecond
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("external moderation returned status %d", resp.StatusCode)
+	}
+	var result externalModerationResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *ContentModerationService) applyExternal(verdict *ModerationVerdict) {
+	result, err := s.callExternal(verdict.Source, verdict.Text)
+	if err != nil {
+		log.Printf("moderation: external check failed, source=%s: %v", verdict.Source, err)
+		if s.cfg.Security.Moderation.External.OnError == "flag" && verdict.Action.Severity() < models.ModerationActionFlag.Severity() {
+			verdict.Action = models.ModerationActionFlag
+			verdict.Provider = "external"
+			verdict.Labels = appendUniqueStrings(verdict.Labels, "external_unavailable")
+		}
+		return
+	}
+	action := models.ModerationAction(strings.ToLower(strings.TrimSpace(result.Action)))
+	switch action {
+	case models.ModerationActionMask, models.ModerationActionFlag, models.ModerationActionReject:
+	default:
+		return
+	}
+	verdict.Labels = appendUniqueStrings(verdict.Labels, result.Labels...)
+	if action == models.ModerationActionMask && strings.TrimSpace(result.MaskedText) != "" {
+		verdict.Text = result.MaskedText
+	}
+	if action.Severity() > verdict.Action.Severity() {
+		verdict.Action = action
+		verdict.Provider = "external"
+	}
+}
+
+// Screen 审核一段用户提交的文本。命中拒绝时记录留档并返回业务错误；
+// 否则返回审核结果，调用方保存 verdict.Text，并在拿到来源 ID 后调用 RecordModerationVerdictTx 登记待审核内容
+// 服务为空或未开启审核时原样放行
+func (s *ContentModerationService) Screen(source models.ModerationSource, userID *uint, text string) (*ModerationVerdict, error) {
+	verdict := &ModerationVerdict{
+		Source:   source,
+		UserID:   userID,
+		Original: text,
+		Text:     text,
+		Action:   models.ModerationActionAllow,
+	}
+	if !s.enabled() || strings.TrimSpace(text) == "" {
+		return verdict, nil
+	}
+
+	rules, err := s.activeRules()
+	if err != nil {
+		return nil, err
+	}
+	applyModerationRules(rules, verdict)
+	if verdict.Action != models.ModerationActionReject && s.cfg.Security.Moderation.External.Enabled {
+		s.applyExternal(verdict)
+	}
+
+	if verdict.Action == models.ModerationActionReject {
+		record := buildModerationCase(verdict, 0, models.ModerationCaseStatusRejected)
+		if err := s.db.Create(record).Error; err != nil {
+			log.Printf("moderation: failed to record rejected submission, source=%s: %v", source, err)
+		}
+		return verdict, bizerr.New("moderation.rejected", "This content cannot be submitted because it violates the content policy")
+	}
+	return verdict, nil
+}
+
+func buildModerationCase(verdict *ModerationVerdict, sourceID uint, status models.ModerationCaseStatus) *models.ModerationCase {
+	return &models.ModerationCase{
+		Source:           verdict.Source,
+		SourceID:         sourceID,
+		UserID:           verdict.UserID,
+		Action:           verdict.Action,
+		Provider:         verdict.Provider,
+		Content:          verdict.Original,
+		ProcessedContent: verdict.Text,
+		Matches:          verdict.Matches,
+		Labels:           verdict.Labels,
+		Status:           status,
+	}
+}
+
+// RecordModerationVerdictTx 登记被标记或打码的内容，放在保存来源记录的同一事务中
+func RecordModerationVerdictTx(tx *gorm.DB, verdict *ModerationVerdict, sourceID uint) error {
+	if !verdict.NeedsReview() {
+		return nil
+	}
+	return tx.Create(buildModerationCase(verdict, sourceID, models.ModerationCaseStatusPending)).Error
+}
+
+// ModerationRuleInput 管理端创建/更新词库规则参数
+type ModerationRuleInput struct {
+	Pattern   string
+	MatchType models.ModerationMatchType
+	Action    models.ModerationAction
+	Sources   []string
+	Note      string
+	IsActive  bool
+}
+
+func validateModerationRuleInput(input *ModerationRuleInput) error {
+	input.Pattern = strings.TrimSpace(input.Pattern)
+	input.Note = strings.TrimSpace(input.Note)
+	if input.Pattern == "" || len([]rune(input.Pattern)) > maxModerationRulePatternLen {
+		return bizerr.Newf("moderation.patternInvalid", "Pattern is required and cannot exceed %d characters", maxModerationRulePatternLen).
+			WithParams(map[string]interface{}{"max": maxModerationRulePatternLen})
+	}
+	if input.MatchType == "" {
+		input.MatchType = models.ModerationMatchKeyword
+	}
+	switch input.MatchType {
+	case models.ModerationMatchKeyword, models.ModerationMatchRegex:
+	default:
+		return bizerr.Newf("moderation.matchTypeInvalid", "Invalid match type: %s", input.MatchType).
+			WithParams(map[string]interface{}{"type": string(input.MatchType)})
+	}
+	switch input.Action {
+	case models.ModerationActionMask, models.ModerationActionFlag, models.ModerationActionReject:
+	default:
+		return bizerr.Newf("moderation.actionInvalid", "Invalid moderation action: %s", input.Action).
+			WithParams(map[string]interface{}{"action": string(input.Action)})
+	}
+	if _, err := compileModerationRule(models.ModerationRule{Pattern: input.Pattern, MatchType: input.MatchType}); err != nil {
+		return bizerr.New("moderation.regexInvalid", "Invalid regular expression")
+	}
+	sources := make([]string, 0, len(input.Sources))
+	for _, raw := range input.Sources {
+		source := strings.TrimSpace(raw)
+		valid := false
+		for _, item := range models.ModerationSources {
+			if source == string(item) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return bizerr.Newf("moderation.sourceInvalid", "Invalid content source: %s", source).
+				WithParams(map[string]interface{}{"source": source})
+		}
+		sources = appendUniqueStrings(sources, source)
+	}
+	input.Sources = sources
+	return nil
+}
+
+// ListRules 词库规则列表
+func (s *ContentModerationService) ListRules() ([]models.ModerationRule, error) {
+	var rules []models.ModerationRule
+	if err := s.db.Order("id DESC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateRule 创建词库规则
+func (s *ContentModerationService) CreateRule(adminID uint, input ModerationRuleInput) (*models.ModerationRule, error) {
+	if err := validateModerationRuleInput(&input); err != nil {
+		return nil, err
+	}
+	rule := &models.ModerationRule{
+		Pattern:   input.Pattern,
+		MatchType: input.MatchType,
+		Action:    input.Action,
+		Sources:   input.Sources,
+		Note:      input.Note,
+		IsActive:  input.IsActive,
+		CreatedBy: adminID,
+	}
+	if err := s.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// UpdateRule 更新词库规则
+func (s *ContentModerationService) UpdateRule(id uint, input ModerationRuleInput) (*models.ModerationRule, error) {
+	if err := validateModerationRuleInput(&input); err != nil {
+		return nil, err
+	}
+	var rule models.ModerationRule
+	if err := s.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrModerationRuleNotFound
+		}
+		return nil, err
+	}
+	rule.Pattern = input.Pattern
+	rule.MatchType = input.MatchType
+	rule.Action = input.Action
+	rule.Sources = input.Sources
+	rule.Note = input.Note
+	rule.IsActive = input.IsActive
+	if err := s.db.Save(&rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return &rule, nil
+}
+
+// DeleteRule 删除词库规则
+func (s *ContentModerationService) DeleteRule(id uint) error {
+	result := s.db.Delete(&models.ModerationRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrModerationRuleNotFound
+	}
+	s.invalidateRules()
+	return nil
+}
+
+// ListCases 审核记录列表，默认按时间倒序
+func (s *ContentModerationService) ListCases(status, source string, page, limit int) ([]models.ModerationCase, int64, error) {
+	query := s.db.Model(&models.ModerationCase{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var cases []models.ModerationCase
+	if err := query.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "email", "name")
+	}).Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&cases).Error; err != nil {
+		return nil, 0, err
+	}
+	return cases, total, nil
+}
+
+// ReviewCase 人工审核：approve 保留内容，remove 从来源处移除内容
+func (s *ContentModerationService) ReviewCase(id, adminID uint, decision models.ModerationCaseStatus, note string) (*models.ModerationCase, error) {
+	if decision != models.ModerationCaseStatusApproved && decision != models.ModerationCaseStatusRemoved {
+		return nil, bizerr.New("moderation.decisionInvalid", "Decision must be approve or remove")
+	}
+	var record models.ModerationCase
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&record, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrModerationCaseNotFound
+			}
+			return err
+		}
+		if record.Status != models.ModerationCaseStatusPending {
+			return bizerr.New("moderation.caseAlreadyReviewed", "This item has already been reviewed")
+		}
+		if decision == models.ModerationCaseStatusRemoved {
+			if err := removeModeratedContentTx(tx, &record); err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		record.Status = decision
+		record.ReviewedBy = &adminID
+		record.ReviewedAt = &now
+		record.ReviewNote = strings.TrimSpace(note)
+		return tx.Save(&record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// removeModeratedContentTx 在来源记录中用占位文本替换被移除的内容
+func removeModeratedContentTx(tx *gorm.DB, record *models.ModerationCase) error {
+	switch record.Source {
+	case models.ModerationSourceOrderRemark:
+		var order models.Order
+		if err := tx.Select("id", "remark").First(&order, record.SourceID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		// 收货表单的备注追加在原备注之后，只替换被审核的那一段
+		remark := strings.Replace(order.Remark, record.ProcessedContent, ModerationRemovedPlaceholder, 1)
+		if remark == order.Remark {
+			remark = ModerationRemovedPlaceholder
+		}
+		return tx.Model(&models.Order{}).Where("id = ?", order.ID).Update("remark", remark).Error
+	case models.ModerationSourceTicketSubject:
+		return tx.Model(&models.Ticket{}).Where("id = ?", record.SourceID).Update("subject", ModerationRemovedPlaceholder).Error
+	case models.ModerationSourceTicketContent:
+		if err := tx.Model(&models.Ticket{}).Where("id = ?", record.SourceID).Updates(map[string]interface{}{
+			"content":              ModerationRemovedPlaceholder,
+			"last_message_preview": ModerationRemovedPlaceholder,
+		}).Error; err != nil {
+			return err
+		}
+		// 工单首条消息包含同样的正文
+		var first models.TicketMessage
+		if err := tx.Where("ticket_id = ? AND sender_type = ?", record.SourceID, "user").Order("id ASC").First(&first).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		return tx.Model(&first).Update("content", ModerationRemovedPlaceholder).Error
+	case models.ModerationSourceTicketMessage:
+		return tx.Model(&models.TicketMessage{}).Where("id = ?", record.SourceID).Update("content", ModerationRemovedPlaceholder).Error
+	}
+	return nil
+}
+
+// PendingCount 待审核数量
+func (s *ContentModerationService) PendingCount() (int64, error) {
+	var count int64
+	err := s.db.Model(&models.ModerationCase{}).Where("status = ?", models.ModerationCaseStatusPending).Count(&count).Error
+	return count, err
+}
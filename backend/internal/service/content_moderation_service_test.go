@@ -0,0 +1,223 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+func newModerationTestService(t *testing.T) (*ContentModerationService, *gorm.DB, *config.Config) {
+	t.Helper()
+
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.ModerationRule{}, &models.ModerationCase{}, &models.Ticket{}, &models.TicketMessage{}); err != nil {
+		t.Fatalf("auto migrate moderation tables failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Security.Moderation.Enabled = true
+	cfg.Security.Moderation.External.TimeoutMs = 1000
+	cfg.Security.Moderation.External.OnError = "allow"
+	return NewContentModerationService(db, cfg), db, cfg
+}
+
+func TestContentModerationRulesMaskFlagAndReject(t *testing.T) {
+	svc, db, _ := newModerationTestService(t)
+
+	_, err := svc.CreateRule(1, ModerationRuleInput{Pattern: "([a-z", MatchType: models.ModerationMatchRegex, Action: models.ModerationActionFlag, IsActive: true})
+	requireOrderBizErr(t, err, "moderation.regexInvalid")
+	_, err = svc.CreateRule(1, ModerationRuleInput{Pattern: "x", Action: "ban", IsActive: true})
+	requireOrderBizErr(t, err, "moderation.actionInvalid")
+	_, err = svc.CreateRule(1, ModerationRuleInput{Pattern: "x", Action: models.ModerationActionFlag, Sources: []string{"review"}, IsActive: true})
+	requireOrderBizErr(t, err, "moderation.sourceInvalid")
+
+	for _, input := range []ModerationRuleInput{
+		{Pattern: "darn", Action: models.ModerationActionMask, IsActive: true},
+		{Pattern: `\d{4}-\d{4}-\d{4}-\d{4}`, MatchType: models.ModerationMatchRegex, Action: models.ModerationActionFlag, Sources: []string{string(models.ModerationSourceTicketMessage)}, IsActive: true},
+		{Pattern: "scam link", Action: models.ModerationActionReject, IsActive: true},
+	} {
+		if _, err := svc.CreateRule(1, input); err != nil {
+			t.Fatalf("create rule %q failed: %v", input.Pattern, err)
+		}
+	}
+
+	userID := uint(7)
+	verdict, err := svc.Screen(models.ModerationSourceOrderRemark, &userID, "Leave it at the DARN door")
+	if err != nil {
+		t.Fatalf("screen masked remark failed: %v", err)
+	}
+	if verdict.Action != models.ModerationActionMask || verdict.Text != "Leave it at the **** door" {
+		t.Fatalf("expected masked remark, got action=%s text=%q", verdict.Action, verdict.Text)
+	}
+
+	// 卡号规则只对工单消息生效
+	verdict, err = svc.Screen(models.ModerationSourceOrderRemark, &userID, "card 1234-5678-9012-3456")
+	if err != nil || verdict.Action != models.ModerationActionAllow {
+		t.Fatalf("expected remark to pass source-scoped rule, got %+v err=%v", verdict, err)
+	}
+	verdict, err = svc.Screen(models.ModerationSourceTicketMessage, &userID, "card 1234-5678-9012-3456")
+	if err != nil || verdict.Action != models.ModerationActionFlag || !verdict.NeedsReview() {
+		t.Fatalf("expected ticket message to be flagged, got %+v err=%v", verdict, err)
+	}
+	if verdict.Text != verdict.Original {
+		t.Fatalf("flag must keep the original text, got %q", verdict.Text)
+	}
+
+	_, err = svc.Screen(models.ModerationSourceTicketContent, &userID, "click this Scam Link now")
+	requireOrderBizErr(t, err, "moderation.rejected")
+	var rejected models.ModerationCase
+	if err := db.Where("status = ?", models.ModerationCaseStatusRejected).First(&rejected).Error; err != nil {
+		t.Fatalf("expected rejected submission to be recorded: %v", err)
+	}
+	if rejected.UserID == nil || *rejected.UserID != userID || rejected.SourceID != 0 {
+		t.Fatalf("unexpected rejected case: %+v", rejected)
+	}
+	if count, _ := svc.PendingCount(); count != 0 {
+		t.Fatalf("rejected submissions must not enter the review queue, got %d", count)
+	}
+}
+
+func TestContentModerationDisabledPassesThrough(t *testing.T) {
+	svc, _, cfg := newModerationTestService(t)
+	if _, err := svc.CreateRule(1, ModerationRuleInput{Pattern: "blocked", Action: models.ModerationActionReject, IsActive: true}); err != nil {
+		t.Fatalf("create rule failed: %v", err)
+	}
+	cfg.Security.Moderation.Enabled = false
+
+	verdict, err := svc.Screen(models.ModerationSourceOrderRemark, nil, "blocked")
+	if err != nil || verdict.Action != models.ModerationActionAllow || verdict.Text != "blocked" {
+		t.Fatalf("expected disabled moderation to pass through, got %+v err=%v", verdict, err)
+	}
+
+	var nilService *ContentModerationService
+	verdict, err = nilService.Screen(models.ModerationSourceOrderRemark, nil, "blocked")
+	if err != nil || verdict.NeedsReview() {
+		t.Fatalf("expected nil service to pass through, got %+v err=%v", verdict, err)
+	}
+}
+
+func TestContentModerationExternalProvider(t *testing.T) {
+	svc, _, cfg := newModerationTestService(t)
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if body["text"] == "buy followers" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"action": "flag", "labels": []string{"spam"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"action": "allow"})
+	}))
+	defer server.Close()
+
+	cfg.Security.Moderation.External.Enabled = true
+	cfg.Security.Moderation.External.URL = server.URL
+	cfg.Security.Moderation.External.APIKey = "secret"
+
+	verdict, err := svc.Screen(models.ModerationSourceTicketMessage, nil, "buy followers")
+	if err != nil {
+		t.Fatalf("screen failed: %v", err)
+	}
+	if verdict.Action != models.ModerationActionFlag || verdict.Provider != "external" || len(verdict.Labels) != 1 || verdict.Labels[0] != "spam" {
+		t.Fatalf("expected external flag with spam label, got %+v", verdict)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Fatalf("expected bearer api key, got %q", gotAuth)
+	}
+
+	// 外部接口不可用时按 on_error 处理
+	server.Close()
+	verdict, err = svc.Screen(models.ModerationSourceTicketMessage, nil, "hello")
+	if err != nil || verdict.Action != models.ModerationActionAllow {
+		t.Fatalf("expected on_error=allow to pass, got %+v err=%v", verdict, err)
+	}
+	cfg.Security.Moderation.External.OnError = "flag"
+	verdict, err = svc.Screen(models.ModerationSourceTicketMessage, nil, "hello")
+	if err != nil || verdict.Action != models.ModerationActionFlag || verdict.Labels[0] != "external_unavailable" {
+		t.Fatalf("expected on_error=flag to queue the text, got %+v err=%v", verdict, err)
+	}
+}
+
+func TestContentModerationReviewRemovesContent(t *testing.T) {
+	svc, db, _ := newModerationTestService(t)
+	if _, err := svc.CreateRule(1, ModerationRuleInput{Pattern: "spam", Action: models.ModerationActionFlag, IsActive: true}); err != nil {
+		t.Fatalf("create rule failed: %v", err)
+	}
+
+	userID := uint(3)
+	verdict, err := svc.Screen(models.ModerationSourceTicketMessage, &userID, "this is spam")
+	if err != nil {
+		t.Fatalf("screen failed: %v", err)
+	}
+	message := &models.TicketMessage{TicketID: 1, SenderType: "user", SenderID: userID, Content: verdict.Text, ContentType: "text"}
+	if err := db.Create(message).Error; err != nil {
+		t.Fatalf("create message failed: %v", err)
+	}
+	if err := RecordModerationVerdictTx(db, verdict, message.ID); err != nil {
+		t.Fatalf("record verdict failed: %v", err)
+	}
+
+	remarkVerdict, err := svc.Screen(models.ModerationSourceOrderRemark, &userID, "more spam")
+	if err != nil {
+		t.Fatalf("screen remark failed: %v", err)
+	}
+	order := &models.Order{OrderNo: "MOD-1", UserID: &userID, Remark: "Ring twice\nmore spam"}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	if err := RecordModerationVerdictTx(db, remarkVerdict, order.ID); err != nil {
+		t.Fatalf("record remark verdict failed: %v", err)
+	}
+
+	cases, total, err := svc.ListCases(string(models.ModerationCaseStatusPending), "", 1, 20)
+	if err != nil || total != 2 {
+		t.Fatalf("expected 2 pending cases, got %d err=%v", total, err)
+	}
+
+	var messageCase, remarkCase models.ModerationCase
+	for _, item := range cases {
+		switch item.Source {
+		case models.ModerationSourceTicketMessage:
+			messageCase = item
+		case models.ModerationSourceOrderRemark:
+			remarkCase = item
+		}
+	}
+
+	_, err = svc.ReviewCase(messageCase.ID, 9, "ban", "")
+	requireOrderBizErr(t, err, "moderation.decisionInvalid")
+
+	reviewed, err := svc.ReviewCase(messageCase.ID, 9, models.ModerationCaseStatusRemoved, "abusive")
+	if err != nil {
+		t.Fatalf("review case failed: %v", err)
+	}
+	if reviewed.Status != models.ModerationCaseStatusRemoved || reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != 9 {
+		t.Fatalf("unexpected reviewed case: %+v", reviewed)
+	}
+	var storedMessage models.TicketMessage
+	db.First(&storedMessage, message.ID)
+	if storedMessage.Content != ModerationRemovedPlaceholder {
+		t.Fatalf("expected message content to be removed, got %q", storedMessage.Content)
+	}
+	_, err = svc.ReviewCase(messageCase.ID, 9, models.ModerationCaseStatusApproved, "")
+	requireOrderBizErr(t, err, "moderation.caseAlreadyReviewed")
+
+	if _, err := svc.ReviewCase(remarkCase.ID, 9, models.ModerationCaseStatusRemoved, ""); err != nil {
+		t.Fatalf("review remark case failed: %v", err)
+	}
+	var storedOrder models.Order
+	db.Select("id", "remark").First(&storedOrder, order.ID)
+	if storedOrder.Remark != "Ring twice\n"+ModerationRemovedPlaceholder {
+		t.Fatalf("expected only the moderated remark segment to be replaced, got %q", storedOrder.Remark)
+	}
+	if count, _ := svc.PendingCount(); count != 0 {
+		t.Fatalf("expected empty queue after review, got %d", count)
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+// builtinDisposableEmailDomains 常见一次性（临时）邮箱服务的域名，子域名同样命中；
+// 新增服务商可通过 security.email_validation.extra_disposable_domains 补充，无需等待发版
+var builtinDisposableEmailDomains = []string{
+	"0-mail.com", "10minutemail.co.uk", "10minutemail.com", "10minutemail.net", "1secmail.com",
+	"1secmail.net", "1secmail.org", "20minutemail.com", "24hourmail.com", "33mail.com", "anonbox.net",
+	"anonymbox.com", "burnermail.io", "byom.de", "cool.fr.nf", "courriel.fr.nf", "discard.email",
+	"discardmail.com", "discardmail.de", "dispostable.com", "dodgit.com", "dropmail.me",
+	"email-fake.com", "emailfake.com", "emailondeck.com", "emailtemporanea.com",
+	"emailtemporanea.net", "emltmp.com", "fakeinbox.com", "fakemail.net", "fakemailgenerator.com",
+	"filzmail.com", "getairmail.com", "getnada.com", "grr.la", "guerrillamail.biz",
+	"guerrillamail.com", "guerrillamail.de", "guerrillamail.info", "guerrillamail.net",
+	"guerrillamail.org", "guerrillamailblock.com", "harakirimail.com", "inboxbear.com",
+	"inboxkitten.com", "incognitomail.org", "jetable.fr.nf", "jetable.org", "linshiyouxiang.net",
+	"mail-temp.com", "mailcatch.com", "maildrop.cc", "mailexpire.com", "mailforspam.com",
+	"mailinator.com", "mailinator.net", "mailinator2.com", "mailnesia.com", "mailnull.com",
+	"mailpoof.com", "mailsac.com", "mailtemp.net", "mailtothis.com", "mintemail.com", "moakt.com",
+	"mohmal.com", "moncourrier.fr.nf", "monemail.fr.nf", "monmail.fr.nf", "mvrht.com", "mytemp.email",
+	"mytrashmail.com", "nada.email", "nowmymail.com", "one-time.email", "owlymail.com",
+	"pokemail.net", "sharklasers.com", "spam4.me", "spamavert.com", "spambog.com", "spambox.us",
+	"spamdecoy.net", "spamex.com", "spamfree24.org", "spamgourmet.com", "spamherelots.com",
+	"spaml.com", "spammotel.com", "temp-mail.io", "temp-mail.org", "tempail.com", "tempinbox.com",
+	"tempm.com", "tempmail.dev", "tempmail.email", "tempmail.net", "tempmail.plus",
+	"tempmailaddress.com", "tempmailo.com", "tempr.email", "tempsky.com", "throwam.com",
+	"throwawaymail.com", "trash-mail.com", "trashmail.com", "trashmail.de", "trashmail.io",
+	"trashmail.me", "trashmail.net", "trashmailer.com", "wegwerfmail.de", "wegwerfmail.net",
+	"yopmail.com", "yopmail.fr", "yopmail.net", "zetmail.com",
+}
@@ -0,0 +1,632 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/cache"
+	"gorm.io/gorm"
+)
+
+// documentDownloadTokenTTL 文档下载令牌有效期，与账单下载令牌一致，单次使用
+const documentDownloadTokenTTL = 60 * time.Second
+
+var (
+	documentKeyPattern      = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	documentFieldKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+)
+
+// documentReservedKeys 与内置文档重名的标识，避免与账单、报价单等地址混淆
+var documentReservedKeys = map[string]bool{
+	"invoice":      true,
+	"quote":        true,
+	"packing-slip": true,
+}
+
+var documentOrderStatuses = map[models.OrderStatus]bool{
+	models.OrderStatusPendingPayment: true,
+	models.OrderStatusDraft:          true,
+	models.OrderStatusPending:        true,
+	models.OrderStatusNeedResubmit:   true,
+	models.OrderStatusShipped:        true,
+	models.OrderStatusCompleted:      true,
+	models.OrderStatusCancelled:      true,
+	models.OrderStatusRefundPending:  true,
+	models.OrderStatusRefunded:       true,
+}
+
+var (
+	errDocumentKeyInvalid      = bizerr.Register("document.keyInvalid", 400, "Document key may only contain lowercase letters, digits, - and _ (up to 64 characters)")
+	errDocumentKeyTaken        = bizerr.Register("document.keyTaken", 409, "Document key is already in use")
+	errDocumentNameRequired    = bizerr.Register("document.nameRequired", 400, "Document name is required")
+	errDocumentBindingInvalid  = bizerr.Register("document.bindingInvalid", 400, "Unsupported document data binding")
+	errDocumentTemplateInvalid = bizerr.Register("document.templateInvalid", 400, "Document template is invalid")
+	errDocumentFieldInvalid    = bizerr.Register("document.fieldInvalid", 400, "Custom field names may only contain letters, digits and _")
+	errDocumentStatusInvalid   = bizerr.Register("document.statusInvalid", 400, "Unknown order status")
+	errDocumentNotFound        = bizerr.Register("document.notFound", 404, "Document type not found")
+	errDocumentNotAvailable    = bizerr.Register("document.notAvailable", 400, "This document is not available for the order")
+	errDocumentItemInvalid     = bizerr.Register("document.itemInvalid", 400, "This document is not available for the selected item")
+	errDocumentTokenInvalid    = bizerr.Register("document.tokenInvalid", 401, "Invalid or expired download token")
+)
+
+// DocumentTypeInput 创建或更新文档类型的参数
+type DocumentTypeInput struct {
+	Key           string
+	Name          string
+	Description   string
+	Binding       string
+	Template      string
+	Fields        map[string]string
+	OrderStatuses []string
+	ItemSKUs      []string
+	Enabled       bool
+	VisibleToUser bool
+}
+
+// DocumentItem 文档中的订单商品
+type DocumentItem struct {
+	Index       int // 商品在订单中的序号，从 0 开始
+	Name        string
+	SKU         string
+	Quantity    int
+	ProductType string
+	Attributes  map[string]interface{}
+}
+
+// DocumentData 自定义文档模板数据
+type DocumentData struct {
+	DocumentCompany
+
+	DocumentName string
+	DocumentNo   string
+	IssueDate    string
+
+	OrderNo       string
+	OrderStatus   string
+	OrderDate     string
+	CompletedDate string
+
+	CustomerName    string
+	CustomerEmail   string
+	CustomerPhone   string
+	CustomerAddress string
+
+	Items       []DocumentItem
+	Item        *DocumentItem // order_item 绑定时为当前商品
+	TotalAmount string
+	Currency    string
+	Fields      map[string]string
+
+	AppName      string
+	PrintBtnText string
+	CloseBtnText string
+}
+
+// OrderDocumentTarget 可下载文档的订单商品
+type OrderDocumentTarget struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	SKU   string `json:"sku"`
+}
+
+// OrderDocument 订单当前可下载的文档
+type OrderDocument struct {
+	Key         string                `json:"key"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Binding     string                `json:"binding"`
+	Items       []OrderDocumentTarget `json:"items,omitempty"` // order_item 绑定时可选的商品
+}
+
+// DocumentDownload 下载令牌对应的文档
+type DocumentDownload struct {
+	UserID    uint
+	Key       string
+	ItemIndex int
+	OrderNo   string
+}
+
+// DocumentService 自定义文档：管理员定义文档类型与模板，按订单数据渲染，用户通过一次性令牌下载
+type DocumentService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewDocumentService(db *gorm.DB, cfg *config.Config) *DocumentService {
+	return &DocumentService{db: db, cfg: cfg}
+}
+
+// List 全部文档类型
+func (s *DocumentService) List() ([]models.DocumentType, error) {
+	var docTypes []models.DocumentType
+	if err := s.db.Order("name ASC, id ASC").Find(&docTypes).Error; err != nil {
+		return nil, err
+	}
+	return docTypes, nil
+}
+
+// Get 按 ID 获取文档类型
+func (s *DocumentService) Get(id uint) (*models.DocumentType, error) {
+	var docType models.DocumentType
+	if err := s.db.First(&docType, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errDocumentNotFound.New()
+		}
+		return nil, err
+	}
+	return &docType, nil
+}
+
+// GetByKey 按标识获取文档类型
+func (s *DocumentService) GetByKey(key string) (*models.DocumentType, error) {
+	var docType models.DocumentType
+	if err := s.db.Where(&models.DocumentType{Key: strings.TrimSpace(key)}).First(&docType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errDocumentNotFound.New()
+		}
+		return nil, err
+	}
+	return &docType, nil
+}
+
+// buildDocumentType 校验输入并生成文档类型；模板为空时使用内置通用模板
+func buildDocumentType(input DocumentTypeInput) (*models.DocumentType, error) {
+	key := strings.ToLower(strings.TrimSpace(input.Key))
+	if !documentKeyPattern.MatchString(key) || documentReservedKeys[key] {
+		return nil, errDocumentKeyInvalid.New()
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errDocumentNameRequired.New()
+	}
+	binding := strings.TrimSpace(input.Binding)
+	if binding == "" {
+		binding = models.DocumentBindingOrder
+	}
+	if binding != models.DocumentBindingOrder && binding != models.DocumentBindingOrderItem {
+		return nil, errDocumentBindingInvalid.New()
+	}
+	if strings.TrimSpace(input.Template) != "" {
+		if _, err := ParseDocumentTemplate("document", input.Template, false); err != nil {
+			return nil, errDocumentTemplateInvalid.New().WithParams(map[string]interface{}{"cause": err.Error()})
+		}
+	}
+
+	fields := make(map[string]string, len(input.Fields))
+	for fieldKey, value := range input.Fields {
+		fieldKey = strings.TrimSpace(fieldKey)
+		if !documentFieldKeyPattern.MatchString(fieldKey) {
+			return nil, errDocumentFieldInvalid.New().WithParams(map[string]interface{}{"field": fieldKey})
+		}
+		fields[fieldKey] = value
+	}
+
+	statuses := make([]string, 0, len(input.OrderStatuses))
+	seenStatus := make(map[string]bool, len(input.OrderStatuses))
+	for _, status := range input.OrderStatuses {
+		status = strings.TrimSpace(status)
+		if status == "" || seenStatus[status] {
+			continue
+		}
+		if !documentOrderStatuses[models.OrderStatus(status)] {
+			return nil, errDocumentStatusInvalid.New().WithParams(map[string]interface{}{"status": status})
+		}
+		seenStatus[status] = true
+		statuses = append(statuses, status)
+	}
+
+	var skus []string
+	if binding == models.DocumentBindingOrderItem {
+		seenSKU := make(map[string]bool, len(input.ItemSKUs))
+		for _, sku := range input.ItemSKUs {
+			sku = strings.TrimSpace(sku)
+			if sku == "" || seenSKU[sku] {
+				continue
+			}
+			seenSKU[sku] = true
+			skus = append(skus, sku)
+		}
+	}
+
+	return &models.DocumentType{
+		Key:           key,
+		Name:          name,
+		Description:   strings.TrimSpace(input.Description),
+		Binding:       binding,
+		Template:      input.Template,
+		Fields:        fields,
+		OrderStatuses: statuses,
+		ItemSKUs:      skus,
+		Enabled:       input.Enabled,
+		VisibleToUser: input.VisibleToUser,
+	}, nil
+}
+
+func (s *DocumentService) ensureKeyAvailable(key string, excludeID uint) error {
+	var count int64
+	query := s.db.Model(&models.DocumentType{}).Where(&models.DocumentType{Key: key})
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errDocumentKeyTaken.New()
+	}
+	return nil
+}
+
+// Create 新建文档类型
+func (s *DocumentService) Create(input DocumentTypeInput) (*models.DocumentType, error) {
+	docType, err := buildDocumentType(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureKeyAvailable(docType.Key, 0); err != nil {
+		return nil, err
+	}
+	if err := s.db.Create(docType).Error; err != nil {
+		return nil, err
+	}
+	return docType, nil
+}
+
+// Update 更新文档类型；修改标识后旧的下载令牌随之失效
+func (s *DocumentService) Update(id uint, input DocumentTypeInput) (*models.DocumentType, error) {
+	existing, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	docType, err := buildDocumentType(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureKeyAvailable(docType.Key, existing.ID); err != nil {
+		return nil, err
+	}
+	docType.ID = existing.ID
+	docType.CreatedAt = existing.CreatedAt
+	if err := s.db.Save(docType).Error; err != nil {
+		return nil, err
+	}
+	return docType, nil
+}
+
+// Delete 删除文档类型
+func (s *DocumentService) Delete(id uint) (*models.DocumentType, error) {
+	docType, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Delete(docType).Error; err != nil {
+		return nil, err
+	}
+	return docType, nil
+}
+
+// OrderByID 按 ID 读取订单
+func (s *DocumentService) OrderByID(id uint) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// OrderByNo 按订单号读取订单
+func (s *DocumentService) OrderByNo(orderNo string) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.Where("order_no = ?", strings.TrimSpace(orderNo)).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// availableForStatus 订单状态是否允许生成该文档，未配置时仅已完成订单可用
+func availableForStatus(docType *models.DocumentType, status models.OrderStatus) bool {
+	if len(docType.OrderStatuses) == 0 {
+		return status == models.OrderStatusCompleted
+	}
+	for _, allowed := range docType.OrderStatuses {
+		if models.OrderStatus(allowed) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// itemApplies order_item 绑定时商品是否适用该文档
+func itemApplies(docType *models.DocumentType, item models.OrderItem) bool {
+	if len(docType.ItemSKUs) == 0 {
+		return true
+	}
+	for _, sku := range docType.ItemSKUs {
+		if sku == item.SKU {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAvailable 校验文档对订单（及商品）是否可用；userFacing 为 true 时还要求文档对用户可见
+func (s *DocumentService) CheckAvailable(docType *models.DocumentType, order *models.Order, itemIndex int, userFacing bool) error {
+	if !docType.Enabled || (userFacing && !docType.VisibleToUser) || !availableForStatus(docType, order.Status) {
+		return errDocumentNotAvailable.New()
+	}
+	if docType.Binding != models.DocumentBindingOrderItem {
+		return nil
+	}
+	if itemIndex < 0 || itemIndex >= len(order.Items) || !itemApplies(docType, order.Items[itemIndex]) {
+		return errDocumentItemInvalid.New()
+	}
+	return nil
+}
+
+// AvailableForOrder 订单当前可下载的文档
+func (s *DocumentService) AvailableForOrder(order *models.Order, userFacing bool) ([]OrderDocument, error) {
+	var docTypes []models.DocumentType
+	if err := s.db.Where("enabled = ?", true).Order("name ASC, id ASC").Find(&docTypes).Error; err != nil {
+		return nil, err
+	}
+	documents := make([]OrderDocument, 0, len(docTypes))
+	for i := range docTypes {
+		docType := &docTypes[i]
+		if (userFacing && !docType.VisibleToUser) || !availableForStatus(docType, order.Status) {
+			continue
+		}
+		document := OrderDocument{
+			Key:         docType.Key,
+			Name:        docType.Name,
+			Description: docType.Description,
+			Binding:     docType.Binding,
+		}
+		if docType.Binding == models.DocumentBindingOrderItem {
+			for index, item := range order.Items {
+				if itemApplies(docType, item) {
+					document.Items = append(document.Items, OrderDocumentTarget{Index: index, Name: item.Name, SKU: item.SKU})
+				}
+			}
+			if len(document.Items) == 0 {
+				continue
+			}
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// DocumentNo 文档编号：标识大写 + 订单号，按商品生成时追加商品序号
+func DocumentNo(docType *models.DocumentType, order *models.Order, itemIndex int) string {
+	no := strings.ToUpper(docType.Key) + "-" + order.OrderNo
+	if docType.Binding == models.DocumentBindingOrderItem {
+		no += "-" + strconv.Itoa(itemIndex+1)
+	}
+	return no
+}
+
+// BuildData 按数据绑定组装模板数据
+func (s *DocumentService) BuildData(docType *models.DocumentType, order *models.Order, itemIndex int, inlineAssets bool) DocumentData {
+	currency := "CNY"
+	appName := ""
+	var invoiceCfg *config.InvoiceConfig
+	if s.cfg != nil {
+		if s.cfg.Order.Currency != "" {
+			currency = s.cfg.Order.Currency
+		}
+		appName = s.cfg.App.Name
+		invoiceCfg = &s.cfg.Order.Invoice
+	}
+
+	data := DocumentData{
+		DocumentCompany: NewDocumentCompany(invoiceCfg, inlineAssets),
+		DocumentName:    docType.Name,
+		DocumentNo:      DocumentNo(docType, order, itemIndex),
+		IssueDate:       models.NowFunc().Format("2006-01-02"),
+		OrderNo:         order.OrderNo,
+		OrderStatus:     string(order.Status),
+		OrderDate:       order.CreatedAt.Format("2006-01-02"),
+		CustomerName:    order.ReceiverName,
+		CustomerEmail:   order.UserEmail,
+		CustomerPhone:   order.ReceiverPhone,
+		CustomerAddress: packingSlipAddress(order),
+		TotalAmount:     formatQuoteAmount(order.TotalAmount, currency),
+		Currency:        currency,
+		Fields:          docType.Fields,
+		AppName:         appName,
+		PrintBtnText:    "Print / Save as PDF",
+		CloseBtnText:    "Close",
+	}
+	if data.CustomerName == "" {
+		data.CustomerName = data.CustomerEmail
+	}
+	if order.CompletedAt != nil {
+		data.CompletedDate = order.CompletedAt.Format("2006-01-02")
+	}
+	if data.Fields == nil {
+		data.Fields = map[string]string{}
+	}
+	for index, item := range order.Items {
+		data.Items = append(data.Items, DocumentItem{
+			Index:       index,
+			Name:        item.Name,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			ProductType: string(item.ProductType),
+			Attributes:  item.Attributes,
+		})
+	}
+	if docType.Binding == models.DocumentBindingOrderItem && itemIndex >= 0 && itemIndex < len(data.Items) {
+		data.Item = &data.Items[itemIndex]
+	}
+	return data
+}
+
+// Render 渲染文档 HTML；inlineAssets 为 true 时模板资源内联为 data URI，供 PDF 渲染使用
+func (s *DocumentService) Render(docType *models.DocumentType, order *models.Order, itemIndex int, inlineAssets bool) ([]byte, error) {
+	tmplStr := docType.Template
+	if strings.TrimSpace(tmplStr) == "" {
+		tmplStr = builtinDocumentTemplate
+	}
+	return RenderDocumentTemplate("document", tmplStr, s.BuildData(docType, order, itemIndex, inlineAssets), inlineAssets)
+}
+
+// Preview 用未保存的文档设置渲染预览，便于编辑模板时查看效果
+func (s *DocumentService) Preview(input DocumentTypeInput, order *models.Order, itemIndex int) ([]byte, error) {
+	if strings.TrimSpace(input.Key) == "" {
+		input.Key = "preview"
+	}
+	docType, err := buildDocumentType(input)
+	if err != nil {
+		return nil, err
+	}
+	if docType.Binding == models.DocumentBindingOrderItem && (itemIndex < 0 || itemIndex >= len(order.Items)) {
+		return nil, errDocumentItemInvalid.New()
+	}
+	return s.Render(docType, order, itemIndex, false)
+}
+
+// IssueDownloadToken 生成一次性文档下载令牌（60秒有效，单次使用）
+func (s *DocumentService) IssueDownloadToken(userID uint, docType *models.DocumentType, order *models.Order, itemIndex int) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := fmt.Sprintf("%x", b)
+	if docType.Binding != models.DocumentBindingOrderItem {
+		itemIndex = 0
+	}
+	value := fmt.Sprintf("%d:%s:%d:%s", userID, docType.Key, itemIndex, order.OrderNo)
+	if err := cache.Set("document_dl:"+token, value, documentDownloadTokenTTL); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumeDownloadToken 读取并作废下载令牌
+func (s *DocumentService) ConsumeDownloadToken(token string) (*DocumentDownload, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errDocumentTokenInvalid.New()
+	}
+	key := "document_dl:" + token
+	value, err := cache.Get(key)
+	if err != nil {
+		return nil, errDocumentTokenInvalid.New()
+	}
+	_ = cache.Del(key)
+
+	parts := strings.SplitN(value, ":", 4)
+	if len(parts) != 4 {
+		return nil, errDocumentTokenInvalid.New()
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return nil, errDocumentTokenInvalid.New()
+	}
+	itemIndex, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, errDocumentTokenInvalid.New()
+	}
+	return &DocumentDownload{UserID: uint(userID), Key: parts[1], ItemIndex: itemIndex, OrderNo: parts[3]}, nil
+}
+
+// DocumentFieldKeys 按名称排序的自定义字段，内置模板据此稳定输出
+func (d DocumentData) DocumentFieldKeys() []string {
+	keys := make([]string, 0, len(d.Fields))
+	for key := range d.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// builtinDocumentTemplate 未填写模板时使用的通用文档模板，版式与内置账单一致
+const builtinDocumentTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.DocumentName}} {{.DocumentNo}}</title>
+<style>
+*{margin:0;padding:0;box-sizing:border-box}
+body{font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;color:#1a1a1a;background:#f5f5f5;line-height:1.6}
+.document-wrapper{max-width:800px;margin:20px auto;background:#fff;box-shadow:0 1px 10px rgba(0,0,0,.08);border-radius:8px;overflow:hidden}
+.document-header{display:flex;justify-content:space-between;align-items:flex-start;padding:40px 40px 30px;border-bottom:2px solid #f0f0f0}
+.company-info h1{font-size:22px;font-weight:700;margin-bottom:4px}
+.company-info p{font-size:13px;color:#666}
+.company-logo{max-height:48px;margin-bottom:8px}
+.document-title{text-align:right}
+.document-title h2{font-size:26px;font-weight:800;color:#2563eb}
+.document-title p{font-size:13px;color:#666}
+.document-body{padding:30px 40px}
+.section{margin-bottom:24px}
+.section h3{font-size:12px;text-transform:uppercase;letter-spacing:.05em;color:#999;margin-bottom:8px}
+table{width:100%;border-collapse:collapse;font-size:14px}
+th{text-align:left;font-size:12px;text-transform:uppercase;color:#999;border-bottom:1px solid #eee;padding:8px 0}
+td{border-bottom:1px solid #f3f3f3;padding:10px 0}
+.document-footer{padding:20px 40px 40px;font-size:12px;color:#999;text-align:center}
+.actions{text-align:center;margin:20px}
+.actions button{padding:8px 18px;margin:0 6px;border:1px solid #ddd;border-radius:6px;background:#fff;cursor:pointer}
+@media print{body{background:#fff}.document-wrapper{box-shadow:none;margin:0}.actions{display:none}}
+</style>
+</head>
+<body>
+<div class="document-wrapper">
+  <div class="document-header">
+    <div class="company-info">
+      {{if .CompanyLogo}}<img src="{{.CompanyLogo}}" alt="Logo" class="company-logo"><br>{{end}}
+      <h1>{{if .CompanyName}}{{.CompanyName}}{{else}}{{.AppName}}{{end}}</h1>
+      {{if .CompanyAddress}}<p>{{.CompanyAddress}}</p>{{end}}
+      {{if .CompanyPhone}}<p>{{.CompanyPhone}}</p>{{end}}
+      {{if .CompanyEmail}}<p>{{.CompanyEmail}}</p>{{end}}
+    </div>
+    <div class="document-title">
+      <h2>{{.DocumentName}}</h2>
+      <p>{{.DocumentNo}}</p>
+      <p>{{.IssueDate}}</p>
+    </div>
+  </div>
+  <div class="document-body">
+    <div class="section">
+      <h3>Order</h3>
+      <p>{{.OrderNo}} · {{.OrderDate}}</p>
+      <p>{{.CustomerName}}{{if .CustomerAddress}} · {{.CustomerAddress}}{{end}}</p>
+    </div>
+    <div class="section">
+      <h3>Items</h3>
+      <table>
+        <thead><tr><th>Item</th><th>SKU</th><th>Qty</th></tr></thead>
+        <tbody>
+        {{if .Item}}<tr><td>{{.Item.Name}}</td><td>{{.Item.SKU}}</td><td>{{.Item.Quantity}}</td></tr>
+        {{else}}{{range .Items}}<tr><td>{{.Name}}</td><td>{{.SKU}}</td><td>{{.Quantity}}</td></tr>
+        {{end}}{{end}}
+        </tbody>
+      </table>
+    </div>
+    {{if .Fields}}<div class="section">
+      <table>
+        <tbody>
+        {{$fields := .Fields}}{{range .DocumentFieldKeys}}<tr><th>{{.}}</th><td>{{index $fields .}}</td></tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>{{end}}
+  </div>
+  {{if .FooterText}}<div class="document-footer">{{.FooterText}}</div>{{end}}
+</div>
+<div class="actions">
+  <button onclick="window.print()">{{.PrintBtnText}}</button>
+  <button onclick="window.close()">{{.CloseBtnText}}</button>
+</div>
+</body>
+</html>`
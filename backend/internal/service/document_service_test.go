@@ -0,0 +1,134 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestDocumentTypesRenderPerOrderAndItem(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.DocumentType{}, &models.Order{}); err != nil {
+		t.Fatalf("auto migrate documents failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.App.Name = "Aura"
+	cfg.Order.Currency = "USD"
+	cfg.Order.Invoice.CompanyName = "Aura Ltd"
+	svc := NewDocumentService(db, cfg)
+
+	_, err := svc.Create(DocumentTypeInput{Key: "invoice", Name: "Invoice"})
+	requireBizErr(t, err, "document.keyInvalid")
+	_, err = svc.Create(DocumentTypeInput{Key: "warranty", Name: "Warranty", Binding: "per_user"})
+	requireBizErr(t, err, "document.bindingInvalid")
+	_, err = svc.Create(DocumentTypeInput{Key: "warranty", Name: "Warranty", Template: "{{.Broken"})
+	requireBizErr(t, err, "document.templateInvalid")
+	_, err = svc.Create(DocumentTypeInput{Key: "warranty", Name: "Warranty", Fields: map[string]string{"bad key": "x"}})
+	requireBizErr(t, err, "document.fieldInvalid")
+	_, err = svc.Create(DocumentTypeInput{Key: "warranty", Name: "Warranty", OrderStatuses: []string{"lost"}})
+	requireBizErr(t, err, "document.statusInvalid")
+
+	warranty, err := svc.Create(DocumentTypeInput{
+		Key:           "Warranty-Card",
+		Name:          "Warranty Card",
+		Binding:       models.DocumentBindingOrderItem,
+		Template:      `<p>{{.DocumentNo}} {{.Item.Name}} {{.Fields.period}} {{.CompanyName}}</p>`,
+		Fields:        map[string]string{"period": "24 months"},
+		OrderStatuses: []string{"shipped", "completed"},
+		ItemSKUs:      []string{"CAM-1"},
+		Enabled:       true,
+		VisibleToUser: true,
+	})
+	if err != nil {
+		t.Fatalf("create warranty document failed: %v", err)
+	}
+	if warranty.Key != "warranty-card" {
+		t.Fatalf("expected normalized key, got %q", warranty.Key)
+	}
+	_, err = svc.Create(DocumentTypeInput{Key: "warranty-card", Name: "Duplicate"})
+	requireBizErr(t, err, "document.keyTaken")
+
+	delivery, err := svc.Create(DocumentTypeInput{Key: "delivery-note", Name: "Delivery Note", Enabled: true})
+	if err != nil {
+		t.Fatalf("create delivery note failed: %v", err)
+	}
+	if _, err := svc.Create(DocumentTypeInput{Key: "internal-cert", Name: "Internal", Enabled: true}); err != nil {
+		t.Fatalf("create admin-only document failed: %v", err)
+	}
+
+	userID := uint(5)
+	order := &models.Order{
+		OrderNo: "DOC-1",
+		UserID:  &userID,
+		Status:  models.OrderStatusShipped,
+		Items: []models.OrderItem{
+			{SKU: "CABLE-1", Name: "Cable", Quantity: 2},
+			{SKU: "CAM-1", Name: "Camera", Quantity: 1},
+		},
+		TotalAmount: 12900,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	documents, err := svc.AvailableForOrder(order, true)
+	if err != nil {
+		t.Fatalf("list order documents failed: %v", err)
+	}
+	if len(documents) != 1 || documents[0].Key != "warranty-card" || len(documents[0].Items) != 1 || documents[0].Items[0].Index != 1 {
+		t.Fatalf("shipped order should only offer the camera warranty card, got %+v", documents)
+	}
+	requireBizErr(t, svc.CheckAvailable(warranty, order, 0, true), "document.itemInvalid")
+	requireBizErr(t, svc.CheckAvailable(delivery, order, 0, true), "document.notAvailable")
+
+	html, err := svc.Render(warranty, order, 1, false)
+	if err != nil {
+		t.Fatalf("render warranty failed: %v", err)
+	}
+	if got := string(html); got != "<p>WARRANTY-CARD-DOC-1-2 Camera 24 months Aura Ltd</p>" {
+		t.Fatalf("unexpected warranty html %q", got)
+	}
+
+	order.Status = models.OrderStatusCompleted
+	html, err = svc.Render(delivery, order, 0, false)
+	if err != nil {
+		t.Fatalf("render builtin template failed: %v", err)
+	}
+	if !strings.Contains(string(html), "DELIVERY-NOTE-DOC-1") || !strings.Contains(string(html), "Cable") {
+		t.Fatalf("builtin template should list the order items, got %s", html)
+	}
+	if _, err := svc.Preview(DocumentTypeInput{Name: "Draft", Binding: models.DocumentBindingOrderItem}, order, 5); err == nil {
+		t.Fatal("expected preview with an out-of-range item to fail")
+	}
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	token, err := svc.IssueDownloadToken(userID, warranty, order, 1)
+	if err != nil {
+		t.Fatalf("issue download token failed: %v", err)
+	}
+	download, err := svc.ConsumeDownloadToken(token)
+	if err != nil {
+		t.Fatalf("consume download token failed: %v", err)
+	}
+	if download.UserID != userID || download.Key != "warranty-card" || download.ItemIndex != 1 || download.OrderNo != "DOC-1" {
+		t.Fatalf("unexpected download %+v", download)
+	}
+	_, err = svc.ConsumeDownloadToken(token)
+	requireBizErr(t, err, "document.tokenInvalid")
+}
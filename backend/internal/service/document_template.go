@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"auralogic/internal/config"
+)
+
+// DocumentCompany 账单、报价单与自定义文档共用的公司信息，取自账单配置
+type DocumentCompany struct {
+	CompanyName    string
+	CompanyAddress string
+	CompanyPhone   string
+	CompanyEmail   string
+	CompanyLogo    template.URL
+	TaxID          string
+	FooterText     string
+}
+
+// NewDocumentCompany 按账单配置构建公司信息；inlineAssets 为 true 时 Logo 内联为 data URI
+func NewDocumentCompany(invoiceCfg *config.InvoiceConfig, inlineAssets bool) DocumentCompany {
+	if invoiceCfg == nil {
+		return DocumentCompany{}
+	}
+	return DocumentCompany{
+		CompanyName:    invoiceCfg.CompanyName,
+		CompanyAddress: invoiceCfg.CompanyAddress,
+		CompanyPhone:   invoiceCfg.CompanyPhone,
+		CompanyEmail:   invoiceCfg.CompanyEmail,
+		CompanyLogo:    ResolveTemplateAssetRef(invoiceCfg.CompanyLogo, inlineAssets),
+		TaxID:          invoiceCfg.TaxID,
+		FooterText:     invoiceCfg.FooterText,
+	}
+}
+
+// ParseDocumentTemplate 解析文档模板并注册模板资源函数，保存模板前也用于校验
+func ParseDocumentTemplate(name, tmplStr string, inlineAssets bool) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateAssetFuncs(inlineAssets)).Parse(tmplStr)
+}
+
+// RenderDocumentTemplate 渲染账单、报价单与自定义文档的 HTML，在线查看与 PDF 共用同一渲染流程
+func RenderDocumentTemplate(name, tmplStr string, data interface{}, inlineAssets bool) ([]byte, error) {
+	tmpl, err := ParseDocumentTemplate(name, tmplStr, inlineAssets)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s template: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -241,7 +241,7 @@ func loadEmailTemplatesFromDir(templateDir string) (map[string]*template.Templat
 			log.Printf("Warning: Failed to stat email template %s: %v", entry.Name(), infoErr)
 			continue
 		}
-		tmpl, parseErr := template.ParseFiles(fullPath)
+		tmpl, parseErr := template.New(entry.Name()).Funcs(TemplateAssetFuncs(false)).ParseFiles(fullPath)
 		if parseErr != nil {
 			log.Printf("Warning: Failed to load template %s: %v", entry.Name(), parseErr)
 			sourceState[entry.Name()] = emailTemplateSourceState{
@@ -442,7 +442,7 @@ func (s *EmailService) SendMarketingAnnouncementEmailWithBatch(user *models.User
 	}
 
 	userID := user.ID
-	return s.queueEmail(user.Email, subject, content, "marketing.announcement", nil, &userID, batchID)
+	return s.queueEmail(user.Email, subject, content, "marketing.announcement", nil, &userID, batchID, "")
 }
 
 // SendEmail 发送邮件
@@ -579,10 +579,10 @@ func (s *EmailService) emitEmailSendAfterHook(emailLog *models.EmailLog) {
 
 // QueueEmail 将邮件加入队列
 func (s *EmailService) QueueEmail(to, subject, content, eventType string, orderID, userID *uint) error {
-	return s.queueEmail(to, subject, content, eventType, orderID, userID, nil)
+	return s.queueEmail(to, subject, content, eventType, orderID, userID, nil, "")
 }
 
-func (s *EmailService) queueEmail(to, subject, content, eventType string, orderID, userID, batchID *uint) error {
+func (s *EmailService) queueEmail(to, subject, content, eventType string, orderID, userID, batchID *uint, campaign string) error {
 	if !s.IsEnabled() {
 		return nil
 	}
@@ -613,6 +613,7 @@ func (s *EmailService) queueEmail(to, subject, content, eventType string, orderI
 				OrderID:   orderID,
 				UserID:    userID,
 				BatchID:   batchID,
+				Campaign:  campaign,
 				Status:    models.EmailLogStatusPending,
 				ExpireAt:  &expireAt,
 			}
@@ -640,6 +641,7 @@ func (s *EmailService) queueEmail(to, subject, content, eventType string, orderI
 		OrderID:   orderID,
 		UserID:    userID,
 		BatchID:   batchID,
+		Campaign:  campaign,
 		Status:    models.EmailLogStatusPending,
 		ExpireAt:  &expireAt,
 	}
@@ -1271,11 +1273,20 @@ func (s *EmailService) SendOrderShippedEmail(order *models.Order) error {
 		shippedAt = order.ShippedAt.Format("2006-01-02 15:04:05")
 	}
 
+	// 游客订单无法登录查看，附带免登录查询页的签名链接
+	orderURL := fmt.Sprintf("%s/orders/%s", s.appURL, order.OrderNo)
+	if order.UserID == nil {
+		if trackingURL := NewOrderTrackingService(s.db, config.GetConfig()).TrackingURL(order.OrderNo); trackingURL != "" {
+			orderURL = trackingURL
+		}
+	}
+
 	data := map[string]interface{}{
 		"ReceiverName": order.ReceiverName,
 		"OrderNo":      order.OrderNo,
 		"TrackingNo":   order.TrackingNo,
 		"ShippedAt":    shippedAt,
+		"OrderURL":     orderURL,
 		"AppURL":       s.appURL,
 		"AppName":      appName,
 	}
@@ -1284,11 +1295,11 @@ func (s *EmailService) SendOrderShippedEmail(order *models.Order) error {
 	if err != nil {
 		log.Printf("Failed to render template, using fallback: %v", err)
 		if locale == "zh" {
-			content = fmt.Sprintf("您的订单已发货！\n\n订单号: %s\n物流单号: %s\n发货时间: %s\n\n查看: %s/orders/%s",
-				order.OrderNo, order.TrackingNo, shippedAt, s.appURL, order.OrderNo)
+			content = fmt.Sprintf("您的订单已发货！\n\n订单号: %s\n物流单号: %s\n发货时间: %s\n\n查看: %s",
+				order.OrderNo, order.TrackingNo, shippedAt, orderURL)
 		} else {
-			content = fmt.Sprintf("Your Order Has Been Shipped!\n\nOrder No: %s\nTracking No: %s\nShipped At: %s\n\nView: %s/orders/%s",
-				order.OrderNo, order.TrackingNo, shippedAt, s.appURL, order.OrderNo)
+			content = fmt.Sprintf("Your Order Has Been Shipped!\n\nOrder No: %s\nTracking No: %s\nShipped At: %s\n\nView: %s",
+				order.OrderNo, order.TrackingNo, shippedAt, orderURL)
 		}
 	}
 
@@ -1340,6 +1351,496 @@ func (s *EmailService) SendOrderCompletedEmail(order *models.Order) error {
 	return s.QueueEmail(order.UserEmail, subject, content, "order.completed", &order.ID, order.UserID)
 }
 
+// SendOrderAutoCompleteReminderEmail 发送订单即将自动完成提醒邮件
+func (s *EmailService) SendOrderAutoCompleteReminderEmail(order *models.Order, autoCompleteAt time.Time) error {
+	if !getEmailNotifyConfig().OrderAutoRemind {
+		return nil
+	}
+	if !s.canSendOrderEmail(order) {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("订单即将自动完成 - %s", order.OrderNo)
+	} else {
+		subject = fmt.Sprintf("Your Order Will Be Completed Soon - %s", order.OrderNo)
+	}
+
+	shippedDays := 0
+	if order.ShippedAt != nil {
+		shippedDays = int(time.Since(*order.ShippedAt).Hours() / 24)
+	}
+	autoCompleteAtStr := autoCompleteAt.Format("2006-01-02 15:04:05")
+
+	data := map[string]interface{}{
+		"ReceiverName":   order.ReceiverName,
+		"OrderNo":        order.OrderNo,
+		"TrackingNo":     order.TrackingNo,
+		"ShippedDays":    shippedDays,
+		"AutoCompleteAt": autoCompleteAtStr,
+		"AppURL":         s.appURL,
+		"AppName":        appName,
+	}
+
+	content, err := s.renderTemplate("order_auto_complete_reminder", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您的订单即将自动完成。\n\n订单号: %s\n自动完成时间: %s\n\n如未收到商品或存在问题，请在此之前提交工单联系客服。", order.OrderNo, autoCompleteAtStr)
+		} else {
+			content = fmt.Sprintf("Your order will be completed automatically soon.\n\nOrder No: %s\nAuto-complete On: %s\n\nIf you have not received it or something is wrong, please open a support ticket before then.", order.OrderNo, autoCompleteAtStr)
+		}
+	}
+
+	return s.QueueEmail(order.UserEmail, subject, content, "order.auto_complete_reminder", &order.ID, order.UserID)
+}
+
+// SendUsageThresholdEmail 发送用量计量阈值提醒邮件，percent 为已达到的额度百分比
+func (s *EmailService) SendUsageThresholdEmail(order *models.Order, meter *models.UsageMeter, percent int) error {
+	if !s.canSendOrderEmail(order) {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("用量已达额度的 %d%% - %s", percent, order.OrderNo)
+	} else {
+		subject = fmt.Sprintf("Usage Reached %d%% of Your Quota - %s", percent, order.OrderNo)
+	}
+
+	data := map[string]interface{}{
+		"OrderNo":          order.OrderNo,
+		"LicenseHint":      meter.LicenseHint,
+		"Unit":             meter.Unit,
+		"Used":             meter.Used,
+		"Quota":            meter.Quota,
+		"Remaining":        meter.Remaining(),
+		"Percent":          percent,
+		"OverageEnabled":   meter.OverageUnitPrice > 0,
+		"OverageUnitPrice": money.MinorToString(meter.OverageUnitPrice),
+		"Currency":         order.Currency,
+		"AppURL":           s.appURL,
+		"AppName":          appName,
+	}
+
+	content, err := s.renderTemplate("usage_threshold", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您的授权 %s 用量已达额度的 %d%%。\n\n订单号: %s\n已用: %d / %d %s", meter.LicenseHint, percent, order.OrderNo, meter.Used, meter.Quota, meter.Unit)
+		} else {
+			content = fmt.Sprintf("Usage for your license %s has reached %d%% of the quota.\n\nOrder No: %s\nUsed: %d / %d %s", meter.LicenseHint, percent, order.OrderNo, meter.Used, meter.Quota, meter.Unit)
+		}
+	}
+
+	return s.QueueEmail(order.UserEmail, subject, content, "usage.threshold", &order.ID, order.UserID)
+}
+
+// SendTrialReminderEmail 发送试用即将到期提醒，conversion 为转正待付款订单（商品已下架或未定价时为空）
+func (s *EmailService) SendTrialReminderEmail(order *models.Order, trial *models.ProductTrial, conversion *models.Order) error {
+	if !s.canSendOrderEmail(order) {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("试用即将到期 - %s", trial.ProductName)
+	} else {
+		subject = fmt.Sprintf("Your Trial Ends Soon - %s", trial.ProductName)
+	}
+
+	expiresAt := trial.ExpiresAt.Format("2006-01-02 15:04:05")
+	data := map[string]interface{}{
+		"ProductName": trial.ProductName,
+		"OrderNo":     order.OrderNo,
+		"ExpiresAt":   expiresAt,
+		"AppURL":      s.appURL,
+		"AppName":     appName,
+	}
+	if conversion != nil {
+		data["ConversionOrderNo"] = conversion.OrderNo
+		data["Amount"] = money.MinorToString(conversion.TotalAmount)
+		data["Currency"] = conversion.Currency
+	}
+
+	content, err := s.renderTemplate("trial_reminder", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您对 %s 的试用将于 %s 到期。\n\n试用订单号: %s", trial.ProductName, expiresAt, order.OrderNo)
+		} else {
+			content = fmt.Sprintf("Your trial of %s ends on %s.\n\nTrial Order No: %s", trial.ProductName, expiresAt, order.OrderNo)
+		}
+	}
+
+	return s.QueueEmail(order.UserEmail, subject, content, "trial.reminder", &order.ID, order.UserID)
+}
+
+// SendTrialExpiredEmail 发送试用已到期、卡密失效通知
+func (s *EmailService) SendTrialExpiredEmail(order *models.Order, trial *models.ProductTrial) error {
+	if !s.canSendOrderEmail(order) {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("试用已到期 - %s", trial.ProductName)
+	} else {
+		subject = fmt.Sprintf("Your Trial Has Ended - %s", trial.ProductName)
+	}
+
+	data := map[string]interface{}{
+		"ProductName": trial.ProductName,
+		"ProductID":   trial.ProductID,
+		"OrderNo":     order.OrderNo,
+		"AppURL":      s.appURL,
+		"AppName":     appName,
+	}
+
+	content, err := s.renderTemplate("trial_expired", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您对 %s 的试用已到期，试用卡密已失效。\n\n试用订单号: %s", trial.ProductName, order.OrderNo)
+		} else {
+			content = fmt.Sprintf("Your trial of %s has ended and the trial license is no longer valid.\n\nTrial Order No: %s", trial.ProductName, order.OrderNo)
+		}
+	}
+
+	return s.QueueEmail(order.UserEmail, subject, content, "trial.expired", &order.ID, order.UserID)
+}
+
+// SendNetTermsDunningEmail 发送账期发票逾期催款邮件，收件人为企业账户的账单邮箱（未设置时为用户邮箱）
+func (s *EmailService) SendNetTermsDunningEmail(account *models.BusinessAccount, invoice *models.NetTermsInvoice, overdueDays int) error {
+	to := strings.TrimSpace(account.BillingEmail)
+	locale := ""
+	if account.User != nil {
+		if to == "" {
+			to = account.User.Email
+		}
+		locale = account.User.Locale
+	}
+	if to == "" {
+		return nil
+	}
+
+	locale = resolveLocale(locale)
+	appName := getAppName()
+	amount := money.MinorToString(invoice.Amount) + " " + invoice.Currency
+	dueAt := invoice.DueAt.Format("2006-01-02")
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("发票已逾期 - %s", invoice.InvoiceNo)
+	} else {
+		subject = fmt.Sprintf("Invoice Overdue - %s", invoice.InvoiceNo)
+	}
+
+	data := map[string]interface{}{
+		"CompanyName": account.CompanyName,
+		"InvoiceNo":   invoice.InvoiceNo,
+		"OrderNo":     invoice.OrderNo,
+		"Amount":      amount,
+		"DueAt":       dueAt,
+		"OverdueDays": overdueDays,
+		"AppURL":      s.appURL,
+		"AppName":     appName,
+	}
+
+	content, err := s.renderTemplate("net_terms_dunning", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您的发票已逾期 %d 天，请尽快付款。\n\n发票号: %s\n订单号: %s\n金额: %s\n到期日: %s", overdueDays, invoice.InvoiceNo, invoice.OrderNo, amount, dueAt)
+		} else {
+			content = fmt.Sprintf("Your invoice is %d days overdue, please arrange payment as soon as possible.\n\nInvoice No: %s\nOrder No: %s\nAmount: %s\nDue Date: %s", overdueDays, invoice.InvoiceNo, invoice.OrderNo, amount, dueAt)
+		}
+	}
+
+	userID := account.UserID
+	return s.QueueEmail(to, subject, content, "net_terms.dunning", &invoice.OrderID, &userID)
+}
+
+// SendOrderPaymentLinkEmail 发送订单付款链接邮件，收件人可不登录直接付款
+func (s *EmailService) SendOrderPaymentLinkEmail(order *models.Order, to, paymentURL string, expiresAt time.Time) error {
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+	amount := money.MinorToString(order.TotalAmount) + " " + order.Currency
+	expires := expiresAt.Format("2006-01-02 15:04")
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("订单待付款 - %s", order.OrderNo)
+	} else {
+		subject = fmt.Sprintf("Payment Requested - %s", order.OrderNo)
+	}
+
+	data := map[string]interface{}{
+		"OrderNo":    order.OrderNo,
+		"Amount":     amount,
+		"PaymentURL": paymentURL,
+		"ExpiresAt":  expires,
+		"AppURL":     s.appURL,
+		"AppName":    appName,
+	}
+
+	content, err := s.renderTemplate("payment_link", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("您的订单 %s 待付款，金额 %s。\n\n请在 %s 前点击以下链接完成支付：\n%s", order.OrderNo, amount, expires, paymentURL)
+		} else {
+			content = fmt.Sprintf("Your order %s is awaiting payment of %s.\n\nPlease pay before %s using the link below:\n%s", order.OrderNo, amount, expires, paymentURL)
+		}
+	}
+
+	return s.QueueEmail(to, subject, content, "order.payment_link", &order.ID, order.UserID)
+}
+
+// SendOrderRecoveryEmail 发送未完成订单的挽回提醒：草稿订单提醒填写收货信息，待付款订单提醒在自动取消前继续付款
+// deadline 为订单自动取消或草稿失效时间，为空时不在邮件中展示；邮件日志记录 campaign 用于统计挽回效果
+func (s *EmailService) SendOrderRecoveryEmail(order *models.Order, to, resumeURL, campaign string, deadline *time.Time) error {
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return nil
+	}
+
+	locale := s.getOrderLocale(order)
+	appName := getAppName()
+	draft := order.Status == models.OrderStatusDraft
+	amount := money.MinorToString(order.TotalAmount) + " " + order.Currency
+	expires := ""
+	if deadline != nil {
+		expires = deadline.Format("2006-01-02 15:04")
+	}
+
+	var subject string
+	switch {
+	case draft && locale == "zh":
+		subject = fmt.Sprintf("请完善订单信息 - %s", order.OrderNo)
+	case draft:
+		subject = fmt.Sprintf("Finish Your Order - %s", order.OrderNo)
+	case locale == "zh":
+		subject = fmt.Sprintf("订单仍待付款 - %s", order.OrderNo)
+	default:
+		subject = fmt.Sprintf("Your Order Is Still Awaiting Payment - %s", order.OrderNo)
+	}
+
+	data := map[string]interface{}{
+		"OrderNo":   order.OrderNo,
+		"Amount":    amount,
+		"IsDraft":   draft,
+		"ResumeURL": resumeURL,
+		"ExpiresAt": expires,
+		"AppURL":    s.appURL,
+		"AppName":   appName,
+	}
+
+	content, err := s.renderTemplate("order_recovery", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		switch {
+		case draft && locale == "zh":
+			content = fmt.Sprintf("您的订单 %s 尚未填写收货信息。\n\n请点击以下链接继续：\n%s", order.OrderNo, resumeURL)
+		case draft:
+			content = fmt.Sprintf("Your order %s still needs your shipping details.\n\nContinue here:\n%s", order.OrderNo, resumeURL)
+		case locale == "zh":
+			content = fmt.Sprintf("您的订单 %s 仍待付款，金额 %s。\n\n请点击以下链接继续支付：\n%s", order.OrderNo, amount, resumeURL)
+		default:
+			content = fmt.Sprintf("Your order %s is still awaiting payment of %s.\n\nResume payment here:\n%s", order.OrderNo, amount, resumeURL)
+		}
+		if expires != "" {
+			if locale == "zh" {
+				content += fmt.Sprintf("\n\n订单将于 %s 失效。", expires)
+			} else {
+				content += fmt.Sprintf("\n\nThe order expires after %s.", expires)
+			}
+		}
+	}
+
+	return s.queueEmail(to, subject, content, "order.recovery", &order.ID, order.UserID, nil, campaign)
+}
+
+// SendQuoteEmail 通知客户有新的报价单，链接指向个人中心的报价单页面
+func (s *EmailService) SendQuoteEmail(quote *models.Quote, user *models.User) error {
+	to := strings.TrimSpace(user.Email)
+	if to == "" {
+		return nil
+	}
+
+	locale := resolveLocale(user.Locale)
+	appName := getAppName()
+	amount := money.MinorToString(quote.TotalAmount) + " " + quote.Currency
+	validUntil := quote.ValidUntil.Format("2006-01-02 15:04")
+	quoteURL := fmt.Sprintf("%s/profile/quotes?quote=%s", s.appURL, quote.QuoteNo)
+
+	var subject string
+	if locale == "zh" {
+		subject = fmt.Sprintf("您有一份新报价 - %s", quote.QuoteNo)
+	} else {
+		subject = fmt.Sprintf("New Quote - %s", quote.QuoteNo)
+	}
+
+	data := map[string]interface{}{
+		"QuoteNo":    quote.QuoteNo,
+		"Title":      quote.Title,
+		"Amount":     amount,
+		"ValidUntil": validUntil,
+		"QuoteURL":   quoteURL,
+		"AppURL":     s.appURL,
+		"AppName":    appName,
+	}
+
+	content, err := s.renderTemplate("quote_sent", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("我们为您准备了报价单 %s，金额 %s，有效期至 %s。\n\n查看并接受报价：%s", quote.QuoteNo, amount, validUntil, quoteURL)
+		} else {
+			content = fmt.Sprintf("We have prepared quote %s for %s, valid until %s.\n\nReview and accept the quote: %s", quote.QuoteNo, amount, validUntil, quoteURL)
+		}
+	}
+
+	return s.QueueEmail(to, subject, content, "quote.sent", nil, &user.ID)
+}
+
+// SendOrganizationInvitationEmail 发送组织成员邀请邮件，链接指向个人中心的组织页面
+// 被邀请人可能尚未注册，邮件语言沿用邀请人的语言
+func (s *EmailService) SendOrganizationInvitationEmail(org *models.Organization, invitation *models.OrganizationInvitation, inviter *models.User) error {
+	locale := resolveLocale(inviter.Locale)
+	appName := getAppName()
+	inviterName := strings.TrimSpace(inviter.Name)
+	if inviterName == "" {
+		inviterName = inviter.Email
+	}
+	inviteURL := fmt.Sprintf("%s/profile/organization?invite=%s", s.appURL, invitation.Token)
+	expiresAt := invitation.ExpiresAt.Format("2006-01-02 15:04")
+
+	role := string(invitation.Role)
+	var subject string
+	if locale == "zh" {
+		if invitation.Role == models.OrganizationRoleViewer {
+			role = "只读成员"
+		} else {
+			role = "采购成员"
+		}
+		subject = fmt.Sprintf("邀请您加入 %s - %s", org.Name, appName)
+	} else {
+		subject = fmt.Sprintf("You're invited to join %s - %s", org.Name, appName)
+	}
+
+	data := map[string]interface{}{
+		"OrganizationName": org.Name,
+		"InviterName":      inviterName,
+		"Role":             role,
+		"InviteURL":        inviteURL,
+		"ExpiresAt":        expiresAt,
+		"AppURL":           s.appURL,
+		"AppName":          appName,
+	}
+
+	content, err := s.renderTemplate("organization_invitation", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("%s 邀请您加入组织 %s（角色: %s）。\n\n接受邀请: %s\n有效期至: %s", inviterName, org.Name, role, inviteURL, expiresAt)
+		} else {
+			content = fmt.Sprintf("%s has invited you to join %s as %s.\n\nAccept invitation: %s\nExpires: %s", inviterName, org.Name, role, inviteURL, expiresAt)
+		}
+	}
+
+	return s.QueueEmail(invitation.Email, subject, content, "organization.invitation", nil, nil)
+}
+
+// SendSpendingControlChangedEmail 通知账户所有人消费控制已变更、将在冷静期后变更或待生效的变更已撤销
+func (s *EmailService) SendSpendingControlChangedEmail(to string, user *models.User, terms models.SpendingControlTerms, currency, change string, effectiveAt *time.Time) error {
+	locale := resolveLocale(user.Locale)
+	appName := getAppName()
+	settingsURL := fmt.Sprintf("%s/profile/spending-controls", s.appURL)
+
+	limit := money.MinorToString(terms.MonthlyLimit) + " " + currency
+	categories := strings.Join(terms.BlockedCategories, ", ")
+	var subject, status string
+	if locale == "zh" {
+		if terms.MonthlyLimit == 0 {
+			limit = "不限"
+		}
+		if categories == "" {
+			categories = "无"
+		}
+		switch change {
+		case SpendingControlChangeScheduled:
+			status = "将于 " + effectiveAt.Format("2006-01-02 15:04") + " 生效"
+			subject = fmt.Sprintf("消费控制将被放宽 - %s", appName)
+		case SpendingControlChangeCancelled:
+			status = "待生效的变更已撤销"
+			subject = fmt.Sprintf("消费控制变更已撤销 - %s", appName)
+		default:
+			status = "已生效"
+			subject = fmt.Sprintf("消费控制已更新 - %s", appName)
+		}
+	} else {
+		if terms.MonthlyLimit == 0 {
+			limit = "No limit"
+		}
+		if categories == "" {
+			categories = "None"
+		}
+		switch change {
+		case SpendingControlChangeScheduled:
+			status = "Takes effect at " + effectiveAt.Format("2006-01-02 15:04")
+			subject = fmt.Sprintf("Spending controls will be relaxed - %s", appName)
+		case SpendingControlChangeCancelled:
+			status = "Pending change cancelled"
+			subject = fmt.Sprintf("Spending control change cancelled - %s", appName)
+		default:
+			status = "In effect"
+			subject = fmt.Sprintf("Spending controls updated - %s", appName)
+		}
+	}
+
+	data := map[string]interface{}{
+		"AccountEmail": user.Email,
+		"MonthlyLimit": limit,
+		"Categories":   categories,
+		"Status":       status,
+		"Scheduled":    change == SpendingControlChangeScheduled,
+		"SettingsURL":  settingsURL,
+		"AppURL":       s.appURL,
+		"AppName":      appName,
+	}
+
+	content, err := s.renderTemplate("spending_control_changed", locale, data)
+	if err != nil {
+		log.Printf("Failed to render template, using fallback: %v", err)
+		if locale == "zh" {
+			content = fmt.Sprintf("账户 %s 的消费控制：每月限额 %s，禁止分类 %s（%s）。\n\n查看设置：%s", user.Email, limit, categories, status, settingsURL)
+		} else {
+			content = fmt.Sprintf("Spending controls for %s: monthly limit %s, blocked categories %s (%s).\n\nReview settings: %s", user.Email, limit, categories, status, settingsURL)
+		}
+	}
+
+	return s.QueueEmail(to, subject, content, "spending_control.changed", nil, &user.ID)
+}
+
 // SendOrderResubmitEmail 发送需要重填信息邮件
 func (s *EmailService) SendOrderResubmitEmail(order *models.Order, formURL string) error {
 	if !getEmailNotifyConfig().OrderResubmit {
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+// 邮箱校验处理方式
+const (
+	EmailValidationModeOff   = "off"
+	EmailValidationModeWarn  = "warn"
+	EmailValidationModeFlag  = "flag"
+	EmailValidationModeBlock = "block"
+)
+
+// 邮箱校验未通过的原因，同时作为用户的 email_risk_flag 取值
+const (
+	EmailRiskDisposable = "disposable"
+	EmailRiskNoMX       = "no_mx"
+)
+
+const (
+	emailValidationCacheTTL     = time.Hour
+	emailValidationCacheMaxSize = 10000
+)
+
+type emailValidationCacheEntry struct {
+	reason    string
+	expiresAt time.Time
+}
+
+// EmailValidationService 按一次性邮箱域名列表与 MX 记录判断邮箱是否可疑；
+// 只给出原因，拒绝、标记还是仅记录由调用方按配置决定
+type EmailValidationService struct {
+	cfg *config.Config
+
+	lookupMX   func(ctx context.Context, domain string) ([]*net.MX, error)
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+
+	mu    sync.Mutex
+	cache map[string]emailValidationCacheEntry
+}
+
+func NewEmailValidationService(cfg *config.Config) *EmailValidationService {
+	return &EmailValidationService{
+		cfg:        cfg,
+		lookupMX:   net.DefaultResolver.LookupMX,
+		lookupHost: net.DefaultResolver.LookupHost,
+		cache:      make(map[string]emailValidationCacheEntry),
+	}
+}
+
+// Mode 返回当前处理方式，未配置时为 off
+func (s *EmailValidationService) Mode() string {
+	if s == nil || s.cfg == nil {
+		return EmailValidationModeOff
+	}
+	mode := strings.ToLower(strings.TrimSpace(s.cfg.Security.EmailValidation.Mode))
+	switch mode {
+	case EmailValidationModeWarn, EmailValidationModeFlag, EmailValidationModeBlock:
+		return mode
+	default:
+		return EmailValidationModeOff
+	}
+}
+
+// Check 返回邮箱未通过校验的原因，通过时返回空字符串。
+// DNS 超时或故障时放行，避免解析服务异常时阻断正常注册
+func (s *EmailValidationService) Check(ctx context.Context, email string) string {
+	if s.Mode() == EmailValidationModeOff {
+		return ""
+	}
+	domain := emailDomain(email)
+	if domain == "" {
+		return ""
+	}
+	cfg := s.cfg.Security.EmailValidation
+	if domainMatchesAny(domain, cfg.AllowedDomains) {
+		return ""
+	}
+	if cfg.CheckDisposable && (domainMatchesAny(domain, builtinDisposableEmailDomains) ||
+		domainMatchesAny(domain, cfg.ExtraDisposableDomains)) {
+		return EmailRiskDisposable
+	}
+	if !cfg.CheckMX {
+		return ""
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	if entry, ok := s.cache[domain]; ok && now.Before(entry.expiresAt) {
+		s.mu.Unlock()
+		return entry.reason
+	}
+	s.mu.Unlock()
+
+	reason, ok := s.checkMX(ctx, domain, time.Duration(cfg.MXTimeoutMs)*time.Millisecond)
+	if !ok {
+		return ""
+	}
+	s.mu.Lock()
+	if len(s.cache) >= emailValidationCacheMaxSize {
+		s.cache = make(map[string]emailValidationCacheEntry)
+	}
+	s.cache[domain] = emailValidationCacheEntry{reason: reason, expiresAt: now.Add(emailValidationCacheTTL)}
+	s.mu.Unlock()
+	return reason
+}
+
+// checkMX 查询域名能否收信；ok 为 false 表示查询失败、结果不可信
+func (s *EmailValidationService) checkMX(ctx context.Context, domain string, timeout time.Duration) (string, bool) {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	records, err := s.lookupMX(ctx, domain)
+	if err == nil {
+		// RFC 7505：唯一一条主机为 "." 的 MX 记录表示该域名不收信
+		if len(records) == 1 && strings.TrimSuffix(records[0].Host, ".") == "" {
+			return EmailRiskNoMX, true
+		}
+		if len(records) > 0 {
+			return "", true
+		}
+	} else if !isDNSNotFound(err) {
+		return "", false
+	}
+
+	// 没有 MX 记录时按 RFC 5321 回退到域名自身的 A/AAAA 记录
+	hosts, err := s.lookupHost(ctx, domain)
+	if err != nil {
+		if isDNSNotFound(err) {
+			return EmailRiskNoMX, true
+		}
+		return "", false
+	}
+	if len(hosts) == 0 {
+		return EmailRiskNoMX, true
+	}
+	return "", true
+}
+
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(email[at+1:])), ".")
+}
+
+// domainMatchesAny 判断域名本身或其上级域名是否在列表中
+func domainMatchesAny(domain string, list []string) bool {
+	for _, item := range list {
+		item = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(item)), "@")
+		if item == "" {
+			continue
+		}
+		if domain == item || strings.HasSuffix(domain, "."+item) {
+			return true
+		}
+	}
+	return false
+}
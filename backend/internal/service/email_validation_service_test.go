@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/bizerr"
+)
+
+func newEmailValidationTestService(mode string) (*EmailValidationService, *int) {
+	cfg := &config.Config{}
+	cfg.Security.EmailValidation = config.EmailValidationConfig{
+		Mode:                   mode,
+		CheckDisposable:        true,
+		CheckMX:                true,
+		ExtraDisposableDomains: []string{"@burner.example"},
+		AllowedDomains:         []string{"corp.example"},
+		MXTimeoutMs:            100,
+	}
+	svc := NewEmailValidationService(cfg)
+	lookups := 0
+	svc.lookupMX = func(ctx context.Context, domain string) ([]*net.MX, error) {
+		lookups++
+		switch domain {
+		case "mail.example":
+			return []*net.MX{{Host: "mx1.mail.example.", Pref: 10}}, nil
+		case "nullmx.example":
+			return []*net.MX{{Host: ".", Pref: 0}}, nil
+		case "flaky.example":
+			return nil, &net.DNSError{Err: "i/o timeout", Name: domain, IsTimeout: true}
+		default:
+			return nil, &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+		}
+	}
+	svc.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if host == "apex.example" {
+			return []string{"192.0.2.10"}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return svc, &lookups
+}
+
+func TestEmailValidationCheck(t *testing.T) {
+	svc, lookups := newEmailValidationTestService(EmailValidationModeBlock)
+
+	cases := map[string]string{
+		"user@mail.example":         "",
+		"user@MAILINATOR.com":       EmailRiskDisposable,
+		"user@inbox.yopmail.com":    EmailRiskDisposable,
+		"user@burner.example":       EmailRiskDisposable,
+		"user@nullmx.example":       EmailRiskNoMX,
+		"user@missing.example":      EmailRiskNoMX,
+		"user@apex.example":         "",
+		"user@flaky.example":        "",
+		"user@corp.example":         "",
+		"user@sales.corp.example":   "",
+		"user@notmailinator.com.io": EmailRiskNoMX,
+	}
+	for email, want := range cases {
+		if got := svc.Check(context.Background(), email); got != want {
+			t.Fatalf("Check(%q) = %q, want %q", email, got, want)
+		}
+	}
+
+	// 结论缓存后不再查询 DNS；DNS 故障的结果不缓存
+	before := *lookups
+	svc.Check(context.Background(), "other@missing.example")
+	if *lookups != before {
+		t.Fatalf("expected cached MX result, lookups went from %d to %d", before, *lookups)
+	}
+	svc.Check(context.Background(), "other@flaky.example")
+	if *lookups != before+1 {
+		t.Fatalf("expected DNS failure not to be cached")
+	}
+}
+
+func TestAuthServiceScreenEmailModes(t *testing.T) {
+	for _, tc := range []struct {
+		mode    string
+		email   string
+		wantKey string
+		flag    string
+	}{
+		{EmailValidationModeOff, "user@mailinator.com", "", ""},
+		{EmailValidationModeWarn, "user@mailinator.com", "", ""},
+		{EmailValidationModeFlag, "user@mailinator.com", "", EmailRiskDisposable},
+		{EmailValidationModeFlag, "user@mail.example", "", ""},
+		{EmailValidationModeBlock, "user@mailinator.com", "auth.emailDisposable", ""},
+		{EmailValidationModeBlock, "user@missing.example", "auth.emailDomainUnreachable", ""},
+	} {
+		validator, _ := newEmailValidationTestService(tc.mode)
+		auth := NewAuthService(nil, validator.cfg)
+		auth.SetEmailValidation(validator)
+
+		flag, err := auth.ScreenEmail(tc.email)
+		if tc.wantKey != "" {
+			var bizErr *bizerr.Error
+			if !errors.As(err, &bizErr) || bizErr.Key != tc.wantKey {
+				t.Fatalf("mode %s %s: expected %s, got %v", tc.mode, tc.email, tc.wantKey, err)
+			}
+			continue
+		}
+		if err != nil || flag != tc.flag {
+			t.Fatalf("mode %s %s: expected flag %q, got %q err=%v", tc.mode, tc.email, tc.flag, flag, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/authbiz"
+)
+
+// 邮箱验证限制方式，对应 security.login.email_verification_mode
+const (
+	EmailVerificationModeLogin         = "login"
+	EmailVerificationModeCheckout      = "checkout"
+	EmailVerificationModeVirtualReveal = "virtual_reveal"
+	EmailVerificationModeWarn          = "warn"
+)
+
+// ResolveEmailVerificationMode 返回生效的限制方式；未开启邮箱验证时返回空字符串
+func ResolveEmailVerificationMode(cfg *config.Config) string {
+	if cfg == nil || !cfg.Security.Login.RequireEmailVerification {
+		return ""
+	}
+	switch cfg.Security.Login.EmailVerificationMode {
+	case EmailVerificationModeCheckout, EmailVerificationModeVirtualReveal, EmailVerificationModeWarn:
+		return cfg.Security.Login.EmailVerificationMode
+	default:
+		return EmailVerificationModeLogin
+	}
+}
+
+// EmailVerificationPending 用户是否仍受邮箱验证限制（管理员、无邮箱与被豁免的用户除外）
+func EmailVerificationPending(cfg *config.Config, user *models.User) bool {
+	if user == nil || ResolveEmailVerificationMode(cfg) == "" {
+		return false
+	}
+	return !user.EmailVerified && user.Email != "" && !user.EmailVerificationExempt && !user.IsAdmin()
+}
+
+// emailVerificationModeLevel 限制由强到弱：login > checkout > virtual_reveal > warn，
+// 较强的方式同时包含较弱方式的限制
+var emailVerificationModeLevel = map[string]int{
+	EmailVerificationModeLogin:         3,
+	EmailVerificationModeCheckout:      2,
+	EmailVerificationModeVirtualReveal: 1,
+	EmailVerificationModeWarn:          0,
+}
+
+// CheckEmailVerification 按当前限制方式校验用户能否执行 action（login/checkout/virtual_reveal）
+func CheckEmailVerification(cfg *config.Config, user *models.User, action string) error {
+	if !EmailVerificationPending(cfg, user) {
+		return nil
+	}
+	actionLevel, ok := emailVerificationModeLevel[action]
+	if !ok || actionLevel == 0 || emailVerificationModeLevel[ResolveEmailVerificationMode(cfg)] < actionLevel {
+		return nil
+	}
+	switch action {
+	case EmailVerificationModeLogin:
+		return authbiz.EmailNotVerified()
+	case EmailVerificationModeCheckout:
+		return authbiz.EmailNotVerifiedForCheckout()
+	default:
+		return authbiz.EmailNotVerifiedForVirtualReveal()
+	}
+}
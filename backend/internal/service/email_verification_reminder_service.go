@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	// 每轮最多提醒的用户数，避免积压时一次性发出大量邮件
+	emailVerificationReminderBatchSize = 200
+	emailVerificationReminderTokenTTL  = 24 * time.Hour
+)
+
+// DefaultEmailVerificationReminderSchedule 未配置时的提醒节奏（注册后第 1、3、7 天）
+var DefaultEmailVerificationReminderSchedule = []int{24, 72, 168}
+
+// EmailVerificationReminderService 邮箱验证提醒服务
+// 按 security.login.email_verification_reminder.schedule_hours 对未验证邮箱的用户逐次重发验证邮件
+type EmailVerificationReminderService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	emailService  *EmailService
+	checkInterval time.Duration
+}
+
+// NewEmailVerificationReminderService 创建邮箱验证提醒服务
+func NewEmailVerificationReminderService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *EmailVerificationReminderService {
+	return &EmailVerificationReminderService{
+		db:            db,
+		cfg:           cfg,
+		emailService:  emailService,
+		checkInterval: time.Hour,
+	}
+}
+
+// RegisterJobs 注册邮箱验证提醒任务
+func (s *EmailVerificationReminderService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "email_verification_reminder",
+		Description: "Resend verification emails to users who have not verified their email address",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			sent, err := s.SendDueReminders(time.Now())
+			if sent > 0 {
+				logger.LogSystemOperation(s.db, "email_verification_reminder_sent", "system", nil, map[string]interface{}{
+					"count": sent,
+				})
+			}
+			return err
+		},
+	})
+}
+
+func (s *EmailVerificationReminderService) schedule() []int {
+	hours := s.cfg.Security.Login.EmailVerificationReminder.ScheduleHours
+	if len(hours) == 0 {
+		return DefaultEmailVerificationReminderSchedule
+	}
+	return hours
+}
+
+// SendDueReminders 给已到提醒时间的未验证用户重发验证邮件，返回发送数量
+func (s *EmailVerificationReminderService) SendDueReminders(now time.Time) (int, error) {
+	if s.cfg == nil || s.emailService == nil || !s.emailService.IsEnabled() ||
+		ResolveEmailVerificationMode(s.cfg) == "" || !s.cfg.Security.Login.EmailVerificationReminder.Enabled {
+		return 0, nil
+	}
+
+	users, err := s.dueUsers(now)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range users {
+		user := &users[i]
+		if err := s.remind(user, now); err != nil {
+			logger.LogSystemOperation(s.db, "email_verification_reminder_failed", "user", &user.ID, map[string]interface{}{
+				"error": err.Error(),
+			})
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// dueUsers 查找下一次提醒已到期的用户：第 n 次提醒在注册后 schedule[n] 小时发送
+func (s *EmailVerificationReminderService) dueUsers(now time.Time) ([]models.User, error) {
+	var due []models.User
+	for step, hours := range s.schedule() {
+		remaining := emailVerificationReminderBatchSize - len(due)
+		if remaining <= 0 {
+			break
+		}
+		var users []models.User
+		if err := s.db.Where("email_verified = ? AND email_verification_exempt = ? AND email <> ''", false, false).
+			Where("role = ? AND is_active = ?", "user", true).
+			Where("verification_reminders_sent = ? AND created_at <= ?", step, now.Add(-time.Duration(hours)*time.Hour)).
+			Order("id ASC").
+			Limit(remaining).
+			Find(&users).Error; err != nil {
+			return nil, err
+		}
+		due = append(due, users...)
+	}
+	return due, nil
+}
+
+// remind 作废旧 token 后签发新 token 并发送验证邮件，与用户主动重发验证邮件的流程一致
+func (s *EmailVerificationReminderService) remind(user *models.User, now time.Time) error {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EmailVerificationToken{}).
+			Where("user_id = ? AND used = ?", user.ID, false).
+			Update("used", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.EmailVerificationToken{
+			Token:     token,
+			UserID:    user.ID,
+			ExpiresAt: now.Add(emailVerificationReminderTokenTTL),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"verification_reminders_sent":   gorm.Expr("verification_reminders_sent + 1"),
+			"last_verification_reminder_at": now,
+		}).Error
+	}); err != nil {
+		return err
+	}
+
+	return s.emailService.SendVerificationEmail(user.Email, user.Name, token, user.Locale)
+}
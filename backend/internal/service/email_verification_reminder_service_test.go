@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openEmailVerificationReminderTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:email-verification-reminder-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.EmailVerificationToken{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestEmailVerificationReminderDueUsersFollowsSchedule(t *testing.T) {
+	db := openEmailVerificationReminderTestDB(t)
+	cfg := &config.Config{}
+	cfg.Security.Login.RequireEmailVerification = true
+	cfg.Security.Login.EmailVerificationReminder.Enabled = true
+	svc := NewEmailVerificationReminderService(db, cfg, nil)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	users := []models.User{
+		{UUID: "u-new", Email: "new@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-2 * time.Hour)},
+		{UUID: "u-day1", Email: "day1@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-30 * time.Hour)},
+		{UUID: "u-step1", Email: "step1@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-50 * time.Hour), VerificationRemindersSent: 1},
+		{UUID: "u-day3", Email: "day3@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-80 * time.Hour), VerificationRemindersSent: 1},
+		{UUID: "u-done", Email: "done@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-400 * time.Hour), VerificationRemindersSent: 3},
+		{UUID: "u-verified", Email: "ok@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-30 * time.Hour), EmailVerified: true},
+		{UUID: "u-exempt", Email: "exempt@example.com", Role: "user", IsActive: true, CreatedAt: now.Add(-30 * time.Hour), EmailVerificationExempt: true},
+		{UUID: "u-admin", Email: "admin@example.com", Role: "admin", IsActive: true, CreatedAt: now.Add(-30 * time.Hour)},
+	}
+	for i := range users {
+		if err := db.Create(&users[i]).Error; err != nil {
+			t.Fatalf("create user failed: %v", err)
+		}
+	}
+
+	due, err := svc.dueUsers(now)
+	if err != nil {
+		t.Fatalf("dueUsers failed: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, user := range due {
+		got[user.UUID] = true
+	}
+	if len(due) != 2 || !got["u-day1"] || !got["u-day3"] {
+		t.Fatalf("unexpected due users: %+v", got)
+	}
+
+	// 邮件服务未配置时不发送也不推进提醒进度
+	if sent, err := svc.SendDueReminders(now); err != nil || sent != 0 {
+		t.Fatalf("expected no reminders without email service, sent=%d err=%v", sent, err)
+	}
+}
+
+func TestCheckEmailVerificationModes(t *testing.T) {
+	user := &models.User{Email: "user@example.com", Role: "user"}
+	cases := []struct {
+		mode    string
+		blocked map[string]string
+	}{
+		{EmailVerificationModeLogin, map[string]string{
+			EmailVerificationModeLogin:         "auth.emailNotVerified",
+			EmailVerificationModeCheckout:      "auth.emailNotVerifiedForCheckout",
+			EmailVerificationModeVirtualReveal: "auth.emailNotVerifiedForVirtualReveal",
+		}},
+		{EmailVerificationModeCheckout, map[string]string{
+			EmailVerificationModeCheckout:      "auth.emailNotVerifiedForCheckout",
+			EmailVerificationModeVirtualReveal: "auth.emailNotVerifiedForVirtualReveal",
+		}},
+		{EmailVerificationModeVirtualReveal, map[string]string{
+			EmailVerificationModeVirtualReveal: "auth.emailNotVerifiedForVirtualReveal",
+		}},
+		{EmailVerificationModeWarn, map[string]string{}},
+	}
+
+	for _, tc := range cases {
+		cfg := &config.Config{}
+		cfg.Security.Login.RequireEmailVerification = true
+		cfg.Security.Login.EmailVerificationMode = tc.mode
+		for _, action := range []string{EmailVerificationModeLogin, EmailVerificationModeCheckout, EmailVerificationModeVirtualReveal} {
+			err := CheckEmailVerification(cfg, user, action)
+			wantKey, wantBlocked := tc.blocked[action]
+			if !wantBlocked {
+				if err != nil {
+					t.Fatalf("mode=%s action=%s: expected allowed, got %v", tc.mode, action, err)
+				}
+				continue
+			}
+			var bizErr *bizerr.Error
+			if !errors.As(err, &bizErr) || bizErr.Key != wantKey {
+				t.Fatalf("mode=%s action=%s: expected %s, got %v", tc.mode, action, wantKey, err)
+			}
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Security.Login.RequireEmailVerification = true
+	exempt := &models.User{Email: "user@example.com", Role: "user", EmailVerificationExempt: true}
+	if err := CheckEmailVerification(cfg, exempt, EmailVerificationModeLogin); err != nil {
+		t.Fatalf("exempt user should not be blocked: %v", err)
+	}
+}
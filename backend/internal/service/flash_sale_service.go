@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/logger"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const (
+	flashSaleStockKeyPrefix       = "flash_sale:stock:"
+	flashSalePendingKeyPrefix     = "flash_sale:pending:"
+	flashSaleJournalKey           = "flash_sale:journal"
+	defaultFlashSaleSyncInterval  = time.Second
+	defaultFlashSaleSyncBatchSize = 1000
+)
+
+var (
+	// 计数不存在时以数据库可售数减去尚未写回的预留作为初始值，之后所有扣减都在 Redis 内原子完成
+	flashSaleReserveScript = redis.NewScript(`
+local stock = redis.call('GET', KEYS[1])
+if not stock then
+  local pending = tonumber(redis.call('GET', KEYS[2]) or '0')
+  stock = tonumber(ARGV[2]) - pending
+  redis.call('SET', KEYS[1], stock)
+else
+  stock = tonumber(stock)
+end
+local qty = tonumber(ARGV[1])
+if stock < qty then
+  return {0, stock}
+end
+redis.call('DECRBY', KEYS[1], qty)
+redis.call('INCRBY', KEYS[2], qty)
+redis.call('RPUSH', KEYS[3], ARGV[3])
+return {1, stock - qty}
+`)
+	flashSaleReleaseScript = redis.NewScript(`
+local qty = tonumber(ARGV[1])
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  redis.call('INCRBY', KEYS[1], qty)
+end
+redis.call('DECRBY', KEYS[2], qty)
+redis.call('RPUSH', KEYS[3], ARGV[2])
+return 1
+`)
+	flashSaleJournalPopScript = redis.NewScript(`
+local items = redis.call('LRANGE', KEYS[1], 0, tonumber(ARGV[1]) - 1)
+if #items > 0 then
+  redis.call('LTRIM', KEYS[1], #items, -1)
+end
+return items
+`)
+)
+
+// flashSaleJournalEntry 尚未写回数据库的预留变动，Quantity 为正表示预留、为负表示释放
+type flashSaleJournalEntry struct {
+	InventoryID uint   `json:"inventory_id"`
+	Quantity    int    `json:"quantity"`
+	OrderNo     string `json:"order_no"`
+}
+
+// FlashSaleStatus 秒杀库存的 Redis 计数状态
+type FlashSaleStatus struct {
+	InventoryID uint `json:"inventory_id"`
+	FlashSale   bool `json:"flash_sale"`
+	Active      bool `json:"active"`    // 全局开关与 Redis 均可用，预留正在走 Redis 计数
+	Remaining   *int `json:"remaining"` // 计数尚未初始化时为空
+	Pending     int  `json:"pending"`   // 尚未写回数据库的预留数量
+}
+
+// FlashSaleService 秒杀模式库存预留
+// 标记为秒杀的库存在下单时只做 Redis 原子扣减，售罄立即失败；预留变动写入日志队列，由后台任务批量写回数据库，
+// 避免数百 RPS 下所有请求排队等待同一行锁
+type FlashSaleService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	checkInterval time.Duration
+}
+
+// NewFlashSaleService 创建秒杀库存服务
+func NewFlashSaleService(db *gorm.DB, cfg *config.Config) *FlashSaleService {
+	interval := defaultFlashSaleSyncInterval
+	if cfg != nil && cfg.Order.FlashSale.SyncIntervalMs > 0 {
+		interval = time.Duration(cfg.Order.FlashSale.SyncIntervalMs) * time.Millisecond
+	}
+	return &FlashSaleService{
+		db:            db,
+		cfg:           cfg,
+		checkInterval: interval,
+	}
+}
+
+// Enabled 秒杀模式是否可用（全局开关开启且 Redis 已初始化）
+func (s *FlashSaleService) Enabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Order.FlashSale.Enabled && cache.RedisClient != nil
+}
+
+func (s *FlashSaleService) batchSize() int {
+	if s.cfg != nil && s.cfg.Order.FlashSale.SyncBatchSize > 0 {
+		return s.cfg.Order.FlashSale.SyncBatchSize
+	}
+	return defaultFlashSaleSyncBatchSize
+}
+
+func flashSaleStockKey(inventoryID uint) string {
+	return flashSaleStockKeyPrefix + strconv.FormatUint(uint64(inventoryID), 10)
+}
+
+func flashSalePendingKey(inventoryID uint) string {
+	return flashSalePendingKeyPrefix + strconv.FormatUint(uint64(inventoryID), 10)
+}
+
+// flashSaleSeed 以数据库计数推算的可售数量，作为 Redis 计数的初始值
+func flashSaleSeed(inventory *models.Inventory) int {
+	seed := inventory.GetAvailableStock()
+	if remaining := inventory.GetRemainingStock(); remaining < seed {
+		seed = remaining
+	}
+	if seed < 0 {
+		return 0
+	}
+	return seed
+}
+
+// InvalidateFlashSaleCounter 删除库存的 Redis 计数，下次预留时按数据库重新初始化
+// 管理员修改库存数量或切换秒杀模式后调用
+func InvalidateFlashSaleCounter(inventoryID uint) {
+	if cache.RedisClient == nil {
+		return
+	}
+	if err := cache.RedisClient.Del(cache.RedisClient.Context(), flashSaleStockKey(inventoryID)).Err(); err != nil {
+		log.Printf("flash sale counter invalidate failed: inventory=%d err=%v", inventoryID, err)
+	}
+}
+
+// flashSalePendingReservations 批量读取尚未写回数据库的预留数量，Redis 不可用时返回空
+func flashSalePendingReservations(inventoryIDs []uint) map[uint]int {
+	pending := make(map[uint]int)
+	if cache.RedisClient == nil || len(inventoryIDs) == 0 {
+		return pending
+	}
+	keys := make([]string, len(inventoryIDs))
+	for i, id := range inventoryIDs {
+		keys[i] = flashSalePendingKey(id)
+	}
+	values, err := cache.RedisClient.MGet(cache.RedisClient.Context(), keys...).Result()
+	if err != nil {
+		log.Printf("flash sale pending lookup failed: %v", err)
+		return pending
+	}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(raw); err == nil && n != 0 {
+			pending[inventoryIDs[i]] = n
+		}
+	}
+	return pending
+}
+
+func (s *FlashSaleService) loadFlashSaleInventory(inventoryID uint) (*models.Inventory, error) {
+	var inventory models.Inventory
+	if err := s.db.Select("id, stock, available_quantity, sold_quantity, reserved_quantity, is_active, flash_sale").
+		First(&inventory, inventoryID).Error; err != nil {
+		return nil, err
+	}
+	if !inventory.FlashSale {
+		return nil, nil
+	}
+	return &inventory, nil
+}
+
+// Remaining 秒杀库存当前可售数量，非秒杀库存或秒杀模式不可用时 ok 为 false
+func (s *FlashSaleService) Remaining(inventory *models.Inventory) (int, bool) {
+	if inventory == nil || !inventory.FlashSale || !s.Enabled() {
+		return 0, false
+	}
+	values, err := cache.RedisClient.MGet(cache.RedisClient.Context(),
+		flashSaleStockKey(inventory.ID), flashSalePendingKey(inventory.ID)).Result()
+	if err != nil {
+		return 0, false
+	}
+	if raw, ok := values[0].(string); ok {
+		if n, err := strconv.Atoi(raw); err == nil {
+			return n, true
+		}
+	}
+	pending := 0
+	if raw, ok := values[1].(string); ok {
+		pending, _ = strconv.Atoi(raw)
+	}
+	return flashSaleSeed(inventory) - pending, true
+}
+
+// CanPurchase 秒杀库存按 Redis 计数快速判断能否购买，售罄时无需再进入预留流程；其他库存沿用数据库计数
+func (s *FlashSaleService) CanPurchase(inventory *models.Inventory, quantity int) (bool, string) {
+	remaining, ok := s.Remaining(inventory)
+	if !ok {
+		return inventory.CanPurchase(quantity)
+	}
+	if !inventory.IsActive {
+		return false, "This specification is unavailable"
+	}
+	if quantity > remaining {
+		if remaining <= 0 {
+			return false, "This specification is sold out"
+		}
+		return false, fmt.Sprintf("Insufficient stock, available quantity: %d", remaining)
+	}
+	return true, ""
+}
+
+// Reserve 秒杀库存走 Redis 原子预留；handled 为 false 时调用方应使用数据库预留
+func (s *FlashSaleService) Reserve(inventoryID uint, quantity int, orderNo string) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+	inventory, err := s.loadFlashSaleInventory(inventoryID)
+	if err != nil || inventory == nil {
+		return false, nil
+	}
+	if !inventory.IsActive {
+		return true, fmt.Errorf("This specification is unavailable")
+	}
+
+	entry, _ := json.Marshal(flashSaleJournalEntry{InventoryID: inventoryID, Quantity: quantity, OrderNo: orderNo})
+	result, err := flashSaleReserveScript.Run(cache.RedisClient.Context(), cache.RedisClient,
+		[]string{flashSaleStockKey(inventoryID), flashSalePendingKey(inventoryID), flashSaleJournalKey},
+		quantity, flashSaleSeed(inventory), string(entry)).Int64Slice()
+	if err != nil {
+		// 不退回数据库行锁预留，否则 Redis 恢复后计数会比实际多，造成超卖
+		return true, fmt.Errorf("flash sale reserve failed: %w", err)
+	}
+	if len(result) < 2 || result[0] == 1 {
+		return true, nil
+	}
+	if result[1] <= 0 {
+		return true, fmt.Errorf("This specification is sold out")
+	}
+	return true, fmt.Errorf("Insufficient stock, available quantity: %d", result[1])
+}
+
+// Release 释放秒杀库存预留；handled 为 false 时调用方应使用数据库释放
+func (s *FlashSaleService) Release(inventoryID uint, quantity int, orderNo string) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+	inventory, err := s.loadFlashSaleInventory(inventoryID)
+	if err != nil || inventory == nil {
+		return false, nil
+	}
+
+	entry, _ := json.Marshal(flashSaleJournalEntry{InventoryID: inventoryID, Quantity: -quantity, OrderNo: orderNo})
+	if err := flashSaleReleaseScript.Run(cache.RedisClient.Context(), cache.RedisClient,
+		[]string{flashSaleStockKey(inventoryID), flashSalePendingKey(inventoryID), flashSaleJournalKey},
+		quantity, string(entry)).Err(); err != nil {
+		return true, fmt.Errorf("flash sale release failed: %w", err)
+	}
+	return true, nil
+}
+
+// Status 查询秒杀库存的计数状态
+func (s *FlashSaleService) Status(inventory *models.Inventory) FlashSaleStatus {
+	status := FlashSaleStatus{InventoryID: inventory.ID, FlashSale: inventory.FlashSale, Active: inventory.FlashSale && s.Enabled()}
+	if cache.RedisClient == nil {
+		return status
+	}
+	status.Pending = flashSalePendingReservations([]uint{inventory.ID})[inventory.ID]
+	if raw, err := cache.RedisClient.Get(cache.RedisClient.Context(), flashSaleStockKey(inventory.ID)).Result(); err == nil {
+		if n, err := strconv.Atoi(raw); err == nil {
+			status.Remaining = &n
+		}
+	}
+	return status
+}
+
+// RegisterJobs 注册预留变动写回任务
+func (s *FlashSaleService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "flash_sale_sync",
+		Description: "Write flash sale stock reservations journaled in Redis back to the database",
+		Interval:    s.checkInterval,
+		Run:         s.drain,
+	})
+}
+
+// Flush 写回队列中剩余的变动，进程退出时在调度器停止后调用
+func (s *FlashSaleService) Flush() {
+	if err := s.drain(context.Background()); err != nil {
+		logger.LogSystemOperation(s.db, "flash_sale_sync_failed", "system", nil, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// drain 写回队列中的全部变动，关闭秒杀模式后仍需处理遗留的变动
+func (s *FlashSaleService) drain(ctx context.Context) error {
+	if cache.RedisClient == nil {
+		return nil
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		synced, err := s.Sync()
+		if err != nil {
+			return err
+		}
+		if synced < s.batchSize() {
+			return nil
+		}
+	}
+}
+
+// Sync 从队列取出一批预留变动，在一个事务内按库存合并写回 reserved_quantity 并记录库存日志
+// 写回失败时变动重新放回队列；进程在取出后、提交前崩溃导致的偏差由每日库存对账发现
+func (s *FlashSaleService) Sync() (int, error) {
+	if cache.RedisClient == nil {
+		return 0, nil
+	}
+	ctx := cache.RedisClient.Context()
+	raw, err := flashSaleJournalPopScript.Run(ctx, cache.RedisClient, []string{flashSaleJournalKey}, s.batchSize()).StringSlice()
+	if err != nil || len(raw) == 0 {
+		return 0, err
+	}
+
+	entries := make([]flashSaleJournalEntry, 0, len(raw))
+	deltas := make(map[uint]int)
+	for _, item := range raw {
+		var entry flashSaleJournalEntry
+		if err := json.Unmarshal([]byte(item), &entry); err != nil || entry.InventoryID == 0 || entry.Quantity == 0 {
+			log.Printf("flash sale journal entry skipped: %q", item)
+			continue
+		}
+		entries = append(entries, entry)
+		deltas[entry.InventoryID] += entry.Quantity
+	}
+	inventoryIDs := make([]uint, 0, len(deltas))
+	for id := range deltas {
+		inventoryIDs = append(inventoryIDs, id)
+	}
+	sort.Slice(inventoryIDs, func(i, j int) bool { return inventoryIDs[i] < inventoryIDs[j] })
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var inventories []models.Inventory
+		if err := tx.Unscoped().Select("id, reserved_quantity").Where("id IN ?", inventoryIDs).Find(&inventories).Error; err != nil {
+			return err
+		}
+		reserved := make(map[uint]int, len(inventories))
+		for _, inventory := range inventories {
+			reserved[inventory.ID] = inventory.ReservedQuantity
+		}
+
+		logs := make([]models.InventoryLog, 0, len(entries))
+		for _, entry := range entries {
+			if _, ok := reserved[entry.InventoryID]; !ok {
+				continue
+			}
+			before := reserved[entry.InventoryID]
+			after := before + entry.Quantity
+			if after < 0 {
+				after = 0
+			}
+			reserved[entry.InventoryID] = after
+			logType, quantity, reason := models.InventoryLogTypeReserve, entry.Quantity, "Reserve inventory for order (flash sale)"
+			if entry.Quantity < 0 {
+				logType, quantity, reason = models.InventoryLogTypeRelease, -entry.Quantity, "Release reserved inventory (flash sale)"
+			}
+			logs = append(logs, models.InventoryLog{
+				InventoryID: entry.InventoryID,
+				Type:        logType,
+				Quantity:    quantity,
+				BeforeStock: before,
+				AfterStock:  after,
+				OrderNo:     entry.OrderNo,
+				Operator:    "system",
+				Reason:      reason,
+			})
+		}
+
+		for _, id := range inventoryIDs {
+			if _, ok := reserved[id]; !ok {
+				continue
+			}
+			// 相对更新，避免覆盖同一时间走数据库路径的预留（例如刚关闭秒杀模式）
+			delta := deltas[id]
+			if err := tx.Model(&models.Inventory{}).Unscoped().Where("id = ?", id).
+				Update("reserved_quantity", gorm.Expr("CASE WHEN reserved_quantity + ? < 0 THEN 0 ELSE reserved_quantity + ? END", delta, delta)).Error; err != nil {
+				return err
+			}
+		}
+		if len(logs) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(logs, 200).Error
+	})
+	if err != nil {
+		requeue := make([]interface{}, len(raw))
+		for i, item := range raw {
+			requeue[i] = item
+		}
+		if pushErr := cache.RedisClient.RPush(ctx, flashSaleJournalKey, requeue...).Err(); pushErr != nil {
+			log.Printf("flash sale journal requeue failed, %d entries lost: %v", len(raw), pushErr)
+		}
+		return 0, err
+	}
+
+	pipe := cache.RedisClient.Pipeline()
+	for id, delta := range deltas {
+		pipe.DecrBy(ctx, flashSalePendingKey(id), int64(delta))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("flash sale pending counter update failed: %v", err)
+	}
+	return len(raw), nil
+}
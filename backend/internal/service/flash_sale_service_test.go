@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestFlashSaleReserveSellsOutAndSyncsReservations(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		if cache.RedisClient != nil {
+			_ = cache.RedisClient.Close()
+		}
+		cache.RedisClient = previousClient
+	}()
+
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	flash := &models.Inventory{Name: "flash", Stock: 5, AvailableQuantity: 5, ReservedQuantity: 2, IsActive: true, FlashSale: true}
+	regular := &models.Inventory{Name: "regular", Stock: 5, AvailableQuantity: 5, IsActive: true}
+	for _, inventory := range []*models.Inventory{flash, regular} {
+		if err := db.Create(inventory).Error; err != nil {
+			t.Fatalf("create inventory: %v", err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Order.FlashSale = config.FlashSaleConfig{Enabled: true}
+	svc := NewFlashSaleService(db, cfg)
+
+	if handled, _ := svc.Reserve(regular.ID, 1, "REGULAR-1"); handled {
+		t.Fatalf("regular inventory must fall through to database reservation")
+	}
+
+	for i, orderNo := range []string{"FS-1", "FS-2", "FS-3"} {
+		if handled, err := svc.Reserve(flash.ID, 1, orderNo); !handled || err != nil {
+			t.Fatalf("reserve #%d: handled=%v err=%v", i+1, handled, err)
+		}
+	}
+	if _, err := svc.Reserve(flash.ID, 1, "FS-4"); err == nil || err.Error() != "This specification is sold out" {
+		t.Fatalf("expected sold out error, got %v", err)
+	}
+	if ok, msg := svc.CanPurchase(flash, 1); ok || msg != "This specification is sold out" {
+		t.Fatalf("expected fail-fast sold out, got ok=%v msg=%q", ok, msg)
+	}
+
+	if handled, err := svc.Release(flash.ID, 1, "FS-2"); !handled || err != nil {
+		t.Fatalf("release: handled=%v err=%v", handled, err)
+	}
+	if remaining, ok := svc.Remaining(flash); !ok || remaining != 1 {
+		t.Fatalf("expected 1 remaining after release, got %d (ok=%v)", remaining, ok)
+	}
+
+	if synced, err := svc.Sync(); err != nil || synced != 4 {
+		t.Fatalf("sync: synced=%d err=%v", synced, err)
+	}
+	var reloaded models.Inventory
+	if err := db.First(&reloaded, flash.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if reloaded.ReservedQuantity != 4 {
+		t.Fatalf("expected reserved quantity 4 after sync, got %d", reloaded.ReservedQuantity)
+	}
+	if pending := flashSalePendingReservations([]uint{flash.ID}); pending[flash.ID] != 0 {
+		t.Fatalf("expected no pending reservations after sync, got %d", pending[flash.ID])
+	}
+	var logCount int64
+	db.Model(&models.InventoryLog{}).Where("inventory_id = ?", flash.ID).Count(&logCount)
+	if logCount != 4 {
+		t.Fatalf("expected 4 inventory logs, got %d", logCount)
+	}
+
+	// 管理员补货后计数按数据库重新初始化
+	if err := db.Model(&models.Inventory{}).Where("id = ?", flash.ID).
+		Updates(map[string]interface{}{"stock": 10, "available_quantity": 10}).Error; err != nil {
+		t.Fatalf("restock: %v", err)
+	}
+	InvalidateFlashSaleCounter(flash.ID)
+	if handled, err := svc.Reserve(flash.ID, 6, "FS-5"); !handled || err != nil {
+		t.Fatalf("reserve after restock: handled=%v err=%v", handled, err)
+	}
+	if _, err := svc.Reserve(flash.ID, 1, "FS-6"); err == nil {
+		t.Fatalf("expected sold out after reseeding from database")
+	}
+}
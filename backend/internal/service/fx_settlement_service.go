@@ -0,0 +1,275 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+const fxSettlementMonthFormat = "2006-01"
+
+// orderFXSnapshot 付款时记录在订单上的汇率快照
+type orderFXSnapshot struct {
+	BaseCurrency string
+	Rate         float64
+	BaseAmount   int64
+}
+
+// resolveOrderFXSnapshot 按当前配置将订单金额折算为记账本位币；未配置该币种汇率时 Rate 为 0
+func resolveOrderFXSnapshot(cfg *config.Config, currency string, amountMinor int64) orderFXSnapshot {
+	baseCurrency := fxSettlementBaseCurrency(cfg)
+	snapshot := orderFXSnapshot{BaseCurrency: baseCurrency}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == baseCurrency {
+		snapshot.Rate = 1
+		snapshot.BaseAmount = amountMinor
+		return snapshot
+	}
+	if cfg == nil {
+		return snapshot
+	}
+	for code, rate := range cfg.Order.FXSettlement.Rates {
+		if strings.ToUpper(strings.TrimSpace(code)) != currency || rate <= 0 {
+			continue
+		}
+		snapshot.Rate = rate
+		snapshot.BaseAmount = convertToBaseMinor(amountMinor, rate)
+		break
+	}
+	return snapshot
+}
+
+func fxSettlementBaseCurrency(cfg *config.Config) string {
+	if cfg == nil {
+		return "CNY"
+	}
+	if base := strings.ToUpper(strings.TrimSpace(cfg.Order.FXSettlement.BaseCurrency)); base != "" {
+		return base
+	}
+	if currency := strings.ToUpper(strings.TrimSpace(cfg.Order.Currency)); currency != "" {
+		return currency
+	}
+	return "CNY"
+}
+
+func convertToBaseMinor(amountMinor int64, rate float64) int64 {
+	return int64(math.Round(float64(amountMinor) * rate))
+}
+
+// FXSettlementOrderRow 单个已付款订单的本位币折算明细
+type FXSettlementOrderRow struct {
+	OrderID             uint      `json:"order_id"`
+	OrderNo             string    `json:"order_no"`
+	Status              string    `json:"status"`
+	PaidAt              time.Time `json:"paid_at"`
+	Currency            string    `json:"currency"`
+	Gateway             string    `json:"gateway"` // 付款方式名称，管理员手动标记付款时为空
+	AmountMinor         int64     `json:"amount_minor"`
+	RefundedAmountMinor int64     `json:"refunded_amount_minor"`
+	FXRate              float64   `json:"fx_rate"`
+	BaseCurrency        string    `json:"base_currency"`
+	BaseAmountMinor     int64     `json:"base_amount_minor"`
+	RefundedBaseMinor   int64     `json:"refunded_base_amount_minor"`
+}
+
+// Converted 付款时是否已配置该币种汇率
+func (r FXSettlementOrderRow) Converted() bool {
+	return r.FXRate > 0
+}
+
+// FXSettlementSummaryRow 按月份、币种、付款方式汇总的结算数据
+type FXSettlementSummaryRow struct {
+	Month               string `json:"month"`
+	Currency            string `json:"currency"`
+	Gateway             string `json:"gateway"`
+	BaseCurrency        string `json:"base_currency"`
+	OrderCount          int64  `json:"order_count"`
+	GrossAmountMinor    int64  `json:"gross_amount_minor"`
+	RefundedCount       int64  `json:"refunded_count"`
+	RefundedAmountMinor int64  `json:"refunded_amount_minor"`
+	BaseAmountMinor     int64  `json:"base_amount_minor"`
+	RefundedBaseMinor   int64  `json:"refunded_base_amount_minor"`
+	NetBaseAmountMinor  int64  `json:"net_base_amount_minor"`
+	UnconvertedCount    int64  `json:"unconverted_count"` // 付款时未配置汇率、未计入本位币金额的订单数
+}
+
+// FXSettlementReport 结算报表
+type FXSettlementReport struct {
+	BaseCurrency string                   `json:"base_currency"`
+	From         string                   `json:"from"`
+	To           string                   `json:"to"`
+	Rows         []FXSettlementSummaryRow `json:"rows"`
+}
+
+// FXSettlementService 按付款时记录的汇率将订单折算为记账本位币，生成月度结算汇总
+type FXSettlementService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewFXSettlementService(db *gorm.DB, cfg *config.Config) *FXSettlementService {
+	return &FXSettlementService{db: db, cfg: cfg}
+}
+
+// ParseFXSettlementMonthRange 解析 YYYY-MM 格式的起止月份（均包含），为空时默认最近12个月
+func ParseFXSettlementMonthRange(fromValue, toValue string, now time.Time) (time.Time, time.Time, error) {
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	to := currentMonth
+	if trimmed := strings.TrimSpace(toValue); trimmed != "" {
+		parsed, err := time.Parse(fxSettlementMonthFormat, trimmed)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to month: %w", err)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, -11, 0)
+	if trimmed := strings.TrimSpace(fromValue); trimmed != "" {
+		parsed, err := time.Parse(fxSettlementMonthFormat, trimmed)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from month: %w", err)
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from month is after to month")
+	}
+	return from, to, nil
+}
+
+// ListOrders 按付款时间返回区间内已付款订单的折算明细，limit<=0 表示不限制
+func (s *FXSettlementService) ListOrders(fromMonth, toMonth time.Time, limit int) ([]FXSettlementOrderRow, error) {
+	var records []struct {
+		ID             uint
+		OrderNo        string
+		Status         string
+		PaidAt         time.Time
+		Currency       string
+		Gateway        string
+		TotalAmount    int64
+		PaymentFee     int64
+		PaymentFeeKept bool
+		FXRate         float64
+		FXBaseCurrency string
+		FXBaseAmount   int64
+	}
+
+	query := s.db.Table("orders").
+		Select("orders.id, orders.order_no, orders.status, orders.paid_at, orders.currency, "+
+			"COALESCE(payment_methods.name, '') AS gateway, orders.total_amount, orders.payment_fee, "+
+			"orders.payment_fee_retained AS payment_fee_kept, orders.fx_rate, orders.fx_base_currency, orders.fx_base_amount").
+		Joins("LEFT JOIN order_payment_methods ON order_payment_methods.order_id = orders.id").
+		Joins("LEFT JOIN payment_methods ON payment_methods.id = order_payment_methods.payment_method_id").
+		Where("orders.paid_at IS NOT NULL AND orders.paid_at >= ? AND orders.paid_at < ?", fromMonth, toMonth.AddDate(0, 1, 0)).
+		Order("orders.paid_at ASC, orders.id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]FXSettlementOrderRow, 0, len(records))
+	for _, record := range records {
+		row := FXSettlementOrderRow{
+			OrderID:         record.ID,
+			OrderNo:         record.OrderNo,
+			Status:          record.Status,
+			PaidAt:          record.PaidAt.UTC(),
+			Currency:        record.Currency,
+			Gateway:         record.Gateway,
+			AmountMinor:     record.TotalAmount,
+			FXRate:          record.FXRate,
+			BaseCurrency:    record.FXBaseCurrency,
+			BaseAmountMinor: record.FXBaseAmount,
+		}
+		if models.OrderStatus(record.Status) == models.OrderStatusRefunded {
+			order := models.Order{
+				TotalAmount:        record.TotalAmount,
+				PaymentFee:         record.PaymentFee,
+				PaymentFeeRetained: record.PaymentFeeKept,
+			}
+			row.RefundedAmountMinor = order.RefundableAmount()
+			if row.Converted() {
+				row.RefundedBaseMinor = convertToBaseMinor(row.RefundedAmountMinor, row.FXRate)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// MonthlySummary 按月份、订单币种、付款方式及记账本位币汇总
+func (s *FXSettlementService) MonthlySummary(fromMonth, toMonth time.Time) (*FXSettlementReport, error) {
+	orders, err := s.ListOrders(fromMonth, toMonth, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	type summaryKey struct {
+		month, currency, gateway, baseCurrency string
+	}
+	grouped := make(map[summaryKey]*FXSettlementSummaryRow)
+	for _, order := range orders {
+		key := summaryKey{
+			month:        order.PaidAt.Format(fxSettlementMonthFormat),
+			currency:     order.Currency,
+			gateway:      order.Gateway,
+			baseCurrency: order.BaseCurrency,
+		}
+		row, ok := grouped[key]
+		if !ok {
+			row = &FXSettlementSummaryRow{
+				Month:        key.month,
+				Currency:     key.currency,
+				Gateway:      key.gateway,
+				BaseCurrency: key.baseCurrency,
+			}
+			grouped[key] = row
+		}
+		row.OrderCount++
+		row.GrossAmountMinor += order.AmountMinor
+		if order.RefundedAmountMinor > 0 {
+			row.RefundedCount++
+			row.RefundedAmountMinor += order.RefundedAmountMinor
+		}
+		if !order.Converted() {
+			row.UnconvertedCount++
+			continue
+		}
+		row.BaseAmountMinor += order.BaseAmountMinor
+		row.RefundedBaseMinor += order.RefundedBaseMinor
+		row.NetBaseAmountMinor = row.BaseAmountMinor - row.RefundedBaseMinor
+	}
+
+	rows := make([]FXSettlementSummaryRow, 0, len(grouped))
+	for _, row := range grouped {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Month != rows[j].Month {
+			return rows[i].Month > rows[j].Month
+		}
+		if rows[i].Currency != rows[j].Currency {
+			return rows[i].Currency < rows[j].Currency
+		}
+		if rows[i].Gateway != rows[j].Gateway {
+			return rows[i].Gateway < rows[j].Gateway
+		}
+		return rows[i].BaseCurrency < rows[j].BaseCurrency
+	})
+
+	return &FXSettlementReport{
+		BaseCurrency: fxSettlementBaseCurrency(s.cfg),
+		From:         fromMonth.Format(fxSettlementMonthFormat),
+		To:           toMonth.Format(fxSettlementMonthFormat),
+		Rows:         rows,
+	}, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func newFXSettlementTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Order.Currency = "CNY"
+	cfg.Order.FXSettlement.Rates = map[string]float64{"usd": 7.1}
+	return cfg
+}
+
+func TestFinalizePendingPaymentOrderCapturesFXSnapshot(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		t.Fatalf("auto migrate orders failed: %v", err)
+	}
+
+	order := &models.Order{
+		OrderNo:     "FX-FINALIZE-1",
+		Status:      models.OrderStatusPendingPayment,
+		TotalAmount: 1999,
+		Currency:    "USD",
+		Items:       []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1, ProductType: models.ProductTypePhysical}},
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	if _, err := finalizePendingPaymentOrderTx(db, order, nil, paidOrderFinalizeOptions{Config: newFXSettlementTestConfig()}); err != nil {
+		t.Fatalf("finalize order failed: %v", err)
+	}
+
+	var stored models.Order
+	if err := db.First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if stored.PaidAt == nil {
+		t.Fatalf("expected paid_at to be recorded")
+	}
+	if stored.FXBaseCurrency != "CNY" || stored.FXRate != 7.1 || stored.FXBaseAmount != 14193 {
+		t.Fatalf("unexpected fx snapshot: base=%s rate=%v amount=%d", stored.FXBaseCurrency, stored.FXRate, stored.FXBaseAmount)
+	}
+}
+
+func TestResolveOrderFXSnapshotWithoutConfiguredRate(t *testing.T) {
+	cfg := newFXSettlementTestConfig()
+
+	same := resolveOrderFXSnapshot(cfg, "cny", 500)
+	if same.Rate != 1 || same.BaseAmount != 500 {
+		t.Fatalf("expected base currency order to convert 1:1, got %+v", same)
+	}
+
+	missing := resolveOrderFXSnapshot(cfg, "EUR", 500)
+	if missing.Rate != 0 || missing.BaseAmount != 0 || missing.BaseCurrency != "CNY" {
+		t.Fatalf("expected unconfigured currency to stay unconverted, got %+v", missing)
+	}
+}
+
+func TestFXSettlementServiceMonthlySummaryGroupsByCurrencyAndGateway(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.PaymentMethod{}, &models.OrderPaymentMethod{}); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	stripe := models.PaymentMethod{Name: "Stripe", Type: models.PaymentMethodTypeCustom, Enabled: true}
+	if err := db.Create(&stripe).Error; err != nil {
+		t.Fatalf("create payment method failed: %v", err)
+	}
+
+	paidAt := func(month time.Month, day int) *time.Time {
+		value := time.Date(2026, month, day, 12, 0, 0, 0, time.UTC)
+		return &value
+	}
+	orders := []models.Order{
+		{OrderNo: "FX-1", Status: models.OrderStatusCompleted, Currency: "USD", TotalAmount: 1000, PaidAt: paidAt(time.March, 2), FXBaseCurrency: "CNY", FXRate: 7.1, FXBaseAmount: 7100},
+		{OrderNo: "FX-2", Status: models.OrderStatusRefunded, Currency: "USD", TotalAmount: 2000, PaymentFee: 100, PaymentFeeRetained: true, PaidAt: paidAt(time.March, 20), FXBaseCurrency: "CNY", FXRate: 7.1, FXBaseAmount: 14200},
+		{OrderNo: "FX-3", Status: models.OrderStatusPending, Currency: "EUR", TotalAmount: 500, PaidAt: paidAt(time.March, 21), FXBaseCurrency: "CNY"},
+		{OrderNo: "FX-4", Status: models.OrderStatusShipped, Currency: "CNY", TotalAmount: 300, PaidAt: paidAt(time.April, 1), FXBaseCurrency: "CNY", FXRate: 1, FXBaseAmount: 300},
+		{OrderNo: "FX-5", Status: models.OrderStatusCompleted, Currency: "CNY", TotalAmount: 900, PaidAt: paidAt(time.January, 5), FXBaseCurrency: "CNY", FXRate: 1, FXBaseAmount: 900},
+		{OrderNo: "FX-6", Status: models.OrderStatusPendingPayment, Currency: "CNY", TotalAmount: 700},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order %s failed: %v", orders[i].OrderNo, err)
+		}
+	}
+	for _, idx := range []int{0, 1} {
+		if err := db.Create(&models.OrderPaymentMethod{OrderID: orders[idx].ID, PaymentMethodID: stripe.ID}).Error; err != nil {
+			t.Fatalf("create order payment method failed: %v", err)
+		}
+	}
+
+	svc := NewFXSettlementService(db, newFXSettlementTestConfig())
+	report, err := svc.MonthlySummary(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("monthly summary failed: %v", err)
+	}
+	if report.BaseCurrency != "CNY" || len(report.Rows) != 3 {
+		t.Fatalf("expected 3 summary rows in CNY, got base=%s rows=%+v", report.BaseCurrency, report.Rows)
+	}
+
+	april := report.Rows[0]
+	if april.Month != "2026-04" || april.Currency != "CNY" || april.Gateway != "" || april.NetBaseAmountMinor != 300 {
+		t.Fatalf("unexpected april row: %+v", april)
+	}
+
+	eur := report.Rows[1]
+	if eur.Currency != "EUR" || eur.OrderCount != 1 || eur.UnconvertedCount != 1 || eur.BaseAmountMinor != 0 {
+		t.Fatalf("unexpected unconverted EUR row: %+v", eur)
+	}
+
+	usd := report.Rows[2]
+	if usd.Month != "2026-03" || usd.Currency != "USD" || usd.Gateway != "Stripe" {
+		t.Fatalf("unexpected USD row key: %+v", usd)
+	}
+	if usd.OrderCount != 2 || usd.GrossAmountMinor != 3000 || usd.BaseAmountMinor != 21300 {
+		t.Fatalf("unexpected USD totals: %+v", usd)
+	}
+	// 附加费不退还：退款 1900 USD 分，按付款时汇率折算 13490
+	if usd.RefundedCount != 1 || usd.RefundedAmountMinor != 1900 || usd.RefundedBaseMinor != 13490 || usd.NetBaseAmountMinor != 7810 {
+		t.Fatalf("unexpected USD refund totals: %+v", usd)
+	}
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"gorm.io/gorm"
+)
+
+const (
+	giftCardCodeAlphabet    = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉易混淆的 0/O、1/I
+	giftCardCodeGroups      = 4
+	giftCardCodeGroupLength = 4
+	maxGiftCardIssueCount   = 100
+	maxGiftCardTextLength   = 500
+)
+
+var ErrGiftCardNotFound = errors.New("gift card not found")
+
+// GiftCardIssueRequest 发行礼品卡请求，Count 张卡面值相同、卡号各自随机生成
+type GiftCardIssueRequest struct {
+	Count     int
+	ValueType models.GiftCardValueType
+	Amount    int64
+	Currency  string
+	ExpiresAt *time.Time
+	Note      string
+	AdminID   uint
+}
+
+// GiftCardBalance 用户结账前查询的礼品卡可用余额
+type GiftCardBalance struct {
+	Code           string     `json:"code"`
+	Currency       string     `json:"currency"`
+	AvailableMinor int64      `json:"available_minor"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// GiftCardService 礼品卡发行、作废与结账抵扣；
+// 抵扣金额在下单时预留，订单完成时扣减余额，订单取消或删除时释放，订单退款时释放或退回余额，流水表保证每笔订单只结算一次
+type GiftCardService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewGiftCardService 创建礼品卡服务
+func NewGiftCardService(db *gorm.DB, cfg *config.Config) *GiftCardService {
+	return &GiftCardService{db: db, cfg: cfg}
+}
+
+func (s *GiftCardService) settings() config.GiftCardConfig {
+	if s.cfg == nil {
+		return config.GiftCardConfig{}
+	}
+	return s.cfg.Order.GiftCard
+}
+
+func (s *GiftCardService) defaultCurrency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+func giftCardDisabledError() error {
+	return bizerr.New("giftCard.disabled", "Gift cards are not available")
+}
+
+func giftCardNotFoundError() error {
+	return bizerr.New("giftCard.notFound", "Gift card not found")
+}
+
+// NormalizeGiftCardCode 统一卡号格式：忽略大小写、空格与分隔符，按 4 位一组重新加连字符
+func NormalizeGiftCardCode(code string) string {
+	var compact strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			compact.WriteRune(r)
+		}
+	}
+	raw := compact.String()
+	var grouped strings.Builder
+	for i, r := range raw {
+		if i > 0 && i%giftCardCodeGroupLength == 0 {
+			grouped.WriteByte('-')
+		}
+		grouped.WriteRune(r)
+	}
+	return grouped.String()
+}
+
+func generateGiftCardCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	var raw strings.Builder
+	for i := 0; i < giftCardCodeGroups*giftCardCodeGroupLength; i++ {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		raw.WriteByte(giftCardCodeAlphabet[n.Int64()])
+	}
+	return NormalizeGiftCardCode(raw.String()), nil
+}
+
+// validateIssue 校验发行数量与面值：固定面值必须在配置的面值列表中，自定义面值需在上下限之间
+func (s *GiftCardService) validateIssue(req *GiftCardIssueRequest) error {
+	settings := s.settings()
+	if req.Count <= 0 || req.Count > maxGiftCardIssueCount {
+		return bizerr.Newf("giftCard.countInvalid", "Gift card count must be between 1 and %d", maxGiftCardIssueCount).
+			WithParams(map[string]interface{}{"max": maxGiftCardIssueCount})
+	}
+	if req.Amount <= 0 {
+		return bizerr.New("giftCard.valueInvalid", "Gift card value must be greater than 0")
+	}
+	switch req.ValueType {
+	case models.GiftCardValueFixed:
+		allowed := false
+		for _, denomination := range settings.Denominations {
+			if denomination == req.Amount {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return bizerr.New("giftCard.denominationInvalid", "Gift card value is not one of the configured denominations")
+		}
+	case models.GiftCardValueVariable:
+		if (settings.MinValue > 0 && req.Amount < settings.MinValue) || (settings.MaxValue > 0 && req.Amount > settings.MaxValue) {
+			return bizerr.New("giftCard.valueOutOfRange", "Gift card value is outside the allowed range").
+				WithParams(map[string]interface{}{"min": settings.MinValue, "max": settings.MaxValue})
+		}
+	default:
+		return bizerr.Newf("giftCard.valueTypeInvalid", "Invalid gift card value type: %s", req.ValueType).
+			WithParams(map[string]interface{}{"type": req.ValueType})
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(models.NowFunc()) {
+		return bizerr.New("giftCard.expiresAtInvalid", "Expiry time must be in the future")
+	}
+	if len([]rune(req.Note)) > maxGiftCardTextLength {
+		return bizerr.Newf("giftCard.noteTooLong", "Note cannot exceed %d characters", maxGiftCardTextLength).
+			WithParams(map[string]interface{}{"max": maxGiftCardTextLength})
+	}
+	return nil
+}
+
+// Issue 发行礼品卡，未指定过期时间时按 validity_days 计算
+func (s *GiftCardService) Issue(req GiftCardIssueRequest) ([]models.GiftCard, error) {
+	req.Note = strings.TrimSpace(req.Note)
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	if req.Currency == "" {
+		req.Currency = s.defaultCurrency()
+	}
+	if err := s.validateIssue(&req); err != nil {
+		return nil, err
+	}
+	expiresAt := req.ExpiresAt
+	if expiresAt == nil && s.settings().ValidityDays > 0 {
+		expires := models.NowFunc().AddDate(0, 0, s.settings().ValidityDays)
+		expiresAt = &expires
+	}
+	var issuedBy *uint
+	if req.AdminID > 0 {
+		issuedBy = &req.AdminID
+	}
+
+	cards := make([]models.GiftCard, 0, req.Count)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < req.Count; i++ {
+			code, err := s.uniqueCodeTx(tx)
+			if err != nil {
+				return err
+			}
+			card := models.GiftCard{
+				Code:         code,
+				ValueType:    req.ValueType,
+				Currency:     req.Currency,
+				InitialValue: req.Amount,
+				Balance:      req.Amount,
+				Status:       models.GiftCardStatusActive,
+				ExpiresAt:    expiresAt,
+				Note:         req.Note,
+				IssuedBy:     issuedBy,
+			}
+			if err := tx.Create(&card).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&models.GiftCardTransaction{
+				GiftCardID:   card.ID,
+				Type:         models.GiftCardTransactionIssue,
+				Amount:       card.InitialValue,
+				BalanceAfter: card.Balance,
+				OperatorID:   issuedBy,
+			}).Error; err != nil {
+				return err
+			}
+			cards = append(cards, card)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (s *GiftCardService) uniqueCodeTx(tx *gorm.DB) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := generateGiftCardCode()
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := tx.Model(&models.GiftCard{}).Where("code = ?", code).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return code, nil
+		}
+	}
+	return "", errors.New("failed to generate a unique gift card code")
+}
+
+// List 礼品卡列表，search 匹配卡号或备注
+func (s *GiftCardService) List(status, search string, page, limit int) ([]models.GiftCard, int64, error) {
+	query := s.db.Model(&models.GiftCard{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search = strings.TrimSpace(search); search != "" {
+		query = query.Where("code LIKE ? OR note LIKE ?", "%"+strings.ToUpper(search)+"%", "%"+search+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var cards []models.GiftCard
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&cards).Error; err != nil {
+		return nil, 0, err
+	}
+	return cards, total, nil
+}
+
+// Get 礼品卡详情与余额流水（新到旧）
+func (s *GiftCardService) Get(id uint) (*models.GiftCard, []models.GiftCardTransaction, error) {
+	var card models.GiftCard
+	if err := s.db.First(&card, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrGiftCardNotFound
+		}
+		return nil, nil, err
+	}
+	var transactions []models.GiftCardTransaction
+	if err := s.db.Where("gift_card_id = ?", id).Order("id DESC").Find(&transactions).Error; err != nil {
+		return nil, nil, err
+	}
+	return &card, transactions, nil
+}
+
+// Void 作废礼品卡并清零余额；仍有未完成订单预留抵扣时需先处理这些订单
+func (s *GiftCardService) Void(id, adminID uint, reason string) (*models.GiftCard, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxGiftCardTextLength {
+		return nil, bizerr.Newf("giftCard.voidReasonInvalid", "A void reason is required and cannot exceed %d characters", maxGiftCardTextLength).
+			WithParams(map[string]interface{}{"max": maxGiftCardTextLength})
+	}
+	var card models.GiftCard
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.GiftCard{}, "id = ?", id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrGiftCardNotFound
+			}
+			return err
+		}
+		if err := tx.First(&card, id).Error; err != nil {
+			return err
+		}
+		if card.Status == models.GiftCardStatusVoid {
+			return bizerr.New("giftCard.alreadyVoid", "Gift card is already void")
+		}
+		if card.ReservedAmount > 0 {
+			return bizerr.New("giftCard.hasPendingOrders", "Gift card is reserved by unfinished orders").
+				WithParams(map[string]interface{}{"reserved": card.ReservedAmount})
+		}
+		voidedBalance := card.Balance
+		now := models.NowFunc()
+		if err := tx.Model(&card).Updates(map[string]interface{}{
+			"status":      models.GiftCardStatusVoid,
+			"balance":     0,
+			"voided_at":   now,
+			"voided_by":   adminID,
+			"void_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GiftCardTransaction{
+			GiftCardID: card.ID,
+			Type:       models.GiftCardTransactionVoid,
+			Amount:     voidedBalance,
+			OperatorID: &adminID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.First(&card, id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// checkGiftCardUsable 校验礼品卡可用于抵扣：未作废、未过期、有可用余额
+func checkGiftCardUsable(card *models.GiftCard) error {
+	if card.Status != models.GiftCardStatusActive {
+		return bizerr.New("giftCard.void", "Gift card has been voided")
+	}
+	if card.IsExpired() {
+		return bizerr.New("giftCard.expired", "Gift card has expired")
+	}
+	if card.Available() <= 0 {
+		return bizerr.New("giftCard.emptyBalance", "Gift card has no remaining balance")
+	}
+	return nil
+}
+
+// Check 用户结账前查询礼品卡可用余额
+func (s *GiftCardService) Check(code string) (*GiftCardBalance, error) {
+	if !s.settings().Enabled {
+		return nil, giftCardDisabledError()
+	}
+	var card models.GiftCard
+	if err := s.db.Where("code = ?", NormalizeGiftCardCode(code)).First(&card).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, giftCardNotFoundError()
+		}
+		return nil, err
+	}
+	if err := checkGiftCardUsable(&card); err != nil {
+		return nil, err
+	}
+	return &GiftCardBalance{
+		Code:           card.Code,
+		Currency:       card.Currency,
+		AvailableMinor: card.Available(),
+		ExpiresAt:      card.ExpiresAt,
+	}, nil
+}
+
+// ApplyToOrderTx 在下单事务中用礼品卡抵扣订单金额并预留，抵扣额为可用余额与应付金额的较小值；
+// 需在订单写入前调用，抵扣结果写入订单的 GiftCard* 字段并从 TotalAmount 中扣除
+func (s *GiftCardService) ApplyToOrderTx(tx *gorm.DB, code string, order *models.Order) error {
+	if !s.settings().Enabled {
+		return giftCardDisabledError()
+	}
+	code = NormalizeGiftCardCode(code)
+	if err := dbutil.LockForUpdate(tx, &models.GiftCard{}, "code = ?", code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return giftCardNotFoundError()
+		}
+		return err
+	}
+	var card models.GiftCard
+	if err := tx.Where("code = ?", code).First(&card).Error; err != nil {
+		return err
+	}
+	if err := checkGiftCardUsable(&card); err != nil {
+		return err
+	}
+	if !strings.EqualFold(card.Currency, order.Currency) {
+		return bizerr.New("giftCard.currencyMismatch", "Gift card currency does not match the order currency").
+			WithParams(map[string]interface{}{"currency": card.Currency})
+	}
+	amount := card.Available()
+	if amount > order.TotalAmount {
+		amount = order.TotalAmount
+	}
+	if amount <= 0 {
+		return nil
+	}
+	if err := tx.Model(&card).Update("reserved_amount", gorm.Expr("reserved_amount + ?", amount)).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&models.GiftCardTransaction{
+		GiftCardID:   card.ID,
+		Type:         models.GiftCardTransactionReserve,
+		Amount:       amount,
+		BalanceAfter: card.Balance,
+		OrderNo:      order.OrderNo,
+	}).Error; err != nil {
+		return err
+	}
+	order.GiftCardID = &card.ID
+	order.GiftCardCode = card.Code
+	order.GiftCardAmount = amount
+	order.TotalAmount -= amount
+	return nil
+}
+
+// ReleaseForOrder 订单取消或删除时释放预留的抵扣金额
+func (s *GiftCardService) ReleaseForOrder(order *models.Order) error {
+	return s.settleOrder(order, models.GiftCardTransactionRelease)
+}
+
+// RedeemForOrder 订单完成时从余额中扣减预留的抵扣金额
+func (s *GiftCardService) RedeemForOrder(order *models.Order) error {
+	return s.settleOrder(order, models.GiftCardTransactionRedeem)
+}
+
+// RefundGiftCardTx 订单退款时结算礼品卡抵扣：仍在预留时释放预留，已扣减时把抵扣金额退回余额
+func RefundGiftCardTx(tx *gorm.DB, order *models.Order) error {
+	return settleGiftCardTx(tx, order, models.GiftCardTransactionRefund)
+}
+
+func (s *GiftCardService) settleOrder(order *models.Order, txType models.GiftCardTransactionType) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return settleGiftCardTx(tx, order, txType)
+	})
+}
+
+func settleGiftCardTx(tx *gorm.DB, order *models.Order, txType models.GiftCardTransactionType) error {
+	if order == nil || order.GiftCardID == nil || order.GiftCardAmount <= 0 {
+		return nil
+	}
+	cardID := *order.GiftCardID
+	if err := dbutil.LockForUpdate(tx, &models.GiftCard{}, "id = ?", cardID); err != nil {
+		return err
+	}
+	var settled []models.GiftCardTransactionType
+	if err := tx.Model(&models.GiftCardTransaction{}).
+		Where("gift_card_id = ? AND order_no = ? AND type IN ?", cardID, order.OrderNo,
+			[]models.GiftCardTransactionType{models.GiftCardTransactionRelease, models.GiftCardTransactionRedeem, models.GiftCardTransactionRefund}).
+		Pluck("type", &settled).Error; err != nil {
+		return err
+	}
+	// 同一订单只释放或扣减一次；退款时未扣减的抵扣按释放处理，已扣减的只退回一次
+	if txType == models.GiftCardTransactionRefund {
+		if len(settled) == 0 {
+			txType = models.GiftCardTransactionRelease
+		} else if len(settled) > 1 || settled[0] != models.GiftCardTransactionRedeem {
+			return nil
+		}
+	} else if len(settled) > 0 {
+		return nil
+	}
+
+	var card models.GiftCard
+	if err := tx.First(&card, cardID).Error; err != nil {
+		return err
+	}
+	amount := order.GiftCardAmount
+	if txType == models.GiftCardTransactionRefund {
+		card.Balance += amount
+	} else {
+		card.ReservedAmount -= amount
+		if card.ReservedAmount < 0 {
+			card.ReservedAmount = 0
+		}
+	}
+	if txType == models.GiftCardTransactionRedeem {
+		card.Balance -= amount
+		if card.Balance < 0 {
+			card.Balance = 0
+		}
+	}
+	if err := tx.Model(&card).Updates(map[string]interface{}{
+		"reserved_amount": card.ReservedAmount,
+		"balance":         card.Balance,
+	}).Error; err != nil {
+		return err
+	}
+	orderID := order.ID
+	return tx.Create(&models.GiftCardTransaction{
+		GiftCardID:   card.ID,
+		Type:         txType,
+		Amount:       amount,
+		BalanceAfter: card.Balance,
+		OrderID:      &orderID,
+		OrderNo:      order.OrderNo,
+	}).Error
+}
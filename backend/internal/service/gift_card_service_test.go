@@ -0,0 +1,192 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestGiftCardReserveRedeemAndRelease(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.GiftCard{}, &models.GiftCardTransaction{}); err != nil {
+		t.Fatalf("auto migrate gift cards failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Order.Currency = "USD"
+	cfg.Order.GiftCard.Denominations = []int64{5000}
+	cfg.Order.GiftCard.MinValue = 100
+	cfg.Order.GiftCard.MaxValue = 100000
+	svc := NewGiftCardService(db, cfg)
+
+	_, err := svc.Issue(GiftCardIssueRequest{Count: 1, ValueType: models.GiftCardValueFixed, Amount: 3000})
+	requireBizErr(t, err, "giftCard.denominationInvalid")
+	_, err = svc.Issue(GiftCardIssueRequest{Count: 1, ValueType: models.GiftCardValueVariable, Amount: 50})
+	requireBizErr(t, err, "giftCard.valueOutOfRange")
+
+	cards, err := svc.Issue(GiftCardIssueRequest{Count: 2, ValueType: models.GiftCardValueFixed, Amount: 5000, AdminID: 1})
+	if err != nil || len(cards) != 2 {
+		t.Fatalf("issue gift cards failed: cards=%d err=%v", len(cards), err)
+	}
+	card := cards[0]
+	if card.Code == cards[1].Code || NormalizeGiftCardCode(card.Code) != card.Code {
+		t.Fatalf("unexpected gift card codes %q / %q", card.Code, cards[1].Code)
+	}
+
+	_, err = svc.Check(card.Code)
+	requireBizErr(t, err, "giftCard.disabled")
+	cfg.Order.GiftCard.Enabled = true
+	balance, err := svc.Check(" " + card.Code[:4] + card.Code[5:] + " ")
+	if err != nil || balance.AvailableMinor != 5000 {
+		t.Fatalf("check gift card failed: balance=%+v err=%v", balance, err)
+	}
+
+	// 第一笔订单抵扣 3000，第二笔只能抵扣剩余 2000
+	first := &models.Order{OrderNo: "GC-1", Currency: "USD", TotalAmount: 3000}
+	if err := svc.ApplyToOrderTx(db, card.Code, first); err != nil {
+		t.Fatalf("apply gift card failed: %v", err)
+	}
+	second := &models.Order{OrderNo: "GC-2", Currency: "USD", TotalAmount: 4000}
+	if err := svc.ApplyToOrderTx(db, card.Code, second); err != nil {
+		t.Fatalf("apply gift card failed: %v", err)
+	}
+	if first.GiftCardAmount != 3000 || first.TotalAmount != 0 || second.GiftCardAmount != 2000 || second.TotalAmount != 2000 {
+		t.Fatalf("unexpected gift card deduction: first=%d/%d second=%d/%d",
+			first.GiftCardAmount, first.TotalAmount, second.GiftCardAmount, second.TotalAmount)
+	}
+	third := &models.Order{OrderNo: "GC-3", Currency: "USD", TotalAmount: 1000}
+	requireBizErr(t, svc.ApplyToOrderTx(db, card.Code, third), "giftCard.emptyBalance")
+	other := &models.Order{OrderNo: "GC-4", Currency: "EUR", TotalAmount: 1000}
+	requireBizErr(t, svc.ApplyToOrderTx(db, cards[1].Code, other), "giftCard.currencyMismatch")
+
+	// 完成与释放均只执行一次
+	for i := 0; i < 2; i++ {
+		if err := svc.RedeemForOrder(first); err != nil {
+			t.Fatalf("redeem gift card failed: %v", err)
+		}
+		if err := svc.ReleaseForOrder(second); err != nil {
+			t.Fatalf("release gift card failed: %v", err)
+		}
+	}
+	if err := svc.ReleaseForOrder(first); err != nil {
+		t.Fatalf("release redeemed order failed: %v", err)
+	}
+	got, transactions, err := svc.Get(card.ID)
+	if err != nil {
+		t.Fatalf("get gift card failed: %v", err)
+	}
+	if got.Balance != 2000 || got.ReservedAmount != 0 || got.Available() != 2000 {
+		t.Fatalf("unexpected gift card balance: balance=%d reserved=%d", got.Balance, got.ReservedAmount)
+	}
+	if len(transactions) != 5 {
+		t.Fatalf("expected 5 ledger entries (issue, 2 reserve, redeem, release), got %d", len(transactions))
+	}
+
+	// 有未完成订单预留时不能作废
+	if err := svc.ApplyToOrderTx(db, card.Code, &models.Order{OrderNo: "GC-5", Currency: "USD", TotalAmount: 500}); err != nil {
+		t.Fatalf("apply gift card failed: %v", err)
+	}
+	_, err = svc.Void(card.ID, 1, "lost")
+	requireBizErr(t, err, "giftCard.hasPendingOrders")
+
+	voided, err := svc.Void(cards[1].ID, 1, "lost")
+	if err != nil || voided.Status != models.GiftCardStatusVoid || voided.Balance != 0 {
+		t.Fatalf("void gift card failed: card=%+v err=%v", voided, err)
+	}
+	_, err = svc.Void(cards[1].ID, 1, "again")
+	requireBizErr(t, err, "giftCard.alreadyVoid")
+	_, err = svc.Check(cards[1].Code)
+	requireBizErr(t, err, "giftCard.void")
+}
+
+func TestRefundOrderSettlesGiftCard(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.GiftCard{},
+		&models.GiftCardTransaction{},
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Order.Currency = "USD"
+	cfg.Order.GiftCard.Enabled = true
+	cfg.Order.GiftCard.Denominations = []int64{5000}
+	giftCards := NewGiftCardService(db, cfg)
+	orderSvc.SetGiftCardService(giftCards)
+	refundSvc := NewRefundService(db, orderSvc, NewJSRuntimeService(db, &config.Config{}))
+
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	cards, err := giftCards.Issue(GiftCardIssueRequest{Count: 2, ValueType: models.GiftCardValueFixed, Amount: 5000})
+	if err != nil {
+		t.Fatalf("issue gift cards: %v", err)
+	}
+	placeOrder := func(orderNo, code string, status models.OrderStatus) *models.Order {
+		order := &models.Order{
+			OrderNo:     orderNo,
+			Status:      status,
+			Items:       []models.OrderItem{{SKU: "MUG", Name: "Mug", Quantity: 1, UnitPrice: 4000}},
+			TotalAmount: 4000,
+			Currency:    "USD",
+		}
+		if err := giftCards.ApplyToOrderTx(db, code, order); err != nil {
+			t.Fatalf("apply gift card: %v", err)
+		}
+		if err := db.Create(order).Error; err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+			t.Fatalf("create order payment method: %v", err)
+		}
+		return order
+	}
+
+	// 已发货订单的抵扣仍在预留中，退款时释放预留
+	shipped := placeOrder("GC-REFUND-1", cards[0].Code, models.OrderStatusShipped)
+	if _, err := refundSvc.RefundOrder(shipped, ""); err != nil {
+		t.Fatalf("refund shipped order: %v", err)
+	}
+	got, _, err := giftCards.Get(cards[0].ID)
+	if err != nil {
+		t.Fatalf("get gift card: %v", err)
+	}
+	if got.Balance != 5000 || got.ReservedAmount != 0 {
+		t.Fatalf("expected shipped refund to release the reservation, balance=%d reserved=%d", got.Balance, got.ReservedAmount)
+	}
+
+	// 已完成订单的抵扣已从余额扣减，退款时退回余额且只退一次
+	completed := placeOrder("GC-REFUND-2", cards[1].Code, models.OrderStatusCompleted)
+	if err := giftCards.RedeemForOrder(completed); err != nil {
+		t.Fatalf("redeem gift card: %v", err)
+	}
+	if _, err := refundSvc.RefundOrder(completed, ""); err != nil {
+		t.Fatalf("refund completed order: %v", err)
+	}
+	if err := RefundGiftCardTx(db, completed); err != nil {
+		t.Fatalf("repeat gift card refund: %v", err)
+	}
+	got, transactions, err := giftCards.Get(cards[1].ID)
+	if err != nil {
+		t.Fatalf("get gift card: %v", err)
+	}
+	if got.Balance != 5000 || got.ReservedAmount != 0 {
+		t.Fatalf("expected completed refund to restore the balance, balance=%d reserved=%d", got.Balance, got.ReservedAmount)
+	}
+	if len(transactions) != 4 || transactions[0].Type != models.GiftCardTransactionRefund {
+		t.Fatalf("expected issue, reserve, redeem and refund entries, got %+v", transactions)
+	}
+}
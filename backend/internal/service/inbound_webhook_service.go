@@ -0,0 +1,696 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/fieldcrypt"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/utils"
+	"github.com/dop251/goja"
+	"gorm.io/gorm"
+)
+
+const (
+	// 转换脚本只做字段映射，超时设置得比发货脚本短
+	inboundWebhookScriptTimeout  = 2 * time.Second
+	inboundWebhookMaxActions     = 20
+	inboundWebhookMaxConsole     = 50
+	inboundWebhookMaxNoteLength  = 5000
+	inboundWebhookSecretLength   = 40
+	inboundWebhookDefaultHeader  = "X-Webhook-Signature"
+	inboundWebhookTicketPreviewN = 200
+)
+
+var (
+	// ErrInboundWebhookNotFound 端点不存在或未启用
+	ErrInboundWebhookNotFound = errors.New("inbound webhook not found")
+	// ErrInboundWebhookSignatureInvalid 签名或密钥校验失败
+	ErrInboundWebhookSignatureInvalid = errors.New("inbound webhook signature invalid")
+
+	inboundWebhookSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,63}$`)
+
+	errInboundWebhookNotFound        = bizerr.Register("inbound_webhook.notFound", 404, "Inbound webhook not found")
+	errInboundWebhookEventNotFound   = bizerr.Register("inbound_webhook.eventNotFound", 404, "Inbound webhook event not found")
+	errInboundWebhookNameRequired    = bizerr.Register("inbound_webhook.nameRequired", 400, "Inbound webhook name is required")
+	errInboundWebhookSlugInvalid     = bizerr.Register("inbound_webhook.slugInvalid", 400, "Slug must be 2-64 lowercase letters, digits, hyphens or underscores")
+	errInboundWebhookSlugTaken       = bizerr.Register("inbound_webhook.slugTaken", 409, "Slug is already used by another inbound webhook")
+	errInboundWebhookAuthModeInvalid = bizerr.Register("inbound_webhook.authModeInvalid", 400, "Auth mode must be hmac_sha256 or header")
+	errInboundWebhookActionsInvalid  = bizerr.Register("inbound_webhook.actionsInvalid", 400, "Allow at least one of mark_paid, add_tracking or ticket_note")
+	errInboundWebhookScriptRequired  = bizerr.Register("inbound_webhook.scriptRequired", 400, "Transformation script is required")
+	errInboundWebhookScriptInvalid   = bizerr.Register("inbound_webhook.scriptInvalid", 400, "Transformation script does not compile")
+	errInboundWebhookSecretTooShort  = bizerr.Register("inbound_webhook.secretTooShort", 400, "Secret must be at least 16 characters")
+)
+
+// inboundWebhookActions 转换脚本可以使用的全部动作
+var inboundWebhookActions = []string{
+	models.InboundWebhookActionMarkPaid,
+	models.InboundWebhookActionAddTracking,
+	models.InboundWebhookActionTicketNote,
+}
+
+// InboundWebhookInput 创建或更新入站 Webhook 的设置
+type InboundWebhookInput struct {
+	Name            string
+	Slug            string
+	Description     string
+	AuthMode        models.InboundWebhookAuthMode
+	SignatureHeader string
+	EventIDHeader   string
+	Secret          string // 为空时创建会自动生成，更新时保持原密钥
+	Script          string
+	AllowedActions  []string
+	Enabled         bool
+}
+
+// InboundWebhookAction 转换脚本返回的一个动作
+type InboundWebhookAction struct {
+	Action     string `json:"action"`
+	OrderNo    string `json:"order_no,omitempty"`
+	TrackingNo string `json:"tracking_no,omitempty"`
+	TicketNo   string `json:"ticket_no,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Remark     string `json:"remark,omitempty"`
+}
+
+// InboundWebhookTestResult 试运行转换脚本的结果，不会执行动作
+type InboundWebhookTestResult struct {
+	Actions []InboundWebhookAction `json:"actions"`
+	Console []string               `json:"console"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// InboundWebhookService 入站 Webhook：校验第三方请求，用 goja 沙箱中的转换脚本把负载映射为
+// 标记已付款、填写物流单号、追加工单消息等动作，请求与处理结果记录在收件箱中
+type InboundWebhookService struct {
+	db           *gorm.DB
+	orderService *OrderService
+}
+
+// NewInboundWebhookService 创建入站 Webhook 服务
+func NewInboundWebhookService(db *gorm.DB, orderService *OrderService) *InboundWebhookService {
+	return &InboundWebhookService{db: db, orderService: orderService}
+}
+
+// List 全部入站 Webhook
+func (s *InboundWebhookService) List() ([]models.InboundWebhook, error) {
+	var webhooks []models.InboundWebhook
+	err := s.db.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// Get 获取入站 Webhook
+func (s *InboundWebhookService) Get(id uint) (*models.InboundWebhook, error) {
+	var webhook models.InboundWebhook
+	if err := s.db.First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInboundWebhookNotFound.New()
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Create 新建入站 Webhook，返回明文密钥供管理员配置到第三方，之后不再展示
+func (s *InboundWebhookService) Create(input InboundWebhookInput) (*models.InboundWebhook, string, error) {
+	if err := s.normalizeInput(&input, 0); err != nil {
+		return nil, "", err
+	}
+	secret, err := resolveInboundWebhookSecret(input.Secret)
+	if err != nil {
+		return nil, "", err
+	}
+	sealed, err := fieldcrypt.Encrypt(secret)
+	if err != nil {
+		return nil, "", err
+	}
+	webhook := &models.InboundWebhook{Secret: sealed}
+	applyInboundWebhookInput(webhook, input)
+	if err := s.db.Create(webhook).Error; err != nil {
+		return nil, "", err
+	}
+	return webhook, secret, nil
+}
+
+// Update 更新入站 Webhook，Secret 为空时保持原密钥
+func (s *InboundWebhookService) Update(id uint, input InboundWebhookInput) (*models.InboundWebhook, error) {
+	webhook, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.normalizeInput(&input, id); err != nil {
+		return nil, err
+	}
+	if input.Secret != "" {
+		if len(input.Secret) < 16 {
+			return nil, errInboundWebhookSecretTooShort.New()
+		}
+		sealed, err := fieldcrypt.Encrypt(input.Secret)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = sealed
+	}
+	applyInboundWebhookInput(webhook, input)
+	if err := s.db.Save(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// RotateSecret 生成新密钥并立即生效，返回明文密钥
+func (s *InboundWebhookService) RotateSecret(id uint) (*models.InboundWebhook, string, error) {
+	webhook, err := s.Get(id)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := resolveInboundWebhookSecret("")
+	if err != nil {
+		return nil, "", err
+	}
+	sealed, err := fieldcrypt.Encrypt(secret)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.db.Model(webhook).Update("secret", sealed).Error; err != nil {
+		return nil, "", err
+	}
+	return webhook, secret, nil
+}
+
+// Delete 删除入站 Webhook 及其收件箱
+func (s *InboundWebhookService) Delete(id uint) (*models.InboundWebhook, error) {
+	webhook, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.InboundWebhookEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(webhook).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *InboundWebhookService) normalizeInput(input *InboundWebhookInput, id uint) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || len(input.Name) > 100 {
+		return errInboundWebhookNameRequired.New()
+	}
+	input.Slug = strings.ToLower(strings.TrimSpace(input.Slug))
+	if !inboundWebhookSlugPattern.MatchString(input.Slug) {
+		return errInboundWebhookSlugInvalid.New()
+	}
+	var count int64
+	if err := s.db.Model(&models.InboundWebhook{}).Where("slug = ? AND id <> ?", input.Slug, id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errInboundWebhookSlugTaken.New()
+	}
+
+	input.Description = strings.TrimSpace(input.Description)
+	if input.AuthMode == "" {
+		input.AuthMode = models.InboundWebhookAuthHMAC
+	}
+	if input.AuthMode != models.InboundWebhookAuthHMAC && input.AuthMode != models.InboundWebhookAuthHeader {
+		return errInboundWebhookAuthModeInvalid.New()
+	}
+	input.SignatureHeader = strings.TrimSpace(input.SignatureHeader)
+	if input.SignatureHeader == "" {
+		input.SignatureHeader = inboundWebhookDefaultHeader
+	}
+	input.EventIDHeader = strings.TrimSpace(input.EventIDHeader)
+	input.Secret = strings.TrimSpace(input.Secret)
+
+	actions := make([]string, 0, len(input.AllowedActions))
+	for _, action := range input.AllowedActions {
+		action = strings.TrimSpace(action)
+		if !slices.Contains(inboundWebhookActions, action) {
+			return errInboundWebhookActionsInvalid.New()
+		}
+		if !slices.Contains(actions, action) {
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return errInboundWebhookActionsInvalid.New()
+	}
+	input.AllowedActions = actions
+
+	if strings.TrimSpace(input.Script) == "" {
+		return errInboundWebhookScriptRequired.New()
+	}
+	if _, err := goja.Compile("inbound_webhook", input.Script, false); err != nil {
+		return errInboundWebhookScriptInvalid.New().WithParams(map[string]interface{}{"error": err.Error()})
+	}
+	return nil
+}
+
+func applyInboundWebhookInput(webhook *models.InboundWebhook, input InboundWebhookInput) {
+	webhook.Name = input.Name
+	webhook.Slug = input.Slug
+	webhook.Description = input.Description
+	webhook.AuthMode = input.AuthMode
+	webhook.SignatureHeader = input.SignatureHeader
+	webhook.EventIDHeader = input.EventIDHeader
+	webhook.Script = input.Script
+	webhook.AllowedActions = input.AllowedActions
+	webhook.Enabled = input.Enabled
+}
+
+func resolveInboundWebhookSecret(provided string) (string, error) {
+	if provided == "" {
+		return utils.GenerateToken(inboundWebhookSecretLength)
+	}
+	if len(provided) < 16 {
+		return "", errInboundWebhookSecretTooShort.New()
+	}
+	return provided, nil
+}
+
+// ListEvents 入站事件列表，按接收时间倒序
+func (s *InboundWebhookService) ListEvents(webhookID uint, status string, page, limit int) ([]models.InboundWebhookEvent, int64, error) {
+	query := s.db.Model(&models.InboundWebhookEvent{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var events []models.InboundWebhookEvent
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&events).Error
+	return events, total, err
+}
+
+// GetEvent 获取入站事件
+func (s *InboundWebhookService) GetEvent(webhookID, eventID uint) (*models.InboundWebhookEvent, error) {
+	var event models.InboundWebhookEvent
+	if err := s.db.Where("webhook_id = ?", webhookID).First(&event, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInboundWebhookEventNotFound.New()
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Receive 处理第三方请求：校验来源后写入收件箱并执行脚本返回的动作。
+// 重复投递（相同事件 ID 或请求体）直接返回已有记录，duplicate 为 true
+func (s *InboundWebhookService) Receive(slug string, header http.Header, query url.Values, body []byte) (*models.InboundWebhookEvent, bool, error) {
+	var webhook models.InboundWebhook
+	if err := s.db.Where("slug = ? AND enabled = ?", strings.ToLower(slug), true).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrInboundWebhookNotFound
+		}
+		return nil, false, err
+	}
+
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		headers[strings.ToLower(key)] = header.Get(key)
+	}
+	secret, err := fieldcrypt.Decrypt(webhook.Secret)
+	if err != nil {
+		return nil, false, err
+	}
+	manifest := DeclaredWebhookManifest{
+		AuthMode:        string(webhook.AuthMode),
+		SecretKey:       "secret",
+		Header:          webhook.SignatureHeader,
+		SignatureHeader: webhook.SignatureHeader,
+	}
+	if err := AuthenticateDeclaredWebhookRequest(manifest, nil, headers, body, map[string]string{"secret": secret}); err != nil {
+		return nil, false, ErrInboundWebhookSignatureInvalid
+	}
+	delete(headers, strings.ToLower(webhook.SignatureHeader))
+
+	eventKey := ""
+	if webhook.EventIDHeader != "" {
+		eventKey = strings.TrimSpace(headers[strings.ToLower(webhook.EventIDHeader)])
+	}
+	if eventKey == "" || len(eventKey) > 150 {
+		sum := sha256.Sum256(body)
+		eventKey = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	queryMap := make(map[string]string, len(query))
+	for key := range query {
+		queryMap[key] = query.Get(key)
+	}
+
+	now := models.NowFunc()
+	event := &models.InboundWebhookEvent{
+		WebhookID: webhook.ID,
+		EventKey:  eventKey,
+		Status:    models.InboundWebhookEventReceived,
+		Headers:   headers,
+		Query:     queryMap,
+		Payload:   string(body),
+		Attempts:  1,
+	}
+	// 先写入收件箱，唯一索引保证并发重试只处理一次
+	if err := s.db.Create(event).Error; err != nil {
+		if !isUniqueConstraintError(err) {
+			return nil, false, err
+		}
+		var existing models.InboundWebhookEvent
+		if err := s.db.Where("webhook_id = ? AND event_key = ?", webhook.ID, eventKey).First(&existing).Error; err != nil {
+			return nil, false, err
+		}
+		return &existing, true, nil
+	}
+	s.db.Model(&webhook).Update("last_received_at", now)
+
+	s.process(&webhook, event)
+	return event, false, nil
+}
+
+// ReplayEvent 用当前脚本重新处理一条事件，适用于修正脚本或数据后补处理
+func (s *InboundWebhookService) ReplayEvent(webhookID, eventID uint) (*models.InboundWebhookEvent, error) {
+	webhook, err := s.Get(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.GetEvent(webhookID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	event.Attempts++
+	s.process(webhook, event)
+	return event, nil
+}
+
+// Test 用示例请求试运行转换脚本，只返回脚本输出的动作，不执行
+func (s *InboundWebhookService) Test(id uint, script string, payload string, headers map[string]string) (*InboundWebhookTestResult, error) {
+	webhook, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(script) != "" {
+		webhook.Script = script
+	}
+	lowered := make(map[string]string, len(headers))
+	for key, value := range headers {
+		lowered[strings.ToLower(key)] = value
+	}
+	actions, console, err := runInboundWebhookScript(webhook, lowered, nil, payload)
+	result := &InboundWebhookTestResult{Actions: actions, Console: console}
+	if result.Actions == nil {
+		result.Actions = []InboundWebhookAction{}
+	}
+	if result.Console == nil {
+		result.Console = []string{}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// process 运行转换脚本并执行动作，结果写回事件
+func (s *InboundWebhookService) process(webhook *models.InboundWebhook, event *models.InboundWebhookEvent) {
+	started := time.Now()
+	actions, console, err := runInboundWebhookScript(webhook, event.Headers, event.Query, event.Payload)
+	event.Console = console
+	event.Actions = nil
+	event.Error = ""
+	if err != nil {
+		event.Status = models.InboundWebhookEventFailed
+		event.Error = err.Error()
+	} else {
+		failed := 0
+		for _, action := range actions {
+			result := s.applyAction(webhook, action)
+			if !result.Success {
+				failed++
+			}
+			event.Actions = append(event.Actions, result)
+		}
+		switch {
+		case len(actions) == 0:
+			event.Status = models.InboundWebhookEventIgnored
+		case failed == 0:
+			event.Status = models.InboundWebhookEventProcessed
+		case failed == len(actions):
+			event.Status = models.InboundWebhookEventFailed
+		default:
+			event.Status = models.InboundWebhookEventPartial
+		}
+	}
+	processedAt := models.NowFunc()
+	event.ProcessedAt = &processedAt
+	event.DurationMs = time.Since(started).Milliseconds()
+	if err := s.db.Select("status", "actions", "console", "error", "duration_ms", "attempts", "processed_at").Save(event).Error; err != nil {
+		log.Printf("[InboundWebhook] Failed to save event %d: %v", event.ID, err)
+	}
+}
+
+// applyAction 执行单个动作，只允许端点配置中放行的动作
+func (s *InboundWebhookService) applyAction(webhook *models.InboundWebhook, action InboundWebhookAction) models.InboundWebhookActionResult {
+	result := models.InboundWebhookActionResult{
+		Action:     action.Action,
+		OrderNo:    action.OrderNo,
+		TicketNo:   action.TicketNo,
+		TrackingNo: action.TrackingNo,
+	}
+	var err error
+	if !slices.Contains(webhook.AllowedActions, action.Action) {
+		err = fmt.Errorf("action %q is not allowed for this webhook", action.Action)
+	} else {
+		switch action.Action {
+		case models.InboundWebhookActionMarkPaid:
+			err = s.markPaid(webhook, action)
+		case models.InboundWebhookActionAddTracking:
+			err = s.addTracking(webhook, action)
+		case models.InboundWebhookActionTicketNote:
+			err = s.appendTicketNote(webhook, action)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+func (s *InboundWebhookService) findOrder(orderNo string) (*models.Order, error) {
+	if orderNo == "" {
+		return nil, fmt.Errorf("order_no is required")
+	}
+	order, err := s.orderService.OrderRepo.FindByOrderNo(orderNo)
+	if err != nil {
+		return nil, normalizeOrderLookupError(err)
+	}
+	return order, nil
+}
+
+func (s *InboundWebhookService) markPaid(webhook *models.InboundWebhook, action InboundWebhookAction) error {
+	order, err := s.findOrder(action.OrderNo)
+	if err != nil {
+		return err
+	}
+	remark := action.Remark
+	if remark == "" {
+		remark = "Marked paid by inbound webhook " + webhook.Name
+	}
+	if err := s.orderService.MarkAsPaidWithOptions(order.ID, MarkAsPaidOptions{AdminRemark: remark}); err != nil {
+		return err
+	}
+	logger.LogSystemOperation(s.db, "inbound_webhook_mark_paid", "order", &order.ID, map[string]interface{}{
+		"order_no":   order.OrderNo,
+		"webhook_id": webhook.ID,
+		"webhook":    webhook.Slug,
+	})
+	return nil
+}
+
+func (s *InboundWebhookService) addTracking(webhook *models.InboundWebhook, action InboundWebhookAction) error {
+	if action.TrackingNo == "" {
+		return fmt.Errorf("tracking_no is required")
+	}
+	order, err := s.findOrder(action.OrderNo)
+	if err != nil {
+		return err
+	}
+	if err := s.orderService.AssignTracking(order.ID, action.TrackingNo); err != nil {
+		return err
+	}
+	logger.LogSystemOperation(s.db, "inbound_webhook_add_tracking", "order", &order.ID, map[string]interface{}{
+		"order_no":    order.OrderNo,
+		"tracking_no": action.TrackingNo,
+		"webhook_id":  webhook.ID,
+		"webhook":     webhook.Slug,
+	})
+	return nil
+}
+
+// appendTicketNote 以端点名称作为发送者在工单中追加一条消息，用户与管理员均可见
+func (s *InboundWebhookService) appendTicketNote(webhook *models.InboundWebhook, action InboundWebhookAction) error {
+	if action.TicketNo == "" {
+		return fmt.Errorf("ticket_no is required")
+	}
+	content := strings.TrimSpace(action.Content)
+	if content == "" {
+		return fmt.Errorf("content is required")
+	}
+	content = truncateRefundText(content, inboundWebhookMaxNoteLength)
+
+	var ticket models.Ticket
+	if err := s.db.Where("ticket_no = ?", action.TicketNo).First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("ticket %s not found", action.TicketNo)
+		}
+		return err
+	}
+	metadata, _ := json.Marshal(map[string]interface{}{"source": "inbound_webhook", "webhook_id": webhook.ID})
+	now := models.NowFunc()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&models.TicketMessage{
+			TicketID:    ticket.ID,
+			SenderType:  "admin",
+			SenderID:    0,
+			SenderName:  webhook.Name,
+			Content:     content,
+			ContentType: "text",
+			Metadata:    models.JSON(metadata),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ticket).Updates(map[string]interface{}{
+			"unread_count_user":    gorm.Expr("unread_count_user + 1"),
+			"unread_count_admin":   gorm.Expr("unread_count_admin + 1"),
+			"last_message_at":      now,
+			"last_message_preview": truncateRefundText(content, inboundWebhookTicketPreviewN),
+			"last_message_by":      "admin",
+		}).Error
+	})
+}
+
+// runInboundWebhookScript 在独立的 goja 运行时中调用 transform(event)。
+// 沙箱只提供 AuraLogic.system.log，不能发起网络请求或访问存储
+func runInboundWebhookScript(webhook *models.InboundWebhook, headers, query map[string]string, payload string) (actions []InboundWebhookAction, console []string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("script panic: %v", recovered)
+			actions = nil
+		}
+	}()
+
+	program, err := getOrCompileJSProgram("inbound_webhook", webhook.Script)
+	if err != nil {
+		return nil, nil, fmt.Errorf("script compile error: %w", err)
+	}
+	vm := goja.New()
+	timer := time.AfterFunc(inboundWebhookScriptTimeout, func() {
+		vm.Interrupt("execution timeout")
+	})
+	defer timer.Stop()
+
+	auralogic := vm.NewObject()
+	system := vm.NewObject()
+	system.Set("log", func(call goja.FunctionCall) goja.Value {
+		if len(console) < inboundWebhookMaxConsole {
+			console = append(console, formatScriptLogArgs(call.Arguments))
+		}
+		return goja.Undefined()
+	})
+	auralogic.Set("system", system)
+	vm.Set("AuraLogic", auralogic)
+
+	if _, err := vm.RunProgram(program); err != nil {
+		return nil, console, fmt.Errorf("script execution error: %w", err)
+	}
+	fn, ok := goja.AssertFunction(vm.Get("transform"))
+	if !ok {
+		return nil, console, fmt.Errorf("transform function not found in script")
+	}
+
+	// 非 JSON 请求体时 body 为 null，脚本可自行解析 raw
+	var body interface{}
+	if err := json.Unmarshal([]byte(payload), &body); err != nil {
+		body = nil
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	if query == nil {
+		query = map[string]string{}
+	}
+	event := map[string]interface{}{
+		"webhook": webhook.Slug,
+		"headers": headers,
+		"query":   query,
+		"body":    body,
+		"raw":     payload,
+	}
+	value, err := fn(goja.Undefined(), vm.ToValue(event))
+	if err != nil {
+		return nil, console, fmt.Errorf("transform execution error: %w", err)
+	}
+	actions, err = parseInboundWebhookActions(value)
+	return actions, console, err
+}
+
+// parseInboundWebhookActions 解析 transform 的返回值：null 表示忽略，可返回单个动作或动作数组
+func parseInboundWebhookActions(value goja.Value) ([]InboundWebhookAction, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, nil
+	}
+	var items []interface{}
+	switch exported := value.Export().(type) {
+	case []interface{}:
+		items = exported
+	case map[string]interface{}:
+		items = []interface{}{exported}
+	default:
+		return nil, fmt.Errorf("transform must return an action object, an array of actions or null")
+	}
+	if len(items) > inboundWebhookMaxActions {
+		return nil, fmt.Errorf("transform returned %d actions, at most %d are allowed", len(items), inboundWebhookMaxActions)
+	}
+
+	actions := make([]InboundWebhookAction, 0, len(items))
+	for i, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("action %d must be an object", i)
+		}
+		field := func(key string) string {
+			if raw, ok := entry[key]; ok && raw != nil {
+				return strings.TrimSpace(fmt.Sprint(raw))
+			}
+			return ""
+		}
+		action := InboundWebhookAction{
+			Action:     field("action"),
+			OrderNo:    field("order_no"),
+			TrackingNo: field("tracking_no"),
+			TicketNo:   field("ticket_no"),
+			Content:    field("content"),
+			Remark:     field("remark"),
+		}
+		if !slices.Contains(inboundWebhookActions, action.Action) {
+			return nil, fmt.Errorf("action %d has unknown type %q", i, action.Action)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestInboundWebhookMapsPayloadToActions(t *testing.T) {
+	db := openConcurrentServiceTestDB(t, &models.User{}, &models.Order{}, &models.Ticket{}, &models.TicketMessage{},
+		&models.InboundWebhook{}, &models.InboundWebhookEvent{}, &models.OperationLog{})
+	cfg := &config.Config{}
+	svc := NewInboundWebhookService(db, newConcurrentOrderService(db, cfg, nil))
+
+	order := models.Order{OrderNo: "WH-1001", Status: models.OrderStatusPendingPayment, TotalAmount: 5000}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	ticket := models.Ticket{TicketNo: "T-1", UserID: 1, Subject: "Payment", Content: "Paid by transfer"}
+	if err := db.Create(&ticket).Error; err != nil {
+		t.Fatalf("create ticket failed: %v", err)
+	}
+
+	script := `function transform(event) {
+		AuraLogic.system.log("type", event.body.type)
+		if (event.body.type !== "payment.succeeded") return null
+		return [
+			{ action: "mark_paid", order_no: event.body.reference },
+			{ action: "ticket_note", ticket_no: event.body.ticket, content: "Bank confirmed " + event.body.amount },
+			{ action: "add_tracking", order_no: event.body.reference, tracking_no: "X" }
+		]
+	}`
+	_, _, err := svc.Create(InboundWebhookInput{Name: "Bank", Slug: "Bad Slug", Script: script, AllowedActions: []string{"mark_paid"}})
+	requireBizErr(t, err, "inbound_webhook.slugInvalid")
+	_, _, err = svc.Create(InboundWebhookInput{Name: "Bank", Slug: "bank", Script: script, AllowedActions: []string{"refund"}})
+	requireBizErr(t, err, "inbound_webhook.actionsInvalid")
+	_, _, err = svc.Create(InboundWebhookInput{Name: "Bank", Slug: "bank", Script: "function transform(", AllowedActions: []string{"mark_paid"}})
+	requireBizErr(t, err, "inbound_webhook.scriptInvalid")
+
+	webhook, secret, err := svc.Create(InboundWebhookInput{
+		Name:           "Bank",
+		Slug:           "bank",
+		EventIDHeader:  "X-Event-Id",
+		Script:         script,
+		AllowedActions: []string{"mark_paid", "ticket_note"},
+		Enabled:        true,
+	})
+	if err != nil {
+		t.Fatalf("create webhook failed: %v", err)
+	}
+	if len(secret) < 16 || webhook.Secret == "" || webhook.SignatureHeader != "X-Webhook-Signature" {
+		t.Fatalf("expected a generated secret and default header, got %q %+v", secret, webhook)
+	}
+
+	body := []byte(`{"type":"payment.succeeded","reference":"WH-1001","ticket":"T-1","amount":"50.00"}`)
+	sign := func(key string) http.Header {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(body)
+		header := http.Header{}
+		header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		header.Set("X-Event-Id", "evt_1")
+		return header
+	}
+
+	if _, _, err := svc.Receive("bank", sign("wrong-secret-value"), nil, body); err != ErrInboundWebhookSignatureInvalid {
+		t.Fatalf("expected signature error, got %v", err)
+	}
+	event, duplicate, err := svc.Receive("bank", sign(secret), nil, body)
+	if err != nil || duplicate {
+		t.Fatalf("receive failed: duplicate=%v err=%v", duplicate, err)
+	}
+	// add_tracking 未放行，其余动作执行成功
+	if event.Status != models.InboundWebhookEventPartial || len(event.Actions) != 3 || len(event.Console) != 1 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	if !event.Actions[0].Success || !event.Actions[1].Success || event.Actions[2].Success {
+		t.Fatalf("unexpected action results %+v", event.Actions)
+	}
+	if _, ok := event.Headers["x-webhook-signature"]; ok {
+		t.Fatalf("signature header should not be stored")
+	}
+
+	var paid models.Order
+	if err := db.First(&paid, order.ID).Error; err != nil || paid.Status == models.OrderStatusPendingPayment {
+		t.Fatalf("expected order to be marked paid, got %s err=%v", paid.Status, err)
+	}
+	var messages []models.TicketMessage
+	if err := db.Where("ticket_id = ?", ticket.ID).Find(&messages).Error; err != nil || len(messages) != 1 || messages[0].Content != "Bank confirmed 50.00" || messages[0].SenderName != "Bank" {
+		t.Fatalf("expected a ticket note, got %+v err=%v", messages, err)
+	}
+
+	again, duplicate, err := svc.Receive("bank", sign(secret), nil, body)
+	if err != nil || !duplicate || again.ID != event.ID {
+		t.Fatalf("expected the retry to be deduplicated, got duplicate=%v err=%v", duplicate, err)
+	}
+
+	// 订单已付款，重放时标记付款失败
+	replayed, err := svc.ReplayEvent(webhook.ID, event.ID)
+	if err != nil || replayed.Attempts != 2 || replayed.Actions[0].Success {
+		t.Fatalf("unexpected replay %+v err=%v", replayed, err)
+	}
+
+	result, err := svc.Test(webhook.ID, "", `{"type":"payment.failed"}`, nil)
+	if err != nil || result.Error != "" || len(result.Actions) != 0 {
+		t.Fatalf("ignored events should return no actions, got %+v err=%v", result, err)
+	}
+	result, err = svc.Test(webhook.ID, `function transform(event) { return { action: "delete_everything" } }`, `{}`, nil)
+	if err != nil || result.Error == "" {
+		t.Fatalf("unknown actions should be rejected, got %+v err=%v", result, err)
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/ticketbiz"
+	"auralogic/internal/pkg/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	integrationDefaultLimit = 50
+	integrationMaxLimit     = 100
+	integrationMaxTagLength = 32
+	integrationMaxOrderTags = 20
+)
+
+var (
+	errIntegrationOrderNotFound  = bizerr.Register("integration.orderNotFound", 404, "Order not found")
+	errIntegrationTicketNotFound = bizerr.Register("integration.ticketNotFound", 404, "Ticket not found")
+	errIntegrationTagInvalid     = bizerr.Register("integration.tagInvalid", 400, "Tag must be 1-32 characters without commas")
+	errIntegrationTagLimit       = bizerr.Register("integration.tagLimit", 400, "An order can have at most 20 tags")
+)
+
+// IntegrationPaidOrderItem 付款订单中的商品摘要
+type IntegrationPaidOrderItem struct {
+	SKU      string `json:"sku"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// IntegrationPaidOrder Zapier / Make 轮询触发器的一条记录。字段保持扁平，id 供平台去重，
+// cursor 为下次轮询的起点
+type IntegrationPaidOrder struct {
+	ID               uint                       `json:"id"`
+	Cursor           string                     `json:"cursor"`
+	OrderNo          string                     `json:"order_no"`
+	Status           models.OrderStatus         `json:"status"`
+	TotalAmountMinor int64                      `json:"total_amount_minor"`
+	Currency         string                     `json:"currency"`
+	PaidAt           time.Time                  `json:"paid_at"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UserEmail        string                     `json:"user_email"`
+	ReceiverName     string                     `json:"receiver_name"`
+	ReceiverCountry  string                     `json:"receiver_country"`
+	ItemCount        int                        `json:"item_count"`
+	Items            []IntegrationPaidOrderItem `json:"items"`
+	Tags             []string                   `json:"tags"`
+}
+
+// IntegrationService 面向 Zapier / Make 等无代码平台的轮询触发器与动作，通过 API Key 调用
+type IntegrationService struct {
+	db           *gorm.DB
+	emailService *EmailService
+}
+
+func NewIntegrationService(db *gorm.DB, emailService *EmailService) *IntegrationService {
+	return &IntegrationService{db: db, emailService: emailService}
+}
+
+// ListPaidOrders 返回 cursor 之后付款的订单，按付款时间倒序。未传 cursor 时返回最近付款的订单；
+// 传入 cursor 时取紧随其后的最早一批，调用方以第一条记录的 cursor 继续轮询，积压再多也不会漏单
+func (s *IntegrationService) ListPaidOrders(cursor string, limit int) ([]IntegrationPaidOrder, error) {
+	if limit <= 0 {
+		limit = integrationDefaultLimit
+	}
+	if limit > integrationMaxLimit {
+		limit = integrationMaxLimit
+	}
+	after, err := dbutil.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []models.Order
+	query := s.db.Model(&models.Order{}).Preload("User").Where("paid_at IS NOT NULL")
+	if after != nil {
+		paidAt := after.CreatedAt.UTC()
+		query = query.Where("(paid_at > ? OR (paid_at = ? AND id > ?))", paidAt, paidAt, after.ID).
+			Order("paid_at ASC").Order("id ASC")
+	} else {
+		query = query.Order("paid_at DESC").Order("id DESC")
+	}
+	if err := query.Limit(limit).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	if after != nil {
+		slices.Reverse(orders)
+	}
+
+	items := make([]IntegrationPaidOrder, 0, len(orders))
+	for _, order := range orders {
+		items = append(items, integrationPaidOrder(order))
+	}
+	return items, nil
+}
+
+func integrationPaidOrder(order models.Order) IntegrationPaidOrder {
+	item := IntegrationPaidOrder{
+		ID:               order.ID,
+		Cursor:           dbutil.EncodeCursor(*order.PaidAt, order.ID),
+		OrderNo:          order.OrderNo,
+		Status:           order.Status,
+		TotalAmountMinor: order.TotalAmount,
+		Currency:         order.Currency,
+		PaidAt:           *order.PaidAt,
+		CreatedAt:        order.CreatedAt,
+		UserEmail:        order.UserEmail,
+		ReceiverName:     order.ReceiverName,
+		ReceiverCountry:  order.ReceiverCountry,
+		Items:            make([]IntegrationPaidOrderItem, 0, len(order.Items)),
+		Tags:             order.Tags,
+	}
+	if item.UserEmail == "" && order.User != nil {
+		item.UserEmail = order.User.Email
+	}
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
+	for _, orderItem := range order.ItemsWithAllocations() {
+		item.ItemCount += orderItem.Quantity
+		item.Items = append(item.Items, IntegrationPaidOrderItem{
+			SKU:      orderItem.SKU,
+			Name:     orderItem.Name,
+			Quantity: orderItem.Quantity,
+		})
+	}
+	return item
+}
+
+// ReplyToTicket 以 API Key 创建者的身份回复工单，效果与管理员在后台回复相同（更新未读数、
+// 待处理工单转为处理中并通知用户），但不自动分配工单
+func (s *IntegrationService) ReplyToTicket(adminID uint, ticketNo, content string) (*models.TicketMessage, error) {
+	var ticket models.Ticket
+	if err := s.db.Where("ticket_no = ?", strings.TrimSpace(ticketNo)).First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errIntegrationTicketNotFound.New()
+		}
+		return nil, err
+	}
+	if ticket.Status == models.TicketStatusClosed {
+		return nil, ticketbiz.ClosedCannotSend()
+	}
+
+	sanitized := validator.SanitizeMarkdown(content)
+	if strings.TrimSpace(sanitized) == "" {
+		return nil, ticketbiz.MessageEmpty()
+	}
+	if cfg := config.GetConfig(); cfg != nil && cfg.Ticket.MaxContentLength > 0 && len([]rune(sanitized)) > cfg.Ticket.MaxContentLength {
+		return nil, ticketbiz.ContentTooLong(cfg.Ticket.MaxContentLength)
+	}
+
+	var admin models.User
+	if err := s.db.Select("id", "name").First(&admin, adminID).Error; err != nil {
+		return nil, err
+	}
+	message := &models.TicketMessage{
+		TicketID:      ticket.ID,
+		SenderType:    "admin",
+		SenderID:      admin.ID,
+		SenderName:    admin.Name,
+		Content:       sanitized,
+		ContentType:   "text",
+		IsReadByUser:  false,
+		IsReadByAdmin: true,
+	}
+	preview := truncateRefundText(sanitized, 200)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		updates := map[string]interface{}{
+			"last_message_at":      message.CreatedAt,
+			"last_message_preview": preview,
+			"last_message_by":      "admin",
+			"unread_count_user":    gorm.Expr("unread_count_user + 1"),
+		}
+		if ticket.Status == models.TicketStatusOpen {
+			updates["status"] = models.TicketStatusProcessing
+		}
+		if err := tx.Model(&ticket).Updates(updates).Error; err != nil {
+			return err
+		}
+		// 与后台回复一致，计入首次响应时间
+		return tx.Model(&models.Ticket{}).
+			Where("id = ? AND first_response_at IS NULL", ticket.ID).
+			Updates(map[string]interface{}{
+				"first_response_at": message.CreatedAt,
+				"first_response_by": admin.ID,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.emailService != nil {
+		go s.emailService.SendTicketAdminReplyEmail(&ticket, admin.Name, preview)
+	}
+	return message, nil
+}
+
+// AddOrderTag 为订单添加标签，标签已存在时不重复添加，返回 added=false，便于平台重试
+func (s *IntegrationService) AddOrderTag(orderNo, tag string) (*models.Order, bool, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || len([]rune(tag)) > integrationMaxTagLength || strings.Contains(tag, ",") {
+		return nil, false, errIntegrationTagInvalid.New().WithParams(map[string]interface{}{"max": integrationMaxTagLength})
+	}
+
+	var order models.Order
+	added := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		orderNo = strings.TrimSpace(orderNo)
+		if err := dbutil.LockForUpdate(tx, &models.Order{}, "order_no = ?", orderNo); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errIntegrationOrderNotFound.New()
+			}
+			return err
+		}
+		if err := tx.Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+			return err
+		}
+		for _, existing := range order.Tags {
+			if strings.EqualFold(existing, tag) {
+				return nil
+			}
+		}
+		if len(order.Tags) >= integrationMaxOrderTags {
+			return errIntegrationTagLimit.New().WithParams(map[string]interface{}{"max": integrationMaxOrderTags})
+		}
+		order.Tags = append(order.Tags, tag)
+		added = true
+		return tx.Model(&order).Select("tags").Updates(&models.Order{Tags: order.Tags}).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &order, added, nil
+}
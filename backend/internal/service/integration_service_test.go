@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestIntegrationPaidOrdersPollFromCursor(t *testing.T) {
+	db := openConcurrentServiceTestDB(t, &models.User{}, &models.Order{})
+	svc := NewIntegrationService(db, nil)
+
+	base := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	// 两笔订单付款时间相同，需要靠 id 区分先后
+	paidOffsets := []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute}
+	for i, offset := range paidOffsets {
+		paidAt := base.Add(offset)
+		order := models.Order{
+			OrderNo:     "ZAP-" + string(rune('A'+i)),
+			Status:      models.OrderStatusPending,
+			TotalAmount: 1000,
+			PaidAt:      &paidAt,
+			Items:       []models.OrderItem{{SKU: "SKU-1", Name: "Widget", Quantity: 2}},
+		}
+		if err := db.Create(&order).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+	}
+	unpaid := models.Order{OrderNo: "ZAP-UNPAID", Status: models.OrderStatusPendingPayment}
+	if err := db.Create(&unpaid).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	latest, err := svc.ListPaidOrders("", 2)
+	if err != nil || len(latest) != 2 || latest[0].OrderNo != "ZAP-D" || latest[1].OrderNo != "ZAP-C" {
+		t.Fatalf("expected the two latest paid orders, got %+v err=%v", latest, err)
+	}
+	if latest[0].ItemCount != 2 || len(latest[0].Tags) != 0 {
+		t.Fatalf("unexpected order summary %+v", latest[0])
+	}
+
+	// 从最早订单的 cursor 开始，每次取 2 条并以第一条的 cursor 继续
+	first, err := svc.ListPaidOrders("", 100)
+	if err != nil || len(first) != 4 {
+		t.Fatalf("expected 4 paid orders, got %d err=%v", len(first), err)
+	}
+	cursor := first[3].Cursor
+	var seen []string
+	for polls := 0; polls < 5; polls++ {
+		page, err := svc.ListPaidOrders(cursor, 2)
+		if err != nil {
+			t.Fatalf("poll failed: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for i := len(page) - 1; i >= 0; i-- {
+			seen = append(seen, page[i].OrderNo)
+		}
+		cursor = page[0].Cursor
+	}
+	if len(seen) != 3 || seen[0] != "ZAP-B" || seen[1] != "ZAP-C" || seen[2] != "ZAP-D" {
+		t.Fatalf("expected every later order exactly once, got %v", seen)
+	}
+
+	_, err = svc.ListPaidOrders("not-a-cursor", 10)
+	requireBizErr(t, err, "common.invalidCursor")
+}
+
+func TestIntegrationActionsTagOrdersAndReplyToTickets(t *testing.T) {
+	db := openConcurrentServiceTestDB(t, &models.User{}, &models.Order{}, &models.Ticket{}, &models.TicketMessage{})
+	svc := NewIntegrationService(db, nil)
+
+	admin := models.User{Email: "ops@example.com", Name: "Ops", Role: "admin", IsActive: true}
+	if err := db.Create(&admin).Error; err != nil {
+		t.Fatalf("create admin failed: %v", err)
+	}
+	order := models.Order{OrderNo: "ZAP-TAG", Status: models.OrderStatusPending}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	ticket := models.Ticket{TicketNo: "T-ZAP", UserID: admin.ID, Subject: "Where is my order", Status: models.TicketStatusOpen}
+	if err := db.Create(&ticket).Error; err != nil {
+		t.Fatalf("create ticket failed: %v", err)
+	}
+
+	_, _, err := svc.AddOrderTag("ZAP-TAG", "a,b")
+	requireBizErr(t, err, "integration.tagInvalid")
+	_, _, err = svc.AddOrderTag("ZAP-MISSING", "vip")
+	requireBizErr(t, err, "integration.orderNotFound")
+
+	tagged, added, err := svc.AddOrderTag("ZAP-TAG", " vip ")
+	if err != nil || !added || len(tagged.Tags) != 1 || tagged.Tags[0] != "vip" {
+		t.Fatalf("expected tag to be added, got %+v added=%v err=%v", tagged, added, err)
+	}
+	tagged, added, err = svc.AddOrderTag("ZAP-TAG", "VIP")
+	if err != nil || added || len(tagged.Tags) != 1 {
+		t.Fatalf("expected a repeated tag to be ignored, got %+v added=%v err=%v", tagged, added, err)
+	}
+	var reloaded models.Order
+	if err := db.First(&reloaded, order.ID).Error; err != nil || len(reloaded.Tags) != 1 {
+		t.Fatalf("expected tags to persist, got %+v err=%v", reloaded.Tags, err)
+	}
+
+	_, err = svc.ReplyToTicket(admin.ID, "T-MISSING", "Hello")
+	requireBizErr(t, err, "integration.ticketNotFound")
+	_, err = svc.ReplyToTicket(admin.ID, "T-ZAP", "   ")
+	requireBizErr(t, err, "ticket.messageEmpty")
+
+	message, err := svc.ReplyToTicket(admin.ID, "T-ZAP", "Your parcel ships today")
+	if err != nil || message.SenderName != "Ops" || message.SenderType != "admin" {
+		t.Fatalf("unexpected reply %+v err=%v", message, err)
+	}
+	var updated models.Ticket
+	if err := db.First(&updated, ticket.ID).Error; err != nil {
+		t.Fatalf("reload ticket failed: %v", err)
+	}
+	if updated.Status != models.TicketStatusProcessing || updated.UnreadCountUser != 1 || updated.FirstResponseAt == nil {
+		t.Fatalf("expected the ticket to be in progress with an unread reply, got %+v", updated)
+	}
+
+	if err := db.Model(&updated).Update("status", models.TicketStatusClosed).Error; err != nil {
+		t.Fatalf("close ticket failed: %v", err)
+	}
+	_, err = svc.ReplyToTicket(admin.ID, "T-ZAP", "Another update")
+	requireBizErr(t, err, "ticket.closedCannotSend")
+}
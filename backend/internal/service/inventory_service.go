@@ -7,6 +7,7 @@ import (
 
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
 	"auralogic/internal/repository"
 	"gorm.io/gorm"
 )
@@ -68,19 +69,39 @@ func (s *InventoryService) CreateInventory(name, sku string, attrs map[string]st
 	return inventory, nil
 }
 
-// UpdateInventory 更新Inventory记录
-func (s *InventoryService) UpdateInventory(id uint, stock, availableQuantity, safetyStock int, isActive bool) error {
-	inventory, err := s.inventoryRepo.FindByID(id)
-	if err != nil {
+// UpdateInventory 更新Inventory记录，expectedVersion 为客户端读取时的版本号（0 表示不校验）
+func (s *InventoryService) UpdateInventory(id, expectedVersion uint, stock, availableQuantity, safetyStock int, isActive bool) error {
+	if _, err := s.inventoryRepo.FindByID(id); err != nil {
 		return translateInventoryLookupError(err)
 	}
 
-	inventory.Stock = stock
-	inventory.AvailableQuantity = availableQuantity
-	inventory.SafetyStock = safetyStock
-	inventory.IsActive = isActive
+	// 只写入编辑的字段，避免覆盖下单并发写入的已售、预留数量
+	if err := s.inventoryRepo.UpdateWithVersion(id, expectedVersion, map[string]interface{}{
+		"stock":              stock,
+		"available_quantity": availableQuantity,
+		"safety_stock":       safetyStock,
+		"is_active":          isActive,
+	}); err != nil {
+		return translateVersionConflictError(translateInventoryLookupError(err))
+	}
+	InvalidateFlashSaleCounter(id)
+	return nil
+}
 
-	return s.inventoryRepo.Update(inventory)
+// SetFlashSale 切换秒杀模式，切换后 Redis 计数按数据库重新初始化
+func (s *InventoryService) SetFlashSale(id uint, enabled bool) error {
+	inventory, err := s.inventoryRepo.FindByID(id)
+	if err != nil {
+		return translateInventoryLookupError(err)
+	}
+	if inventory.FlashSale == enabled {
+		return nil
+	}
+	if err := s.inventoryRepo.UpdateFlashSale(id, enabled); err != nil {
+		return err
+	}
+	InvalidateFlashSaleCounter(id)
+	return nil
 }
 
 // GetInventory 获取Inventory详情
@@ -150,14 +171,62 @@ func (s *InventoryService) CancelReserve(inventoryID uint, quantity int, orderNo
 
 // AdjustStock 调整库存（入库、盘点等）- 旧方法保留用于兼容
 func (s *InventoryService) AdjustStock(id uint, newStock, newAvailable int, operator, reason string) error {
-	return s.inventoryRepo.Adjust(id, newStock, newAvailable, operator, reason)
+	if err := s.inventoryRepo.Adjust(id, newStock, newAvailable, operator, reason); err != nil {
+		return err
+	}
+	InvalidateFlashSaleCounter(id)
+	return nil
 }
 
 // AdjustStockByDelta 通过增量调整库存（推荐使用，避免并发问题）
 func (s *InventoryService) AdjustStockByDelta(id uint, stockDelta, availableDelta int, operator, reason string) error {
-	return translateInventoryAdjustError(
-		s.inventoryRepo.AdjustByDelta(id, stockDelta, availableDelta, operator, reason),
-	)
+	if err := s.inventoryRepo.AdjustByDelta(id, stockDelta, availableDelta, operator, reason); err != nil {
+		return translateInventoryAdjustError(err)
+	}
+	InvalidateFlashSaleCounter(id)
+	return nil
+}
+
+// InventoryAdjustPreview 增量调整的试运行结果
+type InventoryAdjustPreview struct {
+	DryRun                  bool   `json:"dry_run"`
+	InventoryID             uint   `json:"inventory_id"`
+	Name                    string `json:"name"`
+	StockDelta              int    `json:"stock_delta"`
+	AvailableQuantityDelta  int    `json:"available_quantity_delta"`
+	BeforeStock             int    `json:"before_stock"`
+	AfterStock              int    `json:"after_stock"`
+	BeforeAvailableQuantity int    `json:"before_available_quantity"`
+	AfterAvailableQuantity  int    `json:"after_available_quantity"`
+	SoldQuantity            int    `json:"sold_quantity"`
+	ReservedQuantity        int    `json:"reserved_quantity"`
+	Version                 uint   `json:"version"`
+}
+
+// PreviewAdjustStockByDelta 按 AdjustStockByDelta 的校验规则计算调整结果，不写入库存与库存日志
+func (s *InventoryService) PreviewAdjustStockByDelta(id uint, stockDelta, availableDelta int) (*InventoryAdjustPreview, error) {
+	inventory, err := s.inventoryRepo.FindByID(id)
+	if err != nil {
+		return nil, translateInventoryLookupError(err)
+	}
+	afterStock, afterAvailable, err := repository.ApplyInventoryDelta(inventory, stockDelta, availableDelta)
+	if err != nil {
+		return nil, translateInventoryAdjustError(err)
+	}
+	return &InventoryAdjustPreview{
+		DryRun:                  true,
+		InventoryID:             inventory.ID,
+		Name:                    inventory.Name,
+		StockDelta:              stockDelta,
+		AvailableQuantityDelta:  availableDelta,
+		BeforeStock:             inventory.Stock,
+		AfterStock:              afterStock,
+		BeforeAvailableQuantity: inventory.AvailableQuantity,
+		AfterAvailableQuantity:  afterAvailable,
+		SoldQuantity:            inventory.SoldQuantity,
+		ReservedQuantity:        inventory.ReservedQuantity,
+		Version:                 inventory.Version,
+	}, nil
 }
 
 // GetLowStockList get低Inventory列表
@@ -201,6 +270,14 @@ func translateInventoryLookupError(err error) error {
 	return err
 }
 
+// translateVersionConflictError 将乐观锁冲突转换为业务错误，提示管理员刷新后重新修改
+func translateVersionConflictError(err error) error {
+	if errors.Is(err, dbutil.ErrVersionConflict) {
+		return bizerr.CodeVersionConflict.New()
+	}
+	return err
+}
+
 func translateInventoryAdjustError(err error) error {
 	if err == nil {
 		return nil
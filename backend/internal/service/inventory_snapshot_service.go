@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	inventorySnapshotDateLayout  = "2006-01-02"
+	inventorySnapshotJobInterval = time.Hour
+)
+
+var (
+	errInventorySnapshotDateInvalid  = bizerr.Register("inventory.snapshotDateInvalid", 400, "Date must be in YYYY-MM-DD format")
+	errInventorySnapshotRangeInvalid = bizerr.Register("inventory.snapshotRangeInvalid", 400, "The start date must not be later than the end date")
+	errInventorySnapshotNotFound     = bizerr.Register("inventory.snapshotNotFound", 404, "No inventory snapshot exists on or before the requested date")
+)
+
+// InventorySnapshotFilter 快照查询过滤条件
+type InventorySnapshotFilter struct {
+	Source string // physical, virtual，为空表示全部
+	Search string // 按 SKU 或名称模糊匹配
+}
+
+// InventorySnapshotResult 某日的库存水平；SnapshotDate 为实际使用的快照日期（不晚于查询日期的最近一次）
+type InventorySnapshotResult struct {
+	RequestedDate string                     `json:"requested_date"`
+	SnapshotDate  string                     `json:"snapshot_date"`
+	CapturedAt    *time.Time                 `json:"captured_at,omitempty"`
+	Items         []models.InventorySnapshot `json:"items"`
+}
+
+// InventorySnapshotLevel 对比中某一端的库存水平
+type InventorySnapshotLevel struct {
+	OnHand    int64 `json:"on_hand"`
+	Available int64 `json:"available"`
+	Reserved  int64 `json:"reserved"`
+	Sold      int64 `json:"sold"`
+}
+
+// InventorySnapshotComparison 单个库存在两个日期之间的变化；From/To 为 nil 表示该日期尚无此库存
+type InventorySnapshotComparison struct {
+	Source      string                  `json:"source"`
+	InventoryID uint                    `json:"inventory_id"`
+	SKU         string                  `json:"sku"`
+	Name        string                  `json:"name"`
+	From        *InventorySnapshotLevel `json:"from,omitempty"`
+	To          *InventorySnapshotLevel `json:"to,omitempty"`
+	Delta       InventorySnapshotLevel  `json:"delta"`
+}
+
+// InventorySnapshotCompareResult 两个日期的库存对比
+type InventorySnapshotCompareResult struct {
+	FromDate         string                        `json:"from_date"`
+	ToDate           string                        `json:"to_date"`
+	FromSnapshotDate string                        `json:"from_snapshot_date"`
+	ToSnapshotDate   string                        `json:"to_snapshot_date"`
+	Items            []InventorySnapshotComparison `json:"items"`
+	Totals           InventorySnapshotLevel        `json:"totals"` // 各项变化量合计
+}
+
+// InventorySnapshotService 每日库存快照：定时记录每个实物/虚拟库存的库存水平，供会计按历史时点查询与对比
+type InventorySnapshotService struct {
+	db *gorm.DB
+}
+
+func NewInventorySnapshotService(db *gorm.DB) *InventorySnapshotService {
+	return &InventorySnapshotService{db: db}
+}
+
+// RegisterJobs 注册每日快照任务；每小时检查一次，当天（UTC）尚无快照时拍摄，因此快照在零点后的首次执行中生成
+func (s *InventorySnapshotService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "inventory_snapshot",
+		Description: "Record the daily stock level of every physical and virtual inventory for point-in-time reporting",
+		Interval:    inventorySnapshotJobInterval,
+		Run:         s.ProcessDailySnapshot,
+	})
+}
+
+// ProcessDailySnapshot 当天尚无快照时拍摄；已有快照（含手动拍摄）则跳过，保证同一天的数据来自同一时刻
+func (s *InventorySnapshotService) ProcessDailySnapshot(ctx context.Context) error {
+	date := models.NowFunc().UTC().Format(inventorySnapshotDateLayout)
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.InventorySnapshot{}).Where("snapshot_date = ?", date).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := s.TakeSnapshot(ctx)
+	return err
+}
+
+// TakeSnapshot 记录当前库存水平为当天（UTC）的快照；同一天重复执行时覆盖当天已有数据
+func (s *InventorySnapshotService) TakeSnapshot(ctx context.Context) (*InventorySnapshotResult, error) {
+	now := models.NowFunc().UTC()
+	date := now.Format(inventorySnapshotDateLayout)
+
+	snapshots, err := s.capturePhysical(ctx, date, now)
+	if err != nil {
+		return nil, err
+	}
+	virtualSnapshots, err := s.captureVirtual(ctx, date, now)
+	if err != nil {
+		return nil, err
+	}
+	snapshots = append(snapshots, virtualSnapshots...)
+
+	if len(snapshots) > 0 {
+		if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "snapshot_date"}, {Name: "source"}, {Name: "inventory_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"sku", "name", "on_hand", "available", "reserved", "sold", "captured_at"}),
+		}).CreateInBatches(snapshots, 200).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &InventorySnapshotResult{RequestedDate: date, SnapshotDate: date, CapturedAt: &now, Items: snapshots}, nil
+}
+
+// capturePhysical 实物库存：在库数量为库存数减已售数（预留仍在库中）
+func (s *InventorySnapshotService) capturePhysical(ctx context.Context, date string, now time.Time) ([]models.InventorySnapshot, error) {
+	var inventories []models.Inventory
+	if err := s.db.WithContext(ctx).
+		Select("id", "name", "sku", "stock", "available_quantity", "sold_quantity", "reserved_quantity").
+		Order("id ASC").Find(&inventories).Error; err != nil {
+		return nil, err
+	}
+	snapshots := make([]models.InventorySnapshot, 0, len(inventories))
+	for i := range inventories {
+		inv := &inventories[i]
+		snapshots = append(snapshots, models.InventorySnapshot{
+			SnapshotDate: date,
+			Source:       models.InventorySnapshotSourcePhysical,
+			InventoryID:  inv.ID,
+			SKU:          inv.SKU,
+			Name:         inv.Name,
+			OnHand:       int64(inv.Stock - inv.SoldQuantity),
+			Available:    int64(inv.GetAvailableStock()),
+			Reserved:     int64(inv.ReservedQuantity),
+			Sold:         int64(inv.SoldQuantity),
+			CapturedAt:   now,
+		})
+	}
+	return snapshots, nil
+}
+
+// captureVirtual 静态卡密库存：按库存项状态统计；脚本库存按需生成内容，没有库存水平，不纳入快照
+func (s *InventorySnapshotService) captureVirtual(ctx context.Context, date string, now time.Time) ([]models.InventorySnapshot, error) {
+	var inventories []models.VirtualInventory
+	if err := s.db.WithContext(ctx).Select("id", "name", "sku").
+		Where("type <> ?", models.VirtualInventoryTypeScript).
+		Order("id ASC").Find(&inventories).Error; err != nil {
+		return nil, err
+	}
+	if len(inventories) == 0 {
+		return nil, nil
+	}
+	inventoryIDs := make([]uint, 0, len(inventories))
+	for _, inv := range inventories {
+		inventoryIDs = append(inventoryIDs, inv.ID)
+	}
+
+	var countRows []inventoryStatusCountRow
+	if err := s.db.WithContext(ctx).Model(&models.VirtualProductStock{}).
+		Select("virtual_inventory_id, status, COUNT(*) as count").
+		Where("virtual_inventory_id IN ?", inventoryIDs).
+		Group("virtual_inventory_id, status").
+		Scan(&countRows).Error; err != nil {
+		return nil, err
+	}
+	statusCounts := make(map[uint]map[string]int64, len(inventories))
+	for _, row := range countRows {
+		if _, ok := statusCounts[row.VirtualInventoryID]; !ok {
+			statusCounts[row.VirtualInventoryID] = make(map[string]int64)
+		}
+		statusCounts[row.VirtualInventoryID][row.Status] = row.Count
+	}
+
+	snapshots := make([]models.InventorySnapshot, 0, len(inventories))
+	for _, inv := range inventories {
+		counts := statusCounts[inv.ID]
+		available := counts[string(models.VirtualStockStatusAvailable)]
+		reserved := counts[string(models.VirtualStockStatusReserved)] + counts[string(models.VirtualStockStatusHeld)]
+		snapshots = append(snapshots, models.InventorySnapshot{
+			SnapshotDate: date,
+			Source:       models.InventorySnapshotSourceVirtual,
+			InventoryID:  inv.ID,
+			SKU:          inv.SKU,
+			Name:         inv.Name,
+			OnHand:       available + reserved,
+			Available:    available,
+			Reserved:     reserved,
+			Sold:         counts[string(models.VirtualStockStatusSold)],
+			CapturedAt:   now,
+		})
+	}
+	return snapshots, nil
+}
+
+// parseSnapshotDate 校验日期参数，空值表示今天（UTC）
+func parseSnapshotDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return models.NowFunc().UTC().Format(inventorySnapshotDateLayout), nil
+	}
+	parsed, err := time.Parse(inventorySnapshotDateLayout, value)
+	if err != nil {
+		return "", errInventorySnapshotDateInvalid.New()
+	}
+	return parsed.Format(inventorySnapshotDateLayout), nil
+}
+
+// resolveSnapshotDate 查找不晚于指定日期的最近一次快照日期
+func (s *InventorySnapshotService) resolveSnapshotDate(date string) (string, error) {
+	var snapshot models.InventorySnapshot
+	err := s.db.Select("snapshot_date").Where("snapshot_date <= ?", date).
+		Order("snapshot_date DESC").Limit(1).Find(&snapshot).Error
+	if err != nil {
+		return "", err
+	}
+	if snapshot.SnapshotDate == "" {
+		return "", errInventorySnapshotNotFound.Newf("No inventory snapshot exists on or before %s", date).WithParams(map[string]interface{}{"date": date})
+	}
+	return snapshot.SnapshotDate, nil
+}
+
+func (s *InventorySnapshotService) loadSnapshots(date string, filter InventorySnapshotFilter) ([]models.InventorySnapshot, error) {
+	query := s.db.Where("snapshot_date = ?", date)
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("sku LIKE ? OR name LIKE ?", like, like)
+	}
+	var snapshots []models.InventorySnapshot
+	if err := query.Order("source ASC, inventory_id ASC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetSnapshot 查询指定日期的库存水平；当天没有快照时使用之前最近的一次
+func (s *InventorySnapshotService) GetSnapshot(date string, filter InventorySnapshotFilter) (*InventorySnapshotResult, error) {
+	requested, err := parseSnapshotDate(date)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := s.resolveSnapshotDate(requested)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.loadSnapshots(resolved, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := &InventorySnapshotResult{RequestedDate: requested, SnapshotDate: resolved, Items: snapshots}
+	if len(snapshots) > 0 {
+		capturedAt := snapshots[0].CapturedAt
+		result.CapturedAt = &capturedAt
+	}
+	return result, nil
+}
+
+// Compare 对比两个日期的库存水平，Delta 为 to 减 from；只在一端存在的库存按另一端为 0 计算
+func (s *InventorySnapshotService) Compare(from, to string, filter InventorySnapshotFilter) (*InventorySnapshotCompareResult, error) {
+	fromDate, err := parseSnapshotDate(from)
+	if err != nil {
+		return nil, err
+	}
+	toDate, err := parseSnapshotDate(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromDate > toDate {
+		return nil, errInventorySnapshotRangeInvalid.New()
+	}
+	fromSnapshot, err := s.resolveSnapshotDate(fromDate)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := s.resolveSnapshotDate(toDate)
+	if err != nil {
+		return nil, err
+	}
+	fromItems, err := s.loadSnapshots(fromSnapshot, filter)
+	if err != nil {
+		return nil, err
+	}
+	toItems, err := s.loadSnapshots(toSnapshot, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type snapshotKey struct {
+		source string
+		id     uint
+	}
+	comparisons := make(map[snapshotKey]*InventorySnapshotComparison, len(toItems))
+	entry := func(snapshot models.InventorySnapshot) *InventorySnapshotComparison {
+		key := snapshotKey{source: snapshot.Source, id: snapshot.InventoryID}
+		item, ok := comparisons[key]
+		if !ok {
+			item = &InventorySnapshotComparison{Source: snapshot.Source, InventoryID: snapshot.InventoryID}
+			comparisons[key] = item
+		}
+		// 名称与 SKU 以较新的快照为准
+		item.SKU = snapshot.SKU
+		item.Name = snapshot.Name
+		return item
+	}
+	for _, snapshot := range fromItems {
+		entry(snapshot).From = snapshotLevel(snapshot)
+	}
+	for _, snapshot := range toItems {
+		entry(snapshot).To = snapshotLevel(snapshot)
+	}
+
+	result := &InventorySnapshotCompareResult{
+		FromDate:         fromDate,
+		ToDate:           toDate,
+		FromSnapshotDate: fromSnapshot,
+		ToSnapshotDate:   toSnapshot,
+		Items:            make([]InventorySnapshotComparison, 0, len(comparisons)),
+	}
+	for _, item := range comparisons {
+		var fromLevel, toLevel InventorySnapshotLevel
+		if item.From != nil {
+			fromLevel = *item.From
+		}
+		if item.To != nil {
+			toLevel = *item.To
+		}
+		item.Delta = InventorySnapshotLevel{
+			OnHand:    toLevel.OnHand - fromLevel.OnHand,
+			Available: toLevel.Available - fromLevel.Available,
+			Reserved:  toLevel.Reserved - fromLevel.Reserved,
+			Sold:      toLevel.Sold - fromLevel.Sold,
+		}
+		result.Totals.OnHand += item.Delta.OnHand
+		result.Totals.Available += item.Delta.Available
+		result.Totals.Reserved += item.Delta.Reserved
+		result.Totals.Sold += item.Delta.Sold
+		result.Items = append(result.Items, *item)
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].Source != result.Items[j].Source {
+			return result.Items[i].Source < result.Items[j].Source
+		}
+		return result.Items[i].InventoryID < result.Items[j].InventoryID
+	})
+	return result, nil
+}
+
+func snapshotLevel(snapshot models.InventorySnapshot) *InventorySnapshotLevel {
+	return &InventorySnapshotLevel{
+		OnHand:    snapshot.OnHand,
+		Available: snapshot.Available,
+		Reserved:  snapshot.Reserved,
+		Sold:      snapshot.Sold,
+	}
+}
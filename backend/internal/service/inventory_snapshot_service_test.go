@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestInventorySnapshotPointInTimeAndCompare(t *testing.T) {
+	inventoryService, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}, &models.InventorySnapshot{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	snapshots := NewInventorySnapshotService(db)
+
+	physical := &models.Inventory{Name: "T-shirt L", SKU: "TS-L", Stock: 100, AvailableQuantity: 100, SoldQuantity: 20, ReservedQuantity: 5, IsActive: true}
+	if err := db.Create(physical).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	keys := &models.VirtualInventory{Name: "Game keys", SKU: "GK", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	scripted := &models.VirtualInventory{Name: "Scripted", Type: models.VirtualInventoryTypeScript, IsActive: true}
+	for _, inv := range []*models.VirtualInventory{keys, scripted} {
+		if err := db.Create(inv).Error; err != nil {
+			t.Fatalf("create virtual inventory: %v", err)
+		}
+	}
+	if _, err := inventoryService.ImportFromText(keys.ID, "K1\nK2\nK3", "admin"); err != nil {
+		t.Fatalf("import stock: %v", err)
+	}
+
+	originalNow := models.NowFunc
+	models.NowFunc = func() time.Time { return time.Date(2026, 3, 1, 0, 30, 0, 0, time.UTC) }
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	_, err := snapshots.GetSnapshot("2026-03-01", InventorySnapshotFilter{})
+	requireOrderBizErr(t, err, "inventory.snapshotNotFound")
+	if err := snapshots.ProcessDailySnapshot(context.Background()); err != nil {
+		t.Fatalf("daily snapshot: %v", err)
+	}
+
+	// 当天已有快照时定时任务不再重复拍摄，之后的库存变化不影响当天数据
+	if err := db.Model(physical).Updates(map[string]interface{}{"sold_quantity": 50, "reserved_quantity": 0}).Error; err != nil {
+		t.Fatalf("update inventory: %v", err)
+	}
+	if err := db.Model(&models.VirtualProductStock{}).Where("content = ?", "K1").Update("status", models.VirtualStockStatusSold).Error; err != nil {
+		t.Fatalf("sell stock: %v", err)
+	}
+	if err := snapshots.ProcessDailySnapshot(context.Background()); err != nil {
+		t.Fatalf("repeat daily snapshot: %v", err)
+	}
+
+	first, err := snapshots.GetSnapshot("2026-03-01", InventorySnapshotFilter{})
+	if err != nil || len(first.Items) != 2 {
+		t.Fatalf("expected physical and static virtual snapshot, got %+v err=%v", first, err)
+	}
+	for _, item := range first.Items {
+		switch item.Source {
+		case models.InventorySnapshotSourcePhysical:
+			if item.OnHand != 80 || item.Available != 75 || item.Reserved != 5 || item.Sold != 20 {
+				t.Fatalf("unexpected physical snapshot: %+v", item)
+			}
+		case models.InventorySnapshotSourceVirtual:
+			if item.InventoryID != keys.ID || item.OnHand != 3 || item.Sold != 0 {
+				t.Fatalf("unexpected virtual snapshot: %+v", item)
+			}
+		}
+	}
+
+	// 没有快照的日期使用之前最近的一次
+	models.NowFunc = func() time.Time { return time.Date(2026, 3, 3, 0, 10, 0, 0, time.UTC) }
+	if err := snapshots.ProcessDailySnapshot(context.Background()); err != nil {
+		t.Fatalf("second daily snapshot: %v", err)
+	}
+	gap, err := snapshots.GetSnapshot("2026-03-02", InventorySnapshotFilter{Source: models.InventorySnapshotSourcePhysical})
+	if err != nil || gap.SnapshotDate != "2026-03-01" || len(gap.Items) != 1 || gap.Items[0].Sold != 20 {
+		t.Fatalf("expected fallback to 2026-03-01, got %+v err=%v", gap, err)
+	}
+
+	comparison, err := snapshots.Compare("2026-03-01", "2026-03-03", InventorySnapshotFilter{})
+	if err != nil || len(comparison.Items) != 2 {
+		t.Fatalf("compare snapshots: %+v err=%v", comparison, err)
+	}
+	for _, item := range comparison.Items {
+		switch item.Source {
+		case models.InventorySnapshotSourcePhysical:
+			if item.Delta.OnHand != -30 || item.Delta.Sold != 30 || item.Delta.Reserved != -5 {
+				t.Fatalf("unexpected physical delta: %+v", item.Delta)
+			}
+		case models.InventorySnapshotSourceVirtual:
+			if item.Delta.OnHand != -1 || item.Delta.Sold != 1 {
+				t.Fatalf("unexpected virtual delta: %+v", item.Delta)
+			}
+		}
+	}
+	if comparison.Totals.Sold != 31 {
+		t.Fatalf("unexpected totals: %+v", comparison.Totals)
+	}
+
+	_, err = snapshots.Compare("2026-03-03", "2026-03-01", InventorySnapshotFilter{})
+	requireOrderBizErr(t, err, "inventory.snapshotRangeInvalid")
+	_, err = snapshots.GetSnapshot("03/01/2026", InventorySnapshotFilter{})
+	requireOrderBizErr(t, err, "inventory.snapshotDateInvalid")
+}
@@ -0,0 +1,502 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	lowStockAlertJobInterval       = 10 * time.Minute
+	defaultLowStockAlertRepeat     = 24 * time.Hour
+	lowStockAlertMinGap            = time.Hour // 库存在阈值附近反复波动时，两次告警的最短间隔
+	lowStockAlertRequestTimeout    = 15 * time.Second
+	lowStockAlertResponseBodyLimit = 1024
+	maxLowStockSnoozeMinutes       = 30 * 24 * 60
+	lowStockAlertEvent             = "inventory.low_stock"
+)
+
+// telegramAPIBaseURL Telegram Bot API 地址，测试中替换为本地服务
+var telegramAPIBaseURL = "https://api.telegram.org"
+
+var (
+	errLowStockSnoozeInvalid  = bizerr.Register("inventory.lowStockSnoozeInvalid", 400, "Snooze duration must be between 1 minute and 30 days")
+	errLowStockSourceInvalid  = bizerr.Register("inventory.lowStockSourceInvalid", 400, "Source must be physical or virtual")
+	errLowStockAlertNotFound  = bizerr.Register("inventory.lowStockAlertNotFound", 404, "Inventory not found")
+	errLowStockAlertsDisabled = bizerr.Register("inventory.lowStockAlertsDisabled", 400, "Low-stock alerts are not enabled")
+)
+
+// LowStockAlertItem 一条低库存告警
+type LowStockAlertItem struct {
+	Source      string `json:"source"`
+	InventoryID uint   `json:"inventory_id"`
+	Name        string `json:"name"`
+	SKU         string `json:"sku,omitempty"`
+	Available   int64  `json:"available"`
+	Threshold   int    `json:"threshold"`
+	alertEmail  string // 实物库存单独设置的告警邮箱
+}
+
+// LowStockAlertResult 一次检查的结果
+type LowStockAlertResult struct {
+	Checked  int                 `json:"checked"`
+	Low      int                 `json:"low"`
+	Snoozed  int                 `json:"snoozed"`
+	Alerted  []LowStockAlertItem `json:"alerted"`
+	Channels []string            `json:"channels"` // 本次成功发送的通知渠道
+}
+
+// InventoryStockAlertService 低库存告警：定时检查设置了阈值的实物/静态卡密库存，低于阈值时通过邮件、Webhook、Telegram 通知管理员
+// 同一库存低库存期间只在首次及每隔 repeat_hours 提醒一次，管理员可对单个库存静默一段时间，避免告警风暴
+type InventoryStockAlertService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	emailService *EmailService
+	httpClient   *http.Client
+}
+
+func NewInventoryStockAlertService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *InventoryStockAlertService {
+	return &InventoryStockAlertService{
+		db:           db,
+		cfg:          cfg,
+		emailService: emailService,
+		httpClient:   &http.Client{Timeout: lowStockAlertRequestTimeout},
+	}
+}
+
+func (s *InventoryStockAlertService) alertConfig() config.LowStockAlertConfig {
+	if s.cfg == nil {
+		return config.LowStockAlertConfig{}
+	}
+	return s.cfg.Order.LowStockAlert
+}
+
+func (s *InventoryStockAlertService) appName() string {
+	if s.cfg != nil && s.cfg.App.Name != "" {
+		return s.cfg.App.Name
+	}
+	return "AuraLogic"
+}
+
+func (s *InventoryStockAlertService) repeatInterval() time.Duration {
+	if hours := s.alertConfig().RepeatHours; hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultLowStockAlertRepeat
+}
+
+// RegisterJobs 注册低库存检查任务
+func (s *InventoryStockAlertService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "inventory_low_stock_alert",
+		Description: "Notify admins by email, webhook or Telegram when an inventory's available stock falls below its low-stock threshold",
+		Interval:    lowStockAlertJobInterval,
+		Run: func(ctx context.Context) error {
+			if !s.alertConfig().Enabled {
+				return nil
+			}
+			_, err := s.Check(ctx)
+			return err
+		},
+	})
+}
+
+// currentLevels 收集设置了阈值的启用库存的可用数量
+func (s *InventoryStockAlertService) currentLevels(ctx context.Context) ([]LowStockAlertItem, error) {
+	var inventories []models.Inventory
+	if err := s.db.WithContext(ctx).
+		Select("id", "name", "sku", "available_quantity", "sold_quantity", "reserved_quantity", "safety_stock", "alert_email").
+		Where("safety_stock > 0 AND is_active = ?", true).
+		Order("id ASC").Find(&inventories).Error; err != nil {
+		return nil, err
+	}
+	items := make([]LowStockAlertItem, 0, len(inventories))
+	for i := range inventories {
+		inv := &inventories[i]
+		items = append(items, LowStockAlertItem{
+			Source:      models.InventorySnapshotSourcePhysical,
+			InventoryID: inv.ID,
+			Name:        inv.Name,
+			SKU:         inv.SKU,
+			Available:   int64(inv.GetAvailableStock()),
+			Threshold:   inv.SafetyStock,
+			alertEmail:  strings.TrimSpace(inv.AlertEmail),
+		})
+	}
+
+	var virtualInventories []models.VirtualInventory
+	if err := s.db.WithContext(ctx).Select("id", "name", "sku", "low_stock_threshold").
+		Where("low_stock_threshold > 0 AND is_active = ? AND type <> ?", true, models.VirtualInventoryTypeScript).
+		Order("id ASC").Find(&virtualInventories).Error; err != nil {
+		return nil, err
+	}
+	if len(virtualInventories) == 0 {
+		return items, nil
+	}
+	virtualIDs := make([]uint, 0, len(virtualInventories))
+	for _, inv := range virtualInventories {
+		virtualIDs = append(virtualIDs, inv.ID)
+	}
+	var countRows []inventoryStatusCountRow
+	if err := s.db.WithContext(ctx).Model(&models.VirtualProductStock{}).
+		Select("virtual_inventory_id, status, COUNT(*) as count").
+		Where("virtual_inventory_id IN ? AND status = ?", virtualIDs, models.VirtualStockStatusAvailable).
+		Group("virtual_inventory_id, status").
+		Scan(&countRows).Error; err != nil {
+		return nil, err
+	}
+	available := make(map[uint]int64, len(countRows))
+	for _, row := range countRows {
+		available[row.VirtualInventoryID] = row.Count
+	}
+	for _, inv := range virtualInventories {
+		items = append(items, LowStockAlertItem{
+			Source:      models.InventorySnapshotSourceVirtual,
+			InventoryID: inv.ID,
+			Name:        inv.Name,
+			SKU:         inv.SKU,
+			Available:   available[inv.ID],
+			Threshold:   inv.LowStockThreshold,
+		})
+	}
+	return items, nil
+}
+
+func lowStockAlertKey(source string, inventoryID uint) string {
+	return fmt.Sprintf("%s:%d", source, inventoryID)
+}
+
+// Check 检查所有设置了阈值的库存，更新告警状态并发送需要提醒的告警
+func (s *InventoryStockAlertService) Check(ctx context.Context) (*LowStockAlertResult, error) {
+	levels, err := s.currentLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var states []models.InventoryStockAlert
+	if err := s.db.WithContext(ctx).Find(&states).Error; err != nil {
+		return nil, err
+	}
+	statesByKey := make(map[string]*models.InventoryStockAlert, len(states))
+	for i := range states {
+		statesByKey[lowStockAlertKey(states[i].Source, states[i].InventoryID)] = &states[i]
+	}
+
+	now := models.NowFunc()
+	repeat := s.repeatInterval()
+	result := &LowStockAlertResult{Checked: len(levels), Alerted: []LowStockAlertItem{}, Channels: []string{}}
+	seen := make(map[string]bool, len(levels))
+	var pending []*models.InventoryStockAlert
+	for _, level := range levels {
+		key := lowStockAlertKey(level.Source, level.InventoryID)
+		seen[key] = true
+		low := level.Available < int64(level.Threshold)
+		state, exists := statesByKey[key]
+		if !exists {
+			if !low {
+				continue
+			}
+			state = &models.InventoryStockAlert{Source: level.Source, InventoryID: level.InventoryID}
+		}
+		if low && !state.Low {
+			state.LowSince = &now
+			state.AlertCount = 0
+		}
+		if !low {
+			state.LowSince = nil
+			state.AlertCount = 0
+		}
+		state.Low = low
+		state.Name = level.Name
+		state.SKU = level.SKU
+		state.Available = level.Available
+		state.Threshold = level.Threshold
+		state.CheckedAt = now
+
+		if low {
+			result.Low++
+			switch {
+			case state.IsSnoozed(now):
+				result.Snoozed++
+			case state.LastAlertedAt == nil,
+				state.AlertCount == 0 && now.Sub(*state.LastAlertedAt) >= lowStockAlertMinGap,
+				now.Sub(*state.LastAlertedAt) >= repeat:
+				result.Alerted = append(result.Alerted, level)
+				pending = append(pending, state)
+			}
+		}
+		if err := s.db.WithContext(ctx).Save(state).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// 阈值被清除、库存停用或删除后，不再视为低库存
+	for key, state := range statesByKey {
+		if seen[key] || !state.Low {
+			continue
+		}
+		if err := s.db.WithContext(ctx).Model(state).Updates(map[string]interface{}{
+			"low": false, "low_since": nil, "alert_count": 0, "checked_at": now,
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if len(result.Alerted) == 0 {
+		return result, nil
+	}
+	result.Channels = s.notify(ctx, result.Alerted)
+	for _, state := range pending {
+		if err := s.db.WithContext(ctx).Model(state).Updates(map[string]interface{}{
+			"last_alerted_at": now,
+			"alert_count":     gorm.Expr("alert_count + 1"),
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+	logger.LogSystemOperation(s.db, "low_stock_alert", "inventory", nil, map[string]interface{}{
+		"count":    len(result.Alerted),
+		"channels": result.Channels,
+	})
+	return result, nil
+}
+
+// notify 通过已配置的渠道发送告警，返回发送成功的渠道；单个渠道失败只记录日志，不影响其他渠道
+func (s *InventoryStockAlertService) notify(ctx context.Context, items []LowStockAlertItem) []string {
+	cfg := s.alertConfig()
+	channels := []string{}
+	if cfg.Email && s.emailService != nil && s.emailService.IsEnabled() {
+		if err := s.sendEmails(items); err != nil {
+			log.Printf("[LowStockAlert] email failed: %v", err)
+		} else {
+			channels = append(channels, "email")
+		}
+	}
+	if strings.TrimSpace(cfg.Webhook.URL) != "" {
+		if err := s.sendWebhook(ctx, cfg.Webhook, items); err != nil {
+			log.Printf("[LowStockAlert] webhook failed: %v", err)
+		} else {
+			channels = append(channels, "webhook")
+		}
+	}
+	if strings.TrimSpace(cfg.Telegram.BotToken) != "" && strings.TrimSpace(cfg.Telegram.ChatID) != "" {
+		if err := s.sendTelegram(ctx, cfg.Telegram, items); err != nil {
+			log.Printf("[LowStockAlert] telegram failed: %v", err)
+		} else {
+			channels = append(channels, "telegram")
+		}
+	}
+	return channels
+}
+
+func formatLowStockAlertLine(item LowStockAlertItem) string {
+	label := item.Name
+	if item.SKU != "" {
+		label = fmt.Sprintf("%s (%s)", item.Name, item.SKU)
+	}
+	return fmt.Sprintf("[%s #%d] %s: %d available, threshold %d", item.Source, item.InventoryID, label, item.Available, item.Threshold)
+}
+
+// sendEmails 超级管理员收到全部告警；实物库存设置的告警邮箱只收到对应库存的告警
+func (s *InventoryStockAlertService) sendEmails(items []LowStockAlertItem) error {
+	var admins []models.User
+	if err := s.db.Where("role = ? AND is_active = ?", "super_admin", true).Find(&admins).Error; err != nil {
+		return err
+	}
+	recipients := make(map[string][]LowStockAlertItem)
+	var order []string
+	add := func(email string, list []LowStockAlertItem) {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			return
+		}
+		if _, ok := recipients[email]; !ok {
+			order = append(order, email)
+		}
+		recipients[email] = append(recipients[email], list...)
+	}
+	for _, admin := range admins {
+		add(admin.Email, items)
+	}
+	for _, item := range items {
+		if item.alertEmail != "" {
+			if _, isAdmin := recipients[strings.ToLower(item.alertEmail)]; !isAdmin {
+				add(item.alertEmail, []LowStockAlertItem{item})
+			}
+		}
+	}
+
+	var firstErr error
+	for _, email := range order {
+		list := recipients[email]
+		subject := fmt.Sprintf("Low stock: %d inventories below threshold - %s", len(list), s.appName())
+		var body strings.Builder
+		body.WriteString("<p>The following inventories are below their low-stock threshold:</p><ul>")
+		for _, item := range list {
+			body.WriteString("<li>" + html.EscapeString(formatLowStockAlertLine(item)) + "</li>")
+		}
+		body.WriteString("</ul>")
+		if err := s.emailService.QueueEmail(email, subject, body.String(), lowStockAlertEvent, nil, nil); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *InventoryStockAlertService) sendWebhook(ctx context.Context, cfg config.LowStockAlertWebhookConfig, items []LowStockAlertItem) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":   lowStockAlertEvent,
+		"items":   items,
+		"sent_at": models.NowFunc().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSpace(cfg.URL), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return s.doAlertRequest(req)
+}
+
+func (s *InventoryStockAlertService) sendTelegram(ctx context.Context, cfg config.LowStockAlertTelegramConfig, items []LowStockAlertItem) error {
+	lines := make([]string, 0, len(items)+1)
+	lines = append(lines, fmt.Sprintf("Low stock alert - %s", s.appName()))
+	for _, item := range items {
+		lines = append(lines, formatLowStockAlertLine(item))
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"chat_id": strings.TrimSpace(cfg.ChatID),
+		"text":    strings.Join(lines, "\n"),
+	})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(telegramAPIBaseURL, "/"), url.PathEscape(strings.TrimSpace(cfg.BotToken)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := s.doAlertRequest(req); err != nil {
+		// 错误信息中的地址包含 bot token，不写入日志
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *InventoryStockAlertService) doAlertRequest(req *http.Request) error {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, lowStockAlertResponseBodyLimit))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ListAlerts 查询告警状态；lowOnly 为 true 时只返回当前低库存的库存
+func (s *InventoryStockAlertService) ListAlerts(lowOnly bool) ([]models.InventoryStockAlert, error) {
+	query := s.db.Model(&models.InventoryStockAlert{})
+	if lowOnly {
+		query = query.Where("low = ?", true)
+	}
+	var alerts []models.InventoryStockAlert
+	if err := query.Order("low DESC, available ASC, id ASC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// RunNow 立即执行一次检查，未启用告警时返回错误
+func (s *InventoryStockAlertService) RunNow(ctx context.Context) (*LowStockAlertResult, error) {
+	if !s.alertConfig().Enabled {
+		return nil, errLowStockAlertsDisabled.New()
+	}
+	return s.Check(ctx)
+}
+
+// loadInventoryIdentity 校验库存存在并返回名称与 SKU
+func (s *InventoryStockAlertService) loadInventoryIdentity(source string, inventoryID uint) (string, string, error) {
+	var model interface{}
+	switch source {
+	case models.InventorySnapshotSourcePhysical:
+		model = &models.Inventory{}
+	case models.InventorySnapshotSourceVirtual:
+		model = &models.VirtualInventory{}
+	default:
+		return "", "", errLowStockSourceInvalid.New()
+	}
+	var row struct {
+		Name string
+		SKU  string
+	}
+	result := s.db.Model(model).Select("name", "sku").Where("id = ?", inventoryID).Limit(1).Scan(&row)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", "", errLowStockAlertNotFound.New()
+	}
+	return row.Name, row.SKU, nil
+}
+
+// Snooze 在 minutes 分钟内不再发送该库存的告警；minutes 为 0 表示取消静默
+func (s *InventoryStockAlertService) Snooze(source string, inventoryID uint, minutes int, operatorID *uint) (*models.InventoryStockAlert, error) {
+	if minutes < 0 || minutes > maxLowStockSnoozeMinutes {
+		return nil, errLowStockSnoozeInvalid.New()
+	}
+	name, sku, err := s.loadInventoryIdentity(source, inventoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var state models.InventoryStockAlert
+	err = s.db.Where("source = ? AND inventory_id = ?", source, inventoryID).First(&state).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	now := models.NowFunc()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		state = models.InventoryStockAlert{Source: source, InventoryID: inventoryID, CheckedAt: now}
+	}
+	state.Name = name
+	state.SKU = sku
+	if minutes == 0 {
+		state.SnoozedUntil = nil
+		state.SnoozedBy = nil
+	} else {
+		until := now.Add(time.Duration(minutes) * time.Minute)
+		state.SnoozedUntil = &until
+		state.SnoozedBy = operatorID
+	}
+	if err := s.db.Save(&state).Error; err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestInventoryStockAlertNotifiesOnceAndRespectsSnooze(t *testing.T) {
+	inventoryService, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}, &models.InventoryStockAlert{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	var mu sync.Mutex
+	var webhookItems [][]LowStockAlertItem
+	var telegramTexts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/hook":
+			if r.Header.Get("X-Token") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var payload struct {
+				Event string              `json:"event"`
+				Items []LowStockAlertItem `json:"items"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			webhookItems = append(webhookItems, payload.Items)
+		case r.URL.Path == "/botTOKEN/sendMessage":
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			telegramTexts = append(telegramTexts, payload["text"])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	originalTelegram := telegramAPIBaseURL
+	telegramAPIBaseURL = server.URL
+	t.Cleanup(func() { telegramAPIBaseURL = originalTelegram })
+
+	cfg := &config.Config{}
+	cfg.Order.LowStockAlert = config.LowStockAlertConfig{
+		Enabled:     true,
+		RepeatHours: 12,
+		Webhook:     config.LowStockAlertWebhookConfig{URL: server.URL + "/hook", Headers: map[string]string{"X-Token": "secret"}},
+		Telegram:    config.LowStockAlertTelegramConfig{BotToken: "TOKEN", ChatID: "42"},
+	}
+	alerts := NewInventoryStockAlertService(db, cfg, nil)
+
+	shirt := &models.Inventory{Name: "T-shirt L", SKU: "TS-L", Stock: 100, AvailableQuantity: 100, SoldQuantity: 95, SafetyStock: 10, IsActive: true}
+	untracked := &models.Inventory{Name: "Mug", Stock: 1, AvailableQuantity: 1, IsActive: true}
+	for _, inv := range []*models.Inventory{shirt, untracked} {
+		if err := db.Create(inv).Error; err != nil {
+			t.Fatalf("create inventory: %v", err)
+		}
+	}
+	keys := &models.VirtualInventory{Name: "Game keys", Type: models.VirtualInventoryTypeStatic, IsActive: true, LowStockThreshold: 5}
+	if err := db.Create(keys).Error; err != nil {
+		t.Fatalf("create virtual inventory: %v", err)
+	}
+	if _, err := inventoryService.ImportFromText(keys.ID, "K1\nK2\nK3\nK4\nK5\nK6", "admin"); err != nil {
+		t.Fatalf("import stock: %v", err)
+	}
+
+	start := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	originalNow := models.NowFunc
+	models.NowFunc = func() time.Time { return start }
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	// 只有低于阈值的实物库存告警；未设置阈值的库存和库存充足的卡密库存不告警
+	result, err := alerts.Check(context.Background())
+	if err != nil || result.Checked != 2 || len(result.Alerted) != 1 || result.Alerted[0].InventoryID != shirt.ID {
+		t.Fatalf("unexpected first check: %+v err=%v", result, err)
+	}
+	if strings.Join(result.Channels, ",") != "webhook,telegram" || len(webhookItems) != 1 ||
+		len(telegramTexts) != 1 || !strings.Contains(telegramTexts[0], "T-shirt L (TS-L): 5 available, threshold 10") {
+		t.Fatalf("unexpected notifications: channels=%v webhook=%v telegram=%v", result.Channels, webhookItems, telegramTexts)
+	}
+
+	// 持续低库存在重复间隔内不再提醒；卡密库存降到阈值以下时单独告警
+	if err := db.Model(&models.VirtualProductStock{}).Where("content IN ?", []string{"K1", "K2"}).
+		Update("status", models.VirtualStockStatusSold).Error; err != nil {
+		t.Fatalf("sell stock: %v", err)
+	}
+	models.NowFunc = func() time.Time { return start.Add(10 * time.Minute) }
+	result, err = alerts.Check(context.Background())
+	if err != nil || result.Low != 2 || len(result.Alerted) != 1 || result.Alerted[0].Source != models.InventorySnapshotSourceVirtual {
+		t.Fatalf("unexpected second check: %+v err=%v", result, err)
+	}
+
+	// 静默期内即使到了重复间隔也不提醒，取消静默后恢复
+	if _, err := alerts.Snooze(models.InventorySnapshotSourcePhysical, shirt.ID, 24*60, nil); err != nil {
+		t.Fatalf("snooze: %v", err)
+	}
+	_, err = alerts.Snooze(models.InventorySnapshotSourcePhysical, shirt.ID, 31*24*60, nil)
+	requireOrderBizErr(t, err, "inventory.lowStockSnoozeInvalid")
+	_, err = alerts.Snooze("warehouse", shirt.ID, 60, nil)
+	requireOrderBizErr(t, err, "inventory.lowStockSourceInvalid")
+	_, err = alerts.Snooze(models.InventorySnapshotSourceVirtual, 9999, 60, nil)
+	requireOrderBizErr(t, err, "inventory.lowStockAlertNotFound")
+
+	models.NowFunc = func() time.Time { return start.Add(13 * time.Hour) }
+	result, err = alerts.Check(context.Background())
+	if err != nil || result.Snoozed != 1 || len(result.Alerted) != 1 || result.Alerted[0].Source != models.InventorySnapshotSourceVirtual {
+		t.Fatalf("expected only the virtual inventory to repeat, got %+v err=%v", result, err)
+	}
+	if _, err := alerts.Snooze(models.InventorySnapshotSourcePhysical, shirt.ID, 0, nil); err != nil {
+		t.Fatalf("unsnooze: %v", err)
+	}
+	result, err = alerts.Check(context.Background())
+	if err != nil || len(result.Alerted) != 1 || result.Alerted[0].InventoryID != shirt.ID {
+		t.Fatalf("expected physical alert after unsnooze, got %+v err=%v", result, err)
+	}
+
+	// 补货后恢复正常，告警状态重置
+	if err := db.Model(shirt).Update("available_quantity", 200).Error; err != nil {
+		t.Fatalf("restock: %v", err)
+	}
+	if _, err := alerts.Check(context.Background()); err != nil {
+		t.Fatalf("check after restock: %v", err)
+	}
+	low, err := alerts.ListAlerts(true)
+	if err != nil || len(low) != 1 || low[0].Source != models.InventorySnapshotSourceVirtual || low[0].AlertCount != 2 {
+		t.Fatalf("expected only the virtual inventory to stay low, got %+v err=%v", low, err)
+	}
+}
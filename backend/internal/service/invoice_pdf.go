@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+const defaultInvoicePDFTimeout = 30 * time.Second
+
+var (
+	ErrInvoicePDFDisabled      = errors.New("invoice pdf rendering is not configured")
+	ErrInvoicePDFRendererSetup = errors.New("invoice pdf renderer command must contain {input} and {output}")
+)
+
+// invoicePDFSlots 限制同时运行的渲染进程数，无头浏览器单个进程占用内存较大
+var invoicePDFSlots = make(chan struct{}, 2)
+
+// InvoicePDFEnabled 是否配置了账单 PDF 渲染命令
+func InvoicePDFEnabled(cfg *config.InvoiceConfig) bool {
+	return cfg.Enabled && strings.TrimSpace(cfg.PDFRendererCommand) != ""
+}
+
+// RenderInvoicePDF 将账单 HTML 写入临时文件，调用配置的渲染命令输出 PDF。
+// 与在线查看使用同一份 HTML，自定义模板和字体在 PDF 中保持一致
+func RenderInvoicePDF(ctx context.Context, cfg *config.InvoiceConfig, html []byte) ([]byte, error) {
+	if !InvoicePDFEnabled(cfg) {
+		return nil, ErrInvoicePDFDisabled
+	}
+	command := strings.TrimSpace(cfg.PDFRendererCommand)
+	if !strings.Contains(command, "{input}") || !strings.Contains(command, "{output}") {
+		return nil, ErrInvoicePDFRendererSetup
+	}
+
+	timeout := defaultInvoicePDFTimeout
+	if cfg.PDFTimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.PDFTimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case invoicePDFSlots <- struct{}{}:
+		defer func() { <-invoicePDFSlots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	dir, err := os.MkdirTemp("", "invoice-pdf-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "invoice.html")
+	output := filepath.Join(dir, "invoice.pdf")
+	if err := os.WriteFile(input, html, 0o600); err != nil {
+		return nil, err
+	}
+
+	// 按空白拆分参数，不经过 shell，占位符可出现在参数中间（如 --print-to-pdf={output}）
+	fields := strings.Fields(command)
+	args := make([]string, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		field = strings.ReplaceAll(field, "{input}", input)
+		field = strings.ReplaceAll(field, "{output}", output)
+		args = append(args, field)
+	}
+
+	cmd := exec.CommandContext(ctx, fields[0], args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("invoice pdf renderer timed out after %s", timeout)
+		}
+		return nil, fmt.Errorf("invoice pdf renderer failed: %w: %s", err, truncateRendererOutput(stderr.String()))
+	}
+
+	pdf, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("invoice pdf renderer produced no output: %w", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		return nil, errors.New("invoice pdf renderer output is not a PDF document")
+	}
+	return pdf, nil
+}
+
+func truncateRendererOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > 500 {
+		return output[:500] + "..."
+	}
+	return output
+}
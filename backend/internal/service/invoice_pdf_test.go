@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"auralogic/internal/config"
+)
+
+func TestRenderInvoicePDF(t *testing.T) {
+	if _, err := exec.LookPath("cp"); err != nil {
+		t.Skip("cp not available")
+	}
+
+	disabled := &config.InvoiceConfig{Enabled: true}
+	if _, err := RenderInvoicePDF(context.Background(), disabled, []byte("<html></html>")); !errors.Is(err, ErrInvoicePDFDisabled) {
+		t.Fatalf("expected ErrInvoicePDFDisabled, got %v", err)
+	}
+
+	missingPlaceholder := &config.InvoiceConfig{Enabled: true, PDFRendererCommand: "cp {input} /tmp/out.pdf"}
+	if _, err := RenderInvoicePDF(context.Background(), missingPlaceholder, []byte("<html></html>")); !errors.Is(err, ErrInvoicePDFRendererSetup) {
+		t.Fatalf("expected ErrInvoicePDFRendererSetup, got %v", err)
+	}
+
+	// 以 cp 代替无头浏览器：输入内容原样作为输出
+	cfg := &config.InvoiceConfig{Enabled: true, PDFRendererCommand: "cp {input} {output}"}
+	pdf, err := RenderInvoicePDF(context.Background(), cfg, []byte("%PDF-1.4 invoice"))
+	if err != nil {
+		t.Fatalf("render pdf: %v", err)
+	}
+	if string(pdf) != "%PDF-1.4 invoice" {
+		t.Fatalf("unexpected pdf output %q", pdf)
+	}
+
+	if _, err := RenderInvoicePDF(context.Background(), cfg, []byte("<html></html>")); err == nil {
+		t.Fatal("expected non-PDF renderer output to be rejected")
+	}
+}
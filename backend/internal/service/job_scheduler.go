@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/logger"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const (
+	jobLockKeyPrefix = "job:lock:"
+	// jobLockTTL 分布式锁有效期，执行期间每 1/3 周期续期，实例崩溃后最多等待该时长即可由其他实例接管
+	jobLockTTL = time.Minute
+	// jobErrorMaxLength 记录的错误信息上限
+	jobErrorMaxLength = 2000
+)
+
+var (
+	jobLockRefreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+	jobLockReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// JobDefinition 注册到调度器的周期任务
+type JobDefinition struct {
+	Name        string
+	Description string
+	Interval    time.Duration
+	// Run 执行一次任务；ctx 在调度器停止时取消，返回的 error 记录为本次执行失败
+	Run func(ctx context.Context) error
+}
+
+// JobLocker 任务分布式锁，保证同一任务同一时刻只在一个实例上执行
+type JobLocker interface {
+	TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	Refresh(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key, token string) error
+}
+
+// redisJobLocker 基于 Redis SET NX 的分布式锁，释放和续期校验持有者
+type redisJobLocker struct {
+	client *redis.Client
+}
+
+// NewRedisJobLocker 创建 Redis 分布式锁，多实例部署时使用
+func NewRedisJobLocker(client *redis.Client) JobLocker {
+	return &redisJobLocker{client: client}
+}
+
+func (l *redisJobLocker) TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, key, token, ttl).Result()
+}
+
+func (l *redisJobLocker) Refresh(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	result, err := jobLockRefreshScript.Run(ctx, l.client, []string{key}, token, ttl.Milliseconds()).Int()
+	return result == 1, err
+}
+
+func (l *redisJobLocker) Unlock(ctx context.Context, key, token string) error {
+	return jobLockReleaseScript.Run(ctx, l.client, []string{key}, token).Err()
+}
+
+// localJobLocker 进程内锁，仅适用于单实例部署（未初始化 Redis 时的兜底）
+type localJobLocker struct {
+	mu    sync.Mutex
+	locks map[string]localJobLock
+}
+
+type localJobLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewLocalJobLocker 创建进程内锁
+func NewLocalJobLocker() JobLocker {
+	return &localJobLocker{locks: make(map[string]localJobLock)}
+}
+
+func (l *localJobLocker) TryLock(_ context.Context, key, token string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[key]; ok && time.Now().Before(lock.expiresAt) {
+		return false, nil
+	}
+	l.locks[key] = localJobLock{token: token, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (l *localJobLocker) Refresh(_ context.Context, key, token string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock, ok := l.locks[key]
+	if !ok || lock.token != token {
+		return false, nil
+	}
+	lock.expiresAt = time.Now().Add(ttl)
+	l.locks[key] = lock
+	return true, nil
+}
+
+func (l *localJobLocker) Unlock(_ context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[key]; ok && lock.token == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+// sharedLocalJobLocker 未初始化 Redis 时调度器与各服务共用的进程内锁，保证手动触发与定时执行互斥
+var sharedLocalJobLocker = NewLocalJobLocker()
+
+// defaultJobLocker 有 Redis 时使用 Redis 锁，否则使用共享的进程内锁
+func defaultJobLocker() JobLocker {
+	if cache.RedisClient != nil {
+		return NewRedisJobLocker(cache.RedisClient)
+	}
+	return sharedLocalJobLocker
+}
+
+// ScheduledJobInfo 管理端展示的任务状态：本实例的注册信息 + 共享的最近执行记录
+type ScheduledJobInfo struct {
+	models.ScheduledJob
+	Description string     `json:"description,omitempty"`
+	Registered  bool       `json:"registered"`   // 是否在当前实例注册
+	RunningHere bool       `json:"running_here"` // 是否正在当前实例执行
+	NextRunAt   *time.Time `json:"next_run_at,omitempty"`
+}
+
+type scheduledJobEntry struct {
+	def     JobDefinition
+	running atomic.Bool
+}
+
+// JobScheduler 周期任务调度器：每个任务按自身周期触发，执行前获取分布式锁，
+// 并以 scheduled_jobs 中的最近开始时间判断本周期是否已由其他实例执行，多实例部署时每周期只执行一次
+type JobScheduler struct {
+	db         *gorm.DB
+	locker     JobLocker
+	instanceID string
+
+	jobsMu      sync.RWMutex
+	jobs        []*scheduledJobEntry
+	lifecycleMu sync.Mutex
+	running     bool
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewJobScheduler 创建调度器；locker 为 nil 时有 Redis 使用 Redis 锁，否则使用共享的进程内锁
+func NewJobScheduler(db *gorm.DB, locker JobLocker) *JobScheduler {
+	if locker == nil {
+		locker = defaultJobLocker()
+	}
+	return &JobScheduler{
+		db:         db,
+		locker:     locker,
+		instanceID: newJobInstanceID(),
+	}
+}
+
+func newJobInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), randomJobToken(4))
+}
+
+func randomJobToken(size int) string {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// InstanceID 当前实例标识（主机名:进程号:随机后缀）
+func (s *JobScheduler) InstanceID() string {
+	return s.instanceID
+}
+
+// Register 注册周期任务，须在 Start 之前调用；名称重复或定义不完整视为编程错误直接 panic
+func (s *JobScheduler) Register(def JobDefinition) {
+	def.Name = strings.TrimSpace(def.Name)
+	if def.Name == "" || def.Interval <= 0 || def.Run == nil {
+		panic(fmt.Sprintf("job scheduler: invalid job definition %q", def.Name))
+	}
+
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+	if s.running {
+		panic(fmt.Sprintf("job scheduler: register %q after start", def.Name))
+	}
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for _, entry := range s.jobs {
+		if entry.def.Name == def.Name {
+			panic(fmt.Sprintf("job scheduler: job %q registered twice", def.Name))
+		}
+	}
+	s.jobs = append(s.jobs, &scheduledJobEntry{def: def})
+}
+
+// Start 启动所有已注册任务，启动后立即执行一次（本周期已由其他实例执行时跳过）
+func (s *JobScheduler) Start() {
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+	if s.running {
+		return
+	}
+	stopChan := make(chan struct{})
+	s.stopChan = stopChan
+	s.running = true
+
+	s.jobsMu.RLock()
+	defer s.jobsMu.RUnlock()
+	names := make([]string, 0, len(s.jobs))
+	for _, entry := range s.jobs {
+		names = append(names, entry.def.Name)
+		entry := entry
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			runBackgroundServiceWithStopChan("job."+entry.def.Name, stopChan, func(stop <-chan struct{}) {
+				s.loop(entry, stop)
+			})
+		}()
+	}
+
+	logger.LogSystemOperation(s.db, "job_scheduler_start", "system", nil, map[string]interface{}{
+		"instance_id": s.instanceID,
+		"jobs":        names,
+	})
+}
+
+// Stop 停止调度并等待正在执行的任务结束
+func (s *JobScheduler) Stop() {
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+	if !s.running {
+		return
+	}
+	close(s.stopChan)
+	s.wg.Wait()
+	s.stopChan = nil
+	s.running = false
+
+	logger.LogSystemOperation(s.db, "job_scheduler_stop", "system", nil, map[string]interface{}{
+		"instance_id": s.instanceID,
+	})
+}
+
+func (s *JobScheduler) loop(entry *scheduledJobEntry, stopChan <-chan struct{}) {
+	s.runOnce(entry, stopChan)
+
+	ticker := time.NewTicker(entry.def.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			s.runOnce(entry, stopChan)
+		}
+	}
+}
+
+// runOnce 获取锁并在本周期未执行过时执行任务，返回是否实际执行
+func (s *JobScheduler) runOnce(entry *scheduledJobEntry, stopChan <-chan struct{}) bool {
+	name := entry.def.Name
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	key := jobLockKeyPrefix + name
+	token := s.instanceID + ":" + randomJobToken(8)
+	locked, err := s.locker.TryLock(ctx, key, token, jobLockTTL)
+	if err != nil {
+		log.Printf("[JobScheduler] %s: acquire lock failed: %v", name, err)
+		return false
+	}
+	if !locked {
+		return false
+	}
+	defer func() {
+		if err := s.locker.Unlock(context.Background(), key, token); err != nil {
+			log.Printf("[JobScheduler] %s: release lock failed: %v", name, err)
+		}
+	}()
+	go keepJobLock(ctx, s.locker, name, key, token)
+
+	startedAt := time.Now()
+	due, err := s.claim(entry.def, startedAt)
+	if err != nil {
+		log.Printf("[JobScheduler] %s: load job state failed: %v", name, err)
+		return false
+	}
+	if !due {
+		return false
+	}
+
+	entry.running.Store(true)
+	runErr := s.invoke(ctx, entry.def)
+	entry.running.Store(false)
+
+	s.finish(entry.def, startedAt, runErr)
+	return true
+}
+
+// keepJobLock 任务执行期间定期续期，避免长任务被其他实例重复执行
+func keepJobLock(ctx context.Context, locker JobLocker, name, key, token string) {
+	ticker := time.NewTicker(jobLockTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ok, err := locker.Refresh(ctx, key, token, jobLockTTL); err != nil || !ok {
+				if ctx.Err() == nil {
+					log.Printf("[JobScheduler] %s: lock refresh failed (held=%v): %v", name, ok, err)
+				}
+			}
+		}
+	}
+}
+
+// runWithJobLock 持有任务的分布式锁执行 fn，用于管理端手动触发等调度器之外的执行，与该任务的定时执行及其他实例互斥；
+// 锁已被占用时不执行并返回 false
+func runWithJobLock(ctx context.Context, locker JobLocker, name string, fn func(ctx context.Context) error) (bool, error) {
+	key := jobLockKeyPrefix + name
+	token := "manual:" + randomJobToken(8)
+	locked, err := locker.TryLock(ctx, key, token, jobLockTTL)
+	if err != nil {
+		return false, fmt.Errorf("acquire job lock %s: %w", name, err)
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		if err := locker.Unlock(context.Background(), key, token); err != nil {
+			log.Printf("[JobScheduler] %s: release lock failed: %v", name, err)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go keepJobLock(ctx, locker, name, key, token)
+	return true, fn(ctx)
+}
+
+// claim 本周期未执行时写入开始状态；容差为周期的 1/10，吸收各实例触发时间的偏差
+func (s *JobScheduler) claim(def JobDefinition, now time.Time) (bool, error) {
+	var records []models.ScheduledJob
+	if err := s.db.Where("name = ?", def.Name).Limit(1).Find(&records).Error; err != nil {
+		return false, err
+	}
+	if len(records) > 0 && records[0].LastStartedAt != nil {
+		if now.Sub(*records[0].LastStartedAt) < def.Interval-def.Interval/10 {
+			return false, nil
+		}
+	}
+
+	updates := map[string]interface{}{
+		"interval_seconds": int64(def.Interval / time.Second),
+		"last_status":      models.ScheduledJobStatusRunning,
+		"last_started_at":  now,
+		"last_instance":    s.instanceID,
+		"updated_at":       now,
+	}
+	if len(records) == 0 {
+		record := models.ScheduledJob{
+			Name:            def.Name,
+			IntervalSeconds: int64(def.Interval / time.Second),
+			LastStatus:      models.ScheduledJobStatusRunning,
+			LastStartedAt:   &now,
+			LastInstance:    s.instanceID,
+		}
+		return true, s.db.Create(&record).Error
+	}
+	return true, s.db.Model(&models.ScheduledJob{}).Where("name = ?", def.Name).Updates(updates).Error
+}
+
+// invoke 执行任务，panic 转为失败记录
+func (s *JobScheduler) invoke(ctx context.Context, def JobDefinition) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			recordBackgroundServicePanic("job."+def.Name, recovered)
+			log.Printf("[panic-guard] job.%s panic recovered: %v\n%s", def.Name, recovered, debug.Stack())
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return def.Run(ctx)
+}
+
+func (s *JobScheduler) finish(def JobDefinition, startedAt time.Time, runErr error) {
+	finishedAt := time.Now()
+	updates := map[string]interface{}{
+		"last_status":      models.ScheduledJobStatusSuccess,
+		"last_finished_at": finishedAt,
+		"last_duration_ms": finishedAt.Sub(startedAt).Milliseconds(),
+		"last_error":       "",
+		"run_count":        gorm.Expr("run_count + 1"),
+		"updated_at":       finishedAt,
+	}
+	if runErr != nil && !errors.Is(runErr, context.Canceled) {
+		message := runErr.Error()
+		if len(message) > jobErrorMaxLength {
+			message = message[:jobErrorMaxLength]
+		}
+		updates["last_status"] = models.ScheduledJobStatusFailed
+		updates["last_error"] = message
+		updates["fail_count"] = gorm.Expr("fail_count + 1")
+		log.Printf("[JobScheduler] %s failed: %v", def.Name, runErr)
+	}
+	if err := s.db.Model(&models.ScheduledJob{}).Where("name = ?", def.Name).Updates(updates).Error; err != nil {
+		log.Printf("[JobScheduler] %s: save job state failed: %v", def.Name, err)
+	}
+}
+
+// ListJobs 返回本实例注册的任务及其他实例记录过的任务，按名称排序
+func (s *JobScheduler) ListJobs() ([]ScheduledJobInfo, error) {
+	var records []models.ScheduledJob
+	if err := s.db.Order("name ASC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.ScheduledJob, len(records))
+	for _, record := range records {
+		byName[record.Name] = record
+	}
+
+	s.jobsMu.RLock()
+	entries := append([]*scheduledJobEntry(nil), s.jobs...)
+	s.jobsMu.RUnlock()
+
+	result := make([]ScheduledJobInfo, 0, len(byName)+len(entries))
+	for _, entry := range entries {
+		record, ok := byName[entry.def.Name]
+		if !ok {
+			record = models.ScheduledJob{Name: entry.def.Name}
+		}
+		record.IntervalSeconds = int64(entry.def.Interval / time.Second)
+		delete(byName, entry.def.Name)
+		result = append(result, buildScheduledJobInfo(record, entry.def.Description, true, entry.running.Load()))
+	}
+	for _, record := range byName {
+		result = append(result, buildScheduledJobInfo(record, "", false, false))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func buildScheduledJobInfo(record models.ScheduledJob, description string, registered, running bool) ScheduledJobInfo {
+	info := ScheduledJobInfo{
+		ScheduledJob: record,
+		Description:  description,
+		Registered:   registered,
+		RunningHere:  running,
+	}
+	if record.LastStartedAt != nil && record.IntervalSeconds > 0 {
+		next := record.LastStartedAt.Add(time.Duration(record.IntervalSeconds) * time.Second)
+		info.NextRunAt = &next
+	}
+	return info
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openJobSchedulerTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.ScheduledJob{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return db
+}
+
+func TestJobSchedulerRunsOncePerIntervalAcrossInstances(t *testing.T) {
+	db := openJobSchedulerTestDB(t)
+	locker := NewLocalJobLocker()
+
+	runs := 0
+	def := JobDefinition{
+		Name:     "test_job",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			runs++
+			return nil
+		},
+	}
+	// 两个调度器共享数据库和锁，模拟两个后端实例
+	first := NewJobScheduler(db, locker)
+	second := NewJobScheduler(db, locker)
+	first.Register(def)
+	second.Register(def)
+
+	stop := make(chan struct{})
+	if !first.runOnce(first.jobs[0], stop) {
+		t.Fatal("expected first instance to run the job")
+	}
+	if second.runOnce(second.jobs[0], stop) {
+		t.Fatal("expected second instance to skip a job already run this interval")
+	}
+	if runs != 1 {
+		t.Fatalf("expected a single run, got %d", runs)
+	}
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := db.Model(&models.ScheduledJob{}).Where("name = ?", "test_job").Update("last_started_at", past).Error; err != nil {
+		t.Fatalf("rewind job: %v", err)
+	}
+	// 周期已到但锁被其他实例持有时同样跳过
+	if ok, _ := locker.TryLock(context.Background(), jobLockKeyPrefix+"test_job", "other", time.Minute); !ok {
+		t.Fatal("expected lock to be free after the run")
+	}
+	if second.runOnce(second.jobs[0], stop) {
+		t.Fatal("expected job to be skipped while another instance holds the lock")
+	}
+	if err := locker.Unlock(context.Background(), jobLockKeyPrefix+"test_job", "other"); err != nil {
+		t.Fatalf("unlock: %v", err)
+	}
+	if !second.runOnce(second.jobs[0], stop) {
+		t.Fatal("expected second instance to run once the interval has passed")
+	}
+
+	var record models.ScheduledJob
+	if err := db.First(&record, "name = ?", "test_job").Error; err != nil {
+		t.Fatalf("load job record: %v", err)
+	}
+	if record.RunCount != 2 || record.LastStatus != models.ScheduledJobStatusSuccess || record.LastInstance != second.InstanceID() {
+		t.Fatalf("unexpected job record %+v", record)
+	}
+}
+
+func TestJobSchedulerRecordsFailuresAndPanics(t *testing.T) {
+	db := openJobSchedulerTestDB(t)
+	scheduler := NewJobScheduler(db, NewLocalJobLocker())
+	scheduler.Register(JobDefinition{
+		Name:        "failing_job",
+		Description: "always fails",
+		Interval:    time.Minute,
+		Run:         func(ctx context.Context) error { return errors.New("upstream unavailable") },
+	})
+	scheduler.Register(JobDefinition{
+		Name:     "panicking_job",
+		Interval: time.Minute,
+		Run:      func(ctx context.Context) error { panic("boom") },
+	})
+
+	stop := make(chan struct{})
+	for _, entry := range scheduler.jobs {
+		scheduler.runOnce(entry, stop)
+	}
+
+	jobs, err := scheduler.ListJobs()
+	if err != nil {
+		t.Fatalf("list jobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+	byName := make(map[string]ScheduledJobInfo, len(jobs))
+	for _, job := range jobs {
+		byName[job.Name] = job
+	}
+
+	failing := byName["failing_job"]
+	if failing.LastStatus != models.ScheduledJobStatusFailed || failing.LastError != "upstream unavailable" || failing.FailCount != 1 {
+		t.Fatalf("unexpected failing job state %+v", failing)
+	}
+	if !failing.Registered || failing.Description != "always fails" || failing.IntervalSeconds != 60 || failing.NextRunAt == nil {
+		t.Fatalf("expected registration details, got %+v", failing)
+	}
+	if panicking := byName["panicking_job"]; panicking.LastStatus != models.ScheduledJobStatusFailed || panicking.LastError != "panic: boom" {
+		t.Fatalf("expected panic to be recorded as failure, got %+v", panicking)
+	}
+}
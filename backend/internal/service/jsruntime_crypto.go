@@ -0,0 +1,154 @@
+package service
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// 支付网关（微信支付 APIv3、支付宝 RSA2）签名与回调解密所需的加密工具
+
+// createRSASignSHA256 使用商户私钥计算 SHA256withRSA 签名，返回 Base64；密钥无效时返回空字符串
+func (s *JSRuntimeService) createRSASignSHA256(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 2 {
+			return vm.ToValue("")
+		}
+		key, err := parseRSAPrivateKey(call.Arguments[1].String())
+		if err != nil {
+			return vm.ToValue("")
+		}
+		digest := sha256.Sum256([]byte(call.Arguments[0].String()))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			return vm.ToValue("")
+		}
+		return vm.ToValue(base64.StdEncoding.EncodeToString(signature))
+	}
+}
+
+// createRSAVerifySHA256 使用平台公钥（或证书）校验 Base64 编码的 SHA256withRSA 签名
+func (s *JSRuntimeService) createRSAVerifySHA256(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 3 {
+			return vm.ToValue(false)
+		}
+		key, err := parseRSAPublicKey(call.Arguments[2].String())
+		if err != nil {
+			return vm.ToValue(false)
+		}
+		signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(call.Arguments[1].String()))
+		if err != nil {
+			return vm.ToValue(false)
+		}
+		digest := sha256.Sum256([]byte(call.Arguments[0].String()))
+		return vm.ToValue(rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil)
+	}
+}
+
+// createAESGCMDecrypt 解密 AES-GCM 密文（微信支付回调 resource 使用 AEAD_AES_256_GCM）
+// 参数：key、nonce、Base64 密文、附加数据；失败时返回 undefined
+func (s *JSRuntimeService) createAESGCMDecrypt(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 3 {
+			return goja.Undefined()
+		}
+		additionalData := ""
+		if len(call.Arguments) > 3 && !goja.IsUndefined(call.Arguments[3]) && !goja.IsNull(call.Arguments[3]) {
+			additionalData = call.Arguments[3].String()
+		}
+		plaintext, err := decryptAESGCM(
+			[]byte(call.Arguments[0].String()),
+			[]byte(call.Arguments[1].String()),
+			call.Arguments[2].String(),
+			[]byte(additionalData),
+		)
+		if err != nil {
+			return goja.Undefined()
+		}
+		return vm.ToValue(string(plaintext))
+	}
+}
+
+func decryptAESGCM(key, nonce []byte, ciphertextBase64 string, additionalData []byte) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertextBase64))
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// decodePEMOrBase64DER 支持 PEM，也支持支付宝开放平台常见的不带头尾的 Base64 密钥
+func decodePEMOrBase64DER(raw string) ([]byte, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil, errors.New("key is empty")
+	}
+	if block, _ := pem.Decode([]byte(trimmed)); block != nil {
+		return block.Bytes, nil
+	}
+	compact := strings.NewReplacer("\n", "", "\r", "", " ", "", "\t", "").Replace(trimmed)
+	return base64.StdEncoding.DecodeString(compact)
+}
+
+func parseRSAPrivateKey(raw string) (*rsa.PrivateKey, error) {
+	der, err := decodePEMOrBase64DER(raw)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+	return key, nil
+}
+
+func parseRSAPublicKey(raw string) (*rsa.PublicKey, error) {
+	der, err := decodePEMOrBase64DER(raw)
+	if err != nil {
+		return nil, err
+	}
+	if parsed, err := x509.ParsePKIXPublicKey(der); err == nil {
+		if key, ok := parsed.(*rsa.PublicKey); ok {
+			return key, nil
+		}
+		return nil, errors.New("public key is not RSA")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return key, nil
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("certificate key is not RSA")
+	}
+	return key, nil
+}
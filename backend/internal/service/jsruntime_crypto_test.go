@@ -0,0 +1,408 @@
+package service
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openPaymentCryptoTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.PaymentMethodStorageEntry{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return db
+}
+
+func generateTestRSAKeyPEM(t *testing.T) (*rsa.PrivateKey, string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+	return key, privatePEM, publicPEM
+}
+
+func signTestSHA256(t *testing.T, key *rsa.PrivateKey, message string) string {
+	t.Helper()
+	digest := sha256.Sum256([]byte(message))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func builtinPaymentMethodByName(t *testing.T, name string) models.PaymentMethod {
+	t.Helper()
+	for _, method := range models.BuiltinPaymentMethods {
+		if method.Name == name {
+			return method
+		}
+	}
+	t.Fatalf("builtin payment method %q not found", name)
+	return models.PaymentMethod{}
+}
+
+func TestPaymentJSCryptoUtils(t *testing.T) {
+	_, privatePEM, publicPEM := generateTestRSAKeyPEM(t)
+	// 公钥同时以不带头尾的 Base64 形式传入，覆盖支付宝开放平台的密钥格式
+	pemLines := strings.Split(strings.TrimSpace(publicPEM), "\n")
+	bareBase64 := strings.Join(pemLines[1:len(pemLines)-1], "")
+
+	aesKey := "0123456789abcdef0123456789abcdef"
+	nonce := "abcdef123456"
+	block, _ := aes.NewCipher([]byte(aesKey))
+	aead, _ := cipher.NewGCM(block)
+	sealed := base64.StdEncoding.EncodeToString(aead.Seal(nil, []byte(nonce), []byte(`{"ok":true}`), []byte("transaction")))
+
+	svc := NewJSRuntimeService(openPaymentCryptoTestDB(t), &config.Config{})
+	pm := &models.PaymentMethod{
+		Name:   "Crypto Test",
+		Config: fmt.Sprintf(`{"private_key":%q,"public_key":%q,"bare_public_key":%q}`, privatePEM, publicPEM, bareBase64),
+		Script: fmt.Sprintf(`function onGeneratePaymentCard(order, config) {
+			var u = AuraLogic.utils;
+			var sig = u.rsaSignSHA256("payload", config.private_key);
+			var checks = [
+				sig !== "",
+				u.rsaVerifySHA256("payload", sig, config.public_key),
+				u.rsaVerifySHA256("payload", sig, config.bare_public_key),
+				!u.rsaVerifySHA256("tampered", sig, config.public_key),
+				u.rsaSignSHA256("payload", "not a key") === "",
+				u.aesGCMDecrypt(%q, %q, %q, "transaction"),
+				u.aesGCMDecrypt(%q, %q, %q, "other") === undefined
+			];
+			return { html: checks.join("|") };
+		}`, aesKey, nonce, sealed, aesKey, nonce, sealed),
+	}
+
+	result, err := svc.ExecutePaymentCard(pm, &models.Order{OrderNo: "ORD-CRYPTO"})
+	if err != nil {
+		t.Fatalf("execute script: %v", err)
+	}
+	if expected := `true|true|true|true|true|{"ok":true}|true`; result.HTML != expected {
+		t.Fatalf("expected %q, got %q", expected, result.HTML)
+	}
+}
+
+func TestBuiltinWeChatPayNotifyVerifiesSignatureAndAmount(t *testing.T) {
+	db := openPaymentCryptoTestDB(t)
+	svc := NewJSRuntimeService(db, &config.Config{})
+	platformKey, merchantPrivatePEM, platformPublicPEM := generateTestRSAKeyPEM(t)
+	apiV3Key := "0123456789abcdef0123456789abcdef"
+
+	pm := builtinPaymentMethodByName(t, "WeChat Pay")
+	pm.ID = 11
+	cfg, _ := json.Marshal(map[string]string{
+		"app_id":               "wx123",
+		"mch_id":               "1900000001",
+		"mch_serial_no":        "SERIAL",
+		"mch_private_key":      merchantPrivatePEM,
+		"api_v3_key":           apiV3Key,
+		"wechatpay_public_key": platformPublicPEM,
+	})
+	pm.Config = string(cfg)
+	if err := svc.storageSetValue(pm.ID, "trade_ORD1", `{"seq":1,"amount":1999,"out_trade_no":"ORD1_1","jsapi_out_trade_no":"ORD1_1J"}`); err != nil {
+		t.Fatalf("seed trade: %v", err)
+	}
+
+	notify := func(amount int64, tamper bool) *PaymentWebhookResult {
+		plain, _ := json.Marshal(map[string]interface{}{
+			"mchid": "1900000001", "out_trade_no": "ORD1_1", "transaction_id": "4200000001",
+			"trade_state": "SUCCESS", "trade_type": "NATIVE", "amount": map[string]interface{}{"total": amount},
+		})
+		block, _ := aes.NewCipher([]byte(apiV3Key))
+		aead, _ := cipher.NewGCM(block)
+		body, _ := json.Marshal(map[string]interface{}{
+			"event_type": "TRANSACTION.SUCCESS",
+			"resource": map[string]string{
+				"algorithm":       "AEAD_AES_256_GCM",
+				"nonce":           "n0nce1234567",
+				"associated_data": "transaction",
+				"ciphertext":      base64.StdEncoding.EncodeToString(aead.Seal(nil, []byte("n0nce1234567"), plain, []byte("transaction"))),
+			},
+		})
+		timestamp := fmt.Sprint(time.Now().Unix())
+		signature := signTestSHA256(t, platformKey, timestamp+"\nabc\n"+string(body)+"\n")
+		if tamper {
+			body = append(body, ' ')
+		}
+		result, err := svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{
+			Key:      "wechat.notify",
+			Method:   "POST",
+			BodyText: string(body),
+			Headers: map[string]string{
+				"wechatpay-timestamp": timestamp,
+				"wechatpay-nonce":     "abc",
+				"wechatpay-signature": signature,
+			},
+		})
+		if err != nil {
+			t.Fatalf("execute webhook: %v", err)
+		}
+		return result
+	}
+
+	if result := notify(1999, false); !result.Paid || result.OrderNo != "ORD1" || result.TransactionID != "4200000001" || result.AckStatus != 200 {
+		t.Fatalf("expected confirmed payment, got %+v", result)
+	}
+	if result := notify(1999, true); result.Paid || result.AckStatus != 401 {
+		t.Fatalf("expected rejected signature, got %+v", result)
+	}
+	if result := notify(1, false); result.Paid || result.AckStatus != 200 {
+		t.Fatalf("expected amount mismatch to be acknowledged without confirming, got %+v", result)
+	}
+
+	// 没有下单记录时无法核对金额，应答成功但不确认付款
+	if err := svc.storageDeleteKey(pm.ID, "trade_ORD1"); err != nil {
+		t.Fatalf("delete trade: %v", err)
+	}
+	if result := notify(1999, false); result.Paid || result.AckStatus != 200 || result.Message == "" {
+		t.Fatalf("expected notification without trade record to be acknowledged for manual review, got %+v", result)
+	}
+}
+
+func TestBuiltinAlipayNotifyVerifiesSignature(t *testing.T) {
+	db := openPaymentCryptoTestDB(t)
+	svc := NewJSRuntimeService(db, &config.Config{})
+	alipayKey, appPrivatePEM, alipayPublicPEM := generateTestRSAKeyPEM(t)
+
+	pm := builtinPaymentMethodByName(t, "Alipay")
+	pm.ID = 12
+	cfg, _ := json.Marshal(map[string]string{
+		"app_id":            "2021000000000001",
+		"app_private_key":   appPrivatePEM,
+		"alipay_public_key": alipayPublicPEM,
+	})
+	pm.Config = string(cfg)
+	if err := svc.storageSetValue(pm.ID, "trade_ORD2", `{"seq":0,"amount":5000,"out_trade_no":"ORD2","wap_out_trade_no":"ORD2W"}`); err != nil {
+		t.Fatalf("seed trade: %v", err)
+	}
+
+	params := map[string]string{
+		"app_id":       "2021000000000001",
+		"out_trade_no": "ORD2W",
+		"trade_no":     "2026101722001",
+		"trade_status": "TRADE_SUCCESS",
+		"total_amount": "50.00",
+		"gmt_payment":  "2026-10-17 12:00:00",
+		"charset":      "utf-8",
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	content := make([]string, 0, len(keys))
+	form := url.Values{}
+	for _, key := range keys {
+		content = append(content, key+"="+params[key])
+		form.Set(key, params[key])
+	}
+	form.Set("sign_type", "RSA2")
+	form.Set("sign", signTestSHA256(t, alipayKey, strings.Join(content, "&")))
+
+	result, err := svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{Key: "alipay.notify", Method: "POST", BodyText: form.Encode()})
+	if err != nil {
+		t.Fatalf("execute webhook: %v", err)
+	}
+	if !result.Paid || result.OrderNo != "ORD2" || result.TransactionID != "2026101722001" || result.AckBody != "success" {
+		t.Fatalf("expected confirmed payment, got %+v", result)
+	}
+
+	form.Set("total_amount", "0.01")
+	result, err = svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{Key: "alipay.notify", Method: "POST", BodyText: form.Encode()})
+	if err != nil {
+		t.Fatalf("execute webhook: %v", err)
+	}
+	if result.Paid || result.AckBody != "failure" {
+		t.Fatalf("expected tampered notification to be rejected, got %+v", result)
+	}
+
+	// 没有下单记录时无法核对金额，应答 success 但不确认付款
+	if err := svc.storageDeleteKey(pm.ID, "trade_ORD2"); err != nil {
+		t.Fatalf("delete trade: %v", err)
+	}
+	form.Set("total_amount", "50.00")
+	result, err = svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{Key: "alipay.notify", Method: "POST", BodyText: form.Encode()})
+	if err != nil {
+		t.Fatalf("execute webhook: %v", err)
+	}
+	if result.Paid || result.AckBody != "success" || result.Message == "" {
+		t.Fatalf("expected notification without trade record to be acknowledged for manual review, got %+v", result)
+	}
+}
+
+func hmacSHA256Hex(data, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestBuiltinPaddleNotifyVerifiesSignatureAndTaxMode(t *testing.T) {
+	db := openPaymentCryptoTestDB(t)
+	svc := NewJSRuntimeService(db, &config.Config{})
+	secret := "pdl_ntfset_secret"
+
+	pm := builtinPaymentMethodByName(t, "Paddle")
+	pm.ID = 13
+	pm.Config = fmt.Sprintf(`{"api_key":"key","client_token":"test_token","webhook_secret":%q,"tax_inclusive":true}`, secret)
+	if err := svc.storageSetValue(pm.ID, "trade_ORD3", `{"transaction_id":"txn_01","amount":1200,"currency":"EUR","tax_inclusive":true,"checkout_url":"https://shop.example/pay?_ptxn=txn_01"}`); err != nil {
+		t.Fatalf("seed trade: %v", err)
+	}
+	// 不含税模式：订单金额对应税前小计
+	if err := svc.storageSetValue(pm.ID, "trade_ORD4", `{"transaction_id":"txn_02","amount":1000,"currency":"EUR","tax_inclusive":false,"checkout_url":"https://shop.example/pay?_ptxn=txn_02"}`); err != nil {
+		t.Fatalf("seed trade: %v", err)
+	}
+
+	notify := func(orderNo, txnID, subtotal, tax, total string, timestamp int64, tamper bool) *PaymentWebhookResult {
+		body, _ := json.Marshal(map[string]interface{}{
+			"event_type": "transaction.completed",
+			"data": map[string]interface{}{
+				"id":            txnID,
+				"status":        "completed",
+				"currency_code": "EUR",
+				"custom_data":   map[string]string{"order_no": orderNo},
+				"details": map[string]interface{}{
+					"totals":     map[string]string{"subtotal": subtotal, "tax": tax, "total": total},
+					"line_items": []map[string]string{{"id": "txnitm_01"}},
+				},
+			},
+		})
+		ts := fmt.Sprint(timestamp)
+		signature := "ts=" + ts + ";h1=" + hmacSHA256Hex(ts+":"+string(body), secret)
+		if tamper {
+			body = append(body, ' ')
+		}
+		result, err := svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{
+			Key:      "paddle.notify",
+			Method:   "POST",
+			BodyText: string(body),
+			Headers:  map[string]string{"paddle-signature": signature},
+		})
+		if err != nil {
+			t.Fatalf("execute webhook: %v", err)
+		}
+		return result
+	}
+
+	now := time.Now().Unix()
+	result := notify("ORD3", "txn_01", "1000", "200", "1200", now, false)
+	if !result.Paid || result.OrderNo != "ORD3" || result.TransactionID != "txn_01" || result.AckStatus != 200 {
+		t.Fatalf("expected confirmed payment, got %+v", result)
+	}
+	if tax, _ := result.Data["tax_minor"].(int64); tax != 200 {
+		t.Fatalf("expected tax_minor=200 in result data, got %+v", result.Data)
+	}
+	if result := notify("ORD4", "txn_02", "1000", "190", "1190", now, false); !result.Paid {
+		t.Fatalf("expected tax-exclusive payment to match subtotal, got %+v", result)
+	}
+	if result := notify("ORD3", "txn_01", "1000", "200", "1200", now, true); result.Paid || result.AckStatus != 401 {
+		t.Fatalf("expected rejected signature, got %+v", result)
+	}
+	if result := notify("ORD3", "txn_01", "1000", "200", "1200", now-3600, false); result.Paid || result.AckStatus != 401 {
+		t.Fatalf("expected stale signature to be rejected, got %+v", result)
+	}
+	if result := notify("ORD3", "txn_01", "1", "0", "1", now, false); result.Paid || result.AckStatus != 200 {
+		t.Fatalf("expected amount mismatch to be acknowledged without confirming, got %+v", result)
+	}
+}
+
+func TestBuiltinLemonSqueezyNotifyVerifiesSignature(t *testing.T) {
+	db := openPaymentCryptoTestDB(t)
+	svc := NewJSRuntimeService(db, &config.Config{})
+	secret := "ls_signing_secret"
+
+	pm := builtinPaymentMethodByName(t, "Lemon Squeezy")
+	pm.ID = 14
+	pm.Config = fmt.Sprintf(`{"api_key":"key","store_id":"1","variant_id":"2","signing_secret":%q,"currency":"USD","tax_inclusive":true}`, secret)
+	if err := svc.storageSetValue(pm.ID, "trade_ORD5", `{"checkout_id":"chk_1","amount":2500,"currency":"USD","tax_inclusive":true,"checkout_url":"https://store.lemonsqueezy.com/checkout/custom/chk_1"}`); err != nil {
+		t.Fatalf("seed trade: %v", err)
+	}
+
+	notify := func(total int64, tamper bool) *PaymentWebhookResult {
+		body, _ := json.Marshal(map[string]interface{}{
+			"meta": map[string]interface{}{
+				"event_name":  "order_created",
+				"custom_data": map[string]string{"order_no": "ORD5"},
+			},
+			"data": map[string]interface{}{
+				"type": "orders",
+				"id":   "98765",
+				"attributes": map[string]interface{}{
+					"identifier": "104e18a2-d755-4d4b-80c4-a6c1dcbe1c10",
+					"status":     "paid",
+					"currency":   "USD",
+					"subtotal":   total - 400,
+					"tax":        400,
+					"total":      total,
+				},
+			},
+		})
+		signature := hmacSHA256Hex(string(body), secret)
+		if tamper {
+			body = append(body, ' ')
+		}
+		result, err := svc.ExecuteWebhook(&pm, &PaymentWebhookRequest{
+			Key:      "lemonsqueezy.notify",
+			Method:   "POST",
+			BodyText: string(body),
+			Headers:  map[string]string{"x-signature": signature},
+		})
+		if err != nil {
+			t.Fatalf("execute webhook: %v", err)
+		}
+		return result
+	}
+
+	if result := notify(2500, false); !result.Paid || result.OrderNo != "ORD5" || result.TransactionID != "98765" || result.AckStatus != 200 {
+		t.Fatalf("expected confirmed payment, got %+v", result)
+	}
+	if result := notify(2500, true); result.Paid || result.AckStatus != 401 {
+		t.Fatalf("expected rejected signature, got %+v", result)
+	}
+	if result := notify(100, false); result.Paid || result.AckStatus != 200 {
+		t.Fatalf("expected amount mismatch to be acknowledged without confirming, got %+v", result)
+	}
+
+	raw, found, err := svc.storageGetValue(pm.ID, "trade_ORD5")
+	if err != nil || !found || !strings.Contains(raw, `"ls_order_id":"98765"`) {
+		t.Fatalf("expected lemon squeezy order id to be stored for refunds, got %q err=%v", raw, err)
+	}
+}
@@ -330,12 +330,18 @@ func (s *JSRuntimeService) registerAPIs(vm *goja.Runtime, ctx *JSContext, pm *mo
 	utils.Set("generateId", s.createGenerateId(vm))
 	utils.Set("md5", s.createMD5(vm))
 	utils.Set("hmacSHA256", s.createHMACSHA256(vm))
+	utils.Set("rsaSignSHA256", s.createRSASignSHA256(vm))
+	utils.Set("rsaVerifySHA256", s.createRSAVerifySHA256(vm))
+	utils.Set("aesGCMDecrypt", s.createAESGCMDecrypt(vm))
 	utils.Set("base64Encode", s.createBase64Encode(vm))
 	utils.Set("base64Decode", s.createBase64Decode(vm))
 	utils.Set("jsonEncode", s.createJSONEncode(vm))
 	utils.Set("jsonDecode", s.createJSONDecode(vm))
 	utils.Set("qrcode", s.createQRCode(vm))
 
+	// 摘要/HMAC/编码 API
+	registerScriptCryptoAPI(vm, auralogic)
+
 	// Webhook API
 	webhook := vm.NewObject()
 	auralogic.Set("webhook", webhook)
@@ -1031,9 +1037,11 @@ func (s *JSRuntimeService) doHTTPRequest(vm *goja.Runtime, method, urlStr string
 func (s *JSRuntimeService) orderToJS(order *models.Order) map[string]interface{} {
 	totalAmountMinor := order.TotalAmount
 	discountAmountMinor := order.DiscountAmount
+	paymentFeeMinor := order.PaymentFee
 	if !s.moneyMinorUnits {
 		totalAmountMinor = order.TotalAmount * money.CurrencyScale
 		discountAmountMinor = order.DiscountAmount * money.CurrencyScale
+		paymentFeeMinor = order.PaymentFee * money.CurrencyScale
 	}
 
 	return map[string]interface{}{
@@ -1042,6 +1050,7 @@ func (s *JSRuntimeService) orderToJS(order *models.Order) map[string]interface{}
 		"status":                order.Status,
 		"total_amount_minor":    totalAmountMinor,
 		"discount_amount_minor": discountAmountMinor,
+		"payment_fee_minor":     paymentFeeMinor,
 		// Legacy aliases for old built-in/custom scripts that still read major-unit fields.
 		"total_amount":    float64(totalAmountMinor) / float64(money.CurrencyScale),
 		"discount_amount": float64(discountAmountMinor) / float64(money.CurrencyScale),
@@ -1472,6 +1481,12 @@ type RefundResult struct {
 
 // ExecuteRefund 执行退款
 func (s *JSRuntimeService) ExecuteRefund(pm *models.PaymentMethod, order *models.Order) (*RefundResult, error) {
+	return s.ExecuteRefundAmount(pm, order, order.RefundableAmount(), 1)
+}
+
+// ExecuteRefundAmount 按指定金额（最小货币单位）执行退款，用于部分退款和已部分退款订单的整单退款
+// sequence 为该笔退款在订单内的序号，重试同一笔退款时保持不变，供脚本生成网关幂等的退款单号
+func (s *JSRuntimeService) ExecuteRefundAmount(pm *models.PaymentMethod, order *models.Order, amountMinor int64, sequence int) (*RefundResult, error) {
 	if pm.Script == "" {
 		return &RefundResult{Success: false, Message: "Payment method has no script configured"}, nil
 	}
@@ -1501,6 +1516,14 @@ func (s *JSRuntimeService) ExecuteRefund(pm *models.PaymentMethod, order *models
 	}
 
 	orderData := s.orderToJS(order)
+	// 应退金额：付款方式设置了不退还附加费时已扣除附加费，部分退款时小于订单金额
+	refundAmountMinor := amountMinor
+	if !s.moneyMinorUnits {
+		refundAmountMinor *= money.CurrencyScale
+	}
+	orderData["refund_amount_minor"] = refundAmountMinor
+	orderData["partial_refund"] = amountMinor < order.RefundableAmount()
+	orderData["refund_sequence"] = sequence
 	configData := s.parseConfig(pm.Config)
 
 	result, err := fn(goja.Undefined(), vm.ToValue(orderData), vm.ToValue(configData))
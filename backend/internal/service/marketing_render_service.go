@@ -236,7 +236,7 @@ func renderMarketingEmailTemplate(subject, contentHTML, contentText string, vars
 		return "", err
 	}
 
-	tmpl, err := htmltemplate.ParseFiles(templateFile)
+	tmpl, err := htmltemplate.New(filepath.Base(templateFile)).Funcs(TemplateAssetFuncs(false)).ParseFiles(templateFile)
 	if err != nil {
 		return "", err
 	}
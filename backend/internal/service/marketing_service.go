@@ -525,7 +525,7 @@ func (s *MarketingService) processEmailTask(batch *models.MarketingBatch, user *
 	}
 
 	batchID := batch.ID
-	if err := s.emailService.queueEmail(user.Email, emailSubject, emailHTML, "marketing.announcement", nil, &user.ID, &batchID); err != nil {
+	if err := s.emailService.queueEmail(user.Email, emailSubject, emailHTML, "marketing.announcement", nil, &user.ID, &batchID, ""); err != nil {
 		status := models.MarketingTaskStatusFailed
 		errMessage := err.Error()
 		if updateErr := s.updateTaskResult(taskID, status, errMessage); updateErr != nil {
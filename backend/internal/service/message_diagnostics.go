@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"gopkg.in/gomail.v2"
+)
+
+// 测试消息支持的渠道
+const (
+	MessageTestChannelEmail   = "email"
+	MessageTestChannelSMS     = "sms"
+	MessageTestChannelWebhook = "webhook"
+	MessageTestChannelPush    = "push"
+)
+
+const messageTestResponseLimit = 8 * 1024
+
+// MessageTestResult 测试消息发送结果，Response 为服务商原始响应（截断）
+type MessageTestResult struct {
+	Channel    string `json:"channel"`
+	Provider   string `json:"provider"`
+	Target     string `json:"target"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Response   string `json:"response,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// MessageTestRequest 测试消息参数
+type MessageTestRequest struct {
+	Channel   string
+	To        string
+	PhoneCode string
+	Secret    string
+}
+
+// MessageDiagnosticsService 使用当前线上配置向各渠道发送测试消息，便于排查配置问题
+type MessageDiagnosticsService struct {
+	emailService *EmailService
+	smsService   *SMSService
+	httpClient   *http.Client
+}
+
+func NewMessageDiagnosticsService(emailService *EmailService, smsService *SMSService) *MessageDiagnosticsService {
+	return &MessageDiagnosticsService{
+		emailService: emailService,
+		smsService:   smsService,
+		httpClient:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Send 按渠道发送测试消息，发送失败时通过结果中的 Error 返回而不是 error
+func (s *MessageDiagnosticsService) Send(req MessageTestRequest) (*MessageTestResult, error) {
+	channel := strings.ToLower(strings.TrimSpace(req.Channel))
+	target := strings.TrimSpace(req.To)
+	if target == "" {
+		return nil, fmt.Errorf("target is required")
+	}
+
+	result := &MessageTestResult{Channel: channel, Target: target}
+	start := time.Now()
+	var raw string
+	var sendErr error
+
+	switch channel {
+	case MessageTestChannelEmail:
+		result.Provider = "smtp"
+		raw, sendErr = s.emailService.SendTestEmail(target)
+	case MessageTestChannelSMS:
+		if s.smsService == nil {
+			sendErr = fmt.Errorf("SMS service is not initialized")
+			break
+		}
+		result.Provider = s.smsService.cfg.SMS.Provider
+		if resolveOTPPhoneChannel(s.smsService.cfg) == OTPChannelWhatsApp {
+			result.Provider += "_whatsapp"
+		}
+		raw, sendErr = s.smsService.TestSMS(target, strings.TrimSpace(req.PhoneCode))
+	case MessageTestChannelWebhook:
+		result.Provider = "http"
+		result.StatusCode, raw, sendErr = s.sendTestWebhook(target, req.Secret)
+	case MessageTestChannelPush:
+		// 当前版本尚未接入推送服务商，明确返回未配置，避免误以为发送成功
+		result.Provider = "none"
+		sendErr = fmt.Errorf("push notifications are not configured")
+	default:
+		return nil, fmt.Errorf("unsupported channel: %s", req.Channel)
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Response = truncateMessageTestResponse(raw)
+	result.Success = sendErr == nil
+	if sendErr != nil {
+		result.Error = sendErr.Error()
+	}
+	return result, nil
+}
+
+// sendTestWebhook 向指定地址 POST 测试事件，配置了密钥时附带 HMAC-SHA256 签名
+func (s *MessageDiagnosticsService) sendTestWebhook(target, secret string) (int, string, error) {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return 0, "", fmt.Errorf("invalid webhook URL")
+	}
+
+	appName := "AuraLogic"
+	if cfg := config.GetConfig(); cfg != nil && cfg.App.Name != "" {
+		appName = cfg.App.Name
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"event":     "diagnostics.test",
+		"source":    appName,
+		"message":   "This is a test webhook from " + appName,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+
+	req, err := http.NewRequest(http.MethodPost, parsed.String(), bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", appName+"-Diagnostics")
+	if secret = strings.TrimSpace(secret); secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, messageTestResponseLimit+1))
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, string(body), fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// SendTestEmail 使用当前 SMTP 配置直接发送测试邮件（不进入队列）
+func (s *EmailService) SendTestEmail(to string) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("email service is not initialized")
+	}
+
+	s.mu.RLock()
+	enabled := s.cfg != nil && s.cfg.Enabled && s.dialer != nil
+	dialer := s.dialer
+	fromEmail := ""
+	fromName := ""
+	if s.cfg != nil {
+		fromEmail = s.cfg.FromEmail
+		fromName = s.cfg.FromName
+	}
+	s.mu.RUnlock()
+
+	if !enabled {
+		return "", fmt.Errorf("SMTP is not enabled")
+	}
+
+	appName := "AuraLogic"
+	if cfg := config.GetConfig(); cfg != nil && cfg.App.Name != "" {
+		appName = cfg.App.Name
+	}
+
+	m := gomail.NewMessage()
+	if fromName != "" {
+		m.SetAddressHeader("From", fromEmail, fromName)
+	} else {
+		m.SetHeader("From", fromEmail)
+	}
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", appName+" Test Message")
+	m.SetBody("text/html", fmt.Sprintf(
+		`<p>This is a test message from <strong>%s</strong>.</p><p>If you received this email, your live SMTP configuration is working.</p>`,
+		appName,
+	))
+
+	if err := dialer.DialAndSend(m); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("message accepted by %s:%d", dialer.Host, dialer.Port), nil
+}
+
+func truncateMessageTestResponse(raw string) string {
+	if len(raw) <= messageTestResponseLimit {
+		return raw
+	}
+	return raw[:messageTestResponseLimit] + "...(truncated)"
+}
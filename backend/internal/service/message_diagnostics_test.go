@@ -0,0 +1,78 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessageDiagnosticsWebhookReturnsRawResponse(t *testing.T) {
+	var gotSignature string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Webhook-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"received":true}`))
+	}))
+	defer server.Close()
+
+	svc := NewMessageDiagnosticsService(nil, nil)
+	result, err := svc.Send(MessageTestRequest{Channel: "webhook", To: server.URL, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if !result.Success || result.StatusCode != http.StatusAccepted || result.Response != `{"received":true}` {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(gotBody)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); gotSignature != expected {
+		t.Fatalf("unexpected signature %q, want %q", gotSignature, expected)
+	}
+}
+
+func TestMessageDiagnosticsReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	svc := NewMessageDiagnosticsService(nil, nil)
+
+	result, err := svc.Send(MessageTestRequest{Channel: "webhook", To: server.URL})
+	if err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if result.Success || result.StatusCode != http.StatusUnauthorized || result.Response != "bad token\n" {
+		t.Fatalf("expected failed webhook with raw body, got %+v", result)
+	}
+
+	result, err = svc.Send(MessageTestRequest{Channel: "email", To: "admin@example.com"})
+	if err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if result.Success || result.Error == "" {
+		t.Fatalf("expected email failure without service, got %+v", result)
+	}
+
+	result, err = svc.Send(MessageTestRequest{Channel: "push", To: "device-token"})
+	if err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if result.Success || result.Provider != "none" {
+		t.Fatalf("expected push to report not configured, got %+v", result)
+	}
+
+	if _, err := svc.Send(MessageTestRequest{Channel: "webhook", To: "ftp://example.com"}); err != nil {
+		t.Fatalf("invalid url should be reported in result, got error %v", err)
+	}
+	if _, err := svc.Send(MessageTestRequest{Channel: "fax", To: "x"}); err == nil {
+		t.Fatalf("expected unsupported channel error")
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// 每轮最多处理的逾期发票数，避免积压时一次性发出大量邮件
+const netTermsDunningBatchSize = 200
+
+// NetTermsDunningService 账期发票催收服务
+// 按 order.net_terms.dunning_days 在发票逾期后逐次发送催款邮件，逾期超过 credit_hold_after_days 时冻结账户额度
+type NetTermsDunningService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	emailService  *EmailService
+	checkInterval time.Duration
+}
+
+// NewNetTermsDunningService 创建账期发票催收服务
+func NewNetTermsDunningService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *NetTermsDunningService {
+	return &NetTermsDunningService{
+		db:            db,
+		cfg:           cfg,
+		emailService:  emailService,
+		checkInterval: time.Hour,
+	}
+}
+
+// RegisterJobs 注册账期发票催收任务
+func (s *NetTermsDunningService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "net_terms_dunning",
+		Description: "Send dunning emails for overdue net-terms invoices and place credit holds",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			reminded, held, err := s.ProcessOverdue(time.Now().UTC())
+			if reminded > 0 || held > 0 {
+				logger.LogSystemOperation(s.db, "net_terms_dunning_processed", "system", nil, map[string]interface{}{
+					"reminded": reminded,
+					"held":     held,
+				})
+			}
+			return err
+		},
+	})
+}
+
+func (s *NetTermsDunningService) schedule() []int {
+	days := s.cfg.Order.NetTerms.DunningDays
+	if len(days) == 0 {
+		return DefaultNetTermsDunningDays
+	}
+	return days
+}
+
+// overdueDays 发票逾期的整天数，未到期返回 -1
+func overdueDays(invoice *models.NetTermsInvoice, now time.Time) int {
+	if !now.After(invoice.DueAt) {
+		return -1
+	}
+	return int(now.Sub(invoice.DueAt).Hours() / 24)
+}
+
+// ProcessOverdue 处理逾期发票：冻结超过阈值的账户，并发送到期的催款邮件，返回催款数与新冻结的账户数
+func (s *NetTermsDunningService) ProcessOverdue(now time.Time) (int, int, error) {
+	if s.cfg == nil || !s.cfg.Order.NetTerms.Enabled {
+		return 0, 0, nil
+	}
+
+	held, err := s.applyCreditHolds(now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var invoices []models.NetTermsInvoice
+	if err := s.db.Preload("BusinessAccount").Preload("BusinessAccount.User").
+		Where("status = ? AND due_at < ? AND dunning_count < ?", models.NetTermsInvoiceStatusOpen, now, len(s.schedule())).
+		Order("due_at ASC, id ASC").
+		Limit(netTermsDunningBatchSize).
+		Find(&invoices).Error; err != nil {
+		return 0, held, err
+	}
+
+	schedule := s.schedule()
+	reminded := 0
+	for i := range invoices {
+		invoice := &invoices[i]
+		days := overdueDays(invoice, now)
+		if days < schedule[invoice.DunningCount] {
+			continue
+		}
+		// 先推进进度再发送，避免邮件发送失败时重复催款
+		result := s.db.Model(&models.NetTermsInvoice{}).
+			Where("id = ? AND dunning_count = ?", invoice.ID, invoice.DunningCount).
+			Updates(map[string]interface{}{
+				"dunning_count":   gorm.Expr("dunning_count + 1"),
+				"last_dunning_at": now,
+			})
+		if result.Error != nil {
+			return reminded, held, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		reminded++
+		if s.emailService != nil && invoice.BusinessAccount != nil {
+			if err := s.emailService.SendNetTermsDunningEmail(invoice.BusinessAccount, invoice, days); err != nil {
+				logger.LogSystemOperation(s.db, "net_terms_dunning_failed", "net_terms_invoice", &invoice.ID, map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+	}
+	return reminded, held, nil
+}
+
+// applyCreditHolds 冻结存在逾期超过 credit_hold_after_days 天发票的账户
+func (s *NetTermsDunningService) applyCreditHolds(now time.Time) (int, error) {
+	holdAfter := s.cfg.Order.NetTerms.CreditHoldAfterDays
+	if holdAfter <= 0 {
+		return 0, nil
+	}
+	overdueAccounts := s.db.Model(&models.NetTermsInvoice{}).Select("business_account_id").
+		Where("status = ? AND due_at < ?", models.NetTermsInvoiceStatusOpen, now.AddDate(0, 0, -holdAfter))
+	result := s.db.Model(&models.BusinessAccount{}).
+		Where("credit_hold = ? AND id IN (?)", false, overdueAccounts).
+		Updates(map[string]interface{}{
+			"credit_hold":        true,
+			"credit_hold_reason": models.CreditHoldReasonOverdue,
+			"credit_hold_at":     now,
+		})
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,775 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultNetTermsDays            = 30
+	maxNetTermsDays                = 365
+	maxBusinessCompanyNameLength   = 200
+	maxBusinessReviewNoteLength    = 1000
+	maxNetTermsReferenceLength     = 255
+	maxNetTermsVoidReasonLength    = 500
+	netTermsInvoiceNoPrefix        = "INV-"
+	netTermsStatementLineInvoice   = "invoice"
+	netTermsStatementLinePayment   = "payment"
+	netTermsStatementLineVoid      = "void"
+	netTermsOrderAdminRemarkFormat = "[Net terms] Invoice %s due %s"
+)
+
+// DefaultNetTermsDunningDays 未配置时的催款节奏（逾期第 1、7、14 天）
+var DefaultNetTermsDunningDays = []int{1, 7, 14}
+
+var (
+	ErrBusinessAccountNotFound = errors.New("business account not found")
+	ErrNetTermsInvoiceNotFound = errors.New("net terms invoice not found")
+	ErrNetTermsOrderNotFound   = errors.New("order not found")
+)
+
+// BusinessAccountApplication 用户提交的企业账户申请
+type BusinessAccountApplication struct {
+	CompanyName    string
+	TaxID          string
+	BillingEmail   string
+	BillingAddress string
+}
+
+// BusinessAccountTerms 管理员设置的账期条款
+type BusinessAccountTerms struct {
+	Currency    string
+	CreditLimit int64
+	TermsDays   int
+}
+
+// BusinessAccountCredit 企业账户额度使用情况
+type BusinessAccountCredit struct {
+	Currency         string `json:"currency"`
+	CreditLimitMinor int64  `json:"credit_limit_minor"`
+	OutstandingMinor int64  `json:"outstanding_minor"` // 未付发票合计
+	OverdueMinor     int64  `json:"overdue_minor"`     // 其中已逾期的部分
+	AvailableMinor   int64  `json:"available_minor"`   // 可用额度，冻结或停用时为 0
+	OpenInvoiceCount int64  `json:"open_invoice_count"`
+	CanUseTerms      bool   `json:"can_use_terms"`
+}
+
+// BusinessAccountOverview 企业账户及额度
+type BusinessAccountOverview struct {
+	models.BusinessAccount
+	Credit BusinessAccountCredit `json:"credit"`
+}
+
+// MarshalJSON 内嵌的 BusinessAccount 自带 MarshalJSON，需手动合并 credit 字段
+func (o BusinessAccountOverview) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(o.BusinessAccount)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	credit, err := json.Marshal(o.Credit)
+	if err != nil {
+		return nil, err
+	}
+	fields["credit"] = credit
+	return json.Marshal(fields)
+}
+
+// NetTermsStatementLine 对账单明细：开票增加应收，收款与作废减少应收
+type NetTermsStatementLine struct {
+	Date         time.Time `json:"date"`
+	Type         string    `json:"type"` // invoice / payment / void
+	InvoiceNo    string    `json:"invoice_no"`
+	OrderNo      string    `json:"order_no"`
+	DueAt        time.Time `json:"due_at"`
+	Reference    string    `json:"reference,omitempty"`
+	AmountMinor  int64     `json:"amount_minor"`
+	BalanceMinor int64     `json:"balance_minor"`
+}
+
+// NetTermsStatement 企业账户对账单，period_end 当天不包含在内
+type NetTermsStatement struct {
+	Account             *models.BusinessAccount  `json:"account"`
+	Currency            string                   `json:"currency"`
+	PeriodStart         time.Time                `json:"period_start"`
+	PeriodEnd           time.Time                `json:"period_end"`
+	OpeningBalanceMinor int64                    `json:"opening_balance_minor"`
+	InvoicedMinor       int64                    `json:"invoiced_minor"`
+	PaidMinor           int64                    `json:"paid_minor"`
+	VoidedMinor         int64                    `json:"voided_minor"`
+	ClosingBalanceMinor int64                    `json:"closing_balance_minor"`
+	Lines               []NetTermsStatementLine  `json:"lines"`
+	OpenInvoices        []models.NetTermsInvoice `json:"open_invoices"` // 截至 period_end 仍未付的发票，用于账龄展示
+}
+
+// NetTermsService 企业账户与账期付款：审核通过的账户可在信用额度内先发货后付款，
+// 每笔账期订单开具一张发票，管理员登记收款，逾期发票由 NetTermsDunningService 催收
+type NetTermsService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	orderService *OrderService
+}
+
+// NewNetTermsService 创建账期付款服务
+func NewNetTermsService(db *gorm.DB, cfg *config.Config, orderService *OrderService) *NetTermsService {
+	return &NetTermsService{db: db, cfg: cfg, orderService: orderService}
+}
+
+func (s *NetTermsService) enabled() bool {
+	return s.cfg != nil && s.cfg.Order.NetTerms.Enabled
+}
+
+func (s *NetTermsService) defaultTermsDays() int {
+	if s.cfg != nil && s.cfg.Order.NetTerms.DefaultTermsDays > 0 {
+		return s.cfg.Order.NetTerms.DefaultTermsDays
+	}
+	return defaultNetTermsDays
+}
+
+func netTermsDisabledError() error {
+	return bizerr.New("netTerms.disabled", "Net terms billing is not enabled")
+}
+
+func normalizeBusinessAccountApplication(input *BusinessAccountApplication) error {
+	input.CompanyName = strings.TrimSpace(input.CompanyName)
+	input.TaxID = strings.TrimSpace(input.TaxID)
+	input.BillingEmail = strings.TrimSpace(input.BillingEmail)
+	input.BillingAddress = strings.TrimSpace(input.BillingAddress)
+	if input.CompanyName == "" || len([]rune(input.CompanyName)) > maxBusinessCompanyNameLength {
+		return bizerr.Newf("netTerms.companyNameInvalid", "Company name is required and cannot exceed %d characters", maxBusinessCompanyNameLength).
+			WithParams(map[string]interface{}{"max": maxBusinessCompanyNameLength})
+	}
+	return nil
+}
+
+func normalizeBusinessAccountTerms(terms *BusinessAccountTerms) error {
+	terms.Currency = strings.ToUpper(strings.TrimSpace(terms.Currency))
+	if terms.Currency == "" {
+		return bizerr.New("netTerms.currencyRequired", "Currency is required")
+	}
+	if terms.CreditLimit <= 0 {
+		return bizerr.New("netTerms.creditLimitInvalid", "Credit limit must be greater than zero")
+	}
+	if terms.TermsDays <= 0 || terms.TermsDays > maxNetTermsDays {
+		return bizerr.Newf("netTerms.termsDaysInvalid", "Payment terms must be between 1 and %d days", maxNetTermsDays).
+			WithParams(map[string]interface{}{"max": maxNetTermsDays})
+	}
+	return nil
+}
+
+// GetAccountForUser 用户的企业账户，未申请时返回 nil
+func (s *NetTermsService) GetAccountForUser(userID uint) (*BusinessAccountOverview, error) {
+	var account models.BusinessAccount
+	if err := s.db.Where("user_id = ?", userID).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s.overview(s.db, &account, time.Now())
+}
+
+// Apply 提交或重新提交企业账户申请，已通过或停用的账户只能由管理员修改
+func (s *NetTermsService) Apply(userID uint, input BusinessAccountApplication) (*BusinessAccountOverview, error) {
+	if !s.enabled() {
+		return nil, netTermsDisabledError()
+	}
+	if err := normalizeBusinessAccountApplication(&input); err != nil {
+		return nil, err
+	}
+
+	var account models.BusinessAccount
+	err := s.db.Where("user_id = ?", userID).First(&account).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		account = models.BusinessAccount{
+			UserID:         userID,
+			CompanyName:    input.CompanyName,
+			TaxID:          input.TaxID,
+			BillingEmail:   input.BillingEmail,
+			BillingAddress: input.BillingAddress,
+			Status:         models.BusinessAccountStatusPending,
+		}
+		if err := s.db.Create(&account).Error; err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case account.Status == models.BusinessAccountStatusApproved || account.Status == models.BusinessAccountStatusSuspended:
+		return nil, bizerr.New("netTerms.accountAlreadyApproved", "Your business account has already been reviewed, please contact support to change it")
+	default:
+		if err := s.db.Model(&account).Updates(map[string]interface{}{
+			"company_name":    input.CompanyName,
+			"tax_id":          input.TaxID,
+			"billing_email":   input.BillingEmail,
+			"billing_address": input.BillingAddress,
+			"status":          models.BusinessAccountStatusPending,
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+	return s.GetAccountForUser(userID)
+}
+
+// ListAccounts 企业账户列表（管理端）
+func (s *NetTermsService) ListAccounts(status, search string, page, limit int) ([]BusinessAccountOverview, int64, error) {
+	query := s.db.Model(&models.BusinessAccount{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search = strings.TrimSpace(search); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("company_name LIKE ? OR tax_id LIKE ? OR billing_email LIKE ?", like, like, like)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var accounts []models.BusinessAccount
+	if err := query.Preload("User").Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&accounts).Error; err != nil {
+		return nil, 0, err
+	}
+	now := time.Now()
+	result := make([]BusinessAccountOverview, 0, len(accounts))
+	for i := range accounts {
+		overview, err := s.overview(s.db, &accounts[i], now)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, *overview)
+	}
+	return result, total, nil
+}
+
+// GetAccount 企业账户详情（管理端）
+func (s *NetTermsService) GetAccount(id uint) (*BusinessAccountOverview, error) {
+	account, err := s.findAccount(s.db.Preload("User"), id)
+	if err != nil {
+		return nil, err
+	}
+	return s.overview(s.db, account, time.Now())
+}
+
+func (s *NetTermsService) findAccount(db *gorm.DB, id uint) (*models.BusinessAccount, error) {
+	var account models.BusinessAccount
+	if err := db.First(&account, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBusinessAccountNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Approve 审核通过企业账户并设置账期条款，termsDays 为 0 时使用默认账期
+func (s *NetTermsService) Approve(id, adminID uint, terms BusinessAccountTerms, note string) (*BusinessAccountOverview, error) {
+	if terms.TermsDays == 0 {
+		terms.TermsDays = s.defaultTermsDays()
+	}
+	if err := normalizeBusinessAccountTerms(&terms); err != nil {
+		return nil, err
+	}
+	note, err := normalizeBusinessReviewNote(note)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.findAccount(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != models.BusinessAccountStatusPending {
+		return nil, bizerr.Newf("netTerms.accountStatusInvalid", "This business account cannot be reviewed (current status: %s)", account.Status).
+			WithParams(map[string]interface{}{"status": account.Status})
+	}
+	now := models.NowFunc()
+	if err := s.db.Model(account).Updates(map[string]interface{}{
+		"status":       models.BusinessAccountStatusApproved,
+		"currency":     terms.Currency,
+		"credit_limit": terms.CreditLimit,
+		"terms_days":   terms.TermsDays,
+		"reviewed_by":  adminID,
+		"reviewed_at":  now,
+		"review_note":  note,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return s.GetAccount(id)
+}
+
+// Reject 拒绝企业账户申请，用户可修改资料后重新申请
+func (s *NetTermsService) Reject(id, adminID uint, note string) (*BusinessAccountOverview, error) {
+	note, err := normalizeBusinessReviewNote(note)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.findAccount(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != models.BusinessAccountStatusPending {
+		return nil, bizerr.Newf("netTerms.accountStatusInvalid", "This business account cannot be reviewed (current status: %s)", account.Status).
+			WithParams(map[string]interface{}{"status": account.Status})
+	}
+	if err := s.db.Model(account).Updates(map[string]interface{}{
+		"status":      models.BusinessAccountStatusRejected,
+		"reviewed_by": adminID,
+		"reviewed_at": models.NowFunc(),
+		"review_note": note,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return s.GetAccount(id)
+}
+
+func normalizeBusinessReviewNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxBusinessReviewNoteLength {
+		return "", bizerr.Newf("netTerms.reviewNoteTooLong", "Review note cannot exceed %d characters", maxBusinessReviewNoteLength).
+			WithParams(map[string]interface{}{"max": maxBusinessReviewNoteLength})
+	}
+	return note, nil
+}
+
+// UpdateTerms 调整已审核账户的条款；suspended 为 true 时停用，creditHold 为 true 时手动冻结额度。
+// 更换币种需先结清该账户的全部发票
+func (s *NetTermsService) UpdateTerms(id uint, terms BusinessAccountTerms, suspended, creditHold bool) (*BusinessAccountOverview, error) {
+	if err := normalizeBusinessAccountTerms(&terms); err != nil {
+		return nil, err
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.BusinessAccount{}, "id = ?", id); err != nil {
+			return err
+		}
+		account, err := s.findAccount(tx, id)
+		if err != nil {
+			return err
+		}
+		if account.Status != models.BusinessAccountStatusApproved && account.Status != models.BusinessAccountStatusSuspended {
+			return bizerr.Newf("netTerms.accountStatusInvalid", "This business account cannot be reviewed (current status: %s)", account.Status).
+				WithParams(map[string]interface{}{"status": account.Status})
+		}
+		if terms.Currency != account.Currency {
+			var open int64
+			if err := tx.Model(&models.NetTermsInvoice{}).
+				Where("business_account_id = ? AND status = ?", id, models.NetTermsInvoiceStatusOpen).
+				Count(&open).Error; err != nil {
+				return err
+			}
+			if open > 0 {
+				return bizerr.New("netTerms.currencyChangeBlocked", "Settle all open invoices before changing the account currency")
+			}
+		}
+
+		status := models.BusinessAccountStatusApproved
+		if suspended {
+			status = models.BusinessAccountStatusSuspended
+		}
+		updates := map[string]interface{}{
+			"status":       status,
+			"currency":     terms.Currency,
+			"credit_limit": terms.CreditLimit,
+			"terms_days":   terms.TermsDays,
+		}
+		switch {
+		case creditHold && !account.CreditHold:
+			updates["credit_hold"] = true
+			updates["credit_hold_reason"] = models.CreditHoldReasonManual
+			updates["credit_hold_at"] = models.NowFunc()
+		case !creditHold && account.CreditHold:
+			updates["credit_hold"] = false
+			updates["credit_hold_reason"] = ""
+			updates["credit_hold_at"] = nil
+		}
+		return tx.Model(account).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetAccount(id)
+}
+
+// credit 计算额度使用情况，db 可为事务
+func (s *NetTermsService) credit(db *gorm.DB, account *models.BusinessAccount, now time.Time) (BusinessAccountCredit, error) {
+	credit := BusinessAccountCredit{
+		Currency:         account.Currency,
+		CreditLimitMinor: account.CreditLimit,
+	}
+	var row struct {
+		Outstanding int64
+		Overdue     int64
+		Count       int64
+	}
+	if err := db.Model(&models.NetTermsInvoice{}).
+		Select("COALESCE(SUM(amount), 0) AS outstanding, COALESCE(SUM(CASE WHEN due_at < ? THEN amount ELSE 0 END), 0) AS overdue, COUNT(*) AS count", now).
+		Where("business_account_id = ? AND status = ?", account.ID, models.NetTermsInvoiceStatusOpen).
+		Scan(&row).Error; err != nil {
+		return credit, err
+	}
+	credit.OutstandingMinor = row.Outstanding
+	credit.OverdueMinor = row.Overdue
+	credit.OpenInvoiceCount = row.Count
+	credit.CanUseTerms = s.enabled() && account.Status == models.BusinessAccountStatusApproved && !account.CreditHold
+	if credit.CanUseTerms && account.CreditLimit > row.Outstanding {
+		credit.AvailableMinor = account.CreditLimit - row.Outstanding
+	}
+	return credit, nil
+}
+
+func (s *NetTermsService) overview(db *gorm.DB, account *models.BusinessAccount, now time.Time) (*BusinessAccountOverview, error) {
+	credit, err := s.credit(db, account, now)
+	if err != nil {
+		return nil, err
+	}
+	return &BusinessAccountOverview{BusinessAccount: *account, Credit: credit}, nil
+}
+
+// PlaceOrderOnTerms 用账期付款支付待付款订单：校验额度后按已付款流程放行发货，并开具到期发票
+func (s *NetTermsService) PlaceOrderOnTerms(userID uint, orderNo string) (*models.NetTermsInvoice, error) {
+	if !s.enabled() {
+		return nil, netTermsDisabledError()
+	}
+
+	var (
+		order          *models.Order
+		invoice        *models.NetTermsInvoice
+		finalizeResult *paidOrderFinalizeResult
+	)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var ref models.Order
+		if err := tx.Select("id").Where("order_no = ? AND user_id = ?", orderNo, userID).First(&ref).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNetTermsOrderNotFound
+			}
+			return err
+		}
+		lockedOrder, err := repository.NewOrderRepository(tx).FindByIDForUpdate(tx, ref.ID)
+		if err != nil {
+			return err
+		}
+		order = lockedOrder
+		if order.Status != models.OrderStatusPendingPayment {
+			return bizerr.Newf("netTerms.orderStatusInvalid", "Only orders awaiting payment can use net terms (current status: %s)", order.Status).
+				WithParams(map[string]interface{}{"status": order.Status})
+		}
+
+		// 锁定企业账户，避免并发下单超出额度
+		var accountRef models.BusinessAccount
+		if err := tx.Select("id").Where("user_id = ?", userID).First(&accountRef).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return bizerr.New("netTerms.accountNotApproved", "Your business account has not been approved for net terms")
+			}
+			return err
+		}
+		if err := dbutil.LockForUpdate(tx, &models.BusinessAccount{}, "id = ?", accountRef.ID); err != nil {
+			return err
+		}
+		account, err := s.findAccount(tx, accountRef.ID)
+		if err != nil {
+			return err
+		}
+		if account.Status != models.BusinessAccountStatusApproved {
+			return bizerr.New("netTerms.accountNotApproved", "Your business account has not been approved for net terms")
+		}
+		if account.CreditHold {
+			return bizerr.New("netTerms.creditHold", "Net terms are on hold for your account, please settle overdue invoices first")
+		}
+		if !strings.EqualFold(order.Currency, account.Currency) {
+			return bizerr.Newf("netTerms.currencyMismatch", "Net terms are only available for orders in %s", account.Currency).
+				WithParams(map[string]interface{}{"currency": account.Currency})
+		}
+		now := models.NowFunc()
+		credit, err := s.credit(tx, account, now)
+		if err != nil {
+			return err
+		}
+		if order.TotalAmount > credit.AvailableMinor {
+			return bizerr.New("netTerms.creditLimitExceeded", "This order exceeds your available credit").
+				WithParams(map[string]interface{}{"available": credit.AvailableMinor, "currency": account.Currency})
+		}
+
+		dueAt := now.AddDate(0, 0, account.TermsDays)
+		invoice = &models.NetTermsInvoice{
+			InvoiceNo:         netTermsInvoiceNoPrefix + order.OrderNo,
+			BusinessAccountID: account.ID,
+			UserID:            userID,
+			OrderID:           order.ID,
+			OrderNo:           order.OrderNo,
+			Currency:          account.Currency,
+			Amount:            order.TotalAmount,
+			IssuedAt:          now,
+			DueAt:             dueAt,
+			Status:            models.NetTermsInvoiceStatusOpen,
+		}
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+
+		finalizeResult, err = finalizePendingPaymentOrderTx(tx, order, s.orderService.virtualProductSvc, paidOrderFinalizeOptions{
+			AdminRemark:             fmt.Sprintf(netTermsOrderAdminRemarkFormat, invoice.InvoiceNo, dueAt.Format("2006-01-02")),
+			StrictAutoDeliveryCheck: true,
+			Config:                  s.cfg,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if finalizeResult.VirtualDeliveryErr != nil {
+		fmt.Printf("Warning: Failed to deliver virtual products for net terms order %s: %v\n", order.OrderNo, finalizeResult.VirtualDeliveryErr)
+	}
+	s.orderService.syncUserConsumptionStatusTransitionBestEffort(
+		order.UserID,
+		models.OrderStatusPendingPayment,
+		finalizeResult.FinalStatus,
+		order.TotalAmount,
+		"net_terms",
+	)
+	PublishOrderStatusChanged(s.orderService.OrderRepo, s.orderService.pluginManager, nil, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, map[string]interface{}{
+		"source":         "net_terms",
+		"trigger_action": "order.net_terms",
+		"invoice_no":     invoice.InvoiceNo,
+	})
+	if s.orderService.emailService != nil {
+		go s.orderService.emailService.SendOrderPaidEmail(order, finalizeResult.IsVirtualOnly)
+	}
+	return invoice, nil
+}
+
+// ListInvoices 账期发票列表；accountID 为 0 表示全部账户，overdue 为 true 时只返回已逾期未付的发票
+func (s *NetTermsService) ListInvoices(accountID uint, status string, overdue bool, page, limit int) ([]models.NetTermsInvoice, int64, error) {
+	query := s.db.Model(&models.NetTermsInvoice{})
+	if accountID > 0 {
+		query = query.Where("business_account_id = ?", accountID)
+	}
+	if overdue {
+		query = query.Where("status = ? AND due_at < ?", models.NetTermsInvoiceStatusOpen, models.NowFunc())
+	} else if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var invoices []models.NetTermsInvoice
+	if err := query.Preload("BusinessAccount").Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&invoices).Error; err != nil {
+		return nil, 0, err
+	}
+	return invoices, total, nil
+}
+
+// ListInvoicesForUser 用户的账期发票
+func (s *NetTermsService) ListInvoicesForUser(userID uint, status string, page, limit int) ([]models.NetTermsInvoice, int64, error) {
+	query := s.db.Model(&models.NetTermsInvoice{}).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var invoices []models.NetTermsInvoice
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&invoices).Error; err != nil {
+		return nil, 0, err
+	}
+	return invoices, total, nil
+}
+
+// MarkInvoicePaid 登记发票收款；账户因逾期被冻结且已无超期发票时自动解除冻结
+func (s *NetTermsService) MarkInvoicePaid(id, adminID uint, reference string) (*models.NetTermsInvoice, error) {
+	reference = strings.TrimSpace(reference)
+	if len([]rune(reference)) > maxNetTermsReferenceLength {
+		return nil, bizerr.Newf("netTerms.paymentReferenceTooLong", "Payment reference cannot exceed %d characters", maxNetTermsReferenceLength).
+			WithParams(map[string]interface{}{"max": maxNetTermsReferenceLength})
+	}
+	return s.closeInvoice(id, map[string]interface{}{
+		"status":            models.NetTermsInvoiceStatusPaid,
+		"paid_at":           models.NowFunc(),
+		"paid_by":           adminID,
+		"payment_reference": reference,
+	})
+}
+
+// VoidInvoice 作废发票（订单已取消或线下退款），释放占用的额度
+func (s *NetTermsService) VoidInvoice(id uint, reason string) (*models.NetTermsInvoice, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxNetTermsVoidReasonLength {
+		return nil, bizerr.Newf("netTerms.voidReasonInvalid", "A void reason is required and cannot exceed %d characters", maxNetTermsVoidReasonLength).
+			WithParams(map[string]interface{}{"max": maxNetTermsVoidReasonLength})
+	}
+	return s.closeInvoice(id, map[string]interface{}{
+		"status":      models.NetTermsInvoiceStatusVoid,
+		"voided_at":   models.NowFunc(),
+		"void_reason": reason,
+	})
+}
+
+func (s *NetTermsService) closeInvoice(id uint, updates map[string]interface{}) (*models.NetTermsInvoice, error) {
+	var invoice models.NetTermsInvoice
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.NetTermsInvoice{}, "id = ?", id); err != nil {
+			return err
+		}
+		if err := tx.First(&invoice, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNetTermsInvoiceNotFound
+			}
+			return err
+		}
+		if invoice.Status != models.NetTermsInvoiceStatusOpen {
+			return bizerr.Newf("netTerms.invoiceNotOpen", "This invoice is already %s", invoice.Status).
+				WithParams(map[string]interface{}{"status": invoice.Status})
+		}
+		if err := tx.Model(&invoice).Updates(updates).Error; err != nil {
+			return err
+		}
+		return s.releaseOverdueHoldTx(tx, invoice.BusinessAccountID, models.NowFunc())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Preload("BusinessAccount").First(&invoice, id).Error; err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// releaseOverdueHoldTx 因逾期自动冻结的账户在没有超过冻结阈值的未付发票后解除冻结，手动冻结不受影响
+func (s *NetTermsService) releaseOverdueHoldTx(tx *gorm.DB, accountID uint, now time.Time) error {
+	var account models.BusinessAccount
+	if err := tx.First(&account, accountID).Error; err != nil {
+		return err
+	}
+	if !account.CreditHold || account.CreditHoldReason != models.CreditHoldReasonOverdue {
+		return nil
+	}
+	var stillOverdue int64
+	if err := tx.Model(&models.NetTermsInvoice{}).
+		Where("business_account_id = ? AND status = ? AND due_at < ?", accountID, models.NetTermsInvoiceStatusOpen, now.AddDate(0, 0, -s.creditHoldAfterDays())).
+		Count(&stillOverdue).Error; err != nil {
+		return err
+	}
+	if stillOverdue > 0 {
+		return nil
+	}
+	return tx.Model(&account).Updates(map[string]interface{}{
+		"credit_hold":        false,
+		"credit_hold_reason": "",
+		"credit_hold_at":     nil,
+	}).Error
+}
+
+func (s *NetTermsService) creditHoldAfterDays() int {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Order.NetTerms.CreditHoldAfterDays
+}
+
+// Statement 生成企业账户对账单：期初余额、期内开票/收款/作废明细与期末余额
+func (s *NetTermsService) Statement(accountID uint, periodStart, periodEnd time.Time) (*NetTermsStatement, error) {
+	if !periodEnd.After(periodStart) {
+		return nil, bizerr.New("netTerms.statementPeriodInvalid", "Statement end date must be after the start date")
+	}
+	account, err := s.findAccount(s.db.Preload("User"), accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var invoices []models.NetTermsInvoice
+	if err := s.db.Where("business_account_id = ? AND issued_at < ?", accountID, periodEnd).
+		Order("issued_at ASC, id ASC").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	statement := &NetTermsStatement{
+		Account:      account,
+		Currency:     account.Currency,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		Lines:        []NetTermsStatementLine{},
+		OpenInvoices: []models.NetTermsInvoice{},
+	}
+	for _, invoice := range invoices {
+		events := []NetTermsStatementLine{{
+			Date:        invoice.IssuedAt,
+			Type:        netTermsStatementLineInvoice,
+			AmountMinor: invoice.Amount,
+		}}
+		if invoice.PaidAt != nil && invoice.PaidAt.Before(periodEnd) {
+			events = append(events, NetTermsStatementLine{
+				Date:        *invoice.PaidAt,
+				Type:        netTermsStatementLinePayment,
+				Reference:   invoice.PaymentReference,
+				AmountMinor: -invoice.Amount,
+			})
+		}
+		if invoice.VoidedAt != nil && invoice.VoidedAt.Before(periodEnd) {
+			events = append(events, NetTermsStatementLine{
+				Date:        *invoice.VoidedAt,
+				Type:        netTermsStatementLineVoid,
+				Reference:   invoice.VoidReason,
+				AmountMinor: -invoice.Amount,
+			})
+		}
+		closedInPeriod := len(events) > 1
+		if !closedInPeriod {
+			statement.OpenInvoices = append(statement.OpenInvoices, invoice)
+		}
+		for _, event := range events {
+			if event.Date.Before(periodStart) {
+				statement.OpeningBalanceMinor += event.AmountMinor
+				continue
+			}
+			event.InvoiceNo = invoice.InvoiceNo
+			event.OrderNo = invoice.OrderNo
+			event.DueAt = invoice.DueAt
+			switch event.Type {
+			case netTermsStatementLineInvoice:
+				statement.InvoicedMinor += event.AmountMinor
+			case netTermsStatementLinePayment:
+				statement.PaidMinor -= event.AmountMinor
+			case netTermsStatementLineVoid:
+				statement.VoidedMinor -= event.AmountMinor
+			}
+			statement.Lines = append(statement.Lines, event)
+		}
+	}
+
+	sort.SliceStable(statement.Lines, func(i, j int) bool {
+		return statement.Lines[i].Date.Before(statement.Lines[j].Date)
+	})
+	balance := statement.OpeningBalanceMinor
+	for i := range statement.Lines {
+		balance += statement.Lines[i].AmountMinor
+		statement.Lines[i].BalanceMinor = balance
+	}
+	statement.ClosingBalanceMinor = balance
+	return statement, nil
+}
+
+// StatementForUser 用户查看自己企业账户的对账单
+func (s *NetTermsService) StatementForUser(userID uint, periodStart, periodEnd time.Time) (*NetTermsStatement, error) {
+	var account models.BusinessAccount
+	if err := s.db.Select("id").Where("user_id = ?", userID).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBusinessAccountNotFound
+		}
+		return nil, err
+	}
+	return s.Statement(account.ID, periodStart, periodEnd)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func newNetTermsTestService(t *testing.T) (*NetTermsService, *models.User) {
+	t.Helper()
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.BusinessAccount{}, &models.NetTermsInvoice{}, &models.VendorLedgerEntry{}); err != nil {
+		t.Fatalf("auto migrate net terms tables failed: %v", err)
+	}
+	orderSvc.cfg.Order.NetTerms.Enabled = true
+	orderSvc.cfg.Order.NetTerms.CreditHoldAfterDays = 30
+
+	user := &models.User{UUID: "net-terms-user", Email: "buyer@example.com", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	return NewNetTermsService(db, orderSvc.cfg, orderSvc), user
+}
+
+func createNetTermsTestOrder(t *testing.T, svc *NetTermsService, userID uint, orderNo, currency string, amount int64) *models.Order {
+	t.Helper()
+	order := &models.Order{
+		OrderNo:         orderNo,
+		UserID:          &userID,
+		Status:          models.OrderStatusPendingPayment,
+		Currency:        currency,
+		TotalAmount:     amount,
+		ReceiverName:    "Buyer",
+		ReceiverAddress: "1 Warehouse Rd",
+		Items:           []models.OrderItem{{SKU: "B2B-1", Name: "Pallet", Quantity: 1, ProductType: models.ProductTypePhysical}},
+	}
+	if err := svc.db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	return order
+}
+
+func TestNetTermsRequiresApprovalAndEnforcesCreditLimit(t *testing.T) {
+	svc, user := newNetTermsTestService(t)
+	createNetTermsTestOrder(t, svc, user.ID, "NT-1", "USD", 60000)
+	createNetTermsTestOrder(t, svc, user.ID, "NT-2", "USD", 50000)
+	createNetTermsTestOrder(t, svc, user.ID, "NT-3", "CNY", 1000)
+
+	if _, err := svc.PlaceOrderOnTerms(user.ID, "NT-1"); err == nil {
+		t.Fatalf("expected net terms to be rejected without a business account")
+	} else {
+		requireOrderBizErr(t, err, "netTerms.accountNotApproved")
+	}
+
+	account, err := svc.Apply(user.ID, BusinessAccountApplication{CompanyName: "Acme Corp"})
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	_, err = svc.PlaceOrderOnTerms(user.ID, "NT-1")
+	requireOrderBizErr(t, err, "netTerms.accountNotApproved")
+
+	account, err = svc.Approve(account.ID, 1, BusinessAccountTerms{Currency: "usd", CreditLimit: 100000}, "")
+	if err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if account.TermsDays != defaultNetTermsDays || account.Currency != "USD" {
+		t.Fatalf("unexpected approved terms: %+v", account.BusinessAccount)
+	}
+	encoded, err := json.Marshal(account)
+	if err != nil {
+		t.Fatalf("marshal overview failed: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"available_minor":100000`) || !strings.Contains(string(encoded), `"credit_limit_minor":100000`) {
+		t.Fatalf("expected overview json to include credit and limit, got %s", encoded)
+	}
+
+	invoice, err := svc.PlaceOrderOnTerms(user.ID, "NT-1")
+	if err != nil {
+		t.Fatalf("place order on terms failed: %v", err)
+	}
+	if invoice.Amount != 60000 || invoice.Status != models.NetTermsInvoiceStatusOpen {
+		t.Fatalf("unexpected invoice: %+v", invoice)
+	}
+	if days := int(invoice.DueAt.Sub(invoice.IssuedAt).Hours() / 24); days != defaultNetTermsDays {
+		t.Fatalf("expected due in %d days, got %d", defaultNetTermsDays, days)
+	}
+	var shipped models.Order
+	if err := svc.db.Where("order_no = ?", "NT-1").First(&shipped).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if shipped.Status != models.OrderStatusPending || shipped.PaidAt == nil {
+		t.Fatalf("expected order released for shipping, got status=%s paid_at=%v", shipped.Status, shipped.PaidAt)
+	}
+
+	// 剩余额度 40000，不足以支付 50000 的订单
+	_, err = svc.PlaceOrderOnTerms(user.ID, "NT-2")
+	requireOrderBizErr(t, err, "netTerms.creditLimitExceeded")
+	_, err = svc.PlaceOrderOnTerms(user.ID, "NT-3")
+	requireOrderBizErr(t, err, "netTerms.currencyMismatch")
+
+	// 收款后释放额度
+	if _, err := svc.MarkInvoicePaid(invoice.ID, 1, "WIRE-001"); err != nil {
+		t.Fatalf("mark invoice paid failed: %v", err)
+	}
+	if _, err := svc.PlaceOrderOnTerms(user.ID, "NT-2"); err != nil {
+		t.Fatalf("expected order within released credit to succeed: %v", err)
+	}
+}
+
+func TestNetTermsDunningHoldsAndReleasesCredit(t *testing.T) {
+	svc, user := newNetTermsTestService(t)
+	svc.cfg.Order.NetTerms.DunningDays = []int{1, 7}
+	createNetTermsTestOrder(t, svc, user.ID, "NT-10", "USD", 30000)
+
+	account, err := svc.Apply(user.ID, BusinessAccountApplication{CompanyName: "Acme Corp"})
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if _, err := svc.Approve(account.ID, 1, BusinessAccountTerms{Currency: "USD", CreditLimit: 100000, TermsDays: 15}, ""); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	invoice, err := svc.PlaceOrderOnTerms(user.ID, "NT-10")
+	if err != nil {
+		t.Fatalf("place order on terms failed: %v", err)
+	}
+
+	dunning := NewNetTermsDunningService(svc.db, svc.cfg, nil)
+	due := invoice.DueAt
+	steps := []struct {
+		at           time.Time
+		wantReminded int
+		wantHeld     int
+		wantCount    int
+	}{
+		{at: due.Add(-time.Hour), wantReminded: 0, wantHeld: 0, wantCount: 0},
+		{at: due.Add(36 * time.Hour), wantReminded: 1, wantHeld: 0, wantCount: 1},
+		{at: due.Add(48 * time.Hour), wantReminded: 0, wantHeld: 0, wantCount: 1},
+		{at: due.Add(8 * 24 * time.Hour), wantReminded: 1, wantHeld: 0, wantCount: 2},
+		{at: due.Add(31 * 24 * time.Hour), wantReminded: 0, wantHeld: 1, wantCount: 2},
+	}
+	for i, step := range steps {
+		reminded, held, err := dunning.ProcessOverdue(step.at)
+		if err != nil {
+			t.Fatalf("step %d: process overdue failed: %v", i, err)
+		}
+		var current models.NetTermsInvoice
+		if err := svc.db.First(&current, invoice.ID).Error; err != nil {
+			t.Fatalf("step %d: reload invoice failed: %v", i, err)
+		}
+		if reminded != step.wantReminded || held != step.wantHeld || current.DunningCount != step.wantCount {
+			t.Fatalf("step %d: reminded=%d held=%d count=%d, want %d/%d/%d",
+				i, reminded, held, current.DunningCount, step.wantReminded, step.wantHeld, step.wantCount)
+		}
+	}
+
+	overview, err := svc.GetAccount(account.ID)
+	if err != nil {
+		t.Fatalf("get account failed: %v", err)
+	}
+	if !overview.CreditHold || overview.CreditHoldReason != models.CreditHoldReasonOverdue || overview.Credit.CanUseTerms {
+		t.Fatalf("expected overdue credit hold, got %+v", overview)
+	}
+
+	if _, err := svc.MarkInvoicePaid(invoice.ID, 1, "WIRE-002"); err != nil {
+		t.Fatalf("mark invoice paid failed: %v", err)
+	}
+	overview, err = svc.GetAccount(account.ID)
+	if err != nil {
+		t.Fatalf("get account failed: %v", err)
+	}
+	if overview.CreditHold || overview.Credit.OutstandingMinor != 0 {
+		t.Fatalf("expected hold released after payment, got %+v", overview)
+	}
+}
+
+func TestNetTermsStatementBalances(t *testing.T) {
+	svc, user := newNetTermsTestService(t)
+	account := &models.BusinessAccount{UserID: user.ID, CompanyName: "Acme Corp", Status: models.BusinessAccountStatusApproved, Currency: "USD", CreditLimit: 100000, TermsDays: 30}
+	if err := svc.db.Create(account).Error; err != nil {
+		t.Fatalf("create account failed: %v", err)
+	}
+
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := march.AddDate(0, 1, 0)
+	paidInMarch := march.AddDate(0, 0, 10)
+	voidedInMarch := march.AddDate(0, 0, 12)
+	invoices := []models.NetTermsInvoice{
+		// 二月开票、三月收款：计入期初余额与期内收款
+		{InvoiceNo: "INV-A", OrderID: 1, OrderNo: "A", Amount: 10000, IssuedAt: march.AddDate(0, 0, -20), Status: models.NetTermsInvoiceStatusPaid, PaidAt: &paidInMarch},
+		// 三月开票未付
+		{InvoiceNo: "INV-B", OrderID: 2, OrderNo: "B", Amount: 25000, IssuedAt: march.AddDate(0, 0, 5), Status: models.NetTermsInvoiceStatusOpen},
+		// 三月开票并作废
+		{InvoiceNo: "INV-C", OrderID: 3, OrderNo: "C", Amount: 5000, IssuedAt: march.AddDate(0, 0, 6), Status: models.NetTermsInvoiceStatusVoid, VoidedAt: &voidedInMarch},
+		// 四月开票，不在周期内
+		{InvoiceNo: "INV-D", OrderID: 4, OrderNo: "D", Amount: 7000, IssuedAt: april.AddDate(0, 0, 2), Status: models.NetTermsInvoiceStatusOpen},
+	}
+	for i := range invoices {
+		invoices[i].BusinessAccountID = account.ID
+		invoices[i].UserID = user.ID
+		invoices[i].Currency = "USD"
+		invoices[i].DueAt = invoices[i].IssuedAt.AddDate(0, 0, 30)
+		if err := svc.db.Create(&invoices[i]).Error; err != nil {
+			t.Fatalf("create invoice failed: %v", err)
+		}
+	}
+
+	statement, err := svc.Statement(account.ID, march, april)
+	if err != nil {
+		t.Fatalf("statement failed: %v", err)
+	}
+	if statement.OpeningBalanceMinor != 10000 || statement.InvoicedMinor != 30000 ||
+		statement.PaidMinor != 10000 || statement.VoidedMinor != 5000 || statement.ClosingBalanceMinor != 25000 {
+		t.Fatalf("unexpected statement totals: %+v", statement)
+	}
+	if len(statement.Lines) != 4 || statement.Lines[len(statement.Lines)-1].BalanceMinor != 25000 {
+		t.Fatalf("unexpected statement lines: %+v", statement.Lines)
+	}
+	if len(statement.OpenInvoices) != 1 || statement.OpenInvoices[0].InvoiceNo != "INV-B" {
+		t.Fatalf("unexpected open invoices: %+v", statement.OpenInvoices)
+	}
+}
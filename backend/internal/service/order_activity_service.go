@@ -0,0 +1,101 @@
+package service
+
+import (
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+// orderActivityLimit 每类关联记录最多返回的条数（按时间倒序）
+const orderActivityLimit = 50
+
+// 订单详情聚合中按操作日志归类的动作
+var (
+	orderShipmentActions = []string{"assign_tracking"}
+	orderRefundActions   = []string{"refund", "confirm_refund"}
+	orderDeliveryActions = []string{"deliver_virtual_stock", "virtual_delivery_failed", "check_auto_delivery_failed"}
+)
+
+// OrderActivity 订单关联记录汇总，供客服一次性查看订单的完整处理过程
+type OrderActivity struct {
+	RefundRequests   []models.RefundRequest `json:"refund_requests"`
+	RefundEvents     []models.OperationLog  `json:"refund_events"`
+	Shipments        []models.OperationLog  `json:"shipments"`
+	DeliveryAttempts []models.OperationLog  `json:"delivery_attempts"`
+	Emails           []models.EmailLog      `json:"emails"`
+	SMS              []models.SmsLog        `json:"sms"`
+	Tickets          []models.Ticket        `json:"tickets"`
+}
+
+// OrderActivityService 订单关联记录查询服务
+type OrderActivityService struct {
+	db *gorm.DB
+}
+
+func NewOrderActivityService(db *gorm.DB) *OrderActivityService {
+	return &OrderActivityService{db: db}
+}
+
+// Load 加载订单的退款、发货、发货脚本执行、邮件/短信和关联工单记录
+func (s *OrderActivityService) Load(order *models.Order) (*OrderActivity, error) {
+	activity := &OrderActivity{
+		RefundRequests:   []models.RefundRequest{},
+		RefundEvents:     []models.OperationLog{},
+		Shipments:        []models.OperationLog{},
+		DeliveryAttempts: []models.OperationLog{},
+		Emails:           []models.EmailLog{},
+		SMS:              []models.SmsLog{},
+		Tickets:          []models.Ticket{},
+	}
+
+	if err := s.db.Where("order_id = ?", order.ID).
+		Order("created_at DESC").Limit(orderActivityLimit).
+		Find(&activity.RefundRequests).Error; err != nil {
+		return nil, err
+	}
+
+	var err error
+	if activity.RefundEvents, err = s.orderLogs(order.ID, []string{"order"}, orderRefundActions); err != nil {
+		return nil, err
+	}
+	if activity.Shipments, err = s.orderLogs(order.ID, []string{"order"}, orderShipmentActions); err != nil {
+		return nil, err
+	}
+	// 自动发货失败由付款轮询记录在 payment 资源下
+	if activity.DeliveryAttempts, err = s.orderLogs(order.ID, []string{"order", "payment"}, orderDeliveryActions); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Where("order_id = ?", order.ID).
+		Order("created_at DESC").Limit(orderActivityLimit).
+		Find(&activity.Emails).Error; err != nil {
+		return nil, err
+	}
+
+	// 短信日志不关联订单，返回下单以来发送给该用户的短信
+	if order.UserID != nil {
+		if err := s.db.Where("user_id = ? AND created_at >= ?", *order.UserID, order.CreatedAt).
+			Order("created_at DESC").Limit(orderActivityLimit).
+			Find(&activity.SMS).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// 用户授权查看该订单的工单，以及退款申请自动创建的工单
+	ticketIDs := s.db.Model(&models.TicketOrderAccess{}).Select("ticket_id").Where("order_id = ?", order.ID)
+	refundTicketIDs := s.db.Model(&models.RefundRequest{}).Select("ticket_id").Where("order_id = ? AND ticket_id IS NOT NULL", order.ID)
+	if err := s.db.Where("id IN (?) OR id IN (?)", ticketIDs, refundTicketIDs).
+		Order("created_at DESC").Limit(orderActivityLimit).
+		Find(&activity.Tickets).Error; err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+func (s *OrderActivityService) orderLogs(orderID uint, resourceTypes []string, actions []string) ([]models.OperationLog, error) {
+	logs := []models.OperationLog{}
+	err := s.db.Where("resource_type IN ? AND resource_id = ? AND action IN ?", resourceTypes, orderID, actions).
+		Order("created_at DESC").Limit(orderActivityLimit).
+		Find(&logs).Error
+	return logs, err
+}
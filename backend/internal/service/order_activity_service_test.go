@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestOrderActivityServiceLoadsRelatedRecords(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(
+		&models.Order{},
+		&models.RefundRequest{},
+		&models.OperationLog{},
+		&models.EmailLog{},
+		&models.SmsLog{},
+		&models.Ticket{},
+		&models.TicketOrderAccess{},
+	); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	userID := uint(7)
+	order := models.Order{OrderNo: "ORD-ACTIVITY", UserID: &userID, Status: models.OrderStatusShipped, Items: []models.OrderItem{}}
+	other := models.Order{OrderNo: "ORD-OTHER", UserID: &userID, Status: models.OrderStatusPending, Items: []models.OrderItem{}}
+	for _, o := range []*models.Order{&order, &other} {
+		if err := db.Create(o).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+	}
+
+	sharedTicket := models.Ticket{TicketNo: "T-SHARED", UserID: userID, Subject: "Where is my parcel", Content: "..."}
+	refundTicket := models.Ticket{TicketNo: "T-REFUND", UserID: userID, Subject: "Refund", Content: "..."}
+	unrelatedTicket := models.Ticket{TicketNo: "T-OTHER", UserID: userID, Subject: "Other", Content: "..."}
+	for _, ticket := range []*models.Ticket{&sharedTicket, &refundTicket, &unrelatedTicket} {
+		if err := db.Create(ticket).Error; err != nil {
+			t.Fatalf("create ticket failed: %v", err)
+		}
+	}
+
+	records := []interface{}{
+		&models.TicketOrderAccess{TicketID: sharedTicket.ID, OrderID: order.ID, GrantedBy: userID, CanView: true},
+		&models.TicketOrderAccess{TicketID: unrelatedTicket.ID, OrderID: other.ID, GrantedBy: userID, CanView: true},
+		&models.RefundRequest{OrderID: order.ID, OrderNo: order.OrderNo, UserID: userID, Reason: "broken", Resolution: models.RefundResolutionFullRefund, TicketID: &refundTicket.ID},
+		&models.RefundRequest{OrderID: other.ID, OrderNo: other.OrderNo, UserID: userID, Reason: "late", Resolution: models.RefundResolutionOther},
+		&models.OperationLog{Action: "assign_tracking", ResourceType: "order", ResourceID: &order.ID},
+		&models.OperationLog{Action: "deliver_virtual_stock", ResourceType: "order", ResourceID: &order.ID},
+		&models.OperationLog{Action: "virtual_delivery_failed", ResourceType: "payment", ResourceID: &order.ID},
+		&models.OperationLog{Action: "confirm_refund", ResourceType: "order", ResourceID: &order.ID},
+		&models.OperationLog{Action: "mark_paid", ResourceType: "order", ResourceID: &order.ID},
+		&models.OperationLog{Action: "assign_tracking", ResourceType: "order", ResourceID: &other.ID},
+		&models.EmailLog{ToEmail: "buyer@example.com", Subject: "Shipped", Content: "...", OrderID: &order.ID},
+		&models.EmailLog{ToEmail: "buyer@example.com", Subject: "Other", Content: "...", OrderID: &other.ID},
+		&models.SmsLog{Phone: "13800000000", Content: "...", UserID: &userID},
+		&models.SmsLog{Phone: "13800000000", Content: "...", UserID: &userID, CreatedAt: order.CreatedAt.Add(-time.Hour)},
+	}
+	for _, record := range records {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("create %T failed: %v", record, err)
+		}
+	}
+
+	activity, err := NewOrderActivityService(db).Load(&order)
+	if err != nil {
+		t.Fatalf("load order activity failed: %v", err)
+	}
+	if len(activity.RefundRequests) != 1 || len(activity.RefundEvents) != 1 || len(activity.Shipments) != 1 {
+		t.Fatalf("unexpected refund/shipment records: %+v", activity)
+	}
+	if len(activity.DeliveryAttempts) != 2 {
+		t.Fatalf("expected manual and failed automatic deliveries, got %+v", activity.DeliveryAttempts)
+	}
+	if len(activity.Emails) != 1 || activity.Emails[0].Subject != "Shipped" {
+		t.Fatalf("unexpected emails: %+v", activity.Emails)
+	}
+	if len(activity.SMS) != 1 {
+		t.Fatalf("expected only SMS sent after the order was created, got %d", len(activity.SMS))
+	}
+	ticketNos := map[string]bool{}
+	for _, ticket := range activity.Tickets {
+		ticketNos[ticket.TicketNo] = true
+	}
+	if len(activity.Tickets) != 2 || !ticketNos["T-SHARED"] || !ticketNos["T-REFUND"] {
+		t.Fatalf("unexpected tickets: %v", ticketNos)
+	}
+}
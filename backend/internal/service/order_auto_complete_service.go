@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+// OrderAutoCompleteService 已发货订单自动确认完成服务
+type OrderAutoCompleteService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	promoCodeRepo *repository.PromoCodeRepository
+	emailService  *EmailService
+	pluginManager *PluginManagerService
+	giftCards     *GiftCardService
+	checkInterval time.Duration // 检查间隔
+}
+
+// NewOrderAutoCompleteService 创建订单自动完成服务
+func NewOrderAutoCompleteService(
+	db *gorm.DB,
+	cfg *config.Config,
+	promoCodeRepo *repository.PromoCodeRepository,
+	emailService *EmailService,
+) *OrderAutoCompleteService {
+	return &OrderAutoCompleteService{
+		db:            db,
+		cfg:           cfg,
+		promoCodeRepo: promoCodeRepo,
+		emailService:  emailService,
+		checkInterval: 30 * time.Minute, // 每30分钟检查一次
+	}
+}
+
+func (s *OrderAutoCompleteService) SetPluginManager(pluginManager *PluginManagerService) {
+	s.pluginManager = pluginManager
+}
+
+// SetGiftCardService 注入礼品卡服务，自动完成订单时扣减礼品卡余额
+func (s *OrderAutoCompleteService) SetGiftCardService(giftCards *GiftCardService) {
+	s.giftCards = giftCards
+}
+
+func (s *OrderAutoCompleteService) buildOrderAutoCompleteExecutionContext(order *models.Order) *ExecutionContext {
+	if order == nil {
+		return nil
+	}
+	orderID := order.ID
+	metadata := map[string]string{
+		"source":   "order_auto_complete",
+		"order_no": order.OrderNo,
+	}
+	var userID *uint
+	if order.UserID != nil {
+		uid := *order.UserID
+		userID = &uid
+		metadata["user_id"] = strconv.FormatUint(uint64(uid), 10)
+	}
+	return &ExecutionContext{
+		UserID:   userID,
+		OrderID:  &orderID,
+		Metadata: metadata,
+	}
+}
+
+// getAutoCompleteDays 获取自动完成天数，0 表示关闭
+func (s *OrderAutoCompleteService) getAutoCompleteDays() int {
+	if s.cfg == nil || s.cfg.Order.AutoCompleteDays <= 0 {
+		return 0
+	}
+	return s.cfg.Order.AutoCompleteDays
+}
+
+// autoCompleteDaysForType 指定商品类型的自动完成天数，未单独配置时沿用 auto_complete_days
+func (s *OrderAutoCompleteService) autoCompleteDaysForType(productType models.ProductType) int {
+	if s.cfg == nil {
+		return 0
+	}
+	if days, ok := s.cfg.Order.AutoCompleteDaysByType[string(productType)]; ok {
+		if days < 0 {
+			return 0
+		}
+		return days
+	}
+	return s.getAutoCompleteDays()
+}
+
+// autoCompleteDaysFor 订单适用的自动完成天数：纯虚拟商品订单按 virtual，其余（含混合订单）按 physical
+func (s *OrderAutoCompleteService) autoCompleteDaysFor(order *models.Order) int {
+	productType := models.ProductTypeVirtual
+	for _, item := range order.Items {
+		if item.ProductType != models.ProductTypeVirtual {
+			productType = models.ProductTypePhysical
+			break
+		}
+	}
+	return s.autoCompleteDaysForType(productType)
+}
+
+// getMinAutoCompleteDays 各商品类型中最短的自动完成天数，用于粗筛候选订单；全部关闭时为 0
+func (s *OrderAutoCompleteService) getMinAutoCompleteDays() int {
+	minDays := 0
+	for _, productType := range []models.ProductType{models.ProductTypePhysical, models.ProductTypeVirtual} {
+		days := s.autoCompleteDaysForType(productType)
+		if days > 0 && (minDays == 0 || days < minDays) {
+			minDays = days
+		}
+	}
+	return minDays
+}
+
+// getMinReminderAfterDays 发货后最早需要发送提醒的天数，任何类型都不提醒时为 0
+func (s *OrderAutoCompleteService) getMinReminderAfterDays() int {
+	minDays := 0
+	for _, productType := range []models.ProductType{models.ProductTypePhysical, models.ProductTypeVirtual} {
+		days := s.autoCompleteDaysForType(productType)
+		reminderDays := s.getReminderDays(days)
+		if reminderDays <= 0 {
+			continue
+		}
+		if after := days - reminderDays; minDays == 0 || after < minDays {
+			minDays = after
+		}
+	}
+	return minDays
+}
+
+// getDeliveredCompleteDays 签收后自动完成天数，未开启物流轨迹对接时为 0
+func (s *OrderAutoCompleteService) getDeliveredCompleteDays() int {
+	if s.cfg == nil || !s.cfg.Order.CarrierTracking.Enabled || s.cfg.Order.CarrierTracking.CompleteAfterDeliveryDays <= 0 {
+		return 0
+	}
+	return s.cfg.Order.CarrierTracking.CompleteAfterDeliveryDays
+}
+
+// getReminderDays 获取提前提醒天数，必须小于自动完成天数才生效
+func (s *OrderAutoCompleteService) getReminderDays(autoCompleteDays int) int {
+	if s.cfg == nil || autoCompleteDays <= 0 {
+		return 0
+	}
+	days := s.cfg.Order.AutoCompleteReminderDays
+	if days <= 0 || days >= autoCompleteDays {
+		return 0
+	}
+	return days
+}
+
+// RegisterJobs 注册订单自动完成任务：签收或发货超期的订单自动完成，并在自动完成前发送提醒
+func (s *OrderAutoCompleteService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "order_auto_complete",
+		Description: "Complete shipped orders without an open dispute after the configured days and send reminders before completion",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			return s.runOnce()
+		},
+	})
+}
+
+// runOnce 每次执行时读取最新配置，支持热更新
+func (s *OrderAutoCompleteService) runOnce() error {
+	now := models.NowFunc()
+	// 先按签收时间完成，签收较早的订单无需等待发货后的自动完成天数
+	var deliveredErr error
+	if deliveredDays := s.getDeliveredCompleteDays(); deliveredDays > 0 {
+		deliveredErr = s.completeDeliveredOrders(deliveredDays, now)
+	}
+	if s.getMinAutoCompleteDays() <= 0 {
+		return deliveredErr // 所有商品类型均为 0，不自动完成
+	}
+	remindErr := s.sendReminders(now)
+	return errors.Join(deliveredErr, remindErr, s.completeShippedOrders(now))
+}
+
+const orderAutoCompleteBatchSize = 100
+
+// scanShippedOrders 按主键分批遍历发货时间早于 cutoff 且无争议的已发货订单。
+// 各商品类型的天数不同，SQL 只能按最短天数粗筛，需逐单判断是否到期，因此要遍历完所有批次
+func (s *OrderAutoCompleteService) scanShippedOrders(cutoff time.Time, scope func(*gorm.DB) *gorm.DB, visit func(order *models.Order)) error {
+	var lastID uint
+	for {
+		query := withoutOpenDispute(s.db.Model(&models.Order{})).
+			Where("orders.status = ? AND orders.shipped_at IS NOT NULL AND orders.shipped_at < ? AND orders.id > ?", models.OrderStatusShipped, cutoff, lastID)
+		if scope != nil {
+			query = scope(query)
+		}
+		var orders []models.Order
+		if err := query.Order("orders.id ASC").Limit(orderAutoCompleteBatchSize).Find(&orders).Error; err != nil {
+			return err
+		}
+		for i := range orders {
+			visit(&orders[i])
+		}
+		if len(orders) < orderAutoCompleteBatchSize {
+			return nil
+		}
+		lastID = orders[len(orders)-1].ID
+	}
+}
+
+// withoutOpenDispute 排除已分享给客服且关联工单仍未关闭的订单（视为存在争议）
+func withoutOpenDispute(query *gorm.DB) *gorm.DB {
+	activeTicketStatuses := []string{
+		string(models.TicketStatusOpen),
+		string(models.TicketStatusProcessing),
+		string(models.TicketStatusResolved),
+	}
+	return query.Where(
+		"NOT EXISTS (SELECT 1 FROM ticket_order_access toa JOIN tickets t ON t.id = toa.ticket_id WHERE toa.order_id = orders.id AND toa.deleted_at IS NULL AND t.deleted_at IS NULL AND t.status IN ?)",
+		activeTicketStatuses,
+	)
+}
+
+// sendReminders 对即将自动完成的订单发送提醒邮件
+func (s *OrderAutoCompleteService) sendReminders(now time.Time) error {
+	minAfterDays := s.getMinReminderAfterDays()
+	if minAfterDays <= 0 {
+		return nil
+	}
+
+	remindCutoff := now.Add(-time.Duration(minAfterDays) * 24 * time.Hour)
+	notReminded := func(query *gorm.DB) *gorm.DB {
+		return query.Where("orders.auto_complete_reminded_at IS NULL")
+	}
+	err := s.scanShippedOrders(remindCutoff, notReminded, func(order *models.Order) {
+		autoCompleteDays := s.autoCompleteDaysFor(order)
+		reminderDays := s.getReminderDays(autoCompleteDays)
+		if reminderDays <= 0 || !order.ShippedAt.Before(now.Add(-time.Duration(autoCompleteDays-reminderDays)*24*time.Hour)) {
+			return
+		}
+		// 先原子标记已提醒，防止多实例重复发送
+		result := s.db.Model(order).
+			Where("status = ? AND auto_complete_reminded_at IS NULL", models.OrderStatusShipped).
+			Update("auto_complete_reminded_at", now)
+		if result.Error != nil {
+			log.Printf("[OrderAutoComplete] Error marking reminder for order %s: %v", order.OrderNo, result.Error)
+			return
+		}
+		if result.RowsAffected == 0 || s.emailService == nil {
+			return
+		}
+		autoCompleteAt := order.ShippedAt.Add(time.Duration(autoCompleteDays) * 24 * time.Hour)
+		if err := s.emailService.SendOrderAutoCompleteReminderEmail(order, autoCompleteAt); err != nil {
+			log.Printf("[OrderAutoComplete] Order %s failed to queue reminder email: %v", order.OrderNo, err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("query orders to remind: %w", err)
+	}
+	return nil
+}
+
+// completeShippedOrders 自动完成超期无争议的已发货订单，天数按订单商品类型确定
+func (s *OrderAutoCompleteService) completeShippedOrders(now time.Time) error {
+	minDays := s.getMinAutoCompleteDays()
+	cutoffTime := now.Add(-time.Duration(minDays) * 24 * time.Hour)
+
+	completedCount := 0
+	err := s.scanShippedOrders(cutoffTime, nil, func(order *models.Order) {
+		autoCompleteDays := s.autoCompleteDaysFor(order)
+		if autoCompleteDays <= 0 || !order.ShippedAt.Before(now.Add(-time.Duration(autoCompleteDays)*24*time.Hour)) {
+			return
+		}
+		completed, err := s.completeOrder(order, orderAutoCompleteAfterShipped, autoCompleteDays, now)
+		if err != nil {
+			log.Printf("[OrderAutoComplete] Error completing order %s: %v", order.OrderNo, err)
+			return
+		}
+		if completed {
+			completedCount++
+		}
+	})
+	if completedCount > 0 {
+		logger.LogSystemOperation(s.db, "order_auto_complete", "system", nil, map[string]interface{}{
+			"completed_count":             completedCount,
+			"auto_complete_days_physical": s.autoCompleteDaysForType(models.ProductTypePhysical),
+			"auto_complete_days_virtual":  s.autoCompleteDaysForType(models.ProductTypeVirtual),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("query shipped orders: %w", err)
+	}
+	return nil
+}
+
+// completeDeliveredOrders 自动完成物流签收超过指定天数且无争议的订单
+func (s *OrderAutoCompleteService) completeDeliveredOrders(days int, now time.Time) error {
+	cutoffTime := now.Add(-time.Duration(days) * 24 * time.Hour)
+
+	var orders []models.Order
+	if err := withoutOpenDispute(s.db.Model(&models.Order{})).
+		Joins("JOIN order_shipments ON order_shipments.order_id = orders.id").
+		Where("orders.status = ? AND order_shipments.status = ? AND order_shipments.delivered_at < ?", models.OrderStatusShipped, models.ShipmentStatusDelivered, cutoffTime).
+		Limit(100).Find(&orders).Error; err != nil {
+		return fmt.Errorf("query delivered orders: %w", err)
+	}
+
+	completedCount := 0
+	for i := range orders {
+		completed, err := s.completeOrder(&orders[i], orderAutoCompleteAfterDelivered, days, now)
+		if err != nil {
+			log.Printf("[OrderAutoComplete] Error completing delivered order %s: %v", orders[i].OrderNo, err)
+			continue
+		}
+		if completed {
+			completedCount++
+		}
+	}
+
+	if completedCount > 0 {
+		logger.LogSystemOperation(s.db, "order_auto_complete_delivered", "system", nil, map[string]interface{}{
+			"completed_count":              completedCount,
+			"complete_after_delivery_days": days,
+			"cutoff_time":                  cutoffTime.Format(time.RFC3339),
+		})
+	}
+	return nil
+}
+
+// 自动完成依据：发货时间或物流签收时间
+const (
+	orderAutoCompleteAfterShipped   = "shipped"
+	orderAutoCompleteAfterDelivered = "delivered"
+)
+
+// completeOrder 完成单个订单，basis 为自动完成依据，autoCompleteDays 为对应的天数
+func (s *OrderAutoCompleteService) completeOrder(order *models.Order, basis string, autoCompleteDays int, now time.Time) (bool, error) {
+	if order == nil {
+		return false, fmt.Errorf("order is nil")
+	}
+
+	beforeStatus := order.Status
+	adminRemark := fmt.Sprintf("[Complete] System auto-completed: order %s %d days ago without dispute", basis, autoCompleteDays)
+	hookExecCtx := s.buildOrderAutoCompleteExecutionContext(order)
+	if s.pluginManager != nil {
+		hookPayload := map[string]interface{}{
+			"order_id":            order.ID,
+			"order_no":            order.OrderNo,
+			"user_id":             order.UserID,
+			"status_before":       beforeStatus,
+			"shipped_at":          order.ShippedAt,
+			"auto_complete_days":  autoCompleteDays,
+			"auto_complete_basis": basis,
+			"admin_remark":        adminRemark,
+			"source":              "order_auto_complete",
+		}
+		hookResult, hookErr := s.pluginManager.ExecuteHook(HookExecutionRequest{
+			Hook:    "order.auto_complete.before",
+			Payload: hookPayload,
+		}, hookExecCtx)
+		if hookErr != nil {
+			log.Printf("order.auto_complete.before hook execution failed: order=%s err=%v", order.OrderNo, hookErr)
+		} else if hookResult != nil {
+			if hookResult.Blocked {
+				reason := strings.TrimSpace(hookResult.BlockReason)
+				if reason == "" {
+					reason = "order auto-complete blocked by plugin"
+				}
+				log.Printf("[OrderAutoComplete] Skip auto-complete order %s: %s", order.OrderNo, reason)
+				return false, nil
+			}
+			if hookResult.Payload != nil {
+				if rawRemark, exists := hookResult.Payload["admin_remark"]; exists {
+					if remark, ok := rawRemark.(string); ok && strings.TrimSpace(remark) != "" {
+						adminRemark = strings.TrimSpace(remark)
+					}
+				}
+			}
+		}
+	}
+
+	newRemark := adminRemark
+	if order.AdminRemark != "" {
+		newRemark = order.AdminRemark + "\n" + adminRemark
+	}
+
+	// 原子更新订单状态（WHERE status 条件防止与人工完成/退款并发）
+	result := s.db.Model(order).
+		Where("status = ?", models.OrderStatusShipped).
+		Updates(map[string]interface{}{
+			"status":       models.OrderStatusCompleted,
+			"completed_at": now,
+			"admin_remark": newRemark,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// 订单状态已被其他流程修改，跳过
+		return false, nil
+	}
+	order.Status = models.OrderStatusCompleted
+	order.CompletedAt = &now
+	order.AdminRemark = newRemark
+
+	// 扣减优惠码（从预留转为已使用）
+	if order.PromoCodeID != nil && s.promoCodeRepo != nil {
+		if err := s.promoCodeRepo.Deduct(*order.PromoCodeID, order.OrderNo); err != nil {
+			log.Printf("[OrderAutoComplete] Order %s failed to deduct promo code: %v", order.OrderNo, err)
+		}
+	}
+	if order.GiftCardID != nil && s.giftCards != nil {
+		if err := s.giftCards.RedeemForOrder(order); err != nil {
+			log.Printf("[OrderAutoComplete] Order %s failed to redeem gift card: %v", order.OrderNo, err)
+		}
+	}
+
+	shippedAt := ""
+	if order.ShippedAt != nil {
+		shippedAt = order.ShippedAt.Format(time.RFC3339)
+	}
+	logger.LogSystemOperation(s.db, "order_auto_completed", "order", &order.ID, map[string]interface{}{
+		"order_no":            order.OrderNo,
+		"shipped_at":          shippedAt,
+		"auto_complete_days":  autoCompleteDays,
+		"auto_complete_basis": basis,
+	})
+	PublishOrderStatusChanged(repository.NewOrderRepository(s.db), s.pluginManager, hookExecCtx, order, beforeStatus, models.OrderStatusCompleted, map[string]interface{}{
+		"source":             "order_auto_complete",
+		"trigger_action":     "order.auto_complete",
+		"auto_complete_days": autoCompleteDays,
+		"admin_remark":       adminRemark,
+	})
+
+	if s.pluginManager != nil {
+		afterPayload := map[string]interface{}{
+			"order_id":           order.ID,
+			"order_no":           order.OrderNo,
+			"user_id":            order.UserID,
+			"status_before":      beforeStatus,
+			"status_after":       models.OrderStatusCompleted,
+			"auto_complete_days": autoCompleteDays,
+			"admin_remark":       adminRemark,
+			"source":             "order_auto_complete",
+		}
+		go func(execCtx *ExecutionContext, payload map[string]interface{}, orderNo string) {
+			_, hookErr := s.pluginManager.ExecuteHook(HookExecutionRequest{
+				Hook:    "order.auto_complete.after",
+				Payload: payload,
+			}, execCtx)
+			if hookErr != nil {
+				log.Printf("order.auto_complete.after hook execution failed: order=%s err=%v", orderNo, hookErr)
+			}
+		}(hookExecCtx, afterPayload, order.OrderNo)
+	}
+
+	if s.emailService != nil {
+		go s.emailService.SendOrderCompletedEmail(order)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openOrderAutoCompleteTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:order-auto-complete-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.OperationLog{},
+		&models.Order{},
+		&models.Ticket{},
+		&models.TicketOrderAccess{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func createShippedOrderForAutoComplete(t *testing.T, db *gorm.DB, orderNo string, shippedAt time.Time) *models.Order {
+	t.Helper()
+
+	order := &models.Order{
+		OrderNo:   orderNo,
+		Items:     []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		Status:    models.OrderStatusShipped,
+		ShippedAt: &shippedAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	return order
+}
+
+func TestOrderAutoCompleteServiceCompletesShippedOrdersWithoutDispute(t *testing.T) {
+	db := openOrderAutoCompleteTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.AutoCompleteDays = 7
+	cfg.Order.AutoCompleteReminderDays = 2
+
+	now := models.NowFunc()
+	expired := createShippedOrderForAutoComplete(t, db, "ORD-AC-EXPIRED", now.Add(-8*24*time.Hour))
+	disputed := createShippedOrderForAutoComplete(t, db, "ORD-AC-DISPUTED", now.Add(-8*24*time.Hour))
+	remindOnly := createShippedOrderForAutoComplete(t, db, "ORD-AC-REMIND", now.Add(-6*24*time.Hour))
+	fresh := createShippedOrderForAutoComplete(t, db, "ORD-AC-FRESH", now.Add(-1*24*time.Hour))
+
+	ticket := &models.Ticket{
+		TicketNo: "TK-AC-1",
+		UserID:   1,
+		Subject:  "Not received",
+		Content:  "Package missing",
+		Status:   models.TicketStatusOpen,
+	}
+	if err := db.Create(ticket).Error; err != nil {
+		t.Fatalf("create ticket failed: %v", err)
+	}
+	if err := db.Create(&models.TicketOrderAccess{TicketID: ticket.ID, OrderID: disputed.ID, GrantedBy: 1}).Error; err != nil {
+		t.Fatalf("create ticket order access failed: %v", err)
+	}
+
+	svc := NewOrderAutoCompleteService(db, cfg, nil, nil)
+	if err := svc.runOnce(); err != nil {
+		t.Fatalf("auto-complete run failed: %v", err)
+	}
+
+	reload := func(id uint) models.Order {
+		var order models.Order
+		if err := db.First(&order, id).Error; err != nil {
+			t.Fatalf("reload order failed: %v", err)
+		}
+		return order
+	}
+
+	if got := reload(expired.ID); got.Status != models.OrderStatusCompleted || got.CompletedAt == nil {
+		t.Fatalf("expected expired order to be auto-completed, got status=%s completed_at=%v", got.Status, got.CompletedAt)
+	}
+	if got := reload(disputed.ID); got.Status != models.OrderStatusShipped {
+		t.Fatalf("expected disputed order to stay shipped, got %s", got.Status)
+	}
+	if got := reload(remindOnly.ID); got.Status != models.OrderStatusShipped || got.AutoCompleteRemindedAt == nil {
+		t.Fatalf("expected reminder to be recorded without completing, got status=%s reminded_at=%v", got.Status, got.AutoCompleteRemindedAt)
+	}
+	if got := reload(fresh.ID); got.Status != models.OrderStatusShipped || got.AutoCompleteRemindedAt != nil {
+		t.Fatalf("expected fresh order untouched, got status=%s reminded_at=%v", got.Status, got.AutoCompleteRemindedAt)
+	}
+}
+
+func TestOrderAutoCompleteServiceDisabledWhenDaysIsZero(t *testing.T) {
+	db := openOrderAutoCompleteTestDB(t)
+	cfg := &config.Config{}
+
+	order := createShippedOrderForAutoComplete(t, db, "ORD-AC-DISABLED", models.NowFunc().Add(-90*24*time.Hour))
+
+	if err := NewOrderAutoCompleteService(db, cfg, nil, nil).runOnce(); err != nil {
+		t.Fatalf("auto-complete run failed: %v", err)
+	}
+
+	var got models.Order
+	if err := db.First(&got, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusShipped {
+		t.Fatalf("expected order to stay shipped when auto-complete disabled, got %s", got.Status)
+	}
+}
+
+func setAutoCompleteOrderItems(t *testing.T, db *gorm.DB, order *models.Order, items []models.OrderItem) {
+	t.Helper()
+
+	order.Items = items
+	if err := db.Save(order).Error; err != nil {
+		t.Fatalf("update order items failed: %v", err)
+	}
+}
+
+func TestOrderAutoCompleteServiceUsesProductTypeOverrides(t *testing.T) {
+	db := openOrderAutoCompleteTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.AutoCompleteDays = 14
+	cfg.Order.AutoCompleteDaysByType = map[string]int{"virtual": 1}
+
+	now := models.NowFunc()
+	// 一整批尚未到期的实物订单排在前面，不能挡住后面已到期的虚拟订单
+	for i := 0; i < orderAutoCompleteBatchSize; i++ {
+		createShippedOrderForAutoComplete(t, db, fmt.Sprintf("ORD-AC-PHYSICAL-%03d", i), now.Add(-3*24*time.Hour))
+	}
+	virtual := createShippedOrderForAutoComplete(t, db, "ORD-AC-VIRTUAL", now.Add(-2*24*time.Hour))
+	mixed := createShippedOrderForAutoComplete(t, db, "ORD-AC-MIXED", now.Add(-2*24*time.Hour))
+	oldPhysical := createShippedOrderForAutoComplete(t, db, "ORD-AC-PHYSICAL-OLD", now.Add(-15*24*time.Hour))
+	setAutoCompleteOrderItems(t, db, virtual, []models.OrderItem{
+		{SKU: "KEY-1", Name: "License", Quantity: 1, ProductType: models.ProductTypeVirtual},
+	})
+	setAutoCompleteOrderItems(t, db, mixed, []models.OrderItem{
+		{SKU: "KEY-1", Name: "License", Quantity: 1, ProductType: models.ProductTypeVirtual},
+		{SKU: "BOX-1", Name: "Box", Quantity: 1, ProductType: models.ProductTypePhysical},
+	})
+
+	svc := NewOrderAutoCompleteService(db, cfg, nil, nil)
+	if err := svc.runOnce(); err != nil {
+		t.Fatalf("auto-complete run failed: %v", err)
+	}
+
+	statusOf := func(id uint) models.OrderStatus {
+		var order models.Order
+		if err := db.First(&order, id).Error; err != nil {
+			t.Fatalf("reload order failed: %v", err)
+		}
+		return order.Status
+	}
+	if got := statusOf(virtual.ID); got != models.OrderStatusCompleted {
+		t.Fatalf("expected virtual order to complete after 1 day, got %s", got)
+	}
+	if got := statusOf(mixed.ID); got != models.OrderStatusShipped {
+		t.Fatalf("expected mixed order to follow the physical days, got %s", got)
+	}
+	if got := statusOf(oldPhysical.ID); got != models.OrderStatusCompleted {
+		t.Fatalf("expected physical order past 14 days to complete, got %s", got)
+	}
+	var completed int64
+	db.Model(&models.Order{}).Where("status = ?", models.OrderStatusCompleted).Count(&completed)
+	if completed != 2 {
+		t.Fatalf("expected only 2 orders completed, got %d", completed)
+	}
+
+	// 虚拟订单设为 0 时仅关闭该类型
+	cfg.Order.AutoCompleteDaysByType = map[string]int{"virtual": 0}
+	late := createShippedOrderForAutoComplete(t, db, "ORD-AC-VIRTUAL-OFF", now.Add(-30*24*time.Hour))
+	setAutoCompleteOrderItems(t, db, late, []models.OrderItem{
+		{SKU: "KEY-2", Name: "License", Quantity: 1, ProductType: models.ProductTypeVirtual},
+	})
+	if err := svc.runOnce(); err != nil {
+		t.Fatalf("auto-complete run failed: %v", err)
+	}
+	if got := statusOf(late.ID); got != models.OrderStatusShipped {
+		t.Fatalf("expected virtual auto-complete to be disabled, got %s", got)
+	}
+}
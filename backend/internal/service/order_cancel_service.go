@@ -1,11 +1,12 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"auralogic/internal/config"
@@ -17,6 +18,12 @@ import (
 
 const defaultAutoCancelHours = 72
 
+// 自动取消原因
+const (
+	orderCancelReasonPaymentTimeout    = "pending_payment_timeout"
+	orderCancelReasonCheckoutAbandoned = "checkout_abandoned"
+)
+
 // OrderCancelService 订单自动取消服务
 type OrderCancelService struct {
 	db                  *gorm.DB
@@ -26,10 +33,8 @@ type OrderCancelService struct {
 	virtualInventorySvc *VirtualInventoryService
 	serialService       *SerialService
 	pluginManager       *PluginManagerService
-	lifecycleMu         sync.Mutex
-	running             bool
-	stopChan            chan struct{}
-	doneChan            chan struct{}
+	flashSale           *FlashSaleService
+	giftCards           *GiftCardService
 	checkInterval       time.Duration // 检查间隔
 }
 
@@ -57,6 +62,15 @@ func (s *OrderCancelService) SetPluginManager(pluginManager *PluginManagerServic
 	s.pluginManager = pluginManager
 }
 
+func (s *OrderCancelService) SetFlashSaleService(flashSale *FlashSaleService) {
+	s.flashSale = flashSale
+}
+
+// SetGiftCardService 注入礼品卡服务，取消或清理订单时释放礼品卡预留
+func (s *OrderCancelService) SetGiftCardService(giftCards *GiftCardService) {
+	s.giftCards = giftCards
+}
+
 func cloneOrderCancelExecutionContext(execCtx *ExecutionContext) *ExecutionContext {
 	if execCtx == nil {
 		return nil
@@ -116,7 +130,12 @@ func (s *OrderCancelService) buildInventoryHookExecutionContext(order *models.Or
 }
 
 func (s *OrderCancelService) releaseReservedInventoryWithHook(order *models.Order, inventoryID uint, quantity int) error {
-	releaseErr := s.inventoryRepo.ReleaseReserve(inventoryID, quantity, order.OrderNo)
+	var releaseErr error
+	if handled, err := s.flashSale.Release(inventoryID, quantity, order.OrderNo); handled {
+		releaseErr = err
+	} else {
+		releaseErr = s.inventoryRepo.ReleaseReserve(inventoryID, quantity, order.OrderNo)
+	}
 	if s.pluginManager != nil {
 		payload := map[string]interface{}{
 			"order_id":     order.ID,
@@ -143,6 +162,14 @@ func (s *OrderCancelService) releaseReservedInventoryWithHook(order *models.Orde
 	return releaseErr
 }
 
+// orderAutoCancelRemark 生成自动取消的默认管理员备注
+func orderAutoCancelRemark(reason string, autoCancelHours int) string {
+	if reason == orderCancelReasonCheckoutAbandoned {
+		return "System auto-cancelled: user abandoned checkout, reserved stock released early"
+	}
+	return fmt.Sprintf("System auto-cancelled: order unpaid after %d hours", autoCancelHours)
+}
+
 // getAutoCancelHours 获取自动取消小时数，未配置时使用默认值
 func (s *OrderCancelService) getAutoCancelHours() int {
 	if h := s.cfg.Order.AutoCancelHours; h > 0 {
@@ -151,72 +178,23 @@ func (s *OrderCancelService) getAutoCancelHours() int {
 	return defaultAutoCancelHours
 }
 
-// Start 启动自动取消服务
-func (s *OrderCancelService) Start() {
-	s.lifecycleMu.Lock()
-	if s.running {
-		s.lifecycleMu.Unlock()
-		return
-	}
-	stopChan := make(chan struct{})
-	doneChan := make(chan struct{})
-	s.stopChan = stopChan
-	s.doneChan = doneChan
-	s.running = true
-	s.lifecycleMu.Unlock()
-
-	autoCancelHours := s.getAutoCancelHours()
-
-	logger.LogSystemOperation(s.db, "order_cancel_service_start", "system", nil, map[string]interface{}{
-		"auto_cancel_hours": autoCancelHours,
-		"check_interval":    s.checkInterval.String(),
+// RegisterJobs 注册订单自动取消任务：超时订单取消、放弃付款的订单提前释放、草稿清理、虚拟库存暂扣释放
+func (s *OrderCancelService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "order_auto_cancel",
+		Description: "Cancel unpaid orders, release abandoned checkouts, clean up stale drafts and expired virtual stock holds",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			cancelErr := s.cancelExpiredOrders()
+			abandonErr := s.releaseAbandonedCheckouts()
+			s.cleanupStaleDrafts()
+			return errors.Join(cancelErr, abandonErr, s.releaseExpiredVirtualStockHolds())
+		},
 	})
-
-	go func() {
-		defer close(doneChan)
-		runBackgroundServiceWithStopChan("order_cancel.cancelLoop", stopChan, s.cancelLoop)
-	}()
-}
-
-// Stop 停止自动取消服务
-func (s *OrderCancelService) Stop() {
-	s.lifecycleMu.Lock()
-	if !s.running {
-		s.lifecycleMu.Unlock()
-		return
-	}
-	stopChan := s.stopChan
-	doneChan := s.doneChan
-	s.stopChan = nil
-	s.doneChan = nil
-	s.running = false
-
-	logger.LogSystemOperation(s.db, "order_cancel_service_stop", "system", nil, nil)
-	close(stopChan)
-	<-doneChan
-	s.lifecycleMu.Unlock()
-}
-
-// cancelLoop 取消循环
-func (s *OrderCancelService) cancelLoop(stopChan <-chan struct{}) {
-	// 启动时立即执行一次
-	s.cancelExpiredOrders()
-
-	ticker := time.NewTicker(s.checkInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-stopChan:
-			return
-		case <-ticker.C:
-			s.cancelExpiredOrders()
-		}
-	}
 }
 
 // cancelExpiredOrders 取消过期订单
-func (s *OrderCancelService) cancelExpiredOrders() {
+func (s *OrderCancelService) cancelExpiredOrders() error {
 	autoCancelHours := s.getAutoCancelHours()
 
 	// 计算截止时间
@@ -226,17 +204,16 @@ func (s *OrderCancelService) cancelExpiredOrders() {
 	var orders []models.Order
 	if err := s.db.Where("status = ? AND created_at < ?", models.OrderStatusPendingPayment, cutoffTime).
 		Limit(100).Find(&orders).Error; err != nil {
-		log.Printf("[OrderCancel] Error querying expired orders: %v", err)
-		return
+		return fmt.Errorf("query expired orders: %w", err)
 	}
 
 	if len(orders) == 0 {
-		return
+		return nil
 	}
 
 	cancelledCount := 0
 	for _, order := range orders {
-		cancelled, err := s.cancelOrder(&order, autoCancelHours)
+		cancelled, err := s.cancelOrder(&order, autoCancelHours, orderCancelReasonPaymentTimeout)
 		if err != nil {
 			log.Printf("[OrderCancel] Error cancelling order %s: %v", order.OrderNo, err)
 			continue
@@ -253,17 +230,107 @@ func (s *OrderCancelService) cancelExpiredOrders() {
 			"cutoff_time":       cutoffTime.Format(time.RFC3339),
 		})
 	}
+	return nil
+}
+
+// releaseExpiredVirtualStockHolds 释放付款开始时暂扣但超时未确认的虚拟库存
+func (s *OrderCancelService) releaseExpiredVirtualStockHolds() error {
+	if s.virtualInventorySvc == nil {
+		return nil
+	}
+	released, err := s.virtualInventorySvc.ReleaseExpiredHolds(models.NowFunc())
+	if err != nil {
+		return fmt.Errorf("release expired virtual stock holds: %w", err)
+	}
+	if released > 0 {
+		log.Printf("[OrderCancel] Released %d expired virtual stock holds", released)
+	}
+	return nil
+}
+
+const orderAbandonReleaseBatchSize = 100
+
+// releaseAbandonedCheckouts 提前取消用户已主动离开付款页的订单，释放预留库存
+// 已选择付款方式并进入轮询的订单可能仍在外部完成付款，不做提前释放
+func (s *OrderCancelService) releaseAbandonedCheckouts() error {
+	abandonMinutes := s.cfg.Order.AbandonReleaseMinutes
+	if abandonMinutes <= 0 {
+		return nil
+	}
+	autoCancelHours := s.getAutoCancelHours()
+	cutoffTime := time.Now().Add(-time.Duration(abandonMinutes) * time.Minute)
+
+	// 是否占用库存在查询后逐单判断，按主键分页遍历所有候选，避免大量未占用库存的订单挡住后面的订单
+	releasedCount := 0
+	var lastID uint
+	for {
+		var orders []models.Order
+		if err := s.db.Where("status = ? AND checkout_abandoned_at IS NOT NULL AND checkout_abandoned_at < ? AND id > ?", models.OrderStatusPendingPayment, cutoffTime, lastID).
+			Where("NOT EXISTS (SELECT 1 FROM payment_polling_tasks ppt WHERE ppt.order_id = orders.id)").
+			Order("id ASC").Limit(orderAbandonReleaseBatchSize).Find(&orders).Error; err != nil {
+			return fmt.Errorf("query abandoned checkouts: %w", err)
+		}
+
+		for i := range orders {
+			order := &orders[i]
+			// 未占用任何库存的订单无需提前释放，保留到常规超时取消
+			holding, err := s.holdsReservedStock(order)
+			if err != nil {
+				log.Printf("[OrderCancel] Error checking reserved stock of abandoned order %s: %v", order.OrderNo, err)
+				continue
+			}
+			if !holding {
+				continue
+			}
+			cancelled, err := s.cancelOrder(order, autoCancelHours, orderCancelReasonCheckoutAbandoned)
+			if err != nil {
+				log.Printf("[OrderCancel] Error releasing abandoned order %s: %v", order.OrderNo, err)
+				continue
+			}
+			if cancelled {
+				releasedCount++
+			}
+		}
+		if len(orders) < orderAbandonReleaseBatchSize {
+			break
+		}
+		lastID = orders[len(orders)-1].ID
+	}
+
+	if releasedCount > 0 {
+		logger.LogSystemOperation(s.db, "order_abandon_release", "system", nil, map[string]interface{}{
+			"released_count":          releasedCount,
+			"abandon_release_minutes": abandonMinutes,
+			"cutoff_time":             cutoffTime.Format(time.RFC3339),
+		})
+	}
+	return nil
+}
+
+// holdsReservedStock 订单是否占用库存：物理库存与脚本虚拟库存记录在订单绑定中，
+// 静态卡密库存不写入绑定，按订单号预留在 virtual_product_stocks
+func (s *OrderCancelService) holdsReservedStock(order *models.Order) (bool, error) {
+	if len(order.InventoryBindings) > 0 || len(order.VirtualInventoryBindings) > 0 {
+		return true, nil
+	}
+	var count int64
+	if err := s.db.Model(&models.VirtualProductStock{}).
+		Where("order_no = ? AND status = ?", order.OrderNo, models.VirtualStockStatusReserved).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
 
 // cancelOrder 取消单个订单
-func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours int) (bool, error) {
+func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours int, reason string) (bool, error) {
 	if order == nil {
 		return false, fmt.Errorf("order is nil")
 	}
 
 	beforeStatus := order.Status
 	// 先原子更新订单状态为已取消（WHERE status 条件防止并发重复处理）
-	adminRemark := fmt.Sprintf("System auto-cancelled: order unpaid after %d hours", autoCancelHours)
+	adminRemark := orderAutoCancelRemark(reason, autoCancelHours)
 	hookExecCtx := cloneOrderCancelExecutionContext(s.buildInventoryHookExecutionContext(order))
 	if s.pluginManager != nil {
 		hookPayload := map[string]interface{}{
@@ -273,6 +340,7 @@ func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours in
 			"status_before":     beforeStatus,
 			"auto_cancel_hours": autoCancelHours,
 			"admin_remark":      adminRemark,
+			"cancel_reason":     reason,
 			"source":            "order_auto_cancel",
 		}
 		hookResult, hookErr := s.pluginManager.ExecuteHook(HookExecutionRequest{
@@ -307,7 +375,7 @@ func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours in
 					}
 				}
 				if adminRemark == "" {
-					adminRemark = fmt.Sprintf("System auto-cancelled: order unpaid after %d hours", autoCancelHours)
+					adminRemark = orderAutoCancelRemark(reason, autoCancelHours)
 				}
 			}
 		}
@@ -363,6 +431,13 @@ func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours in
 		}
 	}
 
+	// 释放礼品卡预留
+	if order.GiftCardID != nil && s.giftCards != nil {
+		if err := s.giftCards.ReleaseForOrder(order); err != nil {
+			log.Printf("[OrderCancel] Order %s failed to release gift card: %v", order.OrderNo, err)
+		}
+	}
+
 	// 删除关联的序列号
 	if s.serialService != nil {
 		if err := s.serialService.DeleteSerialsByOrderID(order.ID); err != nil {
@@ -373,13 +448,14 @@ func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours in
 	logger.LogPaymentOperation(s.db, "order_auto_cancelled", order.ID, map[string]interface{}{
 		"order_no":   order.OrderNo,
 		"created_at": order.CreatedAt.Format(time.RFC3339),
-		"reason":     "pending_payment_timeout",
+		"reason":     reason,
 	})
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, hookExecCtx, order, beforeStatus, models.OrderStatusCancelled, map[string]interface{}{
+	PublishOrderStatusChanged(repository.NewOrderRepository(s.db), s.pluginManager, hookExecCtx, order, beforeStatus, models.OrderStatusCancelled, map[string]interface{}{
 		"source":            "order_auto_cancel",
 		"trigger_action":    "order.auto_cancel",
 		"auto_cancel_hours": autoCancelHours,
 		"admin_remark":      adminRemark,
+		"cancel_reason":     reason,
 	})
 
 	if s.pluginManager != nil {
@@ -391,6 +467,7 @@ func (s *OrderCancelService) cancelOrder(order *models.Order, autoCancelHours in
 			"status_after":      models.OrderStatusCancelled,
 			"auto_cancel_hours": autoCancelHours,
 			"admin_remark":      adminRemark,
+			"cancel_reason":     reason,
 			"source":            "order_auto_cancel",
 		}
 		go func(execCtx *ExecutionContext, payload map[string]interface{}, orderNo string) {
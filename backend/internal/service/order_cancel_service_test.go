@@ -0,0 +1,369 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openOrderCancelTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:order-cancel-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.OperationLog{},
+		&models.Order{},
+		&models.UserPurchaseStat{},
+		&models.PaymentPollingTask{},
+		&models.VirtualInventory{},
+		&models.VirtualProductStock{},
+		&models.InventoryLog{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestOrderCancelServiceReleasesAbandonedCheckouts(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.AutoCancelHours = 72
+	cfg.Order.AbandonReleaseMinutes = 10
+
+	abandonedAt := models.NowFunc().Add(-15 * time.Minute)
+	recentAt := models.NowFunc().Add(-2 * time.Minute)
+	newOrder := func(orderNo string, abandoned *time.Time, bindings map[int]uint) *models.Order {
+		order := &models.Order{
+			OrderNo:                  orderNo,
+			Items:                    []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+			Status:                   models.OrderStatusPendingPayment,
+			VirtualInventoryBindings: bindings,
+			CheckoutAbandonedAt:      abandoned,
+		}
+		if err := db.Create(order).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+		return order
+	}
+
+	released := newOrder("ORD-AB-RELEASED", &abandonedAt, map[int]uint{0: 1})
+	recent := newOrder("ORD-AB-RECENT", &recentAt, map[int]uint{0: 1})
+	noReservation := newOrder("ORD-AB-NORESERVE", &abandonedAt, nil)
+	polling := newOrder("ORD-AB-POLLING", &abandonedAt, map[int]uint{0: 1})
+	notAbandoned := newOrder("ORD-AB-ACTIVE", nil, map[int]uint{0: 1})
+
+	if err := db.Create(&models.PaymentPollingTask{OrderID: polling.ID, Data: "{}"}).Error; err != nil {
+		t.Fatalf("create polling task failed: %v", err)
+	}
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, nil, nil)
+	svc.releaseAbandonedCheckouts()
+
+	expectations := map[uint]models.OrderStatus{
+		released.ID:      models.OrderStatusCancelled,
+		recent.ID:        models.OrderStatusPendingPayment,
+		noReservation.ID: models.OrderStatusPendingPayment,
+		polling.ID:       models.OrderStatusPendingPayment,
+		notAbandoned.ID:  models.OrderStatusPendingPayment,
+	}
+	for id, want := range expectations {
+		var got models.Order
+		if err := db.First(&got, id).Error; err != nil {
+			t.Fatalf("reload order %d failed: %v", id, err)
+		}
+		if got.Status != want {
+			t.Fatalf("order %s: expected status %s, got %s", got.OrderNo, want, got.Status)
+		}
+	}
+}
+
+func TestOrderCancelServiceReleasesAbandonedStaticVirtualStock(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.AutoCancelHours = 72
+	cfg.Order.AbandonReleaseMinutes = 10
+
+	// 静态卡密库存下单时按订单号预留，不写入订单的库存绑定
+	inventory := &models.VirtualInventory{Name: "Cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory failed: %v", err)
+	}
+	abandonedAt := models.NowFunc().Add(-15 * time.Minute)
+	order := &models.Order{
+		OrderNo:             "ORD-AB-STATIC",
+		Items:               []models.OrderItem{{SKU: "CARD-1", Name: "Card", Quantity: 1}},
+		Status:              models.OrderStatusPendingPayment,
+		CheckoutAbandonedAt: &abandonedAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "CARD-0001"}
+	stock.MarkAsReserved(order.OrderNo)
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create stock failed: %v", err)
+	}
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, NewVirtualInventoryService(db), nil)
+	if err := svc.releaseAbandonedCheckouts(); err != nil {
+		t.Fatalf("release abandoned checkouts failed: %v", err)
+	}
+
+	var got models.Order
+	if err := db.First(&got, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusCancelled {
+		t.Fatalf("expected abandoned order with static stock to be cancelled, got %s", got.Status)
+	}
+	var released models.VirtualProductStock
+	if err := db.First(&released, stock.ID).Error; err != nil {
+		t.Fatalf("reload stock failed: %v", err)
+	}
+	if released.Status != models.VirtualStockStatusAvailable || released.OrderNo != "" {
+		t.Fatalf("expected reserved card to be released, got status=%s order_no=%q", released.Status, released.OrderNo)
+	}
+}
+
+func TestOrderCancelServiceAbandonReleasePagesPastOrdersWithoutStock(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.AutoCancelHours = 72
+	cfg.Order.AbandonReleaseMinutes = 10
+
+	// 排在前面的大量放弃订单未占用库存，不应挡住后面占用库存的订单
+	abandonedAt := models.NowFunc().Add(-15 * time.Minute)
+	for i := 0; i < orderAbandonReleaseBatchSize+20; i++ {
+		idle := &models.Order{
+			OrderNo:             fmt.Sprintf("ORD-AB-IDLE-%03d", i),
+			Items:               []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+			Status:              models.OrderStatusPendingPayment,
+			CheckoutAbandonedAt: &abandonedAt,
+		}
+		if err := db.Create(idle).Error; err != nil {
+			t.Fatalf("create idle order failed: %v", err)
+		}
+	}
+	inventory := &models.VirtualInventory{Name: "Cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory failed: %v", err)
+	}
+	holding := &models.Order{
+		OrderNo:             "ORD-AB-HOLDING",
+		Items:               []models.OrderItem{{SKU: "CARD-1", Name: "Card", Quantity: 1}},
+		Status:              models.OrderStatusPendingPayment,
+		CheckoutAbandonedAt: &abandonedAt,
+	}
+	if err := db.Create(holding).Error; err != nil {
+		t.Fatalf("create holding order failed: %v", err)
+	}
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "CARD-0001"}
+	stock.MarkAsReserved(holding.OrderNo)
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create stock failed: %v", err)
+	}
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, NewVirtualInventoryService(db), nil)
+	if err := svc.releaseAbandonedCheckouts(); err != nil {
+		t.Fatalf("release abandoned checkouts failed: %v", err)
+	}
+
+	var got models.Order
+	if err := db.First(&got, holding.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusCancelled {
+		t.Fatalf("expected holding order behind idle orders to be cancelled, got %s", got.Status)
+	}
+	var pending int64
+	if err := db.Model(&models.Order{}).Where("order_no LIKE ? AND status = ?", "ORD-AB-IDLE-%", models.OrderStatusPendingPayment).Count(&pending).Error; err != nil {
+		t.Fatalf("count idle orders failed: %v", err)
+	}
+	if pending != int64(orderAbandonReleaseBatchSize+20) {
+		t.Fatalf("expected idle orders to stay pending, got %d", pending)
+	}
+}
+
+func TestOrderCancelServiceAbandonReleaseDisabledByDefault(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+
+	abandonedAt := models.NowFunc().Add(-24 * time.Hour)
+	order := &models.Order{
+		OrderNo:                  "ORD-AB-DISABLED",
+		Items:                    []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		Status:                   models.OrderStatusPendingPayment,
+		VirtualInventoryBindings: map[int]uint{0: 1},
+		CheckoutAbandonedAt:      &abandonedAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, nil, nil).releaseAbandonedCheckouts()
+
+	var got models.Order
+	if err := db.First(&got, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusPendingPayment {
+		t.Fatalf("expected order to stay pending when abandon release disabled, got %s", got.Status)
+	}
+}
+
+func TestOrderCancelServiceCleansUpStaleDrafts(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.DraftCleanup.RetentionDays = 7
+
+	paidAt := models.NowFunc().Add(-30 * 24 * time.Hour)
+	newOrder := func(orderNo string, status models.OrderStatus, age time.Duration, paid bool) *models.Order {
+		order := &models.Order{
+			OrderNo:       orderNo,
+			Items:         []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+			Status:        status,
+			ReceiverName:  "Alice",
+			ReceiverPhone: "13800000000",
+			UserEmail:     "alice@example.com",
+		}
+		if paid {
+			order.PaidAt = &paidAt
+		}
+		if err := db.Create(order).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+		if err := db.Model(order).UpdateColumn("updated_at", models.NowFunc().Add(-age)).Error; err != nil {
+			t.Fatalf("backdate order failed: %v", err)
+		}
+		return order
+	}
+
+	staleDraft := newOrder("ORD-DRAFT-STALE", models.OrderStatusDraft, 8*24*time.Hour, false)
+	staleResubmit := newOrder("ORD-RESUBMIT-STALE", models.OrderStatusNeedResubmit, 10*24*time.Hour, false)
+	paidResubmit := newOrder("ORD-RESUBMIT-PAID", models.OrderStatusNeedResubmit, 10*24*time.Hour, true)
+	recentDraft := newOrder("ORD-DRAFT-RECENT", models.OrderStatusDraft, 2*24*time.Hour, false)
+	pendingPayment := newOrder("ORD-PENDING-PAYMENT", models.OrderStatusPendingPayment, 30*24*time.Hour, false)
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, nil, nil)
+	if cleaned := svc.cleanupStaleDrafts(); cleaned != 2 {
+		t.Fatalf("expected 2 drafts cleaned, got %d", cleaned)
+	}
+
+	var remaining int64
+	if err := db.Unscoped().Model(&models.Order{}).Where("id IN ?", []uint{staleDraft.ID, staleResubmit.ID}).Count(&remaining).Error; err != nil {
+		t.Fatalf("count orders failed: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected stale drafts to be deleted, %d remain", remaining)
+	}
+	for _, order := range []*models.Order{paidResubmit, recentDraft, pendingPayment} {
+		var got models.Order
+		if err := db.First(&got, order.ID).Error; err != nil {
+			t.Fatalf("order %s should be kept: %v", order.OrderNo, err)
+		}
+		if got.Status != order.Status {
+			t.Fatalf("order %s: expected status %s, got %s", order.OrderNo, order.Status, got.Status)
+		}
+	}
+}
+
+func TestOrderCancelServiceAnonymizesStaleDrafts(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.DraftCleanup.RetentionDays = 3
+	cfg.Order.DraftCleanup.Action = DraftCleanupActionAnonymize
+
+	formToken := "draft-cleanup-token"
+	order := &models.Order{
+		OrderNo:         "ORD-DRAFT-ANON",
+		Items:           []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		Status:          models.OrderStatusDraft,
+		ReceiverName:    "Alice",
+		ReceiverPhone:   "13800000000",
+		ReceiverAddress: "1 Main St",
+		UserEmail:       "alice@example.com",
+		FormToken:       &formToken,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	if err := db.Model(order).UpdateColumn("updated_at", models.NowFunc().Add(-4*24*time.Hour)).Error; err != nil {
+		t.Fatalf("backdate order failed: %v", err)
+	}
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, nil, nil)
+	if cleaned := svc.cleanupStaleDrafts(); cleaned != 1 {
+		t.Fatalf("expected 1 draft anonymized, got %d", cleaned)
+	}
+
+	var got models.Order
+	if err := db.First(&got, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusCancelled || got.ReceiverName != "" || got.ReceiverPhone != "" ||
+		got.ReceiverAddress != "" || got.UserEmail != "" || got.FormToken != nil {
+		t.Fatalf("expected draft to be cancelled and anonymized, got %+v", got)
+	}
+
+	// 已匿名化的订单不再重复处理
+	if cleaned := svc.cleanupStaleDrafts(); cleaned != 0 {
+		t.Fatalf("expected anonymized draft to be skipped, got %d", cleaned)
+	}
+}
+
+func TestOrderCancelServiceDraftCleanupDryRunLeavesOrdersUntouched(t *testing.T) {
+	db := openOrderCancelTestDB(t)
+	cfg := &config.Config{}
+	cfg.Order.DraftCleanup.RetentionDays = 3
+	cfg.Order.DraftCleanup.Action = DraftCleanupActionAnonymize
+
+	order := &models.Order{
+		OrderNo:      "ORD-DRAFT-DRYRUN",
+		Items:        []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		Status:       models.OrderStatusDraft,
+		ReceiverName: "Alice",
+		UserEmail:    "alice@example.com",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	if err := db.Model(order).UpdateColumn("updated_at", models.NowFunc().Add(-4*24*time.Hour)).Error; err != nil {
+		t.Fatalf("backdate order failed: %v", err)
+	}
+
+	svc := NewOrderCancelService(db, cfg, repository.NewInventoryRepository(db), nil, nil, nil)
+	report, err := svc.CleanupStaleDrafts(true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !report.DryRun || report.CleanedCount != 0 || len(report.Orders) != 1 || report.Orders[0].OrderNo != order.OrderNo {
+		t.Fatalf("expected dry run to list the stale draft without cleaning it, got %+v", report)
+	}
+
+	var got models.Order
+	if err := db.First(&got, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if got.Status != models.OrderStatusDraft || got.ReceiverName != "Alice" || got.UserEmail != "alice@example.com" {
+		t.Fatalf("expected dry run to keep the order unchanged, got %+v", got)
+	}
+
+	// 试运行列出的订单与实际清理的订单一致
+	report, err = svc.CleanupStaleDrafts(false)
+	if err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if report.CleanedCount != 1 || len(report.Orders) != 1 || report.Orders[0].ID != order.ID {
+		t.Fatalf("expected the previewed draft to be cleaned, got %+v", report)
+	}
+}
@@ -0,0 +1,572 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxDisputeStatementLength   = 2000
+	maxDisputeDecisionNote      = 2000
+	defaultDisputeEvidenceDays  = 7
+	orderDisputeTicketPreviewTo = 200
+)
+
+var (
+	ErrOrderDisputeNotFound  = errors.New("dispute not found")
+	ErrDisputeTicketNotFound = errors.New("ticket not found")
+	ErrDisputeOrderNotFound  = errors.New("order not found")
+)
+
+var orderDisputeDecisions = map[string]bool{
+	models.OrderDisputeDecisionRefundFull:    true,
+	models.OrderDisputeDecisionRefundPartial: true,
+	models.OrderDisputeDecisionNoRefund:      true,
+}
+
+// EscalateDisputeInput 用户升级争议时提交的陈述与证据
+type EscalateDisputeInput struct {
+	OrderNo   string // 为空时使用工单中最近分享的订单
+	Statement string
+	Evidence  []string // 已校验的凭证URL
+}
+
+// DisputeEvidenceInput 商家答辩
+type DisputeEvidenceInput struct {
+	Statement string
+	Evidence  []string
+}
+
+// DisputeDecisionInput 平台裁决
+type DisputeDecisionInput struct {
+	Decision string
+	Items    []OrderRefundItemInput // 部分退款裁决的订单项
+	Amount   int64                  // 部分退款金额（最小货币单位），0表示按订单项金额计算
+	Note     string
+}
+
+// OrderDisputeRefundOutcome 裁决触发并已执行的退款，供调用方同步订单状态变更
+type OrderDisputeRefundOutcome struct {
+	Order        *models.Order
+	StatusBefore models.OrderStatus
+	StatusAfter  models.OrderStatus
+	Result       *RefundResult
+}
+
+// OrderDisputeEligibility 用户工单的争议状态：已升级时返回争议，否则返回可升级时间
+type OrderDisputeEligibility struct {
+	Enabled     bool                 `json:"enabled"`
+	Eligible    bool                 `json:"eligible"`
+	AvailableAt *time.Time           `json:"available_at,omitempty"`
+	Dispute     *models.OrderDispute `json:"dispute,omitempty"`
+}
+
+// OrderDisputeService 订单争议升级：工单超过设定天数仍未解决时，用户可升级为平台争议；
+// 双方分别提交证据后由平台裁决，退款类裁决通过退款引擎执行
+type OrderDisputeService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	refundService *RefundService
+}
+
+// NewOrderDisputeService 创建订单争议服务
+func NewOrderDisputeService(db *gorm.DB, cfg *config.Config, refundService *RefundService) *OrderDisputeService {
+	return &OrderDisputeService{db: db, cfg: cfg, refundService: refundService}
+}
+
+func (s *OrderDisputeService) escalationDays() int {
+	if s.cfg == nil || s.cfg.Ticket.DisputeEscalationDays < 0 {
+		return 0
+	}
+	return s.cfg.Ticket.DisputeEscalationDays
+}
+
+func (s *OrderDisputeService) evidenceDays() int {
+	if s.cfg == nil || s.cfg.Ticket.DisputeEvidenceDays <= 0 {
+		return defaultDisputeEvidenceDays
+	}
+	return s.cfg.Ticket.DisputeEvidenceDays
+}
+
+func disputeStatusError(dispute *models.OrderDispute) error {
+	return bizerr.Newf("dispute.statusInvalid", "This dispute is %s and cannot be processed", dispute.Status).
+		WithParams(map[string]interface{}{"status": dispute.Status})
+}
+
+func validateDisputeStatement(statement string) (string, error) {
+	statement = strings.TrimSpace(statement)
+	if statement == "" {
+		return "", bizerr.New("dispute.statementRequired", "Please describe your side of the dispute")
+	}
+	if len([]rune(statement)) > maxDisputeStatementLength {
+		return "", bizerr.Newf("dispute.statementTooLong", "Statement cannot exceed %d characters", maxDisputeStatementLength).
+			WithParams(map[string]interface{}{"max": maxDisputeStatementLength})
+	}
+	return statement, nil
+}
+
+func generateDisputeNo(now time.Time) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("DSP%s%06d", now.Format("20060102"), n.Int64()), nil
+}
+
+func uniqueDisputeNo(tx *gorm.DB) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		disputeNo, err := generateDisputeNo(models.NowFunc())
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := tx.Model(&models.OrderDispute{}).Where("dispute_no = ?", disputeNo).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return disputeNo, nil
+		}
+	}
+	return "", errors.New("failed to generate a unique dispute number")
+}
+
+func (s *OrderDisputeService) userTicket(userID, ticketID uint) (*models.Ticket, error) {
+	var ticket models.Ticket
+	if err := s.db.Where("id = ? AND user_id = ?", ticketID, userID).First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDisputeTicketNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (s *OrderDisputeService) findByTicket(ticketID uint) (*models.OrderDispute, error) {
+	var dispute models.OrderDispute
+	if err := s.db.Where("ticket_id = ?", ticketID).First(&dispute).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// GetForUser 工单的争议或升级资格
+func (s *OrderDisputeService) GetForUser(userID, ticketID uint) (*OrderDisputeEligibility, error) {
+	ticket, err := s.userTicket(userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	dispute, err := s.findByTicket(ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	days := s.escalationDays()
+	result := &OrderDisputeEligibility{Enabled: days > 0, Dispute: dispute}
+	if dispute != nil || days == 0 {
+		return result, nil
+	}
+	availableAt := ticket.CreatedAt.AddDate(0, 0, days)
+	result.AvailableAt = &availableAt
+	result.Eligible = ticket.Status != models.TicketStatusResolved &&
+		ticket.Status != models.TicketStatusClosed &&
+		!models.NowFunc().Before(availableAt)
+	return result, nil
+}
+
+// Escalate 将未解决的工单升级为平台争议，工单优先级提升为紧急并开始商家举证期限
+func (s *OrderDisputeService) Escalate(userID, ticketID uint, input EscalateDisputeInput) (*models.OrderDispute, error) {
+	days := s.escalationDays()
+	if days == 0 {
+		return nil, bizerr.New("dispute.disabled", "Dispute escalation is not available")
+	}
+	ticket, err := s.userTicket(userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == models.TicketStatusResolved || ticket.Status == models.TicketStatusClosed {
+		return nil, bizerr.New("dispute.ticketResolved", "Resolved or closed tickets cannot be escalated")
+	}
+	now := models.NowFunc()
+	if now.Before(ticket.CreatedAt.AddDate(0, 0, days)) {
+		return nil, bizerr.Newf("dispute.notYetEligible", "Tickets can be escalated %d days after they are opened", days).
+			WithParams(map[string]interface{}{"days": days})
+	}
+	statement, err := validateDisputeStatement(input.Statement)
+	if err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	orderNo := strings.TrimSpace(input.OrderNo)
+	if orderNo != "" {
+		err = s.db.Where("order_no = ? AND user_id = ?", orderNo, userID).First(&order).Error
+	} else {
+		err = s.db.Joins("JOIN ticket_order_access ON ticket_order_access.order_id = orders.id").
+			Where("ticket_order_access.ticket_id = ? AND orders.user_id = ?", ticket.ID, userID).
+			Order("ticket_order_access.id DESC").First(&order).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bizerr.New("dispute.orderRequired", "Select the order this dispute is about")
+		}
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDisputeOrderNotFound
+		}
+		return nil, err
+	}
+
+	var user models.User
+	if err := s.db.Select("id, name").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	dispute := &models.OrderDispute{
+		TicketID:         ticket.ID,
+		TicketNo:         ticket.TicketNo,
+		OrderID:          order.ID,
+		OrderNo:          order.OrderNo,
+		Currency:         order.Currency,
+		UserID:           userID,
+		Status:           models.OrderDisputeStatusAwaitingEvidence,
+		BuyerStatement:   statement,
+		BuyerEvidence:    input.Evidence,
+		BuyerSubmittedAt: now,
+		EvidenceDeadline: now.AddDate(0, 0, s.evidenceDays()),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.OrderDispute{}).Where("ticket_id = ?", ticket.ID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return bizerr.New("dispute.alreadyEscalated", "This ticket has already been escalated")
+		}
+		disputeNo, err := uniqueDisputeNo(tx)
+		if err != nil {
+			return err
+		}
+		dispute.DisputeNo = disputeNo
+		if err := tx.Create(dispute).Error; err != nil {
+			return err
+		}
+
+		// 自动授权客服查看争议订单
+		var access models.TicketOrderAccess
+		if err := tx.Where(models.TicketOrderAccess{TicketID: ticket.ID, OrderID: order.ID}).
+			Attrs(models.TicketOrderAccess{GrantedBy: userID, CanView: true}).
+			FirstOrCreate(&access).Error; err != nil {
+			return err
+		}
+		if err := repository.NewOrderRepository(tx).RefreshSharedToSupport(order.ID); err != nil {
+			return err
+		}
+
+		content := buildDisputeMessage("**Escalated to platform dispute** · "+dispute.DisputeNo, statement, input.Evidence)
+		if err := tx.Create(&models.TicketMessage{
+			TicketID:     ticket.ID,
+			SenderType:   "user",
+			SenderID:     userID,
+			SenderName:   user.Name,
+			Content:      content,
+			ContentType:  "text",
+			IsReadByUser: true,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Ticket{}).Where("id = ?", ticket.ID).Updates(map[string]interface{}{
+			"priority":             models.TicketPriorityUrgent,
+			"status":               models.TicketStatusProcessing,
+			"last_message_at":      now,
+			"last_message_preview": truncateRefundText(statement, orderDisputeTicketPreviewTo),
+			"last_message_by":      "user",
+			"unread_count_admin":   gorm.Expr("unread_count_admin + 1"),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDCopy := userID
+	s.recordEvent(dispute, "dispute.escalate", models.OrderEventOperatorUser, &userIDCopy, "Dispute "+dispute.DisputeNo+" opened")
+	return dispute, nil
+}
+
+// buildDisputeMessage 组合写入工单的争议消息：标题 + 陈述 + 凭证
+func buildDisputeMessage(title, statement string, evidence []string) string {
+	var builder strings.Builder
+	builder.WriteString(title)
+	if statement != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(statement)
+	}
+	for _, fileURL := range evidence {
+		builder.WriteString("\n\n![evidence](")
+		builder.WriteString(fileURL)
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
+
+func (s *OrderDisputeService) recordEvent(dispute *models.OrderDispute, source, operatorType string, operatorID *uint, message string) {
+	data := map[string]interface{}{
+		"dispute_id": dispute.ID,
+		"dispute_no": dispute.DisputeNo,
+		"ticket_no":  dispute.TicketNo,
+		"status":     dispute.Status,
+	}
+	if dispute.Decision != "" {
+		data["decision"] = dispute.Decision
+	}
+	recordOrderEventDB(s.db, &models.OrderEvent{
+		OrderID:      dispute.OrderID,
+		OrderNo:      dispute.OrderNo,
+		Type:         models.OrderEventTypeRemark,
+		Source:       source,
+		OperatorType: operatorType,
+		OperatorID:   operatorID,
+		Message:      message,
+		Data:         encodeOrderEventData(data),
+	})
+}
+
+// List 管理端争议队列，status 为空时返回全部
+func (s *OrderDisputeService) List(status string, page, limit int) ([]models.OrderDispute, int64, error) {
+	query := s.db.Model(&models.OrderDispute{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var disputes []models.OrderDispute
+	err := query.Preload("User").Order("id ASC").
+		Offset((page - 1) * limit).Limit(limit).Find(&disputes).Error
+	return disputes, total, err
+}
+
+// Get 争议详情
+func (s *OrderDisputeService) Get(id uint) (*models.OrderDispute, error) {
+	var dispute models.OrderDispute
+	if err := s.db.Preload("User").First(&dispute, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderDisputeNotFound
+		}
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// SubmitMerchantEvidence 商家提交答辩与证据，争议进入平台审核
+func (s *OrderDisputeService) SubmitMerchantEvidence(id, adminID uint, input DisputeEvidenceInput) (*models.OrderDispute, error) {
+	statement, err := validateDisputeStatement(input.Statement)
+	if err != nil {
+		return nil, err
+	}
+	dispute, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	now := models.NowFunc()
+	// 结构体更新以便证据列表按 JSON 序列化
+	result := s.db.Model(&models.OrderDispute{}).
+		Where("id = ? AND status = ?", dispute.ID, models.OrderDisputeStatusAwaitingEvidence).
+		Select("status", "merchant_statement", "merchant_evidence", "merchant_submitted_by", "merchant_submitted_at").
+		Updates(&models.OrderDispute{
+			Status:              models.OrderDisputeStatusUnderReview,
+			MerchantStatement:   statement,
+			MerchantEvidence:    input.Evidence,
+			MerchantSubmittedBy: &adminID,
+			MerchantSubmittedAt: &now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, disputeStatusError(dispute)
+	}
+	dispute.Status = models.OrderDisputeStatusUnderReview
+	dispute.MerchantStatement = statement
+	dispute.MerchantEvidence = input.Evidence
+	dispute.MerchantSubmittedBy = &adminID
+	dispute.MerchantSubmittedAt = &now
+
+	s.notifyTicket(dispute, adminID, buildDisputeMessage("**Merchant response submitted** · "+dispute.DisputeNo, statement, input.Evidence), models.TicketStatusProcessing)
+	s.recordEvent(dispute, "dispute.evidence", models.OrderEventOperatorAdmin, &adminID, "Merchant evidence submitted for dispute "+dispute.DisputeNo)
+	return dispute, nil
+}
+
+// Decide 记录平台裁决并按裁决执行退款。
+// 商家逾期未举证时可直接裁决；提交商家证据的管理员不能同时担任裁决人。
+// 退款执行失败时裁决恢复为原状态，部分退款已批准但付款方式退款失败时保留已批准的退款记录供后续重试
+func (s *OrderDisputeService) Decide(id, adminID uint, input DisputeDecisionInput) (*models.OrderDispute, *OrderDisputeRefundOutcome, error) {
+	if !orderDisputeDecisions[input.Decision] {
+		return nil, nil, bizerr.New("dispute.decisionInvalid", "Invalid dispute decision")
+	}
+	note := strings.TrimSpace(input.Note)
+	if note == "" {
+		return nil, nil, bizerr.New("dispute.decisionNoteRequired", "Please record the reasoning for this decision")
+	}
+	if len([]rune(note)) > maxDisputeDecisionNote {
+		return nil, nil, bizerr.Newf("dispute.decisionNoteTooLong", "Decision note cannot exceed %d characters", maxDisputeDecisionNote).
+			WithParams(map[string]interface{}{"max": maxDisputeDecisionNote})
+	}
+	if input.Decision == models.OrderDisputeDecisionRefundPartial && len(input.Items) == 0 {
+		return nil, nil, bizerr.New("order.partialRefundItemsRequired", "Select at least one item to refund")
+	}
+
+	dispute, err := s.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := models.NowFunc()
+	switch dispute.Status {
+	case models.OrderDisputeStatusUnderReview:
+	case models.OrderDisputeStatusAwaitingEvidence:
+		if now.Before(dispute.EvidenceDeadline) {
+			return nil, nil, bizerr.New("dispute.evidencePending", "The merchant can still submit evidence for this dispute").
+				WithParams(map[string]interface{}{"deadline": dispute.EvidenceDeadline.Format(time.RFC3339)})
+		}
+	default:
+		return nil, nil, disputeStatusError(dispute)
+	}
+	if dispute.MerchantSubmittedBy != nil && *dispute.MerchantSubmittedBy == adminID {
+		return nil, nil, bizerr.New("dispute.reviewerConflict", "The admin who submitted the merchant evidence cannot decide this dispute")
+	}
+
+	// 原子地认领裁决，防止重复执行退款
+	fromStatus := dispute.Status
+	result := s.db.Model(&models.OrderDispute{}).
+		Where("id = ? AND status = ?", dispute.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":        models.OrderDisputeStatusResolved,
+			"decision":      input.Decision,
+			"decision_note": note,
+			"decided_by":    adminID,
+			"decided_at":    now,
+		})
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil, disputeStatusError(dispute)
+	}
+	revert := func() {
+		s.db.Model(&models.OrderDispute{}).Where("id = ?", dispute.ID).Updates(map[string]interface{}{
+			"status":        fromStatus,
+			"decision":      "",
+			"decision_note": "",
+			"decided_by":    nil,
+			"decided_at":    nil,
+		})
+	}
+	dispute.Status = models.OrderDisputeStatusResolved
+	dispute.Decision = input.Decision
+	dispute.DecisionNote = note
+	dispute.DecidedBy = &adminID
+	dispute.DecidedAt = &now
+
+	reason := fmt.Sprintf("Dispute %s: %s", dispute.DisputeNo, truncateRefundText(note, 200))
+	var refunded *OrderDisputeRefundOutcome
+	message := "The platform has reviewed this dispute and decided not to issue a refund."
+	switch input.Decision {
+	case models.OrderDisputeDecisionRefundFull:
+		var order models.Order
+		if err := s.db.First(&order, dispute.OrderID).Error; err != nil {
+			revert()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, ErrDisputeOrderNotFound
+			}
+			return nil, nil, err
+		}
+		outcome, err := s.refundService.RefundOrder(&order, reason)
+		if err != nil {
+			revert()
+			return nil, nil, err
+		}
+		refunded = &OrderDisputeRefundOutcome{Order: &order, StatusBefore: outcome.StatusBefore, StatusAfter: outcome.StatusAfter, Result: outcome.Result}
+		dispute.DecisionAmount = outcome.RefundAmount
+		dispute.RefundStatus = string(outcome.StatusAfter)
+		if outcome.Result != nil {
+			dispute.TransactionID = outcome.Result.TransactionID
+		}
+		message = "The platform has decided this dispute in your favour and the order has been refunded."
+		if outcome.StatusAfter == models.OrderStatusRefundPending {
+			message = "The platform has decided this dispute in your favour. The refund is being processed."
+		}
+	case models.OrderDisputeDecisionRefundPartial:
+		orderService := s.refundService.orderService
+		refund, err := orderService.RefundOrder(dispute.OrderID, input.Items, input.Amount, reason, OrderRefundRequester{UserID: adminID, Role: "admin"})
+		if err != nil {
+			revert()
+			return nil, nil, err
+		}
+		if _, err := orderService.ApproveOrderRefund(refund.ID, adminID, truncateRefundText(note, 200)); err != nil {
+			orderService.RejectOrderRefund(refund.ID, adminID, "Dispute decision reverted")
+			revert()
+			return nil, nil, err
+		}
+		dispute.OrderRefundID = &refund.ID
+		dispute.DecisionAmount = refund.Amount
+		dispute.RefundStatus = string(models.OrderRefundStatusApproved)
+		message = "The platform has decided this dispute partially in your favour. The refund is being processed."
+		if issued, order, completion, err := s.refundService.IssueOrderRefund(refund.ID, adminID); err == nil {
+			refunded = &OrderDisputeRefundOutcome{Order: order, StatusBefore: completion.StatusBefore, StatusAfter: completion.StatusAfter, Result: completion.Result}
+			dispute.RefundStatus = string(issued.Status)
+			if completion.Result != nil {
+				dispute.TransactionID = completion.Result.TransactionID
+			}
+			message = "The platform has decided this dispute partially in your favour and a partial refund has been issued."
+		}
+	}
+	s.db.Model(&models.OrderDispute{}).Where("id = ?", dispute.ID).Updates(map[string]interface{}{
+		"decision_amount": dispute.DecisionAmount,
+		"order_refund_id": dispute.OrderRefundID,
+		"refund_status":   dispute.RefundStatus,
+		"transaction_id":  dispute.TransactionID,
+	})
+
+	s.notifyTicket(dispute, adminID, message+"\n\n"+note, models.TicketStatusResolved)
+	s.recordEvent(dispute, "dispute.decide", models.OrderEventOperatorAdmin, &adminID, "Dispute "+dispute.DisputeNo+" decided: "+dispute.Decision)
+	return dispute, refunded, nil
+}
+
+// notifyTicket 将争议进展作为客服消息写入关联工单
+func (s *OrderDisputeService) notifyTicket(dispute *models.OrderDispute, adminID uint, message string, status models.TicketStatus) {
+	var admin models.User
+	s.db.Select("id, name").First(&admin, adminID)
+
+	now := models.NowFunc()
+	s.db.Create(&models.TicketMessage{
+		TicketID:      dispute.TicketID,
+		SenderType:    "admin",
+		SenderID:      adminID,
+		SenderName:    admin.Name,
+		Content:       message,
+		ContentType:   "text",
+		IsReadByAdmin: true,
+	})
+	updates := map[string]interface{}{
+		"status":               status,
+		"last_message_at":      now,
+		"last_message_preview": truncateRefundText(message, orderDisputeTicketPreviewTo),
+		"last_message_by":      "admin",
+		"unread_count_user":    gorm.Expr("unread_count_user + 1"),
+	}
+	if status == models.TicketStatusResolved {
+		updates["closed_at"] = now
+	}
+	s.db.Model(&models.Ticket{}).Where("id = ?", dispute.TicketID).Updates(updates)
+}
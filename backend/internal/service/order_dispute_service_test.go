@@ -0,0 +1,155 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestOrderDisputeEscalationEvidenceAndDecision(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Order{},
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.Ticket{},
+		&models.TicketMessage{},
+		&models.TicketOrderAccess{},
+		&models.OrderDispute{},
+		&models.OrderEvent{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	originalNow := models.NowFunc
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	buyer := &models.User{UUID: "dispute-buyer", Email: "buyer@example.com", Name: "Buyer", Role: "user", IsActive: true}
+	agent := &models.User{UUID: "dispute-agent", Email: "agent@example.com", Name: "Agent", Role: "admin", IsActive: true}
+	reviewer := &models.User{UUID: "dispute-reviewer", Email: "reviewer@example.com", Name: "Reviewer", Role: "admin", IsActive: true}
+	for _, user := range []*models.User{buyer, agent, reviewer} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true, transaction_id: "RF-DSP" } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:     "ORD-DISPUTE-1",
+		UserID:      &buyer.ID,
+		Status:      models.OrderStatusShipped,
+		Items:       []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		TotalAmount: 1000,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+	openedAt := time.Now().UTC().Add(-24 * time.Hour)
+	ticket := &models.Ticket{TicketNo: "TK-DSP-1", UserID: buyer.ID, Subject: "Never arrived", Content: "Where is it?", Status: models.TicketStatusOpen, Priority: models.TicketPriorityNormal, CreatedAt: openedAt}
+	if err := db.Create(ticket).Error; err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+
+	cfg := &config.Config{}
+	svc := NewOrderDisputeService(db, cfg, NewRefundService(db, nil, NewJSRuntimeService(db, cfg)))
+	input := EscalateDisputeInput{OrderNo: order.OrderNo, Statement: "Tracking has not moved for two weeks"}
+
+	_, err := svc.Escalate(buyer.ID, ticket.ID, input)
+	requireOrderBizErr(t, err, "dispute.disabled")
+	cfg.Ticket.DisputeEscalationDays = 3
+	_, err = svc.Escalate(buyer.ID, ticket.ID, input)
+	requireOrderBizErr(t, err, "dispute.notYetEligible")
+	eligibility, err := svc.GetForUser(buyer.ID, ticket.ID)
+	if err != nil || !eligibility.Enabled || eligibility.Eligible || eligibility.AvailableAt == nil {
+		t.Fatalf("expected ticket not yet eligible, got %+v err=%v", eligibility, err)
+	}
+
+	models.NowFunc = func() time.Time { return openedAt.Add(4 * 24 * time.Hour) }
+	if _, err := svc.GetForUser(agent.ID, ticket.ID); err != ErrDisputeTicketNotFound {
+		t.Fatalf("expected other users' tickets to be hidden, got %v", err)
+	}
+	_, err = svc.Escalate(buyer.ID, ticket.ID, EscalateDisputeInput{Statement: "  "})
+	requireOrderBizErr(t, err, "dispute.statementRequired")
+	_, err = svc.Escalate(buyer.ID, ticket.ID, EscalateDisputeInput{Statement: "No order shared"})
+	requireOrderBizErr(t, err, "dispute.orderRequired")
+
+	dispute, err := svc.Escalate(buyer.ID, ticket.ID, input)
+	if err != nil {
+		t.Fatalf("escalate: %v", err)
+	}
+	if dispute.Status != models.OrderDisputeStatusAwaitingEvidence || dispute.OrderID != order.ID || !dispute.EvidenceDeadline.After(models.NowFunc()) {
+		t.Fatalf("unexpected dispute: %+v", dispute)
+	}
+	var escalated models.Ticket
+	if err := db.First(&escalated, ticket.ID).Error; err != nil {
+		t.Fatalf("reload ticket: %v", err)
+	}
+	if escalated.Priority != models.TicketPriorityUrgent || escalated.UnreadCountAdmin != 1 {
+		t.Fatalf("expected urgent ticket awaiting admin, got priority=%s unread=%d", escalated.Priority, escalated.UnreadCountAdmin)
+	}
+	requireOrderSharedToSupport(t, db, order.ID)
+	_, err = svc.Escalate(buyer.ID, ticket.ID, input)
+	requireOrderBizErr(t, err, "dispute.alreadyEscalated")
+
+	// 举证期限内必须等待商家答辩，答辩人不能担任裁决人
+	decision := DisputeDecisionInput{Decision: models.OrderDisputeDecisionRefundFull, Note: "Carrier confirmed the parcel was lost"}
+	_, _, err = svc.Decide(dispute.ID, reviewer.ID, decision)
+	requireOrderBizErr(t, err, "dispute.evidencePending")
+	_, err = svc.SubmitMerchantEvidence(dispute.ID, agent.ID, DisputeEvidenceInput{})
+	requireOrderBizErr(t, err, "dispute.statementRequired")
+	responded, err := svc.SubmitMerchantEvidence(dispute.ID, agent.ID, DisputeEvidenceInput{
+		Statement: "Shipped on time",
+		Evidence:  []string{"https://shop.example.com/uploads/tickets/2026/10/01/label.png"},
+	})
+	if err != nil || responded.Status != models.OrderDisputeStatusUnderReview {
+		t.Fatalf("submit merchant evidence: %+v err=%v", responded, err)
+	}
+	_, err = svc.SubmitMerchantEvidence(dispute.ID, agent.ID, DisputeEvidenceInput{Statement: "Again"})
+	requireOrderBizErr(t, err, "dispute.statusInvalid")
+	_, _, err = svc.Decide(dispute.ID, agent.ID, decision)
+	requireOrderBizErr(t, err, "dispute.reviewerConflict")
+	_, _, err = svc.Decide(dispute.ID, reviewer.ID, DisputeDecisionInput{Decision: "refund_later", Note: "x"})
+	requireOrderBizErr(t, err, "dispute.decisionInvalid")
+
+	decided, refunded, err := svc.Decide(dispute.ID, reviewer.ID, decision)
+	if err != nil {
+		t.Fatalf("decide: %v", err)
+	}
+	if refunded == nil || refunded.StatusAfter != models.OrderStatusRefunded {
+		t.Fatalf("expected full refund outcome, got %+v", refunded)
+	}
+	if decided.Status != models.OrderDisputeStatusResolved || decided.TransactionID != "RF-DSP" || decided.RefundStatus != string(models.OrderStatusRefunded) {
+		t.Fatalf("unexpected decided dispute: %+v", decided)
+	}
+	stored, err := svc.Get(dispute.ID)
+	if err != nil || len(stored.MerchantEvidence) != 1 || stored.DecisionAmount != decided.DecisionAmount || stored.TransactionID != "RF-DSP" {
+		t.Fatalf("unexpected stored dispute: %+v err=%v", stored, err)
+	}
+	var resolved models.Ticket
+	if err := db.First(&resolved, ticket.ID).Error; err != nil {
+		t.Fatalf("reload ticket: %v", err)
+	}
+	if resolved.Status != models.TicketStatusResolved || resolved.UnreadCountUser != 2 {
+		t.Fatalf("expected resolved ticket with two admin updates, got status=%s unread=%d", resolved.Status, resolved.UnreadCountUser)
+	}
+	_, _, err = svc.Decide(dispute.ID, reviewer.ID, decision)
+	requireOrderBizErr(t, err, "dispute.statusInvalid")
+}
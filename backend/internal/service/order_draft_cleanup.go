@@ -0,0 +1,231 @@
+package service
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/repository"
+)
+
+// 草稿订单清理方式
+const (
+	DraftCleanupActionDelete    = "delete"    // 物理删除订单（默认）
+	DraftCleanupActionAnonymize = "anonymize" // 清除收货与联系信息并标记为已取消，保留订单记录
+)
+
+// draftCleanupAction 获取草稿清理方式，未配置或无法识别时按删除处理
+func (s *OrderCancelService) draftCleanupAction() string {
+	if strings.TrimSpace(s.cfg.Order.DraftCleanup.Action) == DraftCleanupActionAnonymize {
+		return DraftCleanupActionAnonymize
+	}
+	return DraftCleanupActionDelete
+}
+
+// DraftCleanupOrder 草稿清理涉及的订单摘要
+type DraftCleanupOrder struct {
+	ID        uint               `json:"id"`
+	OrderNo   string             `json:"order_no"`
+	Status    models.OrderStatus `json:"status"`
+	UserID    *uint              `json:"user_id,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// DraftCleanupReport 一批草稿清理的范围与结果；DryRun 时 Orders 为将被清理的订单
+type DraftCleanupReport struct {
+	DryRun        bool                `json:"dry_run"`
+	Action        string              `json:"action"`
+	RetentionDays int                 `json:"retention_days"`
+	CutoffTime    time.Time           `json:"cutoff_time"`
+	Orders        []DraftCleanupOrder `json:"orders"`
+	CleanedCount  int                 `json:"cleaned_count"`
+}
+
+var errDraftCleanupDisabled = bizerr.Register("order.draftCleanupDisabled", 400, "Draft cleanup is disabled, set a retention period first")
+
+// findStaleDrafts 查询下一批超过保留天数仍未提交收货信息的草稿和待重填订单
+// 已付款后被要求重填信息的订单不在清理范围内
+func (s *OrderCancelService) findStaleDrafts(cutoffTime time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := s.db.Where("form_submitted_at IS NULL AND updated_at < ?", cutoffTime).
+		Where("status = ? OR (status = ? AND paid_at IS NULL)", models.OrderStatusDraft, models.OrderStatusNeedResubmit).
+		Order("id ASC").Limit(100).Find(&orders).Error
+	return orders, err
+}
+
+// cleanupStaleDrafts 定时任务：清理一批过期草稿
+func (s *OrderCancelService) cleanupStaleDrafts() int {
+	report, err := s.CleanupStaleDrafts(false)
+	if err != nil {
+		if !errDraftCleanupDisabled.Is(err) {
+			log.Printf("[OrderCancel] Error querying stale drafts: %v", err)
+		}
+		return 0
+	}
+	return report.CleanedCount
+}
+
+// CleanupStaleDrafts 立即清理一批（最多 100 条）过期草稿；dryRun 时只返回将被删除或匿名化的订单
+func (s *OrderCancelService) CleanupStaleDrafts(dryRun bool) (*DraftCleanupReport, error) {
+	retentionDays := s.cfg.Order.DraftCleanup.RetentionDays
+	if retentionDays <= 0 {
+		return nil, errDraftCleanupDisabled.New()
+	}
+	action := s.draftCleanupAction()
+	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
+
+	orders, err := s.findStaleDrafts(cutoffTime)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DraftCleanupReport{
+		DryRun:        dryRun,
+		Action:        action,
+		RetentionDays: retentionDays,
+		CutoffTime:    cutoffTime,
+		Orders:        []DraftCleanupOrder{},
+	}
+	for i := range orders {
+		order := &orders[i]
+		if !dryRun {
+			cleaned, err := s.cleanupDraftOrder(order, action)
+			if err != nil {
+				log.Printf("[OrderCancel] Error cleaning up draft order %s: %v", order.OrderNo, err)
+				continue
+			}
+			if !cleaned {
+				continue
+			}
+			report.CleanedCount++
+		}
+		report.Orders = append(report.Orders, DraftCleanupOrder{
+			ID:        order.ID,
+			OrderNo:   order.OrderNo,
+			Status:    order.Status,
+			UserID:    order.UserID,
+			UpdatedAt: order.UpdatedAt,
+		})
+	}
+
+	if report.CleanedCount > 0 {
+		logger.LogSystemOperation(s.db, "order_draft_cleanup", "system", nil, map[string]interface{}{
+			"cleaned_count":  report.CleanedCount,
+			"action":         action,
+			"retention_days": retentionDays,
+			"cutoff_time":    cutoffTime.Format(time.RFC3339),
+		})
+	}
+	return report, nil
+}
+
+// cleanupDraftOrder 删除或匿名化单个草稿订单，并释放其残留的库存和优惠码预留
+func (s *OrderCancelService) cleanupDraftOrder(order *models.Order, action string) (bool, error) {
+	beforeStatus := order.Status
+	// WHERE 条件与查询一致，避免与用户并发提交表单冲突
+	scope := s.db.Where("id = ? AND status = ? AND form_submitted_at IS NULL AND updated_at = ?", order.ID, beforeStatus, order.UpdatedAt)
+
+	var afterStatus models.OrderStatus
+	if action == DraftCleanupActionAnonymize {
+		afterStatus = models.OrderStatusCancelled
+		adminRemark := "System cleanup: shipping information never submitted, personal data removed"
+		if order.AdminRemark != "" {
+			adminRemark = order.AdminRemark + "\n" + adminRemark
+		}
+		result := scope.Model(&models.Order{}).Updates(map[string]interface{}{
+			"status":             afterStatus,
+			"receiver_name":      "",
+			"receiver_phone":     "",
+			"receiver_email":     "",
+			"receiver_province":  "",
+			"receiver_city":      "",
+			"receiver_district":  "",
+			"receiver_address":   "",
+			"receiver_postcode":  "",
+			"user_email":         "",
+			"external_user_name": "",
+			"remark":             "",
+			"form_token":         nil,
+			"form_expires_at":    nil,
+			"admin_remark":       adminRemark,
+		})
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return false, nil
+		}
+	} else {
+		// 草稿从未形成有效订单，直接物理删除，不保留软删除记录
+		result := scope.Unscoped().Delete(&models.Order{})
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return false, nil
+		}
+		if err := s.db.Where("order_id = ?", order.ID).Delete(&models.OrderItemAllocation{}).Error; err != nil {
+			log.Printf("[OrderCancel] Draft order %s failed to delete item allocations: %v", order.OrderNo, err)
+		}
+	}
+
+	syncUserPurchaseStatsTransitionBestEffort(
+		repository.NewOrderRepository(s.db),
+		order.UserID,
+		order.UserID,
+		beforeStatus,
+		afterStatus,
+		order.Items,
+		"draft_cleanup",
+	)
+	spentDelta, countDelta := buildUserConsumptionStatsDelta(beforeStatus, afterStatus, order.TotalAmount)
+	if err := applyUserConsumptionStatsDelta(repository.NewUserRepository(s.db), order.UserID, spentDelta, countDelta); err != nil {
+		log.Printf("[OrderCancel] Draft order %s failed to update user consumption stats: %v", order.OrderNo, err)
+	}
+
+	// 订单已清理，释放残留预留（即使部分失败也不影响清理结果）
+	for i := range order.Items {
+		if inventoryID, exists := order.InventoryBindings[i]; exists && inventoryID > 0 {
+			if err := s.releaseReservedInventoryWithHook(order, inventoryID, order.Items[i].Quantity); err != nil {
+				log.Printf("[OrderCancel] Draft order %s failed to release inventory %d: %v", order.OrderNo, inventoryID, err)
+			}
+		}
+	}
+	if s.virtualInventorySvc != nil {
+		if err := s.virtualInventorySvc.ReleaseStock(order.OrderNo); err != nil {
+			log.Printf("[OrderCancel] Draft order %s failed to release virtual stock: %v", order.OrderNo, err)
+		}
+	}
+	if order.PromoCodeID != nil && s.promoCodeRepo != nil {
+		if err := s.promoCodeRepo.ReleaseReserve(*order.PromoCodeID, order.OrderNo); err != nil {
+			log.Printf("[OrderCancel] Draft order %s failed to release promo code: %v", order.OrderNo, err)
+		}
+	}
+	if order.GiftCardID != nil && s.giftCards != nil {
+		if err := s.giftCards.ReleaseForOrder(order); err != nil {
+			log.Printf("[OrderCancel] Draft order %s failed to release gift card: %v", order.OrderNo, err)
+		}
+	}
+	if s.serialService != nil {
+		if err := s.serialService.DeleteSerialsByOrderID(order.ID); err != nil {
+			log.Printf("[OrderCancel] Draft order %s failed to delete serials: %v", order.OrderNo, err)
+		}
+	}
+
+	logger.LogSystemOperation(s.db, "order_draft_cleaned", "order", &order.ID, map[string]interface{}{
+		"order_no":      order.OrderNo,
+		"status_before": beforeStatus,
+		"action":        action,
+		"updated_at":    order.UpdatedAt.Format(time.RFC3339),
+	})
+	if afterStatus != "" {
+		PublishOrderStatusChanged(repository.NewOrderRepository(s.db), s.pluginManager, s.buildInventoryHookExecutionContext(order), order, beforeStatus, afterStatus, map[string]interface{}{
+			"source":         "order_draft_cleanup",
+			"trigger_action": "order.draft_cleanup",
+		})
+	}
+	return true, nil
+}
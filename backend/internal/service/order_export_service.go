@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/constants"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/repository"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// 订单导出格式
+const (
+	OrderExportFormatCSV  = "csv"
+	OrderExportFormatXLSX = "xlsx"
+)
+
+const (
+	// 每批读取的订单数，导出全程只持有一批订单
+	orderExportBatchSize  = 500
+	orderExportDateFormat = "2006-01-02"
+	orderExportTimeFormat = "2006-01-02 15:04:05"
+)
+
+var orderExportStatusLabels = map[models.OrderStatus]string{
+	models.OrderStatusPendingPayment: "Pending Payment",
+	models.OrderStatusDraft:          "Draft",
+	models.OrderStatusPending:        "Pending Shipment",
+	models.OrderStatusNeedResubmit:   "Needs Resubmit",
+	models.OrderStatusShipped:        "Shipped",
+	models.OrderStatusCompleted:      "Completed",
+	models.OrderStatusCancelled:      "Cancelled",
+	models.OrderStatusRefundPending:  "Refund Pending",
+	models.OrderStatusRefunded:       "Refunded",
+}
+
+// OrderExportColumn 导出列：表头、Excel 列宽与取值
+type OrderExportColumn struct {
+	Header string
+	Width  float64
+	Value  func(order *models.Order) string
+}
+
+// OrderExportRequest 一次导出的参数
+type OrderExportRequest struct {
+	Format    string
+	SheetName string
+	Filter    repository.OrderExportFilter
+	Columns   []OrderExportColumn
+	// Prepare 在取列值前对每个订单调用，如按权限脱敏
+	Prepare func(order *models.Order)
+}
+
+// OrderExportService 以流式方式导出订单：按 id 分批查询，逐行写出 CSV 或 Excel，内存占用与导出总量无关
+type OrderExportService struct {
+	orderRepo *repository.OrderRepository
+}
+
+func NewOrderExportService(db *gorm.DB) *OrderExportService {
+	return &OrderExportService{orderRepo: repository.NewOrderRepository(db)}
+}
+
+// NormalizeOrderExportFormat 校验导出格式，空值使用 fallback
+func NormalizeOrderExportFormat(raw, fallback string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "" {
+		format = fallback
+	}
+	switch format {
+	case OrderExportFormatCSV, OrderExportFormatXLSX:
+		return format, nil
+	case "excel":
+		return OrderExportFormatXLSX, nil
+	}
+	return "", orderbiz.ExportFormatInvalid()
+}
+
+// ParseOrderExportDateRange 解析 YYYY-MM-DD 格式的起止日期（均包含，按 UTC），返回左闭右开的时间范围；未传的一端不限制
+func ParseOrderExportDateRange(from, to string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if raw := strings.TrimSpace(from); raw != "" {
+		parsed, err := time.Parse(orderExportDateFormat, raw)
+		if err != nil {
+			return nil, nil, orderbiz.ExportDateRangeInvalid()
+		}
+		start = &parsed
+	}
+	if raw := strings.TrimSpace(to); raw != "" {
+		parsed, err := time.Parse(orderExportDateFormat, raw)
+		if err != nil {
+			return nil, nil, orderbiz.ExportDateRangeInvalid()
+		}
+		next := parsed.AddDate(0, 0, 1)
+		end = &next
+	}
+	if start != nil && end != nil && !start.Before(*end) {
+		return nil, nil, orderbiz.ExportDateRangeInvalid()
+	}
+	return start, end, nil
+}
+
+// OrderExportContentType 导出文件的 Content-Type
+func OrderExportContentType(format string) string {
+	if format == OrderExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Export 将匹配的订单写入 w，返回导出条数。首批查询成功前不会写入任何内容，
+// 调用方可据此在出错时改为返回 JSON 错误；ctx 取消（如客户端断开）时中止
+func (s *OrderExportService) Export(ctx context.Context, w io.Writer, req OrderExportRequest) (int, error) {
+	batch, err := s.orderRepo.FindExportBatch(req.Filter, 0, orderExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	headers := make([]string, len(req.Columns))
+	for i, column := range req.Columns {
+		headers[i] = column.Header
+	}
+	var sink orderExportSink
+	if req.Format == OrderExportFormatXLSX {
+		sink, err = newXLSXOrderExportSink(w, req.SheetName, req.Columns)
+	} else {
+		sink, err = newCSVOrderExportSink(w)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := sink.WriteRow(headers); err != nil {
+		sink.Abort()
+		return 0, err
+	}
+
+	count := 0
+	row := make([]string, len(req.Columns))
+	for len(batch) > 0 {
+		for i := range batch {
+			order := &batch[i]
+			if req.Prepare != nil {
+				req.Prepare(order)
+			}
+			for j, column := range req.Columns {
+				row[j] = column.Value(order)
+			}
+			if err := sink.WriteRow(row); err != nil {
+				sink.Abort()
+				return count, err
+			}
+			count++
+		}
+		if err := sink.EndBatch(); err != nil {
+			sink.Abort()
+			return count, err
+		}
+		if len(batch) < orderExportBatchSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			sink.Abort()
+			return count, err
+		}
+		lastID := batch[len(batch)-1].ID
+		if batch, err = s.orderRepo.FindExportBatch(req.Filter, lastID, orderExportBatchSize); err != nil {
+			sink.Abort()
+			return count, err
+		}
+	}
+	return count, sink.Close()
+}
+
+type orderExportSink interface {
+	WriteRow(values []string) error
+	// EndBatch 每批写完后调用，CSV 借此把缓冲刷到响应
+	EndBatch() error
+	Close() error
+	Abort()
+}
+
+type csvOrderExportSink struct {
+	writer *csv.Writer
+}
+
+func newCSVOrderExportSink(w io.Writer) (*csvOrderExportSink, error) {
+	// UTF-8 BOM，便于 Excel 正确识别中文
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return nil, err
+	}
+	return &csvOrderExportSink{writer: csv.NewWriter(w)}, nil
+}
+
+func (s *csvOrderExportSink) WriteRow(values []string) error {
+	return s.writer.Write(values)
+}
+
+func (s *csvOrderExportSink) EndBatch() error {
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *csvOrderExportSink) Close() error {
+	return s.EndBatch()
+}
+
+func (s *csvOrderExportSink) Abort() {}
+
+// xlsxOrderExportSink 使用 excelize 的 StreamWriter，行数据超过内存块大小后会落盘到临时文件
+type xlsxOrderExportSink struct {
+	w           io.Writer
+	file        *excelize.File
+	stream      *excelize.StreamWriter
+	headerStyle int
+	row         int
+}
+
+func newXLSXOrderExportSink(w io.Writer, sheetName string, columns []OrderExportColumn) (*xlsxOrderExportSink, error) {
+	file := excelize.NewFile()
+	if sheetName = strings.TrimSpace(sheetName); sheetName == "" {
+		sheetName = "Orders"
+	}
+	if err := file.SetSheetName("Sheet1", sheetName); err != nil {
+		file.Close()
+		return nil, err
+	}
+	headerStyle, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	for i, column := range columns {
+		if column.Width <= 0 {
+			continue
+		}
+		if err := stream.SetColWidth(i+1, i+1, column.Width); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return &xlsxOrderExportSink{w: w, file: file, stream: stream, headerStyle: headerStyle}, nil
+}
+
+func (s *xlsxOrderExportSink) WriteRow(values []string) error {
+	s.row++
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	if s.row == 1 {
+		for i, value := range values {
+			cells[i] = excelize.Cell{StyleID: s.headerStyle, Value: value}
+		}
+		if err := s.stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+			return err
+		}
+	}
+	cell, err := excelize.CoordinatesToCellName(1, s.row)
+	if err != nil {
+		return err
+	}
+	return s.stream.SetRow(cell, cells)
+}
+
+func (s *xlsxOrderExportSink) EndBatch() error {
+	return nil
+}
+
+func (s *xlsxOrderExportSink) Close() error {
+	defer s.file.Close()
+	if err := s.stream.Flush(); err != nil {
+		return err
+	}
+	return s.file.Write(s.w)
+}
+
+func (s *xlsxOrderExportSink) Abort() {
+	s.file.Close()
+}
+
+// UserOrderExportColumns 用户导出自己订单的列
+func UserOrderExportColumns() []OrderExportColumn {
+	return []OrderExportColumn{
+		{Header: "Order No.", Width: 20, Value: func(o *models.Order) string { return o.OrderNo }},
+		{Header: "Order Status", Width: 16, Value: orderExportStatus},
+		{Header: "Items", Width: 40, Value: orderExportItems},
+		{Header: "Quantity", Width: 10, Value: orderExportQuantity},
+		{Header: "Currency", Width: 10, Value: func(o *models.Order) string { return o.Currency }},
+		{Header: "Total Amount", Width: 14, Value: func(o *models.Order) string { return money.MinorToString(o.TotalAmount) }},
+		{Header: "Tracking No.", Width: 20, Value: func(o *models.Order) string { return o.TrackingNo }},
+		{Header: "Created At", Width: 20, Value: func(o *models.Order) string { return o.CreatedAt.Format(orderExportTimeFormat) }},
+		{Header: "Paid At", Width: 20, Value: func(o *models.Order) string { return orderExportTime(o.PaidAt) }},
+		{Header: "Completed At", Width: 20, Value: func(o *models.Order) string { return orderExportTime(o.CompletedAt) }},
+	}
+}
+
+// AdminOrderExportColumns 管理端导出列，前 15 列与物流导入模板一致（订单号在 A 列、物流单号在 M 列）
+func AdminOrderExportColumns() []OrderExportColumn {
+	return []OrderExportColumn{
+		{Header: "Order No.", Width: 18, Value: func(o *models.Order) string { return o.OrderNo }},
+		{Header: "User Email", Width: 25, Value: func(o *models.Order) string { return o.UserEmail }},
+		{Header: "Order Status", Width: 10, Value: orderExportStatus},
+		{Header: "Privacy Protected", Width: 10, Value: func(o *models.Order) string {
+			if o.PrivacyProtected {
+				return "Yes"
+			}
+			return "No"
+		}},
+		{Header: "Recipient", Width: 12, Value: func(o *models.Order) string { return o.ReceiverName }},
+		{Header: "Phone", Width: 15, Value: func(o *models.Order) string {
+			if o.PhoneCode != "" {
+				return o.PhoneCode + " " + o.ReceiverPhone
+			}
+			return o.ReceiverPhone
+		}},
+		{Header: "Country", Width: 12, Value: func(o *models.Order) string {
+			country := o.ReceiverCountry
+			if country == "" {
+				country = "CN"
+			}
+			return constants.GetCountryNameZH(country)
+		}},
+		{Header: "Province", Width: 10, Value: func(o *models.Order) string { return o.ReceiverProvince }},
+		{Header: "City", Width: 10, Value: func(o *models.Order) string { return o.ReceiverCity }},
+		{Header: "District", Width: 10, Value: func(o *models.Order) string { return o.ReceiverDistrict }},
+		{Header: "Address", Width: 30, Value: func(o *models.Order) string { return o.ReceiverAddress }},
+		{Header: "Postcode", Width: 10, Value: func(o *models.Order) string { return o.ReceiverPostcode }},
+		{Header: "Tracking No.", Width: 20, Value: func(o *models.Order) string { return o.TrackingNo }},
+		{Header: "Created At", Width: 20, Value: func(o *models.Order) string { return o.CreatedAt.Format(orderExportTimeFormat) }},
+		{Header: "Completed At", Width: 20, Value: func(o *models.Order) string { return orderExportTime(o.CompletedAt) }},
+		{Header: "Items", Width: 40, Value: orderExportItems},
+		{Header: "Quantity", Width: 10, Value: orderExportQuantity},
+		{Header: "Currency", Width: 10, Value: func(o *models.Order) string { return o.Currency }},
+		{Header: "Total Amount", Width: 14, Value: func(o *models.Order) string { return money.MinorToString(o.TotalAmount) }},
+		{Header: "Paid At", Width: 20, Value: func(o *models.Order) string { return orderExportTime(o.PaidAt) }},
+	}
+}
+
+func orderExportStatus(order *models.Order) string {
+	if label, ok := orderExportStatusLabels[order.Status]; ok {
+		return label
+	}
+	return string(order.Status)
+}
+
+// orderExportItems 订单项摘要，如 "TEE-1 x2; MUG-3 x1"
+func orderExportItems(order *models.Order) string {
+	parts := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		label := item.SKU
+		if label == "" {
+			label = item.Name
+		}
+		parts = append(parts, fmt.Sprintf("%s x%d", label, item.Quantity))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func orderExportQuantity(order *models.Order) string {
+	total := 0
+	for _, item := range order.Items {
+		total += item.Quantity
+	}
+	return strconv.Itoa(total)
+}
+
+func orderExportTime(value *time.Time) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.Format(orderExportTimeFormat)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestOrderExportStreamsAcrossBatchesWithFilters(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	owner, other := uint(1), uint(2)
+	day := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	orders := make([]models.Order, 0, orderExportBatchSize+30)
+	for i := 0; i < orderExportBatchSize+20; i++ {
+		orders = append(orders, models.Order{
+			OrderNo: fmt.Sprintf("ORD-%04d", i), UserID: &owner, Status: models.OrderStatusCompleted,
+			Items:       []models.OrderItem{{SKU: "TEE_1", Name: "Tee", Quantity: 2}},
+			TotalAmount: 1999, Currency: "USD", CreatedAt: day,
+		})
+	}
+	for i := 0; i < 10; i++ {
+		orders = append(orders, models.Order{
+			OrderNo: fmt.Sprintf("OTHER-%d", i), UserID: &owner, Status: models.OrderStatusCompleted,
+			// TEE11 不能被 SKU 为 TEE_1 的 LIKE 模式误匹配
+			Items:     []models.OrderItem{{SKU: "TEE11", Name: "Tee XL", Quantity: 1}},
+			CreatedAt: day,
+		})
+	}
+	orders = append(orders,
+		models.Order{OrderNo: "LATE", UserID: &owner, Status: models.OrderStatusCompleted, Items: []models.OrderItem{{SKU: "TEE_1", Quantity: 1}}, CreatedAt: day.AddDate(0, 0, 5)},
+		models.Order{OrderNo: "FOREIGN", UserID: &other, Status: models.OrderStatusCompleted, Items: []models.OrderItem{{SKU: "TEE_1", Quantity: 1}}, CreatedAt: day},
+	)
+	if err := db.CreateInBatches(orders, 100).Error; err != nil {
+		t.Fatalf("create orders: %v", err)
+	}
+
+	from, to, err := ParseOrderExportDateRange("2026-03-10", "2026-03-10")
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+	svc := NewOrderExportService(db)
+	var buf bytes.Buffer
+	count, err := svc.Export(context.Background(), &buf, OrderExportRequest{
+		Format:  OrderExportFormatCSV,
+		Filter:  repository.OrderExportFilter{UserID: &owner, SKU: "TEE_1", From: from, To: to},
+		Columns: UserOrderExportColumns(),
+	})
+	if err != nil {
+		t.Fatalf("export csv: %v", err)
+	}
+	if count != orderExportBatchSize+20 {
+		t.Fatalf("expected %d exported orders, got %d", orderExportBatchSize+20, count)
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(buf.Bytes(), []byte{0xEF, 0xBB, 0xBF}))).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != count+1 || records[0][0] != "Order No." {
+		t.Fatalf("unexpected csv rows %d header %v", len(records), records[0])
+	}
+	if first := records[1]; first[0] != fmt.Sprintf("ORD-%04d", orderExportBatchSize+19) || first[2] != "TEE_1 x2" || first[5] != "19.99" {
+		t.Fatalf("unexpected first row %v", first)
+	}
+
+	buf.Reset()
+	count, err = svc.Export(context.Background(), &buf, OrderExportRequest{
+		Format:  OrderExportFormatXLSX,
+		Filter:  repository.OrderExportFilter{UserID: &owner, SKU: "TEE11"},
+		Columns: AdminOrderExportColumns(),
+	})
+	if err != nil || count != 10 {
+		t.Fatalf("export xlsx: count=%d err=%v", count, err)
+	}
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer file.Close()
+	rows, err := file.GetRows("Orders")
+	if err != nil || len(rows) != 11 || rows[0][12] != "Tracking No." {
+		t.Fatalf("unexpected xlsx rows: %v %d", err, len(rows))
+	}
+}
+
+func TestOrderExportRejectsInvalidParameters(t *testing.T) {
+	if _, err := NormalizeOrderExportFormat("pdf", OrderExportFormatCSV); err == nil {
+		t.Fatal("expected pdf format to be rejected")
+	}
+	if format, err := NormalizeOrderExportFormat("", OrderExportFormatXLSX); err != nil || format != OrderExportFormatXLSX {
+		t.Fatalf("expected fallback format, got %q %v", format, err)
+	}
+	_, _, err := ParseOrderExportDateRange("2026-03-11", "2026-03-10")
+	requireOrderBizErr(t, err, "order.exportDateRangeInvalid")
+	_, _, err = ParseOrderExportDateRange("03/10/2026", "")
+	requireOrderBizErr(t, err, "order.exportDateRangeInvalid")
+}
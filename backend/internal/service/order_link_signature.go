@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+)
+
+// 订单签名链接的用途，写入签名内容，防止一种链接的签名被用于另一种链接
+const (
+	orderLinkPurposeTracking = "order_tracking"
+	orderLinkPurposePayment  = "payment_link"
+)
+
+// signOrderLink 使用 JWT 密钥对订单号与过期时间做 HMAC，并绑定用途
+func signOrderLink(cfg *config.Config, purpose, orderNo string, expires int64) string {
+	secret := ""
+	if cfg != nil {
+		secret = cfg.JWT.Secret
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose + "\x00" + orderNo + "\x00" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// orderLinkQuery 生成签名链接的查询参数
+func orderLinkQuery(cfg *config.Config, purpose, orderNo string, expires int64) url.Values {
+	query := url.Values{}
+	query.Set("order_no", orderNo)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("sig", signOrderLink(cfg, purpose, orderNo, expires))
+	return query
+}
+
+// verifyOrderLink 校验签名链接参数，通过时返回去除空白后的订单号；订单号为空、已过期或签名不符时返回 false
+func verifyOrderLink(cfg *config.Config, purpose, orderNo, expires, sig string) (string, bool) {
+	orderNo = strings.TrimSpace(orderNo)
+	expiresAt, err := strconv.ParseInt(strings.TrimSpace(expires), 10, 64)
+	if orderNo == "" || err != nil || time.Now().Unix() > expiresAt {
+		return "", false
+	}
+	if !hmac.Equal([]byte(strings.ToLower(strings.TrimSpace(sig))), []byte(signOrderLink(cfg, purpose, orderNo, expiresAt))) {
+		return "", false
+	}
+	return orderNo, true
+}
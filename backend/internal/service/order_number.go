@@ -0,0 +1,232 @@
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/utils"
+	"auralogic/internal/repository"
+)
+
+// 订单号格式
+const (
+	OrderNumberFormatTimestamp      = "timestamp"       // 前缀+时间戳+进程内序号（默认）
+	OrderNumberFormatDateSequence   = "date_sequence"   // 前缀+日期+每日递增序号
+	OrderNumberFormatRandomChecksum = "random_checksum" // 前缀+随机数字+Luhn 校验位
+)
+
+const (
+	orderNumberMaxAttempts    = 5
+	orderNumberSequenceTTL    = 48 * time.Hour
+	orderNumberReservationTTL = 10 * time.Minute
+	orderNumberMaxDigits      = 18
+)
+
+// OrderNumberAllocator 按配置生成订单号。
+// 多实例部署时通过 Redis 分配每日序号并预占候选单号，最后再查库确认未被占用，Redis 不可用时退化为进程内分配。
+type OrderNumberAllocator struct {
+	repo *repository.OrderRepository
+	cfg  *config.Config
+
+	mu       sync.Mutex
+	localSeq map[string]int64
+}
+
+func NewOrderNumberAllocator(repo *repository.OrderRepository, cfg *config.Config) *OrderNumberAllocator {
+	return &OrderNumberAllocator{
+		repo:     repo,
+		cfg:      cfg,
+		localSeq: make(map[string]int64),
+	}
+}
+
+// Allocate 生成一个未被占用的订单号，platform 为 API 草稿订单的来源平台（用于店铺前缀），其他订单传空
+func (a *OrderNumberAllocator) Allocate(platform string) (string, error) {
+	numberCfg := a.cfg.Order.Number
+	prefix := a.prefix(platform)
+
+	for attempt := 0; attempt < orderNumberMaxAttempts; attempt++ {
+		var (
+			orderNo string
+			err     error
+		)
+		switch strings.TrimSpace(numberCfg.Format) {
+		case OrderNumberFormatDateSequence:
+			orderNo, err = a.nextDateSequenceNo(prefix, clampOrderNumberDigits(numberCfg.SequenceDigits, 6))
+		case OrderNumberFormatRandomChecksum:
+			orderNo, err = randomChecksumOrderNo(prefix, clampOrderNumberDigits(numberCfg.RandomDigits, 10))
+		default:
+			orderNo = utils.GenerateOrderNo(prefix)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if !reserveOrderNo(orderNo) {
+			continue
+		}
+		exists, err := a.repo.ExistsByOrderNo(orderNo)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return orderNo, nil
+		}
+	}
+	return "", fmt.Errorf("failed to allocate a unique order number after %d attempts", orderNumberMaxAttempts)
+}
+
+func (a *OrderNumberAllocator) prefix(platform string) string {
+	if platform = strings.TrimSpace(platform); platform != "" {
+		if storePrefix, ok := a.cfg.Order.Number.StorePrefixes[platform]; ok && storePrefix != "" {
+			return storePrefix
+		}
+	}
+	return a.cfg.Order.NoPrefix
+}
+
+func clampOrderNumberDigits(digits, fallback int) int {
+	if digits <= 0 {
+		return fallback
+	}
+	if digits > orderNumberMaxDigits {
+		return orderNumberMaxDigits
+	}
+	return digits
+}
+
+// nextDateSequenceNo 生成 前缀+YYYYMMDD+序号，序号按前缀和日期每日从 1 开始
+func (a *OrderNumberAllocator) nextDateSequenceNo(prefix string, digits int) (string, error) {
+	base := prefix + models.NowFunc().Format("20060102")
+	seq, err := a.nextSequence(base, digits)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%0*d", base, digits, seq), nil
+}
+
+func (a *OrderNumberAllocator) nextSequence(base string, digits int) (int64, error) {
+	if cache.RedisClient != nil {
+		seq, err := a.nextRedisSequence(base, digits)
+		if err == nil {
+			return seq, nil
+		}
+		log.Printf("order number: redis sequence unavailable, falling back to local sequence: %v", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.localSeq[base]; !ok {
+		seed, err := a.lastSequence(base, digits)
+		if err != nil {
+			return 0, err
+		}
+		// 清理往日的序号，避免长期运行时 map 无限增长
+		today := base[len(base)-len("20060102"):]
+		for key := range a.localSeq {
+			if !strings.HasSuffix(key, today) {
+				delete(a.localSeq, key)
+			}
+		}
+		a.localSeq[base] = seed
+	}
+	a.localSeq[base]++
+	return a.localSeq[base], nil
+}
+
+func (a *OrderNumberAllocator) nextRedisSequence(base string, digits int) (int64, error) {
+	key := "order_no:seq:" + base
+	exists, err := cache.Exists(key)
+	if err != nil {
+		return 0, err
+	}
+	if exists == 0 {
+		// Redis 重启或键过期后从数据库中当天最大单号恢复，SetNX 保证并发时只有一个实例写入种子
+		seed, err := a.lastSequence(base, digits)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := cache.SetNX(key, seed, orderNumberSequenceTTL); err != nil {
+			return 0, err
+		}
+	}
+	seq, err := cache.Incr(key)
+	if err != nil {
+		return 0, err
+	}
+	if seq == 1 {
+		_ = cache.Expire(key, orderNumberSequenceTTL)
+	}
+	return seq, nil
+}
+
+func (a *OrderNumberAllocator) lastSequence(base string, digits int) (int64, error) {
+	last, err := a.repo.FindLastOrderNoWithPrefix(base, len(base)+digits)
+	if err != nil || last == "" {
+		return 0, err
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(last, base), 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+	return seq, nil
+}
+
+// reserveOrderNo 通过 Redis 预占候选单号，防止多实例在写库前生成相同单号；未启用 Redis 时直接放行
+func reserveOrderNo(orderNo string) bool {
+	if cache.RedisClient == nil {
+		return true
+	}
+	ok, err := cache.SetNX("order_no:reserved:"+orderNo, 1, orderNumberReservationTTL)
+	if err != nil {
+		return true
+	}
+	return ok
+}
+
+// randomChecksumOrderNo 生成 前缀+随机数字+Luhn 校验位，便于人工录入时发现输错
+func randomChecksumOrderNo(prefix string, digits int) (string, error) {
+	var builder strings.Builder
+	builder.Grow(digits + 1)
+	for i := 0; i < digits; i++ {
+		// 首位不为 0，避免被表格软件截断
+		low, span := int64(0), int64(10)
+		if i == 0 {
+			low, span = 1, 9
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(span))
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(byte('0' + low + n.Int64()))
+	}
+	body := builder.String()
+	return prefix + body + strconv.Itoa(luhnCheckDigit(body)), nil
+}
+
+// luhnCheckDigit 计算数字串的 Luhn 校验位
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
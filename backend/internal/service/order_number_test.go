@@ -0,0 +1,129 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/repository"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newOrderNumberTestAllocator(t *testing.T, number config.OrderNumberConfig) (*OrderNumberAllocator, *repository.OrderRepository) {
+	t.Helper()
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		t.Fatalf("auto migrate orders failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Order.NoPrefix = "ORD"
+	cfg.Order.Number = number
+	repo := repository.NewOrderRepository(db)
+	return NewOrderNumberAllocator(repo, cfg), repo
+}
+
+func createOrderNumberTestOrder(t *testing.T, repo *repository.OrderRepository, orderNo string) {
+	t.Helper()
+	if err := repo.Create(&models.Order{OrderNo: orderNo, Status: models.OrderStatusPending, Items: []models.OrderItem{}}); err != nil {
+		t.Fatalf("create order %s failed: %v", orderNo, err)
+	}
+}
+
+func TestOrderNumberDateSequenceResumesFromDatabase(t *testing.T) {
+	allocator, repo := newOrderNumberTestAllocator(t, config.OrderNumberConfig{
+		Format:         OrderNumberFormatDateSequence,
+		SequenceDigits: 4,
+		StorePrefixes:  map[string]string{"shop-a": "SA-"},
+	})
+	date := models.NowFunc().Format("20060102")
+	createOrderNumberTestOrder(t, repo, "ORD"+date+"0041")
+	// 其他格式的同前缀单号不影响序号恢复
+	createOrderNumberTestOrder(t, repo, "ORD"+date+"999999999999")
+
+	first, err := allocator.Allocate("")
+	if err != nil {
+		t.Fatalf("allocate failed: %v", err)
+	}
+	second, err := allocator.Allocate("")
+	if err != nil {
+		t.Fatalf("allocate failed: %v", err)
+	}
+	if first != "ORD"+date+"0042" || second != "ORD"+date+"0043" {
+		t.Fatalf("expected sequence to continue from database, got %s, %s", first, second)
+	}
+
+	store, err := allocator.Allocate("shop-a")
+	if err != nil {
+		t.Fatalf("allocate store order number failed: %v", err)
+	}
+	if store != "SA-"+date+"0001" {
+		t.Fatalf("expected store prefix with its own sequence, got %s", store)
+	}
+}
+
+func TestOrderNumberDateSequenceUsesRedisAcrossAllocators(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		if cache.RedisClient != nil {
+			_ = cache.RedisClient.Close()
+		}
+		cache.RedisClient = previousClient
+	}()
+
+	number := config.OrderNumberConfig{Format: OrderNumberFormatDateSequence, SequenceDigits: 6}
+	allocator, repo := newOrderNumberTestAllocator(t, number)
+	// 模拟另一实例，共享数据库与 Redis
+	other := NewOrderNumberAllocator(repo, allocator.cfg)
+	date := models.NowFunc().Format("20060102")
+	createOrderNumberTestOrder(t, repo, "ORD"+date+"000005")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		for _, a := range []*OrderNumberAllocator{allocator, other} {
+			orderNo, err := a.Allocate("")
+			if err != nil {
+				t.Fatalf("allocate failed: %v", err)
+			}
+			if seen[orderNo] {
+				t.Fatalf("duplicate order number %s", orderNo)
+			}
+			seen[orderNo] = true
+		}
+	}
+	if !seen["ORD"+date+"000006"] || !seen["ORD"+date+"000013"] {
+		t.Fatalf("expected sequence 6..13 shared through redis, got %v", seen)
+	}
+}
+
+func TestOrderNumberRandomChecksum(t *testing.T) {
+	allocator, _ := newOrderNumberTestAllocator(t, config.OrderNumberConfig{
+		Format:       OrderNumberFormatRandomChecksum,
+		RandomDigits: 8,
+	})
+	for i := 0; i < 20; i++ {
+		orderNo, err := allocator.Allocate("")
+		if err != nil {
+			t.Fatalf("allocate failed: %v", err)
+		}
+		body := strings.TrimPrefix(orderNo, "ORD")
+		if len(body) != 9 || body[0] == '0' {
+			t.Fatalf("unexpected random order number %s", orderNo)
+		}
+		if luhnCheckDigit(body[:8]) != int(body[8]-'0') {
+			t.Fatalf("order number %s has an invalid check digit", orderNo)
+		}
+	}
+	if luhnCheckDigit("7992739871") != 3 {
+		t.Fatalf("luhn check digit mismatch for the reference number")
+	}
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxOrderRefundReasonLength = 1000
+	maxOrderRefundNoteLength   = 1000
+)
+
+var ErrOrderRefundNotFound = errors.New("order refund not found")
+
+// OrderRefundItemInput 部分退款的订单项下标及数量
+type OrderRefundItemInput struct {
+	ItemIndex int
+	Quantity  int
+}
+
+// OrderRefundRequester 部分退款发起人
+type OrderRefundRequester struct {
+	UserID uint
+	Role   string // user / admin
+}
+
+// OrderRefundCompletion 部分退款到账后的订单变化
+type OrderRefundCompletion struct {
+	StatusBefore models.OrderStatus
+	StatusAfter  models.OrderStatus // 全部订单项和金额均已退还时订单转为已退款，否则不变
+	Result       *RefundResult
+}
+
+// RefundOrder 对订单的部分订单项发起退款，创建待审核的退款记录。
+// amount 为 0 时按订单项下单单价 × 数量计算，并以订单剩余可退金额为上限
+func (s *OrderService) RefundOrder(orderID uint, items []OrderRefundItemInput, amount int64, reason string, requester OrderRefundRequester) (*models.OrderRefund, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, bizerr.New("order.partialRefundReasonRequired", "Please provide a reason for the refund")
+	}
+	if len([]rune(reason)) > maxOrderRefundReasonLength {
+		return nil, bizerr.Newf("order.partialRefundReasonTooLong", "Refund reason cannot exceed %d characters", maxOrderRefundReasonLength).
+			WithParams(map[string]interface{}{"max": maxOrderRefundReasonLength})
+	}
+	if len(items) == 0 {
+		return nil, bizerr.New("order.partialRefundItemsRequired", "Select at least one item to refund")
+	}
+	if amount < 0 {
+		return nil, bizerr.New("order.partialRefundAmountInvalid", "Refund amount cannot be negative")
+	}
+
+	var refund *models.OrderRefund
+	err := s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		order, err := repository.NewOrderRepository(tx).FindByIDForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if !RefundableOrderStatuses[order.Status] {
+			return orderbiz.RefundStatusInvalid(order.Status)
+		}
+
+		// 未被拒绝的部分退款占用可退数量和金额
+		var existing []models.OrderRefund
+		if err := tx.Where("order_id = ? AND status <> ?", order.ID, models.OrderRefundStatusRejected).
+			Find(&existing).Error; err != nil {
+			return err
+		}
+		committedQuantities := make(map[int]int)
+		var committedAmount int64
+		for _, item := range existing {
+			committedAmount += item.Amount
+			for _, refundItem := range item.Items {
+				committedQuantities[refundItem.ItemIndex] += refundItem.Quantity
+			}
+		}
+
+		requested := make(map[int]int)
+		for _, input := range items {
+			if input.ItemIndex < 0 || input.ItemIndex >= len(order.Items) {
+				return bizerr.Newf("order.partialRefundItemInvalid", "Order item #%d does not exist", input.ItemIndex).
+					WithParams(map[string]interface{}{"index": input.ItemIndex})
+			}
+			if input.Quantity <= 0 {
+				return bizerr.New("order.partialRefundQuantityInvalid", "Refund quantity must be greater than 0")
+			}
+			requested[input.ItemIndex] += input.Quantity
+		}
+		indexes := make([]int, 0, len(requested))
+		for index := range requested {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+
+		refundItems := make([]models.OrderRefundItem, 0, len(indexes))
+		var itemsAmount int64
+		for _, index := range indexes {
+			item := order.Items[index]
+			available := item.Quantity - committedQuantities[index]
+			if requested[index] > available {
+				if available < 0 {
+					available = 0
+				}
+				return bizerr.Newf("order.partialRefundQuantityExceeded", "Only %d of %s can still be refunded", available, item.SKU).
+					WithParams(map[string]interface{}{"sku": item.SKU, "available": available})
+			}
+			itemsAmount += item.UnitPrice * int64(requested[index])
+			refundItems = append(refundItems, models.OrderRefundItem{
+				ItemIndex: index,
+				SKU:       item.SKU,
+				Name:      item.Name,
+				Quantity:  requested[index],
+			})
+		}
+
+		remaining := order.RefundableAmount() - committedAmount
+		if amount == 0 {
+			amount = itemsAmount
+			if amount > remaining {
+				amount = remaining
+			}
+		}
+		if amount <= 0 {
+			return bizerr.New("order.partialRefundAmountRequired", "Enter the amount to refund for the selected items")
+		}
+		if amount > remaining {
+			return bizerr.New("order.partialRefundAmountExceeded", "Refund amount exceeds the remaining refundable amount of this order")
+		}
+
+		refund = &models.OrderRefund{
+			OrderID:         order.ID,
+			OrderNo:         order.OrderNo,
+			UserID:          order.UserID,
+			Items:           refundItems,
+			Amount:          amount,
+			Currency:        order.Currency,
+			Reason:          reason,
+			Status:          models.OrderRefundStatusRequested,
+			RequestedBy:     requester.UserID,
+			RequestedByRole: requester.Role,
+		}
+		return tx.Create(refund).Error
+	})
+	if err != nil {
+		return nil, normalizeOrderLookupError(err)
+	}
+	return refund, nil
+}
+
+// GetOrderRefund 部分退款记录详情
+func (s *OrderService) GetOrderRefund(id uint) (*models.OrderRefund, error) {
+	refund, err := s.OrderRepo.FindOrderRefundByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderRefundNotFound
+		}
+		return nil, err
+	}
+	return refund, nil
+}
+
+// ListOrderRefunds 订单的部分退款记录
+func (s *OrderService) ListOrderRefunds(orderID uint) ([]models.OrderRefund, error) {
+	return s.OrderRepo.FindOrderRefunds(orderID)
+}
+
+// ListAllOrderRefunds 管理端部分退款队列
+func (s *OrderService) ListAllOrderRefunds(status string, page, limit int) ([]models.OrderRefund, int64, error) {
+	return s.OrderRepo.ListOrderRefunds(status, page, limit)
+}
+
+// transitionOrderRefund 将部分退款从 from 原子地转为 to，防止重复审核或重复退款
+func (s *OrderService) transitionOrderRefund(id uint, from, to models.OrderRefundStatus, updates map[string]interface{}) (*models.OrderRefund, error) {
+	refund, err := s.GetOrderRefund(id)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{"status": to}
+	for key, value := range updates {
+		values[key] = value
+	}
+	var rows int64
+	err = s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrderRefund{}).Where("id = ? AND status = ?", id, from).Updates(values)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, bizerr.Newf("order.partialRefundStatusInvalid", "This refund cannot be processed in its current status (%s)", refund.Status).
+			WithParams(map[string]interface{}{"status": refund.Status})
+	}
+	return s.GetOrderRefund(id)
+}
+
+func validateOrderRefundNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxOrderRefundNoteLength {
+		return "", bizerr.Newf("order.partialRefundNoteTooLong", "Review note cannot exceed %d characters", maxOrderRefundNoteLength).
+			WithParams(map[string]interface{}{"max": maxOrderRefundNoteLength})
+	}
+	return note, nil
+}
+
+// ApproveOrderRefund 批准部分退款，批准后由 RefundService.IssueOrderRefund 通过付款方式退款
+func (s *OrderService) ApproveOrderRefund(id, adminID uint, note string) (*models.OrderRefund, error) {
+	note, err := validateOrderRefundNote(note)
+	if err != nil {
+		return nil, err
+	}
+	return s.transitionOrderRefund(id, models.OrderRefundStatusRequested, models.OrderRefundStatusApproved, map[string]interface{}{
+		"reviewed_by": adminID,
+		"reviewed_at": time.Now(),
+		"review_note": note,
+	})
+}
+
+// RejectOrderRefund 拒绝部分退款，需填写拒绝原因
+func (s *OrderService) RejectOrderRefund(id, adminID uint, note string) (*models.OrderRefund, error) {
+	note, err := validateOrderRefundNote(note)
+	if err != nil {
+		return nil, err
+	}
+	if note == "" {
+		return nil, bizerr.New("order.partialRefundNoteRequired", "Please provide a reason for rejecting the refund")
+	}
+	return s.transitionOrderRefund(id, models.OrderRefundStatusRequested, models.OrderRefundStatusRejected, map[string]interface{}{
+		"reviewed_by": adminID,
+		"reviewed_at": time.Now(),
+		"review_note": note,
+	})
+}
+
+// completeOrderRefund 付款方式退款成功后登记结果、按订单项释放预留库存，
+// 全部订单项与金额都已退还时将订单转为已退款
+func (s *OrderService) completeOrderRefund(refund *models.OrderRefund, order *models.Order, result *RefundResult) (*OrderRefundCompletion, error) {
+	completion := &OrderRefundCompletion{
+		StatusBefore: order.Status,
+		StatusAfter:  order.Status,
+		Result:       result,
+	}
+
+	// 未发货的订单退款时释放对应订单项的预留库存
+	inventoryReleased := false
+	if order.Status == models.OrderStatusDraft || order.Status == models.OrderStatusPending || order.Status == models.OrderStatusNeedResubmit {
+		orderIDRef := order.ID
+		for _, item := range refund.Items {
+			inventoryID, exists := order.InventoryBindings[item.ItemIndex]
+			if !exists || inventoryID == 0 {
+				continue
+			}
+			if err := s.releaseReservedInventoryWithHook(&orderIDRef, order.UserID, order.OrderNo, inventoryID, item.Quantity, "partial_refund"); err != nil {
+				fmt.Printf("Warning: Order %s failed to release reserved inventory for partial refund #%d: %v\n", order.OrderNo, refund.ID, err)
+			}
+		}
+		inventoryReleased = true
+	}
+
+	err := s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.OrderRefund{}).Where("id = ?", refund.ID).Updates(map[string]interface{}{
+			"transaction_id":     result.TransactionID,
+			"payment_pending":    result.Pending,
+			"inventory_released": inventoryReleased,
+		}).Error; err != nil {
+			return err
+		}
+
+		var refunded []models.OrderRefund
+		if err := tx.Where("order_id = ? AND status = ?", order.ID, models.OrderRefundStatusRefunded).
+			Find(&refunded).Error; err != nil {
+			return err
+		}
+		quantities := make(map[int]int)
+		var refundedAmount int64
+		for _, item := range refunded {
+			refundedAmount += item.Amount
+			for _, refundItem := range item.Items {
+				quantities[refundItem.ItemIndex] += refundItem.Quantity
+			}
+		}
+		for index, item := range order.Items {
+			if quantities[index] < item.Quantity {
+				return nil
+			}
+		}
+		if refundedAmount < order.RefundableAmount() {
+			return nil
+		}
+
+		completion.StatusAfter = models.OrderStatusRefunded
+		updates := map[string]interface{}{"status": models.OrderStatusRefunded, "refunded_at": models.NowFunc()}
+		if result.Pending {
+			completion.StatusAfter = models.OrderStatusRefundPending
+			updates = map[string]interface{}{"status": models.OrderStatusRefundPending}
+		}
+		if err := tx.Model(order).Updates(updates).Error; err != nil {
+			return err
+		}
+		if completion.StatusAfter == models.OrderStatusRefunded {
+			if err := RecordVendorRefundTx(tx, order); err != nil {
+				return err
+			}
+			if err := RefundGiftCardTx(tx, order); err != nil {
+				return err
+			}
+			return CancelRevenueSchedulesTx(tx, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	refund.TransactionID = result.TransactionID
+	refund.PaymentPending = result.Pending
+	refund.InventoryReleased = inventoryReleased
+	order.Status = completion.StatusAfter
+	return completion, nil
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestOrderPartialRefundLifecycle(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	buyerID := uint(5)
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true, transaction_id: order.partial_refund ? "RF-PART" : "RF-FULL" } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo: "ORD-PARTIAL-1",
+		UserID:  &buyerID,
+		Status:  models.OrderStatusCompleted,
+		Items: []models.OrderItem{
+			{SKU: "MUG", Name: "Mug", Quantity: 2, UnitPrice: 300},
+			{SKU: "TEE", Name: "T-shirt", Quantity: 1, UnitPrice: 500},
+		},
+		TotalAmount: 1100,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+	refundSvc := NewRefundService(db, orderSvc, NewJSRuntimeService(db, &config.Config{}))
+	buyer := OrderRefundRequester{UserID: buyerID, Role: "user"}
+
+	_, err := orderSvc.RefundOrder(order.ID, nil, 0, "Broken", buyer)
+	requireOrderBizErr(t, err, "order.partialRefundItemsRequired")
+	_, err = orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 5, Quantity: 1}}, 0, "Broken", buyer)
+	requireOrderBizErr(t, err, "order.partialRefundItemInvalid")
+	_, err = orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 3}}, 0, "Broken", buyer)
+	requireOrderBizErr(t, err, "order.partialRefundQuantityExceeded")
+	_, err = orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 2000, "Broken", buyer)
+	requireOrderBizErr(t, err, "order.partialRefundAmountExceeded")
+
+	refund, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 0, "One mug arrived broken", buyer)
+	if err != nil {
+		t.Fatalf("request partial refund: %v", err)
+	}
+	if refund.Status != models.OrderRefundStatusRequested || refund.Amount != 300 || refund.Items[0].SKU != "MUG" {
+		t.Fatalf("unexpected partial refund: %+v", refund)
+	}
+
+	// 待审核的记录同样占用可退数量
+	_, err = orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 2}}, 0, "Both", buyer)
+	requireOrderBizErr(t, err, "order.partialRefundQuantityExceeded")
+
+	_, _, _, err = refundSvc.IssueOrderRefund(refund.ID, 1)
+	requireOrderBizErr(t, err, "order.partialRefundStatusInvalid")
+	if _, err := orderSvc.ApproveOrderRefund(refund.ID, 1, "ok"); err != nil {
+		t.Fatalf("approve partial refund: %v", err)
+	}
+	_, err = orderSvc.RejectOrderRefund(refund.ID, 1, "too late")
+	requireOrderBizErr(t, err, "order.partialRefundStatusInvalid")
+
+	issued, refundedOrder, completion, err := refundSvc.IssueOrderRefund(refund.ID, 1)
+	if err != nil {
+		t.Fatalf("issue partial refund: %v", err)
+	}
+	if issued.Status != models.OrderRefundStatusRefunded || issued.TransactionID != "RF-PART" || issued.RefundedAt == nil {
+		t.Fatalf("unexpected issued refund: %+v", issued)
+	}
+	if completion.StatusAfter != models.OrderStatusCompleted || refundedOrder.Status != models.OrderStatusCompleted {
+		t.Fatalf("partial refund must not change the order status, got %s", completion.StatusAfter)
+	}
+
+	rejected, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 1, Quantity: 1}}, 0, "Wrong size", buyer)
+	if err != nil {
+		t.Fatalf("request second partial refund: %v", err)
+	}
+	_, err = orderSvc.RejectOrderRefund(rejected.ID, 1, " ")
+	requireOrderBizErr(t, err, "order.partialRefundNoteRequired")
+	if _, err := orderSvc.RejectOrderRefund(rejected.ID, 1, "Worn item"); err != nil {
+		t.Fatalf("reject partial refund: %v", err)
+	}
+
+	// 被拒绝的记录释放占用，剩余订单项与金额全部退还后订单转为已退款
+	rest, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}, {ItemIndex: 1, Quantity: 1}}, 0, "Return the rest", OrderRefundRequester{UserID: 1, Role: "admin"})
+	if err != nil {
+		t.Fatalf("request remaining refund: %v", err)
+	}
+	if rest.Amount != 800 {
+		t.Fatalf("expected remaining refund amount 800, got %d", rest.Amount)
+	}
+	if _, err := orderSvc.ApproveOrderRefund(rest.ID, 1, ""); err != nil {
+		t.Fatalf("approve remaining refund: %v", err)
+	}
+	_, refundedOrder, completion, err = refundSvc.IssueOrderRefund(rest.ID, 1)
+	if err != nil {
+		t.Fatalf("issue remaining refund: %v", err)
+	}
+	if completion.StatusAfter != models.OrderStatusRefunded {
+		t.Fatalf("expected fully refunded order, got %s", completion.StatusAfter)
+	}
+	var stored models.Order
+	db.First(&stored, order.ID)
+	if stored.Status != models.OrderStatusRefunded || stored.RefundedAt == nil {
+		t.Fatalf("expected stored order to be refunded, got %s", stored.Status)
+	}
+	_, err = orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 0, "Again", buyer)
+	requireOrderBizErr(t, err, "order.refundStatusInvalid")
+}
+
+func TestFullRefundDeductsPartialRefunds(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true, transaction_id: order.order_no + "R" + order.refund_sequence } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:     "ORD-PARTIAL-2",
+		Status:      models.OrderStatusShipped,
+		Items:       []models.OrderItem{{SKU: "MUG", Name: "Mug", Quantity: 3, UnitPrice: 200}},
+		TotalAmount: 600,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+	refundSvc := NewRefundService(db, orderSvc, NewJSRuntimeService(db, &config.Config{}))
+
+	refund, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 150, "Goodwill", OrderRefundRequester{UserID: 1, Role: "admin"})
+	if err != nil {
+		t.Fatalf("request partial refund: %v", err)
+	}
+	if _, err := orderSvc.ApproveOrderRefund(refund.ID, 1, ""); err != nil {
+		t.Fatalf("approve partial refund: %v", err)
+	}
+	issued, _, _, err := refundSvc.IssueOrderRefund(refund.ID, 1)
+	if err != nil {
+		t.Fatalf("issue partial refund: %v", err)
+	}
+	if issued.TransactionID != "ORD-PARTIAL-2R1" {
+		t.Fatalf("expected first refund sequence, got %s", issued.TransactionID)
+	}
+
+	var reloaded models.Order
+	db.First(&reloaded, order.ID)
+	outcome, err := refundSvc.RefundOrder(&reloaded, "Cancel the rest")
+	if err != nil {
+		t.Fatalf("full refund: %v", err)
+	}
+	if outcome.RefundAmount != 450 {
+		t.Fatalf("expected full refund to deduct the partial refund, got %d", outcome.RefundAmount)
+	}
+	if outcome.Result.TransactionID != "ORD-PARTIAL-2R2" {
+		t.Fatalf("expected full refund to follow the partial refund sequence, got %s", outcome.Result.TransactionID)
+	}
+}
+
+func TestFullRefundSkipsGatewayWhenPartialRefundsCoverAmount(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true, transaction_id: "RF-PART" } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:     "ORD-PARTIAL-3",
+		Status:      models.OrderStatusShipped,
+		Items:       []models.OrderItem{{SKU: "MUG", Name: "Mug", Quantity: 3, UnitPrice: 200}},
+		TotalAmount: 600,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+	refundSvc := NewRefundService(db, orderSvc, NewJSRuntimeService(db, &config.Config{}))
+
+	refund, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 600, "Refund everything", OrderRefundRequester{UserID: 1, Role: "admin"})
+	if err != nil {
+		t.Fatalf("request partial refund: %v", err)
+	}
+	if _, err := orderSvc.ApproveOrderRefund(refund.ID, 1, ""); err != nil {
+		t.Fatalf("approve partial refund: %v", err)
+	}
+	if _, _, _, err := refundSvc.IssueOrderRefund(refund.ID, 1); err != nil {
+		t.Fatalf("issue partial refund: %v", err)
+	}
+
+	// 部分退款已退还全部金额后，任何付款方式调用都会失败
+	if err := db.Model(pm).Update("script", `function onRefund(order, config) { return { success: false, message: "gateway called" } }`).Error; err != nil {
+		t.Fatalf("update payment method script: %v", err)
+	}
+	var reloaded models.Order
+	db.First(&reloaded, order.ID)
+	if reloaded.Status != models.OrderStatusShipped {
+		t.Fatalf("expected order to stay shipped while items remain, got %s", reloaded.Status)
+	}
+	outcome, err := refundSvc.RefundOrder(&reloaded, "Close the order")
+	if err != nil {
+		t.Fatalf("full refund must not call the gateway: %v", err)
+	}
+	if outcome.RefundAmount != 0 || outcome.StatusAfter != models.OrderStatusRefunded {
+		t.Fatalf("unexpected outcome: amount=%d status=%s", outcome.RefundAmount, outcome.StatusAfter)
+	}
+	db.First(&reloaded, order.ID)
+	if reloaded.Status != models.OrderStatusRefunded || reloaded.RefundedAt == nil {
+		t.Fatalf("expected stored order to be refunded, got %s", reloaded.Status)
+	}
+}
+
+func TestFailedFullRefundKeepsItsSequenceAfterPartialRefund(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: false, message: "declined R" + order.refund_sequence } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:     "ORD-PARTIAL-4",
+		Status:      models.OrderStatusShipped,
+		Items:       []models.OrderItem{{SKU: "MUG", Name: "Mug", Quantity: 3, UnitPrice: 200}},
+		TotalAmount: 600,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+	refundSvc := NewRefundService(db, orderSvc, NewJSRuntimeService(db, &config.Config{}))
+
+	// 整单退款被网关拒绝后，序号已分配给这次退款
+	var reloaded models.Order
+	db.First(&reloaded, order.ID)
+	if _, err := refundSvc.RefundOrder(&reloaded, "Cancel"); err == nil || err.Error() != "declined R1" {
+		t.Fatalf("expected declined full refund with sequence 1, got %v", err)
+	}
+
+	if err := db.Model(pm).Update("script", `function onRefund(order, config) { return { success: true, transaction_id: order.order_no + "R" + order.refund_sequence } }`).Error; err != nil {
+		t.Fatalf("update payment method script: %v", err)
+	}
+	refund, err := orderSvc.RefundOrder(order.ID, []OrderRefundItemInput{{ItemIndex: 0, Quantity: 1}}, 150, "Goodwill", OrderRefundRequester{UserID: 1, Role: "admin"})
+	if err != nil {
+		t.Fatalf("request partial refund: %v", err)
+	}
+	if _, err := orderSvc.ApproveOrderRefund(refund.ID, 1, ""); err != nil {
+		t.Fatalf("approve partial refund: %v", err)
+	}
+	issued, _, _, err := refundSvc.IssueOrderRefund(refund.ID, 1)
+	if err != nil {
+		t.Fatalf("issue partial refund: %v", err)
+	}
+	if issued.TransactionID != "ORD-PARTIAL-4R2" {
+		t.Fatalf("partial refund must not reuse the failed full refund sequence, got %s", issued.TransactionID)
+	}
+
+	// 重试整单退款沿用首次分配的序号
+	db.First(&reloaded, order.ID)
+	outcome, err := refundSvc.RefundOrder(&reloaded, "Cancel the rest")
+	if err != nil {
+		t.Fatalf("retry full refund: %v", err)
+	}
+	if outcome.RefundAmount != 450 || outcome.Result.TransactionID != "ORD-PARTIAL-4R1" {
+		t.Fatalf("expected retried full refund to keep sequence 1, got amount=%d tx=%s", outcome.RefundAmount, outcome.Result.TransactionID)
+	}
+}
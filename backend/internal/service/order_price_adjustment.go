@@ -0,0 +1,332 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const maxOrderPriceAdjustmentTextLength = 1000
+
+var ErrOrderPriceAdjustmentNotFound = errors.New("order price adjustment not found")
+
+// OrderPriceAdjustmentInput 管理员调价请求：discount 在当前应付金额上减免 Amount，override 将应付金额改为 TotalAmount
+type OrderPriceAdjustmentInput struct {
+	Type        models.OrderPriceAdjustmentType
+	Amount      int64
+	TotalAmount int64
+	Reason      string
+	AdminID     uint
+}
+
+func (s *OrderService) priceAdjustmentApprovalThreshold() int64 {
+	if s.cfg == nil {
+		return 0
+	}
+	return s.cfg.Order.PriceAdjustment.ApprovalThreshold
+}
+
+// PriceAdjustmentNeedsApproval 应付金额减少超过 order.price_adjustment.approval_threshold 时需另一名管理员批准
+func (s *OrderService) PriceAdjustmentNeedsApproval(before, after int64) bool {
+	threshold := s.priceAdjustmentApprovalThreshold()
+	return threshold > 0 && before-after > threshold
+}
+
+// CheckDirectPriceUpdate 直接改价接口不经过审批，减少金额超过审批阈值时需改用调价申请
+func (s *OrderService) CheckDirectPriceUpdate(before, after int64) error {
+	if !s.PriceAdjustmentNeedsApproval(before, after) {
+		return nil
+	}
+	threshold := s.priceAdjustmentApprovalThreshold()
+	return bizerr.Newf("order.priceAdjustmentApprovalRequired", "Reductions above %d need a price adjustment approved by another administrator", threshold).
+		WithParams(map[string]interface{}{"threshold": threshold})
+}
+
+func validatePriceAdjustmentText(text string, required bool) (string, error) {
+	text = strings.TrimSpace(text)
+	if required && text == "" {
+		return "", bizerr.New("order.priceAdjustmentReasonRequired", "Please provide a reason for the price adjustment")
+	}
+	if len([]rune(text)) > maxOrderPriceAdjustmentTextLength {
+		return "", bizerr.Newf("order.priceAdjustmentReasonTooLong", "Reason cannot exceed %d characters", maxOrderPriceAdjustmentTextLength).
+			WithParams(map[string]interface{}{"max": maxOrderPriceAdjustmentTextLength})
+	}
+	return text, nil
+}
+
+// priceAdjustmentTarget 计算调价后的应付金额；付款方式手续费保留在订单上，调价后应付金额不能低于已计入的附加费
+func priceAdjustmentTarget(order *models.Order, input OrderPriceAdjustmentInput) (int64, error) {
+	var floor int64
+	if order.PaymentFee > 0 {
+		floor = order.PaymentFee
+	}
+	var after int64
+	switch input.Type {
+	case models.OrderPriceAdjustmentDiscount:
+		after = order.TotalAmount - input.Amount
+		if input.Amount <= 0 || after < floor {
+			return 0, bizerr.New("order.priceAdjustmentAmountInvalid", "Discount must be greater than 0 and cannot exceed the amount due")
+		}
+	case models.OrderPriceAdjustmentOverride:
+		after = input.TotalAmount
+		if after < floor || after == order.TotalAmount {
+			return 0, bizerr.New("order.priceAdjustmentAmountInvalid", "Enter a new amount due that differs from the current amount")
+		}
+	default:
+		return 0, bizerr.Newf("order.priceAdjustmentTypeInvalid", "Invalid price adjustment type: %s", input.Type).
+			WithParams(map[string]interface{}{"type": input.Type})
+	}
+	return after, nil
+}
+
+// RequestPriceAdjustment 对待付款订单发起折扣或改价；未超过审批阈值时立即生效，否则等待另一名管理员审批，
+// 同一订单同时只能有一笔待审批的调价
+func (s *OrderService) RequestPriceAdjustment(orderID uint, input OrderPriceAdjustmentInput) (*models.OrderPriceAdjustment, error) {
+	reason, err := validatePriceAdjustmentText(input.Reason, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var adjustment *models.OrderPriceAdjustment
+	var order *models.Order
+	err = s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		order, err = repository.NewOrderRepository(tx).FindByIDForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != models.OrderStatusPendingPayment {
+			return orderbiz.UpdatePriceStatusInvalid(order.Status)
+		}
+		var pending int64
+		if err := tx.Model(&models.OrderPriceAdjustment{}).
+			Where("order_id = ? AND status = ?", order.ID, models.OrderPriceAdjustmentPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return bizerr.New("order.priceAdjustmentPending", "This order already has a price adjustment waiting for approval")
+		}
+		after, err := priceAdjustmentTarget(order, input)
+		if err != nil {
+			return err
+		}
+
+		adjustment = &models.OrderPriceAdjustment{
+			OrderID:          order.ID,
+			OrderNo:          order.OrderNo,
+			Type:             input.Type,
+			Currency:         order.Currency,
+			TotalBefore:      order.TotalAmount,
+			TotalAfter:       after,
+			Reason:           reason,
+			Status:           models.OrderPriceAdjustmentPending,
+			RequiresApproval: s.PriceAdjustmentNeedsApproval(order.TotalAmount, after),
+			RequestedBy:      input.AdminID,
+		}
+		if !adjustment.RequiresApproval {
+			now := models.NowFunc()
+			adjustment.Status = models.OrderPriceAdjustmentApplied
+			adjustment.AppliedAt = &now
+		}
+		if err := tx.Create(adjustment).Error; err != nil {
+			return err
+		}
+		if adjustment.Status == models.OrderPriceAdjustmentApplied {
+			return applyOrderPriceAdjustmentTx(tx, order, adjustment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, normalizeOrderLookupError(err)
+	}
+	if adjustment.Status == models.OrderPriceAdjustmentApplied {
+		s.recordPriceAdjustmentEvent(order, adjustment, input.AdminID)
+	}
+	return adjustment, nil
+}
+
+// GetPriceAdjustment 调价记录详情
+func (s *OrderService) GetPriceAdjustment(id uint) (*models.OrderPriceAdjustment, error) {
+	adjustment, err := s.OrderRepo.FindOrderPriceAdjustmentByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderPriceAdjustmentNotFound
+		}
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// ListOrderPriceAdjustments 订单的调价记录
+func (s *OrderService) ListOrderPriceAdjustments(orderID uint) ([]models.OrderPriceAdjustment, error) {
+	return s.OrderRepo.FindOrderPriceAdjustments(orderID)
+}
+
+// ListAllPriceAdjustments 管理端调价审批队列
+func (s *OrderService) ListAllPriceAdjustments(status string, page, limit int) ([]models.OrderPriceAdjustment, int64, error) {
+	return s.OrderRepo.ListOrderPriceAdjustments(status, page, limit)
+}
+
+func priceAdjustmentNotPendingError(status models.OrderPriceAdjustmentStatus) error {
+	return bizerr.Newf("order.priceAdjustmentNotPending", "This price adjustment is already %s", status).
+		WithParams(map[string]interface{}{"status": status})
+}
+
+// ApprovePriceAdjustment 批准待审批的调价并立即生效；发起人不能批准自己的申请，
+// 订单已不是待付款或应付金额在申请后发生变化时不能生效，需拒绝后重新发起
+func (s *OrderService) ApprovePriceAdjustment(id, adminID uint, note string) (*models.OrderPriceAdjustment, error) {
+	note, err := validatePriceAdjustmentText(note, false)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := s.GetPriceAdjustment(id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment.Status != models.OrderPriceAdjustmentPending {
+		return nil, priceAdjustmentNotPendingError(adjustment.Status)
+	}
+	if adjustment.RequestedBy == adminID {
+		return nil, bizerr.New("order.priceAdjustmentSelfApproval", "A price adjustment must be approved by a different administrator")
+	}
+
+	var order *models.Order
+	err = s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		order, err = repository.NewOrderRepository(tx).FindByIDForUpdate(tx, adjustment.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != models.OrderStatusPendingPayment || order.TotalAmount != adjustment.TotalBefore {
+			return bizerr.New("order.priceAdjustmentStale", "The order has changed since this adjustment was requested, please reject it and request again")
+		}
+		now := models.NowFunc()
+		result := tx.Model(&models.OrderPriceAdjustment{}).
+			Where("id = ? AND status = ?", adjustment.ID, models.OrderPriceAdjustmentPending).
+			Updates(map[string]interface{}{
+				"status":      models.OrderPriceAdjustmentApplied,
+				"reviewed_by": adminID,
+				"reviewed_at": now,
+				"review_note": note,
+				"applied_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return priceAdjustmentNotPendingError(adjustment.Status)
+		}
+		return applyOrderPriceAdjustmentTx(tx, order, adjustment)
+	})
+	if err != nil {
+		return nil, normalizeOrderLookupError(err)
+	}
+	s.recordPriceAdjustmentEvent(order, adjustment, adminID)
+	return s.GetPriceAdjustment(id)
+}
+
+// RejectPriceAdjustment 拒绝待审批的调价，需填写拒绝原因
+func (s *OrderService) RejectPriceAdjustment(id, adminID uint, note string) (*models.OrderPriceAdjustment, error) {
+	note, err := validatePriceAdjustmentText(note, false)
+	if err != nil {
+		return nil, err
+	}
+	if note == "" {
+		return nil, bizerr.New("order.priceAdjustmentNoteRequired", "Please provide a reason for rejecting the price adjustment")
+	}
+	adjustment, err := s.GetPriceAdjustment(id)
+	if err != nil {
+		return nil, err
+	}
+	var rows int64
+	err = s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrderPriceAdjustment{}).
+			Where("id = ? AND status = ?", id, models.OrderPriceAdjustmentPending).
+			Updates(map[string]interface{}{
+				"status":      models.OrderPriceAdjustmentRejected,
+				"reviewed_by": adminID,
+				"reviewed_at": time.Now(),
+				"review_note": note,
+			})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, priceAdjustmentNotPendingError(adjustment.Status)
+	}
+	return s.GetPriceAdjustment(id)
+}
+
+// applyOrderPriceAdjustmentTx 将调价写入订单：更新应付金额并累计手动调价差额，清除按旧金额生成的付款信息
+func applyOrderPriceAdjustmentTx(tx *gorm.DB, order *models.Order, adjustment *models.OrderPriceAdjustment) error {
+	manualAdjustment := order.ManualAdjustment + adjustment.Amount()
+	if err := tx.Model(&models.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+		"total_amount":      adjustment.TotalAfter,
+		"manual_adjustment": manualAdjustment,
+	}).Error; err != nil {
+		return err
+	}
+	if _, err := ResetOrderPaymentArtifactsTx(tx, order.ID); err != nil {
+		return err
+	}
+	order.TotalAmount = adjustment.TotalAfter
+	order.ManualAdjustment = manualAdjustment
+	return nil
+}
+
+// ResetOrderPaymentArtifactsTx 订单金额变化后清除付款方式缓存的付款卡片与按金额生成的存储数据，返回是否有数据被清除
+func ResetOrderPaymentArtifactsTx(tx *gorm.DB, orderID uint) (bool, error) {
+	var opm models.OrderPaymentMethod
+	if err := tx.Where("order_id = ?", orderID).First(&opm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	cacheResult := tx.Model(&models.OrderPaymentMethod{}).
+		Where("order_id = ?", orderID).
+		Updates(map[string]interface{}{
+			"payment_data":       "",
+			"payment_card_cache": "",
+			"cache_expires_at":   nil,
+		})
+	if cacheResult.Error != nil {
+		return false, cacheResult.Error
+	}
+
+	prefix := "order_" + strconv.FormatUint(uint64(orderID), 10)
+	storageKeys := []string{prefix + "_amount", prefix + "_time", prefix + "_address"}
+	deleteResult := tx.
+		Where("payment_method_id = ? AND key IN ?", opm.PaymentMethodID, storageKeys).
+		Delete(&models.PaymentMethodStorageEntry{})
+	if deleteResult.Error != nil {
+		return false, deleteResult.Error
+	}
+	return cacheResult.RowsAffected > 0 || deleteResult.RowsAffected > 0, nil
+}
+
+func (s *OrderService) recordPriceAdjustmentEvent(order *models.Order, adjustment *models.OrderPriceAdjustment, adminID uint) {
+	event := newOrderEvent(order, models.OrderEventTypePayment, "order.price_adjust", map[string]interface{}{
+		"adjustment_id":      adjustment.ID,
+		"type":               adjustment.Type,
+		"amount_minor":       adjustment.Amount(),
+		"total_before_minor": adjustment.TotalBefore,
+		"total_after_minor":  adjustment.TotalAfter,
+		"reason":             adjustment.Reason,
+		"requested_by":       adjustment.RequestedBy,
+	})
+	event.OperatorType = models.OrderEventOperatorAdmin
+	event.OperatorID = &adminID
+	event.Internal = true
+	recordOrderEvent(s.OrderRepo, event)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/models"
+)
+
+func TestOrderPriceAdjustmentApprovalFlow(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.OrderEvent{},
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.OrderPriceAdjustment{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	orderSvc.cfg.Order.PriceAdjustment.ApprovalThreshold = 500
+
+	order := &models.Order{
+		OrderNo:     "ORD-ADJUST-1",
+		Status:      models.OrderStatusPendingPayment,
+		TotalAmount: 5200,
+		PaymentFee:  200,
+		Currency:    "USD",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	requester := uint(1)
+	discount := func(amount int64, reason string) (*models.OrderPriceAdjustment, error) {
+		return orderSvc.RequestPriceAdjustment(order.ID, OrderPriceAdjustmentInput{
+			Type: models.OrderPriceAdjustmentDiscount, Amount: amount, Reason: reason, AdminID: requester,
+		})
+	}
+
+	_, err := discount(100, " ")
+	requireOrderBizErr(t, err, "order.priceAdjustmentReasonRequired")
+	// 手续费保留在订单上，减免后应付金额不能低于附加费
+	_, err = discount(5100, "Goodwill")
+	requireOrderBizErr(t, err, "order.priceAdjustmentAmountInvalid")
+	_, err = orderSvc.RequestPriceAdjustment(order.ID, OrderPriceAdjustmentInput{Type: "markup", Reason: "x", AdminID: requester})
+	requireOrderBizErr(t, err, "order.priceAdjustmentTypeInvalid")
+
+	// 未超过阈值的减免立即生效
+	applied, err := discount(300, "Late delivery")
+	if err != nil || applied.Status != models.OrderPriceAdjustmentApplied || applied.RequiresApproval {
+		t.Fatalf("expected immediate adjustment, got %+v err=%v", applied, err)
+	}
+	var reloaded models.Order
+	db.First(&reloaded, order.ID)
+	if reloaded.TotalAmount != 4900 || reloaded.ManualAdjustment != -300 || reloaded.PaymentFee != 200 {
+		t.Fatalf("unexpected order after discount: total=%d manual=%d fee=%d", reloaded.TotalAmount, reloaded.ManualAdjustment, reloaded.PaymentFee)
+	}
+	if err := orderSvc.CheckDirectPriceUpdate(4900, 4000); err == nil {
+		t.Fatal("expected direct price update above threshold to be rejected")
+	}
+
+	// 超过阈值的改价需另一名管理员批准
+	pending, err := orderSvc.RequestPriceAdjustment(order.ID, OrderPriceAdjustmentInput{
+		Type: models.OrderPriceAdjustmentOverride, TotalAmount: 3000, Reason: "Price match", AdminID: requester,
+	})
+	if err != nil || pending.Status != models.OrderPriceAdjustmentPending || !pending.RequiresApproval {
+		t.Fatalf("expected pending adjustment, got %+v err=%v", pending, err)
+	}
+	_, err = discount(100, "Another")
+	requireOrderBizErr(t, err, "order.priceAdjustmentPending")
+	_, err = orderSvc.ApprovePriceAdjustment(pending.ID, requester, "")
+	requireOrderBizErr(t, err, "order.priceAdjustmentSelfApproval")
+	_, err = orderSvc.RejectPriceAdjustment(pending.ID, 2, "")
+	requireOrderBizErr(t, err, "order.priceAdjustmentNoteRequired")
+
+	approved, err := orderSvc.ApprovePriceAdjustment(pending.ID, 2, "OK")
+	if err != nil || approved.Status != models.OrderPriceAdjustmentApplied || approved.ReviewedBy == nil || *approved.ReviewedBy != 2 {
+		t.Fatalf("approve adjustment failed: %+v err=%v", approved, err)
+	}
+	db.First(&reloaded, order.ID)
+	if reloaded.TotalAmount != 3000 || reloaded.ManualAdjustment != -2200 {
+		t.Fatalf("unexpected order after override: total=%d manual=%d", reloaded.TotalAmount, reloaded.ManualAdjustment)
+	}
+	_, err = orderSvc.RejectPriceAdjustment(pending.ID, 2, "Too late")
+	requireOrderBizErr(t, err, "order.priceAdjustmentNotPending")
+
+	// 申请后订单金额变化时不能生效
+	stale, err := discount(1000, "Bulk order")
+	if err != nil || stale.Status != models.OrderPriceAdjustmentPending {
+		t.Fatalf("expected pending adjustment, got %+v err=%v", stale, err)
+	}
+	db.Model(&models.Order{}).Where("id = ?", order.ID).Update("total_amount", 3100)
+	_, err = orderSvc.ApprovePriceAdjustment(stale.ID, 2, "")
+	requireOrderBizErr(t, err, "order.priceAdjustmentStale")
+
+	adjustments, err := orderSvc.ListOrderPriceAdjustments(order.ID)
+	if err != nil || len(adjustments) != 3 {
+		t.Fatalf("expected 3 adjustments, got %d err=%v", len(adjustments), err)
+	}
+}
@@ -37,3 +37,25 @@ func (s *OrderService) loadProductsForOrderItems(items []models.OrderItem) (map[
 	}
 	return s.productRepo.FindBySKUs(collectOrderItemSKUs(items))
 }
+
+// stampOrderItemVendors 记录下单时的商品单价、所属商家与收入确认方式，供商家分账和收入确认使用；
+// keepUnitPrice 为 true 时保留调用方已设置的单价（管理员手动定价）
+func stampOrderItemVendors(items []models.OrderItem, productBySKU map[string]*models.Product, keepUnitPrice bool) {
+	for i := range items {
+		product := productBySKU[strings.TrimSpace(items[i].SKU)]
+		if product == nil {
+			continue
+		}
+		if !keepUnitPrice {
+			items[i].UnitPrice = product.Price
+		}
+		if product.VendorID != nil {
+			vendorID := *product.VendorID
+			items[i].VendorID = &vendorID
+		}
+		if product.RevenueRecognition != "" && product.ServicePeriodMonths > 0 {
+			items[i].RevenueRecognition = product.RevenueRecognition
+			items[i].ServicePeriodMonths = product.ServicePeriodMonths
+		}
+	}
+}
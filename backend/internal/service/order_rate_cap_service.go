@@ -0,0 +1,285 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/cache"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const (
+	orderRateCapWindow          = time.Hour
+	orderRateCapProductsRefresh = 30 * time.Second
+	maxOrderRateCapDeviceLength = 256
+)
+
+// 下单频率限制维度
+const (
+	OrderRateCapScopeUser   = "user"
+	OrderRateCapScopeIP     = "ip"
+	OrderRateCapScopeDevice = "device"
+)
+
+var (
+	// 所有计数都未达上限时才整体加一，任一维度超限返回其序号与剩余窗口，保证并发下不会超发
+	orderRateCapAcquireScript = redis.NewScript(`
+for i = 1, #KEYS do
+  local current = tonumber(redis.call('GET', KEYS[i]) or '0')
+  if current >= tonumber(ARGV[i + 1]) then
+    return {i, redis.call('PTTL', KEYS[i])}
+  end
+end
+for i = 1, #KEYS do
+  if redis.call('INCR', KEYS[i]) == 1 then
+    redis.call('PEXPIRE', KEYS[i], ARGV[1])
+  end
+end
+return {0, 0}
+`)
+	// 下单失败时归还名额，计数已过期的键不再处理
+	orderRateCapReleaseScript = redis.NewScript(`
+for i = 1, #KEYS do
+  local current = tonumber(redis.call('GET', KEYS[i]) or '0')
+  if current > 0 then
+    redis.call('DECR', KEYS[i])
+  end
+end
+return 0
+`)
+)
+
+// OrderRateCapSettings 管理端查看的商品下单频率限制
+type OrderRateCapSettings struct {
+	ProductID       uint `json:"product_id"`
+	OrderRateCap    bool `json:"order_rate_cap"`
+	Active          bool `json:"active"` // 全局开关与 Redis 均可用
+	PerUserHourly   int  `json:"per_user_hourly"`
+	PerIPHourly     int  `json:"per_ip_hourly"`
+	PerDeviceHourly int  `json:"per_device_hourly"`
+}
+
+type orderRateCapProduct struct {
+	ID   uint
+	Name string
+}
+
+type orderRateCapCounter struct {
+	key   string
+	scope string
+	limit int
+	name  string
+	id    uint
+}
+
+// OrderRateCapService 指定商品的下单频率限制
+// 按用户、IP、设备指纹统计每小时下单次数，防止黄牛批量创建待付款订单占满库存预留
+type OrderRateCapService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	productsMu    sync.RWMutex
+	productsBySKU map[string]orderRateCapProduct
+	productsAt    time.Time
+}
+
+// NewOrderRateCapService 创建下单频率限制服务
+func NewOrderRateCapService(db *gorm.DB, cfg *config.Config) *OrderRateCapService {
+	return &OrderRateCapService{db: db, cfg: cfg}
+}
+
+// Enabled 是否启用（全局开关开启、至少配置一个维度且 Redis 已初始化）
+func (s *OrderRateCapService) Enabled() bool {
+	if s == nil || s.cfg == nil || cache.RedisClient == nil {
+		return false
+	}
+	caps := s.cfg.Order.OrderRateCap
+	return caps.Enabled && (caps.PerUserHourly > 0 || caps.PerIPHourly > 0 || caps.PerDeviceHourly > 0)
+}
+
+func orderRateCapKey(productID uint, scope, subject string) string {
+	return fmt.Sprintf("order_rate_cap:%d:%s:%s", productID, scope, subject)
+}
+
+// NormalizeDeviceFingerprint 将前端提交的设备指纹统一为定长摘要，避免任意长度的值占用 Redis 键空间
+func NormalizeDeviceFingerprint(raw string) string {
+	fingerprint := strings.TrimSpace(raw)
+	if fingerprint == "" {
+		return ""
+	}
+	if len(fingerprint) > maxOrderRateCapDeviceLength {
+		fingerprint = fingerprint[:maxOrderRateCapDeviceLength]
+	}
+	sum := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RefreshProducts 重新加载开启下单频率限制的商品
+func (s *OrderRateCapService) RefreshProducts() error {
+	var products []models.Product
+	if err := s.db.Model(&models.Product{}).Select("id, sku, name").
+		Where("order_rate_cap = ?", true).Find(&products).Error; err != nil {
+		return err
+	}
+	bySKU := make(map[string]orderRateCapProduct, len(products))
+	for _, product := range products {
+		bySKU[product.SKU] = orderRateCapProduct{ID: product.ID, Name: product.Name}
+	}
+	s.productsMu.Lock()
+	s.productsBySKU = bySKU
+	s.productsAt = time.Now()
+	s.productsMu.Unlock()
+	return nil
+}
+
+func (s *OrderRateCapService) cappedProducts() map[string]orderRateCapProduct {
+	s.productsMu.RLock()
+	stale := s.productsBySKU == nil || time.Since(s.productsAt) > orderRateCapProductsRefresh
+	s.productsMu.RUnlock()
+	if stale {
+		if err := s.RefreshProducts(); err != nil {
+			log.Printf("order rate cap product refresh failed: %v", err)
+		}
+	}
+	s.productsMu.RLock()
+	defer s.productsMu.RUnlock()
+	return s.productsBySKU
+}
+
+// RequiresOrderRateCap 是否存在受限商品，供下单中间件跳过无关请求
+func (s *OrderRateCapService) RequiresOrderRateCap() bool {
+	return s.Enabled() && len(s.cappedProducts()) > 0
+}
+
+func (s *OrderRateCapService) counters(userID uint, ip, device string, skus []string) []orderRateCapCounter {
+	caps := s.cfg.Order.OrderRateCap
+	products := s.cappedProducts()
+	seen := make(map[uint]bool)
+	var counters []orderRateCapCounter
+	for _, sku := range skus {
+		product, ok := products[sku]
+		if !ok || seen[product.ID] {
+			continue
+		}
+		seen[product.ID] = true
+		add := func(scope, subject string, limit int) {
+			if limit <= 0 || subject == "" {
+				return
+			}
+			counters = append(counters, orderRateCapCounter{
+				key:   orderRateCapKey(product.ID, scope, subject),
+				scope: scope,
+				limit: limit,
+				name:  product.Name,
+				id:    product.ID,
+			})
+		}
+		if userID > 0 {
+			add(OrderRateCapScopeUser, fmt.Sprintf("%d", userID), caps.PerUserHourly)
+		}
+		add(OrderRateCapScopeIP, ip, caps.PerIPHourly)
+		add(OrderRateCapScopeDevice, NormalizeDeviceFingerprint(device), caps.PerDeviceHourly)
+	}
+	return counters
+}
+
+// AcquireOrderSlots 为本次下单占用各维度的小时名额，返回的 release 用于下单失败时归还
+// 设备指纹由前端通过请求头提交，未提交时仅按用户和IP限制；Redis 异常时放行，避免限流故障影响正常下单
+func (s *OrderRateCapService) AcquireOrderSlots(userID uint, ip, device string, skus []string) (func(), error) {
+	noop := func() {}
+	if !s.Enabled() {
+		return noop, nil
+	}
+	counters := s.counters(userID, ip, device, skus)
+	if len(counters) == 0 {
+		return noop, nil
+	}
+
+	keys := make([]string, 0, len(counters))
+	args := make([]interface{}, 0, len(counters)+1)
+	args = append(args, orderRateCapWindow.Milliseconds())
+	for _, counter := range counters {
+		keys = append(keys, counter.key)
+		args = append(args, counter.limit)
+	}
+	ctx := cache.RedisClient.Context()
+	result, err := orderRateCapAcquireScript.Run(ctx, cache.RedisClient, keys, args...).Int64Slice()
+	if err != nil {
+		log.Printf("order rate cap acquire failed: %v", err)
+		return noop, nil
+	}
+	if len(result) == 2 && result[0] > 0 && int(result[0]) <= len(counters) {
+		counter := counters[result[0]-1]
+		retryAfter := time.Duration(result[1]) * time.Millisecond
+		if retryAfter <= 0 {
+			retryAfter = orderRateCapWindow
+		}
+		minutes := int((retryAfter + time.Minute - 1) / time.Minute)
+		return noop, bizerr.Newf("order.rateCapExceeded",
+			"Too many orders for %s, please try again in %d minutes", counter.name, minutes).
+			WithParams(map[string]interface{}{
+				"product":    counter.name,
+				"product_id": counter.id,
+				"scope":      counter.scope,
+				"limit":      counter.limit,
+				"minutes":    minutes,
+			})
+	}
+
+	return func() {
+		if err := orderRateCapReleaseScript.Run(cache.RedisClient.Context(), cache.RedisClient, keys).Err(); err != nil && err != redis.Nil {
+			log.Printf("order rate cap release failed: %v", err)
+		}
+	}, nil
+}
+
+// Settings 商品下单频率限制概况
+func (s *OrderRateCapService) Settings(productID uint) (*OrderRateCapSettings, error) {
+	var product models.Product
+	if err := s.db.Select("id, order_rate_cap").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	caps := s.cfg.Order.OrderRateCap
+	return &OrderRateCapSettings{
+		ProductID:       product.ID,
+		OrderRateCap:    product.OrderRateCap,
+		Active:          s.Enabled(),
+		PerUserHourly:   caps.PerUserHourly,
+		PerIPHourly:     caps.PerIPHourly,
+		PerDeviceHourly: caps.PerDeviceHourly,
+	}, nil
+}
+
+// SetProductOrderRateCap 开关商品下单频率限制
+func (s *OrderRateCapService) SetProductOrderRateCap(productID uint, enabled bool) (*models.Product, error) {
+	var product models.Product
+	if err := s.db.Select("id, sku, name, order_rate_cap").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if product.OrderRateCap != enabled {
+		if err := s.db.Model(&models.Product{}).Where("id = ?", product.ID).
+			Update("order_rate_cap", enabled).Error; err != nil {
+			return nil, err
+		}
+		product.OrderRateCap = enabled
+	}
+	if err := s.RefreshProducts(); err != nil {
+		log.Printf("order rate cap product refresh failed: %v", err)
+	}
+	return &product, nil
+}
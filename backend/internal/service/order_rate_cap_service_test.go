@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestOrderRateCapLimitsUserIPAndDeviceAtomically(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		if cache.RedisClient != nil {
+			_ = cache.RedisClient.Close()
+		}
+		cache.RedisClient = previousClient
+	}()
+
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Product{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	drop := &models.Product{SKU: "DROP-1", Name: "Drop", Status: models.ProductStatusActive}
+	regular := &models.Product{SKU: "REG-1", Name: "Regular", Status: models.ProductStatusActive}
+	for _, product := range []*models.Product{drop, regular} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Order.OrderRateCap = config.OrderRateCapConfig{Enabled: true, PerUserHourly: 2, PerIPHourly: 3, PerDeviceHourly: 1}
+	svc := NewOrderRateCapService(db, cfg)
+	if svc.RequiresOrderRateCap() {
+		t.Fatalf("no product is capped yet")
+	}
+	if _, err := svc.SetProductOrderRateCap(drop.ID, true); err != nil {
+		t.Fatalf("enable order rate cap: %v", err)
+	}
+	if !svc.RequiresOrderRateCap() {
+		t.Fatalf("expected capped product to be picked up")
+	}
+
+	if _, err := svc.AcquireOrderSlots(1, "10.0.0.1", "device-a", []string{"DROP-1", "DROP-1"}); err != nil {
+		t.Fatalf("first order: %v", err)
+	}
+	// 同一设备第二单被设备维度拦截，且拦截时不占用用户与IP名额
+	_, err = svc.AcquireOrderSlots(1, "10.0.0.1", "device-a", []string{"DROP-1"})
+	requireBizErr(t, err, "order.rateCapExceeded")
+	if _, err := svc.AcquireOrderSlots(1, "10.0.0.1", "device-b", []string{"DROP-1"}); err != nil {
+		t.Fatalf("second order from another device: %v", err)
+	}
+	_, err = svc.AcquireOrderSlots(1, "10.0.0.2", "device-c", []string{"DROP-1"})
+	requireBizErr(t, err, "order.rateCapExceeded")
+
+	// 下单失败归还名额
+	release, err := svc.AcquireOrderSlots(2, "10.0.0.1", "device-d", []string{"DROP-1"})
+	if err != nil {
+		t.Fatalf("third order from shared ip: %v", err)
+	}
+	_, err = svc.AcquireOrderSlots(3, "10.0.0.1", "device-e", []string{"DROP-1"})
+	requireBizErr(t, err, "order.rateCapExceeded")
+	release()
+	if _, err := svc.AcquireOrderSlots(3, "10.0.0.1", "device-e", []string{"DROP-1"}); err != nil {
+		t.Fatalf("expected released slot to be reusable: %v", err)
+	}
+
+	// 未标记的商品不受限制，计数窗口过期后恢复
+	if _, err := svc.AcquireOrderSlots(1, "10.0.0.1", "device-a", []string{"REG-1"}); err != nil {
+		t.Fatalf("regular product must not be capped: %v", err)
+	}
+	mr.FastForward(orderRateCapWindow)
+	if _, err := svc.AcquireOrderSlots(1, "10.0.0.1", "device-a", []string{"DROP-1"}); err != nil {
+		t.Fatalf("expected caps to reset after window: %v", err)
+	}
+}
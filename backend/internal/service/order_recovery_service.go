@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	// 每轮最多提醒的订单数，避免积压时一次性发出大量邮件
+	orderRecoveryBatchSize       = 100
+	defaultOrderRecoveryCampaign = "order_recovery"
+)
+
+// DefaultOrderRecoveryIntervals 未配置时的提醒节奏（下单后第 1、24 小时）
+var DefaultOrderRecoveryIntervals = []int{1, 24}
+
+// OrderRecoveryService 未完成订单挽回提醒服务
+// 按 order.recovery.intervals_hours 对草稿与待付款订单发送继续填写或付款的提醒，进度记录在订单上
+type OrderRecoveryService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	emailService  *EmailService
+	smsService    *SMSService
+	paymentLinks  *PaymentLinkService
+	checkInterval time.Duration
+}
+
+// NewOrderRecoveryService 创建订单挽回提醒服务
+func NewOrderRecoveryService(db *gorm.DB, cfg *config.Config, emailService *EmailService, smsService *SMSService, paymentLinks *PaymentLinkService) *OrderRecoveryService {
+	return &OrderRecoveryService{
+		db:            db,
+		cfg:           cfg,
+		emailService:  emailService,
+		smsService:    smsService,
+		paymentLinks:  paymentLinks,
+		checkInterval: 10 * time.Minute,
+	}
+}
+
+// RegisterJobs 注册订单挽回提醒任务
+func (s *OrderRecoveryService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "order_recovery_reminder",
+		Description: "Remind customers to finish draft orders and pay pending orders before they expire",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			sent, err := s.SendDueReminders(time.Now())
+			if sent > 0 {
+				logger.LogSystemOperation(s.db, "order_recovery_reminder_sent", "system", nil, map[string]interface{}{
+					"count":    sent,
+					"campaign": s.campaign(),
+				})
+			}
+			return err
+		},
+	})
+}
+
+// intervals 返回升序、去重的提醒时间点（小时）
+func (s *OrderRecoveryService) intervals() []int {
+	configured := s.cfg.Order.Recovery.IntervalsHours
+	if len(configured) == 0 {
+		return DefaultOrderRecoveryIntervals
+	}
+	hours := make([]int, 0, len(configured))
+	for _, h := range configured {
+		if h > 0 && !slices.Contains(hours, h) {
+			hours = append(hours, h)
+		}
+	}
+	sort.Ints(hours)
+	return hours
+}
+
+func (s *OrderRecoveryService) campaign() string {
+	if campaign := strings.TrimSpace(s.cfg.Order.Recovery.Campaign); campaign != "" {
+		return campaign
+	}
+	return defaultOrderRecoveryCampaign
+}
+
+func (s *OrderRecoveryService) smsEnabled() bool {
+	return s.cfg.Order.Recovery.SendSMS && s.cfg.SMS.Enabled && s.smsService != nil
+}
+
+// SendDueReminders 给已到提醒时间的未完成订单发送提醒，返回提醒的订单数
+func (s *OrderRecoveryService) SendDueReminders(now time.Time) (int, error) {
+	if s.cfg == nil || !s.cfg.Order.Recovery.Enabled {
+		return 0, nil
+	}
+	emailEnabled := s.emailService != nil && s.emailService.IsEnabled()
+	if !emailEnabled && !s.smsEnabled() {
+		return 0, nil
+	}
+
+	orders, err := s.dueOrders(now)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range orders {
+		reminded, err := s.remind(&orders[i], now)
+		if err != nil {
+			log.Printf("[OrderRecovery] Error reminding order %s: %v", orders[i].OrderNo, err)
+			continue
+		}
+		if reminded {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// dueOrders 查找下一次提醒已到期的订单：第 n 次提醒在下单后 intervals[n] 小时发送
+func (s *OrderRecoveryService) dueOrders(now time.Time) ([]models.Order, error) {
+	var due []models.Order
+	for step, hours := range s.intervals() {
+		remaining := orderRecoveryBatchSize - len(due)
+		if remaining <= 0 {
+			break
+		}
+		var orders []models.Order
+		// 已选择付款方式并进入轮询的订单可能正在外部付款，试用转正订单由试用任务单独提醒
+		if err := s.db.Where("status IN ?", []models.OrderStatus{models.OrderStatusDraft, models.OrderStatusPendingPayment}).
+			Where("recovery_reminders_sent = ? AND created_at <= ?", step, now.Add(-time.Duration(hours)*time.Hour)).
+			Where("source <> ?", OrderSourceTrialConversion).
+			Where("NOT EXISTS (SELECT 1 FROM payment_polling_tasks ppt WHERE ppt.order_id = orders.id)").
+			Order("id ASC").
+			Limit(remaining).
+			Find(&orders).Error; err != nil {
+			return nil, fmt.Errorf("query recovery orders: %w", err)
+		}
+		for i := range orders {
+			if deadline := s.deadline(&orders[i]); deadline == nil || orders[i].CreatedAt.Add(time.Duration(hours)*time.Hour).Before(*deadline) {
+				due = append(due, orders[i])
+			}
+		}
+	}
+	return due, nil
+}
+
+// deadline 订单失效时间：待付款订单为自动取消时间，草稿订单为表单过期或草稿清理时间中较早者
+func (s *OrderRecoveryService) deadline(order *models.Order) *time.Time {
+	var deadline *time.Time
+	earlier := func(t time.Time) {
+		if deadline == nil || t.Before(*deadline) {
+			deadline = &t
+		}
+	}
+	if order.Status == models.OrderStatusPendingPayment {
+		autoCancelHours := s.cfg.Order.AutoCancelHours
+		if autoCancelHours <= 0 {
+			autoCancelHours = defaultAutoCancelHours
+		}
+		earlier(order.CreatedAt.Add(time.Duration(autoCancelHours) * time.Hour))
+		return deadline
+	}
+	if order.FormExpiresAt != nil {
+		earlier(*order.FormExpiresAt)
+	}
+	if days := s.cfg.Order.DraftCleanup.RetentionDays; days > 0 {
+		earlier(order.CreatedAt.AddDate(0, 0, days))
+	}
+	return deadline
+}
+
+// remind 推进提醒进度后发送提醒；积压时跳过已错过的提醒点，只发送一次
+func (s *OrderRecoveryService) remind(order *models.Order, now time.Time) (bool, error) {
+	resumeURL, err := s.resumeURL(order)
+	if err != nil {
+		return false, err
+	}
+	if resumeURL == "" {
+		return false, nil
+	}
+
+	step := order.RecoveryRemindersSent
+	reached := step + 1
+	for _, hours := range s.intervals()[reached:] {
+		if order.CreatedAt.Add(time.Duration(hours) * time.Hour).After(now) {
+			break
+		}
+		reached++
+	}
+	// 以发送进度为条件更新，避免多实例或并发重复提醒
+	result := s.db.Model(&models.Order{}).
+		Where("id = ? AND status = ? AND recovery_reminders_sent = ?", order.ID, order.Status, step).
+		Updates(map[string]interface{}{
+			"recovery_reminders_sent":   reached,
+			"last_recovery_reminder_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	order.RecoveryRemindersSent = reached
+
+	deadline := s.deadline(order)
+	campaign := s.campaign()
+	draft := order.Status == models.OrderStatusDraft
+	sent := false
+	if s.emailService != nil && s.emailService.IsEnabled() && order.EmailNotificationsEnabled {
+		if to := firstNonEmpty(order.UserEmail, order.ReceiverEmail); to != "" {
+			link := withRecoveryAttribution(resumeURL, campaign, "email", reached)
+			if err := s.emailService.SendOrderRecoveryEmail(order, to, link, campaign, deadline); err != nil {
+				log.Printf("[OrderRecovery] Failed to send email: order=%s err=%v", order.OrderNo, err)
+			} else {
+				sent = true
+			}
+		}
+	}
+	if s.smsEnabled() && strings.TrimSpace(order.ReceiverPhone) != "" {
+		link := withRecoveryAttribution(resumeURL, campaign, "sms", reached)
+		if err := s.smsService.SendOrderRecoverySMS(order.ReceiverPhone, order.PhoneCode, order.OrderNo, link, draft, order.UserID); err != nil {
+			log.Printf("[OrderRecovery] Failed to send SMS: order=%s err=%v", order.OrderNo, err)
+		} else {
+			sent = true
+		}
+	}
+
+	logger.LogSystemOperation(s.db, "order_recovery_reminder", "order", &order.ID, map[string]interface{}{
+		"order_no": order.OrderNo,
+		"status":   order.Status,
+		"step":     reached,
+		"campaign": campaign,
+		"sent":     sent,
+	})
+	return sent, nil
+}
+
+// resumeURL 继续下单的链接：草稿订单指向收货信息表单，待付款订单优先使用免登录付款链接
+func (s *OrderRecoveryService) resumeURL(order *models.Order) (string, error) {
+	baseURL := strings.TrimRight(s.cfg.App.URL, "/")
+	if order.Status == models.OrderStatusDraft {
+		if order.FormToken == nil || *order.FormToken == "" {
+			return "", nil
+		}
+		return baseURL + "/form/shipping?token=" + url.QueryEscape(*order.FormToken), nil
+	}
+	if s.paymentLinks != nil && s.paymentLinks.enabled() {
+		link, err := s.paymentLinks.Generate(order)
+		if err != nil {
+			return "", err
+		}
+		return link.URL, nil
+	}
+	return baseURL + "/orders/" + url.PathEscape(order.OrderNo), nil
+}
+
+// withRecoveryAttribution 在链接上追加 utm 参数，便于按活动、渠道与提醒次数统计转化
+func withRecoveryAttribution(link, campaign, medium string, step int) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	query := parsed.Query()
+	query.Set("utm_source", "order_recovery")
+	query.Set("utm_medium", medium)
+	query.Set("utm_campaign", campaign)
+	query.Set("utm_content", fmt.Sprintf("reminder_%d", step))
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestOrderRecoveryDueOrdersStopsBeforeExpiry(t *testing.T) {
+	db := openConcurrentServiceTestDB(t, &models.Order{}, &models.PaymentPollingTask{}, &models.OperationLog{})
+	cfg := &config.Config{}
+	cfg.App.URL = "https://shop.example.com/"
+	cfg.JWT.Secret = "recovery-secret"
+	cfg.Order.AutoCancelHours = 12
+	cfg.Order.PaymentLink.Enabled = true
+	cfg.Order.Recovery.Enabled = true
+	cfg.Order.Recovery.IntervalsHours = []int{24, 1, 0, 24}
+	svc := NewOrderRecoveryService(db, cfg, nil, nil, NewPaymentLinkService(db, cfg))
+
+	if got := svc.intervals(); len(got) != 2 || got[0] != 1 || got[1] != 24 {
+		t.Fatalf("expected sorted unique intervals, got %v", got)
+	}
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	formToken := "draft-form-token"
+	formExpires := now.Add(48 * time.Hour)
+	orders := []models.Order{
+		{OrderNo: "R-NEW", Status: models.OrderStatusPendingPayment, CreatedAt: now.Add(-30 * time.Minute)},
+		{OrderNo: "R-PENDING", Status: models.OrderStatusPendingPayment, CreatedAt: now.Add(-2 * time.Hour)},
+		{OrderNo: "R-BACKLOG", Status: models.OrderStatusPendingPayment, CreatedAt: now.Add(-30 * time.Hour)},
+		// 第二次提醒晚于 12 小时自动取消，不再发送
+		{OrderNo: "R-LATE", Status: models.OrderStatusPendingPayment, CreatedAt: now.Add(-11 * time.Hour), RecoveryRemindersSent: 1},
+		{OrderNo: "R-DRAFT", Status: models.OrderStatusDraft, CreatedAt: now.Add(-25 * time.Hour), RecoveryRemindersSent: 1, FormToken: &formToken, FormExpiresAt: &formExpires},
+		{OrderNo: "R-PAID", Status: models.OrderStatusPending, CreatedAt: now.Add(-2 * time.Hour)},
+		{OrderNo: "R-TRIAL", Status: models.OrderStatusPendingPayment, Source: OrderSourceTrialConversion, CreatedAt: now.Add(-2 * time.Hour)},
+		{OrderNo: "R-POLLING", Status: models.OrderStatusPendingPayment, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	byNo := make(map[string]*models.Order, len(orders))
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+		byNo[orders[i].OrderNo] = &orders[i]
+	}
+	if err := db.Create(&models.PaymentPollingTask{OrderID: byNo["R-POLLING"].ID}).Error; err != nil {
+		t.Fatalf("create polling task failed: %v", err)
+	}
+
+	due, err := svc.dueOrders(now)
+	if err != nil {
+		t.Fatalf("dueOrders failed: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, order := range due {
+		got[order.OrderNo] = true
+	}
+	if len(due) != 3 || !got["R-PENDING"] || !got["R-BACKLOG"] || !got["R-DRAFT"] {
+		t.Fatalf("unexpected due orders: %v", got)
+	}
+
+	// 积压的订单只提醒一次，并跳过已错过的提醒点
+	if _, err := svc.remind(byNo["R-BACKLOG"], now); err != nil {
+		t.Fatalf("remind failed: %v", err)
+	}
+	var reloaded models.Order
+	if err := db.First(&reloaded, byNo["R-BACKLOG"].ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if reloaded.RecoveryRemindersSent != 2 || reloaded.LastRecoveryReminderAt == nil {
+		t.Fatalf("expected reminder progress to skip missed steps, got %d", reloaded.RecoveryRemindersSent)
+	}
+	// 没有可用渠道时同样记录进度，避免每轮重复尝试
+	if reminded, err := svc.remind(byNo["R-PENDING"], now); err != nil || reminded {
+		t.Fatalf("expected no channel to be available, reminded=%v err=%v", reminded, err)
+	}
+	var pending models.Order
+	if err := db.First(&pending, byNo["R-PENDING"].ID).Error; err != nil || pending.RecoveryRemindersSent != 1 {
+		t.Fatalf("expected first reminder to be recorded, got %d err=%v", pending.RecoveryRemindersSent, err)
+	}
+	// 其他实例已推进进度时不重复提醒
+	byNo["R-PENDING"].RecoveryRemindersSent = 0
+	if reminded, err := svc.remind(byNo["R-PENDING"], now); err != nil || reminded {
+		t.Fatalf("stale progress should not remind again, reminded=%v err=%v", reminded, err)
+	}
+
+	link, err := svc.resumeURL(byNo["R-PENDING"])
+	if err != nil || !strings.HasPrefix(link, "https://shop.example.com/pay?") {
+		t.Fatalf("expected a signed payment link, got %q err=%v", link, err)
+	}
+	attributed, err := url.Parse(withRecoveryAttribution(link, svc.campaign(), "email", 1))
+	if err != nil {
+		t.Fatalf("parse attributed link failed: %v", err)
+	}
+	query := attributed.Query()
+	if query.Get("utm_campaign") != "order_recovery" || query.Get("utm_medium") != "email" || query.Get("sig") == "" {
+		t.Fatalf("unexpected attributed link %s", attributed)
+	}
+	if _, err := svc.paymentLinks.ResolvePayable(query.Get("order_no"), query.Get("expires"), query.Get("sig")); err != nil {
+		t.Fatalf("attributed link should stay valid: %v", err)
+	}
+
+	draftLink, err := svc.resumeURL(byNo["R-DRAFT"])
+	if err != nil || draftLink != "https://shop.example.com/form/shipping?token=draft-form-token" {
+		t.Fatalf("unexpected draft link %q err=%v", draftLink, err)
+	}
+}
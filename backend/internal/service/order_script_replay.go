@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+)
+
+// ScriptReplayResult 重放失败脚本执行的结果；Executions 为本次重放新产生的执行记录
+type ScriptReplayResult struct {
+	Success    bool                     `json:"success"`
+	Error      string                   `json:"error,omitempty"`
+	Executions []models.ScriptExecution `json:"executions"`
+}
+
+// ReplayScriptExecution 修复脚本后对失败执行所属的订单重新发货
+// 按手动发货流程处理订单剩余的全部待发货虚拟库存，订单状态、风控冻结等校验与手动发货一致
+func (s *OrderService) ReplayScriptExecution(inventoryID, executionID, adminID uint) (*ScriptReplayResult, error) {
+	if s.virtualProductSvc == nil {
+		return nil, newOrderVirtualServiceUnavailableError()
+	}
+	execution, err := s.virtualProductSvc.GetScriptExecution(inventoryID, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if execution.Success {
+		return nil, errScriptExecutionNotFailed.New()
+	}
+	if execution.ReplayedAt != nil {
+		return nil, errScriptExecutionAlreadyReplayed.New()
+	}
+
+	afterID, err := s.virtualProductSvc.latestScriptExecutionID(execution.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	deliverErr := s.DeliverVirtualStock(execution.OrderID, adminID, false)
+	var bizErr *bizerr.Error
+	if deliverErr != nil && errors.As(deliverErr, &bizErr) {
+		// 订单状态不允许发货或已无待发货库存，未执行脚本
+		return nil, deliverErr
+	}
+
+	executions, err := s.virtualProductSvc.linkScriptReplay(execution, afterID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ScriptReplayResult{Success: deliverErr == nil, Executions: executions}
+	if deliverErr != nil {
+		result.Error = deliverErr.Error()
+	}
+	return result, nil
+}
@@ -1,10 +1,11 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,7 +15,7 @@ import (
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/password"
-	"auralogic/internal/pkg/utils"
+	"auralogic/internal/pkg/tracing"
 	"auralogic/internal/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -28,17 +29,25 @@ type OrderService struct {
 	bindingService    *BindingService
 	serialService     *SerialService
 	serialTaskService *SerialGenerationService
+	flashSale         *FlashSaleService
 	virtualProductSvc *VirtualInventoryService
 	promoCodeRepo     *repository.PromoCodeRepository
+	giftCards         *GiftCardService
 	cfg               *config.Config
 	emailService      *EmailService
 	pluginManager     *PluginManagerService
-	userOrderLocks    sync.Map
+	orderNumbers      *OrderNumberAllocator
+	moderation        *ContentModerationService
+	carrierTracking   *CarrierTrackingService
+	velocityRules     *VelocityRuleService
+	pricingRules      *PricingRuleService
+	userOrderLocks    *sync.Map
 }
 
 type MarkAsPaidOptions struct {
 	AdminRemark      string
 	SkipAutoDelivery bool
+	AdminID          uint // 操作管理员，0 表示系统或插件触发
 }
 
 const (
@@ -61,6 +70,16 @@ func newOrderTotalAmountNegativeError() error {
 	return bizerr.New("order.totalAmountNegative", "Total amount cannot be negative")
 }
 
+func newOrderUnitPriceNegativeError(sku string) error {
+	return bizerr.Newf("order.unitPriceNegative", "Unit price of %s cannot be negative", sku).
+		WithParams(map[string]interface{}{"sku": sku})
+}
+
+func newOrderDiscountInvalidError(subtotal int64) error {
+	return bizerr.New("order.discountInvalid", "Discount must be between 0 and the items subtotal").
+		WithParams(map[string]interface{}{"max": subtotal})
+}
+
 func newOrderUserNotFoundError() error {
 	return bizerr.New("order.userNotFound", "User not found")
 }
@@ -109,6 +128,10 @@ func newOrderDeliverVirtualStatusInvalidError(status models.OrderStatus) error {
 		WithParams(map[string]interface{}{"status": status})
 }
 
+func newOrderRiskHoldError() error {
+	return bizerr.New("order.riskHold", "This order is on risk hold and cannot be shipped until the risk review is approved")
+}
+
 func newOrderVirtualServiceUnavailableError() error {
 	return bizerr.New("order.virtualServiceUnavailable", "Virtual product service is not available")
 }
@@ -230,9 +253,35 @@ func NewOrderService(
 		promoCodeRepo:     promoCodeRepo,
 		cfg:               cfg,
 		emailService:      emailService,
+		orderNumbers:      NewOrderNumberAllocator(orderRepo, cfg),
+		userOrderLocks:    &sync.Map{},
 	}
 }
 
+// withContext 返回仓储绑定 ctx 的浅拷贝，使链路中的数据库查询挂在当前 span 下；ctx 不带 span 时直接返回 s
+func (s *OrderService) withContext(ctx context.Context) *OrderService {
+	if !tracing.HasSpan(ctx) {
+		return s
+	}
+	clone := *s
+	if s.OrderRepo != nil {
+		clone.OrderRepo = s.OrderRepo.WithContext(ctx)
+	}
+	if s.userRepo != nil {
+		clone.userRepo = s.userRepo.WithContext(ctx)
+	}
+	if s.productRepo != nil {
+		clone.productRepo = s.productRepo.WithContext(ctx)
+	}
+	if s.inventoryRepo != nil {
+		clone.inventoryRepo = s.inventoryRepo.WithContext(ctx)
+	}
+	if s.promoCodeRepo != nil {
+		clone.promoCodeRepo = s.promoCodeRepo.WithContext(ctx)
+	}
+	return &clone
+}
+
 func (s *OrderService) SetPluginManager(pluginManager *PluginManagerService) {
 	s.pluginManager = pluginManager
 }
@@ -241,6 +290,89 @@ func (s *OrderService) SetSerialGenerationService(serialTaskService *SerialGener
 	s.serialTaskService = serialTaskService
 }
 
+func (s *OrderService) SetFlashSaleService(flashSale *FlashSaleService) {
+	s.flashSale = flashSale
+}
+
+// SetGiftCardService 注入礼品卡服务，未注入时下单不能使用礼品卡
+func (s *OrderService) SetGiftCardService(giftCards *GiftCardService) {
+	s.giftCards = giftCards
+}
+
+// SetCarrierTrackingService 注入物流轨迹服务，发货时将物流单号登记到物流服务商
+func (s *OrderService) SetCarrierTrackingService(carrierTracking *CarrierTrackingService) {
+	s.carrierTracking = carrierTracking
+}
+
+// ShipmentTracking 订单的物流轨迹，未开启对接或尚未发货时返回 nil
+func (s *OrderService) ShipmentTracking(orderID uint) (*models.OrderShipment, error) {
+	if s.carrierTracking == nil {
+		return nil, nil
+	}
+	return s.carrierTracking.ShipmentForOrder(orderID)
+}
+
+// SetVelocityRuleService 注入频率规则服务，下单与提交收货信息时检查订单
+func (s *OrderService) SetVelocityRuleService(velocityRules *VelocityRuleService) {
+	s.velocityRules = velocityRules
+}
+
+// SetPricingRuleService 注入定价规则服务，用户下单时与优惠码一起计算优惠
+func (s *OrderService) SetPricingRuleService(pricingRules *PricingRuleService) {
+	s.pricingRules = pricingRules
+}
+
+// EvaluateOrderRisk 按频率规则检查订单，命中时订单进入风控审核队列。
+// 检查失败只记录日志，不影响下单流程；订单被暂扣时同步更新 order.RiskHold
+func (s *OrderService) EvaluateOrderRisk(order *models.Order, ip string) {
+	if s.velocityRules == nil || order == nil {
+		return
+	}
+	review, err := s.velocityRules.EvaluateOrder(order.ID, ip)
+	if err != nil {
+		log.Printf("Warning: Order %s failed to evaluate velocity rules: %v", order.OrderNo, err)
+		return
+	}
+	if review != nil && review.Action == models.VelocityActionHold && review.Status == models.OrderRiskReviewStatusPending {
+		order.RiskHold = true
+	}
+}
+
+// RiskReview 订单的风控审核记录，未命中频率规则时返回 nil
+func (s *OrderService) RiskReview(orderID uint) (*models.OrderRiskReview, error) {
+	return s.velocityRules.ReviewForOrder(orderID)
+}
+
+// releaseGiftCard 释放订单预留的礼品卡抵扣金额
+func (s *OrderService) releaseGiftCard(order *models.Order) {
+	if order.GiftCardID == nil || s.giftCards == nil {
+		return
+	}
+	if err := s.giftCards.ReleaseForOrder(order); err != nil {
+		fmt.Printf("Warning: Order %s failed to release gift card: %v\n", order.OrderNo, err)
+	}
+}
+
+// SetContentModeration 设置订单备注的内容审核
+func (s *OrderService) SetContentModeration(moderation *ContentModerationService) {
+	s.moderation = moderation
+}
+
+// reserveInventory 秒杀库存走 Redis 计数，其余走数据库行锁预留
+func (s *OrderService) reserveInventory(inventoryID uint, quantity int, orderNo string) error {
+	if handled, err := s.flashSale.Reserve(inventoryID, quantity, orderNo); handled {
+		return err
+	}
+	return s.inventoryRepo.Reserve(inventoryID, quantity, orderNo)
+}
+
+func (s *OrderService) releaseInventory(inventoryID uint, quantity int, orderNo string) error {
+	if handled, err := s.flashSale.Release(inventoryID, quantity, orderNo); handled {
+		return err
+	}
+	return s.inventoryRepo.ReleaseReserve(inventoryID, quantity, orderNo)
+}
+
 func cloneOrderHookExecutionContext(execCtx *ExecutionContext) *ExecutionContext {
 	if execCtx == nil {
 		return nil
@@ -379,7 +511,7 @@ func (s *OrderService) reserveInventoryWithHook(orderID *uint, userID *uint, ord
 		}
 	}
 
-	reserveErr := s.inventoryRepo.Reserve(reservedInventoryID, quantity, orderNo)
+	reserveErr := s.reserveInventory(reservedInventoryID, quantity, orderNo)
 	if s.pluginManager != nil {
 		afterPayload := map[string]interface{}{
 			"order_id":     orderID,
@@ -411,7 +543,7 @@ func (s *OrderService) reserveInventoryWithHook(orderID *uint, userID *uint, ord
 }
 
 func (s *OrderService) releaseReservedInventoryWithHook(orderID *uint, userID *uint, orderNo string, inventoryID uint, quantity int, source string) error {
-	releaseErr := s.inventoryRepo.ReleaseReserve(inventoryID, quantity, orderNo)
+	releaseErr := s.releaseInventory(inventoryID, quantity, orderNo)
 	if s.pluginManager != nil {
 		execCtx := s.buildInventoryHookExecutionContext(orderID, userID, source, orderNo)
 		afterPayload := map[string]interface{}{
@@ -484,7 +616,10 @@ func (s *OrderService) lockUserOrderCreation(userID uint) func() {
 // CreateDraft CreateOrder草稿
 func (s *OrderService) CreateDraft(items []models.OrderItem, externalUserID, externalOrderID, platform, userEmail, userName, remark string) (*models.Order, error) {
 	// generateOrder号
-	orderNo := utils.GenerateOrderNo(s.cfg.Order.NoPrefix)
+	orderNo, err := s.orderNumbers.Allocate(platform)
+	if err != nil {
+		return nil, err
+	}
 
 	// generate表单Token
 	formToken := uuid.New().String()
@@ -513,6 +648,7 @@ func (s *OrderService) CreateDraft(items []models.OrderItem, externalUserID, ext
 		}
 		totalAmount += product.Price * int64(item.Quantity)
 	}
+	stampOrderItemVendors(items, productBySKU, false)
 
 	// 获取货币单位
 	currency := s.cfg.Order.Currency
@@ -538,10 +674,12 @@ func (s *OrderService) CreateDraft(items []models.OrderItem, externalUserID, ext
 		EmailNotificationsEnabled: true,
 		Remark:                    remark,
 	}
+	applyOrderPriceRounding(s.cfg, order)
 
 	if err := s.OrderRepo.Create(order); err != nil {
 		return nil, err
 	}
+	s.recordOrderCreated(order, "order.create_draft", models.OrderEventOperatorSystem, nil)
 
 	return order, nil
 }
@@ -564,7 +702,9 @@ type AdminOrderRequest struct {
 	AdminRemark      string
 	Status           string
 	TotalAmount      *int64
+	DiscountAmount   int64 // 手动折扣（最小货币单位），从商品小计中扣减
 	UserEmail        string
+	AdminID          uint // 创建订单的管理员，记录到时间线
 }
 
 // AdminOrderItem 管理员订单商品项
@@ -572,7 +712,7 @@ type AdminOrderItem struct {
 	SKU                string                 `json:"sku"`
 	Name               string                 `json:"name"`
 	Quantity           int                    `json:"quantity"`
-	UnitPrice          int64                  `json:"unit_price_minor"`
+	UnitPrice          *int64                 `json:"unit_price_minor,omitempty"` // 为空时使用商品当前售价
 	Attributes         map[string]interface{} `json:"attributes,omitempty"`
 	ProductType        string                 `json:"product_type,omitempty"`
 	VirtualInventoryID *uint                  `json:"virtual_inventory_id,omitempty"`
@@ -626,13 +766,22 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 				WithParams(map[string]interface{}{"max": s.cfg.Order.MaxItemQuantity})
 		}
 
+		if item.UnitPrice != nil && *item.UnitPrice < 0 {
+			return nil, newOrderUnitPriceNegativeError(sku)
+		}
+
 		name := item.Name
 		var imageURL string
 		productType := models.ProductType(item.ProductType)
-		if name == "" || productType == "" {
+		var unitPrice int64
+		if item.UnitPrice != nil {
+			unitPrice = *item.UnitPrice
+		}
+		if name == "" || productType == "" || item.UnitPrice == nil {
 			product, err := s.productRepo.FindBySKU(sku)
 			if err != nil {
-				if name == "" {
+				// 未指定单价时必须能从商品读取售价
+				if name == "" || item.UnitPrice == nil {
 					return nil, bizerr.Newf("order.productNotFound", "Product %s does not exist", sku).
 						WithParams(map[string]interface{}{"sku": sku})
 				}
@@ -643,6 +792,9 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 				if productType == "" {
 					productType = product.ProductType
 				}
+				if item.UnitPrice == nil {
+					unitPrice = product.Price
+				}
 				if len(product.Images) > 0 {
 					imageURL = product.Images[0].URL
 				}
@@ -661,14 +813,25 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 			Attributes:  item.Attributes,
 			ProductType: productType,
 			ImageURL:    imageURL,
+			UnitPrice:   unitPrice,
 		})
-		totalAmount += item.UnitPrice * int64(item.Quantity)
+		totalAmount += unitPrice * int64(item.Quantity)
 		// 保存管理员指定的虚拟库存ID
 		if item.VirtualInventoryID != nil {
 			virtualInventoryIDs[idx] = item.VirtualInventoryID
 		}
 	}
 
+	if productBySKU, err := s.loadProductsForOrderItems(orderItems); err == nil {
+		stampOrderItemVendors(orderItems, productBySKU, true)
+	}
+
+	// 手动折扣不能超过商品小计
+	if req.DiscountAmount < 0 || req.DiscountAmount > totalAmount {
+		return nil, newOrderDiscountInvalidError(totalAmount)
+	}
+	totalAmount -= req.DiscountAmount
+
 	// 允许手动覆盖总金额
 	if req.TotalAmount != nil {
 		totalAmount = *req.TotalAmount
@@ -681,7 +844,10 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 	}
 
 	// 生成订单号
-	orderNo := utils.GenerateOrderNo(s.cfg.Order.NoPrefix)
+	orderNo, err := s.orderNumbers.Allocate("")
+	if err != nil {
+		return nil, err
+	}
 
 	// 物理商品库存绑定
 	inventoryBindings := make(map[int]uint)
@@ -796,6 +962,7 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 		InventoryBindings:         inventoryBindings,
 		Status:                    status,
 		TotalAmount:               totalAmount,
+		DiscountAmount:            req.DiscountAmount,
 		Currency:                  currency,
 		FormToken:                 formToken,
 		FormExpiresAt:             formExpiresAt,
@@ -897,6 +1064,11 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 			fmt.Printf("Warning: Failed to update product sales count - ProductID: %d, Error: %v\n", productID, err)
 		}
 	}
+	var createdBy *uint
+	if req.AdminID > 0 {
+		createdBy = &req.AdminID
+	}
+	s.recordOrderCreated(order, "order.admin.create", models.OrderEventOperatorAdmin, createdBy)
 	syncUserPurchaseStatsTransitionBestEffort(s.OrderRepo, nil, order.UserID, "", order.Status, order.Items, "create_admin_order")
 	s.syncUserConsumptionStatusTransitionBestEffort(order.UserID, "", order.Status, order.TotalAmount, "create_admin_order")
 
@@ -909,7 +1081,19 @@ func (s *OrderService) CreateAdminOrder(req AdminOrderRequest) (*models.Order, e
 }
 
 // CreateUserOrder User直接CreateOrder（无需表单流程）
-func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, remark string, promoCode string) (*models.Order, error) {
+func (s *OrderService) CreateUserOrder(ctx context.Context, userID uint, items []models.OrderItem, remark string, promoCode string, giftCardCode string) (*models.Order, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.CreateUserOrder", tracing.KindInternal)
+	span.SetAttribute("enduser.id", userID)
+	span.SetAttribute("order.item_count", len(items))
+	order, err := s.withContext(ctx).createUserOrder(userID, items, remark, promoCode, giftCardCode)
+	if order != nil {
+		span.SetAttribute("order.no", order.OrderNo)
+	}
+	span.EndWithError(err)
+	return order, err
+}
+
+func (s *OrderService) createUserOrder(userID uint, items []models.OrderItem, remark string, promoCode string, giftCardCode string) (*models.Order, error) {
 	releaseHotPath, err := acquireOrderHighConcurrencyProtection(s.cfg, orderHotPathCreateUserOrder)
 	if err != nil {
 		if isOrderHighConcurrencyBusyError(err) {
@@ -940,6 +1124,12 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		return nil, err
 	}
 
+	remarkVerdict, err := s.moderation.Screen(models.ModerationSourceOrderRemark, &userID, remark)
+	if err != nil {
+		return nil, err
+	}
+	remark = remarkVerdict.Text
+
 	productBySKU, err := s.loadProductsForOrderItems(items)
 	if err != nil {
 		return nil, err
@@ -1154,8 +1344,8 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 			}
 		}
 
-		// 检查Inventory是否足够
-		if canPurchase, msg := inventory.CanPurchase(item.Quantity); !canPurchase {
+		// 检查Inventory是否足够（秒杀库存按 Redis 计数判断，售罄直接失败）
+		if canPurchase, msg := s.flashSale.CanPurchase(inventory, item.Quantity); !canPurchase {
 			if normalized := normalizeOrderInventoryAvailabilityError(product.Name, msg); normalized != nil {
 				return nil, normalized
 			}
@@ -1171,7 +1361,10 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 	}
 
 	// generateOrder号
-	orderNo := utils.GenerateOrderNo(s.cfg.Order.NoPrefix)
+	orderNo, err := s.orderNumbers.Allocate("")
+	if err != nil {
+		return nil, err
+	}
 
 	// Inventory绑定映射（Order项索引 -> InventoryID）
 	inventoryBindings := make(map[int]uint)
@@ -1201,29 +1394,30 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		}
 	}
 
-	// 将盲盒分配结果提取到 ActualAttributes，并从 items 中剥离盲盒属性
-	// ActualAttributes 格式: { "0": {"color": "red"}, "1": {"size": "L"} } (key 为订单项索引)
-	var actualAttrsJSON models.JSON
-	if len(blindBoxAttrNames) > 0 {
-		actualAttrsMap := make(map[string]map[string]interface{})
-		for idx, attrNames := range blindBoxAttrNames {
-			item := &items[idx]
-			blindBoxValues := make(map[string]interface{})
-			for _, name := range attrNames {
-				if val, ok := item.Attributes[name]; ok {
-					blindBoxValues[name] = val
-					delete(item.Attributes, name)
-				}
-			}
-			if len(blindBoxValues) > 0 {
-				actualAttrsMap[fmt.Sprintf("%d", idx)] = blindBoxValues
+	// 将盲盒分配结果提取到 ItemAllocations，并从 items 中剥离盲盒属性（付款后才对用户展示）
+	var itemAllocations []models.OrderItemAllocation
+	allocatedAttrs := make(map[int]map[string]interface{})
+	for idx, attrNames := range blindBoxAttrNames {
+		item := &items[idx]
+		blindBoxValues := make(map[string]interface{})
+		for _, name := range attrNames {
+			if val, ok := item.Attributes[name]; ok {
+				blindBoxValues[name] = val
+				delete(item.Attributes, name)
 			}
 		}
-		if len(actualAttrsMap) > 0 {
-			jsonBytes, _ := json.Marshal(actualAttrsMap)
-			actualAttrsJSON = models.JSON(string(jsonBytes))
+		if len(blindBoxValues) > 0 {
+			allocatedAttrs[idx] = blindBoxValues
+			itemAllocations = append(itemAllocations, models.OrderItemAllocation{
+				ItemIndex:  idx,
+				SKU:        item.SKU,
+				Attributes: blindBoxValues,
+			})
 		}
 	}
+	sort.Slice(itemAllocations, func(i, j int) bool {
+		return itemAllocations[i].ItemIndex < itemAllocations[j].ItemIndex
+	})
 
 	// CreateOrder
 	// 所有订单创建时都是待付款状态
@@ -1236,6 +1430,7 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 			totalAmount += product.Price * int64(item.Quantity)
 		}
 	}
+	stampOrderItemVendors(items, productBySKU, false)
 
 	// 获取货币单位
 	currency := s.cfg.Order.Currency
@@ -1243,10 +1438,10 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		currency = "CNY"
 	}
 
-	// 处理优惠码
+	// 校验优惠码，优惠金额与定价规则一起计算
 	var promoCodeID *uint
 	var promoCodeStr string
-	var discountAmount int64
+	var appliedPromo *models.PromoCode
 	if promoCode != "" && s.promoCodeRepo != nil {
 		promoCodeRepo := s.promoCodeRepo
 		pc, err := promoCodeRepo.FindByCode(strings.ToUpper(strings.TrimSpace(promoCode)))
@@ -1263,6 +1458,12 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 			}
 			return nil, fmt.Errorf("Promo code is not available")
 		}
+		if err := checkPromoCodePerUserLimit(promoCodeRepo, pc, userID); err != nil {
+			for i, inventoryID := range inventoryBindings {
+				_ = s.releaseReservedInventoryWithHook(nil, &userID, orderNo, inventoryID, items[i].Quantity, "user_create_order_rollback")
+			}
+			return nil, err
+		}
 		// 收集订单中的商品ID
 		var productIDs []uint
 		for _, item := range items {
@@ -1286,23 +1487,39 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 				return nil, fmt.Errorf("Promo code is not applicable to the selected products")
 			}
 		}
-		discountAmount = pc.CalculateDiscount(totalAmount)
+		appliedPromo = pc
+	}
+
+	// 按优先级叠加定价规则，最后应用优惠码
+	pricing, err := s.pricingRules.Evaluate(pricingLinesForItems(items, productBySKU), appliedPromo, models.NowFunc())
+	if err == nil && pricing.PromoBlockedBy != "" {
+		err = errPromoCodeNotCombinable.New().WithParams(map[string]interface{}{"rule": pricing.PromoBlockedBy})
+	}
+	if err != nil {
+		for i, inventoryID := range inventoryBindings {
+			_ = s.releaseReservedInventoryWithHook(nil, &userID, orderNo, inventoryID, items[i].Quantity, "user_create_order_rollback")
+		}
+		return nil, err
+	}
+	discountAmount := pricing.Discount()
+
+	if appliedPromo != nil {
 		// 预留优惠码
-		if err := promoCodeRepo.Reserve(pc.ID, orderNo); err != nil {
+		if err := s.promoCodeRepo.Reserve(appliedPromo.ID, orderNo); err != nil {
 			for i, inventoryID := range inventoryBindings {
 				_ = s.releaseReservedInventoryWithHook(nil, &userID, orderNo, inventoryID, items[i].Quantity, "user_create_order_rollback")
 			}
 			return nil, fmt.Errorf("Failed to reserve promo code: %v", err)
 		}
-		promoCodeID = &pc.ID
-		promoCodeStr = pc.Code
+		promoCodeID = &appliedPromo.ID
+		promoCodeStr = appliedPromo.Code
 	}
 
 	order := &models.Order{
 		OrderNo:                   orderNo,
 		UserID:                    &userID,
 		Items:                     items,
-		ActualAttributes:          actualAttrsJSON,
+		ItemAllocations:           itemAllocations,
 		InventoryBindings:         inventoryBindings, // 保存Inventory绑定关系（内部使用）
 		Status:                    orderStatus,
 		TotalAmount:               totalAmount - discountAmount,
@@ -1310,12 +1527,15 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		PromoCodeID:               promoCodeID,
 		PromoCodeStr:              promoCodeStr,
 		DiscountAmount:            discountAmount,
+		RuleDiscountAmount:        pricing.RuleDiscount,
+		PricingTrace:              pricing.Trace,
 		Source:                    "web",
 		UserEmail:                 user.Email,
 		EmailNotificationsEnabled: true,
 		Remark:                    remark,
 		// FormToken 和 FormExpiresAt 在User点击填写时动态generate（仅非虚拟商品订单需要）
 	}
+	applyOrderPriceRounding(s.cfg, order)
 
 	if err := s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
 		if err := s.ensurePendingPaymentLimitTx(tx, userID); err != nil {
@@ -1324,9 +1544,30 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		if err := s.ensurePurchaseLimitsTx(tx, userID, requestedQtyBySKU); err != nil {
 			return err
 		}
+		if err := requirePolicyConsentTx(tx, userID); err != nil {
+			return err
+		}
+		if err := applyOrganizationCheckoutTx(tx, order, time.Now()); err != nil {
+			return err
+		}
+		if err := applySpendingControlsTx(tx, s.cfg, order, orderItemCategories(items, productBySKU), time.Now()); err != nil {
+			return err
+		}
+		// 礼品卡在取整后抵扣，与订单写入在同一事务中预留
+		if strings.TrimSpace(giftCardCode) != "" {
+			if s.giftCards == nil {
+				return giftCardDisabledError()
+			}
+			if err := s.giftCards.ApplyToOrderTx(tx, giftCardCode, order); err != nil {
+				return err
+			}
+		}
 		if err := tx.Create(order).Error; err != nil {
 			return err
 		}
+		if err := RecordModerationVerdictTx(tx, remarkVerdict, order.ID); err != nil {
+			return err
+		}
 		return applyUserPurchaseStatsTransitionTx(tx, nil, order.UserID, "", order.Status, order.Items)
 	}); err != nil {
 		// CreateOrderFailed，释放已预留的Inventory
@@ -1348,22 +1589,13 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 			product := productBySKU[item.SKU]
 			if product != nil && product.ProductType == models.ProductTypeVirtual {
 				// 为虚拟产品分配库存（预留状态），传入完整规格属性
-				// 需要从 ActualAttributes 中合并盲盒属性回来用于库存匹配
+				// 需要合并盲盒分配结果用于库存匹配
 				allocAttrs := make(map[string]interface{})
 				for k, v := range item.Attributes {
 					allocAttrs[k] = v
 				}
-				if bbNames, ok := blindBoxAttrNames[i]; ok && len(actualAttrsJSON) > 0 {
-					var actualMap map[string]map[string]interface{}
-					if err := json.Unmarshal([]byte(actualAttrsJSON), &actualMap); err == nil {
-						if bbVals, ok := actualMap[fmt.Sprintf("%d", i)]; ok {
-							for _, name := range bbNames {
-								if v, ok := bbVals[name]; ok {
-									allocAttrs[name] = v
-								}
-							}
-						}
-					}
+				for k, v := range allocatedAttrs[i] {
+					allocAttrs[k] = v
 				}
 				_, scriptInvID, err := s.virtualProductSvc.AllocateStockForProductByAttributes(product.ID, item.Quantity, orderNo, allocAttrs)
 				if err != nil {
@@ -1380,6 +1612,7 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 							fmt.Printf("Warning: Failed to rollback promo code reserve for order %s: %v\n", orderNo, releaseErr)
 						}
 					}
+					s.releaseGiftCard(order)
 					s.OrderRepo.Delete(order.ID)
 					return nil, fmt.Errorf("failed to allocate virtual product stock: %w", err)
 				}
@@ -1403,6 +1636,8 @@ func (s *OrderService) CreateUserOrder(userID uint, items []models.OrderItem, re
 		}
 	}
 
+	s.recordOrderCreated(order, "order.create", models.OrderEventOperatorUser, &userID)
+
 	// 零金额订单自动完成支付（如100%优惠码或价格为0的商品）
 	if order.TotalAmount == 0 {
 		s.MarkAsPaid(order.ID)
@@ -1466,6 +1701,12 @@ func (s *OrderService) SubmitShippingForm(formToken string, receiverInfo map[str
 		}
 	}
 
+	remarkVerdict, err := s.moderation.Screen(models.ModerationSourceOrderRemark, actorUserID, userRemark)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	userRemark = remarkVerdict.Text
+
 	releaseHotPath, err := acquireOrderHighConcurrencyProtection(s.cfg, orderHotPathSubmitShippingForm)
 	if err != nil {
 		if isOrderHighConcurrencyBusyError(err) {
@@ -1673,6 +1914,10 @@ func (s *OrderService) SubmitShippingForm(formToken string, receiverInfo map[str
 		if err := applyUserPurchaseStatsTransitionTx(tx, beforeUserID, lockedOrder.UserID, beforeStatus, lockedOrder.Status, lockedOrder.Items); err != nil {
 			return err
 		}
+		remarkVerdict.UserID = lockedOrder.UserID
+		if err := RecordModerationVerdictTx(tx, remarkVerdict, lockedOrder.ID); err != nil {
+			return err
+		}
 
 		if !isResubmit && s.serialService != nil {
 			txProductRepo := repository.NewProductRepository(tx)
@@ -1754,7 +1999,7 @@ func (s *OrderService) SubmitShippingForm(formToken string, receiverInfo map[str
 	if len(serialHookSerials) > 0 {
 		s.serialService.emitSerialCreateAfterHook(serialHookSerials, "order_service", order.UserID, order.ID)
 	}
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, statusHookBefore, order.Status, map[string]interface{}{
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, statusHookBefore, order.Status, map[string]interface{}{
 		"source":         "shipping_form_submit",
 		"form_resubmit":  isResubmit,
 		"is_new_user":    isNewUser,
@@ -1788,6 +2033,11 @@ func (s *OrderService) GetOrderByNo(orderNo string) (*models.Order, error) {
 	return s.OrderRepo.FindByOrderNo(orderNo)
 }
 
+// LoadItemAllocations 加载订单的盲盒分配结果
+func (s *OrderService) LoadItemAllocations(order *models.Order) error {
+	return s.OrderRepo.LoadItemAllocations(order)
+}
+
 // GetOrderByID 根据IDgetOrder
 func (s *OrderService) GetOrderByID(id uint) (*models.Order, error) {
 	return s.OrderRepo.FindByID(id)
@@ -1798,6 +2048,11 @@ func (s *OrderService) ListOrders(page, limit int, status, search, country, prod
 	return s.OrderRepo.List(page, limit, status, search, country, productSearch, promoCodeID, promoCode, userID)
 }
 
+// ListOrdersByCursor 按游标getOrder List
+func (s *OrderService) ListOrdersByCursor(cursor string, limit int, status, search, country, productSearch string, promoCodeID *uint, promoCode string, userID *uint) ([]models.Order, string, error) {
+	return s.OrderRepo.ListByCursor(cursor, limit, status, search, country, productSearch, promoCodeID, promoCode, userID)
+}
+
 // GetOrderCountries get所有有Order的国家列表
 func (s *OrderService) GetOrderCountries() ([]string, error) {
 	return s.OrderRepo.GetOrderCountries()
@@ -1808,6 +2063,11 @@ func (s *OrderService) ListUserOrders(userID uint, page, limit int, status strin
 	return s.OrderRepo.FindByUserID(userID, page, limit, status)
 }
 
+// ListUserOrdersByCursor 按游标getUserOrder List
+func (s *OrderService) ListUserOrdersByCursor(userID uint, cursor string, limit int, status string) ([]models.Order, string, error) {
+	return s.OrderRepo.FindByUserIDByCursor(userID, cursor, limit, status)
+}
+
 // AssignTracking 分配物流单号
 func (s *OrderService) AssignTracking(orderID uint, trackingNo string) error {
 	order, err := s.OrderRepo.FindByID(orderID)
@@ -1820,6 +2080,9 @@ func (s *OrderService) AssignTracking(orderID uint, trackingNo string) error {
 	if order.Status != models.OrderStatusPending {
 		return newOrderAssignTrackingStatusInvalidError(order.Status)
 	}
+	if order.RiskHold {
+		return newOrderRiskHoldError()
+	}
 
 	// 发货时将预留Inventory转为已售Inventory
 	for i := range order.Items {
@@ -1853,12 +2116,19 @@ func (s *OrderService) AssignTracking(orderID uint, trackingNo string) error {
 	if err := s.OrderRepo.Update(order); err != nil {
 		return err
 	}
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
 		"source":         "assign_tracking",
 		"trigger_action": "order.assign_tracking",
 		"tracking_no":    trackingNo,
 	})
 
+	// 登记物流跟踪失败只记录日志，不影响发货
+	if s.carrierTracking != nil {
+		if err := s.carrierTracking.TrackShipment(order); err != nil {
+			log.Printf("Warning: Order %s failed to start carrier tracking: %v", order.OrderNo, err)
+		}
+	}
+
 	// 发送发货邮件通知
 	if s.emailService != nil {
 		go s.emailService.SendOrderShippedEmail(order)
@@ -1880,6 +2150,9 @@ func (s *OrderService) DeliverVirtualStock(orderID uint, deliveredBy uint, markO
 	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusShipped {
 		return newOrderDeliverVirtualStatusInvalidError(order.Status)
 	}
+	if order.RiskHold {
+		return newOrderRiskHoldError()
+	}
 
 	if s.virtualProductSvc == nil {
 		return newOrderVirtualServiceUnavailableError()
@@ -1924,7 +2197,7 @@ func (s *OrderService) DeliverVirtualStock(orderID uint, deliveredBy uint, markO
 		if err := s.OrderRepo.Update(order); err != nil {
 			return err
 		}
-		EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
+		PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
 			"source":                "deliver_virtual_stock",
 			"trigger_action":        "order.deliver_virtual",
 			"delivered_by":          deliveredBy,
@@ -1941,6 +2214,52 @@ func (s *OrderService) DeliverVirtualStock(orderID uint, deliveredBy uint, markO
 	return nil
 }
 
+// validateOrderCompletable 只有已发货的订单可以完成
+func validateOrderCompletable(order *models.Order) error {
+	if order.Status != models.OrderStatusShipped {
+		return newOrderCompleteStatusInvalidError(order.Status)
+	}
+	return nil
+}
+
+// validateOrderDeletable 只有待付款、草稿、已取消和已退款的Order可以Delete
+func validateOrderDeletable(order *models.Order) error {
+	if order.Status != models.OrderStatusPendingPayment && order.Status != models.OrderStatusDraft && order.Status != models.OrderStatusCancelled && order.Status != models.OrderStatusRefunded {
+		return newOrderDeleteStatusInvalidError(order.Status)
+	}
+	return nil
+}
+
+// validateOrderCancellable 只有仍处于可取消流程中的订单允许取消
+func validateOrderCancellable(order *models.Order) error {
+	if order.Status != models.OrderStatusPendingPayment &&
+		order.Status != models.OrderStatusDraft &&
+		order.Status != models.OrderStatusPending &&
+		order.Status != models.OrderStatusNeedResubmit {
+		return newOrderCancelStatusInvalidError(order.Status)
+	}
+	return nil
+}
+
+// PreviewOrderAction 按与 CompleteOrder/CancelOrder/DeleteOrder 相同的规则检查订单能否执行 action，不做任何修改
+func (s *OrderService) PreviewOrderAction(orderID uint, action string) (*models.Order, error) {
+	order, err := s.OrderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, normalizeOrderLookupError(err)
+	}
+	switch action {
+	case "complete":
+		err = validateOrderCompletable(order)
+	case "cancel":
+		err = validateOrderCancellable(order)
+	case "delete":
+		err = validateOrderDeletable(order)
+	default:
+		err = fmt.Errorf("unsupported order action: %s", action)
+	}
+	return order, err
+}
+
 // CompleteOrder 完成Order
 func (s *OrderService) CompleteOrder(orderID uint, completedBy uint, feedback, adminRemark string) error {
 	order, err := s.OrderRepo.FindByID(orderID)
@@ -1949,8 +2268,8 @@ func (s *OrderService) CompleteOrder(orderID uint, completedBy uint, feedback, a
 	}
 	beforeStatus := order.Status
 
-	if order.Status != models.OrderStatusShipped {
-		return newOrderCompleteStatusInvalidError(order.Status)
+	if err := validateOrderCompletable(order); err != nil {
+		return err
 	}
 
 	order.Status = models.OrderStatusCompleted
@@ -1973,11 +2292,17 @@ func (s *OrderService) CompleteOrder(orderID uint, completedBy uint, feedback, a
 			fmt.Printf("Warning: Order %s Failed to deduct promo code: %v\n", order.OrderNo, err)
 		}
 	}
+	// 扣减礼品卡余额（从预留转为已使用）
+	if order.GiftCardID != nil && s.giftCards != nil {
+		if err := s.giftCards.RedeemForOrder(order); err != nil {
+			fmt.Printf("Warning: Order %s failed to redeem gift card: %v\n", order.OrderNo, err)
+		}
+	}
 
 	if err := s.OrderRepo.Update(order); err != nil {
 		return err
 	}
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
 		"source":         "complete_order",
 		"trigger_action": "order.complete",
 		"completed_by":   completedBy,
@@ -2017,7 +2342,7 @@ func (s *OrderService) RequestResubmit(orderID uint, reason string) (string, err
 	if err := s.OrderRepo.Update(order); err != nil {
 		return "", err
 	}
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, beforeStatus, order.Status, map[string]interface{}{
 		"source":         "request_resubmit",
 		"trigger_action": "order.request_resubmit",
 		"reason":         reason,
@@ -2039,9 +2364,8 @@ func (s *OrderService) DeleteOrder(orderID uint) error {
 		return normalizeOrderLookupError(err)
 	}
 
-	// 只有待付款、草稿、已取消和已退款的Order可以Delete
-	if order.Status != models.OrderStatusPendingPayment && order.Status != models.OrderStatusDraft && order.Status != models.OrderStatusCancelled && order.Status != models.OrderStatusRefunded {
-		return newOrderDeleteStatusInvalidError(order.Status)
+	if err := validateOrderDeletable(order); err != nil {
+		return err
 	}
 
 	// 删除待付款订单时释放预留库存
@@ -2068,6 +2392,7 @@ func (s *OrderService) DeleteOrder(orderID uint) error {
 				fmt.Printf("Warning: Order %s Failed to release promo code: %v\n", order.OrderNo, err)
 			}
 		}
+		s.releaseGiftCard(order)
 	}
 
 	// Delete serial numbers associated with this order before deleting the order
@@ -2103,12 +2428,8 @@ func (s *OrderService) CancelOrder(orderID uint, reason string) error {
 	}
 	previousStatus := order.Status
 
-	// 只有仍处于可取消流程中的订单允许取消
-	if order.Status != models.OrderStatusPendingPayment &&
-		order.Status != models.OrderStatusDraft &&
-		order.Status != models.OrderStatusPending &&
-		order.Status != models.OrderStatusNeedResubmit {
-		return newOrderCancelStatusInvalidError(order.Status)
+	if err := validateOrderCancellable(order); err != nil {
+		return err
 	}
 
 	// 取消Order时释放预留Inventory
@@ -2142,6 +2463,7 @@ func (s *OrderService) CancelOrder(orderID uint, reason string) error {
 				fmt.Printf("Warning: Order %s Failed to release promo code: %v\n", order.OrderNo, err)
 			}
 		}
+		s.releaseGiftCard(order)
 	}
 
 	// Delete serial numbers associated with this order
@@ -2170,7 +2492,7 @@ func (s *OrderService) CancelOrder(orderID uint, reason string) error {
 	}
 	syncUserPurchaseStatsTransitionBestEffort(s.OrderRepo, order.UserID, order.UserID, previousStatus, order.Status, order.Items, "cancel_order")
 	s.syncUserConsumptionStatusTransitionBestEffort(order.UserID, previousStatus, order.Status, order.TotalAmount, "cancel_order")
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, previousStatus, order.Status, map[string]interface{}{
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, previousStatus, order.Status, map[string]interface{}{
 		"source":         "cancel_order",
 		"trigger_action": "order.cancel",
 		"reason":         reason,
@@ -2184,14 +2506,23 @@ func (s *OrderService) CancelOrder(orderID uint, reason string) error {
 	return nil
 }
 
-// ReleaseOrderReserves 释放订单预留的库存和优惠码（用于退款/取消等场景）
+// ReleaseOrderReserves 释放订单预留的库存、优惠码和礼品卡（用于退款/取消等场景）
 func (s *OrderService) ReleaseOrderReserves(order *models.Order) {
 	orderIDRef := order.ID
+	// 部分退款时已释放的数量不再重复释放
+	released, err := s.OrderRepo.RefundedItemQuantities(order.ID)
+	if err != nil {
+		fmt.Printf("Warning: Order %s failed to load partial refund quantities: %v\n", order.OrderNo, err)
+	}
 	// 释放物理商品库存
 	for i := range order.Items {
 		item := &order.Items[i]
+		quantity := item.Quantity - released[i]
+		if quantity <= 0 {
+			continue
+		}
 		if inventoryID, exists := order.InventoryBindings[i]; exists && inventoryID > 0 {
-			if err := s.releaseReservedInventoryWithHook(&orderIDRef, order.UserID, order.OrderNo, inventoryID, item.Quantity, "release_order_reserves"); err != nil {
+			if err := s.releaseReservedInventoryWithHook(&orderIDRef, order.UserID, order.OrderNo, inventoryID, quantity, "release_order_reserves"); err != nil {
 				fmt.Printf("Warning: Order %s failed to release reserved inventory: %v\n", order.OrderNo, err)
 			}
 		}
@@ -2210,6 +2541,7 @@ func (s *OrderService) ReleaseOrderReserves(order *models.Order) {
 			fmt.Printf("Warning: Order %s failed to release promo code: %v\n", order.OrderNo, err)
 		}
 	}
+	s.releaseGiftCard(order)
 }
 
 // MarkAsPaid 标记订单为已付款
@@ -2234,6 +2566,7 @@ func (s *OrderService) MarkAsPaidWithOptions(orderID uint, options MarkAsPaidOpt
 			AdminRemark:             options.AdminRemark,
 			SkipAutoDelivery:        options.SkipAutoDelivery,
 			StrictAutoDeliveryCheck: true,
+			Config:                  s.cfg,
 		})
 		return err
 	})
@@ -2259,11 +2592,15 @@ func (s *OrderService) MarkAsPaidWithOptions(orderID uint, options MarkAsPaidOpt
 		order.TotalAmount,
 		"mark_as_paid",
 	)
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, nil, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, map[string]interface{}{
+	markPaidExtra := map[string]interface{}{
 		"source":             "mark_as_paid",
 		"trigger_action":     "order.mark_paid",
 		"skip_auto_delivery": options.SkipAutoDelivery,
-	})
+	}
+	if options.AdminID > 0 {
+		markPaidExtra["admin_id"] = options.AdminID
+	}
+	PublishOrderStatusChanged(s.OrderRepo, s.pluginManager, nil, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, markPaidExtra)
 
 	// 发送付款成功邮件
 	if s.emailService != nil {
@@ -2332,13 +2669,3 @@ func (s *OrderService) GetOrRefreshFormToken(order *models.Order) (string, *time
 	// 返回现有的有效Token
 	return *order.FormToken, order.FormExpiresAt, nil
 }
-
-// IsOrderSharedToSupport 检查订单是否被分享到客服工单
-func (s *OrderService) IsOrderSharedToSupport(orderID uint) (bool, error) {
-	return s.OrderRepo.IsOrderSharedToSupport(orderID)
-}
-
-// GetSharedOrderIDs 获取指定订单ID列表中被分享到客服的订单ID集合
-func (s *OrderService) GetSharedOrderIDs(orderIDs []uint) (map[uint]bool, error) {
-	return s.OrderRepo.GetSharedOrderIDs(orderIDs)
-}
@@ -23,7 +23,7 @@ func newOrderServiceTestDB(t *testing.T) (*OrderService, *gorm.DB) {
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-	if err := db.AutoMigrate(&models.User{}, &models.Product{}, &models.Order{}, &models.UserPurchaseStat{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.Product{}, &models.Order{}, &models.UserPurchaseStat{}, &models.OrganizationMember{}, &models.PolicyVersion{}, &models.PolicyConsent{}); err != nil {
 		t.Fatalf("auto migrate: %v", err)
 	}
 
@@ -67,11 +67,12 @@ func requireOrderBizErr(t *testing.T, err error, key string) *bizerr.Error {
 func TestCreateAdminOrderReturnsBizErrors(t *testing.T) {
 	svc, _ := newOrderServiceTestDB(t)
 
+	unitPrice := int64(100)
 	baseItem := AdminOrderItem{
 		SKU:         "SKU-1",
 		Name:        "Demo Product",
 		Quantity:    1,
-		UnitPrice:   100,
+		UnitPrice:   &unitPrice,
 		ProductType: string(models.ProductTypePhysical),
 	}
 
@@ -99,7 +100,7 @@ func TestCreateAdminOrderReturnsBizErrors(t *testing.T) {
 				SKU:         "VSKU-1",
 				Name:        "Virtual Demo",
 				Quantity:    1,
-				UnitPrice:   100,
+				UnitPrice:   &unitPrice,
 				ProductType: string(models.ProductTypeVirtual),
 			}},
 		})
@@ -121,15 +122,54 @@ func TestCreateAdminOrderProductNotFoundReturnsBizError(t *testing.T) {
 	requireOrderBizErr(t, func() error {
 		_, err := svc.CreateAdminOrder(AdminOrderRequest{
 			Items: []AdminOrderItem{{
-				SKU:       "MISSING-SKU",
-				Quantity:  1,
-				UnitPrice: 100,
+				SKU:      "MISSING-SKU",
+				Quantity: 1,
 			}},
 		})
 		return err
 	}(), "order.productNotFound")
 }
 
+func TestCreateAdminOrderDefaultsPriceAndAppliesDiscount(t *testing.T) {
+	svc, db := newOrderServiceTestDB(t)
+
+	product := models.Product{SKU: "SKU-PRICE", Name: "Catalog Product", ProductType: models.ProductTypePhysical, Price: 1500}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+
+	override := int64(800)
+	items := []AdminOrderItem{
+		{SKU: "SKU-PRICE", Quantity: 2},
+		{SKU: "SKU-PRICE", Quantity: 1, UnitPrice: &override},
+	}
+
+	requireOrderBizErr(t, func() error {
+		_, err := svc.CreateAdminOrder(AdminOrderRequest{Items: items, DiscountAmount: 3801})
+		return err
+	}(), "order.discountInvalid")
+
+	negative := int64(-1)
+	requireOrderBizErr(t, func() error {
+		_, err := svc.CreateAdminOrder(AdminOrderRequest{Items: []AdminOrderItem{{SKU: "SKU-PRICE", Quantity: 1, UnitPrice: &negative}}})
+		return err
+	}(), "order.unitPriceNegative")
+
+	order, err := svc.CreateAdminOrder(AdminOrderRequest{Items: items, DiscountAmount: 300, UserEmail: "guest@example.com"})
+	if err != nil {
+		t.Fatalf("create admin order: %v", err)
+	}
+	if order.Items[0].UnitPrice != 1500 || order.Items[1].UnitPrice != 800 {
+		t.Fatalf("unexpected unit prices: %d, %d", order.Items[0].UnitPrice, order.Items[1].UnitPrice)
+	}
+	if order.DiscountAmount != 300 || order.TotalAmount != 3500 {
+		t.Fatalf("expected discount 300 and total 3500, got %d and %d", order.DiscountAmount, order.TotalAmount)
+	}
+	if order.UserID != nil || order.UserEmail != "guest@example.com" {
+		t.Fatalf("expected guest order, got user=%v email=%q", order.UserID, order.UserEmail)
+	}
+}
+
 func TestCreateDraftAttributesTooManyReturnsBizError(t *testing.T) {
 	svc, _ := newOrderServiceTestDB(t)
 
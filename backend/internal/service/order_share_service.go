@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+// OrderShareMaxHours 订单分享给客服的最长有效期（30 天）
+const OrderShareMaxHours = 720
+
+// OrderShareOptions 订单分享设置
+type OrderShareOptions struct {
+	ExpiresInHours      int  // 有效小时数，0 表示不过期
+	IncludeReceiverInfo bool // 是否允许客服查看收货人姓名、电话、邮箱和详细地址
+}
+
+func (o OrderShareOptions) expiresAt(now time.Time) *time.Time {
+	if o.ExpiresInHours <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(time.Duration(o.ExpiresInHours) * time.Hour)
+	return &expiresAt
+}
+
+// OrderShare 订单当前生效的客服分享
+type OrderShare struct {
+	TicketID            uint       `json:"ticket_id"`
+	TicketNo            string     `json:"ticket_no"`
+	TicketSubject       string     `json:"ticket_subject"`
+	TicketStatus        string     `json:"ticket_status"`
+	IncludeReceiverInfo bool       `json:"include_receiver_info"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// OrderShareService 订单分享给工单客服的授权管理
+type OrderShareService struct {
+	db *gorm.DB
+}
+
+func NewOrderShareService(db *gorm.DB) *OrderShareService {
+	return &OrderShareService{db: db}
+}
+
+// activeShareScope 未撤销且未过期的授权
+func activeShareScope(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("ticket_order_access.expires_at IS NULL OR ticket_order_access.expires_at > ?", now)
+}
+
+// ListActiveShares 返回订单当前生效的分享（按分享时间倒序）
+func (s *OrderShareService) ListActiveShares(orderID uint) ([]OrderShare, error) {
+	var accesses []models.TicketOrderAccess
+	if err := activeShareScope(s.db.Preload("Ticket"), time.Now()).
+		Where("order_id = ?", orderID).
+		Order("created_at DESC").
+		Find(&accesses).Error; err != nil {
+		return nil, err
+	}
+
+	shares := make([]OrderShare, 0, len(accesses))
+	for _, access := range accesses {
+		share := OrderShare{
+			TicketID:            access.TicketID,
+			IncludeReceiverInfo: access.CanViewPrivacy,
+			ExpiresAt:           access.ExpiresAt,
+			CreatedAt:           access.CreatedAt,
+		}
+		if access.Ticket != nil {
+			share.TicketNo = access.Ticket.TicketNo
+			share.TicketSubject = access.Ticket.Subject
+			share.TicketStatus = string(access.Ticket.Status)
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// Share 将订单分享到工单，已存在的分享（包括已过期的）按新设置续期
+func (s *OrderShareService) Share(ticketID, orderID, grantedBy uint, opts OrderShareOptions) (*models.TicketOrderAccess, bool, error) {
+	expiresAt := opts.expiresAt(time.Now())
+
+	var access models.TicketOrderAccess
+	err := s.db.Where("ticket_id = ? AND order_id = ?", ticketID, orderID).First(&access).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	created := errors.Is(err, gorm.ErrRecordNotFound)
+	if created {
+		access = models.TicketOrderAccess{
+			TicketID:       ticketID,
+			OrderID:        orderID,
+			GrantedBy:      grantedBy,
+			CanView:        true,
+			CanEdit:        false,
+			CanViewPrivacy: opts.IncludeReceiverInfo,
+			ExpiresAt:      expiresAt,
+		}
+		if err := s.db.Create(&access).Error; err != nil {
+			return nil, false, err
+		}
+	} else {
+		// bool 零值需通过 map 更新
+		if err := s.db.Model(&access).Updates(map[string]interface{}{
+			"can_view_privacy": opts.IncludeReceiverInfo,
+			"expires_at":       expiresAt,
+		}).Error; err != nil {
+			return nil, false, err
+		}
+		access.CanViewPrivacy = opts.IncludeReceiverInfo
+		access.ExpiresAt = expiresAt
+	}
+
+	if err := repository.NewOrderRepository(s.db).RefreshSharedToSupport(orderID); err != nil {
+		return nil, false, err
+	}
+	return &access, created, nil
+}
+
+// OrderShareUpdate 修改分享设置，nil 字段保持不变
+type OrderShareUpdate struct {
+	ExpiresInHours      *int // 从当前时间起重新计算有效期，0 表示不过期
+	IncludeReceiverInfo *bool
+}
+
+// UpdateShare 修改已有分享的有效期和收货信息可见性，分享不存在时返回 gorm.ErrRecordNotFound
+func (s *OrderShareService) UpdateShare(ticketID, orderID uint, update OrderShareUpdate) (*models.TicketOrderAccess, error) {
+	var access models.TicketOrderAccess
+	if err := s.db.Where("ticket_id = ? AND order_id = ?", ticketID, orderID).First(&access).Error; err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if update.ExpiresInHours != nil {
+		access.ExpiresAt = OrderShareOptions{ExpiresInHours: *update.ExpiresInHours}.expiresAt(time.Now())
+		updates["expires_at"] = access.ExpiresAt
+	}
+	if update.IncludeReceiverInfo != nil {
+		access.CanViewPrivacy = *update.IncludeReceiverInfo
+		updates["can_view_privacy"] = access.CanViewPrivacy
+	}
+	if len(updates) == 0 {
+		return &access, nil
+	}
+	if err := s.db.Model(&access).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	if err := repository.NewOrderRepository(s.db).RefreshSharedToSupport(orderID); err != nil {
+		return nil, err
+	}
+	return &access, nil
+}
+
+// Revoke 撤销订单在工单中的分享
+func (s *OrderShareService) Revoke(ticketID, orderID uint) error {
+	if err := s.db.Where("ticket_id = ? AND order_id = ?", ticketID, orderID).
+		Delete(&models.TicketOrderAccess{}).Error; err != nil {
+		return err
+	}
+	return repository.NewOrderRepository(s.db).RefreshSharedToSupport(orderID)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestOrderShareServiceManagesSharesAndExpiry(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.Ticket{}, &models.TicketOrderAccess{}); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	userID := uint(9)
+	order := models.Order{OrderNo: "ORD-SHARE", UserID: &userID, Status: models.OrderStatusShipped, Items: []models.OrderItem{}}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	ticket := models.Ticket{TicketNo: "T-SHARE", UserID: userID, Subject: "Help", Content: "..."}
+	if err := db.Create(&ticket).Error; err != nil {
+		t.Fatalf("create ticket failed: %v", err)
+	}
+
+	requireShared := func(expected bool) {
+		t.Helper()
+		var reloaded models.Order
+		if err := db.First(&reloaded, order.ID).Error; err != nil {
+			t.Fatalf("reload order failed: %v", err)
+		}
+		if reloaded.SharedToSupport != expected {
+			t.Fatalf("expected shared_to_support=%v, got %v", expected, reloaded.SharedToSupport)
+		}
+	}
+
+	svc := NewOrderShareService(db)
+	access, created, err := svc.Share(ticket.ID, order.ID, userID, OrderShareOptions{ExpiresInHours: 24})
+	if err != nil || !created {
+		t.Fatalf("share order failed: created=%v err=%v", created, err)
+	}
+	if access.ExpiresAt == nil || access.CanViewPrivacy {
+		t.Fatalf("unexpected access: %+v", access)
+	}
+	requireShared(true)
+
+	shares, err := svc.ListActiveShares(order.ID)
+	if err != nil || len(shares) != 1 || shares[0].TicketNo != "T-SHARE" {
+		t.Fatalf("unexpected active shares: %+v err=%v", shares, err)
+	}
+
+	// 只修改收货信息可见性时保留原有效期
+	includeReceiverInfo := true
+	access, err = svc.UpdateShare(ticket.ID, order.ID, OrderShareUpdate{IncludeReceiverInfo: &includeReceiverInfo})
+	if err != nil {
+		t.Fatalf("update share failed: %v", err)
+	}
+	if access.ExpiresAt == nil || !access.CanViewPrivacy {
+		t.Fatalf("share settings not updated: %+v", access)
+	}
+	noExpiry := 0
+	if access, err = svc.UpdateShare(ticket.ID, order.ID, OrderShareUpdate{ExpiresInHours: &noExpiry}); err != nil || access.ExpiresAt != nil {
+		t.Fatalf("expected share to no longer expire: %+v err=%v", access, err)
+	}
+
+	// 模拟分享到期：列表不再展示，定期任务清除订单标记
+	past := time.Now().Add(-time.Hour)
+	if err := db.Model(&models.TicketOrderAccess{}).Where("id = ?", access.ID).Update("expires_at", past).Error; err != nil {
+		t.Fatalf("expire share failed: %v", err)
+	}
+	if shares, err = svc.ListActiveShares(order.ID); err != nil || len(shares) != 0 {
+		t.Fatalf("expected expired share to be hidden, got %+v err=%v", shares, err)
+	}
+	cleared, err := repository.NewOrderRepository(db).ClearExpiredSharedToSupport(time.Now())
+	if err != nil || cleared != 1 {
+		t.Fatalf("expected one order cleared, got %d err=%v", cleared, err)
+	}
+	requireShared(false)
+
+	// 重新分享会续期已过期的授权，而不是新建记录
+	if _, created, err = svc.Share(ticket.ID, order.ID, userID, OrderShareOptions{}); err != nil || created {
+		t.Fatalf("re-share should renew existing access: created=%v err=%v", created, err)
+	}
+	requireShared(true)
+
+	if err := svc.Revoke(ticket.ID, order.ID); err != nil {
+		t.Fatalf("revoke share failed: %v", err)
+	}
+	requireShared(false)
+	if _, err := svc.UpdateShare(ticket.ID, order.ID, OrderShareUpdate{}); err == nil {
+		t.Fatalf("expected updating a revoked share to fail")
+	}
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+// orderTimelineLimit 时间线最多返回的事件数
+const orderTimelineLimit = 500
+
+// 状态变更钩子 extra 中写入时间线 data 的字段，其余字段只传给插件
+var orderEventDataKeys = []string{
+	"transaction_id", "tracking_no", "payment_method", "payment_method_id", "refund_pending",
+	"invoice_no", "order_refund_id", "refund_request_id", "form_resubmit",
+}
+
+// 由管理员发起的状态变更动作（对应钩子 extra 的 trigger_action）
+var orderAdminTriggerActions = map[string]bool{
+	"order.admin.refund":           true,
+	"order.admin.refund_finalize":  true,
+	"order.refund_request.approve": true,
+	"order.partial_refund.issue":   true,
+	"order.assign_tracking":        true,
+	"order.deliver_virtual":        true,
+	"order.request_resubmit":       true,
+	"order.cancel":                 true,
+}
+
+// 发货类动作记为物流更新事件
+var orderShipmentTriggerActions = map[string]bool{
+	"order.assign_tracking": true,
+	"order.deliver_virtual": true,
+}
+
+// PublishOrderStatusChanged 记录订单状态变更到时间线，并异步触发 order.status.changed.after 钩子
+func PublishOrderStatusChanged(
+	orderRepo *repository.OrderRepository,
+	pluginManager *PluginManagerService,
+	execCtx *ExecutionContext,
+	order *models.Order,
+	beforeStatus models.OrderStatus,
+	afterStatus models.OrderStatus,
+	extra map[string]interface{},
+) {
+	if order == nil || beforeStatus == afterStatus {
+		return
+	}
+	recordOrderEvent(orderRepo, newOrderStatusChangedEvent(order, beforeStatus, afterStatus, extra))
+	EmitOrderStatusChangedAfterHookAsync(pluginManager, execCtx, order, beforeStatus, afterStatus, extra)
+}
+
+// recordOrderEvent 写入时间线事件，失败只记录日志，不影响业务流程
+func recordOrderEvent(orderRepo *repository.OrderRepository, event *models.OrderEvent) {
+	if orderRepo == nil || event == nil || event.OrderID == 0 {
+		return
+	}
+	if event.OperatorType == "" {
+		event.OperatorType = models.OrderEventOperatorSystem
+	}
+	if err := orderRepo.CreateOrderEvent(event); err != nil {
+		log.Printf("order timeline: failed to record %s event: order=%s err=%v", event.Type, event.OrderNo, err)
+	}
+}
+
+// recordOrderEventDB 供只持有 *gorm.DB 的服务写入时间线
+func recordOrderEventDB(db *gorm.DB, event *models.OrderEvent) {
+	if db == nil {
+		return
+	}
+	recordOrderEvent(repository.NewOrderRepository(db), event)
+}
+
+func newOrderStatusChangedEvent(
+	order *models.Order,
+	beforeStatus models.OrderStatus,
+	afterStatus models.OrderStatus,
+	extra map[string]interface{},
+) *models.OrderEvent {
+	event := &models.OrderEvent{
+		OrderID:      order.ID,
+		OrderNo:      order.OrderNo,
+		Type:         models.OrderEventTypeStatusChanged,
+		FromStatus:   beforeStatus,
+		ToStatus:     afterStatus,
+		Source:       orderEventExtraString(extra, "trigger_action"),
+		OperatorType: models.OrderEventOperatorSystem,
+		Message:      orderEventExtraString(extra, "reason", "cancel_reason"),
+		Remark:       orderEventExtraString(extra, "admin_remark", "remark"),
+	}
+	if event.Source == "" {
+		event.Source = orderEventExtraString(extra, "source")
+	}
+	if orderShipmentTriggerActions[event.Source] {
+		event.Type = models.OrderEventTypeShipment
+	}
+
+	operatorID := orderEventExtraUint(extra, "admin_id", "delivered_by", "completed_by")
+	switch {
+	case event.Source == "shipping_form.submit" || event.Source == "order.net_terms" || event.Source == "order.cash_on_delivery":
+		event.OperatorType = models.OrderEventOperatorUser
+		event.OperatorID = order.UserID
+	case event.Source == "order.complete":
+		// 用户确认收货与管理员完成订单共用同一动作，按操作人是否为下单用户区分
+		if operatorID != nil && order.UserID != nil && *operatorID == *order.UserID {
+			event.OperatorType = models.OrderEventOperatorUser
+		} else {
+			event.OperatorType = models.OrderEventOperatorAdmin
+		}
+		event.OperatorID = operatorID
+	case orderAdminTriggerActions[event.Source] || operatorID != nil:
+		event.OperatorType = models.OrderEventOperatorAdmin
+		event.OperatorID = operatorID
+	}
+
+	data := make(map[string]interface{})
+	for _, key := range orderEventDataKeys {
+		if value, ok := extra[key]; ok && value != nil && value != "" {
+			data[key] = value
+		}
+	}
+	event.Data = encodeOrderEventData(data)
+	return event
+}
+
+func orderEventExtraString(extra map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := extra[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func orderEventExtraUint(extra map[string]interface{}, keys ...string) *uint {
+	for _, key := range keys {
+		var id uint
+		switch v := extra[key].(type) {
+		case uint:
+			id = v
+		case *uint:
+			if v != nil {
+				id = *v
+			}
+		}
+		if id > 0 {
+			return &id
+		}
+	}
+	return nil
+}
+
+func encodeOrderEventData(data map[string]interface{}) models.JSON {
+	if len(data) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return models.JSON(raw)
+}
+
+// newOrderEvent 以订单当前状态构造一条非状态变更的时间线事件（付款尝试、物流更新等）
+func newOrderEvent(order *models.Order, eventType models.OrderEventType, source string, data map[string]interface{}) *models.OrderEvent {
+	return &models.OrderEvent{
+		OrderID:      order.ID,
+		OrderNo:      order.OrderNo,
+		Type:         eventType,
+		ToStatus:     order.Status,
+		Source:       source,
+		OperatorType: models.OrderEventOperatorSystem,
+		Data:         encodeOrderEventData(data),
+	}
+}
+
+// recordOrderCreated 记录下单事件，时间与订单创建时间一致
+func (s *OrderService) recordOrderCreated(order *models.Order, source, operatorType string, operatorID *uint) {
+	if order == nil {
+		return
+	}
+	recordOrderEvent(s.OrderRepo, &models.OrderEvent{
+		OrderID:      order.ID,
+		OrderNo:      order.OrderNo,
+		Type:         models.OrderEventTypeCreated,
+		ToStatus:     order.Status,
+		Source:       source,
+		OperatorType: operatorType,
+		OperatorID:   operatorID,
+		CreatedAt:    order.CreatedAt,
+	})
+}
+
+// AddAdminRemark 追加管理员备注，同时写入订单的 admin_remark 与时间线（仅管理员可见）
+func (s *OrderService) AddAdminRemark(orderID, adminID uint, remark string) (*models.OrderEvent, error) {
+	remark = strings.TrimSpace(remark)
+	var event *models.OrderEvent
+	err := s.OrderRepo.WithTransaction(func(tx *gorm.DB) error {
+		order, err := s.OrderRepo.FindByIDForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		next := order.AdminRemark
+		if strings.TrimSpace(next) != "" {
+			next += "\n"
+		}
+		next += remark
+		if err := tx.Model(&models.Order{}).Where("id = ?", order.ID).Update("admin_remark", next).Error; err != nil {
+			return err
+		}
+		operatorID := adminID
+		event = &models.OrderEvent{
+			OrderID:      order.ID,
+			OrderNo:      order.OrderNo,
+			Type:         models.OrderEventTypeRemark,
+			ToStatus:     order.Status,
+			Source:       "order.admin.remark",
+			OperatorType: models.OrderEventOperatorAdmin,
+			OperatorID:   &operatorID,
+			Remark:       remark,
+			Internal:     true,
+		}
+		return repository.NewOrderRepository(tx).CreateOrderEvent(event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetOrderTimeline 返回订单时间线，按时间正序
+// 面向用户时排除内部事件，并隐藏管理员备注与操作人 ID
+// 时间线功能上线前创建的订单缺少早期事件，按订单上的时间字段补出主要节点
+func (s *OrderService) GetOrderTimeline(order *models.Order, forAdmin bool) ([]models.OrderEvent, error) {
+	events, err := s.OrderRepo.FindOrderEvents(order.ID, forAdmin, orderTimelineLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].Type != models.OrderEventTypeCreated {
+		legacy := synthesizeLegacyOrderTimeline(order)
+		// 只补事件记录开始之前的节点
+		if len(events) > 0 {
+			kept := legacy[:0]
+			for _, event := range legacy {
+				if event.CreatedAt.Before(events[0].CreatedAt) {
+					kept = append(kept, event)
+				}
+			}
+			legacy = kept
+		}
+		events = append(legacy, events...)
+	}
+	if !forAdmin {
+		for i := range events {
+			events[i].Remark = ""
+			events[i].OperatorID = nil
+		}
+	}
+	return events, nil
+}
+
+func synthesizeLegacyOrderTimeline(order *models.Order) []models.OrderEvent {
+	newEvent := func(eventType models.OrderEventType, status models.OrderStatus) models.OrderEvent {
+		return models.OrderEvent{
+			OrderID:      order.ID,
+			OrderNo:      order.OrderNo,
+			Type:         eventType,
+			ToStatus:     status,
+			Source:       "legacy",
+			OperatorType: models.OrderEventOperatorSystem,
+		}
+	}
+
+	events := []models.OrderEvent{}
+	created := newEvent(models.OrderEventTypeCreated, "")
+	created.CreatedAt = order.CreatedAt
+	events = append(events, created)
+	if order.PaidAt != nil {
+		paid := newEvent(models.OrderEventTypePayment, "")
+		paid.Source = "payment.paid"
+		paid.CreatedAt = *order.PaidAt
+		events = append(events, paid)
+	}
+	if order.ShippedAt != nil {
+		shipped := newEvent(models.OrderEventTypeShipment, models.OrderStatusShipped)
+		shipped.CreatedAt = *order.ShippedAt
+		if order.TrackingNo != "" {
+			shipped.Data = encodeOrderEventData(map[string]interface{}{"tracking_no": order.TrackingNo})
+		}
+		events = append(events, shipped)
+	}
+	if order.CompletedAt != nil {
+		completed := newEvent(models.OrderEventTypeStatusChanged, models.OrderStatusCompleted)
+		completed.CreatedAt = *order.CompletedAt
+		events = append(events, completed)
+	}
+	if order.RefundedAt != nil {
+		refunded := newEvent(models.OrderEventTypeStatusChanged, models.OrderStatusRefunded)
+		refunded.CreatedAt = *order.RefundedAt
+		events = append(events, refunded)
+	}
+	if order.Status == models.OrderStatusCancelled {
+		cancelled := newEvent(models.OrderEventTypeStatusChanged, models.OrderStatusCancelled)
+		cancelled.CreatedAt = order.UpdatedAt
+		events = append(events, cancelled)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestOrderTimelineRecordsEventsAndHidesInternalRemarks(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	userID := uint(7)
+	order := &models.Order{OrderNo: "ORD-TIMELINE", UserID: &userID, Status: models.OrderStatusPendingPayment, Items: []models.OrderItem{}}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	svc := &OrderService{OrderRepo: repository.NewOrderRepository(db)}
+
+	svc.recordOrderCreated(order, "order.create", models.OrderEventOperatorUser, &userID)
+	PublishOrderStatusChanged(svc.OrderRepo, nil, nil, order, models.OrderStatusPendingPayment, models.OrderStatusPending, map[string]interface{}{
+		"trigger_action": "payment.confirm",
+		"transaction_id": "TX-1",
+		"retry_count":    3,
+	})
+	PublishOrderStatusChanged(svc.OrderRepo, nil, nil, order, models.OrderStatusPending, models.OrderStatusShipped, map[string]interface{}{
+		"trigger_action": "order.assign_tracking",
+		"tracking_no":    "SF123",
+	})
+	PublishOrderStatusChanged(svc.OrderRepo, nil, nil, order, models.OrderStatusShipped, models.OrderStatusCompleted, map[string]interface{}{
+		"trigger_action": "order.complete",
+		"completed_by":   userID,
+		"admin_remark":   "",
+	})
+	// 状态未变化不记录
+	PublishOrderStatusChanged(svc.OrderRepo, nil, nil, order, models.OrderStatusCompleted, models.OrderStatusCompleted, nil)
+	if _, err := svc.AddAdminRemark(order.ID, 1, "VIP customer"); err != nil {
+		t.Fatalf("add admin remark failed: %v", err)
+	}
+
+	adminEvents, err := svc.GetOrderTimeline(order, true)
+	if err != nil {
+		t.Fatalf("load admin timeline failed: %v", err)
+	}
+	expectedTypes := []models.OrderEventType{
+		models.OrderEventTypeCreated,
+		models.OrderEventTypeStatusChanged,
+		models.OrderEventTypeShipment,
+		models.OrderEventTypeStatusChanged,
+		models.OrderEventTypeRemark,
+	}
+	if len(adminEvents) != len(expectedTypes) {
+		t.Fatalf("expected %d admin events, got %+v", len(expectedTypes), adminEvents)
+	}
+	for i, eventType := range expectedTypes {
+		if adminEvents[i].Type != eventType {
+			t.Fatalf("expected event %d to be %s, got %s", i, eventType, adminEvents[i].Type)
+		}
+	}
+	if adminEvents[1].OperatorType != models.OrderEventOperatorSystem || string(adminEvents[1].Data) != `{"transaction_id":"TX-1"}` {
+		t.Fatalf("unexpected payment confirm event: %+v", adminEvents[1])
+	}
+	if adminEvents[2].OperatorType != models.OrderEventOperatorAdmin || adminEvents[2].ToStatus != models.OrderStatusShipped {
+		t.Fatalf("unexpected shipment event: %+v", adminEvents[2])
+	}
+	if adminEvents[3].OperatorType != models.OrderEventOperatorUser || adminEvents[3].OperatorID == nil || *adminEvents[3].OperatorID != userID {
+		t.Fatalf("expected completion to be attributed to the buyer, got %+v", adminEvents[3])
+	}
+	if remark := adminEvents[4]; !remark.Internal || remark.Remark != "VIP customer" || remark.OperatorType != models.OrderEventOperatorAdmin {
+		t.Fatalf("unexpected remark event: %+v", remark)
+	}
+
+	var stored models.Order
+	if err := db.First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if stored.AdminRemark != "VIP customer" {
+		t.Fatalf("expected admin remark to be saved on order, got %q", stored.AdminRemark)
+	}
+
+	userEvents, err := svc.GetOrderTimeline(order, false)
+	if err != nil {
+		t.Fatalf("load user timeline failed: %v", err)
+	}
+	if len(userEvents) != 4 {
+		t.Fatalf("expected internal remark to be hidden from user, got %+v", userEvents)
+	}
+	for _, event := range userEvents {
+		if event.Internal || event.Remark != "" || event.OperatorID != nil {
+			t.Fatalf("expected user timeline to be sanitized, got %+v", event)
+		}
+	}
+}
+
+func TestOrderTimelineSynthesizesLegacyEvents(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate tables failed: %v", err)
+	}
+
+	createdAt := time.Now().Add(-72 * time.Hour)
+	paidAt := createdAt.Add(time.Hour)
+	shippedAt := createdAt.Add(24 * time.Hour)
+	order := &models.Order{
+		OrderNo:    "ORD-LEGACY",
+		Status:     models.OrderStatusShipped,
+		TrackingNo: "SF999",
+		PaidAt:     &paidAt,
+		ShippedAt:  &shippedAt,
+		Items:      []models.OrderItem{},
+		CreatedAt:  createdAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	svc := &OrderService{OrderRepo: repository.NewOrderRepository(db)}
+
+	events, err := svc.GetOrderTimeline(order, false)
+	if err != nil {
+		t.Fatalf("load timeline failed: %v", err)
+	}
+	if len(events) != 3 || events[0].Type != models.OrderEventTypeCreated || events[1].Type != models.OrderEventTypePayment || events[2].Type != models.OrderEventTypeShipment {
+		t.Fatalf("unexpected legacy timeline: %+v", events)
+	}
+	if string(events[2].Data) != `{"tracking_no":"SF999"}` {
+		t.Fatalf("expected tracking number on shipment event, got %q", events[2].Data)
+	}
+
+	// 上线后产生的事件接在补出的节点之后
+	PublishOrderStatusChanged(svc.OrderRepo, nil, nil, order, models.OrderStatusShipped, models.OrderStatusCompleted, map[string]interface{}{
+		"trigger_action": "order.auto_complete",
+	})
+	events, err = svc.GetOrderTimeline(order, false)
+	if err != nil {
+		t.Fatalf("reload timeline failed: %v", err)
+	}
+	if len(events) != 4 || events[3].ToStatus != models.OrderStatusCompleted || events[3].Source != "order.auto_complete" {
+		t.Fatalf("expected recorded event after legacy events, got %+v", events)
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/cache"
+	"gorm.io/gorm"
+)
+
+const (
+	// 签名链接默认有效期
+	defaultOrderTrackingLinkTTL = 30 * 24 * time.Hour
+	// 同一订单号凭邮箱查询失败的次数上限，防止换 IP 猜测邮箱
+	orderTrackingMaxFailedAttempts = 5
+	orderTrackingFailureWindow     = 15 * time.Minute
+)
+
+// 订单查询时间线节点
+const (
+	OrderTrackingCheckpointPlaced    = "placed"
+	OrderTrackingCheckpointPaid      = "paid"
+	OrderTrackingCheckpointShipped   = "shipped"
+	OrderTrackingCheckpointCompleted = "completed"
+	OrderTrackingCheckpointRefunded  = "refunded"
+)
+
+func newOrderTrackingDisabledError() error {
+	return bizerr.New("order_tracking.disabled", "Order tracking is not available")
+}
+
+// 订单不存在与邮箱不匹配返回同一错误，避免被用来探测订单号
+func newOrderTrackingNotFoundError() error {
+	return bizerr.New("order_tracking.notFound", "No order matches the order number and email")
+}
+
+func newOrderTrackingLinkInvalidError() error {
+	return bizerr.New("order_tracking.linkInvalid", "The tracking link is invalid or has expired")
+}
+
+func newOrderTrackingTooManyAttemptsError() error {
+	return bizerr.Newf("order_tracking.tooManyAttempts", "Too many failed attempts, please try again in %d minutes", int(orderTrackingFailureWindow/time.Minute)).
+		WithParams(map[string]interface{}{"minutes": int(orderTrackingFailureWindow / time.Minute)})
+}
+
+// OrderTrackingCheckpoint 订单状态时间线节点
+type OrderTrackingCheckpoint struct {
+	Key string    `json:"key"`
+	At  time.Time `json:"at"`
+}
+
+// OrderTrackingItem 公开查询页展示的商品（仅名称与数量）
+type OrderTrackingItem struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	ImageURL string `json:"image_url,omitempty"`
+}
+
+// PublicOrderTracking 免登录订单查询结果，不包含收货人、联系方式与金额等个人信息
+type PublicOrderTracking struct {
+	OrderNo             string                    `json:"order_no"`
+	Status              models.OrderStatus        `json:"status"`
+	Items               []OrderTrackingItem       `json:"items"`
+	TrackingNo          string                    `json:"tracking_no,omitempty"`
+	ReceiverCountry     string                    `json:"receiver_country,omitempty"`
+	Checkpoints         []OrderTrackingCheckpoint `json:"checkpoints"`
+	EstimatedDeliveryAt *time.Time                `json:"estimated_delivery_at,omitempty"`
+}
+
+// OrderTrackingService 免登录订单查询（游客下单的买家凭订单号+邮箱或签名链接查看订单状态）
+type OrderTrackingService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewOrderTrackingService 创建订单查询服务
+func NewOrderTrackingService(db *gorm.DB, cfg *config.Config) *OrderTrackingService {
+	return &OrderTrackingService{db: db, cfg: cfg}
+}
+
+func (s *OrderTrackingService) enabled() bool {
+	return s.cfg != nil && s.cfg.Order.PublicTracking.Enabled
+}
+
+func (s *OrderTrackingService) linkTTL() time.Duration {
+	if s.cfg != nil && s.cfg.Order.PublicTracking.LinkTTLDays > 0 {
+		return time.Duration(s.cfg.Order.PublicTracking.LinkTTLDays) * 24 * time.Hour
+	}
+	return defaultOrderTrackingLinkTTL
+}
+
+// sign 订单查询链接签名
+func (s *OrderTrackingService) sign(orderNo string, expires int64) string {
+	return signOrderLink(s.cfg, orderLinkPurposeTracking, orderNo, expires)
+}
+
+// TrackingURL 生成发货邮件中的签名查询链接，未开启时返回空字符串
+func (s *OrderTrackingService) TrackingURL(orderNo string) string {
+	if !s.enabled() || orderNo == "" {
+		return ""
+	}
+	query := orderLinkQuery(s.cfg, orderLinkPurposeTracking, orderNo, time.Now().Add(s.linkTTL()).Unix())
+	return strings.TrimRight(s.cfg.App.URL, "/") + "/track-order?" + query.Encode()
+}
+
+// LookupBySignature 凭签名链接查询订单
+func (s *OrderTrackingService) LookupBySignature(orderNo, expires, sig string) (*PublicOrderTracking, error) {
+	if !s.enabled() {
+		return nil, newOrderTrackingDisabledError()
+	}
+	orderNo, ok := verifyOrderLink(s.cfg, orderLinkPurposeTracking, orderNo, expires, sig)
+	if !ok {
+		return nil, newOrderTrackingLinkInvalidError()
+	}
+
+	order, err := s.loadOrder(orderNo)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newOrderTrackingLinkInvalidError()
+		}
+		return nil, err
+	}
+	return s.buildView(order), nil
+}
+
+// LookupByEmail 凭订单号+下单邮箱（或收货邮箱）查询订单
+func (s *OrderTrackingService) LookupByEmail(orderNo, email string) (*PublicOrderTracking, error) {
+	if !s.enabled() {
+		return nil, newOrderTrackingDisabledError()
+	}
+	orderNo = strings.TrimSpace(orderNo)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if orderNo == "" || email == "" {
+		return nil, newOrderTrackingNotFoundError()
+	}
+	if s.failedAttempts(orderNo) >= orderTrackingMaxFailedAttempts {
+		return nil, newOrderTrackingTooManyAttemptsError()
+	}
+
+	order, err := s.loadOrder(orderNo)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if order == nil || !orderTrackingEmailMatches(order, email) {
+		s.recordFailedAttempt(orderNo)
+		return nil, newOrderTrackingNotFoundError()
+	}
+	return s.buildView(order), nil
+}
+
+func (s *OrderTrackingService) loadOrder(orderNo string) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func orderTrackingEmailMatches(order *models.Order, email string) bool {
+	for _, candidate := range []string{order.UserEmail, order.ReceiverEmail} {
+		if candidate != "" && strings.EqualFold(strings.TrimSpace(candidate), email) {
+			return true
+		}
+	}
+	return false
+}
+
+func orderTrackingFailureKey(orderNo string) string {
+	return fmt.Sprintf("order_tracking_fail:%s", orderNo)
+}
+
+// failedAttempts Redis 不可用时不限制（仍受接口 IP 限流保护）
+func (s *OrderTrackingService) failedAttempts(orderNo string) int64 {
+	if cache.RedisClient == nil {
+		return 0
+	}
+	value, err := cache.Get(orderTrackingFailureKey(orderNo))
+	if err != nil {
+		return 0
+	}
+	count, _ := strconv.ParseInt(value, 10, 64)
+	return count
+}
+
+func (s *OrderTrackingService) recordFailedAttempt(orderNo string) {
+	if cache.RedisClient == nil {
+		return
+	}
+	key := orderTrackingFailureKey(orderNo)
+	count, err := cache.Incr(key)
+	if err == nil && count == 1 {
+		_ = cache.Expire(key, orderTrackingFailureWindow)
+	}
+}
+
+func (s *OrderTrackingService) buildView(order *models.Order) *PublicOrderTracking {
+	view := &PublicOrderTracking{
+		OrderNo:         order.OrderNo,
+		Status:          order.Status,
+		Items:           make([]OrderTrackingItem, 0, len(order.Items)),
+		TrackingNo:      order.TrackingNo,
+		ReceiverCountry: order.ReceiverCountry,
+		Checkpoints:     []OrderTrackingCheckpoint{{Key: OrderTrackingCheckpointPlaced, At: order.CreatedAt}},
+	}
+	for _, item := range order.Items {
+		view.Items = append(view.Items, OrderTrackingItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			ImageURL: item.ImageURL,
+		})
+	}
+
+	for _, checkpoint := range []struct {
+		key string
+		at  *time.Time
+	}{
+		{OrderTrackingCheckpointPaid, order.PaidAt},
+		{OrderTrackingCheckpointShipped, order.ShippedAt},
+		{OrderTrackingCheckpointCompleted, order.CompletedAt},
+		{OrderTrackingCheckpointRefunded, order.RefundedAt},
+	} {
+		if checkpoint.at != nil {
+			view.Checkpoints = append(view.Checkpoints, OrderTrackingCheckpoint{Key: checkpoint.key, At: *checkpoint.at})
+		}
+	}
+
+	if order.Status == models.OrderStatusShipped && order.ShippedAt != nil &&
+		s.cfg != nil && s.cfg.Order.PublicTracking.EstimatedDeliveryDays > 0 {
+		eta := order.ShippedAt.AddDate(0, 0, s.cfg.Order.PublicTracking.EstimatedDeliveryDays)
+		view.EstimatedDeliveryAt = &eta
+	}
+	return view
+}
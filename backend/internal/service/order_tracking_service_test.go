@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newOrderTrackingTestService(t *testing.T) (*OrderTrackingService, *models.Order) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file:"+strings.ReplaceAll(t.Name(), "/", "_")+"?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+
+	shippedAt := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	paidAt := shippedAt.Add(-24 * time.Hour)
+	order := &models.Order{
+		OrderNo:         "ORD-TRACK-1",
+		Status:          models.OrderStatusShipped,
+		Items:           []models.OrderItem{{SKU: "TEE-1", Name: "Tee", Quantity: 2}},
+		ReceiverName:    "Alice Buyer",
+		ReceiverPhone:   "13800000000",
+		ReceiverAddress: "1 Secret Street",
+		ReceiverCountry: "US",
+		UserEmail:       "Guest@Example.com",
+		TrackingNo:      "SF123",
+		PaidAt:          &paidAt,
+		ShippedAt:       &shippedAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.App.URL = "https://shop.example.com/"
+	cfg.JWT.Secret = "order-tracking-test-secret-0123456789"
+	cfg.Order.PublicTracking = config.PublicTrackingConfig{Enabled: true, EstimatedDeliveryDays: 5}
+	return NewOrderTrackingService(db, cfg), order
+}
+
+func TestOrderTrackingSignedLink(t *testing.T) {
+	svc, order := newOrderTrackingTestService(t)
+
+	link, err := url.Parse(svc.TrackingURL(order.OrderNo))
+	if err != nil || link.Host != "shop.example.com" || link.Path != "/track-order" {
+		t.Fatalf("unexpected tracking url: %v (%v)", link, err)
+	}
+	query := link.Query()
+
+	view, err := svc.LookupBySignature(query.Get("order_no"), query.Get("expires"), query.Get("sig"))
+	if err != nil {
+		t.Fatalf("lookup by signature failed: %v", err)
+	}
+	if view.Status != models.OrderStatusShipped || view.TrackingNo != "SF123" || len(view.Checkpoints) != 3 {
+		t.Fatalf("unexpected view: %+v", view)
+	}
+	if view.EstimatedDeliveryAt == nil || !view.EstimatedDeliveryAt.Equal(order.ShippedAt.AddDate(0, 0, 5)) {
+		t.Fatalf("unexpected eta: %v", view.EstimatedDeliveryAt)
+	}
+	payload, _ := json.Marshal(view)
+	for _, secret := range []string{"Alice", "13800000000", "Secret Street", "Guest@Example.com"} {
+		if strings.Contains(string(payload), secret) {
+			t.Fatalf("public view leaked %q: %s", secret, payload)
+		}
+	}
+
+	_, err = svc.LookupBySignature("ORD-OTHER", query.Get("expires"), query.Get("sig"))
+	requireBizErr(t, err, "order_tracking.linkInvalid")
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	_, err = svc.LookupBySignature(order.OrderNo, strconv.FormatInt(expired, 10), svc.sign(order.OrderNo, expired))
+	requireBizErr(t, err, "order_tracking.linkInvalid")
+
+	svc.cfg.Order.PublicTracking.Enabled = false
+	if svc.TrackingURL(order.OrderNo) != "" {
+		t.Fatalf("expected no tracking url when disabled")
+	}
+	_, err = svc.LookupBySignature(query.Get("order_no"), query.Get("expires"), query.Get("sig"))
+	requireBizErr(t, err, "order_tracking.disabled")
+}
+
+func TestOrderTrackingLookupByEmailLocksAfterFailures(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		_ = cache.RedisClient.Close()
+		cache.RedisClient = previousClient
+	}()
+
+	svc, order := newOrderTrackingTestService(t)
+
+	view, err := svc.LookupByEmail(order.OrderNo, " guest@example.com ")
+	if err != nil || view.OrderNo != order.OrderNo {
+		t.Fatalf("expected case-insensitive email match, got %+v err=%v", view, err)
+	}
+
+	_, err = svc.LookupByEmail("ORD-MISSING", "guest@example.com")
+	requireBizErr(t, err, "order_tracking.notFound")
+
+	for i := 0; i < orderTrackingMaxFailedAttempts; i++ {
+		_, err = svc.LookupByEmail(order.OrderNo, "wrong@example.com")
+		requireBizErr(t, err, "order_tracking.notFound")
+	}
+	_, err = svc.LookupByEmail(order.OrderNo, "guest@example.com")
+	requireBizErr(t, err, "order_tracking.tooManyAttempts")
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/dbutil"
@@ -17,6 +18,7 @@ type paidOrderFinalizeOptions struct {
 	AdminRemark             string
 	SkipAutoDelivery        bool
 	StrictAutoDeliveryCheck bool
+	Config                  *config.Config // 用于记录付款时的结算汇率快照
 }
 
 type paidOrderFinalizeResult struct {
@@ -145,7 +147,14 @@ func finalizePendingPaymentOrderTx(tx *gorm.DB, order *models.Order, virtualSvc
 	}
 	result.IsVirtualOnly = isVirtualOnly
 
-	txUpdates := map[string]interface{}{}
+	paidAt := models.NowFunc()
+	fx := resolveOrderFXSnapshot(options.Config, order.Currency, order.TotalAmount)
+	txUpdates := map[string]interface{}{
+		"paid_at":          paidAt,
+		"fx_base_currency": fx.BaseCurrency,
+		"fx_rate":          fx.Rate,
+		"fx_base_amount":   fx.BaseAmount,
+	}
 	if isVirtualOnly {
 		txUpdates["status"] = models.OrderStatusPending
 	} else {
@@ -167,7 +176,8 @@ func finalizePendingPaymentOrderTx(tx *gorm.DB, order *models.Order, virtualSvc
 			}
 			canAuto = false
 		}
-		if options.SkipAutoDelivery {
+		// 风控暂扣的订单等审核通过后再发货
+		if options.SkipAutoDelivery || order.RiskHold {
 			canAuto = false
 		}
 		if canAuto {
@@ -200,8 +210,18 @@ func finalizePendingPaymentOrderTx(tx *gorm.DB, order *models.Order, virtualSvc
 	if err := tx.Model(order).Updates(txUpdates).Error; err != nil {
 		return nil, err
 	}
+	if err := recordVendorSalesTx(tx, order); err != nil {
+		return nil, fmt.Errorf("failed to record vendor sales: %w", err)
+	}
+	if _, err := recordRevenueSchedulesTx(tx, order, paidAt); err != nil {
+		return nil, fmt.Errorf("failed to record revenue schedules: %w", err)
+	}
 
 	result.Updated = true
+	order.PaidAt = &paidAt
+	order.FXBaseCurrency = fx.BaseCurrency
+	order.FXRate = fx.Rate
+	order.FXBaseAmount = fx.BaseAmount
 	if status, ok := txUpdates["status"].(models.OrderStatus); ok {
 		result.FinalStatus = status
 		order.Status = status
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+
+	"auralogic/internal/models"
+)
+
+const (
+	// OrderSourceTrial 发放试用卡密的零金额订单
+	OrderSourceTrial = "trial"
+	// OrderSourceTrialConversion 试用转正的待付款订单
+	OrderSourceTrialConversion = "trial_conversion"
+)
+
+func (s *OrderService) defaultOrderCurrency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+// CreateTrialOrder 为试用生成零金额订单并立即发货
+// 订单只有一件试用商品，不经过购物车、优惠码与限购校验；发放失败时回滚已分配的虚拟库存
+func (s *OrderService) CreateTrialOrder(user *models.User, product *models.Product, days int) (*models.Order, error) {
+	if s.virtualProductSvc == nil {
+		return nil, newOrderVirtualServiceUnavailableError()
+	}
+	if user == nil || product == nil {
+		return nil, fmt.Errorf("user and product are required")
+	}
+
+	orderNo, err := s.orderNumbers.Allocate("")
+	if err != nil {
+		return nil, err
+	}
+	userID := user.ID
+	order := &models.Order{
+		OrderNo: orderNo,
+		UserID:  &userID,
+		Items: []models.OrderItem{{
+			SKU:         product.SKU,
+			Name:        product.Name,
+			Quantity:    1,
+			ImageURL:    product.GetPrimaryImage(),
+			ProductType: models.ProductTypeVirtual,
+			VendorID:    product.VendorID,
+		}},
+		Status:                    models.OrderStatusPendingPayment,
+		Currency:                  s.defaultOrderCurrency(),
+		Source:                    OrderSourceTrial,
+		UserEmail:                 user.Email,
+		EmailNotificationsEnabled: true,
+		Remark:                    fmt.Sprintf("%d-day trial", days),
+	}
+	if err := s.OrderRepo.Create(order); err != nil {
+		return nil, err
+	}
+
+	_, scriptInvID, err := s.virtualProductSvc.AllocateStockForProductByAttributes(product.ID, 1, orderNo, nil)
+	if err != nil {
+		if releaseErr := s.virtualProductSvc.ReleaseStock(orderNo); releaseErr != nil {
+			fmt.Printf("Warning: Failed to rollback virtual stock for trial order %s: %v\n", orderNo, releaseErr)
+		}
+		s.OrderRepo.Delete(order.ID)
+		return nil, fmt.Errorf("failed to allocate virtual product stock: %w", err)
+	}
+	if scriptInvID != nil {
+		order.VirtualInventoryBindings = map[int]uint{0: *scriptInvID}
+		s.OrderRepo.Update(order)
+	}
+	s.recordOrderCreated(order, "order.trial", models.OrderEventOperatorUser, &userID)
+
+	if err := s.MarkAsPaid(order.ID); err != nil {
+		if cancelErr := s.CancelOrder(order.ID, "Trial issuance failed"); cancelErr != nil {
+			fmt.Printf("Warning: Failed to cancel trial order %s: %v\n", orderNo, cancelErr)
+		}
+		return nil, err
+	}
+	return s.OrderRepo.FindByID(order.ID)
+}
+
+// CreateTrialConversionOrder 为即将到期的试用生成转正待付款订单
+// 订单不占用库存，付款后试用卡密转为正式授权；买家、币种与通知邮箱沿用试用订单
+func (s *OrderService) CreateTrialConversionOrder(trial *models.ProductTrial, product *models.Product, source *models.Order) (*models.Order, error) {
+	if trial == nil || product == nil || source == nil {
+		return nil, fmt.Errorf("trial, product and source order are required")
+	}
+	if product.Price <= 0 {
+		return nil, fmt.Errorf("product %d has no price for trial conversion", product.ID)
+	}
+
+	orderNo, err := s.orderNumbers.Allocate("")
+	if err != nil {
+		return nil, err
+	}
+	currency := source.Currency
+	if currency == "" {
+		currency = s.defaultOrderCurrency()
+	}
+
+	order := &models.Order{
+		OrderNo: orderNo,
+		UserID:  source.UserID,
+		Items: []models.OrderItem{{
+			SKU:                 fmt.Sprintf("TRIAL-CONVERT-%d", trial.ID),
+			Name:                fmt.Sprintf("%s (trial %s)", product.Name, source.OrderNo),
+			Quantity:            1,
+			ImageURL:            product.GetPrimaryImage(),
+			ProductType:         models.ProductTypeVirtual,
+			UnitPrice:           product.Price,
+			VendorID:            product.VendorID,
+			RevenueRecognition:  product.RevenueRecognition,
+			ServicePeriodMonths: product.ServicePeriodMonths,
+		}},
+		Status:                    models.OrderStatusPendingPayment,
+		TotalAmount:               product.Price,
+		Currency:                  currency,
+		Source:                    OrderSourceTrialConversion,
+		UserEmail:                 source.UserEmail,
+		EmailNotificationsEnabled: source.EmailNotificationsEnabled,
+		Remark:                    fmt.Sprintf("Trial conversion for order %s", source.OrderNo),
+	}
+	applyOrderPriceRounding(s.cfg, order)
+
+	if err := s.OrderRepo.Create(order); err != nil {
+		return nil, err
+	}
+	s.recordOrderCreated(order, "order.trial_conversion", models.OrderEventOperatorSystem, nil)
+
+	return order, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"auralogic/internal/models"
+)
+
+// OrderSourceUsageOverage 用量超额订单的来源标识
+const OrderSourceUsageOverage = "usage_overage"
+
+// CreateUsageOverageOrder 为计量超出额度的用量生成待付款订单
+// 订单只有一个虚拟商品项，不占用库存；买家、币种与通知邮箱沿用卡密所属订单
+func (s *OrderService) CreateUsageOverageOrder(source *models.Order, meter *models.UsageMeter, units int64) (*models.Order, error) {
+	if source == nil || meter == nil {
+		return nil, fmt.Errorf("source order and meter are required")
+	}
+	if units <= 0 || units > math.MaxInt32 || meter.OverageUnitPrice <= 0 {
+		return nil, fmt.Errorf("invalid overage units %d for meter %d", units, meter.ID)
+	}
+	if meter.OverageUnitPrice > math.MaxInt64/units {
+		return nil, fmt.Errorf("overage amount overflows for meter %d", meter.ID)
+	}
+
+	orderNo, err := s.orderNumbers.Allocate("")
+	if err != nil {
+		return nil, err
+	}
+
+	unit := strings.TrimSpace(meter.Unit)
+	if unit == "" {
+		unit = "usage"
+	}
+	currency := source.Currency
+	if currency == "" {
+		currency = s.cfg.Order.Currency
+	}
+	if currency == "" {
+		currency = "CNY"
+	}
+
+	order := &models.Order{
+		OrderNo: orderNo,
+		UserID:  source.UserID,
+		Items: []models.OrderItem{{
+			SKU:         fmt.Sprintf("USAGE-OVERAGE-%d", meter.VirtualInventoryID),
+			Name:        fmt.Sprintf("%s overage (%s, %s)", unit, source.OrderNo, meter.LicenseHint),
+			Quantity:    int(units),
+			ProductType: models.ProductTypeVirtual,
+			UnitPrice:   meter.OverageUnitPrice,
+		}},
+		Status:                    models.OrderStatusPendingPayment,
+		TotalAmount:               meter.OverageUnitPrice * units,
+		Currency:                  currency,
+		Source:                    OrderSourceUsageOverage,
+		UserEmail:                 source.UserEmail,
+		EmailNotificationsEnabled: source.EmailNotificationsEnabled,
+		Remark:                    fmt.Sprintf("Usage overage for order %s", source.OrderNo),
+	}
+	applyOrderPriceRounding(s.cfg, order)
+
+	if err := s.OrderRepo.Create(order); err != nil {
+		return nil, err
+	}
+	s.recordOrderCreated(order, "order.usage_overage", models.OrderEventOperatorSystem, nil)
+
+	return order, nil
+}
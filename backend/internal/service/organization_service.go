@@ -0,0 +1,598 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/money"
+	"gorm.io/gorm"
+)
+
+const (
+	maxOrganizationNameLength   = 200
+	organizationInvitationTTL   = 7 * 24 * time.Hour
+	organizationInvitationBytes = 32
+)
+
+var (
+	ErrOrganizationNotFound           = errors.New("organization not found")
+	ErrOrganizationMemberNotFound     = errors.New("organization member not found")
+	ErrOrganizationInvitationNotFound = errors.New("organization invitation not found")
+)
+
+// OrganizationMemberView 组织成员信息，只暴露成员之间需要看到的字段
+type OrganizationMemberView struct {
+	ID                 uint                    `json:"id"`
+	UserID             uint                    `json:"user_id"`
+	Email              string                  `json:"email"`
+	Name               string                  `json:"name,omitempty"`
+	Role               models.OrganizationRole `json:"role"`
+	SpendingLimitMinor int64                   `json:"spending_limit_minor"`
+	MonthlySpentMinor  int64                   `json:"monthly_spent_minor"`
+	CreatedAt          time.Time               `json:"created_at"`
+}
+
+// OrganizationOverview 当前用户所在组织、自己的成员身份与全部成员
+type OrganizationOverview struct {
+	Organization models.Organization      `json:"organization"`
+	Currency     string                   `json:"currency"`
+	Membership   OrganizationMemberView   `json:"membership"`
+	Members      []OrganizationMemberView `json:"members"`
+}
+
+// OrganizationMemberUpdate 所有者调整成员角色与限额
+type OrganizationMemberUpdate struct {
+	Role          models.OrganizationRole
+	SpendingLimit int64
+}
+
+// OrganizationService 组织账户：成员共享订单可见性，所有者通过邮件邀请成员并设置角色与每月消费限额
+type OrganizationService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	emailService *EmailService
+}
+
+// NewOrganizationService 创建组织服务
+func NewOrganizationService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *OrganizationService {
+	return &OrganizationService{db: db, cfg: cfg, emailService: emailService}
+}
+
+func (s *OrganizationService) currency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+func normalizeOrganizationName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxOrganizationNameLength {
+		return "", bizerr.Newf("organization.nameInvalid", "Organization name is required and cannot exceed %d characters", maxOrganizationNameLength).
+			WithParams(map[string]interface{}{"max": maxOrganizationNameLength})
+	}
+	return name, nil
+}
+
+func validateOrganizationMemberTerms(role models.OrganizationRole, spendingLimit int64) error {
+	// 所有权只能通过转让变更，邀请与调整只接受采购和只读角色
+	if role != models.OrganizationRolePurchaser && role != models.OrganizationRoleViewer {
+		return bizerr.Newf("organization.roleInvalid", "Invalid member role: %s", role).
+			WithParams(map[string]interface{}{"role": string(role)})
+	}
+	if spendingLimit < 0 {
+		return bizerr.New("organization.spendingLimitInvalid", "Spending limit cannot be negative")
+	}
+	return nil
+}
+
+func organizationOwnerRequiredError() error {
+	return bizerr.New("organization.ownerRequired", "Only the organization owner can do this")
+}
+
+// organizationMonthStart 每月消费限额按 UTC 自然月计算
+func organizationMonthStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// organizationMonthlySpentTx 成员本月以组织身份下单的金额，已取消和已退款的订单不计入
+func organizationMonthlySpentTx(tx *gorm.DB, organizationID, userID uint, now time.Time) (int64, error) {
+	var spent int64
+	err := tx.Model(&models.Order{}).
+		Where("organization_id = ? AND user_id = ? AND created_at >= ?", organizationID, userID, organizationMonthStart(now)).
+		Where("status NOT IN ?", []models.OrderStatus{models.OrderStatusCancelled, models.OrderStatusRefunded}).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Scan(&spent).Error
+	return spent, err
+}
+
+// applyOrganizationCheckoutTx 下单时校验组织角色与每月消费限额，并将订单归属到组织
+// 不属于任何组织的用户不受影响
+func applyOrganizationCheckoutTx(tx *gorm.DB, order *models.Order, now time.Time) error {
+	if order.UserID == nil {
+		return nil
+	}
+	// 锁定成员记录，避免并发下单绕过消费限额
+	if err := dbutil.LockForUpdate(tx, &models.OrganizationMember{}, "user_id = ?", *order.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	var member models.OrganizationMember
+	if err := tx.Where("user_id = ?", *order.UserID).First(&member).Error; err != nil {
+		return err
+	}
+	if !member.Role.CanCheckout() {
+		return bizerr.New("organization.checkoutNotAllowed", "Your organization role does not allow placing orders")
+	}
+	if member.SpendingLimit > 0 {
+		spent, err := organizationMonthlySpentTx(tx, member.OrganizationID, member.UserID, now)
+		if err != nil {
+			return err
+		}
+		if spent+order.TotalAmount > member.SpendingLimit {
+			remaining := member.SpendingLimit - spent
+			if remaining < 0 {
+				remaining = 0
+			}
+			return bizerr.New("organization.spendingLimitExceeded", "Order exceeds your monthly spending limit").
+				WithParams(map[string]interface{}{
+					"remaining": money.MinorToString(remaining),
+					"currency":  order.Currency,
+				})
+		}
+	}
+	order.OrganizationID = &member.OrganizationID
+	return nil
+}
+
+// CanViewOrganizationOrder 组织成员可查看同组织的订单
+func CanViewOrganizationOrder(db *gorm.DB, userID uint, order *models.Order) bool {
+	if db == nil || order == nil || order.OrganizationID == nil {
+		return false
+	}
+	var count int64
+	if err := db.Model(&models.OrganizationMember{}).
+		Where("user_id = ? AND organization_id = ?", userID, *order.OrganizationID).
+		Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+func (s *OrganizationService) findMembership(tx *gorm.DB, userID uint) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	if err := tx.Where("user_id = ?", userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (s *OrganizationService) requireOwner(tx *gorm.DB, userID uint) (*models.OrganizationMember, error) {
+	member, err := s.findMembership(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != models.OrganizationRoleOwner {
+		return nil, organizationOwnerRequiredError()
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) memberViews(organizationID uint, now time.Time) ([]OrganizationMemberView, error) {
+	var members []models.OrganizationMember
+	if err := s.db.Preload("User").Where("organization_id = ?", organizationID).
+		Order("CASE WHEN role = 'owner' THEN 0 ELSE 1 END, id ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	type spentRow struct {
+		UserID uint
+		Total  int64
+	}
+	var rows []spentRow
+	if err := s.db.Model(&models.Order{}).
+		Select("user_id, COALESCE(SUM(total_amount), 0) AS total").
+		Where("organization_id = ? AND created_at >= ?", organizationID, organizationMonthStart(now)).
+		Where("status NOT IN ?", []models.OrderStatus{models.OrderStatusCancelled, models.OrderStatusRefunded}).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	spentByUser := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		spentByUser[row.UserID] = row.Total
+	}
+
+	views := make([]OrganizationMemberView, 0, len(members))
+	for _, member := range members {
+		view := OrganizationMemberView{
+			ID:                 member.ID,
+			UserID:             member.UserID,
+			Role:               member.Role,
+			SpendingLimitMinor: member.SpendingLimit,
+			MonthlySpentMinor:  spentByUser[member.UserID],
+			CreatedAt:          member.CreatedAt,
+		}
+		if member.User != nil {
+			view.Email = member.User.Email
+			view.Name = member.User.Name
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// GetForUser 当前用户所在组织，未加入组织时返回 nil
+func (s *OrganizationService) GetForUser(userID uint) (*OrganizationOverview, error) {
+	member, err := s.findMembership(s.db, userID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var org models.Organization
+	if err := s.db.First(&org, member.OrganizationID).Error; err != nil {
+		return nil, err
+	}
+	members, err := s.memberViews(org.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	overview := &OrganizationOverview{Organization: org, Currency: s.currency(), Members: members}
+	for _, view := range members {
+		if view.UserID == userID {
+			overview.Membership = view
+			break
+		}
+	}
+	return overview, nil
+}
+
+// Create 创建组织，创建者成为所有者
+func (s *OrganizationService) Create(userID uint, name string) (*OrganizationOverview, error) {
+	name, err := normalizeOrganizationName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.OrganizationMember{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return bizerr.New("organization.alreadyMember", "You already belong to an organization")
+		}
+		org := models.Organization{Name: name, OwnerUserID: userID}
+		if err := tx.Create(&org).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         userID,
+			Role:           models.OrganizationRoleOwner,
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetForUser(userID)
+}
+
+// Rename 修改组织名称
+func (s *OrganizationService) Rename(userID uint, name string) (*OrganizationOverview, error) {
+	name, err := normalizeOrganizationName(name)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.requireOwner(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.Organization{}).Where("id = ?", owner.OrganizationID).Update("name", name).Error; err != nil {
+		return nil, err
+	}
+	return s.GetForUser(userID)
+}
+
+// Invite 邀请成员，同一邮箱未处理的旧邀请会被撤销
+func (s *OrganizationService) Invite(userID uint, email string, role models.OrganizationRole, spendingLimit int64) (*models.OrganizationInvitation, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, bizerr.New("organization.inviteEmailInvalid", "A valid email address is required")
+	}
+	if err := validateOrganizationMemberTerms(role, spendingLimit); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, organizationInvitationBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invitation := &models.OrganizationInvitation{
+		Email:         email,
+		Role:          role,
+		SpendingLimit: spendingLimit,
+		Token:         hex.EncodeToString(buf),
+		Status:        models.OrganizationInvitationStatusPending,
+		InvitedBy:     userID,
+		ExpiresAt:     now.Add(organizationInvitationTTL),
+	}
+
+	var org models.Organization
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		owner, err := s.requireOwner(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.First(&org, owner.OrganizationID).Error; err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&models.OrganizationMember{}).
+			Joins("JOIN users ON users.id = organization_members.user_id").
+			Where("organization_members.organization_id = ? AND LOWER(users.email) = ?", org.ID, email).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return bizerr.New("organization.alreadyMember", "This user already belongs to the organization")
+		}
+		if err := tx.Model(&models.OrganizationInvitation{}).
+			Where("organization_id = ? AND email = ? AND status = ?", org.ID, email, models.OrganizationInvitationStatusPending).
+			Update("status", models.OrganizationInvitationStatusRevoked).Error; err != nil {
+			return err
+		}
+		invitation.OrganizationID = org.ID
+		return tx.Create(invitation).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	if s.emailService != nil {
+		var inviter models.User
+		if err := s.db.Select("id", "email", "name", "locale").First(&inviter, userID).Error; err == nil {
+			go s.emailService.SendOrganizationInvitationEmail(&org, invitation, &inviter)
+		}
+	}
+	return invitation, nil
+}
+
+// ListInvitations 组织未处理的邀请
+func (s *OrganizationService) ListInvitations(userID uint) ([]models.OrganizationInvitation, error) {
+	owner, err := s.requireOwner(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	var invitations []models.OrganizationInvitation
+	err = s.db.Where("organization_id = ? AND status = ? AND expires_at > ?", owner.OrganizationID, models.OrganizationInvitationStatusPending, time.Now()).
+		Order("id DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// RevokeInvitation 撤销邀请
+func (s *OrganizationService) RevokeInvitation(userID, invitationID uint) error {
+	owner, err := s.requireOwner(s.db, userID)
+	if err != nil {
+		return err
+	}
+	result := s.db.Model(&models.OrganizationInvitation{}).
+		Where("id = ? AND organization_id = ? AND status = ?", invitationID, owner.OrganizationID, models.OrganizationInvitationStatusPending).
+		Update("status", models.OrganizationInvitationStatusRevoked)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrganizationInvitationNotFound
+	}
+	return nil
+}
+
+// PreviewInvitation 接受前展示邀请的组织与角色，仅限被邀请邮箱本人
+func (s *OrganizationService) PreviewInvitation(userID uint, token string) (*models.OrganizationInvitation, error) {
+	invitation, err := s.loadInvitationForUser(s.db, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	var org models.Organization
+	if err := s.db.First(&org, invitation.OrganizationID).Error; err != nil {
+		return nil, err
+	}
+	invitation.Organization = &org
+	return invitation, nil
+}
+
+func (s *OrganizationService) loadInvitationForUser(tx *gorm.DB, userID uint, token string) (*models.OrganizationInvitation, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrOrganizationInvitationNotFound
+	}
+	var invitation models.OrganizationInvitation
+	if err := tx.Where("token = ?", token).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationInvitationNotFound
+		}
+		return nil, err
+	}
+	if invitation.Status != models.OrganizationInvitationStatusPending || !time.Now().Before(invitation.ExpiresAt) {
+		return nil, bizerr.New("organization.invitationExpired", "This invitation is no longer valid")
+	}
+	var user models.User
+	if err := tx.Select("id", "email").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), invitation.Email) {
+		return nil, bizerr.New("organization.invitationEmailMismatch", "This invitation was sent to a different email address")
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation 接受邀请加入组织
+func (s *OrganizationService) AcceptInvitation(userID uint, token string) (*OrganizationOverview, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		invitation, err := s.loadInvitationForUser(tx, userID, token)
+		if err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.OrganizationMember{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return bizerr.New("organization.alreadyMember", "You already belong to an organization")
+		}
+		now := time.Now()
+		result := tx.Model(&models.OrganizationInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, models.OrganizationInvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":      models.OrganizationInvitationStatusAccepted,
+				"accepted_at": now,
+				"accepted_by": userID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return bizerr.New("organization.invitationExpired", "This invitation is no longer valid")
+		}
+		invitedBy := invitation.InvitedBy
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Role:           invitation.Role,
+			SpendingLimit:  invitation.SpendingLimit,
+			InvitedBy:      &invitedBy,
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetForUser(userID)
+}
+
+// UpdateMember 调整成员角色与限额；将角色设为 owner 表示转让所有权，原所有者降为采购
+func (s *OrganizationService) UpdateMember(userID, memberUserID uint, update OrganizationMemberUpdate) (*OrganizationOverview, error) {
+	if update.Role != models.OrganizationRoleOwner {
+		if err := validateOrganizationMemberTerms(update.Role, update.SpendingLimit); err != nil {
+			return nil, err
+		}
+	} else if update.SpendingLimit < 0 {
+		return nil, bizerr.New("organization.spendingLimitInvalid", "Spending limit cannot be negative")
+	}
+	if memberUserID == userID {
+		return nil, bizerr.New("organization.cannotChangeSelf", "You cannot change your own membership")
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		owner, err := s.requireOwner(tx, userID)
+		if err != nil {
+			return err
+		}
+		var member models.OrganizationMember
+		if err := tx.Where("organization_id = ? AND user_id = ?", owner.OrganizationID, memberUserID).
+			First(&member).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrganizationMemberNotFound
+			}
+			return err
+		}
+		if update.Role == models.OrganizationRoleOwner {
+			if err := tx.Model(owner).Updates(map[string]interface{}{
+				"role": models.OrganizationRolePurchaser,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Organization{}).Where("id = ?", owner.OrganizationID).
+				Update("owner_user_id", member.UserID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&member).Updates(map[string]interface{}{
+			"role":           update.Role,
+			"spending_limit": update.SpendingLimit,
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetForUser(userID)
+}
+
+// RemoveMember 所有者移除成员，或成员自行退出；所有者只有在没有其他成员时才能退出，此时组织随之解散
+// 已下的订单保留组织归属，剩余成员仍可查看
+func (s *OrganizationService) RemoveMember(userID, memberUserID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		actor, err := s.findMembership(tx, userID)
+		if err != nil {
+			return err
+		}
+		if memberUserID != userID && actor.Role != models.OrganizationRoleOwner {
+			return organizationOwnerRequiredError()
+		}
+		var member models.OrganizationMember
+		if err := tx.Where("organization_id = ? AND user_id = ?", actor.OrganizationID, memberUserID).First(&member).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrganizationMemberNotFound
+			}
+			return err
+		}
+		if member.Role == models.OrganizationRoleOwner {
+			var others int64
+			if err := tx.Model(&models.OrganizationMember{}).
+				Where("organization_id = ? AND id <> ?", member.OrganizationID, member.ID).
+				Count(&others).Error; err != nil {
+				return err
+			}
+			if others > 0 {
+				return bizerr.New("organization.ownerCannotLeave", "Transfer ownership before leaving the organization")
+			}
+			if err := tx.Model(&models.OrganizationInvitation{}).
+				Where("organization_id = ? AND status = ?", member.OrganizationID, models.OrganizationInvitationStatusPending).
+				Update("status", models.OrganizationInvitationStatusRevoked).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&member).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&models.Organization{}, member.OrganizationID).Error
+		}
+		return tx.Delete(&member).Error
+	})
+}
+
+// ListOrders 组织订单，所有成员均可查看
+func (s *OrganizationService) ListOrders(userID uint, status string, memberUserID uint, page, limit int) ([]models.Order, int64, error) {
+	member, err := s.findMembership(s.db, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := s.db.Model(&models.Order{}).Where("organization_id = ?", member.OrganizationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if memberUserID > 0 {
+		query = query.Where("user_id = ?", memberUserID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var orders []models.Order
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+func newOrganizationTestService(t *testing.T) (*OrganizationService, *gorm.DB) {
+	t.Helper()
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Organization{}, &models.OrganizationMember{}, &models.OrganizationInvitation{}); err != nil {
+		t.Fatalf("auto migrate organization tables failed: %v", err)
+	}
+	return NewOrganizationService(db, orderSvc.cfg, nil), db
+}
+
+func createOrganizationTestUser(t *testing.T, db *gorm.DB, uuid, email string) *models.User {
+	t.Helper()
+	user := &models.User{UUID: uuid, Email: email, Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	return user
+}
+
+func checkoutOrganizationTestOrder(t *testing.T, db *gorm.DB, userID uint, orderNo string, amount int64) (*models.Order, error) {
+	t.Helper()
+	order := &models.Order{
+		OrderNo:     orderNo,
+		UserID:      &userID,
+		Status:      models.OrderStatusPendingPayment,
+		Currency:    "CNY",
+		TotalAmount: amount,
+		Items:       []models.OrderItem{{SKU: "ORG-1", Name: "Paper", Quantity: 1, ProductType: models.ProductTypePhysical}},
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := applyOrganizationCheckoutTx(tx, order, time.Now()); err != nil {
+			return err
+		}
+		return tx.Create(order).Error
+	})
+	return order, err
+}
+
+func TestOrganizationInvitationFlow(t *testing.T) {
+	svc, db := newOrganizationTestService(t)
+	owner := createOrganizationTestUser(t, db, "org-owner", "owner@example.com")
+	buyer := createOrganizationTestUser(t, db, "org-buyer", "Buyer@Example.com")
+	stranger := createOrganizationTestUser(t, db, "org-stranger", "stranger@example.com")
+
+	if _, err := svc.Create(owner.ID, "Acme Procurement"); err != nil {
+		t.Fatalf("create organization failed: %v", err)
+	}
+	_, err := svc.Invite(buyer.ID, "x@example.com", models.OrganizationRolePurchaser, 0)
+	if err != ErrOrganizationNotFound {
+		t.Fatalf("expected non-member invite to fail with not found, got %v", err)
+	}
+	_, err = svc.Invite(owner.ID, "buyer@example.com", models.OrganizationRoleOwner, 0)
+	requireOrderBizErr(t, err, "organization.roleInvalid")
+
+	first, err := svc.Invite(owner.ID, "buyer@example.com", models.OrganizationRolePurchaser, 5000)
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+	second, err := svc.Invite(owner.ID, "BUYER@example.com", models.OrganizationRolePurchaser, 10000)
+	if err != nil {
+		t.Fatalf("re-invite failed: %v", err)
+	}
+
+	// 重新邀请会撤销旧邀请
+	_, err = svc.AcceptInvitation(buyer.ID, first.Token)
+	requireOrderBizErr(t, err, "organization.invitationExpired")
+	_, err = svc.AcceptInvitation(stranger.ID, second.Token)
+	requireOrderBizErr(t, err, "organization.invitationEmailMismatch")
+
+	overview, err := svc.AcceptInvitation(buyer.ID, second.Token)
+	if err != nil {
+		t.Fatalf("accept invitation failed: %v", err)
+	}
+	if len(overview.Members) != 2 || overview.Membership.Role != models.OrganizationRolePurchaser || overview.Membership.SpendingLimitMinor != 10000 {
+		t.Fatalf("unexpected membership after accept: %+v", overview)
+	}
+	_, err = svc.AcceptInvitation(buyer.ID, second.Token)
+	requireOrderBizErr(t, err, "organization.invitationExpired")
+
+	// 所有者有其他成员时不能退出，转让后原所有者降为采购
+	err = svc.RemoveMember(owner.ID, owner.ID)
+	requireOrderBizErr(t, err, "organization.ownerCannotLeave")
+	if _, err := svc.UpdateMember(owner.ID, buyer.ID, OrganizationMemberUpdate{Role: models.OrganizationRoleOwner}); err != nil {
+		t.Fatalf("transfer ownership failed: %v", err)
+	}
+	var org models.Organization
+	if err := db.First(&org, overview.Organization.ID).Error; err != nil {
+		t.Fatalf("reload organization failed: %v", err)
+	}
+	previous, err := svc.GetForUser(owner.ID)
+	if err != nil {
+		t.Fatalf("get organization failed: %v", err)
+	}
+	if org.OwnerUserID != buyer.ID || previous.Membership.Role != models.OrganizationRolePurchaser {
+		t.Fatalf("expected ownership transferred, owner=%d role=%s", org.OwnerUserID, previous.Membership.Role)
+	}
+	if err := svc.RemoveMember(owner.ID, owner.ID); err != nil {
+		t.Fatalf("leave organization failed: %v", err)
+	}
+	if left, err := svc.GetForUser(owner.ID); err != nil || left != nil {
+		t.Fatalf("expected former owner to have no organization, got %+v err=%v", left, err)
+	}
+}
+
+func TestOrganizationCheckoutRolesAndSpendingLimit(t *testing.T) {
+	svc, db := newOrganizationTestService(t)
+	owner := createOrganizationTestUser(t, db, "org-owner", "owner@example.com")
+	buyer := createOrganizationTestUser(t, db, "org-buyer", "buyer@example.com")
+	viewer := createOrganizationTestUser(t, db, "org-viewer", "viewer@example.com")
+	outsider := createOrganizationTestUser(t, db, "org-outsider", "outsider@example.com")
+
+	overview, err := svc.Create(owner.ID, "Acme Procurement")
+	if err != nil {
+		t.Fatalf("create organization failed: %v", err)
+	}
+	orgID := overview.Organization.ID
+	members := []models.OrganizationMember{
+		{OrganizationID: orgID, UserID: buyer.ID, Role: models.OrganizationRolePurchaser, SpendingLimit: 10000},
+		{OrganizationID: orgID, UserID: viewer.ID, Role: models.OrganizationRoleViewer},
+	}
+	if err := db.Create(&members).Error; err != nil {
+		t.Fatalf("create members failed: %v", err)
+	}
+
+	_, err = checkoutOrganizationTestOrder(t, db, viewer.ID, "ORG-V1", 100)
+	requireOrderBizErr(t, err, "organization.checkoutNotAllowed")
+
+	first, err := checkoutOrganizationTestOrder(t, db, buyer.ID, "ORG-B1", 6000)
+	if err != nil {
+		t.Fatalf("checkout within limit failed: %v", err)
+	}
+	if first.OrganizationID == nil || *first.OrganizationID != orgID {
+		t.Fatalf("expected order attributed to organization, got %v", first.OrganizationID)
+	}
+	_, err = checkoutOrganizationTestOrder(t, db, buyer.ID, "ORG-B2", 5000)
+	bizErr := requireOrderBizErr(t, err, "organization.spendingLimitExceeded")
+	if bizErr.Params["remaining"] != "40.00" {
+		t.Fatalf("expected remaining 40.00, got %v", bizErr.Params["remaining"])
+	}
+
+	// 已取消的订单不计入限额
+	if err := db.Model(first).Update("status", models.OrderStatusCancelled).Error; err != nil {
+		t.Fatalf("cancel order failed: %v", err)
+	}
+	if _, err := checkoutOrganizationTestOrder(t, db, buyer.ID, "ORG-B2", 5000); err != nil {
+		t.Fatalf("expected cancelled order to free the limit: %v", err)
+	}
+
+	personal, err := checkoutOrganizationTestOrder(t, db, outsider.ID, "ORG-O1", 100)
+	if err != nil || personal.OrganizationID != nil {
+		t.Fatalf("expected personal order untouched, org=%v err=%v", personal.OrganizationID, err)
+	}
+
+	if !CanViewOrganizationOrder(db, viewer.ID, first) || !CanViewOrganizationOrder(db, owner.ID, first) {
+		t.Fatalf("expected organization members to see shared orders")
+	}
+	if CanViewOrganizationOrder(db, outsider.ID, first) || CanViewOrganizationOrder(db, viewer.ID, personal) {
+		t.Fatalf("expected outsiders and personal orders to stay private")
+	}
+
+	orders, total, err := svc.ListOrders(viewer.ID, "", 0, 1, 20)
+	if err != nil {
+		t.Fatalf("list organization orders failed: %v", err)
+	}
+	if total != 2 || len(orders) != 2 {
+		t.Fatalf("expected 2 organization orders, got %d", total)
+	}
+}
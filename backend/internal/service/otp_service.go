@@ -0,0 +1,234 @@
+package service
+
+import (
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/authbiz"
+	"auralogic/internal/pkg/cache"
+)
+
+// OTPChannel 验证码投递渠道
+type OTPChannel string
+
+const (
+	OTPChannelSMS      OTPChannel = "sms"
+	OTPChannelEmail    OTPChannel = "email"
+	OTPChannelWhatsApp OTPChannel = "whatsapp"
+)
+
+// OTPPurpose 验证码用途，决定 Redis 键空间与冷却范围
+type OTPPurpose string
+
+const (
+	OTPPurposeEmailLogin        OTPPurpose = "email_login"
+	OTPPurposePhoneLogin        OTPPurpose = "phone_login"
+	OTPPurposePhoneReset        OTPPurpose = "phone_reset"
+	OTPPurposePhoneRegister     OTPPurpose = "phone_register"
+	OTPPurposeBindEmail         OTPPurpose = "bind_email"
+	OTPPurposeBindPhone         OTPPurpose = "bind_phone"
+	OTPPurposeVirtualReveal     OTPPurpose = "virtual_reveal"
+	OTPPurposeTwoFactorRecovery OTPPurpose = "two_factor_recovery"
+	// 邮件重置链接不走验证码，但共用发送冷却
+	OTPPurposePasswordReset OTPPurpose = "password_reset"
+)
+
+const (
+	defaultOTPCodeTTL     = 10 * time.Minute
+	defaultOTPMaxAttempts = 5
+	defaultOTPCooldown    = 60 * time.Second
+)
+
+// otpRecord Redis 中保存的验证码记录，只存哈希不存明文
+type otpRecord struct {
+	Hash     string     `json:"hash"`
+	Channel  OTPChannel `json:"channel"`
+	IssuedAt int64      `json:"issued_at"`
+}
+
+// OTPService 统一管理验证码的生成、哈希存储、尝试次数与重发冷却
+type OTPService struct {
+	cfg *config.Config
+}
+
+func NewOTPService(cfg *config.Config) *OTPService {
+	return &OTPService{cfg: cfg}
+}
+
+// CodeTTL 验证码有效期
+func (s *OTPService) CodeTTL() time.Duration {
+	if s.cfg != nil && s.cfg.Security.OTP.CodeTTLSeconds > 0 {
+		return time.Duration(s.cfg.Security.OTP.CodeTTLSeconds) * time.Second
+	}
+	return defaultOTPCodeTTL
+}
+
+// MaxAttempts 单个验证码允许的最大校验次数
+func (s *OTPService) MaxAttempts() int {
+	if s.cfg != nil && s.cfg.Security.OTP.MaxAttempts > 0 {
+		return s.cfg.Security.OTP.MaxAttempts
+	}
+	return defaultOTPMaxAttempts
+}
+
+// Cooldown 重新发送冷却时间
+func (s *OTPService) Cooldown() time.Duration {
+	if s.cfg != nil && s.cfg.Security.OTP.CooldownSeconds > 0 {
+		return time.Duration(s.cfg.Security.OTP.CooldownSeconds) * time.Second
+	}
+	return defaultOTPCooldown
+}
+
+// CooldownMessage 冷却中的提示文案
+func (s *OTPService) CooldownMessage() string {
+	return fmt.Sprintf("Please wait %d seconds before requesting again", int(s.Cooldown()/time.Second))
+}
+
+// PhoneChannel 手机验证码投递渠道，WhatsApp 仅在 Twilio 配置了发送号码时可用
+func (s *OTPService) PhoneChannel() OTPChannel {
+	return resolveOTPPhoneChannel(s.cfg)
+}
+
+func resolveOTPPhoneChannel(cfg *config.Config) OTPChannel {
+	if cfg == nil {
+		return OTPChannelSMS
+	}
+	channel := OTPChannel(strings.ToLower(strings.TrimSpace(cfg.Security.OTP.PhoneChannel)))
+	if channel == OTPChannelWhatsApp &&
+		cfg.SMS.Provider == "twilio" &&
+		strings.TrimSpace(cfg.SMS.TwilioWhatsAppFrom) != "" {
+		return OTPChannelWhatsApp
+	}
+	return OTPChannelSMS
+}
+
+func otpCodeKey(purpose OTPPurpose, subject string) string {
+	return fmt.Sprintf("otp:%s:%s", purpose, subject)
+}
+
+func otpAttemptsKey(purpose OTPPurpose, subject string) string {
+	return fmt.Sprintf("otp:%s:%s:attempts", purpose, subject)
+}
+
+func otpCooldownKey(purpose OTPPurpose, scope, value string) string {
+	return fmt.Sprintf("otp_cooldown:%s:%s:%s", purpose, scope, value)
+}
+
+// hashCode 使用 JWT 密钥做 HMAC，并绑定用途与对象，防止跨用途复用
+func (s *OTPService) hashCode(purpose OTPPurpose, subject, code string) string {
+	secret := ""
+	if s.cfg != nil {
+		secret = s.cfg.JWT.Secret
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(string(purpose) + "\x00" + subject + "\x00" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateOTPCode() (string, error) {
+	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// Issue 生成验证码并保存哈希，重新签发会覆盖旧验证码并重置尝试次数
+func (s *OTPService) Issue(purpose OTPPurpose, subject string, channel OTPChannel) (string, error) {
+	code, err := generateOTPCode()
+	if err != nil {
+		return "", err
+	}
+	record, err := json.Marshal(otpRecord{
+		Hash:     s.hashCode(purpose, subject, code),
+		Channel:  channel,
+		IssuedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := cache.Set(otpCodeKey(purpose, subject), string(record), s.CodeTTL()); err != nil {
+		return "", fmt.Errorf("failed to store %s code: %w", purpose, err)
+	}
+	_ = cache.Del(otpAttemptsKey(purpose, subject))
+	return code, nil
+}
+
+// Verify 校验验证码，成功后立即作废；超过最大尝试次数后验证码失效
+func (s *OTPService) Verify(purpose OTPPurpose, subject, code string) error {
+	codeKey := otpCodeKey(purpose, subject)
+	attemptsKey := otpAttemptsKey(purpose, subject)
+
+	raw, err := cache.Get(codeKey)
+	if err != nil {
+		return authbiz.CodeExpired()
+	}
+	var record otpRecord
+	if err := json.Unmarshal([]byte(raw), &record); err != nil || record.Hash == "" {
+		_ = cache.Del(codeKey, attemptsKey)
+		return authbiz.CodeExpired()
+	}
+
+	attempts, err := cache.Incr(attemptsKey)
+	if err != nil {
+		return fmt.Errorf("failed to record code attempt: %w", err)
+	}
+	if attempts == 1 {
+		_ = cache.Expire(attemptsKey, s.CodeTTL())
+	}
+	if attempts > int64(s.MaxAttempts()) {
+		_ = cache.Del(codeKey, attemptsKey)
+		return authbiz.TooManyCodeAttempts()
+	}
+
+	expected := s.hashCode(purpose, subject, strings.TrimSpace(code))
+	if !hmac.Equal([]byte(expected), []byte(record.Hash)) {
+		if attempts >= int64(s.MaxAttempts()) {
+			_ = cache.Del(codeKey, attemptsKey)
+			return authbiz.TooManyCodeAttempts()
+		}
+		return authbiz.InvalidCode()
+	}
+
+	// 并发校验时只允许一个请求消费成功
+	deleted, err := cache.RedisClient.Del(cache.RedisClient.Context(), codeKey).Result()
+	if err != nil || deleted == 0 {
+		return authbiz.CodeExpired()
+	}
+	_ = cache.Del(attemptsKey)
+	return nil
+}
+
+// InCooldown 检查 IP 或目标（邮箱/手机号）是否仍处于重发冷却中
+func (s *OTPService) InCooldown(purpose OTPPurpose, ip, target string) bool {
+	keys := make([]string, 0, 2)
+	if ip != "" {
+		keys = append(keys, otpCooldownKey(purpose, "ip", ip))
+	}
+	if target != "" {
+		keys = append(keys, otpCooldownKey(purpose, "target", target))
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	n, _ := cache.Exists(keys...)
+	return n > 0
+}
+
+// StartCooldown 为 IP 与目标同时设置重发冷却
+func (s *OTPService) StartCooldown(purpose OTPPurpose, ip, target string) {
+	if ip != "" {
+		_ = cache.Set(otpCooldownKey(purpose, "ip", ip), "1", s.Cooldown())
+	}
+	if target != "" {
+		_ = cache.Set(otpCooldownKey(purpose, "target", target), "1", s.Cooldown())
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newOTPServiceForTest(t *testing.T, otpCfg config.OTPConfig) (*OTPService, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() {
+		if cache.RedisClient != nil {
+			_ = cache.RedisClient.Close()
+		}
+		cache.RedisClient = previousClient
+		mr.Close()
+	})
+
+	cfg := &config.Config{
+		JWT:      config.JWTConfig{Secret: "otp-test-secret"},
+		Security: config.SecurityConfig{OTP: otpCfg},
+	}
+	return NewOTPService(cfg), mr
+}
+
+func TestOTPServiceStoresHashAndConsumesOnce(t *testing.T) {
+	svc, mr := newOTPServiceForTest(t, config.OTPConfig{})
+
+	code, err := svc.Issue(OTPPurposePhoneLogin, "13800000000", OTPChannelSMS)
+	if err != nil {
+		t.Fatalf("issue failed: %v", err)
+	}
+	raw, err := mr.Get(otpCodeKey(OTPPurposePhoneLogin, "13800000000"))
+	if err != nil {
+		t.Fatalf("expected stored record: %v", err)
+	}
+	if strings.Contains(raw, code) {
+		t.Fatalf("code must not be stored in plaintext: %s", raw)
+	}
+
+	// 不同用途之间不能复用
+	requireAuthBizErr(t, svc.Verify(OTPPurposePhoneReset, "13800000000", code), "auth.codeExpired")
+
+	if err := svc.Verify(OTPPurposePhoneLogin, "13800000000", code); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	requireAuthBizErr(t, svc.Verify(OTPPurposePhoneLogin, "13800000000", code), "auth.codeExpired")
+}
+
+func TestOTPServiceEnforcesMaxAttempts(t *testing.T) {
+	svc, _ := newOTPServiceForTest(t, config.OTPConfig{MaxAttempts: 3})
+
+	code, err := svc.Issue(OTPPurposeEmailLogin, "user@example.com", OTPChannelEmail)
+	if err != nil {
+		t.Fatalf("issue failed: %v", err)
+	}
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	requireAuthBizErr(t, svc.Verify(OTPPurposeEmailLogin, "user@example.com", wrong), "auth.invalidCode")
+	requireAuthBizErr(t, svc.Verify(OTPPurposeEmailLogin, "user@example.com", wrong), "auth.invalidCode")
+	requireAuthBizErr(t, svc.Verify(OTPPurposeEmailLogin, "user@example.com", wrong), "auth.tooManyCodeAttempts")
+	// 达到上限后正确的验证码也已失效
+	requireAuthBizErr(t, svc.Verify(OTPPurposeEmailLogin, "user@example.com", code), "auth.codeExpired")
+
+	// 重新签发会重置尝试次数
+	code, err = svc.Issue(OTPPurposeEmailLogin, "user@example.com", OTPChannelEmail)
+	if err != nil {
+		t.Fatalf("reissue failed: %v", err)
+	}
+	if err := svc.Verify(OTPPurposeEmailLogin, "user@example.com", code); err != nil {
+		t.Fatalf("verify after reissue failed: %v", err)
+	}
+}
+
+func TestOTPServiceCooldownAndPhoneChannel(t *testing.T) {
+	svc, mr := newOTPServiceForTest(t, config.OTPConfig{CooldownSeconds: 30, PhoneChannel: "whatsapp"})
+
+	if svc.InCooldown(OTPPurposePhoneLogin, "1.2.3.4", "13800000000") {
+		t.Fatalf("expected no cooldown before sending")
+	}
+	svc.StartCooldown(OTPPurposePhoneLogin, "1.2.3.4", "13800000000")
+	if !svc.InCooldown(OTPPurposePhoneLogin, "5.6.7.8", "13800000000") {
+		t.Fatalf("expected target cooldown to apply across IPs")
+	}
+	if !svc.InCooldown(OTPPurposePhoneLogin, "1.2.3.4", "13900000000") {
+		t.Fatalf("expected IP cooldown to apply across targets")
+	}
+	if svc.InCooldown(OTPPurposeBindPhone, "1.2.3.4", "13800000000") {
+		t.Fatalf("cooldown must be scoped per purpose")
+	}
+	if ttl := mr.TTL(otpCooldownKey(OTPPurposePhoneLogin, "ip", "1.2.3.4")); ttl.Seconds() != 30 {
+		t.Fatalf("unexpected cooldown ttl: %v", ttl)
+	}
+
+	// WhatsApp 需要 Twilio 发送号码，否则回退短信
+	if channel := svc.PhoneChannel(); channel != OTPChannelSMS {
+		t.Fatalf("expected sms fallback, got %s", channel)
+	}
+	svc.cfg.SMS.Provider = "twilio"
+	svc.cfg.SMS.TwilioWhatsAppFrom = "+15550001111"
+	if channel := svc.PhoneChannel(); channel != OTPChannelWhatsApp {
+		t.Fatalf("expected whatsapp channel, got %s", channel)
+	}
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	qrcode "github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+)
+
+const (
+	// 批量打印单次最多包含的发货单数，避免生成过大的文档
+	PackingSlipBatchMaxOrders = 500
+	packingSlipQRCodeSize     = 160
+)
+
+// PackingSlipItem 装箱单商品行
+type PackingSlipItem struct {
+	SKU        string
+	Name       string
+	Quantity   int
+	Attributes string
+}
+
+// PackingSlip 单个发货单的装箱单数据
+type PackingSlip struct {
+	OrderID         uint
+	OrderNo         string
+	OrderDate       string
+	ShippedDate     string
+	TrackingNo      string
+	ReceiverName    string
+	ReceiverPhone   string
+	ReceiverAddress string
+	Remark          string
+	Items           []PackingSlipItem
+	TotalQuantity   int
+	AdminURL        string
+	// QRCode PNG data URI，扫码打开后台订单详情
+	QRCode template.URL
+}
+
+// PackingSlipDocument 装箱单模板数据，一份文档可包含多张装箱单（每张独占一页）
+type PackingSlipDocument struct {
+	Title        string
+	AppName      string
+	CompanyName  string
+	CompanyLogo  template.URL
+	GeneratedAt  string
+	Slips        []PackingSlip
+	PrintBtnText string
+}
+
+// PackingSlipService 装箱单生成
+type PackingSlipService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewPackingSlipService 创建装箱单服务
+func NewPackingSlipService(db *gorm.DB, cfg *config.Config) *PackingSlipService {
+	return &PackingSlipService{db: db, cfg: cfg}
+}
+
+// ListShipmentsForDay 查询某日（按 day 所在时区的自然日）发货的订单，按发货时间排序
+func (s *PackingSlipService) ListShipmentsForDay(day time.Time) ([]models.Order, error) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var orders []models.Order
+	err := s.db.Where("shipped_at >= ? AND shipped_at < ?", start, end).
+		Where("status IN ?", []models.OrderStatus{models.OrderStatusShipped, models.OrderStatusCompleted}).
+		Order("shipped_at ASC, id ASC").
+		Limit(PackingSlipBatchMaxOrders + 1).
+		Find(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Render 渲染装箱单文档；配置了自定义模板时使用自定义模板
+func (s *PackingSlipService) Render(title string, orders []models.Order) ([]byte, error) {
+	doc := PackingSlipDocument{
+		Title:        title,
+		GeneratedAt:  time.Now().Format("2006-01-02 15:04"),
+		Slips:        make([]PackingSlip, 0, len(orders)),
+		PrintBtnText: "Print",
+	}
+	if s.cfg != nil {
+		doc.AppName = s.cfg.App.Name
+		doc.CompanyName = s.cfg.Order.Invoice.CompanyName
+		doc.CompanyLogo = ResolveTemplateAssetRef(s.cfg.Order.Invoice.CompanyLogo, false)
+	}
+	if doc.CompanyName == "" {
+		doc.CompanyName = doc.AppName
+	}
+
+	for i := range orders {
+		slip, err := s.buildSlip(&orders[i])
+		if err != nil {
+			return nil, err
+		}
+		doc.Slips = append(doc.Slips, slip)
+	}
+
+	tmplStr := builtinPackingSlipTemplate
+	if s.cfg != nil && s.cfg.Order.PackingSlip.TemplateType == "custom" && s.cfg.Order.PackingSlip.CustomTemplate != "" {
+		tmplStr = s.cfg.Order.PackingSlip.CustomTemplate
+	}
+	tmpl, err := template.New("packing_slip").Funcs(TemplateAssetFuncs(false)).Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse packing slip template failed: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, doc); err != nil {
+		return nil, fmt.Errorf("render packing slip failed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// AdminOrderURL 后台订单详情地址，用于装箱单二维码
+func (s *PackingSlipService) AdminOrderURL(orderID uint) string {
+	baseURL := ""
+	if s.cfg != nil {
+		baseURL = strings.TrimRight(s.cfg.App.URL, "/")
+	}
+	return baseURL + "/admin/orders/" + strconv.FormatUint(uint64(orderID), 10)
+}
+
+func (s *PackingSlipService) buildSlip(order *models.Order) (PackingSlip, error) {
+	adminURL := s.AdminOrderURL(order.ID)
+	png, err := qrcode.Encode(adminURL, qrcode.Medium, packingSlipQRCodeSize)
+	if err != nil {
+		return PackingSlip{}, fmt.Errorf("generate packing slip qr code failed: %w", err)
+	}
+
+	slip := PackingSlip{
+		OrderID:         order.ID,
+		OrderNo:         order.OrderNo,
+		OrderDate:       order.CreatedAt.Format("2006-01-02"),
+		TrackingNo:      order.TrackingNo,
+		ReceiverName:    order.ReceiverName,
+		ReceiverPhone:   order.ReceiverPhone,
+		ReceiverAddress: packingSlipAddress(order),
+		Remark:          order.Remark,
+		Items:           make([]PackingSlipItem, 0, len(order.Items)),
+		AdminURL:        adminURL,
+		QRCode:          template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)),
+	}
+	if order.ShippedAt != nil {
+		slip.ShippedDate = order.ShippedAt.Format("2006-01-02")
+	}
+
+	// 虚拟商品无需装箱
+	for _, item := range order.Items {
+		if item.ProductType == models.ProductTypeVirtual {
+			continue
+		}
+		slip.Items = append(slip.Items, PackingSlipItem{
+			SKU:        item.SKU,
+			Name:       item.Name,
+			Quantity:   item.Quantity,
+			Attributes: packingSlipAttributes(item.Attributes),
+		})
+		slip.TotalQuantity += item.Quantity
+	}
+	return slip, nil
+}
+
+func packingSlipAddress(order *models.Order) string {
+	parts := make([]string, 0, 6)
+	for _, part := range []string{
+		order.ReceiverAddress,
+		order.ReceiverDistrict,
+		order.ReceiverCity,
+		order.ReceiverProvince,
+		order.ReceiverPostcode,
+		order.ReceiverCountry,
+	} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func packingSlipAttributes(attributes map[string]interface{}) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %v", key, attributes[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// builtinPackingSlipTemplate 内置装箱单 HTML 模板
+const builtinPackingSlipTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+*{margin:0;padding:0;box-sizing:border-box}
+body{font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;color:#1a1a1a;background:#f5f5f5;line-height:1.5}
+.slip{max-width:800px;margin:20px auto;background:#fff;box-shadow:0 1px 10px rgba(0,0,0,.08);border-radius:8px;padding:32px 36px;page-break-after:always;break-after:page}
+.slip:last-of-type{page-break-after:auto;break-after:auto}
+.slip-header{display:flex;justify-content:space-between;align-items:flex-start;gap:24px;border-bottom:2px solid #f0f0f0;padding-bottom:20px;margin-bottom:20px}
+.slip-header h1{font-size:20px;font-weight:700}
+.slip-header p{font-size:13px;color:#666;margin:1px 0}
+.company-logo{max-height:48px;max-width:160px;object-fit:contain;margin-bottom:6px}
+.qr{text-align:center}
+.qr img{width:120px;height:120px;display:block}
+.qr span{font-size:11px;color:#999}
+.info-row{display:flex;justify-content:space-between;gap:32px;margin-bottom:20px}
+.info-block h3{font-size:11px;text-transform:uppercase;letter-spacing:1px;color:#999;margin-bottom:6px;font-weight:600}
+.info-block p{font-size:14px;margin:2px 0}
+table{width:100%;border-collapse:collapse;margin-bottom:16px}
+thead th{background:#fafafa;padding:10px 12px;text-align:left;font-size:12px;text-transform:uppercase;color:#666;font-weight:600;border-bottom:2px solid #eee}
+tbody td{padding:10px 12px;font-size:14px;border-bottom:1px solid #f0f0f0;vertical-align:top}
+td.qty,th.qty{text-align:right;width:80px}
+td.check,th.check{width:48px;text-align:center}
+.box{display:inline-block;width:16px;height:16px;border:1.5px solid #999;border-radius:3px}
+.item-attrs{font-size:12px;color:#999;margin-top:2px}
+.total{text-align:right;font-size:14px;font-weight:600}
+.remark{margin-top:16px;padding:10px 12px;background:#fafafa;border-radius:6px;font-size:13px}
+.empty{max-width:800px;margin:40px auto;text-align:center;color:#999}
+.no-print{text-align:center;padding:20px}
+.no-print button{padding:10px 28px;border:none;border-radius:6px;font-size:14px;cursor:pointer;background:#1a1a1a;color:#fff}
+@media print{body{background:#fff}.slip{box-shadow:none;border-radius:0;margin:0;max-width:none}.no-print{display:none}}
+</style>
+</head>
+<body>
+<div class="no-print"><button onclick="window.print()">{{.PrintBtnText}}</button></div>
+{{range .Slips}}
+<div class="slip">
+  <div class="slip-header">
+    <div>
+      {{if $.CompanyLogo}}<img class="company-logo" src="{{$.CompanyLogo}}" alt="{{$.CompanyName}}">{{end}}
+      <h1>Packing Slip</h1>
+      <p>{{$.CompanyName}}</p>
+      <p>Order: <strong>{{.OrderNo}}</strong></p>
+      <p>Order Date: {{.OrderDate}}{{if .ShippedDate}} &middot; Shipped: {{.ShippedDate}}{{end}}</p>
+      {{if .TrackingNo}}<p>Tracking No: {{.TrackingNo}}</p>{{end}}
+    </div>
+    <div class="qr">
+      <img src="{{.QRCode}}" alt="{{.AdminURL}}">
+      <span>#{{.OrderID}}</span>
+    </div>
+  </div>
+  <div class="info-row">
+    <div class="info-block">
+      <h3>Ship To</h3>
+      {{if .ReceiverName}}<p><strong>{{.ReceiverName}}</strong></p>{{end}}
+      {{if .ReceiverPhone}}<p>{{.ReceiverPhone}}</p>{{end}}
+      {{if .ReceiverAddress}}<p>{{.ReceiverAddress}}</p>{{end}}
+    </div>
+  </div>
+  <table>
+    <thead>
+      <tr><th class="check"></th><th>Item</th><th>SKU</th><th class="qty">Qty</th></tr>
+    </thead>
+    <tbody>
+      {{range .Items}}
+      <tr>
+        <td class="check"><span class="box"></span></td>
+        <td>{{.Name}}{{if .Attributes}}<div class="item-attrs">{{.Attributes}}</div>{{end}}</td>
+        <td>{{.SKU}}</td>
+        <td class="qty">{{.Quantity}}</td>
+      </tr>
+      {{end}}
+    </tbody>
+  </table>
+  <p class="total">Total Qty: {{.TotalQuantity}}</p>
+  {{if .Remark}}<div class="remark">Note: {{.Remark}}</div>{{end}}
+</div>
+{{else}}
+<p class="empty">No shipments</p>
+{{end}}
+<div class="no-print"><p style="font-size:12px;color:#999">{{.AppName}} &middot; {{.GeneratedAt}}</p></div>
+</body>
+</html>`
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openPackingSlipTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:packing-slip-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestPackingSlipDailyShipmentsAndRender(t *testing.T) {
+	db := openPackingSlipTestDB(t)
+	cfg := &config.Config{}
+	cfg.App.Name = "Shop"
+	cfg.App.URL = "https://shop.example.com/"
+	svc := NewPackingSlipService(db, cfg)
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	morning := day.Add(9 * time.Hour)
+	evening := day.Add(18 * time.Hour)
+	yesterday := day.Add(-2 * time.Hour)
+	orders := []models.Order{
+		{
+			OrderNo:         "ORD-SLIP-2",
+			Status:          models.OrderStatusShipped,
+			ShippedAt:       &evening,
+			ReceiverName:    "Alice",
+			ReceiverAddress: "1 Main St",
+			ReceiverCity:    "Springfield",
+			Items: []models.OrderItem{
+				{SKU: "TEE-1", Name: "Tee", Quantity: 2, Attributes: map[string]interface{}{"size": "L"}},
+				{SKU: "KEY-1", Name: "Game Key", Quantity: 1, ProductType: models.ProductTypeVirtual},
+			},
+		},
+		{
+			OrderNo:   "ORD-SLIP-1",
+			Status:    models.OrderStatusCompleted,
+			ShippedAt: &morning,
+			Items:     []models.OrderItem{{SKU: "MUG-1", Name: "Mug", Quantity: 1}},
+		},
+		{
+			OrderNo:   "ORD-SLIP-OLD",
+			Status:    models.OrderStatusShipped,
+			ShippedAt: &yesterday,
+			Items:     []models.OrderItem{{SKU: "MUG-1", Name: "Mug", Quantity: 1}},
+		},
+		{
+			OrderNo:   "ORD-SLIP-REFUNDED",
+			Status:    models.OrderStatusRefunded,
+			ShippedAt: &morning,
+			Items:     []models.OrderItem{{SKU: "MUG-1", Name: "Mug", Quantity: 1}},
+		},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+	}
+
+	shipments, err := svc.ListShipmentsForDay(day.Add(12 * time.Hour))
+	if err != nil {
+		t.Fatalf("ListShipmentsForDay failed: %v", err)
+	}
+	if len(shipments) != 2 || shipments[0].OrderNo != "ORD-SLIP-1" || shipments[1].OrderNo != "ORD-SLIP-2" {
+		t.Fatalf("unexpected shipments: %+v", shipments)
+	}
+
+	body, err := svc.Render("Packing Slips", shipments)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	html := string(body)
+	if strings.Count(html, `class="slip"`) != 2 {
+		t.Fatalf("expected 2 slips in combined document")
+	}
+	for _, want := range []string{
+		"ORD-SLIP-1",
+		"ORD-SLIP-2",
+		"1 Main St, Springfield",
+		"size: L",
+		"data:image/png;base64,",
+		"https://shop.example.com/admin/orders/1",
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected rendered packing slip to contain %q", want)
+		}
+	}
+	if strings.Contains(html, "Game Key") {
+		t.Fatalf("virtual items should not appear on packing slip")
+	}
+
+	cfg.Order.PackingSlip.TemplateType = "custom"
+	cfg.Order.PackingSlip.CustomTemplate = `{{range .Slips}}[{{.OrderNo}}:{{.TotalQuantity}}]{{end}}`
+	body, err = svc.Render("Packing Slips", shipments)
+	if err != nil {
+		t.Fatalf("Render with custom template failed: %v", err)
+	}
+	if string(body) != "[ORD-SLIP-1:1][ORD-SLIP-2:2]" {
+		t.Fatalf("unexpected custom template output: %q", string(body))
+	}
+}
@@ -0,0 +1,460 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/authbiz"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/webauthn"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxPasskeysPerUser 每个用户最多注册的通行密钥数
+	maxPasskeysPerUser = 10
+	// passkeyCeremonyTTL 注册/登录仪式的挑战有效期，也作为浏览器端超时
+	passkeyCeremonyTTL      = 5 * time.Minute
+	maxPasskeyNameLength    = 100
+	maxPasskeyCredentialLen = 512
+	maxPasskeyTransports    = 8
+)
+
+var ErrPasskeyUserNotFound = errors.New("user not found")
+
+// PasskeyCredentialParam 浏览器可选的公钥算法
+type PasskeyCredentialParam struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// PasskeyCredentialDescriptor 已知凭据
+type PasskeyCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// PasskeyCreationOptions 传给 navigator.credentials.create() 的 publicKey 参数（二进制字段为 base64url）
+type PasskeyCreationOptions struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParam      `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	Attestation            string                        `json:"attestation"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		RequireResident  bool   `json:"requireResidentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+}
+
+// PasskeyRequestOptions 传给 navigator.credentials.get() 的 publicKey 参数。
+// allowCredentials 为空，由浏览器列出本站可发现凭据，用户无需先输入邮箱
+type PasskeyRequestOptions struct {
+	Challenge        string                        `json:"challenge"`
+	RPID             string                        `json:"rpId"`
+	Timeout          int64                         `json:"timeout"`
+	UserVerification string                        `json:"userVerification"`
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+}
+
+// PasskeyRegistration 浏览器返回的注册结果（base64url）
+type PasskeyRegistration struct {
+	ID                string
+	ClientDataJSON    string
+	AttestationObject string
+	Transports        []string
+}
+
+// PasskeyAssertion 浏览器返回的登录断言（base64url）
+type PasskeyAssertion struct {
+	ID                string
+	ClientDataJSON    string
+	AuthenticatorData string
+	Signature         string
+	UserHandle        string
+}
+
+// PasskeyService 通行密钥的注册、管理与登录。挑战存于 Redis，一次仪式只能使用一次
+type PasskeyService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewPasskeyService(db *gorm.DB, cfg *config.Config) *PasskeyService {
+	return &PasskeyService{db: db, cfg: cfg}
+}
+
+// relyingParty 由站点地址推导依赖方，未配置站点地址时不可用
+func (s *PasskeyService) relyingParty() (webauthn.RelyingParty, error) {
+	appURL, name := "", ""
+	if s.cfg != nil {
+		appURL, name = s.cfg.App.URL, s.cfg.App.Name
+	}
+	if strings.TrimSpace(name) == "" {
+		name = "AuraLogic"
+	}
+	rp, err := webauthn.NewRelyingParty(appURL, name)
+	if err != nil {
+		return webauthn.RelyingParty{}, bizerr.New("passkey.unavailable", "Passkeys are not available because the site URL is not configured")
+	}
+	return rp, nil
+}
+
+func passkeyRegisterKey(userID uint) string {
+	return fmt.Sprintf("passkey:register:%d", userID)
+}
+
+func passkeyLoginKey(sessionID string) string {
+	return "passkey:login:" + sessionID
+}
+
+// consumePasskeyChallenge 取出并作废挑战，并发请求只有一个能拿到
+func consumePasskeyChallenge(key string) ([]byte, error) {
+	raw, err := cache.Get(key)
+	if err != nil {
+		return nil, passkeyChallengeExpired()
+	}
+	deleted, err := cache.RedisClient.Del(cache.RedisClient.Context(), key).Result()
+	if err != nil || deleted == 0 {
+		return nil, passkeyChallengeExpired()
+	}
+	challenge, err := webauthn.Encoding.DecodeString(raw)
+	if err != nil {
+		return nil, passkeyChallengeExpired()
+	}
+	return challenge, nil
+}
+
+func passkeyChallengeExpired() *bizerr.Error {
+	return bizerr.New("passkey.challengeExpired", "The passkey request has expired, please try again")
+}
+
+func passkeyVerificationFailed() *bizerr.Error {
+	return bizerr.New("passkey.verificationFailed", "Passkey verification failed")
+}
+
+func decodePasskeyField(value string) ([]byte, error) {
+	decoded, err := webauthn.Encoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+	if err != nil || len(decoded) == 0 {
+		return nil, passkeyVerificationFailed()
+	}
+	return decoded, nil
+}
+
+// formatAAGUID 以 UUID 格式展示认证器型号标识，全零表示未提供
+func formatAAGUID(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return ""
+	}
+	allZero := true
+	for _, b := range aaguid {
+		if b != 0 {
+			allZero = false
+			break
+		}
+	}
+	if allZero {
+		return ""
+	}
+	h := hex.EncodeToString(aaguid)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// List 用户的通行密钥，按注册时间排列
+func (s *PasskeyService) List(userID uint) ([]models.UserPasskey, error) {
+	passkeys := make([]models.UserPasskey, 0)
+	if err := s.db.Where("user_id = ?", userID).Order("id ASC").Find(&passkeys).Error; err != nil {
+		return nil, err
+	}
+	return passkeys, nil
+}
+
+// BeginRegistration 为已登录用户生成注册参数；已注册的凭据放入 excludeCredentials，避免同一设备重复注册
+func (s *PasskeyService) BeginRegistration(userID uint) (*PasskeyCreationOptions, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPasskeyUserNotFound
+		}
+		return nil, err
+	}
+	existing, err := s.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxPasskeysPerUser {
+		return nil, bizerr.Newf("passkey.limitReached", "You can register at most %d passkeys", maxPasskeysPerUser).
+			WithParams(map[string]interface{}{"max": maxPasskeysPerUser})
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+	encoded := webauthn.Encoding.EncodeToString(challenge)
+	if err := cache.Set(passkeyRegisterKey(userID), encoded, passkeyCeremonyTTL); err != nil {
+		return nil, fmt.Errorf("failed to store passkey challenge: %w", err)
+	}
+
+	options := &PasskeyCreationOptions{
+		Challenge:          encoded,
+		Timeout:            passkeyCeremonyTTL.Milliseconds(),
+		Attestation:        "none",
+		ExcludeCredentials: make([]PasskeyCredentialDescriptor, 0, len(existing)),
+	}
+	options.RP.ID = rp.ID
+	options.RP.Name = rp.Name
+	// 用户句柄使用 UUID，不暴露邮箱或自增 ID
+	options.User.ID = webauthn.Encoding.EncodeToString([]byte(user.UUID))
+	options.User.Name = user.Email
+	if options.User.Name == "" && user.Phone != nil {
+		options.User.Name = *user.Phone
+	}
+	options.User.DisplayName = user.Name
+	if options.User.DisplayName == "" {
+		options.User.DisplayName = options.User.Name
+	}
+	for _, alg := range webauthn.SupportedAlgorithms {
+		options.PubKeyCredParams = append(options.PubKeyCredParams, PasskeyCredentialParam{Type: "public-key", Alg: alg})
+	}
+	for _, passkey := range existing {
+		options.ExcludeCredentials = append(options.ExcludeCredentials, PasskeyCredentialDescriptor{
+			Type:       "public-key",
+			ID:         passkey.CredentialID,
+			Transports: passkey.Transports,
+		})
+	}
+	options.AuthenticatorSelection.ResidentKey = "required"
+	options.AuthenticatorSelection.RequireResident = true
+	options.AuthenticatorSelection.UserVerification = "required"
+	return options, nil
+}
+
+// FinishRegistration 校验浏览器返回的凭据并保存
+func (s *PasskeyService) FinishRegistration(userID uint, name string, registration PasskeyRegistration) (*models.UserPasskey, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := consumePasskeyChallenge(passkeyRegisterKey(userID))
+	if err != nil {
+		return nil, err
+	}
+	clientDataJSON, err := decodePasskeyField(registration.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	attestationObject, err := decodePasskeyField(registration.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		log.Printf("passkey registration rejected: user=%d err=%v", userID, err)
+		return nil, passkeyVerificationFailed()
+	}
+	credentialID := webauthn.Encoding.EncodeToString(credential.ID)
+	if len(credentialID) > maxPasskeyCredentialLen {
+		return nil, passkeyVerificationFailed()
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Passkey"
+	}
+	if utf8.RuneCountInString(name) > maxPasskeyNameLength {
+		name = string([]rune(name)[:maxPasskeyNameLength])
+	}
+	transports := registration.Transports
+	if len(transports) > maxPasskeyTransports {
+		transports = transports[:maxPasskeyTransports]
+	}
+
+	passkey := &models.UserPasskey{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credentialID,
+		PublicKey:    credential.PublicKey,
+		Algorithm:    credential.Algorithm,
+		SignCount:    credential.SignCount,
+		AAGUID:       formatAAGUID(credential.AAGUID),
+		Transports:   transports,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.UserPasskey{}).Where("credential_id = ?", credentialID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return bizerr.New("passkey.alreadyRegistered", "This passkey is already registered")
+		}
+		if err := tx.Model(&models.UserPasskey{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxPasskeysPerUser {
+			return bizerr.Newf("passkey.limitReached", "You can register at most %d passkeys", maxPasskeysPerUser).
+				WithParams(map[string]interface{}{"max": maxPasskeysPerUser})
+		}
+		return tx.Create(passkey).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return passkey, nil
+}
+
+// Delete 删除用户自己的通行密钥
+func (s *PasskeyService) Delete(userID, passkeyID uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", passkeyID, userID).Delete(&models.UserPasskey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return bizerr.New("passkey.notFound", "Passkey not found").WithStatus(http.StatusNotFound)
+	}
+	return nil
+}
+
+// BeginLogin 生成登录挑战，返回仪式 ID 与 navigator.credentials.get() 参数
+func (s *PasskeyService) BeginLogin() (string, *PasskeyRequestOptions, error) {
+	if s.cfg == nil || !s.cfg.Security.Login.AllowPasskeyLogin {
+		return "", nil, bizerr.New("passkey.loginDisabled", "Passkey login is disabled")
+	}
+	rp, err := s.relyingParty()
+	if err != nil {
+		return "", nil, err
+	}
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", nil, err
+	}
+	sessionBytes := make([]byte, 16)
+	if _, err := rand.Read(sessionBytes); err != nil {
+		return "", nil, err
+	}
+	sessionID := hex.EncodeToString(sessionBytes)
+	encoded := webauthn.Encoding.EncodeToString(challenge)
+	if err := cache.Set(passkeyLoginKey(sessionID), encoded, passkeyCeremonyTTL); err != nil {
+		return "", nil, fmt.Errorf("failed to store passkey challenge: %w", err)
+	}
+	return sessionID, &PasskeyRequestOptions{
+		Challenge:        encoded,
+		RPID:             rp.ID,
+		Timeout:          passkeyCeremonyTTL.Milliseconds(),
+		UserVerification: "required",
+		AllowCredentials: []PasskeyCredentialDescriptor{},
+	}, nil
+}
+
+// FinishLogin 校验登录断言，成功后返回对应用户。
+// 通行密钥要求用户验证（生物识别或 PIN），本身即为多因素，不再要求输入 TOTP 验证码；
+// 被要求开启两步验证但尚未绑定的用户由处理器引导先完成绑定
+func (s *PasskeyService) FinishLogin(sessionID string, assertion PasskeyAssertion) (*models.User, error) {
+	if s.cfg == nil || !s.cfg.Security.Login.AllowPasskeyLogin {
+		return nil, bizerr.New("passkey.loginDisabled", "Passkey login is disabled")
+	}
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, passkeyChallengeExpired()
+	}
+	challenge, err := consumePasskeyChallenge(passkeyLoginKey(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	credentialID, err := decodePasskeyField(assertion.ID)
+	if err != nil {
+		return nil, err
+	}
+	clientDataJSON, err := decodePasskeyField(assertion.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	authenticatorData, err := decodePasskeyField(assertion.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := decodePasskeyField(assertion.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var passkey models.UserPasskey
+		if err := tx.Where("credential_id = ?", webauthn.Encoding.EncodeToString(credentialID)).First(&passkey).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return passkeyVerificationFailed()
+			}
+			return err
+		}
+		if err := tx.First(&user, passkey.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return passkeyVerificationFailed()
+			}
+			return err
+		}
+		// 浏览器返回的用户句柄必须与凭据所属用户一致
+		if strings.TrimSpace(assertion.UserHandle) != "" {
+			handle, err := decodePasskeyField(assertion.UserHandle)
+			if err != nil || string(handle) != user.UUID {
+				return passkeyVerificationFailed()
+			}
+		}
+		signCount, err := rp.VerifyAssertion(challenge, clientDataJSON, authenticatorData, signature, passkey.PublicKey, passkey.SignCount)
+		if err != nil {
+			log.Printf("passkey login rejected: user=%d passkey=%d err=%v", user.ID, passkey.ID, err)
+			return passkeyVerificationFailed()
+		}
+		now := models.NowFunc()
+		// 以旧计数为条件更新，并发使用同一断言时只有一个成功
+		result := tx.Model(&models.UserPasskey{}).
+			Where("id = ? AND sign_count = ?", passkey.ID, passkey.SignCount).
+			Updates(map[string]interface{}{"sign_count": signCount, "last_used_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return passkeyVerificationFailed()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, authbiz.AccountDisabled()
+	}
+	if err := CheckEmailVerification(s.cfg, &user, EmailVerificationModeLogin); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
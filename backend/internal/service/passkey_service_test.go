@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/webauthn"
+)
+
+func TestPasskeyCeremoniesAndManagement(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.UserPasskey{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	newOTPServiceForTest(t, config.OTPConfig{})
+
+	cfg := &config.Config{}
+	cfg.App.Name = "Shop"
+	svc := NewPasskeyService(db, cfg)
+	user := &models.User{UUID: "passkey-user", Email: "passkey@example.com", Name: "Passkey", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// 未配置站点地址时无法确定依赖方
+	_, err := svc.BeginRegistration(user.ID)
+	requireOrderBizErr(t, err, "passkey.unavailable")
+	cfg.App.URL = "https://shop.example.com"
+
+	existing := models.UserPasskey{UserID: user.ID, Name: "Laptop", CredentialID: "Y3JlZC0x", PublicKey: []byte{0xa0}, Transports: []string{"internal"}}
+	if err := db.Create(&existing).Error; err != nil {
+		t.Fatalf("create passkey: %v", err)
+	}
+	options, err := svc.BeginRegistration(user.ID)
+	if err != nil {
+		t.Fatalf("begin registration: %v", err)
+	}
+	if options.RP.ID != "shop.example.com" || options.RP.Name != "Shop" {
+		t.Fatalf("unexpected relying party: %+v", options.RP)
+	}
+	if options.User.ID != webauthn.Encoding.EncodeToString([]byte(user.UUID)) || options.User.Name != user.Email {
+		t.Fatalf("unexpected user entity: %+v", options.User)
+	}
+	if len(options.ExcludeCredentials) != 1 || options.ExcludeCredentials[0].ID != existing.CredentialID {
+		t.Fatalf("expected existing credential excluded, got %+v", options.ExcludeCredentials)
+	}
+	if options.AuthenticatorSelection.UserVerification != "required" || len(options.PubKeyCredParams) != len(webauthn.SupportedAlgorithms) {
+		t.Fatalf("unexpected options: %+v", options)
+	}
+
+	// 挑战只能使用一次：校验失败后再次提交视为过期
+	_, err = svc.FinishRegistration(user.ID, "Phone", PasskeyRegistration{ID: "eA", ClientDataJSON: "e30", AttestationObject: "oA"})
+	requireOrderBizErr(t, err, "passkey.verificationFailed")
+	_, err = svc.FinishRegistration(user.ID, "Phone", PasskeyRegistration{ID: "eA", ClientDataJSON: "e30", AttestationObject: "oA"})
+	requireOrderBizErr(t, err, "passkey.challengeExpired")
+
+	for i := 1; i < maxPasskeysPerUser; i++ {
+		extra := models.UserPasskey{UserID: user.ID, Name: "Extra", CredentialID: webauthn.Encoding.EncodeToString([]byte{byte(i)}), PublicKey: []byte{0xa0}}
+		if err := db.Create(&extra).Error; err != nil {
+			t.Fatalf("create passkey: %v", err)
+		}
+	}
+	_, err = svc.BeginRegistration(user.ID)
+	requireOrderBizErr(t, err, "passkey.limitReached")
+
+	requireOrderBizErr(t, svc.Delete(user.ID+1, existing.ID), "passkey.notFound")
+	if err := svc.Delete(user.ID, existing.ID); err != nil {
+		t.Fatalf("delete passkey: %v", err)
+	}
+	passkeys, err := svc.List(user.ID)
+	if err != nil || len(passkeys) != maxPasskeysPerUser-1 {
+		t.Fatalf("expected %d passkeys, got %d err=%v", maxPasskeysPerUser-1, len(passkeys), err)
+	}
+
+	// 登录需管理员开启；未知凭据与重复使用的仪式均被拒绝
+	_, _, err = svc.BeginLogin()
+	requireOrderBizErr(t, err, "passkey.loginDisabled")
+	cfg.Security.Login.AllowPasskeyLogin = true
+	sessionID, request, err := svc.BeginLogin()
+	if err != nil || sessionID == "" || request.RPID != "shop.example.com" || len(request.AllowCredentials) != 0 {
+		t.Fatalf("begin login: %q %+v err=%v", sessionID, request, err)
+	}
+	assertion := PasskeyAssertion{ID: "dW5rbm93bg", ClientDataJSON: "e30", AuthenticatorData: "AA", Signature: "AA"}
+	_, err = svc.FinishLogin(sessionID, assertion)
+	requireOrderBizErr(t, err, "passkey.verificationFailed")
+	_, err = svc.FinishLogin(sessionID, assertion)
+	requireOrderBizErr(t, err, "passkey.challengeExpired")
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+// 付款链接默认有效期
+const defaultPaymentLinkTTL = 72 * time.Hour
+
+func newPaymentLinkDisabledError() error {
+	return bizerr.New("payment_link.disabled", "Payment links are not available")
+}
+
+// 订单不存在与签名错误返回同一错误，避免被用来探测订单号
+func newPaymentLinkInvalidError() error {
+	return bizerr.New("payment_link.invalid", "The payment link is invalid or has expired")
+}
+
+func newPaymentLinkOrderNotPayableError(status models.OrderStatus) error {
+	return bizerr.Newf("payment_link.orderNotPayable", "Order is %s and no longer awaiting payment", status).
+		WithParams(map[string]interface{}{"status": string(status)})
+}
+
+// PaymentLink 生成的付款链接
+type PaymentLink struct {
+	OrderNo   string    `json:"order_no"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PublicPaymentLinkOrder 付款链接页展示的订单摘要，不包含收货人与联系方式
+type PublicPaymentLinkOrder struct {
+	OrderNo          string              `json:"order_no"`
+	Status           models.OrderStatus  `json:"status"`
+	Items            []OrderTrackingItem `json:"items"`
+	TotalAmountMinor int64               `json:"total_amount_minor"`
+	Currency         string              `json:"currency"`
+	CreatedAt        time.Time           `json:"created_at"`
+	PaidAt           *time.Time          `json:"paid_at,omitempty"`
+}
+
+// PaymentLinkService 待付款订单的签名付款链接（电话下单客户、企业采购代付无需登录即可付款）
+type PaymentLinkService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewPaymentLinkService 创建付款链接服务
+func NewPaymentLinkService(db *gorm.DB, cfg *config.Config) *PaymentLinkService {
+	return &PaymentLinkService{db: db, cfg: cfg}
+}
+
+func (s *PaymentLinkService) enabled() bool {
+	return s.cfg != nil && s.cfg.Order.PaymentLink.Enabled
+}
+
+func (s *PaymentLinkService) linkTTL() time.Duration {
+	if s.cfg != nil && s.cfg.Order.PaymentLink.LinkTTLHours > 0 {
+		return time.Duration(s.cfg.Order.PaymentLink.LinkTTLHours) * time.Hour
+	}
+	return defaultPaymentLinkTTL
+}
+
+// sign 付款链接签名
+func (s *PaymentLinkService) sign(orderNo string, expires int64) string {
+	return signOrderLink(s.cfg, orderLinkPurposePayment, orderNo, expires)
+}
+
+// Generate 为待付款订单生成签名付款链接
+func (s *PaymentLinkService) Generate(order *models.Order) (*PaymentLink, error) {
+	if !s.enabled() {
+		return nil, newPaymentLinkDisabledError()
+	}
+	if order.Status != models.OrderStatusPendingPayment {
+		return nil, newPaymentLinkOrderNotPayableError(order.Status)
+	}
+
+	expiresAt := time.Now().Add(s.linkTTL()).Truncate(time.Second)
+	query := orderLinkQuery(s.cfg, orderLinkPurposePayment, order.OrderNo, expiresAt.Unix())
+	return &PaymentLink{
+		OrderNo:   order.OrderNo,
+		URL:       strings.TrimRight(s.cfg.App.URL, "/") + "/pay?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Resolve 校验签名链接并返回对应订单；已付款等非待付款订单同样返回，由调用方决定是否允许继续付款
+func (s *PaymentLinkService) Resolve(orderNo, expires, sig string) (*models.Order, error) {
+	if !s.enabled() {
+		return nil, newPaymentLinkDisabledError()
+	}
+	orderNo, ok := verifyOrderLink(s.cfg, orderLinkPurposePayment, orderNo, expires, sig)
+	if !ok {
+		return nil, newPaymentLinkInvalidError()
+	}
+
+	var order models.Order
+	if err := s.db.Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newPaymentLinkInvalidError()
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ResolvePayable 校验签名链接，并要求订单仍处于待付款状态
+func (s *PaymentLinkService) ResolvePayable(orderNo, expires, sig string) (*models.Order, error) {
+	order, err := s.Resolve(orderNo, expires, sig)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.OrderStatusPendingPayment {
+		return nil, newPaymentLinkOrderNotPayableError(order.Status)
+	}
+	return order, nil
+}
+
+// BuildPublicPaymentLinkOrder 构建付款链接页的订单摘要
+func BuildPublicPaymentLinkOrder(order *models.Order) *PublicPaymentLinkOrder {
+	view := &PublicPaymentLinkOrder{
+		OrderNo:          order.OrderNo,
+		Status:           order.Status,
+		Items:            make([]OrderTrackingItem, 0, len(order.Items)),
+		TotalAmountMinor: order.TotalAmount,
+		Currency:         order.Currency,
+		CreatedAt:        order.CreatedAt,
+		PaidAt:           order.PaidAt,
+	}
+	for _, item := range order.Items {
+		view.Items = append(view.Items, OrderTrackingItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			ImageURL: item.ImageURL,
+		})
+	}
+	return view
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestPaymentLinkGenerateAndResolve(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		t.Fatalf("auto migrate orders failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.App.URL = "https://shop.example.com/"
+	cfg.JWT.Secret = "payment-link-secret"
+	svc := NewPaymentLinkService(db, cfg)
+
+	order := &models.Order{OrderNo: "PAY-1", Status: models.OrderStatusPendingPayment, Currency: "USD", TotalAmount: 2500}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	_, err := svc.Generate(order)
+	requireBizErr(t, err, "payment_link.disabled")
+
+	cfg.Order.PaymentLink.Enabled = true
+	link, err := svc.Generate(order)
+	if err != nil {
+		t.Fatalf("generate payment link failed: %v", err)
+	}
+	parsed, err := url.Parse(link.URL)
+	if err != nil || parsed.Host != "shop.example.com" || parsed.Path != "/pay" {
+		t.Fatalf("unexpected payment link %q", link.URL)
+	}
+	if ttl := time.Until(link.ExpiresAt); ttl < 71*time.Hour || ttl > 72*time.Hour {
+		t.Fatalf("expected default 72h ttl, got %s", ttl)
+	}
+	query := parsed.Query()
+
+	resolved, err := svc.ResolvePayable(query.Get("order_no"), query.Get("expires"), query.Get("sig"))
+	if err != nil || resolved.ID != order.ID {
+		t.Fatalf("resolve payment link failed: order=%+v err=%v", resolved, err)
+	}
+
+	// 篡改订单号或有效期均视为无效链接
+	_, err = svc.Resolve("PAY-2", query.Get("expires"), query.Get("sig"))
+	requireBizErr(t, err, "payment_link.invalid")
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	_, err = svc.Resolve("PAY-1", strconv.FormatInt(expires+3600, 10), query.Get("sig"))
+	requireBizErr(t, err, "payment_link.invalid")
+	// 订单查询链接的签名不能用于付款
+	tracking := NewOrderTrackingService(db, cfg)
+	_, err = svc.Resolve("PAY-1", query.Get("expires"), tracking.sign("PAY-1", expires))
+	requireBizErr(t, err, "payment_link.invalid")
+
+	past := time.Now().Add(-time.Minute).Unix()
+	_, err = svc.Resolve("PAY-1", strconv.FormatInt(past, 10), svc.sign("PAY-1", past))
+	requireBizErr(t, err, "payment_link.invalid")
+
+	// 订单付款后链接仍可查看，但不能再选择付款方式，也不能生成新链接
+	if err := db.Model(order).Update("status", models.OrderStatusPending).Error; err != nil {
+		t.Fatalf("mark order paid failed: %v", err)
+	}
+	if resolved, err := svc.Resolve(query.Get("order_no"), query.Get("expires"), query.Get("sig")); err != nil || resolved.Status != models.OrderStatusPending {
+		t.Fatalf("expected paid order to stay viewable, got order=%+v err=%v", resolved, err)
+	}
+	_, err = svc.ResolvePayable(query.Get("order_no"), query.Get("expires"), query.Get("sig"))
+	requireBizErr(t, err, "payment_link.orderNotPayable")
+	order.Status = models.OrderStatusPending
+	_, err = svc.Generate(order)
+	requireBizErr(t, err, "payment_link.orderNotPayable")
+}
@@ -28,6 +28,8 @@ type paymentMethodPackageImportOptions struct {
 	Download      map[string]interface{}
 	Compatibility map[string]interface{}
 	Warnings      []string
+	// CreateDisabled 新建付款方式时保持停用，需管理员填写商户凭据后再启用
+	CreateDisabled bool
 }
 
 type generatedPaymentMethodPackageDefinition struct {
@@ -41,6 +43,8 @@ type generatedPaymentMethodPackageDefinition struct {
 	Script        string
 	PackageName   string
 	EntryFileName string
+	Webhooks      []marketPaymentPackageWebhookManifest
+	Disabled      bool
 }
 
 type builtinPaymentPackageDefinition = generatedPaymentMethodPackageDefinition
@@ -163,7 +167,7 @@ func importPaymentMethodPackageArchiveWithOptions(
 				Name:            preview.Resolved.Name,
 				Description:     preview.Resolved.Description,
 				Type:            models.PaymentMethodTypeCustom,
-				Enabled:         true,
+				Enabled:         !options.CreateDisabled,
 				Script:          preview.Resolved.Script,
 				Config:          preview.Resolved.Config,
 				Icon:            preview.Resolved.Icon,
@@ -177,6 +181,12 @@ func importPaymentMethodPackageArchiveWithOptions(
 			if err := createMarketPaymentMethod(tx, method); err != nil {
 				return &PluginHostActionError{Status: http.StatusInternalServerError, Message: "create payment method failed"}
 			}
+			// enabled 列带有 default:true，Create 会忽略 false 零值，需要显式写回
+			if options.CreateDisabled {
+				if err := tx.Model(method).Update("enabled", false).Error; err != nil {
+					return &PluginHostActionError{Status: http.StatusInternalServerError, Message: "create payment method failed"}
+				}
+			}
 		} else {
 			updates := map[string]interface{}{
 				"name":             preview.Resolved.Name,
@@ -407,6 +417,7 @@ func initBuiltinPaymentMethodPackages(runtime *PluginHostRuntime) error {
 						Name:     definition.ArtifactName,
 						Version:  definition.Version,
 					},
+					CreateDisabled: definition.Disabled,
 				},
 			)
 			if importErr != nil {
@@ -516,6 +527,8 @@ func builtinPaymentPackageDefinitions() ([]builtinPaymentPackageDefinition, erro
 			Script:        method.Script,
 			PackageName:   fmt.Sprintf("%s-%s.zip", artifactName, version),
 			EntryFileName: "index.js",
+			Webhooks:      builtinPaymentPackageWebhooks(artifactName),
+			Disabled:      !method.Enabled,
 		})
 	}
 	return definitions, nil
@@ -527,11 +540,75 @@ func builtinPaymentPackageArtifactName(name string) string {
 		return "builtin-usdt-trc20"
 	case "USDT BEP20 (BSC)":
 		return "builtin-usdt-bep20-bsc"
+	case "WeChat Pay":
+		return "builtin-wechat-pay"
+	case "Alipay":
+		return "builtin-alipay"
+	case "Paddle":
+		return "builtin-paddle"
+	case "Lemon Squeezy":
+		return "builtin-lemon-squeezy"
 	default:
 		return ""
 	}
 }
 
+// builtinPaymentPackageWebhooks 内置付款方式声明的回调；签名由脚本按网关规则自行校验，因此不使用宿主鉴权
+func builtinPaymentPackageWebhooks(artifactName string) []marketPaymentPackageWebhookManifest {
+	switch artifactName {
+	case "builtin-wechat-pay":
+		return []marketPaymentPackageWebhookManifest{
+			{
+				Key:         "wechat.notify",
+				Description: ManifestLocalizedText{raw: "WeChat Pay APIv3 payment notification", value: "WeChat Pay APIv3 payment notification"},
+				Method:      "POST",
+				AuthMode:    "none",
+			},
+			{
+				Key:         "wechat.jsapi",
+				Description: ManifestLocalizedText{raw: "JSAPI checkout inside the WeChat browser", value: "JSAPI checkout inside the WeChat browser"},
+				Method:      "GET",
+				AuthMode:    "none",
+			},
+		}
+	case "builtin-alipay":
+		return []marketPaymentPackageWebhookManifest{
+			{
+				Key:         "alipay.notify",
+				Description: ManifestLocalizedText{raw: "Alipay asynchronous payment notification", value: "Alipay asynchronous payment notification"},
+				Method:      "POST",
+				AuthMode:    "none",
+			},
+		}
+	case "builtin-paddle":
+		return []marketPaymentPackageWebhookManifest{
+			{
+				Key:         "paddle.notify",
+				Description: ManifestLocalizedText{raw: "Paddle transaction notification", value: "Paddle transaction notification"},
+				Method:      "POST",
+				AuthMode:    "none",
+			},
+			{
+				Key:         "paddle.checkout",
+				Description: ManifestLocalizedText{raw: "Default payment link page that opens Paddle Checkout", value: "Default payment link page that opens Paddle Checkout"},
+				Method:      "GET",
+				AuthMode:    "none",
+			},
+		}
+	case "builtin-lemon-squeezy":
+		return []marketPaymentPackageWebhookManifest{
+			{
+				Key:         "lemonsqueezy.notify",
+				Description: ManifestLocalizedText{raw: "Lemon Squeezy order_created webhook", value: "Lemon Squeezy order_created webhook"},
+				Method:      "POST",
+				AuthMode:    "none",
+			},
+		}
+	default:
+		return nil
+	}
+}
+
 func builtinPaymentPackageVersion(_ string) string {
 	return "1.0.0"
 }
@@ -614,6 +691,7 @@ func createGeneratedPaymentPackageArchive(
 		MinHostProtocolVersion: DefaultPluginHostProtocolVersion,
 		MaxHostProtocolVersion: DefaultPluginHostProtocolVersion,
 		Config:                 definition.Config,
+		Webhooks:               definition.Webhooks,
 	}
 	manifestRaw, err := json.Marshal(manifest)
 	if err != nil {
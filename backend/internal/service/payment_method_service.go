@@ -9,7 +9,9 @@ import (
 
 	"auralogic/internal/config"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
 	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -275,27 +277,92 @@ func (s *PaymentMethodService) SelectPaymentMethod(orderID, paymentMethodID uint
 		return errors.New("order is not in pending payment status")
 	}
 
-	// 创建或更新订单付款方式
+	// 创建或更新订单付款方式，并按新付款方式重新计算手续费
 	opm := models.OrderPaymentMethod{
 		OrderID:         orderID,
 		PaymentMethodID: paymentMethodID,
 	}
+	fee := ApplyPaymentMethodFee(&order, pm)
 
-	err = s.db.Where("order_id = ?", orderID).
-		Assign(models.OrderPaymentMethod{PaymentMethodID: paymentMethodID}).
-		FirstOrCreate(&opm).Error
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_id = ?", orderID).
+			Assign(models.OrderPaymentMethod{PaymentMethodID: paymentMethodID}).
+			FirstOrCreate(&opm).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Order{}).Where("id = ?", orderID).Updates(map[string]interface{}{
+			"total_amount":         order.TotalAmount,
+			"payment_fee":          order.PaymentFee,
+			"payment_fee_retained": order.PaymentFeeRetained,
+		}).Error
+	})
 
 	if err == nil {
 		logger.LogPaymentOperation(s.db, "payment_method_selected", orderID, map[string]interface{}{
 			"order_no":          order.OrderNo,
 			"payment_method_id": paymentMethodID,
 			"payment_method":    pm.Name,
+			"payment_fee":       fee,
+			"total_amount":      order.TotalAmount,
+		})
+		event := newOrderEvent(&order, models.OrderEventTypePayment, "payment.select", map[string]interface{}{
+			"payment_method":    pm.Name,
+			"payment_method_id": paymentMethodID,
 		})
+		event.OperatorType = models.OrderEventOperatorUser
+		event.OperatorID = order.UserID
+		recordOrderEventDB(s.db, event)
 	}
 
 	return err
 }
 
+// ApplyPaymentMethodFee 按付款方式的手续费规则重新计算订单金额，返回本次手续费
+// 先扣除之前计入的手续费再计算，切换付款方式不会叠加
+func ApplyPaymentMethodFee(order *models.Order, pm *models.PaymentMethod) int64 {
+	base := order.TotalAmount - order.PaymentFee
+	fee := pm.CalculateFee(base)
+	// 零小数币种（JPY/KRW 等）的手续费取整到整数单位，与下单时的金额取整保持一致
+	if money.IsZeroDecimalCurrency(order.Currency) {
+		whole := money.RoundingRule{Mode: money.RoundingNearest, Increment: money.CurrencyScale}
+		if fee < 0 {
+			fee = -whole.Round(-fee)
+		} else {
+			fee = whole.Round(fee)
+		}
+		if fee < -base {
+			fee = -base
+		}
+	}
+	order.TotalAmount = base + fee
+	order.PaymentFee = fee
+	order.PaymentFeeRetained = fee > 0 && pm.FeeRetainedOnRefund
+	return fee
+}
+
+// UpdatePaymentMethodFee 更新付款方式手续费规则，比例限制在 -100% 到 +100% 之间
+func (s *PaymentMethodService) UpdatePaymentMethodFee(id uint, feeRate, feeFixed int64, retainedOnRefund bool) (*models.PaymentMethod, error) {
+	if feeRate < -money.PercentageScale || feeRate > money.PercentageScale {
+		return nil, bizerr.New("payment.feeRateInvalid", "Fee rate must be between -100% and 100%").
+			WithParams(map[string]interface{}{"min": -100, "max": 100})
+	}
+	pm, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.PaymentMethod{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"fee_rate":               feeRate,
+		"fee_fixed":              feeFixed,
+		"fee_retained_on_refund": retainedOnRefund,
+	}).Error; err != nil {
+		return nil, err
+	}
+	pm.FeeRate = feeRate
+	pm.FeeFixed = feeFixed
+	pm.FeeRetainedOnRefund = retainedOnRefund
+	return pm, nil
+}
+
 // GetOrderPaymentMethod 获取订单选择的付款方式
 func (s *PaymentMethodService) GetOrderPaymentMethod(orderID uint) (*models.PaymentMethod, *models.OrderPaymentMethod, error) {
 	var opm models.OrderPaymentMethod
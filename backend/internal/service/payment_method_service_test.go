@@ -23,13 +23,26 @@ func TestPaymentMethodServiceInitBuiltinPaymentMethodsImportsPackageBackedMethod
 	if err := db.Order("id ASC").Find(&methods).Error; err != nil {
 		t.Fatalf("query payment methods failed: %v", err)
 	}
-	if len(methods) != 2 {
-		t.Fatalf("expected 2 builtin payment methods, got %d", len(methods))
+	if len(methods) != 6 {
+		t.Fatalf("expected 6 builtin payment methods, got %d", len(methods))
 	}
 
 	expectedArtifacts := map[string]string{
 		"USDT TRC20":       "builtin-usdt-trc20",
 		"USDT BEP20 (BSC)": "builtin-usdt-bep20-bsc",
+		"WeChat Pay":       "builtin-wechat-pay",
+		"Alipay":           "builtin-alipay",
+		"Paddle":           "builtin-paddle",
+		"Lemon Squeezy":    "builtin-lemon-squeezy",
+	}
+	// 微信支付/支付宝/代收商户需要商户凭据，初始化时保持停用
+	expectedEnabled := map[string]bool{
+		"USDT TRC20":       true,
+		"USDT BEP20 (BSC)": true,
+		"WeChat Pay":       false,
+		"Alipay":           false,
+		"Paddle":           false,
+		"Lemon Squeezy":    false,
 	}
 	methodByID := make(map[uint]models.PaymentMethod, len(methods))
 	for _, method := range methods {
@@ -38,6 +51,9 @@ func TestPaymentMethodServiceInitBuiltinPaymentMethodsImportsPackageBackedMethod
 		if !ok {
 			t.Fatalf("unexpected builtin payment method name %q", method.Name)
 		}
+		if method.Enabled != expectedEnabled[method.Name] {
+			t.Fatalf("expected builtin method %q enabled=%v, got %v", method.Name, expectedEnabled[method.Name], method.Enabled)
+		}
 		if method.Type != models.PaymentMethodTypeCustom {
 			t.Fatalf("expected builtin method %q type=custom, got %s", method.Name, method.Type)
 		}
@@ -62,8 +78,8 @@ func TestPaymentMethodServiceInitBuiltinPaymentMethodsImportsPackageBackedMethod
 	if err := db.Order("id ASC").Find(&versions).Error; err != nil {
 		t.Fatalf("query payment method versions failed: %v", err)
 	}
-	if len(versions) != 2 {
-		t.Fatalf("expected 2 payment method versions after builtin init, got %d", len(versions))
+	if len(versions) != 6 {
+		t.Fatalf("expected 6 payment method versions after builtin init, got %d", len(versions))
 	}
 	for _, version := range versions {
 		method, ok := methodByID[version.PaymentMethodID]
@@ -99,16 +115,16 @@ func TestPaymentMethodServiceInitBuiltinPaymentMethodsImportsPackageBackedMethod
 	if err := db.Model(&models.PaymentMethod{}).Count(&methodCount).Error; err != nil {
 		t.Fatalf("count payment methods failed: %v", err)
 	}
-	if methodCount != 2 {
-		t.Fatalf("expected builtin init to stay idempotent with 2 payment methods, got %d", methodCount)
+	if methodCount != 6 {
+		t.Fatalf("expected builtin init to stay idempotent with 6 payment methods, got %d", methodCount)
 	}
 
 	var versionCount int64
 	if err := db.Model(&models.PaymentMethodVersion{}).Count(&versionCount).Error; err != nil {
 		t.Fatalf("count payment method versions failed: %v", err)
 	}
-	if versionCount != 2 {
-		t.Fatalf("expected builtin init to stay idempotent with 2 version snapshots, got %d", versionCount)
+	if versionCount != 6 {
+		t.Fatalf("expected builtin init to stay idempotent with 6 version snapshots, got %d", versionCount)
 	}
 }
 
@@ -247,7 +263,75 @@ func TestPaymentMethodServiceInitBuiltinPaymentMethodsAutoMigratesLegacyRecords(
 	if err := db.Model(&models.PaymentMethod{}).Count(&methodCount).Error; err != nil {
 		t.Fatalf("count payment methods failed: %v", err)
 	}
-	if methodCount != 3 {
-		t.Fatalf("expected 3 payment methods after builtin init plus legacy migration, got %d", methodCount)
+	if methodCount != 7 {
+		t.Fatalf("expected 7 payment methods after builtin init plus legacy migration, got %d", methodCount)
+	}
+}
+
+func TestPaymentMethodServiceSelectPaymentMethodAppliesFeeWithoutStacking(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.PaymentMethod{}, &models.OrderPaymentMethod{}, &models.Order{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate payment tables failed: %v", err)
+	}
+
+	svc := NewPaymentMethodService(db, &config.Config{})
+	card := &models.PaymentMethod{Name: "Card", Type: models.PaymentMethodTypeCustom, Enabled: true}
+	crypto := &models.PaymentMethod{Name: "Crypto", Type: models.PaymentMethodTypeCustom, Enabled: true}
+	for _, pm := range []*models.PaymentMethod{card, crypto} {
+		if err := svc.Create(pm); err != nil {
+			t.Fatalf("create payment method failed: %v", err)
+		}
+	}
+	if _, err := svc.UpdatePaymentMethodFee(card.ID, 300, 50, true); err != nil {
+		t.Fatalf("update card fee failed: %v", err)
+	}
+	if _, err := svc.UpdatePaymentMethodFee(crypto.ID, -100, 0, false); err != nil {
+		t.Fatalf("update crypto fee failed: %v", err)
+	}
+	if _, err := svc.UpdatePaymentMethodFee(crypto.ID, 20000, 0, false); err == nil {
+		t.Fatalf("expected fee rate above 100%% to be rejected")
+	}
+
+	order := models.Order{OrderNo: "ORD-FEE-1", Status: models.OrderStatusPendingPayment, TotalAmount: 10000, Currency: "USD"}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	reload := func() models.Order {
+		var current models.Order
+		if err := db.First(&current, order.ID).Error; err != nil {
+			t.Fatalf("reload order failed: %v", err)
+		}
+		return current
+	}
+
+	if err := svc.SelectPaymentMethod(order.ID, card.ID); err != nil {
+		t.Fatalf("select card failed: %v", err)
+	}
+	current := reload()
+	if current.PaymentFee != 350 || current.TotalAmount != 10350 || !current.PaymentFeeRetained {
+		t.Fatalf("expected card surcharge 350 on 10000, got fee=%d total=%d retained=%v", current.PaymentFee, current.TotalAmount, current.PaymentFeeRetained)
+	}
+	if current.RefundableAmount() != 10000 {
+		t.Fatalf("expected retained surcharge to be excluded from refund, got %d", current.RefundableAmount())
+	}
+
+	// 重复选择同一付款方式不会叠加手续费
+	if err := svc.SelectPaymentMethod(order.ID, card.ID); err != nil {
+		t.Fatalf("reselect card failed: %v", err)
+	}
+	if current = reload(); current.TotalAmount != 10350 {
+		t.Fatalf("expected reselect to keep total 10350, got %d", current.TotalAmount)
+	}
+
+	if err := svc.SelectPaymentMethod(order.ID, crypto.ID); err != nil {
+		t.Fatalf("select crypto failed: %v", err)
+	}
+	current = reload()
+	if current.PaymentFee != -100 || current.TotalAmount != 9900 || current.PaymentFeeRetained {
+		t.Fatalf("expected crypto discount -100 on 10000, got fee=%d total=%d retained=%v", current.PaymentFee, current.TotalAmount, current.PaymentFeeRetained)
+	}
+	if current.RefundableAmount() != 9900 {
+		t.Fatalf("expected discounted order to refund the paid total, got %d", current.RefundableAmount())
 	}
 }
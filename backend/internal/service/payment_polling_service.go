@@ -809,6 +809,10 @@ func (s *PaymentPollingService) checkPaymentStatus(task *PollingTask) (bool, int
 			"duration":    time.Since(task.AddedAt).String(),
 			"source":      "payment_polling",
 		}, s.buildPaymentHookExecutionContext(&order, task, "payment_polling"))
+		recordOrderEvent(s.orderRepo, newOrderEvent(&order, models.OrderEventTypePayment, "payment.polling_timeout", map[string]interface{}{
+			"retry_count": task.RetryCount,
+			"duration":    time.Since(task.AddedAt).String(),
+		}))
 		s.removeFromQueue(task.OrderID)
 		return false, 0
 	}
@@ -828,6 +832,10 @@ func (s *PaymentPollingService) checkPaymentStatus(task *PollingTask) (bool, int
 			"max_retries": s.maxRetries,
 			"source":      "payment_polling",
 		}, s.buildPaymentHookExecutionContext(&order, task, "payment_polling"))
+		recordOrderEvent(s.orderRepo, newOrderEvent(&order, models.OrderEventTypePayment, "payment.polling_max_retries", map[string]interface{}{
+			"retry_count": task.RetryCount,
+			"max_retries": s.maxRetries,
+		}))
 		s.removeFromQueue(task.OrderID)
 		return false, 0
 	}
@@ -941,7 +949,7 @@ func (s *PaymentPollingService) handlePaymentSuccess(task *PollingTask, order *m
 			return err
 		}
 		lockedOrder = currentOrder
-		finalizeResult, err = finalizePendingPaymentOrderTx(tx, currentOrder, s.virtualInventorySvc, paidOrderFinalizeOptions{})
+		finalizeResult, err = finalizePendingPaymentOrderTx(tx, currentOrder, s.virtualInventorySvc, paidOrderFinalizeOptions{Config: s.cfg})
 		if err != nil {
 			return err
 		}
@@ -1030,7 +1038,7 @@ func (s *PaymentPollingService) handlePaymentSuccess(task *PollingTask, order *m
 		"retry_count":       task.RetryCount,
 		"source":            normalizedSource,
 	}, hookExecCtx)
-	EmitOrderStatusChangedAfterHookAsync(s.pluginManager, hookExecCtx, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, map[string]interface{}{
+	PublishOrderStatusChanged(s.orderRepo, s.pluginManager, hookExecCtx, order, models.OrderStatusPendingPayment, finalizeResult.FinalStatus, map[string]interface{}{
 		"source":            normalizedSource,
 		"trigger_action":    "payment.confirm",
 		"payment_method_id": pm.ID,
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	maxPersonalAccessTokensPerUser = 20
+	maxPersonalAccessTokenName     = 100
+	maxPersonalAccessTokenDays     = 365
+	personalAccessTokenBytes       = 32
+	personalAccessTokenShownPrefix = 12
+)
+
+var ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+
+// PersonalAccessTokenInput 创建令牌参数，ExpiresInDays 为 0 表示永不过期
+type PersonalAccessTokenInput struct {
+	Name          string
+	Scopes        []string
+	ExpiresInDays int
+}
+
+// CreatedPersonalAccessToken 新建的令牌，Token 为明文，仅返回这一次
+type CreatedPersonalAccessToken struct {
+	models.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// PersonalAccessTokenService 用户个人访问令牌管理
+type PersonalAccessTokenService struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenService 创建个人访问令牌服务
+func NewPersonalAccessTokenService(db *gorm.DB) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{db: db}
+}
+
+func normalizePersonalTokenScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		supported := false
+		for _, known := range models.PersonalTokenScopes {
+			if scope == known {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, bizerr.Newf("personalToken.scopeInvalid", "Unsupported token scope: %s", scope).
+				WithParams(map[string]interface{}{"scope": scope})
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+	if len(normalized) == 0 {
+		return nil, bizerr.New("personalToken.scopeRequired", "Select at least one scope")
+	}
+	return normalized, nil
+}
+
+// Create 创建令牌，返回的明文令牌之后无法再次查看
+func (s *PersonalAccessTokenService) Create(userID uint, input PersonalAccessTokenInput) (*CreatedPersonalAccessToken, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len([]rune(name)) > maxPersonalAccessTokenName {
+		return nil, bizerr.Newf("personalToken.nameInvalid", "Token name is required and cannot exceed %d characters", maxPersonalAccessTokenName).
+			WithParams(map[string]interface{}{"max": maxPersonalAccessTokenName})
+	}
+	scopes, err := normalizePersonalTokenScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if input.ExpiresInDays < 0 || input.ExpiresInDays > maxPersonalAccessTokenDays {
+		return nil, bizerr.Newf("personalToken.expiryInvalid", "Expiry must be between 0 and %d days", maxPersonalAccessTokenDays).
+			WithParams(map[string]interface{}{"max": maxPersonalAccessTokenDays})
+	}
+
+	buf := make([]byte, personalAccessTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	raw := models.PersonalAccessTokenPrefix + hex.EncodeToString(buf)
+	token := models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenPrefix: raw[:personalAccessTokenShownPrefix],
+		TokenHash:   models.HashPersonalAccessToken(raw),
+		Scopes:      scopes,
+	}
+	if input.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, input.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		var active int64
+		if err := tx.Model(&models.PersonalAccessToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active >= maxPersonalAccessTokensPerUser {
+			return bizerr.Newf("personalToken.limitReached", "You can have at most %d active tokens", maxPersonalAccessTokensPerUser).
+				WithParams(map[string]interface{}{"max": maxPersonalAccessTokensPerUser})
+		}
+		return tx.Create(&token).Error
+	}); err != nil {
+		return nil, err
+	}
+	return &CreatedPersonalAccessToken{PersonalAccessToken: token, Token: raw}, nil
+}
+
+// List 用户的全部令牌（含已撤销与已过期），按创建时间倒序
+func (s *PersonalAccessTokenService) List(userID uint) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	err := s.db.Where("user_id = ?", userID).Order("id DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke 撤销令牌，立即失效
+func (s *PersonalAccessTokenService) Revoke(userID, tokenID uint) error {
+	result := s.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPersonalAccessTokenNotFound
+	}
+	return nil
+}
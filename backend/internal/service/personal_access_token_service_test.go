@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"auralogic/internal/models"
+)
+
+func TestPersonalAccessTokenLifecycle(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.PersonalAccessToken{}); err != nil {
+		t.Fatalf("auto migrate personal access tokens failed: %v", err)
+	}
+	svc := NewPersonalAccessTokenService(db)
+
+	_, err := svc.Create(1, PersonalAccessTokenInput{Name: "script"})
+	requireOrderBizErr(t, err, "personalToken.scopeRequired")
+	_, err = svc.Create(1, PersonalAccessTokenInput{Name: "script", Scopes: []string{"orders:write"}})
+	requireOrderBizErr(t, err, "personalToken.scopeInvalid")
+	_, err = svc.Create(1, PersonalAccessTokenInput{Name: "script", Scopes: []string{models.PersonalTokenScopeOrdersRead}, ExpiresInDays: 400})
+	requireOrderBizErr(t, err, "personalToken.expiryInvalid")
+
+	created, err := svc.Create(1, PersonalAccessTokenInput{
+		Name:          "  reseller sync  ",
+		Scopes:        []string{models.PersonalTokenScopeOrdersRead, models.PersonalTokenScopeOrdersRead, models.PersonalTokenScopeVirtualProductsRead},
+		ExpiresInDays: 30,
+	})
+	if err != nil {
+		t.Fatalf("create token failed: %v", err)
+	}
+	if !strings.HasPrefix(created.Token, models.PersonalAccessTokenPrefix) || !strings.HasPrefix(created.Token, created.TokenPrefix) {
+		t.Fatalf("unexpected token format: %q prefix=%q", created.Token, created.TokenPrefix)
+	}
+	if created.Name != "reseller sync" || len(created.Scopes) != 2 || created.ExpiresAt == nil {
+		t.Fatalf("unexpected token: %+v", created.PersonalAccessToken)
+	}
+
+	var stored models.PersonalAccessToken
+	if err := db.First(&stored, created.ID).Error; err != nil {
+		t.Fatalf("reload token failed: %v", err)
+	}
+	if stored.TokenHash != models.HashPersonalAccessToken(created.Token) || strings.Contains(stored.TokenHash, created.Token) {
+		t.Fatalf("expected only the token hash to be stored")
+	}
+
+	if err := svc.Revoke(2, created.ID); err != ErrPersonalAccessTokenNotFound {
+		t.Fatalf("expected other users to be unable to revoke, got %v", err)
+	}
+	if err := svc.Revoke(1, created.ID); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if err := svc.Revoke(1, created.ID); err != ErrPersonalAccessTokenNotFound {
+		t.Fatalf("expected second revoke to report not found, got %v", err)
+	}
+	tokens, err := svc.List(1)
+	if err != nil || len(tokens) != 1 || tokens[0].RevokedAt == nil {
+		t.Fatalf("expected revoked token in list, got %+v err=%v", tokens, err)
+	}
+
+	// 已撤销的令牌不占用数量上限
+	for i := 0; i < maxPersonalAccessTokensPerUser; i++ {
+		if _, err := svc.Create(1, PersonalAccessTokenInput{Name: "bulk", Scopes: []string{models.PersonalTokenScopeOrdersRead}}); err != nil {
+			t.Fatalf("create token %d failed: %v", i, err)
+		}
+	}
+	_, err = svc.Create(1, PersonalAccessTokenInput{Name: "bulk", Scopes: []string{models.PersonalTokenScopeOrdersRead}})
+	requireOrderBizErr(t, err, "personalToken.limitReached")
+}
@@ -216,6 +216,8 @@ var hookDefinitions = map[string]hookDefinition{
 	),
 	"order.auto_cancel.after":      newReadOnlyHookDefinition("order.auto_cancel.after", hookPhaseAfter),
 	"order.auto_cancel.before":     newRestrictedHookDefinition("order.auto_cancel.before", hookPhaseBefore, "admin_remark", "reason"),
+	"order.auto_complete.after":    newReadOnlyHookDefinition("order.auto_complete.after", hookPhaseAfter),
+	"order.auto_complete.before":   newRestrictedHookDefinition("order.auto_complete.before", hookPhaseBefore, "admin_remark"),
 	"order.complete.after":         newReadOnlyHookDefinition("order.complete.after", hookPhaseAfter),
 	"order.complete.before":        newRestrictedHookDefinition("order.complete.before", hookPhaseBefore, "feedback"),
 	"order.create.after":           newReadOnlyHookDefinition("order.create.after", hookPhaseAfter),
@@ -495,6 +495,9 @@ func executePluginHostOrderGet(
 		}
 		return nil, &PluginHostActionError{Status: http.StatusInternalServerError, Message: "query order failed"}
 	}
+	if err := orderRepo.LoadItemAllocations(order); err != nil {
+		return nil, &PluginHostActionError{Status: http.StatusInternalServerError, Message: "query order failed"}
+	}
 
 	hasPrivacyPermission := pluginHostClaimsCanReadOrderPrivacy(claims)
 	cloned := clonePluginHostOrder(order)
@@ -2516,40 +2519,43 @@ func buildPluginHostOrderSummaryResponse(order *models.Order, hasPrivacyPermissi
 		return map[string]interface{}{}
 	}
 	resp := map[string]interface{}{
-		"id":                    order.ID,
-		"order_no":              order.OrderNo,
-		"user_id":               order.UserID,
-		"status":                string(order.Status),
-		"items":                 order.Items,
-		"privacy_protected":     order.PrivacyProtected,
-		"privacy_masked":        order.PrivacyProtected && !hasPrivacyPermission,
-		"tracking_no":           order.TrackingNo,
-		"receiver_name":         order.ReceiverName,
-		"phone_code":            order.PhoneCode,
-		"receiver_phone":        order.ReceiverPhone,
-		"receiver_email":        order.ReceiverEmail,
-		"receiver_country":      order.ReceiverCountry,
-		"receiver_province":     order.ReceiverProvince,
-		"receiver_city":         order.ReceiverCity,
-		"receiver_district":     order.ReceiverDistrict,
-		"receiver_address":      order.ReceiverAddress,
-		"receiver_postcode":     order.ReceiverPostcode,
-		"currency":              order.Currency,
-		"total_amount_minor":    order.TotalAmount,
-		"discount_amount_minor": order.DiscountAmount,
-		"source":                order.Source,
-		"source_platform":       order.SourcePlatform,
-		"external_user_id":      order.ExternalUserID,
-		"external_user_name":    order.ExternalUserName,
-		"external_order_id":     order.ExternalOrderID,
-		"remark":                order.Remark,
-		"admin_remark":          order.AdminRemark,
-		"assigned_to":           order.AssignedTo,
-		"assigned_at":           order.AssignedAt,
-		"shipped_at":            order.ShippedAt,
-		"completed_at":          order.CompletedAt,
-		"created_at":            order.CreatedAt,
-		"updated_at":            order.UpdatedAt,
+		"id":                              order.ID,
+		"order_no":                        order.OrderNo,
+		"user_id":                         order.UserID,
+		"status":                          string(order.Status),
+		"items":                           order.Items,
+		"privacy_protected":               order.PrivacyProtected,
+		"privacy_masked":                  order.PrivacyProtected && !hasPrivacyPermission,
+		"tracking_no":                     order.TrackingNo,
+		"receiver_name":                   order.ReceiverName,
+		"phone_code":                      order.PhoneCode,
+		"receiver_phone":                  order.ReceiverPhone,
+		"receiver_email":                  order.ReceiverEmail,
+		"receiver_country":                order.ReceiverCountry,
+		"receiver_province":               order.ReceiverProvince,
+		"receiver_city":                   order.ReceiverCity,
+		"receiver_district":               order.ReceiverDistrict,
+		"receiver_address":                order.ReceiverAddress,
+		"receiver_postcode":               order.ReceiverPostcode,
+		"currency":                        order.Currency,
+		"total_amount_minor":              order.TotalAmount,
+		"discount_amount_minor":           order.DiscountAmount,
+		"payment_fee_minor":               order.PaymentFee,
+		"price_rounding_rule":             order.PriceRoundingRule,
+		"price_rounding_adjustment_minor": order.PriceRoundingAdjustment,
+		"source":                          order.Source,
+		"source_platform":                 order.SourcePlatform,
+		"external_user_id":                order.ExternalUserID,
+		"external_user_name":              order.ExternalUserName,
+		"external_order_id":               order.ExternalOrderID,
+		"remark":                          order.Remark,
+		"admin_remark":                    order.AdminRemark,
+		"assigned_to":                     order.AssignedTo,
+		"assigned_at":                     order.AssignedAt,
+		"shipped_at":                      order.ShippedAt,
+		"completed_at":                    order.CompletedAt,
+		"created_at":                      order.CreatedAt,
+		"updated_at":                      order.UpdatedAt,
 	}
 	if order.User != nil {
 		resp["user"] = buildPluginHostEmbeddedUserResponse(order.User)
@@ -2562,7 +2568,12 @@ func buildPluginHostOrderDetailResponse(order *models.Order, hasPrivacyPermissio
 	if order == nil {
 		return resp
 	}
-	resp["actual_attributes"] = order.ActualAttributes
+	// actual_attributes 保持按订单项下标索引的旧格式，兼容已有插件
+	actualAttributes := make(map[string]map[string]interface{}, len(order.ItemAllocations))
+	for _, allocation := range order.ItemAllocations {
+		actualAttributes[strconv.Itoa(allocation.ItemIndex)] = allocation.Attributes
+	}
+	resp["actual_attributes"] = actualAttributes
 	resp["form_submitted_at"] = order.FormSubmittedAt
 	resp["form_expires_at"] = order.FormExpiresAt
 	resp["user_email"] = order.UserEmail
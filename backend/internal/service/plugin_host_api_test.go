@@ -26,7 +26,7 @@ func TestBuildPluginHostAccessClaimsPrefersExplicitOperatorUserID(t *testing.T)
 
 func TestExecutePluginHostActionMasksPrivacyProtectedOrder(t *testing.T) {
 	db := openPluginManagerE2ETestDB(t)
-	if err := db.AutoMigrate(&models.User{}, &models.AdminPermission{}, &models.Order{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.AdminPermission{}, &models.Order{}, &models.OrderItemAllocation{}); err != nil {
 		t.Fatalf("auto migrate host api models failed: %v", err)
 	}
 
@@ -148,7 +148,7 @@ func TestExecutePluginHostActionAllowsWorkspaceAppendWithoutOperatorScope(t *tes
 
 func TestExecutePluginHostActionAllowsPrivacyReadWithDoublePermission(t *testing.T) {
 	db := openPluginManagerE2ETestDB(t)
-	if err := db.AutoMigrate(&models.User{}, &models.AdminPermission{}, &models.Order{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.AdminPermission{}, &models.Order{}, &models.OrderItemAllocation{}); err != nil {
 		t.Fatalf("auto migrate host api models failed: %v", err)
 	}
 
@@ -32,7 +32,7 @@ func executePluginHostInvoiceTemplateSave(runtime *PluginHostRuntime, params map
 	if strings.TrimSpace(content) == "" {
 		return nil, &PluginHostActionError{Status: http.StatusBadRequest, Message: "content/html_content/htmlContent/custom_template/customTemplate is required"}
 	}
-	if _, err := template.New("invoice-template-validate").Parse(content); err != nil {
+	if _, err := ParseDocumentTemplate("invoice-template-validate", content, false); err != nil {
 		return nil, &PluginHostActionError{Status: http.StatusBadRequest, Message: fmt.Sprintf("invalid invoice template syntax: %v", err)}
 	}
 
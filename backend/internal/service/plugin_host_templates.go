@@ -111,7 +111,7 @@ func executePluginHostEmailTemplateSave(params map[string]interface{}) (map[stri
 	if strings.TrimSpace(content) == "" {
 		return nil, &PluginHostActionError{Status: http.StatusBadRequest, Message: "content/html_content/htmlContent is required"}
 	}
-	if _, err := template.New("email-template-validate").Parse(content); err != nil {
+	if _, err := template.New("email-template-validate").Funcs(TemplateAssetFuncs(false)).Parse(content); err != nil {
 		return nil, &PluginHostActionError{Status: http.StatusBadRequest, Message: fmt.Sprintf("invalid email template syntax: %v", err)}
 	}
 
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	maxPolicyVersionLength = 50
+	maxPolicyTitleLength   = 255
+	maxPolicySummaryLength = 1000
+	maxConsentUserAgentLen = 500
+)
+
+var ErrPolicyConsentUserNotFound = errors.New("user not found")
+
+// policyTypes 需要用户同意的政策类型，按展示顺序排列
+var policyTypes = []string{models.PolicyTypeTerms, models.PolicyTypePrivacy}
+
+// PublishPolicyInput 管理员发布的政策版本
+type PublishPolicyInput struct {
+	Type    string
+	Version string
+	Title   string
+	Content string
+	Summary string
+}
+
+// AcceptPoliciesInput 用户同意的政策版本及请求来源
+type AcceptPoliciesInput struct {
+	PolicyVersionIDs []uint
+	IPAddress        string
+	UserAgent        string
+}
+
+// PolicyConsentService 服务条款/隐私政策版本与用户同意记录：
+// 发布新版本后，用户需重新同意才能下单
+type PolicyConsentService struct {
+	db *gorm.DB
+}
+
+// NewPolicyConsentService 创建政策同意服务
+func NewPolicyConsentService(db *gorm.DB) *PolicyConsentService {
+	return &PolicyConsentService{db: db}
+}
+
+// currentPolicyVersionsTx 每种政策类型最新发布的版本，未发布的类型不返回
+func currentPolicyVersionsTx(tx *gorm.DB) ([]models.PolicyVersion, error) {
+	current := make([]models.PolicyVersion, 0, len(policyTypes))
+	for _, policyType := range policyTypes {
+		var version models.PolicyVersion
+		err := tx.Where("type = ?", policyType).Order("id DESC").Limit(1).Find(&version).Error
+		if err != nil {
+			return nil, err
+		}
+		if version.ID != 0 {
+			current = append(current, version)
+		}
+	}
+	return current, nil
+}
+
+// pendingPolicyVersionsTx 用户尚未同意的当前版本
+func pendingPolicyVersionsTx(tx *gorm.DB, userID uint) ([]models.PolicyVersion, error) {
+	current, err := currentPolicyVersionsTx(tx)
+	if err != nil || len(current) == 0 {
+		return current, err
+	}
+	ids := make([]uint, 0, len(current))
+	for _, version := range current {
+		ids = append(ids, version.ID)
+	}
+	var accepted []uint
+	if err := tx.Model(&models.PolicyConsent{}).
+		Where("user_id = ? AND policy_version_id IN ?", userID, ids).
+		Pluck("policy_version_id", &accepted).Error; err != nil {
+		return nil, err
+	}
+	acceptedSet := make(map[uint]bool, len(accepted))
+	for _, id := range accepted {
+		acceptedSet[id] = true
+	}
+	pending := make([]models.PolicyVersion, 0, len(current))
+	for _, version := range current {
+		if !acceptedSet[version.ID] {
+			pending = append(pending, version)
+		}
+	}
+	return pending, nil
+}
+
+// requirePolicyConsentTx 下单前校验用户已同意当前所有政策版本，未发布任何政策时不做限制
+func requirePolicyConsentTx(tx *gorm.DB, userID uint) error {
+	pending, err := pendingPolicyVersionsTx(tx, userID)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	titles := make([]string, 0, len(pending))
+	for _, version := range pending {
+		titles = append(titles, version.Title)
+	}
+	return bizerr.New("consent.required", "Please accept the latest policies before placing an order").
+		WithParams(map[string]interface{}{"policies": strings.Join(titles, ", ")})
+}
+
+// Pending 用户需要（重新）同意的政策版本
+func (s *PolicyConsentService) Pending(userID uint) ([]models.PolicyVersion, error) {
+	return pendingPolicyVersionsTx(s.db, userID)
+}
+
+// Current 当前生效的政策版本
+func (s *PolicyConsentService) Current() ([]models.PolicyVersion, error) {
+	return currentPolicyVersionsTx(s.db)
+}
+
+// Accept 记录用户同意的政策版本。只能同意当前版本，重复同意同一版本不会新增记录；
+// 返回仍需同意的版本
+func (s *PolicyConsentService) Accept(userID uint, input AcceptPoliciesInput) ([]models.PolicyVersion, error) {
+	if len(input.PolicyVersionIDs) == 0 {
+		return nil, bizerr.New("consent.policiesRequired", "Select the policies to accept")
+	}
+	userAgent := input.UserAgent
+	if runes := []rune(userAgent); len(runes) > maxConsentUserAgentLen {
+		userAgent = string(runes[:maxConsentUserAgentLen])
+	}
+
+	var pending []models.PolicyVersion
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Select("id").First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPolicyConsentUserNotFound
+			}
+			return err
+		}
+		current, err := currentPolicyVersionsTx(tx)
+		if err != nil {
+			return err
+		}
+		currentByID := make(map[uint]models.PolicyVersion, len(current))
+		for _, version := range current {
+			currentByID[version.ID] = version
+		}
+		now := models.NowFunc()
+		for _, id := range input.PolicyVersionIDs {
+			version, ok := currentByID[id]
+			if !ok {
+				return bizerr.New("consent.versionOutdated", "This policy version is no longer current, please review the latest version")
+			}
+			var count int64
+			if err := tx.Model(&models.PolicyConsent{}).
+				Where("user_id = ? AND policy_version_id = ?", userID, id).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			if err := tx.Create(&models.PolicyConsent{
+				UserID:          userID,
+				PolicyVersionID: version.ID,
+				PolicyType:      version.Type,
+				Version:         version.Version,
+				IPAddress:       input.IPAddress,
+				UserAgent:       userAgent,
+				AcceptedAt:      now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		pending, err = pendingPolicyVersionsTx(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// History 用户的全部同意记录，最新的在前
+func (s *PolicyConsentService) History(userID uint) ([]models.PolicyConsent, error) {
+	var consents []models.PolicyConsent
+	if err := s.db.Where("user_id = ?", userID).Order("accepted_at DESC, id DESC").Find(&consents).Error; err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+// AdminHistory 管理员查询用户的同意记录，用于处理合规请求
+func (s *PolicyConsentService) AdminHistory(userID uint) ([]models.PolicyConsent, error) {
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrPolicyConsentUserNotFound
+	}
+	return s.History(userID)
+}
+
+// ListVersions 已发布的政策版本，可按类型筛选，最新的在前
+func (s *PolicyConsentService) ListVersions(policyType string) ([]models.PolicyVersion, error) {
+	query := s.db.Model(&models.PolicyVersion{})
+	if policyType != "" {
+		query = query.Where("type = ?", policyType)
+	}
+	var versions []models.PolicyVersion
+	if err := query.Order("id DESC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func normalizePublishPolicyInput(input PublishPolicyInput) (PublishPolicyInput, error) {
+	input.Type = strings.TrimSpace(input.Type)
+	input.Version = strings.TrimSpace(input.Version)
+	input.Title = strings.TrimSpace(input.Title)
+	input.Content = strings.TrimSpace(input.Content)
+	input.Summary = strings.TrimSpace(input.Summary)
+
+	validType := false
+	for _, policyType := range policyTypes {
+		if input.Type == policyType {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return input, bizerr.New("consent.policyTypeInvalid", "Invalid policy type").
+			WithParams(map[string]interface{}{"types": strings.Join(policyTypes, ", ")})
+	}
+	if input.Version == "" {
+		return input, bizerr.New("consent.versionRequired", "Version is required")
+	}
+	if len([]rune(input.Version)) > maxPolicyVersionLength {
+		return input, bizerr.Newf("consent.versionTooLong", "Version cannot exceed %d characters", maxPolicyVersionLength).
+			WithParams(map[string]interface{}{"max": maxPolicyVersionLength})
+	}
+	if input.Title == "" {
+		return input, bizerr.New("consent.titleRequired", "Title is required")
+	}
+	if len([]rune(input.Title)) > maxPolicyTitleLength {
+		return input, bizerr.Newf("consent.titleTooLong", "Title cannot exceed %d characters", maxPolicyTitleLength).
+			WithParams(map[string]interface{}{"max": maxPolicyTitleLength})
+	}
+	if input.Content == "" {
+		return input, bizerr.New("consent.contentRequired", "Policy content is required")
+	}
+	if len([]rune(input.Summary)) > maxPolicySummaryLength {
+		return input, bizerr.Newf("consent.summaryTooLong", "Summary cannot exceed %d characters", maxPolicySummaryLength).
+			WithParams(map[string]interface{}{"max": maxPolicySummaryLength})
+	}
+	return input, nil
+}
+
+// Publish 发布新的政策版本，发布后所有用户需重新同意该类型政策
+func (s *PolicyConsentService) Publish(adminID uint, input PublishPolicyInput) (*models.PolicyVersion, error) {
+	input, err := normalizePublishPolicyInput(input)
+	if err != nil {
+		return nil, err
+	}
+	var count int64
+	if err := s.db.Model(&models.PolicyVersion{}).
+		Where("type = ? AND version = ?", input.Type, input.Version).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, bizerr.New("consent.versionExists", "This version has already been published").
+			WithParams(map[string]interface{}{"version": input.Version})
+	}
+	version := &models.PolicyVersion{
+		Type:        input.Type,
+		Version:     input.Version,
+		Title:       input.Title,
+		Content:     input.Content,
+		Summary:     input.Summary,
+		PublishedBy: &adminID,
+	}
+	if err := s.db.Create(version).Error; err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// AcceptanceCounts 各版本的同意人数，便于管理员了解重新同意进度
+func (s *PolicyConsentService) AcceptanceCounts(versions []models.PolicyVersion) (map[uint]int64, error) {
+	stats := make(map[uint]int64, len(versions))
+	if len(versions) == 0 {
+		return stats, nil
+	}
+	ids := make([]uint, 0, len(versions))
+	for _, version := range versions {
+		ids = append(ids, version.ID)
+	}
+	var rows []struct {
+		PolicyVersionID uint
+		Total           int64
+	}
+	if err := s.db.Model(&models.PolicyConsent{}).
+		Select("policy_version_id, COUNT(*) AS total").
+		Where("policy_version_id IN ?", ids).
+		Group("policy_version_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		stats[row.PolicyVersionID] = row.Total
+	}
+	return stats, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/models"
+)
+
+func TestPolicyConsentRequiredAfterPolicyUpdate(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	svc := NewPolicyConsentService(db)
+	user := &models.User{UUID: "consent-user", Email: "consent@example.com", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// 未发布任何政策时不限制下单
+	if err := requirePolicyConsentTx(db, user.ID); err != nil {
+		t.Fatalf("expected checkout without published policies, got %v", err)
+	}
+
+	_, err := svc.Publish(1, PublishPolicyInput{Type: "cookies", Version: "1", Title: "Cookies", Content: "..."})
+	requireOrderBizErr(t, err, "consent.policyTypeInvalid")
+	terms, err := svc.Publish(1, PublishPolicyInput{Type: models.PolicyTypeTerms, Version: " 2026-01 ", Title: "Terms of Service", Content: "# Terms"})
+	if err != nil || terms.Version != "2026-01" {
+		t.Fatalf("publish terms: %+v err=%v", terms, err)
+	}
+	_, err = svc.Publish(1, PublishPolicyInput{Type: models.PolicyTypeTerms, Version: "2026-01", Title: "Terms", Content: "# Terms"})
+	requireOrderBizErr(t, err, "consent.versionExists")
+	privacy, err := svc.Publish(1, PublishPolicyInput{Type: models.PolicyTypePrivacy, Version: "1.0", Title: "Privacy Policy", Content: "# Privacy"})
+	if err != nil {
+		t.Fatalf("publish privacy: %v", err)
+	}
+
+	bizErr := requireOrderBizErr(t, requirePolicyConsentTx(db, user.ID), "consent.required")
+	if bizErr.Params["policies"] != "Terms of Service, Privacy Policy" {
+		t.Fatalf("unexpected params: %v", bizErr.Params)
+	}
+
+	// 重复同意同一版本只保留一条记录
+	pending, err := svc.Accept(user.ID, AcceptPoliciesInput{PolicyVersionIDs: []uint{terms.ID, terms.ID}, IPAddress: "203.0.113.7", UserAgent: "test"})
+	if err != nil || len(pending) != 1 || pending[0].ID != privacy.ID {
+		t.Fatalf("expected only privacy pending, got %+v err=%v", pending, err)
+	}
+	requireOrderBizErr(t, requirePolicyConsentTx(db, user.ID), "consent.required")
+	if pending, err = svc.Accept(user.ID, AcceptPoliciesInput{PolicyVersionIDs: []uint{privacy.ID}}); err != nil || len(pending) != 0 {
+		t.Fatalf("expected nothing pending, got %+v err=%v", pending, err)
+	}
+	if err := requirePolicyConsentTx(db, user.ID); err != nil {
+		t.Fatalf("expected checkout after consent, got %v", err)
+	}
+
+	// 发布新版本后需重新同意，旧版本不能再被同意
+	updated, err := svc.Publish(1, PublishPolicyInput{Type: models.PolicyTypeTerms, Version: "2026-06", Title: "Terms of Service", Content: "# Terms v2"})
+	if err != nil {
+		t.Fatalf("publish updated terms: %v", err)
+	}
+	bizErr = requireOrderBizErr(t, requirePolicyConsentTx(db, user.ID), "consent.required")
+	if bizErr.Params["policies"] != "Terms of Service" {
+		t.Fatalf("unexpected params: %v", bizErr.Params)
+	}
+	_, err = svc.Accept(user.ID, AcceptPoliciesInput{PolicyVersionIDs: []uint{terms.ID}})
+	requireOrderBizErr(t, err, "consent.versionOutdated")
+	if _, err := svc.Accept(user.ID, AcceptPoliciesInput{PolicyVersionIDs: []uint{updated.ID}}); err != nil {
+		t.Fatalf("accept updated terms: %v", err)
+	}
+
+	history, err := svc.AdminHistory(user.ID)
+	if err != nil || len(history) != 3 {
+		t.Fatalf("expected 3 consent records, got %d err=%v", len(history), err)
+	}
+	if history[0].PolicyVersionID != updated.ID || history[len(history)-1].IPAddress != "203.0.113.7" {
+		t.Fatalf("unexpected history order: %+v", history)
+	}
+	counts, err := svc.AcceptanceCounts([]models.PolicyVersion{*terms, *updated})
+	if err != nil || counts[terms.ID] != 1 || counts[updated.ID] != 1 {
+		t.Fatalf("unexpected acceptance counts: %v err=%v", counts, err)
+	}
+	if _, err := svc.AdminHistory(user.ID + 100); err != ErrPolicyConsentUserNotFound {
+		t.Fatalf("expected missing user error, got %v", err)
+	}
+}
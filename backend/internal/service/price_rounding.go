@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/money"
+)
+
+// resolvePriceRoundingRule 返回币种的取整规则；未配置时零小数币种（JPY/KRW 等）仍取整到整数单位
+func resolvePriceRoundingRule(cfg *config.Config, currency string) money.RoundingRule {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	var rule money.RoundingRule
+	if cfg != nil {
+		for code, configured := range cfg.Order.PriceRounding {
+			if strings.ToUpper(strings.TrimSpace(code)) != currency {
+				continue
+			}
+			rule = money.RoundingRule{Mode: configured.Mode, Increment: configured.Increment, Ending: configured.Ending}
+			break
+		}
+	}
+	return rule.Normalize(currency)
+}
+
+// applyOrderPriceRounding 按订单币种的规则对系统计算的应付金额取整，
+// 所用规则与取整差额记录在订单上供审计，差额已计入 TotalAmount
+func applyOrderPriceRounding(cfg *config.Config, order *models.Order) {
+	rule := resolvePriceRoundingRule(cfg, order.Currency)
+	if rule.Mode == money.RoundingNone {
+		return
+	}
+	rounded := rule.Round(order.TotalAmount)
+	order.PriceRoundingRule = rule.String()
+	order.PriceRoundingAdjustment = rounded - order.TotalAmount
+	order.TotalAmount = rounded
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestPriceRoundingRules(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Order.PriceRounding = map[string]config.PriceRoundingRule{
+		"usd": {Mode: "charm", Increment: 100, Ending: 1},
+		"CHF": {Mode: "nearest", Increment: 5},
+		"EUR": {Mode: "up", Increment: 50},
+		"KRW": {Mode: "down", Increment: 50},
+	}
+
+	cases := []struct {
+		currency string
+		amount   int64
+		expected int64
+		rule     string
+	}{
+		{"USD", 1234, 1199, "charm/100-1"},
+		{"USD", 1260, 1299, "charm/100-1"},
+		{"USD", 30, 99, "charm/100-1"},
+		{"CHF", 1233, 1235, "nearest/5"},
+		{"CHF", 1232, 1230, "nearest/5"},
+		{"EUR", 1201, 1250, "up/50"},
+		// 零小数币种按整数单位取整：未配置时四舍五入，配置的步长不足一个单位时提升为一个单位
+		{"JPY", 123456, 123500, "nearest/100"},
+		{"JPY", 123449, 123400, "nearest/100"},
+		{"KRW", 987654, 987600, "down/100"},
+		{"CNY", 1234, 1234, "none"},
+	}
+	for _, tc := range cases {
+		rule := resolvePriceRoundingRule(cfg, tc.currency)
+		if got := rule.Round(tc.amount); got != tc.expected || rule.String() != tc.rule {
+			t.Fatalf("%s %d: expected %d with %s, got %d with %s", tc.currency, tc.amount, tc.expected, tc.rule, got, rule.String())
+		}
+	}
+}
+
+func TestCreateDraftRecordsPriceRounding(t *testing.T) {
+	svc, db := newOrderServiceTestDB(t)
+	svc.cfg.Order.Currency = "JPY"
+	svc.cfg.Order.PriceRounding = map[string]config.PriceRoundingRule{"JPY": {Mode: "nearest", Increment: 1000}}
+
+	product := &models.Product{SKU: "JP-1", Name: "Matcha", Price: 123456, Status: models.ProductStatusActive, ProductType: models.ProductTypePhysical}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product failed: %v", err)
+	}
+
+	order, err := svc.CreateDraft([]models.OrderItem{{SKU: "JP-1", Name: "Matcha", Quantity: 1}}, "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("create draft failed: %v", err)
+	}
+	if order.TotalAmount != 123000 || order.PriceRoundingAdjustment != -456 || order.PriceRoundingRule != "nearest/1000" {
+		t.Fatalf("unexpected rounding: total=%d adjustment=%d rule=%q", order.TotalAmount, order.PriceRoundingAdjustment, order.PriceRoundingRule)
+	}
+
+	var stored models.Order
+	if err := db.First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if stored.PriceRoundingRule != "nearest/1000" || stored.PriceRoundingAdjustment != -456 {
+		t.Fatalf("expected rounding to be persisted, got %+v", stored)
+	}
+
+	// 零小数币种的付款手续费同样取整到整数单位
+	fee := ApplyPaymentMethodFee(&stored, &models.PaymentMethod{FeeRate: 333})
+	if fee != 4100 || stored.TotalAmount != 127100 {
+		t.Fatalf("expected whole-yen fee, got fee=%d total=%d", fee, stored.TotalAmount)
+	}
+}
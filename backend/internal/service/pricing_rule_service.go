@@ -0,0 +1,504 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/money"
+	"gorm.io/gorm"
+)
+
+const (
+	pricingRuleCacheTTL      = 30 * time.Second
+	maxPricingRuleNameLength = 100
+	maxPricingRuleScopeItems = 200
+)
+
+var (
+	errPricingRuleNotFound      = bizerr.Register("pricing.ruleNotFound", 404, "Pricing rule not found")
+	errPricingRuleNameRequired  = bizerr.Register("pricing.nameRequired", 400, "Pricing rule name is required")
+	errPricingRuleTypeInvalid   = bizerr.Register("pricing.typeInvalid", 400, "Pricing rule type must be percentage, fixed or threshold")
+	errPricingRuleValueInvalid  = bizerr.Register("pricing.valueInvalid", 400, "Invalid discount value")
+	errPricingRuleThreshold     = bizerr.Register("pricing.thresholdRequired", 400, "Threshold rules need a minimum amount")
+	errPricingRuleAmountInvalid = bizerr.Register("pricing.amountInvalid", 400, "Amounts cannot be negative")
+	errPricingRuleScopeInvalid  = bizerr.Register("pricing.scopeInvalid", 400, "Pricing rule scope must be all, sku or category")
+	errPricingRuleScopeEmpty    = bizerr.Register("pricing.scopeEmpty", 400, "Choose at least one SKU or category for the rule")
+	errPricingRuleValidity      = bizerr.Register("pricing.validityInvalid", 400, "Start time must be before expiry time")
+	errPromoCodeNotCombinable   = bizerr.Register("promo_code.notCombinable", 400, "Promo code cannot be combined with the current promotion")
+	errPricingItemsRequired     = bizerr.Register("pricing.itemsRequired", 400, "Add at least one item to simulate")
+	errPricingProductNotFound   = bizerr.Register("pricing.productNotFound", 404, "Product not found")
+)
+
+// PricingRuleInput 创建或更新定价规则的设置，金额均为最小货币单位
+type PricingRuleInput struct {
+	Name        string
+	Description string
+	Type        models.PricingRuleType
+	Value       int64
+	MaxDiscount int64
+	MinAmount   int64
+	Scope       models.PricingRuleScope
+	SKUs        []string
+	Categories  []string
+	Priority    int
+	Exclusive   bool
+	Enabled     bool
+	StartsAt    *time.Time
+	ExpiresAt   *time.Time
+}
+
+// PricingLine 参与定价计算的一行订单商品，Amount 为原价小计
+type PricingLine struct {
+	SKU      string
+	Category string
+	Amount   int64
+}
+
+// PricingEvaluation 定价规则与优惠码的计算结果
+type PricingEvaluation struct {
+	Subtotal       int64                      `json:"subtotal_minor"`
+	RuleDiscount   int64                      `json:"rule_discount_minor"`
+	PromoDiscount  int64                      `json:"promo_discount_minor"`
+	Trace          []models.PricingTraceEntry `json:"trace"`
+	PromoBlockedBy string                     `json:"promo_blocked_by,omitempty"` // 阻止优惠码叠加的独占规则名称
+}
+
+// Discount 优惠总额
+func (e *PricingEvaluation) Discount() int64 {
+	return e.RuleDiscount + e.PromoDiscount
+}
+
+// PricingRuleService 管理下单时自动应用的定价规则（折扣、立减、满减），并按优先级叠加计算优惠
+type PricingRuleService struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	rules    []models.PricingRule
+	loadedAt time.Time
+}
+
+// NewPricingRuleService 创建定价规则服务
+func NewPricingRuleService(db *gorm.DB) *PricingRuleService {
+	return &PricingRuleService{db: db}
+}
+
+func (s *PricingRuleService) invalidateRules() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *PricingRuleService) enabledRules() ([]models.PricingRule, error) {
+	now := time.Now()
+	s.mu.RLock()
+	if !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < pricingRuleCacheTTL {
+		rules := s.rules
+		s.mu.RUnlock()
+		return rules, nil
+	}
+	s.mu.RUnlock()
+
+	var rules []models.PricingRule
+	if err := s.db.Where("enabled = ?", true).Order("priority DESC, id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.rules = rules
+	s.loadedAt = now
+	s.mu.Unlock()
+	return rules, nil
+}
+
+// List 全部定价规则，按计算顺序排列
+func (s *PricingRuleService) List() ([]models.PricingRule, error) {
+	var rules []models.PricingRule
+	err := s.db.Order("priority DESC, id ASC").Find(&rules).Error
+	return rules, err
+}
+
+// Get 获取定价规则
+func (s *PricingRuleService) Get(id uint) (*models.PricingRule, error) {
+	var rule models.PricingRule
+	if err := s.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errPricingRuleNotFound.New()
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create 新建定价规则
+func (s *PricingRuleService) Create(input PricingRuleInput) (*models.PricingRule, error) {
+	if err := normalizePricingRuleInput(&input); err != nil {
+		return nil, err
+	}
+	rule := &models.PricingRule{}
+	applyPricingRuleInput(rule, input)
+	if err := s.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// Update 更新定价规则
+func (s *PricingRuleService) Update(id uint, input PricingRuleInput) (*models.PricingRule, error) {
+	rule, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizePricingRuleInput(&input); err != nil {
+		return nil, err
+	}
+	applyPricingRuleInput(rule, input)
+	if err := s.db.Save(rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// Delete 删除定价规则，已下单订单上的评估记录不受影响
+func (s *PricingRuleService) Delete(id uint) (*models.PricingRule, error) {
+	rule, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Delete(rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// Evaluate 按当前启用的规则计算订单优惠。服务为空时只计算优惠码
+func (s *PricingRuleService) Evaluate(lines []PricingLine, promo *models.PromoCode, now time.Time) (*PricingEvaluation, error) {
+	var rules []models.PricingRule
+	if s != nil {
+		var err error
+		if rules, err = s.enabledRules(); err != nil {
+			return nil, err
+		}
+	}
+	return EvaluatePricing(rules, lines, promo, now), nil
+}
+
+// Simulate 用商品 SKU 与数量模拟下单时的优惠计算，便于后台核对规则叠加效果。优惠码只参与计算，不校验可用性
+func (s *PricingRuleService) Simulate(quantities map[string]int, promoCode string) (*PricingEvaluation, error) {
+	if len(quantities) == 0 {
+		return nil, errPricingItemsRequired.New()
+	}
+	skus := make([]string, 0, len(quantities))
+	for sku := range quantities {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	var products []models.Product
+	if err := s.db.Where("sku IN ?", skus).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	productBySKU := make(map[string]*models.Product, len(products))
+	for i := range products {
+		productBySKU[products[i].SKU] = &products[i]
+	}
+	lines := make([]PricingLine, 0, len(skus))
+	for _, sku := range skus {
+		product := productBySKU[sku]
+		if product == nil {
+			return nil, errPricingProductNotFound.New().WithParams(map[string]interface{}{"sku": sku})
+		}
+		lines = append(lines, PricingLine{
+			SKU:      sku,
+			Category: product.Category,
+			Amount:   product.Price * int64(quantities[sku]),
+		})
+	}
+
+	var promo *models.PromoCode
+	if code := strings.ToUpper(strings.TrimSpace(promoCode)); code != "" {
+		var pc models.PromoCode
+		if err := s.db.Where("code = ?", code).First(&pc).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, bizerr.New("promo_code.notFound", "Promo code not found")
+			}
+			return nil, err
+		}
+		promo = &pc
+	}
+	return s.Evaluate(lines, promo, models.NowFunc())
+}
+
+// pricingLinesForItems 按订单商品生成定价计算行，单价取商品当前售价
+func pricingLinesForItems(items []models.OrderItem, productBySKU map[string]*models.Product) []PricingLine {
+	lines := make([]PricingLine, 0, len(items))
+	for _, item := range items {
+		product := productBySKU[item.SKU]
+		if product == nil {
+			continue
+		}
+		lines = append(lines, PricingLine{
+			SKU:      item.SKU,
+			Category: product.Category,
+			Amount:   product.Price * int64(item.Quantity),
+		})
+	}
+	return lines
+}
+
+// EvaluatePricing 按优先级从高到低依次应用规则，最后应用优惠码。
+// 每条规则以适用商品扣除前序优惠后的剩余金额为基数，优惠按剩余金额比例分摊到各商品，后续规则不会重复减免同一部分；
+// 满减等金额门槛按适用商品的原价小计判断。独占规则生效后，优先级更低的规则不再应用，优惠码也不能叠加
+func EvaluatePricing(rules []models.PricingRule, lines []PricingLine, promo *models.PromoCode, now time.Time) *PricingEvaluation {
+	ordered := make([]models.PricingRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled && rule.ActiveAt(now) {
+			ordered = append(ordered, rule)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	result := &PricingEvaluation{}
+	remaining := make([]int64, len(lines))
+	for i, line := range lines {
+		remaining[i] = line.Amount
+		result.Subtotal += line.Amount
+	}
+
+	exclusive := ""
+	for i := range ordered {
+		rule := &ordered[i]
+		ruleID := rule.ID
+		entry := models.PricingTraceEntry{
+			Source:   models.PricingTraceSourceRule,
+			RuleID:   &ruleID,
+			Name:     rule.Name,
+			Type:     string(rule.Type),
+			Priority: rule.Priority,
+		}
+		if exclusive != "" {
+			entry.Reason = models.PricingSkipExclusive
+			entry.BlockedBy = exclusive
+			result.Trace = append(result.Trace, entry)
+			continue
+		}
+
+		var matched []int
+		var scopeSubtotal int64
+		for idx, line := range lines {
+			if rule.Matches(line.SKU, line.Category) {
+				matched = append(matched, idx)
+				scopeSubtotal += line.Amount
+				entry.BaseAmountMinor += remaining[idx]
+				entry.SKUs = appendUniqueString(entry.SKUs, line.SKU)
+			}
+		}
+		switch {
+		case len(matched) == 0:
+			entry.Reason = models.PricingSkipNoMatchingItems
+		case rule.MinAmount > 0 && scopeSubtotal < rule.MinAmount:
+			entry.Reason = models.PricingSkipMinAmountNotMet
+		case entry.BaseAmountMinor <= 0:
+			entry.Reason = models.PricingSkipNothingLeft
+		default:
+			entry.DiscountMinor = pricingRuleDiscount(rule, entry.BaseAmountMinor)
+			allocatePricingDiscount(remaining, matched, entry.BaseAmountMinor, entry.DiscountMinor)
+			entry.Applied = entry.DiscountMinor > 0
+			result.RuleDiscount += entry.DiscountMinor
+			if entry.Applied && rule.Exclusive {
+				exclusive = rule.Name
+			}
+		}
+		result.Trace = append(result.Trace, entry)
+	}
+
+	if promo != nil {
+		entry := models.PricingTraceEntry{
+			Source: models.PricingTraceSourcePromoCode,
+			Name:   promo.Code,
+			Type:   string(promo.DiscountType),
+		}
+		for _, amount := range remaining {
+			entry.BaseAmountMinor += amount
+		}
+		switch {
+		case exclusive != "":
+			entry.Reason = models.PricingSkipExclusive
+			entry.BlockedBy = exclusive
+			result.PromoBlockedBy = exclusive
+		case result.Subtotal < promo.MinOrderAmount:
+			entry.Reason = models.PricingSkipMinAmountNotMet
+		case entry.BaseAmountMinor <= 0:
+			entry.Reason = models.PricingSkipNothingLeft
+		default:
+			// 最低消费已按原价小计判断，优惠按规则减免后的剩余金额计算
+			discounted := *promo
+			discounted.MinOrderAmount = 0
+			entry.DiscountMinor = discounted.CalculateDiscount(entry.BaseAmountMinor)
+			entry.Applied = entry.DiscountMinor > 0
+			result.PromoDiscount = entry.DiscountMinor
+		}
+		result.Trace = append(result.Trace, entry)
+	}
+	return result
+}
+
+// pricingRuleDiscount 规则在基数上的优惠金额，不超过基数
+func pricingRuleDiscount(rule *models.PricingRule, base int64) int64 {
+	var discount int64
+	switch rule.Type {
+	case models.PricingRuleTypePercentage:
+		discount = money.ApplyPercentage(base, rule.Value)
+		if rule.MaxDiscount > 0 && discount > rule.MaxDiscount {
+			discount = rule.MaxDiscount
+		}
+	case models.PricingRuleTypeFixed, models.PricingRuleTypeThreshold:
+		discount = rule.Value
+	}
+	if discount > base {
+		discount = base
+	}
+	if discount < 0 {
+		discount = 0
+	}
+	return discount
+}
+
+// allocatePricingDiscount 按剩余金额比例把优惠分摊到适用商品，尾差计入最后一个商品
+func allocatePricingDiscount(remaining []int64, matched []int, base, discount int64) {
+	if discount <= 0 || base <= 0 {
+		return
+	}
+	left := discount
+	for n, idx := range matched {
+		share := remaining[idx] * discount / base
+		if n == len(matched)-1 || share > left {
+			share = left
+		}
+		if share > remaining[idx] {
+			share = remaining[idx]
+		}
+		remaining[idx] -= share
+		left -= share
+	}
+	// 最后一个商品不足以承担尾差时，由其他仍有余额的商品补足
+	for _, idx := range matched {
+		if left <= 0 {
+			break
+		}
+		share := left
+		if share > remaining[idx] {
+			share = remaining[idx]
+		}
+		remaining[idx] -= share
+		left -= share
+	}
+}
+
+func appendUniqueString(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func normalizePricingRuleInput(input *PricingRuleInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Name == "" || len([]rune(input.Name)) > maxPricingRuleNameLength {
+		return errPricingRuleNameRequired.New()
+	}
+	if input.Value < 0 || input.MaxDiscount < 0 || input.MinAmount < 0 {
+		return errPricingRuleAmountInvalid.New()
+	}
+	switch input.Type {
+	case models.PricingRuleTypePercentage:
+		if input.Value <= 0 || input.Value > money.PercentageScale {
+			return errPricingRuleValueInvalid.New()
+		}
+	case models.PricingRuleTypeFixed:
+		if input.Value <= 0 {
+			return errPricingRuleValueInvalid.New()
+		}
+		input.MaxDiscount = 0
+	case models.PricingRuleTypeThreshold:
+		if input.Value <= 0 {
+			return errPricingRuleValueInvalid.New()
+		}
+		if input.MinAmount <= 0 {
+			return errPricingRuleThreshold.New()
+		}
+		input.MaxDiscount = 0
+	default:
+		return errPricingRuleTypeInvalid.New()
+	}
+
+	input.SKUs = normalizePricingScopeItems(input.SKUs)
+	input.Categories = normalizePricingScopeItems(input.Categories)
+	switch input.Scope {
+	case "", models.PricingRuleScopeAll:
+		input.Scope = models.PricingRuleScopeAll
+		input.SKUs, input.Categories = nil, nil
+	case models.PricingRuleScopeSKU:
+		if len(input.SKUs) == 0 {
+			return errPricingRuleScopeEmpty.New()
+		}
+		input.Categories = nil
+	case models.PricingRuleScopeCategory:
+		if len(input.Categories) == 0 {
+			return errPricingRuleScopeEmpty.New()
+		}
+		input.SKUs = nil
+	default:
+		return errPricingRuleScopeInvalid.New()
+	}
+	if len(input.SKUs) > maxPricingRuleScopeItems || len(input.Categories) > maxPricingRuleScopeItems {
+		return errPricingRuleScopeInvalid.New()
+	}
+
+	if input.StartsAt != nil && input.ExpiresAt != nil && !input.StartsAt.Before(*input.ExpiresAt) {
+		return errPricingRuleValidity.New()
+	}
+	return nil
+}
+
+// normalizePricingScopeItems 去除空白与重复项
+func normalizePricingScopeItems(items []string) []string {
+	var normalized []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			normalized = appendUniqueString(normalized, item)
+		}
+	}
+	return normalized
+}
+
+func applyPricingRuleInput(rule *models.PricingRule, input PricingRuleInput) {
+	rule.Name = input.Name
+	rule.Description = input.Description
+	rule.Type = input.Type
+	rule.Value = input.Value
+	rule.MaxDiscount = input.MaxDiscount
+	rule.MinAmount = input.MinAmount
+	rule.Scope = input.Scope
+	rule.SKUs = input.SKUs
+	rule.Categories = input.Categories
+	rule.Priority = input.Priority
+	rule.Exclusive = input.Exclusive
+	rule.Enabled = input.Enabled
+	rule.StartsAt = input.StartsAt
+	rule.ExpiresAt = input.ExpiresAt
+}
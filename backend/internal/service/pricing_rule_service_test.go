@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestPricingRulesStackByPriorityOnCreateUserOrder(t *testing.T) {
+	db := openConcurrentServiceTestDB(t, &models.User{}, &models.Product{}, &models.Order{}, &models.PricingRule{})
+	cfg := &config.Config{}
+	cfg.Order.MaxOrderItems = 20
+	cfg.Order.MaxItemQuantity = 10
+	cfg.Order.Currency = "USD"
+
+	user := models.User{UUID: "pricing-rule-user", Email: "pricing@example.com", Name: "pricing", Role: "user", IsActive: true, PasswordHash: "hash"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	for _, product := range []models.Product{
+		{SKU: "CABLE-1", Name: "Cable", Category: "cables", ProductType: models.ProductTypeVirtual, Status: models.ProductStatusActive, Price: 1000},
+		{SKU: "CAM-1", Name: "Camera", Category: "cameras", ProductType: models.ProductTypeVirtual, Status: models.ProductStatusActive, Price: 5000},
+	} {
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("create product failed: %v", err)
+		}
+	}
+
+	pricing := NewPricingRuleService(db)
+	_, err := pricing.Create(PricingRuleInput{Name: "Broken", Type: models.PricingRuleTypePercentage, Value: 20000})
+	requireBizErr(t, err, "pricing.valueInvalid")
+	_, err = pricing.Create(PricingRuleInput{Name: "Broken", Type: models.PricingRuleTypeThreshold, Value: 500})
+	requireBizErr(t, err, "pricing.thresholdRequired")
+	_, err = pricing.Create(PricingRuleInput{Name: "Broken", Type: models.PricingRuleTypeFixed, Value: 500, Scope: models.PricingRuleScopeSKU})
+	requireBizErr(t, err, "pricing.scopeEmpty")
+
+	rules := []PricingRuleInput{
+		{Name: "Cables 10%", Type: models.PricingRuleTypePercentage, Value: 1000, Scope: models.PricingRuleScopeCategory, Categories: []string{"cables"}, Priority: 10, Enabled: true},
+		{Name: "Spend 60 save 5", Type: models.PricingRuleTypeThreshold, Value: 500, MinAmount: 6000, Priority: 5, Enabled: true},
+		{Name: "Spend 100 save 20", Type: models.PricingRuleTypeThreshold, Value: 2000, MinAmount: 10000, Priority: 3, Enabled: true},
+		{Name: "Camera deal", Type: models.PricingRuleTypeFixed, Value: 300, Scope: models.PricingRuleScopeSKU, SKUs: []string{"CAM-1"}, Priority: 1, Exclusive: true, Enabled: true},
+		{Name: "Everything 1 off", Type: models.PricingRuleTypeFixed, Value: 100, Enabled: true},
+		{Name: "Disabled", Type: models.PricingRuleTypeFixed, Value: 999, Priority: 99},
+	}
+	for _, input := range rules {
+		if _, err := pricing.Create(input); err != nil {
+			t.Fatalf("create rule %q failed: %v", input.Name, err)
+		}
+	}
+
+	svc := newConcurrentOrderService(db, cfg, nil)
+	svc.SetPricingRuleService(pricing)
+	order, err := svc.CreateUserOrder(context.Background(), user.ID, []models.OrderItem{
+		{SKU: "CABLE-1", Name: "Cable", Quantity: 2},
+		{SKU: "CAM-1", Name: "Camera", Quantity: 1},
+	}, "", "", "")
+	if err != nil {
+		t.Fatalf("create user order failed: %v", err)
+	}
+	if order.DiscountAmount != 1000 || order.RuleDiscountAmount != 1000 || order.TotalAmount != 6000 {
+		t.Fatalf("expected 10.00 off 70.00, got discount=%d rules=%d total=%d", order.DiscountAmount, order.RuleDiscountAmount, order.TotalAmount)
+	}
+
+	expected := []struct {
+		name     string
+		applied  bool
+		reason   string
+		base     int64
+		discount int64
+	}{
+		{"Cables 10%", true, "", 2000, 200},
+		{"Spend 60 save 5", true, "", 6800, 500},
+		{"Spend 100 save 20", false, models.PricingSkipMinAmountNotMet, 6300, 0},
+		{"Camera deal", true, "", 4632, 300},
+		{"Everything 1 off", false, models.PricingSkipExclusive, 0, 0},
+	}
+	var stored models.Order
+	if err := db.First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if len(stored.PricingTrace) != len(expected) {
+		t.Fatalf("expected %d trace entries, got %+v", len(expected), stored.PricingTrace)
+	}
+	for i, want := range expected {
+		got := stored.PricingTrace[i]
+		if got.Name != want.name || got.Applied != want.applied || got.Reason != want.reason || got.BaseAmountMinor != want.base || got.DiscountMinor != want.discount {
+			t.Fatalf("trace[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+	if stored.PricingTrace[4].BlockedBy != "Camera deal" {
+		t.Fatalf("expected the exclusive rule to be named, got %+v", stored.PricingTrace[4])
+	}
+
+	evaluation, err := pricing.Simulate(map[string]int{"CABLE-1": 2, "CAM-1": 1}, "")
+	if err != nil {
+		t.Fatalf("simulate failed: %v", err)
+	}
+	if evaluation.Subtotal != 7000 || evaluation.Discount() != 1000 {
+		t.Fatalf("simulation should match the order, got %+v", evaluation)
+	}
+	_, err = pricing.Simulate(map[string]int{"MISSING": 1}, "")
+	requireBizErr(t, err, "pricing.productNotFound")
+}
+
+func TestEvaluatePricingAppliesPromoCodeAfterRules(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	lines := []PricingLine{{SKU: "A", Amount: 8000}, {SKU: "B", Amount: 2000}}
+	promo := &models.PromoCode{Code: "TEN", DiscountType: models.DiscountTypePercentage, DiscountValue: 1000, MinOrderAmount: 9500}
+
+	rules := []models.PricingRule{
+		{ID: 1, Name: "Fixed 20", Type: models.PricingRuleTypeFixed, Value: 2000, Scope: models.PricingRuleScopeAll, Enabled: true},
+		{ID: 2, Name: "Expired", Type: models.PricingRuleTypeFixed, Value: 500, Scope: models.PricingRuleScopeAll, Enabled: true, ExpiresAt: &past},
+	}
+	result := EvaluatePricing(rules, lines, promo, now)
+	// 优惠码最低消费按原价判断，优惠按规则减免后的 80.00 计算
+	if result.RuleDiscount != 2000 || result.PromoDiscount != 800 || len(result.Trace) != 2 {
+		t.Fatalf("unexpected evaluation %+v", result)
+	}
+	if promo := result.Trace[1]; promo.Source != models.PricingTraceSourcePromoCode || promo.BaseAmountMinor != 8000 || !promo.Applied {
+		t.Fatalf("unexpected promo trace %+v", promo)
+	}
+
+	rules[0].Exclusive = true
+	result = EvaluatePricing(rules, lines, promo, now)
+	if result.PromoBlockedBy != "Fixed 20" || result.PromoDiscount != 0 {
+		t.Fatalf("exclusive rule should block the promo code, got %+v", result)
+	}
+}
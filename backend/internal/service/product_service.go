@@ -21,8 +21,10 @@ type ProductService struct {
 	baseURL       string
 }
 
+// DeleteProductOptions 删除商品选项；商品移入回收站，图片默认保留到彻底删除时再清理，以便恢复
 type DeleteProductOptions struct {
-	DeleteImages bool
+	DeleteImages bool // 立即删除图片文件（恢复后商品将没有图片）
+	DeletedBy    uint // 操作人
 }
 
 var (
@@ -228,9 +230,9 @@ func (s *ProductService) UpdateProduct(id uint, updates *models.Product) error {
 	return nil
 }
 
-// DeleteProduct DeleteProduct
+// DeleteProduct 删除商品（移入回收站）
 func (s *ProductService) DeleteProduct(id uint) error {
-	return s.DeleteProductWithOptions(id, DeleteProductOptions{DeleteImages: true})
+	return s.DeleteProductWithOptions(id, DeleteProductOptions{})
 }
 
 func (s *ProductService) DeleteProductWithOptions(id uint, options DeleteProductOptions) error {
@@ -247,7 +249,7 @@ func (s *ProductService) DeleteProductWithOptions(id uint, options DeleteProduct
 		}
 	}
 
-	return s.productRepo.Delete(product.ID)
+	return s.productRepo.Delete(product.ID, options.DeletedBy)
 }
 
 // deleteProductImages DeleteProduct的所有图片文件
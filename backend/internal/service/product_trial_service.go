@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	productTrialMaxDays   = 365
+	productTrialBatchSize = 100
+	// 到期前多久提醒并生成转正订单；试用期较短时取试用期的一半
+	productTrialReminderLead = 72 * time.Hour
+)
+
+var (
+	errTrialDaysInvalid        = bizerr.Register("trial.daysInvalid", 400, "Trial length must be between 0 and 365 days")
+	errTrialProductNotEligible = bizerr.Register("trial.productNotEligible", 400, "Trials are only available for virtual products with auto delivery")
+	errTrialNotAvailable       = bizerr.Register("trial.notAvailable", 400, "This product does not offer a trial")
+	errTrialAlreadyUsed        = bizerr.Register("trial.alreadyUsed", 409, "You have already used the trial for this product")
+	errTrialServiceUnavailable = bizerr.Register("trial.unavailable", 503, "Trials are temporarily unavailable")
+	errTrialUserNotFound       = bizerr.Register("trial.userNotFound", 404, "User not found")
+)
+
+// ProductTrialStats 商品试用设置与各状态的试用数量
+type ProductTrialStats struct {
+	TrialDays int   `json:"trial_days"`
+	Active    int64 `json:"active"`
+	Converted int64 `json:"converted"`
+	Expired   int64 `json:"expired"`
+}
+
+// ProductTrialService 虚拟商品试用：领取发放、到期提醒与转正、过期失效
+// 系统不保存支付凭据，转正通过到期前生成的待付款订单完成，付款后试用卡密继续有效
+type ProductTrialService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	emailService *EmailService
+	orderService *OrderService
+}
+
+func NewProductTrialService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *ProductTrialService {
+	return &ProductTrialService{db: db, cfg: cfg, emailService: emailService}
+}
+
+// SetOrderService 注入订单服务，用于发放试用订单与生成转正订单
+func (s *ProductTrialService) SetOrderService(orderService *OrderService) {
+	s.orderService = orderService
+}
+
+func trialReminderLead(days int) time.Duration {
+	half := time.Duration(days) * 24 * time.Hour / 2
+	if half < productTrialReminderLead {
+		return half
+	}
+	return productTrialReminderLead
+}
+
+func (s *ProductTrialService) loadProduct(productID uint) (*models.Product, error) {
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SetProductTrial 设置商品试用天数，0 表示关闭试用；已领取的试用不受影响
+func (s *ProductTrialService) SetProductTrial(productID uint, days int) (*models.Product, error) {
+	if days < 0 || days > productTrialMaxDays {
+		return nil, errTrialDaysInvalid.New()
+	}
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if days > 0 && (product.ProductType != models.ProductTypeVirtual || !product.AutoDelivery) {
+		return nil, errTrialProductNotEligible.New()
+	}
+	if product.TrialDays != days {
+		if err := s.db.Model(&models.Product{}).Where("id = ?", product.ID).
+			Update("trial_days", days).Error; err != nil {
+			return nil, err
+		}
+		product.TrialDays = days
+	}
+	return product, nil
+}
+
+// Stats 商品试用设置与各状态的试用数量
+func (s *ProductTrialService) Stats(productID uint) (*ProductTrialStats, error) {
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Status models.ProductTrialStatus
+		Count  int64
+	}
+	if err := s.db.Model(&models.ProductTrial{}).
+		Select("status, COUNT(*) AS count").
+		Where("product_id = ? AND order_id IS NOT NULL", productID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	stats := &ProductTrialStats{TrialDays: product.TrialDays}
+	for _, row := range rows {
+		switch row.Status {
+		case models.ProductTrialStatusActive:
+			stats.Active = row.Count
+		case models.ProductTrialStatusConverted:
+			stats.Converted = row.Count
+		case models.ProductTrialStatusExpired:
+			stats.Expired = row.Count
+		}
+	}
+	return stats, nil
+}
+
+// StartTrial 用户领取商品试用：先登记试用占用 (用户, 商品) 唯一键，再发放零金额订单，发放失败时撤销登记
+func (s *ProductTrialService) StartTrial(userID, productID uint) (*models.ProductTrial, error) {
+	if s.orderService == nil {
+		return nil, errTrialServiceUnavailable.New()
+	}
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		if errors.Is(err, ErrProductNotFound) {
+			return nil, errTrialNotAvailable.New()
+		}
+		return nil, err
+	}
+	if product.TrialDays <= 0 || product.Status != models.ProductStatusActive ||
+		product.ProductType != models.ProductTypeVirtual || !product.AutoDelivery {
+		return nil, errTrialNotAvailable.New()
+	}
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, bizerr.FromStore(err, errTrialUserNotFound)
+	}
+
+	now := models.NowFunc()
+	expiresAt := now.Add(time.Duration(product.TrialDays) * 24 * time.Hour)
+	trial := &models.ProductTrial{
+		UserID:      userID,
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Days:        product.TrialDays,
+		Status:      models.ProductTrialStatusActive,
+		ExpiresAt:   expiresAt,
+		RemindAt:    expiresAt.Add(-trialReminderLead(product.TrialDays)),
+	}
+	if err := s.db.Create(trial).Error; err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, errTrialAlreadyUsed.New()
+		}
+		return nil, err
+	}
+
+	order, err := s.orderService.CreateTrialOrder(&user, product, product.TrialDays)
+	if err != nil {
+		if delErr := s.db.Delete(&models.ProductTrial{}, trial.ID).Error; delErr != nil {
+			log.Printf("product trial %d: rollback after issuance failure failed: %v", trial.ID, delErr)
+		}
+		return nil, err
+	}
+	trial.OrderID = &order.ID
+	trial.OrderNo = order.OrderNo
+	if err := s.db.Model(trial).Updates(map[string]interface{}{
+		"order_id": order.ID,
+		"order_no": order.OrderNo,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return trial, nil
+}
+
+// ListUserTrials 用户领取过的全部试用，最新的在前
+func (s *ProductTrialService) ListUserTrials(userID uint) ([]models.ProductTrial, error) {
+	var trials []models.ProductTrial
+	err := s.db.Where("user_id = ? AND order_id IS NOT NULL", userID).Order("id DESC").Find(&trials).Error
+	return trials, err
+}
+
+// ListTrials 分页查询试用记录，productID/userID 为 0、status 为空时不过滤
+func (s *ProductTrialService) ListTrials(productID, userID uint, status string, page, limit int) ([]models.ProductTrial, int64, error) {
+	query := s.db.Model(&models.ProductTrial{}).Where("order_id IS NOT NULL")
+	if productID > 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var trials []models.ProductTrial
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&trials).Error
+	return trials, total, err
+}
+
+// RegisterJobs 注册试用到期处理定时任务
+func (s *ProductTrialService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "product_trial_lifecycle",
+		Description: "Remind users before product trials end, create conversion orders and expire unpaid trials",
+		Interval:    time.Hour,
+		Run:         s.ProcessTrials,
+	})
+}
+
+// ProcessTrials 处理需要提醒、已付款转正或已到期的试用
+func (s *ProductTrialService) ProcessTrials(ctx context.Context) error {
+	var lastID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := models.NowFunc()
+		var trials []models.ProductTrial
+		err := s.db.Where("id > ? AND status = ? AND order_id IS NOT NULL", lastID, models.ProductTrialStatusActive).
+			Where("conversion_order_id IS NOT NULL OR expires_at <= ? OR (reminder_sent_at IS NULL AND remind_at <= ?)", now, now).
+			Order("id ASC").
+			Limit(productTrialBatchSize).
+			Find(&trials).Error
+		if err != nil {
+			return err
+		}
+		if len(trials) == 0 {
+			return nil
+		}
+		for i := range trials {
+			lastID = trials[i].ID
+			if err := s.processTrial(&trials[i], now); err != nil {
+				log.Printf("product trial %d: process failed: %v", trials[i].ID, err)
+			}
+		}
+		if len(trials) < productTrialBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *ProductTrialService) processTrial(trial *models.ProductTrial, now time.Time) error {
+	if trial.ConversionOrderID != nil {
+		var conversion models.Order
+		err := s.db.Select("id", "status", "paid_at").First(&conversion, *trial.ConversionOrderID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && conversion.PaidAt != nil {
+			return s.markTrial(trial, models.ProductTrialStatusConverted, map[string]interface{}{"converted_at": now})
+		}
+	}
+	if !now.Before(trial.ExpiresAt) {
+		return s.expireTrial(trial, now)
+	}
+	if trial.ReminderSentAt == nil && !now.Before(trial.RemindAt) {
+		return s.remindTrial(trial, now)
+	}
+	return nil
+}
+
+// markTrial 仅在试用仍为进行中时更新状态，避免与并发处理重复流转
+func (s *ProductTrialService) markTrial(trial *models.ProductTrial, status models.ProductTrialStatus, updates map[string]interface{}) error {
+	updates["status"] = status
+	result := s.db.Model(&models.ProductTrial{}).
+		Where("id = ? AND status = ?", trial.ID, models.ProductTrialStatusActive).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("trial is no longer active")
+	}
+	trial.Status = status
+	return nil
+}
+
+// remindTrial 生成转正订单并提醒用户付款；商品已下架或未定价时只发提醒
+func (s *ProductTrialService) remindTrial(trial *models.ProductTrial, now time.Time) error {
+	var source models.Order
+	if err := s.db.First(&source, *trial.OrderID).Error; err != nil {
+		return err
+	}
+	updates := map[string]interface{}{"reminder_sent_at": now}
+	var conversion *models.Order
+	if s.orderService != nil {
+		product, err := s.loadProduct(trial.ProductID)
+		if err != nil && !errors.Is(err, ErrProductNotFound) {
+			return err
+		}
+		if product != nil && product.Status == models.ProductStatusActive && product.Price > 0 {
+			conversion, err = s.orderService.CreateTrialConversionOrder(trial, product, &source)
+			if err != nil {
+				return err
+			}
+			updates["conversion_order_id"] = conversion.ID
+			updates["conversion_order_no"] = conversion.OrderNo
+		}
+	}
+	result := s.db.Model(&models.ProductTrial{}).
+		Where("id = ? AND reminder_sent_at IS NULL", trial.ID).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("trial reminder was sent concurrently")
+	}
+	trial.ReminderSentAt = &now
+	if conversion != nil {
+		trial.ConversionOrderID = &conversion.ID
+		trial.ConversionOrderNo = conversion.OrderNo
+	}
+	if s.emailService != nil {
+		go func(trial models.ProductTrial, source models.Order, conversion *models.Order) {
+			if err := s.emailService.SendTrialReminderEmail(&source, &trial, conversion); err != nil {
+				log.Printf("product trial %d: send reminder failed: %v", trial.ID, err)
+			}
+		}(*trial, source, conversion)
+	}
+	return nil
+}
+
+// expireTrial 到期未付款：先取消仍待付款的转正订单，再将试用标记为过期并通知用户
+// 取消失败（如用户恰好完成付款）时保留试用状态，下次任务重新判断
+func (s *ProductTrialService) expireTrial(trial *models.ProductTrial, now time.Time) error {
+	if trial.ConversionOrderID != nil && s.orderService != nil {
+		var conversion models.Order
+		err := s.db.Select("id", "status").First(&conversion, *trial.ConversionOrderID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && conversion.Status == models.OrderStatusPendingPayment {
+			if err := s.orderService.CancelOrder(conversion.ID, "Trial expired"); err != nil {
+				return fmt.Errorf("cancel conversion order: %w", err)
+			}
+		}
+	}
+	if err := s.markTrial(trial, models.ProductTrialStatusExpired, map[string]interface{}{"expired_at": now}); err != nil {
+		return err
+	}
+	trial.ExpiredAt = &now
+	if s.emailService != nil {
+		var source models.Order
+		if err := s.db.First(&source, *trial.OrderID).Error; err != nil {
+			return err
+		}
+		go func(trial models.ProductTrial, source models.Order) {
+			if err := s.emailService.SendTrialExpiredEmail(&source, &trial); err != nil {
+				log.Printf("product trial %d: send expiry notice failed: %v", trial.ID, err)
+			}
+		}(*trial, source)
+	}
+	return nil
+}
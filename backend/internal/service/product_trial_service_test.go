@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestProductTrialIssuesRemindsConvertsAndExpires(t *testing.T) {
+	orderService, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventory{}, &models.VirtualProductStock{}, &models.ProductVirtualInventoryBinding{},
+		&models.ProductTrial{}, &models.LicenseActivation{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	orderService.virtualProductSvc = NewVirtualInventoryService(db)
+	trialService := NewProductTrialService(db, orderService.cfg, nil)
+	trialService.SetOrderService(orderService)
+	bindingService := NewBindingService(repository.NewBindingRepository(db), repository.NewInventoryRepository(db), repository.NewProductRepository(db))
+
+	product := &models.Product{SKU: "TRIAL-APP", Name: "Desktop app", ProductType: models.ProductTypeVirtual, Status: models.ProductStatusActive, Price: 1000}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "App licenses", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	if err := db.Create(&models.ProductVirtualInventoryBinding{ProductID: product.ID, VirtualInventoryID: inventory.ID, AttributesHash: "default"}).Error; err != nil {
+		t.Fatalf("create binding: %v", err)
+	}
+	for _, key := range []string{"TRIAL-KEY-1", "TRIAL-KEY-2"} {
+		if err := db.Create(&models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: key, Status: models.VirtualStockStatusAvailable}).Error; err != nil {
+			t.Fatalf("create stock: %v", err)
+		}
+	}
+	users := []*models.User{{UUID: "trial-user-1", Email: "lapsed@example.com"}, {UUID: "trial-user-2", Email: "buyer@example.com"}}
+	for _, user := range users {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	_, err := trialService.SetProductTrial(product.ID, 7)
+	requireOrderBizErr(t, err, "trial.productNotEligible")
+	db.Model(product).Update("auto_delivery", true)
+	_, err = trialService.SetProductTrial(product.ID, 400)
+	requireOrderBizErr(t, err, "trial.daysInvalid")
+	if _, err := trialService.SetProductTrial(product.ID, 7); err != nil {
+		t.Fatalf("enable trial: %v", err)
+	}
+
+	start := time.Now()
+	originalNow := models.NowFunc
+	models.NowFunc = func() time.Time { return start }
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	trials := make([]*models.ProductTrial, len(users))
+	keys := make([]string, len(users))
+	for i, user := range users {
+		trial, err := trialService.StartTrial(user.ID, product.ID)
+		if err != nil || trial.OrderID == nil {
+			t.Fatalf("start trial: %+v err=%v", trial, err)
+		}
+		var order models.Order
+		if err := db.First(&order, *trial.OrderID).Error; err != nil || order.Source != OrderSourceTrial ||
+			order.TotalAmount != 0 || order.Status != models.OrderStatusShipped {
+			t.Fatalf("expected delivered zero-amount trial order, got %+v err=%v", order, err)
+		}
+		trials[i] = trial
+		// 库存随机分配，按订单取回实际发放的卡密
+		var stock models.VirtualProductStock
+		if err := db.Where("order_no = ?", order.OrderNo).First(&stock).Error; err != nil {
+			t.Fatalf("load trial stock: %v", err)
+		}
+		keys[i] = stock.Content
+	}
+	// 每个用户每个商品只能试用一次
+	_, err = trialService.StartTrial(users[0].ID, product.ID)
+	requireOrderBizErr(t, err, "trial.alreadyUsed")
+
+	device := LicenseDevice{DeviceID: "laptop"}
+	if _, err := bindingService.ActivateLicense(keys[0], device); err != nil {
+		t.Fatalf("activate trial license: %v", err)
+	}
+
+	// 到期前 72 小时生成转正订单
+	models.NowFunc = func() time.Time { return start.Add(5 * 24 * time.Hour) }
+	if err := trialService.ProcessTrials(context.Background()); err != nil {
+		t.Fatalf("process reminders: %v", err)
+	}
+	conversions := make([]models.Order, len(trials))
+	for i, trial := range trials {
+		db.First(trial, trial.ID)
+		if trial.ReminderSentAt == nil || trial.ConversionOrderID == nil {
+			t.Fatalf("expected reminder with conversion order, got %+v", trial)
+		}
+		db.First(&conversions[i], *trial.ConversionOrderID)
+		if conversions[i].Status != models.OrderStatusPendingPayment || conversions[i].TotalAmount != 1000 || conversions[i].Source != OrderSourceTrialConversion {
+			t.Fatalf("unexpected conversion order: %+v", conversions[i])
+		}
+	}
+	if err := orderService.MarkAsPaid(conversions[1].ID); err != nil {
+		t.Fatalf("pay conversion order: %v", err)
+	}
+
+	// 到期后未付款的卡密立即失效，已付款的在任务处理前也继续有效
+	models.NowFunc = func() time.Time { return start.Add(8 * 24 * time.Hour) }
+	_, err = bindingService.ValidateLicense(keys[0], device)
+	requireOrderBizErr(t, err, "license.trialExpired")
+	if _, err := bindingService.ActivateLicense(keys[1], device); err != nil {
+		t.Fatalf("expected paid trial license to stay valid: %v", err)
+	}
+
+	if err := trialService.ProcessTrials(context.Background()); err != nil {
+		t.Fatalf("process expiry: %v", err)
+	}
+	db.First(trials[0], trials[0].ID)
+	db.First(trials[1], trials[1].ID)
+	if trials[0].Status != models.ProductTrialStatusExpired || trials[1].Status != models.ProductTrialStatusConverted {
+		t.Fatalf("expected expired and converted trials, got %s and %s", trials[0].Status, trials[1].Status)
+	}
+	db.First(&conversions[0], conversions[0].ID)
+	if conversions[0].Status != models.OrderStatusCancelled {
+		t.Fatalf("expected unpaid conversion order to be cancelled, got %s", conversions[0].Status)
+	}
+	stats, err := trialService.Stats(product.ID)
+	if err != nil || stats.TrialDays != 7 || stats.Converted != 1 || stats.Expired != 1 || stats.Active != 0 {
+		t.Fatalf("unexpected trial stats: %+v err=%v", stats, err)
+	}
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	promoCodeCampaignMaxCount        = 10000
+	promoCodeCampaignDefaultPattern  = "XXXXXXXX"
+	promoCodeCampaignMaxPrefixLength = 20
+	promoCodeCampaignMaxPatternLen   = 40
+	promoCodeCampaignMinPlaceholders = 4
+	promoCodeMaxCodeLength           = 50
+	promoCodeCampaignCapacityFactor  = 100 // 组合数至少是生成数量的 100 倍，避免码空间过小被枚举或频繁碰撞
+	promoCodeCampaignLookupChunk     = 500
+	promoCodeCampaignGenerateRounds  = 5
+
+	promoCodeAlphanumericAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉易混淆的 0/O、1/I
+	promoCodeLetterAlphabet       = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	promoCodeDigitAlphabet        = "0123456789"
+)
+
+// PromoCodeCampaignInput 批量生成优惠码的活动设置
+type PromoCodeCampaignInput struct {
+	Name           string
+	Description    string
+	Prefix         string
+	Pattern        string
+	Count          int
+	DiscountType   models.DiscountType
+	DiscountValue  int64
+	MaxDiscount    int64
+	MinOrderAmount int64
+	UsesPerCode    int
+	PerUserLimit   int
+	ProductIDs     []uint
+	ProductScope   string
+	StartsAt       *time.Time
+	ExpiresAt      *time.Time
+	CreatedBy      *uint
+}
+
+// PromoCodeCampaignStats 活动维度的发放与使用统计
+type PromoCodeCampaignStats struct {
+	CampaignID          uint  `json:"campaign_id"`
+	Issued              int64 `json:"issued"`                // 已生成的优惠码数（不含已删除）
+	Active              int64 `json:"active"`                // 当前可用的优惠码数
+	Reserved            int64 `json:"reserved"`              // 未完成订单预留中的使用次数
+	Used                int64 `json:"used"`                  // 已完成订单使用次数
+	CodesUsed           int64 `json:"codes_used"`            // 至少使用过一次的优惠码数
+	Orders              int64 `json:"orders"`                // 使用活动优惠码且未取消的订单数
+	DiscountAmountMinor int64 `json:"discount_amount_minor"` // 上述订单中优惠码带来的优惠总额（不含定价规则）
+}
+
+// PromoCodeCampaignService 优惠码活动：按前缀与模式批量生成优惠码，并按活动汇总发放、预留与使用情况
+type PromoCodeCampaignService struct {
+	db *gorm.DB
+}
+
+func NewPromoCodeCampaignService(db *gorm.DB) *PromoCodeCampaignService {
+	return &PromoCodeCampaignService{db: db}
+}
+
+// Create 创建活动并在同一事务内生成全部优惠码，任一优惠码写入失败则整体回滚
+func (s *PromoCodeCampaignService) Create(input PromoCodeCampaignInput) (*models.PromoCodeCampaign, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	input.Prefix = strings.ToUpper(strings.TrimSpace(input.Prefix))
+	input.Pattern = strings.ToUpper(strings.TrimSpace(input.Pattern))
+	if input.Pattern == "" {
+		input.Pattern = promoCodeCampaignDefaultPattern
+	}
+	if input.ProductScope == "" {
+		input.ProductScope = "all"
+	}
+	if err := validatePromoCodeCampaignInput(&input); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.generateUniqueCodes(input.Prefix, input.Pattern, input.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &models.PromoCodeCampaign{
+		Name:           input.Name,
+		Description:    input.Description,
+		Prefix:         input.Prefix,
+		Pattern:        input.Pattern,
+		DiscountType:   input.DiscountType,
+		DiscountValue:  input.DiscountValue,
+		MaxDiscount:    input.MaxDiscount,
+		MinOrderAmount: input.MinOrderAmount,
+		UsesPerCode:    input.UsesPerCode,
+		PerUserLimit:   input.PerUserLimit,
+		ProductIDs:     input.ProductIDs,
+		ProductScope:   input.ProductScope,
+		StartsAt:       input.StartsAt,
+		ExpiresAt:      input.ExpiresAt,
+		CodeCount:      len(codes),
+		CreatedBy:      input.CreatedBy,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		promoCodes := make([]models.PromoCode, 0, len(codes))
+		for _, code := range codes {
+			promoCodes = append(promoCodes, models.PromoCode{
+				Code:           code,
+				Name:           campaign.Name,
+				Description:    campaign.Description,
+				DiscountType:   campaign.DiscountType,
+				DiscountValue:  campaign.DiscountValue,
+				MaxDiscount:    campaign.MaxDiscount,
+				MinOrderAmount: campaign.MinOrderAmount,
+				TotalQuantity:  campaign.UsesPerCode,
+				ProductIDs:     campaign.ProductIDs,
+				ProductScope:   campaign.ProductScope,
+				Status:         models.PromoCodeStatusActive,
+				StartsAt:       campaign.StartsAt,
+				ExpiresAt:      campaign.ExpiresAt,
+				PerUserLimit:   campaign.PerUserLimit,
+				CampaignID:     &campaign.ID,
+			})
+		}
+		return tx.CreateInBatches(&promoCodes, promoCodeCampaignLookupChunk).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// List 活动分页列表，search 匹配活动名称或前缀
+func (s *PromoCodeCampaignService) List(page, limit int, search string) ([]models.PromoCodeCampaign, int64, error) {
+	query := s.db.Model(&models.PromoCodeCampaign{})
+	if search = strings.TrimSpace(search); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("name LIKE ? OR prefix LIKE ?", like, strings.ToUpper(like))
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var campaigns []models.PromoCodeCampaign
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&campaigns).Error
+	return campaigns, total, err
+}
+
+// Get 获取活动详情
+func (s *PromoCodeCampaignService) Get(id uint) (*models.PromoCodeCampaign, error) {
+	var campaign models.PromoCodeCampaign
+	if err := s.db.First(&campaign, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, promoCodeCampaignNotFoundError()
+		}
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// ListCodes 活动下的全部优惠码（不含已删除），用于导出
+func (s *PromoCodeCampaignService) ListCodes(id uint) ([]models.PromoCode, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+	var codes []models.PromoCode
+	err := s.db.Where("campaign_id = ?", id).Order("id ASC").Find(&codes).Error
+	return codes, err
+}
+
+// Stats 汇总活动下优惠码的发放、预留与使用情况
+func (s *PromoCodeCampaignService) Stats(id uint) (*PromoCodeCampaignStats, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+	stats := &PromoCodeCampaignStats{CampaignID: id}
+
+	var totals struct {
+		Issued    int64
+		Reserved  int64
+		Used      int64
+		CodesUsed int64
+	}
+	if err := s.db.Model(&models.PromoCode{}).
+		Select("COUNT(*) AS issued, COALESCE(SUM(reserved_quantity), 0) AS reserved, COALESCE(SUM(used_quantity), 0) AS used, "+
+			"COALESCE(SUM(CASE WHEN used_quantity > 0 THEN 1 ELSE 0 END), 0) AS codes_used").
+		Where("campaign_id = ?", id).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	stats.Issued = totals.Issued
+	stats.Reserved = totals.Reserved
+	stats.Used = totals.Used
+	stats.CodesUsed = totals.CodesUsed
+
+	now := models.NowFunc()
+	if err := s.db.Model(&models.PromoCode{}).
+		Where("campaign_id = ? AND status = ?", id, models.PromoCodeStatusActive).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("total_quantity = 0 OR used_quantity + reserved_quantity < total_quantity").
+		Count(&stats.Active).Error; err != nil {
+		return nil, err
+	}
+
+	var orders struct {
+		Orders   int64
+		Discount int64
+	}
+	if err := s.db.Model(&models.Order{}).
+		Select("COUNT(*) AS orders, COALESCE(SUM(discount_amount - rule_discount_amount), 0) AS discount").
+		Where("promo_code_id IN (?)", s.db.Unscoped().Model(&models.PromoCode{}).Select("id").Where("campaign_id = ?", id)).
+		Where("status <> ?", models.OrderStatusCancelled).
+		Scan(&orders).Error; err != nil {
+		return nil, err
+	}
+	stats.Orders = orders.Orders
+	stats.DiscountAmountMinor = orders.Discount
+	return stats, nil
+}
+
+func promoCodeCampaignNotFoundError() error {
+	return bizerr.New("promo_code.campaignNotFound", "Promo code campaign not found")
+}
+
+func validatePromoCodeCampaignInput(input *PromoCodeCampaignInput) error {
+	if input.Name == "" {
+		return bizerr.New("promo_code.campaignNameRequired", "Campaign name is required")
+	}
+	if input.Count <= 0 || input.Count > promoCodeCampaignMaxCount {
+		return bizerr.Newf("promo_code.campaignCountInvalid", "Code count must be between 1 and %d", promoCodeCampaignMaxCount).
+			WithParams(map[string]interface{}{"max": promoCodeCampaignMaxCount})
+	}
+	if len(input.Prefix) > promoCodeCampaignMaxPrefixLength || !isPromoCodePrefixValid(input.Prefix) {
+		return bizerr.Newf("promo_code.campaignPrefixInvalid", "Prefix may only contain letters, digits, - and _ (up to %d characters)", promoCodeCampaignMaxPrefixLength).
+			WithParams(map[string]interface{}{"max": promoCodeCampaignMaxPrefixLength})
+	}
+	capacity, ok := promoCodePatternCapacity(input.Pattern)
+	if !ok {
+		return bizerr.Newf("promo_code.campaignPatternInvalid", "Pattern must contain at least %d placeholders (X, 9 or A) and only - or _ as separators", promoCodeCampaignMinPlaceholders).
+			WithParams(map[string]interface{}{"min": promoCodeCampaignMinPlaceholders})
+	}
+	if len(input.Prefix)+len(input.Pattern) > promoCodeMaxCodeLength {
+		return bizerr.Newf("promo_code.campaignCodeTooLong", "Generated codes cannot exceed %d characters", promoCodeMaxCodeLength).
+			WithParams(map[string]interface{}{"max": promoCodeMaxCodeLength})
+	}
+	required := new(big.Int).Mul(big.NewInt(int64(input.Count)), big.NewInt(promoCodeCampaignCapacityFactor))
+	if capacity.Cmp(required) < 0 {
+		return bizerr.New("promo_code.campaignPatternTooSmall", "Pattern does not allow enough unique codes for the requested count, use a longer pattern")
+	}
+	switch input.DiscountType {
+	case models.DiscountTypePercentage:
+		if input.DiscountValue <= 0 || input.DiscountValue > 10000 {
+			return bizerr.New("promo_code.campaignDiscountInvalid", "Invalid discount value")
+		}
+	case models.DiscountTypeFixed:
+		if input.DiscountValue <= 0 {
+			return bizerr.New("promo_code.campaignDiscountInvalid", "Invalid discount value")
+		}
+	default:
+		return bizerr.New("promo_code.campaignDiscountInvalid", "Invalid discount value")
+	}
+	if input.MaxDiscount < 0 || input.MinOrderAmount < 0 || input.UsesPerCode < 0 || input.PerUserLimit < 0 {
+		return bizerr.New("promo_code.campaignLimitInvalid", "Amounts and usage limits cannot be negative")
+	}
+	if input.StartsAt != nil && input.ExpiresAt != nil && !input.StartsAt.Before(*input.ExpiresAt) {
+		return bizerr.New("promo_code.campaignValidityInvalid", "Start time must be before expiry time")
+	}
+	return nil
+}
+
+func isPromoCodePrefixValid(prefix string) bool {
+	for _, r := range prefix {
+		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// promoCodePatternCapacity 计算模式可生成的优惠码组合数，模式非法时返回 false
+func promoCodePatternCapacity(pattern string) (*big.Int, bool) {
+	if len(pattern) > promoCodeCampaignMaxPatternLen {
+		return nil, false
+	}
+	capacity := big.NewInt(1)
+	placeholders := 0
+	for _, r := range pattern {
+		alphabet := promoCodePatternAlphabet(r)
+		if alphabet == "" {
+			if r != '-' && r != '_' {
+				return nil, false
+			}
+			continue
+		}
+		placeholders++
+		capacity.Mul(capacity, big.NewInt(int64(len(alphabet))))
+	}
+	if placeholders < promoCodeCampaignMinPlaceholders {
+		return nil, false
+	}
+	return capacity, true
+}
+
+func promoCodePatternAlphabet(r rune) string {
+	switch r {
+	case 'X':
+		return promoCodeAlphanumericAlphabet
+	case 'A':
+		return promoCodeLetterAlphabet
+	case '9':
+		return promoCodeDigitAlphabet
+	}
+	return ""
+}
+
+func generatePromoCodeFromPattern(prefix, pattern string) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(prefix)
+	for _, r := range pattern {
+		alphabet := promoCodePatternAlphabet(r)
+		if alphabet == "" {
+			builder.WriteRune(r)
+			continue
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(alphabet[n.Int64()])
+	}
+	return builder.String(), nil
+}
+
+// generateUniqueCodes 生成 count 个互不重复且与现有优惠码（含回收站中的）不冲突的优惠码
+func (s *PromoCodeCampaignService) generateUniqueCodes(prefix, pattern string, count int) ([]string, error) {
+	seen := make(map[string]struct{}, count)
+	codes := make([]string, 0, count)
+	for round := 0; round < promoCodeCampaignGenerateRounds && len(codes) < count; round++ {
+		candidates := make([]string, 0, count-len(codes))
+		for len(candidates) < count-len(codes) {
+			code, err := generatePromoCodeFromPattern(prefix, pattern)
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := seen[code]; dup {
+				continue
+			}
+			seen[code] = struct{}{}
+			candidates = append(candidates, code)
+		}
+
+		existing := make(map[string]struct{})
+		for start := 0; start < len(candidates); start += promoCodeCampaignLookupChunk {
+			end := start + promoCodeCampaignLookupChunk
+			if end > len(candidates) {
+				end = len(candidates)
+			}
+			var taken []string
+			if err := s.db.Unscoped().Model(&models.PromoCode{}).
+				Where("code IN ?", candidates[start:end]).
+				Pluck("code", &taken).Error; err != nil {
+				return nil, err
+			}
+			for _, code := range taken {
+				existing[code] = struct{}{}
+			}
+		}
+		for _, code := range candidates {
+			if _, taken := existing[code]; !taken {
+				codes = append(codes, code)
+			}
+		}
+	}
+	if len(codes) < count {
+		return nil, bizerr.New("promo_code.campaignPatternTooSmall", "Pattern does not allow enough unique codes for the requested count, use a longer pattern")
+	}
+	return codes, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestPromoCodeCampaignGeneratesCodesAndStats(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.PromoCode{}, &models.PromoCodeCampaign{}, &models.Order{}); err != nil {
+		t.Fatalf("auto migrate promo codes failed: %v", err)
+	}
+	svc := NewPromoCodeCampaignService(db)
+
+	_, err := svc.Create(PromoCodeCampaignInput{Name: "Spring", Pattern: "XX", Count: 1, DiscountType: models.DiscountTypeFixed, DiscountValue: 100})
+	requireBizErr(t, err, "promo_code.campaignPatternInvalid")
+	_, err = svc.Create(PromoCodeCampaignInput{Name: "Spring", Pattern: "9999", Count: 200, DiscountType: models.DiscountTypeFixed, DiscountValue: 100})
+	requireBizErr(t, err, "promo_code.campaignPatternTooSmall")
+	_, err = svc.Create(PromoCodeCampaignInput{Name: "Spring", Prefix: "SP RING", Count: 1, DiscountType: models.DiscountTypeFixed, DiscountValue: 100})
+	requireBizErr(t, err, "promo_code.campaignPrefixInvalid")
+	_, err = svc.Create(PromoCodeCampaignInput{Name: "Spring", Count: 1, DiscountType: models.DiscountTypePercentage, DiscountValue: 20000})
+	requireBizErr(t, err, "promo_code.campaignDiscountInvalid")
+
+	startsAt := models.NowFunc().Add(time.Hour)
+	future, err := svc.Create(PromoCodeCampaignInput{Name: "Later", Count: 1, DiscountType: models.DiscountTypeFixed, DiscountValue: 100, StartsAt: &startsAt})
+	if err != nil {
+		t.Fatalf("create future campaign failed: %v", err)
+	}
+	futureCodes, _ := svc.ListCodes(future.ID)
+	if len(futureCodes) != 1 || futureCodes[0].IsAvailable() {
+		t.Fatalf("expected one not-yet-started code, got %+v", futureCodes)
+	}
+
+	campaign, err := svc.Create(PromoCodeCampaignInput{
+		Name:          "Spring",
+		Prefix:        "spring-",
+		Pattern:       "xxxx-9999",
+		Count:         50,
+		DiscountType:  models.DiscountTypeFixed,
+		DiscountValue: 500,
+		UsesPerCode:   2,
+		PerUserLimit:  1,
+	})
+	if err != nil {
+		t.Fatalf("create campaign failed: %v", err)
+	}
+	codes, err := svc.ListCodes(campaign.ID)
+	if err != nil || len(codes) != 50 || campaign.CodeCount != 50 {
+		t.Fatalf("list campaign codes failed: count=%d err=%v", len(codes), err)
+	}
+	codePattern := regexp.MustCompile(`^SPRING-[A-HJ-NP-Z2-9]{4}-[0-9]{4}$`)
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if !codePattern.MatchString(code.Code) || seen[code.Code] {
+			t.Fatalf("unexpected or duplicate code %q", code.Code)
+		}
+		seen[code.Code] = true
+		if code.TotalQuantity != 2 || code.PerUserLimit != 1 || code.CampaignID == nil || *code.CampaignID != campaign.ID {
+			t.Fatalf("code did not inherit campaign settings: %+v", code)
+		}
+	}
+
+	// 第一个码用完，第二个码已用一次、预留一次；用户 7 已用第三个码下过单
+	db.Model(&models.PromoCode{}).Where("id = ?", codes[0].ID).Updates(map[string]interface{}{"used_quantity": 2})
+	db.Model(&models.PromoCode{}).Where("id = ?", codes[1].ID).Updates(map[string]interface{}{"used_quantity": 1, "reserved_quantity": 1})
+	userID := uint(7)
+	orders := []models.Order{
+		{OrderNo: "PC-1", UserID: &userID, PromoCodeID: &codes[2].ID, DiscountAmount: 500, Status: models.OrderStatusCompleted},
+		{OrderNo: "PC-2", PromoCodeID: &codes[1].ID, DiscountAmount: 500, Status: models.OrderStatusPendingPayment},
+		{OrderNo: "PC-3", PromoCodeID: &codes[1].ID, DiscountAmount: 500, Status: models.OrderStatusCancelled},
+	}
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("create orders failed: %v", err)
+	}
+
+	stats, err := svc.Stats(campaign.ID)
+	if err != nil {
+		t.Fatalf("campaign stats failed: %v", err)
+	}
+	if stats.Issued != 50 || stats.Active != 48 || stats.Reserved != 1 || stats.Used != 3 || stats.CodesUsed != 2 ||
+		stats.Orders != 2 || stats.DiscountAmountMinor != 1000 {
+		t.Fatalf("unexpected campaign stats: %+v", stats)
+	}
+
+	promoService := NewPromoCodeService(repository.NewPromoCodeRepository(db), nil)
+	_, _, err = promoService.ValidateCode(userID, codes[2].Code, nil, 1000)
+	requireBizErr(t, err, "promo_code.perUserLimitReached")
+	if _, discount, err := promoService.ValidateCode(8, codes[2].Code, nil, 1000); err != nil || discount != 500 {
+		t.Fatalf("validate code for another user failed: discount=%d err=%v", discount, err)
+	}
+
+	_, err = svc.Stats(9999)
+	requireBizErr(t, err, "promo_code.campaignNotFound")
+}
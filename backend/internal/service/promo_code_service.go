@@ -91,8 +91,8 @@ func (s *PromoCodeService) List(page, limit int, status string, search string) (
 	return s.repo.List(page, limit, status, search)
 }
 
-// Delete 删除优惠码
-func (s *PromoCodeService) Delete(id uint) error {
+// Delete 删除优惠码（移入回收站）
+func (s *PromoCodeService) Delete(id uint, deletedBy uint) error {
 	promoCode, err := s.repo.FindByID(id)
 	if err != nil {
 		return translatePromoCodeLookupError(err)
@@ -103,11 +103,11 @@ func (s *PromoCodeService) Delete(id uint) error {
 		return bizerr.New("promo_code.hasReservedUsage", "Promo code has reserved usage, cannot delete")
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(id, deletedBy)
 }
 
-// ValidateCode 验证优惠码是否可用于指定商品
-func (s *PromoCodeService) ValidateCode(code string, productIDs []uint, orderAmount int64) (*models.PromoCode, int64, error) {
+// ValidateCode 验证优惠码是否可用于指定商品，userID 非 0 时同时检查每用户使用次数
+func (s *PromoCodeService) ValidateCode(userID uint, code string, productIDs []uint, orderAmount int64) (*models.PromoCode, int64, error) {
 	code = strings.ToUpper(strings.TrimSpace(code))
 	if code == "" {
 		return nil, 0, bizerr.New("promo_code.codeRequired", "Promo code cannot be empty")
@@ -122,6 +122,12 @@ func (s *PromoCodeService) ValidateCode(code string, productIDs []uint, orderAmo
 		return nil, 0, bizerr.New("promo_code.unavailable", "Promo code is not available")
 	}
 
+	if userID != 0 {
+		if err := checkPromoCodePerUserLimit(s.repo, promoCode, userID); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	// 检查是否适用于指定商品
 	if len(promoCode.ProductIDs) > 0 && len(productIDs) > 0 {
 		applicable := false
@@ -161,6 +167,22 @@ func (s *PromoCodeService) Deduct(promoCodeID uint, orderNo string) error {
 	return s.repo.Deduct(promoCodeID, orderNo)
 }
 
+// checkPromoCodePerUserLimit 用户使用次数已达到优惠码的每用户上限时返回错误
+func checkPromoCodePerUserLimit(repo *repository.PromoCodeRepository, promoCode *models.PromoCode, userID uint) error {
+	if promoCode.PerUserLimit <= 0 {
+		return nil
+	}
+	used, err := repo.CountUserUsage(promoCode.ID, userID)
+	if err != nil {
+		return err
+	}
+	if used >= int64(promoCode.PerUserLimit) {
+		return bizerr.New("promo_code.perUserLimitReached", "You have reached the usage limit for this promo code").
+			WithParams(map[string]interface{}{"limit": promoCode.PerUserLimit})
+	}
+	return nil
+}
+
 func translatePromoCodeLookupError(err error) error {
 	if err == nil {
 		return nil
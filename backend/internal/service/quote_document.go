@@ -0,0 +1,219 @@
+package service
+
+import (
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/money"
+)
+
+// quoteDocumentItem 报价单行项目
+type quoteDocumentItem struct {
+	Name      string
+	SKU       string
+	Quantity  int
+	UnitPrice string
+	LineTotal string
+}
+
+// quoteDocumentData 报价单模板数据，公司信息沿用账单配置
+type quoteDocumentData struct {
+	DocumentCompany
+
+	QuoteNo    string
+	Title      string
+	Status     string
+	IssueDate  string
+	ValidUntil string
+	Note       string
+
+	CustomerName  string
+	CustomerEmail string
+
+	Items          []quoteDocumentItem
+	Subtotal       string
+	DiscountAmount string
+	HasDiscount    bool
+	TotalAmount    string
+	Currency       string
+
+	AppName      string
+	PrintBtnText string
+	CloseBtnText string
+}
+
+func formatQuoteAmount(amount int64, currency string) string {
+	return money.MinorToString(amount) + " " + currency
+}
+
+// RenderQuoteHTML 按内置或自定义模板渲染报价单 HTML，在线查看与 PDF 使用同一模板；
+// inlineAssets 为 true 时模板资源内联为 data URI，供 PDF 渲染使用
+func RenderQuoteHTML(cfg *config.Config, quote *models.Quote, inlineAssets bool) ([]byte, error) {
+	invoiceCfg := cfg.Order.Invoice
+	issued := quote.CreatedAt
+	if quote.SentAt != nil {
+		issued = *quote.SentAt
+	}
+	data := quoteDocumentData{
+		DocumentCompany: NewDocumentCompany(&invoiceCfg, inlineAssets),
+		QuoteNo:         quote.QuoteNo,
+		Title:           quote.Title,
+		Status:          string(quote.Status),
+		IssueDate:       issued.Format("2006-01-02"),
+		ValidUntil:      quote.ValidUntil.Format("2006-01-02 15:04"),
+		Note:            quote.Note,
+		Subtotal:        formatQuoteAmount(quote.Subtotal, quote.Currency),
+		DiscountAmount:  formatQuoteAmount(quote.DiscountAmount, quote.Currency),
+		HasDiscount:     quote.DiscountAmount > 0,
+		TotalAmount:     formatQuoteAmount(quote.TotalAmount, quote.Currency),
+		Currency:        quote.Currency,
+		AppName:         cfg.App.Name,
+		PrintBtnText:    "Print / Save as PDF",
+		CloseBtnText:    "Close",
+	}
+	if quote.User != nil {
+		data.CustomerName = quote.User.Name
+		data.CustomerEmail = quote.User.Email
+	}
+	for _, item := range quote.Items {
+		data.Items = append(data.Items, quoteDocumentItem{
+			Name:      item.Name,
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: formatQuoteAmount(item.UnitPrice, quote.Currency),
+			LineTotal: formatQuoteAmount(item.UnitPrice*int64(item.Quantity), quote.Currency),
+		})
+	}
+
+	tmplStr := builtinQuoteTemplate
+	if cfg.Order.Quote.CustomTemplate != "" {
+		tmplStr = cfg.Order.Quote.CustomTemplate
+	}
+	return RenderDocumentTemplate("quote", tmplStr, data, inlineAssets)
+}
+
+// builtinQuoteTemplate 内置报价单 HTML 模板，版式与内置账单一致
+const builtinQuoteTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Quote {{.QuoteNo}}</title>
+<style>
+*{margin:0;padding:0;box-sizing:border-box}
+body{font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;color:#1a1a1a;background:#f5f5f5;line-height:1.6}
+.quote-wrapper{max-width:800px;margin:20px auto;background:#fff;box-shadow:0 1px 10px rgba(0,0,0,.08);border-radius:8px;overflow:hidden}
+.quote-header{display:flex;justify-content:space-between;align-items:flex-start;padding:40px 40px 30px;border-bottom:2px solid #f0f0f0}
+.company-info h1{font-size:22px;font-weight:700;margin-bottom:4px}
+.company-info p{font-size:13px;color:#666;margin:1px 0}
+.company-logo{max-height:60px;max-width:180px;object-fit:contain}
+.quote-title{text-align:right}
+.quote-title h2{font-size:28px;font-weight:300;color:#333;letter-spacing:2px;text-transform:uppercase}
+.quote-title p{font-size:13px;color:#666;margin:2px 0}
+.quote-body{padding:30px 40px}
+.info-row{display:flex;justify-content:space-between;margin-bottom:30px;gap:40px}
+.info-block h3{font-size:11px;text-transform:uppercase;letter-spacing:1px;color:#999;margin-bottom:8px;font-weight:600}
+.info-block p{font-size:14px;color:#333;margin:2px 0}
+table{width:100%;border-collapse:collapse;margin-bottom:30px}
+thead th{background:#fafafa;padding:12px 16px;text-align:left;font-size:12px;text-transform:uppercase;letter-spacing:.5px;color:#666;font-weight:600;border-bottom:2px solid #eee}
+tbody td{padding:12px 16px;font-size:14px;border-bottom:1px solid #f0f0f0}
+.item-name{font-weight:500}
+.item-sku{font-size:12px;color:#999;margin-top:2px}
+.num{text-align:right}
+.totals{margin-left:auto;width:280px}
+.totals .row{display:flex;justify-content:space-between;padding:8px 0;font-size:14px}
+.totals .row.discount{color:#e74c3c}
+.totals .row.total{border-top:2px solid #333;padding-top:12px;margin-top:4px;font-size:18px;font-weight:700}
+.quote-note{margin-top:30px;padding:16px;background:#fafafa;border-radius:6px;font-size:13px;color:#555;white-space:pre-wrap}
+.quote-footer{padding:20px 40px 30px;border-top:1px solid #f0f0f0;text-align:center}
+.quote-footer p{font-size:12px;color:#999}
+.no-print{text-align:center;padding:20px;background:#f5f5f5}
+.no-print button{padding:10px 28px;margin:0 8px;border:none;border-radius:6px;font-size:14px;cursor:pointer;transition:all .2s}
+.btn-print{background:#1a1a1a;color:#fff}
+.btn-print:hover{background:#333}
+.btn-close{background:#e5e5e5;color:#333}
+.btn-close:hover{background:#d5d5d5}
+@media print{
+  body{background:#fff}
+  .quote-wrapper{box-shadow:none;margin:0;border-radius:0}
+  .no-print{display:none!important}
+  .quote-header{padding:20px 30px 15px}
+  .quote-body{padding:15px 30px}
+}
+@media(max-width:600px){
+  .quote-header,.quote-body,.quote-footer{padding-left:20px;padding-right:20px}
+  .info-row{flex-direction:column;gap:20px}
+  .totals{width:100%}
+}
+</style>
+</head>
+<body>
+<div class="quote-wrapper">
+  <div class="quote-header">
+    <div class="company-info">
+      {{if .CompanyLogo}}<img src="{{.CompanyLogo}}" alt="Logo" class="company-logo"><br>{{end}}
+      <h1>{{if .CompanyName}}{{.CompanyName}}{{else}}{{.AppName}}{{end}}</h1>
+      {{if .CompanyAddress}}<p>{{.CompanyAddress}}</p>{{end}}
+      {{if .CompanyPhone}}<p>{{.CompanyPhone}}</p>{{end}}
+      {{if .CompanyEmail}}<p>{{.CompanyEmail}}</p>{{end}}
+      {{if .TaxID}}<p>Tax ID: {{.TaxID}}</p>{{end}}
+    </div>
+    <div class="quote-title">
+      <h2>Quote</h2>
+      <p><strong>{{.QuoteNo}}</strong></p>
+      <p>Date: {{.IssueDate}}</p>
+      <p>Valid until: {{.ValidUntil}}</p>
+    </div>
+  </div>
+
+  <div class="quote-body">
+    <div class="info-row">
+      <div class="info-block">
+        <h3>Prepared For</h3>
+        {{if .CustomerName}}<p><strong>{{.CustomerName}}</strong></p>{{end}}
+        {{if .CustomerEmail}}<p>{{.CustomerEmail}}</p>{{end}}
+      </div>
+      <div class="info-block" style="text-align:right">
+        <h3>Quote Info</h3>
+        {{if .Title}}<p>{{.Title}}</p>{{end}}
+        <p>Currency: {{.Currency}}</p>
+      </div>
+    </div>
+
+    <table>
+      <thead>
+        <tr><th>Item</th><th style="text-align:center">Qty</th><th class="num">Unit Price</th><th class="num">Amount</th></tr>
+      </thead>
+      <tbody>
+        {{range .Items}}
+        <tr>
+          <td><div class="item-name">{{.Name}}</div><div class="item-sku">{{.SKU}}</div></td>
+          <td style="text-align:center">{{.Quantity}}</td>
+          <td class="num">{{.UnitPrice}}</td>
+          <td class="num">{{.LineTotal}}</td>
+        </tr>
+        {{end}}
+      </tbody>
+    </table>
+
+    <div class="totals">
+      <div class="row"><span>Subtotal</span><span>{{.Subtotal}}</span></div>
+      {{if .HasDiscount}}<div class="row discount"><span>Discount</span><span>-{{.DiscountAmount}}</span></div>{{end}}
+      <div class="row total"><span>Total</span><span>{{.TotalAmount}}</span></div>
+    </div>
+
+    {{if .Note}}<div class="quote-note">{{.Note}}</div>{{end}}
+  </div>
+
+  {{if .FooterText}}
+  <div class="quote-footer">
+    <p>{{.FooterText}}</p>
+  </div>
+  {{end}}
+</div>
+
+<div class="no-print">
+  <button class="btn-print" onclick="window.print()">{{.PrintBtnText}}</button>
+  <button class="btn-close" onclick="window.close()">{{.CloseBtnText}}</button>
+</div>
+</body>
+</html>`
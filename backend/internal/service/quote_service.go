@@ -0,0 +1,510 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultQuoteValidDays = 14
+	maxQuoteValidDays     = 365
+	maxQuoteTitleLength   = 200
+	maxQuoteNoteLength    = 5000
+	maxQuoteReasonLength  = 500
+)
+
+var ErrQuoteNotFound = errors.New("quote not found")
+
+// QuoteInput 管理员创建或修改报价单的内容；行项目单价即报价价格，名称与商品类型未填写时按商品信息补全
+type QuoteInput struct {
+	UserID         uint
+	Title          string
+	Items          []models.QuoteItem
+	DiscountAmount int64
+	ValidUntil     *time.Time
+	Note           string
+	AdminNote      string
+}
+
+// QuoteService 报价单：管理员编制并发送给客户，客户在有效期内接受后按报价价格转为订单
+type QuoteService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	orderService *OrderService
+	emailService *EmailService
+}
+
+// NewQuoteService 创建报价单服务
+func NewQuoteService(db *gorm.DB, cfg *config.Config, orderService *OrderService, emailService *EmailService) *QuoteService {
+	return &QuoteService{db: db, cfg: cfg, orderService: orderService, emailService: emailService}
+}
+
+func (s *QuoteService) settings() config.QuoteConfig {
+	if s.cfg == nil {
+		return config.QuoteConfig{}
+	}
+	return s.cfg.Order.Quote
+}
+
+func (s *QuoteService) currency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+func quoteDisabledError() error {
+	return bizerr.New("quote.disabled", "Quotes are not available")
+}
+
+func quoteStatusError(quote *models.Quote) error {
+	return bizerr.Newf("quote.statusInvalid", "Quote is %s and cannot be changed", quote.Status).
+		WithParams(map[string]interface{}{"status": quote.Status})
+}
+
+func generateQuoteNo(now time.Time) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("QT%s%06d", now.Format("20060102"), n.Int64()), nil
+}
+
+func (s *QuoteService) uniqueQuoteNo() (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		quoteNo, err := generateQuoteNo(models.NowFunc())
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := s.db.Model(&models.Quote{}).Where("quote_no = ?", quoteNo).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return quoteNo, nil
+		}
+	}
+	return "", errors.New("failed to generate a unique quote number")
+}
+
+// buildQuote 校验并补全报价单内容，计算小计与应付金额
+func (s *QuoteService) buildQuote(quote *models.Quote, input QuoteInput) error {
+	input.Title = strings.TrimSpace(input.Title)
+	input.Note = strings.TrimSpace(input.Note)
+	input.AdminNote = strings.TrimSpace(input.AdminNote)
+	if len([]rune(input.Title)) > maxQuoteTitleLength {
+		return bizerr.Newf("quote.titleTooLong", "Title cannot exceed %d characters", maxQuoteTitleLength).
+			WithParams(map[string]interface{}{"max": maxQuoteTitleLength})
+	}
+	if len([]rune(input.Note)) > maxQuoteNoteLength || len([]rune(input.AdminNote)) > maxQuoteNoteLength {
+		return bizerr.Newf("quote.noteTooLong", "Note cannot exceed %d characters", maxQuoteNoteLength).
+			WithParams(map[string]interface{}{"max": maxQuoteNoteLength})
+	}
+
+	var user models.User
+	if err := s.db.First(&user, input.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return newOrderUserNotFoundError()
+		}
+		return err
+	}
+
+	if len(input.Items) == 0 {
+		return bizerr.New("order.itemsEmpty", "Order items cannot be empty")
+	}
+	if len(input.Items) > s.cfg.Order.MaxOrderItems {
+		return bizerr.Newf("order.tooManyItems", "Order items cannot exceed %d", s.cfg.Order.MaxOrderItems).
+			WithParams(map[string]interface{}{"max": s.cfg.Order.MaxOrderItems})
+	}
+	items := make([]models.QuoteItem, 0, len(input.Items))
+	var subtotal int64
+	for _, item := range input.Items {
+		item.SKU = strings.TrimSpace(item.SKU)
+		item.Name = strings.TrimSpace(item.Name)
+		if item.SKU == "" {
+			return bizerr.New("order.skuEmpty", "Product SKU cannot be empty")
+		}
+		if item.Quantity <= 0 {
+			return bizerr.New("order.quantityInvalid", "Quantity must be greater than 0")
+		}
+		if item.Quantity > s.cfg.Order.MaxItemQuantity {
+			return bizerr.Newf("order.quantityExceeded", "Quantity cannot exceed %d", s.cfg.Order.MaxItemQuantity).
+				WithParams(map[string]interface{}{"max": s.cfg.Order.MaxItemQuantity})
+		}
+		if item.UnitPrice < 0 {
+			return newOrderUnitPriceNegativeError(item.SKU)
+		}
+		var product models.Product
+		err := s.db.Where("sku = ?", item.SKU).First(&product).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		found := err == nil
+		if !found && item.Name == "" {
+			return bizerr.Newf("order.productNotFound", "Product %s does not exist", item.SKU).
+				WithParams(map[string]interface{}{"sku": item.SKU})
+		}
+		if found {
+			if item.Name == "" {
+				item.Name = product.Name
+			}
+			if item.ProductType == "" {
+				item.ProductType = string(product.ProductType)
+			}
+		}
+		if item.ProductType == "" {
+			item.ProductType = string(models.ProductTypePhysical)
+		}
+		// 虚拟商品必须在报价时指定虚拟库存，避免客户接受后才发现无法建单
+		if item.ProductType == string(models.ProductTypeVirtual) && item.VirtualInventoryID == nil {
+			return newOrderVirtualInventoryRequiredError(item.SKU)
+		}
+		subtotal += item.UnitPrice * int64(item.Quantity)
+		items = append(items, item)
+	}
+	if input.DiscountAmount < 0 || input.DiscountAmount > subtotal {
+		return newOrderDiscountInvalidError(subtotal)
+	}
+
+	now := models.NowFunc()
+	validUntil := input.ValidUntil
+	if validUntil == nil {
+		days := s.settings().DefaultValidDays
+		if days <= 0 {
+			days = defaultQuoteValidDays
+		}
+		until := now.AddDate(0, 0, days)
+		validUntil = &until
+	}
+	if !validUntil.After(now) || validUntil.After(now.AddDate(0, 0, maxQuoteValidDays)) {
+		return bizerr.Newf("quote.validUntilInvalid", "Valid until must be in the future and within %d days", maxQuoteValidDays).
+			WithParams(map[string]interface{}{"max": maxQuoteValidDays})
+	}
+
+	quote.UserID = user.ID
+	quote.Title = input.Title
+	quote.Items = items
+	quote.Note = input.Note
+	quote.AdminNote = input.AdminNote
+	quote.Subtotal = subtotal
+	quote.DiscountAmount = input.DiscountAmount
+	quote.TotalAmount = subtotal - input.DiscountAmount
+	quote.ValidUntil = *validUntil
+	return nil
+}
+
+// Create 创建报价单草稿
+func (s *QuoteService) Create(input QuoteInput, adminID uint) (*models.Quote, error) {
+	if !s.settings().Enabled {
+		return nil, quoteDisabledError()
+	}
+	quote := &models.Quote{
+		Status:    models.QuoteStatusDraft,
+		Currency:  s.currency(),
+		CreatedBy: adminID,
+	}
+	if err := s.buildQuote(quote, input); err != nil {
+		return nil, err
+	}
+	quoteNo, err := s.uniqueQuoteNo()
+	if err != nil {
+		return nil, err
+	}
+	quote.QuoteNo = quoteNo
+	if err := s.db.Create(quote).Error; err != nil {
+		return nil, err
+	}
+	return s.Get(quote.ID)
+}
+
+// Update 修改报价单草稿；已发送的报价单价格已锁定，需撤回后重新创建
+func (s *QuoteService) Update(id uint, input QuoteInput) (*models.Quote, error) {
+	quote, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != models.QuoteStatusDraft {
+		return nil, quoteStatusError(quote)
+	}
+	if err := s.buildQuote(quote, input); err != nil {
+		return nil, err
+	}
+	result := s.db.Model(&models.Quote{}).
+		Where("id = ? AND status = ?", id, models.QuoteStatusDraft).
+		Select("user_id", "title", "items", "note", "admin_note", "subtotal", "discount_amount", "total_amount", "valid_until").
+		Updates(quote)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, quoteStatusError(quote)
+	}
+	return s.Get(id)
+}
+
+// transition 按当前状态条件更新报价单，并发操作只有一个能成功
+func (s *QuoteService) transition(quote *models.Quote, from models.QuoteStatus, updates map[string]interface{}) error {
+	result := s.db.Model(&models.Quote{}).Where("id = ? AND status = ?", quote.ID, from).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		current, err := s.Get(quote.ID)
+		if err != nil {
+			return err
+		}
+		return quoteStatusError(current)
+	}
+	return nil
+}
+
+// Send 将草稿发送给客户，可选同时发送邮件通知
+func (s *QuoteService) Send(id uint, notify bool) (*models.Quote, error) {
+	if !s.settings().Enabled {
+		return nil, quoteDisabledError()
+	}
+	quote, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != models.QuoteStatusDraft {
+		return nil, quoteStatusError(quote)
+	}
+	if !quote.ValidUntil.After(models.NowFunc()) {
+		return nil, bizerr.Newf("quote.validUntilInvalid", "Valid until must be in the future and within %d days", maxQuoteValidDays).
+			WithParams(map[string]interface{}{"max": maxQuoteValidDays})
+	}
+	if err := s.transition(quote, models.QuoteStatusDraft, map[string]interface{}{
+		"status":  models.QuoteStatusSent,
+		"sent_at": models.NowFunc(),
+	}); err != nil {
+		return nil, err
+	}
+	quote, err = s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if notify && s.emailService != nil && quote.User != nil && quote.User.Email != "" {
+		go s.emailService.SendQuoteEmail(quote, quote.User)
+	}
+	return quote, nil
+}
+
+// Cancel 管理员撤回草稿或未处理的报价单
+func (s *QuoteService) Cancel(id uint) (*models.Quote, error) {
+	quote, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != models.QuoteStatusDraft && quote.Status != models.QuoteStatusSent {
+		return nil, quoteStatusError(quote)
+	}
+	if err := s.transition(quote, quote.Status, map[string]interface{}{
+		"status":       models.QuoteStatusCancelled,
+		"cancelled_at": models.NowFunc(),
+	}); err != nil {
+		return nil, err
+	}
+	return s.Get(id)
+}
+
+// expireDue 将超过有效期仍未处理的报价单标记为已过期，在读取时惰性执行
+func (s *QuoteService) expireDue() {
+	s.db.Model(&models.Quote{}).
+		Where("status = ? AND valid_until < ?", models.QuoteStatusSent, models.NowFunc()).
+		Update("status", models.QuoteStatusExpired)
+}
+
+// List 管理员报价单列表，search 匹配报价单号或标题
+func (s *QuoteService) List(status, search string, userID uint, page, limit int) ([]models.Quote, int64, error) {
+	s.expireDue()
+	query := s.db.Model(&models.Quote{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if search = strings.TrimSpace(search); search != "" {
+		query = query.Where("quote_no LIKE ? OR title LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var quotes []models.Quote
+	if err := query.Preload("User").Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&quotes).Error; err != nil {
+		return nil, 0, err
+	}
+	return quotes, total, nil
+}
+
+// Get 报价单详情
+func (s *QuoteService) Get(id uint) (*models.Quote, error) {
+	var quote models.Quote
+	if err := s.db.Preload("User").First(&quote, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, err
+	}
+	if quote.IsExpiredAt(models.NowFunc()) {
+		s.expireDue()
+		quote.Status = models.QuoteStatusExpired
+	}
+	return &quote, nil
+}
+
+// ListForUser 用户可见的报价单（不含草稿）
+func (s *QuoteService) ListForUser(userID uint, page, limit int) ([]models.Quote, int64, error) {
+	if !s.settings().Enabled {
+		return nil, 0, quoteDisabledError()
+	}
+	s.expireDue()
+	query := s.db.Model(&models.Quote{}).Where("user_id = ? AND status <> ?", userID, models.QuoteStatusDraft)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var quotes []models.Quote
+	if err := query.Omit("admin_note").Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&quotes).Error; err != nil {
+		return nil, 0, err
+	}
+	return quotes, total, nil
+}
+
+// GetForUser 用户查看自己的报价单，草稿与他人的报价单均视为不存在
+func (s *QuoteService) GetForUser(userID uint, quoteNo string) (*models.Quote, error) {
+	if !s.settings().Enabled {
+		return nil, quoteDisabledError()
+	}
+	var quote models.Quote
+	if err := s.db.Preload("User").Where("quote_no = ? AND user_id = ? AND status <> ?", quoteNo, userID, models.QuoteStatusDraft).
+		First(&quote).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, err
+	}
+	if quote.IsExpiredAt(models.NowFunc()) {
+		s.expireDue()
+		quote.Status = models.QuoteStatusExpired
+	}
+	quote.AdminNote = ""
+	return &quote, nil
+}
+
+// Accept 客户接受报价单，按报价锁定的单价与折扣生成待付款订单；
+// 先以条件更新占用报价单防止重复接受，建单失败时恢复为已发送
+func (s *QuoteService) Accept(userID uint, quoteNo, remark string) (*models.Quote, *models.Order, error) {
+	quote, err := s.GetForUser(userID, quoteNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if quote.Status != models.QuoteStatusSent {
+		return nil, nil, quoteStatusError(quote)
+	}
+	if !strings.EqualFold(quote.Currency, s.currency()) {
+		return nil, nil, bizerr.New("quote.currencyMismatch", "Quote currency no longer matches the store currency").
+			WithParams(map[string]interface{}{"currency": quote.Currency})
+	}
+	if err := requirePolicyConsentTx(s.db, userID); err != nil {
+		return nil, nil, err
+	}
+	acceptedAt := models.NowFunc()
+	if err := s.transition(quote, models.QuoteStatusSent, map[string]interface{}{
+		"status":      models.QuoteStatusAccepted,
+		"accepted_at": acceptedAt,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]AdminOrderItem, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		unitPrice := item.UnitPrice
+		items = append(items, AdminOrderItem{
+			SKU:                item.SKU,
+			Name:               item.Name,
+			Quantity:           item.Quantity,
+			UnitPrice:          &unitPrice,
+			Attributes:         item.Attributes,
+			ProductType:        item.ProductType,
+			VirtualInventoryID: item.VirtualInventoryID,
+		})
+	}
+	userIDCopy := quote.UserID
+	order, err := s.orderService.CreateAdminOrder(AdminOrderRequest{
+		UserID:         &userIDCopy,
+		Items:          items,
+		Remark:         strings.TrimSpace(remark),
+		AdminRemark:    "Quote " + quote.QuoteNo,
+		DiscountAmount: quote.DiscountAmount,
+		AdminID:        quote.CreatedBy,
+	})
+	if err != nil {
+		if revertErr := s.db.Model(&models.Quote{}).Where("id = ?", quote.ID).Updates(map[string]interface{}{
+			"status":      models.QuoteStatusSent,
+			"accepted_at": nil,
+		}).Error; revertErr != nil {
+			return nil, nil, fmt.Errorf("%w (failed to reopen quote: %v)", err, revertErr)
+		}
+		return nil, nil, err
+	}
+
+	if err := s.db.Model(&models.Quote{}).Where("id = ?", quote.ID).Updates(map[string]interface{}{
+		"order_id": order.ID,
+		"order_no": order.OrderNo,
+	}).Error; err != nil {
+		return nil, nil, err
+	}
+	recordOrderEventDB(s.db, &models.OrderEvent{
+		OrderID:      order.ID,
+		OrderNo:      order.OrderNo,
+		Type:         models.OrderEventTypeRemark,
+		Source:       "quote.accept",
+		OperatorType: models.OrderEventOperatorUser,
+		OperatorID:   &userIDCopy,
+		Message:      "Created from quote " + quote.QuoteNo,
+		Data:         encodeOrderEventData(map[string]interface{}{"quote_id": quote.ID, "quote_no": quote.QuoteNo}),
+		CreatedAt:    acceptedAt,
+	})
+
+	quote, err = s.GetForUser(userID, quoteNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quote, order, nil
+}
+
+// Decline 客户拒绝报价单
+func (s *QuoteService) Decline(userID uint, quoteNo, reason string) (*models.Quote, error) {
+	reason = strings.TrimSpace(reason)
+	if len([]rune(reason)) > maxQuoteReasonLength {
+		return nil, bizerr.Newf("quote.reasonTooLong", "Reason cannot exceed %d characters", maxQuoteReasonLength).
+			WithParams(map[string]interface{}{"max": maxQuoteReasonLength})
+	}
+	quote, err := s.GetForUser(userID, quoteNo)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != models.QuoteStatusSent {
+		return nil, quoteStatusError(quote)
+	}
+	if err := s.transition(quote, models.QuoteStatusSent, map[string]interface{}{
+		"status":         models.QuoteStatusDeclined,
+		"declined_at":    models.NowFunc(),
+		"decline_reason": reason,
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetForUser(userID, quoteNo)
+}
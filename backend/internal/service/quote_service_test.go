@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestQuoteAcceptanceLocksQuotedPrices(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.OrderEvent{}, &models.Quote{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	orderSvc.cfg.Order.Currency = "USD"
+	quoteSvc := NewQuoteService(db, orderSvc.cfg, orderSvc, nil)
+
+	customer := &models.User{Email: "buyer@example.com", Name: "Buyer"}
+	if err := db.Create(customer).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	product := &models.Product{SKU: "SKU-Q1", Name: "Widget", Price: 2000, ProductType: models.ProductTypePhysical}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	input := QuoteInput{
+		UserID:         customer.ID,
+		Title:          "Bulk widgets",
+		Items:          []models.QuoteItem{{SKU: "SKU-Q1", Quantity: 5, UnitPrice: 1500}},
+		DiscountAmount: 500,
+	}
+
+	_, err := quoteSvc.Create(input, 1)
+	requireOrderBizErr(t, err, "quote.disabled")
+	orderSvc.cfg.Order.Quote.Enabled = true
+
+	past := models.NowFunc().Add(-time.Hour)
+	_, err = quoteSvc.Create(QuoteInput{UserID: customer.ID, Items: input.Items, ValidUntil: &past}, 1)
+	requireOrderBizErr(t, err, "quote.validUntilInvalid")
+	_, err = quoteSvc.Create(QuoteInput{UserID: customer.ID, Items: input.Items, DiscountAmount: 7501}, 1)
+	requireOrderBizErr(t, err, "order.discountInvalid")
+
+	quote, err := quoteSvc.Create(input, 1)
+	if err != nil {
+		t.Fatalf("create quote: %v", err)
+	}
+	if quote.Status != models.QuoteStatusDraft || quote.Subtotal != 7500 || quote.TotalAmount != 7000 || quote.Items[0].Name != "Widget" {
+		t.Fatalf("unexpected quote: %+v", quote)
+	}
+
+	// 草稿对客户不可见
+	if _, err := quoteSvc.GetForUser(customer.ID, quote.QuoteNo); err != ErrQuoteNotFound {
+		t.Fatalf("expected draft to be hidden, got %v", err)
+	}
+	if _, err := quoteSvc.Send(quote.ID, false); err != nil {
+		t.Fatalf("send quote: %v", err)
+	}
+	_, err = quoteSvc.Update(quote.ID, input)
+	requireOrderBizErr(t, err, "quote.statusInvalid")
+	if _, err := quoteSvc.GetForUser(customer.ID+1, quote.QuoteNo); err != ErrQuoteNotFound {
+		t.Fatalf("expected other users to be denied, got %v", err)
+	}
+
+	// 报价后商品涨价，订单仍按报价价格生成
+	db.Model(product).Update("price", 3000)
+	accepted, order, err := quoteSvc.Accept(customer.ID, quote.QuoteNo, "Please ship together")
+	if err != nil {
+		t.Fatalf("accept quote: %v", err)
+	}
+	if accepted.Status != models.QuoteStatusAccepted || accepted.OrderNo != order.OrderNo || accepted.AdminNote != "" {
+		t.Fatalf("unexpected accepted quote: %+v", accepted)
+	}
+	if order.TotalAmount != 7000 || order.DiscountAmount != 500 || order.Items[0].UnitPrice != 1500 || order.Status != models.OrderStatusPendingPayment {
+		t.Fatalf("order did not keep quoted prices: total=%d discount=%d unit=%d status=%s", order.TotalAmount, order.DiscountAmount, order.Items[0].UnitPrice, order.Status)
+	}
+	if order.UserID == nil || *order.UserID != customer.ID || order.Remark != "Please ship together" {
+		t.Fatalf("unexpected order owner or remark: %+v", order)
+	}
+	_, _, err = quoteSvc.Accept(customer.ID, quote.QuoteNo, "")
+	requireOrderBizErr(t, err, "quote.statusInvalid")
+
+	// 超过有效期的报价单不能再接受
+	expiring, err := quoteSvc.Create(input, 1)
+	if err != nil {
+		t.Fatalf("create second quote: %v", err)
+	}
+	if _, err := quoteSvc.Send(expiring.ID, false); err != nil {
+		t.Fatalf("send second quote: %v", err)
+	}
+	db.Model(&models.Quote{}).Where("id = ?", expiring.ID).Update("valid_until", models.NowFunc().Add(-time.Minute))
+	_, _, err = quoteSvc.Accept(customer.ID, expiring.QuoteNo, "")
+	requireOrderBizErr(t, err, "quote.statusInvalid")
+	var stored models.Quote
+	db.First(&stored, expiring.ID)
+	if stored.Status != models.QuoteStatusExpired {
+		t.Fatalf("expected quote to be marked expired, got %s", stored.Status)
+	}
+
+	html, err := RenderQuoteHTML(orderSvc.cfg, accepted, false)
+	if err != nil {
+		t.Fatalf("render quote: %v", err)
+	}
+	for _, want := range []string{quote.QuoteNo, "15.00 USD", "75.00 USD", "70.00 USD"} {
+		if !bytes.Contains(html, []byte(want)) {
+			t.Fatalf("rendered quote missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// RefundRequestTicketCategory 退款申请自动创建的工单分类
+	RefundRequestTicketCategory  = "refund"
+	maxRefundRequestReasonLength = 1000
+	maxRefundRequestNoteLength   = 1000
+)
+
+var (
+	ErrRefundRequestNotFound = errors.New("refund request not found")
+	ErrRefundOrderNotFound   = errors.New("order not found")
+)
+
+var refundRequestResolutions = map[string]bool{
+	models.RefundResolutionFullRefund:    true,
+	models.RefundResolutionPartialRefund: true,
+	models.RefundResolutionReplacement:   true,
+	models.RefundResolutionOther:         true,
+}
+
+// CreateRefundRequestInput 用户提交的退款申请
+type CreateRefundRequestInput struct {
+	Reason     string
+	Resolution string
+	Evidence   []string // 已校验的凭证URL
+}
+
+// RefundRequestService 用户退款申请：提交时自动创建关联工单，管理员审核通过后调用退款引擎
+type RefundRequestService struct {
+	db            *gorm.DB
+	refundService *RefundService
+}
+
+// NewRefundRequestService 创建退款申请服务
+func NewRefundRequestService(db *gorm.DB, refundService *RefundService) *RefundRequestService {
+	return &RefundRequestService{db: db, refundService: refundService}
+}
+
+func generateRefundTicketNo() string {
+	return fmt.Sprintf("TK%s%04d", time.Now().Format("20060102150405"), time.Now().UnixNano()%10000)
+}
+
+// Create 提交退款申请，同一订单同时只能有一个待审核的申请
+func (s *RefundRequestService) Create(userID uint, orderNo string, input CreateRefundRequestInput) (*models.RefundRequest, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, bizerr.New("order.refundRequestReasonRequired", "Please describe the reason for the refund")
+	}
+	if len([]rune(reason)) > maxRefundRequestReasonLength {
+		return nil, bizerr.Newf("order.refundRequestReasonTooLong", "Refund reason cannot exceed %d characters", maxRefundRequestReasonLength).
+			WithParams(map[string]interface{}{"max": maxRefundRequestReasonLength})
+	}
+	if !refundRequestResolutions[input.Resolution] {
+		return nil, bizerr.New("order.refundRequestResolutionInvalid", "Invalid desired resolution")
+	}
+
+	var order models.Order
+	if err := s.db.Where("order_no = ? AND user_id = ?", orderNo, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRefundOrderNotFound
+		}
+		return nil, err
+	}
+	if !RefundableOrderStatuses[order.Status] {
+		return nil, bizerr.Newf("order.refundRequestStatusInvalid", "Refunds cannot be requested for this order (current status: %s)", order.Status).
+			WithParams(map[string]interface{}{"status": order.Status})
+	}
+
+	var user models.User
+	if err := s.db.Select("id, name").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	request := &models.RefundRequest{
+		OrderID:    order.ID,
+		OrderNo:    order.OrderNo,
+		UserID:     userID,
+		Reason:     reason,
+		Resolution: input.Resolution,
+		Evidence:   input.Evidence,
+		Status:     models.RefundRequestStatusPending,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var pending int64
+		if err := tx.Model(&models.RefundRequest{}).
+			Where("order_id = ? AND status = ?", order.ID, models.RefundRequestStatusPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return bizerr.New("order.refundRequestPending", "A refund request for this order is already under review")
+		}
+
+		content := buildRefundRequestMessage(request)
+		now := time.Now()
+		ticket := &models.Ticket{
+			TicketNo:           generateRefundTicketNo(),
+			UserID:             userID,
+			Subject:            fmt.Sprintf("Refund request for order %s", order.OrderNo),
+			Content:            content,
+			Category:           RefundRequestTicketCategory,
+			Priority:           models.TicketPriorityNormal,
+			Status:             models.TicketStatusOpen,
+			LastMessageAt:      &now,
+			LastMessagePreview: truncateRefundText(reason, 200),
+			LastMessageBy:      "user",
+			UnreadCountAdmin:   1,
+		}
+		if err := tx.Create(ticket).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.TicketMessage{
+			TicketID:     ticket.ID,
+			SenderType:   "user",
+			SenderID:     userID,
+			SenderName:   user.Name,
+			Content:      content,
+			ContentType:  "text",
+			IsReadByUser: true,
+		}).Error; err != nil {
+			return err
+		}
+		// 自动授权客服查看订单
+		if err := tx.Create(&models.TicketOrderAccess{
+			TicketID:  ticket.ID,
+			OrderID:   order.ID,
+			GrantedBy: userID,
+			CanView:   true,
+		}).Error; err != nil {
+			return err
+		}
+		if err := repository.NewOrderRepository(tx).RefreshSharedToSupport(order.ID); err != nil {
+			return err
+		}
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"order_id": order.ID,
+			"order_no": order.OrderNo,
+		})
+		if err := tx.Create(&models.TicketMessage{
+			TicketID:     ticket.ID,
+			SenderType:   "user",
+			SenderID:     userID,
+			SenderName:   user.Name,
+			Content:      fmt.Sprintf("Shared order %s", order.OrderNo),
+			ContentType:  "order",
+			Metadata:     models.JSON(metadata),
+			IsReadByUser: true,
+		}).Error; err != nil {
+			return err
+		}
+
+		request.TicketID = &ticket.ID
+		request.TicketNo = ticket.TicketNo
+		return tx.Create(request).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// buildRefundRequestMessage 组合工单首条消息：期望处理方式 + 原因 + 凭证
+func buildRefundRequestMessage(request *models.RefundRequest) string {
+	var builder strings.Builder
+	builder.WriteString("**Refund request** · ")
+	builder.WriteString(request.Resolution)
+	builder.WriteString("\n\n")
+	builder.WriteString(request.Reason)
+	for _, fileURL := range request.Evidence {
+		builder.WriteString("\n\n![evidence](")
+		builder.WriteString(fileURL)
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
+
+func truncateRefundText(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen]) + "..."
+}
+
+// ListForUser 用户某个订单的退款申请记录
+func (s *RefundRequestService) ListForUser(userID uint, orderNo string) ([]models.RefundRequest, error) {
+	var requests []models.RefundRequest
+	err := s.db.Where("user_id = ? AND order_no = ?", userID, orderNo).
+		Order("id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// List 管理端审核队列，status 为空时返回全部
+func (s *RefundRequestService) List(status string, page, limit int) ([]models.RefundRequest, int64, error) {
+	query := s.db.Model(&models.RefundRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var requests []models.RefundRequest
+	err := query.Preload("User").Order("id ASC").
+		Offset((page - 1) * limit).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+// Get 退款申请详情
+func (s *RefundRequestService) Get(id uint) (*models.RefundRequest, error) {
+	var request models.RefundRequest
+	if err := s.db.Preload("User").First(&request, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRefundRequestNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// claim 将待审核的申请原子地标记为审核结果，防止重复审核
+func (s *RefundRequestService) claim(id, adminID uint, status models.RefundRequestStatus, note string) (*models.RefundRequest, error) {
+	request, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if len([]rune(note)) > maxRefundRequestNoteLength {
+		return nil, bizerr.Newf("order.refundRequestNoteTooLong", "Review note cannot exceed %d characters", maxRefundRequestNoteLength).
+			WithParams(map[string]interface{}{"max": maxRefundRequestNoteLength})
+	}
+	now := time.Now()
+	result := s.db.Model(&models.RefundRequest{}).
+		Where("id = ? AND status = ?", id, models.RefundRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": adminID,
+			"reviewed_at": now,
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, bizerr.New("order.refundRequestNotPending", "This refund request has already been reviewed").
+			WithParams(map[string]interface{}{"status": request.Status})
+	}
+	request.Status = status
+	request.ReviewedBy = &adminID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+	return request, nil
+}
+
+// Approve 批准退款申请并通过退款引擎执行退款，退款失败时申请恢复为待审核
+func (s *RefundRequestService) Approve(id, adminID uint, note string) (*models.RefundRequest, *models.Order, *OrderRefundOutcome, error) {
+	note = strings.TrimSpace(note)
+	request, err := s.claim(id, adminID, models.RefundRequestStatusApproved, note)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	revert := func() {
+		s.db.Model(&models.RefundRequest{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+			"status":      models.RefundRequestStatusPending,
+			"reviewed_by": nil,
+			"reviewed_at": nil,
+			"review_note": "",
+		})
+	}
+	var order models.Order
+	if err := s.db.First(&order, request.OrderID).Error; err != nil {
+		revert()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, ErrRefundOrderNotFound
+		}
+		return nil, nil, nil, err
+	}
+	outcome, err := s.refundService.RefundOrder(&order, fmt.Sprintf("Refund request #%d: %s", request.ID, truncateRefundText(request.Reason, 200)))
+	if err != nil {
+		revert()
+		return nil, nil, nil, err
+	}
+
+	request.RefundStatus = string(outcome.StatusAfter)
+	request.TransactionID = outcome.Result.TransactionID
+	s.db.Model(&models.RefundRequest{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+		"refund_status":  request.RefundStatus,
+		"transaction_id": request.TransactionID,
+	})
+
+	message := "Your refund request has been approved and the refund has been issued."
+	if outcome.StatusAfter == models.OrderStatusRefundPending {
+		message = "Your refund request has been approved. The refund is being processed."
+	}
+	s.notifyTicket(request, adminID, message, models.TicketStatusResolved)
+	return request, &order, outcome, nil
+}
+
+// Reject 拒绝退款申请，需填写拒绝原因并同步到关联工单
+func (s *RefundRequestService) Reject(id, adminID uint, note string) (*models.RefundRequest, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, bizerr.New("order.refundRequestNoteRequired", "Please provide a reason for rejecting the refund request")
+	}
+	request, err := s.claim(id, adminID, models.RefundRequestStatusRejected, note)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyTicket(request, adminID, "Your refund request has been declined.", models.TicketStatusProcessing)
+	return request, nil
+}
+
+// notifyTicket 将审核结果作为客服消息写入关联工单
+func (s *RefundRequestService) notifyTicket(request *models.RefundRequest, adminID uint, message string, status models.TicketStatus) {
+	if request.TicketID == nil {
+		return
+	}
+	if request.ReviewNote != "" {
+		message += "\n\n" + request.ReviewNote
+	}
+	var admin models.User
+	s.db.Select("id, name").First(&admin, adminID)
+
+	now := time.Now()
+	s.db.Create(&models.TicketMessage{
+		TicketID:      *request.TicketID,
+		SenderType:    "admin",
+		SenderID:      adminID,
+		SenderName:    admin.Name,
+		Content:       message,
+		ContentType:   "text",
+		IsReadByAdmin: true,
+	})
+	updates := map[string]interface{}{
+		"status":               status,
+		"last_message_at":      now,
+		"last_message_preview": truncateRefundText(message, 200),
+		"last_message_by":      "admin",
+		"unread_count_user":    gorm.Expr("unread_count_user + 1"),
+	}
+	if status == models.TicketStatusResolved {
+		updates["closed_at"] = now
+	}
+	s.db.Model(&models.Ticket{}).Where("id = ?", *request.TicketID).Updates(updates)
+}
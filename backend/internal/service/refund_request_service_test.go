@@ -0,0 +1,122 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+func requireOrderSharedToSupport(t *testing.T, db *gorm.DB, orderID uint) {
+	t.Helper()
+	var order models.Order
+	if err := db.First(&order, orderID).Error; err != nil {
+		t.Fatalf("reload order: %v", err)
+	}
+	if !order.SharedToSupport {
+		t.Fatalf("expected order %s to be flagged as shared to support", order.OrderNo)
+	}
+}
+
+func TestRefundRequestCreatesTicketAndApprovalRefundsOrder(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Order{},
+		&models.OrderPaymentMethod{},
+		&models.PaymentMethod{},
+		&models.PaymentMethodStorageEntry{},
+		&models.Ticket{},
+		&models.TicketMessage{},
+		&models.TicketOrderAccess{},
+		&models.RefundRequest{},
+		&models.VendorLedgerEntry{},
+		&models.RevenueSchedule{},
+		&models.RevenueScheduleEntry{},
+		&models.OrderRefund{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	buyer := &models.User{UUID: "buyer-uuid", Email: "buyer@example.com", Name: "Buyer", Role: "user", IsActive: true}
+	admin := &models.User{UUID: "admin-uuid", Email: "admin@example.com", Name: "Admin", Role: "admin", IsActive: true}
+	for _, user := range []*models.User{buyer, admin} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	pm := &models.PaymentMethod{
+		Name:    "Script Pay",
+		Type:    models.PaymentMethodTypeCustom,
+		Enabled: true,
+		Script:  `function onRefund(order, config) { return { success: true, transaction_id: "RF-1" } }`,
+	}
+	if err := db.Create(pm).Error; err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:     "ORD-REFUND-1",
+		UserID:      &buyer.ID,
+		Status:      models.OrderStatusCompleted,
+		Items:       []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+		TotalAmount: 1000,
+		Currency:    "CNY",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := db.Create(&models.OrderPaymentMethod{OrderID: order.ID, PaymentMethodID: pm.ID}).Error; err != nil {
+		t.Fatalf("create order payment method: %v", err)
+	}
+
+	svc := NewRefundRequestService(db, NewRefundService(db, nil, NewJSRuntimeService(db, &config.Config{})))
+
+	_, err := svc.Create(buyer.ID, order.OrderNo, CreateRefundRequestInput{Reason: "Broken", Resolution: "store_credit"})
+	requireBizErr(t, err, "order.refundRequestResolutionInvalid")
+
+	request, err := svc.Create(buyer.ID, order.OrderNo, CreateRefundRequestInput{
+		Reason:     "Arrived broken",
+		Resolution: models.RefundResolutionFullRefund,
+		Evidence:   []string{"https://shop.example.com/uploads/tickets/intake/a.png"},
+	})
+	if err != nil {
+		t.Fatalf("create refund request: %v", err)
+	}
+	if request.TicketID == nil {
+		t.Fatalf("expected refund request to be linked to a ticket")
+	}
+	var access models.TicketOrderAccess
+	if err := db.Where("ticket_id = ? AND order_id = ?", *request.TicketID, order.ID).First(&access).Error; err != nil {
+		t.Fatalf("expected order to be shared with support: %v", err)
+	}
+	requireOrderSharedToSupport(t, db, order.ID)
+
+	_, err = svc.Create(buyer.ID, order.OrderNo, CreateRefundRequestInput{Reason: "Again", Resolution: models.RefundResolutionOther})
+	requireBizErr(t, err, "order.refundRequestPending")
+	_, err = svc.Reject(request.ID, admin.ID, " ")
+	requireBizErr(t, err, "order.refundRequestNoteRequired")
+
+	approved, refundedOrder, outcome, err := svc.Approve(request.ID, admin.ID, "Sorry about that")
+	if err != nil {
+		t.Fatalf("approve refund request: %v", err)
+	}
+	if approved.Status != models.RefundRequestStatusApproved || approved.TransactionID != "RF-1" {
+		t.Fatalf("unexpected approved request: status=%s tx=%q", approved.Status, approved.TransactionID)
+	}
+	if outcome.StatusAfter != models.OrderStatusRefunded || refundedOrder.Status != models.OrderStatusRefunded {
+		t.Fatalf("expected order to be refunded, got %s", outcome.StatusAfter)
+	}
+	// 退款保存订单时不应覆盖分享标记
+	requireOrderSharedToSupport(t, db, order.ID)
+	var ticket models.Ticket
+	if err := db.First(&ticket, *request.TicketID).Error; err != nil {
+		t.Fatalf("load ticket: %v", err)
+	}
+	if ticket.Status != models.TicketStatusResolved || ticket.UnreadCountUser != 1 {
+		t.Fatalf("expected resolved ticket with admin reply, got status=%s unread=%d", ticket.Status, ticket.UnreadCountUser)
+	}
+
+	_, err = svc.Reject(request.ID, admin.ID, "too late")
+	requireBizErr(t, err, "order.refundRequestNotPending")
+}
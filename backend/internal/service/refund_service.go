@@ -0,0 +1,276 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrRefundExecution 付款方式退款脚本执行异常
+var ErrRefundExecution = errors.New("refund execution failed")
+
+// RefundDeclinedError 付款方式退款接口返回失败
+type RefundDeclinedError struct {
+	Message string
+}
+
+func (e *RefundDeclinedError) Error() string {
+	if e.Message == "" {
+		return "Refund failed"
+	}
+	return e.Message
+}
+
+// OrderRefundOutcome 订单退款结果
+type OrderRefundOutcome struct {
+	StatusBefore models.OrderStatus
+	StatusAfter  models.OrderStatus
+	RefundAmount int64 // 应退金额，已扣除不退还的付款方式附加费
+	Result       *RefundResult
+}
+
+// RefundService 订单退款引擎：调用付款方式的 onRefund 脚本并更新订单状态
+// 管理员直接退款与审核通过的用户退款申请共用同一流程
+type RefundService struct {
+	db               *gorm.DB
+	orderService     *OrderService
+	jsRuntimeService *JSRuntimeService
+}
+
+// NewRefundService 创建退款服务
+func NewRefundService(db *gorm.DB, orderService *OrderService, jsRuntimeService *JSRuntimeService) *RefundService {
+	return &RefundService{db: db, orderService: orderService, jsRuntimeService: jsRuntimeService}
+}
+
+// RefundableOrderStatuses 允许退款的订单状态（包括草稿状态，草稿表示已付款但用户尚未填写收货信息）
+var RefundableOrderStatuses = map[models.OrderStatus]bool{
+	models.OrderStatusDraft:        true,
+	models.OrderStatusPending:      true,
+	models.OrderStatusNeedResubmit: true,
+	models.OrderStatusShipped:      true,
+	models.OrderStatusCompleted:    true,
+}
+
+// orderPaymentMethod 订单付款时使用的付款方式
+func (s *RefundService) orderPaymentMethod(order *models.Order) (*models.PaymentMethod, error) {
+	var opm models.OrderPaymentMethod
+	if err := s.db.Where("order_id = ?", order.ID).First(&opm).Error; err != nil {
+		return nil, orderbiz.OrderPaymentMethodNotFound()
+	}
+	var pm models.PaymentMethod
+	if err := s.db.First(&pm, opm.PaymentMethodID).Error; err != nil {
+		return nil, orderbiz.PaymentMethodNotFound()
+	}
+	return &pm, nil
+}
+
+// refundSequence 退款在订单内的序号：部分退款保存在退款记录上，整单退款保存在订单上，首次执行时分配
+// 同一笔退款失败重试时序号不变，付款脚本据此生成退款单号，网关可据此去重
+func (s *RefundService) refundSequence(orderID, refundID uint) (int, error) {
+	owner := func(tx *gorm.DB) (*gorm.DB, string) {
+		if refundID > 0 {
+			return tx.Model(&models.OrderRefund{}).Where("id = ?", refundID), "refund_sequence"
+		}
+		return tx.Model(&models.Order{}).Where("id = ?", orderID), "full_refund_sequence"
+	}
+	var sequence int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		query, column := owner(tx)
+		if err := query.Select(column).Row().Scan(&sequence); err != nil {
+			return err
+		}
+		if sequence > 0 {
+			return nil
+		}
+		allocated, err := allocateRefundSequenceTx(tx, orderID, refundID)
+		if err != nil {
+			return err
+		}
+		query, column = owner(tx)
+		result := query.Where(column+" = 0").UpdateColumn(column, allocated)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// 并发执行已先分配，沿用已保存的序号
+			query, column = owner(tx)
+			return query.Select(column).Row().Scan(&sequence)
+		}
+		sequence = allocated
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+// allocateRefundSequenceTx 为订单分配下一个退款序号。
+// 未保存序号的旧数据按退款记录的位置推导序号，计数器不低于其它退款记录数，避免与已使用的退款单号重复
+func allocateRefundSequenceTx(tx *gorm.DB, orderID, refundID uint) (int, error) {
+	var count int64
+	if err := tx.Model(&models.OrderRefund{}).Where("order_id = ? AND id <> ?", orderID, refundID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.Order{}).
+		Where("id = ? AND last_refund_sequence < ?", orderID, count).
+		UpdateColumn("last_refund_sequence", count).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.Order{}).
+		Where("id = ?", orderID).
+		UpdateColumn("last_refund_sequence", gorm.Expr("last_refund_sequence + 1")).Error; err != nil {
+		return 0, err
+	}
+	var sequence int
+	if err := tx.Model(&models.Order{}).Where("id = ?", orderID).Select("last_refund_sequence").Row().Scan(&sequence); err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+// executeRefund 调用付款方式的 onRefund 脚本退还指定金额
+func (s *RefundService) executeRefund(pm *models.PaymentMethod, order *models.Order, amount int64, refundID uint) (*RefundResult, error) {
+	sequence, err := s.refundSequence(order.ID, refundID)
+	if err != nil {
+		return nil, err
+	}
+	refundResult, err := s.jsRuntimeService.ExecuteRefundAmount(pm, order, amount, sequence)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRefundExecution, err)
+	}
+	if !refundResult.Success {
+		return nil, &RefundDeclinedError{Message: refundResult.Message}
+	}
+	return refundResult, nil
+}
+
+// RefundOrder 对订单执行退款，reason 非空时追加到管理员备注
+func (s *RefundService) RefundOrder(order *models.Order, reason string) (*OrderRefundOutcome, error) {
+	if !RefundableOrderStatuses[order.Status] {
+		return nil, orderbiz.RefundStatusInvalid(order.Status)
+	}
+
+	pm, err := s.orderPaymentMethod(order)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已通过部分退款退还的金额不再重复退还
+	partialRefunded, err := repository.NewOrderRepository(s.db).RefundedPartialAmount(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	refundAmount := order.RefundableAmount() - partialRefunded
+	if refundAmount < 0 {
+		return nil, orderbiz.RefundedAmountExceeded(partialRefunded, order.RefundableAmount())
+	}
+
+	// 部分退款已退还全部金额时不再调用付款方式，付款脚本会把 0 当作未指定金额而全额退款
+	refundResult := &RefundResult{Success: true}
+	if refundAmount > 0 {
+		refundResult, err = s.executeRefund(pm, order, refundAmount, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outcome := &OrderRefundOutcome{
+		StatusBefore: order.Status,
+		StatusAfter:  models.OrderStatusRefunded,
+		RefundAmount: refundAmount,
+		Result:       refundResult,
+	}
+	if refundResult.Pending {
+		outcome.StatusAfter = models.OrderStatusRefundPending
+	}
+
+	// 未发货的订单退款时释放预留库存（物理库存 + 虚拟库存 + 优惠码）
+	if order.Status == models.OrderStatusDraft || order.Status == models.OrderStatusPending || order.Status == models.OrderStatusNeedResubmit {
+		s.orderService.ReleaseOrderReserves(order)
+	}
+
+	updates := map[string]interface{}{
+		"status": outcome.StatusAfter,
+	}
+	if outcome.StatusAfter == models.OrderStatusRefunded {
+		updates["refunded_at"] = models.NowFunc()
+	}
+	if reason != "" {
+		remark := order.AdminRemark
+		if remark != "" {
+			remark += "\n"
+		}
+		remark += "[Refund] " + reason
+		updates["admin_remark"] = remark
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(order).Updates(updates).Error; err != nil {
+			return err
+		}
+		if outcome.StatusAfter == models.OrderStatusRefunded {
+			if err := RecordVendorRefundTx(tx, order); err != nil {
+				return err
+			}
+			if err := RefundGiftCardTx(tx, order); err != nil {
+				return err
+			}
+			return CancelRevenueSchedulesTx(tx, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	order.Status = outcome.StatusAfter
+	return outcome, nil
+}
+
+// IssueOrderRefund 通过付款方式退还已批准的部分退款，退款失败时记录恢复为已批准
+func (s *RefundService) IssueOrderRefund(id, adminID uint) (*models.OrderRefund, *models.Order, *OrderRefundCompletion, error) {
+	refund, err := s.orderService.transitionOrderRefund(id, models.OrderRefundStatusApproved, models.OrderRefundStatusRefunded, map[string]interface{}{
+		"refunded_by": adminID,
+		"refunded_at": time.Now(),
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	revert := func() {
+		s.db.Model(&models.OrderRefund{}).Where("id = ?", refund.ID).Updates(map[string]interface{}{
+			"status":      models.OrderRefundStatusApproved,
+			"refunded_by": nil,
+			"refunded_at": nil,
+		})
+	}
+
+	var order models.Order
+	if err := s.db.First(&order, refund.OrderID).Error; err != nil {
+		revert()
+		return nil, nil, nil, normalizeOrderLookupError(err)
+	}
+	if !RefundableOrderStatuses[order.Status] {
+		revert()
+		return nil, nil, nil, orderbiz.RefundStatusInvalid(order.Status)
+	}
+
+	pm, err := s.orderPaymentMethod(&order)
+	if err != nil {
+		revert()
+		return nil, nil, nil, err
+	}
+	refundResult, err := s.executeRefund(pm, &order, refund.Amount, refund.ID)
+	if err != nil {
+		revert()
+		return nil, nil, nil, err
+	}
+	completion, err := s.orderService.completeOrderRefund(refund, &order, refundResult)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return refund, &order, completion, nil
+}
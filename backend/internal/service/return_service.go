@@ -0,0 +1,470 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	maxReturnDescriptionLength = 1000
+	maxReturnNoteLength        = 1000
+	maxReturnTrackingLength    = 100
+	maxReturnCarrierLength     = 50
+)
+
+var (
+	ErrReturnRequestNotFound = errors.New("return request not found")
+	ErrReturnOrderNotFound   = errors.New("order not found")
+)
+
+var returnReasons = map[string]bool{
+	models.ReturnReasonDefective:      true,
+	models.ReturnReasonDamaged:        true,
+	models.ReturnReasonWrongItem:      true,
+	models.ReturnReasonNotAsDescribed: true,
+	models.ReturnReasonNoLongerNeeded: true,
+	models.ReturnReasonOther:          true,
+}
+
+// ReturnableOrderStatuses 可以申请退货的订单状态：商品已寄出
+var ReturnableOrderStatuses = map[models.OrderStatus]bool{
+	models.OrderStatusShipped:   true,
+	models.OrderStatusCompleted: true,
+}
+
+// ReturnItemInput 申请退货的订单项
+type ReturnItemInput struct {
+	ItemIndex int
+	Quantity  int
+}
+
+// CreateReturnInput 用户提交的退货申请
+type CreateReturnInput struct {
+	Items       []ReturnItemInput
+	Reason      string
+	Description string
+	Photos      []string // 已校验的图片URL
+}
+
+// ReturnService 退货（RMA）：用户申请、管理员审核、用户登记退货物流、管理员收货并可选退回库存
+type ReturnService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewReturnService 创建退货服务
+func NewReturnService(db *gorm.DB, cfg *config.Config) *ReturnService {
+	return &ReturnService{db: db, cfg: cfg}
+}
+
+func (s *ReturnService) settings() config.ReturnConfig {
+	if s.cfg == nil {
+		return config.ReturnConfig{}
+	}
+	return s.cfg.Order.Returns
+}
+
+func returnStatusError(request *models.ReturnRequest) error {
+	return bizerr.Newf("return.statusInvalid", "This return is %s and cannot be processed", request.Status).
+		WithParams(map[string]interface{}{"status": request.Status})
+}
+
+func generateReturnNo(now time.Time) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("RMA%s%06d", now.Format("20060102"), n.Int64()), nil
+}
+
+func uniqueReturnNo(tx *gorm.DB) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		returnNo, err := generateReturnNo(models.NowFunc())
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := tx.Model(&models.ReturnRequest{}).Where("return_no = ?", returnNo).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return returnNo, nil
+		}
+	}
+	return "", errors.New("failed to generate a unique return number")
+}
+
+// returnWindowStart 退货期限从发货时间开始计算，没有发货时间时按完成时间
+func returnWindowStart(order *models.Order) *time.Time {
+	if order.ShippedAt != nil {
+		return order.ShippedAt
+	}
+	return order.CompletedAt
+}
+
+// Create 提交退货申请，只能退实物商品，同一订单项的退货数量合计不能超过购买数量
+func (s *ReturnService) Create(userID uint, orderNo string, input CreateReturnInput) (*models.ReturnRequest, error) {
+	settings := s.settings()
+	if !settings.Enabled {
+		return nil, bizerr.New("return.disabled", "Returns are not available")
+	}
+	if !returnReasons[input.Reason] {
+		return nil, bizerr.New("return.reasonInvalid", "Invalid return reason")
+	}
+	description := strings.TrimSpace(input.Description)
+	if len([]rune(description)) > maxReturnDescriptionLength {
+		return nil, bizerr.Newf("return.descriptionTooLong", "Description cannot exceed %d characters", maxReturnDescriptionLength).
+			WithParams(map[string]interface{}{"max": maxReturnDescriptionLength})
+	}
+	if len(input.Items) == 0 {
+		return nil, bizerr.New("return.itemsRequired", "Select at least one item to return")
+	}
+
+	var request *models.ReturnRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Where("order_no = ? AND user_id = ?", orderNo, userID).First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReturnOrderNotFound
+			}
+			return err
+		}
+		locked, err := repository.NewOrderRepository(tx).FindByIDForUpdate(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		order = *locked
+		if !ReturnableOrderStatuses[order.Status] {
+			return bizerr.Newf("return.orderStatusInvalid", "Returns cannot be requested for this order (current status: %s)", order.Status).
+				WithParams(map[string]interface{}{"status": order.Status})
+		}
+		if settings.WindowDays > 0 {
+			if start := returnWindowStart(&order); start != nil && models.NowFunc().After(start.AddDate(0, 0, settings.WindowDays)) {
+				return bizerr.Newf("return.windowExpired", "Returns must be requested within %d days of shipment", settings.WindowDays).
+					WithParams(map[string]interface{}{"days": settings.WindowDays})
+			}
+		}
+
+		// 未被拒绝的退货申请占用可退货数量
+		var existing []models.ReturnRequest
+		if err := tx.Where("order_id = ? AND status <> ?", order.ID, models.ReturnRequestStatusRejected).
+			Find(&existing).Error; err != nil {
+			return err
+		}
+		committed := make(map[int]int)
+		for _, item := range existing {
+			for _, returnItem := range item.Items {
+				committed[returnItem.ItemIndex] += returnItem.Quantity
+			}
+		}
+
+		requested := make(map[int]int)
+		for _, input := range input.Items {
+			if input.ItemIndex < 0 || input.ItemIndex >= len(order.Items) {
+				return bizerr.Newf("return.itemInvalid", "Order item #%d does not exist", input.ItemIndex).
+					WithParams(map[string]interface{}{"index": input.ItemIndex})
+			}
+			if input.Quantity <= 0 {
+				return bizerr.New("return.quantityInvalid", "Return quantity must be greater than 0")
+			}
+			requested[input.ItemIndex] += input.Quantity
+		}
+		indexes := make([]int, 0, len(requested))
+		for index := range requested {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+
+		items := make([]models.ReturnItem, 0, len(indexes))
+		for _, index := range indexes {
+			item := order.Items[index]
+			if item.ProductType == models.ProductTypeVirtual {
+				return bizerr.Newf("return.itemNotReturnable", "%s is a virtual product and cannot be returned", item.SKU).
+					WithParams(map[string]interface{}{"sku": item.SKU})
+			}
+			available := item.Quantity - committed[index]
+			if requested[index] > available {
+				if available < 0 {
+					available = 0
+				}
+				return bizerr.Newf("return.quantityExceeded", "Only %d of %s can still be returned", available, item.SKU).
+					WithParams(map[string]interface{}{"sku": item.SKU, "available": available})
+			}
+			items = append(items, models.ReturnItem{
+				ItemIndex: index,
+				SKU:       item.SKU,
+				Name:      item.Name,
+				Quantity:  requested[index],
+			})
+		}
+
+		returnNo, err := uniqueReturnNo(tx)
+		if err != nil {
+			return err
+		}
+		request = &models.ReturnRequest{
+			ReturnNo:    returnNo,
+			OrderID:     order.ID,
+			OrderNo:     order.OrderNo,
+			UserID:      userID,
+			Items:       items,
+			Reason:      input.Reason,
+			Description: description,
+			Photos:      input.Photos,
+			Status:      models.ReturnRequestStatusRequested,
+		}
+		return tx.Create(request).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDCopy := userID
+	s.recordEvent(request, "return.request", models.OrderEventOperatorUser, &userIDCopy, "Return "+request.ReturnNo+" requested")
+	return request, nil
+}
+
+func (s *ReturnService) recordEvent(request *models.ReturnRequest, source, operatorType string, operatorID *uint, message string) {
+	recordOrderEventDB(s.db, &models.OrderEvent{
+		OrderID:      request.OrderID,
+		OrderNo:      request.OrderNo,
+		Type:         models.OrderEventTypeRemark,
+		Source:       source,
+		OperatorType: operatorType,
+		OperatorID:   operatorID,
+		Message:      message,
+		Data: encodeOrderEventData(map[string]interface{}{
+			"return_id": request.ID,
+			"return_no": request.ReturnNo,
+			"status":    request.Status,
+		}),
+	})
+}
+
+// ListForOrder 用户某个订单的退货申请
+func (s *ReturnService) ListForOrder(userID uint, orderNo string) ([]models.ReturnRequest, error) {
+	var requests []models.ReturnRequest
+	err := s.db.Where("user_id = ? AND order_no = ?", userID, orderNo).
+		Order("id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// List 管理端退货列表，status 为空时返回全部，search 匹配退货单号、订单号与退货物流单号
+func (s *ReturnService) List(status, search string, page, limit int) ([]models.ReturnRequest, int64, error) {
+	query := s.db.Model(&models.ReturnRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search = strings.TrimSpace(search); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("return_no LIKE ? OR order_no LIKE ? OR return_tracking_no LIKE ?", like, like, like)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var requests []models.ReturnRequest
+	err := query.Preload("User").Order("id DESC").
+		Offset((page - 1) * limit).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+// Get 退货申请详情
+func (s *ReturnService) Get(id uint) (*models.ReturnRequest, error) {
+	var request models.ReturnRequest
+	if err := s.db.Preload("User").First(&request, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnRequestNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func validateReturnNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxReturnNoteLength {
+		return "", bizerr.Newf("return.noteTooLong", "Note cannot exceed %d characters", maxReturnNoteLength).
+			WithParams(map[string]interface{}{"max": maxReturnNoteLength})
+	}
+	return note, nil
+}
+
+// transition 按当前状态原子地推进退货状态，防止重复处理
+func (s *ReturnService) transition(tx *gorm.DB, request *models.ReturnRequest, from []models.ReturnRequestStatus, updates map[string]interface{}) error {
+	result := tx.Model(&models.ReturnRequest{}).
+		Where("id = ? AND status IN ?", request.ID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return returnStatusError(request)
+	}
+	return nil
+}
+
+// Approve 批准退货申请，等待用户寄回
+func (s *ReturnService) Approve(id, adminID uint, note string) (*models.ReturnRequest, error) {
+	note, err := validateReturnNote(note)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transition(s.db, request, []models.ReturnRequestStatus{models.ReturnRequestStatusRequested}, map[string]interface{}{
+		"status":      models.ReturnRequestStatusApproved,
+		"reviewed_by": adminID,
+		"reviewed_at": models.NowFunc(),
+		"review_note": note,
+	}); err != nil {
+		return nil, err
+	}
+	request, err = s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	s.recordEvent(request, "return.approve", models.OrderEventOperatorAdmin, &adminID, "Return "+request.ReturnNo+" approved")
+	return request, nil
+}
+
+// Reject 拒绝退货申请，需填写拒绝原因
+func (s *ReturnService) Reject(id, adminID uint, note string) (*models.ReturnRequest, error) {
+	note, err := validateReturnNote(note)
+	if err != nil {
+		return nil, err
+	}
+	if note == "" {
+		return nil, bizerr.New("return.noteRequired", "Please provide a reason for rejecting the return")
+	}
+	request, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transition(s.db, request, []models.ReturnRequestStatus{models.ReturnRequestStatusRequested}, map[string]interface{}{
+		"status":      models.ReturnRequestStatusRejected,
+		"reviewed_by": adminID,
+		"reviewed_at": models.NowFunc(),
+		"review_note": note,
+	}); err != nil {
+		return nil, err
+	}
+	request, err = s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	s.recordEvent(request, "return.reject", models.OrderEventOperatorAdmin, &adminID, "Return "+request.ReturnNo+" rejected")
+	return request, nil
+}
+
+// SubmitTracking 用户寄回商品后登记退货物流，收货前可以更正
+func (s *ReturnService) SubmitTracking(userID uint, returnNo, carrier, trackingNo string) (*models.ReturnRequest, error) {
+	carrier = strings.TrimSpace(carrier)
+	trackingNo = strings.TrimSpace(trackingNo)
+	if trackingNo == "" {
+		return nil, bizerr.New("return.trackingRequired", "Please enter the return tracking number")
+	}
+	if len([]rune(trackingNo)) > maxReturnTrackingLength || len([]rune(carrier)) > maxReturnCarrierLength {
+		return nil, bizerr.Newf("return.trackingTooLong", "Tracking number cannot exceed %d characters", maxReturnTrackingLength).
+			WithParams(map[string]interface{}{"max": maxReturnTrackingLength})
+	}
+	var request models.ReturnRequest
+	if err := s.db.Where("return_no = ? AND user_id = ?", returnNo, userID).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnRequestNotFound
+		}
+		return nil, err
+	}
+	if err := s.transition(s.db, &request, []models.ReturnRequestStatus{
+		models.ReturnRequestStatusApproved,
+		models.ReturnRequestStatusInTransit,
+	}, map[string]interface{}{
+		"status":             models.ReturnRequestStatusInTransit,
+		"return_carrier":     carrier,
+		"return_tracking_no": trackingNo,
+		"shipped_back_at":    models.NowFunc(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.db.First(&request, request.ID).Error; err != nil {
+		return nil, err
+	}
+	userIDCopy := userID
+	s.recordEvent(&request, "return.tracking", models.OrderEventOperatorUser, &userIDCopy, "Return "+request.ReturnNo+" shipped back: "+trackingNo)
+	return &request, nil
+}
+
+// Receive 管理员确认收到退货；restock 为 true 时将实物商品退回订单项绑定的库存，
+// 没有绑定库存的订单项跳过
+func (s *ReturnService) Receive(id, adminID uint, restock bool, note, operator string) (*models.ReturnRequest, error) {
+	note, err := validateReturnNote(note)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 先推进状态，避免重复收货导致重复入库
+		if err := s.transition(tx, request, []models.ReturnRequestStatus{
+			models.ReturnRequestStatusApproved,
+			models.ReturnRequestStatusInTransit,
+		}, map[string]interface{}{
+			"status":       models.ReturnRequestStatusReceived,
+			"received_by":  adminID,
+			"received_at":  models.NowFunc(),
+			"receive_note": note,
+		}); err != nil {
+			return err
+		}
+		if !restock {
+			return nil
+		}
+
+		var order models.Order
+		if err := tx.First(&order, request.OrderID).Error; err != nil {
+			return err
+		}
+		items := append([]models.ReturnItem(nil), request.Items...)
+		restocked := false
+		inventoryRepo := repository.NewInventoryRepository(tx)
+		reason := "Restock returned items " + request.ReturnNo
+		for i, item := range items {
+			inventoryID, exists := order.InventoryBindings[item.ItemIndex]
+			if !exists || inventoryID == 0 {
+				continue
+			}
+			if err := inventoryRepo.Restock(inventoryID, item.Quantity, order.OrderNo, operator, reason); err != nil {
+				return err
+			}
+			items[i].Restocked = true
+			restocked = true
+		}
+		return tx.Model(&models.ReturnRequest{ID: request.ID}).Select("items", "restocked").
+			Updates(&models.ReturnRequest{Items: items, Restocked: restocked}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	request, err = s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	s.recordEvent(request, "return.receive", models.OrderEventOperatorAdmin, &adminID, "Return "+request.ReturnNo+" received")
+	return request, nil
+}
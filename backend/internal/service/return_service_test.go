@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestReturnLifecycleRestocksBoundInventory(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.OrderEvent{}, &models.Inventory{}, &models.InventoryLog{}, &models.ReturnRequest{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	returnSvc := NewReturnService(db, orderSvc.cfg)
+
+	inventory := &models.Inventory{Name: "Mug", SKU: "MUG", Stock: 8, AvailableQuantity: 8, SoldQuantity: 2, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	buyerID := uint(7)
+	shippedAt := models.NowFunc().Add(-48 * time.Hour)
+	order := &models.Order{
+		OrderNo: "ORD-RMA-1",
+		UserID:  &buyerID,
+		Status:  models.OrderStatusShipped,
+		Items: []models.OrderItem{
+			{SKU: "MUG", Name: "Mug", Quantity: 2, ProductType: models.ProductTypePhysical},
+			{SKU: "KEY", Name: "License", Quantity: 1, ProductType: models.ProductTypeVirtual},
+		},
+		InventoryBindings: map[int]uint{0: inventory.ID},
+		ShippedAt:         &shippedAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	input := CreateReturnInput{
+		Items:  []ReturnItemInput{{ItemIndex: 0, Quantity: 1}},
+		Reason: models.ReturnReasonDamaged,
+	}
+
+	_, err := returnSvc.Create(buyerID, order.OrderNo, input)
+	requireOrderBizErr(t, err, "return.disabled")
+	orderSvc.cfg.Order.Returns.Enabled = true
+
+	if _, err := returnSvc.Create(buyerID+1, order.OrderNo, input); err != ErrReturnOrderNotFound {
+		t.Fatalf("expected other users to be denied, got %v", err)
+	}
+	_, err = returnSvc.Create(buyerID, order.OrderNo, CreateReturnInput{Items: []ReturnItemInput{{ItemIndex: 1, Quantity: 1}}, Reason: models.ReturnReasonOther})
+	requireOrderBizErr(t, err, "return.itemNotReturnable")
+	_, err = returnSvc.Create(buyerID, order.OrderNo, CreateReturnInput{Items: []ReturnItemInput{{ItemIndex: 0, Quantity: 3}}, Reason: models.ReturnReasonOther})
+	requireOrderBizErr(t, err, "return.quantityExceeded")
+
+	// 超过退货期限
+	orderSvc.cfg.Order.Returns.WindowDays = 1
+	_, err = returnSvc.Create(buyerID, order.OrderNo, input)
+	requireOrderBizErr(t, err, "return.windowExpired")
+	orderSvc.cfg.Order.Returns.WindowDays = 30
+
+	request, err := returnSvc.Create(buyerID, order.OrderNo, input)
+	if err != nil {
+		t.Fatalf("create return: %v", err)
+	}
+	if request.Status != models.ReturnRequestStatusRequested || request.Items[0].SKU != "MUG" || request.ReturnNo == "" {
+		t.Fatalf("unexpected return request: %+v", request)
+	}
+	// 待审核的申请同样占用可退货数量
+	_, err = returnSvc.Create(buyerID, order.OrderNo, CreateReturnInput{Items: []ReturnItemInput{{ItemIndex: 0, Quantity: 2}}, Reason: models.ReturnReasonOther})
+	requireOrderBizErr(t, err, "return.quantityExceeded")
+
+	_, err = returnSvc.SubmitTracking(buyerID, request.ReturnNo, "UPS", "1Z999")
+	requireOrderBizErr(t, err, "return.statusInvalid")
+	_, err = returnSvc.Receive(request.ID, 1, true, "", "admin@example.com")
+	requireOrderBizErr(t, err, "return.statusInvalid")
+	if _, err := returnSvc.Approve(request.ID, 1, "Please use the prepaid label"); err != nil {
+		t.Fatalf("approve return: %v", err)
+	}
+	_, err = returnSvc.Reject(request.ID, 1, "too late")
+	requireOrderBizErr(t, err, "return.statusInvalid")
+
+	shipped, err := returnSvc.SubmitTracking(buyerID, request.ReturnNo, "UPS", "1Z999")
+	if err != nil {
+		t.Fatalf("submit tracking: %v", err)
+	}
+	if shipped.Status != models.ReturnRequestStatusInTransit || shipped.ReturnTrackingNo != "1Z999" || shipped.ShippedBackAt == nil {
+		t.Fatalf("unexpected return after tracking: %+v", shipped)
+	}
+
+	received, err := returnSvc.Receive(request.ID, 1, true, "Box intact", "admin@example.com")
+	if err != nil {
+		t.Fatalf("receive return: %v", err)
+	}
+	if received.Status != models.ReturnRequestStatusReceived || !received.Restocked || !received.Items[0].Restocked {
+		t.Fatalf("unexpected received return: %+v", received)
+	}
+	var stored models.Inventory
+	db.First(&stored, inventory.ID)
+	if stored.Stock != 9 || stored.AvailableQuantity != 9 || stored.SoldQuantity != 1 {
+		t.Fatalf("inventory not restocked: stock=%d available=%d sold=%d", stored.Stock, stored.AvailableQuantity, stored.SoldQuantity)
+	}
+	var logs int64
+	db.Model(&models.InventoryLog{}).Where("inventory_id = ? AND type = ? AND order_no = ?", inventory.ID, models.InventoryLogTypeIn, order.OrderNo).Count(&logs)
+	if logs != 1 {
+		t.Fatalf("expected one restock log, got %d", logs)
+	}
+
+	// 重复收货不会再次入库
+	_, err = returnSvc.Receive(request.ID, 1, true, "", "admin@example.com")
+	requireOrderBizErr(t, err, "return.statusInvalid")
+	db.First(&stored, inventory.ID)
+	if stored.Stock != 9 {
+		t.Fatalf("inventory restocked twice: stock=%d", stored.Stock)
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	maxRevenueServicePeriodMonths = 120
+	revenueBackfillBatchSize      = 200
+)
+
+// revenuePeriod 返回时间所在的收入确认月份（UTC，YYYY-MM）
+func revenuePeriod(t time.Time) string {
+	return t.UTC().Format(fxSettlementMonthFormat)
+}
+
+// splitRevenueAmount 将金额按月平均拆分，除不尽的部分从第一个月起逐月多确认 1 个最小单位
+func splitRevenueAmount(amount int64, months int) []int64 {
+	parts := make([]int64, months)
+	base := amount / int64(months)
+	remainder := amount % int64(months)
+	for i := range parts {
+		parts[i] = base
+		if int64(i) < remainder {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// recordRevenueSchedulesTx 订单付款后为按期确认的商品行创建收入确认计划，重复调用不会重复创建
+func recordRevenueSchedulesTx(tx *gorm.DB, order *models.Order, paidAt time.Time) (int, error) {
+	deferred := false
+	for _, item := range order.Items {
+		if item.RevenueRecognition != "" && item.ServicePeriodMonths > 0 {
+			deferred = true
+			break
+		}
+	}
+	if !deferred {
+		return 0, nil
+	}
+
+	var existing int64
+	if err := tx.Model(&models.RevenueSchedule{}).Where("order_id = ?", order.ID).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+	if existing > 0 {
+		return 0, nil
+	}
+
+	start := time.Date(paidAt.UTC().Year(), paidAt.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	amounts := allocateOrderItemAmounts(order)
+	created := 0
+	for idx, item := range order.Items {
+		if item.RevenueRecognition == "" || item.ServicePeriodMonths <= 0 || amounts[idx] <= 0 {
+			continue
+		}
+		schedule := models.RevenueSchedule{
+			OrderID:     order.ID,
+			ItemIndex:   idx,
+			OrderNo:     order.OrderNo,
+			SKU:         item.SKU,
+			Type:        item.RevenueRecognition,
+			Currency:    order.Currency,
+			Amount:      amounts[idx],
+			TermMonths:  item.ServicePeriodMonths,
+			StartPeriod: revenuePeriod(start),
+			Status:      models.RevenueScheduleStatusActive,
+		}
+		if err := tx.Create(&schedule).Error; err != nil {
+			return created, err
+		}
+		parts := splitRevenueAmount(schedule.Amount, schedule.TermMonths)
+		entries := make([]models.RevenueScheduleEntry, 0, len(parts))
+		for month, amount := range parts {
+			entries = append(entries, models.RevenueScheduleEntry{
+				ScheduleID: schedule.ID,
+				Period:     revenuePeriod(start.AddDate(0, month, 0)),
+				Amount:     amount,
+			})
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// CancelRevenueSchedulesTx 订单退款后取消其收入确认计划：退款当月及之后尚未确认的金额记为释放，已确认的月份保持不变
+func CancelRevenueSchedulesTx(tx *gorm.DB, order *models.Order) error {
+	return cancelRevenueSchedulesTx(tx, order.ID, models.NowFunc())
+}
+
+func cancelRevenueSchedulesTx(tx *gorm.DB, orderID uint, cancelledAt time.Time) error {
+	var schedules []models.RevenueSchedule
+	if err := tx.Where("order_id = ? AND status = ?", orderID, models.RevenueScheduleStatusActive).
+		Find(&schedules).Error; err != nil {
+		return err
+	}
+	period := revenuePeriod(cancelledAt)
+	for _, schedule := range schedules {
+		var released int64
+		if err := tx.Model(&models.RevenueScheduleEntry{}).
+			Where("schedule_id = ? AND period >= ?", schedule.ID, period).
+			Select("COALESCE(SUM(amount), 0)").Scan(&released).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.RevenueSchedule{}).
+			Where("id = ? AND status = ?", schedule.ID, models.RevenueScheduleStatusActive).
+			Updates(map[string]interface{}{
+				"status":           models.RevenueScheduleStatusCancelled,
+				"cancelled_period": period,
+				"released_amount":  released,
+				"cancelled_at":     cancelledAt,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevenueRecognitionRow 按月份、币种、确认方式汇总的递延收入变动
+type RevenueRecognitionRow struct {
+	Period                string `json:"period"`
+	Currency              string `json:"currency"`
+	Type                  string `json:"type"`
+	ScheduleCount         int64  `json:"schedule_count"`          // 当月新增的收入确认计划数
+	DeferredOpeningMinor  int64  `json:"deferred_opening_minor"`  // 期初递延收入
+	BilledAmountMinor     int64  `json:"billed_amount_minor"`     // 当月付款、计入递延的金额
+	RecognizedAmountMinor int64  `json:"recognized_amount_minor"` // 当月确认的收入
+	ReleasedAmountMinor   int64  `json:"released_amount_minor"`   // 当月退款释放的未确认金额
+	DeferredClosingMinor  int64  `json:"deferred_closing_minor"`  // 期末递延收入
+}
+
+// RevenueRecognitionReport 收入确认月度报表
+type RevenueRecognitionReport struct {
+	From string                  `json:"from"`
+	To   string                  `json:"to"`
+	Rows []RevenueRecognitionRow `json:"rows"`
+}
+
+// RevenueRecognitionService 订阅与预付额度的收入确认计划及月度报表
+type RevenueRecognitionService struct {
+	db *gorm.DB
+}
+
+func NewRevenueRecognitionService(db *gorm.DB) *RevenueRecognitionService {
+	return &RevenueRecognitionService{db: db}
+}
+
+// SetProductRevenueRecognition 设置商品的收入确认方式与服务期（月），只影响之后创建的订单
+func (s *RevenueRecognitionService) SetProductRevenueRecognition(productID uint, recognition models.RevenueRecognitionType, months int) (*models.Product, error) {
+	recognition = models.RevenueRecognitionType(strings.TrimSpace(string(recognition)))
+	switch recognition {
+	case "":
+		months = 0
+	case models.RevenueRecognitionSubscription, models.RevenueRecognitionPrepaidCredit:
+		if months < 1 || months > maxRevenueServicePeriodMonths {
+			return nil, bizerr.Newf("revenue.servicePeriodInvalid", "Service period must be between 1 and %d months", maxRevenueServicePeriodMonths).
+				WithParams(map[string]interface{}{"max": maxRevenueServicePeriodMonths})
+		}
+	default:
+		return nil, bizerr.Newf("revenue.recognitionTypeInvalid", "Invalid revenue recognition type: %s", recognition).
+			WithParams(map[string]interface{}{"type": recognition})
+	}
+
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if recognition != "" && product.ProductType != models.ProductTypeVirtual {
+		return nil, bizerr.New("revenue.virtualProductRequired", "Deferred revenue recognition is only available for virtual products")
+	}
+	if err := s.db.Model(&product).Updates(map[string]interface{}{
+		"revenue_recognition":   recognition,
+		"service_period_months": months,
+	}).Error; err != nil {
+		return nil, err
+	}
+	product.RevenueRecognition = recognition
+	product.ServicePeriodMonths = months
+	return &product, nil
+}
+
+// Backfill 为 since 之后付款、尚无收入确认计划的订单补建计划；下单时未记录确认方式的商品行按商品当前设置处理，
+// 已退款的订单按退款时间取消。返回新建的计划数
+func (s *RevenueRecognitionService) Backfill(since time.Time) (int, error) {
+	var products []models.Product
+	if err := s.db.Select("sku, revenue_recognition, service_period_months").
+		Where("revenue_recognition <> '' AND service_period_months > 0").
+		Find(&products).Error; err != nil {
+		return 0, err
+	}
+	productBySKU := make(map[string]models.Product, len(products))
+	for _, product := range products {
+		productBySKU[strings.TrimSpace(product.SKU)] = product
+	}
+
+	created := 0
+	var lastID uint
+	for {
+		var orders []models.Order
+		if err := s.db.Where("paid_at IS NOT NULL AND paid_at >= ? AND id > ?", since, lastID).
+			Where("NOT EXISTS (SELECT 1 FROM revenue_schedules WHERE revenue_schedules.order_id = orders.id)").
+			Order("id ASC").Limit(revenueBackfillBatchSize).Find(&orders).Error; err != nil {
+			return created, err
+		}
+		if len(orders) == 0 {
+			return created, nil
+		}
+		for i := range orders {
+			order := &orders[i]
+			lastID = order.ID
+			for idx := range order.Items {
+				item := &order.Items[idx]
+				if item.RevenueRecognition != "" {
+					continue
+				}
+				if product, ok := productBySKU[strings.TrimSpace(item.SKU)]; ok {
+					item.RevenueRecognition = product.RevenueRecognition
+					item.ServicePeriodMonths = product.ServicePeriodMonths
+				}
+			}
+			err := s.db.Transaction(func(tx *gorm.DB) error {
+				count, err := recordRevenueSchedulesTx(tx, order, *order.PaidAt)
+				if err != nil || count == 0 {
+					return err
+				}
+				created += count
+				if order.Status == models.OrderStatusRefunded && order.RefundedAt != nil {
+					return cancelRevenueSchedulesTx(tx, order.ID, *order.RefundedAt)
+				}
+				return nil
+			})
+			if err != nil {
+				return created, err
+			}
+		}
+	}
+}
+
+type revenueAggregate struct {
+	Period   string
+	Currency string
+	Type     string
+	Count    int64
+	Amount   int64
+}
+
+// MonthlyReport 按月份、币种、确认方式生成递延收入变动表（期初 + 新增 - 确认 - 释放 = 期末）
+func (s *RevenueRecognitionService) MonthlyReport(fromMonth, toMonth time.Time) (*RevenueRecognitionReport, error) {
+	from, to := revenuePeriod(fromMonth), revenuePeriod(toMonth)
+
+	var opening []revenueAggregate
+	if err := s.db.Table("revenue_schedule_entries AS e").
+		Select("s.currency, s.type, COALESCE(SUM(e.amount), 0) AS amount").
+		Joins("JOIN revenue_schedules AS s ON s.id = e.schedule_id").
+		Where("s.start_period < ? AND e.period >= ?", from, from).
+		Where("(s.cancelled_period IS NULL OR s.cancelled_period = '' OR s.cancelled_period >= ?)", from).
+		Group("s.currency, s.type").
+		Scan(&opening).Error; err != nil {
+		return nil, err
+	}
+
+	var billed []revenueAggregate
+	if err := s.db.Model(&models.RevenueSchedule{}).
+		Select("start_period AS period, currency, type, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("start_period >= ? AND start_period <= ?", from, to).
+		Group("start_period, currency, type").
+		Scan(&billed).Error; err != nil {
+		return nil, err
+	}
+
+	var recognized []revenueAggregate
+	if err := s.db.Table("revenue_schedule_entries AS e").
+		Select("e.period, s.currency, s.type, COALESCE(SUM(e.amount), 0) AS amount").
+		Joins("JOIN revenue_schedules AS s ON s.id = e.schedule_id").
+		Where("e.period >= ? AND e.period <= ?", from, to).
+		Where("(s.cancelled_period IS NULL OR s.cancelled_period = '' OR s.cancelled_period > e.period)").
+		Group("e.period, s.currency, s.type").
+		Scan(&recognized).Error; err != nil {
+		return nil, err
+	}
+
+	var released []revenueAggregate
+	if err := s.db.Model(&models.RevenueSchedule{}).
+		Select("cancelled_period AS period, currency, type, COALESCE(SUM(released_amount), 0) AS amount").
+		Where("status = ? AND cancelled_period >= ? AND cancelled_period <= ?", models.RevenueScheduleStatusCancelled, from, to).
+		Group("cancelled_period, currency, type").
+		Scan(&released).Error; err != nil {
+		return nil, err
+	}
+
+	type seriesKey struct{ currency, typ string }
+	type rowKey struct {
+		period string
+		series seriesKey
+	}
+	balances := make(map[seriesKey]int64)
+	for _, item := range opening {
+		balances[seriesKey{item.Currency, item.Type}] += item.Amount
+	}
+	movements := make(map[rowKey]*RevenueRecognitionRow)
+	movement := func(item revenueAggregate) *RevenueRecognitionRow {
+		key := rowKey{item.Period, seriesKey{item.Currency, item.Type}}
+		if _, ok := balances[key.series]; !ok {
+			balances[key.series] = 0
+		}
+		row, ok := movements[key]
+		if !ok {
+			row = &RevenueRecognitionRow{Period: item.Period, Currency: item.Currency, Type: item.Type}
+			movements[key] = row
+		}
+		return row
+	}
+	for _, item := range billed {
+		row := movement(item)
+		row.ScheduleCount += item.Count
+		row.BilledAmountMinor += item.Amount
+	}
+	for _, item := range recognized {
+		movement(item).RecognizedAmountMinor += item.Amount
+	}
+	for _, item := range released {
+		movement(item).ReleasedAmountMinor += item.Amount
+	}
+
+	// 逐月滚动计算期初、期末余额；没有变动但仍有递延余额的月份也输出一行
+	rows := make([]RevenueRecognitionRow, 0, len(movements))
+	for month := fromMonth; !month.After(toMonth); month = month.AddDate(0, 1, 0) {
+		period := revenuePeriod(month)
+		for series, balance := range balances {
+			row, ok := movements[rowKey{period, series}]
+			if !ok {
+				if balance == 0 {
+					continue
+				}
+				row = &RevenueRecognitionRow{Period: period, Currency: series.currency, Type: series.typ}
+			}
+			row.DeferredOpeningMinor = balance
+			row.DeferredClosingMinor = balance + row.BilledAmountMinor - row.RecognizedAmountMinor - row.ReleasedAmountMinor
+			balances[series] = row.DeferredClosingMinor
+			rows = append(rows, *row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Period != rows[j].Period {
+			return rows[i].Period > rows[j].Period
+		}
+		if rows[i].Currency != rows[j].Currency {
+			return rows[i].Currency < rows[j].Currency
+		}
+		return rows[i].Type < rows[j].Type
+	})
+
+	return &RevenueRecognitionReport{From: from, To: to, Rows: rows}, nil
+}
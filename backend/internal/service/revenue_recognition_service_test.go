@@ -0,0 +1,135 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func newRevenueRecognitionTestService(t *testing.T) *RevenueRecognitionService {
+	t.Helper()
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.Product{}, &models.RevenueSchedule{}, &models.RevenueScheduleEntry{}); err != nil {
+		t.Fatalf("auto migrate revenue tables failed: %v", err)
+	}
+	return NewRevenueRecognitionService(db)
+}
+
+func TestRevenueSchedulesSpreadAnnualLicenseAndReleaseOnRefund(t *testing.T) {
+	svc := newRevenueRecognitionTestService(t)
+
+	// 年度授权原价 12000 与一次性商品 3000 共享优惠，实收 13500（另含 100 付款方式附加费）
+	order := &models.Order{
+		OrderNo:     "REV-1",
+		Status:      models.OrderStatusPending,
+		Currency:    "USD",
+		TotalAmount: 13600,
+		PaymentFee:  100,
+		Items: []models.OrderItem{
+			{SKU: "LIC-1Y", Quantity: 1, UnitPrice: 12000, RevenueRecognition: models.RevenueRecognitionSubscription, ServicePeriodMonths: 12},
+			{SKU: "SETUP", Quantity: 1, UnitPrice: 3000},
+		},
+	}
+	if err := svc.db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	paidAt := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if _, err := recordRevenueSchedulesTx(svc.db, order, paidAt); err != nil {
+			t.Fatalf("record revenue schedules failed: %v", err)
+		}
+	}
+
+	var schedules []models.RevenueSchedule
+	if err := svc.db.Find(&schedules).Error; err != nil {
+		t.Fatalf("load schedules failed: %v", err)
+	}
+	if len(schedules) != 1 || schedules[0].Amount != 10800 || schedules[0].StartPeriod != "2026-01" {
+		t.Fatalf("expected one 10800 schedule starting 2026-01, got %+v", schedules)
+	}
+	var entries int64
+	svc.db.Model(&models.RevenueScheduleEntry{}).Where("schedule_id = ? AND amount = ?", schedules[0].ID, 900).Count(&entries)
+	if entries != 12 {
+		t.Fatalf("expected 12 monthly entries of 900, got %d", entries)
+	}
+
+	if err := cancelRevenueSchedulesTx(svc.db, order.ID, time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("cancel revenue schedules failed: %v", err)
+	}
+
+	report, err := svc.MonthlyReport(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("build report failed: %v", err)
+	}
+	// 2 月期初 9900；2、3 月各确认 900；4 月退款释放剩余 8100；5 月已无递延余额
+	expected := map[string][4]int64{
+		"2026-04": {8100, 0, 8100, 0},
+		"2026-03": {9000, 900, 0, 8100},
+		"2026-02": {9900, 900, 0, 9000},
+	}
+	if len(report.Rows) != len(expected) {
+		t.Fatalf("expected %d report rows, got %+v", len(expected), report.Rows)
+	}
+	for _, row := range report.Rows {
+		want, ok := expected[row.Period]
+		got := [4]int64{row.DeferredOpeningMinor, row.RecognizedAmountMinor, row.ReleasedAmountMinor, row.DeferredClosingMinor}
+		if !ok || got != want || row.Currency != "USD" || row.Type != string(models.RevenueRecognitionSubscription) {
+			t.Fatalf("unexpected report row %+v", row)
+		}
+	}
+	if report.Rows[0].Period != "2026-04" {
+		t.Fatalf("expected newest period first, got %s", report.Rows[0].Period)
+	}
+}
+
+func TestRevenueRecognitionProductSettingsAndBackfill(t *testing.T) {
+	svc := newRevenueRecognitionTestService(t)
+	physical := models.Product{SKU: "TEE", Name: "Tee", ProductType: models.ProductTypePhysical}
+	credits := models.Product{SKU: "CREDITS", Name: "Credits", ProductType: models.ProductTypeVirtual}
+	for _, product := range []*models.Product{&physical, &credits} {
+		if err := svc.db.Create(product).Error; err != nil {
+			t.Fatalf("create product failed: %v", err)
+		}
+	}
+
+	_, err := svc.SetProductRevenueRecognition(physical.ID, models.RevenueRecognitionSubscription, 12)
+	requireBizErr(t, err, "revenue.virtualProductRequired")
+	_, err = svc.SetProductRevenueRecognition(credits.ID, models.RevenueRecognitionPrepaidCredit, 0)
+	requireBizErr(t, err, "revenue.servicePeriodInvalid")
+	_, err = svc.SetProductRevenueRecognition(credits.ID, "usage", 3)
+	requireBizErr(t, err, "revenue.recognitionTypeInvalid")
+	if _, err := svc.SetProductRevenueRecognition(credits.ID, models.RevenueRecognitionPrepaidCredit, 3); err != nil {
+		t.Fatalf("set revenue recognition failed: %v", err)
+	}
+
+	// 设置前已付款的订单：商品行未记录确认方式，补建时按商品当前设置处理
+	paidAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	refundedAt := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	orders := []models.Order{
+		{OrderNo: "OLD-1", Status: models.OrderStatusCompleted, Currency: "USD", TotalAmount: 1000, PaidAt: &paidAt, Items: []models.OrderItem{{SKU: "CREDITS", Quantity: 1, UnitPrice: 1000}}},
+		{OrderNo: "OLD-2", Status: models.OrderStatusRefunded, Currency: "USD", TotalAmount: 1000, PaidAt: &paidAt, RefundedAt: &refundedAt, Items: []models.OrderItem{{SKU: "CREDITS", Quantity: 1, UnitPrice: 1000}}},
+		{OrderNo: "OLD-3", Status: models.OrderStatusCompleted, Currency: "USD", TotalAmount: 500, PaidAt: &paidAt, Items: []models.OrderItem{{SKU: "TEE", Quantity: 1, UnitPrice: 500}}},
+	}
+	if err := svc.db.Create(&orders).Error; err != nil {
+		t.Fatalf("create orders failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		created, err := svc.Backfill(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("backfill failed: %v", err)
+		}
+		if want := []int{2, 0}[i]; created != want {
+			t.Fatalf("backfill run %d: expected %d schedules, got %d", i+1, want, created)
+		}
+	}
+
+	var cancelled models.RevenueSchedule
+	if err := svc.db.Where("order_no = ?", "OLD-2").First(&cancelled).Error; err != nil {
+		t.Fatalf("load refunded schedule failed: %v", err)
+	}
+	// 1000 分 3 个月确认为 334/333/333，4 月退款释放 4、5 月的 666
+	if cancelled.Status != models.RevenueScheduleStatusCancelled || cancelled.CancelledPeriod != "2026-04" || cancelled.ReleasedAmount != 666 {
+		t.Fatalf("unexpected refunded schedule %+v", cancelled)
+	}
+}
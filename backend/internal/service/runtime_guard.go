@@ -1,14 +1,61 @@
 package service
 
 import (
-	"context"
+	"fmt"
 	"log"
 	"runtime/debug"
+	"sort"
+	"sync"
 	"time"
 )
 
+// BackgroundServiceFailure 后台任务 panic 记录（进程内，重启后清空）
+type BackgroundServiceFailure struct {
+	Name       string    `json:"name"`
+	Count      int       `json:"count"`
+	LastError  string    `json:"last_error"`
+	LastFailAt time.Time `json:"last_failed_at"`
+}
+
+var (
+	backgroundServiceFailuresMu sync.Mutex
+	backgroundServiceFailures   = make(map[string]*BackgroundServiceFailure)
+)
+
+func recordBackgroundServicePanic(name string, recovered interface{}) {
+	backgroundServiceFailuresMu.Lock()
+	defer backgroundServiceFailuresMu.Unlock()
+
+	failure, ok := backgroundServiceFailures[name]
+	if !ok {
+		failure = &BackgroundServiceFailure{Name: name}
+		backgroundServiceFailures[name] = failure
+	}
+	failure.Count++
+	failure.LastError = fmt.Sprint(recovered)
+	failure.LastFailAt = time.Now()
+}
+
+// RecentBackgroundServiceFailures 返回 since 之后发生过 panic 的后台任务，按最近失败时间倒序
+func RecentBackgroundServiceFailures(since time.Time) []BackgroundServiceFailure {
+	backgroundServiceFailuresMu.Lock()
+	defer backgroundServiceFailuresMu.Unlock()
+
+	failures := make([]BackgroundServiceFailure, 0, len(backgroundServiceFailures))
+	for _, failure := range backgroundServiceFailures {
+		if failure.LastFailAt.After(since) {
+			failures = append(failures, *failure)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].LastFailAt.After(failures[j].LastFailAt)
+	})
+	return failures
+}
+
 func recoverBackgroundServicePanic(name string) {
 	if recovered := recover(); recovered != nil {
+		recordBackgroundServicePanic(name, recovered)
 		log.Printf("[panic-guard] %s panic recovered: %v\n%s", name, recovered, debug.Stack())
 	}
 }
@@ -24,18 +71,6 @@ func runBackgroundServiceWithStopChan(name string, stopChan <-chan struct{}, loo
 	)
 }
 
-func runBackgroundServiceWithContext(name string, ctx context.Context, loop func(ctx context.Context)) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	runBackgroundServiceWithRestart(
-		name,
-		func() bool { return isBackgroundServiceContextDone(ctx) },
-		func(delay time.Duration) bool { return waitBackgroundServiceContext(ctx, delay) },
-		func() { loop(ctx) },
-	)
-}
-
 func runBackgroundServiceWithRestart(
 	name string,
 	shouldStop func() bool,
@@ -48,6 +83,7 @@ func runBackgroundServiceWithRestart(
 			defer func() {
 				if recovered := recover(); recovered != nil {
 					panicked = true
+					recordBackgroundServicePanic(name, recovered)
 					log.Printf("[panic-guard] %s panic recovered: %v\n%s", name, recovered, debug.Stack())
 				}
 			}()
@@ -96,32 +132,3 @@ func waitBackgroundServiceStopChan(stopChan <-chan struct{}, delay time.Duration
 		return false
 	}
 }
-
-func isBackgroundServiceContextDone(ctx context.Context) bool {
-	if ctx == nil {
-		return false
-	}
-	select {
-	case <-ctx.Done():
-		return true
-	default:
-		return false
-	}
-}
-
-func waitBackgroundServiceContext(ctx context.Context, delay time.Duration) bool {
-	if ctx == nil {
-		time.Sleep(delay)
-		return false
-	}
-
-	timer := time.NewTimer(delay)
-	defer timer.Stop()
-
-	select {
-	case <-ctx.Done():
-		return true
-	case <-timer.C:
-		return false
-	}
-}
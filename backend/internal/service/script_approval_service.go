@@ -0,0 +1,284 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+// 差异计算的规模上限（行数乘积），超过后退化为整段替换，避免超大脚本占用过多内存
+const scriptDiffMaxCells = 4_000_000
+
+// ScriptDiffLine 单行差异，Op 为 " "（未变）、"-"（删除）、"+"（新增）
+type ScriptDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// ScriptRevisionDiff 修订与提交时生效版本之间的差异
+type ScriptRevisionDiff struct {
+	Revision     models.VirtualInventoryScriptRevision `json:"revision"`
+	Script       []ScriptDiffLine                      `json:"script"`
+	ScriptConfig []ScriptDiffLine                      `json:"script_config"`
+	// Stale 表示提交后生效脚本已被其他途径修改，批准前需要重新提交
+	Stale bool `json:"stale"`
+}
+
+// ScriptApprovalService 发货脚本四眼审批
+type ScriptApprovalService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewScriptApprovalService(db *gorm.DB, cfg *config.Config) *ScriptApprovalService {
+	return &ScriptApprovalService{db: db, cfg: cfg}
+}
+
+// Required 是否开启脚本修改审批
+func (s *ScriptApprovalService) Required() bool {
+	return s != nil && s.cfg != nil && s.cfg.Order.RequireScriptApproval
+}
+
+func newScriptRevisionNotFoundError() error {
+	return bizerr.New("virtual_inventory.scriptRevisionNotFound", "Script revision not found")
+}
+
+func newScriptRevisionNotPendingError(status string) error {
+	return bizerr.Newf("virtual_inventory.scriptRevisionNotPending", "Script revision is already %s", status).
+		WithParams(map[string]interface{}{"status": status})
+}
+
+func newScriptRevisionSelfApprovalError() error {
+	return bizerr.New("virtual_inventory.scriptRevisionSelfApproval", "A script change must be approved by a different administrator")
+}
+
+func newScriptRevisionStaleError() error {
+	return bizerr.New("virtual_inventory.scriptRevisionStale", "The active script has changed since this revision was submitted, please submit it again")
+}
+
+// Submit 提交脚本修订，同一库存之前未处理的修订会被标记为已取代
+func (s *ScriptApprovalService) Submit(inventory *models.VirtualInventory, script, scriptConfig string, submitterID uint, note string) (*models.VirtualInventoryScriptRevision, error) {
+	if strings.TrimSpace(script) == "" {
+		return nil, bizerr.New("virtual_inventory.scriptRequired", "Script content is required")
+	}
+	if len(note) > 500 {
+		note = note[:500]
+	}
+	revision := &models.VirtualInventoryScriptRevision{
+		VirtualInventoryID: inventory.ID,
+		Status:             models.ScriptRevisionStatusPending,
+		BaseScript:         inventory.Script,
+		BaseScriptConfig:   inventory.ScriptConfig,
+		Script:             script,
+		ScriptConfig:       scriptConfig,
+		SwitchToScript:     inventory.Type != models.VirtualInventoryTypeScript,
+		SubmittedBy:        submitterID,
+		SubmitNote:         note,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.VirtualInventoryScriptRevision{}).
+			Where("virtual_inventory_id = ? AND status = ?", inventory.ID, models.ScriptRevisionStatusPending).
+			Update("status", models.ScriptRevisionStatusSuperseded).Error; err != nil {
+			return err
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// List 查询脚本修订，inventoryID 为 0 时返回全部库存，status 为空时不过滤
+func (s *ScriptApprovalService) List(inventoryID uint, status string, page, limit int) ([]models.VirtualInventoryScriptRevision, int64, error) {
+	query := s.db.Model(&models.VirtualInventoryScriptRevision{})
+	if inventoryID > 0 {
+		query = query.Where("virtual_inventory_id = ?", inventoryID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var revisions []models.VirtualInventoryScriptRevision
+	if err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+func (s *ScriptApprovalService) get(tx *gorm.DB, inventoryID, revisionID uint) (*models.VirtualInventoryScriptRevision, error) {
+	var revision models.VirtualInventoryScriptRevision
+	if err := tx.Where("id = ? AND virtual_inventory_id = ?", revisionID, inventoryID).First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newScriptRevisionNotFoundError()
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// Diff 返回修订相对提交时生效版本的逐行差异
+func (s *ScriptApprovalService) Diff(inventoryID, revisionID uint) (*ScriptRevisionDiff, error) {
+	revision, err := s.get(s.db, inventoryID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ScriptRevisionDiff{
+		Revision:     *revision,
+		Script:       diffScriptLines(revision.BaseScript, revision.Script),
+		ScriptConfig: diffScriptLines(revision.BaseScriptConfig, revision.ScriptConfig),
+	}
+	if revision.Status == models.ScriptRevisionStatusPending {
+		var inventory models.VirtualInventory
+		if err := s.db.Select("id, script, script_config").First(&inventory, inventoryID).Error; err != nil {
+			return nil, err
+		}
+		result.Stale = inventory.Script != revision.BaseScript || inventory.ScriptConfig != revision.BaseScriptConfig
+	}
+	return result, nil
+}
+
+// Approve 由另一名管理员批准修订，并在同一事务中让脚本生效
+func (s *ScriptApprovalService) Approve(inventoryID, revisionID, reviewerID uint, note string) (*models.VirtualInventoryScriptRevision, error) {
+	return s.review(inventoryID, revisionID, reviewerID, note, true)
+}
+
+// Reject 驳回修订，生效脚本保持不变
+func (s *ScriptApprovalService) Reject(inventoryID, revisionID, reviewerID uint, note string) (*models.VirtualInventoryScriptRevision, error) {
+	return s.review(inventoryID, revisionID, reviewerID, note, false)
+}
+
+func (s *ScriptApprovalService) review(inventoryID, revisionID, reviewerID uint, note string, approve bool) (*models.VirtualInventoryScriptRevision, error) {
+	if len(note) > 500 {
+		note = note[:500]
+	}
+	var revision *models.VirtualInventoryScriptRevision
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		revision, err = s.get(tx, inventoryID, revisionID)
+		if err != nil {
+			return err
+		}
+		if revision.Status != models.ScriptRevisionStatusPending {
+			return newScriptRevisionNotPendingError(revision.Status)
+		}
+		// 驳回不受限制，批准必须由提交人以外的管理员完成
+		if approve && revision.SubmittedBy == reviewerID {
+			return newScriptRevisionSelfApprovalError()
+		}
+
+		status := models.ScriptRevisionStatusRejected
+		if approve {
+			status = models.ScriptRevisionStatusApproved
+			updates := map[string]interface{}{
+				"script":        revision.Script,
+				"script_config": revision.ScriptConfig,
+				"version":       gorm.Expr("version + 1"),
+			}
+			if revision.SwitchToScript {
+				updates["type"] = models.VirtualInventoryTypeScript
+			}
+			// 以提交时的生效版本作为条件，防止覆盖审批期间的其他修改
+			result := tx.Model(&models.VirtualInventory{}).
+				Where("id = ? AND script = ? AND script_config = ?", inventoryID, revision.BaseScript, revision.BaseScriptConfig).
+				Updates(updates)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return newScriptRevisionStaleError()
+			}
+		}
+
+		now := models.NowFunc()
+		revision.Status = status
+		revision.ReviewedBy = &reviewerID
+		revision.ReviewNote = note
+		revision.ReviewedAt = &now
+		return tx.Model(&models.VirtualInventoryScriptRevision{}).
+			Where("id = ? AND status = ?", revision.ID, models.ScriptRevisionStatusPending).
+			Updates(map[string]interface{}{
+				"status":      status,
+				"reviewed_by": reviewerID,
+				"review_note": note,
+				"reviewed_at": now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// diffScriptLines 基于最长公共子序列的逐行差异
+func diffScriptLines(before, after string) []ScriptDiffLine {
+	a := splitScriptLines(before)
+	b := splitScriptLines(after)
+	if len(a)*len(b) > scriptDiffMaxCells {
+		lines := make([]ScriptDiffLine, 0, len(a)+len(b))
+		for _, line := range a {
+			lines = append(lines, ScriptDiffLine{Op: "-", Text: line})
+		}
+		for _, line := range b {
+			lines = append(lines, ScriptDiffLine{Op: "+", Text: line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] 为 a[i:] 与 b[j:] 的最长公共子序列长度
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else if lcs[(i+1)*width+j] >= lcs[i*width+j+1] {
+				lcs[i*width+j] = lcs[(i+1)*width+j]
+			} else {
+				lcs[i*width+j] = lcs[i*width+j+1]
+			}
+		}
+	}
+
+	lines := make([]ScriptDiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, ScriptDiffLine{Op: " ", Text: a[i]})
+			i++
+			j++
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			lines = append(lines, ScriptDiffLine{Op: "-", Text: a[i]})
+			i++
+		default:
+			lines = append(lines, ScriptDiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, ScriptDiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, ScriptDiffLine{Op: "+", Text: b[j]})
+	}
+	return lines
+}
+
+func splitScriptLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestScriptApprovalRequiresSecondAdmin(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventoryScriptRevision{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	inventory := &models.VirtualInventory{
+		Name:   "script",
+		Type:   models.VirtualInventoryTypeScript,
+		Script: "function onDeliver() {\n  return fetchA()\n}",
+	}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+
+	approval := NewScriptApprovalService(db, &config.Config{Order: config.OrderConfig{RequireScriptApproval: true}})
+	if !approval.Required() {
+		t.Fatalf("expected approval to be required")
+	}
+
+	first, err := approval.Submit(inventory, "function onDeliver() {\n  return fetchB()\n}", "", 1, "switch upstream")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	revision, err := approval.Submit(inventory, "function onDeliver() {\n  return fetchC()\n}", "", 1, "")
+	if err != nil {
+		t.Fatalf("submit second: %v", err)
+	}
+
+	var superseded models.VirtualInventoryScriptRevision
+	if err := db.First(&superseded, first.ID).Error; err != nil {
+		t.Fatalf("reload first revision: %v", err)
+	}
+	if superseded.Status != models.ScriptRevisionStatusSuperseded {
+		t.Fatalf("expected first revision to be superseded, got %s", superseded.Status)
+	}
+
+	diff, err := approval.Diff(inventory.ID, revision.ID)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	want := []ScriptDiffLine{
+		{Op: " ", Text: "function onDeliver() {"},
+		{Op: "-", Text: "  return fetchA()"},
+		{Op: "+", Text: "  return fetchC()"},
+		{Op: " ", Text: "}"},
+	}
+	if len(diff.Script) != len(want) {
+		t.Fatalf("unexpected diff: %+v", diff.Script)
+	}
+	for i := range want {
+		if diff.Script[i] != want[i] {
+			t.Fatalf("diff line %d = %+v, want %+v", i, diff.Script[i], want[i])
+		}
+	}
+
+	_, err = approval.Approve(inventory.ID, revision.ID, 1, "")
+	requireBizErr(t, err, "virtual_inventory.scriptRevisionSelfApproval")
+
+	var stored models.VirtualInventory
+	if err := db.First(&stored, inventory.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if stored.Script != inventory.Script {
+		t.Fatalf("script must not change before approval")
+	}
+
+	approved, err := approval.Approve(inventory.ID, revision.ID, 2, "looks good")
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if approved.Status != models.ScriptRevisionStatusApproved || approved.ReviewedBy == nil || *approved.ReviewedBy != 2 {
+		t.Fatalf("unexpected approved revision: %+v", approved)
+	}
+	if err := db.First(&stored, inventory.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if stored.Script != revision.Script {
+		t.Fatalf("expected approved script to be active, got %q", stored.Script)
+	}
+
+	_, err = approval.Reject(inventory.ID, revision.ID, 2, "")
+	requireBizErr(t, err, "virtual_inventory.scriptRevisionNotPending")
+}
+
+func TestScriptApprovalRejectsStaleRevision(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventoryScriptRevision{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	inventory := &models.VirtualInventory{Name: "script", Type: models.VirtualInventoryTypeScript, Script: "v1"}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+
+	approval := NewScriptApprovalService(db, &config.Config{Order: config.OrderConfig{RequireScriptApproval: true}})
+	revision, err := approval.Submit(inventory, "v2", "", 1, "")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if err := db.Model(inventory).Update("script", "v1-hotfix").Error; err != nil {
+		t.Fatalf("update script: %v", err)
+	}
+
+	diff, err := approval.Diff(inventory.ID, revision.ID)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !diff.Stale {
+		t.Fatalf("expected revision to be reported as stale")
+	}
+	_, err = approval.Approve(inventory.ID, revision.ID, 2, "")
+	requireBizErr(t, err, "virtual_inventory.scriptRevisionStale")
+}
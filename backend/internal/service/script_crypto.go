@@ -0,0 +1,153 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// AuraLogic.crypto：发货脚本与付款方式脚本共用的摘要、HMAC 与编码工具
+// 供应商接口签名大多是 md5/sha1/sha256 + hex/base64，原生实现避免脚本自带 JS 哈希实现拖慢执行
+
+var scriptHashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// registerScriptCryptoAPI 在 AuraLogic 命名空间下注册 crypto 对象
+// 不支持的算法或编码抛出 TypeError；待解码内容无效时返回空字符串，与 utils.base64Decode 一致
+func registerScriptCryptoAPI(vm *goja.Runtime, auralogic *goja.Object) {
+	cryptoObj := vm.NewObject()
+	auralogic.Set("crypto", cryptoObj)
+
+	for name := range scriptHashAlgorithms {
+		algorithm := name
+		cryptoObj.Set(algorithm, func(call goja.FunctionCall) goja.Value {
+			digest := scriptDigest(vm, algorithm, []byte(call.Argument(0).String()))
+			return vm.ToValue(scriptEncode(vm, digest, scriptStringArg(call.Argument(1), "hex")))
+		})
+	}
+
+	// hmac(algorithm, key, data, options?)：options 可为输出编码字符串，或 {encoding, keyEncoding}
+	cryptoObj.Set("hmac", func(call goja.FunctionCall) goja.Value {
+		algorithm := strings.ToLower(call.Argument(0).String())
+		newHash, ok := scriptHashAlgorithms[algorithm]
+		if !ok {
+			panic(vm.NewTypeError(fmt.Sprintf("unsupported hash algorithm: %s", algorithm)))
+		}
+		encoding, keyEncoding := "hex", "utf8"
+		if options := call.Argument(3); !goja.IsUndefined(options) && !goja.IsNull(options) {
+			if obj, isObject := options.(*goja.Object); isObject {
+				encoding = scriptStringArg(obj.Get("encoding"), encoding)
+				keyEncoding = scriptStringArg(obj.Get("keyEncoding"), keyEncoding)
+			} else {
+				encoding = options.String()
+			}
+		}
+		key, ok := scriptDecode(vm, call.Argument(1).String(), keyEncoding)
+		if !ok {
+			return vm.ToValue("")
+		}
+		mac := hmac.New(newHash, key)
+		_, _ = mac.Write([]byte(call.Argument(2).String()))
+		return vm.ToValue(scriptEncode(vm, mac.Sum(nil), encoding))
+	})
+
+	cryptoObj.Set("base64Encode", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(base64.StdEncoding.EncodeToString([]byte(call.Argument(0).String())))
+	})
+	cryptoObj.Set("base64Decode", func(call goja.FunctionCall) goja.Value {
+		decoded, _ := scriptDecode(vm, call.Argument(0).String(), "base64")
+		return vm.ToValue(string(decoded))
+	})
+	cryptoObj.Set("base64UrlEncode", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(base64.RawURLEncoding.EncodeToString([]byte(call.Argument(0).String())))
+	})
+	cryptoObj.Set("base64UrlDecode", func(call goja.FunctionCall) goja.Value {
+		decoded, _ := scriptDecode(vm, call.Argument(0).String(), "base64url")
+		return vm.ToValue(string(decoded))
+	})
+	cryptoObj.Set("hexEncode", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(hex.EncodeToString([]byte(call.Argument(0).String())))
+	})
+	cryptoObj.Set("hexDecode", func(call goja.FunctionCall) goja.Value {
+		decoded, _ := scriptDecode(vm, call.Argument(0).String(), "hex")
+		return vm.ToValue(string(decoded))
+	})
+
+	// timingSafeEqual 常量时间比较，用于校验回调签名，避免逐字节比较泄露匹配长度
+	cryptoObj.Set("timingSafeEqual", func(call goja.FunctionCall) goja.Value {
+		a := []byte(call.Argument(0).String())
+		b := []byte(call.Argument(1).String())
+		return vm.ToValue(subtle.ConstantTimeCompare(a, b) == 1)
+	})
+}
+
+func scriptDigest(vm *goja.Runtime, algorithm string, data []byte) []byte {
+	newHash, ok := scriptHashAlgorithms[algorithm]
+	if !ok {
+		panic(vm.NewTypeError(fmt.Sprintf("unsupported hash algorithm: %s", algorithm)))
+	}
+	h := newHash()
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+func scriptEncode(vm *goja.Runtime, data []byte, encoding string) string {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		return hex.EncodeToString(data)
+	case "base64":
+		return base64.StdEncoding.EncodeToString(data)
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(data)
+	default:
+		panic(vm.NewTypeError(fmt.Sprintf("unsupported encoding: %s", encoding)))
+	}
+}
+
+// scriptDecode 按编码解析脚本传入的字符串；utf8 原样返回，base64url 兼容带或不带填充
+func scriptDecode(vm *goja.Runtime, value string, encoding string) ([]byte, bool) {
+	var (
+		decoded []byte
+		err     error
+	)
+	switch strings.ToLower(encoding) {
+	case "utf8", "utf-8":
+		return []byte(value), true
+	case "hex":
+		decoded, err = hex.DecodeString(strings.TrimSpace(value))
+	case "base64":
+		decoded, err = base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	case "base64url":
+		decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+	default:
+		panic(vm.NewTypeError(fmt.Sprintf("unsupported encoding: %s", encoding)))
+	}
+	if err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+func scriptStringArg(value goja.Value, fallback string) string {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return fallback
+	}
+	if text := strings.TrimSpace(value.String()); text != "" {
+		return text
+	}
+	return fallback
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dop251/goja"
+)
+
+func runScriptCrypto(t *testing.T, source string) goja.Value {
+	t.Helper()
+	vm := goja.New()
+	auralogic := vm.NewObject()
+	vm.Set("AuraLogic", auralogic)
+	registerScriptCryptoAPI(vm, auralogic)
+	value, err := vm.RunString(source)
+	if err != nil {
+		t.Fatalf("run %q: %v", source, err)
+	}
+	return value
+}
+
+func TestScriptCryptoDigestsAndHMAC(t *testing.T) {
+	cases := []struct {
+		source string
+		want   string
+	}{
+		{`AuraLogic.crypto.md5('hello world')`, "5eb63bbbe01eeed093cb22bb8f5acdc3"},
+		{`AuraLogic.crypto.sha1('abc')`, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{`AuraLogic.crypto.sha256('abc', 'base64')`, "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0="},
+		{`AuraLogic.crypto.hmac('sha256', 'key', 'The quick brown fox jumps over the lazy dog')`, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{`AuraLogic.crypto.hmac('SHA256', '6b6579', 'The quick brown fox jumps over the lazy dog', {keyEncoding: 'hex'})`, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{`AuraLogic.crypto.base64UrlEncode('??>')`, "Pz8-"},
+		{`AuraLogic.crypto.hexDecode(AuraLogic.crypto.hexEncode('卡密'))`, "卡密"},
+	}
+	for _, tc := range cases {
+		if got := runScriptCrypto(t, tc.source).String(); got != tc.want {
+			t.Errorf("%s = %q, want %q", tc.source, got, tc.want)
+		}
+	}
+}
+
+func TestScriptCryptoTimingSafeEqualAndErrors(t *testing.T) {
+	if !runScriptCrypto(t, `AuraLogic.crypto.timingSafeEqual('abc', 'abc')`).ToBoolean() {
+		t.Fatalf("expected equal strings to match")
+	}
+	if runScriptCrypto(t, `AuraLogic.crypto.timingSafeEqual('abc', 'abd')`).ToBoolean() {
+		t.Fatalf("expected different strings not to match")
+	}
+	if got := runScriptCrypto(t, `AuraLogic.crypto.hmac('sha256', 'zz', 'x', {keyEncoding: 'hex'})`).String(); got != "" {
+		t.Fatalf("expected invalid hex key to return empty string, got %q", got)
+	}
+
+	vm := goja.New()
+	auralogic := vm.NewObject()
+	vm.Set("AuraLogic", auralogic)
+	registerScriptCryptoAPI(vm, auralogic)
+	if _, err := vm.RunString(`AuraLogic.crypto.hmac('crc32', 'k', 'x')`); err == nil || !strings.Contains(err.Error(), "unsupported hash algorithm") {
+		t.Fatalf("expected unsupported algorithm to throw, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/money"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	// 单次试运行最多保留的日志/HTTP 事件数，防止死循环打印撑爆内存和响应
+	scriptDryRunMaxEvents = 500
+	// 单条日志的最大长度（字节）
+	scriptDryRunMaxLogBytes = 4096
+)
+
+// ScriptDryRunHTTPMock 试运行时替代上游请求的模拟响应
+type ScriptDryRunHTTPMock struct {
+	Method string      `json:"method"` // 为空时匹配任意方法
+	URL    string      `json:"url"`    // 完整 URL；以 * 结尾时按前缀匹配
+	Status int         `json:"status"` // 为空时返回 200
+	Body   interface{} `json:"body"`   // 字符串原样返回，其他值按 JSON 编码并同时作为 data 返回
+}
+
+// ScriptDryRunOrder 试运行使用的模拟订单，字段为空时使用默认值
+type ScriptDryRunOrder struct {
+	OrderNo          string `json:"order_no"`
+	Currency         string `json:"currency"`
+	TotalAmountMinor int64  `json:"total_amount_minor"`
+	UserName         string `json:"user_name"`
+	UserEmail        string `json:"user_email"`
+}
+
+// ScriptDryRunOptions 试运行参数
+type ScriptDryRunOptions struct {
+	Quantity int
+	Order    ScriptDryRunOrder
+	// MockHTTP 为 true 时脚本的 HTTP 请求只返回 HTTPMocks 中的响应，未匹配的请求直接报错，不访问上游
+	MockHTTP  bool
+	HTTPMocks []ScriptDryRunHTTPMock
+	// OnEvent 每产生一条日志或 HTTP 调用记录时回调，用于流式输出
+	OnEvent func(ScriptDryRunEvent)
+}
+
+// ScriptDryRunEvent 试运行过程中的一条日志或 HTTP 调用记录
+type ScriptDryRunEvent struct {
+	Type       string    `json:"type"` // log / http / storage
+	Time       time.Time `json:"time"`
+	Message    string    `json:"message,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Status     int       `json:"status,omitempty"`
+	Mocked     bool      `json:"mocked,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Op         string    `json:"op,omitempty"`  // storage 事件的操作：set / del / clear
+	Key        string    `json:"key,omitempty"` // storage 事件的键
+	TTLSeconds int64     `json:"ttl_seconds,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// ScriptDryRunResult 试运行结果，脚本执行失败时 Success 为 false 并在 Error 中给出原因
+type ScriptDryRunResult struct {
+	Success         bool                   `json:"success"`
+	Items           []ScriptDeliveryItem   `json:"items"`
+	Message         string                 `json:"message,omitempty"`
+	Error           string                 `json:"error,omitempty"`
+	Quantity        int                    `json:"quantity"`
+	Order           map[string]interface{} `json:"order"`
+	Events          []ScriptDryRunEvent    `json:"events"`
+	EventsTruncated bool                   `json:"events_truncated,omitempty"`
+	DurationMs      int64                  `json:"duration_ms"`
+}
+
+// scriptDryRun 试运行期间替换 AuraLogic.system.log、AuraLogic.http.*、AuraLogic.storage 与 AuraLogic.order.getUser 的行为
+type scriptDryRun struct {
+	user      map[string]interface{}
+	storage   *scriptStorageOverlay
+	mockHTTP  bool
+	mocks     []ScriptDryRunHTTPMock
+	onEvent   func(ScriptDryRunEvent)
+	events    []ScriptDryRunEvent
+	truncated bool
+}
+
+func (d *scriptDryRun) emit(event ScriptDryRunEvent) {
+	if len(d.events) >= scriptDryRunMaxEvents {
+		d.truncated = true
+		return
+	}
+	event.Time = time.Now().UTC()
+	d.events = append(d.events, event)
+	if d.onEvent != nil {
+		d.onEvent(event)
+	}
+}
+
+// log 记录 AuraLogic.system.log 的输出，多个参数以空格拼接
+func (d *scriptDryRun) log(args []goja.Value) {
+	d.emit(ScriptDryRunEvent{Type: "log", Message: formatScriptLogArgs(args)})
+}
+
+// formatScriptLogArgs 拼接 AuraLogic.system.log 的参数并截断过长的输出
+func formatScriptLogArgs(args []goja.Value) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		parts = append(parts, arg.String())
+	}
+	message := strings.Join(parts, " ")
+	if len(message) > scriptDryRunMaxLogBytes {
+		message = message[:scriptDryRunMaxLogBytes] + "...(truncated)"
+	}
+	return message
+}
+
+// storageOp 记录 AuraLogic.storage 的写操作，写入只保存在覆盖层中
+func (d *scriptDryRun) storageOp(op scriptStorageOp) {
+	event := ScriptDryRunEvent{Type: "storage", Op: op.op, Key: op.key, TTLSeconds: int64(op.ttl / time.Second)}
+	if op.err != nil {
+		event.Error = op.err.Error()
+	}
+	d.emit(event)
+}
+
+// doHTTPRequest 优先返回匹配的模拟响应；未开启模拟时照常请求上游，但不计入供应商健康统计
+func (d *scriptDryRun) doHTTPRequest(s *ScriptDeliveryService, vm *goja.Runtime, executeCtx context.Context, method, urlStr string, body interface{}, headers map[string]string) goja.Value {
+	startedAt := time.Now()
+	normalizedMethod := strings.ToUpper(strings.TrimSpace(method))
+
+	var value goja.Value
+	mocked := false
+	if mock := d.matchMock(normalizedMethod, urlStr); mock != nil {
+		value = vm.ToValue(mock.response())
+		mocked = true
+	} else if d.mockHTTP {
+		value = vm.ToValue(map[string]interface{}{
+			"error":  fmt.Sprintf("No mock response configured for %s %s", normalizedMethod, urlStr),
+			"status": 0,
+		})
+	} else {
+		value = s.doHTTPRequest(vm, executeCtx, method, urlStr, body, headers)
+	}
+
+	event := ScriptDryRunEvent{
+		Type:       "http",
+		Method:     normalizedMethod,
+		URL:        urlStr,
+		Mocked:     mocked,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+	if result, ok := value.Export().(map[string]interface{}); ok {
+		if code, ok := result["status"].(int); ok {
+			event.Status = code
+		}
+		if msg, ok := result["error"].(string); ok {
+			event.Error = msg
+		}
+	}
+	d.emit(event)
+	return value
+}
+
+func (d *scriptDryRun) matchMock(method, urlStr string) *ScriptDryRunHTTPMock {
+	for i := range d.mocks {
+		mock := &d.mocks[i]
+		if mockMethod := strings.ToUpper(strings.TrimSpace(mock.Method)); mockMethod != "" && mockMethod != method {
+			continue
+		}
+		pattern := strings.TrimSpace(mock.URL)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(urlStr, prefix) {
+				return mock
+			}
+			continue
+		}
+		if pattern == urlStr {
+			return mock
+		}
+	}
+	return nil
+}
+
+// response 构造与真实请求相同结构的返回值 {status, body, data}
+func (m *ScriptDryRunHTTPMock) response() map[string]interface{} {
+	status := m.Status
+	if status == 0 {
+		status = 200
+	}
+	result := map[string]interface{}{"status": status}
+	switch body := m.Body.(type) {
+	case nil:
+		result["body"] = ""
+	case string:
+		result["body"] = body
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return map[string]interface{}{"error": fmt.Sprintf("Invalid mock body: %v", err), "status": 0}
+		}
+		result["body"] = string(encoded)
+		result["data"] = body
+	}
+	return result
+}
+
+// DryRunDeliveryScript 使用模拟订单试运行发货脚本，不读写真实订单、库存与发货记录
+func (s *ScriptDeliveryService) DryRunDeliveryScript(inventory *models.VirtualInventory, opts ScriptDryRunOptions) *ScriptDryRunResult {
+	quantity := opts.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	order := s.buildDryRunOrder(inventory, opts.Order, quantity)
+	userName := strings.TrimSpace(opts.Order.UserName)
+	if userName == "" {
+		userName = "Dry Run User"
+	}
+	userEmail := strings.TrimSpace(opts.Order.UserEmail)
+	if userEmail == "" {
+		userEmail = "dry-run@example.com"
+	}
+
+	dryRun := &scriptDryRun{
+		user: map[string]interface{}{
+			"id":    0,
+			"name":  userName,
+			"email": userEmail,
+		},
+		storage:  newScriptStorageOverlay(inventory.ID == 0),
+		mockHTTP: opts.MockHTTP,
+		mocks:    opts.HTTPMocks,
+		onEvent:  opts.OnEvent,
+	}
+
+	startedAt := time.Now()
+	deliveryResult, err := s.executeDeliveryScript(inventory, order, quantity, dryRun, nil)
+
+	result := &ScriptDryRunResult{
+		Quantity:        quantity,
+		Order:           s.orderToJS(order, quantity),
+		Events:          dryRun.events,
+		EventsTruncated: dryRun.truncated,
+		DurationMs:      time.Since(startedAt).Milliseconds(),
+	}
+	if result.Events == nil {
+		result.Events = []ScriptDryRunEvent{}
+	}
+	if deliveryResult != nil {
+		result.Items = deliveryResult.Items
+		result.Message = deliveryResult.Message
+	}
+	if result.Items == nil {
+		result.Items = []ScriptDeliveryItem{}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Success = true
+	}
+	return result
+}
+
+func (s *ScriptDeliveryService) buildDryRunOrder(inventory *models.VirtualInventory, input ScriptDryRunOrder, quantity int) *models.Order {
+	orderNo := strings.TrimSpace(input.OrderNo)
+	if orderNo == "" {
+		orderNo = "DRYRUN-" + time.Now().UTC().Format("20060102150405")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(input.Currency))
+	if currency == "" {
+		currency = "CNY"
+	}
+	totalAmountMinor := input.TotalAmountMinor
+	if totalAmountMinor <= 0 {
+		totalAmountMinor = 9999
+	}
+	// orderToJS 会按库中金额单位换算，这里反向换算保证脚本看到的 total_amount_minor 与输入一致
+	totalAmount := totalAmountMinor
+	if !s.moneyMinorUnits {
+		totalAmount = totalAmountMinor / money.CurrencyScale
+	}
+
+	return &models.Order{
+		OrderNo:     orderNo,
+		Status:      models.OrderStatusPending,
+		TotalAmount: totalAmount,
+		Currency:    currency,
+		CreatedAt:   time.Now().UTC(),
+		Items: []models.OrderItem{{
+			SKU:         inventory.SKU,
+			Name:        inventory.Name,
+			Quantity:    quantity,
+			ProductType: models.ProductTypeVirtual,
+		}},
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/tracing"
+
+	"github.com/dop251/goja"
+)
+
+// ScriptRestockRequest 传给供应商脚本 onRestock 的采购请求
+type ScriptRestockRequest struct {
+	Quantity  int    // 本次需要采购的数量
+	Available int64  // 当前可用库存
+	Budget    int64  // 剩余可用金额（最小货币单位），小于 0 表示不限制
+	Currency  string // 金额币种
+}
+
+// ScriptRestockResult 供应商脚本返回的采购结果
+type ScriptRestockResult struct {
+	Success   bool
+	Items     []ScriptDeliveryItem
+	CostMinor int64  // 本次采购实际花费（最小货币单位）
+	Reference string // 供应商侧订单号
+	Message   string
+}
+
+// ExecuteRestockScript 执行补货策略的供应商脚本
+// 调用脚本中的 onRestock(request, config)，可使用与发货脚本相同的 http/storage/crypto 等 API（没有 order）
+// 上游请求计入所属库存的供应商健康统计，日志与请求记录到 audit
+func (s *ScriptDeliveryService) ExecuteRestockScript(
+	inventory *models.VirtualInventory,
+	policy *models.VirtualInventoryRestockPolicy,
+	request ScriptRestockRequest,
+	audit *scriptExecutionAudit,
+) (result *ScriptRestockResult, err error) {
+	traceCtx, span := tracing.Start(context.Background(), "ScriptDeliveryService.ExecuteRestockScript", tracing.KindInternal)
+	span.SetAttribute("virtual_inventory.id", inventory.ID)
+	span.SetAttribute("restock.quantity", request.Quantity)
+	defer func() {
+		span.EndWithError(err)
+	}()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("restock script panic: %v", recovered)
+			result = nil
+		}
+	}()
+
+	if strings.TrimSpace(policy.Script) == "" {
+		return nil, fmt.Errorf("restock policy for inventory %d has no script", inventory.ID)
+	}
+
+	configData := s.parseScriptConfig(policy.ScriptConfig)
+	executionTimeout := time.Duration(s.resolveExecutionTimeoutMs(parseScriptDeliveryTimeoutMs(configData))) * time.Millisecond
+	executeCtx, cancel := context.WithTimeout(traceCtx, executionTimeout)
+	defer cancel()
+
+	vm := goja.New()
+	timer := time.AfterFunc(executionTimeout, func() {
+		vm.Interrupt("execution timeout")
+	})
+	defer timer.Stop()
+
+	ctx := &ScriptDeliveryContext{
+		VirtualInventoryID: inventory.ID,
+		Quantity:           request.Quantity,
+		audit:              audit,
+	}
+	s.registerAPIs(vm, executeCtx, ctx, nil, configData, nil)
+
+	program, err := getOrCompileJSProgram("virtual_inventory_restock", policy.Script)
+	if err != nil {
+		return nil, fmt.Errorf("script compile error: %w", err)
+	}
+	if _, err := vm.RunProgram(program); err != nil {
+		return nil, fmt.Errorf("script execution error: %w", err)
+	}
+
+	fn, ok := goja.AssertFunction(vm.Get("onRestock"))
+	if !ok {
+		return nil, fmt.Errorf("onRestock function not found in script")
+	}
+
+	requestData := map[string]interface{}{
+		"quantity":  request.Quantity,
+		"available": request.Available,
+		"currency":  request.Currency,
+		"inventory": map[string]interface{}{
+			"id":   inventory.ID,
+			"name": inventory.Name,
+			"sku":  inventory.SKU,
+		},
+	}
+	if request.Budget >= 0 {
+		requestData["budget_minor"] = request.Budget
+	} else {
+		requestData["budget_minor"] = nil
+	}
+	resultValue, err := fn(goja.Undefined(), vm.ToValue(requestData), vm.ToValue(configData))
+	if err != nil {
+		return nil, fmt.Errorf("onRestock execution error: %w", err)
+	}
+
+	return parseRestockResult(resultValue)
+}
+
+// parseRestockResult 解析 {success, items, cost_minor, reference, message}
+// 脚本报告失败时仍返回已解析的花费，供应商可能已扣款
+func parseRestockResult(value goja.Value) (*ScriptRestockResult, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, fmt.Errorf("script returned empty result")
+	}
+	obj, ok := value.Export().(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("script must return an object with {success, items}")
+	}
+
+	result := &ScriptRestockResult{}
+	if success, ok := obj["success"].(bool); ok {
+		result.Success = success
+	}
+	if msg, ok := obj["message"].(string); ok {
+		result.Message = msg
+	}
+	if reference, ok := obj["reference"]; ok && reference != nil {
+		result.Reference = strings.TrimSpace(fmt.Sprint(reference))
+	}
+	switch cost := obj["cost_minor"].(type) {
+	case int64:
+		result.CostMinor = cost
+	case float64:
+		result.CostMinor = int64(cost)
+	}
+	if result.CostMinor < 0 {
+		log.Printf("[ScriptRestock] Warning: negative cost_minor %d ignored", result.CostMinor)
+		result.CostMinor = 0
+	}
+
+	if !result.Success {
+		msg := result.Message
+		if msg == "" {
+			msg = "restock script failed"
+		}
+		return result, fmt.Errorf("restock script failed: %s", msg)
+	}
+
+	if items, ok := obj["items"].([]interface{}); ok {
+		for _, item := range items {
+			switch entry := item.(type) {
+			case string:
+				result.Items = append(result.Items, ScriptDeliveryItem{Content: entry})
+			case map[string]interface{}:
+				di := ScriptDeliveryItem{}
+				if content, ok := entry["content"].(string); ok {
+					di.Content = content
+				}
+				if remark, ok := entry["remark"].(string); ok {
+					di.Remark = remark
+				}
+				result.Items = append(result.Items, di)
+			}
+		}
+	}
+	if len(result.Items) == 0 {
+		return result, fmt.Errorf("script returned no items")
+	}
+	return result, nil
+}
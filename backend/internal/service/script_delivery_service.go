@@ -17,6 +17,7 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/tracing"
 
 	"github.com/dop251/goja"
 	"gorm.io/gorm"
@@ -55,6 +56,7 @@ type ScriptDeliveryService struct {
 	cfg               *config.Config
 	httpClientFactory func() *http.Client
 	moneyMinorUnits   bool
+	supplierHealth    *SupplierHealthService
 }
 
 // NewScriptDeliveryService 创建脚本发货服务
@@ -64,6 +66,9 @@ func NewScriptDeliveryService(db *gorm.DB, cfg *config.Config) *ScriptDeliverySe
 		cfg:               cfg,
 		httpClientFactory: getPaymentHTTPClient,
 	}
+	if db != nil {
+		svc.supplierHealth = NewSupplierHealthService(db, cfg)
+	}
 	svc.moneyMinorUnits = svc.detectMoneyMinorUnits()
 	return svc
 }
@@ -91,15 +96,48 @@ type ScriptDeliveryContext struct {
 	OrderID            uint
 	OrderNo            string
 	Quantity           int
+
+	audit *scriptExecutionAudit // 真实发货时收集审计事件，试运行与测试脚本为空
 }
 
 // ExecuteDeliveryScript 执行发货脚本
 // 调用脚本中的 onDeliver(order, config) 函数，返回发货结果
+// 真实订单的每次执行都会写入 script_executions 审计记录；测试脚本（无所属库存或订单）不记录
 func (s *ScriptDeliveryService) ExecuteDeliveryScript(
 	inventory *models.VirtualInventory,
 	order *models.Order,
 	quantity int,
+) (*ScriptDeliveryResult, error) {
+	if s.db == nil || inventory == nil || inventory.ID == 0 || order == nil || order.ID == 0 {
+		return s.executeDeliveryScript(inventory, order, quantity, nil, nil)
+	}
+	audit := &scriptExecutionAudit{}
+	startedAt := time.Now()
+	result, err := s.executeDeliveryScript(inventory, order, quantity, nil, audit)
+	s.recordExecution(inventory, order, quantity, audit, time.Since(startedAt), result, err)
+	return result, err
+}
+
+// executeDeliveryScript 执行发货脚本；dryRun 不为空时为试运行，日志与 HTTP 调用交由 dryRun 处理
+// audit 不为空时额外记录日志与 HTTP 调用用于执行审计
+func (s *ScriptDeliveryService) executeDeliveryScript(
+	inventory *models.VirtualInventory,
+	order *models.Order,
+	quantity int,
+	dryRun *scriptDryRun,
+	audit *scriptExecutionAudit,
 ) (result *ScriptDeliveryResult, err error) {
+	// 发货通常在支付回调或后台任务中执行，没有上游请求链路，每次执行作为一条独立的 trace
+	traceCtx, span := tracing.Start(context.Background(), "ScriptDeliveryService.ExecuteDeliveryScript", tracing.KindInternal)
+	span.SetAttribute("virtual_inventory.id", inventory.ID)
+	span.SetAttribute("delivery.quantity", quantity)
+	span.SetAttribute("delivery.dry_run", dryRun != nil)
+	if order != nil {
+		span.SetAttribute("order.no", order.OrderNo)
+	}
+	defer func() {
+		span.EndWithError(err)
+	}()
 	defer func() {
 		if recovered := recover(); recovered != nil {
 			err = fmt.Errorf("script delivery panic: %v", recovered)
@@ -124,7 +162,7 @@ func (s *ScriptDeliveryService) ExecuteDeliveryScript(
 		)
 	}
 
-	executeCtx, cancel := context.WithTimeout(context.Background(), executionTimeout)
+	executeCtx, cancel := context.WithTimeout(traceCtx, executionTimeout)
 	defer cancel()
 
 	vm := goja.New()
@@ -139,10 +177,11 @@ func (s *ScriptDeliveryService) ExecuteDeliveryScript(
 		OrderID:            order.ID,
 		OrderNo:            order.OrderNo,
 		Quantity:           quantity,
+		audit:              audit,
 	}
 
 	// 注册API
-	s.registerAPIs(vm, executeCtx, ctx, order, configData)
+	s.registerAPIs(vm, executeCtx, ctx, order, configData, dryRun)
 
 	// 执行脚本
 	program, err := getOrCompileJSProgram("virtual_inventory_delivery", inventory.Script)
@@ -177,42 +216,49 @@ func (s *ScriptDeliveryService) registerAPIs(
 	ctx *ScriptDeliveryContext,
 	order *models.Order,
 	configData map[string]interface{},
+	dryRun *scriptDryRun,
 ) {
 	auralogic := vm.NewObject()
 	vm.Set("AuraLogic", auralogic)
 
-	// 订单API（只读）
-	orderObj := vm.NewObject()
-	auralogic.Set("order", orderObj)
-	orderObj.Set("get", func(call goja.FunctionCall) goja.Value {
-		return vm.ToValue(s.orderToJS(order, ctx.Quantity))
-	})
-	orderObj.Set("getItems", func(call goja.FunctionCall) goja.Value {
-		var result []map[string]interface{}
-		for _, item := range order.Items {
-			result = append(result, map[string]interface{}{
-				"sku":          item.SKU,
-				"name":         item.Name,
-				"quantity":     item.Quantity,
-				"product_type": item.ProductType,
+	// 订单API（只读），补货脚本没有关联订单时不注册
+	if order != nil {
+		orderObj := vm.NewObject()
+		auralogic.Set("order", orderObj)
+		orderObj.Set("get", func(call goja.FunctionCall) goja.Value {
+			return vm.ToValue(s.orderToJS(order, ctx.Quantity))
+		})
+		orderObj.Set("getItems", func(call goja.FunctionCall) goja.Value {
+			var result []map[string]interface{}
+			for _, item := range order.Items {
+				result = append(result, map[string]interface{}{
+					"sku":          item.SKU,
+					"name":         item.Name,
+					"quantity":     item.Quantity,
+					"product_type": item.ProductType,
+				})
+			}
+			return vm.ToValue(result)
+		})
+		orderObj.Set("getUser", func(call goja.FunctionCall) goja.Value {
+			if dryRun != nil {
+				// 试运行使用模拟用户，不读取真实用户数据
+				return vm.ToValue(dryRun.user)
+			}
+			if order.UserID == nil {
+				return goja.Undefined()
+			}
+			var user models.User
+			if err := s.db.First(&user, *order.UserID).Error; err != nil {
+				return goja.Undefined()
+			}
+			return vm.ToValue(map[string]interface{}{
+				"id":    user.ID,
+				"name":  user.Name,
+				"email": user.Email,
 			})
-		}
-		return vm.ToValue(result)
-	})
-	orderObj.Set("getUser", func(call goja.FunctionCall) goja.Value {
-		if order.UserID == nil {
-			return goja.Undefined()
-		}
-		var user models.User
-		if err := s.db.First(&user, *order.UserID).Error; err != nil {
-			return goja.Undefined()
-		}
-		return vm.ToValue(map[string]interface{}{
-			"id":    user.ID,
-			"name":  user.Name,
-			"email": user.Email,
 		})
-	})
+	}
 
 	// 工具API
 	utils := vm.NewObject()
@@ -245,6 +291,9 @@ func (s *ScriptDeliveryService) registerAPIs(
 		return vm.ToValue(time.Now().Format(time.RFC3339))
 	})
 
+	// 摘要/HMAC/编码 API
+	registerScriptCryptoAPI(vm, auralogic)
+
 	// HTTP API（使用 SSRF-safe 客户端）
 	httpObj := vm.NewObject()
 	auralogic.Set("http", httpObj)
@@ -252,7 +301,7 @@ func (s *ScriptDeliveryService) registerAPIs(
 		if len(call.Arguments) < 1 {
 			return vm.ToValue(map[string]interface{}{"error": "URL is required", "status": 0})
 		}
-		return s.doHTTPRequest(vm, executeCtx, "GET", call.Arguments[0].String(), nil, s.extractHeaders(call, 1))
+		return s.doScriptHTTPRequest(vm, executeCtx, ctx, dryRun, "GET", call.Arguments[0].String(), nil, s.extractHeaders(call, 1))
 	})
 	httpObj.Set("post", func(call goja.FunctionCall) goja.Value {
 		if len(call.Arguments) < 1 {
@@ -262,7 +311,7 @@ func (s *ScriptDeliveryService) registerAPIs(
 		if len(call.Arguments) > 1 {
 			body = call.Arguments[1].Export()
 		}
-		return s.doHTTPRequest(vm, executeCtx, "POST", call.Arguments[0].String(), body, s.extractHeaders(call, 2))
+		return s.doScriptHTTPRequest(vm, executeCtx, ctx, dryRun, "POST", call.Arguments[0].String(), body, s.extractHeaders(call, 2))
 	})
 	httpObj.Set("request", func(call goja.FunctionCall) goja.Value {
 		if len(call.Arguments) < 2 {
@@ -272,7 +321,7 @@ func (s *ScriptDeliveryService) registerAPIs(
 		if len(call.Arguments) > 2 {
 			body = call.Arguments[2].Export()
 		}
-		return s.doHTTPRequest(vm, executeCtx, call.Arguments[0].String(), call.Arguments[1].String(), body, s.extractHeaders(call, 3))
+		return s.doScriptHTTPRequest(vm, executeCtx, ctx, dryRun, call.Arguments[0].String(), call.Arguments[1].String(), body, s.extractHeaders(call, 3))
 	})
 
 	// 配置API
@@ -299,12 +348,28 @@ func (s *ScriptDeliveryService) registerAPIs(
 		return vm.ToValue(time.Now().Unix())
 	})
 	system.Set("log", func(call goja.FunctionCall) goja.Value {
+		if dryRun != nil {
+			dryRun.log(call.Arguments)
+			return goja.Undefined()
+		}
 		if len(call.Arguments) > 0 {
 			log.Printf("[ScriptDelivery] inventory=%d order=%s: %s",
 				ctx.VirtualInventoryID, ctx.OrderNo, call.Arguments[0].String())
 		}
+		ctx.audit.log(call.Arguments)
 		return goja.Undefined()
 	})
+
+	// 存储API：试运行写入只保存在覆盖层；测试脚本没有所属库存，存储只保存在本次执行的内存中
+	var storageOverlay *scriptStorageOverlay
+	var onStorageWrite func(scriptStorageOp)
+	if dryRun != nil {
+		storageOverlay = dryRun.storage
+		onStorageWrite = dryRun.storageOp
+	} else if ctx.VirtualInventoryID == 0 {
+		storageOverlay = newScriptStorageOverlay(true)
+	}
+	s.registerStorageAPI(vm, auralogic, ctx, storageOverlay, onStorageWrite)
 }
 
 func (s *ScriptDeliveryService) extractHeaders(call goja.FunctionCall, idx int) map[string]string {
@@ -323,6 +388,39 @@ func (s *ScriptDeliveryService) extractHeaders(call goja.FunctionCall, idx int)
 	return headers
 }
 
+// doScriptHTTPRequest 脚本发起的 HTTP 请求：试运行时交由 dryRun 处理，不计入供应商健康统计
+func (s *ScriptDeliveryService) doScriptHTTPRequest(vm *goja.Runtime, executeCtx context.Context, ctx *ScriptDeliveryContext, dryRun *scriptDryRun, method, urlStr string, body interface{}, headers map[string]string) goja.Value {
+	if dryRun != nil {
+		return dryRun.doHTTPRequest(s, vm, executeCtx, method, urlStr, body, headers)
+	}
+	startedAt := time.Now()
+	value := s.doSupplierHTTPRequest(vm, executeCtx, ctx.VirtualInventoryID, method, urlStr, body, headers)
+	ctx.audit.httpCall(strings.ToUpper(strings.TrimSpace(method)), urlStr, value, time.Since(startedAt))
+	return value
+}
+
+// doSupplierHTTPRequest 执行上游请求并记录成功率与耗时，用于供应商健康监控
+func (s *ScriptDeliveryService) doSupplierHTTPRequest(vm *goja.Runtime, executeCtx context.Context, inventoryID uint, method, urlStr string, body interface{}, headers map[string]string) goja.Value {
+	startedAt := time.Now()
+	value := s.doHTTPRequest(vm, executeCtx, method, urlStr, body, headers)
+	if s.supplierHealth == nil {
+		return value
+	}
+
+	status := 0
+	errMsg := ""
+	if result, ok := value.Export().(map[string]interface{}); ok {
+		if code, ok := result["status"].(int); ok {
+			status = code
+		}
+		if msg, ok := result["error"].(string); ok {
+			errMsg = msg
+		}
+	}
+	s.supplierHealth.Record(inventoryID, urlStr, status, time.Since(startedAt), errMsg)
+	return value
+}
+
 // doHTTPRequest 执行HTTP请求（SSRF安全）
 func (s *ScriptDeliveryService) doHTTPRequest(vm *goja.Runtime, executeCtx context.Context, method, urlStr string, body interface{}, headers map[string]string) goja.Value {
 	parsedURL, err := url.Parse(urlStr)
@@ -415,6 +513,7 @@ func (s *ScriptDeliveryService) newScriptHTTPClient() *http.Client {
 	}
 	client := *baseClient
 	client.Timeout = 0
+	client.Transport = tracing.Transport(client.Transport)
 	return &client
 }
 
@@ -317,3 +317,77 @@ func TestExecuteDeliveryScriptRecoversFromHostPanic(t *testing.T) {
 		t.Fatalf("expected recovered panic error, got %v", err)
 	}
 }
+
+func TestDryRunDeliveryScriptCapturesLogsAndUsesHTTPMocks(t *testing.T) {
+	svc := NewScriptDeliveryService(nil, &config.Config{})
+	svc.httpClientFactory = func() *http.Client {
+		return &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("dry run in mock mode must not reach upstream, got %s %s", req.Method, req.URL)
+			return nil, nil
+		})}
+	}
+
+	inventory := &models.VirtualInventory{
+		ID:   5,
+		Name: "Gift Card",
+		Script: `function onDeliver(order, config) {
+			var user = AuraLogic.order.getUser();
+			AuraLogic.system.log("user", user.email, order.quantity);
+			var resp = AuraLogic.http.post("https://supplier.example.com/api/codes", { n: order.quantity });
+			var missing = AuraLogic.http.get("https://other.example.com/ping");
+			AuraLogic.system.log("missing", missing.error);
+			return { success: true, items: resp.data.codes.map(function (c) { return { content: c }; }) };
+		}`,
+	}
+
+	var streamed []ScriptDryRunEvent
+	result := svc.DryRunDeliveryScript(inventory, ScriptDryRunOptions{
+		Quantity: 2,
+		Order:    ScriptDryRunOrder{UserEmail: "buyer@example.com"},
+		MockHTTP: true,
+		HTTPMocks: []ScriptDryRunHTTPMock{{
+			Method: "post",
+			URL:    "https://supplier.example.com/api/*",
+			Body:   map[string]interface{}{"codes": []interface{}{"AAA", "BBB"}},
+		}},
+		OnEvent: func(event ScriptDryRunEvent) { streamed = append(streamed, event) },
+	})
+
+	if !result.Success || result.Error != "" {
+		t.Fatalf("expected dry run to succeed, got %#v", result)
+	}
+	if len(result.Items) != 2 || result.Items[0].Content != "AAA" || result.Items[1].Content != "BBB" {
+		t.Fatalf("unexpected items: %#v", result.Items)
+	}
+	if len(result.Events) != 4 || len(streamed) != 4 {
+		t.Fatalf("expected 4 events (streamed %d), got %#v", len(streamed), result.Events)
+	}
+	if result.Events[0].Type != "log" || result.Events[0].Message != "user buyer@example.com 2" {
+		t.Fatalf("unexpected log event: %#v", result.Events[0])
+	}
+	if call := result.Events[1]; call.Type != "http" || !call.Mocked || call.Status != 200 || call.Method != "POST" {
+		t.Fatalf("unexpected mocked http event: %#v", call)
+	}
+	if call := result.Events[2]; call.Mocked || call.Error == "" {
+		t.Fatalf("expected unmatched request to fail without reaching upstream: %#v", call)
+	}
+}
+
+func TestDryRunDeliveryScriptReportsScriptErrors(t *testing.T) {
+	svc := NewScriptDeliveryService(nil, &config.Config{})
+	inventory := &models.VirtualInventory{
+		ID:     6,
+		Script: `function onDeliver() { AuraLogic.system.log("checking stock"); return { success: false, message: "out of stock" }; }`,
+	}
+
+	result := svc.DryRunDeliveryScript(inventory, ScriptDryRunOptions{})
+	if result.Success {
+		t.Fatalf("expected failed dry run")
+	}
+	if result.Message != "out of stock" || !strings.Contains(result.Error, "out of stock") {
+		t.Fatalf("expected script message in result, got %#v", result)
+	}
+	if result.Quantity != 1 || len(result.Events) != 1 {
+		t.Fatalf("expected default quantity and captured log, got %#v", result)
+	}
+}
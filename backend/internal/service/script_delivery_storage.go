@@ -0,0 +1,247 @@
+package service
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// scriptStorageEntry 内存存储中的一个条目
+type scriptStorageEntry struct {
+	value     string
+	expiresAt time.Time // 零值表示永不过期
+}
+
+func (e *scriptStorageEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// scriptStorageOverlay 不落库的脚本存储
+// 测试脚本（无库存 ID）时完全在内存中读写；试运行时作为真实存储之上的覆盖层：
+// 读取先查覆盖层再回落到数据库，写入只改覆盖层，保证试运行不修改脚本的持久状态
+type scriptStorageOverlay struct {
+	entries map[string]*scriptStorageEntry // nil 值表示该键在覆盖层中已删除
+	cleared bool                           // 为 true 时不再回落到数据库
+}
+
+func newScriptStorageOverlay(cleared bool) *scriptStorageOverlay {
+	return &scriptStorageOverlay{entries: make(map[string]*scriptStorageEntry), cleared: cleared}
+}
+
+// scriptStorageOp 一次 AuraLogic.storage 写操作，用于试运行事件记录
+type scriptStorageOp struct {
+	op  string
+	key string
+	ttl time.Duration
+	err error
+}
+
+// registerStorageAPI 注册 AuraLogic.storage，按虚拟库存隔离的持久化 KV 存储
+// overlay 不为空时读写走内存覆盖层；onWrite 在每次写操作后回调
+func (s *ScriptDeliveryService) registerStorageAPI(
+	vm *goja.Runtime,
+	auralogic *goja.Object,
+	ctx *ScriptDeliveryContext,
+	overlay *scriptStorageOverlay,
+	onWrite func(scriptStorageOp),
+) {
+	inventoryID := ctx.VirtualInventoryID
+	report := func(op scriptStorageOp) {
+		if onWrite != nil {
+			onWrite(op)
+			return
+		}
+		if op.err != nil {
+			log.Printf("[ScriptDelivery] inventory=%d order=%s: storage.%s %q failed: %v",
+				inventoryID, ctx.OrderNo, op.op, op.key, op.err)
+		}
+	}
+
+	storage := vm.NewObject()
+	auralogic.Set("storage", storage)
+
+	storage.Set("get", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 1 {
+			return goja.Undefined()
+		}
+		value, exists, err := s.scriptStorageGet(inventoryID, overlay, call.Arguments[0].String())
+		if err != nil || !exists {
+			return goja.Undefined()
+		}
+		return vm.ToValue(value)
+	})
+
+	storage.Set("set", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 2 {
+			return vm.ToValue(false)
+		}
+		key := call.Arguments[0].String()
+		value := call.Arguments[1].String()
+		ttl := parseScriptStorageTTL(call.Argument(2))
+
+		err := validateScriptStorageEntry(key, value)
+		if err == nil {
+			err = s.scriptStorageSet(inventoryID, overlay, key, value, ttl)
+		}
+		report(scriptStorageOp{op: "set", key: key, ttl: ttl, err: err})
+		return vm.ToValue(err == nil)
+	})
+
+	del := func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 1 {
+			return vm.ToValue(false)
+		}
+		key := call.Arguments[0].String()
+		err := s.scriptStorageDelete(inventoryID, overlay, key)
+		report(scriptStorageOp{op: "del", key: key, err: err})
+		return vm.ToValue(err == nil)
+	}
+	storage.Set("del", del)
+	// 与付款方式脚本的 AuraLogic.storage.delete 保持一致
+	storage.Set("delete", del)
+
+	storage.Set("list", func(call goja.FunctionCall) goja.Value {
+		keys, err := s.scriptStorageList(inventoryID, overlay)
+		if err != nil {
+			return vm.ToValue([]string{})
+		}
+		return vm.ToValue(keys)
+	})
+
+	storage.Set("clear", func(call goja.FunctionCall) goja.Value {
+		err := s.scriptStorageClear(inventoryID, overlay)
+		report(scriptStorageOp{op: "clear", err: err})
+		return vm.ToValue(err == nil)
+	})
+}
+
+// parseScriptStorageTTL 解析 set 的第三个参数：秒数，或 {ttl: 秒数}；缺省或非正数表示永不过期
+func parseScriptStorageTTL(arg goja.Value) time.Duration {
+	if arg == nil || goja.IsUndefined(arg) || goja.IsNull(arg) {
+		return 0
+	}
+	raw := arg.Export()
+	if options, ok := raw.(map[string]interface{}); ok {
+		raw = options["ttl"]
+	}
+	var seconds float64
+	switch v := raw.(type) {
+	case int64:
+		seconds = float64(v)
+	case float64:
+		seconds = v
+	default:
+		return 0
+	}
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (s *ScriptDeliveryService) scriptStorageGet(inventoryID uint, overlay *scriptStorageOverlay, key string) (string, bool, error) {
+	if overlay != nil {
+		if entry, ok := overlay.entries[key]; ok {
+			if entry == nil || entry.expired(time.Now()) {
+				return "", false, nil
+			}
+			return entry.value, true, nil
+		}
+		if overlay.cleared {
+			return "", false, nil
+		}
+	}
+
+	lock := getVirtualInventoryStorageLock(inventoryID)
+	lock.RLock()
+	defer lock.RUnlock()
+	return s.storageGetValue(inventoryID, key)
+}
+
+func (s *ScriptDeliveryService) scriptStorageSet(inventoryID uint, overlay *scriptStorageOverlay, key, value string, ttl time.Duration) error {
+	if overlay == nil {
+		lock := getVirtualInventoryStorageLock(inventoryID)
+		lock.Lock()
+		defer lock.Unlock()
+		return s.storageSetValue(inventoryID, key, value, ttl)
+	}
+
+	keys, err := s.scriptStorageList(inventoryID, overlay)
+	if err != nil {
+		return err
+	}
+	count := len(keys)
+	if i := sort.SearchStrings(keys, key); i < len(keys) && keys[i] == key {
+		count--
+	}
+	if count >= scriptStorageMaxKeys {
+		return errScriptStorageQuota
+	}
+
+	entry := &scriptStorageEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	overlay.entries[key] = entry
+	return nil
+}
+
+func (s *ScriptDeliveryService) scriptStorageDelete(inventoryID uint, overlay *scriptStorageOverlay, key string) error {
+	if overlay != nil {
+		overlay.entries[key] = nil
+		return nil
+	}
+
+	lock := getVirtualInventoryStorageLock(inventoryID)
+	lock.Lock()
+	defer lock.Unlock()
+	return s.storageDeleteKey(inventoryID, key)
+}
+
+// scriptStorageList 返回按字典序排列的未过期键
+func (s *ScriptDeliveryService) scriptStorageList(inventoryID uint, overlay *scriptStorageOverlay) ([]string, error) {
+	var stored []string
+	if overlay == nil || !overlay.cleared {
+		lock := getVirtualInventoryStorageLock(inventoryID)
+		lock.RLock()
+		keys, err := s.storageListKeys(inventoryID)
+		lock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		stored = keys
+	}
+	if overlay == nil {
+		return stored, nil
+	}
+
+	now := time.Now()
+	keys := make([]string, 0, len(stored)+len(overlay.entries))
+	for _, key := range stored {
+		if _, ok := overlay.entries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for key, entry := range overlay.entries {
+		if entry != nil && !entry.expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *ScriptDeliveryService) scriptStorageClear(inventoryID uint, overlay *scriptStorageOverlay) error {
+	if overlay != nil {
+		overlay.entries = make(map[string]*scriptStorageEntry)
+		overlay.cleared = true
+		return nil
+	}
+
+	lock := getVirtualInventoryStorageLock(inventoryID)
+	lock.Lock()
+	defer lock.Unlock()
+	return s.storageClearAll(inventoryID)
+}
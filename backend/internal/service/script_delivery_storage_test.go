@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openScriptStorageTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.VirtualInventoryStorageEntry{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return db
+}
+
+func TestScriptStoragePersistsAcrossRunsWithTTLAndLimits(t *testing.T) {
+	db := openScriptStorageTestDB(t)
+	svc := NewScriptDeliveryService(db, &config.Config{})
+	inventory := &models.VirtualInventory{
+		ID: 7,
+		Script: `function onDeliver(order, config) {
+			var count = parseInt(AuraLogic.storage.get("counter") || "0", 10) + 1;
+			var ok = AuraLogic.storage.set("counter", String(count));
+			AuraLogic.storage.set("token", "abc", { ttl: 60 });
+			var tooBig = AuraLogic.storage.set("big", new Array(` + fmt.Sprint(scriptStorageMaxValueBytes+2) + `).join("x"));
+			AuraLogic.storage.set("tmp", "1");
+			AuraLogic.storage.del("tmp");
+			return { success: true, items: [{ content: count + ":" + ok + ":" + tooBig + ":" + AuraLogic.storage.list().join(",") }] };
+		}`,
+	}
+	order := &models.Order{OrderNo: "ORD-1"}
+
+	for want := 1; want <= 2; want++ {
+		result, err := svc.ExecuteDeliveryScript(inventory, order, 1)
+		if err != nil {
+			t.Fatalf("run %d: %v", want, err)
+		}
+		if got, expected := result.Items[0].Content, fmt.Sprintf("%d:true:false:counter,token", want); got != expected {
+			t.Fatalf("run %d: expected %q, got %q", want, expected, got)
+		}
+	}
+
+	var token models.VirtualInventoryStorageEntry
+	if err := db.Where("virtual_inventory_id = ? AND expires_at IS NOT NULL", 7).Take(&token).Error; err != nil {
+		t.Fatalf("expected token entry with expiry: %v", err)
+	}
+	if remaining := time.Until(*token.ExpiresAt); remaining <= 0 || remaining > time.Minute {
+		t.Fatalf("unexpected token expiry %v", token.ExpiresAt)
+	}
+
+	// 过期后对脚本不可见，并由清理任务删除
+	if err := db.Model(&token).Update("expires_at", time.Now().UTC().Add(-time.Second)).Error; err != nil {
+		t.Fatalf("expire token: %v", err)
+	}
+	if _, exists, err := svc.storageGetValue(7, "token"); err != nil || exists {
+		t.Fatalf("expected expired token to be hidden, exists=%v err=%v", exists, err)
+	}
+	purged, err := purgeExpiredStorageEntries(db, time.Now().UTC())
+	if err != nil || purged != 1 {
+		t.Fatalf("expected 1 purged entry, got %d (%v)", purged, err)
+	}
+
+	if err := svc.storageSetValue(7, strings.Repeat("k", scriptStorageMaxKeyLength+1), "v", 0); err != errScriptStorageKeyInvalid {
+		t.Fatalf("expected key length error, got %v", err)
+	}
+}
+
+func TestScriptStorageQuotaIgnoresOverwrites(t *testing.T) {
+	db := openScriptStorageTestDB(t)
+	svc := NewScriptDeliveryService(db, &config.Config{})
+
+	entries := make([]models.VirtualInventoryStorageEntry, 0, scriptStorageMaxKeys)
+	for i := 0; i < scriptStorageMaxKeys; i++ {
+		entries = append(entries, models.VirtualInventoryStorageEntry{VirtualInventoryID: 3, Key: fmt.Sprintf("k%d", i), Value: "v"})
+	}
+	if err := db.CreateInBatches(entries, 200).Error; err != nil {
+		t.Fatalf("seed entries: %v", err)
+	}
+
+	if err := svc.storageSetValue(3, "k0", "updated", 0); err != nil {
+		t.Fatalf("overwrite at quota should succeed: %v", err)
+	}
+	if err := svc.storageSetValue(3, "extra", "v", 0); err != errScriptStorageQuota {
+		t.Fatalf("expected quota error, got %v", err)
+	}
+	if err := svc.storageSetValue(4, "extra", "v", 0); err != nil {
+		t.Fatalf("quota must be scoped per inventory: %v", err)
+	}
+}
+
+func TestDryRunScriptStorageDoesNotPersistWrites(t *testing.T) {
+	db := openScriptStorageTestDB(t)
+	svc := NewScriptDeliveryService(db, &config.Config{})
+	if err := svc.storageSetValue(9, "cursor", "100", 0); err != nil {
+		t.Fatalf("seed storage: %v", err)
+	}
+
+	inventory := &models.VirtualInventory{
+		ID: 9,
+		Script: `function onDeliver(order, config) {
+			var before = AuraLogic.storage.get("cursor");
+			AuraLogic.storage.set("cursor", "200", 30);
+			AuraLogic.storage.del("missing");
+			return { success: true, items: [{ content: before + "->" + AuraLogic.storage.get("cursor") }] };
+		}`,
+	}
+	result := svc.DryRunDeliveryScript(inventory, ScriptDryRunOptions{})
+	if !result.Success || len(result.Items) != 1 || result.Items[0].Content != "100->200" {
+		t.Fatalf("unexpected dry run result: %#v", result)
+	}
+	if len(result.Events) != 2 {
+		t.Fatalf("expected 2 storage events, got %#v", result.Events)
+	}
+	if event := result.Events[0]; event.Type != "storage" || event.Op != "set" || event.Key != "cursor" || event.TTLSeconds != 30 {
+		t.Fatalf("unexpected set event: %#v", event)
+	}
+
+	value, exists, err := svc.storageGetValue(9, "cursor")
+	if err != nil || !exists || value != "100" {
+		t.Fatalf("dry run must not change stored value, got %q exists=%v err=%v", value, exists, err)
+	}
+}
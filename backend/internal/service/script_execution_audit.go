@@ -0,0 +1,195 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+
+	"github.com/dop251/goja"
+)
+
+// 单次执行审计最多保留的日志/HTTP 事件数，发货频繁时避免审计表膨胀
+const scriptExecutionMaxEvents = 200
+
+var (
+	errScriptExecutionNotFound        = bizerr.Register("script_execution.notFound", 404, "Script execution not found")
+	errScriptExecutionNotFailed       = bizerr.Register("script_execution.notFailed", 409, "Only failed script executions can be replayed")
+	errScriptExecutionAlreadyReplayed = bizerr.Register("script_execution.alreadyReplayed", 409, "Script execution has already been replayed")
+)
+
+// scriptExecutionAudit 收集真实发货执行期间的控制台输出与 HTTP 调用，执行结束后写入 script_executions
+type scriptExecutionAudit struct {
+	console   []ScriptDryRunEvent
+	httpCalls []ScriptDryRunEvent
+	truncated bool
+}
+
+func (a *scriptExecutionAudit) full() bool {
+	if len(a.console)+len(a.httpCalls) >= scriptExecutionMaxEvents {
+		a.truncated = true
+		return true
+	}
+	return false
+}
+
+// log 记录 AuraLogic.system.log 的输出
+func (a *scriptExecutionAudit) log(args []goja.Value) {
+	if a == nil || a.full() {
+		return
+	}
+	a.console = append(a.console, ScriptDryRunEvent{Type: "log", Time: time.Now().UTC(), Message: formatScriptLogArgs(args)})
+}
+
+// httpCall 记录一次上游请求；URL 去掉查询参数与用户信息，避免把凭据落库
+func (a *scriptExecutionAudit) httpCall(method, rawURL string, value goja.Value, duration time.Duration) {
+	if a == nil || a.full() {
+		return
+	}
+	event := ScriptDryRunEvent{
+		Type:       "http",
+		Time:       time.Now().UTC(),
+		Method:     method,
+		URL:        redactScriptAuditURL(rawURL),
+		DurationMs: duration.Milliseconds(),
+	}
+	if result, ok := value.Export().(map[string]interface{}); ok {
+		if code, ok := result["status"].(int); ok {
+			event.Status = code
+		}
+		if msg, ok := result["error"].(string); ok {
+			event.Error = msg
+		}
+	}
+	a.httpCalls = append(a.httpCalls, event)
+}
+
+func redactScriptAuditURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "(invalid url)"
+	}
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+func scriptHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func marshalScriptAuditEvents(events []ScriptDryRunEvent) models.JSON {
+	if len(events) == 0 {
+		return models.JSON("[]")
+	}
+	encoded, err := json.Marshal(events)
+	if err != nil {
+		return models.JSON("[]")
+	}
+	return models.JSON(encoded)
+}
+
+// recordExecution 写入一次真实发货执行的审计记录；写入失败只记日志，不影响发货结果
+func (s *ScriptDeliveryService) recordExecution(inventory *models.VirtualInventory, order *models.Order, quantity int, audit *scriptExecutionAudit, duration time.Duration, result *ScriptDeliveryResult, execErr error) {
+	execution := models.ScriptExecution{
+		VirtualInventoryID: inventory.ID,
+		OrderID:            order.ID,
+		OrderNo:            order.OrderNo,
+		Quantity:           quantity,
+		ScriptHash:         scriptHash(inventory.Script),
+		DurationMs:         duration.Milliseconds(),
+		Console:            marshalScriptAuditEvents(audit.console),
+		HTTPCalls:          marshalScriptAuditEvents(audit.httpCalls),
+		EventsTruncated:    audit.truncated,
+	}
+	if result != nil {
+		execution.ItemCount = len(result.Items)
+		execution.Message = truncateString(result.Message, 500)
+	}
+	switch {
+	case execErr != nil:
+		execution.Error = execErr.Error()
+	case execution.ItemCount < quantity:
+		// 与发货流程一致：返回的发货项不足视为失败
+		execution.Error = fmt.Sprintf("script returned %d items, expected %d", execution.ItemCount, quantity)
+	default:
+		execution.Success = true
+	}
+	if err := s.db.Create(&execution).Error; err != nil {
+		log.Printf("[ScriptDelivery] failed to record execution: inventory=%d order=%s err=%v", inventory.ID, order.OrderNo, err)
+	}
+}
+
+// ListScriptExecutions 分页查询库存的脚本执行记录，success 不为空时按结果过滤
+func (s *VirtualInventoryService) ListScriptExecutions(inventoryID uint, success *bool, page, limit int) ([]models.ScriptExecution, int64, error) {
+	query := s.db.Model(&models.ScriptExecution{}).Where("virtual_inventory_id = ?", inventoryID)
+	if success != nil {
+		query = query.Where("success = ?", *success)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var executions []models.ScriptExecution
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&executions).Error; err != nil {
+		return nil, 0, err
+	}
+	return executions, total, nil
+}
+
+// GetScriptExecution 查询库存下的一条脚本执行记录
+func (s *VirtualInventoryService) GetScriptExecution(inventoryID, executionID uint) (*models.ScriptExecution, error) {
+	var execution models.ScriptExecution
+	if err := s.db.Where("id = ? AND virtual_inventory_id = ?", executionID, inventoryID).First(&execution).Error; err != nil {
+		return nil, bizerr.FromStore(err, errScriptExecutionNotFound)
+	}
+	return &execution, nil
+}
+
+// latestScriptExecutionID 订单当前最新一条执行记录的 ID，用于识别重放新产生的记录
+func (s *VirtualInventoryService) latestScriptExecutionID(orderID uint) (uint, error) {
+	var ids []uint
+	if err := s.db.Model(&models.ScriptExecution{}).Where("order_id = ?", orderID).
+		Order("id DESC").Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// linkScriptReplay 把重放期间新产生的执行记录关联到被重放的执行，并标记其已重放
+func (s *VirtualInventoryService) linkScriptReplay(replayed *models.ScriptExecution, afterID uint, adminID uint) ([]models.ScriptExecution, error) {
+	var executions []models.ScriptExecution
+	if err := s.db.Where("order_id = ? AND id > ?", replayed.OrderID, afterID).Order("id ASC").Find(&executions).Error; err != nil {
+		return nil, err
+	}
+	if len(executions) == 0 {
+		return executions, nil
+	}
+	ids := make([]uint, 0, len(executions))
+	for i := range executions {
+		ids = append(ids, executions[i].ID)
+		executions[i].ReplayOf = &replayed.ID
+	}
+	if err := s.db.Model(&models.ScriptExecution{}).Where("id IN ?", ids).Update("replay_of", replayed.ID).Error; err != nil {
+		return nil, err
+	}
+	now := models.NowFunc()
+	if err := s.db.Model(&models.ScriptExecution{}).Where("id = ?", replayed.ID).
+		Updates(map[string]interface{}{"replayed_at": now, "replayed_by": adminID}).Error; err != nil {
+		return nil, err
+	}
+	replayed.ReplayedAt = &now
+	replayed.ReplayedBy = &adminID
+	return executions, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"auralogic/internal/models"
+)
+
+func TestScriptExecutionAuditRecordsRunsAndReplaysFailure(t *testing.T) {
+	orderService, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventory{}, &models.VirtualProductStock{}, &models.ProductVirtualInventoryBinding{}, &models.ScriptExecution{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	virtualService := NewVirtualInventoryService(db)
+	virtualService.scriptDeliveryService.httpClientFactory = func() *http.Client {
+		return &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader("upstream down"))}, nil
+		})}
+	}
+	orderService.virtualProductSvc = virtualService
+
+	inventory := &models.VirtualInventory{
+		Name:     "Gift codes",
+		Type:     models.VirtualInventoryTypeScript,
+		IsActive: true,
+		Script: `function onDeliver(order) {
+			AuraLogic.system.log("issuing", order.quantity);
+			var res = AuraLogic.http.get("https://supplier.example.com/issue?token=secret");
+			if (res.status !== 200) { throw new Error("supplier returned " + res.status); }
+			return { success: true, items: [{ content: "CODE" }] };
+		}`,
+	}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	order := &models.Order{
+		OrderNo:  "SCR-1",
+		Status:   models.OrderStatusPending,
+		Currency: "USD",
+		Items:    []models.OrderItem{{SKU: "GIFT", Name: "Gift code", Quantity: 1, ProductType: models.ProductTypeVirtual}},
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Status: models.VirtualStockStatusReserved, OrderNo: order.OrderNo}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create reserved stock: %v", err)
+	}
+
+	if err := orderService.DeliverVirtualStock(order.ID, 1, false); err == nil {
+		t.Fatalf("expected delivery to fail while supplier is down")
+	}
+	failed, total, err := virtualService.ListScriptExecutions(inventory.ID, nil, 1, 20)
+	if err != nil || total != 1 || failed[0].Success || !strings.Contains(failed[0].Error, "supplier returned 502") {
+		t.Fatalf("expected one failed execution, got %+v total=%d err=%v", failed, total, err)
+	}
+	if !strings.Contains(string(failed[0].Console), "issuing 1") ||
+		!strings.Contains(string(failed[0].HTTPCalls), `"status":502`) || strings.Contains(string(failed[0].HTTPCalls), "secret") {
+		t.Fatalf("unexpected captured events: console=%s http=%s", failed[0].Console, failed[0].HTTPCalls)
+	}
+
+	// 修复脚本后重放：新执行关联到失败记录，订单完成发货
+	db.Model(inventory).Update("script", `function onDeliver(order) { return { success: true, items: [{ content: "CODE" }] }; }`)
+	result, err := orderService.ReplayScriptExecution(inventory.ID, failed[0].ID, 1)
+	if err != nil || !result.Success || len(result.Executions) != 1 || !result.Executions[0].Success {
+		t.Fatalf("expected successful replay, got %+v err=%v", result, err)
+	}
+	if result.Executions[0].ReplayOf == nil || *result.Executions[0].ReplayOf != failed[0].ID || result.Executions[0].ScriptHash == failed[0].ScriptHash {
+		t.Fatalf("expected replay to link the new execution, got %+v", result.Executions[0])
+	}
+	var delivered models.VirtualProductStock
+	if err := db.First(&delivered, stock.ID).Error; err != nil || delivered.Status != models.VirtualStockStatusSold {
+		t.Fatalf("expected reserved stock to be delivered, got %+v err=%v", delivered, err)
+	}
+
+	_, err = orderService.ReplayScriptExecution(inventory.ID, failed[0].ID, 1)
+	requireOrderBizErr(t, err, "script_execution.alreadyReplayed")
+	_, err = orderService.ReplayScriptExecution(inventory.ID, result.Executions[0].ID, 1)
+	requireOrderBizErr(t, err, "script_execution.notFailed")
+	_, err = orderService.ReplayScriptExecution(inventory.ID+1, failed[0].ID, 1)
+	requireOrderBizErr(t, err, "script_execution.notFound")
+}
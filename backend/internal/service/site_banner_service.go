@@ -0,0 +1,382 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	siteBannerCacheTTL       = 30 * time.Second
+	maxSiteBannerTitleLength = 120
+	maxSiteBannerMessageLen  = 1000
+)
+
+var ErrSiteBannerNotFound = errors.New("site banner not found")
+
+// SiteBannerInput 管理端创建/更新横幅参数
+type SiteBannerInput struct {
+	Type                models.SiteBannerType
+	Audience            models.SiteBannerAudience
+	Translations        map[string]models.SiteBannerContent
+	DefaultLocale       string
+	StartsAt            *time.Time
+	EndsAt              *time.Time
+	MaintenanceStartsAt *time.Time
+	MaintenanceEndsAt   *time.Time
+	Dismissible         bool
+	Priority            int
+	IsActive            bool
+}
+
+// SiteBannerView 按语言解析后的横幅，供公开接口返回
+type SiteBannerView struct {
+	ID                  uint                  `json:"id"`
+	Type                models.SiteBannerType `json:"type"`
+	Locale              string                `json:"locale"`
+	Title               string                `json:"title"`
+	Message             string                `json:"message"`
+	LinkURL             string                `json:"link_url,omitempty"`
+	LinkText            string                `json:"link_text,omitempty"`
+	StartsAt            time.Time             `json:"starts_at"`
+	EndsAt              *time.Time            `json:"ends_at,omitempty"`
+	MaintenanceStartsAt *time.Time            `json:"maintenance_starts_at,omitempty"`
+	MaintenanceEndsAt   *time.Time            `json:"maintenance_ends_at,omitempty"`
+	Dismissible         bool                  `json:"dismissible"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+}
+
+// SiteBannerViewer 当前访问者身份，用于按展示对象筛选
+type SiteBannerViewer struct {
+	LoggedIn bool
+	IsAdmin  bool
+}
+
+// SiteBannerService 站点横幅：维护公告、政策变更等按时间窗口展示的通知
+// 启用中的横幅缓存在内存中，每个请求都会读取其版本号写入响应头
+type SiteBannerService struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	cached   []models.SiteBanner
+	loadedAt time.Time
+}
+
+// NewSiteBannerService 创建站点横幅服务
+func NewSiteBannerService(db *gorm.DB) *SiteBannerService {
+	return &SiteBannerService{db: db}
+}
+
+func (s *SiteBannerService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// enabledBanners 已启用且未结束的横幅（含尚未开始的），带短时缓存
+func (s *SiteBannerService) enabledBanners(now time.Time) ([]models.SiteBanner, error) {
+	s.mu.RLock()
+	if !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < siteBannerCacheTTL {
+		banners := s.cached
+		s.mu.RUnlock()
+		return banners, nil
+	}
+	s.mu.RUnlock()
+
+	var banners []models.SiteBanner
+	if err := s.db.Where("is_active = ?", true).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Order("priority DESC, starts_at DESC, id DESC").
+		Find(&banners).Error; err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cached = banners
+	s.loadedAt = now
+	s.mu.Unlock()
+	return banners, nil
+}
+
+func siteBannerVisibleTo(audience models.SiteBannerAudience, viewer SiteBannerViewer) bool {
+	switch audience {
+	case models.SiteBannerAudienceGuests:
+		return !viewer.LoggedIn
+	case models.SiteBannerAudienceUsers:
+		return viewer.LoggedIn
+	case models.SiteBannerAudienceAdmins:
+		return viewer.IsAdmin
+	default:
+		return true
+	}
+}
+
+// resolveSiteBannerContent 选取请求语言的文案，缺失时回退到默认语言，再回退到任意可用语言
+func resolveSiteBannerContent(banner *models.SiteBanner, locale string) (string, models.SiteBannerContent) {
+	if content, ok := banner.Translations[locale]; ok {
+		return locale, content
+	}
+	if content, ok := banner.Translations[banner.DefaultLocale]; ok {
+		return banner.DefaultLocale, content
+	}
+	locales := make([]string, 0, len(banner.Translations))
+	for key := range banner.Translations {
+		locales = append(locales, key)
+	}
+	sort.Strings(locales)
+	if len(locales) == 0 {
+		return "", models.SiteBannerContent{}
+	}
+	return locales[0], banner.Translations[locales[0]]
+}
+
+// Active 当前展示中的横幅，按访问者身份过滤并解析为指定语言
+func (s *SiteBannerService) Active(viewer SiteBannerViewer, locale string, now time.Time) ([]SiteBannerView, error) {
+	banners, err := s.enabledBanners(now)
+	if err != nil {
+		return nil, err
+	}
+	locale = normalizeSiteBannerLocale(locale)
+	views := make([]SiteBannerView, 0, len(banners))
+	for i := range banners {
+		banner := &banners[i]
+		if !banner.IsLiveAt(now) || !siteBannerVisibleTo(banner.Audience, viewer) {
+			continue
+		}
+		resolved, content := resolveSiteBannerContent(banner, locale)
+		views = append(views, SiteBannerView{
+			ID:                  banner.ID,
+			Type:                banner.Type,
+			Locale:              resolved,
+			Title:               content.Title,
+			Message:             content.Message,
+			LinkURL:             content.LinkURL,
+			LinkText:            content.LinkText,
+			StartsAt:            banner.StartsAt,
+			EndsAt:              banner.EndsAt,
+			MaintenanceStartsAt: banner.MaintenanceStartsAt,
+			MaintenanceEndsAt:   banner.MaintenanceEndsAt,
+			Dismissible:         banner.Dismissible,
+			UpdatedAt:           banner.UpdatedAt,
+		})
+	}
+	return views, nil
+}
+
+// ActiveVersion 当前展示中横幅的版本号，横幅上线、下线或被修改时变化；没有横幅时为空
+// 不区分展示对象，前端发现版本变化后重新拉取横幅列表即可
+func (s *SiteBannerService) ActiveVersion(now time.Time) string {
+	banners, err := s.enabledBanners(now)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	live := 0
+	for i := range banners {
+		if !banners[i].IsLiveAt(now) {
+			continue
+		}
+		live++
+		fmt.Fprintf(hash, "%d:%d;", banners[i].ID, banners[i].UpdatedAt.UnixNano())
+	}
+	if live == 0 {
+		return ""
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func normalizeSiteBannerLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if idx := strings.IndexAny(locale, "-_,;"); idx > 0 {
+		locale = locale[:idx]
+	}
+	return locale
+}
+
+func validateSiteBannerInput(input *SiteBannerInput) error {
+	switch input.Type {
+	case models.SiteBannerTypeMaintenance, models.SiteBannerTypePolicy, models.SiteBannerTypeInfo:
+	default:
+		return bizerr.Newf("siteBanner.typeInvalid", "Invalid banner type: %s", input.Type).
+			WithParams(map[string]interface{}{"type": string(input.Type)})
+	}
+	if input.Audience == "" {
+		input.Audience = models.SiteBannerAudienceAll
+	}
+	switch input.Audience {
+	case models.SiteBannerAudienceAll, models.SiteBannerAudienceGuests, models.SiteBannerAudienceUsers, models.SiteBannerAudienceAdmins:
+	default:
+		return bizerr.Newf("siteBanner.audienceInvalid", "Invalid banner audience: %s", input.Audience).
+			WithParams(map[string]interface{}{"audience": string(input.Audience)})
+	}
+
+	translations := make(map[string]models.SiteBannerContent, len(input.Translations))
+	for rawLocale, content := range input.Translations {
+		locale := normalizeSiteBannerLocale(rawLocale)
+		content.Title = strings.TrimSpace(content.Title)
+		content.Message = strings.TrimSpace(content.Message)
+		content.LinkURL = strings.TrimSpace(content.LinkURL)
+		content.LinkText = strings.TrimSpace(content.LinkText)
+		if content.Title == "" && content.Message == "" {
+			continue
+		}
+		if locale == "" || len(locale) > 10 {
+			return bizerr.Newf("siteBanner.localeInvalid", "Invalid locale: %s", rawLocale).
+				WithParams(map[string]interface{}{"locale": rawLocale})
+		}
+		if len([]rune(content.Title)) > maxSiteBannerTitleLength {
+			return bizerr.Newf("siteBanner.titleTooLong", "Banner title cannot exceed %d characters", maxSiteBannerTitleLength).
+				WithParams(map[string]interface{}{"max": maxSiteBannerTitleLength})
+		}
+		if content.Message == "" || len([]rune(content.Message)) > maxSiteBannerMessageLen {
+			return bizerr.Newf("siteBanner.messageInvalid", "Banner message is required and cannot exceed %d characters", maxSiteBannerMessageLen).
+				WithParams(map[string]interface{}{"max": maxSiteBannerMessageLen})
+		}
+		if content.LinkURL != "" {
+			// 只允许站内路径或 http(s) 链接，避免 javascript: 等协议
+			parsed, err := url.Parse(content.LinkURL)
+			if err != nil || (!strings.HasPrefix(content.LinkURL, "/") && parsed.Scheme != "http" && parsed.Scheme != "https") {
+				return bizerr.New("siteBanner.linkInvalid", "Banner link must be a site path or an http(s) URL")
+			}
+		}
+		translations[locale] = content
+	}
+	if len(translations) == 0 {
+		return bizerr.New("siteBanner.contentRequired", "At least one translation with a message is required")
+	}
+	input.Translations = translations
+
+	input.DefaultLocale = normalizeSiteBannerLocale(input.DefaultLocale)
+	if _, ok := translations[input.DefaultLocale]; !ok {
+		// 默认语言没有文案时取第一个可用语言
+		locales := make([]string, 0, len(translations))
+		for locale := range translations {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+		input.DefaultLocale = locales[0]
+	}
+
+	if input.StartsAt != nil && input.EndsAt != nil && !input.EndsAt.After(*input.StartsAt) {
+		return bizerr.New("siteBanner.scheduleInvalid", "Banner end time must be after its start time")
+	}
+	if input.Type == models.SiteBannerTypeMaintenance {
+		if input.MaintenanceStartsAt != nil && input.MaintenanceEndsAt != nil && !input.MaintenanceEndsAt.After(*input.MaintenanceStartsAt) {
+			return bizerr.New("siteBanner.maintenanceWindowInvalid", "Maintenance end time must be after its start time")
+		}
+	} else {
+		input.MaintenanceStartsAt = nil
+		input.MaintenanceEndsAt = nil
+	}
+	return nil
+}
+
+func applySiteBannerInput(banner *models.SiteBanner, input SiteBannerInput) {
+	banner.Type = input.Type
+	banner.Audience = input.Audience
+	banner.Translations = input.Translations
+	banner.DefaultLocale = input.DefaultLocale
+	if input.StartsAt != nil {
+		banner.StartsAt = *input.StartsAt
+	} else if banner.StartsAt.IsZero() {
+		banner.StartsAt = time.Now()
+	}
+	banner.EndsAt = input.EndsAt
+	banner.MaintenanceStartsAt = input.MaintenanceStartsAt
+	banner.MaintenanceEndsAt = input.MaintenanceEndsAt
+	banner.Dismissible = input.Dismissible
+	banner.Priority = input.Priority
+	banner.IsActive = input.IsActive
+}
+
+// List 管理端横幅列表，status 可选 live（展示中）、scheduled（未开始）、ended（已结束或停用）
+func (s *SiteBannerService) List(status string, bannerType string, page, limit int) ([]models.SiteBanner, int64, error) {
+	now := time.Now()
+	query := s.db.Model(&models.SiteBanner{})
+	if bannerType != "" {
+		query = query.Where("type = ?", bannerType)
+	}
+	switch status {
+	case "live":
+		query = query.Where("is_active = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", true, now, now)
+	case "scheduled":
+		query = query.Where("is_active = ? AND starts_at > ?", true, now)
+	case "ended":
+		query = query.Where("is_active = ? OR (ends_at IS NOT NULL AND ends_at <= ?)", false, now)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var banners []models.SiteBanner
+	if err := query.Order("starts_at DESC, id DESC").Offset((page - 1) * limit).Limit(limit).Find(&banners).Error; err != nil {
+		return nil, 0, err
+	}
+	return banners, total, nil
+}
+
+// Get 获取横幅
+func (s *SiteBannerService) Get(id uint) (*models.SiteBanner, error) {
+	var banner models.SiteBanner
+	if err := s.db.First(&banner, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSiteBannerNotFound
+		}
+		return nil, err
+	}
+	return &banner, nil
+}
+
+// Create 创建横幅，StartsAt 为空时立即开始展示
+func (s *SiteBannerService) Create(adminID uint, input SiteBannerInput) (*models.SiteBanner, error) {
+	if err := validateSiteBannerInput(&input); err != nil {
+		return nil, err
+	}
+	banner := &models.SiteBanner{CreatedBy: adminID}
+	applySiteBannerInput(banner, input)
+	if err := s.db.Create(banner).Error; err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return banner, nil
+}
+
+// Update 更新横幅
+func (s *SiteBannerService) Update(id uint, input SiteBannerInput) (*models.SiteBanner, error) {
+	if err := validateSiteBannerInput(&input); err != nil {
+		return nil, err
+	}
+	banner, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	applySiteBannerInput(banner, input)
+	if err := s.db.Save(banner).Error; err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return banner, nil
+}
+
+// Delete 删除横幅
+func (s *SiteBannerService) Delete(id uint) error {
+	result := s.db.Delete(&models.SiteBanner{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSiteBannerNotFound
+	}
+	s.invalidate()
+	return nil
+}
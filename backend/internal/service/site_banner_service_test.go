@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestSiteBannerScheduleAudienceAndLocale(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.SiteBanner{}); err != nil {
+		t.Fatalf("auto migrate site banners failed: %v", err)
+	}
+	svc := NewSiteBannerService(db)
+
+	_, err := svc.Create(1, SiteBannerInput{Type: "popup", Translations: map[string]models.SiteBannerContent{"en": {Message: "hi"}}})
+	requireOrderBizErr(t, err, "siteBanner.typeInvalid")
+	_, err = svc.Create(1, SiteBannerInput{Type: models.SiteBannerTypeInfo, Translations: map[string]models.SiteBannerContent{"en": {Title: "  "}}})
+	requireOrderBizErr(t, err, "siteBanner.contentRequired")
+	_, err = svc.Create(1, SiteBannerInput{Type: models.SiteBannerTypeInfo, Translations: map[string]models.SiteBannerContent{"en": {Message: "x", LinkURL: "javascript:alert(1)"}}})
+	requireOrderBizErr(t, err, "siteBanner.linkInvalid")
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+	_, err = svc.Create(1, SiteBannerInput{Type: models.SiteBannerTypeInfo, StartsAt: &soon, EndsAt: &past, IsActive: true,
+		Translations: map[string]models.SiteBannerContent{"en": {Message: "x"}}})
+	requireOrderBizErr(t, err, "siteBanner.scheduleInvalid")
+
+	if version := svc.ActiveVersion(now); version != "" {
+		t.Fatalf("expected empty version without banners, got %q", version)
+	}
+
+	maintenance, err := svc.Create(1, SiteBannerInput{
+		Type:                models.SiteBannerTypeMaintenance,
+		StartsAt:            &past,
+		EndsAt:              &later,
+		MaintenanceStartsAt: &soon,
+		MaintenanceEndsAt:   &later,
+		DefaultLocale:       "en",
+		IsActive:            true,
+		Translations: map[string]models.SiteBannerContent{
+			"en":    {Title: "Planned maintenance", Message: "Checkout will be unavailable."},
+			"zh-CN": {Title: "计划维护", Message: "期间暂停下单。"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create maintenance banner failed: %v", err)
+	}
+	if _, ok := maintenance.Translations["zh"]; !ok {
+		t.Fatalf("expected locale to be normalized, got %+v", maintenance.Translations)
+	}
+	if _, err := svc.Create(1, SiteBannerInput{
+		Type:         models.SiteBannerTypePolicy,
+		Audience:     models.SiteBannerAudienceAdmins,
+		StartsAt:     &past,
+		IsActive:     true,
+		Translations: map[string]models.SiteBannerContent{"en": {Message: "Admin only"}},
+	}); err != nil {
+		t.Fatalf("create admin banner failed: %v", err)
+	}
+	if _, err := svc.Create(1, SiteBannerInput{
+		Type:         models.SiteBannerTypeInfo,
+		StartsAt:     &soon,
+		IsActive:     true,
+		Translations: map[string]models.SiteBannerContent{"en": {Message: "Not yet"}},
+	}); err != nil {
+		t.Fatalf("create scheduled banner failed: %v", err)
+	}
+
+	guest, err := svc.Active(SiteBannerViewer{}, "zh-CN,zh;q=0.9", now)
+	if err != nil {
+		t.Fatalf("active banners failed: %v", err)
+	}
+	if len(guest) != 1 || guest[0].ID != maintenance.ID || guest[0].Locale != "zh" || guest[0].Title != "计划维护" {
+		t.Fatalf("unexpected guest banners: %+v", guest)
+	}
+	fallback, _ := svc.Active(SiteBannerViewer{LoggedIn: true}, "fr", now)
+	if len(fallback) != 1 || fallback[0].Locale != "en" {
+		t.Fatalf("expected fallback to default locale, got %+v", fallback)
+	}
+	admin, _ := svc.Active(SiteBannerViewer{LoggedIn: true, IsAdmin: true}, "en", now)
+	if len(admin) != 2 {
+		t.Fatalf("expected admin to see 2 banners, got %+v", admin)
+	}
+
+	version := svc.ActiveVersion(now)
+	if version == "" {
+		t.Fatalf("expected non-empty version")
+	}
+	// 尚未开始的横幅到点后版本号随之变化
+	if next := svc.ActiveVersion(soon.Add(time.Minute)); next == version {
+		t.Fatalf("expected version to change once scheduled banner goes live")
+	}
+
+	input := SiteBannerInput{
+		Type:         models.SiteBannerTypeMaintenance,
+		StartsAt:     &past,
+		EndsAt:       &later,
+		IsActive:     false,
+		Translations: maintenance.Translations,
+	}
+	if _, err := svc.Update(maintenance.ID, input); err != nil {
+		t.Fatalf("deactivate banner failed: %v", err)
+	}
+	guest, _ = svc.Active(SiteBannerViewer{}, "en", now)
+	if len(guest) != 0 {
+		t.Fatalf("expected deactivated banner to be hidden, got %+v", guest)
+	}
+	if svc.ActiveVersion(now) == version {
+		t.Fatalf("expected version to change after update")
+	}
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	skuSalesStatDateLayout = "2006-01-02"
+	// 统计表为空时回填的天数
+	skuSalesStatBackfillDays = 90
+)
+
+// SKU 销售报表分组方式
+const (
+	SKUSalesGroupBySKU   = "sku"
+	SKUSalesGroupByDay   = "day"
+	SKUSalesGroupByMonth = "month"
+)
+
+// skuSalesPaidStatuses 计入销量的订单状态（已付款，含之后退款的订单）
+var skuSalesPaidStatuses = []models.OrderStatus{
+	models.OrderStatusPending,
+	models.OrderStatusShipped,
+	models.OrderStatusCompleted,
+	models.OrderStatusRefundPending,
+	models.OrderStatusRefunded,
+}
+
+// SKUSalesStatsService SKU 销售每日聚合服务
+// 按 SKU 和自然日汇总销量、销售额、退款与库存快照到 sku_sales_daily_stats，供销售速度与售罄率报表使用
+type SKUSalesStatsService struct {
+	db            *gorm.DB
+	checkInterval time.Duration
+}
+
+// NewSKUSalesStatsService 创建 SKU 销售聚合服务
+func NewSKUSalesStatsService(db *gorm.DB) *SKUSalesStatsService {
+	return &SKUSalesStatsService{
+		db:            db,
+		checkInterval: time.Hour, // 每小时刷新当天与前一天的汇总
+	}
+}
+
+// RegisterJobs 注册 SKU 销售每日汇总任务
+func (s *SKUSalesStatsService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "sku_sales_stats",
+		Description: "Refresh daily SKU sales aggregates for today and yesterday",
+		Interval:    s.checkInterval,
+		Run:         s.aggregateRecent,
+	})
+}
+
+// aggregateRecent 刷新当天与前一天的汇总，统计表为空时回填最近 90 天
+func (s *SKUSalesStatsService) aggregateRecent(ctx context.Context) error {
+	now := time.Now().UTC()
+	days := 2
+	var existing int64
+	if err := s.db.Model(&models.SKUSalesDailyStat{}).Count(&existing).Error; err == nil && existing == 0 {
+		days = skuSalesStatBackfillDays
+	}
+	var errs []error
+	for i := days - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		day := now.AddDate(0, 0, -i)
+		if err := s.AggregateDay(day); err != nil {
+			errs = append(errs, fmt.Errorf("aggregate %s: %w", day.Format(skuSalesStatDateLayout), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AggregateDay 重新计算指定自然日（UTC）的 SKU 销售汇总并覆盖写入
+// 库存没有历史记录，只能取聚合时的商品库存：当天取实时值，已有快照的历史日期沿用原快照
+func (s *SKUSalesStatsService) AggregateDay(day time.Time) error {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	statDate := start.Format(skuSalesStatDateLayout)
+	isToday := statDate == time.Now().UTC().Format(skuSalesStatDateLayout)
+
+	stats := make(map[string]*models.SKUSalesDailyStat)
+	statFor := func(sku, name string) *models.SKUSalesDailyStat {
+		stat, ok := stats[sku]
+		if !ok {
+			stat = &models.SKUSalesDailyStat{StatDate: statDate, SKU: sku, Name: name}
+			stats[sku] = stat
+		}
+		if stat.Name == "" {
+			stat.Name = name
+		}
+		return stat
+	}
+
+	// 当日付款的订单，销售额按下单时的商品单价计算（不分摊订单级优惠）
+	var paidOrders []models.Order
+	if err := s.db.Select("id", "items").
+		Where("status IN ? AND paid_at >= ? AND paid_at < ?", skuSalesPaidStatuses, start, end).
+		Find(&paidOrders).Error; err != nil {
+		return err
+	}
+	for _, order := range paidOrders {
+		counted := make(map[string]struct{})
+		for _, item := range order.Items {
+			sku := strings.TrimSpace(item.SKU)
+			if sku == "" || item.Quantity <= 0 {
+				continue
+			}
+			stat := statFor(sku, item.Name)
+			stat.UnitsSold += int64(item.Quantity)
+			stat.RevenueMinor += item.UnitPrice * int64(item.Quantity)
+			if _, ok := counted[sku]; !ok {
+				stat.OrderCount++
+				counted[sku] = struct{}{}
+			}
+		}
+	}
+
+	// 当日完成退款的订单（整单退款）
+	var refundedOrders []models.Order
+	if err := s.db.Select("id", "items").
+		Where("status = ? AND refunded_at >= ? AND refunded_at < ?", models.OrderStatusRefunded, start, end).
+		Find(&refundedOrders).Error; err != nil {
+		return err
+	}
+	for _, order := range refundedOrders {
+		for _, item := range order.Items {
+			sku := strings.TrimSpace(item.SKU)
+			if sku == "" || item.Quantity <= 0 {
+				continue
+			}
+			stat := statFor(sku, item.Name)
+			stat.UnitsRefunded += int64(item.Quantity)
+			stat.RefundedRevenueMinor += item.UnitPrice * int64(item.Quantity)
+		}
+	}
+
+	// 在售商品都写入库存快照，没有销量的 SKU 也能计算售罄率
+	var products []models.Product
+	if err := s.db.Select("id", "sku", "name", "stock").Find(&products).Error; err != nil {
+		return err
+	}
+	for _, product := range products {
+		sku := strings.TrimSpace(product.SKU)
+		if sku == "" {
+			continue
+		}
+		stat := statFor(sku, product.Name)
+		stat.ProductID = product.ID
+		stat.EndingStock = int64(product.Stock)
+	}
+
+	if !isToday {
+		var previous []models.SKUSalesDailyStat
+		if err := s.db.Select("sku", "ending_stock").Where("stat_date = ?", statDate).Find(&previous).Error; err != nil {
+			return err
+		}
+		for _, row := range previous {
+			if stat, ok := stats[row.SKU]; ok {
+				stat.EndingStock = row.EndingStock
+			}
+		}
+	}
+
+	skus := make([]string, 0, len(stats))
+	for sku := range stats {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stat_date = ?", statDate).Delete(&models.SKUSalesDailyStat{}).Error; err != nil {
+			return err
+		}
+		for _, sku := range skus {
+			if err := tx.Create(stats[sku]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SKUSalesReportItem SKU 销售报表行
+type SKUSalesReportItem struct {
+	Period               string  `json:"period,omitempty"`
+	SKU                  string  `json:"sku"`
+	ProductID            uint    `json:"product_id"`
+	Name                 string  `json:"name"`
+	OrderCount           int64   `json:"order_count"`
+	UnitsSold            int64   `json:"units_sold"`
+	RevenueMinor         int64   `json:"revenue_minor"`
+	UnitsRefunded        int64   `json:"units_refunded"`
+	RefundedRevenueMinor int64   `json:"refunded_revenue_minor"`
+	RefundRate           float64 `json:"refund_rate"`       // 退款件数 / 销售件数（百分比）
+	DailyVelocity        float64 `json:"daily_velocity"`    // 日均销量
+	EndingStock          int64   `json:"ending_stock"`      // 期末库存
+	AvgStock             float64 `json:"avg_stock"`         // 期间平均库存
+	StockTurn            float64 `json:"stock_turn"`        // 销售件数 / 平均库存
+	SellThroughRate      float64 `json:"sell_through_rate"` // 销售件数 / (销售件数 + 期末库存)（百分比）
+}
+
+// SKUSalesReportQuery 报表查询条件，日期均为 YYYY-MM-DD（含首尾）
+type SKUSalesReportQuery struct {
+	StartDate string
+	EndDate   string
+	// SKU 非空时只统计该 SKU
+	SKU string
+	// GroupBy 为 day/month 时按“SKU + 周期”输出，否则按 SKU 汇总整个期间
+	GroupBy string
+}
+
+type skuSalesBucket struct {
+	item          SKUSalesReportItem
+	stockTotal    int64
+	stockDays     int64
+	lastStockDate string
+}
+
+// BuildSKUSalesReport 从每日汇总表生成 SKU 销售速度与售罄率报表
+func BuildSKUSalesReport(db *gorm.DB, query SKUSalesReportQuery) ([]SKUSalesReportItem, error) {
+	tx := db.Model(&models.SKUSalesDailyStat{}).
+		Where("stat_date >= ? AND stat_date <= ?", query.StartDate, query.EndDate)
+	if query.SKU != "" {
+		tx = tx.Where("sku = ?", query.SKU)
+	}
+	var rows []models.SKUSalesDailyStat
+	if err := tx.Order("stat_date ASC, sku ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string]*skuSalesBucket)
+	keys := make([]string, 0)
+	for _, row := range rows {
+		period := skuSalesPeriod(row.StatDate, query.GroupBy)
+		key := period + "|" + row.SKU
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &skuSalesBucket{item: SKUSalesReportItem{Period: period, SKU: row.SKU}}
+			buckets[key] = bucket
+			keys = append(keys, key)
+		}
+		if row.ProductID > 0 {
+			bucket.item.ProductID = row.ProductID
+		}
+		if row.Name != "" {
+			bucket.item.Name = row.Name
+		}
+		bucket.item.OrderCount += row.OrderCount
+		bucket.item.UnitsSold += row.UnitsSold
+		bucket.item.RevenueMinor += row.RevenueMinor
+		bucket.item.UnitsRefunded += row.UnitsRefunded
+		bucket.item.RefundedRevenueMinor += row.RefundedRevenueMinor
+		if row.ProductID > 0 {
+			bucket.stockTotal += row.EndingStock
+			bucket.stockDays++
+			if row.StatDate >= bucket.lastStockDate {
+				bucket.lastStockDate = row.StatDate
+				bucket.item.EndingStock = row.EndingStock
+			}
+		}
+	}
+
+	result := make([]SKUSalesReportItem, 0, len(keys))
+	for _, key := range keys {
+		bucket := buckets[key]
+		item := bucket.item
+		// 按日/月分组时跳过没有任何销售与退款的周期
+		if query.GroupBy != SKUSalesGroupBySKU && query.GroupBy != "" && item.UnitsSold == 0 && item.UnitsRefunded == 0 {
+			continue
+		}
+		if item.UnitsSold > 0 {
+			item.RefundRate = roundSKUSalesRatio(float64(item.UnitsRefunded) * 100 / float64(item.UnitsSold))
+		}
+		if days := skuSalesPeriodDays(query, item.Period); days > 0 {
+			item.DailyVelocity = roundSKUSalesRatio(float64(item.UnitsSold) / float64(days))
+		}
+		if bucket.stockDays > 0 {
+			item.AvgStock = roundSKUSalesRatio(float64(bucket.stockTotal) / float64(bucket.stockDays))
+			if bucket.stockTotal > 0 {
+				item.StockTurn = roundSKUSalesRatio(float64(item.UnitsSold) * float64(bucket.stockDays) / float64(bucket.stockTotal))
+			}
+		}
+		if item.UnitsSold+item.EndingStock > 0 {
+			item.SellThroughRate = roundSKUSalesRatio(float64(item.UnitsSold) * 100 / float64(item.UnitsSold+item.EndingStock))
+		}
+		if query.GroupBy == SKUSalesGroupBySKU || query.GroupBy == "" {
+			item.Period = ""
+		}
+		result = append(result, item)
+	}
+
+	if query.GroupBy == SKUSalesGroupBySKU || query.GroupBy == "" {
+		sort.SliceStable(result, func(i, j int) bool {
+			if result[i].UnitsSold == result[j].UnitsSold {
+				return result[i].SKU < result[j].SKU
+			}
+			return result[i].UnitsSold > result[j].UnitsSold
+		})
+	}
+	return result, nil
+}
+
+func skuSalesPeriod(statDate, groupBy string) string {
+	switch groupBy {
+	case SKUSalesGroupByDay:
+		return statDate
+	case SKUSalesGroupByMonth:
+		if len(statDate) >= 7 {
+			return statDate[:7]
+		}
+		return statDate
+	default:
+		return ""
+	}
+}
+
+// skuSalesPeriodDays 周期在查询范围内的自然日数，用于计算日均销量
+func skuSalesPeriodDays(query SKUSalesReportQuery, period string) int64 {
+	start, err := time.Parse(skuSalesStatDateLayout, query.StartDate)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse(skuSalesStatDateLayout, query.EndDate)
+	if err != nil {
+		return 0
+	}
+	switch query.GroupBy {
+	case SKUSalesGroupByDay:
+		return 1
+	case SKUSalesGroupByMonth:
+		monthStart, err := time.Parse("2006-01", period)
+		if err != nil {
+			return 0
+		}
+		if monthStart.After(start) {
+			start = monthStart
+		}
+		if monthEnd := monthStart.AddDate(0, 1, -1); monthEnd.Before(end) {
+			end = monthEnd
+		}
+	}
+	if start.After(end) {
+		return 0
+	}
+	return int64(end.Sub(start)/(24*time.Hour)) + 1
+}
+
+func roundSKUSalesRatio(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openSKUSalesStatsTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:sku-sales-stats-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.OperationLog{},
+		&models.Order{},
+		&models.Product{},
+		&models.SKUSalesDailyStat{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestSKUSalesStatsAggregateDayAndReport(t *testing.T) {
+	db := openSKUSalesStatsTestDB(t)
+	svc := NewSKUSalesStatsService(db)
+
+	product := &models.Product{SKU: "TEE-1", Name: "Tee", Status: models.ProductStatusActive, Stock: 6}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product failed: %v", err)
+	}
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	paidAt := day.Add(9 * time.Hour)
+	refundedAt := day.Add(20 * time.Hour)
+	orders := []models.Order{
+		{
+			OrderNo: "ORD-SKU-1",
+			Status:  models.OrderStatusCompleted,
+			PaidAt:  &paidAt,
+			Items: []models.OrderItem{
+				{SKU: "TEE-1", Name: "Tee", Quantity: 2, UnitPrice: 1500},
+				{SKU: "TEE-1", Name: "Tee", Quantity: 1, UnitPrice: 1500},
+			},
+		},
+		{
+			OrderNo:    "ORD-SKU-2",
+			Status:     models.OrderStatusRefunded,
+			PaidAt:     &paidAt,
+			RefundedAt: &refundedAt,
+			Items:      []models.OrderItem{{SKU: "TEE-1", Name: "Tee", Quantity: 1, UnitPrice: 1500}},
+		},
+		{
+			OrderNo: "ORD-SKU-3",
+			Status:  models.OrderStatusCancelled,
+			PaidAt:  &paidAt,
+			Items:   []models.OrderItem{{SKU: "TEE-1", Name: "Tee", Quantity: 5, UnitPrice: 1500}},
+		},
+	}
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("create orders failed: %v", err)
+	}
+
+	if err := svc.AggregateDay(day); err != nil {
+		t.Fatalf("aggregate failed: %v", err)
+	}
+	// 历史日期重新聚合时沿用原库存快照，且覆盖而不是累加
+	if err := db.Model(product).Update("stock", 100).Error; err != nil {
+		t.Fatalf("update stock failed: %v", err)
+	}
+	if err := svc.AggregateDay(day); err != nil {
+		t.Fatalf("re-aggregate failed: %v", err)
+	}
+
+	var stats []models.SKUSalesDailyStat
+	if err := db.Find(&stats).Error; err != nil {
+		t.Fatalf("query stats failed: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected one stat row, got %d", len(stats))
+	}
+	stat := stats[0]
+	if stat.StatDate != "2026-03-10" || stat.SKU != "TEE-1" || stat.ProductID != product.ID {
+		t.Fatalf("unexpected stat key: %+v", stat)
+	}
+	if stat.OrderCount != 2 || stat.UnitsSold != 4 || stat.RevenueMinor != 6000 {
+		t.Fatalf("unexpected sales stats (cancelled orders excluded): %+v", stat)
+	}
+	if stat.UnitsRefunded != 1 || stat.RefundedRevenueMinor != 1500 {
+		t.Fatalf("unexpected refund stats: %+v", stat)
+	}
+	if stat.EndingStock != 6 {
+		t.Fatalf("expected stock snapshot to be kept, got %d", stat.EndingStock)
+	}
+
+	items, err := BuildSKUSalesReport(db, SKUSalesReportQuery{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-10",
+		GroupBy:   SKUSalesGroupBySKU,
+	})
+	if err != nil {
+		t.Fatalf("build report failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected one report row, got %d", len(items))
+	}
+	item := items[0]
+	if item.Period != "" || item.UnitsSold != 4 || item.RefundRate != 25 {
+		t.Fatalf("unexpected report totals: %+v", item)
+	}
+	if item.DailyVelocity != 0.4 || item.StockTurn != 0.67 || item.SellThroughRate != 40 {
+		t.Fatalf("unexpected velocity/turn/sell-through: %+v", item)
+	}
+
+	monthly, err := BuildSKUSalesReport(db, SKUSalesReportQuery{
+		StartDate: "2026-02-20",
+		EndDate:   "2026-03-31",
+		GroupBy:   SKUSalesGroupByMonth,
+	})
+	if err != nil {
+		t.Fatalf("build monthly report failed: %v", err)
+	}
+	if len(monthly) != 1 || monthly[0].Period != "2026-03" || monthly[0].DailyVelocity != 0.13 {
+		t.Fatalf("unexpected monthly report: %+v", monthly)
+	}
+}
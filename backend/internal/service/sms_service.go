@@ -19,6 +19,7 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/tracing"
 	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 )
@@ -27,10 +28,23 @@ type SMSService struct {
 	cfg           *config.Config
 	db            *gorm.DB
 	pluginManager *PluginManagerService
+	httpClient    *http.Client
 }
 
 func NewSMSService(cfg *config.Config, db *gorm.DB) *SMSService {
-	return &SMSService{cfg: cfg, db: db}
+	return &SMSService{
+		cfg:        cfg,
+		db:         db,
+		httpClient: &http.Client{Transport: tracing.Transport(nil)},
+	}
+}
+
+// startSendSpan 每次调用服务商发送短信记录为一个 span；短信多在异步任务中发送，通常是独立的 trace
+func (s *SMSService) startSendSpan(eventType string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.Start(context.Background(), "SMSService.Send", tracing.KindInternal)
+	span.SetAttribute("sms.provider", s.cfg.SMS.Provider)
+	span.SetAttribute("sms.event_type", eventType)
+	return ctx, span
 }
 
 func (s *SMSService) SetPluginManager(pluginManager *PluginManagerService) {
@@ -246,46 +260,114 @@ func (s *SMSService) SendMarketingSMSWithBatch(user *models.User, content string
 // sendDirect sends the SMS without rate limit checks (used by delayed processing).
 func (s *SMSService) sendDirect(phone, phoneCode, code, eventType string) error {
 	smsCfg := s.cfg.SMS
-	message := fmt.Sprintf("Verification code: %s", code)
+	locale := resolveSMSLocale(smsCfg.Templates, s.lookupSMSUserLocale(phone), phoneCode)
+	message := renderSMSBody(s.cfg, locale, eventType, code)
 	originalMessage := message
 	if err := s.executeSMSBeforeHook(&phone, &phoneCode, &code, &message, eventType, nil, nil); err != nil {
 		s.emitSMSAfterHook(phone, phoneCode, code, message, eventType, smsCfg.Provider, nil, nil, err)
 		return err
 	}
 	if message == originalMessage {
-		message = fmt.Sprintf("Verification code: %s", code)
+		// 插件未改写正文时按（可能被改写的）验证码重新渲染
+		message = renderSMSBody(s.cfg, locale, eventType, code)
 	}
 
+	sendCtx, span := s.startSendSpan(eventType)
+	_, sendErr := s.dispatchVerificationSMS(sendCtx, phone, phoneCode, code, eventType, message)
+	span.EndWithError(sendErr)
+
+	s.logSms(phone, message, eventType, smsCfg.Provider, sendErr, nil, nil)
+	s.emitSMSAfterHook(phone, phoneCode, code, message, eventType, smsCfg.Provider, nil, nil, sendErr)
+	return sendErr
+}
+
+// dispatchVerificationSMS 按当前服务商发送验证码短信，返回服务商原始响应
+func (s *SMSService) dispatchVerificationSMS(ctx context.Context, phone, phoneCode, code, eventType, message string) (string, error) {
 	// Strip '+' prefix from phoneCode for providers that need bare country code
 	countryCode := strings.TrimPrefix(phoneCode, "+")
 
-	var sendErr error
-	switch smsCfg.Provider {
+	switch s.cfg.SMS.Provider {
 	case "aliyun":
-		sendErr = s.sendAliyun(phone, countryCode, code, eventType)
+		return s.sendAliyun(ctx, phone, countryCode, code, eventType)
 	case "aliyun_dypns":
-		sendErr = s.sendAliyunDYPNS(phone, countryCode, code, eventType)
+		return s.sendAliyunDYPNS(ctx, phone, countryCode, code, eventType)
 	case "twilio":
-		sendErr = s.sendTwilio(phoneCode+phone, code)
+		if resolveOTPPhoneChannel(s.cfg) == OTPChannelWhatsApp {
+			return s.sendTwilioWhatsAppMessage(ctx, phoneCode+phone, message)
+		}
+		return s.sendTwilioMessage(ctx, phoneCode+phone, message)
 	case "custom":
-		sendErr = s.sendCustomHTTP(phone, phoneCode, code)
+		return s.sendCustomHTTP(ctx, phone, phoneCode, code, message)
 	default:
-		sendErr = fmt.Errorf("unknown SMS provider: %s", smsCfg.Provider)
+		return "", fmt.Errorf("unknown SMS provider: %s", s.cfg.SMS.Provider)
 	}
-
-	s.logSms(phone, message, eventType, smsCfg.Provider, sendErr, nil, nil)
-	s.emitSMSAfterHook(phone, phoneCode, code, message, eventType, smsCfg.Provider, nil, nil, sendErr)
-	return sendErr
 }
 
 func (s *SMSService) sendMarketingDirect(phone, phoneCode, message string, userID, batchID *uint) error {
+	return s.sendTextDirect(phone, phoneCode, message, "marketing", userID, batchID)
+}
+
+// SendPaymentLinkSMS 发送订单付款链接短信，仅支持可发送自定义正文的服务商（twilio / custom）
+func (s *SMSService) SendPaymentLinkSMS(phone, phoneCode, orderNo, linkURL string, userID *uint) error {
+	return s.sendOrderLinkSMS(phone, phoneCode, "order_payment_link", userID, func(locale string) string {
+		if locale == "zh" {
+			return fmt.Sprintf("您的订单 %s 待付款，请点击链接完成支付：%s", orderNo, linkURL)
+		}
+		return fmt.Sprintf("Your order %s is awaiting payment. Pay here: %s", orderNo, linkURL)
+	})
+}
+
+// SendOrderRecoverySMS 发送未完成订单的挽回提醒短信，草稿订单提醒填写信息，待付款订单提醒继续付款
+func (s *SMSService) SendOrderRecoverySMS(phone, phoneCode, orderNo, linkURL string, draft bool, userID *uint) error {
+	return s.sendOrderLinkSMS(phone, phoneCode, "order_recovery", userID, func(locale string) string {
+		switch {
+		case draft && locale == "zh":
+			return fmt.Sprintf("您的订单 %s 尚未填写收货信息，请点击链接继续：%s", orderNo, linkURL)
+		case draft:
+			return fmt.Sprintf("Your order %s still needs your shipping details. Continue here: %s", orderNo, linkURL)
+		case locale == "zh":
+			return fmt.Sprintf("您的订单 %s 仍待付款，超时将自动取消，请点击链接继续支付：%s", orderNo, linkURL)
+		default:
+			return fmt.Sprintf("Your order %s is still awaiting payment and will be cancelled if unpaid. Resume here: %s", orderNo, linkURL)
+		}
+	})
+}
+
+// sendOrderLinkSMS 校验开关与限流后发送带订单链接的自定义正文短信
+func (s *SMSService) sendOrderLinkSMS(phone, phoneCode, eventType string, userID *uint, buildMessage func(locale string) string) error {
+	if !s.cfg.SMS.Enabled {
+		return fmt.Errorf("SMS service is not enabled")
+	}
+	phone = strings.TrimSpace(phone)
+	phoneCode = strings.TrimSpace(phoneCode)
+	if phone == "" {
+		return fmt.Errorf("phone is required")
+	}
+
+	recipient := phoneCode + phone
+	allowed, _, rateLimitErr := reserveMessageRateLimitSlot("sms", recipient, config.GetConfig().SMSRateLimit)
+	if rateLimitErr != nil {
+		log.Printf("Warning: SMS rate limit reservation failed for %s: %v", recipient, rateLimitErr)
+		allowed = true
+	}
+	if !allowed {
+		return fmt.Errorf("SMS rate limit exceeded")
+	}
+
+	message := buildMessage(resolveSMSLocale(s.cfg.SMS.Templates, s.lookupSMSUserLocale(phone), phoneCode))
+	return s.sendTextDirect(phone, phoneCode, message, eventType, userID, nil)
+}
+
+// sendTextDirect 发送自定义正文短信（不做限流检查）
+func (s *SMSService) sendTextDirect(phone, phoneCode, message, eventType string, userID, batchID *uint) error {
 	smsCfg := s.cfg.SMS
 	code := ""
-	if err := s.executeSMSBeforeHook(&phone, &phoneCode, &code, &message, "marketing", userID, batchID); err != nil {
-		s.emitSMSAfterHook(phone, phoneCode, code, message, "marketing", smsCfg.Provider, userID, batchID, err)
+	if err := s.executeSMSBeforeHook(&phone, &phoneCode, &code, &message, eventType, userID, batchID); err != nil {
+		s.emitSMSAfterHook(phone, phoneCode, code, message, eventType, smsCfg.Provider, userID, batchID, err)
 		return err
 	}
 
+	sendCtx, span := s.startSendSpan(eventType)
 	var sendErr error
 	switch smsCfg.Provider {
 	case "twilio":
@@ -293,25 +375,33 @@ func (s *SMSService) sendMarketingDirect(phone, phoneCode, message string, userI
 		if phoneCode != "" {
 			to = phoneCode + phone
 		}
-		sendErr = s.sendTwilioMessage(to, message)
+		_, sendErr = s.sendTwilioMessage(sendCtx, to, message)
 	case "custom":
-		sendErr = s.sendCustomHTTPMessage(phone, phoneCode, message)
+		_, sendErr = s.sendCustomHTTPMessage(sendCtx, phone, phoneCode, message)
 	default:
-		sendErr = fmt.Errorf("provider %s does not support marketing SMS", smsCfg.Provider)
+		sendErr = fmt.Errorf("provider %s does not support %s SMS", smsCfg.Provider, eventType)
 	}
+	span.EndWithError(sendErr)
 
-	s.logSms(phone, message, "marketing", smsCfg.Provider, sendErr, userID, batchID)
-	s.emitSMSAfterHook(phone, phoneCode, code, message, "marketing", smsCfg.Provider, userID, batchID, sendErr)
+	s.logSms(phone, message, eventType, smsCfg.Provider, sendErr, userID, batchID)
+	s.emitSMSAfterHook(phone, phoneCode, code, message, eventType, smsCfg.Provider, userID, batchID, sendErr)
 	return sendErr
 }
 
-// ProcessDelayedSMS periodically moves ready items from the delayed set and sends them.
-// Skips items older than 10 minutes since verification codes expire by then.
-func (s *SMSService) ProcessDelayedSMS(ctx context.Context) {
-	runBackgroundServiceWithContext("sms.ProcessDelayedSMS", ctx, s.processDelayedSMSLoop)
+// RegisterJobs registers the delayed SMS job, which moves ready items from the
+// delayed set and sends them every 30 seconds.
+func (s *SMSService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "sms_delayed",
+		Description: "Send rate-limited SMS messages once the recipient quota allows",
+		Interval:    30 * time.Second,
+		Run:         s.processDelayedSMS,
+	})
 }
 
-func (s *SMSService) processDelayedSMSLoop(ctx context.Context) {
+// processDelayedSMS sends delayed SMS whose scheduled time has arrived.
+// Skips items older than 10 minutes since verification codes expire by then.
+func (s *SMSService) processDelayedSMS(ctx context.Context) error {
 	type delayedSMSPayload struct {
 		Phone     string `json:"phone"`
 		PhoneCode string `json:"phone_code"`
@@ -320,71 +410,61 @@ func (s *SMSService) processDelayedSMSLoop(ctx context.Context) {
 		CreatedAt int64  `json:"created_at,omitempty"`
 	}
 
-	if cache.RedisClient == nil {
-		log.Println("ProcessDelayedSMS skipped: redis client is not initialized")
-		return
+	if cache.RedisClient == nil || s.cfg == nil || !s.cfg.SMS.Enabled {
+		return nil
 	}
 
+	now := time.Now()
+	results, err := cache.RedisClient.ZRangeByScoreWithScores(ctx, "sms:delayed", &redis.ZRangeBy{
+		Min: "-inf", Max: fmt.Sprintf("%f", float64(now.Unix())), Count: 50,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("load delayed sms: %w", err)
+	}
+	// Queue updates ignore cancellation so a sent message is never left in the delayed set.
 	redisCtx := cache.RedisClient.Context()
-	for {
-		select {
-		case <-ctx.Done():
-			log.Println("ProcessDelayedSMS shutting down")
-			return
-		case <-time.After(30 * time.Second):
-		}
-		if s.cfg == nil || !s.cfg.SMS.Enabled {
+	for _, z := range results {
+		payload, ok := z.Member.(string)
+		if !ok {
+			cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
 			continue
 		}
-		now := time.Now()
-		results, err := cache.RedisClient.ZRangeByScoreWithScores(redisCtx, "sms:delayed", &redis.ZRangeBy{
-			Min: "-inf", Max: fmt.Sprintf("%f", float64(now.Unix())), Count: 50,
-		}).Result()
-		if err != nil || len(results) == 0 {
+
+		var data delayedSMSPayload
+		if err := json.Unmarshal([]byte(payload), &data); err != nil {
+			cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
 			continue
 		}
-		for _, z := range results {
-			payload, ok := z.Member.(string)
-			if !ok {
-				cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
-				continue
-			}
-
-			var data delayedSMSPayload
-			if err := json.Unmarshal([]byte(payload), &data); err != nil {
-				cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
-				continue
-			}
-
-			createdAtUnix := data.CreatedAt
-			if createdAtUnix <= 0 {
-				createdAtUnix = int64(z.Score)
-			}
-			createdAt := time.Unix(createdAtUnix, 0)
-			if now.Sub(createdAt) > 10*time.Minute {
-				log.Printf("Delayed SMS expired (created %v ago), skipping", now.Sub(createdAt))
-				cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
-				continue
-			}
-
-			recipient := data.PhoneCode + data.Phone
-			allowed, availableAt, rateLimitErr := reserveMessageRateLimitSlot("sms", recipient, config.GetConfig().SMSRateLimit)
-			if rateLimitErr != nil {
-				log.Printf("Warning: delayed SMS rate limit reservation failed for %s: %v", recipient, rateLimitErr)
-				allowed = true
-			}
-			if !allowed {
-				cache.RedisClient.ZAdd(redisCtx, "sms:delayed", &redis.Z{
-					Score:  float64(availableAt.Unix()),
-					Member: payload,
-				})
-				continue
-			}
 
+		createdAtUnix := data.CreatedAt
+		if createdAtUnix <= 0 {
+			createdAtUnix = int64(z.Score)
+		}
+		createdAt := time.Unix(createdAtUnix, 0)
+		if now.Sub(createdAt) > 10*time.Minute {
+			log.Printf("Delayed SMS expired (created %v ago), skipping", now.Sub(createdAt))
 			cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
-			s.sendDirect(data.Phone, data.PhoneCode, data.Code, data.EventType)
+			continue
+		}
+
+		recipient := data.PhoneCode + data.Phone
+		allowed, availableAt, rateLimitErr := reserveMessageRateLimitSlot("sms", recipient, config.GetConfig().SMSRateLimit)
+		if rateLimitErr != nil {
+			log.Printf("Warning: delayed SMS rate limit reservation failed for %s: %v", recipient, rateLimitErr)
+			allowed = true
 		}
+		if !allowed {
+			cache.RedisClient.ZAdd(redisCtx, "sms:delayed", &redis.Z{
+				Score:  float64(availableAt.Unix()),
+				Member: payload,
+			})
+			continue
+		}
+
+		cache.RedisClient.ZRem(redisCtx, "sms:delayed", z.Member)
+		s.sendDirect(data.Phone, data.PhoneCode, data.Code, data.EventType)
 	}
+	return nil
 }
 
 // getTemplateCode 根据事件类型获取对应的模板代码
@@ -412,9 +492,18 @@ func (s *SMSService) getTemplateCode(eventType string) string {
 	return smsCfg.AliyunTemplateCode
 }
 
-// TestSMS 发送测试短信
-func (s *SMSService) TestSMS(phone string) error {
-	return s.SendVerificationCode(phone, "", "123456", "test")
+// TestSMS 使用当前配置直接发送测试短信（不计入频率限制、不触发插件钩子），返回服务商原始响应
+func (s *SMSService) TestSMS(phone, phoneCode string) (string, error) {
+	if !s.cfg.SMS.Enabled {
+		return "", fmt.Errorf("SMS service is not enabled")
+	}
+	locale := resolveSMSLocale(s.cfg.SMS.Templates, s.lookupSMSUserLocale(phone), phoneCode)
+	message := renderSMSBody(s.cfg, locale, "test", "123456")
+	sendCtx, span := s.startSendSpan("test")
+	raw, sendErr := s.dispatchVerificationSMS(sendCtx, phone, phoneCode, "123456", "test", message)
+	span.EndWithError(sendErr)
+	s.logSms(phone, message, "test", s.cfg.SMS.Provider, sendErr, nil, nil)
+	return raw, sendErr
 }
 
 func (s *SMSService) logSms(phone, content, eventType, provider string, sendErr error, userID, batchID *uint) {
@@ -442,7 +531,7 @@ func (s *SMSService) logSms(phone, content, eventType, provider string, sendErr
 	s.db.Create(&log)
 }
 
-func (s *SMSService) sendAliyun(phone, countryCode, code, eventType string) error {
+func (s *SMSService) sendAliyun(ctx context.Context, phone, countryCode, code, eventType string) (string, error) {
 	smsCfg := s.cfg.SMS
 	params := url.Values{}
 	params.Set("AccessKeyId", smsCfg.AliyunAccessKeyID)
@@ -463,15 +552,19 @@ func (s *SMSService) sendAliyun(phone, countryCode, code, eventType string) erro
 
 	params.Set("Signature", s.signAliyunParams(params))
 
-	resp, err := http.Get("https://dysmsapi.aliyuncs.com/?" + params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://dysmsapi.aliyuncs.com/?"+params.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("aliyun SMS request failed: %w", err)
+		return "", fmt.Errorf("aliyun SMS request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("aliyun SMS read response failed: %w", err)
+		return "", fmt.Errorf("aliyun SMS read response failed: %w", err)
 	}
 
 	var result struct {
@@ -480,13 +573,13 @@ func (s *SMSService) sendAliyun(phone, countryCode, code, eventType string) erro
 		RequestId string `json:"RequestId"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("aliyun SMS parse response failed: %w", err)
+		return string(body), fmt.Errorf("aliyun SMS parse response failed: %w", err)
 	}
 	if result.Code != "OK" {
-		return fmt.Errorf("aliyun SMS failed: %s - %s (RequestId: %s)", result.Code, result.Message, result.RequestId)
+		return string(body), fmt.Errorf("aliyun SMS failed: %s - %s (RequestId: %s)", result.Code, result.Message, result.RequestId)
 	}
 
-	return nil
+	return string(body), nil
 }
 
 // signAliyunParams 计算阿里云API签名
@@ -498,7 +591,7 @@ func (s *SMSService) signAliyunParams(params url.Values) string {
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
 }
 
-func (s *SMSService) sendAliyunDYPNS(phone, countryCode, code, eventType string) error {
+func (s *SMSService) sendAliyunDYPNS(ctx context.Context, phone, countryCode, code, eventType string) (string, error) {
 	smsCfg := s.cfg.SMS
 
 	params := url.Values{}
@@ -523,15 +616,19 @@ func (s *SMSService) sendAliyunDYPNS(phone, countryCode, code, eventType string)
 
 	params.Set("Signature", s.signAliyunParams(params))
 
-	resp, err := http.Get("https://dypnsapi.aliyuncs.com/?" + params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://dypnsapi.aliyuncs.com/?"+params.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("aliyun DYPNS request failed: %w", err)
+		return "", fmt.Errorf("aliyun DYPNS request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("aliyun DYPNS read response failed: %w", err)
+		return "", fmt.Errorf("aliyun DYPNS read response failed: %w", err)
 	}
 
 	var result struct {
@@ -540,53 +637,59 @@ func (s *SMSService) sendAliyunDYPNS(phone, countryCode, code, eventType string)
 		RequestId string `json:"RequestId"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("aliyun DYPNS parse response failed: %w", err)
+		return string(body), fmt.Errorf("aliyun DYPNS parse response failed: %w", err)
 	}
 	if result.Code != "OK" {
-		return fmt.Errorf("aliyun DYPNS failed: %s - %s (RequestId: %s)", result.Code, result.Message, result.RequestId)
+		return string(body), fmt.Errorf("aliyun DYPNS failed: %s - %s (RequestId: %s)", result.Code, result.Message, result.RequestId)
 	}
 
-	return nil
+	return string(body), nil
+}
+
+func (s *SMSService) sendTwilioMessage(ctx context.Context, phone, body string) (string, error) {
+	return s.postTwilioMessage(ctx, s.cfg.SMS.TwilioFromNumber, phone, body)
 }
 
-func (s *SMSService) sendTwilio(phone, code string) error {
-	return s.sendTwilioMessage(phone, fmt.Sprintf("Your verification code is: %s", code))
+// sendTwilioWhatsAppMessage 通过 Twilio WhatsApp 通道发送验证码
+func (s *SMSService) sendTwilioWhatsAppMessage(ctx context.Context, phone, body string) (string, error) {
+	return s.postTwilioMessage(ctx, "whatsapp:"+s.cfg.SMS.TwilioWhatsAppFrom, "whatsapp:"+phone, body)
 }
 
-func (s *SMSService) sendTwilioMessage(phone, body string) error {
+func (s *SMSService) postTwilioMessage(ctx context.Context, from, to, body string) (string, error) {
 	smsCfg := s.cfg.SMS
 	apiURL := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", smsCfg.TwilioAccountSID)
 
 	data := url.Values{}
-	data.Set("To", phone)
-	data.Set("From", smsCfg.TwilioFromNumber)
+	data.Set("To", to)
+	data.Set("From", from)
 	data.Set("Body", body)
 
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return err
+		return "", err
 	}
 	req.SetBasicAuth(smsCfg.TwilioAccountSID, smsCfg.TwilioAuthToken)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("twilio SMS request failed: %w", err)
+		return "", fmt.Errorf("twilio SMS request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("twilio SMS failed: %s", string(body))
+		return string(respBody), fmt.Errorf("twilio SMS failed: %s", string(respBody))
 	}
-	return nil
+	return string(respBody), nil
 }
 
-func (s *SMSService) sendCustomHTTP(phone, phoneCode, code string) error {
-	return s.sendCustomHTTPMessage(phone, phoneCode, code)
+func (s *SMSService) sendCustomHTTPMessage(ctx context.Context, phone, phoneCode, message string) (string, error) {
+	return s.sendCustomHTTP(ctx, phone, phoneCode, message, message)
 }
 
-func (s *SMSService) sendCustomHTTPMessage(phone, phoneCode, message string) error {
+// sendCustomHTTP 调用自定义 HTTP 接口，{{code}} 为验证码（营销短信时与正文相同），{{message}} 为本地化正文
+func (s *SMSService) sendCustomHTTP(ctx context.Context, phone, phoneCode, code, message string) (string, error) {
 	smsCfg := s.cfg.SMS
 	method := smsCfg.CustomMethod
 	if method == "" {
@@ -596,12 +699,12 @@ func (s *SMSService) sendCustomHTTPMessage(phone, phoneCode, message string) err
 	body := smsCfg.CustomBodyTemplate
 	body = strings.ReplaceAll(body, "{{phone}}", phone)
 	body = strings.ReplaceAll(body, "{{phone_code}}", phoneCode)
-	body = strings.ReplaceAll(body, "{{code}}", message)
+	body = strings.ReplaceAll(body, "{{code}}", code)
 	body = strings.ReplaceAll(body, "{{message}}", message)
 
-	req, err := http.NewRequest(method, smsCfg.CustomURL, bytes.NewBufferString(body))
+	req, err := http.NewRequestWithContext(ctx, method, smsCfg.CustomURL, bytes.NewBufferString(body))
 	if err != nil {
-		return err
+		return "", err
 	}
 	for k, v := range smsCfg.CustomHeaders {
 		req.Header.Set(k, v)
@@ -610,15 +713,15 @@ func (s *SMSService) sendCustomHTTPMessage(phone, phoneCode, message string) err
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("custom SMS request failed: %w", err)
+		return "", fmt.Errorf("custom SMS request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode >= 400 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("custom SMS failed: %s", string(respBody))
+		return string(respBody), fmt.Errorf("custom SMS failed: %s", string(respBody))
 	}
-	return nil
+	return string(respBody), nil
 }
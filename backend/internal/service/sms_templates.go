@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+const defaultSMSLocale = "en"
+
+// 内置短信正文，未配置 templates.bodies 时使用
+var builtinSMSBodies = map[string]string{
+	"en": "Your verification code is: {{code}}",
+	"zh": "您的验证码是：{{code}}，请勿泄露给他人。",
+}
+
+// normalizeSMSLocale 将 zh-CN / en_US 等形式归一为语言主标签
+func normalizeSMSLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if idx := strings.IndexAny(locale, "-_"); idx > 0 {
+		locale = locale[:idx]
+	}
+	return locale
+}
+
+// normalizeSMSPhoneCode 统一区号格式为 "+86"
+func normalizeSMSPhoneCode(phoneCode string) string {
+	phoneCode = strings.TrimSpace(phoneCode)
+	if phoneCode == "" {
+		return ""
+	}
+	return "+" + strings.TrimLeft(phoneCode, "+")
+}
+
+// resolveSMSLocale 选择短信语言：用户语言偏好 > 手机区号映射 > 默认语言
+func resolveSMSLocale(templates config.SMSTemplates, userLocale, phoneCode string) string {
+	if locale := normalizeSMSLocale(userLocale); locale != "" {
+		return locale
+	}
+	if code := normalizeSMSPhoneCode(phoneCode); code != "" {
+		for key, locale := range templates.CountryLocales {
+			if normalizeSMSPhoneCode(key) == code {
+				if normalized := normalizeSMSLocale(locale); normalized != "" {
+					return normalized
+				}
+			}
+		}
+	}
+	if locale := normalizeSMSLocale(templates.DefaultLocale); locale != "" {
+		return locale
+	}
+	return defaultSMSLocale
+}
+
+// lookupSMSBody 按 语言+事件 -> 语言默认 的顺序查找配置的正文
+func lookupSMSBody(templates config.SMSTemplates, locale, eventType string) string {
+	for key, bodies := range templates.Bodies {
+		if normalizeSMSLocale(key) != locale {
+			continue
+		}
+		if body := strings.TrimSpace(bodies[eventType]); body != "" {
+			return body
+		}
+		if body := strings.TrimSpace(bodies["default"]); body != "" {
+			return body
+		}
+	}
+	return ""
+}
+
+// renderSMSBody 渲染本地化的验证码短信正文
+func renderSMSBody(cfg *config.Config, locale, eventType, code string) string {
+	templates := cfg.SMS.Templates
+	body := lookupSMSBody(templates, locale, eventType)
+	if body == "" {
+		body = builtinSMSBodies[locale]
+	}
+	if body == "" {
+		fallback := resolveSMSLocale(templates, "", "")
+		body = lookupSMSBody(templates, fallback, eventType)
+		if body == "" {
+			body = builtinSMSBodies[fallback]
+		}
+	}
+	if body == "" {
+		body = builtinSMSBodies[defaultSMSLocale]
+	}
+
+	replacer := strings.NewReplacer(
+		"{{code}}", code,
+		"{{app_name}}", cfg.App.Name,
+		"{{event}}", eventType,
+	)
+	return replacer.Replace(body)
+}
+
+// lookupSMSUserLocale 根据手机号查找已注册用户的语言偏好
+func (s *SMSService) lookupSMSUserLocale(phone string) string {
+	phone = strings.TrimSpace(phone)
+	if s.db == nil || phone == "" {
+		return ""
+	}
+	var user models.User
+	if err := s.db.Select("id", "locale").Where("phone = ?", phone).Limit(1).Find(&user).Error; err != nil {
+		return ""
+	}
+	return user.Locale
+}
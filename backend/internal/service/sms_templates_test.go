@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+)
+
+func TestResolveSMSLocalePriority(t *testing.T) {
+	templates := config.SMSTemplates{
+		CountryLocales: map[string]string{"86": "zh", "+81": "ja"},
+		DefaultLocale:  "en",
+	}
+
+	cases := []struct {
+		name       string
+		userLocale string
+		phoneCode  string
+		want       string
+	}{
+		{name: "user locale wins", userLocale: "zh-CN", phoneCode: "+81", want: "zh"},
+		{name: "phone code without plus", phoneCode: "+86", want: "zh"},
+		{name: "phone code mapping", phoneCode: "81", want: "ja"},
+		{name: "default locale", phoneCode: "+1", want: "en"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolveSMSLocale(templates, tc.userLocale, tc.phoneCode); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderSMSBodyUsesConfiguredTemplatesAndFallbacks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Name = "AuraLogic"
+	cfg.SMS.Templates = config.SMSTemplates{
+		DefaultLocale: "en",
+		Bodies: map[string]map[string]string{
+			"zh": {
+				"login":   "【{{app_name}}】登录验证码 {{code}}",
+				"default": "【{{app_name}}】验证码 {{code}}",
+			},
+			"EN": {
+				"default": "{{app_name}} code: {{code}}",
+			},
+		},
+	}
+
+	if got := renderSMSBody(cfg, "zh", "login", "123456"); got != "【AuraLogic】登录验证码 123456" {
+		t.Fatalf("unexpected zh login body: %q", got)
+	}
+	if got := renderSMSBody(cfg, "zh", "register", "123456"); got != "【AuraLogic】验证码 123456" {
+		t.Fatalf("unexpected zh default body: %q", got)
+	}
+	if got := renderSMSBody(cfg, "en", "register", "654321"); got != "AuraLogic code: 654321" {
+		t.Fatalf("unexpected en body: %q", got)
+	}
+	// 未配置的语言回退到默认语言模板
+	if got := renderSMSBody(cfg, "fr", "login", "111111"); got != "AuraLogic code: 111111" {
+		t.Fatalf("unexpected fallback body: %q", got)
+	}
+
+	cfg.SMS.Templates = config.SMSTemplates{}
+	if got := renderSMSBody(cfg, "zh", "login", "222222"); got != "您的验证码是：222222，请勿泄露给他人。" {
+		t.Fatalf("unexpected builtin zh body: %q", got)
+	}
+	if got := renderSMSBody(cfg, "de", "login", "333333"); got != "Your verification code is: 333333" {
+		t.Fatalf("unexpected builtin fallback body: %q", got)
+	}
+}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/money"
+	"auralogic/internal/pkg/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSpendingControlCooldownHours = 24
+	maxSpendingControlCategories        = 50
+	maxSpendingControlCategoryLength    = 100
+	maxSpendingControlOwnerEmailLength  = 255
+)
+
+// 消费控制变更通知类型
+const (
+	SpendingControlChangeApplied   = "applied"   // 已生效
+	SpendingControlChangeScheduled = "scheduled" // 冷静期后生效
+	SpendingControlChangeCancelled = "cancelled" // 待生效的变更已撤销
+)
+
+var ErrSpendingControlUserNotFound = errors.New("user not found")
+
+// SpendingControlOverview 消费控制及本月已消费金额
+type SpendingControlOverview struct {
+	Control           models.SpendingControl `json:"control"`
+	MonthlySpentMinor int64                  `json:"monthly_spent_minor"`
+	Currency          string                 `json:"currency"`
+	CooldownHours     int                    `json:"cooldown_hours"`
+}
+
+// SpendingControlService 账户消费控制：每月消费限额与禁止购买的分类，放宽限制需经过冷静期
+type SpendingControlService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	emailService *EmailService
+}
+
+// NewSpendingControlService 创建消费控制服务
+func NewSpendingControlService(db *gorm.DB, cfg *config.Config, emailService *EmailService) *SpendingControlService {
+	return &SpendingControlService{db: db, cfg: cfg, emailService: emailService}
+}
+
+func (s *SpendingControlService) currency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+func (s *SpendingControlService) cooldownHours() int {
+	if s.cfg != nil && s.cfg.Order.SpendingControls.ChangeCooldownHours > 0 {
+		return s.cfg.Order.SpendingControls.ChangeCooldownHours
+	}
+	return defaultSpendingControlCooldownHours
+}
+
+func spendingControlDisabledError() error {
+	return bizerr.New("spendingControl.disabled", "Spending controls are not available")
+}
+
+func (s *SpendingControlService) ensureEnabled() error {
+	if s.cfg == nil || !s.cfg.Order.SpendingControls.Enabled {
+		return spendingControlDisabledError()
+	}
+	return nil
+}
+
+// normalizeSpendingControlTerms 校验条款，分类去重排序，邮箱统一小写
+func normalizeSpendingControlTerms(terms models.SpendingControlTerms) (models.SpendingControlTerms, error) {
+	if terms.MonthlyLimit < 0 {
+		return terms, bizerr.New("spendingControl.limitInvalid", "Monthly limit cannot be negative")
+	}
+
+	seen := make(map[string]struct{}, len(terms.BlockedCategories))
+	categories := make([]string, 0, len(terms.BlockedCategories))
+	for _, category := range terms.BlockedCategories {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			continue
+		}
+		if len([]rune(category)) > maxSpendingControlCategoryLength {
+			return terms, bizerr.Newf("spendingControl.categoryTooLong", "Category cannot exceed %d characters", maxSpendingControlCategoryLength).
+				WithParams(map[string]interface{}{"max": maxSpendingControlCategoryLength})
+		}
+		key := strings.ToLower(category)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		categories = append(categories, category)
+	}
+	if len(categories) > maxSpendingControlCategories {
+		return terms, bizerr.Newf("spendingControl.categoriesTooMany", "At most %d categories can be blocked", maxSpendingControlCategories).
+			WithParams(map[string]interface{}{"max": maxSpendingControlCategories})
+	}
+	sort.Strings(categories)
+	terms.BlockedCategories = categories
+
+	terms.OwnerEmail = strings.ToLower(strings.TrimSpace(terms.OwnerEmail))
+	if terms.OwnerEmail != "" && (len(terms.OwnerEmail) > maxSpendingControlOwnerEmailLength || !validator.IsValidEmail(terms.OwnerEmail)) {
+		return terms, bizerr.New("spendingControl.ownerEmailInvalid", "A valid owner email address is required")
+	}
+	return terms, nil
+}
+
+// spendingControlLoosens 新条款是否放宽了当前限制：提高或取消限额、解除分类限制、更换或移除所有人邮箱
+func spendingControlLoosens(current, next models.SpendingControlTerms) bool {
+	if current.MonthlyLimit > 0 && (next.MonthlyLimit == 0 || next.MonthlyLimit > current.MonthlyLimit) {
+		return true
+	}
+	for _, category := range current.BlockedCategories {
+		if !spendingControlCategoryBlocked(next.BlockedCategories, category) {
+			return true
+		}
+	}
+	return current.OwnerEmail != "" && current.OwnerEmail != next.OwnerEmail
+}
+
+func spendingControlCategoryBlocked(blocked []string, category string) bool {
+	for _, item := range blocked {
+		if strings.EqualFold(item, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDueSpendingControlTx 冷静期已过的变更在读取时生效
+func resolveDueSpendingControlTx(tx *gorm.DB, control *models.SpendingControl, now time.Time) error {
+	if control.ID == 0 || control.Pending == nil || control.PendingEffectiveAt == nil || now.Before(*control.PendingEffectiveAt) {
+		return nil
+	}
+	control.ApplyTerms(*control.Pending)
+	control.Pending = nil
+	control.PendingEffectiveAt = nil
+	return tx.Save(control).Error
+}
+
+// lockSpendingControlTx 锁定并读取用户的消费控制，不存在时返回未保存的空记录
+func lockSpendingControlTx(tx *gorm.DB, userID uint) (*models.SpendingControl, error) {
+	control := &models.SpendingControl{UserID: userID}
+	if err := dbutil.LockForUpdate(tx, &models.SpendingControl{}, "user_id = ?", userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return control, nil
+		}
+		return nil, err
+	}
+	if err := tx.Where("user_id = ?", userID).First(control).Error; err != nil {
+		return nil, err
+	}
+	return control, nil
+}
+
+// spendingMonthlySpentTx 用户本月下单金额，已取消和已退款的订单不计入
+func spendingMonthlySpentTx(tx *gorm.DB, userID uint, now time.Time) (int64, error) {
+	var spent int64
+	err := tx.Model(&models.Order{}).
+		Where("user_id = ? AND created_at >= ?", userID, organizationMonthStart(now)).
+		Where("status NOT IN ?", []models.OrderStatus{models.OrderStatusCancelled, models.OrderStatusRefunded}).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Scan(&spent).Error
+	return spent, err
+}
+
+// orderItemCategories 订单项对应商品的分类
+func orderItemCategories(items []models.OrderItem, productBySKU map[string]*models.Product) []string {
+	categories := make([]string, 0, len(items))
+	for _, item := range items {
+		if product := productBySKU[item.SKU]; product != nil && product.Category != "" {
+			categories = append(categories, product.Category)
+		}
+	}
+	return categories
+}
+
+// applySpendingControlsTx 下单时校验禁止购买的分类与每月消费限额，未设置消费控制的用户不受影响
+func applySpendingControlsTx(tx *gorm.DB, cfg *config.Config, order *models.Order, categories []string, now time.Time) error {
+	if cfg == nil || !cfg.Order.SpendingControls.Enabled || order.UserID == nil {
+		return nil
+	}
+	// 锁定记录，避免并发下单绕过限额
+	control, err := lockSpendingControlTx(tx, *order.UserID)
+	if err != nil || control.ID == 0 {
+		return err
+	}
+	if err := resolveDueSpendingControlTx(tx, control, now); err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if category != "" && spendingControlCategoryBlocked(control.BlockedCategories, category) {
+			return bizerr.New("spendingControl.categoryBlocked", "Purchases from this category are blocked on your account").
+				WithParams(map[string]interface{}{"category": category})
+		}
+	}
+	if control.MonthlyLimit > 0 {
+		spent, err := spendingMonthlySpentTx(tx, *order.UserID, now)
+		if err != nil {
+			return err
+		}
+		if spent+order.TotalAmount > control.MonthlyLimit {
+			remaining := control.MonthlyLimit - spent
+			if remaining < 0 {
+				remaining = 0
+			}
+			return bizerr.New("spendingControl.limitExceeded", "Order exceeds the monthly spending limit on your account").
+				WithParams(map[string]interface{}{
+					"remaining": money.MinorToString(remaining),
+					"currency":  order.Currency,
+				})
+		}
+	}
+	return nil
+}
+
+func (s *SpendingControlService) overview(userID uint) (*SpendingControlOverview, error) {
+	now := time.Now()
+	var result *SpendingControlOverview
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		control, err := lockSpendingControlTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := resolveDueSpendingControlTx(tx, control, now); err != nil {
+			return err
+		}
+		spent, err := spendingMonthlySpentTx(tx, userID, now)
+		if err != nil {
+			return err
+		}
+		result = &SpendingControlOverview{
+			Control:           *control,
+			MonthlySpentMinor: spent,
+			Currency:          s.currency(),
+			CooldownHours:     s.cooldownHours(),
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Get 当前用户的消费控制
+func (s *SpendingControlService) Get(userID uint) (*SpendingControlOverview, error) {
+	if err := s.ensureEnabled(); err != nil {
+		return nil, err
+	}
+	return s.overview(userID)
+}
+
+// Update 用户修改自己的消费控制：收紧立即生效并撤销待生效的变更，放宽则在冷静期后生效
+// 返回变更是否已立即生效
+func (s *SpendingControlService) Update(userID uint, terms models.SpendingControlTerms) (*SpendingControlOverview, bool, error) {
+	if err := s.ensureEnabled(); err != nil {
+		return nil, false, err
+	}
+	return s.update(userID, terms, userID, false)
+}
+
+// AdminGet 管理员查看用户的消费控制
+func (s *SpendingControlService) AdminGet(userID uint) (*SpendingControlOverview, error) {
+	if _, err := s.findUser(userID); err != nil {
+		return nil, err
+	}
+	return s.overview(userID)
+}
+
+// AdminUpdate 管理员修改用户的消费控制，立即生效且不受冷静期限制
+func (s *SpendingControlService) AdminUpdate(userID, adminID uint, terms models.SpendingControlTerms) (*SpendingControlOverview, error) {
+	overview, _, err := s.update(userID, terms, adminID, true)
+	return overview, err
+}
+
+func (s *SpendingControlService) findUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSpendingControlUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *SpendingControlService) update(userID uint, terms models.SpendingControlTerms, actorID uint, immediate bool) (*SpendingControlOverview, bool, error) {
+	next, err := normalizeSpendingControlTerms(terms)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	applied := true
+	var previousOwner string
+	var control *models.SpendingControl
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		control, err = lockSpendingControlTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := resolveDueSpendingControlTx(tx, control, now); err != nil {
+			return err
+		}
+		previousOwner = control.OwnerEmail
+		if !immediate && spendingControlLoosens(control.Terms(), next) {
+			applied = false
+			effectiveAt := now.Add(time.Duration(s.cooldownHours()) * time.Hour)
+			control.Pending = &next
+			control.PendingEffectiveAt = &effectiveAt
+		} else {
+			control.ApplyTerms(next)
+			control.Pending = nil
+			control.PendingEffectiveAt = nil
+		}
+		control.UpdatedBy = &actorID
+		return tx.Save(control).Error
+	}); err != nil {
+		return nil, false, err
+	}
+
+	if applied {
+		s.notify(user, []string{previousOwner, control.OwnerEmail}, next, SpendingControlChangeApplied, nil)
+	} else {
+		s.notify(user, []string{previousOwner}, next, SpendingControlChangeScheduled, control.PendingEffectiveAt)
+	}
+	overview, err := s.overview(userID)
+	return overview, applied, err
+}
+
+// CancelPending 撤销冷静期中的变更
+func (s *SpendingControlService) CancelPending(userID uint) (*SpendingControlOverview, error) {
+	if err := s.ensureEnabled(); err != nil {
+		return nil, err
+	}
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	var cancelled models.SpendingControlTerms
+	var owner string
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		control, err := lockSpendingControlTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := resolveDueSpendingControlTx(tx, control, time.Now()); err != nil {
+			return err
+		}
+		if control.Pending == nil {
+			return bizerr.New("spendingControl.noPendingChange", "There is no pending change to cancel")
+		}
+		cancelled = *control.Pending
+		owner = control.OwnerEmail
+		control.Pending = nil
+		control.PendingEffectiveAt = nil
+		control.UpdatedBy = &userID
+		return tx.Save(control).Error
+	}); err != nil {
+		return nil, err
+	}
+	s.notify(user, []string{owner}, cancelled, SpendingControlChangeCancelled, nil)
+	return s.overview(userID)
+}
+
+// notify 通知账户所有人邮箱；未设置时通知账户本身的邮箱
+func (s *SpendingControlService) notify(user *models.User, owners []string, terms models.SpendingControlTerms, change string, effectiveAt *time.Time) {
+	if s.emailService == nil {
+		return
+	}
+	recipients := make([]string, 0, len(owners))
+	seen := make(map[string]struct{}, len(owners))
+	for _, owner := range owners {
+		if owner == "" {
+			owner = strings.TrimSpace(user.Email)
+		}
+		if owner == "" {
+			continue
+		}
+		if _, ok := seen[owner]; ok {
+			continue
+		}
+		seen[owner] = struct{}{}
+		recipients = append(recipients, owner)
+	}
+	currency := s.currency()
+	for _, to := range recipients {
+		go s.emailService.SendSpendingControlChangedEmail(to, user, terms, currency, change, effectiveAt)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+func checkoutSpendingControlTestOrder(t *testing.T, svc *SpendingControlService, userID uint, orderNo, category string, amount int64) error {
+	t.Helper()
+	order := &models.Order{
+		OrderNo:     orderNo,
+		UserID:      &userID,
+		Status:      models.OrderStatusPendingPayment,
+		Currency:    "CNY",
+		TotalAmount: amount,
+		Items:       []models.OrderItem{{SKU: orderNo, Name: "Item", Quantity: 1, ProductType: models.ProductTypePhysical}},
+	}
+	return svc.db.Transaction(func(tx *gorm.DB) error {
+		if err := applySpendingControlsTx(tx, svc.cfg, order, []string{category}, time.Now()); err != nil {
+			return err
+		}
+		return tx.Create(order).Error
+	})
+}
+
+func TestSpendingControlCooldownAndCheckout(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.SpendingControl{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	svc := NewSpendingControlService(db, orderSvc.cfg, nil)
+	user := &models.User{UUID: "spend-user", Email: "kid@example.com", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	_, _, err := svc.Update(user.ID, models.SpendingControlTerms{MonthlyLimit: 5000})
+	requireOrderBizErr(t, err, "spendingControl.disabled")
+	orderSvc.cfg.Order.SpendingControls.Enabled = true
+	orderSvc.cfg.Order.SpendingControls.ChangeCooldownHours = 48
+
+	_, _, err = svc.Update(user.ID, models.SpendingControlTerms{OwnerEmail: "not-an-email"})
+	requireOrderBizErr(t, err, "spendingControl.ownerEmailInvalid")
+
+	// 首次设置属于收紧，立即生效
+	overview, applied, err := svc.Update(user.ID, models.SpendingControlTerms{
+		MonthlyLimit:      5000,
+		BlockedCategories: []string{" Games ", "games", "Alcohol"},
+		OwnerEmail:        "Parent@Example.com",
+	})
+	if err != nil || !applied {
+		t.Fatalf("expected initial controls to apply, applied=%v err=%v", applied, err)
+	}
+	control := overview.Control
+	if control.MonthlyLimit != 5000 || len(control.BlockedCategories) != 2 || control.OwnerEmail != "parent@example.com" {
+		t.Fatalf("unexpected control: %+v", control)
+	}
+
+	err = checkoutSpendingControlTestOrder(t, svc, user.ID, "SPEND-1", "GAMES", 100)
+	bizErr := requireOrderBizErr(t, err, "spendingControl.categoryBlocked")
+	if bizErr.Params["category"] != "GAMES" {
+		t.Fatalf("unexpected params: %v", bizErr.Params)
+	}
+	if err := checkoutSpendingControlTestOrder(t, svc, user.ID, "SPEND-2", "Books", 3000); err != nil {
+		t.Fatalf("checkout within limit: %v", err)
+	}
+	err = checkoutSpendingControlTestOrder(t, svc, user.ID, "SPEND-3", "Books", 2500)
+	bizErr = requireOrderBizErr(t, err, "spendingControl.limitExceeded")
+	if bizErr.Params["remaining"] != "20.00" {
+		t.Fatalf("expected remaining 20.00, got %v", bizErr.Params["remaining"])
+	}
+
+	// 放宽限额进入冷静期，原限制继续生效
+	overview, applied, err = svc.Update(user.ID, models.SpendingControlTerms{
+		MonthlyLimit:      10000,
+		BlockedCategories: []string{"Alcohol", "Games"},
+		OwnerEmail:        "parent@example.com",
+	})
+	if err != nil || applied {
+		t.Fatalf("expected raised limit to be scheduled, applied=%v err=%v", applied, err)
+	}
+	if overview.Control.MonthlyLimit != 5000 || overview.Control.Pending == nil || overview.Control.PendingEffectiveAt == nil {
+		t.Fatalf("unexpected scheduled control: %+v", overview.Control)
+	}
+	if overview.MonthlySpentMinor != 3000 {
+		t.Fatalf("expected spent 3000, got %d", overview.MonthlySpentMinor)
+	}
+	requireOrderBizErr(t, checkoutSpendingControlTestOrder(t, svc, user.ID, "SPEND-3", "Books", 2500), "spendingControl.limitExceeded")
+
+	if _, err := svc.CancelPending(user.ID); err != nil {
+		t.Fatalf("cancel pending: %v", err)
+	}
+	_, err = svc.CancelPending(user.ID)
+	requireOrderBizErr(t, err, "spendingControl.noPendingChange")
+
+	// 冷静期结束后变更在下单时生效
+	if _, _, err := svc.Update(user.ID, models.SpendingControlTerms{MonthlyLimit: 10000, BlockedCategories: []string{"Games"}, OwnerEmail: "parent@example.com"}); err != nil {
+		t.Fatalf("schedule change: %v", err)
+	}
+	if err := db.Model(&models.SpendingControl{}).Where("user_id = ?", user.ID).
+		Update("pending_effective_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire cooldown: %v", err)
+	}
+	if err := checkoutSpendingControlTestOrder(t, svc, user.ID, "SPEND-4", "Alcohol", 2500); err != nil {
+		t.Fatalf("expected relaxed controls after cooldown: %v", err)
+	}
+	var stored models.SpendingControl
+	db.Where("user_id = ?", user.ID).First(&stored)
+	if stored.MonthlyLimit != 10000 || stored.Pending != nil || len(stored.BlockedCategories) != 1 {
+		t.Fatalf("pending change not applied: %+v", stored)
+	}
+
+	// 管理员修改不受冷静期限制
+	overview, err = svc.AdminUpdate(user.ID, 1, models.SpendingControlTerms{})
+	if err != nil || overview.Control.MonthlyLimit != 0 || len(overview.Control.BlockedCategories) != 0 || overview.Control.Pending != nil {
+		t.Fatalf("expected admin change to apply immediately, got %+v err=%v", overview, err)
+	}
+	if _, err := svc.AdminGet(user.ID + 100); err != ErrSpendingControlUserNotFound {
+		t.Fatalf("expected missing user error, got %v", err)
+	}
+}
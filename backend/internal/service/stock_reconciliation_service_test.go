@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+)
+
+func TestStockReconciliationDetectsAndHealsDrift(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}, &models.Order{}, &models.StockReconciliationRun{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	drifted := &models.Inventory{Name: "drifted", Stock: 10, AvailableQuantity: 10, ReservedQuantity: 5, IsActive: true}
+	oversold := &models.Inventory{Name: "oversold", Stock: 1, AvailableQuantity: 1, ReservedQuantity: 3, IsActive: true}
+	for _, inventory := range []*models.Inventory{drifted, oversold} {
+		if err := db.Create(inventory).Error; err != nil {
+			t.Fatalf("create inventory: %v", err)
+		}
+	}
+
+	orders := []models.Order{
+		{
+			OrderNo:           "OPEN-1",
+			Status:            models.OrderStatusPending,
+			Items:             []models.OrderItem{{SKU: "A", Quantity: 2}},
+			InventoryBindings: map[int]uint{0: drifted.ID},
+		},
+		{
+			OrderNo:           "OPEN-2",
+			Status:            models.OrderStatusPendingPayment,
+			Items:             []models.OrderItem{{SKU: "B", Quantity: 3}},
+			InventoryBindings: map[int]uint{0: oversold.ID},
+		},
+		{
+			OrderNo:           "CANCELLED-1",
+			Status:            models.OrderStatusCancelled,
+			Items:             []models.OrderItem{{SKU: "A", Quantity: 4}},
+			InventoryBindings: map[int]uint{0: drifted.ID},
+		},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+
+	virtualInventory := &models.VirtualInventory{Name: "codes", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(virtualInventory).Error; err != nil {
+		t.Fatalf("create virtual inventory: %v", err)
+	}
+	stocks := []models.VirtualProductStock{
+		{VirtualInventoryID: virtualInventory.ID, Content: "leaked", Status: models.VirtualStockStatusReserved, OrderNo: "CANCELLED-1"},
+		{VirtualInventoryID: virtualInventory.ID, Content: "held", Status: models.VirtualStockStatusReserved, OrderNo: "OPEN-1"},
+		{VirtualInventoryID: virtualInventory.ID, Content: "manual", Status: models.VirtualStockStatusReserved, OrderNo: "MANUAL-RESERVE"},
+	}
+	for i := range stocks {
+		if err := db.Create(&stocks[i]).Error; err != nil {
+			t.Fatalf("create stock: %v", err)
+		}
+	}
+
+	svc := NewStockReconciliationService(db, nil, nil)
+	report, err := svc.Run(models.StockReconciliationTriggerManual, nil, false)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	kinds := map[string]int{}
+	for _, item := range report.Discrepancies {
+		kinds[item.Kind]++
+	}
+	if kinds[models.StockDiscrepancyReservedMismatch] != 1 || kinds[models.StockDiscrepancyOversold] != 1 || kinds[models.StockDiscrepancyVirtualLeak] != 1 {
+		t.Fatalf("unexpected discrepancies: %+v", report.Discrepancies)
+	}
+	if report.HealedCount != 0 {
+		t.Fatalf("report-only run must not heal, got %d", report.HealedCount)
+	}
+
+	healed, err := svc.Run(models.StockReconciliationTriggerManual, nil, true)
+	if err != nil {
+		t.Fatalf("heal run: %v", err)
+	}
+	if healed.HealedCount != 2 {
+		t.Fatalf("expected reserved mismatch and virtual leak to be healed, got %d: %+v", healed.HealedCount, healed.Discrepancies)
+	}
+
+	var reloaded models.Inventory
+	if err := db.First(&reloaded, drifted.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if reloaded.ReservedQuantity != 2 {
+		t.Fatalf("expected reserved quantity 2 after heal, got %d", reloaded.ReservedQuantity)
+	}
+	var leaked models.VirtualProductStock
+	if err := db.First(&leaked, stocks[0].ID).Error; err != nil {
+		t.Fatalf("reload stock: %v", err)
+	}
+	if leaked.Status != models.VirtualStockStatusAvailable || leaked.OrderNo != "" {
+		t.Fatalf("expected leaked stock to be released, got %s/%s", leaked.Status, leaked.OrderNo)
+	}
+
+	after, err := svc.Run(models.StockReconciliationTriggerManual, nil, false)
+	if err != nil {
+		t.Fatalf("verify run: %v", err)
+	}
+	if after.DiscrepancyCount != 1 || after.OversoldCount != 1 {
+		t.Fatalf("only the oversold inventory should remain, got %+v", after.Discrepancies)
+	}
+}
+
+func TestStockReconciliationRunSharesScheduledJobLock(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Inventory{}, &models.Order{}, &models.StockReconciliationRun{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	// 另一实例正在执行定时对账
+	locker := NewLocalJobLocker()
+	if ok, err := locker.TryLock(context.Background(), jobLockKeyPrefix+stockReconciliationJobName, "other-instance", jobLockTTL); err != nil || !ok {
+		t.Fatalf("hold job lock: ok=%v err=%v", ok, err)
+	}
+	svc := NewStockReconciliationService(db, nil, nil)
+	svc.locker = locker
+
+	_, err := svc.Run(models.StockReconciliationTriggerManual, nil, false)
+	var bizErr *bizerr.Error
+	if !errors.As(err, &bizErr) || bizErr.Key != "inventory.reconciliationRunning" {
+		t.Fatalf("expected reconciliationRunning while the job lock is held, got %v", err)
+	}
+
+	if err := locker.Unlock(context.Background(), jobLockKeyPrefix+stockReconciliationJobName, "other-instance"); err != nil {
+		t.Fatalf("release job lock: %v", err)
+	}
+	if _, err := svc.Run(models.StockReconciliationTriggerManual, nil, false); err != nil {
+		t.Fatalf("run after lock released: %v", err)
+	}
+}
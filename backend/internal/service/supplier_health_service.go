@@ -0,0 +1,310 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	supplierHealthBucketSize         = 5 * time.Minute
+	supplierHealthRetention          = 7 * 24 * time.Hour
+	supplierHealthPruneInterval      = time.Hour
+	defaultSupplierHealthWindow      = 15 * time.Minute
+	defaultSupplierHealthMinRequests = 20
+	defaultSupplierHealthErrorRate   = 0.5
+)
+
+// SupplierEndpointHealth 单个脚本库存上游接口在统计窗口内的健康状况
+type SupplierEndpointHealth struct {
+	VirtualInventoryID  uint       `json:"virtual_inventory_id"`
+	InventoryName       string     `json:"inventory_name"`
+	Endpoint            string     `json:"endpoint"`
+	Requests            int64      `json:"requests"`
+	Failures            int64      `json:"failures"`
+	SuccessRate         float64    `json:"success_rate"`
+	AvgLatencyMs        int64      `json:"avg_latency_ms"`
+	MaxLatencyMs        int64      `json:"max_latency_ms"`
+	LastStatus          int        `json:"last_status"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastSeenAt          time.Time  `json:"last_seen_at"`
+	SupplierPausedAt    *time.Time `json:"supplier_paused_at,omitempty"`
+	SupplierPauseReason string     `json:"supplier_pause_reason,omitempty"`
+}
+
+// SupplierHealthService 记录脚本发货的上游调用结果，并在错误率过高时自动暂停对应库存
+type SupplierHealthService struct {
+	db  *gorm.DB
+	cfg *config.Config
+
+	pruneMu   sync.Mutex
+	lastPrune time.Time
+}
+
+func NewSupplierHealthService(db *gorm.DB, cfg *config.Config) *SupplierHealthService {
+	return &SupplierHealthService{db: db, cfg: cfg}
+}
+
+func (s *SupplierHealthService) healthConfig() config.SupplierHealthConfig {
+	if s.cfg == nil {
+		return config.SupplierHealthConfig{}
+	}
+	return s.cfg.Order.SupplierHealth
+}
+
+// Window 统计窗口
+func (s *SupplierHealthService) Window() time.Duration {
+	if minutes := s.healthConfig().WindowMinutes; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return defaultSupplierHealthWindow
+}
+
+func (s *SupplierHealthService) minRequests() int64 {
+	if n := s.healthConfig().MinRequests; n > 0 {
+		return int64(n)
+	}
+	return defaultSupplierHealthMinRequests
+}
+
+func (s *SupplierHealthService) errorRateThreshold() float64 {
+	if rate := s.healthConfig().ErrorRateThreshold; rate > 0 && rate <= 1 {
+		return rate
+	}
+	return defaultSupplierHealthErrorRate
+}
+
+// supplierEndpoint 只保留 scheme://host 作为统计维度
+func supplierEndpoint(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return "invalid"
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host)
+}
+
+// isSupplierFailure 网络错误、5xx 与 429 视为上游故障，其余 4xx 多为脚本自身参数问题
+func isSupplierFailure(status int, errMsg string) bool {
+	if errMsg != "" || status == 0 {
+		return true
+	}
+	return status >= 500 || status == 429
+}
+
+// Record 记录一次上游调用
+func (s *SupplierHealthService) Record(inventoryID uint, rawURL string, status int, latency time.Duration, errMsg string) {
+	if s == nil || s.db == nil || inventoryID == 0 {
+		return
+	}
+
+	now := models.NowFunc()
+	failed := isSupplierFailure(status, errMsg)
+	failures := int64(0)
+	if failed {
+		failures = 1
+	}
+	latencyMs := latency.Milliseconds()
+	if len(errMsg) > 500 {
+		errMsg = errMsg[:500]
+	}
+
+	stat := models.VirtualInventorySupplierStat{
+		VirtualInventoryID: inventoryID,
+		Endpoint:           supplierEndpoint(rawURL),
+		BucketStart:        now.Truncate(supplierHealthBucketSize),
+		Requests:           1,
+		Failures:           failures,
+		TotalLatencyMs:     latencyMs,
+		MaxLatencyMs:       latencyMs,
+		LastStatus:         status,
+		LastError:          errMsg,
+		UpdatedAt:          now,
+	}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "virtual_inventory_id"},
+			{Name: "endpoint"},
+			{Name: "bucket_start"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"requests":         gorm.Expr("requests + 1"),
+			"failures":         gorm.Expr("failures + ?", failures),
+			"total_latency_ms": gorm.Expr("total_latency_ms + ?", latencyMs),
+			"max_latency_ms":   gorm.Expr("CASE WHEN max_latency_ms < ? THEN ? ELSE max_latency_ms END", latencyMs, latencyMs),
+			"last_status":      status,
+			"last_error":       errMsg,
+			"updated_at":       now,
+		}),
+	}).Create(&stat).Error; err != nil {
+		log.Printf("[SupplierHealth] record failed: inventory=%d err=%v", inventoryID, err)
+		return
+	}
+
+	if s.healthConfig().AutoPause {
+		s.evaluate(inventoryID)
+	}
+	s.pruneIfDue(now)
+}
+
+// evaluate 统计窗口内错误率超过阈值时暂停脚本库存
+func (s *SupplierHealthService) evaluate(inventoryID uint) {
+	var totals struct {
+		Requests int64
+		Failures int64
+	}
+	since := models.NowFunc().Add(-s.Window())
+	if err := s.db.Model(&models.VirtualInventorySupplierStat{}).
+		Select("COALESCE(SUM(requests), 0) AS requests, COALESCE(SUM(failures), 0) AS failures").
+		Where("virtual_inventory_id = ? AND bucket_start >= ?", inventoryID, since.Truncate(supplierHealthBucketSize)).
+		Scan(&totals).Error; err != nil {
+		log.Printf("[SupplierHealth] evaluate failed: inventory=%d err=%v", inventoryID, err)
+		return
+	}
+	if totals.Requests < s.minRequests() {
+		return
+	}
+	rate := float64(totals.Failures) / float64(totals.Requests)
+	if rate < s.errorRateThreshold() {
+		return
+	}
+
+	reason := fmt.Sprintf("supplier error rate %.0f%% (%d/%d) in last %s exceeded %.0f%%",
+		rate*100, totals.Failures, totals.Requests, s.Window(), s.errorRateThreshold()*100)
+	now := models.NowFunc()
+	result := s.db.Model(&models.VirtualInventory{}).
+		Where("id = ? AND type = ? AND supplier_paused_at IS NULL", inventoryID, models.VirtualInventoryTypeScript).
+		Updates(map[string]interface{}{
+			"supplier_paused_at":    now,
+			"supplier_pause_reason": reason,
+		})
+	if result.Error != nil {
+		log.Printf("[SupplierHealth] pause failed: inventory=%d err=%v", inventoryID, result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("[SupplierHealth] inventory %d paused: %s", inventoryID, reason)
+		logger.LogSystemOperation(s.db, "supplier_auto_pause", "virtual_inventory", &inventoryID, map[string]interface{}{
+			"requests":   totals.Requests,
+			"failures":   totals.Failures,
+			"error_rate": rate,
+			"reason":     reason,
+		})
+	}
+}
+
+// Resume 解除自动暂停
+func (s *SupplierHealthService) Resume(inventoryID uint) (bool, error) {
+	result := s.db.Model(&models.VirtualInventory{}).
+		Where("id = ? AND supplier_paused_at IS NOT NULL", inventoryID).
+		Updates(map[string]interface{}{
+			"supplier_paused_at":    nil,
+			"supplier_pause_reason": "",
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Dashboard 汇总统计窗口内各脚本库存上游接口的健康状况，错误率高的排在前面
+func (s *SupplierHealthService) Dashboard(window time.Duration) ([]SupplierEndpointHealth, error) {
+	if window <= 0 {
+		window = s.Window()
+	}
+	since := models.NowFunc().Add(-window).Truncate(supplierHealthBucketSize)
+
+	var stats []models.VirtualInventorySupplierStat
+	if err := s.db.Where("bucket_start >= ?", since).
+		Order("bucket_start ASC").
+		Find(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	type endpointKey struct {
+		inventoryID uint
+		endpoint    string
+	}
+	byKey := make(map[endpointKey]*SupplierEndpointHealth)
+	inventoryIDs := make([]uint, 0)
+	totalLatency := make(map[endpointKey]int64)
+	for _, stat := range stats {
+		key := endpointKey{stat.VirtualInventoryID, stat.Endpoint}
+		item, ok := byKey[key]
+		if !ok {
+			item = &SupplierEndpointHealth{VirtualInventoryID: stat.VirtualInventoryID, Endpoint: stat.Endpoint}
+			byKey[key] = item
+			inventoryIDs = append(inventoryIDs, stat.VirtualInventoryID)
+		}
+		item.Requests += stat.Requests
+		item.Failures += stat.Failures
+		totalLatency[key] += stat.TotalLatencyMs
+		if stat.MaxLatencyMs > item.MaxLatencyMs {
+			item.MaxLatencyMs = stat.MaxLatencyMs
+		}
+		if !stat.UpdatedAt.Before(item.LastSeenAt) {
+			item.LastSeenAt = stat.UpdatedAt
+			item.LastStatus = stat.LastStatus
+			item.LastError = stat.LastError
+		}
+	}
+
+	inventories := make(map[uint]models.VirtualInventory)
+	if len(inventoryIDs) > 0 {
+		var rows []models.VirtualInventory
+		if err := s.db.Select("id, name, supplier_paused_at, supplier_pause_reason").
+			Where("id IN ?", inventoryIDs).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			inventories[row.ID] = row
+		}
+	}
+
+	result := make([]SupplierEndpointHealth, 0, len(byKey))
+	for key, item := range byKey {
+		if item.Requests > 0 {
+			item.SuccessRate = float64(item.Requests-item.Failures) / float64(item.Requests)
+			item.AvgLatencyMs = totalLatency[key] / item.Requests
+		}
+		if inv, ok := inventories[key.inventoryID]; ok {
+			item.InventoryName = inv.Name
+			item.SupplierPausedAt = inv.SupplierPausedAt
+			item.SupplierPauseReason = inv.SupplierPauseReason
+		}
+		result = append(result, *item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SuccessRate != result[j].SuccessRate {
+			return result[i].SuccessRate < result[j].SuccessRate
+		}
+		if result[i].VirtualInventoryID != result[j].VirtualInventoryID {
+			return result[i].VirtualInventoryID < result[j].VirtualInventoryID
+		}
+		return result[i].Endpoint < result[j].Endpoint
+	})
+	return result, nil
+}
+
+// pruneIfDue 定期清理过期统计桶
+func (s *SupplierHealthService) pruneIfDue(now time.Time) {
+	s.pruneMu.Lock()
+	if now.Sub(s.lastPrune) < supplierHealthPruneInterval {
+		s.pruneMu.Unlock()
+		return
+	}
+	s.lastPrune = now
+	s.pruneMu.Unlock()
+
+	if err := s.db.Where("bucket_start < ?", now.Add(-supplierHealthRetention)).
+		Delete(&models.VirtualInventorySupplierStat{}).Error; err != nil {
+		log.Printf("[SupplierHealth] prune failed: %v", err)
+	}
+}
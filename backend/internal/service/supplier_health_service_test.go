@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestSupplierHealthAutoPausesScriptInventory(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventorySupplierStat{}, &models.OperationLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	product := &models.Product{SKU: "SUPPLIER-1", Name: "Supplier product", ProductType: models.ProductTypeVirtual}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "upstream", Type: models.VirtualInventoryTypeScript, Script: "function onDeliver(){}", IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	if err := db.Create(&models.ProductVirtualInventoryBinding{ProductID: product.ID, VirtualInventoryID: inventory.ID, AttributesHash: "default"}).Error; err != nil {
+		t.Fatalf("create binding: %v", err)
+	}
+
+	health := NewSupplierHealthService(db, &config.Config{Order: config.OrderConfig{
+		SupplierHealth: config.SupplierHealthConfig{AutoPause: true, MinRequests: 4, ErrorRateThreshold: 0.5},
+	}})
+
+	health.Record(inventory.ID, "https://api.supplier.test/v1/codes?token=secret", 200, 120*time.Millisecond, "")
+	health.Record(inventory.ID, "https://api.supplier.test/v1/codes?token=secret", 502, 300*time.Millisecond, "")
+	health.Record(inventory.ID, "https://api.supplier.test/v1/codes", 0, 50*time.Millisecond, "Request failed: timeout")
+
+	var stored models.VirtualInventory
+	if err := db.First(&stored, inventory.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if stored.SupplierPausedAt != nil {
+		t.Fatalf("must not pause before reaching min requests")
+	}
+
+	// 4xx 由脚本参数导致，不计入上游故障
+	health.Record(inventory.ID, "https://api.supplier.test/v1/codes", 400, 80*time.Millisecond, "")
+	if err := db.First(&stored, inventory.ID).Error; err != nil {
+		t.Fatalf("reload inventory: %v", err)
+	}
+	if stored.SupplierPausedAt == nil {
+		t.Fatalf("expected inventory to be paused at 50%% error rate")
+	}
+
+	items, err := health.Dashboard(0)
+	if err != nil {
+		t.Fatalf("dashboard: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected one endpoint, got %+v", items)
+	}
+	item := items[0]
+	if item.Endpoint != "https://api.supplier.test" || item.Requests != 4 || item.Failures != 2 {
+		t.Fatalf("unexpected endpoint health: %+v", item)
+	}
+	if item.MaxLatencyMs != 300 || item.AvgLatencyMs != 137 || item.SupplierPausedAt == nil {
+		t.Fatalf("unexpected latency or pause info: %+v", item)
+	}
+
+	available, err := svc.GetAvailableCountForProduct(product.ID)
+	if err != nil {
+		t.Fatalf("available count: %v", err)
+	}
+	if available != 0 {
+		t.Fatalf("paused script inventory must be out of stock, got %d", available)
+	}
+	if unlimited, _ := svc.HasUnlimitedScriptInventoryForProduct(product.ID); unlimited {
+		t.Fatalf("paused script inventory must not report unlimited stock")
+	}
+
+	resumed, err := health.Resume(inventory.ID)
+	if err != nil || !resumed {
+		t.Fatalf("resume failed: %v %v", resumed, err)
+	}
+	available, _ = svc.GetAvailableCountForProduct(product.ID)
+	if available != 9999 {
+		t.Fatalf("expected unlimited stock after resume, got %d", available)
+	}
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"auralogic/internal/config"
+	"auralogic/internal/database"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	TemplateAssetMaxSize     = 2 * 1024 * 1024 // 资源会被内联进 PDF，限制单个文件大小
+	templateAssetUploadArea  = "templates"
+	templateAssetRefPrefix   = "asset:"
+	templateAssetVersionSize = 12
+)
+
+var templateAssetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// templateAssetKinds 允许上传的扩展名及其资源类型
+var templateAssetKinds = map[string]string{
+	".png":   models.TemplateAssetKindImage,
+	".jpg":   models.TemplateAssetKindImage,
+	".jpeg":  models.TemplateAssetKindImage,
+	".gif":   models.TemplateAssetKindImage,
+	".webp":  models.TemplateAssetKindImage,
+	".svg":   models.TemplateAssetKindImage,
+	".woff":  models.TemplateAssetKindFont,
+	".woff2": models.TemplateAssetKindFont,
+	".ttf":   models.TemplateAssetKindFont,
+	".otf":   models.TemplateAssetKindFont,
+	".css":   models.TemplateAssetKindCSS,
+}
+
+var (
+	errTemplateAssetNameInvalid = bizerr.Register("templateAsset.nameInvalid", 400, "Asset name may only contain lowercase letters, digits, - and _ (up to 64 characters)")
+	errTemplateAssetTypeInvalid = bizerr.Register("templateAsset.typeNotAllowed", 400, "This file type is not allowed for template assets")
+	errTemplateAssetTooLarge    = bizerr.Register("templateAsset.tooLarge", 400, "Template asset is too large")
+	errTemplateAssetEmpty       = bizerr.Register("templateAsset.empty", 400, "Template asset file is empty")
+	errTemplateAssetNotFound    = bizerr.Register("templateAsset.notFound", 404, "Template asset not found")
+	errTemplateAssetInUse       = bizerr.Register("templateAsset.inUse", 409, "Template asset is used as the company logo")
+)
+
+// TemplateAssetInfo 资源及其稳定访问地址
+type TemplateAssetInfo struct {
+	models.TemplateAsset
+	URL       string `json:"url"`       // 带版本参数的公开地址，内容变化后版本随之变化
+	Reference string `json:"reference"` // 可填入 company_logo 等配置项的引用，如 asset:company-logo
+}
+
+// TemplateAssetService 模板资源：上传后通过 /uploads/templates/<name> 稳定地址提供，
+// 模板中以 {{asset "name"}} 引用；渲染 PDF 时内联为 data URI，渲染器无需访问外网
+type TemplateAssetService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewTemplateAssetService(db *gorm.DB, cfg *config.Config) *TemplateAssetService {
+	return &TemplateAssetService{db: db, cfg: cfg}
+}
+
+// currentConfig 优先使用运行时配置，后台修改上传目录或站点地址后立即生效
+func (s *TemplateAssetService) currentConfig() *config.Config {
+	if runtimeCfg := config.GetConfig(); runtimeCfg != nil {
+		return runtimeCfg
+	}
+	return s.cfg
+}
+
+func (s *TemplateAssetService) uploadDir() string {
+	if cfg := s.currentConfig(); cfg != nil && strings.TrimSpace(cfg.Upload.Dir) != "" {
+		return strings.TrimSpace(cfg.Upload.Dir)
+	}
+	return "uploads"
+}
+
+func (s *TemplateAssetService) baseURL() string {
+	cfg := s.currentConfig()
+	if cfg == nil {
+		return ""
+	}
+	return strings.TrimRight(strings.TrimSpace(cfg.App.URL), "/")
+}
+
+// FilePath 资源文件的本地路径
+func (s *TemplateAssetService) FilePath(asset *models.TemplateAsset) string {
+	return filepath.Join(s.uploadDir(), templateAssetUploadArea, filepath.FromSlash(asset.StoredPath))
+}
+
+// Version 资源当前版本，用作缓存失效参数
+func (s *TemplateAssetService) Version(asset *models.TemplateAsset) string {
+	if len(asset.Checksum) < templateAssetVersionSize {
+		return asset.Checksum
+	}
+	return asset.Checksum[:templateAssetVersionSize]
+}
+
+// URL 资源的公开地址，路径固定，版本参数随内容变化
+func (s *TemplateAssetService) URL(asset *models.TemplateAsset) string {
+	return s.baseURL() + "/uploads/" + templateAssetUploadArea + "/" + asset.Name + "?v=" + s.Version(asset)
+}
+
+func (s *TemplateAssetService) info(asset models.TemplateAsset) TemplateAssetInfo {
+	return TemplateAssetInfo{
+		TemplateAsset: asset,
+		URL:           s.URL(&asset),
+		Reference:     templateAssetRefPrefix + asset.Name,
+	}
+}
+
+// List 全部资源，按名称排序
+func (s *TemplateAssetService) List() ([]TemplateAssetInfo, error) {
+	var assets []models.TemplateAsset
+	if err := s.db.Order("name ASC").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+	items := make([]TemplateAssetInfo, 0, len(assets))
+	for _, asset := range assets {
+		items = append(items, s.info(asset))
+	}
+	return items, nil
+}
+
+// Get 按名称获取资源
+func (s *TemplateAssetService) Get(name string) (*models.TemplateAsset, error) {
+	var asset models.TemplateAsset
+	if err := s.db.Where("name = ?", strings.TrimSpace(name)).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errTemplateAssetNotFound.New()
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// Save 上传或替换同名资源；文件按内容哈希命名，替换后删除旧文件
+func (s *TemplateAssetService) Save(name, fileName string, content []byte, uploadedBy *uint) (*TemplateAssetInfo, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !templateAssetNamePattern.MatchString(name) {
+		return nil, errTemplateAssetNameInvalid.New()
+	}
+	ext := strings.ToLower(filepath.Ext(fileName))
+	kind, ok := templateAssetKinds[ext]
+	if !ok {
+		return nil, errTemplateAssetTypeInvalid.New().WithParams(map[string]interface{}{"ext": ext})
+	}
+	if len(content) == 0 {
+		return nil, errTemplateAssetEmpty.New()
+	}
+	if len(content) > TemplateAssetMaxSize {
+		return nil, errTemplateAssetTooLarge.Newf("Template asset cannot exceed %dMB", TemplateAssetMaxSize/1024/1024).
+			WithParams(map[string]interface{}{"max_mb": TemplateAssetMaxSize / 1024 / 1024})
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	asset := models.TemplateAsset{
+		Name:        name,
+		Kind:        kind,
+		FileName:    filepath.Base(fileName),
+		StoredPath:  name + "-" + checksum[:templateAssetVersionSize] + ext,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Checksum:    checksum,
+		UploadedBy:  uploadedBy,
+	}
+
+	targetDir := filepath.Join(s.uploadDir(), templateAssetUploadArea)
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(s.FilePath(&asset), content, 0644); err != nil {
+		return nil, err
+	}
+
+	var previous models.TemplateAsset
+	hadPrevious := s.db.Where("name = ?", name).First(&previous).Error == nil
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "file_name", "stored_path", "content_type", "size", "checksum", "uploaded_by", "updated_at"}),
+	}).Create(&asset).Error; err != nil {
+		return nil, err
+	}
+	if hadPrevious && previous.StoredPath != asset.StoredPath {
+		if err := os.Remove(s.FilePath(&previous)); err != nil && !os.IsNotExist(err) {
+			log.Printf("Warning: failed to remove replaced template asset %s: %v", previous.StoredPath, err)
+		}
+	}
+
+	saved, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	info := s.info(*saved)
+	return &info, nil
+}
+
+// Delete 删除资源；仍作为公司 Logo 使用时拒绝删除
+func (s *TemplateAssetService) Delete(name string) (*models.TemplateAsset, error) {
+	asset, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if cfg := s.currentConfig(); cfg != nil && strings.TrimSpace(cfg.Order.Invoice.CompanyLogo) == templateAssetRefPrefix+asset.Name {
+		return nil, errTemplateAssetInUse.New()
+	}
+	if err := s.db.Delete(asset).Error; err != nil {
+		return nil, err
+	}
+	if err := os.Remove(s.FilePath(asset)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Warning: failed to remove template asset %s: %v", asset.StoredPath, err)
+	}
+	return asset, nil
+}
+
+// DataURI 读取资源并编码为 data URI
+func (s *TemplateAssetService) DataURI(asset *models.TemplateAsset) (string, error) {
+	content, err := os.ReadFile(s.FilePath(asset))
+	if err != nil {
+		return "", err
+	}
+	return "data:" + asset.ContentType + ";base64," + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// ResolveURL 解析模板中的资源引用：inline 为 true 时返回 data URI（用于 PDF 渲染），否则返回带版本的公开地址。
+// 资源不存在时返回空字符串，模板中的 {{if}} 判断可据此隐藏
+func (s *TemplateAssetService) ResolveURL(name string, inline bool) template.URL {
+	if s == nil || s.db == nil {
+		return ""
+	}
+	asset, err := s.Get(name)
+	if err != nil {
+		return ""
+	}
+	if !inline {
+		return template.URL(s.URL(asset))
+	}
+	dataURI, err := s.DataURI(asset)
+	if err != nil {
+		log.Printf("Warning: failed to inline template asset %s: %v", asset.Name, err)
+		return template.URL(s.URL(asset))
+	}
+	return template.URL(dataURI)
+}
+
+// ResolveRef 解析配置项中的图片地址：asset:<name> 按资源解析，http(s) 与站内绝对路径原样返回，其他值视为无效
+func (s *TemplateAssetService) ResolveRef(ref string, inline bool) template.URL {
+	ref = strings.TrimSpace(ref)
+	if name, ok := strings.CutPrefix(ref, templateAssetRefPrefix); ok {
+		return s.ResolveURL(name, inline)
+	}
+	lower := strings.ToLower(ref)
+	if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") ||
+		(strings.HasPrefix(ref, "/") && !strings.HasPrefix(ref, "//")) {
+		return template.URL(ref)
+	}
+	return ""
+}
+
+// defaultTemplateAssets 使用全局数据库与运行时配置，供未注入依赖的模板渲染处使用
+func defaultTemplateAssets() *TemplateAssetService {
+	return NewTemplateAssetService(database.GetDB(), config.GetConfig())
+}
+
+// TemplateAssetFuncs 账单、报价单、装箱单与邮件模板共用的资源函数 {{asset "name"}}，执行时读取当前数据库与配置
+func TemplateAssetFuncs(inline bool) template.FuncMap {
+	return template.FuncMap{
+		"asset": func(name string) template.URL {
+			return defaultTemplateAssets().ResolveURL(name, inline)
+		},
+	}
+}
+
+// ResolveTemplateAssetRef 按当前配置解析 company_logo 等配置项中的资源引用
+func ResolveTemplateAssetRef(ref string, inline bool) template.URL {
+	return defaultTemplateAssets().ResolveRef(ref, inline)
+}
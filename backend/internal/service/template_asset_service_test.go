@@ -0,0 +1,85 @@
+package service
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+)
+
+func TestTemplateAssetSaveReplaceResolveAndDelete(t *testing.T) {
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.TemplateAsset{}); err != nil {
+		t.Fatalf("auto migrate template assets failed: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.Upload.Dir = t.TempDir()
+	cfg.App.URL = "https://shop.example.com/"
+	svc := NewTemplateAssetService(db, cfg)
+
+	_, err := svc.Save("Bad Name!", "logo.png", []byte("x"), nil)
+	requireBizErr(t, err, "templateAsset.nameInvalid")
+	_, err = svc.Save("logo", "logo.exe", []byte("x"), nil)
+	requireBizErr(t, err, "templateAsset.typeNotAllowed")
+	_, err = svc.Save("logo", "logo.png", nil, nil)
+	requireBizErr(t, err, "templateAsset.empty")
+	_, err = svc.Save("logo", "logo.png", make([]byte, TemplateAssetMaxSize+1), nil)
+	requireBizErr(t, err, "templateAsset.tooLarge")
+
+	first, err := svc.Save("Company-Logo", "logo.png", []byte("first"), nil)
+	if err != nil {
+		t.Fatalf("save asset failed: %v", err)
+	}
+	if first.Name != "company-logo" || first.Kind != models.TemplateAssetKindImage || first.Reference != "asset:company-logo" {
+		t.Fatalf("unexpected saved asset: %+v", first)
+	}
+	if !strings.HasPrefix(first.URL, "https://shop.example.com/uploads/templates/company-logo?v=") {
+		t.Fatalf("unexpected asset url %q", first.URL)
+	}
+	firstPath := svc.FilePath(&first.TemplateAsset)
+	if _, err := os.Stat(firstPath); err != nil {
+		t.Fatalf("asset file missing: %v", err)
+	}
+
+	second, err := svc.Save("company-logo", "logo-v2.png", []byte("second"), nil)
+	if err != nil {
+		t.Fatalf("replace asset failed: %v", err)
+	}
+	if second.ID != first.ID || second.URL == first.URL {
+		t.Fatalf("replace should keep the record and change the version: first=%+v second=%+v", first, second)
+	}
+	if _, err := os.Stat(firstPath); !os.IsNotExist(err) {
+		t.Fatalf("replaced asset file should be removed, stat err=%v", err)
+	}
+
+	if got := string(svc.ResolveRef("asset:company-logo", false)); got != second.URL {
+		t.Fatalf("expected versioned url, got %q", got)
+	}
+	if got := string(svc.ResolveRef("asset:company-logo", true)); got != "data:image/png;base64,c2Vjb25k" {
+		t.Fatalf("expected inline data uri, got %q", got)
+	}
+	if got := svc.ResolveRef("asset:missing", true); got != "" {
+		t.Fatalf("missing asset should resolve empty, got %q", got)
+	}
+	if got := svc.ResolveRef("https://cdn.example.com/logo.png", true); got != "https://cdn.example.com/logo.png" {
+		t.Fatalf("external url should pass through, got %q", got)
+	}
+	if got := svc.ResolveRef("javascript:alert(1)", false); got != "" {
+		t.Fatalf("unsafe reference should be dropped, got %q", got)
+	}
+
+	cfg.Order.Invoice.CompanyLogo = "asset:company-logo"
+	_, err = svc.Delete("company-logo")
+	requireBizErr(t, err, "templateAsset.inUse")
+	cfg.Order.Invoice.CompanyLogo = ""
+	if _, err := svc.Delete("company-logo"); err != nil {
+		t.Fatalf("delete asset failed: %v", err)
+	}
+	if _, err := os.Stat(svc.FilePath(&second.TemplateAsset)); !os.IsNotExist(err) {
+		t.Fatalf("deleted asset file should be removed, stat err=%v", err)
+	}
+	_, err = svc.Get("company-logo")
+	requireBizErr(t, err, "templateAsset.notFound")
+}
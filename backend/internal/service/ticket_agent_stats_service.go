@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	ticketAgentStatDateLayout = "2006-01-02"
+	// 统计表为空时回填的天数
+	ticketAgentStatBackfillDays = 30
+)
+
+// TicketAgentStatsService 客服绩效每日聚合服务
+// 基于工单首次响应、解决时间与满意度评价数据，按客服和自然日汇总到 ticket_agent_daily_stats
+type TicketAgentStatsService struct {
+	db            *gorm.DB
+	checkInterval time.Duration
+}
+
+// NewTicketAgentStatsService 创建客服绩效聚合服务
+func NewTicketAgentStatsService(db *gorm.DB) *TicketAgentStatsService {
+	return &TicketAgentStatsService{
+		db:            db,
+		checkInterval: time.Hour, // 每小时刷新当天与前一天的汇总
+	}
+}
+
+// RegisterJobs 注册客服绩效每日汇总任务
+func (s *TicketAgentStatsService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "ticket_agent_stats",
+		Description: "Refresh daily support agent performance aggregates for today and yesterday",
+		Interval:    s.checkInterval,
+		Run:         s.aggregateRecent,
+	})
+}
+
+// aggregateRecent 刷新当天与前一天的汇总，统计表为空时回填最近 30 天
+func (s *TicketAgentStatsService) aggregateRecent(ctx context.Context) error {
+	now := time.Now().UTC()
+	days := 2
+	var existing int64
+	if err := s.db.Model(&models.TicketAgentDailyStat{}).Count(&existing).Error; err == nil && existing == 0 {
+		days = ticketAgentStatBackfillDays
+	}
+	var errs []error
+	for i := days - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		day := now.AddDate(0, 0, -i)
+		if err := s.AggregateDay(day); err != nil {
+			errs = append(errs, fmt.Errorf("aggregate %s: %w", day.Format(ticketAgentStatDateLayout), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AggregateDay 重新计算指定自然日（UTC，与数据库时间戳一致）的客服绩效并覆盖写入
+func (s *TicketAgentStatsService) AggregateDay(day time.Time) error {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	statDate := start.Format(ticketAgentStatDateLayout)
+
+	stats := make(map[uint]*models.TicketAgentDailyStat)
+	statFor := func(agentID uint) *models.TicketAgentDailyStat {
+		stat, ok := stats[agentID]
+		if !ok {
+			stat = &models.TicketAgentDailyStat{StatDate: statDate, AgentID: agentID}
+			stats[agentID] = stat
+		}
+		return stat
+	}
+
+	// 当日回复过的工单数，SenderID 为 0 的是系统消息
+	var handledRows []struct {
+		SenderID uint
+		Tickets  int64
+	}
+	if err := s.db.Model(&models.TicketMessage{}).
+		Select("sender_id, COUNT(DISTINCT ticket_id) AS tickets").
+		Where("sender_type = ? AND sender_id > 0 AND created_at >= ? AND created_at < ?", "admin", start, end).
+		Group("sender_id").
+		Scan(&handledRows).Error; err != nil {
+		return err
+	}
+	for _, row := range handledRows {
+		statFor(row.SenderID).TicketsHandled = row.Tickets
+	}
+
+	// 首次响应时长
+	var firstResponses []models.Ticket
+	if err := s.db.Select("id", "created_at", "first_response_at", "first_response_by").
+		Where("first_response_by IS NOT NULL AND first_response_at >= ? AND first_response_at < ?", start, end).
+		Find(&firstResponses).Error; err != nil {
+		return err
+	}
+	for _, ticket := range firstResponses {
+		stat := statFor(*ticket.FirstResponseBy)
+		stat.FirstResponseCount++
+		stat.FirstResponseSeconds += ticketDurationSeconds(ticket.CreatedAt, *ticket.FirstResponseAt)
+	}
+
+	// 解决时长，归属于解决时的分配客服
+	var resolved []models.Ticket
+	if err := s.db.Select("id", "created_at", "closed_at", "assigned_to").
+		Where("assigned_to IS NOT NULL AND status IN ? AND closed_at >= ? AND closed_at < ?",
+			[]models.TicketStatus{models.TicketStatusResolved, models.TicketStatusClosed}, start, end).
+		Find(&resolved).Error; err != nil {
+		return err
+	}
+	for _, ticket := range resolved {
+		stat := statFor(*ticket.AssignedTo)
+		stat.ResolvedCount++
+		stat.ResolutionSeconds += ticketDurationSeconds(ticket.CreatedAt, *ticket.ClosedAt)
+	}
+
+	// 满意度评价
+	var ratingRows []struct {
+		AssignedTo uint
+		Count      int64
+		Total      int64
+	}
+	if err := s.db.Model(&models.Ticket{}).
+		Select("assigned_to, COUNT(*) AS count, COALESCE(SUM(satisfaction_rating), 0) AS total").
+		Where("assigned_to IS NOT NULL AND satisfaction_rating > 0 AND rated_at >= ? AND rated_at < ?", start, end).
+		Group("assigned_to").
+		Scan(&ratingRows).Error; err != nil {
+		return err
+	}
+	for _, row := range ratingRows {
+		stat := statFor(row.AssignedTo)
+		stat.RatingCount = row.Count
+		stat.RatingTotal = row.Total
+	}
+
+	agentIDs := make([]uint, 0, len(stats))
+	for agentID := range stats {
+		agentIDs = append(agentIDs, agentID)
+	}
+	sort.Slice(agentIDs, func(i, j int) bool { return agentIDs[i] < agentIDs[j] })
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stat_date = ?", statDate).Delete(&models.TicketAgentDailyStat{}).Error; err != nil {
+			return err
+		}
+		for _, agentID := range agentIDs {
+			if err := tx.Create(stats[agentID]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func ticketDurationSeconds(from, to time.Time) int64 {
+	seconds := int64(to.Sub(from) / time.Second)
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// TicketAgentPerformance 客服绩效报表行
+type TicketAgentPerformance struct {
+	StatDate                string  `json:"stat_date,omitempty"`
+	AgentID                 uint    `json:"agent_id"`
+	AgentName               string  `json:"agent_name"`
+	AgentEmail              string  `json:"agent_email"`
+	TicketsHandled          int64   `json:"tickets_handled"`
+	FirstResponseCount      int64   `json:"first_response_count"`
+	AvgFirstResponseSeconds int64   `json:"avg_first_response_seconds"`
+	ResolvedCount           int64   `json:"resolved_count"`
+	AvgResolutionSeconds    int64   `json:"avg_resolution_seconds"`
+	RatingCount             int64   `json:"rating_count"`
+	AvgRating               float64 `json:"avg_rating"`
+}
+
+// TicketAgentPerformanceQuery 报表查询条件，日期均为 YYYY-MM-DD（含首尾）
+type TicketAgentPerformanceQuery struct {
+	StartDate string
+	EndDate   string
+	AgentID   uint
+	// GroupByDay 为 true 时按“客服 + 日期”输出，否则按客服汇总
+	GroupByDay bool
+}
+
+// BuildTicketAgentPerformance 从每日汇总表生成客服绩效报表
+func BuildTicketAgentPerformance(db *gorm.DB, query TicketAgentPerformanceQuery) ([]TicketAgentPerformance, error) {
+	tx := db.Model(&models.TicketAgentDailyStat{}).
+		Where("stat_date >= ? AND stat_date <= ?", query.StartDate, query.EndDate)
+	if query.AgentID > 0 {
+		tx = tx.Where("agent_id = ?", query.AgentID)
+	}
+	var rows []models.TicketAgentDailyStat
+	if err := tx.Order("stat_date ASC, agent_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string]*models.TicketAgentDailyStat)
+	keys := make([]string, 0)
+	agentIDSet := make(map[uint]struct{})
+	for _, row := range rows {
+		key := strconv.FormatUint(uint64(row.AgentID), 10)
+		if query.GroupByDay {
+			key = row.StatDate + "|" + key
+		}
+		stat, ok := buckets[key]
+		if !ok {
+			stat = &models.TicketAgentDailyStat{AgentID: row.AgentID}
+			if query.GroupByDay {
+				stat.StatDate = row.StatDate
+			}
+			buckets[key] = stat
+			keys = append(keys, key)
+		}
+		stat.TicketsHandled += row.TicketsHandled
+		stat.FirstResponseCount += row.FirstResponseCount
+		stat.FirstResponseSeconds += row.FirstResponseSeconds
+		stat.ResolvedCount += row.ResolvedCount
+		stat.ResolutionSeconds += row.ResolutionSeconds
+		stat.RatingCount += row.RatingCount
+		stat.RatingTotal += row.RatingTotal
+		agentIDSet[row.AgentID] = struct{}{}
+	}
+
+	agents := make(map[uint]models.User, len(agentIDSet))
+	if len(agentIDSet) > 0 {
+		agentIDs := make([]uint, 0, len(agentIDSet))
+		for agentID := range agentIDSet {
+			agentIDs = append(agentIDs, agentID)
+		}
+		var users []models.User
+		if err := db.Select("id", "name", "email").Where("id IN ?", agentIDs).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			agents[user.ID] = user
+		}
+	}
+
+	result := make([]TicketAgentPerformance, 0, len(keys))
+	for _, key := range keys {
+		stat := buckets[key]
+		item := TicketAgentPerformance{
+			StatDate:           stat.StatDate,
+			AgentID:            stat.AgentID,
+			AgentName:          agents[stat.AgentID].Name,
+			AgentEmail:         agents[stat.AgentID].Email,
+			TicketsHandled:     stat.TicketsHandled,
+			FirstResponseCount: stat.FirstResponseCount,
+			ResolvedCount:      stat.ResolvedCount,
+			RatingCount:        stat.RatingCount,
+		}
+		if stat.FirstResponseCount > 0 {
+			item.AvgFirstResponseSeconds = stat.FirstResponseSeconds / stat.FirstResponseCount
+		}
+		if stat.ResolvedCount > 0 {
+			item.AvgResolutionSeconds = stat.ResolutionSeconds / stat.ResolvedCount
+		}
+		if stat.RatingCount > 0 {
+			item.AvgRating = float64(stat.RatingTotal*100/stat.RatingCount) / 100
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openTicketAgentStatsTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:ticket-agent-stats-" + time.Now().UTC().Format("20060102150405.000000000") + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.OperationLog{},
+		&models.User{},
+		&models.Ticket{},
+		&models.TicketMessage{},
+		&models.TicketAgentDailyStat{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return db
+}
+
+func TestTicketAgentStatsAggregateDayAndReport(t *testing.T) {
+	db := openTicketAgentStatsTestDB(t)
+	svc := NewTicketAgentStatsService(db)
+
+	agent := &models.User{Email: "agent@example.com", Name: "Agent", Role: "admin"}
+	if err := db.Create(agent).Error; err != nil {
+		t.Fatalf("create agent failed: %v", err)
+	}
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	created := day.Add(8 * time.Hour)
+	firstResponse := created.Add(30 * time.Minute)
+	closedAt := created.Add(4 * time.Hour)
+	ratedAt := created.Add(5 * time.Hour)
+
+	resolved := &models.Ticket{
+		TicketNo:           "TK-STAT-1",
+		UserID:             99,
+		Subject:            "resolved",
+		Content:            "content",
+		Status:             models.TicketStatusClosed,
+		AssignedTo:         &agent.ID,
+		FirstResponseAt:    &firstResponse,
+		FirstResponseBy:    &agent.ID,
+		ClosedAt:           &closedAt,
+		SatisfactionRating: 4,
+		RatedAt:            &ratedAt,
+		CreatedAt:          created,
+	}
+	pendingFirstResponse := firstResponse.Add(90 * time.Minute)
+	pending := &models.Ticket{
+		TicketNo:        "TK-STAT-2",
+		UserID:          99,
+		Subject:         "pending",
+		Content:         "content",
+		Status:          models.TicketStatusProcessing,
+		AssignedTo:      &agent.ID,
+		FirstResponseAt: &pendingFirstResponse,
+		FirstResponseBy: &agent.ID,
+		CreatedAt:       created,
+	}
+	for _, ticket := range []*models.Ticket{resolved, pending} {
+		if err := db.Create(ticket).Error; err != nil {
+			t.Fatalf("create ticket failed: %v", err)
+		}
+	}
+
+	messages := []models.TicketMessage{
+		{TicketID: resolved.ID, SenderType: "admin", SenderID: agent.ID, Content: "a", CreatedAt: firstResponse},
+		{TicketID: resolved.ID, SenderType: "admin", SenderID: agent.ID, Content: "b", CreatedAt: firstResponse.Add(time.Hour)},
+		{TicketID: pending.ID, SenderType: "admin", SenderID: agent.ID, Content: "c", CreatedAt: pendingFirstResponse},
+		{TicketID: pending.ID, SenderType: "admin", SenderID: 0, Content: "system", CreatedAt: pendingFirstResponse},
+	}
+	if err := db.Create(&messages).Error; err != nil {
+		t.Fatalf("create messages failed: %v", err)
+	}
+
+	if err := svc.AggregateDay(day); err != nil {
+		t.Fatalf("aggregate failed: %v", err)
+	}
+	// 重复聚合应覆盖而不是累加
+	if err := svc.AggregateDay(day); err != nil {
+		t.Fatalf("re-aggregate failed: %v", err)
+	}
+
+	var stats []models.TicketAgentDailyStat
+	if err := db.Find(&stats).Error; err != nil {
+		t.Fatalf("query stats failed: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected one stat row (system messages excluded), got %d", len(stats))
+	}
+	stat := stats[0]
+	if stat.StatDate != "2026-03-10" || stat.AgentID != agent.ID {
+		t.Fatalf("unexpected stat key: %+v", stat)
+	}
+	if stat.TicketsHandled != 2 {
+		t.Fatalf("expected 2 tickets handled, got %d", stat.TicketsHandled)
+	}
+	if stat.FirstResponseCount != 2 || stat.FirstResponseSeconds != int64((30*time.Minute+120*time.Minute)/time.Second) {
+		t.Fatalf("unexpected first response stats: %+v", stat)
+	}
+	if stat.ResolvedCount != 1 || stat.ResolutionSeconds != int64((4*time.Hour)/time.Second) {
+		t.Fatalf("unexpected resolution stats: %+v", stat)
+	}
+	if stat.RatingCount != 1 || stat.RatingTotal != 4 {
+		t.Fatalf("unexpected rating stats: %+v", stat)
+	}
+
+	items, err := BuildTicketAgentPerformance(db, TicketAgentPerformanceQuery{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-31",
+	})
+	if err != nil {
+		t.Fatalf("build report failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected one report row, got %d", len(items))
+	}
+	item := items[0]
+	if item.AgentName != "Agent" || item.StatDate != "" {
+		t.Fatalf("unexpected report identity: %+v", item)
+	}
+	if item.AvgFirstResponseSeconds != int64((75*time.Minute)/time.Second) {
+		t.Fatalf("unexpected avg first response: %d", item.AvgFirstResponseSeconds)
+	}
+	if item.AvgResolutionSeconds != int64((4*time.Hour)/time.Second) || item.AvgRating != 4 {
+		t.Fatalf("unexpected averages: %+v", item)
+	}
+}
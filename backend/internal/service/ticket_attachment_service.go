@@ -0,0 +1,474 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/pkg/objectstore"
+	"auralogic/internal/pkg/ticketbiz"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// ticketAttachmentsPerMessage 每条消息最多附带的附件数
+	ticketAttachmentsPerMessage = 10
+	// ticketAttachmentOrphanTTL 上传后超过该时间仍未随消息发送的附件视为遗弃
+	ticketAttachmentOrphanTTL = 24 * time.Hour
+	ticketAttachmentBatchSize = 200
+)
+
+var (
+	defaultTicketImageTypes = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+	defaultTicketFileTypes  = []string{".pdf", ".txt", ".csv", ".zip", ".doc", ".docx", ".xls", ".xlsx"}
+)
+
+// TicketAttachmentService 工单消息附件：校验并保存到对象存储，发送消息时关联到消息，
+// 通过有时效的签名链接下载，工单彻底删除时清理存储中的文件
+type TicketAttachmentService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewTicketAttachmentService(db *gorm.DB, cfg *config.Config) *TicketAttachmentService {
+	return &TicketAttachmentService{db: db, cfg: cfg}
+}
+
+// store 返回指定存储后端，driver 为空时使用当前配置的后端
+func (s *TicketAttachmentService) store(driver string) (objectstore.Store, error) {
+	storage := s.cfg.Storage
+	if driver == "" {
+		driver = storage.Driver
+	}
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case objectstore.DriverS3:
+		return objectstore.NewS3Store(objectstore.S3Config{
+			Endpoint:        storage.S3.Endpoint,
+			Region:          storage.S3.Region,
+			Bucket:          storage.S3.Bucket,
+			AccessKeyID:     storage.S3.AccessKeyID,
+			SecretAccessKey: storage.S3.SecretAccessKey,
+			PathStyle:       storage.S3.PathStyle,
+			Prefix:          storage.S3.Prefix,
+		})
+	case objectstore.DriverLocal, "":
+		dir := storage.LocalDir
+		if dir == "" {
+			dir = filepath.Join(s.cfg.Upload.Dir, "private")
+		}
+		return objectstore.NewLocalStore(dir), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", driver)
+	}
+}
+
+func (s *TicketAttachmentService) signedURLTTL() time.Duration {
+	if s.cfg.Storage.SignedURLTTLSeconds > 0 {
+		return time.Duration(s.cfg.Storage.SignedURLTTLSeconds) * time.Second
+	}
+	return 15 * time.Minute
+}
+
+// validate 按扩展名区分图片和文档，分别校验开关、大小和类型，返回探测到的内容类型
+func (s *TicketAttachmentService) validate(file *multipart.FileHeader, head []byte) (string, error) {
+	attachment := s.cfg.Ticket.Attachment
+	if attachment == nil {
+		attachment = &config.TicketAttachmentConfig{}
+	}
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext == "" {
+		return "", ticketbiz.FileTypeUnsupported()
+	}
+	imageTypes := attachment.AllowedImageTypes
+	if len(imageTypes) == 0 {
+		imageTypes = defaultTicketImageTypes
+	}
+	fileTypes := attachment.AllowedFileTypes
+	if len(fileTypes) == 0 {
+		fileTypes = defaultTicketFileTypes
+	}
+	detected := http.DetectContentType(head)
+
+	switch {
+	case containsExtension(imageTypes, ext):
+		if !attachment.EnableImage {
+			return "", ticketbiz.ImageUploadDisabled()
+		}
+		maxSize := attachment.MaxImageSize
+		if maxSize <= 0 {
+			maxSize = 5 * 1024 * 1024
+		}
+		if file.Size > maxSize {
+			return "", ticketbiz.ImageFileTooLarge(ticketbiz.BytesToMegabytes(maxSize))
+		}
+		// 内容必须确实是图片，防止改扩展名上传其他文件后被浏览器内联打开
+		if !strings.HasPrefix(detected, "image/") {
+			return "", ticketbiz.ImageFormatUnsupported()
+		}
+		return detected, nil
+	case containsExtension(fileTypes, ext):
+		if !attachment.EnableFile {
+			return "", ticketbiz.FileUploadDisabled()
+		}
+		maxSize := attachment.MaxFileSize
+		if maxSize <= 0 {
+			maxSize = 10 * 1024 * 1024
+		}
+		if file.Size > maxSize {
+			return "", ticketbiz.FileTooLarge(ticketbiz.BytesToMegabytes(maxSize))
+		}
+		// 文档一律以附件形式下载，内容类型仅用于展示
+		if strings.HasPrefix(detected, "text/html") {
+			return "", ticketbiz.FileTypeUnsupported()
+		}
+		return detected, nil
+	default:
+		return "", ticketbiz.FileTypeUnsupported()
+	}
+}
+
+func containsExtension(allowed []string, ext string) bool {
+	for _, item := range allowed {
+		if strings.ToLower(strings.TrimSpace(item)) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// Upload 校验并保存附件，返回尚未关联消息的附件记录
+func (s *TicketAttachmentService) Upload(ctx context.Context, ticket *models.Ticket, uploaderType string, uploaderID uint, file *multipart.FileHeader) (*models.TicketAttachment, error) {
+	if ticket.Status == models.TicketStatusClosed {
+		return nil, ticketbiz.ClosedCannotUpload()
+	}
+	if file == nil {
+		return nil, ticketbiz.FileRequired()
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	contentType, err := s.validate(file, head[:n])
+	if err != nil {
+		return nil, err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	store, err := s.store("")
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	key := fmt.Sprintf("tickets/%d/%s%s", ticket.ID, uuid.New().String(), ext)
+	if err := store.Put(ctx, key, src, file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("store ticket attachment: %w", err)
+	}
+
+	attachment := &models.TicketAttachment{
+		TicketID:     ticket.ID,
+		UploaderType: uploaderType,
+		UploaderID:   uploaderID,
+		Backend:      store.Driver(),
+		StorageKey:   key,
+		Filename:     sanitizeAttachmentFilename(file.Filename),
+		ContentType:  contentType,
+		Size:         file.Size,
+	}
+	if err := s.db.Create(attachment).Error; err != nil {
+		if delErr := store.Delete(ctx, key); delErr != nil {
+			log.Printf("ticket attachment: failed to remove object after insert error: key=%s err=%v", key, delErr)
+		}
+		return nil, err
+	}
+	return s.withDownloadURL(attachment), nil
+}
+
+// sanitizeAttachmentFilename 去掉路径和控制字符，仅保留用于展示和下载的文件名
+func sanitizeAttachmentFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 200 {
+		ext := filepath.Ext(name)
+		name = string(runes[:200-len([]rune(ext))]) + ext
+	}
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	return name
+}
+
+// ResolvePending 校验待发送的附件属于该工单且由同一上传者上传、尚未关联消息
+func (s *TicketAttachmentService) ResolvePending(tx *gorm.DB, ticketID uint, uploaderType string, uploaderID uint, ids []uint) ([]models.TicketAttachment, error) {
+	ids = uniqueUintIDs(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > ticketAttachmentsPerMessage {
+		return nil, ticketbiz.TooManyAttachments(ticketAttachmentsPerMessage)
+	}
+	var attachments []models.TicketAttachment
+	if err := tx.Where("id IN ? AND ticket_id = ? AND uploader_type = ? AND uploader_id = ? AND message_id IS NULL",
+		ids, ticketID, uploaderType, uploaderID).
+		Order("id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	if len(attachments) != len(ids) {
+		return nil, ticketbiz.AttachmentInvalid()
+	}
+	return attachments, nil
+}
+
+// LinkToMessage 将待发送附件关联到消息，需在创建消息的事务中调用
+func (s *TicketAttachmentService) LinkToMessage(tx *gorm.DB, message *models.TicketMessage, attachments []models.TicketAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.ID)
+	}
+	// 条件更新防止同一附件被并发发送到两条消息
+	result := tx.Model(&models.TicketAttachment{}).
+		Where("id IN ? AND message_id IS NULL", ids).
+		Update("message_id", message.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(ids)) {
+		return ticketbiz.AttachmentInvalid()
+	}
+	message.Attachments = make([]models.TicketAttachment, len(attachments))
+	for i := range attachments {
+		message.Attachments[i] = attachments[i]
+		message.Attachments[i].MessageID = &message.ID
+	}
+	s.FillDownloadURLs(message.Attachments)
+	return nil
+}
+
+func uniqueUintIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
+// LoadForMessages 加载消息附件并生成下载链接
+func (s *TicketAttachmentService) LoadForMessages(messages []models.TicketMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	var attachments []models.TicketAttachment
+	if err := s.db.Where("message_id IN ?", ids).Order("id ASC").Find(&attachments).Error; err != nil {
+		return err
+	}
+	s.FillDownloadURLs(attachments)
+	byMessage := make(map[uint][]models.TicketAttachment, len(messages))
+	for _, attachment := range attachments {
+		byMessage[*attachment.MessageID] = append(byMessage[*attachment.MessageID], attachment)
+	}
+	for i := range messages {
+		messages[i].Attachments = byMessage[messages[i].ID]
+	}
+	return nil
+}
+
+// FillDownloadURLs 生成下载链接：支持预签名的存储直接下载，本地存储经签名接口下载
+func (s *TicketAttachmentService) FillDownloadURLs(attachments []models.TicketAttachment) {
+	ttl := s.signedURLTTL()
+	expiresAt := time.Now().Add(ttl)
+	stores := make(map[string]objectstore.Store)
+	for i := range attachments {
+		attachment := &attachments[i]
+		store, ok := stores[attachment.Backend]
+		if !ok {
+			var err error
+			if store, err = s.store(attachment.Backend); err != nil {
+				log.Printf("ticket attachment: storage %q unavailable: %v", attachment.Backend, err)
+			}
+			stores[attachment.Backend] = store
+		}
+		if store == nil {
+			continue
+		}
+		if presigner, ok := store.(objectstore.Presigner); ok {
+			signed, err := presigner.PresignGet(attachment.StorageKey, ttl, attachment.Filename)
+			if err != nil {
+				log.Printf("ticket attachment: presign failed: id=%d err=%v", attachment.ID, err)
+				continue
+			}
+			attachment.DownloadURL = signed
+		} else {
+			expires := expiresAt.Unix()
+			query := url.Values{}
+			query.Set("expires", strconv.FormatInt(expires, 10))
+			query.Set("sig", s.sign(attachment.ID, expires))
+			attachment.DownloadURL = fmt.Sprintf("%s/api/tickets/attachments/%d/download?%s",
+				strings.TrimRight(s.cfg.App.URL, "/"), attachment.ID, query.Encode())
+		}
+		attachment.URLExpiresAt = &expiresAt
+	}
+}
+
+func (s *TicketAttachmentService) withDownloadURL(attachment *models.TicketAttachment) *models.TicketAttachment {
+	list := []models.TicketAttachment{*attachment}
+	s.FillDownloadURLs(list)
+	return &list[0]
+}
+
+// sign 使用 JWT 密钥做 HMAC，并绑定用途，防止与其他签名复用
+func (s *TicketAttachmentService) sign(id uint, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.JWT.Secret))
+	mac.Write([]byte("ticket_attachment\x00" + strconv.FormatUint(uint64(id), 10) + "\x00" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// OpenSigned 校验签名链接并打开附件内容，调用方负责关闭返回的 ReadCloser
+func (s *TicketAttachmentService) OpenSigned(ctx context.Context, id uint, expires, sig string) (*models.TicketAttachment, io.ReadCloser, error) {
+	expiresAt, err := strconv.ParseInt(strings.TrimSpace(expires), 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt ||
+		!hmac.Equal([]byte(s.sign(id, expiresAt)), []byte(strings.TrimSpace(sig))) {
+		return nil, nil, ticketbiz.AttachmentLinkExpired()
+	}
+	// 所属工单已删除的附件不再提供下载
+	var attachment models.TicketAttachment
+	if err := s.db.Joins("JOIN tickets ON tickets.id = ticket_attachments.ticket_id AND tickets.deleted_at IS NULL").
+		First(&attachment, "ticket_attachments.id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ticketbiz.AttachmentInvalid()
+		}
+		return nil, nil, err
+	}
+	store, err := s.store(attachment.Backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := store.Open(ctx, attachment.StorageKey)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, nil, ticketbiz.AttachmentInvalid()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &attachment, reader, nil
+}
+
+// DeleteObjects 删除附件在存储中的文件，数据库记录由调用方删除
+func (s *TicketAttachmentService) DeleteObjects(ctx context.Context, attachments []models.TicketAttachment) error {
+	var errs []error
+	stores := make(map[string]objectstore.Store)
+	for _, attachment := range attachments {
+		store, ok := stores[attachment.Backend]
+		if !ok {
+			var err error
+			if store, err = s.store(attachment.Backend); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			stores[attachment.Backend] = store
+		}
+		if err := store.Delete(ctx, attachment.StorageKey); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s: %w", attachment.StorageKey, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterJobs 注册遗弃附件清理任务
+func (s *TicketAttachmentService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "ticket_attachment_orphans",
+		Description: "Delete ticket attachments that were uploaded but never sent with a message",
+		Interval:    6 * time.Hour,
+		Run:         s.PurgeOrphans,
+	})
+}
+
+// PurgeOrphans 删除上传后超过 24 小时仍未随消息发送的附件
+func (s *TicketAttachmentService) PurgeOrphans(ctx context.Context) error {
+	var attachments []models.TicketAttachment
+	if err := s.db.Where("message_id IS NULL AND created_at < ?", time.Now().Add(-ticketAttachmentOrphanTTL)).
+		Order("id ASC").
+		Limit(ticketAttachmentBatchSize).
+		Find(&attachments).Error; err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+	// 先删除文件再删除记录，文件删除失败的记录保留到下次重试
+	deleted := make([]uint, 0, len(attachments))
+	var errs []error
+	for _, attachment := range attachments {
+		if err := s.DeleteObjects(ctx, []models.TicketAttachment{attachment}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted = append(deleted, attachment.ID)
+	}
+	if len(deleted) > 0 {
+		if err := s.db.Where("id IN ?", deleted).Delete(&models.TicketAttachment{}).Error; err != nil {
+			errs = append(errs, err)
+		}
+		logger.LogSystemOperation(s.db, "ticket_attachment_orphan_purge", "system", nil, map[string]interface{}{
+			"deleted_count": len(deleted),
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// TicketMessagePreview 消息列表预览，仅含附件的消息显示附件名
+func TicketMessagePreview(content string, attachments []models.TicketAttachment) string {
+	if strings.TrimSpace(content) != "" || len(attachments) == 0 {
+		return content
+	}
+	names := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		names = append(names, attachment.Filename)
+	}
+	return "📎 " + strings.Join(names, ", ")
+}
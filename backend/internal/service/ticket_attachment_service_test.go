@@ -0,0 +1,202 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTicketAttachmentTestService(t *testing.T) (*TicketAttachmentService, *gorm.DB, *models.Ticket) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Ticket{}, &models.TicketMessage{}, &models.TicketAttachment{}, &models.TicketOrderAccess{}, &models.RefundRequest{}, &models.Order{},
+		&models.Product{}, &models.PromoCode{}, &models.VirtualInventory{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.App.URL = "https://shop.example.com"
+	cfg.JWT.Secret = "ticket-attachment-test-secret-0123456789"
+	cfg.Storage.Driver = "local"
+	cfg.Storage.LocalDir = t.TempDir()
+	cfg.Ticket.Attachment = &config.TicketAttachmentConfig{
+		EnableImage:      true,
+		EnableFile:       true,
+		MaxFileSize:      1024,
+		AllowedFileTypes: []string{".pdf", ".txt"},
+	}
+
+	ticket := &models.Ticket{TicketNo: "T-1", UserID: 1, Subject: "Broken item", Content: "help", Status: models.TicketStatusOpen}
+	if err := db.Create(ticket).Error; err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+	return NewTicketAttachmentService(db, cfg), db, ticket
+}
+
+func ticketAttachmentFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("parse multipart: %v", err)
+	}
+	return req.MultipartForm.File["file"][0]
+}
+
+func TestTicketAttachmentUploadValidatesTypeAndSize(t *testing.T) {
+	svc, _, ticket := newTicketAttachmentTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "run.exe", []byte("MZ")))
+	requireOrderBizErr(t, err, "ticket.fileTypeUnsupported")
+
+	_, err = svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "big.pdf", bytes.Repeat([]byte("a"), 2048)))
+	requireOrderBizErr(t, err, "ticket.fileTooLarge")
+
+	// 扩展名为图片但内容不是图片
+	_, err = svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "fake.png", []byte("<html><script>alert(1)</script></html>")))
+	requireOrderBizErr(t, err, "ticket.imageFormatUnsupported")
+
+	attachment, err := svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "../../receipt.txt", []byte("order 42")))
+	if err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+	if attachment.Filename != "receipt.txt" || attachment.Backend != "local" || attachment.MessageID != nil {
+		t.Fatalf("unexpected attachment %+v", attachment)
+	}
+	if !strings.HasPrefix(attachment.DownloadURL, "https://shop.example.com/api/tickets/attachments/") {
+		t.Fatalf("expected signed download url, got %q", attachment.DownloadURL)
+	}
+
+	ticket.Status = models.TicketStatusClosed
+	_, err = svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "late.txt", []byte("x")))
+	requireOrderBizErr(t, err, "ticket.closedCannotUpload")
+}
+
+func TestTicketAttachmentLinkAndSignedDownload(t *testing.T) {
+	svc, db, ticket := newTicketAttachmentTestService(t)
+	ctx := context.Background()
+
+	attachment, err := svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "receipt.txt", []byte("order 42")))
+	if err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+
+	// 其他上传者不能引用该附件
+	_, err = svc.ResolvePending(db, ticket.ID, "admin", 9, []uint{attachment.ID})
+	requireOrderBizErr(t, err, "ticket.attachmentInvalid")
+
+	pending, err := svc.ResolvePending(db, ticket.ID, "user", 1, []uint{attachment.ID, attachment.ID})
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("resolve pending failed: %v %v", pending, err)
+	}
+	message := &models.TicketMessage{TicketID: ticket.ID, SenderType: "user", SenderID: 1}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return svc.LinkToMessage(tx, message, pending)
+	}); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if len(message.Attachments) != 1 || message.Attachments[0].DownloadURL == "" {
+		t.Fatalf("expected linked attachment with url, got %+v", message.Attachments)
+	}
+	// 已发送的附件不能再次关联
+	_, err = svc.ResolvePending(db, ticket.ID, "user", 1, []uint{attachment.ID})
+	requireOrderBizErr(t, err, "ticket.attachmentInvalid")
+
+	messages := []models.TicketMessage{*message}
+	if err := svc.LoadForMessages(messages); err != nil || len(messages[0].Attachments) != 1 {
+		t.Fatalf("load for messages failed: %v %+v", err, messages[0].Attachments)
+	}
+
+	signed, _ := url.Parse(messages[0].Attachments[0].DownloadURL)
+	_, reader, err := svc.OpenSigned(ctx, attachment.ID, signed.Query().Get("expires"), signed.Query().Get("sig"))
+	if err != nil {
+		t.Fatalf("open signed failed: %v", err)
+	}
+	body, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(body) != "order 42" {
+		t.Fatalf("unexpected content %q", body)
+	}
+
+	_, _, err = svc.OpenSigned(ctx, attachment.ID, signed.Query().Get("expires"), strings.Repeat("0", 64))
+	requireOrderBizErr(t, err, "ticket.attachmentLinkExpired")
+	expired := fmt.Sprint(time.Now().Add(-time.Minute).Unix())
+	_, _, err = svc.OpenSigned(ctx, attachment.ID, expired, svc.sign(attachment.ID, time.Now().Add(-time.Minute).Unix()))
+	requireOrderBizErr(t, err, "ticket.attachmentLinkExpired")
+}
+
+func TestTrashPurgeTicketDeletesAttachmentFiles(t *testing.T) {
+	svc, db, ticket := newTicketAttachmentTestService(t)
+	ctx := context.Background()
+
+	attachment, err := svc.Upload(ctx, ticket, "user", 1, ticketAttachmentFileHeader(t, "receipt.txt", []byte("order 42")))
+	if err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+	storedPath := filepath.Join(svc.cfg.Storage.LocalDir, filepath.FromSlash(attachment.StorageKey))
+	if _, err := os.Stat(storedPath); err != nil {
+		t.Fatalf("expected stored file: %v", err)
+	}
+
+	if err := dbutil.SoftDelete(db, &models.Ticket{}, ticket.ID, 7); err != nil {
+		t.Fatalf("soft delete failed: %v", err)
+	}
+	trash := NewTrashService(db, svc.cfg, nil)
+	items, total, err := trash.List(TrashEntityTicket, 1, 20)
+	if err != nil || total != 1 || items[0].Name != "Broken item" || items[0].Identifier != "T-1" {
+		t.Fatalf("unexpected trash listing: %v %d %+v", err, total, items)
+	}
+
+	// 回收站中的工单附件不再提供下载
+	links := []models.TicketAttachment{*attachment}
+	svc.FillDownloadURLs(links)
+	signed, _ := url.Parse(links[0].DownloadURL)
+	_, _, err = svc.OpenSigned(ctx, attachment.ID, signed.Query().Get("expires"), signed.Query().Get("sig"))
+	requireOrderBizErr(t, err, "ticket.attachmentInvalid")
+
+	svc.cfg.Trash.RetentionDays = 1
+	db.Unscoped().Model(&models.Ticket{}).Where("id = ?", ticket.ID).Update("deleted_at", time.Now().Add(-48*time.Hour))
+	if err := trash.PurgeExpired(ctx); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	var remaining int64
+	db.Model(&models.TicketAttachment{}).Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("expected attachment rows to be purged, got %d", remaining)
+	}
+	if _, err := os.Stat(storedPath); !os.IsNotExist(err) {
+		t.Fatalf("expected stored file to be deleted, got %v", err)
+	}
+}
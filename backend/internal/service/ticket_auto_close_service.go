@@ -1,16 +1,18 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/logger"
+	"auralogic/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -19,10 +21,6 @@ type TicketAutoCloseService struct {
 	db            *gorm.DB
 	cfg           *config.Config
 	pluginManager *PluginManagerService
-	lifecycleMu   sync.Mutex
-	running       bool
-	stopChan      chan struct{}
-	doneChan      chan struct{}
 	checkInterval time.Duration
 }
 
@@ -91,74 +89,36 @@ func ticketAutoCloseHookValueToOptionalString(value interface{}) (string, error)
 	return str, nil
 }
 
-// Start 启动自动关闭服务
-func (s *TicketAutoCloseService) Start() {
-	s.lifecycleMu.Lock()
-	if s.running {
-		s.lifecycleMu.Unlock()
-		return
-	}
-	stopChan := make(chan struct{})
-	doneChan := make(chan struct{})
-	s.stopChan = stopChan
-	s.doneChan = doneChan
-	s.running = true
-	s.lifecycleMu.Unlock()
-
-	logger.LogSystemOperation(s.db, "ticket_auto_close_start", "system", nil, map[string]interface{}{
-		"auto_close_hours": s.cfg.Ticket.AutoCloseHours,
-		"check_interval":   s.checkInterval.String(),
+// RegisterJobs 注册工单超时自动关闭任务，同时同步到期的订单分享标记
+func (s *TicketAutoCloseService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "ticket_auto_close",
+		Description: "Close tickets without replies and clear expired order shares",
+		Interval:    s.checkInterval,
+		Run: func(ctx context.Context) error {
+			return errors.Join(s.closeInactiveTickets(), s.clearExpiredOrderShares())
+		},
 	})
-
-	go func() {
-		defer close(doneChan)
-		runBackgroundServiceWithStopChan("ticket_auto_close.closeLoop", stopChan, s.closeLoop)
-	}()
 }
 
-// Stop 停止自动关闭服务
-func (s *TicketAutoCloseService) Stop() {
-	s.lifecycleMu.Lock()
-	if !s.running {
-		s.lifecycleMu.Unlock()
-		return
+// clearExpiredOrderShares 订单分享到期后没有写操作触发刷新，定期同步订单的 shared_to_support 标记
+func (s *TicketAutoCloseService) clearExpiredOrderShares() error {
+	cleared, err := repository.NewOrderRepository(s.db).ClearExpiredSharedToSupport(time.Now())
+	if err != nil {
+		return fmt.Errorf("clear expired order shares: %w", err)
 	}
-	stopChan := s.stopChan
-	doneChan := s.doneChan
-	s.stopChan = nil
-	s.doneChan = nil
-	s.running = false
-
-	logger.LogSystemOperation(s.db, "ticket_auto_close_stop", "system", nil, nil)
-	close(stopChan)
-	<-doneChan
-	s.lifecycleMu.Unlock()
-}
-
-// closeLoop 自动关闭循环
-func (s *TicketAutoCloseService) closeLoop(stopChan <-chan struct{}) {
-	// 启动时执行一次
-	s.closeInactiveTickets()
-
-	ticker := time.NewTicker(s.checkInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-stopChan:
-			return
-		case <-ticker.C:
-			s.closeInactiveTickets()
-		}
+	if cleared > 0 {
+		log.Printf("[TicketAutoClose] Cleared shared_to_support on %d orders with expired shares", cleared)
 	}
+	return nil
 }
 
 // closeInactiveTickets 关闭超时无回复的工单
-func (s *TicketAutoCloseService) closeInactiveTickets() {
+func (s *TicketAutoCloseService) closeInactiveTickets() error {
 	// 每次执行时读取最新配置，支持热更新
 	autoCloseHours := s.cfg.Ticket.AutoCloseHours
 	if autoCloseHours <= 0 {
-		return // 0 或负数表示不自动关闭
+		return nil // 0 或负数表示不自动关闭
 	}
 
 	cutoff := time.Now().Add(-time.Duration(autoCloseHours) * time.Hour)
@@ -179,12 +139,11 @@ func (s *TicketAutoCloseService) closeInactiveTickets() {
 	).Limit(100).Find(&tickets).Error
 
 	if err != nil {
-		log.Printf("[TicketAutoClose] Error querying inactive tickets: %v", err)
-		return
+		return fmt.Errorf("query inactive tickets: %w", err)
 	}
 
 	if len(tickets) == 0 {
-		return
+		return nil
 	}
 
 	closedCount := 0
@@ -206,6 +165,7 @@ func (s *TicketAutoCloseService) closeInactiveTickets() {
 			"cutoff_time":      cutoff.Format(time.RFC3339),
 		})
 	}
+	return nil
 }
 
 // closeTicket 关闭单个工单（事务内完成状态更新、系统消息、未读计数）
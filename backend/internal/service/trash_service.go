@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/logger"
+	"auralogic/internal/repository"
+	"gorm.io/gorm"
+)
+
+// 回收站支持的实体类型
+const (
+	TrashEntityProduct          = "product"
+	TrashEntityPromoCode        = "promo_code"
+	TrashEntityVirtualInventory = "virtual_inventory"
+	TrashEntityTicket           = "ticket"
+)
+
+// trashPurgeBatchSize 每类实体单次最多彻底删除的条数
+const trashPurgeBatchSize = 200
+
+var (
+	errTrashEntityInvalid = bizerr.Register("trash.entityTypeInvalid", 400, "Unsupported trash entity type")
+	errTrashItemNotFound  = bizerr.Register("trash.itemNotFound", 404, "Item not found in trash")
+	// errTrashPurgeBlocked 仍被历史数据引用，保留在回收站中不彻底删除
+	errTrashPurgeBlocked = errors.New("trash item is still referenced")
+)
+
+// TrashItem 回收站条目
+type TrashItem struct {
+	EntityType    string    `json:"entity_type"`
+	ID            uint      `json:"id"`
+	Name          string    `json:"name"`
+	Identifier    string    `json:"identifier,omitempty"` // 商品 SKU / 优惠码 / 虚拟库存 SKU / 工单号
+	DeletedAt     time.Time `json:"deleted_at"`
+	DeletedBy     *uint     `json:"deleted_by,omitempty"`
+	DeletedByName string    `json:"deleted_by_name,omitempty"`
+	PurgeAt       time.Time `json:"purge_at"`
+}
+
+type trashEntity struct {
+	table            string
+	model            func() interface{}
+	nameColumn       string // 为空时使用 name
+	identifierColumn string
+	// beforeRestore 恢复前检查与现有数据的冲突
+	beforeRestore func(tx *gorm.DB, id uint) error
+	// purge 删除依赖数据后彻底删除实体，返回 errTrashPurgeBlocked 表示暂不删除
+	purge func(tx *gorm.DB, id uint) error
+}
+
+// TrashService 回收站：列出已删除的商品、优惠码、虚拟库存和工单，支持恢复，超过保留期后定时彻底删除
+type TrashService struct {
+	db                *gorm.DB
+	cfg               *config.Config
+	productService    *ProductService
+	ticketAttachments *TicketAttachmentService
+	entities          map[string]trashEntity
+}
+
+// NewTrashService 创建回收站服务，productService 用于彻底删除商品时清理图片文件，可为 nil
+func NewTrashService(db *gorm.DB, cfg *config.Config, productService *ProductService) *TrashService {
+	s := &TrashService{db: db, cfg: cfg, productService: productService, ticketAttachments: NewTicketAttachmentService(db, cfg)}
+	s.entities = map[string]trashEntity{
+		TrashEntityProduct: {
+			table:            "products",
+			model:            func() interface{} { return &models.Product{} },
+			identifierColumn: "sku",
+			beforeRestore:    restoreProductSKUCheck,
+			purge:            purgeTrashedProduct,
+		},
+		TrashEntityPromoCode: {
+			table:            "promo_codes",
+			model:            func() interface{} { return &models.PromoCode{} },
+			identifierColumn: "code",
+			purge: func(tx *gorm.DB, id uint) error {
+				return tx.Unscoped().Delete(&models.PromoCode{}, id).Error
+			},
+		},
+		TrashEntityVirtualInventory: {
+			table:            "virtual_inventories",
+			model:            func() interface{} { return &models.VirtualInventory{} },
+			identifierColumn: "sku",
+			purge:            purgeTrashedVirtualInventory,
+		},
+		TrashEntityTicket: {
+			table:            "tickets",
+			model:            func() interface{} { return &models.Ticket{} },
+			nameColumn:       "subject",
+			identifierColumn: "ticket_no",
+			purge:            purgeTrashedTicket,
+		},
+	}
+	return s
+}
+
+func (s *TrashService) retention() time.Duration {
+	days := 30
+	if s.cfg != nil && s.cfg.Trash.RetentionDays > 0 {
+		days = s.cfg.Trash.RetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func (s *TrashService) entity(entityType string) (trashEntity, error) {
+	entity, ok := s.entities[entityType]
+	if !ok {
+		return trashEntity{}, errTrashEntityInvalid.New().WithParams(map[string]interface{}{"entity_type": entityType})
+	}
+	return entity, nil
+}
+
+// List 分页列出某类实体的回收站条目，按删除时间倒序
+func (s *TrashService) List(entityType string, page, limit int) ([]TrashItem, int64, error) {
+	entity, err := s.entity(entityType)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := s.db.Table(entity.table).Where("deleted_at IS NOT NULL").Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		ID         uint
+		Name       string
+		Identifier string
+		DeletedAt  time.Time
+		DeletedBy  *uint
+	}
+	nameColumn := entity.nameColumn
+	if nameColumn == "" {
+		nameColumn = "name"
+	}
+	if err := query.
+		Select(fmt.Sprintf("id, %s AS name, %s AS identifier, deleted_at, deleted_by", nameColumn, entity.identifierColumn)).
+		Order("deleted_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	userIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		if row.DeletedBy != nil {
+			userIDs = append(userIDs, *row.DeletedBy)
+		}
+	}
+	names := make(map[uint]string, len(userIDs))
+	if len(userIDs) > 0 {
+		var users []models.User
+		if err := s.db.Unscoped().Select("id, name, email").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, 0, err
+		}
+		for _, user := range users {
+			names[user.ID] = user.Name
+			if names[user.ID] == "" {
+				names[user.ID] = user.Email
+			}
+		}
+	}
+
+	items := make([]TrashItem, 0, len(rows))
+	for _, row := range rows {
+		item := TrashItem{
+			EntityType: entityType,
+			ID:         row.ID,
+			Name:       row.Name,
+			Identifier: row.Identifier,
+			DeletedAt:  row.DeletedAt,
+			DeletedBy:  row.DeletedBy,
+			PurgeAt:    row.DeletedAt.Add(s.retention()),
+		}
+		if row.DeletedBy != nil {
+			item.DeletedByName = names[*row.DeletedBy]
+		}
+		items = append(items, item)
+	}
+	return items, total, nil
+}
+
+// Restore 将回收站中的实体恢复为正常状态
+func (s *TrashService) Restore(entityType string, id uint) error {
+	entity, err := s.entity(entityType)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if entity.beforeRestore != nil {
+			if err := entity.beforeRestore(tx, id); err != nil {
+				return err
+			}
+		}
+		if err := dbutil.Restore(tx, entity.model(), id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTrashItemNotFound.New()
+			}
+			if isUniqueConstraintError(err) && entityType == TrashEntityProduct {
+				return newProductSKUAlreadyExistsError()
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// RegisterJobs 注册回收站到期清理任务
+func (s *TrashService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "trash_purge",
+		Description: "Permanently delete products, promo codes, virtual inventories and tickets past the trash retention period",
+		Interval:    6 * time.Hour,
+		Run:         s.PurgeExpired,
+	})
+}
+
+// PurgeExpired 彻底删除超过保留期的回收站条目；仍被引用的条目跳过并保留在回收站
+func (s *TrashService) PurgeExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention())
+	var errs []error
+	purged := make(map[string]int)
+	skipped := make(map[string]int)
+	for _, entityType := range []string{TrashEntityProduct, TrashEntityPromoCode, TrashEntityVirtualInventory, TrashEntityTicket} {
+		entity := s.entities[entityType]
+		var ids []uint
+		if err := s.db.Table(entity.table).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Order("id ASC").
+			Limit(trashPurgeBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			errs = append(errs, fmt.Errorf("list expired %s: %w", entityType, err))
+			continue
+		}
+		for _, id := range ids {
+			if ctx.Err() != nil {
+				break
+			}
+			var product *models.Product
+			if entityType == TrashEntityProduct && s.productService != nil {
+				var record models.Product
+				if err := s.db.Unscoped().First(&record, id).Error; err == nil {
+					product = &record
+				}
+			}
+			var attachments []models.TicketAttachment
+			if entityType == TrashEntityTicket {
+				if err := s.db.Where("ticket_id = ?", id).Find(&attachments).Error; err != nil {
+					errs = append(errs, fmt.Errorf("list attachments of ticket %d: %w", id, err))
+					continue
+				}
+			}
+			err := s.db.Transaction(func(tx *gorm.DB) error {
+				return entity.purge(tx, id)
+			})
+			switch {
+			case errors.Is(err, errTrashPurgeBlocked):
+				skipped[entityType]++
+				continue
+			case err != nil:
+				errs = append(errs, fmt.Errorf("purge %s %d: %w", entityType, id, err))
+				continue
+			}
+			purged[entityType]++
+			if product != nil {
+				if err := s.productService.deleteProductImages(product); err != nil {
+					log.Printf("Warning: failed to delete images of purged product %d: %v", id, err)
+				}
+			}
+			// 记录删除后再删文件，文件删除失败只留下孤立文件，不会出现指向缺失文件的附件
+			if len(attachments) > 0 {
+				if err := s.ticketAttachments.DeleteObjects(ctx, attachments); err != nil {
+					log.Printf("Warning: failed to delete attachments of purged ticket %d: %v", id, err)
+				}
+			}
+		}
+	}
+
+	if len(purged) > 0 || len(skipped) > 0 {
+		logger.LogSystemOperation(s.db, "trash_purge", "system", nil, map[string]interface{}{
+			"purged":  purged,
+			"skipped": skipped,
+			"cutoff":  cutoff,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// restoreProductSKUCheck 商品 SKU 只在未删除的商品中唯一，删除后可能已被新商品使用
+func restoreProductSKUCheck(tx *gorm.DB, id uint) error {
+	var product models.Product
+	if err := tx.Unscoped().Select("id, sku").Where("id = ? AND deleted_at IS NOT NULL", id).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errTrashItemNotFound.New()
+		}
+		return err
+	}
+	var count int64
+	if err := tx.Model(&models.Product{}).Where("sku = ? AND id <> ?", product.SKU, id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return newProductSKUAlreadyExistsError()
+	}
+	return nil
+}
+
+// purgeTrashedProduct 已生成序列号的商品用于防伪查询，保留不删除；其余删除购物车、库存绑定后彻底删除
+func purgeTrashedProduct(tx *gorm.DB, id uint) error {
+	var serials int64
+	if err := tx.Model(&models.ProductSerial{}).Where("product_id = ?", id).Count(&serials).Error; err != nil {
+		return err
+	}
+	if serials > 0 {
+		return errTrashPurgeBlocked
+	}
+	for _, model := range []interface{}{
+		&models.CartItem{},
+		&models.ProductInventoryBinding{},
+		&models.ProductVirtualInventoryBinding{},
+	} {
+		if err := tx.Unscoped().Where("product_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(&models.Product{}, id).Error
+}
+
+// purgeTrashedVirtualInventory 删除时已校验无库存项和商品绑定，这里清理已软删除的库存项和脚本相关数据
+func purgeTrashedVirtualInventory(tx *gorm.DB, id uint) error {
+	for _, model := range []interface{}{&models.ProductVirtualInventoryBinding{}, &models.VirtualProductStock{}} {
+		var count int64
+		if err := tx.Model(model).Where("virtual_inventory_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errTrashPurgeBlocked
+		}
+	}
+	for _, model := range []interface{}{
+		&models.VirtualProductStock{},
+		&models.VirtualInventoryScriptRevision{},
+		&models.VirtualInventoryStorageEntry{},
+		&models.VirtualInventorySupplierStat{},
+	} {
+		if err := tx.Unscoped().Where("virtual_inventory_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(&models.VirtualInventory{}, id).Error
+}
+
+// purgeTrashedTicket 删除工单消息、附件记录与订单授权，退款申请保留但解除关联；附件文件在事务提交后删除
+func purgeTrashedTicket(tx *gorm.DB, id uint) error {
+	var orderIDs []uint
+	if err := tx.Model(&models.TicketOrderAccess{}).Where("ticket_id = ?", id).Pluck("order_id", &orderIDs).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{
+		&models.TicketAttachment{},
+		&models.TicketMessage{},
+		&models.TicketOrderAccess{},
+	} {
+		if err := tx.Unscoped().Where("ticket_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	orderRepo := repository.NewOrderRepository(tx)
+	for _, orderID := range orderIDs {
+		if err := orderRepo.RefreshSharedToSupport(orderID); err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&models.RefundRequest{}).Where("ticket_id = ?", id).Update("ticket_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&models.Ticket{}, id).Error
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/dbutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openTrashTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Product{},
+		&models.ProductSerial{},
+		&models.CartItem{},
+		&models.ProductInventoryBinding{},
+		&models.PromoCode{},
+		&models.VirtualInventory{},
+		&models.VirtualProductStock{},
+		&models.ProductVirtualInventoryBinding{},
+		&models.VirtualInventoryScriptRevision{},
+		&models.VirtualInventoryStorageEntry{},
+		&models.VirtualInventorySupplierStat{},
+		&models.Ticket{},
+		&models.TicketMessage{},
+		&models.TicketAttachment{},
+		&models.OperationLog{},
+	); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return db
+}
+
+func TestTrashListAndRestore(t *testing.T) {
+	db := openTrashTestDB(t)
+	admin := models.User{Email: "ops@example.com", Name: "Ops", Role: "admin"}
+	if err := db.Create(&admin).Error; err != nil {
+		t.Fatalf("create admin: %v", err)
+	}
+	product := models.Product{SKU: "TEE-1", Name: "Tee", ProductType: models.ProductTypePhysical}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := dbutil.SoftDelete(db, &models.Product{}, product.ID, admin.ID); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	if err := dbutil.SoftDelete(db, &models.Product{}, product.ID, admin.ID); err != gorm.ErrRecordNotFound {
+		t.Fatalf("expected deleting twice to report not found, got %v", err)
+	}
+
+	cfg := &config.Config{Trash: config.TrashConfig{RetentionDays: 7}}
+	trash := NewTrashService(db, cfg, nil)
+	items, total, err := trash.List(TrashEntityProduct, 1, 20)
+	if err != nil {
+		t.Fatalf("list trash: %v", err)
+	}
+	if total != 1 || len(items) != 1 {
+		t.Fatalf("expected one trashed product, got %d", total)
+	}
+	item := items[0]
+	if item.Identifier != "TEE-1" || item.DeletedByName != "Ops" || item.DeletedBy == nil || *item.DeletedBy != admin.ID {
+		t.Fatalf("unexpected trash item %+v", item)
+	}
+	if gap := item.PurgeAt.Sub(item.DeletedAt); gap != 7*24*time.Hour {
+		t.Fatalf("expected purge 7 days after deletion, got %v", gap)
+	}
+	requireBizErr(t, func() error { _, _, err := trash.List("order", 1, 20); return err }(), "trash.entityTypeInvalid")
+
+	// SKU 被新商品占用时不能恢复
+	replacement := models.Product{SKU: "TEE-1", Name: "Tee v2", ProductType: models.ProductTypePhysical}
+	if err := db.Create(&replacement).Error; err != nil {
+		t.Fatalf("create replacement: %v", err)
+	}
+	requireBizErr(t, trash.Restore(TrashEntityProduct, product.ID), "product.skuAlreadyExists")
+	if err := db.Delete(&models.Product{}, replacement.ID).Error; err != nil {
+		t.Fatalf("delete replacement: %v", err)
+	}
+
+	if err := trash.Restore(TrashEntityProduct, product.ID); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	var restored models.Product
+	if err := db.First(&restored, product.ID).Error; err != nil {
+		t.Fatalf("expected restored product to be visible: %v", err)
+	}
+	if restored.DeletedBy != nil {
+		t.Fatalf("expected deleted_by to be cleared, got %v", *restored.DeletedBy)
+	}
+	requireBizErr(t, trash.Restore(TrashEntityProduct, product.ID), "trash.itemNotFound")
+}
+
+func TestTrashPurgeExpired(t *testing.T) {
+	db := openTrashTestDB(t)
+	trash := NewTrashService(db, &config.Config{Trash: config.TrashConfig{RetentionDays: 30}}, nil)
+
+	expired := models.PromoCode{Code: "OLD", Name: "Old", DiscountType: models.DiscountTypeFixed}
+	recent := models.PromoCode{Code: "NEW", Name: "New", DiscountType: models.DiscountTypeFixed}
+	withSerial := models.Product{SKU: "SER-1", Name: "Serialized", ProductType: models.ProductTypePhysical}
+	plain := models.Product{SKU: "PLAIN-1", Name: "Plain", ProductType: models.ProductTypePhysical}
+	inventory := models.VirtualInventory{Name: "Keys", Type: models.VirtualInventoryTypeStatic}
+	for _, record := range []interface{}{&expired, &recent, &withSerial, &plain, &inventory} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("create %T: %v", record, err)
+		}
+	}
+	if err := db.Create(&models.ProductSerial{SerialNumber: "SER-1-001", ProductID: withSerial.ID, OrderID: 1, ProductCode: "SER", SequenceNumber: 1, AntiCounterfeitCode: "ABCD"}).Error; err != nil {
+		t.Fatalf("create serial: %v", err)
+	}
+	if err := db.Create(&models.CartItem{UserID: 1, ProductID: plain.ID, Quantity: 1}).Error; err != nil {
+		t.Fatalf("create cart item: %v", err)
+	}
+
+	longAgo := time.Now().Add(-40 * 24 * time.Hour)
+	softDeleteAt := func(model interface{}, id uint, at time.Time) {
+		if err := dbutil.SoftDelete(db, model, id, 0); err != nil {
+			t.Fatalf("soft delete %T: %v", model, err)
+		}
+		if err := db.Unscoped().Model(model).Where("id = ?", id).Update("deleted_at", at).Error; err != nil {
+			t.Fatalf("backdate %T: %v", model, err)
+		}
+	}
+	softDeleteAt(&models.PromoCode{}, expired.ID, longAgo)
+	softDeleteAt(&models.PromoCode{}, recent.ID, time.Now())
+	softDeleteAt(&models.Product{}, withSerial.ID, longAgo)
+	softDeleteAt(&models.Product{}, plain.ID, longAgo)
+	softDeleteAt(&models.VirtualInventory{}, inventory.ID, longAgo)
+
+	if err := trash.PurgeExpired(context.Background()); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	exists := func(model interface{}, id uint) bool {
+		var count int64
+		if err := db.Unscoped().Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+			t.Fatalf("count %T: %v", model, err)
+		}
+		return count > 0
+	}
+	if exists(&models.PromoCode{}, expired.ID) || exists(&models.Product{}, plain.ID) || exists(&models.VirtualInventory{}, inventory.ID) {
+		t.Fatal("expected expired trash items to be purged")
+	}
+	if !exists(&models.PromoCode{}, recent.ID) {
+		t.Fatal("expected recently deleted promo code to stay in trash")
+	}
+	if !exists(&models.Product{}, withSerial.ID) {
+		t.Fatal("expected product with serials to be kept")
+	}
+	var cartItems int64
+	db.Unscoped().Model(&models.CartItem{}).Where("product_id = ?", plain.ID).Count(&cartItems)
+	if cartItems != 0 {
+		t.Fatalf("expected cart items of purged product to be removed, got %d", cartItems)
+	}
+}
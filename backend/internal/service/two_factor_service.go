@@ -0,0 +1,452 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/fieldcrypt"
+	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/totp"
+	qrcode "github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+)
+
+const (
+	twoFactorBackupCodeCount   = 10
+	twoFactorMaxFailedAttempts = 5
+	twoFactorLockDuration      = 15 * time.Minute
+	twoFactorValidationSkew    = 1 // 允许前后各一个时间步的时钟偏差
+	twoFactorQRCodeSize        = 256
+)
+
+var ErrTwoFactorUserNotFound = errors.New("user not found")
+
+// TwoFactorStatus 用户的两步验证状态
+type TwoFactorStatus struct {
+	Enabled              bool       `json:"enabled"`
+	Required             bool       `json:"required"`
+	EnabledAt            *time.Time `json:"enabled_at,omitempty"`
+	BackupCodesRemaining int        `json:"backup_codes_remaining"`
+}
+
+// TwoFactorSetup 绑定验证器所需的密钥与二维码
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"` // PNG data URL
+}
+
+// TwoFactorService 管理 TOTP 两步验证的绑定、校验、备用码与找回
+type TwoFactorService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	otp          *OTPService
+	emailService *EmailService
+}
+
+// NewTwoFactorService 创建两步验证服务
+func NewTwoFactorService(db *gorm.DB, cfg *config.Config, otp *OTPService, emailService *EmailService) *TwoFactorService {
+	return &TwoFactorService{db: db, cfg: cfg, otp: otp, emailService: emailService}
+}
+
+func twoFactorInvalidCode() *bizerr.Error {
+	return bizerr.New("twoFactor.invalidCode", "Invalid two-factor verification code")
+}
+
+func twoFactorLocked(until time.Time) *bizerr.Error {
+	minutes := int(time.Until(until).Minutes()) + 1
+	return bizerr.Newf("twoFactor.locked", "Too many failed attempts, please try again in %d minutes", minutes).
+		WithParams(map[string]interface{}{"minutes": minutes}).
+		WithStatus(429)
+}
+
+func twoFactorNotEnabled() *bizerr.Error {
+	return bizerr.New("twoFactor.notEnabled", "Two-factor authentication is not enabled")
+}
+
+func (s *TwoFactorService) loadUser(tx *gorm.DB, userID uint) (*models.User, error) {
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// TwoFactorChallengeScope 登录第一步通过后需要的受限令牌作用域；无需两步验证时返回 jwt.ScopeFull
+func TwoFactorChallengeScope(user *models.User) string {
+	switch {
+	case user.TwoFactorEnabled:
+		return jwt.ScopeTwoFactorPending
+	case user.TwoFactorRequired:
+		return jwt.ScopeTwoFactorSetup
+	default:
+		return jwt.ScopeFull
+	}
+}
+
+// TwoFactorChallengeToken 为两步验证未完成的登录签发受限令牌
+func TwoFactorChallengeToken(user *models.User, scope string) (string, error) {
+	return jwt.GenerateScopedToken(user.ID, user.Email, user.Role, scope, jwt.TwoFactorScopeLifetime)
+}
+
+// Status 查询两步验证状态
+func (s *TwoFactorService) Status(userID uint) (*TwoFactorStatus, error) {
+	user, err := s.loadUser(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &TwoFactorStatus{Required: user.TwoFactorRequired}
+	var record models.UserTwoFactor
+	if err := s.db.Where("user_id = ? AND enabled = ?", userID, true).Limit(1).Find(&record).Error; err != nil {
+		return nil, err
+	}
+	if record.ID != 0 {
+		status.Enabled = true
+		status.EnabledAt = record.EnabledAt
+		status.BackupCodesRemaining = len(record.BackupCodes)
+	}
+	return status, nil
+}
+
+// BeginSetup 生成新的验证器密钥；已开启时需先关闭，重复调用会覆盖未完成的绑定
+func (s *TwoFactorService) BeginSetup(userID uint) (*TwoFactorSetup, error) {
+	user, err := s.loadUser(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, bizerr.New("twoFactor.alreadyEnabled", "Two-factor authentication is already enabled")
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := fieldcrypt.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.UserTwoFactor{UserID: userID, Secret: sealed}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := "AuraLogic"
+	if s.cfg != nil && strings.TrimSpace(s.cfg.App.Name) != "" {
+		issuer = strings.TrimSpace(s.cfg.App.Name)
+	}
+	account := user.Email
+	if account == "" && user.Phone != nil {
+		account = *user.Phone
+	}
+	uri := totp.ProvisioningURI(issuer, account, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, twoFactorQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Enable 校验验证器中的首个验证码完成绑定，返回一次性备用码明文
+func (s *TwoFactorService) Enable(userID uint, code string) ([]string, error) {
+	var backupCodes []string
+	var rejected error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := lockTwoFactorTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			return bizerr.New("twoFactor.setupRequired", "Please start two-factor setup first")
+		}
+		if record.Enabled {
+			return bizerr.New("twoFactor.alreadyEnabled", "Two-factor authentication is already enabled")
+		}
+		if rejected, err = checkTwoFactorCodeTx(tx, record, code, false); err != nil || rejected != nil {
+			return err
+		}
+		plain, hashes, err := generateBackupCodes()
+		if err != nil {
+			return err
+		}
+		now := models.NowFunc()
+		record.Enabled = true
+		record.EnabledAt = &now
+		record.BackupCodes = hashes
+		if err := tx.Save(record).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("two_factor_enabled", true).Error; err != nil {
+			return err
+		}
+		backupCodes = plain
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backupCodes, rejected
+}
+
+// Disable 使用验证码或备用码关闭两步验证；管理员强制开启时不可关闭
+func (s *TwoFactorService) Disable(userID uint, code string) error {
+	user, err := s.loadUser(s.db, userID)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactorRequired {
+		return bizerr.New("twoFactor.requiredByAdmin", "Two-factor authentication is required for your account and cannot be disabled")
+	}
+	var rejected error
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := lockEnabledTwoFactorTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if rejected, err = checkTwoFactorCodeTx(tx, record, code, true); err != nil || rejected != nil {
+			return err
+		}
+		return resetTwoFactorTx(tx, userID)
+	})
+	if err != nil {
+		return err
+	}
+	return rejected
+}
+
+// RegenerateBackupCodes 使用验证器验证码重新生成备用码，旧备用码全部失效
+func (s *TwoFactorService) RegenerateBackupCodes(userID uint, code string) ([]string, error) {
+	var backupCodes []string
+	var rejected error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := lockEnabledTwoFactorTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if rejected, err = checkTwoFactorCodeTx(tx, record, code, false); err != nil || rejected != nil {
+			return err
+		}
+		plain, hashes, err := generateBackupCodes()
+		if err != nil {
+			return err
+		}
+		record.BackupCodes = hashes
+		if err := tx.Save(record).Error; err != nil {
+			return err
+		}
+		backupCodes = plain
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backupCodes, rejected
+}
+
+// Verify 登录第二步：校验验证器验证码或备用码（备用码使用后作废）
+func (s *TwoFactorService) Verify(userID uint, code string) error {
+	var rejected error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := lockEnabledTwoFactorTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		rejected, err = checkTwoFactorCodeTx(tx, record, code, true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return rejected
+}
+
+// SendRecoveryCode 丢失验证器时向账号邮箱发送找回验证码，返回脱敏后的邮箱
+func (s *TwoFactorService) SendRecoveryCode(userID uint) (string, error) {
+	user, err := s.loadUser(s.db, userID)
+	if err != nil {
+		return "", err
+	}
+	if !user.TwoFactorEnabled {
+		return "", twoFactorNotEnabled()
+	}
+	if strings.TrimSpace(user.Email) == "" || s.emailService == nil {
+		return "", bizerr.New("twoFactor.recoveryUnavailable", "Email recovery is unavailable, please use a backup code or contact support")
+	}
+	code, err := s.otp.Issue(OTPPurposeTwoFactorRecovery, twoFactorRecoverySubject(userID), OTPChannelEmail)
+	if err != nil {
+		return "", err
+	}
+	locale := user.Locale
+	if locale == "" {
+		locale = "en"
+	}
+	go s.emailService.SendLoginCodeEmail(user.Email, code, locale)
+	return maskRevealEmail(user.Email), nil
+}
+
+// RecoveryCooldown 检查找回验证码重发冷却，未处于冷却时立即开始新的冷却
+func (s *TwoFactorService) RecoveryCooldown(ip string, userID uint) (bool, string) {
+	subject := twoFactorRecoverySubject(userID)
+	if s.otp.InCooldown(OTPPurposeTwoFactorRecovery, ip, subject) {
+		return true, s.otp.CooldownMessage()
+	}
+	s.otp.StartCooldown(OTPPurposeTwoFactorRecovery, ip, subject)
+	return false, ""
+}
+
+// Recover 使用邮箱验证码找回账号：关闭两步验证，用户需重新绑定验证器
+func (s *TwoFactorService) Recover(userID uint, code string) error {
+	if err := s.otp.Verify(OTPPurposeTwoFactorRecovery, twoFactorRecoverySubject(userID), code); err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return resetTwoFactorTx(tx, userID)
+	})
+}
+
+// AdminReset 管理员重置用户的两步验证（用户丢失验证器与备用码时）
+func (s *TwoFactorService) AdminReset(userID uint) error {
+	if _, err := s.loadUser(s.db, userID); err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return resetTwoFactorTx(tx, userID)
+	})
+}
+
+// SetRequired 管理员设置是否强制用户开启两步验证，强制后用户下次登录需先完成绑定
+func (s *TwoFactorService) SetRequired(userID uint, required bool) error {
+	if _, err := s.loadUser(s.db, userID); err != nil {
+		return err
+	}
+	return s.db.Model(&models.User{}).Where("id = ?", userID).Update("two_factor_required", required).Error
+}
+
+func twoFactorRecoverySubject(userID uint) string {
+	return fmt.Sprintf("%d", userID)
+}
+
+// lockTwoFactorTx 锁定并读取用户的两步验证记录，不存在时返回 nil
+func lockTwoFactorTx(tx *gorm.DB, userID uint) (*models.UserTwoFactor, error) {
+	if err := dbutil.LockForUpdate(tx, &models.UserTwoFactor{}, "user_id = ?", userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var record models.UserTwoFactor
+	if err := tx.Where("user_id = ?", userID).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func lockEnabledTwoFactorTx(tx *gorm.DB, userID uint) (*models.UserTwoFactor, error) {
+	record, err := lockTwoFactorTx(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || !record.Enabled {
+		return nil, twoFactorNotEnabled()
+	}
+	return record, nil
+}
+
+func resetTwoFactorTx(tx *gorm.DB, userID uint) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.User{}).Where("id = ?", userID).Update("two_factor_enabled", false).Error
+}
+
+// checkTwoFactorCodeTx 校验验证码，未通过时返回 rejected 业务错误且 err 为 nil，
+// 以便调用方提交失败计数；连续失败达到上限后锁定一段时间，已使用的时间步不可重放
+func checkTwoFactorCodeTx(tx *gorm.DB, record *models.UserTwoFactor, code string, allowBackup bool) (rejected error, err error) {
+	now := models.NowFunc()
+	if record.LockedUntil != nil && now.Before(*record.LockedUntil) {
+		return twoFactorLocked(*record.LockedUntil), nil
+	}
+	secret, err := fieldcrypt.Decrypt(record.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.TrimSpace(code)
+	accepted := false
+	if step, ok := totp.Validate(secret, strings.ReplaceAll(code, " ", ""), now, twoFactorValidationSkew); ok && step > record.LastUsedStep {
+		record.LastUsedStep = step
+		accepted = true
+	} else if allowBackup {
+		hash := hashBackupCode(code)
+		for i, stored := range record.BackupCodes {
+			if stored == hash {
+				record.BackupCodes = append(append([]string{}, record.BackupCodes[:i]...), record.BackupCodes[i+1:]...)
+				accepted = true
+				break
+			}
+		}
+	}
+
+	if accepted {
+		record.FailedAttempts = 0
+		record.LockedUntil = nil
+	} else {
+		record.FailedAttempts++
+		rejected = twoFactorInvalidCode()
+		if record.FailedAttempts >= twoFactorMaxFailedAttempts {
+			lockedUntil := now.Add(twoFactorLockDuration)
+			record.FailedAttempts = 0
+			record.LockedUntil = &lockedUntil
+			rejected = twoFactorLocked(lockedUntil)
+		}
+	}
+	if err := tx.Save(record).Error; err != nil {
+		return nil, err
+	}
+	return rejected, nil
+}
+
+// generateBackupCodes 生成 xxxx-xxxx 格式的备用码，返回明文与哈希
+func generateBackupCodes() ([]string, []string, error) {
+	plain := make([]string, 0, twoFactorBackupCodeCount)
+	hashes := make([]string, 0, twoFactorBackupCodeCount)
+	for i := 0; i < twoFactorBackupCodeCount; i++ {
+		buf := make([]byte, 4)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(buf)
+		code := raw[:4] + "-" + raw[4:]
+		plain = append(plain, code)
+		hashes = append(hashes, hashBackupCode(code))
+	}
+	return plain, hashes, nil
+}
+
+// hashBackupCode 备用码忽略大小写、空格与连字符
+func hashBackupCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
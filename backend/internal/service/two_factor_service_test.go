@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/jwt"
+	"auralogic/internal/pkg/totp"
+)
+
+func TestTwoFactorEnrollVerifyAndLockout(t *testing.T) {
+	_, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.UserTwoFactor{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	svc := NewTwoFactorService(db, nil, nil, nil)
+	user := &models.User{UUID: "2fa-user", Email: "2fa@example.com", Role: "user", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if scope := TwoFactorChallengeScope(user); scope != jwt.ScopeFull {
+		t.Fatalf("expected no challenge before enrollment, got %q", scope)
+	}
+
+	_, err := svc.Enable(user.ID, "123456")
+	requireOrderBizErr(t, err, "twoFactor.setupRequired")
+	setup, err := svc.BeginSetup(user.ID)
+	if err != nil || setup.Secret == "" || setup.QRCode == "" {
+		t.Fatalf("begin setup: %+v err=%v", setup, err)
+	}
+	_, err = svc.Enable(user.ID, "000000x")
+	requireOrderBizErr(t, err, "twoFactor.invalidCode")
+
+	code, err := totp.CodeAt(setup.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("code: %v", err)
+	}
+	backupCodes, err := svc.Enable(user.ID, code)
+	if err != nil || len(backupCodes) != twoFactorBackupCodeCount {
+		t.Fatalf("enable: %v err=%v", backupCodes, err)
+	}
+	if err := db.First(user, user.ID).Error; err != nil || !user.TwoFactorEnabled {
+		t.Fatalf("expected user flagged as enabled, err=%v", err)
+	}
+	if scope := TwoFactorChallengeScope(user); scope != jwt.ScopeTwoFactorPending {
+		t.Fatalf("expected pending challenge, got %q", scope)
+	}
+	_, err = svc.BeginSetup(user.ID)
+	requireOrderBizErr(t, err, "twoFactor.alreadyEnabled")
+
+	// 已使用的验证码不可重放；备用码只能使用一次
+	requireOrderBizErr(t, svc.Verify(user.ID, code), "twoFactor.invalidCode")
+	if err := svc.Verify(user.ID, " "+backupCodes[0]+" "); err != nil {
+		t.Fatalf("verify backup code: %v", err)
+	}
+	requireOrderBizErr(t, svc.Verify(user.ID, backupCodes[0]), "twoFactor.invalidCode")
+	status, err := svc.Status(user.ID)
+	if err != nil || !status.Enabled || status.BackupCodesRemaining != twoFactorBackupCodeCount-1 {
+		t.Fatalf("unexpected status: %+v err=%v", status, err)
+	}
+
+	// 连续失败达到上限后锁定，锁定期间正确验证码也被拒绝
+	requireOrderBizErr(t, svc.Verify(user.ID, "000000"), "twoFactor.invalidCode")
+	requireOrderBizErr(t, svc.Verify(user.ID, "000000"), "twoFactor.invalidCode")
+	requireOrderBizErr(t, svc.Verify(user.ID, "000000"), "twoFactor.invalidCode")
+	requireOrderBizErr(t, svc.Verify(user.ID, "000000"), "twoFactor.locked")
+	requireOrderBizErr(t, svc.Verify(user.ID, backupCodes[1]), "twoFactor.locked")
+
+	// 管理员强制开启后用户不能自行关闭；重置后下次登录需重新绑定
+	if err := svc.SetRequired(user.ID, true); err != nil {
+		t.Fatalf("set required: %v", err)
+	}
+	requireOrderBizErr(t, svc.Disable(user.ID, backupCodes[1]), "twoFactor.requiredByAdmin")
+	if err := svc.AdminReset(user.ID); err != nil {
+		t.Fatalf("admin reset: %v", err)
+	}
+	if err := db.First(user, user.ID).Error; err != nil {
+		t.Fatalf("reload user: %v", err)
+	}
+	if scope := TwoFactorChallengeScope(user); scope != jwt.ScopeTwoFactorSetup {
+		t.Fatalf("expected setup challenge after reset, got %q", scope)
+	}
+	requireOrderBizErr(t, svc.Verify(user.ID, backupCodes[1]), "twoFactor.notEnabled")
+	if err := svc.AdminReset(user.ID + 100); err != ErrTwoFactorUserNotFound {
+		t.Fatalf("expected missing user error, got %v", err)
+	}
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"gorm.io/gorm"
+)
+
+const (
+	usageIdempotencyKeyMaxLength = 100
+	usageNoteMaxLength           = 255
+	usageUnitMaxLength           = 32
+	usageOverageBatchSize        = 100
+	// 单次上报的用量上限，防止误报的超大数值一次性生成巨额超额订单
+	usageReportQuantityLimit = 1_000_000_000
+)
+
+// usageAlertThresholds 通知买家的用量阈值（百分比），从低到高
+var usageAlertThresholds = []int{80, 100}
+
+// UsageReport 商户软件上报的一次用量
+type UsageReport struct {
+	LicenseKey     string
+	Quantity       int64
+	IdempotencyKey string
+	Note           string
+	APIKeyID       *uint
+	IPAddress      string
+}
+
+// UsageBalance 返回给商户软件的计量余额
+type UsageBalance struct {
+	MeterID          uint   `json:"meter_id"`
+	Unit             string `json:"unit"`
+	Quota            int64  `json:"quota"`
+	Used             int64  `json:"used"`
+	Remaining        int64  `json:"remaining"`
+	Overage          int64  `json:"overage"`
+	OverageUnitPrice int64  `json:"overage_unit_price_minor"`
+	RecordID         uint   `json:"record_id,omitempty"`
+	Duplicate        bool   `json:"duplicate"` // 幂等键重复，本次上报未重复计入
+}
+
+// UsageMeterService 已发货卡密的用量计量：上报入账、阈值提醒与超额订单
+type UsageMeterService struct {
+	db             *gorm.DB
+	cfg            *config.Config
+	bindingService *BindingService
+	emailService   *EmailService
+	orderService   *OrderService
+}
+
+func NewUsageMeterService(db *gorm.DB, cfg *config.Config, bindingService *BindingService, emailService *EmailService) *UsageMeterService {
+	return &UsageMeterService{db: db, cfg: cfg, bindingService: bindingService, emailService: emailService}
+}
+
+// SetOrderService 注入订单服务，用于生成超额订单；未注入时超额只累计不出单
+func (s *UsageMeterService) SetOrderService(orderService *OrderService) {
+	s.orderService = orderService
+}
+
+// ValidateUsageSettings 校验虚拟库存的计量配置
+func ValidateUsageSettings(unit string, quota, overageUnitPrice int64) error {
+	if quota < 0 {
+		return bizerr.New("usage.quotaInvalid", "Usage quota cannot be negative")
+	}
+	if overageUnitPrice < 0 {
+		return bizerr.New("usage.overagePriceInvalid", "Overage unit price cannot be negative")
+	}
+	if len([]rune(strings.TrimSpace(unit))) > usageUnitMaxLength {
+		return bizerr.Newf("usage.unitTooLong", "Usage unit must be at most %d characters", usageUnitMaxLength).
+			WithParams(map[string]interface{}{"max": usageUnitMaxLength})
+	}
+	return nil
+}
+
+func usageBalance(meter *models.UsageMeter) *UsageBalance {
+	return &UsageBalance{
+		MeterID:          meter.ID,
+		Unit:             meter.Unit,
+		Quota:            meter.Quota,
+		Used:             meter.Used,
+		Remaining:        meter.Remaining(),
+		Overage:          meter.Overage(),
+		OverageUnitPrice: meter.OverageUnitPrice,
+	}
+}
+
+// usagePercent 已用额度百分比
+func usagePercent(meter *models.UsageMeter) int {
+	if meter.Quota <= 0 || meter.Used <= 0 {
+		return 0
+	}
+	return int(meter.Used * 100 / meter.Quota)
+}
+
+// reachedUsageThreshold 当前用量已达到的最高提醒阈值，未达到任何阈值时为 0
+func reachedUsageThreshold(meter *models.UsageMeter) int {
+	percent := usagePercent(meter)
+	reached := 0
+	for _, threshold := range usageAlertThresholds {
+		if percent >= threshold {
+			reached = threshold
+		}
+	}
+	return reached
+}
+
+// resolveMeteredStock 按卡密查找已发货且配置了计量额度的库存项
+func (s *UsageMeterService) resolveMeteredStock(licenseKey string) (*models.VirtualProductStock, error) {
+	stock, err := s.bindingService.resolveLicense(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+	if stock.VirtualInventory == nil || stock.VirtualInventory.UsageQuota <= 0 {
+		return nil, bizerr.New("usage.notMetered", "This license is not usage metered").WithStatus(http.StatusBadRequest)
+	}
+	return stock, nil
+}
+
+// findOrCreateMeter 首次上报时创建计量，额度与单价从虚拟库存快照
+func (s *UsageMeterService) findOrCreateMeter(tx *gorm.DB, stock *models.VirtualProductStock) (*models.UsageMeter, error) {
+	var meter models.UsageMeter
+	err := tx.Where("stock_id = ?", stock.ID).First(&meter).Error
+	if err == nil {
+		return &meter, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	var order models.Order
+	if err := tx.Select("id", "order_no", "user_id").First(&order, *stock.OrderID).Error; err != nil {
+		return nil, err
+	}
+	inventory := stock.VirtualInventory
+	meter = models.UsageMeter{
+		StockID:            stock.ID,
+		VirtualInventoryID: stock.VirtualInventoryID,
+		OrderID:            order.ID,
+		OrderNo:            order.OrderNo,
+		UserID:             order.UserID,
+		LicenseHint:        licenseHint(stock.Content),
+		Unit:               strings.TrimSpace(inventory.UsageUnit),
+		Quota:              inventory.UsageQuota,
+		OverageUnitPrice:   inventory.OverageUnitPrice,
+	}
+	if err := tx.Create(&meter).Error; err != nil {
+		return nil, err
+	}
+	return &meter, nil
+}
+
+// GetBalance 查询卡密的计量余额，尚未上报过用量时返回库存配置的初始额度
+func (s *UsageMeterService) GetBalance(licenseKey string) (*UsageBalance, error) {
+	stock, err := s.resolveMeteredStock(licenseKey)
+	if err != nil {
+		return nil, err
+	}
+	var meter models.UsageMeter
+	err = s.db.Where("stock_id = ?", stock.ID).First(&meter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		inventory := stock.VirtualInventory
+		return usageBalance(&models.UsageMeter{
+			Unit:             strings.TrimSpace(inventory.UsageUnit),
+			Quota:            inventory.UsageQuota,
+			OverageUnitPrice: inventory.OverageUnitPrice,
+		}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return usageBalance(&meter), nil
+}
+
+// ReportUsage 记录一次用量变化；Quantity 为增量，释放席位等场景可上报负数
+// 同一卡密的上报按库存项加锁串行化，幂等键重复时返回当前余额而不重复计入
+func (s *UsageMeterService) ReportUsage(report UsageReport) (*UsageBalance, error) {
+	if report.Quantity == 0 || report.Quantity > usageReportQuantityLimit || report.Quantity < -usageReportQuantityLimit {
+		return nil, bizerr.Newf("usage.quantityInvalid", "Quantity must be a non-zero integer between -%d and %d", usageReportQuantityLimit, usageReportQuantityLimit).
+			WithParams(map[string]interface{}{"max": usageReportQuantityLimit})
+	}
+	idempotencyKey := strings.TrimSpace(report.IdempotencyKey)
+	if len(idempotencyKey) > usageIdempotencyKeyMaxLength {
+		return nil, bizerr.Newf("usage.idempotencyKeyTooLong", "Idempotency key must be at most %d characters", usageIdempotencyKeyMaxLength).
+			WithParams(map[string]interface{}{"max": usageIdempotencyKeyMaxLength})
+	}
+	note := strings.TrimSpace(report.Note)
+	if runes := []rune(note); len(runes) > usageNoteMaxLength {
+		note = string(runes[:usageNoteMaxLength])
+	}
+
+	stock, err := s.resolveMeteredStock(report.LicenseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		balance      *UsageBalance
+		alertMeter   models.UsageMeter
+		alertReached int
+		shouldAlert  bool
+	)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.VirtualProductStock{}, "id = ?", stock.ID); err != nil {
+			return err
+		}
+		meter, err := s.findOrCreateMeter(tx, stock)
+		if err != nil {
+			return err
+		}
+
+		if idempotencyKey != "" {
+			var existing models.UsageRecord
+			err := tx.Where("meter_id = ? AND idempotency_key = ?", meter.ID, idempotencyKey).First(&existing).Error
+			if err == nil {
+				balance = usageBalance(meter)
+				balance.RecordID = existing.ID
+				balance.Duplicate = true
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		usedAfter := meter.Used + report.Quantity
+		if usedAfter < 0 {
+			return bizerr.New("usage.belowZero", "Reported usage would drop below zero").
+				WithParams(map[string]interface{}{"used": meter.Used}).
+				WithStatus(http.StatusConflict)
+		}
+		// 未配置超额单价时额度即硬上限
+		if report.Quantity > 0 && meter.OverageUnitPrice <= 0 && usedAfter > meter.Quota {
+			return bizerr.New("usage.quotaExceeded", "Usage quota exceeded").
+				WithParams(map[string]interface{}{"quota": meter.Quota, "remaining": meter.Remaining()}).
+				WithStatus(http.StatusConflict)
+		}
+
+		record := &models.UsageRecord{
+			MeterID:   meter.ID,
+			Quantity:  report.Quantity,
+			UsedAfter: usedAfter,
+			Note:      note,
+			APIKeyID:  report.APIKeyID,
+			IPAddress: report.IPAddress,
+		}
+		if idempotencyKey != "" {
+			record.IdempotencyKey = &idempotencyKey
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+
+		now := models.NowFunc()
+		meter.Used = usedAfter
+		meter.LastReportedAt = &now
+		// 用量回落到阈值以下后重置，再次越过时重新提醒
+		reached := reachedUsageThreshold(meter)
+		previousAlerted := meter.AlertedPercent
+		if reached != previousAlerted {
+			meter.AlertedPercent = reached
+		}
+		if err := tx.Model(meter).Updates(map[string]interface{}{
+			"used":             meter.Used,
+			"last_reported_at": now,
+			"alerted_percent":  meter.AlertedPercent,
+		}).Error; err != nil {
+			return err
+		}
+
+		balance = usageBalance(meter)
+		balance.RecordID = record.ID
+		if reached > previousAlerted {
+			alertMeter = *meter
+			alertReached = reached
+			shouldAlert = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if shouldAlert {
+		go s.sendThresholdAlert(alertMeter, alertReached)
+	}
+	return balance, nil
+}
+
+// sendThresholdAlert 异步通知买家用量已越过阈值
+func (s *UsageMeterService) sendThresholdAlert(meter models.UsageMeter, percent int) {
+	if s.emailService == nil {
+		return
+	}
+	var order models.Order
+	if err := s.db.First(&order, meter.OrderID).Error; err != nil {
+		log.Printf("usage meter %d: load order for threshold alert failed: %v", meter.ID, err)
+		return
+	}
+	if err := s.emailService.SendUsageThresholdEmail(&order, &meter, percent); err != nil {
+		log.Printf("usage meter %d: send threshold alert failed: %v", meter.ID, err)
+	}
+}
+
+// ListOrderMeters 订单内已上报过用量的计量
+func (s *UsageMeterService) ListOrderMeters(orderID uint) ([]models.UsageMeter, error) {
+	var meters []models.UsageMeter
+	err := s.db.Where("order_id = ?", orderID).Order("id ASC").Find(&meters).Error
+	return meters, err
+}
+
+// ListMeterRecords 分页查询计量的上报记录，最新的在前
+func (s *UsageMeterService) ListMeterRecords(meterID uint, page, limit int) ([]models.UsageRecord, int64, error) {
+	var meter models.UsageMeter
+	if err := s.db.Select("id").First(&meter, meterID).Error; err != nil {
+		return nil, 0, err
+	}
+	query := s.db.Model(&models.UsageRecord{}).Where("meter_id = ?", meterID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var records []models.UsageRecord
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&records).Error
+	return records, total, err
+}
+
+// RegisterJobs 注册超额计费定时任务
+func (s *UsageMeterService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "usage_overage_billing",
+		Description: "Create pending-payment orders for usage reported beyond the license quota",
+		Interval:    time.Hour,
+		Run:         s.BillOverage,
+	})
+}
+
+// BillOverage 为超出额度且尚未计费的用量生成待付款订单
+// 每个计量同时最多一张待付款超额订单；订单付款前被取消时，其计费用量退回未计费，下次重新出单
+func (s *UsageMeterService) BillOverage(ctx context.Context) error {
+	if s.orderService == nil {
+		return nil
+	}
+	var lastID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var meters []models.UsageMeter
+		err := s.db.Where("id > ? AND overage_unit_price > 0 AND (used > quota + billed_overage OR overage_order_id IS NOT NULL)", lastID).
+			Order("id ASC").
+			Limit(usageOverageBatchSize).
+			Find(&meters).Error
+		if err != nil {
+			return err
+		}
+		if len(meters) == 0 {
+			return nil
+		}
+		for i := range meters {
+			lastID = meters[i].ID
+			if err := s.billMeterOverage(meters[i].ID); err != nil {
+				log.Printf("usage meter %d: bill overage failed: %v", meters[i].ID, err)
+			}
+		}
+		if len(meters) < usageOverageBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *UsageMeterService) billMeterOverage(meterID uint) error {
+	var (
+		meter       models.UsageMeter
+		sourceOrder models.Order
+		units       int64
+	)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := dbutil.LockForUpdate(tx, &models.UsageMeter{}, "id = ?", meterID); err != nil {
+			return err
+		}
+		if err := tx.First(&meter, meterID).Error; err != nil {
+			return err
+		}
+		if meter.OverageOrderID != nil {
+			var overageOrder models.Order
+			err := tx.Select("id", "status", "paid_at").First(&overageOrder, *meter.OverageOrderID).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				// 超额订单被删除，视同未付款取消
+				meter.BilledOverage -= meter.OverageOrderUnits
+			case err != nil:
+				return err
+			case overageOrder.Status == models.OrderStatusPendingPayment:
+				return nil
+			case overageOrder.PaidAt == nil:
+				meter.BilledOverage -= meter.OverageOrderUnits
+			}
+			if meter.BilledOverage < 0 {
+				meter.BilledOverage = 0
+			}
+			meter.OverageOrderID = nil
+			meter.OverageOrderNo = ""
+			meter.OverageOrderUnits = 0
+			if err := tx.Model(&meter).Updates(map[string]interface{}{
+				"billed_overage":      meter.BilledOverage,
+				"overage_order_id":    nil,
+				"overage_order_no":    "",
+				"overage_order_units": 0,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		units = meter.Overage() - meter.BilledOverage
+		if units <= 0 || meter.OverageUnitPrice <= 0 {
+			units = 0
+			return nil
+		}
+		return tx.First(&sourceOrder, meter.OrderID).Error
+	})
+	if err != nil || units <= 0 {
+		return err
+	}
+
+	overageOrder, err := s.orderService.CreateUsageOverageOrder(&sourceOrder, &meter, units)
+	if err != nil {
+		return err
+	}
+	// 出单后再登记，期间的新上报只增加 Used，不影响本次计费的用量
+	result := s.db.Model(&models.UsageMeter{}).
+		Where("id = ? AND overage_order_id IS NULL", meter.ID).
+		Updates(map[string]interface{}{
+			"billed_overage":      gorm.Expr("billed_overage + ?", units),
+			"overage_order_id":    overageOrder.ID,
+			"overage_order_no":    overageOrder.OrderNo,
+			"overage_order_units": units,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("overage order %s created but meter %d was linked concurrently", overageOrder.OrderNo, meter.ID)
+	}
+	return nil
+}
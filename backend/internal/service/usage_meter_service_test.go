@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"auralogic/internal/models"
+	"auralogic/internal/repository"
+)
+
+func TestUsageMeterReportsAlertsAndBillsOverage(t *testing.T) {
+	orderService, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventory{}, &models.VirtualProductStock{}, &models.UsageMeter{}, &models.UsageRecord{}, &models.OrderEvent{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	bindingService := NewBindingService(repository.NewBindingRepository(db), repository.NewInventoryRepository(db), repository.NewProductRepository(db))
+	usageService := NewUsageMeterService(db, orderService.cfg, bindingService, nil)
+	usageService.SetOrderService(orderService)
+
+	userID := uint(7)
+	order := &models.Order{OrderNo: "USG-1", UserID: &userID, Status: models.OrderStatusCompleted, Currency: "USD", UserEmail: "buyer@example.com"}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "API plan", Type: models.VirtualInventoryTypeStatic, IsActive: true, UsageUnit: "calls", UsageQuota: 100, OverageUnitPrice: 5}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "API-KEY-0001"}
+	stock.MarkAsSold(order.ID, order.OrderNo)
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create stock: %v", err)
+	}
+
+	if balance, err := usageService.GetBalance("API-KEY-0001"); err != nil || balance.Quota != 100 || balance.Remaining != 100 {
+		t.Fatalf("expected untouched balance, got %+v err=%v", balance, err)
+	}
+	balance, err := usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: 85, IdempotencyKey: "batch-1"})
+	if err != nil || balance.Used != 85 || balance.Remaining != 15 || balance.Duplicate {
+		t.Fatalf("expected first report to be recorded, got %+v err=%v", balance, err)
+	}
+	// 同一幂等键重试不重复计入
+	if balance, err = usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: 85, IdempotencyKey: "batch-1"}); err != nil || !balance.Duplicate || balance.Used != 85 {
+		t.Fatalf("expected duplicate report to be ignored, got %+v err=%v", balance, err)
+	}
+	_, err = usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: -90})
+	requireOrderBizErr(t, err, "usage.belowZero")
+
+	var meter models.UsageMeter
+	if err := db.Where("stock_id = ?", stock.ID).First(&meter).Error; err != nil || meter.AlertedPercent != 80 {
+		t.Fatalf("expected 80%% alert to be recorded, got %+v err=%v", meter, err)
+	}
+	if balance, err = usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: 27}); err != nil || balance.Overage != 12 {
+		t.Fatalf("expected overage of 12, got %+v err=%v", balance, err)
+	}
+
+	if err := usageService.BillOverage(context.Background()); err != nil {
+		t.Fatalf("bill overage: %v", err)
+	}
+	db.First(&meter, meter.ID)
+	if meter.AlertedPercent != 100 || meter.BilledOverage != 12 || meter.OverageOrderID == nil || meter.OverageOrderUnits != 12 {
+		t.Fatalf("expected overage order for 12 units, got %+v", meter)
+	}
+	var overageOrder models.Order
+	if err := db.First(&overageOrder, *meter.OverageOrderID).Error; err != nil {
+		t.Fatalf("load overage order: %v", err)
+	}
+	if overageOrder.Status != models.OrderStatusPendingPayment || overageOrder.TotalAmount != 60 || overageOrder.Source != OrderSourceUsageOverage ||
+		overageOrder.Currency != "USD" || overageOrder.UserID == nil || *overageOrder.UserID != userID {
+		t.Fatalf("unexpected overage order: %+v", overageOrder)
+	}
+
+	// 待付款期间新增的超额不重复出单；订单取消后计费用量退回，下次合并出单
+	if _, err := usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: 3}); err != nil {
+		t.Fatalf("report more usage: %v", err)
+	}
+	if err := usageService.BillOverage(context.Background()); err != nil {
+		t.Fatalf("bill overage again: %v", err)
+	}
+	var count int64
+	db.Model(&models.Order{}).Where("source = ?", OrderSourceUsageOverage).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected a single pending overage order, got %d", count)
+	}
+	db.Model(&overageOrder).Update("status", models.OrderStatusCancelled)
+	if err := usageService.BillOverage(context.Background()); err != nil {
+		t.Fatalf("bill overage after cancel: %v", err)
+	}
+	db.First(&meter, meter.ID)
+	if meter.BilledOverage != 15 || meter.OverageOrderUnits != 15 || meter.OverageOrderID == nil || *meter.OverageOrderID == overageOrder.ID {
+		t.Fatalf("expected cancelled units to be rebilled in a new order, got %+v", meter)
+	}
+
+	// 未配置超额单价时额度即上限
+	db.Model(&models.UsageMeter{}).Where("id = ?", meter.ID).Update("overage_unit_price", 0)
+	_, err = usageService.ReportUsage(UsageReport{LicenseKey: "API-KEY-0001", Quantity: 1})
+	requireOrderBizErr(t, err, "usage.quotaExceeded")
+
+	records, total, err := usageService.ListMeterRecords(meter.ID, 1, 20)
+	if err != nil || total != 3 || len(records) != 3 || records[0].UsedAfter != 115 {
+		t.Fatalf("expected 3 usage records, got %d/%d err=%v", len(records), total, err)
+	}
+}
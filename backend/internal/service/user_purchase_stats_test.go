@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -44,12 +45,12 @@ func TestCreateUserOrderAndCancelKeepPurchaseStatsInSync(t *testing.T) {
 	}
 
 	svc := newConcurrentOrderService(db, cfg, nil)
-	order, err := svc.CreateUserOrder(user.ID, []models.OrderItem{{
+	order, err := svc.CreateUserOrder(context.Background(), user.ID, []models.OrderItem{{
 		SKU:         product.SKU,
 		Name:        product.Name,
 		Quantity:    2,
 		ProductType: models.ProductTypeVirtual,
-	}}, "", "")
+	}}, "", "", "")
 	if err != nil {
 		t.Fatalf("create user order failed: %v", err)
 	}
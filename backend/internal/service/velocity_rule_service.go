@@ -0,0 +1,447 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	velocityRuleCacheTTL        = 30 * time.Second
+	maxVelocityRuleNameLength   = 100
+	maxVelocityRuleWindowMinute = 365 * 24 * 60
+	maxOrderRiskReviewNote      = 500
+)
+
+var (
+	ErrVelocityRuleNotFound    = errors.New("velocity rule not found")
+	ErrOrderRiskReviewNotFound = errors.New("order risk review not found")
+)
+
+// VelocityRuleService 按管理员配置的频率规则检查新订单，命中的订单进入风控审核队列；
+// 处理方式为 hold 时暂扣订单，审核通过前不能发货
+type VelocityRuleService struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	rules    []models.VelocityRule
+	loadedAt time.Time
+}
+
+// NewVelocityRuleService 创建频率规则服务
+func NewVelocityRuleService(db *gorm.DB) *VelocityRuleService {
+	return &VelocityRuleService{db: db}
+}
+
+func (s *VelocityRuleService) invalidateRules() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *VelocityRuleService) activeRules() ([]models.VelocityRule, error) {
+	now := time.Now()
+	s.mu.RLock()
+	if !s.loadedAt.IsZero() && now.Sub(s.loadedAt) < velocityRuleCacheTTL {
+		rules := s.rules
+		s.mu.RUnlock()
+		return rules, nil
+	}
+	s.mu.RUnlock()
+
+	var rules []models.VelocityRule
+	if err := s.db.Where("is_active = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.rules = rules
+	s.loadedAt = now
+	s.mu.Unlock()
+	return rules, nil
+}
+
+// orderAddressFingerprint 规范化收货地址后取摘要：忽略大小写、空白与常见标点，未填写详细地址时返回空字符串
+func orderAddressFingerprint(order *models.Order) string {
+	if strings.TrimSpace(order.ReceiverAddress) == "" {
+		return ""
+	}
+	normalize := func(value string) string {
+		var b strings.Builder
+		for _, r := range strings.ToLower(value) {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+	parts := []string{
+		normalize(order.ReceiverCountry),
+		normalize(order.ReceiverProvince),
+		normalize(order.ReceiverCity),
+		normalize(order.ReceiverDistrict),
+		normalize(order.ReceiverAddress),
+		normalize(order.ReceiverPostcode),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// measure 统计截至订单创建时、时间窗口内的指标取值，提交收货信息时重新检查也不会计入之后的订单；
+// 订单缺少该指标所需的信息时 ok 为 false
+func (s *VelocityRuleService) measure(rule models.VelocityRule, order *models.Order) (int64, bool, error) {
+	query := s.db.Model(&models.Order{}).Where("created_at <= ?", order.CreatedAt)
+	if rule.WindowMinutes > 0 {
+		query = query.Where("created_at >= ?", order.CreatedAt.Add(-time.Duration(rule.WindowMinutes)*time.Minute))
+	}
+	switch rule.Metric {
+	case models.VelocityMetricOrdersPerIP:
+		if order.CreateIP == "" {
+			return 0, false, nil
+		}
+		query = query.Where("create_ip = ?", order.CreateIP)
+	case models.VelocityMetricOrdersPerUser:
+		if order.UserID == nil {
+			return 0, false, nil
+		}
+		query = query.Where("user_id = ?", *order.UserID)
+	case models.VelocityMetricAccountsPerIP:
+		if order.CreateIP == "" {
+			return 0, false, nil
+		}
+		query = query.Distinct("user_id").Where("create_ip = ? AND user_id IS NOT NULL", order.CreateIP)
+	case models.VelocityMetricAccountsPerAddress:
+		if order.AddressFingerprint == "" {
+			return 0, false, nil
+		}
+		query = query.Distinct("user_id").Where("address_fingerprint = ? AND user_id IS NOT NULL", order.AddressFingerprint)
+	default:
+		return 0, false, nil
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+// EvaluateOrder 记录订单的下单IP与收货地址指纹，并按生效的频率规则检查订单。
+// 下单时与提交收货信息时各调用一次；ip 只在订单尚未记录IP时写入。
+// 命中规则时创建或更新订单的审核记录并返回，未命中时返回 nil。服务为空时不做任何处理
+func (s *VelocityRuleService) EvaluateOrder(orderID uint, ip string) (*models.OrderRiskReview, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var order models.Order
+	if err := s.db.First(&order, orderID).Error; err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if ip = strings.TrimSpace(ip); ip != "" && order.CreateIP == "" {
+		order.CreateIP = ip
+		updates["create_ip"] = ip
+	}
+	if fingerprint := orderAddressFingerprint(&order); fingerprint != order.AddressFingerprint {
+		order.AddressFingerprint = fingerprint
+		updates["address_fingerprint"] = fingerprint
+	}
+	if len(updates) > 0 {
+		// 字段在模型上为只读，需按表名更新
+		if err := s.db.Table(models.Order{}.TableName()).Where("id = ?", order.ID).UpdateColumns(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := s.activeRules()
+	if err != nil {
+		return nil, err
+	}
+	var hits []models.OrderRiskHit
+	for _, rule := range rules {
+		value, ok, err := s.measure(rule, &order)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || value <= int64(rule.Threshold) {
+			continue
+		}
+		hits = append(hits, models.OrderRiskHit{
+			RuleID:        rule.ID,
+			RuleName:      rule.Name,
+			Metric:        rule.Metric,
+			Value:         value,
+			Threshold:     rule.Threshold,
+			WindowMinutes: rule.WindowMinutes,
+			Action:        rule.Action,
+		})
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	return s.recordHits(&order, hits, models.NowFunc())
+}
+
+// recordHits 将命中结果合并到订单的审核记录。已审核的记录只有出现新的命中规则时才重新进入待审核
+func (s *VelocityRuleService) recordHits(order *models.Order, hits []models.OrderRiskHit, now time.Time) (*models.OrderRiskReview, error) {
+	var review models.OrderRiskReview
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("order_id = ?", order.ID).First(&review).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		exists := err == nil
+
+		known := make(map[uint]int, len(review.Hits))
+		for i, hit := range review.Hits {
+			known[hit.RuleID] = i
+		}
+		var newRuleIDs []uint
+		for _, hit := range hits {
+			if i, ok := known[hit.RuleID]; ok {
+				review.Hits[i] = hit
+				continue
+			}
+			review.Hits = append(review.Hits, hit)
+			newRuleIDs = append(newRuleIDs, hit.RuleID)
+		}
+		if exists && len(newRuleIDs) == 0 {
+			return nil
+		}
+		for _, hit := range review.Hits {
+			if hit.Action.Severity() > review.Action.Severity() {
+				review.Action = hit.Action
+			}
+		}
+
+		if len(newRuleIDs) > 0 {
+			if err := tx.Model(&models.VelocityRule{}).Where("id IN ?", newRuleIDs).Updates(map[string]interface{}{
+				"hit_count":   gorm.Expr("hit_count + 1"),
+				"last_hit_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if review.Action == models.VelocityActionHold && !order.RiskHold {
+			if err := tx.Table(models.Order{}.TableName()).Where("id = ?", order.ID).UpdateColumn("risk_hold", true).Error; err != nil {
+				return err
+			}
+			order.RiskHold = true
+		}
+
+		review.OrderID = order.ID
+		review.OrderNo = order.OrderNo
+		review.UserID = order.UserID
+		review.IP = order.CreateIP
+		review.Status = models.OrderRiskReviewStatusPending
+		review.ReviewedBy = nil
+		review.ReviewedAt = nil
+		review.ReviewNote = ""
+		return tx.Save(&review).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// VelocityRuleInput 管理端创建/更新规则参数
+type VelocityRuleInput struct {
+	Name          string
+	Metric        models.VelocityMetric
+	Threshold     int
+	WindowMinutes int
+	Action        models.VelocityAction
+	IsActive      bool
+}
+
+func validateVelocityRuleInput(input *VelocityRuleInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || len([]rune(input.Name)) > maxVelocityRuleNameLength {
+		return bizerr.Newf("velocity.nameInvalid", "Rule name is required and cannot exceed %d characters", maxVelocityRuleNameLength).
+			WithParams(map[string]interface{}{"max": maxVelocityRuleNameLength})
+	}
+	valid := false
+	for _, metric := range models.VelocityMetrics {
+		if input.Metric == metric {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return bizerr.Newf("velocity.metricInvalid", "Invalid velocity metric: %s", input.Metric).
+			WithParams(map[string]interface{}{"metric": string(input.Metric)})
+	}
+	if input.Threshold < 1 {
+		return bizerr.New("velocity.thresholdInvalid", "Threshold must be at least 1")
+	}
+	if input.WindowMinutes < 0 || input.WindowMinutes > maxVelocityRuleWindowMinute {
+		return bizerr.Newf("velocity.windowInvalid", "Time window must be between 0 and %d minutes", maxVelocityRuleWindowMinute).
+			WithParams(map[string]interface{}{"max": maxVelocityRuleWindowMinute})
+	}
+	switch input.Action {
+	case models.VelocityActionFlag, models.VelocityActionHold:
+	default:
+		return bizerr.Newf("velocity.actionInvalid", "Invalid velocity action: %s", input.Action).
+			WithParams(map[string]interface{}{"action": string(input.Action)})
+	}
+	return nil
+}
+
+// ListRules 规则列表
+func (s *VelocityRuleService) ListRules() ([]models.VelocityRule, error) {
+	var rules []models.VelocityRule
+	if err := s.db.Order("id DESC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateRule 创建规则
+func (s *VelocityRuleService) CreateRule(adminID uint, input VelocityRuleInput) (*models.VelocityRule, error) {
+	if err := validateVelocityRuleInput(&input); err != nil {
+		return nil, err
+	}
+	rule := &models.VelocityRule{
+		Name:          input.Name,
+		Metric:        input.Metric,
+		Threshold:     input.Threshold,
+		WindowMinutes: input.WindowMinutes,
+		Action:        input.Action,
+		IsActive:      input.IsActive,
+		CreatedBy:     adminID,
+	}
+	if err := s.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// UpdateRule 更新规则，命中统计保留
+func (s *VelocityRuleService) UpdateRule(id uint, input VelocityRuleInput) (*models.VelocityRule, error) {
+	if err := validateVelocityRuleInput(&input); err != nil {
+		return nil, err
+	}
+	var rule models.VelocityRule
+	if err := s.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVelocityRuleNotFound
+		}
+		return nil, err
+	}
+	rule.Name = input.Name
+	rule.Metric = input.Metric
+	rule.Threshold = input.Threshold
+	rule.WindowMinutes = input.WindowMinutes
+	rule.Action = input.Action
+	rule.IsActive = input.IsActive
+	if err := s.db.Model(&rule).Select("name", "metric", "threshold", "window_minutes", "action", "is_active").Updates(&rule).Error; err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return &rule, nil
+}
+
+// DeleteRule 删除规则，已生成的审核记录保留
+func (s *VelocityRuleService) DeleteRule(id uint) error {
+	result := s.db.Delete(&models.VelocityRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVelocityRuleNotFound
+	}
+	s.invalidateRules()
+	return nil
+}
+
+// ListReviews 风控审核队列，支持按状态与处理方式筛选，默认按时间倒序
+func (s *VelocityRuleService) ListReviews(status, action string, page, limit int) ([]models.OrderRiskReview, int64, error) {
+	query := s.db.Model(&models.OrderRiskReview{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []models.OrderRiskReview
+	if err := query.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "email", "name")
+	}).Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// ReviewForOrder 订单的审核记录，没有记录时返回 nil
+func (s *VelocityRuleService) ReviewForOrder(orderID uint) (*models.OrderRiskReview, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var review models.OrderRiskReview
+	if err := s.db.Where("order_id = ?", orderID).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// PendingCount 待审核数量
+func (s *VelocityRuleService) PendingCount() (int64, error) {
+	var count int64
+	err := s.db.Model(&models.OrderRiskReview{}).Where("status = ?", models.OrderRiskReviewStatusPending).Count(&count).Error
+	return count, err
+}
+
+// Review 人工审核：approved 解除订单暂扣；rejected 保持暂扣，未付款订单由调用方取消
+func (s *VelocityRuleService) Review(id, adminID uint, decision models.OrderRiskReviewStatus, note string) (*models.OrderRiskReview, error) {
+	if decision != models.OrderRiskReviewStatusApproved && decision != models.OrderRiskReviewStatusRejected {
+		return nil, bizerr.New("velocity.decisionInvalid", "Decision must be approve or reject")
+	}
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxOrderRiskReviewNote {
+		return nil, bizerr.Newf("velocity.noteTooLong", "Review note cannot exceed %d characters", maxOrderRiskReviewNote).
+			WithParams(map[string]interface{}{"max": maxOrderRiskReviewNote})
+	}
+	var review models.OrderRiskReview
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&review, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderRiskReviewNotFound
+			}
+			return err
+		}
+		if review.Status != models.OrderRiskReviewStatusPending {
+			return bizerr.New("velocity.reviewAlreadyDone", "This order has already been reviewed")
+		}
+		if decision == models.OrderRiskReviewStatusApproved {
+			if err := tx.Table(models.Order{}.TableName()).Where("id = ?", review.OrderID).UpdateColumn("risk_hold", false).Error; err != nil {
+				return err
+			}
+		}
+		now := models.NowFunc()
+		review.Status = decision
+		review.ReviewedBy = &adminID
+		review.ReviewedAt = &now
+		review.ReviewNote = note
+		return tx.Save(&review).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
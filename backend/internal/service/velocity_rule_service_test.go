@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"auralogic/internal/models"
+)
+
+func TestVelocityRulesHoldAndFlagOrders(t *testing.T) {
+	orderSvc, db := newOrderServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VelocityRule{}, &models.OrderRiskReview{}); err != nil {
+		t.Fatalf("auto migrate velocity rules failed: %v", err)
+	}
+	svc := NewVelocityRuleService(db)
+	orderSvc.SetVelocityRuleService(svc)
+
+	_, err := svc.CreateRule(1, VelocityRuleInput{Name: "bad", Metric: "orders_per_card", Threshold: 1, Action: models.VelocityActionFlag})
+	requireOrderBizErr(t, err, "velocity.metricInvalid")
+	_, err = svc.CreateRule(1, VelocityRuleInput{Name: "bad", Metric: models.VelocityMetricOrdersPerIP, Threshold: 0, Action: models.VelocityActionFlag})
+	requireOrderBizErr(t, err, "velocity.thresholdInvalid")
+
+	ipRule, err := svc.CreateRule(1, VelocityRuleInput{
+		Name: "Burst from one IP", Metric: models.VelocityMetricOrdersPerIP,
+		Threshold: 2, WindowMinutes: 10, Action: models.VelocityActionHold, IsActive: true,
+	})
+	if err != nil {
+		t.Fatalf("create ip rule failed: %v", err)
+	}
+	if _, err := svc.CreateRule(1, VelocityRuleInput{
+		Name: "Shared address", Metric: models.VelocityMetricAccountsPerAddress,
+		Threshold: 1, Action: models.VelocityActionFlag, IsActive: true,
+	}); err != nil {
+		t.Fatalf("create address rule failed: %v", err)
+	}
+
+	createOrder := func(no string, userID uint) *models.Order {
+		t.Helper()
+		order := &models.Order{
+			OrderNo: no,
+			UserID:  &userID,
+			Items:   []models.OrderItem{{SKU: "SKU-1", Name: "Item", Quantity: 1}},
+			Status:  models.OrderStatusPending,
+		}
+		if err := db.Create(order).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+		return order
+	}
+
+	// 同一IP的前两单不超过阈值，第三单命中后被暂扣
+	var orders []*models.Order
+	for i := 1; i <= 3; i++ {
+		order := createOrder(fmt.Sprintf("ORD-VR-%d", i), uint(i))
+		orderSvc.EvaluateOrderRisk(order, "198.51.100.7")
+		orders = append(orders, order)
+	}
+	if orders[0].RiskHold || orders[1].RiskHold || !orders[2].RiskHold {
+		t.Fatalf("expected only the third order to be held, got %v %v %v", orders[0].RiskHold, orders[1].RiskHold, orders[2].RiskHold)
+	}
+	requireOrderBizErr(t, orderSvc.AssignTracking(orders[2].ID, "TRACK-1"), "order.riskHold")
+
+	// 不同账户填写同一地址（格式不同）时命中地址规则，只标记不暂扣
+	for _, item := range []struct {
+		order   *models.Order
+		address string
+	}{
+		{orders[0], "Room 5, 10 Market Street"},
+		{orders[1], "room 5 10 market-street "},
+	} {
+		if err := db.Model(item.order).Updates(map[string]interface{}{
+			"receiver_country": "US", "receiver_city": "Springfield", "receiver_address": item.address,
+		}).Error; err != nil {
+			t.Fatalf("update address failed: %v", err)
+		}
+		orderSvc.EvaluateOrderRisk(item.order, "")
+	}
+	review, err := orderSvc.RiskReview(orders[1].ID)
+	if err != nil || review == nil || review.Action != models.VelocityActionFlag || len(review.Hits) != 1 ||
+		review.Hits[0].Metric != models.VelocityMetricAccountsPerAddress || review.Hits[0].Value != 2 {
+		t.Fatalf("expected address flag review, got %+v err=%v", review, err)
+	}
+	if review.IP != "198.51.100.7" {
+		t.Fatalf("expected review to keep the order IP, got %q", review.IP)
+	}
+
+	reviews, total, err := svc.ListReviews(string(models.OrderRiskReviewStatusPending), "", 1, 20)
+	if err != nil || total != 2 || len(reviews) != 2 {
+		t.Fatalf("expected 2 pending reviews, got %d err=%v", total, err)
+	}
+	var rule models.VelocityRule
+	if err := db.First(&rule, ipRule.ID).Error; err != nil || rule.HitCount != 1 || rule.LastHitAt == nil {
+		t.Fatalf("expected ip rule hit recorded once, got %+v err=%v", rule, err)
+	}
+
+	// 审核通过后解除暂扣，可以正常发货；重复审核被拒绝
+	held, _ := orderSvc.RiskReview(orders[2].ID)
+	if _, err := svc.Review(held.ID, 1, "maybe", ""); err == nil {
+		t.Fatalf("expected invalid decision to be rejected")
+	}
+	if _, err := svc.Review(held.ID, 1, models.OrderRiskReviewStatusApproved, "Known reseller"); err != nil {
+		t.Fatalf("approve review failed: %v", err)
+	}
+	_, err = svc.Review(held.ID, 1, models.OrderRiskReviewStatusRejected, "")
+	requireOrderBizErr(t, err, "velocity.reviewAlreadyDone")
+	if err := orderSvc.AssignTracking(orders[2].ID, "TRACK-1"); err != nil {
+		t.Fatalf("expected approved order to ship, got %v", err)
+	}
+
+	// 保存订单不会覆盖风控字段
+	var saved models.Order
+	if err := db.First(&saved, orders[0].ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	saved.CreateIP = ""
+	saved.AdminRemark = "checked"
+	if err := orderSvc.UpdateOrder(&saved); err != nil {
+		t.Fatalf("update order failed: %v", err)
+	}
+	var reloaded models.Order
+	db.First(&reloaded, orders[0].ID)
+	if reloaded.CreateIP != "198.51.100.7" || reloaded.AddressFingerprint == "" {
+		t.Fatalf("expected risk fields to survive order save, got ip=%q fingerprint=%q", reloaded.CreateIP, reloaded.AddressFingerprint)
+	}
+}
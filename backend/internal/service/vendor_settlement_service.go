@@ -0,0 +1,529 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/money"
+	"gorm.io/gorm"
+)
+
+const (
+	maxVendorNameLength       = 100
+	maxVendorLedgerNoteLength = 500
+)
+
+var (
+	ErrVendorNotFound          = errors.New("vendor not found")
+	ErrVendorStatementNotFound = errors.New("vendor statement not found")
+)
+
+// VendorInput 创建/更新商家
+type VendorInput struct {
+	Name           string
+	ContactEmail   string
+	CommissionRate int64
+	PayoutAccount  string
+	Status         models.VendorStatus
+	Remark         string
+}
+
+// VendorBalance 商家某币种的未结算应付金额
+type VendorBalance struct {
+	Currency    string `json:"currency"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// VendorOverview 商家列表项
+type VendorOverview struct {
+	models.Vendor
+	ProductCount int64           `json:"product_count"`
+	Unsettled    []VendorBalance `json:"unsettled"`
+}
+
+// VendorSettlementService 商家分账：付款时按商品行计提佣金，退款冲回，按结算周期生成打款结算单
+type VendorSettlementService struct {
+	db *gorm.DB
+}
+
+func NewVendorSettlementService(db *gorm.DB) *VendorSettlementService {
+	return &VendorSettlementService{db: db}
+}
+
+func normalizeVendorInput(input *VendorInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.ContactEmail = strings.TrimSpace(input.ContactEmail)
+	input.PayoutAccount = strings.TrimSpace(input.PayoutAccount)
+	input.Remark = strings.TrimSpace(input.Remark)
+	if input.Name == "" || len([]rune(input.Name)) > maxVendorNameLength {
+		return bizerr.Newf("vendor.nameInvalid", "Vendor name is required and cannot exceed %d characters", maxVendorNameLength).
+			WithParams(map[string]interface{}{"max": maxVendorNameLength})
+	}
+	if input.CommissionRate < 0 || input.CommissionRate > money.PercentageScale {
+		return bizerr.New("vendor.commissionRateInvalid", "Commission must be between 0% and 100%").
+			WithParams(map[string]interface{}{"min": 0, "max": 100})
+	}
+	if input.Status == "" {
+		input.Status = models.VendorStatusActive
+	}
+	if input.Status != models.VendorStatusActive && input.Status != models.VendorStatusDisabled {
+		return bizerr.Newf("vendor.statusInvalid", "Invalid vendor status: %s", input.Status).
+			WithParams(map[string]interface{}{"status": input.Status})
+	}
+	return nil
+}
+
+// ListVendors 商家列表，附带关联商品数与未结算应付金额
+func (s *VendorSettlementService) ListVendors() ([]VendorOverview, error) {
+	var vendors []models.Vendor
+	if err := s.db.Order("id ASC").Find(&vendors).Error; err != nil {
+		return nil, err
+	}
+
+	var productCounts []struct {
+		VendorID uint
+		Count    int64
+	}
+	if err := s.db.Model(&models.Product{}).Select("vendor_id, COUNT(*) AS count").
+		Where("vendor_id IS NOT NULL").Group("vendor_id").Scan(&productCounts).Error; err != nil {
+		return nil, err
+	}
+	countByVendor := make(map[uint]int64, len(productCounts))
+	for _, row := range productCounts {
+		countByVendor[row.VendorID] = row.Count
+	}
+
+	var balances []struct {
+		VendorID uint
+		Currency string
+		Amount   int64
+	}
+	if err := s.db.Model(&models.VendorLedgerEntry{}).Select("vendor_id, currency, SUM(net_amount) AS amount").
+		Where("statement_id IS NULL").Group("vendor_id, currency").Scan(&balances).Error; err != nil {
+		return nil, err
+	}
+	balancesByVendor := make(map[uint][]VendorBalance)
+	for _, row := range balances {
+		balancesByVendor[row.VendorID] = append(balancesByVendor[row.VendorID], VendorBalance{Currency: row.Currency, AmountMinor: row.Amount})
+	}
+
+	result := make([]VendorOverview, 0, len(vendors))
+	for _, vendor := range vendors {
+		unsettled := balancesByVendor[vendor.ID]
+		if unsettled == nil {
+			unsettled = []VendorBalance{}
+		}
+		result = append(result, VendorOverview{
+			Vendor:       vendor,
+			ProductCount: countByVendor[vendor.ID],
+			Unsettled:    unsettled,
+		})
+	}
+	return result, nil
+}
+
+// GetVendor 商家详情
+func (s *VendorSettlementService) GetVendor(id uint) (*models.Vendor, error) {
+	var vendor models.Vendor
+	if err := s.db.First(&vendor, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVendorNotFound
+		}
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+// CreateVendor 创建商家
+func (s *VendorSettlementService) CreateVendor(input VendorInput) (*models.Vendor, error) {
+	if err := normalizeVendorInput(&input); err != nil {
+		return nil, err
+	}
+	vendor := &models.Vendor{
+		Name:           input.Name,
+		ContactEmail:   input.ContactEmail,
+		CommissionRate: input.CommissionRate,
+		PayoutAccount:  input.PayoutAccount,
+		Status:         input.Status,
+		Remark:         input.Remark,
+	}
+	if err := s.db.Create(vendor).Error; err != nil {
+		return nil, err
+	}
+	return vendor, nil
+}
+
+// UpdateVendor 更新商家，佣金比例调整只影响之后付款的订单
+func (s *VendorSettlementService) UpdateVendor(id uint, input VendorInput) (*models.Vendor, error) {
+	if err := normalizeVendorInput(&input); err != nil {
+		return nil, err
+	}
+	vendor, err := s.GetVendor(id)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{
+		"name":            input.Name,
+		"contact_email":   input.ContactEmail,
+		"commission_rate": input.CommissionRate,
+		"payout_account":  input.PayoutAccount,
+		"status":          input.Status,
+		"remark":          input.Remark,
+	}
+	if err := s.db.Model(vendor).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return s.GetVendor(id)
+}
+
+// AssignProductVendor 设置商品所属商家，vendorID 为空表示平台自营；只影响之后创建的订单
+func (s *VendorSettlementService) AssignProductVendor(productID uint, vendorID *uint) (*models.Product, error) {
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if vendorID != nil {
+		if _, err := s.GetVendor(*vendorID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.db.Model(&product).Update("vendor_id", vendorID).Error; err != nil {
+		return nil, err
+	}
+	product.VendorID = vendorID
+	return &product, nil
+}
+
+// ListLedger 商家台账，unsettledOnly 为 true 时只返回尚未结算的条目
+func (s *VendorSettlementService) ListLedger(vendorID uint, unsettledOnly bool, page, limit int) ([]models.VendorLedgerEntry, int64, error) {
+	query := s.db.Model(&models.VendorLedgerEntry{}).Where("vendor_id = ?", vendorID)
+	if unsettledOnly {
+		query = query.Where("statement_id IS NULL")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var entries []models.VendorLedgerEntry
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
+
+// CreateAdjustment 手动调整条目：正数为补发给商家，负数为扣回
+func (s *VendorSettlementService) CreateAdjustment(vendorID, adminID uint, currency string, amount int64, note string) (*models.VendorLedgerEntry, error) {
+	note = strings.TrimSpace(note)
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if amount == 0 {
+		return nil, bizerr.New("vendor.adjustmentAmountInvalid", "Adjustment amount cannot be zero")
+	}
+	if note == "" || len([]rune(note)) > maxVendorLedgerNoteLength {
+		return nil, bizerr.Newf("vendor.adjustmentNoteInvalid", "Adjustment note is required and cannot exceed %d characters", maxVendorLedgerNoteLength).
+			WithParams(map[string]interface{}{"max": maxVendorLedgerNoteLength})
+	}
+	if currency == "" {
+		return nil, bizerr.New("vendor.currencyRequired", "Currency is required")
+	}
+	if _, err := s.GetVendor(vendorID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.VendorLedgerEntry{
+		VendorID:    vendorID,
+		Type:        models.VendorLedgerEntryAdjustment,
+		Currency:    currency,
+		GrossAmount: amount,
+		NetAmount:   amount,
+		Note:        note,
+		CreatedBy:   &adminID,
+	}
+	if err := s.db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// allocateOrderItemAmounts 将订单实收商品金额（扣除付款方式手续费）按商品行原价比例分摊，
+// 优惠码折扣与管理员改价随之分摊，最后一行承担取整差额
+func allocateOrderItemAmounts(order *models.Order) []int64 {
+	amounts := make([]int64, len(order.Items))
+	var subtotal int64
+	for i, item := range order.Items {
+		amounts[i] = item.UnitPrice * int64(item.Quantity)
+		subtotal += amounts[i]
+	}
+	goods := order.TotalAmount - order.PaymentFee
+	if subtotal <= 0 || goods == subtotal {
+		return amounts
+	}
+
+	var allocated int64
+	last := len(amounts) - 1
+	for i := range amounts {
+		if i == last {
+			amounts[i] = goods - allocated
+			break
+		}
+		amounts[i] = int64(float64(goods) * float64(amounts[i]) / float64(subtotal))
+		allocated += amounts[i]
+	}
+	return amounts
+}
+
+// recordVendorSalesTx 订单付款后按商品行为所属商家记账，重复调用不会重复记账
+func recordVendorSalesTx(tx *gorm.DB, order *models.Order) error {
+	vendorIDs := make([]uint, 0)
+	for _, item := range order.Items {
+		if item.VendorID != nil {
+			vendorIDs = append(vendorIDs, *item.VendorID)
+		}
+	}
+	if len(vendorIDs) == 0 {
+		return nil
+	}
+
+	var existing int64
+	if err := tx.Model(&models.VendorLedgerEntry{}).
+		Where("order_id = ? AND type = ?", order.ID, models.VendorLedgerEntrySale).
+		Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	var vendors []models.Vendor
+	if err := tx.Where("id IN ?", vendorIDs).Find(&vendors).Error; err != nil {
+		return err
+	}
+	vendorByID := make(map[uint]models.Vendor, len(vendors))
+	for _, vendor := range vendors {
+		vendorByID[vendor.ID] = vendor
+	}
+
+	amounts := allocateOrderItemAmounts(order)
+	orderID := order.ID
+	entries := make([]models.VendorLedgerEntry, 0, len(vendorIDs))
+	for idx, item := range order.Items {
+		if item.VendorID == nil {
+			continue
+		}
+		vendor, ok := vendorByID[*item.VendorID]
+		if !ok {
+			continue
+		}
+		gross := amounts[idx]
+		commission := money.ApplyPercentage(gross, vendor.CommissionRate)
+		entries = append(entries, models.VendorLedgerEntry{
+			VendorID:    vendor.ID,
+			Type:        models.VendorLedgerEntrySale,
+			OrderID:     &orderID,
+			OrderNo:     order.OrderNo,
+			ItemIndex:   idx,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Currency:    order.Currency,
+			GrossAmount: gross,
+			Commission:  commission,
+			NetAmount:   gross - commission,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return tx.Create(&entries).Error
+}
+
+// RecordVendorRefundTx 订单退款后冲回该订单的商家销售条目；已结算的条目冲回计入下一期结算单
+func RecordVendorRefundTx(tx *gorm.DB, order *models.Order) error {
+	var sales []models.VendorLedgerEntry
+	if err := tx.Where("order_id = ? AND type = ?", order.ID, models.VendorLedgerEntrySale).
+		Order("id ASC").Find(&sales).Error; err != nil {
+		return err
+	}
+	if len(sales) == 0 {
+		return nil
+	}
+
+	var existing int64
+	if err := tx.Model(&models.VendorLedgerEntry{}).
+		Where("order_id = ? AND type = ?", order.ID, models.VendorLedgerEntryRefund).
+		Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	refunds := make([]models.VendorLedgerEntry, 0, len(sales))
+	for _, sale := range sales {
+		refunds = append(refunds, models.VendorLedgerEntry{
+			VendorID:    sale.VendorID,
+			Type:        models.VendorLedgerEntryRefund,
+			OrderID:     sale.OrderID,
+			OrderNo:     sale.OrderNo,
+			ItemIndex:   sale.ItemIndex,
+			SKU:         sale.SKU,
+			Quantity:    sale.Quantity,
+			Currency:    sale.Currency,
+			GrossAmount: -sale.GrossAmount,
+			Commission:  -sale.Commission,
+			NetAmount:   -sale.NetAmount,
+		})
+	}
+	return tx.Create(&refunds).Error
+}
+
+// GenerateStatements 为截止 periodEnd 前所有未结算的台账条目生成结算单（按商家和币种各一张），
+// 早于 periodStart 的未结算条目（如已结算订单的退款冲回）一并计入
+func (s *VendorSettlementService) GenerateStatements(periodStart, periodEnd time.Time, adminID uint) ([]models.VendorPayoutStatement, error) {
+	if !periodStart.Before(periodEnd) || periodEnd.After(time.Now()) {
+		return nil, bizerr.New("vendor.statementPeriodInvalid", "The settlement period must end in the past and after it starts")
+	}
+
+	statements := make([]models.VendorPayoutStatement, 0)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var entries []models.VendorLedgerEntry
+		if err := tx.Where("statement_id IS NULL AND created_at < ?", periodEnd).
+			Order("id ASC").Find(&entries).Error; err != nil {
+			return err
+		}
+
+		type groupKey struct {
+			vendorID uint
+			currency string
+		}
+		grouped := make(map[groupKey][]models.VendorLedgerEntry)
+		keys := make([]groupKey, 0)
+		for _, entry := range entries {
+			key := groupKey{vendorID: entry.VendorID, currency: entry.Currency}
+			if _, ok := grouped[key]; !ok {
+				keys = append(keys, key)
+			}
+			grouped[key] = append(grouped[key], entry)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].vendorID != keys[j].vendorID {
+				return keys[i].vendorID < keys[j].vendorID
+			}
+			return keys[i].currency < keys[j].currency
+		})
+
+		for _, key := range keys {
+			group := grouped[key]
+			statement := models.VendorPayoutStatement{
+				VendorID:    key.vendorID,
+				Currency:    key.currency,
+				PeriodStart: periodStart,
+				PeriodEnd:   periodEnd,
+				EntryCount:  len(group),
+				Status:      models.VendorStatementStatusPending,
+				CreatedBy:   &adminID,
+			}
+			ids := make([]uint, 0, len(group))
+			for _, entry := range group {
+				ids = append(ids, entry.ID)
+				switch entry.Type {
+				case models.VendorLedgerEntrySale:
+					statement.SalesAmount += entry.GrossAmount
+					statement.CommissionAmount += entry.Commission
+				case models.VendorLedgerEntryRefund:
+					statement.RefundAmount += entry.GrossAmount
+					statement.CommissionAmount += entry.Commission
+				default:
+					statement.AdjustmentAmount += entry.NetAmount
+				}
+				statement.PayoutAmount += entry.NetAmount
+			}
+			if err := tx.Create(&statement).Error; err != nil {
+				return err
+			}
+			result := tx.Model(&models.VendorLedgerEntry{}).
+				Where("id IN ? AND statement_id IS NULL", ids).
+				Update("statement_id", statement.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(ids)) {
+				return fmt.Errorf("vendor ledger entries changed during settlement, please retry")
+			}
+			statements = append(statements, statement)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// ListStatements 结算单列表，vendorID 为 0 或 status 为空时不过滤
+func (s *VendorSettlementService) ListStatements(vendorID uint, status string, page, limit int) ([]models.VendorPayoutStatement, int64, error) {
+	query := s.db.Model(&models.VendorPayoutStatement{})
+	if vendorID > 0 {
+		query = query.Where("vendor_id = ?", vendorID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var statements []models.VendorPayoutStatement
+	err := query.Preload("Vendor").Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&statements).Error
+	return statements, total, err
+}
+
+// GetStatement 结算单及其包含的台账条目
+func (s *VendorSettlementService) GetStatement(id uint) (*models.VendorPayoutStatement, []models.VendorLedgerEntry, error) {
+	var statement models.VendorPayoutStatement
+	if err := s.db.Preload("Vendor").First(&statement, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrVendorStatementNotFound
+		}
+		return nil, nil, err
+	}
+	var entries []models.VendorLedgerEntry
+	if err := s.db.Where("statement_id = ?", id).Order("id ASC").Find(&entries).Error; err != nil {
+		return nil, nil, err
+	}
+	return &statement, entries, nil
+}
+
+// MarkStatementPaid 标记结算单已打款
+func (s *VendorSettlementService) MarkStatementPaid(id, adminID uint, reference string) (*models.VendorPayoutStatement, error) {
+	reference = strings.TrimSpace(reference)
+	if len([]rune(reference)) > 255 {
+		return nil, bizerr.Newf("vendor.paymentReferenceTooLong", "Payment reference cannot exceed %d characters", 255).
+			WithParams(map[string]interface{}{"max": 255})
+	}
+	if _, _, err := s.GetStatement(id); err != nil {
+		return nil, err
+	}
+
+	now := models.NowFunc()
+	result := s.db.Model(&models.VendorPayoutStatement{}).
+		Where("id = ? AND status = ?", id, models.VendorStatementStatusPending).
+		Updates(map[string]interface{}{
+			"status":            models.VendorStatementStatusPaid,
+			"paid_at":           now,
+			"paid_by":           adminID,
+			"payment_reference": reference,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, bizerr.New("vendor.statementAlreadyPaid", "This statement has already been marked as paid")
+	}
+	statement, _, err := s.GetStatement(id)
+	return statement, err
+}
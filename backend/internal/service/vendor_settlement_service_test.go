@@ -0,0 +1,124 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func newVendorSettlementTestService(t *testing.T) (*VendorSettlementService, *models.Vendor) {
+	t.Helper()
+	db := openPluginManagerE2ETestDB(t)
+	if err := db.AutoMigrate(&models.Order{}, &models.Vendor{}, &models.VendorLedgerEntry{}, &models.VendorPayoutStatement{}); err != nil {
+		t.Fatalf("auto migrate vendor tables failed: %v", err)
+	}
+	svc := NewVendorSettlementService(db)
+	vendor, err := svc.CreateVendor(VendorInput{Name: "Acme Supply", CommissionRate: 1000})
+	if err != nil {
+		t.Fatalf("create vendor failed: %v", err)
+	}
+	return svc, vendor
+}
+
+// backdateUnsettledVendorEntries 将未结算条目的记账时间前移，使其落入已结束的结算周期
+func backdateUnsettledVendorEntries(t *testing.T, svc *VendorSettlementService) {
+	t.Helper()
+	if err := svc.db.Model(&models.VendorLedgerEntry{}).
+		Where("statement_id IS NULL").
+		Update("created_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("backdate vendor entries failed: %v", err)
+	}
+}
+
+func TestVendorSettlementRecordsSalesAndRefundAdjustments(t *testing.T) {
+	svc, vendor := newVendorSettlementTestService(t)
+	vendorID := vendor.ID
+
+	// 两行商品原价 6000 + 4000，优惠后实收 9000（另含 200 付款方式附加费，不参与分账）
+	order := &models.Order{
+		OrderNo:     "VENDOR-1",
+		Status:      models.OrderStatusPending,
+		Currency:    "CNY",
+		TotalAmount: 9200,
+		PaymentFee:  200,
+		Items: []models.OrderItem{
+			{SKU: "V-1", Name: "Vendor item", Quantity: 2, UnitPrice: 3000, VendorID: &vendorID},
+			{SKU: "P-1", Name: "Platform item", Quantity: 1, UnitPrice: 4000},
+		},
+	}
+	if err := svc.db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := recordVendorSalesTx(svc.db, order); err != nil {
+			t.Fatalf("record vendor sales failed: %v", err)
+		}
+	}
+	entries, total, err := svc.ListLedger(vendorID, false, 1, 20)
+	if err != nil {
+		t.Fatalf("list ledger failed: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected sales to be recorded once, got %d entries", total)
+	}
+	sale := entries[0]
+	if sale.GrossAmount != 5400 || sale.Commission != 540 || sale.NetAmount != 4860 {
+		t.Fatalf("unexpected sale entry: gross=%d commission=%d net=%d", sale.GrossAmount, sale.Commission, sale.NetAmount)
+	}
+
+	backdateUnsettledVendorEntries(t, svc)
+	periodEnd := time.Now()
+	statements, err := svc.GenerateStatements(periodEnd.AddDate(0, -1, 0), periodEnd, 1)
+	if err != nil {
+		t.Fatalf("generate statements failed: %v", err)
+	}
+	if len(statements) != 1 || statements[0].PayoutAmount != 4860 || statements[0].SalesAmount != 5400 {
+		t.Fatalf("unexpected first statement: %+v", statements)
+	}
+	if _, err := svc.MarkStatementPaid(statements[0].ID, 1, "TXN-1"); err != nil {
+		t.Fatalf("mark statement paid failed: %v", err)
+	}
+	_, err = svc.MarkStatementPaid(statements[0].ID, 1, "TXN-1")
+	requireBizErr(t, err, "vendor.statementAlreadyPaid")
+
+	// 已结算订单退款后，冲回条目进入下一期结算单
+	if err := RecordVendorRefundTx(svc.db, order); err != nil {
+		t.Fatalf("record vendor refund failed: %v", err)
+	}
+	if err := RecordVendorRefundTx(svc.db, order); err != nil {
+		t.Fatalf("record vendor refund again failed: %v", err)
+	}
+	if _, err := svc.CreateAdjustment(vendorID, 1, "cny", 300, "Shipping subsidy"); err != nil {
+		t.Fatalf("create adjustment failed: %v", err)
+	}
+
+	backdateUnsettledVendorEntries(t, svc)
+	periodEnd = time.Now()
+	statements, err = svc.GenerateStatements(periodEnd.AddDate(0, -1, 0), periodEnd, 1)
+	if err != nil {
+		t.Fatalf("generate second statements failed: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("expected one carry-over statement, got %d", len(statements))
+	}
+	next := statements[0]
+	if next.EntryCount != 2 || next.SalesAmount != 0 || next.RefundAmount != -5400 || next.CommissionAmount != -540 || next.AdjustmentAmount != 300 || next.PayoutAmount != -4560 {
+		t.Fatalf("unexpected carry-over statement: %+v", next)
+	}
+}
+
+func TestVendorSettlementValidatesInput(t *testing.T) {
+	svc, vendor := newVendorSettlementTestService(t)
+
+	_, err := svc.CreateVendor(VendorInput{Name: "Too greedy", CommissionRate: 10001})
+	requireBizErr(t, err, "vendor.commissionRateInvalid")
+
+	_, err = svc.CreateAdjustment(vendor.ID, 1, "CNY", 0, "noop")
+	requireBizErr(t, err, "vendor.adjustmentAmountInvalid")
+
+	now := time.Now()
+	_, err = svc.GenerateStatements(now, now.AddDate(0, 1, 0), 1)
+	requireBizErr(t, err, "vendor.statementPeriodInvalid")
+}
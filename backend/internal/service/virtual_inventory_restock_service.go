@@ -0,0 +1,508 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	restockJobInterval        = 5 * time.Minute
+	defaultRestockCooldown    = 30 * time.Minute
+	maxRestockCooldownMinutes = 24 * 60
+	maxRestockThreshold       = 1000000
+	maxRestockQuantity        = 10000
+	restockManualGuard        = time.Minute // 手动补货与正在执行的补货之间的最小间隔，避免重复采购
+	restockAlertInterval      = 6 * time.Hour
+	restockImportedBy         = "auto-restock"
+	restockMaxMessageLength   = 500
+	restockMaxReferenceLength = 255
+)
+
+var (
+	errRestockStaticOnly    = bizerr.Register("virtual_inventory.restockStaticOnly", 400, "Automatic restock is only available for static inventories")
+	errRestockNotConfigured = bizerr.Register("virtual_inventory.restockNotConfigured", 404, "Automatic restock is not configured for this inventory")
+	errRestockBusy          = bizerr.Register("virtual_inventory.restockBusy", 409, "A restock for this inventory has just run, please try again shortly")
+	errRestockCapReached    = bizerr.Register("virtual_inventory.restockCapReached", 409, "The restock spend cap has been reached")
+)
+
+func newRestockPolicyInvalidError(field string) error {
+	return bizerr.Newf("virtual_inventory.restockPolicyInvalid", "Invalid restock setting: %s", field).
+		WithParams(map[string]interface{}{"field": field})
+}
+
+// VirtualInventoryRestockInput 保存补货策略的参数
+type VirtualInventoryRestockInput struct {
+	Enabled              bool   `json:"enabled"`
+	Threshold            int    `json:"threshold"`
+	Quantity             int    `json:"quantity"`
+	Script               string `json:"script"`
+	ScriptConfig         string `json:"script_config"`
+	CooldownMinutes      int    `json:"cooldown_minutes"`
+	DailySpendCapMinor   int64  `json:"daily_spend_cap_minor"`
+	MonthlySpendCapMinor int64  `json:"monthly_spend_cap_minor"`
+}
+
+// VirtualInventoryRestockOverview 补货策略与本日/本月采购花费
+type VirtualInventoryRestockOverview struct {
+	Policy          *models.VirtualInventoryRestockPolicy `json:"policy"`
+	Available       int64                                 `json:"available"`
+	SpentTodayMinor int64                                 `json:"spent_today_minor"`
+	SpentMonthMinor int64                                 `json:"spent_month_minor"`
+	Currency        string                                `json:"currency"`
+}
+
+// VirtualInventoryRestockService 虚拟库存自动补货
+// 定时检查启用了补货策略的静态库存，可用库存低于阈值时执行供应商脚本采购并导入卡密
+type VirtualInventoryRestockService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	inventory    *VirtualInventoryService
+	emailService *EmailService
+}
+
+func NewVirtualInventoryRestockService(db *gorm.DB, cfg *config.Config, inventory *VirtualInventoryService, emailService *EmailService) *VirtualInventoryRestockService {
+	return &VirtualInventoryRestockService{db: db, cfg: cfg, inventory: inventory, emailService: emailService}
+}
+
+func (s *VirtualInventoryRestockService) defaultCurrency() string {
+	if s.cfg != nil && s.cfg.Order.Currency != "" {
+		return s.cfg.Order.Currency
+	}
+	return "CNY"
+}
+
+func (s *VirtualInventoryRestockService) loadStaticInventory(inventoryID uint) (*models.VirtualInventory, error) {
+	var inventory models.VirtualInventory
+	if err := s.db.First(&inventory, inventoryID).Error; err != nil {
+		return nil, bizerr.FromStore(err, errVirtualInventoryNotFound)
+	}
+	if inventory.Type != models.VirtualInventoryTypeStatic {
+		return nil, errRestockStaticOnly.New()
+	}
+	return &inventory, nil
+}
+
+func (s *VirtualInventoryRestockService) findPolicy(inventoryID uint) (*models.VirtualInventoryRestockPolicy, error) {
+	var policy models.VirtualInventoryRestockPolicy
+	if err := s.db.Where("virtual_inventory_id = ?", inventoryID).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s *VirtualInventoryRestockService) availableStock(inventoryID uint) (int64, error) {
+	var available int64
+	err := s.db.Model(&models.VirtualProductStock{}).
+		Where("virtual_inventory_id = ? AND status = ?", inventoryID, models.VirtualStockStatusAvailable).
+		Count(&available).Error
+	return available, err
+}
+
+// spent 统计本日与本月（UTC）的采购花费
+func (s *VirtualInventoryRestockService) spent(inventoryID uint, now time.Time) (int64, int64, error) {
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var totals struct {
+		Today int64
+		Month int64
+	}
+	err := s.db.Model(&models.VirtualInventoryRestockRun{}).
+		Select("COALESCE(SUM(CASE WHEN created_at >= ? THEN cost_minor ELSE 0 END), 0) AS today, COALESCE(SUM(cost_minor), 0) AS month", dayStart).
+		Where("virtual_inventory_id = ? AND created_at >= ?", inventoryID, monthStart).
+		Scan(&totals).Error
+	return totals.Today, totals.Month, err
+}
+
+// remainingBudget 按每日/每月上限计算剩余可用金额，-1 表示不限制；period 为剩余额度更少的周期
+func remainingBudget(policy *models.VirtualInventoryRestockPolicy, spentToday, spentMonth int64) (int64, string) {
+	budget, period := int64(-1), ""
+	if policy.DailySpendCapMinor > 0 {
+		budget, period = max(policy.DailySpendCapMinor-spentToday, 0), "daily"
+	}
+	if policy.MonthlySpendCapMinor > 0 {
+		if left := max(policy.MonthlySpendCapMinor-spentMonth, 0); budget < 0 || left < budget {
+			budget, period = left, "monthly"
+		}
+	}
+	return budget, period
+}
+
+// Overview 补货策略与当前花费，未配置时 Policy 为空
+func (s *VirtualInventoryRestockService) Overview(inventoryID uint) (*VirtualInventoryRestockOverview, error) {
+	if _, err := s.loadStaticInventory(inventoryID); err != nil {
+		return nil, err
+	}
+	policy, err := s.findPolicy(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	available, err := s.availableStock(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	today, month, err := s.spent(inventoryID, models.NowFunc())
+	if err != nil {
+		return nil, err
+	}
+	return &VirtualInventoryRestockOverview{
+		Policy:          policy,
+		Available:       available,
+		SpentTodayMinor: today,
+		SpentMonthMinor: month,
+		Currency:        s.defaultCurrency(),
+	}, nil
+}
+
+func validateRestockInput(input *VirtualInventoryRestockInput) error {
+	input.Script = strings.TrimSpace(input.Script)
+	input.ScriptConfig = strings.TrimSpace(input.ScriptConfig)
+	switch {
+	case input.Threshold < 0 || input.Threshold > maxRestockThreshold || (input.Enabled && input.Threshold == 0):
+		return newRestockPolicyInvalidError("threshold")
+	case input.Quantity < 0 || input.Quantity > maxRestockQuantity || (input.Enabled && input.Quantity == 0):
+		return newRestockPolicyInvalidError("quantity")
+	case input.CooldownMinutes < 0 || input.CooldownMinutes > maxRestockCooldownMinutes:
+		return newRestockPolicyInvalidError("cooldown_minutes")
+	case input.DailySpendCapMinor < 0:
+		return newRestockPolicyInvalidError("daily_spend_cap_minor")
+	case input.MonthlySpendCapMinor < 0:
+		return newRestockPolicyInvalidError("monthly_spend_cap_minor")
+	case input.Enabled && input.Script == "":
+		return newRestockPolicyInvalidError("script")
+	}
+	if input.Script != "" {
+		if _, err := getOrCompileJSProgram("virtual_inventory_restock", input.Script); err != nil {
+			return bizerr.Newf("virtual_inventory.restockScriptInvalid", "Restock script has a syntax error: %v", err).
+				WithParams(map[string]interface{}{"error": err.Error()})
+		}
+	}
+	if input.ScriptConfig != "" {
+		var configData map[string]interface{}
+		if err := json.Unmarshal([]byte(input.ScriptConfig), &configData); err != nil {
+			return newRestockPolicyInvalidError("script_config")
+		}
+	}
+	return nil
+}
+
+// SavePolicy 创建或更新库存的补货策略
+func (s *VirtualInventoryRestockService) SavePolicy(inventoryID uint, input VirtualInventoryRestockInput, adminID *uint) (*models.VirtualInventoryRestockPolicy, error) {
+	if _, err := s.loadStaticInventory(inventoryID); err != nil {
+		return nil, err
+	}
+	if err := validateRestockInput(&input); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.findPolicy(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.VirtualInventoryRestockPolicy{VirtualInventoryID: inventoryID}
+	}
+	policy.Enabled = input.Enabled
+	policy.Threshold = input.Threshold
+	policy.Quantity = input.Quantity
+	policy.Script = input.Script
+	policy.ScriptConfig = input.ScriptConfig
+	policy.CooldownMinutes = input.CooldownMinutes
+	policy.DailySpendCapMinor = input.DailySpendCapMinor
+	policy.MonthlySpendCapMinor = input.MonthlySpendCapMinor
+	policy.UpdatedBy = adminID
+	if err := s.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ListRuns 分页查询库存的补货采购记录
+func (s *VirtualInventoryRestockService) ListRuns(inventoryID uint, status string, page, limit int) ([]models.VirtualInventoryRestockRun, int64, error) {
+	query := s.db.Model(&models.VirtualInventoryRestockRun{}).Where("virtual_inventory_id = ?", inventoryID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var runs []models.VirtualInventoryRestockRun
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// RunNow 管理员手动补货：忽略阈值与冷却时间，但仍受消费上限约束
+func (s *VirtualInventoryRestockService) RunNow(inventoryID uint, adminID *uint) (*models.VirtualInventoryRestockRun, error) {
+	inventory, err := s.loadStaticInventory(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.findPolicy(inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil || policy.Script == "" || policy.Quantity <= 0 {
+		return nil, errRestockNotConfigured.New()
+	}
+	return s.restock(inventory, policy, models.VirtualInventoryRestockTriggerManual, adminID)
+}
+
+// RegisterJobs 注册自动补货定时任务
+func (s *VirtualInventoryRestockService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "virtual_inventory_restock",
+		Description: "Purchase new codes from suppliers for static virtual inventories that dropped below their restock threshold",
+		Interval:    restockJobInterval,
+		Run:         s.ProcessRestock,
+	})
+}
+
+// ProcessRestock 检查所有启用的补货策略，可用库存低于阈值的库存执行一次补货
+func (s *VirtualInventoryRestockService) ProcessRestock(ctx context.Context) error {
+	var policies []models.VirtualInventoryRestockPolicy
+	if err := s.db.Where("enabled = ?", true).Order("id ASC").Find(&policies).Error; err != nil {
+		return err
+	}
+	for i := range policies {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		policy := &policies[i]
+		available, err := s.availableStock(policy.VirtualInventoryID)
+		if err != nil {
+			return err
+		}
+		if available >= int64(policy.Threshold) {
+			continue
+		}
+		var inventory models.VirtualInventory
+		if err := s.db.First(&inventory, policy.VirtualInventoryID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		if !inventory.IsActive || inventory.Type != models.VirtualInventoryTypeStatic {
+			continue
+		}
+		if _, err := s.restock(&inventory, policy, models.VirtualInventoryRestockTriggerAuto, nil); err != nil {
+			log.Printf("[Restock] inventory %d restock failed: %v", inventory.ID, err)
+		}
+	}
+	return nil
+}
+
+// restock 执行一次补货；自动补货遇到冷却期或消费上限时直接跳过
+// 先以 last_run_at 条件更新占位，避免多个实例或手动与自动补货同时向供应商下单
+func (s *VirtualInventoryRestockService) restock(inventory *models.VirtualInventory, policy *models.VirtualInventoryRestockPolicy, trigger string, triggeredBy *uint) (*models.VirtualInventoryRestockRun, error) {
+	now := models.NowFunc()
+	auto := trigger == models.VirtualInventoryRestockTriggerAuto
+
+	spentToday, spentMonth, err := s.spent(inventory.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	budget, period := remainingBudget(policy, spentToday, spentMonth)
+	if budget == 0 {
+		s.markCapReached(inventory, policy, period, now)
+		if auto {
+			return nil, nil
+		}
+		return nil, errRestockCapReached.New().WithParams(map[string]interface{}{"period": period})
+	}
+
+	guard := restockManualGuard
+	if auto {
+		guard = defaultRestockCooldown
+		if policy.CooldownMinutes > 0 {
+			guard = time.Duration(policy.CooldownMinutes) * time.Minute
+		}
+	}
+	claim := s.db.Model(&models.VirtualInventoryRestockPolicy{}).
+		Where("id = ? AND (last_run_at IS NULL OR last_run_at <= ?)", policy.ID, now.Add(-guard)).
+		Update("last_run_at", now)
+	if claim.Error != nil {
+		return nil, claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		if auto {
+			return nil, nil
+		}
+		return nil, errRestockBusy.New()
+	}
+	policy.LastRunAt = &now
+
+	available, err := s.availableStock(inventory.ID)
+	if err != nil {
+		return nil, err
+	}
+	run := &models.VirtualInventoryRestockRun{
+		VirtualInventoryID: inventory.ID,
+		TriggerType:        trigger,
+		TriggeredBy:        triggeredBy,
+		AvailableBefore:    available,
+		Requested:          policy.Quantity,
+		Currency:           s.defaultCurrency(),
+		ScriptHash:         scriptHash(policy.Script),
+		CreatedAt:          now,
+	}
+
+	audit := &scriptExecutionAudit{}
+	startedAt := time.Now()
+	result, execErr := s.inventory.scriptDeliveryService.ExecuteRestockScript(inventory, policy, ScriptRestockRequest{
+		Quantity:  policy.Quantity,
+		Available: available,
+		Budget:    budget,
+		Currency:  run.Currency,
+	}, audit)
+	run.DurationMs = time.Since(startedAt).Milliseconds()
+	run.Console = marshalScriptAuditEvents(audit.console)
+	run.HTTPCalls = marshalScriptAuditEvents(audit.httpCalls)
+	run.EventsTruncated = audit.truncated
+
+	if result != nil {
+		run.Received = len(result.Items)
+		run.CostMinor = result.CostMinor
+		run.SupplierReference = truncateString(result.Reference, restockMaxReferenceLength)
+		run.Message = truncateString(result.Message, restockMaxMessageLength)
+	}
+	if execErr == nil {
+		rows := make([]virtualStockImportRow, 0, len(result.Items))
+		for i, item := range result.Items {
+			rows = append(rows, newVirtualStockImportRow(i+1, item.Content, item.Remark))
+		}
+		imported, importErr := s.inventory.importStockRows(inventory.ID, rows, restockImportedBy, "restock", "Automatic restock")
+		switch {
+		case importErr != nil:
+			execErr = fmt.Errorf("import purchased codes: %w", importErr)
+		case imported.Added == 0:
+			run.Duplicates, run.Invalid = imported.Duplicates, imported.Invalid
+			execErr = fmt.Errorf("supplier returned no new codes (%d duplicates, %d invalid)", imported.Duplicates, imported.Invalid)
+		default:
+			run.Added, run.Duplicates, run.Invalid = imported.Added, imported.Duplicates, imported.Invalid
+			run.BatchNo = imported.BatchNo
+		}
+	}
+
+	run.Status = models.VirtualInventoryRestockStatusSuccess
+	if execErr != nil {
+		run.Status = models.VirtualInventoryRestockStatusFailed
+		run.Error = execErr.Error()
+	}
+	if err := s.db.Create(run).Error; err != nil {
+		log.Printf("[Restock] failed to record run: inventory=%d err=%v", inventory.ID, err)
+	}
+
+	overspent := budget >= 0 && run.CostMinor > budget
+	s.finishRun(inventory, policy, run, overspent, now)
+	logger.LogSystemOperation(s.db, "virtual_inventory_restock", "virtual_inventory", &inventory.ID, map[string]interface{}{
+		"run_id":     run.ID,
+		"trigger":    trigger,
+		"status":     run.Status,
+		"requested":  run.Requested,
+		"added":      run.Added,
+		"cost_minor": run.CostMinor,
+		"error":      run.Error,
+	})
+	return run, nil
+}
+
+// finishRun 更新策略的最近状态，失败或超出预算时通知管理员
+func (s *VirtualInventoryRestockService) finishRun(inventory *models.VirtualInventory, policy *models.VirtualInventoryRestockPolicy, run *models.VirtualInventoryRestockRun, overspent bool, now time.Time) {
+	previous := policy.LastStatus
+	updates := map[string]interface{}{"last_status": run.Status}
+	if run.Status == models.VirtualInventoryRestockStatusFailed {
+		policy.ConsecutiveFailures++
+		updates["consecutive_failures"] = gorm.Expr("consecutive_failures + 1")
+		updates["last_error"] = truncateString(run.Error, restockMaxMessageLength)
+	} else {
+		policy.ConsecutiveFailures = 0
+		updates["consecutive_failures"] = 0
+		updates["last_error"] = ""
+	}
+	policy.LastStatus = run.Status
+
+	var reason string
+	switch {
+	case run.Status == models.VirtualInventoryRestockStatusFailed && s.shouldAlert(policy, previous, now):
+		reason = fmt.Sprintf("Restock run #%d failed (%d consecutive failures): %s", run.ID, policy.ConsecutiveFailures, run.Error)
+	case overspent:
+		reason = fmt.Sprintf("Restock run #%d cost %d %s, more than the remaining spend cap", run.ID, run.CostMinor, run.Currency)
+	}
+	if reason != "" {
+		policy.LastAlertedAt = &now
+		updates["last_alerted_at"] = now
+	}
+	if err := s.db.Model(&models.VirtualInventoryRestockPolicy{}).Where("id = ?", policy.ID).Updates(updates).Error; err != nil {
+		log.Printf("[Restock] failed to update policy %d: %v", policy.ID, err)
+	}
+	if reason != "" {
+		s.notifyAdmins(inventory, reason)
+	}
+}
+
+// markCapReached 记录已达消费上限；同一周期内只在首次达到时通知管理员
+func (s *VirtualInventoryRestockService) markCapReached(inventory *models.VirtualInventory, policy *models.VirtualInventoryRestockPolicy, period string, now time.Time) {
+	if policy.LastStatus == models.VirtualInventoryRestockStatusCapReached {
+		return
+	}
+	policy.LastStatus = models.VirtualInventoryRestockStatusCapReached
+	policy.LastAlertedAt = &now
+	if err := s.db.Model(&models.VirtualInventoryRestockPolicy{}).Where("id = ?", policy.ID).
+		Updates(map[string]interface{}{"last_status": policy.LastStatus, "last_alerted_at": now}).Error; err != nil {
+		log.Printf("[Restock] failed to update policy %d: %v", policy.ID, err)
+	}
+	s.notifyAdmins(inventory, fmt.Sprintf("The %s restock spend cap has been reached, automatic restock is paused until the cap resets", period))
+}
+
+// shouldAlert 由成功转为失败时立即通知，持续失败时按间隔重复通知
+func (s *VirtualInventoryRestockService) shouldAlert(policy *models.VirtualInventoryRestockPolicy, previous models.VirtualInventoryRestockStatus, now time.Time) bool {
+	if previous != models.VirtualInventoryRestockStatusFailed || policy.LastAlertedAt == nil {
+		return true
+	}
+	return now.Sub(*policy.LastAlertedAt) >= restockAlertInterval
+}
+
+// notifyAdmins 将补货告警发送给超级管理员
+func (s *VirtualInventoryRestockService) notifyAdmins(inventory *models.VirtualInventory, reason string) {
+	logger.LogSystemOperation(s.db, "virtual_inventory_restock_alert", "virtual_inventory", &inventory.ID, map[string]interface{}{
+		"reason": reason,
+	})
+	if s.emailService == nil || !s.emailService.IsEnabled() {
+		return
+	}
+	var admins []models.User
+	if err := s.db.Where("role = ? AND is_active = ?", "super_admin", true).Find(&admins).Error; err != nil {
+		log.Printf("[Restock] load admins failed: %v", err)
+		return
+	}
+
+	subject := fmt.Sprintf("Restock alert for %s - %s", inventory.Name, getAppName())
+	body := fmt.Sprintf("<p>Virtual inventory #%d %s:</p><p>%s</p>", inventory.ID, html.EscapeString(inventory.Name), html.EscapeString(reason))
+	for _, admin := range admins {
+		if admin.Email == "" {
+			continue
+		}
+		if err := s.emailService.QueueEmail(admin.Email, subject, body, "inventory.restock", nil, &admin.ID); err != nil {
+			log.Printf("[Restock] queue alert email to %s failed: %v", admin.Email, err)
+		}
+	}
+}
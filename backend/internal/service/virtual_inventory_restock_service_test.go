@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+const testRestockScript = `
+function onRestock(request, config) {
+	if (config.fail) {
+		return { success: false, message: 'supplier out of stock' };
+	}
+	var items = [];
+	for (var i = 0; i < request.quantity; i++) {
+		items.push({ content: 'SUP-' + AuraLogic.utils.generateId() + '-' + i, remark: 'supplier' });
+	}
+	items.push('EXISTING-CODE');
+	return { success: true, items: items, cost_minor: request.quantity * 100, reference: 'PO-' + request.available };
+}
+`
+
+func TestVirtualInventoryRestockPurchasesWithinSpendCaps(t *testing.T) {
+	inventoryService, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.VirtualInventoryRestockPolicy{}, &models.VirtualInventoryRestockRun{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	restock := NewVirtualInventoryRestockService(db, nil, inventoryService, nil)
+
+	inventory := &models.VirtualInventory{Name: "Game keys", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	scripted := &models.VirtualInventory{Name: "Scripted", Type: models.VirtualInventoryTypeScript, IsActive: true}
+	for _, inv := range []*models.VirtualInventory{inventory, scripted} {
+		if err := db.Create(inv).Error; err != nil {
+			t.Fatalf("create inventory: %v", err)
+		}
+	}
+	if _, err := inventoryService.ImportFromText(inventory.ID, "EXISTING-CODE", "admin"); err != nil {
+		t.Fatalf("seed stock: %v", err)
+	}
+
+	input := VirtualInventoryRestockInput{Enabled: true, Threshold: 5, Quantity: 3, DailySpendCapMinor: 500}
+	_, err := restock.SavePolicy(inventory.ID, input, nil)
+	requireOrderBizErr(t, err, "virtual_inventory.restockPolicyInvalid")
+	input.Script = "function onRestock(request {"
+	_, err = restock.SavePolicy(inventory.ID, input, nil)
+	requireOrderBizErr(t, err, "virtual_inventory.restockScriptInvalid")
+	input.Script = testRestockScript
+	_, err = restock.SavePolicy(scripted.ID, input, nil)
+	requireOrderBizErr(t, err, "virtual_inventory.restockStaticOnly")
+	if _, err := restock.SavePolicy(inventory.ID, input, nil); err != nil {
+		t.Fatalf("save policy: %v", err)
+	}
+
+	start := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	originalNow := models.NowFunc
+	models.NowFunc = func() time.Time { return start }
+	t.Cleanup(func() { models.NowFunc = originalNow })
+
+	// 可用库存低于阈值时自动采购，供应商返回的已有卡密按重复跳过
+	if err := restock.ProcessRestock(context.Background()); err != nil {
+		t.Fatalf("process restock: %v", err)
+	}
+	runs, total, err := restock.ListRuns(inventory.ID, "", 1, 10)
+	if err != nil || total != 1 {
+		t.Fatalf("expected one restock run, got %d err=%v", total, err)
+	}
+	run := runs[0]
+	if run.Status != models.VirtualInventoryRestockStatusSuccess || run.Added != 3 || run.Duplicates != 1 ||
+		run.CostMinor != 300 || run.SupplierReference != "PO-1" || run.AvailableBefore != 1 {
+		t.Fatalf("unexpected restock run: %+v", run)
+	}
+
+	// 冷却期内不重复自动补货，手动补货也不能与刚执行的补货重叠
+	if err := restock.ProcessRestock(context.Background()); err != nil {
+		t.Fatalf("process restock in cooldown: %v", err)
+	}
+	if _, total, _ := restock.ListRuns(inventory.ID, "", 1, 10); total != 1 {
+		t.Fatalf("expected cooldown to skip restock, got %d runs", total)
+	}
+	_, err = restock.RunNow(inventory.ID, nil)
+	requireOrderBizErr(t, err, "virtual_inventory.restockBusy")
+
+	// 超出剩余预算的采购照常导入并告警，之后达到每日上限不再采购
+	models.NowFunc = func() time.Time { return start.Add(2 * time.Minute) }
+	run2, err := restock.RunNow(inventory.ID, nil)
+	if err != nil || run2.Added != 3 || run2.CostMinor != 300 {
+		t.Fatalf("manual restock: %+v err=%v", run2, err)
+	}
+	policy, _ := restock.findPolicy(inventory.ID)
+	if policy.LastAlertedAt == nil {
+		t.Fatalf("expected overspend alert, got %+v", policy)
+	}
+	models.NowFunc = func() time.Time { return start.Add(4 * time.Minute) }
+	_, err = restock.RunNow(inventory.ID, nil)
+	requireOrderBizErr(t, err, "virtual_inventory.restockCapReached")
+	overview, err := restock.Overview(inventory.ID)
+	if err != nil || overview.SpentTodayMinor != 600 || overview.Available != 7 ||
+		overview.Policy.LastStatus != models.VirtualInventoryRestockStatusCapReached {
+		t.Fatalf("unexpected overview: %+v err=%v", overview, err)
+	}
+
+	// 次日上限重置；供应商失败时记录失败并累计连续失败次数
+	input.ScriptConfig = `{"fail": true}`
+	if _, err := restock.SavePolicy(inventory.ID, input, nil); err != nil {
+		t.Fatalf("update policy: %v", err)
+	}
+	models.NowFunc = func() time.Time { return start.Add(24 * time.Hour) }
+	failed, err := restock.RunNow(inventory.ID, nil)
+	if err != nil || failed.Status != models.VirtualInventoryRestockStatusFailed || failed.Added != 0 {
+		t.Fatalf("expected failed restock run, got %+v err=%v", failed, err)
+	}
+	policy, _ = restock.findPolicy(inventory.ID)
+	if policy.ConsecutiveFailures != 1 || policy.LastError == "" || policy.LastStatus != models.VirtualInventoryRestockStatusFailed {
+		t.Fatalf("expected failure recorded on policy, got %+v", policy)
+	}
+	if _, total, _ := restock.ListRuns(inventory.ID, string(models.VirtualInventoryRestockStatusFailed), 1, 10); total != 1 {
+		t.Fatalf("expected one failed run, got %d", total)
+	}
+}
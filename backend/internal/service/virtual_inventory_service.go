@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math/rand"
 	"strings"
 	"time"
@@ -13,12 +15,16 @@ import (
 	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/dbutil"
+	"auralogic/internal/pkg/fieldcrypt"
 
 	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+var errVirtualInventoryNotFound = bizerr.Register("virtual_inventory.notFound", 404, "Virtual inventory not found")
+
 type VirtualInventoryService struct {
 	db                    *gorm.DB
 	cfg                   *config.Config
@@ -34,6 +40,25 @@ func NewVirtualInventoryService(db *gorm.DB) *VirtualInventoryService {
 	}
 }
 
+// RegisterJobs 注册发货脚本存储过期条目清理任务
+func (s *VirtualInventoryService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "virtual_inventory_storage_purge",
+		Description: "Delete expired delivery script storage entries",
+		Interval:    time.Hour,
+		Run: func(ctx context.Context) error {
+			purged, err := purgeExpiredStorageEntries(s.db, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("purge expired script storage: %w", err)
+			}
+			if purged > 0 {
+				log.Printf("[VirtualInventory] Purged %d expired script storage entries", purged)
+			}
+			return nil
+		},
+	})
+}
+
 // createVirtualInventoryLog 记录虚拟库存变动日志
 func (s *VirtualInventoryService) createVirtualInventoryLog(tx *gorm.DB, virtualInventoryID uint, logType string, quantity int, orderNo, batchNo, operator, reason string) {
 	log := &models.InventoryLog{
@@ -192,7 +217,7 @@ func (s *VirtualInventoryService) getStockStatsForInventories(inventoryIDs []uin
 	}
 
 	var inventories []models.VirtualInventory
-	if err := s.db.Select("id, type, total_limit").
+	if err := s.db.Select("id, type, total_limit, supplier_paused_at").
 		Where("id IN ?", inventoryIDs).
 		Find(&inventories).Error; err != nil {
 		return nil, err
@@ -244,6 +269,9 @@ func (s *VirtualInventoryService) getStockStatsForInventories(inventoryIDs []uin
 				}
 				stats["available"] = remaining
 			}
+			if inv.SupplierPaused() {
+				stats["available"] = 0
+			}
 		} else {
 			var total int64
 			for _, count := range counts {
@@ -251,7 +279,8 @@ func (s *VirtualInventoryService) getStockStatsForInventories(inventoryIDs []uin
 			}
 			stats["total"] = total
 			stats["available"] = counts[string(models.VirtualStockStatusAvailable)]
-			stats["reserved"] = counts[string(models.VirtualStockStatusReserved)]
+			// 付款中暂扣的库存同样不可售，计入预留
+			stats["reserved"] = counts[string(models.VirtualStockStatusReserved)] + counts[string(models.VirtualStockStatusHeld)]
 			stats["sold"] = counts[string(models.VirtualStockStatusSold)]
 		}
 
@@ -275,13 +304,20 @@ func (s *VirtualInventoryService) GetVirtualInventory(id uint) (*models.VirtualI
 	return &inventory, nil
 }
 
-// UpdateVirtualInventory 更新虚拟库存
-func (s *VirtualInventoryService) UpdateVirtualInventory(id uint, updates map[string]interface{}) error {
-	return s.db.Model(&models.VirtualInventory{}).Where("id = ?", id).Updates(updates).Error
+// UpdateVirtualInventory 更新虚拟库存，expectedVersion 为客户端读取时的版本号（0 表示不校验）
+func (s *VirtualInventoryService) UpdateVirtualInventory(id, expectedVersion uint, updates map[string]interface{}) error {
+	err := dbutil.UpdateWithVersion(s.db, &models.VirtualInventory{}, id, expectedVersion, updates)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errVirtualInventoryNotFound.New()
+	case errors.Is(err, dbutil.ErrVersionConflict):
+		return bizerr.CodeVersionConflict.New()
+	}
+	return err
 }
 
-// DeleteVirtualInventory 删除虚拟库存（只有没有关联的可用库存项时才能删除）
-func (s *VirtualInventoryService) DeleteVirtualInventory(id uint) error {
+// DeleteVirtualInventory 删除虚拟库存，移入回收站（只有没有关联的可用库存项时才能删除）
+func (s *VirtualInventoryService) DeleteVirtualInventory(id uint, deletedBy uint) error {
 	// 检查是否有关联的库存项
 	var count int64
 	if err := s.db.Model(&models.VirtualProductStock{}).
@@ -307,7 +343,13 @@ func (s *VirtualInventoryService) DeleteVirtualInventory(id uint) error {
 			WithParams(map[string]interface{}{"binding_count": count})
 	}
 
-	return s.db.Delete(&models.VirtualInventory{}, id).Error
+	if err := dbutil.SoftDelete(s.db, &models.VirtualInventory{}, id, deletedBy); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errVirtualInventoryNotFound.New()
+		}
+		return err
+	}
+	return nil
 }
 
 // ListVirtualInventories 获取虚拟库存列表
@@ -361,8 +403,16 @@ func (s *VirtualInventoryService) ListVirtualInventories(page, limit int, search
 			Description:       inv.Description,
 			TotalLimit:        inv.TotalLimit,
 			AllowInlineIframe: inv.AllowInlineIframe,
+			RequireReauth:     inv.RequireReauth,
+			MaxActivations:    inv.MaxActivations,
+			UsageUnit:         inv.UsageUnit,
+			UsageQuota:        inv.UsageQuota,
+			OverageUnitPrice:  inv.OverageUnitPrice,
+			SupplierPausedAt:  inv.SupplierPausedAt,
+			LowStockThreshold: inv.LowStockThreshold,
 			IsActive:          inv.IsActive,
 			Notes:             inv.Notes,
+			Version:           inv.Version,
 			Total:             stats["total"],
 			Available:         stats["available"],
 			Reserved:          stats["reserved"],
@@ -391,11 +441,11 @@ func (s *VirtualInventoryService) GetStockStats(virtualInventoryID uint) (map[st
 	}, nil
 }
 
-// ImportFromExcel 从Excel导入虚拟产品库存
-func (s *VirtualInventoryService) ImportFromExcel(virtualInventoryID uint, filePath string, importedBy string) (int, error) {
+// ImportFromExcel 从Excel导入虚拟产品库存（第一列卡密，第二列备注）
+func (s *VirtualInventoryService) ImportFromExcel(virtualInventoryID uint, filePath string, importedBy string) (*VirtualStockImportResult, error) {
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
-		return 0, fmt.Errorf("failed to open excel file: %w", err)
+		return nil, fmt.Errorf("failed to open excel file: %w", err)
 	}
 	defer f.Close()
 
@@ -403,119 +453,59 @@ func (s *VirtualInventoryService) ImportFromExcel(virtualInventoryID uint, fileP
 	sheetName := f.GetSheetName(0)
 	rows, err := f.GetRows(sheetName)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read excel rows: %w", err)
+		return nil, fmt.Errorf("failed to read excel rows: %w", err)
 	}
 
 	if len(rows) == 0 {
-		return 0, bizerr.New("virtual_inventory.importEmptyFile", "Excel file is empty")
+		return nil, bizerr.New("virtual_inventory.importEmptyFile", "Excel file is empty")
 	}
 
-	// 生成批次号
-	batchNo := fmt.Sprintf("BATCH-%s", time.Now().Format("20060102150405"))
-
-	var stocks []models.VirtualProductStock
-
-	// 跳过第一行标题（如果有）
-	startRow := 0
-	if len(rows) > 0 && (strings.ToLower(rows[0][0]) == "content" || strings.ToLower(rows[0][0]) == "卡密" || strings.ToLower(rows[0][0]) == "激活码") {
-		startRow = 1
-	}
-
-	for i := startRow; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) == 0 || row[0] == "" {
+	var importRows []virtualStockImportRow
+	for i, row := range rows {
+		if len(row) == 0 || strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		// 跳过第一行标题（如果有）
+		if i == 0 && isVirtualStockImportHeader(row[0]) {
 			continue
 		}
 
-		content := strings.TrimSpace(row[0])
 		remark := ""
 		if len(row) > 1 {
-			remark = strings.TrimSpace(row[1])
-		}
-
-		stock := models.VirtualProductStock{
-			VirtualInventoryID: virtualInventoryID,
-			Content:            content,
-			Remark:             remark,
-			Status:             models.VirtualStockStatusAvailable,
-			BatchNo:            batchNo,
-			ImportedBy:         importedBy,
+			remark = row[1]
 		}
-		stocks = append(stocks, stock)
-	}
-
-	if len(stocks) == 0 {
-		return 0, bizerr.New("virtual_inventory.importNoValidData", "No valid stock data found for import").
-			WithParams(map[string]interface{}{"source": "excel"})
+		importRows = append(importRows, newVirtualStockImportRow(i+1, row[0], remark))
 	}
 
-	// 批量插入
-	if err := s.db.Create(&stocks).Error; err != nil {
-		return 0, fmt.Errorf("failed to insert stocks: %w", err)
-	}
-
-	s.createVirtualInventoryLog(s.db, virtualInventoryID, models.InventoryLogTypeImport, len(stocks), "", batchNo, importedBy, "Import from Excel")
-
-	return len(stocks), nil
+	return s.importStockRows(virtualInventoryID, importRows, importedBy, "excel", "Import from Excel")
 }
 
-// ImportFromText 从文本文件导入（每行一个卡密）
-func (s *VirtualInventoryService) ImportFromText(virtualInventoryID uint, content string, importedBy string) (int, error) {
-	lines := strings.Split(content, "\n")
-
-	// 生成批次号
-	batchNo := fmt.Sprintf("BATCH-%s", time.Now().Format("20060102150405"))
-
-	var stocks []models.VirtualProductStock
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+// ImportFromText 从文本文件导入（每行一个卡密，支持 "卡密,备注" 格式）
+func (s *VirtualInventoryService) ImportFromText(virtualInventoryID uint, content string, importedBy string) (*VirtualStockImportResult, error) {
+	var importRows []virtualStockImportRow
+	for i, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(strings.TrimPrefix(line, "\ufeff")) == "" {
 			continue
 		}
 
-		// 支持 "卡密,备注" 格式
 		parts := strings.SplitN(line, ",", 2)
-		contentVal := strings.TrimSpace(parts[0])
 		remark := ""
 		if len(parts) > 1 {
-			remark = strings.TrimSpace(parts[1])
+			remark = parts[1]
 		}
-
-		stock := models.VirtualProductStock{
-			VirtualInventoryID: virtualInventoryID,
-			Content:            contentVal,
-			Remark:             remark,
-			Status:             models.VirtualStockStatusAvailable,
-			BatchNo:            batchNo,
-			ImportedBy:         importedBy,
-		}
-		stocks = append(stocks, stock)
-	}
-
-	if len(stocks) == 0 {
-		return 0, bizerr.New("virtual_inventory.importNoValidData", "No valid stock data found for import").
-			WithParams(map[string]interface{}{"source": "text"})
-	}
-
-	// 批量插入
-	if err := s.db.Create(&stocks).Error; err != nil {
-		return 0, fmt.Errorf("failed to insert stocks: %w", err)
+		importRows = append(importRows, newVirtualStockImportRow(i+1, parts[0], remark))
 	}
 
-	s.createVirtualInventoryLog(s.db, virtualInventoryID, models.InventoryLogTypeImport, len(stocks), "", batchNo, importedBy, "Import from text")
-
-	return len(stocks), nil
+	return s.importStockRows(virtualInventoryID, importRows, importedBy, "text", "Import from text")
 }
 
-// ImportFromCSV 从CSV导入
-func (s *VirtualInventoryService) ImportFromCSV(virtualInventoryID uint, reader io.Reader, importedBy string) (int, error) {
+// ImportFromCSV 从CSV导入（第一列卡密，第二列备注，可带标题行）
+func (s *VirtualInventoryService) ImportFromCSV(virtualInventoryID uint, reader io.Reader, importedBy string) (*VirtualStockImportResult, error) {
 	csvReader := csv.NewReader(reader)
+	// 允许部分行不带备注列
+	csvReader.FieldsPerRecord = -1
 
-	// 生成批次号
-	batchNo := fmt.Sprintf("BATCH-%s", time.Now().Format("20060102150405"))
-
-	var stocks []models.VirtualProductStock
+	var importRows []virtualStockImportRow
 	isFirstRow := true
 
 	for {
@@ -524,51 +514,30 @@ func (s *VirtualInventoryService) ImportFromCSV(virtualInventoryID uint, reader
 			break
 		}
 		if err != nil {
-			return 0, fmt.Errorf("failed to read csv: %w", err)
+			return nil, fmt.Errorf("failed to read csv: %w", err)
 		}
+		line, _ := csvReader.FieldPos(0)
 
 		// 跳过标题行
 		if isFirstRow {
 			isFirstRow = false
-			if strings.ToLower(record[0]) == "content" || strings.ToLower(record[0]) == "卡密" {
+			if len(record) > 0 && isVirtualStockImportHeader(record[0]) {
 				continue
 			}
 		}
 
-		if len(record) == 0 || record[0] == "" {
+		if len(record) == 0 || strings.TrimSpace(strings.Join(record, "")) == "" {
 			continue
 		}
 
-		contentVal := strings.TrimSpace(record[0])
 		remark := ""
 		if len(record) > 1 {
-			remark = strings.TrimSpace(record[1])
+			remark = record[1]
 		}
-
-		stock := models.VirtualProductStock{
-			VirtualInventoryID: virtualInventoryID,
-			Content:            contentVal,
-			Remark:             remark,
-			Status:             models.VirtualStockStatusAvailable,
-			BatchNo:            batchNo,
-			ImportedBy:         importedBy,
-		}
-		stocks = append(stocks, stock)
+		importRows = append(importRows, newVirtualStockImportRow(line, record[0], remark))
 	}
 
-	if len(stocks) == 0 {
-		return 0, bizerr.New("virtual_inventory.importNoValidData", "No valid stock data found for import").
-			WithParams(map[string]interface{}{"source": "csv"})
-	}
-
-	// 批量插入
-	if err := s.db.Create(&stocks).Error; err != nil {
-		return 0, fmt.Errorf("failed to insert stocks: %w", err)
-	}
-
-	s.createVirtualInventoryLog(s.db, virtualInventoryID, models.InventoryLogTypeImport, len(stocks), "", batchNo, importedBy, "Import from CSV")
-
-	return len(stocks), nil
+	return s.importStockRows(virtualInventoryID, importRows, importedBy, "csv", "Import from CSV")
 }
 
 // CreateStockManually 手动创建单个库存项
@@ -582,9 +551,16 @@ func (s *VirtualInventoryService) CreateStockManually(virtualInventoryID uint, c
 		ImportedBy:         importedBy,
 	}
 
+	sealed, err := fieldcrypt.Encrypt(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt stock content: %w", err)
+	}
+	stock.ContentHash = models.LicenseKeyHash(content)
+	stock.Content = sealed
 	if err := s.db.Create(stock).Error; err != nil {
 		return nil, err
 	}
+	stock.Content = content
 
 	s.createVirtualInventoryLog(s.db, virtualInventoryID, models.InventoryLogTypeImport, 1, "", stock.BatchNo, importedBy, "Create stock manually")
 
@@ -610,6 +586,9 @@ func (s *VirtualInventoryService) ListStocks(virtualInventoryID uint, status str
 	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&stocks).Error; err != nil {
 		return nil, 0, err
 	}
+	if err := revealVirtualStockContents(stocks); err != nil {
+		return nil, 0, err
+	}
 
 	return stocks, total, nil
 }
@@ -680,8 +659,16 @@ func (s *VirtualInventoryService) GetVirtualInventoryWithStats(id uint) (*models
 		Description:       inventory.Description,
 		TotalLimit:        inventory.TotalLimit,
 		AllowInlineIframe: inventory.AllowInlineIframe,
+		RequireReauth:     inventory.RequireReauth,
+		MaxActivations:    inventory.MaxActivations,
+		UsageUnit:         inventory.UsageUnit,
+		UsageQuota:        inventory.UsageQuota,
+		OverageUnitPrice:  inventory.OverageUnitPrice,
+		SupplierPausedAt:  inventory.SupplierPausedAt,
+		LowStockThreshold: inventory.LowStockThreshold,
 		IsActive:          inventory.IsActive,
 		Notes:             inventory.Notes,
+		Version:           inventory.Version,
 		Total:             stats["total"],
 		Available:         stats["available"],
 		Reserved:          stats["reserved"],
@@ -813,8 +800,15 @@ func (s *VirtualInventoryService) GetProductBindings(productID uint) ([]models.B
 				Description:       binding.VirtualInventory.Description,
 				TotalLimit:        binding.VirtualInventory.TotalLimit,
 				AllowInlineIframe: binding.VirtualInventory.AllowInlineIframe,
+				RequireReauth:     binding.VirtualInventory.RequireReauth,
+				MaxActivations:    binding.VirtualInventory.MaxActivations,
+				UsageUnit:         binding.VirtualInventory.UsageUnit,
+				UsageQuota:        binding.VirtualInventory.UsageQuota,
+				OverageUnitPrice:  binding.VirtualInventory.OverageUnitPrice,
+				SupplierPausedAt:  binding.VirtualInventory.SupplierPausedAt,
 				IsActive:          binding.VirtualInventory.IsActive,
 				Notes:             binding.VirtualInventory.Notes,
+				Version:           binding.VirtualInventory.Version,
 				Total:             stats["total"],
 				Available:         stats["available"],
 				Reserved:          stats["reserved"],
@@ -923,9 +917,42 @@ func (s *VirtualInventoryService) AllocateStockForProduct(productID uint, quanti
 }
 
 // AllocateStockForProductByAttributes 根据规格属性为商品分配虚拟库存
-// 使用事务和行锁确保并发安全，防止超售
+// 使用事务和条件更新确保并发安全，防止超售
 // 返回值: (分配的库存项, 脚本类型时选中的virtualInventoryID, error)
 func (s *VirtualInventoryService) AllocateStockForProductByAttributes(productID uint, quantity int, orderNo string, attributes map[string]interface{}) ([]models.VirtualProductStock, *uint, error) {
+	bindings, err := s.resolveAllocationBindings(productID, attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allocatedStocks []models.VirtualProductStock
+	var scriptInventoryID *uint
+
+	// 使用事务确保并发安全
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		allocatedStocks, scriptInventoryID, err = s.claimFromBindings(tx, bindings, quantity, map[string]interface{}{
+			"status":   models.VirtualStockStatusReserved,
+			"order_no": orderNo,
+		}, orderNo, "Reserve stock for order")
+		if err != nil {
+			return err
+		}
+		for i := range allocatedStocks {
+			allocatedStocks[i].MarkAsReserved(orderNo)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return allocatedStocks, scriptInventoryID, nil
+}
+
+// resolveAllocationBindings 按规格属性查找可分配的虚拟库存绑定，精确匹配优先，否则返回商品的全部绑定
+func (s *VirtualInventoryService) resolveAllocationBindings(productID uint, attributes map[string]interface{}) ([]models.BindingWithVirtualInventoryInfo, error) {
 	var bindings []models.BindingWithVirtualInventoryInfo
 	var err error
 
@@ -965,116 +992,124 @@ func (s *VirtualInventoryService) AllocateStockForProductByAttributes(productID
 	if len(bindings) == 0 {
 		bindings, err = s.GetProductBindings(productID)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	if len(bindings) == 0 {
-		return nil, nil, newVirtualBindingNoBoundInventoryError()
+		return nil, newVirtualBindingNoBoundInventoryError()
 	}
+	return bindings, nil
+}
 
-	var allocatedStocks []models.VirtualProductStock
+// claimFromBindings 按优先级从绑定的库存中占用 quantity 件库存，updates 为占用时写入的字段
+// 脚本类型库存不创建占位记录，仅返回选中的 virtualInventoryID
+func (s *VirtualInventoryService) claimFromBindings(tx *gorm.DB, bindings []models.BindingWithVirtualInventoryInfo, quantity int, updates map[string]interface{}, orderNo, logReason string) ([]models.VirtualProductStock, *uint, error) {
+	var claimedStocks []models.VirtualProductStock
 	var scriptInventoryID *uint
+	remainingQuantity := quantity
 
-	// 使用事务确保并发安全
-	err = s.db.Transaction(func(tx *gorm.DB) error {
-		remainingQuantity := quantity
+	for _, binding := range bindings {
+		if remainingQuantity <= 0 {
+			break
+		}
 
-		// 按优先级从绑定的库存中分配
-		for _, binding := range bindings {
-			if remainingQuantity <= 0 {
-				break
-			}
+		// 检查是否为脚本类型库存
+		var inv models.VirtualInventory
+		if err := tx.Select("id, type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err != nil {
+			continue
+		}
 
-			// 检查是否为脚本类型库存
-			var inv models.VirtualInventory
-			if err := tx.Select("id, type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err != nil {
+		if inv.Type == models.VirtualInventoryTypeScript {
+			// 上游供应商异常被暂停时视为缺货
+			if inv.SupplierPaused() {
 				continue
 			}
-
-			if inv.Type == models.VirtualInventoryTypeScript {
-				// 脚本类型：检查发货次数限制
-				if inv.TotalLimit > 0 {
-					var sold int64
-					tx.Model(&models.VirtualProductStock{}).
-						Where("virtual_inventory_id = ? AND status = ?", binding.VirtualInventoryID, models.VirtualStockStatusSold).
-						Count(&sold)
-					if sold+int64(remainingQuantity) > inv.TotalLimit {
-						continue // 超过限制，跳过
-					}
+			// 脚本类型：检查发货次数限制
+			if inv.TotalLimit > 0 {
+				var sold int64
+				tx.Model(&models.VirtualProductStock{}).
+					Where("virtual_inventory_id = ? AND status = ?", binding.VirtualInventoryID, models.VirtualStockStatusSold).
+					Count(&sold)
+				if sold+int64(remainingQuantity) > inv.TotalLimit {
+					continue // 超过限制，跳过
 				}
-				// 不创建占位记录，仅记录选中的 virtualInventoryID
-				id := binding.VirtualInventoryID
-				scriptInventoryID = &id
-				remainingQuantity = 0
-				continue
 			}
+			// 不创建占位记录，仅记录选中的 virtualInventoryID
+			id := binding.VirtualInventoryID
+			scriptInventoryID = &id
+			remainingQuantity = 0
+			continue
+		}
 
-			// 静态类型：从已有库存中分配
-			var stocks []models.VirtualProductStock
-			// 使用 FOR UPDATE 行锁防止并发超售
-			query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-				Where("virtual_inventory_id = ? AND status = ?", binding.VirtualInventoryID, models.VirtualStockStatusAvailable)
+		// 静态类型：从已有库存中占用
+		stocks, err := s.claimAvailableStocks(tx, binding.VirtualInventoryID, remainingQuantity, updates)
+		if err != nil {
+			// 部分行可能已更新，交由调用方回滚整个事务
+			return nil, nil, err
+		}
+		if len(stocks) == 0 {
+			continue
+		}
 
-			// 根据配置决定发货顺序
-			deliveryOrder := ""
-			if s.cfg != nil {
-				deliveryOrder = s.cfg.Order.VirtualDeliveryOrder
-			}
-			if deliveryOrder == "newest" {
-				query = query.Order("created_at DESC")
-			} else if deliveryOrder == "oldest" {
-				query = query.Order("created_at ASC")
-			} else {
-				query = query.Order(s.getRandomOrderClause())
-			}
+		s.createVirtualInventoryLog(tx, binding.VirtualInventoryID, models.InventoryLogTypeReserve, len(stocks), orderNo, "", "system", logReason)
 
-			err := query.Limit(remainingQuantity).Find(&stocks).Error
+		claimedStocks = append(claimedStocks, stocks...)
+		remainingQuantity -= len(stocks)
+	}
 
-			if err != nil {
-				continue
-			}
+	if remainingQuantity > 0 && scriptInventoryID == nil {
+		// 分配数量不足，由调用方回滚事务
+		return nil, nil, newVirtualBindingInsufficientAvailableError(quantity, int64(len(claimedStocks)))
+	}
+	return claimedStocks, scriptInventoryID, nil
+}
 
-			if len(stocks) == 0 {
-				continue
-			}
+// claimAvailableStocks 从指定库存池占用最多 quantity 件可用库存
+// 逐条以 status = available 为条件更新，被并发请求抢先占用的行会被跳过并补选，
+// 保证同一卡密不会分配给两个请求（SQLite 等不支持 FOR UPDATE 时行锁不生效）
+func (s *VirtualInventoryService) claimAvailableStocks(tx *gorm.DB, virtualInventoryID uint, quantity int, updates map[string]interface{}) ([]models.VirtualProductStock, error) {
+	claimed := make([]models.VirtualProductStock, 0, quantity)
+	for attempt := 0; attempt < 3 && len(claimed) < quantity; attempt++ {
+		var candidates []models.VirtualProductStock
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("virtual_inventory_id = ? AND status = ?", virtualInventoryID, models.VirtualStockStatusAvailable)
+		if err := s.applyDeliveryOrder(query).Limit(quantity - len(claimed)).Find(&candidates).Error; err != nil {
+			return claimed, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
 
-			// 标记为已预留
-			var ids []uint
-			for i := range stocks {
-				ids = append(ids, stocks[i].ID)
-				stocks[i].MarkAsReserved(orderNo)
+		for _, stock := range candidates {
+			result := tx.Model(&models.VirtualProductStock{}).
+				Where("id = ? AND status = ?", stock.ID, models.VirtualStockStatusAvailable).
+				Updates(updates)
+			if result.Error != nil {
+				return claimed, result.Error
 			}
-
-			// 批量更新状态（在事务内）
-			if err := tx.Model(&models.VirtualProductStock{}).
-				Where("id IN ?", ids).
-				Updates(map[string]interface{}{
-					"status":   models.VirtualStockStatusReserved,
-					"order_no": orderNo,
-				}).Error; err != nil {
-				continue
+			if result.RowsAffected == 1 {
+				claimed = append(claimed, stock)
 			}
-
-			s.createVirtualInventoryLog(tx, binding.VirtualInventoryID, models.InventoryLogTypeReserve, len(stocks), orderNo, "", "system", "Reserve stock for order")
-
-			allocatedStocks = append(allocatedStocks, stocks...)
-			remainingQuantity -= len(stocks)
-		}
-
-		if remainingQuantity > 0 && scriptInventoryID == nil {
-			// 分配数量不足，事务回滚
-			return newVirtualBindingInsufficientAvailableError(quantity, int64(len(allocatedStocks)))
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, nil, err
 	}
+	return claimed, nil
+}
 
-	return allocatedStocks, scriptInventoryID, nil
+// applyDeliveryOrder 根据配置决定发货顺序
+func (s *VirtualInventoryService) applyDeliveryOrder(query *gorm.DB) *gorm.DB {
+	deliveryOrder := ""
+	if s.cfg != nil {
+		deliveryOrder = s.cfg.Order.VirtualDeliveryOrder
+	}
+	switch deliveryOrder {
+	case "newest":
+		return query.Order("created_at DESC")
+	case "oldest":
+		return query.Order("created_at ASC")
+	default:
+		return query.Order(s.getRandomOrderClause())
+	}
 }
 
 // AllocateStockFromInventory 从指定虚拟库存池直接分配库存（管理员创建订单时使用）
@@ -1085,11 +1120,14 @@ func (s *VirtualInventoryService) AllocateStockFromInventory(virtualInventoryID
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// 检查是否为脚本类型库存
 		var inv models.VirtualInventory
-		if err := tx.Select("id, type, total_limit").First(&inv, virtualInventoryID).Error; err != nil {
+		if err := tx.Select("id, type, total_limit, supplier_paused_at").First(&inv, virtualInventoryID).Error; err != nil {
 			return err
 		}
 
 		if inv.Type == models.VirtualInventoryTypeScript {
+			if inv.SupplierPaused() {
+				return fmt.Errorf("script inventory %d is paused due to supplier errors", virtualInventoryID)
+			}
 			// 脚本类型：检查发货次数限制
 			if inv.TotalLimit > 0 {
 				var sold int64
@@ -1105,24 +1143,11 @@ func (s *VirtualInventoryService) AllocateStockFromInventory(virtualInventoryID
 		}
 
 		// 静态类型：从已有库存中分配
-		var stocks []models.VirtualProductStock
-		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("virtual_inventory_id = ? AND status = ?", virtualInventoryID, models.VirtualStockStatusAvailable)
-
-		// 根据配置决定发货顺序
-		deliveryOrder := ""
-		if s.cfg != nil {
-			deliveryOrder = s.cfg.Order.VirtualDeliveryOrder
-		}
-		if deliveryOrder == "newest" {
-			query = query.Order("created_at DESC")
-		} else if deliveryOrder == "oldest" {
-			query = query.Order("created_at ASC")
-		} else {
-			query = query.Order(s.getRandomOrderClause())
-		}
-
-		if err := query.Limit(quantity).Find(&stocks).Error; err != nil {
+		stocks, err := s.claimAvailableStocks(tx, virtualInventoryID, quantity, map[string]interface{}{
+			"status":   models.VirtualStockStatusReserved,
+			"order_no": orderNo,
+		})
+		if err != nil {
 			return err
 		}
 
@@ -1131,21 +1156,10 @@ func (s *VirtualInventoryService) AllocateStockFromInventory(virtualInventoryID
 		}
 
 		// 标记为已预留
-		var ids []uint
 		for i := range stocks {
-			ids = append(ids, stocks[i].ID)
 			stocks[i].MarkAsReserved(orderNo)
 		}
 
-		if err := tx.Model(&models.VirtualProductStock{}).
-			Where("id IN ?", ids).
-			Updates(map[string]interface{}{
-				"status":   models.VirtualStockStatusReserved,
-				"order_no": orderNo,
-			}).Error; err != nil {
-			return err
-		}
-
 		s.createVirtualInventoryLog(tx, virtualInventoryID, models.InventoryLogTypeReserve, len(stocks), orderNo, "", "system", "Reserve stock for order (direct)")
 
 		allocatedStocks = stocks
@@ -1188,7 +1202,10 @@ func (s *VirtualInventoryService) GetAvailableCountForProductByAttributes(produc
 		if binding.AttributesHash == attrsHash {
 			// 检查是否为脚本类型库存
 			var inv models.VirtualInventory
-			if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+			if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+				if inv.SupplierPaused() {
+					return 0, nil
+				}
 				if inv.TotalLimit > 0 {
 					var sold int64
 					s.db.Model(&models.VirtualProductStock{}).
@@ -1236,7 +1253,10 @@ func (s *VirtualInventoryService) GetAvailableCountForProductByAttributes(produc
 
 				// 检查是否为脚本类型库存
 				var inv models.VirtualInventory
-				if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+				if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+					if inv.SupplierPaused() {
+						continue
+					}
 					if inv.TotalLimit > 0 {
 						var sold int64
 						s.db.Model(&models.VirtualProductStock{}).
@@ -1275,10 +1295,10 @@ func (s *VirtualInventoryService) HasUnlimitedScriptInventoryForProduct(productI
 	}
 	for _, binding := range bindings {
 		var inv models.VirtualInventory
-		if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err != nil {
+		if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err != nil {
 			continue
 		}
-		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 {
+		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 && !inv.SupplierPaused() {
 			return true, nil
 		}
 	}
@@ -1306,10 +1326,10 @@ func (s *VirtualInventoryService) HasUnlimitedScriptInventoryForProductByAttribu
 			continue
 		}
 		var inv models.VirtualInventory
-		if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err != nil {
+		if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err != nil {
 			continue
 		}
-		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 {
+		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 && !inv.SupplierPaused() {
 			return true, nil
 		}
 		return false, nil
@@ -1334,10 +1354,10 @@ func (s *VirtualInventoryService) HasUnlimitedScriptInventoryForProductByAttribu
 		inventoryMap[binding.VirtualInventoryID] = true
 
 		var inv models.VirtualInventory
-		if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err != nil {
+		if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err != nil {
 			continue
 		}
-		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 {
+		if inv.Type == models.VirtualInventoryTypeScript && inv.TotalLimit <= 0 && !inv.SupplierPaused() {
 			return true, nil
 		}
 	}
@@ -1723,8 +1743,13 @@ func (s *VirtualInventoryService) executeScriptDeliveryWithDB(db *gorm.DB, order
 
 			for i, stock := range stocks {
 				if i < len(result.Items) {
+					sealed, err := fieldcrypt.Encrypt(result.Items[i].Content)
+					if err != nil {
+						return fmt.Errorf("failed to encrypt stock content: %w", err)
+					}
 					updates := map[string]interface{}{
-						"content": result.Items[i].Content,
+						"content":      sealed,
+						"content_hash": models.LicenseKeyHash(result.Items[i].Content),
 					}
 					if result.Items[i].Remark != "" {
 						updates["remark"] = result.Items[i].Remark
@@ -1806,6 +1831,9 @@ func (s *VirtualInventoryService) executeScriptDeliveryWithDB(db *gorm.DB, order
 			soldStocks = append(soldStocks, stock)
 		}
 		if len(soldStocks) > 0 {
+			if err := sealVirtualStockContents(soldStocks); err != nil {
+				return err
+			}
 			if err := db.CreateInBatches(&soldStocks, 200).Error; err != nil {
 				return fmt.Errorf("failed to create sold stock: %w", err)
 			}
@@ -1943,8 +1971,13 @@ func (s *VirtualInventoryService) ManualReleaseStock(stockID uint) error {
 // GetStockByOrderID 获取订单的虚拟产品库存
 func (s *VirtualInventoryService) GetStockByOrderID(orderID uint) ([]models.VirtualProductStock, error) {
 	var stocks []models.VirtualProductStock
-	err := s.db.Where("order_id = ?", orderID).Find(&stocks).Error
-	return stocks, err
+	if err := s.db.Where("order_id = ?", orderID).Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+	if err := revealVirtualStockContents(stocks); err != nil {
+		return nil, err
+	}
+	return stocks, nil
 }
 
 // GetStockByOrderNo 根据订单号获取库存
@@ -1956,6 +1989,9 @@ func (s *VirtualInventoryService) GetStockByOrderNo(orderNo string) ([]models.Vi
 	if len(stocks) == 0 {
 		return stocks, nil
 	}
+	if err := revealVirtualStockContents(stocks); err != nil {
+		return nil, err
+	}
 
 	inventoryIDs := make([]uint, 0, len(stocks))
 	seenInventoryIDs := make(map[uint]struct{}, len(stocks))
@@ -2008,7 +2044,10 @@ func (s *VirtualInventoryService) GetAvailableCountForProduct(productID uint) (i
 	for _, binding := range bindings {
 		// 检查是否为脚本类型库存（使用TotalLimit限制）
 		var inv models.VirtualInventory
-		if err := s.db.Select("type, total_limit").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+		if err := s.db.Select("type, total_limit, supplier_paused_at").First(&inv, binding.VirtualInventoryID).Error; err == nil && inv.Type == models.VirtualInventoryTypeScript {
+			if inv.SupplierPaused() {
+				continue
+			}
 			if inv.TotalLimit > 0 {
 				var sold int64
 				s.db.Model(&models.VirtualProductStock{}).
@@ -2097,19 +2136,22 @@ func (s *VirtualInventoryService) ListStocksForProduct(productID uint, status st
 	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&stocks).Error; err != nil {
 		return nil, 0, err
 	}
+	if err := revealVirtualStockContents(stocks); err != nil {
+		return nil, 0, err
+	}
 
 	return stocks, total, nil
 }
 
 // ImportStockForProduct 为商品导入虚拟库存（使用第一个绑定的库存）
-func (s *VirtualInventoryService) ImportStockForProduct(productID uint, content string, importedBy string) (int, error) {
+func (s *VirtualInventoryService) ImportStockForProduct(productID uint, content string, importedBy string) (*VirtualStockImportResult, error) {
 	bindings, err := s.GetProductBindings(productID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if len(bindings) == 0 {
-		return 0, newVirtualBindingNoBoundInventoryError()
+		return nil, newVirtualBindingNoBoundInventoryError()
 	}
 
 	// 使用第一个绑定的虚拟库存
@@ -2117,14 +2159,14 @@ func (s *VirtualInventoryService) ImportStockForProduct(productID uint, content
 }
 
 // ImportStockFromFileForProduct 为商品从文件导入虚拟库存
-func (s *VirtualInventoryService) ImportStockFromFileForProduct(productID uint, filePath string, importedBy string) (int, error) {
+func (s *VirtualInventoryService) ImportStockFromFileForProduct(productID uint, filePath string, importedBy string) (*VirtualStockImportResult, error) {
 	bindings, err := s.GetProductBindings(productID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if len(bindings) == 0 {
-		return 0, newVirtualBindingNoBoundInventoryError()
+		return nil, newVirtualBindingNoBoundInventoryError()
 	}
 
 	// 使用第一个绑定的虚拟库存
@@ -2164,6 +2206,9 @@ func (s *VirtualInventoryService) SelectRandomVirtualInventory(productID uint, q
 		}
 		// 脚本类型：有限制时检查剩余量，无限制时直接可用
 		if binding.VirtualInventory.Type == models.VirtualInventoryTypeScript {
+			if binding.VirtualInventory.SupplierPausedAt != nil {
+				continue
+			}
 			if binding.VirtualInventory.TotalLimit > 0 && binding.VirtualInventory.Available < int64(quantity) {
 				continue
 			}
@@ -2276,6 +2321,9 @@ func (s *VirtualInventoryService) FindVirtualInventoryWithPartialMatch(productID
 			// 脚本类型：有限制时检查剩余量，无限制时直接可用；静态类型检查库存数量
 			isScriptType := binding.VirtualInventory.Type == models.VirtualInventoryTypeScript
 			if isScriptType {
+				if binding.VirtualInventory.SupplierPausedAt != nil {
+					continue
+				}
 				if binding.VirtualInventory.TotalLimit > 0 && binding.VirtualInventory.Available < int64(quantity) {
 					continue
 				}
@@ -2392,3 +2440,45 @@ func (s *VirtualInventoryService) TestDeliveryScript(script string, config map[s
 
 	return s.scriptDeliveryService.ExecuteDeliveryScript(inventory, testOrder, quantity)
 }
+
+// 试运行单次最多生成的发货项数量
+const maxScriptDryRunQuantity = 100
+
+var (
+	errScriptDryRunUnsupported   = bizerr.Register("virtual_inventory.dryRunScriptOnly", 400, "Only script type virtual inventory can be dry-run")
+	errScriptDryRunQuantityLimit = bizerr.Register("virtual_inventory.dryRunQuantityTooLarge", 400, "Dry-run quantity exceeds the limit")
+)
+
+// DryRunDeliveryScript 对指定脚本库存执行一次试运行
+// script/config 不为空时覆盖已保存的脚本与配置，便于试运行编辑器中尚未保存的内容
+func (s *VirtualInventoryService) DryRunDeliveryScript(id uint, script *string, config map[string]interface{}, opts ScriptDryRunOptions) (*ScriptDryRunResult, error) {
+	var inventory models.VirtualInventory
+	if err := s.db.First(&inventory, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errVirtualInventoryNotFound.New()
+		}
+		return nil, err
+	}
+	if inventory.Type != models.VirtualInventoryTypeScript {
+		return nil, errScriptDryRunUnsupported.New()
+	}
+	if opts.Quantity > maxScriptDryRunQuantity {
+		return nil, errScriptDryRunQuantityLimit.New().WithParams(map[string]interface{}{"max": maxScriptDryRunQuantity})
+	}
+
+	if script != nil {
+		inventory.Script = *script
+	}
+	if config != nil {
+		configJSON, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		inventory.ScriptConfig = string(configJSON)
+	}
+	if strings.TrimSpace(inventory.Script) == "" {
+		return nil, bizerr.New("virtual_inventory.scriptRequired", "Script content is required")
+	}
+
+	return s.scriptDeliveryService.DryRunDeliveryScript(&inventory, opts), nil
+}
@@ -1,14 +1,17 @@
 package service
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
 	"auralogic/internal/config"
 	"auralogic/internal/models"
 	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/fieldcrypt"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -72,7 +75,7 @@ func TestDeleteVirtualInventoryReturnsBizErrors(t *testing.T) {
 		t.Fatalf("create stock: %v", err)
 	}
 
-	stockErr := requireBizErr(t, svc.DeleteVirtualInventory(inventory.ID), "virtual_inventory.hasStockItems")
+	stockErr := requireBizErr(t, svc.DeleteVirtualInventory(inventory.ID, 0), "virtual_inventory.hasStockItems")
 	if got := stockErr.Params["stock_count"]; got != int64(1) {
 		t.Fatalf("expected stock_count=1, got %#v", stockErr.Params)
 	}
@@ -103,12 +106,44 @@ func TestDeleteVirtualInventoryReturnsBizErrors(t *testing.T) {
 		t.Fatalf("create binding: %v", err)
 	}
 
-	bindingErr := requireBizErr(t, svc.DeleteVirtualInventory(boundInventory.ID), "virtual_inventory.hasProductBindings")
+	bindingErr := requireBizErr(t, svc.DeleteVirtualInventory(boundInventory.ID, 0), "virtual_inventory.hasProductBindings")
 	if got := bindingErr.Params["binding_count"]; got != int64(1) {
 		t.Fatalf("expected binding_count=1, got %#v", bindingErr.Params)
 	}
 }
 
+func TestUpdateVirtualInventoryRejectsStaleVersion(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+
+	inventory := models.VirtualInventory{Name: "Keys", Type: models.VirtualInventoryTypeScript, Script: "v1"}
+	if err := db.Create(&inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	if inventory.Version != 1 {
+		t.Fatalf("expected new inventory to start at version 1, got %d", inventory.Version)
+	}
+
+	// 两名管理员都基于版本 1 编辑，后保存的一方应被拒绝
+	if err := svc.UpdateVirtualInventory(inventory.ID, 1, map[string]interface{}{"script": "v2 by alice"}); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	requireBizErr(t, svc.UpdateVirtualInventory(inventory.ID, 1, map[string]interface{}{"script": "v2 by bob"}), "common.versionConflict")
+
+	var stored models.VirtualInventory
+	if err := db.First(&stored, inventory.ID).Error; err != nil {
+		t.Fatalf("load inventory: %v", err)
+	}
+	if stored.Script != "v2 by alice" || stored.Version != 2 {
+		t.Fatalf("expected first edit to win at version 2, got script=%q version=%d", stored.Script, stored.Version)
+	}
+
+	// 未提交版本的旧客户端不做校验
+	if err := svc.UpdateVirtualInventory(inventory.ID, 0, map[string]interface{}{"notes": "legacy"}); err != nil {
+		t.Fatalf("update without version: %v", err)
+	}
+	requireBizErr(t, svc.UpdateVirtualInventory(999, 0, map[string]interface{}{"notes": "x"}), "virtual_inventory.notFound")
+}
+
 func TestDeleteStockReturnsBizErrors(t *testing.T) {
 	svc, db := newVirtualInventoryServiceTestDB(t)
 
@@ -388,3 +423,191 @@ func TestGetStockByOrderNoHidesPresentationWhenInventoryDisablesIt(t *testing.T)
 		}
 	}
 }
+
+func TestVirtualStockContentIsEncryptedAtRest(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+
+	t.Setenv(fieldcrypt.KeyEnv, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err := fieldcrypt.Init(nil); err != nil {
+		t.Fatalf("init encryption: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv(fieldcrypt.KeyEnv)
+		_ = fieldcrypt.Init(nil)
+	})
+
+	inventory := &models.VirtualInventory{Name: "Cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	if _, err := svc.ImportFromText(inventory.ID, "CARD-A,first\nCARD-B", "admin"); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	var raw []string
+	if err := db.Model(&models.VirtualProductStock{}).Order("id").Pluck("content", &raw).Error; err != nil {
+		t.Fatalf("load raw content: %v", err)
+	}
+	for _, value := range raw {
+		if !fieldcrypt.IsEncrypted(value) || strings.Contains(value, "CARD-") {
+			t.Fatalf("expected encrypted content at rest, got %q", value)
+		}
+	}
+
+	stocks, _, err := svc.ListStocks(inventory.ID, "", 1, 10)
+	if err != nil {
+		t.Fatalf("list stocks: %v", err)
+	}
+	contents := map[string]bool{}
+	for _, stock := range stocks {
+		contents[stock.Content] = true
+	}
+	if !contents["CARD-A"] || !contents["CARD-B"] {
+		t.Fatalf("expected decrypted contents, got %+v", contents)
+	}
+}
+
+func TestEncryptPlaintextVirtualStocksMigratesLegacyRows(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+
+	inventory := &models.VirtualInventory{Name: "Legacy", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	// 未配置密钥时导入的历史明文
+	if _, err := svc.ImportFromText(inventory.ID, "LEGACY-CARD-0001\nLEGACY-CARD-0002", "admin"); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if _, err := EncryptPlaintextVirtualStocks(db, 1); !errors.Is(err, fieldcrypt.ErrKeyNotConfigured) {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+
+	t.Setenv(fieldcrypt.KeyEnv, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err := fieldcrypt.Init(nil); err != nil {
+		t.Fatalf("init encryption: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv(fieldcrypt.KeyEnv)
+		_ = fieldcrypt.Init(nil)
+	})
+
+	encrypted, err := EncryptPlaintextVirtualStocks(db, 1)
+	if err != nil || encrypted != 2 {
+		t.Fatalf("expected 2 rows encrypted, got %d err=%v", encrypted, err)
+	}
+	if again, err := EncryptPlaintextVirtualStocks(db, 1); err != nil || again != 0 {
+		t.Fatalf("expected rerun to be a no-op, got %d err=%v", again, err)
+	}
+
+	var stocks []models.VirtualProductStock
+	if err := db.Order("id").Find(&stocks).Error; err != nil {
+		t.Fatalf("load stocks: %v", err)
+	}
+	for _, stock := range stocks {
+		if !fieldcrypt.IsEncrypted(stock.Content) || stock.ContentHash == "" {
+			t.Fatalf("expected encrypted content with hash, got %+v", stock)
+		}
+	}
+	// 加密后的内容仍按明文哈希去重
+	result, err := svc.ImportFromText(inventory.ID, "LEGACY-CARD-0001", "admin")
+	if err != nil || result.Duplicates != 1 || result.Added != 0 {
+		t.Fatalf("expected duplicate of migrated row, got %+v err=%v", result, err)
+	}
+
+	revealed, err := svc.RevealStock(stocks[0].ID)
+	if err != nil || revealed.Content != "LEGACY-CARD-0001" {
+		t.Fatalf("expected revealed plaintext, got %+v err=%v", revealed, err)
+	}
+	_, err = svc.RevealStock(999)
+	requireBizErr(t, err, "virtual_inventory.stockItemNotFound")
+
+	listed, _, err := svc.ListStocks(inventory.ID, "", 1, 10)
+	if err != nil {
+		t.Fatalf("list stocks: %v", err)
+	}
+	MaskVirtualStockContents(listed)
+	for _, stock := range listed {
+		if strings.Contains(stock.Content, "LEGACY-CARD") || !strings.HasPrefix(stock.Content, "••••") {
+			t.Fatalf("expected masked content, got %q", stock.Content)
+		}
+	}
+}
+
+func TestVirtualStockImportSkipsDuplicatesAndInvalidRows(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+
+	t.Setenv(fieldcrypt.KeyEnv, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err := fieldcrypt.Init(nil); err != nil {
+		t.Fatalf("init encryption: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv(fieldcrypt.KeyEnv)
+		_ = fieldcrypt.Init(nil)
+	})
+
+	existing := &models.VirtualInventory{Name: "Old cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	inventory := &models.VirtualInventory{Name: "New cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	for _, inv := range []*models.VirtualInventory{existing, inventory} {
+		if err := db.Create(inv).Error; err != nil {
+			t.Fatalf("create inventory: %v", err)
+		}
+	}
+	if _, err := svc.ImportFromText(existing.ID, "CARD-OLD", "admin"); err != nil {
+		t.Fatalf("seed stock: %v", err)
+	}
+
+	text := strings.Join([]string{
+		"CARD-1,first",
+		"",
+		"CARD-2",
+		"CARD-1,again",
+		"CARD-OLD",
+		",remark only",
+		"CARD-3," + strings.Repeat("r", 501),
+		"CARD-\x07BELL",
+	}, "\n")
+	result, err := svc.ImportFromText(inventory.ID, text, "admin")
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Total != 7 || result.Added != 2 || result.Duplicates != 2 || result.Invalid != 3 {
+		t.Fatalf("unexpected import summary: %+v", result)
+	}
+	reasons := map[int]string{}
+	for _, issue := range result.Issues {
+		reasons[issue.Line] = issue.Reason
+	}
+	expected := map[int]string{
+		4: VirtualStockImportDuplicateInFile,
+		5: VirtualStockImportDuplicateExisting,
+		6: VirtualStockImportEmptyContent,
+		7: VirtualStockImportRemarkTooLong,
+		8: VirtualStockImportInvalidCharacters,
+	}
+	for line, reason := range expected {
+		if reasons[line] != reason {
+			t.Fatalf("expected line %d skipped as %s, got %+v", line, reason, result.Issues)
+		}
+	}
+
+	// 已加密入库的卡密仍按明文哈希去重
+	csvContent := "content,remark\nCARD-2,again\nCARD-4\n"
+	result, err = svc.ImportFromCSV(inventory.ID, strings.NewReader(csvContent), "admin")
+	if err != nil {
+		t.Fatalf("import csv: %v", err)
+	}
+	if result.Added != 1 || result.Duplicates != 1 || len(result.Issues) != 1 || result.Issues[0].Line != 2 {
+		t.Fatalf("unexpected csv import summary: %+v", result)
+	}
+
+	var count int64
+	db.Model(&models.VirtualProductStock{}).Where("virtual_inventory_id = ?", inventory.ID).Count(&count)
+	if count != 3 {
+		t.Fatalf("expected 3 imported stocks, got %d", count)
+	}
+	var hash string
+	db.Model(&models.VirtualProductStock{}).Where("virtual_inventory_id = ?", inventory.ID).Order("id").Limit(1).Pluck("content_hash", &hash)
+	if hash != models.LicenseKeyHash("CARD-1") {
+		t.Fatalf("expected content hash of plaintext, got %s", hash)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -11,6 +12,23 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+const (
+	// 脚本存储键的最大长度，与 key 列的 varchar(191) 一致
+	scriptStorageMaxKeyLength = 191
+	// 单个值的最大字节数（text 列上限为 64KB）
+	scriptStorageMaxValueBytes = 32 * 1024
+	// 每个虚拟库存最多保存的未过期键数量
+	scriptStorageMaxKeys = 1000
+	// 过期条目每批清理数量
+	scriptStoragePurgeBatchSize = 500
+)
+
+var (
+	errScriptStorageKeyInvalid = fmt.Errorf("storage key must be 1-%d characters", scriptStorageMaxKeyLength)
+	errScriptStorageValueSize  = fmt.Errorf("storage value exceeds %d bytes", scriptStorageMaxValueBytes)
+	errScriptStorageQuota      = fmt.Errorf("storage is limited to %d keys per virtual inventory", scriptStorageMaxKeys)
+)
+
 var (
 	virtualInventoryStorageLocks   = make(map[uint]*sync.RWMutex)
 	virtualInventoryStorageLocksMu sync.Mutex
@@ -34,9 +52,25 @@ func releaseVirtualInventoryStorageLock(virtualInventoryID uint) {
 	delete(virtualInventoryStorageLocks, virtualInventoryID)
 }
 
+// validateScriptStorageEntry 校验键和值的长度限制
+func validateScriptStorageEntry(key, value string) error {
+	if key == "" || len(key) > scriptStorageMaxKeyLength {
+		return errScriptStorageKeyInvalid
+	}
+	if len(value) > scriptStorageMaxValueBytes {
+		return errScriptStorageValueSize
+	}
+	return nil
+}
+
+// storageNotExpired 过滤已过期的条目；过期条目在被定期清理前对脚本不可见
+func storageNotExpired(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("expires_at IS NULL OR expires_at > ?", now)
+}
+
 func (s *ScriptDeliveryService) storageGetValue(virtualInventoryID uint, key string) (string, bool, error) {
 	var entry models.VirtualInventoryStorageEntry
-	err := s.db.Where(map[string]interface{}{
+	err := storageNotExpired(s.db, time.Now().UTC()).Where(map[string]interface{}{
 		"virtual_inventory_id": virtualInventoryID,
 		"key":                  key,
 	}).Take(&entry).Error
@@ -49,26 +83,52 @@ func (s *ScriptDeliveryService) storageGetValue(virtualInventoryID uint, key str
 	return entry.Value, true, nil
 }
 
-func (s *ScriptDeliveryService) storageSetValue(virtualInventoryID uint, key, value string) error {
+// storageSetValue 写入或覆盖键值，ttl 大于 0 时设置过期时间，否则永不过期
+func (s *ScriptDeliveryService) storageSetValue(virtualInventoryID uint, key, value string, ttl time.Duration) error {
+	if err := validateScriptStorageEntry(key, value); err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
-	entry := models.VirtualInventoryStorageEntry{
-		VirtualInventoryID: virtualInventoryID,
-		Key:                key,
-		Value:              value,
-		CreatedAt:          now,
-		UpdatedAt:          now,
+	var expiresAt *time.Time
+	if ttl > 0 {
+		t := now.Add(ttl)
+		expiresAt = &t
 	}
 
-	return s.db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "virtual_inventory_id"},
-			{Name: "key"},
-		},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"value":      value,
-			"updated_at": now,
-		}),
-	}).Create(&entry).Error
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 覆盖已有键不占用新配额，因此统计时排除当前键
+		var count int64
+		if err := storageNotExpired(tx.Model(&models.VirtualInventoryStorageEntry{}), now).
+			Where("virtual_inventory_id = ?", virtualInventoryID).
+			Where(clause.Neq{Column: clause.Column{Name: "key"}, Value: key}).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= scriptStorageMaxKeys {
+			return errScriptStorageQuota
+		}
+
+		entry := models.VirtualInventoryStorageEntry{
+			VirtualInventoryID: virtualInventoryID,
+			Key:                key,
+			Value:              value,
+			ExpiresAt:          expiresAt,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "virtual_inventory_id"},
+				{Name: "key"},
+			},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"value":      value,
+				"expires_at": expiresAt,
+				"updated_at": now,
+			}),
+		}).Create(&entry).Error
+	})
 }
 
 func (s *ScriptDeliveryService) storageDeleteKey(virtualInventoryID uint, key string) error {
@@ -80,7 +140,7 @@ func (s *ScriptDeliveryService) storageDeleteKey(virtualInventoryID uint, key st
 
 func (s *ScriptDeliveryService) storageListKeys(virtualInventoryID uint) ([]string, error) {
 	keys := make([]string, 0)
-	err := s.db.Model(&models.VirtualInventoryStorageEntry{}).
+	err := storageNotExpired(s.db.Model(&models.VirtualInventoryStorageEntry{}), time.Now().UTC()).
 		Where("virtual_inventory_id = ?", virtualInventoryID).
 		Order(clause.OrderByColumn{Column: clause.Column{Name: "key"}}).
 		Pluck("key", &keys).Error
@@ -94,3 +154,29 @@ func (s *ScriptDeliveryService) storageClearAll(virtualInventoryID uint) error {
 	return s.db.Where("virtual_inventory_id = ?", virtualInventoryID).
 		Delete(&models.VirtualInventoryStorageEntry{}).Error
 }
+
+// purgeExpiredStorageEntries 分批删除已过期的脚本存储条目，返回删除数量
+func purgeExpiredStorageEntries(db *gorm.DB, now time.Time) (int64, error) {
+	var total int64
+	for {
+		var ids []uint
+		if err := db.Model(&models.VirtualInventoryStorageEntry{}).
+			Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+			Order("id").
+			Limit(scriptStoragePurgeBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+		result := db.Where("id IN ?", ids).Delete(&models.VirtualInventoryStorageEntry{})
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if len(ids) < scriptStoragePurgeBatchSize {
+			return total, nil
+		}
+	}
+}
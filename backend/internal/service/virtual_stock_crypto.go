@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/fieldcrypt"
+
+	"gorm.io/gorm"
+)
+
+// sealVirtualStockContents 入库前加密卡密内容（未配置密钥时保持明文），内容哈希按明文计算
+func sealVirtualStockContents(stocks []models.VirtualProductStock) error {
+	for i := range stocks {
+		if stocks[i].Content != "" && !fieldcrypt.IsEncrypted(stocks[i].Content) {
+			stocks[i].ContentHash = models.LicenseKeyHash(stocks[i].Content)
+		}
+		sealed, err := fieldcrypt.Encrypt(stocks[i].Content)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt stock content: %w", err)
+		}
+		stocks[i].Content = sealed
+	}
+	return nil
+}
+
+// revealVirtualStockContents 在发货/查看时解密卡密内容，历史明文原样返回
+func revealVirtualStockContents(stocks []models.VirtualProductStock) error {
+	for i := range stocks {
+		plain, err := fieldcrypt.Decrypt(stocks[i].Content)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt stock %d content: %w", stocks[i].ID, err)
+		}
+		stocks[i].Content = plain
+	}
+	return nil
+}
+
+// maskedContentVisibleSuffix 列表中保留的卡密末尾字符数，便于管理员核对
+const maskedContentVisibleSuffix = 4
+
+// MaskVirtualStockContents 管理端列表隐藏卡密明文，只保留较长内容的末尾几位；查看完整内容需单独 reveal 并记录审计
+func MaskVirtualStockContents(stocks []models.VirtualProductStock) {
+	for i := range stocks {
+		stocks[i].Content = maskVirtualStockContent(stocks[i].Content)
+	}
+}
+
+func maskVirtualStockContent(content string) string {
+	if content == "" {
+		return ""
+	}
+	runes := []rune(content)
+	if len(runes) < maskedContentVisibleSuffix*3 {
+		return "••••••••"
+	}
+	return "••••••••" + string(runes[len(runes)-maskedContentVisibleSuffix:])
+}
+
+// RevealStock 解密单个库存项的卡密内容，供管理员显式查看
+func (s *VirtualInventoryService) RevealStock(stockID uint) (*models.VirtualProductStock, error) {
+	var stock models.VirtualProductStock
+	if err := s.db.First(&stock, stockID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bizerr.New("virtual_inventory.stockItemNotFound", "Stock item not found")
+		}
+		return nil, err
+	}
+	stocks := []models.VirtualProductStock{stock}
+	if err := revealVirtualStockContents(stocks); err != nil {
+		return nil, err
+	}
+	return &stocks[0], nil
+}
+
+// EncryptPlaintextVirtualStocks 将启用加密前写入的明文卡密加密，返回加密的条数
+// 可重复执行：已加密的行跳过；内容哈希按明文补齐，保证导入去重不受影响
+func EncryptPlaintextVirtualStocks(db *gorm.DB, batchSize int) (int, error) {
+	if !fieldcrypt.Enabled() {
+		return 0, fieldcrypt.ErrKeyNotConfigured
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	var stocks []models.VirtualProductStock
+	encrypted := 0
+	err := db.Select("id", "content", "content_hash").
+		Where("content <> ''").
+		FindInBatches(&stocks, batchSize, func(tx *gorm.DB, batch int) error {
+			for _, stock := range stocks {
+				if fieldcrypt.IsEncrypted(stock.Content) {
+					continue
+				}
+				sealed, err := fieldcrypt.Encrypt(stock.Content)
+				if err != nil {
+					return fmt.Errorf("failed to encrypt stock %d content: %w", stock.ID, err)
+				}
+				// 条件更新避免覆盖执行期间被改写的内容
+				result := db.Model(&models.VirtualProductStock{}).
+					Where("id = ? AND content = ?", stock.ID, stock.Content).
+					UpdateColumns(map[string]interface{}{
+						"content":      sealed,
+						"content_hash": models.LicenseKeyHash(stock.Content),
+					})
+				if result.Error != nil {
+					return result.Error
+				}
+				encrypted += int(result.RowsAffected)
+			}
+			return nil
+		}).Error
+	return encrypted, err
+}
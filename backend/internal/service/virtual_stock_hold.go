@@ -0,0 +1,190 @@
+package service
+
+import (
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultVirtualStockHoldTTL 付款开始时暂扣库存的默认有效期
+const DefaultVirtualStockHoldTTL = 15 * time.Minute
+
+// VirtualStockHold 付款开始时暂扣的虚拟库存
+// 静态库存以 held 状态 + 暂扣凭证锁定，确认付款时才写入订单号；脚本类型库存不创建占位记录
+type VirtualStockHold struct {
+	Token             string    `json:"token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	StockIDs          []uint    `json:"stock_ids"`
+	ScriptInventoryID *uint     `json:"script_inventory_id,omitempty"`
+}
+
+func newVirtualStockHoldNotFoundError() error {
+	return bizerr.New("virtual_inventory.holdNotFound", "Virtual stock hold not found or already released")
+}
+
+func newVirtualStockHoldExpiredError() error {
+	return bizerr.New("virtual_inventory.holdExpired", "Virtual stock hold has expired, please try again")
+}
+
+// HoldStockForProductByAttributes 付款开始时按规格属性暂扣虚拟库存
+// 暂扣的库存不属于任何订单，其他请求无法再占用；ttl <= 0 时使用默认有效期
+func (s *VirtualInventoryService) HoldStockForProductByAttributes(productID uint, quantity int, attributes map[string]interface{}, ttl time.Duration) (*VirtualStockHold, error) {
+	if ttl <= 0 {
+		ttl = DefaultVirtualStockHoldTTL
+	}
+	bindings, err := s.resolveAllocationBindings(productID, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := &VirtualStockHold{
+		Token:     uuid.NewString(),
+		ExpiresAt: models.NowFunc().Add(ttl),
+		StockIDs:  []uint{},
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		stocks, scriptInventoryID, err := s.claimFromBindings(tx, bindings, quantity, map[string]interface{}{
+			"status":     models.VirtualStockStatusHeld,
+			"hold_token": hold.Token,
+			"held_until": hold.ExpiresAt,
+		}, "", "Hold stock for payment")
+		if err != nil {
+			return err
+		}
+		for _, stock := range stocks {
+			hold.StockIDs = append(hold.StockIDs, stock.ID)
+		}
+		hold.ScriptInventoryID = scriptInventoryID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// ConfirmHold 付款确认后将暂扣的库存绑定到订单（转为已预留，后续按原流程发货）
+// 暂扣已过期时释放剩余库存并返回 virtual_inventory.holdExpired
+func (s *VirtualInventoryService) ConfirmHold(token string, orderNo string) ([]models.VirtualProductStock, error) {
+	if token == "" {
+		return nil, newVirtualStockHoldNotFoundError()
+	}
+
+	var confirmed []models.VirtualProductStock
+	expired := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var held []models.VirtualProductStock
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("hold_token = ? AND status = ?", token, models.VirtualStockStatusHeld).
+			Find(&held).Error; err != nil {
+			return err
+		}
+		if len(held) == 0 {
+			return newVirtualStockHoldNotFoundError()
+		}
+
+		now := models.NowFunc()
+		for _, stock := range held {
+			if stock.HeldUntil == nil || !stock.HeldUntil.After(now) {
+				expired = true
+				return nil
+			}
+		}
+
+		result := tx.Model(&models.VirtualProductStock{}).
+			Where("hold_token = ? AND status = ?", token, models.VirtualStockStatusHeld).
+			Updates(map[string]interface{}{
+				"status":     models.VirtualStockStatusReserved,
+				"order_no":   orderNo,
+				"hold_token": "",
+				"held_until": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(held)) {
+			// 期间有库存被过期任务释放，整体回滚
+			expired = true
+			return newVirtualStockHoldExpiredError()
+		}
+
+		invCounts := make(map[uint]int)
+		for i := range held {
+			held[i].MarkAsReserved(orderNo)
+			held[i].HoldToken = ""
+			held[i].HeldUntil = nil
+			invCounts[held[i].VirtualInventoryID]++
+		}
+		for invID, count := range invCounts {
+			s.createVirtualInventoryLog(tx, invID, models.InventoryLogTypeReserve, count, orderNo, "", "system", "Confirm held stock for order")
+		}
+		confirmed = held
+		return nil
+	})
+	if expired {
+		if _, releaseErr := s.ReleaseHold(token); releaseErr != nil {
+			return nil, releaseErr
+		}
+		return nil, newVirtualStockHoldExpiredError()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return confirmed, nil
+}
+
+// ReleaseHold 付款取消或失败时释放暂扣的库存，返回释放的数量
+func (s *VirtualInventoryService) ReleaseHold(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	return s.releaseHeldStocks(s.db.Where("hold_token = ?", token), "Release held stock")
+}
+
+// ReleaseExpiredHolds 释放已过期未确认的暂扣库存，由后台任务定期调用
+func (s *VirtualInventoryService) ReleaseExpiredHolds(now time.Time) (int64, error) {
+	return s.releaseHeldStocks(s.db.Where("held_until < ?", now), "Release expired stock hold")
+}
+
+func (s *VirtualInventoryService) releaseHeldStocks(scope *gorm.DB, reason string) (int64, error) {
+	var released int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var held []models.VirtualProductStock
+		if err := tx.Select("id, virtual_inventory_id").
+			Where(scope).
+			Where("status = ?", models.VirtualStockStatusHeld).
+			Find(&held).Error; err != nil {
+			return err
+		}
+		if len(held) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(held))
+		invCounts := make(map[uint]int)
+		for _, stock := range held {
+			ids = append(ids, stock.ID)
+			invCounts[stock.VirtualInventoryID]++
+		}
+		result := tx.Model(&models.VirtualProductStock{}).
+			Where("id IN ? AND status = ?", ids, models.VirtualStockStatusHeld).
+			Updates(map[string]interface{}{
+				"status":     models.VirtualStockStatusAvailable,
+				"hold_token": "",
+				"held_until": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		released = result.RowsAffected
+		for invID, count := range invCounts {
+			s.createVirtualInventoryLog(tx, invID, models.InventoryLogTypeRelease, count, "", "", "system", reason)
+		}
+		return nil
+	})
+	return released, err
+}
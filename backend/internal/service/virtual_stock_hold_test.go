@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"auralogic/internal/models"
+)
+
+func TestVirtualStockHoldLifecycle(t *testing.T) {
+	svc, db := newVirtualInventoryServiceTestDB(t)
+
+	inventory := &models.VirtualInventory{
+		Name:     "Hold inventory",
+		Type:     models.VirtualInventoryTypeStatic,
+		IsActive: true,
+	}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	product := &models.Product{
+		SKU:         "virtual-hold-1",
+		Name:        "Virtual Hold Product",
+		ProductType: models.ProductTypeVirtual,
+		Status:      models.ProductStatusActive,
+		Stock:       3,
+	}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := db.Create(&models.ProductVirtualInventoryBinding{
+		ProductID:          product.ID,
+		VirtualInventoryID: inventory.ID,
+		IsRandom:           true,
+		Priority:           1,
+	}).Error; err != nil {
+		t.Fatalf("create binding: %v", err)
+	}
+	for _, content := range []string{"CARD-H1", "CARD-H2", "CARD-H3"} {
+		if err := db.Create(&models.VirtualProductStock{
+			VirtualInventoryID: inventory.ID,
+			Content:            content,
+			Status:             models.VirtualStockStatusAvailable,
+		}).Error; err != nil {
+			t.Fatalf("create stock: %v", err)
+		}
+	}
+
+	first, err := svc.HoldStockForProductByAttributes(product.ID, 1, nil, 0)
+	if err != nil {
+		t.Fatalf("hold first: %v", err)
+	}
+	second, err := svc.HoldStockForProductByAttributes(product.ID, 1, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("hold second: %v", err)
+	}
+	if len(first.StockIDs) != 1 || len(second.StockIDs) != 1 || first.StockIDs[0] == second.StockIDs[0] {
+		t.Fatalf("expected two holds on distinct stock, got %v / %v", first.StockIDs, second.StockIDs)
+	}
+
+	confirmed, err := svc.ConfirmHold(first.Token, "ORD-HOLD-1")
+	if err != nil {
+		t.Fatalf("confirm hold: %v", err)
+	}
+	if len(confirmed) != 1 {
+		t.Fatalf("expected one confirmed stock, got %d", len(confirmed))
+	}
+	var stock models.VirtualProductStock
+	if err := db.First(&stock, first.StockIDs[0]).Error; err != nil {
+		t.Fatalf("load confirmed stock: %v", err)
+	}
+	if stock.Status != models.VirtualStockStatusReserved || stock.OrderNo != "ORD-HOLD-1" || stock.HoldToken != "" {
+		t.Fatalf("expected reserved stock bound to order, got %+v", stock)
+	}
+	requireBizErr(t, func() error { _, err := svc.ConfirmHold(first.Token, "ORD-HOLD-1"); return err }(), "virtual_inventory.holdNotFound")
+
+	// 暂扣过期后确认失败，并释放库存
+	if err := db.Model(&models.VirtualProductStock{}).
+		Where("hold_token = ?", second.Token).
+		Update("held_until", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire hold: %v", err)
+	}
+	_, err = svc.ConfirmHold(second.Token, "ORD-HOLD-2")
+	requireBizErr(t, err, "virtual_inventory.holdExpired")
+	var expiredStock models.VirtualProductStock
+	if err := db.First(&expiredStock, second.StockIDs[0]).Error; err != nil {
+		t.Fatalf("load expired stock: %v", err)
+	}
+	if expiredStock.Status != models.VirtualStockStatusAvailable || expiredStock.OrderNo != "" {
+		t.Fatalf("expected expired hold released, got %+v", expiredStock)
+	}
+
+	third, err := svc.HoldStockForProductByAttributes(product.ID, 2, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("hold third: %v", err)
+	}
+	released, err := svc.ReleaseExpiredHolds(time.Now())
+	if err != nil || released != 0 {
+		t.Fatalf("expected no unexpired holds released, got %d err=%v", released, err)
+	}
+	released, err = svc.ReleaseExpiredHolds(third.ExpiresAt.Add(time.Second))
+	if err != nil || released != 2 {
+		t.Fatalf("expected two expired holds released, got %d err=%v", released, err)
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxVirtualStockImportRows 单次导入的最大行数
+	maxVirtualStockImportRows = 100000
+	// maxVirtualStockContentLength 单条卡密的最大字符数
+	maxVirtualStockContentLength = 1000
+	// maxVirtualStockRemarkLength 备注最大字符数，与 remark 列宽一致
+	maxVirtualStockRemarkLength = 500
+	// maxVirtualStockImportIssues 导入结果中最多返回的跳过行明细
+	maxVirtualStockImportIssues = 100
+
+	virtualStockImportBatchSize = 500
+	virtualStockHashLookupSize  = 500
+)
+
+// 导入跳过原因
+const (
+	VirtualStockImportDuplicateInFile   = "duplicate_in_file"
+	VirtualStockImportDuplicateExisting = "duplicate_existing"
+	VirtualStockImportEmptyContent      = "empty_content"
+	VirtualStockImportContentTooLong    = "content_too_long"
+	VirtualStockImportRemarkTooLong     = "remark_too_long"
+	VirtualStockImportInvalidCharacters = "invalid_characters"
+)
+
+var errVirtualStockImportTooManyRows = bizerr.Register("virtual_inventory.importTooManyRows", 400, "Too many rows to import in one batch")
+
+// VirtualStockImportIssue 导入时被跳过的行
+type VirtualStockImportIssue struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// VirtualStockImportResult 批量导入汇总：新增、重复与无效行数
+type VirtualStockImportResult struct {
+	BatchNo    string                    `json:"batch_no,omitempty"`
+	Total      int                       `json:"total"`
+	Added      int                       `json:"added"`
+	Duplicates int                       `json:"duplicates"`
+	Invalid    int                       `json:"invalid"`
+	Issues     []VirtualStockImportIssue `json:"issues"`
+}
+
+func (r *VirtualStockImportResult) skip(line int, reason string) {
+	if reason == VirtualStockImportDuplicateInFile || reason == VirtualStockImportDuplicateExisting {
+		r.Duplicates++
+	} else {
+		r.Invalid++
+	}
+	if len(r.Issues) < maxVirtualStockImportIssues {
+		r.Issues = append(r.Issues, VirtualStockImportIssue{Line: line, Reason: reason})
+	}
+}
+
+// virtualStockImportRow 解析后的一行导入数据，Line 为源文件中的行号（从 1 开始）
+type virtualStockImportRow struct {
+	Line    int
+	Content string
+	Remark  string
+}
+
+func newVirtualStockImportRow(line int, content, remark string) virtualStockImportRow {
+	if line == 1 {
+		content = strings.TrimPrefix(content, "\ufeff")
+	}
+	return virtualStockImportRow{
+		Line:    line,
+		Content: strings.TrimSpace(content),
+		Remark:  strings.TrimSpace(remark),
+	}
+}
+
+// isVirtualStockImportHeader 识别常见的标题行
+func isVirtualStockImportHeader(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(value, "\ufeff"))) {
+	case "content", "code", "卡密", "激活码":
+		return true
+	}
+	return false
+}
+
+func validateVirtualStockImportRow(row virtualStockImportRow) string {
+	if row.Content == "" {
+		return VirtualStockImportEmptyContent
+	}
+	if utf8.RuneCountInString(row.Content) > maxVirtualStockContentLength {
+		return VirtualStockImportContentTooLong
+	}
+	if utf8.RuneCountInString(row.Remark) > maxVirtualStockRemarkLength {
+		return VirtualStockImportRemarkTooLong
+	}
+	if !utf8.ValidString(row.Content) || strings.IndexFunc(row.Content, unicode.IsControl) >= 0 {
+		return VirtualStockImportInvalidCharacters
+	}
+	return ""
+}
+
+// importStockRows 校验并去重后在一个事务内分批写入库存
+// 文件内重复与已有库存重复（按内容哈希，含其他库存池）的卡密都会跳过，避免同一激活码发给多个买家
+func (s *VirtualInventoryService) importStockRows(virtualInventoryID uint, rows []virtualStockImportRow, importedBy, source, reason string) (*VirtualStockImportResult, error) {
+	if len(rows) == 0 {
+		return nil, bizerr.New("virtual_inventory.importNoValidData", "No valid stock data found for import").
+			WithParams(map[string]interface{}{"source": source})
+	}
+	if len(rows) > maxVirtualStockImportRows {
+		return nil, errVirtualStockImportTooManyRows.New().
+			WithParams(map[string]interface{}{"max": maxVirtualStockImportRows, "rows": len(rows)})
+	}
+
+	result := &VirtualStockImportResult{
+		BatchNo: fmt.Sprintf("BATCH-%s", time.Now().Format("20060102150405")),
+		Total:   len(rows),
+		Issues:  []VirtualStockImportIssue{},
+	}
+
+	valid := make([]virtualStockImportRow, 0, len(rows))
+	hashes := make([]string, 0, len(rows))
+	seen := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if issue := validateVirtualStockImportRow(row); issue != "" {
+			result.skip(row.Line, issue)
+			continue
+		}
+		hash := models.LicenseKeyHash(row.Content)
+		if _, ok := seen[hash]; ok {
+			result.skip(row.Line, VirtualStockImportDuplicateInFile)
+			continue
+		}
+		seen[hash] = struct{}{}
+		valid = append(valid, row)
+		hashes = append(hashes, hash)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		existing := make(map[string]struct{})
+		for start := 0; start < len(hashes); start += virtualStockHashLookupSize {
+			end := start + virtualStockHashLookupSize
+			if end > len(hashes) {
+				end = len(hashes)
+			}
+			var found []string
+			if err := tx.Model(&models.VirtualProductStock{}).
+				Where("content_hash IN ?", hashes[start:end]).
+				Distinct().Pluck("content_hash", &found).Error; err != nil {
+				return fmt.Errorf("failed to check existing stocks: %w", err)
+			}
+			for _, hash := range found {
+				existing[hash] = struct{}{}
+			}
+		}
+
+		stocks := make([]models.VirtualProductStock, 0, len(valid))
+		for i, row := range valid {
+			if _, ok := existing[hashes[i]]; ok {
+				result.skip(row.Line, VirtualStockImportDuplicateExisting)
+				continue
+			}
+			stocks = append(stocks, models.VirtualProductStock{
+				VirtualInventoryID: virtualInventoryID,
+				Content:            row.Content,
+				Remark:             row.Remark,
+				Status:             models.VirtualStockStatusAvailable,
+				BatchNo:            result.BatchNo,
+				ImportedBy:         importedBy,
+			})
+		}
+		if len(stocks) == 0 {
+			return nil
+		}
+
+		if err := sealVirtualStockContents(stocks); err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(&stocks, virtualStockImportBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to insert stocks: %w", err)
+		}
+		result.Added = len(stocks)
+
+		s.createVirtualInventoryLog(tx, virtualInventoryID, models.InventoryLogTypeImport, len(stocks), "", result.BatchNo, importedBy,
+			fmt.Sprintf("%s (duplicates: %d, invalid: %d)", reason, result.Duplicates, result.Invalid))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Added == 0 {
+		result.BatchNo = ""
+	}
+	return result, nil
+}
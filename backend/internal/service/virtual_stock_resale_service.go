@@ -0,0 +1,224 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"gorm.io/gorm"
+)
+
+const (
+	maxResaleCodeLength   = 1000
+	maxResaleSourceLength = 500
+	maxResaleNoteLength   = 1000
+)
+
+var errResaleCodeInvalid = bizerr.Register("virtual_stock.resaleCodeInvalid", 400, "Code must be between 1 and 1000 characters")
+
+// VirtualStockResaleCheckInput 核查站外出现的卡密
+type VirtualStockResaleCheckInput struct {
+	Code   string `json:"code"`
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+// VirtualStockResaleBuyer 命中订单的买家
+type VirtualStockResaleBuyer struct {
+	ID    uint   `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// VirtualStockResaleDelivery 卡密的一次发货记录
+type VirtualStockResaleDelivery struct {
+	StockID            uint                             `json:"stock_id"`
+	StockStatus        models.VirtualProductStockStatus `json:"stock_status"`
+	VirtualInventoryID uint                             `json:"virtual_inventory_id"`
+	InventoryName      string                           `json:"inventory_name,omitempty"`
+	OrderID            uint                             `json:"order_id"`
+	OrderNo            string                           `json:"order_no"`
+	OrderStatus        models.OrderStatus               `json:"order_status,omitempty"`
+	PaidAt             *time.Time                       `json:"paid_at,omitempty"`
+	DeliveredAt        *time.Time                       `json:"delivered_at,omitempty"`
+	Refunded           bool                             `json:"refunded"`
+	Buyer              *VirtualStockResaleBuyer         `json:"buyer,omitempty"`
+}
+
+// VirtualStockResaleCheckResult 核查结果；PreviousReports 为此前对同一卡密的核查次数
+type VirtualStockResaleCheckResult struct {
+	ReportID        uint                         `json:"report_id"`
+	Matched         bool                         `json:"matched"`
+	Deliveries      []VirtualStockResaleDelivery `json:"deliveries"`
+	PreviousReports int64                        `json:"previous_reports"`
+	FirstReportedAt *time.Time                   `json:"first_reported_at,omitempty"`
+}
+
+// VirtualStockResaleService 灰色市场转售核查：按发货卡密的哈希登记查找售出订单与买家
+type VirtualStockResaleService struct {
+	db *gorm.DB
+}
+
+func NewVirtualStockResaleService(db *gorm.DB) *VirtualStockResaleService {
+	return &VirtualStockResaleService{db: db}
+}
+
+// Check 按卡密的带密钥哈希查找发货记录并登记本次核查，哈希按明文计算，已加密的库存同样可以命中
+// 包括已软删除的库存项：卡密一旦发出，即使库存记录被清理也应能追溯
+func (s *VirtualStockResaleService) Check(input VirtualStockResaleCheckInput, reportedBy *uint) (*VirtualStockResaleCheckResult, error) {
+	code := strings.TrimSpace(input.Code)
+	if code == "" || len(code) > maxResaleCodeLength {
+		return nil, errResaleCodeInvalid.New()
+	}
+	hash := models.LicenseKeyHash(code)
+
+	var stocks []models.VirtualProductStock
+	if err := s.db.Unscoped().Preload("VirtualInventory").
+		Select("id", "virtual_inventory_id", "status", "order_id", "order_no", "delivered_at").
+		Where("content_hash = ? AND order_id IS NOT NULL", hash).
+		Order("id DESC").
+		Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.buildDeliveries(stocks)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VirtualStockResaleCheckResult{Matched: len(deliveries) > 0, Deliveries: deliveries}
+	var previous []models.VirtualStockResaleReport
+	if err := s.db.Select("id", "created_at").Where("content_hash = ?", hash).Order("id ASC").Find(&previous).Error; err != nil {
+		return nil, err
+	}
+	result.PreviousReports = int64(len(previous))
+	if len(previous) > 0 {
+		first := previous[0].CreatedAt
+		result.FirstReportedAt = &first
+	}
+
+	report := &models.VirtualStockResaleReport{
+		ContentHash: hash,
+		Matched:     result.Matched,
+		MatchCount:  len(deliveries),
+		Source:      truncateString(strings.TrimSpace(input.Source), maxResaleSourceLength),
+		Note:        truncateString(strings.TrimSpace(input.Note), maxResaleNoteLength),
+		ReportedBy:  reportedBy,
+		CreatedAt:   models.NowFunc(),
+	}
+	if result.Matched {
+		latest := deliveries[0]
+		report.StockID = &latest.StockID
+		report.OrderID = &latest.OrderID
+		report.OrderNo = latest.OrderNo
+		report.Refunded = latest.Refunded
+		if latest.Buyer != nil {
+			report.UserID = &latest.Buyer.ID
+		}
+	}
+	if err := s.db.Create(report).Error; err != nil {
+		return nil, err
+	}
+	result.ReportID = report.ID
+	return result, nil
+}
+
+// buildDeliveries 批量加载订单、买家与退款状态
+func (s *VirtualStockResaleService) buildDeliveries(stocks []models.VirtualProductStock) ([]VirtualStockResaleDelivery, error) {
+	deliveries := make([]VirtualStockResaleDelivery, 0, len(stocks))
+	if len(stocks) == 0 {
+		return deliveries, nil
+	}
+
+	orderIDs := make([]uint, 0, len(stocks))
+	for _, stock := range stocks {
+		orderIDs = append(orderIDs, *stock.OrderID)
+	}
+	var orders []models.Order
+	if err := s.db.Unscoped().Select("id", "order_no", "user_id", "status", "paid_at").
+		Where("id IN ?", orderIDs).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	ordersByID := make(map[uint]models.Order, len(orders))
+	userIDs := make([]uint, 0, len(orders))
+	for _, order := range orders {
+		ordersByID[order.ID] = order
+		if order.UserID != nil {
+			userIDs = append(userIDs, *order.UserID)
+		}
+	}
+
+	usersByID := make(map[uint]models.User, len(userIDs))
+	if len(userIDs) > 0 {
+		var users []models.User
+		if err := s.db.Unscoped().Select("id", "email", "name").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			usersByID[user.ID] = user
+		}
+	}
+
+	// 部分退款不改变订单状态，需单独查询已完成的退款记录
+	var refundedOrderIDs []uint
+	if err := s.db.Model(&models.OrderRefund{}).
+		Where("order_id IN ? AND status = ?", orderIDs, models.OrderRefundStatusRefunded).
+		Distinct().Pluck("order_id", &refundedOrderIDs).Error; err != nil {
+		return nil, err
+	}
+	partiallyRefunded := make(map[uint]bool, len(refundedOrderIDs))
+	for _, id := range refundedOrderIDs {
+		partiallyRefunded[id] = true
+	}
+
+	for _, stock := range stocks {
+		delivery := VirtualStockResaleDelivery{
+			StockID:            stock.ID,
+			StockStatus:        stock.Status,
+			VirtualInventoryID: stock.VirtualInventoryID,
+			OrderID:            *stock.OrderID,
+			OrderNo:            stock.OrderNo,
+			DeliveredAt:        stock.DeliveredAt,
+			Refunded:           partiallyRefunded[*stock.OrderID],
+		}
+		if stock.VirtualInventory != nil {
+			delivery.InventoryName = stock.VirtualInventory.Name
+		}
+		if order, ok := ordersByID[*stock.OrderID]; ok {
+			delivery.OrderNo = order.OrderNo
+			delivery.OrderStatus = order.Status
+			delivery.PaidAt = order.PaidAt
+			if order.Status == models.OrderStatusRefunded || order.Status == models.OrderStatusRefundPending {
+				delivery.Refunded = true
+			}
+			if order.UserID != nil {
+				if user, ok := usersByID[*order.UserID]; ok {
+					delivery.Buyer = &VirtualStockResaleBuyer{ID: user.ID, Email: user.Email, Name: user.Name}
+				}
+			}
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// ListReports 分页查询核查记录，可按是否命中与订单过滤
+func (s *VirtualStockResaleService) ListReports(matched *bool, orderID uint, page, limit int) ([]models.VirtualStockResaleReport, int64, error) {
+	query := s.db.Model(&models.VirtualStockResaleReport{})
+	if matched != nil {
+		query = query.Where("matched = ?", *matched)
+	}
+	if orderID > 0 {
+		query = query.Where("order_id = ?", orderID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reports []models.VirtualStockResaleReport
+	if err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/fieldcrypt"
+)
+
+func TestVirtualStockResaleCheckFindsBuyerAndRefunds(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.User{}, &models.Order{}, &models.OrderRefund{}, &models.VirtualStockResaleReport{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	resale := NewVirtualStockResaleService(db)
+
+	buyer := &models.User{Email: "fraud@example.com", Name: "Reseller", Role: "user", IsActive: true}
+	if err := db.Create(buyer).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	order := &models.Order{OrderNo: "RESALE-1", UserID: &buyer.ID, Status: models.OrderStatusRefunded}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "Gift cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	sold := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "GIFT-0001-AAAA"}
+	unsold := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "GIFT-0002-BBBB"}
+	for _, stock := range []*models.VirtualProductStock{sold, unsold} {
+		if err := db.Create(stock).Error; err != nil {
+			t.Fatalf("create stock: %v", err)
+		}
+	}
+	sold.MarkAsSold(order.ID, order.OrderNo)
+	if err := db.Save(sold).Error; err != nil {
+		t.Fatalf("mark stock sold: %v", err)
+	}
+
+	_, err := resale.Check(VirtualStockResaleCheckInput{Code: "   "}, nil)
+	requireOrderBizErr(t, err, "virtual_stock.resaleCodeInvalid")
+
+	// 站外出现的卡密（带首尾空白）命中已退款订单与买家
+	result, err := resale.Check(VirtualStockResaleCheckInput{Code: " GIFT-0001-AAAA\n", Source: "https://market.example.com/item/9"}, nil)
+	if err != nil || !result.Matched || len(result.Deliveries) != 1 || result.PreviousReports != 0 {
+		t.Fatalf("expected one matched delivery, got %+v err=%v", result, err)
+	}
+	delivery := result.Deliveries[0]
+	if delivery.OrderNo != "RESALE-1" || !delivery.Refunded || delivery.Buyer == nil ||
+		delivery.Buyer.Email != "fraud@example.com" || delivery.InventoryName != "Gift cards" {
+		t.Fatalf("unexpected delivery: %+v buyer=%+v", delivery, delivery.Buyer)
+	}
+
+	// 再次核查同一卡密时返回此前的核查次数
+	again, err := resale.Check(VirtualStockResaleCheckInput{Code: "GIFT-0001-AAAA"}, nil)
+	if err != nil || again.PreviousReports != 1 || again.FirstReportedAt == nil {
+		t.Fatalf("expected previous report, got %+v err=%v", again, err)
+	}
+
+	// 未售出或不存在的卡密不命中
+	for _, code := range []string{"GIFT-0002-BBBB", "NOT-OURS"} {
+		miss, err := resale.Check(VirtualStockResaleCheckInput{Code: code}, nil)
+		if err != nil || miss.Matched || len(miss.Deliveries) != 0 {
+			t.Fatalf("expected no match for %s, got %+v err=%v", code, miss, err)
+		}
+	}
+
+	matched := true
+	reports, total, err := resale.ListReports(&matched, order.ID, 1, 10)
+	if err != nil || total != 2 || reports[0].UserID == nil || *reports[0].UserID != buyer.ID || !reports[0].Refunded {
+		t.Fatalf("unexpected matched reports: %+v total=%d err=%v", reports, total, err)
+	}
+	if _, total, _ := resale.ListReports(nil, 0, 1, 10); total != 4 {
+		t.Fatalf("expected 4 reports, got %d", total)
+	}
+}
+
+func TestVirtualStockResaleCheckMatchesEncryptedStockWithKeyedHash(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.User{}, &models.Order{}, &models.OrderRefund{}, &models.VirtualStockResaleReport{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	t.Setenv(fieldcrypt.KeyEnv, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err := fieldcrypt.Init(nil); err != nil {
+		t.Fatalf("init encryption: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv(fieldcrypt.KeyEnv)
+		_ = fieldcrypt.Init(nil)
+	})
+	resale := NewVirtualStockResaleService(db)
+
+	order := &models.Order{OrderNo: "RESALE-ENC-1", Status: models.OrderStatusCompleted}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "Gift cards", Type: models.VirtualInventoryTypeStatic, IsActive: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	stocks := []models.VirtualProductStock{{VirtualInventoryID: inventory.ID, Content: "ENC-0001"}}
+	if err := sealVirtualStockContents(stocks); err != nil {
+		t.Fatalf("seal stock: %v", err)
+	}
+	stocks[0].MarkAsSold(order.ID, order.OrderNo)
+	if err := db.Create(&stocks[0]).Error; err != nil {
+		t.Fatalf("create stock: %v", err)
+	}
+
+	result, err := resale.Check(VirtualStockResaleCheckInput{Code: "ENC-0001"}, nil)
+	if err != nil || !result.Matched || len(result.Deliveries) != 1 || result.Deliveries[0].OrderNo != "RESALE-ENC-1" {
+		t.Fatalf("expected encrypted stock to match, got %+v err=%v", result, err)
+	}
+
+	// 报告与库存只保存带密钥的哈希，无法用明文 SHA-256 反查卡密
+	plain := sha256.Sum256([]byte("ENC-0001"))
+	var report models.VirtualStockResaleReport
+	if err := db.First(&report, result.ReportID).Error; err != nil {
+		t.Fatalf("load report: %v", err)
+	}
+	if report.ContentHash != models.LicenseKeyHash("ENC-0001") || report.ContentHash == hex.EncodeToString(plain[:]) {
+		t.Fatalf("expected keyed content hash on the report, got %q", report.ContentHash)
+	}
+}
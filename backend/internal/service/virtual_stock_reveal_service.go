@@ -0,0 +1,213 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/orderbiz"
+	"auralogic/internal/pkg/password"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultVirtualRevealReauthTTL = 10 * time.Minute
+	virtualRevealThrottleWindow   = time.Hour
+)
+
+// VirtualStockRevealService 管理用户查看虚拟商品内容时的限流、重新验证与审计记录
+type VirtualStockRevealService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	otp          *OTPService
+	emailService *EmailService
+}
+
+func NewVirtualStockRevealService(db *gorm.DB, cfg *config.Config, otp *OTPService, emailService *EmailService) *VirtualStockRevealService {
+	return &VirtualStockRevealService{db: db, cfg: cfg, otp: otp, emailService: emailService}
+}
+
+// VirtualStockRevealRecord 单次查看的审计信息
+type VirtualStockRevealRecord struct {
+	OrderID    uint
+	OrderNo    string
+	UserID     uint
+	StockIDs   []uint
+	AuthMethod string
+	IPAddress  string
+	UserAgent  string
+}
+
+func (s *VirtualStockRevealService) reauthTTL() time.Duration {
+	if s.cfg != nil && s.cfg.Order.VirtualStockReveal.ReauthTTLMinutes > 0 {
+		return time.Duration(s.cfg.Order.VirtualStockReveal.ReauthTTLMinutes) * time.Minute
+	}
+	return defaultVirtualRevealReauthTTL
+}
+
+func virtualRevealThrottleKey(userID, orderID uint) string {
+	return fmt.Sprintf("virtual_reveal:count:%d:%d", userID, orderID)
+}
+
+func virtualRevealGrantKey(userID, orderID uint) string {
+	return fmt.Sprintf("virtual_reveal:grant:%d:%d", userID, orderID)
+}
+
+func virtualRevealOTPSubject(userID, orderID uint) string {
+	return fmt.Sprintf("%d:%d", userID, orderID)
+}
+
+// RequiresReauth 订单中是否包含开启了查看前重新验证的虚拟库存
+func (s *VirtualStockRevealService) RequiresReauth(orderID uint) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.VirtualProductStock{}).
+		Joins("JOIN virtual_inventories ON virtual_inventories.id = virtual_product_stocks.virtual_inventory_id").
+		Where("virtual_product_stocks.order_id = ? AND virtual_inventories.require_reauth = ?", orderID, true).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Throttle 记录一次查看并检查是否超过每小时上限；Redis 不可用时放行
+func (s *VirtualStockRevealService) Throttle(userID, orderID uint) error {
+	if s.cfg == nil || s.cfg.Order.VirtualStockReveal.MaxPerHour <= 0 || cache.RedisClient == nil {
+		return nil
+	}
+	maxPerHour := s.cfg.Order.VirtualStockReveal.MaxPerHour
+	key := virtualRevealThrottleKey(userID, orderID)
+	count, err := cache.Incr(key)
+	if err != nil {
+		return nil
+	}
+	if count == 1 {
+		_ = cache.Expire(key, virtualRevealThrottleWindow)
+	}
+	if count > int64(maxPerHour) {
+		return orderbiz.VirtualRevealRateLimited(maxPerHour)
+	}
+	return nil
+}
+
+// GrantedMethod 返回当前有效的重新验证方式，未验证或已过期返回空字符串
+func (s *VirtualStockRevealService) GrantedMethod(userID, orderID uint) string {
+	method, err := cache.Get(virtualRevealGrantKey(userID, orderID))
+	if err != nil {
+		return ""
+	}
+	return method
+}
+
+func (s *VirtualStockRevealService) grant(userID, orderID uint, method string) error {
+	return cache.Set(virtualRevealGrantKey(userID, orderID), method, s.reauthTTL())
+}
+
+func (s *VirtualStockRevealService) loadUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ReauthWithPassword 使用登录密码重新验证
+func (s *VirtualStockRevealService) ReauthWithPassword(userID, orderID uint, plain string) error {
+	user, err := s.loadUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash == "" {
+		return orderbiz.VirtualRevealPasswordUnavailable()
+	}
+	if !password.CheckPassword(plain, user.PasswordHash) {
+		return orderbiz.VirtualRevealPasswordInvalid()
+	}
+	return s.grant(userID, orderID, models.VirtualStockRevealAuthPassword)
+}
+
+// SendReauthCode 向用户邮箱发送重新验证码，返回脱敏后的邮箱
+func (s *VirtualStockRevealService) SendReauthCode(userID, orderID uint) (string, error) {
+	user, err := s.loadUser(userID)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(user.Email) == "" || s.emailService == nil {
+		return "", orderbiz.VirtualRevealCodeUnavailable()
+	}
+	code, err := s.otp.Issue(OTPPurposeVirtualReveal, virtualRevealOTPSubject(userID, orderID), OTPChannelEmail)
+	if err != nil {
+		return "", err
+	}
+	locale := user.Locale
+	if locale == "" {
+		locale = "en"
+	}
+	go s.emailService.SendLoginCodeEmail(user.Email, code, locale)
+	return maskRevealEmail(user.Email), nil
+}
+
+// CodeCooldown 检查验证码重发冷却，未处于冷却时立即开始新的冷却
+func (s *VirtualStockRevealService) CodeCooldown(ip string, userID, orderID uint) (bool, string) {
+	subject := virtualRevealOTPSubject(userID, orderID)
+	if s.otp.InCooldown(OTPPurposeVirtualReveal, ip, subject) {
+		return true, s.otp.CooldownMessage()
+	}
+	s.otp.StartCooldown(OTPPurposeVirtualReveal, ip, subject)
+	return false, ""
+}
+
+// ReauthWithCode 使用邮箱验证码重新验证
+func (s *VirtualStockRevealService) ReauthWithCode(userID, orderID uint, code string) error {
+	if err := s.otp.Verify(OTPPurposeVirtualReveal, virtualRevealOTPSubject(userID, orderID), code); err != nil {
+		return err
+	}
+	return s.grant(userID, orderID, models.VirtualStockRevealAuthOTP)
+}
+
+func maskRevealEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 1 {
+		return email
+	}
+	return email[:1] + strings.Repeat("*", at-1) + email[at:]
+}
+
+// Record 写入查看审计记录
+func (s *VirtualStockRevealService) Record(record VirtualStockRevealRecord) error {
+	stockIDs, _ := json.Marshal(record.StockIDs)
+	authMethod := record.AuthMethod
+	if authMethod == "" {
+		authMethod = models.VirtualStockRevealAuthNone
+	}
+	return s.db.Create(&models.VirtualStockRevealLog{
+		OrderID:    record.OrderID,
+		OrderNo:    record.OrderNo,
+		UserID:     record.UserID,
+		StockIDs:   models.JSON(stockIDs),
+		StockCount: len(record.StockIDs),
+		AuthMethod: authMethod,
+		IPAddress:  record.IPAddress,
+		UserAgent:  record.UserAgent,
+	}).Error
+}
+
+// ListByOrder 按时间倒序分页返回订单的查看记录
+func (s *VirtualStockRevealService) ListByOrder(orderID uint, page, limit int) ([]models.VirtualStockRevealLog, int64, error) {
+	var logs []models.VirtualStockRevealLog
+	var total int64
+	query := s.db.Model(&models.VirtualStockRevealLog{}).Where("order_id = ?", orderID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
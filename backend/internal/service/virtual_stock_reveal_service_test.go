@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/password"
+)
+
+func TestVirtualStockRevealReauthAndThrottle(t *testing.T) {
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.User{}, &models.VirtualStockRevealLog{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	otp, _ := newOTPServiceForTest(t, config.OTPConfig{})
+	cfg := otp.cfg
+	cfg.Order.VirtualStockReveal = config.VirtualStockRevealConfig{MaxPerHour: 2}
+	svc := NewVirtualStockRevealService(db, cfg, otp, nil)
+
+	hash, err := password.HashPassword("Secret123!")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := &models.User{Email: "buyer@example.com", PasswordHash: hash, IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	inventory := &models.VirtualInventory{Name: "gift cards", Type: models.VirtualInventoryTypeStatic, RequireReauth: true}
+	if err := db.Create(inventory).Error; err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+	orderID := uint(7)
+	stock := &models.VirtualProductStock{VirtualInventoryID: inventory.ID, Content: "CODE-1", Status: models.VirtualStockStatusSold, OrderID: &orderID, OrderNo: "ORD7"}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("create stock: %v", err)
+	}
+
+	required, err := svc.RequiresReauth(orderID)
+	if err != nil || !required {
+		t.Fatalf("expected reauth to be required, got %v %v", required, err)
+	}
+	if required, _ := svc.RequiresReauth(orderID + 1); required {
+		t.Fatalf("orders without protected inventory must not require reauth")
+	}
+
+	requireBizErr(t, svc.ReauthWithPassword(user.ID, orderID, "wrong"), "order.virtualRevealPasswordInvalid")
+	if method := svc.GrantedMethod(user.ID, orderID); method != "" {
+		t.Fatalf("failed reauth must not grant access, got %q", method)
+	}
+	if err := svc.ReauthWithPassword(user.ID, orderID, "Secret123!"); err != nil {
+		t.Fatalf("reauth failed: %v", err)
+	}
+	if method := svc.GrantedMethod(user.ID, orderID); method != models.VirtualStockRevealAuthPassword {
+		t.Fatalf("unexpected granted method %q", method)
+	}
+	// 授权按订单隔离
+	if method := svc.GrantedMethod(user.ID, orderID+1); method != "" {
+		t.Fatalf("grant must be scoped per order, got %q", method)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := svc.Throttle(user.ID, orderID); err != nil {
+			t.Fatalf("reveal %d throttled unexpectedly: %v", i+1, err)
+		}
+	}
+	requireBizErr(t, svc.Throttle(user.ID, orderID), "order.virtualRevealRateLimited")
+
+	if err := svc.Record(VirtualStockRevealRecord{
+		OrderID:    orderID,
+		OrderNo:    "ORD7",
+		UserID:     user.ID,
+		StockIDs:   []uint{stock.ID},
+		AuthMethod: models.VirtualStockRevealAuthPassword,
+		IPAddress:  "203.0.113.9",
+	}); err != nil {
+		t.Fatalf("record reveal: %v", err)
+	}
+	logs, total, err := svc.ListByOrder(orderID, 1, 20)
+	if err != nil || total != 1 || len(logs) != 1 {
+		t.Fatalf("unexpected reveal logs: total=%d len=%d err=%v", total, len(logs), err)
+	}
+	if logs[0].IPAddress != "203.0.113.9" || logs[0].StockCount != 1 || logs[0].AuthMethod != models.VirtualStockRevealAuthPassword {
+		t.Fatalf("unexpected reveal log: %+v", logs[0])
+	}
+}
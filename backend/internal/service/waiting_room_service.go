@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/bizerr"
+	"auralogic/internal/pkg/cache"
+	"auralogic/internal/pkg/utils"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const (
+	waitingRoomProductsKey           = "waiting_room:products"
+	defaultWaitingRoomAdmitBatch     = 50
+	defaultWaitingRoomAdmitInterval  = 5 * time.Second
+	defaultWaitingRoomAccessTTL      = 300 * time.Second
+	defaultWaitingRoomHeartbeatLimit = 60 * time.Second
+	waitingRoomTokenLength           = 32
+)
+
+// 等候室状态
+const (
+	WaitingRoomStatusNotRequired = "not_required" // 商品未开启等候室或等候室未启用
+	WaitingRoomStatusNone        = "none"         // 尚未排队
+	WaitingRoomStatusQueued      = "queued"
+	WaitingRoomStatusAdmitted    = "admitted"
+)
+
+var (
+	// 已持有令牌直接返回；否则按需入队并刷新心跳，返回排名与队列长度
+	waitingRoomJoinScript = redis.NewScript(`
+local token = redis.call('GET', KEYS[3])
+if token then
+  return {2, redis.call('TTL', KEYS[3]), token}
+end
+local rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+if not rank then
+  if ARGV[4] ~= '1' then
+    return {0, 0, ''}
+  end
+  local seq = redis.call('INCR', KEYS[4])
+  redis.call('ZADD', KEYS[1], seq, ARGV[1])
+  redis.call('SADD', KEYS[5], ARGV[3])
+  rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return {1, rank + 1, tostring(redis.call('ZCARD', KEYS[1]))}
+`)
+	// 清理心跳超时的排队者和过期的准入记录，再按队列顺序放行一批并发放令牌
+	waitingRoomAdmitScript = redis.NewScript(`
+local stale = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[2])
+for _, uid in ipairs(stale) do
+  redis.call('ZREM', KEYS[1], uid)
+end
+if #stale > 0 then
+  redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[2])
+end
+redis.call('ZREMRANGEBYSCORE', KEYS[3], '-inf', ARGV[1])
+local batch = tonumber(ARGV[3])
+local maxActive = tonumber(ARGV[6])
+if maxActive > 0 then
+  local room = maxActive - redis.call('ZCARD', KEYS[3])
+  if room < batch then
+    batch = room
+  end
+end
+local admitted = 0
+if batch > 0 then
+  local popped = redis.call('ZPOPMIN', KEYS[1], batch)
+  for i = 1, #popped, 2 do
+    local uid = popped[i]
+    admitted = admitted + 1
+    redis.call('SET', ARGV[5] .. uid, ARGV[6 + admitted], 'EX', ARGV[4])
+    redis.call('ZADD', KEYS[3], tonumber(ARGV[1]) + tonumber(ARGV[4]), uid)
+    redis.call('ZREM', KEYS[2], uid)
+  end
+end
+return {admitted, redis.call('ZCARD', KEYS[1]), redis.call('ZCARD', KEYS[3])}
+`)
+)
+
+// WaitingRoomTicket 用户在某个商品等候室中的状态
+type WaitingRoomTicket struct {
+	ProductID        uint       `json:"product_id"`
+	Status           string     `json:"status"`
+	Position         int64      `json:"position,omitempty"`     // 队列中的位置，从1开始
+	QueueLength      int64      `json:"queue_length,omitempty"` // 当前排队总人数
+	AccessToken      string     `json:"access_token,omitempty"` // 下单时通过 X-Waiting-Room-Token 请求头提交
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	PollAfterSeconds int        `json:"poll_after_seconds,omitempty"`
+}
+
+// WaitingRoomStats 管理端查看的等候室概况
+type WaitingRoomStats struct {
+	ProductID   uint  `json:"product_id"`
+	WaitingRoom bool  `json:"waiting_room"`
+	Active      bool  `json:"active"` // 全局开关与 Redis 均可用
+	QueueLength int64 `json:"queue_length"`
+	Admitted    int64 `json:"admitted"` // 持有未过期令牌的人数
+}
+
+type waitingRoomProduct struct {
+	ID   uint
+	SKU  string
+	Name string
+}
+
+// WaitingRoomService 限量发售等候室
+// 开启等候室的商品在下单前需先排队，后台任务按批次放行并发放限时准入令牌，下单中间件校验令牌后才进入 CreateOrder
+type WaitingRoomService struct {
+	db            *gorm.DB
+	cfg           *config.Config
+	productsMu    sync.RWMutex
+	productsBySKU map[string]waitingRoomProduct
+	productsAt    time.Time
+}
+
+// NewWaitingRoomService 创建等候室服务
+func NewWaitingRoomService(db *gorm.DB, cfg *config.Config) *WaitingRoomService {
+	return &WaitingRoomService{db: db, cfg: cfg}
+}
+
+// Enabled 等候室是否可用（全局开关开启且 Redis 已初始化）
+func (s *WaitingRoomService) Enabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Order.WaitingRoom.Enabled && cache.RedisClient != nil
+}
+
+func (s *WaitingRoomService) admitBatchSize() int {
+	if s.cfg.Order.WaitingRoom.AdmitBatchSize > 0 {
+		return s.cfg.Order.WaitingRoom.AdmitBatchSize
+	}
+	return defaultWaitingRoomAdmitBatch
+}
+
+func (s *WaitingRoomService) admitInterval() time.Duration {
+	if s.cfg.Order.WaitingRoom.AdmitIntervalSeconds > 0 {
+		return time.Duration(s.cfg.Order.WaitingRoom.AdmitIntervalSeconds) * time.Second
+	}
+	return defaultWaitingRoomAdmitInterval
+}
+
+func (s *WaitingRoomService) accessTTL() time.Duration {
+	if s.cfg.Order.WaitingRoom.AccessTokenTTLSeconds > 0 {
+		return time.Duration(s.cfg.Order.WaitingRoom.AccessTokenTTLSeconds) * time.Second
+	}
+	return defaultWaitingRoomAccessTTL
+}
+
+func (s *WaitingRoomService) heartbeatTimeout() time.Duration {
+	if s.cfg.Order.WaitingRoom.HeartbeatTimeoutSeconds > 0 {
+		return time.Duration(s.cfg.Order.WaitingRoom.HeartbeatTimeoutSeconds) * time.Second
+	}
+	return defaultWaitingRoomHeartbeatLimit
+}
+
+func waitingRoomQueueKey(productID uint) string {
+	return fmt.Sprintf("waiting_room:queue:%d", productID)
+}
+
+func waitingRoomSeenKey(productID uint) string {
+	return fmt.Sprintf("waiting_room:seen:%d", productID)
+}
+
+func waitingRoomSeqKey(productID uint) string {
+	return fmt.Sprintf("waiting_room:seq:%d", productID)
+}
+
+func waitingRoomAdmittedKey(productID uint) string {
+	return fmt.Sprintf("waiting_room:admitted:%d", productID)
+}
+
+func waitingRoomAccessKeyPrefix(productID uint) string {
+	return fmt.Sprintf("waiting_room:access:%d:", productID)
+}
+
+func waitingRoomAccessKey(productID, userID uint) string {
+	return waitingRoomAccessKeyPrefix(productID) + strconv.FormatUint(uint64(userID), 10)
+}
+
+// RefreshProducts 重新加载开启等候室的商品，后台任务每轮放行前都会刷新
+func (s *WaitingRoomService) RefreshProducts() error {
+	var products []models.Product
+	if err := s.db.Model(&models.Product{}).Select("id, sku, name").
+		Where("waiting_room = ?", true).Find(&products).Error; err != nil {
+		return err
+	}
+	bySKU := make(map[string]waitingRoomProduct, len(products))
+	for _, product := range products {
+		bySKU[product.SKU] = waitingRoomProduct{ID: product.ID, SKU: product.SKU, Name: product.Name}
+	}
+	s.productsMu.Lock()
+	s.productsBySKU = bySKU
+	s.productsAt = time.Now()
+	s.productsMu.Unlock()
+	return nil
+}
+
+func (s *WaitingRoomService) waitingRoomProducts() map[string]waitingRoomProduct {
+	s.productsMu.RLock()
+	stale := s.productsBySKU == nil || time.Since(s.productsAt) > s.admitInterval()
+	s.productsMu.RUnlock()
+	// 多实例部署时其他实例修改的开关也能在一个放行周期内生效
+	if stale {
+		if err := s.RefreshProducts(); err != nil {
+			log.Printf("waiting room product refresh failed: %v", err)
+		}
+	}
+	s.productsMu.RLock()
+	defer s.productsMu.RUnlock()
+	return s.productsBySKU
+}
+
+// RequiresWaitingRoom 是否存在需要排队的商品，供下单中间件跳过无关请求
+func (s *WaitingRoomService) RequiresWaitingRoom() bool {
+	return s.Enabled() && len(s.waitingRoomProducts()) > 0
+}
+
+func (s *WaitingRoomService) loadProduct(productID uint) (*models.Product, error) {
+	var product models.Product
+	if err := s.db.Select("id, sku, name, waiting_room").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// Join 加入商品等候室；已在队列中时仅刷新心跳，已获准入时返回令牌
+func (s *WaitingRoomService) Join(productID, userID uint) (*WaitingRoomTicket, error) {
+	return s.ticket(productID, userID, true)
+}
+
+// Poll 查询排队状态并刷新心跳
+func (s *WaitingRoomService) Poll(productID, userID uint) (*WaitingRoomTicket, error) {
+	return s.ticket(productID, userID, false)
+}
+
+func (s *WaitingRoomService) ticket(productID, userID uint, join bool) (*WaitingRoomTicket, error) {
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	ticket := &WaitingRoomTicket{ProductID: product.ID, Status: WaitingRoomStatusNotRequired}
+	if !product.WaitingRoom || !s.Enabled() {
+		return ticket, nil
+	}
+
+	joinFlag := "0"
+	if join {
+		joinFlag = "1"
+	}
+	uid := strconv.FormatUint(uint64(userID), 10)
+	result, err := waitingRoomJoinScript.Run(cache.RedisClient.Context(), cache.RedisClient,
+		[]string{
+			waitingRoomQueueKey(product.ID),
+			waitingRoomSeenKey(product.ID),
+			waitingRoomAccessKey(product.ID, userID),
+			waitingRoomSeqKey(product.ID),
+			waitingRoomProductsKey,
+		},
+		uid, time.Now().Unix(), product.ID, joinFlag).Slice()
+	if err != nil || len(result) < 3 {
+		return nil, bizerr.New("order.systemBusy", "System is busy, please retry shortly")
+	}
+
+	state, _ := result[0].(int64)
+	switch state {
+	case 2:
+		ttl, _ := result[1].(int64)
+		expiresAt := time.Now().Add(time.Duration(ttl) * time.Second)
+		ticket.Status = WaitingRoomStatusAdmitted
+		ticket.AccessToken, _ = result[2].(string)
+		ticket.ExpiresAt = &expiresAt
+	case 1:
+		ticket.Status = WaitingRoomStatusQueued
+		ticket.Position, _ = result[1].(int64)
+		if raw, ok := result[2].(string); ok {
+			ticket.QueueLength, _ = strconv.ParseInt(raw, 10, 64)
+		}
+		ticket.PollAfterSeconds = int(s.admitInterval() / time.Second)
+	default:
+		ticket.Status = WaitingRoomStatusNone
+	}
+	return ticket, nil
+}
+
+// CheckOrderAccess 校验订单中开启等候室的商品均持有有效准入令牌
+func (s *WaitingRoomService) CheckOrderAccess(userID uint, skus []string, tokens []string) error {
+	if !s.Enabled() {
+		return nil
+	}
+	products := s.waitingRoomProducts()
+	provided := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			provided[token] = true
+		}
+	}
+
+	checked := make(map[uint]bool)
+	for _, sku := range skus {
+		product, ok := products[sku]
+		if !ok || checked[product.ID] {
+			continue
+		}
+		checked[product.ID] = true
+
+		token, err := cache.RedisClient.Get(cache.RedisClient.Context(), waitingRoomAccessKey(product.ID, userID)).Result()
+		if err != nil && err != redis.Nil {
+			return bizerr.New("order.systemBusy", "System is busy, please retry shortly")
+		}
+		if err == redis.Nil || !provided[token] {
+			return bizerr.Newf("order.waitingRoomRequired", "%s is a limited release, please join the waiting room first", product.Name).
+				WithParams(map[string]interface{}{"product": product.Name, "product_id": product.ID})
+		}
+	}
+	return nil
+}
+
+// ConsumeOrderAccess 下单成功后作废准入令牌，腾出放行名额
+func (s *WaitingRoomService) ConsumeOrderAccess(userID uint, skus []string) {
+	if !s.Enabled() {
+		return
+	}
+	products := s.waitingRoomProducts()
+	ctx := cache.RedisClient.Context()
+	uid := strconv.FormatUint(uint64(userID), 10)
+	for _, sku := range skus {
+		product, ok := products[sku]
+		if !ok {
+			continue
+		}
+		pipe := cache.RedisClient.TxPipeline()
+		pipe.Del(ctx, waitingRoomAccessKey(product.ID, userID))
+		pipe.ZRem(ctx, waitingRoomAdmittedKey(product.ID), uid)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("waiting room access consume failed: product=%d user=%d err=%v", product.ID, userID, err)
+		}
+	}
+}
+
+// Admit 为单个商品执行一轮放行，返回放行人数
+func (s *WaitingRoomService) Admit(productID uint) (int, error) {
+	if !s.Enabled() {
+		return 0, nil
+	}
+	batch := s.admitBatchSize()
+	now := time.Now()
+	args := []interface{}{
+		now.Unix(),
+		now.Add(-s.heartbeatTimeout()).Unix(),
+		batch,
+		int(s.accessTTL() / time.Second),
+		waitingRoomAccessKeyPrefix(productID),
+		s.cfg.Order.WaitingRoom.MaxActive,
+	}
+	for i := 0; i < batch; i++ {
+		token, err := utils.GenerateToken(waitingRoomTokenLength)
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, token)
+	}
+
+	ctx := cache.RedisClient.Context()
+	result, err := waitingRoomAdmitScript.Run(ctx, cache.RedisClient,
+		[]string{waitingRoomQueueKey(productID), waitingRoomSeenKey(productID), waitingRoomAdmittedKey(productID)},
+		args...).Int64Slice()
+	if err != nil {
+		return 0, err
+	}
+	if len(result) == 3 && result[1] == 0 && result[2] == 0 {
+		// 队列与准入均已清空，不再参与放行轮询
+		cache.RedisClient.SRem(ctx, waitingRoomProductsKey, productID)
+	}
+	return int(result[0]), nil
+}
+
+// Stats 管理端查看等候室概况
+func (s *WaitingRoomService) Stats(productID uint) (*WaitingRoomStats, error) {
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &WaitingRoomStats{ProductID: product.ID, WaitingRoom: product.WaitingRoom, Active: s.Enabled()}
+	if cache.RedisClient == nil {
+		return stats, nil
+	}
+	ctx := cache.RedisClient.Context()
+	stats.QueueLength, _ = cache.RedisClient.ZCard(ctx, waitingRoomQueueKey(product.ID)).Result()
+	stats.Admitted, _ = cache.RedisClient.ZCount(ctx, waitingRoomAdmittedKey(product.ID),
+		strconv.FormatInt(time.Now().Unix(), 10), "+inf").Result()
+	return stats, nil
+}
+
+// SetProductWaitingRoom 开关商品等候室
+func (s *WaitingRoomService) SetProductWaitingRoom(productID uint, enabled bool) (*models.Product, error) {
+	product, err := s.loadProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.WaitingRoom != enabled {
+		if err := s.db.Model(&models.Product{}).Where("id = ?", product.ID).
+			Update("waiting_room", enabled).Error; err != nil {
+			return nil, err
+		}
+		product.WaitingRoom = enabled
+	}
+	if err := s.RefreshProducts(); err != nil {
+		log.Printf("waiting room product refresh failed: %v", err)
+	}
+	return product, nil
+}
+
+// RegisterJobs 注册等候室放行任务，按 admit_interval_seconds 周期为排队中的商品放行一批用户
+func (s *WaitingRoomService) RegisterJobs(scheduler *JobScheduler) {
+	scheduler.Register(JobDefinition{
+		Name:        "waiting_room_admit",
+		Description: "Admit the next batch of queued shoppers for products with a waiting room",
+		Interval:    s.admitInterval(),
+		Run: func(ctx context.Context) error {
+			return s.admitAll(ctx)
+		},
+	})
+}
+
+func (s *WaitingRoomService) admitAll(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if err := s.RefreshProducts(); err != nil {
+		log.Printf("waiting room product refresh failed: %v", err)
+	}
+	members, err := cache.RedisClient.SMembers(ctx, waitingRoomProductsKey).Result()
+	if err != nil {
+		return fmt.Errorf("list waiting room products: %w", err)
+	}
+	var errs []error
+	for _, member := range members {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		productID, err := strconv.ParseUint(member, 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, err := s.Admit(uint(productID)); err != nil {
+			errs = append(errs, fmt.Errorf("admit product %d: %w", productID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
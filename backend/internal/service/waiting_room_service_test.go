@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+
+	"auralogic/internal/config"
+	"auralogic/internal/models"
+	"auralogic/internal/pkg/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestWaitingRoomQueuesAdmitsAndGatesOrders(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mr.Close()
+
+	previousClient := cache.RedisClient
+	cache.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		if cache.RedisClient != nil {
+			_ = cache.RedisClient.Close()
+		}
+		cache.RedisClient = previousClient
+	}()
+
+	_, db := newVirtualInventoryServiceTestDB(t)
+	if err := db.AutoMigrate(&models.Product{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	drop := &models.Product{SKU: "DROP-1", Name: "Limited Drop", WaitingRoom: true}
+	regular := &models.Product{SKU: "REG-1", Name: "Regular"}
+	for _, product := range []*models.Product{drop, regular} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Order.WaitingRoom = config.WaitingRoomConfig{Enabled: true, AdmitBatchSize: 1}
+	svc := NewWaitingRoomService(db, cfg)
+
+	if ticket, err := svc.Join(regular.ID, 1); err != nil || ticket.Status != WaitingRoomStatusNotRequired {
+		t.Fatalf("regular product must not queue, got %+v err=%v", ticket, err)
+	}
+	if ticket, err := svc.Poll(drop.ID, 1); err != nil || ticket.Status != WaitingRoomStatusNone {
+		t.Fatalf("poll before join should report none, got %+v err=%v", ticket, err)
+	}
+
+	first, err := svc.Join(drop.ID, 1)
+	if err != nil || first.Status != WaitingRoomStatusQueued || first.Position != 1 {
+		t.Fatalf("unexpected first ticket: %+v err=%v", first, err)
+	}
+	second, err := svc.Join(drop.ID, 2)
+	if err != nil || second.Position != 2 || second.QueueLength != 2 {
+		t.Fatalf("unexpected second ticket: %+v err=%v", second, err)
+	}
+	if again, _ := svc.Join(drop.ID, 2); again.Position != 2 {
+		t.Fatalf("rejoining must keep the queue position, got %d", again.Position)
+	}
+
+	if err := svc.CheckOrderAccess(1, []string{"DROP-1"}, nil); err == nil {
+		t.Fatalf("expected order to be blocked before admission")
+	} else {
+		requireBizErr(t, err, "order.waitingRoomRequired")
+	}
+	if err := svc.CheckOrderAccess(1, []string{"REG-1"}, nil); err != nil {
+		t.Fatalf("regular product must not be gated: %v", err)
+	}
+
+	if admitted, err := svc.Admit(drop.ID); err != nil || admitted != 1 {
+		t.Fatalf("admit: admitted=%d err=%v", admitted, err)
+	}
+	ticket, err := svc.Poll(drop.ID, 1)
+	if err != nil || ticket.Status != WaitingRoomStatusAdmitted || ticket.AccessToken == "" || ticket.ExpiresAt == nil {
+		t.Fatalf("expected first user to be admitted, got %+v err=%v", ticket, err)
+	}
+	if waiting, _ := svc.Poll(drop.ID, 2); waiting.Status != WaitingRoomStatusQueued || waiting.Position != 1 {
+		t.Fatalf("second user should move to the head of the queue, got %+v", waiting)
+	}
+
+	if err := svc.CheckOrderAccess(1, []string{"DROP-1"}, []string{"wrong"}); err == nil {
+		t.Fatalf("expected mismatched token to be rejected")
+	}
+	if err := svc.CheckOrderAccess(1, []string{"DROP-1", "REG-1"}, []string{ticket.AccessToken}); err != nil {
+		t.Fatalf("admitted user should pass: %v", err)
+	}
+
+	svc.ConsumeOrderAccess(1, []string{"DROP-1"})
+	if err := svc.CheckOrderAccess(1, []string{"DROP-1"}, []string{ticket.AccessToken}); err == nil {
+		t.Fatalf("token must be single use")
+	}
+	stats, err := svc.Stats(drop.ID)
+	if err != nil || stats.QueueLength != 1 || stats.Admitted != 0 {
+		t.Fatalf("unexpected stats: %+v err=%v", stats, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"auralogic/internal/database"
 	adminHandler "auralogic/internal/handler/admin"
 	"auralogic/internal/models"
+	"auralogic/internal/pkg/fieldcrypt"
 	"auralogic/internal/pkg/password"
 	"github.com/google/uuid"
 )
@@ -27,6 +28,11 @@ func main() {
 		log.Fatalf("Failed to load admin config: %v", err)
 	}
 
+	// 初始化敏感数据加密密钥，迁移按密钥计算卡密内容哈希
+	if err := fieldcrypt.Init(&cfg.Security.DataEncryption); err != nil {
+		log.Fatalf("Failed to initialize data encryption: %v", err)
+	}
+
 	// 初始化数据库
 	if err := database.InitDatabase(&cfg.Database); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -80,6 +86,9 @@ func main() {
 		"order.delete",
 		"order.status_update",
 		"order.refund",
+		"order.price_adjust",
+		"order.price_approve",
+		"order.risk_review",
 		"order.assign_tracking",
 		"order.request_resubmit",
 		// Product
@@ -89,10 +98,14 @@ func main() {
 		// Serial
 		"serial.view",
 		"serial.manage",
+		// Usage
+		"usage.view",
+		"usage.report",
 		// User
 		"user.view",
 		"user.edit",
 		"user.permission",
+		"user.blocklist",
 		// Admin
 		"admin.create",
 		"admin.edit",
@@ -108,6 +121,9 @@ func main() {
 		// Announcement
 		"announcement.view",
 		"announcement.edit",
+		// Moderation
+		"moderation.view",
+		"moderation.manage",
 		// Marketing
 		"marketing.view",
 		"marketing.send",
@@ -115,6 +131,7 @@ func main() {
 		"ticket.view",
 		"ticket.reply",
 		"ticket.status_update",
+		"ticket.dispute",
 		// Market
 		"market.view",
 		"market.install",
@@ -0,0 +1,118 @@
+// AuraLogic Zapier 集成示例（zapier-platform-core，`zapier init` 生成的项目中替换 index.js 即可）
+// 触发器：新付款订单；动作：回复工单、为订单添加标签
+
+const authentication = {
+  type: 'custom',
+  fields: [
+    { key: 'base_url', label: 'Store URL', required: true, helpText: 'https://shop.example.com' },
+    { key: 'api_key', label: 'API Key', required: true },
+    { key: 'api_secret', label: 'API Secret', required: true, type: 'password' },
+  ],
+  test: { url: '{{bundle.authData.base_url}}/api/admin/integrations/me' },
+  connectionLabel: (z, bundle) => bundle.inputData.data.label,
+}
+
+const addApiKeyHeaders = (request, z, bundle) => {
+  request.headers['X-API-Key'] = bundle.authData.api_key
+  request.headers['X-API-Secret'] = bundle.authData.api_secret
+  // 由 unwrap 抛出带业务提示的错误
+  request.skipThrowForStatus = true
+  return request
+}
+
+// 接口统一返回 {code, message, data}，业务错误的 i18n key 在 data.error_key 中
+const unwrap = (response) => {
+  const body = response.json
+  if (response.status >= 400 || !body || body.code !== 0) {
+    throw new Error((body && body.message) || `HTTP ${response.status}`)
+  }
+  return body.data
+}
+
+const apiUrl = (bundle, path) =>
+  `${bundle.authData.base_url.replace(/\/+$/, '')}/api/admin/integrations${path}`
+
+const newPaidOrder = {
+  key: 'new_paid_order',
+  noun: 'Order',
+  display: { label: 'New Paid Order', description: 'Triggers when an order is paid.' },
+  operation: {
+    // Zapier 按 id 去重，只需取最近付款的订单
+    perform: async (z, bundle) =>
+      unwrap(
+        await z.request({ url: apiUrl(bundle, '/triggers/paid-orders'), params: { limit: 50 } })
+      ),
+    sample: {
+      id: 42,
+      cursor: 'MTc5MjMxMDQwMDAwMDAwMDAwMDo0Mg',
+      order_no: 'ORD20261018000042',
+      status: 'pending',
+      total_amount_minor: 5990,
+      currency: 'USD',
+      paid_at: '2026-10-18T08:00:00Z',
+      created_at: '2026-10-18T07:58:12Z',
+      user_email: 'buyer@example.com',
+      receiver_name: 'Alex Chen',
+      receiver_country: 'US',
+      item_count: 2,
+      items: [{ sku: 'SKU-1', name: 'Widget', quantity: 2 }],
+      tags: [],
+    },
+  },
+}
+
+const ticketReply = {
+  key: 'ticket_reply',
+  noun: 'Ticket Reply',
+  display: { label: 'Reply to Ticket', description: 'Posts a staff reply to a support ticket.' },
+  operation: {
+    inputFields: [
+      { key: 'ticket_no', label: 'Ticket Number', required: true },
+      { key: 'content', label: 'Message', required: true, type: 'text' },
+    ],
+    perform: async (z, bundle) =>
+      unwrap(
+        await z.request({
+          url: apiUrl(bundle, '/actions/ticket-reply'),
+          method: 'POST',
+          body: { ticket_no: bundle.inputData.ticket_no, content: bundle.inputData.content },
+        })
+      ),
+    sample: {
+      id: 7,
+      ticket_no: 'T20261018001',
+      content: 'Thanks!',
+      created_at: '2026-10-18T08:01:00Z',
+    },
+  },
+}
+
+const orderTag = {
+  key: 'order_tag',
+  noun: 'Order Tag',
+  display: { label: 'Add Order Tag', description: 'Adds a tag to an order.' },
+  operation: {
+    inputFields: [
+      { key: 'order_no', label: 'Order Number', required: true },
+      { key: 'tag', label: 'Tag', required: true, helpText: 'Up to 32 characters, no commas.' },
+    ],
+    perform: async (z, bundle) =>
+      unwrap(
+        await z.request({
+          url: apiUrl(bundle, '/actions/order-tag'),
+          method: 'POST',
+          body: { order_no: bundle.inputData.order_no, tag: bundle.inputData.tag },
+        })
+      ),
+    sample: { id: 42, order_no: 'ORD20261018000042', tags: ['vip'], added: true },
+  },
+}
+
+module.exports = {
+  version: require('./package.json').version,
+  platformVersion: require('zapier-platform-core').version,
+  authentication,
+  beforeRequest: [addApiKeyHeaders],
+  triggers: { [newPaidOrder.key]: newPaidOrder },
+  creates: { [ticketReply.key]: ticketReply, [orderTag.key]: orderTag },
+}
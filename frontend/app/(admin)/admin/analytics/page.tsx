@@ -1,11 +1,13 @@
 'use client'
 
 import { useQuery } from '@tanstack/react-query'
-import { getUserAnalytics, getOrderAnalytics, getRevenueAnalytics, getDeviceAnalytics, getSettings } from '@/lib/api'
+import Link from 'next/link'
+import { getUserAnalytics, getOrderAnalytics, getRevenueAnalytics, getDeviceAnalytics, getFunnelAnalytics, getSettings, type AnalyticsFunnel } from '@/lib/api'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { Button } from '@/components/ui/button'
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
 import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
-import { Users, ShoppingCart, DollarSign, TrendingUp, TrendingDown, BarChart3, Smartphone, Monitor, AlertTriangle } from 'lucide-react'
+import { Users, ShoppingCart, DollarSign, TrendingUp, TrendingDown, BarChart3, Smartphone, Monitor, AlertTriangle, Package, Filter, Eye } from 'lucide-react'
 import { formatCurrency } from '@/lib/utils'
 import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
@@ -87,6 +89,12 @@ export default function AnalyticsPage() {
     enabled: isSuper && analyticsEnabled,
   })
 
+  const { data: funnelData } = useQuery({
+    queryKey: ['analyticsFunnel'],
+    queryFn: () => getFunnelAnalytics(),
+    enabled: isSuper && analyticsEnabled,
+  })
+
   if (!isSuper) {
     return null
   }
@@ -95,6 +103,7 @@ export default function AnalyticsPage() {
   const orders = orderData?.data
   const rev = revenueData?.data
   const devices = deviceData?.data
+  const funnel: AnalyticsFunnel | undefined = funnelData?.data
   const adminAnalyticsPluginContext = {
     view: 'admin_analytics',
     analytics_enabled: analyticsEnabled,
@@ -103,6 +112,7 @@ export default function AnalyticsPage() {
       orders: !orderData,
       revenue: !revenueData,
       devices: !deviceData,
+      funnel: !funnelData,
     },
     summary: {
       users_overview: users?.overview || null,
@@ -114,6 +124,7 @@ export default function AnalyticsPage() {
       os_distribution_count: Array.isArray(devices?.os_distribution)
         ? devices.os_distribution.length
         : 0,
+      funnel_sessions: funnel?.sessions || 0,
     },
   }
 
@@ -148,6 +159,16 @@ export default function AnalyticsPage() {
     refunded: t.order.status.refunded,
   }
 
+  const funnelStageLabels: Record<string, string> = {
+    visit: t.admin.funnelStageVisit,
+    add_to_cart: t.admin.funnelStageAddToCart,
+    checkout_cart: t.admin.funnelStageCheckoutCart,
+    checkout_submit: t.admin.funnelStageCheckoutSubmit,
+    checkout_placed: t.admin.funnelStageCheckoutPlaced,
+    checkout_payment: t.admin.funnelStageCheckoutPayment,
+  }
+  const placedStage = funnel?.stages?.find((stage) => stage.stage === 'checkout_placed')
+
   const tooltipStyle = {
     contentStyle: { backgroundColor: chart.tooltipBg, border: `1px solid ${chart.tooltipBorder}`, borderRadius: '8px' },
     labelStyle: { color: chart.textColor },
@@ -158,9 +179,17 @@ export default function AnalyticsPage() {
   return (
     <div className="space-y-6">
       <PluginSlot slot="admin.analytics.top" context={adminAnalyticsPluginContext} />
-      <div>
-        <h1 className="text-3xl font-bold">{t.admin.analyticsTitle}</h1>
-        <p className="text-muted-foreground mt-1">{t.admin.analyticsDesc}</p>
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div>
+          <h1 className="text-3xl font-bold">{t.admin.analyticsTitle}</h1>
+          <p className="text-muted-foreground mt-1">{t.admin.analyticsDesc}</p>
+        </div>
+        <Button variant="outline" asChild>
+          <Link href="/admin/analytics/sku-sales">
+            <Package className="mr-2 h-4 w-4" />
+            {t.admin.skuSales}
+          </Link>
+        </Button>
       </div>
 
       <Tabs defaultValue="users">
@@ -177,6 +206,10 @@ export default function AnalyticsPage() {
             <DollarSign className="h-4 w-4" />
             {t.admin.revenueAnalytics}
           </TabsTrigger>
+          <TabsTrigger value="funnel" className="gap-1.5">
+            <Filter className="h-4 w-4" />
+            {t.admin.funnelAnalytics}
+          </TabsTrigger>
         </TabsList>
 
         {/* Users Tab */}
@@ -751,6 +784,115 @@ export default function AnalyticsPage() {
             </Card>
           </div>
         </TabsContent>
+
+        {/* Funnel Tab */}
+        <TabsContent value="funnel" className="space-y-6">
+          <p className="text-sm text-muted-foreground">{t.admin.funnelDesc}</p>
+          <div className="grid grid-cols-1 md:grid-cols-3 gap-4">
+            <StatCard
+              title={t.admin.funnelSessions}
+              value={funnel?.sessions || 0}
+              icon={<Users className="h-4 w-4" />}
+            />
+            <StatCard
+              title={t.admin.funnelPageViews}
+              value={funnel?.page_views || 0}
+              icon={<Eye className="h-4 w-4" />}
+            />
+            <StatCard
+              title={t.admin.funnelConversion}
+              value={`${(placedStage?.rate_from_start || 0).toFixed(2)}%`}
+              icon={<ShoppingCart className="h-4 w-4" />}
+              className="text-green-600 dark:text-green-400"
+            />
+          </div>
+
+          <div className="grid grid-cols-1 lg:grid-cols-2 gap-4">
+            {/* Funnel Stages */}
+            <Card>
+              <CardHeader>
+                <CardTitle>{t.admin.funnelStages}</CardTitle>
+                <CardDescription>{t.admin.last30Days}</CardDescription>
+              </CardHeader>
+              <CardContent>
+                {funnel?.sessions ? (
+                  <div className="space-y-4">
+                    {funnel.stages.map((stage, i) => (
+                      <div key={stage.stage} className="space-y-1.5">
+                        <div className="flex items-center justify-between text-sm">
+                          <span className="font-medium">{funnelStageLabels[stage.stage] || stage.stage}</span>
+                          <span className="tabular-nums">
+                            {stage.sessions} · {stage.rate_from_start.toFixed(2)}%
+                          </span>
+                        </div>
+                        <div className="h-2 rounded-full bg-muted">
+                          <div
+                            className="h-2 rounded-full"
+                            style={{ width: `${Math.min(stage.rate_from_start, 100)}%`, backgroundColor: COLORS[i % COLORS.length] }}
+                          />
+                        </div>
+                        {i > 0 && (
+                          <p className="text-xs text-muted-foreground">
+                            {t.admin.funnelFromPrevious.replace('{rate}', stage.rate_from_previous.toFixed(2))}
+                          </p>
+                        )}
+                      </div>
+                    ))}
+                  </div>
+                ) : (
+                  <EmptyState text={t.admin.noAnalyticsData} />
+                )}
+              </CardContent>
+            </Card>
+
+            {/* Entry Pages */}
+            <Card>
+              <CardHeader>
+                <CardTitle>{t.admin.funnelEntryPages}</CardTitle>
+              </CardHeader>
+              <CardContent>
+                {funnel?.entry_pages?.length ? (
+                  <ResponsiveContainer width="100%" height={300}>
+                    <BarChart data={funnel.entry_pages} layout="vertical">
+                      <CartesianGrid strokeDasharray="3 3" stroke={chart.gridColor} />
+                      <XAxis type="number" tick={{ fontSize: 12, fill: chart.tickColor }} stroke={chart.gridColor} />
+                      <YAxis dataKey="path" type="category" width={120} tick={{ fontSize: 12, fill: chart.tickColor }} stroke={chart.gridColor} />
+                      <Tooltip {...tooltipStyle} />
+                      <Bar dataKey="sessions" fill="#8b5cf6" radius={[0, 4, 4, 0]} name={t.admin.funnelSessions} />
+                    </BarChart>
+                  </ResponsiveContainer>
+                ) : (
+                  <EmptyState text={t.admin.noAnalyticsData} />
+                )}
+              </CardContent>
+            </Card>
+
+            {/* Daily Sessions */}
+            <Card className="lg:col-span-2">
+              <CardHeader>
+                <CardTitle>{t.admin.funnelDailyTrend}</CardTitle>
+                <CardDescription>{t.admin.last30Days}</CardDescription>
+              </CardHeader>
+              <CardContent>
+                {funnel?.sessions ? (
+                  <ResponsiveContainer width="100%" height={350}>
+                    <LineChart data={funnel.daily_trend}>
+                      <CartesianGrid strokeDasharray="3 3" stroke={chart.gridColor} />
+                      <XAxis dataKey="date" tick={{ fontSize: 12, fill: chart.tickColor }} stroke={chart.gridColor} />
+                      <YAxis tick={{ fontSize: 12, fill: chart.tickColor }} stroke={chart.gridColor} />
+                      <Tooltip {...tooltipStyle} />
+                      <Legend wrapperStyle={{ color: chart.legendColor }} />
+                      <Line type="monotone" dataKey="sessions" stroke="#3b82f6" strokeWidth={2} dot={false} name={t.admin.funnelSessions} />
+                      <Line type="monotone" dataKey="placed" stroke="#10b981" strokeWidth={2} dot={false} name={t.admin.funnelPlacedSessions} />
+                    </LineChart>
+                  </ResponsiveContainer>
+                ) : (
+                  <EmptyState text={t.admin.noAnalyticsData} />
+                )}
+              </CardContent>
+            </Card>
+          </div>
+        </TabsContent>
       </Tabs>
     </div>
   )
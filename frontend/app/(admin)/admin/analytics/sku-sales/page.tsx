@@ -0,0 +1,201 @@
+'use client'
+
+import { useState } from 'react'
+import { useQuery } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Download } from 'lucide-react'
+import { getSKUSalesAnalytics, SKUSalesGroupBy, SKUSalesReportItem } from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Card, CardContent } from '@/components/ui/card'
+import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { formatCurrency } from '@/lib/utils'
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+export default function AdminSKUSalesPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminSkuSales)
+
+  const [endDate, setEndDate] = useState(() => formatDateInput(new Date()))
+  const [startDate, setStartDate] = useState(() =>
+    formatDateInput(new Date(Date.now() - 29 * 24 * 60 * 60 * 1000))
+  )
+  const [sku, setSku] = useState('')
+  const [groupBy, setGroupBy] = useState<SKUSalesGroupBy>('sku')
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['skuSalesAnalytics', startDate, endDate, sku, groupBy],
+    queryFn: () =>
+      getSKUSalesAnalytics({
+        start_date: startDate,
+        end_date: endDate,
+        sku: sku.trim() || undefined,
+        group_by: groupBy,
+      }),
+    enabled: !!startDate && !!endDate,
+  })
+  const items: SKUSalesReportItem[] = data?.data?.items || []
+  const currency: string = data?.data?.currency || 'CNY'
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExport = () => {
+    const params = new URLSearchParams({
+      start_date: startDate,
+      end_date: endDate,
+      group_by: groupBy,
+    })
+    if (sku.trim()) {
+      params.set('sku', sku.trim())
+    }
+    const url = resolveClientAPIProxyURL(
+      `/api/admin/analytics/sku-sales/export?${params.toString()}`
+    )
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `sku_sales_${startDate}_${endDate}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+        toast.success(t.admin.skuSalesExportSuccess)
+      })
+      .catch((err) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const columns = [
+    ...(groupBy !== 'sku'
+      ? [
+          {
+            header: t.admin.skuSalesPeriod,
+            accessorKey: 'period',
+          },
+        ]
+      : []),
+    {
+      header: t.admin.skuSalesProduct,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) => (
+        <div>
+          <div className="font-medium">{row.original.name || row.original.sku}</div>
+          <div className="text-xs text-muted-foreground">{row.original.sku}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.skuSalesUnitsSold,
+      accessorKey: 'units_sold',
+    },
+    {
+      header: t.admin.skuSalesRevenue,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) =>
+        formatCurrency(row.original.revenue_minor, currency),
+    },
+    {
+      header: t.admin.skuSalesRefundRate,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) =>
+        `${row.original.refund_rate.toFixed(2)}% (${row.original.units_refunded})`,
+    },
+    {
+      header: t.admin.skuSalesVelocity,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) =>
+        row.original.daily_velocity.toFixed(2),
+    },
+    {
+      header: t.admin.skuSalesEndingStock,
+      accessorKey: 'ending_stock',
+    },
+    {
+      header: t.admin.skuSalesStockTurn,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) =>
+        row.original.avg_stock > 0 ? row.original.stock_turn.toFixed(2) : '-',
+    },
+    {
+      header: t.admin.skuSalesSellThrough,
+      cell: ({ row }: { row: { original: SKUSalesReportItem } }) =>
+        `${row.original.sell_through_rate.toFixed(2)}%`,
+    },
+  ]
+
+  return (
+    <div className="space-y-4 p-4">
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div className="flex items-center gap-2">
+          <Button variant="outline" size="icon" asChild className="h-8 w-8">
+            <Link href="/admin/analytics">
+              <ArrowLeft className="h-4 w-4" />
+              <span className="sr-only">{t.admin.analyticsTitle}</span>
+            </Link>
+          </Button>
+          <div>
+            <h1 className="text-xl font-bold">{t.admin.skuSales}</h1>
+            <p className="text-sm text-muted-foreground">{t.admin.skuSalesDesc}</p>
+          </div>
+        </div>
+        <Button variant="outline" onClick={handleExport}>
+          <Download className="mr-2 h-4 w-4" />
+          {t.admin.skuSalesExport}
+        </Button>
+      </div>
+
+      <Card>
+        <CardContent className="flex flex-col gap-3 pt-6 md:flex-row md:items-end">
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.skuSalesStartDate}</label>
+            <Input type="date" value={startDate} onChange={(e) => setStartDate(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.skuSalesEndDate}</label>
+            <Input type="date" value={endDate} onChange={(e) => setEndDate(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.skuSalesSkuFilter}</label>
+            <Input value={sku} placeholder="SKU" onChange={(e) => setSku(e.target.value)} />
+          </div>
+          <Tabs value={groupBy} onValueChange={(value) => setGroupBy(value as SKUSalesGroupBy)}>
+            <TabsList>
+              <TabsTrigger value="sku">{t.admin.skuSalesGroupBySku}</TabsTrigger>
+              <TabsTrigger value="day">{t.admin.skuSalesGroupByDay}</TabsTrigger>
+              <TabsTrigger value="month">{t.admin.skuSalesGroupByMonth}</TabsTrigger>
+            </TabsList>
+          </Tabs>
+        </CardContent>
+      </Card>
+
+      {!isLoading && items.length === 0 ? (
+        <p className="py-8 text-center text-sm text-muted-foreground">{t.admin.skuSalesNoData}</p>
+      ) : (
+        <DataTable columns={columns} data={items} isLoading={isLoading} />
+      )}
+    </div>
+  )
+}
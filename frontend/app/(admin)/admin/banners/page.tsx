@@ -0,0 +1,550 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Pencil, Plus, Trash2 } from 'lucide-react'
+import {
+  createSiteBanner,
+  deleteSiteBanner,
+  getAdminSiteBanners,
+  updateSiteBanner,
+  type SiteBanner,
+  type SiteBannerAudience,
+  type SiteBannerContent,
+  type SiteBannerInput,
+  type SiteBannerType,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Switch } from '@/components/ui/switch'
+import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { Textarea } from '@/components/ui/textarea'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+const BANNER_LOCALES = ['en', 'zh'] as const
+const BANNER_TYPES: SiteBannerType[] = ['maintenance', 'policy', 'info']
+const BANNER_AUDIENCES: SiteBannerAudience[] = ['all', 'guests', 'users', 'admins']
+
+interface BannerForm {
+  type: SiteBannerType
+  audience: SiteBannerAudience
+  default_locale: string
+  translations: Record<string, SiteBannerContent>
+  starts_at: string
+  ends_at: string
+  maintenance_starts_at: string
+  maintenance_ends_at: string
+  dismissible: boolean
+  priority: string
+  is_active: boolean
+}
+
+function emptyContent(): SiteBannerContent {
+  return { title: '', message: '', link_url: '', link_text: '' }
+}
+
+function createEmptyForm(): BannerForm {
+  return {
+    type: 'maintenance',
+    audience: 'all',
+    default_locale: 'en',
+    translations: Object.fromEntries(BANNER_LOCALES.map((locale) => [locale, emptyContent()])),
+    starts_at: '',
+    ends_at: '',
+    maintenance_starts_at: '',
+    maintenance_ends_at: '',
+    dismissible: true,
+    priority: '0',
+    is_active: true,
+  }
+}
+
+// ISO 时间转换为 datetime-local 输入框格式（本地时区）
+function toLocalInput(value?: string) {
+  if (!value) return ''
+  const date = new Date(value)
+  const pad = (n: number) => String(n).padStart(2, '0')
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}T${pad(date.getHours())}:${pad(date.getMinutes())}`
+}
+
+function toISO(value: string) {
+  return value ? new Date(value).toISOString() : undefined
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+function formFromBanner(banner: SiteBanner): BannerForm {
+  const translations = Object.fromEntries(
+    BANNER_LOCALES.map((locale) => [locale, { ...emptyContent(), ...banner.translations?.[locale] }])
+  )
+  return {
+    type: banner.type,
+    audience: banner.audience,
+    default_locale: banner.default_locale,
+    translations,
+    starts_at: toLocalInput(banner.starts_at),
+    ends_at: toLocalInput(banner.ends_at),
+    maintenance_starts_at: toLocalInput(banner.maintenance_starts_at),
+    maintenance_ends_at: toLocalInput(banner.maintenance_ends_at),
+    dismissible: banner.dismissible,
+    priority: String(banner.priority ?? 0),
+    is_active: banner.is_active,
+  }
+}
+
+function buildPayload(form: BannerForm): SiteBannerInput {
+  return {
+    type: form.type,
+    audience: form.audience,
+    default_locale: form.default_locale,
+    translations: form.translations,
+    starts_at: toISO(form.starts_at),
+    ends_at: toISO(form.ends_at),
+    maintenance_starts_at: form.type === 'maintenance' ? toISO(form.maintenance_starts_at) : undefined,
+    maintenance_ends_at: form.type === 'maintenance' ? toISO(form.maintenance_ends_at) : undefined,
+    dismissible: form.dismissible,
+    priority: Number(form.priority || 0),
+    is_active: form.is_active,
+  }
+}
+
+export default function AdminSiteBannersPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminSiteBanners)
+  const { hasPermission } = usePermission()
+  const canEdit = hasPermission('announcement.edit')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('all')
+  const [editing, setEditing] = useState<SiteBanner | null>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [form, setForm] = useState<BannerForm>(createEmptyForm())
+  const [deleting, setDeleting] = useState<SiteBanner | null>(null)
+
+  const typeLabels: Record<SiteBannerType, string> = {
+    maintenance: t.siteBanner.typeMaintenance,
+    policy: t.siteBanner.typePolicy,
+    info: t.siteBanner.typeInfo,
+  }
+  const audienceLabels: Record<SiteBannerAudience, string> = {
+    all: t.siteBanner.audienceAll,
+    guests: t.siteBanner.audienceGuests,
+    users: t.siteBanner.audienceUsers,
+    admins: t.siteBanner.audienceAdmins,
+  }
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['adminSiteBanners', page, status],
+    queryFn: () =>
+      getAdminSiteBanners({ page, limit: 20, status: status === 'all' ? undefined : status }),
+  })
+  const banners: SiteBanner[] = data?.data?.items || []
+
+  const refresh = () => {
+    queryClient.invalidateQueries({ queryKey: ['adminSiteBanners'] })
+    queryClient.invalidateQueries({ queryKey: ['siteBanners'] })
+  }
+
+  const saveMutation = useMutation({
+    mutationFn: () =>
+      editing ? updateSiteBanner(editing.id, buildPayload(form)) : createSiteBanner(buildPayload(form)),
+    onSuccess: () => {
+      toast.success(t.siteBanner.saved)
+      setFormOpen(false)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.siteBanner.saveFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deleteSiteBanner(id),
+    onSuccess: () => {
+      toast.success(t.siteBanner.deleted)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.siteBanner.deleteFailed))
+    },
+  })
+
+  const openCreate = () => {
+    setEditing(null)
+    setForm(createEmptyForm())
+    setFormOpen(true)
+  }
+
+  const openEdit = (banner: SiteBanner) => {
+    setEditing(banner)
+    setForm(formFromBanner(banner))
+    setFormOpen(true)
+  }
+
+  const updateContent = (bannerLocale: string, patch: Partial<SiteBannerContent>) => {
+    setForm((current) => ({
+      ...current,
+      translations: {
+        ...current.translations,
+        [bannerLocale]: { ...current.translations[bannerLocale], ...patch },
+      },
+    }))
+  }
+
+  const bannerState = (banner: SiteBanner) => {
+    const now = Date.now()
+    if (!banner.is_active || (banner.ends_at && new Date(banner.ends_at).getTime() <= now)) {
+      return <Badge variant="outline">{t.siteBanner.statusEnded}</Badge>
+    }
+    if (new Date(banner.starts_at).getTime() > now) {
+      return <Badge variant="secondary">{t.siteBanner.statusScheduled}</Badge>
+    }
+    return <Badge>{t.siteBanner.statusLive}</Badge>
+  }
+
+  const columns = [
+    {
+      header: t.siteBanner.type,
+      cell: ({ row }: { row: { original: SiteBanner } }) => typeLabels[row.original.type],
+    },
+    {
+      header: t.siteBanner.bannerTitle,
+      cell: ({ row }: { row: { original: SiteBanner } }) => {
+        const content =
+          row.original.translations?.[locale] ||
+          row.original.translations?.[row.original.default_locale]
+        return (
+          <div className="max-w-[320px] truncate">{content?.title || content?.message || '-'}</div>
+        )
+      },
+    },
+    {
+      header: t.siteBanner.audience,
+      cell: ({ row }: { row: { original: SiteBanner } }) => audienceLabels[row.original.audience],
+    },
+    {
+      header: t.siteBanner.schedule,
+      cell: ({ row }: { row: { original: SiteBanner } }) => (
+        <div className="text-xs">
+          {formatDateTime(row.original.starts_at)}
+          {' → '}
+          {row.original.ends_at ? formatDateTime(row.original.ends_at) : t.siteBanner.noEnd}
+        </div>
+      ),
+    },
+    {
+      header: t.siteBanner.status,
+      cell: ({ row }: { row: { original: SiteBanner } }) => bannerState(row.original),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: SiteBanner } }) =>
+        canEdit ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openEdit(row.original)}>
+              <Pencil className="h-4 w-4" />
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => setDeleting(row.original)}>
+              <Trash2 className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-wrap items-center justify-between gap-4">
+        <div>
+          <h1 className="text-3xl font-bold">{t.siteBanner.title}</h1>
+          <p className="text-sm text-muted-foreground">{t.siteBanner.description}</p>
+        </div>
+        {canEdit ? (
+          <Button onClick={openCreate}>
+            <Plus className="mr-2 h-4 w-4" />
+            {t.siteBanner.create}
+          </Button>
+        ) : null}
+      </div>
+
+      <Select
+        value={status}
+        onValueChange={(value) => {
+          setStatus(value)
+          setPage(1)
+        }}
+      >
+        <SelectTrigger className="w-[160px]">
+          <SelectValue />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value="all">{t.common.all}</SelectItem>
+          <SelectItem value="live">{t.siteBanner.statusLive}</SelectItem>
+          <SelectItem value="scheduled">{t.siteBanner.statusScheduled}</SelectItem>
+          <SelectItem value="ended">{t.siteBanner.statusEnded}</SelectItem>
+        </SelectContent>
+      </Select>
+
+      <DataTable
+        columns={columns}
+        data={banners}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: data?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      <Dialog open={formOpen} onOpenChange={setFormOpen}>
+        <DialogContent className="max-h-[90vh] max-w-2xl overflow-y-auto">
+          <DialogHeader>
+            <DialogTitle>{editing ? t.siteBanner.edit : t.siteBanner.create}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="grid gap-4 md:grid-cols-3">
+              <div className="space-y-2">
+                <Label>{t.siteBanner.type}</Label>
+                <Select
+                  value={form.type}
+                  onValueChange={(value) => setForm({ ...form, type: value as SiteBannerType })}
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {BANNER_TYPES.map((type) => (
+                      <SelectItem key={type} value={type}>
+                        {typeLabels[type]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.siteBanner.audience}</Label>
+                <Select
+                  value={form.audience}
+                  onValueChange={(value) =>
+                    setForm({ ...form, audience: value as SiteBannerAudience })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {BANNER_AUDIENCES.map((audience) => (
+                      <SelectItem key={audience} value={audience}>
+                        {audienceLabels[audience]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.siteBanner.priority}</Label>
+                <Input
+                  type="number"
+                  value={form.priority}
+                  onChange={(e) => setForm({ ...form, priority: e.target.value })}
+                />
+              </div>
+            </div>
+
+            <div className="grid gap-4 md:grid-cols-2">
+              <div className="space-y-2">
+                <Label>{t.siteBanner.startsAt}</Label>
+                <Input
+                  type="datetime-local"
+                  value={form.starts_at}
+                  onChange={(e) => setForm({ ...form, starts_at: e.target.value })}
+                />
+                <p className="text-xs text-muted-foreground">{t.siteBanner.startsAtHint}</p>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.siteBanner.endsAt}</Label>
+                <Input
+                  type="datetime-local"
+                  value={form.ends_at}
+                  onChange={(e) => setForm({ ...form, ends_at: e.target.value })}
+                />
+                <p className="text-xs text-muted-foreground">{t.siteBanner.endsAtHint}</p>
+              </div>
+            </div>
+
+            {form.type === 'maintenance' ? (
+              <div className="grid gap-4 md:grid-cols-2">
+                <div className="space-y-2">
+                  <Label>{t.siteBanner.maintenanceStartsAt}</Label>
+                  <Input
+                    type="datetime-local"
+                    value={form.maintenance_starts_at}
+                    onChange={(e) => setForm({ ...form, maintenance_starts_at: e.target.value })}
+                  />
+                </div>
+                <div className="space-y-2">
+                  <Label>{t.siteBanner.maintenanceEndsAt}</Label>
+                  <Input
+                    type="datetime-local"
+                    value={form.maintenance_ends_at}
+                    onChange={(e) => setForm({ ...form, maintenance_ends_at: e.target.value })}
+                  />
+                </div>
+              </div>
+            ) : null}
+
+            <Tabs defaultValue="en">
+              <div className="flex items-center justify-between gap-2">
+                <TabsList>
+                  {BANNER_LOCALES.map((bannerLocale) => (
+                    <TabsTrigger key={bannerLocale} value={bannerLocale}>
+                      {bannerLocale === 'zh' ? t.siteBanner.localeZh : t.siteBanner.localeEn}
+                    </TabsTrigger>
+                  ))}
+                </TabsList>
+                <Select
+                  value={form.default_locale}
+                  onValueChange={(value) => setForm({ ...form, default_locale: value })}
+                >
+                  <SelectTrigger className="w-[180px]">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {BANNER_LOCALES.map((bannerLocale) => (
+                      <SelectItem key={bannerLocale} value={bannerLocale}>
+                        {t.siteBanner.defaultLocale.replace(
+                          '{locale}',
+                          bannerLocale === 'zh' ? t.siteBanner.localeZh : t.siteBanner.localeEn
+                        )}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              {BANNER_LOCALES.map((bannerLocale) => {
+                const content = form.translations[bannerLocale] || emptyContent()
+                return (
+                  <TabsContent key={bannerLocale} value={bannerLocale} className="space-y-3">
+                    <div className="space-y-2">
+                      <Label>{t.siteBanner.bannerTitle}</Label>
+                      <Input
+                        value={content.title}
+                        onChange={(e) => updateContent(bannerLocale, { title: e.target.value })}
+                      />
+                    </div>
+                    <div className="space-y-2">
+                      <Label>{t.siteBanner.message}</Label>
+                      <Textarea
+                        rows={3}
+                        value={content.message}
+                        onChange={(e) => updateContent(bannerLocale, { message: e.target.value })}
+                      />
+                    </div>
+                    <div className="grid gap-3 md:grid-cols-2">
+                      <div className="space-y-2">
+                        <Label>{t.siteBanner.linkUrl}</Label>
+                        <Input
+                          placeholder="/knowledge/1"
+                          value={content.link_url || ''}
+                          onChange={(e) => updateContent(bannerLocale, { link_url: e.target.value })}
+                        />
+                      </div>
+                      <div className="space-y-2">
+                        <Label>{t.siteBanner.linkText}</Label>
+                        <Input
+                          value={content.link_text || ''}
+                          onChange={(e) => updateContent(bannerLocale, { link_text: e.target.value })}
+                        />
+                      </div>
+                    </div>
+                  </TabsContent>
+                )
+              })}
+            </Tabs>
+
+            <div className="flex flex-wrap gap-6">
+              <div className="flex items-center gap-2">
+                <Switch
+                  checked={form.is_active}
+                  onCheckedChange={(checked) => setForm({ ...form, is_active: checked })}
+                />
+                <Label>{t.siteBanner.active}</Label>
+              </div>
+              <div className="flex items-center gap-2">
+                <Switch
+                  checked={form.dismissible}
+                  onCheckedChange={(checked) => setForm({ ...form, dismissible: checked })}
+                />
+                <Label>{t.siteBanner.dismissibleLabel}</Label>
+              </div>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setFormOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => saveMutation.mutate()} disabled={saveMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog open={Boolean(deleting)} onOpenChange={(open) => (!open ? setDeleting(null) : null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.siteBanner.deleteTitle}</AlertDialogTitle>
+            <AlertDialogDescription>{t.siteBanner.deleteConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deleting) deleteMutation.mutate(deleting.id)
+                setDeleting(null)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -0,0 +1,488 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Pencil, Plus, Search, Trash2, Upload } from 'lucide-react'
+import {
+  createBlocklistEntry,
+  deleteBlocklistEntry,
+  getBlocklistEntries,
+  importBlocklistEntries,
+  updateBlocklistEntry,
+  type BlocklistEntry,
+  type BlocklistImportResult,
+  type BlocklistType,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+const BLOCKLIST_TYPES: BlocklistType[] = ['ip', 'email', 'email_domain', 'phone']
+
+interface BlocklistForm {
+  type: BlocklistType
+  value: string
+  reason: string
+  expires_at: string
+}
+
+function createEmptyForm(): BlocklistForm {
+  return { type: 'ip', value: '', reason: '', expires_at: '' }
+}
+
+// ISO 时间转换为 datetime-local 输入框格式（本地时区）
+function toLocalInput(value?: string) {
+  if (!value) return ''
+  const date = new Date(value)
+  const pad = (n: number) => String(n).padStart(2, '0')
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}T${pad(date.getHours())}:${pad(date.getMinutes())}`
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+export default function AdminBlocklistPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminBlocklist)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('user.blocklist')
+  const fileInputRef = useRef<HTMLInputElement>(null)
+
+  const [page, setPage] = useState(1)
+  const [type, setType] = useState('all')
+  const [searchInput, setSearchInput] = useState('')
+  const [search, setSearch] = useState('')
+  const [editing, setEditing] = useState<BlocklistEntry | null>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [form, setForm] = useState<BlocklistForm>(createEmptyForm())
+  const [deleting, setDeleting] = useState<BlocklistEntry | null>(null)
+  const [importOpen, setImportOpen] = useState(false)
+  const [importFile, setImportFile] = useState<File | null>(null)
+  const [importType, setImportType] = useState<string>('none')
+  const [importResult, setImportResult] = useState<BlocklistImportResult | null>(null)
+
+  const typeLabels: Record<BlocklistType, string> = {
+    ip: t.blocklist.typeIp,
+    email: t.blocklist.typeEmail,
+    email_domain: t.blocklist.typeEmailDomain,
+    phone: t.blocklist.typePhone,
+  }
+  const scopeLabels: Record<string, string> = {
+    register: t.blocklist.scopeRegister,
+    login: t.blocklist.scopeLogin,
+    order: t.blocklist.scopeOrder,
+  }
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['adminBlocklist', page, type, search],
+    queryFn: () =>
+      getBlocklistEntries({
+        page,
+        limit: 20,
+        type: type === 'all' ? undefined : type,
+        search: search || undefined,
+      }),
+  })
+  const entries: BlocklistEntry[] = data?.data?.items || []
+
+  const saveMutation = useMutation({
+    mutationFn: () => {
+      const expiresAt = form.expires_at ? new Date(form.expires_at).toISOString() : null
+      return editing
+        ? updateBlocklistEntry(editing.id, { reason: form.reason, expires_at: expiresAt })
+        : createBlocklistEntry({
+            type: form.type,
+            value: form.value,
+            reason: form.reason,
+            expires_at: expiresAt,
+          })
+    },
+    onSuccess: () => {
+      toast.success(t.blocklist.saved)
+      setFormOpen(false)
+      queryClient.invalidateQueries({ queryKey: ['adminBlocklist'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.blocklist.saveFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deleteBlocklistEntry(id),
+    onSuccess: () => {
+      toast.success(t.blocklist.deleted)
+      queryClient.invalidateQueries({ queryKey: ['adminBlocklist'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.blocklist.deleteFailed))
+    },
+  })
+
+  const importMutation = useMutation({
+    mutationFn: () =>
+      importBlocklistEntries(
+        importFile!,
+        importType === 'none' ? undefined : (importType as BlocklistType)
+      ),
+    onSuccess: (res: any) => {
+      const result: BlocklistImportResult = res.data
+      setImportResult(result)
+      toast.success(
+        t.blocklist.importSummary
+          .replace('{created}', String(result.created_count))
+          .replace('{updated}', String(result.updated_count))
+          .replace('{errors}', String(result.error_count))
+      )
+      queryClient.invalidateQueries({ queryKey: ['adminBlocklist'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.blocklist.importFailed))
+    },
+  })
+
+  const openCreate = () => {
+    setEditing(null)
+    setForm(createEmptyForm())
+    setFormOpen(true)
+  }
+
+  const openEdit = (entry: BlocklistEntry) => {
+    setEditing(entry)
+    setForm({
+      type: entry.type,
+      value: entry.value,
+      reason: entry.reason || '',
+      expires_at: toLocalInput(entry.expires_at),
+    })
+    setFormOpen(true)
+  }
+
+  const openImport = () => {
+    setImportFile(null)
+    setImportType('none')
+    setImportResult(null)
+    if (fileInputRef.current) fileInputRef.current.value = ''
+    setImportOpen(true)
+  }
+
+  const columns = [
+    {
+      header: t.blocklist.value,
+      cell: ({ row }: { row: { original: BlocklistEntry } }) => (
+        <div className="max-w-[280px] space-y-1">
+          <code className="break-all text-sm">{row.original.value}</code>
+          <div>
+            <Badge variant="outline">{typeLabels[row.original.type] || row.original.type}</Badge>
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.blocklist.reason,
+      cell: ({ row }: { row: { original: BlocklistEntry } }) => (
+        <div className="max-w-[240px] whitespace-pre-wrap break-words text-sm">
+          {row.original.reason || '-'}
+        </div>
+      ),
+    },
+    {
+      header: t.blocklist.expiresAt,
+      cell: ({ row }: { row: { original: BlocklistEntry } }) => {
+        const expired =
+          row.original.expires_at && new Date(row.original.expires_at).getTime() <= Date.now()
+        return (
+          <div className="space-y-1 text-sm">
+            <div>
+              {row.original.expires_at ? formatDateTime(row.original.expires_at) : t.blocklist.never}
+            </div>
+            {expired ? <Badge variant="secondary">{t.blocklist.expired}</Badge> : null}
+          </div>
+        )
+      },
+    },
+    {
+      header: t.blocklist.matches,
+      cell: ({ row }: { row: { original: BlocklistEntry } }) => (
+        <div className="space-y-1 text-sm">
+          <div>{row.original.match_count}</div>
+          {row.original.last_matched_at ? (
+            <div className="text-xs text-muted-foreground">
+              {formatDateTime(row.original.last_matched_at)}
+              {row.original.last_matched_scope
+                ? ` · ${scopeLabels[row.original.last_matched_scope] || row.original.last_matched_scope}`
+                : ''}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: BlocklistEntry } }) =>
+        canManage ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openEdit(row.original)}>
+              <Pencil className="h-4 w-4" />
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => setDeleting(row.original)}>
+              <Trash2 className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-wrap items-start justify-between gap-4">
+        <div>
+          <h1 className="text-3xl font-bold">{t.blocklist.title}</h1>
+          <p className="text-sm text-muted-foreground">{t.blocklist.description}</p>
+        </div>
+        {canManage ? (
+          <div className="flex gap-2">
+            <Button variant="outline" onClick={openImport}>
+              <Upload className="mr-2 h-4 w-4" />
+              {t.blocklist.import}
+            </Button>
+            <Button onClick={openCreate}>
+              <Plus className="mr-2 h-4 w-4" />
+              {t.blocklist.create}
+            </Button>
+          </div>
+        ) : null}
+      </div>
+
+      <div className="flex flex-wrap gap-2">
+        <Select
+          value={type}
+          onValueChange={(value) => {
+            setType(value)
+            setPage(1)
+          }}
+        >
+          <SelectTrigger className="w-[160px]">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value="all">{t.blocklist.allTypes}</SelectItem>
+            {BLOCKLIST_TYPES.map((item) => (
+              <SelectItem key={item} value={item}>
+                {typeLabels[item]}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <form
+          className="flex gap-2"
+          onSubmit={(e) => {
+            e.preventDefault()
+            setSearch(searchInput.trim())
+            setPage(1)
+          }}
+        >
+          <Input
+            className="w-[240px]"
+            value={searchInput}
+            onChange={(e) => setSearchInput(e.target.value)}
+            placeholder={t.blocklist.searchPlaceholder}
+          />
+          <Button type="submit" variant="outline" size="icon">
+            <Search className="h-4 w-4" />
+          </Button>
+        </form>
+      </div>
+
+      <DataTable
+        columns={columns}
+        data={entries}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: data?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      <Dialog open={formOpen} onOpenChange={setFormOpen}>
+        <DialogContent className="max-w-lg">
+          <DialogHeader>
+            <DialogTitle>{editing ? t.blocklist.edit : t.blocklist.create}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="grid gap-4 md:grid-cols-[160px_1fr]">
+              <div className="space-y-2">
+                <Label>{t.blocklist.type}</Label>
+                <Select
+                  value={form.type}
+                  disabled={Boolean(editing)}
+                  onValueChange={(value) => setForm({ ...form, type: value as BlocklistType })}
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {BLOCKLIST_TYPES.map((item) => (
+                      <SelectItem key={item} value={item}>
+                        {typeLabels[item]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.blocklist.value}</Label>
+                <Input
+                  value={form.value}
+                  disabled={Boolean(editing)}
+                  onChange={(e) => setForm({ ...form, value: e.target.value })}
+                />
+              </div>
+            </div>
+            <p className="text-xs text-muted-foreground">{t.blocklist.valueHint}</p>
+            <div className="space-y-2">
+              <Label>{t.blocklist.reason}</Label>
+              <Input
+                value={form.reason}
+                maxLength={500}
+                onChange={(e) => setForm({ ...form, reason: e.target.value })}
+              />
+              <p className="text-xs text-muted-foreground">{t.blocklist.reasonHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.blocklist.expiresAt}</Label>
+              <Input
+                type="datetime-local"
+                value={form.expires_at}
+                onChange={(e) => setForm({ ...form, expires_at: e.target.value })}
+              />
+              <p className="text-xs text-muted-foreground">{t.blocklist.expiresAtHint}</p>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setFormOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              onClick={() => saveMutation.mutate()}
+              disabled={!form.value.trim() || saveMutation.isPending}
+            >
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={importOpen} onOpenChange={setImportOpen}>
+        <DialogContent className="max-w-lg">
+          <DialogHeader>
+            <DialogTitle>{t.blocklist.import}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.blocklist.importHint}</p>
+            <div className="space-y-2">
+              <Label>{t.blocklist.importFile}</Label>
+              <Input
+                ref={fileInputRef}
+                type="file"
+                accept=".csv,.xlsx"
+                onChange={(e) => setImportFile(e.target.files?.[0] || null)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.blocklist.importDefaultType}</Label>
+              <Select value={importType} onValueChange={setImportType}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="none">{t.blocklist.importTypeFromFile}</SelectItem>
+                  {BLOCKLIST_TYPES.map((item) => (
+                    <SelectItem key={item} value={item}>
+                      {typeLabels[item]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            {importResult && importResult.errors?.length > 0 ? (
+              <div className="max-h-40 space-y-1 overflow-y-auto rounded-md border p-3 text-xs text-destructive">
+                {importResult.errors.map((message) => (
+                  <div key={message}>{message}</div>
+                ))}
+              </div>
+            ) : null}
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setImportOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              onClick={() => importMutation.mutate()}
+              disabled={!importFile || importMutation.isPending}
+            >
+              {t.blocklist.import}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog open={Boolean(deleting)} onOpenChange={(open) => (!open ? setDeleting(null) : null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.blocklist.deleteTitle}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.blocklist.deleteConfirm.replace('{value}', deleting?.value || '')}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deleting) deleteMutation.mutate(deleting.id)
+                setDeleting(null)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -0,0 +1,873 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Ban, CheckCircle, ClipboardCheck, Download, FileText, Pencil } from 'lucide-react'
+import {
+  approveBusinessAccount,
+  getBusinessAccounts,
+  getBusinessAccountStatement,
+  getNetTermsInvoices,
+  markNetTermsInvoicePaid,
+  rejectBusinessAccount,
+  updateBusinessAccount,
+  voidNetTermsInvoice,
+  type BusinessAccount,
+  type BusinessAccountStatus,
+  type NetTermsInvoice,
+  type NetTermsStatement,
+  type NetTermsStatementLine,
+} from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency, majorToMinor, minorToMajor } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+interface TermsForm {
+  currency: string
+  credit_limit: string
+  terms_days: string
+  suspended: boolean
+  credit_hold: boolean
+  note: string
+}
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+function isOverdue(invoice: NetTermsInvoice) {
+  return invoice.status === 'open' && new Date(invoice.due_at).getTime() < Date.now()
+}
+
+export default function AdminBusinessAccountsPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminBusinessAccounts)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('business_account.manage')
+
+  const [accountPage, setAccountPage] = useState(1)
+  const [accountStatus, setAccountStatus] = useState('all')
+  const [search, setSearch] = useState('')
+  const [invoicePage, setInvoicePage] = useState(1)
+  const [invoiceStatus, setInvoiceStatus] = useState('open')
+  const [overdueOnly, setOverdueOnly] = useState(false)
+
+  const [reviewAccount, setReviewAccount] = useState<BusinessAccount | null>(null)
+  const [editAccount, setEditAccount] = useState<BusinessAccount | null>(null)
+  const [termsForm, setTermsForm] = useState<TermsForm>({
+    currency: 'CNY',
+    credit_limit: '',
+    terms_days: '30',
+    suspended: false,
+    credit_hold: false,
+    note: '',
+  })
+  const [statementAccount, setStatementAccount] = useState<BusinessAccount | null>(null)
+  const [period, setPeriod] = useState(() => {
+    const now = new Date()
+    return {
+      start: formatDateInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth(), 1))),
+      end: formatDateInput(now),
+    }
+  })
+  const [payInvoice, setPayInvoice] = useState<NetTermsInvoice | null>(null)
+  const [payReference, setPayReference] = useState('')
+  const [voidTarget, setVoidTarget] = useState<NetTermsInvoice | null>(null)
+  const [voidReason, setVoidReason] = useState('')
+
+  const { data: accountsData, isLoading: accountsLoading } = useQuery({
+    queryKey: ['businessAccounts', accountPage, accountStatus, search],
+    queryFn: () =>
+      getBusinessAccounts({
+        page: accountPage,
+        limit: 20,
+        status: accountStatus === 'all' ? undefined : accountStatus,
+        search: search || undefined,
+      }),
+  })
+  const accounts: BusinessAccount[] = accountsData?.data?.items || []
+
+  const { data: invoicesData, isLoading: invoicesLoading } = useQuery({
+    queryKey: ['netTermsInvoices', invoicePage, invoiceStatus, overdueOnly],
+    queryFn: () =>
+      getNetTermsInvoices({
+        page: invoicePage,
+        limit: 20,
+        status: invoiceStatus === 'all' ? undefined : invoiceStatus,
+        overdue: overdueOnly ? 1 : undefined,
+      }),
+  })
+  const invoices: NetTermsInvoice[] = invoicesData?.data?.items || []
+
+  const { data: statementData, isLoading: statementLoading } = useQuery({
+    queryKey: ['businessAccountStatement', statementAccount?.id, period.start, period.end],
+    queryFn: () =>
+      getBusinessAccountStatement(statementAccount!.id, { start: period.start, end: period.end }),
+    enabled: statementAccount !== null && Boolean(period.start && period.end),
+  })
+  const statement: NetTermsStatement | undefined = statementData?.data
+
+  const refreshAll = () => {
+    queryClient.invalidateQueries({ queryKey: ['businessAccounts'] })
+    queryClient.invalidateQueries({ queryKey: ['netTermsInvoices'] })
+    queryClient.invalidateQueries({ queryKey: ['businessAccountStatement'] })
+  }
+
+  const termsPayload = () => ({
+    currency: termsForm.currency,
+    credit_limit_minor: majorToMinor(termsForm.credit_limit || '0'),
+    terms_days: Number(termsForm.terms_days || 0),
+  })
+
+  const approveMutation = useMutation({
+    mutationFn: () =>
+      approveBusinessAccount(reviewAccount!.id, { ...termsPayload(), note: termsForm.note }),
+    onSuccess: () => {
+      toast.success(t.admin.businessAccountApproved)
+      setReviewAccount(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.businessAccountReviewFailed))
+    },
+  })
+
+  const rejectMutation = useMutation({
+    mutationFn: () => rejectBusinessAccount(reviewAccount!.id, termsForm.note),
+    onSuccess: () => {
+      toast.success(t.admin.businessAccountRejected)
+      setReviewAccount(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.businessAccountReviewFailed))
+    },
+  })
+
+  const updateMutation = useMutation({
+    mutationFn: () =>
+      updateBusinessAccount(editAccount!.id, {
+        ...termsPayload(),
+        suspended: termsForm.suspended,
+        credit_hold: termsForm.credit_hold,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.businessAccountSaved)
+      setEditAccount(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.businessAccountSaveFailed))
+    },
+  })
+
+  const markPaidMutation = useMutation({
+    mutationFn: () => markNetTermsInvoicePaid(payInvoice!.id, payReference),
+    onSuccess: () => {
+      toast.success(t.admin.netTermsInvoiceMarkedPaid)
+      setPayInvoice(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.netTermsInvoiceUpdateFailed))
+    },
+  })
+
+  const voidMutation = useMutation({
+    mutationFn: () => voidNetTermsInvoice(voidTarget!.id, voidReason),
+    onSuccess: () => {
+      toast.success(t.admin.netTermsInvoiceVoided)
+      setVoidTarget(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.netTermsInvoiceUpdateFailed))
+    },
+  })
+
+  const openTermsForm = (account: BusinessAccount) => {
+    setTermsForm({
+      currency: account.currency || 'CNY',
+      credit_limit: account.credit_limit_minor
+        ? minorToMajor(account.credit_limit_minor).toString()
+        : '',
+      terms_days: (account.terms_days || 30).toString(),
+      suspended: account.status === 'suspended',
+      credit_hold: account.credit_hold,
+      note: '',
+    })
+  }
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExportStatement = () => {
+    if (!statementAccount) return
+    const params = new URLSearchParams({ start: period.start, end: period.end })
+    const url = resolveClientAPIProxyURL(
+      `/api/admin/business-accounts/${statementAccount.id}/statement/export?${params.toString()}`
+    )
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `business_statement_${statementAccount.id}_${period.start}_${period.end}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+      })
+      .catch((err: Error) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const statusLabels: Record<BusinessAccountStatus, string> = {
+    pending: t.admin.businessAccountStatusPending,
+    approved: t.admin.businessAccountStatusApproved,
+    rejected: t.admin.businessAccountStatusRejected,
+    suspended: t.admin.businessAccountStatusSuspended,
+  }
+
+  const invoiceStatusLabels: Record<NetTermsInvoice['status'], string> = {
+    open: t.admin.netTermsInvoiceOpen,
+    paid: t.admin.netTermsInvoicePaid,
+    void: t.admin.netTermsInvoiceVoid,
+  }
+
+  const lineTypeLabels: Record<NetTermsStatementLine['type'], string> = {
+    invoice: t.admin.netTermsLineInvoice,
+    payment: t.admin.netTermsLinePayment,
+    void: t.admin.netTermsLineVoid,
+  }
+
+  const accountColumns = [
+    {
+      header: t.admin.businessAccountCompany,
+      cell: ({ row }: { row: { original: BusinessAccount } }) => (
+        <div>
+          <div className="font-medium">{row.original.company_name}</div>
+          <div className="text-xs text-muted-foreground">
+            {row.original.billing_email || row.original.user?.email || `#${row.original.user_id}`}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.businessAccountStatus,
+      cell: ({ row }: { row: { original: BusinessAccount } }) => (
+        <div className="flex flex-wrap gap-1">
+          <Badge variant={row.original.status === 'approved' ? 'secondary' : 'outline'}>
+            {statusLabels[row.original.status] || row.original.status}
+          </Badge>
+          {row.original.credit_hold ? (
+            <Badge variant="destructive">
+              {row.original.credit_hold_reason === 'overdue'
+                ? t.admin.businessAccountHoldOverdue
+                : t.admin.businessAccountHoldManual}
+            </Badge>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.businessAccountTerms,
+      cell: ({ row }: { row: { original: BusinessAccount } }) =>
+        row.original.status === 'pending' || row.original.status === 'rejected'
+          ? '-'
+          : t.admin.businessAccountTermsValue
+              .replace(
+                '{limit}',
+                formatCurrency(row.original.credit_limit_minor, row.original.currency)
+              )
+              .replace('{days}', String(row.original.terms_days)),
+    },
+    {
+      header: t.admin.businessAccountOutstanding,
+      cell: ({ row }: { row: { original: BusinessAccount } }) => {
+        const credit = row.original.credit
+        if (!credit || credit.open_invoice_count === 0) return '-'
+        return (
+          <div>
+            <div>{formatCurrency(credit.outstanding_minor, credit.currency)}</div>
+            {credit.overdue_minor > 0 ? (
+              <div className="text-xs text-destructive">
+                {t.admin.businessAccountOverdueAmount.replace(
+                  '{amount}',
+                  formatCurrency(credit.overdue_minor, credit.currency)
+                )}
+              </div>
+            ) : null}
+          </div>
+        )
+      },
+    },
+    {
+      header: t.admin.businessAccountAvailable,
+      cell: ({ row }: { row: { original: BusinessAccount } }) =>
+        row.original.credit && row.original.status === 'approved'
+          ? formatCurrency(row.original.credit.available_minor, row.original.credit.currency)
+          : '-',
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: BusinessAccount } }) => (
+        <div className="flex items-center gap-2">
+          {canManage && row.original.status === 'pending' ? (
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                openTermsForm(row.original)
+                setReviewAccount(row.original)
+              }}
+            >
+              <ClipboardCheck className="h-4 w-4" />
+            </Button>
+          ) : null}
+          {canManage &&
+          (row.original.status === 'approved' || row.original.status === 'suspended') ? (
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                openTermsForm(row.original)
+                setEditAccount(row.original)
+              }}
+            >
+              <Pencil className="h-4 w-4" />
+            </Button>
+          ) : null}
+          {row.original.status !== 'pending' && row.original.status !== 'rejected' ? (
+            <Button size="sm" variant="outline" onClick={() => setStatementAccount(row.original)}>
+              <FileText className="h-4 w-4" />
+            </Button>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  const invoiceColumns = [
+    {
+      header: t.admin.netTermsInvoiceNo,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.invoice_no}</div>
+          <div className="text-xs text-muted-foreground">{row.original.order_no}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.businessAccountCompany,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) =>
+        row.original.business_account?.company_name || `#${row.original.business_account_id}`,
+    },
+    {
+      header: t.admin.netTermsInvoiceAmount,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) =>
+        formatCurrency(row.original.amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.netTermsInvoiceIssued,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) =>
+        formatDate(row.original.issued_at),
+    },
+    {
+      header: t.admin.netTermsInvoiceDue,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) =>
+        isOverdue(row.original) ? (
+          <span className="text-destructive">{formatDate(row.original.due_at)}</span>
+        ) : (
+          formatDate(row.original.due_at)
+        ),
+    },
+    {
+      header: t.admin.businessAccountStatus,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) => (
+        <div>
+          <Badge variant={row.original.status === 'open' ? 'outline' : 'secondary'}>
+            {isOverdue(row.original)
+              ? t.admin.netTermsInvoiceOverdue
+              : invoiceStatusLabels[row.original.status]}
+          </Badge>
+          {row.original.payment_reference || row.original.void_reason ? (
+            <div className="mt-1 text-xs text-muted-foreground">
+              {row.original.payment_reference || row.original.void_reason}
+            </div>
+          ) : null}
+          {row.original.status === 'open' && row.original.dunning_count > 0 ? (
+            <div className="mt-1 text-xs text-muted-foreground">
+              {t.admin.netTermsDunningSent.replace('{count}', String(row.original.dunning_count))}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: NetTermsInvoice } }) =>
+        canManage && row.original.status === 'open' ? (
+          <div className="flex items-center gap-2">
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setPayReference('')
+                setPayInvoice(row.original)
+              }}
+            >
+              <CheckCircle className="h-4 w-4" />
+            </Button>
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setVoidReason('')
+                setVoidTarget(row.original)
+              }}
+            >
+              <Ban className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  const statementColumns = [
+    {
+      header: t.admin.netTermsLineDate,
+      cell: ({ row }: { row: { original: NetTermsStatementLine } }) =>
+        formatDate(row.original.date),
+    },
+    {
+      header: t.admin.netTermsLineType,
+      cell: ({ row }: { row: { original: NetTermsStatementLine } }) =>
+        lineTypeLabels[row.original.type] || row.original.type,
+    },
+    {
+      header: t.admin.netTermsInvoiceNo,
+      cell: ({ row }: { row: { original: NetTermsStatementLine } }) =>
+        row.original.reference
+          ? `${row.original.invoice_no} · ${row.original.reference}`
+          : row.original.invoice_no,
+    },
+    {
+      header: t.admin.netTermsInvoiceAmount,
+      cell: ({ row }: { row: { original: NetTermsStatementLine } }) =>
+        formatCurrency(row.original.amount_minor, statement?.currency),
+    },
+    {
+      header: t.admin.netTermsLineBalance,
+      cell: ({ row }: { row: { original: NetTermsStatementLine } }) =>
+        formatCurrency(row.original.balance_minor, statement?.currency),
+    },
+  ]
+
+  const termsFields = (
+    <div className="grid grid-cols-3 gap-4">
+      <div className="space-y-2">
+        <Label>{t.admin.businessAccountCurrency}</Label>
+        <Input
+          value={termsForm.currency}
+          onChange={(e) => setTermsForm({ ...termsForm, currency: e.target.value.toUpperCase() })}
+        />
+      </div>
+      <div className="space-y-2">
+        <Label>{t.admin.businessAccountCreditLimit}</Label>
+        <Input
+          type="number"
+          min={0}
+          step="0.01"
+          value={termsForm.credit_limit}
+          onChange={(e) => setTermsForm({ ...termsForm, credit_limit: e.target.value })}
+        />
+      </div>
+      <div className="space-y-2">
+        <Label>{t.admin.businessAccountTermsDays}</Label>
+        <Input
+          type="number"
+          min={1}
+          value={termsForm.terms_days}
+          onChange={(e) => setTermsForm({ ...termsForm, terms_days: e.target.value })}
+        />
+      </div>
+    </div>
+  )
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.admin.businessAccountManagementTitle}</h1>
+        <p className="mt-1 text-sm text-muted-foreground">
+          {t.admin.businessAccountManagementDesc}
+        </p>
+      </div>
+
+      <Tabs defaultValue="accounts">
+        <TabsList>
+          <TabsTrigger value="accounts">{t.admin.businessAccountTabAccounts}</TabsTrigger>
+          <TabsTrigger value="invoices">{t.admin.businessAccountTabInvoices}</TabsTrigger>
+        </TabsList>
+
+        <TabsContent value="accounts" className="space-y-4">
+          <div className="flex flex-col gap-3 md:flex-row md:items-center">
+            <Select
+              value={accountStatus}
+              onValueChange={(value) => {
+                setAccountStatus(value)
+                setAccountPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[150px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.common.all}</SelectItem>
+                {(Object.keys(statusLabels) as BusinessAccountStatus[]).map((status) => (
+                  <SelectItem key={status} value={status}>
+                    {statusLabels[status]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <Input
+              className="md:w-[280px]"
+              placeholder={t.admin.businessAccountSearchPlaceholder}
+              value={search}
+              onChange={(e) => {
+                setSearch(e.target.value)
+                setAccountPage(1)
+              }}
+            />
+          </div>
+          <DataTable
+            columns={accountColumns}
+            data={accounts}
+            isLoading={accountsLoading}
+            pagination={{
+              page: accountPage,
+              total_pages: accountsData?.data?.pagination?.total_pages || 1,
+              onPageChange: setAccountPage,
+            }}
+          />
+        </TabsContent>
+
+        <TabsContent value="invoices" className="space-y-4">
+          <div className="flex flex-col gap-3 md:flex-row md:items-center">
+            <Select
+              value={invoiceStatus}
+              onValueChange={(value) => {
+                setInvoiceStatus(value)
+                setInvoicePage(1)
+              }}
+            >
+              <SelectTrigger className="w-[150px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.common.all}</SelectItem>
+                <SelectItem value="open">{t.admin.netTermsInvoiceOpen}</SelectItem>
+                <SelectItem value="paid">{t.admin.netTermsInvoicePaid}</SelectItem>
+                <SelectItem value="void">{t.admin.netTermsInvoiceVoid}</SelectItem>
+              </SelectContent>
+            </Select>
+            <div className="flex items-center gap-2">
+              <Switch
+                checked={overdueOnly}
+                onCheckedChange={(checked) => {
+                  setOverdueOnly(checked)
+                  setInvoicePage(1)
+                }}
+              />
+              <Label>{t.admin.netTermsOverdueOnly}</Label>
+            </div>
+          </div>
+          <DataTable
+            columns={invoiceColumns}
+            data={invoices}
+            isLoading={invoicesLoading}
+            pagination={{
+              page: invoicePage,
+              total_pages: invoicesData?.data?.pagination?.total_pages || 1,
+              onPageChange: setInvoicePage,
+            }}
+          />
+        </TabsContent>
+      </Tabs>
+
+      {/* 审核申请 */}
+      <Dialog
+        open={reviewAccount !== null}
+        onOpenChange={(open) => !open && setReviewAccount(null)}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.businessAccountReview.replace('{name}', reviewAccount?.company_name || '')}
+            </DialogTitle>
+          </DialogHeader>
+          {reviewAccount ? (
+            <div className="space-y-4">
+              <div className="space-y-1 rounded-md border p-3 text-sm">
+                <div>
+                  {t.admin.businessAccountTaxId}: {reviewAccount.tax_id || '-'}
+                </div>
+                <div>
+                  {t.admin.businessAccountBillingEmail}:{' '}
+                  {reviewAccount.billing_email || reviewAccount.user?.email || '-'}
+                </div>
+                <div className="whitespace-pre-wrap">
+                  {t.admin.businessAccountBillingAddress}: {reviewAccount.billing_address || '-'}
+                </div>
+              </div>
+              {termsFields}
+              <div className="space-y-2">
+                <Label>{t.admin.businessAccountReviewNote}</Label>
+                <Textarea
+                  rows={3}
+                  value={termsForm.note}
+                  onChange={(e) => setTermsForm({ ...termsForm, note: e.target.value })}
+                />
+              </div>
+            </div>
+          ) : null}
+          <DialogFooter>
+            <Button
+              variant="outline"
+              onClick={() => rejectMutation.mutate()}
+              disabled={rejectMutation.isPending || approveMutation.isPending}
+            >
+              {t.admin.businessAccountReject}
+            </Button>
+            <Button
+              onClick={() => approveMutation.mutate()}
+              disabled={rejectMutation.isPending || approveMutation.isPending}
+            >
+              {t.admin.businessAccountApprove}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 调整条款 */}
+      <Dialog open={editAccount !== null} onOpenChange={(open) => !open && setEditAccount(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.businessAccountEdit.replace('{name}', editAccount?.company_name || '')}
+            </DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            {termsFields}
+            <div className="flex items-center justify-between rounded-md border p-3">
+              <div>
+                <Label>{t.admin.businessAccountCreditHold}</Label>
+                <p className="text-xs text-muted-foreground">
+                  {t.admin.businessAccountCreditHoldHint}
+                </p>
+              </div>
+              <Switch
+                checked={termsForm.credit_hold}
+                onCheckedChange={(checked) => setTermsForm({ ...termsForm, credit_hold: checked })}
+              />
+            </div>
+            <div className="flex items-center justify-between rounded-md border p-3">
+              <div>
+                <Label>{t.admin.businessAccountSuspended}</Label>
+                <p className="text-xs text-muted-foreground">
+                  {t.admin.businessAccountSuspendedHint}
+                </p>
+              </div>
+              <Switch
+                checked={termsForm.suspended}
+                onCheckedChange={(checked) => setTermsForm({ ...termsForm, suspended: checked })}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setEditAccount(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => updateMutation.mutate()} disabled={updateMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 对账单 */}
+      <Dialog
+        open={statementAccount !== null}
+        onOpenChange={(open) => !open && setStatementAccount(null)}
+      >
+        <DialogContent className="max-w-4xl">
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.businessAccountStatement.replace(
+                '{name}',
+                statementAccount?.company_name || ''
+              )}
+            </DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="flex flex-col gap-3 md:flex-row md:items-end">
+              <div className="space-y-2">
+                <Label>{t.admin.netTermsPeriodStart}</Label>
+                <Input
+                  type="date"
+                  value={period.start}
+                  onChange={(e) => setPeriod({ ...period, start: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.admin.netTermsPeriodEnd}</Label>
+                <Input
+                  type="date"
+                  value={period.end}
+                  onChange={(e) => setPeriod({ ...period, end: e.target.value })}
+                />
+              </div>
+              <Button variant="outline" onClick={handleExportStatement}>
+                <Download className="mr-2 h-4 w-4" />
+                {t.admin.netTermsExportStatement}
+              </Button>
+            </div>
+            {statement ? (
+              <div className="grid grid-cols-2 gap-3 text-sm md:grid-cols-5">
+                {[
+                  [t.admin.netTermsOpeningBalance, statement.opening_balance_minor],
+                  [t.admin.netTermsInvoiced, statement.invoiced_minor],
+                  [t.admin.netTermsPaid, statement.paid_minor],
+                  [t.admin.netTermsVoided, statement.voided_minor],
+                  [t.admin.netTermsClosingBalance, statement.closing_balance_minor],
+                ].map(([label, amount]) => (
+                  <div key={label as string} className="rounded-md border p-3">
+                    <div className="text-xs text-muted-foreground">{label}</div>
+                    <div className="font-medium">
+                      {formatCurrency(amount as number, statement.currency)}
+                    </div>
+                  </div>
+                ))}
+              </div>
+            ) : null}
+            <DataTable
+              columns={statementColumns}
+              data={statement?.lines || []}
+              isLoading={statementLoading}
+            />
+          </div>
+        </DialogContent>
+      </Dialog>
+
+      {/* 登记收款 */}
+      <Dialog open={payInvoice !== null} onOpenChange={(open) => !open && setPayInvoice(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.netTermsMarkPaid}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            {payInvoice ? (
+              <p className="text-sm">
+                {t.admin.netTermsMarkPaidConfirm
+                  .replace('{amount}', formatCurrency(payInvoice.amount_minor, payInvoice.currency))
+                  .replace('{invoice}', payInvoice.invoice_no)}
+              </p>
+            ) : null}
+            <div className="space-y-2">
+              <Label>{t.admin.netTermsPaymentReference}</Label>
+              <Input value={payReference} onChange={(e) => setPayReference(e.target.value)} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setPayInvoice(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => markPaidMutation.mutate()} disabled={markPaidMutation.isPending}>
+              {t.admin.netTermsMarkPaid}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 作废发票 */}
+      <Dialog open={voidTarget !== null} onOpenChange={(open) => !open && setVoidTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.netTermsVoidInvoice}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.admin.netTermsVoidHint}</p>
+            <div className="space-y-2">
+              <Label>{t.admin.netTermsVoidReason}</Label>
+              <Textarea
+                rows={3}
+                value={voidReason}
+                onChange={(e) => setVoidReason(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setVoidTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant="destructive"
+              onClick={() => voidMutation.mutate()}
+              disabled={voidMutation.isPending}
+            >
+              {t.admin.netTermsVoidInvoice}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
@@ -0,0 +1,349 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Ban, CheckCircle } from 'lucide-react'
+import {
+  confirmCODCollection,
+  getCODCollections,
+  getCODReport,
+  voidCODCollection,
+  type CODBalance,
+  type CODCollection,
+  type CODCollectionStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency, majorToMinor, minorToMajor } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+export default function AdminCODCollectionsPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminCODCollections)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('order.status_update')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('outstanding')
+  const [search, setSearch] = useState('')
+  const [confirmTarget, setConfirmTarget] = useState<CODCollection | null>(null)
+  const [collectedAmount, setCollectedAmount] = useState('')
+  const [reference, setReference] = useState('')
+  const [voidTarget, setVoidTarget] = useState<CODCollection | null>(null)
+  const [voidReason, setVoidReason] = useState('')
+
+  const { data: collectionsData, isLoading } = useQuery({
+    queryKey: ['codCollections', page, status, search],
+    queryFn: () =>
+      getCODCollections({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        search: search || undefined,
+      }),
+  })
+  const collections: CODCollection[] = collectionsData?.data?.items || []
+
+  const { data: reportData } = useQuery({
+    queryKey: ['codReport'],
+    queryFn: getCODReport,
+  })
+  const balances: CODBalance[] = reportData?.data?.balances || []
+
+  const refreshAll = () => {
+    queryClient.invalidateQueries({ queryKey: ['codCollections'] })
+    queryClient.invalidateQueries({ queryKey: ['codReport'] })
+  }
+
+  const confirmMutation = useMutation({
+    mutationFn: () =>
+      confirmCODCollection(confirmTarget!.id, {
+        collected_amount_minor: majorToMinor(collectedAmount || '0'),
+        reference: reference || undefined,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.codConfirmed)
+      setConfirmTarget(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.codUpdateFailed))
+    },
+  })
+
+  const voidMutation = useMutation({
+    mutationFn: () => voidCODCollection(voidTarget!.id, voidReason),
+    onSuccess: () => {
+      toast.success(t.admin.codVoided)
+      setVoidTarget(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.codUpdateFailed))
+    },
+  })
+
+  const statusLabels: Record<CODCollectionStatus, string> = {
+    outstanding: t.admin.codStatusOutstanding,
+    collected: t.admin.codStatusCollected,
+    void: t.admin.codStatusVoid,
+  }
+
+  const columns = [
+    {
+      header: t.admin.codOrder,
+      cell: ({ row }: { row: { original: CODCollection } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.order_no}</div>
+          {row.original.receiver_country ? (
+            <div className="text-xs text-muted-foreground">{row.original.receiver_country}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.codAmount,
+      cell: ({ row }: { row: { original: CODCollection } }) =>
+        formatCurrency(row.original.amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.codCollectedAmount,
+      cell: ({ row }: { row: { original: CODCollection } }) =>
+        row.original.collected_amount_minor !== undefined
+          ? formatCurrency(row.original.collected_amount_minor, row.original.currency)
+          : '-',
+    },
+    {
+      header: t.admin.codPlacedAt,
+      cell: ({ row }: { row: { original: CODCollection } }) => formatDate(row.original.created_at),
+    },
+    {
+      header: t.admin.businessAccountStatus,
+      cell: ({ row }: { row: { original: CODCollection } }) => (
+        <div>
+          <Badge variant={row.original.status === 'outstanding' ? 'outline' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          {row.original.reference || row.original.void_reason ? (
+            <div className="mt-1 text-xs text-muted-foreground">
+              {row.original.reference || row.original.void_reason}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: CODCollection } }) =>
+        canManage && row.original.status === 'outstanding' ? (
+          <div className="flex items-center gap-2">
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setCollectedAmount(minorToMajor(row.original.amount_minor).toString())
+                setReference('')
+                setConfirmTarget(row.original)
+              }}
+            >
+              <CheckCircle className="h-4 w-4" />
+            </Button>
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setVoidReason('')
+                setVoidTarget(row.original)
+              }}
+            >
+              <Ban className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.admin.codManagementTitle}</h1>
+        <p className="mt-1 text-sm text-muted-foreground">{t.admin.codManagementDesc}</p>
+      </div>
+
+      {balances.map((balance) => (
+        <div key={balance.currency} className="grid grid-cols-2 gap-3 text-sm md:grid-cols-6">
+          {[
+            [
+              t.admin.codOutstanding.replace('{count}', String(balance.outstanding_count)),
+              balance.outstanding_minor,
+            ],
+            ...balance.aging.map((bucket) => [
+              t.admin.codAgingDays.replace('{label}', bucket.label),
+              bucket.amount_minor,
+            ]),
+            [
+              t.admin.codCollectedTotal.replace('{count}', String(balance.collected_count)),
+              balance.collected_minor,
+            ],
+            [t.admin.codShortfall, balance.shortfall_minor],
+          ].map(([label, amount]) => (
+            <div key={label as string} className="rounded-md border p-3">
+              <div className="text-xs text-muted-foreground">{label}</div>
+              <div className="font-medium">
+                {formatCurrency(amount as number, balance.currency)}
+              </div>
+            </div>
+          ))}
+        </div>
+      ))}
+
+      <div className="flex flex-col gap-3 md:flex-row md:items-center">
+        <Select
+          value={status}
+          onValueChange={(value) => {
+            setStatus(value)
+            setPage(1)
+          }}
+        >
+          <SelectTrigger className="w-[150px]">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value="all">{t.common.all}</SelectItem>
+            {(Object.keys(statusLabels) as CODCollectionStatus[]).map((value) => (
+              <SelectItem key={value} value={value}>
+                {statusLabels[value]}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <Input
+          className="md:w-[280px]"
+          placeholder={t.admin.codSearchPlaceholder}
+          value={search}
+          onChange={(e) => {
+            setSearch(e.target.value)
+            setPage(1)
+          }}
+        />
+      </div>
+      <DataTable
+        columns={columns}
+        data={collections}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: collectionsData?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      {/* 登记收款 */}
+      <Dialog
+        open={confirmTarget !== null}
+        onOpenChange={(open) => !open && setConfirmTarget(null)}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.codConfirm}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            {confirmTarget ? (
+              <p className="text-sm">
+                {t.admin.codConfirmHint
+                  .replace('{order}', confirmTarget.order_no)
+                  .replace(
+                    '{amount}',
+                    formatCurrency(confirmTarget.amount_minor, confirmTarget.currency)
+                  )}
+              </p>
+            ) : null}
+            <div className="space-y-2">
+              <Label>{t.admin.codCollectedAmount}</Label>
+              <Input
+                type="number"
+                min="0"
+                step="0.01"
+                value={collectedAmount}
+                onChange={(e) => setCollectedAmount(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.codReference}</Label>
+              <Input value={reference} onChange={(e) => setReference(e.target.value)} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setConfirmTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => confirmMutation.mutate()} disabled={confirmMutation.isPending}>
+              {t.admin.codConfirm}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 作废代收 */}
+      <Dialog open={voidTarget !== null} onOpenChange={(open) => !open && setVoidTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.codVoid}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.admin.codVoidHint}</p>
+            <div className="space-y-2">
+              <Label>{t.admin.codVoidReason}</Label>
+              <Textarea
+                rows={3}
+                value={voidReason}
+                onChange={(e) => setVoidReason(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setVoidTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant="destructive"
+              onClick={() => voidMutation.mutate()}
+              disabled={voidMutation.isPending}
+            >
+              {t.admin.codVoid}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
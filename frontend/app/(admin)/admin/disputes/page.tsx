@@ -0,0 +1,526 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Gavel, ImagePlus, Loader2, Reply, X } from 'lucide-react'
+import {
+  decideDispute,
+  getAdminDisputes,
+  getAdminOrder,
+  submitDisputeEvidence,
+  uploadAdminTicketFile,
+  type OrderDispute,
+  type OrderDisputeDecision,
+  type OrderDisputeStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatPrice, parseMajorToMinor } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+const MAX_EVIDENCE = 10
+const DECISIONS: OrderDisputeDecision[] = ['refund_full', 'refund_partial', 'no_refund']
+
+export default function AdminDisputesPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminDisputes)
+  const { hasPermission } = usePermission()
+  const canRespond = hasPermission('ticket.reply')
+  const canDecide = hasPermission('ticket.dispute')
+  const fileInputRef = useRef<HTMLInputElement>(null)
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('all')
+  const [respondTarget, setRespondTarget] = useState<OrderDispute | null>(null)
+  const [statement, setStatement] = useState('')
+  const [evidence, setEvidence] = useState<string[]>([])
+  const [uploading, setUploading] = useState(false)
+  const [decideTarget, setDecideTarget] = useState<OrderDispute | null>(null)
+  const [decision, setDecision] = useState<OrderDisputeDecision>('refund_full')
+  const [quantities, setQuantities] = useState<Record<number, number>>({})
+  const [amount, setAmount] = useState('')
+  const [note, setNote] = useState('')
+
+  const { data: disputesData, isLoading } = useQuery({
+    queryKey: ['adminDisputes', page, status],
+    queryFn: () =>
+      getAdminDisputes({ page, limit: 20, status: status === 'all' ? undefined : status }),
+  })
+  const disputes: OrderDispute[] = disputesData?.data?.items || []
+
+  const { data: orderData } = useQuery({
+    queryKey: ['adminOrder', decideTarget?.order_id],
+    queryFn: () => getAdminOrder(decideTarget!.order_id),
+    enabled: !!decideTarget && decision === 'refund_partial',
+  })
+  const order = orderData?.data?.order || orderData?.data
+  const orderItems: { sku: string; name: string; quantity: number }[] = order?.items || []
+
+  const invalidate = () => {
+    queryClient.invalidateQueries({ queryKey: ['adminDisputes'] })
+  }
+
+  const respondMutation = useMutation({
+    mutationFn: () =>
+      submitDisputeEvidence(respondTarget!.id, { statement: statement.trim(), evidence }),
+    onSuccess: () => {
+      toast.success(t.dispute.responded)
+      setRespondTarget(null)
+      invalidate()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.dispute.updateFailed))
+    },
+  })
+
+  const selected = Object.entries(quantities)
+    .filter(([, quantity]) => quantity > 0)
+    .map(([index, quantity]) => ({ item_index: Number(index), quantity }))
+
+  const decideMutation = useMutation({
+    mutationFn: () =>
+      decideDispute(decideTarget!.id, {
+        decision,
+        items: decision === 'refund_partial' ? selected : undefined,
+        amount_minor:
+          decision === 'refund_partial' && amount.trim()
+            ? parseMajorToMinor(amount.trim()) || 0
+            : undefined,
+        note: note.trim(),
+      }),
+    onSuccess: (res: any) => {
+      const result: OrderDispute | undefined = res?.data
+      if (result?.decision === 'refund_partial' && result.refund_status === 'approved') {
+        toast.error(t.dispute.refundIssueFailed)
+      } else {
+        toast.success(t.dispute.decided)
+      }
+      setDecideTarget(null)
+      invalidate()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.dispute.updateFailed))
+    },
+  })
+
+  const statusLabels: Record<OrderDisputeStatus, string> = {
+    awaiting_evidence: t.dispute.statusAwaitingEvidence,
+    under_review: t.dispute.statusUnderReview,
+    resolved: t.dispute.statusResolved,
+  }
+  const decisionLabels: Record<OrderDisputeDecision, string> = {
+    refund_full: t.dispute.decisionRefundFull,
+    refund_partial: t.dispute.decisionRefundPartial,
+    no_refund: t.dispute.decisionNoRefund,
+  }
+
+  const isOverdue = (dispute: OrderDispute) =>
+    dispute.status === 'awaiting_evidence' && new Date(dispute.evidence_deadline) < new Date()
+
+  const openRespond = (dispute: OrderDispute) => {
+    setStatement('')
+    setEvidence([])
+    setRespondTarget(dispute)
+  }
+
+  const openDecide = (dispute: OrderDispute) => {
+    setDecision('refund_full')
+    setQuantities({})
+    setAmount('')
+    setNote('')
+    setDecideTarget(dispute)
+  }
+
+  const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    e.target.value = ''
+    if (!file || !respondTarget) return
+    setUploading(true)
+    try {
+      const res = await uploadAdminTicketFile(respondTarget.ticket_id, file)
+      const url = res.data?.url
+      if (url) {
+        setEvidence((prev) => [...prev, url])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+    } finally {
+      setUploading(false)
+    }
+  }
+
+  const renderEvidence = (urls?: string[]) =>
+    urls?.length ? (
+      <div className="flex flex-wrap gap-1">
+        {urls.map((url) => (
+          <a key={url} href={url} target="_blank" rel="noopener noreferrer">
+            {/* eslint-disable-next-line @next/next/no-img-element */}
+            <img src={url} alt="" className="h-10 w-10 rounded border object-cover" />
+          </a>
+        ))}
+      </div>
+    ) : null
+
+  const columns = [
+    {
+      header: t.dispute.disputeNo,
+      cell: ({ row }: { row: { original: OrderDispute } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.dispute_no}</div>
+          <Link
+            href={`/admin/orders/${row.original.order_id}`}
+            className="font-mono text-xs text-muted-foreground hover:underline"
+          >
+            {row.original.order_no}
+          </Link>
+          <div className="font-mono text-xs text-muted-foreground">{row.original.ticket_no}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.dispute.customer,
+      cell: ({ row }: { row: { original: OrderDispute } }) =>
+        row.original.user?.email || row.original.user?.name || `#${row.original.user_id}`,
+    },
+    {
+      header: t.dispute.buyer,
+      cell: ({ row }: { row: { original: OrderDispute } }) => (
+        <div className="max-w-[260px] space-y-1">
+          <p className="line-clamp-4 whitespace-pre-wrap text-xs">
+            {row.original.buyer_statement}
+          </p>
+          {renderEvidence(row.original.buyer_evidence)}
+        </div>
+      ),
+    },
+    {
+      header: t.dispute.merchant,
+      cell: ({ row }: { row: { original: OrderDispute } }) => (
+        <div className="max-w-[260px] space-y-1">
+          {row.original.merchant_statement ? (
+            <p className="line-clamp-4 whitespace-pre-wrap text-xs">
+              {row.original.merchant_statement}
+            </p>
+          ) : (
+            <p className="text-xs text-muted-foreground">{t.dispute.noMerchantResponse}</p>
+          )}
+          {renderEvidence(row.original.merchant_evidence)}
+        </div>
+      ),
+    },
+    {
+      header: t.dispute.status,
+      cell: ({ row }: { row: { original: OrderDispute } }) => (
+        <div className="space-y-1">
+          <Badge variant={isOverdue(row.original) ? 'destructive' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          {row.original.status === 'awaiting_evidence' ? (
+            <div className="text-xs text-muted-foreground">
+              {t.dispute.deadline}: {new Date(row.original.evidence_deadline).toLocaleString()}
+              {isOverdue(row.original) ? ` · ${t.dispute.overdue}` : ''}
+            </div>
+          ) : null}
+          {row.original.decision ? (
+            <div className="text-xs">
+              {decisionLabels[row.original.decision]}
+              {row.original.decision_amount_minor
+                ? ` · ${formatPrice(row.original.decision_amount_minor, row.original.currency)}`
+                : ''}
+            </div>
+          ) : null}
+          {row.original.decision_note ? (
+            <p className="line-clamp-3 max-w-[220px] whitespace-pre-wrap text-xs text-muted-foreground">
+              {row.original.decision_note}
+            </p>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.dispute.createdAt,
+      cell: ({ row }: { row: { original: OrderDispute } }) =>
+        new Date(row.original.created_at).toLocaleString(),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: OrderDispute } }) => {
+        const dispute = row.original
+        const decidable =
+          dispute.status === 'under_review' ||
+          (dispute.status === 'awaiting_evidence' && isOverdue(dispute))
+        return (
+          <div className="flex items-center gap-2">
+            {canRespond && dispute.status === 'awaiting_evidence' ? (
+              <Button
+                size="sm"
+                variant="outline"
+                title={t.dispute.respond}
+                onClick={() => openRespond(dispute)}
+              >
+                <Reply className="h-4 w-4" />
+              </Button>
+            ) : null}
+            {canDecide && decidable ? (
+              <Button
+                size="sm"
+                variant="outline"
+                title={t.dispute.decide}
+                onClick={() => openDecide(dispute)}
+              >
+                <Gavel className="h-4 w-4" />
+              </Button>
+            ) : null}
+          </div>
+        )
+      },
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.dispute.management}</h1>
+        <p className="mt-1 text-sm text-muted-foreground">{t.dispute.managementDesc}</p>
+      </div>
+
+      <Select
+        value={status}
+        onValueChange={(value) => {
+          setStatus(value)
+          setPage(1)
+        }}
+      >
+        <SelectTrigger className="w-[180px]">
+          <SelectValue />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value="all">{t.common.all}</SelectItem>
+          {(Object.keys(statusLabels) as OrderDisputeStatus[]).map((value) => (
+            <SelectItem key={value} value={value}>
+              {statusLabels[value]}
+            </SelectItem>
+          ))}
+        </SelectContent>
+      </Select>
+      <DataTable
+        columns={columns}
+        data={disputes}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: disputesData?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      {/* 商家答辩 */}
+      <Dialog
+        open={respondTarget !== null}
+        onOpenChange={(open) => !open && setRespondTarget(null)}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.dispute.respond}</DialogTitle>
+            <DialogDescription className="font-mono">{respondTarget?.dispute_no}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label htmlFor="dispute_statement">{t.dispute.responseStatement} *</Label>
+              <Textarea
+                id="dispute_statement"
+                rows={4}
+                maxLength={2000}
+                value={statement}
+                onChange={(e) => setStatement(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.dispute.evidence}</Label>
+              <div className="flex flex-wrap gap-2">
+                {evidence.map((url) => (
+                  <div key={url} className="relative h-16 w-16 overflow-hidden rounded border">
+                    {/* eslint-disable-next-line @next/next/no-img-element */}
+                    <img src={url} alt="" className="h-full w-full object-cover" />
+                    <button
+                      type="button"
+                      className="absolute right-0 top-0 rounded-bl bg-background/80 p-0.5"
+                      aria-label={t.dispute.removeEvidence}
+                      onClick={() => setEvidence((prev) => prev.filter((item) => item !== url))}
+                    >
+                      <X className="h-3 w-3" />
+                    </button>
+                  </div>
+                ))}
+                {evidence.length < MAX_EVIDENCE ? (
+                  <Button
+                    type="button"
+                    variant="outline"
+                    className="h-16 w-16 p-0"
+                    disabled={uploading}
+                    aria-label={t.dispute.uploadEvidence}
+                    title={t.dispute.uploadEvidence}
+                    onClick={() => fileInputRef.current?.click()}
+                  >
+                    {uploading ? (
+                      <Loader2 className="h-4 w-4 animate-spin" />
+                    ) : (
+                      <ImagePlus className="h-4 w-4" />
+                    )}
+                  </Button>
+                ) : null}
+              </div>
+              <input
+                ref={fileInputRef}
+                type="file"
+                accept="image/*"
+                className="hidden"
+                onChange={handleFileChange}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setRespondTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              onClick={() => respondMutation.mutate()}
+              disabled={respondMutation.isPending || uploading || !statement.trim()}
+            >
+              {t.dispute.respond}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 平台裁决 */}
+      <Dialog open={decideTarget !== null} onOpenChange={(open) => !open && setDecideTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {t.dispute.decide} · <span className="font-mono">{decideTarget?.dispute_no}</span>
+            </DialogTitle>
+            <DialogDescription>{t.dispute.decideDesc}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>{t.dispute.decision}</Label>
+              <Select
+                value={decision}
+                onValueChange={(value) => setDecision(value as OrderDisputeDecision)}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {DECISIONS.map((value) => (
+                    <SelectItem key={value} value={value}>
+                      {decisionLabels[value]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            {decision === 'refund_partial' ? (
+              <>
+                <div className="space-y-2">
+                  <Label>{t.dispute.refundItems} *</Label>
+                  {orderItems.map((item, index) => (
+                    <div
+                      key={`${item.sku}-${index}`}
+                      className="flex items-center justify-between gap-3"
+                    >
+                      <div className="min-w-0 text-sm">
+                        <div className="truncate">{item.name}</div>
+                        <div className="text-xs text-muted-foreground">
+                          {item.sku} × {item.quantity}
+                        </div>
+                      </div>
+                      <Input
+                        type="number"
+                        min={0}
+                        max={item.quantity}
+                        className="w-20"
+                        value={quantities[index] ?? 0}
+                        onChange={(e) => {
+                          const value = Math.min(
+                            Math.max(parseInt(e.target.value, 10) || 0, 0),
+                            item.quantity
+                          )
+                          setQuantities((prev) => ({ ...prev, [index]: value }))
+                        }}
+                      />
+                    </div>
+                  ))}
+                </div>
+                <div className="space-y-1.5">
+                  <Label htmlFor="dispute_amount">{t.dispute.refundAmount}</Label>
+                  <Input
+                    id="dispute_amount"
+                    inputMode="decimal"
+                    value={amount}
+                    onChange={(e) => setAmount(e.target.value)}
+                  />
+                  <p className="text-xs text-muted-foreground">{t.dispute.refundAmountHint}</p>
+                </div>
+              </>
+            ) : null}
+            <div className="space-y-2">
+              <Label htmlFor="dispute_note">{t.dispute.decisionNote} *</Label>
+              <Textarea
+                id="dispute_note"
+                rows={4}
+                maxLength={2000}
+                value={note}
+                onChange={(e) => setNote(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setDecideTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={decision === 'no_refund' ? 'destructive' : 'default'}
+              onClick={() => decideMutation.mutate()}
+              disabled={
+                decideMutation.isPending ||
+                !note.trim() ||
+                (decision === 'refund_partial' && selected.length === 0)
+              }
+            >
+              {decideMutation.isPending ? t.common.processing : t.dispute.decide}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
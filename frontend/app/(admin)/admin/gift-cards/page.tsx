@@ -0,0 +1,490 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Ban, Copy, History, Plus } from 'lucide-react'
+import {
+  getAdminGiftCard,
+  getAdminGiftCards,
+  issueGiftCards,
+  voidGiftCard,
+  type GiftCard,
+  type GiftCardStatus,
+  type GiftCardTransaction,
+  type GiftCardValueType,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency, majorToMinor } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+export default function AdminGiftCardsPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminGiftCards)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('product.edit')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('all')
+  const [search, setSearch] = useState('')
+  const [issueOpen, setIssueOpen] = useState(false)
+  const [issueForm, setIssueForm] = useState({
+    count: '1',
+    value_type: 'fixed' as GiftCardValueType,
+    amount: '',
+    currency: '',
+    expires_at: '',
+    note: '',
+  })
+  const [issuedCodes, setIssuedCodes] = useState<string[]>([])
+  const [ledgerTarget, setLedgerTarget] = useState<GiftCard | null>(null)
+  const [voidTarget, setVoidTarget] = useState<GiftCard | null>(null)
+  const [voidReason, setVoidReason] = useState('')
+
+  const { data: cardsData, isLoading } = useQuery({
+    queryKey: ['adminGiftCards', page, status, search],
+    queryFn: () =>
+      getAdminGiftCards({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        search: search || undefined,
+      }),
+  })
+  const cards: GiftCard[] = cardsData?.data?.items || []
+
+  const { data: ledgerData } = useQuery({
+    queryKey: ['adminGiftCard', ledgerTarget?.id],
+    queryFn: () => getAdminGiftCard(ledgerTarget!.id),
+    enabled: ledgerTarget !== null,
+  })
+  const transactions: GiftCardTransaction[] = ledgerData?.data?.transactions || []
+
+  const issueMutation = useMutation({
+    mutationFn: () =>
+      issueGiftCards({
+        count: parseInt(issueForm.count, 10) || 1,
+        value_type: issueForm.value_type,
+        amount_minor: majorToMinor(issueForm.amount || '0'),
+        currency: issueForm.currency.trim() || undefined,
+        expires_at: issueForm.expires_at
+          ? new Date(`${issueForm.expires_at}T23:59:59`).toISOString()
+          : undefined,
+        note: issueForm.note.trim() || undefined,
+      }),
+    onSuccess: (response) => {
+      const issued: GiftCard[] = response?.data?.items || []
+      toast.success(t.giftCard.issued.replace('{count}', String(issued.length)))
+      setIssuedCodes(issued.map((card) => card.code))
+      queryClient.invalidateQueries({ queryKey: ['adminGiftCards'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.giftCard.issueFailed))
+    },
+  })
+
+  const voidMutation = useMutation({
+    mutationFn: () => voidGiftCard(voidTarget!.id, voidReason),
+    onSuccess: () => {
+      toast.success(t.giftCard.voided)
+      setVoidTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['adminGiftCards'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.giftCard.voidFailed))
+    },
+  })
+
+  const openIssueDialog = () => {
+    setIssuedCodes([])
+    setIssueForm({
+      count: '1',
+      value_type: 'fixed',
+      amount: '',
+      currency: '',
+      expires_at: '',
+      note: '',
+    })
+    setIssueOpen(true)
+  }
+
+  const copyIssuedCodes = async () => {
+    await navigator.clipboard.writeText(issuedCodes.join('\n'))
+    toast.success(t.common.copiedToClipboard)
+  }
+
+  const statusLabels: Record<GiftCardStatus, string> = {
+    active: t.giftCard.statusActive,
+    void: t.giftCard.statusVoid,
+  }
+  const transactionLabels: Record<GiftCardTransaction['type'], string> = {
+    issue: t.giftCard.txIssue,
+    reserve: t.giftCard.txReserve,
+    release: t.giftCard.txRelease,
+    redeem: t.giftCard.txRedeem,
+    refund: t.giftCard.txRefund,
+    void: t.giftCard.txVoid,
+  }
+
+  const isExpired = (card: GiftCard) =>
+    !!card.expires_at && new Date(card.expires_at).getTime() < Date.now()
+
+  const columns = [
+    {
+      header: t.giftCard.code,
+      cell: ({ row }: { row: { original: GiftCard } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.code}</div>
+          {row.original.note ? (
+            <div className="text-xs text-muted-foreground">{row.original.note}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.giftCard.amount,
+      cell: ({ row }: { row: { original: GiftCard } }) =>
+        formatCurrency(row.original.initial_value_minor, row.original.currency),
+    },
+    {
+      header: t.giftCard.balance,
+      cell: ({ row }: { row: { original: GiftCard } }) => (
+        <div>
+          <div>{formatCurrency(row.original.balance_minor, row.original.currency)}</div>
+          {row.original.reserved_amount_minor > 0 ? (
+            <div className="text-xs text-muted-foreground">
+              {t.giftCard.reserved}:{' '}
+              {formatCurrency(row.original.reserved_amount_minor, row.original.currency)}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.giftCard.expiresAt,
+      cell: ({ row }: { row: { original: GiftCard } }) =>
+        row.original.expires_at ? formatDate(row.original.expires_at) : t.giftCard.noExpiry,
+    },
+    {
+      header: t.giftCard.status,
+      cell: ({ row }: { row: { original: GiftCard } }) => (
+        <div>
+          <Badge variant={row.original.status === 'active' ? 'outline' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          {row.original.status === 'active' && isExpired(row.original) ? (
+            <Badge variant="secondary" className="ml-1">
+              {t.giftCard.expired}
+            </Badge>
+          ) : null}
+          {row.original.void_reason ? (
+            <div className="mt-1 text-xs text-muted-foreground">{row.original.void_reason}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: GiftCard } }) => (
+        <div className="flex items-center gap-2">
+          <Button
+            size="sm"
+            variant="outline"
+            title={t.giftCard.ledger}
+            onClick={() => setLedgerTarget(row.original)}
+          >
+            <History className="h-4 w-4" />
+          </Button>
+          {canManage && row.original.status === 'active' ? (
+            <Button
+              size="sm"
+              variant="outline"
+              title={t.giftCard.void}
+              onClick={() => {
+                setVoidReason('')
+                setVoidTarget(row.original)
+              }}
+            >
+              <Ban className="h-4 w-4" />
+            </Button>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-col gap-3 md:flex-row md:items-start md:justify-between">
+        <div>
+          <h1 className="text-3xl font-bold">{t.giftCard.giftCardManagement}</h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.giftCard.giftCardManagementDesc}</p>
+        </div>
+        {canManage ? (
+          <Button onClick={openIssueDialog}>
+            <Plus className="mr-2 h-4 w-4" />
+            {t.giftCard.issue}
+          </Button>
+        ) : null}
+      </div>
+
+      <div className="flex flex-col gap-3 md:flex-row md:items-center">
+        <Select
+          value={status}
+          onValueChange={(value) => {
+            setStatus(value)
+            setPage(1)
+          }}
+        >
+          <SelectTrigger className="w-[150px]">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value="all">{t.common.all}</SelectItem>
+            {(Object.keys(statusLabels) as GiftCardStatus[]).map((value) => (
+              <SelectItem key={value} value={value}>
+                {statusLabels[value]}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <Input
+          className="md:w-[280px]"
+          placeholder={t.giftCard.searchPlaceholder}
+          value={search}
+          onChange={(e) => {
+            setSearch(e.target.value)
+            setPage(1)
+          }}
+        />
+      </div>
+      <DataTable
+        columns={columns}
+        data={cards}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: cardsData?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      {/* 发行礼品卡 */}
+      <Dialog open={issueOpen} onOpenChange={setIssueOpen}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.giftCard.issue}</DialogTitle>
+          </DialogHeader>
+          {issuedCodes.length > 0 ? (
+            <div className="space-y-2">
+              <Label>{t.giftCard.issuedCodes}</Label>
+              <Textarea
+                readOnly
+                rows={Math.min(issuedCodes.length, 10)}
+                className="font-mono"
+                value={issuedCodes.join('\n')}
+              />
+            </div>
+          ) : (
+            <div className="space-y-4">
+              <div className="grid grid-cols-2 gap-4">
+                <div className="space-y-2">
+                  <Label>{t.giftCard.valueType}</Label>
+                  <Select
+                    value={issueForm.value_type}
+                    onValueChange={(value) =>
+                      setIssueForm({ ...issueForm, value_type: value as GiftCardValueType })
+                    }
+                  >
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      <SelectItem value="fixed">{t.giftCard.valueFixed}</SelectItem>
+                      <SelectItem value="variable">{t.giftCard.valueVariable}</SelectItem>
+                    </SelectContent>
+                  </Select>
+                </div>
+                <div className="space-y-2">
+                  <Label>{t.giftCard.count}</Label>
+                  <Input
+                    type="number"
+                    min="1"
+                    max="100"
+                    value={issueForm.count}
+                    onChange={(e) => setIssueForm({ ...issueForm, count: e.target.value })}
+                  />
+                </div>
+                <div className="space-y-2">
+                  <Label>{t.giftCard.amount}</Label>
+                  <Input
+                    type="number"
+                    min="0"
+                    step="0.01"
+                    value={issueForm.amount}
+                    onChange={(e) => setIssueForm({ ...issueForm, amount: e.target.value })}
+                  />
+                </div>
+                <div className="space-y-2">
+                  <Label>{t.giftCard.currency}</Label>
+                  <Input
+                    maxLength={10}
+                    placeholder={t.giftCard.currencyPlaceholder}
+                    value={issueForm.currency}
+                    onChange={(e) => setIssueForm({ ...issueForm, currency: e.target.value })}
+                  />
+                </div>
+              </div>
+              <p className="text-xs text-muted-foreground">{t.giftCard.valueTypeHint}</p>
+              <div className="space-y-2">
+                <Label>{t.giftCard.expiresAt}</Label>
+                <Input
+                  type="date"
+                  value={issueForm.expires_at}
+                  onChange={(e) => setIssueForm({ ...issueForm, expires_at: e.target.value })}
+                />
+                <p className="text-xs text-muted-foreground">{t.giftCard.expiresAtHint}</p>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.giftCard.note}</Label>
+                <Textarea
+                  rows={2}
+                  maxLength={500}
+                  value={issueForm.note}
+                  onChange={(e) => setIssueForm({ ...issueForm, note: e.target.value })}
+                />
+              </div>
+            </div>
+          )}
+          <DialogFooter>
+            {issuedCodes.length > 0 ? (
+              <>
+                <Button variant="outline" onClick={copyIssuedCodes}>
+                  <Copy className="mr-2 h-4 w-4" />
+                  {t.giftCard.copyCodes}
+                </Button>
+                <Button onClick={() => setIssueOpen(false)}>{t.common.close}</Button>
+              </>
+            ) : (
+              <>
+                <Button variant="outline" onClick={() => setIssueOpen(false)}>
+                  {t.common.cancel}
+                </Button>
+                <Button
+                  onClick={() => issueMutation.mutate()}
+                  disabled={!issueForm.amount || issueMutation.isPending}
+                >
+                  {t.giftCard.issue}
+                </Button>
+              </>
+            )}
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 余额流水 */}
+      <Dialog open={ledgerTarget !== null} onOpenChange={(open) => !open && setLedgerTarget(null)}>
+        <DialogContent className="max-w-2xl">
+          <DialogHeader>
+            <DialogTitle>{t.giftCard.ledger}</DialogTitle>
+            <DialogDescription className="font-mono">{ledgerTarget?.code}</DialogDescription>
+          </DialogHeader>
+          {transactions.length === 0 ? (
+            <p className="text-sm text-muted-foreground">{t.common.noData}</p>
+          ) : (
+            <div className="max-h-[60vh] overflow-y-auto">
+              <table className="w-full text-sm">
+                <thead>
+                  <tr className="border-b text-left text-muted-foreground">
+                    <th className="py-2 font-medium">{t.giftCard.time}</th>
+                    <th className="py-2 font-medium">{t.giftCard.txType}</th>
+                    <th className="py-2 text-right font-medium">{t.giftCard.amount}</th>
+                    <th className="py-2 text-right font-medium">{t.giftCard.balanceAfter}</th>
+                    <th className="py-2 pl-4 font-medium">{t.giftCard.order}</th>
+                  </tr>
+                </thead>
+                <tbody>
+                  {transactions.map((tx) => (
+                    <tr key={tx.id} className="border-b last:border-0">
+                      <td className="py-2">{new Date(tx.created_at).toLocaleString()}</td>
+                      <td className="py-2">{transactionLabels[tx.type]}</td>
+                      <td className="py-2 text-right">
+                        {formatCurrency(tx.amount_minor, ledgerTarget?.currency || 'CNY')}
+                      </td>
+                      <td className="py-2 text-right">
+                        {formatCurrency(tx.balance_after_minor, ledgerTarget?.currency || 'CNY')}
+                      </td>
+                      <td className="py-2 pl-4 font-mono text-xs">{tx.order_no || '-'}</td>
+                    </tr>
+                  ))}
+                </tbody>
+              </table>
+            </div>
+          )}
+        </DialogContent>
+      </Dialog>
+
+      {/* 作废礼品卡 */}
+      <Dialog open={voidTarget !== null} onOpenChange={(open) => !open && setVoidTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.giftCard.void}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.giftCard.voidHint}</p>
+            <div className="space-y-2">
+              <Label>{t.giftCard.voidReason}</Label>
+              <Textarea
+                rows={3}
+                value={voidReason}
+                onChange={(e) => setVoidReason(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setVoidTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant="destructive"
+              onClick={() => voidMutation.mutate()}
+              disabled={!voidReason.trim() || voidMutation.isPending}
+            >
+              {t.giftCard.void}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
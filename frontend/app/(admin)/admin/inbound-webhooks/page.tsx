@@ -0,0 +1,691 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Copy, Inbox, KeyRound, Loader2, Pencil, Play, Plus, RotateCcw, Trash2 } from 'lucide-react'
+import {
+  createInboundWebhook,
+  deleteInboundWebhook,
+  getInboundWebhookEvents,
+  getInboundWebhooks,
+  replayInboundWebhookEvent,
+  rotateInboundWebhookSecret,
+  testInboundWebhook,
+  updateInboundWebhook,
+  type InboundWebhook,
+  type InboundWebhookAction,
+  type InboundWebhookEvent,
+  type InboundWebhookEventStatus,
+  type InboundWebhookInput,
+  type InboundWebhookTestResult,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { LazyCodeEditor } from '@/components/ui/lazy-code-editor'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Switch } from '@/components/ui/switch'
+import { Textarea } from '@/components/ui/textarea'
+import { useTheme } from '@/contexts/theme-context'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { getTranslations } from '@/lib/i18n'
+
+const WEBHOOK_ACTIONS: InboundWebhookAction[] = ['mark_paid', 'add_tracking', 'ticket_note']
+
+const EVENT_STATUSES: InboundWebhookEventStatus[] = [
+  'processed',
+  'partial',
+  'failed',
+  'ignored',
+  'received',
+]
+
+const DEFAULT_SCRIPT = `// event: { webhook, headers, query, body, raw }
+// 返回 null 忽略事件，或返回一个/一组动作
+function transform(event) {
+  if (event.body.type !== 'payment.succeeded') return null
+  return { action: 'mark_paid', order_no: event.body.reference }
+}
+`
+
+const DEFAULT_PAYLOAD = '{"type": "payment.succeeded", "reference": "ORD20260101000001"}'
+
+function createEmptyForm(): InboundWebhookInput {
+  return {
+    name: '',
+    slug: '',
+    description: '',
+    auth_mode: 'hmac_sha256',
+    signature_header: 'X-Webhook-Signature',
+    event_id_header: '',
+    secret: '',
+    script: DEFAULT_SCRIPT,
+    allowed_actions: ['mark_paid'],
+    enabled: true,
+  }
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+function endpointURL(slug: string) {
+  const origin = typeof window === 'undefined' ? '' : window.location.origin
+  return `${origin}/api/webhooks/inbound/${slug}`
+}
+
+export default function AdminInboundWebhooksPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminInboundWebhooks)
+  const { resolvedTheme } = useTheme()
+
+  const [editing, setEditing] = useState<InboundWebhook | null>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [form, setForm] = useState<InboundWebhookInput>(createEmptyForm())
+  const [testPayload, setTestPayload] = useState(DEFAULT_PAYLOAD)
+  const [testResult, setTestResult] = useState<InboundWebhookTestResult | null>(null)
+  const [deleting, setDeleting] = useState<InboundWebhook | null>(null)
+  const [rotating, setRotating] = useState<InboundWebhook | null>(null)
+  const [revealedSecret, setRevealedSecret] = useState('')
+  const [inbox, setInbox] = useState<InboundWebhook | null>(null)
+  const [eventPage, setEventPage] = useState(1)
+  const [eventStatus, setEventStatus] = useState('all')
+
+  const actionLabels: Record<InboundWebhookAction, string> = {
+    mark_paid: t.inboundWebhook.actionMarkPaid,
+    add_tracking: t.inboundWebhook.actionAddTracking,
+    ticket_note: t.inboundWebhook.actionTicketNote,
+  }
+  const statusLabels: Record<InboundWebhookEventStatus, string> = {
+    received: t.inboundWebhook.statusReceived,
+    processed: t.inboundWebhook.statusProcessed,
+    partial: t.inboundWebhook.statusPartial,
+    failed: t.inboundWebhook.statusFailed,
+    ignored: t.inboundWebhook.statusIgnored,
+  }
+  const statusVariants: Record<
+    InboundWebhookEventStatus,
+    'default' | 'secondary' | 'destructive' | 'outline'
+  > = {
+    received: 'outline',
+    processed: 'default',
+    partial: 'secondary',
+    failed: 'destructive',
+    ignored: 'outline',
+  }
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['adminInboundWebhooks'],
+    queryFn: getInboundWebhooks,
+  })
+  const webhooks: InboundWebhook[] = data?.data || []
+
+  const { data: eventsData, isLoading: eventsLoading } = useQuery({
+    queryKey: ['adminInboundWebhookEvents', inbox?.id, eventPage, eventStatus],
+    queryFn: () =>
+      getInboundWebhookEvents(inbox!.id, {
+        page: eventPage,
+        limit: 20,
+        status: eventStatus === 'all' ? undefined : eventStatus,
+      }),
+    enabled: Boolean(inbox),
+  })
+  const events: InboundWebhookEvent[] = eventsData?.data?.items || []
+
+  const saveMutation = useMutation({
+    mutationFn: () => {
+      const payload = { ...form, secret: form.secret?.trim() || undefined }
+      return editing ? updateInboundWebhook(editing.id, payload) : createInboundWebhook(payload)
+    },
+    onSuccess: (res: any) => {
+      toast.success(t.inboundWebhook.saved)
+      setFormOpen(false)
+      if (!editing && res.data?.secret) setRevealedSecret(res.data.secret)
+      queryClient.invalidateQueries({ queryKey: ['adminInboundWebhooks'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.inboundWebhook.saveFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deleteInboundWebhook(id),
+    onSuccess: () => {
+      toast.success(t.inboundWebhook.deleted)
+      queryClient.invalidateQueries({ queryKey: ['adminInboundWebhooks'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.inboundWebhook.deleteFailed))
+    },
+  })
+
+  const rotateMutation = useMutation({
+    mutationFn: (id: number) => rotateInboundWebhookSecret(id),
+    onSuccess: (res: any) => setRevealedSecret(res.data?.secret || ''),
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.inboundWebhook.rotateFailed))
+    },
+  })
+
+  const testMutation = useMutation({
+    mutationFn: () =>
+      testInboundWebhook(editing!.id, { script: form.script, payload: testPayload }),
+    onSuccess: (res: any) => setTestResult(res.data),
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.inboundWebhook.testFailed))
+    },
+  })
+
+  const replayMutation = useMutation({
+    mutationFn: (event: InboundWebhookEvent) =>
+      replayInboundWebhookEvent(event.webhook_id, event.id),
+    onSuccess: () => {
+      toast.success(t.inboundWebhook.replayed)
+      queryClient.invalidateQueries({ queryKey: ['adminInboundWebhookEvents'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.inboundWebhook.replayFailed))
+    },
+  })
+
+  const openCreate = () => {
+    setEditing(null)
+    setForm(createEmptyForm())
+    setTestResult(null)
+    setFormOpen(true)
+  }
+
+  const openEdit = (webhook: InboundWebhook) => {
+    setEditing(webhook)
+    setForm({
+      name: webhook.name,
+      slug: webhook.slug,
+      description: webhook.description || '',
+      auth_mode: webhook.auth_mode,
+      signature_header: webhook.signature_header,
+      event_id_header: webhook.event_id_header || '',
+      secret: '',
+      script: webhook.script,
+      allowed_actions: webhook.allowed_actions || [],
+      enabled: webhook.enabled,
+    })
+    setTestResult(null)
+    setFormOpen(true)
+  }
+
+  const openInbox = (webhook: InboundWebhook) => {
+    setInbox(webhook)
+    setEventPage(1)
+    setEventStatus('all')
+  }
+
+  const toggleAction = (action: InboundWebhookAction, checked: boolean) => {
+    const current = form.allowed_actions.filter((item) => item !== action)
+    setForm({ ...form, allowed_actions: checked ? [...current, action] : current })
+  }
+
+  const copy = async (value: string) => {
+    await navigator.clipboard.writeText(value)
+    toast.success(t.inboundWebhook.copied)
+  }
+
+  const columns = [
+    {
+      header: t.inboundWebhook.name,
+      cell: ({ row }: { row: { original: InboundWebhook } }) => (
+        <div className="max-w-[320px] space-y-1">
+          <div className="flex items-center gap-2">
+            <span className="font-medium">{row.original.name}</span>
+            <Badge variant={row.original.enabled ? 'default' : 'outline'}>
+              {row.original.enabled ? t.inboundWebhook.enabled : t.inboundWebhook.disabled}
+            </Badge>
+          </div>
+          <button
+            type="button"
+            className="flex items-center gap-1 break-all text-left font-mono text-xs text-muted-foreground hover:text-foreground"
+            onClick={() => copy(endpointURL(row.original.slug))}
+          >
+            {endpointURL(row.original.slug)}
+            <Copy className="h-3 w-3 shrink-0" />
+          </button>
+        </div>
+      ),
+    },
+    {
+      header: t.inboundWebhook.allowedActions,
+      cell: ({ row }: { row: { original: InboundWebhook } }) => (
+        <div className="flex flex-wrap gap-1">
+          {(row.original.allowed_actions || []).map((action) => (
+            <Badge key={action} variant="secondary">
+              {actionLabels[action] || action}
+            </Badge>
+          ))}
+        </div>
+      ),
+    },
+    {
+      header: t.inboundWebhook.lastReceived,
+      cell: ({ row }: { row: { original: InboundWebhook } }) => (
+        <span className="text-sm">{formatDateTime(row.original.last_received_at)}</span>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: InboundWebhook } }) => (
+        <div className="flex gap-2">
+          <Button size="sm" variant="outline" onClick={() => openInbox(row.original)}>
+            <Inbox className="mr-1 h-4 w-4" />
+            {t.inboundWebhook.inbox}
+          </Button>
+          <Button size="sm" variant="outline" onClick={() => openEdit(row.original)}>
+            <Pencil className="h-4 w-4" />
+          </Button>
+          <Button size="sm" variant="outline" onClick={() => setRotating(row.original)}>
+            <KeyRound className="h-4 w-4" />
+          </Button>
+          <Button size="sm" variant="outline" onClick={() => setDeleting(row.original)}>
+            <Trash2 className="h-4 w-4" />
+          </Button>
+        </div>
+      ),
+    },
+  ]
+
+  const eventColumns = [
+    {
+      header: t.inboundWebhook.receivedAt,
+      cell: ({ row }: { row: { original: InboundWebhookEvent } }) => (
+        <div className="space-y-1 text-sm">
+          <div>{formatDateTime(row.original.created_at)}</div>
+          <div className="max-w-[200px] truncate font-mono text-xs text-muted-foreground">
+            {row.original.event_key}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.inboundWebhook.status,
+      cell: ({ row }: { row: { original: InboundWebhookEvent } }) => (
+        <div className="space-y-1">
+          <Badge variant={statusVariants[row.original.status]}>
+            {statusLabels[row.original.status] || row.original.status}
+          </Badge>
+          {row.original.attempts > 1 ? (
+            <div className="text-xs text-muted-foreground">
+              {t.inboundWebhook.attempts.replace('{count}', String(row.original.attempts))}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.inboundWebhook.results,
+      cell: ({ row }: { row: { original: InboundWebhookEvent } }) => (
+        <div className="max-w-[360px] space-y-1 text-xs">
+          {row.original.error ? (
+            <div className="break-words text-destructive">{row.original.error}</div>
+          ) : null}
+          {(row.original.actions || []).map((result, index) => (
+            <div key={index} className={result.success ? '' : 'text-destructive'}>
+              {actionLabels[result.action] || result.action}{' '}
+              {result.order_no || result.ticket_no}
+              {result.error ? ` · ${result.error}` : ''}
+            </div>
+          ))}
+          <details>
+            <summary className="cursor-pointer text-muted-foreground">
+              {t.inboundWebhook.payload}
+            </summary>
+            <pre className="mt-1 max-h-40 overflow-auto whitespace-pre-wrap break-all rounded bg-muted p-2">
+              {row.original.payload}
+            </pre>
+            {row.original.console?.length ? (
+              <pre className="mt-1 max-h-24 overflow-auto whitespace-pre-wrap rounded bg-muted p-2">
+                {row.original.console.join('\n')}
+              </pre>
+            ) : null}
+          </details>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: InboundWebhookEvent } }) => (
+        <Button
+          size="sm"
+          variant="outline"
+          disabled={replayMutation.isPending}
+          onClick={() => replayMutation.mutate(row.original)}
+        >
+          <RotateCcw className="mr-1 h-4 w-4" />
+          {t.inboundWebhook.replay}
+        </Button>
+      ),
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-wrap items-start justify-between gap-4">
+        <div>
+          <h1 className="text-3xl font-bold">{t.inboundWebhook.title}</h1>
+          <p className="text-sm text-muted-foreground">{t.inboundWebhook.description}</p>
+        </div>
+        <Button onClick={openCreate}>
+          <Plus className="mr-2 h-4 w-4" />
+          {t.inboundWebhook.create}
+        </Button>
+      </div>
+
+      <DataTable columns={columns} data={webhooks} isLoading={isLoading} />
+
+      <Dialog open={formOpen} onOpenChange={setFormOpen}>
+        <DialogContent className="max-h-[90vh] max-w-3xl overflow-y-auto">
+          <DialogHeader>
+            <DialogTitle>{editing ? t.inboundWebhook.edit : t.inboundWebhook.create}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="grid gap-4 sm:grid-cols-2">
+              <div className="space-y-2">
+                <Label>{t.inboundWebhook.name}</Label>
+                <Input
+                  value={form.name}
+                  maxLength={100}
+                  onChange={(e) => setForm({ ...form, name: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.inboundWebhook.slug}</Label>
+                <Input
+                  value={form.slug}
+                  maxLength={64}
+                  placeholder="bank-transfer"
+                  onChange={(e) => setForm({ ...form, slug: e.target.value.toLowerCase() })}
+                />
+                <p className="break-all text-xs text-muted-foreground">
+                  {endpointURL(form.slug || '{slug}')}
+                </p>
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.inboundWebhook.descriptionLabel}</Label>
+              <Input
+                value={form.description}
+                onChange={(e) => setForm({ ...form, description: e.target.value })}
+              />
+            </div>
+            <div className="grid gap-4 sm:grid-cols-3">
+              <div className="space-y-2">
+                <Label>{t.inboundWebhook.authMode}</Label>
+                <Select
+                  value={form.auth_mode}
+                  onValueChange={(value) =>
+                    setForm({ ...form, auth_mode: value as InboundWebhookInput['auth_mode'] })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="hmac_sha256">{t.inboundWebhook.authHmac}</SelectItem>
+                    <SelectItem value="header">{t.inboundWebhook.authHeader}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.inboundWebhook.signatureHeader}</Label>
+                <Input
+                  value={form.signature_header}
+                  onChange={(e) => setForm({ ...form, signature_header: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.inboundWebhook.eventIdHeader}</Label>
+                <Input
+                  value={form.event_id_header}
+                  placeholder="X-Event-Id"
+                  onChange={(e) => setForm({ ...form, event_id_header: e.target.value })}
+                />
+              </div>
+            </div>
+            <p className="text-xs text-muted-foreground">{t.inboundWebhook.authHint}</p>
+            <div className="space-y-2">
+              <Label>{t.inboundWebhook.secret}</Label>
+              <Input
+                type="password"
+                autoComplete="new-password"
+                value={form.secret}
+                onChange={(e) => setForm({ ...form, secret: e.target.value })}
+              />
+              <p className="text-xs text-muted-foreground">
+                {editing ? t.inboundWebhook.secretKeepHint : t.inboundWebhook.secretHint}
+              </p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.inboundWebhook.allowedActions}</Label>
+              <div className="flex flex-wrap gap-4">
+                {WEBHOOK_ACTIONS.map((action) => (
+                  <label key={action} className="flex items-center gap-2 text-sm">
+                    <Checkbox
+                      checked={form.allowed_actions.includes(action)}
+                      onCheckedChange={(checked) => toggleAction(action, checked === true)}
+                    />
+                    {actionLabels[action]}
+                  </label>
+                ))}
+              </div>
+              <p className="text-xs text-muted-foreground">{t.inboundWebhook.allowedActionsHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.inboundWebhook.script}</Label>
+              <LazyCodeEditor
+                value={form.script}
+                onChange={(value) => setForm({ ...form, script: value })}
+                language="javascript"
+                height="220px"
+                theme={resolvedTheme === 'dark' ? 'dark' : 'light'}
+                className="overflow-hidden rounded-md border text-sm"
+              />
+              <p className="text-xs text-muted-foreground">{t.inboundWebhook.scriptHint}</p>
+            </div>
+            {editing ? (
+              <div className="space-y-2 rounded-md border p-3">
+                <Label>{t.inboundWebhook.testPayload}</Label>
+                <Textarea
+                  rows={4}
+                  className="font-mono text-xs"
+                  value={testPayload}
+                  onChange={(e) => setTestPayload(e.target.value)}
+                />
+                <Button
+                  size="sm"
+                  variant="outline"
+                  disabled={testMutation.isPending}
+                  onClick={() => testMutation.mutate()}
+                >
+                  <Play className="mr-1 h-4 w-4" />
+                  {t.inboundWebhook.runTest}
+                </Button>
+                {testResult ? (
+                  <pre className="max-h-48 overflow-auto whitespace-pre-wrap rounded bg-muted p-2 text-xs">
+                    {testResult.error
+                      ? testResult.error
+                      : JSON.stringify(testResult.actions, null, 2)}
+                    {testResult.console?.length ? `\n\n${testResult.console.join('\n')}` : ''}
+                  </pre>
+                ) : null}
+                <p className="text-xs text-muted-foreground">{t.inboundWebhook.testHint}</p>
+              </div>
+            ) : null}
+            <div className="flex items-center gap-2">
+              <Switch
+                id="inbound_webhook_enabled"
+                checked={form.enabled}
+                onCheckedChange={(checked) => setForm({ ...form, enabled: checked })}
+              />
+              <Label htmlFor="inbound_webhook_enabled">{t.inboundWebhook.enabled}</Label>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setFormOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              onClick={() => saveMutation.mutate()}
+              disabled={
+                !form.name.trim() ||
+                !form.slug.trim() ||
+                form.allowed_actions.length === 0 ||
+                saveMutation.isPending
+              }
+            >
+              {saveMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog
+        open={Boolean(revealedSecret)}
+        onOpenChange={(open) => !open && setRevealedSecret('')}
+      >
+        <DialogContent className="max-w-lg">
+          <DialogHeader>
+            <DialogTitle>{t.inboundWebhook.secretTitle}</DialogTitle>
+            <DialogDescription>{t.inboundWebhook.secretOnce}</DialogDescription>
+          </DialogHeader>
+          <div className="flex items-center gap-2">
+            <Input readOnly value={revealedSecret} className="font-mono" />
+            <Button variant="outline" onClick={() => copy(revealedSecret)}>
+              <Copy className="h-4 w-4" />
+            </Button>
+          </div>
+          <DialogFooter>
+            <Button onClick={() => setRevealedSecret('')}>{t.common.close}</Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={Boolean(inbox)} onOpenChange={(open) => !open && setInbox(null)}>
+        <DialogContent className="max-h-[90vh] max-w-5xl overflow-y-auto">
+          <DialogHeader>
+            <DialogTitle>
+              {t.inboundWebhook.inboxTitle.replace('{name}', inbox?.name || '')}
+            </DialogTitle>
+            <DialogDescription>{t.inboundWebhook.inboxHint}</DialogDescription>
+          </DialogHeader>
+          <Select
+            value={eventStatus}
+            onValueChange={(value) => {
+              setEventStatus(value)
+              setEventPage(1)
+            }}
+          >
+            <SelectTrigger className="w-[180px]">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="all">{t.common.all}</SelectItem>
+              {EVENT_STATUSES.map((status) => (
+                <SelectItem key={status} value={status}>
+                  {statusLabels[status]}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <DataTable
+            columns={eventColumns}
+            data={events}
+            isLoading={eventsLoading}
+            pagination={{
+              page: eventPage,
+              total_pages: eventsData?.data?.pagination?.total_pages || 1,
+              onPageChange: setEventPage,
+            }}
+          />
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog
+        open={Boolean(rotating)}
+        onOpenChange={(open) => (!open ? setRotating(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.inboundWebhook.rotateTitle}</AlertDialogTitle>
+            <AlertDialogDescription>{t.inboundWebhook.rotateConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (rotating) rotateMutation.mutate(rotating.id)
+                setRotating(null)
+              }}
+            >
+              {t.inboundWebhook.rotate}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+
+      <AlertDialog
+        open={Boolean(deleting)}
+        onOpenChange={(open) => (!open ? setDeleting(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.inboundWebhook.deleteTitle}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.inboundWebhook.deleteConfirm.replace('{name}', deleting?.name || '')}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deleting) deleteMutation.mutate(deleting.id)
+                setDeleting(null)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -3,7 +3,13 @@
 import { use, useEffect, useState } from 'react'
 import { useRouter } from 'next/navigation'
 import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
-import { getInventory, updateInventory, adjustStock } from '@/lib/api'
+import {
+  getInventory,
+  getInventoryFlashSaleStatus,
+  updateInventory,
+  adjustStock,
+  FlashSaleStatus,
+} from '@/lib/api'
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
@@ -39,11 +45,21 @@ export default function InventoryDetailPage({ params }: { params: Promise<{ id:
 
   const inventory = data?.data
 
+  // 秒杀库存的 Redis 计数
+  const { data: flashSaleData } = useQuery({
+    queryKey: ['inventoryFlashSale', id],
+    queryFn: () => getInventoryFlashSaleStatus(parseInt(id)),
+    enabled: Boolean(inventory?.flash_sale),
+    refetchInterval: 5000,
+  })
+  const flashSaleStatus: FlashSaleStatus | undefined = flashSaleData?.data
+
   // 表单状态
   const [stock, setStock] = useState<string>('')
   const [availableQuantity, setAvailableQuantity] = useState<string>('')
   const [safetyStock, setSafetyStock] = useState<string>('')
   const [isActive, setIsActive] = useState(true)
+  const [flashSale, setFlashSale] = useState(false)
   const [alertEmail, setAlertEmail] = useState<string>('')
   const [notes, setNotes] = useState<string>('')
 
@@ -60,6 +76,7 @@ export default function InventoryDetailPage({ params }: { params: Promise<{ id:
       setAvailableQuantity(inventory.available_quantity.toString())
       setSafetyStock(inventory.safety_stock.toString())
       setIsActive(inventory.is_active)
+      setFlashSale(Boolean(inventory.flash_sale))
       setAlertEmail(inventory.alert_email || '')
       setNotes(inventory.notes || '')
     }
@@ -71,6 +88,7 @@ export default function InventoryDetailPage({ params }: { params: Promise<{ id:
     onSuccess: () => {
       toast.success(t.admin.invUpdateSuccess)
       queryClient.invalidateQueries({ queryKey: ['inventory', id] })
+      queryClient.invalidateQueries({ queryKey: ['inventoryFlashSale', id] })
     },
     onError: (error: unknown) => {
       toast.error(resolveApiErrorMessage(error, t, t.admin.invUpdateFailed))
@@ -119,8 +137,10 @@ export default function InventoryDetailPage({ params }: { params: Promise<{ id:
       available_quantity: availableNum,
       safety_stock: parseInt(safetyStock) || 0,
       is_active: isActive,
+      flash_sale: flashSale,
       alert_email: alertEmail || undefined,
       notes: notes || undefined,
+      version: inventory?.version,
     })
   }
 
@@ -395,6 +415,23 @@ export default function InventoryDetailPage({ params }: { params: Promise<{ id:
                   <Label htmlFor="active">{t.admin.invEnableConfig}</Label>
                 </div>
 
+                <div className="space-y-1">
+                  <div className="flex items-center space-x-2">
+                    <Switch id="flash-sale" checked={flashSale} onCheckedChange={setFlashSale} />
+                    <Label htmlFor="flash-sale">{t.admin.invFlashSale}</Label>
+                  </div>
+                  <p className="text-xs text-muted-foreground">{t.admin.invFlashSaleDesc}</p>
+                  {inventory.flash_sale && flashSaleStatus && (
+                    <p className="text-xs text-muted-foreground">
+                      {flashSaleStatus.active
+                        ? t.admin.invFlashSaleStatus
+                            .replace('{remaining}', String(flashSaleStatus.remaining ?? '-'))
+                            .replace('{pending}', String(flashSaleStatus.pending))
+                        : t.admin.invFlashSaleInactive}
+                    </p>
+                  )}
+                </div>
+
                 <Button type="submit" disabled={updateMutation.isPending}>
                   <Save className="mr-2 h-4 w-4" />
                   {updateMutation.isPending ? t.admin.invSaving : t.admin.invSaveChanges}
@@ -2,7 +2,7 @@
 
 import { useState, useRef, useEffect } from 'react'
 import { useParams, useRouter } from 'next/navigation'
-import { useQuery, useMutation } from '@tanstack/react-query'
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import {
   getVirtualInventory,
   updateVirtualInventory,
@@ -12,7 +12,11 @@ import {
   createVirtualInventoryStockManually,
   reserveVirtualInventoryStock,
   releaseVirtualInventoryStock,
-  testDeliveryScript
+  revealVirtualInventoryStock,
+  streamDryRunDeliveryScript,
+  type ScriptDryRunEvent,
+  type ScriptDryRunRequest,
+  type VirtualStockImportResult,
 } from '@/lib/api'
 import { Card, CardContent, CardHeader, CardTitle, CardDescription } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
@@ -61,7 +65,7 @@ import {
   AlertDialogTitle,
   AlertDialogTrigger,
 } from '@/components/ui/alert-dialog'
-import { ArrowLeft, Save, Plus, Trash2, RefreshCw, Database, FileText, Upload, Loader2, Lock, Unlock, Code2, Play, BookOpen } from 'lucide-react'
+import { ArrowLeft, Save, Plus, Trash2, RefreshCw, Database, FileText, Upload, Loader2, Lock, Unlock, Code2, Play, BookOpen, Eye, EyeOff } from 'lucide-react'
 import Link from 'next/link'
 import { useToast } from '@/hooks/use-toast'
 import {
@@ -76,7 +80,11 @@ import { usePageTitle } from '@/hooks/use-page-title'
 import { useTheme } from '@/contexts/theme-context'
 import { ConfigEditor } from '@/components/admin/config-editor'
 import { resolveApiErrorMessage } from '@/lib/api-error'
+import { majorToMinor, minorToMajor } from '@/lib/utils'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { ScriptRevisionsCard } from '@/components/admin/script-revisions-card'
+import { ScriptExecutionsCard } from '@/components/admin/script-executions-card'
+import { RestockPolicyCard } from '@/components/admin/restock-policy-card'
 import { LazyCodeEditor } from '@/components/ui/lazy-code-editor'
 
 // Example delivery scripts
@@ -560,6 +568,7 @@ export default function VirtualInventoryEditPage() {
   const params = useParams()
   const router = useRouter()
   const toast = useToast()
+  const queryClient = useQueryClient()
   const fileInputRef = useRef<HTMLInputElement>(null)
   const inventoryId = Number(params.id)
   const { locale } = useLocale()
@@ -575,8 +584,12 @@ export default function VirtualInventoryEditPage() {
   const [page, setPage] = useState(1)
   const [limit] = useState(20)
   const [statusFilter, setStatusFilter] = useState<string>('all')
+  const [revealedContents, setRevealedContents] = useState<Record<number, string>>({})
   const [testQuantity, setTestQuantity] = useState(1)
   const [testResult, setTestResult] = useState<any>(null)
+  const [testEvents, setTestEvents] = useState<ScriptDryRunEvent[]>([])
+  const [testHttpMode, setTestHttpMode] = useState<'live' | 'mock'>('live')
+  const [testHttpMocks, setTestHttpMocks] = useState('')
   const configFlushRef = useRef<(() => string | null) | null>(null)
 
   const [editForm, setEditForm] = useState({
@@ -588,6 +601,12 @@ export default function VirtualInventoryEditPage() {
     description: '',
     total_limit: 0,
     allow_inline_iframe: false,
+    require_reauth: false,
+    max_activations: 0,
+    low_stock_threshold: 0,
+    usage_unit: '',
+    usage_quota: 0,
+    overage_unit_price: '',
     is_active: true,
     notes: ''
   })
@@ -619,6 +638,12 @@ export default function VirtualInventoryEditPage() {
       description: inv.description || '',
       total_limit: inv.total_limit || 0,
       allow_inline_iframe: !!inv.allow_inline_iframe,
+      require_reauth: !!inv.require_reauth,
+      max_activations: inv.max_activations || 0,
+      low_stock_threshold: inv.low_stock_threshold || 0,
+      usage_unit: inv.usage_unit || '',
+      usage_quota: inv.usage_quota || 0,
+      overage_unit_price: inv.overage_unit_price_minor ? String(minorToMajor(inv.overage_unit_price_minor)) : '',
       is_active: inv.is_active ?? true,
       notes: inv.notes || ''
     })
@@ -636,9 +661,19 @@ export default function VirtualInventoryEditPage() {
   })
 
   const updateMutation = useMutation({
-    mutationFn: (data: typeof editForm) => updateVirtualInventory(inventoryId, data),
-    onSuccess: () => {
-      toast.success(t.admin.saveSuccess)
+    mutationFn: ({ overage_unit_price, ...data }: typeof editForm) =>
+      updateVirtualInventory(inventoryId, {
+        ...data,
+        overage_unit_price_minor: majorToMinor(overage_unit_price || 0),
+        version: inventoryData?.data?.version,
+      }),
+    onSuccess: (res: any) => {
+      if (res?.data?.script_revision) {
+        toast.success(t.admin.scriptRevisionSubmitted)
+        queryClient.invalidateQueries({ queryKey: ['virtualInventoryScriptRevisions', inventoryId] })
+      } else {
+        toast.success(t.admin.saveSuccess)
+      }
       refetchInventory()
     },
     onError: (error: unknown) => {
@@ -650,7 +685,18 @@ export default function VirtualInventoryEditPage() {
     mutationFn: (data: { import_type: 'file' | 'text'; file?: File; content?: string }) =>
       importVirtualInventoryStock(inventoryId, data),
     onSuccess: (response: any) => {
-      toast.success(t.admin.importSuccessCount.replace('{count}', String(response?.data?.count || 0)))
+      const result: VirtualStockImportResult | undefined = response?.data
+      if (result && result.duplicates + result.invalid > 0) {
+        toast.success(
+          t.admin.importSummary
+            .replace('{added}', String(result.added))
+            .replace('{duplicates}', String(result.duplicates))
+            .replace('{invalid}', String(result.invalid))
+            .replace('{line}', String(result.issues[0]?.line ?? '-'))
+        )
+      } else {
+        toast.success(t.admin.importSuccessCount.replace('{count}', String(result?.count || 0)))
+      }
       setImportDialogOpen(false)
       setTextContent('')
       setSelectedFile(null)
@@ -714,12 +760,45 @@ export default function VirtualInventoryEditPage() {
     },
   })
 
+  // 列表只返回打码内容，明文需逐条 reveal（服务端记录审计日志）
+  const revealMutation = useMutation({
+    mutationFn: (stockId: number) => revealVirtualInventoryStock(inventoryId, stockId),
+    onSuccess: (response: any, stockId) => {
+      setRevealedContents((prev) => ({ ...prev, [stockId]: response?.data?.content ?? '' }))
+    },
+    onError: (error: unknown) => {
+      toast.error(formatActionError(error, t.admin.revealContentFailed))
+    },
+  })
+
+  const toggleStockContent = (stockId: number) => {
+    if (revealedContents[stockId] !== undefined) {
+      setRevealedContents((prev) => {
+        const next = { ...prev }
+        delete next[stockId]
+        return next
+      })
+      return
+    }
+    revealMutation.mutate(stockId)
+  }
+
   const testMutation = useMutation({
-    mutationFn: ({ script, config, quantity }: { script: string; config: Record<string, any>; quantity: number }) =>
-      testDeliveryScript(script, config, quantity),
-    onSuccess: (data: any) => {
-      setTestResult(data?.data)
-      toast.success(t.admin.scriptTestSuccess)
+    mutationFn: (data: ScriptDryRunRequest) => {
+      setTestEvents([])
+      setTestResult(null)
+      return streamDryRunDeliveryScript(inventoryId, data, {
+        locale,
+        onEvent: (event) => setTestEvents((prev) => [...prev, event]),
+      })
+    },
+    onSuccess: (result) => {
+      setTestResult(result)
+      if (result.success) {
+        toast.success(t.admin.scriptTestSuccess)
+      } else {
+        toast.error(t.admin.scriptTestFailed)
+      }
     },
     onError: (error: unknown) => {
       const message = formatActionError(error, t.admin.scriptTestFailed)
@@ -753,6 +832,10 @@ export default function VirtualInventoryEditPage() {
       is_active: editForm.is_active,
       total_limit: Number(editForm.total_limit || 0),
       allow_inline_iframe: Boolean(editForm.allow_inline_iframe),
+      require_reauth: Boolean(editForm.require_reauth),
+      max_activations: Number(editForm.max_activations || 0),
+      low_stock_threshold: Number(editForm.low_stock_threshold || 0),
+      usage_quota: Number(editForm.usage_quota || 0),
       description_length: editForm.description.length,
       notes_length: editForm.notes.length,
       script_length: editForm.script.length,
@@ -842,7 +925,22 @@ export default function VirtualInventoryEditPage() {
     } catch {
       // use empty config
     }
-    testMutation.mutate({ script: editForm.script, config, quantity: testQuantity })
+    let httpMocks: ScriptDryRunRequest['http_mocks'] = []
+    if (testHttpMode === 'mock' && testHttpMocks.trim()) {
+      try {
+        httpMocks = JSON.parse(testHttpMocks)
+      } catch {
+        toast.error(t.admin.scriptDryRunInvalidMocks)
+        return
+      }
+    }
+    testMutation.mutate({
+      script: editForm.script,
+      config,
+      quantity: testQuantity,
+      http_mode: testHttpMode,
+      http_mocks: httpMocks,
+    })
   }
 
   const getStatusBadge = (status: string) => {
@@ -851,6 +949,8 @@ export default function VirtualInventoryEditPage() {
         return <Badge variant="default">{t.admin.statusAvailable}</Badge>
       case 'reserved':
         return <Badge variant="secondary">{t.admin.statusReserved}</Badge>
+      case 'held':
+        return <Badge variant="secondary">{t.admin.statusHeld}</Badge>
       case 'sold':
         return <Badge variant="outline">{t.admin.statusSold}</Badge>
       case 'invalid':
@@ -1039,6 +1139,82 @@ export default function VirtualInventoryEditPage() {
               />
             </div>
           )}
+          <div className="flex items-center justify-between rounded-lg border border-border/70 bg-muted/20 px-3 py-3">
+            <div className="pr-4">
+              <Label>{t.admin.requireRevealReauth}</Label>
+              <p className="text-xs text-muted-foreground">{t.admin.requireRevealReauthHint}</p>
+            </div>
+            <Switch
+              checked={editForm.require_reauth}
+              onCheckedChange={(checked) => setEditForm({ ...editForm, require_reauth: checked })}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="max_activations">{t.admin.maxActivations}</Label>
+            <Input
+              id="max_activations"
+              type="number"
+              min={0}
+              placeholder="0"
+              value={editForm.max_activations}
+              onChange={(e) =>
+                setEditForm({
+                  ...editForm,
+                  max_activations: Math.max(0, parseInt(e.target.value) || 0),
+                })
+              }
+            />
+            <p className="text-xs text-muted-foreground">{t.admin.maxActivationsHint}</p>
+          </div>
+          {editForm.type === 'static' && (
+            <div className="space-y-2">
+              <Label htmlFor="low_stock_threshold">{t.admin.lowStockThreshold}</Label>
+              <Input
+                id="low_stock_threshold"
+                type="number"
+                min={0}
+                placeholder="0"
+                value={editForm.low_stock_threshold}
+                onChange={(e) =>
+                  setEditForm({
+                    ...editForm,
+                    low_stock_threshold: Math.max(0, parseInt(e.target.value) || 0),
+                  })
+                }
+              />
+              <p className="text-xs text-muted-foreground">{t.admin.lowStockThresholdHint}</p>
+            </div>
+          )}
+          <div className="space-y-2">
+            <Label>{t.admin.usageMetering}</Label>
+            <div className="grid gap-2 sm:grid-cols-3">
+              <Input
+                id="usage_quota"
+                type="number"
+                min={0}
+                placeholder={t.admin.usageQuota}
+                value={editForm.usage_quota || ''}
+                onChange={(e) => setEditForm({ ...editForm, usage_quota: Math.max(0, parseInt(e.target.value) || 0) })}
+              />
+              <Input
+                id="usage_unit"
+                maxLength={32}
+                placeholder={t.admin.usageUnitPlaceholder}
+                value={editForm.usage_unit}
+                onChange={(e) => setEditForm({ ...editForm, usage_unit: e.target.value })}
+              />
+              <Input
+                id="overage_unit_price"
+                type="number"
+                min={0}
+                step="0.01"
+                placeholder={t.admin.overageUnitPrice}
+                value={editForm.overage_unit_price}
+                onChange={(e) => setEditForm({ ...editForm, overage_unit_price: e.target.value })}
+              />
+            </div>
+            <p className="text-xs text-muted-foreground">{t.admin.usageMeteringHint}</p>
+          </div>
           <div className="space-y-2">
             <Label htmlFor="description">{t.admin.descriptionLabel}</Label>
             <Textarea
@@ -1074,6 +1250,12 @@ export default function VirtualInventoryEditPage() {
         </CardContent>
       </Card>
 
+      <ScriptRevisionsCard inventoryId={inventoryId} onReviewed={refetchInventory} />
+
+      <ScriptExecutionsCard inventoryId={inventoryId} />
+
+      {inventory.type !== 'script' && <RestockPolicyCard inventoryId={inventoryId} />}
+
       {/* Script editing section (only for script type) */}
       {editForm.type === 'script' && (
         <>
@@ -1185,6 +1367,18 @@ export default function VirtualInventoryEditPage() {
                   onChange={(e) => setTestQuantity(Math.max(1, Math.min(10, parseInt(e.target.value) || 1)))}
                   className="w-20"
                 />
+                <Select
+                  value={testHttpMode}
+                  onValueChange={(value) => setTestHttpMode(value as 'live' | 'mock')}
+                >
+                  <SelectTrigger className="w-40">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="live">{t.admin.scriptDryRunHttpLive}</SelectItem>
+                    <SelectItem value="mock">{t.admin.scriptDryRunHttpMock}</SelectItem>
+                  </SelectContent>
+                </Select>
                 <Button variant="outline" onClick={handleTest} disabled={testMutation.isPending}>
                   <Play className="h-4 w-4 mr-2" />
                   {testMutation.isPending ? t.admin.scriptTesting : t.admin.scriptTestBtn}
@@ -1195,9 +1389,65 @@ export default function VirtualInventoryEditPage() {
                 {updateMutation.isPending ? t.admin.savingText : t.common.save}
               </Button>
             </div>
+            {testHttpMode === 'mock' && (
+              <div className="space-y-2">
+                <Label>{t.admin.scriptDryRunMocksLabel}</Label>
+                <p className="text-xs text-muted-foreground">{t.admin.scriptDryRunMocksDesc}</p>
+                <Textarea
+                  value={testHttpMocks}
+                  onChange={(e) => setTestHttpMocks(e.target.value)}
+                  placeholder={'[{"method": "POST", "url": "https://api.example.com/*", "status": 200, "body": {"codes": ["ABC"]}}]'}
+                  className="font-mono text-xs"
+                  rows={4}
+                />
+              </div>
+            )}
           </CardContent>
         </Card>
 
+        {/* Dry-run Logs */}
+        {(testEvents.length > 0 || testMutation.isPending) && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-sm">{t.admin.scriptDryRunLogs}</CardTitle>
+            </CardHeader>
+            <CardContent>
+              <div className="max-h-72 overflow-auto rounded-md bg-muted p-3 font-mono text-xs space-y-1">
+                {testEvents.length === 0 && (
+                  <p className="text-muted-foreground">{t.admin.scriptDryRunWaiting}</p>
+                )}
+                {testEvents.map((event, i) => (
+                  <div key={i} className="break-all">
+                    <span className="text-muted-foreground">
+                      {new Date(event.time).toLocaleTimeString()}{' '}
+                    </span>
+                    {event.type === 'http' ? (
+                      <span className={event.error ? 'text-destructive' : undefined}>
+                        {event.method} {event.url} → {event.status || '-'} ({event.duration_ms ?? 0}ms)
+                        {event.mocked ? ` [${t.admin.scriptDryRunMocked}]` : ''}
+                        {event.error ? ` ${event.error}` : ''}
+                      </span>
+                    ) : event.type === 'storage' ? (
+                      <span className={event.error ? 'text-destructive' : undefined}>
+                        storage.{event.op}
+                        {event.key ? `("${event.key}")` : '()'}
+                        {event.ttl_seconds ? ` ttl=${event.ttl_seconds}s` : ''}
+                        {` [${t.admin.scriptDryRunStorageNotSaved}]`}
+                        {event.error ? ` ${event.error}` : ''}
+                      </span>
+                    ) : (
+                      <span>{event.message}</span>
+                    )}
+                  </div>
+                ))}
+                {testResult?.events_truncated && (
+                  <p className="text-muted-foreground">{t.admin.scriptDryRunTruncated}</p>
+                )}
+              </div>
+            </CardContent>
+          </Card>
+        )}
+
         {/* Test Result */}
         {testResult && (
           <Card>
@@ -1274,6 +1524,17 @@ export default function VirtualInventoryEditPage() {
               <p className="font-semibold mb-1">AuraLogic.http <span className="font-normal text-muted-foreground">({t.admin.scriptHttpApi})</span></p>
               <p><code>get(url, headers?)</code> / <code>post(url, body, headers?)</code></p>
             </div>
+            <div>
+              <p className="font-semibold mb-1">AuraLogic.storage <span className="font-normal text-muted-foreground">({t.admin.scriptStorageApi})</span></p>
+              <p><code>get(key)</code> / <code>set(key, value, {'{ttl: seconds}'}?)</code> / <code>del(key)</code> / <code>list()</code> / <code>clear()</code></p>
+              <p className="text-muted-foreground">{t.admin.scriptStorageApiDesc}</p>
+            </div>
+            <div>
+              <p className="font-semibold mb-1">AuraLogic.crypto <span className="font-normal text-muted-foreground">({t.admin.scriptCryptoApi})</span></p>
+              <p><code>md5/sha1/sha256/sha512(data, encoding?)</code> / <code>hmac(algorithm, key, data, {'{encoding, keyEncoding}'}?)</code></p>
+              <p><code>base64Encode/base64Decode</code> / <code>base64UrlEncode/base64UrlDecode</code> / <code>hexEncode/hexDecode</code> / <code>timingSafeEqual(a, b)</code></p>
+              <p className="text-muted-foreground">{t.admin.scriptCryptoApiDesc}</p>
+            </div>
           </CardContent>
         </Card>
         </>
@@ -1339,8 +1600,30 @@ export default function VirtualInventoryEditPage() {
                   {stocks.map((stock: any) => (
                     <TableRow key={stock.id}>
                       <TableCell className="font-mono">{stock.id}</TableCell>
-                      <TableCell className="font-mono max-w-xs truncate" title={stock.content}>
-                        {stock.content}
+                      <TableCell className="font-mono max-w-xs">
+                        <div className="flex items-center gap-1">
+                          <span className="truncate" title={revealedContents[stock.id]}>
+                            {revealedContents[stock.id] ?? stock.content}
+                          </span>
+                          <Button
+                            size="sm"
+                            variant="ghost"
+                            className="h-6 w-6 shrink-0 p-0"
+                            onClick={() => toggleStockContent(stock.id)}
+                            disabled={revealMutation.isPending}
+                            title={
+                              revealedContents[stock.id] !== undefined
+                                ? t.admin.hideContent
+                                : t.admin.revealContent
+                            }
+                          >
+                            {revealedContents[stock.id] !== undefined ? (
+                              <EyeOff className="h-3 w-3" />
+                            ) : (
+                              <Eye className="h-3 w-3" />
+                            )}
+                          </Button>
+                        </div>
                       </TableCell>
                       <TableCell className="text-sm text-muted-foreground max-w-xs truncate">
                         {stock.remark || '-'}
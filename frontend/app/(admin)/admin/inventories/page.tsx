@@ -14,6 +14,7 @@ import {
   updateVirtualInventory,
   importVirtualInventoryStock,
   createVirtualInventoryStockManually,
+  type VirtualStockImportResult,
 } from '@/lib/api'
 import { Card, CardContent, CardHeader, CardTitle, CardDescription } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
@@ -57,6 +58,7 @@ import {
   FileText,
   Upload,
   Code2,
+  Zap,
 } from 'lucide-react'
 import Link from 'next/link'
 import { useToast } from '@/hooks/use-toast'
@@ -75,6 +77,12 @@ import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { resolveApiErrorMessage } from '@/lib/api-error'
+import { majorToMinor } from '@/lib/utils'
+import { SupplierHealthPanel } from '@/components/admin/supplier-health-panel'
+import { ResaleCheckPanel } from '@/components/admin/resale-check-panel'
+import { StockReconciliationPanel } from '@/components/admin/stock-reconciliation-panel'
+import { InventorySnapshotPanel } from '@/components/admin/inventory-snapshot-panel'
+import { LowStockAlertsPanel } from '@/components/admin/low-stock-alerts-panel'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 
 export default function InventoriesPage() {
@@ -122,6 +130,12 @@ function InventoriesContent() {
     description: '',
     total_limit: 0,
     allow_inline_iframe: false,
+    require_reauth: false,
+    max_activations: 0,
+    low_stock_threshold: 0,
+    usage_unit: '',
+    usage_quota: 0,
+    overage_unit_price: '',
     is_active: true,
     notes: '',
   })
@@ -178,9 +192,15 @@ function InventoriesContent() {
 
   // 创建虚拟库存
   const createVirtualMutation = useMutation({
-    mutationFn: createVirtualInventory,
-    onSuccess: () => {
-      toast.success(t.admin.virtualCreated)
+    mutationFn: ({ overage_unit_price, ...data }: typeof newVirtualInventory) =>
+      createVirtualInventory({
+        ...data,
+        overage_unit_price_minor: majorToMinor(overage_unit_price || 0),
+      }),
+    onSuccess: (res: any) => {
+      toast.success(
+        res?.data?.script_revision ? t.admin.scriptRevisionSubmitted : t.admin.virtualCreated
+      )
       setCreateDialogOpen(false)
       setNewVirtualInventory({
         name: '',
@@ -191,6 +211,12 @@ function InventoriesContent() {
         description: '',
         total_limit: 0,
         allow_inline_iframe: false,
+        require_reauth: false,
+        max_activations: 0,
+        low_stock_threshold: 0,
+        usage_unit: '',
+        usage_quota: 0,
+        overage_unit_price: '',
         is_active: true,
         notes: '',
       })
@@ -210,7 +236,18 @@ function InventoriesContent() {
       content?: string
     }) => importVirtualInventoryStock(data.virtualInventoryId, data),
     onSuccess: (response: any) => {
-      toast.success(t.admin.importSuccess.replace('{count}', String(response?.data?.count || 0)))
+      const result: VirtualStockImportResult | undefined = response?.data
+      if (result && result.duplicates + result.invalid > 0) {
+        toast.success(
+          t.admin.importSummary
+            .replace('{added}', String(result.added))
+            .replace('{duplicates}', String(result.duplicates))
+            .replace('{invalid}', String(result.invalid))
+            .replace('{line}', String(result.issues[0]?.line ?? '-'))
+        )
+      } else {
+        toast.success(t.admin.importSuccess.replace('{count}', String(result?.count || 0)))
+      }
       setImportDialogOpen(false)
       setTextContent('')
       setSelectedFile(null)
@@ -271,6 +308,7 @@ function InventoriesContent() {
         available_quantity: inventory.available_quantity,
         safety_stock: inventory.safety_stock,
         is_active: !inventory.is_active,
+        version: inventory.version,
       }),
     onSuccess: () => {
       toast.success(t.admin.updateSuccess)
@@ -283,7 +321,8 @@ function InventoriesContent() {
 
   // 切换虚拟库存启用状态
   const toggleVirtualMutation = useMutation({
-    mutationFn: (vi: any) => updateVirtualInventory(vi.id, { is_active: !vi.is_active }),
+    mutationFn: (vi: any) =>
+      updateVirtualInventory(vi.id, { is_active: !vi.is_active, version: vi.version }),
     onSuccess: () => {
       toast.success(t.admin.updateSuccess)
       refetchVirtual()
@@ -619,6 +658,11 @@ function InventoriesContent() {
                                   {t.admin.disabled}
                                 </Badge>
                               )}
+                              {inventory.flash_sale && (
+                                <Badge variant="outline" title={t.admin.invFlashSale}>
+                                  <Zap className="h-3.5 w-3.5" />
+                                </Badge>
+                              )}
                               {isLowStock(inventory) && (
                                 <Badge variant="destructive" className="h-5 w-5 justify-center p-0">
                                   <AlertTriangle className="h-3.5 w-3.5" />
@@ -726,6 +770,12 @@ function InventoriesContent() {
               )}
             </CardContent>
           </Card>
+
+          <LowStockAlertsPanel />
+
+          <StockReconciliationPanel />
+
+          <InventorySnapshotPanel />
         </TabsContent>
 
         {/* 虚拟库存标签内容 */}
@@ -886,6 +936,11 @@ function InventoriesContent() {
                                 {t.admin.disabled}
                               </Badge>
                             )}
+                            {vi.supplier_paused_at && (
+                              <Badge variant="destructive" className="ml-1">
+                                {t.admin.supplierHealthPaused}
+                              </Badge>
+                            )}
                           </TableCell>
                           <TableCell>
                             <div className="flex items-center gap-2">
@@ -1002,6 +1057,10 @@ function InventoriesContent() {
               )}
             </CardContent>
           </Card>
+
+          <SupplierHealthPanel />
+
+          <ResaleCheckPanel />
         </TabsContent>
       </Tabs>
 
@@ -1020,6 +1079,12 @@ function InventoriesContent() {
               description: '',
               total_limit: 0,
               allow_inline_iframe: false,
+              require_reauth: false,
+              max_activations: 0,
+              low_stock_threshold: 0,
+              usage_unit: '',
+              usage_quota: 0,
+              overage_unit_price: '',
               is_active: true,
               notes: '',
             })
@@ -1149,6 +1214,100 @@ function InventoriesContent() {
               </div>
             )}
 
+            <div className="flex items-center justify-between rounded-lg border border-border/70 bg-muted/20 px-3 py-2">
+              <div className="pr-4">
+                <Label>{t.admin.requireRevealReauth}</Label>
+                <p className="text-xs text-muted-foreground">{t.admin.requireRevealReauthHint}</p>
+              </div>
+              <Switch
+                checked={newVirtualInventory.require_reauth}
+                onCheckedChange={(checked) =>
+                  setNewVirtualInventory({ ...newVirtualInventory, require_reauth: checked })
+                }
+              />
+            </div>
+
+            <div className="space-y-2">
+              <Label htmlFor="max_activations">{t.admin.maxActivations}</Label>
+              <Input
+                id="max_activations"
+                type="number"
+                min={0}
+                placeholder="0"
+                value={newVirtualInventory.max_activations}
+                onChange={(e) =>
+                  setNewVirtualInventory({
+                    ...newVirtualInventory,
+                    max_activations: Math.max(0, parseInt(e.target.value) || 0),
+                  })
+                }
+              />
+              <p className="text-xs text-muted-foreground">{t.admin.maxActivationsHint}</p>
+            </div>
+
+            {newVirtualInventory.type === 'static' && (
+              <div className="space-y-2">
+                <Label htmlFor="low_stock_threshold">{t.admin.lowStockThreshold}</Label>
+                <Input
+                  id="low_stock_threshold"
+                  type="number"
+                  min={0}
+                  placeholder="0"
+                  value={newVirtualInventory.low_stock_threshold}
+                  onChange={(e) =>
+                    setNewVirtualInventory({
+                      ...newVirtualInventory,
+                      low_stock_threshold: Math.max(0, parseInt(e.target.value) || 0),
+                    })
+                  }
+                />
+                <p className="text-xs text-muted-foreground">{t.admin.lowStockThresholdHint}</p>
+              </div>
+            )}
+
+            <div className="space-y-2">
+              <Label>{t.admin.usageMetering}</Label>
+              <div className="grid gap-2 sm:grid-cols-3">
+                <Input
+                  id="usage_quota"
+                  type="number"
+                  min={0}
+                  placeholder={t.admin.usageQuota}
+                  value={newVirtualInventory.usage_quota || ''}
+                  onChange={(e) =>
+                    setNewVirtualInventory({
+                      ...newVirtualInventory,
+                      usage_quota: Math.max(0, parseInt(e.target.value) || 0),
+                    })
+                  }
+                />
+                <Input
+                  id="usage_unit"
+                  maxLength={32}
+                  placeholder={t.admin.usageUnitPlaceholder}
+                  value={newVirtualInventory.usage_unit}
+                  onChange={(e) =>
+                    setNewVirtualInventory({ ...newVirtualInventory, usage_unit: e.target.value })
+                  }
+                />
+                <Input
+                  id="overage_unit_price"
+                  type="number"
+                  min={0}
+                  step="0.01"
+                  placeholder={t.admin.overageUnitPrice}
+                  value={newVirtualInventory.overage_unit_price}
+                  onChange={(e) =>
+                    setNewVirtualInventory({
+                      ...newVirtualInventory,
+                      overage_unit_price: e.target.value,
+                    })
+                  }
+                />
+              </div>
+              <p className="text-xs text-muted-foreground">{t.admin.usageMeteringHint}</p>
+            </div>
+
             <div className="space-y-2">
               <Label htmlFor="notes">{t.admin.notesOptional}</Label>
               <Textarea
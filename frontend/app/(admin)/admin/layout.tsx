@@ -8,6 +8,7 @@ import { getTranslations } from '@/lib/i18n'
 import { usePreventScrollLock } from '@/hooks/use-prevent-scroll-lock'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 import { Sidebar } from '@/components/layout/sidebar'
+import { SiteBanners } from '@/components/site-banner'
 import { isAuthenticated as hasClientSession } from '@/lib/auth'
 
 function AdminSidebarFallback() {
@@ -27,6 +28,7 @@ function AdminLayoutFrame({
         <Sidebar />
       </Suspense>
       <main className="flex-1 overflow-y-auto bg-background p-8">
+        <SiteBanners className="mb-6" />
         {slotContext ? (
           <Suspense fallback={null}>
             <PluginSlot slot="admin.layout.content.top" context={slotContext} />
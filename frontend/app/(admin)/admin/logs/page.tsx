@@ -10,6 +10,7 @@ import {
   getLogStatistics,
   retryFailedEmails,
   getInventoryLogs,
+  getAdminJobs,
 } from '@/lib/api'
 import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
 import { DataTable } from '@/components/admin/data-table'
@@ -27,7 +28,7 @@ import {
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
 import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
 import { useToast } from '@/hooks/use-toast'
-import { Download, FileText, Mail, RefreshCw, Package, Smartphone } from 'lucide-react'
+import { Clock, Download, FileText, Mail, RefreshCw, Package, Smartphone } from 'lucide-react'
 import { formatDate } from '@/lib/utils'
 import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
@@ -49,6 +50,7 @@ const operationResourceOrder = [
   'plugin',
   'system',
   'system_config',
+  'admin_audit',
 ] as const
 
 const operationActionsByResource: Record<string, string[]> = {
@@ -117,8 +119,11 @@ const operationActionsByResource: Record<string, string[]> = {
     'ticket_auto_close_start',
     'ticket_auto_close_stop',
     'ticket_auto_close',
+    'job_scheduler_start',
+    'job_scheduler_stop',
   ],
   system_config: ['update'],
+  admin_audit: ['create', 'update', 'delete'],
 }
 
 function uniqueStrings(values: string[]): string[] {
@@ -175,6 +180,7 @@ export default function LogsPage() {
     status: '',
     event_type: '',
     to_email: '',
+    campaign: '',
     start_date: '',
     end_date: '',
   })
@@ -243,6 +249,14 @@ export default function LogsPage() {
     queryFn: () => getSmsLogs({ ...smsFilters, page: smsPage, limit: 20 }),
   })
 
+  // 周期任务状态查询，仅在任务标签页轮询
+  const { data: jobs, isLoading: jobsLoading } = useQuery({
+    queryKey: ['adminJobs'],
+    queryFn: getAdminJobs,
+    enabled: activeTab === 'jobs',
+    refetchInterval: activeTab === 'jobs' ? 30000 : false,
+  })
+
   // 统计信息查询
   const { data: statistics } = useQuery({
     queryKey: ['logStatistics'],
@@ -271,7 +285,9 @@ export default function LogsPage() {
           ? t.admin.inventoryLogs
           : activeTab === 'sms'
             ? t.admin.smsLogs
-            : t.admin.systemLogs
+            : activeTab === 'jobs'
+              ? t.admin.scheduledJobs
+              : t.admin.systemLogs
   const handleExport = useCallback(() => {
     let path = '/api/admin/logs/operations/export'
     let fileName = `operation_logs_${new Date().toISOString().slice(0, 10)}.xlsx`
@@ -284,6 +300,7 @@ export default function LogsPage() {
         if (emailFilters.status) params.append('status', emailFilters.status)
         if (emailFilters.event_type) params.append('event_type', emailFilters.event_type)
         if (emailFilters.to_email) params.append('to_email', emailFilters.to_email)
+        if (emailFilters.campaign) params.append('campaign', emailFilters.campaign)
         if (emailFilters.start_date) params.append('start_date', emailFilters.start_date)
         if (emailFilters.end_date) params.append('end_date', emailFilters.end_date)
         break
@@ -369,6 +386,7 @@ export default function LogsPage() {
             emailFilters.status,
             emailFilters.event_type,
             emailFilters.to_email,
+            emailFilters.campaign,
             emailFilters.start_date,
             emailFilters.end_date,
           ]
@@ -381,13 +399,15 @@ export default function LogsPage() {
               inventoryFilters.start_date,
               inventoryFilters.end_date,
             ]
-          : [
-              smsFilters.status,
-              smsFilters.event_type,
-              smsFilters.phone,
-              smsFilters.start_date,
-              smsFilters.end_date,
-            ]
+          : activeTab === 'jobs'
+            ? []
+            : [
+                smsFilters.status,
+                smsFilters.event_type,
+                smsFilters.phone,
+                smsFilters.start_date,
+                smsFilters.end_date,
+              ]
   ).filter(Boolean)
   const resolveOperationResourceLabel = useCallback(
     (resourceType: string) => {
@@ -418,6 +438,8 @@ export default function LogsPage() {
           return t.admin.system
         case 'system_config':
           return t.admin.logResourceSystemConfig
+        case 'admin_audit':
+          return t.admin.logResourceAdminAudit
         default:
           return locale === 'zh' ? String(resourceType || '') : humanizeLogToken(resourceType)
       }
@@ -531,6 +553,14 @@ export default function LogsPage() {
           return t.admin.logActionTicketAutoCloseStop
         case 'ticket_auto_close':
           return t.admin.logActionTicketAutoClose
+        case 'job_scheduler_start':
+          return t.admin.logActionJobSchedulerStart
+        case 'job_scheduler_stop':
+          return t.admin.logActionJobSchedulerStop
+        case 'restore':
+          return t.admin.logActionRestore
+        case 'trash_purge':
+          return t.admin.logActionTrashPurge
         default:
           return locale === 'zh' ? String(action || '') : humanizeLogToken(action)
       }
@@ -754,7 +784,12 @@ export default function LogsPage() {
       accessorKey: 'event_type',
       cell: ({ row }: { row: { original: any } }) =>
         row.original.event_type ? (
-          <Badge variant="secondary">{row.original.event_type}</Badge>
+          <div className="flex flex-col items-start gap-1">
+            <Badge variant="secondary">{row.original.event_type}</Badge>
+            {row.original.campaign && (
+              <span className="text-xs text-muted-foreground">{row.original.campaign}</span>
+            )}
+          </div>
         ) : (
           <span className="text-muted-foreground">-</span>
         ),
@@ -810,10 +845,12 @@ export default function LogsPage() {
           </p>
         </div>
         <div className="flex gap-2">
-          <Button variant="outline" onClick={handleExport}>
-            <Download className="mr-2 h-4 w-4" />
-            {t.admin.exportCurrentLogs}
-          </Button>
+          {activeTab !== 'jobs' && (
+            <Button variant="outline" onClick={handleExport}>
+              <Download className="mr-2 h-4 w-4" />
+              {t.admin.exportCurrentLogs}
+            </Button>
+          )}
         </div>
       </div>
 
@@ -918,6 +955,10 @@ export default function LogsPage() {
             <Smartphone className="mr-2 h-4 w-4" />
             {t.admin.smsLogs}
           </TabsTrigger>
+          <TabsTrigger value="jobs">
+            <Clock className="mr-2 h-4 w-4" />
+            {t.admin.scheduledJobs}
+          </TabsTrigger>
         </TabsList>
 
         <TabsContent value="operations" className="space-y-4">
@@ -1085,7 +1126,7 @@ export default function LogsPage() {
               <CardTitle className="text-base">{t.admin.filterConditions}</CardTitle>
             </CardHeader>
             <CardContent>
-              <div className="grid gap-4 md:grid-cols-5">
+              <div className="grid gap-4 md:grid-cols-3 lg:grid-cols-6">
                 <div>
                   <Label htmlFor="status">{t.admin.status}</Label>
                   <Select
@@ -1116,6 +1157,15 @@ export default function LogsPage() {
                     onChange={(e) => setEmailFilters({ ...emailFilters, to_email: e.target.value })}
                   />
                 </div>
+                <div>
+                  <Label htmlFor="email_campaign">{t.admin.emailCampaign}</Label>
+                  <Input
+                    id="email_campaign"
+                    placeholder={t.admin.emailCampaignPlaceholder}
+                    value={emailFilters.campaign}
+                    onChange={(e) => setEmailFilters({ ...emailFilters, campaign: e.target.value })}
+                  />
+                </div>
                 <div>
                   <Label htmlFor="email_start_date">{t.admin.startDate}</Label>
                   <Input
@@ -1144,6 +1194,7 @@ export default function LogsPage() {
                         status: '',
                         event_type: '',
                         to_email: '',
+                        campaign: '',
                         start_date: '',
                         end_date: '',
                       })
@@ -1548,6 +1599,120 @@ export default function LogsPage() {
             }}
           />
         </TabsContent>
+
+        <TabsContent value="jobs" className="space-y-4">
+          <p className="text-xs text-muted-foreground">
+            {t.admin.scheduledJobsHint.replace('{instance}', jobs?.data?.instance_id || '-')}
+          </p>
+          <DataTable
+            columns={[
+              {
+                header: t.admin.scheduledJobName,
+                accessorKey: 'name',
+                cell: ({ row }: any) => (
+                  <div className="space-y-1">
+                    <div className="font-mono text-sm">{row.original.name}</div>
+                    {row.original.description && (
+                      <div className="text-xs text-muted-foreground">
+                        {row.original.description}
+                      </div>
+                    )}
+                    {!row.original.registered && (
+                      <Badge variant="outline">{t.admin.scheduledJobNotRegistered}</Badge>
+                    )}
+                  </div>
+                ),
+              },
+              {
+                header: t.admin.scheduledJobInterval,
+                accessorKey: 'interval_seconds',
+                cell: ({ row }: any) => `${row.original.interval_seconds || 0}s`,
+              },
+              {
+                header: t.admin.status,
+                accessorKey: 'last_status',
+                cell: ({ row }: any) => {
+                  const statusMap: Record<
+                    string,
+                    { label: string; color: 'default' | 'secondary' | 'destructive' }
+                  > = {
+                    running: { label: t.admin.scheduledJobRunning, color: 'secondary' },
+                    success: { label: t.admin.scheduledJobSuccess, color: 'default' },
+                    failed: { label: t.admin.scheduledJobFailed, color: 'destructive' },
+                  }
+                  const config = statusMap[row.original.last_status]
+                  if (!config) {
+                    return <span className="text-muted-foreground">-</span>
+                  }
+                  return (
+                    <div className="space-y-1">
+                      <Badge variant={config.color}>{config.label}</Badge>
+                      {row.original.running_here && (
+                        <div className="text-xs text-muted-foreground">
+                          {t.admin.scheduledJobRunningHere}
+                        </div>
+                      )}
+                    </div>
+                  )
+                },
+              },
+              {
+                header: t.admin.scheduledJobLastRun,
+                accessorKey: 'last_started_at',
+                cell: ({ row }: any) =>
+                  row.original.last_started_at ? (
+                    <div className="space-y-1 text-sm">
+                      <div>{formatDate(row.original.last_started_at)}</div>
+                      <div className="text-xs text-muted-foreground">
+                        {row.original.last_duration_ms || 0}ms
+                      </div>
+                      <div className="font-mono text-xs text-muted-foreground">
+                        {row.original.last_instance || '-'}
+                      </div>
+                    </div>
+                  ) : (
+                    <span className="text-muted-foreground">-</span>
+                  ),
+              },
+              {
+                header: t.admin.scheduledJobNextRun,
+                accessorKey: 'next_run_at',
+                cell: ({ row }: any) =>
+                  row.original.next_run_at ? (
+                    formatDate(row.original.next_run_at)
+                  ) : (
+                    <span className="text-muted-foreground">-</span>
+                  ),
+              },
+              {
+                header: t.admin.scheduledJobRuns,
+                accessorKey: 'run_count',
+                cell: ({ row }: any) => (
+                  <span className="text-sm">
+                    {row.original.run_count || 0}
+                    {row.original.fail_count > 0 && (
+                      <span className="text-destructive"> / {row.original.fail_count}</span>
+                    )}
+                  </span>
+                ),
+              },
+              {
+                header: t.admin.scheduledJobLastError,
+                accessorKey: 'last_error',
+                cell: ({ row }: any) =>
+                  row.original.last_error ? (
+                    <span className="line-clamp-2 max-w-xs text-xs text-destructive">
+                      {row.original.last_error}
+                    </span>
+                  ) : (
+                    <span className="text-muted-foreground">-</span>
+                  ),
+              },
+            ]}
+            data={jobs?.data?.items || []}
+            isLoading={jobsLoading}
+          />
+        </TabsContent>
       </Tabs>
     </div>
   )
@@ -0,0 +1,566 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Check, Pencil, Plus, Trash2, X } from 'lucide-react'
+import {
+  createModerationRule,
+  deleteModerationRule,
+  getModerationCases,
+  getModerationRules,
+  reviewModerationCase,
+  updateModerationRule,
+  type ModerationAction,
+  type ModerationCase,
+  type ModerationCaseStatus,
+  type ModerationMatchType,
+  type ModerationRule,
+  type ModerationRuleInput,
+  type ModerationSource,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Switch } from '@/components/ui/switch'
+import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { Textarea } from '@/components/ui/textarea'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+const MODERATION_SOURCES: ModerationSource[] = [
+  'order_remark',
+  'ticket_subject',
+  'ticket_content',
+  'ticket_message',
+]
+const MODERATION_ACTIONS: ModerationAction[] = ['mask', 'flag', 'reject']
+const CASE_STATUSES: ModerationCaseStatus[] = ['pending', 'approved', 'removed', 'rejected']
+
+function createEmptyRule(): ModerationRuleInput {
+  return { pattern: '', match_type: 'keyword', action: 'flag', sources: [], note: '', is_active: true }
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+export default function AdminModerationPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminModeration)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('moderation.manage')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState<string>('pending')
+  const [source, setSource] = useState('all')
+  const [reviewing, setReviewing] = useState<{ item: ModerationCase; decision: 'approve' | 'remove' } | null>(null)
+  const [reviewNote, setReviewNote] = useState('')
+  const [editingRule, setEditingRule] = useState<ModerationRule | null>(null)
+  const [ruleFormOpen, setRuleFormOpen] = useState(false)
+  const [ruleForm, setRuleForm] = useState<ModerationRuleInput>(createEmptyRule())
+  const [deletingRule, setDeletingRule] = useState<ModerationRule | null>(null)
+
+  const sourceLabels: Record<ModerationSource, string> = {
+    order_remark: t.moderation.sourceOrderRemark,
+    ticket_subject: t.moderation.sourceTicketSubject,
+    ticket_content: t.moderation.sourceTicketContent,
+    ticket_message: t.moderation.sourceTicketMessage,
+  }
+  const actionLabels: Record<ModerationAction, string> = {
+    mask: t.moderation.actionMask,
+    flag: t.moderation.actionFlag,
+    reject: t.moderation.actionReject,
+  }
+  const statusLabels: Record<ModerationCaseStatus, string> = {
+    pending: t.moderation.statusPending,
+    approved: t.moderation.statusApproved,
+    removed: t.moderation.statusRemoved,
+    rejected: t.moderation.statusRejected,
+  }
+
+  const { data: casesData, isLoading: casesLoading } = useQuery({
+    queryKey: ['adminModerationCases', page, status, source],
+    queryFn: () =>
+      getModerationCases({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        source: source === 'all' ? undefined : source,
+      }),
+  })
+  const cases: ModerationCase[] = casesData?.data?.items || []
+
+  const { data: rulesData, isLoading: rulesLoading } = useQuery({
+    queryKey: ['adminModerationRules'],
+    queryFn: getModerationRules,
+  })
+  const rules: ModerationRule[] = rulesData?.data?.items || []
+
+  const reviewMutation = useMutation({
+    mutationFn: () =>
+      reviewModerationCase(reviewing!.item.id, { decision: reviewing!.decision, note: reviewNote }),
+    onSuccess: () => {
+      toast.success(t.moderation.reviewed)
+      setReviewing(null)
+      queryClient.invalidateQueries({ queryKey: ['adminModerationCases'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.moderation.reviewFailed))
+    },
+  })
+
+  const saveRuleMutation = useMutation({
+    mutationFn: () =>
+      editingRule ? updateModerationRule(editingRule.id, ruleForm) : createModerationRule(ruleForm),
+    onSuccess: () => {
+      toast.success(t.moderation.ruleSaved)
+      setRuleFormOpen(false)
+      queryClient.invalidateQueries({ queryKey: ['adminModerationRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.moderation.ruleSaveFailed))
+    },
+  })
+
+  const deleteRuleMutation = useMutation({
+    mutationFn: (id: number) => deleteModerationRule(id),
+    onSuccess: () => {
+      toast.success(t.moderation.ruleDeleted)
+      queryClient.invalidateQueries({ queryKey: ['adminModerationRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.moderation.ruleDeleteFailed))
+    },
+  })
+
+  const openReview = (item: ModerationCase, decision: 'approve' | 'remove') => {
+    setReviewNote('')
+    setReviewing({ item, decision })
+  }
+
+  const openCreateRule = () => {
+    setEditingRule(null)
+    setRuleForm(createEmptyRule())
+    setRuleFormOpen(true)
+  }
+
+  const openEditRule = (rule: ModerationRule) => {
+    setEditingRule(rule)
+    setRuleForm({
+      pattern: rule.pattern,
+      match_type: rule.match_type,
+      action: rule.action,
+      sources: rule.sources || [],
+      note: rule.note || '',
+      is_active: rule.is_active,
+    })
+    setRuleFormOpen(true)
+  }
+
+  const toggleRuleSource = (item: ModerationSource, checked: boolean) => {
+    setRuleForm((current) => ({
+      ...current,
+      sources: checked
+        ? [...current.sources, item]
+        : current.sources.filter((value) => value !== item),
+    }))
+  }
+
+  const caseColumns = [
+    {
+      header: t.moderation.source,
+      cell: ({ row }: { row: { original: ModerationCase } }) => (
+        <div className="text-sm">
+          <div>{sourceLabels[row.original.source]}</div>
+          {row.original.source_id ? (
+            <div className="text-xs text-muted-foreground">#{row.original.source_id}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.moderation.user,
+      cell: ({ row }: { row: { original: ModerationCase } }) =>
+        row.original.user?.email || (row.original.user_id ? `#${row.original.user_id}` : '-'),
+    },
+    {
+      header: t.moderation.content,
+      cell: ({ row }: { row: { original: ModerationCase } }) => (
+        <div className="max-w-[360px] space-y-1 text-sm">
+          <div className="whitespace-pre-wrap break-words">{row.original.content}</div>
+          {row.original.processed_content !== row.original.content ? (
+            <div className="whitespace-pre-wrap break-words text-xs text-muted-foreground">
+              {t.moderation.storedAs}: {row.original.processed_content}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.moderation.matches,
+      cell: ({ row }: { row: { original: ModerationCase } }) => (
+        <div className="flex max-w-[200px] flex-wrap gap-1">
+          {[...(row.original.matches || []), ...(row.original.labels || [])].map((value) => (
+            <Badge key={value} variant="outline">
+              {value}
+            </Badge>
+          ))}
+        </div>
+      ),
+    },
+    {
+      header: t.moderation.action,
+      cell: ({ row }: { row: { original: ModerationCase } }) => (
+        <div className="text-sm">
+          <div>{actionLabels[row.original.action] || row.original.action}</div>
+          <div className="text-xs text-muted-foreground">
+            {row.original.provider === 'external' ? t.moderation.providerExternal : t.moderation.providerRules}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.moderation.status,
+      cell: ({ row }: { row: { original: ModerationCase } }) => (
+        <div className="space-y-1">
+          <Badge variant={row.original.status === 'pending' ? 'default' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          <div className="text-xs text-muted-foreground">{formatDateTime(row.original.created_at)}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: ModerationCase } }) =>
+        canManage && row.original.status === 'pending' ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openReview(row.original, 'approve')}>
+              <Check className="mr-1 h-4 w-4" />
+              {t.moderation.approve}
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => openReview(row.original, 'remove')}>
+              <X className="mr-1 h-4 w-4" />
+              {t.moderation.remove}
+            </Button>
+          </div>
+        ) : row.original.review_note ? (
+          <div className="max-w-[200px] text-xs text-muted-foreground">{row.original.review_note}</div>
+        ) : null,
+    },
+  ]
+
+  const ruleColumns = [
+    {
+      header: t.moderation.pattern,
+      cell: ({ row }: { row: { original: ModerationRule } }) => (
+        <div className="max-w-[280px] space-y-1">
+          <code className="break-all text-sm">{row.original.pattern}</code>
+          {row.original.note ? (
+            <div className="text-xs text-muted-foreground">{row.original.note}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.moderation.matchType,
+      cell: ({ row }: { row: { original: ModerationRule } }) =>
+        row.original.match_type === 'regex' ? t.moderation.matchRegex : t.moderation.matchKeyword,
+    },
+    {
+      header: t.moderation.action,
+      cell: ({ row }: { row: { original: ModerationRule } }) => actionLabels[row.original.action],
+    },
+    {
+      header: t.moderation.sources,
+      cell: ({ row }: { row: { original: ModerationRule } }) =>
+        row.original.sources?.length
+          ? row.original.sources.map((item) => sourceLabels[item] || item).join(', ')
+          : t.moderation.allSources,
+    },
+    {
+      header: t.moderation.status,
+      cell: ({ row }: { row: { original: ModerationRule } }) => (
+        <Badge variant={row.original.is_active ? 'default' : 'outline'}>
+          {row.original.is_active ? t.moderation.ruleActive : t.moderation.ruleInactive}
+        </Badge>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: ModerationRule } }) =>
+        canManage ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openEditRule(row.original)}>
+              <Pencil className="h-4 w-4" />
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => setDeletingRule(row.original)}>
+              <Trash2 className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.moderation.title}</h1>
+        <p className="text-sm text-muted-foreground">{t.moderation.description}</p>
+      </div>
+
+      <Tabs defaultValue="queue">
+        <TabsList>
+          <TabsTrigger value="queue">{t.moderation.queueTab}</TabsTrigger>
+          <TabsTrigger value="rules">{t.moderation.rulesTab}</TabsTrigger>
+        </TabsList>
+
+        <TabsContent value="queue" className="space-y-4">
+          <div className="flex flex-wrap gap-2">
+            <Select
+              value={status}
+              onValueChange={(value) => {
+                setStatus(value)
+                setPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[160px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.common.all}</SelectItem>
+                {CASE_STATUSES.map((item) => (
+                  <SelectItem key={item} value={item}>
+                    {statusLabels[item]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <Select
+              value={source}
+              onValueChange={(value) => {
+                setSource(value)
+                setPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[180px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.moderation.allSources}</SelectItem>
+                {MODERATION_SOURCES.map((item) => (
+                  <SelectItem key={item} value={item}>
+                    {sourceLabels[item]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <DataTable
+            columns={caseColumns}
+            data={cases}
+            isLoading={casesLoading}
+            pagination={{
+              page,
+              total_pages: casesData?.data?.pagination?.total_pages || 1,
+              onPageChange: setPage,
+            }}
+          />
+        </TabsContent>
+
+        <TabsContent value="rules" className="space-y-4">
+          <div className="flex flex-wrap items-center justify-between gap-4">
+            <p className="text-sm text-muted-foreground">{t.moderation.rulesHint}</p>
+            {canManage ? (
+              <Button onClick={openCreateRule}>
+                <Plus className="mr-2 h-4 w-4" />
+                {t.moderation.createRule}
+              </Button>
+            ) : null}
+          </div>
+          <DataTable columns={ruleColumns} data={rules} isLoading={rulesLoading} />
+        </TabsContent>
+      </Tabs>
+
+      <Dialog open={Boolean(reviewing)} onOpenChange={(open) => (!open ? setReviewing(null) : null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {reviewing?.decision === 'remove' ? t.moderation.removeTitle : t.moderation.approveTitle}
+            </DialogTitle>
+          </DialogHeader>
+          <div className="space-y-3">
+            <p className="text-sm text-muted-foreground">
+              {reviewing?.decision === 'remove' ? t.moderation.removeHint : t.moderation.approveHint}
+            </p>
+            <div className="space-y-2">
+              <Label>{t.moderation.reviewNote}</Label>
+              <Textarea rows={3} value={reviewNote} onChange={(e) => setReviewNote(e.target.value)} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReviewing(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={reviewing?.decision === 'remove' ? 'destructive' : 'default'}
+              onClick={() => reviewMutation.mutate()}
+              disabled={reviewMutation.isPending}
+            >
+              {reviewing?.decision === 'remove' ? t.moderation.remove : t.moderation.approve}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={ruleFormOpen} onOpenChange={setRuleFormOpen}>
+        <DialogContent className="max-w-lg">
+          <DialogHeader>
+            <DialogTitle>{editingRule ? t.moderation.editRule : t.moderation.createRule}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>{t.moderation.pattern}</Label>
+              <Input
+                value={ruleForm.pattern}
+                onChange={(e) => setRuleForm({ ...ruleForm, pattern: e.target.value })}
+              />
+            </div>
+            <div className="grid gap-4 md:grid-cols-2">
+              <div className="space-y-2">
+                <Label>{t.moderation.matchType}</Label>
+                <Select
+                  value={ruleForm.match_type}
+                  onValueChange={(value) =>
+                    setRuleForm({ ...ruleForm, match_type: value as ModerationMatchType })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="keyword">{t.moderation.matchKeyword}</SelectItem>
+                    <SelectItem value="regex">{t.moderation.matchRegex}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>{t.moderation.action}</Label>
+                <Select
+                  value={ruleForm.action}
+                  onValueChange={(value) =>
+                    setRuleForm({ ...ruleForm, action: value as ModerationAction })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {MODERATION_ACTIONS.map((item) => (
+                      <SelectItem key={item} value={item}>
+                        {actionLabels[item]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.moderation.sources}</Label>
+              <div className="grid gap-2 md:grid-cols-2">
+                {MODERATION_SOURCES.map((item) => (
+                  <label key={item} className="flex items-center gap-2 text-sm">
+                    <Checkbox
+                      checked={ruleForm.sources.includes(item)}
+                      onCheckedChange={(checked) => toggleRuleSource(item, checked === true)}
+                    />
+                    {sourceLabels[item]}
+                  </label>
+                ))}
+              </div>
+              <p className="text-xs text-muted-foreground">{t.moderation.sourcesHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.moderation.note}</Label>
+              <Input
+                value={ruleForm.note}
+                onChange={(e) => setRuleForm({ ...ruleForm, note: e.target.value })}
+              />
+            </div>
+            <div className="flex items-center gap-2">
+              <Switch
+                checked={ruleForm.is_active}
+                onCheckedChange={(checked) => setRuleForm({ ...ruleForm, is_active: checked })}
+              />
+              <Label>{t.moderation.ruleActive}</Label>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setRuleFormOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => saveRuleMutation.mutate()} disabled={saveRuleMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog
+        open={Boolean(deletingRule)}
+        onOpenChange={(open) => (!open ? setDeletingRule(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.moderation.deleteRuleTitle}</AlertDialogTitle>
+            <AlertDialogDescription>{t.moderation.deleteRuleConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deletingRule) deleteRuleMutation.mutate(deletingRule.id)
+                setDeletingRule(null)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
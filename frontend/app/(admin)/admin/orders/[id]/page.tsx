@@ -7,7 +7,7 @@ import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
 import { usePageTitle } from '@/hooks/use-page-title'
 import {
-  getAdminOrderDetail,
+  getAdminOrderFull,
   assignTracking,
   adminCompleteOrder,
   adminCancelOrder,
@@ -20,9 +20,27 @@ import {
   adminDeliverVirtualStock,
   adminRefundOrder,
   adminConfirmRefund,
+  getAdminOrderTimeline,
+  addAdminOrderRemark,
+  getAdminOrderLicenseActivations,
+  deactivateAdminOrderLicenseActivation,
+  getAdminOrderUsageMeters,
+  getAdminOrderDocuments,
+  getAdminOrderDocumentPath,
+  type OrderRiskReview,
 } from '@/lib/api'
 import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getPackingSlipPath, openPackingSlip } from '@/lib/packing-slip'
 import { OrderDetail } from '@/components/orders/order-detail'
+import { VirtualRevealLogCard } from '@/components/admin/virtual-reveal-log-card'
+import { OrderActivityCard } from '@/components/admin/order-activity-card'
+import { OrderTimelineCard } from '@/components/orders/order-timeline-card'
+import { LicenseActivationCard } from '@/components/orders/license-activation-card'
+import { UsageMeterCard } from '@/components/orders/usage-meter-card'
+import { OrderDocumentsCard } from '@/components/orders/order-documents-card'
+import { OrderPartialRefundPanel } from '@/components/admin/order-partial-refund-panel'
+import { OrderPriceAdjustmentPanel } from '@/components/admin/order-price-adjustment-panel'
+import { OrderPaymentLinkDialog } from '@/components/admin/order-payment-link-dialog'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
 import { Textarea } from '@/components/ui/textarea'
@@ -78,6 +96,7 @@ import {
   DollarSign,
   Key,
   Undo2,
+  Printer,
 } from 'lucide-react'
 import Link from 'next/link'
 import { useToast } from '@/hooks/use-toast'
@@ -149,7 +168,7 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
 
   const { data, isLoading } = useQuery({
     queryKey: ['adminOrderDetail', orderId],
-    queryFn: () => getAdminOrderDetail(orderId),
+    queryFn: () => getAdminOrderFull(orderId),
     enabled: !!orderId,
     staleTime: 0,
   })
@@ -386,13 +405,16 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
   }
 
   // 处理新的数据结构：{order, serials, virtual_stocks} 或 旧结构直接是order
-  const order = data.data.order || data.data
+  const order = data.data.order
+    ? { ...data.data.order, shipment: data.data.shipment }
+    : data.data
   const serials = data.data.serials || []
   const virtualStocks = data.data.virtual_stocks || []
   const hasPendingVirtualStock = Boolean(
     data.data.has_pending_virtual_stock ?? data.data.hasPendingVirtualStock
   )
   const paymentInfo = data.data.payment_info
+  const riskReview: OrderRiskReview | null = data.data.risk_review || null
   const orderNumber = order.orderNo || order.order_no
   const hasTracking = Boolean(order.trackingNo || order.tracking_no)
   const orderFormURL = String(formAccess?.form_url || data.data.form_url || '').trim()
@@ -412,6 +434,7 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
     false
   const canMarkPaid = order.status === 'pending_payment'
   const canUpdatePrice = canMarkPaid
+  const canCreatePaymentLink = canMarkPaid
   const canDeliverVirtual =
     hasVirtualItems &&
     hasPendingVirtualStock &&
@@ -421,6 +444,9 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
   const canRequestResubmit = order.status === 'pending' && !isVirtualOnly
   const canAssignTracking = order.status === 'pending' && !isVirtualOnly && !hasTracking
   const canMarkComplete = order.status === 'shipped'
+  const canPrintPackingSlip =
+    !isVirtualOnly &&
+    (order.status === 'pending' || order.status === 'shipped' || order.status === 'completed')
   const canCancel =
     order.status === 'pending_payment' ||
     order.status === 'draft' ||
@@ -440,6 +466,11 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
     order.status === 'refunded'
   const secondaryActionCount =
     Number(canCancel) + Number(canRefund) + Number(canConfirmRefund) + Number(canDelete)
+  const handlePrintPackingSlip = () => {
+    openPackingSlip(getPackingSlipPath(order.id), t, t.admin.packingSlipFailed).catch((error) => {
+      toast.error(error.message || t.admin.packingSlipFailed)
+    })
+  }
   const adminOrderDetailPluginContext = {
     view: 'admin_order_detail',
     order: {
@@ -615,6 +646,27 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
           </AlertDescription>
         </Alert>
       )}
+      {riskReview && riskReview.status !== 'approved' && (
+        <Alert variant={riskReview.action === 'hold' ? 'destructive' : 'default'}>
+          <AlertTitle>
+            {riskReview.action === 'hold' ? t.risk.orderHeldTitle : t.risk.orderFlaggedTitle}
+          </AlertTitle>
+          <AlertDescription className="space-y-1">
+            {(riskReview.hits || []).map((hit) => (
+              <p key={hit.rule_id}>
+                {hit.rule_name}: {hit.value} / {hit.threshold}
+              </p>
+            ))}
+            {riskReview.status === 'rejected' ? (
+              <p>{t.risk.orderRejected}</p>
+            ) : (
+              <Link href="/admin/risk" className="text-sm underline">
+                {t.risk.openQueue}
+              </Link>
+            )}
+          </AlertDescription>
+        </Alert>
+      )}
       <div className="flex flex-col gap-3 xl:flex-row xl:items-start xl:justify-between">
         <div className="flex items-center gap-4">
           <Button asChild variant="outline" size="sm">
@@ -624,6 +676,15 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
             </Link>
           </Button>
           <h1 className="text-lg font-bold md:text-xl">{t.order.orderDetail}</h1>
+          {order.tags?.length > 0 && (
+            <div className="flex flex-wrap gap-1">
+              {order.tags.map((tag: string) => (
+                <Badge key={tag} variant="secondary">
+                  {tag}
+                </Badge>
+              ))}
+            </div>
+          )}
         </div>
 
         <div className="xl:max-w-[60%]">
@@ -715,6 +776,9 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
               </Dialog>
             )}
 
+            {/* 付款链接 */}
+            {canCreatePaymentLink && <OrderPaymentLinkDialog orderId={order.id} />}
+
             {/* 发货虚拟商品 */}
             {canDeliverVirtual && (
               <Dialog
@@ -1025,6 +1089,12 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
                 </DialogContent>
               </Dialog>
             )}
+            {canPrintPackingSlip && (
+              <Button variant="outline" onClick={handlePrintPackingSlip}>
+                <Printer className="mr-2 h-4 w-4" />
+                {t.admin.packingSlip}
+              </Button>
+            )}
             {secondaryActionCount > 0 ? (
               <DropdownMenu>
                 <DropdownMenuTrigger asChild>
@@ -1244,6 +1314,53 @@ export default function AdminOrderDetailPage({ params }: { params: Promise<{ id:
         pluginSlotContext={adminOrderDetailPluginContext}
         pluginSlotPath={`/admin/orders/${orderId}`}
       />
+      {virtualStocks.length > 0 && <VirtualRevealLogCard orderId={orderId} />}
+      {virtualStocks.length > 0 && (
+        <LicenseActivationCard
+          queryKey={['adminOrderDetail', orderId, 'licenseActivations']}
+          fetchActivations={() => getAdminOrderLicenseActivations(orderId)}
+          deactivate={(activationId) =>
+            deactivateAdminOrderLicenseActivation(orderId, activationId)
+          }
+        />
+      )}
+      {virtualStocks.length > 0 && (
+        <UsageMeterCard
+          queryKey={['adminOrderDetail', orderId, 'usageMeters']}
+          fetchMeters={() => getAdminOrderUsageMeters(orderId)}
+          currency={order.currency || 'CNY'}
+          overageOrderHref={(meter) => `/admin/orders/${meter.overage_order_id}`}
+        />
+      )}
+      <OrderPriceAdjustmentPanel
+        order={{ id: orderId, currency: order.currency || 'CNY', status: order.status }}
+      />
+      <OrderPartialRefundPanel
+        order={{
+          id: orderId,
+          currency: order.currency || 'CNY',
+          status: order.status,
+          items: order.items,
+        }}
+      />
+      <OrderTimelineCard
+        queryKey={['adminOrderDetail', orderId, 'timeline']}
+        fetchTimeline={() => getAdminOrderTimeline(orderId)}
+        addRemark={(remark) => addAdminOrderRemark(orderId, remark)}
+      />
+      <OrderDocumentsCard
+        queryKey={['adminOrderDetail', orderId, 'documents', order.status]}
+        fetchDocuments={() => getAdminOrderDocuments(orderId)}
+        openDocument={(document, item, format) =>
+          openPackingSlip(
+            getAdminOrderDocumentPath(orderId, document.key, item, format),
+            t,
+            t.order.documentOpenFailed
+          )
+        }
+        pdfEnabled
+      />
+      <OrderActivityCard activity={data.data} />
       <PluginSlot slot="admin.order_detail.bottom" context={adminOrderDetailPluginContext} />
     </div>
   )
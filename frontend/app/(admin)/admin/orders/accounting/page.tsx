@@ -0,0 +1,256 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Download, Upload } from 'lucide-react'
+import {
+  getAccountingExportRuns,
+  triggerAccountingExport,
+  AccountingExportFormat,
+  AccountingExportRun,
+} from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Card, CardContent } from '@/components/ui/card'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+export default function AdminAccountingExportPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminAccountingExport)
+  const queryClient = useQueryClient()
+  const { hasPermission } = usePermission()
+  const canPush = hasPermission('system.config')
+
+  const [toDate, setToDate] = useState(() => formatDateInput(new Date()))
+  const [fromDate, setFromDate] = useState(() => {
+    const now = new Date()
+    return formatDateInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth(), 1)))
+  })
+  const [format, setFormat] = useState<AccountingExportFormat>('journal')
+  const [page, setPage] = useState(1)
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['accountingExportRuns', page],
+    queryFn: () => getAccountingExportRuns({ page, limit: 20 }),
+  })
+  const runs: AccountingExportRun[] = data?.data?.items || []
+
+  const pushMutation = useMutation({
+    mutationFn: () => triggerAccountingExport(),
+    onSuccess: (res) => {
+      const run: AccountingExportRun | undefined = res?.data
+      const message = t.admin.accountingPushResult
+        .replace('{pushed}', String(run?.pushed_count || 0))
+        .replace('{failed}', String(run?.failed_count || 0))
+      if (run?.status === 'completed') {
+        toast.success(message)
+      } else {
+        toast.error(message)
+      }
+      queryClient.invalidateQueries({ queryKey: ['accountingExportRuns'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.accountingPushFailed))
+    },
+  })
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExport = () => {
+    const params = new URLSearchParams({ from: fromDate, to: toDate, format })
+    const url = resolveClientAPIProxyURL(`/api/admin/reports/accounting/export?${params}`)
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        const skipped = Number(res.headers.get('X-Skipped-Journals') || 0)
+        return { blob: await res.blob(), skipped }
+      })
+      .then(({ blob, skipped }) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `accounting_${format}_${fromDate}_${toDate}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+        toast.success(t.admin.accountingExportSuccess)
+        if (skipped > 0) {
+          toast.error(t.admin.accountingExportSkipped.replace('{count}', String(skipped)))
+        }
+      })
+      .catch((err) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const statusVariant = (status: AccountingExportRun['status']) => {
+    if (status === 'completed') return 'default' as const
+    if (status === 'partial') return 'secondary' as const
+    return 'destructive' as const
+  }
+
+  const statusLabel = (status: AccountingExportRun['status']) => {
+    if (status === 'completed') return t.admin.accountingRunCompleted
+    if (status === 'partial') return t.admin.accountingRunPartial
+    return t.admin.accountingRunFailed
+  }
+
+  const columns = [
+    {
+      header: t.admin.accountingRunStartedAt,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) =>
+        formatDateTime(row.original.started_at),
+    },
+    {
+      header: t.admin.accountingProvider,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) =>
+        row.original.provider === 'xero' ? 'Xero' : 'QuickBooks Online',
+    },
+    {
+      header: t.admin.accountingRunTrigger,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) =>
+        row.original.trigger_type === 'manual'
+          ? t.admin.accountingTriggerManual
+          : t.admin.accountingTriggerScheduled,
+    },
+    {
+      header: t.admin.accountingRunStatus,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) => (
+        <Badge variant={statusVariant(row.original.status)}>
+          {statusLabel(row.original.status)}
+        </Badge>
+      ),
+    },
+    {
+      header: t.admin.accountingRunCounts,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) => {
+        const { pushed_count, failed_count, skipped_count } = row.original
+        return `${pushed_count} / ${failed_count} / ${skipped_count}`
+      },
+    },
+    {
+      header: t.admin.accountingRunError,
+      cell: ({ row }: { row: { original: AccountingExportRun } }) =>
+        row.original.error ? (
+          <span className="line-clamp-2 max-w-md text-xs text-destructive">
+            {row.original.error}
+          </span>
+        ) : (
+          '-'
+        ),
+    },
+  ]
+
+  return (
+    <div className="space-y-4 p-4">
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div className="flex items-center gap-2">
+          <Button variant="outline" size="icon" asChild className="h-8 w-8">
+            <Link href="/admin/orders">
+              <ArrowLeft className="h-4 w-4" />
+              <span className="sr-only">{t.admin.orderManagement}</span>
+            </Link>
+          </Button>
+          <div>
+            <h1 className="text-xl font-bold">{t.admin.accountingExport}</h1>
+            <p className="text-sm text-muted-foreground">{t.admin.accountingExportDesc}</p>
+          </div>
+        </div>
+        {canPush ? (
+          <Button onClick={() => pushMutation.mutate()} disabled={pushMutation.isPending}>
+            <Upload className="mr-2 h-4 w-4" />
+            {pushMutation.isPending ? t.admin.accountingPushing : t.admin.accountingPushNow}
+          </Button>
+        ) : null}
+      </div>
+
+      <Card>
+        <CardContent className="flex flex-col gap-3 pt-6 md:flex-row md:items-end">
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.accountingFromDate}</label>
+            <Input type="date" value={fromDate} onChange={(e) => setFromDate(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.accountingToDate}</label>
+            <Input type="date" value={toDate} onChange={(e) => setToDate(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.accountingFormat}</label>
+            <Select
+              value={format}
+              onValueChange={(value) => setFormat(value as AccountingExportFormat)}
+            >
+              <SelectTrigger className="w-[220px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="journal">{t.admin.accountingFormatJournal}</SelectItem>
+                <SelectItem value="quickbooks">{t.admin.accountingFormatQuickBooks}</SelectItem>
+                <SelectItem value="xero">{t.admin.accountingFormatXero}</SelectItem>
+              </SelectContent>
+            </Select>
+          </div>
+          <Button variant="outline" onClick={handleExport} disabled={!fromDate || !toDate}>
+            <Download className="mr-2 h-4 w-4" />
+            {t.admin.accountingDownload}
+          </Button>
+        </CardContent>
+      </Card>
+
+      <div className="space-y-2">
+        <h2 className="text-base font-semibold">{t.admin.accountingRuns}</h2>
+        <p className="text-xs text-muted-foreground">{t.admin.accountingRunsHint}</p>
+      </div>
+      {!isLoading && runs.length === 0 ? (
+        <p className="py-8 text-center text-sm text-muted-foreground">{t.admin.accountingNoRuns}</p>
+      ) : (
+        <DataTable
+          columns={columns}
+          data={runs}
+          isLoading={isLoading}
+          pagination={{
+            page,
+            total_pages: data?.data?.pagination?.total_pages || 1,
+            onPageChange: setPage,
+          }}
+        />
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,476 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Copy, Loader2, Plus, Search, Trash2 } from 'lucide-react'
+import { createAdminOrder, getAdminProducts, getUsers, PaymentLinkResult } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { parseMajorToMinor } from '@/lib/utils'
+import { useCurrency, formatPrice } from '@/contexts/currency-context'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Checkbox } from '@/components/ui/checkbox'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { Textarea } from '@/components/ui/textarea'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+interface ManualOrderItem {
+  sku: string
+  name: string
+  quantity: number
+  catalog_price_minor: number
+  // 留空时按商品当前售价计价
+  unit_price_major: string
+}
+
+type PaymentAction = 'none' | 'mark_paid' | 'payment_link'
+
+// 管理员手动建单：电话、私信等线下渠道的订单直接在后台录入
+export default function AdminNewOrderPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminNewOrder)
+  const { currency } = useCurrency()
+
+  const [customerMode, setCustomerMode] = useState<'user' | 'guest'>('user')
+  const [userSearch, setUserSearch] = useState('')
+  const [selectedUser, setSelectedUser] = useState<any | null>(null)
+  const [guestEmail, setGuestEmail] = useState('')
+  const [receiverName, setReceiverName] = useState('')
+  const [phoneCode, setPhoneCode] = useState('')
+  const [receiverPhone, setReceiverPhone] = useState('')
+  const [productSearch, setProductSearch] = useState('')
+  const [items, setItems] = useState<ManualOrderItem[]>([])
+  const [discountMajor, setDiscountMajor] = useState('')
+  const [remark, setRemark] = useState('')
+  const [adminRemark, setAdminRemark] = useState('')
+  const [paymentAction, setPaymentAction] = useState<PaymentAction>('none')
+  const [sendEmail, setSendEmail] = useState(true)
+  const [sendSms, setSendSms] = useState(false)
+  const [result, setResult] = useState<any | null>(null)
+
+  const { data: usersData, isFetching: usersLoading } = useQuery({
+    queryKey: ['manualOrderUsers', userSearch],
+    queryFn: () => getUsers({ page: 1, limit: 5, search: userSearch }),
+    enabled: customerMode === 'user' && !selectedUser && userSearch.trim().length >= 2,
+  })
+  const userOptions: any[] = usersData?.data?.items || []
+
+  const { data: productsData, isFetching: productsLoading } = useQuery({
+    queryKey: ['manualOrderProducts', productSearch],
+    queryFn: () => getAdminProducts({ page: 1, limit: 5, search: productSearch }),
+    enabled: productSearch.trim().length >= 2,
+  })
+  const productOptions: any[] = productsData?.data?.items || []
+
+  const itemPrice = (item: ManualOrderItem) => {
+    if (item.unit_price_major.trim() === '') return item.catalog_price_minor
+    return parseMajorToMinor(item.unit_price_major) || 0
+  }
+  const subtotal = items.reduce((sum, item) => sum + itemPrice(item) * item.quantity, 0)
+  const discountMinor = discountMajor.trim() === '' ? 0 : parseMajorToMinor(discountMajor)
+  const total = Math.max(subtotal - (discountMinor || 0), 0)
+
+  const updateItem = (index: number, patch: Partial<ManualOrderItem>) => {
+    setItems(items.map((item, i) => (i === index ? { ...item, ...patch } : item)))
+  }
+
+  const addProduct = (product: any) => {
+    setItems([
+      ...items,
+      {
+        sku: product.sku,
+        name: product.name,
+        quantity: 1,
+        catalog_price_minor: product.price_minor || 0,
+        unit_price_major: '',
+      },
+    ])
+    setProductSearch('')
+  }
+
+  const createMutation = useMutation({
+    mutationFn: createAdminOrder,
+    onSuccess: (response: any) => {
+      const data = response?.data || null
+      setResult(data)
+      toast.success(t.admin.orderCreated)
+      if (data?.payment_action_error) {
+        toast.error(t.admin.manualOrderPaymentActionFailed)
+      }
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.orderCreateFailed))
+    },
+  })
+
+  const handleSubmit = () => {
+    if (customerMode === 'user' && !selectedUser) {
+      toast.error(t.admin.manualOrderSelectUser)
+      return
+    }
+    if (customerMode === 'guest' && !guestEmail.trim()) {
+      toast.error(t.admin.manualOrderGuestEmailRequired)
+      return
+    }
+    if (items.length === 0) {
+      toast.error(t.admin.addItem)
+      return
+    }
+    const normalizedItems: any[] = []
+    for (const item of items) {
+      const entry: any = { sku: item.sku, name: item.name, quantity: item.quantity }
+      if (item.unit_price_major.trim() !== '') {
+        const unitPriceMinor = parseMajorToMinor(item.unit_price_major)
+        if (unitPriceMinor === null || unitPriceMinor < 0) {
+          toast.error(t.order.invalidPrice)
+          return
+        }
+        entry.unit_price_minor = unitPriceMinor
+      }
+      normalizedItems.push(entry)
+    }
+    if (discountMinor === null || discountMinor < 0 || discountMinor > subtotal) {
+      toast.error(t.admin.manualOrderDiscountInvalid)
+      return
+    }
+
+    const data: any = {
+      items: normalizedItems,
+      discount_minor: discountMinor,
+      receiver_name: receiverName.trim(),
+      phone_code: phoneCode.trim(),
+      receiver_phone: receiverPhone.trim(),
+      remark,
+      admin_remark: adminRemark,
+      payment_action: paymentAction,
+    }
+    if (customerMode === 'user') {
+      data.user_id = selectedUser.id
+    } else {
+      data.user_email = guestEmail.trim()
+      data.receiver_email = guestEmail.trim()
+    }
+    if (paymentAction === 'payment_link') {
+      data.payment_link = { send_email: sendEmail, send_sms: sendSms }
+    }
+    createMutation.mutate(data)
+  }
+
+  const paymentLink: PaymentLinkResult | undefined = result?.payment_link
+
+  const handleCopyLink = async () => {
+    if (!paymentLink?.url || typeof navigator === 'undefined' || !navigator.clipboard) return
+    await navigator.clipboard.writeText(paymentLink.url)
+    toast.success(t.paymentLink.copied)
+  }
+
+  if (result) {
+    return (
+      <div className="mx-auto max-w-2xl space-y-6">
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.admin.orderCreated}</CardTitle>
+            <CardDescription className="font-mono">{result.order_no}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4 text-sm">
+            {result.payment_action_error && (
+              <p className="text-destructive">{t.admin.manualOrderPaymentActionFailed}</p>
+            )}
+            {paymentLink && (
+              <div className="space-y-2">
+                <Label>{t.paymentLink.link}</Label>
+                <div className="flex gap-2">
+                  <Input readOnly value={paymentLink.url} onFocus={(e) => e.target.select()} />
+                  <Button variant="outline" size="icon" onClick={handleCopyLink}>
+                    <Copy className="h-4 w-4" />
+                  </Button>
+                </div>
+              </div>
+            )}
+            <div className="flex gap-2">
+              <Button asChild>
+                <Link href={`/admin/orders/${result.order_id}`}>{t.admin.view}</Link>
+              </Button>
+              <Button
+                variant="outline"
+                onClick={() => {
+                  setResult(null)
+                  setItems([])
+                  setDiscountMajor('')
+                  setSelectedUser(null)
+                }}
+              >
+                {t.admin.manualOrderCreateAnother}
+              </Button>
+            </div>
+          </CardContent>
+        </Card>
+      </div>
+    )
+  }
+
+  return (
+    <div className="mx-auto max-w-4xl space-y-6">
+      <div className="flex items-center gap-3">
+        <Button variant="ghost" size="icon" asChild>
+          <Link href="/admin/orders">
+            <ArrowLeft className="h-4 w-4" />
+          </Link>
+        </Button>
+        <div>
+          <h1 className="text-3xl font-bold">{t.admin.manualOrderTitle}</h1>
+          <p className="text-sm text-muted-foreground">{t.admin.manualOrderDesc}</p>
+        </div>
+      </div>
+
+      <Card>
+        <CardHeader>
+          <CardTitle className="text-base">{t.admin.manualOrderCustomer}</CardTitle>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          <Tabs value={customerMode} onValueChange={(v) => setCustomerMode(v as 'user' | 'guest')}>
+            <TabsList>
+              <TabsTrigger value="user">{t.admin.manualOrderExistingUser}</TabsTrigger>
+              <TabsTrigger value="guest">{t.admin.manualOrderGuest}</TabsTrigger>
+            </TabsList>
+          </Tabs>
+          {customerMode === 'user' ? (
+            selectedUser ? (
+              <div className="flex items-center justify-between rounded-md border p-3 text-sm">
+                <div>
+                  <div className="font-medium">{selectedUser.name || selectedUser.email}</div>
+                  <div className="text-muted-foreground">{selectedUser.email}</div>
+                </div>
+                <Button variant="outline" size="sm" onClick={() => setSelectedUser(null)}>
+                  {t.admin.manualOrderChangeUser}
+                </Button>
+              </div>
+            ) : (
+              <div className="space-y-2">
+                <div className="relative">
+                  <Search className="absolute left-3 top-1/2 h-4 w-4 -translate-y-1/2 text-muted-foreground" />
+                  <Input
+                    className="pl-9"
+                    placeholder={t.admin.manualOrderSearchUser}
+                    value={userSearch}
+                    onChange={(e) => setUserSearch(e.target.value)}
+                  />
+                </div>
+                {usersLoading && <Loader2 className="h-4 w-4 animate-spin" />}
+                {userOptions.map((user) => (
+                  <button
+                    key={user.id}
+                    type="button"
+                    className="flex w-full items-center justify-between rounded-md border px-3 py-2 text-left text-sm hover:bg-muted"
+                    onClick={() => {
+                      setSelectedUser(user)
+                      setReceiverName(receiverName || user.name || '')
+                    }}
+                  >
+                    <span className="font-medium">{user.name || '-'}</span>
+                    <span className="text-muted-foreground">{user.email}</span>
+                  </button>
+                ))}
+              </div>
+            )
+          ) : (
+            <div className="space-y-2">
+              <Label htmlFor="manual_order_guest_email">{t.admin.manualOrderGuestEmail}</Label>
+              <Input
+                id="manual_order_guest_email"
+                type="email"
+                value={guestEmail}
+                onChange={(e) => setGuestEmail(e.target.value)}
+              />
+            </div>
+          )}
+          <div className="grid gap-3 sm:grid-cols-[2fr_1fr_2fr]">
+            <Input
+              placeholder={t.admin.manualOrderReceiverName}
+              value={receiverName}
+              onChange={(e) => setReceiverName(e.target.value)}
+            />
+            <Input
+              placeholder="+1"
+              value={phoneCode}
+              onChange={(e) => setPhoneCode(e.target.value)}
+            />
+            <Input
+              type="tel"
+              placeholder={t.admin.manualOrderReceiverPhone}
+              value={receiverPhone}
+              onChange={(e) => setReceiverPhone(e.target.value)}
+            />
+          </div>
+          <p className="text-xs text-muted-foreground">{t.admin.manualOrderShippingHint}</p>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardHeader>
+          <CardTitle className="text-base">{t.admin.orderItems}</CardTitle>
+          <CardDescription>{t.admin.manualOrderPriceHint}</CardDescription>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          <div className="space-y-2">
+            <div className="relative">
+              <Search className="absolute left-3 top-1/2 h-4 w-4 -translate-y-1/2 text-muted-foreground" />
+              <Input
+                className="pl-9"
+                placeholder={t.admin.manualOrderSearchProduct}
+                value={productSearch}
+                onChange={(e) => setProductSearch(e.target.value)}
+              />
+            </div>
+            {productsLoading && <Loader2 className="h-4 w-4 animate-spin" />}
+            {productSearch.trim().length >= 2 &&
+              productOptions.map((product) => (
+                <button
+                  key={product.id}
+                  type="button"
+                  className="flex w-full items-center justify-between rounded-md border px-3 py-2 text-left text-sm hover:bg-muted"
+                  onClick={() => addProduct(product)}
+                >
+                  <span>
+                    <span className="font-medium">{product.name}</span>
+                    <span className="ml-2 font-mono text-xs text-muted-foreground">
+                      {product.sku}
+                    </span>
+                  </span>
+                  <span className="flex items-center gap-2">
+                    {formatPrice(product.price_minor, currency)}
+                    <Plus className="h-4 w-4" />
+                  </span>
+                </button>
+              ))}
+          </div>
+
+          {items.map((item, index) => (
+            <div key={index} className="flex flex-wrap items-center gap-2 rounded-md border p-3">
+              <div className="min-w-0 flex-1">
+                <div className="truncate font-medium">{item.name}</div>
+                <div className="font-mono text-xs text-muted-foreground">{item.sku}</div>
+              </div>
+              <Input
+                className="w-20"
+                type="number"
+                min={1}
+                value={item.quantity}
+                onChange={(e) =>
+                  updateItem(index, { quantity: Math.max(1, Number(e.target.value) || 1) })
+                }
+              />
+              <Input
+                className="w-32"
+                inputMode="decimal"
+                placeholder={formatPrice(item.catalog_price_minor, currency)}
+                value={item.unit_price_major}
+                onChange={(e) => updateItem(index, { unit_price_major: e.target.value })}
+              />
+              <Button
+                variant="ghost"
+                size="icon"
+                onClick={() => setItems(items.filter((_, i) => i !== index))}
+              >
+                <Trash2 className="h-4 w-4" />
+              </Button>
+            </div>
+          ))}
+
+          <div className="grid gap-2 border-t pt-4 text-sm">
+            <div className="flex justify-between">
+              <span className="text-muted-foreground">{t.admin.manualOrderSubtotal}</span>
+              <span>{formatPrice(subtotal, currency)}</span>
+            </div>
+            <div className="flex items-center justify-between gap-4">
+              <Label htmlFor="manual_order_discount" className="text-muted-foreground">
+                {t.admin.manualOrderDiscount}
+              </Label>
+              <Input
+                id="manual_order_discount"
+                className="w-32"
+                inputMode="decimal"
+                placeholder="0.00"
+                value={discountMajor}
+                onChange={(e) => setDiscountMajor(e.target.value)}
+              />
+            </div>
+            <div className="flex justify-between font-semibold">
+              <span>{t.admin.manualOrderTotal}</span>
+              <span>{formatPrice(total, currency)}</span>
+            </div>
+          </div>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardHeader>
+          <CardTitle className="text-base">{t.admin.manualOrderPayment}</CardTitle>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          <Select value={paymentAction} onValueChange={(v) => setPaymentAction(v as PaymentAction)}>
+            <SelectTrigger>
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="none">{t.admin.manualOrderPaymentNone}</SelectItem>
+              <SelectItem value="mark_paid">{t.admin.manualOrderPaymentMarkPaid}</SelectItem>
+              <SelectItem value="payment_link">{t.admin.manualOrderPaymentLink}</SelectItem>
+            </SelectContent>
+          </Select>
+          {paymentAction === 'payment_link' && (
+            <div className="flex flex-wrap gap-6">
+              <div className="flex items-center gap-2">
+                <Checkbox
+                  id="manual_order_send_email"
+                  checked={sendEmail}
+                  onCheckedChange={(checked) => setSendEmail(checked === true)}
+                />
+                <Label htmlFor="manual_order_send_email">{t.paymentLink.sendEmail}</Label>
+              </div>
+              <div className="flex items-center gap-2">
+                <Checkbox
+                  id="manual_order_send_sms"
+                  checked={sendSms}
+                  onCheckedChange={(checked) => setSendSms(checked === true)}
+                />
+                <Label htmlFor="manual_order_send_sms">{t.paymentLink.sendSms}</Label>
+              </div>
+            </div>
+          )}
+          <Textarea
+            placeholder={t.admin.manualOrderRemark}
+            value={remark}
+            onChange={(e) => setRemark(e.target.value)}
+          />
+          <Textarea
+            placeholder={t.admin.manualOrderAdminRemark}
+            value={adminRemark}
+            onChange={(e) => setAdminRemark(e.target.value)}
+          />
+        </CardContent>
+      </Card>
+
+      <div className="flex justify-end">
+        <Button disabled={createMutation.isPending} onClick={handleSubmit}>
+          {createMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+          {t.admin.manualOrderSubmit}
+        </Button>
+      </div>
+    </div>
+  )
+}
@@ -3,9 +3,16 @@
 import { Suspense, useState, useEffect, useRef, useCallback } from 'react'
 import { useSearchParams } from 'next/navigation'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
-import { getAdminOrders, batchUpdateOrders } from '@/lib/api'
+import {
+  getAdminOrders,
+  batchUpdateOrders,
+  previewBatchUpdateOrders,
+  type BatchOrderPreview,
+} from '@/lib/api'
 import { resolveApiErrorMessage } from '@/lib/api-error'
 import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { OrderExportDialog, OrderExportOptions } from '@/components/orders/order-export-dialog'
+import { getDailyPackingSlipsPath, openPackingSlip } from '@/lib/packing-slip'
 import { DataTable } from '@/components/admin/data-table'
 import { OrderStatusBadge } from '@/components/orders/order-status-badge'
 import { OrderFilter } from '@/components/orders/order-filter'
@@ -41,15 +48,24 @@ import {
   Trash2,
   ChevronDown,
   X,
+  Landmark,
+  CalendarRange,
+  BookText,
+  Printer,
+  Plus,
 } from 'lucide-react'
 import Link from 'next/link'
+import { format } from 'date-fns'
 import { getToken } from '@/lib/auth'
 import toast from 'react-hot-toast'
 import { useLocale } from '@/hooks/use-locale'
-import { getTranslations } from '@/lib/i18n'
+import { getTranslations, translateBizError } from '@/lib/i18n'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { PluginExtensionList } from '@/components/plugins/plugin-extension-list'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { RefundRequestPanel } from '@/components/admin/refund-request-panel'
+import { OrderPartialRefundPanel } from '@/components/admin/order-partial-refund-panel'
+import { OrderPriceAdjustmentPanel } from '@/components/admin/order-price-adjustment-panel'
 import { usePluginExtensionBatch } from '@/lib/plugin-extension-batch'
 
 function buildAdminOrderRowSummary(order: any) {
@@ -122,6 +138,7 @@ function AdminOrdersContent() {
   const [batchDialogOpen, setBatchDialogOpen] = useState(false)
   const [batchAction, setBatchAction] = useState('')
   const fileInputRef = useRef<HTMLInputElement>(null)
+  const [exportOpen, setExportOpen] = useState(false)
 
   const readFetchErrorMessage = useCallback(
     async (response: Response, fallback: string) => {
@@ -194,8 +211,11 @@ function AdminOrdersContent() {
       }),
   })
 
-  const handleExport = () => {
-    const params = new URLSearchParams()
+  const handleExport = (options: OrderExportOptions) => {
+    const params = new URLSearchParams({ format: options.format })
+    if (options.from) params.append('from', options.from)
+    if (options.to) params.append('to', options.to)
+    if (options.sku) params.append('sku', options.sku)
     if (status && status !== 'all') params.append('status', status)
     if (search) params.append('search', search)
     if (productSearch) params.append('product_search', productSearch)
@@ -216,12 +236,13 @@ function AdminOrdersContent() {
         const url = window.URL.createObjectURL(blob)
         const a = document.createElement('a')
         a.href = url
-        a.download = `orders_${new Date().toISOString().slice(0, 10)}.xlsx`
+        a.download = `orders_${new Date().toISOString().slice(0, 10)}.${options.format}`
         document.body.appendChild(a)
         a.click()
         document.body.removeChild(a)
         window.URL.revokeObjectURL(url)
 
+        setExportOpen(false)
         toast.success(t.admin.exportSuccess)
       })
       .catch((err) => {
@@ -229,6 +250,16 @@ function AdminOrdersContent() {
       })
   }
 
+  const handlePrintTodayShipments = () => {
+    openPackingSlip(
+      getDailyPackingSlipsPath(format(new Date(), 'yyyy-MM-dd')),
+      t,
+      t.admin.packingSlipFailed
+    ).catch((err) => {
+      toast.error(err.message || t.admin.packingSlipFailed)
+    })
+  }
+
   const handleDownloadTemplate = () => {
     const url = resolveClientAPIProxyURL('/api/admin/orders/import-template')
 
@@ -332,6 +363,22 @@ function AdminOrdersContent() {
     },
   })
 
+  // 打开确认框时先试运行，展示实际会被修改和会被跳过的订单
+  const batchPreviewIds = Array.from(selectedIds).sort((left, right) => left - right)
+  const {
+    data: batchPreviewData,
+    isFetching: batchPreviewLoading,
+    error: batchPreviewError,
+  } = useQuery({
+    queryKey: ['adminOrdersBatchPreview', batchAction, batchPreviewIds],
+    queryFn: () => previewBatchUpdateOrders(batchPreviewIds, batchAction),
+    enabled: batchDialogOpen && !!batchAction && batchPreviewIds.length > 0,
+    staleTime: 0,
+    retry: false,
+  })
+  const batchPreview: BatchOrderPreview | undefined = batchPreviewData?.data
+  const batchPreviewSkipped = (batchPreview?.orders || []).filter((item) => !item.applicable)
+
   const handleBatchAction = (action: string) => {
     setBatchAction(action)
     setBatchDialogOpen(true)
@@ -592,6 +639,12 @@ function AdminOrdersContent() {
       <div className="flex items-center justify-between">
         <h1 className="text-3xl font-bold">{t.admin.orderManagement}</h1>
         <div className="flex gap-2">
+          <Button size="sm" asChild>
+            <Link href="/admin/orders/new">
+              <Plus className="mr-2 h-4 w-4" />
+              {t.admin.manualOrder}
+            </Link>
+          </Button>
           <Button variant="outline" size="sm" onClick={handleDownloadTemplate}>
             <FileDown className="mr-2 h-4 w-4" />
             {t.admin.downloadTemplate}
@@ -607,10 +660,32 @@ function AdminOrdersContent() {
             onChange={handleFileChange}
             style={{ display: 'none' }}
           />
-          <Button variant="outline" size="sm" onClick={handleExport}>
+          <Button variant="outline" size="sm" onClick={() => setExportOpen(true)}>
             <Download className="mr-2 h-4 w-4" />
             {t.admin.exportOrders}
           </Button>
+          <Button variant="outline" size="sm" onClick={handlePrintTodayShipments}>
+            <Printer className="mr-2 h-4 w-4" />
+            {t.admin.printTodayShipments}
+          </Button>
+          <Button variant="outline" size="sm" asChild>
+            <Link href="/admin/orders/settlement">
+              <Landmark className="mr-2 h-4 w-4" />
+              {t.admin.settlementReport}
+            </Link>
+          </Button>
+          <Button variant="outline" size="sm" asChild>
+            <Link href="/admin/orders/revenue">
+              <CalendarRange className="mr-2 h-4 w-4" />
+              {t.admin.revenueRecognition}
+            </Link>
+          </Button>
+          <Button variant="outline" size="sm" asChild>
+            <Link href="/admin/orders/accounting">
+              <BookText className="mr-2 h-4 w-4" />
+              {t.admin.accountingExport}
+            </Link>
+          </Button>
           <Button variant="outline" size="sm" onClick={() => refetch()}>
             <RefreshCw className="mr-2 h-4 w-4" />
             {t.admin.refresh}
@@ -623,6 +698,13 @@ function AdminOrdersContent() {
         </div>
       </div>
 
+      <OrderExportDialog
+        open={exportOpen}
+        onOpenChange={setExportOpen}
+        defaultFormat="xlsx"
+        onExport={handleExport}
+      />
+
       <OrderFilter
         status={status}
         search={search}
@@ -727,6 +809,9 @@ function AdminOrdersContent() {
           </div>
         </div>
       )}
+      <RefundRequestPanel />
+      <OrderPartialRefundPanel />
+      <OrderPriceAdjustmentPanel />
       <PluginSlot slot="admin.orders.before_table" context={adminOrdersPluginContext} />
 
       <DataTable
@@ -784,11 +869,49 @@ function AdminOrdersContent() {
                 </div>
               </div>
             ) : null}
+            <div className="rounded-md border border-input/60 bg-muted/10 p-3 text-sm">
+              {batchPreviewError ? (
+                <p className="text-destructive">
+                  {resolveApiErrorMessage(batchPreviewError, t, t.admin.batchPreviewFailed)}
+                </p>
+              ) : batchPreviewLoading || !batchPreview ? (
+                <p className="text-muted-foreground">{t.admin.batchPreviewLoading}</p>
+              ) : (
+                <div className="space-y-2">
+                  <p>
+                    {t.admin.batchPreviewSummary
+                      .replace('{affected}', String(batchPreview.affected_count))
+                      .replace('{skipped}', String(batchPreview.skipped_count))}
+                  </p>
+                  {batchPreviewSkipped.slice(0, 5).map((item) => (
+                    <p key={item.order_id} className="text-xs text-muted-foreground">
+                      <span className="font-mono">{item.order_no || `#${item.order_id}`}</span>
+                      {': '}
+                      {translateBizError(
+                        t,
+                        item.error?.key || '',
+                        item.error?.params,
+                        item.error?.message
+                      )}
+                    </p>
+                  ))}
+                  {batchPreviewSkipped.length > 5 ? (
+                    <p className="text-xs text-muted-foreground">
+                      {t.admin.batchSelectionPreviewMore.replace(
+                        '{count}',
+                        String(batchPreviewSkipped.length - 5)
+                      )}
+                    </p>
+                  ) : null}
+                </div>
+              )}
+            </div>
           </div>
           <AlertDialogFooter>
             <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
             <AlertDialogAction
               onClick={confirmBatchAction}
+              disabled={batchPreview?.affected_count === 0}
               className={
                 batchAction === 'delete'
                   ? 'bg-destructive text-destructive-foreground hover:bg-destructive/90'
@@ -0,0 +1,212 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Download, History, Loader2 } from 'lucide-react'
+import {
+  backfillRevenueSchedules,
+  getRevenueRecognitionReport,
+  RevenueRecognitionReport,
+  RevenueRecognitionRow,
+} from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Card, CardContent } from '@/components/ui/card'
+import { useLocale } from '@/hooks/use-locale'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+function formatMonthInput(date: Date) {
+  return date.toISOString().slice(0, 7)
+}
+
+export default function AdminRevenueRecognitionPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminRevenueRecognition)
+  const queryClient = useQueryClient()
+  const { hasPermission } = usePermission()
+  const canBackfill = hasPermission('system.config')
+
+  const [toMonth, setToMonth] = useState(() => formatMonthInput(new Date()))
+  const [fromMonth, setFromMonth] = useState(() => {
+    const now = new Date()
+    return formatMonthInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth() - 11, 1)))
+  })
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['revenueRecognitionReport', fromMonth, toMonth],
+    queryFn: () => getRevenueRecognitionReport({ from: fromMonth, to: toMonth }),
+    enabled: !!fromMonth && !!toMonth,
+  })
+  const report: RevenueRecognitionReport | undefined = data?.data
+  const rows: RevenueRecognitionRow[] = report?.rows || []
+
+  const backfillMutation = useMutation({
+    mutationFn: () => backfillRevenueSchedules(fromMonth),
+    onSuccess: (res) => {
+      toast.success(
+        t.admin.revenueBackfillSuccess.replace('{count}', String(res?.data?.created || 0))
+      )
+      queryClient.invalidateQueries({ queryKey: ['revenueRecognitionReport'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.revenueBackfillFailed))
+    },
+  })
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExport = () => {
+    const params = new URLSearchParams({ from: fromMonth, to: toMonth })
+    const url = resolveClientAPIProxyURL(`/api/admin/reports/revenue-recognition/export?${params}`)
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `revenue_recognition_${fromMonth}_${toMonth}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+        toast.success(t.admin.revenueExportSuccess)
+      })
+      .catch((err) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const money = (amount: number, currency: string) => formatCurrency(amount, currency)
+
+  const columns = [
+    {
+      header: t.admin.settlementMonth,
+      accessorKey: 'period',
+    },
+    {
+      header: t.admin.settlementCurrency,
+      accessorKey: 'currency',
+    },
+    {
+      header: t.admin.revenueType,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) =>
+        row.original.type === 'prepaid_credit'
+          ? t.admin.revenueTypePrepaidCredit
+          : t.admin.revenueTypeSubscription,
+    },
+    {
+      header: t.admin.revenueOpening,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) =>
+        money(row.original.deferred_opening_minor, row.original.currency),
+    },
+    {
+      header: t.admin.revenueBilled,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) => {
+        if (row.original.schedule_count === 0) return '-'
+        const amount = money(row.original.billed_amount_minor, row.original.currency)
+        return `${amount} (${row.original.schedule_count})`
+      },
+    },
+    {
+      header: t.admin.revenueRecognized,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) => (
+        <span className="font-medium">
+          {money(row.original.recognized_amount_minor, row.original.currency)}
+        </span>
+      ),
+    },
+    {
+      header: t.admin.revenueReleased,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) =>
+        row.original.released_amount_minor === 0
+          ? '-'
+          : money(row.original.released_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.revenueClosing,
+      cell: ({ row }: { row: { original: RevenueRecognitionRow } }) =>
+        money(row.original.deferred_closing_minor, row.original.currency),
+    },
+  ]
+
+  return (
+    <div className="space-y-4 p-4">
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div className="flex items-center gap-2">
+          <Button variant="outline" size="icon" asChild className="h-8 w-8">
+            <Link href="/admin/orders">
+              <ArrowLeft className="h-4 w-4" />
+              <span className="sr-only">{t.admin.orderManagement}</span>
+            </Link>
+          </Button>
+          <div>
+            <h1 className="text-xl font-bold">{t.admin.revenueRecognition}</h1>
+            <p className="text-sm text-muted-foreground">{t.admin.revenueRecognitionDesc}</p>
+          </div>
+        </div>
+        <div className="flex items-center gap-2">
+          {canBackfill && (
+            <Button
+              variant="outline"
+              disabled={backfillMutation.isPending || !fromMonth}
+              onClick={() => backfillMutation.mutate()}
+              title={t.admin.revenueBackfillHint}
+            >
+              {backfillMutation.isPending ? (
+                <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+              ) : (
+                <History className="mr-2 h-4 w-4" />
+              )}
+              {t.admin.revenueBackfill}
+            </Button>
+          )}
+          <Button variant="outline" onClick={handleExport}>
+            <Download className="mr-2 h-4 w-4" />
+            {t.admin.settlementExport}
+          </Button>
+        </div>
+      </div>
+
+      <Card>
+        <CardContent className="flex flex-col gap-3 pt-6 md:flex-row md:items-end">
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.settlementFromMonth}</label>
+            <Input type="month" value={fromMonth} onChange={(e) => setFromMonth(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.settlementToMonth}</label>
+            <Input type="month" value={toMonth} onChange={(e) => setToMonth(e.target.value)} />
+          </div>
+        </CardContent>
+      </Card>
+
+      {!isLoading && rows.length === 0 ? (
+        <p className="py-8 text-center text-sm text-muted-foreground">{t.admin.revenueNoData}</p>
+      ) : (
+        <DataTable columns={columns} data={rows} isLoading={isLoading} />
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,196 @@
+'use client'
+
+import { useState } from 'react'
+import { useQuery } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Download } from 'lucide-react'
+import { getFXSettlementReport, FXSettlementReport, FXSettlementRow } from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Card, CardContent } from '@/components/ui/card'
+import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+type ExportType = 'summary' | 'orders'
+
+function formatMonthInput(date: Date) {
+  return date.toISOString().slice(0, 7)
+}
+
+export default function AdminSettlementReportPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminSettlementReport)
+
+  const [toMonth, setToMonth] = useState(() => formatMonthInput(new Date()))
+  const [fromMonth, setFromMonth] = useState(() => {
+    const now = new Date()
+    return formatMonthInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth() - 11, 1)))
+  })
+  const [exportType, setExportType] = useState<ExportType>('summary')
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['fxSettlementReport', fromMonth, toMonth],
+    queryFn: () => getFXSettlementReport({ from: fromMonth, to: toMonth }),
+    enabled: !!fromMonth && !!toMonth,
+  })
+  const report: FXSettlementReport | undefined = data?.data
+  const rows: FXSettlementRow[] = report?.rows || []
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExport = () => {
+    const params = new URLSearchParams({ from: fromMonth, to: toMonth, type: exportType })
+    const url = resolveClientAPIProxyURL(`/api/admin/reports/fx-settlement/export?${params}`)
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download =
+          exportType === 'orders'
+            ? `fx_settlement_orders_${fromMonth}_${toMonth}.csv`
+            : `fx_settlement_${fromMonth}_${toMonth}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+        toast.success(t.admin.settlementExportSuccess)
+      })
+      .catch((err) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const columns = [
+    {
+      header: t.admin.settlementMonth,
+      accessorKey: 'month',
+    },
+    {
+      header: t.admin.settlementCurrency,
+      accessorKey: 'currency',
+    },
+    {
+      header: t.admin.settlementGateway,
+      cell: ({ row }: { row: { original: FXSettlementRow } }) =>
+        row.original.gateway || (
+          <span className="text-muted-foreground">{t.admin.settlementManualGateway}</span>
+        ),
+    },
+    {
+      header: t.admin.settlementOrderCount,
+      accessorKey: 'order_count',
+    },
+    {
+      header: t.admin.settlementGross,
+      cell: ({ row }: { row: { original: FXSettlementRow } }) =>
+        formatCurrency(row.original.gross_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.settlementRefunded,
+      cell: ({ row }: { row: { original: FXSettlementRow } }) => {
+        if (row.original.refunded_count === 0) return '-'
+        const amount = formatCurrency(row.original.refunded_amount_minor, row.original.currency)
+        return `${amount} (${row.original.refunded_count})`
+      },
+    },
+    {
+      header: t.admin.settlementBaseAmount,
+      cell: ({ row }: { row: { original: FXSettlementRow } }) =>
+        formatCurrency(row.original.base_amount_minor, row.original.base_currency),
+    },
+    {
+      header: t.admin.settlementNetBaseAmount,
+      cell: ({ row }: { row: { original: FXSettlementRow } }) => (
+        <div className="flex items-center gap-2">
+          <span className="font-medium">
+            {formatCurrency(row.original.net_base_amount_minor, row.original.base_currency)}
+          </span>
+          {row.original.unconverted_count > 0 ? (
+            <Badge variant="destructive">
+              {t.admin.settlementUnconverted.replace(
+                '{count}',
+                String(row.original.unconverted_count)
+              )}
+            </Badge>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  return (
+    <div className="space-y-4 p-4">
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div className="flex items-center gap-2">
+          <Button variant="outline" size="icon" asChild className="h-8 w-8">
+            <Link href="/admin/orders">
+              <ArrowLeft className="h-4 w-4" />
+              <span className="sr-only">{t.admin.orderManagement}</span>
+            </Link>
+          </Button>
+          <div>
+            <h1 className="text-xl font-bold">{t.admin.settlementReport}</h1>
+            <p className="text-sm text-muted-foreground">
+              {t.admin.settlementReportDesc.replace('{currency}', report?.base_currency || '-')}
+            </p>
+          </div>
+        </div>
+        <div className="flex items-center gap-2">
+          <Tabs value={exportType} onValueChange={(value) => setExportType(value as ExportType)}>
+            <TabsList>
+              <TabsTrigger value="summary">{t.admin.settlementExportSummary}</TabsTrigger>
+              <TabsTrigger value="orders">{t.admin.settlementExportOrders}</TabsTrigger>
+            </TabsList>
+          </Tabs>
+          <Button variant="outline" onClick={handleExport}>
+            <Download className="mr-2 h-4 w-4" />
+            {t.admin.settlementExport}
+          </Button>
+        </div>
+      </div>
+
+      <Card>
+        <CardContent className="flex flex-col gap-3 pt-6 md:flex-row md:items-end">
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.settlementFromMonth}</label>
+            <Input type="month" value={fromMonth} onChange={(e) => setFromMonth(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.admin.settlementToMonth}</label>
+            <Input type="month" value={toMonth} onChange={(e) => setToMonth(e.target.value)} />
+          </div>
+        </CardContent>
+      </Card>
+
+      {!isLoading && rows.length === 0 ? (
+        <p className="py-8 text-center text-sm text-muted-foreground">{t.admin.settlementNoData}</p>
+      ) : (
+        <DataTable columns={columns} data={rows} isLoading={isLoading} />
+      )}
+    </div>
+  )
+}
@@ -21,6 +21,7 @@ import {
   previewPaymentMethodPackage,
   importPaymentMethodPackageFromMarket,
   uploadPaymentMethodPackage,
+  getPublicConfig,
 } from '@/lib/api'
 import { Card, CardContent, CardHeader, CardTitle, CardDescription } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
@@ -72,6 +73,7 @@ import {
   FileUp,
   Loader2,
   Package,
+  Percent,
 } from 'lucide-react'
 import toast from 'react-hot-toast'
 import { useLocale } from '@/hooks/use-locale'
@@ -85,6 +87,8 @@ import {
   findAdminMarketPluginBasePath,
 } from '@/lib/plugin-market-route'
 import { PaymentMethodWebhookPanel } from '@/components/admin/payment-method-webhook-panel'
+import { PaymentMethodFeeDialog } from '@/components/admin/payment-method-fee-dialog'
+import { formatPaymentFeeRule } from '@/lib/payment-fee'
 import { PluginJSONObjectEditor } from '@/components/admin/plugins/plugin-json-object-editor'
 import { PluginJSONSchemaEditor } from '@/components/admin/plugins/plugin-json-schema-editor'
 import { ConfigEditor } from '@/components/admin/config-editor'
@@ -254,6 +258,7 @@ export default function PaymentMethodsPage() {
   const [isCreateOpen, setIsCreateOpen] = useState(false)
   const [isImportOpen, setIsImportOpen] = useState(false)
   const [deleteId, setDeleteId] = useState<number | null>(null)
+  const [feeMethod, setFeeMethod] = useState<PaymentMethod | null>(null)
   const [testResult, setTestResult] = useState<string | null>(null)
   const configFlushRef = useRef<(() => string | null) | null>(null)
   const packageFileInputRef = useRef<HTMLInputElement | null>(null)
@@ -329,6 +334,12 @@ export default function PaymentMethodsPage() {
   })
 
   const methods = data?.data?.items || []
+  const { data: publicConfigData } = useQuery({
+    queryKey: ['publicConfig'],
+    queryFn: getPublicConfig,
+    staleTime: 5 * 60 * 1000,
+  })
+  const currency = publicConfigData?.data?.currency || 'CNY'
   const deleteMethod =
     deleteId !== null
       ? methods.find((method: PaymentMethod) => method.id === deleteId) || null
@@ -970,6 +981,7 @@ export default function PaymentMethodsPage() {
         ) : (
           methods.map((method: PaymentMethod, index: number) => {
             const rowExtensions = adminPaymentMethodActionExtensions[String(method.id)] || []
+            const feeRule = formatPaymentFeeRule(method.fee_rate, method.fee_fixed_minor, currency)
             return (
               <Card
                 key={method.id}
@@ -1023,6 +1035,7 @@ export default function PaymentMethodsPage() {
                     <div className="min-w-0 flex-1">
                       <div className="flex flex-wrap items-center gap-2">
                         <h3 className="font-semibold">{method.name}</h3>
+                        {feeRule ? <Badge variant="outline">{feeRule}</Badge> : null}
                       </div>
                       <p className="truncate text-sm text-muted-foreground">{method.description}</p>
                       <p className="mt-1 truncate text-xs text-muted-foreground">
@@ -1047,6 +1060,15 @@ export default function PaymentMethodsPage() {
                           onCheckedChange={() => toggleMutation.mutate(method.id)}
                         />
                       </div>
+                      <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => setFeeMethod(method)}
+                        aria-label={t.admin.pmFeeEdit}
+                        title={t.admin.pmFeeEdit}
+                      >
+                        <Percent className="h-4 w-4" />
+                      </Button>
                       <Button variant="outline" size="sm" onClick={() => openEdit(method)}>
                         <Pencil className="h-4 w-4" />
                       </Button>
@@ -1076,6 +1098,12 @@ export default function PaymentMethodsPage() {
         )}
       </div>
 
+      <PaymentMethodFeeDialog
+        method={feeMethod}
+        currency={currency}
+        onClose={() => setFeeMethod(null)}
+      />
+
       {/* 创建/编辑对话框 */}
       <Dialog
         open={isCreateOpen || !!editingMethod}
@@ -1355,6 +1383,20 @@ export default function PaymentMethodsPage() {
                       <code>jsonEncode(data)</code> / <code>jsonDecode(data)</code>
                     </p>
                   </div>
+                  <div>
+                    <p className="mb-1 font-semibold">AuraLogic.crypto</p>
+                    <p>
+                      <code>md5/sha1/sha256/sha512(data, encoding?)</code>
+                    </p>
+                    <p>
+                      <code>hmac(algorithm, key, data, options?)</code> /{' '}
+                      <code>timingSafeEqual(a, b)</code>
+                    </p>
+                    <p>
+                      <code>base64UrlEncode(data)</code> / <code>hexEncode(data)</code>
+                    </p>
+                    <p className="text-muted-foreground">{t.admin.scriptCryptoApiDesc}</p>
+                  </div>
                   <div>
                     <p className="mb-1 font-semibold">AuraLogic.http</p>
                     <p>
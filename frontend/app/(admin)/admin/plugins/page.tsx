@@ -209,6 +209,8 @@ const DEFAULT_PLUGIN_HOOK_GROUPS: Record<PluginHookGroupKey, string[]> = {
     'order.admin.delete.after',
     'order.auto_cancel.before',
     'order.auto_cancel.after',
+    'order.auto_complete.before',
+    'order.auto_complete.after',
   ],
   payment: [
     'payment.method.select.before',
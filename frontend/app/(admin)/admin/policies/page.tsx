@@ -0,0 +1,236 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Loader2, Plus } from 'lucide-react'
+import {
+  getPolicyVersions,
+  publishPolicyVersion,
+  type AdminPolicyVersion,
+  type PolicyType,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { getTranslations } from '@/lib/i18n'
+
+const POLICY_TYPES: PolicyType[] = ['terms', 'privacy']
+
+const emptyForm = { type: 'terms' as PolicyType, version: '', title: '', content: '', summary: '' }
+
+export default function AdminPoliciesPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminPolicies)
+
+  const [type, setType] = useState<'all' | PolicyType>('all')
+  const [publishOpen, setPublishOpen] = useState(false)
+  const [form, setForm] = useState(emptyForm)
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['adminPolicyVersions', type],
+    queryFn: () => getPolicyVersions(type === 'all' ? undefined : type),
+  })
+  const versions: AdminPolicyVersion[] = data?.data || []
+
+  const publishMutation = useMutation({
+    mutationFn: () =>
+      publishPolicyVersion({
+        type: form.type,
+        version: form.version.trim(),
+        title: form.title.trim(),
+        content: form.content,
+        summary: form.summary.trim() || undefined,
+      }),
+    onSuccess: () => {
+      toast.success(t.consent.published)
+      setPublishOpen(false)
+      queryClient.invalidateQueries({ queryKey: ['adminPolicyVersions'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.consent.publishFailed))
+    },
+  })
+
+  const columns = [
+    {
+      header: t.consent.policyType,
+      cell: ({ row }: { row: { original: AdminPolicyVersion } }) =>
+        t.consent.types[row.original.type] || row.original.type,
+    },
+    {
+      header: t.consent.titleLabel,
+      cell: ({ row }: { row: { original: AdminPolicyVersion } }) => (
+        <div className="max-w-[320px]">
+          <div className="text-sm">{row.original.title}</div>
+          {row.original.summary ? (
+            <p className="line-clamp-2 text-xs text-muted-foreground">{row.original.summary}</p>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.consent.version,
+      cell: ({ row }: { row: { original: AdminPolicyVersion } }) => (
+        <div className="flex items-center gap-2">
+          <span className="font-mono text-sm">{row.original.version}</span>
+          {row.original.is_current ? <Badge variant="secondary">{t.consent.current}</Badge> : null}
+        </div>
+      ),
+    },
+    {
+      header: t.consent.acceptedCount,
+      cell: ({ row }: { row: { original: AdminPolicyVersion } }) => row.original.accepted_count,
+    },
+    {
+      header: t.consent.publishedAt,
+      cell: ({ row }: { row: { original: AdminPolicyVersion } }) =>
+        new Date(row.original.created_at).toLocaleString(),
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-col gap-3 md:flex-row md:items-start md:justify-between">
+        <div>
+          <h1 className="text-3xl font-bold">{t.consent.management}</h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.consent.managementDesc}</p>
+        </div>
+        <Button
+          onClick={() => {
+            setForm(emptyForm)
+            setPublishOpen(true)
+          }}
+        >
+          <Plus className="mr-1.5 h-4 w-4" />
+          {t.consent.publish}
+        </Button>
+      </div>
+
+      <Select value={type} onValueChange={(value) => setType(value as 'all' | PolicyType)}>
+        <SelectTrigger className="w-[180px]">
+          <SelectValue />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value="all">{t.common.all}</SelectItem>
+          {POLICY_TYPES.map((value) => (
+            <SelectItem key={value} value={value}>
+              {t.consent.types[value]}
+            </SelectItem>
+          ))}
+        </SelectContent>
+      </Select>
+      <DataTable columns={columns} data={versions} isLoading={isLoading} />
+
+      <Dialog open={publishOpen} onOpenChange={setPublishOpen}>
+        <DialogContent className="max-w-2xl">
+          <DialogHeader>
+            <DialogTitle>{t.consent.publish}</DialogTitle>
+            <DialogDescription>{t.consent.publishHint}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="grid gap-4 sm:grid-cols-2">
+              <div className="space-y-1.5">
+                <Label>{t.consent.policyType}</Label>
+                <Select
+                  value={form.type}
+                  onValueChange={(value) => setForm({ ...form, type: value as PolicyType })}
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {POLICY_TYPES.map((value) => (
+                      <SelectItem key={value} value={value}>
+                        {t.consent.types[value]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-1.5">
+                <Label htmlFor="policy_version">{t.consent.version}</Label>
+                <Input
+                  id="policy_version"
+                  maxLength={50}
+                  placeholder="2026-10"
+                  value={form.version}
+                  onChange={(e) => setForm({ ...form, version: e.target.value })}
+                />
+              </div>
+            </div>
+            <div className="space-y-1.5">
+              <Label htmlFor="policy_title">{t.consent.titleLabel}</Label>
+              <Input
+                id="policy_title"
+                maxLength={255}
+                value={form.title}
+                onChange={(e) => setForm({ ...form, title: e.target.value })}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label htmlFor="policy_summary">{t.consent.summary}</Label>
+              <Textarea
+                id="policy_summary"
+                rows={2}
+                maxLength={1000}
+                value={form.summary}
+                onChange={(e) => setForm({ ...form, summary: e.target.value })}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label htmlFor="policy_content">{t.consent.content}</Label>
+              <Textarea
+                id="policy_content"
+                rows={10}
+                className="font-mono text-xs"
+                value={form.content}
+                onChange={(e) => setForm({ ...form, content: e.target.value })}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setPublishOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              disabled={
+                publishMutation.isPending ||
+                !form.version.trim() ||
+                !form.title.trim() ||
+                !form.content.trim()
+              }
+              onClick={() => publishMutation.mutate()}
+            >
+              {publishMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.consent.publish}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
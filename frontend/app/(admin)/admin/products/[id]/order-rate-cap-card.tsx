@@ -0,0 +1,92 @@
+'use client'
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Gauge } from 'lucide-react'
+import {
+  getProductOrderRateCap,
+  updateProductOrderRateCap,
+  type OrderRateCapSettings,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { Badge } from '@/components/ui/badge'
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+// 下单频率限制设置：按用户/IP/设备指纹限制每小时下单次数
+export function ProductOrderRateCapCard({ productId }: { productId: number }) {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({
+    queryKey: ['productOrderRateCap', productId],
+    queryFn: () => getProductOrderRateCap(productId),
+  })
+  const settings: OrderRateCapSettings | undefined = data?.data
+
+  const updateMutation = useMutation({
+    mutationFn: (enabled: boolean) => updateProductOrderRateCap(productId, enabled),
+    onSuccess: () => {
+      toast.success(t.admin.productOrderRateCapUpdated)
+      queryClient.invalidateQueries({ queryKey: ['productOrderRateCap', productId] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.productOrderRateCapUpdateFailed))
+    },
+  })
+
+  const formatLimit = (value: number) =>
+    value > 0 ? String(value) : t.admin.productOrderRateCapNoLimit
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Gauge className="h-5 w-5" />
+          {t.admin.productOrderRateCap}
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex items-center space-x-2">
+          <Switch
+            id="order_rate_cap"
+            checked={Boolean(settings?.order_rate_cap)}
+            disabled={!settings || updateMutation.isPending}
+            onCheckedChange={(checked) => updateMutation.mutate(checked)}
+          />
+          <Label htmlFor="order_rate_cap">{t.admin.productOrderRateCapEnable}</Label>
+        </div>
+        <p className="text-sm text-muted-foreground">{t.admin.productOrderRateCapHint}</p>
+        {settings?.order_rate_cap && !settings.active && (
+          <Badge variant="outline">{t.admin.productOrderRateCapInactive}</Badge>
+        )}
+        {settings?.order_rate_cap && settings.active && (
+          <div className="flex flex-wrap gap-2">
+            <Badge variant="secondary">
+              {t.admin.productOrderRateCapPerUser.replace(
+                '{limit}',
+                formatLimit(settings.per_user_hourly)
+              )}
+            </Badge>
+            <Badge variant="secondary">
+              {t.admin.productOrderRateCapPerIP.replace(
+                '{limit}',
+                formatLimit(settings.per_ip_hourly)
+              )}
+            </Badge>
+            <Badge variant="secondary">
+              {t.admin.productOrderRateCapPerDevice.replace(
+                '{limit}',
+                formatLimit(settings.per_device_hourly)
+              )}
+            </Badge>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -94,6 +94,11 @@ import { usePageTitle } from '@/hooks/use-page-title'
 import { MarkdownMessage } from '@/components/ui/markdown-message'
 import { resolveApiErrorMessage } from '@/lib/api-error'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { ProductWaitingRoomCard } from './waiting-room-card'
+import { ProductTrialCard } from './trial-card'
+import { ProductOrderRateCapCard } from './order-rate-cap-card'
+import { ProductVendorCard } from './vendor-card'
+import { ProductRevenueRecognitionCard } from './revenue-recognition-card'
 
 // 虚拟库存绑定卡片组件
 function VirtualInventoryBindingCard({
@@ -1672,6 +1677,25 @@ export default function ProductEditPage() {
           </CardContent>
         </Card>
 
+        {!isNew && productId !== null && <ProductWaitingRoomCard productId={productId} />}
+        {!isNew && productId !== null && <ProductOrderRateCapCard productId={productId} />}
+        {!isNew && productId !== null && (
+          <ProductVendorCard
+            productId={productId}
+            vendorId={productData?.data?.vendor_id ?? null}
+          />
+        )}
+        {!isNew && productId !== null && form.product_type === 'virtual' && (
+          <ProductTrialCard productId={productId} />
+        )}
+        {!isNew && productId !== null && form.product_type === 'virtual' && (
+          <ProductRevenueRecognitionCard
+            productId={productId}
+            recognition={productData?.data?.revenue_recognition ?? ''}
+            servicePeriodMonths={productData?.data?.service_period_months ?? 0}
+          />
+        )}
+
         {/* 规格与库存配置：根据商品类型显示不同的界面 */}
         {/* 只有在表单数据加载完成后才渲染，避免用空数据初始化 */}
         {isFormDataLoaded && form.product_type === 'physical' && (
@@ -0,0 +1,114 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { CalendarRange } from 'lucide-react'
+import { updateProductRevenueRecognition, type RevenueRecognitionType } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+const AT_SALE_VALUE = 'at_sale'
+
+// 虚拟商品收入确认方式：订阅或预付额度在服务期内按月确认，只影响之后的订单
+export function ProductRevenueRecognitionCard({
+  productId,
+  recognition,
+  servicePeriodMonths,
+}: {
+  productId: number
+  recognition: RevenueRecognitionType
+  servicePeriodMonths: number
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [type, setType] = useState<RevenueRecognitionType>(recognition)
+  const [months, setMonths] = useState(servicePeriodMonths > 0 ? String(servicePeriodMonths) : '12')
+
+  useEffect(() => {
+    setType(recognition)
+    setMonths(servicePeriodMonths > 0 ? String(servicePeriodMonths) : '12')
+  }, [recognition, servicePeriodMonths])
+
+  const updateMutation = useMutation({
+    mutationFn: () =>
+      updateProductRevenueRecognition(productId, {
+        revenue_recognition: type,
+        service_period_months: type ? Number(months) || 0 : 0,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.productRevenueUpdated)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.productRevenueUpdateFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <CalendarRange className="h-5 w-5" />
+          {t.admin.productRevenueRecognition}
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="flex flex-col gap-3 md:flex-row md:items-end">
+          <div className="space-y-1.5">
+            <Label htmlFor="revenue_recognition">{t.admin.revenueType}</Label>
+            <Select
+              value={type || AT_SALE_VALUE}
+              onValueChange={(value) =>
+                setType(value === AT_SALE_VALUE ? '' : (value as RevenueRecognitionType))
+              }
+            >
+              <SelectTrigger id="revenue_recognition" className="w-full md:w-64">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value={AT_SALE_VALUE}>{t.admin.revenueTypeAtSale}</SelectItem>
+                <SelectItem value="subscription">{t.admin.revenueTypeSubscription}</SelectItem>
+                <SelectItem value="prepaid_credit">{t.admin.revenueTypePrepaidCredit}</SelectItem>
+              </SelectContent>
+            </Select>
+          </div>
+          {type && (
+            <div className="space-y-1.5">
+              <Label htmlFor="service_period_months">{t.admin.productServicePeriodMonths}</Label>
+              <Input
+                id="service_period_months"
+                type="number"
+                min={1}
+                max={120}
+                className="w-full md:w-32"
+                value={months}
+                onChange={(e) => setMonths(e.target.value)}
+              />
+            </div>
+          )}
+          <Button
+            type="button"
+            variant="outline"
+            disabled={updateMutation.isPending}
+            onClick={() => updateMutation.mutate()}
+          >
+            {t.common.save}
+          </Button>
+        </div>
+        <p className="text-sm text-muted-foreground">{t.admin.productRevenueHint}</p>
+      </CardContent>
+    </Card>
+  )
+}
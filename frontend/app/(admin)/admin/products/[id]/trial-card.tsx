@@ -0,0 +1,138 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Hourglass } from 'lucide-react'
+import {
+  getProductTrial,
+  getProductTrials,
+  updateProductTrial,
+  type ProductTrial,
+  type ProductTrialStats,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+// 虚拟商品免费试用：设置试用天数，查看试用转正情况与最近的试用记录
+export function ProductTrialCard({ productId }: { productId: number }) {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [days, setDays] = useState('0')
+
+  const { data } = useQuery({
+    queryKey: ['productTrial', productId],
+    queryFn: () => getProductTrial(productId),
+  })
+  const stats: ProductTrialStats | undefined = data?.data
+
+  const { data: trialsData } = useQuery({
+    queryKey: ['productTrials', productId],
+    queryFn: () => getProductTrials({ product_id: productId, page: 1, limit: 5 }),
+  })
+  const trials: ProductTrial[] = trialsData?.data?.items || []
+
+  useEffect(() => {
+    if (stats) setDays(String(stats.trial_days))
+  }, [stats])
+
+  const statusLabels: Record<ProductTrial['status'], string> = {
+    active: t.admin.productTrialStatusActive,
+    converted: t.admin.productTrialStatusConverted,
+    expired: t.admin.productTrialStatusExpired,
+  }
+
+  const updateMutation = useMutation({
+    mutationFn: () => updateProductTrial(productId, Number(days) || 0),
+    onSuccess: () => {
+      toast.success(t.admin.productTrialUpdated)
+      queryClient.invalidateQueries({ queryKey: ['productTrial', productId] })
+      queryClient.invalidateQueries({ queryKey: ['adminProduct', productId] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.productTrialUpdateFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Hourglass className="h-5 w-5" />
+          {t.admin.productTrial}
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="flex flex-col gap-3 md:flex-row md:items-end">
+          <div className="space-y-1.5">
+            <Label htmlFor="trial_days">{t.admin.productTrialDays}</Label>
+            <Input
+              id="trial_days"
+              type="number"
+              min={0}
+              max={365}
+              className="w-full md:w-32"
+              value={days}
+              onChange={(e) => setDays(e.target.value)}
+            />
+          </div>
+          <Button
+            type="button"
+            variant="outline"
+            disabled={!stats || updateMutation.isPending}
+            onClick={() => updateMutation.mutate()}
+          >
+            {t.common.save}
+          </Button>
+        </div>
+        <p className="text-sm text-muted-foreground">{t.admin.productTrialHint}</p>
+        {stats && stats.active + stats.converted + stats.expired > 0 && (
+          <div className="flex flex-wrap gap-2">
+            <Badge variant="secondary">
+              {t.admin.productTrialActiveCount.replace('{count}', String(stats.active))}
+            </Badge>
+            <Badge variant="secondary">
+              {t.admin.productTrialConvertedCount.replace('{count}', String(stats.converted))}
+            </Badge>
+            <Badge variant="secondary">
+              {t.admin.productTrialExpiredCount.replace('{count}', String(stats.expired))}
+            </Badge>
+          </div>
+        )}
+        {trials.length > 0 && (
+          <div className="space-y-2">
+            <p className="text-sm font-medium">{t.admin.productTrialRecent}</p>
+            {trials.map((trial) => (
+              <div
+                key={trial.id}
+                className="flex flex-wrap items-center gap-2 rounded-md border p-2 text-sm"
+              >
+                <Badge variant={trial.status === 'expired' ? 'outline' : 'secondary'}>
+                  {statusLabels[trial.status]}
+                </Badge>
+                <span>{t.admin.productTrialUser.replace('{id}', String(trial.user_id))}</span>
+                <span className="font-mono text-xs">{trial.order_no}</span>
+                {trial.conversion_order_no && (
+                  <span className="font-mono text-xs text-muted-foreground">
+                    → {trial.conversion_order_no}
+                  </span>
+                )}
+                <span className="text-xs text-muted-foreground">
+                  {t.admin.productTrialEnds.replace('{time}', formatDate(trial.expires_at))}
+                </span>
+              </div>
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
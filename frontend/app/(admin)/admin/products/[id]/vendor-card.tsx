@@ -0,0 +1,89 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation, useQuery } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Store } from 'lucide-react'
+import { getVendors, updateProductVendor, type Vendor } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+const PLATFORM_VALUE = 'platform'
+
+// 商品所属商家：付款后按商家佣金比例记入商家台账
+export function ProductVendorCard({
+  productId,
+  vendorId,
+}: {
+  productId: number
+  vendorId: number | null
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [selected, setSelected] = useState<number | null>(vendorId)
+
+  useEffect(() => {
+    setSelected(vendorId)
+  }, [vendorId])
+
+  const { data } = useQuery({
+    queryKey: ['vendors'],
+    queryFn: () => getVendors(),
+  })
+  const vendors: Vendor[] = data?.data?.items || []
+
+  const updateMutation = useMutation({
+    mutationFn: (next: number | null) => updateProductVendor(productId, next),
+    onSuccess: (_, next) => {
+      setSelected(next)
+      toast.success(t.admin.productVendorUpdated)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.productVendorUpdateFailed))
+    },
+  })
+
+  const formatCommission = (vendor: Vendor) => `${(vendor.commission_rate / 100).toFixed(2)}%`
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Store className="h-5 w-5" />
+          {t.admin.productVendor}
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        <Select
+          value={selected === null ? PLATFORM_VALUE : String(selected)}
+          disabled={updateMutation.isPending}
+          onValueChange={(value) =>
+            updateMutation.mutate(value === PLATFORM_VALUE ? null : Number(value))
+          }
+        >
+          <SelectTrigger className="w-full md:w-80">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value={PLATFORM_VALUE}>{t.admin.productVendorPlatform}</SelectItem>
+            {vendors.map((vendor) => (
+              <SelectItem key={vendor.id} value={String(vendor.id)}>
+                {vendor.name} ({formatCommission(vendor)})
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <p className="text-sm text-muted-foreground">{t.admin.productVendorHint}</p>
+      </CardContent>
+    </Card>
+  )
+}
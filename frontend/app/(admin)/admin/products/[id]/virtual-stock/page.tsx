@@ -8,6 +8,7 @@ import {
   getVirtualStockList,
   getVirtualStockStats,
   deleteVirtualStock,
+  revealVirtualStock,
   deleteStockBatch,
   getProductVirtualInventoryBindings,
 } from '@/lib/api'
@@ -86,7 +87,7 @@ export default function VirtualStockPage() {
   const [statusFilter, setStatusFilter] = useState<string>('all')
   const [deleteId, setDeleteId] = useState<number | null>(null)
   const [deleteBatchNo, setDeleteBatchNo] = useState<string | null>(null)
-  const [showContent, setShowContent] = useState<Record<number, boolean>>({})
+  const [revealed, setRevealed] = useState<Record<number, string>>({})
   const formatDeleteError = (error: unknown) =>
     t.virtualStock.deleteFailed.replace(
       '{msg}',
@@ -156,13 +157,37 @@ export default function VirtualStockPage() {
     },
   })
 
-  const copyToClipboard = (content: string) => {
+  // 列表只返回打码内容，明文需逐条 reveal（服务端记录审计日志）
+  const revealContent = async (id: number) => {
+    if (revealed[id] !== undefined) return revealed[id]
+    try {
+      const response: any = await revealVirtualStock(id)
+      const content: string = response?.data?.content ?? ''
+      setRevealed(prev => ({ ...prev, [id]: content }))
+      return content
+    } catch (error: unknown) {
+      toast.error(resolveApiErrorMessage(error, t, t.virtualStock.revealFailed))
+      return null
+    }
+  }
+
+  const copyToClipboard = async (id: number) => {
+    const content = await revealContent(id)
+    if (content === null) return
     navigator.clipboard.writeText(content)
     toast.success(t.virtualStock.copiedToClipboard)
   }
 
   const toggleContentVisibility = (id: number) => {
-    setShowContent(prev => ({ ...prev, [id]: !prev[id] }))
+    if (revealed[id] !== undefined) {
+      setRevealed(prev => {
+        const next = { ...prev }
+        delete next[id]
+        return next
+      })
+      return
+    }
+    revealContent(id)
   }
 
   const product = productData?.data
@@ -386,7 +411,7 @@ export default function VirtualStockPage() {
                     <TableCell>
                       <div className="flex items-center gap-2">
                         <code className="bg-muted px-2 py-1 rounded text-sm max-w-[200px] truncate">
-                          {showContent[stock.id] ? stock.content : '••••••••••'}
+                          {revealed[stock.id] ?? stock.content}
                         </code>
                         <Button
                           variant="ghost"
@@ -394,13 +419,13 @@ export default function VirtualStockPage() {
                           className="h-6 w-6 p-0"
                           onClick={() => toggleContentVisibility(stock.id)}
                         >
-                          {showContent[stock.id] ? <EyeOff className="w-3 h-3" /> : <Eye className="w-3 h-3" />}
+                          {revealed[stock.id] !== undefined ? <EyeOff className="w-3 h-3" /> : <Eye className="w-3 h-3" />}
                         </Button>
                         <Button
                           variant="ghost"
                           size="sm"
                           className="h-6 w-6 p-0"
-                          onClick={() => copyToClipboard(stock.content)}
+                          onClick={() => copyToClipboard(stock.id)}
                         >
                           <Copy className="w-3 h-3" />
                         </Button>
@@ -0,0 +1,75 @@
+'use client'
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Users } from 'lucide-react'
+import { getProductWaitingRoom, updateProductWaitingRoom, type WaitingRoomStats } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { Badge } from '@/components/ui/badge'
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+// 限量发售等候室设置：开关等候室并查看当前排队情况
+export function ProductWaitingRoomCard({ productId }: { productId: number }) {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({
+    queryKey: ['productWaitingRoom', productId],
+    queryFn: () => getProductWaitingRoom(productId),
+    refetchInterval: 10000,
+  })
+  const stats: WaitingRoomStats | undefined = data?.data
+
+  const updateMutation = useMutation({
+    mutationFn: (enabled: boolean) => updateProductWaitingRoom(productId, enabled),
+    onSuccess: () => {
+      toast.success(t.admin.productWaitingRoomUpdated)
+      queryClient.invalidateQueries({ queryKey: ['productWaitingRoom', productId] })
+      queryClient.invalidateQueries({ queryKey: ['adminProduct', productId] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.productWaitingRoomUpdateFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Users className="h-5 w-5" />
+          {t.admin.productWaitingRoom}
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex items-center space-x-2">
+          <Switch
+            id="waiting_room"
+            checked={Boolean(stats?.waiting_room)}
+            disabled={!stats || updateMutation.isPending}
+            onCheckedChange={(checked) => updateMutation.mutate(checked)}
+          />
+          <Label htmlFor="waiting_room">{t.admin.productWaitingRoomEnable}</Label>
+        </div>
+        <p className="text-sm text-muted-foreground">{t.admin.productWaitingRoomHint}</p>
+        {stats?.waiting_room && !stats.active && (
+          <Badge variant="outline">{t.admin.productWaitingRoomInactive}</Badge>
+        )}
+        {stats?.waiting_room && stats.active && (
+          <div className="flex gap-2">
+            <Badge variant="secondary">
+              {t.admin.productWaitingRoomQueued.replace('{count}', String(stats.queue_length))}
+            </Badge>
+            <Badge variant="secondary">
+              {t.admin.productWaitingRoomAdmitted.replace('{count}', String(stats.admitted))}
+            </Badge>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -4,6 +4,8 @@ import { useDeferredValue, useRef, useState } from 'react'
 import { useQuery, useMutation } from '@tanstack/react-query'
 import { getAdminPromoCodes, deletePromoCode } from '@/lib/api'
 import { DataTable } from '@/components/admin/data-table'
+import { PromoCodeCampaignsPanel } from '@/components/admin/promo-code-campaigns-panel'
+import { PricingRulesPanel } from '@/components/admin/pricing-rules-panel'
 import { Button } from '@/components/ui/button'
 import { Badge } from '@/components/ui/badge'
 import { Download, Plus, RefreshCw, Pencil, Trash2, Upload } from 'lucide-react'
@@ -468,6 +470,9 @@ export default function AdminPromoCodesPage() {
         }}
       />
 
+      <PromoCodeCampaignsPanel canEdit={canEditPromoCodes} />
+      <PricingRulesPanel canEdit={canEditPromoCodes} />
+
       {/* 删除确认对话框 */}
       <AlertDialog open={deleteId !== null} onOpenChange={() => setDeleteId(null)}>
         <AlertDialogContent>
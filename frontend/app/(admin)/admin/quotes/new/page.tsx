@@ -0,0 +1,412 @@
+'use client'
+
+import { Suspense, useEffect, useState } from 'react'
+import { useRouter, useSearchParams } from 'next/navigation'
+import { useMutation, useQuery } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Loader2, Plus, Search, Trash2 } from 'lucide-react'
+import {
+  createQuote,
+  getAdminProducts,
+  getAdminQuote,
+  getUsers,
+  updateQuote,
+  type Quote,
+  type QuoteInput,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { minorToMajor, parseMajorToMinor } from '@/lib/utils'
+import { useCurrency, formatPrice } from '@/contexts/currency-context'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+interface QuoteFormItem {
+  sku: string
+  name: string
+  quantity: number
+  unit_price_major: string
+}
+
+function toDateInput(value?: string) {
+  if (!value) return ''
+  const date = new Date(value)
+  const pad = (n: number) => String(n).padStart(2, '0')
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}`
+}
+
+export default function AdminQuoteEditorPage() {
+  return (
+    <Suspense
+      fallback={
+        <div className="flex items-center justify-center py-12">
+          <div className="h-8 w-8 animate-spin rounded-full border-4 border-primary border-t-transparent" />
+        </div>
+      }
+    >
+      <QuoteEditor />
+    </Suspense>
+  )
+}
+
+// 报价单编辑：新建草稿或修改尚未发送的草稿（?id=）
+function QuoteEditor() {
+  const router = useRouter()
+  const searchParams = useSearchParams()
+  const quoteId = Number(searchParams.get('id')) || 0
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(quoteId ? t.pageTitle.adminQuoteEdit : t.quote.create)
+  const { currency } = useCurrency()
+
+  const [userSearch, setUserSearch] = useState('')
+  const [selectedUser, setSelectedUser] = useState<any | null>(null)
+  const [title, setTitle] = useState('')
+  const [productSearch, setProductSearch] = useState('')
+  const [items, setItems] = useState<QuoteFormItem[]>([])
+  const [discountMajor, setDiscountMajor] = useState('')
+  const [validUntil, setValidUntil] = useState('')
+  const [note, setNote] = useState('')
+  const [adminNote, setAdminNote] = useState('')
+
+  const { data: quoteData, isLoading: quoteLoading } = useQuery({
+    queryKey: ['adminQuote', quoteId],
+    queryFn: () => getAdminQuote(quoteId),
+    enabled: quoteId > 0,
+  })
+  const existing: Quote | undefined = quoteData?.data
+
+  useEffect(() => {
+    if (!existing) return
+    setSelectedUser(existing.user || { id: existing.user_id })
+    setTitle(existing.title || '')
+    setItems(
+      existing.items.map((item) => ({
+        sku: item.sku,
+        name: item.name,
+        quantity: item.quantity,
+        unit_price_major: String(minorToMajor(item.unit_price_minor)),
+      }))
+    )
+    setDiscountMajor(
+      existing.discount_amount_minor ? String(minorToMajor(existing.discount_amount_minor)) : ''
+    )
+    setValidUntil(toDateInput(existing.valid_until))
+    setNote(existing.note || '')
+    setAdminNote(existing.admin_note || '')
+  }, [existing])
+
+  const { data: usersData, isFetching: usersLoading } = useQuery({
+    queryKey: ['quoteUsers', userSearch],
+    queryFn: () => getUsers({ page: 1, limit: 5, search: userSearch }),
+    enabled: !selectedUser && userSearch.trim().length >= 2,
+  })
+  const userOptions: any[] = usersData?.data?.items || []
+
+  const { data: productsData, isFetching: productsLoading } = useQuery({
+    queryKey: ['quoteProducts', productSearch],
+    queryFn: () => getAdminProducts({ page: 1, limit: 5, search: productSearch }),
+    enabled: productSearch.trim().length >= 2,
+  })
+  const productOptions: any[] = productsData?.data?.items || []
+
+  const itemPrice = (item: QuoteFormItem) => parseMajorToMinor(item.unit_price_major) || 0
+  const subtotal = items.reduce((sum, item) => sum + itemPrice(item) * item.quantity, 0)
+  const discountMinor = discountMajor.trim() === '' ? 0 : parseMajorToMinor(discountMajor)
+  const total = Math.max(subtotal - (discountMinor || 0), 0)
+  const quoteCurrency = existing?.currency || currency
+
+  const updateItem = (index: number, patch: Partial<QuoteFormItem>) => {
+    setItems(items.map((item, i) => (i === index ? { ...item, ...patch } : item)))
+  }
+
+  const addProduct = (product: any) => {
+    setItems([
+      ...items,
+      {
+        sku: product.sku,
+        name: product.name,
+        quantity: 1,
+        unit_price_major: String(minorToMajor(product.price_minor || 0)),
+      },
+    ])
+    setProductSearch('')
+  }
+
+  const saveMutation = useMutation({
+    mutationFn: (data: QuoteInput) => (quoteId ? updateQuote(quoteId, data) : createQuote(data)),
+    onSuccess: () => {
+      toast.success(t.quote.saved)
+      router.push('/admin/quotes')
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.saveFailed))
+    },
+  })
+
+  const handleSubmit = () => {
+    if (!selectedUser) {
+      toast.error(t.quote.selectUser)
+      return
+    }
+    if (items.length === 0) {
+      toast.error(t.quote.itemsRequired)
+      return
+    }
+    const normalizedItems = []
+    for (const item of items) {
+      const unitPriceMinor = parseMajorToMinor(item.unit_price_major)
+      if (unitPriceMinor === null || unitPriceMinor < 0) {
+        toast.error(t.order.invalidPrice)
+        return
+      }
+      normalizedItems.push({
+        sku: item.sku,
+        name: item.name,
+        quantity: item.quantity,
+        unit_price_minor: unitPriceMinor,
+      })
+    }
+    if (discountMinor === null || discountMinor < 0 || discountMinor > subtotal) {
+      toast.error(t.admin.manualOrderDiscountInvalid)
+      return
+    }
+    saveMutation.mutate({
+      user_id: selectedUser.id,
+      title: title.trim(),
+      items: normalizedItems,
+      discount_minor: discountMinor,
+      valid_until: validUntil ? new Date(`${validUntil}T23:59:59`).toISOString() : undefined,
+      note,
+      admin_note: adminNote,
+    })
+  }
+
+  if (quoteId && quoteLoading) {
+    return (
+      <div className="flex items-center justify-center py-12">
+        <Loader2 className="h-8 w-8 animate-spin" />
+      </div>
+    )
+  }
+
+  return (
+    <div className="mx-auto max-w-4xl space-y-6">
+      <div className="flex items-center gap-3">
+        <Button variant="ghost" size="icon" asChild>
+          <Link href="/admin/quotes">
+            <ArrowLeft className="h-4 w-4" />
+          </Link>
+        </Button>
+        <div>
+          <h1 className="text-3xl font-bold">{quoteId ? t.quote.edit : t.quote.create}</h1>
+          {existing ? (
+            <p className="font-mono text-sm text-muted-foreground">{existing.quote_no}</p>
+          ) : null}
+        </div>
+      </div>
+
+      <Card>
+        <CardHeader>
+          <CardTitle className="text-base">{t.quote.customer}</CardTitle>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          {selectedUser ? (
+            <div className="flex items-center justify-between rounded-md border p-3 text-sm">
+              <div>
+                <div className="font-medium">{selectedUser.name || selectedUser.email}</div>
+                <div className="text-muted-foreground">{selectedUser.email}</div>
+              </div>
+              <Button variant="outline" size="sm" onClick={() => setSelectedUser(null)}>
+                {t.admin.manualOrderChangeUser}
+              </Button>
+            </div>
+          ) : (
+            <div className="space-y-2">
+              <div className="relative">
+                <Search className="absolute left-3 top-1/2 h-4 w-4 -translate-y-1/2 text-muted-foreground" />
+                <Input
+                  className="pl-9"
+                  placeholder={t.admin.manualOrderSearchUser}
+                  value={userSearch}
+                  onChange={(e) => setUserSearch(e.target.value)}
+                />
+              </div>
+              {usersLoading && <Loader2 className="h-4 w-4 animate-spin" />}
+              {userOptions.map((user) => (
+                <button
+                  key={user.id}
+                  type="button"
+                  className="flex w-full items-center justify-between rounded-md border px-3 py-2 text-left text-sm hover:bg-muted"
+                  onClick={() => setSelectedUser(user)}
+                >
+                  <span className="font-medium">{user.name || '-'}</span>
+                  <span className="text-muted-foreground">{user.email}</span>
+                </button>
+              ))}
+            </div>
+          )}
+          <div className="space-y-2">
+            <Label htmlFor="quote_title">{t.quote.title}</Label>
+            <Input
+              id="quote_title"
+              maxLength={200}
+              placeholder={t.quote.titlePlaceholder}
+              value={title}
+              onChange={(e) => setTitle(e.target.value)}
+            />
+          </div>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardHeader>
+          <CardTitle className="text-base">{t.quote.items}</CardTitle>
+          <CardDescription>{t.quote.itemsHint}</CardDescription>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          <div className="space-y-2">
+            <div className="relative">
+              <Search className="absolute left-3 top-1/2 h-4 w-4 -translate-y-1/2 text-muted-foreground" />
+              <Input
+                className="pl-9"
+                placeholder={t.admin.manualOrderSearchProduct}
+                value={productSearch}
+                onChange={(e) => setProductSearch(e.target.value)}
+              />
+            </div>
+            {productsLoading && <Loader2 className="h-4 w-4 animate-spin" />}
+            {productSearch.trim().length >= 2 &&
+              productOptions.map((product) => (
+                <button
+                  key={product.id}
+                  type="button"
+                  className="flex w-full items-center justify-between rounded-md border px-3 py-2 text-left text-sm hover:bg-muted"
+                  onClick={() => addProduct(product)}
+                >
+                  <span>
+                    <span className="font-medium">{product.name}</span>
+                    <span className="ml-2 font-mono text-xs text-muted-foreground">
+                      {product.sku}
+                    </span>
+                  </span>
+                  <span className="flex items-center gap-2">
+                    {formatPrice(product.price_minor, currency)}
+                    <Plus className="h-4 w-4" />
+                  </span>
+                </button>
+              ))}
+          </div>
+
+          {items.map((item, index) => (
+            <div key={index} className="flex flex-wrap items-center gap-2 rounded-md border p-3">
+              <div className="min-w-0 flex-1">
+                <div className="truncate font-medium">{item.name}</div>
+                <div className="font-mono text-xs text-muted-foreground">{item.sku}</div>
+              </div>
+              <Input
+                className="w-20"
+                type="number"
+                min={1}
+                title={t.quote.quantity}
+                value={item.quantity}
+                onChange={(e) =>
+                  updateItem(index, { quantity: Math.max(1, Number(e.target.value) || 1) })
+                }
+              />
+              <Input
+                className="w-32"
+                inputMode="decimal"
+                title={t.quote.unitPrice}
+                value={item.unit_price_major}
+                onChange={(e) => updateItem(index, { unit_price_major: e.target.value })}
+              />
+              <span className="w-28 text-right text-sm">
+                {formatPrice(itemPrice(item) * item.quantity, quoteCurrency)}
+              </span>
+              <Button
+                variant="ghost"
+                size="icon"
+                onClick={() => setItems(items.filter((_, i) => i !== index))}
+              >
+                <Trash2 className="h-4 w-4" />
+              </Button>
+            </div>
+          ))}
+
+          <div className="grid gap-2 border-t pt-4 text-sm">
+            <div className="flex justify-between">
+              <span className="text-muted-foreground">{t.quote.subtotal}</span>
+              <span>{formatPrice(subtotal, quoteCurrency)}</span>
+            </div>
+            <div className="flex items-center justify-between gap-4">
+              <Label htmlFor="quote_discount" className="text-muted-foreground">
+                {t.quote.discount}
+              </Label>
+              <Input
+                id="quote_discount"
+                className="w-32"
+                inputMode="decimal"
+                placeholder="0.00"
+                value={discountMajor}
+                onChange={(e) => setDiscountMajor(e.target.value)}
+              />
+            </div>
+            <div className="flex justify-between font-semibold">
+              <span>{t.quote.total}</span>
+              <span>{formatPrice(total, quoteCurrency)}</span>
+            </div>
+          </div>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardContent className="space-y-4 pt-6">
+          <div className="space-y-2">
+            <Label htmlFor="quote_valid_until">{t.quote.validUntil}</Label>
+            <Input
+              id="quote_valid_until"
+              type="date"
+              className="w-48"
+              value={validUntil}
+              onChange={(e) => setValidUntil(e.target.value)}
+            />
+            <p className="text-xs text-muted-foreground">{t.quote.validUntilHint}</p>
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="quote_note">{t.quote.note}</Label>
+            <Textarea
+              id="quote_note"
+              rows={3}
+              value={note}
+              onChange={(e) => setNote(e.target.value)}
+            />
+            <p className="text-xs text-muted-foreground">{t.quote.noteHint}</p>
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="quote_admin_note">{t.quote.adminNote}</Label>
+            <Textarea
+              id="quote_admin_note"
+              rows={2}
+              value={adminNote}
+              onChange={(e) => setAdminNote(e.target.value)}
+            />
+          </div>
+        </CardContent>
+      </Card>
+
+      <div className="flex justify-end">
+        <Button disabled={saveMutation.isPending} onClick={handleSubmit}>
+          {saveMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+          {t.quote.saveDraft}
+        </Button>
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,348 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Ban, Download, Eye, Pencil, Plus, Send } from 'lucide-react'
+import {
+  cancelQuote,
+  getAdminQuotes,
+  openQuoteDocument,
+  sendQuote,
+  type Quote,
+  type QuoteStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+export default function AdminQuotesPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminQuotes)
+  const { hasPermission } = usePermission()
+  const canEdit = hasPermission('order.edit')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('all')
+  const [search, setSearch] = useState('')
+  const [sendTarget, setSendTarget] = useState<Quote | null>(null)
+  const [sendEmail, setSendEmail] = useState(true)
+  const [cancelTarget, setCancelTarget] = useState<Quote | null>(null)
+
+  const { data: quotesData, isLoading } = useQuery({
+    queryKey: ['adminQuotes', page, status, search],
+    queryFn: () =>
+      getAdminQuotes({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        search: search || undefined,
+      }),
+  })
+  const quotes: Quote[] = quotesData?.data?.items || []
+
+  const sendMutation = useMutation({
+    mutationFn: () => sendQuote(sendTarget!.id, sendEmail),
+    onSuccess: () => {
+      toast.success(t.quote.sent)
+      setSendTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['adminQuotes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.sendFailed))
+    },
+  })
+
+  const cancelMutation = useMutation({
+    mutationFn: () => cancelQuote(cancelTarget!.id),
+    onSuccess: () => {
+      toast.success(t.quote.cancelled)
+      setCancelTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['adminQuotes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.cancelFailed))
+    },
+  })
+
+  const openDocument = async (quote: Quote, pdf: boolean) => {
+    try {
+      if (pdf) {
+        await openQuoteDocument(
+          `/api/admin/quotes/${quote.id}/document?format=pdf`,
+          `${quote.quote_no}.pdf`
+        )
+      } else {
+        await openQuoteDocument(`/api/admin/quotes/${quote.id}/document`)
+      }
+    } catch (error: unknown) {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.documentFailed))
+    }
+  }
+
+  const statusLabels: Record<QuoteStatus, string> = {
+    draft: t.quote.statusDraft,
+    sent: t.quote.statusSent,
+    accepted: t.quote.statusAccepted,
+    declined: t.quote.statusDeclined,
+    expired: t.quote.statusExpired,
+    cancelled: t.quote.statusCancelled,
+  }
+  const statusVariant = (value: QuoteStatus) => {
+    if (value === 'accepted') return 'default' as const
+    if (value === 'sent') return 'outline' as const
+    return 'secondary' as const
+  }
+
+  const columns = [
+    {
+      header: t.quote.title,
+      cell: ({ row }: { row: { original: Quote } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.quote_no}</div>
+          {row.original.title ? (
+            <div className="text-xs text-muted-foreground">{row.original.title}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.quote.customer,
+      cell: ({ row }: { row: { original: Quote } }) => (
+        <div>
+          <div>{row.original.user?.name || '-'}</div>
+          <div className="text-xs text-muted-foreground">{row.original.user?.email}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.quote.total,
+      cell: ({ row }: { row: { original: Quote } }) =>
+        formatCurrency(row.original.total_amount_minor, row.original.currency),
+    },
+    {
+      header: t.quote.validUntil,
+      cell: ({ row }: { row: { original: Quote } }) =>
+        new Date(row.original.valid_until).toLocaleDateString(),
+    },
+    {
+      header: t.quote.status,
+      cell: ({ row }: { row: { original: Quote } }) => (
+        <div>
+          <Badge variant={statusVariant(row.original.status)}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          {row.original.decline_reason ? (
+            <div className="mt-1 text-xs text-muted-foreground">{row.original.decline_reason}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.quote.order,
+      cell: ({ row }: { row: { original: Quote } }) =>
+        row.original.order_id ? (
+          <Link
+            href={`/admin/orders/${row.original.order_id}`}
+            className="font-mono text-xs text-primary hover:underline"
+          >
+            {row.original.order_no}
+          </Link>
+        ) : (
+          '-'
+        ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: Quote } }) => (
+        <div className="flex items-center gap-2">
+          <Button
+            size="sm"
+            variant="outline"
+            title={t.quote.preview}
+            onClick={() => openDocument(row.original, false)}
+          >
+            <Eye className="h-4 w-4" />
+          </Button>
+          <Button
+            size="sm"
+            variant="outline"
+            title={t.quote.downloadPdf}
+            onClick={() => openDocument(row.original, true)}
+          >
+            <Download className="h-4 w-4" />
+          </Button>
+          {canEdit && row.original.status === 'draft' ? (
+            <>
+              <Button size="sm" variant="outline" title={t.quote.edit} asChild>
+                <Link href={`/admin/quotes/new?id=${row.original.id}`}>
+                  <Pencil className="h-4 w-4" />
+                </Link>
+              </Button>
+              <Button
+                size="sm"
+                variant="outline"
+                title={t.quote.send}
+                onClick={() => {
+                  setSendEmail(true)
+                  setSendTarget(row.original)
+                }}
+              >
+                <Send className="h-4 w-4" />
+              </Button>
+            </>
+          ) : null}
+          {canEdit && (row.original.status === 'draft' || row.original.status === 'sent') ? (
+            <Button
+              size="sm"
+              variant="outline"
+              title={t.quote.cancel}
+              onClick={() => setCancelTarget(row.original)}
+            >
+              <Ban className="h-4 w-4" />
+            </Button>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-col gap-3 md:flex-row md:items-start md:justify-between">
+        <div>
+          <h1 className="text-3xl font-bold">{t.quote.quoteManagement}</h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.quote.quoteManagementDesc}</p>
+        </div>
+        {canEdit ? (
+          <Button asChild>
+            <Link href="/admin/quotes/new">
+              <Plus className="mr-2 h-4 w-4" />
+              {t.quote.create}
+            </Link>
+          </Button>
+        ) : null}
+      </div>
+
+      <div className="flex flex-col gap-3 md:flex-row md:items-center">
+        <Select
+          value={status}
+          onValueChange={(value) => {
+            setStatus(value)
+            setPage(1)
+          }}
+        >
+          <SelectTrigger className="w-[150px]">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value="all">{t.common.all}</SelectItem>
+            {(Object.keys(statusLabels) as QuoteStatus[]).map((value) => (
+              <SelectItem key={value} value={value}>
+                {statusLabels[value]}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <Input
+          className="md:w-[280px]"
+          placeholder={t.quote.searchPlaceholder}
+          value={search}
+          onChange={(e) => {
+            setSearch(e.target.value)
+            setPage(1)
+          }}
+        />
+      </div>
+      <DataTable
+        columns={columns}
+        data={quotes}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: quotesData?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      {/* 发送报价单 */}
+      <Dialog open={sendTarget !== null} onOpenChange={(open) => !open && setSendTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.quote.send}</DialogTitle>
+            <DialogDescription className="font-mono">{sendTarget?.quote_no}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.quote.sendHint}</p>
+            <div className="flex items-center gap-2">
+              <Checkbox
+                id="quote_send_email"
+                checked={sendEmail}
+                onCheckedChange={(checked) => setSendEmail(checked === true)}
+              />
+              <Label htmlFor="quote_send_email">{t.quote.sendEmail}</Label>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setSendTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => sendMutation.mutate()} disabled={sendMutation.isPending}>
+              {t.quote.send}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 撤回报价单 */}
+      <Dialog open={cancelTarget !== null} onOpenChange={(open) => !open && setCancelTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.quote.cancel}</DialogTitle>
+            <DialogDescription className="font-mono">{cancelTarget?.quote_no}</DialogDescription>
+          </DialogHeader>
+          <p className="text-sm text-muted-foreground">{t.quote.cancelConfirm}</p>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setCancelTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant="destructive"
+              onClick={() => cancelMutation.mutate()}
+              disabled={cancelMutation.isPending}
+            >
+              {t.quote.cancel}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
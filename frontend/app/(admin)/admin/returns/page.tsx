@@ -0,0 +1,386 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { CheckCircle, PackageCheck, XCircle } from 'lucide-react'
+import {
+  approveReturn,
+  getAdminReturns,
+  receiveReturn,
+  rejectReturn,
+  type ReturnReason,
+  type ReturnRequest,
+  type ReturnStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+type ReviewMode = 'approve' | 'reject'
+
+export default function AdminReturnsPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminReturns)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('order.edit')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState('requested')
+  const [search, setSearch] = useState('')
+  const [reviewTarget, setReviewTarget] = useState<ReturnRequest | null>(null)
+  const [reviewMode, setReviewMode] = useState<ReviewMode>('approve')
+  const [reviewNote, setReviewNote] = useState('')
+  const [receiveTarget, setReceiveTarget] = useState<ReturnRequest | null>(null)
+  const [restock, setRestock] = useState(true)
+  const [receiveNote, setReceiveNote] = useState('')
+
+  const { data: returnsData, isLoading } = useQuery({
+    queryKey: ['adminReturns', page, status, search],
+    queryFn: () =>
+      getAdminReturns({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        search: search || undefined,
+      }),
+  })
+  const returns: ReturnRequest[] = returnsData?.data?.items || []
+
+  const reviewMutation = useMutation({
+    mutationFn: () =>
+      reviewMode === 'approve'
+        ? approveReturn(reviewTarget!.id, reviewNote.trim() || undefined)
+        : rejectReturn(reviewTarget!.id, reviewNote.trim()),
+    onSuccess: () => {
+      toast.success(reviewMode === 'approve' ? t.returns.approved : t.returns.rejected)
+      setReviewTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['adminReturns'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.returns.updateFailed))
+    },
+  })
+
+  const receiveMutation = useMutation({
+    mutationFn: () =>
+      receiveReturn(receiveTarget!.id, { restock, note: receiveNote.trim() || undefined }),
+    onSuccess: () => {
+      toast.success(t.returns.received)
+      setReceiveTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['adminReturns'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.returns.updateFailed))
+    },
+  })
+
+  const statusLabels: Record<ReturnStatus, string> = {
+    requested: t.returns.statusRequested,
+    approved: t.returns.statusApproved,
+    rejected: t.returns.statusRejected,
+    in_transit: t.returns.statusInTransit,
+    received: t.returns.statusReceived,
+  }
+  const reasonLabels: Record<ReturnReason, string> = {
+    defective: t.returns.reasonDefective,
+    damaged: t.returns.reasonDamaged,
+    wrong_item: t.returns.reasonWrongItem,
+    not_as_described: t.returns.reasonNotAsDescribed,
+    no_longer_needed: t.returns.reasonNoLongerNeeded,
+    other: t.returns.reasonOther,
+  }
+
+  const openReview = (request: ReturnRequest, mode: ReviewMode) => {
+    setReviewMode(mode)
+    setReviewNote('')
+    setReviewTarget(request)
+  }
+
+  const columns = [
+    {
+      header: t.returns.returnNo,
+      cell: ({ row }: { row: { original: ReturnRequest } }) => (
+        <div>
+          <div className="font-mono text-sm">{row.original.return_no}</div>
+          <Link
+            href={`/admin/orders/${row.original.order_id}`}
+            className="font-mono text-xs text-muted-foreground hover:underline"
+          >
+            {row.original.order_no}
+          </Link>
+        </div>
+      ),
+    },
+    {
+      header: t.returns.customer,
+      cell: ({ row }: { row: { original: ReturnRequest } }) =>
+        row.original.user?.email || row.original.user?.name || `#${row.original.user_id}`,
+    },
+    {
+      header: t.returns.items,
+      cell: ({ row }: { row: { original: ReturnRequest } }) => (
+        <ul className="text-xs">
+          {row.original.items.map((item) => (
+            <li key={item.item_index}>
+              {item.name} ({item.sku}) × {item.quantity}
+            </li>
+          ))}
+        </ul>
+      ),
+    },
+    {
+      header: t.returns.reason,
+      cell: ({ row }: { row: { original: ReturnRequest } }) => (
+        <div className="max-w-[260px] space-y-1">
+          <div className="text-sm">{reasonLabels[row.original.reason] || row.original.reason}</div>
+          {row.original.description ? (
+            <p className="line-clamp-3 whitespace-pre-wrap text-xs text-muted-foreground">
+              {row.original.description}
+            </p>
+          ) : null}
+          {row.original.photos?.length ? (
+            <div className="flex flex-wrap gap-1">
+              {row.original.photos.map((url) => (
+                <a key={url} href={url} target="_blank" rel="noopener noreferrer">
+                  {/* eslint-disable-next-line @next/next/no-img-element */}
+                  <img src={url} alt="" className="h-10 w-10 rounded border object-cover" />
+                </a>
+              ))}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.returns.status,
+      cell: ({ row }: { row: { original: ReturnRequest } }) => (
+        <div>
+          <Badge variant={row.original.status === 'rejected' ? 'destructive' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          {row.original.return_tracking_no ? (
+            <div className="mt-1 font-mono text-xs text-muted-foreground">
+              {row.original.return_carrier ? `${row.original.return_carrier} ` : ''}
+              {row.original.return_tracking_no}
+            </div>
+          ) : null}
+          {row.original.status === 'received' ? (
+            <div className="mt-1 text-xs text-muted-foreground">
+              {row.original.restocked ? t.returns.restocked : t.returns.notRestocked}
+            </div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.returns.createdAt,
+      cell: ({ row }: { row: { original: ReturnRequest } }) =>
+        new Date(row.original.created_at).toLocaleString(),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: ReturnRequest } }) => {
+        if (!canManage) return null
+        if (row.original.status === 'requested') {
+          return (
+            <div className="flex items-center gap-2">
+              <Button
+                size="sm"
+                variant="outline"
+                title={t.returns.approve}
+                onClick={() => openReview(row.original, 'approve')}
+              >
+                <CheckCircle className="h-4 w-4" />
+              </Button>
+              <Button
+                size="sm"
+                variant="outline"
+                title={t.returns.reject}
+                onClick={() => openReview(row.original, 'reject')}
+              >
+                <XCircle className="h-4 w-4" />
+              </Button>
+            </div>
+          )
+        }
+        if (row.original.status === 'approved' || row.original.status === 'in_transit') {
+          return (
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setRestock(true)
+                setReceiveNote('')
+                setReceiveTarget(row.original)
+              }}
+            >
+              <PackageCheck className="mr-1.5 h-4 w-4" />
+              {t.returns.receive}
+            </Button>
+          )
+        }
+        return null
+      },
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.returns.management}</h1>
+        <p className="mt-1 text-sm text-muted-foreground">{t.returns.managementDesc}</p>
+      </div>
+
+      <div className="flex flex-col gap-3 md:flex-row md:items-center">
+        <Select
+          value={status}
+          onValueChange={(value) => {
+            setStatus(value)
+            setPage(1)
+          }}
+        >
+          <SelectTrigger className="w-[150px]">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            <SelectItem value="all">{t.common.all}</SelectItem>
+            {(Object.keys(statusLabels) as ReturnStatus[]).map((value) => (
+              <SelectItem key={value} value={value}>
+                {statusLabels[value]}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <Input
+          className="md:w-[280px]"
+          placeholder={t.returns.searchPlaceholder}
+          value={search}
+          onChange={(e) => {
+            setSearch(e.target.value)
+            setPage(1)
+          }}
+        />
+      </div>
+      <DataTable
+        columns={columns}
+        data={returns}
+        isLoading={isLoading}
+        pagination={{
+          page,
+          total_pages: returnsData?.data?.pagination?.total_pages || 1,
+          onPageChange: setPage,
+        }}
+      />
+
+      {/* 审核退货申请 */}
+      <Dialog open={reviewTarget !== null} onOpenChange={(open) => !open && setReviewTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {reviewMode === 'approve' ? t.returns.approve : t.returns.reject}
+            </DialogTitle>
+            <DialogDescription className="font-mono">{reviewTarget?.return_no}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-2">
+            <Label htmlFor="return_review_note">
+              {reviewMode === 'approve' ? t.returns.approveNote : `${t.returns.rejectNote} *`}
+            </Label>
+            <Textarea
+              id="return_review_note"
+              rows={3}
+              maxLength={1000}
+              value={reviewNote}
+              onChange={(e) => setReviewNote(e.target.value)}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReviewTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={reviewMode === 'approve' ? 'default' : 'destructive'}
+              onClick={() => reviewMutation.mutate()}
+              disabled={
+                reviewMutation.isPending || (reviewMode === 'reject' && !reviewNote.trim())
+              }
+            >
+              {reviewMode === 'approve' ? t.returns.approve : t.returns.reject}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 确认收到退货 */}
+      <Dialog
+        open={receiveTarget !== null}
+        onOpenChange={(open) => !open && setReceiveTarget(null)}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.returns.receive}</DialogTitle>
+            <DialogDescription className="font-mono">{receiveTarget?.return_no}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="flex items-start gap-2">
+              <Checkbox
+                id="return_restock"
+                checked={restock}
+                onCheckedChange={(checked) => setRestock(checked === true)}
+              />
+              <div className="space-y-1">
+                <Label htmlFor="return_restock">{t.returns.restock}</Label>
+                <p className="text-xs text-muted-foreground">{t.returns.restockHint}</p>
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="return_receive_note">{t.returns.receiveNote}</Label>
+              <Textarea
+                id="return_receive_note"
+                rows={3}
+                maxLength={1000}
+                value={receiveNote}
+                onChange={(e) => setReceiveNote(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReceiveTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => receiveMutation.mutate()} disabled={receiveMutation.isPending}>
+              {t.returns.receive}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
@@ -0,0 +1,572 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Check, Pencil, Plus, Trash2, X } from 'lucide-react'
+import {
+  createVelocityRule,
+  deleteVelocityRule,
+  getOrderRiskReviews,
+  getVelocityRules,
+  reviewOrderRisk,
+  updateVelocityRule,
+  type OrderRiskReview,
+  type OrderRiskReviewStatus,
+  type VelocityAction,
+  type VelocityMetric,
+  type VelocityRule,
+  type VelocityRuleInput,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Switch } from '@/components/ui/switch'
+import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { Textarea } from '@/components/ui/textarea'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+const VELOCITY_METRICS: VelocityMetric[] = [
+  'orders_per_ip',
+  'orders_per_user',
+  'accounts_per_ip',
+  'accounts_per_address',
+]
+const VELOCITY_ACTIONS: VelocityAction[] = ['flag', 'hold']
+const REVIEW_STATUSES: OrderRiskReviewStatus[] = ['pending', 'approved', 'rejected']
+
+function createEmptyRule(): VelocityRuleInput {
+  return {
+    name: '',
+    metric: 'orders_per_ip',
+    threshold: 3,
+    window_minutes: 10,
+    action: 'hold',
+    is_active: true,
+  }
+}
+
+function formatDateTime(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+export default function AdminRiskPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminRisk)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('order.risk_review')
+
+  const [page, setPage] = useState(1)
+  const [status, setStatus] = useState<string>('pending')
+  const [action, setAction] = useState('all')
+  const [reviewing, setReviewing] = useState<{
+    item: OrderRiskReview
+    decision: 'approve' | 'reject'
+  } | null>(null)
+  const [reviewNote, setReviewNote] = useState('')
+  const [editingRule, setEditingRule] = useState<VelocityRule | null>(null)
+  const [ruleFormOpen, setRuleFormOpen] = useState(false)
+  const [ruleForm, setRuleForm] = useState<VelocityRuleInput>(createEmptyRule())
+  const [deletingRule, setDeletingRule] = useState<VelocityRule | null>(null)
+
+  const metricLabels: Record<VelocityMetric, string> = {
+    orders_per_ip: t.risk.metricOrdersPerIp,
+    orders_per_user: t.risk.metricOrdersPerUser,
+    accounts_per_ip: t.risk.metricAccountsPerIp,
+    accounts_per_address: t.risk.metricAccountsPerAddress,
+  }
+  const actionLabels: Record<VelocityAction, string> = {
+    flag: t.risk.actionFlag,
+    hold: t.risk.actionHold,
+  }
+  const statusLabels: Record<OrderRiskReviewStatus, string> = {
+    pending: t.risk.statusPending,
+    approved: t.risk.statusApproved,
+    rejected: t.risk.statusRejected,
+  }
+  const formatWindow = (minutes: number) =>
+    minutes > 0
+      ? t.risk.windowMinutesValue.replace('{minutes}', String(minutes))
+      : t.risk.windowAllTime
+
+  const { data: reviewsData, isLoading: reviewsLoading } = useQuery({
+    queryKey: ['adminOrderRiskReviews', page, status, action],
+    queryFn: () =>
+      getOrderRiskReviews({
+        page,
+        limit: 20,
+        status: status === 'all' ? undefined : status,
+        action: action === 'all' ? undefined : action,
+      }),
+  })
+  const reviews: OrderRiskReview[] = reviewsData?.data?.items || []
+
+  const { data: rulesData, isLoading: rulesLoading } = useQuery({
+    queryKey: ['adminVelocityRules'],
+    queryFn: getVelocityRules,
+  })
+  const rules: VelocityRule[] = rulesData?.data?.items || []
+
+  const reviewMutation = useMutation({
+    mutationFn: () =>
+      reviewOrderRisk(reviewing!.item.id, { decision: reviewing!.decision, note: reviewNote }),
+    onSuccess: (result: any) => {
+      toast.success(result?.data?.order_cancelled ? t.risk.reviewedCancelled : t.risk.reviewed)
+      setReviewing(null)
+      queryClient.invalidateQueries({ queryKey: ['adminOrderRiskReviews'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.risk.reviewFailed))
+    },
+  })
+
+  const saveRuleMutation = useMutation({
+    mutationFn: () =>
+      editingRule ? updateVelocityRule(editingRule.id, ruleForm) : createVelocityRule(ruleForm),
+    onSuccess: () => {
+      toast.success(t.risk.ruleSaved)
+      setRuleFormOpen(false)
+      queryClient.invalidateQueries({ queryKey: ['adminVelocityRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.risk.ruleSaveFailed))
+    },
+  })
+
+  const deleteRuleMutation = useMutation({
+    mutationFn: (id: number) => deleteVelocityRule(id),
+    onSuccess: () => {
+      toast.success(t.risk.ruleDeleted)
+      queryClient.invalidateQueries({ queryKey: ['adminVelocityRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.risk.ruleDeleteFailed))
+    },
+  })
+
+  const openReview = (item: OrderRiskReview, decision: 'approve' | 'reject') => {
+    setReviewNote('')
+    setReviewing({ item, decision })
+  }
+
+  const openCreateRule = () => {
+    setEditingRule(null)
+    setRuleForm(createEmptyRule())
+    setRuleFormOpen(true)
+  }
+
+  const openEditRule = (rule: VelocityRule) => {
+    setEditingRule(rule)
+    setRuleForm({
+      name: rule.name,
+      metric: rule.metric,
+      threshold: rule.threshold,
+      window_minutes: rule.window_minutes,
+      action: rule.action,
+      is_active: rule.is_active,
+    })
+    setRuleFormOpen(true)
+  }
+
+  const reviewColumns = [
+    {
+      header: t.risk.order,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) => (
+        <div className="text-sm">
+          <Link href={`/admin/orders/${row.original.order_id}`} className="font-mono underline">
+            {row.original.order_no}
+          </Link>
+          {row.original.ip ? (
+            <div className="text-xs text-muted-foreground">{row.original.ip}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.risk.user,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) =>
+        row.original.user?.email || (row.original.user_id ? `#${row.original.user_id}` : '-'),
+    },
+    {
+      header: t.risk.hits,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) => (
+        <div className="max-w-[360px] space-y-1 text-sm">
+          {(row.original.hits || []).map((hit) => (
+            <div key={hit.rule_id}>
+              <div>{hit.rule_name}</div>
+              <div className="text-xs text-muted-foreground">
+                {metricLabels[hit.metric] || hit.metric}: {hit.value} &gt; {hit.threshold} ·{' '}
+                {formatWindow(hit.window_minutes)}
+              </div>
+            </div>
+          ))}
+        </div>
+      ),
+    },
+    {
+      header: t.risk.action,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) => (
+        <Badge variant={row.original.action === 'hold' ? 'destructive' : 'outline'}>
+          {actionLabels[row.original.action] || row.original.action}
+        </Badge>
+      ),
+    },
+    {
+      header: t.risk.status,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) => (
+        <div className="space-y-1">
+          <Badge variant={row.original.status === 'pending' ? 'default' : 'secondary'}>
+            {statusLabels[row.original.status]}
+          </Badge>
+          <div className="text-xs text-muted-foreground">
+            {formatDateTime(row.original.updated_at)}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: OrderRiskReview } }) =>
+        canManage && row.original.status === 'pending' ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openReview(row.original, 'approve')}>
+              <Check className="mr-1 h-4 w-4" />
+              {t.risk.approve}
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => openReview(row.original, 'reject')}>
+              <X className="mr-1 h-4 w-4" />
+              {t.risk.reject}
+            </Button>
+          </div>
+        ) : row.original.review_note ? (
+          <div className="max-w-[200px] text-xs text-muted-foreground">
+            {row.original.review_note}
+          </div>
+        ) : null,
+    },
+  ]
+
+  const ruleColumns = [
+    {
+      header: t.risk.ruleName,
+      cell: ({ row }: { row: { original: VelocityRule } }) => row.original.name,
+    },
+    {
+      header: t.risk.condition,
+      cell: ({ row }: { row: { original: VelocityRule } }) => (
+        <div className="text-sm">
+          <div>
+            {metricLabels[row.original.metric] || row.original.metric} &gt; {row.original.threshold}
+          </div>
+          <div className="text-xs text-muted-foreground">
+            {formatWindow(row.original.window_minutes)}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.risk.action,
+      cell: ({ row }: { row: { original: VelocityRule } }) => actionLabels[row.original.action],
+    },
+    {
+      header: t.risk.hitCount,
+      cell: ({ row }: { row: { original: VelocityRule } }) => (
+        <div className="text-sm">
+          <div>{row.original.hit_count}</div>
+          <div className="text-xs text-muted-foreground">
+            {formatDateTime(row.original.last_hit_at)}
+          </div>
+        </div>
+      ),
+    },
+    {
+      header: t.risk.status,
+      cell: ({ row }: { row: { original: VelocityRule } }) => (
+        <Badge variant={row.original.is_active ? 'default' : 'outline'}>
+          {row.original.is_active ? t.risk.ruleActive : t.risk.ruleInactive}
+        </Badge>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: VelocityRule } }) =>
+        canManage ? (
+          <div className="flex gap-2">
+            <Button size="sm" variant="outline" onClick={() => openEditRule(row.original)}>
+              <Pencil className="h-4 w-4" />
+            </Button>
+            <Button size="sm" variant="outline" onClick={() => setDeletingRule(row.original)}>
+              <Trash2 className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.risk.title}</h1>
+        <p className="text-sm text-muted-foreground">{t.risk.description}</p>
+      </div>
+
+      <Tabs defaultValue="queue">
+        <TabsList>
+          <TabsTrigger value="queue">{t.risk.queueTab}</TabsTrigger>
+          <TabsTrigger value="rules">{t.risk.rulesTab}</TabsTrigger>
+        </TabsList>
+
+        <TabsContent value="queue" className="space-y-4">
+          <div className="flex flex-wrap gap-2">
+            <Select
+              value={status}
+              onValueChange={(value) => {
+                setStatus(value)
+                setPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[160px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.common.all}</SelectItem>
+                {REVIEW_STATUSES.map((item) => (
+                  <SelectItem key={item} value={item}>
+                    {statusLabels[item]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <Select
+              value={action}
+              onValueChange={(value) => {
+                setAction(value)
+                setPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[160px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.risk.allActions}</SelectItem>
+                {VELOCITY_ACTIONS.map((item) => (
+                  <SelectItem key={item} value={item}>
+                    {actionLabels[item]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <DataTable
+            columns={reviewColumns}
+            data={reviews}
+            isLoading={reviewsLoading}
+            pagination={{
+              page,
+              total_pages: reviewsData?.data?.pagination?.total_pages || 1,
+              onPageChange: setPage,
+            }}
+          />
+        </TabsContent>
+
+        <TabsContent value="rules" className="space-y-4">
+          <div className="flex flex-wrap items-center justify-between gap-4">
+            <p className="text-sm text-muted-foreground">{t.risk.rulesHint}</p>
+            {canManage ? (
+              <Button onClick={openCreateRule}>
+                <Plus className="mr-2 h-4 w-4" />
+                {t.risk.createRule}
+              </Button>
+            ) : null}
+          </div>
+          <DataTable columns={ruleColumns} data={rules} isLoading={rulesLoading} />
+        </TabsContent>
+      </Tabs>
+
+      <Dialog open={Boolean(reviewing)} onOpenChange={(open) => (!open ? setReviewing(null) : null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {reviewing?.decision === 'reject' ? t.risk.rejectTitle : t.risk.approveTitle}
+            </DialogTitle>
+          </DialogHeader>
+          <div className="space-y-3">
+            <p className="text-sm text-muted-foreground">
+              {reviewing?.decision === 'reject' ? t.risk.rejectHint : t.risk.approveHint}
+            </p>
+            <div className="space-y-2">
+              <Label>{t.risk.reviewNote}</Label>
+              <Textarea rows={3} value={reviewNote} onChange={(e) => setReviewNote(e.target.value)} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReviewing(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={reviewing?.decision === 'reject' ? 'destructive' : 'default'}
+              onClick={() => reviewMutation.mutate()}
+              disabled={reviewMutation.isPending}
+            >
+              {reviewing?.decision === 'reject' ? t.risk.reject : t.risk.approve}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={ruleFormOpen} onOpenChange={setRuleFormOpen}>
+        <DialogContent className="max-w-lg">
+          <DialogHeader>
+            <DialogTitle>{editingRule ? t.risk.editRule : t.risk.createRule}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>{t.risk.ruleName}</Label>
+              <Input
+                value={ruleForm.name}
+                onChange={(e) => setRuleForm({ ...ruleForm, name: e.target.value })}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.risk.metric}</Label>
+              <Select
+                value={ruleForm.metric}
+                onValueChange={(value) =>
+                  setRuleForm({ ...ruleForm, metric: value as VelocityMetric })
+                }
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {VELOCITY_METRICS.map((item) => (
+                    <SelectItem key={item} value={item}>
+                      {metricLabels[item]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="grid gap-4 md:grid-cols-2">
+              <div className="space-y-2">
+                <Label>{t.risk.threshold}</Label>
+                <Input
+                  type="number"
+                  min={1}
+                  value={ruleForm.threshold}
+                  onChange={(e) =>
+                    setRuleForm({ ...ruleForm, threshold: parseInt(e.target.value, 10) || 0 })
+                  }
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.risk.windowMinutes}</Label>
+                <Input
+                  type="number"
+                  min={0}
+                  value={ruleForm.window_minutes}
+                  onChange={(e) =>
+                    setRuleForm({ ...ruleForm, window_minutes: parseInt(e.target.value, 10) || 0 })
+                  }
+                />
+              </div>
+            </div>
+            <p className="text-xs text-muted-foreground">{t.risk.thresholdHint}</p>
+            <div className="space-y-2">
+              <Label>{t.risk.action}</Label>
+              <Select
+                value={ruleForm.action}
+                onValueChange={(value) =>
+                  setRuleForm({ ...ruleForm, action: value as VelocityAction })
+                }
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {VELOCITY_ACTIONS.map((item) => (
+                    <SelectItem key={item} value={item}>
+                      {actionLabels[item]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+              <p className="text-xs text-muted-foreground">{t.risk.actionHint}</p>
+            </div>
+            <div className="flex items-center gap-2">
+              <Switch
+                checked={ruleForm.is_active}
+                onCheckedChange={(checked) => setRuleForm({ ...ruleForm, is_active: checked })}
+              />
+              <Label>{t.risk.ruleActive}</Label>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setRuleFormOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => saveRuleMutation.mutate()} disabled={saveRuleMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog
+        open={Boolean(deletingRule)}
+        onOpenChange={(open) => (!open ? setDeletingRule(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.risk.deleteRuleTitle}</AlertDialogTitle>
+            <AlertDialogDescription>{t.risk.deleteRuleConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deletingRule) deleteRuleMutation.mutate(deletingRule.id)
+                setDeletingRule(null)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -76,6 +76,10 @@ import {
   SelectValue,
 } from '@/components/ui/select'
 import { LazyCodeEditor } from '@/components/ui/lazy-code-editor'
+import { MessageTestConsole } from '@/components/admin/message-test-console'
+import { DraftCleanupActions } from '@/components/admin/draft-cleanup-actions'
+import { DocumentTypesPanel } from '@/components/admin/document-types-panel'
+import { TemplateAssetsPanel } from '@/components/admin/template-assets-panel'
 import { useTheme } from '@/contexts/theme-context'
 import { resolveApiErrorMessage } from '@/lib/api-error'
 import { invalidatePageInjectRuntime } from '@/lib/page-inject'
@@ -108,6 +112,23 @@ const PRIMARY_COLOR_PRESETS = [
   { value: '24.6 95% 53.1%', labelKey: 'orange', hex: '#f97316' },
   { value: '0 72.2% 50.6%', labelKey: 'red', hex: '#dc2626' },
 ] as const
+const RATE_LIMIT_POLICIES = [
+  {
+    name: 'login',
+    labelKey: 'rateLimitPolicyLogin',
+    defaults: { rate: 10, burst: 10, keys: 'ip' },
+  },
+  {
+    name: 'order_create',
+    labelKey: 'rateLimitPolicyOrderCreate',
+    defaults: { rate: 30, burst: 30, keys: 'user,ip' },
+  },
+  {
+    name: 'ticket_message',
+    labelKey: 'rateLimitPolicyTicketMessage',
+    defaults: { rate: 20, burst: 10, keys: 'user' },
+  },
+] as const
 
 function formatBytes(bytes: number) {
   if (!Number.isFinite(bytes) || bytes <= 0) return '0 B'
@@ -117,6 +138,23 @@ function formatBytes(bytes: number) {
   return `${(bytes / (1024 * 1024 * 1024)).toFixed(1)} GB`
 }
 
+function readRateLimitPolicies(formData: FormData) {
+  return Object.fromEntries(
+    RATE_LIMIT_POLICIES.map(({ name }) => [
+      name,
+      {
+        rate: parseInt(formData.get(`policy_${name}_rate`) as string) || 0,
+        period_seconds: parseInt(formData.get(`policy_${name}_period`) as string) || 60,
+        burst: parseInt(formData.get(`policy_${name}_burst`) as string) || 0,
+        keys: String(formData.get(`policy_${name}_keys`) || '')
+          .split(',')
+          .map((key) => key.trim())
+          .filter(Boolean),
+      },
+    ])
+  )
+}
+
 function clamp(value: number, min: number, max: number) {
   return Math.min(Math.max(value, min), max)
 }
@@ -608,6 +646,7 @@ function AuthBrandingCard({
 export default function SettingsPage() {
   const [activeTab, setActiveTab] = useState('general')
   const [defaultTheme, setDefaultTheme] = useState('system')
+  const [emailVerificationMode, setEmailVerificationMode] = useState('login')
   const [primaryColor, setPrimaryColor] = useState('')
   const [logoUrl, setLogoUrl] = useState('')
   const [faviconUrl, setFaviconUrl] = useState('')
@@ -641,7 +680,8 @@ export default function SettingsPage() {
   })
 
   const updateMutation = useMutation({
-    mutationFn: updateSettings,
+    // 携带读取时的配置版本，配置已被其他管理员修改时后端返回 409
+    mutationFn: (data: any) => updateSettings({ ...data, version: settings?.data?.version }),
     onSuccess: (res: any) => {
       const maintenanceMessages: string[] = []
       if (typeof res?.data?.payment_card_cache_cleared === 'number') {
@@ -741,7 +781,7 @@ export default function SettingsPage() {
 
   const saveTemplateMutation = useMutation({
     mutationFn: ({ filename, content }: { filename: string; content: string }) =>
-      updateEmailTemplate(filename, content),
+      updateEmailTemplate(filename, content, templateData?.data?.version),
     onSuccess: () => {
       toast.success(t.admin.templateSaved)
       queryClient.invalidateQueries({ queryKey: ['emailTemplate', selectedTemplate] })
@@ -929,6 +969,7 @@ export default function SettingsPage() {
     order_paid: t.admin.templateEventOrderPaid,
     order_shipped: t.admin.templateEventOrderShipped,
     order_completed: t.admin.templateEventOrderCompleted,
+    order_auto_complete_reminder: t.admin.templateEventOrderAutoCompleteReminder,
     order_cancelled: t.admin.templateEventOrderCancelled,
     order_resubmit: t.admin.templateEventOrderResubmit,
     ticket_created: t.admin.templateEventTicketCreated,
@@ -1017,6 +1058,9 @@ export default function SettingsPage() {
     if (settingsData?.app?.default_theme) {
       setDefaultTheme(settingsData.app.default_theme)
     }
+    if (settingsData?.security?.login?.email_verification_mode) {
+      setEmailVerificationMode(settingsData.security.login.email_verification_mode)
+    }
     if (settingsData?.customization?.primary_color !== undefined) {
       setPrimaryColor(settingsData.customization.primary_color)
     }
@@ -1509,6 +1553,20 @@ export default function SettingsPage() {
                       }
                     />
                   </div>
+                  <div className="flex items-center justify-between">
+                    <div>
+                      <Label>{t.admin.orderAutoRemind}</Label>
+                      <p className="mt-0.5 text-xs text-muted-foreground">
+                        {t.admin.orderAutoRemindDesc}
+                      </p>
+                    </div>
+                    <Switch
+                      checked={emailNotifications.order_auto_remind || false}
+                      onCheckedChange={(v) =>
+                        setEmailNotifications((prev) => ({ ...prev, order_auto_remind: v }))
+                      }
+                    />
+                  </div>
                   <div className="flex items-center justify-between">
                     <div>
                       <Label>{t.admin.orderCancelled}</Label>
@@ -1762,6 +1820,31 @@ export default function SettingsPage() {
                     toast.error(t.admin.pmInvalidJson)
                     return
                   }
+                  const messageTemplatesSubmitted = formData.has('sms_template_bodies')
+                  let templateBodies: Record<string, Record<string, string>> = {}
+                  let templateCountryLocales: Record<string, string> = {}
+                  if (messageTemplatesSubmitted) {
+                    try {
+                      const rawBodies = String(formData.get('sms_template_bodies') || '').trim()
+                      const rawLocales = String(formData.get('sms_country_locales') || '').trim()
+                      templateBodies = rawBodies ? JSON.parse(rawBodies) : {}
+                      templateCountryLocales = rawLocales ? JSON.parse(rawLocales) : {}
+                    } catch {
+                      toast.error(t.admin.pmInvalidJson)
+                      return
+                    }
+                    if (
+                      !templateBodies ||
+                      typeof templateBodies !== 'object' ||
+                      Array.isArray(templateBodies) ||
+                      !templateCountryLocales ||
+                      typeof templateCountryLocales !== 'object' ||
+                      Array.isArray(templateCountryLocales)
+                    ) {
+                      toast.error(t.admin.pmInvalidJson)
+                      return
+                    }
+                  }
                   handleSubmit('sms', {
                     _submitted: true,
                     enabled: formData.get('sms_enabled') === 'on',
@@ -1783,6 +1866,14 @@ export default function SettingsPage() {
                     custom_headers_submitted: customHeadersSubmitted,
                     ...(customHeadersSubmitted ? { custom_headers: customHeaders } : {}),
                     custom_body_template: formData.get('custom_body_template') || '',
+                    ...(messageTemplatesSubmitted
+                      ? {
+                          message_templates_submitted: true,
+                          template_bodies: templateBodies,
+                          template_country_locales: templateCountryLocales,
+                          template_default_locale: formData.get('sms_default_locale') || '',
+                        }
+                      : {}),
                   })
                 }}
                 className="space-y-4"
@@ -1988,6 +2079,54 @@ export default function SettingsPage() {
                   </div>
                 )}
 
+                {(smsProvider === 'twilio' || smsProvider === 'custom') && (
+                  <div className="space-y-3 rounded-md border p-4">
+                    <div>
+                      <Label>{t.admin.smsMessageTemplates}</Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.smsMessageTemplatesHint}
+                      </p>
+                    </div>
+                    <div>
+                      <Label>{t.admin.smsDefaultLocale}</Label>
+                      <Input
+                        name="sms_default_locale"
+                        defaultValue={settingsData?.sms?.templates?.default_locale || ''}
+                        placeholder="en"
+                        className="mt-1.5"
+                      />
+                    </div>
+                    <div>
+                      <Label>{t.admin.smsCountryLocales}</Label>
+                      <Input
+                        name="sms_country_locales"
+                        defaultValue={JSON.stringify(
+                          settingsData?.sms?.templates?.country_locales || {}
+                        )}
+                        className="mt-1.5"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.smsCountryLocalesHint}
+                      </p>
+                    </div>
+                    <div>
+                      <Label>{t.admin.smsTemplateBodies}</Label>
+                      <textarea
+                        name="sms_template_bodies"
+                        defaultValue={JSON.stringify(
+                          settingsData?.sms?.templates?.bodies || {},
+                          null,
+                          2
+                        )}
+                        className="mt-1.5 min-h-[120px] w-full rounded-md border border-input bg-background px-3 py-2 font-mono text-sm"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.smsTemplateBodiesHint}
+                      </p>
+                    </div>
+                  </div>
+                )}
+
                 <div className="flex gap-2">
                   <Button type="submit" disabled={updateMutation.isPending}>
                     <Save className="mr-2 h-4 w-4" />
@@ -2035,12 +2174,23 @@ export default function SettingsPage() {
                           formData.get('allow_guest_product_browse') === 'on',
                         require_email_verification:
                           formData.get('require_email_verification') === 'on',
+                        email_verification_mode: emailVerificationMode,
+                        email_verification_reminder: {
+                          enabled: formData.get('email_verification_reminder_enabled') === 'on',
+                          schedule_hours: String(
+                            formData.get('email_verification_reminder_hours') || ''
+                          )
+                            .split(',')
+                            .map((s) => parseInt(s.trim(), 10))
+                            .filter((n) => n > 0),
+                        },
                         allow_email_login: formData.get('allow_email_login') === 'on',
                         allow_password_reset: formData.get('allow_password_reset') === 'on',
                         allow_phone_login: formData.get('allow_phone_login') === 'on',
                         allow_phone_register: formData.get('allow_phone_register') === 'on',
                         allow_phone_password_reset:
                           formData.get('allow_phone_password_reset') === 'on',
+                        allow_passkey_login: formData.get('allow_passkey_login') === 'on',
                       },
                     })
                   }}
@@ -2116,6 +2266,62 @@ export default function SettingsPage() {
                     />
                   </div>
 
+                  <div>
+                    <Label>{t.admin.emailVerificationMode}</Label>
+                    <Select value={emailVerificationMode} onValueChange={setEmailVerificationMode}>
+                      <SelectTrigger className="mt-1.5">
+                        <SelectValue />
+                      </SelectTrigger>
+                      <SelectContent>
+                        <SelectItem value="login">{t.admin.emailVerificationModeLogin}</SelectItem>
+                        <SelectItem value="checkout">
+                          {t.admin.emailVerificationModeCheckout}
+                        </SelectItem>
+                        <SelectItem value="virtual_reveal">
+                          {t.admin.emailVerificationModeVirtualReveal}
+                        </SelectItem>
+                        <SelectItem value="warn">{t.admin.emailVerificationModeWarn}</SelectItem>
+                      </SelectContent>
+                    </Select>
+                    <p className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.emailVerificationModeHint}
+                    </p>
+                  </div>
+
+                  <div className="flex items-center justify-between">
+                    <div>
+                      <Label htmlFor="email_verification_reminder_enabled">
+                        {t.admin.emailVerificationReminder}
+                      </Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.emailVerificationReminderHint}
+                      </p>
+                    </div>
+                    <Switch
+                      id="email_verification_reminder_enabled"
+                      name="email_verification_reminder_enabled"
+                      defaultChecked={
+                        settingsData?.security?.login?.email_verification_reminder?.enabled
+                      }
+                    />
+                  </div>
+
+                  <div>
+                    <Label htmlFor="email_verification_reminder_hours">
+                      {t.admin.emailVerificationReminderHours}
+                    </Label>
+                    <Input
+                      id="email_verification_reminder_hours"
+                      name="email_verification_reminder_hours"
+                      defaultValue={(
+                        settingsData?.security?.login?.email_verification_reminder
+                          ?.schedule_hours || [24, 72, 168]
+                      ).join(', ')}
+                      placeholder="24, 72, 168"
+                      className="mt-1.5"
+                    />
+                  </div>
+
                   <div className="flex items-center justify-between">
                     <div>
                       <Label htmlFor="allow_email_login">{t.admin.allowEmailLogin}</Label>
@@ -2193,6 +2399,25 @@ export default function SettingsPage() {
                     />
                   </div>
 
+                  {/* Passkey */}
+                  <div className="border-b pb-1 pt-2 text-sm font-medium text-muted-foreground">
+                    {t.admin.loginCategoryPasskey}
+                  </div>
+
+                  <div className="flex items-center justify-between">
+                    <div>
+                      <Label htmlFor="allow_passkey_login">{t.admin.allowPasskeyLogin}</Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.allowPasskeyLoginHint}
+                      </p>
+                    </div>
+                    <Switch
+                      id="allow_passkey_login"
+                      name="allow_passkey_login"
+                      defaultChecked={settingsData?.security?.login?.allow_passkey_login}
+                    />
+                  </div>
+
                   <Button type="submit" disabled={updateMutation.isPending}>
                     <Save className="mr-2 h-4 w-4" />
                     {t.admin.saveSettings}
@@ -2624,6 +2849,7 @@ export default function SettingsPage() {
                     order_create: parseInt(formData.get('order_create') as string) || 30,
                     payment_info: parseInt(formData.get('payment_info') as string) || 120,
                     payment_select: parseInt(formData.get('payment_select') as string) || 60,
+                    policies: readRateLimitPolicies(formData),
                   })
                 }}
                 className="space-y-4"
@@ -2731,6 +2957,76 @@ export default function SettingsPage() {
                   </div>
                 </div>
 
+                <div className="space-y-3 rounded-md border p-4">
+                  <div>
+                    <Label>{t.admin.rateLimitPolicies}</Label>
+                    <p className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.rateLimitPoliciesHint}
+                    </p>
+                  </div>
+                  {RATE_LIMIT_POLICIES.map(({ name, labelKey, defaults }) => {
+                    const policy = settingsData?.rate_limit?.policies?.[name]
+                    return (
+                      <div key={name} className="grid grid-cols-2 gap-3 md:grid-cols-5">
+                        <div className="col-span-2 flex items-end pb-2 md:col-span-1">
+                          <span className="text-sm font-medium">{t.admin[labelKey]}</span>
+                        </div>
+                        <div>
+                          <Label htmlFor={`policy_${name}_rate`}>
+                            {t.admin.rateLimitPolicyRate}
+                          </Label>
+                          <Input
+                            id={`policy_${name}_rate`}
+                            name={`policy_${name}_rate`}
+                            type="number"
+                            min="0"
+                            defaultValue={policy?.rate ?? defaults.rate}
+                            className="mt-1.5"
+                          />
+                        </div>
+                        <div>
+                          <Label htmlFor={`policy_${name}_period`}>
+                            {t.admin.rateLimitPolicyPeriod}
+                          </Label>
+                          <Input
+                            id={`policy_${name}_period`}
+                            name={`policy_${name}_period`}
+                            type="number"
+                            min="1"
+                            defaultValue={policy?.period_seconds || 60}
+                            className="mt-1.5"
+                          />
+                        </div>
+                        <div>
+                          <Label htmlFor={`policy_${name}_burst`}>
+                            {t.admin.rateLimitPolicyBurst}
+                          </Label>
+                          <Input
+                            id={`policy_${name}_burst`}
+                            name={`policy_${name}_burst`}
+                            type="number"
+                            min="0"
+                            defaultValue={policy?.burst ?? defaults.burst}
+                            className="mt-1.5"
+                          />
+                        </div>
+                        <div>
+                          <Label htmlFor={`policy_${name}_keys`}>
+                            {t.admin.rateLimitPolicyKeys}
+                          </Label>
+                          <Input
+                            id={`policy_${name}_keys`}
+                            name={`policy_${name}_keys`}
+                            defaultValue={policy?.keys?.join(',') ?? defaults.keys}
+                            placeholder="ip,user,api_key"
+                            className="mt-1.5"
+                          />
+                        </div>
+                      </div>
+                    )
+                  })}
+                </div>
+
                 <Button type="submit" disabled={updateMutation.isPending}>
                   <Save className="mr-2 h-4 w-4" />
                   {t.admin.saveSettings}
@@ -3289,6 +3585,8 @@ export default function SettingsPage() {
                 </form>
               </CardContent>
             </Card>
+
+            <MessageTestConsole />
           </div>
         </TabsContent>
 
@@ -3997,6 +4295,10 @@ export default function SettingsPage() {
                       max_content_length:
                         parseInt(formData.get('max_content_length') as string) || 0,
                       auto_close_hours: parseInt(formData.get('auto_close_hours') as string) || 0,
+                      dispute_escalation_days:
+                        parseInt(formData.get('dispute_escalation_days') as string) || 0,
+                      dispute_evidence_days:
+                        parseInt(formData.get('dispute_evidence_days') as string) || 0,
                     })
                   }}
                   className="space-y-4"
@@ -4080,6 +4382,41 @@ export default function SettingsPage() {
                     </p>
                   </div>
 
+                  <div className="grid gap-4 md:grid-cols-2">
+                    <div>
+                      <Label htmlFor="dispute_escalation_days">
+                        {t.admin.disputeEscalationDays}
+                      </Label>
+                      <Input
+                        id="dispute_escalation_days"
+                        name="dispute_escalation_days"
+                        type="number"
+                        min="0"
+                        max="365"
+                        defaultValue={settingsData?.ticket?.dispute_escalation_days || 0}
+                        className="mt-1.5"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.disputeEscalationDaysHint}
+                      </p>
+                    </div>
+                    <div>
+                      <Label htmlFor="dispute_evidence_days">{t.admin.disputeEvidenceDays}</Label>
+                      <Input
+                        id="dispute_evidence_days"
+                        name="dispute_evidence_days"
+                        type="number"
+                        min="0"
+                        max="90"
+                        defaultValue={settingsData?.ticket?.dispute_evidence_days || 7}
+                        className="mt-1.5"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.disputeEvidenceDaysHint}
+                      </p>
+                    </div>
+                  </div>
+
                   <Button type="submit" disabled={updateMutation.isPending}>
                     <Save className="mr-2 h-4 w-4" />
                     {t.admin.saveSettings}
@@ -4106,10 +4443,18 @@ export default function SettingsPage() {
                       .split(',')
                       .map((s) => s.trim())
                       .filter(Boolean)
+                    const allowedFileTypes = (formData.get('allowed_file_types') as string)
+                      .split(',')
+                      .map((s) => s.trim())
+                      .filter(Boolean)
                     handleSubmit('ticket', {
                       attachment: {
                         enable_image: formData.get('enable_image') === 'on',
                         enable_voice: formData.get('enable_voice') === 'on',
+                        enable_file: formData.get('enable_file') === 'on',
+                        max_file_size:
+                          parseInt(formData.get('max_file_size') as string) * 1024 * 1024,
+                        allowed_file_types: allowedFileTypes,
                         max_image_size:
                           parseInt(formData.get('max_image_size') as string) * 1024 * 1024,
                         max_voice_size:
@@ -4218,6 +4563,52 @@ export default function SettingsPage() {
                     </p>
                   </div>
 
+                  <div className="flex items-center justify-between">
+                    <div>
+                      <Label htmlFor="enable_file">{t.admin.enableFileUpload}</Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.enableFileUploadHint}
+                      </p>
+                    </div>
+                    <Switch
+                      id="enable_file"
+                      name="enable_file"
+                      defaultChecked={settingsData?.ticket?.attachment?.enable_file ?? true}
+                    />
+                  </div>
+
+                  <div className="grid grid-cols-2 gap-4">
+                    <div>
+                      <Label htmlFor="max_file_size">{t.admin.maxFileSize}</Label>
+                      <Input
+                        id="max_file_size"
+                        name="max_file_size"
+                        type="number"
+                        min="1"
+                        max="100"
+                        defaultValue={
+                          (settingsData?.ticket?.attachment?.max_file_size || 10485760) /
+                          1024 /
+                          1024
+                        }
+                        className="mt-1.5"
+                      />
+                    </div>
+                    <div>
+                      <Label htmlFor="allowed_file_types">{t.admin.allowedFileFormats}</Label>
+                      <Input
+                        id="allowed_file_types"
+                        name="allowed_file_types"
+                        defaultValue={
+                          settingsData?.ticket?.attachment?.allowed_file_types?.join(', ') ||
+                          '.pdf, .txt, .csv, .zip'
+                        }
+                        placeholder=".pdf, .txt, .csv, .zip"
+                        className="mt-1.5"
+                      />
+                    </div>
+                  </div>
+
                   <div>
                     <Label htmlFor="retention_days">{t.admin.attachmentRetentionDays}</Label>
                     <Input
@@ -4348,7 +4739,43 @@ export default function SettingsPage() {
                   const formData = new FormData(e.currentTarget)
                   handleSubmit('order', {
                     no_prefix: formData.get('no_prefix'),
+                    number: {
+                      format: formData.get('order_number_format') || 'timestamp',
+                      sequence_digits:
+                        parseInt(formData.get('order_number_sequence_digits') as string) || 6,
+                      random_digits:
+                        parseInt(formData.get('order_number_random_digits') as string) || 10,
+                    },
                     auto_cancel_hours: parseInt(formData.get('auto_cancel_hours') as string),
+                    draft_cleanup: {
+                      retention_days:
+                        parseInt(formData.get('draft_cleanup_retention_days') as string) || 0,
+                      action: formData.get('draft_cleanup_action') || 'delete',
+                    },
+                    recovery: {
+                      enabled: formData.get('recovery_enabled') === 'on',
+                      intervals_hours: String(formData.get('recovery_intervals_hours') || '')
+                        .split(',')
+                        .map((value) => parseInt(value.trim()))
+                        .filter((value) => value > 0),
+                      send_sms: formData.get('recovery_send_sms') === 'on',
+                      campaign: String(formData.get('recovery_campaign') || '').trim(),
+                    },
+                    auto_complete_days: parseInt(formData.get('auto_complete_days') as string) || 0,
+                    auto_complete_reminder_days:
+                      parseInt(formData.get('auto_complete_reminder_days') as string) || 0,
+                    // 留空的商品类型沿用自动完成天数
+                    auto_complete_days_by_type: Object.fromEntries(
+                      (['physical', 'virtual'] as const)
+                        .map((type) => [
+                          type,
+                          String(formData.get(`auto_complete_days_${type}`) ?? '').trim(),
+                        ])
+                        .filter(([, value]) => value !== '')
+                        .map(([type, value]) => [type, parseInt(value) || 0])
+                    ),
+                    abandon_release_minutes:
+                      parseInt(formData.get('abandon_release_minutes') as string) || 0,
                     max_pending_payment_orders_per_user:
                       parseInt(formData.get('max_pending_payment_orders_per_user') as string) || 10,
                     max_payment_polling_tasks_per_user:
@@ -4411,6 +4838,63 @@ export default function SettingsPage() {
                   </p>
                 </div>
 
+                <div>
+                  <Label htmlFor="order_number_format">{t.admin.orderNumberFormat}</Label>
+                  <Select
+                    name="order_number_format"
+                    defaultValue={settingsData?.order?.number?.format || 'timestamp'}
+                  >
+                    <SelectTrigger id="order_number_format" className="mt-1.5">
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      <SelectItem value="timestamp">
+                        {t.admin.orderNumberFormatTimestamp}
+                      </SelectItem>
+                      <SelectItem value="date_sequence">
+                        {t.admin.orderNumberFormatDateSequence}
+                      </SelectItem>
+                      <SelectItem value="random_checksum">
+                        {t.admin.orderNumberFormatRandomChecksum}
+                      </SelectItem>
+                    </SelectContent>
+                  </Select>
+                  <p className="mt-1 text-xs text-muted-foreground">
+                    {t.admin.orderNumberFormatHint}
+                  </p>
+                </div>
+
+                <div className="grid grid-cols-1 gap-4 md:grid-cols-2">
+                  <div>
+                    <Label htmlFor="order_number_sequence_digits">
+                      {t.admin.orderNumberSequenceDigits}
+                    </Label>
+                    <Input
+                      id="order_number_sequence_digits"
+                      name="order_number_sequence_digits"
+                      type="number"
+                      min={1}
+                      max={18}
+                      defaultValue={settingsData?.order?.number?.sequence_digits || 6}
+                      className="mt-1.5"
+                    />
+                  </div>
+                  <div>
+                    <Label htmlFor="order_number_random_digits">
+                      {t.admin.orderNumberRandomDigits}
+                    </Label>
+                    <Input
+                      id="order_number_random_digits"
+                      name="order_number_random_digits"
+                      type="number"
+                      min={1}
+                      max={18}
+                      defaultValue={settingsData?.order?.number?.random_digits || 10}
+                      className="mt-1.5"
+                    />
+                  </div>
+                </div>
+
                 <div>
                   <Label htmlFor="currency">{t.admin.currency}</Label>
                   <Select name="currency" defaultValue={settingsData?.order?.currency || 'CNY'}>
@@ -4448,6 +4932,186 @@ export default function SettingsPage() {
                   </p>
                 </div>
 
+                <div className="grid grid-cols-1 gap-4 md:grid-cols-2">
+                  <div>
+                    <Label htmlFor="draft_cleanup_retention_days">
+                      {t.admin.draftCleanupRetentionDays}
+                    </Label>
+                    <Input
+                      id="draft_cleanup_retention_days"
+                      name="draft_cleanup_retention_days"
+                      type="number"
+                      min={0}
+                      defaultValue={settingsData?.order?.draft_cleanup?.retention_days || 0}
+                      className="mt-1.5"
+                    />
+                    <p className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.draftCleanupRetentionDaysHint}
+                    </p>
+                  </div>
+                  <div>
+                    <Label htmlFor="draft_cleanup_action">{t.admin.draftCleanupAction}</Label>
+                    <Select
+                      name="draft_cleanup_action"
+                      defaultValue={settingsData?.order?.draft_cleanup?.action || 'delete'}
+                    >
+                      <SelectTrigger id="draft_cleanup_action" className="mt-1.5">
+                        <SelectValue />
+                      </SelectTrigger>
+                      <SelectContent>
+                        <SelectItem value="delete">{t.admin.draftCleanupActionDelete}</SelectItem>
+                        <SelectItem value="anonymize">
+                          {t.admin.draftCleanupActionAnonymize}
+                        </SelectItem>
+                      </SelectContent>
+                    </Select>
+                  </div>
+                </div>
+                <DraftCleanupActions />
+
+                <div className="border-t border-border pt-4">
+                  <h4 className="mb-3 font-medium">{t.admin.orderRecoveryTitle}</h4>
+                  <p className="mb-4 text-xs text-muted-foreground">{t.admin.orderRecoveryDesc}</p>
+                  <div className="flex items-center justify-between rounded-lg border border-border/70 bg-muted/20 px-4 py-3">
+                    <div>
+                      <Label htmlFor="recovery_enabled">{t.admin.orderRecoveryEnabled}</Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.orderRecoveryEnabledHint}
+                      </p>
+                    </div>
+                    <Switch
+                      id="recovery_enabled"
+                      name="recovery_enabled"
+                      defaultChecked={settingsData?.order?.recovery?.enabled || false}
+                    />
+                  </div>
+                  <div className="mt-4 grid grid-cols-1 gap-4 md:grid-cols-2">
+                    <div>
+                      <Label htmlFor="recovery_intervals_hours">
+                        {t.admin.orderRecoveryIntervals}
+                      </Label>
+                      <Input
+                        id="recovery_intervals_hours"
+                        name="recovery_intervals_hours"
+                        placeholder="1, 24"
+                        defaultValue={
+                          settingsData?.order?.recovery?.intervals_hours?.join(', ') || ''
+                        }
+                        className="mt-1.5"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.orderRecoveryIntervalsHint}
+                      </p>
+                    </div>
+                    <div>
+                      <Label htmlFor="recovery_campaign">{t.admin.orderRecoveryCampaign}</Label>
+                      <Input
+                        id="recovery_campaign"
+                        name="recovery_campaign"
+                        maxLength={100}
+                        placeholder="order_recovery"
+                        defaultValue={settingsData?.order?.recovery?.campaign || ''}
+                        className="mt-1.5"
+                      />
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.orderRecoveryCampaignHint}
+                      </p>
+                    </div>
+                  </div>
+                  <div className="mt-4 flex items-center justify-between rounded-lg border border-border/70 bg-muted/20 px-4 py-3">
+                    <div>
+                      <Label htmlFor="recovery_send_sms">{t.admin.orderRecoverySendSms}</Label>
+                      <p className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.orderRecoverySendSmsHint}
+                      </p>
+                    </div>
+                    <Switch
+                      id="recovery_send_sms"
+                      name="recovery_send_sms"
+                      defaultChecked={settingsData?.order?.recovery?.send_sms || false}
+                    />
+                  </div>
+                </div>
+
+                <div className="grid grid-cols-1 gap-4 md:grid-cols-2">
+                  <div>
+                    <Label htmlFor="auto_complete_days">{t.admin.autoCompleteDays}</Label>
+                    <Input
+                      id="auto_complete_days"
+                      name="auto_complete_days"
+                      type="number"
+                      min="0"
+                      defaultValue={settingsData?.order?.auto_complete_days ?? 0}
+                      className="mt-1.5"
+                    />
+                    <p className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.autoCompleteDaysHint}
+                    </p>
+                  </div>
+                  <div>
+                    <Label htmlFor="auto_complete_reminder_days">
+                      {t.admin.autoCompleteReminderDays}
+                    </Label>
+                    <Input
+                      id="auto_complete_reminder_days"
+                      name="auto_complete_reminder_days"
+                      type="number"
+                      min="0"
+                      defaultValue={settingsData?.order?.auto_complete_reminder_days ?? 0}
+                      className="mt-1.5"
+                    />
+                    <p className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.autoCompleteReminderDaysHint}
+                    </p>
+                  </div>
+                  <div>
+                    <Label htmlFor="auto_complete_days_physical">
+                      {t.admin.autoCompleteDaysPhysical}
+                    </Label>
+                    <Input
+                      id="auto_complete_days_physical"
+                      name="auto_complete_days_physical"
+                      type="number"
+                      min="0"
+                      placeholder={t.admin.autoCompleteDaysInherit}
+                      defaultValue={settingsData?.order?.auto_complete_days_by_type?.physical ?? ''}
+                      className="mt-1.5"
+                    />
+                  </div>
+                  <div>
+                    <Label htmlFor="auto_complete_days_virtual">
+                      {t.admin.autoCompleteDaysVirtual}
+                    </Label>
+                    <Input
+                      id="auto_complete_days_virtual"
+                      name="auto_complete_days_virtual"
+                      type="number"
+                      min="0"
+                      placeholder={t.admin.autoCompleteDaysInherit}
+                      defaultValue={settingsData?.order?.auto_complete_days_by_type?.virtual ?? ''}
+                      className="mt-1.5"
+                    />
+                  </div>
+                  <p className="text-xs text-muted-foreground md:col-span-2">
+                    {t.admin.autoCompleteDaysByTypeHint}
+                  </p>
+                </div>
+
+                <div>
+                  <Label htmlFor="abandon_release_minutes">{t.admin.abandonReleaseMinutes}</Label>
+                  <Input
+                    id="abandon_release_minutes"
+                    name="abandon_release_minutes"
+                    type="number"
+                    min="0"
+                    defaultValue={settingsData?.order?.abandon_release_minutes ?? 0}
+                    className="mt-1.5"
+                  />
+                  <p className="mt-1 text-xs text-muted-foreground">
+                    {t.admin.abandonReleaseMinutesHint}
+                  </p>
+                </div>
+
                 <div className="grid grid-cols-1 gap-4 md:grid-cols-3">
                   <div>
                     <Label htmlFor="max_pending_payment_orders_per_user">
@@ -4936,6 +5600,9 @@ export default function SettingsPage() {
                               className="mt-1.5"
                               placeholder="https://"
                             />
+                            <p className="mt-1 text-xs text-muted-foreground">
+                              {t.admin.invoiceCompanyLogoHint}
+                            </p>
                           </div>
                           <div>
                             <Label htmlFor="invoice_footer_text">{t.admin.invoiceFooterText}</Label>
@@ -5002,6 +5669,9 @@ export default function SettingsPage() {
                           />
                         </div>
                       )}
+
+                      <TemplateAssetsPanel />
+                      <DocumentTypesPanel />
                     </div>
                   )}
                 </div>
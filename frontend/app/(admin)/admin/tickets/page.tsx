@@ -20,15 +20,29 @@ import {
   getAdminTicketSharedOrder,
   getTicketStats,
   uploadAdminTicketFile,
+  uploadAdminTicketAttachment,
+  deleteAdminTicket,
   getPublicConfig,
   Ticket,
   TicketMessage,
+  TicketAttachment,
 } from '@/lib/api'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
 import { Badge } from '@/components/ui/badge'
 import { MarkdownMessage } from '@/components/ui/markdown-message'
 import { MessageToolbar } from '@/components/ticket/message-toolbar'
+import { TicketAttachmentList } from '@/components/ticket/ticket-attachment-list'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
 import {
   Select,
   SelectContent,
@@ -46,7 +60,10 @@ import {
   MapPin,
   Truck,
   MessageSquare,
+  BarChart3,
+  Trash2,
 } from 'lucide-react'
+import Link from 'next/link'
 import { useToast } from '@/hooks/use-toast'
 import { TICKET_STATUS_CONFIG, TICKET_PRIORITY_CONFIG } from '@/lib/constants'
 import { format, formatDistanceToNow } from 'date-fns'
@@ -62,6 +79,7 @@ import { PluginExtensionList } from '@/components/plugins/plugin-extension-list'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 import { resolveApiErrorMessage } from '@/lib/api-error'
 import { usePluginExtensionBatch } from '@/lib/plugin-extension-batch'
+import { usePermission } from '@/hooks/use-permission'
 
 function resolveTicketMessageOrderID(msg: TicketMessage): number | null {
   if (msg.content_type !== 'order' || !msg.metadata) return null
@@ -135,12 +153,15 @@ function AdminTicketsPageContent() {
   const [search, setSearch] = useState('')
   const [assignedTo, setAssignedTo] = useState('')
   const [message, setMessage] = useState('')
+  const [pendingAttachments, setPendingAttachments] = useState<TicketAttachment[]>([])
+  const [deleteOpen, setDeleteOpen] = useState(false)
   const [viewingOrderId, setViewingOrderId] = useState<number | null>(null)
   const messagesEndRef = useRef<HTMLDivElement>(null)
   const ticketListRef = useRef<HTMLDivElement>(null)
   const sentinelRef = useRef<HTMLDivElement>(null)
   const queryClient = useQueryClient()
   const toast = useToast()
+  const { hasPermission } = usePermission()
   const { locale } = useLocale()
   const t = getTranslations(locale)
   usePageTitle(t.pageTitle.adminTickets)
@@ -282,9 +303,14 @@ function AdminTicketsPageContent() {
 
   // 发送消息
   const sendMessageMutation = useMutation({
-    mutationFn: (content: string) => sendAdminTicketMessage(selectedTicketId!, { content }),
+    mutationFn: (content: string) =>
+      sendAdminTicketMessage(selectedTicketId!, {
+        content,
+        attachment_ids: pendingAttachments.map((attachment) => attachment.id),
+      }),
     onSuccess: () => {
       setMessage('')
+      setPendingAttachments([])
       queryClient.invalidateQueries({ queryKey: ['adminTicketMessages', selectedTicketId] })
       queryClient.invalidateQueries({ queryKey: ['adminTicket', selectedTicketId] })
       queryClient.invalidateQueries({ queryKey: ['adminTickets'] })
@@ -309,6 +335,20 @@ function AdminTicketsPageContent() {
     },
   })
 
+  // 删除工单（移入回收站）
+  const deleteTicketMutation = useMutation({
+    mutationFn: (id: number) => deleteAdminTicket(id),
+    onSuccess: () => {
+      toast.success(t.ticket.deleteTicketSuccess)
+      setSelectedTicketId(null)
+      queryClient.invalidateQueries({ queryKey: ['adminTickets'] })
+      queryClient.invalidateQueries({ queryKey: ['ticketStats'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveTicketError(error, t.ticket.deleteTicketFailed))
+    },
+  })
+
   const tickets: Ticket[] = useMemo(() => {
     if (status) {
       return filteredInfiniteData?.pages.flatMap((p: any) => p?.data?.items || []) || []
@@ -490,7 +530,7 @@ function AdminTicketsPageContent() {
   }, [hasMore, loadingMore, loadMore])
 
   const handleSend = () => {
-    if (!message.trim()) return
+    if (!message.trim() && pendingAttachments.length === 0) return
     if (maxContentLength > 0 && message.trim().length > maxContentLength) {
       toast.error(t.ticket.contentTooLong.replace('{max}', String(maxContentLength)))
       return
@@ -508,6 +548,18 @@ function AdminTicketsPageContent() {
     }
   }
 
+  const handleAttachFile = async (file: File) => {
+    try {
+      const res = await uploadAdminTicketAttachment(selectedTicketId!, file)
+      if (res.data) {
+        setPendingAttachments((prev) => [...prev, res.data as TicketAttachment])
+      }
+    } catch (error) {
+      toast.error(resolveTicketError(error, t.ticket.uploadFailed))
+      throw error
+    }
+  }
+
   // 状态颜色映射（支持暗色模式）
   const statusColorMap: Record<string, string> = {
     open: 'bg-yellow-500/20 text-yellow-700 dark:text-yellow-400 border-yellow-500/30',
@@ -551,7 +603,15 @@ function AdminTicketsPageContent() {
       />
       {/* 顶部统计栏 - 更紧凑 */}
       <div className="flex flex-col gap-3 border-b px-4 py-2 md:flex-row md:items-center md:justify-between">
-        <h1 className="text-xl font-bold">{t.ticket.ticketManagement}</h1>
+        <div className="flex items-center gap-3">
+          <h1 className="text-xl font-bold">{t.ticket.ticketManagement}</h1>
+          <Button variant="outline" size="sm" asChild>
+            <Link href="/admin/tickets/performance">
+              <BarChart3 className="mr-1.5 h-4 w-4" />
+              {t.ticket.agentPerformance}
+            </Link>
+          </Button>
+        </div>
         {stats && (
           <div className="flex flex-wrap gap-3 text-sm">
             <span>
@@ -645,6 +705,7 @@ function AdminTicketsPageContent() {
                         selectedTicketId === ticket.id && 'bg-accent'
                       )}
                       onClick={() => {
+                        if (selectedTicketId !== ticket.id) setPendingAttachments([])
                         setSelectedTicketId(ticket.id)
                         queryClient.invalidateQueries({
                           queryKey: ['adminTicketMessages', ticket.id],
@@ -748,6 +809,19 @@ function AdminTicketsPageContent() {
                         ))}
                       </SelectContent>
                     </Select>
+                    {hasPermission('ticket.status_update') && (
+                      <Button
+                        variant="ghost"
+                        size="icon"
+                        className="h-8 w-8 shrink-0 text-destructive"
+                        onClick={() => setDeleteOpen(true)}
+                        disabled={deleteTicketMutation.isPending}
+                        title={t.ticket.deleteTicket}
+                        aria-label={t.ticket.deleteTicket}
+                      >
+                        <Trash2 className="h-4 w-4" />
+                      </Button>
+                    )}
                   </div>
                   <p className="mt-1 text-xs text-muted-foreground">
                     #{selectedTicket.ticket_no} |{' '}
@@ -891,10 +965,18 @@ function AdminTicketsPageContent() {
                                   </div>
                                 )
                               ) : (
-                                <MarkdownMessage
-                                  content={msg.content}
-                                  isOwnMessage={msg.sender_type === 'admin'}
-                                />
+                                <>
+                                  {msg.content ? (
+                                    <MarkdownMessage
+                                      content={msg.content}
+                                      isOwnMessage={msg.sender_type === 'admin'}
+                                    />
+                                  ) : null}
+                                  <TicketAttachmentList
+                                    attachments={msg.attachments}
+                                    isOwnMessage={msg.sender_type === 'admin'}
+                                  />
+                                </>
                               )}
                             </div>
                             {rowExtensions.length > 0 ? (
@@ -931,6 +1013,15 @@ function AdminTicketsPageContent() {
                     enableImage={ticketAttachment?.enable_image ?? true}
                     enableVoice={ticketAttachment?.enable_voice ?? true}
                     acceptImageTypes={ticketAttachment?.allowed_image_types}
+                    enableFile={ticketAttachment?.enable_file ?? false}
+                    acceptFileTypes={ticketAttachment?.allowed_file_types}
+                    attachments={pendingAttachments}
+                    onAttachFile={handleAttachFile}
+                    onRemoveAttachment={(id) =>
+                      setPendingAttachments((prev) =>
+                        prev.filter((attachment) => attachment.id !== id)
+                      )
+                    }
                     maxLength={maxContentLength > 0 ? maxContentLength : undefined}
                     pluginSlotNamespace="admin.ticket_detail.composer"
                     pluginSlotContext={{
@@ -1194,6 +1285,26 @@ function AdminTicketsPageContent() {
           )}
         </DialogContent>
       </Dialog>
+
+      <AlertDialog open={deleteOpen} onOpenChange={setDeleteOpen}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.ticket.deleteTicket}</AlertDialogTitle>
+            <AlertDialogDescription>{t.ticket.deleteTicketConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (selectedTicketId) deleteTicketMutation.mutate(selectedTicketId)
+                setDeleteOpen(false)
+              }}
+            >
+              {t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
     </div>
   )
 }
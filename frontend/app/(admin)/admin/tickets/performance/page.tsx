@@ -0,0 +1,191 @@
+'use client'
+
+import { useState } from 'react'
+import { useQuery } from '@tanstack/react-query'
+import Link from 'next/link'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Download } from 'lucide-react'
+import { getTicketAgentPerformance, TicketAgentPerformance } from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { DataTable } from '@/components/admin/data-table'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Card, CardContent } from '@/components/ui/card'
+import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+function formatDuration(seconds: number) {
+  if (!seconds) return '-'
+  if (seconds < 60) return `${seconds}s`
+  const minutes = Math.floor(seconds / 60)
+  if (minutes < 60) return `${minutes}m`
+  const hours = Math.floor(minutes / 60)
+  if (hours < 24) return `${hours}h ${minutes % 60}m`
+  return `${Math.floor(hours / 24)}d ${hours % 24}h`
+}
+
+export default function AdminTicketPerformancePage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminTicketPerformance)
+
+  const [endDate, setEndDate] = useState(() => formatDateInput(new Date()))
+  const [startDate, setStartDate] = useState(() =>
+    formatDateInput(new Date(Date.now() - 29 * 24 * 60 * 60 * 1000))
+  )
+  const [groupBy, setGroupBy] = useState<'agent' | 'day'>('agent')
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['ticketAgentPerformance', startDate, endDate, groupBy],
+    queryFn: () =>
+      getTicketAgentPerformance({ start_date: startDate, end_date: endDate, group_by: groupBy }),
+    enabled: !!startDate && !!endDate,
+  })
+  const items: TicketAgentPerformance[] = data?.data?.items || []
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExport = () => {
+    const params = new URLSearchParams({
+      start_date: startDate,
+      end_date: endDate,
+      group_by: groupBy,
+    })
+    const url = resolveClientAPIProxyURL(
+      `/api/admin/tickets/agent-performance/export?${params.toString()}`
+    )
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `agent_performance_${startDate}_${endDate}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+        toast.success(t.ticket.perfExportSuccess)
+      })
+      .catch((err) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const columns = [
+    ...(groupBy === 'day'
+      ? [
+          {
+            header: t.ticket.perfDate,
+            accessorKey: 'stat_date',
+          },
+        ]
+      : []),
+    {
+      header: t.ticket.adminAgent,
+      cell: ({ row }: { row: { original: TicketAgentPerformance } }) => (
+        <div>
+          <div className="font-medium">
+            {row.original.agent_name || `#${row.original.agent_id}`}
+          </div>
+          <div className="text-xs text-muted-foreground">{row.original.agent_email}</div>
+        </div>
+      ),
+    },
+    {
+      header: t.ticket.perfTicketsHandled,
+      accessorKey: 'tickets_handled',
+    },
+    {
+      header: t.ticket.perfAvgFirstResponse,
+      cell: ({ row }: { row: { original: TicketAgentPerformance } }) => {
+        const avg = formatDuration(row.original.avg_first_response_seconds)
+        return `${avg} (${row.original.first_response_count})`
+      },
+    },
+    {
+      header: t.ticket.perfResolved,
+      accessorKey: 'resolved_count',
+    },
+    {
+      header: t.ticket.perfAvgResolution,
+      cell: ({ row }: { row: { original: TicketAgentPerformance } }) =>
+        formatDuration(row.original.avg_resolution_seconds),
+    },
+    {
+      header: t.ticket.perfCsat,
+      cell: ({ row }: { row: { original: TicketAgentPerformance } }) =>
+        row.original.rating_count > 0
+          ? `${row.original.avg_rating.toFixed(2)} (${row.original.rating_count})`
+          : '-',
+    },
+  ]
+
+  return (
+    <div className="space-y-4 p-4">
+      <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+        <div className="flex items-center gap-2">
+          <Button variant="outline" size="icon" asChild className="h-8 w-8">
+            <Link href="/admin/tickets">
+              <ArrowLeft className="h-4 w-4" />
+              <span className="sr-only">{t.ticket.ticketManagement}</span>
+            </Link>
+          </Button>
+          <div>
+            <h1 className="text-xl font-bold">{t.ticket.agentPerformance}</h1>
+            <p className="text-sm text-muted-foreground">{t.ticket.agentPerformanceDesc}</p>
+          </div>
+        </div>
+        <Button variant="outline" onClick={handleExport}>
+          <Download className="mr-2 h-4 w-4" />
+          {t.ticket.perfExport}
+        </Button>
+      </div>
+
+      <Card>
+        <CardContent className="flex flex-col gap-3 pt-6 md:flex-row md:items-end">
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.ticket.perfStartDate}</label>
+            <Input type="date" value={startDate} onChange={(e) => setStartDate(e.target.value)} />
+          </div>
+          <div className="space-y-1">
+            <label className="text-sm font-medium">{t.ticket.perfEndDate}</label>
+            <Input type="date" value={endDate} onChange={(e) => setEndDate(e.target.value)} />
+          </div>
+          <Tabs value={groupBy} onValueChange={(value) => setGroupBy(value as 'agent' | 'day')}>
+            <TabsList>
+              <TabsTrigger value="agent">{t.ticket.perfGroupByAgent}</TabsTrigger>
+              <TabsTrigger value="day">{t.ticket.perfGroupByDay}</TabsTrigger>
+            </TabsList>
+          </Tabs>
+        </CardContent>
+      </Card>
+
+      {!isLoading && items.length === 0 ? (
+        <p className="py-8 text-center text-sm text-muted-foreground">{t.ticket.perfNoData}</p>
+      ) : (
+        <DataTable columns={columns} data={items} isLoading={isLoading} />
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,16 @@
+import { Skeleton, TableSkeleton } from '@/components/ui/page-loading'
+
+export default function Loading() {
+  return (
+    <div className="space-y-6">
+      {/* 标题 */}
+      <Skeleton className="h-8 w-32" />
+
+      {/* 类型 */}
+      <Skeleton className="h-10 w-96" />
+
+      {/* 表格 */}
+      <TableSkeleton rows={10} cols={6} />
+    </div>
+  )
+}
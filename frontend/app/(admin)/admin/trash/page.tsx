@@ -0,0 +1,183 @@
+'use client'
+
+import { useState } from 'react'
+import { useQuery, useMutation } from '@tanstack/react-query'
+import { getAdminTrash, restoreTrashItem } from '@/lib/api'
+import { DataTable } from '@/components/admin/data-table'
+import { Button } from '@/components/ui/button'
+import { ArchiveRestore, RefreshCw } from 'lucide-react'
+import toast from 'react-hot-toast'
+import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { usePermission } from '@/hooks/use-permission'
+
+type TrashEntityType = 'product' | 'promo_code' | 'virtual_inventory' | 'ticket'
+
+interface TrashItem {
+  entity_type: TrashEntityType
+  id: number
+  name: string
+  identifier?: string
+  deleted_at: string
+  deleted_by?: number
+  deleted_by_name?: string
+  purge_at: string
+}
+
+export default function AdminTrashPage() {
+  const [entityType, setEntityType] = useState<TrashEntityType>('product')
+  const [page, setPage] = useState(1)
+  const [restoreTarget, setRestoreTarget] = useState<TrashItem | null>(null)
+  const { locale } = useLocale()
+  const { hasPermission } = usePermission()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminTrash)
+  const canRestore = hasPermission('product.delete')
+
+  const { data, isLoading, refetch } = useQuery({
+    queryKey: ['adminTrash', entityType, page],
+    queryFn: () => getAdminTrash({ type: entityType, page, limit: 20 }),
+  })
+
+  const restoreMutation = useMutation({
+    mutationFn: (item: TrashItem) => restoreTrashItem(item.entity_type, item.id),
+    onSuccess: () => {
+      toast.success(t.admin.trashRestored)
+      setRestoreTarget(null)
+      refetch()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.trashRestoreFailed))
+    },
+  })
+
+  const columns = [
+    {
+      header: t.admin.trashName,
+      accessorKey: 'name',
+    },
+    {
+      header: t.admin.trashIdentifier,
+      cell: ({ row }: { row: { original: TrashItem } }) => (
+        <div className="font-mono text-sm">{row.original.identifier || '-'}</div>
+      ),
+    },
+    {
+      header: t.admin.trashDeletedBy,
+      cell: ({ row }: { row: { original: TrashItem } }) => {
+        const item = row.original
+        if (!item.deleted_by) {
+          return <span className="text-muted-foreground">{t.admin.trashSystem}</span>
+        }
+        return <div>{item.deleted_by_name || `#${item.deleted_by}`}</div>
+      },
+    },
+    {
+      header: t.admin.trashDeletedAt,
+      cell: ({ row }: { row: { original: TrashItem } }) => (
+        <div>{new Date(row.original.deleted_at).toLocaleString()}</div>
+      ),
+    },
+    {
+      header: t.admin.trashPurgeAt,
+      cell: ({ row }: { row: { original: TrashItem } }) => (
+        <div className="text-muted-foreground">
+          {new Date(row.original.purge_at).toLocaleString()}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: TrashItem } }) =>
+        canRestore ? (
+          <Button size="sm" variant="outline" onClick={() => setRestoreTarget(row.original)}>
+            <ArchiveRestore className="mr-1 h-4 w-4" />
+            {t.admin.trashRestore}
+          </Button>
+        ) : null,
+    },
+  ]
+  const items: TrashItem[] = data?.data?.items || []
+  const total = Number(data?.data?.pagination?.total || 0)
+
+  return (
+    <div className="space-y-6">
+      <div className="flex flex-col gap-4 md:flex-row md:items-start md:justify-between">
+        <div>
+          <h1 className="text-3xl font-bold">{t.admin.trashManagement}</h1>
+          <p className="mt-1 text-sm text-muted-foreground">
+            {t.admin.totalRecords.replace('{count}', String(total))}
+          </p>
+          <p className="mt-2 text-xs text-muted-foreground">{t.admin.trashDescription}</p>
+        </div>
+        <Button variant="outline" onClick={() => refetch()}>
+          <RefreshCw className="mr-2 h-4 w-4" />
+          {t.admin.refresh}
+        </Button>
+      </div>
+
+      <Tabs
+        value={entityType}
+        onValueChange={(value) => {
+          setEntityType(value as TrashEntityType)
+          setPage(1)
+        }}
+      >
+        <TabsList>
+          <TabsTrigger value="product">{t.admin.trashTypeProduct}</TabsTrigger>
+          <TabsTrigger value="promo_code">{t.admin.trashTypePromoCode}</TabsTrigger>
+          <TabsTrigger value="virtual_inventory">{t.admin.trashTypeVirtualInventory}</TabsTrigger>
+          <TabsTrigger value="ticket">{t.admin.trashTypeTicket}</TabsTrigger>
+        </TabsList>
+      </Tabs>
+
+      {!isLoading && items.length === 0 ? (
+        <p className="py-12 text-center text-sm text-muted-foreground">{t.admin.trashEmpty}</p>
+      ) : (
+        <DataTable
+          columns={columns}
+          data={items}
+          isLoading={isLoading}
+          pagination={{
+            page,
+            total_pages: data?.data?.pagination?.total_pages || 1,
+            onPageChange: setPage,
+          }}
+        />
+      )}
+
+      <AlertDialog open={restoreTarget !== null} onOpenChange={() => setRestoreTarget(null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.admin.trashRestore}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.admin.trashConfirmRestore.replace('{name}', restoreTarget?.name || '')}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => restoreTarget && canRestore && restoreMutation.mutate(restoreTarget)}
+              disabled={restoreMutation.isPending}
+            >
+              {t.admin.trashRestore}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -1,10 +1,11 @@
 'use client'
 
 import { use } from 'react'
-import { useQuery } from '@tanstack/react-query'
-import { getPublicConfig, getUserDetail } from '@/lib/api'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { getPublicConfig, getUserDetail, updateUser } from '@/lib/api'
 import { Card, CardHeader, CardTitle, CardContent } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
+import { Switch } from '@/components/ui/switch'
 import { ArrowLeft, Calendar, Copy, Mail, Shield, User } from 'lucide-react'
 import Link from 'next/link'
 import { formatDate, formatPrice } from '@/lib/utils'
@@ -12,7 +13,11 @@ import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { useToast } from '@/hooks/use-toast'
+import { resolveApiErrorMessage } from '@/lib/api-error'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { UserConsentCard } from '@/components/admin/user-consent-card'
+import { UserSpendingControlCard } from '@/components/admin/user-spending-control-card'
+import { UserTwoFactorCard } from '@/components/admin/user-two-factor-card'
 
 export default function UserDetailPage({ params }: { params: Promise<{ id: string }> }) {
   const { id } = use(params)
@@ -20,6 +25,7 @@ export default function UserDetailPage({ params }: { params: Promise<{ id: strin
   const { locale } = useLocale()
   const t = getTranslations(locale)
   const toast = useToast()
+  const queryClient = useQueryClient()
   usePageTitle(t.pageTitle.adminUserDetail)
 
   const roleLabels: Record<string, string> = {
@@ -38,6 +44,16 @@ export default function UserDetailPage({ params }: { params: Promise<{ id: strin
     queryFn: getPublicConfig,
     staleTime: 5 * 60 * 1000,
   })
+  const exemptMutation = useMutation({
+    mutationFn: (exempt: boolean) => updateUser(userId, { email_verification_exempt: exempt }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['userDetail', userId] })
+      toast.success(t.admin.emailVerificationExemptUpdated)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.emailVerificationExemptUpdateFailed))
+    },
+  })
 
   if (isLoading) {
     return <div className="py-12 text-center">{t.common.loading}</div>
@@ -49,6 +65,7 @@ export default function UserDetailPage({ params }: { params: Promise<{ id: strin
 
   const user = data.data
   const currency = publicConfigData?.data?.currency || 'CNY'
+  const spendingControlsEnabled = Boolean(publicConfigData?.data?.spending_controls_enabled)
   const adminUserDetailPluginContext = {
     view: 'admin_user_detail',
     user: {
@@ -216,6 +233,19 @@ export default function UserDetailPage({ params }: { params: Promise<{ id: strin
                 {user.isActive || user.is_active ? t.admin.active : t.admin.inactive}
               </span>
             </div>
+            <div className="flex items-center justify-between gap-4">
+              <div>
+                <span className="text-sm">{t.admin.emailVerificationExempt}</span>
+                <p className="mt-1 text-xs text-muted-foreground">
+                  {t.admin.emailVerificationExemptHint}
+                </p>
+              </div>
+              <Switch
+                checked={Boolean(user.email_verification_exempt)}
+                disabled={exemptMutation.isPending}
+                onCheckedChange={(checked) => exemptMutation.mutate(checked)}
+              />
+            </div>
             <div className="flex items-center justify-between">
               <span className="text-sm">UUID</span>
               <div className="flex items-center gap-2">
@@ -259,6 +289,9 @@ export default function UserDetailPage({ params }: { params: Promise<{ id: strin
             </div>
           </CardContent>
         </Card>
+        {spendingControlsEnabled ? <UserSpendingControlCard userId={user.id} /> : null}
+        <UserConsentCard userId={user.id} />
+        <UserTwoFactorCard userId={user.id} />
       </div>
     </div>
   )
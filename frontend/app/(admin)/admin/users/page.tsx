@@ -127,6 +127,7 @@ export default function AdminUsersPage() {
   const [emailMarketingFilter, setEmailMarketingFilter] = useState<TriState>('all')
   const [smsMarketingFilter, setSmsMarketingFilter] = useState<TriState>('all')
   const [hasPhoneFilter, setHasPhoneFilter] = useState<TriState>('all')
+  const [emailRiskFilter, setEmailRiskFilter] = useState<TriState>('all')
   const [localeFilter, setLocaleFilter] = useState('')
   const [openUser, setOpenUser] = useState(false)
   const [openAdmin, setOpenAdmin] = useState(false)
@@ -202,6 +203,7 @@ export default function AdminUsersPage() {
     const emailNotifyMarketing = parseTriState(emailMarketingFilter)
     const smsNotifyMarketing = parseTriState(smsMarketingFilter)
     const hasPhone = parseTriState(hasPhoneFilter)
+    const emailFlagged = parseTriState(emailRiskFilter)
 
     if (isActive !== undefined) params.append('is_active', String(isActive))
     if (emailVerified !== undefined) params.append('email_verified', String(emailVerified))
@@ -210,6 +212,7 @@ export default function AdminUsersPage() {
     if (smsNotifyMarketing !== undefined)
       params.append('sms_notify_marketing', String(smsNotifyMarketing))
     if (hasPhone !== undefined) params.append('has_phone', String(hasPhone))
+    if (emailFlagged !== undefined) params.append('email_flagged', String(emailFlagged))
     if (localeFilter) params.append('locale', localeFilter)
 
     const prefix = viewMode === 'admins' ? 'admins' : viewMode === 'users' ? 'users' : 'all_users'
@@ -249,6 +252,7 @@ export default function AdminUsersPage() {
       emailMarketingFilter,
       smsMarketingFilter,
       hasPhoneFilter,
+      emailRiskFilter,
       localeFilter,
     ],
     queryFn: () =>
@@ -262,6 +266,7 @@ export default function AdminUsersPage() {
         email_notify_marketing: parseTriState(emailMarketingFilter),
         sms_notify_marketing: parseTriState(smsMarketingFilter),
         has_phone: parseTriState(hasPhoneFilter),
+        email_flagged: parseTriState(emailRiskFilter),
         locale: localeFilter || undefined,
       }),
     enabled: canViewUsers,
@@ -483,6 +488,13 @@ export default function AdminUsersPage() {
       }`
     )
   }
+  if (emailRiskFilter !== 'all') {
+    activeFilterBadges.push(
+      `${cleanFilterLabel(t.admin.userFilterEmailRisk)}: ${
+        emailRiskFilter === 'true' ? t.admin.emailRiskFlagged : t.admin.emailRiskClean
+      }`
+    )
+  }
   if (emailMarketingFilter !== 'all') {
     activeFilterBadges.push(
       `${cleanFilterLabel(t.admin.userFilterEmailMarketing)}: ${
@@ -514,6 +526,7 @@ export default function AdminUsersPage() {
     email_notify_marketing: parseTriState(emailMarketingFilter),
     sms_notify_marketing: parseTriState(smsMarketingFilter),
     has_phone: parseTriState(hasPhoneFilter),
+    email_flagged: parseTriState(emailRiskFilter),
     locale: localeFilter || undefined,
   }
   const adminUsersPagination = {
@@ -599,6 +612,13 @@ export default function AdminUsersPage() {
                 {t.admin.unverified}
               </Badge>
             )}
+            {row.original.email_risk_flag ? (
+              <Badge variant="destructive" className="text-[11px]">
+                {row.original.email_risk_flag === 'disposable'
+                  ? t.admin.emailRiskDisposable
+                  : t.admin.emailRiskNoMX}
+              </Badge>
+            ) : null}
             {row.original.locale ? (
               <Badge variant="outline" className="text-[11px]">
                 {row.original.locale}
@@ -1167,6 +1187,28 @@ export default function AdminUsersPage() {
               </Select>
             </div>
 
+            <div className="space-y-2">
+              <label className="text-sm font-medium">
+                {cleanFilterLabel(t.admin.userFilterEmailRisk)}
+              </label>
+              <Select
+                value={emailRiskFilter}
+                onValueChange={(v) => {
+                  setEmailRiskFilter(v as TriState)
+                  setPage(1)
+                }}
+              >
+                <SelectTrigger>
+                  <SelectValue placeholder={cleanFilterLabel(t.admin.userFilterEmailRisk)} />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="all">{t.common.all}</SelectItem>
+                  <SelectItem value="true">{t.admin.emailRiskFlagged}</SelectItem>
+                  <SelectItem value="false">{t.admin.emailRiskClean}</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+
             <div className="space-y-2">
               <label className="text-sm font-medium">
                 {cleanFilterLabel(t.admin.userFilterEmailMarketing)}
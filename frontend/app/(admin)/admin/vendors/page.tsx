@@ -0,0 +1,726 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { BookOpen, CheckCircle, Download, FilePlus, Pencil, Plus, Scale } from 'lucide-react'
+import {
+  createVendor,
+  createVendorAdjustment,
+  generateVendorStatements,
+  getVendorLedger,
+  getVendors,
+  getVendorStatements,
+  markVendorStatementPaid,
+  updateVendor,
+  type Vendor,
+  type VendorInput,
+  type VendorLedgerEntry,
+  type VendorPayoutStatement,
+  type VendorStatus,
+} from '@/lib/api'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatCurrency, majorToMinor } from '@/lib/utils'
+import { DataTable } from '@/components/admin/data-table'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { Tabs, TabsContent, TabsList, TabsTrigger } from '@/components/ui/tabs'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { usePermission } from '@/hooks/use-permission'
+import { getTranslations } from '@/lib/i18n'
+
+interface VendorForm {
+  name: string
+  contact_email: string
+  commission_percent: string
+  payout_account: string
+  status: VendorStatus
+  remark: string
+}
+
+const emptyVendorForm: VendorForm = {
+  name: '',
+  contact_email: '',
+  commission_percent: '0',
+  payout_account: '',
+  status: 'active',
+  remark: '',
+}
+
+function formatCommissionRate(rate: number) {
+  return `${(rate / 100).toFixed(2)}%`
+}
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+export default function AdminVendorsPage() {
+  const queryClient = useQueryClient()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.adminVendors)
+  const { hasPermission } = usePermission()
+  const canManage = hasPermission('vendor.manage')
+
+  const [statementPage, setStatementPage] = useState(1)
+  const [statementStatus, setStatementStatus] = useState('all')
+  const [editingVendor, setEditingVendor] = useState<Vendor | null>(null)
+  const [vendorDialogOpen, setVendorDialogOpen] = useState(false)
+  const [vendorForm, setVendorForm] = useState<VendorForm>(emptyVendorForm)
+  const [ledgerVendor, setLedgerVendor] = useState<Vendor | null>(null)
+  const [adjustVendor, setAdjustVendor] = useState<Vendor | null>(null)
+  const [adjustForm, setAdjustForm] = useState({ currency: 'CNY', amount: '', note: '' })
+  const [generateOpen, setGenerateOpen] = useState(false)
+  const [period, setPeriod] = useState(() => {
+    const now = new Date()
+    return {
+      start: formatDateInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth() - 1, 1))),
+      end: formatDateInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth(), 1))),
+    }
+  })
+  const [payStatement, setPayStatement] = useState<VendorPayoutStatement | null>(null)
+  const [payReference, setPayReference] = useState('')
+
+  const { data: vendorsData, isLoading: vendorsLoading } = useQuery({
+    queryKey: ['vendors'],
+    queryFn: () => getVendors(),
+  })
+  const vendors: Vendor[] = vendorsData?.data?.items || []
+
+  const { data: statementsData, isLoading: statementsLoading } = useQuery({
+    queryKey: ['vendorStatements', statementPage, statementStatus],
+    queryFn: () =>
+      getVendorStatements({
+        page: statementPage,
+        limit: 20,
+        status: statementStatus === 'all' ? undefined : statementStatus,
+      }),
+  })
+  const statements: VendorPayoutStatement[] = statementsData?.data?.items || []
+
+  const { data: ledgerData, isLoading: ledgerLoading } = useQuery({
+    queryKey: ['vendorLedger', ledgerVendor?.id],
+    queryFn: () => getVendorLedger(ledgerVendor!.id, { page: 1, limit: 50 }),
+    enabled: ledgerVendor !== null,
+  })
+  const ledgerEntries: VendorLedgerEntry[] = ledgerData?.data?.items || []
+
+  const refreshAll = () => {
+    queryClient.invalidateQueries({ queryKey: ['vendors'] })
+    queryClient.invalidateQueries({ queryKey: ['vendorStatements'] })
+    queryClient.invalidateQueries({ queryKey: ['vendorLedger'] })
+  }
+
+  const saveVendorMutation = useMutation({
+    mutationFn: (data: VendorInput) =>
+      editingVendor ? updateVendor(editingVendor.id, data) : createVendor(data),
+    onSuccess: () => {
+      toast.success(t.admin.vendorSaved)
+      setVendorDialogOpen(false)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.vendorSaveFailed))
+    },
+  })
+
+  const adjustmentMutation = useMutation({
+    mutationFn: () =>
+      createVendorAdjustment(adjustVendor!.id, {
+        currency: adjustForm.currency,
+        amount_minor: majorToMinor(adjustForm.amount || '0'),
+        note: adjustForm.note,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.vendorAdjustmentCreated)
+      setAdjustVendor(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.vendorAdjustmentFailed))
+    },
+  })
+
+  const generateMutation = useMutation({
+    mutationFn: () =>
+      generateVendorStatements({ period_start: period.start, period_end: period.end }),
+    onSuccess: (res) => {
+      const count = res?.data?.items?.length || 0
+      toast.success(t.admin.vendorStatementsGenerated.replace('{count}', String(count)))
+      setGenerateOpen(false)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.vendorStatementsGenerateFailed))
+    },
+  })
+
+  const markPaidMutation = useMutation({
+    mutationFn: () => markVendorStatementPaid(payStatement!.id, payReference),
+    onSuccess: () => {
+      toast.success(t.admin.vendorStatementMarkedPaid)
+      setPayStatement(null)
+      refreshAll()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.vendorStatementMarkPaidFailed))
+    },
+  })
+
+  const openVendorDialog = (vendor: Vendor | null) => {
+    setEditingVendor(vendor)
+    setVendorForm(
+      vendor
+        ? {
+            name: vendor.name,
+            contact_email: vendor.contact_email || '',
+            commission_percent: (vendor.commission_rate / 100).toString(),
+            payout_account: vendor.payout_account || '',
+            status: vendor.status,
+            remark: vendor.remark || '',
+          }
+        : emptyVendorForm
+    )
+    setVendorDialogOpen(true)
+  }
+
+  const submitVendor = () => {
+    saveVendorMutation.mutate({
+      name: vendorForm.name,
+      contact_email: vendorForm.contact_email,
+      commission_rate: Math.round(Number(vendorForm.commission_percent || 0) * 100),
+      payout_account: vendorForm.payout_account,
+      status: vendorForm.status,
+      remark: vendorForm.remark,
+    })
+  }
+
+  const readFetchErrorMessage = async (response: Response, fallback: string) => {
+    try {
+      const payload = await response.json()
+      return resolveApiErrorMessage(payload, t, fallback)
+    } catch {
+      return fallback
+    }
+  }
+
+  const handleExportStatement = (statement: VendorPayoutStatement) => {
+    const url = resolveClientAPIProxyURL(`/api/admin/vendor-statements/${statement.id}/export`)
+
+    fetch(url)
+      .then(async (res) => {
+        if (!res.ok) {
+          throw new Error(await readFetchErrorMessage(res, t.admin.exportFailed))
+        }
+        return res.blob()
+      })
+      .then((blob) => {
+        const blobUrl = window.URL.createObjectURL(blob)
+        const a = document.createElement('a')
+        a.href = blobUrl
+        a.download = `vendor_statement_${statement.id}.csv`
+        document.body.appendChild(a)
+        a.click()
+        document.body.removeChild(a)
+        window.URL.revokeObjectURL(blobUrl)
+      })
+      .catch((err: Error) => {
+        toast.error(`${t.admin.exportFailed}: ${err.message}`)
+      })
+  }
+
+  const ledgerTypeLabels: Record<VendorLedgerEntry['type'], string> = {
+    sale: t.admin.vendorLedgerSale,
+    refund: t.admin.vendorLedgerRefund,
+    adjustment: t.admin.vendorLedgerAdjustment,
+  }
+
+  const vendorColumns = [
+    {
+      header: t.admin.vendorName,
+      cell: ({ row }: { row: { original: Vendor } }) => (
+        <div>
+          <div className="font-medium">{row.original.name}</div>
+          {row.original.contact_email ? (
+            <div className="text-xs text-muted-foreground">{row.original.contact_email}</div>
+          ) : null}
+        </div>
+      ),
+    },
+    {
+      header: t.admin.vendorCommission,
+      cell: ({ row }: { row: { original: Vendor } }) =>
+        formatCommissionRate(row.original.commission_rate),
+    },
+    {
+      header: t.admin.vendorProducts,
+      accessorKey: 'product_count',
+    },
+    {
+      header: t.admin.vendorUnsettled,
+      cell: ({ row }: { row: { original: Vendor } }) => {
+        const balances = row.original.unsettled || []
+        if (balances.length === 0) return '-'
+        return balances
+          .map((balance) => formatCurrency(balance.amount_minor, balance.currency))
+          .join(' / ')
+      },
+    },
+    {
+      header: t.admin.vendorStatus,
+      cell: ({ row }: { row: { original: Vendor } }) =>
+        row.original.status === 'active' ? (
+          <Badge variant="secondary">{t.admin.vendorStatusActive}</Badge>
+        ) : (
+          <Badge variant="outline">{t.admin.vendorStatusDisabled}</Badge>
+        ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: Vendor } }) => (
+        <div className="flex items-center gap-2">
+          <Button size="sm" variant="outline" onClick={() => setLedgerVendor(row.original)}>
+            <BookOpen className="h-4 w-4" />
+          </Button>
+          {canManage ? (
+            <>
+              <Button size="sm" variant="outline" onClick={() => openVendorDialog(row.original)}>
+                <Pencil className="h-4 w-4" />
+              </Button>
+              <Button
+                size="sm"
+                variant="outline"
+                onClick={() => {
+                  setAdjustForm({ currency: 'CNY', amount: '', note: '' })
+                  setAdjustVendor(row.original)
+                }}
+              >
+                <Scale className="h-4 w-4" />
+              </Button>
+            </>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  const statementColumns = [
+    {
+      header: t.admin.vendorName,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        row.original.vendor?.name || `#${row.original.vendor_id}`,
+    },
+    {
+      header: t.admin.vendorStatementPeriod,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        `${formatDate(row.original.period_start)} - ${formatDate(row.original.period_end)}`,
+    },
+    {
+      header: t.admin.vendorStatementSales,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        formatCurrency(row.original.sales_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorStatementCommission,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        formatCurrency(row.original.commission_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorStatementRefunds,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        formatCurrency(row.original.refund_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorStatementAdjustments,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        formatCurrency(row.original.adjustment_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorStatementPayout,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) => (
+        <span className="font-medium">
+          {formatCurrency(row.original.payout_amount_minor, row.original.currency)}
+        </span>
+      ),
+    },
+    {
+      header: t.admin.vendorStatus,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) =>
+        row.original.status === 'paid' ? (
+          <div>
+            <Badge variant="secondary">{t.admin.vendorStatementPaid}</Badge>
+            {row.original.payment_reference ? (
+              <div className="mt-1 text-xs text-muted-foreground">
+                {row.original.payment_reference}
+              </div>
+            ) : null}
+          </div>
+        ) : (
+          <Badge variant="outline">{t.admin.vendorStatementPending}</Badge>
+        ),
+    },
+    {
+      header: t.admin.actions,
+      cell: ({ row }: { row: { original: VendorPayoutStatement } }) => (
+        <div className="flex items-center gap-2">
+          <Button size="sm" variant="outline" onClick={() => handleExportStatement(row.original)}>
+            <Download className="h-4 w-4" />
+          </Button>
+          {canManage && row.original.status === 'pending' ? (
+            <Button
+              size="sm"
+              variant="outline"
+              onClick={() => {
+                setPayReference('')
+                setPayStatement(row.original)
+              }}
+            >
+              <CheckCircle className="h-4 w-4" />
+            </Button>
+          ) : null}
+        </div>
+      ),
+    },
+  ]
+
+  const ledgerColumns = [
+    {
+      header: t.admin.vendorLedgerDate,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        formatDate(row.original.created_at),
+    },
+    {
+      header: t.admin.vendorLedgerType,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        ledgerTypeLabels[row.original.type] || row.original.type,
+    },
+    {
+      header: t.admin.vendorLedgerReference,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        row.original.order_no
+          ? `${row.original.order_no} · ${row.original.sku} × ${row.original.quantity}`
+          : row.original.note || '-',
+    },
+    {
+      header: t.admin.vendorStatementCommission,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        formatCurrency(row.original.commission_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorLedgerNet,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        formatCurrency(row.original.net_amount_minor, row.original.currency),
+    },
+    {
+      header: t.admin.vendorLedgerSettled,
+      cell: ({ row }: { row: { original: VendorLedgerEntry } }) =>
+        row.original.statement_id ? `#${row.original.statement_id}` : '-',
+    },
+  ]
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-3xl font-bold">{t.admin.vendorManagementTitle}</h1>
+        <p className="mt-1 text-sm text-muted-foreground">{t.admin.vendorManagementDesc}</p>
+      </div>
+
+      <Tabs defaultValue="vendors">
+        <TabsList>
+          <TabsTrigger value="vendors">{t.admin.vendorTabVendors}</TabsTrigger>
+          <TabsTrigger value="statements">{t.admin.vendorTabStatements}</TabsTrigger>
+        </TabsList>
+
+        <TabsContent value="vendors" className="space-y-4">
+          {canManage ? (
+            <div className="flex justify-end">
+              <Button onClick={() => openVendorDialog(null)}>
+                <Plus className="mr-2 h-4 w-4" />
+                {t.admin.vendorCreate}
+              </Button>
+            </div>
+          ) : null}
+          <DataTable columns={vendorColumns} data={vendors} isLoading={vendorsLoading} />
+        </TabsContent>
+
+        <TabsContent value="statements" className="space-y-4">
+          <div className="flex flex-col gap-3 md:flex-row md:items-center md:justify-between">
+            <Select
+              value={statementStatus}
+              onValueChange={(value) => {
+                setStatementStatus(value)
+                setStatementPage(1)
+              }}
+            >
+              <SelectTrigger className="w-[150px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.common.all}</SelectItem>
+                <SelectItem value="pending">{t.admin.vendorStatementPending}</SelectItem>
+                <SelectItem value="paid">{t.admin.vendorStatementPaid}</SelectItem>
+              </SelectContent>
+            </Select>
+            {canManage ? (
+              <Button onClick={() => setGenerateOpen(true)}>
+                <FilePlus className="mr-2 h-4 w-4" />
+                {t.admin.vendorGenerateStatements}
+              </Button>
+            ) : null}
+          </div>
+          <DataTable
+            columns={statementColumns}
+            data={statements}
+            isLoading={statementsLoading}
+            pagination={{
+              page: statementPage,
+              total_pages: statementsData?.data?.pagination?.total_pages || 1,
+              onPageChange: setStatementPage,
+            }}
+          />
+        </TabsContent>
+      </Tabs>
+
+      {/* 创建/编辑商家 */}
+      <Dialog open={vendorDialogOpen} onOpenChange={setVendorDialogOpen}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{editingVendor ? t.admin.vendorEdit : t.admin.vendorCreate}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>{t.admin.vendorName}</Label>
+              <Input
+                value={vendorForm.name}
+                onChange={(e) => setVendorForm({ ...vendorForm, name: e.target.value })}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.vendorContactEmail}</Label>
+              <Input
+                type="email"
+                value={vendorForm.contact_email}
+                onChange={(e) => setVendorForm({ ...vendorForm, contact_email: e.target.value })}
+              />
+            </div>
+            <div className="grid grid-cols-2 gap-4">
+              <div className="space-y-2">
+                <Label>{t.admin.vendorCommissionPercent}</Label>
+                <Input
+                  type="number"
+                  min={0}
+                  max={100}
+                  step="0.01"
+                  value={vendorForm.commission_percent}
+                  onChange={(e) =>
+                    setVendorForm({ ...vendorForm, commission_percent: e.target.value })
+                  }
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.admin.vendorStatus}</Label>
+                <Select
+                  value={vendorForm.status}
+                  onValueChange={(value) =>
+                    setVendorForm({ ...vendorForm, status: value as VendorStatus })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="active">{t.admin.vendorStatusActive}</SelectItem>
+                    <SelectItem value="disabled">{t.admin.vendorStatusDisabled}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.vendorPayoutAccount}</Label>
+              <Input
+                value={vendorForm.payout_account}
+                onChange={(e) => setVendorForm({ ...vendorForm, payout_account: e.target.value })}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.vendorRemark}</Label>
+              <Textarea
+                rows={3}
+                value={vendorForm.remark}
+                onChange={(e) => setVendorForm({ ...vendorForm, remark: e.target.value })}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setVendorDialogOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={submitVendor} disabled={saveVendorMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 手动调整 */}
+      <Dialog open={adjustVendor !== null} onOpenChange={(open) => !open && setAdjustVendor(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.vendorAdjustment.replace('{name}', adjustVendor?.name || '')}
+            </DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.admin.vendorAdjustmentHint}</p>
+            <div className="grid grid-cols-2 gap-4">
+              <div className="space-y-2">
+                <Label>{t.admin.vendorAdjustmentCurrency}</Label>
+                <Input
+                  value={adjustForm.currency}
+                  onChange={(e) =>
+                    setAdjustForm({ ...adjustForm, currency: e.target.value.toUpperCase() })
+                  }
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.admin.vendorAdjustmentAmount}</Label>
+                <Input
+                  type="number"
+                  step="0.01"
+                  value={adjustForm.amount}
+                  onChange={(e) => setAdjustForm({ ...adjustForm, amount: e.target.value })}
+                />
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.vendorAdjustmentNote}</Label>
+              <Textarea
+                rows={3}
+                value={adjustForm.note}
+                onChange={(e) => setAdjustForm({ ...adjustForm, note: e.target.value })}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setAdjustVendor(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              onClick={() => adjustmentMutation.mutate()}
+              disabled={adjustmentMutation.isPending}
+            >
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 商家台账 */}
+      <Dialog open={ledgerVendor !== null} onOpenChange={(open) => !open && setLedgerVendor(null)}>
+        <DialogContent className="max-w-4xl">
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.vendorLedger.replace('{name}', ledgerVendor?.name || '')}
+            </DialogTitle>
+          </DialogHeader>
+          <DataTable columns={ledgerColumns} data={ledgerEntries} isLoading={ledgerLoading} />
+        </DialogContent>
+      </Dialog>
+
+      {/* 生成结算单 */}
+      <Dialog open={generateOpen} onOpenChange={setGenerateOpen}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.vendorGenerateStatements}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            <p className="text-sm text-muted-foreground">{t.admin.vendorGenerateHint}</p>
+            <div className="grid grid-cols-2 gap-4">
+              <div className="space-y-2">
+                <Label>{t.admin.vendorPeriodStart}</Label>
+                <Input
+                  type="date"
+                  value={period.start}
+                  onChange={(e) => setPeriod({ ...period, start: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.admin.vendorPeriodEnd}</Label>
+                <Input
+                  type="date"
+                  value={period.end}
+                  onChange={(e) => setPeriod({ ...period, end: e.target.value })}
+                />
+              </div>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setGenerateOpen(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => generateMutation.mutate()} disabled={generateMutation.isPending}>
+              {t.admin.vendorGenerateStatements}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 标记已打款 */}
+      <Dialog open={payStatement !== null} onOpenChange={(open) => !open && setPayStatement(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.admin.vendorMarkPaid}</DialogTitle>
+          </DialogHeader>
+          <div className="space-y-4">
+            {payStatement ? (
+              <p className="text-sm">
+                {t.admin.vendorMarkPaidConfirm
+                  .replace('{name}', payStatement.vendor?.name || `#${payStatement.vendor_id}`)
+                  .replace(
+                    '{amount}',
+                    formatCurrency(payStatement.payout_amount_minor, payStatement.currency)
+                  )}
+              </p>
+            ) : null}
+            <div className="space-y-2">
+              <Label>{t.admin.vendorPaymentReference}</Label>
+              <Input value={payReference} onChange={(e) => setPayReference(e.target.value)} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setPayStatement(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => markPaidMutation.mutate()} disabled={markPaidMutation.isPending}>
+              {t.admin.vendorMarkPaid}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
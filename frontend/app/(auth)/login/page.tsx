@@ -19,7 +19,17 @@ import { createLoginSchema, loginSchema } from '@/lib/validators'
 import { useLocale } from '@/hooks/use-locale'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { getTranslations } from '@/lib/i18n'
-import { Loader2, Mail, Lock, ArrowRight, KeyRound, Phone, Eye, EyeOff } from 'lucide-react'
+import {
+  Loader2,
+  Mail,
+  Lock,
+  ArrowRight,
+  KeyRound,
+  Phone,
+  Eye,
+  EyeOff,
+  Fingerprint,
+} from 'lucide-react'
 import Link from 'next/link'
 import { useRouter } from 'next/navigation'
 import { useQuery } from '@tanstack/react-query'
@@ -34,6 +44,8 @@ import { PluginSlotBatchBoundary } from '@/lib/plugin-slot-batch'
 import { readAuthReturnState, type AuthReturnState } from '@/lib/auth-return-state'
 import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert'
 import { resolveAuthApiErrorMessage } from '@/lib/api-error'
+import { TwoFactorLoginStep } from '@/components/two-factor-login-step'
+import { isPasskeySupported } from '@/lib/webauthn'
 
 function getLoginReturnHint(state: AuthReturnState | null, t: any) {
   if (!state) return null
@@ -70,11 +82,16 @@ export default function LoginPage() {
     login,
     loginWithCode,
     loginWithPhoneCode,
+    loginWithPasskey,
     isLoggingIn,
     isLoggingInWithCode,
     isLoggingInWithPhoneCode,
+    isLoggingInWithPasskey,
     isAuthenticated,
     isLoading,
+    twoFactorChallenge,
+    completeTwoFactor,
+    cancelTwoFactor,
   } = useAuth()
   const router = useRouter()
   const { locale } = useLocale()
@@ -91,6 +108,7 @@ export default function LoginPage() {
 
   useEffect(() => {
     setPendingReturnState(readAuthReturnState())
+    setPasskeySupported(isPasskeySupported())
   }, [])
 
   const [captchaToken, setCaptchaToken] = useState('')
@@ -109,6 +127,7 @@ export default function LoginPage() {
   const [phoneCodeSent, setPhoneCodeSent] = useState(false)
   const [showPassword, setShowPassword] = useState(false)
   const [pendingReturnState, setPendingReturnState] = useState<AuthReturnState | null>(null)
+  const [passkeySupported, setPasskeySupported] = useState(false)
   const { resolvedTheme } = useTheme()
   const captchaContainerRef = useRef<HTMLDivElement>(null)
   const widgetRendered = useRef(false)
@@ -131,6 +150,7 @@ export default function LoginPage() {
   const smsEnabled = publicConfig?.data?.sms_enabled
   const allowPhoneLogin = publicConfig?.data?.allow_phone_login
   const phoneLoginAvailable = smsEnabled && allowPhoneLogin
+  const passkeyLoginAvailable = passkeySupported && publicConfig?.data?.allow_passkey_login
   // 密码登录禁用时自动切换到可用模式
   useEffect(() => {
     if (!publicConfig) return
@@ -403,6 +423,7 @@ export default function LoginPage() {
         password_login_enabled: Boolean(allowPasswordLogin),
         email_code_login_enabled: Boolean(emailCodeAvailable),
         phone_login_enabled: Boolean(phoneLoginAvailable),
+        passkey_login_enabled: Boolean(passkeyLoginAvailable),
         registration_enabled: Boolean(allowRegistration),
         password_reset_enabled: Boolean(allowPasswordReset),
         captcha_required: Boolean(needCaptcha),
@@ -420,6 +441,7 @@ export default function LoginPage() {
         sending_phone_code: isSendingPhoneCode,
         phone_submitting: isLoggingInWithPhoneCode,
         password_submitting: isLoggingIn,
+        passkey_submitting: isLoggingInWithPasskey,
         return_hint_visible: Boolean(loginReturnHint),
         redirect_path: pendingReturnState?.redirectPath || undefined,
       },
@@ -437,12 +459,14 @@ export default function LoginPage() {
       isLoggingIn,
       isLoggingInWithCode,
       isLoggingInWithPhoneCode,
+      isLoggingInWithPasskey,
       isSendingCode,
       isSendingPhoneCode,
       loginMode,
       loginReturnHint,
       pendingReturnState?.redirectPath,
       needCaptcha,
+      passkeyLoginAvailable,
       phoneCodeSent,
       phoneCountdown,
       phoneLoginAvailable,
@@ -498,6 +522,24 @@ export default function LoginPage() {
     [authLoginPluginContext]
   )
 
+  if (twoFactorChallenge) {
+    return (
+      <div className="flex min-h-screen">
+        <AuthBrandingPanel />
+        <div className="flex flex-1 items-center justify-center bg-background p-6 sm:p-12">
+          <div className="w-full max-w-sm space-y-6 sm:space-y-8">
+            <AuthMobileBrand />
+            <TwoFactorLoginStep
+              challenge={twoFactorChallenge}
+              onComplete={completeTwoFactor}
+              onCancel={cancelTwoFactor}
+            />
+          </div>
+        </div>
+      </div>
+    )
+  }
+
   return (
     <div className="flex min-h-screen">
       <AuthBrandingPanel />
@@ -1015,6 +1057,24 @@ export default function LoginPage() {
               </div>
             )}
 
+            {/* Passkey login */}
+            {passkeyLoginAvailable && (
+              <Button
+                type="button"
+                variant="outline"
+                className="h-11 w-full text-sm font-medium"
+                disabled={isLoggingInWithPasskey}
+                onClick={() => loginWithPasskey()}
+              >
+                {isLoggingInWithPasskey ? (
+                  <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+                ) : (
+                  <Fingerprint className="mr-2 h-4 w-4" />
+                )}
+                {t.passkey.signIn}
+              </Button>
+            )}
+
             {/* Forgot Password */}
             {allowPasswordReset && (
               <div className="text-center">
@@ -8,11 +8,14 @@ import { useCart } from '@/contexts/cart-context'
 import {
   createOrder,
   validatePromoCode,
+  checkGiftCard,
   getPublicConfig,
   getProduct,
   getProductAvailableStock,
+  type GiftCardBalance,
 } from '@/lib/api'
 import { resolveApiErrorMessage } from '@/lib/api-error'
+import { trackCheckoutStep } from '@/lib/analytics-events'
 import { Card, CardContent, CardHeader, CardFooter } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
@@ -244,9 +247,25 @@ export default function CartPage() {
     max_discount_minor: number
     min_order_amount_minor: number
   } | null>(null)
+  // Gift card state
+  const giftCardEnabled = Boolean(publicConfig?.data?.gift_card_enabled)
+  const [giftCardInput, setGiftCardInput] = useState('')
+  const [giftCardExpanded, setGiftCardExpanded] = useState(false)
+  const [isCheckingGiftCard, setIsCheckingGiftCard] = useState(false)
+  const [appliedGiftCard, setAppliedGiftCard] = useState<GiftCardBalance | null>(null)
   const items = isGuestMode ? guestItems : serverItems
   const isLoading = authLoading || (isGuestMode ? !hasGuestItemsLoaded : serverCartLoading)
 
+  // 每次打开购物车页面只记录一次结账漏斗的第一步
+  const hasTrackedCartStepRef = useRef(false)
+  useEffect(() => {
+    if (isLoading || items.length === 0 || hasTrackedCartStepRef.current) {
+      return
+    }
+    hasTrackedCartStepRef.current = true
+    trackCheckoutStep('cart')
+  }, [isLoading, items.length])
+
   const refetchCart = useCallback(() => {
     if (isGuestMode) {
       refreshGuestItems()
@@ -373,11 +392,38 @@ export default function CartPage() {
     setPromoCodeInput('')
   }
 
+  // 应用礼品卡：只查询可用余额，实际抵扣在下单时由服务端预留
+  const handleApplyGiftCard = async () => {
+    if (!giftCardInput.trim()) return
+
+    setIsCheckingGiftCard(true)
+    try {
+      const response = await checkGiftCard(giftCardInput.trim())
+      const data: GiftCardBalance = response.data
+      setAppliedGiftCard(data)
+      setGiftCardExpanded(false)
+      toast.success(
+        t.giftCard.applied.replace('{balance}', formatPrice(data.available_minor, data.currency))
+      )
+    } catch (error: any) {
+      toast.error(resolveApiErrorMessage(error, t, t.giftCard.checkFailed))
+    } finally {
+      setIsCheckingGiftCard(false)
+    }
+  }
+
+  // 移除礼品卡
+  const handleRemoveGiftCard = () => {
+    setAppliedGiftCard(null)
+    setGiftCardInput('')
+  }
+
   // 创建订单
   const createOrderMutation = useMutation({
     mutationFn: createOrder,
     onSuccess: async (data) => {
       const orderNo = data?.data?.order_no
+      trackCheckoutStep('placed')
       toast.success(t.cart.orderSuccess)
       // 只清空已选中的商品
       const selectedItemIds = Array.from(selectedItems)
@@ -386,6 +432,7 @@ export default function CartPage() {
       // 清空优惠码状态
       setAppliedPromo(null)
       setPromoCodeInput('')
+      handleRemoveGiftCard()
       queryClient.invalidateQueries({ queryKey: ['orders'] })
       router.push(`/orders/${orderNo}`)
     },
@@ -593,6 +640,11 @@ export default function CartPage() {
       return Math.min(appliedPromo.discount_value_minor, selectedTotalPrice)
     }
   }, [appliedPromo, selectedTotalPrice])
+  // 礼品卡在优惠码折扣之后抵扣，最多抵扣到应付为 0
+  const giftCardDeduction = appliedGiftCard
+    ? Math.min(appliedGiftCard.available_minor, Math.max(0, selectedTotalPrice - promoDiscount))
+    : 0
+  const payableTotal = Math.max(0, selectedTotalPrice - promoDiscount - giftCardDeduction)
   const userCartPluginContext = {
     view: 'user_cart',
     summary: {
@@ -603,7 +655,7 @@ export default function CartPage() {
       selected_item_ids: Array.from(selectedItems),
       selected_total_quantity: selectedTotalQuantity,
       selected_total_amount_minor: selectedTotalPrice,
-      payable_total_amount_minor: Math.max(0, selectedTotalPrice - promoDiscount),
+      payable_total_amount_minor: payableTotal,
       all_available_items_selected: allAvailableItemsSelected,
       is_guest_mode: isGuestMode,
       is_loading: isLoading,
@@ -619,6 +671,12 @@ export default function CartPage() {
       min_order_amount_minor: appliedPromo?.min_order_amount_minor,
       max_discount_minor: appliedPromo?.max_discount_minor,
     },
+    gift_card: {
+      enabled: giftCardEnabled,
+      applied: Boolean(appliedGiftCard),
+      code: appliedGiftCard?.code,
+      deduction_amount_minor: giftCardDeduction,
+    },
     display: {
       view_mode: viewMode,
       currency,
@@ -678,9 +736,11 @@ export default function CartPage() {
       product_type: item.product_type,
     }))
 
+    trackCheckoutStep('submit')
     createOrderMutation.mutate({
       items: orderItems,
       ...(appliedPromo ? { promo_code: appliedPromo.code } : {}),
+      ...(appliedGiftCard ? { gift_card_code: appliedGiftCard.code } : {}),
     })
   }
 
@@ -1342,6 +1402,76 @@ export default function CartPage() {
               slot="user.cart.checkout.promo.after"
               context={{ ...userCartPluginContext, section: 'checkout_promo' }}
             />
+            {/* 礼品卡：输入卡号查询余额，已应用时显示抵扣金额 */}
+            {giftCardEnabled && !isGuestMode && (
+              <div className="mb-2 flex items-center gap-2">
+                {appliedGiftCard ? (
+                  <>
+                    <span
+                      className={
+                        isMobile
+                          ? 'text-xs font-medium text-green-600 dark:text-green-400'
+                          : 'text-sm font-medium text-green-600 dark:text-green-400'
+                      }
+                    >
+                      {t.giftCard.deduction
+                        .replace('{code}', appliedGiftCard.code)
+                        .replace('{amount}', formatPrice(giftCardDeduction, currency))}
+                    </span>
+                    <button
+                      className="text-xs text-red-500 underline hover:text-red-600"
+                      onClick={handleRemoveGiftCard}
+                    >
+                      {t.promoCode.remove}
+                    </button>
+                  </>
+                ) : giftCardExpanded ? (
+                  <>
+                    <div className="relative flex-1 md:max-w-xs">
+                      <Input
+                        value={giftCardInput}
+                        onChange={(e) => setGiftCardInput(e.target.value)}
+                        placeholder={t.giftCard.codePlaceholder}
+                        className="h-8 pr-20 font-mono text-sm"
+                        maxLength={50}
+                        onKeyDown={(e) => {
+                          if (e.key === 'Enter') handleApplyGiftCard()
+                        }}
+                        autoFocus
+                      />
+                      <Button
+                        onClick={handleApplyGiftCard}
+                        disabled={!giftCardInput.trim() || isCheckingGiftCard}
+                        size="sm"
+                        className="absolute right-0.5 top-1/2 h-7 -translate-y-1/2 px-3 text-xs"
+                      >
+                        {isCheckingGiftCard ? (
+                          <Loader2 className="h-3 w-3 animate-spin" />
+                        ) : (
+                          t.promoCode.apply
+                        )}
+                      </Button>
+                    </div>
+                    <button
+                      className="shrink-0 text-xs text-muted-foreground hover:text-foreground"
+                      onClick={() => {
+                        setGiftCardExpanded(false)
+                        setGiftCardInput('')
+                      }}
+                    >
+                      {t.common.cancel}
+                    </button>
+                  </>
+                ) : (
+                  <button
+                    className="text-xs font-medium text-primary hover:text-primary/80"
+                    onClick={() => setGiftCardExpanded(true)}
+                  >
+                    {t.giftCard.haveGiftCard}
+                  </button>
+                )}
+              </div>
+            )}
             <PluginSlot
               slot="user.cart.checkout.submit.before"
               context={{ ...userCartPluginContext, section: 'checkout_submit' }}
@@ -1436,13 +1566,13 @@ export default function CartPage() {
                     }
                   >
                     <span className="hidden sm:inline">{t.cart.total}:</span>
-                    {appliedPromo ? (
+                    {appliedPromo || appliedGiftCard ? (
                       <>
                         <span className="ml-1 text-xs font-normal text-muted-foreground line-through">
                           {formatPrice(selectedTotalPrice, currency)}
                         </span>
                         <span className="ml-1 text-red-600">
-                          {formatPrice(Math.max(0, selectedTotalPrice - promoDiscount), currency)}
+                          {formatPrice(payableTotal, currency)}
                         </span>
                       </>
                     ) : (
@@ -14,12 +14,15 @@ import { getPublicConfig, type PluginFrontendBootstrapRoute } from '@/lib/api'
 import { matchPluginRoute, readPluginSearchParams } from '@/lib/plugin-frontend-routing'
 import { usePluginBootstrapQuery } from '@/lib/plugin-bootstrap-query'
 import { resolvePluginPlatformEnabled } from '@/lib/plugin-slot-behavior'
+import { trackAnalyticsEvent } from '@/lib/analytics-events'
 import { cn } from '@/lib/utils'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 import { UserSidebar } from '@/components/layout/user-sidebar'
 import { MobileBottomNav } from '@/components/layout/mobile-bottom-nav'
 import { CartProvider } from '@/contexts/cart-context'
 import { AnnouncementPopup } from '@/components/announcement-popup'
+import { PolicyConsentPopup } from '@/components/policy-consent-popup'
+import { SiteBanners } from '@/components/site-banner'
 import { Button } from '@/components/ui/button'
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
 import { FullPageLoading } from '@/components/ui/page-loading'
@@ -69,6 +72,7 @@ function UserLayoutFrame({
         )}
       >
         <div className={cn('w-full', isTablet && 'mx-auto max-w-[39rem]')}>
+          <SiteBanners className="mb-4" />
           {slotContext ? <PluginSlot slot="user.layout.content.top" context={slotContext} /> : null}
           {children}
           {slotContext ? (
@@ -245,6 +249,13 @@ function UserLayoutContent({ children }: { children: React.ReactNode }) {
     setMounted(true)
   }, [])
 
+  useEffect(() => {
+    if (!pathname) {
+      return
+    }
+    trackAnalyticsEvent({ type: 'page_view', path: pathname, referer: document.referrer })
+  }, [pathname])
+
   const t = getTranslations(localeMounted ? locale : 'zh')
   const loadingText = t.common.loading
 
@@ -381,6 +392,7 @@ function UserLayoutContent({ children }: { children: React.ReactNode }) {
         {children}
       </UserLayoutFrame>
       <AnnouncementPopup />
+      <PolicyConsentPopup />
     </CartProvider>
   )
 }
@@ -6,20 +6,50 @@ import { useQuery } from '@tanstack/react-query'
 import { useOrderDetail } from '@/hooks/use-orders'
 import { OrderDetail } from '@/components/orders/order-detail'
 import { PaymentMethodCard } from '@/components/orders/payment-method-card'
+import { VirtualRevealReauthCard } from '@/components/orders/virtual-reveal-reauth-card'
+import { RefundRequestCard } from '@/components/orders/refund-request-card'
+import { PartialRefundCard } from '@/components/orders/partial-refund-card'
+import { ReturnRequestCard } from '@/components/orders/return-request-card'
+import { NetTermsCard } from '@/components/orders/net-terms-card'
+import { CashOnDeliveryCard } from '@/components/orders/cash-on-delivery-card'
+import { PaymentLinkShareCard } from '@/components/orders/payment-link-share-card'
+import { OrderSharesCard } from '@/components/orders/order-shares-card'
+import { OrderTimelineCard } from '@/components/orders/order-timeline-card'
+import { LicenseActivationCard } from '@/components/orders/license-activation-card'
+import { UsageMeterCard } from '@/components/orders/usage-meter-card'
+import { OrderDocumentsCard } from '@/components/orders/order-documents-card'
 import { ShippingForm } from '@/components/forms/shipping-form'
 import { Button } from '@/components/ui/button'
 import { Card, CardContent } from '@/components/ui/card'
 import { PageLoading } from '@/components/ui/page-loading'
-import { ArrowLeft, Loader2, FileText, AlertTriangle, Clock, RefreshCw } from 'lucide-react'
+import {
+  ArrowLeft,
+  Loader2,
+  FileText,
+  FileDown,
+  AlertTriangle,
+  Clock,
+  RefreshCw,
+} from 'lucide-react'
 import Link from 'next/link'
-import { getOrRefreshFormToken, getFormInfo, getInvoiceToken } from '@/lib/api'
+import {
+  deactivateOrderLicenseActivation,
+  getOrRefreshFormToken,
+  getFormInfo,
+  getInvoiceToken,
+  getOrderDocumentToken,
+  getOrderDocuments,
+  getOrderLicenseActivations,
+  getOrderUsageMeters,
+  getOrderTimeline,
+} from '@/lib/api'
 import { useLocale } from '@/hooks/use-locale'
 import { useIsMobile } from '@/hooks/use-mobile'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { getTranslations } from '@/lib/i18n'
 import { buildListReturnPath, readListBrowseState } from '@/lib/list-browse-state'
 import { resolveApiErrorMessage } from '@/lib/api-error'
-import { resolvePublicAPIURL } from '@/lib/api-base-url'
+import { resolveClientAPIProxyURL, resolvePublicAPIURL } from '@/lib/api-base-url'
 import toast from 'react-hot-toast'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 import {
@@ -28,6 +58,10 @@ import {
 } from '@/lib/order-detail-queries'
 import { getPublicConfigQueryOptions } from '@/lib/product-detail-queries'
 
+// 允许用户发起退款申请的订单状态，与后端可退款状态保持一致
+const REFUND_REQUEST_STATUSES = ['draft', 'pending', 'need_resubmit', 'shipped', 'completed']
+const RETURN_REQUEST_STATUSES = ['shipped', 'completed']
+
 function usePaymentCountdown(createdAt: string | undefined, autoCancelHours: number) {
   const [remaining, setRemaining] = useState<{
     hours: number
@@ -74,6 +108,7 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
   const [formLoading, setFormLoading] = useState(false)
   const [formError, setFormError] = useState<string | null>(null)
   const [invoiceLoading, setInvoiceLoading] = useState(false)
+  const [invoicePdfLoading, setInvoicePdfLoading] = useState(false)
   const [orderListBackHref, setOrderListBackHref] = useState('/orders')
 
   const {
@@ -108,7 +143,7 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
     setShouldAutoRefresh(activeStatuses.includes(order.status))
   }, [order?.status])
 
-  const { data: virtualStocksData } = useQuery({
+  const { data: virtualStocksData, refetch: refetchVirtualStocks } = useQuery({
     ...getOrderVirtualProductsQueryOptions(orderNo),
     enabled: shouldFetchOrderVirtualProducts(order),
   })
@@ -159,8 +194,14 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
   )
   const isPendingPayment = order?.status === 'pending_payment'
   const virtualStocks = virtualStocksData?.data?.stocks || []
+  const virtualRevealReauthRequired = !!virtualStocksData?.data?.reauth_required
   const invoiceEnabled = !!publicConfig?.data?.invoice_enabled
+  const invoicePdfEnabled = !!publicConfig?.data?.invoice_pdf_enabled
+  const netTermsEnabled = !!publicConfig?.data?.net_terms_enabled
+  const cashOnDeliveryEnabled = !!publicConfig?.data?.cash_on_delivery_enabled
+  const paymentLinkEnabled = !!publicConfig?.data?.payment_link_enabled
   const showVirtualStockRemark = !!publicConfig?.data?.show_virtual_stock_remark
+  const returnsEnabled = !!publicConfig?.data?.returns_enabled
   const userOrderDetailPluginContext = {
     view: 'user_order_detail',
     order: order
@@ -275,6 +316,30 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
     }
   }
 
+  const handleDownloadInvoicePdf = async () => {
+    if (invoicePdfLoading) return
+    setInvoicePdfLoading(true)
+    try {
+      const res = await fetch(resolveClientAPIProxyURL(`/api/user/orders/${orderNo}/invoice.pdf`))
+      if (!res.ok) {
+        const payload = await res.json().catch(() => undefined)
+        throw payload
+      }
+      const blobUrl = window.URL.createObjectURL(await res.blob())
+      const a = document.createElement('a')
+      a.href = blobUrl
+      a.download = `INV-${orderNo}.pdf`
+      document.body.appendChild(a)
+      a.click()
+      document.body.removeChild(a)
+      window.URL.revokeObjectURL(blobUrl)
+    } catch (error: any) {
+      toast.error(resolveApiErrorMessage(error, t, t.order.downloadInvoiceFailed))
+    } finally {
+      setInvoicePdfLoading(false)
+    }
+  }
+
   const shippingFormNode =
     needsShippingForm && formToken && formData ? (
       <ShippingForm
@@ -380,6 +445,23 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
               )}
             </Button>
           )}
+          {invoicePdfEnabled && order.status === 'completed' && (
+            <Button
+              variant="outline"
+              size={isCompactLayout ? 'icon' : 'sm'}
+              onClick={handleDownloadInvoicePdf}
+              disabled={invoicePdfLoading}
+            >
+              <FileDown className={isCompactLayout ? 'h-4 w-4' : 'h-4 w-4 md:mr-1.5'} />
+              {isCompactLayout ? (
+                <span className="sr-only">
+                  {invoicePdfLoading ? t.common.loading : t.order.downloadInvoicePdf}
+                </span>
+              ) : (
+                <span>{invoicePdfLoading ? t.common.loading : t.order.downloadInvoicePdf}</span>
+              )}
+            </Button>
+          )}
         </div>
       </div>
 
@@ -428,6 +510,10 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
         </div>
       )}
 
+      {virtualRevealReauthRequired && (
+        <VirtualRevealReauthCard orderNo={orderNo} onVerified={() => refetchVirtualStocks()} />
+      )}
+
       <OrderDetail
         order={order}
         virtualStocks={virtualStocks}
@@ -439,19 +525,90 @@ function OrderDetailContent({ orderNo }: { orderNo: string }) {
         pluginSlotPath={`/orders/${orderNo}`}
         paymentCard={
           isPendingPayment ? (
-            <PaymentMethodCard
-              orderNo={orderNo}
-              onPaymentSelected={() => {
-                refetch()
-              }}
-              pluginSlotNamespace="user.order_detail.payment"
-              pluginSlotContext={userOrderDetailPluginContext}
-              pluginSlotPath={`/orders/${orderNo}`}
-            />
+            <div className="space-y-4">
+              {netTermsEnabled ? (
+                <NetTermsCard
+                  orderNo={orderNo}
+                  currency={order.currency}
+                  totalAmountMinor={order.total_amount_minor ?? 0}
+                  onPaid={() => refetch()}
+                />
+              ) : null}
+              {cashOnDeliveryEnabled ? (
+                <CashOnDeliveryCard orderNo={orderNo} onPaid={() => refetch()} />
+              ) : null}
+              <PaymentMethodCard
+                orderNo={orderNo}
+                currency={order.currency}
+                onPaymentSelected={() => {
+                  refetch()
+                }}
+                pluginSlotNamespace="user.order_detail.payment"
+                pluginSlotContext={userOrderDetailPluginContext}
+                pluginSlotPath={`/orders/${orderNo}`}
+              />
+              {paymentLinkEnabled ? <PaymentLinkShareCard orderNo={orderNo} /> : null}
+            </div>
           ) : undefined
         }
         shippingForm={shippingFormNode}
       />
+      {virtualStocks.length > 0 && (
+        <LicenseActivationCard
+          queryKey={['orderLicenseActivations', orderNo]}
+          fetchActivations={() => getOrderLicenseActivations(orderNo)}
+          deactivate={(activationId) => deactivateOrderLicenseActivation(orderNo, activationId)}
+        />
+      )}
+      {virtualStocks.length > 0 && (
+        <UsageMeterCard
+          queryKey={['orderUsageMeters', orderNo]}
+          fetchMeters={() => getOrderUsageMeters(orderNo)}
+          currency={order.currency || 'CNY'}
+          overageOrderHref={(meter) => `/orders/${meter.overage_order_no}`}
+        />
+      )}
+      <RefundRequestCard
+        orderNo={orderNo}
+        canRequest={REFUND_REQUEST_STATUSES.includes(order.status)}
+        onChanged={() => refetch()}
+      />
+      <PartialRefundCard
+        orderNo={orderNo}
+        items={order.items || []}
+        canRequest={REFUND_REQUEST_STATUSES.includes(order.status)}
+        onChanged={() => refetch()}
+      />
+      {returnsEnabled ? (
+        <ReturnRequestCard
+          orderNo={orderNo}
+          items={order.items || []}
+          canRequest={RETURN_REQUEST_STATUSES.includes(order.status)}
+          onChanged={() => refetch()}
+        />
+      ) : null}
+      <OrderDocumentsCard
+        queryKey={['orderDocuments', orderNo, order.status]}
+        fetchDocuments={() => getOrderDocuments(orderNo)}
+        openDocument={async (document, item, format) => {
+          const res = await getOrderDocumentToken(orderNo, document.key, item)
+          const query = format === 'pdf' ? '?format=pdf' : ''
+          window.open(
+            resolvePublicAPIURL(`/api/user/documents/${res.data.token}${query}`),
+            '_blank'
+          )
+        }}
+        pdfEnabled={invoicePdfEnabled}
+      />
+      <OrderSharesCard
+        orderNo={orderNo}
+        shares={order.active_shares || []}
+        onChanged={() => refetch()}
+      />
+      <OrderTimelineCard
+        queryKey={['orderTimeline', orderNo, order.status]}
+        fetchTimeline={() => getOrderTimeline(orderNo)}
+      />
       <PluginSlot slot="user.order_detail.bottom" context={userOrderDetailPluginContext} />
     </div>
   )
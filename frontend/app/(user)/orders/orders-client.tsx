@@ -7,13 +7,17 @@ import { OrderList } from '@/components/orders/order-list'
 import { OrderFilter } from '@/components/orders/order-filter'
 import { Button } from '@/components/ui/button'
 import { Card, CardContent } from '@/components/ui/card'
-import { RefreshCw } from 'lucide-react'
+import { Download, RefreshCw } from 'lucide-react'
 import { useLocale } from '@/hooks/use-locale'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { getTranslations } from '@/lib/i18n'
 import { useResponsiveLayout } from '@/hooks/use-mobile'
 import { Order } from '@/types/order'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import { OrderExportDialog, OrderExportOptions } from '@/components/orders/order-export-dialog'
+import { useToast } from '@/hooks/use-toast'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
 import { useDebounce } from '@/hooks/use-debounce'
 import { buildUpdatedQueryString, normalizeQueryString } from '@/lib/query-state'
 import {
@@ -42,6 +46,9 @@ function OrdersPageContent() {
   const t = getTranslations(locale)
   usePageTitle(t.pageTitle.orders)
   const { isPhone, isMobile, mounted } = useResponsiveLayout()
+  const toast = useToast()
+  const [exportOpen, setExportOpen] = useState(false)
+  const [exporting, setExporting] = useState(false)
   const searchParamsKey = searchParams.toString()
   const initialQuery = parseOrderListSearchParams(searchParams)
   const initialSearch = initialQuery.search
@@ -424,6 +431,37 @@ function OrdersPageContent() {
     restoreOrdersScrollTop,
   ])
 
+  // 导出订单：沿用当前状态筛选，文件由后端流式生成
+  const handleExport = async (options: OrderExportOptions) => {
+    if (exporting) return
+    setExporting(true)
+    try {
+      const params = new URLSearchParams({ format: options.format })
+      if (status) params.append('status', status)
+      if (options.from) params.append('from', options.from)
+      if (options.to) params.append('to', options.to)
+      if (options.sku) params.append('sku', options.sku)
+      const res = await fetch(resolveClientAPIProxyURL(`/api/user/orders/export?${params}`))
+      if (!res.ok) {
+        const payload = await res.json().catch(() => undefined)
+        throw payload
+      }
+      const blobUrl = window.URL.createObjectURL(await res.blob())
+      const a = document.createElement('a')
+      a.href = blobUrl
+      a.download = `orders_${new Date().toISOString().slice(0, 10)}.${options.format}`
+      document.body.appendChild(a)
+      a.click()
+      document.body.removeChild(a)
+      window.URL.revokeObjectURL(blobUrl)
+      setExportOpen(false)
+    } catch (error: any) {
+      toast.error(resolveApiErrorMessage(error, t, t.order.exportFailed))
+    } finally {
+      setExporting(false)
+    }
+  }
+
   return (
     <div className="space-y-6">
       <PluginSlot slot="user.orders.top" context={userOrdersPluginContext} />
@@ -431,25 +469,46 @@ function OrdersPageContent() {
         <div>
           <h1 className="text-3xl font-bold">{t.order.myOrders}</h1>
         </div>
-        <Button
-          variant="outline"
-          size="sm"
-          onClick={handleRefresh}
-          disabled={isFetching}
-          aria-label={t.common.refresh}
-          title={t.common.refresh}
-          className="shrink-0"
-        >
-          <RefreshCw
-            className={`h-4 w-4 ${!isMobile ? 'mr-2' : ''} ${isFetching ? 'animate-spin' : ''}`}
-          />
-          {isMobile ? (
-            <span className="sr-only">{t.common.refresh}</span>
-          ) : (
-            <span>{t.common.refresh}</span>
-          )}
-        </Button>
+        <div className="flex shrink-0 gap-2">
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={() => setExportOpen(true)}
+            aria-label={t.order.exportOrders}
+            title={t.order.exportOrders}
+          >
+            <Download className={`h-4 w-4 ${!isMobile ? 'mr-2' : ''}`} />
+            {isMobile ? (
+              <span className="sr-only">{t.order.exportOrders}</span>
+            ) : (
+              <span>{t.order.exportOrders}</span>
+            )}
+          </Button>
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={handleRefresh}
+            disabled={isFetching}
+            aria-label={t.common.refresh}
+            title={t.common.refresh}
+          >
+            <RefreshCw
+              className={`h-4 w-4 ${!isMobile ? 'mr-2' : ''} ${isFetching ? 'animate-spin' : ''}`}
+            />
+            {isMobile ? (
+              <span className="sr-only">{t.common.refresh}</span>
+            ) : (
+              <span>{t.common.refresh}</span>
+            )}
+          </Button>
+        </div>
       </div>
+      <OrderExportDialog
+        open={exportOpen}
+        onOpenChange={setExportOpen}
+        exporting={exporting}
+        onExport={handleExport}
+      />
 
       <OrderFilter
         status={status}
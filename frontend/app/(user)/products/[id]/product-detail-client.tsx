@@ -35,6 +35,7 @@ import { usePageTitle } from '@/hooks/use-page-title'
 import { getTranslations } from '@/lib/i18n'
 import { useCurrency, formatPrice, getCurrencySymbol } from '@/contexts/currency-context'
 import { addToGuestCart } from '@/lib/guest-cart'
+import { trackAnalyticsEvent } from '@/lib/analytics-events'
 import {
   clearAuthReturnState,
   readAuthReturnState,
@@ -51,6 +52,8 @@ import {
   getProductStockQueryOptions,
   getPublicConfigQueryOptions,
 } from '@/lib/product-detail-queries'
+import { WaitingRoomCard } from './waiting-room-card'
+import { TrialCard } from './trial-card'
 
 type GuestActionHint = 'cart_added' | 'login_for_checkout' | 'login_for_promo' | null
 
@@ -66,6 +69,7 @@ export default function ProductDetailClient({ productId }: { productId: number }
   const [isAddingToCart, setIsAddingToCart] = useState(false)
   const [productListBackHref, setProductListBackHref] = useState('/products')
   const [guestActionHint, setGuestActionHint] = useState<GuestActionHint>(null)
+  const [waitingRoomAdmitted, setWaitingRoomAdmitted] = useState(false)
   const hasRestoredAuthReturnStateRef = useRef(false)
   const toast = useToast()
   const { user, isAuthenticated, isLoading: authLoading } = useAuth()
@@ -546,6 +550,7 @@ export default function ProductDetailClient({ productId }: { productId: number }
         },
         maxItemQuantity
       )
+      trackAnalyticsEvent({ type: 'add_to_cart', product_id: productId, quantity })
       setGuestActionHint('cart_added')
       toast.success(t.product.guestCartAdded)
       return
@@ -560,6 +565,7 @@ export default function ProductDetailClient({ productId }: { productId: number }
       })
       queryClient.invalidateQueries({ queryKey: ['cart'] })
       queryClient.invalidateQueries({ queryKey: ['cartCount'] })
+      trackAnalyticsEvent({ type: 'add_to_cart', product_id: productId, quantity })
       setGuestActionHint(null)
       toast.success(t.cart.addedToCart)
     } catch (error: any) {
@@ -1287,6 +1293,17 @@ export default function ProductDetailClient({ productId }: { productId: number }
                   context={{ ...userProductDetailPluginContext, section: 'purchase_actions' }}
                 />
 
+                {product.waiting_room && isAuthenticated && (
+                  <WaitingRoomCard
+                    productId={productId}
+                    onAdmittedChange={setWaitingRoomAdmitted}
+                  />
+                )}
+
+                {product.trial_days > 0 && isAuthenticated && (
+                  <TrialCard productId={productId} trialDays={product.trial_days} />
+                )}
+
                 {/* Action buttons */}
                 <div className="flex flex-col gap-3 sm:flex-row">
                   <Button
@@ -1315,7 +1332,8 @@ export default function ProductDetailClient({ productId }: { productId: number }
                       authLoading ||
                       !isAvailable ||
                       !allAttributesSelected ||
-                      createOrderMutation.isPending
+                      createOrderMutation.isPending ||
+                      (product.waiting_room && isAuthenticated && !waitingRoomAdmitted)
                     }
                     onClick={handleBuyNow}
                   >
@@ -0,0 +1,104 @@
+'use client'
+
+import { useRouter } from 'next/navigation'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Gift, Loader2 } from 'lucide-react'
+
+import { ProductTrial, getMyTrials, startProductTrial } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert'
+import { Button } from '@/components/ui/button'
+
+// 免费试用：每个账户每个商品可领取一次，领取后展示试用状态与转正订单
+export function TrialCard({
+  productId,
+  trialDays,
+}: {
+  productId: number
+  trialDays: number
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const router = useRouter()
+  const queryClient = useQueryClient()
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['myTrials'],
+    queryFn: getMyTrials,
+  })
+  const trials: ProductTrial[] = data?.data || []
+  const trial = trials.find((item) => item.product_id === productId)
+
+  const startMutation = useMutation({
+    mutationFn: () => startProductTrial(productId),
+    onSuccess: (res: any) => {
+      const started: ProductTrial | undefined = res?.data
+      toast.success(t.product.trialStarted)
+      queryClient.invalidateQueries({ queryKey: ['myTrials'] })
+      if (started?.order_no) {
+        router.push(`/orders/${started.order_no}`)
+      }
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.product.trialStartFailed))
+    },
+  })
+
+  if (isLoading) {
+    return null
+  }
+
+  if (!trial) {
+    return (
+      <Alert>
+        <Gift className="h-4 w-4" />
+        <AlertTitle>{t.product.trialTitle}</AlertTitle>
+        <AlertDescription className="space-y-2">
+          <p>{t.product.trialDesc.replace('{days}', String(trialDays))}</p>
+          <Button
+            size="sm"
+            variant="outline"
+            disabled={startMutation.isPending}
+            onClick={() => startMutation.mutate()}
+          >
+            {startMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            {t.product.trialStart.replace('{days}', String(trialDays))}
+          </Button>
+        </AlertDescription>
+      </Alert>
+    )
+  }
+
+  return (
+    <Alert>
+      <Gift className="h-4 w-4" />
+      <AlertTitle>{t.product.trialTitle}</AlertTitle>
+      <AlertDescription className="space-y-1">
+        {trial.status === 'active' && (
+          <p>{t.product.trialActive.replace('{time}', formatDate(trial.expires_at))}</p>
+        )}
+        {trial.status === 'active' && trial.conversion_order_no && (
+          <p>
+            {t.product.trialConvertPending.replace('{order}', trial.conversion_order_no)}{' '}
+            <Link href={`/orders/${trial.conversion_order_no}`} className="underline">
+              {t.product.trialPayNow}
+            </Link>
+          </p>
+        )}
+        {trial.status === 'converted' && <p>{t.product.trialConverted}</p>}
+        {trial.status === 'expired' && <p>{t.product.trialExpired}</p>}
+        {trial.order_no && (
+          <Link href={`/orders/${trial.order_no}`} className="text-sm underline">
+            {t.product.trialViewOrder}
+          </Link>
+        )}
+      </AlertDescription>
+    </Alert>
+  )
+}
@@ -0,0 +1,104 @@
+'use client'
+
+import { useEffect } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Hourglass, Loader2, Ticket } from 'lucide-react'
+
+import { WaitingRoomTicket, getWaitingRoomTicket, joinWaitingRoom } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { saveWaitingRoomToken } from '@/lib/waiting-room'
+import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert'
+import { Button } from '@/components/ui/button'
+
+// 限量发售等候室：排队、轮询位置，放行后保存准入令牌供下单使用
+export function WaitingRoomCard({
+  productId,
+  onAdmittedChange,
+}: {
+  productId: number
+  onAdmittedChange: (admitted: boolean) => void
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const queryKey = ['waitingRoom', productId]
+
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getWaitingRoomTicket(productId),
+    refetchInterval: (query) => {
+      const current: WaitingRoomTicket | undefined = (query.state.data as any)?.data
+      return current?.status === 'queued' ? (current.poll_after_seconds || 5) * 1000 : false
+    },
+  })
+  const ticket: WaitingRoomTicket | undefined = data?.data
+
+  const joinMutation = useMutation({
+    mutationFn: () => joinWaitingRoom(productId),
+    onSuccess: (res: any) => {
+      queryClient.setQueryData(queryKey, res)
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.product.waitingRoomJoinFailed))
+    },
+  })
+
+  useEffect(() => {
+    if (ticket?.status === 'admitted' && ticket.access_token) {
+      saveWaitingRoomToken(productId, ticket.access_token, ticket.expires_at)
+    }
+    onAdmittedChange(ticket?.status === 'admitted' || ticket?.status === 'not_required')
+  }, [ticket, productId, onAdmittedChange])
+
+  if (!ticket || ticket.status === 'not_required') {
+    return null
+  }
+
+  if (ticket.status === 'admitted') {
+    return (
+      <Alert>
+        <Ticket className="h-4 w-4" />
+        <AlertTitle>{t.product.waitingRoomAdmitted}</AlertTitle>
+        <AlertDescription>
+          {t.product.waitingRoomAdmittedDesc.replace(
+            '{time}',
+            ticket.expires_at ? formatDate(ticket.expires_at) : '-'
+          )}
+        </AlertDescription>
+      </Alert>
+    )
+  }
+
+  return (
+    <Alert>
+      <Hourglass className="h-4 w-4" />
+      <AlertTitle>{t.product.waitingRoomTitle}</AlertTitle>
+      <AlertDescription className="space-y-2">
+        {ticket.status === 'queued' ? (
+          <p>
+            {t.product.waitingRoomPosition
+              .replace('{position}', String(ticket.position ?? '-'))
+              .replace('{total}', String(ticket.queue_length ?? '-'))}
+          </p>
+        ) : (
+          <>
+            <p>{t.product.waitingRoomDesc}</p>
+            <Button
+              size="sm"
+              disabled={joinMutation.isPending}
+              onClick={() => joinMutation.mutate()}
+            >
+              {joinMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.product.waitingRoomJoin}
+            </Button>
+          </>
+        )}
+      </AlertDescription>
+    </Alert>
+  )
+}
@@ -0,0 +1,324 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Copy, KeyRound, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  createPersonalAccessToken,
+  getPersonalAccessTokens,
+  revokePersonalAccessToken,
+  type PersonalAccessToken,
+  type PersonalTokenScope,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { copyToClipboard } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Checkbox } from '@/components/ui/checkbox'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const EXPIRY_OPTIONS = [30, 90, 365, 0]
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleString() : '-'
+}
+
+export default function APITokensPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.apiTokens)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+
+  const [name, setName] = useState('')
+  const [scopes, setScopes] = useState<PersonalTokenScope[]>(['orders:read'])
+  const [expiresInDays, setExpiresInDays] = useState('90')
+  const [createdToken, setCreatedToken] = useState<string | null>(null)
+  const [revoking, setRevoking] = useState<PersonalAccessToken | null>(null)
+
+  const scopeOptions: { value: PersonalTokenScope; label: string; desc: string }[] = [
+    {
+      value: 'orders:read',
+      label: t.apiTokens.scopeOrdersRead,
+      desc: t.apiTokens.scopeOrdersReadDesc,
+    },
+    {
+      value: 'virtual_products:read',
+      label: t.apiTokens.scopeVirtualProductsRead,
+      desc: t.apiTokens.scopeVirtualProductsReadDesc,
+    },
+  ]
+  const scopeLabels = Object.fromEntries(
+    scopeOptions.map((option) => [option.value, option.label])
+  ) as Record<PersonalTokenScope, string>
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['personalAccessTokens'],
+    queryFn: getPersonalAccessTokens,
+  })
+  const tokens: PersonalAccessToken[] = data?.data?.items || []
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      createPersonalAccessToken({
+        name: name.trim(),
+        scopes,
+        expires_in_days: Number(expiresInDays),
+      }),
+    onSuccess: (res: any) => {
+      setCreatedToken(res?.data?.token || null)
+      setName('')
+      queryClient.invalidateQueries({ queryKey: ['personalAccessTokens'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.apiTokens.createFailed))
+    },
+  })
+
+  const revokeMutation = useMutation({
+    mutationFn: (id: number) => revokePersonalAccessToken(id),
+    onSuccess: () => {
+      toast.success(t.apiTokens.revokeSuccess)
+      queryClient.invalidateQueries({ queryKey: ['personalAccessTokens'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.apiTokens.revokeFailed))
+    },
+  })
+
+  const toggleScope = (scope: PersonalTokenScope) => {
+    setScopes((current) =>
+      current.includes(scope) ? current.filter((item) => item !== scope) : [...current, scope]
+    )
+  }
+
+  const handleCopy = async () => {
+    if (createdToken && (await copyToClipboard(createdToken))) {
+      toast.success(t.apiTokens.copied)
+    }
+  }
+
+  const tokenState = (token: PersonalAccessToken) => {
+    if (token.revoked_at) return t.apiTokens.revoked
+    if (token.expires_at && new Date(token.expires_at).getTime() < Date.now()) {
+      return t.apiTokens.expired
+    }
+    return null
+  }
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <h1 className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}>
+          {t.apiTokens.title}
+        </h1>
+      </div>
+
+      <Card>
+        <CardHeader>
+          <CardTitle>{t.apiTokens.createTitle}</CardTitle>
+          <CardDescription>{t.apiTokens.description}</CardDescription>
+        </CardHeader>
+        <CardContent className="space-y-4">
+          <div className="grid gap-4 md:grid-cols-2">
+            <div className="space-y-2">
+              <Label>{t.apiTokens.name}</Label>
+              <Input
+                value={name}
+                placeholder={t.apiTokens.namePlaceholder}
+                onChange={(e) => setName(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.apiTokens.expiry}</Label>
+              <Select value={expiresInDays} onValueChange={setExpiresInDays}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {EXPIRY_OPTIONS.map((days) => (
+                    <SelectItem key={days} value={String(days)}>
+                      {days > 0
+                        ? t.apiTokens.expiryDays.replace('{days}', String(days))
+                        : t.apiTokens.expiryNever}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          </div>
+          <div className="space-y-2">
+            <Label>{t.apiTokens.scopes}</Label>
+            {scopeOptions.map((option) => (
+              <label key={option.value} className="flex items-start gap-3 text-sm">
+                <Checkbox
+                  className="mt-0.5"
+                  checked={scopes.includes(option.value)}
+                  onCheckedChange={() => toggleScope(option.value)}
+                />
+                <span>
+                  <span className="font-medium">{option.label}</span>
+                  <span className="block text-xs text-muted-foreground">{option.desc}</span>
+                </span>
+              </label>
+            ))}
+          </div>
+          <Button
+            onClick={() => createMutation.mutate()}
+            disabled={createMutation.isPending || !name.trim() || scopes.length === 0}
+          >
+            {createMutation.isPending ? <Loader2 className="mr-2 h-4 w-4 animate-spin" /> : null}
+            {t.apiTokens.create}
+          </Button>
+          <p className="text-xs text-muted-foreground">{t.apiTokens.usageHint}</p>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardHeader>
+          <CardTitle>{t.apiTokens.tokens}</CardTitle>
+        </CardHeader>
+        <CardContent className="p-0">
+          {isLoading ? (
+            <div className="flex justify-center py-8">
+              <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+            </div>
+          ) : tokens.length === 0 ? (
+            <p className="p-4 text-sm text-muted-foreground">{t.apiTokens.noTokens}</p>
+          ) : (
+            <div className="divide-y">
+              {tokens.map((token) => {
+                const state = tokenState(token)
+                return (
+                  <div
+                    key={token.id}
+                    className="flex flex-wrap items-center justify-between gap-3 p-4 text-sm"
+                  >
+                    <div className="space-y-1">
+                      <div className="flex items-center gap-2 font-medium">
+                        <KeyRound className="h-4 w-4 text-muted-foreground" />
+                        {token.name}
+                        {state ? <Badge variant="outline">{state}</Badge> : null}
+                      </div>
+                      <div className="font-mono text-xs text-muted-foreground">
+                        {token.token_prefix}…
+                      </div>
+                      <div className="flex flex-wrap gap-1">
+                        {token.scopes.map((scope) => (
+                          <Badge key={scope} variant="secondary">
+                            {scopeLabels[scope] || scope}
+                          </Badge>
+                        ))}
+                      </div>
+                      <div className="text-xs text-muted-foreground">
+                        {token.last_used_at
+                          ? t.apiTokens.lastUsed.replace('{date}', formatDate(token.last_used_at))
+                          : t.apiTokens.neverUsed}
+                        {token.last_used_ip ? ` · ${token.last_used_ip}` : ''}
+                        {token.expires_at && !state
+                          ? ` · ${t.apiTokens.expiresOn.replace('{date}', formatDate(token.expires_at))}`
+                          : ''}
+                      </div>
+                    </div>
+                    {!token.revoked_at ? (
+                      <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={() => setRevoking(token)}
+                        disabled={revokeMutation.isPending}
+                      >
+                        {t.apiTokens.revoke}
+                      </Button>
+                    ) : null}
+                  </div>
+                )
+              })}
+            </div>
+          )}
+        </CardContent>
+      </Card>
+
+      <Dialog open={Boolean(createdToken)} onOpenChange={(open) => (!open ? setCreatedToken(null) : null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.apiTokens.createdTitle}</DialogTitle>
+            <DialogDescription>{t.apiTokens.createdHint}</DialogDescription>
+          </DialogHeader>
+          <div className="flex items-center gap-2">
+            <Input readOnly className="font-mono text-xs" value={createdToken || ''} />
+            <Button size="icon" variant="outline" onClick={handleCopy}>
+              <Copy className="h-4 w-4" />
+            </Button>
+          </div>
+          <DialogFooter>
+            <Button onClick={() => setCreatedToken(null)}>{t.apiTokens.done}</Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog open={Boolean(revoking)} onOpenChange={(open) => (!open ? setRevoking(null) : null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.apiTokens.revoke}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.apiTokens.revokeConfirm.replace('{name}', revoking?.name || '')}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (revoking) revokeMutation.mutate(revoking.id)
+                setRevoking(null)
+              }}
+            >
+              {t.apiTokens.revoke}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
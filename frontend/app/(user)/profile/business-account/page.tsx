@@ -0,0 +1,330 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Building2, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  applyBusinessAccount,
+  getMyBusinessAccount,
+  getMyBusinessAccountStatement,
+  getMyNetTermsInvoices,
+  type BusinessAccount,
+  type NetTermsInvoice,
+  type NetTermsStatement,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+function formatDateInput(date: Date) {
+  return date.toISOString().slice(0, 10)
+}
+
+export default function BusinessAccountPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.businessAccount)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+
+  const [form, setForm] = useState({
+    company_name: '',
+    tax_id: '',
+    billing_email: '',
+    billing_address: '',
+  })
+  const [period, setPeriod] = useState(() => {
+    const now = new Date()
+    return {
+      start: formatDateInput(new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth(), 1))),
+      end: formatDateInput(now),
+    }
+  })
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['myBusinessAccount'],
+    queryFn: getMyBusinessAccount,
+  })
+  const account: BusinessAccount | null = data?.data?.account || null
+  const isActive = account?.status === 'approved' || account?.status === 'suspended'
+
+  const { data: invoicesData } = useQuery({
+    queryKey: ['myNetTermsInvoices'],
+    queryFn: () => getMyNetTermsInvoices({ page: 1, limit: 50 }),
+    enabled: isActive,
+  })
+  const invoices: NetTermsInvoice[] = invoicesData?.data?.items || []
+
+  const { data: statementData } = useQuery({
+    queryKey: ['myBusinessAccountStatement', period.start, period.end],
+    queryFn: () => getMyBusinessAccountStatement({ start: period.start, end: period.end }),
+    enabled: isActive && Boolean(period.start && period.end),
+  })
+  const statement: NetTermsStatement | undefined = statementData?.data
+
+  const applyMutation = useMutation({
+    mutationFn: () => applyBusinessAccount(form),
+    onSuccess: () => {
+      toast.success(t.businessAccount.applySubmitted)
+      queryClient.invalidateQueries({ queryKey: ['myBusinessAccount'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.businessAccount.applyFailed))
+    },
+  })
+
+  const statusLabels: Record<BusinessAccount['status'], string> = {
+    pending: t.businessAccount.statusPending,
+    approved: t.businessAccount.statusApproved,
+    rejected: t.businessAccount.statusRejected,
+    suspended: t.businessAccount.statusSuspended,
+  }
+
+  const invoiceStatusLabels: Record<NetTermsInvoice['status'], string> = {
+    open: t.businessAccount.invoiceOpen,
+    paid: t.businessAccount.invoicePaid,
+    void: t.businessAccount.invoiceVoid,
+  }
+
+  const canApply = !account || account.status === 'rejected'
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <h1 className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}>
+          {t.businessAccount.title}
+        </h1>
+      </div>
+
+      {isLoading ? (
+        <div className="flex justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+        </div>
+      ) : null}
+
+      {account ? (
+        <Card>
+          <CardHeader>
+            <CardTitle className="flex items-center gap-2">
+              <Building2 className="h-5 w-5" />
+              {account.company_name}
+              <Badge variant={account.status === 'approved' ? 'secondary' : 'outline'}>
+                {statusLabels[account.status]}
+              </Badge>
+              {account.credit_hold ? (
+                <Badge variant="destructive">{t.businessAccount.creditHold}</Badge>
+              ) : null}
+            </CardTitle>
+            {account.status === 'pending' ? (
+              <CardDescription>{t.businessAccount.pendingHint}</CardDescription>
+            ) : null}
+            {account.status === 'rejected' && account.review_note ? (
+              <CardDescription>
+                {t.businessAccount.rejectedReason.replace('{reason}', account.review_note)}
+              </CardDescription>
+            ) : null}
+          </CardHeader>
+          {isActive && account.credit ? (
+            <CardContent>
+              <div className="grid grid-cols-2 gap-3 text-sm md:grid-cols-4">
+                {[
+                  [t.businessAccount.creditLimit, account.credit.credit_limit_minor],
+                  [t.businessAccount.outstanding, account.credit.outstanding_minor],
+                  [t.businessAccount.overdue, account.credit.overdue_minor],
+                  [t.businessAccount.availableCredit, account.credit.available_minor],
+                ].map(([label, amount]) => (
+                  <div key={label as string} className="rounded-md border p-3">
+                    <div className="text-xs text-muted-foreground">{label}</div>
+                    <div className="font-medium">
+                      {formatCurrency(amount as number, account.credit!.currency)}
+                    </div>
+                  </div>
+                ))}
+              </div>
+              <p className="mt-3 text-sm text-muted-foreground">
+                {t.businessAccount.termsSummary.replace('{days}', String(account.terms_days))}
+              </p>
+              {account.credit_hold ? (
+                <p className="mt-2 text-sm text-destructive">
+                  {t.businessAccount.creditHoldNotice}
+                </p>
+              ) : null}
+            </CardContent>
+          ) : null}
+        </Card>
+      ) : null}
+
+      {!isLoading && canApply ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.businessAccount.applyTitle}</CardTitle>
+            <CardDescription>{t.businessAccount.applyDesc}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="grid gap-4 md:grid-cols-2">
+              <div className="space-y-2">
+                <Label>{t.businessAccount.companyName}</Label>
+                <Input
+                  value={form.company_name}
+                  onChange={(e) => setForm({ ...form, company_name: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.businessAccount.taxId}</Label>
+                <Input
+                  value={form.tax_id}
+                  onChange={(e) => setForm({ ...form, tax_id: e.target.value })}
+                />
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.businessAccount.billingEmail}</Label>
+              <Input
+                type="email"
+                value={form.billing_email}
+                onChange={(e) => setForm({ ...form, billing_email: e.target.value })}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.businessAccount.billingAddress}</Label>
+              <Textarea
+                rows={3}
+                value={form.billing_address}
+                onChange={(e) => setForm({ ...form, billing_address: e.target.value })}
+              />
+            </div>
+            <Button
+              onClick={() => applyMutation.mutate()}
+              disabled={applyMutation.isPending || !form.company_name.trim()}
+            >
+              {t.businessAccount.applySubmit}
+            </Button>
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {isActive ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.businessAccount.invoices}</CardTitle>
+          </CardHeader>
+          <CardContent className="p-0">
+            {invoices.length === 0 ? (
+              <p className="p-4 text-sm text-muted-foreground">{t.businessAccount.noInvoices}</p>
+            ) : (
+              <div className="divide-y">
+                {invoices.map((invoice) => {
+                  const overdue =
+                    invoice.status === 'open' && new Date(invoice.due_at).getTime() < Date.now()
+                  return (
+                    <div
+                      key={invoice.id}
+                      className="flex items-center justify-between gap-4 p-4 text-sm"
+                    >
+                      <div>
+                        <div className="font-mono">{invoice.invoice_no}</div>
+                        <Link
+                          href={`/orders/${invoice.order_no}`}
+                          className="text-xs text-muted-foreground hover:underline"
+                        >
+                          {invoice.order_no}
+                        </Link>
+                      </div>
+                      <div className="text-right">
+                        <div className="font-medium">
+                          {formatCurrency(invoice.amount_minor, invoice.currency)}
+                        </div>
+                        <div
+                          className={
+                            overdue ? 'text-xs text-destructive' : 'text-xs text-muted-foreground'
+                          }
+                        >
+                          {overdue
+                            ? t.businessAccount.invoiceOverdue
+                            : invoiceStatusLabels[invoice.status]}
+                          {invoice.status === 'open'
+                            ? ` · ${t.businessAccount.dueOn.replace('{date}', formatDate(invoice.due_at))}`
+                            : ''}
+                        </div>
+                      </div>
+                    </div>
+                  )
+                })}
+              </div>
+            )}
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {isActive ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.businessAccount.statement}</CardTitle>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="grid grid-cols-2 gap-4 md:max-w-md">
+              <div className="space-y-2">
+                <Label>{t.businessAccount.periodStart}</Label>
+                <Input
+                  type="date"
+                  value={period.start}
+                  onChange={(e) => setPeriod({ ...period, start: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.businessAccount.periodEnd}</Label>
+                <Input
+                  type="date"
+                  value={period.end}
+                  onChange={(e) => setPeriod({ ...period, end: e.target.value })}
+                />
+              </div>
+            </div>
+            {statement ? (
+              <div className="space-y-1 text-sm">
+                {[
+                  [t.businessAccount.openingBalance, statement.opening_balance_minor],
+                  [t.businessAccount.invoiced, statement.invoiced_minor],
+                  [t.businessAccount.paid, statement.paid_minor],
+                  [t.businessAccount.voided, statement.voided_minor],
+                  [t.businessAccount.closingBalance, statement.closing_balance_minor],
+                ].map(([label, amount]) => (
+                  <div key={label as string} className="flex justify-between">
+                    <span className="text-muted-foreground">{label}</span>
+                    <span className="font-medium">
+                      {formatCurrency(amount as number, statement.currency)}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            ) : null}
+          </CardContent>
+        </Card>
+      ) : null}
+    </div>
+  )
+}
@@ -0,0 +1,131 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  acceptPolicies,
+  getPolicyConsents,
+  type PolicyConsent,
+  type PolicyVersion,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { ConsentHistoryList } from '@/components/consent-history-list'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Checkbox } from '@/components/ui/checkbox'
+import { MarkdownMessage } from '@/components/ui/markdown-message'
+
+export default function ConsentsPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.consents)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+  const [agreed, setAgreed] = useState(false)
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['policyConsents'],
+    queryFn: getPolicyConsents,
+  })
+  const pending: PolicyVersion[] = data?.data?.pending || []
+  const history: PolicyConsent[] = data?.data?.history || []
+
+  const acceptMutation = useMutation({
+    mutationFn: () => acceptPolicies(pending.map((policy) => policy.id)),
+    onSuccess: () => {
+      setAgreed(false)
+      toast.success(t.consent.accepted)
+      queryClient.invalidateQueries({ queryKey: ['policyConsents'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.consent.acceptFailed))
+      queryClient.invalidateQueries({ queryKey: ['policyConsents'] })
+    },
+  })
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <div>
+          <h1
+            className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}
+          >
+            {t.consent.title}
+          </h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.consent.desc}</p>
+        </div>
+      </div>
+
+      {isLoading ? (
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin" />
+        </div>
+      ) : (
+        <>
+          {pending.length > 0 ? (
+            <Card className="border-amber-300">
+              <CardHeader>
+                <CardTitle className="text-base">{t.consent.pendingTitle}</CardTitle>
+                <CardDescription>{t.consent.popupDesc}</CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-4">
+                {pending.map((policy) => (
+                  <details key={policy.id} className="rounded-md border p-3">
+                    <summary className="cursor-pointer text-sm font-medium">
+                      {policy.title} · {t.consent.version} {policy.version}
+                    </summary>
+                    {policy.summary ? (
+                      <p className="mt-2 text-sm text-muted-foreground">{policy.summary}</p>
+                    ) : null}
+                    <div className="mt-2 max-h-80 overflow-y-auto rounded-md bg-muted/40 p-3">
+                      <MarkdownMessage content={policy.content} className="markdown-body" />
+                    </div>
+                  </details>
+                ))}
+                <label className="flex items-center gap-2 text-sm">
+                  <Checkbox
+                    checked={agreed}
+                    onCheckedChange={(checked) => setAgreed(checked === true)}
+                  />
+                  {t.consent.agree}
+                </label>
+                <Button
+                  disabled={!agreed || acceptMutation.isPending}
+                  onClick={() => acceptMutation.mutate()}
+                >
+                  {acceptMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                  {t.consent.accept}
+                </Button>
+              </CardContent>
+            </Card>
+          ) : null}
+
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-base">{t.consent.history}</CardTitle>
+            </CardHeader>
+            <CardContent>
+              <ConsentHistoryList history={history} />
+            </CardContent>
+          </Card>
+        </>
+      )}
+    </div>
+  )
+}
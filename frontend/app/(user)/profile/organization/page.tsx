@@ -0,0 +1,727 @@
+'use client'
+
+import { Suspense, useState } from 'react'
+import Link from 'next/link'
+import { useRouter, useSearchParams } from 'next/navigation'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Loader2, Pencil, Trash2, Users } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  acceptOrganizationInvitation,
+  createOrganization,
+  getMyOrganization,
+  getOrganizationInvitations,
+  getOrganizationOrders,
+  inviteOrganizationMember,
+  leaveOrganization,
+  previewOrganizationInvitation,
+  removeOrganizationMember,
+  renameOrganization,
+  revokeOrganizationInvitation,
+  updateOrganizationMember,
+  type OrganizationInvitation,
+  type OrganizationMember,
+  type OrganizationOverview,
+  type OrganizationRole,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency, majorToMinor, minorToMajor } from '@/lib/utils'
+import type { Order } from '@/types/order'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { OrderStatusBadge } from '@/components/orders/order-status-badge'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+interface PendingConfirm {
+  title: string
+  description: string
+  onConfirm: () => void
+}
+
+function formatDate(value?: string) {
+  return value ? new Date(value).toLocaleDateString() : '-'
+}
+
+function OrganizationPageContent() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.organization)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+  const router = useRouter()
+  const searchParams = useSearchParams()
+  const inviteToken = searchParams.get('invite') || ''
+
+  const [createName, setCreateName] = useState('')
+  const [renaming, setRenaming] = useState<string | null>(null)
+  const [inviteForm, setInviteForm] = useState<{
+    email: string
+    role: OrganizationRole
+    limit: string
+  }>({ email: '', role: 'purchaser', limit: '' })
+  const [editing, setEditing] = useState<{
+    member: OrganizationMember
+    role: OrganizationRole
+    limit: string
+  } | null>(null)
+  const [pendingConfirm, setPendingConfirm] = useState<PendingConfirm | null>(null)
+  const [memberFilter, setMemberFilter] = useState('all')
+
+  const roleLabels: Record<OrganizationRole, string> = {
+    owner: t.organization.roleOwner,
+    purchaser: t.organization.rolePurchaser,
+    viewer: t.organization.roleViewer,
+  }
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['myOrganization'],
+    queryFn: getMyOrganization,
+  })
+  const overview: OrganizationOverview | null = data?.data?.organization || null
+  const isOwner = overview?.membership.role === 'owner'
+  const currency = overview?.currency || 'CNY'
+
+  const { data: invitationsData } = useQuery({
+    queryKey: ['organizationInvitations'],
+    queryFn: getOrganizationInvitations,
+    enabled: isOwner,
+  })
+  const invitations: OrganizationInvitation[] = invitationsData?.data?.items || []
+
+  const { data: ordersData } = useQuery({
+    queryKey: ['organizationOrders', memberFilter],
+    queryFn: () =>
+      getOrganizationOrders({
+        page: 1,
+        limit: 50,
+        user_id: memberFilter === 'all' ? undefined : Number(memberFilter),
+      }),
+    enabled: Boolean(overview),
+  })
+  const orders: Order[] = ordersData?.data?.items || []
+
+  const { data: previewData, isError: previewFailed } = useQuery({
+    queryKey: ['organizationInvitationPreview', inviteToken],
+    queryFn: () => previewOrganizationInvitation(inviteToken),
+    enabled: Boolean(inviteToken) && !isLoading && !overview,
+    retry: false,
+  })
+  const preview: OrganizationInvitation | undefined = previewData?.data?.invitation
+
+  const refresh = () => {
+    queryClient.invalidateQueries({ queryKey: ['myOrganization'] })
+    queryClient.invalidateQueries({ queryKey: ['organizationInvitations'] })
+    queryClient.invalidateQueries({ queryKey: ['organizationOrders'] })
+  }
+
+  const createMutation = useMutation({
+    mutationFn: () => createOrganization(createName.trim()),
+    onSuccess: () => {
+      toast.success(t.organization.created)
+      setCreateName('')
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.createFailed))
+    },
+  })
+
+  const renameMutation = useMutation({
+    mutationFn: (name: string) => renameOrganization(name),
+    onSuccess: () => {
+      toast.success(t.organization.renamed)
+      setRenaming(null)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.saveFailed))
+    },
+  })
+
+  const inviteMutation = useMutation({
+    mutationFn: () =>
+      inviteOrganizationMember({
+        email: inviteForm.email.trim(),
+        role: inviteForm.role,
+        spending_limit_minor: inviteForm.limit ? majorToMinor(inviteForm.limit) : 0,
+      }),
+    onSuccess: () => {
+      toast.success(t.organization.invited.replace('{email}', inviteForm.email.trim()))
+      setInviteForm({ email: '', role: 'purchaser', limit: '' })
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.inviteFailed))
+    },
+  })
+
+  const revokeMutation = useMutation({
+    mutationFn: (id: number) => revokeOrganizationInvitation(id),
+    onSuccess: () => {
+      toast.success(t.organization.revoked)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.revokeFailed))
+    },
+  })
+
+  const acceptMutation = useMutation({
+    mutationFn: () => acceptOrganizationInvitation(inviteToken),
+    onSuccess: () => {
+      toast.success(t.organization.accepted.replace('{name}', preview?.organization?.name || ''))
+      router.replace('/profile/organization')
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.acceptFailed))
+    },
+  })
+
+  const updateMemberMutation = useMutation({
+    mutationFn: (payload: { userId: number; role: OrganizationRole; limit: string }) =>
+      updateOrganizationMember(payload.userId, {
+        role: payload.role,
+        spending_limit_minor: payload.limit ? majorToMinor(payload.limit) : 0,
+      }),
+    onSuccess: () => {
+      toast.success(t.organization.memberUpdated)
+      setEditing(null)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.memberUpdateFailed))
+    },
+  })
+
+  const removeMemberMutation = useMutation({
+    mutationFn: (userId: number) => removeOrganizationMember(userId),
+    onSuccess: () => {
+      toast.success(t.organization.memberRemoved)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.removeFailed))
+    },
+  })
+
+  const leaveMutation = useMutation({
+    mutationFn: leaveOrganization,
+    onSuccess: () => {
+      toast.success(t.organization.left)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.organization.leaveFailed))
+    },
+  })
+
+  const formatLimit = (amount: number) =>
+    amount > 0 ? formatCurrency(amount, currency) : t.organization.unlimited
+
+  const submitMemberEdit = () => {
+    if (!editing) return
+    const payload = {
+      userId: editing.member.user_id,
+      role: editing.role,
+      limit: editing.limit,
+    }
+    if (editing.role === 'owner') {
+      setPendingConfirm({
+        title: t.organization.transferOwnership,
+        description: t.organization.transferConfirm.replace('{email}', editing.member.email),
+        onConfirm: () => updateMemberMutation.mutate(payload),
+      })
+      return
+    }
+    updateMemberMutation.mutate(payload)
+  }
+
+  const memberName = (userId: number) => {
+    const member = overview?.members.find((item) => item.user_id === userId)
+    return member ? member.name || member.email : '-'
+  }
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <h1 className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}>
+          {t.organization.title}
+        </h1>
+      </div>
+
+      {isLoading ? (
+        <div className="flex justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin text-muted-foreground" />
+        </div>
+      ) : null}
+
+      {!isLoading && !overview && inviteToken ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.organization.acceptTitle}</CardTitle>
+            <CardDescription>
+              {previewFailed
+                ? t.organization.invitationLoadFailed
+                : preview
+                  ? t.organization.acceptDesc
+                      .replace('{name}', preview.organization?.name || '')
+                      .replace('{role}', roleLabels[preview.role])
+                  : null}
+            </CardDescription>
+          </CardHeader>
+          {preview ? (
+            <CardContent>
+              <Button onClick={() => acceptMutation.mutate()} disabled={acceptMutation.isPending}>
+                {acceptMutation.isPending ? <Loader2 className="mr-2 h-4 w-4 animate-spin" /> : null}
+                {t.organization.accept}
+              </Button>
+            </CardContent>
+          ) : null}
+        </Card>
+      ) : null}
+
+      {!isLoading && !overview ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.organization.createTitle}</CardTitle>
+            <CardDescription>{t.organization.createDesc}</CardDescription>
+          </CardHeader>
+          <CardContent className="flex flex-col gap-3 md:max-w-md">
+            <Label>{t.organization.name}</Label>
+            <Input value={createName} onChange={(e) => setCreateName(e.target.value)} />
+            <Button
+              onClick={() => createMutation.mutate()}
+              disabled={createMutation.isPending || !createName.trim()}
+            >
+              {t.organization.create}
+            </Button>
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {overview ? (
+        <Card>
+          <CardHeader>
+            <CardTitle className="flex flex-wrap items-center gap-2">
+              <Users className="h-5 w-5" />
+              {renaming !== null ? (
+                <span className="flex items-center gap-2">
+                  <Input
+                    className="h-8 w-56"
+                    value={renaming}
+                    onChange={(e) => setRenaming(e.target.value)}
+                  />
+                  <Button
+                    size="sm"
+                    onClick={() => renameMutation.mutate(renaming.trim())}
+                    disabled={renameMutation.isPending || !renaming.trim()}
+                  >
+                    {t.common.save}
+                  </Button>
+                  <Button size="sm" variant="ghost" onClick={() => setRenaming(null)}>
+                    {t.common.cancel}
+                  </Button>
+                </span>
+              ) : (
+                <>
+                  {overview.organization.name}
+                  {isOwner ? (
+                    <Button
+                      size="icon"
+                      variant="ghost"
+                      className="h-7 w-7"
+                      onClick={() => setRenaming(overview.organization.name)}
+                    >
+                      <Pencil className="h-4 w-4" />
+                      <span className="sr-only">{t.organization.rename}</span>
+                    </Button>
+                  ) : null}
+                </>
+              )}
+              <Badge variant="secondary">{roleLabels[overview.membership.role]}</Badge>
+            </CardTitle>
+            <CardDescription>{t.organization.roleHint}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {overview.membership.role !== 'viewer' ? (
+              <div className="grid grid-cols-2 gap-3 text-sm md:max-w-md">
+                <div className="rounded-md border p-3">
+                  <div className="text-xs text-muted-foreground">{t.organization.spendingLimit}</div>
+                  <div className="font-medium">
+                    {formatLimit(overview.membership.spending_limit_minor)}
+                  </div>
+                </div>
+                <div className="rounded-md border p-3">
+                  <div className="text-xs text-muted-foreground">
+                    {t.organization.spentThisMonth}
+                  </div>
+                  <div className="font-medium">
+                    {formatCurrency(overview.membership.monthly_spent_minor, currency)}
+                  </div>
+                </div>
+              </div>
+            ) : null}
+            <Button
+              variant="outline"
+              onClick={() =>
+                setPendingConfirm({
+                  title: t.organization.leave,
+                  description:
+                    isOwner && overview.members.length === 1
+                      ? t.organization.leaveOwnerConfirm
+                      : t.organization.leaveConfirm,
+                  onConfirm: () => leaveMutation.mutate(),
+                })
+              }
+              disabled={leaveMutation.isPending || (isOwner && overview.members.length > 1)}
+            >
+              {t.organization.leave}
+            </Button>
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {overview ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.organization.members}</CardTitle>
+          </CardHeader>
+          <CardContent className="p-0">
+            <div className="divide-y">
+              {overview.members.map((member) => {
+                const isSelf = member.user_id === overview.membership.user_id
+                return (
+                  <div
+                    key={member.id}
+                    className="flex flex-wrap items-center justify-between gap-3 p-4 text-sm"
+                  >
+                    <div>
+                      <div className="flex items-center gap-2 font-medium">
+                        {member.name || member.email}
+                        {isSelf ? <Badge variant="outline">{t.organization.you}</Badge> : null}
+                      </div>
+                      <div className="text-xs text-muted-foreground">{member.email}</div>
+                    </div>
+                    <div className="flex items-center gap-3">
+                      <div className="text-right">
+                        <Badge variant="secondary">{roleLabels[member.role]}</Badge>
+                        {member.role !== 'viewer' ? (
+                          <div className="mt-1 text-xs text-muted-foreground">
+                            {formatCurrency(member.monthly_spent_minor, currency)} /{' '}
+                            {formatLimit(member.spending_limit_minor)}
+                          </div>
+                        ) : null}
+                      </div>
+                      {isOwner && !isSelf ? (
+                        <>
+                          <Button
+                            size="icon"
+                            variant="ghost"
+                            onClick={() =>
+                              setEditing({
+                                member,
+                                role: member.role,
+                                limit: member.spending_limit_minor
+                                  ? minorToMajor(member.spending_limit_minor).toString()
+                                  : '',
+                              })
+                            }
+                          >
+                            <Pencil className="h-4 w-4" />
+                            <span className="sr-only">{t.organization.editMember}</span>
+                          </Button>
+                          <Button
+                            size="icon"
+                            variant="ghost"
+                            onClick={() =>
+                              setPendingConfirm({
+                                title: t.organization.removeMember,
+                                description: t.organization.removeConfirm.replace(
+                                  '{email}',
+                                  member.email
+                                ),
+                                onConfirm: () => removeMemberMutation.mutate(member.user_id),
+                              })
+                            }
+                          >
+                            <Trash2 className="h-4 w-4" />
+                            <span className="sr-only">{t.organization.removeMember}</span>
+                          </Button>
+                        </>
+                      ) : null}
+                    </div>
+                  </div>
+                )
+              })}
+            </div>
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {isOwner ? (
+        <Card>
+          <CardHeader>
+            <CardTitle>{t.organization.inviteTitle}</CardTitle>
+            <CardDescription>{t.organization.spendingLimitHint}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="grid gap-4 md:grid-cols-3">
+              <div className="space-y-2">
+                <Label>{t.organization.inviteEmail}</Label>
+                <Input
+                  type="email"
+                  value={inviteForm.email}
+                  onChange={(e) => setInviteForm({ ...inviteForm, email: e.target.value })}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label>{t.organization.role}</Label>
+                <Select
+                  value={inviteForm.role}
+                  onValueChange={(value) =>
+                    setInviteForm({ ...inviteForm, role: value as OrganizationRole })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="purchaser">{t.organization.rolePurchaser}</SelectItem>
+                    <SelectItem value="viewer">{t.organization.roleViewer}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>
+                  {t.organization.spendingLimit} ({currency})
+                </Label>
+                <Input
+                  type="number"
+                  min="0"
+                  step="0.01"
+                  value={inviteForm.limit}
+                  disabled={inviteForm.role === 'viewer'}
+                  onChange={(e) => setInviteForm({ ...inviteForm, limit: e.target.value })}
+                />
+              </div>
+            </div>
+            <Button
+              onClick={() => inviteMutation.mutate()}
+              disabled={inviteMutation.isPending || !inviteForm.email.trim()}
+            >
+              {t.organization.invite}
+            </Button>
+
+            <div className="space-y-2 border-t pt-4">
+              <div className="text-sm font-medium">{t.organization.invitations}</div>
+              {invitations.length === 0 ? (
+                <p className="text-sm text-muted-foreground">{t.organization.noInvitations}</p>
+              ) : (
+                invitations.map((invitation) => (
+                  <div
+                    key={invitation.id}
+                    className="flex items-center justify-between gap-3 rounded-md border p-3 text-sm"
+                  >
+                    <div>
+                      <div>{invitation.email}</div>
+                      <div className="text-xs text-muted-foreground">
+                        {roleLabels[invitation.role]} ·{' '}
+                        {t.organization.expiresAt.replace('{date}', formatDate(invitation.expires_at))}
+                      </div>
+                    </div>
+                    <Button
+                      size="sm"
+                      variant="outline"
+                      onClick={() => revokeMutation.mutate(invitation.id)}
+                      disabled={revokeMutation.isPending}
+                    >
+                      {t.organization.revoke}
+                    </Button>
+                  </div>
+                ))
+              )}
+            </div>
+          </CardContent>
+        </Card>
+      ) : null}
+
+      {overview ? (
+        <Card>
+          <CardHeader className="flex flex-row items-center justify-between gap-4 space-y-0">
+            <CardTitle>{t.organization.orders}</CardTitle>
+            <Select value={memberFilter} onValueChange={setMemberFilter}>
+              <SelectTrigger className="w-[180px]">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">{t.organization.allMembers}</SelectItem>
+                {overview.members.map((member) => (
+                  <SelectItem key={member.user_id} value={String(member.user_id)}>
+                    {member.name || member.email}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </CardHeader>
+          <CardContent className="p-0">
+            {orders.length === 0 ? (
+              <p className="p-4 text-sm text-muted-foreground">{t.organization.noOrders}</p>
+            ) : (
+              <div className="divide-y">
+                {orders.map((order) => (
+                  <div
+                    key={order.id}
+                    className="flex items-center justify-between gap-4 p-4 text-sm"
+                  >
+                    <div>
+                      <Link href={`/orders/${order.order_no}`} className="font-mono hover:underline">
+                        {order.order_no}
+                      </Link>
+                      <div className="text-xs text-muted-foreground">
+                        {t.organization.orderPlacedBy}: {memberName(order.user_id || 0)} ·{' '}
+                        {formatDate(order.created_at)}
+                      </div>
+                    </div>
+                    <div className="flex items-center gap-3">
+                      <span className="font-medium">
+                        {formatCurrency(order.total_amount_minor, order.currency || currency)}
+                      </span>
+                      <OrderStatusBadge status={order.status} />
+                    </div>
+                  </div>
+                ))}
+              </div>
+            )}
+          </CardContent>
+        </Card>
+      ) : null}
+
+      <Dialog open={Boolean(editing)} onOpenChange={(open) => (!open ? setEditing(null) : null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {t.organization.editMember}
+              {editing ? ` - ${editing.member.email}` : ''}
+            </DialogTitle>
+          </DialogHeader>
+          {editing ? (
+            <div className="space-y-4">
+              <div className="space-y-2">
+                <Label>{t.organization.role}</Label>
+                <Select
+                  value={editing.role}
+                  onValueChange={(value) =>
+                    setEditing({ ...editing, role: value as OrganizationRole })
+                  }
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="purchaser">{t.organization.rolePurchaser}</SelectItem>
+                    <SelectItem value="viewer">{t.organization.roleViewer}</SelectItem>
+                    <SelectItem value="owner">{t.organization.transferOwnership}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>
+                  {t.organization.spendingLimit} ({currency})
+                </Label>
+                <Input
+                  type="number"
+                  min="0"
+                  step="0.01"
+                  value={editing.limit}
+                  disabled={editing.role === 'viewer'}
+                  onChange={(e) => setEditing({ ...editing, limit: e.target.value })}
+                />
+                <p className="text-xs text-muted-foreground">{t.organization.spendingLimitHint}</p>
+              </div>
+            </div>
+          ) : null}
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setEditing(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={submitMemberEdit} disabled={updateMemberMutation.isPending}>
+              {t.common.save}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <AlertDialog
+        open={Boolean(pendingConfirm)}
+        onOpenChange={(open) => (!open ? setPendingConfirm(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{pendingConfirm?.title}</AlertDialogTitle>
+            <AlertDialogDescription>{pendingConfirm?.description}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                pendingConfirm?.onConfirm()
+                setPendingConfirm(null)
+              }}
+            >
+              {t.common.confirm}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
+
+// useSearchParams 需要 Suspense 边界
+export default function OrganizationPage() {
+  return (
+    <Suspense fallback={null}>
+      <OrganizationPageContent />
+    </Suspense>
+  )
+}
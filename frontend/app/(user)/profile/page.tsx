@@ -31,6 +31,14 @@ import {
   BookOpen,
   Megaphone,
   Bell,
+  AlertTriangle,
+  Building2,
+  Users,
+  KeyRound,
+  FileText,
+  Wallet,
+  ScrollText,
+  Smartphone,
   type LucideIcon,
 } from 'lucide-react'
 import Link from 'next/link'
@@ -80,6 +88,9 @@ function ProfilePageContent() {
     queryParams,
   })
   const ticketEnabled = publicConfigData?.data?.ticket?.enabled ?? true
+  const netTermsEnabled = Boolean(publicConfigData?.data?.net_terms_enabled)
+  const quoteEnabled = Boolean(publicConfigData?.data?.quote_enabled)
+  const spendingControlsEnabled = Boolean(publicConfigData?.data?.spending_controls_enabled)
   const hasAdminAccess = user?.role === 'admin' || user?.role === 'super_admin'
   const pluginQuickActions = useMemo<ProfilePluginQuickAction[]>(
     () =>
@@ -219,6 +230,20 @@ function ProfilePageContent() {
           context={{ ...userProfilePluginContext, section: 'header' }}
         />
 
+        {user?.email_verification_pending && user?.email ? (
+          <div className="flex flex-col gap-3 rounded-lg border border-amber-300 bg-amber-50 p-4 text-sm text-amber-900 dark:border-amber-800 dark:bg-amber-950/40 dark:text-amber-200 sm:flex-row sm:items-center sm:justify-between">
+            <div className="flex items-start gap-2">
+              <AlertTriangle className="mt-0.5 h-4 w-4 shrink-0" />
+              <span>{t.profile.emailVerificationPending}</span>
+            </div>
+            <Button asChild size="sm" variant="outline">
+              <Link href={`/verify-email?email=${encodeURIComponent(user.email)}&pending=true`}>
+                {t.profile.verifyEmailNow}
+              </Link>
+            </Button>
+          </div>
+        ) : null}
+
         <Card className="overflow-hidden">
           <CardContent className="p-0">
             <div className="border-b bg-gradient-to-r from-muted/60 via-background to-background p-6">
@@ -355,6 +380,89 @@ function ProfilePageContent() {
                 <ChevronRight className="h-5 w-5 text-muted-foreground" />
               </Link>
 
+              <Link
+                href="/profile/organization"
+                className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+              >
+                <div className="flex items-center gap-3">
+                  <Users className="h-5 w-5 text-muted-foreground" />
+                  <span>{t.organization.title}</span>
+                </div>
+                <ChevronRight className="h-5 w-5 text-muted-foreground" />
+              </Link>
+
+              <Link
+                href="/profile/api-tokens"
+                className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+              >
+                <div className="flex items-center gap-3">
+                  <KeyRound className="h-5 w-5 text-muted-foreground" />
+                  <span>{t.apiTokens.title}</span>
+                </div>
+                <ChevronRight className="h-5 w-5 text-muted-foreground" />
+              </Link>
+
+              <Link
+                href="/profile/security"
+                className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+              >
+                <div className="flex items-center gap-3">
+                  <Smartphone className="h-5 w-5 text-muted-foreground" />
+                  <span>{t.twoFactor.title}</span>
+                </div>
+                <ChevronRight className="h-5 w-5 text-muted-foreground" />
+              </Link>
+
+              {netTermsEnabled && (
+                <Link
+                  href="/profile/business-account"
+                  className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+                >
+                  <div className="flex items-center gap-3">
+                    <Building2 className="h-5 w-5 text-muted-foreground" />
+                    <span>{t.businessAccount.title}</span>
+                  </div>
+                  <ChevronRight className="h-5 w-5 text-muted-foreground" />
+                </Link>
+              )}
+
+              {quoteEnabled && (
+                <Link
+                  href="/profile/quotes"
+                  className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+                >
+                  <div className="flex items-center gap-3">
+                    <FileText className="h-5 w-5 text-muted-foreground" />
+                    <span>{t.quote.myQuotes}</span>
+                  </div>
+                  <ChevronRight className="h-5 w-5 text-muted-foreground" />
+                </Link>
+              )}
+
+              {spendingControlsEnabled && (
+                <Link
+                  href="/profile/spending-controls"
+                  className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+                >
+                  <div className="flex items-center gap-3">
+                    <Wallet className="h-5 w-5 text-muted-foreground" />
+                    <span>{t.spendingControl.title}</span>
+                  </div>
+                  <ChevronRight className="h-5 w-5 text-muted-foreground" />
+                </Link>
+              )}
+
+              <Link
+                href="/profile/consents"
+                className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
+              >
+                <div className="flex items-center gap-3">
+                  <ScrollText className="h-5 w-5 text-muted-foreground" />
+                  <span>{t.consent.title}</span>
+                </div>
+                <ChevronRight className="h-5 w-5 text-muted-foreground" />
+              </Link>
+
               <Link
                 href="/serial-verify"
                 className="flex items-center justify-between p-4 transition-colors hover:bg-accent"
@@ -0,0 +1,320 @@
+'use client'
+
+import { Suspense, useState } from 'react'
+import Link from 'next/link'
+import { useRouter, useSearchParams } from 'next/navigation'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Download, FileText, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  acceptQuote,
+  declineQuote,
+  getMyQuotes,
+  getPublicConfig,
+  openQuoteDocument,
+  type Quote,
+  type QuoteStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { cn, formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+
+export default function QuotesPage() {
+  return (
+    <Suspense
+      fallback={
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin" />
+        </div>
+      }
+    >
+      <QuotesContent />
+    </Suspense>
+  )
+}
+
+function QuotesContent() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.quotes)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const router = useRouter()
+  const queryClient = useQueryClient()
+  // 邮件中的链接带 ?quote=，用于高亮对应报价单
+  const highlighted = useSearchParams().get('quote')
+
+  const [acceptTarget, setAcceptTarget] = useState<Quote | null>(null)
+  const [remark, setRemark] = useState('')
+  const [declineTarget, setDeclineTarget] = useState<Quote | null>(null)
+  const [reason, setReason] = useState('')
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['myQuotes'],
+    queryFn: () => getMyQuotes({ page: 1, limit: 50 }),
+  })
+  const quotes: Quote[] = data?.data?.items || []
+
+  const { data: publicConfig } = useQuery({
+    queryKey: ['publicConfig'],
+    queryFn: getPublicConfig,
+    staleTime: 1000 * 60 * 5,
+  })
+  const pdfEnabled = !!publicConfig?.data?.invoice_pdf_enabled
+
+  const acceptMutation = useMutation({
+    mutationFn: () => acceptQuote(acceptTarget!.quote_no, remark.trim() || undefined),
+    onSuccess: (response: any) => {
+      toast.success(t.quote.accepted)
+      setAcceptTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['myQuotes'] })
+      const orderNo = response?.data?.order?.order_no
+      if (orderNo) {
+        router.push(`/orders/${orderNo}`)
+      }
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.acceptFailed))
+    },
+  })
+
+  const declineMutation = useMutation({
+    mutationFn: () => declineQuote(declineTarget!.quote_no, reason.trim() || undefined),
+    onSuccess: () => {
+      toast.success(t.quote.declined)
+      setDeclineTarget(null)
+      queryClient.invalidateQueries({ queryKey: ['myQuotes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.declineFailed))
+    },
+  })
+
+  const openDocument = async (quote: Quote, pdf: boolean) => {
+    try {
+      if (pdf) {
+        await openQuoteDocument(
+          `/api/user/quotes/${quote.quote_no}/document.pdf`,
+          `${quote.quote_no}.pdf`
+        )
+      } else {
+        await openQuoteDocument(`/api/user/quotes/${quote.quote_no}/document`)
+      }
+    } catch (error: unknown) {
+      toast.error(resolveApiErrorMessage(error, t, t.quote.documentFailed))
+    }
+  }
+
+  const statusLabels: Record<QuoteStatus, string> = {
+    draft: t.quote.statusDraft,
+    sent: t.quote.statusSent,
+    accepted: t.quote.statusAccepted,
+    declined: t.quote.statusDeclined,
+    expired: t.quote.statusExpired,
+    cancelled: t.quote.statusCancelled,
+  }
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <div>
+          <h1
+            className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}
+          >
+            {t.quote.myQuotes}
+          </h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.quote.myQuotesDesc}</p>
+        </div>
+      </div>
+
+      {isLoading ? (
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin" />
+        </div>
+      ) : quotes.length === 0 ? (
+        <Card>
+          <CardContent className="py-12 text-center text-muted-foreground">
+            {t.quote.noQuotes}
+          </CardContent>
+        </Card>
+      ) : (
+        quotes.map((quote) => (
+          <Card
+            key={quote.id}
+            className={cn(highlighted === quote.quote_no && 'ring-2 ring-primary')}
+          >
+            <CardHeader>
+              <div className="flex flex-wrap items-start justify-between gap-2">
+                <div>
+                  <CardTitle className="text-base">{quote.title || quote.quote_no}</CardTitle>
+                  <CardDescription className="font-mono">{quote.quote_no}</CardDescription>
+                </div>
+                <Badge variant={quote.status === 'sent' ? 'default' : 'secondary'}>
+                  {statusLabels[quote.status]}
+                </Badge>
+              </div>
+            </CardHeader>
+            <CardContent className="space-y-4 text-sm">
+              <div className="space-y-1">
+                {quote.items.map((item, index) => (
+                  <div key={index} className="flex justify-between gap-4">
+                    <span>
+                      {item.name} × {item.quantity}
+                    </span>
+                    <span>
+                      {formatCurrency(item.unit_price_minor * item.quantity, quote.currency)}
+                    </span>
+                  </div>
+                ))}
+                {quote.discount_amount_minor > 0 ? (
+                  <div className="flex justify-between gap-4 text-muted-foreground">
+                    <span>{t.quote.discount}</span>
+                    <span>-{formatCurrency(quote.discount_amount_minor, quote.currency)}</span>
+                  </div>
+                ) : null}
+                <div className="flex justify-between gap-4 border-t pt-2 font-semibold">
+                  <span>{t.quote.total}</span>
+                  <span>{formatCurrency(quote.total_amount_minor, quote.currency)}</span>
+                </div>
+              </div>
+              <div className="text-muted-foreground">
+                {t.quote.validUntil}: {new Date(quote.valid_until).toLocaleString()}
+              </div>
+              {quote.note ? (
+                <p className="whitespace-pre-wrap rounded-md bg-muted p-3">{quote.note}</p>
+              ) : null}
+              <div className="flex flex-wrap gap-2">
+                <Button variant="outline" size="sm" onClick={() => openDocument(quote, false)}>
+                  <FileText className="mr-2 h-4 w-4" />
+                  {t.quote.viewDocument}
+                </Button>
+                {pdfEnabled ? (
+                  <Button variant="outline" size="sm" onClick={() => openDocument(quote, true)}>
+                    <Download className="mr-2 h-4 w-4" />
+                    {t.quote.downloadPdf}
+                  </Button>
+                ) : null}
+                {quote.status === 'sent' ? (
+                  <>
+                    <Button
+                      size="sm"
+                      onClick={() => {
+                        setRemark('')
+                        setAcceptTarget(quote)
+                      }}
+                    >
+                      {t.quote.accept}
+                    </Button>
+                    <Button
+                      variant="outline"
+                      size="sm"
+                      onClick={() => {
+                        setReason('')
+                        setDeclineTarget(quote)
+                      }}
+                    >
+                      {t.quote.decline}
+                    </Button>
+                  </>
+                ) : null}
+                {quote.order_no ? (
+                  <Button asChild size="sm">
+                    <Link href={`/orders/${quote.order_no}`}>{t.quote.viewOrder}</Link>
+                  </Button>
+                ) : null}
+              </div>
+            </CardContent>
+          </Card>
+        ))
+      )}
+
+      {/* 接受报价单 */}
+      <Dialog open={acceptTarget !== null} onOpenChange={(open) => !open && setAcceptTarget(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.quote.accept}</DialogTitle>
+            <DialogDescription>{t.quote.acceptHint}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-2">
+            <Label htmlFor="quote_accept_remark">{t.quote.acceptRemark}</Label>
+            <Textarea
+              id="quote_accept_remark"
+              rows={3}
+              maxLength={500}
+              value={remark}
+              onChange={(e) => setRemark(e.target.value)}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setAcceptTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button onClick={() => acceptMutation.mutate()} disabled={acceptMutation.isPending}>
+              {acceptMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.quote.accept}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {/* 拒绝报价单 */}
+      <Dialog
+        open={declineTarget !== null}
+        onOpenChange={(open) => !open && setDeclineTarget(null)}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{t.quote.decline}</DialogTitle>
+            <DialogDescription className="font-mono">{declineTarget?.quote_no}</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-2">
+            <Label htmlFor="quote_decline_reason">{t.quote.declineReason}</Label>
+            <Textarea
+              id="quote_decline_reason"
+              rows={3}
+              maxLength={500}
+              value={reason}
+              onChange={(e) => setReason(e.target.value)}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setDeclineTarget(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant="destructive"
+              onClick={() => declineMutation.mutate()}
+              disabled={declineMutation.isPending}
+            >
+              {t.quote.decline}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
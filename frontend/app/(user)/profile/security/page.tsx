@@ -0,0 +1,219 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  disableTwoFactor,
+  getTwoFactorStatus,
+  regenerateTwoFactorBackupCodes,
+  type TwoFactorStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { TwoFactorBackupCodes } from '@/components/two-factor-backup-codes'
+import { TwoFactorSetupPanel } from '@/components/two-factor-setup-panel'
+import { PasskeyManager } from '@/components/passkey-manager'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+type CodeAction = 'disable' | 'regenerate'
+
+export default function SecurityPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.security)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+
+  const [settingUp, setSettingUp] = useState(false)
+  const [backupCodes, setBackupCodes] = useState<string[] | null>(null)
+  const [codeAction, setCodeAction] = useState<CodeAction | null>(null)
+  const [code, setCode] = useState('')
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['twoFactorStatus'],
+    queryFn: getTwoFactorStatus,
+  })
+  const status: TwoFactorStatus | undefined = data?.data
+
+  const codeMutation = useMutation({
+    mutationFn: () =>
+      codeAction === 'disable'
+        ? disableTwoFactor(code.trim())
+        : regenerateTwoFactorBackupCodes(code.trim()),
+    onSuccess: (res: any) => {
+      if (codeAction === 'disable') {
+        toast.success(t.twoFactor.disabledSuccess)
+      } else {
+        setBackupCodes(res.data?.backup_codes || [])
+      }
+      setCodeAction(null)
+      setCode('')
+      queryClient.invalidateQueries({ queryKey: ['twoFactorStatus'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.twoFactor.actionFailed))
+    },
+  })
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <div>
+          <h1
+            className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}
+          >
+            {t.twoFactor.title}
+          </h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.twoFactor.desc}</p>
+        </div>
+      </div>
+
+      {isLoading || !status ? (
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin" />
+        </div>
+      ) : (
+        <Card>
+          <CardHeader>
+            <CardTitle className="flex items-center gap-2 text-base">
+              {t.twoFactor.title}
+              <Badge variant={status.enabled ? 'default' : 'secondary'}>
+                {status.enabled ? t.twoFactor.enabled : t.twoFactor.disabled}
+              </Badge>
+            </CardTitle>
+            <CardDescription>
+              {status.enabled && status.enabled_at
+                ? t.twoFactor.enabledSince.replace(
+                    '{date}',
+                    new Date(status.enabled_at).toLocaleString()
+                  )
+                : t.twoFactor.desc}
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {status.required && (
+              <p className="text-sm text-amber-600 dark:text-amber-400">
+                {t.twoFactor.requiredByAdmin}
+              </p>
+            )}
+
+            {backupCodes ? (
+              <div className="space-y-3">
+                <h3 className="text-sm font-medium">{t.twoFactor.backupCodesTitle}</h3>
+                <TwoFactorBackupCodes codes={backupCodes} />
+                <Button variant="outline" onClick={() => setBackupCodes(null)}>
+                  {t.twoFactor.continue}
+                </Button>
+              </div>
+            ) : status.enabled ? (
+              <>
+                <p className="text-sm text-muted-foreground">
+                  {t.twoFactor.backupCodesRemaining.replace(
+                    '{count}',
+                    String(status.backup_codes_remaining)
+                  )}
+                </p>
+                <div className="flex flex-wrap gap-2">
+                  <Button variant="outline" onClick={() => setCodeAction('regenerate')}>
+                    {t.twoFactor.regenerateBackupCodes}
+                  </Button>
+                  {!status.required && (
+                    <Button variant="destructive" onClick={() => setCodeAction('disable')}>
+                      {t.twoFactor.disable}
+                    </Button>
+                  )}
+                </div>
+              </>
+            ) : settingUp ? (
+              <div className="max-w-sm">
+                <TwoFactorSetupPanel
+                  onEnabled={(res: any) => {
+                    setSettingUp(false)
+                    setBackupCodes(res.data?.backup_codes || [])
+                    queryClient.invalidateQueries({ queryKey: ['twoFactorStatus'] })
+                  }}
+                />
+              </div>
+            ) : (
+              <Button onClick={() => setSettingUp(true)}>{t.twoFactor.setupStart}</Button>
+            )}
+          </CardContent>
+        </Card>
+      )}
+
+      <PasskeyManager />
+
+      <Dialog
+        open={codeAction !== null}
+        onOpenChange={(open) => {
+          if (!open) {
+            setCodeAction(null)
+            setCode('')
+          }
+        }}
+      >
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {codeAction === 'disable' ? t.twoFactor.disable : t.twoFactor.regenerateBackupCodes}
+            </DialogTitle>
+            <DialogDescription>
+              {codeAction === 'disable' ? t.twoFactor.disableDesc : t.twoFactor.regenerateDesc}
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-1.5">
+            <Label htmlFor="two_factor_action_code">{t.twoFactor.code}</Label>
+            <Input
+              id="two_factor_action_code"
+              autoComplete="one-time-code"
+              maxLength={32}
+              placeholder={t.twoFactor.codePlaceholder}
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setCodeAction(null)}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={codeAction === 'disable' ? 'destructive' : 'default'}
+              disabled={!code.trim() || codeMutation.isPending}
+              onClick={() => codeMutation.mutate()}
+            >
+              {codeMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {codeAction === 'disable' ? t.twoFactor.disable : t.twoFactor.regenerateBackupCodes}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </div>
+  )
+}
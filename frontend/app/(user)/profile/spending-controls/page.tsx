@@ -0,0 +1,199 @@
+'use client'
+
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ArrowLeft, Clock, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+import {
+  cancelPendingSpendingControl,
+  getCategories,
+  getSpendingControl,
+  updateSpendingControl,
+  type SpendingControlOverview,
+  type SpendingControlTerms,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { useIsMobile } from '@/hooks/use-mobile'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { SpendingControlForm } from '@/components/forms/spending-control-form'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+export default function SpendingControlsPage() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.spendingControls)
+  const { isMobile, mounted } = useIsMobile()
+  const isCompactLayout = mounted ? isMobile : false
+  const queryClient = useQueryClient()
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['spendingControl'],
+    queryFn: getSpendingControl,
+  })
+  const overview: SpendingControlOverview | undefined = data?.data
+
+  const { data: categoriesData } = useQuery({
+    queryKey: ['productCategories'],
+    queryFn: getCategories,
+    staleTime: 1000 * 60 * 5,
+  })
+  const categories: string[] = categoriesData?.data?.categories || []
+
+  const updateMutation = useMutation({
+    mutationFn: (terms: SpendingControlTerms) => updateSpendingControl(terms),
+    onSuccess: (response: any) => {
+      toast.success(response?.data?.applied ? t.spendingControl.saved : t.spendingControl.scheduled)
+      queryClient.invalidateQueries({ queryKey: ['spendingControl'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.spendingControl.saveFailed))
+    },
+  })
+
+  const cancelMutation = useMutation({
+    mutationFn: cancelPendingSpendingControl,
+    onSuccess: () => {
+      toast.success(t.spendingControl.pendingCancelled)
+      queryClient.invalidateQueries({ queryKey: ['spendingControl'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.spendingControl.saveFailed))
+    },
+  })
+
+  const control = overview?.control
+  const currency = overview?.currency || 'CNY'
+  const formatLimit = (minor: number) =>
+    minor > 0 ? formatCurrency(minor, currency) : t.spendingControl.noLimit
+  const formatCategories = (items?: string[] | null) =>
+    items && items.length > 0 ? items.join(', ') : t.spendingControl.none
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center gap-4">
+        {isCompactLayout ? (
+          <Button asChild variant="outline" size="icon">
+            <Link href="/profile">
+              <ArrowLeft className="h-5 w-5" />
+              <span className="sr-only">{t.profile.profileCenter}</span>
+            </Link>
+          </Button>
+        ) : null}
+        <div>
+          <h1
+            className={isCompactLayout ? 'text-2xl font-bold' : 'text-2xl font-bold md:text-3xl'}
+          >
+            {t.spendingControl.title}
+          </h1>
+          <p className="mt-1 text-sm text-muted-foreground">{t.spendingControl.desc}</p>
+        </div>
+      </div>
+
+      {isLoading || !overview || !control ? (
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin" />
+        </div>
+      ) : (
+        <>
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-base">{t.spendingControl.thisMonth}</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-2 text-sm">
+              <div className="flex justify-between gap-4">
+                <span className="text-muted-foreground">{t.spendingControl.spent}</span>
+                <span className="font-medium">
+                  {formatCurrency(overview.monthly_spent_minor, currency)}
+                </span>
+              </div>
+              <div className="flex justify-between gap-4">
+                <span className="text-muted-foreground">{t.spendingControl.monthlyLimit}</span>
+                <span className="font-medium">{formatLimit(control.monthly_limit_minor)}</span>
+              </div>
+              <div className="flex justify-between gap-4">
+                <span className="text-muted-foreground">
+                  {t.spendingControl.blockedCategories}
+                </span>
+                <span className="text-right font-medium">
+                  {formatCategories(control.blocked_categories)}
+                </span>
+              </div>
+            </CardContent>
+          </Card>
+
+          {control.pending && control.pending_effective_at ? (
+            <Card className="border-amber-300">
+              <CardHeader>
+                <CardTitle className="flex items-center gap-2 text-base">
+                  <Clock className="h-4 w-4" />
+                  {t.spendingControl.pendingTitle}
+                </CardTitle>
+                <CardDescription>
+                  {t.spendingControl.pendingDesc.replace(
+                    '{time}',
+                    new Date(control.pending_effective_at).toLocaleString()
+                  )}
+                </CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-2 text-sm">
+                <div className="flex justify-between gap-4">
+                  <span className="text-muted-foreground">{t.spendingControl.monthlyLimit}</span>
+                  <span>{formatLimit(control.pending.monthly_limit_minor)}</span>
+                </div>
+                <div className="flex justify-between gap-4">
+                  <span className="text-muted-foreground">
+                    {t.spendingControl.blockedCategories}
+                  </span>
+                  <span className="text-right">
+                    {formatCategories(control.pending.blocked_categories)}
+                  </span>
+                </div>
+                <div className="flex justify-between gap-4">
+                  <span className="text-muted-foreground">{t.spendingControl.ownerEmail}</span>
+                  <span className="break-all text-right">
+                    {control.pending.owner_email || t.spendingControl.none}
+                  </span>
+                </div>
+                <Button
+                  variant="outline"
+                  size="sm"
+                  disabled={cancelMutation.isPending}
+                  onClick={() => cancelMutation.mutate()}
+                >
+                  {t.spendingControl.cancelPending}
+                </Button>
+              </CardContent>
+            </Card>
+          ) : null}
+
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-base">{t.spendingControl.settings}</CardTitle>
+              <CardDescription>
+                {t.spendingControl.cooldownHint.replace('{hours}', String(overview.cooldown_hours))}
+              </CardDescription>
+            </CardHeader>
+            <CardContent>
+              <SpendingControlForm
+                key={control.updated_at || 'new'}
+                initial={{
+                  monthly_limit_minor: control.monthly_limit_minor,
+                  blocked_categories: control.blocked_categories || [],
+                  owner_email: control.owner_email || '',
+                }}
+                currency={currency}
+                categories={categories}
+                submitting={updateMutation.isPending}
+                onSubmit={(terms) => updateMutation.mutate(terms)}
+              />
+            </CardContent>
+          </Card>
+        </>
+      )}
+    </div>
+  )
+}
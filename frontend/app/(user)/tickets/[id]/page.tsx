@@ -12,8 +12,10 @@ import {
   getTicketSharedOrders,
   getOrders,
   uploadTicketFile,
+  uploadTicketAttachment,
   getPublicConfig,
   TicketMessage,
+  TicketAttachment,
 } from '@/lib/api'
 import { Button } from '@/components/ui/button'
 import { Badge } from '@/components/ui/badge'
@@ -21,6 +23,14 @@ import { Card, CardContent } from '@/components/ui/card'
 import { MarkdownMessage } from '@/components/ui/markdown-message'
 import { Skeleton } from '@/components/ui/page-loading'
 import { MessageToolbar } from '@/components/ticket/message-toolbar'
+import { TicketRatingCard } from '@/components/ticket/ticket-rating-card'
+import { TicketDisputeCard } from '@/components/ticket/ticket-dispute-card'
+import { TicketAttachmentList } from '@/components/ticket/ticket-attachment-list'
+import {
+  ORDER_SHARE_DURATIONS,
+  useOrderShareDurationLabels,
+} from '@/components/orders/order-shares-card'
+import { Switch } from '@/components/ui/switch'
 import {
   Dialog,
   DialogContent,
@@ -99,8 +109,12 @@ export default function TicketDetailPage() {
   const params = useParams()
   const ticketId = Number(params.id)
   const [message, setMessage] = useState('')
+  const [pendingAttachments, setPendingAttachments] = useState<TicketAttachment[]>([])
   const [openShare, setOpenShare] = useState(false)
   const [selectedOrder, setSelectedOrder] = useState<number | null>(null)
+  const [shareDuration, setShareDuration] = useState(0)
+  const [shareReceiverInfo, setShareReceiverInfo] = useState(true)
+  const shareDurationLabels = useOrderShareDurationLabels()
   const [ticketListBackHref, setTicketListBackHref] = useState('/tickets')
   const messagesEndRef = useRef<HTMLDivElement>(null)
   const queryClient = useQueryClient()
@@ -152,9 +166,14 @@ export default function TicketDetailPage() {
   })
 
   const sendMessageMutation = useMutation({
-    mutationFn: (content: string) => sendTicketMessage(ticketId, { content }),
+    mutationFn: (content: string) =>
+      sendTicketMessage(ticketId, {
+        content,
+        attachment_ids: pendingAttachments.map((attachment) => attachment.id),
+      }),
     onSuccess: () => {
       setMessage('')
+      setPendingAttachments([])
       queryClient.invalidateQueries({ queryKey: ['ticketMessages', ticketId] })
       queryClient.invalidateQueries({ queryKey: ['ticket', ticketId] })
     },
@@ -179,6 +198,8 @@ export default function TicketDetailPage() {
     mutationFn: () =>
       shareOrderToTicket(ticketId, {
         order_id: selectedOrder!,
+        expires_in_hours: shareDuration,
+        include_receiver_info: shareReceiverInfo,
       }),
     onSuccess: () => {
       toast.success(t.ticket.shareSuccess)
@@ -284,7 +305,7 @@ export default function TicketDetailPage() {
   }, [messages.length])
 
   const handleSend = () => {
-    if (!message.trim()) return
+    if (!message.trim() && pendingAttachments.length === 0) return
     if (maxContentLength > 0 && message.trim().length > maxContentLength) {
       toast.error(t.ticket.contentTooLong.replace('{max}', String(maxContentLength)))
       return
@@ -302,6 +323,18 @@ export default function TicketDetailPage() {
     }
   }
 
+  const handleAttachFile = async (file: File) => {
+    try {
+      const res = await uploadTicketAttachment(ticketId, file)
+      if (res.data) {
+        setPendingAttachments((prev) => [...prev, res.data as TicketAttachment])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+      throw error
+    }
+  }
+
   const getStatusBadge = (status: string) => {
     const config = TICKET_STATUS_CONFIG[status as keyof typeof TICKET_STATUS_CONFIG]
     if (!config) return <Badge variant="secondary">{status}</Badge>
@@ -523,6 +556,35 @@ export default function TicketDetailPage() {
                 </Select>
               </div>
 
+              <div>
+                <label className="text-sm font-medium">{t.ticket.shareDuration}</label>
+                <Select
+                  value={shareDuration.toString()}
+                  onValueChange={(v) => setShareDuration(Number(v))}
+                >
+                  <SelectTrigger className="mt-1.5">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {ORDER_SHARE_DURATIONS.map((hours) => (
+                      <SelectItem key={hours} value={hours.toString()}>
+                        {shareDurationLabels[hours]}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+
+              <div className="flex items-start justify-between gap-4">
+                <div>
+                  <p className="text-sm font-medium">{t.ticket.shareIncludeReceiverInfo}</p>
+                  <p className="text-xs text-muted-foreground">
+                    {t.ticket.shareIncludeReceiverInfoDesc}
+                  </p>
+                </div>
+                <Switch checked={shareReceiverInfo} onCheckedChange={setShareReceiverInfo} />
+              </div>
+
               <p className="text-sm text-muted-foreground">{t.ticket.shareOrderTip}</p>
 
               <div className="flex gap-2">
@@ -658,10 +720,18 @@ export default function TicketDetailPage() {
                         <span>{msg.content}</span>
                       </div>
                     ) : (
-                      <MarkdownMessage
-                        content={msg.content}
-                        isOwnMessage={msg.sender_type === 'user'}
-                      />
+                      <>
+                        {msg.content ? (
+                          <MarkdownMessage
+                            content={msg.content}
+                            isOwnMessage={msg.sender_type === 'user'}
+                          />
+                        ) : null}
+                        <TicketAttachmentList
+                          attachments={msg.attachments}
+                          isOwnMessage={msg.sender_type === 'user'}
+                        />
+                      </>
                     )}
                   </div>
                   {rowExtensions.length > 0 ? (
@@ -674,9 +744,21 @@ export default function TicketDetailPage() {
             )
           })
         )}
+        {!messagesLoading ? (
+          <TicketDisputeCard
+            ticketId={ticketId}
+            sharedOrderNos={sharedOrders
+              .map((access: any) => access.order?.order_no)
+              .filter(Boolean)}
+          />
+        ) : null}
         <div ref={messagesEndRef} />
       </div>
 
+      {ticket.status === 'resolved' || ticket.status === 'closed' ? (
+        <TicketRatingCard ticket={ticket} compactLayout={isCompactLayout} />
+      ) : null}
+
       {/* 输入框 */}
       {!isClosed ? (
         <div
@@ -699,6 +781,13 @@ export default function TicketDetailPage() {
             enableImage={ticketAttachment?.enable_image ?? true}
             enableVoice={ticketAttachment?.enable_voice ?? true}
             acceptImageTypes={ticketAttachment?.allowed_image_types}
+            enableFile={ticketAttachment?.enable_file ?? false}
+            acceptFileTypes={ticketAttachment?.allowed_file_types}
+            attachments={pendingAttachments}
+            onAttachFile={handleAttachFile}
+            onRemoveAttachment={(id) =>
+              setPendingAttachments((prev) => prev.filter((attachment) => attachment.id !== id))
+            }
             maxLength={maxContentLength > 0 ? maxContentLength : undefined}
             pluginSlotNamespace="user.ticket_detail.composer"
             pluginSlotContext={{ ...userTicketDetailPluginContext, section: 'composer' }}
@@ -3,7 +3,14 @@
 import { usePathname, useRouter, useSearchParams } from 'next/navigation'
 import { Suspense, useState, useEffect, useRef, useCallback, useMemo } from 'react'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
-import { getTickets, createTicket, getOrders, getPublicConfig, Ticket } from '@/lib/api'
+import {
+  getTickets,
+  createTicket,
+  getOrders,
+  getPublicConfig,
+  Ticket,
+  TicketTopic,
+} from '@/lib/api'
 import { Card, CardContent } from '@/components/ui/card'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
@@ -46,6 +53,10 @@ import { useLocale } from '@/hooks/use-locale'
 import { usePageTitle } from '@/hooks/use-page-title'
 import { getTranslations } from '@/lib/i18n'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
+import {
+  TicketIntakeFields,
+  TICKET_INTAKE_FIELD_PREFIX,
+} from '@/components/ticket/ticket-intake-fields'
 import { useDebounce } from '@/hooks/use-debounce'
 import { useIsMobile } from '@/hooks/use-mobile'
 import {
@@ -93,6 +104,8 @@ function TicketsPageContent() {
   const [selectedOrderId, setSelectedOrderId] = useState<number | null>(null)
   const [draftContent, setDraftContent] = useState('')
   const [createFormKey, setCreateFormKey] = useState(0)
+  const [selectedCategory, setSelectedCategory] = useState('general')
+  const [intakeAttachments, setIntakeAttachments] = useState<string[]>([])
   const stateRef = useRef({
     status: initialStatus,
     searchText: initialSearch,
@@ -131,6 +144,10 @@ function TicketsPageContent() {
   })
   const ticketEnabled = publicConfigData?.data?.ticket?.enabled ?? true
   const maxContentLength = publicConfigData?.data?.ticket?.max_content_length || 0
+  const ticketTopics: TicketTopic[] = publicConfigData?.data?.ticket?.topics || []
+  const activeTopic = ticketTopics.find(
+    (topic) => topic.category?.trim().toLowerCase() === selectedCategory.toLowerCase()
+  )
 
   useEffect(() => {
     stateRef.current = {
@@ -206,6 +223,8 @@ function TicketsPageContent() {
       setOpenCreate(false)
       setSelectedOrderId(null)
       setDraftContent('')
+      setSelectedCategory('general')
+      setIntakeAttachments([])
       setCreateFormKey((current) => current + 1)
     },
     onError: (error: any) => {
@@ -357,12 +376,21 @@ function TicketsPageContent() {
       toast.error(t.ticket.contentTooLong.replace('{max}', String(maxContentLength)))
       return
     }
+    const fields: Record<string, string> = {}
+    for (const field of activeTopic?.fields || []) {
+      const value = formData.get(`${TICKET_INTAKE_FIELD_PREFIX}${field.key}`)
+      if (typeof value === 'string' && value.trim()) {
+        fields[field.key] = value.trim()
+      }
+    }
     createMutation.mutate({
       subject: formData.get('subject') as string,
       content,
       category: formData.get('category') as string,
       priority: formData.get('priority') as string,
       order_id: selectedOrderId || undefined,
+      fields: activeTopic ? fields : undefined,
+      attachments: activeTopic && intakeAttachments.length > 0 ? intakeAttachments : undefined,
     })
   }
 
@@ -569,7 +597,20 @@ function TicketsPageContent() {
                 <div className="grid grid-cols-2 gap-4">
                   <div>
                     <label className="text-sm font-medium">{t.ticket.category}</label>
-                    <Select name="category" defaultValue="general">
+                    <Select
+                      name="category"
+                      value={selectedCategory}
+                      onValueChange={(value) => {
+                        setSelectedCategory(value)
+                        setIntakeAttachments([])
+                        const topic = ticketTopics.find(
+                          (item) => item.category?.trim().toLowerCase() === value.toLowerCase()
+                        )
+                        if (topic?.content_template && !draftContent.trim()) {
+                          setDraftContent(topic.content_template)
+                        }
+                      }}
+                    >
                       <SelectTrigger className="mt-1.5">
                         <SelectValue />
                       </SelectTrigger>
@@ -602,6 +643,14 @@ function TicketsPageContent() {
                   </div>
                 </div>
 
+                {activeTopic ? (
+                  <TicketIntakeFields
+                    topic={activeTopic}
+                    attachments={intakeAttachments}
+                    onAttachmentsChange={setIntakeAttachments}
+                  />
+                ) : null}
+
                 <div>
                   <label className="text-sm font-medium">{t.ticket.descriptionRequired}</label>
                   <Textarea
@@ -635,7 +684,9 @@ function TicketsPageContent() {
                 <div>
                   <label className="flex items-center gap-2 text-sm font-medium">
                     <Package className="h-4 w-4" />
-                    {t.ticket.relatedOrder}
+                    {activeTopic?.require_order
+                      ? t.ticket.relatedOrderRequired
+                      : t.ticket.relatedOrder}
                   </label>
                   <Select
                     value={selectedOrderId?.toString() || 'none'}
@@ -694,6 +745,8 @@ function TicketsPageContent() {
                       setOpenCreate(false)
                       setSelectedOrderId(null)
                       setDraftContent('')
+                      setSelectedCategory('general')
+                      setIntakeAttachments([])
                       setCreateFormKey((current) => current + 1)
                     }}
                   >
@@ -156,4 +156,41 @@ describe('backend proxy route', () => {
       data: { id: 9, email: 'legacy@example.com' },
     })
   })
+
+  test('prefers the explicit two-factor challenge token over a stale session cookie', async () => {
+    const challengeToken = createJWT(Math.floor(Date.now() / 1000) + 600)
+    const sessionToken = createJWT(Math.floor(Date.now() / 1000) + 3600)
+    fetchMock.mockResolvedValueOnce(
+      new Response(JSON.stringify({ data: { token: sessionToken, user: { id: 3 } } }), {
+        status: 200,
+        headers: {
+          'content-type': 'application/json',
+        },
+      })
+    )
+
+    const request = new Request(
+      'https://frontend.example.com/api/_backend/api/user/auth/2fa/verify',
+      {
+        method: 'POST',
+        headers: {
+          authorization: `Bearer ${challengeToken}`,
+          cookie: `${AUTH_TOKEN_COOKIE_NAME}=stale-token`,
+          'content-type': 'application/json',
+          'x-forwarded-proto': 'https',
+        },
+        body: JSON.stringify({ code: '123456' }),
+      }
+    )
+
+    const response = await POST(request, {
+      params: Promise.resolve({ path: ['api', 'user', 'auth', '2fa', 'verify'] }),
+    })
+
+    const upstreamInit = fetchMock.mock.calls[0][1] as RequestInit
+    const upstreamHeaders = upstreamInit.headers as Headers
+    expect(upstreamHeaders.get('authorization')).toBe(`Bearer ${challengeToken}`)
+    expect(response.cookies.get(AUTH_TOKEN_COOKIE_NAME)?.value).toBe(sessionToken)
+    await expect(response.json()).resolves.toEqual({ data: { user: { id: 3 } } })
+  })
 })
@@ -10,6 +10,7 @@ import {
   joinBaseURL,
   readPayloadToken,
   readRequestCookie,
+  resolveProxyBearerToken,
   shouldAdoptLegacyBearer,
   shouldClearSession,
   stripPayloadToken,
@@ -75,7 +76,7 @@ async function handleProxyRequest(
 
   const authCookieToken = readRequestCookie(request, AUTH_TOKEN_COOKIE_NAME)
   const legacyBearerToken = getBearerToken(request.headers.get('authorization'))
-  const bearerToken = authCookieToken || legacyBearerToken
+  const bearerToken = resolveProxyBearerToken(normalizedPath, authCookieToken, legacyBearerToken)
   const hasBody = request.method !== 'GET' && request.method !== 'HEAD'
   const upstreamResponse = await fetch(upstreamURL, {
     method: request.method,
@@ -0,0 +1,334 @@
+'use client'
+/* eslint-disable @next/next/no-img-element */
+
+import { Suspense, useState } from 'react'
+import { useSearchParams } from 'next/navigation'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Check, CheckCircle2, CreditCard, Loader2, Package } from 'lucide-react'
+import {
+  getPaymentLinkOrder,
+  PaymentCardResult,
+  PaymentLinkParams,
+  PublicPaymentLinkOrder,
+  selectPaymentLinkMethod,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { formatPaymentFeeRule } from '@/lib/payment-fee'
+import { resolvePaymentMethodIcon } from '@/lib/payment-method-icons'
+import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { FullPageLoading } from '@/components/ui/page-loading'
+import { SandboxedHtmlFrame } from '@/components/ui/sandboxed-html-frame'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+
+// 付款确认前轮询订单状态的间隔
+const PAYMENT_LINK_POLL_INTERVAL = 5000
+
+interface PaymentLinkMethod {
+  id: number
+  name: string
+  description?: string
+  icon: string
+  fee_rate?: number
+  fee_fixed_minor?: number
+}
+
+interface PaymentLinkPayload {
+  order: PublicPaymentLinkOrder
+  payment?: {
+    selected: boolean
+    payment_method?: { id: number; name: string; icon: string }
+    payment_card?: PaymentCardResult
+  }
+  methods?: PaymentLinkMethod[]
+}
+
+export default function PaymentLinkPage() {
+  return (
+    <Suspense fallback={<FullPageLoading />}>
+      <PaymentLinkContent />
+    </Suspense>
+  )
+}
+
+// 免登录付款页：凭管理员或下单用户分享的签名链接为待付款订单付款
+function PaymentLinkContent() {
+  const searchParams = useSearchParams()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.paymentLink)
+  const queryClient = useQueryClient()
+
+  const params: PaymentLinkParams = {
+    order_no: searchParams.get('order_no') || '',
+    expires: searchParams.get('expires') || '',
+    sig: searchParams.get('sig') || '',
+  }
+  const hasParams = Boolean(params.order_no && params.expires && params.sig)
+  const queryKey = ['paymentLink', params.order_no, params.expires, params.sig]
+
+  const [selectedId, setSelectedId] = useState<number | null>(null)
+  const [isChanging, setIsChanging] = useState(false)
+  const [selectError, setSelectError] = useState('')
+
+  const { data, isLoading, isFetching, error, refetch } = useQuery({
+    queryKey,
+    queryFn: () => getPaymentLinkOrder(params),
+    enabled: hasParams,
+    retry: false,
+    // 已选择付款方式时轮询订单状态，付款确认后停止
+    refetchInterval: (query) => {
+      const payload = (query.state.data as any)?.data as PaymentLinkPayload | undefined
+      return payload?.order.status === 'pending_payment' && payload.payment?.selected
+        ? PAYMENT_LINK_POLL_INTERVAL
+        : false
+    },
+  })
+  const payload = data?.data as PaymentLinkPayload | undefined
+
+  const selectMutation = useMutation({
+    mutationFn: (paymentMethodId: number) => selectPaymentLinkMethod(params, paymentMethodId),
+    onSuccess: () => {
+      setIsChanging(false)
+      setSelectedId(null)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError: (err: unknown) => {
+      setSelectedId(null)
+      queryClient.invalidateQueries({ queryKey })
+      setSelectError(resolveApiErrorMessage(err, t, t.paymentLink.selectFailed))
+    },
+  })
+
+  const statusLabels = t.order.status as Record<string, string>
+  const order = payload?.order
+  const payment = payload?.payment
+  const methods = payload?.methods || []
+  const isPending = order?.status === 'pending_payment'
+  const isPaid = Boolean(order?.paid_at)
+  const showMethods = isPending && (!payment?.selected || isChanging)
+
+  const getIcon = (iconName: string) => {
+    const Icon = resolvePaymentMethodIcon(iconName)
+    return <Icon className="h-5 w-5" />
+  }
+
+  return (
+    <div className="flex min-h-screen items-start justify-center bg-muted/30 px-4 py-10">
+      <div className="w-full max-w-xl space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle className="flex items-center gap-2">
+              <CreditCard className="h-5 w-5" />
+              {t.paymentLink.title}
+            </CardTitle>
+            <CardDescription>{t.paymentLink.desc}</CardDescription>
+          </CardHeader>
+          {!order && (
+            <CardContent>
+              {!hasParams ? (
+                <Alert variant="destructive">
+                  <AlertDescription>{t.paymentLink.missingParams}</AlertDescription>
+                </Alert>
+              ) : isLoading ? (
+                <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                  <Loader2 className="h-4 w-4 animate-spin" />
+                  {t.paymentLink.loading}
+                </div>
+              ) : error ? (
+                <Alert variant="destructive">
+                  <AlertDescription>
+                    {resolveApiErrorMessage(error, t, t.paymentLink.loadFailed)}
+                  </AlertDescription>
+                </Alert>
+              ) : null}
+            </CardContent>
+          )}
+        </Card>
+
+        {order && (
+          <Card>
+            <CardHeader>
+              <div className="flex flex-wrap items-center justify-between gap-2">
+                <CardTitle className="font-mono text-base">{order.order_no}</CardTitle>
+                <Badge variant="secondary">{statusLabels[order.status] || order.status}</Badge>
+              </div>
+            </CardHeader>
+            <CardContent className="space-y-5 text-sm">
+              <div className="flex items-baseline justify-between">
+                <span className="text-muted-foreground">{t.paymentLink.amountDue}</span>
+                <span className="text-xl font-semibold">
+                  {formatCurrency(order.total_amount_minor, order.currency)}
+                </span>
+              </div>
+
+              <div className="space-y-2">
+                <div className="font-medium">{t.paymentLink.items}</div>
+                <ul className="space-y-2">
+                  {order.items.map((item, index) => (
+                    <li key={index} className="flex items-center gap-3">
+                      {item.image_url ? (
+                        <img
+                          src={item.image_url}
+                          alt={item.name}
+                          className="h-10 w-10 rounded object-cover"
+                        />
+                      ) : (
+                        <Package className="h-10 w-10 rounded bg-muted p-2 text-muted-foreground" />
+                      )}
+                      <span className="flex-1">{item.name}</span>
+                      <span className="text-muted-foreground">x{item.quantity}</span>
+                    </li>
+                  ))}
+                </ul>
+              </div>
+
+              {isPaid ? (
+                <Alert>
+                  <CheckCircle2 className="h-4 w-4 text-green-600" />
+                  <AlertTitle>{t.paymentLink.paid}</AlertTitle>
+                  <AlertDescription>{t.paymentLink.paidDesc}</AlertDescription>
+                </Alert>
+              ) : !isPending ? (
+                <Alert>
+                  <AlertDescription>{t.paymentLink.notPayable}</AlertDescription>
+                </Alert>
+              ) : null}
+            </CardContent>
+          </Card>
+        )}
+
+        {isPending && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-base">{t.paymentLink.paymentMethod}</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-4 text-sm">
+              {selectError && (
+                <Alert variant="destructive">
+                  <AlertDescription>{selectError}</AlertDescription>
+                </Alert>
+              )}
+              {showMethods ? (
+                methods.length === 0 ? (
+                  <Alert>
+                    <AlertDescription>{t.paymentLink.noMethods}</AlertDescription>
+                  </Alert>
+                ) : (
+                  <>
+                    <p className="text-muted-foreground">{t.paymentLink.selectMethod}</p>
+                    <div className="grid gap-3">
+                      {methods.map((method) => {
+                        const feeRule = formatPaymentFeeRule(
+                          method.fee_rate,
+                          method.fee_fixed_minor,
+                          order?.currency
+                        )
+                        return (
+                          <button
+                            key={method.id}
+                            type="button"
+                            className={`flex w-full items-center gap-3 rounded-xl border px-4 py-3 text-left transition-all hover:bg-muted/70 ${
+                              selectedId === method.id
+                                ? 'border-primary/60 bg-primary/10 ring-1 ring-primary/20'
+                                : 'bg-background'
+                            }`}
+                            onClick={() => setSelectedId(method.id)}
+                            aria-pressed={selectedId === method.id}
+                          >
+                            <div className="rounded-lg bg-muted p-2">{getIcon(method.icon)}</div>
+                            <div className="min-w-0 flex-1">
+                              <div className="font-medium">{method.name}</div>
+                              {method.description && (
+                                <div className="mt-1 line-clamp-2 text-muted-foreground">
+                                  {method.description}
+                                </div>
+                              )}
+                              {feeRule && (
+                                <div
+                                  className={`mt-1 text-xs ${
+                                    feeRule.startsWith('-')
+                                      ? 'text-emerald-600 dark:text-emerald-400'
+                                      : 'text-amber-600 dark:text-amber-400'
+                                  }`}
+                                >
+                                  {t.paymentLink.methodFee.replace('{fee}', feeRule)}
+                                </div>
+                              )}
+                            </div>
+                            {selectedId === method.id && <Check className="h-4 w-4 text-primary" />}
+                          </button>
+                        )
+                      })}
+                    </div>
+                    <div className="flex flex-wrap gap-2">
+                      <Button
+                        className="flex-1"
+                        disabled={!selectedId || selectMutation.isPending}
+                        onClick={() => {
+                          if (!selectedId) return
+                          setSelectError('')
+                          selectMutation.mutate(selectedId)
+                        }}
+                      >
+                        {selectMutation.isPending && (
+                          <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+                        )}
+                        {t.paymentLink.confirmMethod}
+                      </Button>
+                      {isChanging && (
+                        <Button variant="outline" onClick={() => setIsChanging(false)}>
+                          {t.common.cancel}
+                        </Button>
+                      )}
+                    </div>
+                  </>
+                )
+              ) : payment?.payment_method ? (
+                <>
+                  <div className="flex items-center gap-2 rounded-lg bg-muted p-3">
+                    {getIcon(payment.payment_method.icon)}
+                    <span className="font-medium">{payment.payment_method.name}</span>
+                  </div>
+                  {payment.payment_card?.html ? (
+                    <SandboxedHtmlFrame
+                      html={payment.payment_card.html}
+                      title={t.paymentLink.paymentMethod}
+                      className="payment-card-content"
+                      locale={locale}
+                    />
+                  ) : (
+                    <Alert>
+                      <AlertDescription>{t.paymentLink.paymentCardPending}</AlertDescription>
+                    </Alert>
+                  )}
+                  <p className="text-muted-foreground">{t.paymentLink.awaitingConfirmation}</p>
+                  <div className="flex flex-wrap gap-2">
+                    <Button
+                      variant="outline"
+                      className="flex-1"
+                      onClick={() => {
+                        setIsChanging(true)
+                        setSelectedId(null)
+                      }}
+                    >
+                      {t.paymentLink.changeMethod}
+                    </Button>
+                    <Button variant="outline" disabled={isFetching} onClick={() => refetch()}>
+                      {t.paymentLink.refresh}
+                    </Button>
+                  </div>
+                </>
+              ) : null}
+            </CardContent>
+          </Card>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,219 @@
+'use client'
+/* eslint-disable @next/next/no-img-element */
+
+import { Suspense, useEffect, useState } from 'react'
+import { useSearchParams } from 'next/navigation'
+import { useMutation } from '@tanstack/react-query'
+import { CheckCircle2, Loader2, Package, Search, Truck } from 'lucide-react'
+import { getOrderTrackingByLink, lookupOrderTracking, PublicOrderTracking } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { Alert, AlertDescription } from '@/components/ui/alert'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { FullPageLoading } from '@/components/ui/page-loading'
+import { useLocale } from '@/hooks/use-locale'
+import { usePageTitle } from '@/hooks/use-page-title'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+
+export default function TrackOrderPage() {
+  return (
+    <Suspense fallback={<FullPageLoading />}>
+      <TrackOrderContent />
+    </Suspense>
+  )
+}
+
+// 游客订单免登录查询：凭订单号+邮箱，或发货邮件中的签名链接
+function TrackOrderContent() {
+  const searchParams = useSearchParams()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  usePageTitle(t.pageTitle.orderTracking)
+
+  const [orderNo, setOrderNo] = useState('')
+  const [email, setEmail] = useState('')
+  const [result, setResult] = useState<PublicOrderTracking | null>(null)
+  const [error, setError] = useState('')
+
+  const handleResult = {
+    onSuccess: (res: any) => {
+      setError('')
+      setResult(res.data)
+    },
+    onError: (err: unknown) => {
+      setResult(null)
+      setError(resolveApiErrorMessage(err, t, t.orderTracking.lookupFailed))
+    },
+  }
+
+  const lookupMutation = useMutation({
+    mutationFn: lookupOrderTracking,
+    ...handleResult,
+  })
+  const linkMutation = useMutation({
+    mutationFn: getOrderTrackingByLink,
+    ...handleResult,
+  })
+
+  const linkOrderNo = searchParams.get('order_no') || ''
+  const linkExpires = searchParams.get('expires') || ''
+  const linkSig = searchParams.get('sig') || ''
+
+  useEffect(() => {
+    if (linkOrderNo && linkExpires && linkSig) {
+      setOrderNo(linkOrderNo)
+      linkMutation.mutate({ order_no: linkOrderNo, expires: linkExpires, sig: linkSig })
+    }
+    // 签名链接只在进入页面时查询一次
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [linkOrderNo, linkExpires, linkSig])
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault()
+    if (!orderNo.trim() || !email.trim()) return
+    lookupMutation.mutate({ order_no: orderNo.trim(), email: email.trim() })
+  }
+
+  const statusLabels = t.order.status as Record<string, string>
+  const isLoading = lookupMutation.isPending || linkMutation.isPending
+
+  return (
+    <div className="flex min-h-screen items-start justify-center bg-muted/30 px-4 py-10">
+      <div className="w-full max-w-xl space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle className="flex items-center gap-2">
+              <Truck className="h-5 w-5" />
+              {t.orderTracking.title}
+            </CardTitle>
+            <CardDescription>{t.orderTracking.desc}</CardDescription>
+          </CardHeader>
+          {!result && (
+            <CardContent>
+              {linkMutation.isPending ? (
+                <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                  <Loader2 className="h-4 w-4 animate-spin" />
+                  {t.orderTracking.loadingLink}
+                </div>
+              ) : (
+                <form onSubmit={handleSubmit} className="space-y-3">
+                  <div className="space-y-1">
+                    <label className="text-sm font-medium">{t.orderTracking.orderNo}</label>
+                    <Input value={orderNo} onChange={(e) => setOrderNo(e.target.value)} />
+                  </div>
+                  <div className="space-y-1">
+                    <label className="text-sm font-medium">{t.orderTracking.email}</label>
+                    <Input
+                      type="email"
+                      autoComplete="email"
+                      value={email}
+                      onChange={(e) => setEmail(e.target.value)}
+                    />
+                  </div>
+                  <Button
+                    type="submit"
+                    className="w-full"
+                    disabled={isLoading || !orderNo.trim() || !email.trim()}
+                  >
+                    {lookupMutation.isPending ? (
+                      <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+                    ) : (
+                      <Search className="mr-2 h-4 w-4" />
+                    )}
+                    {t.orderTracking.submit}
+                  </Button>
+                </form>
+              )}
+              {error && (
+                <Alert variant="destructive" className="mt-3">
+                  <AlertDescription>{error}</AlertDescription>
+                </Alert>
+              )}
+            </CardContent>
+          )}
+        </Card>
+
+        {result && (
+          <Card>
+            <CardHeader>
+              <div className="flex flex-wrap items-center justify-between gap-2">
+                <CardTitle className="font-mono text-base">{result.order_no}</CardTitle>
+                <Badge variant="secondary">{statusLabels[result.status] || result.status}</Badge>
+              </div>
+            </CardHeader>
+            <CardContent className="space-y-5 text-sm">
+              {(result.tracking_no || result.estimated_delivery_at) && (
+                <div className="grid gap-3 sm:grid-cols-2">
+                  {result.tracking_no && (
+                    <div>
+                      <div className="text-muted-foreground">{t.orderTracking.trackingNo}</div>
+                      <div className="font-mono">{result.tracking_no}</div>
+                    </div>
+                  )}
+                  {result.estimated_delivery_at && (
+                    <div>
+                      <div className="text-muted-foreground">
+                        {t.orderTracking.estimatedDelivery}
+                      </div>
+                      <div>{formatDate(result.estimated_delivery_at)}</div>
+                    </div>
+                  )}
+                </div>
+              )}
+
+              <div className="space-y-2">
+                <div className="font-medium">{t.orderTracking.progress}</div>
+                <ol className="space-y-2">
+                  {result.checkpoints.map((checkpoint) => (
+                    <li key={checkpoint.key} className="flex items-center gap-2">
+                      <CheckCircle2 className="h-4 w-4 text-green-600" />
+                      <span>{t.orderTracking.checkpoints[checkpoint.key] || checkpoint.key}</span>
+                      <span className="ml-auto text-xs text-muted-foreground">
+                        {formatDate(checkpoint.at)}
+                      </span>
+                    </li>
+                  ))}
+                </ol>
+              </div>
+
+              <div className="space-y-2">
+                <div className="font-medium">{t.orderTracking.items}</div>
+                <ul className="space-y-2">
+                  {result.items.map((item, index) => (
+                    <li key={index} className="flex items-center gap-3">
+                      {item.image_url ? (
+                        <img
+                          src={item.image_url}
+                          alt={item.name}
+                          className="h-10 w-10 rounded object-cover"
+                        />
+                      ) : (
+                        <Package className="h-10 w-10 rounded bg-muted p-2 text-muted-foreground" />
+                      )}
+                      <span className="flex-1">{item.name}</span>
+                      <span className="text-muted-foreground">x{item.quantity}</span>
+                    </li>
+                  ))}
+                </ul>
+              </div>
+
+              <Button
+                variant="outline"
+                className="w-full"
+                onClick={() => {
+                  setResult(null)
+                  setEmail('')
+                }}
+              >
+                {t.orderTracking.searchAgain}
+              </Button>
+            </CardContent>
+          </Card>
+        )}
+      </div>
+    </div>
+  )
+}
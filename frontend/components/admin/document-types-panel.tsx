@@ -0,0 +1,504 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Eye, Files, Pencil, Plus, Trash2 } from 'lucide-react'
+
+import {
+  DocumentType,
+  DocumentTypePayload,
+  createDocumentType,
+  deleteDocumentType,
+  getDocumentTypes,
+  updateDocumentType,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { useTheme } from '@/contexts/theme-context'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Checkbox } from '@/components/ui/checkbox'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { LazyCodeEditor } from '@/components/ui/lazy-code-editor'
+import { Switch } from '@/components/ui/switch'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const DOCUMENT_ORDER_STATUSES = [
+  'pending_payment',
+  'draft',
+  'pending',
+  'need_resubmit',
+  'shipped',
+  'completed',
+  'cancelled',
+  'refund_pending',
+  'refunded',
+] as const
+
+interface DocumentTypeForm {
+  key: string
+  name: string
+  description: string
+  binding: 'order' | 'order_item'
+  template: string
+  fields: string
+  order_statuses: string[]
+  item_skus: string
+  enabled: boolean
+  visible_to_user: boolean
+}
+
+const EMPTY_FORM: DocumentTypeForm = {
+  key: '',
+  name: '',
+  description: '',
+  binding: 'order',
+  template: '',
+  fields: '',
+  order_statuses: ['completed'],
+  item_skus: '',
+  enabled: true,
+  visible_to_user: true,
+}
+
+// 自定义字段以每行 key=value 编辑
+function parseFields(value: string): Record<string, string> {
+  const fields: Record<string, string> = {}
+  for (const line of value.split('\n')) {
+    const index = line.indexOf('=')
+    if (index <= 0) continue
+    fields[line.slice(0, index).trim()] = line.slice(index + 1).trim()
+  }
+  return fields
+}
+
+function formatFields(fields?: Record<string, string>) {
+  return Object.entries(fields || {})
+    .map(([key, value]) => `${key}=${value}`)
+    .join('\n')
+}
+
+function toForm(docType: DocumentType): DocumentTypeForm {
+  return {
+    key: docType.key,
+    name: docType.name,
+    description: docType.description || '',
+    binding: docType.binding,
+    template: docType.template || '',
+    fields: formatFields(docType.fields),
+    order_statuses: docType.order_statuses?.length ? docType.order_statuses : ['completed'],
+    item_skus: (docType.item_skus || []).join(', '),
+    enabled: docType.enabled,
+    visible_to_user: docType.visible_to_user,
+  }
+}
+
+function toPayload(form: DocumentTypeForm): DocumentTypePayload {
+  return {
+    key: form.key.trim(),
+    name: form.name.trim(),
+    description: form.description.trim(),
+    binding: form.binding,
+    template: form.template,
+    fields: parseFields(form.fields),
+    order_statuses: form.order_statuses,
+    item_skus: form.item_skus
+      .split(',')
+      .map((sku) => sku.trim())
+      .filter(Boolean),
+    enabled: form.enabled,
+    visible_to_user: form.visible_to_user,
+  }
+}
+
+export function DocumentTypesPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const { resolvedTheme } = useTheme()
+  const [editingId, setEditingId] = useState<number | null>(null)
+  const [showForm, setShowForm] = useState(false)
+  const [form, setForm] = useState<DocumentTypeForm>(EMPTY_FORM)
+  const [previewOrderNo, setPreviewOrderNo] = useState('')
+  const [previewItem, setPreviewItem] = useState('1')
+  const [deleting, setDeleting] = useState<DocumentType | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['documentTypes'],
+    queryFn: getDocumentTypes,
+  })
+  const docTypes: DocumentType[] = data?.data || []
+
+  const updateForm = <K extends keyof DocumentTypeForm>(field: K, value: DocumentTypeForm[K]) =>
+    setForm((prev) => ({ ...prev, [field]: value }))
+
+  const closeForm = () => {
+    setShowForm(false)
+    setEditingId(null)
+    setForm(EMPTY_FORM)
+  }
+
+  const saveMutation = useMutation({
+    mutationFn: () =>
+      editingId
+        ? updateDocumentType(editingId, toPayload(form))
+        : createDocumentType(toPayload(form)),
+    onSuccess: () => {
+      toast.success(t.admin.documentTypeSaved)
+      closeForm()
+      queryClient.invalidateQueries({ queryKey: ['documentTypes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.documentTypeSaveFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deleteDocumentType(id),
+    onSuccess: () => {
+      toast.success(t.admin.documentTypeDeleted)
+      setDeleting(null)
+      queryClient.invalidateQueries({ queryKey: ['documentTypes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.documentTypeDeleteFailed))
+    },
+  })
+
+  // 先同步打开空白窗口再加载预览，避免异步请求后的弹窗被浏览器拦截
+  const handlePreview = async () => {
+    const win = window.open('', '_blank')
+    try {
+      const payload = toPayload(form)
+      const res = await fetch(
+        resolveClientAPIProxyURL('/api/admin/settings/document-types/preview'),
+        {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify({
+            key: payload.key,
+            name: payload.name,
+            binding: payload.binding,
+            template: payload.template,
+            fields: payload.fields,
+            order_no: previewOrderNo.trim(),
+            item_index: Math.max(Number(previewItem || 1) - 1, 0),
+          }),
+        }
+      )
+      if (!res.ok) {
+        throw await res.json().catch(() => null)
+      }
+      const url = window.URL.createObjectURL(await res.blob())
+      if (win) {
+        win.location.href = url
+      } else {
+        window.open(url, '_blank')
+      }
+      window.setTimeout(() => window.URL.revokeObjectURL(url), 60_000)
+    } catch (error) {
+      win?.close()
+      toast.error(resolveApiErrorMessage(error, t, t.admin.documentTypePreviewFailed))
+    }
+  }
+
+  const toggleStatus = (status: string, checked: boolean) =>
+    updateForm(
+      'order_statuses',
+      checked
+        ? [...form.order_statuses, status]
+        : form.order_statuses.filter((item) => item !== status)
+    )
+
+  return (
+    <Card className="bg-muted/30">
+      <CardHeader className="pb-3">
+        <div className="flex items-center justify-between gap-2">
+          <div>
+            <CardTitle className="flex items-center gap-2 text-sm">
+              <Files className="h-4 w-4" />
+              {t.admin.documentTypes}
+            </CardTitle>
+            <CardDescription>{t.admin.documentTypesDesc}</CardDescription>
+          </div>
+          {!showForm && (
+            <Button type="button" size="sm" variant="outline" onClick={() => setShowForm(true)}>
+              <Plus className="mr-1.5 h-4 w-4" />
+              {t.admin.documentTypeCreate}
+            </Button>
+          )}
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {showForm && (
+          <div className="space-y-4 rounded-md border bg-background p-4">
+            <div className="grid gap-3 md:grid-cols-3">
+              <div>
+                <Label htmlFor="document_type_key">{t.admin.documentTypeKey}</Label>
+                <Input
+                  id="document_type_key"
+                  value={form.key}
+                  placeholder="warranty-card"
+                  className="mt-1.5"
+                  onChange={(e) => updateForm('key', e.target.value)}
+                />
+              </div>
+              <div>
+                <Label htmlFor="document_type_name">{t.admin.name}</Label>
+                <Input
+                  id="document_type_name"
+                  value={form.name}
+                  className="mt-1.5"
+                  onChange={(e) => updateForm('name', e.target.value)}
+                />
+              </div>
+              <div>
+                <Label>{t.admin.documentTypeBinding}</Label>
+                <Select
+                  value={form.binding}
+                  onValueChange={(value) =>
+                    updateForm('binding', value as DocumentTypeForm['binding'])
+                  }
+                >
+                  <SelectTrigger className="mt-1.5">
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="order">{t.admin.documentTypeBindingOrder}</SelectItem>
+                    <SelectItem value="order_item">
+                      {t.admin.documentTypeBindingOrderItem}
+                    </SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+            <div>
+              <Label htmlFor="document_type_description">{t.admin.documentTypeDescription}</Label>
+              <Input
+                id="document_type_description"
+                value={form.description}
+                className="mt-1.5"
+                onChange={(e) => updateForm('description', e.target.value)}
+              />
+            </div>
+            <div>
+              <Label>{t.admin.documentTypeStatuses}</Label>
+              <div className="mt-1.5 flex flex-wrap gap-3">
+                {DOCUMENT_ORDER_STATUSES.map((status) => (
+                  <label key={status} className="flex items-center gap-1.5 text-sm">
+                    <Checkbox
+                      checked={form.order_statuses.includes(status)}
+                      onCheckedChange={(checked) => toggleStatus(status, checked === true)}
+                    />
+                    {t.order.status[status]}
+                  </label>
+                ))}
+              </div>
+            </div>
+            {form.binding === 'order_item' && (
+              <div>
+                <Label htmlFor="document_type_skus">{t.admin.documentTypeSkus}</Label>
+                <Input
+                  id="document_type_skus"
+                  value={form.item_skus}
+                  placeholder={t.admin.documentTypeSkusPlaceholder}
+                  className="mt-1.5"
+                  onChange={(e) => updateForm('item_skus', e.target.value)}
+                />
+              </div>
+            )}
+            <div>
+              <Label htmlFor="document_type_fields">{t.admin.documentTypeFields}</Label>
+              <Textarea
+                id="document_type_fields"
+                value={form.fields}
+                rows={3}
+                placeholder="period=24 months"
+                className="mt-1.5 font-mono text-xs"
+                onChange={(e) => updateForm('fields', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.admin.documentTypeTemplate}</Label>
+              <LazyCodeEditor
+                value={form.template}
+                onChange={(value) => updateForm('template', value)}
+                language="html"
+                height="260px"
+                theme={resolvedTheme}
+                className="overflow-hidden rounded-md border text-sm"
+              />
+              <p className="text-xs text-muted-foreground">{t.admin.documentTypeTemplateTip}</p>
+            </div>
+            <div className="flex flex-wrap items-center gap-6">
+              <label className="flex items-center gap-2 text-sm">
+                <Switch
+                  checked={form.enabled}
+                  onCheckedChange={(checked) => updateForm('enabled', checked)}
+                />
+                {t.admin.documentTypeEnabled}
+              </label>
+              <label className="flex items-center gap-2 text-sm">
+                <Switch
+                  checked={form.visible_to_user}
+                  onCheckedChange={(checked) => updateForm('visible_to_user', checked)}
+                />
+                {t.admin.documentTypeVisibleToUser}
+              </label>
+            </div>
+            <div className="flex flex-wrap items-end gap-2">
+              <div>
+                <Label htmlFor="document_type_preview_order">
+                  {t.admin.documentTypePreviewOrder}
+                </Label>
+                <Input
+                  id="document_type_preview_order"
+                  value={previewOrderNo}
+                  className="mt-1.5 w-48"
+                  onChange={(e) => setPreviewOrderNo(e.target.value)}
+                />
+              </div>
+              {form.binding === 'order_item' && (
+                <div>
+                  <Label htmlFor="document_type_preview_item">
+                    {t.admin.documentTypePreviewItem}
+                  </Label>
+                  <Input
+                    id="document_type_preview_item"
+                    type="number"
+                    min={1}
+                    value={previewItem}
+                    className="mt-1.5 w-24"
+                    onChange={(e) => setPreviewItem(e.target.value)}
+                  />
+                </div>
+              )}
+              <Button
+                type="button"
+                variant="outline"
+                disabled={!previewOrderNo.trim()}
+                onClick={handlePreview}
+              >
+                <Eye className="mr-1.5 h-4 w-4" />
+                {t.admin.documentTypePreview}
+              </Button>
+            </div>
+            <div className="flex gap-2">
+              <Button
+                type="button"
+                disabled={saveMutation.isPending}
+                onClick={() => saveMutation.mutate()}
+              >
+                {saveMutation.isPending ? t.admin.processing : t.common.save}
+              </Button>
+              <Button type="button" variant="outline" onClick={closeForm}>
+                {t.common.cancel}
+              </Button>
+            </div>
+          </div>
+        )}
+
+        {docTypes.length === 0 ? (
+          !showForm && <p className="text-sm text-muted-foreground">{t.admin.documentTypeEmpty}</p>
+        ) : (
+          <div className="space-y-2">
+            {docTypes.map((docType) => (
+              <div
+                key={docType.id}
+                className="flex flex-wrap items-center justify-between gap-2 rounded-md border bg-background p-3 text-sm"
+              >
+                <div className="space-y-1">
+                  <div className="flex flex-wrap items-center gap-2">
+                    <span className="font-medium">{docType.name}</span>
+                    <code className="text-xs text-muted-foreground">{docType.key}</code>
+                    <Badge variant="outline">
+                      {docType.binding === 'order_item'
+                        ? t.admin.documentTypeBindingOrderItem
+                        : t.admin.documentTypeBindingOrder}
+                    </Badge>
+                    {!docType.enabled && <Badge variant="secondary">{t.admin.disabled}</Badge>}
+                    {docType.enabled && !docType.visible_to_user && (
+                      <Badge variant="secondary">{t.admin.documentTypeAdminOnly}</Badge>
+                    )}
+                  </div>
+                  {docType.description && (
+                    <div className="text-xs text-muted-foreground">{docType.description}</div>
+                  )}
+                </div>
+                <div className="flex gap-1">
+                  <Button
+                    type="button"
+                    variant="ghost"
+                    size="sm"
+                    title={t.common.edit}
+                    onClick={() => {
+                      setEditingId(docType.id)
+                      setForm(toForm(docType))
+                      setShowForm(true)
+                    }}
+                  >
+                    <Pencil className="h-4 w-4" />
+                  </Button>
+                  <Button
+                    type="button"
+                    variant="ghost"
+                    size="sm"
+                    title={t.common.delete}
+                    onClick={() => setDeleting(docType)}
+                  >
+                    <Trash2 className="h-4 w-4 text-destructive" />
+                  </Button>
+                </div>
+              </div>
+            ))}
+          </div>
+        )}
+      </CardContent>
+
+      <AlertDialog open={deleting !== null} onOpenChange={(open) => !open && setDeleting(null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{deleting?.name}</AlertDialogTitle>
+            <AlertDialogDescription>{t.admin.documentTypeDeleteConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={(e) => {
+                e.preventDefault()
+                if (deleting) deleteMutation.mutate(deleting.id)
+              }}
+              disabled={deleteMutation.isPending}
+              className="bg-destructive text-destructive-foreground hover:bg-destructive/90"
+            >
+              {deleteMutation.isPending ? t.admin.processing : t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  )
+}
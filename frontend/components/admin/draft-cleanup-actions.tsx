@@ -0,0 +1,136 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import { Eye, Trash2 } from 'lucide-react'
+
+import { DraftCleanupReport, cleanupStaleDraftOrders } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+
+// 草稿清理的试运行与立即执行，作用于已保存的保留策略
+export function DraftCleanupActions() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const [preview, setPreview] = useState<DraftCleanupReport | null>(null)
+  const [confirmOpen, setConfirmOpen] = useState(false)
+
+  const previewMutation = useMutation({
+    mutationFn: () => cleanupStaleDraftOrders(true),
+    onSuccess: (res: any) => setPreview(res?.data || null),
+    onError: (error: unknown) => {
+      setPreview(null)
+      toast.error(resolveApiErrorMessage(error, t, t.admin.draftCleanupPreviewFailed))
+    },
+  })
+
+  const runMutation = useMutation({
+    mutationFn: () => cleanupStaleDraftOrders(false),
+    onSuccess: (res: any) => {
+      const report: DraftCleanupReport | undefined = res?.data
+      toast.success(
+        t.admin.draftCleanupRunSuccess.replace('{count}', String(report?.cleaned_count || 0))
+      )
+      setPreview(null)
+      setConfirmOpen(false)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.draftCleanupRunFailed))
+    },
+  })
+
+  const actionLabel =
+    preview?.action === 'anonymize'
+      ? t.admin.draftCleanupActionAnonymize
+      : t.admin.draftCleanupActionDelete
+
+  return (
+    <div className="space-y-3 rounded-md border border-input/60 bg-muted/10 p-3">
+      <div className="flex flex-wrap items-center gap-2">
+        <Button
+          type="button"
+          variant="outline"
+          size="sm"
+          onClick={() => previewMutation.mutate()}
+          disabled={previewMutation.isPending}
+        >
+          <Eye className="mr-2 h-4 w-4" />
+          {t.admin.draftCleanupPreview}
+        </Button>
+        <Button
+          type="button"
+          variant="destructive"
+          size="sm"
+          onClick={() => setConfirmOpen(true)}
+          disabled={!preview || preview.orders.length === 0 || runMutation.isPending}
+        >
+          <Trash2 className="mr-2 h-4 w-4" />
+          {t.admin.draftCleanupRunNow}
+        </Button>
+        <p className="text-xs text-muted-foreground">{t.admin.draftCleanupPreviewHint}</p>
+      </div>
+
+      {preview && (
+        <div className="space-y-2 text-sm">
+          <p>
+            {t.admin.draftCleanupPreviewSummary
+              .replace('{count}', String(preview.orders.length))
+              .replace('{action}', actionLabel)
+              .replace('{cutoff}', formatDate(preview.cutoff_time))}
+          </p>
+          {preview.orders.length > 0 && (
+            <div className="flex max-h-40 flex-wrap gap-2 overflow-auto">
+              {preview.orders.map((order) => (
+                <Badge key={order.id} variant="outline" className="font-mono">
+                  {order.order_no}
+                </Badge>
+              ))}
+            </div>
+          )}
+        </div>
+      )}
+
+      <AlertDialog open={confirmOpen} onOpenChange={setConfirmOpen}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.admin.draftCleanupRunNow}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.admin.draftCleanupRunConfirm
+                .replace('{count}', String(preview?.orders.length || 0))
+                .replace('{action}', actionLabel)}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={(e) => {
+                e.preventDefault()
+                runMutation.mutate()
+              }}
+              disabled={runMutation.isPending}
+              className="bg-destructive text-destructive-foreground hover:bg-destructive/90"
+            >
+              {runMutation.isPending ? t.admin.processing : t.common.confirm}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </div>
+  )
+}
@@ -0,0 +1,227 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Camera, History } from 'lucide-react'
+
+import {
+  InventorySnapshotCompareResult,
+  InventorySnapshotResult,
+  compareInventorySnapshots,
+  getInventorySnapshot,
+  takeInventorySnapshot,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+function formatDelta(value: number) {
+  return value > 0 ? `+${value}` : String(value)
+}
+
+function deltaClass(value: number) {
+  if (value > 0) return 'text-green-600'
+  if (value < 0) return 'text-red-600'
+  return 'text-muted-foreground'
+}
+
+// 历史库存：按日期查询每日快照中的库存水平，或对比两个日期的变化
+export function InventorySnapshotPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [fromDate, setFromDate] = useState('')
+  const [toDate, setToDate] = useState(() => new Date().toISOString().slice(0, 10))
+  const [query, setQuery] = useState<{ from: string; to: string } | null>(null)
+
+  const { data, error } = useQuery({
+    queryKey: ['inventorySnapshots', query],
+    queryFn: () =>
+      query!.from
+        ? compareInventorySnapshots({ from: query!.from, to: query!.to })
+        : getInventorySnapshot({ date: query!.to }),
+    enabled: query !== null,
+    retry: false,
+  })
+  const snapshot: InventorySnapshotResult | undefined = query?.from ? undefined : data?.data
+  const comparison: InventorySnapshotCompareResult | undefined = query?.from
+    ? data?.data
+    : undefined
+
+  const takeMutation = useMutation({
+    mutationFn: () => takeInventorySnapshot(),
+    onSuccess: (res: any) => {
+      const result: InventorySnapshotResult | undefined = res?.data
+      toast.success(t.admin.inventorySnapshotTaken.replace('{date}', result?.snapshot_date || ''))
+      queryClient.invalidateQueries({ queryKey: ['inventorySnapshots'] })
+    },
+    onError: (err: unknown) => {
+      toast.error(resolveApiErrorMessage(err, t, t.admin.inventorySnapshotTakeFailed))
+    },
+  })
+
+  const sourceBadge = (source: 'physical' | 'virtual') => (
+    <Badge variant="outline">
+      {source === 'physical' ? t.admin.inventorySnapshotPhysical : t.admin.inventorySnapshotVirtual}
+    </Badge>
+  )
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <History className="h-4 w-4" />
+            {t.admin.inventorySnapshots}
+          </CardTitle>
+          <CardDescription>{t.admin.inventorySnapshotsDesc}</CardDescription>
+        </div>
+        <Button
+          size="sm"
+          variant="outline"
+          disabled={takeMutation.isPending}
+          onClick={() => takeMutation.mutate()}
+        >
+          <Camera className="mr-1 h-4 w-4" />
+          {t.admin.inventorySnapshotTake}
+        </Button>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex flex-wrap items-end gap-3">
+          <div className="space-y-2">
+            <Label>{t.admin.inventorySnapshotFrom}</Label>
+            <Input type="date" value={fromDate} onChange={(e) => setFromDate(e.target.value)} />
+          </div>
+          <div className="space-y-2">
+            <Label>{t.admin.inventorySnapshotTo}</Label>
+            <Input type="date" value={toDate} onChange={(e) => setToDate(e.target.value)} />
+          </div>
+          <Button disabled={!toDate} onClick={() => setQuery({ from: fromDate, to: toDate })}>
+            {fromDate ? t.admin.inventorySnapshotCompare : t.admin.inventorySnapshotView}
+          </Button>
+        </div>
+
+        {error && (
+          <p className="text-sm text-red-600">
+            {resolveApiErrorMessage(error, t, t.admin.inventorySnapshotLoadFailed)}
+          </p>
+        )}
+
+        {snapshot && (
+          <div className="space-y-2">
+            <p className="text-xs text-muted-foreground">
+              {t.admin.inventorySnapshotUsing.replace('{date}', snapshot.snapshot_date)}
+            </p>
+            {snapshot.items.length === 0 ? (
+              <p className="text-sm text-muted-foreground">{t.admin.inventorySnapshotEmpty}</p>
+            ) : (
+              <div className="max-h-96 overflow-auto">
+                <Table>
+                  <TableHeader>
+                    <TableRow>
+                      <TableHead>{t.admin.stockReconciliationInventory}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotOnHand}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotAvailable}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotReserved}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotSold}</TableHead>
+                    </TableRow>
+                  </TableHeader>
+                  <TableBody>
+                    {snapshot.items.map((item) => (
+                      <TableRow key={item.id}>
+                        <TableCell>
+                          <div className="flex items-center gap-2">
+                            {sourceBadge(item.source)}
+                            <span>{item.name}</span>
+                            {item.sku && (
+                              <span className="font-mono text-xs text-muted-foreground">
+                                {item.sku}
+                              </span>
+                            )}
+                          </div>
+                        </TableCell>
+                        <TableCell>{item.on_hand}</TableCell>
+                        <TableCell>{item.available}</TableCell>
+                        <TableCell>{item.reserved}</TableCell>
+                        <TableCell>{item.sold}</TableCell>
+                      </TableRow>
+                    ))}
+                  </TableBody>
+                </Table>
+              </div>
+            )}
+          </div>
+        )}
+
+        {comparison && (
+          <div className="space-y-2">
+            <p className="text-xs text-muted-foreground">
+              {t.admin.inventorySnapshotUsingRange
+                .replace('{from}', comparison.from_snapshot_date)
+                .replace('{to}', comparison.to_snapshot_date)}
+              {' · '}
+              {t.admin.inventorySnapshotTotal}: {t.admin.inventorySnapshotOnHand}{' '}
+              {formatDelta(comparison.totals.on_hand)}, {t.admin.inventorySnapshotSold}{' '}
+              {formatDelta(comparison.totals.sold)}
+            </p>
+            {comparison.items.length === 0 ? (
+              <p className="text-sm text-muted-foreground">{t.admin.inventorySnapshotEmpty}</p>
+            ) : (
+              <div className="max-h-96 overflow-auto">
+                <Table>
+                  <TableHeader>
+                    <TableRow>
+                      <TableHead>{t.admin.stockReconciliationInventory}</TableHead>
+                      <TableHead>{comparison.from_snapshot_date}</TableHead>
+                      <TableHead>{comparison.to_snapshot_date}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotChange}</TableHead>
+                      <TableHead>{t.admin.inventorySnapshotSold}</TableHead>
+                    </TableRow>
+                  </TableHeader>
+                  <TableBody>
+                    {comparison.items.map((item) => (
+                      <TableRow key={`${item.source}-${item.inventory_id}`}>
+                        <TableCell>
+                          <div className="flex items-center gap-2">
+                            {sourceBadge(item.source)}
+                            <span>{item.name}</span>
+                            {item.sku && (
+                              <span className="font-mono text-xs text-muted-foreground">
+                                {item.sku}
+                              </span>
+                            )}
+                          </div>
+                        </TableCell>
+                        <TableCell>{item.from ? item.from.on_hand : '-'}</TableCell>
+                        <TableCell>{item.to ? item.to.on_hand : '-'}</TableCell>
+                        <TableCell className={deltaClass(item.delta.on_hand)}>
+                          {formatDelta(item.delta.on_hand)}
+                        </TableCell>
+                        <TableCell>{formatDelta(item.delta.sold)}</TableCell>
+                      </TableRow>
+                    ))}
+                  </TableBody>
+                </Table>
+              </div>
+            )}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,200 @@
+'use client'
+
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { BellOff, BellRing } from 'lucide-react'
+
+import {
+  InventoryStockAlert,
+  LowStockAlertResult,
+  getLowStockAlerts,
+  runLowStockCheck,
+  snoozeLowStockAlert,
+  unsnoozeLowStockAlert,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+const SNOOZE_OPTIONS = [
+  { minutes: 60, key: 'lowStockSnooze1h' },
+  { minutes: 24 * 60, key: 'lowStockSnooze1d' },
+  { minutes: 7 * 24 * 60, key: 'lowStockSnooze7d' },
+] as const
+
+// 低库存告警：列出当前低于阈值的库存，可立即检查或静默单个库存的告警
+export function LowStockAlertsPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+
+  const { data } = useQuery({
+    queryKey: ['lowStockAlerts'],
+    queryFn: () => getLowStockAlerts({ low: true }),
+  })
+  const alerts: InventoryStockAlert[] = data?.data?.items || []
+
+  const checkMutation = useMutation({
+    mutationFn: () => runLowStockCheck(),
+    onSuccess: (res: any) => {
+      const result: LowStockAlertResult | undefined = res?.data
+      toast.success(
+        t.admin.lowStockCheckDone
+          .replace('{low}', String(result?.low ?? 0))
+          .replace('{alerted}', String(result?.alerted?.length ?? 0))
+      )
+      queryClient.invalidateQueries({ queryKey: ['lowStockAlerts'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.lowStockCheckFailed))
+    },
+  })
+
+  const snoozeMutation = useMutation({
+    mutationFn: ({ alert, minutes }: { alert: InventoryStockAlert; minutes: number }) =>
+      minutes > 0
+        ? snoozeLowStockAlert(alert.source, alert.inventory_id, minutes)
+        : unsnoozeLowStockAlert(alert.source, alert.inventory_id),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['lowStockAlerts'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.lowStockSnoozeFailed))
+    },
+  })
+
+  const isSnoozed = (alert: InventoryStockAlert) =>
+    !!alert.snoozed_until && new Date(alert.snoozed_until).getTime() > Date.now()
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <BellRing className="h-4 w-4" />
+            {t.admin.lowStockAlerts}
+          </CardTitle>
+          <CardDescription>{t.admin.lowStockAlertsDesc}</CardDescription>
+        </div>
+        <Button
+          size="sm"
+          variant="outline"
+          disabled={checkMutation.isPending}
+          onClick={() => checkMutation.mutate()}
+        >
+          {t.admin.lowStockCheckNow}
+        </Button>
+      </CardHeader>
+      <CardContent>
+        {alerts.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.admin.lowStockAlertsEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.admin.stockReconciliationInventory}</TableHead>
+                <TableHead>{t.admin.inventorySnapshotAvailable}</TableHead>
+                <TableHead>{t.admin.lowStockSince}</TableHead>
+                <TableHead>{t.admin.lowStockLastAlerted}</TableHead>
+                <TableHead />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {alerts.map((alert) => (
+                <TableRow key={alert.id}>
+                  <TableCell>
+                    <div className="flex items-center gap-2">
+                      <Badge variant="outline">
+                        {alert.source === 'physical'
+                          ? t.admin.inventorySnapshotPhysical
+                          : t.admin.inventorySnapshotVirtual}
+                      </Badge>
+                      {alert.source === 'virtual' ? (
+                        <Link
+                          href={`/admin/inventories/${alert.inventory_id}/virtual`}
+                          className="underline"
+                        >
+                          {alert.name}
+                        </Link>
+                      ) : (
+                        <span>{alert.name}</span>
+                      )}
+                      {alert.sku && (
+                        <span className="font-mono text-xs text-muted-foreground">{alert.sku}</span>
+                      )}
+                    </div>
+                  </TableCell>
+                  <TableCell className="text-red-600">
+                    {t.admin.lowStockAvailableOf
+                      .replace('{available}', String(alert.available))
+                      .replace('{threshold}', String(alert.threshold))}
+                  </TableCell>
+                  <TableCell className="text-sm">
+                    {alert.low_since ? formatDate(alert.low_since) : '-'}
+                  </TableCell>
+                  <TableCell className="text-sm">
+                    {alert.last_alerted_at ? formatDate(alert.last_alerted_at) : '-'}
+                  </TableCell>
+                  <TableCell className="text-right">
+                    {isSnoozed(alert) ? (
+                      <div className="flex items-center justify-end gap-2">
+                        <span className="flex items-center gap-1 text-xs text-muted-foreground">
+                          <BellOff className="h-3 w-3" />
+                          {t.admin.lowStockSnoozedUntil.replace(
+                            '{date}',
+                            formatDate(alert.snoozed_until!)
+                          )}
+                        </span>
+                        <Button
+                          size="sm"
+                          variant="ghost"
+                          disabled={snoozeMutation.isPending}
+                          onClick={() => snoozeMutation.mutate({ alert, minutes: 0 })}
+                        >
+                          {t.admin.lowStockUnsnooze}
+                        </Button>
+                      </div>
+                    ) : (
+                      <div className="flex items-center justify-end gap-1">
+                        <span className="text-xs text-muted-foreground">
+                          {t.admin.lowStockSnooze}:
+                        </span>
+                        {SNOOZE_OPTIONS.map((option) => (
+                          <Button
+                            key={option.minutes}
+                            size="sm"
+                            variant="ghost"
+                            disabled={snoozeMutation.isPending}
+                            onClick={() =>
+                              snoozeMutation.mutate({ alert, minutes: option.minutes })
+                            }
+                          >
+                            {t.admin[option.key]}
+                          </Button>
+                        ))}
+                      </div>
+                    )}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,176 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import { CheckCircle2, Send, XCircle } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { AdminTestMessageChannel, AdminTestMessageResult, sendAdminTestMessage } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const CHANNELS: AdminTestMessageChannel[] = ['email', 'sms', 'webhook', 'push']
+
+// 使用线上配置向各渠道发送测试消息，展示服务商原始响应
+export function MessageTestConsole() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [channel, setChannel] = useState<AdminTestMessageChannel>('email')
+  const [target, setTarget] = useState('')
+  const [phoneCode, setPhoneCode] = useState('')
+  const [secret, setSecret] = useState('')
+  const [result, setResult] = useState<AdminTestMessageResult | null>(null)
+
+  const channelLabels: Record<AdminTestMessageChannel, string> = {
+    email: t.admin.messageConsoleChannelEmail,
+    sms: t.admin.messageConsoleChannelSms,
+    webhook: t.admin.messageConsoleChannelWebhook,
+    push: t.admin.messageConsoleChannelPush,
+  }
+  const targetLabels: Record<AdminTestMessageChannel, string> = {
+    email: t.admin.messageConsoleTargetEmail,
+    sms: t.admin.messageConsoleTargetSms,
+    webhook: t.admin.messageConsoleTargetWebhook,
+    push: t.admin.messageConsoleTargetPush,
+  }
+
+  const sendMutation = useMutation({
+    mutationFn: () =>
+      sendAdminTestMessage({
+        channel,
+        to: target.trim(),
+        phone_code: channel === 'sms' ? phoneCode.trim() || undefined : undefined,
+        secret: channel === 'webhook' ? secret.trim() || undefined : undefined,
+      }),
+    onSuccess: (res: any) => {
+      setResult(res?.data || null)
+    },
+    onError: (error: any) => {
+      setResult(null)
+      toast.error(resolveApiErrorMessage(error, t, t.admin.testFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>{t.admin.messageConsole}</CardTitle>
+        <CardDescription>{t.admin.messageConsoleDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid gap-4 md:grid-cols-2">
+          <div>
+            <Label>{t.admin.messageConsoleChannel}</Label>
+            <Select
+              value={channel}
+              onValueChange={(value) => {
+                setChannel(value as AdminTestMessageChannel)
+                setResult(null)
+              }}
+            >
+              <SelectTrigger className="mt-1.5">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {CHANNELS.map((item) => (
+                  <SelectItem key={item} value={item}>
+                    {channelLabels[item]}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <div>
+            <Label>{targetLabels[channel]}</Label>
+            <Input
+              className="mt-1.5"
+              value={target}
+              onChange={(e) => setTarget(e.target.value)}
+              placeholder={channel === 'webhook' ? 'https://' : undefined}
+            />
+          </div>
+          {channel === 'sms' && (
+            <div>
+              <Label>{t.admin.messageConsolePhoneCode}</Label>
+              <Input
+                className="mt-1.5"
+                value={phoneCode}
+                onChange={(e) => setPhoneCode(e.target.value)}
+                placeholder="+86"
+              />
+            </div>
+          )}
+          {channel === 'webhook' && (
+            <div>
+              <Label>{t.admin.messageConsoleSecret}</Label>
+              <Input
+                className="mt-1.5"
+                type="password"
+                value={secret}
+                onChange={(e) => setSecret(e.target.value)}
+              />
+            </div>
+          )}
+        </div>
+
+        <Button
+          type="button"
+          onClick={() => sendMutation.mutate()}
+          disabled={!target.trim() || sendMutation.isPending}
+        >
+          <Send className="mr-2 h-4 w-4" />
+          {sendMutation.isPending ? t.admin.testing : t.admin.messageConsoleSend}
+        </Button>
+
+        {result && (
+          <div className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center gap-2">
+              {result.success ? (
+                <Badge className="gap-1">
+                  <CheckCircle2 className="h-3.5 w-3.5" />
+                  {t.admin.messageConsoleSuccess}
+                </Badge>
+              ) : (
+                <Badge variant="destructive" className="gap-1">
+                  <XCircle className="h-3.5 w-3.5" />
+                  {t.admin.messageConsoleFailed}
+                </Badge>
+              )}
+              <span className="text-muted-foreground">
+                {t.admin.messageConsoleProvider}: {result.provider || '-'}
+              </span>
+              {result.status_code ? (
+                <span className="text-muted-foreground">
+                  {t.admin.messageConsoleStatus}: {result.status_code}
+                </span>
+              ) : null}
+              <span className="text-muted-foreground">
+                {t.admin.messageConsoleDuration}: {result.duration_ms}ms
+              </span>
+            </div>
+            {result.error && <p className="text-destructive">{result.error}</p>}
+            <div>
+              <Label>{t.admin.messageConsoleResponse}</Label>
+              <pre className="mt-1.5 max-h-64 overflow-auto whitespace-pre-wrap break-all rounded bg-muted p-2 font-mono text-xs">
+                {result.response || t.admin.messageConsoleNoResponse}
+              </pre>
+            </div>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,138 @@
+'use client'
+
+import type { ReactNode } from 'react'
+import { History } from 'lucide-react'
+
+import { AdminOrderActivity, AdminOrderActivityLog } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface ActivityRow {
+  key: string
+  time: string
+  title: ReactNode
+  status?: string
+  failed?: boolean
+}
+
+// 汇总展示订单的退款、发货、发货脚本、邮件/短信和关联工单记录，数据来自 /full 接口
+export function OrderActivityCard({ activity }: { activity: AdminOrderActivity }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const actionLabels: Record<string, string> = {
+    refund: t.admin.orderActivityActionRefund,
+    confirm_refund: t.admin.orderActivityActionConfirmRefund,
+    assign_tracking: t.admin.orderActivityActionAssignTracking,
+    deliver_virtual_stock: t.admin.orderActivityActionDeliverVirtualStock,
+    virtual_delivery_failed: t.admin.orderActivityActionVirtualDeliveryFailed,
+    check_auto_delivery_failed: t.admin.orderActivityActionVirtualDeliveryFailed,
+  }
+  const logRow = (log: AdminOrderActivityLog): ActivityRow => {
+    const detail = log.details?.tracking_no || log.details?.error
+    return {
+      key: `log-${log.id}`,
+      time: log.created_at,
+      title: (
+        <>
+          {actionLabels[log.action] || log.action}
+          {detail ? <span className="ml-2 text-muted-foreground">{String(detail)}</span> : null}
+        </>
+      ),
+      failed: log.action.endsWith('_failed'),
+    }
+  }
+
+  const sections: { title: string; rows: ActivityRow[] }[] = [
+    {
+      title: t.admin.orderActivityRefunds,
+      rows: [
+        ...(activity.refund_requests || []).map((request) => ({
+          key: `refund-request-${request.id}`,
+          time: request.created_at,
+          title: request.reason,
+          status: request.status,
+        })),
+        ...(activity.refund_events || []).map(logRow),
+      ],
+    },
+    { title: t.admin.orderActivityShipments, rows: (activity.shipments || []).map(logRow) },
+    {
+      title: t.admin.orderActivityDeliveries,
+      rows: (activity.delivery_attempts || []).map(logRow),
+    },
+    {
+      title: t.admin.orderActivityEmails,
+      rows: (activity.emails || []).map((email) => ({
+        key: `email-${email.id}`,
+        time: email.created_at,
+        title: email.subject,
+        status: email.status,
+        failed: email.status === 'failed',
+      })),
+    },
+    {
+      title: t.admin.orderActivitySms,
+      rows: (activity.sms || []).map((sms) => ({
+        key: `sms-${sms.id}`,
+        time: sms.created_at,
+        title: `${sms.phone}${sms.event_type ? ` · ${sms.event_type}` : ''}`,
+        status: sms.status,
+        failed: sms.status === 'failed',
+      })),
+    },
+    {
+      title: t.admin.orderActivityTickets,
+      rows: (activity.tickets || []).map((ticket) => ({
+        key: `ticket-${ticket.id}`,
+        time: ticket.created_at,
+        title: `${ticket.ticket_no} · ${ticket.subject}`,
+        status: ticket.status,
+      })),
+    },
+  ]
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <History className="h-4 w-4" />
+          {t.admin.orderActivity}
+        </CardTitle>
+        <CardDescription>{t.admin.orderActivityDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="grid gap-6 md:grid-cols-2">
+        {sections.map((section) => (
+          <div key={section.title} className="space-y-2">
+            <h3 className="flex items-center gap-2 text-sm font-medium">
+              {section.title}
+              {section.rows.length > 0 && <Badge variant="secondary">{section.rows.length}</Badge>}
+            </h3>
+            {section.rows.length === 0 ? (
+              <p className="text-xs text-muted-foreground">{t.admin.orderActivityEmpty}</p>
+            ) : (
+              <ul className="space-y-1.5">
+                {section.rows
+                  .sort((a, b) => b.time.localeCompare(a.time))
+                  .map((row) => (
+                    <li key={row.key} className="flex items-start justify-between gap-3 text-sm">
+                      <div className="min-w-0">
+                        <p className="truncate">{row.title}</p>
+                        <p className="text-xs text-muted-foreground">{formatDate(row.time)}</p>
+                      </div>
+                      {row.status ? (
+                        <Badge variant={row.failed ? 'destructive' : 'outline'}>{row.status}</Badge>
+                      ) : null}
+                    </li>
+                  ))}
+              </ul>
+            )}
+          </div>
+        ))}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,396 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { PackageMinus } from 'lucide-react'
+
+import {
+  OrderRefund,
+  OrderRefundStatus,
+  approveOrderRefund,
+  createAdminOrderPartialRefund,
+  getAdminOrderPartialRefunds,
+  getAdminOrderRefunds,
+  issueOrderRefund,
+  rejectOrderRefund,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate, formatPrice, parseMajorToMinor } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+type RefundAction = 'approve' | 'reject' | 'issue'
+
+interface OrderPartialRefundPanelProps {
+  // 传入订单时展示该订单的全部部分退款并允许发起；否则展示待处理队列
+  order?: {
+    id: number
+    currency: string
+    status: string
+    items?: { sku: string; name: string; quantity: number }[]
+  }
+}
+
+const REFUNDABLE_STATUSES = ['draft', 'pending', 'need_resubmit', 'shipped', 'completed']
+
+// 部分退款：待审核 → 已批准 → 通过付款方式退款，待审核时可拒绝
+export function OrderPartialRefundPanel({ order }: OrderPartialRefundPanelProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [acting, setActing] = useState<{ refund: OrderRefund; action: RefundAction }>()
+  const [note, setNote] = useState('')
+  const [createOpen, setCreateOpen] = useState(false)
+  const [quantities, setQuantities] = useState<Record<number, number>>({})
+  const [amount, setAmount] = useState('')
+  const [reason, setReason] = useState('')
+
+  const orderQuery = useQuery({
+    queryKey: ['adminOrderPartialRefunds', order?.id],
+    queryFn: () => getAdminOrderPartialRefunds(order!.id),
+    enabled: !!order,
+  })
+  const requestedQuery = useQuery({
+    queryKey: ['adminOrderRefunds', 'requested'],
+    queryFn: () => getAdminOrderRefunds({ page: 1, limit: 50, status: 'requested' }),
+    enabled: !order,
+  })
+  const approvedQuery = useQuery({
+    queryKey: ['adminOrderRefunds', 'approved'],
+    queryFn: () => getAdminOrderRefunds({ page: 1, limit: 50, status: 'approved' }),
+    enabled: !order,
+  })
+  const refunds: OrderRefund[] = order
+    ? orderQuery.data?.data?.items || []
+    : [...(requestedQuery.data?.data?.items || []), ...(approvedQuery.data?.data?.items || [])]
+
+  const statusLabels: Record<OrderRefundStatus, string> = {
+    requested: t.admin.partialRefundStatusRequested,
+    approved: t.admin.partialRefundStatusApproved,
+    refunded: t.admin.partialRefundStatusRefunded,
+    rejected: t.admin.partialRefundStatusRejected,
+  }
+
+  const invalidate = () => {
+    queryClient.invalidateQueries({ queryKey: ['adminOrderPartialRefunds'] })
+    queryClient.invalidateQueries({ queryKey: ['adminOrderRefunds'] })
+    queryClient.invalidateQueries({ queryKey: ['adminOrders'] })
+    queryClient.invalidateQueries({ queryKey: ['adminOrderDetail'] })
+  }
+
+  const closeAction = () => {
+    setActing(undefined)
+    setNote('')
+  }
+
+  const actionMutation = useMutation({
+    mutationFn: ({ refund, action }: { refund: OrderRefund; action: RefundAction }) => {
+      if (action === 'approve') return approveOrderRefund(refund.id, note.trim())
+      if (action === 'reject') return rejectOrderRefund(refund.id, note.trim())
+      return issueOrderRefund(refund.id)
+    },
+    onSuccess: (_res, { action }) => {
+      const messages: Record<RefundAction, string> = {
+        approve: t.admin.partialRefundApproved,
+        reject: t.admin.partialRefundRejected,
+        issue: t.admin.partialRefundIssued,
+      }
+      toast.success(messages[action])
+      closeAction()
+      invalidate()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.partialRefundActionFailed))
+    },
+  })
+
+  const selected = Object.entries(quantities)
+    .filter(([, quantity]) => quantity > 0)
+    .map(([index, quantity]) => ({ item_index: Number(index), quantity }))
+  const amountMinor = amount.trim() ? parseMajorToMinor(amount.trim()) : 0
+
+  const resetCreate = () => {
+    setCreateOpen(false)
+    setQuantities({})
+    setAmount('')
+    setReason('')
+  }
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      createAdminOrderPartialRefund(order!.id, {
+        items: selected,
+        amount_minor: amountMinor || 0,
+        reason: reason.trim(),
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.partialRefundCreated)
+      resetCreate()
+      invalidate()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.partialRefundCreateFailed))
+    },
+  })
+
+  const canCreate = !!order && REFUNDABLE_STATUSES.includes(order.status)
+  if (refunds.length === 0 && !canCreate) {
+    return null
+  }
+
+  const actionTitles: Record<RefundAction, string> = {
+    approve: t.admin.partialRefundApproveTitle,
+    reject: t.admin.partialRefundRejectTitle,
+    issue: t.admin.partialRefundIssueTitle,
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <PackageMinus className="h-4 w-4" />
+          {order ? t.admin.partialRefundTitle : t.admin.partialRefundQueue}
+          {!order ? <Badge variant="secondary">{refunds.length}</Badge> : null}
+        </CardTitle>
+        <CardDescription>
+          {order ? t.admin.partialRefundDesc : t.admin.partialRefundQueueDesc}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {refunds.length > 0 ? (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                {!order ? <TableHead>{t.admin.orderNo}</TableHead> : null}
+                <TableHead>{t.admin.partialRefundItems}</TableHead>
+                <TableHead>{t.admin.partialRefundAmount}</TableHead>
+                <TableHead>{t.admin.partialRefundReason}</TableHead>
+                <TableHead>{t.admin.partialRefundStatus}</TableHead>
+                <TableHead>{t.admin.partialRefundCreatedAt}</TableHead>
+                <TableHead />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {refunds.map((refund) => (
+                <TableRow key={refund.id}>
+                  {!order ? (
+                    <TableCell className="font-mono text-xs">
+                      <Link href={`/admin/orders/${refund.order_id}`} className="hover:underline">
+                        {refund.order_no}
+                      </Link>
+                    </TableCell>
+                  ) : null}
+                  <TableCell className="text-sm">
+                    {refund.items.map((item) => (
+                      <div key={item.item_index}>
+                        {item.name} ({item.sku}) × {item.quantity}
+                      </div>
+                    ))}
+                  </TableCell>
+                  <TableCell className="text-sm">
+                    {formatPrice(refund.amount_minor, refund.currency)}
+                  </TableCell>
+                  <TableCell className="max-w-[260px] whitespace-pre-wrap break-words text-sm">
+                    {refund.reason}
+                    {refund.review_note ? (
+                      <div className="mt-1 text-xs text-muted-foreground">{refund.review_note}</div>
+                    ) : null}
+                  </TableCell>
+                  <TableCell>
+                    <Badge variant={refund.status === 'rejected' ? 'destructive' : 'outline'}>
+                      {statusLabels[refund.status]}
+                    </Badge>
+                    {refund.payment_pending ? (
+                      <div className="mt-1 text-xs text-muted-foreground">
+                        {t.admin.partialRefundPaymentPending}
+                      </div>
+                    ) : null}
+                  </TableCell>
+                  <TableCell className="text-sm">{formatDate(refund.created_at)}</TableCell>
+                  <TableCell className="text-right">
+                    <div className="flex justify-end gap-1">
+                      {refund.status === 'requested' ? (
+                        <>
+                          <Button
+                            size="sm"
+                            onClick={() => setActing({ refund, action: 'approve' })}
+                          >
+                            {t.admin.partialRefundApprove}
+                          </Button>
+                          <Button
+                            size="sm"
+                            variant="outline"
+                            onClick={() => setActing({ refund, action: 'reject' })}
+                          >
+                            {t.admin.partialRefundReject}
+                          </Button>
+                        </>
+                      ) : null}
+                      {refund.status === 'approved' ? (
+                        <Button size="sm" onClick={() => setActing({ refund, action: 'issue' })}>
+                          {t.admin.partialRefundIssue}
+                        </Button>
+                      ) : null}
+                    </div>
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        ) : null}
+
+        {canCreate ? (
+          <Button variant="outline" size="sm" onClick={() => setCreateOpen(true)}>
+            {t.admin.partialRefundCreate}
+          </Button>
+        ) : null}
+      </CardContent>
+
+      <Dialog open={!!acting} onOpenChange={(open) => !open && closeAction()}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>{acting ? actionTitles[acting.action] : null}</DialogTitle>
+            <DialogDescription>
+              {acting
+                ? t.admin.partialRefundActionDesc
+                    .replace('{orderNo}', acting.refund.order_no)
+                    .replace(
+                      '{amount}',
+                      formatPrice(acting.refund.amount_minor, acting.refund.currency)
+                    )
+                : null}
+            </DialogDescription>
+          </DialogHeader>
+          {acting && acting.action !== 'issue' ? (
+            <div className="space-y-1.5">
+              <Label>
+                {t.admin.partialRefundNote}
+                {acting.action === 'reject' ? ' *' : ''}
+              </Label>
+              <Textarea
+                value={note}
+                onChange={(e) => setNote(e.target.value)}
+                maxLength={1000}
+                rows={3}
+              />
+            </div>
+          ) : null}
+          <DialogFooter>
+            <Button variant="outline" onClick={closeAction}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={acting?.action === 'reject' ? 'destructive' : 'default'}
+              disabled={actionMutation.isPending || (acting?.action === 'reject' && !note.trim())}
+              onClick={() => acting && actionMutation.mutate(acting)}
+            >
+              {actionMutation.isPending ? t.common.processing : t.common.confirm}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {order ? (
+        <Dialog open={createOpen} onOpenChange={(open) => !open && resetCreate()}>
+          <DialogContent>
+            <DialogHeader>
+              <DialogTitle>{t.admin.partialRefundCreate}</DialogTitle>
+              <DialogDescription>{t.admin.partialRefundCreateDesc}</DialogDescription>
+            </DialogHeader>
+            <div className="space-y-4">
+              <div className="space-y-2">
+                <Label>{t.admin.partialRefundItems} *</Label>
+                {(order.items || []).map((item, index) => (
+                  <div
+                    key={`${item.sku}-${index}`}
+                    className="flex items-center justify-between gap-3"
+                  >
+                    <div className="min-w-0 text-sm">
+                      <div className="truncate">{item.name}</div>
+                      <div className="text-xs text-muted-foreground">
+                        {item.sku} × {item.quantity}
+                      </div>
+                    </div>
+                    <Input
+                      type="number"
+                      min={0}
+                      max={item.quantity}
+                      className="w-20"
+                      value={quantities[index] ?? 0}
+                      onChange={(e) => {
+                        const value = Math.min(
+                          Math.max(parseInt(e.target.value, 10) || 0, 0),
+                          item.quantity
+                        )
+                        setQuantities((prev) => ({ ...prev, [index]: value }))
+                      }}
+                    />
+                  </div>
+                ))}
+              </div>
+              <div className="space-y-1.5">
+                <Label>{t.admin.partialRefundAmount}</Label>
+                <Input
+                  inputMode="decimal"
+                  value={amount}
+                  onChange={(e) => setAmount(e.target.value)}
+                  placeholder={t.admin.partialRefundAmountPlaceholder}
+                />
+              </div>
+              <div className="space-y-1.5">
+                <Label>{t.admin.partialRefundReason} *</Label>
+                <Textarea
+                  value={reason}
+                  onChange={(e) => setReason(e.target.value)}
+                  maxLength={1000}
+                  rows={3}
+                />
+              </div>
+            </div>
+            <DialogFooter>
+              <Button variant="outline" onClick={resetCreate}>
+                {t.common.cancel}
+              </Button>
+              <Button
+                disabled={
+                  createMutation.isPending ||
+                  selected.length === 0 ||
+                  !reason.trim() ||
+                  amountMinor === null
+                }
+                onClick={() => createMutation.mutate()}
+              >
+                {createMutation.isPending ? t.common.processing : t.common.confirm}
+              </Button>
+            </DialogFooter>
+          </DialogContent>
+        </Dialog>
+      ) : null}
+    </Card>
+  )
+}
@@ -0,0 +1,175 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import { Copy, Link2, Loader2 } from 'lucide-react'
+
+import { createAdminOrderPaymentLink, PaymentLinkResult } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+  DialogTrigger,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+// 为待付款订单生成免登录付款链接，可选通过邮件或短信发送给买家
+export function OrderPaymentLinkDialog({ orderId }: { orderId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const [open, setOpen] = useState(false)
+  const [sendEmail, setSendEmail] = useState(false)
+  const [sendSms, setSendSms] = useState(false)
+  const [email, setEmail] = useState('')
+  const [phoneCode, setPhoneCode] = useState('')
+  const [phone, setPhone] = useState('')
+  const [link, setLink] = useState<PaymentLinkResult | null>(null)
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      createAdminOrderPaymentLink(orderId, {
+        send_email: sendEmail,
+        send_sms: sendSms,
+        email: sendEmail ? email.trim() : undefined,
+        phone: sendSms ? phone.trim() : undefined,
+        phone_code: sendSms ? phoneCode.trim() : undefined,
+      }),
+    onSuccess: (response: any) => {
+      const result = response?.data as PaymentLinkResult
+      setLink(result)
+      toast.success(t.paymentLink.created)
+      if (sendEmail) {
+        if (result?.email_sent) {
+          toast.success(t.paymentLink.emailSent)
+        } else {
+          toast.error(t.paymentLink.emailNotSent)
+        }
+      }
+      if (sendSms) {
+        if (result?.sms_sent) {
+          toast.success(t.paymentLink.smsSent)
+        } else {
+          toast.error(t.paymentLink.smsNotSent)
+        }
+      }
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.paymentLink.createFailed))
+    },
+  })
+
+  const handleCopy = async () => {
+    if (!link?.url || typeof navigator === 'undefined' || !navigator.clipboard) return
+    await navigator.clipboard.writeText(link.url)
+    toast.success(t.paymentLink.copied)
+  }
+
+  return (
+    <Dialog
+      open={open}
+      onOpenChange={(next) => {
+        setOpen(next)
+        if (!next) setLink(null)
+      }}
+    >
+      <DialogTrigger asChild>
+        <Button variant="outline">
+          <Link2 className="mr-2 h-4 w-4" />
+          {t.paymentLink.create}
+        </Button>
+      </DialogTrigger>
+      <DialogContent>
+        <DialogHeader>
+          <DialogTitle>{t.paymentLink.dialogTitle}</DialogTitle>
+          <DialogDescription>{t.paymentLink.dialogDesc}</DialogDescription>
+        </DialogHeader>
+        <div className="space-y-4 py-2">
+          <div className="space-y-2">
+            <div className="flex items-center gap-2">
+              <Checkbox
+                id="payment_link_send_email"
+                checked={sendEmail}
+                onCheckedChange={(checked) => setSendEmail(checked === true)}
+              />
+              <Label htmlFor="payment_link_send_email">{t.paymentLink.sendEmail}</Label>
+            </div>
+            {sendEmail && (
+              <Input
+                type="email"
+                placeholder={t.paymentLink.emailPlaceholder}
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+              />
+            )}
+          </div>
+          <div className="space-y-2">
+            <div className="flex items-center gap-2">
+              <Checkbox
+                id="payment_link_send_sms"
+                checked={sendSms}
+                onCheckedChange={(checked) => setSendSms(checked === true)}
+              />
+              <Label htmlFor="payment_link_send_sms">{t.paymentLink.sendSms}</Label>
+            </div>
+            {sendSms && (
+              <div className="flex gap-2">
+                <Input
+                  className="w-24"
+                  placeholder="+1"
+                  value={phoneCode}
+                  onChange={(e) => setPhoneCode(e.target.value)}
+                />
+                <Input
+                  type="tel"
+                  placeholder={t.paymentLink.phonePlaceholder}
+                  value={phone}
+                  onChange={(e) => setPhone(e.target.value)}
+                />
+              </div>
+            )}
+          </div>
+          {link && (
+            <div className="space-y-2 rounded-md border bg-muted/40 p-3">
+              <Label>{t.paymentLink.link}</Label>
+              <div className="flex gap-2">
+                <Input readOnly value={link.url} onFocus={(e) => e.target.select()} />
+                <Button
+                  variant="outline"
+                  size="icon"
+                  onClick={handleCopy}
+                  title={t.paymentLink.copy}
+                >
+                  <Copy className="h-4 w-4" />
+                </Button>
+              </div>
+              <p className="text-xs text-muted-foreground">
+                {t.paymentLink.expiresAt.replace('{time}', formatDate(link.expires_at))}
+              </p>
+            </div>
+          )}
+        </div>
+        <DialogFooter>
+          <Button variant="outline" onClick={() => setOpen(false)}>
+            {t.common.close}
+          </Button>
+          <Button disabled={createMutation.isPending} onClick={() => createMutation.mutate()}>
+            {createMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            {createMutation.isPending ? t.paymentLink.generating : t.paymentLink.generate}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  )
+}
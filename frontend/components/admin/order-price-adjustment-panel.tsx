@@ -0,0 +1,384 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { BadgePercent } from 'lucide-react'
+
+import {
+  OrderPriceAdjustment,
+  OrderPriceAdjustmentStatus,
+  OrderPriceAdjustmentType,
+  approvePriceAdjustment,
+  createOrderPriceAdjustment,
+  getOrderPriceAdjustments,
+  getPriceAdjustments,
+  rejectPriceAdjustment,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate, formatPrice, parseMajorToMinor } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+type AdjustmentAction = 'approve' | 'reject'
+
+interface OrderPriceAdjustmentPanelProps {
+  // 传入订单时展示该订单的改价记录并允许发起；否则展示待批准队列
+  order?: {
+    id: number
+    currency: string
+    status: string
+  }
+}
+
+// 订单改价：未超过审批阈值立即生效，否则等待另一名管理员批准或拒绝
+export function OrderPriceAdjustmentPanel({ order }: OrderPriceAdjustmentPanelProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [acting, setActing] = useState<{
+    adjustment: OrderPriceAdjustment
+    action: AdjustmentAction
+  }>()
+  const [note, setNote] = useState('')
+  const [createOpen, setCreateOpen] = useState(false)
+  const [type, setType] = useState<OrderPriceAdjustmentType>('discount')
+  const [amount, setAmount] = useState('')
+  const [reason, setReason] = useState('')
+
+  const orderQuery = useQuery({
+    queryKey: ['adminOrderPriceAdjustments', order?.id],
+    queryFn: () => getOrderPriceAdjustments(order!.id),
+    enabled: !!order,
+  })
+  const pendingQuery = useQuery({
+    queryKey: ['adminPriceAdjustments', 'pending'],
+    queryFn: () => getPriceAdjustments({ page: 1, limit: 50, status: 'pending' }),
+    enabled: !order,
+  })
+  const adjustments: OrderPriceAdjustment[] = order
+    ? orderQuery.data?.data?.items || []
+    : pendingQuery.data?.data?.items || []
+
+  const statusLabels: Record<OrderPriceAdjustmentStatus, string> = {
+    pending: t.admin.priceAdjustmentStatusPending,
+    applied: t.admin.priceAdjustmentStatusApplied,
+    rejected: t.admin.priceAdjustmentStatusRejected,
+  }
+  const typeLabels: Record<OrderPriceAdjustmentType, string> = {
+    discount: t.admin.priceAdjustmentTypeDiscount,
+    override: t.admin.priceAdjustmentTypeOverride,
+  }
+
+  const invalidate = () => {
+    queryClient.invalidateQueries({ queryKey: ['adminOrderPriceAdjustments'] })
+    queryClient.invalidateQueries({ queryKey: ['adminPriceAdjustments'] })
+    queryClient.invalidateQueries({ queryKey: ['adminOrders'] })
+    queryClient.invalidateQueries({ queryKey: ['adminOrderDetail'] })
+  }
+
+  const closeAction = () => {
+    setActing(undefined)
+    setNote('')
+  }
+
+  const actionMutation = useMutation({
+    mutationFn: ({
+      adjustment,
+      action,
+    }: {
+      adjustment: OrderPriceAdjustment
+      action: AdjustmentAction
+    }) =>
+      action === 'approve'
+        ? approvePriceAdjustment(adjustment.id, note.trim())
+        : rejectPriceAdjustment(adjustment.id, note.trim()),
+    onSuccess: (_res, { action }) => {
+      toast.success(
+        action === 'approve' ? t.admin.priceAdjustmentApproved : t.admin.priceAdjustmentRejected
+      )
+      closeAction()
+      invalidate()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.priceAdjustmentActionFailed))
+    },
+  })
+
+  const amountMinor = amount.trim() ? parseMajorToMinor(amount.trim()) : null
+
+  const resetCreate = () => {
+    setCreateOpen(false)
+    setType('discount')
+    setAmount('')
+    setReason('')
+  }
+
+  const createMutation = useMutation({
+    mutationFn: () =>
+      createOrderPriceAdjustment(order!.id, {
+        type,
+        ...(type === 'discount'
+          ? { amount_minor: amountMinor ?? 0 }
+          : { total_amount_minor: amountMinor ?? 0 }),
+        reason: reason.trim(),
+      }),
+    onSuccess: (response: any) => {
+      toast.success(
+        response?.data?.status === 'pending'
+          ? t.admin.priceAdjustmentRequested
+          : t.admin.priceAdjustmentApplied
+      )
+      resetCreate()
+      invalidate()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.priceAdjustmentCreateFailed))
+    },
+  })
+
+  const canCreate = !!order && order.status === 'pending_payment'
+  if (adjustments.length === 0 && !canCreate) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <BadgePercent className="h-4 w-4" />
+          {order ? t.admin.priceAdjustmentTitle : t.admin.priceAdjustmentQueue}
+          {!order ? <Badge variant="secondary">{adjustments.length}</Badge> : null}
+        </CardTitle>
+        <CardDescription>
+          {order ? t.admin.priceAdjustmentDesc : t.admin.priceAdjustmentQueueDesc}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {adjustments.length > 0 ? (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                {!order ? <TableHead>{t.admin.orderNo}</TableHead> : null}
+                <TableHead>{t.admin.priceAdjustmentType}</TableHead>
+                <TableHead>{t.admin.priceAdjustmentChange}</TableHead>
+                <TableHead>{t.admin.priceAdjustmentReason}</TableHead>
+                <TableHead>{t.admin.priceAdjustmentStatus}</TableHead>
+                <TableHead>{t.admin.priceAdjustmentCreatedAt}</TableHead>
+                <TableHead />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {adjustments.map((adjustment) => (
+                <TableRow key={adjustment.id}>
+                  {!order ? (
+                    <TableCell className="font-mono text-xs">
+                      <Link
+                        href={`/admin/orders/${adjustment.order_id}`}
+                        className="hover:underline"
+                      >
+                        {adjustment.order_no}
+                      </Link>
+                    </TableCell>
+                  ) : null}
+                  <TableCell className="text-sm">{typeLabels[adjustment.type]}</TableCell>
+                  <TableCell className="whitespace-nowrap text-sm">
+                    {formatPrice(adjustment.total_before_minor, adjustment.currency)} →{' '}
+                    {formatPrice(adjustment.total_after_minor, adjustment.currency)}
+                  </TableCell>
+                  <TableCell className="max-w-[260px] whitespace-pre-wrap break-words text-sm">
+                    {adjustment.reason}
+                    <div className="mt-1 text-xs text-muted-foreground">
+                      {t.admin.priceAdjustmentRequestedBy.replace(
+                        '{id}',
+                        String(adjustment.requested_by)
+                      )}
+                    </div>
+                    {adjustment.review_note ? (
+                      <div className="mt-1 text-xs text-muted-foreground">
+                        {adjustment.review_note}
+                      </div>
+                    ) : null}
+                  </TableCell>
+                  <TableCell>
+                    <Badge variant={adjustment.status === 'rejected' ? 'destructive' : 'outline'}>
+                      {statusLabels[adjustment.status]}
+                    </Badge>
+                  </TableCell>
+                  <TableCell className="text-sm">{formatDate(adjustment.created_at)}</TableCell>
+                  <TableCell className="text-right">
+                    {adjustment.status === 'pending' ? (
+                      <div className="flex justify-end gap-1">
+                        <Button
+                          size="sm"
+                          onClick={() => setActing({ adjustment, action: 'approve' })}
+                        >
+                          {t.admin.priceAdjustmentApprove}
+                        </Button>
+                        <Button
+                          size="sm"
+                          variant="outline"
+                          onClick={() => setActing({ adjustment, action: 'reject' })}
+                        >
+                          {t.admin.priceAdjustmentReject}
+                        </Button>
+                      </div>
+                    ) : null}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        ) : null}
+
+        {canCreate ? (
+          <Button variant="outline" size="sm" onClick={() => setCreateOpen(true)}>
+            {t.admin.priceAdjustmentCreate}
+          </Button>
+        ) : null}
+      </CardContent>
+
+      <Dialog open={!!acting} onOpenChange={(open) => !open && closeAction()}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {acting?.action === 'reject'
+                ? t.admin.priceAdjustmentRejectTitle
+                : t.admin.priceAdjustmentApproveTitle}
+            </DialogTitle>
+            <DialogDescription>
+              {acting
+                ? t.admin.priceAdjustmentActionDesc
+                    .replace('{orderNo}', acting.adjustment.order_no)
+                    .replace(
+                      '{before}',
+                      formatPrice(acting.adjustment.total_before_minor, acting.adjustment.currency)
+                    )
+                    .replace(
+                      '{after}',
+                      formatPrice(acting.adjustment.total_after_minor, acting.adjustment.currency)
+                    )
+                : null}
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-1.5">
+            <Label>
+              {t.admin.priceAdjustmentNote}
+              {acting?.action === 'reject' ? ' *' : ''}
+            </Label>
+            <Textarea
+              value={note}
+              onChange={(e) => setNote(e.target.value)}
+              maxLength={1000}
+              rows={3}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={closeAction}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={acting?.action === 'reject' ? 'destructive' : 'default'}
+              disabled={actionMutation.isPending || (acting?.action === 'reject' && !note.trim())}
+              onClick={() => acting && actionMutation.mutate(acting)}
+            >
+              {actionMutation.isPending ? t.common.processing : t.common.confirm}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      {order ? (
+        <Dialog open={createOpen} onOpenChange={(open) => !open && resetCreate()}>
+          <DialogContent>
+            <DialogHeader>
+              <DialogTitle>{t.admin.priceAdjustmentCreate}</DialogTitle>
+              <DialogDescription>{t.admin.priceAdjustmentCreateDesc}</DialogDescription>
+            </DialogHeader>
+            <div className="space-y-4">
+              <div className="space-y-1.5">
+                <Label>{t.admin.priceAdjustmentType}</Label>
+                <Select
+                  value={type}
+                  onValueChange={(value) => setType(value as OrderPriceAdjustmentType)}
+                >
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="discount">{t.admin.priceAdjustmentTypeDiscount}</SelectItem>
+                    <SelectItem value="override">{t.admin.priceAdjustmentTypeOverride}</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-1.5">
+                <Label>
+                  {type === 'discount'
+                    ? t.admin.priceAdjustmentDiscountAmount
+                    : t.admin.priceAdjustmentNewTotal}{' '}
+                  ({order.currency}) *
+                </Label>
+                <Input
+                  inputMode="decimal"
+                  value={amount}
+                  onChange={(e) => setAmount(e.target.value)}
+                />
+              </div>
+              <div className="space-y-1.5">
+                <Label>{t.admin.priceAdjustmentReason} *</Label>
+                <Textarea
+                  value={reason}
+                  onChange={(e) => setReason(e.target.value)}
+                  maxLength={1000}
+                  rows={3}
+                />
+              </div>
+            </div>
+            <DialogFooter>
+              <Button variant="outline" onClick={resetCreate}>
+                {t.common.cancel}
+              </Button>
+              <Button
+                disabled={createMutation.isPending || amountMinor === null || !reason.trim()}
+                onClick={() => createMutation.mutate()}
+              >
+                {createMutation.isPending ? t.common.processing : t.common.confirm}
+              </Button>
+            </DialogFooter>
+          </DialogContent>
+        </Dialog>
+      ) : null}
+    </Card>
+  )
+}
@@ -0,0 +1,129 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+
+import { PaymentMethod, updatePaymentMethodFee } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatPaymentFeeRule } from '@/lib/payment-fee'
+import { adminPaymentMethodsQueryKey } from '@/lib/payment-queries'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+
+interface PaymentMethodFeeDialogProps {
+  method: PaymentMethod | null
+  currency: string
+  onClose: () => void
+}
+
+// 付款方式手续费规则：比例（可为负数表示优惠）+ 固定金额，选择付款方式时计入订单金额
+export function PaymentMethodFeeDialog({ method, currency, onClose }: PaymentMethodFeeDialogProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [ratePercent, setRatePercent] = useState('0')
+  const [fixedAmount, setFixedAmount] = useState('0')
+  const [retainedOnRefund, setRetainedOnRefund] = useState(false)
+
+  useEffect(() => {
+    if (!method) return
+    setRatePercent(String((method.fee_rate || 0) / 100))
+    setFixedAmount(String((method.fee_fixed_minor || 0) / 100))
+    setRetainedOnRefund(!!method.fee_retained_on_refund)
+  }, [method])
+
+  const feeRate = Math.round((parseFloat(ratePercent) || 0) * 100)
+  const feeFixedMinor = Math.round((parseFloat(fixedAmount) || 0) * 100)
+  const preview = formatPaymentFeeRule(feeRate, feeFixedMinor, currency)
+
+  const saveMutation = useMutation({
+    mutationFn: () =>
+      updatePaymentMethodFee(method!.id, {
+        fee_rate: feeRate,
+        fee_fixed_minor: feeFixedMinor,
+        fee_retained_on_refund: retainedOnRefund,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.pmFeeUpdated)
+      queryClient.invalidateQueries({ queryKey: adminPaymentMethodsQueryKey })
+      onClose()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.pmFeeUpdateFailed))
+    },
+  })
+
+  return (
+    <Dialog open={!!method} onOpenChange={(open) => !open && onClose()}>
+      <DialogContent>
+        <DialogHeader>
+          <DialogTitle>{t.admin.pmFeeTitle.replace('{name}', method?.name || '')}</DialogTitle>
+          <DialogDescription>{t.admin.pmFeeDesc}</DialogDescription>
+        </DialogHeader>
+        <div className="space-y-4">
+          <div className="grid gap-4 sm:grid-cols-2">
+            <div className="space-y-1.5">
+              <Label htmlFor="pm-fee-rate">{t.admin.pmFeeRate}</Label>
+              <Input
+                id="pm-fee-rate"
+                type="number"
+                step="0.01"
+                min="-100"
+                max="100"
+                value={ratePercent}
+                onChange={(e) => setRatePercent(e.target.value)}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label htmlFor="pm-fee-fixed">{t.admin.pmFeeFixed}</Label>
+              <Input
+                id="pm-fee-fixed"
+                type="number"
+                step="0.01"
+                value={fixedAmount}
+                onChange={(e) => setFixedAmount(e.target.value)}
+              />
+            </div>
+          </div>
+          <p className="text-xs text-muted-foreground">{t.admin.pmFeeHint}</p>
+          <div className="flex items-center justify-between gap-4 rounded-md border p-3">
+            <div className="space-y-0.5">
+              <Label htmlFor="pm-fee-retained">{t.admin.pmFeeRetainedOnRefund}</Label>
+              <p className="text-xs text-muted-foreground">{t.admin.pmFeeRetainedOnRefundHint}</p>
+            </div>
+            <Switch
+              id="pm-fee-retained"
+              checked={retainedOnRefund}
+              onCheckedChange={setRetainedOnRefund}
+            />
+          </div>
+          <p className="text-sm">
+            {t.admin.pmFeePreview}:{' '}
+            <span className="font-medium">{preview || t.admin.pmFeeNone}</span>
+          </p>
+        </div>
+        <DialogFooter>
+          <Button variant="outline" onClick={onClose}>
+            {t.common.cancel}
+          </Button>
+          <Button disabled={saveMutation.isPending} onClick={() => saveMutation.mutate()}>
+            {saveMutation.isPending ? t.common.processing : t.common.save}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  )
+}
@@ -0,0 +1,586 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Calculator, Pencil, Plus, Tags, Trash2 } from 'lucide-react'
+
+import {
+  PricingEvaluation,
+  PricingRule,
+  PricingRulePayload,
+  PricingTraceEntry,
+  createPricingRule,
+  deletePricingRule,
+  getPricingRules,
+  simulatePricingRules,
+  updatePricingRule,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatCurrency, formatDate, minorToMajor, parseMajorToMinor } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+
+interface PricingRuleForm {
+  name: string
+  description: string
+  type: PricingRule['type']
+  value: string
+  max_discount: string
+  min_amount: string
+  scope: PricingRule['scope']
+  targets: string
+  priority: string
+  exclusive: boolean
+  enabled: boolean
+  starts_at: string
+  expires_at: string
+}
+
+const EMPTY_FORM: PricingRuleForm = {
+  name: '',
+  description: '',
+  type: 'percentage',
+  value: '',
+  max_discount: '',
+  min_amount: '',
+  scope: 'all',
+  targets: '',
+  priority: '0',
+  exclusive: false,
+  enabled: true,
+  starts_at: '',
+  expires_at: '',
+}
+
+// ISO 时间转换为 datetime-local 输入框格式（本地时区）
+function toLocalInput(value?: string) {
+  if (!value) return ''
+  const date = new Date(value)
+  const pad = (n: number) => String(n).padStart(2, '0')
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}T${pad(date.getHours())}:${pad(date.getMinutes())}`
+}
+
+function toForm(rule: PricingRule): PricingRuleForm {
+  const targets = rule.scope === 'sku' ? rule.skus : rule.categories
+  return {
+    name: rule.name,
+    description: rule.description || '',
+    type: rule.type,
+    value: String(minorToMajor(rule.value_minor)),
+    max_discount: rule.max_discount_minor ? String(minorToMajor(rule.max_discount_minor)) : '',
+    min_amount: rule.min_amount_minor ? String(minorToMajor(rule.min_amount_minor)) : '',
+    scope: rule.scope,
+    targets: (targets || []).join(', '),
+    priority: String(rule.priority),
+    exclusive: rule.exclusive,
+    enabled: rule.enabled,
+    starts_at: toLocalInput(rule.starts_at),
+    expires_at: toLocalInput(rule.expires_at),
+  }
+}
+
+// 比例规则输入百分比，换算为基点后与金额共用两位小数的转换
+function toPayload(form: PricingRuleForm): PricingRulePayload | null {
+  const valueMinor = parseMajorToMinor(form.value)
+  const maxDiscountMinor = parseMajorToMinor(form.max_discount || '0')
+  const minAmountMinor = parseMajorToMinor(form.min_amount || '0')
+  if (valueMinor === null || maxDiscountMinor === null || minAmountMinor === null) {
+    return null
+  }
+  const targets = form.targets
+    .split(/[,\n]/)
+    .map((item) => item.trim())
+    .filter(Boolean)
+  return {
+    name: form.name,
+    description: form.description,
+    type: form.type,
+    value_minor: valueMinor,
+    max_discount_minor: form.type === 'percentage' ? maxDiscountMinor : 0,
+    min_amount_minor: minAmountMinor,
+    scope: form.scope,
+    skus: form.scope === 'sku' ? targets : [],
+    categories: form.scope === 'category' ? targets : [],
+    priority: Number(form.priority || 0),
+    exclusive: form.exclusive,
+    enabled: form.enabled,
+    starts_at: form.starts_at ? new Date(form.starts_at).toISOString() : null,
+    expires_at: form.expires_at ? new Date(form.expires_at).toISOString() : null,
+  }
+}
+
+// 每行 "SKU:数量"，数量缺省为 1
+function parseSimulationItems(text: string) {
+  return text
+    .split('\n')
+    .map((line) => line.trim())
+    .filter(Boolean)
+    .map((line) => {
+      const [sku, quantity] = line.split(':')
+      const count = Number(quantity || 1) || 1
+      return { sku: sku.trim(), quantity: Math.max(count, 1) }
+    })
+}
+
+// 定价规则：按优先级叠加的折扣、立减与满减，可模拟下单查看每条规则的计算过程
+export function PricingRulesPanel({ canEdit }: { canEdit: boolean }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [showForm, setShowForm] = useState(false)
+  const [editingId, setEditingId] = useState<number | null>(null)
+  const [form, setForm] = useState<PricingRuleForm>(EMPTY_FORM)
+  const [deleting, setDeleting] = useState<PricingRule | null>(null)
+  const [simulationItems, setSimulationItems] = useState('')
+  const [simulationPromo, setSimulationPromo] = useState('')
+  const [evaluation, setEvaluation] = useState<PricingEvaluation | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['pricingRules'],
+    queryFn: getPricingRules,
+  })
+  const rules: PricingRule[] = data?.data || []
+
+  const updateForm = <K extends keyof PricingRuleForm>(field: K, value: PricingRuleForm[K]) =>
+    setForm((prev) => ({ ...prev, [field]: value }))
+
+  const closeForm = () => {
+    setShowForm(false)
+    setEditingId(null)
+    setForm(EMPTY_FORM)
+  }
+
+  const saveMutation = useMutation({
+    mutationFn: () => {
+      const payload = toPayload(form)
+      if (!payload) {
+        throw new Error(t.order.invalidPrice)
+      }
+      return editingId ? updatePricingRule(editingId, payload) : createPricingRule(payload)
+    },
+    onSuccess: () => {
+      toast.success(t.promoCode.pricingRuleSaved)
+      closeForm()
+      queryClient.invalidateQueries({ queryKey: ['pricingRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.promoCode.pricingRuleSaveFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deletePricingRule(id),
+    onSuccess: () => {
+      toast.success(t.promoCode.pricingRuleDeleted)
+      setDeleting(null)
+      queryClient.invalidateQueries({ queryKey: ['pricingRules'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.promoCode.pricingRuleDeleteFailed))
+    },
+  })
+
+  const simulateMutation = useMutation({
+    mutationFn: () =>
+      simulatePricingRules({
+        items: parseSimulationItems(simulationItems),
+        promo_code: simulationPromo.trim() || undefined,
+      }),
+    onSuccess: (res: any) => setEvaluation(res?.data || null),
+    onError: (error: unknown) => {
+      setEvaluation(null)
+      toast.error(resolveApiErrorMessage(error, t, t.promoCode.pricingSimulateFailed))
+    },
+  })
+
+  const ruleValue = (rule: PricingRule) => {
+    if (rule.type === 'percentage') {
+      return `${minorToMajor(rule.value_minor)}%`
+    }
+    const value = formatCurrency(rule.value_minor)
+    return rule.type === 'threshold'
+      ? t.promoCode.pricingThresholdValue
+          .replace('{min}', formatCurrency(rule.min_amount_minor))
+          .replace('{value}', value)
+      : value
+  }
+
+  const ruleScope = (rule: PricingRule) => {
+    if (rule.scope === 'sku') return `SKU: ${(rule.skus || []).join(', ')}`
+    if (rule.scope === 'category') {
+      return `${t.promoCode.pricingScopeCategory}: ${(rule.categories || []).join(', ')}`
+    }
+    return t.promoCode.pricingScopeAll
+  }
+
+  const validity = (rule: PricingRule) => {
+    if (!rule.starts_at && !rule.expires_at) return t.promoCode.noExpiry
+    const from = rule.starts_at ? formatDate(rule.starts_at) : ''
+    const to = rule.expires_at ? formatDate(rule.expires_at) : t.promoCode.noExpiry
+    return from ? `${from} ~ ${to}` : to
+  }
+
+  const traceNote = (entry: PricingTraceEntry) => {
+    if (entry.applied) return `-${formatCurrency(entry.discount_minor)}`
+    if (!entry.reason) return ''
+    return t.order.pricingSkipReason[entry.reason].replace('{rule}', entry.blocked_by || '')
+  }
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <Tags className="h-4 w-4" />
+            {t.promoCode.pricingRules}
+          </CardTitle>
+          <CardDescription>{t.promoCode.pricingRulesDesc}</CardDescription>
+        </div>
+        {canEdit && !showForm && (
+          <Button size="sm" variant="outline" onClick={() => setShowForm(true)}>
+            <Plus className="mr-1 h-4 w-4" />
+            {t.promoCode.newPricingRule}
+          </Button>
+        )}
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {showForm && (
+          <form
+            className="grid gap-3 rounded-md border p-4 md:grid-cols-3"
+            onSubmit={(e) => {
+              e.preventDefault()
+              saveMutation.mutate()
+            }}
+          >
+            <div className="space-y-2">
+              <Label>{t.promoCode.name}</Label>
+              <Input
+                required
+                value={form.name}
+                onChange={(e) => updateForm('name', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.discountType}</Label>
+              <Select
+                value={form.type}
+                onValueChange={(value) => updateForm('type', value as PricingRule['type'])}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="percentage">{t.promoCode.percentage}</SelectItem>
+                  <SelectItem value="fixed">{t.promoCode.fixed}</SelectItem>
+                  <SelectItem value="threshold">{t.promoCode.pricingTypeThreshold}</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label>
+                {form.type === 'percentage'
+                  ? t.promoCode.pricingPercentValue
+                  : t.promoCode.discountValue}
+              </Label>
+              <Input
+                type="number"
+                step="0.01"
+                min={0}
+                required
+                value={form.value}
+                onChange={(e) => updateForm('value', e.target.value)}
+              />
+            </div>
+            {form.type === 'percentage' && (
+              <div className="space-y-2">
+                <Label>{t.promoCode.maxDiscount}</Label>
+                <Input
+                  type="number"
+                  step="0.01"
+                  min={0}
+                  value={form.max_discount}
+                  onChange={(e) => updateForm('max_discount', e.target.value)}
+                />
+              </div>
+            )}
+            <div className="space-y-2">
+              <Label>{t.promoCode.pricingMinAmount}</Label>
+              <Input
+                type="number"
+                step="0.01"
+                min={0}
+                required={form.type === 'threshold'}
+                value={form.min_amount}
+                onChange={(e) => updateForm('min_amount', e.target.value)}
+              />
+              <p className="text-xs text-muted-foreground">{t.promoCode.pricingMinAmountHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.pricingScope}</Label>
+              <Select
+                value={form.scope}
+                onValueChange={(value) => updateForm('scope', value as PricingRule['scope'])}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="all">{t.promoCode.pricingScopeAll}</SelectItem>
+                  <SelectItem value="sku">SKU</SelectItem>
+                  <SelectItem value="category">{t.promoCode.pricingScopeCategory}</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            {form.scope !== 'all' && (
+              <div className="space-y-2">
+                <Label>{form.scope === 'sku' ? 'SKU' : t.promoCode.pricingScopeCategory}</Label>
+                <Input
+                  required
+                  value={form.targets}
+                  placeholder={form.scope === 'sku' ? 'SKU-1, SKU-2' : undefined}
+                  onChange={(e) => updateForm('targets', e.target.value)}
+                />
+                <p className="text-xs text-muted-foreground">{t.promoCode.pricingTargetsHint}</p>
+              </div>
+            )}
+            <div className="space-y-2">
+              <Label>{t.promoCode.pricingPriority}</Label>
+              <Input
+                type="number"
+                value={form.priority}
+                onChange={(e) => updateForm('priority', e.target.value)}
+              />
+              <p className="text-xs text-muted-foreground">{t.promoCode.pricingPriorityHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignStartsAt}</Label>
+              <Input
+                type="datetime-local"
+                value={form.starts_at}
+                onChange={(e) => updateForm('starts_at', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.expiresAt}</Label>
+              <Input
+                type="datetime-local"
+                value={form.expires_at}
+                onChange={(e) => updateForm('expires_at', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2 md:col-span-3">
+              <Label>{t.promoCode.description}</Label>
+              <Textarea
+                rows={2}
+                value={form.description}
+                onChange={(e) => updateForm('description', e.target.value)}
+              />
+            </div>
+            <div className="flex flex-wrap items-center gap-6 md:col-span-3">
+              <label className="flex items-center gap-2 text-sm">
+                <Switch
+                  checked={form.enabled}
+                  onCheckedChange={(checked) => updateForm('enabled', checked)}
+                />
+                {t.promoCode.pricingEnabled}
+              </label>
+              <label className="flex items-center gap-2 text-sm">
+                <Switch
+                  checked={form.exclusive}
+                  onCheckedChange={(checked) => updateForm('exclusive', checked)}
+                />
+                {t.promoCode.pricingExclusive}
+              </label>
+              <p className="text-xs text-muted-foreground">{t.promoCode.pricingExclusiveHint}</p>
+            </div>
+            <div className="flex items-end justify-end gap-2 md:col-span-3">
+              <Button type="button" variant="ghost" onClick={closeForm}>
+                {t.common.cancel}
+              </Button>
+              <Button type="submit" disabled={saveMutation.isPending}>
+                {saveMutation.isPending ? t.admin.processing : t.common.save}
+              </Button>
+            </div>
+          </form>
+        )}
+
+        {rules.length === 0 ? (
+          !showForm && (
+            <p className="text-sm text-muted-foreground">{t.promoCode.pricingRulesEmpty}</p>
+          )
+        ) : (
+          <div className="space-y-2">
+            {rules.map((rule) => (
+              <div
+                key={rule.id}
+                className="flex flex-wrap items-center justify-between gap-2 rounded-md border bg-background p-3 text-sm"
+              >
+                <div className="space-y-1">
+                  <div className="flex flex-wrap items-center gap-2">
+                    <Badge variant="outline">#{rule.priority}</Badge>
+                    <span className="font-medium">{rule.name}</span>
+                    <span>{ruleValue(rule)}</span>
+                    {rule.exclusive && <Badge>{t.promoCode.pricingExclusive}</Badge>}
+                    {!rule.enabled && <Badge variant="secondary">{t.admin.disabled}</Badge>}
+                  </div>
+                  <div className="text-xs text-muted-foreground">
+                    {ruleScope(rule)} · {validity(rule)}
+                  </div>
+                </div>
+                {canEdit && (
+                  <div className="flex gap-1">
+                    <Button
+                      type="button"
+                      variant="ghost"
+                      size="sm"
+                      title={t.common.edit}
+                      onClick={() => {
+                        setEditingId(rule.id)
+                        setForm(toForm(rule))
+                        setShowForm(true)
+                      }}
+                    >
+                      <Pencil className="h-4 w-4" />
+                    </Button>
+                    <Button
+                      type="button"
+                      variant="ghost"
+                      size="sm"
+                      title={t.common.delete}
+                      onClick={() => setDeleting(rule)}
+                    >
+                      <Trash2 className="h-4 w-4 text-destructive" />
+                    </Button>
+                  </div>
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+
+        <div className="space-y-3 rounded-md border p-4">
+          <div className="flex items-center gap-2 text-sm font-medium">
+            <Calculator className="h-4 w-4" />
+            {t.promoCode.pricingSimulate}
+          </div>
+          <div className="grid gap-3 md:grid-cols-3">
+            <div className="space-y-2 md:col-span-2">
+              <Label>{t.promoCode.pricingSimulateItems}</Label>
+              <Textarea
+                rows={3}
+                className="font-mono text-xs"
+                placeholder={'SKU-1:2\nSKU-2:1'}
+                value={simulationItems}
+                onChange={(e) => setSimulationItems(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.code}</Label>
+              <Input
+                value={simulationPromo}
+                onChange={(e) => setSimulationPromo(e.target.value.toUpperCase())}
+              />
+              <Button
+                type="button"
+                variant="outline"
+                className="w-full"
+                disabled={!simulationItems.trim() || simulateMutation.isPending}
+                onClick={() => simulateMutation.mutate()}
+              >
+                {t.promoCode.pricingSimulateRun}
+              </Button>
+            </div>
+          </div>
+          {evaluation && (
+            <div className="space-y-2 text-sm">
+              <ul className="space-y-1">
+                {(evaluation.trace || []).map((entry, index) => (
+                  <li
+                    key={`${entry.source}-${entry.rule_id ?? entry.name}-${index}`}
+                    className="flex flex-wrap justify-between gap-2"
+                  >
+                    <span className={entry.applied ? undefined : 'text-muted-foreground'}>
+                      {entry.name}
+                      {entry.base_amount_minor > 0 && (
+                        <span className="ml-2 text-xs text-muted-foreground">
+                          {t.promoCode.pricingBase}: {formatCurrency(entry.base_amount_minor)}
+                        </span>
+                      )}
+                    </span>
+                    <span className={entry.applied ? 'font-medium' : 'text-muted-foreground'}>
+                      {traceNote(entry)}
+                    </span>
+                  </li>
+                ))}
+              </ul>
+              <div className="flex flex-wrap justify-end gap-x-6 border-t pt-2">
+                <span>
+                  {t.promoCode.pricingSubtotal}: {formatCurrency(evaluation.subtotal_minor)}
+                </span>
+                <span>
+                  {t.order.discountTotal}:{' '}
+                  {formatCurrency(evaluation.rule_discount_minor + evaluation.promo_discount_minor)}
+                </span>
+                <span className="font-medium">
+                  {t.promoCode.pricingTotal}:{' '}
+                  {formatCurrency(
+                    evaluation.subtotal_minor -
+                      evaluation.rule_discount_minor -
+                      evaluation.promo_discount_minor
+                  )}
+                </span>
+              </div>
+            </div>
+          )}
+        </div>
+      </CardContent>
+
+      <AlertDialog open={deleting !== null} onOpenChange={(open) => !open && setDeleting(null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{deleting?.name}</AlertDialogTitle>
+            <AlertDialogDescription>{t.promoCode.pricingRuleDeleteConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={(e) => {
+                e.preventDefault()
+                if (deleting) deleteMutation.mutate(deleting.id)
+              }}
+              disabled={deleteMutation.isPending}
+            >
+              {deleteMutation.isPending ? t.admin.processing : t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  )
+}
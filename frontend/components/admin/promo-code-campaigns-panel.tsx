@@ -0,0 +1,418 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { BarChart3, Download, Layers, Plus } from 'lucide-react'
+
+import {
+  PromoCodeCampaign,
+  PromoCodeCampaignStats,
+  createPromoCodeCampaign,
+  getPromoCodeCampaignStats,
+  getPromoCodeCampaigns,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatCurrency, formatDate, parseMajorToMinor } from '@/lib/utils'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+interface CampaignForm {
+  name: string
+  prefix: string
+  pattern: string
+  count: string
+  discount_type: 'percentage' | 'fixed'
+  discount_value: string
+  max_discount: string
+  min_order_amount: string
+  uses_per_code: string
+  per_user_limit: string
+  starts_at: string
+  expires_at: string
+}
+
+const EMPTY_FORM: CampaignForm = {
+  name: '',
+  prefix: '',
+  pattern: 'XXXXXXXX',
+  count: '100',
+  discount_type: 'percentage',
+  discount_value: '',
+  max_discount: '',
+  min_order_amount: '',
+  uses_per_code: '1',
+  per_user_limit: '1',
+  starts_at: '',
+  expires_at: '',
+}
+
+function CampaignStatsRow({ campaignId, colSpan }: { campaignId: number; colSpan: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const { data, error } = useQuery({
+    queryKey: ['promoCodeCampaignStats', campaignId],
+    queryFn: () => getPromoCodeCampaignStats(campaignId),
+  })
+  const stats: PromoCodeCampaignStats | undefined = data?.data
+
+  return (
+    <TableRow>
+      <TableCell colSpan={colSpan} className="bg-muted/40">
+        {error ? (
+          <span className="text-sm text-red-600">
+            {resolveApiErrorMessage(error, t, t.promoCode.campaignStatsLoadFailed)}
+          </span>
+        ) : stats ? (
+          <div className="flex flex-wrap gap-x-6 gap-y-1 text-sm">
+            <span>
+              {t.promoCode.campaignStatsIssued}: {stats.issued}
+            </span>
+            <span>
+              {t.promoCode.campaignStatsActive}: {stats.active}
+            </span>
+            <span>
+              {t.promoCode.reservedQuantity}: {stats.reserved}
+            </span>
+            <span>
+              {t.promoCode.usedQuantity}: {stats.used}
+            </span>
+            <span>
+              {t.promoCode.campaignStatsCodesUsed}: {stats.codes_used}
+            </span>
+            <span>
+              {t.promoCode.campaignStatsOrders}: {stats.orders}
+            </span>
+            <span>
+              {t.promoCode.discountAmount}: {formatCurrency(stats.discount_amount_minor)}
+            </span>
+          </div>
+        ) : null}
+      </TableCell>
+    </TableRow>
+  )
+}
+
+// 优惠码活动：按前缀与模式批量生成优惠码，查看活动统计并导出 CSV
+export function PromoCodeCampaignsPanel({ canEdit }: { canEdit: boolean }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [showForm, setShowForm] = useState(false)
+  const [form, setForm] = useState<CampaignForm>(EMPTY_FORM)
+  const [statsId, setStatsId] = useState<number | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['promoCodeCampaigns'],
+    queryFn: () => getPromoCodeCampaigns({ limit: 50 }),
+  })
+  const campaigns: PromoCodeCampaign[] = data?.data?.items || []
+
+  const updateForm = (field: keyof CampaignForm, value: string) =>
+    setForm((prev) => ({ ...prev, [field]: value }))
+
+  const createMutation = useMutation({
+    mutationFn: () => {
+      const discountValueMinor = parseMajorToMinor(form.discount_value)
+      const maxDiscountMinor = parseMajorToMinor(form.max_discount || '0')
+      const minOrderAmountMinor = parseMajorToMinor(form.min_order_amount || '0')
+      if (
+        discountValueMinor === null ||
+        maxDiscountMinor === null ||
+        minOrderAmountMinor === null
+      ) {
+        throw new Error(t.order.invalidPrice)
+      }
+      return createPromoCodeCampaign({
+        name: form.name,
+        prefix: form.prefix || undefined,
+        pattern: form.pattern || undefined,
+        count: Number(form.count),
+        discount_type: form.discount_type,
+        discount_value_minor: discountValueMinor,
+        max_discount_minor: form.discount_type === 'percentage' ? maxDiscountMinor : 0,
+        min_order_amount_minor: minOrderAmountMinor,
+        uses_per_code: Number(form.uses_per_code || 0),
+        per_user_limit: Number(form.per_user_limit || 0),
+        starts_at: form.starts_at ? new Date(form.starts_at).toISOString() : undefined,
+        expires_at: form.expires_at ? new Date(form.expires_at).toISOString() : undefined,
+      })
+    },
+    onSuccess: (res: any) => {
+      const campaign: PromoCodeCampaign | undefined = res?.data
+      toast.success(
+        t.promoCode.campaignCreated.replace('{count}', String(campaign?.code_count ?? 0))
+      )
+      setShowForm(false)
+      setForm(EMPTY_FORM)
+      queryClient.invalidateQueries({ queryKey: ['promoCodeCampaigns'] })
+      queryClient.invalidateQueries({ queryKey: ['adminPromoCodes'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.promoCode.campaignCreateFailed))
+    },
+  })
+
+  const handleExport = async (campaign: PromoCodeCampaign) => {
+    try {
+      const res = await fetch(
+        resolveClientAPIProxyURL(`/api/admin/promo-code-campaigns/${campaign.id}/export`)
+      )
+      if (!res.ok) {
+        const payload = await res.json().catch(() => null)
+        throw payload
+      }
+      const blobUrl = window.URL.createObjectURL(await res.blob())
+      const a = document.createElement('a')
+      a.href = blobUrl
+      a.download = `promo_code_campaign_${campaign.id}.csv`
+      document.body.appendChild(a)
+      a.click()
+      document.body.removeChild(a)
+      window.URL.revokeObjectURL(blobUrl)
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.exportFailed))
+    }
+  }
+
+  const validity = (campaign: PromoCodeCampaign) => {
+    if (!campaign.starts_at && !campaign.expires_at) return t.promoCode.noExpiry
+    const from = campaign.starts_at ? formatDate(campaign.starts_at) : ''
+    const to = campaign.expires_at ? formatDate(campaign.expires_at) : t.promoCode.noExpiry
+    return from ? `${from} ~ ${to}` : to
+  }
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <Layers className="h-4 w-4" />
+            {t.promoCode.campaigns}
+          </CardTitle>
+          <CardDescription>{t.promoCode.campaignsDesc}</CardDescription>
+        </div>
+        {canEdit && (
+          <Button size="sm" variant="outline" onClick={() => setShowForm((value) => !value)}>
+            <Plus className="mr-1 h-4 w-4" />
+            {t.promoCode.newCampaign}
+          </Button>
+        )}
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {showForm && (
+          <form
+            className="grid gap-3 rounded-md border p-4 md:grid-cols-3"
+            onSubmit={(e) => {
+              e.preventDefault()
+              createMutation.mutate()
+            }}
+          >
+            <div className="space-y-2">
+              <Label>{t.promoCode.name}</Label>
+              <Input
+                required
+                value={form.name}
+                onChange={(e) => updateForm('name', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignPrefix}</Label>
+              <Input
+                value={form.prefix}
+                placeholder="SPRING-"
+                onChange={(e) => updateForm('prefix', e.target.value.toUpperCase())}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignPattern}</Label>
+              <Input
+                value={form.pattern}
+                onChange={(e) => updateForm('pattern', e.target.value.toUpperCase())}
+              />
+              <p className="text-xs text-muted-foreground">{t.promoCode.campaignPatternHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignCount}</Label>
+              <Input
+                type="number"
+                min={1}
+                max={10000}
+                required
+                value={form.count}
+                onChange={(e) => updateForm('count', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.discountType}</Label>
+              <Select
+                value={form.discount_type}
+                onValueChange={(value) => updateForm('discount_type', value)}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="percentage">{t.promoCode.percentage}</SelectItem>
+                  <SelectItem value="fixed">{t.promoCode.fixed}</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.discountValue}</Label>
+              <Input
+                type="number"
+                step="0.01"
+                min={0}
+                required
+                value={form.discount_value}
+                onChange={(e) => updateForm('discount_value', e.target.value)}
+              />
+            </div>
+            {form.discount_type === 'percentage' && (
+              <div className="space-y-2">
+                <Label>{t.promoCode.maxDiscount}</Label>
+                <Input
+                  type="number"
+                  step="0.01"
+                  min={0}
+                  value={form.max_discount}
+                  onChange={(e) => updateForm('max_discount', e.target.value)}
+                />
+              </div>
+            )}
+            <div className="space-y-2">
+              <Label>{t.promoCode.minOrderAmount}</Label>
+              <Input
+                type="number"
+                step="0.01"
+                min={0}
+                value={form.min_order_amount}
+                onChange={(e) => updateForm('min_order_amount', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignUsesPerCode}</Label>
+              <Input
+                type="number"
+                min={0}
+                value={form.uses_per_code}
+                onChange={(e) => updateForm('uses_per_code', e.target.value)}
+              />
+              <p className="text-xs text-muted-foreground">{t.promoCode.totalQuantityHint}</p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignPerUserLimit}</Label>
+              <Input
+                type="number"
+                min={0}
+                value={form.per_user_limit}
+                onChange={(e) => updateForm('per_user_limit', e.target.value)}
+              />
+              <p className="text-xs text-muted-foreground">
+                {t.promoCode.campaignPerUserLimitHint}
+              </p>
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.campaignStartsAt}</Label>
+              <Input
+                type="datetime-local"
+                value={form.starts_at}
+                onChange={(e) => updateForm('starts_at', e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>{t.promoCode.expiresAt}</Label>
+              <Input
+                type="datetime-local"
+                value={form.expires_at}
+                onChange={(e) => updateForm('expires_at', e.target.value)}
+              />
+            </div>
+            <div className="flex items-end justify-end gap-2 md:col-span-3">
+              <Button type="button" variant="ghost" onClick={() => setShowForm(false)}>
+                {t.common.cancel}
+              </Button>
+              <Button type="submit" disabled={createMutation.isPending}>
+                {t.promoCode.campaignGenerate}
+              </Button>
+            </div>
+          </form>
+        )}
+
+        {campaigns.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.promoCode.campaignsEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.promoCode.name}</TableHead>
+                <TableHead>{t.promoCode.campaignPattern}</TableHead>
+                <TableHead>{t.promoCode.campaignCount}</TableHead>
+                <TableHead>{t.promoCode.campaignValidity}</TableHead>
+                <TableHead />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {campaigns.map((campaign) => [
+                <TableRow key={campaign.id}>
+                  <TableCell>{campaign.name}</TableCell>
+                  <TableCell className="font-mono text-xs">
+                    {campaign.prefix}
+                    {campaign.pattern}
+                  </TableCell>
+                  <TableCell>{campaign.code_count}</TableCell>
+                  <TableCell className="text-sm">{validity(campaign)}</TableCell>
+                  <TableCell className="text-right">
+                    <div className="flex justify-end gap-1">
+                      <Button
+                        size="sm"
+                        variant="ghost"
+                        onClick={() => setStatsId(statsId === campaign.id ? null : campaign.id)}
+                      >
+                        <BarChart3 className="mr-1 h-4 w-4" />
+                        {t.promoCode.campaignStats}
+                      </Button>
+                      <Button size="sm" variant="ghost" onClick={() => handleExport(campaign)}>
+                        <Download className="mr-1 h-4 w-4" />
+                        CSV
+                      </Button>
+                    </div>
+                  </TableCell>
+                </TableRow>,
+                statsId === campaign.id ? (
+                  <CampaignStatsRow
+                    key={`${campaign.id}-stats`}
+                    campaignId={campaign.id}
+                    colSpan={5}
+                  />
+                ) : null,
+              ])}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
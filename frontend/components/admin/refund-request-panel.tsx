@@ -0,0 +1,217 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Undo2 } from 'lucide-react'
+
+import {
+  RefundRequest,
+  approveRefundRequest,
+  getRefundRequests,
+  rejectRefundRequest,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+type ReviewAction = 'approve' | 'reject'
+
+// 用户退款申请审核队列：批准后调用付款方式退款，拒绝需填写说明，结果同步到关联工单
+export function RefundRequestPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [reviewing, setReviewing] = useState<{ request: RefundRequest; action: ReviewAction }>()
+  const [note, setNote] = useState('')
+
+  const { data } = useQuery({
+    queryKey: ['refundRequests', 'pending'],
+    queryFn: () => getRefundRequests({ page: 1, limit: 50, status: 'pending' }),
+  })
+  const requests: RefundRequest[] = data?.data?.items || []
+
+  const resolutionLabels: Record<RefundRequest['resolution'], string> = {
+    full_refund: t.admin.refundRequestResolutionFullRefund,
+    partial_refund: t.admin.refundRequestResolutionPartialRefund,
+    replacement: t.admin.refundRequestResolutionReplacement,
+    other: t.admin.refundRequestResolutionOther,
+  }
+
+  const closeReview = () => {
+    setReviewing(undefined)
+    setNote('')
+  }
+
+  const reviewMutation = useMutation({
+    mutationFn: ({ request, action }: { request: RefundRequest; action: ReviewAction }) =>
+      action === 'approve'
+        ? approveRefundRequest(request.id, note.trim())
+        : rejectRefundRequest(request.id, note.trim()),
+    onSuccess: (_res, { action }) => {
+      toast.success(
+        action === 'approve' ? t.admin.refundRequestApproved : t.admin.refundRequestRejected
+      )
+      closeReview()
+      queryClient.invalidateQueries({ queryKey: ['refundRequests'] })
+      queryClient.invalidateQueries({ queryKey: ['adminOrders'] })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.refundRequestReviewFailed))
+    },
+  })
+
+  if (requests.length === 0) {
+    return null
+  }
+
+  const noteRequired = reviewing?.action === 'reject'
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Undo2 className="h-4 w-4" />
+          {t.admin.refundRequestQueue}
+          <Badge variant="secondary">{data?.data?.pagination?.total ?? requests.length}</Badge>
+        </CardTitle>
+        <CardDescription>{t.admin.refundRequestQueueDesc}</CardDescription>
+      </CardHeader>
+      <CardContent>
+        <Table>
+          <TableHeader>
+            <TableRow>
+              <TableHead>{t.admin.orderNo}</TableHead>
+              <TableHead>{t.admin.refundRequestUser}</TableHead>
+              <TableHead>{t.admin.refundRequestResolution}</TableHead>
+              <TableHead>{t.admin.refundRequestReason}</TableHead>
+              <TableHead>{t.admin.refundRequestEvidence}</TableHead>
+              <TableHead>{t.admin.refundRequestCreatedAt}</TableHead>
+              <TableHead />
+            </TableRow>
+          </TableHeader>
+          <TableBody>
+            {requests.map((request) => (
+              <TableRow key={request.id}>
+                <TableCell className="font-mono text-xs">
+                  <Link href={`/admin/orders/${request.order_id}`} className="hover:underline">
+                    {request.order_no}
+                  </Link>
+                  {request.ticket_no ? (
+                    <div className="mt-1 text-muted-foreground">
+                      {t.admin.refundRequestTicket.replace('{ticketNo}', request.ticket_no)}
+                    </div>
+                  ) : null}
+                </TableCell>
+                <TableCell className="text-sm">{request.user?.email || request.user_id}</TableCell>
+                <TableCell>
+                  <Badge variant="outline">{resolutionLabels[request.resolution]}</Badge>
+                </TableCell>
+                <TableCell className="max-w-[280px] whitespace-pre-wrap break-words text-sm">
+                  {request.reason}
+                </TableCell>
+                <TableCell>
+                  <div className="flex flex-wrap gap-1">
+                    {(request.evidence || []).map((url) => (
+                      <a
+                        key={url}
+                        href={url}
+                        target="_blank"
+                        rel="noopener noreferrer"
+                        className="block h-10 w-10 overflow-hidden rounded border"
+                      >
+                        {/* eslint-disable-next-line @next/next/no-img-element */}
+                        <img src={url} alt="" className="h-full w-full object-cover" />
+                      </a>
+                    ))}
+                  </div>
+                </TableCell>
+                <TableCell className="text-sm">{formatDate(request.created_at)}</TableCell>
+                <TableCell className="text-right">
+                  <div className="flex justify-end gap-1">
+                    <Button size="sm" onClick={() => setReviewing({ request, action: 'approve' })}>
+                      {t.admin.refundRequestApprove}
+                    </Button>
+                    <Button
+                      size="sm"
+                      variant="outline"
+                      onClick={() => setReviewing({ request, action: 'reject' })}
+                    >
+                      {t.admin.refundRequestReject}
+                    </Button>
+                  </div>
+                </TableCell>
+              </TableRow>
+            ))}
+          </TableBody>
+        </Table>
+      </CardContent>
+
+      <Dialog open={!!reviewing} onOpenChange={(open) => !open && closeReview()}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>
+              {reviewing?.action === 'approve'
+                ? t.admin.refundRequestApproveTitle
+                : t.admin.refundRequestRejectTitle}
+            </DialogTitle>
+            <DialogDescription>
+              {reviewing?.action === 'approve'
+                ? t.admin.refundRequestApproveDesc.replace('{orderNo}', reviewing.request.order_no)
+                : t.admin.refundRequestRejectDesc}
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-1.5">
+            <Label>
+              {t.admin.refundRequestNote}
+              {noteRequired ? ' *' : ''}
+            </Label>
+            <Textarea
+              value={note}
+              onChange={(e) => setNote(e.target.value)}
+              placeholder={t.admin.refundRequestNotePlaceholder}
+              maxLength={1000}
+              rows={3}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={closeReview}>
+              {t.common.cancel}
+            </Button>
+            <Button
+              variant={reviewing?.action === 'reject' ? 'destructive' : 'default'}
+              disabled={reviewMutation.isPending || (noteRequired && !note.trim())}
+              onClick={() => reviewing && reviewMutation.mutate(reviewing)}
+            >
+              {reviewMutation.isPending ? t.common.processing : t.common.confirm}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </Card>
+  )
+}
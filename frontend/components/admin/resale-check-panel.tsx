@@ -0,0 +1,162 @@
+'use client'
+
+import { useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ShieldAlert } from 'lucide-react'
+
+import {
+  VirtualStockResaleCheckResult,
+  VirtualStockResaleReport,
+  checkVirtualStockResale,
+  getVirtualStockResaleReports,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { OrderStatusBadge } from '@/components/orders/order-status-badge'
+import type { OrderStatus } from '@/types/order'
+
+// 站外转售核查：输入在其他渠道出现的卡密，查询是否由本店售出以及买家
+export function ResaleCheckPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [code, setCode] = useState('')
+  const [source, setSource] = useState('')
+  const [result, setResult] = useState<VirtualStockResaleCheckResult | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['virtualStockResaleReports'],
+    queryFn: () => getVirtualStockResaleReports({ matched: true, page: 1, limit: 5 }),
+  })
+  const reports: VirtualStockResaleReport[] = data?.data?.items || []
+
+  const checkMutation = useMutation({
+    mutationFn: () => checkVirtualStockResale({ code, source: source.trim() || undefined }),
+    onSuccess: (response: any) => {
+      setResult(response?.data || null)
+      queryClient.invalidateQueries({ queryKey: ['virtualStockResaleReports'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.resaleCheckFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <ShieldAlert className="h-4 w-4" />
+          {t.admin.resaleCheck}
+        </CardTitle>
+        <CardDescription>{t.admin.resaleCheckDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid gap-4 md:grid-cols-2">
+          <div className="space-y-2">
+            <Label>{t.admin.resaleCheckCode}</Label>
+            <Input
+              className="font-mono"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              onKeyDown={(e) => {
+                if (e.key === 'Enter' && code.trim()) checkMutation.mutate()
+              }}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label>{t.admin.resaleCheckSource}</Label>
+            <Input
+              placeholder="https://"
+              value={source}
+              onChange={(e) => setSource(e.target.value)}
+            />
+          </div>
+        </div>
+        <div className="flex justify-end">
+          <Button
+            onClick={() => checkMutation.mutate()}
+            disabled={!code.trim() || checkMutation.isPending}
+          >
+            {checkMutation.isPending ? t.admin.resaleChecking : t.admin.resaleCheckSubmit}
+          </Button>
+        </div>
+
+        {result && (
+          <div className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center gap-2">
+              <Badge variant={result.matched ? 'destructive' : 'secondary'}>
+                {result.matched ? t.admin.resaleMatched : t.admin.resaleNotMatched}
+              </Badge>
+              {result.previous_reports > 0 && (
+                <span className="text-xs text-muted-foreground">
+                  {t.admin.resalePreviousReports
+                    .replace('{count}', String(result.previous_reports))
+                    .replace('{date}', formatDate(result.first_reported_at || ''))}
+                </span>
+              )}
+            </div>
+            {result.deliveries.map((delivery) => (
+              <div key={delivery.stock_id} className="space-y-1 border-t pt-2">
+                <div className="flex flex-wrap items-center gap-2">
+                  <Link
+                    href={`/admin/orders/${delivery.order_id}`}
+                    className="font-mono underline"
+                  >
+                    {delivery.order_no}
+                  </Link>
+                  {delivery.order_status && (
+                    <OrderStatusBadge status={delivery.order_status as OrderStatus} />
+                  )}
+                  {delivery.refunded && (
+                    <Badge variant="destructive">{t.admin.resaleRefunded}</Badge>
+                  )}
+                  {delivery.inventory_name && (
+                    <span className="text-xs text-muted-foreground">{delivery.inventory_name}</span>
+                  )}
+                </div>
+                <p className="text-xs text-muted-foreground">
+                  {t.admin.resaleBuyer}:{' '}
+                  {delivery.buyer ? delivery.buyer.email : '-'}
+                  {delivery.buyer?.name && ` (${delivery.buyer.name})`}
+                  {delivery.delivered_at &&
+                    ` · ${t.admin.resaleDeliveredAt}: ${formatDate(delivery.delivered_at)}`}
+                </p>
+              </div>
+            ))}
+          </div>
+        )}
+
+        {reports.length > 0 && (
+          <div className="space-y-2">
+            <h4 className="text-sm font-medium">{t.admin.resaleRecentMatches}</h4>
+            {reports.map((report) => (
+              <div
+                key={report.id}
+                className="flex flex-wrap items-center gap-2 text-xs text-muted-foreground"
+              >
+                <span>{formatDate(report.created_at)}</span>
+                {report.order_id && (
+                  <Link href={`/admin/orders/${report.order_id}`} className="font-mono underline">
+                    {report.order_no}
+                  </Link>
+                )}
+                {report.refunded && <Badge variant="destructive">{t.admin.resaleRefunded}</Badge>}
+                {report.source && <span className="max-w-xs truncate">{report.source}</span>}
+              </div>
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
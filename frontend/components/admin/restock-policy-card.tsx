@@ -0,0 +1,331 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { PackagePlus, Play, Save } from 'lucide-react'
+
+import {
+  VirtualInventoryRestockOverview,
+  VirtualInventoryRestockRun,
+  getVirtualInventoryRestock,
+  getVirtualInventoryRestockRuns,
+  runVirtualInventoryRestock,
+  updateVirtualInventoryRestock,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatCurrency, formatDate, majorToMinor, minorToMajor } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+import { Textarea } from '@/components/ui/textarea'
+
+const RESTOCK_SCRIPT_TEMPLATE = `// Purchase codes from the supplier when stock runs low
+// Set api_url and api_key in Script Config below
+function onRestock(request, config) {
+  var resp = AuraLogic.http.post(config.api_url, {
+    sku: request.inventory.sku,
+    quantity: request.quantity
+  }, {
+    "Authorization": "Bearer " + config.api_key
+  });
+  if (resp.error || resp.status !== 200) {
+    return { success: false, message: resp.error || "API error: " + resp.status };
+  }
+  return {
+    success: true,
+    items: resp.data.codes || [],
+    cost_minor: resp.data.total_minor,
+    reference: resp.data.order_id
+  };
+}
+`
+
+interface RestockForm {
+  enabled: boolean
+  threshold: string
+  quantity: string
+  cooldown: string
+  dailyCap: string
+  monthlyCap: string
+  script: string
+  config: string
+}
+
+function toForm(overview?: VirtualInventoryRestockOverview): RestockForm {
+  const policy = overview?.policy
+  return {
+    enabled: policy?.enabled ?? false,
+    threshold: String(policy?.threshold ?? 10),
+    quantity: String(policy?.quantity ?? 50),
+    cooldown: String(policy?.cooldown_minutes ?? 0),
+    dailyCap: policy?.daily_spend_cap_minor
+      ? String(minorToMajor(policy.daily_spend_cap_minor))
+      : '',
+    monthlyCap: policy?.monthly_spend_cap_minor
+      ? String(minorToMajor(policy.monthly_spend_cap_minor))
+      : '',
+    script: policy?.script || RESTOCK_SCRIPT_TEMPLATE,
+    config: policy?.script_config || '',
+  }
+}
+
+// 静态卡密库存的自动补货：低于阈值时执行供应商脚本采购，受每日/每月消费上限约束
+export function RestockPolicyCard({ inventoryId }: { inventoryId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [form, setForm] = useState<RestockForm>(() => toForm())
+
+  const { data } = useQuery({
+    queryKey: ['virtualInventoryRestock', inventoryId],
+    queryFn: () => getVirtualInventoryRestock(inventoryId),
+    enabled: !!inventoryId,
+  })
+  const overview: VirtualInventoryRestockOverview | undefined = data?.data
+
+  useEffect(() => {
+    if (overview) {
+      setForm(toForm(overview))
+    }
+  }, [overview])
+
+  const { data: runsData } = useQuery({
+    queryKey: ['virtualInventoryRestockRuns', inventoryId],
+    queryFn: () => getVirtualInventoryRestockRuns(inventoryId, { page: 1, limit: 10 }),
+    enabled: !!inventoryId,
+  })
+  const runs: VirtualInventoryRestockRun[] = runsData?.data?.items || []
+
+  const refresh = () => {
+    queryClient.invalidateQueries({ queryKey: ['virtualInventoryRestock', inventoryId] })
+    queryClient.invalidateQueries({ queryKey: ['virtualInventoryRestockRuns', inventoryId] })
+    queryClient.invalidateQueries({ queryKey: ['virtualInventoryStocks'] })
+    queryClient.invalidateQueries({ queryKey: ['virtualInventory', inventoryId] })
+  }
+
+  const saveMutation = useMutation({
+    mutationFn: () =>
+      updateVirtualInventoryRestock(inventoryId, {
+        enabled: form.enabled,
+        threshold: Number(form.threshold) || 0,
+        quantity: Number(form.quantity) || 0,
+        cooldown_minutes: Number(form.cooldown) || 0,
+        daily_spend_cap_minor: form.dailyCap ? majorToMinor(Number(form.dailyCap)) : 0,
+        monthly_spend_cap_minor: form.monthlyCap ? majorToMinor(Number(form.monthlyCap)) : 0,
+        script: form.script,
+        script_config: form.config.trim() || undefined,
+      }),
+    onSuccess: () => {
+      toast.success(t.admin.restockSaved)
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.restockSaveFailed))
+    },
+  })
+
+  const runMutation = useMutation({
+    mutationFn: () => runVirtualInventoryRestock(inventoryId),
+    onSuccess: (response: any) => {
+      const run: VirtualInventoryRestockRun | undefined = response?.data
+      if (run?.status === 'success') {
+        toast.success(t.admin.restockRunSucceeded.replace('{count}', String(run.added)))
+      } else {
+        toast.error(run?.error || t.admin.restockRunFailed)
+      }
+      refresh()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.restockRunFailed))
+    },
+  })
+
+  const policy = overview?.policy
+  const currency = overview?.currency || 'CNY'
+  const update = (patch: Partial<RestockForm>) => setForm((prev) => ({ ...prev, ...patch }))
+
+  return (
+    <Card>
+      <CardHeader>
+        <div className="flex flex-wrap items-start justify-between gap-2">
+          <div className="space-y-1.5">
+            <CardTitle className="flex items-center gap-2">
+              <PackagePlus className="h-5 w-5" />
+              {t.admin.restockTitle}
+            </CardTitle>
+            <CardDescription>{t.admin.restockDesc}</CardDescription>
+          </div>
+          <label className="flex items-center gap-2 text-sm">
+            <Switch checked={form.enabled} onCheckedChange={(enabled) => update({ enabled })} />
+            {t.admin.restockEnabled}
+          </label>
+        </div>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {overview && (
+          <div className="flex flex-wrap gap-x-6 gap-y-1 text-sm text-muted-foreground">
+            <span>
+              {t.admin.restockAvailable}: {overview.available}
+            </span>
+            <span>
+              {t.admin.restockSpentToday}: {formatCurrency(overview.spent_today_minor, currency)}
+            </span>
+            <span>
+              {t.admin.restockSpentMonth}: {formatCurrency(overview.spent_month_minor, currency)}
+            </span>
+            {policy?.last_run_at && (
+              <span>
+                {t.admin.restockLastRun}: {formatDate(policy.last_run_at)}
+              </span>
+            )}
+          </div>
+        )}
+        {policy?.last_status === 'cap_reached' && (
+          <p className="text-sm text-amber-600">{t.admin.restockCapReachedHint}</p>
+        )}
+        {policy?.last_status === 'failed' && policy.last_error && (
+          <p className="break-all text-sm text-destructive">
+            {t.admin.restockLastError.replace('{count}', String(policy.consecutive_failures))}:{' '}
+            {policy.last_error}
+          </p>
+        )}
+
+        <div className="grid gap-4 md:grid-cols-3">
+          <div className="space-y-2">
+            <Label>{t.admin.restockThreshold}</Label>
+            <Input
+              type="number"
+              min={1}
+              value={form.threshold}
+              onChange={(e) => update({ threshold: e.target.value })}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label>{t.admin.restockQuantity}</Label>
+            <Input
+              type="number"
+              min={1}
+              value={form.quantity}
+              onChange={(e) => update({ quantity: e.target.value })}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label>{t.admin.restockCooldown}</Label>
+            <Input
+              type="number"
+              min={0}
+              value={form.cooldown}
+              onChange={(e) => update({ cooldown: e.target.value })}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label>
+              {t.admin.restockDailyCap} ({currency})
+            </Label>
+            <Input
+              type="number"
+              min={0}
+              step="0.01"
+              placeholder={t.admin.restockCapUnlimited}
+              value={form.dailyCap}
+              onChange={(e) => update({ dailyCap: e.target.value })}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label>
+              {t.admin.restockMonthlyCap} ({currency})
+            </Label>
+            <Input
+              type="number"
+              min={0}
+              step="0.01"
+              placeholder={t.admin.restockCapUnlimited}
+              value={form.monthlyCap}
+              onChange={(e) => update({ monthlyCap: e.target.value })}
+            />
+          </div>
+        </div>
+
+        <div className="space-y-2">
+          <Label>{t.admin.restockScript}</Label>
+          <Textarea
+            rows={12}
+            className="font-mono text-xs"
+            value={form.script}
+            onChange={(e) => update({ script: e.target.value })}
+          />
+          <p className="text-xs text-muted-foreground">{t.admin.restockScriptHint}</p>
+        </div>
+        <div className="space-y-2">
+          <Label>{t.admin.restockScriptConfig}</Label>
+          <Textarea
+            rows={4}
+            className="font-mono text-xs"
+            placeholder='{"api_url": "https://supplier.example.com/api/orders", "api_key": "..."}'
+            value={form.config}
+            onChange={(e) => update({ config: e.target.value })}
+          />
+        </div>
+
+        <div className="flex justify-end gap-2">
+          <Button
+            variant="outline"
+            onClick={() => runMutation.mutate()}
+            disabled={!policy || runMutation.isPending}
+          >
+            <Play className="mr-2 h-4 w-4" />
+            {runMutation.isPending ? t.admin.restockRunning : t.admin.restockRunNow}
+          </Button>
+          <Button onClick={() => saveMutation.mutate()} disabled={saveMutation.isPending}>
+            <Save className="mr-2 h-4 w-4" />
+            {saveMutation.isPending ? t.admin.savingText : t.common.save}
+          </Button>
+        </div>
+
+        <div className="space-y-2">
+          <h4 className="text-sm font-medium">{t.admin.restockRuns}</h4>
+          {runs.length === 0 && (
+            <p className="text-sm text-muted-foreground">{t.admin.restockRunsEmpty}</p>
+          )}
+          {runs.map((run) => (
+            <div key={run.id} className="space-y-1 rounded-md border p-3 text-sm">
+              <div className="flex flex-wrap items-center gap-2">
+                <span className="font-medium">#{run.id}</span>
+                <Badge variant={run.status === 'success' ? 'secondary' : 'destructive'}>
+                  {run.status === 'success' ? t.admin.restockRunSuccess : t.admin.restockRunFailure}
+                </Badge>
+                <Badge variant="outline">
+                  {run.trigger_type === 'manual'
+                    ? t.admin.restockTriggerManual
+                    : t.admin.restockTriggerAuto}
+                </Badge>
+                {run.supplier_reference && (
+                  <span className="font-mono text-xs">{run.supplier_reference}</span>
+                )}
+                <span className="text-xs text-muted-foreground">
+                  {formatDate(run.created_at)} · {run.duration_ms}ms
+                </span>
+              </div>
+              <p className="text-xs text-muted-foreground">
+                {t.admin.restockRunSummary
+                  .replace('{added}', String(run.added))
+                  .replace('{requested}', String(run.requested))
+                  .replace('{duplicates}', String(run.duplicates))
+                  .replace('{invalid}', String(run.invalid))
+                  .replace('{cost}', formatCurrency(run.cost_minor, run.currency || currency))}
+              </p>
+              {run.error && <p className="break-all text-xs text-destructive">{run.error}</p>}
+            </div>
+          ))}
+        </div>
+      </CardContent>
+    </Card>
+  )
+}
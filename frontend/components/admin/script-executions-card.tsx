@@ -0,0 +1,224 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { History, RotateCcw } from 'lucide-react'
+
+import {
+  ScriptDryRunEvent,
+  ScriptExecution,
+  ScriptReplayResult,
+  getVirtualInventoryScriptExecution,
+  getVirtualInventoryScriptExecutions,
+  replayVirtualInventoryScriptExecution,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Switch } from '@/components/ui/switch'
+
+function EventLog({ events, empty }: { events?: ScriptDryRunEvent[] | null; empty: string }) {
+  return (
+    <div className="max-h-60 space-y-1 overflow-auto rounded-md bg-muted p-3 font-mono text-xs">
+      {!events || events.length === 0 ? (
+        <p className="text-muted-foreground">{empty}</p>
+      ) : (
+        events.map((event, index) => (
+          <div key={index} className="break-all">
+            <span className="text-muted-foreground">
+              {new Date(event.time).toLocaleTimeString()}{' '}
+            </span>
+            {event.type === 'http' ? (
+              <span className={event.error ? 'text-destructive' : undefined}>
+                {event.method} {event.url} → {event.status || '-'} ({event.duration_ms ?? 0}ms)
+                {event.error ? ` ${event.error}` : ''}
+              </span>
+            ) : (
+              <span>{event.message}</span>
+            )}
+          </div>
+        ))
+      )}
+    </div>
+  )
+}
+
+// 发货脚本执行审计：查看真实订单的执行日志，修复脚本后重放失败的执行
+export function ScriptExecutionsCard({ inventoryId }: { inventoryId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [failedOnly, setFailedOnly] = useState(false)
+  const [selectedId, setSelectedId] = useState<number | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['virtualInventoryScriptExecutions', inventoryId, failedOnly],
+    queryFn: () =>
+      getVirtualInventoryScriptExecutions(inventoryId, {
+        success: failedOnly ? false : undefined,
+        page: 1,
+        limit: 10,
+      }),
+    enabled: !!inventoryId,
+  })
+  const executions: ScriptExecution[] = data?.data?.items || []
+
+  const { data: detailData, isLoading: detailLoading } = useQuery({
+    queryKey: ['virtualInventoryScriptExecution', inventoryId, selectedId],
+    queryFn: () => getVirtualInventoryScriptExecution(inventoryId, selectedId!),
+    enabled: !!selectedId,
+  })
+  const detail: ScriptExecution | undefined = detailData?.data
+
+  const replayMutation = useMutation({
+    mutationFn: (executionId: number) =>
+      replayVirtualInventoryScriptExecution(inventoryId, executionId),
+    onSuccess: (response: any) => {
+      const result: ScriptReplayResult | undefined = response?.data
+      if (result?.success) {
+        toast.success(t.admin.scriptExecutionReplaySucceeded)
+      } else {
+        toast.error(result?.error || t.admin.scriptExecutionReplayStillFailing)
+      }
+      setSelectedId(result?.executions?.[0]?.id ?? null)
+      queryClient.invalidateQueries({ queryKey: ['virtualInventoryScriptExecutions'] })
+      queryClient.invalidateQueries({ queryKey: ['virtualInventoryScriptExecution'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.scriptExecutionReplayFailed))
+    },
+  })
+
+  if (executions.length === 0 && !failedOnly) {
+    return null
+  }
+
+  return (
+    <>
+      <Card>
+        <CardHeader>
+          <div className="flex flex-wrap items-start justify-between gap-2">
+            <div className="space-y-1.5">
+              <CardTitle className="flex items-center gap-2">
+                <History className="h-5 w-5" />
+                {t.admin.scriptExecutions}
+              </CardTitle>
+              <CardDescription>{t.admin.scriptExecutionsDesc}</CardDescription>
+            </div>
+            <label className="flex items-center gap-2 text-sm">
+              <Switch checked={failedOnly} onCheckedChange={setFailedOnly} />
+              {t.admin.scriptExecutionFailedOnly}
+            </label>
+          </div>
+        </CardHeader>
+        <CardContent className="space-y-2">
+          {executions.length === 0 && (
+            <p className="text-sm text-muted-foreground">{t.admin.scriptExecutionEmpty}</p>
+          )}
+          {executions.map((execution) => (
+            <div
+              key={execution.id}
+              className="flex items-center justify-between gap-4 rounded-md border p-3 text-sm"
+            >
+              <div className="min-w-0 space-y-1">
+                <div className="flex flex-wrap items-center gap-2">
+                  <span className="font-medium">#{execution.id}</span>
+                  <Badge variant={execution.success ? 'secondary' : 'destructive'}>
+                    {execution.success
+                      ? t.admin.scriptExecutionSuccess
+                      : t.admin.scriptExecutionFailed}
+                  </Badge>
+                  {execution.replayed_at && (
+                    <Badge variant="outline">{t.admin.scriptExecutionReplayed}</Badge>
+                  )}
+                  <span className="font-mono text-xs">{execution.order_no}</span>
+                  <span className="text-xs text-muted-foreground">
+                    {formatDate(execution.created_at)} · {execution.duration_ms}ms
+                  </span>
+                </div>
+                {execution.error && (
+                  <p className="truncate text-xs text-destructive">{execution.error}</p>
+                )}
+              </div>
+              <Button size="sm" variant="outline" onClick={() => setSelectedId(execution.id)}>
+                {t.admin.scriptExecutionView}
+              </Button>
+            </div>
+          ))}
+        </CardContent>
+      </Card>
+
+      <Dialog open={!!selectedId} onOpenChange={(open) => !open && setSelectedId(null)}>
+        <DialogContent className="max-w-3xl">
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.scriptExecutionTitle.replace('{id}', String(selectedId ?? ''))}
+            </DialogTitle>
+            <DialogDescription>
+              {detail &&
+                t.admin.scriptExecutionSummary
+                  .replace('{order}', detail.order_no)
+                  .replace('{items}', String(detail.item_count))
+                  .replace('{quantity}', String(detail.quantity))
+                  .replace('{duration}', String(detail.duration_ms))
+                  .replace('{hash}', detail.script_hash)}
+            </DialogDescription>
+          </DialogHeader>
+          {detailLoading || !detail ? (
+            <p className="text-sm text-muted-foreground">{t.common.loading}</p>
+          ) : (
+            <div className="space-y-3">
+              {detail.error && (
+                <p className="break-all rounded-md border border-destructive/50 p-2 text-sm text-destructive">
+                  {detail.error}
+                </p>
+              )}
+              {detail.replay_of && (
+                <p className="text-xs text-muted-foreground">
+                  {t.admin.scriptExecutionReplayOf.replace('{id}', String(detail.replay_of))}
+                </p>
+              )}
+              <div className="space-y-1">
+                <p className="text-sm font-medium">{t.admin.scriptExecutionConsole}</p>
+                <EventLog events={detail.console} empty={t.admin.scriptExecutionNoEvents} />
+              </div>
+              <div className="space-y-1">
+                <p className="text-sm font-medium">{t.admin.scriptExecutionHttpCalls}</p>
+                <EventLog events={detail.http_calls} empty={t.admin.scriptExecutionNoEvents} />
+              </div>
+              {detail.events_truncated && (
+                <p className="text-xs text-muted-foreground">{t.admin.scriptDryRunTruncated}</p>
+              )}
+            </div>
+          )}
+          {detail && !detail.success && !detail.replayed_at && (
+            <DialogFooter className="items-center gap-2 sm:justify-between">
+              <p className="text-xs text-muted-foreground">{t.admin.scriptExecutionReplayHint}</p>
+              <Button
+                disabled={replayMutation.isPending}
+                onClick={() => replayMutation.mutate(detail.id)}
+              >
+                <RotateCcw className="mr-2 h-4 w-4" />
+                {t.admin.scriptExecutionReplay}
+              </Button>
+            </DialogFooter>
+          )}
+        </DialogContent>
+      </Dialog>
+    </>
+  )
+}
@@ -0,0 +1,212 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { AlertTriangle, GitCompare } from 'lucide-react'
+
+import {
+  ScriptDiffLine,
+  ScriptRevision,
+  ScriptRevisionDiff,
+  approveVirtualInventoryScriptRevision,
+  getVirtualInventoryScriptRevisionDiff,
+  getVirtualInventoryScriptRevisions,
+  rejectVirtualInventoryScriptRevision,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Textarea } from '@/components/ui/textarea'
+
+function DiffBlock({ lines }: { lines: ScriptDiffLine[] }) {
+  return (
+    <pre className="max-h-80 overflow-auto rounded-md border bg-muted/30 p-2 font-mono text-xs">
+      {lines.map((line, index) => (
+        <div
+          key={index}
+          className={
+            line.op === '+'
+              ? 'bg-green-500/15 text-green-700 dark:text-green-400'
+              : line.op === '-'
+                ? 'bg-red-500/15 text-red-700 dark:text-red-400'
+                : undefined
+          }
+        >
+          {line.op} {line.text}
+        </div>
+      ))}
+    </pre>
+  )
+}
+
+// 发货脚本修订列表：查看差异并由另一名管理员批准或驳回
+export function ScriptRevisionsCard({
+  inventoryId,
+  onReviewed,
+}: {
+  inventoryId: number
+  onReviewed?: () => void
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [selected, setSelected] = useState<ScriptRevision | null>(null)
+  const [note, setNote] = useState('')
+
+  const { data } = useQuery({
+    queryKey: ['virtualInventoryScriptRevisions', inventoryId],
+    queryFn: () => getVirtualInventoryScriptRevisions(inventoryId, { page: 1, limit: 10 }),
+    enabled: !!inventoryId,
+  })
+  const revisions: ScriptRevision[] = data?.data?.items || []
+
+  const { data: diffData, isLoading: diffLoading } = useQuery({
+    queryKey: ['virtualInventoryScriptRevisionDiff', inventoryId, selected?.id],
+    queryFn: () => getVirtualInventoryScriptRevisionDiff(inventoryId, selected!.id),
+    enabled: !!selected,
+  })
+  const diff: ScriptRevisionDiff | undefined = diffData?.data
+
+  const reviewMutation = useMutation({
+    mutationFn: ({ approve, revisionId }: { approve: boolean; revisionId: number }) =>
+      approve
+        ? approveVirtualInventoryScriptRevision(inventoryId, revisionId, note)
+        : rejectVirtualInventoryScriptRevision(inventoryId, revisionId, note),
+    onSuccess: (_, variables) => {
+      toast.success(
+        variables.approve ? t.admin.scriptRevisionApproved : t.admin.scriptRevisionRejected
+      )
+      setSelected(null)
+      setNote('')
+      queryClient.invalidateQueries({ queryKey: ['virtualInventoryScriptRevisions'] })
+      onReviewed?.()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.scriptRevisionReviewFailed))
+    },
+  })
+
+  if (revisions.length === 0) {
+    return null
+  }
+
+  const statusLabels: Record<ScriptRevision['status'], string> = {
+    pending: t.admin.scriptRevisionPending,
+    approved: t.admin.scriptRevisionStatusApproved,
+    rejected: t.admin.scriptRevisionStatusRejected,
+    superseded: t.admin.scriptRevisionSuperseded,
+  }
+
+  return (
+    <>
+      <Card>
+        <CardHeader>
+          <CardTitle className="flex items-center gap-2">
+            <GitCompare className="h-5 w-5" />
+            {t.admin.scriptRevisions}
+          </CardTitle>
+          <CardDescription>{t.admin.scriptRevisionsDesc}</CardDescription>
+        </CardHeader>
+        <CardContent className="space-y-2">
+          {revisions.map((revision) => (
+            <div
+              key={revision.id}
+              className="flex items-center justify-between gap-4 rounded-md border p-3 text-sm"
+            >
+              <div className="min-w-0 space-y-1">
+                <div className="flex items-center gap-2">
+                  <span className="font-medium">#{revision.id}</span>
+                  <Badge variant={revision.status === 'pending' ? 'default' : 'secondary'}>
+                    {statusLabels[revision.status]}
+                  </Badge>
+                  <span className="text-xs text-muted-foreground">
+                    {formatDate(revision.created_at)}
+                  </span>
+                </div>
+                {revision.submit_note && (
+                  <p className="truncate text-xs text-muted-foreground">{revision.submit_note}</p>
+                )}
+              </div>
+              <Button size="sm" variant="outline" onClick={() => setSelected(revision)}>
+                {t.admin.scriptRevisionViewDiff}
+              </Button>
+            </div>
+          ))}
+        </CardContent>
+      </Card>
+
+      <Dialog open={!!selected} onOpenChange={(open) => !open && setSelected(null)}>
+        <DialogContent className="max-w-3xl">
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.scriptRevisionDiffTitle.replace('{id}', String(selected?.id ?? ''))}
+            </DialogTitle>
+            <DialogDescription>{t.admin.scriptRevisionDiffDesc}</DialogDescription>
+          </DialogHeader>
+          {diffLoading || !diff ? (
+            <p className="text-sm text-muted-foreground">{t.common.loading}</p>
+          ) : (
+            <div className="space-y-3">
+              {diff.stale && (
+                <div className="flex items-center gap-2 rounded-md border border-yellow-300 bg-yellow-50 p-2 text-sm text-yellow-800 dark:border-yellow-800 dark:bg-yellow-950 dark:text-yellow-200">
+                  <AlertTriangle className="h-4 w-4" />
+                  {t.admin.scriptRevisionStaleHint}
+                </div>
+              )}
+              <div className="space-y-1">
+                <p className="text-sm font-medium">{t.admin.scriptLabel}</p>
+                <DiffBlock lines={diff.script} />
+              </div>
+              {diff.script_config.some((line) => line.op !== ' ') && (
+                <div className="space-y-1">
+                  <p className="text-sm font-medium">{t.admin.scriptRevisionConfig}</p>
+                  <DiffBlock lines={diff.script_config} />
+                </div>
+              )}
+              {diff.revision.status === 'pending' && (
+                <Textarea
+                  value={note}
+                  onChange={(e) => setNote(e.target.value)}
+                  placeholder={t.admin.scriptRevisionNotePlaceholder}
+                  maxLength={500}
+                  rows={2}
+                />
+              )}
+            </div>
+          )}
+          {diff?.revision.status === 'pending' && (
+            <DialogFooter>
+              <Button
+                variant="outline"
+                disabled={reviewMutation.isPending}
+                onClick={() => reviewMutation.mutate({ approve: false, revisionId: diff.revision.id })}
+              >
+                {t.admin.scriptRevisionReject}
+              </Button>
+              <Button
+                disabled={reviewMutation.isPending || diff.stale}
+                onClick={() => reviewMutation.mutate({ approve: true, revisionId: diff.revision.id })}
+              >
+                {t.admin.scriptRevisionApprove}
+              </Button>
+            </DialogFooter>
+          )}
+        </DialogContent>
+      </Dialog>
+    </>
+  )
+}
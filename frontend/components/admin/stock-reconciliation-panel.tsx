@@ -0,0 +1,223 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ClipboardCheck, Wrench } from 'lucide-react'
+
+import {
+  StockDiscrepancy,
+  StockReconciliationRun,
+  getStockReconciliationRun,
+  getStockReconciliationRuns,
+  runStockReconciliation,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+// 每日库存对账：查看最近的对账记录，或手动执行（可选自动修正）
+export function StockReconciliationPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [selectedId, setSelectedId] = useState<number | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['stockReconciliationRuns'],
+    queryFn: () => getStockReconciliationRuns({ page: 1, limit: 10 }),
+  })
+  const runs: StockReconciliationRun[] = data?.data?.items || []
+
+  const { data: detailData, isLoading: detailLoading } = useQuery({
+    queryKey: ['stockReconciliationRun', selectedId],
+    queryFn: () => getStockReconciliationRun(selectedId!),
+    enabled: selectedId !== null,
+  })
+  const detail: StockReconciliationRun | undefined = detailData?.data
+
+  const runMutation = useMutation({
+    mutationFn: (autoHeal: boolean) => runStockReconciliation(autoHeal),
+    onSuccess: (res: any) => {
+      const run: StockReconciliationRun | undefined = res?.data
+      toast.success(
+        t.admin.stockReconciliationDone.replace('{count}', String(run?.discrepancy_count ?? 0))
+      )
+      queryClient.invalidateQueries({ queryKey: ['stockReconciliationRuns'] })
+      queryClient.invalidateQueries({ queryKey: ['inventories'] })
+      if (run?.id) setSelectedId(run.id)
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.stockReconciliationFailed))
+    },
+  })
+
+  const kindLabels: Record<StockDiscrepancy['kind'], string> = {
+    reserved_mismatch: t.admin.stockReconciliationKindReserved,
+    oversold: t.admin.stockReconciliationKindOversold,
+    virtual_leak: t.admin.stockReconciliationKindVirtualLeak,
+    virtual_undelivered: t.admin.stockReconciliationKindVirtualUndelivered,
+  }
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <ClipboardCheck className="h-4 w-4" />
+            {t.admin.stockReconciliation}
+          </CardTitle>
+          <CardDescription>{t.admin.stockReconciliationDesc}</CardDescription>
+        </div>
+        <div className="flex items-center gap-2">
+          <Button
+            size="sm"
+            variant="outline"
+            disabled={runMutation.isPending}
+            onClick={() => runMutation.mutate(false)}
+          >
+            {t.admin.stockReconciliationRun}
+          </Button>
+          <Button
+            size="sm"
+            disabled={runMutation.isPending}
+            onClick={() => runMutation.mutate(true)}
+          >
+            <Wrench className="mr-1 h-4 w-4" />
+            {t.admin.stockReconciliationRunHeal}
+          </Button>
+        </div>
+      </CardHeader>
+      <CardContent>
+        {runs.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.admin.stockReconciliationEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.admin.stockReconciliationStartedAt}</TableHead>
+                <TableHead>{t.admin.stockReconciliationTrigger}</TableHead>
+                <TableHead>{t.admin.stockReconciliationDiscrepancies}</TableHead>
+                <TableHead>{t.admin.stockReconciliationOversold}</TableHead>
+                <TableHead>{t.admin.stockReconciliationHealed}</TableHead>
+                <TableHead />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {runs.map((run) => (
+                <TableRow key={run.id}>
+                  <TableCell className="text-sm">{formatDate(run.started_at)}</TableCell>
+                  <TableCell>
+                    <Badge variant="secondary">
+                      {run.trigger_type === 'scheduled'
+                        ? t.admin.stockReconciliationScheduled
+                        : t.admin.stockReconciliationManual}
+                    </Badge>
+                    {run.status === 'failed' && (
+                      <Badge variant="destructive" className="ml-1">
+                        {t.admin.stockReconciliationStatusFailed}
+                      </Badge>
+                    )}
+                  </TableCell>
+                  <TableCell>{run.discrepancy_count}</TableCell>
+                  <TableCell className={run.oversold_count > 0 ? 'text-red-600' : undefined}>
+                    {run.oversold_count}
+                  </TableCell>
+                  <TableCell>{run.auto_heal ? run.healed_count : '-'}</TableCell>
+                  <TableCell className="text-right">
+                    <Button size="sm" variant="ghost" onClick={() => setSelectedId(run.id)}>
+                      {t.admin.stockReconciliationView}
+                    </Button>
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+
+      <Dialog open={selectedId !== null} onOpenChange={(open) => !open && setSelectedId(null)}>
+        <DialogContent className="max-w-3xl">
+          <DialogHeader>
+            <DialogTitle>
+              {t.admin.stockReconciliationDetailTitle.replace('{id}', String(selectedId ?? ''))}
+            </DialogTitle>
+            <DialogDescription>{t.admin.stockReconciliationDetailDesc}</DialogDescription>
+          </DialogHeader>
+          {detailLoading || !detail ? (
+            <p className="text-sm text-muted-foreground">{t.common.loading}</p>
+          ) : (
+            <div className="space-y-3">
+              {detail.error && <p className="text-sm text-red-600">{detail.error}</p>}
+              {(detail.discrepancies || []).length === 0 ? (
+                <p className="text-sm text-muted-foreground">
+                  {t.admin.stockReconciliationNoDiscrepancies}
+                </p>
+              ) : (
+                <div className="max-h-96 overflow-auto">
+                  <Table>
+                    <TableHeader>
+                      <TableRow>
+                        <TableHead>{t.admin.stockReconciliationKind}</TableHead>
+                        <TableHead>{t.admin.stockReconciliationInventory}</TableHead>
+                        <TableHead>{t.admin.stockReconciliationExpected}</TableHead>
+                        <TableHead>{t.admin.stockReconciliationActual}</TableHead>
+                        <TableHead>{t.admin.stockReconciliationOrders}</TableHead>
+                      </TableRow>
+                    </TableHeader>
+                    <TableBody>
+                      {(detail.discrepancies || []).map((item, index) => (
+                        <TableRow key={`${item.kind}-${item.inventory_id}-${index}`}>
+                          <TableCell>
+                            <div className="flex items-center gap-1">
+                              <Badge variant={item.kind === 'oversold' ? 'destructive' : 'outline'}>
+                                {kindLabels[item.kind] || item.kind}
+                              </Badge>
+                              {item.healed && (
+                                <Badge variant="secondary">
+                                  {t.admin.stockReconciliationHealed}
+                                </Badge>
+                              )}
+                            </div>
+                          </TableCell>
+                          <TableCell className="text-sm">
+                            #{item.inventory_id} {item.inventory_name}
+                          </TableCell>
+                          <TableCell>{item.expected}</TableCell>
+                          <TableCell>{item.actual}</TableCell>
+                          <TableCell className="max-w-[200px] truncate font-mono text-xs">
+                            {(item.order_nos || []).join(', ')}
+                          </TableCell>
+                        </TableRow>
+                      ))}
+                    </TableBody>
+                  </Table>
+                </div>
+              )}
+            </div>
+          )}
+        </DialogContent>
+      </Dialog>
+    </Card>
+  )
+}
@@ -0,0 +1,169 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Activity, PlayCircle, RefreshCw } from 'lucide-react'
+
+import {
+  SupplierEndpointHealth,
+  getVirtualInventorySupplierHealth,
+  resumeVirtualInventorySupplier,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+const WINDOW_OPTIONS = [15, 60, 360, 1440]
+
+// 脚本发货上游接口的成功率与延迟，以及自动暂停状态
+export function SupplierHealthPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const [windowMinutes, setWindowMinutes] = useState(60)
+
+  const { data, isLoading, refetch, isFetching } = useQuery({
+    queryKey: ['virtualInventorySupplierHealth', windowMinutes],
+    queryFn: () => getVirtualInventorySupplierHealth(windowMinutes),
+  })
+  const items: SupplierEndpointHealth[] = data?.data?.items || []
+
+  const resumeMutation = useMutation({
+    mutationFn: (id: number) => resumeVirtualInventorySupplier(id),
+    onSuccess: () => {
+      toast.success(t.admin.supplierHealthResumed)
+      queryClient.invalidateQueries({ queryKey: ['virtualInventorySupplierHealth'] })
+      queryClient.invalidateQueries({ queryKey: ['virtualInventories'] })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.supplierHealthResumeFailed))
+    },
+  })
+
+  const windowLabel = (minutes: number) =>
+    minutes < 60
+      ? t.admin.supplierHealthWindowMinutes.replace('{count}', String(minutes))
+      : t.admin.supplierHealthWindowHours.replace('{count}', String(minutes / 60))
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-start justify-between gap-4 space-y-0">
+        <div className="space-y-1.5">
+          <CardTitle className="flex items-center gap-2">
+            <Activity className="h-4 w-4" />
+            {t.admin.supplierHealth}
+          </CardTitle>
+          <CardDescription>{t.admin.supplierHealthDesc}</CardDescription>
+        </div>
+        <div className="flex items-center gap-2">
+          <Select
+            value={String(windowMinutes)}
+            onValueChange={(value) => setWindowMinutes(Number(value))}
+          >
+            <SelectTrigger className="w-32">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {WINDOW_OPTIONS.map((minutes) => (
+                <SelectItem key={minutes} value={String(minutes)}>
+                  {windowLabel(minutes)}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <Button variant="outline" size="icon" onClick={() => refetch()} disabled={isFetching}>
+            <RefreshCw className={`h-4 w-4 ${isFetching ? 'animate-spin' : ''}`} />
+          </Button>
+        </div>
+      </CardHeader>
+      <CardContent>
+        {isLoading ? (
+          <p className="text-sm text-muted-foreground">{t.common.loading}</p>
+        ) : items.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.admin.supplierHealthEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.admin.supplierHealthInventory}</TableHead>
+                <TableHead>{t.admin.supplierHealthEndpoint}</TableHead>
+                <TableHead>{t.admin.supplierHealthSuccessRate}</TableHead>
+                <TableHead>{t.admin.supplierHealthLatency}</TableHead>
+                <TableHead>{t.admin.supplierHealthLastSeen}</TableHead>
+                <TableHead>{t.admin.status}</TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {items.map((item) => (
+                <TableRow key={`${item.virtual_inventory_id}-${item.endpoint}`}>
+                  <TableCell>{item.inventory_name || `#${item.virtual_inventory_id}`}</TableCell>
+                  <TableCell className="font-mono text-xs">{item.endpoint}</TableCell>
+                  <TableCell>
+                    <span className={item.success_rate < 0.9 ? 'text-destructive' : undefined}>
+                      {(item.success_rate * 100).toFixed(1)}%
+                    </span>
+                    <span className="ml-1 text-xs text-muted-foreground">
+                      ({item.requests - item.failures}/{item.requests})
+                    </span>
+                  </TableCell>
+                  <TableCell className="whitespace-nowrap text-xs">
+                    {item.avg_latency_ms}ms / {item.max_latency_ms}ms
+                  </TableCell>
+                  <TableCell className="whitespace-nowrap text-xs">
+                    {formatDate(item.last_seen_at)}
+                    {item.last_error && (
+                      <p className="max-w-xs truncate text-destructive" title={item.last_error}>
+                        {item.last_error}
+                      </p>
+                    )}
+                  </TableCell>
+                  <TableCell>
+                    {item.supplier_paused_at ? (
+                      <div className="flex items-center gap-2">
+                        <Badge variant="destructive" title={item.supplier_pause_reason}>
+                          {t.admin.supplierHealthPaused}
+                        </Badge>
+                        <Button
+                          size="sm"
+                          variant="outline"
+                          onClick={() => resumeMutation.mutate(item.virtual_inventory_id)}
+                          disabled={resumeMutation.isPending}
+                        >
+                          <PlayCircle className="mr-1 h-3.5 w-3.5" />
+                          {t.admin.supplierHealthResume}
+                        </Button>
+                      </div>
+                    ) : (
+                      <Badge variant="secondary">{t.admin.supplierHealthActive}</Badge>
+                    )}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,229 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Copy, FileImage, Link2, Trash2, Upload } from 'lucide-react'
+
+import {
+  TemplateAsset,
+  deleteTemplateAsset,
+  getTemplateAssets,
+  uploadTemplateAsset,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { useToast } from '@/hooks/use-toast'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+const TEMPLATE_ASSET_ACCEPT = '.png,.jpg,.jpeg,.gif,.webp,.svg,.woff,.woff2,.ttf,.otf,.css'
+
+function formatAssetSize(size: number) {
+  if (size < 1024) return `${size} B`
+  if (size < 1024 * 1024) return `${(size / 1024).toFixed(1)} KB`
+  return `${(size / 1024 / 1024).toFixed(2)} MB`
+}
+
+export function TemplateAssetsPanel() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const toast = useToast()
+  const queryClient = useQueryClient()
+  const fileInputRef = useRef<HTMLInputElement>(null)
+  const [file, setFile] = useState<File | null>(null)
+  const [name, setName] = useState('')
+  const [deleting, setDeleting] = useState<TemplateAsset | null>(null)
+
+  const { data } = useQuery({
+    queryKey: ['templateAssets'],
+    queryFn: getTemplateAssets,
+  })
+  const assets: TemplateAsset[] = data?.data || []
+
+  const uploadMutation = useMutation({
+    mutationFn: () => uploadTemplateAsset(file as File, name.trim() || undefined),
+    onSuccess: () => {
+      toast.success(t.admin.templateAssetUploaded)
+      setFile(null)
+      setName('')
+      if (fileInputRef.current) fileInputRef.current.value = ''
+      queryClient.invalidateQueries({ queryKey: ['templateAssets'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.templateAssetUploadFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (assetName: string) => deleteTemplateAsset(assetName),
+    onSuccess: () => {
+      toast.success(t.admin.templateAssetDeleted)
+      setDeleting(null)
+      queryClient.invalidateQueries({ queryKey: ['templateAssets'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.admin.templateAssetDeleteFailed))
+    },
+  })
+
+  const handleCopy = async (value: string) => {
+    try {
+      await navigator.clipboard.writeText(value)
+      toast.success(t.common.copiedToClipboard)
+    } catch {
+      toast.error(t.admin.operationFailed)
+    }
+  }
+
+  return (
+    <Card className="bg-muted/30">
+      <CardHeader className="pb-3">
+        <CardTitle className="flex items-center gap-2 text-sm">
+          <FileImage className="h-4 w-4" />
+          {t.admin.templateAssets}
+        </CardTitle>
+        <CardDescription>{t.admin.templateAssetsDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid gap-3 md:grid-cols-[1fr_1fr_auto] md:items-end">
+          <div>
+            <Label htmlFor="template_asset_file">{t.admin.templateAssetUpload}</Label>
+            <Input
+              id="template_asset_file"
+              ref={fileInputRef}
+              type="file"
+              accept={TEMPLATE_ASSET_ACCEPT}
+              className="mt-1.5"
+              onChange={(e) => setFile(e.target.files?.[0] || null)}
+            />
+          </div>
+          <div>
+            <Label htmlFor="template_asset_name">{t.admin.templateAssetName}</Label>
+            <Input
+              id="template_asset_name"
+              value={name}
+              placeholder={t.admin.templateAssetNamePlaceholder}
+              className="mt-1.5"
+              onChange={(e) => setName(e.target.value)}
+            />
+          </div>
+          <Button
+            type="button"
+            disabled={!file || uploadMutation.isPending}
+            onClick={() => uploadMutation.mutate()}
+          >
+            <Upload className="mr-2 h-4 w-4" />
+            {uploadMutation.isPending ? t.admin.processing : t.admin.templateAssetUpload}
+          </Button>
+        </div>
+
+        {assets.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.admin.templateAssetEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.admin.name}</TableHead>
+                <TableHead>{t.admin.type}</TableHead>
+                <TableHead>{t.admin.templateAssetSize}</TableHead>
+                <TableHead>{t.order.updatedAt}</TableHead>
+                <TableHead className="text-right">{t.admin.actions}</TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {assets.map((asset) => (
+                <TableRow key={asset.id}>
+                  <TableCell>
+                    <code className="text-xs">{asset.reference}</code>
+                    <div className="text-xs text-muted-foreground">{asset.file_name}</div>
+                  </TableCell>
+                  <TableCell>
+                    <Badge variant="outline">{asset.kind}</Badge>
+                  </TableCell>
+                  <TableCell>{formatAssetSize(asset.size)}</TableCell>
+                  <TableCell>{formatDate(asset.updated_at)}</TableCell>
+                  <TableCell className="text-right">
+                    <div className="flex justify-end gap-1">
+                      <Button
+                        type="button"
+                        variant="ghost"
+                        size="sm"
+                        title={t.admin.templateAssetCopyReference}
+                        onClick={() => handleCopy(asset.reference)}
+                      >
+                        <Copy className="h-4 w-4" />
+                      </Button>
+                      <Button
+                        type="button"
+                        variant="ghost"
+                        size="sm"
+                        title={t.admin.templateAssetCopyUrl}
+                        onClick={() => handleCopy(asset.url)}
+                      >
+                        <Link2 className="h-4 w-4" />
+                      </Button>
+                      <Button
+                        type="button"
+                        variant="ghost"
+                        size="sm"
+                        title={t.common.delete}
+                        onClick={() => setDeleting(asset)}
+                      >
+                        <Trash2 className="h-4 w-4 text-destructive" />
+                      </Button>
+                    </div>
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+
+      <AlertDialog open={deleting !== null} onOpenChange={(open) => !open && setDeleting(null)}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{deleting?.reference}</AlertDialogTitle>
+            <AlertDialogDescription>{t.admin.templateAssetDeleteConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={(e) => {
+                e.preventDefault()
+                if (deleting) deleteMutation.mutate(deleting.name)
+              }}
+              disabled={deleteMutation.isPending}
+              className="bg-destructive text-destructive-foreground hover:bg-destructive/90"
+            >
+              {deleteMutation.isPending ? t.admin.processing : t.common.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  )
+}
@@ -0,0 +1,41 @@
+'use client'
+
+import { useQuery } from '@tanstack/react-query'
+
+import { getUserConsents, type PolicyConsent, type PolicyVersion } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { ConsentHistoryList } from '@/components/consent-history-list'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+// 用户的政策同意记录，用于处理合规请求
+export function UserConsentCard({ userId }: { userId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({
+    queryKey: ['userConsents', userId],
+    queryFn: () => getUserConsents(userId),
+  })
+  const history: PolicyConsent[] = data?.data?.history || []
+  const pending: PolicyVersion[] = data?.data?.pending || []
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>{t.consent.history}</CardTitle>
+        <CardDescription>
+          {pending.length > 0
+            ? t.consent.adminPending.replace(
+                '{policies}',
+                pending.map((policy) => `${policy.title} ${policy.version}`).join(', ')
+              )
+            : t.consent.adminUpToDate}
+        </CardDescription>
+      </CardHeader>
+      <CardContent>
+        <ConsentHistoryList history={history} />
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,118 @@
+'use client'
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+
+import {
+  getAdminProductCategories,
+  getUserSpendingControl,
+  updateUserSpendingControl,
+  type SpendingControlOverview,
+  type SpendingControlTerms,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { usePermission } from '@/hooks/use-permission'
+import { SpendingControlForm } from '@/components/forms/spending-control-form'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+// 管理员查看和修改用户的消费控制，修改立即生效
+export function UserSpendingControlCard({ userId }: { userId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const { hasPermission } = usePermission()
+  const canEdit = hasPermission('user.edit')
+
+  const queryKey = ['userSpendingControl', userId]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getUserSpendingControl(userId),
+  })
+  const overview: SpendingControlOverview | undefined = data?.data
+
+  const { data: categoriesData } = useQuery({
+    queryKey: ['adminProductCategories'],
+    queryFn: getAdminProductCategories,
+    enabled: canEdit,
+    staleTime: 1000 * 60 * 5,
+  })
+  const categories: string[] = categoriesData?.data?.categories || []
+
+  const updateMutation = useMutation({
+    mutationFn: (terms: SpendingControlTerms) => updateUserSpendingControl(userId, terms),
+    onSuccess: () => {
+      toast.success(t.spendingControl.saved)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.spendingControl.saveFailed))
+    },
+  })
+
+  if (!overview) {
+    return null
+  }
+  const { control, currency } = overview
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>{t.spendingControl.title}</CardTitle>
+        <CardDescription>{t.spendingControl.adminDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex items-center justify-between text-sm">
+          <span>{t.spendingControl.spent}</span>
+          <span className="font-medium">
+            {formatCurrency(overview.monthly_spent_minor, currency)}
+            {control.monthly_limit_minor > 0
+              ? ` / ${formatCurrency(control.monthly_limit_minor, currency)}`
+              : ''}
+          </span>
+        </div>
+        {control.pending && control.pending_effective_at ? (
+          <p className="rounded-md bg-muted p-3 text-xs">
+            {t.spendingControl.pendingDesc.replace(
+              '{time}',
+              new Date(control.pending_effective_at).toLocaleString()
+            )}
+          </p>
+        ) : null}
+        {canEdit ? (
+          <SpendingControlForm
+            key={control.updated_at || 'new'}
+            initial={{
+              monthly_limit_minor: control.monthly_limit_minor,
+              blocked_categories: control.blocked_categories || [],
+              owner_email: control.owner_email || '',
+            }}
+            currency={currency}
+            categories={categories}
+            submitting={updateMutation.isPending}
+            onSubmit={(terms) => updateMutation.mutate(terms)}
+          />
+        ) : (
+          <div className="space-y-2 text-sm">
+            <div className="flex justify-between gap-4">
+              <span>{t.spendingControl.blockedCategories}</span>
+              <span className="text-right text-muted-foreground">
+                {control.blocked_categories?.length
+                  ? control.blocked_categories.join(', ')
+                  : t.spendingControl.none}
+              </span>
+            </div>
+            <div className="flex justify-between gap-4">
+              <span>{t.spendingControl.ownerEmail}</span>
+              <span className="break-all text-right text-muted-foreground">
+                {control.owner_email || t.spendingControl.none}
+              </span>
+            </div>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
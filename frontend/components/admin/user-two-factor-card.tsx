@@ -0,0 +1,134 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+
+import {
+  getUserTwoFactor,
+  resetUserTwoFactor,
+  updateUserTwoFactor,
+  type TwoFactorStatus,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { usePermission } from '@/hooks/use-permission'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Label } from '@/components/ui/label'
+import { Switch } from '@/components/ui/switch'
+
+// 管理员强制用户开启两步验证，或在用户丢失验证器时重置
+export function UserTwoFactorCard({ userId }: { userId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const { hasPermission } = usePermission()
+  const canEdit = hasPermission('user.edit')
+  const [confirmReset, setConfirmReset] = useState(false)
+
+  const queryKey = ['userTwoFactor', userId]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getUserTwoFactor(userId),
+  })
+  const status: TwoFactorStatus | undefined = data?.data
+
+  const onError = (error: unknown) => {
+    toast.error(resolveApiErrorMessage(error, t, t.twoFactor.actionFailed))
+  }
+  const requireMutation = useMutation({
+    mutationFn: (required: boolean) => updateUserTwoFactor(userId, required),
+    onSuccess: () => {
+      toast.success(t.twoFactor.adminUpdated)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError,
+  })
+  const resetMutation = useMutation({
+    mutationFn: () => resetUserTwoFactor(userId),
+    onSuccess: () => {
+      toast.success(t.twoFactor.adminResetSuccess)
+      setConfirmReset(false)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError,
+  })
+
+  if (!status) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          {t.twoFactor.title}
+          <Badge variant={status.enabled ? 'default' : 'secondary'}>
+            {status.enabled ? t.twoFactor.enabled : t.twoFactor.disabled}
+          </Badge>
+        </CardTitle>
+        <CardDescription>
+          {status.enabled
+            ? t.twoFactor.backupCodesRemaining.replace(
+                '{count}',
+                String(status.backup_codes_remaining)
+              )
+            : t.twoFactor.adminNotEnrolled}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex items-start justify-between gap-4">
+          <div className="space-y-1">
+            <Label htmlFor="two_factor_required">{t.twoFactor.adminRequire}</Label>
+            <p className="text-xs text-muted-foreground">{t.twoFactor.adminRequireDesc}</p>
+          </div>
+          <Switch
+            id="two_factor_required"
+            checked={status.required}
+            disabled={!canEdit || requireMutation.isPending}
+            onCheckedChange={(checked) => requireMutation.mutate(checked)}
+          />
+        </div>
+        {canEdit && status.enabled && (
+          <Button variant="outline" onClick={() => setConfirmReset(true)}>
+            {t.twoFactor.adminReset}
+          </Button>
+        )}
+      </CardContent>
+
+      <AlertDialog open={confirmReset} onOpenChange={setConfirmReset}>
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.twoFactor.adminReset}</AlertDialogTitle>
+            <AlertDialogDescription>{t.twoFactor.adminResetConfirm}</AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              disabled={resetMutation.isPending}
+              onClick={(e) => {
+                e.preventDefault()
+                resetMutation.mutate()
+              }}
+            >
+              {t.twoFactor.adminReset}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  )
+}
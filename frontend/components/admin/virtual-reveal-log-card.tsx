@@ -0,0 +1,84 @@
+'use client'
+
+import { useQuery } from '@tanstack/react-query'
+import { Eye } from 'lucide-react'
+
+import { AdminVirtualRevealLog, getAdminOrderVirtualRevealLogs } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { formatDate } from '@/lib/utils'
+import { Badge } from '@/components/ui/badge'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table'
+
+// 展示用户查看虚拟商品内容的记录，处理“卡密无效”类纠纷时作为依据
+export function VirtualRevealLogCard({ orderId }: { orderId: number }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['adminOrderVirtualRevealLogs', orderId],
+    queryFn: () => getAdminOrderVirtualRevealLogs(orderId, { page: 1, limit: 50 }),
+    enabled: !!orderId,
+  })
+  const logs: AdminVirtualRevealLog[] = data?.data?.items || []
+  const total: number = data?.data?.pagination?.total ?? logs.length
+
+  const authLabels: Record<AdminVirtualRevealLog['auth_method'], string> = {
+    none: t.admin.virtualRevealAuthNone,
+    password: t.admin.virtualRevealAuthPassword,
+    otp: t.admin.virtualRevealAuthOtp,
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <Eye className="h-4 w-4" />
+          {t.admin.virtualRevealLogs}
+          {total > 0 && <Badge variant="secondary">{total}</Badge>}
+        </CardTitle>
+        <CardDescription>{t.admin.virtualRevealLogsDesc}</CardDescription>
+      </CardHeader>
+      <CardContent>
+        {isLoading ? (
+          <p className="text-sm text-muted-foreground">{t.common.loading}</p>
+        ) : logs.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.admin.virtualRevealLogsEmpty}</p>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>{t.admin.virtualRevealTime}</TableHead>
+                <TableHead>{t.admin.virtualRevealIp}</TableHead>
+                <TableHead>{t.admin.virtualRevealAuth}</TableHead>
+                <TableHead>{t.admin.virtualRevealCount}</TableHead>
+                <TableHead>{t.admin.virtualRevealUserAgent}</TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {logs.map((log) => (
+                <TableRow key={log.id}>
+                  <TableCell className="whitespace-nowrap">{formatDate(log.created_at)}</TableCell>
+                  <TableCell className="font-mono text-xs">{log.ip_address || '-'}</TableCell>
+                  <TableCell>{authLabels[log.auth_method] || log.auth_method}</TableCell>
+                  <TableCell>{log.stock_count}</TableCell>
+                  <TableCell className="max-w-xs truncate text-xs text-muted-foreground">
+                    {log.user_agent || '-'}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
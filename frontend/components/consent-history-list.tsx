@@ -0,0 +1,32 @@
+'use client'
+
+import type { PolicyConsent } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+
+// 政策同意记录，用户端与管理端共用
+export function ConsentHistoryList({ history }: { history: PolicyConsent[] }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  if (history.length === 0) {
+    return <p className="text-sm text-muted-foreground">{t.consent.noHistory}</p>
+  }
+  return (
+    <div className="divide-y text-sm">
+      {history.map((consent) => (
+        <div key={consent.id} className="flex flex-wrap items-center justify-between gap-2 py-2">
+          <span>
+            {t.consent.types[consent.policy_type] || consent.policy_type} · {t.consent.version}{' '}
+            {consent.version}
+          </span>
+          <span className="text-muted-foreground">
+            {formatDate(consent.accepted_at)}
+            {consent.ip_address ? ` · ${consent.ip_address}` : ''}
+          </span>
+        </div>
+      ))}
+    </div>
+  )
+}
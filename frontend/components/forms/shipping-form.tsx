@@ -26,13 +26,19 @@ import {
   SelectTrigger,
   SelectValue,
 } from '@/components/ui/select'
-import { submitShippingForm, getCountries } from '@/lib/api'
+import { submitShippingForm, getCountries, getAddressSchemas } from '@/lib/api'
 import { resolveApiErrorMessage } from '@/lib/api-error'
 import { shippingFormSchema } from '@/lib/validators'
 import toast from 'react-hot-toast'
 import { Globe, Package } from 'lucide-react'
-import { phoneCodeMap } from '@/lib/phone-codes'
-import { getTranslations } from '@/lib/i18n'
+import {
+  indexAddressSchemas,
+  isAddressFieldRequired,
+  resolvePhoneCode,
+  validateAddressAgainstSchema,
+  type AddressSchema,
+} from '@/lib/address-schema'
+import { getTranslations, translateBizError } from '@/lib/i18n'
 import { PluginSlot } from '@/components/plugins/plugin-slot'
 
 interface ShippingFormProps {
@@ -72,6 +78,7 @@ export function ShippingForm({
   const t = translations.shippingForm
   const [isSubmitting, setIsSubmitting] = useState(false)
   const [countries, setCountries] = useState<any[]>([])
+  const [addressSchemas, setAddressSchemas] = useState<Record<string, AddressSchema>>({})
 
   // 从 localStorage 读取上次填写的收货信息
   const savedShipping = (() => {
@@ -134,9 +141,20 @@ export function ShippingForm({
       })
   }, [activeLocale])
 
+  // 获取各国电话区号与地址格式，加载失败时回退到本地区号表且不做前端格式校验
+  useEffect(() => {
+    getAddressSchemas()
+      .then((response: any) => {
+        setAddressSchemas(indexAddressSchemas(response.data))
+      })
+      .catch(err => {
+        console.error('Failed to load address schemas:', err)
+      })
+  }, [])
+
   // 为区号选择器生成选项列表
   const phoneCodeOptions = countries.map((country) => {
-    const phoneCode = phoneCodeMap[country.code] || `+${country.code}`
+    const phoneCode = resolvePhoneCode(addressSchemas[country.code], country.code)
     return {
       countryCode: country.code,
       phoneCode: phoneCode,
@@ -186,6 +204,9 @@ export function ShippingForm({
 
   // 判断是否是中国（需要填写省市区）
   const isChina = selectedCountry === 'CN'
+  const addressSchema = addressSchemas[selectedCountry]
+  const isRequired = (field: Parameters<typeof isAddressFieldRequired>[1]) =>
+    isAddressFieldRequired(addressSchema, field)
   const shippingFormPluginContext = pluginSlotNamespace
     ? {
         ...(pluginSlotContext || {}),
@@ -219,6 +240,15 @@ export function ShippingForm({
     ) : null
 
   async function onSubmit(values: z.infer<typeof shippingFormSchema>) {
+    // 按国家地址格式预校验，规则与后端一致
+    const addressIssue = validateAddressAgainstSchema(addressSchema, values)
+    if (addressIssue) {
+      form.setError(addressIssue.field, {
+        message: translateBizError(translations, addressIssue.errorKey, addressIssue.params),
+      })
+      return
+    }
+
     setIsSubmitting(true)
 
     try {
@@ -370,7 +400,7 @@ export function ShippingForm({
                   const normalized = normalizeCountryCode(value, countries)
                   field.onChange(normalized)
                   setSelectedCountry(normalized)
-                  const phoneCode = phoneCodeMap[normalized] || '+' + normalized
+                  const phoneCode = resolvePhoneCode(addressSchemas[normalized], normalized)
                   form.setValue('phone_code', phoneCode)
                 }}
                 value={normalizeCountryCode(field.value, countries)}
@@ -444,7 +474,9 @@ export function ShippingForm({
               name="receiver_city"
               render={({ field }) => (
                 <FormItem>
-                  <FormLabel>{t.cityOptional}</FormLabel>
+                  <FormLabel>
+                    {isRequired('receiver_city') ? `${t.city} *` : t.cityOptional}
+                  </FormLabel>
                   <FormControl>
                     <Input placeholder={t.cityPlaceholder} {...field} />
                   </FormControl>
@@ -457,7 +489,9 @@ export function ShippingForm({
               name="receiver_province"
               render={({ field }) => (
                 <FormItem>
-                  <FormLabel>{t.provinceOptional}</FormLabel>
+                  <FormLabel>
+                    {isRequired('receiver_province') ? `${t.province} *` : t.provinceOptional}
+                  </FormLabel>
                   <FormControl>
                     <Input placeholder={t.provincePlaceholder} {...field} />
                   </FormControl>
@@ -487,9 +521,14 @@ export function ShippingForm({
             name="receiver_postcode"
             render={({ field }) => (
               <FormItem>
-                <FormLabel>{t.postcodeOptional}</FormLabel>
+                <FormLabel>
+                  {isRequired('receiver_postcode') ? `${t.postcode} *` : t.postcodeOptional}
+                </FormLabel>
                 <FormControl>
-                  <Input placeholder={t.postcodePlaceholder} {...field} />
+                  <Input
+                    placeholder={addressSchema?.postcode_example || t.postcodePlaceholder}
+                    {...field}
+                  />
                 </FormControl>
                 <FormMessage />
               </FormItem>
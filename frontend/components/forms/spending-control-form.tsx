@@ -0,0 +1,108 @@
+'use client'
+
+import { useState } from 'react'
+import { Loader2 } from 'lucide-react'
+
+import type { SpendingControlTerms } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { majorToMinor, minorToMajor } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+interface SpendingControlFormProps {
+  initial: SpendingControlTerms
+  currency: string
+  categories: string[]
+  submitting: boolean
+  onSubmit: (terms: SpendingControlTerms) => void
+}
+
+// 每月限额、禁止购买的分类与所有人邮箱，用户端与管理端共用
+export function SpendingControlForm({
+  initial,
+  currency,
+  categories,
+  submitting,
+  onSubmit,
+}: SpendingControlFormProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [limit, setLimit] = useState(
+    initial.monthly_limit_minor ? minorToMajor(initial.monthly_limit_minor).toString() : ''
+  )
+  const [blocked, setBlocked] = useState<string[]>(initial.blocked_categories)
+  const [ownerEmail, setOwnerEmail] = useState(initial.owner_email)
+
+  // 已屏蔽但商品中已不存在的分类也需要展示，便于解除
+  const options = Array.from(new Set([...categories, ...initial.blocked_categories])).sort()
+  const isBlocked = (category: string) =>
+    blocked.some((item) => item.toLowerCase() === category.toLowerCase())
+  const toggle = (category: string) =>
+    setBlocked((prev) =>
+      isBlocked(category)
+        ? prev.filter((item) => item.toLowerCase() !== category.toLowerCase())
+        : [...prev, category]
+    )
+
+  return (
+    <div className="space-y-4">
+      <div className="space-y-1.5">
+        <Label htmlFor="spending_monthly_limit">
+          {t.spendingControl.monthlyLimit} ({currency})
+        </Label>
+        <Input
+          id="spending_monthly_limit"
+          type="number"
+          min="0"
+          step="0.01"
+          placeholder={t.spendingControl.noLimit}
+          value={limit}
+          onChange={(e) => setLimit(e.target.value)}
+        />
+        <p className="text-xs text-muted-foreground">{t.spendingControl.monthlyLimitHint}</p>
+      </div>
+      <div className="space-y-1.5">
+        <Label>{t.spendingControl.blockedCategories}</Label>
+        {options.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.spendingControl.noCategories}</p>
+        ) : (
+          <div className="grid gap-2 sm:grid-cols-2">
+            {options.map((category) => (
+              <label key={category} className="flex items-center gap-2 text-sm">
+                <Checkbox checked={isBlocked(category)} onCheckedChange={() => toggle(category)} />
+                {category}
+              </label>
+            ))}
+          </div>
+        )}
+      </div>
+      <div className="space-y-1.5">
+        <Label htmlFor="spending_owner_email">{t.spendingControl.ownerEmail}</Label>
+        <Input
+          id="spending_owner_email"
+          type="email"
+          maxLength={255}
+          value={ownerEmail}
+          onChange={(e) => setOwnerEmail(e.target.value)}
+        />
+        <p className="text-xs text-muted-foreground">{t.spendingControl.ownerEmailHint}</p>
+      </div>
+      <Button
+        disabled={submitting}
+        onClick={() =>
+          onSubmit({
+            monthly_limit_minor: limit ? majorToMinor(limit) : 0,
+            blocked_categories: blocked,
+            owner_email: ownerEmail.trim(),
+          })
+        }
+      >
+        {submitting && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+        {t.common.save}
+      </Button>
+    </div>
+  )
+}
@@ -22,8 +22,22 @@ import {
   Tag,
   BookOpen,
   Megaphone,
+  Flag,
+  ShieldAlert,
   Send,
   Puzzle,
+  Store,
+  Building2,
+  Banknote,
+  ArchiveRestore,
+  Gift,
+  ClipboardList,
+  PackageOpen,
+  Scale,
+  ScrollText,
+  Ban,
+  Gauge,
+  Webhook,
 } from 'lucide-react'
 import { Button } from '@/components/ui/button'
 import { usePermission } from '@/hooks/use-permission'
@@ -80,12 +94,60 @@ const menuItems = [
     icon: Tag,
     permission: 'product.view',
   },
+  {
+    titleKey: 'giftCardManagement' as const,
+    href: '/admin/gift-cards',
+    icon: Gift,
+    permission: 'product.view',
+  },
+  {
+    titleKey: 'trashManagement' as const,
+    href: '/admin/trash',
+    icon: ArchiveRestore,
+    permission: 'product.view',
+  },
   {
     titleKey: 'orderManagement' as const,
     href: '/admin/orders',
     icon: Package,
     permission: 'order.view',
   },
+  {
+    titleKey: 'quoteManagement' as const,
+    href: '/admin/quotes',
+    icon: ClipboardList,
+    permission: 'order.view',
+  },
+  {
+    titleKey: 'returnManagement' as const,
+    href: '/admin/returns',
+    icon: PackageOpen,
+    permission: 'order.view',
+  },
+  {
+    titleKey: 'disputeManagement' as const,
+    href: '/admin/disputes',
+    icon: Scale,
+    permission: 'ticket.view',
+  },
+  {
+    titleKey: 'vendorManagement' as const,
+    href: '/admin/vendors',
+    icon: Store,
+    permission: 'vendor.view',
+  },
+  {
+    titleKey: 'businessAccountManagement' as const,
+    href: '/admin/business-accounts',
+    icon: Building2,
+    permission: 'business_account.view',
+  },
+  {
+    titleKey: 'codManagement' as const,
+    href: '/admin/cod-collections',
+    icon: Banknote,
+    permission: 'order.view',
+  },
   {
     titleKey: 'serialManagement' as const,
     href: '/admin/serials',
@@ -116,6 +178,30 @@ const menuItems = [
     icon: Megaphone,
     permission: 'announcement.view',
   },
+  {
+    titleKey: 'siteBannerManagement' as const,
+    href: '/admin/banners',
+    icon: Flag,
+    permission: 'announcement.view',
+  },
+  {
+    titleKey: 'moderationManagement' as const,
+    href: '/admin/moderation',
+    icon: ShieldAlert,
+    permission: 'moderation.view',
+  },
+  {
+    titleKey: 'blocklistManagement' as const,
+    href: '/admin/blocklist',
+    icon: Ban,
+    permission: 'user.view',
+  },
+  {
+    titleKey: 'riskManagement' as const,
+    href: '/admin/risk',
+    icon: Gauge,
+    permission: 'order.view',
+  },
   {
     titleKey: 'marketingManagement' as const,
     href: '/admin/marketing',
@@ -130,12 +216,24 @@ const menuItems = [
     superAdminOnly: true,
     pluginPlatformOnly: true,
   },
+  {
+    titleKey: 'policyManagement' as const,
+    href: '/admin/policies',
+    icon: ScrollText,
+    permission: 'system.config',
+  },
   {
     titleKey: 'paymentMethods' as const,
     href: '/admin/payment-methods',
     icon: CreditCard,
     permission: 'system.config',
   },
+  {
+    titleKey: 'inboundWebhookManagement' as const,
+    href: '/admin/inbound-webhooks',
+    icon: Webhook,
+    permission: 'system.config',
+  },
   {
     titleKey: 'apiKeys' as const,
     href: '/admin/api-keys',
@@ -0,0 +1,83 @@
+'use client'
+
+import { useMutation, useQuery } from '@tanstack/react-query'
+import { Banknote, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { getCODEligibility, payOrderCOD, type CODEligibility } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations, translateBizError } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface CashOnDeliveryCardProps {
+  orderNo: string
+  onPaid?: () => void
+}
+
+// 货到付款：符合国家与金额条件的实物订单可先发货，签收时向快递员付款
+export function CashOnDeliveryCard({ orderNo, onPaid }: CashOnDeliveryCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({
+    queryKey: ['codEligibility', orderNo],
+    queryFn: () => getCODEligibility(orderNo),
+  })
+  const eligibility: CODEligibility | null = data?.data || null
+
+  const payMutation = useMutation({
+    mutationFn: () => payOrderCOD(orderNo),
+    onSuccess: () => {
+      toast.success(t.cod.payOnDeliverySuccess)
+      onPaid?.()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.cod.payOnDeliveryFailed))
+    },
+  })
+
+  // 功能关闭或纯虚拟订单时不展示；其余不符合条件的情况展示原因
+  if (
+    !eligibility ||
+    eligibility.reason_key === 'cod.disabled' ||
+    eligibility.reason_key === 'cod.virtualOnly'
+  ) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Banknote className="h-4 w-4" />
+          {t.cod.payOnDelivery}
+        </CardTitle>
+        <CardDescription>{t.cod.payOnDeliveryDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="flex items-center justify-between text-sm">
+          <span className="text-muted-foreground">{t.cod.amountDue}</span>
+          <span className="font-medium">
+            {formatCurrency(eligibility.amount_minor, eligibility.currency)}
+          </span>
+        </div>
+        {!eligibility.eligible && eligibility.reason_key ? (
+          <p className="text-sm text-destructive">
+            {translateBizError(t, eligibility.reason_key, eligibility.params)}
+          </p>
+        ) : null}
+        <Button
+          className="w-full"
+          disabled={!eligibility.eligible || payMutation.isPending}
+          onClick={() => payMutation.mutate()}
+        >
+          {payMutation.isPending ? <Loader2 className="mr-2 h-4 w-4 animate-spin" /> : null}
+          {t.cod.payOnDeliveryConfirm}
+        </Button>
+      </CardContent>
+    </Card>
+  )
+}
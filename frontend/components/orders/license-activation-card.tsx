@@ -0,0 +1,121 @@
+'use client'
+
+import { useMutation, useQuery, useQueryClient, type QueryKey } from '@tanstack/react-query'
+import { MonitorSmartphone } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { LicenseActivation } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface LicenseActivationCardProps {
+  queryKey: QueryKey
+  fetchActivations: () => Promise<any>
+  deactivate: (activationId: number) => Promise<any>
+}
+
+// 卡密的设备激活历史，激活中的设备可解绑以释放名额；没有激活记录时不显示
+export function LicenseActivationCard({
+  queryKey,
+  fetchActivations,
+  deactivate,
+}: LicenseActivationCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+
+  const { data } = useQuery({ queryKey, queryFn: fetchActivations })
+  const activations: LicenseActivation[] = data?.data?.items || []
+
+  const deactivateMutation = useMutation({
+    mutationFn: (activationId: number) => deactivate(activationId),
+    onSuccess: () => {
+      toast.success(t.order.licenseDeviceDeactivated)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.licenseDeviceDeactivateFailed))
+    },
+  })
+
+  if (activations.length === 0) {
+    return null
+  }
+
+  const activeCount = activations.filter((activation) => activation.status === 'active').length
+  const deactivatedByLabels: Record<NonNullable<LicenseActivation['deactivated_by']>, string> = {
+    software: t.order.licenseDeactivatedBySoftware,
+    user: t.order.licenseDeactivatedByUser,
+    admin: t.order.licenseDeactivatedByAdmin,
+    transfer: t.order.licenseDeactivatedByTransfer,
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <MonitorSmartphone className="h-4 w-4" />
+          {t.order.licenseActivationsTitle}
+          <Badge variant="secondary">{activeCount}</Badge>
+        </CardTitle>
+        <CardDescription>{t.order.licenseActivationsDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {activations.map((activation) => (
+          <div key={activation.id} className="space-y-1 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <div className="flex flex-wrap items-center gap-2">
+                <span className="font-medium">
+                  {activation.device_name || activation.device_id}
+                </span>
+                <span className="font-mono text-xs text-muted-foreground">
+                  {activation.license_hint}
+                </span>
+                {activation.status === 'active' ? (
+                  <Badge>{t.order.licenseActivationActive}</Badge>
+                ) : (
+                  <Badge variant="outline">{t.order.licenseActivationDeactivated}</Badge>
+                )}
+              </div>
+              {activation.status === 'active' && (
+                <Button
+                  size="sm"
+                  variant="outline"
+                  disabled={deactivateMutation.isPending}
+                  onClick={() => deactivateMutation.mutate(activation.id)}
+                >
+                  {t.order.licenseDeactivate}
+                </Button>
+              )}
+            </div>
+            {activation.device_name && (
+              <p className="break-all font-mono text-xs text-muted-foreground">
+                {activation.device_id}
+              </p>
+            )}
+            <p className="text-xs text-muted-foreground">
+              {t.order.licenseActivatedAt}: {formatDate(activation.activated_at)}
+              {activation.last_seen_at
+                ? ` · ${t.order.licenseLastSeenAt}: ${formatDate(activation.last_seen_at)}`
+                : ''}
+              {activation.ip_address ? ` · ${activation.ip_address}` : ''}
+            </p>
+            {activation.status === 'deactivated' && activation.deactivated_at && (
+              <p className="text-xs text-muted-foreground">
+                {t.order.licenseDeactivatedAt}: {formatDate(activation.deactivated_at)}
+                {activation.deactivated_by
+                  ? ` · ${deactivatedByLabels[activation.deactivated_by]}`
+                  : ''}
+              </p>
+            )}
+          </div>
+        ))}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,92 @@
+'use client'
+
+import { useMutation, useQuery } from '@tanstack/react-query'
+import { Building2, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { getMyBusinessAccount, payOrderOnNetTerms, type BusinessAccount } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface NetTermsCardProps {
+  orderNo: string
+  currency: string
+  totalAmountMinor: number
+  onPaid?: () => void
+}
+
+// 已审核的企业账户可用账期付款：订单先发货，按账期开具发票
+export function NetTermsCard({ orderNo, currency, totalAmountMinor, onPaid }: NetTermsCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({
+    queryKey: ['myBusinessAccount'],
+    queryFn: getMyBusinessAccount,
+    staleTime: 60 * 1000,
+  })
+  const account: BusinessAccount | null = data?.data?.account || null
+
+  const payMutation = useMutation({
+    mutationFn: () => payOrderOnNetTerms(orderNo),
+    onSuccess: () => {
+      toast.success(t.businessAccount.payOnTermsSuccess)
+      onPaid?.()
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.businessAccount.payOnTermsFailed))
+    },
+  })
+
+  const credit = account?.credit
+  if (!account || account.status !== 'approved' || !credit) {
+    return null
+  }
+  // 币种不一致的订单不能使用账期，直接隐藏
+  if (credit.currency.toUpperCase() !== currency.toUpperCase()) {
+    return null
+  }
+
+  const sufficient = credit.can_use_terms && credit.available_minor >= totalAmountMinor
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Building2 className="h-4 w-4" />
+          {t.businessAccount.payOnTerms}
+        </CardTitle>
+        <CardDescription>
+          {t.businessAccount.payOnTermsDesc.replace('{days}', String(account.terms_days))}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="flex items-center justify-between text-sm">
+          <span className="text-muted-foreground">{t.businessAccount.availableCredit}</span>
+          <span className="font-medium">
+            {formatCurrency(credit.available_minor, credit.currency)}
+          </span>
+        </div>
+        {!sufficient ? (
+          <p className="text-sm text-destructive">
+            {account.credit_hold
+              ? t.businessAccount.creditHoldNotice
+              : t.businessAccount.insufficientCredit}
+          </p>
+        ) : null}
+        <Button
+          className="w-full"
+          disabled={!sufficient || payMutation.isPending}
+          onClick={() => payMutation.mutate()}
+        >
+          {payMutation.isPending ? <Loader2 className="mr-2 h-4 w-4 animate-spin" /> : null}
+          {t.businessAccount.payOnTermsConfirm}
+        </Button>
+      </CardContent>
+    </Card>
+  )
+}
@@ -28,6 +28,7 @@ import {
 } from 'lucide-react'
 import { cn, formatDate, formatCurrency } from '@/lib/utils'
 import type { Order } from '@/types/order'
+import type { PricingTraceEntry } from '@/lib/api'
 import type { VirtualProductStock, VirtualStockInlineIframe } from '@/types/product'
 import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
@@ -183,6 +184,14 @@ export function OrderDetail({
   const [showContent, setShowContent] = useState<Record<number, boolean>>({})
   const [showInlineIframe, setShowInlineIframe] = useState<Record<number, boolean>>({})
   const orderItems = Array.isArray(order.items) ? order.items : []
+  const shipment = order.shipment || null
+  const shipmentCheckpoints = Array.isArray(shipment?.checkpoints) ? shipment.checkpoints : []
+  const shipmentNeedsAttention =
+    shipment?.status === 'exception' || shipment?.status === 'failed_attempt'
+  // 注册失败原因仅在后台展示，用户接口不返回该字段
+  const shipmentRegisterError = !shipment?.registered_at ? shipment?.register_error || '' : ''
+  const shipmentStatusLabel = (status: string) =>
+    t.order.shipmentStatuses[status as keyof typeof t.order.shipmentStatuses] || status
   const serialGenerationStatus = String(
     order.serialGenerationStatus || order.serial_generation_status || ''
   ).trim()
@@ -213,6 +222,16 @@ export function OrderDetail({
     order.sourcePlatform || order.source_platform || order.platform || ''
   ).trim()
   const adminRemark = String(order.adminRemark || order.admin_remark || '').trim()
+  // 下单时的定价规则评估过程：用户只看到生效的优惠，后台同时列出未生效的规则及原因
+  const pricingTrace = (order.pricing_trace || []).filter(
+    (entry) => entry.applied || showOperationalMeta
+  )
+  const pricingEntryNote = (entry: PricingTraceEntry) => {
+    if (entry.applied) return ` -${formatCurrency(entry.discount_minor, order.currency)}`
+    if (!entry.reason) return ''
+    const reason = t.order.pricingSkipReason[entry.reason]
+    return ` · ${reason.replace('{rule}', entry.blocked_by || '')}`
+  }
   const buildSectionPluginContext = useCallback(
     (section: string, extra?: Record<string, any>) => ({
       ...(pluginSlotContext || {}),
@@ -559,6 +578,83 @@ export function OrderDetail({
                 {formatCurrency(order.total_amount_minor ?? 0, order.currency)}
               </dd>
             </div>
+            {(!!order.discount_amount_minor || pricingTrace.length > 0) && (
+              <div>
+                <dt className="text-muted-foreground">{t.order.discountTotal}</dt>
+                <dd className="font-medium">
+                  -{formatCurrency(order.discount_amount_minor ?? 0, order.currency)}
+                  {order.promo_code && (
+                    <span className="ml-2 font-mono text-xs font-normal text-muted-foreground">
+                      {order.promo_code}
+                    </span>
+                  )}
+                  {pricingTrace.length > 0 && (
+                    <ul className="mt-1 space-y-0.5 text-xs font-normal text-muted-foreground">
+                      {pricingTrace.map((entry, index) => (
+                        <li key={`${entry.source}-${entry.rule_id ?? entry.name}-${index}`}>
+                          <span className={entry.applied ? 'text-foreground' : undefined}>
+                            {entry.name}
+                          </span>
+                          {pricingEntryNote(entry)}
+                        </li>
+                      ))}
+                    </ul>
+                  )}
+                </dd>
+              </div>
+            )}
+            {!!order.payment_fee_minor && (
+              <div>
+                <dt className="text-muted-foreground">
+                  {order.payment_fee_minor > 0 ? t.order.paymentSurcharge : t.order.paymentDiscount}
+                </dt>
+                <dd className="font-medium">
+                  {order.payment_fee_minor > 0 ? '+' : '-'}
+                  {formatCurrency(Math.abs(order.payment_fee_minor), order.currency)}
+                  {order.payment_fee_retained && (
+                    <span className="ml-2 text-xs font-normal text-muted-foreground">
+                      {t.order.paymentSurchargeNonRefundable}
+                    </span>
+                  )}
+                </dd>
+              </div>
+            )}
+            {!!order.price_rounding_adjustment_minor && (
+              <div>
+                <dt className="text-muted-foreground">{t.order.priceRounding}</dt>
+                <dd className="font-medium">
+                  {order.price_rounding_adjustment_minor > 0 ? '+' : '-'}
+                  {formatCurrency(Math.abs(order.price_rounding_adjustment_minor), order.currency)}
+                  {showOperationalMeta && order.price_rounding_rule && (
+                    <span className="ml-2 font-mono text-xs font-normal text-muted-foreground">
+                      {order.price_rounding_rule}
+                    </span>
+                  )}
+                </dd>
+              </div>
+            )}
+            {!!order.manual_adjustment_minor && (
+              <div>
+                <dt className="text-muted-foreground">{t.order.manualAdjustment}</dt>
+                <dd className="font-medium">
+                  {order.manual_adjustment_minor > 0 ? '+' : '-'}
+                  {formatCurrency(Math.abs(order.manual_adjustment_minor), order.currency)}
+                </dd>
+              </div>
+            )}
+            {!!order.gift_card_amount_minor && (
+              <div>
+                <dt className="text-muted-foreground">{t.order.giftCardDeduction}</dt>
+                <dd className="font-medium">
+                  -{formatCurrency(order.gift_card_amount_minor, order.currency)}
+                  {order.gift_card_code && (
+                    <span className="ml-2 font-mono text-xs font-normal text-muted-foreground">
+                      {order.gift_card_code}
+                    </span>
+                  )}
+                </dd>
+              </div>
+            )}
             {showOperationalMeta && source && (
               <div>
                 <dt className="text-muted-foreground">{t.order.orderSource}</dt>
@@ -972,7 +1068,58 @@ export function OrderDetail({
                           <dd>{formatDate(order.shippedAt || order.shipped_at || '')}</dd>
                         </div>
                       )}
+                      {shipment && (
+                        <div className="flex flex-col sm:flex-row">
+                          <dt className="flex-shrink-0 text-muted-foreground sm:w-28">
+                            {t.order.shipmentStatus}
+                          </dt>
+                          <dd className="flex flex-wrap items-center gap-2">
+                            <Badge variant={shipmentNeedsAttention ? 'destructive' : 'outline'}>
+                              {shipmentStatusLabel(shipment.status)}
+                            </Badge>
+                            {showOperationalMeta && shipment.carrier && (
+                              <span className="font-mono text-xs text-muted-foreground">
+                                {shipment.provider} · {shipment.carrier}
+                              </span>
+                            )}
+                          </dd>
+                        </div>
+                      )}
+                      {shipment?.delivered_at && (
+                        <div className="flex flex-col sm:flex-row">
+                          <dt className="flex-shrink-0 text-muted-foreground sm:w-28">
+                            {t.order.shipmentDeliveredAt}
+                          </dt>
+                          <dd>{formatDate(shipment.delivered_at)}</dd>
+                        </div>
+                      )}
+                      {showOperationalMeta && shipmentRegisterError && (
+                        <p className="flex items-start gap-2 text-xs text-destructive">
+                          <AlertTriangle className="mt-0.5 h-3.5 w-3.5 flex-shrink-0" />
+                          <span className="break-all">
+                            {t.order.shipmentRegisterFailed.replace(
+                              '{error}',
+                              shipmentRegisterError
+                            )}
+                          </span>
+                        </p>
+                      )}
                     </dl>
+                    {shipmentCheckpoints.length > 0 && (
+                      <ol className="ml-6 mt-4 space-y-3 border-l pl-4 text-sm">
+                        {shipmentCheckpoints.map((checkpoint) => (
+                          <li key={checkpoint.id}>
+                            <p className="font-medium">
+                              {checkpoint.message || shipmentStatusLabel(checkpoint.status)}
+                            </p>
+                            <p className="text-xs text-muted-foreground">
+                              {formatDate(checkpoint.occurred_at)}
+                              {checkpoint.location ? ` · ${checkpoint.location}` : ''}
+                            </p>
+                          </li>
+                        ))}
+                      </ol>
+                    )}
                     {renderSectionPluginSlot('shipping.tracking.after', 'shipping', {
                       shipping_section: 'tracking',
                       has_tracking: true,
@@ -0,0 +1,121 @@
+'use client'
+
+import { useState } from 'react'
+import { useQuery, type QueryKey } from '@tanstack/react-query'
+import { FileDown, FileText, Files } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { OrderDocument } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface OrderDocumentsCardProps {
+  queryKey: QueryKey
+  fetchDocuments: () => Promise<any>
+  openDocument: (document: OrderDocument, item: number, format: 'html' | 'pdf') => Promise<void>
+  pdfEnabled?: boolean
+}
+
+// 订单的自定义文档（保修卡、送货单、正品证书等）；按商品生成的文档逐个商品列出，没有可用文档时不显示
+export function OrderDocumentsCard({
+  queryKey,
+  fetchDocuments,
+  openDocument,
+  pdfEnabled = false,
+}: OrderDocumentsCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [opening, setOpening] = useState<string | null>(null)
+
+  const { data } = useQuery({ queryKey, queryFn: fetchDocuments })
+  const documents: OrderDocument[] = data?.data || []
+
+  if (documents.length === 0) {
+    return null
+  }
+
+  const handleOpen = async (document: OrderDocument, item: number, format: 'html' | 'pdf') => {
+    const openingKey = `${document.key}:${item}:${format}`
+    if (opening) return
+    setOpening(openingKey)
+    try {
+      await openDocument(document, item, format)
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.order.documentOpenFailed))
+    } finally {
+      setOpening(null)
+    }
+  }
+
+  const renderActions = (document: OrderDocument, item: number) => (
+    <div className="flex shrink-0 gap-2">
+      <Button
+        variant="outline"
+        size="sm"
+        disabled={opening !== null}
+        onClick={() => handleOpen(document, item, 'html')}
+      >
+        <FileText className="mr-1.5 h-4 w-4" />
+        {opening === `${document.key}:${item}:html` ? t.common.loading : t.order.documentView}
+      </Button>
+      {pdfEnabled && (
+        <Button
+          variant="outline"
+          size="sm"
+          disabled={opening !== null}
+          onClick={() => handleOpen(document, item, 'pdf')}
+        >
+          <FileDown className="mr-1.5 h-4 w-4" />
+          {opening === `${document.key}:${item}:pdf` ? t.common.loading : t.order.documentPdf}
+        </Button>
+      )}
+    </div>
+  )
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Files className="h-4 w-4" />
+          {t.order.documentsTitle}
+        </CardTitle>
+        <CardDescription>{t.order.documentsDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {documents.map((document) => (
+          <div key={document.key} className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <div>
+                <div className="font-medium">{document.name}</div>
+                {document.description && (
+                  <div className="text-xs text-muted-foreground">{document.description}</div>
+                )}
+              </div>
+              {document.binding === 'order' && renderActions(document, 0)}
+            </div>
+            {document.binding === 'order_item' &&
+              (document.items || []).map((item) => (
+                <div
+                  key={item.index}
+                  className="flex flex-wrap items-center justify-between gap-2 border-t pt-2"
+                >
+                  <div>
+                    <span>{item.name}</span>
+                    {item.sku && (
+                      <span className="ml-2 font-mono text-xs text-muted-foreground">
+                        {item.sku}
+                      </span>
+                    )}
+                  </div>
+                  {renderActions(document, item.index)}
+                </div>
+              ))}
+          </div>
+        ))}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,140 @@
+'use client'
+
+import { useState } from 'react'
+import { Download, Loader2 } from 'lucide-react'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+export type OrderExportFormat = 'csv' | 'xlsx'
+
+export interface OrderExportOptions {
+  format: OrderExportFormat
+  from?: string
+  to?: string
+  sku?: string
+}
+
+interface OrderExportDialogProps {
+  open: boolean
+  onOpenChange: (open: boolean) => void
+  defaultFormat?: OrderExportFormat
+  exporting?: boolean
+  onExport: (options: OrderExportOptions) => void | Promise<void>
+}
+
+// 导出订单：选择格式、创建日期范围与 SKU，其余筛选条件沿用列表当前筛选
+export function OrderExportDialog({
+  open,
+  onOpenChange,
+  defaultFormat = 'csv',
+  exporting = false,
+  onExport,
+}: OrderExportDialogProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [format, setFormat] = useState<OrderExportFormat>(defaultFormat)
+  const [from, setFrom] = useState('')
+  const [to, setTo] = useState('')
+  const [sku, setSku] = useState('')
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault()
+    onExport({
+      format,
+      from: from || undefined,
+      to: to || undefined,
+      sku: sku.trim() || undefined,
+    })
+  }
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="sm:max-w-md">
+        <form onSubmit={handleSubmit} className="space-y-4">
+          <DialogHeader>
+            <DialogTitle>{t.order.exportOrders}</DialogTitle>
+            <DialogDescription>{t.order.exportOrdersDesc}</DialogDescription>
+          </DialogHeader>
+
+          <div className="space-y-1.5">
+            <Label htmlFor="order-export-format">{t.order.exportFormat}</Label>
+            <Select value={format} onValueChange={(value) => setFormat(value as OrderExportFormat)}>
+              <SelectTrigger id="order-export-format">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="csv">CSV</SelectItem>
+                <SelectItem value="xlsx">Excel (.xlsx)</SelectItem>
+              </SelectContent>
+            </Select>
+          </div>
+
+          <div className="grid grid-cols-2 gap-3">
+            <div className="space-y-1.5">
+              <Label htmlFor="order-export-from">{t.order.exportFrom}</Label>
+              <Input
+                id="order-export-from"
+                type="date"
+                value={from}
+                max={to || undefined}
+                onChange={(e) => setFrom(e.target.value)}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label htmlFor="order-export-to">{t.order.exportTo}</Label>
+              <Input
+                id="order-export-to"
+                type="date"
+                value={to}
+                min={from || undefined}
+                onChange={(e) => setTo(e.target.value)}
+              />
+            </div>
+          </div>
+
+          <div className="space-y-1.5">
+            <Label htmlFor="order-export-sku">{t.order.exportSku}</Label>
+            <Input
+              id="order-export-sku"
+              value={sku}
+              placeholder={t.order.exportSkuPlaceholder}
+              onChange={(e) => setSku(e.target.value)}
+            />
+          </div>
+
+          <DialogFooter>
+            <Button type="button" variant="outline" onClick={() => onOpenChange(false)}>
+              {t.common.cancel}
+            </Button>
+            <Button type="submit" disabled={exporting}>
+              {exporting ? (
+                <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+              ) : (
+                <Download className="mr-2 h-4 w-4" />
+              )}
+              {t.order.exportDownload}
+            </Button>
+          </DialogFooter>
+        </form>
+      </DialogContent>
+    </Dialog>
+  )
+}
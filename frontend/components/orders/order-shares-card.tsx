@@ -0,0 +1,155 @@
+'use client'
+
+import Link from 'next/link'
+import { useMutation } from '@tanstack/react-query'
+import { Headphones, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { OrderShare, OrderShareSettings, revokeOrderShare, updateOrderShare } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Switch } from '@/components/ui/switch'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+// 可选的分享有效期（小时），0 表示不过期，最长 30 天与后端保持一致
+export const ORDER_SHARE_DURATIONS = [0, 24, 72, 168, 720]
+
+export function useOrderShareDurationLabels(): Record<number, string> {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  return {
+    0: t.ticket.shareDurationForever,
+    24: t.ticket.shareDuration24h,
+    72: t.ticket.shareDuration3d,
+    168: t.ticket.shareDuration7d,
+    720: t.ticket.shareDuration30d,
+  }
+}
+
+interface OrderSharesCardProps {
+  orderNo: string
+  shares: OrderShare[]
+  onChanged?: () => void
+}
+
+// 用户查看订单当前分享给客服的工单，可修改有效期、收货信息可见性或撤销分享
+export function OrderSharesCard({ orderNo, shares, onChanged }: OrderSharesCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const durationLabels = useOrderShareDurationLabels()
+
+  const updateMutation = useMutation({
+    mutationFn: ({ ticketId, data }: { ticketId: number; data: OrderShareSettings }) =>
+      updateOrderShare(orderNo, ticketId, data),
+    onSuccess: () => {
+      toast.success(t.order.orderShareUpdated)
+      onChanged?.()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.orderShareUpdateFailed))
+    },
+  })
+
+  const revokeMutation = useMutation({
+    mutationFn: (ticketId: number) => revokeOrderShare(orderNo, ticketId),
+    onSuccess: () => {
+      toast.success(t.order.orderShareRevoked)
+      onChanged?.()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.orderShareRevokeFailed))
+    },
+  })
+
+  if (shares.length === 0) {
+    return null
+  }
+
+  const busy = updateMutation.isPending || revokeMutation.isPending
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Headphones className="h-4 w-4" />
+          {t.order.orderSharesTitle}
+        </CardTitle>
+        <CardDescription>{t.order.orderSharesDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {shares.map((share) => (
+          <div key={share.ticket_id} className="space-y-3 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <Link href={`/tickets/${share.ticket_id}`} className="font-medium hover:underline">
+                {share.ticket_no} · {share.ticket_subject}
+              </Link>
+              <span className="text-xs text-muted-foreground">
+                {share.expires_at
+                  ? `${t.order.orderShareExpiresAt}: ${formatDate(share.expires_at)}`
+                  : t.order.orderShareNoExpiry}
+              </span>
+            </div>
+            <div className="flex flex-wrap items-center gap-3">
+              <label className="flex items-center gap-2 text-xs">
+                <Switch
+                  checked={share.include_receiver_info}
+                  disabled={busy}
+                  onCheckedChange={(checked) =>
+                    updateMutation.mutate({
+                      ticketId: share.ticket_id,
+                      data: { include_receiver_info: checked },
+                    })
+                  }
+                />
+                {t.ticket.shareIncludeReceiverInfo}
+              </label>
+              <Select
+                value=""
+                disabled={busy}
+                onValueChange={(value) =>
+                  updateMutation.mutate({
+                    ticketId: share.ticket_id,
+                    data: { expires_in_hours: Number(value) },
+                  })
+                }
+              >
+                <SelectTrigger className="h-8 w-40">
+                  <SelectValue placeholder={t.order.orderShareRenew} />
+                </SelectTrigger>
+                <SelectContent>
+                  {ORDER_SHARE_DURATIONS.map((hours) => (
+                    <SelectItem key={hours} value={hours.toString()}>
+                      {durationLabels[hours]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+              <Button
+                variant="outline"
+                size="sm"
+                className="ml-auto"
+                disabled={busy}
+                onClick={() => revokeMutation.mutate(share.ticket_id)}
+              >
+                {revokeMutation.isPending && revokeMutation.variables === share.ticket_id ? (
+                  <Loader2 className="mr-1.5 h-3.5 w-3.5 animate-spin" />
+                ) : null}
+                {t.order.orderShareRevoke}
+              </Button>
+            </div>
+          </div>
+        ))}
+      </CardContent>
+    </Card>
+  )
+}
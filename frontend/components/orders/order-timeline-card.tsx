@@ -0,0 +1,174 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient, type QueryKey } from '@tanstack/react-query'
+import { CreditCard, Loader2, MessageSquare, RefreshCw, ShoppingCart, Truck } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { OrderEvent, OrderEventType } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Textarea } from '@/components/ui/textarea'
+
+const eventIcons: Record<OrderEventType, typeof ShoppingCart> = {
+  created: ShoppingCart,
+  status_changed: RefreshCw,
+  payment: CreditCard,
+  shipment: Truck,
+  remark: MessageSquare,
+}
+
+const ADMIN_REMARK_MAX_LENGTH = 1000
+
+interface OrderTimelineCardProps {
+  queryKey: QueryKey
+  fetchTimeline: () => Promise<any>
+  // 传入后显示管理员备注输入框
+  addRemark?: (remark: string) => Promise<any>
+}
+
+// 订单时间线：下单、状态变更、付款、发货与管理员备注，按时间正序展示
+export function OrderTimelineCard({ queryKey, fetchTimeline, addRemark }: OrderTimelineCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [remark, setRemark] = useState('')
+
+  const { data, isLoading } = useQuery({ queryKey, queryFn: fetchTimeline })
+  const events: OrderEvent[] = data?.data?.events || []
+
+  const remarkMutation = useMutation({
+    mutationFn: () => addRemark!(remark.trim()),
+    onSuccess: () => {
+      toast.success(t.order.orderTimelineRemarkAdded)
+      setRemark('')
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.orderTimelineRemarkFailed))
+    },
+  })
+
+  const statusLabel = (status?: string) =>
+    (status && t.order.status[status as keyof typeof t.order.status]) || status || ''
+
+  const operatorLabels: Record<OrderEvent['operator_type'], string> = {
+    user: t.order.orderTimelineOperatorUser,
+    admin: t.order.orderTimelineOperatorAdmin,
+    system: t.order.orderTimelineOperatorSystem,
+  }
+
+  const eventTitle = (event: OrderEvent) => {
+    switch (event.type) {
+      case 'created':
+        return t.order.orderTimelineCreated
+      case 'payment':
+        if (event.source === 'payment.select') {
+          return t.order.orderTimelinePaymentSelected.replace(
+            '{method}',
+            String(event.data?.payment_method || '')
+          )
+        }
+        if (event.source === 'payment.paid') {
+          return t.order.orderTimelinePaid
+        }
+        if (event.source === 'payment.cod_collected') {
+          return t.order.orderTimelineCODCollected
+        }
+        return t.order.orderTimelinePaymentUnconfirmed
+      case 'shipment':
+        if (event.source === 'carrier_tracking') {
+          const status = String(event.data?.shipment_status || event.message || '')
+          return t.order.orderTimelineShipmentUpdate.replace(
+            '{status}',
+            t.order.shipmentStatuses[status as keyof typeof t.order.shipmentStatuses] || status
+          )
+        }
+        return t.order.orderTimelineShipped
+      case 'remark':
+        return t.order.orderTimelineRemark
+      default:
+        return t.order.orderTimelineStatusChanged.replace('{status}', statusLabel(event.to_status))
+    }
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-base">{t.order.orderTimelineTitle}</CardTitle>
+        <CardDescription>{t.order.orderTimelineDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {isLoading ? (
+          <div className="flex justify-center py-4">
+            <Loader2 className="h-5 w-5 animate-spin text-muted-foreground" />
+          </div>
+        ) : events.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.order.orderTimelineEmpty}</p>
+        ) : (
+          <ol className="relative space-y-4 border-l pl-6">
+            {events.map((event, index) => {
+              const Icon = eventIcons[event.type] || RefreshCw
+              const trackingNo = event.data?.tracking_no
+              return (
+                <li key={event.id || `${event.type}-${index}`} className="relative">
+                  <span className="absolute -left-[2.0625rem] flex h-6 w-6 items-center justify-center rounded-full border bg-background">
+                    <Icon className="h-3 w-3 text-muted-foreground" />
+                  </span>
+                  <div className="flex flex-wrap items-center gap-2 text-sm font-medium">
+                    {eventTitle(event)}
+                    {event.internal && (
+                      <Badge variant="secondary">{t.order.orderTimelineInternal}</Badge>
+                    )}
+                  </div>
+                  <p className="text-xs text-muted-foreground">
+                    {formatDate(event.created_at)} · {operatorLabels[event.operator_type]}
+                    {event.operator_id ? ` #${event.operator_id}` : ''}
+                  </p>
+                  {trackingNo ? (
+                    <p className="text-sm text-muted-foreground">
+                      {t.order.trackingNo}: {String(trackingNo)}
+                    </p>
+                  ) : null}
+                  {event.message && event.source !== 'carrier_tracking' ? (
+                    <p className="text-sm text-muted-foreground">{event.message}</p>
+                  ) : null}
+                  {event.remark ? (
+                    <p className="whitespace-pre-wrap text-sm">{event.remark}</p>
+                  ) : null}
+                </li>
+              )
+            })}
+          </ol>
+        )}
+
+        {addRemark && (
+          <div className="space-y-2 border-t pt-4">
+            <Textarea
+              value={remark}
+              maxLength={ADMIN_REMARK_MAX_LENGTH}
+              placeholder={t.order.orderTimelineRemarkPlaceholder}
+              onChange={(e) => setRemark(e.target.value)}
+              rows={3}
+            />
+            <div className="flex justify-end">
+              <Button
+                size="sm"
+                disabled={!remark.trim() || remarkMutation.isPending}
+                onClick={() => remarkMutation.mutate()}
+              >
+                {remarkMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.order.orderTimelineAddRemark}
+              </Button>
+            </div>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,208 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Loader2, PackageMinus } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import {
+  OrderRefund,
+  OrderRefundStatus,
+  createOrderPartialRefund,
+  getOrderPartialRefunds,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate, formatPrice } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import type { OrderItem } from '@/types/order'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+
+interface PartialRefundCardProps {
+  orderNo: string
+  items: OrderItem[]
+  canRequest: boolean
+  onChanged?: () => void
+}
+
+// 用户按订单项申请部分退款，金额按下单单价计算，审核与退款进度
+export function PartialRefundCard({
+  orderNo,
+  items,
+  canRequest,
+  onChanged,
+}: PartialRefundCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [formOpen, setFormOpen] = useState(false)
+  const [quantities, setQuantities] = useState<Record<number, number>>({})
+  const [reason, setReason] = useState('')
+
+  const queryKey = ['orderPartialRefunds', orderNo]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getOrderPartialRefunds(orderNo),
+  })
+  const refunds: OrderRefund[] = data?.data?.items || []
+
+  // 未被拒绝的记录占用可退数量
+  const committed: Record<number, number> = {}
+  refunds
+    .filter((refund) => refund.status !== 'rejected')
+    .forEach((refund) =>
+      refund.items.forEach((item) => {
+        committed[item.item_index] = (committed[item.item_index] || 0) + item.quantity
+      })
+    )
+  const available = items.map((item, index) => Math.max(item.quantity - (committed[index] || 0), 0))
+  const canRequestMore = canRequest && available.some((quantity) => quantity > 0)
+
+  const statusLabels: Record<OrderRefundStatus, string> = {
+    requested: t.order.partialRefundStatusRequested,
+    approved: t.order.partialRefundStatusApproved,
+    refunded: t.order.partialRefundStatusRefunded,
+    rejected: t.order.partialRefundStatusRejected,
+  }
+
+  const selected = Object.entries(quantities)
+    .filter(([, quantity]) => quantity > 0)
+    .map(([index, quantity]) => ({ item_index: Number(index), quantity }))
+
+  const resetForm = () => {
+    setFormOpen(false)
+    setQuantities({})
+    setReason('')
+  }
+
+  const submitMutation = useMutation({
+    mutationFn: () => createOrderPartialRefund(orderNo, { items: selected, reason: reason.trim() }),
+    onSuccess: () => {
+      toast.success(t.order.partialRefundSubmitted)
+      resetForm()
+      queryClient.invalidateQueries({ queryKey })
+      onChanged?.()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.partialRefundFailed))
+    },
+  })
+
+  if (refunds.length === 0 && !canRequestMore) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <PackageMinus className="h-4 w-4" />
+          {t.order.partialRefundTitle}
+        </CardTitle>
+        <CardDescription>{t.order.partialRefundDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {refunds.map((refund) => (
+          <div key={refund.id} className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <div className="flex items-center gap-2">
+                <Badge variant={refund.status === 'rejected' ? 'destructive' : 'secondary'}>
+                  {statusLabels[refund.status]}
+                </Badge>
+                <span className="font-medium">
+                  {formatPrice(refund.amount_minor, refund.currency)}
+                </span>
+              </div>
+              <span className="text-xs text-muted-foreground">{formatDate(refund.created_at)}</span>
+            </div>
+            <ul className="text-xs text-muted-foreground">
+              {refund.items.map((item) => (
+                <li key={item.item_index}>
+                  {item.name} ({item.sku}) × {item.quantity}
+                </li>
+              ))}
+            </ul>
+            <p className="whitespace-pre-wrap break-words text-muted-foreground">{refund.reason}</p>
+            {refund.review_note ? (
+              <p className="text-xs">
+                <span className="font-medium">{t.order.partialRefundReviewNote}: </span>
+                {refund.review_note}
+              </p>
+            ) : null}
+          </div>
+        ))}
+
+        {canRequestMore && !formOpen ? (
+          <Button variant="outline" size="sm" onClick={() => setFormOpen(true)}>
+            {t.order.partialRefundButton}
+          </Button>
+        ) : null}
+
+        {canRequestMore && formOpen ? (
+          <div className="space-y-4 rounded-md border p-4">
+            <div className="space-y-2">
+              <Label>{t.order.partialRefundItems} *</Label>
+              {items.map((item, index) => (
+                <div
+                  key={`${item.sku}-${index}`}
+                  className="flex items-center justify-between gap-3"
+                >
+                  <div className="min-w-0 text-sm">
+                    <div className="truncate">{item.name}</div>
+                    <div className="text-xs text-muted-foreground">
+                      {t.order.partialRefundAvailable.replace('{count}', String(available[index]))}
+                    </div>
+                  </div>
+                  <Input
+                    type="number"
+                    min={0}
+                    max={available[index]}
+                    disabled={available[index] === 0}
+                    className="w-20"
+                    value={quantities[index] ?? 0}
+                    onChange={(e) => {
+                      const value = Math.min(
+                        Math.max(parseInt(e.target.value, 10) || 0, 0),
+                        available[index]
+                      )
+                      setQuantities((prev) => ({ ...prev, [index]: value }))
+                    }}
+                  />
+                </div>
+              ))}
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.order.partialRefundReason} *</Label>
+              <Textarea
+                value={reason}
+                onChange={(e) => setReason(e.target.value)}
+                placeholder={t.order.partialRefundReasonPlaceholder}
+                maxLength={1000}
+                rows={3}
+              />
+            </div>
+            <p className="text-xs text-muted-foreground">{t.order.partialRefundAmountHint}</p>
+            <div className="flex flex-wrap gap-2">
+              <Button
+                size="sm"
+                disabled={selected.length === 0 || !reason.trim() || submitMutation.isPending}
+                onClick={() => submitMutation.mutate()}
+              >
+                {submitMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.order.partialRefundSubmit}
+              </Button>
+              <Button variant="ghost" size="sm" onClick={resetForm}>
+                {t.common.cancel}
+              </Button>
+            </div>
+          </div>
+        ) : null}
+      </CardContent>
+    </Card>
+  )
+}
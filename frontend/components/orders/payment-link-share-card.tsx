@@ -0,0 +1,75 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import { Copy, Link2, Loader2 } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { createOrderPaymentLink, type PaymentLinkResult } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+
+// 分享付款链接：代付人（如企业财务）无需登录即可为订单付款
+export function PaymentLinkShareCard({ orderNo }: { orderNo: string }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [link, setLink] = useState<PaymentLinkResult | null>(null)
+
+  const createMutation = useMutation({
+    mutationFn: () => createOrderPaymentLink(orderNo),
+    onSuccess: (response: any) => {
+      setLink(response?.data || null)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.paymentLink.createFailed))
+    },
+  })
+
+  const handleCopy = async () => {
+    if (!link?.url || typeof navigator === 'undefined' || !navigator.clipboard) return
+    await navigator.clipboard.writeText(link.url)
+    toast.success(t.paymentLink.copied)
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Link2 className="h-4 w-4" />
+          {t.paymentLink.share}
+        </CardTitle>
+        <CardDescription>{t.paymentLink.dialogDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {link ? (
+          <>
+            <div className="flex gap-2">
+              <Input readOnly value={link.url} onFocus={(e) => e.target.select()} />
+              <Button variant="outline" size="icon" onClick={handleCopy} title={t.paymentLink.copy}>
+                <Copy className="h-4 w-4" />
+              </Button>
+            </div>
+            <p className="text-xs text-muted-foreground">
+              {t.paymentLink.expiresAt.replace('{time}', formatDate(link.expires_at))}
+            </p>
+          </>
+        ) : (
+          <Button
+            variant="outline"
+            className="w-full"
+            disabled={createMutation.isPending}
+            onClick={() => createMutation.mutate()}
+          >
+            {createMutation.isPending ? <Loader2 className="mr-2 h-4 w-4 animate-spin" /> : null}
+            {t.paymentLink.generate}
+          </Button>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
@@ -2,8 +2,13 @@
 
 import { useEffect, useRef, useState } from 'react'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
-import { selectOrderPaymentMethod, PaymentCardResult } from '@/lib/api'
+import {
+  selectOrderPaymentMethod,
+  sendCheckoutAbandonBeacon,
+  PaymentCardResult,
+} from '@/lib/api'
 import { resolveApiErrorMessage } from '@/lib/api-error'
+import { trackCheckoutStep } from '@/lib/analytics-events'
 import {
   getOrderPaymentInfoQueryKey,
   getOrderPaymentInfoQueryOptions,
@@ -16,6 +21,7 @@ import { Button } from '@/components/ui/button'
 import { Badge } from '@/components/ui/badge'
 import { SandboxedHtmlFrame } from '@/components/ui/sandboxed-html-frame'
 import { resolvePaymentMethodIcon } from '@/lib/payment-method-icons'
+import { formatPaymentFeeRule } from '@/lib/payment-fee'
 import { CreditCard, Check, Loader2, ChevronDown, ChevronUp, AlertTriangle } from 'lucide-react'
 import { useLocale } from '@/hooks/use-locale'
 import { getTranslations } from '@/lib/i18n'
@@ -35,6 +41,7 @@ const localizedBuiltinPaymentMethodDescriptions: Record<string, { zh: string; en
 
 interface PaymentMethodCardProps {
   orderNo: string
+  currency?: string
   onPaymentSelected?: () => void
   pluginSlotNamespace?: string
   pluginSlotContext?: Record<string, any>
@@ -43,6 +50,7 @@ interface PaymentMethodCardProps {
 
 export function PaymentMethodCard({
   orderNo,
+  currency,
   onPaymentSelected,
   pluginSlotNamespace,
   pluginSlotContext,
@@ -85,6 +93,19 @@ export function PaymentMethodCard({
     toast.error(message)
   }, [paymentInfoError, t])
 
+  // 用户离开付款页时上报，便于后端在宽限期后提前释放预留库存
+  useEffect(() => {
+    const handlePageHide = (event: PageTransitionEvent) => {
+      if (event.persisted) return
+      sendCheckoutAbandonBeacon(orderNo, 'page_hide')
+    }
+    window.addEventListener('pagehide', handlePageHide)
+    return () => {
+      window.removeEventListener('pagehide', handlePageHide)
+      sendCheckoutAbandonBeacon(orderNo, 'navigate_away')
+    }
+  }, [orderNo])
+
   // 获取可用付款方式列表（用于更换时）
   const { data: methodsData, isLoading: methodsLoading } = useQuery({
     ...getUserPaymentMethodsQueryOptions(),
@@ -97,6 +118,7 @@ export function PaymentMethodCard({
     onSuccess: (response) => {
       setIsChanging(false)
       setPaymentInfoErrorMessage('')
+      trackCheckoutStep('payment')
       // 直接用 select-payment 返回的数据更新缓存，避免再次调用 payment-info 触发重复的 JSVM 执行
       const selectedMethod = availableMethods.find((m: any) => m.id === selectedId)
       if (selectedMethod && response?.data) {
@@ -317,6 +339,11 @@ export function PaymentMethodCard({
                   <div className="grid gap-3">
                     {availableMethods.map((method: any) => {
                       const methodDescription = getMethodDescription(method)
+                      const feeRule = formatPaymentFeeRule(
+                        method.fee_rate,
+                        method.fee_fixed_minor,
+                        currency
+                      )
 
                       return (
                         <button
@@ -343,6 +370,17 @@ export function PaymentMethodCard({
                             <div className="mt-1 line-clamp-2 break-words text-sm leading-5 text-muted-foreground">
                               {methodDescription}
                             </div>
+                            {feeRule ? (
+                              <div
+                                className={`mt-1 text-xs font-medium ${
+                                  feeRule.startsWith('-')
+                                    ? 'text-emerald-600 dark:text-emerald-400'
+                                    : 'text-amber-600 dark:text-amber-400'
+                                }`}
+                              >
+                                {t.order.paymentMethodFee.replace('{fee}', feeRule)}
+                              </div>
+                            ) : null}
                           </div>
                           {selectedId === method.id ? (
                             <Badge className="shrink-0 gap-1">
@@ -0,0 +1,249 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import Link from 'next/link'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ImagePlus, Loader2, MessageSquare, Undo2, X } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import {
+  RefundRequest,
+  RefundResolution,
+  createRefundRequest,
+  getOrderRefundRequests,
+  uploadTicketIntakeAttachment,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const RESOLUTIONS: RefundResolution[] = ['full_refund', 'partial_refund', 'replacement', 'other']
+const MAX_EVIDENCE = 5
+
+interface RefundRequestCardProps {
+  orderNo: string
+  canRequest: boolean
+  onChanged?: () => void
+}
+
+// 用户发起退款申请，审核进度与关联工单
+export function RefundRequestCard({ orderNo, canRequest, onChanged }: RefundRequestCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const fileInputRef = useRef<HTMLInputElement>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [reason, setReason] = useState('')
+  const [resolution, setResolution] = useState<RefundResolution>('full_refund')
+  const [evidence, setEvidence] = useState<string[]>([])
+  const [uploading, setUploading] = useState(false)
+
+  const queryKey = ['orderRefundRequests', orderNo]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getOrderRefundRequests(orderNo),
+  })
+  const requests: RefundRequest[] = data?.data?.items || []
+  const hasPending = requests.some((item) => item.status === 'pending')
+
+  const resolutionLabels: Record<RefundResolution, string> = {
+    full_refund: t.order.refundRequestResolutionFullRefund,
+    partial_refund: t.order.refundRequestResolutionPartialRefund,
+    replacement: t.order.refundRequestResolutionReplacement,
+    other: t.order.refundRequestResolutionOther,
+  }
+  const statusLabels: Record<RefundRequest['status'], string> = {
+    pending: t.order.refundRequestStatusPending,
+    approved: t.order.refundRequestStatusApproved,
+    rejected: t.order.refundRequestStatusRejected,
+  }
+
+  const submitMutation = useMutation({
+    mutationFn: () => createRefundRequest(orderNo, { reason: reason.trim(), resolution, evidence }),
+    onSuccess: () => {
+      toast.success(t.order.refundRequestSubmitted)
+      setFormOpen(false)
+      setReason('')
+      setResolution('full_refund')
+      setEvidence([])
+      queryClient.invalidateQueries({ queryKey })
+      onChanged?.()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.refundRequestFailed))
+    },
+  })
+
+  const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    e.target.value = ''
+    if (!file) return
+    setUploading(true)
+    try {
+      const res = await uploadTicketIntakeAttachment(file)
+      const url = res.data?.url
+      if (url) {
+        setEvidence((prev) => [...prev, url])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+    } finally {
+      setUploading(false)
+    }
+  }
+
+  if (requests.length === 0 && !canRequest) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Undo2 className="h-4 w-4" />
+          {t.order.refundRequestTitle}
+        </CardTitle>
+        <CardDescription>{t.order.refundRequestDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {requests.map((item) => (
+          <div key={item.id} className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <div className="flex items-center gap-2">
+                <Badge variant={item.status === 'rejected' ? 'destructive' : 'secondary'}>
+                  {statusLabels[item.status]}
+                </Badge>
+                <span className="font-medium">{resolutionLabels[item.resolution]}</span>
+              </div>
+              <span className="text-xs text-muted-foreground">{formatDate(item.created_at)}</span>
+            </div>
+            <p className="whitespace-pre-wrap break-words text-muted-foreground">{item.reason}</p>
+            {item.review_note ? (
+              <p className="text-xs">
+                <span className="font-medium">{t.order.refundRequestReviewNote}: </span>
+                {item.review_note}
+              </p>
+            ) : null}
+            {item.ticket_id ? (
+              <Button asChild variant="link" size="sm" className="h-auto p-0">
+                <Link href={`/tickets/${item.ticket_id}`}>
+                  <MessageSquare className="mr-1.5 h-3.5 w-3.5" />
+                  {t.order.refundRequestViewTicket}
+                </Link>
+              </Button>
+            ) : null}
+          </div>
+        ))}
+
+        {canRequest && !hasPending && !formOpen ? (
+          <Button variant="outline" size="sm" onClick={() => setFormOpen(true)}>
+            {t.order.refundRequestButton}
+          </Button>
+        ) : null}
+
+        {canRequest && !hasPending && formOpen ? (
+          <div className="space-y-4 rounded-md border p-4">
+            <div className="space-y-1.5">
+              <Label>{t.order.refundRequestResolution}</Label>
+              <Select
+                value={resolution}
+                onValueChange={(value) => setResolution(value as RefundResolution)}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {RESOLUTIONS.map((value) => (
+                    <SelectItem key={value} value={value}>
+                      {resolutionLabels[value]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.order.refundRequestReason} *</Label>
+              <Textarea
+                value={reason}
+                onChange={(e) => setReason(e.target.value)}
+                placeholder={t.order.refundRequestReasonPlaceholder}
+                maxLength={1000}
+                rows={4}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.order.refundRequestEvidence}</Label>
+              <p className="text-xs text-muted-foreground">{t.order.refundRequestEvidenceHint}</p>
+              <div className="flex flex-wrap gap-2">
+                {evidence.map((url) => (
+                  <div key={url} className="relative h-16 w-16 overflow-hidden rounded border">
+                    {/* eslint-disable-next-line @next/next/no-img-element */}
+                    <img src={url} alt="" className="h-full w-full object-cover" />
+                    <button
+                      type="button"
+                      className="absolute right-0 top-0 rounded-bl bg-background/80 p-0.5"
+                      aria-label={t.order.refundRequestRemoveEvidence}
+                      onClick={() => setEvidence((prev) => prev.filter((item) => item !== url))}
+                    >
+                      <X className="h-3 w-3" />
+                    </button>
+                  </div>
+                ))}
+                {evidence.length < MAX_EVIDENCE ? (
+                  <Button
+                    type="button"
+                    variant="outline"
+                    className="h-16 w-16 p-0"
+                    disabled={uploading}
+                    aria-label={t.order.refundRequestUploadEvidence}
+                    title={t.order.refundRequestUploadEvidence}
+                    onClick={() => fileInputRef.current?.click()}
+                  >
+                    {uploading ? (
+                      <Loader2 className="h-4 w-4 animate-spin" />
+                    ) : (
+                      <ImagePlus className="h-4 w-4" />
+                    )}
+                  </Button>
+                ) : null}
+              </div>
+              <input
+                ref={fileInputRef}
+                type="file"
+                accept="image/*"
+                className="hidden"
+                onChange={handleFileChange}
+              />
+            </div>
+            <div className="flex flex-wrap gap-2">
+              <Button
+                size="sm"
+                disabled={!reason.trim() || uploading || submitMutation.isPending}
+                onClick={() => submitMutation.mutate()}
+              >
+                {submitMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.order.refundRequestSubmit}
+              </Button>
+              <Button variant="ghost" size="sm" onClick={() => setFormOpen(false)}>
+                {t.common.cancel}
+              </Button>
+            </div>
+          </div>
+        ) : null}
+      </CardContent>
+    </Card>
+  )
+}
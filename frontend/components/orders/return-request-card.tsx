@@ -0,0 +1,409 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ImagePlus, Loader2, PackageOpen, X } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import {
+  ReturnReason,
+  ReturnRequest,
+  ReturnStatus,
+  createReturnRequest,
+  getOrderReturns,
+  submitReturnTracking,
+  uploadTicketIntakeAttachment,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import type { OrderItem } from '@/types/order'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const REASONS: ReturnReason[] = [
+  'defective',
+  'damaged',
+  'wrong_item',
+  'not_as_described',
+  'no_longer_needed',
+  'other',
+]
+const MAX_PHOTOS = 5
+
+interface TrackingDraft {
+  carrier: string
+  trackingNo: string
+}
+
+interface ReturnRequestCardProps {
+  orderNo: string
+  items: OrderItem[]
+  canRequest: boolean
+  onChanged?: () => void
+}
+
+// 用户对已发货订单申请退货，审核通过后登记退货物流
+export function ReturnRequestCard({
+  orderNo,
+  items,
+  canRequest,
+  onChanged,
+}: ReturnRequestCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const fileInputRef = useRef<HTMLInputElement>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [quantities, setQuantities] = useState<Record<number, number>>({})
+  const [reason, setReason] = useState<ReturnReason>('defective')
+  const [description, setDescription] = useState('')
+  const [photos, setPhotos] = useState<string[]>([])
+  const [uploading, setUploading] = useState(false)
+  const [tracking, setTracking] = useState<Record<string, TrackingDraft>>({})
+
+  const queryKey = ['orderReturns', orderNo]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getOrderReturns(orderNo),
+  })
+  const returns: ReturnRequest[] = data?.data?.items || []
+
+  // 未被拒绝的退货占用可退数量，虚拟商品不支持退货
+  const committed: Record<number, number> = {}
+  returns
+    .filter((item) => item.status !== 'rejected')
+    .forEach((request) =>
+      request.items.forEach((item) => {
+        committed[item.item_index] = (committed[item.item_index] || 0) + item.quantity
+      })
+    )
+  const available = items.map((item, index) =>
+    item.product_type === 'virtual' ? 0 : Math.max(item.quantity - (committed[index] || 0), 0)
+  )
+  const canRequestMore = canRequest && available.some((quantity) => quantity > 0)
+
+  const reasonLabels: Record<ReturnReason, string> = {
+    defective: t.returns.reasonDefective,
+    damaged: t.returns.reasonDamaged,
+    wrong_item: t.returns.reasonWrongItem,
+    not_as_described: t.returns.reasonNotAsDescribed,
+    no_longer_needed: t.returns.reasonNoLongerNeeded,
+    other: t.returns.reasonOther,
+  }
+  const statusLabels: Record<ReturnStatus, string> = {
+    requested: t.returns.statusRequested,
+    approved: t.returns.statusApproved,
+    rejected: t.returns.statusRejected,
+    in_transit: t.returns.statusInTransit,
+    received: t.returns.statusReceived,
+  }
+
+  const selected = Object.entries(quantities)
+    .filter(([, quantity]) => quantity > 0)
+    .map(([index, quantity]) => ({ item_index: Number(index), quantity }))
+
+  const resetForm = () => {
+    setFormOpen(false)
+    setQuantities({})
+    setReason('defective')
+    setDescription('')
+    setPhotos([])
+  }
+
+  const submitMutation = useMutation({
+    mutationFn: () =>
+      createReturnRequest(orderNo, {
+        items: selected,
+        reason,
+        description: description.trim() || undefined,
+        photos,
+      }),
+    onSuccess: () => {
+      toast.success(t.returns.submitted)
+      resetForm()
+      queryClient.invalidateQueries({ queryKey })
+      onChanged?.()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.returns.submitFailed))
+    },
+  })
+
+  const trackingMutation = useMutation({
+    mutationFn: (returnNo: string) =>
+      submitReturnTracking(returnNo, {
+        carrier: tracking[returnNo]?.carrier.trim() || undefined,
+        tracking_no: tracking[returnNo]?.trackingNo.trim() || '',
+      }),
+    onSuccess: () => {
+      toast.success(t.returns.trackingSaved)
+      queryClient.invalidateQueries({ queryKey })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.returns.trackingFailed))
+    },
+  })
+
+  const updateTracking = (returnNo: string, patch: Partial<TrackingDraft>) =>
+    setTracking((prev) => ({
+      ...prev,
+      [returnNo]: { carrier: '', trackingNo: '', ...prev[returnNo], ...patch },
+    }))
+
+  const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    e.target.value = ''
+    if (!file) return
+    setUploading(true)
+    try {
+      const res = await uploadTicketIntakeAttachment(file)
+      const url = res.data?.url
+      if (url) {
+        setPhotos((prev) => [...prev, url])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+    } finally {
+      setUploading(false)
+    }
+  }
+
+  if (returns.length === 0 && !canRequestMore) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <PackageOpen className="h-4 w-4" />
+          {t.returns.title}
+        </CardTitle>
+        <CardDescription>{t.returns.desc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {returns.map((request) => (
+          <div key={request.id} className="space-y-2 rounded-md border p-3 text-sm">
+            <div className="flex flex-wrap items-center justify-between gap-2">
+              <div className="flex items-center gap-2">
+                <Badge variant={request.status === 'rejected' ? 'destructive' : 'secondary'}>
+                  {statusLabels[request.status]}
+                </Badge>
+                <span className="font-mono text-xs">{request.return_no}</span>
+              </div>
+              <span className="text-xs text-muted-foreground">
+                {formatDate(request.created_at)}
+              </span>
+            </div>
+            <ul className="text-xs text-muted-foreground">
+              {request.items.map((item) => (
+                <li key={item.item_index}>
+                  {item.name} ({item.sku}) × {item.quantity}
+                </li>
+              ))}
+            </ul>
+            <p className="text-xs">
+              <span className="font-medium">{t.returns.reason}: </span>
+              {reasonLabels[request.reason] || request.reason}
+            </p>
+            {request.description ? (
+              <p className="whitespace-pre-wrap break-words text-muted-foreground">
+                {request.description}
+              </p>
+            ) : null}
+            {request.review_note ? (
+              <p className="text-xs">
+                <span className="font-medium">{t.returns.reviewNote}: </span>
+                {request.review_note}
+              </p>
+            ) : null}
+            {request.return_tracking_no ? (
+              <p className="text-xs">
+                <span className="font-medium">{t.returns.trackingNo}: </span>
+                {request.return_carrier ? `${request.return_carrier} ` : ''}
+                {request.return_tracking_no}
+              </p>
+            ) : null}
+            {request.status === 'received' && request.receive_note ? (
+              <p className="text-xs">
+                <span className="font-medium">{t.returns.receiveNote}: </span>
+                {request.receive_note}
+              </p>
+            ) : null}
+            {request.status === 'approved' || request.status === 'in_transit' ? (
+              <div className="space-y-2 border-t pt-2">
+                <p className="text-xs text-muted-foreground">{t.returns.shipBackHint}</p>
+                <div className="flex flex-wrap gap-2">
+                  <Input
+                    className="w-32"
+                    placeholder={t.returns.carrier}
+                    maxLength={50}
+                    value={tracking[request.return_no]?.carrier ?? request.return_carrier ?? ''}
+                    onChange={(e) => updateTracking(request.return_no, { carrier: e.target.value })}
+                  />
+                  <Input
+                    className="min-w-0 flex-1"
+                    placeholder={t.returns.trackingNo}
+                    maxLength={100}
+                    value={
+                      tracking[request.return_no]?.trackingNo ?? request.return_tracking_no ?? ''
+                    }
+                    onChange={(e) =>
+                      updateTracking(request.return_no, { trackingNo: e.target.value })
+                    }
+                  />
+                  <Button
+                    size="sm"
+                    disabled={
+                      !tracking[request.return_no]?.trackingNo.trim() || trackingMutation.isPending
+                    }
+                    onClick={() => trackingMutation.mutate(request.return_no)}
+                  >
+                    {t.returns.saveTracking}
+                  </Button>
+                </div>
+              </div>
+            ) : null}
+          </div>
+        ))}
+
+        {canRequestMore && !formOpen ? (
+          <Button variant="outline" size="sm" onClick={() => setFormOpen(true)}>
+            {t.returns.requestButton}
+          </Button>
+        ) : null}
+
+        {canRequestMore && formOpen ? (
+          <div className="space-y-4 rounded-md border p-4">
+            <div className="space-y-2">
+              <Label>{t.returns.items} *</Label>
+              {items.map((item, index) => (
+                <div
+                  key={`${item.sku}-${index}`}
+                  className="flex items-center justify-between gap-3"
+                >
+                  <div className="min-w-0 text-sm">
+                    <div className="truncate">{item.name}</div>
+                    <div className="text-xs text-muted-foreground">
+                      {t.returns.available.replace('{count}', String(available[index]))}
+                    </div>
+                  </div>
+                  <Input
+                    type="number"
+                    min={0}
+                    max={available[index]}
+                    disabled={available[index] === 0}
+                    className="w-20"
+                    value={quantities[index] ?? 0}
+                    onChange={(e) => {
+                      const value = Math.min(
+                        Math.max(parseInt(e.target.value, 10) || 0, 0),
+                        available[index]
+                      )
+                      setQuantities((prev) => ({ ...prev, [index]: value }))
+                    }}
+                  />
+                </div>
+              ))}
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.returns.reason} *</Label>
+              <Select value={reason} onValueChange={(value) => setReason(value as ReturnReason)}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {REASONS.map((value) => (
+                    <SelectItem key={value} value={value}>
+                      {reasonLabels[value]}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.returns.description}</Label>
+              <Textarea
+                value={description}
+                onChange={(e) => setDescription(e.target.value)}
+                placeholder={t.returns.descriptionPlaceholder}
+                maxLength={1000}
+                rows={3}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.returns.photos}</Label>
+              <p className="text-xs text-muted-foreground">{t.returns.photosHint}</p>
+              <div className="flex flex-wrap gap-2">
+                {photos.map((url) => (
+                  <div key={url} className="relative h-16 w-16 overflow-hidden rounded border">
+                    {/* eslint-disable-next-line @next/next/no-img-element */}
+                    <img src={url} alt="" className="h-full w-full object-cover" />
+                    <button
+                      type="button"
+                      className="absolute right-0 top-0 rounded-bl bg-background/80 p-0.5"
+                      aria-label={t.returns.removePhoto}
+                      onClick={() => setPhotos((prev) => prev.filter((item) => item !== url))}
+                    >
+                      <X className="h-3 w-3" />
+                    </button>
+                  </div>
+                ))}
+                {photos.length < MAX_PHOTOS ? (
+                  <Button
+                    type="button"
+                    variant="outline"
+                    className="h-16 w-16 p-0"
+                    disabled={uploading}
+                    aria-label={t.returns.uploadPhoto}
+                    title={t.returns.uploadPhoto}
+                    onClick={() => fileInputRef.current?.click()}
+                  >
+                    {uploading ? (
+                      <Loader2 className="h-4 w-4 animate-spin" />
+                    ) : (
+                      <ImagePlus className="h-4 w-4" />
+                    )}
+                  </Button>
+                ) : null}
+              </div>
+              <input
+                ref={fileInputRef}
+                type="file"
+                accept="image/*"
+                className="hidden"
+                onChange={handleFileChange}
+              />
+            </div>
+            <div className="flex flex-wrap gap-2">
+              <Button
+                size="sm"
+                disabled={selected.length === 0 || uploading || submitMutation.isPending}
+                onClick={() => submitMutation.mutate()}
+              >
+                {submitMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.returns.submit}
+              </Button>
+              <Button variant="ghost" size="sm" onClick={resetForm}>
+                {t.common.cancel}
+              </Button>
+            </div>
+          </div>
+        ) : null}
+      </CardContent>
+    </Card>
+  )
+}
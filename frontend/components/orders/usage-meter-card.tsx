@@ -0,0 +1,100 @@
+'use client'
+
+import Link from 'next/link'
+import { useQuery, type QueryKey } from '@tanstack/react-query'
+import { Gauge } from 'lucide-react'
+
+import { UsageMeter } from '@/lib/api'
+import { getTranslations } from '@/lib/i18n'
+import { formatCurrency, formatDate } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+
+interface UsageMeterCardProps {
+  queryKey: QueryKey
+  fetchMeters: () => Promise<any>
+  currency: string
+  overageOrderHref: (meter: UsageMeter) => string
+}
+
+// 卡密的已用、剩余额度与待付款的超额订单；尚未上报过用量时不显示
+export function UsageMeterCard({
+  queryKey,
+  fetchMeters,
+  currency,
+  overageOrderHref,
+}: UsageMeterCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  const { data } = useQuery({ queryKey, queryFn: fetchMeters })
+  const meters: UsageMeter[] = data?.data?.items || []
+
+  if (meters.length === 0) {
+    return null
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Gauge className="h-4 w-4" />
+          {t.order.usageMetersTitle}
+        </CardTitle>
+        <CardDescription>{t.order.usageMetersDesc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {meters.map((meter) => {
+          const percent = meter.quota > 0 ? Math.min(100, (meter.used / meter.quota) * 100) : 0
+          const overage = Math.max(0, meter.used - meter.quota)
+          return (
+            <div key={meter.id} className="space-y-2 rounded-md border p-3 text-sm">
+              <div className="flex flex-wrap items-center justify-between gap-2">
+                <span className="font-mono text-xs text-muted-foreground">
+                  {meter.license_hint}
+                </span>
+                {overage > 0 ? (
+                  <Badge variant="destructive">{t.order.usageOverQuota}</Badge>
+                ) : percent >= 80 ? (
+                  <Badge variant="secondary">{t.order.usageNearQuota}</Badge>
+                ) : null}
+              </div>
+              <div className="h-2 overflow-hidden rounded-full bg-muted">
+                <div
+                  className={overage > 0 ? 'h-full bg-destructive' : 'h-full bg-primary'}
+                  style={{ width: `${percent}%` }}
+                />
+              </div>
+              <p>
+                {t.order.usageUsed}: {meter.used} / {meter.quota} {meter.unit}
+                {' · '}
+                {t.order.usageRemaining}: {Math.max(0, meter.quota - meter.used)} {meter.unit}
+              </p>
+              {meter.overage_unit_price_minor > 0 && (
+                <p className="text-xs text-muted-foreground">
+                  {t.order.usageOveragePrice}:{' '}
+                  {formatCurrency(meter.overage_unit_price_minor, currency)} / {meter.unit}
+                  {overage > 0 ? ` · ${t.order.usageOverage}: ${overage} ${meter.unit}` : ''}
+                </p>
+              )}
+              {meter.overage_order_no && (
+                <p className="text-xs">
+                  {t.order.usageOverageOrder}:{' '}
+                  <Link href={overageOrderHref(meter)} className="font-mono underline">
+                    {meter.overage_order_no}
+                  </Link>
+                </p>
+              )}
+              {meter.last_reported_at && (
+                <p className="text-xs text-muted-foreground">
+                  {t.order.usageLastReportedAt}: {formatDate(meter.last_reported_at)}
+                </p>
+              )}
+            </div>
+          )
+        })}
+      </CardContent>
+    </Card>
+  )
+}
@@ -0,0 +1,138 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import { KeyRound, Mail, ShieldCheck } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import { reauthOrderVirtualProducts, sendOrderVirtualProductsReauthCode } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+interface VirtualRevealReauthCardProps {
+  orderNo: string
+  onVerified: () => void
+}
+
+// 高价值虚拟商品查看前的身份确认（密码或邮箱验证码）
+export function VirtualRevealReauthCard({ orderNo, onVerified }: VirtualRevealReauthCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [method, setMethod] = useState<'password' | 'code'>('password')
+  const [password, setPassword] = useState('')
+  const [code, setCode] = useState('')
+
+  const sendCodeMutation = useMutation({
+    mutationFn: () => sendOrderVirtualProductsReauthCode(orderNo),
+    onSuccess: (res: any) => {
+      toast.success(t.order.virtualRevealCodeSent.replace('{email}', res?.data?.email || ''))
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.virtualRevealFailed))
+    },
+  })
+
+  const verifyMutation = useMutation({
+    mutationFn: () =>
+      reauthOrderVirtualProducts(
+        orderNo,
+        method === 'password' ? { password } : { code: code.trim() }
+      ),
+    onSuccess: () => {
+      setPassword('')
+      setCode('')
+      onVerified()
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.order.virtualRevealFailed))
+    },
+  })
+
+  const canSubmit = method === 'password' ? password !== '' : code.trim() !== ''
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <ShieldCheck className="h-5 w-5" />
+          {t.order.virtualRevealReauthTitle}
+        </CardTitle>
+        <CardDescription>{t.order.virtualRevealReauthDesc}</CardDescription>
+      </CardHeader>
+      <CardContent>
+        <form
+          className="space-y-4"
+          onSubmit={(e) => {
+            e.preventDefault()
+            if (canSubmit) verifyMutation.mutate()
+          }}
+        >
+          <div className="flex flex-wrap gap-2">
+            <Button
+              type="button"
+              size="sm"
+              variant={method === 'password' ? 'default' : 'outline'}
+              onClick={() => setMethod('password')}
+            >
+              <KeyRound className="mr-2 h-4 w-4" />
+              {t.order.virtualRevealUsePassword}
+            </Button>
+            <Button
+              type="button"
+              size="sm"
+              variant={method === 'code' ? 'default' : 'outline'}
+              onClick={() => setMethod('code')}
+            >
+              <Mail className="mr-2 h-4 w-4" />
+              {t.order.virtualRevealUseCode}
+            </Button>
+          </div>
+
+          {method === 'password' ? (
+            <div>
+              <Label htmlFor="virtual-reveal-password">{t.order.virtualRevealPassword}</Label>
+              <Input
+                id="virtual-reveal-password"
+                className="mt-1.5"
+                type="password"
+                autoComplete="current-password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+              />
+            </div>
+          ) : (
+            <div>
+              <Label htmlFor="virtual-reveal-code">{t.order.virtualRevealCode}</Label>
+              <div className="mt-1.5 flex gap-2">
+                <Input
+                  id="virtual-reveal-code"
+                  inputMode="numeric"
+                  autoComplete="one-time-code"
+                  value={code}
+                  onChange={(e) => setCode(e.target.value)}
+                />
+                <Button
+                  type="button"
+                  variant="outline"
+                  onClick={() => sendCodeMutation.mutate()}
+                  disabled={sendCodeMutation.isPending}
+                >
+                  {t.order.virtualRevealSendCode}
+                </Button>
+              </div>
+            </div>
+          )}
+
+          <Button type="submit" disabled={!canSubmit || verifyMutation.isPending}>
+            {verifyMutation.isPending ? t.order.virtualRevealVerifying : t.order.virtualRevealVerify}
+          </Button>
+        </form>
+      </CardContent>
+    </Card>
+  )
+}
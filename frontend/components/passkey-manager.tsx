@@ -0,0 +1,184 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Fingerprint, Loader2, Trash2 } from 'lucide-react'
+import {
+  beginPasskeyRegistration,
+  deletePasskey,
+  finishPasskeyRegistration,
+  getPasskeys,
+  type UserPasskey,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { createPasskey, isPasskeyCancelled, isPasskeySupported } from '@/lib/webauthn'
+import { useLocale } from '@/hooks/use-locale'
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+} from '@/components/ui/alert-dialog'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+// 个人中心的通行密钥管理：注册、查看与删除
+export function PasskeyManager() {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [supported, setSupported] = useState(false)
+  const [name, setName] = useState('')
+  const [deleting, setDeleting] = useState<UserPasskey | null>(null)
+
+  useEffect(() => {
+    setSupported(isPasskeySupported())
+  }, [])
+
+  const { data, isLoading } = useQuery({
+    queryKey: ['passkeys'],
+    queryFn: getPasskeys,
+  })
+  const passkeys: UserPasskey[] = data?.data?.items || []
+
+  const registerMutation = useMutation({
+    mutationFn: async () => {
+      const begin: any = await beginPasskeyRegistration()
+      const credential = await createPasskey(begin.data.public_key)
+      return finishPasskeyRegistration({ name: name.trim(), ...credential })
+    },
+    onSuccess: () => {
+      toast.success(t.passkey.registered)
+      setName('')
+      queryClient.invalidateQueries({ queryKey: ['passkeys'] })
+    },
+    onError: (error: unknown) => {
+      if (isPasskeyCancelled(error)) return
+      toast.error(resolveApiErrorMessage(error, t, t.passkey.registerFailed))
+    },
+  })
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: number) => deletePasskey(id),
+    onSuccess: () => {
+      toast.success(t.passkey.deleted)
+      queryClient.invalidateQueries({ queryKey: ['passkeys'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.passkey.deleteFailed))
+    },
+  })
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Fingerprint className="h-4 w-4" />
+          {t.passkey.title}
+        </CardTitle>
+        <CardDescription>{t.passkey.desc}</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {isLoading ? (
+          <div className="flex items-center justify-center py-6">
+            <Loader2 className="h-5 w-5 animate-spin" />
+          </div>
+        ) : passkeys.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t.passkey.empty}</p>
+        ) : (
+          <ul className="divide-y rounded-md border">
+            {passkeys.map((passkey) => (
+              <li key={passkey.id} className="flex items-center justify-between gap-3 p-3">
+                <div className="min-w-0">
+                  <p className="truncate text-sm font-medium">{passkey.name}</p>
+                  <p className="text-xs text-muted-foreground">
+                    {t.passkey.createdAt.replace(
+                      '{date}',
+                      new Date(passkey.created_at).toLocaleString()
+                    )}
+                    {' · '}
+                    {passkey.last_used_at
+                      ? t.passkey.lastUsedAt.replace(
+                          '{date}',
+                          new Date(passkey.last_used_at).toLocaleString()
+                        )
+                      : t.passkey.neverUsed}
+                  </p>
+                </div>
+                <Button
+                  variant="ghost"
+                  size="icon"
+                  aria-label={t.passkey.delete}
+                  disabled={deleteMutation.isPending}
+                  onClick={() => setDeleting(passkey)}
+                >
+                  <Trash2 className="h-4 w-4" />
+                </Button>
+              </li>
+            ))}
+          </ul>
+        )}
+
+        {supported ? (
+          <form
+            className="flex flex-col gap-2 sm:flex-row sm:items-end"
+            onSubmit={(e) => {
+              e.preventDefault()
+              registerMutation.mutate()
+            }}
+          >
+            <div className="flex-1 space-y-1.5">
+              <Label htmlFor="passkey_name">{t.passkey.name}</Label>
+              <Input
+                id="passkey_name"
+                maxLength={100}
+                placeholder={t.passkey.namePlaceholder}
+                value={name}
+                onChange={(e) => setName(e.target.value)}
+              />
+            </div>
+            <Button type="submit" disabled={registerMutation.isPending}>
+              {registerMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.passkey.add}
+            </Button>
+          </form>
+        ) : (
+          <p className="text-sm text-muted-foreground">{t.passkey.unsupported}</p>
+        )}
+      </CardContent>
+
+      <AlertDialog
+        open={Boolean(deleting)}
+        onOpenChange={(open) => (!open ? setDeleting(null) : null)}
+      >
+        <AlertDialogContent>
+          <AlertDialogHeader>
+            <AlertDialogTitle>{t.passkey.delete}</AlertDialogTitle>
+            <AlertDialogDescription>
+              {t.passkey.deleteConfirm.replace('{name}', deleting?.name || '')}
+            </AlertDialogDescription>
+          </AlertDialogHeader>
+          <AlertDialogFooter>
+            <AlertDialogCancel>{t.common.cancel}</AlertDialogCancel>
+            <AlertDialogAction
+              onClick={() => {
+                if (deleting) deleteMutation.mutate(deleting.id)
+                setDeleting(null)
+              }}
+            >
+              {t.passkey.delete}
+            </AlertDialogAction>
+          </AlertDialogFooter>
+        </AlertDialogContent>
+      </AlertDialog>
+    </Card>
+  )
+}
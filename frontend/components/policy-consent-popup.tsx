@@ -0,0 +1,106 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { Loader2, ScrollText } from 'lucide-react'
+import toast from 'react-hot-toast'
+import { acceptPolicies, getPolicyConsents, type PolicyVersion } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { useAuth } from '@/hooks/use-auth'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog'
+import { Button } from '@/components/ui/button'
+import { Checkbox } from '@/components/ui/checkbox'
+import { MarkdownMessage } from '@/components/ui/markdown-message'
+
+const EMPTY_POLICIES: PolicyVersion[] = []
+
+// 发布新的服务条款/隐私政策后提示用户重新同意；可暂时关闭，但未同意前无法下单
+export function PolicyConsentPopup() {
+  const { isAuthenticated } = useAuth()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [agreed, setAgreed] = useState(false)
+  const [dismissed, setDismissed] = useState(false)
+
+  const { data } = useQuery({
+    queryKey: ['policyConsents'],
+    queryFn: getPolicyConsents,
+    enabled: isAuthenticated,
+    staleTime: 60000,
+  })
+  const pending: PolicyVersion[] = data?.data?.pending ?? EMPTY_POLICIES
+
+  const acceptMutation = useMutation({
+    mutationFn: () => acceptPolicies(pending.map((policy) => policy.id)),
+    onSuccess: () => {
+      setAgreed(false)
+      toast.success(t.consent.accepted)
+      queryClient.invalidateQueries({ queryKey: ['policyConsents'] })
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.consent.acceptFailed))
+      queryClient.invalidateQueries({ queryKey: ['policyConsents'] })
+    },
+  })
+
+  return (
+    <Dialog
+      open={isAuthenticated && !dismissed && pending.length > 0}
+      onOpenChange={(open) => !open && setDismissed(true)}
+    >
+      <DialogContent className="flex max-h-[85dvh] max-w-2xl flex-col">
+        <DialogHeader>
+          <DialogTitle className="flex items-center gap-2">
+            <ScrollText className="h-5 w-5" />
+            {t.consent.popupTitle}
+          </DialogTitle>
+          <DialogDescription>{t.consent.popupDesc}</DialogDescription>
+        </DialogHeader>
+        <div className="min-h-0 flex-1 space-y-4 overflow-y-auto">
+          {pending.map((policy) => (
+            <div key={policy.id} className="space-y-2 rounded-md border p-4">
+              <div className="flex flex-wrap items-baseline justify-between gap-2">
+                <h3 className="font-medium">{policy.title}</h3>
+                <span className="text-xs text-muted-foreground">
+                  {t.consent.version} {policy.version}
+                </span>
+              </div>
+              {policy.summary ? (
+                <p className="text-sm text-muted-foreground">{policy.summary}</p>
+              ) : null}
+              <div className="max-h-64 overflow-y-auto rounded-md bg-muted/40 p-3">
+                <MarkdownMessage content={policy.content} className="markdown-body" />
+              </div>
+            </div>
+          ))}
+        </div>
+        <label className="flex items-center gap-2 text-sm">
+          <Checkbox checked={agreed} onCheckedChange={(checked) => setAgreed(checked === true)} />
+          {t.consent.agree}
+        </label>
+        <DialogFooter>
+          <Button variant="outline" onClick={() => setDismissed(true)}>
+            {t.consent.later}
+          </Button>
+          <Button
+            disabled={!agreed || acceptMutation.isPending}
+            onClick={() => acceptMutation.mutate()}
+          >
+            {acceptMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            {t.consent.accept}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  )
+}
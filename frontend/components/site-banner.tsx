@@ -0,0 +1,138 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import Link from 'next/link'
+import { useQuery, useQueryClient } from '@tanstack/react-query'
+import { AlertTriangle, Info, ScrollText, X } from 'lucide-react'
+import { getActiveSiteBanners, type SiteBannerView } from '@/lib/api'
+import { useAuth } from '@/hooks/use-auth'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import {
+  SITE_BANNER_VERSION_EVENT,
+  dismissSiteBanner,
+  readDismissedSiteBanners,
+  siteBannerDismissKey,
+} from '@/lib/site-banner'
+import { cn } from '@/lib/utils'
+
+const EMPTY_BANNERS: SiteBannerView[] = []
+
+const bannerStyles: Record<SiteBannerView['type'], string> = {
+  maintenance: 'border-amber-300 bg-amber-50 text-amber-900 dark:border-amber-800 dark:bg-amber-950/40 dark:text-amber-100',
+  policy: 'border-blue-300 bg-blue-50 text-blue-900 dark:border-blue-800 dark:bg-blue-950/40 dark:text-blue-100',
+  info: 'border-border bg-muted/40 text-foreground',
+}
+
+const bannerIcons = {
+  maintenance: AlertTriangle,
+  policy: ScrollText,
+  info: Info,
+}
+
+function formatWindowTime(value: string) {
+  return new Date(value).toLocaleString(undefined, {
+    month: 'short',
+    day: 'numeric',
+    hour: '2-digit',
+    minute: '2-digit',
+    timeZoneName: 'short',
+  })
+}
+
+export function SiteBanners({ className }: { className?: string }) {
+  const { user } = useAuth()
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [dismissed, setDismissed] = useState<string[]>([])
+
+  useEffect(() => {
+    setDismissed(readDismissedSiteBanners())
+  }, [])
+
+  // 任意接口响应中的横幅版本号变化后重新拉取
+  useEffect(() => {
+    const handleVersionChange = () => {
+      queryClient.invalidateQueries({ queryKey: ['siteBanners'] })
+    }
+    window.addEventListener(SITE_BANNER_VERSION_EVENT, handleVersionChange)
+    return () => window.removeEventListener(SITE_BANNER_VERSION_EVENT, handleVersionChange)
+  }, [queryClient])
+
+  const { data } = useQuery({
+    queryKey: ['siteBanners', locale, user?.id ?? 0, user?.role ?? ''],
+    queryFn: () => getActiveSiteBanners(locale),
+    staleTime: 5 * 60 * 1000,
+  })
+  const banners: SiteBannerView[] = data?.data?.items ?? EMPTY_BANNERS
+  const visible = banners.filter(
+    (banner) => !banner.dismissible || !dismissed.includes(siteBannerDismissKey(banner))
+  )
+
+  if (visible.length === 0) {
+    return null
+  }
+
+  return (
+    <div className={cn('space-y-2', className)}>
+      {visible.map((banner) => {
+        const Icon = bannerIcons[banner.type] || Info
+        const isExternal = /^https?:\/\//i.test(banner.link_url || '')
+        return (
+          <div
+            key={banner.id}
+            role={banner.type === 'maintenance' ? 'alert' : 'status'}
+            className={cn('flex items-start gap-3 rounded-md border px-4 py-3 text-sm', bannerStyles[banner.type])}
+          >
+            <Icon className="mt-0.5 h-4 w-4 shrink-0" />
+            <div className="min-w-0 flex-1 space-y-1">
+              {banner.title ? <div className="font-medium">{banner.title}</div> : null}
+              <div className="whitespace-pre-wrap break-words">{banner.message}</div>
+              {banner.maintenance_starts_at && banner.maintenance_ends_at ? (
+                <div className="text-xs opacity-80">
+                  {t.siteBanner.maintenanceWindow
+                    .replace('{start}', formatWindowTime(banner.maintenance_starts_at))
+                    .replace('{end}', formatWindowTime(banner.maintenance_ends_at))}
+                </div>
+              ) : null}
+              {banner.link_url ? (
+                isExternal ? (
+                  <a
+                    href={banner.link_url}
+                    target="_blank"
+                    rel="noopener noreferrer"
+                    className="inline-block font-medium underline underline-offset-2"
+                  >
+                    {banner.link_text || t.siteBanner.learnMore}
+                  </a>
+                ) : (
+                  <Link
+                    href={banner.link_url}
+                    className="inline-block font-medium underline underline-offset-2"
+                  >
+                    {banner.link_text || t.siteBanner.learnMore}
+                  </Link>
+                )
+              ) : null}
+            </div>
+            {banner.dismissible ? (
+              <button
+                type="button"
+                className="shrink-0 rounded p-0.5 opacity-70 hover:opacity-100"
+                onClick={() => {
+                  const key = siteBannerDismissKey(banner)
+                  dismissSiteBanner(key)
+                  setDismissed((current) => [...current, key])
+                }}
+              >
+                <X className="h-4 w-4" />
+                <span className="sr-only">{t.siteBanner.dismiss}</span>
+              </button>
+            ) : null}
+          </div>
+        )
+      })}
+    </div>
+  )
+}
@@ -17,6 +17,8 @@ import {
   Send,
   Loader2,
   Square,
+  Paperclip,
+  X,
 } from 'lucide-react'
 import { cn } from '@/lib/utils'
 import { useLocale } from '@/hooks/use-locale'
@@ -39,6 +41,13 @@ interface ToolbarTranslations {
   editMode: string
   send: string
   noPreviewContent: string
+  attachFile?: string
+  removeAttachment?: string
+}
+
+interface PendingAttachment {
+  id: number
+  filename: string
 }
 
 interface MessageToolbarProps {
@@ -53,6 +62,12 @@ interface MessageToolbarProps {
   enableImage?: boolean
   enableVoice?: boolean
   acceptImageTypes?: string[]
+  // 消息附件：上传后先列在输入框下方，随下一条消息一起发送
+  enableFile?: boolean
+  acceptFileTypes?: string[]
+  attachments?: PendingAttachment[]
+  onAttachFile?: (file: File) => Promise<void>
+  onRemoveAttachment?: (id: number) => void
   maxLength?: number
   translations?: ToolbarTranslations
   pluginSlotNamespace?: string
@@ -72,6 +87,11 @@ export function MessageToolbar({
   enableImage = true,
   enableVoice = true,
   acceptImageTypes,
+  enableFile = false,
+  acceptFileTypes,
+  attachments = [],
+  onAttachFile,
+  onRemoveAttachment,
   maxLength,
   translations,
   pluginSlotNamespace,
@@ -97,12 +117,15 @@ export function MessageToolbar({
     send: t.ticket.send,
     noPreviewContent: t.ticket.noPreviewContent,
   }
+  const attachFileLabel = tt.attachFile || t.ticket.attachFile
+  const removeAttachmentLabel = tt.removeAttachment || t.ticket.removeAttachment
   const [isPreview, setIsPreview] = useState(false)
   const [isUploading, setIsUploading] = useState(false)
   const [isRecording, setIsRecording] = useState(false)
   const [recordingDuration, setRecordingDuration] = useState(0)
   const textareaRef = useRef<HTMLTextAreaElement>(null)
   const fileInputRef = useRef<HTMLInputElement>(null)
+  const attachmentInputRef = useRef<HTMLInputElement>(null)
   const mediaRecorderRef = useRef<MediaRecorder | null>(null)
   const audioChunksRef = useRef<Blob[]>([])
   const recordingTimerRef = useRef<NodeJS.Timeout | null>(null)
@@ -114,6 +137,9 @@ export function MessageToolbar({
     }
     return 'image/*'
   })()
+  const attachmentAccept = [...(acceptImageTypes || []), ...(acceptFileTypes || [])].join(',')
+  const canAttach = enableFile && Boolean(onAttachFile)
+  const hasAttachments = attachments.length > 0
   const isOverLimit = Boolean(maxLength && value.length > maxLength)
   const buildPluginComposerContext = (section: string, extra?: Record<string, any>) => ({
     ...(pluginSlotContext || {}),
@@ -214,6 +240,21 @@ export function MessageToolbar({
     }
   }
 
+  const handleAttachmentChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    if (!file || !onAttachFile) return
+    e.target.value = ''
+
+    setIsUploading(true)
+    try {
+      await onAttachFile(file)
+    } catch {
+      // 错误由上层处理
+    } finally {
+      setIsUploading(false)
+    }
+  }
+
   // 语音录制
   const startRecording = async () => {
     try {
@@ -366,6 +407,23 @@ export function MessageToolbar({
           </Button>
         )}
 
+        {/* 文件附件 */}
+        {canAttach && (
+          <Button
+            type="button"
+            variant="ghost"
+            size="icon"
+            className="h-7 w-7"
+            onClick={() => attachmentInputRef.current?.click()}
+            disabled={disabled || isUploading}
+            title={attachFileLabel}
+            aria-label={attachFileLabel}
+          >
+            <Paperclip className="h-3.5 w-3.5" />
+            <span className="sr-only">{attachFileLabel}</span>
+          </Button>
+        )}
+
         {isRecording && (
           <span className="ml-0.5 animate-pulse text-xs tabular-nums text-red-500">
             {recordingDuration}s
@@ -374,7 +432,7 @@ export function MessageToolbar({
 
         {!compactLayout ? (
           <>
-            {(enableImage || enableVoice) && (
+            {(enableImage || enableVoice || canAttach) && (
               <div className="mx-0.5 hidden h-4 w-px bg-border md:block" />
             )}
 
@@ -411,7 +469,14 @@ export function MessageToolbar({
             size="icon"
             className="h-7 w-7"
             onClick={onSend}
-            disabled={disabled || !value.trim() || isSending || isPreview || isOverLimit}
+            disabled={
+              disabled ||
+              (!value.trim() && !hasAttachments) ||
+              isSending ||
+              isUploading ||
+              isPreview ||
+              isOverLimit
+            }
             title={tt.send}
             aria-label={tt.send}
           >
@@ -464,6 +529,31 @@ export function MessageToolbar({
         </div>
       )}
 
+      {hasAttachments && (
+        <div className="flex flex-wrap gap-1.5 border-t px-2 py-1.5">
+          {attachments.map((attachment) => (
+            <span
+              key={attachment.id}
+              className="inline-flex max-w-[220px] items-center gap-1 rounded-md bg-muted px-2 py-0.5 text-xs"
+            >
+              <Paperclip className="h-3 w-3 shrink-0" />
+              <span className="truncate">{attachment.filename}</span>
+              {onRemoveAttachment && (
+                <button
+                  type="button"
+                  className="shrink-0 opacity-70 hover:opacity-100"
+                  onClick={() => onRemoveAttachment(attachment.id)}
+                  title={removeAttachmentLabel}
+                  aria-label={removeAttachmentLabel}
+                >
+                  <X className="h-3 w-3" />
+                </button>
+              )}
+            </span>
+          ))}
+        </div>
+      )}
+
       {renderPluginSlot('bottom', 'bottom')}
 
       {/* 隐藏的文件输入 */}
@@ -475,6 +565,16 @@ export function MessageToolbar({
         onChange={handleFileChange}
         aria-label={tt.uploadImage}
       />
+      {canAttach && (
+        <input
+          ref={attachmentInputRef}
+          type="file"
+          accept={attachmentAccept || undefined}
+          className="hidden"
+          onChange={handleAttachmentChange}
+          aria-label={attachFileLabel}
+        />
+      )}
     </div>
   )
 }
@@ -0,0 +1,61 @@
+'use client'
+
+import { FileText, Download } from 'lucide-react'
+import type { TicketAttachment } from '@/lib/api'
+import { cn } from '@/lib/utils'
+
+function formatBytes(bytes: number) {
+  if (!Number.isFinite(bytes) || bytes <= 0) return '0 B'
+  if (bytes < 1024) return `${bytes} B`
+  if (bytes < 1024 * 1024) return `${(bytes / 1024).toFixed(1)} KB`
+  return `${(bytes / (1024 * 1024)).toFixed(1)} MB`
+}
+
+interface TicketAttachmentListProps {
+  attachments?: TicketAttachment[]
+  isOwnMessage?: boolean
+}
+
+// 消息附件：图片显示缩略图，其余文件显示为下载链接；链接为有时效的签名地址，随消息列表刷新
+export function TicketAttachmentList({ attachments, isOwnMessage }: TicketAttachmentListProps) {
+  if (!attachments || attachments.length === 0) return null
+
+  return (
+    <div className="mt-1 flex flex-col gap-1.5">
+      {attachments.map((attachment) =>
+        attachment.content_type.startsWith('image/') && attachment.download_url ? (
+          <a
+            key={attachment.id}
+            href={attachment.download_url}
+            target="_blank"
+            rel="noopener noreferrer"
+            title={attachment.filename}
+          >
+            {/* eslint-disable-next-line @next/next/no-img-element */}
+            <img
+              src={attachment.download_url}
+              alt={attachment.filename}
+              className="max-h-48 max-w-full rounded-md border object-contain"
+            />
+          </a>
+        ) : (
+          <a
+            key={attachment.id}
+            href={attachment.download_url}
+            target="_blank"
+            rel="noopener noreferrer"
+            className={cn(
+              'flex items-center gap-2 rounded-md border px-2 py-1.5 text-sm',
+              isOwnMessage ? 'border-primary-foreground/30' : 'bg-background'
+            )}
+          >
+            <FileText className="h-4 w-4 shrink-0" />
+            <span className="min-w-0 flex-1 truncate">{attachment.filename}</span>
+            <span className="shrink-0 text-xs opacity-70">{formatBytes(attachment.size)}</span>
+            <Download className="h-3.5 w-3.5 shrink-0 opacity-70" />
+          </a>
+        )
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,307 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
+import { ImagePlus, Loader2, Scale, X } from 'lucide-react'
+import toast from 'react-hot-toast'
+
+import {
+  OrderDispute,
+  OrderDisputeDecision,
+  OrderDisputeEligibility,
+  OrderDisputeStatus,
+  escalateTicketDispute,
+  getTicketDispute,
+  uploadTicketIntakeAttachment,
+} from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { formatDate, formatPrice } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Badge } from '@/components/ui/badge'
+import { Button } from '@/components/ui/button'
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Textarea } from '@/components/ui/textarea'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+
+const MAX_EVIDENCE = 10
+
+interface TicketDisputeCardProps {
+  ticketId: number
+  sharedOrderNos: string[]
+}
+
+// 工单长时间未解决时升级为平台争议，展示双方陈述与裁决结果
+export function TicketDisputeCard({ ticketId, sharedOrderNos }: TicketDisputeCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const fileInputRef = useRef<HTMLInputElement>(null)
+  const [formOpen, setFormOpen] = useState(false)
+  const [orderNo, setOrderNo] = useState('')
+  const [statement, setStatement] = useState('')
+  const [evidence, setEvidence] = useState<string[]>([])
+  const [uploading, setUploading] = useState(false)
+
+  const queryKey = ['ticketDispute', ticketId]
+  const { data } = useQuery({
+    queryKey,
+    queryFn: () => getTicketDispute(ticketId),
+    enabled: !!ticketId,
+  })
+  const eligibility: OrderDisputeEligibility | undefined = data?.data
+  const dispute: OrderDispute | undefined = eligibility?.dispute
+
+  const statusLabels: Record<OrderDisputeStatus, string> = {
+    awaiting_evidence: t.dispute.statusAwaitingEvidence,
+    under_review: t.dispute.statusUnderReview,
+    resolved: t.dispute.statusResolved,
+  }
+  const decisionLabels: Record<OrderDisputeDecision, string> = {
+    refund_full: t.dispute.decisionRefundFull,
+    refund_partial: t.dispute.decisionRefundPartial,
+    no_refund: t.dispute.decisionNoRefund,
+  }
+
+  const escalateMutation = useMutation({
+    mutationFn: () =>
+      escalateTicketDispute(ticketId, {
+        order_no: orderNo.trim() || undefined,
+        statement: statement.trim(),
+        evidence,
+      }),
+    onSuccess: () => {
+      toast.success(t.dispute.escalated)
+      setFormOpen(false)
+      setStatement('')
+      setEvidence([])
+      queryClient.invalidateQueries({ queryKey })
+      queryClient.invalidateQueries({ queryKey: ['ticket', ticketId] })
+      queryClient.invalidateQueries({ queryKey: ['ticketMessages', ticketId] })
+      queryClient.invalidateQueries({ queryKey: ['ticketSharedOrders', ticketId] })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.dispute.escalateFailed))
+    },
+  })
+
+  const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    e.target.value = ''
+    if (!file) return
+    setUploading(true)
+    try {
+      const res = await uploadTicketIntakeAttachment(file)
+      const url = res.data?.url
+      if (url) {
+        setEvidence((prev) => [...prev, url])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+    } finally {
+      setUploading(false)
+    }
+  }
+
+  if (!eligibility || (!eligibility.enabled && !dispute)) {
+    return null
+  }
+
+  const renderEvidence = (urls?: string[]) =>
+    urls && urls.length > 0 ? (
+      <div className="flex flex-wrap gap-2">
+        {urls.map((url) => (
+          <a
+            key={url}
+            href={url}
+            target="_blank"
+            rel="noopener noreferrer"
+            className="h-12 w-12 overflow-hidden rounded border"
+          >
+            {/* eslint-disable-next-line @next/next/no-img-element */}
+            <img src={url} alt="" className="h-full w-full object-cover" />
+          </a>
+        ))}
+      </div>
+    ) : null
+
+  return (
+    <Card>
+      <CardHeader className="pb-3">
+        <CardTitle className="flex items-center gap-2 text-base">
+          <Scale className="h-4 w-4" />
+          {t.dispute.title}
+        </CardTitle>
+        {!dispute ? <CardDescription>{t.dispute.desc}</CardDescription> : null}
+      </CardHeader>
+      <CardContent className="space-y-3 text-sm">
+        {dispute ? (
+          <>
+            <div className="flex flex-wrap items-center gap-2">
+              <Badge variant={dispute.status === 'resolved' ? 'default' : 'secondary'}>
+                {statusLabels[dispute.status]}
+              </Badge>
+              <span className="font-mono text-xs text-muted-foreground">
+                {dispute.dispute_no} · {dispute.order_no}
+              </span>
+              {dispute.status === 'awaiting_evidence' ? (
+                <span className="text-xs text-muted-foreground">
+                  {t.dispute.evidenceDeadline.replace(
+                    '{date}',
+                    formatDate(dispute.evidence_deadline)
+                  )}
+                </span>
+              ) : null}
+            </div>
+            <div className="space-y-1">
+              <p className="text-xs font-medium">{t.dispute.buyer}</p>
+              <p className="whitespace-pre-wrap break-words text-muted-foreground">
+                {dispute.buyer_statement}
+              </p>
+              {renderEvidence(dispute.buyer_evidence)}
+            </div>
+            <div className="space-y-1">
+              <p className="text-xs font-medium">{t.dispute.merchant}</p>
+              <p className="whitespace-pre-wrap break-words text-muted-foreground">
+                {dispute.merchant_statement || t.dispute.noMerchantResponse}
+              </p>
+              {renderEvidence(dispute.merchant_evidence)}
+            </div>
+            {dispute.status === 'resolved' && dispute.decision ? (
+              <div className="space-y-1 rounded-md border p-3">
+                <p className="font-medium">
+                  {t.dispute.decision}: {decisionLabels[dispute.decision]}
+                </p>
+                {dispute.decision_amount_minor ? (
+                  <p className="text-xs">
+                    {t.dispute.refundAmount}:{' '}
+                    {formatPrice(dispute.decision_amount_minor, dispute.currency)}
+                  </p>
+                ) : null}
+                {dispute.decision !== 'no_refund' && !dispute.transaction_id ? (
+                  <p className="text-xs text-muted-foreground">{t.dispute.refundProcessing}</p>
+                ) : null}
+                {dispute.decision_note ? (
+                  <p className="whitespace-pre-wrap break-words text-muted-foreground">
+                    {dispute.decision_note}
+                  </p>
+                ) : null}
+              </div>
+            ) : null}
+          </>
+        ) : !eligibility.eligible ? (
+          <p className="text-xs text-muted-foreground">
+            {eligibility.available_at
+              ? t.dispute.availableAt.replace('{date}', formatDate(eligibility.available_at))
+              : t.dispute.unavailable}
+          </p>
+        ) : !formOpen ? (
+          <Button variant="outline" size="sm" onClick={() => setFormOpen(true)}>
+            {t.dispute.escalate}
+          </Button>
+        ) : (
+          <div className="space-y-4 rounded-md border p-4">
+            <div className="space-y-1.5">
+              <Label>{t.dispute.order}</Label>
+              {sharedOrderNos.length > 0 ? (
+                <Select value={orderNo} onValueChange={setOrderNo}>
+                  <SelectTrigger>
+                    <SelectValue placeholder={t.dispute.orderPlaceholder} />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {sharedOrderNos.map((value) => (
+                      <SelectItem key={value} value={value}>
+                        {value}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              ) : (
+                <Input
+                  value={orderNo}
+                  onChange={(e) => setOrderNo(e.target.value)}
+                  placeholder={t.dispute.orderNoPlaceholder}
+                />
+              )}
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.dispute.statement} *</Label>
+              <Textarea
+                value={statement}
+                onChange={(e) => setStatement(e.target.value)}
+                placeholder={t.dispute.statementPlaceholder}
+                maxLength={2000}
+                rows={4}
+              />
+            </div>
+            <div className="space-y-1.5">
+              <Label>{t.dispute.evidence}</Label>
+              <p className="text-xs text-muted-foreground">{t.dispute.evidenceHint}</p>
+              <div className="flex flex-wrap gap-2">
+                {evidence.map((url) => (
+                  <div key={url} className="relative h-16 w-16 overflow-hidden rounded border">
+                    {/* eslint-disable-next-line @next/next/no-img-element */}
+                    <img src={url} alt="" className="h-full w-full object-cover" />
+                    <button
+                      type="button"
+                      className="absolute right-0 top-0 rounded-bl bg-background/80 p-0.5"
+                      aria-label={t.dispute.removeEvidence}
+                      onClick={() => setEvidence((prev) => prev.filter((item) => item !== url))}
+                    >
+                      <X className="h-3 w-3" />
+                    </button>
+                  </div>
+                ))}
+                {evidence.length < MAX_EVIDENCE ? (
+                  <Button
+                    type="button"
+                    variant="outline"
+                    className="h-16 w-16 p-0"
+                    disabled={uploading}
+                    aria-label={t.dispute.uploadEvidence}
+                    title={t.dispute.uploadEvidence}
+                    onClick={() => fileInputRef.current?.click()}
+                  >
+                    {uploading ? (
+                      <Loader2 className="h-4 w-4 animate-spin" />
+                    ) : (
+                      <ImagePlus className="h-4 w-4" />
+                    )}
+                  </Button>
+                ) : null}
+              </div>
+              <input
+                ref={fileInputRef}
+                type="file"
+                accept="image/*"
+                className="hidden"
+                onChange={handleFileChange}
+              />
+            </div>
+            <div className="flex flex-wrap gap-2">
+              <Button
+                size="sm"
+                disabled={!statement.trim() || uploading || escalateMutation.isPending}
+                onClick={() => escalateMutation.mutate()}
+              >
+                {escalateMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.dispute.submit}
+              </Button>
+              <Button variant="ghost" size="sm" onClick={() => setFormOpen(false)}>
+                {t.common.cancel}
+              </Button>
+            </div>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
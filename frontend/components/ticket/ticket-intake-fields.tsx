@@ -0,0 +1,153 @@
+'use client'
+
+import { useRef, useState } from 'react'
+import { Input } from '@/components/ui/input'
+import { Textarea } from '@/components/ui/textarea'
+import { Button } from '@/components/ui/button'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import { ImagePlus, Loader2, X } from 'lucide-react'
+import toast from 'react-hot-toast'
+import { TicketTopic, uploadTicketIntakeAttachment } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+
+export const TICKET_INTAKE_FIELD_PREFIX = 'intake_'
+
+interface TicketIntakeFieldsProps {
+  topic: TicketTopic
+  attachments: string[]
+  onAttachmentsChange: (attachments: string[]) => void
+}
+
+// 分类主题模板的结构化字段与截图上传
+export function TicketIntakeFields({
+  topic,
+  attachments,
+  onAttachmentsChange,
+}: TicketIntakeFieldsProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const fileInputRef = useRef<HTMLInputElement>(null)
+  const [uploading, setUploading] = useState(false)
+  const minAttachments = topic.min_attachments || 0
+
+  const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0]
+    e.target.value = ''
+    if (!file) return
+    setUploading(true)
+    try {
+      const res = await uploadTicketIntakeAttachment(file)
+      const url = res.data?.url
+      if (url) {
+        onAttachmentsChange([...attachments, url])
+      }
+    } catch (error) {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.uploadFailed))
+    } finally {
+      setUploading(false)
+    }
+  }
+
+  return (
+    <div className="space-y-4">
+      {topic.description ? (
+        <p className="text-sm text-muted-foreground">{topic.description}</p>
+      ) : null}
+
+      {(topic.fields || []).map((field) => {
+        const name = `${TICKET_INTAKE_FIELD_PREFIX}${field.key}`
+        const label = `${field.label || field.key}${field.required ? ' *' : ''}`
+        const maxLength = field.max_length && field.max_length > 0 ? field.max_length : undefined
+        return (
+          <div key={field.key}>
+            <label className="text-sm font-medium">{label}</label>
+            {field.type === 'select' ? (
+              <Select name={name} required={field.required}>
+                <SelectTrigger className="mt-1.5">
+                  <SelectValue placeholder={t.ticket.intakeSelectPlaceholder} />
+                </SelectTrigger>
+                <SelectContent>
+                  {(field.options || []).map((option) => (
+                    <SelectItem key={option} value={option}>
+                      {option}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            ) : field.type === 'textarea' ? (
+              <Textarea
+                name={name}
+                className="mt-1.5"
+                required={field.required}
+                maxLength={maxLength}
+              />
+            ) : (
+              <Input
+                name={name}
+                type={field.type === 'number' ? 'number' : 'text'}
+                className="mt-1.5"
+                required={field.required}
+                maxLength={maxLength}
+              />
+            )}
+          </div>
+        )
+      })}
+
+      {minAttachments > 0 ? (
+        <div>
+          <label className="text-sm font-medium">{t.ticket.intakeScreenshots} *</label>
+          <p className="mt-1 text-xs text-muted-foreground">
+            {t.ticket.intakeScreenshotsHint.replace('{min}', String(minAttachments))}
+          </p>
+          <div className="mt-2 flex flex-wrap gap-2">
+            {attachments.map((url) => (
+              <div key={url} className="relative h-16 w-16 overflow-hidden rounded border">
+                {/* eslint-disable-next-line @next/next/no-img-element */}
+                <img src={url} alt="" className="h-full w-full object-cover" />
+                <button
+                  type="button"
+                  className="absolute right-0 top-0 rounded-bl bg-background/80 p-0.5"
+                  aria-label={t.ticket.intakeRemoveScreenshot}
+                  onClick={() => onAttachmentsChange(attachments.filter((item) => item !== url))}
+                >
+                  <X className="h-3 w-3" />
+                </button>
+              </div>
+            ))}
+            <Button
+              type="button"
+              variant="outline"
+              className="h-16 w-16 p-0"
+              disabled={uploading}
+              aria-label={t.ticket.intakeUploadScreenshot}
+              title={t.ticket.intakeUploadScreenshot}
+              onClick={() => fileInputRef.current?.click()}
+            >
+              {uploading ? (
+                <Loader2 className="h-4 w-4 animate-spin" />
+              ) : (
+                <ImagePlus className="h-4 w-4" />
+              )}
+            </Button>
+            <input
+              ref={fileInputRef}
+              type="file"
+              accept="image/*"
+              className="hidden"
+              onChange={handleFileChange}
+            />
+          </div>
+        </div>
+      ) : null}
+    </div>
+  )
+}
@@ -0,0 +1,94 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation, useQueryClient } from '@tanstack/react-query'
+import { Star } from 'lucide-react'
+import toast from 'react-hot-toast'
+import { Button } from '@/components/ui/button'
+import { Textarea } from '@/components/ui/textarea'
+import { Ticket, rateTicket } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { useLocale } from '@/hooks/use-locale'
+import { getTranslations } from '@/lib/i18n'
+import { cn } from '@/lib/utils'
+
+interface TicketRatingCardProps {
+  ticket: Ticket
+  compactLayout?: boolean
+}
+
+// 已解决/已关闭工单的满意度评价
+export function TicketRatingCard({ ticket, compactLayout }: TicketRatingCardProps) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const queryClient = useQueryClient()
+  const [rating, setRating] = useState(0)
+  const [comment, setComment] = useState('')
+
+  const rateMutation = useMutation({
+    mutationFn: () => rateTicket(ticket.id, { rating, comment: comment.trim() || undefined }),
+    onSuccess: () => {
+      toast.success(t.ticket.rateSuccess)
+      queryClient.invalidateQueries({ queryKey: ['ticket', ticket.id] })
+    },
+    onError: (error: any) => {
+      toast.error(resolveApiErrorMessage(error, t, t.ticket.rateFailed))
+    },
+  })
+
+  const rated = !!ticket.rated_at
+  const displayRating = rated ? ticket.satisfaction_rating || 0 : rating
+
+  return (
+    <div
+      className={cn(
+        'shrink-0 space-y-2 border-t bg-muted/15',
+        compactLayout ? 'px-2 py-1.5' : 'px-3 py-2'
+      )}
+    >
+      <div className="flex flex-wrap items-center gap-2 text-sm">
+        <span className="font-medium">{rated ? t.ticket.ratedLabel : t.ticket.rateTitle}</span>
+        <div className="flex items-center gap-0.5">
+          {[1, 2, 3, 4, 5].map((value) => (
+            <button
+              key={value}
+              type="button"
+              disabled={rated || rateMutation.isPending}
+              onClick={() => setRating(value)}
+              className="p-0.5 disabled:cursor-default"
+              aria-label={`${value}`}
+            >
+              <Star
+                className={cn(
+                  'h-4 w-4',
+                  value <= displayRating
+                    ? 'fill-yellow-400 text-yellow-400'
+                    : 'text-muted-foreground'
+                )}
+              />
+            </button>
+          ))}
+        </div>
+      </div>
+      {rated ? (
+        ticket.satisfaction_comment ? (
+          <p className="text-xs text-muted-foreground">{ticket.satisfaction_comment}</p>
+        ) : null
+      ) : rating > 0 ? (
+        <div className="flex flex-col gap-2 sm:flex-row sm:items-end">
+          <Textarea
+            value={comment}
+            onChange={(e) => setComment(e.target.value)}
+            placeholder={t.ticket.rateCommentPlaceholder}
+            maxLength={500}
+            rows={2}
+            className="flex-1"
+          />
+          <Button size="sm" onClick={() => rateMutation.mutate()} disabled={rateMutation.isPending}>
+            {t.ticket.rateSubmit}
+          </Button>
+        </div>
+      ) : null}
+    </div>
+  )
+}
@@ -0,0 +1,38 @@
+'use client'
+
+import toast from 'react-hot-toast'
+import { Copy } from 'lucide-react'
+import { getTranslations } from '@/lib/i18n'
+import { copyToClipboard } from '@/lib/utils'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+
+// 一次性展示的两步验证备用码
+export function TwoFactorBackupCodes({ codes }: { codes: string[] }) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+
+  return (
+    <div className="space-y-3">
+      <p className="text-sm text-muted-foreground">{t.twoFactor.backupCodesDesc}</p>
+      <div className="grid grid-cols-2 gap-2 rounded-md border bg-muted/40 p-3 font-mono text-sm">
+        {codes.map((code) => (
+          <span key={code}>{code}</span>
+        ))}
+      </div>
+      <Button
+        type="button"
+        variant="outline"
+        size="sm"
+        onClick={async () => {
+          if (await copyToClipboard(codes.join('\n'))) {
+            toast.success(t.twoFactor.copied)
+          }
+        }}
+      >
+        <Copy className="mr-1.5 h-4 w-4" />
+        {t.twoFactor.copyCodes}
+      </Button>
+    </div>
+  )
+}
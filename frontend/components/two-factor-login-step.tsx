@@ -0,0 +1,164 @@
+'use client'
+
+import { useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { ArrowLeft, Loader2, ShieldCheck } from 'lucide-react'
+import {
+  recoverTwoFactor,
+  sendTwoFactorRecoveryCode,
+  verifyTwoFactor,
+  type TwoFactorChallenge,
+} from '@/lib/api'
+import { resolveAuthApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { TwoFactorBackupCodes } from '@/components/two-factor-backup-codes'
+import { TwoFactorSetupPanel } from '@/components/two-factor-setup-panel'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+// 登录第二步：输入验证码/备用码，或在管理员强制要求时先绑定验证器；丢失验证器可通过邮箱找回
+export function TwoFactorLoginStep({
+  challenge,
+  onComplete,
+  onCancel,
+}: {
+  challenge: TwoFactorChallenge
+  onComplete: (data: any) => Promise<void> | void
+  onCancel: () => void
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [mode, setMode] = useState<'code' | 'recovery'>('code')
+  const [code, setCode] = useState('')
+  const [recoveryEmail, setRecoveryEmail] = useState('')
+  const [enabledResponse, setEnabledResponse] = useState<any>(null)
+
+  const verifyMutation = useMutation({
+    mutationFn: () =>
+      mode === 'recovery'
+        ? recoverTwoFactor(code.trim(), challenge.two_factor_token)
+        : verifyTwoFactor(code.trim(), challenge.two_factor_token),
+    onSuccess: async (res: any) => {
+      setCode('')
+      setMode('code')
+      await onComplete(res)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveAuthApiErrorMessage(error, t, t.twoFactor.verifyFailed))
+    },
+  })
+
+  const sendRecoveryMutation = useMutation({
+    mutationFn: () => sendTwoFactorRecoveryCode(challenge.two_factor_token),
+    onSuccess: (res: any) => {
+      setRecoveryEmail(res.data?.email || '')
+      toast.success(t.twoFactor.recoveryCodeSent.replace('{email}', res.data?.email || ''))
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveAuthApiErrorMessage(error, t, t.twoFactor.actionFailed))
+    },
+  })
+
+  const title = challenge.two_factor_setup
+    ? t.twoFactor.setupRequiredTitle
+    : mode === 'recovery'
+      ? t.twoFactor.recoveryTitle
+      : t.twoFactor.challengeTitle
+  const description = challenge.two_factor_setup
+    ? t.twoFactor.setupRequiredDesc
+    : mode === 'recovery'
+      ? t.twoFactor.recoveryDesc
+      : t.twoFactor.challengeDesc
+
+  return (
+    <div className="space-y-6">
+      <div className="space-y-2">
+        <h2 className="flex items-center gap-2 text-2xl font-semibold tracking-tight">
+          <ShieldCheck className="h-6 w-6" />
+          {title}
+        </h2>
+        <p className="text-sm text-muted-foreground">{description}</p>
+      </div>
+
+      {challenge.two_factor_setup ? (
+        enabledResponse ? (
+          <div className="space-y-4">
+            <TwoFactorBackupCodes codes={enabledResponse.data?.backup_codes || []} />
+            <Button className="w-full" onClick={() => void onComplete(enabledResponse)}>
+              {t.twoFactor.continue}
+            </Button>
+          </div>
+        ) : (
+          <TwoFactorSetupPanel
+            challengeToken={challenge.two_factor_token}
+            onEnabled={setEnabledResponse}
+          />
+        )
+      ) : (
+        <form
+          className="space-y-4"
+          onSubmit={(e) => {
+            e.preventDefault()
+            verifyMutation.mutate()
+          }}
+        >
+          {mode === 'recovery' && (
+            <Button
+              type="button"
+              variant="outline"
+              className="w-full"
+              disabled={sendRecoveryMutation.isPending}
+              onClick={() => sendRecoveryMutation.mutate()}
+            >
+              {sendRecoveryMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {t.twoFactor.sendRecoveryCode}
+            </Button>
+          )}
+          {(mode === 'code' || recoveryEmail) && (
+            <div className="space-y-1.5">
+              <Label htmlFor="two_factor_code">{t.twoFactor.code}</Label>
+              <Input
+                id="two_factor_code"
+                autoFocus
+                autoComplete="one-time-code"
+                className="h-11"
+                maxLength={32}
+                placeholder={t.twoFactor.codePlaceholder}
+                value={code}
+                onChange={(e) => setCode(e.target.value)}
+              />
+            </div>
+          )}
+          {(mode === 'code' || recoveryEmail) && (
+            <Button
+              type="submit"
+              className="h-11 w-full"
+              disabled={!code.trim() || verifyMutation.isPending}
+            >
+              {verifyMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+              {mode === 'recovery' ? t.twoFactor.recover : t.twoFactor.verify}
+            </Button>
+          )}
+          <button
+            type="button"
+            className="text-sm text-primary hover:underline"
+            onClick={() => {
+              setCode('')
+              setMode(mode === 'code' ? 'recovery' : 'code')
+            }}
+          >
+            {mode === 'code' ? t.twoFactor.lostDevice : t.twoFactor.useAuthenticator}
+          </button>
+        </form>
+      )}
+
+      <Button variant="ghost" className="w-full" onClick={onCancel}>
+        <ArrowLeft className="mr-1.5 h-4 w-4" />
+        {t.twoFactor.backToLogin}
+      </Button>
+    </div>
+  )
+}
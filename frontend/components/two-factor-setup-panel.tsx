@@ -0,0 +1,105 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { useMutation } from '@tanstack/react-query'
+import toast from 'react-hot-toast'
+import { Loader2 } from 'lucide-react'
+import { enableTwoFactor, setupTwoFactor, type TwoFactorSetup } from '@/lib/api'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+import { getTranslations } from '@/lib/i18n'
+import { useLocale } from '@/hooks/use-locale'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+
+// 绑定验证器：展示二维码与密钥，校验首个验证码后返回启用接口的响应（含备用码）
+export function TwoFactorSetupPanel({
+  challengeToken,
+  onEnabled,
+}: {
+  challengeToken?: string
+  onEnabled: (data: any) => void
+}) {
+  const { locale } = useLocale()
+  const t = getTranslations(locale)
+  const [setup, setSetup] = useState<TwoFactorSetup | null>(null)
+  const [code, setCode] = useState('')
+
+  const setupMutation = useMutation({
+    mutationFn: () => setupTwoFactor(challengeToken),
+    onSuccess: (res: any) => setSetup(res.data),
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.twoFactor.actionFailed))
+    },
+  })
+  const { mutate: startSetup } = setupMutation
+
+  useEffect(() => {
+    startSetup()
+  }, [startSetup])
+
+  const enableMutation = useMutation({
+    mutationFn: () => enableTwoFactor(code.trim(), challengeToken),
+    onSuccess: (res: any) => {
+      toast.success(t.twoFactor.enabledSuccess)
+      onEnabled(res)
+    },
+    onError: (error: unknown) => {
+      toast.error(resolveApiErrorMessage(error, t, t.twoFactor.verifyFailed))
+    },
+  })
+
+  if (!setup) {
+    return (
+      <div className="flex items-center justify-center py-8">
+        {setupMutation.isError ? (
+          <Button variant="outline" onClick={() => startSetup()}>
+            {t.twoFactor.setupStart}
+          </Button>
+        ) : (
+          <Loader2 className="h-6 w-6 animate-spin" />
+        )}
+      </div>
+    )
+  }
+
+  return (
+    <form
+      className="space-y-4"
+      onSubmit={(e) => {
+        e.preventDefault()
+        enableMutation.mutate()
+      }}
+    >
+      <p className="text-sm text-muted-foreground">{t.twoFactor.scanQr}</p>
+      {/* eslint-disable-next-line @next/next/no-img-element */}
+      <img
+        src={setup.qr_code}
+        alt={t.twoFactor.qrAlt}
+        className="mx-auto h-48 w-48 rounded-md border bg-white p-2"
+      />
+      <div className="space-y-1">
+        <p className="text-xs text-muted-foreground">{t.twoFactor.manualEntry}</p>
+        <code className="block break-all rounded-md bg-muted px-3 py-2 text-sm">
+          {setup.secret}
+        </code>
+      </div>
+      <div className="space-y-1.5">
+        <Label htmlFor="two_factor_setup_code">{t.twoFactor.code}</Label>
+        <Input
+          id="two_factor_setup_code"
+          inputMode="numeric"
+          autoComplete="one-time-code"
+          maxLength={6}
+          placeholder={t.twoFactor.codePlaceholder}
+          value={code}
+          onChange={(e) => setCode(e.target.value)}
+        />
+      </div>
+      <Button type="submit" className="w-full" disabled={!code.trim() || enableMutation.isPending}>
+        {enableMutation.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+        {t.twoFactor.enable}
+      </Button>
+    </form>
+  )
+}
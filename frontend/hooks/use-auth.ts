@@ -1,9 +1,11 @@
 'use client'
 
-import { useMemo } from 'react'
+import { useMemo, useState } from 'react'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import {
   addToCart,
+  beginPasskeyLogin,
+  finishPasskeyLogin,
   getCurrentUser,
   login,
   loginWithCode,
@@ -11,6 +13,7 @@ import {
   logout,
   register,
   phoneRegister,
+  type TwoFactorChallenge,
 } from '@/lib/api'
 import {
   clearLegacyToken,
@@ -24,6 +27,7 @@ import { clearGuestCart, getGuestCart, setGuestCart } from '@/lib/guest-cart'
 import { clearAuthReturnState, readAuthReturnState } from '@/lib/auth-return-state'
 import { resolveAuthApiErrorMessage } from '@/lib/api-error'
 import { normalizeAuthUser } from '@/lib/auth-user'
+import { getPasskeyAssertion, isPasskeyCancelled } from '@/lib/webauthn'
 import { useRouter } from 'next/navigation'
 import toast from 'react-hot-toast'
 import { useLocale } from '@/hooks/use-locale'
@@ -48,6 +52,7 @@ export function useAuth() {
   const queryClient = useQueryClient()
   const { locale, setLocale } = useLocale()
   const t = getTranslations(locale)
+  const [twoFactorChallenge, setTwoFactorChallenge] = useState<TwoFactorChallenge | null>(null)
 
   async function syncGuestCartAfterLogin(): Promise<boolean> {
     const guestItems = getGuestCart()
@@ -78,6 +83,12 @@ export function useAuth() {
   }
 
   async function handleAuthSuccess(data: any) {
+    // 开启了两步验证：先进入验证码步骤，受限令牌不会保存为会话
+    if (data.data?.two_factor_required) {
+      setTwoFactorChallenge(data.data as TwoFactorChallenge)
+      return
+    }
+    setTwoFactorChallenge(null)
     const user = normalizeAuthUser(data.data.user)
     const desired = resolvePostLoginLocale(user?.locale)
     const finalUser = normalizeAuthUser(desired ? { ...user, locale: desired } : user)
@@ -152,6 +163,22 @@ export function useAuth() {
     },
   })
 
+  // 通行密钥登录：浏览器列出本站的可发现凭据，用户验证后直接签发会话
+  const passkeyLoginMutation = useMutation({
+    mutationFn: async () => {
+      const begin: any = await beginPasskeyLogin()
+      const assertion = await getPasskeyAssertion(begin.data.public_key)
+      return finishPasskeyLogin({ session_id: begin.data.session_id, ...assertion })
+    },
+    onSuccess: async (data: any) => {
+      await handleAuthSuccess(data)
+    },
+    onError: (error: any) => {
+      if (isPasskeyCancelled(error)) return
+      toast.error(resolveAuthApiErrorMessage(error, t, t.passkey.loginFailed))
+    },
+  })
+
   // 注册
   const registerMutation = useMutation({
     mutationFn: register,
@@ -208,15 +235,21 @@ export function useAuth() {
     login: loginMutation.mutate,
     loginWithCode: loginWithCodeMutation.mutate,
     loginWithPhoneCode: loginWithPhoneCodeMutation.mutate,
+    loginWithPasskey: passkeyLoginMutation.mutate,
     logout: () => {
       void logoutUser()
     },
     isLoggingIn: loginMutation.isPending,
     isLoggingInWithCode: loginWithCodeMutation.isPending,
     isLoggingInWithPhoneCode: loginWithPhoneCodeMutation.isPending,
+    isLoggingInWithPasskey: passkeyLoginMutation.isPending,
     register: registerMutation.mutate,
     isRegistering: registerMutation.isPending,
     registerWithPhone: phoneRegisterMutation.mutate,
     isRegisteringWithPhone: phoneRegisterMutation.isPending,
+    twoFactorChallenge,
+    // 两步验证步骤的响应（完成登录或转入强制绑定）交回统一处理
+    completeTwoFactor: handleAuthSuccess,
+    cancelTwoFactor: () => setTwoFactorChallenge(null),
   }
 }
@@ -0,0 +1,45 @@
+import {
+  indexAddressSchemas,
+  resolvePhoneCode,
+  validateAddressAgainstSchema,
+  type AddressSchema,
+} from '@/lib/address-schema'
+
+const usSchema: AddressSchema = {
+  code: 'US',
+  phone_code: '+1',
+  required_fields: ['receiver_province', 'receiver_city', 'receiver_postcode'],
+  postcode_pattern: '^\\d{5}(-\\d{4})?$',
+  postcode_example: '94105',
+}
+
+describe('address-schema', () => {
+  it('reports the first missing required field', () => {
+    expect(
+      validateAddressAgainstSchema(usSchema, { receiver_province: 'CA', receiver_city: '' })
+    ).toEqual({
+      field: 'receiver_city',
+      errorKey: 'order.cityRequired',
+      params: { country: 'US' },
+    })
+  })
+
+  it('checks postcode format after required fields', () => {
+    const values = { receiver_province: 'CA', receiver_city: 'San Francisco' }
+    expect(
+      validateAddressAgainstSchema(usSchema, { ...values, receiver_postcode: '9410' })?.errorKey
+    ).toBe('order.postcodeFormatInvalid')
+    expect(
+      validateAddressAgainstSchema(usSchema, { ...values, receiver_postcode: ' 94105-1234 ' })
+    ).toBeNull()
+  })
+
+  it('skips validation for countries without a schema', () => {
+    expect(validateAddressAgainstSchema(undefined, {})).toBeNull()
+  })
+
+  it('falls back to the bundled phone codes before the catalog loads', () => {
+    expect(resolvePhoneCode(indexAddressSchemas([usSchema]).US, 'US')).toBe('+1')
+    expect(resolvePhoneCode(undefined, 'JP')).toBe('+81')
+  })
+})
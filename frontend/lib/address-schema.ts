@@ -0,0 +1,70 @@
+import { phoneCodeMap } from '@/lib/phone-codes'
+
+export type AddressField =
+  | 'receiver_province'
+  | 'receiver_city'
+  | 'receiver_district'
+  | 'receiver_postcode'
+
+// 后端 /api/form/address-schemas 返回的国家地址格式
+export interface AddressSchema {
+  code: string
+  phone_code: string
+  required_fields: AddressField[]
+  postcode_pattern?: string
+  postcode_example?: string
+}
+
+export interface AddressSchemaIssue {
+  field: AddressField
+  errorKey: string
+  params: Record<string, string>
+}
+
+const requiredErrorKeys: Record<AddressField, string> = {
+  receiver_province: 'order.provinceRequired',
+  receiver_city: 'order.cityRequired',
+  receiver_district: 'order.districtRequired',
+  receiver_postcode: 'order.postcodeRequired',
+}
+
+export function indexAddressSchemas(schemas: AddressSchema[] | undefined) {
+  const index: Record<string, AddressSchema> = {}
+  for (const schema of schemas || []) {
+    if (schema?.code) index[schema.code.toUpperCase()] = schema
+  }
+  return index
+}
+
+// 电话区号优先使用后端目录，目录未加载时回退到本地表
+export function resolvePhoneCode(schema: AddressSchema | undefined, countryCode: string) {
+  return schema?.phone_code || phoneCodeMap[countryCode] || `+${countryCode}`
+}
+
+export function isAddressFieldRequired(schema: AddressSchema | undefined, field: AddressField) {
+  return Boolean(schema?.required_fields?.includes(field))
+}
+
+// 与后端 orderbiz.ValidateReceiverAddress 保持一致：先检查必填字段，再校验邮编格式
+export function validateAddressAgainstSchema(
+  schema: AddressSchema | undefined,
+  values: Partial<Record<AddressField, string>>
+): AddressSchemaIssue | null {
+  if (!schema) return null
+  const params = { country: schema.code }
+  for (const field of schema.required_fields || []) {
+    if (!String(values[field] || '').trim()) {
+      return { field, errorKey: requiredErrorKeys[field], params }
+    }
+  }
+  const postcode = String(values.receiver_postcode || '').trim().toUpperCase()
+  const pattern = schema.postcode_pattern ? new RegExp(schema.postcode_pattern) : null
+  if (postcode && pattern && !pattern.test(postcode)) {
+    return {
+      field: 'receiver_postcode',
+      errorKey: 'order.postcodeFormatInvalid',
+      params: { ...params, example: schema.postcode_example || '' },
+    }
+  }
+  return null
+}
@@ -0,0 +1,44 @@
+// 第一方行为事件上报：经同源代理发送到后端 /api/events，不写 Cookie，
+// 会话由后端按访客划分，广告拦截插件屏蔽第三方统计时仍能得到转化漏斗。
+
+import { resolveClientAPIProxyURL } from './api-base-url'
+
+export type CheckoutStep = 'cart' | 'submit' | 'placed' | 'payment'
+
+export type AnalyticsEvent =
+  | { type: 'page_view'; path?: string; referer?: string }
+  | { type: 'add_to_cart'; product_id: number; quantity: number; path?: string }
+  | { type: 'checkout_step'; step: CheckoutStep; path?: string }
+
+const ANALYTICS_EVENTS_PATH = '/api/events'
+
+// 上报失败不影响页面，后端未启用数据分析时也会静默丢弃
+export function trackAnalyticsEvent(event: AnalyticsEvent) {
+  if (typeof window === 'undefined') {
+    return
+  }
+  const body = JSON.stringify({ path: window.location.pathname, ...event })
+  const url = resolveClientAPIProxyURL(ANALYTICS_EVENTS_PATH)
+  try {
+    // 页面跳转时 sendBeacon 仍能送达；以 Blob 发送才能带上 JSON Content-Type
+    if (
+      typeof navigator.sendBeacon === 'function' &&
+      navigator.sendBeacon(url, new Blob([body], { type: 'application/json' }))
+    ) {
+      return
+    }
+    void fetch(url, {
+      method: 'POST',
+      body,
+      headers: { 'Content-Type': 'application/json' },
+      credentials: 'omit',
+      keepalive: true,
+    }).catch(() => {})
+  } catch {
+    // ignore
+  }
+}
+
+export function trackCheckoutStep(step: CheckoutStep) {
+  trackAnalyticsEvent({ type: 'checkout_step', step })
+}
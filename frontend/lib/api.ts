@@ -7,6 +7,9 @@ import {
   resolvePublicAPIURL,
 } from './api-base-url'
 import { stringifyPluginHostContext } from './plugin-frontend-routing'
+import { WAITING_ROOM_TOKEN_HEADER, getWaitingRoomTokenHeader } from './waiting-room'
+import { DEVICE_FINGERPRINT_HEADER, getDeviceFingerprint } from './device-fingerprint'
+import { reportSiteBannerVersion } from './site-banner'
 
 const PROXY_API_BASE_URL =
   typeof window === 'undefined' ? getConfiguredPublicAPIBaseURL() : getClientAPIProxyBaseURL()
@@ -271,9 +274,13 @@ function createAPIClient(baseURL: string, options?: APIClientOptions): AxiosInst
 
   client.interceptors.response.use(
     (response) => {
+      reportSiteBannerVersion(response.headers)
       return response.data
     },
     (error) => {
+      if (error.response) {
+        reportSiteBannerVersion(error.response.headers)
+      }
       if (options?.clearTokenOnUnauthorized && error.response?.status === 401) {
         // Token过期，清除token但不自动跳转
         // 跳转逻辑由各页面的布局组件控制
@@ -322,7 +329,9 @@ export interface Inventory {
   safety_stock: number
   alert_email?: string
   is_active: boolean
+  flash_sale: boolean
   notes?: string
+  version: number
   created_at: string
   updated_at: string
   product?: any
@@ -344,15 +353,26 @@ export interface UpdateInventoryRequest {
   available_quantity: number
   safety_stock: number
   is_active: boolean
+  flash_sale?: boolean
   alert_email?: string
   notes?: string
+  version?: number // 读取时的版本号，已被他人修改时返回 409 common.versionConflict
+}
+
+export interface FlashSaleStatus {
+  inventory_id: number
+  flash_sale: boolean
+  active: boolean
+  remaining: number | null
+  pending: number
 }
 
 export interface AdjustStockRequest {
-  stock: number
-  available_quantity: number
+  stock_delta: number
+  available_quantity_delta: number
   reason: string
   notes?: string
+  dry_run?: boolean
 }
 
 // 获取库存列表
@@ -430,12 +450,159 @@ export async function replaceProductBindings(productId: number, bindings: any[])
   })
 }
 
+// 跨商品查询当前的 SKU 与库存绑定
+export async function getAllInventoryBindings(params?: {
+  page?: number
+  limit?: number
+  search?: string
+  product_id?: number
+  inventory_id?: number
+}) {
+  return apiClient.get('/api/admin/inventory-bindings', { params })
+}
+
+export interface BindingAffectedOrder {
+  order_id: number
+  order_no: string
+  status: string
+  item_index: number
+  quantity: number
+  created_at: string
+}
+
+export interface BindingRebindImpact {
+  binding_id: number
+  product_id: number
+  product_sku: string
+  from_inventory_id: number
+  to_inventory_id: number
+  to_inventory?: Inventory
+  affected_orders: BindingAffectedOrder[]
+  affected_quantity: number
+  applied: boolean
+}
+
+// 换绑库存；dry_run 时只返回受影响的未完成订单
+export async function rebindInventoryBinding(
+  bindingId: number,
+  data: { inventory_id: number; dry_run?: boolean; force?: boolean; notes?: string }
+) {
+  return apiClient.post(`/api/admin/inventory-bindings/${bindingId}/rebind`, data)
+}
+
+export interface InventoryBindingHistory {
+  id: number
+  binding_id: number
+  product_id: number
+  action: 'create' | 'update' | 'delete' | 'rebind'
+  from_inventory_id?: number
+  to_inventory_id?: number
+  attributes?: Record<string, string>
+  affected_orders: number
+  operator_id?: number
+  notes?: string
+  created_at: string
+}
+
+export async function getInventoryBindingHistory(params?: {
+  page?: number
+  limit?: number
+  product_id?: number
+  binding_id?: number
+}) {
+  return apiClient.get('/api/admin/inventory-bindings/history', { params })
+}
+
 export async function updateProductInventoryMode(productId: number, mode: 'fixed' | 'random') {
   return apiClient.put(`/api/admin/products/${productId}/inventory-mode`, {
     inventory_mode: mode,
   })
 }
 
+export interface WaitingRoomStats {
+  product_id: number
+  waiting_room: boolean
+  active: boolean
+  queue_length: number
+  admitted: number
+}
+
+// 获取商品等候室概况
+export async function getProductWaitingRoom(productId: number) {
+  return apiClient.get(`/api/admin/products/${productId}/waiting-room`)
+}
+
+// 开关商品等候室
+export async function updateProductWaitingRoom(productId: number, enabled: boolean) {
+  return apiClient.put(`/api/admin/products/${productId}/waiting-room`, { enabled })
+}
+
+export interface ProductTrialStats {
+  trial_days: number
+  active: number
+  converted: number
+  expired: number
+}
+
+export interface ProductTrial {
+  id: number
+  user_id: number
+  product_id: number
+  product_name: string
+  days: number
+  status: 'active' | 'converted' | 'expired'
+  order_id?: number
+  order_no?: string
+  expires_at: string
+  remind_at: string
+  reminder_sent_at?: string
+  conversion_order_id?: number
+  conversion_order_no?: string
+  converted_at?: string
+  expired_at?: string
+  created_at: string
+}
+
+// 获取商品试用设置与试用统计
+export async function getProductTrial(productId: number) {
+  return apiClient.get(`/api/admin/products/${productId}/trial`)
+}
+
+// 设置商品试用天数，0 表示关闭
+export async function updateProductTrial(productId: number, trialDays: number) {
+  return apiClient.put(`/api/admin/products/${productId}/trial`, { trial_days: trialDays })
+}
+
+// 分页查询试用记录
+export async function getProductTrials(params?: {
+  page?: number
+  limit?: number
+  product_id?: number
+  user_id?: number
+  status?: string
+}) {
+  return apiClient.get('/api/admin/trials', { params })
+}
+
+export interface OrderRateCapSettings {
+  product_id: number
+  order_rate_cap: boolean
+  active: boolean
+  per_user_hourly: number
+  per_ip_hourly: number
+  per_device_hourly: number
+}
+
+// 获取商品下单频率限制
+export async function getProductOrderRateCap(productId: number) {
+  return apiClient.get(`/api/admin/products/${productId}/order-rate-cap`)
+}
+
+// 开关商品下单频率限制
+export async function updateProductOrderRateCap(productId: number, enabled: boolean) {
+  return apiClient.put(`/api/admin/products/${productId}/order-rate-cap`, { enabled })
+}
+
 // 获取库存详情
 export async function getInventory(id: number) {
   return apiClient.get(`/api/admin/inventories/${id}`)
@@ -446,6 +613,11 @@ export async function updateInventory(id: number, data: UpdateInventoryRequest)
   return apiClient.put(`/api/admin/inventories/${id}`, data)
 }
 
+// 获取秒杀库存的 Redis 计数状态
+export async function getInventoryFlashSaleStatus(id: number) {
+  return apiClient.get(`/api/admin/inventories/${id}/flash-sale`)
+}
+
 // 调整库存
 export async function adjustStock(id: number, data: AdjustStockRequest) {
   return apiClient.post(`/api/admin/inventories/${id}/adjust`, data)
@@ -461,6 +633,162 @@ export async function getLowStockList() {
   return apiClient.get('/api/admin/inventories/low-stock')
 }
 
+export interface StockDiscrepancy {
+  kind: 'reserved_mismatch' | 'oversold' | 'virtual_leak' | 'virtual_undelivered'
+  source: 'physical' | 'virtual'
+  inventory_id: number
+  inventory_name?: string
+  expected: number
+  actual: number
+  order_nos?: string[]
+  healed: boolean
+}
+
+export interface StockReconciliationRun {
+  id: number
+  trigger_type: 'scheduled' | 'manual'
+  triggered_by?: number
+  auto_heal: boolean
+  status: 'completed' | 'failed'
+  inventories_checked: number
+  virtual_stocks_checked: number
+  discrepancy_count: number
+  oversold_count: number
+  healed_count: number
+  discrepancies?: StockDiscrepancy[]
+  error?: string
+  started_at: string
+  finished_at?: string
+}
+
+// 库存对账记录
+export async function getStockReconciliationRuns(params?: { page?: number; limit?: number }) {
+  return apiClient.get('/api/admin/inventories/reconciliation/runs', { params })
+}
+
+export async function getStockReconciliationRun(id: number) {
+  return apiClient.get(`/api/admin/inventories/reconciliation/runs/${id}`)
+}
+
+// 立即执行库存对账，autoHeal 为 true 时同时修正计数
+export async function runStockReconciliation(autoHeal: boolean) {
+  return apiClient.post('/api/admin/inventories/reconciliation/runs', { auto_heal: autoHeal })
+}
+
+export interface InventorySnapshotLevel {
+  on_hand: number
+  available: number
+  reserved: number
+  sold: number
+}
+
+export interface InventorySnapshot extends InventorySnapshotLevel {
+  id: number
+  snapshot_date: string
+  source: 'physical' | 'virtual'
+  inventory_id: number
+  sku: string
+  name: string
+  captured_at: string
+}
+
+export interface InventorySnapshotResult {
+  requested_date: string
+  snapshot_date: string
+  captured_at?: string
+  items: InventorySnapshot[]
+}
+
+export interface InventorySnapshotComparison {
+  source: 'physical' | 'virtual'
+  inventory_id: number
+  sku: string
+  name: string
+  from?: InventorySnapshotLevel
+  to?: InventorySnapshotLevel
+  delta: InventorySnapshotLevel
+}
+
+export interface InventorySnapshotCompareResult {
+  from_date: string
+  to_date: string
+  from_snapshot_date: string
+  to_snapshot_date: string
+  items: InventorySnapshotComparison[]
+  totals: InventorySnapshotLevel
+}
+
+interface InventorySnapshotFilter {
+  source?: 'physical' | 'virtual'
+  search?: string
+}
+
+// 查询指定日期（YYYY-MM-DD，UTC）的库存快照，当天无快照时返回之前最近一次
+export async function getInventorySnapshot(params: { date?: string } & InventorySnapshotFilter) {
+  return apiClient.get('/api/admin/inventories/snapshots', { params })
+}
+
+// 对比两个日期的库存快照
+export async function compareInventorySnapshots(
+  params: { from: string; to: string } & InventorySnapshotFilter
+) {
+  return apiClient.get('/api/admin/inventories/snapshots/compare', { params })
+}
+
+// 立即拍摄当天库存快照
+export async function takeInventorySnapshot() {
+  return apiClient.post('/api/admin/inventories/snapshots')
+}
+
+export interface InventoryStockAlert {
+  id: number
+  source: 'physical' | 'virtual'
+  inventory_id: number
+  name: string
+  sku: string
+  low: boolean
+  available: number
+  threshold: number
+  low_since?: string
+  last_alerted_at?: string
+  alert_count: number
+  snoozed_until?: string
+  checked_at: string
+}
+
+export interface LowStockAlertResult {
+  checked: number
+  low: number
+  snoozed: number
+  alerted: { source: 'physical' | 'virtual'; inventory_id: number; name: string }[]
+  channels: string[]
+}
+
+// 低库存告警状态，low 为 true 时只返回当前低库存的库存
+export async function getLowStockAlerts(params?: { low?: boolean }) {
+  return apiClient.get('/api/admin/inventories/low-stock-alerts', { params })
+}
+
+// 立即检查低库存并发送告警
+export async function runLowStockCheck() {
+  return apiClient.post('/api/admin/inventories/low-stock-alerts/check')
+}
+
+// 静默单个库存的低库存告警
+export async function snoozeLowStockAlert(
+  source: 'physical' | 'virtual',
+  inventoryId: number,
+  minutes: number
+) {
+  return apiClient.post(`/api/admin/inventories/low-stock-alerts/${source}/${inventoryId}/snooze`, {
+    minutes,
+  })
+}
+
+export async function unsnoozeLowStockAlert(source: 'physical' | 'virtual', inventoryId: number) {
+  return apiClient.delete(`/api/admin/inventories/low-stock-alerts/${source}/${inventoryId}/snooze`)
+}
+
 // 获取库存日志
 export async function getInventoryLogs(params?: {
   page?: number
@@ -519,6 +847,82 @@ export async function getCountries() {
   return publicApiClient.get('/api/form/countries')
 }
 
+// 获取各国电话区号与地址格式（与后端校验规则一致）
+export async function getAddressSchemas() {
+  return publicApiClient.get('/api/form/address-schemas')
+}
+
+// ==========================================
+// 免登录订单查询API
+// ==========================================
+
+export type OrderTrackingCheckpointKey = 'placed' | 'paid' | 'shipped' | 'completed' | 'refunded'
+
+export interface PublicOrderTracking {
+  order_no: string
+  status: string
+  items: Array<{ name: string; quantity: number; image_url?: string }>
+  tracking_no?: string
+  receiver_country?: string
+  checkpoints: Array<{ key: OrderTrackingCheckpointKey; at: string }>
+  estimated_delivery_at?: string
+}
+
+// 凭订单号+下单邮箱查询
+export async function lookupOrderTracking(data: { order_no: string; email: string }) {
+  return publicApiClient.post('/api/order-tracking/lookup', data)
+}
+
+// 凭发货邮件中的签名链接查询
+export async function getOrderTrackingByLink(params: {
+  order_no: string
+  expires: string
+  sig: string
+}) {
+  return publicApiClient.get('/api/order-tracking/link', { params })
+}
+
+// ==========================================
+// 免登录付款链接API
+// ==========================================
+
+export interface PaymentLinkParams {
+  order_no: string
+  expires: string
+  sig: string
+}
+
+export interface PublicPaymentLinkOrder {
+  order_no: string
+  status: string
+  items: Array<{ name: string; quantity: number; image_url?: string }>
+  total_amount_minor: number
+  currency: string
+  created_at: string
+  paid_at?: string
+}
+
+export interface PaymentLinkResult {
+  order_no: string
+  url: string
+  expires_at: string
+  email_sent?: boolean
+  sms_sent?: boolean
+}
+
+// 凭签名付款链接查看订单与付款信息
+export async function getPaymentLinkOrder(params: PaymentLinkParams) {
+  return publicApiClient.get('/api/payment-link', { params })
+}
+
+// 凭签名付款链接选择付款方式
+export async function selectPaymentLinkMethod(params: PaymentLinkParams, paymentMethodId: number) {
+  return publicApiClient.post('/api/payment-link/select-payment', {
+    ...params,
+    payment_method_id: paymentMethodId,
+  })
+}
+
 // ==========================================
 // 认证API
 // ==========================================
@@ -646,6 +1050,134 @@ export async function updateUserPreferences(data: {
   return apiClient.put('/api/user/auth/preferences', data)
 }
 
+// ==========================================
+// 两步验证（TOTP）
+// ==========================================
+
+export interface TwoFactorStatus {
+  enabled: boolean
+  required: boolean
+  enabled_at?: string
+  backup_codes_remaining: number
+}
+
+export interface TwoFactorSetup {
+  secret: string
+  otpauth_uri: string
+  qr_code: string
+}
+
+// 登录第一步通过但需要两步验证时的响应，two_factor_token 只能调用两步验证接口
+export interface TwoFactorChallenge {
+  two_factor_required: true
+  two_factor_setup: boolean
+  two_factor_token: string
+}
+
+function twoFactorChallengeHeaders(challengeToken?: string) {
+  return challengeToken ? { headers: { Authorization: `Bearer ${challengeToken}` } } : undefined
+}
+
+export async function getTwoFactorStatus() {
+  return apiClient.get('/api/user/auth/2fa')
+}
+
+// challengeToken 仅在管理员强制绑定的登录流程中传入，个人中心使用当前会话
+export async function setupTwoFactor(challengeToken?: string) {
+  return apiClient.post('/api/user/auth/2fa/setup', {}, twoFactorChallengeHeaders(challengeToken))
+}
+
+export async function enableTwoFactor(code: string, challengeToken?: string) {
+  return apiClient.post(
+    '/api/user/auth/2fa/enable',
+    { code },
+    twoFactorChallengeHeaders(challengeToken)
+  )
+}
+
+export async function disableTwoFactor(code: string) {
+  return apiClient.post('/api/user/auth/2fa/disable', { code })
+}
+
+export async function regenerateTwoFactorBackupCodes(code: string) {
+  return apiClient.post('/api/user/auth/2fa/backup-codes', { code })
+}
+
+export async function verifyTwoFactor(code: string, challengeToken: string) {
+  return apiClient.post(
+    '/api/user/auth/2fa/verify',
+    { code },
+    twoFactorChallengeHeaders(challengeToken)
+  )
+}
+
+export async function sendTwoFactorRecoveryCode(challengeToken: string) {
+  return apiClient.post(
+    '/api/user/auth/2fa/recovery/send',
+    {},
+    twoFactorChallengeHeaders(challengeToken)
+  )
+}
+
+export async function recoverTwoFactor(code: string, challengeToken: string) {
+  return apiClient.post(
+    '/api/user/auth/2fa/recovery',
+    { code },
+    twoFactorChallengeHeaders(challengeToken)
+  )
+}
+
+// ==========================================
+// 通行密钥（Passkey）
+// ==========================================
+
+export interface UserPasskey {
+  id: number
+  name: string
+  algorithm: number
+  aaguid?: string
+  transports?: string[]
+  last_used_at?: string
+  created_at: string
+}
+
+export async function getPasskeys() {
+  return apiClient.get('/api/user/auth/passkeys')
+}
+
+export async function beginPasskeyRegistration() {
+  return apiClient.post('/api/user/auth/passkeys/register/begin', {})
+}
+
+export async function finishPasskeyRegistration(data: {
+  name?: string
+  id: string
+  client_data_json: string
+  attestation_object: string
+  transports?: string[]
+}) {
+  return apiClient.post('/api/user/auth/passkeys/register/finish', data)
+}
+
+export async function deletePasskey(id: number) {
+  return apiClient.delete(`/api/user/auth/passkeys/${id}`)
+}
+
+export async function beginPasskeyLogin() {
+  return apiClient.post('/api/user/auth/passkey/login/begin', {})
+}
+
+export async function finishPasskeyLogin(data: {
+  session_id: string
+  id: string
+  client_data_json: string
+  authenticator_data: string
+  signature: string
+  user_handle?: string
+}) {
+  return apiClient.post('/api/user/auth/passkey/login/finish', data)
+}
+
 export async function sendBindEmailCode(email: string, captcha_token?: string) {
   return apiClient.post('/api/user/auth/send-bind-email-code', { email, captcha_token })
 }
@@ -702,28 +1234,392 @@ export async function getOrder(orderNo: string) {
   return apiClient.get(`/api/user/orders/${orderNo}`)
 }
 
-export async function createOrder(data: { items: any[]; promo_code?: string }) {
-  return apiClient.post('/api/user/orders', data)
+export type OrderEventType = 'created' | 'status_changed' | 'payment' | 'shipment' | 'remark'
+
+export interface OrderEvent {
+  id: number
+  order_id: number
+  order_no: string
+  type: OrderEventType
+  from_status?: string
+  to_status?: string
+  source?: string
+  operator_type: 'user' | 'admin' | 'system'
+  operator_id?: number
+  message?: string
+  remark?: string
+  data?: Record<string, any> | null
+  internal: boolean
+  created_at: string
 }
 
-export async function getOrRefreshFormToken(orderNo: string) {
-  return apiClient.get(`/api/user/orders/${orderNo}/form-token`)
+export type ShipmentStatus =
+  | 'pending'
+  | 'info_received'
+  | 'in_transit'
+  | 'out_for_delivery'
+  | 'available_for_pickup'
+  | 'failed_attempt'
+  | 'delivered'
+  | 'exception'
+  | 'expired'
+
+export interface OrderShipmentCheckpoint {
+  id: number
+  shipment_id: number
+  status: ShipmentStatus
+  message: string
+  location?: string
+  occurred_at: string
+  created_at: string
 }
 
-// Get virtual products for an order
-export async function getOrderVirtualProducts(orderNo: string) {
-  return apiClient.get(`/api/user/orders/${orderNo}/virtual-products`)
+// 物流服务商回传的跟踪状态与轨迹，随订单详情返回
+export interface OrderShipment {
+  id: number
+  order_id: number
+  order_no: string
+  tracking_no: string
+  carrier?: string
+  provider: string
+  status: ShipmentStatus
+  registered_at?: string
+  register_attempts: number
+  register_error?: string
+  last_event_at?: string
+  delivered_at?: string
+  checkpoints: OrderShipmentCheckpoint[]
+  created_at: string
+  updated_at: string
 }
 
-export async function getInvoiceToken(orderNo: string) {
-  return apiClient.get(`/api/user/orders/${orderNo}/invoice-token`)
+// 订单时间线（状态变更、付款、发货记录）
+export async function getOrderTimeline(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/timeline`)
 }
 
-// ==========================================
-// 商品API
-// ==========================================
+export async function createOrder(data: {
+  items: any[]
+  promo_code?: string
+  gift_card_code?: string
+}) {
+  const headers: Record<string, string> = {}
+  // 限量发售商品需附带等候室准入令牌
+  const waitingRoomTokens = getWaitingRoomTokenHeader()
+  if (waitingRoomTokens) headers[WAITING_ROOM_TOKEN_HEADER] = waitingRoomTokens
+  // 限购频率商品按设备指纹统计下单次数
+  const deviceFingerprint = await getDeviceFingerprint()
+  if (deviceFingerprint) headers[DEVICE_FINGERPRINT_HEADER] = deviceFingerprint
+  return apiClient.post('/api/user/orders', data, { headers })
+}
 
-export async function getProducts(params?: {
+export interface WaitingRoomTicket {
+  product_id: number
+  status: 'not_required' | 'none' | 'queued' | 'admitted'
+  position?: number
+  queue_length?: number
+  access_token?: string
+  expires_at?: string
+  poll_after_seconds?: number
+}
+
+// 加入限量发售等候室
+export async function joinWaitingRoom(productId: number) {
+  return apiClient.post(`/api/user/waiting-room/${productId}/join`)
+}
+
+// 查询等候室排队位置（同时保持排队资格）
+export async function getWaitingRoomTicket(productId: number) {
+  return apiClient.get(`/api/user/waiting-room/${productId}`)
+}
+
+// 领取商品试用
+export async function startProductTrial(productId: number) {
+  return apiClient.post(`/api/user/trials/products/${productId}`)
+}
+
+// 我领取过的试用
+export async function getMyTrials() {
+  return apiClient.get('/api/user/trials')
+}
+
+export async function getOrRefreshFormToken(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/form-token`)
+}
+
+// Get virtual products for an order
+export async function getOrderVirtualProducts(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/virtual-products`)
+}
+
+// 高价值虚拟商品查看前重新验证身份（密码或邮箱验证码）
+export async function reauthOrderVirtualProducts(
+  orderNo: string,
+  data: { password?: string; code?: string }
+) {
+  return apiClient.post(`/api/user/orders/${orderNo}/virtual-products/reauth`, data)
+}
+
+export async function sendOrderVirtualProductsReauthCode(orderNo: string) {
+  return apiClient.post(`/api/user/orders/${orderNo}/virtual-products/reauth/send-code`)
+}
+
+export interface LicenseActivation {
+  id: number
+  stock_id: number
+  order_id: number
+  order_no: string
+  license_hint: string
+  device_id: string
+  device_name?: string
+  status: 'active' | 'deactivated'
+  ip_address?: string
+  activated_at: string
+  last_seen_at?: string
+  deactivated_at?: string
+  deactivated_by?: 'software' | 'user' | 'admin' | 'transfer'
+  transferred_to_id?: number
+}
+
+// 订单内卡密的设备激活历史
+export async function getOrderLicenseActivations(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/license-activations`)
+}
+
+// 解绑设备，释放激活名额
+export async function deactivateOrderLicenseActivation(orderNo: string, activationId: number) {
+  return apiClient.post(
+    `/api/user/orders/${orderNo}/license-activations/${activationId}/deactivate`
+  )
+}
+
+export interface UsageMeter {
+  id: number
+  stock_id: number
+  virtual_inventory_id: number
+  order_id: number
+  order_no: string
+  license_hint: string
+  unit: string
+  quota: number
+  used: number
+  overage_unit_price_minor: number
+  billed_overage: number
+  overage_order_id?: number
+  overage_order_no?: string
+  overage_order_units?: number
+  alerted_percent: number
+  last_reported_at?: string
+}
+
+// 订单内卡密的用量与剩余额度
+export async function getOrderUsageMeters(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/usage-meters`)
+}
+
+export async function getInvoiceToken(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/invoice-token`)
+}
+
+export interface OrderDocument {
+  key: string
+  name: string
+  description?: string
+  binding: 'order' | 'order_item'
+  items?: { index: number; name: string; sku: string }[]
+}
+
+// 订单当前可下载的自定义文档
+export async function getOrderDocuments(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/documents`)
+}
+
+// 一次性文档下载令牌
+export async function getOrderDocumentToken(orderNo: string, key: string, item = 0) {
+  return apiClient.get(`/api/user/orders/${orderNo}/documents/${encodeURIComponent(key)}/token`, {
+    params: { item },
+  })
+}
+
+export type RefundResolution = 'full_refund' | 'partial_refund' | 'replacement' | 'other'
+
+export interface RefundRequest {
+  id: number
+  order_id: number
+  order_no: string
+  user_id: number
+  user?: { id: number; name: string; email: string }
+  reason: string
+  resolution: RefundResolution
+  evidence?: string[]
+  status: 'pending' | 'approved' | 'rejected'
+  ticket_id?: number
+  ticket_no?: string
+  reviewed_at?: string
+  review_note?: string
+  refund_status?: string
+  transaction_id?: string
+  created_at: string
+}
+
+// 提交退款申请，凭证截图需先通过 uploadTicketIntakeAttachment 上传
+export async function createRefundRequest(
+  orderNo: string,
+  data: { reason: string; resolution: RefundResolution; evidence?: string[] }
+) {
+  return apiClient.post(`/api/user/orders/${orderNo}/refund-request`, data)
+}
+
+export async function getOrderRefundRequests(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/refund-requests`)
+}
+
+// 退货（RMA）：requested → approved → in_transit → received，待审核时可被拒绝
+export type ReturnStatus = 'requested' | 'approved' | 'rejected' | 'in_transit' | 'received'
+
+export type ReturnReason =
+  | 'defective'
+  | 'damaged'
+  | 'wrong_item'
+  | 'not_as_described'
+  | 'no_longer_needed'
+  | 'other'
+
+export interface ReturnItem {
+  item_index: number
+  sku: string
+  name: string
+  quantity: number
+  restocked: boolean
+}
+
+export interface ReturnRequest {
+  id: number
+  return_no: string
+  order_id: number
+  order_no: string
+  user_id: number
+  user?: { id: number; name: string; email: string }
+  items: ReturnItem[]
+  reason: ReturnReason
+  description?: string
+  photos?: string[]
+  status: ReturnStatus
+  reviewed_at?: string
+  review_note?: string
+  return_carrier?: string
+  return_tracking_no?: string
+  shipped_back_at?: string
+  received_at?: string
+  receive_note?: string
+  restocked: boolean
+  created_at: string
+}
+
+// 申请退货，图片需先通过 uploadTicketIntakeAttachment 上传
+export async function createReturnRequest(
+  orderNo: string,
+  data: {
+    items: { item_index: number; quantity: number }[]
+    reason: ReturnReason
+    description?: string
+    photos?: string[]
+  }
+) {
+  return apiClient.post(`/api/user/orders/${orderNo}/returns`, data)
+}
+
+export async function getOrderReturns(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/returns`)
+}
+
+export async function submitReturnTracking(
+  returnNo: string,
+  data: { carrier?: string; tracking_no: string }
+) {
+  return apiClient.post(`/api/user/returns/${returnNo}/tracking`, data)
+}
+
+// 部分退款：按订单项退款，状态 requested → approved → refunded，待审核时可被拒绝
+export type OrderRefundStatus = 'requested' | 'approved' | 'refunded' | 'rejected'
+
+export interface OrderRefundItem {
+  item_index: number
+  sku: string
+  name: string
+  quantity: number
+}
+
+export interface OrderRefund {
+  id: number
+  order_id: number
+  order_no: string
+  user_id?: number
+  items: OrderRefundItem[]
+  amount_minor: number
+  currency: string
+  reason: string
+  status: OrderRefundStatus
+  requested_by: number
+  requested_by_role: 'user' | 'admin'
+  reviewed_at?: string
+  review_note?: string
+  refunded_at?: string
+  transaction_id?: string
+  payment_pending: boolean
+  inventory_released: boolean
+  created_at: string
+}
+
+// 申请部分退款，金额按下单单价自动计算
+export async function createOrderPartialRefund(
+  orderNo: string,
+  data: { items: { item_index: number; quantity: number }[]; reason: string }
+) {
+  return apiClient.post(`/api/user/orders/${orderNo}/partial-refunds`, data)
+}
+
+export async function getOrderPartialRefunds(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/partial-refunds`)
+}
+
+// 订单分享给客服的工单授权，expires_at 为空表示不过期
+export interface OrderShare {
+  ticket_id: number
+  ticket_no: string
+  ticket_subject: string
+  ticket_status: string
+  include_receiver_info: boolean
+  expires_at?: string | null
+  created_at: string
+}
+
+export interface OrderShareSettings {
+  expires_in_hours?: number
+  include_receiver_info?: boolean
+}
+
+export async function getOrderShares(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/shares`)
+}
+
+export async function updateOrderShare(
+  orderNo: string,
+  ticketId: number,
+  data: OrderShareSettings
+) {
+  return apiClient.put(`/api/user/orders/${orderNo}/shares/${ticketId}`, data)
+}
+
+export async function revokeOrderShare(orderNo: string, ticketId: number) {
+  return apiClient.delete(`/api/user/orders/${orderNo}/shares/${ticketId}`)
+}
+
+// ==========================================
+// 商品API
+// ==========================================
+
+export async function getProducts(params?: {
   page?: number
   limit?: number
   category?: string
@@ -853,6 +1749,50 @@ export async function getAdminOrder(id: number) {
   return apiClient.get(`/api/admin/orders/${id}`)
 }
 
+// 订单时间线，包含管理员备注
+export async function getAdminOrderTimeline(id: number | string) {
+  return apiClient.get(`/api/admin/orders/${id}/timeline`)
+}
+
+// 追加管理员备注
+export async function addAdminOrderRemark(id: number | string, remark: string) {
+  return apiClient.post(`/api/admin/orders/${id}/remarks`, { remark })
+}
+
+export interface AdminVirtualRevealLog {
+  id: number
+  order_id: number
+  order_no: string
+  user_id: number
+  stock_count: number
+  auth_method: 'none' | 'password' | 'otp'
+  ip_address?: string
+  user_agent?: string
+  created_at: string
+}
+
+// 获取用户查看虚拟商品内容的审计记录
+export async function getAdminOrderVirtualRevealLogs(
+  id: number,
+  params?: { page?: number; limit?: number }
+) {
+  return apiClient.get(`/api/admin/orders/${id}/virtual-reveal-logs`, { params })
+}
+
+// 获取订单卡密的设备激活历史
+export async function getAdminOrderLicenseActivations(id: number) {
+  return apiClient.get(`/api/admin/orders/${id}/license-activations`)
+}
+
+export async function deactivateAdminOrderLicenseActivation(id: number, activationId: number) {
+  return apiClient.post(`/api/admin/orders/${id}/license-activations/${activationId}/deactivate`)
+}
+
+// 获取订单卡密的用量计量
+export async function getAdminOrderUsageMeters(id: number) {
+  return apiClient.get(`/api/admin/orders/${id}/usage-meters`)
+}
+
 // 获取有订单的国家列表
 export async function getOrderCountries() {
   return apiClient.get('/api/admin/orders/countries')
@@ -919,6 +1859,36 @@ export async function getAdminOrderDetail(id: number) {
   return apiClient.get(`/api/admin/orders/${id}`)
 }
 
+export interface AdminOrderActivityLog {
+  id: number
+  action: string
+  operator_name?: string
+  details?: Record<string, any>
+  created_at: string
+}
+
+export interface AdminOrderActivity {
+  refund_requests: RefundRequest[]
+  refund_events: AdminOrderActivityLog[]
+  shipments: AdminOrderActivityLog[]
+  delivery_attempts: AdminOrderActivityLog[]
+  emails: {
+    id: number
+    to_email: string
+    subject: string
+    event_type?: string
+    status: string
+    created_at: string
+  }[]
+  sms: { id: number; phone: string; event_type?: string; status: string; created_at: string }[]
+  tickets: { id: number; ticket_no: string; subject: string; status: string; created_at: string }[]
+}
+
+// 获取订单详情及退款、发货、发货脚本、邮件/短信和关联工单记录（一次请求）
+export async function getAdminOrderFull(id: number) {
+  return apiClient.get(`/api/admin/orders/${id}/full`)
+}
+
 // 管理员创建订单
 export async function createAdminOrder(data: any) {
   return apiClient.post('/api/admin/orders', data)
@@ -951,2589 +1921,4730 @@ export async function adminConfirmRefund(
   return apiClient.post(`/api/admin/orders/${id}/confirm-refund`, data || {})
 }
 
-export async function batchUpdateOrders(orderIds: number[], action: string) {
-  return apiClient.post('/api/admin/orders/batch/update', { order_ids: orderIds, action })
+// 退款申请审核队列
+export async function getRefundRequests(params?: {
+  page?: number
+  limit?: number
+  status?: string
+}) {
+  return apiClient.get('/api/admin/refund-requests', { params })
 }
 
-export async function updateOrderShippingInfo(id: number, data: any) {
-  return apiClient.put(`/api/admin/orders/${id}/shipping-info`, data)
+export async function approveRefundRequest(id: number, note?: string) {
+  return apiClient.post(`/api/admin/refund-requests/${id}/approve`, { note })
 }
 
-export async function requestOrderResubmit(id: number, reason: string) {
-  return apiClient.post(`/api/admin/orders/${id}/request-resubmit`, { reason })
+export async function rejectRefundRequest(id: number, note: string) {
+  return apiClient.post(`/api/admin/refund-requests/${id}/reject`, { note })
 }
 
-export async function adminMarkOrderAsPaid(id: number) {
-  return apiClient.post(`/api/admin/orders/${id}/mark-paid`)
+// 退货审核、收货
+export async function getAdminReturns(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  search?: string
+}) {
+  return apiClient.get('/api/admin/returns', { params })
 }
 
-export async function adminDeliverVirtualStock(id: number, data?: { mark_only_shipped?: boolean }) {
-  return apiClient.post(`/api/admin/orders/${id}/deliver-virtual`, data || {})
+export async function getAdminReturn(id: number) {
+  return apiClient.get(`/api/admin/returns/${id}`)
 }
 
-export async function updateOrderPrice(id: number, totalAmountMinor: number) {
-  return apiClient.put(`/api/admin/orders/${id}/price`, { total_amount_minor: totalAmountMinor })
+export async function approveReturn(id: number, note?: string) {
+  return apiClient.post(`/api/admin/returns/${id}/approve`, { note })
 }
 
-// 用户管理
-export async function getUsers(params?: {
+export async function rejectReturn(id: number, note: string) {
+  return apiClient.post(`/api/admin/returns/${id}/reject`, { note })
+}
+
+export async function receiveReturn(id: number, data: { restock: boolean; note?: string }) {
+  return apiClient.post(`/api/admin/returns/${id}/receive`, data)
+}
+
+// 订单争议队列、商家答辩与平台裁决
+export async function getAdminDisputes(params?: {
   page?: number
   limit?: number
-  role?: string
-  search?: string
-  is_active?: boolean
-  email_verified?: boolean
-  email_notify_marketing?: boolean
-  sms_notify_marketing?: boolean
-  has_phone?: boolean
-  locale?: string
-  country?: string
+  status?: string
 }) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.role) query.append('role', params.role)
-  if (params?.search) query.append('search', params.search)
-  if (params?.is_active !== undefined) query.append('is_active', String(params.is_active))
-  if (params?.email_verified !== undefined)
-    query.append('email_verified', String(params.email_verified))
-  if (params?.email_notify_marketing !== undefined)
-    query.append('email_notify_marketing', String(params.email_notify_marketing))
-  if (params?.sms_notify_marketing !== undefined)
-    query.append('sms_notify_marketing', String(params.sms_notify_marketing))
-  if (params?.has_phone !== undefined) query.append('has_phone', String(params.has_phone))
-  if (params?.locale) query.append('locale', params.locale)
-  if (params?.country) query.append('country', params.country)
+  return apiClient.get('/api/admin/disputes', { params })
+}
 
-  return apiClient.get(`/api/admin/users?${query}`)
+export async function getAdminDispute(id: number) {
+  return apiClient.get(`/api/admin/disputes/${id}`)
 }
 
-export async function getUserCountries(params?: { role?: string }) {
-  const query = new URLSearchParams()
-  if (params?.role) query.append('role', params.role)
-  return apiClient.get(`/api/admin/users/countries?${query}`)
+// 商家证据需先通过 uploadAdminTicketFile 上传到争议关联的工单
+export async function submitDisputeEvidence(
+  id: number,
+  data: { statement: string; evidence?: string[] }
+) {
+  return apiClient.post(`/api/admin/disputes/${id}/evidence`, data)
 }
 
-export async function getUserDetail(id: number) {
-  return apiClient.get(`/api/admin/users/${id}`)
+export async function decideDispute(
+  id: number,
+  data: {
+    decision: OrderDisputeDecision
+    items?: { item_index: number; quantity: number }[]
+    amount_minor?: number
+    note: string
+  }
+) {
+  return apiClient.post(`/api/admin/disputes/${id}/decide`, data)
 }
 
-export async function createUser(data: any) {
-  return apiClient.post('/api/admin/users', data)
+// 部分退款队列及审核、退款
+export async function getAdminOrderRefunds(params?: {
+  page?: number
+  limit?: number
+  status?: string
+}) {
+  return apiClient.get('/api/admin/order-refunds', { params })
 }
 
-export async function updateUser(id: number, data: any) {
-  return apiClient.put(`/api/admin/users/${id}`, data)
+export async function getAdminOrderPartialRefunds(orderId: number) {
+  return apiClient.get(`/api/admin/orders/${orderId}/partial-refunds`)
 }
 
-export async function deleteUser(id: number) {
-  return apiClient.delete(`/api/admin/users/${id}`)
+// amount_minor 为 0 时按下单单价计算
+export async function createAdminOrderPartialRefund(
+  orderId: number,
+  data: {
+    items: { item_index: number; quantity: number }[]
+    amount_minor?: number
+    reason: string
+  }
+) {
+  return apiClient.post(`/api/admin/orders/${orderId}/partial-refunds`, data)
 }
 
-// 管理员用户管理
-export async function getAdmins(params?: { page?: number; limit?: number }) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
+export async function approveOrderRefund(id: number, note?: string) {
+  return apiClient.post(`/api/admin/order-refunds/${id}/approve`, { note })
+}
 
-  return apiClient.get(`/api/admin/admins?${query}`)
+export async function rejectOrderRefund(id: number, note: string) {
+  return apiClient.post(`/api/admin/order-refunds/${id}/reject`, { note })
 }
 
-export async function createAdmin(data: any) {
-  return apiClient.post('/api/admin/admins', data)
+export async function issueOrderRefund(id: number) {
+  return apiClient.post(`/api/admin/order-refunds/${id}/issue`)
 }
 
-export async function updateAdmin(id: number, data: any) {
-  return apiClient.put(`/api/admin/admins/${id}`, data)
+// 订单改价：减免超过 order.price_adjustment.approval_threshold 时需另一名管理员批准
+export type OrderPriceAdjustmentType = 'discount' | 'override'
+export type OrderPriceAdjustmentStatus = 'pending' | 'applied' | 'rejected'
+
+export interface OrderPriceAdjustment {
+  id: number
+  order_id: number
+  order_no: string
+  type: OrderPriceAdjustmentType
+  currency: string
+  total_before_minor: number
+  total_after_minor: number
+  amount_minor: number
+  reason: string
+  status: OrderPriceAdjustmentStatus
+  requires_approval: boolean
+  requested_by: number
+  reviewed_by?: number
+  reviewed_at?: string
+  review_note?: string
+  applied_at?: string
+  created_at: string
 }
 
-export async function deleteAdmin(id: number) {
-  return apiClient.delete(`/api/admin/admins/${id}`)
+export async function getOrderPriceAdjustments(orderId: number) {
+  return apiClient.get(`/api/admin/orders/${orderId}/price-adjustments`)
 }
 
-// API密钥管理
-export async function getApiKeys() {
-  return apiClient.get('/api/admin/api-keys')
+// discount 传 amount_minor（减免金额），override 传 total_amount_minor（新的应付金额）
+export async function createOrderPriceAdjustment(
+  orderId: number,
+  data: {
+    type: OrderPriceAdjustmentType
+    amount_minor?: number
+    total_amount_minor?: number
+    reason: string
+  }
+) {
+  return apiClient.post(`/api/admin/orders/${orderId}/price-adjustments`, data)
 }
 
-export async function createApiKey(data: {
-  key_name: string
-  platform: string
-  scopes: string[]
-  rate_limit?: number
-  expires_at?: string
+export async function getPriceAdjustments(params?: {
+  page?: number
+  limit?: number
+  status?: OrderPriceAdjustmentStatus
 }) {
-  return apiClient.post('/api/admin/api-keys', data)
+  return apiClient.get('/api/admin/price-adjustments', { params })
 }
 
-export async function deleteApiKey(id: number) {
-  return apiClient.delete(`/api/admin/api-keys/${id}`)
+export async function approvePriceAdjustment(id: number, note?: string) {
+  return apiClient.post(`/api/admin/price-adjustments/${id}/approve`, { note })
 }
 
-// 插件管理
-export interface AdminPluginEffectiveCapabilityPolicy {
-  hooks: string[]
-  disabled_hooks: string[]
-  requested_permissions: string[]
-  granted_permissions: string[]
-  frontend_min_scope: 'guest' | 'authenticated' | 'super_admin' | string
-  frontend_required_permissions: string[]
-  frontend_allowed_areas: string[]
-  allowed_frontend_slots: string[]
-  allow_hook_execute: boolean
-  allow_block: boolean
-  allow_payload_patch: boolean
-  allow_frontend_extensions: boolean
-  allow_execute_api: boolean
-  allow_network: boolean
-  allow_file_system: boolean
-  valid: boolean
+export async function rejectPriceAdjustment(id: number, note: string) {
+  return apiClient.post(`/api/admin/price-adjustments/${id}/reject`, { note })
 }
 
-export interface AdminPluginWorkspaceCommand {
-  name: string
-  title?: string
-  description?: string
-  entry?: string
-  interactive: boolean
-  builtin?: boolean
-  permissions?: string[]
-  missing_permissions?: string[]
-  granted: boolean
+// 本位币结算报表：订单按付款时记录的汇率折算，按月份、币种、付款方式汇总
+export interface FXSettlementRow {
+  month: string
+  currency: string
+  gateway: string
+  base_currency: string
+  order_count: number
+  gross_amount_minor: number
+  refunded_count: number
+  refunded_amount_minor: number
+  base_amount_minor: number
+  refunded_base_amount_minor: number
+  net_base_amount_minor: number
+  unconverted_count: number
 }
 
-export interface AdminPlugin {
-  id: number
-  name: string
-  display_name?: string
-  description?: string
-  type: string
-  runtime?: string
-  address: string
-  version?: string
-  config?: string
-  runtime_params?: string
-  capabilities?: string
-  manifest?: string
-  package_path?: string
-  package_path_display?: string
-  package_checksum?: string
-  address_display?: string
-  runtime_spec_hash?: string
-  desired_generation?: number
-  applied_generation?: number
-  enabled: boolean
-  status?: string
-  lifecycle_status?: string
-  last_error?: string
-  last_healthy?: string
-  installed_at?: string
-  started_at?: string
-  stopped_at?: string
-  retired_at?: string
-  fail_count?: number
-  created_at?: string
-  updated_at?: string
-  effective_capability_policy?: AdminPluginEffectiveCapabilityPolicy
-  workspace_commands?: AdminPluginWorkspaceCommand[]
-  latest_deployment?: AdminPluginDeployment
+export interface FXSettlementReport {
+  base_currency: string
+  from: string
+  to: string
+  rows: FXSettlementRow[]
 }
 
-export interface AdminPluginSecretMeta {
-  key: string
-  configured: boolean
-  updated_at?: string
+export async function getFXSettlementReport(params?: { from?: string; to?: string }) {
+  return apiClient.get('/api/admin/reports/fx-settlement', { params })
 }
 
-export interface AdminPluginExecution {
-  id: number
-  plugin_id: number
-  user_id?: number
-  order_id?: number
-  action: string
-  params?: string
-  success: boolean
-  result?: string
-  error?: string
-  duration?: number
-  created_at?: string
+// 收入确认：订阅与预付额度在服务期内按月确认，报表为递延收入月度变动表
+export type RevenueRecognitionType = '' | 'subscription' | 'prepaid_credit'
+
+export interface RevenueRecognitionRow {
+  period: string
+  currency: string
+  type: Exclude<RevenueRecognitionType, ''>
+  schedule_count: number
+  deferred_opening_minor: number
+  billed_amount_minor: number
+  recognized_amount_minor: number
+  released_amount_minor: number
+  deferred_closing_minor: number
 }
 
-export interface AdminPluginVersion {
-  id: number
-  plugin_id: number
-  version: string
-  package_name?: string
-  package_path?: string
-  package_path_display?: string
-  package_checksum?: string
-  manifest?: string
-  type?: string
-  runtime?: string
-  address?: string
-  config_snapshot?: string
-  runtime_params?: string
-  capabilities_snapshot?: string
-  changelog?: string
-  lifecycle_status?: string
-  is_active: boolean
-  uploaded_by?: number
-  activated_at?: string
-  created_at?: string
-  updated_at?: string
+export interface RevenueRecognitionReport {
+  from: string
+  to: string
+  rows: RevenueRecognitionRow[]
 }
 
-export interface AdminPluginDeployment {
+export async function getRevenueRecognitionReport(params?: { from?: string; to?: string }) {
+  return apiClient.get('/api/admin/reports/revenue-recognition', { params })
+}
+
+export async function backfillRevenueSchedules(since: string) {
+  return apiClient.post('/api/admin/reports/revenue-recognition/backfill', { since })
+}
+
+export async function updateProductRevenueRecognition(
+  productId: number,
+  data: { revenue_recognition: RevenueRecognitionType; service_period_months: number }
+) {
+  return apiClient.put(`/api/admin/products/${productId}/revenue-recognition`, data)
+}
+
+// 会计系统对接（QuickBooks / Xero）
+export type AccountingExportFormat = 'journal' | 'quickbooks' | 'xero'
+
+export interface AccountingExportRun {
   id: number
-  plugin_id: number
-  operation: string
-  trigger?: string
-  status: string
-  target_version_id?: number
-  requested_generation?: number
-  applied_generation?: number
-  runtime_spec_hash?: string
-  auto_start?: boolean
-  requested_by?: number
-  detail?: string
+  provider: 'quickbooks' | 'xero'
+  trigger_type: 'scheduled' | 'manual'
+  triggered_by?: number
+  status: 'completed' | 'partial' | 'failed'
+  pushed_count: number
+  failed_count: number
+  skipped_count: number
   error?: string
-  started_at?: string
+  started_at: string
   finished_at?: string
-  created_at?: string
-  updated_at?: string
 }
 
-export interface PluginPermissionRequest {
-  key: string
-  required: boolean
-  reason?: string
-  title?: string
-  description?: string
-  default_granted?: boolean
+export async function getAccountingExportRuns(params?: { page?: number; limit?: number }) {
+  return apiClient.get('/api/admin/reports/accounting/runs', { params })
 }
 
-export interface AdminPluginMarketSource {
-  source_id: string
-  name?: string
-  base_url: string
-  public_key?: string
-  default_channel?: string
-  allowed_kinds?: string[]
-  enabled?: boolean
+export async function triggerAccountingExport() {
+  return apiClient.post('/api/admin/reports/accounting/runs')
 }
 
-export interface AdminPluginMarketPreviewRequest {
-  source: AdminPluginMarketSource
-  kind: string
+// 入驻商家与分账结算
+export type VendorStatus = 'active' | 'disabled'
+
+export interface Vendor {
+  id: number
   name: string
-  version: string
+  contact_email?: string
+  commission_rate: number
+  payout_account?: string
+  status: VendorStatus
+  remark?: string
+  created_at: string
+  updated_at: string
+  product_count?: number
+  unsettled?: { currency: string; amount_minor: number }[]
 }
 
-export interface AdminPluginMarketInstallRequest extends AdminPluginMarketPreviewRequest {
-  granted_permissions?: string[]
-  activate?: boolean
-  auto_start?: boolean
-  note?: string
+export interface VendorInput {
+  name: string
+  contact_email?: string
+  commission_rate: number
+  payout_account?: string
+  status?: VendorStatus
+  remark?: string
 }
 
-export interface AdminPaymentMethodMarketPreviewRequest extends AdminPluginMarketPreviewRequest {
-  payment_method_id?: number
+export interface VendorLedgerEntry {
+  id: number
+  vendor_id: number
+  type: 'sale' | 'refund' | 'adjustment'
+  order_id?: number
+  order_no?: string
+  item_index: number
+  sku?: string
+  quantity: number
+  currency: string
+  gross_amount_minor: number
+  commission_minor: number
+  net_amount_minor: number
+  note?: string
+  created_by?: number
+  statement_id?: number
+  created_at: string
 }
 
-export interface AdminPaymentMethodMarketImportRequest extends AdminPluginMarketPreviewRequest {
-  payment_method_id?: number
-  payment_name?: string
-  payment_description?: string
-  icon?: string
-  entry?: string
-  config?: string
-  poll_interval?: number
+export interface VendorPayoutStatement {
+  id: number
+  vendor_id: number
+  vendor?: Vendor
+  currency: string
+  period_start: string
+  period_end: string
+  entry_count: number
+  sales_amount_minor: number
+  commission_amount_minor: number
+  refund_amount_minor: number
+  adjustment_amount_minor: number
+  payout_amount_minor: number
+  status: 'pending' | 'paid'
+  paid_at?: string
+  payment_reference?: string
+  created_at: string
 }
 
-export type PluginHookGroupKey =
-  | 'frontend'
-  | 'auth'
-  | 'order'
-  | 'payment'
-  | 'ticket'
-  | 'product_inventory'
-  | 'promo'
-  | 'other'
-
-export interface AdminPluginHookCatalogGroup {
-  key: PluginHookGroupKey | string
-  hooks: string[]
+export async function getVendors() {
+  return apiClient.get('/api/admin/vendors')
 }
 
-export interface AdminPluginHookCatalog {
-  groups: AdminPluginHookCatalogGroup[]
-  hooks: string[]
+export async function createVendor(data: VendorInput) {
+  return apiClient.post('/api/admin/vendors', data)
 }
 
-export interface PluginObservabilityExecutionCounters {
-  total?: number
-  success?: number
-  failed?: number
-  error_rate?: number
-  timeout?: number
-  timeout_rate?: number
-  avg_duration_ms?: number
-  max_duration_ms?: number
+export async function updateVendor(id: number, data: VendorInput) {
+  return apiClient.put(`/api/admin/vendors/${id}`, data)
 }
 
-export interface PluginObservabilityPluginExecution extends PluginObservabilityExecutionCounters {
-  plugin_id?: number
-  plugin_name?: string
-  runtime?: string
+export async function getVendorLedger(
+  id: number,
+  params?: { page?: number; limit?: number; unsettled?: 1 }
+) {
+  return apiClient.get(`/api/admin/vendors/${id}/ledger`, { params })
 }
 
-export interface PluginObservabilityExecutionSnapshot {
-  overall?: PluginObservabilityExecutionCounters
-  by_runtime?: Record<string, PluginObservabilityExecutionCounters>
-  by_action?: Record<string, PluginObservabilityExecutionCounters>
-  by_plugin?: PluginObservabilityPluginExecution[]
+export async function createVendorAdjustment(
+  id: number,
+  data: { currency: string; amount_minor: number; note: string }
+) {
+  return apiClient.post(`/api/admin/vendors/${id}/adjustments`, data)
 }
 
-export interface PluginObservabilityHookLimiterSnapshot {
-  total_hits?: number
-  by_hook?: Record<string, number>
+export async function getVendorStatements(params?: {
+  page?: number
+  limit?: number
+  vendor_id?: number
+  status?: string
+}) {
+  return apiClient.get('/api/admin/vendor-statements', { params })
 }
 
-export interface PluginObservabilityPublicEndpointSnapshot {
-  requests?: number
-  rate_limited?: number
-  rate_limit_hit_rate?: number
-  cache_hits?: number
-  cache_misses?: number
-  cache_hit_rate?: number
+export async function generateVendorStatements(data: { period_start: string; period_end: string }) {
+  return apiClient.post('/api/admin/vendor-statements/generate', data)
 }
 
-export interface PluginObservabilityFrontendResolverSnapshot {
-  cache_hits?: number
-  cache_misses?: number
-  cache_hit_rate?: number
-  singleflight_waits?: number
-  catalog_hits?: number
-  db_fallbacks?: number
+export async function markVendorStatementPaid(id: number, reference: string) {
+  return apiClient.post(`/api/admin/vendor-statements/${id}/mark-paid`, { reference })
 }
 
-export interface PluginObservabilityFrontendSnapshot {
-  slot_requests?: number
-  batch_requests?: number
-  bootstrap_requests?: number
-  batch_items?: number
-  batch_unique_items?: number
-  batch_deduped_items?: number
-  html_mode?: PluginObservabilityFrontendResolverSnapshot
-  execute_api?: PluginObservabilityFrontendResolverSnapshot
-  prepared_hook?: PluginObservabilityFrontendResolverSnapshot
+// 设置商品所属商家，vendorId 为 null 表示平台自营
+export async function updateProductVendor(productId: number, vendorId: number | null) {
+  return apiClient.put(`/api/admin/products/${productId}/vendor`, { vendor_id: vendorId })
 }
 
-export interface PluginObservabilityExecutionWindowBucket {
-  hour_start?: string
-  total_executions?: number
-  failed_executions?: number
+// 企业账户与账期付款
+export type BusinessAccountStatus = 'pending' | 'approved' | 'rejected' | 'suspended'
+
+export interface BusinessAccountCredit {
+  currency: string
+  credit_limit_minor: number
+  outstanding_minor: number
+  overdue_minor: number
+  available_minor: number
+  open_invoice_count: number
+  can_use_terms: boolean
 }
 
-export interface PluginObservabilityExecutionFailureGroup {
-  plugin_id?: number
-  plugin_name?: string
-  action?: string
-  hook?: string
-  failure_count?: number
-  last_failure_at?: string
+export interface BusinessAccount {
+  id: number
+  user_id: number
+  user?: { id: number; email: string; name?: string }
+  company_name: string
+  tax_id?: string
+  billing_email?: string
+  billing_address?: string
+  status: BusinessAccountStatus
+  currency?: string
+  credit_limit_minor: number
+  terms_days: number
+  credit_hold: boolean
+  credit_hold_reason?: 'overdue' | 'manual'
+  credit_hold_at?: string
+  reviewed_at?: string
+  review_note?: string
+  created_at: string
+  updated_at: string
+  credit?: BusinessAccountCredit
 }
 
-export interface PluginObservabilityExecutionFailureSample {
-  id?: number
-  plugin_id?: number
-  plugin_name?: string
-  action?: string
-  hook?: string
-  error?: string
-  duration?: number
-  created_at?: string
+export interface NetTermsInvoice {
+  id: number
+  invoice_no: string
+  business_account_id: number
+  business_account?: BusinessAccount
+  user_id: number
+  order_id: number
+  order_no: string
+  currency: string
+  amount_minor: number
+  issued_at: string
+  due_at: string
+  status: 'open' | 'paid' | 'void'
+  paid_at?: string
+  payment_reference?: string
+  voided_at?: string
+  void_reason?: string
+  dunning_count: number
+  last_dunning_at?: string
+  created_at: string
 }
 
-export interface PluginObservabilityExecutionHookGroup {
-  plugin_id?: number
-  plugin_name?: string
-  hook?: string
-  failure_count?: number
-  last_failure_at?: string
-  last_error?: string
+export interface NetTermsStatementLine {
+  date: string
+  type: 'invoice' | 'payment' | 'void'
+  invoice_no: string
+  order_no: string
+  due_at: string
+  reference?: string
+  amount_minor: number
+  balance_minor: number
 }
 
-export interface PluginObservabilityExecutionErrorSignature {
-  plugin_id?: number
-  plugin_name?: string
-  signature?: string
-  failure_count?: number
-  last_failure_at?: string
-  sample_error?: string
+export interface NetTermsStatement {
+  account: BusinessAccount
+  currency: string
+  period_start: string
+  period_end: string
+  opening_balance_minor: number
+  invoiced_minor: number
+  paid_minor: number
+  voided_minor: number
+  closing_balance_minor: number
+  lines: NetTermsStatementLine[]
+  open_invoices: NetTermsInvoice[]
 }
 
-export interface PluginObservabilityExecutionWindow {
-  window_hours?: number
-  total_executions?: number
-  failed_executions?: number
-  hook_failed_executions?: number
-  action_failed_executions?: number
-  last_failure_at?: string
-  last_success_at?: string
-  by_hour?: PluginObservabilityExecutionWindowBucket[]
-  failure_groups?: PluginObservabilityExecutionFailureGroup[]
-  hook_groups?: PluginObservabilityExecutionHookGroup[]
-  error_signatures?: PluginObservabilityExecutionErrorSignature[]
-  recent_failures?: PluginObservabilityExecutionFailureSample[]
+export interface BusinessAccountTermsInput {
+  currency: string
+  credit_limit_minor: number
+  terms_days: number
 }
 
-export interface PluginObservabilityBreakerPlugin {
-  plugin_id?: number
-  plugin_name?: string
-  runtime?: string
-  enabled?: boolean
-  lifecycle_status?: string
-  health_status?: string
-  breaker_state?: string
-  failure_count?: number
-  failure_threshold?: number
-  cooldown_active?: boolean
-  cooldown_until?: string
-  cooldown_reason?: string
-  probe_in_flight?: boolean
-  probe_started_at?: string
-  window_total_executions?: number
-  window_failed_executions?: number
+export async function getMyBusinessAccount() {
+  return apiClient.get('/api/user/business-account')
 }
 
-export interface PluginObservabilityBreakerOverview {
-  window_hours?: number
-  total_plugins?: number
-  enabled_plugins?: number
-  open_count?: number
-  half_open_count?: number
-  closed_count?: number
-  cooldown_active_count?: number
-  probe_in_flight_count?: number
-  rows?: PluginObservabilityBreakerPlugin[]
+export async function applyBusinessAccount(data: {
+  company_name: string
+  tax_id?: string
+  billing_email?: string
+  billing_address?: string
+}) {
+  return apiClient.post('/api/user/business-account', data)
 }
 
-export interface PluginObservabilitySnapshot {
-  generated_at?: string
-  execution?: PluginObservabilityExecutionSnapshot
-  hook_limiter?: PluginObservabilityHookLimiterSnapshot
-  public_access?: Record<string, PluginObservabilityPublicEndpointSnapshot>
-  frontend?: PluginObservabilityFrontendSnapshot
-  breaker_overview?: PluginObservabilityBreakerOverview
-  execution_window?: PluginObservabilityExecutionWindow
+export async function getMyNetTermsInvoices(params?: { page?: number; limit?: number; status?: string }) {
+  return apiClient.get('/api/user/business-account/invoices', { params })
 }
 
-export interface AdminPluginRuntimeInspection {
-  configured_runtime?: string
-  resolved_runtime?: string
-  valid?: boolean
-  enabled?: boolean
-  lifecycle_status?: string
-  health_status?: string
-  connection_state?: string
-  address_present?: boolean
-  package_path_present?: boolean
-  ready?: boolean
-  breaker_state?: string
-  failure_count?: number
-  failure_threshold?: number
-  cooldown_active?: boolean
-  cooldown_until?: string
-  cooldown_reason?: string
-  probe_in_flight?: boolean
-  probe_started_at?: string
-  active_generation?: number
-  active_in_flight?: number
-  draining_slot_count?: number
-  draining_in_flight?: number
-  draining_generations?: number[]
-  last_error?: string
+export async function getMyBusinessAccountStatement(params?: { start?: string; end?: string }) {
+  return apiClient.get('/api/user/business-account/statement', { params })
 }
 
-export interface AdminPluginProtocolCompatibilityInspection {
-  manifest_present?: boolean
-  runtime?: string
-  host_manifest_version?: string
-  manifest_version?: string
-  host_protocol_version?: string
-  protocol_version?: string
-  min_host_protocol_version?: string
-  max_host_protocol_version?: string
-  compatible?: boolean
-  legacy_defaults_applied?: boolean
-  reason_code?: string
-  reason?: string
+export async function payOrderOnNetTerms(orderNo: string) {
+  return apiClient.post(`/api/user/orders/${orderNo}/net-terms`)
 }
 
-export interface AdminPluginRegistrationInspection {
-  state?: 'success' | 'error' | 'never_attempted' | 'unavailable' | string
-  trigger?: string
-  runtime?: string
-  attempted_at?: string
-  completed_at?: string
-  duration_ms?: number
-  detail?: string
+export type CODCollectionStatus = 'outstanding' | 'collected' | 'void'
+
+export interface CODCollection {
+  id: number
+  order_id: number
+  order_no: string
+  user_id: number
+  receiver_country?: string
+  currency: string
+  amount_minor: number
+  status: CODCollectionStatus
+  collected_amount_minor?: number
+  collected_at?: string
+  collected_by?: number
+  reference?: string
+  voided_at?: string
+  void_reason?: string
+  created_at: string
 }
 
-export interface AdminPluginHookParticipationDiagnosis {
-  hook?: string
-  area?: string
-  path?: string
-  slot?: string
-  participates?: boolean
-  supports_hook?: boolean
-  access_allowed?: boolean
-  supports_frontend_area?: boolean
-  supports_frontend_slot?: boolean
-  allow_block?: boolean
-  allow_payload_patch?: boolean
-  allow_frontend_extensions?: boolean
-  valid_capability_policy?: boolean
-  reason_code?: string
-  reason?: string
+export interface CODEligibility {
+  eligible: boolean
+  amount_minor: number
+  currency: string
+  reason_key?: string
+  params?: Record<string, any>
 }
 
-export interface AdminPluginDiagnosticCheck {
-  key: string
-  state: string
-  summary: string
-  detail?: string
+export interface CODBalance {
+  currency: string
+  outstanding_count: number
+  outstanding_minor: number
+  collected_count: number
+  collected_minor: number
+  shortfall_minor: number
+  aging: { label: string; count: number; amount_minor: number }[]
 }
 
-export interface AdminPluginDiagnosticIssue {
-  code: string
-  severity: 'error' | 'warn' | 'info' | string
-  summary: string
-  detail?: string
-  hint?: string
+export async function getCODEligibility(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/cash-on-delivery`)
 }
 
-export interface AdminPluginFrontendRouteScopeDiagnostic {
-  scope: 'guest' | 'authenticated' | 'super_admin' | string
-  eligible: boolean
-  frontend_visible: boolean
-  reason_code?: string
-  reason?: string
-  diagnostic: AdminPluginHookParticipationDiagnosis
+export async function payOrderCOD(orderNo: string) {
+  return apiClient.post(`/api/user/orders/${orderNo}/cash-on-delivery`)
 }
 
-export interface AdminPluginFrontendRouteDiagnostic {
-  area: 'user' | 'admin' | string
-  path: string
-  execute_api_available: boolean
-  scope_checks: AdminPluginFrontendRouteScopeDiagnostic[]
+export type OrganizationRole = 'owner' | 'purchaser' | 'viewer'
+
+export interface OrganizationMember {
+  id: number
+  user_id: number
+  email: string
+  name?: string
+  role: OrganizationRole
+  spending_limit_minor: number
+  monthly_spent_minor: number
+  created_at: string
 }
 
-export interface AdminPluginPublicCacheEntryDiagnostic {
-  key: string
-  created_at?: string
-  expires_at?: string
-  area?: string
-  path?: string
-  slot?: string
-  extension_count?: number
-  menu_count?: number
-  route_count?: number
+export interface OrganizationOverview {
+  organization: {
+    id: number
+    name: string
+    owner_user_id: number
+    created_at: string
+  }
+  currency: string
+  membership: OrganizationMember
+  members: OrganizationMember[]
 }
 
-export interface AdminPluginPublicCacheBucketDiagnostic {
-  total_entries: number
-  matching_entries: number
-  entries: AdminPluginPublicCacheEntryDiagnostic[]
+export interface OrganizationInvitation {
+  id: number
+  organization_id: number
+  organization?: { id: number; name: string }
+  email: string
+  role: OrganizationRole
+  spending_limit_minor: number
+  status: 'pending' | 'accepted' | 'revoked'
+  expires_at: string
+  created_at: string
 }
 
-export interface AdminPluginPublicCacheDiagnostics {
-  ttl_seconds: number
-  max_entries: number
-  extensions: AdminPluginPublicCacheBucketDiagnostic
-  bootstrap: AdminPluginPublicCacheBucketDiagnostic
+export async function getMyOrganization() {
+  return apiClient.get('/api/user/organization')
 }
 
-export interface AdminPluginExecutionTaskSnapshot {
-  id: string
-  plugin_id: number
-  plugin_name?: string
-  runtime?: string
-  action?: string
-  hook?: string
-  stream: boolean
-  status: 'running' | 'completed' | 'failed' | 'canceled' | 'timed_out' | string
-  cancelable: boolean
-  started_at: string
-  updated_at: string
-  completed_at?: string
-  duration_ms?: number
-  chunk_count?: number
-  user_id?: number
-  order_id?: number
-  session_id?: string
-  request_path?: string
-  plugin_page_path?: string
-  error?: string
-  metadata?: Record<string, string>
+export async function createOrganization(name: string) {
+  return apiClient.post('/api/user/organization', { name })
 }
 
-export interface AdminPluginExecutionTaskOverview {
-  active_count: number
-  recent_count: number
-  active: AdminPluginExecutionTaskSnapshot[]
-  recent: AdminPluginExecutionTaskSnapshot[]
+export async function renameOrganization(name: string) {
+  return apiClient.put('/api/user/organization', { name })
 }
 
-export interface AdminPluginExecutionTaskListResponse {
-  plugin_id: number
-  status: string
-  limit: number
-  tasks: AdminPluginExecutionTaskSnapshot[]
-  active_count: number
-  recent_count: number
-  active_tasks: AdminPluginExecutionTaskSnapshot[]
-  recent_tasks: AdminPluginExecutionTaskSnapshot[]
+export async function getOrganizationOrders(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  user_id?: number
+}) {
+  return apiClient.get('/api/user/organization/orders', { params })
 }
 
-export interface AdminPluginWorkspaceEntry {
-  seq: number
-  timestamp?: string
-  channel?: string
-  level?: string
-  message?: string
-  source?: string
-  action?: string
-  hook?: string
-  task_id?: string
-  metadata?: Record<string, string>
+export async function getOrganizationInvitations() {
+  return apiClient.get('/api/user/organization/invitations')
 }
 
-export interface AdminPluginWorkspaceControlEvent {
-  seq: number
-  timestamp?: string
-  type?: string
-  admin_id?: number
-  owner_admin_id?: number
-  previous_owner_id?: number
-  signal?: string
-  result?: string
-  message?: string
+export async function inviteOrganizationMember(data: {
+  email: string
+  role: OrganizationRole
+  spending_limit_minor?: number
+}) {
+  return apiClient.post('/api/user/organization/invitations', data)
 }
 
-export interface AdminPluginWorkspaceSnapshot {
-  owner_admin_id?: number
-  viewer_count?: number
-  control_granted?: boolean
-  status?: string
-  active_task_id?: string
-  active_command?: string
-  prompt?: string
-  completion_reason?: string
-  last_error?: string
-  buffer_capacity: number
-  entry_count: number
-  last_seq: number
-  updated_at?: string
-  has_more?: boolean
-  recent_control_events?: AdminPluginWorkspaceControlEvent[]
-  entries: AdminPluginWorkspaceEntry[]
+export async function revokeOrganizationInvitation(id: number) {
+  return apiClient.delete(`/api/user/organization/invitations/${id}`)
 }
 
-export interface AdminPluginWorkspaceRuntimeState {
-  available: boolean
-  exists: boolean
-  instance_id?: string
-  script_path?: string
-  loaded?: boolean
-  busy?: boolean
-  current_action?: string
-  last_action?: string
-  created_at?: string
-  last_used_at?: string
-  boot_count?: number
-  total_requests?: number
-  execute_count?: number
-  eval_count?: number
-  inspect_count?: number
-  last_error?: string
-  completion_paths?: string[]
+export async function previewOrganizationInvitation(token: string) {
+  return apiClient.get('/api/user/organization/invitations/preview', { params: { token } })
 }
 
-export interface AdminPluginWorkspaceResponse {
-  workspace?: AdminPluginWorkspaceSnapshot
+export async function acceptOrganizationInvitation(token: string) {
+  return apiClient.post('/api/user/organization/invitations/accept', { token })
 }
 
-export interface AdminPluginWorkspaceStreamEvent {
-  type?: 'snapshot' | 'delta' | 'keepalive' | string
-  workspace?: AdminPluginWorkspaceSnapshot
-  entries?: AdminPluginWorkspaceEntry[]
-  cleared?: boolean
-  last_seq?: number
-  entry_count?: number
-  updated_at?: string
+export async function updateOrganizationMember(
+  userId: number,
+  data: { role: OrganizationRole; spending_limit_minor?: number }
+) {
+  return apiClient.put(`/api/user/organization/members/${userId}`, data)
 }
 
-export interface AdminPluginWorkspaceWebSocketClientFrame {
-  type: 'terminal_line' | 'runtime_eval' | 'runtime_inspect' | 'input' | 'signal' | string
-  request_id?: string
-  task_id?: string
-  input?: string
-  line?: string
-  depth?: number
-  signal?: string
+export async function removeOrganizationMember(userId: number) {
+  return apiClient.delete(`/api/user/organization/members/${userId}`)
 }
 
-export interface AdminPluginWorkspaceWebSocketAck {
-  request_id?: string
-  action?: 'terminal_line' | 'runtime_eval' | 'runtime_inspect' | 'signal' | string
-  success: boolean
-  error?: string
-  runtime_state?: AdminPluginWorkspaceRuntimeState
-  workspace?: AdminPluginWorkspaceSnapshot
+export async function leaveOrganization() {
+  return apiClient.post('/api/user/organization/leave')
 }
 
-export interface AdminPluginWorkspaceWebSocketServerFrame {
-  type?:
-    | 'workspace_snapshot'
-    | 'workspace_delta'
-    | 'workspace_request_ack'
-    | 'workspace_error'
-    | string
-  event?: AdminPluginWorkspaceStreamEvent
-  ack?: AdminPluginWorkspaceWebSocketAck
-  message?: string
+// 账户消费控制：放宽限制需等待冷静期，每次变更都会通知账户所有人邮箱
+export interface SpendingControlTerms {
+  monthly_limit_minor: number
+  blocked_categories: string[]
+  owner_email: string
 }
 
-export interface AdminPluginExecutionFailureSample {
+export interface SpendingControl {
   id: number
-  action: string
-  hook?: string
-  error?: string
-  duration: number
-  created_at?: string
+  user_id: number
+  monthly_limit_minor: number
+  blocked_categories: string[] | null
+  owner_email?: string
+  pending?: SpendingControlTerms
+  pending_effective_at?: string
+  updated_at?: string
 }
 
-export interface AdminPluginExecutionFailureGroup {
-  action: string
-  hook?: string
-  failure_count: number
-  last_failure_at?: string
+export interface SpendingControlOverview {
+  control: SpendingControl
+  monthly_spent_minor: number
+  currency: string
+  cooldown_hours: number
 }
 
-export interface AdminPluginExecutionObservability {
-  window_hours: number
-  total_executions: number
-  failed_executions: number
-  hook_failed_executions: number
-  action_failed_executions: number
-  last_failure_at?: string
-  last_success_at?: string
-  failure_groups: AdminPluginExecutionFailureGroup[]
-  recent_failures: AdminPluginExecutionFailureSample[]
+export async function getSpendingControl() {
+  return apiClient.get('/api/user/spending-controls')
 }
 
-export interface AdminPluginStorageActionProfileDiagnostic {
-  action: string
-  mode: 'unknown' | 'none' | 'read' | 'write' | string
+export async function updateSpendingControl(data: SpendingControlTerms) {
+  return apiClient.put('/api/user/spending-controls', data)
 }
 
-export interface AdminPluginStorageObservationDiagnostic {
-  source: string
-  task_id?: string
-  action?: string
-  hook?: string
-  status?: string
-  stream: boolean
-  declared_access_mode?: 'unknown' | 'none' | 'read' | 'write' | string
-  observed_access_mode?: 'unknown' | 'none' | 'read' | 'write' | string
-  updated_at?: string
-  completed_at?: string
+export async function cancelPendingSpendingControl() {
+  return apiClient.delete('/api/user/spending-controls/pending')
 }
 
-export interface AdminPluginStorageDiagnostics {
-  profile_count: number
-  declared_profiles: AdminPluginStorageActionProfileDiagnostic[]
-  last_observed?: AdminPluginStorageObservationDiagnostic
-  has_observed_access: boolean
-}
+export type PolicyType = 'terms' | 'privacy'
 
-export interface AdminPluginDiagnostics {
-  plugin: AdminPlugin
-  runtime: AdminPluginRuntimeInspection
-  compatibility: AdminPluginProtocolCompatibilityInspection
-  registration: AdminPluginRegistrationInspection
-  recent_deployments: AdminPluginDeployment[]
-  execution_tasks: AdminPluginExecutionTaskOverview
-  execution_observability: AdminPluginExecutionObservability
-  storage_diagnostics: AdminPluginStorageDiagnostics
-  public_cache: AdminPluginPublicCacheDiagnostics
-  missing_permissions: string[]
-  checks: AdminPluginDiagnosticCheck[]
-  frontend_routes: AdminPluginFrontendRouteDiagnostic[]
-  requested_hook?: AdminPluginHookParticipationDiagnosis
-  issues: AdminPluginDiagnosticIssue[]
+export interface PolicyVersion {
+  id: number
+  type: PolicyType
+  version: string
+  title: string
+  content: string
+  summary: string
+  published_by?: number
+  created_at: string
 }
 
-export async function getAdminPlugins() {
-  return apiClient.get('/api/admin/plugins')
+export interface PolicyConsent {
+  id: number
+  user_id: number
+  policy_version_id: number
+  policy_type: PolicyType
+  version: string
+  ip_address: string
+  user_agent: string
+  accepted_at: string
 }
 
-export async function getAdminPluginHookCatalog() {
-  return apiClient.get('/api/admin/plugins/hook-catalog')
+// pending 为需要（重新）同意的当前政策版本，未同意前无法下单
+export async function getPolicyConsents() {
+  return apiClient.get('/api/user/consents')
 }
 
-export async function getAdminPlugin(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}`)
+export async function acceptPolicies(policyVersionIds: number[]) {
+  return apiClient.post('/api/user/consents', { policy_version_ids: policyVersionIds })
 }
 
-export async function getAdminPluginDiagnostics(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}/diagnostics`)
+export type PersonalTokenScope = 'orders:read' | 'virtual_products:read'
+
+export interface PersonalAccessToken {
+  id: number
+  name: string
+  token_prefix: string
+  scopes: PersonalTokenScope[]
+  last_used_at?: string
+  last_used_ip?: string
+  expires_at?: string
+  revoked_at?: string
+  created_at: string
 }
 
-export async function getAdminPluginWorkspace(id: number, params?: { limit?: number }) {
-  const query = new URLSearchParams()
-  if (params?.limit) query.append('limit', params.limit.toString())
-  query.append('_ts', String(Date.now()))
-  const suffix = query.toString()
-  return apiClient.get(`/api/admin/plugins/${id}/workspace${suffix ? `?${suffix}` : ''}`)
+export async function getPersonalAccessTokens() {
+  return apiClient.get('/api/user/api-tokens')
 }
 
-export async function getAdminPluginWorkspaceRuntimeState(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}/workspace/runtime?_ts=${Date.now()}`)
+// 返回的 token 明文只出现这一次
+export async function createPersonalAccessToken(data: {
+  name: string
+  scopes: PersonalTokenScope[]
+  expires_in_days?: number
+}) {
+  return apiClient.post('/api/user/api-tokens', data)
 }
 
-export async function clearAdminPluginWorkspace(id: number) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/clear`)
+export async function revokePersonalAccessToken(id: number) {
+  return apiClient.delete(`/api/user/api-tokens/${id}`)
 }
 
-export async function resetAdminPluginWorkspace(id: number) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/reset`)
+export async function getBusinessAccounts(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  search?: string
+}) {
+  return apiClient.get('/api/admin/business-accounts', { params })
 }
 
-export async function resetAdminPluginWorkspaceRuntime(id: number) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/reset`)
+export async function approveBusinessAccount(
+  id: number,
+  data: BusinessAccountTermsInput & { note?: string }
+) {
+  return apiClient.post(`/api/admin/business-accounts/${id}/approve`, data)
 }
 
-export async function claimAdminPluginWorkspaceControl(id: number) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/control/claim`)
+export async function rejectBusinessAccount(id: number, note: string) {
+  return apiClient.post(`/api/admin/business-accounts/${id}/reject`, { note })
 }
 
-export async function submitAdminPluginWorkspaceInput(
+export async function updateBusinessAccount(
   id: number,
-  data: {
-    task_id?: string
-    input: string
-  }
+  data: BusinessAccountTermsInput & { suspended: boolean; credit_hold: boolean }
 ) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/input`, data)
+  return apiClient.put(`/api/admin/business-accounts/${id}`, data)
 }
 
-export async function enterAdminPluginWorkspaceTerminalLine(
+export async function getBusinessAccountStatement(
   id: number,
-  data: {
-    line?: string
-    context?: {
-      user_id?: number
-      order_id?: number
-      session_id?: string
-      metadata?: Record<string, string>
-    }
-  }
+  params?: { start?: string; end?: string }
 ) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/terminal`, data)
+  return apiClient.get(`/api/admin/business-accounts/${id}/statement`, { params })
 }
 
-const pluginWorkspaceRuntimeRequestTimeoutMs = 180000
+export async function getNetTermsInvoices(params?: {
+  page?: number
+  limit?: number
+  business_account_id?: number
+  status?: string
+  overdue?: 1
+}) {
+  return apiClient.get('/api/admin/net-terms-invoices', { params })
+}
 
-export async function evaluateAdminPluginWorkspaceRuntime(
-  id: number,
-  data: {
-    line?: string
-    task_id?: string
-    silent?: boolean
-    context?: {
-      user_id?: number
-      order_id?: number
-      session_id?: string
-      metadata?: Record<string, string>
-    }
-  }
-) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/eval`, data, {
-    timeout: pluginWorkspaceRuntimeRequestTimeoutMs,
-  })
+export async function markNetTermsInvoicePaid(id: number, reference: string) {
+  return apiClient.post(`/api/admin/net-terms-invoices/${id}/mark-paid`, { reference })
 }
 
-export async function inspectAdminPluginWorkspaceRuntime(
-  id: number,
-  data: {
-    line?: string
-    depth?: number
-    task_id?: string
-    silent?: boolean
-    context?: {
-      user_id?: number
-      order_id?: number
-      session_id?: string
-      metadata?: Record<string, string>
-    }
-  }
-) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/inspect`, data, {
-    timeout: pluginWorkspaceRuntimeRequestTimeoutMs,
-  })
+export async function voidNetTermsInvoice(id: number, reason: string) {
+  return apiClient.post(`/api/admin/net-terms-invoices/${id}/void`, { reason })
 }
 
-export async function signalAdminPluginWorkspace(
-  id: number,
-  data: {
-    task_id?: string
-    signal?: 'interrupt' | 'terminate' | string
-  }
-) {
-  return apiClient.post(`/api/admin/plugins/${id}/workspace/signal`, data)
+export async function getCODCollections(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  search?: string
+}) {
+  return apiClient.get('/api/admin/cod-collections', { params })
 }
 
-export async function streamAdminPluginWorkspace(
+export async function getCODReport() {
+  return apiClient.get('/api/admin/cod-collections/report')
+}
+
+export async function confirmCODCollection(
   id: number,
-  options?: {
-    limit?: number
-    signal?: AbortSignal
-    locale?: string
-    onEvent?: (event: AdminPluginWorkspaceStreamEvent) => void | Promise<void>
-  }
+  data: { collected_amount_minor: number; reference?: string }
 ) {
-  const query = new URLSearchParams()
-  if (options?.limit) query.append('limit', String(options.limit))
-  const headers = new Headers({
-    Accept: 'application/x-ndjson',
-  })
-  const token = getToken()
-  if (token) {
-    headers.set('Authorization', `Bearer ${token}`)
-  }
-  const locale = normalizeAppLocale(options?.locale) || resolveClientLocaleHeaderValue()
-  if (locale) {
-    headers.set(APP_LOCALE_HEADER, locale)
-  }
+  return apiClient.post(`/api/admin/cod-collections/${id}/confirm`, data)
+}
 
-  const response = await fetch(
-    resolveFetchAPIURL(
-      `/api/admin/plugins/${id}/workspace/stream${query.toString() ? `?${query}` : ''}`
-    ),
-    {
-      method: 'GET',
-      headers,
-      signal: options?.signal,
-      credentials: 'same-origin',
-    }
+export async function voidCODCollection(id: number, reason: string) {
+  return apiClient.post(`/api/admin/cod-collections/${id}/void`, { reason })
+}
+
+export async function batchUpdateOrders(orderIds: number[], action: string) {
+  return apiClient.post('/api/admin/orders/batch/update', { order_ids: orderIds, action })
+}
+
+export interface BatchOrderPreviewItem {
+  order_id: number
+  order_no?: string
+  status?: string
+  applicable: boolean
+  error?: { key?: string; message: string; params?: Record<string, any> }
+}
+
+export interface BatchOrderPreview {
+  dry_run: true
+  action: string
+  total_count: number
+  affected_count: number
+  skipped_count: number
+  orders: BatchOrderPreviewItem[]
+}
+
+// 批量操作试运行：返回每个订单能否执行，不做任何修改
+export async function previewBatchUpdateOrders(orderIds: number[], action: string) {
+  return apiClient.post(
+    '/api/admin/orders/batch/update',
+    { order_ids: orderIds, action },
+    { params: { dry_run: true } }
   )
+}
 
-  const contentType = response.headers.get('content-type') || ''
-  if (contentType.includes('application/json')) {
-    const payload = await response.json().catch(() => ({}))
-    if (!response.ok) {
-      throw createAPIErrorFromPayload(payload, response.statusText || 'Request failed')
-    }
-    return payload
-  }
-  if (!response.ok) {
-    const payload = await response.text().catch(() => '')
-    throw createAPIErrorFromPayload(
-      { message: payload || response.statusText },
-      response.statusText || 'Request failed'
-    )
-  }
-  if (!response.body) {
-    throw new Error('Workspace stream response body is unavailable')
-  }
+export interface DraftCleanupReport {
+  dry_run: boolean
+  action: 'delete' | 'anonymize'
+  retention_days: number
+  cutoff_time: string
+  orders: Array<{
+    id: number
+    order_no: string
+    status: string
+    user_id?: number
+    updated_at: string
+  }>
+  cleaned_count: number
+}
 
-  const decoder = new TextDecoder()
-  const reader = response.body.getReader()
-  let buffer = ''
+// 立即清理一批过期草稿订单；dryRun 时只返回将被删除或匿名化的订单
+export async function cleanupStaleDraftOrders(dryRun: boolean) {
+  return apiClient.post('/api/admin/orders/batch/cleanup-drafts', undefined, {
+    params: dryRun ? { dry_run: true } : undefined,
+  })
+}
 
-  const parseLine = async (line: string) => {
-    const trimmed = line.trim()
-    if (!trimmed) return
-    let parsed: PluginRouteStreamChunk
-    try {
-      parsed = JSON.parse(trimmed) as PluginRouteStreamChunk
-    } catch {
-      throw new Error('Invalid workspace stream response chunk')
-    }
-    const root = asRecord(parsed?.data)
-    const event = asRecord(root?.event) as AdminPluginWorkspaceStreamEvent | null
-    if (event && options?.onEvent) {
-      await options.onEvent(event)
-    }
-  }
+export async function updateOrderShippingInfo(id: number, data: any) {
+  return apiClient.put(`/api/admin/orders/${id}/shipping-info`, data)
+}
 
-  for (;;) {
-    const { value, done } = await reader.read()
-    if (done) break
-    buffer += decoder.decode(value, { stream: true })
-    const lines = buffer.split(/\r?\n/)
-    buffer = lines.pop() || ''
-    for (const line of lines) {
-      await parseLine(line)
-    }
-  }
-  buffer += decoder.decode()
-  if (buffer.trim() !== '') {
-    await parseLine(buffer)
-  }
+export async function requestOrderResubmit(id: number, reason: string) {
+  return apiClient.post(`/api/admin/orders/${id}/request-resubmit`, { reason })
 }
 
-export function resolveAdminPluginWorkspaceWebSocketURL(
+export async function adminMarkOrderAsPaid(id: number) {
+  return apiClient.post(`/api/admin/orders/${id}/mark-paid`)
+}
+
+export async function adminDeliverVirtualStock(id: number, data?: { mark_only_shipped?: boolean }) {
+  return apiClient.post(`/api/admin/orders/${id}/deliver-virtual`, data || {})
+}
+
+export async function updateOrderPrice(id: number, totalAmountMinor: number) {
+  return apiClient.put(`/api/admin/orders/${id}/price`, { total_amount_minor: totalAmountMinor })
+}
+
+// 生成付款链接，可选通过邮件/短信发送给买家（收件人为空时使用订单上的联系方式）
+export async function createAdminOrderPaymentLink(
   id: number,
-  options?: {
-    limit?: number
-    locale?: string
+  data: {
+    send_email?: boolean
+    send_sms?: boolean
+    email?: string
+    phone?: string
+    phone_code?: string
   }
 ) {
+  return apiClient.post(`/api/admin/orders/${id}/payment-link`, data)
+}
+
+// 用户管理
+export async function getUsers(params?: {
+  page?: number
+  limit?: number
+  role?: string
+  search?: string
+  is_active?: boolean
+  email_verified?: boolean
+  email_notify_marketing?: boolean
+  sms_notify_marketing?: boolean
+  has_phone?: boolean
+  email_flagged?: boolean
+  locale?: string
+  country?: string
+}) {
   const query = new URLSearchParams()
-  if (options?.limit) query.append('limit', String(options.limit))
-  const locale = normalizeAppLocale(options?.locale) || resolveClientLocaleHeaderValue()
-  if (locale) {
-    query.append('locale', locale)
-  }
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.role) query.append('role', params.role)
+  if (params?.search) query.append('search', params.search)
+  if (params?.is_active !== undefined) query.append('is_active', String(params.is_active))
+  if (params?.email_verified !== undefined)
+    query.append('email_verified', String(params.email_verified))
+  if (params?.email_notify_marketing !== undefined)
+    query.append('email_notify_marketing', String(params.email_notify_marketing))
+  if (params?.sms_notify_marketing !== undefined)
+    query.append('sms_notify_marketing', String(params.sms_notify_marketing))
+  if (params?.has_phone !== undefined) query.append('has_phone', String(params.has_phone))
+  if (params?.email_flagged !== undefined)
+    query.append('email_flagged', String(params.email_flagged))
+  if (params?.locale) query.append('locale', params.locale)
+  if (params?.country) query.append('country', params.country)
 
-  const suffix = query.toString()
-  const absoluteURL = resolvePublicAPIURL(
-    `/api/admin/plugins/${id}/workspace/ws${suffix ? `?${suffix}` : ''}`
-  )
-  const base =
-    typeof window !== 'undefined' && window.location?.origin
-      ? window.location.origin
-      : 'http://localhost'
-  const url = new URL(absoluteURL, base)
-  url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:'
-  return url.toString()
+  return apiClient.get(`/api/admin/users?${query}`)
 }
 
-export function resolveAdminPluginWorkspaceWebSocketProtocols(): string[] {
-  const protocols = ['auralogic.workspace.v1']
-  const token = getToken()
-  if (token) {
-    protocols.push(`auralogic.auth.bearer.${token}`)
-  }
-  return protocols
+export async function getUserCountries(params?: { role?: string }) {
+  const query = new URLSearchParams()
+  if (params?.role) query.append('role', params.role)
+  return apiClient.get(`/api/admin/users/countries?${query}`)
 }
 
-export async function createAdminPlugin(data: Partial<AdminPlugin>) {
-  return apiClient.post('/api/admin/plugins', data)
+export async function getUserDetail(id: number) {
+  return apiClient.get(`/api/admin/users/${id}`)
 }
 
-export async function updateAdminPlugin(id: number, data: Partial<AdminPlugin>) {
-  return apiClient.put(`/api/admin/plugins/${id}`, data)
+export async function createUser(data: any) {
+  return apiClient.post('/api/admin/users', data)
 }
 
-export async function getAdminPluginSecrets(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}/secrets`)
+export async function updateUser(id: number, data: any) {
+  return apiClient.put(`/api/admin/users/${id}`, data)
 }
 
-export async function updateAdminPluginSecrets(
-  id: number,
-  data: { upserts?: Record<string, string>; delete_keys?: string[] }
-) {
-  return apiClient.put(`/api/admin/plugins/${id}/secrets`, data)
+export async function deleteUser(id: number) {
+  return apiClient.delete(`/api/admin/users/${id}`)
 }
 
-export async function deleteAdminPlugin(id: number) {
-  return apiClient.delete(`/api/admin/plugins/${id}`)
+export async function getUserSpendingControl(id: number) {
+  return apiClient.get(`/api/admin/users/${id}/spending-controls`)
 }
 
-export async function testAdminPlugin(id: number) {
-  return apiClient.post(`/api/admin/plugins/${id}/test`)
+export async function updateUserSpendingControl(id: number, data: SpendingControlTerms) {
+  return apiClient.put(`/api/admin/users/${id}/spending-controls`, data)
 }
 
-export interface PluginRouteExecuteRequest {
-  action: string
-  params?: Record<string, string>
-  path?: string
-  query_params?: Record<string, string>
-  route_params?: Record<string, string>
+export async function getUserConsents(id: number) {
+  return apiClient.get(`/api/admin/users/${id}/consents`)
 }
 
-export interface PluginFrontendRouteExecuteAPI {
-  url?: string
-  method?: string
-  scope?: 'public' | 'admin' | string
-  requires_auth?: boolean
-  path_param?: string
-  action_param?: string
-  params_format?: string
-  allowed_actions?: string[]
-  stream_url?: string
-  stream_format?: string
-  stream_actions?: string[]
+export async function getUserTwoFactor(id: number) {
+  return apiClient.get(`/api/admin/users/${id}/two-factor`)
 }
 
-export interface PluginRouteStreamChunk {
-  type?: 'chunk' | 'error' | 'task' | string
-  index?: number
-  task_id?: string
-  task_status_url?: string
-  task_cancel_url?: string
-  success?: boolean
-  data?: Record<string, any>
-  error?: string
-  metadata?: Record<string, string>
-  is_final?: boolean
+export async function updateUserTwoFactor(id: number, required: boolean) {
+  return apiClient.put(`/api/admin/users/${id}/two-factor`, { required })
 }
 
-function normalizePluginExecuteActionList(value: unknown): string[] {
-  if (!Array.isArray(value)) {
-    return []
-  }
-  return value
+export async function resetUserTwoFactor(id: number) {
+  return apiClient.post(`/api/admin/users/${id}/two-factor/reset`)
+}
+
+export interface AdminPolicyVersion extends PolicyVersion {
+  is_current: boolean
+  accepted_count: number
+}
+
+export async function getPolicyVersions(type?: PolicyType) {
+  return apiClient.get('/api/admin/policies', { params: type ? { type } : undefined })
+}
+
+export async function publishPolicyVersion(data: {
+  type: PolicyType
+  version: string
+  title: string
+  content: string
+  summary?: string
+}) {
+  return apiClient.post('/api/admin/policies', data)
+}
+
+// 管理员用户管理
+export async function getAdmins(params?: { page?: number; limit?: number }) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+
+  return apiClient.get(`/api/admin/admins?${query}`)
+}
+
+export async function createAdmin(data: any) {
+  return apiClient.post('/api/admin/admins', data)
+}
+
+export async function updateAdmin(id: number, data: any) {
+  return apiClient.put(`/api/admin/admins/${id}`, data)
+}
+
+export async function deleteAdmin(id: number) {
+  return apiClient.delete(`/api/admin/admins/${id}`)
+}
+
+// API密钥管理
+export async function getApiKeys() {
+  return apiClient.get('/api/admin/api-keys')
+}
+
+export async function createApiKey(data: {
+  key_name: string
+  platform: string
+  scopes: string[]
+  rate_limit?: number
+  expires_at?: string
+}) {
+  return apiClient.post('/api/admin/api-keys', data)
+}
+
+export async function deleteApiKey(id: number) {
+  return apiClient.delete(`/api/admin/api-keys/${id}`)
+}
+
+// 插件管理
+export interface AdminPluginEffectiveCapabilityPolicy {
+  hooks: string[]
+  disabled_hooks: string[]
+  requested_permissions: string[]
+  granted_permissions: string[]
+  frontend_min_scope: 'guest' | 'authenticated' | 'super_admin' | string
+  frontend_required_permissions: string[]
+  frontend_allowed_areas: string[]
+  allowed_frontend_slots: string[]
+  allow_hook_execute: boolean
+  allow_block: boolean
+  allow_payload_patch: boolean
+  allow_frontend_extensions: boolean
+  allow_execute_api: boolean
+  allow_network: boolean
+  allow_file_system: boolean
+  valid: boolean
+}
+
+export interface AdminPluginWorkspaceCommand {
+  name: string
+  title?: string
+  description?: string
+  entry?: string
+  interactive: boolean
+  builtin?: boolean
+  permissions?: string[]
+  missing_permissions?: string[]
+  granted: boolean
+}
+
+export interface AdminPlugin {
+  id: number
+  name: string
+  display_name?: string
+  description?: string
+  type: string
+  runtime?: string
+  address: string
+  version?: string
+  config?: string
+  runtime_params?: string
+  capabilities?: string
+  manifest?: string
+  package_path?: string
+  package_path_display?: string
+  package_checksum?: string
+  address_display?: string
+  runtime_spec_hash?: string
+  desired_generation?: number
+  applied_generation?: number
+  enabled: boolean
+  status?: string
+  lifecycle_status?: string
+  last_error?: string
+  last_healthy?: string
+  installed_at?: string
+  started_at?: string
+  stopped_at?: string
+  retired_at?: string
+  fail_count?: number
+  created_at?: string
+  updated_at?: string
+  effective_capability_policy?: AdminPluginEffectiveCapabilityPolicy
+  workspace_commands?: AdminPluginWorkspaceCommand[]
+  latest_deployment?: AdminPluginDeployment
+}
+
+export interface AdminPluginSecretMeta {
+  key: string
+  configured: boolean
+  updated_at?: string
+}
+
+export interface AdminPluginExecution {
+  id: number
+  plugin_id: number
+  user_id?: number
+  order_id?: number
+  action: string
+  params?: string
+  success: boolean
+  result?: string
+  error?: string
+  duration?: number
+  created_at?: string
+}
+
+export interface AdminPluginVersion {
+  id: number
+  plugin_id: number
+  version: string
+  package_name?: string
+  package_path?: string
+  package_path_display?: string
+  package_checksum?: string
+  manifest?: string
+  type?: string
+  runtime?: string
+  address?: string
+  config_snapshot?: string
+  runtime_params?: string
+  capabilities_snapshot?: string
+  changelog?: string
+  lifecycle_status?: string
+  is_active: boolean
+  uploaded_by?: number
+  activated_at?: string
+  created_at?: string
+  updated_at?: string
+}
+
+export interface AdminPluginDeployment {
+  id: number
+  plugin_id: number
+  operation: string
+  trigger?: string
+  status: string
+  target_version_id?: number
+  requested_generation?: number
+  applied_generation?: number
+  runtime_spec_hash?: string
+  auto_start?: boolean
+  requested_by?: number
+  detail?: string
+  error?: string
+  started_at?: string
+  finished_at?: string
+  created_at?: string
+  updated_at?: string
+}
+
+export interface PluginPermissionRequest {
+  key: string
+  required: boolean
+  reason?: string
+  title?: string
+  description?: string
+  default_granted?: boolean
+}
+
+export interface AdminPluginMarketSource {
+  source_id: string
+  name?: string
+  base_url: string
+  public_key?: string
+  default_channel?: string
+  allowed_kinds?: string[]
+  enabled?: boolean
+}
+
+export interface AdminPluginMarketPreviewRequest {
+  source: AdminPluginMarketSource
+  kind: string
+  name: string
+  version: string
+}
+
+export interface AdminPluginMarketInstallRequest extends AdminPluginMarketPreviewRequest {
+  granted_permissions?: string[]
+  activate?: boolean
+  auto_start?: boolean
+  note?: string
+}
+
+export interface AdminPaymentMethodMarketPreviewRequest extends AdminPluginMarketPreviewRequest {
+  payment_method_id?: number
+}
+
+export interface AdminPaymentMethodMarketImportRequest extends AdminPluginMarketPreviewRequest {
+  payment_method_id?: number
+  payment_name?: string
+  payment_description?: string
+  icon?: string
+  entry?: string
+  config?: string
+  poll_interval?: number
+}
+
+export type PluginHookGroupKey =
+  | 'frontend'
+  | 'auth'
+  | 'order'
+  | 'payment'
+  | 'ticket'
+  | 'product_inventory'
+  | 'promo'
+  | 'other'
+
+export interface AdminPluginHookCatalogGroup {
+  key: PluginHookGroupKey | string
+  hooks: string[]
+}
+
+export interface AdminPluginHookCatalog {
+  groups: AdminPluginHookCatalogGroup[]
+  hooks: string[]
+}
+
+export interface PluginObservabilityExecutionCounters {
+  total?: number
+  success?: number
+  failed?: number
+  error_rate?: number
+  timeout?: number
+  timeout_rate?: number
+  avg_duration_ms?: number
+  max_duration_ms?: number
+}
+
+export interface PluginObservabilityPluginExecution extends PluginObservabilityExecutionCounters {
+  plugin_id?: number
+  plugin_name?: string
+  runtime?: string
+}
+
+export interface PluginObservabilityExecutionSnapshot {
+  overall?: PluginObservabilityExecutionCounters
+  by_runtime?: Record<string, PluginObservabilityExecutionCounters>
+  by_action?: Record<string, PluginObservabilityExecutionCounters>
+  by_plugin?: PluginObservabilityPluginExecution[]
+}
+
+export interface PluginObservabilityHookLimiterSnapshot {
+  total_hits?: number
+  by_hook?: Record<string, number>
+}
+
+export interface PluginObservabilityPublicEndpointSnapshot {
+  requests?: number
+  rate_limited?: number
+  rate_limit_hit_rate?: number
+  cache_hits?: number
+  cache_misses?: number
+  cache_hit_rate?: number
+}
+
+export interface PluginObservabilityFrontendResolverSnapshot {
+  cache_hits?: number
+  cache_misses?: number
+  cache_hit_rate?: number
+  singleflight_waits?: number
+  catalog_hits?: number
+  db_fallbacks?: number
+}
+
+export interface PluginObservabilityFrontendSnapshot {
+  slot_requests?: number
+  batch_requests?: number
+  bootstrap_requests?: number
+  batch_items?: number
+  batch_unique_items?: number
+  batch_deduped_items?: number
+  html_mode?: PluginObservabilityFrontendResolverSnapshot
+  execute_api?: PluginObservabilityFrontendResolverSnapshot
+  prepared_hook?: PluginObservabilityFrontendResolverSnapshot
+}
+
+export interface PluginObservabilityExecutionWindowBucket {
+  hour_start?: string
+  total_executions?: number
+  failed_executions?: number
+}
+
+export interface PluginObservabilityExecutionFailureGroup {
+  plugin_id?: number
+  plugin_name?: string
+  action?: string
+  hook?: string
+  failure_count?: number
+  last_failure_at?: string
+}
+
+export interface PluginObservabilityExecutionFailureSample {
+  id?: number
+  plugin_id?: number
+  plugin_name?: string
+  action?: string
+  hook?: string
+  error?: string
+  duration?: number
+  created_at?: string
+}
+
+export interface PluginObservabilityExecutionHookGroup {
+  plugin_id?: number
+  plugin_name?: string
+  hook?: string
+  failure_count?: number
+  last_failure_at?: string
+  last_error?: string
+}
+
+export interface PluginObservabilityExecutionErrorSignature {
+  plugin_id?: number
+  plugin_name?: string
+  signature?: string
+  failure_count?: number
+  last_failure_at?: string
+  sample_error?: string
+}
+
+export interface PluginObservabilityExecutionWindow {
+  window_hours?: number
+  total_executions?: number
+  failed_executions?: number
+  hook_failed_executions?: number
+  action_failed_executions?: number
+  last_failure_at?: string
+  last_success_at?: string
+  by_hour?: PluginObservabilityExecutionWindowBucket[]
+  failure_groups?: PluginObservabilityExecutionFailureGroup[]
+  hook_groups?: PluginObservabilityExecutionHookGroup[]
+  error_signatures?: PluginObservabilityExecutionErrorSignature[]
+  recent_failures?: PluginObservabilityExecutionFailureSample[]
+}
+
+export interface PluginObservabilityBreakerPlugin {
+  plugin_id?: number
+  plugin_name?: string
+  runtime?: string
+  enabled?: boolean
+  lifecycle_status?: string
+  health_status?: string
+  breaker_state?: string
+  failure_count?: number
+  failure_threshold?: number
+  cooldown_active?: boolean
+  cooldown_until?: string
+  cooldown_reason?: string
+  probe_in_flight?: boolean
+  probe_started_at?: string
+  window_total_executions?: number
+  window_failed_executions?: number
+}
+
+export interface PluginObservabilityBreakerOverview {
+  window_hours?: number
+  total_plugins?: number
+  enabled_plugins?: number
+  open_count?: number
+  half_open_count?: number
+  closed_count?: number
+  cooldown_active_count?: number
+  probe_in_flight_count?: number
+  rows?: PluginObservabilityBreakerPlugin[]
+}
+
+export interface PluginObservabilitySnapshot {
+  generated_at?: string
+  execution?: PluginObservabilityExecutionSnapshot
+  hook_limiter?: PluginObservabilityHookLimiterSnapshot
+  public_access?: Record<string, PluginObservabilityPublicEndpointSnapshot>
+  frontend?: PluginObservabilityFrontendSnapshot
+  breaker_overview?: PluginObservabilityBreakerOverview
+  execution_window?: PluginObservabilityExecutionWindow
+}
+
+export interface AdminPluginRuntimeInspection {
+  configured_runtime?: string
+  resolved_runtime?: string
+  valid?: boolean
+  enabled?: boolean
+  lifecycle_status?: string
+  health_status?: string
+  connection_state?: string
+  address_present?: boolean
+  package_path_present?: boolean
+  ready?: boolean
+  breaker_state?: string
+  failure_count?: number
+  failure_threshold?: number
+  cooldown_active?: boolean
+  cooldown_until?: string
+  cooldown_reason?: string
+  probe_in_flight?: boolean
+  probe_started_at?: string
+  active_generation?: number
+  active_in_flight?: number
+  draining_slot_count?: number
+  draining_in_flight?: number
+  draining_generations?: number[]
+  last_error?: string
+}
+
+export interface AdminPluginProtocolCompatibilityInspection {
+  manifest_present?: boolean
+  runtime?: string
+  host_manifest_version?: string
+  manifest_version?: string
+  host_protocol_version?: string
+  protocol_version?: string
+  min_host_protocol_version?: string
+  max_host_protocol_version?: string
+  compatible?: boolean
+  legacy_defaults_applied?: boolean
+  reason_code?: string
+  reason?: string
+}
+
+export interface AdminPluginRegistrationInspection {
+  state?: 'success' | 'error' | 'never_attempted' | 'unavailable' | string
+  trigger?: string
+  runtime?: string
+  attempted_at?: string
+  completed_at?: string
+  duration_ms?: number
+  detail?: string
+}
+
+export interface AdminPluginHookParticipationDiagnosis {
+  hook?: string
+  area?: string
+  path?: string
+  slot?: string
+  participates?: boolean
+  supports_hook?: boolean
+  access_allowed?: boolean
+  supports_frontend_area?: boolean
+  supports_frontend_slot?: boolean
+  allow_block?: boolean
+  allow_payload_patch?: boolean
+  allow_frontend_extensions?: boolean
+  valid_capability_policy?: boolean
+  reason_code?: string
+  reason?: string
+}
+
+export interface AdminPluginDiagnosticCheck {
+  key: string
+  state: string
+  summary: string
+  detail?: string
+}
+
+export interface AdminPluginDiagnosticIssue {
+  code: string
+  severity: 'error' | 'warn' | 'info' | string
+  summary: string
+  detail?: string
+  hint?: string
+}
+
+export interface AdminPluginFrontendRouteScopeDiagnostic {
+  scope: 'guest' | 'authenticated' | 'super_admin' | string
+  eligible: boolean
+  frontend_visible: boolean
+  reason_code?: string
+  reason?: string
+  diagnostic: AdminPluginHookParticipationDiagnosis
+}
+
+export interface AdminPluginFrontendRouteDiagnostic {
+  area: 'user' | 'admin' | string
+  path: string
+  execute_api_available: boolean
+  scope_checks: AdminPluginFrontendRouteScopeDiagnostic[]
+}
+
+export interface AdminPluginPublicCacheEntryDiagnostic {
+  key: string
+  created_at?: string
+  expires_at?: string
+  area?: string
+  path?: string
+  slot?: string
+  extension_count?: number
+  menu_count?: number
+  route_count?: number
+}
+
+export interface AdminPluginPublicCacheBucketDiagnostic {
+  total_entries: number
+  matching_entries: number
+  entries: AdminPluginPublicCacheEntryDiagnostic[]
+}
+
+export interface AdminPluginPublicCacheDiagnostics {
+  ttl_seconds: number
+  max_entries: number
+  extensions: AdminPluginPublicCacheBucketDiagnostic
+  bootstrap: AdminPluginPublicCacheBucketDiagnostic
+}
+
+export interface AdminPluginExecutionTaskSnapshot {
+  id: string
+  plugin_id: number
+  plugin_name?: string
+  runtime?: string
+  action?: string
+  hook?: string
+  stream: boolean
+  status: 'running' | 'completed' | 'failed' | 'canceled' | 'timed_out' | string
+  cancelable: boolean
+  started_at: string
+  updated_at: string
+  completed_at?: string
+  duration_ms?: number
+  chunk_count?: number
+  user_id?: number
+  order_id?: number
+  session_id?: string
+  request_path?: string
+  plugin_page_path?: string
+  error?: string
+  metadata?: Record<string, string>
+}
+
+export interface AdminPluginExecutionTaskOverview {
+  active_count: number
+  recent_count: number
+  active: AdminPluginExecutionTaskSnapshot[]
+  recent: AdminPluginExecutionTaskSnapshot[]
+}
+
+export interface AdminPluginExecutionTaskListResponse {
+  plugin_id: number
+  status: string
+  limit: number
+  tasks: AdminPluginExecutionTaskSnapshot[]
+  active_count: number
+  recent_count: number
+  active_tasks: AdminPluginExecutionTaskSnapshot[]
+  recent_tasks: AdminPluginExecutionTaskSnapshot[]
+}
+
+export interface AdminPluginWorkspaceEntry {
+  seq: number
+  timestamp?: string
+  channel?: string
+  level?: string
+  message?: string
+  source?: string
+  action?: string
+  hook?: string
+  task_id?: string
+  metadata?: Record<string, string>
+}
+
+export interface AdminPluginWorkspaceControlEvent {
+  seq: number
+  timestamp?: string
+  type?: string
+  admin_id?: number
+  owner_admin_id?: number
+  previous_owner_id?: number
+  signal?: string
+  result?: string
+  message?: string
+}
+
+export interface AdminPluginWorkspaceSnapshot {
+  owner_admin_id?: number
+  viewer_count?: number
+  control_granted?: boolean
+  status?: string
+  active_task_id?: string
+  active_command?: string
+  prompt?: string
+  completion_reason?: string
+  last_error?: string
+  buffer_capacity: number
+  entry_count: number
+  last_seq: number
+  updated_at?: string
+  has_more?: boolean
+  recent_control_events?: AdminPluginWorkspaceControlEvent[]
+  entries: AdminPluginWorkspaceEntry[]
+}
+
+export interface AdminPluginWorkspaceRuntimeState {
+  available: boolean
+  exists: boolean
+  instance_id?: string
+  script_path?: string
+  loaded?: boolean
+  busy?: boolean
+  current_action?: string
+  last_action?: string
+  created_at?: string
+  last_used_at?: string
+  boot_count?: number
+  total_requests?: number
+  execute_count?: number
+  eval_count?: number
+  inspect_count?: number
+  last_error?: string
+  completion_paths?: string[]
+}
+
+export interface AdminPluginWorkspaceResponse {
+  workspace?: AdminPluginWorkspaceSnapshot
+}
+
+export interface AdminPluginWorkspaceStreamEvent {
+  type?: 'snapshot' | 'delta' | 'keepalive' | string
+  workspace?: AdminPluginWorkspaceSnapshot
+  entries?: AdminPluginWorkspaceEntry[]
+  cleared?: boolean
+  last_seq?: number
+  entry_count?: number
+  updated_at?: string
+}
+
+export interface AdminPluginWorkspaceWebSocketClientFrame {
+  type: 'terminal_line' | 'runtime_eval' | 'runtime_inspect' | 'input' | 'signal' | string
+  request_id?: string
+  task_id?: string
+  input?: string
+  line?: string
+  depth?: number
+  signal?: string
+}
+
+export interface AdminPluginWorkspaceWebSocketAck {
+  request_id?: string
+  action?: 'terminal_line' | 'runtime_eval' | 'runtime_inspect' | 'signal' | string
+  success: boolean
+  error?: string
+  runtime_state?: AdminPluginWorkspaceRuntimeState
+  workspace?: AdminPluginWorkspaceSnapshot
+}
+
+export interface AdminPluginWorkspaceWebSocketServerFrame {
+  type?:
+    | 'workspace_snapshot'
+    | 'workspace_delta'
+    | 'workspace_request_ack'
+    | 'workspace_error'
+    | string
+  event?: AdminPluginWorkspaceStreamEvent
+  ack?: AdminPluginWorkspaceWebSocketAck
+  message?: string
+}
+
+export interface AdminPluginExecutionFailureSample {
+  id: number
+  action: string
+  hook?: string
+  error?: string
+  duration: number
+  created_at?: string
+}
+
+export interface AdminPluginExecutionFailureGroup {
+  action: string
+  hook?: string
+  failure_count: number
+  last_failure_at?: string
+}
+
+export interface AdminPluginExecutionObservability {
+  window_hours: number
+  total_executions: number
+  failed_executions: number
+  hook_failed_executions: number
+  action_failed_executions: number
+  last_failure_at?: string
+  last_success_at?: string
+  failure_groups: AdminPluginExecutionFailureGroup[]
+  recent_failures: AdminPluginExecutionFailureSample[]
+}
+
+export interface AdminPluginStorageActionProfileDiagnostic {
+  action: string
+  mode: 'unknown' | 'none' | 'read' | 'write' | string
+}
+
+export interface AdminPluginStorageObservationDiagnostic {
+  source: string
+  task_id?: string
+  action?: string
+  hook?: string
+  status?: string
+  stream: boolean
+  declared_access_mode?: 'unknown' | 'none' | 'read' | 'write' | string
+  observed_access_mode?: 'unknown' | 'none' | 'read' | 'write' | string
+  updated_at?: string
+  completed_at?: string
+}
+
+export interface AdminPluginStorageDiagnostics {
+  profile_count: number
+  declared_profiles: AdminPluginStorageActionProfileDiagnostic[]
+  last_observed?: AdminPluginStorageObservationDiagnostic
+  has_observed_access: boolean
+}
+
+export interface AdminPluginDiagnostics {
+  plugin: AdminPlugin
+  runtime: AdminPluginRuntimeInspection
+  compatibility: AdminPluginProtocolCompatibilityInspection
+  registration: AdminPluginRegistrationInspection
+  recent_deployments: AdminPluginDeployment[]
+  execution_tasks: AdminPluginExecutionTaskOverview
+  execution_observability: AdminPluginExecutionObservability
+  storage_diagnostics: AdminPluginStorageDiagnostics
+  public_cache: AdminPluginPublicCacheDiagnostics
+  missing_permissions: string[]
+  checks: AdminPluginDiagnosticCheck[]
+  frontend_routes: AdminPluginFrontendRouteDiagnostic[]
+  requested_hook?: AdminPluginHookParticipationDiagnosis
+  issues: AdminPluginDiagnosticIssue[]
+}
+
+export async function getAdminPlugins() {
+  return apiClient.get('/api/admin/plugins')
+}
+
+export async function getAdminPluginHookCatalog() {
+  return apiClient.get('/api/admin/plugins/hook-catalog')
+}
+
+export async function getAdminPlugin(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}`)
+}
+
+export async function getAdminPluginDiagnostics(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}/diagnostics`)
+}
+
+export async function getAdminPluginWorkspace(id: number, params?: { limit?: number }) {
+  const query = new URLSearchParams()
+  if (params?.limit) query.append('limit', params.limit.toString())
+  query.append('_ts', String(Date.now()))
+  const suffix = query.toString()
+  return apiClient.get(`/api/admin/plugins/${id}/workspace${suffix ? `?${suffix}` : ''}`)
+}
+
+export async function getAdminPluginWorkspaceRuntimeState(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}/workspace/runtime?_ts=${Date.now()}`)
+}
+
+export async function clearAdminPluginWorkspace(id: number) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/clear`)
+}
+
+export async function resetAdminPluginWorkspace(id: number) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/reset`)
+}
+
+export async function resetAdminPluginWorkspaceRuntime(id: number) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/reset`)
+}
+
+export async function claimAdminPluginWorkspaceControl(id: number) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/control/claim`)
+}
+
+export async function submitAdminPluginWorkspaceInput(
+  id: number,
+  data: {
+    task_id?: string
+    input: string
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/input`, data)
+}
+
+export async function enterAdminPluginWorkspaceTerminalLine(
+  id: number,
+  data: {
+    line?: string
+    context?: {
+      user_id?: number
+      order_id?: number
+      session_id?: string
+      metadata?: Record<string, string>
+    }
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/terminal`, data)
+}
+
+const pluginWorkspaceRuntimeRequestTimeoutMs = 180000
+
+export async function evaluateAdminPluginWorkspaceRuntime(
+  id: number,
+  data: {
+    line?: string
+    task_id?: string
+    silent?: boolean
+    context?: {
+      user_id?: number
+      order_id?: number
+      session_id?: string
+      metadata?: Record<string, string>
+    }
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/eval`, data, {
+    timeout: pluginWorkspaceRuntimeRequestTimeoutMs,
+  })
+}
+
+export async function inspectAdminPluginWorkspaceRuntime(
+  id: number,
+  data: {
+    line?: string
+    depth?: number
+    task_id?: string
+    silent?: boolean
+    context?: {
+      user_id?: number
+      order_id?: number
+      session_id?: string
+      metadata?: Record<string, string>
+    }
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/runtime/inspect`, data, {
+    timeout: pluginWorkspaceRuntimeRequestTimeoutMs,
+  })
+}
+
+export async function signalAdminPluginWorkspace(
+  id: number,
+  data: {
+    task_id?: string
+    signal?: 'interrupt' | 'terminate' | string
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/workspace/signal`, data)
+}
+
+export async function streamAdminPluginWorkspace(
+  id: number,
+  options?: {
+    limit?: number
+    signal?: AbortSignal
+    locale?: string
+    onEvent?: (event: AdminPluginWorkspaceStreamEvent) => void | Promise<void>
+  }
+) {
+  const query = new URLSearchParams()
+  if (options?.limit) query.append('limit', String(options.limit))
+  const headers = new Headers({
+    Accept: 'application/x-ndjson',
+  })
+  const token = getToken()
+  if (token) {
+    headers.set('Authorization', `Bearer ${token}`)
+  }
+  const locale = normalizeAppLocale(options?.locale) || resolveClientLocaleHeaderValue()
+  if (locale) {
+    headers.set(APP_LOCALE_HEADER, locale)
+  }
+
+  const response = await fetch(
+    resolveFetchAPIURL(
+      `/api/admin/plugins/${id}/workspace/stream${query.toString() ? `?${query}` : ''}`
+    ),
+    {
+      method: 'GET',
+      headers,
+      signal: options?.signal,
+      credentials: 'same-origin',
+    }
+  )
+
+  const contentType = response.headers.get('content-type') || ''
+  if (contentType.includes('application/json')) {
+    const payload = await response.json().catch(() => ({}))
+    if (!response.ok) {
+      throw createAPIErrorFromPayload(payload, response.statusText || 'Request failed')
+    }
+    return payload
+  }
+  if (!response.ok) {
+    const payload = await response.text().catch(() => '')
+    throw createAPIErrorFromPayload(
+      { message: payload || response.statusText },
+      response.statusText || 'Request failed'
+    )
+  }
+  if (!response.body) {
+    throw new Error('Workspace stream response body is unavailable')
+  }
+
+  const decoder = new TextDecoder()
+  const reader = response.body.getReader()
+  let buffer = ''
+
+  const parseLine = async (line: string) => {
+    const trimmed = line.trim()
+    if (!trimmed) return
+    let parsed: PluginRouteStreamChunk
+    try {
+      parsed = JSON.parse(trimmed) as PluginRouteStreamChunk
+    } catch {
+      throw new Error('Invalid workspace stream response chunk')
+    }
+    const root = asRecord(parsed?.data)
+    const event = asRecord(root?.event) as AdminPluginWorkspaceStreamEvent | null
+    if (event && options?.onEvent) {
+      await options.onEvent(event)
+    }
+  }
+
+  for (;;) {
+    const { value, done } = await reader.read()
+    if (done) break
+    buffer += decoder.decode(value, { stream: true })
+    const lines = buffer.split(/\r?\n/)
+    buffer = lines.pop() || ''
+    for (const line of lines) {
+      await parseLine(line)
+    }
+  }
+  buffer += decoder.decode()
+  if (buffer.trim() !== '') {
+    await parseLine(buffer)
+  }
+}
+
+export function resolveAdminPluginWorkspaceWebSocketURL(
+  id: number,
+  options?: {
+    limit?: number
+    locale?: string
+  }
+) {
+  const query = new URLSearchParams()
+  if (options?.limit) query.append('limit', String(options.limit))
+  const locale = normalizeAppLocale(options?.locale) || resolveClientLocaleHeaderValue()
+  if (locale) {
+    query.append('locale', locale)
+  }
+
+  const suffix = query.toString()
+  const absoluteURL = resolvePublicAPIURL(
+    `/api/admin/plugins/${id}/workspace/ws${suffix ? `?${suffix}` : ''}`
+  )
+  const base =
+    typeof window !== 'undefined' && window.location?.origin
+      ? window.location.origin
+      : 'http://localhost'
+  const url = new URL(absoluteURL, base)
+  url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:'
+  return url.toString()
+}
+
+export function resolveAdminPluginWorkspaceWebSocketProtocols(): string[] {
+  const protocols = ['auralogic.workspace.v1']
+  const token = getToken()
+  if (token) {
+    protocols.push(`auralogic.auth.bearer.${token}`)
+  }
+  return protocols
+}
+
+export async function createAdminPlugin(data: Partial<AdminPlugin>) {
+  return apiClient.post('/api/admin/plugins', data)
+}
+
+export async function updateAdminPlugin(id: number, data: Partial<AdminPlugin>) {
+  return apiClient.put(`/api/admin/plugins/${id}`, data)
+}
+
+export async function getAdminPluginSecrets(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}/secrets`)
+}
+
+export async function updateAdminPluginSecrets(
+  id: number,
+  data: { upserts?: Record<string, string>; delete_keys?: string[] }
+) {
+  return apiClient.put(`/api/admin/plugins/${id}/secrets`, data)
+}
+
+export async function deleteAdminPlugin(id: number) {
+  return apiClient.delete(`/api/admin/plugins/${id}`)
+}
+
+export async function testAdminPlugin(id: number) {
+  return apiClient.post(`/api/admin/plugins/${id}/test`)
+}
+
+export interface PluginRouteExecuteRequest {
+  action: string
+  params?: Record<string, string>
+  path?: string
+  query_params?: Record<string, string>
+  route_params?: Record<string, string>
+}
+
+export interface PluginFrontendRouteExecuteAPI {
+  url?: string
+  method?: string
+  scope?: 'public' | 'admin' | string
+  requires_auth?: boolean
+  path_param?: string
+  action_param?: string
+  params_format?: string
+  allowed_actions?: string[]
+  stream_url?: string
+  stream_format?: string
+  stream_actions?: string[]
+}
+
+export interface PluginRouteStreamChunk {
+  type?: 'chunk' | 'error' | 'task' | string
+  index?: number
+  task_id?: string
+  task_status_url?: string
+  task_cancel_url?: string
+  success?: boolean
+  data?: Record<string, any>
+  error?: string
+  metadata?: Record<string, string>
+  is_final?: boolean
+}
+
+function normalizePluginExecuteActionList(value: unknown): string[] {
+  if (!Array.isArray(value)) {
+    return []
+  }
+  return value
     .map((item) =>
       String(item || '')
         .trim()
         .toLowerCase()
     )
-    .filter((item, index, source) => !!item && source.indexOf(item) === index)
+    .filter((item, index, source) => !!item && source.indexOf(item) === index)
+}
+
+function createAPIErrorFromPayload(payload: unknown, fallbackMessage: string): any {
+  const parsed = parseApiErrorPayload(payload)
+  const message = parsed.message || fallbackMessage || 'Request failed'
+  const apiError: any = new Error(message)
+  apiError.code = parsed.code
+  apiError.data = parsed.data
+  apiError.status = parsed.status
+  apiError.errorKey = parsed.errorKey
+  apiError.error_key = parsed.errorKey
+  apiError.errorParams = parsed.errorParams
+  apiError.error_params = parsed.errorParams
+  return apiError
+}
+
+function normalizePluginRouteScope(scope: unknown): string {
+  return String(scope || '')
+    .trim()
+    .toLowerCase()
+}
+
+export function shouldUseDirectPublicPluginRouteAPI(
+  executeAPI?: Pick<PluginFrontendRouteExecuteAPI, 'scope' | 'requires_auth'>
+): boolean {
+  // Public plugin/config routes can still depend on optional auth and session context.
+  // Keep them on the Next proxy so auth cookies are translated into Authorization.
+  void executeAPI
+  return false
+}
+
+function resolveFetchAPIURL(url: string, options?: { direct?: boolean }): string {
+  void options
+  return resolveClientAPIProxyURL(url)
+}
+
+export function pluginRouteShouldStream(
+  executeAPI: PluginFrontendRouteExecuteAPI,
+  action: string,
+  preferredMode?: string
+): boolean {
+  const normalizedAction = String(action || '')
+    .trim()
+    .toLowerCase()
+  if (!normalizedAction) {
+    return false
+  }
+  const streamURL = typeof executeAPI?.stream_url === 'string' ? executeAPI.stream_url.trim() : ''
+  if (!streamURL) {
+    return false
+  }
+  const streamActions = normalizePluginExecuteActionList(executeAPI?.stream_actions)
+  const explicitMode = String(preferredMode || '')
+    .trim()
+    .toLowerCase()
+  if (explicitMode === 'stream') {
+    return streamActions.length === 0 || streamActions.includes(normalizedAction)
+  }
+  return streamActions.includes(normalizedAction)
+}
+
+export async function executePluginRouteAction(
+  executeAPI: PluginFrontendRouteExecuteAPI,
+  data: PluginRouteExecuteRequest,
+  options?: {
+    locale?: string
+  }
+) {
+  const url = typeof executeAPI?.url === 'string' ? executeAPI.url.trim() : ''
+  if (!url) {
+    throw new Error('Plugin execute API URL is missing')
+  }
+  const method =
+    typeof executeAPI?.method === 'string' && executeAPI.method.trim() !== ''
+      ? executeAPI.method.trim().toLowerCase()
+      : 'post'
+  const action = String(data?.action || '').trim()
+  const allowedActions = normalizePluginExecuteActionList(executeAPI?.allowed_actions)
+  if (allowedActions.length > 0 && !allowedActions.includes(action.toLowerCase())) {
+    throw new Error('Plugin execute action is not declared for this page route')
+  }
+  const client =
+    normalizePluginRouteScope(executeAPI?.scope) === 'public' ? publicApiClient : apiClient
+  return client.request({
+    url,
+    method: method as any,
+    data,
+    headers: buildLocaleHeaders(options?.locale),
+  })
+}
+
+export async function executePluginRouteActionStream(
+  executeAPI: PluginFrontendRouteExecuteAPI,
+  data: PluginRouteExecuteRequest,
+  options?: {
+    signal?: AbortSignal
+    onChunk?: (chunk: PluginRouteStreamChunk) => void | Promise<void>
+    locale?: string
+  }
+) {
+  const url = typeof executeAPI?.stream_url === 'string' ? executeAPI.stream_url.trim() : ''
+  if (!url) {
+    throw new Error('Plugin execute stream API URL is missing')
+  }
+  const action = String(data?.action || '').trim()
+  const allowedActions = normalizePluginExecuteActionList(executeAPI?.allowed_actions)
+  if (allowedActions.length > 0 && !allowedActions.includes(action.toLowerCase())) {
+    throw new Error('Plugin execute action is not declared for this page route')
+  }
+  const streamActions = normalizePluginExecuteActionList(executeAPI?.stream_actions)
+  if (streamActions.length > 0 && !streamActions.includes(action.toLowerCase())) {
+    throw new Error('Plugin stream action is not declared for this page route')
+  }
+  const useDirectPublicAPI = shouldUseDirectPublicPluginRouteAPI(executeAPI)
+
+  const headers = new Headers({
+    Accept:
+      typeof executeAPI?.stream_format === 'string' && executeAPI.stream_format.trim() !== ''
+        ? `application/x-${executeAPI.stream_format.trim().toLowerCase()}`
+        : 'application/x-ndjson',
+    'Content-Type': 'application/json',
+  })
+  const token = getToken()
+  if (token) {
+    headers.set('Authorization', `Bearer ${token}`)
+  }
+  const locale = resolveClientLocaleHeaderValue()
+  const requestedLocale = normalizeAppLocale(options?.locale) || locale
+  if (requestedLocale) {
+    headers.set(APP_LOCALE_HEADER, requestedLocale)
+  }
+
+  const response = await fetch(resolveFetchAPIURL(url, { direct: useDirectPublicAPI }), {
+    method: 'POST',
+    headers,
+    body: JSON.stringify(data || {}),
+    signal: options?.signal,
+    credentials: 'same-origin',
+  })
+
+  const contentType = response.headers.get('content-type') || ''
+  if (contentType.includes('application/json')) {
+    const payload = await response.json().catch(() => ({}))
+    if (!response.ok) {
+      throw createAPIErrorFromPayload(payload, response.statusText || 'Request failed')
+    }
+    return payload
+  }
+  if (!response.ok) {
+    const payload = await response.text().catch(() => '')
+    throw createAPIErrorFromPayload(
+      { message: payload || response.statusText },
+      response.statusText || 'Request failed'
+    )
+  }
+  if (!response.body) {
+    throw new Error('Streaming response body is unavailable')
+  }
+
+  const decoder = new TextDecoder()
+  const reader = response.body.getReader()
+  const chunks: PluginRouteStreamChunk[] = []
+  let buffer = ''
+
+  const emitChunk = async (chunk: PluginRouteStreamChunk) => {
+    chunks.push(chunk)
+    if (options?.onChunk) {
+      await options.onChunk(chunk)
+    }
+  }
+
+  const parseLine = async (line: string) => {
+    const trimmed = line.trim()
+    if (!trimmed) {
+      return
+    }
+    let parsed: PluginRouteStreamChunk
+    try {
+      parsed = JSON.parse(trimmed) as PluginRouteStreamChunk
+    } catch {
+      throw new Error('Invalid plugin stream response chunk')
+    }
+    await emitChunk(parsed)
+  }
+
+  for (;;) {
+    const { value, done } = await reader.read()
+    if (done) {
+      break
+    }
+    buffer += decoder.decode(value, { stream: true })
+    const lines = buffer.split(/\r?\n/)
+    buffer = lines.pop() || ''
+    for (const line of lines) {
+      await parseLine(line)
+    }
+  }
+  buffer += decoder.decode()
+  if (buffer.trim() !== '') {
+    await parseLine(buffer)
+  }
+
+  const finalChunk =
+    [...chunks].reverse().find((chunk) => chunk?.is_final) || chunks[chunks.length - 1]
+  if (!finalChunk) {
+    throw new Error('Plugin stream returned no chunks')
+  }
+
+  return {
+    success: finalChunk.success !== false,
+    task_id: finalChunk.task_id,
+    task_status_url: finalChunk.task_status_url,
+    task_cancel_url: finalChunk.task_cancel_url,
+    data: finalChunk.data,
+    metadata: finalChunk.metadata,
+    error: finalChunk.error,
+  }
+}
+
+export async function getAdminPluginExecutions(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}/executions`)
+}
+
+export async function getAdminPluginExecutionTasks(
+  id: number,
+  params?: {
+    status?: string
+    limit?: number
+  }
+) {
+  return apiClient.get(`/api/admin/plugins/${id}/tasks`, {
+    params,
+  })
+}
+
+export async function getAdminPluginExecutionTask(id: number, taskId: string) {
+  return apiClient.get(`/api/admin/plugins/${id}/tasks/${encodeURIComponent(taskId)}`)
+}
+
+export async function cancelAdminPluginExecutionTask(id: number, taskId: string) {
+  return apiClient.post(`/api/admin/plugins/${id}/tasks/${encodeURIComponent(taskId)}/cancel`)
+}
+
+export async function uploadAdminPluginPackage(data: FormData) {
+  return apiClient.post('/api/admin/plugins/upload', data, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
+  })
+}
+
+export async function previewAdminPluginPackage(data: FormData) {
+  return apiClient.post('/api/admin/plugins/upload/preview', data, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
+  })
+}
+
+export async function previewAdminPluginMarketInstall(data: AdminPluginMarketPreviewRequest) {
+  return apiClient.post('/api/admin/plugins/market/preview', data)
+}
+
+export async function installAdminPluginFromMarket(data: AdminPluginMarketInstallRequest) {
+  return apiClient.post('/api/admin/plugins/market/install', data)
+}
+
+export async function pluginLifecycleAction(
+  id: number,
+  data: {
+    action: string
+    version_id?: number
+    auto_start?: boolean
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/lifecycle`, data)
+}
+
+export async function getAdminPluginVersions(id: number) {
+  return apiClient.get(`/api/admin/plugins/${id}/versions`)
+}
+
+export async function deleteAdminPluginVersion(id: number, versionId: number) {
+  return apiClient.delete(`/api/admin/plugins/${id}/versions/${versionId}`)
+}
+
+export async function activateAdminPluginVersion(
+  id: number,
+  versionId: number,
+  data?: {
+    auto_start?: boolean
+  }
+) {
+  return apiClient.post(`/api/admin/plugins/${id}/versions/${versionId}/activate`, data || {})
+}
+
+export async function getAdminPluginObservability(params?: { plugin_id?: number; hours?: number }) {
+  const query = new URLSearchParams()
+  if (params?.plugin_id) query.append('plugin_id', String(params.plugin_id))
+  if (params?.hours) query.append('hours', String(params.hours))
+  const suffix = query.toString()
+  return apiClient.get(`/api/admin/plugins/observability${suffix ? `?${suffix}` : ''}`)
+}
+
+// 系统日志
+export async function getOperationLogs(params?: {
+  page?: number
+  limit?: number
+  action?: string
+  resource_type?: string
+  resource_id?: string
+  order_no?: string
+  user_id?: string
+  start_date?: string
+  end_date?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.action) query.append('action', params.action)
+  if (params?.resource_type) query.append('resource_type', params.resource_type)
+  if (params?.resource_id) query.append('resource_id', params.resource_id)
+  if (params?.order_no) query.append('order_no', params.order_no)
+  if (params?.user_id) query.append('user_id', params.user_id)
+  if (params?.start_date) query.append('start_date', params.start_date)
+  if (params?.end_date) query.append('end_date', params.end_date)
+
+  return apiClient.get(`/api/admin/logs/operations?${query}`)
+}
+
+// 周期任务运行状态
+export async function getAdminJobs() {
+  return apiClient.get('/api/admin/jobs')
+}
+
+export async function getEmailLogs(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  event_type?: string
+  to_email?: string
+  campaign?: string
+  start_date?: string
+  end_date?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+  if (params?.event_type) query.append('event_type', params.event_type)
+  if (params?.to_email) query.append('to_email', params.to_email)
+  if (params?.campaign) query.append('campaign', params.campaign)
+  if (params?.start_date) query.append('start_date', params.start_date)
+  if (params?.end_date) query.append('end_date', params.end_date)
+
+  return apiClient.get(`/api/admin/logs/emails?${query}`)
+}
+
+export async function getSmsLogs(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  event_type?: string
+  phone?: string
+  start_date?: string
+  end_date?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+  if (params?.event_type) query.append('event_type', params.event_type)
+  if (params?.phone) query.append('phone', params.phone)
+  if (params?.start_date) query.append('start_date', params.start_date)
+  if (params?.end_date) query.append('end_date', params.end_date)
+
+  return apiClient.get(`/api/admin/logs/sms?${query}`)
+}
+
+export async function getLogStatistics() {
+  return apiClient.get('/api/admin/logs/statistics')
+}
+
+export async function retryFailedEmails(emailIds?: number[]) {
+  if (emailIds && emailIds.length > 0) {
+    return apiClient.post('/api/admin/logs/emails/retry', { email_ids: emailIds })
+  }
+  return apiClient.post('/api/admin/logs/emails/retry')
+}
+
+// 仪表盘
+export async function getDashboardStatistics() {
+  return apiClient.get('/api/admin/dashboard/statistics')
+}
+
+export async function getRecentActivities() {
+  return apiClient.get('/api/admin/dashboard/activities')
+}
+
+// ==================== Analytics ====================
+
+export async function getUserAnalytics() {
+  return apiClient.get('/api/admin/analytics/users')
+}
+
+export async function getOrderAnalytics() {
+  return apiClient.get('/api/admin/analytics/orders')
+}
+
+export async function getRevenueAnalytics() {
+  return apiClient.get('/api/admin/analytics/revenue')
+}
+
+export async function getDeviceAnalytics() {
+  return apiClient.get('/api/admin/analytics/devices')
+}
+
+export interface AnalyticsFunnelStage {
+  stage: string
+  sessions: number
+  rate_from_start: number
+  rate_from_previous: number
+}
+
+export interface AnalyticsFunnel {
+  sessions: number
+  page_views: number
+  stages: AnalyticsFunnelStage[]
+  entry_pages: { path: string; sessions: number }[]
+  daily_trend: { date: string; sessions: number; placed: number }[]
+  period_start: string
+  period_end: string
+}
+
+export async function getFunnelAnalytics(params?: { start_date?: string; end_date?: string }) {
+  return apiClient.get('/api/admin/analytics/funnel', { params })
+}
+
+export interface SKUSalesReportItem {
+  period?: string
+  sku: string
+  product_id: number
+  name: string
+  order_count: number
+  units_sold: number
+  revenue_minor: number
+  units_refunded: number
+  refunded_revenue_minor: number
+  refund_rate: number
+  daily_velocity: number
+  ending_stock: number
+  avg_stock: number
+  stock_turn: number
+  sell_through_rate: number
+}
+
+export type SKUSalesGroupBy = 'sku' | 'day' | 'month'
+
+export async function getSKUSalesAnalytics(params?: {
+  start_date?: string
+  end_date?: string
+  sku?: string
+  group_by?: SKUSalesGroupBy
+}) {
+  return apiClient.get('/api/admin/analytics/sku-sales', { params })
+}
+
+// ==================== Virtual Product Stock ====================
+
+// Import virtual product stock
+export async function importVirtualStock(
+  productId: number,
+  data: {
+    import_type: 'file' | 'text'
+    file?: File
+    content?: string
+  }
+) {
+  const formData = new FormData()
+  formData.append('import_type', data.import_type)
+
+  if (data.import_type === 'file' && data.file) {
+    formData.append('file', data.file)
+  } else if (data.import_type === 'text' && data.content) {
+    formData.append('content', data.content)
+  }
+
+  return apiClient.post(`/api/admin/virtual-products/${productId}/import`, formData, {
+    headers: { 'Content-Type': 'multipart/form-data' },
+  })
+}
+
+// Get virtual product stock list
+export async function getVirtualStockList(
+  productId: number,
+  params?: {
+    page?: number
+    limit?: number
+    status?: string
+  }
+) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+
+  return apiClient.get(`/api/admin/virtual-products/${productId}/stocks?${query}`)
+}
+
+// Get virtual product stock stats
+export async function getVirtualStockStats(productId: number) {
+  return apiClient.get(`/api/admin/virtual-products/${productId}/stats`)
+}
+
+// Delete virtual stock
+export async function deleteVirtualStock(stockId: number) {
+  return apiClient.delete(`/api/admin/virtual-products/stocks/${stockId}`)
+}
+
+// Reveal virtual stock content (audited; lists only return masked content)
+export async function revealVirtualStock(stockId: number) {
+  return apiClient.post(`/api/admin/virtual-products/stocks/${stockId}/reveal`)
+}
+
+// Delete stock batch
+export async function deleteStockBatch(batchNo: string) {
+  return apiClient.delete('/api/admin/virtual-products/batch', {
+    data: { batch_no: batchNo },
+  })
+}
+
+// ==================== Virtual Inventory (New API) ====================
+
+// Virtual Inventory interface
+export interface VirtualInventory {
+  id: number
+  name: string
+  sku: string
+  type: 'static' | 'script'
+  script: string
+  script_config: string
+  description: string
+  total_limit: number
+  allow_inline_iframe: boolean
+  require_reauth: boolean
+  max_activations: number
+  usage_unit: string
+  usage_quota: number
+  overage_unit_price_minor: number
+  supplier_paused_at?: string
+  low_stock_threshold: number
+  is_active: boolean
+  notes: string
+  total: number
+  available: number
+  reserved: number
+  sold: number
+  created_at: string
+}
+
+// Get virtual inventories list
+export async function getVirtualInventories(params?: {
+  page?: number
+  limit?: number
+  search?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.search) query.append('search', params.search)
+
+  return apiClient.get(`/api/admin/virtual-inventories?${query}`)
+}
+
+// Create virtual inventory
+export async function createVirtualInventory(data: {
+  name: string
+  sku?: string
+  type?: string
+  script?: string
+  script_config?: string
+  description?: string
+  total_limit?: number
+  allow_inline_iframe?: boolean
+  require_reauth?: boolean
+  max_activations?: number
+  usage_unit?: string
+  usage_quota?: number
+  overage_unit_price_minor?: number
+  low_stock_threshold?: number
+  is_active?: boolean
+  notes?: string
+}) {
+  return apiClient.post('/api/admin/virtual-inventories', data)
+}
+
+// Get virtual inventory detail
+export async function getVirtualInventory(id: number) {
+  return apiClient.get(`/api/admin/virtual-inventories/${id}`)
+}
+
+// Update virtual inventory
+export async function updateVirtualInventory(
+  id: number,
+  data: {
+    name?: string
+    sku?: string
+    type?: string
+    script?: string
+    script_config?: string
+    description?: string
+    total_limit?: number
+    allow_inline_iframe?: boolean
+    require_reauth?: boolean
+    max_activations?: number
+    usage_unit?: string
+    usage_quota?: number
+    overage_unit_price_minor?: number
+    low_stock_threshold?: number
+    is_active?: boolean
+    notes?: string
+    change_note?: string
+    version?: number
+  }
+) {
+  return apiClient.put(`/api/admin/virtual-inventories/${id}`, data)
+}
+
+// Delete virtual inventory
+export async function deleteVirtualInventory(id: number) {
+  return apiClient.delete(`/api/admin/virtual-inventories/${id}`)
+}
+
+export interface VirtualStockImportResult {
+  message: string
+  count: number
+  batch_no?: string
+  total: number
+  added: number
+  duplicates: number
+  invalid: number
+  issues: {
+    line: number
+    reason:
+      | 'duplicate_in_file'
+      | 'duplicate_existing'
+      | 'empty_content'
+      | 'content_too_long'
+      | 'remark_too_long'
+      | 'invalid_characters'
+  }[]
+}
+
+// Import stock to virtual inventory, skipping duplicate and invalid rows
+export async function importVirtualInventoryStock(
+  virtualInventoryId: number,
+  data: {
+    import_type: 'file' | 'text'
+    file?: File
+    content?: string
+  }
+) {
+  const formData = new FormData()
+  formData.append('import_type', data.import_type)
+
+  if (data.import_type === 'file' && data.file) {
+    formData.append('file', data.file)
+  } else if (data.import_type === 'text' && data.content) {
+    formData.append('content', data.content)
+  }
+
+  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/import`, formData, {
+    headers: { 'Content-Type': 'multipart/form-data' },
+  })
+}
+
+// Create stock manually in virtual inventory
+export async function createVirtualInventoryStockManually(
+  virtualInventoryId: number,
+  data: {
+    content: string
+    remark?: string
+  }
+) {
+  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks`, data)
+}
+
+// Get virtual inventory stock list
+export async function getVirtualInventoryStockList(
+  virtualInventoryId: number,
+  params?: {
+    page?: number
+    limit?: number
+    status?: string
+  }
+) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+
+  return apiClient.get(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks?${query}`)
+}
+
+// Alias for getVirtualInventoryStockList
+export const getVirtualInventoryStocks = getVirtualInventoryStockList
+
+// Delete virtual inventory stock item
+export async function deleteVirtualInventoryStock(virtualInventoryId: number, stockId: number) {
+  return apiClient.delete(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}`)
+}
+
+// Reveal virtual inventory stock content (audited; lists only return masked content)
+export async function revealVirtualInventoryStock(virtualInventoryId: number, stockId: number) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}/reveal`
+  )
+}
+
+// Reserve virtual inventory stock item (manual)
+export async function reserveVirtualInventoryStock(
+  virtualInventoryId: number,
+  stockId: number,
+  remark?: string
+) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}/reserve`,
+    { remark }
+  )
+}
+
+// Release virtual inventory stock item (manual)
+export async function releaseVirtualInventoryStock(virtualInventoryId: number, stockId: number) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}/release`
+  )
+}
+
+// Test delivery script
+export async function testDeliveryScript(
+  script: string,
+  config?: Record<string, any>,
+  quantity?: number
+) {
+  return apiClient.post('/api/admin/virtual-inventories/test-script', { script, config, quantity })
+}
+
+export interface ScriptDryRunHTTPMock {
+  method?: string
+  url: string
+  status?: number
+  body?: any
+}
+
+export interface ScriptDryRunRequest {
+  script?: string
+  config?: Record<string, any>
+  quantity?: number
+  order?: {
+    order_no?: string
+    currency?: string
+    total_amount_minor?: number
+    user_name?: string
+    user_email?: string
+  }
+  http_mode?: 'live' | 'mock'
+  http_mocks?: ScriptDryRunHTTPMock[]
+}
+
+export interface ScriptDryRunEvent {
+  type: 'log' | 'http' | 'storage'
+  time: string
+  message?: string
+  method?: string
+  url?: string
+  status?: number
+  mocked?: boolean
+  duration_ms?: number
+  op?: 'set' | 'del' | 'clear'
+  key?: string
+  ttl_seconds?: number
+  error?: string
+}
+
+export interface ScriptDryRunResult {
+  success: boolean
+  items: Array<{ content: string; remark?: string }>
+  message?: string
+  error?: string
+  quantity: number
+  order: Record<string, any>
+  events: ScriptDryRunEvent[] | null
+  events_truncated?: boolean
+  duration_ms: number
+}
+
+export async function dryRunDeliveryScript(id: number, data: ScriptDryRunRequest) {
+  return apiClient.post(`/api/admin/virtual-inventories/${id}/dry-run`, data)
+}
+
+// 流式试运行：脚本日志与 HTTP 调用逐条回调，返回最终结果
+export async function streamDryRunDeliveryScript(
+  id: number,
+  data: ScriptDryRunRequest,
+  options?: {
+    signal?: AbortSignal
+    locale?: string
+    onEvent?: (event: ScriptDryRunEvent) => void
+  }
+): Promise<ScriptDryRunResult> {
+  const headers = new Headers({
+    Accept: 'application/x-ndjson',
+    'Content-Type': 'application/json',
+  })
+  const token = getToken()
+  if (token) {
+    headers.set('Authorization', `Bearer ${token}`)
+  }
+  const locale = normalizeAppLocale(options?.locale) || resolveClientLocaleHeaderValue()
+  if (locale) {
+    headers.set(APP_LOCALE_HEADER, locale)
+  }
+
+  const response = await fetch(
+    resolveFetchAPIURL(`/api/admin/virtual-inventories/${id}/dry-run/stream`),
+    {
+      method: 'POST',
+      headers,
+      body: JSON.stringify(data),
+      signal: options?.signal,
+      credentials: 'same-origin',
+    }
+  )
+
+  const contentType = response.headers.get('content-type') || ''
+  if (contentType.includes('application/json')) {
+    const payload = await response.json().catch(() => ({}))
+    throw createAPIErrorFromPayload(payload, response.statusText || 'Request failed')
+  }
+  if (!response.ok) {
+    const payload = await response.text().catch(() => '')
+    throw createAPIErrorFromPayload(
+      { message: payload || response.statusText },
+      response.statusText || 'Request failed'
+    )
+  }
+  if (!response.body) {
+    throw new Error('Dry-run stream response body is unavailable')
+  }
+
+  const decoder = new TextDecoder()
+  const reader = response.body.getReader()
+  let buffer = ''
+  let result: ScriptDryRunResult | null = null
+  const events: ScriptDryRunEvent[] = []
+
+  const parseLine = (line: string) => {
+    const trimmed = line.trim()
+    if (!trimmed) return
+    let parsed: { type: string; event?: ScriptDryRunEvent; result?: ScriptDryRunResult; error?: string }
+    try {
+      parsed = JSON.parse(trimmed)
+    } catch {
+      throw new Error('Invalid dry-run stream response chunk')
+    }
+    if (parsed.type === 'error') {
+      throw new Error(parsed.error || 'Dry run failed')
+    }
+    if (parsed.type === 'result' && parsed.result) {
+      result = parsed.result
+      return
+    }
+    if (parsed.event) {
+      events.push(parsed.event)
+      options?.onEvent?.(parsed.event)
+    }
+  }
+
+  for (;;) {
+    const { value, done } = await reader.read()
+    if (done) break
+    buffer += decoder.decode(value, { stream: true })
+    const lines = buffer.split(/\r?\n/)
+    buffer = lines.pop() || ''
+    for (const line of lines) {
+      parseLine(line)
+    }
+  }
+  buffer += decoder.decode()
+  if (buffer.trim() !== '') {
+    parseLine(buffer)
+  }
+  if (!result) {
+    throw new Error('Dry-run stream ended without a result')
+  }
+  const finalResult = result as ScriptDryRunResult
+  return { ...finalResult, events }
+}
+
+export interface SupplierEndpointHealth {
+  virtual_inventory_id: number
+  inventory_name: string
+  endpoint: string
+  requests: number
+  failures: number
+  success_rate: number
+  avg_latency_ms: number
+  max_latency_ms: number
+  last_status: number
+  last_error?: string
+  last_seen_at: string
+  supplier_paused_at?: string
+  supplier_pause_reason?: string
 }
 
-function createAPIErrorFromPayload(payload: unknown, fallbackMessage: string): any {
-  const parsed = parseApiErrorPayload(payload)
-  const message = parsed.message || fallbackMessage || 'Request failed'
-  const apiError: any = new Error(message)
-  apiError.code = parsed.code
-  apiError.data = parsed.data
-  apiError.status = parsed.status
-  apiError.errorKey = parsed.errorKey
-  apiError.error_key = parsed.errorKey
-  apiError.errorParams = parsed.errorParams
-  apiError.error_params = parsed.errorParams
-  return apiError
+export type ScriptRevisionStatus = 'pending' | 'approved' | 'rejected' | 'superseded'
+
+export interface ScriptRevision {
+  id: number
+  virtual_inventory_id: number
+  status: ScriptRevisionStatus
+  base_script: string
+  base_script_config: string
+  script: string
+  script_config: string
+  switch_to_script: boolean
+  submitted_by: number
+  submit_note?: string
+  reviewed_by?: number
+  review_note?: string
+  reviewed_at?: string
+  created_at: string
 }
 
-function normalizePluginRouteScope(scope: unknown): string {
-  return String(scope || '')
-    .trim()
-    .toLowerCase()
+export interface ScriptDiffLine {
+  op: ' ' | '-' | '+'
+  text: string
+}
+
+export interface ScriptRevisionDiff {
+  revision: ScriptRevision
+  script: ScriptDiffLine[]
+  script_config: ScriptDiffLine[]
+  stale: boolean
+}
+
+// 发货脚本修订（开启四眼审批时脚本修改需另一名管理员批准）
+export async function getVirtualInventoryScriptRevisions(
+  virtualInventoryId?: number,
+  params?: { status?: ScriptRevisionStatus; page?: number; limit?: number }
+) {
+  const path = virtualInventoryId
+    ? `/api/admin/virtual-inventories/${virtualInventoryId}/script-revisions`
+    : '/api/admin/virtual-inventories/script-revisions'
+  return apiClient.get(path, { params })
+}
+
+export async function getVirtualInventoryScriptRevisionDiff(
+  virtualInventoryId: number,
+  revisionId: number
+) {
+  return apiClient.get(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/script-revisions/${revisionId}/diff`
+  )
+}
+
+export async function approveVirtualInventoryScriptRevision(
+  virtualInventoryId: number,
+  revisionId: number,
+  note?: string
+) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/script-revisions/${revisionId}/approve`,
+    { note }
+  )
+}
+
+export async function rejectVirtualInventoryScriptRevision(
+  virtualInventoryId: number,
+  revisionId: number,
+  note?: string
+) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/script-revisions/${revisionId}/reject`,
+    { note }
+  )
+}
+
+// 脚本发货上游接口健康看板
+export async function getVirtualInventorySupplierHealth(windowMinutes?: number) {
+  return apiClient.get('/api/admin/virtual-inventories/supplier-health', {
+    params: windowMinutes ? { window_minutes: windowMinutes } : undefined,
+  })
+}
+
+// 解除上游异常导致的自动暂停
+export async function resumeVirtualInventorySupplier(virtualInventoryId: number) {
+  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/supplier-resume`)
+}
+
+export interface VirtualStockResaleDelivery {
+  stock_id: number
+  stock_status: string
+  virtual_inventory_id: number
+  inventory_name?: string
+  order_id: number
+  order_no: string
+  order_status?: string
+  paid_at?: string
+  delivered_at?: string
+  refunded: boolean
+  buyer?: { id: number; email: string; name?: string }
+}
+
+export interface VirtualStockResaleCheckResult {
+  report_id: number
+  matched: boolean
+  deliveries: VirtualStockResaleDelivery[]
+  previous_reports: number
+  first_reported_at?: string
+}
+
+export interface VirtualStockResaleReport {
+  id: number
+  content_hash: string
+  matched: boolean
+  match_count: number
+  stock_id?: number
+  order_id?: number
+  order_no?: string
+  user_id?: number
+  refunded: boolean
+  source?: string
+  note?: string
+  reported_by?: number
+  created_at: string
+}
+
+// 核查站外出现的卡密是否由本店售出、售给了谁；每次核查都会登记
+export async function checkVirtualStockResale(data: {
+  code: string
+  source?: string
+  note?: string
+}) {
+  return apiClient.post('/api/admin/virtual-stocks/resale-check', data)
+}
+
+export async function getVirtualStockResaleReports(params?: {
+  matched?: boolean
+  order_id?: number
+  page?: number
+  limit?: number
+}) {
+  return apiClient.get('/api/admin/virtual-stocks/resale-reports', { params })
+}
+
+export interface ScriptExecution {
+  id: number
+  virtual_inventory_id: number
+  order_id: number
+  order_no: string
+  quantity: number
+  script_hash: string
+  success: boolean
+  item_count: number
+  message?: string
+  error?: string
+  duration_ms: number
+  console?: ScriptDryRunEvent[] | null
+  http_calls?: ScriptDryRunEvent[] | null
+  events_truncated?: boolean
+  replay_of?: number
+  replayed_at?: string
+  replayed_by?: number
+  created_at: string
+}
+
+export interface ScriptReplayResult {
+  success: boolean
+  error?: string
+  executions: ScriptExecution[]
+}
+
+// 发货脚本执行审计：真实订单每次执行的日志、HTTP 调用与结果
+export async function getVirtualInventoryScriptExecutions(
+  virtualInventoryId: number,
+  params?: { success?: boolean; page?: number; limit?: number }
+) {
+  return apiClient.get(`/api/admin/virtual-inventories/${virtualInventoryId}/script-executions`, {
+    params,
+  })
+}
+
+export async function getVirtualInventoryScriptExecution(
+  virtualInventoryId: number,
+  executionId: number
+) {
+  return apiClient.get(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/script-executions/${executionId}`
+  )
+}
+
+// 修复脚本后对失败执行所属的订单重新发货
+export async function replayVirtualInventoryScriptExecution(
+  virtualInventoryId: number,
+  executionId: number
+) {
+  return apiClient.post(
+    `/api/admin/virtual-inventories/${virtualInventoryId}/script-executions/${executionId}/replay`
+  )
+}
+
+export interface VirtualInventoryRestockPolicy {
+  id: number
+  virtual_inventory_id: number
+  enabled: boolean
+  threshold: number
+  quantity: number
+  script: string
+  script_config?: string
+  cooldown_minutes: number
+  daily_spend_cap_minor: number
+  monthly_spend_cap_minor: number
+  last_run_at?: string
+  last_status?: 'success' | 'failed' | 'cap_reached'
+  last_error?: string
+  consecutive_failures: number
+  last_alerted_at?: string
+}
+
+export interface VirtualInventoryRestockOverview {
+  policy: VirtualInventoryRestockPolicy | null
+  available: number
+  spent_today_minor: number
+  spent_month_minor: number
+  currency: string
+}
+
+export interface VirtualInventoryRestockRun {
+  id: number
+  virtual_inventory_id: number
+  trigger_type: 'auto' | 'manual'
+  triggered_by?: number
+  status: 'success' | 'failed'
+  available_before: number
+  requested: number
+  received: number
+  added: number
+  duplicates: number
+  invalid: number
+  cost_minor: number
+  currency: string
+  supplier_reference?: string
+  batch_no?: string
+  message?: string
+  error?: string
+  script_hash: string
+  duration_ms: number
+  console?: ScriptDryRunEvent[] | null
+  http_calls?: ScriptDryRunEvent[] | null
+  events_truncated?: boolean
+  created_at: string
+}
+
+// 静态库存自动补货：库存低于阈值时执行供应商脚本采购并导入卡密
+export async function getVirtualInventoryRestock(virtualInventoryId: number) {
+  return apiClient.get(`/api/admin/virtual-inventories/${virtualInventoryId}/restock`)
+}
+
+export async function updateVirtualInventoryRestock(
+  virtualInventoryId: number,
+  data: {
+    enabled: boolean
+    threshold: number
+    quantity: number
+    script: string
+    script_config?: string
+    cooldown_minutes: number
+    daily_spend_cap_minor: number
+    monthly_spend_cap_minor: number
+  }
+) {
+  return apiClient.put(`/api/admin/virtual-inventories/${virtualInventoryId}/restock`, data)
+}
+
+export async function runVirtualInventoryRestock(virtualInventoryId: number) {
+  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/restock/run`)
+}
+
+export async function getVirtualInventoryRestockRuns(
+  virtualInventoryId: number,
+  params?: { status?: string; page?: number; limit?: number }
+) {
+  return apiClient.get(`/api/admin/virtual-inventories/${virtualInventoryId}/restock/runs`, {
+    params,
+  })
+}
+
+// ==================== Product Virtual Inventory Bindings ====================
+
+// Get product virtual inventory bindings
+export async function getProductVirtualInventoryBindings(productId: number) {
+  return apiClient.get(`/api/admin/products/${productId}/virtual-inventory-bindings`)
+}
+
+// Create product virtual inventory binding
+export async function createProductVirtualInventoryBinding(
+  productId: number,
+  data: {
+    virtual_inventory_id: number
+    is_random?: boolean
+    priority?: number
+    notes?: string
+  }
+) {
+  return apiClient.post(`/api/admin/products/${productId}/virtual-inventory-bindings`, data)
+}
+
+// Delete product virtual inventory binding
+export async function deleteProductVirtualInventoryBinding(productId: number, bindingId: number) {
+  return apiClient.delete(
+    `/api/admin/products/${productId}/virtual-inventory-bindings/${bindingId}`
+  )
+}
+
+// Save product virtual inventory variant bindings (batch save)
+export async function saveProductVirtualVariantBindings(
+  productId: number,
+  bindings: Array<{
+    attributes: Record<string, string>
+    virtual_inventory_id: number | null
+    is_random?: boolean
+    priority?: number
+  }>
+) {
+  return apiClient.put(`/api/admin/products/${productId}/virtual-inventory-bindings`, { bindings })
+}
+
+// 系统设置
+export async function getSettings() {
+  return apiClient.get('/api/admin/settings')
+}
+
+export async function updateSettings(data: any) {
+  return apiClient.put('/api/admin/settings', data)
+}
+
+export async function testSMTP(data: any) {
+  return apiClient.post('/api/admin/settings/smtp/test', data)
+}
+
+export async function testSMS(data: { phone: string; phone_code?: string }) {
+  return apiClient.post('/api/admin/settings/sms/test', data)
+}
+
+export type AdminTestMessageChannel = 'email' | 'sms' | 'webhook' | 'push'
+
+export interface AdminTestMessageResult {
+  channel: AdminTestMessageChannel
+  provider: string
+  target: string
+  success: boolean
+  status_code?: number
+  response?: string
+  error?: string
+  duration_ms: number
+}
+
+export async function sendAdminTestMessage(data: {
+  channel: AdminTestMessageChannel
+  to: string
+  phone_code?: string
+  secret?: string
+}) {
+  return apiClient.post('/api/admin/settings/test-message', data)
 }
 
-export function shouldUseDirectPublicPluginRouteAPI(
-  executeAPI?: Pick<PluginFrontendRouteExecuteAPI, 'scope' | 'requires_auth'>
-): boolean {
-  // Public plugin/config routes can still depend on optional auth and session context.
-  // Keep them on the Next proxy so auth cookies are translated into Authorization.
-  void executeAPI
-  return false
+// 邮件模板管理
+export async function getEmailTemplates() {
+  return apiClient.get('/api/admin/settings/email-templates')
 }
 
-function resolveFetchAPIURL(url: string, options?: { direct?: boolean }): string {
-  void options
-  return resolveClientAPIProxyURL(url)
+export async function getEmailTemplate(filename: string) {
+  return apiClient.get(`/api/admin/settings/email-templates/${filename}`)
 }
 
-export function pluginRouteShouldStream(
-  executeAPI: PluginFrontendRouteExecuteAPI,
-  action: string,
-  preferredMode?: string
-): boolean {
-  const normalizedAction = String(action || '')
-    .trim()
-    .toLowerCase()
-  if (!normalizedAction) {
-    return false
-  }
-  const streamURL = typeof executeAPI?.stream_url === 'string' ? executeAPI.stream_url.trim() : ''
-  if (!streamURL) {
-    return false
-  }
-  const streamActions = normalizePluginExecuteActionList(executeAPI?.stream_actions)
-  const explicitMode = String(preferredMode || '')
-    .trim()
-    .toLowerCase()
-  if (explicitMode === 'stream') {
-    return streamActions.length === 0 || streamActions.includes(normalizedAction)
-  }
-  return streamActions.includes(normalizedAction)
+export async function updateEmailTemplate(filename: string, content: string, version?: string) {
+  return apiClient.put(`/api/admin/settings/email-templates/${filename}`, { content, version })
 }
 
-export async function executePluginRouteAction(
-  executeAPI: PluginFrontendRouteExecuteAPI,
-  data: PluginRouteExecuteRequest,
-  options?: {
-    locale?: string
-  }
+export async function importAdminTemplatePackage(
+  file: File,
+  expectedKind?: string,
+  targetKey?: string
 ) {
-  const url = typeof executeAPI?.url === 'string' ? executeAPI.url.trim() : ''
-  if (!url) {
-    throw new Error('Plugin execute API URL is missing')
+  const formData = new FormData()
+  formData.append('file', file)
+  if (expectedKind) {
+    formData.append('expected_kind', expectedKind)
   }
-  const method =
-    typeof executeAPI?.method === 'string' && executeAPI.method.trim() !== ''
-      ? executeAPI.method.trim().toLowerCase()
-      : 'post'
-  const action = String(data?.action || '').trim()
-  const allowedActions = normalizePluginExecuteActionList(executeAPI?.allowed_actions)
-  if (allowedActions.length > 0 && !allowedActions.includes(action.toLowerCase())) {
-    throw new Error('Plugin execute action is not declared for this page route')
+  if (targetKey) {
+    formData.append('target_key', targetKey)
   }
-  const client =
-    normalizePluginRouteScope(executeAPI?.scope) === 'public' ? publicApiClient : apiClient
-  return client.request({
-    url,
-    method: method as any,
-    data,
-    headers: buildLocaleHeaders(options?.locale),
+  return apiClient.post('/api/admin/settings/template-packages/import', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
   })
 }
 
-export async function executePluginRouteActionStream(
-  executeAPI: PluginFrontendRouteExecuteAPI,
-  data: PluginRouteExecuteRequest,
-  options?: {
-    signal?: AbortSignal
-    onChunk?: (chunk: PluginRouteStreamChunk) => void | Promise<void>
-    locale?: string
-  }
-) {
-  const url = typeof executeAPI?.stream_url === 'string' ? executeAPI.stream_url.trim() : ''
-  if (!url) {
-    throw new Error('Plugin execute stream API URL is missing')
-  }
-  const action = String(data?.action || '').trim()
-  const allowedActions = normalizePluginExecuteActionList(executeAPI?.allowed_actions)
-  if (allowedActions.length > 0 && !allowedActions.includes(action.toLowerCase())) {
-    throw new Error('Plugin execute action is not declared for this page route')
-  }
-  const streamActions = normalizePluginExecuteActionList(executeAPI?.stream_actions)
-  if (streamActions.length > 0 && !streamActions.includes(action.toLowerCase())) {
-    throw new Error('Plugin stream action is not declared for this page route')
-  }
-  const useDirectPublicAPI = shouldUseDirectPublicPluginRouteAPI(executeAPI)
+// 落地页管理
+export async function getLandingPage() {
+  return apiClient.get('/api/admin/settings/landing-page')
+}
 
-  const headers = new Headers({
-    Accept:
-      typeof executeAPI?.stream_format === 'string' && executeAPI.stream_format.trim() !== ''
-        ? `application/x-${executeAPI.stream_format.trim().toLowerCase()}`
-        : 'application/x-ndjson',
-    'Content-Type': 'application/json',
-  })
-  const token = getToken()
-  if (token) {
-    headers.set('Authorization', `Bearer ${token}`)
-  }
-  const locale = resolveClientLocaleHeaderValue()
-  const requestedLocale = normalizeAppLocale(options?.locale) || locale
-  if (requestedLocale) {
-    headers.set(APP_LOCALE_HEADER, requestedLocale)
-  }
+export async function updateLandingPage(htmlContent: string) {
+  return apiClient.put('/api/admin/settings/landing-page', { html_content: htmlContent })
+}
 
-  const response = await fetch(resolveFetchAPIURL(url, { direct: useDirectPublicAPI }), {
-    method: 'POST',
-    headers,
-    body: JSON.stringify(data || {}),
-    signal: options?.signal,
-    credentials: 'same-origin',
-  })
+export async function resetLandingPage() {
+  return apiClient.post('/api/admin/settings/landing-page/reset')
+}
 
-  const contentType = response.headers.get('content-type') || ''
-  if (contentType.includes('application/json')) {
-    const payload = await response.json().catch(() => ({}))
-    if (!response.ok) {
-      throw createAPIErrorFromPayload(payload, response.statusText || 'Request failed')
-    }
-    return payload
-  }
-  if (!response.ok) {
-    const payload = await response.text().catch(() => '')
-    throw createAPIErrorFromPayload(
-      { message: payload || response.statusText },
-      response.statusText || 'Request failed'
-    )
-  }
-  if (!response.body) {
-    throw new Error('Streaming response body is unavailable')
-  }
+// 公开配置（无需登录）
+export async function getPublicConfig() {
+  return publicApiClient.get('/api/config/public')
+}
 
-  const decoder = new TextDecoder()
-  const reader = response.body.getReader()
-  const chunks: PluginRouteStreamChunk[] = []
-  let buffer = ''
+// 获取页面注入脚本/样式（无需登录，通过path参数穿透CDN）
+export async function getPageInject(path: string) {
+  return publicApiClient.get(`/api/config/page-inject?path=${encodeURIComponent(path)}`)
+}
 
-  const emitChunk = async (chunk: PluginRouteStreamChunk) => {
-    chunks.push(chunk)
-    if (options?.onChunk) {
-      await options.onChunk(chunk)
-    }
-  }
+export interface PluginFrontendExtension {
+  id?: string
+  slot?: string
+  type?: string
+  title?: LocalizedTextValue
+  content?: LocalizedTextValue
+  link?: string
+  priority?: number
+  data?: Record<string, any>
+  metadata?: Record<string, string>
+  plugin_id?: number
+  plugin_name?: string
+}
 
-  const parseLine = async (line: string) => {
-    const trimmed = line.trim()
-    if (!trimmed) {
-      return
-    }
-    let parsed: PluginRouteStreamChunk
-    try {
-      parsed = JSON.parse(trimmed) as PluginRouteStreamChunk
-    } catch {
-      throw new Error('Invalid plugin stream response chunk')
-    }
-    await emitChunk(parsed)
-  }
+export interface PluginFrontendExtensionBatchRequestItem {
+  key?: string
+  slot: string
+  path?: string
+  query_params?: Record<string, string>
+  host_context?: Record<string, any>
+}
 
-  for (;;) {
-    const { value, done } = await reader.read()
-    if (done) {
-      break
-    }
-    buffer += decoder.decode(value, { stream: true })
-    const lines = buffer.split(/\r?\n/)
-    buffer = lines.pop() || ''
-    for (const line of lines) {
-      await parseLine(line)
-    }
-  }
-  buffer += decoder.decode()
-  if (buffer.trim() !== '') {
-    await parseLine(buffer)
-  }
+export interface PluginFrontendExtensionBatchResponseItem {
+  key?: string
+  slot?: string
+  path?: string
+  extensions?: PluginFrontendExtension[]
+}
 
-  const finalChunk =
-    [...chunks].reverse().find((chunk) => chunk?.is_final) || chunks[chunks.length - 1]
-  if (!finalChunk) {
-    throw new Error('Plugin stream returned no chunks')
-  }
+export interface PluginFrontendBootstrapMenuItem {
+  id?: string
+  area?: 'user' | 'admin' | string
+  title?: LocalizedTextValue
+  path?: string
+  icon?: string
+  priority?: number
+  required_permissions?: string[]
+  super_admin_only?: boolean
+  guest_visible?: boolean
+  mobile_visible?: boolean
+  plugin_id?: number
+  plugin_name?: string
+}
+
+export interface PluginFrontendBootstrapRoute {
+  id?: string
+  area?: 'user' | 'admin' | string
+  title?: LocalizedTextValue
+  path?: string
+  route_params?: Record<string, string>
+  priority?: number
+  required_permissions?: string[]
+  super_admin_only?: boolean
+  guest_visible?: boolean
+  html_mode?: 'sanitize' | 'trusted' | string
+  page?: Record<string, any>
+  execute_api?: PluginFrontendRouteExecuteAPI
+  plugin_id?: number
+  plugin_name?: string
+}
 
-  return {
-    success: finalChunk.success !== false,
-    task_id: finalChunk.task_id,
-    task_status_url: finalChunk.task_status_url,
-    task_cancel_url: finalChunk.task_cancel_url,
-    data: finalChunk.data,
-    metadata: finalChunk.metadata,
-    error: finalChunk.error,
+function appendPluginQueryParams(query: URLSearchParams, queryParams?: Record<string, string>) {
+  if (!queryParams || Object.keys(queryParams).length === 0) {
+    return
   }
+  query.append('query_params', JSON.stringify(queryParams))
 }
 
-export async function getAdminPluginExecutions(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}/executions`)
+function appendPluginHostContext(query: URLSearchParams, hostContext?: Record<string, any>) {
+  const serialized = stringifyPluginHostContext(hostContext)
+  if (serialized === '{}') {
+    return
+  }
+  query.append('host_context', serialized)
 }
 
-export async function getAdminPluginExecutionTasks(
-  id: number,
-  params?: {
-    status?: string
-    limit?: number
-  }
+// 获取指定页面插槽的插件扩展（无需登录）
+export async function getPluginExtensions(
+  path: string,
+  slot: string,
+  queryParams?: Record<string, string>,
+  hostContext?: Record<string, any>,
+  signal?: AbortSignal,
+  locale?: string
 ) {
-  return apiClient.get(`/api/admin/plugins/${id}/tasks`, {
-    params,
+  const query = new URLSearchParams()
+  query.append('path', path || '/')
+  query.append('slot', slot || 'default')
+  appendPluginQueryParams(query, queryParams)
+  appendPluginHostContext(query, hostContext)
+  return publicApiClient.get(`/api/config/plugin-extensions?${query.toString()}`, {
+    signal,
+    headers: buildLocaleHeaders(locale),
   })
 }
 
-export async function getAdminPluginExecutionTask(id: number, taskId: string) {
-  return apiClient.get(`/api/admin/plugins/${id}/tasks/${encodeURIComponent(taskId)}`)
-}
-
-export async function cancelAdminPluginExecutionTask(id: number, taskId: string) {
-  return apiClient.post(`/api/admin/plugins/${id}/tasks/${encodeURIComponent(taskId)}/cancel`)
-}
-
-export async function uploadAdminPluginPackage(data: FormData) {
-  return apiClient.post('/api/admin/plugins/upload', data, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
+// 获取管理端页面插槽的插件扩展（需管理员登录）
+export async function getAdminPluginExtensions(
+  path: string,
+  slot: string,
+  queryParams?: Record<string, string>,
+  hostContext?: Record<string, any>,
+  signal?: AbortSignal,
+  locale?: string
+) {
+  const query = new URLSearchParams()
+  query.append('path', path || '/admin')
+  query.append('slot', slot || 'default')
+  appendPluginQueryParams(query, queryParams)
+  appendPluginHostContext(query, hostContext)
+  return apiClient.get(`/api/admin/plugin-extensions?${query.toString()}`, {
+    signal,
+    headers: buildLocaleHeaders(locale),
   })
 }
 
-export async function previewAdminPluginPackage(data: FormData) {
-  return apiClient.post('/api/admin/plugins/upload/preview', data, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
+export async function getPluginExtensionsBatch(
+  path: string,
+  items: PluginFrontendExtensionBatchRequestItem[],
+  signal?: AbortSignal,
+  locale?: string
+) {
+  return publicApiClient.post(
+    '/api/config/plugin-extensions/batch',
+    {
+      path: path || '/',
+      items,
     },
-  })
-}
-
-export async function previewAdminPluginMarketInstall(data: AdminPluginMarketPreviewRequest) {
-  return apiClient.post('/api/admin/plugins/market/preview', data)
+    {
+      signal,
+      headers: buildLocaleHeaders(locale),
+    }
+  )
 }
 
-export async function installAdminPluginFromMarket(data: AdminPluginMarketInstallRequest) {
-  return apiClient.post('/api/admin/plugins/market/install', data)
+export async function getAdminPluginExtensionsBatch(
+  path: string,
+  items: PluginFrontendExtensionBatchRequestItem[],
+  signal?: AbortSignal,
+  locale?: string
+) {
+  return apiClient.post(
+    '/api/admin/plugin-extensions/batch',
+    {
+      path: path || '/admin',
+      items,
+    },
+    {
+      signal,
+      headers: buildLocaleHeaders(locale),
+    }
+  )
 }
 
-export async function pluginLifecycleAction(
-  id: number,
-  data: {
-    action: string
-    version_id?: number
-    auto_start?: boolean
-  }
+// 获取用户端前端插件 bootstrap（无需登录）
+export async function getPluginFrontendBootstrap(
+  path: string,
+  queryParams?: Record<string, string>,
+  signal?: AbortSignal,
+  locale?: string
 ) {
-  return apiClient.post(`/api/admin/plugins/${id}/lifecycle`, data)
+  const query = new URLSearchParams()
+  query.append('path', path || '/')
+  appendPluginQueryParams(query, queryParams)
+  return publicApiClient.get(`/api/config/plugin-bootstrap?${query.toString()}`, {
+    signal,
+    headers: buildLocaleHeaders(locale),
+  })
 }
 
-export async function getAdminPluginVersions(id: number) {
-  return apiClient.get(`/api/admin/plugins/${id}/versions`)
+// 获取管理端前端插件 bootstrap（需管理员登录）
+export async function getAdminPluginFrontendBootstrap(
+  path: string,
+  queryParams?: Record<string, string>,
+  signal?: AbortSignal,
+  locale?: string
+) {
+  const query = new URLSearchParams()
+  query.append('path', path || '/admin')
+  appendPluginQueryParams(query, queryParams)
+  return apiClient.get(`/api/admin/plugin-bootstrap?${query.toString()}`, {
+    signal,
+    headers: buildLocaleHeaders(locale),
+  })
 }
 
-export async function deleteAdminPluginVersion(id: number, versionId: number) {
-  return apiClient.delete(`/api/admin/plugins/${id}/versions/${versionId}`)
-}
+// ==========================================
+// 付款方式API
+// ==========================================
 
-export async function activateAdminPluginVersion(
-  id: number,
-  versionId: number,
-  data?: {
-    auto_start?: boolean
-  }
-) {
-  return apiClient.post(`/api/admin/plugins/${id}/versions/${versionId}/activate`, data || {})
+export interface PaymentMethod {
+  id: number
+  name: string
+  description: string
+  type: 'builtin' | 'custom'
+  enabled: boolean
+  script?: string
+  config?: string
+  icon?: string
+  version?: string
+  package_name?: string
+  package_entry?: string
+  package_checksum?: string
+  manifest?: string
+  sort_order: number
+  poll_interval: number
+  fee_rate?: number
+  fee_fixed_minor?: number
+  fee_retained_on_refund?: boolean
+  created_at: string
+  updated_at: string
 }
 
-export async function getAdminPluginObservability(params?: { plugin_id?: number; hours?: number }) {
-  const query = new URLSearchParams()
-  if (params?.plugin_id) query.append('plugin_id', String(params.plugin_id))
-  if (params?.hours) query.append('hours', String(params.hours))
-  const suffix = query.toString()
-  return apiClient.get(`/api/admin/plugins/observability${suffix ? `?${suffix}` : ''}`)
-}
+export type LocalizedTextValue = string | Record<string, unknown>
 
-// 系统日志
-export async function getOperationLogs(params?: {
-  page?: number
-  limit?: number
+export interface PaymentMethodPackageWebhookManifest {
+  key: string
+  description?: LocalizedTextValue
   action?: string
-  resource_type?: string
-  resource_id?: string
-  order_no?: string
-  user_id?: string
-  start_date?: string
-  end_date?: string
-}) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.action) query.append('action', params.action)
-  if (params?.resource_type) query.append('resource_type', params.resource_type)
-  if (params?.resource_id) query.append('resource_id', params.resource_id)
-  if (params?.order_no) query.append('order_no', params.order_no)
-  if (params?.user_id) query.append('user_id', params.user_id)
-  if (params?.start_date) query.append('start_date', params.start_date)
-  if (params?.end_date) query.append('end_date', params.end_date)
-
-  return apiClient.get(`/api/admin/logs/operations?${query}`)
+  method?: string
+  auth_mode?: string
+  secret_key?: string
+  header?: string
+  query_param?: string
+  signature_header?: string
 }
 
-export async function getEmailLogs(params?: {
-  page?: number
-  limit?: number
-  status?: string
-  event_type?: string
-  to_email?: string
-  start_date?: string
-  end_date?: string
-}) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-  if (params?.event_type) query.append('event_type', params.event_type)
-  if (params?.to_email) query.append('to_email', params.to_email)
-  if (params?.start_date) query.append('start_date', params.start_date)
-  if (params?.end_date) query.append('end_date', params.end_date)
-
-  return apiClient.get(`/api/admin/logs/emails?${query}`)
+export interface PaymentMethodPackageManifest {
+  name?: string
+  display_name?: LocalizedTextValue
+  description?: LocalizedTextValue
+  icon?: string
+  address?: string
+  entry?: string
+  version?: string
+  poll_interval?: number
+  config?: Record<string, any>
+  config_schema?: Record<string, any>
+  webhooks?: PaymentMethodPackageWebhookManifest[]
 }
 
-export async function getSmsLogs(params?: {
-  page?: number
-  limit?: number
-  status?: string
-  event_type?: string
-  phone?: string
-  start_date?: string
-  end_date?: string
-}) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-  if (params?.event_type) query.append('event_type', params.event_type)
-  if (params?.phone) query.append('phone', params.phone)
-  if (params?.start_date) query.append('start_date', params.start_date)
-  if (params?.end_date) query.append('end_date', params.end_date)
-
-  return apiClient.get(`/api/admin/logs/sms?${query}`)
+export interface PaymentMethodPackageResolvedPreview {
+  name: string
+  description: string
+  version: string
+  entry: string
+  icon: string
+  config: string
+  poll_interval: number
+  script_bytes: number
+  checksum: string
 }
 
-export async function getLogStatistics() {
-  return apiClient.get('/api/admin/logs/statistics')
+export interface PaymentMethodPackagePreview {
+  manifest?: PaymentMethodPackageManifest
+  resolved: PaymentMethodPackageResolvedPreview
 }
 
-export async function retryFailedEmails(emailIds?: number[]) {
-  if (emailIds && emailIds.length > 0) {
-    return apiClient.post('/api/admin/logs/emails/retry', { email_ids: emailIds })
+export interface PaymentMethodMarketPreview extends PaymentMethodPackagePreview {
+  source: AdminPluginMarketSource
+  coordinates: {
+    source_id: string
+    kind: string
+    name: string
+    version: string
   }
-  return apiClient.post('/api/admin/logs/emails/retry')
+  release?: Record<string, any>
+  compatibility?: Record<string, any>
+  target_state?: Record<string, any>
+  warnings?: string[]
 }
 
-// 仪表盘
-export async function getDashboardStatistics() {
-  return apiClient.get('/api/admin/dashboard/statistics')
+export interface PaymentMethodMarketCatalogItem {
+  kind?: string
+  name: string
+  title?: LocalizedTextValue
+  description?: LocalizedTextValue
+  summary?: LocalizedTextValue
+  latest_version?: string
+  channels?: string[]
 }
 
-export async function getRecentActivities() {
-  return apiClient.get('/api/admin/dashboard/activities')
+export interface PaymentMethodMarketArtifactVersion {
+  version: string
+  channel?: string
+  published_at?: string
 }
 
-// ==================== Analytics ====================
-
-export async function getUserAnalytics() {
-  return apiClient.get('/api/admin/analytics/users')
+export interface PaymentMethodMarketArtifact {
+  source: AdminPluginMarketSource
+  kind?: string
+  name: string
+  title?: LocalizedTextValue
+  description?: LocalizedTextValue
+  summary?: LocalizedTextValue
+  latest_version?: string
+  channels?: string[]
+  versions?: PaymentMethodMarketArtifactVersion[]
+  governance?: Record<string, any>
 }
 
-export async function getOrderAnalytics() {
-  return apiClient.get('/api/admin/analytics/orders')
+export interface PaymentCardResult {
+  html: string
+  title?: LocalizedTextValue
+  description?: LocalizedTextValue
+  data?: Record<string, any>
 }
 
-export async function getRevenueAnalytics() {
-  return apiClient.get('/api/admin/analytics/revenue')
+// 管理端API
+export async function getPaymentMethods(enabledOnly?: boolean) {
+  return apiClient.get('/api/admin/payment-methods', { params: { enabled_only: enabledOnly } })
 }
 
-export async function getDeviceAnalytics() {
-  return apiClient.get('/api/admin/analytics/devices')
+export async function getPaymentMethod(id: number) {
+  return apiClient.get(`/api/admin/payment-methods/${id}`)
 }
 
-// ==================== Virtual Product Stock ====================
-
-// Import virtual product stock
-export async function importVirtualStock(
-  productId: number,
-  data: {
-    import_type: 'file' | 'text'
-    file?: File
-    content?: string
-  }
-) {
-  const formData = new FormData()
-  formData.append('import_type', data.import_type)
+export async function createPaymentMethod(data: Partial<PaymentMethod>) {
+  return apiClient.post('/api/admin/payment-methods', data)
+}
 
-  if (data.import_type === 'file' && data.file) {
-    formData.append('file', data.file)
-  } else if (data.import_type === 'text' && data.content) {
-    formData.append('content', data.content)
-  }
+export async function updatePaymentMethod(id: number, data: Partial<PaymentMethod>) {
+  return apiClient.put(`/api/admin/payment-methods/${id}`, data)
+}
 
-  return apiClient.post(`/api/admin/virtual-products/${productId}/import`, formData, {
-    headers: { 'Content-Type': 'multipart/form-data' },
+export async function previewPaymentMethodPackage(formData: FormData) {
+  return apiClient.post('/api/admin/payment-methods/preview-package', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
   })
 }
 
-// Get virtual product stock list
-export async function getVirtualStockList(
-  productId: number,
-  params?: {
-    page?: number
-    limit?: number
-    status?: string
-  }
+export async function previewPaymentMethodMarketPackage(
+  data: AdminPaymentMethodMarketPreviewRequest
 ) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-
-  return apiClient.get(`/api/admin/virtual-products/${productId}/stocks?${query}`)
+  return apiClient.post('/api/admin/payment-methods/market/preview', data)
 }
 
-// Get virtual product stock stats
-export async function getVirtualStockStats(productId: number) {
-  return apiClient.get(`/api/admin/virtual-products/${productId}/stats`)
+export async function getPaymentMethodMarketSources() {
+  return apiClient.get('/api/admin/payment-methods/market/sources')
 }
 
-// Delete virtual stock
-export async function deleteVirtualStock(stockId: number) {
-  return apiClient.delete(`/api/admin/virtual-products/stocks/${stockId}`)
+export async function getPaymentMethodMarketCatalog(params?: {
+  source_id?: string
+  channel?: string
+  q?: string
+  limit?: number
+  offset?: number
+}) {
+  return apiClient.get('/api/admin/payment-methods/market/catalog', { params })
 }
 
-// Delete stock batch
-export async function deleteStockBatch(batchNo: string) {
-  return apiClient.delete('/api/admin/virtual-products/batch', {
-    data: { batch_no: batchNo },
+export async function getPaymentMethodMarketArtifact(
+  name: string,
+  params?: {
+    source_id?: string
+  }
+) {
+  return apiClient.get(`/api/admin/payment-methods/market/artifacts/${encodeURIComponent(name)}`, {
+    params,
   })
 }
 
-// ==================== Virtual Inventory (New API) ====================
-
-// Virtual Inventory interface
-export interface VirtualInventory {
-  id: number
-  name: string
-  sku: string
-  type: 'static' | 'script'
-  script: string
-  script_config: string
-  description: string
-  total_limit: number
-  allow_inline_iframe: boolean
-  is_active: boolean
-  notes: string
-  total: number
-  available: number
-  reserved: number
-  sold: number
-  created_at: string
+export async function uploadPaymentMethodPackage(formData: FormData) {
+  return apiClient.post('/api/admin/payment-methods/upload-package', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
+  })
 }
 
-// Get virtual inventories list
-export async function getVirtualInventories(params?: {
-  page?: number
-  limit?: number
-  search?: string
-}) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.search) query.append('search', params.search)
-
-  return apiClient.get(`/api/admin/virtual-inventories?${query}`)
+export async function importPaymentMethodPackageFromMarket(
+  data: AdminPaymentMethodMarketImportRequest
+) {
+  return apiClient.post('/api/admin/payment-methods/market/import', data)
 }
 
-// Create virtual inventory
-export async function createVirtualInventory(data: {
-  name: string
-  sku?: string
-  type?: string
-  script?: string
-  script_config?: string
-  description?: string
-  total_limit?: number
-  allow_inline_iframe?: boolean
-  is_active?: boolean
-  notes?: string
-}) {
-  return apiClient.post('/api/admin/virtual-inventories', data)
+export async function deletePaymentMethod(id: number) {
+  return apiClient.delete(`/api/admin/payment-methods/${id}`)
 }
 
-// Get virtual inventory detail
-export async function getVirtualInventory(id: number) {
-  return apiClient.get(`/api/admin/virtual-inventories/${id}`)
+export async function togglePaymentMethodEnabled(id: number) {
+  return apiClient.post(`/api/admin/payment-methods/${id}/toggle`)
 }
 
-// Update virtual inventory
-export async function updateVirtualInventory(
-  id: number,
-  data: {
-    name?: string
-    sku?: string
-    type?: string
-    script?: string
-    script_config?: string
-    description?: string
-    total_limit?: number
-    allow_inline_iframe?: boolean
-    is_active?: boolean
-    notes?: string
-  }
+// 付款方式手续费规则：fee_rate 为基点（300 = +3%），负数表示优惠
+export async function updatePaymentMethodFee(
+  id: number,
+  data: { fee_rate: number; fee_fixed_minor: number; fee_retained_on_refund: boolean }
 ) {
-  return apiClient.put(`/api/admin/virtual-inventories/${id}`, data)
+  return apiClient.put(`/api/admin/payment-methods/${id}/fee`, data)
 }
 
-// Delete virtual inventory
-export async function deleteVirtualInventory(id: number) {
-  return apiClient.delete(`/api/admin/virtual-inventories/${id}`)
+export async function reorderPaymentMethods(ids: number[]) {
+  return apiClient.post('/api/admin/payment-methods/reorder', { ids })
 }
 
-// Import stock to virtual inventory
-export async function importVirtualInventoryStock(
-  virtualInventoryId: number,
-  data: {
-    import_type: 'file' | 'text'
-    file?: File
-    content?: string
-  }
-) {
-  const formData = new FormData()
-  formData.append('import_type', data.import_type)
+export async function testPaymentScript(script: string, config?: Record<string, any>) {
+  return apiClient.post('/api/admin/payment-methods/test-script', { script, config })
+}
 
-  if (data.import_type === 'file' && data.file) {
-    formData.append('file', data.file)
-  } else if (data.import_type === 'text' && data.content) {
-    formData.append('content', data.content)
-  }
+export async function initBuiltinPaymentMethods() {
+  return apiClient.post('/api/admin/payment-methods/init-builtin')
+}
 
-  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/import`, formData, {
-    headers: { 'Content-Type': 'multipart/form-data' },
-  })
+// 用户端API
+export async function getUserPaymentMethods() {
+  return apiClient.get('/api/user/payment-methods')
 }
 
-// Create stock manually in virtual inventory
-export async function createVirtualInventoryStockManually(
-  virtualInventoryId: number,
-  data: {
-    content: string
-    remark?: string
-  }
-) {
-  return apiClient.post(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks`, data)
+export async function getOrderPaymentInfo(orderNo: string) {
+  return apiClient.get(`/api/user/orders/${orderNo}/payment-info`)
 }
 
-// Get virtual inventory stock list
-export async function getVirtualInventoryStockList(
-  virtualInventoryId: number,
-  params?: {
-    page?: number
-    limit?: number
-    status?: string
-  }
-) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
+export async function selectOrderPaymentMethod(orderNo: string, paymentMethodId: number) {
+  return apiClient.post(`/api/user/orders/${orderNo}/select-payment`, {
+    payment_method_id: paymentMethodId,
+  })
+}
 
-  return apiClient.get(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks?${query}`)
+// 为自己的待付款订单生成付款链接，转发给代付人
+export async function createOrderPaymentLink(orderNo: string) {
+  return apiClient.post(`/api/user/orders/${orderNo}/payment-link`)
 }
 
-// Alias for getVirtualInventoryStockList
-export const getVirtualInventoryStocks = getVirtualInventoryStockList
+export type CheckoutAbandonReason = 'page_hide' | 'navigate_away' | 'user_cancel'
 
-// Delete virtual inventory stock item
-export async function deleteVirtualInventoryStock(virtualInventoryId: number, stockId: number) {
-  return apiClient.delete(`/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}`)
+// 离开付款页信号：使用 keepalive fetch 以便在页面卸载时仍能送达，失败静默忽略
+export function sendCheckoutAbandonBeacon(orderNo: string, reason: CheckoutAbandonReason) {
+  if (typeof window === 'undefined') return
+  const headers = new Headers({ 'Content-Type': 'application/json' })
+  const token = getToken()
+  if (token) {
+    headers.set('Authorization', `Bearer ${token}`)
+  }
+  try {
+    void fetch(resolveFetchAPIURL(`/api/user/orders/${orderNo}/checkout-abandon`), {
+      method: 'POST',
+      headers,
+      body: JSON.stringify({ reason }),
+      keepalive: true,
+      credentials: 'same-origin',
+    }).catch(() => undefined)
+  } catch {
+    // ignore
+  }
 }
 
-// Reserve virtual inventory stock item (manual)
-export async function reserveVirtualInventoryStock(
-  virtualInventoryId: number,
-  stockId: number,
-  remark?: string
-) {
-  return apiClient.post(
-    `/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}/reserve`,
-    { remark }
-  )
-}
+// ==========================================
+// 市场平台 API
+// ==========================================
 
-// Release virtual inventory stock item (manual)
-export async function releaseVirtualInventoryStock(virtualInventoryId: number, stockId: number) {
-  return apiClient.post(
-    `/api/admin/virtual-inventories/${virtualInventoryId}/stocks/${stockId}/release`
-  )
+export interface MarketPagination {
+  page: number
+  limit: number
+  total: number
+  total_pages: number
+  has_next: boolean
+  has_prev: boolean
 }
 
-// Test delivery script
-export async function testDeliveryScript(
-  script: string,
-  config?: Record<string, any>,
-  quantity?: number
-) {
-  return apiClient.post('/api/admin/virtual-inventories/test-script', { script, config, quantity })
-}
+// ==========================================
+// 工单/客服中心 API
+// ==========================================
 
-// ==================== Product Virtual Inventory Bindings ====================
+export interface Ticket {
+  id: number
+  ticket_no: string
+  user_id: number
+  subject: string
+  content: string
+  category?: string
+  priority: string
+  status: string
+  assigned_to?: number
+  last_message_at?: string
+  last_message_preview?: string
+  last_message_by?: string
+  unread_count_user: number
+  unread_count_admin: number
+  created_at: string
+  updated_at: string
+  closed_at?: string
+  first_response_at?: string
+  satisfaction_rating?: number
+  satisfaction_comment?: string
+  rated_at?: string
+  user?: any
+  assigned_user?: any
+}
 
-// Get product virtual inventory bindings
-export async function getProductVirtualInventoryBindings(productId: number) {
-  return apiClient.get(`/api/admin/products/${productId}/virtual-inventory-bindings`)
+export interface TicketMessage {
+  id: number
+  ticket_id: number
+  sender_type: string
+  sender_id: number
+  sender_name: string
+  content: string
+  content_type: string
+  metadata?: any
+  is_read_by_user: boolean
+  is_read_by_admin: boolean
+  attachments?: TicketAttachment[]
+  created_at: string
 }
 
-// Create product virtual inventory binding
-export async function createProductVirtualInventoryBinding(
-  productId: number,
-  data: {
-    virtual_inventory_id: number
-    is_random?: boolean
-    priority?: number
-    notes?: string
-  }
-) {
-  return apiClient.post(`/api/admin/products/${productId}/virtual-inventory-bindings`, data)
+// 工单消息附件，download_url 为有时效的签名链接
+export interface TicketAttachment {
+  id: number
+  ticket_id: number
+  message_id?: number
+  uploader_type: 'user' | 'admin'
+  uploader_id: number
+  filename: string
+  content_type: string
+  size: number
+  download_url?: string
+  url_expires_at?: string
+  created_at: string
 }
 
-// Delete product virtual inventory binding
-export async function deleteProductVirtualInventoryBinding(productId: number, bindingId: number) {
-  return apiClient.delete(
-    `/api/admin/products/${productId}/virtual-inventory-bindings/${bindingId}`
-  )
+// 用户端工单 API
+export interface TicketIntakeField {
+  key: string
+  label: string
+  type: 'text' | 'textarea' | 'select' | 'number' | string
+  required?: boolean
+  options?: string[]
+  max_length?: number
 }
 
-// Save product virtual inventory variant bindings (batch save)
-export async function saveProductVirtualVariantBindings(
-  productId: number,
-  bindings: Array<{
-    attributes: Record<string, string>
-    virtual_inventory_id: number | null
-    is_random?: boolean
-    priority?: number
-  }>
-) {
-  return apiClient.put(`/api/admin/products/${productId}/virtual-inventory-bindings`, { bindings })
+export interface TicketTopic {
+  category: string
+  description?: string
+  content_template?: string
+  require_order?: boolean
+  min_attachments?: number
+  fields?: TicketIntakeField[]
 }
 
-// 系统设置
-export async function getSettings() {
-  return apiClient.get('/api/admin/settings')
+export async function createTicket(data: {
+  subject: string
+  content: string
+  category?: string
+  priority?: string
+  order_id?: number
+  fields?: Record<string, string>
+  attachments?: string[]
+}) {
+  return apiClient.post('/api/user/tickets', data)
 }
 
-export async function updateSettings(data: any) {
-  return apiClient.put('/api/admin/settings', data)
+export async function getTickets(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  search?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+  if (params?.search) query.append('search', params.search)
+
+  return apiClient.get(`/api/user/tickets?${query}`)
 }
 
-export async function testSMTP(data: any) {
-  return apiClient.post('/api/admin/settings/smtp/test', data)
+export async function getTicket(id: number) {
+  return apiClient.get(`/api/user/tickets/${id}`)
 }
 
-export async function testSMS(data: { phone: string }) {
-  return apiClient.post('/api/admin/settings/sms/test', data)
+export async function getTicketMessages(id: number) {
+  return apiClient.get(`/api/user/tickets/${id}/messages`)
 }
 
-// 邮件模板管理
-export async function getEmailTemplates() {
-  return apiClient.get('/api/admin/settings/email-templates')
+export async function sendTicketMessage(
+  id: number,
+  data: {
+    content: string
+    content_type?: string
+    attachment_ids?: number[]
+  }
+) {
+  return apiClient.post(`/api/user/tickets/${id}/messages`, data)
 }
 
-export async function getEmailTemplate(filename: string) {
-  return apiClient.get(`/api/admin/settings/email-templates/${filename}`)
+export async function updateTicketStatus(id: number, status: string) {
+  return apiClient.put(`/api/user/tickets/${id}/status`, { status })
 }
 
-export async function updateEmailTemplate(filename: string, content: string) {
-  return apiClient.put(`/api/admin/settings/email-templates/${filename}`, { content })
+export async function rateTicket(id: number, data: { rating: number; comment?: string }) {
+  return apiClient.post(`/api/user/tickets/${id}/rating`, data)
 }
 
-export async function importAdminTemplatePackage(
-  file: File,
-  expectedKind?: string,
-  targetKey?: string
+export async function shareOrderToTicket(
+  ticketId: number,
+  data: OrderShareSettings & { order_id: number }
 ) {
-  const formData = new FormData()
-  formData.append('file', file)
-  if (expectedKind) {
-    formData.append('expected_kind', expectedKind)
-  }
-  if (targetKey) {
-    formData.append('target_key', targetKey)
-  }
-  return apiClient.post('/api/admin/settings/template-packages/import', formData, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
-  })
+  return apiClient.post(`/api/user/tickets/${ticketId}/share-order`, data)
+}
+
+export async function getTicketSharedOrders(ticketId: number) {
+  return apiClient.get(`/api/user/tickets/${ticketId}/shared-orders`)
 }
 
-// 落地页管理
-export async function getLandingPage() {
-  return apiClient.get('/api/admin/settings/landing-page')
+// 订单争议：工单超过设定天数仍未解决时升级，awaiting_evidence → under_review → resolved
+export type OrderDisputeStatus = 'awaiting_evidence' | 'under_review' | 'resolved'
+
+export type OrderDisputeDecision = 'refund_full' | 'refund_partial' | 'no_refund'
+
+export interface OrderDispute {
+  id: number
+  dispute_no: string
+  ticket_id: number
+  ticket_no: string
+  order_id: number
+  order_no: string
+  user_id: number
+  user?: { id: number; name: string; email: string }
+  status: OrderDisputeStatus
+  buyer_statement: string
+  buyer_evidence?: string[]
+  buyer_submitted_at: string
+  merchant_statement?: string
+  merchant_evidence?: string[]
+  merchant_submitted_by?: number
+  merchant_submitted_at?: string
+  evidence_deadline: string
+  decision?: OrderDisputeDecision
+  decision_amount_minor?: number
+  currency: string
+  decision_note?: string
+  decided_by?: number
+  decided_at?: string
+  order_refund_id?: number
+  refund_status?: string
+  transaction_id?: string
+  created_at: string
 }
 
-export async function updateLandingPage(htmlContent: string) {
-  return apiClient.put('/api/admin/settings/landing-page', { html_content: htmlContent })
+export interface OrderDisputeEligibility {
+  enabled: boolean
+  eligible: boolean
+  available_at?: string
+  dispute?: OrderDispute
 }
 
-export async function resetLandingPage() {
-  return apiClient.post('/api/admin/settings/landing-page/reset')
+export async function getTicketDispute(ticketId: number) {
+  return apiClient.get(`/api/user/tickets/${ticketId}/dispute`)
 }
 
-// 公开配置（无需登录）
-export async function getPublicConfig() {
-  return publicApiClient.get('/api/config/public')
+// 升级为平台争议，凭证需先通过 uploadTicketIntakeAttachment 上传
+export async function escalateTicketDispute(
+  ticketId: number,
+  data: { order_no?: string; statement: string; evidence?: string[] }
+) {
+  return apiClient.post(`/api/user/tickets/${ticketId}/dispute`, data)
 }
 
-// 获取页面注入脚本/样式（无需登录，通过path参数穿透CDN）
-export async function getPageInject(path: string) {
-  return publicApiClient.get(`/api/config/page-inject?path=${encodeURIComponent(path)}`)
+// 管理端工单 API
+export async function getAdminTickets(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  exclude_status?: string
+  search?: string
+  assigned_to?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+  if (params?.exclude_status) query.append('exclude_status', params.exclude_status)
+  if (params?.search) query.append('search', params.search)
+  if (params?.assigned_to) query.append('assigned_to', params.assigned_to)
+
+  return apiClient.get(`/api/admin/tickets?${query}`)
 }
 
-export interface PluginFrontendExtension {
-  id?: string
-  slot?: string
-  type?: string
-  title?: LocalizedTextValue
-  content?: LocalizedTextValue
-  link?: string
-  priority?: number
-  data?: Record<string, any>
-  metadata?: Record<string, string>
-  plugin_id?: number
-  plugin_name?: string
+export async function getAdminTicket(id: number) {
+  return apiClient.get(`/api/admin/tickets/${id}`)
 }
 
-export interface PluginFrontendExtensionBatchRequestItem {
-  key?: string
-  slot: string
-  path?: string
-  query_params?: Record<string, string>
-  host_context?: Record<string, any>
+export async function getAdminTicketMessages(id: number) {
+  return apiClient.get(`/api/admin/tickets/${id}/messages`)
 }
 
-export interface PluginFrontendExtensionBatchResponseItem {
-  key?: string
-  slot?: string
-  path?: string
-  extensions?: PluginFrontendExtension[]
+export async function sendAdminTicketMessage(
+  id: number,
+  data: {
+    content: string
+    content_type?: string
+    attachment_ids?: number[]
+  }
+) {
+  return apiClient.post(`/api/admin/tickets/${id}/messages`, data)
 }
 
-export interface PluginFrontendBootstrapMenuItem {
-  id?: string
-  area?: 'user' | 'admin' | string
-  title?: LocalizedTextValue
-  path?: string
-  icon?: string
-  priority?: number
-  required_permissions?: string[]
-  super_admin_only?: boolean
-  guest_visible?: boolean
-  mobile_visible?: boolean
-  plugin_id?: number
-  plugin_name?: string
+export async function updateAdminTicket(
+  id: number,
+  data: {
+    status?: string
+    priority?: string
+    assigned_to?: number
+  }
+) {
+  return apiClient.put(`/api/admin/tickets/${id}`, data)
 }
 
-export interface PluginFrontendBootstrapRoute {
-  id?: string
-  area?: 'user' | 'admin' | string
-  title?: LocalizedTextValue
-  path?: string
-  route_params?: Record<string, string>
-  priority?: number
-  required_permissions?: string[]
-  super_admin_only?: boolean
-  guest_visible?: boolean
-  html_mode?: 'sanitize' | 'trusted' | string
-  page?: Record<string, any>
-  execute_api?: PluginFrontendRouteExecuteAPI
-  plugin_id?: number
-  plugin_name?: string
+export async function getAdminTicketSharedOrders(ticketId: number) {
+  return apiClient.get(`/api/admin/tickets/${ticketId}/shared-orders`)
 }
 
-function appendPluginQueryParams(query: URLSearchParams, queryParams?: Record<string, string>) {
-  if (!queryParams || Object.keys(queryParams).length === 0) {
-    return
-  }
-  query.append('query_params', JSON.stringify(queryParams))
+export async function getAdminTicketSharedOrder(ticketId: number, orderId: number) {
+  return apiClient.get(`/api/admin/tickets/${ticketId}/shared-orders/${orderId}`)
 }
 
-function appendPluginHostContext(query: URLSearchParams, hostContext?: Record<string, any>) {
-  const serialized = stringifyPluginHostContext(hostContext)
-  if (serialized === '{}') {
-    return
-  }
-  query.append('host_context', serialized)
+export async function getTicketStats() {
+  return apiClient.get('/api/admin/tickets/stats')
 }
 
-// 获取指定页面插槽的插件扩展（无需登录）
-export async function getPluginExtensions(
-  path: string,
-  slot: string,
-  queryParams?: Record<string, string>,
-  hostContext?: Record<string, any>,
-  signal?: AbortSignal,
-  locale?: string
-) {
-  const query = new URLSearchParams()
-  query.append('path', path || '/')
-  query.append('slot', slot || 'default')
-  appendPluginQueryParams(query, queryParams)
-  appendPluginHostContext(query, hostContext)
-  return publicApiClient.get(`/api/config/plugin-extensions?${query.toString()}`, {
-    signal,
-    headers: buildLocaleHeaders(locale),
-  })
+// 工单附件上传
+export interface TicketAgentPerformance {
+  stat_date?: string
+  agent_id: number
+  agent_name: string
+  agent_email: string
+  tickets_handled: number
+  first_response_count: number
+  avg_first_response_seconds: number
+  resolved_count: number
+  avg_resolution_seconds: number
+  rating_count: number
+  avg_rating: number
+}
+
+export async function getTicketAgentPerformance(params?: {
+  start_date?: string
+  end_date?: string
+  agent_id?: number
+  group_by?: 'agent' | 'day'
+}) {
+  return apiClient.get('/api/admin/tickets/agent-performance', { params })
 }
 
-// 获取管理端页面插槽的插件扩展（需管理员登录）
-export async function getAdminPluginExtensions(
-  path: string,
-  slot: string,
-  queryParams?: Record<string, string>,
-  hostContext?: Record<string, any>,
-  signal?: AbortSignal,
-  locale?: string
-) {
-  const query = new URLSearchParams()
-  query.append('path', path || '/admin')
-  query.append('slot', slot || 'default')
-  appendPluginQueryParams(query, queryParams)
-  appendPluginHostContext(query, hostContext)
-  return apiClient.get(`/api/admin/plugin-extensions?${query.toString()}`, {
-    signal,
-    headers: buildLocaleHeaders(locale),
+export async function uploadTicketFile(ticketId: number, file: File) {
+  const formData = new FormData()
+  formData.append('file', file)
+  return apiClient.post(`/api/user/tickets/${ticketId}/upload`, formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
   })
 }
 
-export async function getPluginExtensionsBatch(
-  path: string,
-  items: PluginFrontendExtensionBatchRequestItem[],
-  signal?: AbortSignal,
-  locale?: string
-) {
-  return publicApiClient.post(
-    '/api/config/plugin-extensions/batch',
-    {
-      path: path || '/',
-      items,
+// 创建工单前预上传截图
+export async function uploadTicketIntakeAttachment(file: File) {
+  const formData = new FormData()
+  formData.append('file', file)
+  return apiClient.post('/api/user/tickets/attachments', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
     },
-    {
-      signal,
-      headers: buildLocaleHeaders(locale),
-    }
-  )
+  })
 }
 
-export async function getAdminPluginExtensionsBatch(
-  path: string,
-  items: PluginFrontendExtensionBatchRequestItem[],
-  signal?: AbortSignal,
-  locale?: string
-) {
-  return apiClient.post(
-    '/api/admin/plugin-extensions/batch',
-    {
-      path: path || '/admin',
-      items,
+export async function uploadAdminTicketFile(ticketId: number, file: File) {
+  const formData = new FormData()
+  formData.append('file', file)
+  return apiClient.post(`/api/admin/tickets/${ticketId}/upload`, formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
     },
-    {
-      signal,
-      headers: buildLocaleHeaders(locale),
-    }
-  )
+  })
 }
 
-// 获取用户端前端插件 bootstrap（无需登录）
-export async function getPluginFrontendBootstrap(
-  path: string,
-  queryParams?: Record<string, string>,
-  signal?: AbortSignal,
-  locale?: string
-) {
-  const query = new URLSearchParams()
-  query.append('path', path || '/')
-  appendPluginQueryParams(query, queryParams)
-  return publicApiClient.get(`/api/config/plugin-bootstrap?${query.toString()}`, {
-    signal,
-    headers: buildLocaleHeaders(locale),
+// 上传消息附件，发送消息时通过 attachment_ids 关联
+export async function uploadTicketAttachment(ticketId: number, file: File) {
+  const formData = new FormData()
+  formData.append('file', file)
+  return apiClient.post(`/api/user/tickets/${ticketId}/attachments`, formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
   })
 }
 
-// 获取管理端前端插件 bootstrap（需管理员登录）
-export async function getAdminPluginFrontendBootstrap(
-  path: string,
-  queryParams?: Record<string, string>,
-  signal?: AbortSignal,
-  locale?: string
-) {
-  const query = new URLSearchParams()
-  query.append('path', path || '/admin')
-  appendPluginQueryParams(query, queryParams)
-  return apiClient.get(`/api/admin/plugin-bootstrap?${query.toString()}`, {
-    signal,
-    headers: buildLocaleHeaders(locale),
+export async function uploadAdminTicketAttachment(ticketId: number, file: File) {
+  const formData = new FormData()
+  formData.append('file', file)
+  return apiClient.post(`/api/admin/tickets/${ticketId}/attachments`, formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
   })
 }
 
+// 删除工单（移入回收站）
+export async function deleteAdminTicket(id: number) {
+  return apiClient.delete(`/api/admin/tickets/${id}`)
+}
+
 // ==========================================
-// 付款方式API
+// 优惠码 API
 // ==========================================
 
-export interface PaymentMethod {
-  id: number
-  name: string
-  description: string
-  type: 'builtin' | 'custom'
-  enabled: boolean
-  script?: string
-  config?: string
-  icon?: string
-  version?: string
-  package_name?: string
-  package_entry?: string
-  package_checksum?: string
-  manifest?: string
-  sort_order: number
-  poll_interval: number
-  created_at: string
-  updated_at: string
-}
-
-export type LocalizedTextValue = string | Record<string, unknown>
+// 管理端 - 优惠码列表
+export async function getAdminPromoCodes(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  search?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.status) query.append('status', params.status)
+  if (params?.search) query.append('search', params.search)
 
-export interface PaymentMethodPackageWebhookManifest {
-  key: string
-  description?: LocalizedTextValue
-  action?: string
-  method?: string
-  auth_mode?: string
-  secret_key?: string
-  header?: string
-  query_param?: string
-  signature_header?: string
+  return apiClient.get(`/api/admin/promo-codes?${query}`)
 }
 
-export interface PaymentMethodPackageManifest {
-  name?: string
-  display_name?: LocalizedTextValue
-  description?: LocalizedTextValue
-  icon?: string
-  address?: string
-  entry?: string
-  version?: string
-  poll_interval?: number
-  config?: Record<string, any>
-  config_schema?: Record<string, any>
-  webhooks?: PaymentMethodPackageWebhookManifest[]
+// 管理端 - 获取优惠码详情
+export async function getAdminPromoCode(id: number) {
+  return apiClient.get(`/api/admin/promo-codes/${id}`)
 }
 
-export interface PaymentMethodPackageResolvedPreview {
+// 管理端 - 创建优惠码
+export async function createPromoCode(data: {
+  code: string
   name: string
-  description: string
-  version: string
-  entry: string
-  icon: string
-  config: string
-  poll_interval: number
-  script_bytes: number
-  checksum: string
-}
-
-export interface PaymentMethodPackagePreview {
-  manifest?: PaymentMethodPackageManifest
-  resolved: PaymentMethodPackageResolvedPreview
+  description?: string
+  discount_type: 'percentage' | 'fixed'
+  // fixed: minor units, percentage: basis points (10000 = 100%)
+  discount_value_minor: number
+  // minor units
+  max_discount_minor?: number
+  // minor units
+  min_order_amount_minor?: number
+  total_quantity?: number
+  product_ids?: number[]
+  status?: string
+  expires_at?: string
+}) {
+  return apiClient.post('/api/admin/promo-codes', data)
 }
 
-export interface PaymentMethodMarketPreview extends PaymentMethodPackagePreview {
-  source: AdminPluginMarketSource
-  coordinates: {
-    source_id: string
-    kind: string
-    name: string
-    version: string
+// 管理端 - 更新优惠码
+export async function updatePromoCode(
+  id: number,
+  data: {
+    code?: string
+    name?: string
+    description?: string
+    discount_type?: 'percentage' | 'fixed'
+    // fixed: minor units, percentage: basis points (10000 = 100%)
+    discount_value_minor?: number
+    // minor units
+    max_discount_minor?: number
+    // minor units
+    min_order_amount_minor?: number
+    total_quantity?: number
+    product_ids?: number[]
+    status?: string
+    expires_at?: string
   }
-  release?: Record<string, any>
-  compatibility?: Record<string, any>
-  target_state?: Record<string, any>
-  warnings?: string[]
-}
-
-export interface PaymentMethodMarketCatalogItem {
-  kind?: string
-  name: string
-  title?: LocalizedTextValue
-  description?: LocalizedTextValue
-  summary?: LocalizedTextValue
-  latest_version?: string
-  channels?: string[]
+) {
+  return apiClient.put(`/api/admin/promo-codes/${id}`, data)
 }
 
-export interface PaymentMethodMarketArtifactVersion {
-  version: string
-  channel?: string
-  published_at?: string
+// 管理端 - 删除优惠码
+export async function deletePromoCode(id: number) {
+  return apiClient.delete(`/api/admin/promo-codes/${id}`)
 }
 
-export interface PaymentMethodMarketArtifact {
-  source: AdminPluginMarketSource
-  kind?: string
+export interface PromoCodeCampaign {
+  id: number
   name: string
-  title?: LocalizedTextValue
-  description?: LocalizedTextValue
-  summary?: LocalizedTextValue
-  latest_version?: string
-  channels?: string[]
-  versions?: PaymentMethodMarketArtifactVersion[]
-  governance?: Record<string, any>
+  description?: string
+  prefix?: string
+  pattern: string
+  discount_type: 'percentage' | 'fixed'
+  discount_value_minor: number
+  max_discount_minor: number
+  min_order_amount_minor: number
+  uses_per_code: number
+  per_user_limit: number
+  product_ids?: number[]
+  product_scope: string
+  starts_at?: string
+  expires_at?: string
+  code_count: number
+  created_by?: number
+  created_at: string
 }
 
-export interface PaymentCardResult {
-  html: string
-  title?: LocalizedTextValue
-  description?: LocalizedTextValue
-  data?: Record<string, any>
+export interface PromoCodeCampaignStats {
+  campaign_id: number
+  issued: number
+  active: number
+  reserved: number
+  used: number
+  codes_used: number
+  orders: number
+  discount_amount_minor: number
 }
 
-// 管理端API
-export async function getPaymentMethods(enabledOnly?: boolean) {
-  return apiClient.get('/api/admin/payment-methods', { params: { enabled_only: enabledOnly } })
+// 管理端 - 优惠码活动列表
+export async function getPromoCodeCampaigns(params?: {
+  page?: number
+  limit?: number
+  search?: string
+}) {
+  const query = new URLSearchParams()
+  if (params?.page) query.append('page', params.page.toString())
+  if (params?.limit) query.append('limit', params.limit.toString())
+  if (params?.search) query.append('search', params.search)
+
+  return apiClient.get(`/api/admin/promo-code-campaigns?${query}`)
 }
 
-export async function getPaymentMethod(id: number) {
-  return apiClient.get(`/api/admin/payment-methods/${id}`)
+// 管理端 - 创建优惠码活动并批量生成优惠码
+export async function createPromoCodeCampaign(data: {
+  name: string
+  description?: string
+  prefix?: string
+  // X = letter or digit, 9 = digit, A = letter; - and _ are kept as-is
+  pattern?: string
+  count: number
+  discount_type: 'percentage' | 'fixed'
+  // fixed: minor units, percentage: basis points (10000 = 100%)
+  discount_value_minor: number
+  max_discount_minor?: number
+  min_order_amount_minor?: number
+  uses_per_code?: number
+  per_user_limit?: number
+  product_ids?: number[]
+  product_scope?: string
+  starts_at?: string
+  expires_at?: string
+}) {
+  return apiClient.post('/api/admin/promo-code-campaigns', data)
 }
 
-export async function createPaymentMethod(data: Partial<PaymentMethod>) {
-  return apiClient.post('/api/admin/payment-methods', data)
+// 管理端 - 优惠码活动统计
+export async function getPromoCodeCampaignStats(id: number) {
+  return apiClient.get(`/api/admin/promo-code-campaigns/${id}/stats`)
 }
 
-export async function updatePaymentMethod(id: number, data: Partial<PaymentMethod>) {
-  return apiClient.put(`/api/admin/payment-methods/${id}`, data)
+export interface PricingRule {
+  id: number
+  name: string
+  description?: string
+  type: 'percentage' | 'fixed' | 'threshold'
+  value_minor: number
+  max_discount_minor: number
+  min_amount_minor: number
+  scope: 'all' | 'sku' | 'category'
+  skus?: string[]
+  categories?: string[]
+  priority: number
+  exclusive: boolean
+  enabled: boolean
+  starts_at?: string
+  expires_at?: string
+  created_at: string
+  updated_at: string
 }
 
-export async function previewPaymentMethodPackage(formData: FormData) {
-  return apiClient.post('/api/admin/payment-methods/preview-package', formData, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
-  })
+export type PricingRulePayload = Omit<
+  PricingRule,
+  'id' | 'created_at' | 'updated_at' | 'starts_at' | 'expires_at'
+> & {
+  starts_at?: string | null
+  expires_at?: string | null
 }
 
-export async function previewPaymentMethodMarketPackage(
-  data: AdminPaymentMethodMarketPreviewRequest
-) {
-  return apiClient.post('/api/admin/payment-methods/market/preview', data)
+export interface PricingTraceEntry {
+  source: 'rule' | 'promo_code'
+  rule_id?: number
+  name: string
+  type: string
+  priority: number
+  applied: boolean
+  reason?: 'no_matching_items' | 'min_amount_not_met' | 'nothing_left' | 'blocked_by_exclusive'
+  blocked_by?: string
+  skus?: string[]
+  base_amount_minor: number
+  discount_minor: number
 }
 
-export async function getPaymentMethodMarketSources() {
-  return apiClient.get('/api/admin/payment-methods/market/sources')
+export interface PricingEvaluation {
+  subtotal_minor: number
+  rule_discount_minor: number
+  promo_discount_minor: number
+  trace: PricingTraceEntry[] | null
+  promo_blocked_by?: string
 }
 
-export async function getPaymentMethodMarketCatalog(params?: {
-  source_id?: string
-  channel?: string
-  q?: string
-  limit?: number
-  offset?: number
-}) {
-  return apiClient.get('/api/admin/payment-methods/market/catalog', { params })
+// 管理端 - 定价规则列表（按计算顺序）
+export async function getPricingRules() {
+  return apiClient.get('/api/admin/pricing-rules')
 }
 
-export async function getPaymentMethodMarketArtifact(
-  name: string,
-  params?: {
-    source_id?: string
-  }
-) {
-  return apiClient.get(`/api/admin/payment-methods/market/artifacts/${encodeURIComponent(name)}`, {
-    params,
-  })
+// 管理端 - 新建定价规则
+export async function createPricingRule(data: PricingRulePayload) {
+  return apiClient.post('/api/admin/pricing-rules', data)
 }
 
-export async function uploadPaymentMethodPackage(formData: FormData) {
-  return apiClient.post('/api/admin/payment-methods/upload-package', formData, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
-  })
+// 管理端 - 更新定价规则
+export async function updatePricingRule(id: number, data: PricingRulePayload) {
+  return apiClient.put(`/api/admin/pricing-rules/${id}`, data)
 }
 
-export async function importPaymentMethodPackageFromMarket(
-  data: AdminPaymentMethodMarketImportRequest
-) {
-  return apiClient.post('/api/admin/payment-methods/market/import', data)
+// 管理端 - 删除定价规则
+export async function deletePricingRule(id: number) {
+  return apiClient.delete(`/api/admin/pricing-rules/${id}`)
 }
 
-export async function deletePaymentMethod(id: number) {
-  return apiClient.delete(`/api/admin/payment-methods/${id}`)
+// 管理端 - 模拟下单时的规则叠加计算
+export async function simulatePricingRules(data: {
+  items: { sku: string; quantity: number }[]
+  promo_code?: string
+}) {
+  return apiClient.post('/api/admin/pricing-rules/simulate', data)
 }
 
-export async function togglePaymentMethodEnabled(id: number) {
-  return apiClient.post(`/api/admin/payment-methods/${id}/toggle`)
+export interface TemplateAsset {
+  id: number
+  name: string
+  kind: 'image' | 'font' | 'css'
+  file_name: string
+  content_type: string
+  size: number
+  checksum: string
+  uploaded_by?: number | null
+  created_at: string
+  updated_at: string
+  url: string
+  reference: string
 }
 
-export async function reorderPaymentMethods(ids: number[]) {
-  return apiClient.post('/api/admin/payment-methods/reorder', { ids })
+// 管理端 - 模板资源列表
+export async function getTemplateAssets() {
+  return apiClient.get('/api/admin/settings/template-assets')
 }
 
-export async function testPaymentScript(script: string, config?: Record<string, any>) {
-  return apiClient.post('/api/admin/payment-methods/test-script', { script, config })
+// 管理端 - 上传或替换模板资源
+export async function uploadTemplateAsset(file: File, name?: string) {
+  const formData = new FormData()
+  formData.append('file', file)
+  if (name) {
+    formData.append('name', name)
+  }
+  return apiClient.post('/api/admin/settings/template-assets', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data',
+    },
+  })
 }
 
-export async function initBuiltinPaymentMethods() {
-  return apiClient.post('/api/admin/payment-methods/init-builtin')
+// 管理端 - 删除模板资源
+export async function deleteTemplateAsset(name: string) {
+  return apiClient.delete(`/api/admin/settings/template-assets/${encodeURIComponent(name)}`)
 }
 
-// 用户端API
-export async function getUserPaymentMethods() {
-  return apiClient.get('/api/user/payment-methods')
+export interface DocumentType {
+  id: number
+  key: string
+  name: string
+  description?: string
+  binding: 'order' | 'order_item'
+  template: string
+  fields?: Record<string, string>
+  order_statuses?: string[]
+  item_skus?: string[]
+  enabled: boolean
+  visible_to_user: boolean
+  created_at: string
+  updated_at: string
 }
 
-export async function getOrderPaymentInfo(orderNo: string) {
-  return apiClient.get(`/api/user/orders/${orderNo}/payment-info`)
-}
+export type DocumentTypePayload = Omit<DocumentType, 'id' | 'created_at' | 'updated_at'>
 
-export async function selectOrderPaymentMethod(orderNo: string, paymentMethodId: number) {
-  return apiClient.post(`/api/user/orders/${orderNo}/select-payment`, {
-    payment_method_id: paymentMethodId,
-  })
+// 管理端 - 自定义文档类型
+export async function getDocumentTypes() {
+  return apiClient.get('/api/admin/settings/document-types')
 }
 
-// ==========================================
-// 市场平台 API
-// ==========================================
-
-export interface MarketPagination {
-  page: number
-  limit: number
-  total: number
-  total_pages: number
-  has_next: boolean
-  has_prev: boolean
+export async function createDocumentType(data: DocumentTypePayload) {
+  return apiClient.post('/api/admin/settings/document-types', data)
 }
 
-// ==========================================
-// 工单/客服中心 API
-// ==========================================
+export async function updateDocumentType(id: number, data: DocumentTypePayload) {
+  return apiClient.put(`/api/admin/settings/document-types/${id}`, data)
+}
 
-export interface Ticket {
-  id: number
-  ticket_no: string
-  user_id: number
-  subject: string
-  content: string
-  category?: string
-  priority: string
-  status: string
-  assigned_to?: number
-  last_message_at?: string
-  last_message_preview?: string
-  last_message_by?: string
-  unread_count_user: number
-  unread_count_admin: number
-  created_at: string
-  updated_at: string
-  closed_at?: string
-  user?: any
-  assigned_user?: any
+export async function deleteDocumentType(id: number) {
+  return apiClient.delete(`/api/admin/settings/document-types/${id}`)
 }
 
-export interface TicketMessage {
-  id: number
-  ticket_id: number
-  sender_type: string
-  sender_id: number
-  sender_name: string
-  content: string
-  content_type: string
-  metadata?: any
-  is_read_by_user: boolean
-  is_read_by_admin: boolean
-  created_at: string
+// 管理端 - 订单可生成的文档
+export async function getAdminOrderDocuments(orderId: number) {
+  return apiClient.get(`/api/admin/orders/${orderId}/documents`)
 }
 
-// 用户端工单 API
-export async function createTicket(data: {
-  subject: string
-  content: string
-  category?: string
-  priority?: string
-  order_id?: number
-}) {
-  return apiClient.post('/api/user/tickets', data)
+export function getAdminOrderDocumentPath(
+  orderId: number,
+  key: string,
+  item: number,
+  format: 'html' | 'pdf'
+) {
+  const query = new URLSearchParams({ item: String(item) })
+  if (format === 'pdf') {
+    query.set('format', 'pdf')
+  }
+  return `/api/admin/orders/${orderId}/documents/${encodeURIComponent(key)}?${query.toString()}`
 }
 
-export async function getTickets(params?: {
-  page?: number
-  limit?: number
-  status?: string
-  search?: string
-}) {
+// 回收站
+export async function getAdminTrash(params: { type: string; page?: number; limit?: number }) {
   const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-  if (params?.search) query.append('search', params.search)
+  query.append('type', params.type)
+  if (params.page) query.append('page', params.page.toString())
+  if (params.limit) query.append('limit', params.limit.toString())
 
-  return apiClient.get(`/api/user/tickets?${query}`)
+  return apiClient.get(`/api/admin/trash?${query}`)
 }
 
-export async function getTicket(id: number) {
-  return apiClient.get(`/api/user/tickets/${id}`)
+export async function restoreTrashItem(type: string, id: number) {
+  return apiClient.post(`/api/admin/trash/${type}/${id}/restore`)
 }
 
-export async function getTicketMessages(id: number) {
-  return apiClient.get(`/api/user/tickets/${id}/messages`)
+// 用户端 - 验证优惠码
+export async function validatePromoCode(data: {
+  code: string
+  product_ids?: number[]
+  // minor units
+  amount_minor?: number
+}) {
+  return apiClient.post('/api/user/promo-codes/validate', data)
 }
 
-export async function sendTicketMessage(
-  id: number,
-  data: {
-    content: string
-    content_type?: string
-  }
-) {
-  return apiClient.post(`/api/user/tickets/${id}/messages`, data)
+export type GiftCardStatus = 'active' | 'void'
+export type GiftCardValueType = 'fixed' | 'variable'
+
+export interface GiftCard {
+  id: number
+  code: string
+  value_type: GiftCardValueType
+  currency: string
+  initial_value_minor: number
+  balance_minor: number
+  reserved_amount_minor: number
+  available_minor: number
+  status: GiftCardStatus
+  expires_at?: string
+  note?: string
+  issued_by?: number
+  voided_at?: string
+  voided_by?: number
+  void_reason?: string
+  created_at: string
+  updated_at: string
 }
 
-export async function updateTicketStatus(id: number, status: string) {
-  return apiClient.put(`/api/user/tickets/${id}/status`, { status })
+export interface GiftCardTransaction {
+  id: number
+  gift_card_id: number
+  type: 'issue' | 'reserve' | 'release' | 'redeem' | 'refund' | 'void'
+  amount_minor: number
+  balance_after_minor: number
+  order_id?: number
+  order_no?: string
+  operator_id?: number
+  created_at: string
 }
 
-export async function shareOrderToTicket(
-  ticketId: number,
-  data: {
-    order_id: number
-    can_edit?: boolean
-    can_view_privacy?: boolean
-  }
-) {
-  return apiClient.post(`/api/user/tickets/${ticketId}/share-order`, data)
+export interface GiftCardBalance {
+  code: string
+  currency: string
+  available_minor: number
+  expires_at?: string
 }
 
-export async function getTicketSharedOrders(ticketId: number) {
-  return apiClient.get(`/api/user/tickets/${ticketId}/shared-orders`)
+// 用户端 - 结账前查询礼品卡可用余额
+export async function checkGiftCard(code: string) {
+  return apiClient.post('/api/user/gift-cards/check', { code })
 }
 
-// 管理端工单 API
-export async function getAdminTickets(params?: {
+export async function getAdminGiftCards(params?: {
   page?: number
   limit?: number
   status?: string
-  exclude_status?: string
   search?: string
-  assigned_to?: string
 }) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-  if (params?.exclude_status) query.append('exclude_status', params.exclude_status)
-  if (params?.search) query.append('search', params.search)
-  if (params?.assigned_to) query.append('assigned_to', params.assigned_to)
-
-  return apiClient.get(`/api/admin/tickets?${query}`)
+  return apiClient.get('/api/admin/gift-cards', { params })
 }
 
-export async function getAdminTicket(id: number) {
-  return apiClient.get(`/api/admin/tickets/${id}`)
+export async function getAdminGiftCard(id: number) {
+  return apiClient.get(`/api/admin/gift-cards/${id}`)
 }
 
-export async function getAdminTicketMessages(id: number) {
-  return apiClient.get(`/api/admin/tickets/${id}/messages`)
+export async function issueGiftCards(data: {
+  count: number
+  value_type: GiftCardValueType
+  amount_minor: number
+  currency?: string
+  expires_at?: string
+  note?: string
+}) {
+  return apiClient.post('/api/admin/gift-cards', data)
 }
 
-export async function sendAdminTicketMessage(
-  id: number,
-  data: {
-    content: string
-    content_type?: string
-  }
-) {
-  return apiClient.post(`/api/admin/tickets/${id}/messages`, data)
+export async function voidGiftCard(id: number, reason: string) {
+  return apiClient.post(`/api/admin/gift-cards/${id}/void`, { reason })
 }
 
-export async function updateAdminTicket(
-  id: number,
-  data: {
-    status?: string
-    priority?: string
-    assigned_to?: number
-  }
-) {
-  return apiClient.put(`/api/admin/tickets/${id}`, data)
-}
+export type QuoteStatus = 'draft' | 'sent' | 'accepted' | 'declined' | 'expired' | 'cancelled'
 
-export async function getAdminTicketSharedOrders(ticketId: number) {
-  return apiClient.get(`/api/admin/tickets/${ticketId}/shared-orders`)
+export interface QuoteItem {
+  sku: string
+  name: string
+  quantity: number
+  unit_price_minor: number
+  line_total_minor?: number
+  attributes?: Record<string, any>
+  product_type?: string
+  virtual_inventory_id?: number
 }
 
-export async function getAdminTicketSharedOrder(ticketId: number, orderId: number) {
-  return apiClient.get(`/api/admin/tickets/${ticketId}/shared-orders/${orderId}`)
+export interface Quote {
+  id: number
+  quote_no: string
+  user_id: number
+  user?: { id: number; email: string; name?: string }
+  title: string
+  status: QuoteStatus
+  currency: string
+  items: QuoteItem[]
+  note?: string
+  admin_note?: string
+  subtotal_minor: number
+  discount_amount_minor: number
+  total_amount_minor: number
+  valid_until: string
+  sent_at?: string
+  accepted_at?: string
+  declined_at?: string
+  decline_reason?: string
+  cancelled_at?: string
+  order_id?: number
+  order_no?: string
+  created_by: number
+  created_at: string
+  updated_at: string
 }
 
-export async function getTicketStats() {
-  return apiClient.get('/api/admin/tickets/stats')
+export interface QuoteInput {
+  user_id: number
+  title?: string
+  items: QuoteItem[]
+  discount_minor?: number
+  valid_until?: string
+  note?: string
+  admin_note?: string
 }
 
-// 工单附件上传
-export async function uploadTicketFile(ticketId: number, file: File) {
-  const formData = new FormData()
-  formData.append('file', file)
-  return apiClient.post(`/api/user/tickets/${ticketId}/upload`, formData, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
-  })
+// 用户端 - 报价单
+export async function getMyQuotes(params?: { page?: number; limit?: number }) {
+  return apiClient.get('/api/user/quotes', { params })
 }
 
-export async function uploadAdminTicketFile(ticketId: number, file: File) {
-  const formData = new FormData()
-  formData.append('file', file)
-  return apiClient.post(`/api/admin/tickets/${ticketId}/upload`, formData, {
-    headers: {
-      'Content-Type': 'multipart/form-data',
-    },
-  })
+export async function getMyQuote(quoteNo: string) {
+  return apiClient.get(`/api/user/quotes/${quoteNo}`)
 }
 
-// ==========================================
-// 优惠码 API
-// ==========================================
-
-// 管理端 - 优惠码列表
-export async function getAdminPromoCodes(params?: {
-  page?: number
-  limit?: number
-  status?: string
-  search?: string
-}) {
-  const query = new URLSearchParams()
-  if (params?.page) query.append('page', params.page.toString())
-  if (params?.limit) query.append('limit', params.limit.toString())
-  if (params?.status) query.append('status', params.status)
-  if (params?.search) query.append('search', params.search)
-
-  return apiClient.get(`/api/admin/promo-codes?${query}`)
+export async function acceptQuote(quoteNo: string, remark?: string) {
+  return apiClient.post(`/api/user/quotes/${quoteNo}/accept`, { remark })
 }
 
-// 管理端 - 获取优惠码详情
-export async function getAdminPromoCode(id: number) {
-  return apiClient.get(`/api/admin/promo-codes/${id}`)
+export async function declineQuote(quoteNo: string, reason?: string) {
+  return apiClient.post(`/api/user/quotes/${quoteNo}/decline`, { reason })
 }
 
-// 管理端 - 创建优惠码
-export async function createPromoCode(data: {
-  code: string
-  name: string
-  description?: string
-  discount_type: 'percentage' | 'fixed'
-  // fixed: minor units, percentage: basis points (10000 = 100%)
-  discount_value_minor: number
-  // minor units
-  max_discount_minor?: number
-  // minor units
-  min_order_amount_minor?: number
-  total_quantity?: number
-  product_ids?: number[]
+export async function getAdminQuotes(params?: {
+  page?: number
+  limit?: number
   status?: string
-  expires_at?: string
+  search?: string
+  user_id?: number
 }) {
-  return apiClient.post('/api/admin/promo-codes', data)
+  return apiClient.get('/api/admin/quotes', { params })
 }
 
-// 管理端 - 更新优惠码
-export async function updatePromoCode(
-  id: number,
-  data: {
-    code?: string
-    name?: string
-    description?: string
-    discount_type?: 'percentage' | 'fixed'
-    // fixed: minor units, percentage: basis points (10000 = 100%)
-    discount_value_minor?: number
-    // minor units
-    max_discount_minor?: number
-    // minor units
-    min_order_amount_minor?: number
-    total_quantity?: number
-    product_ids?: number[]
-    status?: string
-    expires_at?: string
-  }
-) {
-  return apiClient.put(`/api/admin/promo-codes/${id}`, data)
+export async function getAdminQuote(id: number) {
+  return apiClient.get(`/api/admin/quotes/${id}`)
 }
 
-// 管理端 - 删除优惠码
-export async function deletePromoCode(id: number) {
-  return apiClient.delete(`/api/admin/promo-codes/${id}`)
+export async function createQuote(data: QuoteInput) {
+  return apiClient.post('/api/admin/quotes', data)
 }
 
-// 用户端 - 验证优惠码
-export async function validatePromoCode(data: {
-  code: string
-  product_ids?: number[]
-  // minor units
-  amount_minor?: number
-}) {
-  return apiClient.post('/api/user/promo-codes/validate', data)
+export async function updateQuote(id: number, data: QuoteInput) {
+  return apiClient.put(`/api/admin/quotes/${id}`, data)
+}
+
+export async function sendQuote(id: number, sendEmail: boolean) {
+  return apiClient.post(`/api/admin/quotes/${id}/send`, { send_email: sendEmail })
+}
+
+export async function cancelQuote(id: number) {
+  return apiClient.post(`/api/admin/quotes/${id}/cancel`)
+}
+
+// 报价单 HTML/PDF 需携带登录态，经代理获取后在新窗口打开；指定 filename 时改为下载
+export async function openQuoteDocument(path: string, filename?: string) {
+  const res = await fetch(resolveClientAPIProxyURL(path))
+  if (!res.ok) {
+    const payload = await res.json().catch(() => undefined)
+    throw payload
+  }
+  const blobUrl = window.URL.createObjectURL(await res.blob())
+  if (filename) {
+    const a = document.createElement('a')
+    a.href = blobUrl
+    a.download = filename
+    document.body.appendChild(a)
+    a.click()
+    document.body.removeChild(a)
+    window.URL.revokeObjectURL(blobUrl)
+    return
+  }
+  window.open(blobUrl, '_blank')
+  window.setTimeout(() => window.URL.revokeObjectURL(blobUrl), 60000)
 }
 
 // ==========================================
@@ -3902,6 +7013,459 @@ export async function sendAdminMarketing(data: SendAdminMarketingData) {
   return apiClient.post('/api/admin/marketing/send', data)
 }
 
+// ==========================================
+// 站点横幅API
+// ==========================================
+
+export type SiteBannerType = 'maintenance' | 'policy' | 'info'
+export type SiteBannerAudience = 'all' | 'guests' | 'users' | 'admins'
+
+export interface SiteBannerContent {
+  title: string
+  message: string
+  link_url?: string
+  link_text?: string
+}
+
+export interface SiteBannerView {
+  id: number
+  type: SiteBannerType
+  locale: string
+  title: string
+  message: string
+  link_url?: string
+  link_text?: string
+  starts_at: string
+  ends_at?: string
+  maintenance_starts_at?: string
+  maintenance_ends_at?: string
+  dismissible: boolean
+  updated_at: string
+}
+
+export interface SiteBanner {
+  id: number
+  type: SiteBannerType
+  audience: SiteBannerAudience
+  translations: Record<string, SiteBannerContent>
+  default_locale: string
+  starts_at: string
+  ends_at?: string
+  maintenance_starts_at?: string
+  maintenance_ends_at?: string
+  dismissible: boolean
+  priority: number
+  is_active: boolean
+  created_by: number
+  created_at: string
+  updated_at: string
+}
+
+export interface SiteBannerInput {
+  type: SiteBannerType
+  audience: SiteBannerAudience
+  translations: Record<string, SiteBannerContent>
+  default_locale: string
+  starts_at?: string
+  ends_at?: string
+  maintenance_starts_at?: string
+  maintenance_ends_at?: string
+  dismissible: boolean
+  priority: number
+  is_active: boolean
+}
+
+export async function getActiveSiteBanners(locale?: string) {
+  return publicApiClient.get('/api/config/banners', { params: { locale } })
+}
+
+export async function getAdminSiteBanners(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  type?: string
+}) {
+  return apiClient.get('/api/admin/banners', { params })
+}
+
+export async function createSiteBanner(data: SiteBannerInput) {
+  return apiClient.post('/api/admin/banners', data)
+}
+
+export async function updateSiteBanner(id: number, data: SiteBannerInput) {
+  return apiClient.put(`/api/admin/banners/${id}`, data)
+}
+
+export async function deleteSiteBanner(id: number) {
+  return apiClient.delete(`/api/admin/banners/${id}`)
+}
+
+// ==========================================
+// 内容审核 API
+// ==========================================
+
+export type ModerationAction = 'mask' | 'flag' | 'reject'
+export type ModerationSource = 'order_remark' | 'ticket_subject' | 'ticket_content' | 'ticket_message'
+export type ModerationMatchType = 'keyword' | 'regex'
+export type ModerationCaseStatus = 'pending' | 'approved' | 'removed' | 'rejected'
+
+export interface ModerationRule {
+  id: number
+  pattern: string
+  match_type: ModerationMatchType
+  action: ModerationAction
+  sources: ModerationSource[] | null
+  note?: string
+  is_active: boolean
+  created_by: number
+  created_at: string
+  updated_at: string
+}
+
+export interface ModerationRuleInput {
+  pattern: string
+  match_type: ModerationMatchType
+  action: ModerationAction
+  sources: ModerationSource[]
+  note: string
+  is_active: boolean
+}
+
+export interface ModerationCase {
+  id: number
+  source: ModerationSource
+  source_id: number
+  user_id?: number
+  user?: { id: number; email: string; name?: string }
+  action: ModerationAction
+  provider: string
+  content: string
+  processed_content: string
+  matches: string[] | null
+  labels?: string[] | null
+  status: ModerationCaseStatus
+  reviewed_by?: number
+  reviewed_at?: string
+  review_note?: string
+  created_at: string
+}
+
+export async function getModerationCases(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  source?: string
+}) {
+  return apiClient.get('/api/admin/moderation/cases', { params })
+}
+
+export async function getModerationPendingCount() {
+  return apiClient.get('/api/admin/moderation/cases/pending-count')
+}
+
+export async function reviewModerationCase(id: number, data: { decision: 'approve' | 'remove'; note?: string }) {
+  return apiClient.post(`/api/admin/moderation/cases/${id}/review`, data)
+}
+
+export async function getModerationRules() {
+  return apiClient.get('/api/admin/moderation/rules')
+}
+
+export async function createModerationRule(data: ModerationRuleInput) {
+  return apiClient.post('/api/admin/moderation/rules', data)
+}
+
+export async function updateModerationRule(id: number, data: ModerationRuleInput) {
+  return apiClient.put(`/api/admin/moderation/rules/${id}`, data)
+}
+
+export async function deleteModerationRule(id: number) {
+  return apiClient.delete(`/api/admin/moderation/rules/${id}`)
+}
+
+// ==========================================
+// 频率规则与风控审核 API
+// ==========================================
+
+export type VelocityMetric =
+  | 'orders_per_ip'
+  | 'orders_per_user'
+  | 'accounts_per_ip'
+  | 'accounts_per_address'
+export type VelocityAction = 'flag' | 'hold'
+export type OrderRiskReviewStatus = 'pending' | 'approved' | 'rejected'
+
+export interface VelocityRule {
+  id: number
+  name: string
+  metric: VelocityMetric
+  threshold: number
+  window_minutes: number
+  action: VelocityAction
+  is_active: boolean
+  hit_count: number
+  last_hit_at?: string
+  created_by: number
+  created_at: string
+  updated_at: string
+}
+
+export interface VelocityRuleInput {
+  name: string
+  metric: VelocityMetric
+  threshold: number
+  window_minutes: number
+  action: VelocityAction
+  is_active: boolean
+}
+
+export interface OrderRiskHit {
+  rule_id: number
+  rule_name: string
+  metric: VelocityMetric
+  value: number
+  threshold: number
+  window_minutes: number
+  action: VelocityAction
+}
+
+export interface OrderRiskReview {
+  id: number
+  order_id: number
+  order_no: string
+  user_id?: number
+  user?: { id: number; email: string; name?: string }
+  action: VelocityAction
+  hits: OrderRiskHit[] | null
+  ip?: string
+  status: OrderRiskReviewStatus
+  reviewed_by?: number
+  reviewed_at?: string
+  review_note?: string
+  created_at: string
+  updated_at: string
+}
+
+export async function getOrderRiskReviews(params?: {
+  page?: number
+  limit?: number
+  status?: string
+  action?: string
+}) {
+  return apiClient.get('/api/admin/risk/reviews', { params })
+}
+
+export async function getOrderRiskPendingCount() {
+  return apiClient.get('/api/admin/risk/reviews/pending-count')
+}
+
+export async function reviewOrderRisk(
+  id: number,
+  data: { decision: 'approve' | 'reject'; note?: string }
+) {
+  return apiClient.post(`/api/admin/risk/reviews/${id}/review`, data)
+}
+
+export async function getVelocityRules() {
+  return apiClient.get('/api/admin/risk/rules')
+}
+
+export async function createVelocityRule(data: VelocityRuleInput) {
+  return apiClient.post('/api/admin/risk/rules', data)
+}
+
+export async function updateVelocityRule(id: number, data: VelocityRuleInput) {
+  return apiClient.put(`/api/admin/risk/rules/${id}`, data)
+}
+
+export async function deleteVelocityRule(id: number) {
+  return apiClient.delete(`/api/admin/risk/rules/${id}`)
+}
+
+// ==========================================
+// 黑名单 API
+// ==========================================
+
+export type BlocklistType = 'ip' | 'email' | 'email_domain' | 'phone'
+
+export interface BlocklistEntry {
+  id: number
+  type: BlocklistType
+  value: string
+  reason?: string
+  expires_at?: string
+  match_count: number
+  last_matched_at?: string
+  last_matched_scope?: 'register' | 'login' | 'order'
+  created_by: number
+  created_at: string
+  updated_at: string
+}
+
+export interface BlocklistImportResult {
+  total_rows: number
+  created_count: number
+  updated_count: number
+  error_count: number
+  errors: string[]
+}
+
+export async function getBlocklistEntries(params?: {
+  page?: number
+  limit?: number
+  type?: string
+  search?: string
+}) {
+  return apiClient.get('/api/admin/blocklist', { params })
+}
+
+export async function createBlocklistEntry(data: {
+  type: BlocklistType
+  value: string
+  reason?: string
+  expires_at?: string | null
+}) {
+  return apiClient.post('/api/admin/blocklist', data)
+}
+
+export async function updateBlocklistEntry(
+  id: number,
+  data: { reason?: string; expires_at?: string | null }
+) {
+  return apiClient.put(`/api/admin/blocklist/${id}`, data)
+}
+
+export async function deleteBlocklistEntry(id: number) {
+  return apiClient.delete(`/api/admin/blocklist/${id}`)
+}
+
+// 导入 .csv/.xlsx，文件缺少 type 列时使用 defaultType
+export async function importBlocklistEntries(file: File, defaultType?: BlocklistType) {
+  const formData = new FormData()
+  formData.append('file', file)
+  if (defaultType) {
+    formData.append('type', defaultType)
+  }
+  return apiClient.post('/api/admin/blocklist/import', formData, {
+    headers: { 'Content-Type': 'multipart/form-data' },
+  })
+}
+
+// ==========================================
+// 入站 Webhook API
+// ==========================================
+
+export type InboundWebhookAction = 'mark_paid' | 'add_tracking' | 'ticket_note'
+
+export type InboundWebhookEventStatus = 'received' | 'processed' | 'partial' | 'failed' | 'ignored'
+
+export interface InboundWebhook {
+  id: number
+  name: string
+  slug: string
+  description?: string
+  auth_mode: 'hmac_sha256' | 'header'
+  signature_header: string
+  event_id_header?: string
+  script: string
+  allowed_actions: InboundWebhookAction[]
+  enabled: boolean
+  last_received_at?: string
+  created_at: string
+  updated_at: string
+}
+
+export interface InboundWebhookInput {
+  name: string
+  slug: string
+  description?: string
+  auth_mode: 'hmac_sha256' | 'header'
+  signature_header?: string
+  event_id_header?: string
+  secret?: string
+  script: string
+  allowed_actions: InboundWebhookAction[]
+  enabled: boolean
+}
+
+export interface InboundWebhookScriptAction {
+  action: InboundWebhookAction
+  order_no?: string
+  tracking_no?: string
+  ticket_no?: string
+  content?: string
+  remark?: string
+}
+
+export interface InboundWebhookEvent {
+  id: number
+  webhook_id: number
+  event_key: string
+  status: InboundWebhookEventStatus
+  headers?: Record<string, string>
+  query?: Record<string, string>
+  payload: string
+  actions?: {
+    action: InboundWebhookAction
+    order_no?: string
+    ticket_no?: string
+    tracking_no?: string
+    success: boolean
+    error?: string
+  }[]
+  console?: string[]
+  error?: string
+  duration_ms: number
+  attempts: number
+  created_at: string
+  processed_at?: string
+}
+
+export interface InboundWebhookTestResult {
+  actions: InboundWebhookScriptAction[]
+  console?: string[]
+  error?: string
+}
+
+export async function getInboundWebhooks() {
+  return apiClient.get('/api/admin/inbound-webhooks')
+}
+
+// 创建与轮换密钥的响应包含明文 secret，只返回这一次
+export async function createInboundWebhook(data: InboundWebhookInput) {
+  return apiClient.post('/api/admin/inbound-webhooks', data)
+}
+
+export async function updateInboundWebhook(id: number, data: InboundWebhookInput) {
+  return apiClient.put(`/api/admin/inbound-webhooks/${id}`, data)
+}
+
+export async function deleteInboundWebhook(id: number) {
+  return apiClient.delete(`/api/admin/inbound-webhooks/${id}`)
+}
+
+export async function rotateInboundWebhookSecret(id: number) {
+  return apiClient.post(`/api/admin/inbound-webhooks/${id}/rotate-secret`)
+}
+
+export async function testInboundWebhook(
+  id: number,
+  data: { script?: string; payload: string; headers?: Record<string, string> }
+) {
+  return apiClient.post(`/api/admin/inbound-webhooks/${id}/test`, data)
+}
+
+export async function getInboundWebhookEvents(
+  id: number,
+  params?: { page?: number; limit?: number; status?: string }
+) {
+  return apiClient.get(`/api/admin/inbound-webhooks/${id}/events`, { params })
+}
+
+export async function replayInboundWebhookEvent(id: number, eventId: number) {
+  return apiClient.post(`/api/admin/inbound-webhooks/${id}/events/${eventId}/replay`)
+}
+
 // ==========================================
 // 公告 API
 // ==========================================
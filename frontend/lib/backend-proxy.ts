@@ -9,6 +9,19 @@ export const TOKEN_ISSUING_PATHS = new Set([
   '/api/user/auth/login-with-phone-code',
   '/api/user/auth/phone-register',
   '/api/user/auth/verify-email',
+  '/api/user/auth/2fa/enable',
+  '/api/user/auth/2fa/verify',
+  '/api/user/auth/2fa/recovery',
+  '/api/user/auth/passkey/login/finish',
+])
+
+// 两步验证登录阶段由前端显式携带受限令牌，优先于可能残留的会话 Cookie
+export const TWO_FACTOR_CHALLENGE_PATHS = new Set([
+  '/api/user/auth/2fa/setup',
+  '/api/user/auth/2fa/enable',
+  '/api/user/auth/2fa/verify',
+  '/api/user/auth/2fa/recovery/send',
+  '/api/user/auth/2fa/recovery',
 ])
 
 export const FORWARDED_REQUEST_HEADERS = [
@@ -145,6 +158,17 @@ export function copyProxyResponseHeaders(source: Headers): Headers {
   return headers
 }
 
+export function resolveProxyBearerToken(
+  path: string,
+  cookieToken?: string,
+  headerToken?: string
+): string | undefined {
+  if (TWO_FACTOR_CHALLENGE_PATHS.has(path)) {
+    return headerToken || cookieToken
+  }
+  return cookieToken || headerToken
+}
+
 export function shouldAdoptLegacyBearer(
   path: string,
   status: number,
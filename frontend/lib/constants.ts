@@ -19,6 +19,9 @@ export const PERMISSIONS = [
   { value: 'order.delete', labelKey: 'permOrderDelete' as const, category: 'order' },
   { value: 'order.status_update', labelKey: 'permOrderStatusUpdate' as const, category: 'order' },
   { value: 'order.refund', labelKey: 'permOrderRefund' as const, category: 'order' },
+  { value: 'order.price_adjust', labelKey: 'permOrderPriceAdjust' as const, category: 'order' },
+  { value: 'order.price_approve', labelKey: 'permOrderPriceApprove' as const, category: 'order' },
+  { value: 'order.risk_review', labelKey: 'permOrderRiskReview' as const, category: 'order' },
   { value: 'order.assign_tracking', labelKey: 'permOrderAssignTracking' as const, category: 'order' },
   { value: 'order.request_resubmit', labelKey: 'permOrderRequestResubmit' as const, category: 'order' },
 
@@ -26,21 +29,36 @@ export const PERMISSIONS = [
   { value: 'product.view', labelKey: 'permProductView' as const, category: 'product' },
   { value: 'product.edit', labelKey: 'permProductEdit' as const, category: 'product' },
   { value: 'product.delete', labelKey: 'permProductDelete' as const, category: 'product' },
+  { value: 'product.script_approve', labelKey: 'permProductScriptApprove' as const, category: 'product' },
 
   // 用户权限
   { value: 'user.view', labelKey: 'permUserView' as const, category: 'user' },
   { value: 'user.edit', labelKey: 'permUserEdit' as const, category: 'user' },
   { value: 'user.permission', labelKey: 'permUserPermission' as const, category: 'user' },
+  { value: 'user.blocklist', labelKey: 'permUserBlocklist' as const, category: 'user' },
 
   // 工单权限
   { value: 'ticket.view', labelKey: 'permTicketView' as const, category: 'ticket' },
   { value: 'ticket.reply', labelKey: 'permTicketReply' as const, category: 'ticket' },
   { value: 'ticket.status_update', labelKey: 'permTicketStatusUpdate' as const, category: 'ticket' },
+  { value: 'ticket.dispute', labelKey: 'permTicketDispute' as const, category: 'ticket' },
 
   // 序列号权限
   { value: 'serial.view', labelKey: 'permSerialView' as const, category: 'serial' },
   { value: 'serial.manage', labelKey: 'permSerialManage' as const, category: 'serial' },
 
+  // 用量计量权限
+  { value: 'usage.view', labelKey: 'permUsageView' as const, category: 'usage' },
+  { value: 'usage.report', labelKey: 'permUsageReport' as const, category: 'usage' },
+
+  // 商家结算权限
+  { value: 'vendor.view', labelKey: 'permVendorView' as const, category: 'vendor' },
+  { value: 'vendor.manage', labelKey: 'permVendorManage' as const, category: 'vendor' },
+
+  // 企业账户权限
+  { value: 'business_account.view', labelKey: 'permBusinessAccountView' as const, category: 'business_account' },
+  { value: 'business_account.manage', labelKey: 'permBusinessAccountManage' as const, category: 'business_account' },
+
   // 知识库权限
   { value: 'knowledge.view', labelKey: 'permKnowledgeView' as const, category: 'knowledge' },
   { value: 'knowledge.edit', labelKey: 'permKnowledgeEdit' as const, category: 'knowledge' },
@@ -49,6 +67,10 @@ export const PERMISSIONS = [
   { value: 'announcement.view', labelKey: 'permAnnouncementView' as const, category: 'announcement' },
   { value: 'announcement.edit', labelKey: 'permAnnouncementEdit' as const, category: 'announcement' },
 
+  // 内容审核权限
+  { value: 'moderation.view', labelKey: 'permModerationView' as const, category: 'moderation' },
+  { value: 'moderation.manage', labelKey: 'permModerationManage' as const, category: 'moderation' },
+
   // 营销权限
   { value: 'marketing.view', labelKey: 'permMarketingView' as const, category: 'marketing' },
   { value: 'marketing.send', labelKey: 'permMarketingSend' as const, category: 'marketing' },
@@ -77,17 +99,21 @@ export const PERMISSIONS = [
 ]
 
 // 权限分类键名
-export const PERMISSION_CATEGORIES = ['order', 'product', 'serial', 'user', 'ticket', 'knowledge', 'announcement', 'marketing', 'admin', 'system', 'payment', 'plugin'] as const
+export const PERMISSION_CATEGORIES = ['order', 'product', 'vendor', 'business_account', 'serial', 'usage', 'user', 'ticket', 'knowledge', 'announcement', 'moderation', 'marketing', 'admin', 'system', 'payment', 'plugin'] as const
 
 // 分类键名到翻译键的映射
 export const CATEGORY_LABEL_KEYS: Record<string, string> = {
   order: 'permCategoryOrder',
   product: 'permCategoryProduct',
+  vendor: 'permCategoryVendor',
+  business_account: 'permCategoryBusinessAccount',
   serial: 'permCategorySerial',
+  usage: 'permCategoryUsage',
   user: 'permCategoryUser',
   ticket: 'permCategoryTicket',
   knowledge: 'permCategoryKnowledge',
   announcement: 'permCategoryAnnouncement',
+  moderation: 'permCategoryModeration',
   marketing: 'permCategoryMarketing',
   admin: 'permCategoryAdmin',
   system: 'permCategorySystem',
@@ -99,11 +125,15 @@ export const CATEGORY_LABEL_KEYS: Record<string, string> = {
 export const PERMISSIONS_BY_CATEGORY: Record<string, typeof PERMISSIONS> = {
   order: PERMISSIONS.filter(p => p.category === 'order'),
   product: PERMISSIONS.filter(p => p.category === 'product'),
+  vendor: PERMISSIONS.filter(p => p.category === 'vendor'),
+  business_account: PERMISSIONS.filter(p => p.category === 'business_account'),
   serial: PERMISSIONS.filter(p => p.category === 'serial'),
+  usage: PERMISSIONS.filter(p => p.category === 'usage'),
   user: PERMISSIONS.filter(p => p.category === 'user'),
   ticket: PERMISSIONS.filter(p => p.category === 'ticket'),
   knowledge: PERMISSIONS.filter(p => p.category === 'knowledge'),
   announcement: PERMISSIONS.filter(p => p.category === 'announcement'),
+  moderation: PERMISSIONS.filter(p => p.category === 'moderation'),
   marketing: PERMISSIONS.filter(p => p.category === 'marketing'),
   admin: PERMISSIONS.filter(p => p.category === 'admin'),
   system: PERMISSIONS.filter(p => p.category === 'system'),
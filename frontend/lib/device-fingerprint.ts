@@ -0,0 +1,40 @@
+export const DEVICE_FINGERPRINT_HEADER = 'X-Device-Fingerprint'
+
+let cachedFingerprint: Promise<string> | null = null
+
+// 仅使用稳定的浏览器与硬件特征，清理本地存储不会改变指纹
+function collectDeviceTraits(): string {
+  const nav = window.navigator
+  const screen = window.screen
+  return [
+    nav.userAgent,
+    nav.language,
+    (nav.languages || []).join(','),
+    nav.platform,
+    String(nav.hardwareConcurrency || ''),
+    String((nav as Navigator & { deviceMemory?: number }).deviceMemory || ''),
+    String(nav.maxTouchPoints || 0),
+    `${screen.width}x${screen.height}x${screen.colorDepth}`,
+    String(window.devicePixelRatio || 1),
+    Intl.DateTimeFormat().resolvedOptions().timeZone || '',
+  ].join('|')
+}
+
+async function digest(value: string): Promise<string> {
+  if (!window.crypto?.subtle) {
+    return value
+  }
+  const buffer = await window.crypto.subtle.digest('SHA-256', new TextEncoder().encode(value))
+  return Array.from(new Uint8Array(buffer))
+    .map((byte) => byte.toString(16).padStart(2, '0'))
+    .join('')
+}
+
+// 获取设备指纹，下单时提交给后端用于按设备限制下单频率
+export function getDeviceFingerprint(): Promise<string> {
+  if (typeof window === 'undefined') return Promise.resolve('')
+  if (!cachedFingerprint) {
+    cachedFingerprint = digest(collectDeviceTraits()).catch(() => '')
+  }
+  return cachedFingerprint
+}
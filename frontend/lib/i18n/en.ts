@@ -49,6 +49,13 @@ export const enTranslations = {
       'password.needLowercase': 'Password must contain at least one lowercase letter',
       'password.needDigit': 'Password must contain at least one digit',
       'password.needSpecial': 'Password must contain at least one special character',
+      'common.notFound': 'Resource not found',
+      'common.conflict': 'Resource already exists',
+      'common.versionConflict':
+        'This record was changed by someone else. Reload to see the latest version, then apply your changes again',
+      'common.serviceUnavailable': 'Service temporarily unavailable, please try again later',
+      'common.internalError': 'Internal server error, please try again later',
+      'common.invalidCursor': 'Invalid page position, please reload the list',
     },
   },
 
@@ -200,13 +207,21 @@ export const enTranslations = {
       'auth.passwordLoginDisabled':
         'Password login is disabled, please use quick login or OAuth login',
       'auth.emailNotVerified': 'Please verify your email before logging in',
+      'auth.emailNotVerifiedForCheckout': 'Please verify your email before placing an order',
+      'auth.emailNotVerifiedForVirtualReveal':
+        'Please verify your email before viewing virtual product content',
       'auth.emailAlreadyInUse': 'Email already in use',
+      'auth.emailDisposable':
+        'Disposable email addresses are not accepted. Please use a permanent email address',
+      'auth.emailDomainUnreachable':
+        'This email domain cannot receive mail. Please check the address or use another one',
       'auth.phoneAlreadyInUse': 'Phone number already in use',
       'auth.incorrectOldPassword': 'Incorrect old password',
       'auth.userNotFound': 'User not found',
       'auth.resetTokenExpired': 'Reset token expired or invalid',
       'auth.codeExpired': 'Verification code expired or invalid',
       'auth.invalidCode': 'Invalid verification code',
+      'auth.tooManyCodeAttempts': 'Too many incorrect attempts, please request a new verification code',
       'auth.registrationDisabled': 'Registration is disabled',
       'auth.emailLoginUnavailable': 'Email login is currently unavailable',
       'auth.emailLoginDisabled': 'Email code login is disabled',
@@ -319,6 +334,26 @@ export const enTranslations = {
     sku: 'SKU',
     addToCart: 'Add to Cart',
     buyNow: 'Buy Now',
+    waitingRoomTitle: 'Limited release waiting room',
+    waitingRoomDesc:
+      'This product is a limited release. Join the queue and you will be let in to order when it is your turn.',
+    waitingRoomJoin: 'Join queue',
+    waitingRoomJoinFailed: 'Failed to join the waiting room',
+    waitingRoomPosition:
+      'You are #{position} of {total} in line. Keep this page open to hold your place.',
+    waitingRoomAdmitted: "It's your turn",
+    waitingRoomAdmittedDesc: 'Please complete your order before {time}',
+    trialTitle: 'Free trial',
+    trialDesc: 'Try this product free for {days} days. Each account can claim one trial.',
+    trialStart: 'Start {days}-day free trial',
+    trialStarted: 'Trial started',
+    trialStartFailed: 'Failed to start trial',
+    trialActive: 'Your trial ends on {time}',
+    trialConvertPending: 'Pay order {order} before the trial ends to keep your license.',
+    trialPayNow: 'Pay now',
+    trialConverted: 'Your trial has been converted to a full license.',
+    trialExpired: 'Your trial has ended and the trial license is no longer valid.',
+    trialViewOrder: 'View trial order',
     outOfStock: 'Out of Stock',
     soldOut: 'Sold Out',
     inStock: 'In Stock',
@@ -521,6 +556,20 @@ export const enTranslations = {
     adminRemark: 'Admin Remark',
     trackingInfo: 'Tracking Info',
     trackingNo: 'Tracking No.',
+    shipmentStatus: 'Delivery Status',
+    shipmentDeliveredAt: 'Delivered At',
+    shipmentRegisterFailed: 'Carrier tracking registration failed: {error}',
+    shipmentStatuses: {
+      pending: 'Awaiting carrier update',
+      info_received: 'Label created',
+      in_transit: 'In transit',
+      out_for_delivery: 'Out for delivery',
+      available_for_pickup: 'Ready for pickup',
+      failed_attempt: 'Delivery attempt failed',
+      delivered: 'Delivered',
+      exception: 'Delivery exception',
+      expired: 'Tracking expired',
+    },
     privacyProtected: 'Privacy Protected',
     shippingNotFilled: 'Shipping info not filled',
     shippingNotFilledDesc: 'Please fill in shipping info so we can deliver your order',
@@ -557,9 +606,39 @@ export const enTranslations = {
     noPaymentMethodsHint:
       'No payment methods are currently available for this order. Please try again later or contact the admin.',
     paymentMethodNoDescription: 'No additional description is available for this payment method.',
+    paymentMethodFee: 'Payment fee {fee}',
+    paymentSurcharge: 'Payment Surcharge',
+    paymentDiscount: 'Payment Discount',
+    paymentSurchargeNonRefundable: 'Non-refundable',
+    priceRounding: 'Rounding',
+    manualAdjustment: 'Price Adjustment',
+    giftCardDeduction: 'Gift Card',
+    discountTotal: 'Discount',
+    pricingSkipReason: {
+      no_matching_items: 'no matching items',
+      min_amount_not_met: 'minimum not reached',
+      nothing_left: 'nothing left to discount',
+      blocked_by_exclusive: 'not combined with {rule}',
+    },
     confirmSelection: 'Confirm Selection',
     downloadInvoice: 'Download Invoice',
     downloadInvoiceFailed: 'Failed to download invoice',
+    exportOrders: 'Export Orders',
+    exportOrdersDesc:
+      'Download orders matching the current filters. Leave the dates empty to include all orders.',
+    exportFormat: 'Format',
+    exportFrom: 'From',
+    exportTo: 'To',
+    exportSku: 'SKU',
+    exportSkuPlaceholder: 'Only orders containing this SKU',
+    exportDownload: 'Download',
+    exportFailed: 'Failed to export orders',
+    downloadInvoicePdf: 'Download PDF',
+    documentsTitle: 'Documents',
+    documentsDesc: 'Certificates and other documents issued for this order',
+    documentView: 'View',
+    documentPdf: 'PDF',
+    documentOpenFailed: 'Failed to open document',
     invoiceNotAvailable: 'Invoice generation is not enabled',
     paymentUrgencyTitle: 'Please complete payment soon',
     paymentUrgencyDesc:
@@ -576,6 +655,116 @@ export const enTranslations = {
     virtualProductDelivered: 'Your virtual products have been delivered',
     virtualProductKeepSafe: 'Below are your purchased codes/activation keys, please keep them safe',
     virtualProductShipped: 'Virtual product shipped, click to view',
+    virtualRevealReauthTitle: 'Verify your identity to view',
+    virtualRevealReauthDesc:
+      'This order contains high-value codes. Please confirm it is you before they are displayed.',
+    virtualRevealPassword: 'Account password',
+    virtualRevealCode: 'Email verification code',
+    virtualRevealUsePassword: 'Use password',
+    virtualRevealUseCode: 'Use email code',
+    virtualRevealSendCode: 'Send code',
+    virtualRevealCodeSent: 'Verification code sent to {email}',
+    virtualRevealVerify: 'Verify and view',
+    virtualRevealVerifying: 'Verifying...',
+    virtualRevealFailed: 'Verification failed',
+    refundRequestTitle: 'Refund request',
+    refundRequestDesc:
+      'Describe the problem and attach screenshots. A support ticket is opened automatically and our team will review it.',
+    refundRequestButton: 'Request refund',
+    refundRequestResolution: 'Desired resolution',
+    refundRequestResolutionFullRefund: 'Full refund',
+    refundRequestResolutionPartialRefund: 'Partial refund',
+    refundRequestResolutionReplacement: 'Replacement',
+    refundRequestResolutionOther: 'Other',
+    refundRequestReason: 'Reason',
+    refundRequestReasonPlaceholder: 'Tell us what went wrong with this order',
+    refundRequestEvidence: 'Evidence',
+    refundRequestEvidenceHint: 'Optional, up to 5 images',
+    refundRequestUploadEvidence: 'Upload image',
+    refundRequestRemoveEvidence: 'Remove image',
+    refundRequestSubmit: 'Submit request',
+    refundRequestSubmitted: 'Refund request submitted',
+    refundRequestFailed: 'Failed to submit refund request',
+    refundRequestStatusPending: 'Under review',
+    refundRequestStatusApproved: 'Approved',
+    refundRequestStatusRejected: 'Rejected',
+    refundRequestReviewNote: 'Reply',
+    refundRequestViewTicket: 'View support ticket',
+    partialRefundTitle: 'Item Refunds',
+    partialRefundDesc:
+      'Return some of the items in this order. The amount is calculated from the price you paid.',
+    partialRefundButton: 'Refund items',
+    partialRefundItems: 'Items to refund',
+    partialRefundAvailable: '{count} refundable',
+    partialRefundReason: 'Reason',
+    partialRefundReasonPlaceholder: 'Tell us what is wrong with these items',
+    partialRefundAmountHint:
+      'Refunds are reviewed by our team before being returned to your payment method.',
+    partialRefundSubmit: 'Submit request',
+    partialRefundSubmitted: 'Item refund requested',
+    partialRefundFailed: 'Failed to request item refund',
+    partialRefundStatusRequested: 'Under review',
+    partialRefundStatusApproved: 'Approved',
+    partialRefundStatusRefunded: 'Refunded',
+    partialRefundStatusRejected: 'Rejected',
+    partialRefundReviewNote: 'Reply',
+    orderSharesTitle: 'Shared with Support',
+    orderSharesDesc:
+      'Support agents on these tickets can view this order. Shares stop working once they expire.',
+    orderShareExpiresAt: 'Expires',
+    orderShareNoExpiry: 'No expiry',
+    orderShareRenew: 'Change duration',
+    orderShareRevoke: 'Stop sharing',
+    orderShareUpdated: 'Share settings updated',
+    orderShareUpdateFailed: 'Failed to update share settings',
+    orderShareRevoked: 'Order is no longer shared with this ticket',
+    orderShareRevokeFailed: 'Failed to stop sharing',
+    orderTimelineTitle: 'Order History',
+    orderTimelineDesc: 'Everything that has happened to this order, oldest first.',
+    orderTimelineEmpty: 'No history yet',
+    orderTimelineCreated: 'Order placed',
+    orderTimelineStatusChanged: 'Status changed to {status}',
+    orderTimelinePaymentSelected: 'Payment method selected: {method}',
+    orderTimelinePaymentUnconfirmed: 'Payment was not confirmed in time',
+    orderTimelineCODCollected: 'Cash on delivery collected',
+    orderTimelinePaid: 'Payment received',
+    orderTimelineShipped: 'Shipped',
+    orderTimelineShipmentUpdate: 'Delivery update: {status}',
+    orderTimelineRemark: 'Admin remark',
+    orderTimelineInternal: 'Admin only',
+    orderTimelineOperatorUser: 'Customer',
+    orderTimelineOperatorAdmin: 'Admin',
+    orderTimelineOperatorSystem: 'System',
+    orderTimelineRemarkPlaceholder: 'Add an internal remark, only visible to admins',
+    orderTimelineAddRemark: 'Add remark',
+    orderTimelineRemarkAdded: 'Remark added',
+    orderTimelineRemarkFailed: 'Failed to add remark',
+    licenseActivationsTitle: 'Device Activations',
+    licenseActivationsDesc:
+      'Devices where the delivered license has been activated. Deactivate a device you no longer use to free a slot.',
+    licenseActivationActive: 'Active',
+    licenseActivationDeactivated: 'Deactivated',
+    licenseActivatedAt: 'Activated',
+    licenseLastSeenAt: 'Last seen',
+    licenseDeactivatedAt: 'Deactivated',
+    licenseDeactivate: 'Deactivate',
+    licenseDeviceDeactivated: 'Device deactivated',
+    licenseDeviceDeactivateFailed: 'Failed to deactivate device',
+    licenseDeactivatedBySoftware: 'by the software',
+    licenseDeactivatedByUser: 'by the customer',
+    licenseDeactivatedByAdmin: 'by an admin',
+    licenseDeactivatedByTransfer: 'moved to another device',
+    usageMetersTitle: 'Usage',
+    usageMetersDesc:
+      'Usage reported by the software against your license. Usage beyond the quota is billed in a separate order.',
+    usageUsed: 'Used',
+    usageRemaining: 'Remaining',
+    usageOverage: 'Over quota',
+    usageOveragePrice: 'Overage price',
+    usageOverageOrder: 'Overage order',
+    usageLastReportedAt: 'Last reported',
+    usageNearQuota: 'Near quota',
+    usageOverQuota: 'Over quota',
     delivered: 'Delivered',
     deliveryTime: 'Delivery Time',
     totalCodes: '{count} codes in total',
@@ -707,11 +896,25 @@ export const enTranslations = {
       'order.trackingNumberLengthInvalid':
         'Tracking number length must be between {min} and {max} characters',
       'order.adminRemarkTooLong': 'Admin remark length cannot exceed {max} characters',
+      'order.adminRemarkRequired': 'Admin remark cannot be empty',
+      'order.draftCleanupDisabled': 'Draft cleanup is disabled, set a retention period first',
       'order.cancellationReasonTooLong':
         'Cancellation reason length cannot exceed {max} characters',
       'order.refundReasonTooLong': 'Refund reason length cannot exceed {max} characters',
+      'order.virtualRevealRateLimited':
+        'Virtual products of this order can be viewed at most {max} times per hour, please try again later',
+      'order.virtualRevealPasswordInvalid': 'Incorrect password',
+      'order.virtualRevealPasswordUnavailable':
+        'This account has no password, please verify with an email code',
+      'order.virtualRevealCodeUnavailable':
+        'This account has no email address, please verify with your password',
+      'order.exportFormatInvalid': 'Export format must be CSV or Excel (xlsx)',
+      'order.exportDateRangeInvalid':
+        'Invalid date range, use YYYY-MM-DD and make sure the start is not after the end',
       'order.refundStatusInvalid':
         'Current order status does not support refund (current: {status})',
+      'order.refundedAmountExceeded':
+        'Refunded amount ({refunded}) exceeds the refundable amount of this order ({refundable})',
       'order.refundFinalizeStatusInvalid':
         'Current order status does not support refund confirmation (current: {status})',
       'order.refundTransactionIDTooLong':
@@ -732,6 +935,11 @@ export const enTranslations = {
       'order.addressLengthInvalid':
         'Detailed address length must be between {min} and {max} characters',
       'order.postcodeInvalid': 'Invalid postal code format or length',
+      'order.provinceRequired': 'Province/state is required for addresses in {country}',
+      'order.cityRequired': 'City is required for addresses in {country}',
+      'order.districtRequired': 'District is required for addresses in {country}',
+      'order.postcodeRequired': 'Postal code is required for addresses in {country}',
+      'order.postcodeFormatInvalid': 'Invalid postal code for {country} (e.g. {example})',
       'order.resubmitReasonLengthInvalid':
         'Resubmit reason length must be between {min} and {max} characters',
       'order.resubmitStatusInvalid':
@@ -748,10 +956,42 @@ export const enTranslations = {
       'order.productNotAvailable': 'Product is not available',
       'order.productNotFound': 'Product {sku} does not exist',
       'order.notFound': 'Order not found',
+      'order.unitPriceNegative': 'Unit price of {sku} cannot be negative',
+      'order.discountInvalid': 'Discount must be between 0 and the items subtotal',
+      'order.paymentActionInvalid': 'Invalid payment action: {action}',
+      'order.paymentActionStatusInvalid':
+        'Marking as paid or sending a payment link requires a pending payment order',
       'order.totalAmountNegative': 'Total amount cannot be negative',
       'order.userNotFound': 'User not found',
       'order.virtualInventoryRequired': 'Virtual product {sku} must select a virtual inventory',
       'order.statusInvalid': 'Invalid order status: {status}',
+      'order.riskHold':
+        'This order is on risk hold and cannot be shipped until the risk review is approved',
+      'license.notFound': 'License not found',
+      'license.revoked': 'License has been revoked',
+      'license.trialExpired': 'Trial license has expired',
+      'license.deviceIdInvalid': 'Device ID must be 1-{max} characters',
+      'license.activationLimit':
+        'License is already activated on {max} device(s), deactivate one first',
+      'license.deviceNotActivated': 'License is not activated on this device',
+      'license.transferSameDevice': 'Source and target devices must differ',
+      'license.alreadyDeactivated': 'This device has already been deactivated',
+      'license.maxActivationsInvalid': 'Max activations must be between 0 and {max}',
+      'usage.notMetered': 'This license is not usage metered',
+      'usage.quantityInvalid': 'Quantity must be a non-zero integer of at most {max}',
+      'usage.idempotencyKeyTooLong': 'Idempotency key must be at most {max} characters',
+      'usage.belowZero': 'Reported usage would drop below zero (currently used: {used})',
+      'usage.quotaExceeded': 'Usage quota exceeded, {remaining} of {quota} remaining',
+      'usage.quotaInvalid': 'Usage quota cannot be negative',
+      'usage.overagePriceInvalid': 'Overage unit price cannot be negative',
+      'usage.unitTooLong': 'Usage unit must be at most {max} characters',
+      'trial.daysInvalid': 'Trial length must be between 0 and 365 days',
+      'trial.productNotEligible':
+        'Trials are only available for virtual products with auto delivery',
+      'trial.notAvailable': 'This product does not offer a trial',
+      'trial.alreadyUsed': 'You have already used the trial for this product',
+      'trial.unavailable': 'Trials are temporarily unavailable',
+      'trial.userNotFound': 'User not found',
       'order.assignTrackingStatusInvalid':
         'Current order status does not allow assigning tracking number (current: {status})',
       'order.completeStatusInvalid':
@@ -771,6 +1011,49 @@ export const enTranslations = {
         '{product} exceeds purchase limit, {remaining} remaining ({limit} per account)',
       'order.stockInsufficient': '{product} is out of stock, only {available} left',
       'order.systemBusy': 'System is busy, please retry shortly',
+      'order.waitingRoomRequired':
+        '{product} is a limited release, please join the waiting room first',
+      'order.rateCapExceeded':
+        'Too many orders for {product} recently, please try again in {minutes} minutes',
+      'order.refundRequestReasonRequired': 'Please describe the reason for the refund',
+      'order.refundRequestReasonTooLong': 'Refund reason cannot exceed {max} characters',
+      'order.refundRequestResolutionInvalid': 'Invalid refund resolution',
+      'order.refundRequestStatusInvalid':
+        'Refunds cannot be requested for orders in {status} status',
+      'order.refundRequestPending': 'A refund request for this order is already under review',
+      'order.refundRequestNotPending': 'This refund request has already been reviewed',
+      'order.refundRequestNoteRequired': 'Please provide a reason for rejecting the request',
+      'order.refundRequestNoteTooLong': 'Review note cannot exceed {max} characters',
+      'order.partialRefundReasonRequired': 'Please provide a reason for the refund',
+      'order.partialRefundReasonTooLong': 'Refund reason cannot exceed {max} characters',
+      'order.partialRefundItemsRequired': 'Select at least one item to refund',
+      'order.partialRefundAmountInvalid': 'Refund amount cannot be negative',
+      'order.partialRefundItemInvalid': 'Order item #{index} does not exist',
+      'order.partialRefundQuantityInvalid': 'Refund quantity must be greater than 0',
+      'order.partialRefundQuantityExceeded': 'Only {available} of {sku} can still be refunded',
+      'order.partialRefundAmountRequired': 'Enter the amount to refund for the selected items',
+      'order.partialRefundAmountExceeded':
+        'Refund amount exceeds the remaining refundable amount of this order',
+      'order.partialRefundStatusInvalid':
+        'This refund cannot be processed in its current status ({status})',
+      'order.partialRefundNoteRequired': 'Please provide a reason for rejecting the refund',
+      'order.partialRefundNoteTooLong': 'Review note cannot exceed {max} characters',
+      'order.priceAdjustmentApprovalRequired':
+        'Reductions above {threshold} need a price adjustment approved by another administrator',
+      'order.priceAdjustmentReasonRequired': 'Please provide a reason for the price adjustment',
+      'order.priceAdjustmentReasonTooLong': 'Reason cannot exceed {max} characters',
+      'order.priceAdjustmentAmountInvalid':
+        'The adjustment must change the amount due and cannot go below the payment fee',
+      'order.priceAdjustmentTypeInvalid': 'Invalid price adjustment type: {type}',
+      'order.priceAdjustmentPending':
+        'This order already has a price adjustment waiting for approval',
+      'order.priceAdjustmentNotPending': 'This price adjustment is already {status}',
+      'order.priceAdjustmentSelfApproval':
+        'A price adjustment must be approved by a different administrator',
+      'order.priceAdjustmentStale':
+        'The order has changed since this adjustment was requested, please reject it and request again',
+      'order.priceAdjustmentNoteRequired':
+        'Please provide a reason for rejecting the price adjustment',
       'order.pendingPaymentLimitExceeded':
         'You already have {current} unpaid orders (limit: {max}). Please complete or cancel existing unpaid orders first.',
       'order.externalUserIDLengthInvalid':
@@ -790,12 +1073,629 @@ export const enTranslations = {
         'You already have {current} payment polling tasks (limit: {max})',
       'payment.pollingGlobalQueueLimitExceeded':
         'Payment polling queue is full (limit: {max}). Please try again later.',
+      'payment.feeRateInvalid': 'Fee percentage must be between {min}% and {max}%',
+    },
+  },
+
+  vendor: {
+    bizError: {
+      'vendor.nameInvalid': 'Vendor name is required and cannot exceed {max} characters',
+      'vendor.commissionRateInvalid': 'Commission must be between {min}% and {max}%',
+      'vendor.statusInvalid': 'Invalid vendor status: {status}',
+      'vendor.adjustmentAmountInvalid': 'Adjustment amount cannot be zero',
+      'vendor.adjustmentNoteInvalid':
+        'Adjustment reason is required and cannot exceed {max} characters',
+      'vendor.currencyRequired': 'Currency is required',
+      'vendor.statementPeriodInvalid':
+        'The settlement period must end in the past and after it starts',
+      'vendor.paymentReferenceTooLong': 'Payment reference cannot exceed {max} characters',
+      'vendor.statementAlreadyPaid': 'This statement has already been marked as paid',
+    },
+  },
+
+  revenue: {
+    bizError: {
+      'revenue.recognitionTypeInvalid': 'Invalid revenue recognition type: {type}',
+      'revenue.servicePeriodInvalid': 'Service period must be between 1 and {max} months',
+      'revenue.virtualProductRequired':
+        'Deferred revenue recognition is only available for virtual products',
+    },
+  },
+
+  netTerms: {
+    bizError: {
+      'netTerms.disabled': 'Net terms payment is not enabled',
+      'netTerms.companyNameInvalid': 'Company name is required and cannot exceed {max} characters',
+      'netTerms.currencyRequired': 'Currency is required',
+      'netTerms.creditLimitInvalid': 'Credit limit must be greater than zero',
+      'netTerms.termsDaysInvalid': 'Payment terms must be between 1 and {max} days',
+      'netTerms.accountAlreadyApproved':
+        'A business account has already been approved for this user',
+      'netTerms.accountStatusInvalid':
+        'This action is not allowed for an account in status {status}',
+      'netTerms.reviewNoteTooLong': 'Review note cannot exceed {max} characters',
+      'netTerms.currencyChangeBlocked':
+        'Currency cannot be changed while the account has open invoices',
+      'netTerms.orderStatusInvalid':
+        'Only orders awaiting payment can be paid on terms (current status: {status})',
+      'netTerms.accountNotApproved': 'Net terms require an approved business account',
+      'netTerms.creditHold':
+        'Your business account is on credit hold. Please settle overdue invoices first',
+      'netTerms.currencyMismatch': 'Net terms are only available for orders in {currency}',
+      'netTerms.creditLimitExceeded':
+        'Order exceeds your available credit ({available} {currency})',
+      'netTerms.paymentReferenceTooLong': 'Payment reference cannot exceed {max} characters',
+      'netTerms.voidReasonInvalid': 'Void reason is required and cannot exceed {max} characters',
+      'netTerms.invoiceNotOpen': 'Invoice is already {status}',
+      'netTerms.statementPeriodInvalid': 'Statement end date must be after the start date',
+    },
+  },
+
+  cod: {
+    payOnDelivery: 'Cash on Delivery',
+    payOnDeliveryDesc: 'Ship now and pay the courier when the parcel arrives.',
+    amountDue: 'Amount due on delivery',
+    payOnDeliveryConfirm: 'Place with Cash on Delivery',
+    payOnDeliverySuccess: 'Order placed, please pay the courier on delivery',
+    payOnDeliveryFailed: 'Failed to place the order with cash on delivery',
+    bizError: {
+      'cod.disabled': 'Cash on delivery is not enabled',
+      'cod.orderStatusInvalid':
+        'Only orders awaiting payment can be paid on delivery (current status: {status})',
+      'cod.virtualOnly': 'Cash on delivery is not available for orders with only virtual items',
+      'cod.countryNotEligible': 'Cash on delivery is not available for {country}',
+      'cod.amountBelowMinimum':
+        'Cash on delivery requires an order total of at least {min} {currency}',
+      'cod.amountAboveMaximum': 'Cash on delivery is limited to orders of up to {max} {currency}',
+      'cod.referenceTooLong': 'Collection reference cannot exceed {max} characters',
+      'cod.collectedAmountInvalid':
+        'Collected amount must be between 0 and the amount due ({max} {currency})',
+      'cod.orderNotShipped':
+        'Collection can only be confirmed after shipment (current status: {status})',
+      'cod.voidReasonInvalid': 'Void reason is required and cannot exceed {max} characters',
+      'cod.collectionNotOutstanding': 'This collection is already {status}',
+    },
+  },
+
+  businessAccount: {
+    title: 'Business Account',
+    applyTitle: 'Apply for a Business Account',
+    applyDesc:
+      'Approved business accounts can pay orders on net terms: orders ship immediately and are invoiced with a due date within your credit limit.',
+    companyName: 'Company name',
+    taxId: 'Tax ID',
+    billingEmail: 'Billing email',
+    billingAddress: 'Billing address',
+    applySubmit: 'Submit Application',
+    applySubmitted: 'Application submitted, we will review it shortly',
+    applyFailed: 'Failed to submit application',
+    statusPending: 'Under review',
+    statusApproved: 'Approved',
+    statusRejected: 'Rejected',
+    statusSuspended: 'Suspended',
+    pendingHint:
+      'Your application is being reviewed. You can keep ordering with regular payment methods meanwhile.',
+    rejectedReason: 'Application rejected: {reason}',
+    creditHold: 'Credit hold',
+    creditHoldNotice:
+      'Your account is on credit hold. Settle overdue invoices to pay on terms again.',
+    creditLimit: 'Credit limit',
+    outstanding: 'Outstanding',
+    overdue: 'Overdue',
+    availableCredit: 'Available credit',
+    termsSummary: 'Invoices are due {days} days after the order is placed on terms.',
+    invoices: 'Invoices',
+    noInvoices: 'No invoices yet',
+    invoiceOpen: 'Open',
+    invoicePaid: 'Paid',
+    invoiceVoid: 'Void',
+    invoiceOverdue: 'Overdue',
+    dueOn: 'Due {date}',
+    statement: 'Statement',
+    periodStart: 'From',
+    periodEnd: 'To',
+    openingBalance: 'Opening balance',
+    invoiced: 'Invoiced',
+    paid: 'Paid',
+    voided: 'Voided',
+    closingBalance: 'Closing balance',
+    payOnTerms: 'Pay on Net Terms',
+    payOnTermsDesc: 'Ship now and pay by invoice within {days} days.',
+    payOnTermsConfirm: 'Place on Terms',
+    payOnTermsSuccess: 'Order placed on terms, an invoice has been issued',
+    payOnTermsFailed: 'Failed to pay on terms',
+    insufficientCredit: 'Available credit is not enough for this order.',
+  },
+
+  organization: {
+    title: 'Organization',
+    createTitle: 'Create an Organization',
+    createDesc:
+      'Share order visibility and checkout with teammates. You become the owner and can invite members by email.',
+    name: 'Organization name',
+    create: 'Create Organization',
+    created: 'Organization created',
+    createFailed: 'Failed to create organization',
+    rename: 'Rename',
+    renamed: 'Organization renamed',
+    saveFailed: 'Failed to update organization',
+    roleOwner: 'Owner',
+    rolePurchaser: 'Purchaser',
+    roleViewer: 'Viewer',
+    roleHint:
+      'Purchasers can place orders within their monthly limit; viewers can only see organization orders.',
+    members: 'Members',
+    member: 'Member',
+    role: 'Role',
+    spendingLimit: 'Monthly limit',
+    spentThisMonth: 'Spent this month',
+    unlimited: 'Unlimited',
+    spendingLimitHint: 'Leave empty or 0 for no limit. Cancelled and refunded orders do not count.',
+    you: 'You',
+    editMember: 'Edit member',
+    transferOwnership: 'Make owner',
+    transferConfirm:
+      'Transfer ownership to {email}? You will become a purchaser and can no longer manage members.',
+    memberUpdated: 'Member updated',
+    memberUpdateFailed: 'Failed to update member',
+    removeMember: 'Remove',
+    removeConfirm: 'Remove {email} from the organization?',
+    memberRemoved: 'Member removed',
+    removeFailed: 'Failed to remove member',
+    leave: 'Leave Organization',
+    leaveConfirm: 'Leave this organization? You will no longer see its orders.',
+    leaveOwnerConfirm: 'You are the only member. Leaving will dissolve the organization.',
+    left: 'You have left the organization',
+    leaveFailed: 'Failed to leave organization',
+    invitations: 'Pending Invitations',
+    noInvitations: 'No pending invitations',
+    inviteTitle: 'Invite Member',
+    inviteEmail: 'Email',
+    invite: 'Send Invitation',
+    invited: 'Invitation sent to {email}',
+    inviteFailed: 'Failed to send invitation',
+    expiresAt: 'Expires {date}',
+    revoke: 'Revoke',
+    revoked: 'Invitation revoked',
+    revokeFailed: 'Failed to revoke invitation',
+    acceptTitle: 'Organization Invitation',
+    acceptDesc: 'You have been invited to join {name} as {role}.',
+    accept: 'Accept Invitation',
+    accepted: 'You joined {name}',
+    acceptFailed: 'Failed to accept invitation',
+    invitationLoadFailed: 'This invitation is invalid or was sent to another email address',
+    orders: 'Organization Orders',
+    noOrders: 'No organization orders yet',
+    orderPlacedBy: 'Placed by',
+    allMembers: 'All members',
+    bizError: {
+      'organization.nameInvalid': 'Organization name is required and cannot exceed {max} characters',
+      'organization.roleInvalid': 'Invalid member role: {role}',
+      'organization.spendingLimitInvalid': 'Spending limit cannot be negative',
+      'organization.ownerRequired': 'Only the organization owner can do this',
+      'organization.alreadyMember': 'This account already belongs to an organization',
+      'organization.inviteEmailInvalid': 'A valid email address is required',
+      'organization.invitationExpired': 'This invitation is no longer valid',
+      'organization.invitationEmailMismatch':
+        'This invitation was sent to a different email address',
+      'organization.cannotChangeSelf': 'You cannot change your own membership',
+      'organization.ownerCannotLeave': 'Transfer ownership before leaving the organization',
+      'organization.checkoutNotAllowed': 'Your organization role does not allow placing orders',
+      'organization.spendingLimitExceeded':
+        'Order exceeds your monthly spending limit ({remaining} {currency} remaining)',
+    },
+  },
+
+  apiTokens: {
+    title: 'API Tokens',
+    description:
+      'Personal access tokens let your scripts read your orders and virtual products. Tokens are read-only and cannot place orders or change your account.',
+    createTitle: 'Create Token',
+    name: 'Token name',
+    namePlaceholder: 'e.g. Inventory sync script',
+    scopes: 'Scopes',
+    scopeOrdersRead: 'Read orders',
+    scopeOrdersReadDesc: 'List your orders and view order details',
+    scopeVirtualProductsRead: 'Read virtual products',
+    scopeVirtualProductsReadDesc: 'Fetch delivered virtual product contents of paid orders',
+    expiry: 'Expiration',
+    expiryDays: '{days} days',
+    expiryNever: 'No expiration',
+    create: 'Create Token',
+    createFailed: 'Failed to create token',
+    createdTitle: 'Token created',
+    createdHint: 'Copy this token now. For your security it will not be shown again.',
+    copied: 'Token copied',
+    done: 'Done',
+    tokens: 'Your Tokens',
+    noTokens: 'No tokens yet',
+    lastUsed: 'Last used {date}',
+    neverUsed: 'Never used',
+    expiresOn: 'Expires {date}',
+    expired: 'Expired',
+    revoked: 'Revoked',
+    revoke: 'Revoke',
+    revokeConfirm: 'Revoke token "{name}"? Scripts using it will stop working immediately.',
+    revokeSuccess: 'Token revoked',
+    revokeFailed: 'Failed to revoke token',
+    usageHint: 'Send the token as "Authorization: Bearer <token>" to the /api/user/automation endpoints.',
+    bizError: {
+      'personalToken.nameInvalid': 'Token name is required and cannot exceed {max} characters',
+      'personalToken.scopeInvalid': 'Unsupported token scope: {scope}',
+      'personalToken.scopeRequired': 'Select at least one scope',
+      'personalToken.expiryInvalid': 'Expiry must be between 0 and {max} days',
+      'personalToken.limitReached': 'You can have at most {max} active tokens',
+    },
+  },
+
+  siteBanner: {
+    title: 'Site Banners',
+    description: 'Scheduled maintenance notices and policy changes shown at the top of every page.',
+    create: 'New Banner',
+    edit: 'Edit Banner',
+    type: 'Type',
+    typeMaintenance: 'Maintenance',
+    typePolicy: 'Policy change',
+    typeInfo: 'Information',
+    audience: 'Audience',
+    audienceAll: 'Everyone',
+    audienceGuests: 'Guests only',
+    audienceUsers: 'Signed-in users',
+    audienceAdmins: 'Admins only',
+    priority: 'Priority',
+    bannerTitle: 'Title',
+    message: 'Message',
+    linkUrl: 'Link URL',
+    linkText: 'Link text',
+    schedule: 'Schedule',
+    noEnd: 'No end',
+    status: 'Status',
+    statusLive: 'Live',
+    statusScheduled: 'Scheduled',
+    statusEnded: 'Ended',
+    startsAt: 'Show from',
+    startsAtHint: 'Leave empty to show immediately.',
+    endsAt: 'Show until',
+    endsAtHint: 'Leave empty to keep showing until deactivated.',
+    maintenanceStartsAt: 'Maintenance starts',
+    maintenanceEndsAt: 'Maintenance ends',
+    maintenanceWindow: 'Maintenance window: {start} – {end}',
+    localeEn: 'English',
+    localeZh: 'Chinese',
+    defaultLocale: 'Fallback: {locale}',
+    active: 'Active',
+    dismissibleLabel: 'Users can dismiss',
+    dismiss: 'Dismiss',
+    learnMore: 'Learn more',
+    saved: 'Banner saved',
+    saveFailed: 'Failed to save banner',
+    deleted: 'Banner deleted',
+    deleteFailed: 'Failed to delete banner',
+    deleteTitle: 'Delete banner',
+    deleteConfirm: 'This banner will stop showing immediately. Continue?',
+    bizError: {
+      'siteBanner.typeInvalid': 'Invalid banner type: {type}',
+      'siteBanner.audienceInvalid': 'Invalid banner audience: {audience}',
+      'siteBanner.contentRequired': 'Enter a message in at least one language',
+      'siteBanner.localeInvalid': 'Invalid language: {locale}',
+      'siteBanner.titleTooLong': 'Banner title cannot exceed {max} characters',
+      'siteBanner.messageInvalid': 'Banner message is required and cannot exceed {max} characters',
+      'siteBanner.linkInvalid': 'Link must be a site path (starting with /) or an http(s) URL',
+      'siteBanner.scheduleInvalid': 'End time must be after the start time',
+      'siteBanner.maintenanceWindowInvalid': 'Maintenance end time must be after its start time',
+    },
+  },
+  moderation: {
+    title: 'Content Moderation',
+    description: 'Review flagged or masked user text and manage the word list used to screen order remarks and tickets',
+    queueTab: 'Review Queue',
+    rulesTab: 'Word List',
+    source: 'Source',
+    sources: 'Sources',
+    allSources: 'All sources',
+    sourcesHint: 'Leave all unchecked to apply the rule to every source',
+    sourceOrderRemark: 'Order remark',
+    sourceTicketSubject: 'Ticket subject',
+    sourceTicketContent: 'Ticket body',
+    sourceTicketMessage: 'Ticket message',
+    user: 'User',
+    content: 'Content',
+    storedAs: 'Stored as',
+    matches: 'Matches',
+    action: 'Action',
+    actionMask: 'Mask',
+    actionFlag: 'Flag for review',
+    actionReject: 'Reject',
+    providerRules: 'Word list',
+    providerExternal: 'External provider',
+    status: 'Status',
+    statusPending: 'Pending',
+    statusApproved: 'Approved',
+    statusRemoved: 'Removed',
+    statusRejected: 'Rejected on submit',
+    approve: 'Keep',
+    remove: 'Remove',
+    approveTitle: 'Keep content',
+    approveHint: 'The content stays as it is currently stored.',
+    removeTitle: 'Remove content',
+    removeHint: 'The content will be replaced with "[Removed by moderator]" where it was posted. This cannot be undone.',
+    reviewNote: 'Review note (optional)',
+    reviewed: 'Review saved',
+    reviewFailed: 'Failed to save review',
+    rulesHint: 'Keyword rules match case-insensitively; when several rules match, the strictest action wins.',
+    pattern: 'Pattern',
+    matchType: 'Match type',
+    matchKeyword: 'Keyword',
+    matchRegex: 'Regular expression',
+    note: 'Note',
+    ruleActive: 'Active',
+    ruleInactive: 'Inactive',
+    createRule: 'Add Rule',
+    editRule: 'Edit Rule',
+    ruleSaved: 'Rule saved',
+    ruleSaveFailed: 'Failed to save rule',
+    ruleDeleted: 'Rule deleted',
+    ruleDeleteFailed: 'Failed to delete rule',
+    deleteRuleTitle: 'Delete rule',
+    deleteRuleConfirm: 'New submissions will no longer be checked against this rule. Continue?',
+    bizError: {
+      'moderation.rejected': 'This content cannot be submitted because it violates the content policy',
+      'moderation.patternInvalid': 'Pattern is required and cannot exceed {max} characters',
+      'moderation.matchTypeInvalid': 'Invalid match type: {type}',
+      'moderation.actionInvalid': 'Invalid moderation action: {action}',
+      'moderation.regexInvalid': 'Invalid regular expression',
+      'moderation.sourceInvalid': 'Invalid source: {source}',
+      'moderation.decisionInvalid': 'Decision must be keep or remove',
+      'moderation.caseAlreadyReviewed': 'This item has already been reviewed',
+    },
+  },
+  blocklist: {
+    title: 'Blocklist',
+    description:
+      'Block IP addresses, emails, email domains and phone numbers from registering, signing in or placing orders',
+    type: 'Type',
+    allTypes: 'All types',
+    typeIp: 'IP / CIDR',
+    typeEmail: 'Email',
+    typeEmailDomain: 'Email domain',
+    typePhone: 'Phone',
+    value: 'Value',
+    valueHint:
+      'IPs accept CIDR ranges such as 203.0.113.0/24. Email domains also match their subdomains.',
+    reason: 'Reason',
+    reasonHint: 'Only visible to admins; blocked users see a generic message',
+    expiresAt: 'Expires',
+    expiresAtHint: 'Leave empty to block permanently',
+    never: 'Never',
+    expired: 'Expired',
+    matches: 'Matches',
+    scopeRegister: 'Registration',
+    scopeLogin: 'Sign-in',
+    scopeOrder: 'Checkout',
+    searchPlaceholder: 'Search value or reason',
+    create: 'Add Entry',
+    edit: 'Edit Entry',
+    saved: 'Entry saved',
+    saveFailed: 'Failed to save entry',
+    deleted: 'Entry deleted',
+    deleteFailed: 'Failed to delete entry',
+    deleteTitle: 'Delete entry',
+    deleteConfirm: '{value} will no longer be blocked. Continue?',
+    import: 'Import',
+    importHint:
+      'Upload a .csv or .xlsx file with a value column and optional type, reason and expires_at columns. Existing entries are updated.',
+    importFile: 'File',
+    importDefaultType: 'Default type',
+    importTypeFromFile: 'Use the type column',
+    importSummary: 'Imported: {created} created, {updated} updated, {errors} failed',
+    importFailed: 'Import failed',
+    bizError: {
+      'blocklist.blocked':
+        'This request cannot be completed. Please contact support if you think this is a mistake.',
+      'blocklist.typeInvalid': 'Invalid blocklist type: {type}',
+      'blocklist.valueInvalid': 'Invalid {type} value: {value}',
+      'blocklist.reasonTooLong': 'Reason cannot exceed {max} characters',
+      'blocklist.expiresInPast': 'Expiry time must be in the future',
+      'blocklist.duplicate': '{value} is already on the blocklist',
+    },
+  },
+  risk: {
+    title: 'Risk Review',
+    description:
+      'Velocity rules check new orders for abuse patterns and send matching orders to the review queue',
+    queueTab: 'Review Queue',
+    rulesTab: 'Velocity Rules',
+    order: 'Order',
+    user: 'User',
+    hits: 'Matched Rules',
+    action: 'Action',
+    allActions: 'All actions',
+    status: 'Status',
+    actionFlag: 'Flag for review',
+    actionHold: 'Hold shipment',
+    actionHint:
+      'Held orders cannot be shipped or auto-delivered until approved; flagged orders continue normally',
+    statusPending: 'Pending',
+    statusApproved: 'Approved',
+    statusRejected: 'Rejected',
+    metric: 'Metric',
+    metricOrdersPerIp: 'Orders from one IP',
+    metricOrdersPerUser: 'Orders from one account',
+    metricAccountsPerIp: 'Accounts ordering from one IP',
+    metricAccountsPerAddress: 'Accounts shipping to one address',
+    threshold: 'Threshold',
+    thresholdHint:
+      'The rule matches when the count, including the new order, exceeds the threshold. A window of 0 counts all history.',
+    windowMinutes: 'Window (minutes)',
+    windowMinutesValue: 'in {minutes} min',
+    windowAllTime: 'all time',
+    condition: 'Condition',
+    hitCount: 'Hits',
+    ruleName: 'Name',
+    ruleActive: 'Active',
+    ruleInactive: 'Inactive',
+    rulesHint: 'Rules run when an order is placed and again when its shipping address is submitted',
+    createRule: 'Add Rule',
+    editRule: 'Edit Rule',
+    ruleSaved: 'Rule saved',
+    ruleSaveFailed: 'Failed to save rule',
+    ruleDeleted: 'Rule deleted',
+    ruleDeleteFailed: 'Failed to delete rule',
+    deleteRuleTitle: 'Delete rule',
+    deleteRuleConfirm: 'Existing review records are kept. Continue?',
+    approve: 'Approve',
+    reject: 'Reject',
+    approveTitle: 'Approve order',
+    approveHint: 'The hold is released and the order can be shipped normally.',
+    rejectTitle: 'Reject order',
+    rejectHint:
+      'Unpaid orders are cancelled. Paid orders stay on hold so you can refund them from the order page.',
+    reviewNote: 'Note',
+    reviewed: 'Review saved',
+    reviewedCancelled: 'Review saved and the order was cancelled',
+    reviewFailed: 'Failed to save review',
+    orderHeldTitle: 'This order is on risk hold',
+    orderFlaggedTitle: 'This order was flagged by velocity rules',
+    orderRejected: 'The risk review was rejected.',
+    openQueue: 'Open the review queue',
+    bizError: {
+      'velocity.nameInvalid': 'Rule name is required and cannot exceed {max} characters',
+      'velocity.metricInvalid': 'Invalid velocity metric: {metric}',
+      'velocity.thresholdInvalid': 'Threshold must be at least 1',
+      'velocity.windowInvalid': 'Time window must be between 0 and {max} minutes',
+      'velocity.actionInvalid': 'Invalid velocity action: {action}',
+      'velocity.decisionInvalid': 'Decision must be approve or reject',
+      'velocity.noteTooLong': 'Review note cannot exceed {max} characters',
+      'velocity.reviewAlreadyDone': 'This order has already been reviewed',
+    },
+  },
+  inboundWebhook: {
+    title: 'Inbound Webhooks',
+    description:
+      'Receive events from banks, carriers and help desks, and map them to order and ticket actions with a script.',
+    create: 'New webhook',
+    edit: 'Edit webhook',
+    name: 'Name',
+    slug: 'Slug',
+    descriptionLabel: 'Description',
+    authMode: 'Verification',
+    authHmac: 'HMAC-SHA256 signature',
+    authHeader: 'Shared secret header',
+    authHint:
+      'HMAC mode expects the hex HMAC-SHA256 of the raw body (an optional sha256= prefix is accepted). Header mode compares the header value with the secret.',
+    signatureHeader: 'Signature header',
+    eventIdHeader: 'Event ID header',
+    secret: 'Secret',
+    secretHint: 'Leave empty to generate one. At least 16 characters.',
+    secretKeepHint: 'Leave empty to keep the current secret.',
+    secretTitle: 'Webhook secret',
+    secretOnce: 'Copy it now. The secret is not shown again.',
+    allowedActions: 'Allowed actions',
+    allowedActionsHint:
+      'Actions returned by the script that are not allowed here are recorded as failed.',
+    actionMarkPaid: 'Mark order paid',
+    actionAddTracking: 'Add tracking number',
+    actionTicketNote: 'Append ticket note',
+    script: 'Transformation script',
+    scriptHint:
+      'Define transform(event) and return null, one action or an array of actions. Scripts time out after 2 seconds and can only call AuraLogic.system.log.',
+    testPayload: 'Sample payload',
+    runTest: 'Run script',
+    testHint: 'Test runs only show the mapped actions. Nothing is applied.',
+    testFailed: 'Failed to run script',
+    enabled: 'Enabled',
+    disabled: 'Disabled',
+    lastReceived: 'Last received',
+    inbox: 'Inbox',
+    inboxTitle: 'Inbox · {name}',
+    inboxHint: 'Replaying an event runs the current script again and applies its actions.',
+    receivedAt: 'Received',
+    status: 'Status',
+    results: 'Results',
+    payload: 'Payload',
+    attempts: '{count} attempts',
+    statusReceived: 'Received',
+    statusProcessed: 'Processed',
+    statusPartial: 'Partial',
+    statusFailed: 'Failed',
+    statusIgnored: 'Ignored',
+    replay: 'Replay',
+    replayed: 'Event replayed',
+    replayFailed: 'Failed to replay event',
+    saved: 'Webhook saved',
+    saveFailed: 'Failed to save webhook',
+    deleted: 'Webhook deleted',
+    deleteFailed: 'Failed to delete webhook',
+    deleteTitle: 'Delete webhook',
+    deleteConfirm: 'Delete "{name}" and its event history? Senders will get 404 afterwards.',
+    rotate: 'Rotate',
+    rotateTitle: 'Rotate secret',
+    rotateConfirm: 'The current secret stops working immediately. Continue?',
+    rotateFailed: 'Failed to rotate secret',
+    copied: 'Copied',
+    bizError: {
+      'inbound_webhook.notFound': 'Inbound webhook not found',
+      'inbound_webhook.eventNotFound': 'Inbound webhook event not found',
+      'inbound_webhook.nameRequired': 'Name is required',
+      'inbound_webhook.slugInvalid':
+        'Slug must be 2-64 lowercase letters, digits, hyphens or underscores',
+      'inbound_webhook.slugTaken': 'Slug is already used by another inbound webhook',
+      'inbound_webhook.authModeInvalid': 'Verification must be hmac_sha256 or header',
+      'inbound_webhook.actionsInvalid': 'Allow at least one of the supported actions',
+      'inbound_webhook.scriptRequired': 'Transformation script is required',
+      'inbound_webhook.scriptInvalid': 'Transformation script error: {error}',
+      'inbound_webhook.secretTooShort': 'Secret must be at least 16 characters',
+    },
+  },
+  integration: {
+    bizError: {
+      'integration.orderNotFound': 'Order not found',
+      'integration.ticketNotFound': 'Ticket not found',
+      'integration.tagInvalid': 'Tag must be 1-{max} characters without commas',
+      'integration.tagLimit': 'An order can have at most {max} tags',
+    },
+  },
+  validation: {
+    bizError: {
+      'validation.required': '{field} is required',
+      'validation.email': '{field} must be a valid email address',
+      'validation.url': '{field} must be a valid URL',
+      'validation.oneof': '{field} must be one of: {param}',
+      'validation.minLength': '{field} must be at least {param} characters',
+      'validation.maxLength': '{field} cannot exceed {param} characters',
+      'validation.length': '{field} must be exactly {param} characters',
+      'validation.minItems': '{field} must contain at least {param} items',
+      'validation.maxItems': '{field} cannot contain more than {param} items',
+      'validation.lengthItems': '{field} must contain exactly {param} items',
+      'validation.min': '{field} must be at least {param}',
+      'validation.max': '{field} cannot exceed {param}',
+      'validation.equal': '{field} must equal {param}',
+      'validation.greaterThan': '{field} must be greater than {param}',
+      'validation.lessThan': '{field} must be less than {param}',
+      'validation.type': '{field} has an invalid type',
+      'validation.invalid': '{field} is invalid',
+      'validation.bodyRequired': 'Request body is required',
+      'validation.malformed': 'Request body is not valid JSON',
+      'validation.invalidRequest': 'Invalid request parameters',
+    },
+  },
+  accounting: {
+    bizError: {
+      'accounting.providerNotConfigured':
+        'No accounting system is configured. Set order.accounting_export.provider first',
+      'accounting.accountMappingMissing': 'Account mapping "{field}" is not configured',
+      'accounting.exportRunning': 'An accounting export is already running, please try again later',
+      'accounting.credentialsMissing': 'OAuth credentials for {provider} are not configured',
+      'accounting.providerInvalid': 'Unsupported accounting system: {provider}',
     },
   },
 
   profile: {
     profile: 'Profile',
     profileCenter: 'Profile Center',
+    emailVerificationPending:
+      'Your email is not verified yet. Some features may be restricted until you verify it.',
+    verifyEmailNow: 'Verify Now',
     editProfile: 'Edit Profile',
     username: 'Username',
     name: 'Name',
@@ -961,6 +1861,12 @@ export const enTranslations = {
     contentLimitDesc: 'Up to {max} characters',
     contentUnlimited: 'No content limit is currently enforced',
     relatedOrder: 'Related Order (optional)',
+    relatedOrderRequired: 'Related Order *',
+    intakeScreenshots: 'Screenshots',
+    intakeScreenshotsHint: 'Upload at least {min} screenshot(s)',
+    intakeUploadScreenshot: 'Add screenshot',
+    intakeRemoveScreenshot: 'Remove',
+    intakeSelectPlaceholder: 'Please select',
     selectOrder: 'Select an order to link',
     noRelatedOrder: 'No related order',
     relatedOrderTip: 'Linking an order allows the agent to view its details to better assist you',
@@ -997,6 +1903,15 @@ export const enTranslations = {
     selectOrderPlaceholder: 'Please select an order',
     alreadyShared: '(Shared)',
     shareOrderTip: 'After sharing, the agent will be able to view the order details to assist you.',
+    shareDuration: 'Share for',
+    shareDurationForever: 'Until revoked',
+    shareDuration24h: '24 hours',
+    shareDuration3d: '3 days',
+    shareDuration7d: '7 days',
+    shareDuration30d: '30 days',
+    shareIncludeReceiverInfo: 'Include recipient details',
+    shareIncludeReceiverInfoDesc:
+      'Allow the agent to see the recipient name, contact details and full address.',
     sharing: 'Sharing...',
     confirmShare: 'Confirm Share',
     shareSuccess: 'Order shared successfully',
@@ -1021,6 +1936,13 @@ export const enTranslations = {
     messagePlaceholder: 'Type a message... (Enter to send, Shift+Enter for new line)',
     uploadImage: 'Upload Image',
     uploadFailed: 'Failed to upload',
+    attachFile: 'Attach File',
+    removeAttachment: 'Remove attachment',
+    deleteTicket: 'Delete Ticket',
+    deleteTicketConfirm:
+      'The ticket will be moved to the trash and can be restored until the retention period ends. Continue?',
+    deleteTicketSuccess: 'Ticket moved to trash',
+    deleteTicketFailed: 'Failed to delete ticket',
     recordVoice: 'Record Voice',
     recording: 'Recording',
     recordingTip: 'Click to stop',
@@ -1048,6 +1970,21 @@ export const enTranslations = {
       'ticket.imageUploadDisabled': 'Image upload is currently disabled',
       'ticket.imageFileTooLarge': 'Image size cannot exceed {max}MB',
       'ticket.imageFormatUnsupported': 'Unsupported image format',
+      'ticket.fileUploadDisabled': 'File attachments are currently disabled',
+      'ticket.fileTooLarge': 'File size cannot exceed {max}MB',
+      'ticket.fileTypeUnsupported': 'Unsupported file type',
+      'ticket.tooManyAttachments': 'A message can have at most {max} attachments',
+      'ticket.messageEmpty': 'Enter a message or add an attachment',
+      'ticket.attachmentLinkExpired': 'The download link is invalid or has expired',
+      'ticket.intakeFieldRequired': '{field} is required',
+      'ticket.intakeFieldInvalid': 'Invalid value for {field}',
+      'ticket.intakeFieldTooLong': '{field} cannot exceed {max} characters',
+      'ticket.orderRequired': 'Please select the related order',
+      'ticket.attachmentRequired': 'Please upload at least {min} screenshot(s)',
+      'ticket.attachmentInvalid': 'Invalid attachment',
+      'ticket.ratingInvalid': 'Rating must be between 1 and 5',
+      'ticket.ratingNotAllowed': 'Only resolved or closed tickets can be rated',
+      'ticket.alreadyRated': 'This ticket has already been rated',
     },
     items: 'items',
     ticketStatus: {
@@ -1108,6 +2045,30 @@ export const enTranslations = {
     updateFailed: 'Failed to update',
     adminMessagePlaceholder:
       'Type a message... (Enter to send, Shift+Enter for new line, Markdown supported)',
+    // Satisfaction rating
+    rateTitle: 'How satisfied are you with the support on this ticket?',
+    rateCommentPlaceholder: 'Anything else you would like to tell us? (optional)',
+    rateSubmit: 'Submit Rating',
+    rateSuccess: 'Thanks for your feedback',
+    rateFailed: 'Failed to submit rating',
+    ratedLabel: 'Your rating',
+    // Agent performance
+    agentPerformance: 'Agent Performance',
+    agentPerformanceDesc:
+      'First response, resolution and satisfaction metrics per agent, aggregated daily (UTC).',
+    perfStartDate: 'Start Date',
+    perfEndDate: 'End Date',
+    perfGroupByAgent: 'By Agent',
+    perfGroupByDay: 'By Day',
+    perfDate: 'Date',
+    perfTicketsHandled: 'Tickets Handled',
+    perfAvgFirstResponse: 'Avg First Response',
+    perfResolved: 'Resolved',
+    perfAvgResolution: 'Avg Resolution',
+    perfCsat: 'CSAT',
+    perfNoData: 'No data for the selected range',
+    perfExport: 'Export CSV',
+    perfExportSuccess: 'Report exported',
   },
 
   admin: {
@@ -1118,19 +2079,52 @@ export const enTranslations = {
     productManagement: 'Products',
     inventoryManagement: 'Inventory',
     orderManagement: 'Orders',
+    vendorManagement: 'Vendors',
+    businessAccountManagement: 'Business Accounts',
+    codManagement: 'Cash on Delivery',
     serialManagement: 'Serials',
     userManagement: 'Users',
     ticketManagement: 'Tickets',
     paymentMethods: 'Payment Methods',
+    inboundWebhookManagement: 'Inbound Webhooks',
     apiKeys: 'API Keys',
     systemLogs: 'System Logs',
     systemSettings: 'Settings',
     analytics: 'Analytics',
     promoCodeManagement: 'Promo Codes',
+    giftCardManagement: 'Gift Cards',
+    quoteManagement: 'Quotes',
+    returnManagement: 'Returns',
+    disputeManagement: 'Disputes',
+    policyManagement: 'Terms & Privacy',
     knowledgeManagement: 'Knowledge Base',
     announcementManagement: 'Announcements',
+    siteBannerManagement: 'Site Banners',
+    moderationManagement: 'Content Moderation',
+    blocklistManagement: 'Blocklist',
+    riskManagement: 'Risk Review',
     marketingManagement: 'Marketing',
     pluginManagement: 'Plugins',
+    trashManagement: 'Trash',
+
+    // Trash
+    trashDescription:
+      'Deleted products, promo codes and virtual inventories are kept here until the retention period ends, then removed permanently.',
+    trashTypeProduct: 'Products',
+    trashTypePromoCode: 'Promo Codes',
+    trashTypeVirtualInventory: 'Virtual Inventories',
+    trashTypeTicket: 'Tickets',
+    trashName: 'Name',
+    trashIdentifier: 'SKU / Code',
+    trashDeletedBy: 'Deleted By',
+    trashDeletedAt: 'Deleted At',
+    trashPurgeAt: 'Purged At',
+    trashRestore: 'Restore',
+    trashRestored: 'Restored',
+    trashRestoreFailed: 'Failed to restore',
+    trashConfirmRestore: 'Restore "{name}"? It will be visible again in its list.',
+    trashEmpty: 'Trash is empty',
+    trashSystem: 'System',
 
     // Marketing
     marketingDescription:
@@ -2460,9 +3454,32 @@ export const enTranslations = {
         'Package uploaded but activation failed: {cause}',
       'admin.emailAlreadyInUse': 'Email is already in use',
       'admin.notFound': 'Admin does not exist',
+      'admin.userNotFound': 'User not found',
       'admin.userNotAdmin': 'This user is not an admin',
       'admin.cannotModifySelfRoleOrStatus': 'You cannot modify your own role or active status',
       'admin.cannotDeleteSelf': 'You cannot delete the currently signed-in admin',
+      'trash.entityTypeInvalid': 'Unsupported trash item type',
+      'trash.itemNotFound': 'Item not found in trash',
+      'templateAsset.nameInvalid':
+        'Asset name may only contain lowercase letters, digits, - and _ (up to 64 characters)',
+      'templateAsset.typeNotAllowed': 'File type {ext} is not allowed for template assets',
+      'templateAsset.tooLarge': 'Template asset cannot exceed {max_mb}MB',
+      'templateAsset.empty': 'Template asset file is empty',
+      'templateAsset.notFound': 'Template asset not found',
+      'templateAsset.inUse':
+        'This asset is used as the company logo. Change the logo setting before deleting it.',
+      'document.keyInvalid':
+        'Document key may only contain lowercase letters, digits, - and _ (up to 64 characters) and cannot be a built-in document',
+      'document.keyTaken': 'A document type with this key already exists',
+      'document.nameRequired': 'Document name is required',
+      'document.bindingInvalid': 'Documents can only be generated per order or per order item',
+      'document.templateInvalid': 'Document template is invalid: {cause}',
+      'document.fieldInvalid': 'Custom field name {field} is invalid',
+      'document.statusInvalid': 'Unknown order status {status}',
+      'document.notFound': 'Document type not found',
+      'document.notAvailable': 'This document is not available for the order',
+      'document.itemInvalid': 'This document is not available for the selected item',
+      'document.tokenInvalid': 'Download link is invalid or has expired',
     },
 
     // Common
@@ -2527,9 +3544,48 @@ export const enTranslations = {
     userAnalytics: 'Users',
     orderAnalytics: 'Orders',
     revenueAnalytics: 'Revenue',
+    funnelAnalytics: 'Funnel',
+    funnelDesc:
+      'First-party events without cookies. Sessions end after 30 minutes of inactivity. Bots are ignored.',
+    funnelSessions: 'Sessions',
+    funnelPageViews: 'Page Views',
+    funnelConversion: 'Order Conversion',
+    funnelStages: 'Conversion Funnel',
+    funnelFromPrevious: '{rate}% of previous step',
+    funnelStageVisit: 'Visited',
+    funnelStageAddToCart: 'Added to cart',
+    funnelStageCheckoutCart: 'Opened cart',
+    funnelStageCheckoutSubmit: 'Submitted order',
+    funnelStageCheckoutPlaced: 'Order placed',
+    funnelStageCheckoutPayment: 'Chose payment method',
+    funnelDailyTrend: 'Daily Sessions',
+    funnelPlacedSessions: 'Sessions with orders',
+    funnelEntryPages: 'Top Entry Pages',
     overview: 'Overview',
     trend: 'Trend',
     distribution: 'Distribution',
+    // SKU sales report
+    skuSales: 'SKU Sales',
+    skuSalesDesc:
+      'Units sold, revenue, refund rate, stock turn and sell-through per SKU, aggregated daily (UTC).',
+    skuSalesStartDate: 'Start Date',
+    skuSalesEndDate: 'End Date',
+    skuSalesSkuFilter: 'Filter SKU',
+    skuSalesGroupBySku: 'By SKU',
+    skuSalesGroupByDay: 'By Day',
+    skuSalesGroupByMonth: 'By Month',
+    skuSalesPeriod: 'Period',
+    skuSalesProduct: 'Product',
+    skuSalesUnitsSold: 'Units Sold',
+    skuSalesRevenue: 'Revenue',
+    skuSalesRefundRate: 'Refund Rate',
+    skuSalesVelocity: 'Units / Day',
+    skuSalesEndingStock: 'Ending Stock',
+    skuSalesStockTurn: 'Stock Turn',
+    skuSalesSellThrough: 'Sell-through',
+    skuSalesNoData: 'No data in the selected date range',
+    skuSalesExport: 'Export CSV',
+    skuSalesExportSuccess: 'Report exported',
     // User analytics
     totalUsersCount: 'Total Users',
     activeUsersCount: 'Active Users',
@@ -2595,6 +3651,7 @@ export const enTranslations = {
     logResourceMarketingBatch: 'Marketing Batch',
     logResourcePlugin: 'Plugin',
     logResourceSystemConfig: 'System Config',
+    logResourceAdminAudit: 'Admin Audit',
     logActionRegister: 'Register',
     logActionVerifyEmail: 'Verify Email',
     logActionAssignTracking: 'Assign Tracking',
@@ -2643,6 +3700,10 @@ export const enTranslations = {
     logActionTicketAutoCloseStart: 'Start Ticket Auto Close',
     logActionTicketAutoCloseStop: 'Stop Ticket Auto Close',
     logActionTicketAutoClose: 'Ticket Auto Close',
+    logActionJobSchedulerStart: 'Job Scheduler Started',
+    logActionJobSchedulerStop: 'Job Scheduler Stopped',
+    logActionRestore: 'Restore from Trash',
+    logActionTrashPurge: 'Trash Purge',
 
     // Product Management
     addProduct: 'Add Product',
@@ -2691,6 +3752,9 @@ export const enTranslations = {
     batchConfirmTitle: 'Confirm Batch Operation',
     batchSuccess: 'Batch operation completed: {success} succeeded, {failed} failed',
     batchSelectionPreviewMore: '{count} more selected item(s)',
+    batchPreviewLoading: 'Checking which orders will be changed...',
+    batchPreviewSummary: '{affected} order(s) will be changed, {skipped} will be skipped',
+    batchPreviewFailed: 'Failed to preview the batch operation',
     selectAll: 'Select All',
     deselectAll: 'Deselect All',
     downloadTemplate: 'Download Template',
@@ -2764,6 +3828,11 @@ export const enTranslations = {
     unverified: 'Unverified',
     withPhone: 'With Phone',
     withoutPhone: 'Without Phone',
+    userFilterEmailRisk: 'Filter: Email Risk',
+    emailRiskFlagged: 'Flagged',
+    emailRiskClean: 'Not Flagged',
+    emailRiskDisposable: 'Disposable email',
+    emailRiskNoMX: 'Email domain has no MX',
     viewOrders: 'View Orders',
     createOrderForUser: 'Create Order',
     createOrderTitle: 'Create Order for User',
@@ -2835,17 +3904,23 @@ export const enTranslations = {
     permOrderDelete: 'Delete Orders',
     permOrderStatusUpdate: 'Update Order Status',
     permOrderRefund: 'Refund Orders',
+    permOrderPriceAdjust: 'Request Price Adjustments',
+    permOrderPriceApprove: 'Approve Price Adjustments',
+    permOrderRiskReview: 'Review Risky Orders & Manage Velocity Rules',
     permOrderAssignTracking: 'Assign Tracking Number',
     permOrderRequestResubmit: 'Request Info Resubmission',
     permProductView: 'View Products',
     permProductEdit: 'Edit Products',
     permProductDelete: 'Delete Products',
+    permProductScriptApprove: 'Approve Delivery Scripts',
     permUserView: 'View Users',
     permUserEdit: 'Edit Users',
     permUserPermission: 'Modify User Permissions',
+    permUserBlocklist: 'Manage Blocklist',
     permTicketView: 'View Tickets',
     permTicketReply: 'Reply to Tickets',
     permTicketStatusUpdate: 'Update Ticket Status',
+    permTicketDispute: 'Decide Disputes',
     permAdminCreate: 'Create Admin',
     permAdminEdit: 'Edit Admin',
     permAdminDelete: 'Delete Admin',
@@ -2863,16 +3938,28 @@ export const enTranslations = {
     permPluginUpload: 'Upload Plugin Packages',
     permCategoryKnowledge: 'Knowledge Base Permissions',
     permCategoryAnnouncement: 'Announcement Permissions',
+    permCategoryModeration: 'Moderation Permissions',
     permCategoryMarketing: 'Marketing Permissions',
     permKnowledgeView: 'View Knowledge Base',
     permKnowledgeEdit: 'Edit Knowledge Base',
     permAnnouncementView: 'View Announcements',
     permAnnouncementEdit: 'Edit Announcements',
+    permModerationView: 'View Moderation Queue',
+    permModerationManage: 'Review Content & Manage Word List',
     permMarketingView: 'View Marketing',
     permMarketingSend: 'Send Marketing Messages',
     permCategorySerial: 'Serial Permissions',
     permSerialView: 'View Serials',
     permSerialManage: 'Manage Serials',
+    permCategoryUsage: 'Usage Metering Permissions',
+    permUsageView: 'View Usage Records',
+    permUsageReport: 'Report License Usage',
+    permCategoryVendor: 'Vendor Settlement Permissions',
+    permVendorView: 'View Vendors & Statements',
+    permVendorManage: 'Manage Vendors & Payouts',
+    permCategoryBusinessAccount: 'Business Account Permissions',
+    permBusinessAccountView: 'View Business Accounts & Invoices',
+    permBusinessAccountManage: 'Manage Credit Terms & Invoices',
     permSelectAll: 'Select All',
     permDeselectAll: 'Deselect All',
     permInvertSelection: 'Invert',
@@ -2934,6 +4021,85 @@ export const enTranslations = {
     inventoryTypeScriptDesc:
       'JavaScript script generates delivery content dynamically on order payment',
     scriptLabel: 'Delivery Script',
+    scriptRevisions: 'Script Revisions',
+    scriptRevisionsDesc:
+      'Script changes take effect only after another administrator approves them, because delivery scripts can make arbitrary outbound HTTP calls.',
+    scriptRevisionPending: 'Pending Approval',
+    scriptRevisionStatusApproved: 'Approved',
+    scriptRevisionStatusRejected: 'Rejected',
+    scriptRevisionSuperseded: 'Superseded',
+    scriptRevisionViewDiff: 'View Diff',
+    scriptRevisionDiffTitle: 'Script Revision #{id}',
+    scriptRevisionDiffDesc: 'Changes compared with the script that was active when this revision was submitted.',
+    scriptRevisionConfig: 'Script Config',
+    scriptRevisionStaleHint:
+      'The active script has changed since this revision was submitted. Reject it and submit again.',
+    scriptRevisionNotePlaceholder: 'Review note (optional)',
+    scriptRevisionApprove: 'Approve & Activate',
+    scriptRevisionReject: 'Reject',
+    scriptRevisionApproved: 'Script revision approved and activated',
+    scriptRevisionRejected: 'Script revision rejected',
+    scriptRevisionReviewFailed: 'Failed to review script revision',
+    scriptRevisionSubmitted: 'Saved. The script change is waiting for another administrator to approve it',
+    scriptExecutions: 'Script Executions',
+    scriptExecutionsDesc:
+      'Every delivery script run for a real order, with console output, HTTP calls and result',
+    scriptExecutionFailedOnly: 'Failed only',
+    scriptExecutionEmpty: 'No matching executions',
+    scriptExecutionSuccess: 'Succeeded',
+    scriptExecutionFailed: 'Failed',
+    scriptExecutionReplayed: 'Replayed',
+    scriptExecutionView: 'Details',
+    scriptExecutionTitle: 'Script Execution #{id}',
+    scriptExecutionSummary: '{order} · {items}/{quantity} items · {duration}ms · script {hash}',
+    scriptExecutionReplayOf: 'Replay of execution #{id}',
+    scriptExecutionConsole: 'Console Output',
+    scriptExecutionHttpCalls: 'HTTP Calls',
+    scriptExecutionNoEvents: 'Nothing recorded',
+    scriptExecutionReplayHint:
+      'Replaying delivers all remaining virtual stock of this order with the current script',
+    scriptExecutionReplay: 'Replay Delivery',
+    scriptExecutionReplaySucceeded: 'Replay succeeded, the order has been delivered',
+    scriptExecutionReplayStillFailing: 'The script failed again',
+    scriptExecutionReplayFailed: 'Failed to replay script execution',
+    revealContent: 'Reveal content (recorded in the audit log)',
+    hideContent: 'Hide content',
+    revealContentFailed: 'Failed to reveal content',
+    restockTitle: 'Auto Restock',
+    restockDesc:
+      'Purchase new codes from the supplier script when available stock drops below the threshold',
+    restockEnabled: 'Enabled',
+    restockAvailable: 'Available',
+    restockSpentToday: 'Spent today',
+    restockSpentMonth: 'Spent this month',
+    restockLastRun: 'Last run',
+    restockLastError: 'Last run failed ({count} in a row)',
+    restockCapReachedHint:
+      'The spend cap has been reached, automatic purchases resume in the next period',
+    restockThreshold: 'Restock when available below',
+    restockQuantity: 'Quantity per purchase',
+    restockCooldown: 'Cooldown (minutes, 0 = default 30)',
+    restockDailyCap: 'Daily spend cap',
+    restockMonthlyCap: 'Monthly spend cap',
+    restockCapUnlimited: 'Unlimited',
+    restockScript: 'Supplier Script',
+    restockScriptHint:
+      'Define onRestock(request, config) and return { success, items, cost_minor, reference }. request has quantity, available, currency, budget_minor and inventory',
+    restockScriptConfig: 'Script Config (JSON)',
+    restockSaved: 'Restock policy saved',
+    restockSaveFailed: 'Failed to save restock policy',
+    restockRunNow: 'Restock Now',
+    restockRunning: 'Purchasing...',
+    restockRunSucceeded: 'Restock completed, {count} codes added',
+    restockRunFailed: 'Restock failed',
+    restockRuns: 'Purchase Log',
+    restockRunsEmpty: 'No purchases yet',
+    restockRunSuccess: 'Succeeded',
+    restockRunFailure: 'Failed',
+    restockTriggerAuto: 'Auto',
+    restockTriggerManual: 'Manual',
+    restockRunSummary:
+      '{added}/{requested} added · {duplicates} duplicates · {invalid} invalid · cost {cost}',
     scriptExamples: 'Examples',
     scriptExampleBasic: 'Basic - Random Activation Codes',
     scriptExampleHttp: 'HTTP - External API Delivery',
@@ -2958,6 +4124,17 @@ export const enTranslations = {
     scriptTestContent: 'Content',
     scriptTestRemark: 'Remark',
     scriptTestNoItems: 'No items generated',
+    scriptDryRunHttpLive: 'Live HTTP',
+    scriptDryRunHttpMock: 'Mock HTTP',
+    scriptDryRunMocksLabel: 'HTTP Mock Responses',
+    scriptDryRunMocksDesc:
+      'JSON array of {method, url, status, body}. A url ending in * matches by prefix. Unmatched requests fail instead of reaching the supplier.',
+    scriptDryRunInvalidMocks: 'HTTP mock responses must be a valid JSON array',
+    scriptDryRunLogs: 'Dry-run Logs',
+    scriptDryRunWaiting: 'Waiting for script output...',
+    scriptDryRunMocked: 'mock',
+    scriptDryRunStorageNotSaved: 'not saved',
+    scriptDryRunTruncated: 'Further output was truncated',
     scriptApiRef: 'API Reference',
     scriptApiRefDesc: 'Available APIs in delivery script',
     scriptRequiredCallback: 'Required Callback',
@@ -2969,6 +4146,12 @@ export const enTranslations = {
     scriptGetUser: 'Get order user info',
     scriptUtilsApi: 'Utility Functions',
     scriptHttpApi: 'HTTP Requests',
+    scriptStorageApi: 'Persistent Storage',
+    scriptStorageApiDesc:
+      'String values scoped to this inventory and kept across runs. Keys up to 191 characters, values up to 32KB, at most 1000 keys. Test runs and dry runs never write to it.',
+    scriptCryptoApi: 'Hashing & Signing',
+    scriptCryptoApiDesc:
+      'Native hash, HMAC and encoding helpers for vendor request signing. encoding is hex (default), base64 or base64url.',
     scriptConfigJsonLabel: 'Config (JSON)',
     scriptConfigFieldsLabel: 'Config Fields',
     scriptConfigJsonEditor: 'JSON Editor',
@@ -3029,6 +4212,7 @@ export const enTranslations = {
     totalInventory: 'Total Inventory',
     statusAvailable: 'Available',
     statusReserved: 'Reserved',
+    statusHeld: 'Held for Payment',
     statusSold: 'Sold',
     statusInvalid: 'Invalid',
     descriptionLabel: 'Description',
@@ -3036,6 +4220,147 @@ export const enTranslations = {
     allowInlineIframe: 'Allow Inline iframe',
     allowInlineIframeHint:
       'Only applies to script-based virtual inventory. When enabled, the script can return presentation.inline_iframe to render a dedicated panel in the order detail page.',
+    supplierHealth: 'Supplier API Health',
+    supplierHealthDesc:
+      'Success rate and latency of upstream endpoints called by delivery scripts. Inventories are paused automatically and treated as out of stock when the error rate exceeds the configured threshold.',
+    supplierHealthEmpty: 'No supplier calls recorded in this window',
+    supplierHealthWindowMinutes: 'Last {count} min',
+    supplierHealthWindowHours: 'Last {count} h',
+    supplierHealthInventory: 'Inventory',
+    supplierHealthEndpoint: 'Endpoint',
+    supplierHealthSuccessRate: 'Success Rate',
+    supplierHealthLatency: 'Avg / Max Latency',
+    supplierHealthLastSeen: 'Last Call',
+    supplierHealthPaused: 'Paused',
+    supplierHealthActive: 'Normal',
+    supplierHealthResume: 'Resume',
+    supplierHealthResumed: 'Inventory resumed',
+    supplierHealthResumeFailed: 'Failed to resume inventory',
+    resaleCheck: 'Resale Check',
+    resaleCheckDesc:
+      'Check whether a code found on another marketplace was sold by this store, and to whom. Every check is logged',
+    resaleCheckCode: 'Code',
+    resaleCheckSource: 'Where it was found (optional)',
+    resaleCheckSubmit: 'Check',
+    resaleChecking: 'Checking...',
+    resaleCheckFailed: 'Failed to check code',
+    resaleMatched: 'Sold by this store',
+    resaleNotMatched: 'Not sold by this store',
+    resalePreviousReports: 'Checked {count} times before, first on {date}',
+    resaleRefunded: 'Refunded',
+    resaleBuyer: 'Buyer',
+    resaleDeliveredAt: 'Delivered',
+    resaleRecentMatches: 'Recent matches',
+    stockReconciliation: 'Stock reconciliation',
+    stockReconciliationDesc:
+      'A nightly job compares reserved counters with open orders and virtual stock assignments. Run it now to check, or run and heal to fix counters.',
+    stockReconciliationRun: 'Run check',
+    stockReconciliationRunHeal: 'Run and heal',
+    stockReconciliationDone: 'Reconciliation finished with {count} discrepancies',
+    stockReconciliationFailed: 'Failed to run stock reconciliation',
+    stockReconciliationEmpty: 'No reconciliation runs yet',
+    stockReconciliationStartedAt: 'Started at',
+    stockReconciliationTrigger: 'Trigger',
+    stockReconciliationScheduled: 'Scheduled',
+    stockReconciliationManual: 'Manual',
+    stockReconciliationStatusFailed: 'Failed',
+    stockReconciliationDiscrepancies: 'Discrepancies',
+    stockReconciliationOversold: 'Oversold',
+    stockReconciliationHealed: 'Healed',
+    stockReconciliationView: 'Details',
+    stockReconciliationDetailTitle: 'Reconciliation #{id}',
+    stockReconciliationDetailDesc:
+      'Oversold inventories and undelivered virtual items are never healed automatically and need manual follow-up.',
+    stockReconciliationNoDiscrepancies: 'No discrepancies found',
+    stockReconciliationKind: 'Type',
+    stockReconciliationKindReserved: 'Reserved mismatch',
+    stockReconciliationKindOversold: 'Oversold',
+    stockReconciliationKindVirtualLeak: 'Leaked reservation',
+    stockReconciliationKindVirtualUndelivered: 'Undelivered',
+    stockReconciliationInventory: 'Inventory',
+    stockReconciliationExpected: 'Expected',
+    stockReconciliationActual: 'Actual',
+    stockReconciliationOrders: 'Orders',
+    lowStockThreshold: 'Low-stock alert threshold',
+    lowStockThresholdHint:
+      'Admins are notified when available codes fall below this number. 0 = no alert.',
+    lowStockAlerts: 'Low-stock alerts',
+    lowStockAlertsDesc:
+      'Inventories whose available stock is below their threshold (safety stock for physical inventories). Snooze an inventory to pause its alerts.',
+    lowStockAlertsEmpty: 'No inventories are below their threshold',
+    lowStockCheckNow: 'Check now',
+    lowStockCheckDone: '{low} low, {alerted} alerts sent',
+    lowStockCheckFailed: 'Failed to check low stock',
+    lowStockAvailableOf: '{available} / {threshold}',
+    lowStockSince: 'Low since',
+    lowStockLastAlerted: 'Last alert',
+    lowStockSnooze: 'Snooze',
+    lowStockSnooze1h: '1 hour',
+    lowStockSnooze1d: '1 day',
+    lowStockSnooze7d: '7 days',
+    lowStockSnoozedUntil: 'Snoozed until {date}',
+    lowStockUnsnooze: 'Resume alerts',
+    lowStockSnoozeFailed: 'Failed to update snooze',
+    inventorySnapshots: 'Stock history',
+    inventorySnapshotsDesc:
+      'Daily snapshots of on-hand stock per SKU (UTC). Look up the level on a past date or compare two dates for accounting.',
+    inventorySnapshotTake: 'Snapshot now',
+    inventorySnapshotTaken: 'Snapshot saved for {date}',
+    inventorySnapshotTakeFailed: 'Failed to take snapshot',
+    inventorySnapshotLoadFailed: 'Failed to load stock history',
+    inventorySnapshotFrom: 'Compare from',
+    inventorySnapshotTo: 'Date',
+    inventorySnapshotView: 'View',
+    inventorySnapshotCompare: 'Compare',
+    inventorySnapshotUsing: 'Using snapshot of {date}',
+    inventorySnapshotUsingRange: 'Using snapshots of {from} and {to}',
+    inventorySnapshotEmpty: 'No inventories in this snapshot',
+    inventorySnapshotPhysical: 'Physical',
+    inventorySnapshotVirtual: 'Virtual',
+    inventorySnapshotOnHand: 'On hand',
+    inventorySnapshotAvailable: 'Available',
+    inventorySnapshotReserved: 'Reserved',
+    inventorySnapshotSold: 'Sold',
+    inventorySnapshotChange: 'Change',
+    inventorySnapshotTotal: 'Total change',
+    requireRevealReauth: 'Require Re-authentication to View',
+    requireRevealReauthHint:
+      'For high-value inventory. Buyers must confirm their password or an email code before delivered content is shown.',
+    maxActivations: 'Max Device Activations',
+    maxActivationsHint:
+      'How many devices each delivered license can be active on through the license activation API. 0 = unlimited.',
+    usageMetering: 'Usage Metering',
+    usageQuota: 'Quota per license',
+    usageUnitPlaceholder: 'Unit, e.g. API calls',
+    overageUnitPrice: 'Overage price per unit',
+    usageMeteringHint:
+      'Quota included with each delivered license, reported through the usage API. 0 = not metered. Without an overage price, usage beyond the quota is rejected; otherwise overage is billed hourly as a pending-payment order. Changes apply to licenses that have not reported usage yet.',
+    virtualRevealLogs: 'Virtual Product View Log',
+    virtualRevealLogsDesc:
+      'Every time the buyer viewed the delivered codes, useful as evidence in "code did not work" disputes.',
+    virtualRevealLogsEmpty: 'The buyer has not viewed the delivered content yet',
+    virtualRevealTime: 'Viewed At',
+    virtualRevealIp: 'IP Address',
+    virtualRevealAuth: 'Verification',
+    virtualRevealCount: 'Items',
+    virtualRevealUserAgent: 'User Agent',
+    orderActivity: 'Related Records',
+    orderActivityDesc: 'Refunds, shipments, deliveries, messages and tickets for this order.',
+    orderActivityEmpty: 'None',
+    orderActivityRefunds: 'Refunds',
+    orderActivityShipments: 'Shipments',
+    orderActivityDeliveries: 'Delivery Attempts',
+    orderActivityEmails: 'Emails',
+    orderActivitySms: 'SMS',
+    orderActivityTickets: 'Tickets',
+    orderActivityActionRefund: 'Refund issued',
+    orderActivityActionConfirmRefund: 'Refund confirmed',
+    orderActivityActionAssignTracking: 'Tracking number assigned',
+    orderActivityActionDeliverVirtualStock: 'Delivered manually',
+    orderActivityActionVirtualDeliveryFailed: 'Automatic delivery failed',
+    virtualRevealAuthNone: 'None',
+    virtualRevealAuthPassword: 'Password',
+    virtualRevealAuthOtp: 'Email code',
     savingText: 'Saving...',
     stockItemList: 'Stock Item List',
     totalRecordsCount: '{count} records in total',
@@ -3086,6 +4411,8 @@ export const enTranslations = {
     releaseSuccess: 'Released successfully',
     releaseFailed: 'Failed to release',
     importSuccessCount: 'Successfully imported {count} items',
+    importSummary:
+      'Imported {added} items, skipped {duplicates} duplicates and {invalid} invalid rows (first skipped line: {line})',
 
     // Serial Management
     totalSerials: 'Total Serials',
@@ -3173,6 +4500,20 @@ export const enTranslations = {
     inventoryLogs: 'Inventory Logs',
     inventorySource: 'Source',
     smsLogs: 'SMS Logs',
+    scheduledJobs: 'Scheduled Jobs',
+    scheduledJobsHint:
+      'Shared across all backend instances; each job runs once per interval. Current instance: {instance}',
+    scheduledJobName: 'Job',
+    scheduledJobInterval: 'Interval',
+    scheduledJobRunning: 'Running',
+    scheduledJobSuccess: 'Succeeded',
+    scheduledJobFailed: 'Failed',
+    scheduledJobRunningHere: 'Running on this instance',
+    scheduledJobNotRegistered: 'Not registered on this instance',
+    scheduledJobLastRun: 'Last Run',
+    scheduledJobNextRun: 'Next Run',
+    scheduledJobRuns: 'Runs / Failures',
+    scheduledJobLastError: 'Last Error',
     logsFilterSummary: '{count} filters are currently applied.',
     logsFilterHint: 'No extra filters are applied. Recent logs are shown.',
     logsSelectedEmails: '{count} email logs selected',
@@ -3198,6 +4539,8 @@ export const enTranslations = {
     retryCount: 'Retries',
     sentTime: 'Sent At',
     searchEmail: 'Search email',
+    emailCampaign: 'Campaign',
+    emailCampaignPlaceholder: 'e.g. order_recovery',
     resourceIdLabel: 'Resource ID',
     retryFailed: 'Retry Failed',
     emailRetryQueued: 'Emails re-queued for sending',
@@ -3373,6 +4716,29 @@ export const enTranslations = {
     testSms: 'Test SMS',
     testSmsSent: 'Test SMS sent, please check your phone',
     enterTestPhone: 'Please enter the test phone number',
+    messageConsole: 'Test Message Console',
+    messageConsoleDesc:
+      'Send a test email, SMS, webhook or push notification with the live configuration and inspect the raw provider response',
+    messageConsoleChannel: 'Channel',
+    messageConsoleChannelEmail: 'Email',
+    messageConsoleChannelSms: 'SMS',
+    messageConsoleChannelWebhook: 'Webhook',
+    messageConsoleChannelPush: 'Push notification',
+    messageConsoleTarget: 'Recipient',
+    messageConsoleTargetEmail: 'Email address',
+    messageConsoleTargetSms: 'Phone number',
+    messageConsoleTargetWebhook: 'Webhook URL',
+    messageConsoleTargetPush: 'Device token',
+    messageConsolePhoneCode: 'Country code',
+    messageConsoleSecret: 'Signing secret (optional)',
+    messageConsoleSend: 'Send test',
+    messageConsoleSuccess: 'Delivered',
+    messageConsoleFailed: 'Failed',
+    messageConsoleProvider: 'Provider',
+    messageConsoleStatus: 'HTTP status',
+    messageConsoleDuration: 'Duration',
+    messageConsoleResponse: 'Provider response',
+    messageConsoleNoResponse: 'No response body',
     twilioAccountSid: 'Account SID',
     twilioAuthToken: 'Auth Token',
     twilioFromNumber: 'From Number',
@@ -3382,7 +4748,17 @@ export const enTranslations = {
     customHeadersKeepHint: 'Leave blank to keep existing headers. Enter {} to clear them.',
     customHeadersConfiguredKeys: 'Configured header keys',
     customBodyTemplate: 'Request Body Template',
-    customBodyTemplateHint: 'Use {{phone}} and {{code}} as placeholders',
+    customBodyTemplateHint:
+      'Use {{phone}}, {{phone_code}}, {{code}} and {{message}} (localized SMS text) as placeholders',
+    smsMessageTemplates: 'Localized Message Text',
+    smsMessageTemplatesHint:
+      'Message text sent by Twilio and custom HTTP providers. The language is chosen from the user locale, then the phone country code, then the default language.',
+    smsDefaultLocale: 'Default Language',
+    smsCountryLocales: 'Country Code → Language',
+    smsCountryLocalesHint: 'JSON object, e.g. {"+86":"zh","+1":"en"}',
+    smsTemplateBodies: 'Message Templates',
+    smsTemplateBodiesHint:
+      'JSON object of language → event (login/register/reset_password/bind_phone/default) → text. Placeholders: {{code}}, {{app_name}}, {{event}}',
     saveSmsSettings: 'Save SMS Settings',
     // Email notification toggles
     emailNotificationToggles: 'Email Notification Toggles',
@@ -3399,6 +4775,8 @@ export const enTranslations = {
     orderShippedDesc: 'Notify user when order is shipped',
     orderCompleted: 'Order Completed',
     orderCompletedDesc: 'Notify user when order is completed',
+    orderAutoRemind: 'Auto-complete Reminder',
+    orderAutoRemindDesc: 'Remind user before a shipped order is completed automatically',
     orderCancelled: 'Order Cancelled',
     orderCancelledDesc: 'Notify user when order is cancelled',
     resubmitRequired: 'Resubmit Required',
@@ -3458,6 +4836,7 @@ export const enTranslations = {
     templateEventOrderPaid: 'Order Paid',
     templateEventOrderShipped: 'Order Shipped',
     templateEventOrderCompleted: 'Order Completed',
+    templateEventOrderAutoCompleteReminder: 'Order Auto-complete Reminder',
     templateEventOrderCancelled: 'Order Cancelled',
     templateEventOrderResubmit: 'Resubmit',
     templateEventTicketCreated: 'Ticket Created',
@@ -3478,6 +4857,22 @@ export const enTranslations = {
     requireEmailVerification: 'Require Email Verification',
     requireEmailVerificationHint:
       'Users must verify email after registration to log in. Requires SMTP configured.',
+    emailVerificationMode: 'Unverified Email Restriction',
+    emailVerificationModeHint:
+      'Applies when email verification is required. Stricter modes include looser restrictions.',
+    emailVerificationModeLogin: 'Block login',
+    emailVerificationModeCheckout: 'Allow login, block checkout',
+    emailVerificationModeVirtualReveal: 'Allow checkout, block virtual product reveal',
+    emailVerificationModeWarn: 'Warn only',
+    emailVerificationReminder: 'Verification Reminders',
+    emailVerificationReminderHint:
+      'Resend the verification email to unverified users at the scheduled times',
+    emailVerificationReminderHours: 'Reminder schedule (hours after registration, comma-separated)',
+    emailVerificationExempt: 'Exempt from Email Verification',
+    emailVerificationExemptHint:
+      'This user is not restricted by email verification and receives no reminders',
+    emailVerificationExemptUpdated: 'Email verification exemption updated',
+    emailVerificationExemptUpdateFailed: 'Failed to update email verification exemption',
     allowEmailLogin: 'Allow Email Login',
     allowEmailLoginHint:
       'Allow users to log in with email verification code. Requires SMTP enabled.',
@@ -3491,10 +4886,14 @@ export const enTranslations = {
     allowPhonePasswordReset: 'Allow Phone Password Reset',
     allowPhonePasswordResetHint:
       'Allow users to reset password via phone code. Requires SMS enabled.',
+    allowPasskeyLogin: 'Allow Passkey Login',
+    allowPasskeyLoginHint:
+      'Allow users to sign in with a registered passkey (fingerprint, face or device PIN). Requires the site URL to be set.',
     // Login settings categories
     loginCategoryPassword: 'Password & Registration',
     loginCategoryEmail: 'Email Verification',
     loginCategoryPhone: 'Phone (SMS)',
+    loginCategoryPasskey: 'Passkey',
     // Password policy
     passwordPolicy: 'Password Policy',
     passwordPolicyDesc: 'Set password complexity requirements',
@@ -3541,6 +4940,16 @@ export const enTranslations = {
     paymentInfoLimitHint: 'Limit request rate for payment-info / payment-card endpoints',
     paymentSelectLimit: 'Payment Selection Limit (per minute)',
     paymentSelectLimitHint: 'Limit request rate for select-payment endpoint',
+    rateLimitPolicies: 'Route Group Token Buckets',
+    rateLimitPoliciesHint:
+      'Token-bucket limits for login, order creation and ticket messages. Set rate to 0 to disable a policy.',
+    rateLimitPolicyLogin: 'Login',
+    rateLimitPolicyOrderCreate: 'Order creation',
+    rateLimitPolicyTicketMessage: 'Ticket messages',
+    rateLimitPolicyRate: 'Rate (tokens per period)',
+    rateLimitPolicyPeriod: 'Period (seconds)',
+    rateLimitPolicyBurst: 'Burst (bucket size)',
+    rateLimitPolicyKeys: 'Keys (ip/user/api_key)',
     // Email/SMS rate limit
     emailRateLimit: 'Email Rate Limit',
     emailRateLimitDesc: 'Limit emails sent per recipient',
@@ -3629,7 +5038,7 @@ export const enTranslations = {
     analyticsSettingsDesc: 'Configure data analytics feature. Disabling can reduce backend load.',
     enableAnalytics: 'Enable Data Analytics',
     enableAnalyticsHint:
-      'When enabled, the analytics page will query and display detailed statistics. Disabling will stop all analytics queries to reduce server pressure.',
+      'When enabled, the analytics page will query and display detailed statistics. Disabling will stop all analytics queries to reduce server pressure. Storefront events (page views, add to cart, checkout steps) are only recorded while enabled.',
     tabAnalytics: 'Analytics',
     analyticsDisabledTitle: 'Data Analytics Disabled',
     analyticsDisabledDesc:
@@ -3649,12 +5058,23 @@ export const enTranslations = {
     autoCloseHours: 'Auto-Close After (hours)',
     autoCloseHoursHint:
       'Tickets with no reply for this many hours will be automatically closed. Set 0 to disable.',
+    disputeEscalationDays: 'Dispute Escalation After (days)',
+    disputeEscalationDaysHint:
+      'Customers can escalate a ticket that is still unresolved after this many days to a platform dispute. Set 0 to disable.',
+    disputeEvidenceDays: 'Merchant Response Window (days)',
+    disputeEvidenceDaysHint:
+      'Time the merchant has to submit evidence. The dispute can be decided without a response afterwards.',
     ticketAttachmentSettings: 'Ticket Attachment Settings',
-    ticketAttachmentSettingsDesc: 'Configure image and voice upload limits for ticket messages',
+    ticketAttachmentSettingsDesc: 'Configure image, voice and file upload limits for ticket messages',
     enableImageUpload: 'Allow Image Upload',
     enableImageUploadHint: 'Users and admins can send images in ticket messages',
     enableVoiceUpload: 'Allow Voice Upload',
     enableVoiceUploadHint: 'Users and admins can send voice messages in tickets',
+    enableFileUpload: 'Allow File Attachments',
+    enableFileUploadHint:
+      'Users and admins can attach documents to ticket messages; files are stored privately',
+    maxFileSize: 'Max File Size (MB)',
+    allowedFileFormats: 'Allowed File Formats',
     maxImageSize: 'Max Image Size (MB)',
     maxVoiceSize: 'Max Voice Size (MB)',
     maxVoiceDuration: 'Max Voice Duration (seconds)',
@@ -3670,6 +5090,14 @@ export const enTranslations = {
     orderSettingsDesc: 'Configure order-related parameters',
     orderNoPrefix: 'Order Number Prefix',
     orderNoPrefixExample: 'Example: ORD20240101001',
+    orderNumberFormat: 'Order Number Format',
+    orderNumberFormatTimestamp: 'Timestamp (default)',
+    orderNumberFormatDateSequence: 'Date + daily sequence',
+    orderNumberFormatRandomChecksum: 'Random digits + check digit',
+    orderNumberFormatHint:
+      'Date sequence: ORD20240101000001; random: ORD4821937065 with a Luhn check digit. Applies to new orders only.',
+    orderNumberSequenceDigits: 'Daily Sequence Digits',
+    orderNumberRandomDigits: 'Random Digits',
     currency: 'Currency',
     currencyCNY: 'CNY - Chinese Yuan (¥)',
     currencyUSD: 'USD - US Dollar ($)',
@@ -3685,6 +5113,48 @@ export const enTranslations = {
     currencyHint: 'Currency unit for displaying order amounts',
     autoCancelHours: 'Auto-cancel Hours',
     autoCancelHoursHint: 'Unpaid orders auto-cancel after this duration. Set 0 to disable.',
+    draftCleanupRetentionDays: 'Draft Retention (days)',
+    draftCleanupRetentionDaysHint:
+      'Unsubmitted draft and need-resubmit orders are cleaned up after this many days. Paid orders are kept. 0 = keep forever',
+    draftCleanupAction: 'Draft Cleanup Action',
+    draftCleanupActionDelete: 'Delete order',
+    draftCleanupActionAnonymize: 'Remove personal data and cancel',
+    orderRecoveryTitle: 'Order Recovery Reminders',
+    orderRecoveryDesc:
+      'Remind customers to finish draft orders or pay pending orders before they are cancelled or cleaned up.',
+    orderRecoveryEnabled: 'Send recovery reminders',
+    orderRecoveryEnabledHint:
+      'Checked every 10 minutes. Orders already in payment polling and trial conversions are skipped.',
+    orderRecoveryIntervals: 'Reminder Schedule (hours)',
+    orderRecoveryIntervalsHint:
+      'Comma-separated hours after the order is created, e.g. 1, 24. Reminders that would land after expiry are not sent.',
+    orderRecoverySendSms: 'Also send SMS',
+    orderRecoverySendSmsHint: 'Requires SMS to be enabled and a phone number on the order',
+    orderRecoveryCampaign: 'Campaign',
+    orderRecoveryCampaignHint:
+      'Recorded on email logs and added to links as utm_campaign. Defaults to order_recovery',
+    draftCleanupPreview: 'Preview Cleanup',
+    draftCleanupPreviewHint:
+      'Uses the saved settings. Each run handles up to 100 orders; the preview lists exactly the orders the next run will touch.',
+    draftCleanupPreviewSummary:
+      '{count} order(s) will be affected ({action}), last updated before {cutoff}',
+    draftCleanupPreviewFailed: 'Failed to preview draft cleanup',
+    draftCleanupRunNow: 'Run Cleanup Now',
+    draftCleanupRunConfirm:
+      'Apply "{action}" to {count} draft order(s) now? This cannot be undone.',
+    draftCleanupRunSuccess: '{count} draft order(s) cleaned up',
+    draftCleanupRunFailed: 'Failed to clean up draft orders',
+    autoCompleteDays: 'Auto-complete Days',
+    autoCompleteDaysHint: 'Shipped orders without an open support ticket are completed automatically after this many days. Set 0 to disable.',
+    autoCompleteReminderDays: 'Reminder Days Before Auto-complete',
+    autoCompleteReminderDaysHint: 'Send a reminder email this many days before auto-completion. Set 0 to disable.',
+    autoCompleteDaysPhysical: 'Auto-complete Days (Physical Orders)',
+    autoCompleteDaysVirtual: 'Auto-complete Days (Virtual-only Orders)',
+    autoCompleteDaysInherit: 'Same as auto-complete days',
+    autoCompleteDaysByTypeHint:
+      'Orders that contain only virtual products use the virtual value; all other orders use the physical value. Leave empty to use Auto-complete Days, or set 0 to turn auto-complete off for that type.',
+    abandonReleaseMinutes: 'Abandoned Checkout Release (minutes)',
+    abandonReleaseMinutesHint: 'When a user leaves the payment page and does not return within this many minutes, the unpaid order is cancelled early and its reserved stock released. Orders already waiting on a payment method are skipped. Set 0 to disable.',
     maxPendingPaymentOrdersPerUser: 'Max Unpaid Orders Per User',
     maxPendingPaymentOrdersPerUserHint:
       'Users cannot create new orders after this limit is reached',
@@ -3763,12 +5233,60 @@ export const enTranslations = {
     invoiceCompanyPhone: 'Phone',
     invoiceCompanyEmail: 'Email',
     invoiceCompanyLogo: 'Logo URL',
+    invoiceCompanyLogoHint:
+      'Use asset:<name> to reference an uploaded template asset. PDFs embed it directly, so no external host is needed.',
+    templateAssets: 'Template Assets',
+    templateAssetsDesc:
+      'Logos, fonts and stylesheets for invoice, quote and email templates. Reference them with {{asset "name"}} in templates.',
+    templateAssetName: 'Asset name',
+    templateAssetNamePlaceholder: 'e.g. company-logo (defaults to file name)',
+    templateAssetSize: 'Size',
+    templateAssetUpload: 'Upload',
+    templateAssetUploaded: 'Template asset uploaded',
+    templateAssetUploadFailed: 'Failed to upload template asset',
+    templateAssetDeleted: 'Template asset deleted',
+    templateAssetDeleteFailed: 'Failed to delete template asset',
+    templateAssetDeleteConfirm:
+      'Delete this template asset? Templates referencing it will show nothing.',
+    templateAssetEmpty: 'No template assets uploaded yet',
+    templateAssetCopyReference: 'Copy reference',
+    templateAssetCopyUrl: 'Copy URL',
+    documentTypes: 'Document Types',
+    documentTypesDesc:
+      'Custom documents such as warranty cards, delivery notes or certificates of authenticity, rendered per order or per item with the invoice template engine.',
+    documentTypeCreate: 'New document type',
+    documentTypeKey: 'Key',
+    documentTypeDescription: 'Description',
+    documentTypeBinding: 'Generate per',
+    documentTypeBindingOrder: 'Order',
+    documentTypeBindingOrderItem: 'Order item',
+    documentTypeStatuses: 'Available for order statuses',
+    documentTypeSkus: 'Only for SKUs',
+    documentTypeSkusPlaceholder: 'Comma-separated, empty for all items',
+    documentTypeFields: 'Custom fields (one key=value per line)',
+    documentTypeTemplate: 'HTML Template',
+    documentTypeTemplateTip:
+      'Leave empty to use the built-in layout. Available variables: {{.DocumentName}}, {{.DocumentNo}}, {{.IssueDate}}, {{.OrderNo}}, {{.OrderDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerAddress}}, {{.Items}}, {{.Item}}, {{.TotalAmount}}, {{.Currency}}, {{.Fields.key}}, {{.CompanyName}}, {{.CompanyLogo}}, {{.FooterText}}, {{asset "name"}}',
+    documentTypeEnabled: 'Enabled',
+    documentTypeVisibleToUser: 'Visible to customers',
+    documentTypeAdminOnly: 'Admin only',
+    documentTypePreviewOrder: 'Preview with order no.',
+    documentTypePreviewItem: 'Item #',
+    documentTypePreview: 'Preview',
+    documentTypePreviewFailed: 'Failed to render preview',
+    documentTypeSaved: 'Document type saved',
+    documentTypeSaveFailed: 'Failed to save document type',
+    documentTypeDeleted: 'Document type deleted',
+    documentTypeDeleteFailed: 'Failed to delete document type',
+    documentTypeDeleteConfirm:
+      'Delete this document type? Customers will no longer be able to download it.',
+    documentTypeEmpty: 'No custom document types yet',
     invoiceTaxId: 'Tax ID',
     invoiceFooterText: 'Footer Text',
     invoiceFooterPlaceholder: 'e.g. Thank you for your business!',
     invoiceCustomTemplate: 'Custom HTML Template',
     invoiceCustomTemplateTip:
-      'Available variables: {{.CompanyName}}, {{.OrderNo}}, {{.InvoiceNo}}, {{.OrderDate}}, {{.CompletedDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerPhone}}, {{.CustomerAddress}}, {{.Items}}, {{.Subtotal}}, {{.DiscountAmount}}, {{.HasDiscount}}, {{.TotalAmount}}, {{.Currency}}, {{.FooterText}}, {{.AppName}}',
+      'Available variables: {{.CompanyName}}, {{.OrderNo}}, {{.InvoiceNo}}, {{.OrderDate}}, {{.CompletedDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerPhone}}, {{.CustomerAddress}}, {{.Items}}, {{.Subtotal}}, {{.DiscountAmount}}, {{.HasDiscount}}, {{.PaymentFee}}, {{.HasPaymentFee}}, {{.Adjustment}}, {{.HasAdjustment}}, {{.GiftCard}}, {{.HasGiftCard}}, {{.TotalAmount}}, {{.Currency}}, {{.FooterText}}, {{.AppName}}',
     formAndLinkSettings: 'Form & Link Settings',
     formAndLinkSettingsDesc: 'Configure form and magic link expiration',
     magicLinkExpiry: 'Magic Link Expiry (minutes)',
@@ -3931,6 +5449,408 @@ export const enTranslations = {
     featuredProduct: 'Featured',
     recommendedProduct: 'Recommended',
     autoDelivery: 'Auto Delivery',
+    productWaitingRoom: 'Waiting Room',
+    productWaitingRoomEnable: 'Require waiting room before ordering',
+    productWaitingRoomHint:
+      'Buyers must queue and receive a timed access token before they can place an order for this product.',
+    productWaitingRoomInactive: 'Waiting room is disabled in system config or Redis is unavailable',
+    productWaitingRoomQueued: '{count} in queue',
+    productWaitingRoomAdmitted: '{count} admitted',
+    productWaitingRoomUpdated: 'Waiting room updated',
+    productWaitingRoomUpdateFailed: 'Failed to update waiting room',
+    productTrial: 'Free Trial',
+    productTrialDays: 'Trial length (days)',
+    productTrialHint:
+      'Logged-in users can claim one time-limited license per product; requires auto delivery. Before the trial ends a pending order at the product price is created and the user is reminded, unpaid trials expire and the license stops validating. Set 0 to disable.',
+    productTrialUpdated: 'Trial settings updated',
+    productTrialUpdateFailed: 'Failed to update trial settings',
+    productTrialActiveCount: '{count} active',
+    productTrialConvertedCount: '{count} converted',
+    productTrialExpiredCount: '{count} expired',
+    productTrialRecent: 'Recent trials',
+    productTrialStatusActive: 'Active',
+    productTrialStatusConverted: 'Converted',
+    productTrialStatusExpired: 'Expired',
+    productTrialUser: 'User #{id}',
+    productTrialEnds: 'Ends {time}',
+    productOrderRateCap: 'Order Rate Limit',
+    productOrderRateCapEnable: 'Limit hourly orders per buyer',
+    productOrderRateCapHint:
+      'Caps how many orders each account, IP and device can place for this product per hour.',
+    productOrderRateCapInactive:
+      'Order rate limit is disabled in system config or Redis is unavailable',
+    productOrderRateCapPerUser: 'Per account: {limit}/h',
+    productOrderRateCapPerIP: 'Per IP: {limit}/h',
+    productOrderRateCapPerDevice: 'Per device: {limit}/h',
+    productOrderRateCapNoLimit: 'unlimited',
+    productOrderRateCapUpdated: 'Order rate limit updated',
+    productOrderRateCapUpdateFailed: 'Failed to update order rate limit',
+    refundRequestQueue: 'Refund requests',
+    refundRequestQueueDesc:
+      'Refund requests submitted by customers. Approving runs the payment method refund; the result is posted to the linked ticket.',
+    refundRequestUser: 'User',
+    refundRequestResolution: 'Resolution',
+    refundRequestResolutionFullRefund: 'Full refund',
+    refundRequestResolutionPartialRefund: 'Partial refund',
+    refundRequestResolutionReplacement: 'Replacement',
+    refundRequestResolutionOther: 'Other',
+    refundRequestReason: 'Reason',
+    refundRequestEvidence: 'Evidence',
+    refundRequestCreatedAt: 'Submitted At',
+    refundRequestTicket: 'Ticket #{ticketNo}',
+    refundRequestApprove: 'Approve',
+    refundRequestReject: 'Reject',
+    refundRequestApproveTitle: 'Approve refund request',
+    refundRequestApproveDesc: 'Order {orderNo} will be refunded through its payment method.',
+    refundRequestRejectTitle: 'Reject refund request',
+    refundRequestRejectDesc: 'The reason will be sent to the customer in the linked ticket.',
+    refundRequestNote: 'Note',
+    refundRequestNotePlaceholder: 'Shown to the customer in the ticket',
+    settlementReport: 'Settlement Report',
+    packingSlip: 'Packing Slip',
+    printTodayShipments: "Print Today's Shipments",
+    packingSlipFailed: 'Failed to generate packing slip',
+    settlementReportDesc:
+      'Paid orders converted to {currency} at the exchange rate captured when each order was paid',
+    settlementFromMonth: 'From month',
+    settlementToMonth: 'To month',
+    settlementMonth: 'Month',
+    settlementCurrency: 'Currency',
+    settlementGateway: 'Payment method',
+    settlementManualGateway: 'Marked paid manually',
+    settlementOrderCount: 'Orders',
+    settlementGross: 'Gross',
+    settlementRefunded: 'Refunded',
+    settlementBaseAmount: 'Base amount',
+    settlementNetBaseAmount: 'Net (base)',
+    settlementUnconverted: '{count} without rate',
+    settlementNoData: 'No paid orders in this period',
+    settlementExport: 'Export CSV',
+    settlementExportSummary: 'Monthly summary',
+    settlementExportOrders: 'Per order',
+    settlementExportSuccess: 'Settlement report exported',
+    vendorManagementTitle: 'Vendors & Settlement',
+    vendorManagementDesc:
+      'Vendor products earn commission per sale; unsettled ledger entries roll up into payout statements',
+    vendorTabVendors: 'Vendors',
+    vendorTabStatements: 'Payout Statements',
+    vendorCreate: 'Add Vendor',
+    vendorEdit: 'Edit Vendor',
+    vendorName: 'Vendor',
+    vendorContactEmail: 'Contact email',
+    vendorCommission: 'Commission',
+    vendorCommissionPercent: 'Platform commission (%)',
+    vendorPayoutAccount: 'Payout account',
+    vendorRemark: 'Remarks',
+    vendorProducts: 'Products',
+    vendorUnsettled: 'Unsettled payable',
+    vendorStatus: 'Status',
+    vendorStatusActive: 'Active',
+    vendorStatusDisabled: 'Disabled',
+    vendorSaved: 'Vendor saved',
+    vendorSaveFailed: 'Failed to save vendor',
+    vendorLedger: 'Ledger - {name}',
+    vendorLedgerDate: 'Date',
+    vendorLedgerType: 'Type',
+    vendorLedgerSale: 'Sale',
+    vendorLedgerRefund: 'Refund reversal',
+    vendorLedgerAdjustment: 'Adjustment',
+    vendorLedgerReference: 'Reference',
+    vendorLedgerNet: 'Payable',
+    vendorLedgerSettled: 'Statement',
+    vendorAdjustment: 'Adjust payable - {name}',
+    vendorAdjustmentHint:
+      'Positive amounts increase the payout, negative amounts deduct from it. The entry is included in the next statement.',
+    vendorAdjustmentCurrency: 'Currency',
+    vendorAdjustmentAmount: 'Amount',
+    vendorAdjustmentNote: 'Reason',
+    vendorAdjustmentCreated: 'Adjustment recorded',
+    vendorAdjustmentFailed: 'Failed to record adjustment',
+    vendorGenerateStatements: 'Generate Statements',
+    vendorGenerateHint:
+      'Creates one statement per vendor and currency for all unsettled entries recorded before the end date (exclusive), including refund reversals of previously settled orders.',
+    vendorPeriodStart: 'Period start',
+    vendorPeriodEnd: 'Period end (exclusive)',
+    vendorStatementsGenerated: '{count} statement(s) generated',
+    vendorStatementsGenerateFailed: 'Failed to generate statements',
+    vendorStatementPeriod: 'Period',
+    vendorStatementSales: 'Sales',
+    vendorStatementCommission: 'Commission',
+    vendorStatementRefunds: 'Refunds',
+    vendorStatementAdjustments: 'Adjustments',
+    vendorStatementPayout: 'Payout',
+    vendorStatementPending: 'Pending payout',
+    vendorStatementPaid: 'Paid',
+    vendorMarkPaid: 'Mark as Paid',
+    vendorMarkPaidConfirm: 'Confirm that {amount} has been paid to {name}.',
+    vendorPaymentReference: 'Payment reference',
+    vendorStatementMarkedPaid: 'Statement marked as paid',
+    vendorStatementMarkPaidFailed: 'Failed to update statement',
+    productVendor: 'Vendor',
+    productVendorPlatform: 'Platform (no vendor)',
+    productVendorHint:
+      'Sales of this product are credited to the vendor minus the platform commission once paid. Existing orders keep their original vendor.',
+    productVendorUpdated: 'Product vendor updated',
+    productVendorUpdateFailed: 'Failed to update product vendor',
+    productRevenueRecognition: 'Revenue Recognition',
+    productServicePeriodMonths: 'Service period (months)',
+    productRevenueHint:
+      'Subscriptions and prepaid credit are recognized evenly over the service period, starting in the month of payment. Only affects new orders.',
+    productRevenueUpdated: 'Revenue recognition updated',
+    productRevenueUpdateFailed: 'Failed to update revenue recognition',
+    revenueRecognition: 'Revenue Recognition',
+    revenueRecognitionDesc:
+      'Monthly deferred revenue roll-forward for subscriptions and prepaid credit',
+    revenueType: 'Recognition',
+    revenueTypeAtSale: 'At sale (month of payment)',
+    revenueTypeSubscription: 'Subscription',
+    revenueTypePrepaidCredit: 'Prepaid credit',
+    revenueOpening: 'Opening deferred',
+    revenueBilled: 'New deferred',
+    revenueRecognized: 'Recognized',
+    revenueReleased: 'Released by refunds',
+    revenueClosing: 'Closing deferred',
+    revenueNoData: 'No deferred revenue in this period',
+    revenueExportSuccess: 'Revenue recognition report exported',
+    revenueBackfill: 'Backfill schedules',
+    revenueBackfillHint:
+      'Create schedules for orders paid since the start month that have none yet',
+    revenueBackfillSuccess: '{count} schedule(s) created',
+    revenueBackfillFailed: 'Failed to backfill revenue schedules',
+    manualOrder: 'New Order',
+    manualOrderTitle: 'New Manual Order',
+    manualOrderDesc: 'Record phone, chat or in-person orders on behalf of a customer',
+    manualOrderCustomer: 'Customer',
+    manualOrderExistingUser: 'Existing user',
+    manualOrderGuest: 'Guest',
+    manualOrderSearchUser: 'Search users by email or name',
+    manualOrderChangeUser: 'Change',
+    manualOrderSelectUser: 'Please select a user',
+    manualOrderGuestEmail: 'Guest email',
+    manualOrderGuestEmailRequired: 'Please enter the guest email',
+    manualOrderReceiverName: 'Receiver name',
+    manualOrderReceiverPhone: 'Phone',
+    manualOrderShippingHint:
+      'For physical items without a full address, the customer receives a shipping form link',
+    manualOrderSearchProduct: 'Search products by name or SKU',
+    manualOrderPriceHint: 'Leave the unit price empty to use the current product price',
+    manualOrderSubtotal: 'Subtotal',
+    manualOrderDiscount: 'Manual discount',
+    manualOrderDiscountInvalid: 'Discount must be between 0 and the subtotal',
+    manualOrderTotal: 'Total',
+    manualOrderPayment: 'Payment',
+    manualOrderPaymentNone: 'Leave pending payment',
+    manualOrderPaymentMarkPaid: 'Mark as paid',
+    manualOrderPaymentLink: 'Send payment link',
+    manualOrderPaymentActionFailed:
+      'Order created, but the payment step failed. Retry it from the order detail page',
+    manualOrderRemark: 'Customer remark',
+    manualOrderAdminRemark: 'Admin remark',
+    manualOrderSubmit: 'Create order',
+    manualOrderCreateAnother: 'Create another',
+    businessAccountManagementTitle: 'Business Accounts & Net Terms',
+    businessAccountManagementDesc:
+      'Approved accounts can pay on invoice within their credit limit; overdue invoices trigger reminders and an automatic credit hold',
+    businessAccountTabAccounts: 'Accounts',
+    businessAccountTabInvoices: 'Invoices',
+    businessAccountSearchPlaceholder: 'Search company, tax ID or email',
+    businessAccountCompany: 'Company',
+    businessAccountStatus: 'Status',
+    businessAccountStatusPending: 'Pending review',
+    businessAccountStatusApproved: 'Approved',
+    businessAccountStatusRejected: 'Rejected',
+    businessAccountStatusSuspended: 'Suspended',
+    businessAccountHoldOverdue: 'Hold: overdue',
+    businessAccountHoldManual: 'Hold: manual',
+    businessAccountTerms: 'Terms',
+    businessAccountTermsValue: '{limit} · Net {days}',
+    businessAccountOutstanding: 'Outstanding',
+    businessAccountOverdueAmount: '{amount} overdue',
+    businessAccountAvailable: 'Available',
+    businessAccountReview: 'Review application - {name}',
+    businessAccountTaxId: 'Tax ID',
+    businessAccountBillingEmail: 'Billing email',
+    businessAccountBillingAddress: 'Billing address',
+    businessAccountCurrency: 'Currency',
+    businessAccountCreditLimit: 'Credit limit',
+    businessAccountTermsDays: 'Terms (days)',
+    businessAccountReviewNote: 'Review note',
+    businessAccountApprove: 'Approve',
+    businessAccountReject: 'Reject',
+    businessAccountApproved: 'Business account approved',
+    businessAccountRejected: 'Application rejected',
+    businessAccountReviewFailed: 'Failed to review application',
+    businessAccountEdit: 'Edit terms - {name}',
+    businessAccountCreditHold: 'Credit hold',
+    businessAccountCreditHoldHint:
+      'Blocks new orders on terms. Overdue holds are lifted automatically once overdue invoices are settled.',
+    businessAccountSuspended: 'Suspended',
+    businessAccountSuspendedHint: 'Disables net terms for this account until reactivated.',
+    businessAccountSaved: 'Business account updated',
+    businessAccountSaveFailed: 'Failed to update business account',
+    businessAccountStatement: 'Statement - {name}',
+    codManagementTitle: 'Cash on Delivery Collections',
+    codManagementDesc:
+      'Record the cash collected by couriers for shipped orders and track outstanding balances',
+    codSearchPlaceholder: 'Search order number',
+    codOrder: 'Order',
+    codAmount: 'Amount due',
+    codCollectedAmount: 'Collected',
+    codPlacedAt: 'Placed',
+    codStatusOutstanding: 'Outstanding',
+    codStatusCollected: 'Collected',
+    codStatusVoid: 'Void',
+    codOutstanding: 'Outstanding ({count})',
+    codCollectedTotal: 'Collected ({count})',
+    codShortfall: 'Shortfall',
+    codAgingDays: '{label} days',
+    codConfirm: 'Record Collection',
+    codConfirmHint: 'Amount due for order {order}: {amount}. Enter the amount the courier remitted.',
+    codReference: 'Remittance reference',
+    codConfirmed: 'Collection recorded',
+    codUpdateFailed: 'Failed to update collection',
+    codVoid: 'Void Collection',
+    codVoidHint:
+      'Use this when the parcel was refused or returned. The order itself is not changed.',
+    codVoidReason: 'Reason',
+    codVoided: 'Collection voided',
+    netTermsOverdueOnly: 'Overdue only',
+    netTermsInvoiceNo: 'Invoice',
+    netTermsInvoiceAmount: 'Amount',
+    netTermsInvoiceIssued: 'Issued',
+    netTermsInvoiceDue: 'Due',
+    netTermsInvoiceOpen: 'Open',
+    netTermsInvoicePaid: 'Paid',
+    netTermsInvoiceVoid: 'Void',
+    netTermsInvoiceOverdue: 'Overdue',
+    netTermsDunningSent: '{count} reminder(s) sent',
+    netTermsMarkPaid: 'Record Payment',
+    netTermsMarkPaidConfirm: 'Confirm that {amount} has been received for invoice {invoice}.',
+    netTermsPaymentReference: 'Payment reference',
+    netTermsInvoiceMarkedPaid: 'Payment recorded',
+    netTermsInvoiceUpdateFailed: 'Failed to update invoice',
+    netTermsVoidInvoice: 'Void Invoice',
+    netTermsVoidHint:
+      'Voiding releases the credit without recording a payment. The order itself is not cancelled or refunded.',
+    netTermsVoidReason: 'Reason',
+    netTermsInvoiceVoided: 'Invoice voided',
+    netTermsPeriodStart: 'From',
+    netTermsPeriodEnd: 'To (inclusive)',
+    netTermsExportStatement: 'Export CSV',
+    netTermsOpeningBalance: 'Opening balance',
+    netTermsInvoiced: 'Invoiced',
+    netTermsPaid: 'Paid',
+    netTermsVoided: 'Voided',
+    netTermsClosingBalance: 'Closing balance',
+    netTermsLineDate: 'Date',
+    netTermsLineType: 'Type',
+    netTermsLineInvoice: 'Invoice',
+    netTermsLinePayment: 'Payment',
+    netTermsLineVoid: 'Void',
+    netTermsLineBalance: 'Balance',
+    accountingExport: 'Accounting Export',
+    accountingExportDesc:
+      'Export invoice, payment and refund journals for QuickBooks Online or Xero, or push them directly',
+    accountingFromDate: 'From',
+    accountingToDate: 'To',
+    accountingFormat: 'Format',
+    accountingFormatJournal: 'Journal CSV (generic)',
+    accountingFormatQuickBooks: 'QuickBooks Online journal import',
+    accountingFormatXero: 'Xero manual journal import',
+    accountingDownload: 'Download CSV',
+    accountingExportSuccess: 'Accounting journals exported',
+    accountingExportSkipped:
+      '{count} foreign-currency journals were skipped because no exchange rate was recorded at payment',
+    accountingPushNow: 'Push Now',
+    accountingPushing: 'Pushing...',
+    accountingPushResult: 'Pushed {pushed} journals, {failed} failed',
+    accountingPushFailed: 'Failed to push accounting journals',
+    accountingRuns: 'Push History',
+    accountingRunsHint:
+      'Journals are pushed daily when a provider is configured. Failed journals are retried on the next run.',
+    accountingNoRuns: 'No push history yet',
+    accountingRunStartedAt: 'Started At',
+    accountingProvider: 'Accounting System',
+    accountingRunTrigger: 'Trigger',
+    accountingTriggerManual: 'Manual',
+    accountingTriggerScheduled: 'Scheduled',
+    accountingRunStatus: 'Status',
+    accountingRunCompleted: 'Completed',
+    accountingRunPartial: 'Partially failed',
+    accountingRunFailed: 'Failed',
+    accountingRunCounts: 'Pushed / Failed / Skipped',
+    accountingRunError: 'Error',
+    refundRequestApproved: 'Refund request approved',
+    refundRequestRejected: 'Refund request rejected',
+    refundRequestReviewFailed: 'Failed to review refund request',
+    partialRefundQueue: 'Item refunds',
+    partialRefundQueueDesc:
+      'Item refunds awaiting review or waiting to be returned through the payment method.',
+    partialRefundTitle: 'Item refunds',
+    partialRefundDesc:
+      'Refund selected items. Unshipped items release their reserved stock once refunded.',
+    partialRefundItems: 'Items',
+    partialRefundAmount: 'Amount',
+    partialRefundAmountPlaceholder: 'Leave empty to use the price paid',
+    partialRefundReason: 'Reason',
+    partialRefundStatus: 'Status',
+    partialRefundCreatedAt: 'Requested At',
+    partialRefundStatusRequested: 'Requested',
+    partialRefundStatusApproved: 'Approved',
+    partialRefundStatusRefunded: 'Refunded',
+    partialRefundStatusRejected: 'Rejected',
+    partialRefundPaymentPending: 'Awaiting payment provider',
+    partialRefundApprove: 'Approve',
+    partialRefundReject: 'Reject',
+    partialRefundIssue: 'Refund',
+    partialRefundApproveTitle: 'Approve item refund',
+    partialRefundRejectTitle: 'Reject item refund',
+    partialRefundIssueTitle: 'Issue item refund',
+    partialRefundActionDesc: 'Order {orderNo}, refund amount {amount}.',
+    partialRefundNote: 'Note',
+    partialRefundCreate: 'Refund items',
+    partialRefundCreateDesc:
+      'The refund is created for review and still needs to be approved and issued.',
+    partialRefundCreated: 'Item refund created',
+    partialRefundCreateFailed: 'Failed to create item refund',
+    partialRefundApproved: 'Item refund approved',
+    partialRefundRejected: 'Item refund rejected',
+    partialRefundIssued: 'Item refund issued',
+    partialRefundActionFailed: 'Failed to process item refund',
+    priceAdjustmentQueue: 'Price adjustments',
+    priceAdjustmentQueueDesc:
+      'Price adjustments above the approval threshold, waiting for another administrator.',
+    priceAdjustmentTitle: 'Price adjustments',
+    priceAdjustmentDesc:
+      'Discount or override the amount due. Large reductions are applied after another administrator approves them.',
+    priceAdjustmentType: 'Type',
+    priceAdjustmentTypeDiscount: 'Discount',
+    priceAdjustmentTypeOverride: 'Override',
+    priceAdjustmentChange: 'Amount due',
+    priceAdjustmentReason: 'Reason',
+    priceAdjustmentStatus: 'Status',
+    priceAdjustmentCreatedAt: 'Requested At',
+    priceAdjustmentStatusPending: 'Awaiting approval',
+    priceAdjustmentStatusApplied: 'Applied',
+    priceAdjustmentStatusRejected: 'Rejected',
+    priceAdjustmentRequestedBy: 'Requested by admin #{id}',
+    priceAdjustmentApprove: 'Approve',
+    priceAdjustmentReject: 'Reject',
+    priceAdjustmentApproveTitle: 'Approve price adjustment',
+    priceAdjustmentRejectTitle: 'Reject price adjustment',
+    priceAdjustmentActionDesc: 'Order {orderNo}: {before} → {after}.',
+    priceAdjustmentNote: 'Note',
+    priceAdjustmentCreate: 'Adjust price',
+    priceAdjustmentCreateDesc:
+      'The payment fee stays on the order. The customer must choose a payment method again.',
+    priceAdjustmentDiscountAmount: 'Discount amount',
+    priceAdjustmentNewTotal: 'New amount due',
+    priceAdjustmentApplied: 'Price adjusted',
+    priceAdjustmentRequested: 'Price adjustment submitted for approval',
+    priceAdjustmentCreateFailed: 'Failed to adjust price',
+    priceAdjustmentApproved: 'Price adjustment approved',
+    priceAdjustmentRejected: 'Price adjustment rejected',
+    priceAdjustmentActionFailed: 'Failed to process price adjustment',
     sortOrder: 'Sort Order',
     remarkLabel: 'Remarks',
     virtualStockManageBtn: 'Virtual Inventory',
@@ -4104,6 +6024,12 @@ export const enTranslations = {
     invAlertEmail: 'Alert Email',
     invNotes: 'Notes',
     invEnableConfig: 'Enable this inventory config',
+    invFlashSale: 'Flash sale mode',
+    invFlashSaleDesc:
+      'Reservations use atomic Redis counters and are written back to the database asynchronously. Orders fail immediately once sold out.',
+    invFlashSaleStatus: 'Redis remaining: {remaining}, pending database sync: {pending}',
+    invFlashSaleInactive:
+      'Flash sale mode is disabled in the server config or Redis is unavailable; reservations use the database',
     invSaving: 'Saving...',
     invSaveChanges: 'Save Changes',
     invAdjustTitle: 'Adjust Stock (Restock / Audit)',
@@ -4206,6 +6132,20 @@ export const enTranslations = {
     pmPackageImportedSuccess: 'Payment package imported',
     pmPackageMissingRequiredConfig: 'Fill the required config field first: {field}',
     pmPackageImportedBadge: 'Package',
+    pmFeeEdit: 'Fee rules',
+    pmFeeTitle: 'Fee rules · {name}',
+    pmFeeDesc:
+      'Applied to the order total when a customer selects this payment method and shown as a separate line on the order and invoice.',
+    pmFeeRate: 'Percentage (%)',
+    pmFeeFixed: 'Fixed amount',
+    pmFeeHint:
+      'Use positive values for a surcharge and negative values for a discount, e.g. 3 or -1.',
+    pmFeeRetainedOnRefund: 'Keep surcharge on refund',
+    pmFeeRetainedOnRefundHint: 'Refunds return the order total minus the surcharge',
+    pmFeePreview: 'Preview',
+    pmFeeNone: 'No fee',
+    pmFeeUpdated: 'Fee rules updated',
+    pmFeeUpdateFailed: 'Failed to update fee rules',
     pmPackageSummary: 'Package Summary',
     pmPackageVersion: 'Package Version',
     pmPackageEntry: 'Entry Script',
@@ -4418,6 +6358,91 @@ export const enTranslations = {
     copyAntiCounterfeitCode: 'Copy anti-counterfeit code',
   },
 
+  orderTracking: {
+    title: 'Track Your Order',
+    desc:
+      'Enter your order number and the email used at checkout to see its status without signing in.',
+    orderNo: 'Order Number',
+    email: 'Email',
+    submit: 'Track',
+    lookupFailed: 'Lookup failed, please try again later',
+    loadingLink: 'Loading order…',
+    status: 'Status',
+    trackingNo: 'Tracking Number',
+    estimatedDelivery: 'Estimated Delivery',
+    items: 'Items',
+    progress: 'Progress',
+    searchAgain: 'Track another order',
+    checkpoints: {
+      placed: 'Placed',
+      paid: 'Paid',
+      shipped: 'Shipped',
+      completed: 'Completed',
+      refunded: 'Refunded',
+    },
+    bizError: {
+      'order_tracking.disabled': 'Order tracking is not available',
+      'order_tracking.notFound': 'No order matches the order number and email',
+      'order_tracking.linkInvalid':
+        'The tracking link is invalid or has expired, please look up the order by email',
+      'order_tracking.tooManyAttempts':
+        'Too many failed attempts, please try again in {minutes} minutes',
+    },
+  },
+
+  paymentLink: {
+    title: 'Pay for Your Order',
+    desc: 'Choose a payment method to pay for this order. No sign-in required.',
+    loading: 'Loading order…',
+    loadFailed: 'Failed to load the payment link',
+    missingParams:
+      'This payment link is incomplete, please open the full link from your email or message',
+    orderNo: 'Order Number',
+    amountDue: 'Amount Due',
+    items: 'Items',
+    paymentMethod: 'Payment Method',
+    selectMethod: 'Select a payment method',
+    changeMethod: 'Change payment method',
+    confirmMethod: 'Continue to pay',
+    selectFailed: 'Failed to select payment method',
+    noMethods: 'No payment methods are available, please contact the merchant',
+    methodFee: 'Fee: {fee}',
+    paymentCardPending: 'Payment instructions are being prepared, please refresh shortly',
+    awaitingConfirmation: 'This page updates automatically once your payment is confirmed.',
+    paid: 'Payment received, thank you!',
+    paidDesc: 'The merchant will process the order shortly.',
+    notPayable: 'This order is no longer awaiting payment.',
+    refresh: 'Refresh',
+    // 管理员与用户生成付款链接
+    create: 'Payment Link',
+    share: 'Share Payment Link',
+    dialogTitle: 'Payment Link',
+    dialogDesc:
+      'Create a signed link so the buyer can pay this order without signing in. Anyone with the link can pay.',
+    sendEmail: 'Send by email',
+    sendSms: 'Send by SMS',
+    emailPlaceholder: 'Defaults to the order email',
+    phonePlaceholder: 'Defaults to the receiver phone',
+    generate: 'Create Link',
+    generating: 'Creating…',
+    link: 'Link',
+    expiresAt: 'Expires {time}',
+    copy: 'Copy Link',
+    copied: 'Payment link copied',
+    created: 'Payment link created',
+    createFailed: 'Failed to create payment link',
+    emailSent: 'Payment link emailed',
+    smsSent: 'Payment link sent by SMS',
+    emailNotSent: 'The email could not be sent, please check the recipient and email settings',
+    smsNotSent: 'The SMS could not be sent, please check the phone and SMS provider',
+    bizError: {
+      'payment_link.disabled': 'Payment links are not available',
+      'payment_link.invalid':
+        'The payment link is invalid or has expired, please ask for a new one',
+      'payment_link.orderNotPayable': 'This order is no longer awaiting payment',
+    },
+  },
+
   shippingPublic: {
     missingFormToken: 'Missing form token',
     formLoadFailed: 'Failed to load form',
@@ -4482,9 +6507,19 @@ export const enTranslations = {
     profile: 'Profile',
     accountSettings: 'Account Settings',
     profilePreferences: 'Preferences',
+    businessAccount: 'Business Account',
+    quotes: 'My Quotes',
+    adminPolicies: 'Terms & Privacy',
+    consents: 'Terms & Privacy',
+    security: 'Two-factor authentication',
+    spendingControls: 'Spending Controls',
+    organization: 'Organization',
+    apiTokens: 'API Tokens',
     tickets: 'Support Center',
     ticketDetail: 'Ticket Detail',
     serialVerify: 'Serial Verification',
+    orderTracking: 'Track Order',
+    paymentLink: 'Pay Order',
     shippingForm: 'Shipping Info',
     adminDashboard: 'Dashboard',
     adminProducts: 'Product Management',
@@ -4500,15 +6535,30 @@ export const enTranslations = {
     adminUsers: 'User Management',
     adminUserDetail: 'User Detail',
     adminTickets: 'Ticket Management',
+    adminTicketPerformance: 'Agent Performance',
+    adminSettlementReport: 'Settlement Report',
+    adminNewOrder: 'New Order',
+    adminRevenueRecognition: 'Revenue Recognition',
+    adminAccountingExport: 'Accounting Export',
+    adminVendors: 'Vendors',
+    adminBusinessAccounts: 'Business Accounts',
+    adminCODCollections: 'Cash on Delivery',
     adminSettings: 'System Settings',
     adminLogs: 'System Logs',
     adminApiKeys: 'API Key Management',
     adminAnalytics: 'Analytics',
+    adminSkuSales: 'SKU Sales',
     adminPaymentMethods: 'Payment Methods',
     adminSerials: 'Serial Management',
     adminPromoCodes: 'Promo Code Management',
+    adminGiftCards: 'Gift Cards',
+    adminQuotes: 'Quotes',
+    adminQuoteEdit: 'Edit Quote',
+    adminReturns: 'Returns',
+    adminDisputes: 'Disputes',
     adminPromoCodeNew: 'New Promo Code',
     adminPromoCodeEdit: 'Edit Promo Code',
+    adminTrash: 'Trash',
     knowledge: 'Knowledge Base',
     knowledgeArticle: 'Article Detail',
     announcements: 'Announcements',
@@ -4517,6 +6567,11 @@ export const enTranslations = {
     adminKnowledgeArticleNew: 'New Article',
     adminKnowledgeArticleEdit: 'Edit Article',
     adminAnnouncements: 'Announcement Management',
+    adminSiteBanners: 'Site Banners',
+    adminModeration: 'Content Moderation',
+    adminBlocklist: 'Blocklist',
+    adminInboundWebhooks: 'Inbound Webhooks',
+    adminRisk: 'Risk Review',
     adminMarketing: 'Marketing Management',
     adminPlugins: 'Plugin Management',
     adminPluginObservability: 'Plugin Observability',
@@ -4579,6 +6634,7 @@ export const enTranslations = {
     deleteFailed: 'Delete failed: {msg}',
     batchDeleteSuccess: 'Successfully deleted {n} stock items',
     copiedToClipboard: 'Copied to clipboard',
+    revealFailed: 'Failed to reveal content',
     pleaseEnterContent: 'Please enter stock content',
     pleaseSelectFile: 'Please select a file',
     unsupportedFormat: 'Only .xlsx, .xls, .csv, .txt formats are supported',
@@ -4736,6 +6792,14 @@ export const enTranslations = {
       'inventory.skuAlreadyExists': 'SKU already exists',
       'inventory.attributesInvalid': 'Inventory attributes are invalid',
       'inventory.notFound': 'Inventory record does not exist',
+      'inventory.snapshotDateInvalid': 'Date must be in YYYY-MM-DD format',
+      'inventory.snapshotRangeInvalid': 'The start date must not be later than the end date',
+      'inventory.snapshotNotFound': 'No stock snapshot exists on or before {date}',
+      'inventory.lowStockSnoozeInvalid': 'Snooze duration must be between 1 minute and 30 days',
+      'inventory.lowStockSourceInvalid': 'Source must be physical or virtual',
+      'inventory.lowStockAlertNotFound': 'Inventory not found',
+      'inventory.lowStockAlertsDisabled':
+        'Low-stock alerts are not enabled in the server configuration',
       'inventory.hasSalesOrReservations':
         'This inventory record has sales or reservations and cannot be deleted',
       'inventory.specUnavailable': 'This specification is unavailable',
@@ -4746,6 +6810,8 @@ export const enTranslations = {
       'inventory.availableExceedsStock': 'Available quantity cannot exceed total stock',
       'inventory.adjustedStockNegative': 'Adjusted inventory cannot be negative',
       'inventory.adjustedAvailableNegative': 'Adjusted available quantity cannot be negative',
+      'inventory.reconciliationRunning': 'A stock reconciliation is already running',
+      'inventory.reconciliationRunNotFound': 'Stock reconciliation run not found',
     },
   },
 
@@ -4760,6 +6826,10 @@ export const enTranslations = {
       'binding.noMatchingInventory': 'No matching inventory configuration found',
       'binding.noFixedInventory': 'This product has no inventory configured with fixed attributes',
       'binding.specUnavailable': 'This specification is unavailable',
+      'binding.rebindSameInventory': 'The binding already points to this inventory',
+      'binding.rebindInventoryInactive': 'The target inventory is disabled',
+      'binding.rebindOpenOrders':
+        '{count} open orders ({quantity} units) still hold stock reserved from the current inventory',
     },
   },
 
@@ -4783,12 +6853,23 @@ export const enTranslations = {
         'This virtual inventory still has product bindings and cannot be deleted',
       'virtual_inventory.importEmptyFile': 'The import file is empty',
       'virtual_inventory.importNoValidData': 'No valid data found to import',
+      'virtual_inventory.importTooManyRows': 'Too many rows, import at most {max} per file',
+      'virtual_inventory.restockStaticOnly': 'Auto restock is only available for static inventories',
+      'virtual_inventory.restockNotConfigured': 'Auto restock is not configured for this inventory',
+      'virtual_inventory.restockBusy': 'A restock just ran, please try again in a minute',
+      'virtual_inventory.restockCapReached': 'The {period} spend cap has been reached, purchases are paused',
+      'virtual_inventory.restockPolicyInvalid': 'Invalid restock setting: {field}',
+      'virtual_inventory.restockScriptInvalid': 'Supplier script error: {error}',
+      'virtual_stock.resaleCodeInvalid': 'Enter a code of at most 1000 characters',
       'virtual_inventory.stockDeleteStatusInvalid':
         'Only available or reserved stock can be deleted',
       'virtual_inventory.stockItemNotFound': 'Stock item not found',
+      'virtual_inventory.notFound': 'Virtual inventory not found',
       'virtual_inventory.stockItemUnavailable': 'Stock item is not available for reservation',
       'virtual_inventory.stockItemNotReserved': 'Stock item is not currently reserved',
       'virtual_inventory.scriptRequired': 'Script content is required',
+      'virtual_inventory.dryRunScriptOnly': 'Only script type virtual inventory can be dry-run',
+      'virtual_inventory.dryRunQuantityTooLarge': 'Dry-run quantity cannot exceed {max}',
       'virtual_inventory.manualImportUnsupported':
         'Script type inventory does not support manual stock import',
       'virtual_inventory.manualCreateUnsupported':
@@ -4800,6 +6881,17 @@ export const enTranslations = {
         'No virtual inventory is bound to this product yet. Please bind one first',
       'virtual_inventory.noPendingScriptStock':
         'There is no pending script virtual stock to mark as shipped',
+      'virtual_inventory.scriptRevisionNotFound': 'Script revision not found',
+      'virtual_inventory.scriptRevisionNotPending': 'This script revision has already been {status}',
+      'virtual_inventory.scriptRevisionSelfApproval':
+        'A script change must be approved by a different administrator',
+      'virtual_inventory.scriptRevisionStale':
+        'The active script has changed since this revision was submitted. Please submit it again',
+      'script_execution.notFound': 'Script execution not found',
+      'script_execution.notFailed': 'Only failed script executions can be replayed',
+      'script_execution.alreadyReplayed': 'This script execution has already been replayed',
+      'virtual_inventory.holdNotFound': 'Virtual stock hold not found or already released',
+      'virtual_inventory.holdExpired': 'Virtual stock hold has expired, please try again',
     },
   },
 
@@ -4867,6 +6959,61 @@ export const enTranslations = {
     relatedOrders: 'Related Orders',
     relatedOrdersHint: 'Orders that used this promo code',
     viewRelatedOrders: 'View Related Orders',
+    campaigns: 'Bulk Campaigns',
+    campaignsDesc:
+      'Generate a batch of codes from a prefix and pattern with shared discount, usage limits and validity window.',
+    campaignsEmpty: 'No campaigns yet',
+    newCampaign: 'New Campaign',
+    campaignPrefix: 'Prefix',
+    campaignPattern: 'Pattern',
+    campaignPatternHint: 'X = letter or digit, 9 = digit, A = letter; - and _ are kept as-is',
+    campaignCount: 'Number of Codes',
+    campaignUsesPerCode: 'Uses per Code',
+    campaignPerUserLimit: 'Per-Customer Limit',
+    campaignPerUserLimitHint: 'Times each customer can use a code, 0 for unlimited',
+    campaignStartsAt: 'Starts At',
+    campaignValidity: 'Validity',
+    campaignGenerate: 'Generate Codes',
+    campaignCreated: 'Generated {count} codes',
+    campaignCreateFailed: 'Failed to generate codes',
+    campaignStats: 'Stats',
+    campaignStatsIssued: 'Issued',
+    campaignStatsActive: 'Available',
+    campaignStatsCodesUsed: 'Codes Used',
+    campaignStatsOrders: 'Orders',
+    campaignStatsLoadFailed: 'Failed to load campaign stats',
+    pricingRules: 'Pricing Rules',
+    pricingRulesDesc:
+      'Automatic discounts applied at checkout in priority order. Each rule works on what is left after higher-priority rules; promo codes apply last.',
+    pricingRulesEmpty: 'No pricing rules yet',
+    newPricingRule: 'New Rule',
+    pricingTypeThreshold: 'Spend Threshold',
+    pricingThresholdValue: '{value} off orders over {min}',
+    pricingPercentValue: 'Percentage (%)',
+    pricingMinAmount: 'Minimum Amount',
+    pricingMinAmountHint: 'Compared with the original subtotal of matching items',
+    pricingScope: 'Applies To',
+    pricingScopeAll: 'All products',
+    pricingScopeCategory: 'Category',
+    pricingTargetsHint: 'Separate multiple values with commas',
+    pricingPriority: 'Priority',
+    pricingPriorityHint: 'Higher priority rules are calculated first',
+    pricingEnabled: 'Enabled',
+    pricingExclusive: 'Exclusive',
+    pricingExclusiveHint:
+      'Once an exclusive rule applies, lower-priority rules and promo codes are skipped',
+    pricingRuleSaved: 'Pricing rule saved',
+    pricingRuleSaveFailed: 'Failed to save pricing rule',
+    pricingRuleDeleted: 'Pricing rule deleted',
+    pricingRuleDeleteFailed: 'Failed to delete pricing rule',
+    pricingRuleDeleteConfirm: 'Orders already placed keep their discounts. Delete this rule?',
+    pricingSimulate: 'Simulate Checkout',
+    pricingSimulateItems: 'Items (one SKU:quantity per line)',
+    pricingSimulateRun: 'Simulate',
+    pricingSimulateFailed: 'Simulation failed',
+    pricingBase: 'Base',
+    pricingSubtotal: 'Subtotal',
+    pricingTotal: 'Total',
 
     // User-facing
     enterPromoCode: 'Enter Promo Code',
@@ -4894,6 +7041,524 @@ export const enTranslations = {
       'promo_code.unavailable': 'Promo code is not available',
       'promo_code.notApplicable': 'Promo code is not applicable to the selected products',
       'promo_code.minOrderAmountNotMet': 'Order amount does not meet the minimum requirement',
+      'promo_code.perUserLimitReached': 'You have reached the usage limit for this promo code',
+      'promo_code.campaignNotFound': 'Promo code campaign not found',
+      'promo_code.campaignNameRequired': 'Campaign name is required',
+      'promo_code.campaignCountInvalid': 'Code count must be between 1 and {max}',
+      'promo_code.campaignPrefixInvalid':
+        'Prefix may only contain letters, digits, - and _ (up to {max} characters)',
+      'promo_code.campaignPatternInvalid':
+        'Pattern must contain at least {min} placeholders (X, 9 or A) and only - or _ as separators',
+      'promo_code.campaignCodeTooLong': 'Generated codes cannot exceed {max} characters',
+      'promo_code.campaignPatternTooSmall':
+        'Pattern does not allow enough unique codes for the requested count, use a longer pattern',
+      'promo_code.campaignDiscountInvalid': 'Invalid discount value',
+      'promo_code.campaignLimitInvalid': 'Amounts and usage limits cannot be negative',
+      'promo_code.campaignValidityInvalid': 'Start time must be before expiry time',
+      'promo_code.notCombinable': 'This promo code cannot be combined with {rule}',
+      'pricing.ruleNotFound': 'Pricing rule not found',
+      'pricing.nameRequired': 'Rule name is required',
+      'pricing.typeInvalid': 'Rule type must be percentage, fixed or threshold',
+      'pricing.valueInvalid': 'Invalid discount value',
+      'pricing.thresholdRequired': 'Spend threshold rules need a minimum amount',
+      'pricing.amountInvalid': 'Amounts cannot be negative',
+      'pricing.scopeInvalid': 'Rule scope must be all, sku or category',
+      'pricing.scopeEmpty': 'Choose at least one SKU or category for the rule',
+      'pricing.validityInvalid': 'Start time must be before expiry time',
+      'pricing.itemsRequired': 'Add at least one item to simulate',
+      'pricing.productNotFound': 'Product {sku} not found',
+    },
+  },
+
+  giftCard: {
+    // Admin
+    giftCardManagement: 'Gift Cards',
+    giftCardManagementDesc:
+      'Issue gift cards and track their balances. Amounts used at checkout are reserved until the order completes.',
+    issue: 'Issue Gift Cards',
+    count: 'Quantity',
+    valueType: 'Value Type',
+    valueFixed: 'Fixed denomination',
+    valueVariable: 'Custom value',
+    valueTypeHint:
+      'Fixed denominations must match the configured denominations; custom values must be within the configured range.',
+    amount: 'Value',
+    currency: 'Currency',
+    currencyPlaceholder: 'Defaults to the store currency',
+    expiresAt: 'Expires At',
+    expiresAtHint: 'Leave empty to use the default validity period',
+    note: 'Note',
+    code: 'Code',
+    balance: 'Balance',
+    reserved: 'Reserved',
+    status: 'Status',
+    statusActive: 'Active',
+    statusVoid: 'Void',
+    expired: 'Expired',
+    noExpiry: 'No Expiry',
+    searchPlaceholder: 'Search code or note...',
+    issued: '{count} gift card(s) issued',
+    issuedCodes: 'Issued codes',
+    copyCodes: 'Copy Codes',
+    issueFailed: 'Failed to issue gift cards',
+    void: 'Void Gift Card',
+    voidHint: 'The remaining balance will be cleared and the card can no longer be used.',
+    voidReason: 'Reason',
+    voided: 'Gift card voided',
+    voidFailed: 'Failed to void gift card',
+    ledger: 'Balance History',
+    txType: 'Type',
+    txIssue: 'Issued',
+    txReserve: 'Reserved',
+    txRelease: 'Released',
+    txRedeem: 'Redeemed',
+    txRefund: 'Refunded',
+    txVoid: 'Voided',
+    balanceAfter: 'Balance After',
+    order: 'Order',
+    time: 'Time',
+
+    // User-facing
+    haveGiftCard: 'Have a gift card?',
+    codePlaceholder: 'Enter gift card code',
+    applied: 'Gift card applied, available balance {balance}',
+    deduction: 'Gift card {code}: -{amount}',
+    checkFailed: 'Failed to check gift card',
+    bizError: {
+      'giftCard.disabled': 'Gift cards are not available',
+      'giftCard.notFound': 'Gift card not found',
+      'giftCard.countInvalid': 'Quantity must be between 1 and {max}',
+      'giftCard.valueInvalid': 'Gift card value must be greater than 0',
+      'giftCard.denominationInvalid': 'Gift card value is not one of the configured denominations',
+      'giftCard.valueOutOfRange': 'Gift card value is outside the allowed range',
+      'giftCard.valueTypeInvalid': 'Invalid gift card value type: {type}',
+      'giftCard.expiresAtInvalid': 'Expiry time must be in the future',
+      'giftCard.noteTooLong': 'Note cannot exceed {max} characters',
+      'giftCard.voidReasonInvalid': 'A void reason is required and cannot exceed {max} characters',
+      'giftCard.alreadyVoid': 'Gift card is already void',
+      'giftCard.hasPendingOrders':
+        'Gift card is reserved by unfinished orders, please complete or cancel them first',
+      'giftCard.void': 'Gift card has been voided',
+      'giftCard.expired': 'Gift card has expired',
+      'giftCard.emptyBalance': 'Gift card has no remaining balance',
+      'giftCard.currencyMismatch':
+        'Gift card currency ({currency}) does not match the order currency',
+    },
+  },
+
+  quote: {
+    // Admin
+    quoteManagement: 'Quotes',
+    quoteManagementDesc:
+      'Prepare quotes for customers. Prices are locked once a quote is sent, and the customer can accept it to create an order at the quoted prices.',
+    create: 'New Quote',
+    edit: 'Edit Quote',
+    customer: 'Customer',
+    title: 'Subject',
+    titlePlaceholder: 'e.g. Annual license renewal',
+    items: 'Line Items',
+    itemsHint: 'Unit prices default to the current product price and can be changed.',
+    unitPrice: 'Unit Price',
+    lineTotal: 'Amount',
+    quantity: 'Qty',
+    subtotal: 'Subtotal',
+    discount: 'Discount',
+    total: 'Total',
+    validUntil: 'Valid Until',
+    validUntilHint: 'Leave empty to use the default validity period',
+    note: 'Terms & Notes',
+    noteHint: 'Shown to the customer on the quote',
+    adminNote: 'Internal Note',
+    status: 'Status',
+    statusDraft: 'Draft',
+    statusSent: 'Sent',
+    statusAccepted: 'Accepted',
+    statusDeclined: 'Declined',
+    statusExpired: 'Expired',
+    statusCancelled: 'Cancelled',
+    searchPlaceholder: 'Search quote number or subject...',
+    saveDraft: 'Save Draft',
+    saved: 'Quote saved',
+    saveFailed: 'Failed to save quote',
+    send: 'Send to Customer',
+    sendHint: 'Once sent, the quote can no longer be edited. Cancel it and create a new one to change prices.',
+    sendEmail: 'Notify the customer by email',
+    sent: 'Quote sent',
+    sendFailed: 'Failed to send quote',
+    cancel: 'Cancel Quote',
+    cancelConfirm: 'Cancel this quote? The customer will no longer be able to accept it.',
+    cancelled: 'Quote cancelled',
+    cancelFailed: 'Failed to cancel quote',
+    preview: 'Preview',
+    downloadPdf: 'Download PDF',
+    documentFailed: 'Failed to load the quote document',
+    order: 'Order',
+    selectUser: 'Please select a customer',
+    itemsRequired: 'Please add at least one item',
+
+    // User-facing
+    myQuotes: 'My Quotes',
+    myQuotesDesc: 'Quotes prepared for you. Accept a quote to place an order at the quoted prices.',
+    noQuotes: 'No quotes yet',
+    viewDocument: 'View Quote',
+    accept: 'Accept Quote',
+    acceptHint:
+      'An order will be created at the quoted prices. You can then complete shipping details and payment from the order page.',
+    acceptRemark: 'Order remark (optional)',
+    accepted: 'Quote accepted, order created',
+    acceptFailed: 'Failed to accept quote',
+    decline: 'Decline',
+    declineReason: 'Reason (optional)',
+    declined: 'Quote declined',
+    declineFailed: 'Failed to decline quote',
+    viewOrder: 'View Order',
+    bizError: {
+      'quote.disabled': 'Quotes are not available',
+      'quote.statusInvalid': 'This quote is {status} and can no longer be changed',
+      'quote.titleTooLong': 'Subject cannot exceed {max} characters',
+      'quote.noteTooLong': 'Note cannot exceed {max} characters',
+      'quote.validUntilInvalid': 'Valid until must be in the future and within {max} days',
+      'quote.currencyMismatch':
+        'The quote currency ({currency}) no longer matches the store currency',
+      'quote.reasonTooLong': 'Reason cannot exceed {max} characters',
+    },
+  },
+
+  returns: {
+    // User
+    title: 'Returns',
+    desc:
+      'Return shipped items. Once approved, send the items back and enter the return tracking number. Refunds are handled separately.',
+    requestButton: 'Request a Return',
+    items: 'Items',
+    available: '{count} returnable',
+    reason: 'Reason',
+    reasonDefective: 'Defective',
+    reasonDamaged: 'Damaged in transit',
+    reasonWrongItem: 'Wrong item received',
+    reasonNotAsDescribed: 'Not as described',
+    reasonNoLongerNeeded: 'No longer needed',
+    reasonOther: 'Other',
+    description: 'Details',
+    descriptionPlaceholder: 'Describe the problem with the items',
+    photos: 'Photos',
+    photosHint: 'Up to 5 images',
+    uploadPhoto: 'Upload photo',
+    removePhoto: 'Remove photo',
+    submit: 'Submit Return',
+    submitted: 'Return request submitted',
+    submitFailed: 'Failed to submit return request',
+    reviewNote: 'Review note',
+    receiveNote: 'Receipt note',
+    shipBackHint: 'Send the items back and enter the carrier and tracking number below.',
+    carrier: 'Carrier',
+    trackingNo: 'Tracking number',
+    saveTracking: 'Save',
+    trackingSaved: 'Return tracking saved',
+    trackingFailed: 'Failed to save return tracking',
+    statusRequested: 'Pending review',
+    statusApproved: 'Approved',
+    statusRejected: 'Rejected',
+    statusInTransit: 'Shipped back',
+    statusReceived: 'Received',
+    // Admin
+    management: 'Returns',
+    managementDesc:
+      'Review return requests, track items shipped back and confirm receipt. Received items can be restocked to the inventory bound to the order item.',
+    searchPlaceholder: 'Return no., order no. or tracking no.',
+    returnNo: 'Return',
+    customer: 'Customer',
+    status: 'Status',
+    createdAt: 'Requested',
+    approve: 'Approve',
+    reject: 'Reject',
+    approveNote: 'Instructions for the customer (optional)',
+    rejectNote: 'Reason',
+    approved: 'Return approved',
+    rejected: 'Return rejected',
+    receive: 'Mark Received',
+    received: 'Return marked as received',
+    restock: 'Restock items',
+    restockHint: 'Add the returned quantity back to the inventory bound to each order item.',
+    restocked: 'Restocked',
+    notRestocked: 'Not restocked',
+    updateFailed: 'Failed to update return request',
+    bizError: {
+      'return.disabled': 'Returns are not available',
+      'return.reasonInvalid': 'Please choose a valid return reason',
+      'return.descriptionTooLong': 'Details cannot exceed {max} characters',
+      'return.itemsRequired': 'Select at least one item to return',
+      'return.orderStatusInvalid': 'Orders in status {status} cannot be returned',
+      'return.windowExpired': 'The {days}-day return window has passed',
+      'return.itemInvalid': 'Order item {index} does not exist',
+      'return.quantityInvalid': 'Return quantity must be greater than 0',
+      'return.itemNotReturnable': '{sku} cannot be returned',
+      'return.quantityExceeded': 'Only {available} of {sku} can still be returned',
+      'return.statusInvalid': 'This return is {status} and cannot be changed',
+      'return.noteTooLong': 'Note cannot exceed {max} characters',
+      'return.noteRequired': 'Please enter a reason',
+      'return.trackingRequired': 'Please enter the return tracking number',
+      'return.trackingTooLong': 'Tracking number cannot exceed {max} characters',
+    },
+  },
+  dispute: {
+    // User
+    title: 'Platform Dispute',
+    desc:
+      'If this ticket is not resolved, escalate it to the platform. Both sides submit evidence and the platform makes the final decision.',
+    availableAt: 'You can escalate this ticket from {date}',
+    unavailable: 'Resolved or closed tickets cannot be escalated',
+    escalate: 'Escalate to Platform',
+    order: 'Order',
+    orderPlaceholder: 'Select the order',
+    orderNoPlaceholder: 'Order number',
+    statement: 'Your statement',
+    statementPlaceholder: 'Describe what happened and the outcome you expect',
+    evidence: 'Evidence',
+    evidenceHint: 'Screenshots or photos that support your statement',
+    uploadEvidence: 'Upload evidence',
+    removeEvidence: 'Remove evidence',
+    submit: 'Submit Dispute',
+    escalated: 'Dispute submitted to the platform',
+    escalateFailed: 'Failed to submit dispute',
+    statusAwaitingEvidence: 'Awaiting merchant response',
+    statusUnderReview: 'Under platform review',
+    statusResolved: 'Decided',
+    evidenceDeadline: 'Merchant response due {date}',
+    buyer: 'Buyer',
+    merchant: 'Merchant',
+    noMerchantResponse: 'No response yet',
+    decision: 'Decision',
+    decisionRefundFull: 'Full refund',
+    decisionRefundPartial: 'Partial refund',
+    decisionNoRefund: 'No refund',
+    refundAmount: 'Refund amount',
+    refundProcessing: 'The refund is being processed',
+    // Admin
+    management: 'Disputes',
+    managementDesc:
+      'Tickets escalated by customers. The merchant responds with evidence before the deadline, then a reviewer other than the responder records the decision. Refund decisions are executed immediately.',
+    disputeNo: 'Dispute',
+    customer: 'Customer',
+    status: 'Status',
+    deadline: 'Response due',
+    overdue: 'Overdue',
+    createdAt: 'Opened',
+    viewTicket: 'Open ticket',
+    respond: 'Submit Response',
+    responseStatement: 'Merchant statement',
+    responded: 'Response submitted',
+    decide: 'Decide',
+    decideDesc:
+      'The decision is final and is posted to the ticket. Refunds go through the payment method.',
+    decisionNote: 'Reasoning',
+    refundItems: 'Items to refund',
+    refundAmountHint: 'Leave empty to refund the selected items at their purchase price',
+    decided: 'Decision recorded',
+    refundIssueFailed:
+      'The partial refund was approved but could not be issued. Retry it from the order refunds.',
+    updateFailed: 'Failed to update dispute',
+    bizError: {
+      'dispute.disabled': 'Dispute escalation is not available',
+      'dispute.ticketResolved': 'Resolved or closed tickets cannot be escalated',
+      'dispute.notYetEligible': 'Tickets can be escalated {days} days after they are opened',
+      'dispute.statementRequired': 'Please describe your side of the dispute',
+      'dispute.statementTooLong': 'Statement cannot exceed {max} characters',
+      'dispute.orderRequired': 'Select the order this dispute is about',
+      'dispute.alreadyEscalated': 'This ticket has already been escalated',
+      'dispute.statusInvalid': 'This dispute is {status} and cannot be changed',
+      'dispute.decisionInvalid': 'Invalid dispute decision',
+      'dispute.decisionNoteRequired': 'Please record the reasoning for this decision',
+      'dispute.decisionNoteTooLong': 'Reasoning cannot exceed {max} characters',
+      'dispute.evidencePending': 'The merchant can still submit evidence until {deadline}',
+      'dispute.reviewerConflict':
+        'The admin who submitted the merchant response cannot decide this dispute',
+    },
+  },
+  spendingControl: {
+    title: 'Spending Controls',
+    desc: 'Monthly spending limit and blocked purchase categories for this account',
+    adminDesc: 'Changes made by administrators take effect immediately and notify the owner.',
+    thisMonth: 'This Month',
+    spent: 'Spent this month',
+    monthlyLimit: 'Monthly limit',
+    noLimit: 'No limit',
+    monthlyLimitHint: 'Leave empty for no limit. Checkout is blocked once the limit is reached.',
+    blockedCategories: 'Blocked categories',
+    noCategories: 'No product categories available',
+    ownerEmail: 'Account owner email',
+    ownerEmailHint: 'Change notifications go to this address, or to the account email if empty.',
+    none: 'None',
+    pendingTitle: 'Pending change',
+    pendingDesc: 'Takes effect at {time}. Current controls stay in place until then.',
+    cancelPending: 'Cancel pending change',
+    pendingCancelled: 'Pending change cancelled',
+    settings: 'Settings',
+    cooldownHint:
+      'Tighter controls apply immediately. Raising the limit, unblocking categories or changing the owner email waits {hours} hours.',
+    saved: 'Spending controls updated',
+    scheduled: 'Change scheduled after the cooldown period',
+    saveFailed: 'Failed to update spending controls',
+    bizError: {
+      'spendingControl.disabled': 'Spending controls are not available',
+      'spendingControl.limitInvalid': 'Monthly limit cannot be negative',
+      'spendingControl.categoryTooLong': 'Category names cannot exceed {max} characters',
+      'spendingControl.categoriesTooMany': 'At most {max} categories can be blocked',
+      'spendingControl.ownerEmailInvalid': 'Please enter a valid owner email',
+      'spendingControl.noPendingChange': 'There is no pending change',
+      'spendingControl.categoryBlocked':
+        'Purchases in category {category} are blocked for this account',
+      'spendingControl.limitExceeded':
+        'This order exceeds your monthly spending limit ({remaining} {currency} remaining)',
+    },
+  },
+  consent: {
+    title: 'Terms & Privacy',
+    desc: 'Policies you have accepted and any updates waiting for your consent',
+    version: 'Version',
+    types: {
+      terms: 'Terms of Service',
+      privacy: 'Privacy Policy',
+    },
+    popupTitle: 'Our policies have been updated',
+    popupDesc: 'Please review and accept the latest versions. Orders cannot be placed until then.',
+    agree: 'I have read and agree to the policies above',
+    accept: 'Accept',
+    later: 'Later',
+    accepted: 'Thank you, your consent has been recorded',
+    acceptFailed: 'Failed to record consent',
+    pendingTitle: 'Waiting for your consent',
+    history: 'Consent History',
+    noHistory: 'No consent records yet',
+    adminPending: 'Not yet accepted: {policies}',
+    adminUpToDate: 'All current policies have been accepted',
+    management: 'Terms & Privacy',
+    managementDesc:
+      'Publish new versions of the terms of service and privacy policy. Users must accept the latest versions before checkout.',
+    publish: 'Publish Version',
+    publishHint:
+      'Once published, every user has to accept this version again before placing orders.',
+    policyType: 'Policy',
+    titleLabel: 'Title',
+    summary: 'Summary of changes',
+    content: 'Content (Markdown)',
+    current: 'Current',
+    acceptedCount: 'Accepted by',
+    publishedAt: 'Published At',
+    published: 'Policy version published',
+    publishFailed: 'Failed to publish policy version',
+    bizError: {
+      'consent.required': 'Please accept the latest {policies} before placing an order',
+      'consent.policiesRequired': 'Select the policies to accept',
+      'consent.versionOutdated':
+        'This policy version is no longer current, please review the latest version',
+      'consent.policyTypeInvalid': 'Policy type must be one of {types}',
+      'consent.versionRequired': 'Version is required',
+      'consent.versionTooLong': 'Version cannot exceed {max} characters',
+      'consent.versionExists': 'Version {version} has already been published',
+      'consent.titleRequired': 'Title is required',
+      'consent.titleTooLong': 'Title cannot exceed {max} characters',
+      'consent.contentRequired': 'Policy content is required',
+      'consent.summaryTooLong': 'Summary cannot exceed {max} characters',
+    },
+  },
+
+  twoFactor: {
+    title: 'Two-factor authentication',
+    desc: 'Protect your account with a one-time code from an authenticator app',
+    challengeTitle: 'Two-factor verification',
+    challengeDesc:
+      'Enter the 6-digit code from your authenticator app, or one of your backup codes',
+    setupRequiredTitle: 'Set up two-factor authentication',
+    setupRequiredDesc:
+      'Your account requires two-factor authentication. Set up an authenticator app to continue',
+    recoveryTitle: 'Recover your account',
+    recoveryDesc:
+      'We will email a code to your account address. Recovering turns off two-factor authentication until you set it up again',
+    code: 'Verification code',
+    codePlaceholder: '123456',
+    verify: 'Verify',
+    recover: 'Recover account',
+    backToLogin: 'Back to sign in',
+    lostDevice: 'Lost access to your authenticator?',
+    useAuthenticator: 'Use an authenticator or backup code instead',
+    sendRecoveryCode: 'Email me a recovery code',
+    recoveryCodeSent: 'Recovery code sent to {email}',
+    scanQr: 'Scan this QR code with Google Authenticator, 1Password or another authenticator app',
+    qrAlt: 'Authenticator QR code',
+    manualEntry: 'Or enter this key manually',
+    setupStart: 'Set up',
+    enable: 'Enable',
+    enabled: 'Enabled',
+    disabled: 'Not enabled',
+    enabledSince: 'Enabled since {date}',
+    enabledSuccess: 'Two-factor authentication enabled',
+    disable: 'Turn off',
+    disableDesc:
+      'Enter a code from your authenticator app or a backup code to turn off two-factor authentication',
+    disabledSuccess: 'Two-factor authentication turned off',
+    requiredByAdmin: 'Two-factor authentication is required for your account by an administrator',
+    backupCodesTitle: 'Backup codes',
+    backupCodesDesc:
+      'Each code can be used once if you lose your authenticator. Store them somewhere safe, they will not be shown again',
+    backupCodesRemaining: '{count} backup codes remaining',
+    regenerateBackupCodes: 'Regenerate backup codes',
+    regenerateDesc:
+      'Enter a code from your authenticator app. Existing backup codes will stop working',
+    copyCodes: 'Copy codes',
+    copied: 'Copied',
+    continue: 'Continue',
+    actionFailed: 'Operation failed',
+    verifyFailed: 'Verification failed',
+    adminNotEnrolled: 'The user has not set up an authenticator',
+    adminRequire: 'Require two-factor authentication',
+    adminRequireDesc:
+      'The user must set up an authenticator at their next sign-in and cannot turn it off',
+    adminUpdated: 'Two-factor requirement updated',
+    adminReset: 'Reset two-factor',
+    adminResetConfirm:
+      "Reset this user's two-factor authentication? Their authenticator and backup codes will stop working",
+    adminResetSuccess: 'Two-factor authentication reset',
+    bizError: {
+      'twoFactor.invalidCode': 'Invalid verification code',
+      'twoFactor.locked': 'Too many failed attempts, please try again in {minutes} minutes',
+      'twoFactor.notEnabled': 'Two-factor authentication is not enabled',
+      'twoFactor.alreadyEnabled': 'Two-factor authentication is already enabled',
+      'twoFactor.setupRequired': 'Please start two-factor setup first',
+      'twoFactor.requiredByAdmin':
+        'Two-factor authentication is required for your account and cannot be turned off',
+      'twoFactor.recoveryUnavailable':
+        'Email recovery is unavailable, please use a backup code or contact support',
+    },
+  },
+
+  passkey: {
+    title: 'Passkeys',
+    desc: 'Sign in with your fingerprint, face or device PIN instead of a password',
+    signIn: 'Sign in with a passkey',
+    empty: 'No passkeys registered yet',
+    name: 'Passkey name',
+    namePlaceholder: 'e.g. MacBook or iPhone',
+    add: 'Add passkey',
+    registered: 'Passkey added',
+    registerFailed: 'Failed to add passkey',
+    delete: 'Remove passkey',
+    deleteConfirm: 'Remove the passkey "{name}"? You will no longer be able to sign in with it',
+    deleted: 'Passkey removed',
+    deleteFailed: 'Failed to remove passkey',
+    createdAt: 'Added {date}',
+    lastUsedAt: 'Last used {date}',
+    neverUsed: 'Never used',
+    unsupported: 'This browser does not support passkeys',
+    loginFailed: 'Passkey sign-in failed',
+    bizError: {
+      'passkey.unavailable': 'Passkeys are not available on this site',
+      'passkey.loginDisabled': 'Passkey sign-in is disabled',
+      'passkey.challengeExpired': 'The passkey request has expired, please try again',
+      'passkey.verificationFailed': 'Passkey verification failed',
+      'passkey.alreadyRegistered': 'This passkey is already registered',
+      'passkey.limitReached': 'You can register at most {max} passkeys',
+      'passkey.notFound': 'Passkey not found',
     },
   },
 
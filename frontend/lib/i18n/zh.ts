@@ -49,6 +49,12 @@ export const zhTranslations = {
       'password.needLowercase': '密码必须包含至少一个小写字母',
       'password.needDigit': '密码必须包含至少一个数字',
       'password.needSpecial': '密码必须包含至少一个特殊字符',
+      'common.notFound': '资源不存在',
+      'common.conflict': '资源已存在',
+      'common.versionConflict': '该记录已被他人修改，请刷新查看最新内容后重新修改',
+      'common.serviceUnavailable': '服务暂时不可用，请稍后重试',
+      'common.internalError': '服务器内部错误，请稍后重试',
+      'common.invalidCursor': '分页位置无效，请刷新列表后重试',
     },
   },
 
@@ -189,13 +195,18 @@ export const zhTranslations = {
       'auth.accountDisabled': '账户已被禁用',
       'auth.passwordLoginDisabled': '密码登录已禁用，请使用快速登录或 OAuth 登录',
       'auth.emailNotVerified': '请先验证您的邮箱',
+      'auth.emailNotVerifiedForCheckout': '请先验证您的邮箱后再下单',
+      'auth.emailNotVerifiedForVirtualReveal': '请先验证您的邮箱后再查看虚拟商品内容',
       'auth.emailAlreadyInUse': '该邮箱已被注册',
+      'auth.emailDisposable': '不支持一次性邮箱，请使用常用邮箱地址',
+      'auth.emailDomainUnreachable': '该邮箱域名无法接收邮件，请检查地址或更换邮箱',
       'auth.phoneAlreadyInUse': '该手机号已被注册',
       'auth.incorrectOldPassword': '旧密码错误',
       'auth.userNotFound': '用户不存在',
       'auth.resetTokenExpired': '重置链接已过期或无效',
       'auth.codeExpired': '验证码已过期或无效',
       'auth.invalidCode': '验证码错误',
+      'auth.tooManyCodeAttempts': '验证码错误次数过多，请重新获取验证码',
       'auth.registrationDisabled': '注册功能已关闭',
       'auth.emailLoginUnavailable': '邮箱登录当前不可用',
       'auth.emailLoginDisabled': '邮箱验证码登录已关闭',
@@ -305,6 +316,25 @@ export const zhTranslations = {
     sku: 'SKU',
     addToCart: '加入购物车',
     buyNow: '立即购买',
+    waitingRoomTitle: '限量发售等候室',
+    waitingRoomDesc: '该商品为限量发售，请先排队，轮到您后即可下单',
+    waitingRoomJoin: '加入排队',
+    waitingRoomJoinFailed: '加入等候室失败',
+    waitingRoomPosition:
+      '您当前排在第 {position} 位（共 {total} 人），请保持页面打开以保留排队资格',
+    waitingRoomAdmitted: '已轮到您',
+    waitingRoomAdmittedDesc: '请在 {time} 前完成下单',
+    trialTitle: '免费试用',
+    trialDesc: '可免费试用 {days} 天，每个账户限领一次。',
+    trialStart: '开始 {days} 天免费试用',
+    trialStarted: '试用已开通',
+    trialStartFailed: '开通试用失败',
+    trialActive: '试用将于 {time} 到期',
+    trialConvertPending: '请在试用到期前支付订单 {order}，即可继续使用当前卡密。',
+    trialPayNow: '立即支付',
+    trialConverted: '试用已转为正式授权。',
+    trialExpired: '试用已到期，试用卡密已失效。',
+    trialViewOrder: '查看试用订单',
     outOfStock: '缺货',
     soldOut: '已售罄',
     inStock: '有货',
@@ -488,6 +518,20 @@ export const zhTranslations = {
     adminRemark: '管理员备注',
     trackingInfo: '物流信息',
     trackingNo: '物流单号',
+    shipmentStatus: '物流状态',
+    shipmentDeliveredAt: '签收时间',
+    shipmentRegisterFailed: '物流跟踪注册失败：{error}',
+    shipmentStatuses: {
+      pending: '等待承运商更新',
+      info_received: '已揽收待运',
+      in_transit: '运输中',
+      out_for_delivery: '派送中',
+      available_for_pickup: '待自提',
+      failed_attempt: '投递失败',
+      delivered: '已签收',
+      exception: '物流异常',
+      expired: '跟踪已过期',
+    },
     privacyProtected: '隐私保护',
     shippingNotFilled: '收货信息尚未填写',
     shippingNotFilledDesc: '请填写收货信息以便我们为您发货',
@@ -518,9 +562,38 @@ export const zhTranslations = {
     noPaymentMethods: '暂无可用的付款方式',
     noPaymentMethodsHint: '当前没有可用于此订单的付款方式，请稍后重试或联系管理员。',
     paymentMethodNoDescription: '该付款方式暂未提供更多说明。',
+    paymentMethodFee: '手续费 {fee}',
+    paymentSurcharge: '付款方式附加费',
+    paymentDiscount: '付款方式优惠',
+    paymentSurchargeNonRefundable: '退款时不退还',
+    priceRounding: '价格取整',
+    manualAdjustment: '人工改价',
+    giftCardDeduction: '礼品卡抵扣',
+    discountTotal: '优惠',
+    pricingSkipReason: {
+      no_matching_items: '无适用商品',
+      min_amount_not_met: '未达到金额门槛',
+      nothing_left: '已无可优惠金额',
+      blocked_by_exclusive: '不与 {rule} 同享',
+    },
     confirmSelection: '确认选择',
     downloadInvoice: '下载账单',
     downloadInvoiceFailed: '下载账单失败',
+    exportOrders: '导出订单',
+    exportOrdersDesc: '下载符合当前筛选条件的订单，日期留空表示不限。',
+    exportFormat: '格式',
+    exportFrom: '开始日期',
+    exportTo: '结束日期',
+    exportSku: 'SKU',
+    exportSkuPlaceholder: '仅导出包含该 SKU 的订单',
+    exportDownload: '下载',
+    exportFailed: '导出订单失败',
+    downloadInvoicePdf: '下载 PDF',
+    documentsTitle: '订单文档',
+    documentsDesc: '为此订单签发的证书等文档',
+    documentView: '查看',
+    documentPdf: 'PDF',
+    documentOpenFailed: '打开文档失败',
     invoiceNotAvailable: '账单功能未启用',
     paymentUrgencyTitle: '请尽快完成付款',
     paymentUrgencyDesc: '超过付款时限后，订单将被自动取消。',
@@ -536,6 +609,109 @@ export const zhTranslations = {
     virtualProductDelivered: '您的虚拟产品已自动发货',
     virtualProductKeepSafe: '以下是您购买的卡密/激活码，请妥善保管',
     virtualProductShipped: '虚拟商品已发货，点击查看卡密',
+    virtualRevealReauthTitle: '验证身份后查看',
+    virtualRevealReauthDesc: '该订单包含高价值卡密，显示前需要确认是您本人操作。',
+    virtualRevealPassword: '账号密码',
+    virtualRevealCode: '邮箱验证码',
+    virtualRevealUsePassword: '使用密码',
+    virtualRevealUseCode: '使用邮箱验证码',
+    virtualRevealSendCode: '发送验证码',
+    virtualRevealCodeSent: '验证码已发送至 {email}',
+    virtualRevealVerify: '验证并查看',
+    virtualRevealVerifying: '验证中...',
+    virtualRevealFailed: '验证失败',
+    refundRequestTitle: '退款申请',
+    refundRequestDesc: '请描述遇到的问题并上传截图，系统会自动创建工单，客服审核后处理',
+    refundRequestButton: '申请退款',
+    refundRequestResolution: '期望处理方式',
+    refundRequestResolutionFullRefund: '全额退款',
+    refundRequestResolutionPartialRefund: '部分退款',
+    refundRequestResolutionReplacement: '换货/补发',
+    refundRequestResolutionOther: '其他',
+    refundRequestReason: '申请原因',
+    refundRequestReasonPlaceholder: '请说明订单存在的问题',
+    refundRequestEvidence: '凭证',
+    refundRequestEvidenceHint: '可选，最多 5 张图片',
+    refundRequestUploadEvidence: '上传图片',
+    refundRequestRemoveEvidence: '移除图片',
+    refundRequestSubmit: '提交申请',
+    refundRequestSubmitted: '退款申请已提交',
+    refundRequestFailed: '提交退款申请失败',
+    refundRequestStatusPending: '审核中',
+    refundRequestStatusApproved: '已批准',
+    refundRequestStatusRejected: '已拒绝',
+    refundRequestReviewNote: '处理说明',
+    refundRequestViewTicket: '查看关联工单',
+    partialRefundTitle: '商品退款',
+    partialRefundDesc: '退还订单中的部分商品，金额按下单时的单价计算。',
+    partialRefundButton: '申请商品退款',
+    partialRefundItems: '退款商品',
+    partialRefundAvailable: '可退 {count} 件',
+    partialRefundReason: '退款原因',
+    partialRefundReasonPlaceholder: '请描述这些商品存在的问题',
+    partialRefundAmountHint: '退款经审核后原路退回。',
+    partialRefundSubmit: '提交申请',
+    partialRefundSubmitted: '商品退款申请已提交',
+    partialRefundFailed: '商品退款申请提交失败',
+    partialRefundStatusRequested: '审核中',
+    partialRefundStatusApproved: '已批准',
+    partialRefundStatusRefunded: '已退款',
+    partialRefundStatusRejected: '已拒绝',
+    partialRefundReviewNote: '回复',
+    orderSharesTitle: '已分享给客服',
+    orderSharesDesc: '以下工单的客服可以查看该订单，分享到期后自动失效。',
+    orderShareExpiresAt: '到期时间',
+    orderShareNoExpiry: '长期有效',
+    orderShareRenew: '修改有效期',
+    orderShareRevoke: '取消分享',
+    orderShareUpdated: '分享设置已更新',
+    orderShareUpdateFailed: '更新分享设置失败',
+    orderShareRevoked: '已取消该工单的订单分享',
+    orderShareRevokeFailed: '取消分享失败',
+    orderTimelineTitle: '订单记录',
+    orderTimelineDesc: '订单从创建至今的全部变化，按时间先后排列。',
+    orderTimelineEmpty: '暂无记录',
+    orderTimelineCreated: '创建订单',
+    orderTimelineStatusChanged: '状态变更为{status}',
+    orderTimelinePaymentSelected: '选择付款方式：{method}',
+    orderTimelinePaymentUnconfirmed: '付款未能及时确认',
+    orderTimelineCODCollected: '货到付款已收款',
+    orderTimelinePaid: '已付款',
+    orderTimelineShipped: '已发货',
+    orderTimelineShipmentUpdate: '物流更新：{status}',
+    orderTimelineRemark: '管理员备注',
+    orderTimelineInternal: '仅管理员可见',
+    orderTimelineOperatorUser: '用户',
+    orderTimelineOperatorAdmin: '管理员',
+    orderTimelineOperatorSystem: '系统',
+    orderTimelineRemarkPlaceholder: '添加内部备注，仅管理员可见',
+    orderTimelineAddRemark: '添加备注',
+    orderTimelineRemarkAdded: '备注已添加',
+    orderTimelineRemarkFailed: '添加备注失败',
+    licenseActivationsTitle: '设备激活',
+    licenseActivationsDesc: '已激活该卡密的设备。解绑不再使用的设备即可释放名额，在新设备上激活。',
+    licenseActivationActive: '激活中',
+    licenseActivationDeactivated: '已解绑',
+    licenseActivatedAt: '激活时间',
+    licenseLastSeenAt: '最近校验',
+    licenseDeactivatedAt: '解绑时间',
+    licenseDeactivate: '解绑',
+    licenseDeviceDeactivated: '设备已解绑',
+    licenseDeviceDeactivateFailed: '解绑设备失败',
+    licenseDeactivatedBySoftware: '软件端解绑',
+    licenseDeactivatedByUser: '买家解绑',
+    licenseDeactivatedByAdmin: '管理员解绑',
+    licenseDeactivatedByTransfer: '已迁移到新设备',
+    usageMetersTitle: '用量',
+    usageMetersDesc: '软件按卡密上报的用量。超出额度的部分将单独生成订单计费。',
+    usageUsed: '已用',
+    usageRemaining: '剩余',
+    usageOverage: '超额',
+    usageOveragePrice: '超额单价',
+    usageOverageOrder: '超额订单',
+    usageLastReportedAt: '最近上报',
+    usageNearQuota: '即将用尽',
+    usageOverQuota: '已超额',
     delivered: '已发货',
     deliveryTime: '发货时间',
     totalCodes: '共 {count} 个卡密',
@@ -662,9 +838,18 @@ export const zhTranslations = {
       'order.invalidRequestParameters': '请求参数无效',
       'order.trackingNumberLengthInvalid': '物流单号长度必须在 {min}-{max} 个字符之间',
       'order.adminRemarkTooLong': '管理员备注长度不能超过 {max} 个字符',
+      'order.adminRemarkRequired': '管理员备注不能为空',
+      'order.draftCleanupDisabled': '草稿清理未开启，请先设置保留天数',
       'order.cancellationReasonTooLong': '取消原因长度不能超过 {max} 个字符',
       'order.refundReasonTooLong': '退款原因长度不能超过 {max} 个字符',
+      'order.virtualRevealRateLimited': '该订单的虚拟商品每小时最多查看 {max} 次，请稍后再试',
+      'order.virtualRevealPasswordInvalid': '密码错误',
+      'order.virtualRevealPasswordUnavailable': '当前账号未设置密码，请使用邮箱验证码验证',
+      'order.virtualRevealCodeUnavailable': '当前账号未绑定邮箱，请使用密码验证',
+      'order.exportFormatInvalid': '导出格式仅支持 CSV 或 Excel（xlsx）',
+      'order.exportDateRangeInvalid': '日期范围无效，请使用 YYYY-MM-DD 格式且开始日期不晚于结束日期',
       'order.refundStatusInvalid': '当前订单状态不支持退款（当前状态：{status}）',
+      'order.refundedAmountExceeded': '已退款金额（{refunded}）超过订单可退金额（{refundable}）',
       'order.refundFinalizeStatusInvalid': '当前订单状态不支持确认退款（当前状态：{status}）',
       'order.refundTransactionIDTooLong': '退款流水号长度不能超过 {max} 个字符',
       'order.orderPaymentMethodNotFound': '订单未找到关联的付款方式',
@@ -680,6 +865,11 @@ export const zhTranslations = {
       'order.districtTooLong': '区/县长度不能超过 {max} 个字符',
       'order.addressLengthInvalid': '详细地址长度必须在 {min}-{max} 个字符之间',
       'order.postcodeInvalid': '邮编格式或长度无效',
+      'order.provinceRequired': '{country} 的收货地址需填写省/州',
+      'order.cityRequired': '{country} 的收货地址需填写城市',
+      'order.districtRequired': '{country} 的收货地址需填写区/县',
+      'order.postcodeRequired': '{country} 的收货地址需填写邮编',
+      'order.postcodeFormatInvalid': '{country} 的邮编格式无效（示例：{example}）',
       'order.resubmitReasonLengthInvalid': '重填原因长度必须在 {min}-{max} 个字符之间',
       'order.resubmitStatusInvalid': '只有待发货订单可以要求重填（当前状态：{status}）',
       'order.updatePriceStatusInvalid': '只有待付款订单可以修改价格（当前状态：{status}）',
@@ -693,10 +883,38 @@ export const zhTranslations = {
       'order.productNotAvailable': '商品暂时不可购买',
       'order.productNotFound': '商品 {sku} 不存在',
       'order.notFound': '订单不存在',
+      'order.unitPriceNegative': '{sku} 的单价不能小于 0',
+      'order.discountInvalid': '折扣需在 0 到商品小计之间',
+      'order.paymentActionInvalid': '无效的付款处理方式：{action}',
+      'order.paymentActionStatusInvalid': '标记已付款或发送付款链接仅适用于待付款订单',
       'order.totalAmountNegative': '订单总金额不能小于 0',
       'order.userNotFound': '用户不存在',
       'order.virtualInventoryRequired': '虚拟商品 {sku} 必须选择虚拟库存',
       'order.statusInvalid': '订单状态无效：{status}',
+      'order.riskHold': '订单已被风控暂扣，风控审核通过前不能发货',
+      'license.notFound': '卡密不存在',
+      'license.revoked': '卡密已失效',
+      'license.trialExpired': '试用卡密已过期',
+      'license.deviceIdInvalid': '设备 ID 长度需为 1-{max} 个字符',
+      'license.activationLimit': '卡密已在 {max} 台设备上激活，请先解绑其中一台',
+      'license.deviceNotActivated': '卡密未在该设备上激活',
+      'license.transferSameDevice': '迁移的源设备和目标设备不能相同',
+      'license.alreadyDeactivated': '该设备已解绑',
+      'license.maxActivationsInvalid': '设备数上限需在 0 到 {max} 之间',
+      'usage.notMetered': '该卡密未启用用量计量',
+      'usage.quantityInvalid': '用量须为绝对值不超过 {max} 的非零整数',
+      'usage.idempotencyKeyTooLong': '幂等键长度不能超过 {max} 个字符',
+      'usage.belowZero': '上报后用量将小于 0（当前已用：{used}）',
+      'usage.quotaExceeded': '用量额度已用尽，额度 {quota}，剩余 {remaining}',
+      'usage.quotaInvalid': '用量额度不能为负数',
+      'usage.overagePriceInvalid': '超额单价不能为负数',
+      'usage.unitTooLong': '用量单位不能超过 {max} 个字符',
+      'trial.daysInvalid': '试用天数须在 0 到 365 之间',
+      'trial.productNotEligible': '仅开启自动发货的虚拟商品可设置试用',
+      'trial.notAvailable': '该商品不提供试用',
+      'trial.alreadyUsed': '您已试用过该商品',
+      'trial.unavailable': '试用功能暂不可用',
+      'trial.userNotFound': '用户不存在',
       'order.assignTrackingStatusInvalid': '当前订单状态不支持分配物流单号（当前状态：{status}）',
       'order.completeStatusInvalid': '当前订单状态不支持标记完成（当前状态：{status}）',
       'order.cancelStatusInvalid': '当前订单状态不支持取消（当前状态：{status}）',
@@ -710,6 +928,38 @@ export const zhTranslations = {
         '商品 {product} 超出限购，还可购买{remaining}件（每人限购{limit}件）',
       'order.stockInsufficient': '商品 {product} 库存不足，仅剩{available}件',
       'order.systemBusy': '系统繁忙，请稍后重试',
+      'order.waitingRoomRequired': '{product} 为限量发售商品，请先进入等候室排队',
+      'order.rateCapExceeded': '{product} 近期下单过于频繁，请 {minutes} 分钟后再试',
+      'order.refundRequestReasonRequired': '请填写退款原因',
+      'order.refundRequestReasonTooLong': '退款原因不能超过 {max} 个字符',
+      'order.refundRequestResolutionInvalid': '无效的处理方式',
+      'order.refundRequestStatusInvalid': '{status} 状态的订单不能申请退款',
+      'order.refundRequestPending': '该订单已有退款申请正在审核中',
+      'order.refundRequestNotPending': '该退款申请已处理',
+      'order.refundRequestNoteRequired': '请填写拒绝原因',
+      'order.refundRequestNoteTooLong': '处理说明不能超过 {max} 个字符',
+      'order.partialRefundReasonRequired': '请填写退款原因',
+      'order.partialRefundReasonTooLong': '退款原因不能超过 {max} 个字符',
+      'order.partialRefundItemsRequired': '请至少选择一件退款商品',
+      'order.partialRefundAmountInvalid': '退款金额不能为负数',
+      'order.partialRefundItemInvalid': '订单项 #{index} 不存在',
+      'order.partialRefundQuantityInvalid': '退款数量必须大于 0',
+      'order.partialRefundQuantityExceeded': '{sku} 最多还可退 {available} 件',
+      'order.partialRefundAmountRequired': '请填写所选商品的退款金额',
+      'order.partialRefundAmountExceeded': '退款金额超过订单剩余可退金额',
+      'order.partialRefundStatusInvalid': '当前状态（{status}）的退款不能执行此操作',
+      'order.partialRefundNoteRequired': '请填写拒绝原因',
+      'order.priceAdjustmentApprovalRequired': '减免超过 {threshold} 需提交改价申请并由其他管理员批准',
+      'order.priceAdjustmentReasonRequired': '请填写改价原因',
+      'order.priceAdjustmentReasonTooLong': '改价原因不能超过 {max} 个字符',
+      'order.priceAdjustmentAmountInvalid': '改价后的应付金额必须发生变化，且不能低于支付手续费',
+      'order.priceAdjustmentTypeInvalid': '无效的改价类型：{type}',
+      'order.priceAdjustmentPending': '该订单已有等待审批的改价申请',
+      'order.priceAdjustmentNotPending': '该改价申请已处理（{status}）',
+      'order.priceAdjustmentSelfApproval': '改价申请必须由其他管理员批准',
+      'order.priceAdjustmentStale': '申请后订单已发生变化，请拒绝后重新申请',
+      'order.priceAdjustmentNoteRequired': '请填写拒绝原因',
+      'order.partialRefundNoteTooLong': '处理说明不能超过 {max} 个字符',
       'order.pendingPaymentLimitExceeded':
         '您当前有 {current} 个待支付订单，已达到上限 {max}，请先完成或取消已有订单',
       'order.externalUserIDLengthInvalid': '外部用户 ID 长度必须在 {min}-{max} 个字符之间',
@@ -726,12 +976,597 @@ export const zhTranslations = {
       'payment.pollingUserQueueLimitExceeded':
         '您当前有 {current} 个支付轮询任务，已达到上限 {max}',
       'payment.pollingGlobalQueueLimitExceeded': '系统支付轮询队列已满（上限 {max}），请稍后重试',
+      'payment.feeRateInvalid': '手续费比例需在 {min}% 到 {max}% 之间',
+    },
+  },
+
+  vendor: {
+    bizError: {
+      'vendor.nameInvalid': '商家名称不能为空且不能超过 {max} 个字符',
+      'vendor.commissionRateInvalid': '佣金比例需在 {min}% 到 {max}% 之间',
+      'vendor.statusInvalid': '无效的商家状态：{status}',
+      'vendor.adjustmentAmountInvalid': '调整金额不能为零',
+      'vendor.adjustmentNoteInvalid': '调整原因不能为空且不能超过 {max} 个字符',
+      'vendor.currencyRequired': '请填写币种',
+      'vendor.statementPeriodInvalid': '结算周期的截止时间必须早于当前时间且晚于开始时间',
+      'vendor.paymentReferenceTooLong': '打款凭证号不能超过 {max} 个字符',
+      'vendor.statementAlreadyPaid': '该结算单已标记为已打款',
+    },
+  },
+
+  revenue: {
+    bizError: {
+      'revenue.recognitionTypeInvalid': '无效的收入确认方式：{type}',
+      'revenue.servicePeriodInvalid': '服务期需在 1 到 {max} 个月之间',
+      'revenue.virtualProductRequired': '仅虚拟商品可以按服务期分期确认收入',
+    },
+  },
+
+  netTerms: {
+    bizError: {
+      'netTerms.disabled': '未启用账期付款',
+      'netTerms.companyNameInvalid': '公司名称不能为空且不能超过 {max} 个字符',
+      'netTerms.currencyRequired': '请填写币种',
+      'netTerms.creditLimitInvalid': '信用额度必须大于零',
+      'netTerms.termsDaysInvalid': '账期需在 1 到 {max} 天之间',
+      'netTerms.accountAlreadyApproved': '该用户已有审核通过的企业账户',
+      'netTerms.accountStatusInvalid': '当前账户状态（{status}）不允许此操作',
+      'netTerms.reviewNoteTooLong': '审核备注不能超过 {max} 个字符',
+      'netTerms.currencyChangeBlocked': '账户存在未付发票时不能修改币种',
+      'netTerms.orderStatusInvalid': '仅待付款订单可使用账期付款（当前状态：{status}）',
+      'netTerms.accountNotApproved': '账期付款需要审核通过的企业账户',
+      'netTerms.creditHold': '企业账户额度已冻结，请先结清逾期发票',
+      'netTerms.currencyMismatch': '账期付款仅适用于 {currency} 订单',
+      'netTerms.creditLimitExceeded': '订单金额超出可用额度（{available} {currency}）',
+      'netTerms.paymentReferenceTooLong': '收款凭证号不能超过 {max} 个字符',
+      'netTerms.voidReasonInvalid': '作废原因不能为空且不能超过 {max} 个字符',
+      'netTerms.invoiceNotOpen': '发票已处于 {status} 状态',
+      'netTerms.statementPeriodInvalid': '对账单结束日期需晚于开始日期',
+    },
+  },
+
+  cod: {
+    payOnDelivery: '货到付款',
+    payOnDeliveryDesc: '立即发货，签收时向快递员付款。',
+    amountDue: '签收时应付',
+    payOnDeliveryConfirm: '使用货到付款下单',
+    payOnDeliverySuccess: '下单成功，请在签收时向快递员付款',
+    payOnDeliveryFailed: '货到付款下单失败',
+    bizError: {
+      'cod.disabled': '未启用货到付款',
+      'cod.orderStatusInvalid': '仅待付款订单可选择货到付款（当前状态：{status}）',
+      'cod.virtualOnly': '纯虚拟商品订单不支持货到付款',
+      'cod.countryNotEligible': '{country} 不支持货到付款',
+      'cod.amountBelowMinimum': '货到付款要求订单金额不低于 {min} {currency}',
+      'cod.amountAboveMaximum': '货到付款仅支持不超过 {max} {currency} 的订单',
+      'cod.referenceTooLong': '收款回单号不能超过 {max} 个字符',
+      'cod.collectedAmountInvalid': '实收金额需在 0 到应收金额（{max} {currency}）之间',
+      'cod.orderNotShipped': '订单发货后才能登记代收款（当前状态：{status}）',
+      'cod.voidReasonInvalid': '作废原因不能为空且不能超过 {max} 个字符',
+      'cod.collectionNotOutstanding': '该代收记录已是 {status} 状态',
+    },
+  },
+
+  businessAccount: {
+    title: '企业账户',
+    applyTitle: '申请企业账户',
+    applyDesc: '审核通过的企业账户可使用账期付款：订单立即发货，在信用额度内按账期开具发票。',
+    companyName: '公司名称',
+    taxId: '税号',
+    billingEmail: '账单邮箱',
+    billingAddress: '账单地址',
+    applySubmit: '提交申请',
+    applySubmitted: '申请已提交，我们会尽快审核',
+    applyFailed: '提交申请失败',
+    statusPending: '审核中',
+    statusApproved: '已通过',
+    statusRejected: '已拒绝',
+    statusSuspended: '已停用',
+    pendingHint: '申请正在审核中，期间仍可使用常规支付方式下单。',
+    rejectedReason: '申请未通过：{reason}',
+    creditHold: '额度冻结',
+    creditHoldNotice: '账户额度已冻结，结清逾期发票后可恢复账期付款。',
+    creditLimit: '信用额度',
+    outstanding: '未付金额',
+    overdue: '逾期金额',
+    availableCredit: '可用额度',
+    termsSummary: '使用账期付款的订单，发票在下单后 {days} 天到期。',
+    invoices: '发票',
+    noInvoices: '暂无发票',
+    invoiceOpen: '未付',
+    invoicePaid: '已付',
+    invoiceVoid: '已作废',
+    invoiceOverdue: '已逾期',
+    dueOn: '{date} 到期',
+    statement: '对账单',
+    periodStart: '开始日期',
+    periodEnd: '结束日期',
+    openingBalance: '期初余额',
+    invoiced: '本期开票',
+    paid: '本期收款',
+    voided: '本期作废',
+    closingBalance: '期末余额',
+    payOnTerms: '账期付款',
+    payOnTermsDesc: '立即发货，{days} 天内凭发票付款。',
+    payOnTermsConfirm: '使用账期下单',
+    payOnTermsSuccess: '已使用账期付款，发票已开具',
+    payOnTermsFailed: '账期付款失败',
+    insufficientCredit: '可用额度不足以支付该订单。',
+  },
+
+  organization: {
+    title: '组织',
+    createTitle: '创建组织',
+    createDesc: '与同事共享订单可见性并以组织身份下单。创建后您将成为所有者，可通过邮件邀请成员。',
+    name: '组织名称',
+    create: '创建组织',
+    created: '组织已创建',
+    createFailed: '创建组织失败',
+    rename: '重命名',
+    renamed: '组织名称已更新',
+    saveFailed: '更新组织失败',
+    roleOwner: '所有者',
+    rolePurchaser: '采购成员',
+    roleViewer: '只读成员',
+    roleHint: '采购成员可在每月限额内下单；只读成员只能查看组织订单。',
+    members: '成员',
+    member: '成员',
+    role: '角色',
+    spendingLimit: '每月限额',
+    spentThisMonth: '本月已用',
+    unlimited: '不限',
+    spendingLimitHint: '留空或填 0 表示不限。已取消和已退款的订单不计入限额。',
+    you: '我',
+    editMember: '编辑成员',
+    transferOwnership: '设为所有者',
+    transferConfirm: '确定将所有权转让给 {email}？转让后您将成为采购成员，无法再管理成员。',
+    memberUpdated: '成员已更新',
+    memberUpdateFailed: '更新成员失败',
+    removeMember: '移除',
+    removeConfirm: '确定将 {email} 移出组织？',
+    memberRemoved: '成员已移除',
+    removeFailed: '移除成员失败',
+    leave: '退出组织',
+    leaveConfirm: '确定退出该组织？退出后将无法查看组织订单。',
+    leaveOwnerConfirm: '您是唯一的成员，退出后组织将被解散。',
+    left: '已退出组织',
+    leaveFailed: '退出组织失败',
+    invitations: '待接受的邀请',
+    noInvitations: '暂无待接受的邀请',
+    inviteTitle: '邀请成员',
+    inviteEmail: '邮箱',
+    invite: '发送邀请',
+    invited: '已向 {email} 发送邀请',
+    inviteFailed: '发送邀请失败',
+    expiresAt: '{date} 过期',
+    revoke: '撤销',
+    revoked: '邀请已撤销',
+    revokeFailed: '撤销邀请失败',
+    acceptTitle: '组织邀请',
+    acceptDesc: '您被邀请以{role}身份加入 {name}。',
+    accept: '接受邀请',
+    accepted: '已加入 {name}',
+    acceptFailed: '接受邀请失败',
+    invitationLoadFailed: '邀请无效或发送给了其他邮箱',
+    orders: '组织订单',
+    noOrders: '暂无组织订单',
+    orderPlacedBy: '下单成员',
+    allMembers: '全部成员',
+    bizError: {
+      'organization.nameInvalid': '组织名称不能为空且不能超过 {max} 个字符',
+      'organization.roleInvalid': '无效的成员角色：{role}',
+      'organization.spendingLimitInvalid': '消费限额不能为负数',
+      'organization.ownerRequired': '只有组织所有者可以执行此操作',
+      'organization.alreadyMember': '该账户已属于一个组织',
+      'organization.inviteEmailInvalid': '请输入有效的邮箱地址',
+      'organization.invitationExpired': '邀请已失效',
+      'organization.invitationEmailMismatch': '该邀请发送给了其他邮箱地址',
+      'organization.cannotChangeSelf': '不能修改自己的成员身份',
+      'organization.ownerCannotLeave': '请先转让所有权再退出组织',
+      'organization.checkoutNotAllowed': '您在组织中的角色不允许下单',
+      'organization.spendingLimitExceeded': '订单超出本月消费限额（剩余 {remaining} {currency}）',
+    },
+  },
+
+  apiTokens: {
+    title: 'API 令牌',
+    description:
+      '个人访问令牌可供脚本读取您的订单与虚拟商品。令牌为只读，不能下单或修改账户。',
+    createTitle: '创建令牌',
+    name: '令牌名称',
+    namePlaceholder: '例如：库存同步脚本',
+    scopes: '权限范围',
+    scopeOrdersRead: '读取订单',
+    scopeOrdersReadDesc: '查询订单列表与订单详情',
+    scopeVirtualProductsRead: '读取虚拟商品',
+    scopeVirtualProductsReadDesc: '获取已付款订单中已发放的虚拟商品内容',
+    expiry: '有效期',
+    expiryDays: '{days} 天',
+    expiryNever: '永不过期',
+    create: '创建令牌',
+    createFailed: '创建令牌失败',
+    createdTitle: '令牌已创建',
+    createdHint: '请立即复制此令牌，出于安全考虑，它不会再次显示。',
+    copied: '令牌已复制',
+    done: '完成',
+    tokens: '我的令牌',
+    noTokens: '暂无令牌',
+    lastUsed: '最后使用于 {date}',
+    neverUsed: '从未使用',
+    expiresOn: '{date} 过期',
+    expired: '已过期',
+    revoked: '已撤销',
+    revoke: '撤销',
+    revokeConfirm: '确定撤销令牌「{name}」？使用该令牌的脚本将立即失效。',
+    revokeSuccess: '令牌已撤销',
+    revokeFailed: '撤销令牌失败',
+    usageHint: '请求 /api/user/automation 接口时，在请求头中携带 "Authorization: Bearer <令牌>"。',
+    bizError: {
+      'personalToken.nameInvalid': '令牌名称不能为空且不能超过 {max} 个字符',
+      'personalToken.scopeInvalid': '不支持的权限范围：{scope}',
+      'personalToken.scopeRequired': '请至少选择一个权限范围',
+      'personalToken.expiryInvalid': '有效期需在 0 到 {max} 天之间',
+      'personalToken.limitReached': '最多只能同时拥有 {max} 个有效令牌',
+    },
+  },
+
+  siteBanner: {
+    title: '站点横幅',
+    description: '计划维护通知与政策变更，按时间窗口显示在每个页面顶部。',
+    create: '新建横幅',
+    edit: '编辑横幅',
+    type: '类型',
+    typeMaintenance: '计划维护',
+    typePolicy: '政策变更',
+    typeInfo: '一般通知',
+    audience: '展示对象',
+    audienceAll: '所有人',
+    audienceGuests: '仅未登录访客',
+    audienceUsers: '已登录用户',
+    audienceAdmins: '仅管理员',
+    priority: '优先级',
+    bannerTitle: '标题',
+    message: '内容',
+    linkUrl: '链接地址',
+    linkText: '链接文字',
+    schedule: '展示时间',
+    noEnd: '不结束',
+    status: '状态',
+    statusLive: '展示中',
+    statusScheduled: '未开始',
+    statusEnded: '已结束',
+    startsAt: '开始展示',
+    startsAtHint: '留空则立即展示。',
+    endsAt: '结束展示',
+    endsAtHint: '留空则一直展示到手动停用。',
+    maintenanceStartsAt: '维护开始',
+    maintenanceEndsAt: '维护结束',
+    maintenanceWindow: '维护时间：{start} – {end}',
+    localeEn: '英文',
+    localeZh: '中文',
+    defaultLocale: '回退语言：{locale}',
+    active: '启用',
+    dismissibleLabel: '允许用户关闭',
+    dismiss: '关闭',
+    learnMore: '了解详情',
+    saved: '横幅已保存',
+    saveFailed: '保存横幅失败',
+    deleted: '横幅已删除',
+    deleteFailed: '删除横幅失败',
+    deleteTitle: '删除横幅',
+    deleteConfirm: '删除后横幅将立即停止展示，确定继续吗？',
+    bizError: {
+      'siteBanner.typeInvalid': '无效的横幅类型：{type}',
+      'siteBanner.audienceInvalid': '无效的展示对象：{audience}',
+      'siteBanner.contentRequired': '请至少填写一种语言的内容',
+      'siteBanner.localeInvalid': '无效的语言：{locale}',
+      'siteBanner.titleTooLong': '横幅标题不能超过 {max} 个字符',
+      'siteBanner.messageInvalid': '横幅内容不能为空且不能超过 {max} 个字符',
+      'siteBanner.linkInvalid': '链接必须是站内路径（以 / 开头）或 http(s) 地址',
+      'siteBanner.scheduleInvalid': '结束时间必须晚于开始时间',
+      'siteBanner.maintenanceWindowInvalid': '维护结束时间必须晚于开始时间',
+    },
+  },
+  moderation: {
+    title: '内容审核',
+    description: '审核被标记或打码的用户文本，并管理用于检查订单备注与工单的词库',
+    queueTab: '审核队列',
+    rulesTab: '词库',
+    source: '来源',
+    sources: '生效来源',
+    allSources: '全部来源',
+    sourcesHint: '全部不勾选表示对所有来源生效',
+    sourceOrderRemark: '订单备注',
+    sourceTicketSubject: '工单标题',
+    sourceTicketContent: '工单正文',
+    sourceTicketMessage: '工单消息',
+    user: '用户',
+    content: '内容',
+    storedAs: '实际保存',
+    matches: '命中',
+    action: '处理方式',
+    actionMask: '打码',
+    actionFlag: '标记待审',
+    actionReject: '拒绝',
+    providerRules: '词库',
+    providerExternal: '外部审核',
+    status: '状态',
+    statusPending: '待审核',
+    statusApproved: '已保留',
+    statusRemoved: '已移除',
+    statusRejected: '提交时已拒绝',
+    approve: '保留',
+    remove: '移除',
+    approveTitle: '保留内容',
+    approveHint: '内容将按当前保存的文本保留。',
+    removeTitle: '移除内容',
+    removeHint: '内容将在原位置替换为“[Removed by moderator]”，此操作不可撤销。',
+    reviewNote: '审核备注（可选）',
+    reviewed: '审核结果已保存',
+    reviewFailed: '保存审核结果失败',
+    rulesHint: '关键词不区分大小写；多条规则同时命中时按最严格的处理方式执行。',
+    pattern: '匹配内容',
+    matchType: '匹配方式',
+    matchKeyword: '关键词',
+    matchRegex: '正则表达式',
+    note: '备注',
+    ruleActive: '启用',
+    ruleInactive: '停用',
+    createRule: '添加规则',
+    editRule: '编辑规则',
+    ruleSaved: '规则已保存',
+    ruleSaveFailed: '保存规则失败',
+    ruleDeleted: '规则已删除',
+    ruleDeleteFailed: '删除规则失败',
+    deleteRuleTitle: '删除规则',
+    deleteRuleConfirm: '删除后新提交的内容将不再按此规则检查，是否继续？',
+    bizError: {
+      'moderation.rejected': '内容违反社区规范，无法提交',
+      'moderation.patternInvalid': '匹配内容不能为空且不能超过 {max} 个字符',
+      'moderation.matchTypeInvalid': '无效的匹配方式：{type}',
+      'moderation.actionInvalid': '无效的处理方式：{action}',
+      'moderation.regexInvalid': '正则表达式无效',
+      'moderation.sourceInvalid': '无效的来源：{source}',
+      'moderation.decisionInvalid': '审核结果只能是保留或移除',
+      'moderation.caseAlreadyReviewed': '该内容已审核',
+    },
+  },
+  blocklist: {
+    title: '黑名单',
+    description: '禁止指定的 IP、邮箱、邮箱域名和手机号注册、登录或下单',
+    type: '类型',
+    allTypes: '全部类型',
+    typeIp: 'IP / 网段',
+    typeEmail: '邮箱',
+    typeEmailDomain: '邮箱域名',
+    typePhone: '手机号',
+    value: '值',
+    valueHint: 'IP 支持 CIDR 网段，如 203.0.113.0/24；邮箱域名同时匹配其子域名',
+    reason: '原因',
+    reasonHint: '仅管理员可见，被拦截的用户只会看到通用提示',
+    expiresAt: '到期时间',
+    expiresAtHint: '留空表示永久有效',
+    never: '永久',
+    expired: '已过期',
+    matches: '命中',
+    scopeRegister: '注册',
+    scopeLogin: '登录',
+    scopeOrder: '下单',
+    searchPlaceholder: '搜索值或原因',
+    create: '添加条目',
+    edit: '编辑条目',
+    saved: '条目已保存',
+    saveFailed: '保存条目失败',
+    deleted: '条目已删除',
+    deleteFailed: '删除条目失败',
+    deleteTitle: '删除条目',
+    deleteConfirm: '删除后将不再拦截 {value}，是否继续？',
+    import: '导入',
+    importHint:
+      '上传包含 value 列的 .csv 或 .xlsx 文件，可选 type、reason、expires_at 列；已存在的条目会被更新',
+    importFile: '文件',
+    importDefaultType: '默认类型',
+    importTypeFromFile: '使用文件中的 type 列',
+    importSummary: '导入完成：新增 {created} 条，更新 {updated} 条，失败 {errors} 条',
+    importFailed: '导入失败',
+    bizError: {
+      'blocklist.blocked': '当前请求无法完成，如有疑问请联系客服',
+      'blocklist.typeInvalid': '无效的黑名单类型：{type}',
+      'blocklist.valueInvalid': '无效的{type}值：{value}',
+      'blocklist.reasonTooLong': '原因不能超过 {max} 个字符',
+      'blocklist.expiresInPast': '到期时间必须晚于当前时间',
+      'blocklist.duplicate': '{value} 已在黑名单中',
+    },
+  },
+  risk: {
+    title: '风控审核',
+    description: '频率规则检查新订单中的滥用行为，命中的订单进入审核队列',
+    queueTab: '审核队列',
+    rulesTab: '频率规则',
+    order: '订单',
+    user: '用户',
+    hits: '命中规则',
+    action: '处理方式',
+    allActions: '全部处理方式',
+    status: '状态',
+    actionFlag: '标记待审',
+    actionHold: '暂扣发货',
+    actionHint: '暂扣的订单在审核通过前不能发货或自动发货；标记的订单正常流转',
+    statusPending: '待审核',
+    statusApproved: '已通过',
+    statusRejected: '已拒绝',
+    metric: '指标',
+    metricOrdersPerIp: '同一 IP 的订单数',
+    metricOrdersPerUser: '同一账户的订单数',
+    metricAccountsPerIp: '同一 IP 下单的账户数',
+    metricAccountsPerAddress: '同一收货地址的账户数',
+    threshold: '阈值',
+    thresholdHint: '统计值（包含当前订单）超过阈值时命中。时间窗口为 0 表示统计全部历史。',
+    windowMinutes: '时间窗口（分钟）',
+    windowMinutesValue: '{minutes} 分钟内',
+    windowAllTime: '不限时间',
+    condition: '条件',
+    hitCount: '命中次数',
+    ruleName: '名称',
+    ruleActive: '启用',
+    ruleInactive: '停用',
+    rulesHint: '规则在下单时检查，提交收货地址后会再检查一次',
+    createRule: '添加规则',
+    editRule: '编辑规则',
+    ruleSaved: '规则已保存',
+    ruleSaveFailed: '保存规则失败',
+    ruleDeleted: '规则已删除',
+    ruleDeleteFailed: '删除规则失败',
+    deleteRuleTitle: '删除规则',
+    deleteRuleConfirm: '已有的审核记录会保留，确定继续吗？',
+    approve: '通过',
+    reject: '拒绝',
+    approveTitle: '审核通过',
+    approveHint: '解除暂扣，订单可以正常发货。',
+    rejectTitle: '审核拒绝',
+    rejectHint: '未付款订单将被取消；已付款订单保持暂扣，可在订单详情中退款。',
+    reviewNote: '备注',
+    reviewed: '审核已保存',
+    reviewedCancelled: '审核已保存，订单已取消',
+    reviewFailed: '保存审核失败',
+    orderHeldTitle: '订单已被风控暂扣',
+    orderFlaggedTitle: '订单命中频率规则',
+    orderRejected: '风控审核已拒绝。',
+    openQueue: '前往审核队列',
+    bizError: {
+      'velocity.nameInvalid': '规则名称不能为空且不能超过 {max} 个字符',
+      'velocity.metricInvalid': '无效的频率指标：{metric}',
+      'velocity.thresholdInvalid': '阈值至少为 1',
+      'velocity.windowInvalid': '时间窗口必须在 0 到 {max} 分钟之间',
+      'velocity.actionInvalid': '无效的处理方式：{action}',
+      'velocity.decisionInvalid': '审核结果必须为通过或拒绝',
+      'velocity.noteTooLong': '审核备注不能超过 {max} 个字符',
+      'velocity.reviewAlreadyDone': '该订单已审核',
+    },
+  },
+  inboundWebhook: {
+    title: '入站 Webhook',
+    description: '接收银行、物流商和客服系统推送的事件，并通过脚本映射为订单和工单动作。',
+    create: '新建 Webhook',
+    edit: '编辑 Webhook',
+    name: '名称',
+    slug: '标识',
+    descriptionLabel: '说明',
+    authMode: '校验方式',
+    authHmac: 'HMAC-SHA256 签名',
+    authHeader: '请求头密钥',
+    authHint:
+      'HMAC 模式要求请求头携带原始请求体的十六进制 HMAC-SHA256 签名（可带 sha256= 前缀）；请求头模式直接比对请求头与密钥。',
+    signatureHeader: '签名请求头',
+    eventIdHeader: '事件 ID 请求头',
+    secret: '密钥',
+    secretHint: '留空自动生成，至少 16 个字符。',
+    secretKeepHint: '留空保持当前密钥不变。',
+    secretTitle: 'Webhook 密钥',
+    secretOnce: '请立即复制，密钥不会再次显示。',
+    allowedActions: '允许的动作',
+    allowedActionsHint: '脚本返回未在此放行的动作时，该动作记为失败。',
+    actionMarkPaid: '标记订单已付款',
+    actionAddTracking: '填写物流单号',
+    actionTicketNote: '追加工单备注',
+    script: '转换脚本',
+    scriptHint:
+      '定义 transform(event)，返回 null、单个动作或动作数组。脚本 2 秒超时，只能调用 AuraLogic.system.log。',
+    testPayload: '示例负载',
+    runTest: '运行脚本',
+    testHint: '试运行只展示映射出的动作，不会实际执行。',
+    testFailed: '脚本运行失败',
+    enabled: '启用',
+    disabled: '已停用',
+    lastReceived: '最近接收',
+    inbox: '收件箱',
+    inboxTitle: '收件箱 · {name}',
+    inboxHint: '重放会使用当前脚本重新处理事件并执行动作。',
+    receivedAt: '接收时间',
+    status: '状态',
+    results: '处理结果',
+    payload: '负载',
+    attempts: '已处理 {count} 次',
+    statusReceived: '已接收',
+    statusProcessed: '已处理',
+    statusPartial: '部分成功',
+    statusFailed: '失败',
+    statusIgnored: '已忽略',
+    replay: '重放',
+    replayed: '事件已重放',
+    replayFailed: '重放事件失败',
+    saved: 'Webhook 已保存',
+    saveFailed: '保存 Webhook 失败',
+    deleted: 'Webhook 已删除',
+    deleteFailed: '删除 Webhook 失败',
+    deleteTitle: '删除 Webhook',
+    deleteConfirm: '删除「{name}」及其事件记录？之后发送方将收到 404。',
+    rotate: '轮换',
+    rotateTitle: '轮换密钥',
+    rotateConfirm: '当前密钥将立即失效，是否继续？',
+    rotateFailed: '轮换密钥失败',
+    copied: '已复制',
+    bizError: {
+      'inbound_webhook.notFound': '入站 Webhook 不存在',
+      'inbound_webhook.eventNotFound': '入站事件不存在',
+      'inbound_webhook.nameRequired': '名称不能为空',
+      'inbound_webhook.slugInvalid': '标识须为 2-64 位小写字母、数字、连字符或下划线',
+      'inbound_webhook.slugTaken': '该标识已被其他入站 Webhook 使用',
+      'inbound_webhook.authModeInvalid': '校验方式必须为 hmac_sha256 或 header',
+      'inbound_webhook.actionsInvalid': '至少需要允许一个受支持的动作',
+      'inbound_webhook.scriptRequired': '转换脚本不能为空',
+      'inbound_webhook.scriptInvalid': '转换脚本错误：{error}',
+      'inbound_webhook.secretTooShort': '密钥至少需要 16 个字符',
+    },
+  },
+  integration: {
+    bizError: {
+      'integration.orderNotFound': '订单不存在',
+      'integration.ticketNotFound': '工单不存在',
+      'integration.tagInvalid': '标签须为 1-{max} 个字符且不能包含逗号',
+      'integration.tagLimit': '每个订单最多 {max} 个标签',
+    },
+  },
+  validation: {
+    bizError: {
+      'validation.required': '{field} 为必填项',
+      'validation.email': '{field} 必须是有效的邮箱地址',
+      'validation.url': '{field} 必须是有效的 URL',
+      'validation.oneof': '{field} 必须是以下值之一：{param}',
+      'validation.minLength': '{field} 至少需要 {param} 个字符',
+      'validation.maxLength': '{field} 不能超过 {param} 个字符',
+      'validation.length': '{field} 必须为 {param} 个字符',
+      'validation.minItems': '{field} 至少需要 {param} 项',
+      'validation.maxItems': '{field} 不能超过 {param} 项',
+      'validation.lengthItems': '{field} 必须为 {param} 项',
+      'validation.min': '{field} 不能小于 {param}',
+      'validation.max': '{field} 不能大于 {param}',
+      'validation.equal': '{field} 必须等于 {param}',
+      'validation.greaterThan': '{field} 必须大于 {param}',
+      'validation.lessThan': '{field} 必须小于 {param}',
+      'validation.type': '{field} 类型不正确',
+      'validation.invalid': '{field} 格式不正确',
+      'validation.bodyRequired': '请求体不能为空',
+      'validation.malformed': '请求体不是有效的 JSON',
+      'validation.invalidRequest': '请求参数无效',
+    },
+  },
+  accounting: {
+    bizError: {
+      'accounting.providerNotConfigured':
+        '尚未配置会计系统，请先设置 order.accounting_export.provider',
+      'accounting.accountMappingMissing': '未配置会计科目映射 "{field}"',
+      'accounting.exportRunning': '会计分录正在推送中，请稍后再试',
+      'accounting.credentialsMissing': '未配置 {provider} 的 OAuth 凭据',
+      'accounting.providerInvalid': '不支持的会计系统：{provider}',
     },
   },
 
   profile: {
     profile: '个人资料',
     profileCenter: '个人中心',
+    emailVerificationPending: '您的邮箱尚未验证，部分功能可能受限，请尽快完成验证',
+    verifyEmailNow: '立即验证',
     editProfile: '编辑资料',
     username: '用户名',
     name: '姓名',
@@ -887,6 +1722,12 @@ export const zhTranslations = {
     contentLimitDesc: '最多可填写 {max} 个字符',
     contentUnlimited: '当前内容长度不受限制',
     relatedOrder: '关联订单(可选)',
+    relatedOrderRequired: '关联订单 *',
+    intakeScreenshots: '截图',
+    intakeScreenshotsHint: '请至少上传 {min} 张截图',
+    intakeUploadScreenshot: '添加截图',
+    intakeRemoveScreenshot: '移除',
+    intakeSelectPlaceholder: '请选择',
     selectOrder: '选择要关联的订单',
     noRelatedOrder: '不关联订单',
     relatedOrderTip: '关联订单后，客服可以查看该订单信息以便更好地帮助您',
@@ -916,6 +1757,14 @@ export const zhTranslations = {
     selectOrderPlaceholder: '请选择订单',
     alreadyShared: '(已分享)',
     shareOrderTip: '分享后，客服将能够查看该订单的详细信息以便为您提供帮助。',
+    shareDuration: '分享有效期',
+    shareDurationForever: '直到手动取消',
+    shareDuration24h: '24 小时',
+    shareDuration3d: '3 天',
+    shareDuration7d: '7 天',
+    shareDuration30d: '30 天',
+    shareIncludeReceiverInfo: '包含收货信息',
+    shareIncludeReceiverInfoDesc: '允许客服查看收货人姓名、联系方式和详细地址。',
     sharing: '分享中...',
     confirmShare: '确认分享',
     shareSuccess: '订单分享成功',
@@ -938,6 +1787,12 @@ export const zhTranslations = {
     messagePlaceholder: '输入消息... (Enter 发送, Shift+Enter 换行)',
     uploadImage: '上传图片',
     uploadFailed: '上传失败',
+    attachFile: '添加附件',
+    removeAttachment: '移除附件',
+    deleteTicket: '删除工单',
+    deleteTicketConfirm: '工单将移入回收站，保留期内可恢复。是否继续？',
+    deleteTicketSuccess: '工单已移入回收站',
+    deleteTicketFailed: '删除工单失败',
     recordVoice: '录制语音',
     recording: '录制中',
     recordingTip: '点击停止',
@@ -965,6 +1820,21 @@ export const zhTranslations = {
       'ticket.imageUploadDisabled': '当前不允许上传图片',
       'ticket.imageFileTooLarge': '图片大小不能超过 {max}MB',
       'ticket.imageFormatUnsupported': '图片格式不受支持',
+      'ticket.fileUploadDisabled': '当前不允许上传文件附件',
+      'ticket.fileTooLarge': '文件大小不能超过 {max}MB',
+      'ticket.fileTypeUnsupported': '文件类型不受支持',
+      'ticket.tooManyAttachments': '每条消息最多添加 {max} 个附件',
+      'ticket.messageEmpty': '请输入消息内容或添加附件',
+      'ticket.attachmentLinkExpired': '下载链接无效或已过期',
+      'ticket.intakeFieldRequired': '请填写{field}',
+      'ticket.intakeFieldInvalid': '{field}的值无效',
+      'ticket.intakeFieldTooLong': '{field}不能超过{max}个字符',
+      'ticket.orderRequired': '请选择相关订单',
+      'ticket.attachmentRequired': '请至少上传{min}张截图',
+      'ticket.attachmentInvalid': '附件无效',
+      'ticket.ratingInvalid': '评分必须在 1 到 5 之间',
+      'ticket.ratingNotAllowed': '仅已解决或已关闭的工单可以评价',
+      'ticket.alreadyRated': '该工单已评价过',
     },
     items: '商品',
     ticketStatus: {
@@ -1023,6 +1893,29 @@ export const zhTranslations = {
     updateSuccess: '更新成功',
     updateFailed: '更新失败',
     adminMessagePlaceholder: '输入消息... (Enter 发送, Shift+Enter 换行，支持 Markdown)',
+    // 满意度评价
+    rateTitle: '您对本次工单的服务满意吗？',
+    rateCommentPlaceholder: '还有什么想告诉我们的吗？（选填）',
+    rateSubmit: '提交评价',
+    rateSuccess: '感谢您的反馈',
+    rateFailed: '评价提交失败',
+    ratedLabel: '您的评价',
+    // 客服绩效
+    agentPerformance: '客服绩效',
+    agentPerformanceDesc: '按客服统计首次响应、解决时长与满意度，每日（UTC）汇总。',
+    perfStartDate: '开始日期',
+    perfEndDate: '结束日期',
+    perfGroupByAgent: '按客服',
+    perfGroupByDay: '按日期',
+    perfDate: '日期',
+    perfTicketsHandled: '处理工单数',
+    perfAvgFirstResponse: '平均首次响应',
+    perfResolved: '已解决',
+    perfAvgResolution: '平均解决时长',
+    perfCsat: '满意度',
+    perfNoData: '所选时间范围内暂无数据',
+    perfExport: '导出 CSV',
+    perfExportSuccess: '报表已导出',
   },
 
   admin: {
@@ -1033,19 +1926,51 @@ export const zhTranslations = {
     productManagement: '商品管理',
     inventoryManagement: '库存管理',
     orderManagement: '订单管理',
+    vendorManagement: '商家结算',
+    businessAccountManagement: '企业账户',
+    codManagement: '货到付款',
     serialManagement: '序列号管理',
     userManagement: '用户管理',
     ticketManagement: '工单管理',
     paymentMethods: '付款方式',
+    inboundWebhookManagement: '入站 Webhook',
     apiKeys: 'API密钥',
     systemLogs: '系统日志',
     systemSettings: '系统设置',
     analytics: '数据分析',
     promoCodeManagement: '优惠码',
+    giftCardManagement: '礼品卡',
+    quoteManagement: '报价单',
+    returnManagement: '退货管理',
+    disputeManagement: '争议裁决',
+    policyManagement: '条款与隐私政策',
     knowledgeManagement: '知识库管理',
     announcementManagement: '公告管理',
+    siteBannerManagement: '站点横幅',
+    moderationManagement: '内容审核',
+    blocklistManagement: '黑名单',
+    riskManagement: '风控审核',
     marketingManagement: '营销管理',
     pluginManagement: '插件管理',
+    trashManagement: '回收站',
+
+    // 回收站
+    trashDescription: '已删除的商品、优惠码和虚拟库存会在回收站保留至保留期结束，之后彻底删除。',
+    trashTypeProduct: '商品',
+    trashTypePromoCode: '优惠码',
+    trashTypeVirtualInventory: '虚拟库存',
+    trashTypeTicket: '工单',
+    trashName: '名称',
+    trashIdentifier: 'SKU / 优惠码',
+    trashDeletedBy: '删除人',
+    trashDeletedAt: '删除时间',
+    trashPurgeAt: '彻底删除时间',
+    trashRestore: '恢复',
+    trashRestored: '已恢复',
+    trashRestoreFailed: '恢复失败',
+    trashConfirmRestore: '确定恢复「{name}」吗？恢复后将重新出现在对应列表中。',
+    trashEmpty: '回收站为空',
+    trashSystem: '系统',
 
     // 营销
     marketingDescription: '向全部用户、规则筛选用户或指定用户发送营销邮件/短信。',
@@ -2299,9 +3224,30 @@ export const zhTranslations = {
         '插件包上传成功，但激活失败：{cause}',
       'admin.emailAlreadyInUse': '该邮箱已被使用',
       'admin.notFound': '管理员不存在',
+      'admin.userNotFound': '用户不存在',
       'admin.userNotAdmin': '该用户不是管理员',
       'admin.cannotModifySelfRoleOrStatus': '不能修改自己的角色或启用状态',
       'admin.cannotDeleteSelf': '不能删除当前登录管理员',
+      'trash.entityTypeInvalid': '不支持的回收站类型',
+      'trash.itemNotFound': '回收站中不存在该项目',
+      'templateAsset.nameInvalid': '资源名称只能包含小写字母、数字、- 和 _（最多 64 个字符）',
+      'templateAsset.typeNotAllowed': '模板资源不允许上传 {ext} 类型的文件',
+      'templateAsset.tooLarge': '模板资源不能超过 {max_mb}MB',
+      'templateAsset.empty': '模板资源文件为空',
+      'templateAsset.notFound': '模板资源不存在',
+      'templateAsset.inUse': '该资源正被用作公司 Logo，请先修改 Logo 设置再删除',
+      'document.keyInvalid':
+        '文档标识只能包含小写字母、数字、- 和 _（最多64个字符），且不能与内置文档重名',
+      'document.keyTaken': '该标识的文档类型已存在',
+      'document.nameRequired': '请填写文档名称',
+      'document.bindingInvalid': '文档只能按订单或按商品生成',
+      'document.templateInvalid': '文档模板无效：{cause}',
+      'document.fieldInvalid': '自定义字段名 {field} 无效',
+      'document.statusInvalid': '未知的订单状态 {status}',
+      'document.notFound': '文档类型不存在',
+      'document.notAvailable': '该订单当前无法生成此文档',
+      'document.itemInvalid': '所选商品无法生成此文档',
+      'document.tokenInvalid': '下载链接无效或已过期',
     },
 
     // 通用
@@ -2366,9 +3312,46 @@ export const zhTranslations = {
     userAnalytics: '用户分析',
     orderAnalytics: '订单分析',
     revenueAnalytics: '收入分析',
+    funnelAnalytics: '转化漏斗',
+    funnelDesc: '第一方事件统计，不使用 Cookie。访客 30 分钟无操作即结束会话，爬虫访问不计入。',
+    funnelSessions: '会话数',
+    funnelPageViews: '页面浏览量',
+    funnelConversion: '下单转化率',
+    funnelStages: '转化漏斗',
+    funnelFromPrevious: '上一步的 {rate}%',
+    funnelStageVisit: '访问',
+    funnelStageAddToCart: '加入购物车',
+    funnelStageCheckoutCart: '打开购物车',
+    funnelStageCheckoutSubmit: '提交订单',
+    funnelStageCheckoutPlaced: '下单成功',
+    funnelStageCheckoutPayment: '选择付款方式',
+    funnelDailyTrend: '每日会话',
+    funnelPlacedSessions: '下单会话',
+    funnelEntryPages: '热门入口页面',
     overview: '概览',
     trend: '趋势',
     distribution: '分布',
+    // SKU 销售报表
+    skuSales: 'SKU 销售',
+    skuSalesDesc: '按 SKU 统计销量、销售额、退款率、库存周转与售罄率，每日（UTC）汇总。',
+    skuSalesStartDate: '开始日期',
+    skuSalesEndDate: '结束日期',
+    skuSalesSkuFilter: '筛选 SKU',
+    skuSalesGroupBySku: '按 SKU',
+    skuSalesGroupByDay: '按日',
+    skuSalesGroupByMonth: '按月',
+    skuSalesPeriod: '周期',
+    skuSalesProduct: '商品',
+    skuSalesUnitsSold: '销量',
+    skuSalesRevenue: '销售额',
+    skuSalesRefundRate: '退款率',
+    skuSalesVelocity: '日均销量',
+    skuSalesEndingStock: '期末库存',
+    skuSalesStockTurn: '库存周转',
+    skuSalesSellThrough: '售罄率',
+    skuSalesNoData: '所选时间范围内暂无数据',
+    skuSalesExport: '导出 CSV',
+    skuSalesExportSuccess: '报表已导出',
     // 用户分析
     totalUsersCount: '总用户数',
     activeUsersCount: '活跃用户',
@@ -2434,6 +3417,7 @@ export const zhTranslations = {
     logResourceMarketingBatch: '营销批次',
     logResourcePlugin: '插件',
     logResourceSystemConfig: '系统配置',
+    logResourceAdminAudit: '管理端审计',
     logActionRegister: '注册',
     logActionVerifyEmail: '验证邮箱',
     logActionAssignTracking: '分配物流单号',
@@ -2482,6 +3466,10 @@ export const zhTranslations = {
     logActionTicketAutoCloseStart: '启动工单自动关闭',
     logActionTicketAutoCloseStop: '停止工单自动关闭',
     logActionTicketAutoClose: '工单自动关闭',
+    logActionJobSchedulerStart: '周期任务调度器启动',
+    logActionJobSchedulerStop: '周期任务调度器停止',
+    logActionRestore: '从回收站恢复',
+    logActionTrashPurge: '回收站清理',
 
     // 商品管理
     addProduct: '添加商品',
@@ -2529,6 +3517,9 @@ export const zhTranslations = {
     batchConfirmTitle: '确认批量操作',
     batchSuccess: '批量操作完成：{success} 成功，{failed} 失败',
     batchSelectionPreviewMore: '还有 {count} 项未展开',
+    batchPreviewLoading: '正在检查将被修改的订单...',
+    batchPreviewSummary: '{affected} 个订单将被修改，{skipped} 个将被跳过',
+    batchPreviewFailed: '批量操作预览失败',
     selectAll: '全选当前页',
     deselectAll: '取消选择',
     downloadTemplate: '下载模板',
@@ -2602,6 +3593,11 @@ export const zhTranslations = {
     unverified: '未验证',
     withPhone: '有手机号',
     withoutPhone: '无手机号',
+    userFilterEmailRisk: '筛选：邮箱风险',
+    emailRiskFlagged: '已标记',
+    emailRiskClean: '未标记',
+    emailRiskDisposable: '一次性邮箱',
+    emailRiskNoMX: '邮箱域名无 MX 记录',
     viewOrders: '查看订单',
     createOrderForUser: '创建订单',
     createOrderTitle: '为用户创建订单',
@@ -2671,17 +3667,23 @@ export const zhTranslations = {
     permOrderDelete: '删除订单',
     permOrderStatusUpdate: '更新订单状态',
     permOrderRefund: '订单退款',
+    permOrderPriceAdjust: '申请订单改价',
+    permOrderPriceApprove: '审批订单改价',
+    permOrderRiskReview: '风控审核与频率规则管理',
     permOrderAssignTracking: '分配物流单号',
     permOrderRequestResubmit: '要求重填信息',
     permProductView: '查看商品',
     permProductEdit: '编辑商品',
     permProductDelete: '删除商品',
+    permProductScriptApprove: '审批发货脚本',
     permUserView: '查看用户',
     permUserEdit: '编辑用户',
     permUserPermission: '修改用户权限',
+    permUserBlocklist: '管理黑名单',
     permTicketView: '查看工单',
     permTicketReply: '回复工单',
     permTicketStatusUpdate: '更新工单状态',
+    permTicketDispute: '裁决订单争议',
     permAdminCreate: '创建管理员',
     permAdminEdit: '编辑管理员',
     permAdminDelete: '删除管理员',
@@ -2699,16 +3701,28 @@ export const zhTranslations = {
     permPluginUpload: '上传插件包',
     permCategoryKnowledge: '知识库权限',
     permCategoryAnnouncement: '公告权限',
+    permCategoryModeration: '内容审核权限',
     permCategoryMarketing: '营销权限',
     permKnowledgeView: '查看知识库',
     permKnowledgeEdit: '编辑知识库',
     permAnnouncementView: '查看公告',
     permAnnouncementEdit: '编辑公告',
+    permModerationView: '查看审核队列',
+    permModerationManage: '审核内容与管理词库',
     permMarketingView: '查看营销管理',
     permMarketingSend: '发送营销消息',
     permCategorySerial: '序列号权限',
     permSerialView: '查看序列号',
     permSerialManage: '管理序列号',
+    permCategoryUsage: '用量计量权限',
+    permUsageView: '查看用量记录',
+    permUsageReport: '上报卡密用量',
+    permCategoryVendor: '商家结算权限',
+    permVendorView: '查看商家与结算单',
+    permVendorManage: '管理商家与打款',
+    permCategoryBusinessAccount: '企业账户权限',
+    permBusinessAccountView: '查看企业账户与发票',
+    permBusinessAccountManage: '管理账期条款与发票',
     permSelectAll: '全选',
     permDeselectAll: '取消全选',
     permInvertSelection: '反选',
@@ -2763,6 +3777,78 @@ export const zhTranslations = {
     inventoryTypeStaticDesc: '预先导入的卡密/激活码，订单付款后从库存中分配',
     inventoryTypeScriptDesc: 'JavaScript脚本在订单付款后动态生成发货内容',
     scriptLabel: '发货脚本',
+    scriptRevisions: '脚本修订',
+    scriptRevisionsDesc: '发货脚本可以任意发起外部 HTTP 请求，脚本修改需由另一名管理员批准后才会生效。',
+    scriptRevisionPending: '待审批',
+    scriptRevisionStatusApproved: '已批准',
+    scriptRevisionStatusRejected: '已驳回',
+    scriptRevisionSuperseded: '已被取代',
+    scriptRevisionViewDiff: '查看差异',
+    scriptRevisionDiffTitle: '脚本修订 #{id}',
+    scriptRevisionDiffDesc: '与提交时生效脚本的差异。',
+    scriptRevisionConfig: '脚本配置',
+    scriptRevisionStaleHint: '提交后生效脚本已被修改，请驳回后重新提交。',
+    scriptRevisionNotePlaceholder: '审批备注（可选）',
+    scriptRevisionApprove: '批准并生效',
+    scriptRevisionReject: '驳回',
+    scriptRevisionApproved: '脚本修订已批准并生效',
+    scriptRevisionRejected: '脚本修订已驳回',
+    scriptRevisionReviewFailed: '审批脚本修订失败',
+    scriptRevisionSubmitted: '已保存，脚本修改需另一名管理员批准后生效',
+    scriptExecutions: '脚本执行记录',
+    scriptExecutionsDesc: '真实订单每次执行发货脚本的控制台输出、HTTP 调用与结果',
+    scriptExecutionFailedOnly: '仅看失败',
+    scriptExecutionEmpty: '没有符合条件的执行记录',
+    scriptExecutionSuccess: '成功',
+    scriptExecutionFailed: '失败',
+    scriptExecutionReplayed: '已重放',
+    scriptExecutionView: '详情',
+    scriptExecutionTitle: '脚本执行 #{id}',
+    scriptExecutionSummary: '{order} · {items}/{quantity} 项 · {duration}ms · 脚本 {hash}',
+    scriptExecutionReplayOf: '重放自执行 #{id}',
+    scriptExecutionConsole: '控制台输出',
+    scriptExecutionHttpCalls: 'HTTP 调用',
+    scriptExecutionNoEvents: '无记录',
+    scriptExecutionReplayHint: '重放会使用当前脚本为该订单发货所有剩余的虚拟库存',
+    scriptExecutionReplay: '重放发货',
+    scriptExecutionReplaySucceeded: '重放成功，订单已发货',
+    scriptExecutionReplayStillFailing: '脚本再次执行失败',
+    scriptExecutionReplayFailed: '重放脚本执行失败',
+    revealContent: '查看明文（将记录审计日志）',
+    hideContent: '隐藏内容',
+    revealContentFailed: '查看内容失败',
+    restockTitle: '自动补货',
+    restockDesc: '可用库存低于阈值时执行供应商脚本采购新卡密',
+    restockEnabled: '启用',
+    restockAvailable: '可用库存',
+    restockSpentToday: '今日花费',
+    restockSpentMonth: '本月花费',
+    restockLastRun: '上次执行',
+    restockLastError: '上次补货失败（连续 {count} 次）',
+    restockCapReachedHint: '已达到消费上限，下个周期恢复自动采购',
+    restockThreshold: '可用库存低于',
+    restockQuantity: '每次采购数量',
+    restockCooldown: '冷却时间（分钟，0 为默认 30）',
+    restockDailyCap: '每日消费上限',
+    restockMonthlyCap: '每月消费上限',
+    restockCapUnlimited: '不限制',
+    restockScript: '供应商脚本',
+    restockScriptHint:
+      '定义 onRestock(request, config)，返回 { success, items, cost_minor, reference }。request 包含 quantity、available、currency、budget_minor 和 inventory',
+    restockScriptConfig: '脚本配置（JSON）',
+    restockSaved: '补货策略已保存',
+    restockSaveFailed: '保存补货策略失败',
+    restockRunNow: '立即补货',
+    restockRunning: '采购中...',
+    restockRunSucceeded: '补货完成，新增 {count} 条卡密',
+    restockRunFailed: '补货失败',
+    restockRuns: '采购记录',
+    restockRunsEmpty: '暂无采购记录',
+    restockRunSuccess: '成功',
+    restockRunFailure: '失败',
+    restockTriggerAuto: '自动',
+    restockTriggerManual: '手动',
+    restockRunSummary: '新增 {added}/{requested} · 重复 {duplicates} · 无效 {invalid} · 花费 {cost}',
     scriptExamples: '示例脚本',
     scriptExampleBasic: '基础 - 生成随机激活码',
     scriptExampleHttp: 'HTTP - 调用外部API发货',
@@ -2787,6 +3873,17 @@ export const zhTranslations = {
     scriptTestContent: '内容',
     scriptTestRemark: '备注',
     scriptTestNoItems: '未生成任何项目',
+    scriptDryRunHttpLive: '真实请求',
+    scriptDryRunHttpMock: '模拟请求',
+    scriptDryRunMocksLabel: 'HTTP 模拟响应',
+    scriptDryRunMocksDesc:
+      'JSON 数组，每项为 {method, url, status, body}，url 以 * 结尾时按前缀匹配。未匹配的请求直接失败，不会访问供应商',
+    scriptDryRunInvalidMocks: 'HTTP 模拟响应必须是合法的 JSON 数组',
+    scriptDryRunLogs: '试运行日志',
+    scriptDryRunWaiting: '等待脚本输出...',
+    scriptDryRunMocked: '模拟',
+    scriptDryRunStorageNotSaved: '未保存',
+    scriptDryRunTruncated: '后续输出已截断',
     scriptApiRef: 'API 参考',
     scriptApiRefDesc: '发货脚本中可用的 API',
     scriptRequiredCallback: '必需的回调函数',
@@ -2798,6 +3895,12 @@ export const zhTranslations = {
     scriptGetUser: '获取下单用户信息',
     scriptUtilsApi: '工具函数',
     scriptHttpApi: 'HTTP 请求',
+    scriptStorageApi: '持久化存储',
+    scriptStorageApiDesc:
+      '按当前库存隔离、跨次执行保留的字符串存储。键最长 191 字符，值最大 32KB，最多 1000 个键。测试与试运行不会写入真实存储。',
+    scriptCryptoApi: '摘要与签名',
+    scriptCryptoApiDesc:
+      '原生实现的摘要、HMAC 与编码工具，用于供应商接口签名；encoding 为 hex（默认）、base64 或 base64url',
     scriptConfigJsonLabel: '配置（JSON）',
     scriptConfigFieldsLabel: '配置字段',
     scriptConfigJsonEditor: 'JSON 编辑器',
@@ -2857,6 +3960,7 @@ export const zhTranslations = {
     totalInventory: '总库存',
     statusAvailable: '可用',
     statusReserved: '已预留',
+    statusHeld: '付款暂扣',
     statusSold: '已售出',
     statusInvalid: '已失效',
     descriptionLabel: '描述',
@@ -2864,6 +3968,138 @@ export const zhTranslations = {
     allowInlineIframe: '允许返回内联 iframe',
     allowInlineIframeHint:
       '仅脚本型虚拟库存生效。开启后，脚本可返回 presentation.inline_iframe，在订单详情中显示专属面板。',
+    supplierHealth: '上游接口健康',
+    supplierHealthDesc:
+      '发货脚本调用的上游接口成功率与延迟。错误率超过配置阈值时，对应库存会被自动暂停并视为缺货。',
+    supplierHealthEmpty: '该时间窗口内没有上游调用记录',
+    supplierHealthWindowMinutes: '最近 {count} 分钟',
+    supplierHealthWindowHours: '最近 {count} 小时',
+    supplierHealthInventory: '库存',
+    supplierHealthEndpoint: '接口',
+    supplierHealthSuccessRate: '成功率',
+    supplierHealthLatency: '平均 / 最大延迟',
+    supplierHealthLastSeen: '最近调用',
+    supplierHealthPaused: '已暂停',
+    supplierHealthActive: '正常',
+    supplierHealthResume: '恢复',
+    supplierHealthResumed: '库存已恢复',
+    supplierHealthResumeFailed: '恢复库存失败',
+    resaleCheck: '转售核查',
+    resaleCheckDesc: '核查在其他渠道出现的卡密是否由本店售出以及买家，每次核查都会登记',
+    resaleCheckCode: '卡密',
+    resaleCheckSource: '发现渠道（可选）',
+    resaleCheckSubmit: '核查',
+    resaleChecking: '核查中...',
+    resaleCheckFailed: '核查卡密失败',
+    resaleMatched: '由本店售出',
+    resaleNotMatched: '非本店售出',
+    resalePreviousReports: '此前已核查 {count} 次，首次于 {date}',
+    resaleRefunded: '已退款',
+    resaleBuyer: '买家',
+    resaleDeliveredAt: '发货时间',
+    resaleRecentMatches: '最近命中',
+    stockReconciliation: '库存对账',
+    stockReconciliationDesc: '每日定时比对预留计数与未完成订单、虚拟库存分配情况。可立即检查，或检查并自动修正计数。',
+    stockReconciliationRun: '立即检查',
+    stockReconciliationRunHeal: '检查并修正',
+    stockReconciliationDone: '对账完成，发现 {count} 处差异',
+    stockReconciliationFailed: '执行库存对账失败',
+    stockReconciliationEmpty: '暂无对账记录',
+    stockReconciliationStartedAt: '开始时间',
+    stockReconciliationTrigger: '触发方式',
+    stockReconciliationScheduled: '定时',
+    stockReconciliationManual: '手动',
+    stockReconciliationStatusFailed: '失败',
+    stockReconciliationDiscrepancies: '差异数',
+    stockReconciliationOversold: '超卖',
+    stockReconciliationHealed: '已修正',
+    stockReconciliationView: '详情',
+    stockReconciliationDetailTitle: '对账记录 #{id}',
+    stockReconciliationDetailDesc: '超卖库存与已发货未交付的虚拟库存不会自动修正，需要人工处理。',
+    stockReconciliationNoDiscrepancies: '未发现差异',
+    stockReconciliationKind: '类型',
+    stockReconciliationKindReserved: '预留数不一致',
+    stockReconciliationKindOversold: '超卖',
+    stockReconciliationKindVirtualLeak: '预留泄漏',
+    stockReconciliationKindVirtualUndelivered: '未交付',
+    stockReconciliationInventory: '库存',
+    stockReconciliationExpected: '期望值',
+    stockReconciliationActual: '实际值',
+    stockReconciliationOrders: '订单',
+    lowStockThreshold: '低库存告警阈值',
+    lowStockThresholdHint: '可用卡密少于此数量时通知管理员，0 表示不告警。',
+    lowStockAlerts: '低库存告警',
+    lowStockAlertsDesc: '可用库存低于阈值（实物库存为安全库存）的库存。可对单个库存静默，暂停其告警。',
+    lowStockAlertsEmpty: '没有低于阈值的库存',
+    lowStockCheckNow: '立即检查',
+    lowStockCheckDone: '{low} 个库存不足，已发送 {alerted} 条告警',
+    lowStockCheckFailed: '低库存检查失败',
+    lowStockAvailableOf: '{available} / {threshold}',
+    lowStockSince: '低库存开始于',
+    lowStockLastAlerted: '上次告警',
+    lowStockSnooze: '静默',
+    lowStockSnooze1h: '1 小时',
+    lowStockSnooze1d: '1 天',
+    lowStockSnooze7d: '7 天',
+    lowStockSnoozedUntil: '静默至 {date}',
+    lowStockUnsnooze: '恢复告警',
+    lowStockSnoozeFailed: '更新静默失败',
+    inventorySnapshots: '历史库存',
+    inventorySnapshotsDesc: '每天按 SKU 记录在库数量（UTC 日期），可查询过去某天的库存水平或对比两个日期，用于会计盘点。',
+    inventorySnapshotTake: '立即快照',
+    inventorySnapshotTaken: '已保存 {date} 的库存快照',
+    inventorySnapshotTakeFailed: '库存快照失败',
+    inventorySnapshotLoadFailed: '加载历史库存失败',
+    inventorySnapshotFrom: '对比起始日期',
+    inventorySnapshotTo: '日期',
+    inventorySnapshotView: '查看',
+    inventorySnapshotCompare: '对比',
+    inventorySnapshotUsing: '使用 {date} 的快照',
+    inventorySnapshotUsingRange: '使用 {from} 与 {to} 的快照',
+    inventorySnapshotEmpty: '该快照中没有库存',
+    inventorySnapshotPhysical: '实物',
+    inventorySnapshotVirtual: '虚拟',
+    inventorySnapshotOnHand: '在库',
+    inventorySnapshotAvailable: '可售',
+    inventorySnapshotReserved: '预留',
+    inventorySnapshotSold: '已售',
+    inventorySnapshotChange: '变化',
+    inventorySnapshotTotal: '合计变化',
+    requireRevealReauth: '查看前要求重新验证',
+    requireRevealReauthHint: '适用于高价值库存，买家需验证密码或邮箱验证码后才能查看已发货内容。',
+    maxActivations: '设备激活上限',
+    maxActivationsHint: '每个已发货卡密通过激活接口可同时绑定的设备数，0 表示不限制。',
+    usageMetering: '用量计量',
+    usageQuota: '每个卡密的额度',
+    usageUnitPlaceholder: '单位，如 API 调用',
+    overageUnitPrice: '超额单价',
+    usageMeteringHint:
+      '每个已发货卡密包含的用量额度，通过用量接口上报，0 表示不计量。未设置超额单价时超出额度的上报会被拒绝，否则每小时将超额部分生成待付款订单。修改只影响尚未上报过用量的卡密。',
+    virtualRevealLogs: '虚拟商品查看记录',
+    virtualRevealLogsDesc: '买家每次查看已发货卡密的记录，可作为“卡密无效”纠纷的依据。',
+    virtualRevealLogsEmpty: '买家尚未查看已发货内容',
+    virtualRevealTime: '查看时间',
+    virtualRevealIp: 'IP 地址',
+    virtualRevealAuth: '验证方式',
+    virtualRevealCount: '数量',
+    virtualRevealUserAgent: '客户端',
+    orderActivity: '关联记录',
+    orderActivityDesc: '该订单的退款、发货、发货脚本、邮件/短信及工单记录。',
+    orderActivityEmpty: '暂无',
+    orderActivityRefunds: '退款',
+    orderActivityShipments: '发货',
+    orderActivityDeliveries: '虚拟发货',
+    orderActivityEmails: '邮件',
+    orderActivitySms: '短信',
+    orderActivityTickets: '工单',
+    orderActivityActionRefund: '已发起退款',
+    orderActivityActionConfirmRefund: '已确认退款',
+    orderActivityActionAssignTracking: '已分配物流单号',
+    orderActivityActionDeliverVirtualStock: '手动发货',
+    orderActivityActionVirtualDeliveryFailed: '自动发货失败',
+    virtualRevealAuthNone: '无',
+    virtualRevealAuthPassword: '密码',
+    virtualRevealAuthOtp: '邮箱验证码',
     savingText: '保存中...',
     stockItemList: '库存项列表',
     totalRecordsCount: '共 {count} 条记录',
@@ -2913,6 +4149,7 @@ export const zhTranslations = {
     releaseSuccess: '释放成功',
     releaseFailed: '释放失败',
     importSuccessCount: '成功导入 {count} 条库存',
+    importSummary: '已导入 {added} 条，跳过重复 {duplicates} 条、无效 {invalid} 条（首个跳过行：第 {line} 行）',
 
     // 序列号管理
     totalSerials: '总序列号数',
@@ -2993,6 +4230,20 @@ export const zhTranslations = {
     inventoryLogs: '库存日志',
     inventorySource: '库存来源',
     smsLogs: '短信日志',
+    scheduledJobs: '周期任务',
+    scheduledJobsHint:
+      '所有后端实例共享，每个任务在一个周期内只执行一次。当前实例：{instance}',
+    scheduledJobName: '任务',
+    scheduledJobInterval: '执行间隔',
+    scheduledJobRunning: '执行中',
+    scheduledJobSuccess: '成功',
+    scheduledJobFailed: '失败',
+    scheduledJobRunningHere: '正在本实例执行',
+    scheduledJobNotRegistered: '本实例未注册',
+    scheduledJobLastRun: '最近执行',
+    scheduledJobNextRun: '下次执行',
+    scheduledJobRuns: '执行 / 失败次数',
+    scheduledJobLastError: '最近错误',
     logsFilterSummary: '当前已应用 {count} 个筛选条件。',
     logsFilterHint: '当前未应用额外筛选，展示最近日志记录。',
     logsSelectedEmails: '已选中 {count} 条邮件日志',
@@ -3018,6 +4269,8 @@ export const zhTranslations = {
     retryCount: '重试次数',
     sentTime: '发送时间',
     searchEmail: '搜索邮箱',
+    emailCampaign: '活动标识',
+    emailCampaignPlaceholder: '如 order_recovery',
     resourceIdLabel: '资源ID',
     retryFailed: '重试失败',
     emailRetryQueued: '已将邮件重新加入发送队列',
@@ -3181,6 +4434,28 @@ export const zhTranslations = {
     testSms: '测试短信',
     testSmsSent: '测试短信已发送，请检查手机',
     enterTestPhone: '请输入测试手机号',
+    messageConsole: '测试消息控制台',
+    messageConsoleDesc: '使用当前线上配置发送测试邮件、短信、Webhook 或推送通知，并查看服务商原始响应',
+    messageConsoleChannel: '渠道',
+    messageConsoleChannelEmail: '邮件',
+    messageConsoleChannelSms: '短信',
+    messageConsoleChannelWebhook: 'Webhook',
+    messageConsoleChannelPush: '推送通知',
+    messageConsoleTarget: '接收方',
+    messageConsoleTargetEmail: '邮箱地址',
+    messageConsoleTargetSms: '手机号',
+    messageConsoleTargetWebhook: 'Webhook 地址',
+    messageConsoleTargetPush: '设备 Token',
+    messageConsolePhoneCode: '国家区号',
+    messageConsoleSecret: '签名密钥（可选）',
+    messageConsoleSend: '发送测试',
+    messageConsoleSuccess: '发送成功',
+    messageConsoleFailed: '发送失败',
+    messageConsoleProvider: '服务商',
+    messageConsoleStatus: 'HTTP 状态码',
+    messageConsoleDuration: '耗时',
+    messageConsoleResponse: '服务商响应',
+    messageConsoleNoResponse: '无响应内容',
     twilioAccountSid: 'Account SID',
     twilioAuthToken: 'Auth Token',
     twilioFromNumber: '发送号码',
@@ -3190,7 +4465,17 @@ export const zhTranslations = {
     customHeadersKeepHint: '留空则保持现有请求头，输入 {} 可清空',
     customHeadersConfiguredKeys: '已配置请求头键',
     customBodyTemplate: '请求体模板',
-    customBodyTemplateHint: '使用 {{phone}} 和 {{code}} 作为占位符',
+    customBodyTemplateHint:
+      '使用 {{phone}}、{{phone_code}}、{{code}} 和 {{message}}（本地化短信正文）作为占位符',
+    smsMessageTemplates: '本地化短信正文',
+    smsMessageTemplatesHint:
+      'Twilio 与自定义 HTTP 服务商发送的短信正文。语言按用户语言偏好、手机号区号、默认语言的顺序选择。',
+    smsDefaultLocale: '默认语言',
+    smsCountryLocales: '区号 → 语言',
+    smsCountryLocalesHint: 'JSON 对象，例如 {"+86":"zh","+1":"en"}',
+    smsTemplateBodies: '正文模板',
+    smsTemplateBodiesHint:
+      'JSON 对象：语言 → 事件（login/register/reset_password/bind_phone/default）→ 正文。占位符：{{code}}、{{app_name}}、{{event}}',
     saveSmsSettings: '保存短信设置',
     // 邮件通知开关
     emailNotificationToggles: '邮件通知开关',
@@ -3207,6 +4492,8 @@ export const zhTranslations = {
     orderShippedDesc: '订单发货后通知用户',
     orderCompleted: '订单完成',
     orderCompletedDesc: '订单完成后通知用户',
+    orderAutoRemind: '自动完成提醒',
+    orderAutoRemindDesc: '已发货订单自动完成前提醒用户',
     orderCancelled: '订单取消',
     orderCancelledDesc: '订单取消后通知用户',
     resubmitRequired: '要求重新提交',
@@ -3263,6 +4550,7 @@ export const zhTranslations = {
     templateEventOrderPaid: '付款成功',
     templateEventOrderShipped: '订单发货',
     templateEventOrderCompleted: '订单完成',
+    templateEventOrderAutoCompleteReminder: '订单自动完成提醒',
     templateEventOrderCancelled: '订单取消',
     templateEventOrderResubmit: '重新提交',
     templateEventTicketCreated: '工单创建',
@@ -3281,6 +4569,19 @@ export const zhTranslations = {
     allowGuestProductBrowseHint: '开启后未登录用户可浏览商品列表、详情页与购物车页',
     requireEmailVerification: '注册邮箱验证',
     requireEmailVerificationHint: '开启后用户注册需要验证邮箱才能登录，需先配置SMTP',
+    emailVerificationMode: '未验证邮箱的限制方式',
+    emailVerificationModeHint: '开启注册邮箱验证后生效，较严格的方式同时包含较宽松方式的限制',
+    emailVerificationModeLogin: '禁止登录',
+    emailVerificationModeCheckout: '允许登录，禁止下单',
+    emailVerificationModeVirtualReveal: '允许下单，禁止查看虚拟商品内容',
+    emailVerificationModeWarn: '仅提示，不做限制',
+    emailVerificationReminder: '验证提醒邮件',
+    emailVerificationReminderHint: '按设定的时间点向未验证邮箱的用户重发验证邮件',
+    emailVerificationReminderHours: '提醒时间点（注册后小时数，逗号分隔）',
+    emailVerificationExempt: '豁免邮箱验证',
+    emailVerificationExemptHint: '开启后该用户不受邮箱验证限制，也不会收到验证提醒',
+    emailVerificationExemptUpdated: '邮箱验证豁免已更新',
+    emailVerificationExemptUpdateFailed: '更新邮箱验证豁免失败',
     allowEmailLogin: '允许邮件验证码登录',
     allowEmailLoginHint: '开启后用户可使用邮箱验证码登录，需先启用SMTP',
     allowPasswordReset: '允许重置密码',
@@ -3291,10 +4592,13 @@ export const zhTranslations = {
     allowPhoneRegisterHint: '开启后用户可使用手机号注册账户，需先启用短信服务',
     allowPhonePasswordReset: '允许手机找回密码',
     allowPhonePasswordResetHint: '开启后用户可通过手机验证码重置密码，需先启用短信服务',
+    allowPasskeyLogin: '允许通行密钥登录',
+    allowPasskeyLoginHint: '开启后用户可使用已注册的通行密钥（指纹、面容或设备 PIN）登录，需先设置站点地址',
     // 登录设置分类
     loginCategoryPassword: '密码与注册',
     loginCategoryEmail: '邮箱验证',
     loginCategoryPhone: '手机号（短信）',
+    loginCategoryPasskey: '通行密钥',
     // 密码策略
     passwordPolicy: '密码策略',
     passwordPolicyDesc: '设置密码复杂度要求',
@@ -3341,6 +4645,15 @@ export const zhTranslations = {
     paymentInfoLimitHint: '限制 payment-info / payment-card 查询频率',
     paymentSelectLimit: '选择支付方式限流（次/分钟）',
     paymentSelectLimitHint: '限制 select-payment 接口请求频率',
+    rateLimitPolicies: '路由组令牌桶',
+    rateLimitPoliciesHint: '登录、创建订单与工单消息的令牌桶限流，速率设为 0 可关闭对应策略',
+    rateLimitPolicyLogin: '登录',
+    rateLimitPolicyOrderCreate: '创建订单',
+    rateLimitPolicyTicketMessage: '工单消息',
+    rateLimitPolicyRate: '速率（每周期令牌数）',
+    rateLimitPolicyPeriod: '周期（秒）',
+    rateLimitPolicyBurst: '突发（桶容量）',
+    rateLimitPolicyKeys: '计数维度（ip/user/api_key）',
     // 邮件/短信频率限制
     emailRateLimit: '邮件发送频率限制',
     emailRateLimitDesc: '限制每个收件人的邮件发送频率',
@@ -3423,7 +4736,7 @@ export const zhTranslations = {
     analyticsSettingsDesc: '配置数据分析功能，关闭可以减轻后端压力',
     enableAnalytics: '启用数据分析',
     enableAnalyticsHint:
-      '启用后分析页面将查询并展示详细统计数据。关闭后将停止所有分析查询以减轻服务器压力。',
+      '启用后分析页面将查询并展示详细统计数据。关闭后将停止所有分析查询以减轻服务器压力。商城行为事件（浏览、加购、结账步骤）仅在启用时记录。',
     tabAnalytics: '数据分析',
     analyticsDisabledTitle: '数据分析已关闭',
     analyticsDisabledDesc: '数据分析功能当前已关闭，管理员可在系统设置 > 数据分析中开启。',
@@ -3438,12 +4751,21 @@ export const zhTranslations = {
     maxContentLengthHint: '工单内容和消息的最大字符数，设为 0 表示不限制',
     autoCloseHours: '超时自动关闭（小时）',
     autoCloseHoursHint: '工单超过指定小时无任何回复将自动关闭，设为 0 表示不自动关闭',
+    disputeEscalationDays: '争议升级等待天数',
+    disputeEscalationDaysHint:
+      '工单创建超过该天数仍未解决时，用户可升级为平台争议，设为 0 表示关闭',
+    disputeEvidenceDays: '商家举证期限（天）',
+    disputeEvidenceDaysHint: '商家提交答辩证据的期限，逾期未答辩时平台可直接裁决',
     ticketAttachmentSettings: '工单附件设置',
-    ticketAttachmentSettingsDesc: '配置工单消息中的图片和语音上传限制',
+    ticketAttachmentSettingsDesc: '配置工单消息中的图片、语音和文件上传限制',
     enableImageUpload: '允许上传图片',
     enableImageUploadHint: '用户和管理员可在工单消息中发送图片',
     enableVoiceUpload: '允许上传语音',
     enableVoiceUploadHint: '用户和管理员可在工单消息中发送语音消息',
+    enableFileUpload: '允许上传文件附件',
+    enableFileUploadHint: '用户和管理员可在工单消息中附加文档，文件私有存储',
+    maxFileSize: '最大文件大小（MB）',
+    allowedFileFormats: '允许的文件格式',
     maxImageSize: '最大图片大小（MB）',
     maxVoiceSize: '最大语音大小（MB）',
     maxVoiceDuration: '最大语音时长（秒）',
@@ -3458,6 +4780,14 @@ export const zhTranslations = {
     orderSettingsDesc: '配置订单相关参数',
     orderNoPrefix: '订单号前缀',
     orderNoPrefixExample: '示例：ORD20240101001',
+    orderNumberFormat: '订单号格式',
+    orderNumberFormatTimestamp: '时间戳（默认）',
+    orderNumberFormatDateSequence: '日期 + 每日序号',
+    orderNumberFormatRandomChecksum: '随机数字 + 校验位',
+    orderNumberFormatHint:
+      '日期序号：ORD20240101000001；随机：ORD4821937065（末位为 Luhn 校验位）。仅对新订单生效。',
+    orderNumberSequenceDigits: '每日序号位数',
+    orderNumberRandomDigits: '随机数字位数',
     currency: '货币单位',
     currencyCNY: 'CNY - 人民币 (¥)',
     currencyUSD: 'USD - 美元 ($)',
@@ -3473,6 +4803,43 @@ export const zhTranslations = {
     currencyHint: '订单金额显示的货币单位',
     autoCancelHours: '自动取消时长（小时）',
     autoCancelHoursHint: '待付款订单超过此时长未付款将自动取消，设为0则禁用自动取消',
+    draftCleanupRetentionDays: '草稿保留天数',
+    draftCleanupRetentionDaysHint:
+      '草稿和待重填订单超过该天数仍未提交收货信息时自动清理并释放预留，已付款订单不受影响。0 表示永久保留',
+    draftCleanupAction: '草稿清理方式',
+    draftCleanupActionDelete: '删除订单',
+    draftCleanupActionAnonymize: '清除个人信息并取消',
+    orderRecoveryTitle: '订单挽回提醒',
+    orderRecoveryDesc: '在草稿订单被清理、待付款订单被取消前，提醒客户继续填写或完成付款。',
+    orderRecoveryEnabled: '发送挽回提醒',
+    orderRecoveryEnabledHint: '每 10 分钟检查一次，已进入支付轮询的订单和试用转正订单不会提醒。',
+    orderRecoveryIntervals: '提醒时间点（小时）',
+    orderRecoveryIntervalsHint:
+      '下单后的小时数，用逗号分隔，如 1, 24。晚于订单失效时间的提醒不会发送。',
+    orderRecoverySendSms: '同时发送短信',
+    orderRecoverySendSmsHint: '需要启用短信服务且订单填写了手机号',
+    orderRecoveryCampaign: '活动标识',
+    orderRecoveryCampaignHint:
+      '记录在邮件日志中，并作为 utm_campaign 追加到链接，默认 order_recovery',
+    draftCleanupPreview: '预览清理',
+    draftCleanupPreviewHint: '按已保存的设置执行，每次最多处理 100 个订单，预览列出的即为下一次清理将处理的订单',
+    draftCleanupPreviewSummary: '将对 {count} 个订单执行「{action}」，最后更新时间早于 {cutoff}',
+    draftCleanupPreviewFailed: '预览草稿清理失败',
+    draftCleanupRunNow: '立即清理',
+    draftCleanupRunConfirm: '确定立即对 {count} 个草稿订单执行「{action}」吗？此操作无法撤销',
+    draftCleanupRunSuccess: '已清理 {count} 个草稿订单',
+    draftCleanupRunFailed: '清理草稿订单失败',
+    autoCompleteDays: '自动完成天数',
+    autoCompleteDaysHint: '已发货订单在此天数后若无未关闭的关联工单将自动完成，设为0则禁用',
+    autoCompleteReminderDays: '自动完成前提醒天数',
+    autoCompleteReminderDaysHint: '在自动完成前N天向用户发送提醒邮件，设为0则不提醒',
+    autoCompleteDaysPhysical: '自动完成天数（实物订单）',
+    autoCompleteDaysVirtual: '自动完成天数（纯虚拟订单）',
+    autoCompleteDaysInherit: '沿用自动完成天数',
+    autoCompleteDaysByTypeHint:
+      '仅含虚拟商品的订单按纯虚拟订单天数处理，其余订单按实物订单天数处理；留空则沿用自动完成天数，设为0则该类型不自动完成',
+    abandonReleaseMinutes: '离开付款页提前释放（分钟）',
+    abandonReleaseMinutesHint: '用户离开付款页后在此时长内未返回，将提前取消未付款订单并释放预留库存；已选择付款方式等待确认的订单不受影响。设为0则禁用',
     maxPendingPaymentOrdersPerUser: '每用户待支付订单上限',
     maxPendingPaymentOrdersPerUserHint: '超过上限后将无法继续创建新订单',
     maxPaymentPollingTasksPerUser: '每用户支付轮询任务上限',
@@ -3548,12 +4915,58 @@ export const zhTranslations = {
     invoiceCompanyPhone: '联系电话',
     invoiceCompanyEmail: '联系邮箱',
     invoiceCompanyLogo: 'Logo URL',
+    invoiceCompanyLogoHint:
+      '填写 asset:<名称> 可引用已上传的模板资源，PDF 渲染时直接内联，无需访问外部站点',
+    templateAssets: '模板资源',
+    templateAssetsDesc:
+      '账单、报价单与邮件模板使用的 Logo、字体与样式表，模板中以 {{asset "名称"}} 引用',
+    templateAssetName: '资源名称',
+    templateAssetNamePlaceholder: '例如 company-logo（默认使用文件名）',
+    templateAssetSize: '大小',
+    templateAssetUpload: '上传',
+    templateAssetUploaded: '模板资源已上传',
+    templateAssetUploadFailed: '上传模板资源失败',
+    templateAssetDeleted: '模板资源已删除',
+    templateAssetDeleteFailed: '删除模板资源失败',
+    templateAssetDeleteConfirm: '确定删除该模板资源？引用它的模板将不再显示该资源。',
+    templateAssetEmpty: '尚未上传模板资源',
+    templateAssetCopyReference: '复制引用',
+    templateAssetCopyUrl: '复制地址',
+    documentTypes: '文档类型',
+    documentTypesDesc:
+      '保修卡、送货单、正品证书等自定义文档，使用账单模板引擎按订单或按商品生成。',
+    documentTypeCreate: '新建文档类型',
+    documentTypeKey: '标识',
+    documentTypeDescription: '描述',
+    documentTypeBinding: '生成维度',
+    documentTypeBindingOrder: '按订单',
+    documentTypeBindingOrderItem: '按商品',
+    documentTypeStatuses: '可用的订单状态',
+    documentTypeSkus: '仅限 SKU',
+    documentTypeSkusPlaceholder: '逗号分隔，留空表示全部商品',
+    documentTypeFields: '自定义字段（每行一个 key=value）',
+    documentTypeTemplate: 'HTML 模板',
+    documentTypeTemplateTip:
+      '留空使用内置版式。可用变量：{{.DocumentName}}, {{.DocumentNo}}, {{.IssueDate}}, {{.OrderNo}}, {{.OrderDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerAddress}}, {{.Items}}, {{.Item}}, {{.TotalAmount}}, {{.Currency}}, {{.Fields.key}}, {{.CompanyName}}, {{.CompanyLogo}}, {{.FooterText}}, {{asset "name"}}',
+    documentTypeEnabled: '启用',
+    documentTypeVisibleToUser: '用户可见',
+    documentTypeAdminOnly: '仅后台',
+    documentTypePreviewOrder: '预览订单号',
+    documentTypePreviewItem: '商品序号',
+    documentTypePreview: '预览',
+    documentTypePreviewFailed: '预览渲染失败',
+    documentTypeSaved: '文档类型已保存',
+    documentTypeSaveFailed: '保存文档类型失败',
+    documentTypeDeleted: '文档类型已删除',
+    documentTypeDeleteFailed: '删除文档类型失败',
+    documentTypeDeleteConfirm: '确定删除此文档类型？用户将无法再下载该文档。',
+    documentTypeEmpty: '暂无自定义文档类型',
     invoiceTaxId: '税号/统一编号',
     invoiceFooterText: '页脚文字',
     invoiceFooterPlaceholder: '例如：感谢您的惠顾！',
     invoiceCustomTemplate: '自定义 HTML 模板',
     invoiceCustomTemplateTip:
-      '可用变量：{{.CompanyName}}, {{.OrderNo}}, {{.InvoiceNo}}, {{.OrderDate}}, {{.CompletedDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerPhone}}, {{.CustomerAddress}}, {{.Items}}, {{.Subtotal}}, {{.DiscountAmount}}, {{.HasDiscount}}, {{.TotalAmount}}, {{.Currency}}, {{.FooterText}}, {{.AppName}}',
+      '可用变量：{{.CompanyName}}, {{.OrderNo}}, {{.InvoiceNo}}, {{.OrderDate}}, {{.CompletedDate}}, {{.CustomerName}}, {{.CustomerEmail}}, {{.CustomerPhone}}, {{.CustomerAddress}}, {{.Items}}, {{.Subtotal}}, {{.DiscountAmount}}, {{.HasDiscount}}, {{.PaymentFee}}, {{.HasPaymentFee}}, {{.Adjustment}}, {{.HasAdjustment}}, {{.GiftCard}}, {{.HasGiftCard}}, {{.TotalAmount}}, {{.Currency}}, {{.FooterText}}, {{.AppName}}',
     formAndLinkSettings: '表单和链接设置',
     formAndLinkSettingsDesc: '配置表单和魔法链接过期时间',
     magicLinkExpiry: '魔法链接过期时间（分钟）',
@@ -3710,6 +5123,382 @@ export const zhTranslations = {
     featuredProduct: '精选商品',
     recommendedProduct: '推荐商品',
     autoDelivery: '自动发货',
+    productWaitingRoom: '等候室',
+    productWaitingRoomEnable: '下单前需进入等候室排队',
+    productWaitingRoomHint: '买家需先排队并获得限时准入令牌后才能为该商品下单',
+    productWaitingRoomInactive: '系统配置未启用等候室或 Redis 不可用',
+    productWaitingRoomQueued: '排队中 {count} 人',
+    productWaitingRoomAdmitted: '已准入 {count} 人',
+    productWaitingRoomUpdated: '等候室设置已更新',
+    productWaitingRoomUpdateFailed: '更新等候室设置失败',
+    productTrial: '免费试用',
+    productTrialDays: '试用天数',
+    productTrialHint:
+      '登录用户每个商品可领取一次限时卡密，需开启自动发货。到期前按商品价格生成待付款转正订单并提醒用户，到期未付款则试用失效、卡密校验不再通过。设为 0 关闭试用。',
+    productTrialUpdated: '试用设置已更新',
+    productTrialUpdateFailed: '更新试用设置失败',
+    productTrialActiveCount: '试用中 {count}',
+    productTrialConvertedCount: '已转正 {count}',
+    productTrialExpiredCount: '已过期 {count}',
+    productTrialRecent: '最近试用',
+    productTrialStatusActive: '试用中',
+    productTrialStatusConverted: '已转正',
+    productTrialStatusExpired: '已过期',
+    productTrialUser: '用户 #{id}',
+    productTrialEnds: '{time} 到期',
+    productOrderRateCap: '下单频率限制',
+    productOrderRateCapEnable: '限制买家每小时下单次数',
+    productOrderRateCapHint: '限制每个账号、IP 和设备每小时为该商品下单的次数',
+    productOrderRateCapInactive: '系统配置未启用下单频率限制或 Redis 不可用',
+    productOrderRateCapPerUser: '每账号 {limit} 次/小时',
+    productOrderRateCapPerIP: '每 IP {limit} 次/小时',
+    productOrderRateCapPerDevice: '每设备 {limit} 次/小时',
+    productOrderRateCapNoLimit: '不限',
+    productOrderRateCapUpdated: '下单频率限制已更新',
+    productOrderRateCapUpdateFailed: '更新下单频率限制失败',
+    refundRequestQueue: '退款申请',
+    refundRequestQueueDesc:
+      '用户提交的退款申请，批准后通过订单付款方式发起退款，处理结果同步到关联工单',
+    refundRequestUser: '用户',
+    refundRequestResolution: '期望处理',
+    refundRequestResolutionFullRefund: '全额退款',
+    refundRequestResolutionPartialRefund: '部分退款',
+    refundRequestResolutionReplacement: '换货/补发',
+    refundRequestResolutionOther: '其他',
+    refundRequestReason: '申请原因',
+    refundRequestEvidence: '凭证',
+    refundRequestCreatedAt: '提交时间',
+    refundRequestTicket: '工单 #{ticketNo}',
+    refundRequestApprove: '批准',
+    refundRequestReject: '拒绝',
+    refundRequestApproveTitle: '批准退款申请',
+    refundRequestApproveDesc: '将通过订单 {orderNo} 的付款方式发起退款',
+    refundRequestRejectTitle: '拒绝退款申请',
+    refundRequestRejectDesc: '拒绝原因会通过关联工单发送给用户',
+    refundRequestNote: '处理说明',
+    refundRequestNotePlaceholder: '将在工单中展示给用户',
+    settlementReport: '结算报表',
+    packingSlip: '装箱单',
+    printTodayShipments: '打印今日发货单',
+    packingSlipFailed: '生成装箱单失败',
+    settlementReportDesc: '已付款订单按付款时记录的汇率折算为 {currency}',
+    settlementFromMonth: '起始月份',
+    settlementToMonth: '截止月份',
+    settlementMonth: '月份',
+    settlementCurrency: '币种',
+    settlementGateway: '付款方式',
+    settlementManualGateway: '手动标记付款',
+    settlementOrderCount: '订单数',
+    settlementGross: '收款金额',
+    settlementRefunded: '已退款',
+    settlementBaseAmount: '本位币金额',
+    settlementNetBaseAmount: '本位币净额',
+    settlementUnconverted: '{count} 笔无汇率',
+    settlementNoData: '该期间内没有已付款订单',
+    settlementExport: '导出 CSV',
+    settlementExportSummary: '月度汇总',
+    settlementExportOrders: '逐单明细',
+    settlementExportSuccess: '结算报表已导出',
+    vendorManagementTitle: '商家与结算',
+    vendorManagementDesc: '商家商品每笔销售按佣金比例分账，未结算台账条目汇总生成打款结算单',
+    vendorTabVendors: '商家',
+    vendorTabStatements: '结算单',
+    vendorCreate: '添加商家',
+    vendorEdit: '编辑商家',
+    vendorName: '商家',
+    vendorContactEmail: '联系邮箱',
+    vendorCommission: '佣金比例',
+    vendorCommissionPercent: '平台佣金比例（%）',
+    vendorPayoutAccount: '收款账户',
+    vendorRemark: '备注',
+    vendorProducts: '商品数',
+    vendorUnsettled: '未结算应付',
+    vendorStatus: '状态',
+    vendorStatusActive: '正常',
+    vendorStatusDisabled: '停用',
+    vendorSaved: '商家已保存',
+    vendorSaveFailed: '保存商家失败',
+    vendorLedger: '台账 - {name}',
+    vendorLedgerDate: '日期',
+    vendorLedgerType: '类型',
+    vendorLedgerSale: '销售',
+    vendorLedgerRefund: '退款冲回',
+    vendorLedgerAdjustment: '手动调整',
+    vendorLedgerReference: '关联',
+    vendorLedgerNet: '应付金额',
+    vendorLedgerSettled: '结算单',
+    vendorAdjustment: '调整应付金额 - {name}',
+    vendorAdjustmentHint: '正数增加应付金额，负数扣减应付金额，调整条目计入下一张结算单',
+    vendorAdjustmentCurrency: '币种',
+    vendorAdjustmentAmount: '金额',
+    vendorAdjustmentNote: '调整原因',
+    vendorAdjustmentCreated: '调整已记录',
+    vendorAdjustmentFailed: '记录调整失败',
+    vendorGenerateStatements: '生成结算单',
+    vendorGenerateHint:
+      '为截止日期（不含当天）前所有未结算条目按商家和币种各生成一张结算单，已结算订单的退款冲回一并计入',
+    vendorPeriodStart: '周期开始',
+    vendorPeriodEnd: '周期截止（不含）',
+    vendorStatementsGenerated: '已生成 {count} 张结算单',
+    vendorStatementsGenerateFailed: '生成结算单失败',
+    vendorStatementPeriod: '结算周期',
+    vendorStatementSales: '销售额',
+    vendorStatementCommission: '佣金',
+    vendorStatementRefunds: '退款',
+    vendorStatementAdjustments: '调整',
+    vendorStatementPayout: '应付金额',
+    vendorStatementPending: '待打款',
+    vendorStatementPaid: '已打款',
+    vendorMarkPaid: '标记已打款',
+    vendorMarkPaidConfirm: '确认已向 {name} 支付 {amount}。',
+    vendorPaymentReference: '打款凭证号',
+    vendorStatementMarkedPaid: '结算单已标记为已打款',
+    vendorStatementMarkPaidFailed: '更新结算单失败',
+    productVendor: '所属商家',
+    productVendorPlatform: '平台自营',
+    productVendorHint: '该商品付款后销售额扣除平台佣金计入商家台账，已有订单保持原商家不变',
+    productVendorUpdated: '商品所属商家已更新',
+    productVendorUpdateFailed: '更新商品所属商家失败',
+    productRevenueRecognition: '收入确认',
+    productServicePeriodMonths: '服务期（月）',
+    productRevenueHint: '订阅与预付额度自付款当月起在服务期内按月平均确认收入，仅影响之后的订单。',
+    productRevenueUpdated: '收入确认方式已更新',
+    productRevenueUpdateFailed: '更新收入确认方式失败',
+    revenueRecognition: '收入确认',
+    revenueRecognitionDesc: '订阅与预付额度的递延收入月度变动表',
+    revenueType: '确认方式',
+    revenueTypeAtSale: '付款当月一次性确认',
+    revenueTypeSubscription: '订阅',
+    revenueTypePrepaidCredit: '预付额度',
+    revenueOpening: '期初递延',
+    revenueBilled: '本期新增',
+    revenueRecognized: '本期确认',
+    revenueReleased: '退款释放',
+    revenueClosing: '期末递延',
+    revenueNoData: '该期间没有递延收入',
+    revenueExportSuccess: '收入确认报表已导出',
+    revenueBackfill: '补建确认计划',
+    revenueBackfillHint: '为自起始月份以来付款、尚无收入确认计划的订单补建计划',
+    revenueBackfillSuccess: '已新建 {count} 个确认计划',
+    revenueBackfillFailed: '补建收入确认计划失败',
+    manualOrder: '手动建单',
+    manualOrderTitle: '手动创建订单',
+    manualOrderDesc: '代客户录入电话、私信或线下渠道的订单',
+    manualOrderCustomer: '客户',
+    manualOrderExistingUser: '已有用户',
+    manualOrderGuest: '游客',
+    manualOrderSearchUser: '按邮箱或名称搜索用户',
+    manualOrderChangeUser: '更换',
+    manualOrderSelectUser: '请选择用户',
+    manualOrderGuestEmail: '游客邮箱',
+    manualOrderGuestEmailRequired: '请输入游客邮箱',
+    manualOrderReceiverName: '收货人',
+    manualOrderReceiverPhone: '手机号',
+    manualOrderShippingHint: '实物商品未填写完整地址时，客户会收到填写收货信息的表单链接',
+    manualOrderSearchProduct: '按名称或 SKU 搜索商品',
+    manualOrderPriceHint: '单价留空时使用商品当前售价',
+    manualOrderSubtotal: '商品小计',
+    manualOrderDiscount: '手动折扣',
+    manualOrderDiscountInvalid: '折扣需在 0 到商品小计之间',
+    manualOrderTotal: '应付金额',
+    manualOrderPayment: '付款',
+    manualOrderPaymentNone: '保持待付款',
+    manualOrderPaymentMarkPaid: '标记为已付款',
+    manualOrderPaymentLink: '发送付款链接',
+    manualOrderPaymentActionFailed: '订单已创建，但付款处理失败，请在订单详情中重试',
+    manualOrderRemark: '客户备注',
+    manualOrderAdminRemark: '管理员备注',
+    manualOrderSubmit: '创建订单',
+    manualOrderCreateAnother: '继续建单',
+    businessAccountManagementTitle: '企业账户与账期',
+    businessAccountManagementDesc: '审核通过的账户可在信用额度内先发货后付款，发票逾期会发送催款邮件并自动冻结额度',
+    businessAccountTabAccounts: '企业账户',
+    businessAccountTabInvoices: '账期发票',
+    businessAccountSearchPlaceholder: '搜索公司名称、税号或邮箱',
+    businessAccountCompany: '公司',
+    businessAccountStatus: '状态',
+    businessAccountStatusPending: '待审核',
+    businessAccountStatusApproved: '已通过',
+    businessAccountStatusRejected: '已拒绝',
+    businessAccountStatusSuspended: '已停用',
+    businessAccountHoldOverdue: '冻结：逾期',
+    businessAccountHoldManual: '冻结：手动',
+    businessAccountTerms: '账期条款',
+    businessAccountTermsValue: '{limit} · {days} 天',
+    businessAccountOutstanding: '未付金额',
+    businessAccountOverdueAmount: '逾期 {amount}',
+    businessAccountAvailable: '可用额度',
+    businessAccountReview: '审核申请 - {name}',
+    businessAccountTaxId: '税号',
+    businessAccountBillingEmail: '账单邮箱',
+    businessAccountBillingAddress: '账单地址',
+    businessAccountCurrency: '币种',
+    businessAccountCreditLimit: '信用额度',
+    businessAccountTermsDays: '账期（天）',
+    businessAccountReviewNote: '审核备注',
+    businessAccountApprove: '通过',
+    businessAccountReject: '拒绝',
+    businessAccountApproved: '企业账户已审核通过',
+    businessAccountRejected: '已拒绝申请',
+    businessAccountReviewFailed: '审核失败',
+    businessAccountEdit: '调整条款 - {name}',
+    businessAccountCreditHold: '冻结额度',
+    businessAccountCreditHoldHint: '冻结后不能再使用账期下单；因逾期产生的冻结会在结清逾期发票后自动解除。',
+    businessAccountSuspended: '停用账户',
+    businessAccountSuspendedHint: '停用后该账户无法使用账期付款，直至重新启用。',
+    businessAccountSaved: '企业账户已更新',
+    businessAccountSaveFailed: '更新企业账户失败',
+    businessAccountStatement: '对账单 - {name}',
+    codManagementTitle: '货到付款代收',
+    codManagementDesc: '登记快递员为已发货订单代收的货款，并跟踪未收款余额',
+    codSearchPlaceholder: '搜索订单号',
+    codOrder: '订单',
+    codAmount: '应收金额',
+    codCollectedAmount: '实收',
+    codPlacedAt: '下单时间',
+    codStatusOutstanding: '待收款',
+    codStatusCollected: '已收款',
+    codStatusVoid: '已作废',
+    codOutstanding: '待收款（{count}）',
+    codCollectedTotal: '已收款（{count}）',
+    codShortfall: '短收',
+    codAgingDays: '{label} 天',
+    codConfirm: '登记收款',
+    codConfirmHint: '订单 {order} 应收 {amount}，请填写快递实际回款金额。',
+    codReference: '回款单号',
+    codConfirmed: '已登记收款',
+    codUpdateFailed: '更新代收记录失败',
+    codVoid: '作废代收',
+    codVoidHint: '适用于拒收或退回的包裹，订单本身不会改变。',
+    codVoidReason: '原因',
+    codVoided: '代收记录已作废',
+    netTermsOverdueOnly: '仅显示逾期',
+    netTermsInvoiceNo: '发票号',
+    netTermsInvoiceAmount: '金额',
+    netTermsInvoiceIssued: '开票日期',
+    netTermsInvoiceDue: '到期日',
+    netTermsInvoiceOpen: '未付',
+    netTermsInvoicePaid: '已付',
+    netTermsInvoiceVoid: '已作废',
+    netTermsInvoiceOverdue: '已逾期',
+    netTermsDunningSent: '已催款 {count} 次',
+    netTermsMarkPaid: '登记收款',
+    netTermsMarkPaidConfirm: '确认已收到发票 {invoice} 的款项 {amount}。',
+    netTermsPaymentReference: '收款凭证号',
+    netTermsInvoiceMarkedPaid: '已登记收款',
+    netTermsInvoiceUpdateFailed: '更新发票失败',
+    netTermsVoidInvoice: '作废发票',
+    netTermsVoidHint: '作废会释放额度但不记录收款，订单本身不会被取消或退款。',
+    netTermsVoidReason: '作废原因',
+    netTermsInvoiceVoided: '发票已作废',
+    netTermsPeriodStart: '开始日期',
+    netTermsPeriodEnd: '结束日期（含）',
+    netTermsExportStatement: '导出 CSV',
+    netTermsOpeningBalance: '期初余额',
+    netTermsInvoiced: '本期开票',
+    netTermsPaid: '本期收款',
+    netTermsVoided: '本期作废',
+    netTermsClosingBalance: '期末余额',
+    netTermsLineDate: '日期',
+    netTermsLineType: '类型',
+    netTermsLineInvoice: '开票',
+    netTermsLinePayment: '收款',
+    netTermsLineVoid: '作废',
+    netTermsLineBalance: '余额',
+    accountingExport: '会计导出',
+    accountingExportDesc: '导出 QuickBooks Online 或 Xero 可导入的销售、收款与退款分录，或直接推送',
+    accountingFromDate: '开始日期',
+    accountingToDate: '结束日期',
+    accountingFormat: '格式',
+    accountingFormatJournal: '通用分录 CSV',
+    accountingFormatQuickBooks: 'QuickBooks Online 分录导入',
+    accountingFormatXero: 'Xero 手工分录导入',
+    accountingDownload: '下载 CSV',
+    accountingExportSuccess: '会计分录已导出',
+    accountingExportSkipped: '{count} 条外币分录因付款时未记录汇率已跳过',
+    accountingPushNow: '立即推送',
+    accountingPushing: '推送中...',
+    accountingPushResult: '已推送 {pushed} 条分录，失败 {failed} 条',
+    accountingPushFailed: '推送会计分录失败',
+    accountingRuns: '推送记录',
+    accountingRunsHint: '配置会计系统后每日自动推送，失败的分录会在下次推送时重试',
+    accountingNoRuns: '暂无推送记录',
+    accountingRunStartedAt: '开始时间',
+    accountingProvider: '会计系统',
+    accountingRunTrigger: '触发方式',
+    accountingTriggerManual: '手动',
+    accountingTriggerScheduled: '定时',
+    accountingRunStatus: '状态',
+    accountingRunCompleted: '已完成',
+    accountingRunPartial: '部分失败',
+    accountingRunFailed: '失败',
+    accountingRunCounts: '推送 / 失败 / 跳过',
+    accountingRunError: '错误信息',
+    refundRequestApproved: '退款申请已批准',
+    refundRequestRejected: '退款申请已拒绝',
+    refundRequestReviewFailed: '审核退款申请失败',
+    partialRefundQueue: '商品退款',
+    partialRefundQueueDesc: '待审核或等待通过付款方式退款的商品退款。',
+    partialRefundTitle: '商品退款',
+    partialRefundDesc: '按商品退款，未发货商品退款后释放预留库存。',
+    partialRefundItems: '商品',
+    partialRefundAmount: '金额',
+    partialRefundAmountPlaceholder: '留空按下单单价计算',
+    partialRefundReason: '原因',
+    partialRefundStatus: '状态',
+    partialRefundCreatedAt: '申请时间',
+    partialRefundStatusRequested: '待审核',
+    partialRefundStatusApproved: '已批准',
+    partialRefundStatusRefunded: '已退款',
+    partialRefundStatusRejected: '已拒绝',
+    partialRefundPaymentPending: '等待支付渠道到账',
+    partialRefundApprove: '批准',
+    partialRefundReject: '拒绝',
+    partialRefundIssue: '退款',
+    partialRefundApproveTitle: '批准商品退款',
+    partialRefundRejectTitle: '拒绝商品退款',
+    partialRefundIssueTitle: '执行商品退款',
+    partialRefundActionDesc: '订单 {orderNo}，退款金额 {amount}。',
+    partialRefundNote: '说明',
+    partialRefundCreate: '商品退款',
+    partialRefundCreateDesc: '创建后为待审核状态，仍需批准并执行退款。',
+    partialRefundCreated: '商品退款已创建',
+    partialRefundCreateFailed: '商品退款创建失败',
+    partialRefundApproved: '商品退款已批准',
+    partialRefundRejected: '商品退款已拒绝',
+    partialRefundIssued: '商品退款已执行',
+    partialRefundActionFailed: '商品退款处理失败',
+    priceAdjustmentQueue: '订单改价',
+    priceAdjustmentQueueDesc: '超过审批阈值、等待其他管理员批准的改价申请。',
+    priceAdjustmentTitle: '订单改价',
+    priceAdjustmentDesc: '减免或直接修改应付金额，大额减免需其他管理员批准后生效。',
+    priceAdjustmentType: '类型',
+    priceAdjustmentTypeDiscount: '减免',
+    priceAdjustmentTypeOverride: '改价',
+    priceAdjustmentChange: '应付金额',
+    priceAdjustmentReason: '原因',
+    priceAdjustmentStatus: '状态',
+    priceAdjustmentCreatedAt: '申请时间',
+    priceAdjustmentStatusPending: '待批准',
+    priceAdjustmentStatusApplied: '已生效',
+    priceAdjustmentStatusRejected: '已拒绝',
+    priceAdjustmentRequestedBy: '申请人：管理员 #{id}',
+    priceAdjustmentApprove: '批准',
+    priceAdjustmentReject: '拒绝',
+    priceAdjustmentApproveTitle: '批准改价',
+    priceAdjustmentRejectTitle: '拒绝改价',
+    priceAdjustmentActionDesc: '订单 {orderNo}：{before} → {after}。',
+    priceAdjustmentNote: '说明',
+    priceAdjustmentCreate: '改价',
+    priceAdjustmentCreateDesc: '支付手续费保留在订单上，改价后买家需重新选择付款方式。',
+    priceAdjustmentDiscountAmount: '减免金额',
+    priceAdjustmentNewTotal: '新的应付金额',
+    priceAdjustmentApplied: '改价已生效',
+    priceAdjustmentRequested: '改价申请已提交，等待批准',
+    priceAdjustmentCreateFailed: '改价失败',
+    priceAdjustmentApproved: '改价已批准',
+    priceAdjustmentRejected: '改价已拒绝',
+    priceAdjustmentActionFailed: '改价申请处理失败',
     sortOrder: '排序',
     remarkLabel: '备注',
     virtualStockManageBtn: '虚拟库存管理',
@@ -3876,6 +5665,10 @@ export const zhTranslations = {
     invAlertEmail: '告警邮箱',
     invNotes: '备注',
     invEnableConfig: '启用此库存配置',
+    invFlashSale: '秒杀模式',
+    invFlashSaleDesc: '预留改用 Redis 原子计数并异步写回数据库，售罄后下单立即失败',
+    invFlashSaleStatus: 'Redis 剩余：{remaining}，待写回数据库：{pending}',
+    invFlashSaleInactive: '服务端未开启秒杀模式或 Redis 不可用，预留仍走数据库',
     invSaving: '保存中...',
     invSaveChanges: '保存更改',
     invAdjustTitle: '调整库存（入库/盘点）',
@@ -3965,6 +5758,18 @@ export const zhTranslations = {
     pmPackageImportedSuccess: '付款包导入成功',
     pmPackageMissingRequiredConfig: '请先填写必填配置项：{field}',
     pmPackageImportedBadge: '包导入',
+    pmFeeEdit: '手续费规则',
+    pmFeeTitle: '手续费规则 · {name}',
+    pmFeeDesc: '用户选择该付款方式时计入订单金额，并在订单和账单中单独列示',
+    pmFeeRate: '比例（%）',
+    pmFeeFixed: '固定金额',
+    pmFeeHint: '正数为附加费，负数为优惠，例如 3 或 -1',
+    pmFeeRetainedOnRefund: '退款时不退还附加费',
+    pmFeeRetainedOnRefundHint: '退款金额为订单金额减去附加费',
+    pmFeePreview: '预览',
+    pmFeeNone: '无手续费',
+    pmFeeUpdated: '手续费规则已更新',
+    pmFeeUpdateFailed: '更新手续费规则失败',
     pmPackageSummary: '付款包摘要',
     pmPackageVersion: '包版本',
     pmPackageEntry: '入口脚本',
@@ -4166,6 +5971,85 @@ export const zhTranslations = {
     copyAntiCounterfeitCode: '复制防伪码',
   },
 
+  orderTracking: {
+    title: '订单查询',
+    desc: '输入订单号和下单时填写的邮箱，无需登录即可查看订单状态。',
+    orderNo: '订单号',
+    email: '邮箱',
+    submit: '查询',
+    lookupFailed: '查询失败，请稍后重试',
+    loadingLink: '正在加载订单…',
+    status: '订单状态',
+    trackingNo: '物流单号',
+    estimatedDelivery: '预计送达',
+    items: '商品',
+    progress: '订单进度',
+    searchAgain: '查询其他订单',
+    checkpoints: {
+      placed: '已下单',
+      paid: '已付款',
+      shipped: '已发货',
+      completed: '已完成',
+      refunded: '已退款',
+    },
+    bizError: {
+      'order_tracking.disabled': '订单查询功能未开启',
+      'order_tracking.notFound': '未找到与订单号和邮箱匹配的订单',
+      'order_tracking.linkInvalid': '查询链接无效或已过期，请使用订单号和邮箱查询',
+      'order_tracking.tooManyAttempts': '失败次数过多，请 {minutes} 分钟后再试',
+    },
+  },
+
+  paymentLink: {
+    title: '订单付款',
+    desc: '选择付款方式为此订单付款，无需登录。',
+    loading: '正在加载订单…',
+    loadFailed: '付款链接加载失败',
+    missingParams: '付款链接不完整，请从邮件或短信中打开完整链接',
+    orderNo: '订单号',
+    amountDue: '应付金额',
+    items: '商品',
+    paymentMethod: '付款方式',
+    selectMethod: '请选择付款方式',
+    changeMethod: '更换付款方式',
+    confirmMethod: '去付款',
+    selectFailed: '选择付款方式失败',
+    noMethods: '暂无可用的付款方式，请联系商家',
+    methodFee: '手续费：{fee}',
+    paymentCardPending: '付款信息正在生成，请稍后刷新',
+    awaitingConfirmation: '付款确认后本页面会自动更新。',
+    paid: '已收到付款，谢谢！',
+    paidDesc: '商家会尽快处理您的订单。',
+    notPayable: '该订单已不再等待付款。',
+    refresh: '刷新',
+    // 管理员与用户生成付款链接
+    create: '付款链接',
+    share: '分享付款链接',
+    dialogTitle: '付款链接',
+    dialogDesc: '生成签名链接，买家无需登录即可为此订单付款。持有链接的任何人都可以付款。',
+    sendEmail: '通过邮件发送',
+    sendSms: '通过短信发送',
+    emailPlaceholder: '默认使用订单邮箱',
+    phonePlaceholder: '默认使用收货手机号',
+    generate: '生成链接',
+    generating: '生成中…',
+    link: '链接',
+    expiresAt: '有效期至 {time}',
+    copy: '复制链接',
+    copied: '付款链接已复制',
+    created: '付款链接已生成',
+    createFailed: '生成付款链接失败',
+    emailSent: '付款链接邮件已发送',
+    smsSent: '付款链接短信已发送',
+    emailNotSent: '邮件发送失败，请检查收件人与邮件设置',
+    smsNotSent: '短信发送失败，请检查手机号与短信服务商',
+    bizError: {
+      'payment_link.disabled': '付款链接功能未开启',
+      'payment_link.invalid': '付款链接无效或已过期，请联系商家重新获取',
+      'payment_link.orderNotPayable': '该订单已不再等待付款',
+    },
+  },
+
   shippingPublic: {
     missingFormToken: '缺少表单令牌',
     formLoadFailed: '表单加载失败',
@@ -4228,9 +6112,19 @@ export const zhTranslations = {
     profile: '个人中心',
     accountSettings: '账户设置',
     profilePreferences: '偏好设置',
+    businessAccount: '企业账户',
+    quotes: '我的报价',
+    adminPolicies: '条款与隐私政策',
+    consents: '条款与隐私',
+    security: '两步验证',
+    spendingControls: '消费控制',
+    organization: '组织',
+    apiTokens: 'API 令牌',
     tickets: '客服中心',
     ticketDetail: '工单详情',
     serialVerify: '序列号验证',
+    orderTracking: '订单查询',
+    paymentLink: '订单付款',
     shippingForm: '物流信息',
     adminDashboard: '管理仪表盘',
     adminProducts: '商品管理',
@@ -4246,15 +6140,30 @@ export const zhTranslations = {
     adminUsers: '用户管理',
     adminUserDetail: '用户详情',
     adminTickets: '工单管理',
+    adminTicketPerformance: '客服绩效',
+    adminSettlementReport: '结算报表',
+    adminNewOrder: '手动建单',
+    adminRevenueRecognition: '收入确认',
+    adminAccountingExport: '会计导出',
+    adminVendors: '商家与结算',
+    adminBusinessAccounts: '企业账户',
+    adminCODCollections: '货到付款',
     adminSettings: '系统设置',
     adminLogs: '系统日志',
     adminApiKeys: 'API 密钥管理',
     adminAnalytics: '数据统计',
+    adminSkuSales: 'SKU 销售',
     adminPaymentMethods: '支付方式',
     adminSerials: '序列号管理',
     adminPromoCodes: '优惠码管理',
+    adminGiftCards: '礼品卡',
+    adminQuotes: '报价单',
+    adminQuoteEdit: '编辑报价单',
+    adminReturns: '退货管理',
+    adminDisputes: '争议裁决',
     adminPromoCodeNew: '新建优惠码',
     adminPromoCodeEdit: '编辑优惠码',
+    adminTrash: '回收站',
     knowledge: '知识库',
     knowledgeArticle: '文章详情',
     announcements: '公告',
@@ -4263,6 +6172,11 @@ export const zhTranslations = {
     adminKnowledgeArticleNew: '新建文章',
     adminKnowledgeArticleEdit: '编辑文章',
     adminAnnouncements: '公告管理',
+    adminSiteBanners: '站点横幅',
+    adminModeration: '内容审核',
+    adminBlocklist: '黑名单',
+    adminInboundWebhooks: '入站 Webhook',
+    adminRisk: '风控审核',
     adminMarketing: '营销管理',
     adminPlugins: '插件管理',
     adminPluginObservability: '插件观测',
@@ -4324,6 +6238,7 @@ export const zhTranslations = {
     deleteFailed: '删除失败: {msg}',
     batchDeleteSuccess: '成功删除 {n} 条库存',
     copiedToClipboard: '已复制到剪贴板',
+    revealFailed: '查看内容失败',
     pleaseEnterContent: '请输入库存内容',
     pleaseSelectFile: '请选择文件',
     unsupportedFormat: '只支持 .xlsx, .xls, .csv, .txt 格式',
@@ -4473,6 +6388,13 @@ export const zhTranslations = {
       'inventory.skuAlreadyExists': 'SKU 已存在',
       'inventory.attributesInvalid': '库存规格属性无效',
       'inventory.notFound': '库存记录不存在',
+      'inventory.snapshotDateInvalid': '日期格式应为 YYYY-MM-DD',
+      'inventory.snapshotRangeInvalid': '起始日期不能晚于结束日期',
+      'inventory.snapshotNotFound': '{date} 及之前没有库存快照',
+      'inventory.lowStockSnoozeInvalid': '静默时长须在 1 分钟到 30 天之间',
+      'inventory.lowStockSourceInvalid': '库存来源须为实物或虚拟',
+      'inventory.lowStockAlertNotFound': '库存不存在',
+      'inventory.lowStockAlertsDisabled': '服务端配置未启用低库存告警',
       'inventory.hasSalesOrReservations': '该库存已有销量或预留记录，无法删除',
       'inventory.specUnavailable': '该规格暂不可用',
       'inventory.soldOut': '该规格已售罄',
@@ -4482,6 +6404,8 @@ export const zhTranslations = {
       'inventory.availableExceedsStock': '可售数量不能超过库存总量',
       'inventory.adjustedStockNegative': '调整后的库存不能为负数',
       'inventory.adjustedAvailableNegative': '调整后的可售数量不能为负数',
+      'inventory.reconciliationRunning': '已有库存对账正在执行',
+      'inventory.reconciliationRunNotFound': '库存对账记录不存在',
     },
   },
 
@@ -4496,6 +6420,9 @@ export const zhTranslations = {
       'binding.noMatchingInventory': '未找到匹配的库存配置',
       'binding.noFixedInventory': '该商品尚未配置固定规格库存',
       'binding.specUnavailable': '该规格暂不可用',
+      'binding.rebindSameInventory': '该绑定已指向此库存',
+      'binding.rebindInventoryInactive': '目标库存已停用',
+      'binding.rebindOpenOrders': '仍有 {count} 个未完成订单（共 {quantity} 件）占用当前库存的预留',
     },
   },
 
@@ -4515,11 +6442,22 @@ export const zhTranslations = {
       'virtual_inventory.hasProductBindings': '该虚拟库存仍有关联商品绑定，无法删除',
       'virtual_inventory.importEmptyFile': '导入文件为空',
       'virtual_inventory.importNoValidData': '未找到可导入的有效数据',
+      'virtual_inventory.importTooManyRows': '行数过多，每个文件最多导入 {max} 条',
+      'virtual_inventory.restockStaticOnly': '仅静态库存支持自动补货',
+      'virtual_inventory.restockNotConfigured': '该库存未配置自动补货',
+      'virtual_inventory.restockBusy': '刚刚执行过补货，请一分钟后再试',
+      'virtual_inventory.restockCapReached': '已达到消费上限（{period}），暂停采购',
+      'virtual_inventory.restockPolicyInvalid': '补货设置无效：{field}',
+      'virtual_inventory.restockScriptInvalid': '供应商脚本错误：{error}',
+      'virtual_stock.resaleCodeInvalid': '请输入不超过 1000 个字符的卡密',
       'virtual_inventory.stockDeleteStatusInvalid': '只有可用或已预留状态的库存才能删除',
       'virtual_inventory.stockItemNotFound': '库存项不存在',
+      'virtual_inventory.notFound': '虚拟库存不存在',
       'virtual_inventory.stockItemUnavailable': '库存项当前不可预留',
       'virtual_inventory.stockItemNotReserved': '库存项当前不是已预留状态',
       'virtual_inventory.scriptRequired': '请输入发货脚本内容',
+      'virtual_inventory.dryRunScriptOnly': '仅脚本类型的虚拟库存支持试运行',
+      'virtual_inventory.dryRunQuantityTooLarge': '试运行数量不能超过 {max}',
       'virtual_inventory.manualImportUnsupported': '脚本类型虚拟库存不支持手动导入',
       'virtual_inventory.manualCreateUnsupported': '脚本类型虚拟库存不支持手动新增库存',
       'virtual_inventory.contentRequired': '请输入库存内容',
@@ -4527,6 +6465,15 @@ export const zhTranslations = {
       'virtual_inventory.unsupportedFileType': '不支持的文件类型',
       'virtual_inventory.noBoundInventory': '当前商品未绑定虚拟库存，请先绑定',
       'virtual_inventory.noPendingScriptStock': '当前没有待标记发货的脚本虚拟库存',
+      'virtual_inventory.scriptRevisionNotFound': '脚本修订不存在',
+      'virtual_inventory.scriptRevisionNotPending': '该脚本修订已处理（{status}）',
+      'virtual_inventory.scriptRevisionSelfApproval': '脚本修改必须由另一名管理员批准',
+      'virtual_inventory.scriptRevisionStale': '提交后生效脚本已被修改，请重新提交该修订',
+      'script_execution.notFound': '脚本执行记录不存在',
+      'script_execution.notFailed': '只能重放失败的脚本执行',
+      'script_execution.alreadyReplayed': '该脚本执行已重放过',
+      'virtual_inventory.holdNotFound': '库存暂扣不存在或已释放',
+      'virtual_inventory.holdExpired': '库存暂扣已过期，请重试',
     },
   },
 
@@ -4593,6 +6540,59 @@ export const zhTranslations = {
     relatedOrders: '关联订单',
     relatedOrdersHint: '使用了此优惠码的订单',
     viewRelatedOrders: '查看关联订单',
+    campaigns: '批量活动',
+    campaignsDesc: '按前缀与模式批量生成优惠码，共享折扣、使用次数与有效期设置。',
+    campaignsEmpty: '暂无活动',
+    newCampaign: '新建活动',
+    campaignPrefix: '前缀',
+    campaignPattern: '模式',
+    campaignPatternHint: 'X = 字母或数字，9 = 数字，A = 字母；- 与 _ 原样保留',
+    campaignCount: '生成数量',
+    campaignUsesPerCode: '单码可用次数',
+    campaignPerUserLimit: '每用户限用次数',
+    campaignPerUserLimitHint: '每位用户可使用的次数，0 表示不限制',
+    campaignStartsAt: '生效时间',
+    campaignValidity: '有效期',
+    campaignGenerate: '生成优惠码',
+    campaignCreated: '已生成 {count} 个优惠码',
+    campaignCreateFailed: '生成优惠码失败',
+    campaignStats: '统计',
+    campaignStatsIssued: '已发放',
+    campaignStatsActive: '可用',
+    campaignStatsCodesUsed: '已使用码数',
+    campaignStatsOrders: '订单数',
+    campaignStatsLoadFailed: '加载活动统计失败',
+    pricingRules: '定价规则',
+    pricingRulesDesc:
+      '下单时按优先级自动应用的折扣。每条规则基于更高优先级规则减免后的剩余金额计算，优惠码最后应用。',
+    pricingRulesEmpty: '暂无定价规则',
+    newPricingRule: '新建规则',
+    pricingTypeThreshold: '满减',
+    pricingThresholdValue: '满 {min} 减 {value}',
+    pricingPercentValue: '折扣比例（%）',
+    pricingMinAmount: '金额门槛',
+    pricingMinAmountHint: '按适用商品的原价小计判断',
+    pricingScope: '适用范围',
+    pricingScopeAll: '全部商品',
+    pricingScopeCategory: '分类',
+    pricingTargetsHint: '多个值用逗号分隔',
+    pricingPriority: '优先级',
+    pricingPriorityHint: '优先级越高越先计算',
+    pricingEnabled: '启用',
+    pricingExclusive: '独占',
+    pricingExclusiveHint: '独占规则生效后，不再应用优先级更低的规则和优惠码',
+    pricingRuleSaved: '定价规则已保存',
+    pricingRuleSaveFailed: '保存定价规则失败',
+    pricingRuleDeleted: '定价规则已删除',
+    pricingRuleDeleteFailed: '删除定价规则失败',
+    pricingRuleDeleteConfirm: '已下单的订单保留原有优惠。确定删除该规则？',
+    pricingSimulate: '模拟下单',
+    pricingSimulateItems: '商品（每行一个 SKU:数量）',
+    pricingSimulateRun: '模拟计算',
+    pricingSimulateFailed: '模拟计算失败',
+    pricingBase: '计算基数',
+    pricingSubtotal: '小计',
+    pricingTotal: '合计',
 
     // 用户端
     enterPromoCode: '输入优惠码',
@@ -4620,6 +6620,495 @@ export const zhTranslations = {
       'promo_code.unavailable': '优惠码当前不可用',
       'promo_code.notApplicable': '优惠码不适用于所选商品',
       'promo_code.minOrderAmountNotMet': '订单金额未达到最低要求',
+      'promo_code.perUserLimitReached': '您已达到该优惠码的使用次数上限',
+      'promo_code.campaignNotFound': '优惠码活动不存在',
+      'promo_code.campaignNameRequired': '活动名称不能为空',
+      'promo_code.campaignCountInvalid': '生成数量需在 1 到 {max} 之间',
+      'promo_code.campaignPrefixInvalid': '前缀只能包含字母、数字、- 和 _，最多 {max} 个字符',
+      'promo_code.campaignPatternInvalid': '模式至少包含 {min} 个占位符（X、9 或 A），分隔符只能使用 - 或 _',
+      'promo_code.campaignCodeTooLong': '生成的优惠码长度不能超过 {max} 个字符',
+      'promo_code.campaignPatternTooSmall': '模式可生成的优惠码数量不足，请使用更长的模式',
+      'promo_code.campaignDiscountInvalid': '折扣值无效',
+      'promo_code.campaignLimitInvalid': '金额与使用次数不能为负数',
+      'promo_code.campaignValidityInvalid': '生效时间必须早于过期时间',
+      'promo_code.notCombinable': '该优惠码不能与 {rule} 同时使用',
+      'pricing.ruleNotFound': '定价规则不存在',
+      'pricing.nameRequired': '规则名称不能为空',
+      'pricing.typeInvalid': '规则类型必须是 percentage、fixed 或 threshold',
+      'pricing.valueInvalid': '优惠值无效',
+      'pricing.thresholdRequired': '满减规则需要设置金额门槛',
+      'pricing.amountInvalid': '金额不能为负数',
+      'pricing.scopeInvalid': '适用范围必须是 all、sku 或 category',
+      'pricing.scopeEmpty': '请至少选择一个 SKU 或分类',
+      'pricing.validityInvalid': '生效时间必须早于过期时间',
+      'pricing.itemsRequired': '请至少添加一个商品进行模拟',
+      'pricing.productNotFound': '商品 {sku} 不存在',
+    },
+  },
+
+  giftCard: {
+    // Admin
+    giftCardManagement: '礼品卡',
+    giftCardManagementDesc:
+      '发行礼品卡并查看余额。结账时使用的金额会预留，订单完成后才从余额中扣减。',
+    issue: '发行礼品卡',
+    count: '数量',
+    valueType: '面值类型',
+    valueFixed: '固定面值',
+    valueVariable: '自定义面值',
+    valueTypeHint: '固定面值需为配置的面值之一，自定义面值需在配置的上下限之间。',
+    amount: '面值',
+    currency: '币种',
+    currencyPlaceholder: '默认使用商店币种',
+    expiresAt: '过期时间',
+    expiresAtHint: '留空则按默认有效期计算',
+    note: '备注',
+    code: '卡号',
+    balance: '余额',
+    reserved: '已预留',
+    status: '状态',
+    statusActive: '可用',
+    statusVoid: '已作废',
+    expired: '已过期',
+    noExpiry: '永久有效',
+    searchPlaceholder: '搜索卡号或备注...',
+    issued: '已发行 {count} 张礼品卡',
+    issuedCodes: '本次发行的卡号',
+    copyCodes: '复制卡号',
+    issueFailed: '发行礼品卡失败',
+    void: '作废礼品卡',
+    voidHint: '作废后剩余余额将清零，礼品卡不能再使用。',
+    voidReason: '作废原因',
+    voided: '礼品卡已作废',
+    voidFailed: '作废礼品卡失败',
+    ledger: '余额流水',
+    txType: '类型',
+    txIssue: '发行',
+    txReserve: '预留',
+    txRelease: '释放',
+    txRedeem: '扣减',
+    txRefund: '退款退回',
+    txVoid: '作废',
+    balanceAfter: '变动后余额',
+    order: '订单',
+    time: '时间',
+
+    // User-facing
+    haveGiftCard: '我有礼品卡?',
+    codePlaceholder: '输入礼品卡卡号',
+    applied: '礼品卡已应用，可用余额 {balance}',
+    deduction: '礼品卡 {code}：-{amount}',
+    checkFailed: '查询礼品卡失败',
+    bizError: {
+      'giftCard.disabled': '礼品卡功能未开启',
+      'giftCard.notFound': '礼品卡不存在',
+      'giftCard.countInvalid': '数量需在 1 到 {max} 之间',
+      'giftCard.valueInvalid': '礼品卡面值必须大于 0',
+      'giftCard.denominationInvalid': '礼品卡面值不在配置的面值列表中',
+      'giftCard.valueOutOfRange': '礼品卡面值超出允许范围',
+      'giftCard.valueTypeInvalid': '无效的礼品卡面值类型：{type}',
+      'giftCard.expiresAtInvalid': '过期时间必须晚于当前时间',
+      'giftCard.noteTooLong': '备注不能超过 {max} 个字符',
+      'giftCard.voidReasonInvalid': '请填写作废原因，且不能超过 {max} 个字符',
+      'giftCard.alreadyVoid': '礼品卡已作废',
+      'giftCard.hasPendingOrders':
+        '礼品卡仍被未完成的订单预留，请先完成或取消这些订单',
+      'giftCard.void': '礼品卡已作废',
+      'giftCard.expired': '礼品卡已过期',
+      'giftCard.emptyBalance': '礼品卡余额不足',
+      'giftCard.currencyMismatch': '礼品卡币种（{currency}）与订单币种不一致',
+    },
+  },
+
+  quote: {
+    // Admin
+    quoteManagement: '报价单',
+    quoteManagementDesc:
+      '为客户编制报价。报价单发送后价格即锁定，客户接受后按报价价格生成订单。',
+    create: '新建报价单',
+    edit: '编辑报价单',
+    customer: '客户',
+    title: '主题',
+    titlePlaceholder: '例如：年度授权续费',
+    items: '报价明细',
+    itemsHint: '单价默认为商品当前售价，可修改。',
+    unitPrice: '单价',
+    lineTotal: '金额',
+    quantity: '数量',
+    subtotal: '小计',
+    discount: '折扣',
+    total: '合计',
+    validUntil: '有效期至',
+    validUntilHint: '留空则按默认有效期计算',
+    note: '条款与说明',
+    noteHint: '展示在客户看到的报价单上',
+    adminNote: '内部备注',
+    status: '状态',
+    statusDraft: '草稿',
+    statusSent: '已发送',
+    statusAccepted: '已接受',
+    statusDeclined: '已拒绝',
+    statusExpired: '已过期',
+    statusCancelled: '已撤回',
+    searchPlaceholder: '搜索报价单号或主题...',
+    saveDraft: '保存草稿',
+    saved: '报价单已保存',
+    saveFailed: '保存报价单失败',
+    send: '发送给客户',
+    sendHint: '发送后报价单不能再修改，如需改价请撤回后重新创建。',
+    sendEmail: '同时发送邮件通知客户',
+    sent: '报价单已发送',
+    sendFailed: '发送报价单失败',
+    cancel: '撤回报价单',
+    cancelConfirm: '确定撤回该报价单？撤回后客户将无法接受。',
+    cancelled: '报价单已撤回',
+    cancelFailed: '撤回报价单失败',
+    preview: '预览',
+    downloadPdf: '下载 PDF',
+    documentFailed: '加载报价单失败',
+    order: '订单',
+    selectUser: '请选择客户',
+    itemsRequired: '请至少添加一个商品',
+
+    // User-facing
+    myQuotes: '我的报价',
+    myQuotesDesc: '商家为您准备的报价单，接受后将按报价价格下单。',
+    noQuotes: '暂无报价单',
+    viewDocument: '查看报价单',
+    accept: '接受报价',
+    acceptHint: '将按报价价格为您生成订单，之后可在订单页面填写收货信息并付款。',
+    acceptRemark: '订单备注（可选）',
+    accepted: '已接受报价并生成订单',
+    acceptFailed: '接受报价失败',
+    decline: '拒绝',
+    declineReason: '拒绝原因（可选）',
+    declined: '已拒绝报价',
+    declineFailed: '拒绝报价失败',
+    viewOrder: '查看订单',
+    bizError: {
+      'quote.disabled': '报价单功能未开启',
+      'quote.statusInvalid': '报价单当前状态为 {status}，无法操作',
+      'quote.titleTooLong': '主题不能超过 {max} 个字符',
+      'quote.noteTooLong': '备注不能超过 {max} 个字符',
+      'quote.validUntilInvalid': '有效期必须晚于当前时间且不超过 {max} 天',
+      'quote.currencyMismatch': '报价单币种（{currency}）与商店当前币种不一致',
+      'quote.reasonTooLong': '原因不能超过 {max} 个字符',
+    },
+  },
+
+  returns: {
+    // 用户端
+    title: '退货',
+    desc: '对已发货的商品申请退货，审核通过后寄回商品并填写退货物流单号。退款需另行申请。',
+    requestButton: '申请退货',
+    items: '退货商品',
+    available: '可退 {count} 件',
+    reason: '退货原因',
+    reasonDefective: '商品故障',
+    reasonDamaged: '运输损坏',
+    reasonWrongItem: '发错商品',
+    reasonNotAsDescribed: '与描述不符',
+    reasonNoLongerNeeded: '不再需要',
+    reasonOther: '其他',
+    description: '问题描述',
+    descriptionPlaceholder: '请描述商品存在的问题',
+    photos: '图片',
+    photosHint: '最多 5 张',
+    uploadPhoto: '上传图片',
+    removePhoto: '移除图片',
+    submit: '提交退货申请',
+    submitted: '退货申请已提交',
+    submitFailed: '提交退货申请失败',
+    reviewNote: '审核备注',
+    receiveNote: '收货备注',
+    shipBackHint: '请寄回商品，并在下方填写物流公司和单号。',
+    carrier: '物流公司',
+    trackingNo: '物流单号',
+    saveTracking: '保存',
+    trackingSaved: '退货物流已保存',
+    trackingFailed: '保存退货物流失败',
+    statusRequested: '待审核',
+    statusApproved: '已批准',
+    statusRejected: '已拒绝',
+    statusInTransit: '已寄回',
+    statusReceived: '已收货',
+    // 管理端
+    management: '退货管理',
+    managementDesc: '审核退货申请、跟踪寄回物流并确认收货，收货时可将商品退回订单项绑定的库存。',
+    searchPlaceholder: '退货单号、订单号或物流单号',
+    returnNo: '退货单',
+    customer: '客户',
+    status: '状态',
+    createdAt: '申请时间',
+    approve: '批准',
+    reject: '拒绝',
+    approveNote: '给客户的寄回说明（可选）',
+    rejectNote: '拒绝原因',
+    approved: '已批准退货',
+    rejected: '已拒绝退货',
+    receive: '确认收货',
+    received: '已确认收到退货',
+    restock: '退回库存',
+    restockHint: '将退货数量加回各订单项绑定的库存。',
+    restocked: '已入库',
+    notRestocked: '未入库',
+    updateFailed: '更新退货申请失败',
+    bizError: {
+      'return.disabled': '暂不支持退货',
+      'return.reasonInvalid': '请选择有效的退货原因',
+      'return.descriptionTooLong': '问题描述不能超过 {max} 个字符',
+      'return.itemsRequired': '请至少选择一件退货商品',
+      'return.orderStatusInvalid': '{status} 状态的订单不能退货',
+      'return.windowExpired': '已超过 {days} 天的退货期限',
+      'return.itemInvalid': '订单项 {index} 不存在',
+      'return.quantityInvalid': '退货数量必须大于 0',
+      'return.itemNotReturnable': '{sku} 不支持退货',
+      'return.quantityExceeded': '{sku} 最多还可退 {available} 件',
+      'return.statusInvalid': '该退货申请当前为 {status} 状态，无法变更',
+      'return.noteTooLong': '备注不能超过 {max} 个字符',
+      'return.noteRequired': '请填写原因',
+      'return.trackingRequired': '请填写退货物流单号',
+      'return.trackingTooLong': '物流单号不能超过 {max} 个字符',
+    },
+  },
+  dispute: {
+    // 用户端
+    title: '平台争议',
+    desc: '工单迟迟未解决时可升级为平台争议，双方分别提交证据后由平台作出最终裁决。',
+    availableAt: '{date} 起可升级此工单',
+    unavailable: '已解决或已关闭的工单不能升级',
+    escalate: '升级至平台',
+    order: '订单',
+    orderPlaceholder: '选择争议订单',
+    orderNoPlaceholder: '订单号',
+    statement: '争议陈述',
+    statementPlaceholder: '请描述事情经过以及您期望的处理结果',
+    evidence: '凭证',
+    evidenceHint: '可上传支持您陈述的截图或照片',
+    uploadEvidence: '上传凭证',
+    removeEvidence: '移除凭证',
+    submit: '提交争议',
+    escalated: '争议已提交至平台',
+    escalateFailed: '提交争议失败',
+    statusAwaitingEvidence: '等待商家答辩',
+    statusUnderReview: '平台审核中',
+    statusResolved: '已裁决',
+    evidenceDeadline: '商家需在 {date} 前答辩',
+    buyer: '买家',
+    merchant: '商家',
+    noMerchantResponse: '尚未答辩',
+    decision: '裁决结果',
+    decisionRefundFull: '全额退款',
+    decisionRefundPartial: '部分退款',
+    decisionNoRefund: '不予退款',
+    refundAmount: '退款金额',
+    refundProcessing: '退款处理中',
+    // 管理端
+    management: '争议裁决',
+    managementDesc:
+      '用户升级的工单争议。商家需在期限内提交答辩证据，随后由答辩人以外的审核人记录裁决，退款类裁决会立即执行退款。',
+    disputeNo: '争议',
+    customer: '用户',
+    status: '状态',
+    deadline: '答辩期限',
+    overdue: '已逾期',
+    createdAt: '发起时间',
+    viewTicket: '查看工单',
+    respond: '提交答辩',
+    responseStatement: '商家陈述',
+    responded: '答辩已提交',
+    decide: '裁决',
+    decideDesc: '裁决结果为最终结果并会同步到工单，退款通过订单付款方式原路退回。',
+    decisionNote: '裁决理由',
+    refundItems: '退款商品',
+    refundAmountHint: '留空则按所选商品的下单单价计算',
+    decided: '裁决已记录',
+    refundIssueFailed: '部分退款已批准但原路退款失败，请在订单退款中重试。',
+    updateFailed: '更新争议失败',
+    bizError: {
+      'dispute.disabled': '暂未开放争议升级',
+      'dispute.ticketResolved': '已解决或已关闭的工单不能升级',
+      'dispute.notYetEligible': '工单创建 {days} 天后才可升级为平台争议',
+      'dispute.statementRequired': '请填写争议陈述',
+      'dispute.statementTooLong': '陈述不能超过 {max} 个字符',
+      'dispute.orderRequired': '请选择争议涉及的订单',
+      'dispute.alreadyEscalated': '该工单已升级为平台争议',
+      'dispute.statusInvalid': '争议当前状态为 {status}，无法操作',
+      'dispute.decisionInvalid': '无效的裁决结果',
+      'dispute.decisionNoteRequired': '请填写裁决理由',
+      'dispute.decisionNoteTooLong': '裁决理由不能超过 {max} 个字符',
+      'dispute.evidencePending': '商家在 {deadline} 前仍可提交答辩',
+      'dispute.reviewerConflict': '提交商家答辩的管理员不能裁决该争议',
+    },
+  },
+  spendingControl: {
+    title: '消费控制',
+    desc: '为本账户设置每月消费限额和禁止购买的商品分类',
+    adminDesc: '管理员修改立即生效，并通知账户所有人。',
+    thisMonth: '本月',
+    spent: '本月已消费',
+    monthlyLimit: '每月限额',
+    noLimit: '不限',
+    monthlyLimitHint: '留空表示不限额，达到限额后将无法下单。',
+    blockedCategories: '禁止购买的分类',
+    noCategories: '暂无商品分类',
+    ownerEmail: '账户所有人邮箱',
+    ownerEmailHint: '变更通知将发送到该邮箱，留空则发送到账户邮箱。',
+    none: '无',
+    pendingTitle: '待生效的变更',
+    pendingDesc: '将于 {time} 生效，在此之前仍按当前设置执行。',
+    cancelPending: '取消待生效变更',
+    pendingCancelled: '已取消待生效变更',
+    settings: '设置',
+    cooldownHint: '收紧限制立即生效；提高限额、解除分类或修改所有人邮箱需等待 {hours} 小时。',
+    saved: '消费控制已更新',
+    scheduled: '变更将在冷静期结束后生效',
+    saveFailed: '更新消费控制失败',
+    bizError: {
+      'spendingControl.disabled': '消费控制功能未开启',
+      'spendingControl.limitInvalid': '每月限额不能为负数',
+      'spendingControl.categoryTooLong': '分类名称不能超过 {max} 个字符',
+      'spendingControl.categoriesTooMany': '最多只能禁止 {max} 个分类',
+      'spendingControl.ownerEmailInvalid': '请输入有效的所有人邮箱',
+      'spendingControl.noPendingChange': '没有待生效的变更',
+      'spendingControl.categoryBlocked': '本账户禁止购买 {category} 分类的商品',
+      'spendingControl.limitExceeded': '订单超出每月消费限额（剩余 {remaining} {currency}）',
+    },
+  },
+  consent: {
+    title: '条款与隐私',
+    desc: '已同意的政策以及等待您同意的更新',
+    version: '版本',
+    types: {
+      terms: '服务条款',
+      privacy: '隐私政策',
+    },
+    popupTitle: '我们的政策已更新',
+    popupDesc: '请阅读并同意最新版本，同意前将无法下单。',
+    agree: '我已阅读并同意以上政策',
+    accept: '同意',
+    later: '稍后',
+    accepted: '感谢，已记录您的同意',
+    acceptFailed: '记录同意失败',
+    pendingTitle: '等待您同意',
+    history: '同意记录',
+    noHistory: '暂无同意记录',
+    adminPending: '尚未同意：{policies}',
+    adminUpToDate: '已同意所有当前政策',
+    management: '条款与隐私政策',
+    managementDesc: '发布新版服务条款与隐私政策，用户需同意最新版本后才能下单。',
+    publish: '发布版本',
+    publishHint: '发布后所有用户都需要重新同意该版本才能下单。',
+    policyType: '政策',
+    titleLabel: '标题',
+    summary: '更新说明',
+    content: '内容（Markdown）',
+    current: '当前版本',
+    acceptedCount: '同意人数',
+    publishedAt: '发布时间',
+    published: '政策版本已发布',
+    publishFailed: '发布政策版本失败',
+    bizError: {
+      'consent.required': '请先同意最新的{policies}后再下单',
+      'consent.policiesRequired': '请选择要同意的政策',
+      'consent.versionOutdated': '该政策版本已不是最新版本，请查看最新版本',
+      'consent.policyTypeInvalid': '政策类型必须为 {types} 之一',
+      'consent.versionRequired': '请填写版本号',
+      'consent.versionTooLong': '版本号不能超过 {max} 个字符',
+      'consent.versionExists': '版本 {version} 已发布',
+      'consent.titleRequired': '请填写标题',
+      'consent.titleTooLong': '标题不能超过 {max} 个字符',
+      'consent.contentRequired': '请填写政策内容',
+      'consent.summaryTooLong': '更新说明不能超过 {max} 个字符',
+    },
+  },
+
+  twoFactor: {
+    title: '两步验证',
+    desc: '登录时需额外输入验证器应用生成的动态验证码，保护账号安全',
+    challengeTitle: '两步验证',
+    challengeDesc: '请输入验证器应用中的 6 位验证码，或使用一个备用码',
+    setupRequiredTitle: '设置两步验证',
+    setupRequiredDesc: '您的账号要求开启两步验证，请先绑定验证器应用',
+    recoveryTitle: '找回账号',
+    recoveryDesc: '我们将向账号邮箱发送验证码。找回后两步验证将关闭，需重新绑定',
+    code: '验证码',
+    codePlaceholder: '123456',
+    verify: '验证',
+    recover: '找回账号',
+    backToLogin: '返回登录',
+    lostDevice: '无法使用验证器？',
+    useAuthenticator: '改用验证器验证码或备用码',
+    sendRecoveryCode: '发送邮箱验证码',
+    recoveryCodeSent: '验证码已发送至 {email}',
+    scanQr: '使用 Google Authenticator、1Password 等验证器应用扫描二维码',
+    qrAlt: '验证器二维码',
+    manualEntry: '或手动输入以下密钥',
+    setupStart: '开始设置',
+    enable: '启用',
+    enabled: '已开启',
+    disabled: '未开启',
+    enabledSince: '开启于 {date}',
+    enabledSuccess: '两步验证已开启',
+    disable: '关闭',
+    disableDesc: '请输入验证器中的验证码或一个备用码以关闭两步验证',
+    disabledSuccess: '两步验证已关闭',
+    requiredByAdmin: '管理员要求您的账号必须开启两步验证',
+    backupCodesTitle: '备用码',
+    backupCodesDesc: '丢失验证器时每个备用码可使用一次。请妥善保存，关闭后将不再显示',
+    backupCodesRemaining: '剩余 {count} 个备用码',
+    regenerateBackupCodes: '重新生成备用码',
+    regenerateDesc: '请输入验证器中的验证码，原有备用码将全部失效',
+    copyCodes: '复制备用码',
+    copied: '已复制',
+    continue: '继续',
+    actionFailed: '操作失败',
+    verifyFailed: '验证失败',
+    adminNotEnrolled: '用户尚未绑定验证器',
+    adminRequire: '强制开启两步验证',
+    adminRequireDesc: '用户下次登录时需先绑定验证器，且无法自行关闭',
+    adminUpdated: '两步验证要求已更新',
+    adminReset: '重置两步验证',
+    adminResetConfirm: '确定重置该用户的两步验证吗？其验证器与备用码将全部失效',
+    adminResetSuccess: '两步验证已重置',
+    bizError: {
+      'twoFactor.invalidCode': '验证码错误',
+      'twoFactor.locked': '失败次数过多，请 {minutes} 分钟后再试',
+      'twoFactor.notEnabled': '未开启两步验证',
+      'twoFactor.alreadyEnabled': '两步验证已开启',
+      'twoFactor.setupRequired': '请先开始设置两步验证',
+      'twoFactor.requiredByAdmin': '您的账号必须开启两步验证，无法关闭',
+      'twoFactor.recoveryUnavailable': '无法通过邮箱找回，请使用备用码或联系客服',
+    },
+  },
+
+  passkey: {
+    title: '通行密钥',
+    desc: '使用指纹、面容或设备 PIN 登录，无需输入密码',
+    signIn: '使用通行密钥登录',
+    empty: '尚未注册通行密钥',
+    name: '通行密钥名称',
+    namePlaceholder: '例如 MacBook 或 iPhone',
+    add: '添加通行密钥',
+    registered: '通行密钥已添加',
+    registerFailed: '添加通行密钥失败',
+    delete: '删除通行密钥',
+    deleteConfirm: '确定删除通行密钥「{name}」？删除后将无法再用它登录',
+    deleted: '通行密钥已删除',
+    deleteFailed: '删除通行密钥失败',
+    createdAt: '添加于 {date}',
+    lastUsedAt: '最近使用 {date}',
+    neverUsed: '从未使用',
+    unsupported: '当前浏览器不支持通行密钥',
+    loginFailed: '通行密钥登录失败',
+    bizError: {
+      'passkey.unavailable': '本站暂不支持通行密钥',
+      'passkey.loginDisabled': '通行密钥登录已关闭',
+      'passkey.challengeExpired': '通行密钥请求已过期，请重试',
+      'passkey.verificationFailed': '通行密钥验证失败',
+      'passkey.alreadyRegistered': '该通行密钥已注册',
+      'passkey.limitReached': '最多只能注册 {max} 个通行密钥',
+      'passkey.notFound': '通行密钥不存在',
     },
   },
 
@@ -0,0 +1,38 @@
+import { resolveClientAPIProxyURL } from '@/lib/api-base-url'
+import { resolveApiErrorMessage } from '@/lib/api-error'
+
+export function getPackingSlipPath(orderId: number | string) {
+  return `/api/admin/orders/${orderId}/packing-slip`
+}
+
+export function getDailyPackingSlipsPath(date: string) {
+  return `/api/admin/orders/packing-slips?date=${encodeURIComponent(date)}`
+}
+
+// 先同步打开空白窗口再加载装箱单，避免异步请求后的弹窗被浏览器拦截
+export async function openPackingSlip(path: string, t: any, fallback: string) {
+  const win = window.open('', '_blank')
+  try {
+    const res = await fetch(resolveClientAPIProxyURL(path))
+    if (!res.ok) {
+      let message = fallback
+      try {
+        message = resolveApiErrorMessage(await res.json(), t, fallback)
+      } catch {
+        // 非 JSON 错误响应，使用默认提示
+      }
+      throw new Error(message)
+    }
+    const blob = await res.blob()
+    const url = window.URL.createObjectURL(blob)
+    if (win) {
+      win.location.href = url
+    } else {
+      window.open(url, '_blank')
+    }
+    window.setTimeout(() => window.URL.revokeObjectURL(url), 60_000)
+  } catch (error) {
+    win?.close()
+    throw error
+  }
+}
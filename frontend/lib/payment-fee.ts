@@ -0,0 +1,19 @@
+import { formatCurrency } from '@/lib/utils'
+
+// 付款方式手续费规则的展示文本，如 "+3%"、"-1%"、"+3% +¥0.50"，未配置时返回空字符串
+export function formatPaymentFeeRule(
+  feeRate?: number,
+  feeFixedMinor?: number,
+  currency?: string
+): string {
+  const parts: string[] = []
+  if (feeRate) {
+    parts.push(`${feeRate > 0 ? '+' : '-'}${Math.abs(feeRate) / 100}%`)
+  }
+  if (feeFixedMinor) {
+    parts.push(
+      `${feeFixedMinor > 0 ? '+' : '-'}${formatCurrency(Math.abs(feeFixedMinor), currency)}`
+    )
+  }
+  return parts.join(' ')
+}
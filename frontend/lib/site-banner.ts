@@ -0,0 +1,61 @@
+// 站点横幅版本追踪：后端在每个 API 响应中附带 X-Site-Banner-Version，
+// 版本变化时通知横幅组件重新拉取，维护公告无需刷新页面即可出现或消失。
+
+export const SITE_BANNER_VERSION_HEADER = 'x-site-banner-version'
+export const SITE_BANNER_VERSION_EVENT = 'auralogic:site-banner-version'
+
+const DISMISSED_STORAGE_KEY = 'auralogic.dismissedSiteBanners'
+
+let lastSeenVersion: string | null = null
+
+export function reportSiteBannerVersion(headers: unknown) {
+  if (typeof window === 'undefined' || !headers || typeof headers !== 'object') {
+    return
+  }
+  const getter = (headers as { get?: (name: string) => unknown }).get
+  const raw =
+    typeof getter === 'function'
+      ? getter.call(headers, SITE_BANNER_VERSION_HEADER)
+      : (headers as Record<string, unknown>)[SITE_BANNER_VERSION_HEADER]
+  const version = typeof raw === 'string' ? raw : ''
+  if (lastSeenVersion === null) {
+    // 首个响应只记录基线，横幅组件挂载时会自行拉取
+    lastSeenVersion = version
+    return
+  }
+  if (version === lastSeenVersion) {
+    return
+  }
+  lastSeenVersion = version
+  window.dispatchEvent(new CustomEvent(SITE_BANNER_VERSION_EVENT, { detail: version }))
+}
+
+// 关闭记录以 id + updated_at 为键，横幅内容更新后会重新展示
+export function siteBannerDismissKey(banner: { id: number; updated_at: string }) {
+  return `${banner.id}:${banner.updated_at}`
+}
+
+export function readDismissedSiteBanners(): string[] {
+  if (typeof window === 'undefined') {
+    return []
+  }
+  try {
+    const parsed = JSON.parse(window.localStorage.getItem(DISMISSED_STORAGE_KEY) || '[]')
+    return Array.isArray(parsed) ? parsed.filter((item) => typeof item === 'string') : []
+  } catch {
+    return []
+  }
+}
+
+export function dismissSiteBanner(key: string) {
+  if (typeof window === 'undefined') {
+    return
+  }
+  // 只保留最近的记录，避免长期累积
+  const next = [key, ...readDismissedSiteBanners().filter((item) => item !== key)].slice(0, 50)
+  try {
+    window.localStorage.setItem(DISMISSED_STORAGE_KEY, JSON.stringify(next))
+  } catch {
+    // ignore storage errors (private mode, quota)
+  }
+}
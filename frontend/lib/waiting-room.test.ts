@@ -0,0 +1,27 @@
+import {
+  clearWaitingRoomToken,
+  getWaitingRoomTokenHeader,
+  saveWaitingRoomToken,
+} from '@/lib/waiting-room'
+
+describe('waiting-room', () => {
+  beforeEach(() => {
+    sessionStorage.clear()
+  })
+
+  it('joins active tokens and drops expired ones', () => {
+    const now = Date.now()
+    saveWaitingRoomToken(1, 'tok-a', new Date(now + 60_000).toISOString())
+    saveWaitingRoomToken(2, 'tok-b', new Date(now - 1_000).toISOString())
+    saveWaitingRoomToken(3, 'tok-c', new Date(now + 60_000).toISOString())
+
+    expect(getWaitingRoomTokenHeader(now)).toBe('tok-a,tok-c')
+
+    clearWaitingRoomToken(1)
+    expect(getWaitingRoomTokenHeader(now)).toBe('tok-c')
+  })
+
+  it('returns an empty header when nothing is stored', () => {
+    expect(getWaitingRoomTokenHeader()).toBe('')
+  })
+})
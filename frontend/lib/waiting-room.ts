@@ -0,0 +1,60 @@
+const WAITING_ROOM_TOKENS_KEY = 'auralogic_waiting_room_tokens_v1'
+
+export const WAITING_ROOM_TOKEN_HEADER = 'X-Waiting-Room-Token'
+
+type StoredWaitingRoomTokens = Record<string, { token: string; expiresAt: number }>
+
+function isBrowser(): boolean {
+  return typeof window !== 'undefined'
+}
+
+function readTokens(): StoredWaitingRoomTokens {
+  if (!isBrowser()) return {}
+  try {
+    const parsed = JSON.parse(sessionStorage.getItem(WAITING_ROOM_TOKENS_KEY) || '{}')
+    return parsed && typeof parsed === 'object' ? parsed : {}
+  } catch {
+    return {}
+  }
+}
+
+function writeTokens(tokens: StoredWaitingRoomTokens) {
+  if (!isBrowser()) return
+  if (Object.keys(tokens).length === 0) {
+    sessionStorage.removeItem(WAITING_ROOM_TOKENS_KEY)
+    return
+  }
+  sessionStorage.setItem(WAITING_ROOM_TOKENS_KEY, JSON.stringify(tokens))
+}
+
+// 保存等候室放行后获得的准入令牌，下单时随请求头提交
+export function saveWaitingRoomToken(productId: number, token: string, expiresAt?: string) {
+  if (!token) return
+  const tokens = readTokens()
+  const expiry = expiresAt ? new Date(expiresAt).getTime() : Date.now() + 5 * 60 * 1000
+  tokens[String(productId)] = { token, expiresAt: expiry }
+  writeTokens(tokens)
+}
+
+export function clearWaitingRoomToken(productId: number) {
+  const tokens = readTokens()
+  delete tokens[String(productId)]
+  writeTokens(tokens)
+}
+
+// 返回所有未过期的令牌（逗号分隔），没有时返回空字符串
+export function getWaitingRoomTokenHeader(now: number = Date.now()): string {
+  const tokens = readTokens()
+  const active: StoredWaitingRoomTokens = {}
+  for (const [productId, entry] of Object.entries(tokens)) {
+    if (entry && typeof entry.token === 'string' && entry.expiresAt > now) {
+      active[productId] = entry
+    }
+  }
+  if (Object.keys(active).length !== Object.keys(tokens).length) {
+    writeTokens(active)
+  }
+  return Object.values(active)
+    .map((entry) => entry.token)
+    .join(',')
+}
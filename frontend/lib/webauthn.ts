@@ -0,0 +1,93 @@
+// 通行密钥（WebAuthn）浏览器端封装：后端以 base64url 传输二进制字段，这里负责与 ArrayBuffer 互转
+
+export function isPasskeySupported() {
+  return (
+    typeof window !== 'undefined' &&
+    window.isSecureContext &&
+    typeof window.PublicKeyCredential !== 'undefined' &&
+    !!navigator.credentials
+  )
+}
+
+// 用户取消或超时时浏览器抛出 NotAllowedError，调用方无需提示错误
+export function isPasskeyCancelled(error: unknown) {
+  return error instanceof DOMException && error.name === 'NotAllowedError'
+}
+
+export function base64UrlToBuffer(value: string): ArrayBuffer {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/')
+  const padded = base64 + '='.repeat((4 - (base64.length % 4)) % 4)
+  const binary = atob(padded)
+  const bytes = new Uint8Array(binary.length)
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i)
+  }
+  return bytes.buffer
+}
+
+export function bufferToBase64Url(buffer: ArrayBuffer): string {
+  const bytes = new Uint8Array(buffer)
+  let binary = ''
+  for (let i = 0; i < bytes.length; i++) {
+    binary += String.fromCharCode(bytes[i])
+  }
+  return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '')
+}
+
+interface CredentialDescriptorJSON {
+  type: 'public-key'
+  id: string
+  transports?: string[]
+}
+
+function toDescriptors(items?: CredentialDescriptorJSON[]): PublicKeyCredentialDescriptor[] {
+  return (items || []).map((item) => ({
+    type: item.type,
+    id: base64UrlToBuffer(item.id),
+    transports: item.transports as AuthenticatorTransport[] | undefined,
+  }))
+}
+
+// createPasskey 调用 navigator.credentials.create()，返回提交给注册完成接口的字段
+export async function createPasskey(options: any) {
+  const credential = (await navigator.credentials.create({
+    publicKey: {
+      ...options,
+      challenge: base64UrlToBuffer(options.challenge),
+      user: { ...options.user, id: base64UrlToBuffer(options.user.id) },
+      excludeCredentials: toDescriptors(options.excludeCredentials),
+    },
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    throw new DOMException('Passkey creation was cancelled', 'NotAllowedError')
+  }
+  const response = credential.response as AuthenticatorAttestationResponse
+  return {
+    id: credential.id,
+    client_data_json: bufferToBase64Url(response.clientDataJSON),
+    attestation_object: bufferToBase64Url(response.attestationObject),
+    transports: typeof response.getTransports === 'function' ? response.getTransports() : undefined,
+  }
+}
+
+// getPasskeyAssertion 调用 navigator.credentials.get()，返回提交给登录完成接口的字段
+export async function getPasskeyAssertion(options: any) {
+  const credential = (await navigator.credentials.get({
+    publicKey: {
+      ...options,
+      challenge: base64UrlToBuffer(options.challenge),
+      allowCredentials: toDescriptors(options.allowCredentials),
+    },
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    throw new DOMException('Passkey sign-in was cancelled', 'NotAllowedError')
+  }
+  const response = credential.response as AuthenticatorAssertionResponse
+  return {
+    id: credential.id,
+    client_data_json: bufferToBase64Url(response.clientDataJSON),
+    authenticator_data: bufferToBase64Url(response.authenticatorData),
+    signature: bufferToBase64Url(response.signature),
+    user_handle: response.userHandle ? bufferToBase64Url(response.userHandle) : undefined,
+  }
+}
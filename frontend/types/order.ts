@@ -1,4 +1,5 @@
 import type { ProductType } from './product'
+import type { OrderShare, OrderShipment, PricingTraceEntry } from '@/lib/api'
 
 export interface OrderItem {
   sku: string
@@ -30,6 +31,18 @@ export interface Order {
   status: OrderStatus
   items: OrderItem[]
   total_amount_minor?: number
+  discount_amount_minor?: number
+  rule_discount_amount_minor?: number
+  promo_code?: string
+  pricing_trace?: PricingTraceEntry[]
+  organization_id?: number | null
+  payment_fee_minor?: number
+  payment_fee_retained?: boolean
+  price_rounding_adjustment_minor?: number
+  price_rounding_rule?: string
+  manual_adjustment_minor?: number
+  gift_card_code?: string
+  gift_card_amount_minor?: number
   currency?: string
   receiverName?: string
   receiver_name?: string
@@ -59,6 +72,7 @@ export interface Order {
   serial_generated_at?: string
   shippedAt?: string
   shipped_at?: string
+  shipment?: OrderShipment | null
   formToken?: string
   form_token?: string
   formSubmittedAt?: string
@@ -70,8 +84,10 @@ export interface Order {
   remark?: string
   adminRemark?: string
   admin_remark?: string
+  tags?: string[]
   sharedToSupport?: boolean
   shared_to_support?: boolean
+  active_shares?: OrderShare[]
   createdAt: string
   created_at?: string
   updatedAt: string
@@ -93,7 +93,7 @@ export interface CreateProductRequest {
 export interface UpdateProductRequest extends Partial<CreateProductRequest> { }
 
 // Virtual Product Stock Types
-export type VirtualStockStatus = 'available' | 'sold' | 'reserved' | 'invalid'
+export type VirtualStockStatus = 'available' | 'sold' | 'reserved' | 'held' | 'invalid'
 
 export interface VirtualStockInlineIframe {
   title?: string
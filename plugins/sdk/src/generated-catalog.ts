@@ -113,6 +113,8 @@ export const OFFICIAL_PLUGIN_HOOKS = [
   "order.admin.update_shipping.before",
   "order.auto_cancel.after",
   "order.auto_cancel.before",
+  "order.auto_complete.after",
+  "order.auto_complete.before",
   "order.complete.after",
   "order.complete.before",
   "order.create.after",
@@ -372,6 +374,8 @@ export const OFFICIAL_PLUGIN_HOOK_GROUPS = {
     "order.admin.update_shipping.before",
     "order.auto_cancel.after",
     "order.auto_cancel.before",
+    "order.auto_complete.after",
+    "order.auto_complete.before",
     "order.complete.after",
     "order.complete.before",
     "order.create.after",